@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/api-gateway/internal/graphql"
+	"github.com/joho/godotenv"
+)
+
+type Config struct {
+	ServerPort string
+
+	// Backing services
+	UserServiceHost        string
+	UserServicePort        string
+	FeedServiceHost        string
+	FeedServicePort        string
+	EventsServiceHost      string
+	EventsServicePort      string
+	MarketplaceServiceHost string
+	MarketplaceServicePort string
+
+	// GraphQL
+	QueryLimits graphql.Limits
+	// LoaderWait is how long loaders collect keys before fetching a batch
+	LoaderWait time.Duration
+
+	// Auth & Rate Limiting
+	JWTSecret         string
+	JWKSURL           string    // Key set access tokens are signed by, published by user-service
+	LegacyHS256Until  time.Time // Until then JWTSecret tokens are still accepted alongside
+	ServiceAuthSecret string    // Signs the tokens presented to other services
+	RedisURLs         []string
+	RedisPass         string
+	RateLimitEnabled  bool
+	RateLimitLimit    float64
+	RateLimitBurst    int
+
+	CORSAllowedOrigins []string
+
+	// Observability
+	JaegerOTLPEndpoint string
+}
+
+func Load() *Config {
+	godotenv.Load()
+
+	rateLimitEnabled, _ := strconv.ParseBool(getEnv("RATE_LIMIT_ENABLED", "true"))
+	rateLimitLimit, _ := strconv.ParseFloat(getEnv("RATE_LIMIT_LIMIT", "50"), 64)
+	rateLimitBurst, _ := strconv.Atoi(getEnv("RATE_LIMIT_BURST", "100"))
+	loaderWait, err := time.ParseDuration(getEnv("LOADER_WAIT", "2ms"))
+	if err != nil {
+		loaderWait = 2 * time.Millisecond
+	}
+
+	corsOrigins := strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173"), ",")
+	for i := range corsOrigins {
+		corsOrigins[i] = strings.TrimSpace(corsOrigins[i])
+	}
+
+	legacyHS256Until, _ := time.Parse(time.RFC3339, getEnv("JWT_LEGACY_HS256_UNTIL", ""))
+
+	return &Config{
+		ServerPort: getEnv("SERVER_PORT", "8090"),
+
+		UserServiceHost:        getEnv("USER_SERVICE_HOST", "localhost"),
+		UserServicePort:        getEnv("USER_SERVICE_PORT", "9083"),
+		FeedServiceHost:        getEnv("FEED_SERVICE_HOST", "localhost"),
+		FeedServicePort:        getEnv("FEED_SERVICE_PORT", "9098"),
+		EventsServiceHost:      getEnv("EVENTS_SERVICE_HOST", "localhost"),
+		EventsServicePort:      getEnv("EVENTS_SERVICE_PORT", "9096"),
+		MarketplaceServiceHost: getEnv("MARKETPLACE_SERVICE_HOST", "localhost"),
+		MarketplaceServicePort: getEnv("MARKETPLACE_SERVICE_PORT", "9098"),
+
+		QueryLimits: graphql.Limits{
+			MaxDocumentBytes: getEnvInt("GRAPHQL_MAX_DOCUMENT_BYTES", 16384),
+			MaxDepth:         getEnvInt("GRAPHQL_MAX_DEPTH", 8),
+			MaxFields:        getEnvInt("GRAPHQL_MAX_FIELDS", 300),
+			MaxAliases:       getEnvInt("GRAPHQL_MAX_ALIASES", 20),
+			MaxComplexity:    getEnvInt("GRAPHQL_MAX_COMPLEXITY", 5000),
+		},
+		LoaderWait: loaderWait,
+
+		// Auth & Rate limiting
+		JWTSecret:          getEnv("JWT_SECRET", "very-secret-key"),
+		JWKSURL:            getEnv("JWKS_URL", ""),
+		LegacyHS256Until:   legacyHS256Until,
+		ServiceAuthSecret:  getEnv("SERVICE_AUTH_SECRET", ""),
+		RedisURLs:          strings.Split(getEnv("REDIS_URL", "localhost:6379"), ","),
+		RedisPass:          getEnv("REDIS_PASS", ""),
+		RateLimitEnabled:   rateLimitEnabled,
+		RateLimitLimit:     rateLimitLimit,
+		RateLimitBurst:     rateLimitBurst,
+		CORSAllowedOrigins: corsOrigins,
+
+		JaegerOTLPEndpoint: getEnv("JAEGER_OTLP_ENDPOINT", "localhost:4317"),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	if value := os.Getenv(key); value != "" {
+		if intVal, err := strconv.Atoi(value); err == nil {
+			return intVal
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,140 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/api-gateway/internal/loader"
+	eventspb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/events/v1"
+	feedpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/feed/v1"
+	marketplacepb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/marketplace/v1"
+	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxUserBatch keeps GetUsers requests to a size user-service answers quickly
+	maxUserBatch = 100
+	// lookupConcurrency bounds parallel calls to services without a batch RPC
+	lookupConcurrency = 10
+)
+
+// Clients are the services the gateway reads from
+type Clients struct {
+	Users       userpb.UserServiceClient
+	Feed        feedpb.FeedServiceClient
+	Events      eventspb.EventsServiceClient
+	Marketplace marketplacepb.MarketplaceServiceClient
+}
+
+type commentsKey struct {
+	postID string
+	page   int64
+	limit  int64
+}
+
+// Loaders batch and cache the lookups of one request on behalf of one viewer.
+// Users are fetched with a single GetUsers call per batch; the other services
+// only look entities up one at a time, so their loaders dedupe and bound the
+// calls instead.
+type Loaders struct {
+	viewerID string
+
+	users    *loader.Loader[string, *userpb.User]
+	posts    *loader.Loader[string, *feedpb.PostResponse]
+	comments *loader.Loader[commentsKey, []*feedpb.CommentResponse]
+	events   *loader.Loader[string, *eventspb.Event]
+	listings *loader.Loader[string, *marketplacepb.Product]
+	friends  *loader.Loader[string, map[string]bool]
+}
+
+func NewLoaders(clients Clients, viewerID string, wait time.Duration) *Loaders {
+	return &Loaders{
+		viewerID: viewerID,
+		users:    loader.New(fetchUsers(clients.Users), wait, maxUserBatch),
+		posts: loader.New(loader.FetchEach(lookupConcurrency, func(ctx context.Context, id string) (*feedpb.PostResponse, error) {
+			post, err := clients.Feed.GetPost(ctx, &feedpb.GetPostRequest{PostId: id, ViewerId: viewerID})
+			return orNotFound(post, err, "feed-service")
+		}), wait, 0),
+		comments: loader.New(loader.FetchEach(lookupConcurrency, func(ctx context.Context, key commentsKey) ([]*feedpb.CommentResponse, error) {
+			resp, err := clients.Feed.ListComments(ctx, &feedpb.ListCommentsRequest{PostId: key.postID, Page: key.page, Limit: key.limit})
+			if err != nil {
+				return nil, serviceError("feed-service", err)
+			}
+			return resp.Comments, nil
+		}), wait, 0),
+		events: loader.New(loader.FetchEach(lookupConcurrency, func(ctx context.Context, id string) (*eventspb.Event, error) {
+			resp, err := clients.Events.GetEvent(ctx, &eventspb.GetEventRequest{EventId: id, ViewerId: viewerID})
+			if resp, err = orNotFound(resp, err, "events-service"); resp == nil {
+				return nil, err
+			}
+			return resp.Event, nil
+		}), wait, 0),
+		listings: loader.New(loader.FetchEach(lookupConcurrency, func(ctx context.Context, id string) (*marketplacepb.Product, error) {
+			resp, err := clients.Marketplace.GetProduct(ctx, &marketplacepb.GetProductRequest{ProductId: id, ViewerId: viewerID})
+			if resp, err = orNotFound(resp, err, "marketplace-service"); resp == nil {
+				return nil, err
+			}
+			return resp.Product, nil
+		}), wait, 0),
+		friends: loader.New(loader.FetchEach(1, func(ctx context.Context, userID string) (map[string]bool, error) {
+			resp, err := clients.Users.GetFriendIDs(ctx, &userpb.GetFriendIDsRequest{UserId: userID})
+			if err != nil {
+				return nil, serviceError("user-service", err)
+			}
+			friends := make(map[string]bool, len(resp.FriendIds))
+			for _, id := range resp.FriendIds {
+				friends[id] = true
+			}
+			return friends, nil
+		}), wait, 0),
+	}
+}
+
+func fetchUsers(client userpb.UserServiceClient) loader.BatchFunc[string, *userpb.User] {
+	return func(ctx context.Context, ids []string) ([]*userpb.User, []error) {
+		resp, err := client.GetUsers(ctx, &userpb.GetUsersRequest{UserIds: ids})
+		if err != nil {
+			return nil, loader.Fill(len(ids), serviceError("user-service", err))
+		}
+		byID := make(map[string]*userpb.User, len(resp.Users))
+		for _, u := range resp.Users {
+			byID[u.Id] = u
+		}
+		users := make([]*userpb.User, len(ids))
+		for i, id := range ids {
+			users[i] = byID[id]
+		}
+		return users, nil
+	}
+}
+
+type loadersKey struct{}
+
+func WithLoaders(ctx context.Context, l *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *Loaders {
+	l, _ := ctx.Value(loadersKey{}).(*Loaders)
+	return l
+}
+
+// orNotFound turns a NotFound status into a nil result, which resolves to null
+func orNotFound[T any](resp *T, err error, service string) (*T, error) {
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, serviceError(service, err)
+	}
+	return resp, nil
+}
+
+// serviceError keeps the status message, dropping the "rpc error: code = ..."
+// prefix clients have no use for
+func serviceError(service string, err error) error {
+	return fmt.Errorf("%s: %s", service, status.Convert(err).Message())
+}
@@ -0,0 +1,434 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MuhibNayem/connectify-v2/api-gateway/internal/graphql"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	eventspb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/events/v1"
+	feedpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/feed/v1"
+	marketplacepb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/marketplace/v1"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 50
+	// maxUsersPerQuery bounds the ids accepted by the users field
+	maxUsersPerQuery = 100
+)
+
+var errNoLoaders = errors.New("request has no loaders")
+
+// NewSchema builds the gateway schema on top of the service clients. Each
+// request must carry its Loaders in the context (see WithLoaders).
+func NewSchema(clients Clients, limits graphql.Limits) (*graphql.Schema, error) {
+	user := &graphql.Object{Name: "User", Description: "A public profile"}
+	post := &graphql.Object{Name: "Post"}
+	postConnection := &graphql.Object{Name: "PostConnection", Description: "A page of posts and the cursor of the next one"}
+	comment := &graphql.Object{Name: "Comment"}
+	event := &graphql.Object{Name: "Event"}
+	listing := &graphql.Object{Name: "Listing", Description: "A marketplace listing"}
+
+	user.Fields = []*graphql.FieldDef{
+		{Name: "id", Type: graphql.NonNullOf(graphql.ID)},
+		{Name: "username", Type: graphql.NonNullOf(graphql.String)},
+		{Name: "fullName", Type: graphql.String},
+		{Name: "avatar", Type: graphql.String},
+		{Name: "coverPicture", Type: graphql.String},
+		{Name: "bio", Type: graphql.String},
+		{Name: "location", Type: graphql.String},
+		{Name: "isVerified", Type: graphql.NonNullOf(graphql.Boolean)},
+		{Name: "createdAt", Type: graphql.DateTime},
+	}
+
+	post.Fields = []*graphql.FieldDef{
+		{Name: "id", Type: graphql.NonNullOf(graphql.ID)},
+		{Name: "content", Type: graphql.NonNullOf(graphql.String)},
+		{Name: "privacy", Type: graphql.NonNullOf(graphql.String)},
+		{Name: "location", Type: graphql.String},
+		{Name: "hashtags", Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(graphql.String)))},
+		{Name: "totalReactions", Type: graphql.NonNullOf(graphql.Int)},
+		{Name: "totalComments", Type: graphql.NonNullOf(graphql.Int)},
+		{Name: "totalShares", Type: graphql.NonNullOf(graphql.Int)},
+		{Name: "createdAt", Type: graphql.DateTime},
+		{Name: "updatedAt", Type: graphql.DateTime},
+		{Name: "author", Type: user, Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return loadUser(ctx, p.Source.(*Post).authorID)
+		}},
+		{
+			Name: "comments",
+			Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(comment))),
+			Args: pageArgs(10),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				l := loadersFrom(ctx)
+				if l == nil {
+					return nil, errNoLoaders
+				}
+				page, limit := pageOf(p.Args)
+				resp, err := l.comments.Load(ctx, commentsKey{postID: p.Source.(*Post).ID, page: page, limit: limit})
+				if err != nil {
+					return nil, err
+				}
+				comments := make([]*Comment, len(resp))
+				for i, c := range resp {
+					comments[i] = toComment(c)
+				}
+				return comments, nil
+			},
+		},
+	}
+
+	postConnection.Fields = []*graphql.FieldDef{
+		{Name: "posts", Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(post)))},
+		{Name: "nextCursor", Type: graphql.String, Description: "Pass as cursor for the next page; null on the last page"},
+	}
+
+	comment.Fields = []*graphql.FieldDef{
+		{Name: "id", Type: graphql.NonNullOf(graphql.ID)},
+		{Name: "content", Type: graphql.NonNullOf(graphql.String)},
+		{Name: "mediaType", Type: graphql.String},
+		{Name: "mediaUrl", Type: graphql.String},
+		{Name: "createdAt", Type: graphql.DateTime},
+		{Name: "updatedAt", Type: graphql.DateTime},
+		{Name: "author", Type: user, Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return loadUser(ctx, p.Source.(*Comment).authorID)
+		}},
+		{Name: "post", Type: post, Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return loadPost(ctx, p.Source.(*Comment).postID)
+		}},
+	}
+
+	event.Fields = []*graphql.FieldDef{
+		{Name: "id", Type: graphql.NonNullOf(graphql.ID)},
+		{Name: "title", Type: graphql.NonNullOf(graphql.String)},
+		{Name: "description", Type: graphql.String},
+		{Name: "startDate", Type: graphql.DateTime},
+		{Name: "endDate", Type: graphql.DateTime},
+		{Name: "location", Type: graphql.String},
+		{Name: "isOnline", Type: graphql.NonNullOf(graphql.Boolean)},
+		{Name: "privacy", Type: graphql.NonNullOf(graphql.String)},
+		{Name: "category", Type: graphql.String},
+		{Name: "coverImage", Type: graphql.String},
+		{Name: "goingCount", Type: graphql.NonNullOf(graphql.Int)},
+		{Name: "interestedCount", Type: graphql.NonNullOf(graphql.Int)},
+		{Name: "myStatus", Type: graphql.String, Description: "The viewer's RSVP, if any"},
+		{Name: "createdAt", Type: graphql.DateTime},
+		{Name: "creator", Type: user, Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return loadUser(ctx, p.Source.(*Event).creatorID)
+		}},
+	}
+
+	listing.Fields = []*graphql.FieldDef{
+		{Name: "id", Type: graphql.NonNullOf(graphql.ID)},
+		{Name: "title", Type: graphql.NonNullOf(graphql.String)},
+		{Name: "description", Type: graphql.String},
+		{Name: "price", Type: graphql.NonNullOf(graphql.Float)},
+		{Name: "currency", Type: graphql.NonNullOf(graphql.String)},
+		{Name: "images", Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(graphql.String)))},
+		{Name: "status", Type: graphql.NonNullOf(graphql.String)},
+		{Name: "tags", Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(graphql.String)))},
+		{Name: "category", Type: graphql.String},
+		{Name: "city", Type: graphql.String},
+		{Name: "country", Type: graphql.String},
+		{Name: "views", Type: graphql.NonNullOf(graphql.Int)},
+		{Name: "createdAt", Type: graphql.DateTime},
+		{Name: "seller", Type: user, Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return loadUser(ctx, p.Source.(*Listing).sellerID)
+		}},
+	}
+
+	query := &graphql.Object{Name: "Query", Fields: []*graphql.FieldDef{
+		{Name: "me", Type: user, Description: "The signed-in user", Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			l := loadersFrom(ctx)
+			if l == nil {
+				return nil, errNoLoaders
+			}
+			return loadUser(ctx, l.viewerID)
+		}},
+		{
+			Name: "user",
+			Type: user,
+			Args: []*graphql.ArgDef{{Name: "id", Type: graphql.NonNullOf(graphql.ID)}},
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return loadUser(ctx, p.Args["id"].(string))
+			},
+		},
+		{
+			Name:        "users",
+			Type:        graphql.NonNullOf(graphql.ListOf(user)),
+			Description: "Users in the order of ids, with null for unknown ones",
+			Args:        []*graphql.ArgDef{{Name: "ids", Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(graphql.ID)))}},
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				l := loadersFrom(ctx)
+				if l == nil {
+					return nil, errNoLoaders
+				}
+				args := p.Args["ids"].([]any)
+				if len(args) > maxUsersPerQuery {
+					return nil, fmt.Errorf("at most %d ids may be requested", maxUsersPerQuery)
+				}
+				ids := make([]string, len(args))
+				for i, id := range args {
+					ids[i] = id.(string)
+				}
+				found, errs := l.users.LoadMany(ctx, ids)
+				users := make([]*User, len(ids))
+				for i := range ids {
+					if errs[i] != nil {
+						return nil, errs[i]
+					}
+					users[i] = toUser(found[i])
+				}
+				return users, nil
+			},
+		},
+		{
+			Name: "post",
+			Type: post,
+			Args: []*graphql.ArgDef{{Name: "id", Type: graphql.NonNullOf(graphql.ID)}},
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return loadPost(ctx, p.Args["id"].(string))
+			},
+		},
+		{
+			Name:        "feed",
+			Type:        graphql.NonNullOf(postConnection),
+			Description: "The signed-in user's timeline",
+			Args: []*graphql.ArgDef{
+				{Name: "limit", Type: graphql.Int, Default: defaultPageSize},
+				{Name: "cursor", Type: graphql.String},
+			},
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return resolveFeed(ctx, clients.Feed, p.Args)
+			},
+		},
+		{
+			Name: "event",
+			Type: event,
+			Args: []*graphql.ArgDef{{Name: "id", Type: graphql.NonNullOf(graphql.ID)}},
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				l := loadersFrom(ctx)
+				if l == nil {
+					return nil, errNoLoaders
+				}
+				e, err := l.events.Load(ctx, p.Args["id"].(string))
+				if err != nil {
+					return nil, err
+				}
+				return toEvent(e), nil
+			},
+		},
+		{
+			Name: "events",
+			Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(event))),
+			Args: append([]*graphql.ArgDef{
+				{Name: "query", Type: graphql.String},
+				{Name: "category", Type: graphql.String},
+				{Name: "period", Type: graphql.String, Description: "e.g. today, this_week"},
+			}, pageArgs(defaultPageSize)...),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return resolveEvents(ctx, clients.Events, p.Args)
+			},
+		},
+		{
+			Name: "listing",
+			Type: listing,
+			Args: []*graphql.ArgDef{{Name: "id", Type: graphql.NonNullOf(graphql.ID)}},
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				l := loadersFrom(ctx)
+				if l == nil {
+					return nil, errNoLoaders
+				}
+				product, err := l.listings.Load(ctx, p.Args["id"].(string))
+				if err != nil {
+					return nil, err
+				}
+				return toListing(product), nil
+			},
+		},
+		{
+			Name: "listings",
+			Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(listing))),
+			Args: append([]*graphql.ArgDef{
+				{Name: "query", Type: graphql.String},
+				{Name: "categoryId", Type: graphql.ID},
+				{Name: "minPrice", Type: graphql.Float},
+				{Name: "maxPrice", Type: graphql.Float},
+				{Name: "location", Type: graphql.String},
+			}, pageArgs(defaultPageSize)...),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return resolveListings(ctx, clients.Marketplace, p.Args)
+			},
+		},
+	}}
+
+	return graphql.NewSchema(query, limits)
+}
+
+func pageArgs(defaultLimit int) []*graphql.ArgDef {
+	return []*graphql.ArgDef{
+		{Name: "page", Type: graphql.Int, Default: 1},
+		{Name: "limit", Type: graphql.Int, Default: defaultLimit},
+	}
+}
+
+// pageOf reads page and limit, clamping limit to maxPageSize
+func pageOf(args map[string]any) (int64, int64) {
+	page, _ := args["page"].(int)
+	limit, _ := args["limit"].(int)
+	return int64(max(page, 1)), int64(min(max(limit, 1), maxPageSize))
+}
+
+func loadUser(ctx context.Context, id string) (*User, error) {
+	if id == "" {
+		return nil, nil
+	}
+	l := loadersFrom(ctx)
+	if l == nil {
+		return nil, errNoLoaders
+	}
+	u, err := l.users.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(u), nil
+}
+
+// loadPost returns the post if the viewer may see it. feed-service's GetPost
+// does not check the audience, so the gateway does, the same way feed-service
+// filters timelines.
+func loadPost(ctx context.Context, id string) (*Post, error) {
+	l := loadersFrom(ctx)
+	if l == nil {
+		return nil, errNoLoaders
+	}
+	p, err := l.posts.Load(ctx, id)
+	if err != nil || p == nil {
+		return nil, err
+	}
+	visible, err := l.canSee(ctx, p)
+	if err != nil || !visible {
+		return nil, err
+	}
+	return toPost(p), nil
+}
+
+func (l *Loaders) canSee(ctx context.Context, p *feedpb.PostResponse) (bool, error) {
+	if p.UserId == l.viewerID {
+		return true, nil
+	}
+	if p.Status != "" && p.Status != string(models.PostStatusActive) {
+		return false, nil
+	}
+
+	viewerID, err := primitive.ObjectIDFromHex(l.viewerID)
+	if err != nil {
+		return false, nil
+	}
+	post := models.Post{Privacy: models.PrivacySettingType(p.Privacy)}
+	if post.UserID, err = primitive.ObjectIDFromHex(p.UserId); err != nil {
+		return false, nil
+	}
+	for _, id := range p.CustomAudience {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			post.CustomAudience = append(post.CustomAudience, oid)
+		}
+	}
+
+	isFriend := false
+	if post.NeedsFriendship() {
+		friends, err := l.friends.Load(ctx, p.UserId)
+		if err != nil {
+			return false, err
+		}
+		isFriend = friends[l.viewerID]
+	}
+	return post.VisibleTo(viewerID, isFriend), nil
+}
+
+func resolveFeed(ctx context.Context, client feedpb.FeedServiceClient, args map[string]any) (*PostConnection, error) {
+	l := loadersFrom(ctx)
+	if l == nil {
+		return nil, errNoLoaders
+	}
+	_, limit := pageOf(args)
+	cursor, _ := args["cursor"].(string)
+
+	resp, err := client.ListPosts(ctx, &feedpb.ListPostsRequest{ViewerId: l.viewerID, Page: 1, Limit: limit, Cursor: cursor})
+	if err != nil {
+		return nil, serviceError("feed-service", err)
+	}
+	conn := &PostConnection{Posts: make([]*Post, 0, len(resp.Posts))}
+	for _, p := range resp.Posts {
+		l.posts.Prime(p.Id, p)
+		conn.Posts = append(conn.Posts, toPost(p))
+	}
+	if resp.NextCursor != "" {
+		conn.NextCursor = &resp.NextCursor
+	}
+	return conn, nil
+}
+
+func resolveEvents(ctx context.Context, client eventspb.EventsServiceClient, args map[string]any) ([]*Event, error) {
+	l := loadersFrom(ctx)
+	if l == nil {
+		return nil, errNoLoaders
+	}
+	page, limit := pageOf(args)
+	query, _ := args["query"].(string)
+	category, _ := args["category"].(string)
+	period, _ := args["period"].(string)
+
+	resp, err := client.ListEvents(ctx, &eventspb.ListEventsRequest{
+		UserId:   l.viewerID,
+		Query:    query,
+		Category: category,
+		Period:   period,
+		Page:     page,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, serviceError("events-service", err)
+	}
+	events := make([]*Event, 0, len(resp.Events))
+	for _, e := range resp.Events {
+		l.events.Prime(e.Id, e)
+		events = append(events, toEvent(e))
+	}
+	return events, nil
+}
+
+func resolveListings(ctx context.Context, client marketplacepb.MarketplaceServiceClient, args map[string]any) ([]*Listing, error) {
+	l := loadersFrom(ctx)
+	if l == nil {
+		return nil, errNoLoaders
+	}
+	page, limit := pageOf(args)
+	query, _ := args["query"].(string)
+	categoryID, _ := args["categoryId"].(string)
+	location, _ := args["location"].(string)
+	minPrice, _ := args["minPrice"].(float64)
+	maxPrice, _ := args["maxPrice"].(float64)
+
+	resp, err := client.SearchProducts(ctx, &marketplacepb.SearchProductsRequest{
+		Query:      query,
+		CategoryId: categoryID,
+		Location:   location,
+		MinPrice:   minPrice,
+		MaxPrice:   maxPrice,
+		Page:       page,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, serviceError("marketplace-service", err)
+	}
+	listings := make([]*Listing, 0, len(resp.Products))
+	for _, p := range resp.Products {
+		l.listings.Prime(p.Id, p)
+		listings = append(listings, toListing(p))
+	}
+	return listings, nil
+}
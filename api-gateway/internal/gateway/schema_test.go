@@ -0,0 +1,99 @@
+package gateway
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/api-gateway/internal/graphql"
+	feedpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/feed/v1"
+	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeUsers struct {
+	userpb.UserServiceClient
+
+	mu    sync.Mutex
+	calls [][]string
+	users map[string]*userpb.User
+}
+
+func (f *fakeUsers) GetUsers(ctx context.Context, in *userpb.GetUsersRequest, opts ...grpc.CallOption) (*userpb.GetUsersResponse, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, in.UserIds)
+	f.mu.Unlock()
+	resp := &userpb.GetUsersResponse{}
+	for _, id := range in.UserIds {
+		if u, ok := f.users[id]; ok {
+			resp.Users = append(resp.Users, u)
+		}
+	}
+	return resp, nil
+}
+
+type fakeFeed struct {
+	feedpb.FeedServiceClient
+
+	posts []*feedpb.PostResponse
+}
+
+func (f *fakeFeed) ListPosts(ctx context.Context, in *feedpb.ListPostsRequest, opts ...grpc.CallOption) (*feedpb.FeedResponse, error) {
+	return &feedpb.FeedResponse{Posts: f.posts, NextCursor: "next"}, nil
+}
+
+func (f *fakeFeed) GetPost(ctx context.Context, in *feedpb.GetPostRequest, opts ...grpc.CallOption) (*feedpb.PostResponse, error) {
+	return nil, status.Error(codes.NotFound, "post not found")
+}
+
+func newTestGateway(t *testing.T) (*graphql.Schema, Clients, *fakeUsers) {
+	t.Helper()
+	users := &fakeUsers{users: map[string]*userpb.User{
+		"u1": {Id: "u1", Username: "alice"},
+		"u2": {Id: "u2", Username: "bob"},
+	}}
+	feed := &fakeFeed{posts: []*feedpb.PostResponse{
+		{Id: "p1", UserId: "u1", Content: "one", Privacy: "PUBLIC"},
+		{Id: "p2", UserId: "u2", Content: "two", Privacy: "PUBLIC"},
+		{Id: "p3", UserId: "u1", Content: "three", Privacy: "PUBLIC"},
+	}}
+	clients := Clients{Users: users, Feed: feed}
+	schema, err := NewSchema(clients, graphql.Limits{MaxDepth: 8})
+	require.NoError(t, err)
+	return schema, clients, users
+}
+
+func TestFeed_BatchesAuthorLookups(t *testing.T) {
+	schema, clients, users := newTestGateway(t)
+	ctx := WithLoaders(context.Background(), NewLoaders(clients, "u1", 5*time.Millisecond))
+
+	resp := schema.Execute(ctx, graphql.Request{Query: `{
+		me { username }
+		feed(limit: 3) { nextCursor posts { id author { username } } }
+	}`})
+	require.Empty(t, resp.Errors)
+
+	feed := resp.Data.(*graphql.OrderedMap).Get("feed").(*graphql.OrderedMap)
+	assert.Equal(t, "next", feed.Get("nextCursor"))
+	posts := feed.Get("posts").([]any)
+	require.Len(t, posts, 3)
+	assert.Equal(t, "bob", posts[1].(*graphql.OrderedMap).Get("author").(*graphql.OrderedMap).Get("username"))
+
+	// me and all three authors resolve with a single GetUsers call
+	require.Len(t, users.calls, 1)
+	assert.ElementsMatch(t, []string{"u1", "u2"}, users.calls[0])
+}
+
+func TestPost_NotFoundResolvesToNull(t *testing.T) {
+	schema, clients, _ := newTestGateway(t)
+	ctx := WithLoaders(context.Background(), NewLoaders(clients, "u1", time.Millisecond))
+
+	resp := schema.Execute(ctx, graphql.Request{Query: `{ post(id: "missing") { id } }`})
+	require.Empty(t, resp.Errors)
+	assert.Nil(t, resp.Data.(*graphql.OrderedMap).Get("post"))
+}
@@ -0,0 +1,214 @@
+package gateway
+
+import (
+	"time"
+
+	eventspb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/events/v1"
+	feedpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/feed/v1"
+	marketplacepb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/marketplace/v1"
+	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The types below are what resolvers hand to the executor. Tagged fields are
+// exposed as is; untagged ones hold the IDs that relation fields load from.
+
+type User struct {
+	ID           string    `graphql:"id"`
+	Username     string    `graphql:"username"`
+	FullName     string    `graphql:"fullName"`
+	Avatar       string    `graphql:"avatar"`
+	CoverPicture string    `graphql:"coverPicture"`
+	Bio          string    `graphql:"bio"`
+	Location     string    `graphql:"location"`
+	IsVerified   bool      `graphql:"isVerified"`
+	CreatedAt    time.Time `graphql:"createdAt"`
+}
+
+type Post struct {
+	ID             string    `graphql:"id"`
+	Content        string    `graphql:"content"`
+	Privacy        string    `graphql:"privacy"`
+	Location       string    `graphql:"location"`
+	Hashtags       []string  `graphql:"hashtags"`
+	TotalReactions int64     `graphql:"totalReactions"`
+	TotalComments  int64     `graphql:"totalComments"`
+	TotalShares    int64     `graphql:"totalShares"`
+	CreatedAt      time.Time `graphql:"createdAt"`
+	UpdatedAt      time.Time `graphql:"updatedAt"`
+
+	authorID string
+}
+
+type PostConnection struct {
+	Posts      []*Post `graphql:"posts"`
+	NextCursor *string `graphql:"nextCursor"`
+}
+
+type Comment struct {
+	ID        string    `graphql:"id"`
+	Content   string    `graphql:"content"`
+	MediaType string    `graphql:"mediaType"`
+	MediaURL  string    `graphql:"mediaUrl"`
+	CreatedAt time.Time `graphql:"createdAt"`
+	UpdatedAt time.Time `graphql:"updatedAt"`
+
+	postID   string
+	authorID string
+}
+
+type Event struct {
+	ID              string    `graphql:"id"`
+	Title           string    `graphql:"title"`
+	Description     string    `graphql:"description"`
+	StartDate       time.Time `graphql:"startDate"`
+	EndDate         time.Time `graphql:"endDate"`
+	Location        string    `graphql:"location"`
+	IsOnline        bool      `graphql:"isOnline"`
+	Privacy         string    `graphql:"privacy"`
+	Category        string    `graphql:"category"`
+	CoverImage      string    `graphql:"coverImage"`
+	GoingCount      int64     `graphql:"goingCount"`
+	InterestedCount int64     `graphql:"interestedCount"`
+	MyStatus        string    `graphql:"myStatus"`
+	CreatedAt       time.Time `graphql:"createdAt"`
+
+	creatorID string
+}
+
+type Listing struct {
+	ID          string    `graphql:"id"`
+	Title       string    `graphql:"title"`
+	Description string    `graphql:"description"`
+	Price       float64   `graphql:"price"`
+	Currency    string    `graphql:"currency"`
+	Images      []string  `graphql:"images"`
+	Status      string    `graphql:"status"`
+	Tags        []string  `graphql:"tags"`
+	Category    string    `graphql:"category"`
+	City        string    `graphql:"city"`
+	Country     string    `graphql:"country"`
+	Views       int64     `graphql:"views"`
+	CreatedAt   time.Time `graphql:"createdAt"`
+
+	sellerID string
+}
+
+func toUser(u *userpb.User) *User {
+	if u == nil {
+		return nil
+	}
+	return &User{
+		ID:           u.Id,
+		Username:     u.Username,
+		FullName:     u.FullName,
+		Avatar:       u.Avatar,
+		CoverPicture: u.CoverPicture,
+		Bio:          u.Bio,
+		Location:     u.Location,
+		IsVerified:   u.IsVerified,
+		CreatedAt:    toTime(u.CreatedAt),
+	}
+}
+
+func toPost(p *feedpb.PostResponse) *Post {
+	if p == nil {
+		return nil
+	}
+	return &Post{
+		ID:             p.Id,
+		Content:        p.Content,
+		Privacy:        p.Privacy,
+		Location:       p.Location,
+		Hashtags:       orEmpty(p.Hashtags),
+		TotalReactions: p.TotalReactions,
+		TotalComments:  p.TotalComments,
+		TotalShares:    p.TotalShares,
+		CreatedAt:      toTime(p.CreatedAt),
+		UpdatedAt:      toTime(p.UpdatedAt),
+		authorID:       p.UserId,
+	}
+}
+
+func toComment(c *feedpb.CommentResponse) *Comment {
+	return &Comment{
+		ID:        c.Id,
+		Content:   c.Content,
+		MediaType: c.MediaType,
+		MediaURL:  c.MediaUrl,
+		CreatedAt: toTime(c.CreatedAt),
+		UpdatedAt: toTime(c.UpdatedAt),
+		postID:    c.PostId,
+		authorID:  c.UserId,
+	}
+}
+
+func toEvent(e *eventspb.Event) *Event {
+	if e == nil {
+		return nil
+	}
+	event := &Event{
+		ID:          e.Id,
+		Title:       e.Title,
+		Description: e.Description,
+		StartDate:   toTime(e.StartDate),
+		EndDate:     toTime(e.EndDate),
+		Location:    e.Location,
+		IsOnline:    e.IsOnline,
+		Privacy:     e.Privacy,
+		Category:    e.Category,
+		CoverImage:  e.CoverImage,
+		MyStatus:    e.MyStatus,
+		CreatedAt:   toTime(e.CreatedAt),
+		creatorID:   e.CreatorId,
+	}
+	if e.Stats != nil {
+		event.GoingCount = e.Stats.GoingCount
+		event.InterestedCount = e.Stats.InterestedCount
+	}
+	return event
+}
+
+func toListing(p *marketplacepb.Product) *Listing {
+	if p == nil {
+		return nil
+	}
+	listing := &Listing{
+		ID:          p.Id,
+		Title:       p.Title,
+		Description: p.Description,
+		Price:       p.Price,
+		Currency:    p.Currency,
+		Images:      orEmpty(p.Images),
+		Status:      p.Status,
+		Tags:        orEmpty(p.Tags),
+		Views:       p.Views,
+		CreatedAt:   toTime(p.CreatedAt),
+	}
+	if p.Category != nil {
+		listing.Category = p.Category.Name
+	}
+	if p.Location != nil {
+		listing.City = p.Location.City
+		listing.Country = p.Location.Country
+	}
+	if p.Seller != nil {
+		listing.sellerID = p.Seller.Id
+	}
+	return listing
+}
+
+func toTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// orEmpty keeps empty repeated fields from resolving to null in non-null lists
+func orEmpty(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,105 @@
+package graphql
+
+// Document is a parsed request: its operations and the fragments they spread
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+type Operation struct {
+	Type         string // query, mutation or subscription
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []Selection
+}
+
+type VariableDefinition struct {
+	Name    string
+	Type    TypeRef
+	Default *Value
+}
+
+// TypeRef is a type as written in a variable definition, e.g. [ID!]!
+type TypeRef struct {
+	Name    string
+	Elem    *TypeRef // set for list types
+	NonNull bool
+}
+
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	SelectionSet  []Selection
+}
+
+// Selection is a *Field, *FragmentSpread or *InlineFragment
+type Selection interface {
+	isSelection()
+}
+
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+	Line         int
+	Column       int
+}
+
+// ResponseKey is the name the field's result is returned under
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+}
+
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+func (*Field) isSelection()          {}
+func (*FragmentSpread) isSelection() {}
+func (*InlineFragment) isSelection() {}
+
+type Argument struct {
+	Name  string
+	Value Value
+}
+
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+}
+
+// ValueKind tells which field of Value is set
+type ValueKind int
+
+const (
+	NullValue ValueKind = iota
+	IntValue
+	FloatValue
+	StringValue
+	BooleanValue
+	EnumValue
+	ListValue
+	ObjectValue
+	VariableValue
+)
+
+// Value is a literal or variable reference in the query text. Scalars and
+// enums keep their source text in Raw; variables keep their name in it.
+type Value struct {
+	Kind   ValueKind
+	Raw    string
+	List   []Value
+	Fields map[string]Value
+}
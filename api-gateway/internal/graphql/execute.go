@@ -0,0 +1,398 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type Response struct {
+	Data   any      `json:"data"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type Error struct {
+	Message   string     `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+	Path      []any      `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Execute runs a query. Requests that fail to parse or validate come back
+// with errors and no data; failures in individual fields null those fields
+// and are reported alongside the rest of the data.
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	if limit := s.Limits.MaxDocumentBytes; limit > 0 && len(req.Query) > limit {
+		return &Response{Errors: []*Error{{Message: fmt.Sprintf("query is larger than %d bytes", limit)}}}
+	}
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+	op, variables, err := s.prepare(doc, req.OperationName, req.Variables)
+	if err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+
+	e := &executor{schema: s, doc: doc, variables: variables}
+	data, ok := e.selectionSet(ctx, s.Query, nil, op.SelectionSet, nil)
+	resp := &Response{Errors: e.errors}
+	if ok {
+		resp.Data = data
+	}
+	return resp
+}
+
+func toError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return &Error{Message: err.Error()}
+}
+
+type executor struct {
+	schema    *Schema
+	doc       *Document
+	variables map[string]any
+
+	mu     sync.Mutex
+	errors []*Error
+}
+
+func (e *executor) addError(field *Field, path []any, message string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.errors = append(e.errors, &Error{
+		Message:   message,
+		Locations: []Location{{Line: field.Line, Column: field.Column}},
+		Path:      path,
+	})
+}
+
+// selectionSet resolves the selected fields of an object concurrently, so
+// loads issued by sibling fields and list items land in the same batch. It
+// returns false when a non-null field came back null, nulling the object.
+func (e *executor) selectionSet(ctx context.Context, obj *Object, source any, set []Selection, path []any) (*OrderedMap, bool) {
+	groups := e.collectFields(obj, set, make(map[string]bool), nil)
+	values := make([]any, len(groups))
+	oks := make([]bool, len(groups))
+
+	var wg sync.WaitGroup
+	for i, group := range groups {
+		if group.fields[0].Name == "__typename" {
+			values[i], oks[i] = obj.Name, true
+			continue
+		}
+		wg.Add(1)
+		go func(i int, group fieldGroup) {
+			defer wg.Done()
+			values[i], oks[i] = e.field(ctx, obj, source, group, appendPath(path, group.key))
+		}(i, group)
+	}
+	wg.Wait()
+
+	result := &OrderedMap{}
+	for i, group := range groups {
+		if !oks[i] {
+			return nil, false
+		}
+		result.Set(group.key, values[i])
+	}
+	return result, true
+}
+
+type fieldGroup struct {
+	key    string
+	fields []*Field
+}
+
+// collectFields flattens fragments and applies @skip and @include, merging
+// fields that share a response key
+func (e *executor) collectFields(obj *Object, set []Selection, visited map[string]bool, groups []fieldGroup) []fieldGroup {
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *Field:
+			if !e.included(sel.Directives) {
+				continue
+			}
+			key := sel.ResponseKey()
+			merged := false
+			for i := range groups {
+				if groups[i].key == key {
+					groups[i].fields = append(groups[i].fields, sel)
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				groups = append(groups, fieldGroup{key: key, fields: []*Field{sel}})
+			}
+		case *FragmentSpread:
+			if !e.included(sel.Directives) || visited[sel.Name] {
+				continue
+			}
+			visited[sel.Name] = true
+			groups = e.collectFields(obj, e.doc.Fragments[sel.Name].SelectionSet, visited, groups)
+		case *InlineFragment:
+			if !e.included(sel.Directives) {
+				continue
+			}
+			groups = e.collectFields(obj, sel.SelectionSet, visited, groups)
+		}
+	}
+	return groups
+}
+
+func (e *executor) included(directives []*Directive) bool {
+	for _, d := range directives {
+		value, _ := coerceLiteral(NonNullOf(Boolean), d.Arguments[0].Value, e.variables)
+		condition, _ := value.(bool)
+		if (d.Name == "skip" && condition) || (d.Name == "include" && !condition) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *executor) field(ctx context.Context, obj *Object, source any, group fieldGroup, path []any) (any, bool) {
+	field := group.fields[0]
+	def := obj.Field(field.Name)
+
+	args, err := e.arguments(def, field)
+	if err != nil {
+		return e.fail(field, def.Type, path, err)
+	}
+
+	result, err := resolve(ctx, def, ResolveParams{Source: source, Args: args})
+	if err != nil {
+		return e.fail(field, def.Type, path, err)
+	}
+	return e.complete(ctx, def.Type, group, result, path)
+}
+
+// fail reports a field error once and nulls the field, or its parent when
+// the field is non-null
+func (e *executor) fail(field *Field, typ Type, path []any, err error) (any, bool) {
+	e.addError(field, path, err.Error())
+	_, nonNull := typ.(*NonNull)
+	return nil, !nonNull
+}
+
+func resolve(ctx context.Context, def *FieldDef, p ResolveParams) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("internal error resolving %q", def.Name)
+		}
+	}()
+	if def.Resolve != nil {
+		return def.Resolve(ctx, p)
+	}
+	return defaultResolve(p.Source, def.Name), nil
+}
+
+func (e *executor) arguments(def *FieldDef, field *Field) (map[string]any, error) {
+	return coerceArguments(def, field, e.variables)
+}
+
+// coerceArguments returns the values a resolver sees, with defaults filled in
+func coerceArguments(def *FieldDef, field *Field, variables map[string]any) (map[string]any, error) {
+	args := make(map[string]any, len(def.Args))
+	for _, argDef := range def.Args {
+		var value any
+		for _, arg := range field.Arguments {
+			if arg.Name != argDef.Name {
+				continue
+			}
+			coerced, err := coerceLiteral(argDef.Type, arg.Value, variables)
+			if err != nil && argDef.Default == nil {
+				return nil, fmt.Errorf("argument %q: %w", arg.Name, err)
+			}
+			value = coerced
+		}
+		if value == nil {
+			value = argDef.Default
+		}
+		if value != nil {
+			args[argDef.Name] = value
+		}
+	}
+	return args, nil
+}
+
+// complete turns a resolved value into its response form. Nulls in non-null
+// positions return false, which the nearest nullable position turns into null.
+func (e *executor) complete(ctx context.Context, typ Type, group fieldGroup, result any, path []any) (any, bool) {
+	if nonNull, ok := typ.(*NonNull); ok {
+		value, ok := e.completeNullable(ctx, nonNull.Of, group, result, path)
+		if !ok {
+			return nil, false
+		}
+		if value == nil {
+			e.addError(group.fields[0], path, fmt.Sprintf("cannot return null for non-nullable field of type %s", typ))
+			return nil, false
+		}
+		return value, true
+	}
+	value, ok := e.completeNullable(ctx, typ, group, result, path)
+	if !ok {
+		return nil, true
+	}
+	return value, true
+}
+
+func (e *executor) completeNullable(ctx context.Context, typ Type, group fieldGroup, result any, path []any) (any, bool) {
+	if isNil(result) {
+		return nil, true
+	}
+
+	switch typ := typ.(type) {
+	case *Scalar:
+		value, err := typ.Serialize(result)
+		if err != nil {
+			e.addError(group.fields[0], path, err.Error())
+			return nil, true
+		}
+		return value, true
+
+	case *Object:
+		var set []Selection
+		for _, f := range group.fields {
+			set = append(set, f.SelectionSet...)
+		}
+		return e.selectionSet(ctx, typ, result, set, path)
+
+	case *List:
+		items := reflect.ValueOf(result)
+		if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+			e.addError(group.fields[0], path, fmt.Sprintf("expected a list for type %s", typ))
+			return nil, true
+		}
+		values := make([]any, items.Len())
+		oks := make([]bool, items.Len())
+		var wg sync.WaitGroup
+		for i := 0; i < items.Len(); i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				values[i], oks[i] = e.complete(ctx, typ.Of, group, items.Index(i).Interface(), appendPath(path, i))
+			}(i)
+		}
+		wg.Wait()
+		for _, ok := range oks {
+			if !ok {
+				return nil, false
+			}
+		}
+		return values, true
+	}
+	return nil, true
+}
+
+// defaultResolve reads a field from a map or from a struct field tagged
+// `graphql:"<name>"`
+func defaultResolve(source any, name string) any {
+	if m, ok := source.(map[string]any); ok {
+		return m[name]
+	}
+	v := reflect.ValueOf(source)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("graphql") != name {
+			continue
+		}
+		field := v.Field(i)
+		// Optional scalars are pointers; objects are resolved from pointers as is
+		if field.Kind() == reflect.Pointer && !field.IsNil() && field.Elem().Kind() != reflect.Struct {
+			return field.Elem().Interface()
+		}
+		return field.Interface()
+	}
+	return nil
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func appendPath(path []any, key any) []any {
+	next := make([]any, len(path)+1)
+	copy(next, path)
+	next[len(path)] = key
+	return next
+}
+
+// OrderedMap is a JSON object that keeps its keys in selection order, as the
+// spec requires of responses
+type OrderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func (m *OrderedMap) Set(key string, value any) {
+	if m.values == nil {
+		m.values = make(map[string]any)
+	}
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *OrderedMap) Get(key string) any {
+	return m.values[key]
+}
+
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,220 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBook struct {
+	ID     string  `graphql:"id"`
+	Title  string  `graphql:"title"`
+	Rating *string `graphql:"rating"`
+}
+
+func newTestSchema(t testing.TB) *Schema {
+	t.Helper()
+	book := &Object{Name: "Book"}
+	book.Fields = []*FieldDef{
+		{Name: "id", Type: NonNullOf(ID)},
+		{Name: "title", Type: NonNullOf(String)},
+		{Name: "rating", Type: String},
+		{Name: "broken", Type: NonNullOf(String), Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+			return nil, errors.New("boom")
+		}},
+		{Name: "related", Type: ListOf(book), Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+			return []*testBook{{ID: "r1", Title: "Related"}}, nil
+		}},
+	}
+	query := &Object{Name: "Query", Fields: []*FieldDef{
+		{
+			Name: "book",
+			Type: book,
+			Args: []*ArgDef{{Name: "id", Type: NonNullOf(ID)}},
+			Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+				rating := "5"
+				return &testBook{ID: p.Args["id"].(string), Title: "Dune", Rating: &rating}, nil
+			},
+		},
+		{
+			Name: "books",
+			Type: NonNullOf(ListOf(NonNullOf(book))),
+			Args: []*ArgDef{{Name: "limit", Type: Int, Default: 2}},
+			Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+				var books []*testBook
+				for i := 0; i < p.Args["limit"].(int); i++ {
+					books = append(books, &testBook{ID: string(rune('a' + i)), Title: "Book"})
+				}
+				return books, nil
+			},
+		},
+	}}
+	schema, err := NewSchema(query, Limits{MaxDepth: 4})
+	require.NoError(t, err)
+	return schema
+}
+
+func execute(t *testing.T, s *Schema, req Request) (map[string]any, *Response) {
+	t.Helper()
+	resp := s.Execute(context.Background(), req)
+	raw, err := json.Marshal(resp)
+	require.NoError(t, err)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(raw, &out))
+	return out, resp
+}
+
+func TestExecute_ResolvesFieldsAliasesAndFragments(t *testing.T) {
+	s := newTestSchema(t)
+	out, resp := execute(t, s, Request{Query: `
+		query Get($id: ID!) {
+			first: book(id: $id) { ...Fields rating }
+			books { id }
+		}
+		fragment Fields on Book { id title __typename }
+	`, Variables: map[string]any{"id": "b1"}})
+
+	require.Empty(t, resp.Errors)
+	data := out["data"].(map[string]any)
+	assert.Equal(t, map[string]any{"id": "b1", "title": "Dune", "__typename": "Book", "rating": "5"}, data["first"])
+	assert.Len(t, data["books"], 2)
+}
+
+func TestExecute_KeepsSelectionOrder(t *testing.T) {
+	s := newTestSchema(t)
+	resp := s.Execute(context.Background(), Request{Query: `{ book(id: "1") { title id } }`})
+	raw, err := json.Marshal(resp.Data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"book":{"title":"Dune","id":"1"}}`, string(raw))
+	assert.Equal(t, `{"book":{"title":"Dune","id":"1"}}`, string(raw))
+}
+
+func TestExecute_ArgumentDefaultsAndDirectives(t *testing.T) {
+	s := newTestSchema(t)
+	out, resp := execute(t, s, Request{
+		Query:     `query($skip: Boolean!) { books(limit: 3) { id title @skip(if: $skip) } }`,
+		Variables: map[string]any{"skip": true},
+	})
+	require.Empty(t, resp.Errors)
+	books := out["data"].(map[string]any)["books"].([]any)
+	require.Len(t, books, 3)
+	assert.Equal(t, map[string]any{"id": "a"}, books[0])
+}
+
+func TestExecute_NullPropagatesToNearestNullableField(t *testing.T) {
+	s := newTestSchema(t)
+	out, resp := execute(t, s, Request{Query: `{ book(id: "1") { id broken } }`})
+
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "boom", resp.Errors[0].Message)
+	assert.Equal(t, []any{"book", "broken"}, resp.Errors[0].Path)
+	assert.Nil(t, out["data"].(map[string]any)["book"])
+}
+
+func TestExecute_RejectsInvalidQueries(t *testing.T) {
+	s := newTestSchema(t)
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"syntax error", `{ book(id: "1") { id }`},
+		{"unknown field", `{ book(id: "1") { isbn } }`},
+		{"missing required argument", `{ book { id } }`},
+		{"object without selection", `{ book(id: "1") }`},
+		{"selection on scalar", `{ book(id: "1") { id { x } } }`},
+		{"too deep", `{ book(id: "1") { related { related { related { related { id } } } } } }`},
+		{"mutation", `mutation { book(id: "1") { id } }`},
+		{"fragment cycle", `{ book(id: "1") { ...A } } fragment A on Book { ...B } fragment B on Book { ...A }`},
+		{"undefined variable", `{ book(id: $id) { id } }`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := s.Execute(context.Background(), Request{Query: tt.query})
+			assert.Nil(t, resp.Data)
+			assert.NotEmpty(t, resp.Errors)
+		})
+	}
+}
+
+func TestExecute_MissingRequiredVariable(t *testing.T) {
+	s := newTestSchema(t)
+	resp := s.Execute(context.Background(), Request{Query: `query($id: ID!) { book(id: $id) { id } }`})
+	assert.Nil(t, resp.Data)
+	require.Len(t, resp.Errors, 1)
+}
+
+func TestSchema_SDL(t *testing.T) {
+	sdl := newTestSchema(t).SDL()
+	assert.Contains(t, sdl, "type Query {")
+	assert.Contains(t, sdl, "book(id: ID!): Book")
+	assert.Contains(t, sdl, "books(limit: Int = 2): [Book!]!")
+}
+
+func TestExecute_EnforcesLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits Limits
+		query  string
+		want   string
+	}{
+		{
+			"document size",
+			Limits{MaxDocumentBytes: 32},
+			`{ book(id: "1") { id title rating } }`,
+			"query is larger than 32 bytes",
+		},
+		{
+			"field count",
+			Limits{MaxFields: 3},
+			`{ book(id: "1") { id title rating } }`,
+			"query selects more than 3 fields",
+		},
+		{
+			"field count across fragment spreads",
+			Limits{MaxFields: 5},
+			`{ book(id: "1") { ...F related { ...F } } } fragment F on Book { id title }`,
+			"query selects more than 5 fields",
+		},
+		{
+			"alias count",
+			Limits{MaxAliases: 2},
+			`{ a: book(id: "1") { id } b: book(id: "2") { id } c: book(id: "3") { id } }`,
+			"query uses more than 2 aliases",
+		},
+		{
+			"complexity multiplied by limit",
+			Limits{MaxComplexity: 100},
+			`{ books(limit: 50) { id title } }`,
+			"query complexity 101 exceeds the limit of 100",
+		},
+		{
+			"complexity from a variable limit",
+			Limits{MaxComplexity: 15},
+			`query($n: Int) { books(limit: $n) { id related { id } } }`,
+			"query complexity 16 exceeds the limit of 15",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSchema(t)
+			s.Limits = tt.limits
+			resp := s.Execute(context.Background(), Request{Query: tt.query, Variables: map[string]any{"n": float64(5)}})
+			assert.Nil(t, resp.Data)
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, tt.want, resp.Errors[0].Message)
+		})
+	}
+}
+
+func TestExecute_QueriesWithinLimitsRun(t *testing.T) {
+	s := newTestSchema(t)
+	s.Limits = Limits{MaxDocumentBytes: 128, MaxDepth: 4, MaxFields: 5, MaxAliases: 1, MaxComplexity: 7}
+	// books defaults to a limit of 2, so this costs 1 + 2*(1+1+1) = 7
+	resp := s.Execute(context.Background(), Request{Query: `{ books { id name: title __typename } }`})
+	assert.Empty(t, resp.Errors)
+	assert.NotNil(t, resp.Data)
+}
@@ -0,0 +1,212 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind   tokenKind
+	value  string
+	line   int
+	column int
+}
+
+// lexer splits a query into tokens. Commas count as whitespace and comments
+// run from # to the end of the line, as in the spec.
+type lexer struct {
+	src    string
+	pos    int
+	line   int
+	column int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src, line: 1, column: 1}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, line: l.line, column: l.column}, nil
+	}
+
+	line, column := l.line, l.column
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.advance(3)
+		return token{kind: tokenPunct, value: "...", line: line, column: column}, nil
+	case strings.IndexByte("!$&()/:=@[]{}|", c) >= 0:
+		l.advance(1)
+		return token{kind: tokenPunct, value: string(c), line: line, column: column}, nil
+	case c == '_' || isLetter(c):
+		start := l.pos
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		return token{kind: tokenName, value: l.src[start:l.pos], line: line, column: column}, nil
+	case c == '-' || isDigit(c):
+		return l.number(line, column)
+	case c == '"':
+		return l.string(line, column)
+	}
+	return token{}, fmt.Errorf("syntax error at %d:%d: unexpected character %q", line, column, c)
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == '\n':
+			l.pos++
+			l.line++
+			l.column = 1
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\uFEFF"):
+			l.pos += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) advance(n int) {
+	l.pos += n
+	l.column += n
+}
+
+func (l *lexer) number(line, column int) (token, error) {
+	start := l.pos
+	kind := tokenInt
+	if l.src[l.pos] == '-' {
+		l.advance(1)
+	}
+	l.digits()
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokenFloat
+		l.advance(1)
+		l.digits()
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokenFloat
+		l.advance(1)
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.advance(1)
+		}
+		l.digits()
+	}
+	text := l.src[start:l.pos]
+	if _, err := strconv.ParseFloat(text, 64); err != nil {
+		return token{}, fmt.Errorf("syntax error at %d:%d: invalid number %q", line, column, text)
+	}
+	return token{kind: kind, value: text, line: line, column: column}, nil
+}
+
+func (l *lexer) digits() {
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.advance(1)
+	}
+}
+
+func (l *lexer) string(line, column int) (token, error) {
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		return l.blockString(line, column)
+	}
+	l.advance(1)
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.advance(1)
+			return token{kind: tokenString, value: b.String(), line: line, column: column}, nil
+		case c == '\n':
+			return token{}, fmt.Errorf("syntax error at %d:%d: unterminated string", line, column)
+		case c == '\\':
+			if l.pos+1 >= len(l.src) {
+				return token{}, fmt.Errorf("syntax error at %d:%d: unterminated string", line, column)
+			}
+			esc := l.src[l.pos+1]
+			l.advance(2)
+			switch esc {
+			case '"', '\\', '/':
+				b.WriteByte(esc)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.pos+4 > len(l.src) {
+					return token{}, fmt.Errorf("syntax error at %d:%d: invalid unicode escape", l.line, l.column)
+				}
+				r, err := strconv.ParseUint(l.src[l.pos:l.pos+4], 16, 32)
+				if err != nil {
+					return token{}, fmt.Errorf("syntax error at %d:%d: invalid unicode escape", l.line, l.column)
+				}
+				b.WriteRune(rune(r))
+				l.advance(4)
+			default:
+				return token{}, fmt.Errorf("syntax error at %d:%d: invalid escape \\%c", l.line, l.column, esc)
+			}
+		default:
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			b.WriteRune(r)
+			l.advance(size)
+		}
+	}
+	return token{}, fmt.Errorf("syntax error at %d:%d: unterminated string", line, column)
+}
+
+// blockString reads a """ string. Common indentation is not stripped; the
+// gateway only needs block strings to survive being sent, not pretty values.
+func (l *lexer) blockString(line, column int) (token, error) {
+	l.advance(3)
+	end := strings.Index(l.src[l.pos:], `"""`)
+	if end < 0 {
+		return token{}, fmt.Errorf("syntax error at %d:%d: unterminated block string", line, column)
+	}
+	value := l.src[l.pos : l.pos+end]
+	for _, c := range value {
+		if c == '\n' {
+			l.line++
+			l.column = 1
+		} else {
+			l.column++
+		}
+	}
+	l.pos += end
+	l.advance(3)
+	return token{kind: tokenString, value: value, line: line, column: column}, nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
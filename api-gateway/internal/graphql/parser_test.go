@@ -0,0 +1,140 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_ExecutableDocuments(t *testing.T) {
+	doc, err := Parse(`
+		# A comment, then a named query
+		query Feed($limit: Int = 10, $ids: [ID!]!) @cached {
+			alias: user(id: "u1", tags: ["a", "b"], filter: {min: -1.5e3, on: true, kind: OPEN, none: null}) {
+				... on User @include(if: true) { id }
+				...Profile
+			}
+		}
+		fragment Profile on User { bio, avatar }
+		{ shorthand }
+	`)
+	require.NoError(t, err)
+	require.Len(t, doc.Operations, 2)
+
+	op := doc.Operations[0]
+	assert.Equal(t, "query", op.Type)
+	assert.Equal(t, "Feed", op.Name)
+	require.Len(t, op.Variables, 2)
+	assert.Equal(t, TypeRef{Name: "Int"}, op.Variables[0].Type)
+	assert.Equal(t, &Value{Kind: IntValue, Raw: "10"}, op.Variables[0].Default)
+	assert.Equal(t, TypeRef{Elem: &TypeRef{Name: "ID", NonNull: true}, NonNull: true}, op.Variables[1].Type)
+
+	field := op.SelectionSet[0].(*Field)
+	assert.Equal(t, "alias", field.ResponseKey())
+	assert.Equal(t, "user", field.Name)
+	assert.Equal(t, 4, field.Line)
+	filter := field.Arguments[2].Value
+	assert.Equal(t, ObjectValue, filter.Kind)
+	assert.Equal(t, Value{Kind: FloatValue, Raw: "-1.5e3"}, filter.Fields["min"])
+	assert.Equal(t, Value{Kind: EnumValue, Raw: "OPEN"}, filter.Fields["kind"])
+	assert.Equal(t, NullValue, filter.Fields["none"].Kind)
+	assert.IsType(t, &InlineFragment{}, field.SelectionSet[0])
+	assert.Equal(t, &FragmentSpread{Name: "Profile"}, field.SelectionSet[1])
+
+	assert.Equal(t, "User", doc.Fragments["Profile"].TypeCondition)
+	assert.Equal(t, "shorthand", doc.Operations[1].SelectionSet[0].(*Field).Name)
+}
+
+func TestParse_Strings(t *testing.T) {
+	tests := []struct {
+		literal string
+		want    string
+	}{
+		{`"plain"`, "plain"},
+		{`"quote \" slash \\ \/ tab \t"`, "quote \" slash \\ / tab \t"},
+		{`"été"`, "été"},
+		{`"ünïcödé"`, "ünïcödé"},
+		{`"""block "quoted"
+		text"""`, "block \"quoted\"\n\t\ttext"},
+	}
+	for _, tt := range tests {
+		doc, err := Parse(`{ f(s: ` + tt.literal + `) }`)
+		require.NoError(t, err, tt.literal)
+		assert.Equal(t, tt.want, doc.Operations[0].SelectionSet[0].(*Field).Arguments[0].Value.Raw, tt.literal)
+	}
+}
+
+func TestParse_RejectsInvalidDocuments(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"empty", ``},
+		{"only comments", `# nothing here`},
+		{"empty selection set", `{ }`},
+		{"unclosed selection set", `{ a { b }`},
+		{"unterminated string", `{ a(s: "open) }`},
+		{"newline in string", "{ a(s: \"one\ntwo\") }"},
+		{"bad escape", `{ a(s: "\q") }`},
+		{"short unicode escape", `{ a(s: "\u12") }`},
+		{"unterminated block string", `{ a(s: """open) }`},
+		{"invalid number", `{ a(n: 1.2.3) }`},
+		{"bare minus", `{ a(n: -) }`},
+		{"unexpected character", `{ a ? }`},
+		{"variable in default", `query($a: Int = $b) { a }`},
+		{"fragment named on", `fragment on on User { id } { a }`},
+		{"fragment without type condition", `fragment F User { id } { a }`},
+		{"duplicate fragment", `fragment F on A { a } fragment F on A { b } { a }`},
+		{"type definition", `type Query { a: Int }`},
+		{"missing argument value", `{ a(x:) }`},
+		{"unclosed list type", `query($a: [Int) { a }`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParse_ReportsErrorPosition(t *testing.T) {
+	_, err := Parse("{\n  a(x: 1\n}")
+	require.Error(t, err)
+	assert.Equal(t, `syntax error at 3:1: unexpected "}"`, err.Error())
+}
+
+// FuzzParse checks that no input panics the parser, and that anything it
+// accepts can go through validation against a schema without panicking
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		`{ book(id: "1") { id title } }`,
+		`query Get($id: ID! = "x") { first: book(id: $id) { ...F related { id } } } fragment F on Book { id }`,
+		`{ books(limit: 3) { id title @skip(if: false) ... on Book { rating } } }`,
+		`{ a(v: [1, -2.5e3, "s", """b""", true, null, ENUM, {k: [$v]}]) }`,
+		`fragment A on Book { ...B } fragment B on Book { ...A } { book(id: "1") { ...A } }`,
+		"\uFEFF{ a # comment\n, b }",
+		`{ a(s: "é\n") }`,
+		strings.Repeat("{ a ", 64) + strings.Repeat("}", 64),
+		`mutation M { a } subscription S { b }`,
+	} {
+		f.Add(seed)
+	}
+
+	schema := newTestSchema(f)
+	schema.Limits = Limits{MaxDepth: 4, MaxFields: 100, MaxAliases: 10, MaxComplexity: 1000}
+	f.Fuzz(func(t *testing.T, query string) {
+		doc, err := Parse(query)
+		if err != nil {
+			return
+		}
+		require.NotEmpty(t, doc.Operations)
+		for _, op := range doc.Operations {
+			if _, _, err := schema.prepare(doc, op.Name, nil); err == nil {
+				schema.Execute(context.Background(), Request{Query: query, OperationName: op.Name})
+			}
+		}
+	})
+}
@@ -0,0 +1,442 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Type is a *Scalar, *Object, *List or *NonNull
+type Type interface {
+	String() string
+}
+
+// Scalar is a leaf type. Serialize turns a resolved Go value into its JSON
+// form; ParseLiteral and ParseVariable coerce query literals and variables.
+type Scalar struct {
+	Name          string
+	Description   string
+	Serialize     func(any) (any, error)
+	ParseLiteral  func(Value) (any, error)
+	ParseVariable func(any) (any, error)
+}
+
+func (s *Scalar) String() string { return s.Name }
+
+type Object struct {
+	Name        string
+	Description string
+	Fields      []*FieldDef
+
+	fields map[string]*FieldDef
+}
+
+func (o *Object) String() string { return o.Name }
+
+// Field returns the named field, or nil if the type has none
+func (o *Object) Field(name string) *FieldDef {
+	if o.fields == nil {
+		o.fields = make(map[string]*FieldDef, len(o.Fields))
+		for _, f := range o.Fields {
+			o.fields[f.Name] = f
+		}
+	}
+	return o.fields[name]
+}
+
+type List struct{ Of Type }
+
+func (l *List) String() string { return "[" + l.Of.String() + "]" }
+
+type NonNull struct{ Of Type }
+
+func (n *NonNull) String() string { return n.Of.String() + "!" }
+
+func ListOf(t Type) *List       { return &List{Of: t} }
+func NonNullOf(t Type) *NonNull { return &NonNull{Of: t} }
+
+type FieldDef struct {
+	Name        string
+	Description string
+	Type        Type
+	Args        []*ArgDef
+	// Resolve produces the field's value from its parent's. When nil the
+	// field is read from the parent: a map key, or a struct field tagged
+	// `graphql:"<name>"`.
+	Resolve ResolveFunc
+}
+
+type ArgDef struct {
+	Name        string
+	Description string
+	Type        Type
+	// Default is used when the argument is omitted; nil means no default
+	Default any
+}
+
+type ResolveParams struct {
+	Source any
+	Args   map[string]any
+}
+
+type ResolveFunc func(ctx context.Context, p ResolveParams) (any, error)
+
+// Schema is a query-only schema. The gateway reads from the services behind
+// it, so there is no mutation or subscription root.
+type Schema struct {
+	Query  *Object
+	Limits Limits
+
+	types map[string]Type
+}
+
+// Limits bound the work a single query can ask for. Every limit is checked
+// before any resolver runs; zero means unlimited.
+type Limits struct {
+	// MaxDocumentBytes caps the query text and is checked before parsing
+	MaxDocumentBytes int
+	// MaxDepth limits how deeply fields may nest
+	MaxDepth int
+	// MaxFields caps the selected fields, counting a fragment once per spread
+	MaxFields int
+	// MaxAliases caps aliased fields, which can ask for one field many times
+	MaxAliases int
+	// MaxComplexity caps the query's cost. Each field costs one, and a field
+	// taking a limit argument multiplies the cost of its selection by it.
+	MaxComplexity int
+}
+
+func NewSchema(query *Object, limits Limits) (*Schema, error) {
+	s := &Schema{Query: query, Limits: limits, types: make(map[string]Type)}
+	// Built-in scalars are usable in variable definitions even when no
+	// field returns them
+	for _, scalar := range []*Scalar{String, ID, Int, Float, Boolean} {
+		s.types[scalar.Name] = scalar
+	}
+	if err := s.collect(query); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// collect registers every named type reachable from t
+func (s *Schema) collect(t Type) error {
+	switch t := t.(type) {
+	case *NonNull:
+		return s.collect(t.Of)
+	case *List:
+		return s.collect(t.Of)
+	case *Scalar:
+		return s.register(t.Name, t)
+	case *Object:
+		if existing, ok := s.types[t.Name]; ok {
+			if existing != Type(t) {
+				return fmt.Errorf("type %s is defined twice", t.Name)
+			}
+			return nil
+		}
+		s.types[t.Name] = t
+		for _, f := range t.Fields {
+			if err := s.collect(f.Type); err != nil {
+				return err
+			}
+			for _, arg := range f.Args {
+				if _, ok := namedType(arg.Type).(*Scalar); !ok {
+					return fmt.Errorf("argument %s.%s(%s) must be a scalar or list of scalars", t.Name, f.Name, arg.Name)
+				}
+				if err := s.collect(arg.Type); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) register(name string, t Type) error {
+	if existing, ok := s.types[name]; ok && existing != t {
+		return fmt.Errorf("type %s is defined twice", name)
+	}
+	s.types[name] = t
+	return nil
+}
+
+// namedType strips list and non-null wrappers
+func namedType(t Type) Type {
+	for {
+		switch w := t.(type) {
+		case *NonNull:
+			t = w.Of
+		case *List:
+			t = w.Of
+		default:
+			return t
+		}
+	}
+}
+
+// Built-in scalars, plus DateTime for timestamps in RFC 3339
+var (
+	String = &Scalar{
+		Name:          "String",
+		Serialize:     serializeString,
+		ParseLiteral:  literalOf(StringValue, func(raw string) (any, error) { return raw, nil }),
+		ParseVariable: variableOf[string]("String"),
+	}
+	ID = &Scalar{
+		Name:      "ID",
+		Serialize: serializeString,
+		ParseLiteral: func(v Value) (any, error) {
+			if v.Kind != StringValue && v.Kind != IntValue {
+				return nil, fmt.Errorf("ID cannot represent %s", v.Raw)
+			}
+			return v.Raw, nil
+		},
+		ParseVariable: func(v any) (any, error) {
+			switch v := v.(type) {
+			case string:
+				return v, nil
+			case float64:
+				if v == math.Trunc(v) {
+					return strconv.FormatInt(int64(v), 10), nil
+				}
+			}
+			return nil, fmt.Errorf("ID cannot represent %v", v)
+		},
+	}
+	Int = &Scalar{
+		Name: "Int",
+		Serialize: func(v any) (any, error) {
+			switch v := v.(type) {
+			case int:
+				return v, nil
+			case int32:
+				return int(v), nil
+			case int64:
+				if v > math.MaxInt32 || v < math.MinInt32 {
+					return nil, fmt.Errorf("Int cannot represent %d", v)
+				}
+				return int(v), nil
+			}
+			return nil, fmt.Errorf("Int cannot represent %v", v)
+		},
+		ParseLiteral: literalOf(IntValue, func(raw string) (any, error) {
+			n, err := strconv.ParseInt(raw, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("Int cannot represent %s", raw)
+			}
+			return int(n), nil
+		}),
+		ParseVariable: func(v any) (any, error) {
+			if f, ok := v.(float64); ok && f == math.Trunc(f) && f <= math.MaxInt32 && f >= math.MinInt32 {
+				return int(f), nil
+			}
+			return nil, fmt.Errorf("Int cannot represent %v", v)
+		},
+	}
+	Float = &Scalar{
+		Name: "Float",
+		Serialize: func(v any) (any, error) {
+			switch v := v.(type) {
+			case float64:
+				return v, nil
+			case float32:
+				return float64(v), nil
+			case int:
+				return float64(v), nil
+			}
+			return nil, fmt.Errorf("Float cannot represent %v", v)
+		},
+		ParseLiteral: func(v Value) (any, error) {
+			if v.Kind != FloatValue && v.Kind != IntValue {
+				return nil, fmt.Errorf("Float cannot represent %s", v.Raw)
+			}
+			return strconv.ParseFloat(v.Raw, 64)
+		},
+		ParseVariable: variableOf[float64]("Float"),
+	}
+	Boolean = &Scalar{
+		Name: "Boolean",
+		Serialize: func(v any) (any, error) {
+			if b, ok := v.(bool); ok {
+				return b, nil
+			}
+			return nil, fmt.Errorf("Boolean cannot represent %v", v)
+		},
+		ParseLiteral:  literalOf(BooleanValue, func(raw string) (any, error) { return raw == "true", nil }),
+		ParseVariable: variableOf[bool]("Boolean"),
+	}
+	DateTime = &Scalar{
+		Name:        "DateTime",
+		Description: "A point in time in RFC 3339 format",
+		Serialize: func(v any) (any, error) {
+			t, ok := v.(time.Time)
+			if !ok {
+				return nil, fmt.Errorf("DateTime cannot represent %v", v)
+			}
+			if t.IsZero() {
+				return nil, nil
+			}
+			return t.UTC().Format(time.RFC3339), nil
+		},
+		ParseLiteral: literalOf(StringValue, parseDateTime),
+		ParseVariable: func(v any) (any, error) {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("DateTime cannot represent %v", v)
+			}
+			return parseDateTime(s)
+		},
+	}
+)
+
+func serializeString(v any) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	return nil, fmt.Errorf("String cannot represent %v", v)
+}
+
+func parseDateTime(raw string) (any, error) {
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("DateTime cannot represent %q", raw)
+	}
+	return t, nil
+}
+
+func literalOf(kind ValueKind, parse func(string) (any, error)) func(Value) (any, error) {
+	return func(v Value) (any, error) {
+		if v.Kind != kind {
+			return nil, fmt.Errorf("unexpected value %s", v.Raw)
+		}
+		return parse(v.Raw)
+	}
+}
+
+func variableOf[T any](name string) func(any) (any, error) {
+	return func(v any) (any, error) {
+		if t, ok := v.(T); ok {
+			return t, nil
+		}
+		return nil, fmt.Errorf("%s cannot represent %v", name, v)
+	}
+}
+
+// coerceLiteral turns an argument written in the query into a Go value,
+// substituting variables, which have already been coerced
+func coerceLiteral(t Type, v Value, variables map[string]any) (any, error) {
+	if v.Kind == VariableValue {
+		value, ok := variables[v.Raw]
+		if !ok || value == nil {
+			if _, nonNull := t.(*NonNull); nonNull {
+				return nil, fmt.Errorf("variable $%s is required", v.Raw)
+			}
+			return nil, nil
+		}
+		return value, nil
+	}
+
+	switch t := t.(type) {
+	case *NonNull:
+		if v.Kind == NullValue {
+			return nil, fmt.Errorf("expected a non-null %s", t.Of)
+		}
+		return coerceLiteral(t.Of, v, variables)
+	case *List:
+		if v.Kind == NullValue {
+			return nil, nil
+		}
+		if v.Kind != ListValue {
+			// A single value is accepted where a list is expected
+			item, err := coerceLiteral(t.Of, v, variables)
+			if err != nil {
+				return nil, err
+			}
+			return []any{item}, nil
+		}
+		items := make([]any, len(v.List))
+		for i, item := range v.List {
+			coerced, err := coerceLiteral(t.Of, item, variables)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = coerced
+		}
+		return items, nil
+	case *Scalar:
+		if v.Kind == NullValue {
+			return nil, nil
+		}
+		return t.ParseLiteral(v)
+	}
+	return nil, fmt.Errorf("cannot coerce input of type %s", t)
+}
+
+// coerceVariable turns a decoded JSON variable into a Go value of type t
+func coerceVariable(t Type, v any) (any, error) {
+	switch t := t.(type) {
+	case *NonNull:
+		if v == nil {
+			return nil, fmt.Errorf("expected a non-null %s", t.Of)
+		}
+		return coerceVariable(t.Of, v)
+	case *List:
+		if v == nil {
+			return nil, nil
+		}
+		raw, ok := v.([]any)
+		if !ok {
+			item, err := coerceVariable(t.Of, v)
+			if err != nil {
+				return nil, err
+			}
+			return []any{item}, nil
+		}
+		items := make([]any, len(raw))
+		for i, item := range raw {
+			coerced, err := coerceVariable(t.Of, item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = coerced
+		}
+		return items, nil
+	case *Scalar:
+		if v == nil {
+			return nil, nil
+		}
+		return t.ParseVariable(v)
+	}
+	return nil, fmt.Errorf("cannot coerce input of type %s", t)
+}
+
+// resolveTypeRef finds the input type a variable definition names
+func (s *Schema) resolveTypeRef(ref TypeRef) (Type, error) {
+	var t Type
+	if ref.Elem != nil {
+		elem, err := s.resolveTypeRef(*ref.Elem)
+		if err != nil {
+			return nil, err
+		}
+		t = ListOf(elem)
+	} else {
+		named, ok := s.types[ref.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %q", ref.Name)
+		}
+		if _, ok := named.(*Scalar); !ok {
+			return nil, fmt.Errorf("variable type %s is not an input type", ref.Name)
+		}
+		t = named
+	}
+	if ref.NonNull {
+		t = NonNullOf(t)
+	}
+	return t, nil
+}
@@ -0,0 +1,70 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SDL prints the schema in the GraphQL schema language. The gateway does not
+// answer introspection queries; clients generate types from this instead.
+func (s *Schema) SDL() string {
+	names := make([]string, 0, len(s.types))
+	for name := range s.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("schema {\n  query: " + s.Query.Name + "\n}\n")
+	for _, name := range names {
+		switch t := s.types[name].(type) {
+		case *Scalar:
+			if isBuiltinScalar(t) {
+				continue
+			}
+			b.WriteString("\n")
+			writeDescription(&b, t.Description, "")
+			fmt.Fprintf(&b, "scalar %s\n", t.Name)
+		case *Object:
+			b.WriteString("\n")
+			writeDescription(&b, t.Description, "")
+			fmt.Fprintf(&b, "type %s {\n", t.Name)
+			for _, f := range t.Fields {
+				writeDescription(&b, f.Description, "  ")
+				b.WriteString("  " + f.Name)
+				if len(f.Args) > 0 {
+					args := make([]string, len(f.Args))
+					for i, a := range f.Args {
+						args[i] = a.Name + ": " + a.Type.String()
+						if a.Default != nil {
+							args[i] += " = " + formatDefault(a.Default)
+						}
+					}
+					b.WriteString("(" + strings.Join(args, ", ") + ")")
+				}
+				b.WriteString(": " + f.Type.String() + "\n")
+			}
+			b.WriteString("}\n")
+		}
+	}
+	return b.String()
+}
+
+func isBuiltinScalar(s *Scalar) bool {
+	return s == String || s == ID || s == Int || s == Float || s == Boolean
+}
+
+func writeDescription(b *strings.Builder, description, indent string) {
+	if description == "" {
+		return
+	}
+	fmt.Fprintf(b, "%s%q\n", indent, description)
+}
+
+func formatDefault(v any) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,319 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+)
+
+// prepare picks the operation to run, coerces its variables and checks every
+// selection against the schema, so a bad query fails before any resolver runs
+func (s *Schema) prepare(doc *Document, operationName string, rawVariables map[string]any) (*Operation, map[string]any, error) {
+	op, err := selectOperation(doc, operationName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if op.Type != "query" {
+		return nil, nil, fmt.Errorf("%s operations are not supported", op.Type)
+	}
+
+	variables, err := s.coerceVariables(op, rawVariables)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := &validator{schema: s, doc: doc, variables: variables, declared: make(map[string]bool)}
+	for _, def := range op.Variables {
+		v.declared[def.Name] = true
+	}
+	complexity, err := v.selectionSet(s.Query, op.SelectionSet, 0, make(map[string]bool))
+	if err != nil {
+		return nil, nil, err
+	}
+	if limit := s.Limits.MaxComplexity; limit > 0 && complexity > limit {
+		return nil, nil, fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, limit)
+	}
+	return op, variables, nil
+}
+
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, fmt.Errorf("operationName is required when the document has several operations")
+		}
+		return doc.Operations[0], nil
+	}
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+func (s *Schema) coerceVariables(op *Operation, raw map[string]any) (map[string]any, error) {
+	variables := make(map[string]any, len(op.Variables))
+	for _, def := range op.Variables {
+		if _, exists := variables[def.Name]; exists {
+			return nil, fmt.Errorf("variable $%s is defined twice", def.Name)
+		}
+		typ, err := s.resolveTypeRef(def.Type)
+		if err != nil {
+			return nil, fmt.Errorf("variable $%s: %w", def.Name, err)
+		}
+
+		value, provided := raw[def.Name]
+		switch {
+		case provided:
+			if value, err = coerceVariable(typ, value); err != nil {
+				return nil, fmt.Errorf("variable $%s: %w", def.Name, err)
+			}
+		case def.Default != nil:
+			if value, err = coerceLiteral(typ, *def.Default, nil); err != nil {
+				return nil, fmt.Errorf("variable $%s default: %w", def.Name, err)
+			}
+		default:
+			if _, nonNull := typ.(*NonNull); nonNull {
+				return nil, fmt.Errorf("variable $%s of type %s is required", def.Name, typ)
+			}
+			continue
+		}
+		variables[def.Name] = value
+	}
+	return variables, nil
+}
+
+type validator struct {
+	schema    *Schema
+	doc       *Document
+	variables map[string]any
+	declared  map[string]bool
+
+	// Running totals across every fragment spread, so a few small fragments
+	// spread into each other cannot hide a huge selection
+	fields  int
+	aliases int
+}
+
+// selectionSet validates set and returns its complexity
+func (v *validator) selectionSet(parent *Object, set []Selection, depth int, spreading map[string]bool) (int, error) {
+	complexity := 0
+	for _, sel := range set {
+		var cost int
+		var err error
+		switch sel := sel.(type) {
+		case *Field:
+			cost, err = v.field(parent, sel, depth)
+		case *FragmentSpread:
+			if err := v.directives(sel.Directives); err != nil {
+				return 0, err
+			}
+			frag, ok := v.doc.Fragments[sel.Name]
+			if !ok {
+				return 0, fmt.Errorf("unknown fragment %q", sel.Name)
+			}
+			if spreading[sel.Name] {
+				return 0, fmt.Errorf("fragment %q spreads itself", sel.Name)
+			}
+			if err := v.typeCondition(parent, frag.TypeCondition); err != nil {
+				return 0, err
+			}
+			spreading[sel.Name] = true
+			cost, err = v.selectionSet(parent, frag.SelectionSet, depth, spreading)
+			delete(spreading, sel.Name)
+		case *InlineFragment:
+			if err := v.directives(sel.Directives); err != nil {
+				return 0, err
+			}
+			if sel.TypeCondition != "" {
+				if err := v.typeCondition(parent, sel.TypeCondition); err != nil {
+					return 0, err
+				}
+			}
+			cost, err = v.selectionSet(parent, sel.SelectionSet, depth, spreading)
+		}
+		if err != nil {
+			return 0, err
+		}
+		complexity = addCapped(complexity, cost)
+	}
+	return complexity, nil
+}
+
+// typeCondition checks a fragment can apply to parent. Every type is an
+// object, so it must name parent itself.
+func (v *validator) typeCondition(parent *Object, name string) error {
+	if _, ok := v.schema.types[name]; !ok {
+		return fmt.Errorf("unknown type %q", name)
+	}
+	if name != parent.Name {
+		return fmt.Errorf("fragment on %s can never apply to %s", name, parent.Name)
+	}
+	return nil
+}
+
+// field validates one field and returns its complexity
+func (v *validator) field(parent *Object, field *Field, depth int) (int, error) {
+	if err := v.directives(field.Directives); err != nil {
+		return 0, err
+	}
+	if err := v.count(field); err != nil {
+		return 0, err
+	}
+	if field.Name == "__typename" {
+		if len(field.Arguments) > 0 || field.SelectionSet != nil {
+			return 0, fieldError(field, "__typename takes no arguments or selections")
+		}
+		return 1, nil
+	}
+
+	def := parent.Field(field.Name)
+	if def == nil {
+		return 0, fieldError(field, fmt.Sprintf("cannot query field %q on type %q", field.Name, parent.Name))
+	}
+	if limit := v.schema.Limits.MaxDepth; limit > 0 && depth+1 > limit {
+		return 0, fieldError(field, fmt.Sprintf("query is nested deeper than %d levels", limit))
+	}
+	if err := v.arguments(def, field); err != nil {
+		return 0, err
+	}
+
+	switch named := namedType(def.Type).(type) {
+	case *Object:
+		if field.SelectionSet == nil {
+			return 0, fieldError(field, fmt.Sprintf("field %q of type %s must have a selection of subfields", field.Name, def.Type))
+		}
+		cost, err := v.selectionSet(named, field.SelectionSet, depth+1, make(map[string]bool))
+		if err != nil {
+			return 0, err
+		}
+		args, err := coerceArguments(def, field, v.variables)
+		if err != nil {
+			return 0, fieldError(field, err.Error())
+		}
+		return addCapped(1, multiplyCapped(cost, pageSize(args))), nil
+	default:
+		if field.SelectionSet != nil {
+			return 0, fieldError(field, fmt.Sprintf("field %q of type %s must not have a selection", field.Name, def.Type))
+		}
+	}
+	return 1, nil
+}
+
+// count adds field to the running totals and checks the field and alias limits
+func (v *validator) count(field *Field) error {
+	limits := v.schema.Limits
+	v.fields++
+	if limits.MaxFields > 0 && v.fields > limits.MaxFields {
+		return fieldError(field, fmt.Sprintf("query selects more than %d fields", limits.MaxFields))
+	}
+	if field.Alias != "" {
+		v.aliases++
+		if limits.MaxAliases > 0 && v.aliases > limits.MaxAliases {
+			return fieldError(field, fmt.Sprintf("query uses more than %d aliases", limits.MaxAliases))
+		}
+	}
+	return nil
+}
+
+// pageSize is the limit a field will resolve with; a field without one
+// counts as a single item
+func pageSize(args map[string]any) int {
+	if limit, ok := args["limit"].(int); ok && limit > 1 {
+		return limit
+	}
+	return 1
+}
+
+// addCapped and multiplyCapped saturate instead of overflowing, so a hostile
+// query cannot wrap its complexity back under the limit
+func addCapped(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+func multiplyCapped(a, b int) int {
+	if a != 0 && b > math.MaxInt/a {
+		return math.MaxInt
+	}
+	return a * b
+}
+
+func (v *validator) arguments(def *FieldDef, field *Field) error {
+	given := make(map[string]bool, len(field.Arguments))
+	for _, arg := range field.Arguments {
+		if given[arg.Name] {
+			return fieldError(field, fmt.Sprintf("argument %q is given twice", arg.Name))
+		}
+		given[arg.Name] = true
+
+		var argDef *ArgDef
+		for _, a := range def.Args {
+			if a.Name == arg.Name {
+				argDef = a
+				break
+			}
+		}
+		if argDef == nil {
+			return fieldError(field, fmt.Sprintf("unknown argument %q on field %q", arg.Name, field.Name))
+		}
+		if err := v.usesDeclaredVariables(arg.Value); err != nil {
+			return fieldError(field, err.Error())
+		}
+		if _, err := coerceLiteral(argDef.Type, arg.Value, v.variables); err != nil && !(arg.Value.Kind == VariableValue && argDef.Default != nil) {
+			return fieldError(field, fmt.Sprintf("argument %q: %v", arg.Name, err))
+		}
+	}
+	for _, a := range def.Args {
+		if _, nonNull := a.Type.(*NonNull); nonNull && a.Default == nil && !given[a.Name] {
+			return fieldError(field, fmt.Sprintf("argument %q of type %s is required", a.Name, a.Type))
+		}
+	}
+	return nil
+}
+
+func (v *validator) usesDeclaredVariables(value Value) error {
+	switch value.Kind {
+	case VariableValue:
+		if !v.declared[value.Raw] {
+			return fmt.Errorf("variable $%s is not defined", value.Raw)
+		}
+	case ListValue:
+		for _, item := range value.List {
+			if err := v.usesDeclaredVariables(item); err != nil {
+				return err
+			}
+		}
+	case ObjectValue:
+		for _, item := range value.Fields {
+			if err := v.usesDeclaredVariables(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// directives allows only @skip and @include, each with a Boolean "if"
+func (v *validator) directives(directives []*Directive) error {
+	for _, d := range directives {
+		if d.Name != "skip" && d.Name != "include" {
+			return fmt.Errorf("unknown directive @%s", d.Name)
+		}
+		if len(d.Arguments) != 1 || d.Arguments[0].Name != "if" {
+			return fmt.Errorf("directive @%s takes a single \"if\" argument", d.Name)
+		}
+		if err := v.usesDeclaredVariables(d.Arguments[0].Value); err != nil {
+			return err
+		}
+		if _, err := coerceLiteral(NonNullOf(Boolean), d.Arguments[0].Value, v.variables); err != nil {
+			return fmt.Errorf("directive @%s: %w", d.Name, err)
+		}
+	}
+	return nil
+}
+
+func fieldError(field *Field, message string) error {
+	return &Error{Message: message, Locations: []Location{{Line: field.Line, Column: field.Column}}}
+}
@@ -0,0 +1,86 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/api-gateway/internal/gateway"
+	"github.com/MuhibNayem/connectify-v2/api-gateway/internal/graphql"
+	"github.com/gin-gonic/gin"
+)
+
+// maxRequestBytes bounds the size of a GraphQL request body
+const maxRequestBytes = 1 << 20
+
+type GraphQLHandler struct {
+	schema     *graphql.Schema
+	clients    gateway.Clients
+	loaderWait time.Duration
+}
+
+func NewGraphQLHandler(schema *graphql.Schema, clients gateway.Clients, loaderWait time.Duration) *GraphQLHandler {
+	return &GraphQLHandler{schema: schema, clients: clients, loaderWait: loaderWait}
+}
+
+func (h *GraphQLHandler) RegisterRoutes(router *gin.Engine, auth gin.HandlerFunc) {
+	router.GET("/graphql/schema", h.Schema)
+
+	gql := router.Group("/graphql")
+	gql.Use(auth)
+	{
+		gql.POST("", h.Query)
+		gql.GET("", h.Query)
+	}
+}
+
+// Query handles POST /graphql with a JSON body, or GET /graphql with query,
+// operationName and variables as query parameters
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	viewerID := c.GetString("userID")
+	if viewerID == "" {
+		respondWithErrors(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	var req graphql.Request
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if raw := c.Query("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+				respondWithErrors(c, http.StatusBadRequest, "variables must be a JSON object")
+				return
+			}
+		}
+	} else {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxRequestBytes)
+		if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+			respondWithErrors(c, http.StatusBadRequest, "request body must be a JSON object with a query")
+			return
+		}
+	}
+	if req.Query == "" {
+		respondWithErrors(c, http.StatusBadRequest, "query is required")
+		return
+	}
+
+	loaders := gateway.NewLoaders(h.clients, viewerID, h.loaderWait)
+	resp := h.schema.Execute(gateway.WithLoaders(c.Request.Context(), loaders), req)
+
+	// A response without data failed to parse or validate
+	statusCode := http.StatusOK
+	if resp.Data == nil && len(resp.Errors) > 0 {
+		statusCode = http.StatusBadRequest
+	}
+	c.JSON(statusCode, resp)
+}
+
+// Schema handles GET /graphql/schema, returning the schema in SDL
+func (h *GraphQLHandler) Schema(c *gin.Context) {
+	c.String(http.StatusOK, h.schema.SDL())
+}
+
+func respondWithErrors(c *gin.Context, statusCode int, message string) {
+	c.JSON(statusCode, graphql.Response{Errors: []*graphql.Error{{Message: message}}})
+}
@@ -0,0 +1,53 @@
+package httpapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/api-gateway/config"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/middleware"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func BuildRouter(cfg *config.Config, handler *GraphQLHandler, redisClient *redis.ClusterClient) *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.Use(middleware.TracingMiddleware("api-gateway"))
+
+	corsCfg := cors.Config{
+		AllowOrigins:     cfg.CORSAllowedOrigins,
+		AllowMethods:     []string{"GET", "POST", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		ExposeHeaders:    []string{"Content-Length", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	}
+	router.Use(cors.New(corsCfg))
+
+	// Global rate limiter
+	router.Use(middleware.NewSlidingWindowLimiter(redisClient.GetClient(), nil).RateLimiter(
+		cfg.RateLimitEnabled,
+		cfg.RateLimitLimit,
+		cfg.RateLimitBurst,
+		"gateway:global",
+	))
+
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "service": "api-gateway"})
+	})
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	authMiddleware := middleware.AuthMiddleware(
+		cfg.JWTSecret,
+		redisClient.GetClient(),
+		middleware.WithFailClosedResponse(http.StatusServiceUnavailable, "authentication temporarily unavailable"),
+		middleware.WithJWKS(cfg.JWKSURL),
+		middleware.WithLegacyHS256Until(cfg.LegacyHS256Until),
+	)
+
+	handler.RegisterRoutes(router, authMiddleware)
+	return router
+}
@@ -0,0 +1,167 @@
+package loader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchFunc fetches many keys at once. It returns one value and one error per
+// key, in the order of keys; a missing entity is a zero value with no error.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) ([]V, []error)
+
+// Loader collects the keys requested within a short window into one batch
+// and remembers every result, so resolving a list of posts asks for their
+// authors once and in a single call. A Loader lives for one request.
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending *batch[K, V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	results []*result[V]
+	timer   *time.Timer
+}
+
+// New creates a loader that waits up to wait for more keys before fetching,
+// or fetches right away once maxBatch keys are waiting
+func New[K comparable, V any](fetch BatchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		results:  make(map[K]*result[V]),
+	}
+}
+
+// Load returns the value for key, joining the pending batch or a finished one
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	r, ok := l.results[key]
+	if !ok {
+		r = &result[V]{done: make(chan struct{})}
+		l.results[key] = r
+		l.enqueue(ctx, key, r)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// Prime stores a value fetched some other way, such as an item of a list
+// response, so loading its key later needs no call. Keys already loaded or
+// loading are left alone.
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.results[key]; ok {
+		return
+	}
+	r := &result[V]{done: make(chan struct{}), value: value}
+	close(r.done)
+	l.results[key] = r
+}
+
+// LoadMany loads several keys, fetching any not yet loaded in one batch
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, []error) {
+	values := make([]V, len(keys))
+	errs := make([]error, len(keys))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i int, key K) {
+			defer wg.Done()
+			values[i], errs[i] = l.Load(ctx, key)
+		}(i, key)
+	}
+	wg.Wait()
+	return values, errs
+}
+
+// enqueue adds key to the pending batch, starting one if there is none.
+// Callers hold l.mu.
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K, r *result[V]) {
+	if l.pending == nil {
+		b := &batch[K, V]{}
+		b.timer = time.AfterFunc(l.wait, func() { l.dispatch(ctx, b) })
+		l.pending = b
+	}
+	b := l.pending
+	b.keys = append(b.keys, key)
+	b.results = append(b.results, r)
+	if l.maxBatch > 0 && len(b.keys) >= l.maxBatch && b.timer.Stop() {
+		l.pending = nil
+		go l.run(ctx, b)
+	}
+}
+
+func (l *Loader[K, V]) dispatch(ctx context.Context, b *batch[K, V]) {
+	l.mu.Lock()
+	if l.pending == b {
+		l.pending = nil
+	}
+	l.mu.Unlock()
+	l.run(ctx, b)
+}
+
+func (l *Loader[K, V]) run(ctx context.Context, b *batch[K, V]) {
+	values, errs := l.fetch(ctx, b.keys)
+	for i, r := range b.results {
+		if i < len(values) {
+			r.value = values[i]
+		}
+		if i < len(errs) {
+			r.err = errs[i]
+		}
+		close(r.done)
+	}
+}
+
+// FetchEach adapts a single-key lookup into a BatchFunc for services without
+// a batch endpoint, running up to concurrency lookups at a time
+func FetchEach[K comparable, V any](concurrency int, fetch func(ctx context.Context, key K) (V, error)) BatchFunc[K, V] {
+	return func(ctx context.Context, keys []K) ([]V, []error) {
+		values := make([]V, len(keys))
+		errs := make([]error, len(keys))
+		sem := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for i, key := range keys {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int, key K) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				values[i], errs[i] = fetch(ctx, key)
+			}(i, key)
+		}
+		wg.Wait()
+		return values, errs
+	}
+}
+
+// Fill returns a slice of n copies of err, for batch calls that fail as a whole
+func Fill(n int, err error) []error {
+	errs := make([]error, n)
+	for i := range errs {
+		errs[i] = err
+	}
+	return errs
+}
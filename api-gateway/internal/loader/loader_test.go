@@ -0,0 +1,99 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]string
+}
+
+func (r *recorder) fetch(ctx context.Context, keys []string) ([]string, []error) {
+	r.mu.Lock()
+	r.batches = append(r.batches, append([]string(nil), keys...))
+	r.mu.Unlock()
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = "value-" + k
+	}
+	return values, nil
+}
+
+func TestLoader_BatchesConcurrentLoads(t *testing.T) {
+	rec := &recorder{}
+	l := New(rec.fetch, 10*time.Millisecond, 0)
+
+	values, errs := l.LoadMany(context.Background(), []string{"a", "b", "a", "c"})
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"value-a", "value-b", "value-a", "value-c"}, values)
+	require.Len(t, rec.batches, 1)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, rec.batches[0])
+
+	// Cached keys need no further fetch
+	v, err := l.Load(context.Background(), "b")
+	require.NoError(t, err)
+	assert.Equal(t, "value-b", v)
+	assert.Len(t, rec.batches, 1)
+}
+
+func TestLoader_SplitsAtMaxBatch(t *testing.T) {
+	rec := &recorder{}
+	l := New(rec.fetch, time.Hour, 2)
+
+	_, errs := l.LoadMany(context.Background(), []string{"a", "b", "c", "d"})
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Len(t, rec.batches, 2)
+	for _, b := range rec.batches {
+		assert.Len(t, b, 2)
+	}
+}
+
+func TestLoader_PrimeSkipsFetch(t *testing.T) {
+	rec := &recorder{}
+	l := New(rec.fetch, time.Millisecond, 0)
+	l.Prime("a", "primed")
+
+	v, err := l.Load(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, "primed", v)
+	assert.Empty(t, rec.batches)
+}
+
+func TestFetchEach_BoundsConcurrencyAndKeepsErrors(t *testing.T) {
+	var running, peak int32
+	errMissing := errors.New("missing")
+	fetch := FetchEach(2, func(ctx context.Context, key int) (int, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		if key == 3 {
+			return 0, errMissing
+		}
+		return key * 10, nil
+	})
+
+	values, errs := fetch(context.Background(), []int{1, 2, 3, 4})
+	assert.Equal(t, []int{10, 20, 0, 40}, values)
+	assert.ErrorIs(t, errs[2], errMissing)
+	assert.NoError(t, errs[0])
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
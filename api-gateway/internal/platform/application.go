@@ -0,0 +1,171 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/api-gateway/config"
+	"github.com/MuhibNayem/connectify-v2/api-gateway/internal/gateway"
+	"github.com/MuhibNayem/connectify-v2/api-gateway/internal/httpapi"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
+	eventspb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/events/v1"
+	feedpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/feed/v1"
+	marketplacepb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/marketplace/v1"
+	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/resilience"
+	"google.golang.org/grpc"
+)
+
+type Application struct {
+	cfg         *config.Config
+	httpServer  *http.Server
+	redisClient *redis.ClusterClient
+	conns       []namedConn
+}
+
+type namedConn struct {
+	name string
+	conn *grpc.ClientConn
+}
+
+func NewApplication(cfg *config.Config) *Application {
+	return &Application{cfg: cfg}
+}
+
+func (a *Application) Bootstrap() error {
+	if _, err := observability.InitTracer(context.Background(), observability.TracerConfig{
+		ServiceName:    "api-gateway",
+		ServiceVersion: "1.0.0",
+		Environment:    "development", // TODO: Configurable
+		JaegerEndpoint: a.cfg.JaegerOTLPEndpoint,
+	}); err != nil {
+		slog.Error("Failed to initialize tracer", "error", err)
+	}
+
+	if err := a.initRedis(); err != nil {
+		return fmt.Errorf("failed to initialize redis: %w", err)
+	}
+
+	userConn, err := a.dial("user-service", a.cfg.UserServiceHost, a.cfg.UserServicePort)
+	if err != nil {
+		return fmt.Errorf("failed to connect to user service: %w", err)
+	}
+	feedConn, err := a.dial("feed-service", a.cfg.FeedServiceHost, a.cfg.FeedServicePort)
+	if err != nil {
+		return fmt.Errorf("failed to connect to feed service: %w", err)
+	}
+	eventsConn, err := a.dial("events-service", a.cfg.EventsServiceHost, a.cfg.EventsServicePort)
+	if err != nil {
+		return fmt.Errorf("failed to connect to events service: %w", err)
+	}
+	marketplaceConn, err := a.dial("marketplace-service", a.cfg.MarketplaceServiceHost, a.cfg.MarketplaceServicePort)
+	if err != nil {
+		return fmt.Errorf("failed to connect to marketplace service: %w", err)
+	}
+
+	clients := gateway.Clients{
+		Users:       userpb.NewUserServiceClient(userConn),
+		Feed:        feedpb.NewFeedServiceClient(feedConn),
+		Events:      eventspb.NewEventsServiceClient(eventsConn),
+		Marketplace: marketplacepb.NewMarketplaceServiceClient(marketplaceConn),
+	}
+
+	schema, err := gateway.NewSchema(clients, a.cfg.QueryLimits)
+	if err != nil {
+		return fmt.Errorf("failed to build graphql schema: %w", err)
+	}
+
+	handler := httpapi.NewGraphQLHandler(schema, clients, a.cfg.LoaderWait)
+	router := httpapi.BuildRouter(a.cfg, handler, a.redisClient)
+	a.httpServer = &http.Server{
+		Addr:    fmt.Sprintf(":%s", a.cfg.ServerPort),
+		Handler: router,
+	}
+
+	slog.Info("Application bootstrapped successfully")
+	return nil
+}
+
+func (a *Application) Run() error {
+	slog.Info("API gateway HTTP server listening", "port", a.cfg.ServerPort)
+	if err := a.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (a *Application) Shutdown() {
+	slog.Info("Shutting down api-gateway...")
+
+	if a.httpServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := a.httpServer.Shutdown(ctx); err != nil {
+			slog.Error("Error shutting down HTTP server", "error", err)
+		} else {
+			slog.Info("HTTP server stopped")
+		}
+	}
+
+	if a.redisClient != nil {
+		if err := a.redisClient.Close(); err != nil {
+			slog.Error("Error closing Redis connection", "error", err)
+		}
+	}
+
+	for _, c := range a.conns {
+		if err := c.conn.Close(); err != nil {
+			slog.Error("Error closing client connection", "service", c.name, "error", err)
+		}
+	}
+
+	slog.Info("API gateway shutdown complete")
+}
+
+func (a *Application) initRedis() error {
+	cfg := redis.Config{
+		RedisURLs: a.cfg.RedisURLs,
+		RedisPass: a.cfg.RedisPass,
+	}
+	client := redis.NewClusterClient(cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to connect to Redis cluster within timeout")
+		case <-ticker.C:
+			if client.IsAvailable(context.Background()) {
+				a.redisClient = client
+				slog.Info("Connected to Redis cluster")
+				return nil
+			}
+			slog.Warn("Waiting for Redis cluster...")
+		}
+	}
+}
+
+func (a *Application) dial(name, host, port string) (*grpc.ClientConn, error) {
+	conn, err := resilience.NewGRPCClient(name).
+		WithTimeout(10*time.Second).
+		WithRetry(resilience.DefaultRetryPolicy(), resilience.ReadOnlyMethods...).
+		Dial(net.JoinHostPort(host, port))
+	if err != nil {
+		return nil, err
+	}
+
+	a.conns = append(a.conns, namedConn{name: name, conn: conn})
+	slog.Info("Connected to service", "service", name, "host", host, "port", port)
+	return conn, nil
+}
@@ -17,6 +17,8 @@ type Config struct {
 	DBName            string
 	KafkaBrokers      []string
 	JWTSecret         string
+	JWKSURL           string    // Key set access tokens are signed by, published by user-service
+	LegacyHS256Until  time.Time // Until then JWTSecret tokens are still accepted alongside
 	ServerPort        string
 	KafkaTopic        string
 	WebSocketPort     string
@@ -34,6 +36,7 @@ type Config struct {
 	StorageBucket     string
 	StorageUseSSL     bool
 	StoragePublicURL  string
+	PublicAPIURL      string
 	EventsGRPCPort    string
 	EventsGRPCHost    string
 	EventsMetricsPort string
@@ -88,6 +91,8 @@ func LoadConfig() *Config {
 	realtimeGRPCPort := getEnv("REALTIME_GRPC_PORT", "9097")
 	realtimeGRPCHost := getEnv("REALTIME_GRPC_HOST", "localhost")
 
+	legacyHS256Until, _ := time.Parse(time.RFC3339, getEnv("JWT_LEGACY_HS256_UNTIL", ""))
+
 	return &Config{
 		MongoURI:           getEnv("MONGO_URI", "mongodb://localhost:27017"),
 		MongoUser:          getEnv("MONGO_USER", ""),
@@ -95,6 +100,8 @@ func LoadConfig() *Config {
 		DBName:             getEnv("DB_NAME", "messaging_app"),
 		KafkaBrokers:       strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
 		JWTSecret:          getEnv("JWT_SECRET", "very-secret-key"),
+		JWKSURL:            getEnv("JWKS_URL", ""),
+		LegacyHS256Until:   legacyHS256Until,
 		ServerPort:         getEnv("SERVER_PORT", "8080"),
 		KafkaTopic:         getEnv("KAFKA_TOPIC", "messages"),
 		WebSocketPort:      getEnv("WS_PORT", "8081"),
@@ -112,6 +119,7 @@ func LoadConfig() *Config {
 		StorageBucket:      getEnv("STORAGE_BUCKET", "connectify-uploads"),
 		StorageUseSSL:      storageUseSSL,
 		StoragePublicURL:   getEnv("STORAGE_PUBLIC_URL", "http://localhost:9000"),
+		PublicAPIURL:       getEnv("PUBLIC_API_URL", "http://localhost:8080"),
 		EventsGRPCPort:     eventsGRPCPort,
 		EventsGRPCHost:     eventsGRPCHost,
 		EventsMetricsPort:  eventsMetricsPort,
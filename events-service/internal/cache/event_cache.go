@@ -6,13 +6,17 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/cache"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
 )
 
 // EventCache provides caching for event-related data
 type EventCache struct {
-	client *redis.ClusterClient
+	client     *redis.ClusterClient
+	categories *cache.Cache[[]models.EventCategory]
+	trending   *cache.Cache[[]string]
+	calendars  *cache.Cache[string]
 }
 
 // Cache TTL constants
@@ -23,6 +27,9 @@ const (
 	FriendsGoingTTL    = 2 * time.Minute  // Friends going to an event
 	TrendingEventsTTL  = 5 * time.Minute  // Trending events list
 	EventCategoriesTTL = 1 * time.Hour    // Categories with counts
+	CalendarFeedTTL    = 1 * time.Hour    // Rendered calendar subscription feeds
+
+	ttlJitter = 0.1 // ±10% so keys written together don't expire together
 )
 
 // NewEventCache creates a new event cache instance
@@ -30,7 +37,21 @@ func NewEventCache(client *redis.ClusterClient) *EventCache {
 	if client == nil {
 		return nil
 	}
-	return &EventCache{client: client}
+	return &EventCache{
+		client: client,
+		categories: cache.New(client.GetClient(), cache.JSONCodec[[]models.EventCategory]{}, cache.Options{
+			TTL:    EventCategoriesTTL,
+			Jitter: ttlJitter,
+		}),
+		trending: cache.New(client.GetClient(), cache.JSONCodec[[]string]{}, cache.Options{
+			TTL:    TrendingEventsTTL,
+			Jitter: ttlJitter,
+		}),
+		calendars: cache.New(client.GetClient(), cache.StringCodec{}, cache.Options{
+			TTL:    CalendarFeedTTL,
+			Jitter: ttlJitter,
+		}),
+	}
 }
 
 // Key builders
@@ -54,6 +75,10 @@ func categoriesKey() string {
 	return "events:categories"
 }
 
+func calendarFeedKey(userID string) string {
+	return fmt.Sprintf("user:%s:calendar_feed", userID)
+}
+
 // EventStats represents cached event statistics
 type EventStats struct {
 	GoingCount      int64 `json:"going_count"`
@@ -191,16 +216,8 @@ func (c *EventCache) GetTrendingEvents(ctx context.Context) ([]string, error) {
 		return nil, nil
 	}
 
-	data, err := c.client.Get(ctx, trendingEventsKey())
-	if err != nil || data == "" {
-		return nil, nil
-	}
-
-	var eventIDs []string
-	if err := json.Unmarshal([]byte(data), &eventIDs); err != nil {
-		return nil, err
-	}
-	return eventIDs, nil
+	eventIDs, _, err := c.trending.Get(ctx, trendingEventsKey())
+	return eventIDs, err
 }
 
 // SetTrendingEvents caches trending event IDs
@@ -208,41 +225,33 @@ func (c *EventCache) SetTrendingEvents(ctx context.Context, eventIDs []string) e
 	if c == nil {
 		return nil
 	}
-
-	data, err := json.Marshal(eventIDs)
-	if err != nil {
-		return err
-	}
-
-	return c.client.Set(ctx, trendingEventsKey(), data, TrendingEventsTTL)
+	return c.trending.Set(ctx, trendingEventsKey(), eventIDs)
 }
 
-// GetCategories returns cached event categories.
-func (c *EventCache) GetCategories(ctx context.Context) ([]models.EventCategory, error) {
+// LoadCategories returns cached event categories, calling load on a miss.
+// Concurrent misses share a single load so an expired key cannot stampede the
+// aggregation behind it.
+func (c *EventCache) LoadCategories(ctx context.Context, load func(ctx context.Context) ([]models.EventCategory, error)) ([]models.EventCategory, error) {
 	if c == nil {
-		return nil, nil
-	}
-
-	data, err := c.client.Get(ctx, categoriesKey())
-	if err != nil || data == "" {
-		return nil, nil
+		return load(ctx)
 	}
+	return c.categories.GetOrLoad(ctx, categoriesKey(), load)
+}
 
-	var categories []models.EventCategory
-	if err := json.Unmarshal([]byte(data), &categories); err != nil {
-		return nil, err
+// LoadCalendarFeed returns a user's cached calendar feed, calling load to
+// render it on a miss
+func (c *EventCache) LoadCalendarFeed(ctx context.Context, userID string, load func(ctx context.Context) (string, error)) (string, error) {
+	if c == nil {
+		return load(ctx)
 	}
-	return categories, nil
+	return c.calendars.GetOrLoad(ctx, calendarFeedKey(userID), load)
 }
 
-// SetCategories caches event categories with counts.
-func (c *EventCache) SetCategories(ctx context.Context, categories []models.EventCategory) error {
+// InvalidateCalendarFeed drops a user's cached calendar feed so the next
+// fetch renders it again
+func (c *EventCache) InvalidateCalendarFeed(ctx context.Context, userID string) error {
 	if c == nil {
 		return nil
 	}
-	data, err := json.Marshal(categories)
-	if err != nil {
-		return err
-	}
-	return c.client.Set(ctx, categoriesKey(), data, EventCategoriesTTL)
+	return c.calendars.Delete(ctx, calendarFeedKey(userID))
 }
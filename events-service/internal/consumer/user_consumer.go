@@ -91,16 +91,17 @@ func (c *UserConsumer) handleMessage(ctx context.Context, value []byte) error {
 		return err // Send to DLQ
 	}
 
-	return c.processMessage(ctx, objID, event.Username, event.FullName, event.Avatar, event.DateOfBirth)
+	return c.processMessage(ctx, objID, &event)
 }
 
-func (c *UserConsumer) processMessage(ctx context.Context, userID primitive.ObjectID, username, fullName, avatar string, dob *time.Time) error {
+func (c *UserConsumer) processMessage(ctx context.Context, userID primitive.ObjectID, event *events.UserUpdatedEvent) error {
 	user := &integration.EventUser{
 		ID:          userID,
-		Username:    username,
-		FullName:    fullName,
-		Avatar:      avatar,
-		DateOfBirth: dob,
+		Username:    event.Username,
+		FullName:    event.FullName,
+		Avatar:      event.Avatar,
+		DateOfBirth: event.DateOfBirth,
+		Verified:    event.Verified,
 	}
 
 	return c.repo.UpsertUser(ctx, user)
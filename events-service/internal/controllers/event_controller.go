@@ -1,11 +1,16 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/MuhibNayem/connectify-v2/events-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/validation"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 
 	"github.com/gin-gonic/gin"
@@ -73,6 +78,10 @@ func (c *EventController) CreateEvent(ctx *gin.Context) {
 
 	event, err := c.eventService.CreateEvent(ctx, userID, req)
 	if err != nil {
+		if errors.Is(err, moderation.ErrContentRejected) {
+			utils.RespondWithError(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
 		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
 		return
 	}
@@ -119,6 +128,10 @@ func (c *EventController) UpdateEvent(ctx *gin.Context) {
 
 	response, err := c.eventService.UpdateEvent(ctx, eventID, userID, req)
 	if err != nil {
+		if errors.Is(err, moderation.ErrContentRejected) {
+			utils.RespondWithError(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
 		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
 		return
 	}
@@ -139,6 +152,21 @@ func (c *EventController) DeleteEvent(ctx *gin.Context) {
 		return
 	}
 
+	// occurrence_start cancels a single occurrence of a recurring event
+	if raw := ctx.Query("occurrence_start"); raw != "" {
+		occurrenceStart, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid occurrence_start, expected RFC 3339")
+			return
+		}
+		if err := c.eventService.DeleteOccurrence(ctx, eventID, userID, occurrenceStart); err != nil {
+			utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Event occurrence cancelled successfully"})
+		return
+	}
+
 	if err := c.eventService.DeleteEvent(ctx, eventID, userID); err != nil {
 		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
 		return
@@ -211,7 +239,12 @@ func (c *EventController) RSVP(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.eventService.RSVP(ctx, eventID, userID, req.Status); err != nil {
+	if req.OccurrenceStart != nil {
+		err = c.eventService.RSVPOccurrence(ctx, eventID, userID, *req.OccurrenceStart, req.Status)
+	} else {
+		err = c.eventService.RSVP(ctx, eventID, userID, req.Status, req.TierID)
+	}
+	if err != nil {
 		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
 		return
 	}
@@ -219,6 +252,165 @@ func (c *EventController) RSVP(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "RSVP updated successfully"})
 }
 
+// GetTicket returns the current user's ticket, including their waitlist position
+func (c *EventController) GetTicket(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	eventID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	ticket, err := c.eventService.GetTicket(ctx, eventID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrTicketNotFound) {
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Ticket retrieved successfully", "data": ticket})
+}
+
+// GetReminderSettings returns whether the current user gets reminders for an event
+func (c *EventController) GetReminderSettings(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	eventID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	settings, err := c.eventService.GetReminderSettings(ctx, eventID, userID)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Reminder settings retrieved successfully", "data": settings})
+}
+
+// UpdateReminderSettings turns the current user's reminders for an event on or off
+func (c *EventController) UpdateReminderSettings(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	eventID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req models.UpdateEventRemindersRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	settings, err := c.eventService.UpdateReminderSettings(ctx, eventID, userID, *req.Enabled)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Reminder settings updated successfully", "data": settings})
+}
+
+// ExportEventICS downloads an event as an iCalendar file
+func (c *EventController) ExportEventICS(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	eventID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	ics, err := c.eventService.ExportEventICS(ctx, eventID, userID)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="event-`+eventID.Hex()+`.ics"`)
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(ics))
+}
+
+// GetCalendarFeed returns the current user's calendar subscription URL
+func (c *EventController) GetCalendarFeed(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	feed, err := c.eventService.GetCalendarFeed(ctx, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrCalendarFeedNotFound) {
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Calendar feed retrieved successfully", "data": feed})
+}
+
+// RevokeCalendarFeed invalidates the current user's calendar subscription URL
+func (c *EventController) RevokeCalendarFeed(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	if err := c.eventService.RevokeCalendarFeed(ctx, userID); err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Calendar feed revoked successfully"})
+}
+
+// CalendarFeed serves a calendar subscription. Calendar apps cannot send a
+// bearer token, so the token in the URL authenticates the request.
+func (c *EventController) CalendarFeed(ctx *gin.Context) {
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+
+	ics, err := c.eventService.CalendarFeedICS(ctx, token)
+	if err != nil {
+		if errors.Is(err, service.ErrCalendarFeedNotFound) {
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=900")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(ics))
+}
+
 func (c *EventController) GetBirthdays(ctx *gin.Context) {
 	userID, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
@@ -346,6 +538,10 @@ func (c *EventController) CreatePost(ctx *gin.Context) {
 
 	post, err := c.eventService.CreatePost(ctx, eventID, userID, req)
 	if err != nil {
+		if errors.Is(err, moderation.ErrContentRejected) {
+			utils.RespondWithError(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
 		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
 		return
 	}
@@ -489,7 +685,16 @@ func (c *EventController) AddCoHost(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.eventService.AddCoHost(ctx, eventID, userID, coHostID); err != nil {
+	perms := models.CoHostPermDefault
+	if req.Permissions != nil {
+		perms = *req.Permissions
+	}
+
+	if err := c.eventService.AddCoHost(ctx, eventID, userID, coHostID, perms); err != nil {
+		if errors.Is(err, validation.ErrInvalidPermissions) {
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
 		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
 		return
 	}
@@ -525,6 +730,88 @@ func (c *EventController) RemoveCoHost(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "Operation completed successfully"})
 }
 
+// UpdateCoHostPermissions sets what a co-host may do
+func (c *EventController) UpdateCoHostPermissions(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	eventID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	coHostID, err := primitive.ObjectIDFromHex(ctx.Param("userId"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req models.UpdateCoHostPermissionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.eventService.UpdateCoHostPermissions(ctx, eventID, userID, coHostID, *req.Permissions); err != nil {
+		switch {
+		case errors.Is(err, validation.ErrInvalidPermissions):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrNotCoHost):
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+		default:
+			utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Co-host permissions updated successfully"})
+}
+
+// ApproveAttendee lets a user waiting for approval attend
+func (c *EventController) ApproveAttendee(ctx *gin.Context) {
+	c.reviewAttendee(ctx, true)
+}
+
+// DeclineAttendee turns down a user waiting for approval
+func (c *EventController) DeclineAttendee(ctx *gin.Context) {
+	c.reviewAttendee(ctx, false)
+}
+
+func (c *EventController) reviewAttendee(ctx *gin.Context, approve bool) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	eventID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	attendeeID, err := primitive.ObjectIDFromHex(ctx.Param("userId"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := c.eventService.ReviewAttendee(ctx, eventID, userID, attendeeID, approve); err != nil {
+		if errors.Is(err, service.ErrNotPendingApproval) {
+			utils.RespondWithError(ctx, http.StatusConflict, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Attendee reviewed successfully"})
+}
+
 // ================================
 // Categories Endpoint
 // ================================
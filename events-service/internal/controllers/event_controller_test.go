@@ -51,6 +51,11 @@ func (m *MockEventService) DeleteEvent(ctx context.Context, eventID, userID prim
 	return args.Error(0)
 }
 
+func (m *MockEventService) DeleteOccurrence(ctx context.Context, eventID, userID primitive.ObjectID, occurrenceStart time.Time) error {
+	args := m.Called(ctx, eventID, userID, occurrenceStart)
+	return args.Error(0)
+}
+
 func (m *MockEventService) ListEvents(ctx context.Context, userID primitive.ObjectID, limit, page int64, query, category, period string) ([]models.EventResponse, int64, error) {
 	args := m.Called(ctx, userID, limit, page, query, category, period)
 	return args.Get(0).([]models.EventResponse), args.Get(1).(int64), args.Error(2)
@@ -61,13 +66,75 @@ func (m *MockEventService) GetUserEvents(ctx context.Context, userID primitive.O
 	return args.Get(0).([]models.EventResponse), args.Error(1)
 }
 
-func (m *MockEventService) RSVP(ctx context.Context, eventID, userID primitive.ObjectID, status models.RSVPStatus) error {
-	args := m.Called(ctx, eventID, userID, status)
+func (m *MockEventService) RSVP(ctx context.Context, eventID, userID primitive.ObjectID, status models.RSVPStatus, tierID string) error {
+	args := m.Called(ctx, eventID, userID, status, tierID)
 	return args.Error(0)
 }
 
-func (m *MockEventService) AddCoHost(ctx context.Context, eventID, userID, coHostID primitive.ObjectID) error {
-	args := m.Called(ctx, eventID, userID, coHostID)
+func (m *MockEventService) GetTicket(ctx context.Context, eventID, userID primitive.ObjectID) (*models.EventTicketResponse, error) {
+	args := m.Called(ctx, eventID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EventTicketResponse), args.Error(1)
+}
+
+func (m *MockEventService) ExportEventICS(ctx context.Context, eventID, viewerID primitive.ObjectID) (string, error) {
+	args := m.Called(ctx, eventID, viewerID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockEventService) GetCalendarFeed(ctx context.Context, userID primitive.ObjectID) (*models.CalendarFeedResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CalendarFeedResponse), args.Error(1)
+}
+
+func (m *MockEventService) RevokeCalendarFeed(ctx context.Context, userID primitive.ObjectID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockEventService) CalendarFeedICS(ctx context.Context, token string) (string, error) {
+	args := m.Called(ctx, token)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockEventService) GetReminderSettings(ctx context.Context, eventID, userID primitive.ObjectID) (*models.EventReminderSettings, error) {
+	args := m.Called(ctx, eventID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EventReminderSettings), args.Error(1)
+}
+
+func (m *MockEventService) UpdateReminderSettings(ctx context.Context, eventID, userID primitive.ObjectID, enabled bool) (*models.EventReminderSettings, error) {
+	args := m.Called(ctx, eventID, userID, enabled)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EventReminderSettings), args.Error(1)
+}
+
+func (m *MockEventService) RSVPOccurrence(ctx context.Context, eventID, userID primitive.ObjectID, occurrenceStart time.Time, status models.RSVPStatus) error {
+	args := m.Called(ctx, eventID, userID, occurrenceStart, status)
+	return args.Error(0)
+}
+
+func (m *MockEventService) AddCoHost(ctx context.Context, eventID, userID, coHostID primitive.ObjectID, perms models.CoHostPermission) error {
+	args := m.Called(ctx, eventID, userID, coHostID, perms)
+	return args.Error(0)
+}
+
+func (m *MockEventService) UpdateCoHostPermissions(ctx context.Context, eventID, userID, coHostID primitive.ObjectID, perms models.CoHostPermission) error {
+	args := m.Called(ctx, eventID, userID, coHostID, perms)
+	return args.Error(0)
+}
+
+func (m *MockEventService) ReviewAttendee(ctx context.Context, eventID, reviewerID, attendeeID primitive.ObjectID, approve bool) error {
+	args := m.Called(ctx, eventID, reviewerID, attendeeID, approve)
 	return args.Error(0)
 }
 
@@ -399,7 +466,7 @@ func TestEventController_RSVP_Success(t *testing.T) {
 	eventID := primitive.NewObjectID()
 
 	// "going" is a valid status, "attending" is not
-	mockEventService.On("RSVP", mock.Anything, eventID, userID, models.RSVPStatus("going")).Return(nil)
+	mockEventService.On("RSVP", mock.Anything, eventID, userID, models.RSVPStatus("going"), "").Return(nil)
 
 	w := httptest.NewRecorder()
 	router := gin.New()
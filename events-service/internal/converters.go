@@ -108,6 +108,7 @@ func toProtoUserShort(user models.UserShort) *eventspb.UserShort {
 		Username: user.Username,
 		FullName: user.FullName,
 		Avatar:   user.Avatar,
+		Verified: user.Verified,
 	}
 }
 
@@ -153,7 +153,7 @@ func (s *Server) RSVP(ctx context.Context, req *eventspb.RSVPRequest) (*emptypb.
 		return nil, err
 	}
 
-	if err := s.eventService.RSVP(ctx, eventID, userID, models.RSVPStatus(req.Status)); err != nil {
+	if err := s.eventService.RSVP(ctx, eventID, userID, models.RSVPStatus(req.Status), ""); err != nil {
 		return nil, err
 	}
 	return &emptypb.Empty{}, nil
@@ -400,7 +400,7 @@ func (s *Server) AddCoHost(ctx context.Context, req *eventspb.CoHostRequest) (*e
 	if err != nil {
 		return nil, err
 	}
-	if err := s.eventService.AddCoHost(ctx, eventID, userID, coHostID); err != nil {
+	if err := s.eventService.AddCoHost(ctx, eventID, userID, coHostID, models.CoHostPermDefault); err != nil {
 		return nil, err
 	}
 	return &emptypb.Empty{}, nil
@@ -15,6 +15,7 @@ type EventUser struct {
 	FullName             string               `bson:"full_name,omitempty" json:"full_name,omitempty"`
 	Avatar               string               `bson:"avatar" json:"avatar"`
 	DateOfBirth          *time.Time           `bson:"date_of_birth,omitempty" json:"date_of_birth,omitempty"`
+	Verified             bool                 `bson:"verified" json:"verified"`
 	Friends              []primitive.ObjectID `bson:"friends,omitempty" json:"friends,omitempty"`
 	NotificationSettings NotificationSettings `bson:"notification_settings,omitempty" json:"notification_settings,omitempty"`
 }
@@ -22,7 +22,10 @@ import (
 	"github.com/MuhibNayem/connectify-v2/events-service/internal/repository"
 	"github.com/MuhibNayem/connectify-v2/events-service/internal/service"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
 	pkgkafka "github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
 
@@ -49,8 +52,11 @@ type Application struct {
 
 	dlqProducer   *pkgkafka.DLQProducer
 	eventProducer *producer.EventProducer
+	searchIndexer *pkgkafka.SearchIndexPublisher
+	deletions     *pkgkafka.UserDeletionParticipant
 
 	eventService  *service.EventService
+	moderator     *moderation.Moderator
 	mainRouter    *gin.Engine
 	httpServer    *http.Server
 	metricsServer *http.Server
@@ -107,6 +113,10 @@ func (a *Application) Run() error {
 		}
 	}()
 
+	go a.eventService.RunReminderWorker(a.ctx)
+	go a.moderator.Run(a.ctx)
+	go a.deletions.Run(a.ctx)
+
 	select {
 	case <-quit:
 		slog.Info("Received shutdown signal")
@@ -154,6 +164,12 @@ func (a *Application) Close() {
 	if a.eventProducer != nil {
 		a.eventProducer.Close()
 	}
+	if a.searchIndexer != nil {
+		_ = a.searchIndexer.Close()
+	}
+	if a.deletions != nil {
+		_ = a.deletions.Close()
+	}
 	if a.neo4jClient != nil {
 		_ = a.neo4jClient.Close(context.Background())
 	}
@@ -203,6 +219,18 @@ func (a *Application) bootstrap() error {
 	eventGraphRepo := repository.NewEventGraphRepository(a.neo4jClient.Driver)
 	eventInvitationRepo := repository.NewEventInvitationRepository(a.db)
 	eventPostRepo := repository.NewEventPostRepository(a.db)
+	eventTicketRepo, err := repository.NewEventTicketRepository(a.db)
+	if err != nil {
+		return err
+	}
+	calendarFeedRepo, err := repository.NewCalendarFeedRepository(a.db)
+	if err != nil {
+		return err
+	}
+	eventReminderRepo, err := repository.NewEventReminderRepository(a.db)
+	if err != nil {
+		return err
+	}
 	friendshipRepo := integration.NewFriendshipLocalRepository(a.db)
 
 	notificationProducer := producer.NewNotificationProducer(a.cfg.KafkaBrokers, "notifications")
@@ -226,6 +254,18 @@ func (a *Application) bootstrap() error {
 		breaker,
 		businessMetrics,
 	)
+	a.searchIndexer = pkgkafka.NewSearchIndexPublisher(a.cfg.KafkaBrokers)
+	a.eventService.SetSearchIndexer(a.searchIndexer)
+	a.eventService.SetBlockChecker(eventGraphRepo)
+	a.eventService.SetTicketRepo(eventTicketRepo)
+	a.eventService.SetCalendarFeeds(calendarFeedRepo, a.cfg.PublicAPIURL)
+	a.eventService.SetReminderRepo(eventReminderRepo)
+	// Rules and the review queue live in the database shared with messaging-app,
+	// which serves the moderation admin API
+	a.moderator = moderation.NewModerator(moderation.NewStore(a.db), serviceLogger)
+	a.moderator.Handle(models.ModeratedEvent, a.eventService)
+	a.moderator.Handle(models.ModeratedEventPost, a.eventService)
+	a.eventService.SetModerator(a.moderator)
 
 	eventRecommendationService := service.NewEventRecommendationService(
 		eventRepo,
@@ -261,6 +301,8 @@ func (a *Application) bootstrap() error {
 	)
 	grpcEventHandler := eventgrpc.NewServer(a.eventService, eventRecommendationService)
 	eventspb.RegisterEventsServiceServer(a.grpcServer, grpcEventHandler)
+	dataexport.NewServer(models.ErasureServiceEvents, a.eventService.ExportUserData).Register(a.grpcServer)
+	a.deletions = pkgkafka.NewUserDeletionParticipant(a.cfg.KafkaBrokers, models.ErasureServiceEvents, a.eventService.DeleteUserData)
 
 	return nil
 }
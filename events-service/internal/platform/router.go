@@ -32,20 +32,19 @@ func (a *Application) buildRouters(cfg RouterConfig) *gin.Engine {
 	corsConfig := cors.Config{
 		AllowOrigins:     allowedOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Idempotency-Key"},
+		ExposeHeaders:    []string{"Content-Length", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
 	router.Use(cors.New(corsConfig))
 
 	// Rate Limiter
-	router.Use(middleware.RateLimiter(
+	router.Use(middleware.NewSlidingWindowLimiter(a.redisClient.GetClient(), a.recordRateLimitHit).RateLimiter(
 		a.cfg.RateLimitEnabled,
 		a.cfg.RateLimitLimit,
 		a.cfg.RateLimitBurst,
 		"events:global",
-		a.recordRateLimitHit,
 	))
 
 	a.registerHealthRoutes(router)
@@ -74,8 +73,16 @@ func (a *Application) registerAPIRoutes(router *gin.Engine, cfg RouterConfig) {
 		a.cfg.JWTSecret,
 		a.redisClusterClient(),
 		middleware.WithFailClosedResponse(http.StatusServiceUnavailable, "authentication temporarily unavailable"),
+		middleware.WithJWKS(a.cfg.JWKSURL),
+		middleware.WithLegacyHS256Until(a.cfg.LegacyHS256Until),
 	)
-	api := router.Group("/api", authMiddleware)
+	// Calendar apps authenticate subscriptions by the token in the URL
+	router.GET("/api/events/calendar/feeds/:token", a.eventActionLimiter(middleware.SearchRateLimit), cfg.EventController.CalendarFeed)
+
+	// Retried RSVPs and posts replay the first response instead of
+	// creating duplicates
+	idempotency := middleware.NewIdempotency(a.redisClient.GetClient(), middleware.DefaultIdempotencyTTL)
+	api := router.Group("/api", authMiddleware, idempotency.Handle())
 
 	eventGroup := api.Group("/events")
 	{
@@ -88,17 +95,26 @@ func (a *Application) registerAPIRoutes(router *gin.Engine, cfg RouterConfig) {
 		eventGroup.GET("/trending", a.eventActionLimiter(middleware.TrendingRateLimit), cfg.EventController.GetTrending)
 		eventGroup.GET("/search", a.eventActionLimiter(middleware.SearchRateLimit), cfg.EventController.SearchEvents)
 		eventGroup.GET("/nearby", a.eventActionLimiter(middleware.SearchRateLimit), cfg.EventController.GetNearbyEvents)
+		eventGroup.GET("/calendar/feed", a.eventActionLimiter(middleware.SearchRateLimit), cfg.EventController.GetCalendarFeed)
+		eventGroup.DELETE("/calendar/feed", a.eventActionLimiter(middleware.CreateEventRateLimit), cfg.EventController.RevokeCalendarFeed)
 		eventGroup.GET("/invitations", a.eventActionLimiter(middleware.SearchRateLimit), cfg.EventController.GetInvitations)
 		eventGroup.POST("/invitations/:id/respond", a.eventActionLimiter(middleware.RSVPRateLimit), cfg.EventController.RespondToInvitation)
 		eventGroup.GET("/:id", a.eventActionLimiter(middleware.SearchRateLimit), cfg.EventController.GetEvent)
 		eventGroup.PUT("/:id", a.eventActionLimiter(middleware.CreateEventRateLimit), cfg.EventController.UpdateEvent)
 		eventGroup.DELETE("/:id", a.eventActionLimiter(middleware.CreateEventRateLimit), cfg.EventController.DeleteEvent)
 		eventGroup.POST("/:id/rsvp", a.eventActionLimiter(middleware.RSVPRateLimit), cfg.EventController.RSVP)
+		eventGroup.GET("/:id/ticket", a.eventActionLimiter(middleware.SearchRateLimit), cfg.EventController.GetTicket)
+		eventGroup.GET("/:id/ics", a.eventActionLimiter(middleware.SearchRateLimit), cfg.EventController.ExportEventICS)
+		eventGroup.GET("/:id/reminders", a.eventActionLimiter(middleware.SearchRateLimit), cfg.EventController.GetReminderSettings)
+		eventGroup.PUT("/:id/reminders", a.eventActionLimiter(middleware.RSVPRateLimit), cfg.EventController.UpdateReminderSettings)
 		eventGroup.POST("/:id/share", a.eventActionLimiter(middleware.InviteRateLimit), cfg.EventController.ShareEvent)
 		eventGroup.POST("/:id/invite", a.eventActionLimiter(middleware.InviteRateLimit), cfg.EventController.InviteFriends)
 		eventGroup.GET("/:id/attendees", a.eventActionLimiter(middleware.SearchRateLimit), cfg.EventController.GetAttendees)
 		eventGroup.POST("/:id/co-hosts", a.eventActionLimiter(middleware.CreateEventRateLimit), cfg.EventController.AddCoHost)
 		eventGroup.DELETE("/:id/co-hosts/:userId", a.eventActionLimiter(middleware.CreateEventRateLimit), cfg.EventController.RemoveCoHost)
+		eventGroup.PUT("/:id/co-hosts/:userId/permissions", a.eventActionLimiter(middleware.CreateEventRateLimit), cfg.EventController.UpdateCoHostPermissions)
+		eventGroup.POST("/:id/attendees/:userId/approve", a.eventActionLimiter(middleware.RSVPRateLimit), cfg.EventController.ApproveAttendee)
+		eventGroup.POST("/:id/attendees/:userId/decline", a.eventActionLimiter(middleware.RSVPRateLimit), cfg.EventController.DeclineAttendee)
 		eventGroup.POST("/:id/posts", a.eventActionLimiter(middleware.EventPostRateLimit), cfg.EventController.CreatePost)
 		eventGroup.GET("/:id/posts", a.eventActionLimiter(middleware.SearchRateLimit), cfg.EventController.GetPosts)
 		eventGroup.DELETE("/:id/posts/:postId", a.eventActionLimiter(middleware.CreateEventRateLimit), cfg.EventController.DeletePost)
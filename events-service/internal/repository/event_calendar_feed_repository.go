@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CalendarFeedRepository struct {
+	collection *mongo.Collection
+}
+
+// NewCalendarFeedRepository fails when the indexes cannot be created, since
+// the unique token index is what keeps two users from sharing a feed URL
+func NewCalendarFeedRepository(db *mongo.Database) (*CalendarFeedRepository, error) {
+	collection := db.Collection("event_calendar_feeds")
+
+	_, err := collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "token", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create calendar feed indexes: %w", err)
+	}
+
+	return &CalendarFeedRepository{
+		collection: collection,
+	}, nil
+}
+
+// GetOrCreate returns the user's feed, creating it with token if they have none
+func (r *CalendarFeedRepository) GetOrCreate(ctx context.Context, userID primitive.ObjectID, token string) (*models.CalendarFeed, error) {
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"token":      token,
+			"created_at": time.Now(),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var feed models.CalendarFeed
+	if err := r.collection.FindOneAndUpdate(ctx, bson.M{"user_id": userID}, update, opts).Decode(&feed); err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// GetByToken returns the feed with the given token, or nil if there is none
+func (r *CalendarFeedRepository) GetByToken(ctx context.Context, token string) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := r.collection.FindOne(ctx, bson.M{"token": token}).Decode(&feed)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// DeleteByUser removes a user's feed
+func (r *CalendarFeedRepository) DeleteByUser(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID})
+	return err
+}
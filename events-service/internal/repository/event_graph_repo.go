@@ -255,3 +255,23 @@ func (r *EventGraphRepository) GetMutualFriendsCount(ctx context.Context, userID
 	}
 	return 0, nil
 }
+
+// GetBlockedIDs returns the users that userID blocked or was blocked by.
+// BLOCKED edges are written by user-service.
+func (r *EventGraphRepository) GetBlockedIDs(ctx context.Context, userID string) ([]string, error) {
+	query := `MATCH (u:User {id: $userID})-[:BLOCKED]-(b:User) RETURN DISTINCT b.id`
+	params := map[string]any{"userID": userID}
+
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, record := range result.Records {
+		if id, ok := record.Values[0].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
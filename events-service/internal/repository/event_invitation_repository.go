@@ -184,3 +184,15 @@ func (r *EventInvitationRepository) DeleteByEventAndInvitee(ctx context.Context,
 	})
 	return err
 }
+
+// DeleteByUser removes every invitation a user sent or received
+func (r *EventInvitationRepository) DeleteByUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	res, err := r.collection.DeleteMany(ctx, bson.M{"$or": []bson.M{
+		{"inviter_id": userID},
+		{"invitee_id": userID},
+	}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
@@ -100,6 +100,22 @@ func (r *EventPostRepository) GetByEventID(ctx context.Context, eventID primitiv
 	return posts, total, nil
 }
 
+// GetByAuthorID returns every post a user wrote, newest first
+func (r *EventPostRepository) GetByAuthorID(ctx context.Context, authorID primitive.ObjectID) ([]models.EventPost, error) {
+	opts := options.Find().SetSort(bson.M{"created_at": -1})
+	cursor, err := r.collection.Find(ctx, bson.M{"author_id": authorID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var posts []models.EventPost
+	if err = cursor.All(ctx, &posts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
 func (r *EventPostRepository) Update(ctx context.Context, post *models.EventPost) error {
 	post.UpdatedAt = time.Now()
 	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": post.ID}, post)
@@ -118,6 +134,30 @@ func (r *EventPostRepository) DeleteByEventID(ctx context.Context, eventID primi
 	return err
 }
 
+// DeleteByAuthorID removes every post a user wrote
+func (r *EventPostRepository) DeleteByAuthorID(ctx context.Context, authorID primitive.ObjectID) (int64, error) {
+	res, err := r.collection.DeleteMany(ctx, bson.M{"author_id": authorID})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// RemoveUserReactions removes a user's reactions from every post
+func (r *EventPostRepository) RemoveUserReactions(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	res, err := r.collection.UpdateMany(ctx,
+		bson.M{"reactions.user_id": userID},
+		bson.M{
+			"$pull": bson.M{"reactions": bson.M{"user_id": userID}},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
 // AddReaction adds a reaction to a post
 func (r *EventPostRepository) AddReaction(ctx context.Context, postID primitive.ObjectID, reaction models.EventPostReaction) error {
 
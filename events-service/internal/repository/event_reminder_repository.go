@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// completedReminderTTL is how long sent and skipped reminders are kept
+const completedReminderTTL = 30 * 24 * time.Hour
+
+// EventReminderRepository stores reminder jobs and the users who opted out
+// of reminders for an event
+type EventReminderRepository struct {
+	collection *mongo.Collection
+	optOuts    *mongo.Collection
+}
+
+// NewEventReminderRepository fails when the indexes cannot be created, since
+// the unique job and opt-out indexes are what keep reminders from being sent
+// twice
+func NewEventReminderRepository(db *mongo.Database) (*EventReminderRepository, error) {
+	collection := db.Collection("event_reminders")
+	optOuts := db.Collection("event_reminder_opt_outs")
+
+	_, err := collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		// One job per reminder kind per occurrence
+		{
+			Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "occurrence_start", Value: 1}, {Key: "kind", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		// Due scan
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "due_at", Value: 1}},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.D{{Key: "completed_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(completedReminderTTL.Seconds())),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event reminder indexes: %w", err)
+	}
+
+	_, err = optOuts.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "user_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event reminder opt-out indexes: %w", err)
+	}
+
+	return &EventReminderRepository{
+		collection: collection,
+		optOuts:    optOuts,
+	}, nil
+}
+
+// Schedule creates a pending reminder, or moves a pending one to its new due
+// time. Reminders already sent or skipped are left alone.
+func (r *EventReminderRepository) Schedule(ctx context.Context, reminder *models.EventReminder) error {
+	filter := bson.M{
+		"event_id":         reminder.EventID,
+		"occurrence_start": reminder.OccurrenceStart,
+		"kind":             reminder.Kind,
+		"status":           models.EventReminderPending,
+	}
+	update := bson.M{
+		"$set": bson.M{"due_at": reminder.DueAt},
+		"$setOnInsert": bson.M{
+			"attempts":   0,
+			"created_at": time.Now(),
+		},
+	}
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// ClaimDue leases the next due reminder to the caller so concurrent workers
+// never send the same one. It returns nil when nothing is due.
+func (r *EventReminderRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, maxAttempts int) (*models.EventReminder, error) {
+	filter := bson.M{
+		"status":   models.EventReminderPending,
+		"due_at":   bson.M{"$lte": now},
+		"attempts": bson.M{"$lt": maxAttempts},
+		"$or": []bson.M{
+			{"locked_until": nil},
+			{"locked_until": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{"locked_until": now.Add(lease)},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "due_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var reminder models.EventReminder
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&reminder)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// Complete marks a claimed reminder sent or skipped
+func (r *EventReminderRepository) Complete(ctx context.Context, id primitive.ObjectID, status models.EventReminderStatus) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set":   bson.M{"status": status, "completed_at": time.Now()},
+		"$unset": bson.M{"locked_until": ""},
+	})
+	return err
+}
+
+// Reschedule releases a claimed reminder to come due again at dueAt
+func (r *EventReminderRepository) Reschedule(ctx context.Context, id primitive.ObjectID, dueAt time.Time) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set":   bson.M{"due_at": dueAt, "attempts": 0},
+		"$unset": bson.M{"locked_until": ""},
+	})
+	return err
+}
+
+// DeletePending removes an event's unsent reminders
+func (r *EventReminderRepository) DeletePending(ctx context.Context, eventID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"event_id": eventID, "status": models.EventReminderPending})
+	return err
+}
+
+// DeleteByEventID removes an event's reminders and opt-outs
+func (r *EventReminderRepository) DeleteByEventID(ctx context.Context, eventID primitive.ObjectID) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"event_id": eventID}); err != nil {
+		return err
+	}
+	_, err := r.optOuts.DeleteMany(ctx, bson.M{"event_id": eventID})
+	return err
+}
+
+// SetOptOut records whether a user opted out of an event's reminders
+func (r *EventReminderRepository) SetOptOut(ctx context.Context, eventID, userID primitive.ObjectID, optOut bool) error {
+	filter := bson.M{"event_id": eventID, "user_id": userID}
+	if !optOut {
+		_, err := r.optOuts.DeleteOne(ctx, filter)
+		return err
+	}
+	update := bson.M{"$setOnInsert": bson.M{"created_at": time.Now()}}
+	_, err := r.optOuts.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// IsOptedOut reports whether a user opted out of an event's reminders
+func (r *EventReminderRepository) IsOptedOut(ctx context.Context, eventID, userID primitive.ObjectID) (bool, error) {
+	count, err := r.optOuts.CountDocuments(ctx, bson.M{"event_id": eventID, "user_id": userID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// OptedOutUsers returns the users who opted out of an event's reminders
+func (r *EventReminderRepository) OptedOutUsers(ctx context.Context, eventID primitive.ObjectID) (map[primitive.ObjectID]bool, error) {
+	cursor, err := r.optOuts.Find(ctx, bson.M{"event_id": eventID}, options.Find().SetProjection(bson.M{"user_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		UserID primitive.ObjectID `bson:"user_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	users := make(map[primitive.ObjectID]bool, len(docs))
+	for _, doc := range docs {
+		users[doc.UserID] = true
+	}
+	return users, nil
+}
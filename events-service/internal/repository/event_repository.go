@@ -97,9 +97,41 @@ func (r *EventRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*
 	return &event, nil
 }
 
+// Update replaces the event document. The stored seat counter is kept so a
+// reservation made since the event was read is not overwritten.
 func (r *EventRepository) Update(ctx context.Context, event *models.Event) error {
 	event.UpdatedAt = time.Now()
-	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": event.ID}, event)
+	pipeline := mongo.Pipeline{{{Key: "$replaceWith", Value: bson.M{
+		"$mergeObjects": bson.A{
+			bson.M{"$literal": event},
+			bson.M{"seats_taken": bson.M{"$ifNull": bson.A{"$seats_taken", 0}}},
+		},
+	}}}}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": event.ID}, pipeline)
+	return err
+}
+
+// ReserveSeat atomically takes a seat if the event has one left. It reports
+// false when the event is at capacity.
+func (r *EventRepository) ReserveSeat(ctx context.Context, eventID primitive.ObjectID) (bool, error) {
+	filter := bson.M{
+		"_id": eventID,
+		"$expr": bson.M{"$or": bson.A{
+			bson.M{"$lte": bson.A{bson.M{"$ifNull": bson.A{"$capacity", 0}}, 0}},
+			bson.M{"$lt": bson.A{bson.M{"$ifNull": bson.A{"$seats_taken", 0}}, "$capacity"}},
+		}},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"seats_taken": 1}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// ReleaseSeat gives back a seat taken with ReserveSeat
+func (r *EventRepository) ReleaseSeat(ctx context.Context, eventID primitive.ObjectID) error {
+	filter := bson.M{"_id": eventID, "seats_taken": bson.M{"$gt": 0}}
+	_, err := r.collection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"seats_taken": -1}})
 	return err
 }
 
@@ -161,6 +193,23 @@ func (r *EventRepository) AddOrUpdateAttendee(ctx context.Context, eventID primi
 	return nil
 }
 
+// SetOccurrenceRSVP records a user's RSVP for one occurrence of a recurring
+// event, replacing any earlier RSVP they gave for it
+func (r *EventRepository) SetOccurrenceRSVP(ctx context.Context, eventID primitive.ObjectID, rsvp models.OccurrenceRSVP) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": eventID}, bson.M{
+		"$pull": bson.M{"occurrence_rsvps": bson.M{"user_id": rsvp.UserID, "occurrence_start": rsvp.OccurrenceStart}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": eventID}, bson.M{
+		"$push": bson.M{"occurrence_rsvps": rsvp},
+		"$set":  bson.M{"updated_at": time.Now()},
+	})
+	return err
+}
+
 func (r *EventRepository) RemoveAttendee(ctx context.Context, eventID, userID primitive.ObjectID) error {
 	filter := bson.M{"_id": eventID}
 	update := bson.M{
@@ -171,6 +220,29 @@ func (r *EventRepository) RemoveAttendee(ctx context.Context, eventID, userID pr
 	return err
 }
 
+// RemoveUser takes a deleted user off every event's guest list, occurrence
+// RSVPs and co-hosts. Returns how many events changed.
+func (r *EventRepository) RemoveUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	filter := bson.M{"$or": []bson.M{
+		{"attendees.user_id": userID},
+		{"occurrence_rsvps.user_id": userID},
+		{"co_hosts.user_id": userID},
+	}}
+	update := bson.M{
+		"$pull": bson.M{
+			"attendees":        bson.M{"user_id": userID},
+			"occurrence_rsvps": bson.M{"user_id": userID},
+			"co_hosts":         bson.M{"user_id": userID},
+		},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+	res, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
 func (r *EventRepository) UpdateStats(ctx context.Context, eventID primitive.ObjectID, stats models.EventStats) error {
 	// Usually stats should be re-calculated or atomically incremented.
 	// For simplicity in this "best way" request, we might want to recalculate counts
@@ -305,6 +377,22 @@ func (r *EventRepository) RemoveCoHost(ctx context.Context, eventID, userID prim
 	return err
 }
 
+// SetCoHostPermissions replaces a co-host's permissions. It reports false if
+// the user is not a co-host.
+func (r *EventRepository) SetCoHostPermissions(ctx context.Context, eventID, userID primitive.ObjectID, perms models.CoHostPermission) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": eventID, "co_hosts.user_id": userID},
+		bson.M{"$set": bson.M{
+			"co_hosts.$.permissions": perms,
+			"updated_at":             time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
 // IsCoHost checks if a user is a co-host of the event
 func (r *EventRepository) IsCoHost(ctx context.Context, eventID, userID primitive.ObjectID) (bool, error) {
 	count, err := r.collection.CountDocuments(ctx, bson.M{
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type EventTicketRepository struct {
+	collection *mongo.Collection
+}
+
+// NewEventTicketRepository fails when the indexes cannot be created, since
+// ticket issuance relies on the unique (event_id, user_id) index to reject
+// duplicates
+func NewEventTicketRepository(db *mongo.Database) (*EventTicketRepository, error) {
+	collection := db.Collection("event_tickets")
+
+	_, err := collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		// One ticket per user per event
+		{
+			Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		// Waitlist order and per-status counts
+		{
+			Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "status", Value: 1}, {Key: "queued_at", Value: 1}},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "status", Value: 1}},
+			Options: options.Index(),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event ticket indexes: %w", err)
+	}
+
+	return &EventTicketRepository{
+		collection: collection,
+	}, nil
+}
+
+// GetByEventAndUser returns a user's ticket for an event, or nil if they never had one
+func (r *EventTicketRepository) GetByEventAndUser(ctx context.Context, eventID, userID primitive.ObjectID) (*models.EventTicket, error) {
+	var ticket models.EventTicket
+	err := r.collection.FindOne(ctx, bson.M{
+		"event_id": eventID,
+		"user_id":  userID,
+	}).Decode(&ticket)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// Issue stores a new ticket, reusing the user's cancelled ticket if they had
+// one. It reports false when the user already holds a live ticket.
+func (r *EventTicketRepository) Issue(ctx context.Context, ticket *models.EventTicket) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"event_id": ticket.EventID,
+		"user_id":  ticket.UserID,
+		"status":   models.TicketStatusCancelled,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"tier_id":    ticket.TierID,
+			"status":     ticket.Status,
+			"queued_at":  now,
+			"updated_at": now,
+		},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(ticket)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Transition moves a ticket from one status to another, reporting false if
+// the ticket was no longer in the from status
+func (r *EventTicketRepository) Transition(ctx context.Context, id primitive.ObjectID, from, to models.EventTicketStatus) (bool, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"status":     to,
+			"updated_at": time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "status": from}, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// ListByUser returns every ticket a user holds or held
+func (r *EventTicketRepository) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.EventTicket, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tickets []models.EventTicket
+	if err = cursor.All(ctx, &tickets); err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+// NextWaitlisted returns the ticket at the head of an event's waitlist, or nil
+// when nobody is waiting
+func (r *EventTicketRepository) NextWaitlisted(ctx context.Context, eventID primitive.ObjectID) (*models.EventTicket, error) {
+	var ticket models.EventTicket
+	opts := options.FindOne().SetSort(bson.D{{Key: "queued_at", Value: 1}, {Key: "_id", Value: 1}})
+	err := r.collection.FindOne(ctx, bson.M{
+		"event_id": eventID,
+		"status":   models.TicketStatusWaitlisted,
+	}, opts).Decode(&ticket)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// WaitlistPosition returns the 1-based place of a waitlisted ticket in its event's queue
+func (r *EventTicketRepository) WaitlistPosition(ctx context.Context, ticket *models.EventTicket) (int64, error) {
+	ahead, err := r.collection.CountDocuments(ctx, bson.M{
+		"event_id": ticket.EventID,
+		"status":   models.TicketStatusWaitlisted,
+		"$or": []bson.M{
+			{"queued_at": bson.M{"$lt": ticket.QueuedAt}},
+			{"queued_at": ticket.QueuedAt, "_id": bson.M{"$lt": ticket.ID}},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return ahead + 1, nil
+}
+
+// CountByStatus counts an event's tickets in a status
+func (r *EventTicketRepository) CountByStatus(ctx context.Context, eventID primitive.ObjectID, status models.EventTicketStatus) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{
+		"event_id": eventID,
+		"status":   status,
+	})
+}
+
+// DeleteByEventID removes every ticket for an event
+func (r *EventTicketRepository) DeleteByEventID(ctx context.Context, eventID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"event_id": eventID})
+	return err
+}
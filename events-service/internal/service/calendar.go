@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	calendarFeedPath    = "/api/events/calendar/feeds/"
+	calendarFeedRefresh = time.Hour
+	// calendarFeedHistory is how far back a feed keeps events the user went to
+	calendarFeedHistory = 180 * 24 * time.Hour
+	calendarFeedLimit   = 500
+)
+
+var ErrCalendarFeedNotFound = apperrors.NotFound("calendar feed not found")
+
+// SetCalendarFeeds enables calendar subscriptions, served under baseURL
+func (s *EventService) SetCalendarFeeds(feeds CalendarFeedRepo, baseURL string) {
+	s.calendarFeeds = feeds
+	s.calendarBaseURL = strings.TrimRight(baseURL, "/")
+}
+
+// ExportEventICS returns an event as an iCalendar file
+func (s *EventService) ExportEventICS(ctx context.Context, eventID, viewerID primitive.ObjectID) (string, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return "", err
+	}
+
+	if event.Privacy == models.EventPrivacyPrivate {
+		if !s.canAccessPrivateEvent(ctx, event, viewerID) {
+			return "", errors.New("unauthorized: you do not have access to this private event")
+		}
+	}
+
+	cal := newICSCalendar("", 0)
+	cal.addEvent(event, viewerID)
+	return cal.finish(), nil
+}
+
+// GetCalendarFeed returns the user's calendar subscription, creating it on first use
+func (s *EventService) GetCalendarFeed(ctx context.Context, userID primitive.ObjectID) (*models.CalendarFeedResponse, error) {
+	if s.calendarFeeds == nil {
+		return nil, ErrCalendarFeedNotFound
+	}
+
+	token, err := newCalendarFeedToken()
+	if err != nil {
+		return nil, err
+	}
+	feed, err := s.calendarFeeds.GetOrCreate(ctx, userID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	url := s.calendarBaseURL + calendarFeedPath + feed.Token + ".ics"
+	webcal := url
+	if i := strings.Index(url, "://"); i >= 0 {
+		webcal = "webcal" + url[i:]
+	}
+	return &models.CalendarFeedResponse{
+		URL:       url,
+		WebcalURL: webcal,
+		CreatedAt: feed.CreatedAt,
+	}, nil
+}
+
+// RevokeCalendarFeed stops the user's subscription URL working. The next
+// GetCalendarFeed issues a new one.
+func (s *EventService) RevokeCalendarFeed(ctx context.Context, userID primitive.ObjectID) error {
+	if s.calendarFeeds == nil {
+		return nil
+	}
+	if err := s.calendarFeeds.DeleteByUser(ctx, userID); err != nil {
+		return err
+	}
+	s.invalidateCalendarFeed(ctx, userID)
+	return nil
+}
+
+// CalendarFeedICS returns the subscription calendar for a feed token: every
+// event its owner is going to
+func (s *EventService) CalendarFeedICS(ctx context.Context, token string) (string, error) {
+	if s.calendarFeeds == nil || token == "" {
+		return "", ErrCalendarFeedNotFound
+	}
+	feed, err := s.calendarFeeds.GetByToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	if feed == nil {
+		return "", ErrCalendarFeedNotFound
+	}
+
+	load := func(ctx context.Context) (string, error) {
+		return s.buildCalendarFeed(ctx, feed.UserID)
+	}
+	if s.eventCache == nil {
+		return load(ctx)
+	}
+	return s.eventCache.LoadCalendarFeed(ctx, feed.UserID.Hex(), load)
+}
+
+func (s *EventService) buildCalendarFeed(ctx context.Context, userID primitive.ObjectID) (string, error) {
+	filter := bson.M{
+		"attendees": bson.M{"$elemMatch": bson.M{
+			"user_id": userID,
+			"status":  models.RSVPStatusGoing,
+		}},
+		"$and": []bson.M{dateRangeFilter(time.Now().Add(-calendarFeedHistory), time.Time{})},
+	}
+	events, _, err := s.eventRepo.List(ctx, calendarFeedLimit, 1, filter)
+	if err != nil {
+		return "", err
+	}
+
+	cal := newICSCalendar("Connectify Events", calendarFeedRefresh)
+	for i := range events {
+		cal.addEvent(&events[i], userID)
+	}
+	return cal.finish(), nil
+}
+
+// invalidateCalendarFeed drops a user's cached feed so it is regenerated
+// with their latest RSVPs
+func (s *EventService) invalidateCalendarFeed(ctx context.Context, userID primitive.ObjectID) {
+	if s.eventCache != nil && s.calendarFeeds != nil {
+		_ = s.eventCache.InvalidateCalendarFeed(ctx, userID.Hex())
+	}
+}
+
+func newCalendarFeedToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrNotCoHost          = apperrors.NotFound("user is not a co-host")
+	ErrNotPendingApproval = apperrors.Conflict("user is not waiting for approval")
+)
+
+func findCoHost(event *models.Event, userID primitive.ObjectID) *models.EventCoHost {
+	for i := range event.CoHosts {
+		if event.CoHosts[i].UserID == userID {
+			return &event.CoHosts[i]
+		}
+	}
+	return nil
+}
+
+// hasPermission reports whether a user may act on an event: the creator can
+// do anything, co-hosts what the creator granted them
+func hasPermission(event *models.Event, userID primitive.ObjectID, perm models.CoHostPermission) bool {
+	if event.CreatorID == userID {
+		return true
+	}
+	if coHost := findCoHost(event, userID); coHost != nil {
+		return coHost.Granted().Has(perm)
+	}
+	return false
+}
+
+// UpdateCoHostPermissions replaces what a co-host may do. Only the creator can change them.
+func (s *EventService) UpdateCoHostPermissions(ctx context.Context, eventID, userID, coHostID primitive.ObjectID, perms models.CoHostPermission) error {
+	if err := validation.ValidateCoHostPermissions(perms); err != nil {
+		return err
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if event.CreatorID != userID {
+		return errors.New("unauthorized: only the event creator can change co-host permissions")
+	}
+
+	updated, err := s.eventRepo.SetCoHostPermissions(ctx, eventID, coHostID, perms)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrNotCoHost
+	}
+	return nil
+}
+
+// needsApproval reports whether a going RSVP from a user must wait for a
+// host. Hosts, invitees and people already going skip the queue.
+func (s *EventService) needsApproval(ctx context.Context, event *models.Event, userID primitive.ObjectID) bool {
+	if !event.RequiresApproval || event.CreatorID == userID || findCoHost(event, userID) != nil {
+		return false
+	}
+	switch seriesStatus(event, userID) {
+	case models.RSVPStatusGoing, models.RSVPStatusWaitlisted:
+		return false
+	}
+	if s.invitationRepo != nil {
+		if invitation, _ := s.invitationRepo.CheckExisting(ctx, event.ID, userID); invitation != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ReviewAttendee approves or declines a user waiting for approval. Approved
+// users go through the normal going RSVP, so they may land on the waitlist.
+func (s *EventService) ReviewAttendee(ctx context.Context, eventID, reviewerID, attendeeID primitive.ObjectID, approve bool) error {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if !hasPermission(event, reviewerID, models.CoHostPermApproveAttendees) {
+		return errors.New("unauthorized: you cannot approve attendees for this event")
+	}
+	if seriesStatus(event, attendeeID) != models.RSVPStatusPendingApproval {
+		return ErrNotPendingApproval
+	}
+
+	status := models.RSVPStatusNotGoing
+	if approve {
+		status = models.RSVPStatusGoing
+	}
+	return s.rsvp(ctx, eventID, attendeeID, status, "", true)
+}
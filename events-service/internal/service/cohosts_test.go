@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/pkg/async"
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/service/mocks"
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/service/testutil"
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestHasPermission tests what creators and co-hosts may do
+func TestHasPermission(t *testing.T) {
+	creatorID := primitive.NewObjectID()
+	coHostID := primitive.NewObjectID()
+	limitedID := primitive.NewObjectID()
+	strangerID := primitive.NewObjectID()
+
+	postsOnly := models.CoHostPermManagePosts
+	event := testutil.NewEventBuilder().WithCreatorID(creatorID).WithCoHost(coHostID).Build()
+	event.CoHosts = append(event.CoHosts, models.EventCoHost{UserID: limitedID, Permissions: &postsOnly})
+
+	tests := []struct {
+		name   string
+		userID primitive.ObjectID
+		perm   models.CoHostPermission
+		want   bool
+	}{
+		{"creator", creatorID, models.CoHostPermEditDetails, true},
+		{"co-host with default permissions", coHostID, models.CoHostPermApproveAttendees, true},
+		{"co-host with granted permission", limitedID, models.CoHostPermManagePosts, true},
+		{"co-host without permission", limitedID, models.CoHostPermEditDetails, false},
+		{"stranger", strangerID, models.CoHostPermManagePosts, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hasPermission(event, tt.userID, tt.perm))
+		})
+	}
+}
+
+// TestEventService_UpdateCoHostPermissions tests changing a co-host's permissions
+func TestEventService_UpdateCoHostPermissions(t *testing.T) {
+	creatorID := primitive.NewObjectID()
+	coHostID := primitive.NewObjectID()
+
+	tests := []struct {
+		name       string
+		userID     primitive.ObjectID
+		perms      models.CoHostPermission
+		isCoHost   bool
+		wantErr    error
+		wantErrMsg string
+	}{
+		{name: "creator updates co-host", userID: creatorID, perms: models.CoHostPermInvite, isCoHost: true},
+		{name: "unknown permission bits", userID: creatorID, perms: 1 << 10, isCoHost: true, wantErr: validation.ErrInvalidPermissions},
+		{name: "user is not a co-host", userID: creatorID, perms: models.CoHostPermInvite, wantErr: ErrNotCoHost},
+		{name: "co-host cannot change permissions", userID: coHostID, perms: models.CoHostPermAll, isCoHost: true, wantErrMsg: "unauthorized"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored *models.CoHostPermission
+			repo := &mocks.MockEventRepository{
+				GetByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*models.Event, error) {
+					return testutil.NewEventBuilder().WithID(id).WithCreatorID(creatorID).WithCoHost(coHostID).Build(), nil
+				},
+				SetCoHostPermissionsFunc: func(ctx context.Context, eventID, userID primitive.ObjectID, perms models.CoHostPermission) (bool, error) {
+					stored = &perms
+					return tt.isCoHost, nil
+				},
+			}
+			svc := &EventService{eventRepo: repo, asyncRunner: async.NewRunner(slog.Default())}
+
+			err := svc.UpdateCoHostPermissions(context.Background(), primitive.NewObjectID(), tt.userID, coHostID, tt.perms)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.wantErrMsg != "":
+				assert.ErrorContains(t, err, tt.wantErrMsg)
+				assert.Nil(t, stored)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.perms, *stored)
+			}
+		})
+	}
+}
+
+// TestEventService_AttendeeApproval tests RSVPs to events that need approval
+func TestEventService_AttendeeApproval(t *testing.T) {
+	creatorID := primitive.NewObjectID()
+	coHostID := primitive.NewObjectID()
+	guestID := primitive.NewObjectID()
+	pendingID := primitive.NewObjectID()
+	inviteOnly := models.CoHostPermInvite
+
+	newService := func(event *models.Event, saved *models.EventAttendee) *EventService {
+		repo := &mocks.MockEventRepository{
+			GetByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*models.Event, error) {
+				return event, nil
+			},
+			AddOrUpdateAttendeeFunc: func(ctx context.Context, eventID primitive.ObjectID, attendee models.EventAttendee) error {
+				*saved = attendee
+				return nil
+			},
+		}
+		return &EventService{eventRepo: repo, asyncRunner: async.NewRunner(slog.Default())}
+	}
+	newEvent := func() *models.Event {
+		e := testutil.NewEventBuilder().
+			WithCreatorID(creatorID).
+			WithCoHost(coHostID).
+			WithAttendee(pendingID, models.RSVPStatusPendingApproval).
+			Build()
+		e.RequiresApproval = true
+		return e
+	}
+
+	t.Run("going waits for approval", func(t *testing.T) {
+		var saved models.EventAttendee
+		event := newEvent()
+		err := newService(event, &saved).RSVP(context.Background(), event.ID, guestID, models.RSVPStatusGoing, "")
+		assert.NoError(t, err)
+		assert.Equal(t, models.RSVPStatusPendingApproval, saved.Status)
+	})
+
+	t.Run("interested needs no approval", func(t *testing.T) {
+		var saved models.EventAttendee
+		event := newEvent()
+		err := newService(event, &saved).RSVP(context.Background(), event.ID, guestID, models.RSVPStatusInterested, "")
+		assert.NoError(t, err)
+		assert.Equal(t, models.RSVPStatusInterested, saved.Status)
+	})
+
+	t.Run("co-host approves attendee", func(t *testing.T) {
+		var saved models.EventAttendee
+		event := newEvent()
+		err := newService(event, &saved).ReviewAttendee(context.Background(), event.ID, coHostID, pendingID, true)
+		assert.NoError(t, err)
+		assert.Equal(t, pendingID, saved.UserID)
+		assert.Equal(t, models.RSVPStatusGoing, saved.Status)
+	})
+
+	t.Run("creator declines attendee", func(t *testing.T) {
+		var saved models.EventAttendee
+		event := newEvent()
+		err := newService(event, &saved).ReviewAttendee(context.Background(), event.ID, creatorID, pendingID, false)
+		assert.NoError(t, err)
+		assert.Equal(t, models.RSVPStatusNotGoing, saved.Status)
+	})
+
+	t.Run("co-host without permission", func(t *testing.T) {
+		var saved models.EventAttendee
+		event := newEvent()
+		event.CoHosts[0].Permissions = &inviteOnly
+		err := newService(event, &saved).ReviewAttendee(context.Background(), event.ID, coHostID, pendingID, true)
+		assert.ErrorContains(t, err, "unauthorized")
+		assert.Empty(t, saved.Status)
+	})
+
+	t.Run("attendee is not waiting", func(t *testing.T) {
+		var saved models.EventAttendee
+		event := newEvent()
+		err := newService(event, &saved).ReviewAttendee(context.Background(), event.ID, creatorID, guestID, true)
+		assert.ErrorIs(t, err, ErrNotPendingApproval)
+	})
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeleteUserData purges a deleted account from events: the events it hosts
+// are removed with their discussions, its RSVPs, tickets and co-host roles
+// are withdrawn, and its posts, reactions and invitations are deleted. Seats
+// it held go to the waitlist as if it had declined.
+func (s *EventService) DeleteUserData(ctx context.Context, userID string) (int64, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, errors.New("invalid user ID")
+	}
+	var deleted int64
+
+	// A limit of 0 lists everything
+	hosted, _, err := s.eventRepo.List(ctx, 0, 1, bson.M{"creator_id": uID})
+	if err != nil {
+		return deleted, fmt.Errorf("failed to list hosted events: %w", err)
+	}
+	for _, event := range hosted {
+		if err := s.postRepo.DeleteByEventID(ctx, event.ID); err != nil {
+			return deleted, fmt.Errorf("failed to delete posts of event %s: %w", event.ID.Hex(), err)
+		}
+		if err := s.removeEvent(ctx, event.ID); err != nil {
+			return deleted, fmt.Errorf("failed to delete event %s: %w", event.ID.Hex(), err)
+		}
+		deleted++
+	}
+
+	n, err := s.leaveEvents(ctx, uID)
+	deleted += n
+	if err != nil {
+		return deleted, err
+	}
+
+	if n, err = s.postRepo.DeleteByAuthorID(ctx, uID); err != nil {
+		return deleted, fmt.Errorf("failed to delete event posts: %w", err)
+	}
+	deleted += n
+	if n, err = s.postRepo.RemoveUserReactions(ctx, uID); err != nil {
+		return deleted, fmt.Errorf("failed to delete event post reactions: %w", err)
+	}
+	deleted += n
+	if n, err = s.invitationRepo.DeleteByUser(ctx, uID); err != nil {
+		return deleted, fmt.Errorf("failed to delete invitations: %w", err)
+	}
+	deleted += n
+
+	if s.calendarFeeds != nil {
+		if err := s.calendarFeeds.DeleteByUser(ctx, uID); err != nil {
+			return deleted, fmt.Errorf("failed to delete calendar feed: %w", err)
+		}
+	}
+	return deleted, nil
+}
+
+// leaveEvents withdraws the user from every event they RSVPed to or co-host,
+// freeing their seats, and refreshes the counts of the events they left
+func (s *EventService) leaveEvents(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	joined, _, err := s.eventRepo.List(ctx, 0, 1, bson.M{"$or": []bson.M{
+		{"attendees.user_id": userID},
+		{"occurrence_rsvps.user_id": userID},
+		{"co_hosts.user_id": userID},
+	}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list joined events: %w", err)
+	}
+
+	promoted := make(map[primitive.ObjectID][]primitive.ObjectID, len(joined))
+	if s.ticketRepo != nil {
+		for _, event := range joined {
+			if promoted[event.ID], err = s.cancelTicket(ctx, event.ID, userID); err != nil {
+				return 0, fmt.Errorf("failed to cancel ticket for event %s: %w", event.ID.Hex(), err)
+			}
+		}
+	}
+
+	changed, err := s.eventRepo.RemoveUser(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove user from events: %w", err)
+	}
+
+	for _, event := range joined {
+		updated, err := s.eventRepo.GetByID(ctx, event.ID)
+		if err != nil || updated == nil {
+			continue
+		}
+		stats := s.countStats(ctx, updated)
+		if err := s.eventRepo.UpdateStats(ctx, event.ID, stats); err != nil {
+			return changed, fmt.Errorf("failed to update stats of event %s: %w", event.ID.Hex(), err)
+		}
+		updated.Stats = stats
+		s.indexEvent(ctx, updated)
+
+		if s.eventCache != nil {
+			s.eventCache.SetEventStats(ctx, event.ID.Hex(), &stats)
+			s.eventCache.InvalidateUserRSVPStatus(ctx, userID.Hex(), event.ID.Hex())
+			s.invalidateFriendsGoing(ctx, event.ID, userID)
+		}
+		s.announcePromotions(ctx, updated, promoted[event.ID], stats)
+
+		if s.eventGraphRepo != nil {
+			eventID := event.ID
+			taskCtx := s.detachContext(ctx)
+			s.asyncRunner.RunAsyncRetry(taskCtx, "remove_deleted_user_attendee", func() error {
+				graphCtx, cancel := context.WithTimeout(taskCtx, 5*time.Second)
+				defer cancel()
+				return s.executeGraphOp(graphCtx, "remove_rsvp_attendee", func(gctx context.Context) error {
+					return s.eventGraphRepo.RemoveAttendee(gctx, userID, eventID)
+				})
+			}, asyncRetryAttempts, asyncRetryDelay)
+		}
+	}
+	return changed, nil
+}
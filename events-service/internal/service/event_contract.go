@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 
@@ -14,10 +15,19 @@ type EventServiceContract interface {
 	GetEvent(ctx context.Context, id primitive.ObjectID, viewerID primitive.ObjectID) (*models.EventResponse, error)
 	UpdateEvent(ctx context.Context, id, userID primitive.ObjectID, req models.UpdateEventRequest) (*models.EventResponse, error)
 	DeleteEvent(ctx context.Context, id, userID primitive.ObjectID) error
+	DeleteOccurrence(ctx context.Context, id, userID primitive.ObjectID, occurrenceStart time.Time) error
 	ListEvents(ctx context.Context, userID primitive.ObjectID, limit, page int64, query, category, period string) ([]models.EventResponse, int64, error)
 	GetUserEvents(ctx context.Context, userID primitive.ObjectID, limit, page int64) ([]models.EventResponse, error)
 	GetFriendBirthdays(ctx context.Context, userID primitive.ObjectID) (*models.BirthdayResponse, error)
-	RSVP(ctx context.Context, eventID primitive.ObjectID, userID primitive.ObjectID, status models.RSVPStatus) error
+	RSVP(ctx context.Context, eventID primitive.ObjectID, userID primitive.ObjectID, status models.RSVPStatus, tierID string) error
+	RSVPOccurrence(ctx context.Context, eventID, userID primitive.ObjectID, occurrenceStart time.Time, status models.RSVPStatus) error
+	GetTicket(ctx context.Context, eventID, userID primitive.ObjectID) (*models.EventTicketResponse, error)
+	ExportEventICS(ctx context.Context, eventID, viewerID primitive.ObjectID) (string, error)
+	GetCalendarFeed(ctx context.Context, userID primitive.ObjectID) (*models.CalendarFeedResponse, error)
+	RevokeCalendarFeed(ctx context.Context, userID primitive.ObjectID) error
+	CalendarFeedICS(ctx context.Context, token string) (string, error)
+	GetReminderSettings(ctx context.Context, eventID, userID primitive.ObjectID) (*models.EventReminderSettings, error)
+	UpdateReminderSettings(ctx context.Context, eventID, userID primitive.ObjectID, enabled bool) (*models.EventReminderSettings, error)
 	InviteFriends(ctx context.Context, eventID, inviterID primitive.ObjectID, friendIDs []string, message string) error
 	GetUserInvitations(ctx context.Context, userID primitive.ObjectID, limit, page int64) ([]models.EventInvitationResponse, int64, error)
 	RespondToInvitation(ctx context.Context, invitationID, userID primitive.ObjectID, accept bool) error
@@ -26,7 +36,9 @@ type EventServiceContract interface {
 	DeletePost(ctx context.Context, eventID, postID, userID primitive.ObjectID) error
 	ReactToPost(ctx context.Context, postID, userID primitive.ObjectID, emoji string) error
 	GetAttendees(ctx context.Context, eventID primitive.ObjectID, status models.RSVPStatus, limit, page int64) (*models.AttendeesListResponse, error)
-	AddCoHost(ctx context.Context, eventID, userID, coHostID primitive.ObjectID) error
+	AddCoHost(ctx context.Context, eventID, userID, coHostID primitive.ObjectID, perms models.CoHostPermission) error
+	UpdateCoHostPermissions(ctx context.Context, eventID, userID, coHostID primitive.ObjectID, perms models.CoHostPermission) error
+	ReviewAttendee(ctx context.Context, eventID, reviewerID, attendeeID primitive.ObjectID, approve bool) error
 	RemoveCoHost(ctx context.Context, eventID, userID, coHostID primitive.ObjectID) error
 	GetCategories(ctx context.Context) ([]models.EventCategory, error)
 	SearchEvents(ctx context.Context, req models.SearchEventsRequest, userID primitive.ObjectID) ([]models.EventResponse, int64, error)
@@ -82,6 +82,7 @@ func (s *EventRecommendationService) computeRecommendations(ctx context.Context,
 			Username: f.Username,
 			FullName: f.FullName,
 			Avatar:   f.Avatar,
+			Verified: f.Verified,
 		}
 	}
 
@@ -215,6 +216,7 @@ func (s *EventRecommendationService) GetGraphBasedRecommendations(ctx context.Co
 					Username: u.Username,
 					FullName: u.FullName,
 					Avatar:   u.Avatar,
+					Verified: u.Verified,
 				})
 			}
 		}
@@ -3,8 +3,9 @@ package service
 import (
 	"context"
 	"errors"
-	"log"
+	"fmt"
 	"log/slog"
+	"reflect"
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/events-service/internal/cache"
@@ -24,10 +25,11 @@ type EventCache interface {
 	InvalidateUserRSVPStatus(ctx context.Context, userID, eventID string) error
 	SetUserRSVPStatus(ctx context.Context, userID, eventID string, status models.RSVPStatus) error
 	InvalidateFriendsGoing(ctx context.Context, userID, eventID string) error
-	GetCategories(ctx context.Context) ([]models.EventCategory, error)
-	SetCategories(ctx context.Context, categories []models.EventCategory) error
+	LoadCategories(ctx context.Context, load func(ctx context.Context) ([]models.EventCategory, error)) ([]models.EventCategory, error)
 	GetTrendingEvents(ctx context.Context) ([]string, error)
 	SetTrendingEvents(ctx context.Context, eventIDs []string) error
+	LoadCalendarFeed(ctx context.Context, userID string, load func(ctx context.Context) (string, error)) (string, error)
+	InvalidateCalendarFeed(ctx context.Context, userID string) error
 }
 
 // cacheAdapter wraps the concrete cache implementation
@@ -58,12 +60,8 @@ func (a *cacheAdapter) InvalidateFriendsGoing(ctx context.Context, userID, event
 	return a.delegate.InvalidateFriendsGoing(ctx, userID, eventID)
 }
 
-func (a *cacheAdapter) GetCategories(ctx context.Context) ([]models.EventCategory, error) {
-	return a.delegate.GetCategories(ctx)
-}
-
-func (a *cacheAdapter) SetCategories(ctx context.Context, categories []models.EventCategory) error {
-	return a.delegate.SetCategories(ctx, categories)
+func (a *cacheAdapter) LoadCategories(ctx context.Context, load func(ctx context.Context) ([]models.EventCategory, error)) ([]models.EventCategory, error) {
+	return a.delegate.LoadCategories(ctx, load)
 }
 
 func (a *cacheAdapter) GetTrendingEvents(ctx context.Context) ([]string, error) {
@@ -74,6 +72,14 @@ func (a *cacheAdapter) SetTrendingEvents(ctx context.Context, eventIDs []string)
 	return a.delegate.SetTrendingEvents(ctx, eventIDs)
 }
 
+func (a *cacheAdapter) LoadCalendarFeed(ctx context.Context, userID string, load func(ctx context.Context) (string, error)) (string, error) {
+	return a.delegate.LoadCalendarFeed(ctx, userID, load)
+}
+
+func (a *cacheAdapter) InvalidateCalendarFeed(ctx context.Context, userID string) error {
+	return a.delegate.InvalidateCalendarFeed(ctx, userID)
+}
+
 const (
 	asyncRetryAttempts = 5
 	asyncRetryDelay    = time.Second
@@ -97,12 +103,19 @@ type EventService struct {
 	eventGraphRepo       EventGraphRepo
 	invitationRepo       InvitationRepo
 	postRepo             PostRepo
+	ticketRepo           TicketRepo
 	notificationProducer *producer.NotificationProducer
 	eventCache           EventCache
 	broadcaster          EventBroadcaster
 	asyncRunner          *async.Runner
 	breaker              *CircuitBreakerWrapper
 	metrics              *metrics.BusinessMetrics
+	search               SearchIndexer
+	blocks               BlockChecker
+	calendarFeeds        CalendarFeedRepo
+	reminderRepo         ReminderRepo
+	moderator            ContentModerator
+	calendarBaseURL      string
 }
 
 func NewEventService(
@@ -133,6 +146,22 @@ func NewEventService(
 	}
 }
 
+// SetSearchIndexer enables publishing event changes to the search index
+func (s *EventService) SetSearchIndexer(search SearchIndexer) {
+	s.search = search
+}
+
+// SetBlockChecker stops users from inviting people they have blocked or been blocked by
+func (s *EventService) SetBlockChecker(blocks BlockChecker) {
+	s.blocks = blocks
+}
+
+func (s *EventService) indexEvent(ctx context.Context, event *models.Event) {
+	if s.search != nil && event != nil {
+		s.search.UpsertEvent(ctx, models.NewSearchEventDocument(event))
+	}
+}
+
 func (s *EventService) detachContext(ctx context.Context) context.Context {
 	if ctx == nil {
 		return context.Background()
@@ -173,6 +202,11 @@ func (s *EventService) CreateEvent(ctx context.Context, userID primitive.ObjectI
 	if err := validation.ValidateCreateEventRequest(&req); err != nil {
 		return nil, err
 	}
+	text := moderatedText(req.Title, req.Description)
+	decision, err := s.screenText(ctx, text)
+	if err != nil {
+		return nil, err
+	}
 
 	event := &models.Event{
 		Title:       req.Title,
@@ -185,6 +219,14 @@ func (s *EventService) CreateEvent(ctx context.Context, userID primitive.ObjectI
 		Category:    req.Category,
 		CoverImage:  req.CoverImage,
 		CreatorID:   userID,
+		Capacity:    req.Capacity,
+		TicketTiers: newTicketTiers(req.TicketTiers),
+
+		RequiresApproval: req.RequiresApproval,
+	}
+	if req.Recurrence != nil {
+		event.Recurrence = req.Recurrence
+		event.RecurrenceEnd = recurrenceEnd(req.Recurrence, req.StartDate)
 	}
 
 	// Creator is automatically going
@@ -196,11 +238,24 @@ func (s *EventService) CreateEvent(ctx context.Context, userID primitive.ObjectI
 		},
 	}
 	event.Stats.GoingCount = 1
+	event.SeatsTaken = 1
 
 	if err := s.eventRepo.Create(ctx, event); err != nil {
 		return nil, err
 	}
 
+	if s.ticketRepo != nil {
+		ticket := &models.EventTicket{
+			EventID: event.ID,
+			UserID:  userID,
+			Status:  models.TicketStatusConfirmed,
+		}
+		ticket.TierID, _ = selectTier(event, "")
+		if _, err := s.ticketRepo.Issue(ctx, ticket); err != nil {
+			return nil, err
+		}
+	}
+
 	// Graph: Add Creator as Attendee
 	if s.eventGraphRepo != nil {
 		taskCtx := s.detachContext(ctx)
@@ -213,9 +268,14 @@ func (s *EventService) CreateEvent(ctx context.Context, userID primitive.ObjectI
 		}, asyncRetryAttempts, asyncRetryDelay)
 	}
 
+	s.reportEvent(ctx, decision, event.ID, userID, text)
+	s.invalidateCalendarFeed(ctx, userID)
+	s.scheduleReminders(ctx, event, time.Now())
+
 	if s.metrics != nil {
 		s.metrics.IncrementEventsCreated()
 	}
+	s.indexEvent(ctx, event)
 
 	return event, nil
 }
@@ -273,13 +333,34 @@ func (s *EventService) UpdateEvent(ctx context.Context, id, userID primitive.Obj
 		return nil, err
 	}
 
-	if event.CreatorID != userID {
-		return nil, errors.New("unauthorized: only creator can update event")
+	if !hasPermission(event, userID, models.CoHostPermEditDetails) {
+		return nil, errors.New("unauthorized: you cannot update this event")
 	}
 
 	if err := validation.ValidateUpdateEventRequest(&req); err != nil {
 		return nil, err
 	}
+	text := moderatedText(req.Title, req.Description)
+	decision, err := s.screenText(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Scope == models.EventEditScopeThis {
+		resp, err := s.updateOccurrence(ctx, event, userID, req)
+		if err == nil {
+			s.reportEvent(ctx, decision, event.ID, userID, text)
+		}
+		return resp, err
+	}
+	previousStart := event.StartDate
+
+	// Raising or removing the limit frees seats for the waitlist
+	capacityRaised := false
+	if req.Capacity != nil {
+		capacityRaised = event.Capacity > 0 && (*req.Capacity == 0 || *req.Capacity > event.Capacity)
+		event.Capacity = *req.Capacity
+	}
 
 	if req.Title != "" {
 		event.Title = req.Title
@@ -308,10 +389,53 @@ func (s *EventService) UpdateEvent(ctx context.Context, id, userID primitive.Obj
 	if req.CoverImage != "" {
 		event.CoverImage = req.CoverImage
 	}
+	if req.RequiresApproval != nil {
+		event.RequiresApproval = *req.RequiresApproval
+	}
+	if req.Recurrence != nil {
+		if err := validation.ValidateRecurrence(req.Recurrence, event.StartDate); err != nil {
+			return nil, err
+		}
+	}
+	if event.Recurrence != nil || req.Recurrence != nil {
+		// Per-occurrence edits and RSVPs are keyed by the generated starts,
+		// which no longer exist once the series moves or its rule changes
+		if !event.StartDate.Equal(previousStart) || (req.Recurrence != nil && !reflect.DeepEqual(req.Recurrence, event.Recurrence)) {
+			event.OccurrenceOverrides = nil
+			event.OccurrenceRSVPs = nil
+		}
+		if req.Recurrence != nil {
+			event.Recurrence = req.Recurrence
+		}
+		event.RecurrenceEnd = recurrenceEnd(event.Recurrence, event.StartDate)
+	}
 
 	if err := s.eventRepo.Update(ctx, event); err != nil {
 		return nil, err
 	}
+	s.reportEvent(ctx, decision, event.ID, userID, text)
+	if !event.StartDate.Equal(previousStart) || req.Recurrence != nil {
+		s.rescheduleReminders(ctx, event)
+	}
+
+	if capacityRaised && s.ticketRepo != nil {
+		promoted, err := s.promoteWaitlist(ctx, event.ID)
+		if len(promoted) > 0 {
+			if updated, getErr := s.eventRepo.GetByID(ctx, event.ID); getErr == nil {
+				event = updated
+			}
+			event.Stats = s.countStats(ctx, event)
+			s.eventRepo.UpdateStats(ctx, event.ID, event.Stats)
+			if s.eventCache != nil {
+				s.eventCache.SetEventStats(ctx, event.ID.Hex(), &event.Stats)
+			}
+			s.announcePromotions(ctx, event, promoted, event.Stats)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	s.indexEvent(ctx, event)
 
 	resp, err := s.mapToResponse(ctx, event, userID)
 	if err == nil && s.broadcaster != nil {
@@ -332,6 +456,127 @@ func (s *EventService) UpdateEvent(ctx context.Context, id, userID primitive.Obj
 	return resp, err
 }
 
+// updateOccurrence applies an edit to one occurrence of a recurring event,
+// leaving the rest of the series unchanged
+func (s *EventService) updateOccurrence(ctx context.Context, event *models.Event, userID primitive.ObjectID, req models.UpdateEventRequest) (*models.EventResponse, error) {
+	if event.Recurrence == nil {
+		return nil, ErrNotRecurring
+	}
+	if req.OccurrenceStart == nil {
+		return nil, ErrOccurrenceStartRequired
+	}
+	if req.Recurrence != nil || req.Privacy != "" || req.Category != "" || req.CoverImage != "" || req.IsOnline != nil {
+		return nil, ErrSeriesOnlyField
+	}
+	occurrenceStart := *req.OccurrenceStart
+	if !isOccurrence(event, occurrenceStart) {
+		return nil, ErrOccurrenceNotFound
+	}
+
+	_, override := findOverride(event, occurrenceStart)
+	if override == nil {
+		event.OccurrenceOverrides = append(event.OccurrenceOverrides, models.EventOccurrenceOverride{OccurrenceStart: occurrenceStart})
+		override = &event.OccurrenceOverrides[len(event.OccurrenceOverrides)-1]
+	}
+	if override.Cancelled {
+		return nil, ErrOccurrenceNotFound
+	}
+
+	if req.Title != "" {
+		override.Title = req.Title
+	}
+	if req.Description != "" {
+		override.Description = req.Description
+	}
+	if req.Location != "" {
+		override.Location = req.Location
+	}
+	if req.StartDate != nil {
+		start := *req.StartDate
+		override.StartDate = &start
+	}
+	if req.EndDate != nil {
+		end := *req.EndDate
+		override.EndDate = &end
+	}
+
+	series, err := s.mapToResponse(ctx, event, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp, _ := applyOccurrence(*series, event, occurrenceStart, userID)
+	if resp.EndDate.Before(resp.StartDate) {
+		return nil, validation.ErrInvalidDateRange
+	}
+
+	if err := s.eventRepo.Update(ctx, event); err != nil {
+		return nil, err
+	}
+	if req.StartDate != nil {
+		s.scheduleReminders(ctx, event, time.Now())
+	}
+
+	if s.broadcaster != nil {
+		s.broadcaster.PublishEventUpdated(ctx, models.EventUpdatedEvent{
+			ID:              event.ID.Hex(),
+			Title:           resp.Title,
+			Description:     resp.Description,
+			StartDate:       resp.StartDate,
+			EndDate:         resp.EndDate,
+			Location:        resp.Location,
+			IsOnline:        resp.IsOnline,
+			Privacy:         resp.Privacy,
+			Category:        resp.Category,
+			CoverImage:      resp.CoverImage,
+			UpdatedAt:       event.UpdatedAt,
+			OccurrenceStart: resp.OccurrenceStart,
+		})
+	}
+	return &resp, nil
+}
+
+// DeleteOccurrence cancels one occurrence of a recurring event
+func (s *EventService) DeleteOccurrence(ctx context.Context, id, userID primitive.ObjectID, occurrenceStart time.Time) error {
+	event, err := s.eventRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !hasPermission(event, userID, models.CoHostPermEditDetails) {
+		return errors.New("unauthorized")
+	}
+	if event.Recurrence == nil {
+		return ErrNotRecurring
+	}
+	if !isOccurrence(event, occurrenceStart) {
+		return ErrOccurrenceNotFound
+	}
+
+	cancelled := models.EventOccurrenceOverride{OccurrenceStart: occurrenceStart, Cancelled: true}
+	if i, override := findOverride(event, occurrenceStart); override != nil {
+		if override.Cancelled {
+			return ErrOccurrenceNotFound
+		}
+		event.OccurrenceOverrides[i] = cancelled
+	} else {
+		event.OccurrenceOverrides = append(event.OccurrenceOverrides, cancelled)
+	}
+
+	if err := s.eventRepo.Update(ctx, event); err != nil {
+		return err
+	}
+
+	if s.broadcaster != nil {
+		s.broadcaster.PublishEventDeleted(ctx, models.EventDeletedEvent{
+			ID:              id.Hex(),
+			DeletedAt:       time.Now(),
+			OccurrenceStart: &occurrenceStart,
+		})
+	}
+
+	return nil
+}
+
 func (s *EventService) DeleteEvent(ctx context.Context, id, userID primitive.ObjectID) error {
 	event, err := s.eventRepo.GetByID(ctx, id)
 	if err != nil {
@@ -342,13 +587,38 @@ func (s *EventService) DeleteEvent(ctx context.Context, id, userID primitive.Obj
 		return errors.New("unauthorized")
 	}
 
+	return s.removeEvent(ctx, id)
+}
+
+// removeEvent deletes an event along with its tickets, reminders and search entry
+func (s *EventService) removeEvent(ctx context.Context, id primitive.ObjectID) error {
 	if err := s.eventRepo.Delete(ctx, id); err != nil {
 		return err
 	}
 
+	if s.ticketRepo != nil {
+		taskCtx := s.detachContext(ctx)
+		s.asyncRunner.RunAsyncRetry(taskCtx, "delete_event_tickets", func() error {
+			deleteCtx, cancel := context.WithTimeout(taskCtx, 5*time.Second)
+			defer cancel()
+			return s.ticketRepo.DeleteByEventID(deleteCtx, id)
+		}, asyncRetryAttempts, asyncRetryDelay)
+	}
+	if s.reminderRepo != nil {
+		taskCtx := s.detachContext(ctx)
+		s.asyncRunner.RunAsyncRetry(taskCtx, "delete_event_reminders", func() error {
+			deleteCtx, cancel := context.WithTimeout(taskCtx, 5*time.Second)
+			defer cancel()
+			return s.reminderRepo.DeleteByEventID(deleteCtx, id)
+		}, asyncRetryAttempts, asyncRetryDelay)
+	}
+
 	if s.metrics != nil {
 		s.metrics.IncrementEventsDeleted()
 	}
+	if s.search != nil {
+		s.search.Delete(ctx, models.SearchEntityEvent, id.Hex())
+	}
 
 	if s.broadcaster != nil {
 		s.broadcaster.PublishEventDeleted(ctx, models.EventDeletedEvent{
@@ -378,31 +648,66 @@ func (s *EventService) ListEvents(ctx context.Context, userID primitive.ObjectID
 
 	// Period: today, week, weekend
 	now := time.Now()
+	var from, to time.Time
 	if period == "today" {
-		tomorrow := now.Add(24 * time.Hour)
-		filter["start_date"] = bson.M{"$gte": now, "$lt": tomorrow}
+		from, to = now, now.Add(24*time.Hour)
 	} else if period == "week" {
-		nextWeek := now.Add(7 * 24 * time.Hour)
-		filter["start_date"] = bson.M{"$gte": now, "$lt": nextWeek}
+		from, to = now, now.Add(7*24*time.Hour)
 	} else if period == "past" {
-		filter["start_date"] = bson.M{"$lt": now}
+		to = now
 	} else {
 		// Default upcoming
-		filter["start_date"] = bson.M{"$gte": now}
+		from = now
 	}
+	filter["$and"] = []bson.M{dateRangeFilter(from, to)}
 
 	events, total, err := s.eventRepo.List(ctx, limit, page, filter)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	responses := make([]models.EventResponse, len(events))
-	for i, event := range events {
-		resp, _ := s.mapToResponse(ctx, &event, userID)
-		responses[i] = *resp
+	return s.expandOccurrences(ctx, events, from, to, userID), total, nil
+}
+
+// expandOccurrences maps events to responses, replacing each recurring series
+// with its occurrences in [from, to). Pages are of series, so a page may hold
+// more responses than its limit; at most maxOccurrencesPerSeries per series
+// are returned, the latest ones for past listings.
+func (s *EventService) expandOccurrences(ctx context.Context, events []models.Event, from, to time.Time, viewerID primitive.ObjectID) []models.EventResponse {
+	responses := make([]models.EventResponse, 0, len(events))
+	for i := range events {
+		event := &events[i]
+		resp, _ := s.mapToResponse(ctx, event, viewerID)
+		if resp == nil {
+			continue
+		}
+		if event.Recurrence == nil {
+			responses = append(responses, *resp)
+			continue
+		}
+
+		limit := maxOccurrencesPerSeries
+		expandTo := to
+		if expandTo.IsZero() {
+			expandTo = from.Add(recurrenceHorizon)
+		}
+		if from.IsZero() {
+			// Past listings keep the most recent occurrences
+			limit = 0
+		}
+		starts := occurrenceStarts(event.Recurrence, event.StartDate, from, expandTo, limit)
+		if len(starts) > maxOccurrencesPerSeries {
+			starts = starts[len(starts)-maxOccurrencesPerSeries:]
+		}
+		for _, start := range starts {
+			if occurrence, ok := applyOccurrence(*resp, event, start, viewerID); ok {
+				responses = append(responses, occurrence)
+			}
+		}
 	}
 
-	return responses, total, nil
+	sortByStart(responses)
+	return responses
 }
 
 func (s *EventService) GetUserEvents(ctx context.Context, userID primitive.ObjectID, limit, page int64) ([]models.EventResponse, error) {
@@ -492,12 +797,35 @@ func (s *EventService) GetFriendBirthdays(ctx context.Context, userID primitive.
 	return response, nil
 }
 
-func (s *EventService) RSVP(ctx context.Context, eventID primitive.ObjectID, userID primitive.ObjectID, status models.RSVPStatus) error {
-	_, err := s.eventRepo.GetByID(ctx, eventID)
+func (s *EventService) RSVP(ctx context.Context, eventID primitive.ObjectID, userID primitive.ObjectID, status models.RSVPStatus, tierID string) error {
+	return s.rsvp(ctx, eventID, userID, status, tierID, false)
+}
+
+// rsvp records an RSVP. Approved RSVPs skip the approval some events require.
+func (s *EventService) rsvp(ctx context.Context, eventID, userID primitive.ObjectID, status models.RSVPStatus, tierID string, approved bool) error {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
 		return err
 	}
 
+	if status == models.RSVPStatusGoing && !approved && s.needsApproval(ctx, event, userID) {
+		status = models.RSVPStatusPendingApproval
+	}
+
+	// Going takes a seat, or a place on the waitlist when the event is full;
+	// anything else gives the user's seat to the next person waiting
+	var promoted []primitive.ObjectID
+	if s.ticketRepo != nil {
+		if status == models.RSVPStatusGoing {
+			status, err = s.claimTicket(ctx, event, userID, tierID)
+		} else {
+			promoted, err = s.cancelTicket(ctx, eventID, userID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
 	attendee := models.EventAttendee{
 		UserID:    userID,
 		Status:    status,
@@ -513,25 +841,13 @@ func (s *EventService) RSVP(ctx context.Context, eventID primitive.ObjectID, use
 	// Optimally we'd do this incrementally or async.
 	updatedEvent, _ := s.eventRepo.GetByID(ctx, eventID)
 	if updatedEvent != nil {
-		var going, interested, invited int64
-		for _, a := range updatedEvent.Attendees {
-			switch a.Status {
-			case models.RSVPStatusGoing:
-				going++
-			case models.RSVPStatusInterested:
-				interested++
-			case models.RSVPStatusInvited:
-				invited++
-			}
-		}
-		stats := models.EventStats{
-			GoingCount:      going,
-			InterestedCount: interested,
-			InvitedCount:    invited,
-			ShareCount:      updatedEvent.Stats.ShareCount,
-		}
+		stats := s.countStats(ctx, updatedEvent)
 		s.eventRepo.UpdateStats(ctx, eventID, stats)
 
+		// Attendees gain search visibility of private events
+		updatedEvent.Stats = stats
+		s.indexEvent(ctx, updatedEvent)
+
 		if s.eventCache != nil {
 			s.eventCache.SetEventStats(ctx, eventID.Hex(), &stats)
 			s.eventCache.InvalidateUserRSVPStatus(ctx, userID.Hex(), eventID.Hex())
@@ -539,6 +855,7 @@ func (s *EventService) RSVP(ctx context.Context, eventID primitive.ObjectID, use
 			s.eventCache.SetUserRSVPStatus(ctx, userID.Hex(), eventID.Hex(), status)
 			s.invalidateFriendsGoing(ctx, eventID, userID)
 		}
+		s.invalidateCalendarFeed(ctx, userID)
 
 		if s.broadcaster != nil {
 			s.broadcaster.BroadcastRSVP(models.EventRSVPEvent{
@@ -549,6 +866,7 @@ func (s *EventService) RSVP(ctx context.Context, eventID primitive.ObjectID, use
 				Stats:     stats,
 			})
 		}
+		s.announcePromotions(ctx, updatedEvent, promoted, stats)
 	}
 
 	if s.metrics != nil {
@@ -574,6 +892,60 @@ func (s *EventService) RSVP(ctx context.Context, eventID primitive.ObjectID, use
 	return nil
 }
 
+// RSVPOccurrence sets a user's RSVP for one occurrence of a recurring event,
+// overriding their series-wide RSVP for that occurrence only
+func (s *EventService) RSVPOccurrence(ctx context.Context, eventID, userID primitive.ObjectID, occurrenceStart time.Time, status models.RSVPStatus) error {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if event.Recurrence == nil {
+		return ErrNotRecurring
+	}
+	if !isOccurrence(event, occurrenceStart) {
+		return ErrOccurrenceNotFound
+	}
+	if _, override := findOverride(event, occurrenceStart); override != nil && override.Cancelled {
+		return ErrOccurrenceNotFound
+	}
+
+	rsvp := models.OccurrenceRSVP{
+		UserID:          userID,
+		OccurrenceStart: occurrenceStart,
+		Status:          status,
+		Timestamp:       time.Now(),
+	}
+	if err := s.eventRepo.SetOccurrenceRSVP(ctx, eventID, rsvp); err != nil {
+		return err
+	}
+
+	rsvps := event.OccurrenceRSVPs[:0]
+	for _, existing := range event.OccurrenceRSVPs {
+		if existing.UserID != userID || !existing.OccurrenceStart.Equal(occurrenceStart) {
+			rsvps = append(rsvps, existing)
+		}
+	}
+	event.OccurrenceRSVPs = append(rsvps, rsvp)
+	s.invalidateCalendarFeed(ctx, userID)
+
+	if s.broadcaster != nil {
+		s.broadcaster.BroadcastRSVP(models.EventRSVPEvent{
+			EventID:         eventID.Hex(),
+			UserID:          userID.Hex(),
+			Status:          status,
+			Timestamp:       rsvp.Timestamp,
+			Stats:           occurrenceStats(event, occurrenceStart),
+			OccurrenceStart: &occurrenceStart,
+		})
+	}
+
+	if s.metrics != nil {
+		s.metrics.IncrementRSVP(string(status))
+	}
+
+	return nil
+}
+
 func (s *EventService) mapToResponse(ctx context.Context, event *models.Event, viewerID primitive.ObjectID) (*models.EventResponse, error) {
 	// Fetch Creator info
 	creator, _ := s.userRepo.FindByID(ctx, event.CreatorID)
@@ -585,6 +957,7 @@ func (s *EventService) mapToResponse(ctx context.Context, event *models.Event, v
 		creatorShort.Username = creator.Username
 		creatorShort.FullName = creator.FullName
 		creatorShort.Avatar = creator.Avatar
+		creatorShort.Verified = creator.Verified
 	}
 
 	// Determine MyStatus and IsHost
@@ -595,6 +968,11 @@ func (s *EventService) mapToResponse(ctx context.Context, event *models.Event, v
 			break
 		}
 	}
+	var coHostPermissions *models.CoHostPermission
+	if coHost := findCoHost(event, viewerID); coHost != nil {
+		granted := coHost.Granted()
+		coHostPermissions = &granted
+	}
 
 	// Fetch friends going (from Neo4j)
 	var friendsGoing []models.UserShort
@@ -624,6 +1002,7 @@ func (s *EventService) mapToResponse(ctx context.Context, event *models.Event, v
 							Username: friend.Username,
 							FullName: friend.FullName,
 							Avatar:   friend.Avatar,
+							Verified: friend.Verified,
 						})
 					}
 				}
@@ -648,6 +1027,13 @@ func (s *EventService) mapToResponse(ctx context.Context, event *models.Event, v
 		IsHost:       event.CreatorID == viewerID,
 		FriendsGoing: friendsGoing,
 		CreatedAt:    event.CreatedAt,
+		Recurrence:   event.Recurrence,
+		Capacity:     event.Capacity,
+		SeatsLeft:    seatsLeft(event),
+		TicketTiers:  event.TicketTiers,
+
+		RequiresApproval:  event.RequiresApproval,
+		CoHostPermissions: coHostPermissions,
 	}, nil
 }
 
@@ -696,16 +1082,8 @@ func (s *EventService) InviteFriends(ctx context.Context, eventID, inviterID pri
 		return err
 	}
 
-	// Only creator, co-hosts, or going attendees can invite
-	canInvite := event.CreatorID == inviterID
-	if !canInvite {
-		for _, coHost := range event.CoHosts {
-			if coHost.UserID == inviterID {
-				canInvite = true
-				break
-			}
-		}
-	}
+	// Only creator, co-hosts allowed to invite, or going attendees can invite
+	canInvite := hasPermission(event, inviterID, models.CoHostPermInvite)
 	if !canInvite {
 		for _, attendee := range event.Attendees {
 			if attendee.UserID == inviterID && attendee.Status == models.RSVPStatusGoing {
@@ -718,12 +1096,27 @@ func (s *EventService) InviteFriends(ctx context.Context, eventID, inviterID pri
 		return errors.New("unauthorized: you cannot invite to this event")
 	}
 
+	blocked := make(map[string]struct{})
+	if s.blocks != nil {
+		blockedIDs, err := s.blocks.GetBlockedIDs(ctx, inviterID.Hex())
+		if err != nil {
+			return fmt.Errorf("failed to check blocked users: %w", err)
+		}
+		for _, id := range blockedIDs {
+			blocked[id] = struct{}{}
+		}
+	}
+
 	var invitations []models.EventInvitation
 	for _, friendIDStr := range friendIDs {
 		friendID, err := primitive.ObjectIDFromHex(friendIDStr)
 		if err != nil {
 			continue
 		}
+		// Skipped silently, like other invitees that cannot be invited
+		if _, ok := blocked[friendID.Hex()]; ok {
+			continue
+		}
 
 		existing, _ := s.invitationRepo.CheckExisting(ctx, eventID, friendID)
 		if existing != nil {
@@ -821,6 +1214,7 @@ func (s *EventService) GetUserInvitations(ctx context.Context, userID primitive.
 			inviterShort.Username = inviter.Username
 			inviterShort.FullName = inviter.FullName
 			inviterShort.Avatar = inviter.Avatar
+			inviterShort.Verified = inviter.Verified
 		}
 
 		responses = append(responses, models.EventInvitationResponse{
@@ -866,7 +1260,7 @@ func (s *EventService) RespondToInvitation(ctx context.Context, invitationID, us
 	if accept {
 		newStatus = models.InvitationStatusAccepted
 		// Add user as going
-		if err := s.RSVP(ctx, invitation.EventID, userID, models.RSVPStatusGoing); err != nil {
+		if err := s.RSVP(ctx, invitation.EventID, userID, models.RSVPStatusGoing, ""); err != nil {
 			return err
 		}
 	} else {
@@ -947,7 +1341,7 @@ func (s *EventService) CreatePost(ctx context.Context, eventID, authorID primiti
 		return nil, err
 	}
 
-	canPost := event.CreatorID == authorID
+	canPost := event.CreatorID == authorID || findCoHost(event, authorID) != nil
 	if !canPost {
 		for _, a := range event.Attendees {
 			if a.UserID == authorID && a.Status != models.RSVPStatusNotGoing {
@@ -959,6 +1353,10 @@ func (s *EventService) CreatePost(ctx context.Context, eventID, authorID primiti
 	if !canPost {
 		return nil, errors.New("only attendees can post in event discussions")
 	}
+	decision, err := s.screenText(ctx, req.Content)
+	if err != nil {
+		return nil, err
+	}
 
 	post := &models.EventPost{
 		EventID:   eventID,
@@ -970,6 +1368,12 @@ func (s *EventService) CreatePost(ctx context.Context, eventID, authorID primiti
 	if err := s.postRepo.Create(ctx, post); err != nil {
 		return nil, err
 	}
+	s.report(ctx, decision, models.ModerationQueueItem{
+		ContentType: models.ModeratedEventPost,
+		ContentID:   post.ID.Hex(),
+		ParentID:    eventID.Hex(),
+		AuthorID:    authorID,
+	}, post.Content)
 
 	author, _ := s.userRepo.FindByID(ctx, authorID)
 	authorShort := models.UserShort{ID: authorID.Hex(), Username: "Unknown"}
@@ -977,6 +1381,7 @@ func (s *EventService) CreatePost(ctx context.Context, eventID, authorID primiti
 		authorShort.Username = author.Username
 		authorShort.FullName = author.FullName
 		authorShort.Avatar = author.Avatar
+		authorShort.Verified = author.Verified
 	}
 
 	resp := &models.EventPostResponse{
@@ -1017,6 +1422,7 @@ func (s *EventService) GetPosts(ctx context.Context, eventID primitive.ObjectID,
 			authorShort.Username = author.Username
 			authorShort.FullName = author.FullName
 			authorShort.Avatar = author.Avatar
+			authorShort.Verified = author.Verified
 		}
 
 		// Map reactions
@@ -1027,6 +1433,7 @@ func (s *EventService) GetPosts(ctx context.Context, eventID primitive.ObjectID,
 			if user != nil {
 				userShort.Username = user.Username
 				userShort.Avatar = user.Avatar
+				userShort.Verified = user.Verified
 			}
 			reactions = append(reactions, models.EventPostReactionResponse{
 				User:      userShort,
@@ -1059,9 +1466,14 @@ func (s *EventService) DeletePost(ctx context.Context, eventID, postID, userID p
 		return errors.New("post does not belong to this event")
 	}
 
-	event, _ := s.eventRepo.GetByID(ctx, eventID)
-	if post.AuthorID != userID && event.CreatorID != userID {
-		return errors.New("unauthorized")
+	if post.AuthorID != userID {
+		event, err := s.eventRepo.GetByID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if !hasPermission(event, userID, models.CoHostPermManagePosts) {
+			return errors.New("unauthorized")
+		}
 	}
 
 	return s.postRepo.Delete(ctx, postID)
@@ -1090,6 +1502,7 @@ func (s *EventService) ReactToPost(ctx context.Context, postID, userID primitive
 	if user != nil {
 		userShort.Username = user.Username
 		userShort.Avatar = user.Avatar
+		userShort.Verified = user.Verified
 	}
 
 	if s.broadcaster != nil {
@@ -1129,6 +1542,7 @@ func (s *EventService) GetAttendees(ctx context.Context, eventID primitive.Objec
 			userShort.Username = user.Username
 			userShort.FullName = user.FullName
 			userShort.Avatar = user.Avatar
+			userShort.Verified = user.Verified
 		}
 
 		isCoHost := false
@@ -1160,8 +1574,12 @@ func (s *EventService) GetAttendees(ctx context.Context, eventID primitive.Objec
 // Co-Host Methods
 // ===============================
 
-// AddCoHost adds a co-host to an event
-func (s *EventService) AddCoHost(ctx context.Context, eventID, userID, coHostID primitive.ObjectID) error {
+// AddCoHost adds a co-host to an event with the given permissions
+func (s *EventService) AddCoHost(ctx context.Context, eventID, userID, coHostID primitive.ObjectID, perms models.CoHostPermission) error {
+	if err := validation.ValidateCoHostPermissions(perms); err != nil {
+		return err
+	}
+
 	event, err := s.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
 		return err
@@ -1178,9 +1596,10 @@ func (s *EventService) AddCoHost(ctx context.Context, eventID, userID, coHostID
 	}
 
 	coHost := models.EventCoHost{
-		UserID:    coHostID,
-		AddedAt:   time.Now(),
-		AddedByID: userID,
+		UserID:      coHostID,
+		AddedAt:     time.Now(),
+		AddedByID:   userID,
+		Permissions: &perms,
 	}
 
 	if err := s.eventRepo.AddCoHost(ctx, eventID, coHost); err != nil {
@@ -1193,6 +1612,7 @@ func (s *EventService) AddCoHost(ctx context.Context, eventID, userID, coHostID
 		if coHostUser != nil {
 			coHostShort.Username = coHostUser.Username
 			coHostShort.Avatar = coHostUser.Avatar
+			coHostShort.Verified = coHostUser.Verified
 		}
 
 		s.broadcaster.PublishCoHostAdded(ctx, models.EventCoHostAddedEvent{
@@ -1239,23 +1659,10 @@ func (s *EventService) RemoveCoHost(ctx context.Context, eventID, userID, coHost
 
 // GetCategories returns all event categories with counts
 func (s *EventService) GetCategories(ctx context.Context) ([]models.EventCategory, error) {
-	if s.eventCache != nil {
-		if categories, err := s.eventCache.GetCategories(ctx); err == nil && len(categories) > 0 {
-			return categories, nil
-		}
-	}
-
-	categories, err := s.eventRepo.GetCategories(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	if s.eventCache != nil {
-		if err := s.eventCache.SetCategories(ctx, categories); err != nil {
-			log.Printf("failed to cache event categories: %v", err)
-		}
+	if s.eventCache == nil {
+		return s.eventRepo.GetCategories(ctx)
 	}
-	return categories, nil
+	return s.eventCache.LoadCategories(ctx, s.eventRepo.GetCategories)
 }
 
 // ===============================
@@ -1290,17 +1697,16 @@ func (s *EventService) SearchEvents(ctx context.Context, req models.SearchEvents
 
 	// Period filter
 	now := time.Now()
+	var from, to time.Time
 	switch req.Period {
 	case "today":
 		tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
-		filter["start_date"] = bson.M{"$gte": now, "$lt": tomorrow}
+		from, to = now, tomorrow
 	case "tomorrow":
 		tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
-		dayAfter := tomorrow.AddDate(0, 0, 1)
-		filter["start_date"] = bson.M{"$gte": tomorrow, "$lt": dayAfter}
+		from, to = tomorrow, tomorrow.AddDate(0, 0, 1)
 	case "this_week":
-		nextWeek := now.AddDate(0, 0, 7)
-		filter["start_date"] = bson.M{"$gte": now, "$lt": nextWeek}
+		from, to = now, now.AddDate(0, 0, 7)
 	case "this_weekend":
 		// Find next Saturday
 		daysUntilSat := (6 - int(now.Weekday()) + 7) % 7
@@ -1308,12 +1714,13 @@ func (s *EventService) SearchEvents(ctx context.Context, req models.SearchEvents
 			daysUntilSat = 7
 		}
 		saturday := time.Date(now.Year(), now.Month(), now.Day()+daysUntilSat, 0, 0, 0, 0, now.Location())
-		monday := saturday.AddDate(0, 0, 2)
-		filter["start_date"] = bson.M{"$gte": saturday, "$lt": monday}
+		from, to = saturday, saturday.AddDate(0, 0, 2)
 	default:
 		// Default to upcoming
-		filter["start_date"] = bson.M{"$gte": now}
+		from = now
 	}
+	// Search puts its text match under $or, so the date range goes under $and
+	filter["$and"] = []bson.M{dateRangeFilter(from, to)}
 
 	limit := req.Limit
 	if limit <= 0 {
@@ -1329,15 +1736,7 @@ func (s *EventService) SearchEvents(ctx context.Context, req models.SearchEvents
 		return nil, 0, err
 	}
 
-	responses := make([]models.EventResponse, 0, len(events))
-	for _, event := range events {
-		resp, _ := s.mapToResponse(ctx, &event, userID)
-		if resp != nil {
-			responses = append(responses, *resp)
-		}
-	}
-
-	return responses, total, nil
+	return s.expandOccurrences(ctx, events, from, to, userID), total, nil
 }
 
 // GetNearbyEvents returns events near a location
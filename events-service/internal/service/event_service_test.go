@@ -303,7 +303,7 @@ func TestEventService_RSVP(t *testing.T) {
 				asyncRunner: async.NewRunner(slog.Default()),
 			}
 
-			err := svc.RSVP(context.Background(), tt.eventID, tt.userID, tt.status)
+			err := svc.RSVP(context.Background(), tt.eventID, tt.userID, tt.status, "")
 
 			if tt.wantErr {
 				if err == nil {
@@ -571,7 +571,7 @@ func BenchmarkEventService_RSVP(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = svc.RSVP(ctx, eventID, userID, models.RSVPStatusGoing)
+		_ = svc.RSVP(ctx, eventID, userID, models.RSVPStatusGoing, "")
 	}
 }
 
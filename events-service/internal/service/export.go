@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	exportpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/export/v1"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// exportedRSVP is the user's own answer to an event, without the rest of
+// the guest list
+type exportedRSVP struct {
+	EventID    primitive.ObjectID `json:"event_id"`
+	EventTitle string             `json:"event_title"`
+	StartDate  time.Time          `json:"start_date"`
+	Status     models.RSVPStatus  `json:"status"`
+	AnsweredAt time.Time          `json:"answered_at"`
+}
+
+// ExportUserData collects the events the user hosts, their RSVPs,
+// invitations, event posts and tickets for their data export
+func (s *EventService) ExportUserData(ctx context.Context, userID string) ([]*exportpb.ExportSection, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	// A limit of 0 lists everything
+	hosted, _, err := s.eventRepo.List(ctx, 0, 1, bson.M{"creator_id": uID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export hosted events: %w", err)
+	}
+	attended, _, err := s.eventRepo.List(ctx, 0, 1, bson.M{"attendees.user_id": uID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export RSVPs: %w", err)
+	}
+	invitations, _, err := s.invitationRepo.GetUserInvitations(ctx, uID, "", 0, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export invitations: %w", err)
+	}
+	posts, err := s.postRepo.GetByAuthorID(ctx, uID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export event posts: %w", err)
+	}
+	var tickets []models.EventTicket
+	if s.ticketRepo != nil {
+		if tickets, err = s.ticketRepo.ListByUser(ctx, uID); err != nil {
+			return nil, fmt.Errorf("failed to export tickets: %w", err)
+		}
+	}
+
+	rsvps := make([]exportedRSVP, 0, len(attended))
+	for _, event := range attended {
+		for _, a := range event.Attendees {
+			if a.UserID == uID {
+				rsvps = append(rsvps, exportedRSVP{
+					EventID:    event.ID,
+					EventTitle: event.Title,
+					StartDate:  event.StartDate,
+					Status:     a.Status,
+					AnsweredAt: a.Timestamp,
+				})
+			}
+		}
+	}
+
+	var covers, postMedia []string
+	for _, event := range hosted {
+		if event.CoverImage != "" {
+			covers = append(covers, event.CoverImage)
+		}
+	}
+	for _, post := range posts {
+		postMedia = append(postMedia, post.MediaURLs...)
+	}
+
+	var b dataexport.Builder
+	b.Add("hosted_events", hosted, covers...)
+	b.Add("rsvps", rsvps)
+	b.Add("invitations", invitations)
+	b.Add("event_posts", posts, postMedia...)
+	b.Add("tickets", tickets)
+	return b.Sections()
+}
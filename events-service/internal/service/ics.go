@@ -0,0 +1,368 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	icsProductID  = "-//Connectify//Events//EN"
+	icsTimeLayout = "20060102T150405"
+	// icsLineLimit is the RFC 5545 content line limit in octets, excluding CRLF
+	icsLineLimit = 75
+	// icsOpenSeriesYears is how far ahead time zone definitions are written
+	// for series without an end
+	icsOpenSeriesYears = 10
+)
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// icsCalendar writes an iCalendar (RFC 5545) document
+type icsCalendar struct {
+	b      strings.Builder
+	events icsCalendarBody
+	zones  map[string]*icsZone
+}
+
+// icsCalendarBody holds the VEVENTs until finish, since the VTIMEZONEs they
+// reference are only known once every event has been added
+type icsCalendarBody struct {
+	strings.Builder
+}
+
+// icsZone is a time zone events refer to and the span its definition must cover
+type icsZone struct {
+	loc      *time.Location
+	from, to time.Time
+}
+
+// newICSCalendar starts a calendar. A positive refresh asks subscribed
+// clients to poll at that interval.
+func newICSCalendar(name string, refresh time.Duration) *icsCalendar {
+	c := &icsCalendar{zones: map[string]*icsZone{}}
+	c.line("BEGIN", "VCALENDAR")
+	c.line("VERSION", "2.0")
+	c.line("PRODID", icsProductID)
+	c.line("CALSCALE", "GREGORIAN")
+	c.line("METHOD", "PUBLISH")
+	if name != "" {
+		c.line("X-WR-CALNAME", escapeICSText(name))
+	}
+	if refresh > 0 {
+		c.line("REFRESH-INTERVAL;VALUE=DURATION", icsDuration(refresh))
+		c.line("X-PUBLISHED-TTL", icsDuration(refresh))
+	}
+	return c
+}
+
+// finish writes the time zones and events and closes the calendar
+func (c *icsCalendar) finish() string {
+	names := make([]string, 0, len(c.zones))
+	for name := range c.zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c.timeZone(c.zones[name])
+	}
+	c.b.WriteString(c.events.String())
+	c.line("END", "VCALENDAR")
+	return c.b.String()
+}
+
+// line writes a content line, folding it so no physical line exceeds the
+// octet limit and no UTF-8 sequence is split
+func (c *icsCalendar) line(name, value string) {
+	writeICSLine(&c.b, name, value)
+}
+
+func (b *icsCalendarBody) line(name, value string) {
+	writeICSLine(&b.Builder, name, value)
+}
+
+func writeICSLine(b *strings.Builder, name, value string) {
+	l := name + ":" + value
+	limit := icsLineLimit
+	for len(l) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(l[cut]) {
+			cut--
+		}
+		b.WriteString(l[:cut])
+		b.WriteString("\r\n ")
+		l = l[cut:]
+		// Continuation lines spend one octet on the leading space
+		limit = icsLineLimit - 1
+	}
+	b.WriteString(l)
+	b.WriteString("\r\n")
+}
+
+// timeProp writes a DATE-TIME property in UTC, or as local time with a TZID
+// for series reckoned in a time zone so clients keep them on local time
+// across DST changes
+func (c *icsCalendar) timeProp(name string, t time.Time, loc *time.Location) {
+	if loc == time.UTC {
+		c.events.line(name, t.UTC().Format(icsTimeLayout)+"Z")
+		return
+	}
+	c.events.line(name+";TZID="+loc.String(), t.In(loc).Format(icsTimeLayout))
+}
+
+// useZone records that an event spanning [from, to] is written in loc
+func (c *icsCalendar) useZone(loc *time.Location, from, to time.Time) {
+	if loc == time.UTC {
+		return
+	}
+	zone, ok := c.zones[loc.String()]
+	if !ok {
+		c.zones[loc.String()] = &icsZone{loc: loc, from: from, to: to}
+		return
+	}
+	if from.Before(zone.from) {
+		zone.from = from
+	}
+	if to.After(zone.to) {
+		zone.to = to
+	}
+}
+
+// timeZone writes a VTIMEZONE with one observance per UTC offset change
+// in the zone's span, found by probing the zone database
+func (c *icsCalendar) timeZone(zone *icsZone) {
+	c.line("BEGIN", "VTIMEZONE")
+	c.line("TZID", zone.loc.String())
+
+	// Start from the offset in force at the beginning of the first year
+	at := time.Date(zone.from.In(zone.loc).Year(), 1, 1, 0, 0, 0, 0, zone.loc)
+	name, offset := at.Zone()
+	c.observance(at, at, offset)
+
+	for day := at; day.Before(zone.to); {
+		next := day.Add(24 * time.Hour)
+		if _, o := next.Zone(); o == offset {
+			day = next
+			continue
+		}
+		// Narrow the change down to the second
+		lo, hi := day, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, o := mid.Zone(); o == offset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		prev := offset
+		name, offset = hi.Zone()
+		c.observance(hi, hi.In(time.FixedZone(name, prev)), prev)
+		day = hi
+	}
+	c.line("END", "VTIMEZONE")
+}
+
+// observance writes the STANDARD or DAYLIGHT block for the offset that takes
+// effect at t. Its DTSTART is wall-clock time under the offset it replaces.
+func (c *icsCalendar) observance(t, wall time.Time, offsetFrom int) {
+	kind := "STANDARD"
+	if t.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	name, offset := t.Zone()
+	c.line("BEGIN", kind)
+	c.line("DTSTART", wall.Format(icsTimeLayout))
+	c.line("TZOFFSETFROM", icsUTCOffset(offsetFrom))
+	c.line("TZOFFSETTO", icsUTCOffset(offset))
+	if name != "" {
+		c.line("TZNAME", escapeICSText(name))
+	}
+	c.line("END", kind)
+}
+
+// addEvent writes an event. Recurring events become one VEVENT with an RRULE,
+// plus one per edited occurrence; cancelled occurrences and those viewerID
+// declined are excluded.
+func (c *icsCalendar) addEvent(event *models.Event, viewerID primitive.ObjectID) {
+	uid := event.ID.Hex() + "@connectify"
+	loc := icsLocation(event)
+	stamp := event.UpdatedAt
+	if stamp.IsZero() {
+		stamp = event.CreatedAt
+	}
+	c.useZone(loc, event.StartDate, icsSeriesEnd(event))
+
+	c.events.line("BEGIN", "VEVENT")
+	c.events.line("UID", uid)
+	c.events.line("DTSTAMP", stamp.UTC().Format(icsTimeLayout)+"Z")
+	c.timeProp("DTSTART", event.StartDate, loc)
+	if !event.EndDate.IsZero() {
+		c.timeProp("DTEND", event.EndDate, loc)
+	}
+	c.eventDetails(event, event.Title, event.Description, event.Location)
+
+	excluded := map[int64]bool{}
+	if event.Recurrence != nil {
+		c.events.line("RRULE", icsRRule(event.Recurrence))
+		for _, t := range excludedOccurrences(event, viewerID) {
+			if excluded[t.UnixNano()] {
+				continue
+			}
+			excluded[t.UnixNano()] = true
+			c.timeProp("EXDATE", t, loc)
+		}
+	}
+	c.events.line("END", "VEVENT")
+
+	for _, override := range event.OccurrenceOverrides {
+		if override.Cancelled || excluded[override.OccurrenceStart.UnixNano()] {
+			continue
+		}
+		start := override.OccurrenceStart
+		if override.StartDate != nil {
+			start = *override.StartDate
+		}
+		end := time.Time{}
+		if override.EndDate != nil {
+			end = *override.EndDate
+		} else if !event.EndDate.IsZero() {
+			end = start.Add(event.EndDate.Sub(event.StartDate))
+		}
+		c.useZone(loc, start, end)
+
+		c.events.line("BEGIN", "VEVENT")
+		c.events.line("UID", uid)
+		c.events.line("DTSTAMP", stamp.UTC().Format(icsTimeLayout)+"Z")
+		c.timeProp("RECURRENCE-ID", override.OccurrenceStart, loc)
+		c.timeProp("DTSTART", start, loc)
+		if !end.IsZero() {
+			c.timeProp("DTEND", end, loc)
+		}
+		c.eventDetails(event, firstNonEmpty(override.Title, event.Title),
+			firstNonEmpty(override.Description, event.Description),
+			firstNonEmpty(override.Location, event.Location))
+		c.events.line("END", "VEVENT")
+	}
+}
+
+func (c *icsCalendar) eventDetails(event *models.Event, title, description, location string) {
+	c.events.line("SUMMARY", escapeICSText(title))
+	if description != "" {
+		c.events.line("DESCRIPTION", escapeICSText(description))
+	}
+	if location == "" && event.IsOnline {
+		location = "Online"
+	}
+	if location != "" {
+		c.events.line("LOCATION", escapeICSText(location))
+	}
+	if !event.IsOnline && len(event.Coordinates) == 2 {
+		// Coordinates are GeoJSON [lng, lat]; GEO is lat;lng
+		c.events.line("GEO", strconv.FormatFloat(event.Coordinates[1], 'f', -1, 64)+";"+strconv.FormatFloat(event.Coordinates[0], 'f', -1, 64))
+	}
+	c.events.line("STATUS", "CONFIRMED")
+}
+
+// icsLocation returns the zone a series is reckoned in, or UTC
+func icsLocation(event *models.Event) *time.Location {
+	if event.Recurrence == nil || event.Recurrence.TimeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(event.Recurrence.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// icsSeriesEnd returns when an event's last occurrence starts, assuming
+// open-ended series run for icsOpenSeriesYears
+func icsSeriesEnd(event *models.Event) time.Time {
+	if event.Recurrence == nil {
+		return event.EndDate
+	}
+	if event.RecurrenceEnd != nil {
+		return *event.RecurrenceEnd
+	}
+	return event.StartDate.AddDate(icsOpenSeriesYears, 0, 0)
+}
+
+func icsRRule(rule *models.RecurrenceRule) string {
+	parts := []string{"FREQ=" + string(rule.Frequency)}
+	if rule.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(rule.Interval))
+	}
+	if len(rule.ByDay) > 0 {
+		parts = append(parts, "BYDAY="+strings.Join(rule.ByDay, ","))
+	}
+	if rule.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(rule.Count))
+	}
+	if rule.Until != nil {
+		parts = append(parts, "UNTIL="+rule.Until.UTC().Format(icsTimeLayout)+"Z")
+	}
+	return strings.Join(parts, ";")
+}
+
+// excludedOccurrences returns the cancelled occurrences of a series and
+// those viewerID said they are not going to
+func excludedOccurrences(event *models.Event, viewerID primitive.ObjectID) []time.Time {
+	var excluded []time.Time
+	for _, override := range event.OccurrenceOverrides {
+		if override.Cancelled {
+			excluded = append(excluded, override.OccurrenceStart)
+		}
+	}
+	if !viewerID.IsZero() {
+		for _, rsvp := range event.OccurrenceRSVPs {
+			if rsvp.UserID == viewerID && rsvp.Status == models.RSVPStatusNotGoing {
+				excluded = append(excluded, rsvp.OccurrenceStart)
+			}
+		}
+	}
+	return excluded
+}
+
+func escapeICSText(s string) string {
+	return icsTextEscaper.Replace(s)
+}
+
+// icsUTCOffset formats an offset in seconds east of UTC as +HHMM, or
+// +HHMMSS when it is not whole minutes
+func icsUTCOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	offset := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds/60%60)
+	if seconds%60 != 0 {
+		offset += fmt.Sprintf("%02d", seconds%60)
+	}
+	return offset
+}
+
+// icsDuration formats a whole-minute duration as an RFC 5545 DURATION
+func icsDuration(d time.Duration) string {
+	minutes := int64(d / time.Minute)
+	if minutes%60 == 0 {
+		return fmt.Sprintf("PT%dH", minutes/60)
+	}
+	return fmt.Sprintf("PT%dM", minutes)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/service/mocks"
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/service/testutil"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestICSLineFolding tests that long lines fold at 75 octets without splitting runes
+func TestICSLineFolding(t *testing.T) {
+	cal := &icsCalendar{}
+	cal.line("DESCRIPTION", strings.Repeat("é", 100))
+	doc := cal.b.String()
+
+	assert.True(t, strings.HasSuffix(doc, "\r\n"))
+	var unfolded strings.Builder
+	for i, l := range strings.Split(strings.TrimSuffix(doc, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(l), icsLineLimit)
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(l, " "))
+			l = l[1:]
+		}
+		assert.True(t, strings.ToValidUTF8(l, "?") == l, "line %d splits a rune", i)
+		unfolded.WriteString(l)
+	}
+	assert.Equal(t, "DESCRIPTION:"+strings.Repeat("é", 100), unfolded.String())
+}
+
+// TestEscapeICSText tests escaping of TEXT values
+func TestEscapeICSText(t *testing.T) {
+	assert.Equal(t, `a\;b\,c\\d\ne`, escapeICSText("a;b,c\\d\r\ne"))
+}
+
+// TestICSCalendar_AddEvent tests the VEVENTs written for single and recurring events
+func TestICSCalendar_AddEvent(t *testing.T) {
+	viewerID := primitive.NewObjectID()
+
+	t.Run("single event in UTC", func(t *testing.T) {
+		event := testutil.NewEventBuilder().
+			WithTitle("Launch, party").
+			WithLocation("Dhaka", 23.8103, 90.4125).
+			WithDates(date(2026, 3, 1, 18), date(2026, 3, 1, 21)).
+			Build()
+
+		cal := newICSCalendar("", 0)
+		cal.addEvent(event, viewerID)
+		doc := cal.finish()
+
+		assert.True(t, strings.HasPrefix(doc, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		assert.True(t, strings.HasSuffix(doc, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+		assert.Contains(t, doc, "UID:"+event.ID.Hex()+"@connectify\r\n")
+		assert.Contains(t, doc, "DTSTART:20260301T180000Z\r\n")
+		assert.Contains(t, doc, "DTEND:20260301T210000Z\r\n")
+		assert.Contains(t, doc, `SUMMARY:Launch\, party`)
+		assert.Contains(t, doc, "GEO:23.8103;90.4125\r\n")
+		assert.NotContains(t, doc, "RRULE")
+	})
+
+	t.Run("recurring event with overrides", func(t *testing.T) {
+		until := date(2026, 3, 31, 0)
+		event := testutil.NewEventBuilder().
+			WithDates(date(2026, 3, 2, 17), date(2026, 3, 2, 18)).
+			WithRecurrence(&models.RecurrenceRule{
+				Frequency: models.RecurrenceWeekly,
+				Interval:  2,
+				ByDay:     []string{"MO", "WE"},
+				Until:     &until,
+				TimeZone:  "Asia/Dhaka",
+			}).
+			Build()
+		moved := date(2026, 3, 4, 19)
+		event.OccurrenceOverrides = []models.EventOccurrenceOverride{
+			{OccurrenceStart: date(2026, 3, 16, 17), Cancelled: true},
+			{OccurrenceStart: date(2026, 3, 4, 17), Title: "Moved", StartDate: &moved},
+		}
+		event.OccurrenceRSVPs = []models.OccurrenceRSVP{
+			{UserID: viewerID, OccurrenceStart: date(2026, 3, 16, 17), Status: models.RSVPStatusNotGoing},
+			{UserID: viewerID, OccurrenceStart: date(2026, 3, 18, 17), Status: models.RSVPStatusNotGoing},
+		}
+
+		cal := newICSCalendar("", 0)
+		cal.addEvent(event, viewerID)
+		doc := cal.finish()
+
+		assert.Contains(t, doc, "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;UNTIL=20260331T000000Z\r\n")
+		assert.Contains(t, doc, "DTSTART;TZID=Asia/Dhaka:20260302T230000\r\n")
+		assert.Equal(t, 1, strings.Count(doc, "EXDATE;TZID=Asia/Dhaka:20260316T230000\r\n"))
+		assert.Contains(t, doc, "EXDATE;TZID=Asia/Dhaka:20260318T230000\r\n")
+		assert.Contains(t, doc, "RECURRENCE-ID;TZID=Asia/Dhaka:20260304T230000\r\n")
+		assert.Contains(t, doc, "DTSTART;TZID=Asia/Dhaka:20260305T010000\r\n")
+		assert.Contains(t, doc, "DTEND;TZID=Asia/Dhaka:20260305T020000\r\n")
+		assert.Contains(t, doc, "SUMMARY:Moved\r\n")
+		assert.Equal(t, 2, strings.Count(doc, "BEGIN:VEVENT"))
+	})
+}
+
+// TestEventService_CalendarFeedICS tests serving a subscription feed by token
+func TestEventService_CalendarFeedICS(t *testing.T) {
+	ownerID := primitive.NewObjectID()
+	event := testutil.NewEventBuilder().WithAttendee(ownerID, models.RSVPStatusGoing).Build()
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid token", token: "valid"},
+		{name: "unknown token", token: "unknown", wantErr: ErrCalendarFeedNotFound},
+		{name: "empty token", token: "", wantErr: ErrCalendarFeedNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockEventRepository{}
+			mockFeeds := &mocks.MockCalendarFeedRepository{}
+			mockCache := &mocks.MockEventCache{}
+			var filter bson.M
+			mockRepo.ListFunc = func(ctx context.Context, limit, page int64, f bson.M) ([]models.Event, int64, error) {
+				filter = f
+				return []models.Event{*event}, 1, nil
+			}
+			mockFeeds.GetByTokenFunc = func(ctx context.Context, token string) (*models.CalendarFeed, error) {
+				if token != "valid" {
+					return nil, nil
+				}
+				return &models.CalendarFeed{UserID: ownerID, Token: token}, nil
+			}
+			var cachedFor string
+			mockCache.LoadCalendarFeedFunc = func(ctx context.Context, userID string, load func(ctx context.Context) (string, error)) (string, error) {
+				cachedFor = userID
+				return load(ctx)
+			}
+
+			svc := &EventService{eventRepo: mockRepo, calendarFeeds: mockFeeds, eventCache: mockCache}
+			doc, err := svc.CalendarFeedICS(context.Background(), tt.token)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Equal(t, ownerID.Hex(), cachedFor)
+			assert.Contains(t, doc, "UID:"+event.ID.Hex()+"@connectify")
+			assert.Contains(t, doc, "REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+			assert.Equal(t, bson.M{"user_id": ownerID, "status": models.RSVPStatusGoing},
+				filter["attendees"].(bson.M)["$elemMatch"])
+		})
+	}
+}
+
+// TestEventService_GetCalendarFeed tests the subscription URLs returned for a feed
+func TestEventService_GetCalendarFeed(t *testing.T) {
+	userID := primitive.NewObjectID()
+	svc := &EventService{}
+	svc.SetCalendarFeeds(&mocks.MockCalendarFeedRepository{}, "https://connectify.example/")
+
+	feed, err := svc.GetCalendarFeed(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(feed.URL, "https://connectify.example/api/events/calendar/feeds/"))
+	assert.True(t, strings.HasSuffix(feed.URL, ".ics"))
+	assert.Equal(t, "webcal"+strings.TrimPrefix(feed.URL, "https"), feed.WebcalURL)
+	assert.WithinDuration(t, time.Now(), feed.CreatedAt, time.Minute)
+}
+
+// TestICSCalendar_TimeZone tests that referenced zones get a VTIMEZONE with their DST changes
+func TestICSCalendar_TimeZone(t *testing.T) {
+	until := date(2026, 12, 31, 0)
+	event := testutil.NewEventBuilder().
+		WithDates(date(2026, 1, 5, 17), date(2026, 1, 5, 18)).
+		WithRecurrence(&models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Until: &until, TimeZone: "America/New_York"}).
+		Build()
+	event.RecurrenceEnd = &until
+
+	cal := newICSCalendar("", 0)
+	cal.addEvent(event, primitive.NilObjectID)
+	cal.addEvent(event, primitive.NilObjectID)
+	doc := cal.finish()
+
+	assert.Equal(t, 1, strings.Count(doc, "BEGIN:VTIMEZONE"))
+	assert.Less(t, strings.Index(doc, "END:VTIMEZONE"), strings.Index(doc, "BEGIN:VEVENT"))
+	assert.Contains(t, doc, "BEGIN:DAYLIGHT\r\nDTSTART:20260308T020000\r\nTZOFFSETFROM:-0500\r\nTZOFFSETTO:-0400\r\n")
+	assert.Contains(t, doc, "BEGIN:STANDARD\r\nDTSTART:20261101T020000\r\nTZOFFSETFROM:-0400\r\nTZOFFSETTO:-0500\r\n")
+	assert.Contains(t, doc, "DTSTART;TZID=America/New_York:20260105T120000\r\n")
+}
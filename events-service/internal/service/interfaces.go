@@ -2,9 +2,11 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/MuhibNayem/connectify-v2/events-service/internal/integration"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -17,7 +19,11 @@ type EventRepository interface {
 	Delete(ctx context.Context, id primitive.ObjectID) error
 	List(ctx context.Context, limit, page int64, filter bson.M) ([]models.Event, int64, error)
 	AddOrUpdateAttendee(ctx context.Context, eventID primitive.ObjectID, attendee models.EventAttendee) error
+	SetOccurrenceRSVP(ctx context.Context, eventID primitive.ObjectID, rsvp models.OccurrenceRSVP) error
 	RemoveAttendee(ctx context.Context, eventID, userID primitive.ObjectID) error
+	RemoveUser(ctx context.Context, userID primitive.ObjectID) (int64, error)
+	ReserveSeat(ctx context.Context, eventID primitive.ObjectID) (bool, error)
+	ReleaseSeat(ctx context.Context, eventID primitive.ObjectID) error
 	UpdateStats(ctx context.Context, eventID primitive.ObjectID, stats models.EventStats) error
 	GetUserEvents(ctx context.Context, userID primitive.ObjectID, limit, page int64) ([]models.Event, error)
 	GetAttendeesByStatus(ctx context.Context, eventID primitive.ObjectID, status models.RSVPStatus, limit, page int64) ([]models.EventAttendee, int64, error)
@@ -26,10 +32,59 @@ type EventRepository interface {
 	AddCoHost(ctx context.Context, eventID primitive.ObjectID, coHost models.EventCoHost) error
 	RemoveCoHost(ctx context.Context, eventID, userID primitive.ObjectID) error
 	IsCoHost(ctx context.Context, eventID, userID primitive.ObjectID) (bool, error)
+	SetCoHostPermissions(ctx context.Context, eventID, userID primitive.ObjectID, perms models.CoHostPermission) (bool, error)
 	Search(ctx context.Context, query string, filter bson.M, limit, page int64) ([]models.Event, int64, error)
 	GetNearbyEvents(ctx context.Context, lat, lng, radiusKm float64, limit, page int64) ([]models.Event, int64, error)
 }
 
+// TicketRepo defines interface for event ticket persistence
+type TicketRepo interface {
+	GetByEventAndUser(ctx context.Context, eventID, userID primitive.ObjectID) (*models.EventTicket, error)
+	Issue(ctx context.Context, ticket *models.EventTicket) (bool, error)
+	Transition(ctx context.Context, id primitive.ObjectID, from, to models.EventTicketStatus) (bool, error)
+	NextWaitlisted(ctx context.Context, eventID primitive.ObjectID) (*models.EventTicket, error)
+	WaitlistPosition(ctx context.Context, ticket *models.EventTicket) (int64, error)
+	CountByStatus(ctx context.Context, eventID primitive.ObjectID, status models.EventTicketStatus) (int64, error)
+	DeleteByEventID(ctx context.Context, eventID primitive.ObjectID) error
+	ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.EventTicket, error)
+}
+
+type CalendarFeedRepo interface {
+	GetOrCreate(ctx context.Context, userID primitive.ObjectID, token string) (*models.CalendarFeed, error)
+	GetByToken(ctx context.Context, token string) (*models.CalendarFeed, error)
+	DeleteByUser(ctx context.Context, userID primitive.ObjectID) error
+}
+
+type ReminderRepo interface {
+	Schedule(ctx context.Context, reminder *models.EventReminder) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, maxAttempts int) (*models.EventReminder, error)
+	Complete(ctx context.Context, id primitive.ObjectID, status models.EventReminderStatus) error
+	Reschedule(ctx context.Context, id primitive.ObjectID, dueAt time.Time) error
+	DeletePending(ctx context.Context, eventID primitive.ObjectID) error
+	DeleteByEventID(ctx context.Context, eventID primitive.ObjectID) error
+	SetOptOut(ctx context.Context, eventID, userID primitive.ObjectID, optOut bool) error
+	IsOptedOut(ctx context.Context, eventID, userID primitive.ObjectID) (bool, error)
+	OptedOutUsers(ctx context.Context, eventID primitive.ObjectID) (map[primitive.ObjectID]bool, error)
+}
+
+// SearchIndexer publishes event changes for search-service to index
+type SearchIndexer interface {
+	UpsertEvent(ctx context.Context, doc *models.SearchEventDocument)
+	Delete(ctx context.Context, entity models.SearchEntityType, id string)
+}
+
+// BlockChecker resolves which users have blocked each other
+type BlockChecker interface {
+	GetBlockedIDs(ctx context.Context, userID string) ([]string, error)
+}
+
+// ContentModerator screens event text against the moderation rules and
+// queues what they catch for review
+type ContentModerator interface {
+	Screen(ctx context.Context, text string, communityID *primitive.ObjectID) moderation.Decision
+	Report(ctx context.Context, decision moderation.Decision, item models.ModerationQueueItem, text string)
+}
+
 // UserRepo defines interface for user interactions
 type UserRepo interface {
 	FindByID(ctx context.Context, id primitive.ObjectID) (*integration.EventUser, error)
@@ -70,6 +125,7 @@ type InvitationRepo interface {
 	GetUserInvitations(ctx context.Context, userID primitive.ObjectID, status models.EventInvitationStatus, limit, page int64) ([]models.EventInvitation, int64, error)
 	GetByID(ctx context.Context, id primitive.ObjectID) (*models.EventInvitation, error)
 	UpdateStatus(ctx context.Context, id primitive.ObjectID, status models.EventInvitationStatus) error
+	DeleteByUser(ctx context.Context, userID primitive.ObjectID) (int64, error)
 }
 
 // PostRepo defines interface for event posts
@@ -77,9 +133,12 @@ type PostRepo interface {
 	Create(ctx context.Context, post *models.EventPost) error
 	GetByID(ctx context.Context, id primitive.ObjectID) (*models.EventPost, error)
 	GetByEventID(ctx context.Context, eventID primitive.ObjectID, limit, page int64) ([]models.EventPost, int64, error)
+	GetByAuthorID(ctx context.Context, authorID primitive.ObjectID) ([]models.EventPost, error)
 	Update(ctx context.Context, post *models.EventPost) error
 	Delete(ctx context.Context, id primitive.ObjectID) error
 	DeleteByEventID(ctx context.Context, eventID primitive.ObjectID) error
+	DeleteByAuthorID(ctx context.Context, authorID primitive.ObjectID) (int64, error)
+	RemoveUserReactions(ctx context.Context, userID primitive.ObjectID) (int64, error)
 	AddReaction(ctx context.Context, postID primitive.ObjectID, reaction models.EventPostReaction) error
 	RemoveReaction(ctx context.Context, postID, userID primitive.ObjectID) error
 	GetPostCount(ctx context.Context, eventID primitive.ObjectID) (int64, error)
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockCalendarFeedRepository is a mock implementation of CalendarFeedRepo for testing
+type MockCalendarFeedRepository struct {
+	GetOrCreateFunc  func(ctx context.Context, userID primitive.ObjectID, token string) (*models.CalendarFeed, error)
+	GetByTokenFunc   func(ctx context.Context, token string) (*models.CalendarFeed, error)
+	DeleteByUserFunc func(ctx context.Context, userID primitive.ObjectID) error
+
+	// Tracking calls for verification
+	DeleteByUserCalls int
+}
+
+func (m *MockCalendarFeedRepository) GetOrCreate(ctx context.Context, userID primitive.ObjectID, token string) (*models.CalendarFeed, error) {
+	if m.GetOrCreateFunc != nil {
+		return m.GetOrCreateFunc(ctx, userID, token)
+	}
+	return &models.CalendarFeed{ID: primitive.NewObjectID(), UserID: userID, Token: token, CreatedAt: time.Now()}, nil
+}
+
+func (m *MockCalendarFeedRepository) GetByToken(ctx context.Context, token string) (*models.CalendarFeed, error) {
+	if m.GetByTokenFunc != nil {
+		return m.GetByTokenFunc(ctx, token)
+	}
+	return nil, nil
+}
+
+func (m *MockCalendarFeedRepository) DeleteByUser(ctx context.Context, userID primitive.ObjectID) error {
+	m.DeleteByUserCalls++
+	if m.DeleteByUserFunc != nil {
+		return m.DeleteByUserFunc(ctx, userID)
+	}
+	return nil
+}
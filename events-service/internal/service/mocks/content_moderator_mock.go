@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockContentModerator screens text with a fixed rule set and records the
+// queued items instead of storing them
+type MockContentModerator struct {
+	Rules *moderation.RuleSet
+
+	// Call tracking
+	Reports []models.ModerationQueueItem
+}
+
+func NewMockContentModerator(rules ...models.ModerationRule) *MockContentModerator {
+	return &MockContentModerator{Rules: moderation.Compile(rules)}
+}
+
+func (m *MockContentModerator) Screen(ctx context.Context, text string, communityID *primitive.ObjectID) moderation.Decision {
+	return m.Rules.Evaluate(text)
+}
+
+func (m *MockContentModerator) Report(ctx context.Context, decision moderation.Decision, item models.ModerationQueueItem, text string) {
+	item.Action = decision.Action
+	item.Rules = decision.Rules
+	m.Reports = append(m.Reports, item)
+}
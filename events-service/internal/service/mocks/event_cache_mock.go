@@ -25,10 +25,12 @@ type MockEventCache struct {
 		UserID  string
 		EventID string
 	}
-	GetCategoriesFunc     func(ctx context.Context) ([]models.EventCategory, error)
-	SetCategoriesFunc     func(ctx context.Context, categories []models.EventCategory) error
+	LoadCategoriesFunc    func(ctx context.Context, load func(ctx context.Context) ([]models.EventCategory, error)) ([]models.EventCategory, error)
 	GetTrendingEventsFunc func(ctx context.Context) ([]string, error)
 	SetTrendingEventsFunc func(ctx context.Context, eventIDs []string) error
+	LoadCalendarFeedFunc  func(ctx context.Context, userID string, load func(ctx context.Context) (string, error)) (string, error)
+
+	InvalidateCalendarFeedCalls []string
 }
 
 func (m *MockEventCache) SetEventStats(ctx context.Context, eventID string, stats *models.EventStats) error {
@@ -64,18 +66,11 @@ func (m *MockEventCache) InvalidateFriendsGoing(ctx context.Context, userID, eve
 	return nil
 }
 
-func (m *MockEventCache) GetCategories(ctx context.Context) ([]models.EventCategory, error) {
-	if m.GetCategoriesFunc != nil {
-		return m.GetCategoriesFunc(ctx)
-	}
-	return nil, nil
-}
-
-func (m *MockEventCache) SetCategories(ctx context.Context, categories []models.EventCategory) error {
-	if m.SetCategoriesFunc != nil {
-		return m.SetCategoriesFunc(ctx, categories)
+func (m *MockEventCache) LoadCategories(ctx context.Context, load func(ctx context.Context) ([]models.EventCategory, error)) ([]models.EventCategory, error) {
+	if m.LoadCategoriesFunc != nil {
+		return m.LoadCategoriesFunc(ctx, load)
 	}
-	return nil
+	return load(ctx)
 }
 
 func (m *MockEventCache) GetTrendingEvents(ctx context.Context) ([]string, error) {
@@ -91,3 +86,15 @@ func (m *MockEventCache) SetTrendingEvents(ctx context.Context, eventIDs []strin
 	}
 	return nil
 }
+
+func (m *MockEventCache) LoadCalendarFeed(ctx context.Context, userID string, load func(ctx context.Context) (string, error)) (string, error) {
+	if m.LoadCalendarFeedFunc != nil {
+		return m.LoadCalendarFeedFunc(ctx, userID, load)
+	}
+	return load(ctx)
+}
+
+func (m *MockEventCache) InvalidateCalendarFeed(ctx context.Context, userID string) error {
+	m.InvalidateCalendarFeedCalls = append(m.InvalidateCalendarFeedCalls, userID)
+	return nil
+}
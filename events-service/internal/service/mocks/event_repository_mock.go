@@ -16,7 +16,11 @@ type MockEventRepository struct {
 	DeleteFunc               func(ctx context.Context, id primitive.ObjectID) error
 	ListFunc                 func(ctx context.Context, limit, page int64, filter bson.M) ([]models.Event, int64, error)
 	AddOrUpdateAttendeeFunc  func(ctx context.Context, eventID primitive.ObjectID, attendee models.EventAttendee) error
+	SetOccurrenceRSVPFunc    func(ctx context.Context, eventID primitive.ObjectID, rsvp models.OccurrenceRSVP) error
 	RemoveAttendeeFunc       func(ctx context.Context, eventID, userID primitive.ObjectID) error
+	RemoveUserFunc           func(ctx context.Context, userID primitive.ObjectID) (int64, error)
+	ReserveSeatFunc          func(ctx context.Context, eventID primitive.ObjectID) (bool, error)
+	ReleaseSeatFunc          func(ctx context.Context, eventID primitive.ObjectID) error
 	UpdateStatsFunc          func(ctx context.Context, eventID primitive.ObjectID, stats models.EventStats) error
 	GetUserEventsFunc        func(ctx context.Context, userID primitive.ObjectID, limit, page int64) ([]models.Event, error)
 	GetAttendeesByStatusFunc func(ctx context.Context, eventID primitive.ObjectID, status models.RSVPStatus, limit, page int64) ([]models.EventAttendee, int64, error)
@@ -25,6 +29,7 @@ type MockEventRepository struct {
 	AddCoHostFunc            func(ctx context.Context, eventID primitive.ObjectID, coHost models.EventCoHost) error
 	RemoveCoHostFunc         func(ctx context.Context, eventID, userID primitive.ObjectID) error
 	IsCoHostFunc             func(ctx context.Context, eventID, userID primitive.ObjectID) (bool, error)
+	SetCoHostPermissionsFunc func(ctx context.Context, eventID, userID primitive.ObjectID, perms models.CoHostPermission) (bool, error)
 	SearchFunc               func(ctx context.Context, query string, filter bson.M, limit, page int64) ([]models.Event, int64, error)
 	GetNearbyEventsFunc      func(ctx context.Context, lat, lng, radiusKm float64, limit, page int64) ([]models.Event, int64, error)
 
@@ -34,6 +39,8 @@ type MockEventRepository struct {
 	UpdateCalls              int
 	DeleteCalls              int
 	AddOrUpdateAttendeeCalls int
+	ReserveSeatCalls         int
+	ReleaseSeatCalls         int
 }
 
 func (m *MockEventRepository) Create(ctx context.Context, event *models.Event) error {
@@ -83,6 +90,29 @@ func (m *MockEventRepository) AddOrUpdateAttendee(ctx context.Context, eventID p
 	return nil
 }
 
+func (m *MockEventRepository) SetOccurrenceRSVP(ctx context.Context, eventID primitive.ObjectID, rsvp models.OccurrenceRSVP) error {
+	if m.SetOccurrenceRSVPFunc != nil {
+		return m.SetOccurrenceRSVPFunc(ctx, eventID, rsvp)
+	}
+	return nil
+}
+
+func (m *MockEventRepository) ReserveSeat(ctx context.Context, eventID primitive.ObjectID) (bool, error) {
+	m.ReserveSeatCalls++
+	if m.ReserveSeatFunc != nil {
+		return m.ReserveSeatFunc(ctx, eventID)
+	}
+	return true, nil
+}
+
+func (m *MockEventRepository) ReleaseSeat(ctx context.Context, eventID primitive.ObjectID) error {
+	m.ReleaseSeatCalls++
+	if m.ReleaseSeatFunc != nil {
+		return m.ReleaseSeatFunc(ctx, eventID)
+	}
+	return nil
+}
+
 func (m *MockEventRepository) RemoveAttendee(ctx context.Context, eventID, userID primitive.ObjectID) error {
 	if m.RemoveAttendeeFunc != nil {
 		return m.RemoveAttendeeFunc(ctx, eventID, userID)
@@ -90,6 +120,13 @@ func (m *MockEventRepository) RemoveAttendee(ctx context.Context, eventID, userI
 	return nil
 }
 
+func (m *MockEventRepository) RemoveUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	if m.RemoveUserFunc != nil {
+		return m.RemoveUserFunc(ctx, userID)
+	}
+	return 0, nil
+}
+
 func (m *MockEventRepository) UpdateStats(ctx context.Context, eventID primitive.ObjectID, stats models.EventStats) error {
 	if m.UpdateStatsFunc != nil {
 		return m.UpdateStatsFunc(ctx, eventID, stats)
@@ -146,6 +183,13 @@ func (m *MockEventRepository) IsCoHost(ctx context.Context, eventID, userID prim
 	return false, nil
 }
 
+func (m *MockEventRepository) SetCoHostPermissions(ctx context.Context, eventID, userID primitive.ObjectID, perms models.CoHostPermission) (bool, error) {
+	if m.SetCoHostPermissionsFunc != nil {
+		return m.SetCoHostPermissionsFunc(ctx, eventID, userID, perms)
+	}
+	return true, nil
+}
+
 func (m *MockEventRepository) Search(ctx context.Context, query string, filter bson.M, limit, page int64) ([]models.Event, int64, error) {
 	if m.SearchFunc != nil {
 		return m.SearchFunc(ctx, query, filter, limit, page)
@@ -0,0 +1,101 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockReminderRepository is a mock implementation of ReminderRepo for testing
+type MockReminderRepository struct {
+	ScheduleFunc        func(ctx context.Context, reminder *models.EventReminder) error
+	ClaimDueFunc        func(ctx context.Context, now time.Time, lease time.Duration, maxAttempts int) (*models.EventReminder, error)
+	CompleteFunc        func(ctx context.Context, id primitive.ObjectID, status models.EventReminderStatus) error
+	RescheduleFunc      func(ctx context.Context, id primitive.ObjectID, dueAt time.Time) error
+	DeletePendingFunc   func(ctx context.Context, eventID primitive.ObjectID) error
+	DeleteByEventIDFunc func(ctx context.Context, eventID primitive.ObjectID) error
+	SetOptOutFunc       func(ctx context.Context, eventID, userID primitive.ObjectID, optOut bool) error
+	IsOptedOutFunc      func(ctx context.Context, eventID, userID primitive.ObjectID) (bool, error)
+	OptedOutUsersFunc   func(ctx context.Context, eventID primitive.ObjectID) (map[primitive.ObjectID]bool, error)
+
+	// Tracking calls for verification
+	Scheduled          []models.EventReminder
+	Completed          map[primitive.ObjectID]models.EventReminderStatus
+	Rescheduled        map[primitive.ObjectID]time.Time
+	DeletePendingCalls int
+}
+
+func (m *MockReminderRepository) Schedule(ctx context.Context, reminder *models.EventReminder) error {
+	m.Scheduled = append(m.Scheduled, *reminder)
+	if m.ScheduleFunc != nil {
+		return m.ScheduleFunc(ctx, reminder)
+	}
+	return nil
+}
+
+func (m *MockReminderRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, maxAttempts int) (*models.EventReminder, error) {
+	if m.ClaimDueFunc != nil {
+		return m.ClaimDueFunc(ctx, now, lease, maxAttempts)
+	}
+	return nil, nil
+}
+
+func (m *MockReminderRepository) Complete(ctx context.Context, id primitive.ObjectID, status models.EventReminderStatus) error {
+	if m.Completed == nil {
+		m.Completed = map[primitive.ObjectID]models.EventReminderStatus{}
+	}
+	m.Completed[id] = status
+	if m.CompleteFunc != nil {
+		return m.CompleteFunc(ctx, id, status)
+	}
+	return nil
+}
+
+func (m *MockReminderRepository) Reschedule(ctx context.Context, id primitive.ObjectID, dueAt time.Time) error {
+	if m.Rescheduled == nil {
+		m.Rescheduled = map[primitive.ObjectID]time.Time{}
+	}
+	m.Rescheduled[id] = dueAt
+	if m.RescheduleFunc != nil {
+		return m.RescheduleFunc(ctx, id, dueAt)
+	}
+	return nil
+}
+
+func (m *MockReminderRepository) DeletePending(ctx context.Context, eventID primitive.ObjectID) error {
+	m.DeletePendingCalls++
+	if m.DeletePendingFunc != nil {
+		return m.DeletePendingFunc(ctx, eventID)
+	}
+	return nil
+}
+
+func (m *MockReminderRepository) DeleteByEventID(ctx context.Context, eventID primitive.ObjectID) error {
+	if m.DeleteByEventIDFunc != nil {
+		return m.DeleteByEventIDFunc(ctx, eventID)
+	}
+	return nil
+}
+
+func (m *MockReminderRepository) SetOptOut(ctx context.Context, eventID, userID primitive.ObjectID, optOut bool) error {
+	if m.SetOptOutFunc != nil {
+		return m.SetOptOutFunc(ctx, eventID, userID, optOut)
+	}
+	return nil
+}
+
+func (m *MockReminderRepository) IsOptedOut(ctx context.Context, eventID, userID primitive.ObjectID) (bool, error) {
+	if m.IsOptedOutFunc != nil {
+		return m.IsOptedOutFunc(ctx, eventID, userID)
+	}
+	return false, nil
+}
+
+func (m *MockReminderRepository) OptedOutUsers(ctx context.Context, eventID primitive.ObjectID) (map[primitive.ObjectID]bool, error) {
+	if m.OptedOutUsersFunc != nil {
+		return m.OptedOutUsersFunc(ctx, eventID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,83 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockTicketRepository is a mock implementation of TicketRepo for testing
+type MockTicketRepository struct {
+	GetByEventAndUserFunc func(ctx context.Context, eventID, userID primitive.ObjectID) (*models.EventTicket, error)
+	IssueFunc             func(ctx context.Context, ticket *models.EventTicket) (bool, error)
+	TransitionFunc        func(ctx context.Context, id primitive.ObjectID, from, to models.EventTicketStatus) (bool, error)
+	NextWaitlistedFunc    func(ctx context.Context, eventID primitive.ObjectID) (*models.EventTicket, error)
+	WaitlistPositionFunc  func(ctx context.Context, ticket *models.EventTicket) (int64, error)
+	CountByStatusFunc     func(ctx context.Context, eventID primitive.ObjectID, status models.EventTicketStatus) (int64, error)
+	DeleteByEventIDFunc   func(ctx context.Context, eventID primitive.ObjectID) error
+	ListByUserFunc        func(ctx context.Context, userID primitive.ObjectID) ([]models.EventTicket, error)
+
+	// Tracking calls for verification
+	IssueCalls      int
+	TransitionCalls int
+}
+
+func (m *MockTicketRepository) GetByEventAndUser(ctx context.Context, eventID, userID primitive.ObjectID) (*models.EventTicket, error) {
+	if m.GetByEventAndUserFunc != nil {
+		return m.GetByEventAndUserFunc(ctx, eventID, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockTicketRepository) Issue(ctx context.Context, ticket *models.EventTicket) (bool, error) {
+	m.IssueCalls++
+	if m.IssueFunc != nil {
+		return m.IssueFunc(ctx, ticket)
+	}
+	ticket.ID = primitive.NewObjectID()
+	return true, nil
+}
+
+func (m *MockTicketRepository) Transition(ctx context.Context, id primitive.ObjectID, from, to models.EventTicketStatus) (bool, error) {
+	m.TransitionCalls++
+	if m.TransitionFunc != nil {
+		return m.TransitionFunc(ctx, id, from, to)
+	}
+	return true, nil
+}
+
+func (m *MockTicketRepository) NextWaitlisted(ctx context.Context, eventID primitive.ObjectID) (*models.EventTicket, error) {
+	if m.NextWaitlistedFunc != nil {
+		return m.NextWaitlistedFunc(ctx, eventID)
+	}
+	return nil, nil
+}
+
+func (m *MockTicketRepository) WaitlistPosition(ctx context.Context, ticket *models.EventTicket) (int64, error) {
+	if m.WaitlistPositionFunc != nil {
+		return m.WaitlistPositionFunc(ctx, ticket)
+	}
+	return 1, nil
+}
+
+func (m *MockTicketRepository) CountByStatus(ctx context.Context, eventID primitive.ObjectID, status models.EventTicketStatus) (int64, error) {
+	if m.CountByStatusFunc != nil {
+		return m.CountByStatusFunc(ctx, eventID, status)
+	}
+	return 0, nil
+}
+
+func (m *MockTicketRepository) DeleteByEventID(ctx context.Context, eventID primitive.ObjectID) error {
+	if m.DeleteByEventIDFunc != nil {
+		return m.DeleteByEventIDFunc(ctx, eventID)
+	}
+	return nil
+}
+
+func (m *MockTicketRepository) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.EventTicket, error) {
+	if m.ListByUserFunc != nil {
+		return m.ListByUserFunc(ctx, userID)
+	}
+	return nil, nil
+}
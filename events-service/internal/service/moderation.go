@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SetModerator screens event details and discussion posts against the text
+// moderation rules
+func (s *EventService) SetModerator(moderator ContentModerator) {
+	s.moderator = moderator
+}
+
+// screenText refuses text a reject rule matches and returns the decision
+// for everything else. Events belong to no community, so only the global
+// rules apply.
+func (s *EventService) screenText(ctx context.Context, text string) (moderation.Decision, error) {
+	if s.moderator == nil || text == "" {
+		return moderation.Decision{}, nil
+	}
+	decision := s.moderator.Screen(ctx, text, nil)
+	if decision.Rejected() {
+		return decision, moderation.ErrContentRejected
+	}
+	return decision, nil
+}
+
+func (s *EventService) report(ctx context.Context, decision moderation.Decision, item models.ModerationQueueItem, text string) {
+	if s.moderator == nil || !decision.Matched() {
+		return
+	}
+	s.moderator.Report(ctx, decision, item, text)
+}
+
+func (s *EventService) reportEvent(ctx context.Context, decision moderation.Decision, eventID, authorID primitive.ObjectID, text string) {
+	s.report(ctx, decision, models.ModerationQueueItem{
+		ContentType: models.ModeratedEvent,
+		ContentID:   eventID.Hex(),
+		AuthorID:    authorID,
+	}, text)
+}
+
+// moderatedText joins the free-text fields of an event for screening
+func moderatedText(fields ...string) string {
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field != "" {
+			parts = append(parts, field)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// ApproveContent leaves the content as it is; events and event posts are
+// never held back
+func (s *EventService) ApproveContent(ctx context.Context, item *models.ModerationQueueItem) error {
+	return nil
+}
+
+// RemoveContent deletes an event or discussion post a moderator removed
+func (s *EventService) RemoveContent(ctx context.Context, item *models.ModerationQueueItem) error {
+	id, err := primitive.ObjectIDFromHex(item.ContentID)
+	if err != nil {
+		return nil
+	}
+
+	switch item.ContentType {
+	case models.ModeratedEvent:
+		if _, err := s.eventRepo.GetByID(ctx, id); err != nil {
+			if err.Error() == "event not found" {
+				return nil
+			}
+			return err
+		}
+		return s.removeEvent(ctx, id)
+	case models.ModeratedEventPost:
+		return s.postRepo.Delete(ctx, id)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/pkg/async"
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/service/mocks"
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/service/testutil"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func moderationRule(name string, action models.ModerationAction, patterns ...string) models.ModerationRule {
+	return models.ModerationRule{
+		Name:     name,
+		Kind:     models.ModerationRuleKeyword,
+		Patterns: patterns,
+		Action:   action,
+		Enabled:  true,
+	}
+}
+
+func TestEventService_CreateEvent_Moderation(t *testing.T) {
+	moderator := mocks.NewMockContentModerator(
+		moderationRule("spam", models.ModerationActionFlag, "free money"),
+		moderationRule("slurs", models.ModerationActionReject, "badword"),
+	)
+
+	t.Run("rejected text is not stored", func(t *testing.T) {
+		repo := &mocks.MockEventRepository{}
+		svc := &EventService{eventRepo: repo, asyncRunner: async.NewRunner(slog.Default()), moderator: moderator}
+
+		req := testutil.NewCreateEventRequestBuilder().WithDescription("a BADWORD party").Build()
+		_, err := svc.CreateEvent(context.Background(), primitive.NewObjectID(), *req)
+
+		assert.ErrorIs(t, err, moderation.ErrContentRejected)
+		assert.Equal(t, 0, repo.CreateCalls)
+		assert.Empty(t, moderator.Reports)
+	})
+
+	t.Run("flagged event is created and queued", func(t *testing.T) {
+		repo := &mocks.MockEventRepository{
+			CreateFunc: func(ctx context.Context, event *models.Event) error {
+				event.ID = primitive.NewObjectID()
+				return nil
+			},
+		}
+		svc := &EventService{eventRepo: repo, asyncRunner: async.NewRunner(slog.Default()), moderator: moderator}
+		userID := primitive.NewObjectID()
+
+		req := testutil.NewCreateEventRequestBuilder().WithTitle("Free money meetup").Build()
+		event, err := svc.CreateEvent(context.Background(), userID, *req)
+		require.NoError(t, err)
+
+		require.Len(t, moderator.Reports, 1)
+		item := moderator.Reports[0]
+		assert.Equal(t, models.ModeratedEvent, item.ContentType)
+		assert.Equal(t, event.ID.Hex(), item.ContentID)
+		assert.Equal(t, userID, item.AuthorID)
+		assert.Equal(t, models.ModerationActionFlag, item.Action)
+		assert.Equal(t, []string{"spam"}, item.Rules)
+	})
+}
+
+func TestEventService_UpdateEvent_RejectedText(t *testing.T) {
+	hostID := primitive.NewObjectID()
+	repo := &mocks.MockEventRepository{
+		GetByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*models.Event, error) {
+			return testutil.NewEventBuilder().WithID(id).WithCreatorID(hostID).Build(), nil
+		},
+	}
+	svc := &EventService{
+		eventRepo: repo,
+		moderator: mocks.NewMockContentModerator(moderationRule("slurs", models.ModerationActionReject, "badword")),
+	}
+
+	_, err := svc.UpdateEvent(context.Background(), primitive.NewObjectID(), hostID, models.UpdateEventRequest{Title: "badword"})
+
+	assert.ErrorIs(t, err, moderation.ErrContentRejected)
+	assert.Equal(t, 0, repo.UpdateCalls)
+}
+
+func TestEventService_RemoveContent(t *testing.T) {
+	eventID := primitive.NewObjectID()
+	item := &models.ModerationQueueItem{ContentType: models.ModeratedEvent, ContentID: eventID.Hex()}
+
+	t.Run("removes the event", func(t *testing.T) {
+		repo := &mocks.MockEventRepository{
+			GetByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*models.Event, error) {
+				return testutil.NewEventBuilder().WithID(eventID).Build(), nil
+			},
+		}
+		broadcaster := &mocks.MockEventBroadcaster{}
+		svc := &EventService{eventRepo: repo, broadcaster: broadcaster, asyncRunner: async.NewRunner(slog.Default())}
+
+		require.NoError(t, svc.RemoveContent(context.Background(), item))
+		assert.Equal(t, 1, repo.DeleteCalls)
+		assert.Equal(t, 1, broadcaster.PublishEventDeletedCalls)
+	})
+
+	t.Run("event already gone", func(t *testing.T) {
+		repo := &mocks.MockEventRepository{
+			GetByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*models.Event, error) {
+				return nil, errors.New("event not found")
+			},
+		}
+		broadcaster := &mocks.MockEventBroadcaster{}
+		svc := &EventService{eventRepo: repo, broadcaster: broadcaster, asyncRunner: async.NewRunner(slog.Default())}
+
+		require.NoError(t, svc.RemoveContent(context.Background(), item))
+		assert.Equal(t, 0, repo.DeleteCalls)
+		assert.Equal(t, 0, broadcaster.PublishEventDeletedCalls)
+	})
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrNotRecurring            = apperrors.Validation("event is not recurring")
+	ErrOccurrenceNotFound      = apperrors.NotFound("occurrence not found")
+	ErrOccurrenceStartRequired = apperrors.Validation("occurrence_start is required to edit a single occurrence")
+	ErrSeriesOnlyField         = apperrors.Validation("only title, description, location and dates can be changed for a single occurrence")
+)
+
+const (
+	// recurrenceHorizon bounds occurrence expansion for listings without an end
+	recurrenceHorizon = 30 * 24 * time.Hour
+	// maxOccurrencesPerSeries caps how many occurrences of one series a page shows
+	maxOccurrencesPerSeries = 10
+	// maxRecurrencePeriods guards expansion against pathological rules
+	maxRecurrencePeriods = 5000
+)
+
+// occurrenceStarts returns the starts a rule generates in [from, to) for a
+// series whose first occurrence is at start. A zero to is unbounded; a limit
+// of zero returns every occurrence in range.
+func occurrenceStarts(rule *models.RecurrenceRule, start, from, to time.Time, limit int) []time.Time {
+	loc, err := time.LoadLocation(rule.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	start = start.In(loc)
+	interval := rule.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	// Counted series must be walked from the start; others can skip ahead
+	period := 0
+	if rule.Count == 0 && from.After(start) {
+		if skip := periodsBetween(rule.Frequency, start, from.In(loc)) - 1; skip > 0 {
+			period = skip / interval
+		}
+	}
+
+	var starts []time.Time
+	generated := 0
+	for i := 0; i < maxRecurrencePeriods; i, period = i+1, period+1 {
+		for _, candidate := range periodStarts(rule, start, period*interval) {
+			if candidate.Before(start) {
+				continue
+			}
+			generated++
+			if rule.Count > 0 && generated > rule.Count {
+				return starts
+			}
+			if rule.Until != nil && candidate.After(*rule.Until) {
+				return starts
+			}
+			if !to.IsZero() && !candidate.Before(to) {
+				return starts
+			}
+			if !candidate.Before(from) {
+				starts = append(starts, candidate)
+				if limit > 0 && len(starts) == limit {
+					return starts
+				}
+			}
+		}
+	}
+	return starts
+}
+
+// periodStarts returns the candidate starts in the nth period after start, in order
+func periodStarts(rule *models.RecurrenceRule, start time.Time, n int) []time.Time {
+	switch rule.Frequency {
+	case models.RecurrenceDaily:
+		return []time.Time{start.AddDate(0, 0, n)}
+	case models.RecurrenceWeekly:
+		if len(rule.ByDay) == 0 {
+			return []time.Time{start.AddDate(0, 0, 7*n)}
+		}
+		// Weeks start on Monday, as with the RRULE default WKST=MO
+		weekStart := start.AddDate(0, 0, -mondayOffset(start.Weekday())+7*n)
+		offsets := make([]int, 0, len(rule.ByDay))
+		for _, day := range rule.ByDay {
+			if weekday, ok := validation.Weekdays[day]; ok {
+				offsets = append(offsets, mondayOffset(weekday))
+			}
+		}
+		sort.Ints(offsets)
+		starts := make([]time.Time, 0, len(offsets))
+		for i, offset := range offsets {
+			if i > 0 && offset == offsets[i-1] {
+				continue
+			}
+			starts = append(starts, weekStart.AddDate(0, 0, offset))
+		}
+		return starts
+	case models.RecurrenceMonthly, models.RecurrenceYearly:
+		months := n
+		if rule.Frequency == models.RecurrenceYearly {
+			months = 12 * n
+		}
+		candidate := time.Date(start.Year(), start.Month()+time.Month(months), start.Day(),
+			start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+		// Months without the start's day (e.g. the 31st) are skipped, as in RRULE
+		if candidate.Day() != start.Day() {
+			return nil
+		}
+		return []time.Time{candidate}
+	}
+	return nil
+}
+
+// periodsBetween counts whole periods of freq from start to t
+func periodsBetween(freq models.RecurrenceFrequency, start, t time.Time) int {
+	switch freq {
+	case models.RecurrenceDaily:
+		return int(t.Sub(start).Hours() / 24)
+	case models.RecurrenceWeekly:
+		return int(t.Sub(start).Hours() / (24 * 7))
+	case models.RecurrenceMonthly:
+		return (t.Year()-start.Year())*12 + int(t.Month()) - int(start.Month())
+	case models.RecurrenceYearly:
+		return t.Year() - start.Year()
+	}
+	return 0
+}
+
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// recurrenceEnd returns the start of a series' last occurrence, or nil when
+// the series never ends
+func recurrenceEnd(rule *models.RecurrenceRule, start time.Time) *time.Time {
+	if rule.Until != nil {
+		until := *rule.Until
+		return &until
+	}
+	if rule.Count == 0 {
+		return nil
+	}
+	starts := occurrenceStarts(rule, start, start, time.Time{}, rule.Count)
+	if len(starts) == 0 {
+		return &start
+	}
+	last := starts[len(starts)-1]
+	return &last
+}
+
+// isOccurrence reports whether the event's rule generates an occurrence starting at t
+func isOccurrence(event *models.Event, t time.Time) bool {
+	if event.Recurrence == nil {
+		return false
+	}
+	starts := occurrenceStarts(event.Recurrence, event.StartDate, t, t.Add(time.Nanosecond), 1)
+	return len(starts) == 1 && starts[0].Equal(t)
+}
+
+// dateRangeFilter matches single events starting in [from, to) and recurring
+// series with an occurrence that may fall in it. Zero bounds are open.
+func dateRangeFilter(from, to time.Time) bson.M {
+	single := bson.M{}
+	if !from.IsZero() {
+		single["$gte"] = from
+	}
+	if !to.IsZero() {
+		single["$lt"] = to
+	}
+
+	series := bson.M{"recurrence": bson.M{"$ne": nil}}
+	if !to.IsZero() {
+		series["start_date"] = bson.M{"$lt": to}
+	}
+	if !from.IsZero() {
+		series["$or"] = []bson.M{
+			{"recurrence_end": nil},
+			{"recurrence_end": bson.M{"$gte": from}},
+		}
+	}
+
+	return bson.M{"$or": []bson.M{
+		{"recurrence": nil, "start_date": single},
+		series,
+	}}
+}
+
+func findOverride(event *models.Event, occurrenceStart time.Time) (int, *models.EventOccurrenceOverride) {
+	for i := range event.OccurrenceOverrides {
+		if event.OccurrenceOverrides[i].OccurrenceStart.Equal(occurrenceStart) {
+			return i, &event.OccurrenceOverrides[i]
+		}
+	}
+	return -1, nil
+}
+
+// seriesStatus returns a user's series-wide RSVP
+func seriesStatus(event *models.Event, userID primitive.ObjectID) models.RSVPStatus {
+	for _, attendee := range event.Attendees {
+		if attendee.UserID == userID {
+			return attendee.Status
+		}
+	}
+	return ""
+}
+
+// occurrenceStatus returns a user's RSVP for one occurrence, falling back to
+// their series-wide RSVP
+func occurrenceStatus(event *models.Event, userID primitive.ObjectID, occurrenceStart time.Time) models.RSVPStatus {
+	for _, rsvp := range event.OccurrenceRSVPs {
+		if rsvp.UserID == userID && rsvp.OccurrenceStart.Equal(occurrenceStart) {
+			return rsvp.Status
+		}
+	}
+	return seriesStatus(event, userID)
+}
+
+// occurrenceStats adjusts the series stats for the RSVPs overridden on one occurrence
+func occurrenceStats(event *models.Event, occurrenceStart time.Time) models.EventStats {
+	stats := event.Stats
+	adjust := func(status models.RSVPStatus, delta int64) {
+		switch status {
+		case models.RSVPStatusGoing:
+			stats.GoingCount += delta
+		case models.RSVPStatusInterested:
+			stats.InterestedCount += delta
+		case models.RSVPStatusInvited:
+			stats.InvitedCount += delta
+		}
+	}
+	for _, rsvp := range event.OccurrenceRSVPs {
+		if !rsvp.OccurrenceStart.Equal(occurrenceStart) {
+			continue
+		}
+		adjust(seriesStatus(event, rsvp.UserID), -1)
+		adjust(rsvp.Status, 1)
+	}
+	return stats
+}
+
+// applyOccurrence turns a series response into the response for the
+// occurrence starting at occurrenceStart. It returns false for cancelled occurrences.
+func applyOccurrence(series models.EventResponse, event *models.Event, occurrenceStart time.Time, viewerID primitive.ObjectID) (models.EventResponse, bool) {
+	resp := series
+	start := occurrenceStart
+	resp.OccurrenceStart = &start
+	resp.StartDate = occurrenceStart
+	if !event.EndDate.IsZero() {
+		resp.EndDate = occurrenceStart.Add(event.EndDate.Sub(event.StartDate))
+	}
+	resp.Stats = occurrenceStats(event, occurrenceStart)
+	if !viewerID.IsZero() {
+		resp.MyStatus = occurrenceStatus(event, viewerID, occurrenceStart)
+	}
+
+	if _, override := findOverride(event, occurrenceStart); override != nil {
+		if override.Cancelled {
+			return resp, false
+		}
+		if override.Title != "" {
+			resp.Title = override.Title
+		}
+		if override.Description != "" {
+			resp.Description = override.Description
+		}
+		if override.Location != "" {
+			resp.Location = override.Location
+		}
+		if override.StartDate != nil {
+			resp.StartDate = *override.StartDate
+		}
+		if override.EndDate != nil {
+			resp.EndDate = *override.EndDate
+		}
+	}
+	return resp, true
+}
+
+// sortByStart orders responses by when they start, keeping the order of ties
+func sortByStart(responses []models.EventResponse) {
+	sort.SliceStable(responses, func(i, j int) bool {
+		return responses[i].StartDate.Before(responses[j].StartDate)
+	})
+}
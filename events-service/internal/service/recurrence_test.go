@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/pkg/async"
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/service/mocks"
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/service/testutil"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func date(year int, month time.Month, day, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+// TestOccurrenceStarts tests occurrence expansion for each frequency
+func TestOccurrenceStarts(t *testing.T) {
+	until := date(2026, 1, 8, 0)
+	tests := []struct {
+		name  string
+		rule  *models.RecurrenceRule
+		start time.Time
+		from  time.Time
+		to    time.Time
+		limit int
+		want  []time.Time
+	}{
+		{
+			name:  "daily every other day",
+			rule:  &models.RecurrenceRule{Frequency: models.RecurrenceDaily, Interval: 2},
+			start: date(2026, 1, 1, 18),
+			from:  date(2026, 1, 1, 0),
+			to:    date(2026, 1, 8, 0),
+			want:  []time.Time{date(2026, 1, 1, 18), date(2026, 1, 3, 18), date(2026, 1, 5, 18), date(2026, 1, 7, 18)},
+		},
+		{
+			name:  "weekly by day skips days before start",
+			rule:  &models.RecurrenceRule{Frequency: models.RecurrenceWeekly, ByDay: []string{"MO", "TH"}},
+			start: date(2026, 1, 1, 9), // Thursday
+			from:  date(2026, 1, 1, 0),
+			to:    date(2026, 1, 13, 0),
+			want:  []time.Time{date(2026, 1, 1, 9), date(2026, 1, 5, 9), date(2026, 1, 8, 9), date(2026, 1, 12, 9)},
+		},
+		{
+			name:  "monthly skips months without the day",
+			rule:  &models.RecurrenceRule{Frequency: models.RecurrenceMonthly},
+			start: date(2026, 1, 31, 12),
+			from:  date(2026, 1, 1, 0),
+			to:    date(2026, 6, 1, 0),
+			want:  []time.Time{date(2026, 1, 31, 12), date(2026, 3, 31, 12), date(2026, 5, 31, 12)},
+		},
+		{
+			name:  "count ends series",
+			rule:  &models.RecurrenceRule{Frequency: models.RecurrenceDaily, Count: 3},
+			start: date(2026, 1, 1, 18),
+			from:  date(2026, 1, 2, 0),
+			want:  []time.Time{date(2026, 1, 2, 18), date(2026, 1, 3, 18)},
+		},
+		{
+			name:  "until ends series",
+			rule:  &models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Until: &until},
+			start: date(2026, 1, 1, 18),
+			from:  date(2026, 1, 1, 0),
+			want:  []time.Time{date(2026, 1, 1, 18)},
+		},
+		{
+			name:  "skips ahead to from",
+			rule:  &models.RecurrenceRule{Frequency: models.RecurrenceYearly},
+			start: date(2020, 2, 29, 10),
+			from:  date(2025, 1, 1, 0),
+			limit: 2,
+			want:  []time.Time{date(2028, 2, 29, 10), date(2032, 2, 29, 10)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := occurrenceStarts(tt.rule, tt.start, tt.from, tt.to, tt.limit)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestOccurrenceStarts_TimeZone tests that occurrences keep their local time across DST
+func TestOccurrenceStarts_TimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	rule := &models.RecurrenceRule{Frequency: models.RecurrenceWeekly, TimeZone: "America/New_York"}
+	start := time.Date(2026, 3, 1, 19, 0, 0, 0, loc).UTC()
+
+	got := occurrenceStarts(rule, start, start, time.Time{}, 3)
+
+	assert.Len(t, got, 3)
+	for _, occurrence := range got {
+		assert.Equal(t, 19, occurrence.In(loc).Hour())
+	}
+}
+
+// TestRecurrenceEnd tests the stored end of a series
+func TestRecurrenceEnd(t *testing.T) {
+	start := date(2026, 1, 1, 18)
+	until := date(2026, 2, 1, 0)
+
+	assert.Nil(t, recurrenceEnd(&models.RecurrenceRule{Frequency: models.RecurrenceDaily}, start))
+	assert.Equal(t, until, *recurrenceEnd(&models.RecurrenceRule{Frequency: models.RecurrenceDaily, Until: &until}, start))
+	assert.Equal(t, date(2026, 1, 15, 18), *recurrenceEnd(&models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Count: 3}, start))
+}
+
+// TestApplyOccurrence tests that overrides and occurrence RSVPs shape an occurrence's response
+func TestApplyOccurrence(t *testing.T) {
+	viewerID := primitive.NewObjectID()
+	start := date(2026, 1, 1, 18)
+	second := date(2026, 1, 2, 18)
+	third := date(2026, 1, 3, 18)
+	moved := date(2026, 1, 2, 20)
+
+	event := testutil.NewEventBuilder().
+		WithDates(start, start.Add(2*time.Hour)).
+		WithAttendee(viewerID, models.RSVPStatusGoing).
+		WithStats(1, 0, 0, 0).
+		WithRecurrence(&models.RecurrenceRule{Frequency: models.RecurrenceDaily}).
+		Build()
+	event.OccurrenceOverrides = []models.EventOccurrenceOverride{
+		{OccurrenceStart: second, Title: "Moved", StartDate: &moved},
+		{OccurrenceStart: third, Cancelled: true},
+	}
+	event.OccurrenceRSVPs = []models.OccurrenceRSVP{
+		{UserID: viewerID, OccurrenceStart: second, Status: models.RSVPStatusInterested},
+	}
+	series := models.EventResponse{Title: event.Title, StartDate: start, EndDate: event.EndDate}
+
+	first, ok := applyOccurrence(series, event, start, viewerID)
+	assert.True(t, ok)
+	assert.Equal(t, event.Title, first.Title)
+	assert.Equal(t, models.RSVPStatusGoing, first.MyStatus)
+	assert.Equal(t, int64(1), first.Stats.GoingCount)
+
+	edited, ok := applyOccurrence(series, event, second, viewerID)
+	assert.True(t, ok)
+	assert.Equal(t, "Moved", edited.Title)
+	assert.Equal(t, moved, edited.StartDate)
+	assert.Equal(t, second.Add(2*time.Hour), edited.EndDate)
+	assert.Equal(t, second, *edited.OccurrenceStart)
+	assert.Equal(t, models.RSVPStatusInterested, edited.MyStatus)
+	assert.Equal(t, int64(0), edited.Stats.GoingCount)
+	assert.Equal(t, int64(1), edited.Stats.InterestedCount)
+
+	_, ok = applyOccurrence(series, event, third, viewerID)
+	assert.False(t, ok)
+}
+
+// TestEventService_RSVPOccurrence tests RSVPs to a single occurrence
+func TestEventService_RSVPOccurrence(t *testing.T) {
+	eventID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	start := date(2026, 1, 1, 18)
+	daily := &models.RecurrenceRule{Frequency: models.RecurrenceDaily}
+
+	tests := []struct {
+		name            string
+		occurrenceStart time.Time
+		event           *models.Event
+		wantErr         error
+		wantBroadcast   int
+	}{
+		{
+			name:            "successful occurrence RSVP",
+			occurrenceStart: start.AddDate(0, 0, 2),
+			event:           testutil.NewEventBuilder().WithID(eventID).WithDates(start, start.Add(time.Hour)).WithRecurrence(daily).Build(),
+			wantBroadcast:   1,
+		},
+		{
+			name:            "event not recurring",
+			occurrenceStart: start,
+			event:           testutil.NewEventBuilder().WithID(eventID).WithDates(start, start.Add(time.Hour)).Build(),
+			wantErr:         ErrNotRecurring,
+		},
+		{
+			name:            "start not generated by rule",
+			occurrenceStart: start.Add(time.Hour),
+			event:           testutil.NewEventBuilder().WithID(eventID).WithDates(start, start.Add(time.Hour)).WithRecurrence(daily).Build(),
+			wantErr:         ErrOccurrenceNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockEventRepository{}
+			mockBroadcaster := &mocks.MockEventBroadcaster{}
+			mockRepo.GetByIDFunc = func(ctx context.Context, id primitive.ObjectID) (*models.Event, error) {
+				return tt.event, nil
+			}
+			var saved *models.OccurrenceRSVP
+			mockRepo.SetOccurrenceRSVPFunc = func(ctx context.Context, id primitive.ObjectID, rsvp models.OccurrenceRSVP) error {
+				saved = &rsvp
+				return nil
+			}
+
+			svc := &EventService{
+				eventRepo:   mockRepo,
+				broadcaster: mockBroadcaster,
+				asyncRunner: async.NewRunner(slog.Default()),
+			}
+
+			err := svc.RSVPOccurrence(context.Background(), eventID, userID, tt.occurrenceStart, models.RSVPStatusGoing)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantBroadcast, mockBroadcaster.BroadcastRSVPCalls)
+			if tt.wantErr == nil {
+				assert.NotNil(t, saved)
+				assert.Equal(t, tt.occurrenceStart, saved.OccurrenceStart)
+			}
+		})
+	}
+}
+
+// TestEventService_UpdateOccurrence tests edits scoped to a single occurrence
+func TestEventService_UpdateOccurrence(t *testing.T) {
+	eventID := primitive.NewObjectID()
+	hostID := primitive.NewObjectID()
+	start := date(2026, 1, 1, 18)
+	occurrenceStart := start.AddDate(0, 0, 7)
+
+	tests := []struct {
+		name    string
+		req     models.UpdateEventRequest
+		wantErr error
+	}{
+		{
+			name: "successful occurrence edit",
+			req:  models.UpdateEventRequest{Scope: models.EventEditScopeThis, OccurrenceStart: &occurrenceStart, Title: "Holiday Edition"},
+		},
+		{
+			name:    "missing occurrence start",
+			req:     models.UpdateEventRequest{Scope: models.EventEditScopeThis, Title: "Holiday Edition"},
+			wantErr: ErrOccurrenceStartRequired,
+		},
+		{
+			name:    "series-only field",
+			req:     models.UpdateEventRequest{Scope: models.EventEditScopeThis, OccurrenceStart: &occurrenceStart, Category: "Music"},
+			wantErr: ErrSeriesOnlyField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := testutil.NewEventBuilder().
+				WithID(eventID).
+				WithCreatorID(hostID).
+				WithDates(start, start.Add(time.Hour)).
+				WithRecurrence(&models.RecurrenceRule{Frequency: models.RecurrenceWeekly}).
+				Build()
+			mockRepo := &mocks.MockEventRepository{}
+			mockRepo.GetByIDFunc = func(ctx context.Context, id primitive.ObjectID) (*models.Event, error) {
+				return event, nil
+			}
+
+			svc := &EventService{
+				eventRepo:   mockRepo,
+				userRepo:    &mocks.MockUserRepo{},
+				asyncRunner: async.NewRunner(slog.Default()),
+			}
+
+			resp, err := svc.UpdateEvent(context.Background(), eventID, hostID, tt.req)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, "Holiday Edition", resp.Title)
+				assert.Equal(t, occurrenceStart, *resp.OccurrenceStart)
+				assert.Len(t, event.OccurrenceOverrides, 1)
+				assert.Equal(t, 1, mockRepo.UpdateCalls)
+			}
+		})
+	}
+}
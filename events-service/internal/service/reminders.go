@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrRemindersUnavailable = apperrors.Unavailable("event reminders are unavailable")
+
+const (
+	reminderScanInterval = time.Minute
+	// reminderLease is how long a worker holds a claimed reminder before
+	// another may retry it
+	reminderLease       = 5 * time.Minute
+	maxReminderAttempts = 5
+	// reminderBatchSize caps how many reminders one sweep sends
+	reminderBatchSize = 200
+	// reminderLookahead is how many occurrences of a series are checked for
+	// one that still has reminders to come
+	reminderLookahead = 8
+)
+
+// reminderKinds are the reminders sent before every occurrence, earliest first
+var reminderKinds = []models.EventReminderKind{
+	models.EventReminderDayBefore,
+	models.EventReminderHourBefore,
+}
+
+// SetReminderRepo enables reminders before events start, and per-event opt-outs
+func (s *EventService) SetReminderRepo(reminders ReminderRepo) {
+	s.reminderRepo = reminders
+}
+
+// GetReminderSettings returns whether a user gets reminders for an event
+func (s *EventService) GetReminderSettings(ctx context.Context, eventID, userID primitive.ObjectID) (*models.EventReminderSettings, error) {
+	if s.reminderRepo == nil {
+		return nil, ErrRemindersUnavailable
+	}
+	if _, err := s.accessibleEvent(ctx, eventID, userID); err != nil {
+		return nil, err
+	}
+
+	optedOut, err := s.reminderRepo.IsOptedOut(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.EventReminderSettings{EventID: eventID.Hex(), Enabled: !optedOut}, nil
+}
+
+// UpdateReminderSettings turns a user's reminders for an event on or off
+func (s *EventService) UpdateReminderSettings(ctx context.Context, eventID, userID primitive.ObjectID, enabled bool) (*models.EventReminderSettings, error) {
+	if s.reminderRepo == nil {
+		return nil, ErrRemindersUnavailable
+	}
+	if _, err := s.accessibleEvent(ctx, eventID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.reminderRepo.SetOptOut(ctx, eventID, userID, !enabled); err != nil {
+		return nil, err
+	}
+	return &models.EventReminderSettings{EventID: eventID.Hex(), Enabled: enabled}, nil
+}
+
+func (s *EventService) accessibleEvent(ctx context.Context, eventID, viewerID primitive.ObjectID) (*models.Event, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.Privacy == models.EventPrivacyPrivate && !s.canAccessPrivateEvent(ctx, event, viewerID) {
+		return nil, errors.New("unauthorized: you do not have access to this private event")
+	}
+	return event, nil
+}
+
+// scheduleReminders schedules the reminders still to come for the first
+// occurrence starting after the given time that has any
+func (s *EventService) scheduleReminders(ctx context.Context, event *models.Event, after time.Time) {
+	if s.reminderRepo == nil {
+		return
+	}
+	for _, reminder := range upcomingReminders(event, after, time.Now()) {
+		if err := s.reminderRepo.Schedule(ctx, &reminder); err != nil {
+			slog.Warn("Failed to schedule event reminder", "event_id", event.ID.Hex(), "kind", reminder.Kind, "error", err)
+		}
+	}
+}
+
+// rescheduleReminders replaces an event's pending reminders after its dates change
+func (s *EventService) rescheduleReminders(ctx context.Context, event *models.Event) {
+	if s.reminderRepo == nil {
+		return
+	}
+	if err := s.reminderRepo.DeletePending(ctx, event.ID); err != nil {
+		slog.Warn("Failed to clear event reminders", "event_id", event.ID.Hex(), "error", err)
+		return
+	}
+	s.scheduleReminders(ctx, event, time.Now())
+}
+
+// upcomingReminders returns the reminders due after now for the first
+// occurrence starting after the given time that has any
+func upcomingReminders(event *models.Event, after, now time.Time) []models.EventReminder {
+	var starts []time.Time
+	if event.Recurrence == nil {
+		if event.StartDate.After(after) {
+			starts = []time.Time{event.StartDate}
+		}
+	} else {
+		starts = occurrenceStarts(event.Recurrence, event.StartDate, after.Add(time.Nanosecond), time.Time{}, reminderLookahead)
+	}
+
+	for _, occurrenceStart := range starts {
+		start, ok := effectiveStart(event, occurrenceStart)
+		if !ok {
+			continue
+		}
+		var reminders []models.EventReminder
+		for _, kind := range reminderKinds {
+			if due := start.Add(-kind.Before()); due.After(now) {
+				reminders = append(reminders, models.EventReminder{
+					EventID:         event.ID,
+					OccurrenceStart: occurrenceStart,
+					Kind:            kind,
+					DueAt:           due,
+					Status:          models.EventReminderPending,
+				})
+			}
+		}
+		if len(reminders) > 0 {
+			return reminders
+		}
+	}
+	return nil
+}
+
+// effectiveStart returns when an occurrence actually starts, following any
+// edit that moved it. It reports false if the occurrence no longer exists.
+func effectiveStart(event *models.Event, occurrenceStart time.Time) (time.Time, bool) {
+	if event.Recurrence == nil {
+		return event.StartDate, occurrenceStart.Equal(event.StartDate)
+	}
+	if !isOccurrence(event, occurrenceStart) {
+		return time.Time{}, false
+	}
+	if _, override := findOverride(event, occurrenceStart); override != nil {
+		if override.Cancelled {
+			return time.Time{}, false
+		}
+		if override.StartDate != nil {
+			return *override.StartDate, true
+		}
+	}
+	return occurrenceStart, true
+}
+
+// RunReminderWorker sends due reminders until ctx is cancelled
+func (s *EventService) RunReminderWorker(ctx context.Context) {
+	if s.reminderRepo == nil {
+		return
+	}
+	ticker := time.NewTicker(reminderScanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.DispatchDueReminders(ctx); err != nil {
+				slog.Error("Event reminder sweep failed", "error", err)
+			} else if n > 0 {
+				slog.Info("Event reminder sweep sent reminders", "count", n)
+			}
+		}
+	}
+}
+
+// DispatchDueReminders claims and sends due reminders, returning how many
+// were sent. Reminders that fail are retried once their lease expires.
+func (s *EventService) DispatchDueReminders(ctx context.Context) (int, error) {
+	sent := 0
+	for i := 0; i < reminderBatchSize; i++ {
+		reminder, err := s.reminderRepo.ClaimDue(ctx, time.Now(), reminderLease, maxReminderAttempts)
+		if err != nil {
+			return sent, err
+		}
+		if reminder == nil {
+			return sent, nil
+		}
+
+		status, err := s.sendReminder(ctx, reminder)
+		if err != nil {
+			slog.Error("Failed to send event reminder", "reminder_id", reminder.ID.Hex(), "event_id", reminder.EventID.Hex(), "error", err)
+			continue
+		}
+		if status == models.EventReminderSent {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// sendReminder notifies everyone going to a reminder's occurrence, checking
+// first that it still exists and has not moved, and returns how the reminder
+// was settled. Pending is returned when it was moved to a new due time.
+func (s *EventService) sendReminder(ctx context.Context, reminder *models.EventReminder) (models.EventReminderStatus, error) {
+	event, err := s.eventRepo.GetByID(ctx, reminder.EventID)
+	if err != nil {
+		if err.Error() == "event not found" {
+			return models.EventReminderSkipped, s.reminderRepo.Complete(ctx, reminder.ID, models.EventReminderSkipped)
+		}
+		return "", err
+	}
+	// Whatever happens to this occurrence, the rest of the series keeps its reminders
+	defer s.scheduleReminders(ctx, event, reminder.OccurrenceStart)
+
+	now := time.Now()
+	start, ok := effectiveStart(event, reminder.OccurrenceStart)
+	if !ok || !start.After(now) || supersededReminder(reminder.Kind, start, now) {
+		return models.EventReminderSkipped, s.reminderRepo.Complete(ctx, reminder.ID, models.EventReminderSkipped)
+	}
+	if due := start.Add(-reminder.Kind.Before()); due.After(now) {
+		return models.EventReminderPending, s.reminderRepo.Reschedule(ctx, reminder.ID, due)
+	}
+
+	optedOut, err := s.reminderRepo.OptedOutUsers(ctx, event.ID)
+	if err != nil {
+		return "", err
+	}
+	for _, userID := range reminderRecipients(event, reminder.OccurrenceStart, optedOut) {
+		s.publishReminder(ctx, event, start, reminder.Kind, userID)
+	}
+	return models.EventReminderSent, s.reminderRepo.Complete(ctx, reminder.ID, models.EventReminderSent)
+}
+
+// supersededReminder reports whether a later reminder for the same occurrence
+// is already due, so a reminder that was held up is not sent right before it
+func supersededReminder(kind models.EventReminderKind, start, now time.Time) bool {
+	for _, later := range reminderKinds {
+		if later.Before() < kind.Before() && !start.Add(-later.Before()).After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// reminderRecipients returns the users going to an occurrence who have not
+// opted out of the event's reminders
+func reminderRecipients(event *models.Event, occurrenceStart time.Time, optedOut map[primitive.ObjectID]bool) []primitive.ObjectID {
+	candidates := make([]primitive.ObjectID, 0, len(event.Attendees))
+	for _, attendee := range event.Attendees {
+		candidates = append(candidates, attendee.UserID)
+	}
+	if event.Recurrence != nil {
+		for _, rsvp := range event.OccurrenceRSVPs {
+			if rsvp.OccurrenceStart.Equal(occurrenceStart) {
+				candidates = append(candidates, rsvp.UserID)
+			}
+		}
+	}
+
+	seen := make(map[primitive.ObjectID]bool, len(candidates))
+	var recipients []primitive.ObjectID
+	for _, userID := range candidates {
+		if seen[userID] || optedOut[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		status := seriesStatus(event, userID)
+		if event.Recurrence != nil {
+			status = occurrenceStatus(event, userID, occurrenceStart)
+		}
+		if status == models.RSVPStatusGoing {
+			recipients = append(recipients, userID)
+		}
+	}
+	return recipients
+}
+
+func (s *EventService) publishReminder(ctx context.Context, event *models.Event, start time.Time, kind models.EventReminderKind, userID primitive.ObjectID) {
+	if s.notificationProducer == nil {
+		return
+	}
+
+	when := "in 1 hour"
+	if kind == models.EventReminderDayBefore {
+		when = "tomorrow"
+	}
+	notification := &models.Notification{
+		ID:          primitive.NewObjectID(),
+		RecipientID: userID,
+		SenderID:    event.CreatorID,
+		Type:        models.NotificationTypeEventReminder,
+		TargetID:    event.ID,
+		TargetType:  "event",
+		Content:     event.Title + " starts " + when,
+		Data: map[string]interface{}{
+			"event_id":    event.ID.Hex(),
+			"event_title": event.Title,
+			"start_date":  start.Format(time.RFC3339),
+			"reminder":    string(kind),
+		},
+		Read:      false,
+		CreatedAt: time.Now(),
+	}
+
+	taskCtx := s.detachContext(ctx)
+	s.asyncRunner.RunAsyncRetry(taskCtx, "publish_event_reminder", func() error {
+		notifyCtx, cancel := context.WithTimeout(taskCtx, 5*time.Second)
+		defer cancel()
+		return s.notificationProducer.PublishNotification(notifyCtx, notification)
+	}, asyncRetryAttempts, asyncRetryDelay)
+}
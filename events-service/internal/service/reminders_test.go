@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/pkg/async"
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/service/mocks"
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/service/testutil"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestUpcomingReminders tests which reminders are scheduled for an event
+func TestUpcomingReminders(t *testing.T) {
+	now := date(2026, 3, 1, 12)
+	daily := &models.RecurrenceRule{Frequency: models.RecurrenceDaily}
+	moved := date(2026, 3, 1, 20)
+
+	tests := []struct {
+		name      string
+		event     *models.Event
+		wantStart time.Time
+		wantDue   []time.Time
+	}{
+		{
+			name:      "single event days away",
+			event:     testutil.NewEventBuilder().WithDates(date(2026, 3, 4, 18), date(2026, 3, 4, 20)).Build(),
+			wantStart: date(2026, 3, 4, 18),
+			wantDue:   []time.Time{date(2026, 3, 3, 18), date(2026, 3, 4, 17)},
+		},
+		{
+			name:      "single event later today",
+			event:     testutil.NewEventBuilder().WithDates(date(2026, 3, 1, 17), date(2026, 3, 1, 19)).Build(),
+			wantStart: date(2026, 3, 1, 17),
+			wantDue:   []time.Time{date(2026, 3, 1, 16)},
+		},
+		{
+			name:  "single event about to start",
+			event: testutil.NewEventBuilder().WithDates(now.Add(30*time.Minute), now.Add(2*time.Hour)).Build(),
+		},
+		{
+			name:      "series uses next occurrence",
+			event:     testutil.NewEventBuilder().WithDates(date(2026, 2, 1, 18), date(2026, 2, 1, 19)).WithRecurrence(daily).Build(),
+			wantStart: date(2026, 3, 1, 18),
+			wantDue:   []time.Time{date(2026, 3, 1, 17)},
+		},
+		{
+			name: "series skips cancelled occurrence",
+			event: func() *models.Event {
+				e := testutil.NewEventBuilder().WithDates(date(2026, 2, 1, 18), date(2026, 2, 1, 19)).WithRecurrence(daily).Build()
+				e.OccurrenceOverrides = []models.EventOccurrenceOverride{{OccurrenceStart: date(2026, 3, 1, 18), Cancelled: true}}
+				return e
+			}(),
+			wantStart: date(2026, 3, 2, 18),
+			wantDue:   []time.Time{date(2026, 3, 1, 18), date(2026, 3, 2, 17)},
+		},
+		{
+			name: "series follows moved occurrence",
+			event: func() *models.Event {
+				e := testutil.NewEventBuilder().WithDates(date(2026, 2, 1, 18), date(2026, 2, 1, 19)).WithRecurrence(daily).Build()
+				e.OccurrenceOverrides = []models.EventOccurrenceOverride{{OccurrenceStart: date(2026, 3, 1, 18), StartDate: &moved}}
+				return e
+			}(),
+			wantStart: date(2026, 3, 1, 18),
+			wantDue:   []time.Time{date(2026, 3, 1, 19)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminders := upcomingReminders(tt.event, now, now)
+
+			var due []time.Time
+			for _, r := range reminders {
+				assert.Equal(t, tt.event.ID, r.EventID)
+				assert.True(t, tt.wantStart.Equal(r.OccurrenceStart))
+				assert.Equal(t, models.EventReminderPending, r.Status)
+				due = append(due, r.DueAt)
+			}
+			assert.Equal(t, len(tt.wantDue), len(due))
+			for i := range tt.wantDue {
+				assert.True(t, tt.wantDue[i].Equal(due[i]), "due %d: want %v, got %v", i, tt.wantDue[i], due[i])
+			}
+		})
+	}
+}
+
+// TestReminderRecipients tests that reminders go to those going who have not opted out
+func TestReminderRecipients(t *testing.T) {
+	going := primitive.NewObjectID()
+	interested := primitive.NewObjectID()
+	optedOut := primitive.NewObjectID()
+	skipping := primitive.NewObjectID()
+	joining := primitive.NewObjectID()
+	occurrence := date(2026, 3, 2, 18)
+
+	event := testutil.NewEventBuilder().
+		WithDates(date(2026, 3, 1, 18), date(2026, 3, 1, 19)).
+		WithRecurrence(&models.RecurrenceRule{Frequency: models.RecurrenceDaily}).
+		WithAttendee(going, models.RSVPStatusGoing).
+		WithAttendee(interested, models.RSVPStatusInterested).
+		WithAttendee(optedOut, models.RSVPStatusGoing).
+		WithAttendee(skipping, models.RSVPStatusGoing).
+		Build()
+	event.OccurrenceRSVPs = []models.OccurrenceRSVP{
+		{UserID: skipping, OccurrenceStart: occurrence, Status: models.RSVPStatusNotGoing},
+		{UserID: joining, OccurrenceStart: occurrence, Status: models.RSVPStatusGoing},
+		{UserID: interested, OccurrenceStart: date(2026, 3, 3, 18), Status: models.RSVPStatusGoing},
+	}
+
+	recipients := reminderRecipients(event, occurrence, map[primitive.ObjectID]bool{optedOut: true})
+
+	assert.ElementsMatch(t, []primitive.ObjectID{going, joining}, recipients)
+}
+
+// TestEventService_DispatchDueReminders tests how claimed reminders are settled
+func TestEventService_DispatchDueReminders(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		kind           models.EventReminderKind
+		start          time.Time
+		recurring      bool
+		deleted        bool
+		wantSent       int
+		wantStatus     models.EventReminderStatus
+		wantReschedule bool
+		wantScheduled  int
+	}{
+		{
+			name:       "due reminder is sent",
+			kind:       models.EventReminderHourBefore,
+			start:      now.Add(50 * time.Minute),
+			wantSent:   1,
+			wantStatus: models.EventReminderSent,
+		},
+		{
+			name:       "deleted event is skipped",
+			kind:       models.EventReminderHourBefore,
+			start:      now.Add(50 * time.Minute),
+			deleted:    true,
+			wantStatus: models.EventReminderSkipped,
+		},
+		{
+			name:           "postponed event is rescheduled",
+			kind:           models.EventReminderHourBefore,
+			start:          now.Add(5 * time.Hour),
+			wantReschedule: true,
+		},
+		{
+			name:       "late day-before reminder is superseded",
+			kind:       models.EventReminderDayBefore,
+			start:      now.Add(30 * time.Minute),
+			wantStatus: models.EventReminderSkipped,
+		},
+		{
+			name:          "series schedules next occurrence",
+			kind:          models.EventReminderHourBefore,
+			start:         now.Add(50 * time.Minute),
+			recurring:     true,
+			wantSent:      1,
+			wantStatus:    models.EventReminderSent,
+			wantScheduled: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := testutil.NewEventBuilder().
+				WithDates(tt.start, tt.start.Add(time.Hour)).
+				WithAttendee(primitive.NewObjectID(), models.RSVPStatusGoing)
+			if tt.recurring {
+				builder = builder.WithRecurrence(&models.RecurrenceRule{Frequency: models.RecurrenceDaily})
+			}
+			event := builder.Build()
+			reminder := &models.EventReminder{
+				ID:              primitive.NewObjectID(),
+				EventID:         event.ID,
+				OccurrenceStart: event.StartDate,
+				Kind:            tt.kind,
+				DueAt:           now.Add(-time.Minute),
+				Status:          models.EventReminderPending,
+			}
+
+			mockRepo := &mocks.MockEventRepository{}
+			mockReminders := &mocks.MockReminderRepository{}
+			mockRepo.GetByIDFunc = func(ctx context.Context, id primitive.ObjectID) (*models.Event, error) {
+				if tt.deleted {
+					return nil, errors.New("event not found")
+				}
+				return event, nil
+			}
+			claimed := false
+			mockReminders.ClaimDueFunc = func(ctx context.Context, now time.Time, lease time.Duration, maxAttempts int) (*models.EventReminder, error) {
+				if claimed {
+					return nil, nil
+				}
+				claimed = true
+				return reminder, nil
+			}
+
+			svc := &EventService{
+				eventRepo:    mockRepo,
+				reminderRepo: mockReminders,
+				asyncRunner:  async.NewRunner(slog.Default()),
+			}
+
+			sent, err := svc.DispatchDueReminders(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSent, sent)
+			assert.Equal(t, tt.wantStatus, mockReminders.Completed[reminder.ID])
+			if tt.wantReschedule {
+				assert.True(t, tt.start.Add(-time.Hour).Equal(mockReminders.Rescheduled[reminder.ID]))
+			} else {
+				assert.Empty(t, mockReminders.Rescheduled)
+			}
+			assert.Len(t, mockReminders.Scheduled, tt.wantScheduled)
+			for _, next := range mockReminders.Scheduled {
+				assert.True(t, next.OccurrenceStart.After(reminder.OccurrenceStart))
+			}
+		})
+	}
+}
+
+// TestEventService_UpdateEventReschedulesReminders tests that moving an event replaces its reminders
+func TestEventService_UpdateEventReschedulesReminders(t *testing.T) {
+	userID := primitive.NewObjectID()
+	event := testutil.NewEventBuilder().WithCreatorID(userID).Build()
+	newStart := time.Now().Add(72 * time.Hour)
+	newEnd := newStart.Add(2 * time.Hour)
+
+	mockRepo := &mocks.MockEventRepository{
+		GetByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*models.Event, error) {
+			return event, nil
+		},
+	}
+	mockReminders := &mocks.MockReminderRepository{}
+
+	svc := &EventService{
+		eventRepo:    mockRepo,
+		userRepo:     &mocks.MockUserRepo{},
+		reminderRepo: mockReminders,
+		asyncRunner:  async.NewRunner(slog.Default()),
+	}
+
+	_, err := svc.UpdateEvent(context.Background(), event.ID, userID, models.UpdateEventRequest{StartDate: &newStart, EndDate: &newEnd})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mockReminders.DeletePendingCalls)
+	if assert.Len(t, mockReminders.Scheduled, 2) {
+		assert.True(t, newStart.Add(-24*time.Hour).Equal(mockReminders.Scheduled[0].DueAt))
+		assert.True(t, newStart.Add(-time.Hour).Equal(mockReminders.Scheduled[1].DueAt))
+	}
+}
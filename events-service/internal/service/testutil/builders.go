@@ -128,6 +128,11 @@ func (b *EventBuilder) WithIsOnline(isOnline bool) *EventBuilder {
 	return b
 }
 
+func (b *EventBuilder) WithRecurrence(rule *models.RecurrenceRule) *EventBuilder {
+	b.event.Recurrence = rule
+	return b
+}
+
 func (b *EventBuilder) Build() *models.Event {
 	return b.event
 }
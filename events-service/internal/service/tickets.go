@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrTicketTierNotFound = apperrors.NotFound("ticket tier not found")
+	ErrTicketNotFound     = apperrors.NotFound("ticket not found")
+)
+
+// SetTicketRepo enables ticketing: going RSVPs then reserve a seat within the
+// event's capacity or join its waitlist
+func (s *EventService) SetTicketRepo(tickets TicketRepo) {
+	s.ticketRepo = tickets
+}
+
+func newTicketTiers(reqs []models.TicketTierRequest) []models.TicketTier {
+	if len(reqs) == 0 {
+		return nil
+	}
+	tiers := make([]models.TicketTier, 0, len(reqs))
+	for _, req := range reqs {
+		tier := models.TicketTier{
+			ID:   primitive.NewObjectID().Hex(),
+			Name: req.Name,
+			Type: req.Type,
+		}
+		if req.Type == models.TicketTierPaid {
+			tier.Price = req.Price
+			tier.Currency = req.Currency
+		}
+		tiers = append(tiers, tier)
+	}
+	return tiers
+}
+
+func findTier(event *models.Event, tierID string) *models.TicketTier {
+	for i := range event.TicketTiers {
+		if event.TicketTiers[i].ID == tierID {
+			return &event.TicketTiers[i]
+		}
+	}
+	return nil
+}
+
+// selectTier resolves the tier a new ticket is for, defaulting to the first
+func selectTier(event *models.Event, tierID string) (string, error) {
+	if tierID == "" {
+		if len(event.TicketTiers) > 0 {
+			return event.TicketTiers[0].ID, nil
+		}
+		return "", nil
+	}
+	if findTier(event, tierID) == nil {
+		return "", ErrTicketTierNotFound
+	}
+	return tierID, nil
+}
+
+// seatsLeft returns how many seats an event has left, or nil when it is unlimited
+func seatsLeft(event *models.Event) *int64 {
+	if event.Capacity <= 0 {
+		return nil
+	}
+	left := event.Capacity - event.SeatsTaken
+	if left < 0 {
+		left = 0
+	}
+	return &left
+}
+
+// claimTicket gives a user a confirmed ticket if a seat is left and a place
+// on the waitlist otherwise. It returns the RSVP status to record for them.
+func (s *EventService) claimTicket(ctx context.Context, event *models.Event, userID primitive.ObjectID, tierID string) (models.RSVPStatus, error) {
+	existing, err := s.ticketRepo.GetByEventAndUser(ctx, event.ID, userID)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil && existing.Status != models.TicketStatusCancelled {
+		return ticketRSVPStatus(existing), nil
+	}
+
+	tier, err := selectTier(event, tierID)
+	if err != nil {
+		return "", err
+	}
+
+	reserved, err := s.eventRepo.ReserveSeat(ctx, event.ID)
+	if err != nil {
+		return "", err
+	}
+	ticket := &models.EventTicket{
+		EventID: event.ID,
+		UserID:  userID,
+		TierID:  tier,
+		Status:  models.TicketStatusWaitlisted,
+	}
+	if reserved {
+		ticket.Status = models.TicketStatusConfirmed
+	}
+
+	issued, err := s.ticketRepo.Issue(ctx, ticket)
+	if err != nil || !issued {
+		if reserved {
+			if releaseErr := s.eventRepo.ReleaseSeat(ctx, event.ID); releaseErr != nil && err == nil {
+				err = releaseErr
+			}
+		}
+		if err != nil {
+			return "", err
+		}
+		// A concurrent RSVP issued the user's ticket first
+		existing, err = s.ticketRepo.GetByEventAndUser(ctx, event.ID, userID)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return "", ErrTicketNotFound
+		}
+		ticket = existing
+	}
+	return ticketRSVPStatus(ticket), nil
+}
+
+func ticketRSVPStatus(ticket *models.EventTicket) models.RSVPStatus {
+	if ticket.Status == models.TicketStatusWaitlisted {
+		return models.RSVPStatusWaitlisted
+	}
+	return models.RSVPStatusGoing
+}
+
+// cancelTicket cancels a user's ticket. A freed seat goes to the waitlist;
+// the users promoted into it are returned.
+func (s *EventService) cancelTicket(ctx context.Context, eventID, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ticket, err := s.ticketRepo.GetByEventAndUser(ctx, eventID, userID)
+	if err != nil || ticket == nil || ticket.Status == models.TicketStatusCancelled {
+		return nil, err
+	}
+
+	cancelled, err := s.ticketRepo.Transition(ctx, ticket.ID, ticket.Status, models.TicketStatusCancelled)
+	if err != nil || !cancelled || ticket.Status != models.TicketStatusConfirmed {
+		return nil, err
+	}
+
+	if err := s.eventRepo.ReleaseSeat(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return s.promoteWaitlist(ctx, eventID)
+}
+
+// promoteWaitlist confirms waitlisted tickets, oldest first, while the event
+// has seats left. It returns the promoted users.
+func (s *EventService) promoteWaitlist(ctx context.Context, eventID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	var promoted []primitive.ObjectID
+	for {
+		next, err := s.ticketRepo.NextWaitlisted(ctx, eventID)
+		if err != nil || next == nil {
+			return promoted, err
+		}
+
+		reserved, err := s.eventRepo.ReserveSeat(ctx, eventID)
+		if err != nil || !reserved {
+			return promoted, err
+		}
+
+		confirmed, err := s.ticketRepo.Transition(ctx, next.ID, models.TicketStatusWaitlisted, models.TicketStatusConfirmed)
+		if err != nil || !confirmed {
+			// The user left the waitlist meanwhile; the seat goes to the next in line
+			if releaseErr := s.eventRepo.ReleaseSeat(ctx, eventID); releaseErr != nil && err == nil {
+				err = releaseErr
+			}
+			if err != nil {
+				return promoted, err
+			}
+			continue
+		}
+
+		attendee := models.EventAttendee{
+			UserID:    next.UserID,
+			Status:    models.RSVPStatusGoing,
+			Timestamp: time.Now(),
+		}
+		if err := s.eventRepo.AddOrUpdateAttendee(ctx, eventID, attendee); err != nil {
+			return promoted, err
+		}
+		promoted = append(promoted, next.UserID)
+	}
+}
+
+// countStats derives an event's RSVP counts. With ticketing the going and
+// waitlist counts come from tickets rather than attendee RSVPs.
+func (s *EventService) countStats(ctx context.Context, event *models.Event) models.EventStats {
+	stats := models.EventStats{ShareCount: event.Stats.ShareCount}
+	for _, a := range event.Attendees {
+		switch a.Status {
+		case models.RSVPStatusGoing:
+			stats.GoingCount++
+		case models.RSVPStatusInterested:
+			stats.InterestedCount++
+		case models.RSVPStatusInvited:
+			stats.InvitedCount++
+		case models.RSVPStatusWaitlisted:
+			stats.WaitlistCount++
+		}
+	}
+
+	if s.ticketRepo != nil {
+		if going, err := s.ticketRepo.CountByStatus(ctx, event.ID, models.TicketStatusConfirmed); err == nil {
+			stats.GoingCount = going
+		}
+		if waiting, err := s.ticketRepo.CountByStatus(ctx, event.ID, models.TicketStatusWaitlisted); err == nil {
+			stats.WaitlistCount = waiting
+		}
+	}
+	return stats
+}
+
+// announcePromotions tells users promoted off the waitlist that they have a
+// seat, and updates everything that tracks who is going
+func (s *EventService) announcePromotions(ctx context.Context, event *models.Event, promoted []primitive.ObjectID, stats models.EventStats) {
+	taskCtx := s.detachContext(ctx)
+	for _, userID := range promoted {
+		if s.eventCache != nil {
+			s.eventCache.SetUserRSVPStatus(ctx, userID.Hex(), event.ID.Hex(), models.RSVPStatusGoing)
+			s.invalidateFriendsGoing(ctx, event.ID, userID)
+		}
+		s.invalidateCalendarFeed(ctx, userID)
+
+		if s.broadcaster != nil {
+			s.broadcaster.BroadcastRSVP(models.EventRSVPEvent{
+				EventID:   event.ID.Hex(),
+				UserID:    userID.Hex(),
+				Status:    models.RSVPStatusGoing,
+				Timestamp: time.Now(),
+				Stats:     stats,
+			})
+		}
+
+		if s.eventGraphRepo != nil {
+			s.asyncRunner.RunAsyncRetry(taskCtx, "add_promoted_attendee", func() error {
+				graphCtx, cancel := context.WithTimeout(taskCtx, 5*time.Second)
+				defer cancel()
+				return s.executeGraphOp(graphCtx, "add_rsvp_attendee", func(gctx context.Context) error {
+					return s.eventGraphRepo.AddAttendee(gctx, userID, event.ID)
+				})
+			}, asyncRetryAttempts, asyncRetryDelay)
+		}
+
+		if s.notificationProducer != nil {
+			notification := &models.Notification{
+				ID:          primitive.NewObjectID(),
+				RecipientID: userID,
+				SenderID:    event.CreatorID,
+				Type:        models.NotificationTypeEventWaitlistSeat,
+				TargetID:    event.ID,
+				TargetType:  "event",
+				Content:     "A seat opened up at " + event.Title + ". You're going!",
+				Data: map[string]interface{}{
+					"event_id":    event.ID.Hex(),
+					"event_title": event.Title,
+				},
+				Read:      false,
+				CreatedAt: time.Now(),
+			}
+			s.asyncRunner.RunAsyncRetry(taskCtx, "publish_waitlist_notification", func() error {
+				notifyCtx, cancel := context.WithTimeout(taskCtx, 5*time.Second)
+				defer cancel()
+				return s.notificationProducer.PublishNotification(notifyCtx, notification)
+			}, asyncRetryAttempts, asyncRetryDelay)
+		}
+	}
+}
+
+// GetTicket returns a user's ticket for an event, with their place in the
+// queue while they are waitlisted
+func (s *EventService) GetTicket(ctx context.Context, eventID, userID primitive.ObjectID) (*models.EventTicketResponse, error) {
+	if s.ticketRepo == nil {
+		return nil, ErrTicketNotFound
+	}
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.GetByEventAndUser(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if ticket == nil || ticket.Status == models.TicketStatusCancelled {
+		return nil, ErrTicketNotFound
+	}
+
+	resp := &models.EventTicketResponse{
+		ID:       ticket.ID.Hex(),
+		EventID:  eventID.Hex(),
+		Tier:     findTier(event, ticket.TierID),
+		Status:   ticket.Status,
+		QueuedAt: ticket.QueuedAt,
+	}
+	if ticket.Status == models.TicketStatusWaitlisted {
+		position, err := s.ticketRepo.WaitlistPosition(ctx, ticket)
+		if err != nil {
+			return nil, err
+		}
+		resp.WaitlistPosition = position
+	}
+	return resp, nil
+}
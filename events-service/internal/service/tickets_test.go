@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/pkg/async"
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/service/mocks"
+	"github.com/MuhibNayem/connectify-v2/events-service/internal/service/testutil"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestEventService_RSVPTickets tests seat reservation and the waitlist on going RSVPs
+func TestEventService_RSVPTickets(t *testing.T) {
+	eventID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	tierID := primitive.NewObjectID().Hex()
+
+	tests := []struct {
+		name       string
+		tierID     string
+		existing   *models.EventTicket
+		seatLeft   bool
+		wantErr    error
+		wantStatus models.RSVPStatus
+		wantIssued int
+	}{
+		{
+			name:       "seat reserved",
+			seatLeft:   true,
+			wantStatus: models.RSVPStatusGoing,
+			wantIssued: 1,
+		},
+		{
+			name:       "full event waitlists",
+			seatLeft:   false,
+			wantStatus: models.RSVPStatusWaitlisted,
+			wantIssued: 1,
+		},
+		{
+			name:       "waitlisted user keeps place",
+			existing:   &models.EventTicket{ID: primitive.NewObjectID(), Status: models.TicketStatusWaitlisted},
+			seatLeft:   true,
+			wantStatus: models.RSVPStatusWaitlisted,
+		},
+		{
+			name:     "unknown tier",
+			tierID:   "missing",
+			seatLeft: true,
+			wantErr:  ErrTicketTierNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockEventRepository{}
+			mockTickets := &mocks.MockTicketRepository{}
+			mockRepo.GetByIDFunc = func(ctx context.Context, id primitive.ObjectID) (*models.Event, error) {
+				event := testutil.NewEventBuilder().WithID(eventID).Build()
+				event.Capacity = 10
+				event.TicketTiers = []models.TicketTier{{ID: tierID, Name: "General", Type: models.TicketTierFree}}
+				return event, nil
+			}
+			mockRepo.ReserveSeatFunc = func(ctx context.Context, id primitive.ObjectID) (bool, error) {
+				return tt.seatLeft, nil
+			}
+			var recorded models.RSVPStatus
+			mockRepo.AddOrUpdateAttendeeFunc = func(ctx context.Context, id primitive.ObjectID, attendee models.EventAttendee) error {
+				recorded = attendee.Status
+				return nil
+			}
+			mockTickets.GetByEventAndUserFunc = func(ctx context.Context, eventID, userID primitive.ObjectID) (*models.EventTicket, error) {
+				return tt.existing, nil
+			}
+			var issued *models.EventTicket
+			mockTickets.IssueFunc = func(ctx context.Context, ticket *models.EventTicket) (bool, error) {
+				issued = ticket
+				return true, nil
+			}
+
+			svc := &EventService{
+				eventRepo:   mockRepo,
+				ticketRepo:  mockTickets,
+				asyncRunner: async.NewRunner(slog.Default()),
+			}
+
+			err := svc.RSVP(context.Background(), eventID, userID, models.RSVPStatusGoing, tt.tierID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantIssued, mockTickets.IssueCalls)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Equal(t, tt.wantStatus, recorded)
+			if issued != nil {
+				assert.Equal(t, tierID, issued.TierID)
+			}
+		})
+	}
+}
+
+// TestEventService_RSVPPromotesWaitlist tests that a freed seat goes to the head of the waitlist
+func TestEventService_RSVPPromotesWaitlist(t *testing.T) {
+	eventID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	waitingID := primitive.NewObjectID()
+	waiting := &models.EventTicket{ID: primitive.NewObjectID(), EventID: eventID, UserID: waitingID, Status: models.TicketStatusWaitlisted}
+
+	mockRepo := &mocks.MockEventRepository{}
+	mockTickets := &mocks.MockTicketRepository{}
+	mockBroadcaster := &mocks.MockEventBroadcaster{}
+	mockRepo.GetByIDFunc = func(ctx context.Context, id primitive.ObjectID) (*models.Event, error) {
+		event := testutil.NewEventBuilder().WithID(eventID).WithAttendee(userID, models.RSVPStatusGoing).Build()
+		event.Capacity = 1
+		return event, nil
+	}
+	attendees := map[primitive.ObjectID]models.RSVPStatus{}
+	mockRepo.AddOrUpdateAttendeeFunc = func(ctx context.Context, id primitive.ObjectID, attendee models.EventAttendee) error {
+		attendees[attendee.UserID] = attendee.Status
+		return nil
+	}
+	mockTickets.GetByEventAndUserFunc = func(ctx context.Context, eventID, userID primitive.ObjectID) (*models.EventTicket, error) {
+		return &models.EventTicket{ID: primitive.NewObjectID(), Status: models.TicketStatusConfirmed}, nil
+	}
+	mockTickets.NextWaitlistedFunc = func(ctx context.Context, eventID primitive.ObjectID) (*models.EventTicket, error) {
+		if waiting.Status != models.TicketStatusWaitlisted {
+			return nil, nil
+		}
+		return waiting, nil
+	}
+	mockTickets.TransitionFunc = func(ctx context.Context, id primitive.ObjectID, from, to models.EventTicketStatus) (bool, error) {
+		if id == waiting.ID {
+			waiting.Status = to
+		}
+		return true, nil
+	}
+	mockTickets.CountByStatusFunc = func(ctx context.Context, eventID primitive.ObjectID, status models.EventTicketStatus) (int64, error) {
+		if status == models.TicketStatusConfirmed {
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	svc := &EventService{
+		eventRepo:   mockRepo,
+		ticketRepo:  mockTickets,
+		broadcaster: mockBroadcaster,
+		asyncRunner: async.NewRunner(slog.Default()),
+	}
+
+	err := svc.RSVP(context.Background(), eventID, userID, models.RSVPStatusNotGoing, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.TicketStatusConfirmed, waiting.Status)
+	assert.Equal(t, models.RSVPStatusGoing, attendees[waitingID])
+	assert.Equal(t, models.RSVPStatusNotGoing, attendees[userID])
+	assert.Equal(t, 1, mockRepo.ReleaseSeatCalls)
+	assert.Equal(t, 1, mockRepo.ReserveSeatCalls)
+	assert.Equal(t, 2, mockBroadcaster.BroadcastRSVPCalls)
+}
+
+// TestCountStats tests that going and waitlist counts come from tickets
+func TestCountStats(t *testing.T) {
+	event := testutil.NewEventBuilder().
+		WithAttendee(primitive.NewObjectID(), models.RSVPStatusGoing).
+		WithAttendee(primitive.NewObjectID(), models.RSVPStatusInterested).
+		Build()
+	mockTickets := &mocks.MockTicketRepository{
+		CountByStatusFunc: func(ctx context.Context, eventID primitive.ObjectID, status models.EventTicketStatus) (int64, error) {
+			if status == models.TicketStatusConfirmed {
+				return 7, nil
+			}
+			return 3, nil
+		},
+	}
+
+	svc := &EventService{ticketRepo: mockTickets}
+	stats := svc.countStats(context.Background(), event)
+
+	assert.Equal(t, int64(7), stats.GoingCount)
+	assert.Equal(t, int64(3), stats.WaitlistCount)
+	assert.Equal(t, int64(1), stats.InterestedCount)
+}
@@ -1,23 +1,54 @@
 package validation
 
 import (
-	"errors"
+	"fmt"
 	"strings"
 	"time"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 )
 
 var (
-	ErrTitleRequired      = errors.New("title is required")
-	ErrTitleTooLong       = errors.New("title must be 200 characters or less")
-	ErrDescriptionTooLong = errors.New("description must be 5000 characters or less")
-	ErrInvalidDateRange   = errors.New("end date must be after start date")
-	ErrPastStartDate      = errors.New("start date cannot be in the past")
-	ErrInvalidPrivacy     = errors.New("invalid privacy setting")
-	ErrInvalidCategory    = errors.New("invalid category")
+	ErrTitleRequired      = apperrors.Validation("title is required")
+	ErrTitleTooLong       = apperrors.Validation("title must be 200 characters or less")
+	ErrDescriptionTooLong = apperrors.Validation("description must be 5000 characters or less")
+	ErrInvalidDateRange   = apperrors.Validation("end date must be after start date")
+	ErrPastStartDate      = apperrors.Validation("start date cannot be in the past")
+	ErrInvalidPrivacy     = apperrors.Validation("invalid privacy setting")
+	ErrInvalidCategory    = apperrors.Validation("invalid category")
+	ErrInvalidRecurrence  = apperrors.Validation("invalid recurrence rule")
+	ErrInvalidEditScope   = apperrors.Validation("scope must be this or all")
+	ErrInvalidCapacity    = apperrors.Validation("capacity cannot be negative")
+	ErrInvalidTicketTier  = apperrors.Validation("invalid ticket tier")
+	ErrInvalidPermissions = apperrors.Validation("invalid co-host permissions")
 )
 
+// MaxRecurrenceCount caps how many occurrences a counted series may have
+const MaxRecurrenceCount = 730
+
+// MaxTicketTiers caps how many ticket tiers an event may offer
+const MaxTicketTiers = 10
+
+// ValidFrequencies defines allowed recurrence frequencies
+var ValidFrequencies = map[models.RecurrenceFrequency]bool{
+	models.RecurrenceDaily:   true,
+	models.RecurrenceWeekly:  true,
+	models.RecurrenceMonthly: true,
+	models.RecurrenceYearly:  true,
+}
+
+// Weekdays maps RRULE BYDAY codes to weekdays
+var Weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
 // ValidPrivacies defines allowed privacy values
 var ValidPrivacies = map[models.EventPrivacy]bool{
 	models.EventPrivacyPublic:  true,
@@ -79,6 +110,79 @@ func ValidateCreateEventRequest(req *models.CreateEventRequest) error {
 		}
 	}
 
+	if req.Recurrence != nil {
+		if err := ValidateRecurrence(req.Recurrence, req.StartDate); err != nil {
+			return err
+		}
+	}
+
+	if req.Capacity < 0 {
+		return ErrInvalidCapacity
+	}
+	if err := ValidateTicketTiers(req.TicketTiers); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateTicketTiers validates the ticket tiers of a new event
+func ValidateTicketTiers(tiers []models.TicketTierRequest) error {
+	if len(tiers) > MaxTicketTiers {
+		return fmt.Errorf("%w: at most %d tiers are allowed", ErrInvalidTicketTier, MaxTicketTiers)
+	}
+	for _, tier := range tiers {
+		name := strings.TrimSpace(tier.Name)
+		if name == "" || len(name) > 100 {
+			return fmt.Errorf("%w: name must be 1 to 100 characters", ErrInvalidTicketTier)
+		}
+		switch tier.Type {
+		case models.TicketTierFree:
+			if tier.Price != 0 {
+				return fmt.Errorf("%w: free tiers cannot have a price", ErrInvalidTicketTier)
+			}
+		case models.TicketTierPaid:
+			if tier.Price <= 0 {
+				return fmt.Errorf("%w: paid tiers need a positive price", ErrInvalidTicketTier)
+			}
+			if len(tier.Currency) != 3 {
+				return fmt.Errorf("%w: paid tiers need a three-letter currency code", ErrInvalidTicketTier)
+			}
+		default:
+			return fmt.Errorf("%w: type must be free or paid", ErrInvalidTicketTier)
+		}
+	}
+	return nil
+}
+
+// ValidateRecurrence validates a recurrence rule for a series starting at start
+func ValidateRecurrence(rule *models.RecurrenceRule, start time.Time) error {
+	if !ValidFrequencies[rule.Frequency] {
+		return fmt.Errorf("%w: freq must be DAILY, WEEKLY, MONTHLY or YEARLY", ErrInvalidRecurrence)
+	}
+	if rule.Interval < 0 || rule.Interval > 365 {
+		return fmt.Errorf("%w: interval must be between 1 and 365", ErrInvalidRecurrence)
+	}
+	if len(rule.ByDay) > 0 && rule.Frequency != models.RecurrenceWeekly {
+		return fmt.Errorf("%w: by_day is only supported for WEEKLY rules", ErrInvalidRecurrence)
+	}
+	for _, day := range rule.ByDay {
+		if _, ok := Weekdays[day]; !ok {
+			return fmt.Errorf("%w: unknown weekday %q", ErrInvalidRecurrence, day)
+		}
+	}
+	if rule.Count < 0 || rule.Count > MaxRecurrenceCount {
+		return fmt.Errorf("%w: count must be between 1 and %d", ErrInvalidRecurrence, MaxRecurrenceCount)
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return fmt.Errorf("%w: count and until cannot both be set", ErrInvalidRecurrence)
+	}
+	if _, err := time.LoadLocation(rule.TimeZone); err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", ErrInvalidRecurrence, rule.TimeZone)
+	}
+	if rule.Until != nil && rule.Until.Before(start) {
+		return fmt.Errorf("%w: until must be after the start date", ErrInvalidRecurrence)
+	}
 	return nil
 }
 
@@ -102,6 +206,14 @@ func ValidateUpdateEventRequest(req *models.UpdateEventRequest) error {
 		return ErrInvalidDateRange
 	}
 
+	if req.Scope != "" && req.Scope != models.EventEditScopeAll && req.Scope != models.EventEditScopeThis {
+		return ErrInvalidEditScope
+	}
+
+	if req.Capacity != nil && *req.Capacity < 0 {
+		return ErrInvalidCapacity
+	}
+
 	// Privacy validation
 	if req.Privacy != "" && !ValidPrivacies[req.Privacy] {
 		return ErrInvalidPrivacy
@@ -117,3 +229,11 @@ func ValidateUpdateEventRequest(req *models.UpdateEventRequest) error {
 
 	return nil
 }
+
+// ValidateCoHostPermissions rejects bits that are not co-host permissions
+func ValidateCoHostPermissions(perms models.CoHostPermission) error {
+	if perms&^models.CoHostPermAll != 0 {
+		return ErrInvalidPermissions
+	}
+	return nil
+}
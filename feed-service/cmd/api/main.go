@@ -4,15 +4,27 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
+	"net/http"
 	"time"
 
+	"github.com/MuhibNayem/connectify-v2/feed-service/internal/admin"
 	"github.com/MuhibNayem/connectify-v2/feed-service/internal/config"
 	"github.com/MuhibNayem/connectify-v2/feed-service/internal/events"
 	"github.com/MuhibNayem/connectify-v2/feed-service/internal/graph"
 	"github.com/MuhibNayem/connectify-v2/feed-service/internal/grpc"
 	"github.com/MuhibNayem/connectify-v2/feed-service/internal/repository"
 	"github.com/MuhibNayem/connectify-v2/feed-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/featureflags"
+	sharedkafka "github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
+	exportpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/export/v1"
+	feedpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/feed/v1"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/serviceauth"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	googlegrpc "google.golang.org/grpc"
@@ -21,6 +33,22 @@ import (
 func main() {
 	cfg := config.LoadConfig()
 
+	// Tracing lets a post be followed from the request through Kafka to
+	// WebSocket delivery
+	tracerCtx, tracerCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	tp, err := observability.InitTracer(tracerCtx, observability.TracerConfig{
+		ServiceName:    "feed-service",
+		ServiceVersion: "1.0.0",
+		Environment:    "development",
+		JaegerEndpoint: cfg.JaegerOTLPEndpoint,
+	})
+	tracerCancel()
+	if err != nil {
+		log.Printf("Warning: Failed to initialize tracer: %v", err)
+	} else {
+		defer tp.Shutdown(context.Background())
+	}
+
 	// Connect to MongoDB
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -38,6 +66,16 @@ func main() {
 		log.Printf("Warning: Failed to ensure indexes: %v", err)
 	}
 
+	outboxRepo := repository.NewOutboxRepository(db)
+	if err := outboxRepo.EnsureIndexes(ctx); err != nil {
+		log.Printf("Warning: Failed to ensure outbox indexes: %v", err)
+	}
+
+	deadLetterRepo := repository.NewDeadLetterRepository(db)
+	if err := deadLetterRepo.EnsureIndexes(ctx); err != nil {
+		log.Printf("Warning: Failed to ensure dead letter indexes: %v", err)
+	}
+
 	// Initialize Neo4j Client
 	neo4jClient, err := graph.NewNeo4jClient(cfg.Neo4jURI, cfg.Neo4jUser, cfg.Neo4jPassword)
 	if err != nil {
@@ -50,17 +88,71 @@ func main() {
 	// Initialize Redis Cache
 	cacheRepo := repository.NewCacheRepository(cfg.RedisAddrs, cfg.RedisPassword)
 
+	// Trending Hashtags
+	trendingSvc := service.NewTrendingService(repository.NewTrendingRepository(cacheRepo))
+
 	// Start Event Listener
+	retryPipeline := events.NewRetryPipeline(cfg.KafkaBrokers, cfg.RetryDelays, deadLetterRepo)
+	defer retryPipeline.Close()
 	eventListener := events.NewEventListener(cfg, repo, cacheRepo, graphRepo)
+	eventListener.SetRetryPipeline(retryPipeline)
+	eventListener.SetTrending(trendingSvc)
 	ctxBg := context.Background()
 	eventListener.Start(ctxBg)
+	go trendingSvc.RunDecayWorker(ctxBg, cfg.TrendingDecayInterval)
 
 	// Event Producer
 	producer := events.NewEventProducer(cfg)
 	defer producer.Close()
 
+	searchIndexer := sharedkafka.NewSearchIndexPublisher(cfg.KafkaBrokers)
+	defer searchIndexer.Close()
+
+	insightsPublisher := sharedkafka.NewInsightsPublisher(cfg.KafkaBrokers)
+	defer insightsPublisher.Close()
+
 	svc := service.NewFeedService(repo, cacheRepo, graphRepo, producer)
+	svc.SetSearchIndexer(searchIndexer)
+	svc.SetInsights(insightsPublisher)
+	svc.SetOutbox(outboxRepo, cfg.KafkaTopic)
+
+	// Ranked Feeds, on for users in the feed.ranked rollout unless they pick
+	// an order
+	featureFlags := featureflags.NewClient(cacheRepo.Client(), featureflags.DefaultRefreshInterval, nil)
+	go featureFlags.Watch(ctxBg)
+	svc.SetFeatureFlags(featureFlags)
+	svc.SetRankedFeeds(repository.NewRankedFeedRepository(cacheRepo), cfg.RankedFeedTTL)
+	go svc.RunRankingWorker(ctxBg, cfg.RankedFeedRefreshInterval)
+
+	deletionParticipant := sharedkafka.NewUserDeletionParticipant(cfg.KafkaBrokers, models.ErasureServiceFeed, svc.DeleteUserData)
+	defer deletionParticipant.Close()
+	go deletionParticipant.Run(ctxBg)
+
+	// Post Change Watcher, keeping cached posts in step with edits from any service
+	postWatcher := events.NewPostChangeWatcher(cfg.KafkaBrokers, cfg.PostDeltaTopic, repo, cacheRepo)
+	defer postWatcher.Close()
+	go postWatcher.Start(ctxBg)
+
+	// Outbox Relay
+	outboxRelay := events.NewOutboxRelay(cfg.KafkaBrokers, outboxRepo)
+	defer outboxRelay.Close()
+	go outboxRelay.Start(ctxBg)
+
+	// Metrics
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		if cfg.AdminToken != "" {
+			admin.NewDeadLetterHandler(deadLetterRepo, eventListener, cfg.AdminToken).Register(mux)
+			admin.NewTimelineHandler(svc, cfg.AdminToken).Register(mux)
+		}
+		log.Printf("Feed Service metrics listening on port %s", cfg.ServerPort)
+		if err := http.ListenAndServe(fmt.Sprintf(":%s", cfg.ServerPort), mux); err != nil {
+			log.Printf("Metrics server stopped: %v", err)
+		}
+	}()
 	handler := grpc.NewServer(svc)
+	handler.SetTrendingService(trendingSvc)
 
 	// Start gRPC Server
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPCPort))
@@ -68,8 +160,13 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := googlegrpc.NewServer()
+	callers := serviceauth.NewVerifier("feed-service", cfg.ServiceAuthSecret, serviceauth.ParseMode(cfg.ServiceAuthMode), serviceauth.Allowlist{
+		serviceauth.AllMethods(feedpb.FeedService_ServiceDesc):         {"messaging-app", "api-gateway"},
+		serviceauth.AllMethods(exportpb.DataExportService_ServiceDesc): {"user-service"},
+	}, slog.Default())
+	grpcServer := googlegrpc.NewServer(observability.GetGRPCServerOption(), callers.UnaryServerOption(), callers.StreamServerOption())
 	handler.Register(grpcServer)
+	dataexport.NewServer(models.ErasureServiceFeed, svc.ExportUserData).Register(grpcServer)
 
 	log.Printf("Feed Service listening on port %s", cfg.GRPCPort)
 	if err := grpcServer.Serve(lis); err != nil {
@@ -0,0 +1,178 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/feed-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
+)
+
+// DeadLetterStore reads parked events
+type DeadLetterStore interface {
+	List(ctx context.Context, consumer string, includeReplayed bool, limit int64) ([]repository.DeadLetter, error)
+	FindByID(ctx context.Context, id primitive.ObjectID) (*repository.DeadLetter, error)
+}
+
+// Replayer runs a parked event through its consumer again
+type Replayer interface {
+	Replay(ctx context.Context, id primitive.ObjectID) (*repository.DeadLetter, error)
+}
+
+// DeadLetterHandler serves the dead-letter admin endpoints. Every request
+// must carry the admin token as a bearer token.
+type DeadLetterHandler struct {
+	store    DeadLetterStore
+	replayer Replayer
+	token    string
+}
+
+func NewDeadLetterHandler(store DeadLetterStore, replayer Replayer, token string) *DeadLetterHandler {
+	return &DeadLetterHandler{store: store, replayer: replayer, token: token}
+}
+
+// Register mounts the endpoints on mux
+func (h *DeadLetterHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /admin/dead-letters", h.authorized(h.list))
+	mux.HandleFunc("GET /admin/dead-letters/{id}", h.authorized(h.get))
+	mux.HandleFunc("POST /admin/dead-letters/{id}/replay", h.authorized(h.replay))
+}
+
+// deadLetterResponse shows the payload as text; event payloads are JSON
+type deadLetterResponse struct {
+	ID              string     `json:"id"`
+	Consumer        string     `json:"consumer"`
+	SourceTopic     string     `json:"source_topic"`
+	SourcePartition int        `json:"source_partition"`
+	SourceOffset    int64      `json:"source_offset"`
+	Key             string     `json:"key,omitempty"`
+	Payload         string     `json:"payload"`
+	Attempts        int        `json:"attempts"`
+	LastError       string     `json:"last_error"`
+	FirstFailedAt   time.Time  `json:"first_failed_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ReplayAttempts  int        `json:"replay_attempts"`
+	ReplayedAt      *time.Time `json:"replayed_at,omitempty"`
+}
+
+func toResponse(e *repository.DeadLetter) deadLetterResponse {
+	return deadLetterResponse{
+		ID:              e.ID.Hex(),
+		Consumer:        e.Consumer,
+		SourceTopic:     e.SourceTopic,
+		SourcePartition: e.SourcePartition,
+		SourceOffset:    e.SourceOffset,
+		Key:             string(e.Key),
+		Payload:         string(e.Payload),
+		Attempts:        e.Attempts,
+		LastError:       e.LastError,
+		FirstFailedAt:   e.FirstFailedAt,
+		CreatedAt:       e.CreatedAt,
+		ReplayAttempts:  e.ReplayAttempts,
+		ReplayedAt:      e.ReplayedAt,
+	}
+}
+
+// list returns parked events, newest first. Query: consumer, limit and
+// include_replayed.
+func (h *DeadLetterHandler) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit := int64(defaultListLimit)
+	if raw := query.Get("limit"); raw != "" {
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = min(n, maxListLimit)
+	}
+	includeReplayed, _ := strconv.ParseBool(query.Get("include_replayed"))
+
+	entries, err := h.store.List(r.Context(), query.Get("consumer"), includeReplayed, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	resp := make([]deadLetterResponse, len(entries))
+	for i := range entries {
+		resp[i] = toResponse(&entries[i])
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *DeadLetterHandler) get(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid dead letter ID")
+		return
+	}
+	entry, err := h.store.FindByID(r.Context(), id)
+	if err != nil {
+		writeDeadLetterError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toResponse(entry))
+}
+
+// replay handles the event again and reports whether it went through this time
+func (h *DeadLetterHandler) replay(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid dead letter ID")
+		return
+	}
+	entry, err := h.replayer.Replay(r.Context(), id)
+	if err != nil {
+		writeDeadLetterError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toResponse(entry))
+}
+
+func (h *DeadLetterHandler) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return requireToken(h.token, next)
+}
+
+// requireToken lets through only requests bearing the admin token
+func requireToken(adminToken string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			writeError(w, http.StatusUnauthorized, "admin token required")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func writeDeadLetterError(w http.ResponseWriter, err error) {
+	if errors.Is(err, repository.ErrDeadLetterNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("Error writing admin response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TimelineRebuilder refills Redis timelines from Mongo
+type TimelineRebuilder interface {
+	RebuildTimeline(ctx context.Context, userID string) (int, error)
+	RebuildAllTimelines(ctx context.Context) (rebuilt, failed int, err error)
+}
+
+// TimelineHandler serves the timeline admin endpoints, for recovering after
+// Redis lost the fanned-out timelines. Every request must carry the admin
+// token as a bearer token.
+type TimelineHandler struct {
+	rebuilder TimelineRebuilder
+	token     string
+	// running is set while a rebuild of every timeline is under way
+	running atomic.Bool
+}
+
+func NewTimelineHandler(rebuilder TimelineRebuilder, token string) *TimelineHandler {
+	return &TimelineHandler{rebuilder: rebuilder, token: token}
+}
+
+// Register mounts the endpoints on mux
+func (h *TimelineHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("POST /admin/timelines/rebuild", requireToken(h.token, h.rebuildAll))
+	mux.HandleFunc("POST /admin/timelines/{user_id}/rebuild", requireToken(h.token, h.rebuild))
+}
+
+// rebuild refills one user's timeline and reports how many posts it holds
+func (h *TimelineHandler) rebuild(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("user_id")
+	if !primitive.IsValidObjectID(userID) {
+		writeError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	posts, err := h.rebuilder.RebuildTimeline(r.Context(), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"user_id": userID, "posts": posts})
+}
+
+// rebuildAll starts rebuilding every user's timeline in the background; its
+// progress is logged. Only one such rebuild runs at a time.
+func (h *TimelineHandler) rebuildAll(w http.ResponseWriter, r *http.Request) {
+	if !h.running.CompareAndSwap(false, true) {
+		writeError(w, http.StatusConflict, "a rebuild of every timeline is already running")
+		return
+	}
+	go func() {
+		defer h.running.Store(false)
+		log.Printf("Rebuilding every timeline")
+		rebuilt, failed, err := h.rebuilder.RebuildAllTimelines(context.Background())
+		if err != nil {
+			log.Printf("Rebuilding every timeline stopped after %d (%d failed): %v", rebuilt, failed, err)
+			return
+		}
+		log.Printf("Rebuilt %d timelines, %d failed", rebuilt, failed)
+	}()
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": "rebuilding"})
+}
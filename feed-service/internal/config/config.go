@@ -3,7 +3,9 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -22,6 +24,30 @@ type Config struct {
 	Neo4jURI          string
 	Neo4jUser         string
 	Neo4jPassword     string
+
+	// Failed events are retried once per delay, via one retry topic each,
+	// before they are parked on the dead-letter topic
+	RetryDelays []time.Duration
+	// AdminToken guards the /admin endpoints; they are disabled when empty
+	AdminToken string
+	// TrendingDecayInterval is how often trending hashtag scores are aged
+	TrendingDecayInterval time.Duration
+	JaegerOTLPEndpoint    string
+	// RankedFeedRefreshInterval is how often the ranked feeds of active users
+	// are recomputed, RankedFeedTTL how long one is kept unread
+	RankedFeedRefreshInterval time.Duration
+	RankedFeedTTL             time.Duration
+	// FanoutCelebrityThreshold is the friend count above which an author's
+	// posts are pulled into timelines on read instead of fanned out; 0 always
+	// fans out
+	FanoutCelebrityThreshold int
+	// PostDeltaTopic receives a delta event for every change to a post seen
+	// on the posts change stream; publishing is off when it is empty
+	PostDeltaTopic string
+	// ServiceAuthSecret signs and verifies the tokens services call each
+	// other with; ServiceAuthMode is enforce, permissive or disabled
+	ServiceAuthSecret string
+	ServiceAuthMode   string
 }
 
 func LoadConfig() *Config {
@@ -43,6 +69,22 @@ func LoadConfig() *Config {
 		Neo4jURI:      getEnv("NEO4J_URI", "bolt://localhost:7687"),
 		Neo4jUser:     getEnv("NEO4J_USER", "neo4j"),
 		Neo4jPassword: getEnv("NEO4J_PASSWORD", "connectify"),
+
+		RetryDelays: durationsEnv("EVENT_RETRY_DELAYS", "30s,5m,30m"),
+		AdminToken:  getEnv("ADMIN_TOKEN", ""),
+
+		TrendingDecayInterval: durationEnv("TRENDING_DECAY_INTERVAL", time.Minute),
+		JaegerOTLPEndpoint:    getEnv("JAEGER_OTLP_ENDPOINT", "localhost:4317"),
+
+		RankedFeedRefreshInterval: durationEnv("RANKED_FEED_REFRESH_INTERVAL", 5*time.Minute),
+		RankedFeedTTL:             durationEnv("RANKED_FEED_TTL", 30*time.Minute),
+
+		FanoutCelebrityThreshold: intEnv("FANOUT_CELEBRITY_THRESHOLD", 5000),
+
+		PostDeltaTopic: getEnv("POST_DELTA_TOPIC", "post-deltas"),
+
+		ServiceAuthSecret: getEnv("SERVICE_AUTH_SECRET", ""),
+		ServiceAuthMode:   getEnv("SERVICE_AUTH_MODE", "enforce"),
 	}
 }
 
@@ -57,3 +99,46 @@ func splitEnv(key, fallback string) []string {
 	value := getEnv(key, fallback)
 	return strings.Split(value, ",")
 }
+
+func durationsEnv(key, fallback string) []time.Duration {
+	var durations []time.Duration
+	for _, part := range splitEnv(key, fallback) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		d, err := time.ParseDuration(part)
+		if err != nil {
+			log.Printf("Ignoring invalid duration %q in %s", part, key)
+			continue
+		}
+		durations = append(durations, d)
+	}
+	return durations
+}
+
+func intEnv(key string, fallback int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Printf("Ignoring invalid number %q in %s", value, key)
+		return fallback
+	}
+	return n
+}
+
+func durationEnv(key string, fallback time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Ignoring invalid duration %q in %s", value, key)
+		return fallback
+	}
+	return d
+}
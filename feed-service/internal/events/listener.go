@@ -3,22 +3,45 @@ package events
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/segmentio/kafka-go"
 	"github.com/MuhibNayem/connectify-v2/feed-service/internal/config"
 	"github.com/MuhibNayem/connectify-v2/feed-service/internal/repository"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	sharedkafka "github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var (
+	ErrUnknownConsumer     = errors.New("no handler for consumer")
+	ErrDeadLettersDisabled = errors.New("dead-letter queue is not enabled")
+)
+
+type handlerFunc func(context.Context, []byte) error
+
+// TrendingRecorder counts the hashtags of new posts
+type TrendingRecorder interface {
+	RecordPost(ctx context.Context, post *models.Post) error
+}
+
 type EventListener struct {
 	cfg       *config.Config
 	repo      *repository.FeedRepository
 	cacheRepo *repository.CacheRepository
 	graphRepo *repository.GraphRepository
 	readers   []*kafka.Reader
+	retries   *RetryPipeline
+	trending  TrendingRecorder
+	handlers  map[string]handlerFunc
+	mu        sync.Mutex
 }
 
 func NewEventListener(cfg *config.Config, repo *repository.FeedRepository, cacheRepo *repository.CacheRepository, graphRepo *repository.GraphRepository) *EventListener {
@@ -28,21 +51,61 @@ func NewEventListener(cfg *config.Config, repo *repository.FeedRepository, cache
 		cacheRepo: cacheRepo,
 		graphRepo: graphRepo,
 		readers:   []*kafka.Reader{},
+		handlers:  make(map[string]handlerFunc),
 	}
 }
 
+// SetRetryPipeline sends events that fail handling through retry topics and
+// on to a dead-letter topic. Without one, failed events are logged and dropped.
+func (l *EventListener) SetRetryPipeline(retries *RetryPipeline) {
+	l.retries = retries
+}
+
+// SetTrending counts the hashtags of created posts towards trending, on a
+// consumer group of its own so it lags or retries independently of fan-out
+func (l *EventListener) SetTrending(trending TrendingRecorder) {
+	l.trending = trending
+}
+
 func (l *EventListener) Start(ctx context.Context) {
 	// User Events Reader
-	l.startReader(ctx, "user-events", "feed-service-users", l.handleUserEvent)
+	l.startConsumer(ctx, "user-events", "feed-service-users", l.handleUserEvent)
 
 	// Friendship Events Reader
-	l.startReader(ctx, "friendship-events", "feed-service-friendships", l.handleFriendshipEvent)
+	l.startConsumer(ctx, "friendship-events", "feed-service-friendships", l.handleFriendshipEvent)
+
+	// Follow Events (drop the celebrity followees cached for timeline reads)
+	l.startConsumer(ctx, sharedkafka.FollowEventsTopic, "feed-service-follows", l.handleFollowEvent)
 
 	// Post Events (Fan-out)
-	l.startReader(ctx, l.cfg.KafkaTopic, "feed-service-fanout", l.handlePostEvent)
+	l.startConsumer(ctx, l.cfg.KafkaTopic, "feed-service-fanout", l.handlePostEvent)
+
+	// Media Moderation Verdicts (hold or decline posts showing flagged images)
+	l.startConsumer(ctx, sharedkafka.MediaModerationTopic, "feed-service-moderation", l.handleModerationVerdict)
+
+	// Post Events (Trending Hashtags)
+	if l.trending != nil {
+		l.startConsumer(ctx, l.cfg.KafkaTopic, "feed-service-trending", l.handleTrendingEvent)
+	}
 }
 
-func (l *EventListener) startReader(ctx context.Context, topic, groupID string, handler func(context.Context, []byte) error) {
+// startConsumer reads topic as groupID, along with the group's retry topics
+func (l *EventListener) startConsumer(ctx context.Context, topic, groupID string, handler handlerFunc) {
+	l.handlers[groupID] = handler
+	l.startReader(ctx, topic, groupID, groupID, handler)
+	if l.retries == nil {
+		return
+	}
+	for attempt := 1; attempt <= l.retries.Levels(); attempt++ {
+		retry := retryTopic(groupID, attempt)
+		l.startReader(ctx, retry, retry, groupID, handler)
+	}
+}
+
+// startReader hands each message on topic to handler. An offset is committed
+// once its message was handled or passed to the retry pipeline, so a crash in
+// between redelivers the message rather than losing it.
+func (l *EventListener) startReader(ctx context.Context, topic, groupID, consumer string, handler handlerFunc) {
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:  l.cfg.KafkaBrokers,
 		Topic:    topic,
@@ -50,7 +113,9 @@ func (l *EventListener) startReader(ctx context.Context, topic, groupID string,
 		MinBytes: 10e3, // 10KB
 		MaxBytes: 10e6, // 10MB
 	})
+	l.mu.Lock()
 	l.readers = append(l.readers, reader)
+	l.mu.Unlock()
 
 	go func() {
 		defer func() {
@@ -60,7 +125,7 @@ func (l *EventListener) startReader(ctx context.Context, topic, groupID string,
 		}()
 		log.Printf("Started Kafka consumer for topic: %s", topic)
 		for {
-			m, err := reader.ReadMessage(ctx)
+			m, err := reader.FetchMessage(ctx)
 			if err != nil {
 				// Check for context cancellation or closing
 				if ctx.Err() != nil {
@@ -72,94 +137,236 @@ func (l *EventListener) startReader(ctx context.Context, topic, groupID string,
 				continue
 			}
 
-			if err := handler(ctx, m.Value); err != nil {
+			if !waitUntilDue(ctx, m) {
+				return
+			}
+			// Each message continues the trace of the request that published it
+			msgCtx, span := observability.StartKafkaConsumerSpan(ctx, consumer, m)
+			err = handler(msgCtx, m.Value)
+			observability.EndSpan(span, err)
+			if err != nil {
 				log.Printf("Error handling message from %s: %v", topic, err)
+				if l.retries != nil && !l.retries.Fail(ctx, consumer, m, err) {
+					return
+				}
+			}
+			if err := reader.CommitMessages(ctx, m); err != nil {
+				log.Printf("Error committing offset on %s: %v", topic, err)
 			}
 		}
 	}()
 }
 
+// Replay runs a dead-lettered event through its consumer's handler again,
+// typically after the bug or outage that parked it was fixed
+func (l *EventListener) Replay(ctx context.Context, id primitive.ObjectID) (*repository.DeadLetter, error) {
+	if l.retries == nil {
+		return nil, ErrDeadLettersDisabled
+	}
+	deadLetters := l.retries.deadLetters
+	entry, err := deadLetters.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	handler, ok := l.handlers[entry.Consumer]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownConsumer, entry.Consumer)
+	}
+
+	if err := handler(ctx, entry.Payload); err != nil {
+		deadLetterReplays.WithLabelValues(entry.Consumer, "failed").Inc()
+		if markErr := deadLetters.MarkReplayFailed(ctx, id, err); markErr != nil {
+			log.Printf("Error recording failed replay of %s: %v", id.Hex(), markErr)
+		}
+		return nil, fmt.Errorf("replay failed: %w", err)
+	}
+
+	deadLetterReplays.WithLabelValues(entry.Consumer, "succeeded").Inc()
+	if err := deadLetters.MarkReplayed(ctx, id); err != nil {
+		return nil, err
+	}
+	return deadLetters.FindByID(ctx, id)
+}
+
 func (l *EventListener) handleUserEvent(ctx context.Context, value []byte) error {
 	// UserUpdatedEvent structure from shared-entity or manually defined if sharing is restricted
 	// Using shared-entity/events which we saw referenced in user_service.go
 	var event events.UserUpdatedEvent
 	if err := json.Unmarshal(value, &event); err != nil {
-		return err
+		return permanent(err)
 	}
 
+	// Both writes are idempotent, so a retry may safely repeat the one that worked
+	var errs []error
 	// Sync to MongoDB Replica (for Profile details)
 	if err := l.repo.UpsertUserReplica(ctx, &event); err != nil {
-		log.Printf("Error updating user replica: %v", err)
+		errs = append(errs, fmt.Errorf("updating user replica: %w", err))
 	}
 	// Sync to Neo4j (for Graph structure)
 	if err := l.graphRepo.SyncUser(ctx, event.UserID); err != nil {
-		log.Printf("Error syncing user to Neo4j: %v", err)
+		errs = append(errs, fmt.Errorf("syncing user to Neo4j: %w", err))
 	}
-	return nil // Return nil if both operations are attempted, logging errors internally
+	return errors.Join(errs...)
 }
 
 func (l *EventListener) handleFriendshipEvent(ctx context.Context, value []byte) error {
 	var event events.FriendshipEvent
 	if err := json.Unmarshal(value, &event); err != nil {
-		return err
+		return permanent(err)
 	}
 
+	var errs []error
 	// Sync to MongoDB Replica (Legacy, can be removed if fully switched)
 	if err := l.repo.UpdateFriendshipReplica(ctx, &event); err != nil {
-		log.Printf("Error updating friendship replica: %v", err)
+		errs = append(errs, fmt.Errorf("updating friendship replica: %w", err))
 	}
 	// Sync to Neo4j (Primary Friend Source)
 	if err := l.graphRepo.UpdateFriendship(ctx, event.RequesterID, event.ReceiverID, event.Status); err != nil {
-		log.Printf("Error updating friendship in Neo4j: %v", err)
+		errs = append(errs, fmt.Errorf("updating friendship in Neo4j: %w", err))
 	}
-	return nil // Return nil if both operations are attempted, logging errors internally
+	if err := l.cacheRepo.InvalidateCelebrityFollowees(ctx, event.RequesterID, event.ReceiverID); err != nil {
+		errs = append(errs, fmt.Errorf("invalidating celebrity followees: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// handleFollowEvent drops the follower's cached celebrity followees, so their
+// next timeline read picks up a celebrity they followed or stops merging one
+// they unfollowed. user-service writes the FOLLOWS edge itself.
+func (l *EventListener) handleFollowEvent(ctx context.Context, value []byte) error {
+	var event events.FollowEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		return permanent(err)
+	}
+	if err := l.cacheRepo.InvalidateCelebrityFollowees(ctx, event.FollowerID); err != nil {
+		return fmt.Errorf("invalidating celebrity followees of %s: %w", event.FollowerID, err)
+	}
+	return nil
 }
 
 func (l *EventListener) handlePostEvent(ctx context.Context, value []byte) error {
 	var event models.WebSocketEvent
 	if err := json.Unmarshal(value, &event); err != nil {
-		return err // Not a WebSocketEvent, ignore
+		return nil // Not a WebSocketEvent, ignore
 	}
 
 	if event.Type == "PostCreated" {
 		var post models.Post
 		if err := json.Unmarshal(event.Data, &post); err != nil {
-			log.Printf("Error unmarshaling post data for fanout: %v", err)
-			return nil
+			return permanent(fmt.Errorf("unmarshaling post data for fanout: %w", err))
 		}
 
-		// Fan-out: Get Friends and Push to their Timeline
+		// Fan-out: Push to the Timelines of the Friends in the Post's Audience
 		log.Printf("Starting Fan-out for Post %s (Author: %s)", post.ID.Hex(), post.UserID.Hex())
 
-		friendIDs, err := l.graphRepo.GetFriendIDs(ctx, post.UserID)
-		if err != nil {
-			log.Printf("Error fetching friends for fanout: %v", err)
-			return nil // Don't block processing others
+		var friendIDs []primitive.ObjectID
+		friendsAudience := post.Privacy != models.PrivacySettingOnlyMe && post.Privacy != models.PrivacySettingCustom
+		if friendsAudience {
+			ids, err := l.graphRepo.GetFriendIDs(ctx, post.UserID)
+			if err != nil {
+				return fmt.Errorf("fetching friends for fanout: %w", err)
+			}
+			for _, id := range ids {
+				if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+					friendIDs = append(friendIDs, oid)
+				}
+			}
 		}
 
-		// Also push to Author's timeline (My posts should be in my feed)
-		// Or maybe not? If feed is "Following", no. If "Timeline", yes.
-		// Standard: "Home Feed" includes own posts.
-		// friendIDs = append(friendIDs, post.UserID.Hex())
-		// Actually let's just stick to friends for now as "My Posts" are usually merged at read time or added here.
-		// Let's add author just in case.
-		if err := l.cacheRepo.PushToTimeline(ctx, post.UserID.Hex(), post.ID.Hex()); err != nil {
-			log.Printf("Error pushing to author timeline: %v", err)
+		// Followers who are not friends only get public posts, but count
+		// towards the author's reach either way
+		var followerIDs []primitive.ObjectID
+		if friendsAudience {
+			ids, err := l.graphRepo.GetFollowerIDs(ctx, post.UserID)
+			if err != nil {
+				return fmt.Errorf("fetching followers for fanout: %w", err)
+			}
+			for _, id := range ids {
+				if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+					followerIDs = append(followerIDs, oid)
+				}
+			}
 		}
 
-		for _, friendID := range friendIDs {
-			if err := l.cacheRepo.PushToTimeline(ctx, friendID, post.ID.Hex()); err != nil {
-				log.Printf("Error pushing to timeline:%s: %v", friendID, err)
-				// Continue...
+		// The audience always includes the author (My posts should be in my feed)
+		// Pushes are idempotent, so a retry of a partly failed fan-out only
+		// repeats it harmlessly for the timelines that already have the post
+		recipients := post.AudienceRecipients(friendIDs)
+		if post.Privacy == models.PrivacySettingPublic {
+			for _, id := range followerIDs {
+				if !slices.Contains(recipients, id) {
+					recipients = append(recipients, id)
+				}
+			}
+		}
+		if friendsAudience {
+			// Authors with too many friends and followers to fan out to are
+			// read into timelines on request instead; only their own timeline
+			// gets the post
+			celebrity := l.cfg.FanoutCelebrityThreshold > 0 && len(friendIDs)+len(followerIDs) > l.cfg.FanoutCelebrityThreshold
+			if err := l.cacheRepo.SetCelebrity(ctx, post.UserID.Hex(), celebrity); err != nil {
+				return fmt.Errorf("recording fan-out mode of %s: %w", post.UserID.Hex(), err)
 			}
+			if celebrity {
+				recipients = []primitive.ObjectID{post.UserID}
+				fanoutSkipped.Inc()
+			}
+		}
+		failed := 0
+		var lastErr error
+		for _, userID := range recipients {
+			if err := l.cacheRepo.PushToTimeline(ctx, userID.Hex(), post.ID.Hex()); err != nil {
+				failed++
+				lastErr = err
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("pushing post %s to %d of %d timelines: %w", post.ID.Hex(), failed, len(recipients), lastErr)
 		}
-		log.Printf("Fan-out complete for Post %s to %d timelines", post.ID.Hex(), len(friendIDs))
+		log.Printf("Fan-out complete for Post %s to %d timelines", post.ID.Hex(), len(recipients))
 	}
 
 	return nil
 }
 
+func (l *EventListener) handleTrendingEvent(ctx context.Context, value []byte) error {
+	var event models.WebSocketEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		return nil // Not a WebSocketEvent, ignore
+	}
+	if event.Type != "PostCreated" {
+		return nil
+	}
+
+	var post models.Post
+	if err := json.Unmarshal(event.Data, &post); err != nil {
+		return permanent(fmt.Errorf("unmarshaling post data for trending: %w", err))
+	}
+	return l.trending.RecordPost(ctx, &post)
+}
+
+func (l *EventListener) handleModerationVerdict(ctx context.Context, value []byte) error {
+	var event models.MediaModerationEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		return permanent(err)
+	}
+	if !event.Verdict.Flagged() {
+		return nil
+	}
+
+	moderated, err := l.repo.ModeratePostsByMedia(ctx, event.URL, event.Verdict)
+	if err != nil {
+		return fmt.Errorf("moderating posts for %s: %w", event.Key, err)
+	}
+	if moderated > 0 {
+		log.Printf("Moderation verdict %s applied to %d posts showing %s", event.Verdict, moderated, event.Key)
+	}
+	return nil
+}
+
 func (l *EventListener) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	for _, r := range l.readers {
 		if err := r.Close(); err != nil {
 			log.Printf("Error closing Kafka reader: %v", err)
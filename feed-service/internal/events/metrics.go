@@ -0,0 +1,50 @@
+package events
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	outboxPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "feed_outbox_events_published_total",
+		Help: "Total number of outbox events relayed to Kafka, by event type",
+	}, []string{"type"})
+	outboxPublishErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "feed_outbox_publish_errors_total",
+		Help: "Total number of failed outbox publish attempts, by event type",
+	}, []string{"type"})
+	outboxFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "feed_outbox_events_failed_total",
+		Help: "Total number of outbox events parked after exhausting their attempts, by event type",
+	}, []string{"type"})
+	outboxLag = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "feed_outbox_relay_lag_seconds",
+		Help:    "Time from an outbox event being written to it being published",
+		Buckets: []float64{.01, .05, .1, .5, 1, 5, 15, 60, 300},
+	})
+	outboxPending = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "feed_outbox_pending_events",
+		Help: "Number of outbox events waiting to be relayed",
+	})
+	retryOutcomes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "feed_consumer_failed_events_total",
+		Help: "Total number of consumed events that failed handling, by consumer and whether they were retried or dead-lettered",
+	}, []string{"consumer", "outcome"})
+	deadLetterReplays = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "feed_dead_letter_replays_total",
+		Help: "Total number of dead-letter replays, by consumer and result",
+	}, []string{"consumer", "result"})
+	fanoutSkipped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "feed_fanout_skipped_total",
+		Help: "Total number of posts by celebrity authors left to be read into timelines instead of fanned out",
+	})
+	postChanges = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "feed_post_changes_total",
+		Help: "Total number of post changes seen on the posts change stream, by delta operation",
+	}, []string{"op"})
+	postChangeStreamRestarts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "feed_post_change_stream_restarts_total",
+		Help: "Total number of times the posts change stream failed and was reopened",
+	})
+)
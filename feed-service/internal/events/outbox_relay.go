@@ -0,0 +1,122 @@
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/feed-service/internal/repository"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	outboxPollInterval = time.Second
+	outboxLease        = 30 * time.Second
+	outboxMaxAttempts  = 10
+	outboxMaxBackoff   = 5 * time.Minute
+)
+
+// OutboxRelay drains the outbox into Kafka. Delivery is at least once: an
+// event is only marked published after Kafka acknowledged it, so a crash in
+// between publishes it again.
+type OutboxRelay struct {
+	outbox *repository.OutboxRepository
+	writer *kafka.Writer
+}
+
+func NewOutboxRelay(brokers []string, outbox *repository.OutboxRepository) *OutboxRelay {
+	return &OutboxRelay{
+		outbox: outbox,
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+	}
+}
+
+func (r *OutboxRelay) Start(ctx context.Context) {
+	log.Println("Starting outbox relay")
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.drain(ctx)
+		if pending, err := r.outbox.CountPending(ctx); err == nil {
+			outboxPending.Set(float64(pending))
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Outbox relay stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain publishes due events until none are left
+func (r *OutboxRelay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		event, err := r.outbox.ClaimDue(ctx, outboxLease)
+		if err != nil {
+			log.Printf("Error claiming outbox event: %v", err)
+			return
+		}
+		if event == nil {
+			return
+		}
+		r.publish(ctx, event)
+	}
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, event *repository.OutboxEvent) {
+	msg := kafka.Message{
+		Topic: event.Topic,
+		Value: event.Payload,
+		Time:  event.CreatedAt,
+	}
+	if event.Key != "" {
+		msg.Key = []byte(event.Key)
+	}
+
+	// The relay publishes in the trace of the request that wrote the event
+	publishCtx, span := observability.StartKafkaProducerSpan(observability.ExtractMap(ctx, event.TraceContext), event.Topic, &msg)
+	err := r.writer.WriteMessages(publishCtx, msg)
+	observability.EndSpan(span, err)
+	if err != nil {
+		outboxPublishErrors.WithLabelValues(event.Type).Inc()
+		if event.Attempts >= outboxMaxAttempts {
+			log.Printf("Giving up on outbox event %s (%s) after %d attempts: %v", event.ID.Hex(), event.Type, event.Attempts, err)
+			outboxFailed.WithLabelValues(event.Type).Inc()
+			if err := r.outbox.MarkFailed(ctx, event.ID, err); err != nil {
+				log.Printf("Error marking outbox event %s failed: %v", event.ID.Hex(), err)
+			}
+			return
+		}
+		log.Printf("Error publishing outbox event %s (%s), attempt %d: %v", event.ID.Hex(), event.Type, event.Attempts, err)
+		if err := r.outbox.MarkRetry(ctx, event.ID, err, time.Now().Add(outboxBackoff(event.Attempts))); err != nil {
+			log.Printf("Error scheduling retry for outbox event %s: %v", event.ID.Hex(), err)
+		}
+		return
+	}
+
+	outboxPublished.WithLabelValues(event.Type).Inc()
+	outboxLag.Observe(time.Since(event.CreatedAt).Seconds())
+	if err := r.outbox.MarkPublished(ctx, event.ID); err != nil {
+		log.Printf("Error marking outbox event %s published: %v", event.ID.Hex(), err)
+	}
+}
+
+// outboxBackoff doubles the delay with every attempt, starting at one second
+func outboxBackoff(attempts int) time.Duration {
+	backoff := time.Second << min(attempts-1, 16)
+	return min(backoff, outboxMaxBackoff)
+}
+
+func (r *OutboxRelay) Close() {
+	if err := r.writer.Close(); err != nil {
+		log.Printf("Error closing outbox writer: %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/feed-service/internal/repository"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// postChangeStream names the posts change stream's saved resume token
+	postChangeStream = "posts"
+
+	postWatchMinBackoff = time.Second
+	postWatchMaxBackoff = 5 * time.Minute
+
+	// changeStreamHistoryLost is the server error for a resume token the
+	// oplog no longer reaches back to
+	changeStreamHistoryLost = 286
+)
+
+// postChange is the part of a posts change stream event the watcher reads
+type postChange struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument      *models.Post `bson:"fullDocument"`
+	UpdateDescription struct {
+		UpdatedFields bson.M   `bson:"updatedFields"`
+		RemovedFields []string `bson:"removedFields"`
+	} `bson:"updateDescription"`
+	ClusterTime primitive.Timestamp `bson:"clusterTime"`
+}
+
+// PostChangeWatcher follows the posts collection's change stream, so posts
+// edited by any service, messaging-app included, reach feed-service's cache:
+// cached posts are refreshed on update and dropped on delete, rather than
+// served stale until their TTL runs out. Every change is also published as a
+// PostDelta. Its position is kept in Redis, so a restart picks up the changes
+// made while it was down.
+type PostChangeWatcher struct {
+	repo      *repository.FeedRepository
+	cacheRepo *repository.CacheRepository
+	writer    *kafka.Writer
+}
+
+// NewPostChangeWatcher creates a watcher publishing deltas to topic; an empty
+// topic only keeps the cache coherent
+func NewPostChangeWatcher(brokers []string, topic string, repo *repository.FeedRepository, cacheRepo *repository.CacheRepository) *PostChangeWatcher {
+	w := &PostChangeWatcher{repo: repo, cacheRepo: cacheRepo}
+	if topic != "" {
+		w.writer = &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireOne,
+		}
+	}
+	return w
+}
+
+// Start watches posts until ctx is done, reopening the stream with backoff
+// when it fails, e.g. against a standalone server without change streams
+func (w *PostChangeWatcher) Start(ctx context.Context) {
+	log.Println("Starting post change watcher")
+	backoff := postWatchMinBackoff
+	for {
+		handled, err := w.watch(ctx)
+		if ctx.Err() != nil {
+			log.Println("Post change watcher stopped")
+			return
+		}
+		if handled > 0 {
+			backoff = postWatchMinBackoff
+		}
+		postChangeStreamRestarts.Inc()
+		log.Printf("Post change stream failed, reopening in %s: %v", backoff, err)
+
+		select {
+		case <-ctx.Done():
+			log.Println("Post change watcher stopped")
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, postWatchMaxBackoff)
+	}
+}
+
+func (w *PostChangeWatcher) Close() error {
+	if w.writer == nil {
+		return nil
+	}
+	return w.writer.Close()
+}
+
+// watch follows the stream from the saved resume token until it fails and
+// returns how many changes it handled
+func (w *PostChangeWatcher) watch(ctx context.Context) (int, error) {
+	token, err := w.cacheRepo.GetResumeToken(ctx, postChangeStream)
+	if err != nil {
+		return 0, err
+	}
+	stream, err := w.repo.WatchPosts(ctx, token)
+	var serverErr mongo.ServerError
+	if token != nil && errors.As(err, &serverErr) && serverErr.HasErrorCode(changeStreamHistoryLost) {
+		// Changes since the token are gone; cached posts written before then
+		// fall back to their TTL
+		log.Printf("Post change stream history lost, resuming from now")
+		if err := w.cacheRepo.ClearResumeToken(ctx, postChangeStream); err != nil {
+			return 0, err
+		}
+		stream, err = w.repo.WatchPosts(ctx, nil)
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer stream.Close(context.Background())
+
+	handled := 0
+	for stream.Next(ctx) {
+		var change postChange
+		if err := stream.Decode(&change); err != nil {
+			return handled, err
+		}
+		w.handle(ctx, &change)
+		handled++
+
+		if err := w.cacheRepo.SetResumeToken(ctx, postChangeStream, stream.ResumeToken()); err != nil {
+			log.Printf("Failed to save post change stream position: %v", err)
+		}
+	}
+	return handled, stream.Err()
+}
+
+// handle brings the cache in line with one change and publishes its delta.
+// Failures are logged rather than retried: the stream has moved on, and the
+// cache TTL bounds how long a missed refresh lasts.
+func (w *PostChangeWatcher) handle(ctx context.Context, change *postChange) {
+	delta := postDelta(change)
+	postChanges.WithLabelValues(string(delta.Op)).Inc()
+
+	postID := delta.PostID.Hex()
+	switch {
+	case delta.Op == models.PostDeltaCreated:
+		// Not cached yet
+	case delta.Post != nil && delta.Op == models.PostDeltaUpdated:
+		if err := w.cacheRepo.RefreshPost(ctx, delta.Post); err != nil {
+			log.Printf("Failed to refresh cached post %s: %v", postID, err)
+		}
+	default:
+		if err := w.cacheRepo.InvalidatePost(ctx, postID); err != nil {
+			log.Printf("Failed to invalidate cached post %s: %v", postID, err)
+		}
+	}
+
+	if w.writer == nil {
+		return
+	}
+	data, err := json.Marshal(delta)
+	if err != nil {
+		log.Printf("Failed to marshal delta for post %s: %v", postID, err)
+		return
+	}
+	if err := w.writer.WriteMessages(ctx, kafka.Message{Key: []byte(postID), Value: data, Time: delta.At}); err != nil {
+		log.Printf("Failed to publish delta for post %s: %v", postID, err)
+	}
+}
+
+// postDelta describes a change stream event as a PostDelta. A post moved to
+// the trash counts as deleted; one that was deleted before its update could be
+// looked up has no Post and is invalidated rather than refreshed.
+func postDelta(change *postChange) models.PostDelta {
+	delta := models.PostDelta{
+		PostID: change.DocumentKey.ID,
+		Op:     models.PostDeltaUpdated,
+		Post:   change.FullDocument,
+		At:     time.Unix(int64(change.ClusterTime.T), 0),
+	}
+	switch change.OperationType {
+	case "insert":
+		delta.Op = models.PostDeltaCreated
+	case "delete":
+		delta.Op = models.PostDeltaDeleted
+		delta.Post = nil
+	case "update":
+		for field := range change.UpdateDescription.UpdatedFields {
+			delta.UpdatedFields = append(delta.UpdatedFields, field)
+		}
+		delta.UpdatedFields = append(delta.UpdatedFields, change.UpdateDescription.RemovedFields...)
+		sort.Strings(delta.UpdatedFields)
+	}
+	if delta.Post != nil && delta.Post.Status == models.PostStatusDeleted {
+		delta.Op = models.PostDeltaDeleted
+	}
+	return delta
+}
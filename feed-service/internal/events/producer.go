@@ -10,6 +10,7 @@ import (
 	"github.com/MuhibNayem/connectify-v2/feed-service/internal/config"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
 )
 
 type EventProducer struct {
@@ -60,10 +61,13 @@ func (p *EventProducer) PublishWebSocketEvent(ctx context.Context, userID string
 		Time:  time.Now(),
 	}
 
-	return p.wsWriter.WriteMessages(ctx, msg)
+	ctx, span := observability.StartKafkaProducerSpan(ctx, p.wsWriter.Topic, &msg)
+	err = p.wsWriter.WriteMessages(ctx, msg)
+	observability.EndSpan(span, err)
+	return err
 }
 
-func (p *EventProducer) PublishEvent(topic string, event models.WebSocketEvent) error {
+func (p *EventProducer) PublishEvent(ctx context.Context, topic string, event models.WebSocketEvent) error {
 	// topic arg is currently unused as we have dedicated writers,
 	// but keeping signature generic for future extensibility if we add more writers.
 	// We assume 'messages' topic implies wsWriter.
@@ -85,7 +89,10 @@ func (p *EventProducer) PublishEvent(topic string, event models.WebSocketEvent)
 		Time:  time.Now(),
 	}
 
-	return p.wsWriter.WriteMessages(context.Background(), msg)
+	ctx, span := observability.StartKafkaProducerSpan(ctx, p.wsWriter.Topic, &msg)
+	err = p.wsWriter.WriteMessages(ctx, msg)
+	observability.EndSpan(span, err)
+	return err
 }
 
 func (p *EventProducer) PublishNotification(ctx context.Context, apiEvent *events.NotificationCreatedEvent) error {
@@ -103,7 +110,10 @@ func (p *EventProducer) PublishNotification(ctx context.Context, apiEvent *event
 		Time:  time.Now(),
 	}
 
-	return p.notifWriter.WriteMessages(ctx, msg)
+	ctx, span := observability.StartKafkaProducerSpan(ctx, p.notifWriter.Topic, &msg)
+	err = p.notifWriter.WriteMessages(ctx, msg)
+	observability.EndSpan(span, err)
+	return err
 }
 
 func (p *EventProducer) Close() {
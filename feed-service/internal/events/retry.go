@@ -0,0 +1,212 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/feed-service/internal/repository"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
+	"github.com/segmentio/kafka-go"
+)
+
+// Headers carried by retried and dead-lettered messages
+const (
+	headerSourceTopic     = "x-source-topic"
+	headerSourcePartition = "x-source-partition"
+	headerSourceOffset    = "x-source-offset"
+	headerAttempts        = "x-attempts"
+	headerNotBefore       = "x-not-before"
+	headerFirstFailedAt   = "x-first-failed-at"
+	headerError           = "x-error"
+)
+
+const retryPublishBackoff = 5 * time.Second
+
+// permanentError marks a failure that retrying cannot fix, such as a payload
+// that does not parse. Such messages skip the retry topics.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+func permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// retryTopic names the topic holding a consumer's messages that failed attempt
+// times. Topics are per consumer group so other consumers of the source topic
+// never see them.
+func retryTopic(consumer string, attempt int) string {
+	return fmt.Sprintf("%s.retry.%d", consumer, attempt)
+}
+
+func deadLetterTopic(consumer string) string {
+	return consumer + ".dlq"
+}
+
+// RetryPipeline moves messages that failed handling onto the next retry topic,
+// each with a longer delay, and finally onto the dead-letter topic
+type RetryPipeline struct {
+	delays      []time.Duration
+	writer      *kafka.Writer
+	deadLetters *repository.DeadLetterRepository
+}
+
+func NewRetryPipeline(brokers []string, delays []time.Duration, deadLetters *repository.DeadLetterRepository) *RetryPipeline {
+	return &RetryPipeline{
+		delays:      delays,
+		deadLetters: deadLetters,
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+		},
+	}
+}
+
+// Levels is the number of retry topics per consumer
+func (p *RetryPipeline) Levels() int {
+	return len(p.delays)
+}
+
+// Fail hands on a message consumer could not handle. It returns false only if
+// ctx ended before the message was safely handed on, in which case its offset
+// must not be committed.
+func (p *RetryPipeline) Fail(ctx context.Context, consumer string, m kafka.Message, cause error) bool {
+	attempts := headerInt(m, headerAttempts) + 1
+	firstFailedAt := time.Now()
+	if ms := headerInt(m, headerFirstFailedAt); ms > 0 {
+		firstFailedAt = time.UnixMilli(int64(ms))
+	}
+	source := sourceOf(m)
+
+	var next kafka.Message
+	var pe permanentError
+	if errors.As(cause, &pe) || attempts > len(p.delays) {
+		next = p.message(deadLetterTopic(consumer), m, source, attempts, firstFailedAt, time.Time{}, cause)
+		retryOutcomes.WithLabelValues(consumer, "dead_lettered").Inc()
+		log.Printf("Dead-lettering message from %s after %d attempt(s): %v", source.topic, attempts, cause)
+	} else {
+		notBefore := time.Now().Add(p.delays[attempts-1])
+		next = p.message(retryTopic(consumer, attempts), m, source, attempts, firstFailedAt, notBefore, cause)
+		retryOutcomes.WithLabelValues(consumer, "retried").Inc()
+	}
+
+	for {
+		err := p.writer.WriteMessages(ctx, next)
+		if err == nil {
+			break
+		}
+		log.Printf("Error publishing failed message to %s: %v", next.Topic, err)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(retryPublishBackoff):
+		}
+	}
+
+	if next.Topic == deadLetterTopic(consumer) {
+		if err := p.deadLetters.Insert(ctx, &repository.DeadLetter{
+			Consumer:        consumer,
+			SourceTopic:     source.topic,
+			SourcePartition: source.partition,
+			SourceOffset:    source.offset,
+			Key:             m.Key,
+			Payload:         m.Value,
+			Attempts:        attempts,
+			LastError:       cause.Error(),
+			FirstFailedAt:   firstFailedAt,
+		}); err != nil {
+			// The message is safe on the dead-letter topic, only harder to find
+			log.Printf("Error recording dead letter from %s: %v", source.topic, err)
+		}
+	}
+	return true
+}
+
+func (p *RetryPipeline) message(topic string, m kafka.Message, source messageSource, attempts int, firstFailedAt, notBefore time.Time, cause error) kafka.Message {
+	headers := []kafka.Header{
+		{Key: headerSourceTopic, Value: []byte(source.topic)},
+		{Key: headerSourcePartition, Value: []byte(strconv.Itoa(source.partition))},
+		{Key: headerSourceOffset, Value: []byte(strconv.FormatInt(source.offset, 10))},
+		{Key: headerAttempts, Value: []byte(strconv.Itoa(attempts))},
+		{Key: headerFirstFailedAt, Value: []byte(strconv.FormatInt(firstFailedAt.UnixMilli(), 10))},
+		{Key: headerError, Value: []byte(cause.Error())},
+	}
+	if !notBefore.IsZero() {
+		headers = append(headers, kafka.Header{Key: headerNotBefore, Value: []byte(strconv.FormatInt(notBefore.UnixMilli(), 10))})
+	}
+	// Retries stay in the trace of the original message
+	headers = append(headers, observability.TraceHeaders(m.Headers)...)
+	return kafka.Message{
+		Topic:   topic,
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: headers,
+		Time:    time.Now(),
+	}
+}
+
+func (p *RetryPipeline) Close() error {
+	return p.writer.Close()
+}
+
+// waitUntilDue sleeps until a retried message's delay has passed. Each retry
+// topic has a single delay, so messages become due in the order they are read.
+func waitUntilDue(ctx context.Context, m kafka.Message) bool {
+	ms := headerInt(m, headerNotBefore)
+	if ms <= 0 {
+		return true
+	}
+	wait := time.Until(time.UnixMilli(int64(ms)))
+	if wait <= 0 {
+		return true
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+type messageSource struct {
+	topic     string
+	partition int
+	offset    int64
+}
+
+// sourceOf returns where a message was first consumed from, following the
+// headers of retried messages back to the original topic
+func sourceOf(m kafka.Message) messageSource {
+	topic := header(m, headerSourceTopic)
+	if topic == "" {
+		return messageSource{topic: m.Topic, partition: m.Partition, offset: m.Offset}
+	}
+	offset, _ := strconv.ParseInt(header(m, headerSourceOffset), 10, 64)
+	return messageSource{topic: topic, partition: headerInt(m, headerSourcePartition), offset: offset}
+}
+
+func header(m kafka.Message, key string) string {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func headerInt(m kafka.Message, key string) int {
+	n, _ := strconv.Atoi(header(m, key))
+	return n
+}
@@ -2,31 +2,41 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
 	"github.com/MuhibNayem/connectify-v2/feed-service/internal/service"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/pkg/pagination"
 	feedpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/feed/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Server struct {
 	feedpb.UnimplementedFeedServiceServer
-	service *service.FeedService
+	service  *service.FeedService
+	trending *service.TrendingService
 }
 
 func NewServer(svc *service.FeedService) *Server {
 	return &Server{service: svc}
 }
 
+// SetTrendingService enables GetTrendingHashtags
+func (s *Server) SetTrendingService(trending *service.TrendingService) {
+	s.trending = trending
+}
+
 func (s *Server) Register(grpcServer *grpc.Server) {
 	feedpb.RegisterFeedServiceServer(grpcServer, s)
 }
 
 // CreatePost implements the gRPC method
 func (s *Server) CreatePost(ctx context.Context, req *feedpb.CreatePostRequest) (*feedpb.PostResponse, error) {
-	post, err := s.service.CreatePost(ctx, req.UserId, req.Content, req.Privacy)
+	post, err := s.service.CreatePost(ctx, req.UserId, req.Content, req.Privacy, req.CustomAudience)
 	if err != nil {
 		return nil, err
 	}
@@ -57,11 +67,54 @@ func (s *Server) DeletePost(ctx context.Context, req *feedpb.DeletePostRequest)
 	return &emptypb.Empty{}, nil
 }
 
+func (s *Server) SharePost(ctx context.Context, req *feedpb.SharePostRequest) (*feedpb.PostResponse, error) {
+	post, err := s.service.SharePost(ctx, req.PostId, req.UserId, req.Content, req.Privacy, req.CustomAudience)
+	switch {
+	case errors.Is(err, service.ErrPostNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrPostNotShareable), errors.Is(err, models.ErrShareExceedsAudience):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	return toProtoPost(post), nil
+}
+
 func (s *Server) ListPosts(ctx context.Context, req *feedpb.ListPostsRequest) (*feedpb.FeedResponse, error) {
-	posts, err := s.service.ListPosts(ctx, req.ViewerId, req.Page, req.Limit)
+	var (
+		posts      []models.Post
+		nextCursor string
+		err        error
+	)
+	order, err := s.service.FeedOrder(req.ViewerId, req.Order)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.Cursor != "" {
+		// Cursors come from chronological pages, and only page those
+		if req.Order == service.FeedOrderRanked {
+			return nil, status.Error(codes.InvalidArgument, "cursors only page the chronological feed")
+		}
+		order = service.FeedOrderChronological
+		posts, nextCursor, err = s.service.ListPostsAfter(ctx, req.ViewerId, req.Cursor, req.Limit)
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	} else if order == service.FeedOrderRanked {
+		posts, err = s.service.ListRankedPosts(ctx, req.ViewerId, req.Page, req.Limit)
+	} else {
+		posts, err = s.service.ListPosts(ctx, req.ViewerId, req.Page, req.Limit)
+		// A full page may have more after it; hand out a cursor so clients can
+		// switch from page numbers to cursors.
+		if req.Limit > 0 && int64(len(posts)) == req.Limit {
+			last := posts[len(posts)-1]
+			nextCursor = pagination.EncodeCursor(last.CreatedAt, last.ID)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
+	s.service.RecordImpressions(ctx, req.ViewerId, posts)
 
 	var protoPosts []*feedpb.PostResponse
 	for _, p := range posts {
@@ -69,10 +122,12 @@ func (s *Server) ListPosts(ctx context.Context, req *feedpb.ListPostsRequest) (*
 	}
 
 	return &feedpb.FeedResponse{
-		Posts: protoPosts,
-		Total: int64(len(posts)), // Placeholder
-		Page:  req.Page,
-		Limit: req.Limit,
+		Posts:      protoPosts,
+		Total:      int64(len(posts)), // Placeholder
+		Page:       req.Page,
+		Limit:      req.Limit,
+		NextCursor: nextCursor,
+		Order:      order,
 	}, nil
 }
 
@@ -95,6 +150,29 @@ func (s *Server) GetPostsByHashtag(ctx context.Context, req *feedpb.GetPostsByHa
 	}, nil
 }
 
+func (s *Server) GetTrendingHashtags(ctx context.Context, req *feedpb.GetTrendingHashtagsRequest) (*feedpb.GetTrendingHashtagsResponse, error) {
+	if s.trending == nil {
+		return nil, status.Error(codes.Unimplemented, "trending hashtags are not enabled")
+	}
+	tags, window, err := s.trending.Trending(ctx, req.Region, req.Window, int(req.Limit))
+	switch {
+	case errors.Is(err, service.ErrUnknownTrendingWindow):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, err
+	}
+
+	protoTags := make([]*feedpb.TrendingHashtag, 0, len(tags))
+	for _, t := range tags {
+		protoTags = append(protoTags, &feedpb.TrendingHashtag{Tag: t.Tag, Score: t.Score})
+	}
+	return &feedpb.GetTrendingHashtagsResponse{
+		Hashtags: protoTags,
+		Region:   service.NormalizeRegion(req.Region),
+		Window:   window,
+	}, nil
+}
+
 func (s *Server) ReactToPost(ctx context.Context, req *feedpb.ReactToPostRequest) (*emptypb.Empty, error) {
 	err := s.service.ReactToPost(ctx, req.UserId, req.PostId, req.Emoji)
 	if err != nil {
@@ -128,11 +206,60 @@ func toProtoPost(p *models.Post) *feedpb.PostResponse {
 		Privacy:        string(p.Privacy),
 		TotalReactions: p.TotalReactions,
 		TotalComments:  p.TotalComments,
+		TotalShares:    p.TotalShares,
+		SharedFrom:     toProtoPostShare(p.SharedFrom),
+		LinkPreview:    toProtoLinkPreview(p.LinkPreview),
 		CreatedAt:      timestamppb.New(p.CreatedAt),
 		UpdatedAt:      timestamppb.New(p.UpdatedAt),
 	}
 }
 
+func toProtoPostShare(s *models.PostShare) *feedpb.PostShare {
+	if s == nil {
+		return nil
+	}
+	share := &feedpb.PostShare{
+		PostId:           s.PostID.Hex(),
+		OriginalPostId:   s.OriginalPostID.Hex(),
+		OriginalAuthorId: s.OriginalAuthorID.Hex(),
+		Available:        s.Available,
+		OriginalContent:  s.OriginalContent,
+	}
+	for _, id := range s.Chain {
+		share.Chain = append(share.Chain, id.Hex())
+	}
+	for _, m := range s.OriginalMedia {
+		share.OriginalMedia = append(share.OriginalMedia, &feedpb.MediaItem{Url: m.URL, Type: m.Type})
+	}
+	if s.OriginalAuthor != nil {
+		share.OriginalAuthor = &feedpb.PostAuthor{
+			Id:       s.OriginalAuthor.ID,
+			Username: s.OriginalAuthor.Username,
+			Avatar:   s.OriginalAuthor.Avatar,
+			FullName: s.OriginalAuthor.FullName,
+			Verified: s.OriginalAuthor.Verified,
+		}
+	}
+	if s.OriginalCreatedAt != nil {
+		share.OriginalCreatedAt = timestamppb.New(*s.OriginalCreatedAt)
+	}
+	return share
+}
+
+func toProtoLinkPreview(l *models.LinkPreview) *feedpb.LinkPreview {
+	if l == nil {
+		return nil
+	}
+	return &feedpb.LinkPreview{
+		Url:         l.URL,
+		Title:       l.Title,
+		Description: l.Description,
+		ImageUrl:    l.ImageURL,
+		SiteName:    l.SiteName,
+		FetchedAt:   timestamppb.New(l.FetchedAt),
+	}
+}
+
 func toProtoComment(c *models.Comment) *feedpb.CommentResponse {
 	return &feedpb.CommentResponse{
 		Id:        c.ID.Hex(),
@@ -36,6 +36,11 @@ func NewCacheRepository(addrs []string, password string) *CacheRepository {
 	}
 }
 
+// Client exposes the Redis client for helpers that manage their own keys
+func (r *CacheRepository) Client() redis.UniversalClient {
+	return r.client
+}
+
 // ----------------------------- Post Caching -----------------------------
 
 func (r *CacheRepository) SetPost(ctx context.Context, post *models.Post) error {
@@ -111,15 +116,100 @@ func (r *CacheRepository) InvalidatePost(ctx context.Context, postID string) err
 	return r.client.Del(ctx, key).Err()
 }
 
+// RefreshPost replaces the cached copy of post, if there is one; posts that
+// are not cached stay uncached
+func (r *CacheRepository) RefreshPost(ctx context.Context, post *models.Post) error {
+	data, err := json.Marshal(post)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("post:%s", post.ID.Hex())
+	return r.client.SetXX(ctx, key, data, r.ttl).Err()
+}
+
+// ----------------------------- Change Streams -----------------------------
+
+func resumeTokenKey(stream string) string {
+	return fmt.Sprintf("changestream:%s:resume_token", stream)
+}
+
+// GetResumeToken returns where the named change stream left off, or nil to
+// start from now
+func (r *CacheRepository) GetResumeToken(ctx context.Context, stream string) ([]byte, error) {
+	token, err := r.client.Get(ctx, resumeTokenKey(stream)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	return token, err
+}
+
+// SetResumeToken records how far the named change stream got
+func (r *CacheRepository) SetResumeToken(ctx context.Context, stream string, token []byte) error {
+	return r.client.Set(ctx, resumeTokenKey(stream), token, 0).Err()
+}
+
+// ClearResumeToken forgets the named change stream's position, e.g. once the
+// oplog no longer reaches back to it
+func (r *CacheRepository) ClearResumeToken(ctx context.Context, stream string) error {
+	return r.client.Del(ctx, resumeTokenKey(stream)).Err()
+}
+
 // ----------------------------- Timeline (Fan-out) -----------------------------
 
+// TimelineMaxLen is how many posts a timeline keeps (Cost efficiency)
+const TimelineMaxLen = 500
+
+// celebritiesKey holds the authors with too many friends to fan out to, whose
+// posts are pulled into timelines on read instead
+const celebritiesKey = "timeline:celebrities"
+
+// celebritiesVersionKey counts the changes to celebritiesKey, so cached
+// celebrity followees computed before a change can be told apart
+const celebritiesVersionKey = "timeline:celebrities:version"
+
+// celebrityFolloweesTTL bounds how long a viewer's cached celebrity followees
+// live; changes to the viewer's friends and follows drop them sooner
+const celebrityFolloweesTTL = 15 * time.Minute
+
+func celebrityFolloweesKey(userID string) string {
+	return fmt.Sprintf("timeline:celebrity_followees:%s", userID)
+}
+
+// timelineAfterScript returns up to ARGV[2] entries of the timeline after
+// ARGV[1], or false when ARGV[1] is not in it
+var timelineAfterScript = redis.NewScript(`
+local pos = redis.call('LPOS', KEYS[1], ARGV[1])
+if not pos then
+	return false
+end
+return redis.call('LRANGE', KEYS[1], pos + 1, pos + tonumber(ARGV[2]))
+`)
+
 func (r *CacheRepository) PushToTimeline(ctx context.Context, userID, postID string) error {
 	key := fmt.Sprintf("timeline:%s", userID)
-	pipe := r.client.Pipeline()
+	pipe := r.client.TxPipeline()
+	// Drop any earlier copy so a redelivered fan-out does not list the post twice
+	pipe.LRem(ctx, key, 0, postID)
 	// Push to head
 	pipe.LPush(ctx, key, postID)
-	// Trim to keep only last 500 posts (Cost efficiency)
-	pipe.LTrim(ctx, key, 0, 499)
+	pipe.LTrim(ctx, key, 0, TimelineMaxLen-1)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ReplaceTimeline swaps userID's timeline for postIDs, newest first
+func (r *CacheRepository) ReplaceTimeline(ctx context.Context, userID string, postIDs []string) error {
+	key := fmt.Sprintf("timeline:%s", userID)
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(postIDs) > 0 {
+		values := make([]interface{}, len(postIDs))
+		for i, id := range postIDs {
+			values[i] = id
+		}
+		pipe.RPush(ctx, key, values...)
+		pipe.LTrim(ctx, key, 0, TimelineMaxLen-1)
+	}
 	_, err := pipe.Exec(ctx)
 	return err
 }
@@ -128,3 +218,121 @@ func (r *CacheRepository) GetTimeline(ctx context.Context, userID string, offset
 	key := fmt.Sprintf("timeline:%s", userID)
 	return r.client.LRange(ctx, key, offset, offset+limit-1).Result()
 }
+
+// GetTimelineAfter returns up to limit entries of userID's timeline after
+// postID. found is false when postID is not in the timeline, e.g. because it
+// was trimmed off or was never fanned out.
+func (r *CacheRepository) GetTimelineAfter(ctx context.Context, userID, postID string, limit int64) (ids []string, found bool, err error) {
+	key := fmt.Sprintf("timeline:%s", userID)
+	ids, err = timelineAfterScript.Run(ctx, r.client, []string{key}, postID, limit).StringSlice()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return ids, true, nil
+}
+
+// SetCelebrity records whether userID's posts skip fan-out. Actual changes
+// bump the celebrities version, invalidating every cached celebrity followee
+// set.
+func (r *CacheRepository) SetCelebrity(ctx context.Context, userID string, celebrity bool) error {
+	var changed int64
+	var err error
+	if celebrity {
+		changed, err = r.client.SAdd(ctx, celebritiesKey, userID).Result()
+	} else {
+		changed, err = r.client.SRem(ctx, celebritiesKey, userID).Result()
+	}
+	if err != nil || changed == 0 {
+		return err
+	}
+	return r.client.Incr(ctx, celebritiesVersionKey).Err()
+}
+
+// CelebrityFollowees are the celebrities among a viewer's friends and
+// followed users, as of a version of the celebrities set
+type CelebrityFollowees struct {
+	Version int64    `json:"version"`
+	IDs     []string `json:"ids"`
+}
+
+// GetCelebrityFollowees returns userID's cached celebrity followees, or nil
+// when there are none or they predate the current celebrities version, along
+// with that version.
+func (r *CacheRepository) GetCelebrityFollowees(ctx context.Context, userID string) (*CelebrityFollowees, int64, error) {
+	pipe := r.client.Pipeline()
+	versionCmd := pipe.Get(ctx, celebritiesVersionKey)
+	cachedCmd := pipe.Get(ctx, celebrityFolloweesKey(userID))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, 0, err
+	}
+
+	version, err := versionCmd.Int64()
+	if err != nil && err != redis.Nil {
+		return nil, 0, err
+	}
+	data, err := cachedCmd.Bytes()
+	if err == redis.Nil {
+		return nil, version, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	var cached CelebrityFollowees
+	if err := json.Unmarshal(data, &cached); err != nil || cached.Version != version {
+		return nil, version, nil
+	}
+	return &cached, version, nil
+}
+
+// SetCelebrityFollowees caches userID's celebrity followees
+func (r *CacheRepository) SetCelebrityFollowees(ctx context.Context, userID string, followees CelebrityFollowees) error {
+	data, err := json.Marshal(followees)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, celebrityFolloweesKey(userID), data, celebrityFolloweesTTL).Err()
+}
+
+// InvalidateCelebrityFollowees drops the cached celebrity followees of
+// userIDs, whose friends or follows changed
+func (r *CacheRepository) InvalidateCelebrityFollowees(ctx context.Context, userIDs ...string) error {
+	pipe := r.client.Pipeline()
+	for _, id := range userIDs {
+		pipe.Del(ctx, celebrityFolloweesKey(id))
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// FilterCelebrities returns those of userIDs whose posts skip fan-out
+func (r *CacheRepository) FilterCelebrities(ctx context.Context, userIDs []string) ([]string, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	members := make([]interface{}, len(userIDs))
+	for i, id := range userIDs {
+		members[i] = id
+	}
+	flags, err := r.client.SMIsMember(ctx, celebritiesKey, members...).Result()
+	if err != nil {
+		return nil, err
+	}
+	var celebrities []string
+	for i, isCelebrity := range flags {
+		if isCelebrity {
+			celebrities = append(celebrities, userIDs[i])
+		}
+	}
+	return celebrities, nil
+}
+
+// ----------------------------- Account Restrictions -----------------------------
+
+// GetShadowBannedIDs returns the users user-service limited, whose content
+// only they themselves get to see
+func (r *CacheRepository) GetShadowBannedIDs(ctx context.Context) ([]string, error) {
+	return r.client.SMembers(ctx, models.ShadowBannedUsersKey).Result()
+}
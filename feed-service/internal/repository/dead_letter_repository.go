@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// replayedDeadLetterRetention is how long replayed entries are kept for auditing
+const replayedDeadLetterRetention = 30 * 24 * time.Hour
+
+// DeadLetter is an event the listener gave up on. The same message is on the
+// consumer's dead-letter topic; this copy is what admins inspect and replay.
+type DeadLetter struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty"`
+	Consumer        string             `bson:"consumer"`
+	SourceTopic     string             `bson:"source_topic"`
+	SourcePartition int                `bson:"source_partition"`
+	SourceOffset    int64              `bson:"source_offset"`
+	Key             []byte             `bson:"key,omitempty"`
+	Payload         []byte             `bson:"payload"`
+	Attempts        int                `bson:"attempts"`
+	LastError       string             `bson:"last_error"`
+	FirstFailedAt   time.Time          `bson:"first_failed_at"`
+	CreatedAt       time.Time          `bson:"created_at"`
+	ReplayAttempts  int                `bson:"replay_attempts"`
+	ReplayedAt      *time.Time         `bson:"replayed_at,omitempty"`
+}
+
+type DeadLetterRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDeadLetterRepository(db *mongo.Database) *DeadLetterRepository {
+	return &DeadLetterRepository{collection: db.Collection("feed_dead_letters")}
+}
+
+func (r *DeadLetterRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "consumer", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys:    bson.D{{Key: "replayed_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(replayedDeadLetterRetention.Seconds())),
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to create indexes on feed_dead_letters: %v", err)
+	}
+	return err
+}
+
+func (r *DeadLetterRepository) Insert(ctx context.Context, entry *DeadLetter) error {
+	entry.ID = primitive.NewObjectID()
+	entry.CreatedAt = time.Now()
+	_, err := r.collection.InsertOne(ctx, entry)
+	return err
+}
+
+// List returns the newest entries, optionally for one consumer only. Replayed
+// entries are left out unless includeReplayed is set.
+func (r *DeadLetterRepository) List(ctx context.Context, consumer string, includeReplayed bool, limit int64) ([]DeadLetter, error) {
+	filter := bson.M{}
+	if consumer != "" {
+		filter["consumer"] = consumer
+	}
+	if !includeReplayed {
+		filter["replayed_at"] = bson.M{"$exists": false}
+	}
+
+	cursor, err := r.collection.Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []DeadLetter{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *DeadLetterRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*DeadLetter, error) {
+	var entry DeadLetter
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&entry)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// MarkReplayed records a successful replay
+func (r *DeadLetterRepository) MarkReplayed(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"replayed_at": time.Now()},
+		"$inc": bson.M{"replay_attempts": 1},
+	})
+	return err
+}
+
+// MarkReplayFailed records a replay that failed again
+func (r *DeadLetterRepository) MarkReplayFailed(ctx context.Context, id primitive.ObjectID, cause error) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"last_error": cause.Error()},
+		"$inc": bson.M{"replay_attempts": 1},
+	})
+	return err
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/pkg/pagination"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -36,8 +37,37 @@ func NewFeedRepository(db *mongo.Database) *FeedRepository {
 	}
 }
 
+// WithTransaction runs fn in a MongoDB transaction. Repository calls made with
+// the context passed to fn, including outbox writes, commit or abort together.
+func (r *FeedRepository) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
+	session, err := r.postsCollection.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
+
 // ----------------------------- Posts -----------------------------
 
+// WatchPosts opens a change stream on the posts collection reporting inserts,
+// updates, replacements and deletes, each with the post as it now stands.
+// A non-nil resumeToken continues after the change it was taken from.
+func (r *FeedRepository) WatchPosts(ctx context.Context, resumeToken bson.Raw) (*mongo.ChangeStream, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"operationType": bson.M{"$in": bson.A{"insert", "update", "replace", "delete"}}}}},
+	}
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if resumeToken != nil {
+		opts.SetResumeAfter(resumeToken)
+	}
+	return r.postsCollection.Watch(ctx, pipeline, opts)
+}
+
 func (r *FeedRepository) CreatePost(ctx context.Context, post *models.Post) (*models.Post, error) {
 	post.CreatedAt = time.Now()
 	post.UpdatedAt = time.Now()
@@ -80,6 +110,32 @@ func (r *FeedRepository) UpdatePostStatus(ctx context.Context, postID primitive.
 	return err
 }
 
+// ModeratePostsByMedia applies a media verdict to the posts showing it: a
+// held image sends its posts back to pending, a rejected one declines them.
+// Deleted posts are left alone. It returns how many posts changed.
+func (r *FeedRepository) ModeratePostsByMedia(ctx context.Context, mediaURL string, verdict models.ModerationVerdict) (int64, error) {
+	var status models.PostStatus
+	from := []models.PostStatus{models.PostStatusActive}
+	switch verdict {
+	case models.ModerationHeld:
+		status = models.PostStatusPending
+	case models.ModerationRejected:
+		status = models.PostStatusDeclined
+		from = append(from, models.PostStatusPending)
+	default:
+		return 0, nil
+	}
+
+	res, err := r.postsCollection.UpdateMany(ctx,
+		bson.M{"media.url": mediaURL, "status": bson.M{"$in": from}},
+		bson.M{"$set": bson.M{"status": status, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
 func (r *FeedRepository) DeletePost(ctx context.Context, userID, postID primitive.ObjectID) error {
 	res, err := r.postsCollection.DeleteOne(ctx, bson.M{"_id": postID, "user_id": userID})
 	if err != nil {
@@ -91,6 +147,125 @@ func (r *FeedRepository) DeletePost(ctx context.Context, userID, postID primitiv
 	return nil
 }
 
+// DeleteUserContent removes everything a deleted user left in the feed: their
+// posts with the comments, replies and reactions under them, their comments,
+// replies and reactions elsewhere, and their albums. Reaction counters on
+// surviving content are decremented. Returns the IDs of the removed posts and
+// the number of documents deleted; running it again deletes nothing more.
+func (r *FeedRepository) DeleteUserContent(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, int64, error) {
+	postIDs, err := distinctIDs(ctx, r.postsCollection, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, 0, err
+	}
+	commentIDs, err := distinctIDs(ctx, r.commentsCollection, bson.M{"$or": []bson.M{
+		{"user_id": userID},
+		{"post_id": bson.M{"$in": postIDs}},
+	}})
+	if err != nil {
+		return nil, 0, err
+	}
+	replyIDs, err := distinctIDs(ctx, r.repliesCollection, bson.M{"$or": []bson.M{
+		{"user_id": userID},
+		{"comment_id": bson.M{"$in": commentIDs}},
+	}})
+	if err != nil {
+		return nil, 0, err
+	}
+	albumIDs, err := distinctIDs(ctx, r.albumsCollection, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	removed := make(map[primitive.ObjectID]bool, len(postIDs)+len(commentIDs)+len(replyIDs))
+	for _, ids := range [][]primitive.ObjectID{postIDs, commentIDs, replyIDs} {
+		for _, id := range ids {
+			removed[id] = true
+		}
+	}
+	if err := r.decrementReactionCounters(ctx, userID, removed); err != nil {
+		return nil, 0, err
+	}
+
+	targets := append(append(append([]primitive.ObjectID{}, postIDs...), commentIDs...), replyIDs...)
+	deletes := []struct {
+		coll   *mongo.Collection
+		filter bson.M
+	}{
+		{r.reactionsCollection, bson.M{"$or": []bson.M{{"user_id": userID}, {"target_id": bson.M{"$in": targets}}}}},
+		{r.repliesCollection, bson.M{"_id": bson.M{"$in": replyIDs}}},
+		{r.commentsCollection, bson.M{"_id": bson.M{"$in": commentIDs}}},
+		{r.postsCollection, bson.M{"_id": bson.M{"$in": postIDs}}},
+		{r.albumMediaCollection, bson.M{"$or": []bson.M{{"user_id": userID}, {"album_id": bson.M{"$in": albumIDs}}}}},
+		{r.albumsCollection, bson.M{"_id": bson.M{"$in": albumIDs}}},
+	}
+
+	var total int64
+	for _, d := range deletes {
+		res, err := d.coll.DeleteMany(ctx, d.filter)
+		if err != nil {
+			return nil, total, err
+		}
+		total += res.DeletedCount
+	}
+	return postIDs, total, nil
+}
+
+// decrementReactionCounters takes the user's reactions off the content that
+// outlives them
+func (r *FeedRepository) decrementReactionCounters(ctx context.Context, userID primitive.ObjectID, removed map[primitive.ObjectID]bool) error {
+	reactions, err := r.ListReactions(ctx, bson.M{"user_id": userID}, nil)
+	if err != nil {
+		return err
+	}
+	byType := map[string][]primitive.ObjectID{}
+	for _, reaction := range reactions {
+		if !removed[reaction.TargetID] {
+			byType[reaction.TargetType] = append(byType[reaction.TargetType], reaction.TargetID)
+		}
+	}
+
+	collections := map[string]*mongo.Collection{
+		"post":    r.postsCollection,
+		"comment": r.commentsCollection,
+		"reply":   r.repliesCollection,
+	}
+	for targetType, ids := range byType {
+		coll, ok := collections[targetType]
+		if !ok {
+			continue
+		}
+		// A user reacts to a target at most once, so each loses one reaction
+		if _, err := coll.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, bson.M{"$inc": bson.M{"total_reactions": -1}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// distinctIDs returns the _id of every document in coll matching filter
+func distinctIDs(ctx context.Context, coll *mongo.Collection, filter bson.M) ([]primitive.ObjectID, error) {
+	values, err := coll.Distinct(ctx, "_id", filter)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(primitive.ObjectID); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// AdjustPostShareCount adds delta to the share counter of each post
+func (r *FeedRepository) AdjustPostShareCount(ctx context.Context, postIDs []primitive.ObjectID, delta int64) error {
+	_, err := r.postsCollection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": postIDs}},
+		bson.M{"$inc": bson.M{"total_shares": delta}},
+	)
+	return err
+}
+
 // ... (Other methods will be ported incrementally or genericized) ...
 // For the MVP, we need ListPosts and basic CRUD.
 
@@ -102,6 +277,88 @@ func (r *FeedRepository) ListPosts(ctx context.Context, filter bson.M, opts *opt
 	// NOTE: This assumes 'users' collection is in the same DB, which fits our "Shared DB" plan.
 	pipeline := mongo.Pipeline{
 		bson.D{{Key: "$match", Value: filter}},
+	}
+	pipeline = append(pipeline, authorLookupStages()...)
+	pipeline = append(pipeline, sharedFromLookupStages()...)
+
+	if opts != nil {
+		if opts.Sort != nil {
+			pipeline = append(pipeline, bson.D{{Key: "$sort", Value: opts.Sort}})
+		}
+		if opts.Skip != nil {
+			pipeline = append(pipeline, bson.D{{Key: "$skip", Value: *opts.Skip}})
+		}
+		if opts.Limit != nil {
+			pipeline = append(pipeline, bson.D{{Key: "$limit", Value: *opts.Limit}})
+		}
+	}
+
+	return r.aggregatePosts(ctx, pipeline)
+}
+
+// ListPostIDs returns the IDs of the newest posts matching filter
+func (r *FeedRepository) ListPostIDs(ctx context.Context, filter bson.M, limit int64) ([]primitive.ObjectID, error) {
+	opts := options.Find().
+		SetSort(pagination.Sort).
+		SetLimit(limit).
+		SetProjection(bson.M{"_id": 1})
+	cursor, err := r.postsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, len(docs))
+	for i, d := range docs {
+		ids[i] = d.ID
+	}
+	return ids, nil
+}
+
+// ListPostsAfter pages through posts newest first, starting after cursor
+// (nil for the first page). Unlike skip/limit, the cost of a page does not
+// grow with its depth. The returned cursor is empty on the last page.
+func (r *FeedRepository) ListPostsAfter(ctx context.Context, filter bson.M, cursor *pagination.Cursor, limit int64) ([]models.Post, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	if cursor != nil {
+		filter = bson.M{"$and": []bson.M{filter, cursor.Filter()}}
+	}
+
+	// Sort and limit before the author lookup so only one page is joined.
+	// One extra post tells us whether there is a next page.
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: filter}},
+		bson.D{{Key: "$sort", Value: pagination.Sort}},
+		bson.D{{Key: "$limit", Value: limit + 1}},
+	}
+	pipeline = append(pipeline, authorLookupStages()...)
+	pipeline = append(pipeline, sharedFromLookupStages()...)
+
+	posts, err := r.aggregatePosts(ctx, pipeline)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if int64(len(posts)) > limit {
+		posts = posts[:limit]
+		last := posts[len(posts)-1]
+		next = pagination.EncodeCursor(last.CreatedAt, last.ID)
+	}
+	return posts, next, nil
+}
+
+// authorLookupStages joins each post with its author from the local user replica
+func authorLookupStages() mongo.Pipeline {
+	return mongo.Pipeline{
 		// Lookup User (Author)
 		bson.D{{Key: "$lookup", Value: bson.M{
 			"from":         "users_replica",
@@ -117,22 +374,64 @@ func (r *FeedRepository) ListPosts(ctx context.Context, filter bson.M, opts *opt
 				"username":  "$author_info.username",
 				"full_name": "$author_info.full_name",
 				"avatar":    "$author_info.avatar",
+				"verified":  "$author_info.verified",
 			},
 		}}},
 	}
+}
 
-	if opts != nil {
-		if opts.Sort != nil {
-			pipeline = append(pipeline, bson.D{{Key: "$sort", Value: opts.Sort}})
-		}
-		if opts.Skip != nil {
-			pipeline = append(pipeline, bson.D{{Key: "$skip", Value: *opts.Skip}})
-		}
-		if opts.Limit != nil {
-			pipeline = append(pipeline, bson.D{{Key: "$limit", Value: *opts.Limit}})
-		}
+// sharedFromLookupStages resolves the original post and author of reshares.
+// Originals that were deleted, hidden, or made private are marked unavailable
+// and their content is left out.
+func sharedFromLookupStages() mongo.Pipeline {
+	available := bson.M{"$and": bson.A{
+		bson.M{"$eq": bson.A{bson.M{"$type": "$shared_original"}, "object"}},
+		bson.M{"$ne": bson.A{"$shared_original.privacy", models.PrivacySettingOnlyMe}},
+		bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$shared_original.status", models.PostStatusActive}}, models.PostStatusActive}},
+	}}
+	ifAvailable := func(value any) bson.M {
+		return bson.M{"$cond": bson.A{available, value, nil}}
 	}
 
+	return mongo.Pipeline{
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         "posts",
+			"localField":   "shared_from.original_post_id",
+			"foreignField": "_id",
+			"as":           "shared_original",
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.M{"path": "$shared_original", "preserveNullAndEmptyArrays": true}}},
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         "users_replica",
+			"localField":   "shared_original.user_id",
+			"foreignField": "_id",
+			"as":           "shared_original_author",
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.M{"path": "$shared_original_author", "preserveNullAndEmptyArrays": true}}},
+		bson.D{{Key: "$addFields", Value: bson.M{
+			"shared_from": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{bson.M{"$type": "$shared_from"}, "object"}},
+				bson.M{"$mergeObjects": bson.A{"$shared_from", bson.M{
+					"available":           available,
+					"original_content":    ifAvailable("$shared_original.content"),
+					"original_media":      ifAvailable("$shared_original.media"),
+					"original_created_at": ifAvailable("$shared_original.created_at"),
+					"original_author": ifAvailable(bson.M{
+						"id":        bson.M{"$toString": "$shared_original_author._id"},
+						"username":  "$shared_original_author.username",
+						"full_name": "$shared_original_author.full_name",
+						"avatar":    "$shared_original_author.avatar",
+						"verified":  "$shared_original_author.verified",
+					}),
+				}}},
+				"$$REMOVE",
+			}},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{"shared_original": 0, "shared_original_author": 0}}},
+	}
+}
+
+func (r *FeedRepository) aggregatePosts(ctx context.Context, pipeline mongo.Pipeline) ([]models.Post, error) {
 	cur, err := r.postsCollection.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
@@ -153,11 +452,14 @@ func (r *FeedRepository) CountPosts(ctx context.Context, filter bson.M) (int64,
 	return r.postsCollection.CountDocuments(ctx, filter)
 }
 
-func (r *FeedRepository) GetPostsByHashtag(ctx context.Context, hashtag string, limit, offset int64) ([]models.Post, error) {
+// GetPostsByHashtag lists active posts with the hashtag that match audience,
+// the viewer's models.PostAudienceFilter
+func (r *FeedRepository) GetPostsByHashtag(ctx context.Context, hashtag string, audience bson.M, limit, offset int64) ([]models.Post, error) {
 	filter := bson.M{
-		"hashtags": hashtag, // Simple array match
-		"status":   "ACTIVE",
-		"privacy":  "PUBLIC", // Hashtag searches are public usually
+		"$and": []bson.M{
+			{"hashtags": hashtag, "status": models.PostStatusActive}, // Simple array match
+			audience,
+		},
 	}
 	opts := options.Find().
 		SetLimit(limit).
@@ -179,30 +481,30 @@ func (r *FeedRepository) GetPostsByHashtag(ctx context.Context, hashtag string,
 
 // ----------------------------- Reactions -----------------------------
 
-func (r *FeedRepository) CreateReaction(ctx context.Context, reaction *models.Reaction) (*models.Reaction, error) {
-	reaction.CreatedAt = time.Now()
-	// Upsert to prevent duplicate reactions from same user
+// CreateReaction upserts the user's reaction on a target, changing its type if
+// one already exists. created reports whether a new reaction was inserted, so
+// callers only bump the target's counter once per user.
+func (r *FeedRepository) CreateReaction(ctx context.Context, reaction *models.Reaction) (*models.Reaction, bool, error) {
 	filter := bson.M{
 		"user_id":     reaction.UserID,
 		"target_id":   reaction.TargetID,
 		"target_type": reaction.TargetType,
 	}
 	update := bson.M{
-		"$set": reaction,
+		"$set":         bson.M{"type": reaction.Type},
+		"$setOnInsert": bson.M{"created_at": time.Now()},
 	}
 	opts := options.Update().SetUpsert(true)
-	_, err := r.reactionsCollection.UpdateOne(ctx, filter, update, opts)
+	res, err := r.reactionsCollection.UpdateOne(ctx, filter, update, opts)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	// We might need to fetch the inserted ID if new, but for reaction toggling this is often sufficient.
-	// However, to return a complete model with ID:
 	var savedReaction models.Reaction
 	err = r.reactionsCollection.FindOne(ctx, filter).Decode(&savedReaction)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	return &savedReaction, nil
+	return &savedReaction, res.UpsertedCount > 0, nil
 }
 
 func (r *FeedRepository) DeleteReaction(ctx context.Context, userID, targetID primitive.ObjectID, targetType string) error {
@@ -296,6 +598,29 @@ func (r *FeedRepository) GetFriends(ctx context.Context, userID primitive.Object
 	return friendIDs, nil
 }
 
+// ForEachUserID calls fn with the ID of every user in the local replica,
+// stopping at the first error
+func (r *FeedRepository) ForEachUserID(ctx context.Context, fn func(primitive.ObjectID) error) error {
+	cursor, err := r.usersCollection.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		if err := fn(doc.ID); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
 // ----------------------------- Data Replication -----------------------------
 
 func (r *FeedRepository) UpsertUserReplica(ctx context.Context, event *events.UserUpdatedEvent) error {
@@ -311,6 +636,7 @@ func (r *FeedRepository) UpsertUserReplica(ctx context.Context, event *events.Us
 			"full_name":     event.FullName,
 			"avatar":        event.Avatar,
 			"date_of_birth": event.DateOfBirth,
+			"verified":      event.Verified,
 			"updated_at":    time.Now(),
 		},
 	}
@@ -425,6 +751,7 @@ func (r *FeedRepository) ListComments(ctx context.Context, filter bson.M, opts *
 				"username":  "$author_info.username",
 				"full_name": "$author_info.full_name",
 				"avatar":    "$author_info.avatar",
+				"verified":  "$author_info.verified",
 			},
 		}}},
 	}
@@ -513,6 +840,7 @@ func (r *FeedRepository) ListReplies(ctx context.Context, filter bson.M, opts *o
 				"username":  "$author_info.username",
 				"full_name": "$author_info.full_name",
 				"avatar":    "$author_info.avatar",
+				"verified":  "$author_info.verified",
 			},
 		}}},
 	}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -83,3 +84,110 @@ func (r *GraphRepository) GetFriendIDs(ctx context.Context, userID primitive.Obj
 	}
 	return ids, nil
 }
+
+// GetFollowingIDs returns the users userID follows. FOLLOWS edges are
+// written by user-service.
+func (r *GraphRepository) GetFollowingIDs(ctx context.Context, userID primitive.ObjectID) ([]string, error) {
+	query := `MATCH (u:User {id: $userID})-[:FOLLOWS]->(f:User) RETURN f.id`
+	return r.userIDs(ctx, query, userID)
+}
+
+// GetFollowerIDs returns the users following userID
+func (r *GraphRepository) GetFollowerIDs(ctx context.Context, userID primitive.ObjectID) ([]string, error) {
+	query := `MATCH (u:User {id: $userID})<-[:FOLLOWS]-(f:User) RETURN f.id`
+	return r.userIDs(ctx, query, userID)
+}
+
+func (r *GraphRepository) userIDs(ctx context.Context, query string, userID primitive.ObjectID) ([]string, error) {
+	params := map[string]any{"userID": userID.Hex()}
+
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, rec := range result.Records {
+		if id, ok := rec.Values[0].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// GetBlockedIDs returns the users that userID blocked or was blocked by.
+// BLOCKED edges are written by user-service.
+func (r *GraphRepository) GetBlockedIDs(ctx context.Context, userID primitive.ObjectID) ([]string, error) {
+	query := `MATCH (u:User {id: $userID})-[:BLOCKED]-(b:User) RETURN DISTINCT b.id`
+	params := map[string]any{"userID": userID.Hex()}
+
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, rec := range result.Records {
+		if id, ok := rec.Values[0].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// Interaction is how much a user has engaged with an author's posts, as of
+// the last time they did
+type Interaction struct {
+	AuthorID string
+	Weight   float64
+	LastAt   time.Time
+}
+
+// RecordInteraction adds weight to userID's INTERACTED edge towards authorID.
+// The weight already there first decays by halfLife since the edge was last
+// touched, so old habits fade.
+func (r *GraphRepository) RecordInteraction(ctx context.Context, userID, authorID string, weight float64, halfLife time.Duration) error {
+	query := `
+		MERGE (u:User {id: $userID})
+		MERGE (a:User {id: $authorID})
+		MERGE (u)-[i:INTERACTED]->(a)
+		SET i.weight = coalesce(i.weight, 0.0) * 0.5 ^ ((timestamp() - coalesce(i.last_at, timestamp())) / $halfLife) + $weight,
+			i.last_at = timestamp()
+	`
+	params := map[string]any{
+		"userID":   userID,
+		"authorID": authorID,
+		"weight":   weight,
+		"halfLife": float64(halfLife.Milliseconds()),
+	}
+	_, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	return err
+}
+
+// GetInteractions returns the authors userID interacted with most, undecayed
+func (r *GraphRepository) GetInteractions(ctx context.Context, userID primitive.ObjectID, limit int) ([]Interaction, error) {
+	query := `
+		MATCH (u:User {id: $userID})-[i:INTERACTED]->(a:User)
+		RETURN a.id, i.weight, i.last_at
+		ORDER BY i.weight DESC
+		LIMIT $limit
+	`
+	params := map[string]any{"userID": userID.Hex(), "limit": limit}
+
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil {
+		return nil, err
+	}
+
+	interactions := make([]Interaction, 0, len(result.Records))
+	for _, rec := range result.Records {
+		id, ok := rec.Values[0].(string)
+		if !ok {
+			continue
+		}
+		weight, _ := rec.Values[1].(float64)
+		lastAt, _ := rec.Values[2].(int64)
+		interactions = append(interactions, Interaction{AuthorID: id, Weight: weight, LastAt: time.UnixMilli(lastAt)})
+	}
+	return interactions, nil
+}
@@ -39,6 +39,14 @@ func (r *FeedRepository) EnsureIndexes(ctx context.Context) error {
 		log.Printf("Failed to create index on posts(privacy): %v", err)
 	}
 
+	// Index for moderation verdicts, which find posts by the media they show
+	_, err = r.postsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "media.url", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("Failed to create index on posts(media.url): %v", err)
+	}
+
 	// 2. Friendships Indexes
 	// We query by requester_id OR receiver_id.
 	_, err = r.friendshipsCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending   OutboxStatus = "pending"
+	OutboxStatusPublished OutboxStatus = "published"
+	OutboxStatusFailed    OutboxStatus = "failed"
+)
+
+// publishedOutboxRetention is how long published events are kept for debugging
+const publishedOutboxRetention = 7 * 24 * time.Hour
+
+// OutboxEvent is a Kafka message waiting to be relayed. It is written in the
+// same transaction as the change it describes, so the event is only lost if
+// the change is.
+type OutboxEvent struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	Topic         string             `bson:"topic"`
+	Key           string             `bson:"key,omitempty"`
+	Type          string             `bson:"type"`
+	Payload       []byte             `bson:"payload"`
+	Status        OutboxStatus       `bson:"status"`
+	Attempts      int                `bson:"attempts"`
+	LastError     string             `bson:"last_error,omitempty"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at"`
+	CreatedAt     time.Time          `bson:"created_at"`
+	PublishedAt   *time.Time         `bson:"published_at,omitempty"`
+	// TraceContext links the published message to the request that wrote it
+	TraceContext map[string]string `bson:"trace_context,omitempty"`
+}
+
+type OutboxRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxRepository(db *mongo.Database) *OutboxRepository {
+	return &OutboxRepository{collection: db.Collection("outbox_events")}
+}
+
+func (r *OutboxRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "published_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(publishedOutboxRetention.Seconds())),
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to create indexes on outbox_events: %v", err)
+	}
+	return err
+}
+
+// Enqueue stores an event for the relay. Pass the transaction's context so the
+// event commits or aborts together with the write it belongs to.
+func (r *OutboxRepository) Enqueue(ctx context.Context, event *OutboxEvent) error {
+	now := time.Now()
+	event.ID = primitive.NewObjectID()
+	event.Status = OutboxStatusPending
+	event.CreatedAt = now
+	event.NextAttemptAt = now
+	event.TraceContext = observability.InjectMap(ctx)
+	_, err := r.collection.InsertOne(ctx, event)
+	return err
+}
+
+// ClaimDue leases the oldest due event for lease, so concurrent relays do not
+// publish it twice while it is in flight. It returns nil when nothing is due.
+func (r *OutboxRepository) ClaimDue(ctx context.Context, lease time.Duration) (*OutboxEvent, error) {
+	now := time.Now()
+	var event OutboxEvent
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"status": OutboxStatusPending, "next_attempt_at": bson.M{"$lte": now}},
+		bson.M{
+			"$set": bson.M{"next_attempt_at": now.Add(lease)},
+			"$inc": bson.M{"attempts": 1},
+		},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}).
+			SetReturnDocument(options.After),
+	).Decode(&event)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set":   bson.M{"status": OutboxStatusPublished, "published_at": time.Now()},
+		"$unset": bson.M{"last_error": ""},
+	})
+	return err
+}
+
+// MarkRetry records a failed attempt and schedules the next one
+func (r *OutboxRepository) MarkRetry(ctx context.Context, id primitive.ObjectID, cause error, nextAttemptAt time.Time) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"last_error": cause.Error(), "next_attempt_at": nextAttemptAt},
+	})
+	return err
+}
+
+// MarkFailed parks an event that ran out of attempts. Failed events stay in
+// the collection for inspection and can be replayed by resetting their status.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id primitive.ObjectID, cause error) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"status": OutboxStatusFailed, "last_error": cause.Error()},
+	})
+	return err
+}
+
+func (r *OutboxRepository) CountPending(ctx context.Context) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"status": OutboxStatusPending})
+}
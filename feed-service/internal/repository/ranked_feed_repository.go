@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	rankedFeedActiveKey  = "ranked_feed:active"
+	rankedFeedRefreshKey = "ranked_feed:refresh_lock"
+)
+
+// RankedPost is a post of a precomputed feed with its ranking score
+type RankedPost struct {
+	PostID string
+	Score  float64
+}
+
+// RankedFeedRepository keeps each user's precomputed ranked feed in a Redis
+// sorted set scored by rank, along with the users who read theirs recently
+type RankedFeedRepository struct {
+	client redis.UniversalClient
+}
+
+func NewRankedFeedRepository(cache *CacheRepository) *RankedFeedRepository {
+	return &RankedFeedRepository{client: cache.client}
+}
+
+func rankedFeedKey(userID string) string {
+	return "ranked_feed:" + userID
+}
+
+// Store replaces userID's ranked feed; readers see either the old or the new one
+func (r *RankedFeedRepository) Store(ctx context.Context, userID string, posts []RankedPost, ttl time.Duration) error {
+	key := rankedFeedKey(userID)
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(posts) > 0 {
+		members := make([]redis.Z, len(posts))
+		for i, p := range posts {
+			members[i] = redis.Z{Score: p.Score, Member: p.PostID}
+		}
+		pipe.ZAdd(ctx, key, members...)
+		pipe.Expire(ctx, key, ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Page returns a page of userID's ranked feed, best first. found is false
+// when no feed has been computed or it has expired.
+func (r *RankedFeedRepository) Page(ctx context.Context, userID string, offset, limit int64) (ids []string, found bool, err error) {
+	key := rankedFeedKey(userID)
+	pipe := r.client.Pipeline()
+	exists := pipe.Exists(ctx, key)
+	page := pipe.ZRevRange(ctx, key, offset, offset+limit-1)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, false, err
+	}
+	return page.Val(), exists.Val() > 0, nil
+}
+
+// MarkActive records that userID just read their ranked feed
+func (r *RankedFeedRepository) MarkActive(ctx context.Context, userID string, now time.Time) error {
+	return r.client.ZAdd(ctx, rankedFeedActiveKey, redis.Z{Score: float64(now.Unix()), Member: userID}).Err()
+}
+
+// ActiveUsers returns the users who read their ranked feed since the given
+// time, forgetting everyone who has not
+func (r *RankedFeedRepository) ActiveUsers(ctx context.Context, since time.Time) ([]string, error) {
+	if err := r.client.ZRemRangeByScore(ctx, rankedFeedActiveKey, "-inf", "("+strconv.FormatInt(since.Unix(), 10)).Err(); err != nil {
+		return nil, err
+	}
+	return r.client.ZRange(ctx, rankedFeedActiveKey, 0, -1).Result()
+}
+
+// AcquireRefresh claims the next refresh of every active feed for ttl, so only
+// one replica does it each interval
+func (r *RankedFeedRepository) AcquireRefresh(ctx context.Context, ttl time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, rankedFeedRefreshKey, 1, ttl).Result()
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// trendingMaxTags caps each sorted set; anything below the top few hundred
+	// tags never surfaces anyway
+	trendingMaxTags = 1000
+	// trendingMinScore drops tags whose score has decayed to noise
+	trendingMinScore = 0.05
+	trendingSeenTTL  = 7 * 24 * time.Hour
+)
+
+// trendingDecayScript scales every set of a window by 0.5^(elapsed/halfLife)
+// since its last decay, prunes faded tags and forgets regions left empty.
+// KEYS: decayed_at, regions, global, region sets. ARGV: now (ms), half-life
+// (ms), min score, then the region names matching the region set keys.
+var trendingDecayScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local last = tonumber(redis.call('GET', KEYS[1]) or now)
+redis.call('SET', KEYS[1], now)
+local elapsed = now - last
+if elapsed <= 0 then
+	return 0
+end
+local factor = math.pow(0.5, elapsed / tonumber(ARGV[2]))
+for i = 3, #KEYS do
+	redis.call('ZUNIONSTORE', KEYS[i], 1, KEYS[i], 'WEIGHTS', factor)
+	redis.call('ZREMRANGEBYSCORE', KEYS[i], '-inf', '(' .. ARGV[3])
+	if i > 3 and redis.call('EXISTS', KEYS[i]) == 0 then
+		redis.call('SREM', KEYS[2], ARGV[i])
+	end
+end
+return #KEYS - 2
+`)
+
+// TrendingRepository keeps per-window hashtag scores in Redis sorted sets,
+// one worldwide and one per region. All keys of a window share a hash tag so
+// the decay script can touch them together on a cluster.
+type TrendingRepository struct {
+	client redis.UniversalClient
+}
+
+func NewTrendingRepository(cache *CacheRepository) *TrendingRepository {
+	return &TrendingRepository{client: cache.client}
+}
+
+func trendingKey(window, region string) string {
+	if region == "" {
+		return fmt.Sprintf("trending:hashtags:{%s}:global", window)
+	}
+	return fmt.Sprintf("trending:hashtags:{%s}:region:%s", window, region)
+}
+
+func trendingRegionsKey(window string) string {
+	return fmt.Sprintf("trending:hashtags:{%s}:regions", window)
+}
+
+func trendingDecayedAtKey(window string) string {
+	return fmt.Sprintf("trending:hashtags:{%s}:decayed_at", window)
+}
+
+// MarkCounted records that postID's hashtags are being counted. It returns
+// false if they already were, so redelivered events are not counted twice.
+func (r *TrendingRepository) MarkCounted(ctx context.Context, postID string) (bool, error) {
+	return r.client.SetNX(ctx, "trending:hashtags:seen:"+postID, 1, trendingSeenTTL).Result()
+}
+
+// UnmarkCounted lets a post be counted again after recording it failed
+func (r *TrendingRepository) UnmarkCounted(ctx context.Context, postID string) error {
+	return r.client.Del(ctx, "trending:hashtags:seen:"+postID).Err()
+}
+
+// Record adds one use of each tag to every window, worldwide and, when region
+// is set, for that region
+func (r *TrendingRepository) Record(ctx context.Context, windows []string, region string, tags []string) error {
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, window := range windows {
+			keys := []string{trendingKey(window, "")}
+			if region != "" {
+				keys = append(keys, trendingKey(window, region))
+				pipe.SAdd(ctx, trendingRegionsKey(window), region)
+			}
+			for _, key := range keys {
+				for _, tag := range tags {
+					pipe.ZIncrBy(ctx, key, 1, tag)
+				}
+				pipe.ZRemRangeByRank(ctx, key, 0, -(trendingMaxTags + 1))
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// Decay ages a window's scores by the time since its last decay. Concurrent
+// callers are safe: the elapsed time is read and reset inside the script.
+func (r *TrendingRepository) Decay(ctx context.Context, window string, halfLife time.Duration, now time.Time) error {
+	regions, err := r.client.SMembers(ctx, trendingRegionsKey(window)).Result()
+	if err != nil {
+		return err
+	}
+	keys := []string{trendingDecayedAtKey(window), trendingRegionsKey(window), trendingKey(window, "")}
+	args := []interface{}{now.UnixMilli(), halfLife.Milliseconds(), strconv.FormatFloat(trendingMinScore, 'f', -1, 64)}
+	for _, region := range regions {
+		keys = append(keys, trendingKey(window, region))
+		args = append(args, region)
+	}
+	return trendingDecayScript.Run(ctx, r.client, keys, args...).Err()
+}
+
+// Top returns the highest scoring tags of a window, worldwide when region is empty
+func (r *TrendingRepository) Top(ctx context.Context, window, region string, limit int64) ([]models.TrendingHashtag, error) {
+	entries, err := r.client.ZRevRangeWithScores(ctx, trendingKey(window, region), 0, limit-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]models.TrendingHashtag, 0, len(entries))
+	for _, e := range entries {
+		tag, _ := e.Member.(string)
+		tags = append(tags, models.TrendingHashtag{Tag: tag, Score: e.Score})
+	}
+	return tags, nil
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeleteUserData purges a deleted account's posts, comments, replies,
+// reactions and albums, and drops its posts from the search index
+func (s *FeedService) DeleteUserData(ctx context.Context, userID string) (int64, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, errors.New("invalid user ID")
+	}
+
+	postIDs, deleted, err := s.repo.DeleteUserContent(ctx, uID)
+	if err != nil {
+		return deleted, fmt.Errorf("failed to delete feed content: %w", err)
+	}
+	if s.search != nil {
+		for _, id := range postIDs {
+			s.search.Delete(ctx, models.SearchEntityPost, id.Hex())
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/pkg/pagination"
+	exportpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/export/v1"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ExportUserData collects the user's posts, comments, replies, reactions and
+// albums for their data export
+func (s *FeedService) ExportUserData(ctx context.Context, userID string) ([]*exportpb.ExportSection, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	byUser := bson.M{"user_id": uID}
+	newestFirst := options.Find().SetSort(pagination.Sort)
+
+	posts, err := s.repo.ListPosts(ctx, byUser, newestFirst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export posts: %w", err)
+	}
+	comments, err := s.repo.ListComments(ctx, byUser, newestFirst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export comments: %w", err)
+	}
+	replies, err := s.repo.ListReplies(ctx, byUser, newestFirst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export replies: %w", err)
+	}
+	reactions, err := s.repo.ListReactions(ctx, byUser, newestFirst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export reactions: %w", err)
+	}
+	albums, err := s.repo.ListAlbums(ctx, byUser, newestFirst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export albums: %w", err)
+	}
+	albumMedia, err := s.repo.GetAlbumMedia(ctx, byUser, newestFirst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export album media: %w", err)
+	}
+
+	var postMedia, commentMedia, replyMedia, albumURLs []string
+	for _, p := range posts {
+		for _, m := range p.Media {
+			postMedia = append(postMedia, m.URL)
+		}
+	}
+	for _, c := range comments {
+		if c.MediaURL != "" {
+			commentMedia = append(commentMedia, c.MediaURL)
+		}
+	}
+	for _, r := range replies {
+		if r.MediaURL != "" {
+			replyMedia = append(replyMedia, r.MediaURL)
+		}
+	}
+	for _, m := range albumMedia {
+		albumURLs = append(albumURLs, m.URL)
+	}
+
+	var b dataexport.Builder
+	b.Add("posts", posts, postMedia...)
+	b.Add("comments", comments, commentMedia...)
+	b.Add("replies", replies, replyMedia...)
+	b.Add("reactions", reactions)
+	b.Add("albums", albums)
+	b.Add("album_media", albumMedia, albumURLs...)
+	return b.Sections()
+}
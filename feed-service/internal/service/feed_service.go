@@ -5,22 +5,44 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/feed-service/internal/events"
 	"github.com/MuhibNayem/connectify-v2/feed-service/internal/repository"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/featureflags"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/pkg/pagination"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ErrPostNotFound is returned for posts that do not exist or that the viewer cannot see
+var ErrPostNotFound = errors.New("post not found")
+
 type FeedService struct {
 	repo      *repository.FeedRepository
 	cacheRepo *repository.CacheRepository
 	graphRepo *repository.GraphRepository
 	producer  *events.EventProducer
+	search    SearchIndexer
+
+	outbox      *repository.OutboxRepository
+	outboxTopic string
+
+	ranked    *repository.RankedFeedRepository
+	rankedTTL time.Duration
+	flags     *featureflags.Client
+
+	insights InsightsRecorder
+}
+
+// SearchIndexer publishes post changes for search-service to index
+type SearchIndexer interface {
+	UpsertPost(ctx context.Context, doc *models.SearchPostDocument)
+	Delete(ctx context.Context, entity models.SearchEntityType, id string)
 }
 
 func NewFeedService(repo *repository.FeedRepository, cacheRepo *repository.CacheRepository, graphRepo *repository.GraphRepository, producer *events.EventProducer) *FeedService {
@@ -32,6 +54,57 @@ func NewFeedService(repo *repository.FeedRepository, cacheRepo *repository.Cache
 	}
 }
 
+// SetSearchIndexer enables publishing post changes to the search index
+func (s *FeedService) SetSearchIndexer(search SearchIndexer) {
+	s.search = search
+}
+
+// SetOutbox makes post events go through the transactional outbox on topic
+// instead of being published directly after the write
+func (s *FeedService) SetOutbox(outbox *repository.OutboxRepository, topic string) {
+	s.outbox = outbox
+	s.outboxTopic = topic
+}
+
+// writeWithEvent runs write and records the WebSocket event it returns. With an
+// outbox both are committed in one transaction and the relay publishes the
+// event; without one the event is published directly, best effort.
+func (s *FeedService) writeWithEvent(ctx context.Context, key string, write func(ctx context.Context) (*models.WebSocketEvent, error)) error {
+	if s.outbox == nil {
+		event, err := write(ctx)
+		if err != nil {
+			return err
+		}
+		if err := s.producer.PublishEvent(ctx, "messages", *event); err != nil {
+			fmt.Printf("Error publishing WS event: %v\n", err)
+		}
+		return nil
+	}
+
+	return s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		event, err := write(txCtx)
+		if err != nil {
+			return err
+		}
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		return s.outbox.Enqueue(txCtx, &repository.OutboxEvent{
+			Topic:   s.outboxTopic,
+			Key:     key,
+			Type:    event.Type,
+			Payload: payload,
+		})
+	})
+}
+
+func (s *FeedService) indexPost(ctx context.Context, post *models.Post) {
+	if s.search != nil && post != nil {
+		s.search.UpsertPost(ctx, models.NewSearchPostDocument(post))
+	}
+}
+
 // UpdatePostStatus updates the status of a post
 func (s *FeedService) UpdatePostStatus(ctx context.Context, postID, userID, status string) error {
 	pID, err := primitive.ObjectIDFromHex(postID)
@@ -51,69 +124,286 @@ func (s *FeedService) UpdatePostStatus(ctx context.Context, postID, userID, stat
 		return errors.New("unauthorized")
 	}
 
-	return s.repo.UpdatePostStatus(ctx, pID, status)
+	if err := s.repo.UpdatePostStatus(ctx, pID, status); err != nil {
+		return err
+	}
+
+	post.Status = models.PostStatus(status)
+	post.UpdatedAt = time.Now()
+	s.indexPost(ctx, post)
+	return nil
 }
 
 // CreatePost creates a new post
-func (s *FeedService) CreatePost(ctx context.Context, userID string, content string, privacy string) (*models.Post, error) {
+func (s *FeedService) CreatePost(ctx context.Context, userID string, content string, privacy string, customAudience []string) (*models.Post, error) {
 	uID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		return nil, errors.New("invalid user ID")
 	}
+	audience, err := parseAudience(customAudience)
+	if err != nil {
+		return nil, err
+	}
 
 	post := &models.Post{
-		UserID:    uID,
-		Content:   content,
-		Privacy:   models.PrivacySettingType(privacy), // Assuming simplified conversion for now
-		Status:    models.PostStatusActive,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		UserID:         uID,
+		Content:        content,
+		Privacy:        models.PrivacySettingType(privacy), // Assuming simplified conversion for now
+		CustomAudience: audience,
+		Status:         models.PostStatusActive,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 		// TODO: Handle Media, Mentions, Hashtags parsing
 	}
 
-	createdPost, err := s.repo.CreatePost(ctx, post)
+	// Publish Event (Smart Producer: Calculate Recipients here)
+	// We determine WHO should receive this update so the consumer (messaging-app) doesn't need to query the DB.
+	var friendIDs []primitive.ObjectID
+	if post.Privacy != models.PrivacySettingOnlyMe && post.Privacy != models.PrivacySettingCustom {
+		// Fetch friends from Neo4j (Graph Source of Truth)
+		if friendIDs, err = s.friendIDs(ctx, post.UserID); err != nil {
+			// Log error but don't fail the request; the author is always notified
+			fmt.Printf("Failed to resolve post audience: %v\n", err)
+		}
+	}
+	var recipientIDs []string
+	for _, id := range post.AudienceRecipients(friendIDs) {
+		recipientIDs = append(recipientIDs, id.Hex())
+	}
+
+	var createdPost *models.Post
+	err = s.writeWithEvent(ctx, userID, func(ctx context.Context) (*models.WebSocketEvent, error) {
+		created, err := s.repo.CreatePost(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		postData, err := json.Marshal(created)
+		if err != nil {
+			return nil, fmt.Errorf("marshal post for event: %w", err)
+		}
+		createdPost = created
+		return &models.WebSocketEvent{
+			Type:       "PostCreated",
+			Data:       postData,
+			Recipients: recipientIDs,
+		}, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	s.indexPost(ctx, createdPost)
 
-	// 3. Publish Event (Smart Producer: Calculate Recipients here)
-	// We determine WHO should receive this update so the consumer (messaging-app) doesn't need to query the DB.
+	// 2. Notifications for Mentions (Mock Logic - needs Mentions parsing)
+	// TODO: Parse mentions and send notifications
 
-	postData, err := json.Marshal(createdPost)
+	return createdPost, nil
+}
+
+// SharePost reshares a post to the user's timeline. The share must stay within
+// the audience of both the post and the original it was shared from, so
+// friends-only posts cannot be spread beyond the author's friends.
+func (s *FeedService) SharePost(ctx context.Context, postID, userID, content, privacy string, customAudience []string) (*models.Post, error) {
+	pID, err := primitive.ObjectIDFromHex(postID)
 	if err != nil {
-		// Should not happen, but log it
-		fmt.Printf("Error marshaling post for event: %v\n", err)
-	} else {
-		var recipientIDs []string
-		if post.Privacy == "PUBLIC" || post.Privacy == "FRIENDS" {
-			// Fetch friends from Neo4j (Graph Source of Truth)
-			friends, err := s.graphRepo.GetFriendIDs(ctx, post.UserID)
-			if err != nil {
-				// Log error but don't fail the request
-				// log.Printf("Failed to get friends: %v", err)
-			} else {
-				// Friends are already strings
-				recipientIDs = append(recipientIDs, friends...)
-			}
+		return nil, errors.New("invalid post ID")
+	}
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	audience, err := parseAudience(customAudience)
+	if err != nil {
+		return nil, err
+	}
+	sharePrivacy := models.PrivacySettingType(privacy)
+
+	parent, err := s.repo.GetPostByID(ctx, pID)
+	if err != nil {
+		return nil, ErrPostNotFound
+	}
+	if !s.canViewPost(ctx, uID, parent) {
+		return nil, ErrPostNotFound
+	}
+	if err := s.checkShareAudience(ctx, parent, sharePrivacy, audience); err != nil {
+		return nil, err
+	}
+
+	shared := models.NewPostShare(parent)
+	if shared.OriginalPostID != parent.ID {
+		original, err := s.repo.GetPostByID(ctx, shared.OriginalPostID)
+		if err != nil {
+			return nil, models.ErrPostNotShareable
+		}
+		if err := s.checkShareAudience(ctx, original, sharePrivacy, audience); err != nil {
+			return nil, err
 		}
-		// Always include self
-		recipientIDs = append(recipientIDs, post.UserID.Hex())
+	}
 
-		postCreatedEvent := models.WebSocketEvent{
-			Type:       "PostCreated",
+	post := &models.Post{
+		UserID:         uID,
+		Content:        content,
+		Privacy:        sharePrivacy,
+		CustomAudience: audience,
+		Status:         models.PostStatusActive,
+		SharedFrom:     shared,
+	}
+	createdPost, err := s.repo.CreatePost(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	s.indexPost(ctx, createdPost)
+
+	if err := s.repo.AdjustPostShareCount(ctx, shared.ShareCounterIDs(), 1); err != nil {
+		fmt.Printf("Failed to increment share count for post %s: %v\n", shared.OriginalPostID.Hex(), err)
+	}
+	for _, id := range shared.ShareCounterIDs() {
+		_ = s.cacheRepo.InvalidatePost(ctx, id.Hex())
+	}
+	s.recordInteraction(ctx, uID, shared.OriginalAuthorID, interactionShare)
+
+	postData, err := json.Marshal(createdPost)
+	if err == nil {
+		recipientIDs := []string{userID}
+		if shared.OriginalAuthorID != uID {
+			recipientIDs = append(recipientIDs, shared.OriginalAuthorID.Hex())
+		}
+		if err := s.producer.PublishEvent(ctx, "messages", models.WebSocketEvent{
+			Type:       "PostShared",
 			Data:       postData,
 			Recipients: recipientIDs,
+		}); err != nil {
+			fmt.Printf("Error publishing WS event: %v\n", err)
 		}
+	}
 
-		if err := s.producer.PublishEvent("messages", postCreatedEvent); err != nil {
-			fmt.Printf("Error publishing WS event: %v\n", err)
+	return createdPost, nil
+}
+
+// canViewPost reports whether viewerID is in the audience of post, neither
+// user has blocked the other and the author is not shadow-banned
+func (s *FeedService) canViewPost(ctx context.Context, viewerID primitive.ObjectID, post *models.Post) bool {
+	if viewerID != post.UserID {
+		hidden, err := s.hiddenAuthorIDs(ctx, viewerID)
+		if err != nil {
+			return false
+		}
+		if slices.Contains(hidden, post.UserID) {
+			return false
 		}
 	}
+	isFriend := false
+	if viewerID != post.UserID && post.NeedsFriendship() {
+		friendIDs, err := s.graphRepo.GetFriendIDs(ctx, post.UserID)
+		if err != nil {
+			return false
+		}
+		for _, id := range friendIDs {
+			if id == viewerID.Hex() {
+				isFriend = true
+				break
+			}
+		}
+	}
+	return post.VisibleTo(viewerID, isFriend)
+}
 
-	// 2. Notifications for Mentions (Mock Logic - needs Mentions parsing)
-	// TODO: Parse mentions and send notifications
+// checkShareAudience verifies a share's audience against the audience of post
+func (s *FeedService) checkShareAudience(ctx context.Context, post *models.Post, privacy models.PrivacySettingType, audience []primitive.ObjectID) error {
+	var friendIDs []primitive.ObjectID
+	if privacy == models.PrivacySettingCustom && post.NeedsFriendship() {
+		var err error
+		if friendIDs, err = s.friendIDs(ctx, post.UserID); err != nil {
+			return fmt.Errorf("failed to resolve post audience: %w", err)
+		}
+	}
+	return models.CheckShareAudience(post, privacy, audience, friendIDs)
+}
 
-	return createdPost, nil
+// friendIDs returns the user's friends from the graph as ObjectIDs
+func (s *FeedService) friendIDs(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ids, err := s.graphRepo.GetFriendIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	friendIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			friendIDs = append(friendIDs, oid)
+		}
+	}
+	return friendIDs, nil
+}
+
+// followingIDs returns the users userID follows
+func (s *FeedService) followingIDs(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ids, err := s.graphRepo.GetFollowingIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	following := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			following = append(following, oid)
+		}
+	}
+	return following, nil
+}
+
+// blockedIDs returns the users viewerID blocked or was blocked by
+func (s *FeedService) blockedIDs(ctx context.Context, viewerID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ids, err := s.graphRepo.GetBlockedIDs(ctx, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	blocked := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			blocked = append(blocked, oid)
+		}
+	}
+	return blocked, nil
+}
+
+// hiddenAuthorIDs returns the users whose posts viewerID must not see: those
+// blocked either way and those shadow-banned. Shadow bans are best effort, so
+// failing to read them only logs.
+func (s *FeedService) hiddenAuthorIDs(ctx context.Context, viewerID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	hidden, err := s.blockedIDs(ctx, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	banned, err := s.cacheRepo.GetShadowBannedIDs(ctx)
+	if err != nil {
+		fmt.Printf("Failed to resolve shadow-banned users: %v\n", err)
+		return hidden, nil
+	}
+	for _, id := range banned {
+		// Shadow-banned users still see their own posts
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil && oid != viewerID {
+			hidden = append(hidden, oid)
+		}
+	}
+	return hidden, nil
+}
+
+// withoutBlocked narrows filter to posts whose author is not blocked
+func withoutBlocked(filter bson.M, blocked []primitive.ObjectID) bson.M {
+	if len(blocked) == 0 {
+		return filter
+	}
+	return bson.M{"$and": []bson.M{filter, {"user_id": bson.M{"$nin": blocked}}}}
+}
+
+func parseAudience(ids []string) ([]primitive.ObjectID, error) {
+	audience := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, errors.New("invalid custom audience ID")
+		}
+		audience = append(audience, oid)
+	}
+	return audience, nil
 }
 
 func (s *FeedService) GetPost(ctx context.Context, postID string) (*models.Post, error) {
@@ -169,21 +459,31 @@ func (s *FeedService) UpdatePost(ctx context.Context, postID, userID, content, p
 		update["privacy"] = privacy
 	}
 
-	updatedPost, err := s.repo.UpdatePost(ctx, pID, update)
+	// 3. Update and Publish Event
+	var updatedPost *models.Post
+	err = s.writeWithEvent(ctx, userID, func(ctx context.Context) (*models.WebSocketEvent, error) {
+		updated, err := s.repo.UpdatePost(ctx, pID, update)
+		if err != nil {
+			return nil, err
+		}
+		postData, err := json.Marshal(updated)
+		if err != nil {
+			return nil, fmt.Errorf("marshal post for event: %w", err)
+		}
+		updatedPost = updated
+		return &models.WebSocketEvent{
+			Type:       "PostUpdated",
+			Data:       postData,
+			Recipients: []string{userID}, // Simplified
+		}, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	// Invalidate Cache
 	_ = s.cacheRepo.InvalidatePost(ctx, postID)
-
-	// 3. Publish Event
-	postData, _ := json.Marshal(updatedPost)
-	s.producer.PublishEvent("messages", models.WebSocketEvent{
-		Type:       "PostUpdated",
-		Data:       postData,
-		Recipients: []string{userID}, // Simplified
-	})
+	s.indexPost(ctx, updatedPost)
 
 	return updatedPost, nil
 }
@@ -197,9 +497,22 @@ func (s *FeedService) GetPostsByHashtag(ctx context.Context, viewerID string, ha
 		offset = 0
 	}
 
-	// In a real app, we might also filter by blocked users or more complex privacy,
-	// but mostly hashtags are for public discovery.
-	return s.repo.GetPostsByHashtag(ctx, hashtag, limit, offset)
+	vID, err := primitive.ObjectIDFromHex(viewerID)
+	if err != nil {
+		return nil, errors.New("invalid viewer ID")
+	}
+	friendIDs, err := s.friendIDs(ctx, vID)
+	if err != nil {
+		// Fall back to what everyone can see
+		fmt.Printf("Failed to resolve friend graph for %s: %v\n", viewerID, err)
+	}
+
+	hidden, err := s.hiddenAuthorIDs(ctx, vID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve blocked users: %w", err)
+	}
+	audience := withoutBlocked(models.PostAudienceFilter(vID, friendIDs), hidden)
+	return s.repo.GetPostsByHashtag(ctx, hashtag, audience, limit, offset)
 }
 
 func (s *FeedService) DeletePost(ctx context.Context, postID, userID string) error {
@@ -212,13 +525,26 @@ func (s *FeedService) DeletePost(ctx context.Context, postID, userID string) err
 		return errors.New("invalid user ID")
 	}
 
+	post, err := s.repo.GetPostByID(ctx, pID)
+	if err != nil {
+		return err
+	}
+
 	err = s.repo.DeletePost(ctx, uID, pID)
 	if err != nil {
 		return err
 	}
+	if post.SharedFrom != nil {
+		if err := s.repo.AdjustPostShareCount(ctx, post.SharedFrom.ShareCounterIDs(), -1); err != nil {
+			fmt.Printf("Failed to decrement share count for post %s: %v\n", post.SharedFrom.OriginalPostID.Hex(), err)
+		}
+	}
+	if s.search != nil {
+		s.search.Delete(ctx, models.SearchEntityPost, postID)
+	}
 
 	// Publish Event
-	s.producer.PublishEvent("messages", models.WebSocketEvent{
+	s.producer.PublishEvent(ctx, "messages", models.WebSocketEvent{
 		Type:       "PostDeleted",
 		Data:       []byte(fmt.Sprintf(`{"id": "%s"}`, postID)),
 		Recipients: []string{userID},
@@ -241,100 +567,137 @@ func (s *FeedService) ListPosts(ctx context.Context, viewerID string, page, limi
 		offset = 0
 	}
 
-	// 1. Try Fetching from Redis Timeline
-	timelineIDs, err := s.cacheRepo.GetTimeline(ctx, viewerID, offset, limit)
-	if err == nil && len(timelineIDs) > 0 {
-		// Found in Redis. Hydrate posts.
-		posts, missingIDs, err := s.cacheRepo.GetPosts(ctx, timelineIDs)
-		if err == nil {
-			// If we have some missing posts (evicted?), we could fetch them from DB
-			if len(missingIDs) > 0 {
-				fmt.Printf("Cache partial miss for %d posts\n", len(missingIDs))
-				// Optionally fetch missing ones from DB and re-cache
-				for _, mid := range missingIDs {
-					if oid, err := primitive.ObjectIDFromHex(mid); err == nil {
-						p, err := s.repo.GetPostByID(ctx, oid)
-						if err == nil {
-							posts = append(posts, p)
-							_ = s.cacheRepo.SetPost(ctx, p)
-						}
-					}
-				}
-			}
-
-			// Dereference pointers to values (sort order corresponds to timelineIDs order, ideally)
-			// But MGET might not preserve order if we appended missing ones.
-			// For simplicity, we assume map correlation or just return list.
-			// Ideally we should re-sort by CreatedAt if we mixed sources, but Timeline in Redis IS sorted.
-
-			deduped := make([]*models.Post, 0, len(posts))
-			seen := make(map[string]struct{}, len(posts))
-			for _, p := range posts {
-				if p == nil {
-					continue
-				}
-				key := p.ID.Hex()
-				if _, ok := seen[key]; ok {
-					continue
-				}
-				seen[key] = struct{}{}
-				deduped = append(deduped, p)
-			}
-
-			sort.Slice(deduped, func(i, j int) bool {
-				return deduped[i].CreatedAt.After(deduped[j].CreatedAt)
-			})
-			if len(deduped) > int(limit) {
-				deduped = deduped[:limit]
+	// 1. Try Fetching from Redis Timeline. Posts of celebrity friends were
+	// not fanned out and are merged in here. Placing them in a deeper page
+	// would take every page before it, so deeper pages of viewers with
+	// celebrity friends come from Mongo; cursors page those cheaply.
+	celebrities := s.celebrityFriendIDs(ctx, vID)
+	if offset == 0 || len(celebrities) == 0 {
+		timelineIDs, err := s.cacheRepo.GetTimeline(ctx, viewerID, offset, limit)
+		if err == nil && len(timelineIDs) == 0 && offset == 0 {
+			// The timeline is missing, e.g. Redis lost it; rebuild it for next time
+			if _, err := s.RebuildTimeline(ctx, viewerID); err != nil {
+				fmt.Printf("Failed to rebuild timeline of %s: %v\n", viewerID, err)
+			} else {
+				timelineIDs, err = s.cacheRepo.GetTimeline(ctx, viewerID, offset, limit)
 			}
-
-			result := make([]models.Post, 0, len(deduped))
-			for _, p := range deduped {
-				result = append(result, *p)
+		}
+		if err == nil && len(timelineIDs) > 0 {
+			// Found in Redis. Hydrate posts, dropping those fanned out before a
+			// block or shadow ban.
+			if posts, err := s.timelinePage(ctx, vID, timelineIDs, celebrities, nil, limit); err == nil {
+				return posts, nil
 			}
-			return result, nil
 		}
 	}
 
 	// 2. Fallback to Mongo (Aggregations) - The "Pull" Model
-	// Get Friends List from Neo4j
-	friendIDTags, err := s.graphRepo.GetFriendIDs(ctx, vID)
+	filter := s.timelineFilter(ctx, vID)
+	opts := options.Find().
+		SetSort(pagination.Sort).
+		SetSkip(offset).
+		SetLimit(limit)
+	return s.repo.ListPosts(ctx, filter, opts)
+}
+
+// ListPostsAfter returns the viewer's timeline page after cursor (empty for
+// the first page) along with the cursor of the next page, which is empty once
+// the timeline is exhausted. It resumes the Redis timeline at the cursor's
+// post; cursors ending on a post the timeline does not hold, such as a
+// celebrity's or one trimmed off, continue from Mongo.
+func (s *FeedService) ListPostsAfter(ctx context.Context, viewerID, cursor string, limit int64) ([]models.Post, string, error) {
+	vID, err := primitive.ObjectIDFromHex(viewerID)
 	if err != nil {
-		fmt.Printf("Failed to resolve friend graph for %s: %v\n", viewerID, err)
+		return nil, "", errors.New("invalid viewer ID")
 	}
 
-	// Convert string IDs to ObjectIDs for Mongo Query
-	var friendIDs []primitive.ObjectID
-	for _, idStr := range friendIDTags {
-		if oid, err := primitive.ObjectIDFromHex(idStr); err == nil {
-			friendIDs = append(friendIDs, oid)
+	var after *pagination.Cursor
+	if cursor != "" {
+		if after, err = pagination.DecodeCursor(cursor); err != nil {
+			return nil, "", err
 		}
 	}
-	// Note: We don't strictly need to append vID here if using $or query that handles "My Posts" separately,
-	// but keeping it simple.
 
-	// 2. Build Query
-	orConditions := []bson.M{
-		{
-			"user_id": vID,
-			"status":  "active",
-		},
+	if limit <= 0 {
+		limit = 20
 	}
-	if len(friendIDs) > 0 {
-		orConditions = append(orConditions, bson.M{
-			"user_id": bson.M{"$in": friendIDs},
-			"privacy": bson.M{"$in": []string{"PUBLIC", "FRIENDS"}},
-			"status":  "active",
-		})
+
+	var timelineIDs []string
+	found := true
+	if after == nil {
+		timelineIDs, err = s.cacheRepo.GetTimeline(ctx, viewerID, 0, limit)
+	} else {
+		timelineIDs, found, err = s.cacheRepo.GetTimelineAfter(ctx, viewerID, after.ID.Hex(), limit)
+	}
+	if err == nil && found && len(timelineIDs) > 0 {
+		celebrities := s.celebrityFriendIDs(ctx, vID)
+		posts, err := s.timelinePage(ctx, vID, timelineIDs, celebrities, after, limit)
+		if err == nil && len(posts) > 0 {
+			// Whether older posts follow is only known from the next read,
+			// which ends the feed from Mongo once the timeline runs out
+			last := posts[len(posts)-1]
+			return posts, pagination.EncodeCursor(last.CreatedAt, last.ID), nil
+		}
 	}
 
-	filter := bson.M{
-		"$or": orConditions,
+	return s.repo.ListPostsAfter(ctx, s.timelineFilter(ctx, vID), after, limit)
+}
+
+// timelinePage hydrates a slice of the viewer's Redis timeline and merges in
+// their celebrity friends' posts after cursor, returning up to limit posts
+// newest first
+func (s *FeedService) timelinePage(ctx context.Context, vID primitive.ObjectID, timelineIDs []string, celebrities []primitive.ObjectID, cursor *pagination.Cursor, limit int64) ([]models.Post, error) {
+	posts, err := s.hydratePosts(ctx, vID, timelineIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(celebrities) > 0 {
+		celebrityPosts, err := s.celebrityPosts(ctx, vID, celebrities, cursor, limit)
+		if err != nil {
+			fmt.Printf("Failed to load celebrity posts for %s: %v\n", vID.Hex(), err)
+		}
+		posts = mergePosts(posts, celebrityPosts)
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		if !posts[i].CreatedAt.Equal(posts[j].CreatedAt) {
+			return posts[i].CreatedAt.After(posts[j].CreatedAt)
+		}
+		return posts[i].ID.Hex() > posts[j].ID.Hex()
+	})
+	if len(posts) > int(limit) {
+		posts = posts[:limit]
 	}
+	return posts, nil
+}
 
-	// 3. Pagination is handled by repo (needs proper options construction)
-	// For now passing nil options as POC
-	return s.repo.ListPosts(ctx, filter, nil)
+// timelineFilter matches the viewer's own posts and those of their friends
+// that the viewer is allowed to see. Blocked users are never friends, since
+// blocking removes the FRIEND edge, but shadow-banned friends are left out.
+func (s *FeedService) timelineFilter(ctx context.Context, vID primitive.ObjectID) bson.M {
+	// Get Friends List from Neo4j
+	friendIDs, err := s.friendIDs(ctx, vID)
+	if err != nil {
+		fmt.Printf("Failed to resolve friend graph for %s: %v\n", vID.Hex(), err)
+	}
+	followingIDs, err := s.followingIDs(ctx, vID)
+	if err != nil {
+		fmt.Printf("Failed to resolve followed users for %s: %v\n", vID.Hex(), err)
+	}
+	hidden, err := s.hiddenAuthorIDs(ctx, vID)
+	if err != nil {
+		fmt.Printf("Failed to resolve blocked users for %s: %v\n", vID.Hex(), err)
+	}
+
+	// Followed users who are not friends only pass the audience filter with
+	// public posts
+	authors := append([]primitive.ObjectID{vID}, friendIDs...)
+	authors = append(authors, followingIDs...)
+	return withoutBlocked(bson.M{
+		"$and": []bson.M{
+			{"user_id": bson.M{"$in": authors}, "status": models.PostStatusActive},
+			models.PostAudienceFilter(vID, friendIDs),
+		},
+	}, hidden)
 }
 
 // ----------------------------- Reactions -----------------------------
@@ -363,15 +726,19 @@ func (s *FeedService) ReactToPost(ctx context.Context, userID, postID, emoji str
 		Type:       models.ReactionType(emoji), // Casting string to ReactionType
 	}
 
-	createdReaction, err := s.repo.CreateReaction(ctx, reaction)
+	createdReaction, created, err := s.repo.CreateReaction(ctx, reaction)
 	if err != nil {
 		return err
 	}
 
-	// 3. Increment Counter
-	if err := s.repo.IncrementPostReactionCount(ctx, pID); err != nil {
-		return fmt.Errorf("failed to increment reaction counter: %w", err)
+	// 3. Increment Counter, only for a new reaction; a type change keeps the count
+	if created {
+		if err := s.repo.IncrementPostReactionCount(ctx, pID); err != nil {
+			return fmt.Errorf("failed to increment reaction counter: %w", err)
+		}
+		s.recordInsight(ctx, models.InsightPostReaction, post.UserID, uID, pID)
 	}
+	s.recordInteraction(ctx, uID, post.UserID, interactionReaction)
 
 	// 4. Publish Event (ReactionCreated)
 	reactionData, err := json.Marshal(createdReaction)
@@ -388,7 +755,7 @@ func (s *FeedService) ReactToPost(ctx context.Context, userID, postID, emoji str
 		}
 
 		// Use "messages" topic (or "feed-events")
-		if err := s.producer.PublishEvent("messages", wsEvent); err != nil {
+		if err := s.producer.PublishEvent(ctx, "messages", wsEvent); err != nil {
 			fmt.Printf("Failed to publish ReactionCreated: %v\n", err)
 		}
 	}
@@ -419,13 +786,15 @@ func (s *FeedService) ReactToComment(ctx context.Context, userID, commentID, emo
 		Type:       models.ReactionType(emoji),
 	}
 
-	createdReaction, err := s.repo.CreateReaction(ctx, reaction)
+	createdReaction, created, err := s.repo.CreateReaction(ctx, reaction)
 	if err != nil {
 		return err
 	}
 
-	if err := s.repo.IncrementCommentReactionCount(ctx, cID); err != nil {
-		return fmt.Errorf("failed to increment reaction counter: %w", err)
+	if created {
+		if err := s.repo.IncrementCommentReactionCount(ctx, cID); err != nil {
+			return fmt.Errorf("failed to increment reaction counter: %w", err)
+		}
 	}
 
 	reactionData, err := json.Marshal(createdReaction)
@@ -435,7 +804,7 @@ func (s *FeedService) ReactToComment(ctx context.Context, userID, commentID, emo
 			Data:       reactionData,
 			Recipients: []string{comment.UserID.Hex()},
 		}
-		s.producer.PublishEvent("messages", wsEvent)
+		s.producer.PublishEvent(ctx, "messages", wsEvent)
 	}
 	return nil
 }
@@ -462,13 +831,15 @@ func (s *FeedService) ReactToReply(ctx context.Context, userID, replyID, emoji s
 		Type:       models.ReactionType(emoji),
 	}
 
-	createdReaction, err := s.repo.CreateReaction(ctx, reaction)
+	createdReaction, created, err := s.repo.CreateReaction(ctx, reaction)
 	if err != nil {
 		return err
 	}
 
-	if err := s.repo.IncrementReplyReactionCount(ctx, rID); err != nil {
-		return fmt.Errorf("failed to increment reaction counter: %w", err)
+	if created {
+		if err := s.repo.IncrementReplyReactionCount(ctx, rID); err != nil {
+			return fmt.Errorf("failed to increment reaction counter: %w", err)
+		}
 	}
 
 	reactionData, err := json.Marshal(createdReaction)
@@ -478,7 +849,7 @@ func (s *FeedService) ReactToReply(ctx context.Context, userID, replyID, emoji s
 			Data:       reactionData,
 			Recipients: []string{reply.UserID.Hex()},
 		}
-		s.producer.PublishEvent("messages", wsEvent)
+		s.producer.PublishEvent(ctx, "messages", wsEvent)
 	}
 	return nil
 }
@@ -513,6 +884,8 @@ func (s *FeedService) CreateComment(ctx context.Context, userID, postID, content
 	if err != nil {
 		return nil, err
 	}
+	s.recordInteraction(ctx, uID, post.UserID, interactionComment)
+	s.recordInsight(ctx, models.InsightPostComment, post.UserID, uID, pID)
 
 	// 2. Increment Post Comment Count (Optimization: Denormalization)
 	// TODO: Add IncrementPostCommentCount to repo
@@ -526,7 +899,7 @@ func (s *FeedService) CreateComment(ctx context.Context, userID, postID, content
 			Data:       commentData,
 			Recipients: []string{post.UserID.Hex()}, // Notify Post Author
 		}
-		s.producer.PublishEvent("messages", wsEvent)
+		s.producer.PublishEvent(ctx, "messages", wsEvent)
 	}
 
 	return createdComment, nil
@@ -588,7 +961,7 @@ func (s *FeedService) CreateReply(ctx context.Context, userID, commentID, conten
 			// Notify Comment Author
 			Recipients: []string{comment.UserID.Hex()},
 		}
-		s.producer.PublishEvent("messages", wsEvent)
+		s.producer.PublishEvent(ctx, "messages", wsEvent)
 	}
 
 	return createdReply, nil
@@ -651,7 +1024,7 @@ func (s *FeedService) CreateAlbum(ctx context.Context, userID, title, descriptio
 			Data:       albumData,
 			Recipients: recipientIDs,
 		}
-		s.producer.PublishEvent("messages", wsEvent)
+		s.producer.PublishEvent(ctx, "messages", wsEvent)
 	}
 
 	return createdAlbum, nil
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// InsightsRecorder counts interactions toward their owner's creator insights
+type InsightsRecorder interface {
+	Record(ctx context.Context, metric models.InsightMetric, owner, actor, target primitive.ObjectID)
+}
+
+// SetInsights enables counting impressions, reactions and comments toward
+// post authors' insights
+func (s *FeedService) SetInsights(insights InsightsRecorder) {
+	s.insights = insights
+}
+
+// RecordImpressions counts posts shown in viewerID's feed toward their
+// authors' impressions and reach
+func (s *FeedService) RecordImpressions(ctx context.Context, viewerID string, posts []models.Post) {
+	if s.insights == nil {
+		return
+	}
+	vID, err := primitive.ObjectIDFromHex(viewerID)
+	if err != nil {
+		return
+	}
+	for _, post := range posts {
+		s.insights.Record(ctx, models.InsightPostImpression, post.UserID, vID, post.ID)
+	}
+}
+
+func (s *FeedService) recordInsight(ctx context.Context, metric models.InsightMetric, owner, actor, target primitive.ObjectID) {
+	if s.insights != nil {
+		s.insights.Record(ctx, metric, owner, actor, target)
+	}
+}
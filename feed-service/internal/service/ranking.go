@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/feed-service/internal/repository"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/featureflags"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/pkg/pagination"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrUnknownFeedOrder = errors.New("unknown feed order")
+
+const (
+	FeedOrderRanked        = "ranked"
+	FeedOrderChronological = "chronological"
+
+	// RankedFeedFlag turns the ranked feed on for users who did not ask for
+	// an order
+	RankedFeedFlag = "feed.ranked"
+
+	// rankingCandidateWindow is how far back ranked feeds reach; older posts
+	// are only in the chronological feed
+	rankingCandidateWindow = 3 * 24 * time.Hour
+	maxRankingCandidates   = 500
+	// postHalfLife halves a post's score every so often, so a day-old post
+	// needs a far closer author or richer content to beat a fresh one
+	postHalfLife = 12 * time.Hour
+	// affinityHalfLife fades interactions, so last month's chats count for less
+	affinityHalfLife   = 14 * 24 * time.Hour
+	maxAffinityAuthors = 500
+	// activeFeedWindow is how long after a user last read their ranked feed
+	// it keeps being precomputed
+	activeFeedWindow = 24 * time.Hour
+)
+
+// Interaction weights: the more effort, the stronger the tie to the author
+const (
+	interactionReaction = 1
+	interactionComment  = 2
+	interactionShare    = 3
+)
+
+// contentTypeWeights favors posts that tend to draw more engagement
+var contentTypeWeights = map[string]float64{
+	"video": 1.5,
+	"image": 1.3,
+	"poll":  1.2,
+	"share": 0.9,
+	"text":  1.0,
+}
+
+// SetRankedFeeds enables ranked feeds, precomputed into ranked and kept for ttl
+func (s *FeedService) SetRankedFeeds(ranked *repository.RankedFeedRepository, ttl time.Duration) {
+	s.ranked = ranked
+	s.rankedTTL = ttl
+}
+
+// SetFeatureFlags lets the feed.ranked flag pick the order for users who did
+// not ask for one
+func (s *FeedService) SetFeatureFlags(flags *featureflags.Client) {
+	s.flags = flags
+}
+
+// FeedOrder resolves the order of viewerID's feed from the requested one.
+// Without ranked feeds every feed is chronological.
+func (s *FeedService) FeedOrder(viewerID, requested string) (string, error) {
+	switch requested {
+	case FeedOrderRanked, FeedOrderChronological:
+	case "":
+		requested = FeedOrderChronological
+		if s.flags.Enabled(RankedFeedFlag, viewerID) {
+			requested = FeedOrderRanked
+		}
+	default:
+		return "", ErrUnknownFeedOrder
+	}
+	if s.ranked == nil {
+		return FeedOrderChronological, nil
+	}
+	return requested, nil
+}
+
+// ListRankedPosts returns a page of the viewer's ranked feed, computing it
+// when it is not precomputed. A viewer with no recent posts to rank, or whose
+// feed cannot be ranked right now, gets the chronological feed instead.
+func (s *FeedService) ListRankedPosts(ctx context.Context, viewerID string, page, limit int64) ([]models.Post, error) {
+	vID, err := primitive.ObjectIDFromHex(viewerID)
+	if err != nil {
+		return nil, errors.New("invalid viewer ID")
+	}
+	if s.ranked == nil {
+		return s.ListPosts(ctx, viewerID, page, limit)
+	}
+
+	if limit <= 0 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	if err := s.ranked.MarkActive(ctx, viewerID, time.Now()); err != nil {
+		log.Printf("Failed to mark ranked feed of %s active: %v", viewerID, err)
+	}
+
+	ids, found, err := s.ranked.Page(ctx, viewerID, offset, limit)
+	if err != nil {
+		log.Printf("Failed to read ranked feed of %s: %v", viewerID, err)
+		return s.ListPosts(ctx, viewerID, page, limit)
+	}
+	if !found {
+		ranked, err := s.RankFeed(ctx, vID)
+		if err != nil {
+			log.Printf("Failed to rank feed of %s: %v", viewerID, err)
+		}
+		if len(ranked) == 0 {
+			return s.ListPosts(ctx, viewerID, page, limit)
+		}
+		for i := offset; i < offset+limit && i < int64(len(ranked)); i++ {
+			ids = append(ids, ranked[i].PostID)
+		}
+	}
+
+	posts, err := s.hydratePosts(ctx, vID, ids)
+	if err != nil {
+		return s.ListPosts(ctx, viewerID, page, limit)
+	}
+	return posts, nil
+}
+
+// RankFeed scores the recent posts of the viewer's timeline EdgeRank style,
+// stores them as the viewer's ranked feed and returns them best first. A
+// post's score is the viewer's affinity with its author times the weight of
+// its content type, halved every postHalfLife.
+func (s *FeedService) RankFeed(ctx context.Context, vID primitive.ObjectID) ([]repository.RankedPost, error) {
+	now := time.Now()
+	filter := bson.M{"$and": []bson.M{
+		s.timelineFilter(ctx, vID),
+		{"created_at": bson.M{"$gte": now.Add(-rankingCandidateWindow)}},
+	}}
+	candidates, err := s.repo.ListPosts(ctx, filter, options.Find().SetSort(pagination.Sort).SetLimit(maxRankingCandidates))
+	if err != nil {
+		return nil, fmt.Errorf("listing ranking candidates for %s: %w", vID.Hex(), err)
+	}
+
+	affinities := s.affinities(ctx, vID, now)
+	ranked := make([]repository.RankedPost, 0, len(candidates))
+	for i := range candidates {
+		post := &candidates[i]
+		ranked = append(ranked, repository.RankedPost{
+			PostID: post.ID.Hex(),
+			Score:  rankScore(post, affinities[post.UserID.Hex()], now),
+		})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+
+	if err := s.ranked.Store(ctx, vID.Hex(), ranked, s.rankedTTL); err != nil {
+		return nil, fmt.Errorf("storing ranked feed of %s: %w", vID.Hex(), err)
+	}
+	return ranked, nil
+}
+
+// RunRankingWorker recomputes the ranked feed of every recently active user
+// each interval until ctx ends. Replicas take turns, one per interval.
+func (s *FeedService) RunRankingWorker(ctx context.Context, interval time.Duration) {
+	if s.ranked == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			acquired, err := s.ranked.AcquireRefresh(ctx, interval)
+			if err != nil {
+				log.Printf("Error claiming ranked feed refresh: %v", err)
+				continue
+			}
+			if !acquired {
+				continue
+			}
+			users, err := s.ranked.ActiveUsers(ctx, now.Add(-activeFeedWindow))
+			if err != nil {
+				log.Printf("Error listing active ranked feeds: %v", err)
+				continue
+			}
+			for _, userID := range users {
+				vID, err := primitive.ObjectIDFromHex(userID)
+				if err != nil {
+					continue
+				}
+				if _, err := s.RankFeed(ctx, vID); err != nil {
+					log.Printf("Error ranking feed of %s: %v", userID, err)
+				}
+			}
+		}
+	}
+}
+
+// affinities returns the viewer's affinity with each author they interacted
+// with. Ranking goes on without them if the graph is unavailable.
+func (s *FeedService) affinities(ctx context.Context, vID primitive.ObjectID, now time.Time) map[string]float64 {
+	interactions, err := s.graphRepo.GetInteractions(ctx, vID, maxAffinityAuthors)
+	if err != nil {
+		log.Printf("Failed to resolve interactions of %s: %v", vID.Hex(), err)
+		return nil
+	}
+	affinities := make(map[string]float64, len(interactions))
+	for _, in := range interactions {
+		affinities[in.AuthorID] = in.Weight * halfLifeDecay(now.Sub(in.LastAt), affinityHalfLife)
+	}
+	return affinities
+}
+
+// recordInteraction strengthens the tie from userID to authorID. Interactions
+// only tune ranking, so failing to record one just logs.
+func (s *FeedService) recordInteraction(ctx context.Context, userID, authorID primitive.ObjectID, weight float64) {
+	if userID == authorID {
+		return
+	}
+	if err := s.graphRepo.RecordInteraction(ctx, userID.Hex(), authorID.Hex(), weight, affinityHalfLife); err != nil {
+		log.Printf("Failed to record interaction of %s with %s: %v", userID.Hex(), authorID.Hex(), err)
+	}
+}
+
+// rankScore scores post for a viewer with the given affinity to its author.
+// Every author starts at an affinity of 1, so friends the viewer never
+// interacted with still show up.
+func rankScore(post *models.Post, affinity float64, now time.Time) float64 {
+	return (1 + affinity) * contentTypeWeights[contentType(post)] * halfLifeDecay(now.Sub(post.CreatedAt), postHalfLife)
+}
+
+// contentType classifies a post by its richest content
+func contentType(post *models.Post) string {
+	if slices.ContainsFunc(post.Media, func(m models.MediaItem) bool { return m.Type == "video" }) {
+		return "video"
+	}
+	switch {
+	case len(post.Media) > 0:
+		return "image"
+	case len(post.PollOptions) > 0:
+		return "poll"
+	case post.SharedFrom != nil:
+		return "share"
+	}
+	return "text"
+}
+
+func halfLifeDecay(age, halfLife time.Duration) float64 {
+	if age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, age.Hours()/halfLife.Hours())
+}
+
+// hydratePosts loads the posts with the given IDs in order, from the cache
+// where possible, skipping posts since deleted and those whose author the
+// viewer must not see
+func (s *FeedService) hydratePosts(ctx context.Context, vID primitive.ObjectID, ids []string) ([]models.Post, error) {
+	cached, missingIDs, err := s.cacheRepo.GetPosts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*models.Post, len(ids))
+	for _, p := range cached {
+		byID[p.ID.Hex()] = p
+	}
+	for _, mid := range missingIDs {
+		if oid, err := primitive.ObjectIDFromHex(mid); err == nil {
+			p, err := s.repo.GetPostByID(ctx, oid)
+			if err == nil {
+				byID[mid] = p
+				_ = s.cacheRepo.SetPost(ctx, p)
+			}
+		}
+	}
+
+	// Posts stored before a block or shadow ban are still listed, so drop
+	// them here
+	hidden, err := s.hiddenAuthorIDs(ctx, vID)
+	if err != nil {
+		fmt.Printf("Failed to resolve blocked users for %s: %v\n", vID.Hex(), err)
+	}
+
+	posts := make([]models.Post, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		p, ok := byID[id]
+		if !ok || p == nil || slices.Contains(hidden, p.UserID) {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		posts = append(posts, *p)
+	}
+	return posts, nil
+}
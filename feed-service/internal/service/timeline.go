@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/MuhibNayem/connectify-v2/feed-service/internal/repository"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/pkg/pagination"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RebuildTimeline refills the user's Redis timeline from Mongo, e.g. after
+// the cache was lost, and returns how many posts it now holds. Posts of
+// celebrity friends are left out, as fan-out leaves them out.
+func (s *FeedService) RebuildTimeline(ctx context.Context, userID string) (int, error) {
+	vID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, errors.New("invalid user ID")
+	}
+
+	filter := s.timelineFilter(ctx, vID)
+	if celebrities := s.celebrityFriendIDs(ctx, vID); len(celebrities) > 0 {
+		filter = bson.M{"$and": []bson.M{filter, {"user_id": bson.M{"$nin": celebrities}}}}
+	}
+	ids, err := s.repo.ListPostIDs(ctx, filter, repository.TimelineMaxLen)
+	if err != nil {
+		return 0, fmt.Errorf("listing timeline posts of %s: %w", userID, err)
+	}
+
+	postIDs := make([]string, len(ids))
+	for i, id := range ids {
+		postIDs[i] = id.Hex()
+	}
+	if err := s.cacheRepo.ReplaceTimeline(ctx, userID, postIDs); err != nil {
+		return 0, fmt.Errorf("storing timeline of %s: %w", userID, err)
+	}
+	return len(postIDs), nil
+}
+
+// RebuildAllTimelines rebuilds the timeline of every known user, for when the
+// whole cache was lost. Users whose timeline fails to rebuild are logged and
+// counted; they are rebuilt on their next read anyway.
+func (s *FeedService) RebuildAllTimelines(ctx context.Context) (rebuilt, failed int, err error) {
+	err = s.repo.ForEachUserID(ctx, func(userID primitive.ObjectID) error {
+		if _, err := s.RebuildTimeline(ctx, userID.Hex()); err != nil {
+			log.Printf("Failed to rebuild timeline of %s: %v", userID.Hex(), err)
+			failed++
+			return ctx.Err()
+		}
+		rebuilt++
+		return nil
+	})
+	return rebuilt, failed, err
+}
+
+// celebrityFriendIDs returns the viewer's friends and followed users whose
+// posts skip fan-out. They are cached per viewer until their friends,
+// follows or the celebrities change. Failing to resolve them only logs,
+// leaving their posts out of the timeline.
+func (s *FeedService) celebrityFriendIDs(ctx context.Context, vID primitive.ObjectID) []primitive.ObjectID {
+	cached, version, err := s.cacheRepo.GetCelebrityFollowees(ctx, vID.Hex())
+	if err != nil {
+		fmt.Printf("Failed to read cached celebrity friends of %s: %v\n", vID.Hex(), err)
+	}
+	var ids []string
+	if cached != nil {
+		ids = cached.IDs
+	} else {
+		if ids, err = s.resolveCelebrityFriendIDs(ctx, vID); err != nil {
+			fmt.Printf("Failed to resolve celebrity friends of %s: %v\n", vID.Hex(), err)
+			return nil
+		}
+		followees := repository.CelebrityFollowees{Version: version, IDs: ids}
+		if err := s.cacheRepo.SetCelebrityFollowees(ctx, vID.Hex(), followees); err != nil {
+			fmt.Printf("Failed to cache celebrity friends of %s: %v\n", vID.Hex(), err)
+		}
+	}
+
+	celebrities := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			celebrities = append(celebrities, oid)
+		}
+	}
+	return celebrities
+}
+
+// resolveCelebrityFriendIDs reads the viewer's friends and followed users
+// from the graph and keeps the celebrities among them
+func (s *FeedService) resolveCelebrityFriendIDs(ctx context.Context, vID primitive.ObjectID) ([]string, error) {
+	friendIDs, err := s.graphRepo.GetFriendIDs(ctx, vID)
+	if err != nil {
+		return nil, fmt.Errorf("resolving friend graph: %w", err)
+	}
+	followingIDs, err := s.graphRepo.GetFollowingIDs(ctx, vID)
+	if err != nil {
+		fmt.Printf("Failed to resolve followed users for %s: %v\n", vID.Hex(), err)
+	}
+	ids, err := s.cacheRepo.FilterCelebrities(ctx, append(friendIDs, followingIDs...))
+	if err != nil {
+		return nil, fmt.Errorf("filtering celebrities: %w", err)
+	}
+	return ids, nil
+}
+
+// celebrityPosts returns the newest posts of the given celebrities after
+// cursor (nil for the first page) that the viewer may see, read from Mongo
+// since they were never fanned out
+func (s *FeedService) celebrityPosts(ctx context.Context, vID primitive.ObjectID, celebrities []primitive.ObjectID, cursor *pagination.Cursor, limit int64) ([]models.Post, error) {
+	filter := bson.M{"$and": []bson.M{
+		s.timelineFilter(ctx, vID),
+		{"user_id": bson.M{"$in": celebrities}},
+	}}
+	posts, _, err := s.repo.ListPostsAfter(ctx, filter, cursor, limit)
+	return posts, err
+}
+
+// mergePosts appends the posts of extra not already in posts
+func mergePosts(posts, extra []models.Post) []models.Post {
+	seen := make(map[primitive.ObjectID]struct{}, len(posts))
+	for _, p := range posts {
+		seen[p.ID] = struct{}{}
+	}
+	for _, p := range extra {
+		if _, ok := seen[p.ID]; !ok {
+			posts = append(posts, p)
+		}
+	}
+	return posts
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/MuhibNayem/connectify-v2/feed-service/internal/repository"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+)
+
+var ErrUnknownTrendingWindow = errors.New("unknown trending window")
+
+const (
+	DefaultTrendingWindow = "24h"
+
+	defaultTrendingLimit = 10
+	maxTrendingLimit     = 50
+	// maxTagsPerPost stops one post stuffed with hashtags from swamping the list
+	maxTagsPerPost = 10
+)
+
+// trendingHalfLives maps each window to the half-life of its scores. A quarter
+// of the window keeps a tag used steadily over the whole window ahead of one
+// that spiked once near its start.
+var trendingHalfLives = map[string]time.Duration{
+	"1h":  15 * time.Minute,
+	"24h": 6 * time.Hour,
+	"7d":  42 * time.Hour,
+}
+
+// TrendingService ranks hashtags by recent use, worldwide and per region
+type TrendingService struct {
+	repo *repository.TrendingRepository
+}
+
+func NewTrendingService(repo *repository.TrendingRepository) *TrendingService {
+	return &TrendingService{repo: repo}
+}
+
+// RecordPost counts the hashtags of a newly created post. Only active public
+// posts count, so trends never reveal what was shared with a limited audience.
+func (s *TrendingService) RecordPost(ctx context.Context, post *models.Post) error {
+	if post.Privacy != models.PrivacySettingPublic || (post.Status != "" && post.Status != models.PostStatusActive) {
+		return nil
+	}
+	tags := postHashtags(post)
+	if len(tags) == 0 {
+		return nil
+	}
+
+	first, err := s.repo.MarkCounted(ctx, post.ID.Hex())
+	if err != nil {
+		return fmt.Errorf("marking post %s counted: %w", post.ID.Hex(), err)
+	}
+	if !first {
+		return nil
+	}
+
+	windows := make([]string, 0, len(trendingHalfLives))
+	for window := range trendingHalfLives {
+		windows = append(windows, window)
+	}
+	if err := s.repo.Record(ctx, windows, NormalizeRegion(regionOf(post.Location)), tags); err != nil {
+		if unmarkErr := s.repo.UnmarkCounted(ctx, post.ID.Hex()); unmarkErr != nil {
+			log.Printf("Error unmarking post %s for trending: %v", post.ID.Hex(), unmarkErr)
+		}
+		return fmt.Errorf("recording hashtags of post %s: %w", post.ID.Hex(), err)
+	}
+	return nil
+}
+
+// Trending returns the top hashtags of window, worldwide when region is empty.
+// An empty window means DefaultTrendingWindow; the window used is returned.
+func (s *TrendingService) Trending(ctx context.Context, region, window string, limit int) ([]models.TrendingHashtag, string, error) {
+	if window == "" {
+		window = DefaultTrendingWindow
+	}
+	if _, ok := trendingHalfLives[window]; !ok {
+		return nil, window, ErrUnknownTrendingWindow
+	}
+	if limit <= 0 {
+		limit = defaultTrendingLimit
+	}
+	limit = min(limit, maxTrendingLimit)
+
+	tags, err := s.repo.Top(ctx, window, NormalizeRegion(region), int64(limit))
+	if err != nil {
+		return nil, window, err
+	}
+	return tags, window, nil
+}
+
+// RunDecayWorker ages every window's scores each interval until ctx ends
+func (s *TrendingService) RunDecayWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for window, halfLife := range trendingHalfLives {
+				if err := s.repo.Decay(ctx, window, halfLife, now); err != nil {
+					log.Printf("Error decaying %s trending hashtags: %v", window, err)
+				}
+			}
+		}
+	}
+}
+
+func postHashtags(post *models.Post) []string {
+	raw := post.Hashtags
+	if len(raw) == 0 {
+		raw = utils.ExtractHashtags(post.Content)
+	}
+	seen := make(map[string]struct{}, len(raw))
+	tags := make([]string, 0, len(raw))
+	for _, tag := range raw {
+		tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+		if len(tags) == maxTagsPerPost {
+			break
+		}
+	}
+	return tags
+}
+
+// regionOf takes the broadest part of a free-form location, so "Dhaka,
+// Bangladesh" counts towards Bangladesh
+func regionOf(location string) string {
+	parts := strings.Split(location, ",")
+	return parts[len(parts)-1]
+}
+
+// NormalizeRegion lowercases a region name and turns anything other than
+// letters and digits into dashes, so "United States" and "united-states" match
+func NormalizeRegion(region string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(region)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -245,7 +245,7 @@ export async function apiRequest(
 
 		if (!response.ok) {
 			const errorData = await response.json();
-			throw new Error(errorData.error || 'Something went wrong');
+			throw new Error(errorData.error?.message || errorData.error || 'Something went wrong');
 		}
 
 		// For requests that don't return a body (e.g., 204 No Content)
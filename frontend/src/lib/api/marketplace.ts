@@ -1,7 +1,7 @@
 import { apiRequest } from '$lib/api';
 import type { UserShortResponse } from '$lib/api'; // Reuse generic types
 
-export type ProductStatus = 'available' | 'sold' | 'pending' | 'archived';
+export type ProductStatus = 'available' | 'reserved' | 'sold' | 'pending' | 'archived';
 
 export interface Category {
     id: string;
@@ -24,6 +24,8 @@ export interface Product {
     status: ProductStatus;
     tags?: string[];
     views: number;
+    buyer_id?: string; // Who the listing is reserved for or was sold to
+    sold_at?: string;
     created_at: string;
     updated_at: string;
     seller?: UserShortResponse; // Populated seller info
@@ -100,7 +100,7 @@ export const auth = {
 
         if (!response.ok) {
             const errorData = await response.json();
-            throw new Error(errorData.error || 'Login failed');
+            throw new Error(errorData.error?.message || errorData.error || 'Login failed');
         }
 
         const data = await response.json();
@@ -121,7 +121,7 @@ export const auth = {
 
         if (!response.ok) {
             const errorData = await response.json();
-            throw new Error(errorData.error || 'Registration failed');
+            throw new Error(errorData.error?.message || errorData.error || 'Registration failed');
         }
 
         const data = await response.json();
@@ -36,6 +36,20 @@ export interface Message {
 		images?: string[];
 		status: string;
 	};
+	// Offer card posted when a marketplace offer is made, countered or settled
+	offer?: {
+		offer_id: string;
+		product_id: string;
+		product_title: string;
+		product_image?: string;
+		action: 'made' | 'countered' | 'accepted' | 'declined' | 'expired';
+		amount: number;
+		currency: string;
+		status: string;
+		message?: string;
+		expires_at: string;
+		round: number;
+	};
 	created_at: string;
 	updated_at?: string;
 	// E2EE
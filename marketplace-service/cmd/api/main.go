@@ -15,9 +15,14 @@ import (
 	grpcserver "github.com/MuhibNayem/connectify-v2/marketplace-service/internal/grpc"
 	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/httpapi"
 	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/platform"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
+	sharedkafka "github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
+	exportpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/export/v1"
 	marketplacepb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/marketplace/v1"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/serviceauth"
 	"github.com/joho/godotenv"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
@@ -54,10 +59,24 @@ func run() error {
 	}
 
 	// Create gRPC server
+	callers := serviceauth.NewVerifier("marketplace-service", cfg.ServiceAuthSecret, serviceauth.ParseMode(cfg.ServiceAuthMode), serviceauth.Allowlist{
+		serviceauth.AllMethods(marketplacepb.MarketplaceService_ServiceDesc): {"messaging-app", "api-gateway"},
+		serviceauth.AllMethods(exportpb.DataExportService_ServiceDesc):       {"user-service"},
+	}, slog.Default())
 	grpcSrv := grpc.NewServer(
 		observability.GetGRPCServerOption(),
+		callers.UnaryServerOption(),
+		callers.StreamServerOption(),
 	)
 	marketplacepb.RegisterMarketplaceServiceServer(grpcSrv, grpcserver.NewServer(deps.MarketplaceService))
+	dataexport.NewServer(models.ErasureServiceMarketplace, deps.MarketplaceService.ExportUserData).Register(grpcSrv)
+
+	// Erase marketplace data of deleted accounts
+	deletionCtx, stopDeletions := context.WithCancel(context.Background())
+	defer stopDeletions()
+	deletionParticipant := sharedkafka.NewUserDeletionParticipant(cfg.KafkaBrokers, models.ErasureServiceMarketplace, deps.MarketplaceService.DeleteUserData)
+	defer deletionParticipant.Close()
+	go deletionParticipant.Run(deletionCtx)
 
 	// Setup metrics server
 	metricsServer := &http.Server{
@@ -115,6 +134,12 @@ func run() error {
 	}
 
 	grpcSrv.GracefulStop()
+	if err := deps.SearchIndexer.Close(); err != nil {
+		slog.Error("Search indexer close error", "error", err)
+	}
+	if err := deps.Notifications.Close(); err != nil {
+		slog.Error("Notification producer close error", "error", err)
+	}
 	if redisClient != nil {
 		if err := redisClient.Close(); err != nil {
 			slog.Error("Redis close error", "error", err)
@@ -4,6 +4,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -13,11 +14,20 @@ type Config struct {
 	CassandraHosts []string
 	KafkaBrokers   []string
 
+	// NotificationTopic is where price-drop alerts go for delivery
+	NotificationTopic string
+
 	GRPCPort    string
 	ServerPort  string
 	MetricsPort string
 
-	JWTSecret string
+	JWTSecret        string
+	JWKSURL          string    // Key set access tokens are signed by, published by user-service
+	LegacyHS256Until time.Time // Until then JWTSecret tokens are still accepted alongside
+
+	// Service-to-service authentication; mode is enforce, permissive or disabled
+	ServiceAuthSecret string
+	ServiceAuthMode   string
 
 	RedisURLs          []string
 	RedisPass          string
@@ -47,14 +57,21 @@ func LoadConfig() *Config {
 		corsOrigins[i] = strings.TrimSpace(corsOrigins[i])
 	}
 
+	legacyHS256Until, _ := time.Parse(time.RFC3339, getEnv("JWT_LEGACY_HS256_UNTIL", ""))
+
 	return &Config{
 		MongoURI:           mongoURI,
 		CassandraHosts:     []string{getEnv("CASSANDRA_HOSTS", "localhost:9042")},
 		KafkaBrokers:       strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
+		NotificationTopic:  getEnv("NOTIFICATION_TOPIC", "notifications_events"),
 		GRPCPort:           grpcPort,
 		ServerPort:         serverPort,
 		MetricsPort:        metricsPort,
 		JWTSecret:          getEnv("JWT_SECRET", "very-secret-key"),
+		JWKSURL:            getEnv("JWKS_URL", ""),
+		LegacyHS256Until:   legacyHS256Until,
+		ServiceAuthSecret:  getEnv("SERVICE_AUTH_SECRET", ""),
+		ServiceAuthMode:    getEnv("SERVICE_AUTH_MODE", "enforce"),
 		RedisURLs:          strings.Split(getEnv("REDIS_URL", "localhost:6379"), ","),
 		RedisPass:          getEnv("REDIS_PASS", ""),
 		RateLimitEnabled:   rateLimitEnabled,
@@ -15,7 +15,26 @@ type MarketplaceService interface {
 	GetProductByID(ctx context.Context, id primitive.ObjectID, viewerID primitive.ObjectID) (*models.ProductResponse, error)
 	SearchProducts(ctx context.Context, filter models.ProductFilter) (*service.MarketplaceListResponse, error)
 	GetMarketplaceConversations(ctx context.Context, userID primitive.ObjectID) ([]models.ConversationSummary, error)
-	MarkProductSold(ctx context.Context, productID, userID primitive.ObjectID) error
+	MarkProductSold(ctx context.Context, productID, sellerID primitive.ObjectID, req models.MarkProductSoldRequest) (*models.Product, error)
+	ReserveProduct(ctx context.Context, productID, sellerID primitive.ObjectID, req models.ReserveProductRequest) (*models.Product, error)
+	RelistProduct(ctx context.Context, productID, sellerID primitive.ObjectID) (*models.Product, error)
+	GetSoldListings(ctx context.Context, sellerID primitive.ObjectID, page, limit int64) (*service.MarketplaceListResponse, error)
 	DeleteProduct(ctx context.Context, productID, userID primitive.ObjectID) error
 	ToggleSaveProduct(ctx context.Context, productID, userID primitive.ObjectID) (bool, error)
+	UpdateProduct(ctx context.Context, productID, userID primitive.ObjectID, req models.UpdateProductRequest) (*models.Product, error)
+	SaveListing(ctx context.Context, productID, userID primitive.ObjectID) error
+	UnsaveListing(ctx context.Context, productID, userID primitive.ObjectID) error
+	GetSavedListings(ctx context.Context, userID primitive.ObjectID, page, limit int64) (*service.MarketplaceListResponse, error)
+	GetAlertPreferences(ctx context.Context, userID primitive.ObjectID) (*models.MarketplaceAlertPreferences, error)
+	UpdateAlertPreferences(ctx context.Context, userID primitive.ObjectID, req models.UpdateMarketplaceAlertPreferencesRequest) (*models.MarketplaceAlertPreferences, error)
+	SearchListings(ctx context.Context, filter models.ProductFilter) (*service.MarketplaceSearchResponse, error)
+	SaveSearch(ctx context.Context, userID primitive.ObjectID, req models.SaveProductSearchRequest) (*models.SavedProductSearch, error)
+	GetSavedSearches(ctx context.Context, userID primitive.ObjectID) ([]models.SavedProductSearch, error)
+	DeleteSavedSearch(ctx context.Context, searchID, userID primitive.ObjectID) error
+	MakeOffer(ctx context.Context, productID, buyerID primitive.ObjectID, req models.MakeOfferRequest) (*models.Offer, error)
+	CounterOffer(ctx context.Context, offerID, userID primitive.ObjectID, req models.CounterOfferRequest) (*models.Offer, error)
+	AcceptOffer(ctx context.Context, offerID, userID primitive.ObjectID) (*models.Offer, error)
+	DeclineOffer(ctx context.Context, offerID, userID primitive.ObjectID) (*models.Offer, error)
+	GetOffer(ctx context.Context, offerID, userID primitive.ObjectID) (*models.Offer, error)
+	GetOffers(ctx context.Context, userID primitive.ObjectID, productID *primitive.ObjectID) ([]models.Offer, error)
 }
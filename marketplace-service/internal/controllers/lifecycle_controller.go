@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func respondWithLifecycleError(ctx *gin.Context, err error, forbidden, fallback string) {
+	switch {
+	case errors.Is(err, validation.ErrInvalidBuyer):
+		RespondWithError(ctx, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+	case errors.Is(err, service.ErrInvalidTransition):
+		RespondWithError(ctx, http.StatusConflict, err.Error(), ErrCodeInvalidTransition)
+	case err.Error() == "product not found":
+		RespondWithError(ctx, http.StatusNotFound, "Product not found", ErrCodeProductNotFound)
+	case err.Error() == "unauthorized":
+		RespondWithError(ctx, http.StatusForbidden, forbidden, ErrCodeInsufficientPerms)
+	default:
+		RespondWithError(ctx, http.StatusInternalServerError, fallback, ErrCodeInternalError)
+	}
+}
+
+func (c *MarketplaceController) ReserveProduct(ctx *gin.Context) {
+	userObjectID, ok := authenticatedUserID(ctx)
+	if !ok {
+		return
+	}
+
+	productID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid product ID format", ErrCodeInvalidProductID)
+		return
+	}
+
+	var req models.ReserveProductRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid request format", ErrCodeValidation)
+		return
+	}
+
+	product, err := c.service.ReserveProduct(ctx.Request.Context(), productID, userObjectID, req)
+	if err != nil {
+		respondWithLifecycleError(ctx, err, "You can only reserve your own products", "Failed to reserve product")
+		return
+	}
+
+	RespondWithSuccess(ctx, http.StatusOK, "Product reserved", product)
+}
+
+func (c *MarketplaceController) RelistProduct(ctx *gin.Context) {
+	userObjectID, ok := authenticatedUserID(ctx)
+	if !ok {
+		return
+	}
+
+	productID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid product ID format", ErrCodeInvalidProductID)
+		return
+	}
+
+	product, err := c.service.RelistProduct(ctx.Request.Context(), productID, userObjectID)
+	if err != nil {
+		respondWithLifecycleError(ctx, err, "You can only relist your own products", "Failed to relist product")
+		return
+	}
+
+	RespondWithSuccess(ctx, http.StatusOK, "Product relisted", product)
+}
+
+// GetSoldListings is a seller's public archive of sold items
+func (c *MarketplaceController) GetSoldListings(ctx *gin.Context) {
+	sellerID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid seller ID", ErrCodeValidation)
+		return
+	}
+
+	page, _ := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+
+	listings, err := c.service.GetSoldListings(ctx.Request.Context(), sellerID, page, limit)
+	if err != nil {
+		RespondWithError(ctx, http.StatusInternalServerError, "Failed to fetch sold listings", ErrCodeInternalError)
+		return
+	}
+
+	RespondWithData(ctx, http.StatusOK, listings)
+}
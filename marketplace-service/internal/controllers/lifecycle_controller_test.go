@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newLifecycleRouter(controller *MarketplaceController, userID primitive.ObjectID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	// Mock authentication middleware
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", userID.Hex())
+		c.Next()
+	})
+
+	router.PUT("/products/:id/sold", controller.MarkProductSold)
+	router.POST("/products/:id/reserve", controller.ReserveProduct)
+	router.POST("/products/:id/relist", controller.RelistProduct)
+	router.GET("/sellers/:id/sold", controller.GetSoldListings)
+	return router
+}
+
+func TestMarketplaceController_MarkProductSold_WithBuyer(t *testing.T) {
+	mockService := new(MockMarketplaceService)
+	userID := primitive.NewObjectID()
+	productID := primitive.NewObjectID()
+
+	req := models.MarkProductSoldRequest{BuyerID: primitive.NewObjectID().Hex()}
+	mockService.On("MarkProductSold", mock.Anything, productID, userID, req).Return(&models.Product{ID: productID, Status: models.ProductStatusSold}, nil)
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq := httptest.NewRequest("PUT", "/products/"+productID.Hex()+"/sold", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	newLifecycleRouter(NewMarketplaceController(mockService), userID).ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestMarketplaceController_ReserveProduct_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "invalid buyer", err: validation.ErrInvalidBuyer, wantStatus: http.StatusBadRequest},
+		{name: "already sold", err: service.ErrInvalidTransition, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMarketplaceService)
+			userID := primitive.NewObjectID()
+			productID := primitive.NewObjectID()
+
+			req := models.ReserveProductRequest{BuyerID: primitive.NewObjectID().Hex()}
+			mockService.On("ReserveProduct", mock.Anything, productID, userID, req).Return(nil, tt.err)
+
+			body, _ := json.Marshal(req)
+			w := httptest.NewRecorder()
+			httpReq := httptest.NewRequest("POST", "/products/"+productID.Hex()+"/reserve", bytes.NewBuffer(body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			newLifecycleRouter(NewMarketplaceController(mockService), userID).ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestMarketplaceController_GetSoldListings(t *testing.T) {
+	mockService := new(MockMarketplaceService)
+	sellerID := primitive.NewObjectID()
+
+	list := &service.MarketplaceListResponse{Products: []models.ProductResponse{{Title: "Road bike", Status: models.ProductStatusSold}}, Total: 1, Page: 1, Limit: 20}
+	mockService.On("GetSoldListings", mock.Anything, sellerID, int64(1), int64(20)).Return(list, nil)
+
+	w := httptest.NewRecorder()
+	newLifecycleRouter(NewMarketplaceController(mockService), primitive.NewObjectID()).
+		ServeHTTP(w, httptest.NewRequest("GET", "/sellers/"+sellerID.Hex()+"/sold", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
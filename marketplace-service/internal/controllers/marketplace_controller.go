@@ -1,9 +1,12 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -88,11 +91,11 @@ func (c *MarketplaceController) GetProduct(ctx *gin.Context) {
 	RespondWithData(ctx, http.StatusOK, product)
 }
 
-func (c *MarketplaceController) SearchProducts(ctx *gin.Context) {
+func bindProductFilter(ctx *gin.Context) (models.ProductFilter, bool) {
 	var filter models.ProductFilter
 	if err := ctx.ShouldBindQuery(&filter); err != nil {
 		RespondWithError(ctx, http.StatusBadRequest, "Invalid search parameters", ErrCodeValidation)
-		return
+		return filter, false
 	}
 
 	if filter.Page < 1 {
@@ -101,16 +104,139 @@ func (c *MarketplaceController) SearchProducts(ctx *gin.Context) {
 	if filter.Limit < 1 {
 		filter.Limit = 20
 	}
+	return filter, true
+}
+
+func respondWithSearchError(ctx *gin.Context, err error) {
+	if errors.Is(err, validation.ErrInvalidSearch) {
+		RespondWithError(ctx, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+	RespondWithError(ctx, http.StatusInternalServerError, "Search failed", ErrCodeInternalError)
+}
+
+func (c *MarketplaceController) SearchProducts(ctx *gin.Context) {
+	filter, ok := bindProductFilter(ctx)
+	if !ok {
+		return
+	}
 
 	resp, err := c.service.SearchProducts(ctx.Request.Context(), filter)
 	if err != nil {
-		RespondWithError(ctx, http.StatusInternalServerError, "Search failed", ErrCodeInternalError)
+		respondWithSearchError(ctx, err)
 		return
 	}
 
 	RespondWithData(ctx, http.StatusOK, resp)
 }
 
+// SearchListings searches listings and returns facets for refining the search
+func (c *MarketplaceController) SearchListings(ctx *gin.Context) {
+	filter, ok := bindProductFilter(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := c.service.SearchListings(ctx.Request.Context(), filter)
+	if err != nil {
+		respondWithSearchError(ctx, err)
+		return
+	}
+
+	RespondWithData(ctx, http.StatusOK, resp)
+}
+
+func (c *MarketplaceController) SaveSearch(ctx *gin.Context) {
+	userIDStr, ok := ExtractUserID(ctx)
+	if !ok {
+		RespondWithError(ctx, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	userObjectID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		RespondWithError(ctx, http.StatusUnauthorized, "Invalid user authentication", ErrCodeUnauthorized)
+		return
+	}
+
+	var req models.SaveProductSearchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid request format", ErrCodeValidation)
+		return
+	}
+
+	search, err := c.service.SaveSearch(ctx.Request.Context(), userObjectID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, validation.ErrInvalidSearch):
+			RespondWithError(ctx, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		case errors.Is(err, service.ErrTooManySavedSearches):
+			RespondWithError(ctx, http.StatusConflict, err.Error(), ErrCodeSearchLimit)
+		default:
+			RespondWithError(ctx, http.StatusInternalServerError, "Failed to save search", ErrCodeInternalError)
+		}
+		return
+	}
+	RespondWithSuccess(ctx, http.StatusCreated, "Search saved successfully", search)
+}
+
+func (c *MarketplaceController) GetSavedSearches(ctx *gin.Context) {
+	userIDStr, ok := ExtractUserID(ctx)
+	if !ok {
+		RespondWithError(ctx, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	userObjectID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		RespondWithError(ctx, http.StatusUnauthorized, "Invalid user authentication", ErrCodeUnauthorized)
+		return
+	}
+
+	searches, err := c.service.GetSavedSearches(ctx.Request.Context(), userObjectID)
+	if err != nil {
+		RespondWithError(ctx, http.StatusInternalServerError, "Failed to fetch saved searches", ErrCodeInternalError)
+		return
+	}
+
+	if searches == nil {
+		searches = []models.SavedProductSearch{}
+	}
+
+	RespondWithData(ctx, http.StatusOK, searches)
+}
+
+func (c *MarketplaceController) DeleteSavedSearch(ctx *gin.Context) {
+	userIDStr, ok := ExtractUserID(ctx)
+	if !ok {
+		RespondWithError(ctx, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	userObjectID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		RespondWithError(ctx, http.StatusUnauthorized, "Invalid user authentication", ErrCodeUnauthorized)
+		return
+	}
+
+	searchID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid saved search ID", ErrCodeValidation)
+		return
+	}
+
+	if err := c.service.DeleteSavedSearch(ctx.Request.Context(), searchID, userObjectID); err != nil {
+		if errors.Is(err, service.ErrSavedSearchNotFound) {
+			RespondWithError(ctx, http.StatusNotFound, err.Error(), ErrCodeSearchNotFound)
+			return
+		}
+		RespondWithError(ctx, http.StatusInternalServerError, "Failed to delete saved search", ErrCodeInternalError)
+		return
+	}
+
+	RespondWithSuccess(ctx, http.StatusOK, "Saved search deleted successfully")
+}
+
 func (c *MarketplaceController) GetMarketplaceConversations(ctx *gin.Context) {
 	userIDStr, ok := ExtractUserID(ctx)
 	if !ok {
@@ -141,42 +267,48 @@ func (c *MarketplaceController) MarkProductSold(ctx *gin.Context) {
 	productIDStr := ctx.Param("id")
 	productID, err := primitive.ObjectIDFromHex(productIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
 	userID, _ := ctx.Get("userID")
 	userIDStr, ok := userID.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type in context"})
+		RespondWithError(ctx, http.StatusInternalServerError, "Invalid user ID type in context")
 		return
 	}
 	userObjectID, _ := primitive.ObjectIDFromHex(userIDStr)
 
-	if err := c.service.MarkProductSold(ctx.Request.Context(), productID, userObjectID); err != nil {
-		if err.Error() == "unauthorized" {
-			RespondWithError(ctx, http.StatusForbidden, "You can only mark your own products as sold", ErrCodeInsufficientPerms)
+	// The body is optional; without a buyer the reserved buyer is used
+	var req models.MarkProductSoldRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			RespondWithError(ctx, http.StatusBadRequest, "Invalid request format", ErrCodeValidation)
 			return
 		}
-		RespondWithError(ctx, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+	}
+
+	product, err := c.service.MarkProductSold(ctx.Request.Context(), productID, userObjectID, req)
+	if err != nil {
+		respondWithLifecycleError(ctx, err, "You can only mark your own products as sold", "Failed to mark product as sold")
 		return
 	}
 
-	RespondWithSuccess(ctx, http.StatusOK, "Product marked as sold")
+	RespondWithSuccess(ctx, http.StatusOK, "Product marked as sold", product)
 }
 
 func (c *MarketplaceController) DeleteProduct(ctx *gin.Context) {
 	productIDStr := ctx.Param("id")
 	productID, err := primitive.ObjectIDFromHex(productIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
 	userID, _ := ctx.Get("userID")
 	userIDStr, ok := userID.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type in context"})
+		RespondWithError(ctx, http.StatusInternalServerError, "Invalid user ID type in context")
 		return
 	}
 	userObjectID, _ := primitive.ObjectIDFromHex(userIDStr)
@@ -197,14 +329,14 @@ func (c *MarketplaceController) ToggleSaveProduct(ctx *gin.Context) {
 	productIDStr := ctx.Param("id")
 	productID, err := primitive.ObjectIDFromHex(productIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
 	userID, _ := ctx.Get("userID")
 	userIDStr, ok := userID.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type in context"})
+		RespondWithError(ctx, http.StatusInternalServerError, "Invalid user ID type in context")
 		return
 	}
 	userObjectID, _ := primitive.ObjectIDFromHex(userIDStr)
@@ -223,5 +355,39 @@ func (c *MarketplaceController) ToggleSaveProduct(ctx *gin.Context) {
 }
 
 func (c *MarketplaceController) UpdateProduct(ctx *gin.Context) {
-	ctx.JSON(http.StatusNotImplemented, gin.H{"error": "Not implemented yet"})
+	userObjectID, ok := authenticatedUserID(ctx)
+	if !ok {
+		return
+	}
+
+	productID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid product ID format", ErrCodeInvalidProductID)
+		return
+	}
+
+	var req models.UpdateProductRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid request format", ErrCodeValidation)
+		return
+	}
+
+	product, err := c.service.UpdateProduct(ctx.Request.Context(), productID, userObjectID, req)
+	if err != nil {
+		switch {
+		case validation.IsProductError(err):
+			RespondWithError(ctx, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		case errors.Is(err, service.ErrProductSold):
+			RespondWithError(ctx, http.StatusConflict, err.Error(), ErrCodeValidation)
+		case err.Error() == "product not found":
+			RespondWithError(ctx, http.StatusNotFound, "Product not found", ErrCodeProductNotFound)
+		case err.Error() == "unauthorized":
+			RespondWithError(ctx, http.StatusForbidden, "You can only update your own products", ErrCodeInsufficientPerms)
+		default:
+			RespondWithError(ctx, http.StatusInternalServerError, "Failed to update product", ErrCodeInternalError)
+		}
+		return
+	}
+
+	RespondWithData(ctx, http.StatusOK, product)
 }
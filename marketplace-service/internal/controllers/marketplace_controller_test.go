@@ -11,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -50,9 +51,36 @@ func (m *MockMarketplaceService) SearchProducts(ctx context.Context, filter mode
 	return args.Get(0).(*service.MarketplaceListResponse), args.Error(1)
 }
 
-func (m *MockMarketplaceService) MarkProductSold(ctx context.Context, productID, userID primitive.ObjectID) error {
-	args := m.Called(ctx, productID, userID)
-	return args.Error(0)
+func (m *MockMarketplaceService) MarkProductSold(ctx context.Context, productID, sellerID primitive.ObjectID, req models.MarkProductSoldRequest) (*models.Product, error) {
+	args := m.Called(ctx, productID, sellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockMarketplaceService) ReserveProduct(ctx context.Context, productID, sellerID primitive.ObjectID, req models.ReserveProductRequest) (*models.Product, error) {
+	args := m.Called(ctx, productID, sellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockMarketplaceService) RelistProduct(ctx context.Context, productID, sellerID primitive.ObjectID) (*models.Product, error) {
+	args := m.Called(ctx, productID, sellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockMarketplaceService) GetSoldListings(ctx context.Context, sellerID primitive.ObjectID, page, limit int64) (*service.MarketplaceListResponse, error) {
+	args := m.Called(ctx, sellerID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.MarketplaceListResponse), args.Error(1)
 }
 
 func (m *MockMarketplaceService) DeleteProduct(ctx context.Context, productID, userID primitive.ObjectID) error {
@@ -70,6 +98,106 @@ func (m *MockMarketplaceService) GetMarketplaceConversations(ctx context.Context
 	return args.Get(0).([]models.ConversationSummary), args.Error(1)
 }
 
+func (m *MockMarketplaceService) SearchListings(ctx context.Context, filter models.ProductFilter) (*service.MarketplaceSearchResponse, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.MarketplaceSearchResponse), args.Error(1)
+}
+
+func (m *MockMarketplaceService) SaveSearch(ctx context.Context, userID primitive.ObjectID, req models.SaveProductSearchRequest) (*models.SavedProductSearch, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SavedProductSearch), args.Error(1)
+}
+
+func (m *MockMarketplaceService) GetSavedSearches(ctx context.Context, userID primitive.ObjectID) ([]models.SavedProductSearch, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]models.SavedProductSearch), args.Error(1)
+}
+
+func (m *MockMarketplaceService) DeleteSavedSearch(ctx context.Context, searchID, userID primitive.ObjectID) error {
+	args := m.Called(ctx, searchID, userID)
+	return args.Error(0)
+}
+
+func offerResult(args mock.Arguments) (*models.Offer, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Offer), args.Error(1)
+}
+
+func (m *MockMarketplaceService) MakeOffer(ctx context.Context, productID, buyerID primitive.ObjectID, req models.MakeOfferRequest) (*models.Offer, error) {
+	return offerResult(m.Called(ctx, productID, buyerID, req))
+}
+
+func (m *MockMarketplaceService) CounterOffer(ctx context.Context, offerID, userID primitive.ObjectID, req models.CounterOfferRequest) (*models.Offer, error) {
+	return offerResult(m.Called(ctx, offerID, userID, req))
+}
+
+func (m *MockMarketplaceService) AcceptOffer(ctx context.Context, offerID, userID primitive.ObjectID) (*models.Offer, error) {
+	return offerResult(m.Called(ctx, offerID, userID))
+}
+
+func (m *MockMarketplaceService) DeclineOffer(ctx context.Context, offerID, userID primitive.ObjectID) (*models.Offer, error) {
+	return offerResult(m.Called(ctx, offerID, userID))
+}
+
+func (m *MockMarketplaceService) GetOffer(ctx context.Context, offerID, userID primitive.ObjectID) (*models.Offer, error) {
+	return offerResult(m.Called(ctx, offerID, userID))
+}
+
+func (m *MockMarketplaceService) GetOffers(ctx context.Context, userID primitive.ObjectID, productID *primitive.ObjectID) ([]models.Offer, error) {
+	args := m.Called(ctx, userID, productID)
+	return args.Get(0).([]models.Offer), args.Error(1)
+}
+
+func (m *MockMarketplaceService) UpdateProduct(ctx context.Context, productID, userID primitive.ObjectID, req models.UpdateProductRequest) (*models.Product, error) {
+	args := m.Called(ctx, productID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockMarketplaceService) SaveListing(ctx context.Context, productID, userID primitive.ObjectID) error {
+	args := m.Called(ctx, productID, userID)
+	return args.Error(0)
+}
+
+func (m *MockMarketplaceService) UnsaveListing(ctx context.Context, productID, userID primitive.ObjectID) error {
+	args := m.Called(ctx, productID, userID)
+	return args.Error(0)
+}
+
+func (m *MockMarketplaceService) GetSavedListings(ctx context.Context, userID primitive.ObjectID, page, limit int64) (*service.MarketplaceListResponse, error) {
+	args := m.Called(ctx, userID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.MarketplaceListResponse), args.Error(1)
+}
+
+func (m *MockMarketplaceService) GetAlertPreferences(ctx context.Context, userID primitive.ObjectID) (*models.MarketplaceAlertPreferences, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MarketplaceAlertPreferences), args.Error(1)
+}
+
+func (m *MockMarketplaceService) UpdateAlertPreferences(ctx context.Context, userID primitive.ObjectID, req models.UpdateMarketplaceAlertPreferencesRequest) (*models.MarketplaceAlertPreferences, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MarketplaceAlertPreferences), args.Error(1)
+}
+
 func TestMarketplaceController_CreateProduct_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	
@@ -170,9 +298,9 @@ func TestMarketplaceController_CreateProduct_TooManyImages(t *testing.T) {
 	var response ErrorResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, "Too many images", response.Error)
-	assert.Equal(t, ErrCodeValidation, response.Code)
-	assert.Contains(t, response.Details["images"], "Maximum 5 images")
+	assert.Equal(t, "Too many images", response.Error.Message)
+	assert.Equal(t, ErrCodeValidation, response.Error.Code)
+	assert.Contains(t, response.Error.Details["images"], "Maximum 5 images")
 }
 
 func TestMarketplaceController_GetProduct_Success(t *testing.T) {
@@ -230,7 +358,7 @@ func TestMarketplaceController_MarkProductSold_Unauthorized(t *testing.T) {
 	productID := primitive.NewObjectID()
 	userID := primitive.NewObjectID()
 	
-	mockService.On("MarkProductSold", mock.Anything, productID, userID).Return(errors.New("unauthorized"))
+	mockService.On("MarkProductSold", mock.Anything, productID, userID, models.MarkProductSoldRequest{}).Return(nil, errors.New("unauthorized"))
 	
 	w := httptest.NewRecorder()
 	router := gin.New()
@@ -251,8 +379,8 @@ func TestMarketplaceController_MarkProductSold_Unauthorized(t *testing.T) {
 	var response ErrorResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, "You can only mark your own products as sold", response.Error)
-	assert.Equal(t, ErrCodeInsufficientPerms, response.Code)
+	assert.Equal(t, "You can only mark your own products as sold", response.Error.Message)
+	assert.Equal(t, ErrCodeInsufficientPerms, response.Error.Code)
 	
 	mockService.AssertExpectations(t)
 }
@@ -294,4 +422,96 @@ func TestMarketplaceController_ToggleSaveProduct_Success(t *testing.T) {
 	assert.Equal(t, true, data["saved"])
 	
 	mockService.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+func TestMarketplaceController_SearchListings_BindsFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockMarketplaceService)
+	controller := NewMarketplaceController(mockService)
+
+	expected := &service.MarketplaceSearchResponse{
+		MarketplaceListResponse: service.MarketplaceListResponse{Products: []models.ProductResponse{}, Page: 1, Limit: 20},
+		Facets:                  &models.ProductFacets{Categories: []models.FacetCount{}, Conditions: []models.FacetCount{}},
+	}
+	mockService.On("SearchListings", mock.Anything, mock.MatchedBy(func(f models.ProductFilter) bool {
+		return f.Query == "bike" &&
+			len(f.Conditions) == 2 && f.Conditions[1] == models.ProductConditionGood &&
+			f.Latitude != nil && *f.Latitude == 23.81 &&
+			f.Longitude != nil && *f.Longitude == 90.41 &&
+			f.RadiusKm == 10 && f.SortBy == "distance" &&
+			f.Page == 1 && f.Limit == 20
+	})).Return(expected, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.New()
+	router.GET("/search", controller.SearchListings)
+
+	req := httptest.NewRequest("GET", "/search?q=bike&condition=new&condition=good&lat=23.81&lng=90.41&radius_km=10&sort_by=distance", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"facets"`)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestMarketplaceController_SearchListings_InvalidFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockMarketplaceService)
+	controller := NewMarketplaceController(mockService)
+
+	mockService.On("SearchListings", mock.Anything, mock.AnythingOfType("models.ProductFilter")).Return(nil, validation.ErrSearchSortNoPoint)
+
+	w := httptest.NewRecorder()
+	router := gin.New()
+	router.GET("/search", controller.SearchListings)
+
+	req := httptest.NewRequest("GET", "/search?sort_by=distance", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrCodeValidation, response.Error.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestMarketplaceController_DeleteSavedSearch_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockMarketplaceService)
+	controller := NewMarketplaceController(mockService)
+
+	searchID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+
+	mockService.On("DeleteSavedSearch", mock.Anything, searchID, userID).Return(service.ErrSavedSearchNotFound)
+
+	w := httptest.NewRecorder()
+	router := gin.New()
+
+	// Mock authentication middleware
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", userID.Hex())
+		c.Next()
+	})
+
+	router.DELETE("/saved-searches/:id", controller.DeleteSavedSearch)
+
+	req := httptest.NewRequest("DELETE", "/saved-searches/"+searchID.Hex(), nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var response ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrCodeSearchNotFound, response.Error.Code)
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func authenticatedUserID(ctx *gin.Context) (primitive.ObjectID, bool) {
+	userIDStr, ok := ExtractUserID(ctx)
+	if !ok {
+		RespondWithError(ctx, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return primitive.NilObjectID, false
+	}
+
+	userObjectID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		RespondWithError(ctx, http.StatusUnauthorized, "Invalid user authentication", ErrCodeUnauthorized)
+		return primitive.NilObjectID, false
+	}
+	return userObjectID, true
+}
+
+func offerIDParam(ctx *gin.Context) (primitive.ObjectID, bool) {
+	offerID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid offer ID", ErrCodeValidation)
+		return primitive.NilObjectID, false
+	}
+	return offerID, true
+}
+
+func respondWithOfferError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, validation.ErrInvalidOffer):
+		RespondWithError(ctx, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+	case errors.Is(err, service.ErrOfferNotFound):
+		RespondWithError(ctx, http.StatusNotFound, err.Error(), ErrCodeOfferNotFound)
+	case errors.Is(err, service.ErrOfferExists),
+		errors.Is(err, service.ErrOfferNotPending),
+		errors.Is(err, service.ErrOfferAwaitingReply),
+		errors.Is(err, service.ErrProductUnavailable):
+		RespondWithError(ctx, http.StatusConflict, err.Error(), ErrCodeOfferConflict)
+	case err.Error() == "product not found":
+		RespondWithError(ctx, http.StatusNotFound, "Product not found", ErrCodeProductNotFound)
+	case err.Error() == "unauthorized":
+		RespondWithError(ctx, http.StatusForbidden, "You cannot make an offer on your own listing", ErrCodeInsufficientPerms)
+	default:
+		RespondWithError(ctx, http.StatusInternalServerError, fallback, ErrCodeInternalError)
+	}
+}
+
+func (c *MarketplaceController) MakeOffer(ctx *gin.Context) {
+	userObjectID, ok := authenticatedUserID(ctx)
+	if !ok {
+		return
+	}
+
+	productID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid product ID format", ErrCodeInvalidProductID)
+		return
+	}
+
+	var req models.MakeOfferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid request format", ErrCodeValidation)
+		return
+	}
+
+	offer, err := c.service.MakeOffer(ctx.Request.Context(), productID, userObjectID, req)
+	if err != nil {
+		respondWithOfferError(ctx, err, "Failed to make offer")
+		return
+	}
+	RespondWithSuccess(ctx, http.StatusCreated, "Offer sent", offer)
+}
+
+func (c *MarketplaceController) CounterOffer(ctx *gin.Context) {
+	userObjectID, ok := authenticatedUserID(ctx)
+	if !ok {
+		return
+	}
+	offerID, ok := offerIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.CounterOfferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid request format", ErrCodeValidation)
+		return
+	}
+
+	offer, err := c.service.CounterOffer(ctx.Request.Context(), offerID, userObjectID, req)
+	if err != nil {
+		respondWithOfferError(ctx, err, "Failed to counter offer")
+		return
+	}
+	RespondWithSuccess(ctx, http.StatusOK, "Counter offer sent", offer)
+}
+
+func (c *MarketplaceController) AcceptOffer(ctx *gin.Context) {
+	userObjectID, ok := authenticatedUserID(ctx)
+	if !ok {
+		return
+	}
+	offerID, ok := offerIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	offer, err := c.service.AcceptOffer(ctx.Request.Context(), offerID, userObjectID)
+	if err != nil {
+		respondWithOfferError(ctx, err, "Failed to accept offer")
+		return
+	}
+	RespondWithSuccess(ctx, http.StatusOK, "Offer accepted", offer)
+}
+
+func (c *MarketplaceController) DeclineOffer(ctx *gin.Context) {
+	userObjectID, ok := authenticatedUserID(ctx)
+	if !ok {
+		return
+	}
+	offerID, ok := offerIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	offer, err := c.service.DeclineOffer(ctx.Request.Context(), offerID, userObjectID)
+	if err != nil {
+		respondWithOfferError(ctx, err, "Failed to decline offer")
+		return
+	}
+	RespondWithSuccess(ctx, http.StatusOK, "Offer declined", offer)
+}
+
+func (c *MarketplaceController) GetOffer(ctx *gin.Context) {
+	userObjectID, ok := authenticatedUserID(ctx)
+	if !ok {
+		return
+	}
+	offerID, ok := offerIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	offer, err := c.service.GetOffer(ctx.Request.Context(), offerID, userObjectID)
+	if err != nil {
+		respondWithOfferError(ctx, err, "Failed to fetch offer")
+		return
+	}
+	RespondWithData(ctx, http.StatusOK, offer)
+}
+
+// GetOffers lists the offers the user made or received, narrowed to one
+// listing with ?product_id=
+func (c *MarketplaceController) GetOffers(ctx *gin.Context) {
+	userObjectID, ok := authenticatedUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var productID *primitive.ObjectID
+	if raw := ctx.Query("product_id"); raw != "" {
+		id, err := primitive.ObjectIDFromHex(raw)
+		if err != nil {
+			RespondWithError(ctx, http.StatusBadRequest, "Invalid product ID format", ErrCodeInvalidProductID)
+			return
+		}
+		productID = &id
+	}
+
+	offers, err := c.service.GetOffers(ctx.Request.Context(), userObjectID, productID)
+	if err != nil {
+		respondWithOfferError(ctx, err, "Failed to fetch offers")
+		return
+	}
+	if offers == nil {
+		offers = []models.Offer{}
+	}
+	RespondWithData(ctx, http.StatusOK, offers)
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newOfferRouter(controller *MarketplaceController, userID primitive.ObjectID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	// Mock authentication middleware
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", userID.Hex())
+		c.Next()
+	})
+
+	router.POST("/products/:id/offers", controller.MakeOffer)
+	router.GET("/offers", controller.GetOffers)
+	router.POST("/offers/:id/accept", controller.AcceptOffer)
+	return router
+}
+
+func TestMarketplaceController_MakeOffer_Success(t *testing.T) {
+	mockService := new(MockMarketplaceService)
+	userID := primitive.NewObjectID()
+	productID := primitive.NewObjectID()
+
+	req := models.MakeOfferRequest{Amount: 250, Message: "Cash today"}
+	offer := &models.Offer{ID: primitive.NewObjectID(), ProductID: productID, BuyerID: userID, Amount: 250, Status: models.OfferStatusPending}
+	mockService.On("MakeOffer", mock.Anything, productID, userID, req).Return(offer, nil)
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq := httptest.NewRequest("POST", "/products/"+productID.Hex()+"/offers", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	newOfferRouter(NewMarketplaceController(mockService), userID).ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestMarketplaceController_MakeOffer_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+		expectedErr  string
+	}{
+		{"invalid amount", validation.ErrOfferAmount, http.StatusBadRequest, ErrCodeValidation},
+		{"pending offer exists", service.ErrOfferExists, http.StatusConflict, ErrCodeOfferConflict},
+		{"listing sold", service.ErrProductUnavailable, http.StatusConflict, ErrCodeOfferConflict},
+		{"own listing", errors.New("unauthorized"), http.StatusForbidden, ErrCodeInsufficientPerms},
+		{"unexpected failure", assert.AnError, http.StatusInternalServerError, ErrCodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMarketplaceService)
+			userID := primitive.NewObjectID()
+			productID := primitive.NewObjectID()
+			mockService.On("MakeOffer", mock.Anything, productID, userID, mock.Anything).Return(nil, tt.err)
+
+			w := httptest.NewRecorder()
+			httpReq := httptest.NewRequest("POST", "/products/"+productID.Hex()+"/offers", bytes.NewBufferString(`{"amount":250}`))
+			httpReq.Header.Set("Content-Type", "application/json")
+			newOfferRouter(NewMarketplaceController(mockService), userID).ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			var response ErrorResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedErr, response.Error.Code)
+		})
+	}
+}
+
+func TestMarketplaceController_AcceptOffer_NotFound(t *testing.T) {
+	mockService := new(MockMarketplaceService)
+	userID := primitive.NewObjectID()
+	offerID := primitive.NewObjectID()
+	mockService.On("AcceptOffer", mock.Anything, offerID, userID).Return(nil, service.ErrOfferNotFound)
+
+	w := httptest.NewRecorder()
+	httpReq := httptest.NewRequest("POST", "/offers/"+offerID.Hex()+"/accept", nil)
+	newOfferRouter(NewMarketplaceController(mockService), userID).ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, ErrCodeOfferNotFound, response.Error.Code)
+}
+
+func TestMarketplaceController_GetOffers_FiltersByProduct(t *testing.T) {
+	mockService := new(MockMarketplaceService)
+	userID := primitive.NewObjectID()
+	productID := primitive.NewObjectID()
+	mockService.On("GetOffers", mock.Anything, userID, &productID).Return([]models.Offer{{ProductID: productID}}, nil)
+
+	w := httptest.NewRecorder()
+	httpReq := httptest.NewRequest("GET", "/offers?product_id="+productID.Hex(), nil)
+	newOfferRouter(NewMarketplaceController(mockService), userID).ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var offers []models.Offer
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &offers))
+	assert.Len(t, offers, 1)
+	mockService.AssertExpectations(t)
+}
@@ -3,15 +3,12 @@ package controllers
 import (
 	"net/http"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
 	"github.com/gin-gonic/gin"
 )
 
-// ErrorResponse represents a standardized error response
-type ErrorResponse struct {
-	Error   string            `json:"error"`
-	Code    string            `json:"code,omitempty"`
-	Details map[string]string `json:"details,omitempty"`
-}
+// ErrorResponse is the standard error envelope shared by all services
+type ErrorResponse = apperrors.Response
 
 // SuccessResponse represents a standardized success response
 type SuccessResponse struct {
@@ -21,37 +18,35 @@ type SuccessResponse struct {
 
 // Standard error codes for marketplace service
 const (
-	ErrCodeValidation        = "VALIDATION_ERROR"
-	ErrCodeUnauthorized      = "UNAUTHORIZED"
+	ErrCodeValidation        = apperrors.CodeValidation
+	ErrCodeUnauthorized      = apperrors.CodeUnauthorized
 	ErrCodeProductNotFound   = "PRODUCT_NOT_FOUND"
 	ErrCodeCategoryNotFound  = "CATEGORY_NOT_FOUND"
 	ErrCodeInvalidProductID  = "INVALID_PRODUCT_ID"
 	ErrCodeTooManyImages     = "TOO_MANY_IMAGES"
 	ErrCodeInsufficientPerms = "INSUFFICIENT_PERMISSIONS"
-	ErrCodeRateLimited       = "RATE_LIMITED"
-	ErrCodeInternalError     = "INTERNAL_ERROR"
+	ErrCodeRateLimited       = apperrors.CodeRateLimited
+	ErrCodeSearchNotFound    = "SAVED_SEARCH_NOT_FOUND"
+	ErrCodeSearchLimit       = "SAVED_SEARCH_LIMIT"
+	ErrCodeOfferNotFound     = "OFFER_NOT_FOUND"
+	ErrCodeOfferConflict     = "OFFER_CONFLICT"
+	ErrCodeInvalidTransition = "INVALID_STATUS_TRANSITION"
+	ErrCodeInternalError     = apperrors.CodeInternal
 )
 
-// RespondWithError sends a standardized error response
+// RespondWithError sends the standard error envelope. Without a code the
+// one for the status is used.
 func RespondWithError(c *gin.Context, statusCode int, message string, code ...string) {
-	errorCode := ErrCodeInternalError
+	errorCode := ""
 	if len(code) > 0 {
 		errorCode = code[0]
 	}
-	
-	c.JSON(statusCode, ErrorResponse{
-		Error: message,
-		Code:  errorCode,
-	})
+	apperrors.Write(c, statusCode, errorCode, message, nil)
 }
 
 // RespondWithValidationError sends a validation error with details
 func RespondWithValidationError(c *gin.Context, message string, details map[string]string) {
-	c.JSON(http.StatusBadRequest, ErrorResponse{
-		Error:   message,
-		Code:    ErrCodeValidation,
-		Details: details,
-	})
+	apperrors.Write(c, http.StatusBadRequest, ErrCodeValidation, message, details)
 }
 
 // RespondWithSuccess sends a standardized success response
@@ -59,11 +54,11 @@ func RespondWithSuccess(c *gin.Context, statusCode int, message string, data ...
 	response := SuccessResponse{
 		Message: message,
 	}
-	
+
 	if len(data) > 0 {
 		response.Data = data[0]
 	}
-	
+
 	c.JSON(statusCode, response)
 }
 
@@ -78,7 +73,7 @@ func ExtractUserID(c *gin.Context) (string, bool) {
 	if !exists {
 		return "", false
 	}
-	
+
 	userIDStr, ok := userID.(string)
 	return userIDStr, ok
-}
\ No newline at end of file
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (c *MarketplaceController) SaveListing(ctx *gin.Context) {
+	c.setListingSaved(ctx, true)
+}
+
+func (c *MarketplaceController) UnsaveListing(ctx *gin.Context) {
+	c.setListingSaved(ctx, false)
+}
+
+func (c *MarketplaceController) setListingSaved(ctx *gin.Context, saved bool) {
+	userObjectID, ok := authenticatedUserID(ctx)
+	if !ok {
+		return
+	}
+
+	productID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid product ID format", ErrCodeInvalidProductID)
+		return
+	}
+
+	if saved {
+		err = c.service.SaveListing(ctx.Request.Context(), productID, userObjectID)
+	} else {
+		err = c.service.UnsaveListing(ctx.Request.Context(), productID, userObjectID)
+	}
+	if err != nil {
+		if err.Error() == "product not found" {
+			RespondWithError(ctx, http.StatusNotFound, "Product not found", ErrCodeProductNotFound)
+			return
+		}
+		RespondWithError(ctx, http.StatusInternalServerError, "Failed to update saved listings", ErrCodeInternalError)
+		return
+	}
+
+	message := "Product saved successfully"
+	if !saved {
+		message = "Product removed from saved items"
+	}
+	RespondWithSuccess(ctx, http.StatusOK, message, gin.H{"saved": saved})
+}
+
+func (c *MarketplaceController) GetSavedListings(ctx *gin.Context) {
+	userObjectID, ok := authenticatedUserID(ctx)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+
+	listings, err := c.service.GetSavedListings(ctx.Request.Context(), userObjectID, page, limit)
+	if err != nil {
+		RespondWithError(ctx, http.StatusInternalServerError, "Failed to fetch saved listings", ErrCodeInternalError)
+		return
+	}
+
+	RespondWithData(ctx, http.StatusOK, listings)
+}
+
+func (c *MarketplaceController) GetAlertPreferences(ctx *gin.Context) {
+	userObjectID, ok := authenticatedUserID(ctx)
+	if !ok {
+		return
+	}
+
+	prefs, err := c.service.GetAlertPreferences(ctx.Request.Context(), userObjectID)
+	if err != nil {
+		RespondWithError(ctx, http.StatusInternalServerError, "Failed to fetch alert preferences", ErrCodeInternalError)
+		return
+	}
+
+	RespondWithData(ctx, http.StatusOK, prefs)
+}
+
+func (c *MarketplaceController) UpdateAlertPreferences(ctx *gin.Context) {
+	userObjectID, ok := authenticatedUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateMarketplaceAlertPreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondWithError(ctx, http.StatusBadRequest, "Invalid request format", ErrCodeValidation)
+		return
+	}
+
+	prefs, err := c.service.UpdateAlertPreferences(ctx.Request.Context(), userObjectID, req)
+	if err != nil {
+		if errors.Is(err, validation.ErrInvalidMinDropPercent) {
+			RespondWithError(ctx, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+			return
+		}
+		RespondWithError(ctx, http.StatusInternalServerError, "Failed to update alert preferences", ErrCodeInternalError)
+		return
+	}
+
+	RespondWithData(ctx, http.StatusOK, prefs)
+}
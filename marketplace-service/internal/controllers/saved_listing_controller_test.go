@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newSavedListingRouter(controller *MarketplaceController, userID primitive.ObjectID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	// Mock authentication middleware
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", userID.Hex())
+		c.Next()
+	})
+
+	router.PUT("/products/:id", controller.UpdateProduct)
+	router.POST("/products/:id/save", controller.SaveListing)
+	router.DELETE("/products/:id/save", controller.UnsaveListing)
+	router.GET("/saved-listings", controller.GetSavedListings)
+	router.PUT("/alert-preferences", controller.UpdateAlertPreferences)
+	return router
+}
+
+func TestMarketplaceController_UpdateProduct(t *testing.T) {
+	price := 250.0
+	req := models.UpdateProductRequest{Price: &price}
+
+	tests := []struct {
+		name       string
+		product    *models.Product
+		err        error
+		wantStatus int
+	}{
+		{name: "updated", product: &models.Product{Price: price}, wantStatus: http.StatusOK},
+		{name: "invalid", err: validation.ErrPriceInvalid, wantStatus: http.StatusBadRequest},
+		{name: "not the seller", err: errors.New("unauthorized"), wantStatus: http.StatusForbidden},
+		{name: "missing", err: errors.New("product not found"), wantStatus: http.StatusNotFound},
+		{name: "sold", err: service.ErrProductSold, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMarketplaceService)
+			userID := primitive.NewObjectID()
+			productID := primitive.NewObjectID()
+			if tt.product != nil {
+				mockService.On("UpdateProduct", mock.Anything, productID, userID, req).Return(tt.product, nil)
+			} else {
+				mockService.On("UpdateProduct", mock.Anything, productID, userID, req).Return(nil, tt.err)
+			}
+
+			body, _ := json.Marshal(req)
+			w := httptest.NewRecorder()
+			httpReq := httptest.NewRequest("PUT", "/products/"+productID.Hex(), bytes.NewBuffer(body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			newSavedListingRouter(NewMarketplaceController(mockService), userID).ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestMarketplaceController_SaveAndUnsaveListing(t *testing.T) {
+	mockService := new(MockMarketplaceService)
+	userID := primitive.NewObjectID()
+	productID := primitive.NewObjectID()
+	missingID := primitive.NewObjectID()
+
+	mockService.On("SaveListing", mock.Anything, productID, userID).Return(nil)
+	mockService.On("UnsaveListing", mock.Anything, productID, userID).Return(nil)
+	mockService.On("SaveListing", mock.Anything, missingID, userID).Return(errors.New("product not found"))
+	router := newSavedListingRouter(NewMarketplaceController(mockService), userID)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/products/"+productID.Hex()+"/save", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/products/"+productID.Hex()+"/save", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/products/"+missingID.Hex()+"/save", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestMarketplaceController_GetSavedListings(t *testing.T) {
+	mockService := new(MockMarketplaceService)
+	userID := primitive.NewObjectID()
+
+	list := &service.MarketplaceListResponse{Products: []models.ProductResponse{{Title: "Road bike", IsSaved: true}}, Total: 1, Page: 2, Limit: 10}
+	mockService.On("GetSavedListings", mock.Anything, userID, int64(2), int64(10)).Return(list, nil)
+
+	w := httptest.NewRecorder()
+	newSavedListingRouter(NewMarketplaceController(mockService), userID).
+		ServeHTTP(w, httptest.NewRequest("GET", "/saved-listings?page=2&limit=10", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestMarketplaceController_UpdateAlertPreferences_Invalid(t *testing.T) {
+	mockService := new(MockMarketplaceService)
+	userID := primitive.NewObjectID()
+
+	minDrop := 95.0
+	req := models.UpdateMarketplaceAlertPreferencesRequest{MinDropPercent: &minDrop}
+	mockService.On("UpdateAlertPreferences", mock.Anything, userID, mock.Anything).Return(nil, validation.ErrInvalidMinDropPercent)
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq := httptest.NewRequest("PUT", "/alert-preferences", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	newSavedListingRouter(NewMarketplaceController(mockService), userID).ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -125,6 +125,7 @@ func ToProtoProduct(product *models.ProductResponse) *marketplacepb.Product {
 			Username: product.Seller.Username,
 			FullName: product.Seller.FullName,
 			Avatar:   product.Seller.Avatar,
+			Verified: product.Seller.Verified,
 		},
 		Category: &marketplacepb.Category{
 			Id:   product.Category.ID.Hex(),
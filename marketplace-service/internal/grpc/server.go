@@ -2,10 +2,12 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	marketplace "github.com/MuhibNayem/connectify-v2/marketplace-service/internal"
 	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	marketplacepb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/marketplace/v1"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -50,6 +52,11 @@ func (s *Server) CreateProduct(ctx context.Context, req *marketplacepb.CreatePro
 		Location:    locationStr, // Product.Location is a string
 		Tags:        req.Tags,
 	}
+	// proto3 cannot tell 0,0 from unset; treat it as no coordinates
+	if lat, lng := req.Location.Latitude, req.Location.Longitude; lat != 0 || lng != 0 {
+		createReq.Latitude = &lat
+		createReq.Longitude = &lng
+	}
 
 	product, err := s.service.CreateProduct(ctx, userID, createReq)
 	if err != nil {
@@ -87,12 +94,17 @@ func (s *Server) SearchProducts(ctx context.Context, req *marketplacepb.SearchPr
 	filter := models.ProductFilter{
 		CategoryID: req.CategoryId,
 		Query:      req.Query,
-		MinPrice:   &req.MinPrice,
-		MaxPrice:   &req.MaxPrice,
 		SortBy:     req.SortBy,
 		Page:       req.Page,
 		Limit:      req.Limit,
 	}
+	// Unset prices arrive as 0, which would otherwise match only free listings
+	if req.MinPrice > 0 {
+		filter.MinPrice = &req.MinPrice
+	}
+	if req.MaxPrice > 0 {
+		filter.MaxPrice = &req.MaxPrice
+	}
 
 	if filter.Page == 0 {
 		filter.Page = 1
@@ -103,6 +115,9 @@ func (s *Server) SearchProducts(ctx context.Context, req *marketplacepb.SearchPr
 
 	result, err := s.service.SearchProducts(ctx, filter)
 	if err != nil {
+		if errors.Is(err, validation.ErrInvalidSearch) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to search products: %v", err)
 	}
 
@@ -173,7 +188,13 @@ func (s *Server) MarkProductSold(ctx context.Context, req *marketplacepb.MarkPro
 		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID: %v", err)
 	}
 
-	if err := s.service.MarkProductSold(ctx, productID, userID); err != nil {
+	if _, err := s.service.MarkProductSold(ctx, productID, userID, models.MarkProductSoldRequest{}); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTransition):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case err.Error() == "unauthorized":
+			return nil, status.Error(codes.PermissionDenied, "only the seller can mark this product sold")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to mark as sold: %v", err)
 	}
 
@@ -215,9 +236,69 @@ func (s *Server) GetMarketplaceConversations(ctx context.Context, req *marketpla
 }
 
 func (s *Server) UpdateProduct(ctx context.Context, req *marketplacepb.UpdateProductRequest) (*marketplacepb.ProductResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "UpdateProduct not implemented yet")
+	productID, err := primitive.ObjectIDFromHex(req.ProductId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product ID: %v", err)
+	}
+
+	userID, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID: %v", err)
+	}
+
+	// proto3 cannot tell unset from empty, so zero values leave a field as is
+	updateReq := models.UpdateProductRequest{
+		Title:       req.Title,
+		Description: req.Description,
+		Images:      req.Images,
+	}
+	if req.Price > 0 {
+		updateReq.Price = &req.Price
+	}
+	if req.Location != nil {
+		updateReq.Location = req.Location.City
+	}
+
+	product, err := s.service.UpdateProduct(ctx, productID, userID, updateReq)
+	if err != nil {
+		switch {
+		case validation.IsProductError(err):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrProductSold):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case err.Error() == "product not found":
+			return nil, status.Error(codes.NotFound, err.Error())
+		case err.Error() == "unauthorized":
+			return nil, status.Error(codes.PermissionDenied, "only the seller can update this product")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update product: %v", err)
+	}
+
+	return &marketplacepb.ProductResponse{
+		Product: marketplace.ToProtoProductFromModel(product),
+	}, nil
 }
 
 func (s *Server) GetSavedProducts(ctx context.Context, req *marketplacepb.GetSavedProductsRequest) (*marketplacepb.SearchProductsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "GetSavedProducts not implemented yet")
+	userID, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID: %v", err)
+	}
+
+	result, err := s.service.GetSavedListings(ctx, userID, req.Page, req.Limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get saved products: %v", err)
+	}
+
+	products := make([]*marketplacepb.Product, len(result.Products))
+	for i, p := range result.Products {
+		products[i] = marketplace.ToProtoProduct(&p)
+	}
+
+	return &marketplacepb.SearchProductsResponse{
+		Products: products,
+		Total:    result.Total,
+		Page:     result.Page,
+		Limit:    result.Limit,
+	}, nil
 }
@@ -21,8 +21,8 @@ func BuildRouter(cfg *config.Config, marketplaceService *service.MarketplaceServ
 	corsCfg := cors.Config{
 		AllowOrigins:     cfg.CORSAllowedOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Idempotency-Key"},
+		ExposeHeaders:    []string{"Content-Length", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
@@ -34,7 +34,15 @@ func BuildRouter(cfg *config.Config, marketplaceService *service.MarketplaceServ
 		rateLimitObserver = businessMetrics.RecordRateLimitHit
 	}
 
-	router.Use(middleware.RateLimiter(cfg.RateLimitEnabled, cfg.RateLimitLimit, cfg.RateLimitBurst, "marketplace:global", rateLimitObserver))
+	// Limits are counted in Redis so they hold across replicas
+	var rateLimits *middleware.SlidingWindowLimiter
+	var idempotency *middleware.Idempotency
+	if redisClient != nil {
+		rateLimits = middleware.NewSlidingWindowLimiter(redisClient.GetClient(), rateLimitObserver)
+		idempotency = middleware.NewIdempotency(redisClient.GetClient(), middleware.DefaultIdempotencyTTL)
+	}
+
+	router.Use(rateLimits.RateLimiter(cfg.RateLimitEnabled, cfg.RateLimitLimit, cfg.RateLimitBurst, "marketplace:global"))
 
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -46,9 +54,11 @@ func BuildRouter(cfg *config.Config, marketplaceService *service.MarketplaceServ
 			cfg.JWTSecret,
 			redisClient.GetClient(),
 			middleware.WithFailClosedResponse(http.StatusServiceUnavailable, "authentication temporarily unavailable"),
+			middleware.WithJWKS(cfg.JWKSURL),
+			middleware.WithLegacyHS256Until(cfg.LegacyHS256Until),
 		)
 	} else {
-		authMiddleware = middleware.JWTAuthSimple(cfg.JWTSecret)
+		authMiddleware = middleware.AuthMiddleware(cfg.JWTSecret, nil, middleware.WithJWKS(cfg.JWKSURL), middleware.WithLegacyHS256Until(cfg.LegacyHS256Until))
 	}
 
 	controller := controllers.NewMarketplaceController(marketplaceService)
@@ -58,42 +68,114 @@ func BuildRouter(cfg *config.Config, marketplaceService *service.MarketplaceServ
 	{
 		// Public routes with appropriate rate limits
 		marketplace.GET("/products", 
-			middleware.StrictRateLimiter(5, 20, "marketplace:search", rateLimitObserver),
+			rateLimits.StrictRateLimiter(5, 20, "marketplace:search"),
 			controller.SearchProducts,
 		)
 		marketplace.GET("/products/:id", 
-			middleware.StrictRateLimiter(10, 30, "marketplace:view", rateLimitObserver),
+			rateLimits.StrictRateLimiter(10, 30, "marketplace:view"),
 			controller.GetProduct,
 		)
+		marketplace.GET("/search",
+			rateLimits.StrictRateLimiter(5, 20, "marketplace:search"),
+			controller.SearchListings,
+		)
 		marketplace.GET("/categories", controller.GetCategories)
+		marketplace.GET("/sellers/:id/sold",
+			rateLimits.StrictRateLimiter(2, 10, "marketplace:sold-archive"),
+			controller.GetSoldListings,
+		)
 
 		authGroup := marketplace.Group("")
-		authGroup.Use(authMiddleware)
+		authGroup.Use(authMiddleware, idempotency.Handle())
 		{
 			authGroup.POST("/products", 
-				middleware.StrictRateLimiter(0.1, 3, "marketplace:create", rateLimitObserver), // 6 per minute
+				rateLimits.StrictRateLimiter(0.1, 3, "marketplace:create"), // 6 per minute
 				controller.CreateProduct,
 			)
 			authGroup.PUT("/products/:id", 
-				middleware.StrictRateLimiter(0.5, 5, "marketplace:update", rateLimitObserver), // 30 per minute
+				rateLimits.StrictRateLimiter(0.5, 5, "marketplace:update"), // 30 per minute
 				controller.UpdateProduct,
 			)
 			authGroup.DELETE("/products/:id", 
-				middleware.StrictRateLimiter(0.2, 2, "marketplace:delete", rateLimitObserver), // 12 per minute
+				rateLimits.StrictRateLimiter(0.2, 2, "marketplace:delete"), // 12 per minute
 				controller.DeleteProduct,
 			)
 			authGroup.PUT("/products/:id/sold", 
-				middleware.StrictRateLimiter(0.5, 5, "marketplace:sold", rateLimitObserver),
+				rateLimits.StrictRateLimiter(0.5, 5, "marketplace:sold"),
 				controller.MarkProductSold,
 			)
+			authGroup.POST("/products/:id/reserve",
+				rateLimits.StrictRateLimiter(0.5, 5, "marketplace:reserve"),
+				controller.ReserveProduct,
+			)
+			authGroup.POST("/products/:id/relist",
+				rateLimits.StrictRateLimiter(0.5, 5, "marketplace:relist"),
+				controller.RelistProduct,
+			)
 			authGroup.PUT("/products/:id/save", 
-				middleware.StrictRateLimiter(2, 10, "marketplace:save", rateLimitObserver), // 120 per minute
+				rateLimits.StrictRateLimiter(2, 10, "marketplace:save"), // 120 per minute
 				controller.ToggleSaveProduct,
 			)
+			authGroup.POST("/products/:id/save",
+				rateLimits.StrictRateLimiter(2, 10, "marketplace:save"),
+				controller.SaveListing,
+			)
+			authGroup.DELETE("/products/:id/save",
+				rateLimits.StrictRateLimiter(2, 10, "marketplace:save"),
+				controller.UnsaveListing,
+			)
+			authGroup.GET("/saved-listings",
+				rateLimits.StrictRateLimiter(1, 5, "marketplace:saved-listings"),
+				controller.GetSavedListings,
+			)
+			authGroup.GET("/alert-preferences",
+				rateLimits.StrictRateLimiter(1, 5, "marketplace:alert-preferences"),
+				controller.GetAlertPreferences,
+			)
+			authGroup.PUT("/alert-preferences",
+				rateLimits.StrictRateLimiter(0.2, 3, "marketplace:alert-preferences"),
+				controller.UpdateAlertPreferences,
+			)
 			authGroup.GET("/conversations", 
-				middleware.StrictRateLimiter(1, 5, "marketplace:conversations", rateLimitObserver),
+				rateLimits.StrictRateLimiter(1, 5, "marketplace:conversations"),
 				controller.GetMarketplaceConversations,
 			)
+			authGroup.GET("/saved-searches",
+				rateLimits.StrictRateLimiter(1, 5, "marketplace:saved-searches"),
+				controller.GetSavedSearches,
+			)
+			authGroup.POST("/saved-searches",
+				rateLimits.StrictRateLimiter(0.2, 3, "marketplace:save-search"), // 12 per minute
+				controller.SaveSearch,
+			)
+			authGroup.DELETE("/saved-searches/:id",
+				rateLimits.StrictRateLimiter(0.5, 5, "marketplace:delete-search"),
+				controller.DeleteSavedSearch,
+			)
+			authGroup.POST("/products/:id/offers",
+				rateLimits.StrictRateLimiter(0.2, 3, "marketplace:offer"), // 12 per minute
+				controller.MakeOffer,
+			)
+			authGroup.GET("/offers",
+				rateLimits.StrictRateLimiter(1, 5, "marketplace:offers"),
+				controller.GetOffers,
+			)
+			authGroup.GET("/offers/:id",
+				rateLimits.StrictRateLimiter(2, 10, "marketplace:offers"),
+				controller.GetOffer,
+			)
+			authGroup.POST("/offers/:id/counter",
+				rateLimits.StrictRateLimiter(0.2, 3, "marketplace:offer"),
+				controller.CounterOffer,
+			)
+			authGroup.POST("/offers/:id/accept",
+				rateLimits.StrictRateLimiter(0.5, 5, "marketplace:offer-respond"),
+				controller.AcceptOffer,
+			)
+			authGroup.POST("/offers/:id/decline",
+				rateLimits.StrictRateLimiter(0.5, 5, "marketplace:offer-respond"),
+				controller.DeclineOffer,
+			)
 		}
 	}
 
@@ -8,9 +8,11 @@ import (
 
 	"github.com/MuhibNayem/connectify-v2/marketplace-service/config"
 	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/metrics"
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/producer"
 	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/repository"
 	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/resilience"
 	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/service"
+	sharedkafka "github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
 	"github.com/segmentio/kafka-go"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -23,6 +25,9 @@ type Dependencies struct {
 	MarketplaceRepo    *repository.MarketplaceRepository
 	MarketplaceService *service.MarketplaceService
 	Metrics            *metrics.BusinessMetrics
+	SearchIndexer      *sharedkafka.SearchIndexPublisher
+	OfferPublisher     *sharedkafka.OfferEventPublisher
+	Notifications      *producer.NotificationProducer
 }
 
 func InitializeDependencies(cfg *config.Config) (*Dependencies, error) {
@@ -73,6 +78,12 @@ func InitializeDependencies(cfg *config.Config) (*Dependencies, error) {
 		cb,
 		kafkaWriter,
 	)
+	searchIndexer := sharedkafka.NewSearchIndexPublisher(cfg.KafkaBrokers)
+	marketplaceService.SetSearchIndexer(searchIndexer)
+	offerPublisher := sharedkafka.NewOfferEventPublisher(cfg.KafkaBrokers)
+	marketplaceService.SetOfferPublisher(offerPublisher)
+	notificationProducer := producer.NewNotificationProducer(cfg.KafkaBrokers, cfg.NotificationTopic)
+	marketplaceService.SetNotificationPublisher(notificationProducer)
 
 	return &Dependencies{
 		Config:             cfg,
@@ -80,5 +91,8 @@ func InitializeDependencies(cfg *config.Config) (*Dependencies, error) {
 		MarketplaceRepo:    marketplaceRepo,
 		MarketplaceService: marketplaceService,
 		Metrics:            businessMetrics,
+		SearchIndexer:      searchIndexer,
+		OfferPublisher:     offerPublisher,
+		Notifications:      notificationProducer,
 	}, nil
 }
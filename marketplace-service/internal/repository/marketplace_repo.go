@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"math"
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
@@ -13,12 +14,18 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// earthRadiusKm is the radius used for radius searches and distances
+const earthRadiusKm = 6378.1
+
 type MarketplaceRepository struct {
-	db                 *mongo.Database
-	productCollection  *mongo.Collection
-	categoryCollection *mongo.Collection
-	messageCollection  *mongo.Collection
-	userCollection     *mongo.Collection
+	db                    *mongo.Database
+	productCollection     *mongo.Collection
+	categoryCollection    *mongo.Collection
+	messageCollection     *mongo.Collection
+	userCollection        *mongo.Collection
+	savedSearchCollection *mongo.Collection
+	offerCollection       *mongo.Collection
+	alertPrefsCollection  *mongo.Collection
 }
 
 func NewMarketplaceRepository(db *mongo.Database) *MarketplaceRepository {
@@ -26,6 +33,9 @@ func NewMarketplaceRepository(db *mongo.Database) *MarketplaceRepository {
 	categoryCollection := db.Collection("categories")
 	messageCollection := db.Collection("messages")
 	userCollection := db.Collection("users")
+	savedSearchCollection := db.Collection("marketplace_saved_searches")
+	offerCollection := db.Collection("marketplace_offers")
+	alertPrefsCollection := db.Collection("marketplace_alert_preferences")
 
 	// Create Indexes for Products
 	productIndexes := []mongo.IndexModel{
@@ -50,6 +60,24 @@ func NewMarketplaceRepository(db *mongo.Database) *MarketplaceRepository {
 		{
 			Keys: bson.D{{Key: "coordinates", Value: "2dsphere"}},
 		},
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "condition", Value: 1},
+				{Key: "price", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{{Key: "saved_by", Value: 1}},
+		},
+		{
+			// Sold items archive
+			Keys: bson.D{
+				{Key: "seller_id", Value: 1},
+				{Key: "status", Value: 1},
+				{Key: "sold_at", Value: -1},
+			},
+		},
 	}
 	_, err := productCollection.Indexes().CreateMany(context.Background(), productIndexes)
 	if err != nil {
@@ -71,12 +99,62 @@ func NewMarketplaceRepository(db *mongo.Database) *MarketplaceRepository {
 		slog.Error("Failed to create category indexes", "error", err)
 	}
 
+	_, err = savedSearchCollection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	})
+	if err != nil {
+		slog.Error("Failed to create saved search indexes", "error", err)
+	}
+
+	// A buyer has at most one pending offer per listing
+	offerIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "product_id", Value: 1},
+				{Key: "buyer_id", Value: 1},
+			},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": models.OfferStatusPending}),
+		},
+		{
+			Keys: bson.D{
+				{Key: "buyer_id", Value: 1},
+				{Key: "updated_at", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "seller_id", Value: 1},
+				{Key: "updated_at", Value: -1},
+			},
+		},
+	}
+	_, err = offerCollection.Indexes().CreateMany(context.Background(), offerIndexes)
+	if err != nil {
+		slog.Error("Failed to create offer indexes", "error", err)
+	}
+
+	_, err = alertPrefsCollection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		slog.Error("Failed to create alert preference indexes", "error", err)
+	}
+
 	return &MarketplaceRepository{
-		db:                 db,
-		productCollection:  productCollection,
-		categoryCollection: categoryCollection,
-		messageCollection:  messageCollection,
-		userCollection:     userCollection,
+		db:                    db,
+		productCollection:     productCollection,
+		categoryCollection:    categoryCollection,
+		messageCollection:     messageCollection,
+		userCollection:        userCollection,
+		savedSearchCollection: savedSearchCollection,
+		offerCollection:       offerCollection,
+		alertPrefsCollection:  alertPrefsCollection,
 	}
 }
 
@@ -168,6 +246,44 @@ func (r *MarketplaceRepository) UpdateProduct(ctx context.Context, id primitive.
 	return &updatedProduct, nil
 }
 
+// TransitionProduct applies update only while the listing is still in one of
+// the from statuses, so concurrent lifecycle changes cannot both win. It
+// returns nil if the listing has moved on.
+func (r *MarketplaceRepository) TransitionProduct(ctx context.Context, id primitive.ObjectID, from []models.ProductStatus, update bson.M) (*models.Product, error) {
+	filter := bson.M{
+		"_id":    id,
+		"status": bson.M{"$in": from},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var product models.Product
+	err := r.productCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&product)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+// FindProducts returns the full listings matching filter, newest first,
+// whatever their status
+func (r *MarketplaceRepository) FindProducts(ctx context.Context, filter bson.M) ([]models.Product, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.productCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	products := []models.Product{}
+	if err = cursor.All(ctx, &products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
 func (r *MarketplaceRepository) DeleteProduct(ctx context.Context, id primitive.ObjectID) error {
 	_, err := r.productCollection.DeleteOne(ctx, bson.M{"_id": id})
 	return err
@@ -203,32 +319,106 @@ func (r *MarketplaceRepository) BatchIncrementViews(ctx context.Context, product
 	return err
 }
 
-func (r *MarketplaceRepository) ListProducts(ctx context.Context, filter models.ProductFilter) ([]models.ProductResponse, int64, error) {
-	matchStage := bson.M{
+// searchMatch returns the filters every facet shares: availability, the
+// search radius, and the text query, which must be in the first $match stage
+func searchMatch(filter models.ProductFilter) bson.M {
+	match := bson.M{
 		"status": models.ProductStatusAvailable,
 	}
-
-	if filter.CategoryID != "" {
-		catID, err := primitive.ObjectIDFromHex(filter.CategoryID)
-		if err == nil {
-			matchStage["category_id"] = catID
-		}
+	switch {
+	case filter.SavedBy != nil:
+		match = bson.M{"saved_by": *filter.SavedBy}
+	case filter.SoldBy != nil:
+		match = bson.M{"seller_id": *filter.SoldBy, "status": models.ProductStatusSold}
 	}
 
 	if filter.Query != "" {
-		matchStage["$text"] = bson.M{"$search": filter.Query}
+		match["$text"] = bson.M{"$search": filter.Query}
 	}
 
-	if filter.MinPrice != nil || filter.MaxPrice != nil {
-		priceFilter := bson.M{}
-		if filter.MinPrice != nil {
-			priceFilter["$gte"] = *filter.MinPrice
-		}
-		if filter.MaxPrice != nil {
-			priceFilter["$lte"] = *filter.MaxPrice
+	if filter.HasLocation() && filter.RadiusKm > 0 {
+		// Uses the 2dsphere index; coordinates are stored as [lng, lat]
+		match["coordinates"] = bson.M{"$geoWithin": bson.M{
+			"$centerSphere": bson.A{
+				bson.A{*filter.Longitude, *filter.Latitude},
+				filter.RadiusKm / earthRadiusKm,
+			},
+		}}
+	}
+
+	return match
+}
+
+func categoryMatch(filter models.ProductFilter) bson.M {
+	if catID, err := primitive.ObjectIDFromHex(filter.CategoryID); err == nil {
+		return bson.M{"category_id": catID}
+	}
+	return bson.M{}
+}
+
+func conditionMatch(filter models.ProductFilter) bson.M {
+	if len(filter.Conditions) > 0 {
+		return bson.M{"condition": bson.M{"$in": filter.Conditions}}
+	}
+	return bson.M{}
+}
+
+func priceMatch(filter models.ProductFilter) bson.M {
+	if filter.MinPrice == nil && filter.MaxPrice == nil {
+		return bson.M{}
+	}
+	priceFilter := bson.M{}
+	if filter.MinPrice != nil {
+		priceFilter["$gte"] = *filter.MinPrice
+	}
+	if filter.MaxPrice != nil {
+		priceFilter["$lte"] = *filter.MaxPrice
+	}
+	return bson.M{"price": priceFilter}
+}
+
+// mergeMatch combines filters on distinct fields into one
+func mergeMatch(matches ...bson.M) bson.M {
+	merged := bson.M{}
+	for _, match := range matches {
+		for k, v := range match {
+			merged[k] = v
 		}
-		matchStage["price"] = priceFilter
 	}
+	return merged
+}
+
+// productMatch combines every filter
+func productMatch(filter models.ProductFilter) bson.M {
+	return mergeMatch(searchMatch(filter), categoryMatch(filter), conditionMatch(filter), priceMatch(filter))
+}
+
+// distanceKmExpr computes the great-circle distance from a point to a
+// listing's coordinates. $geoNear would do this, but it cannot follow $text.
+func distanceKmExpr(lat, lng float64) bson.M {
+	lat1 := lat * math.Pi / 180
+	lng1 := lng * math.Pi / 180
+	lat2 := bson.M{"$degreesToRadians": bson.M{"$arrayElemAt": bson.A{"$coordinates", 1}}}
+	lng2 := bson.M{"$degreesToRadians": bson.M{"$arrayElemAt": bson.A{"$coordinates", 0}}}
+
+	halfSinSq := func(a, b interface{}) bson.M {
+		return bson.M{"$pow": bson.A{
+			bson.M{"$sin": bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{a, b}}, 2}}},
+			2,
+		}}
+	}
+	haversine := bson.M{"$add": bson.A{
+		halfSinSq(lat2, lat1),
+		bson.M{"$multiply": bson.A{math.Cos(lat1), bson.M{"$cos": lat2}, halfSinSq(lng2, lng1)}},
+	}}
+	return bson.M{"$multiply": bson.A{
+		2 * earthRadiusKm,
+		bson.M{"$asin": bson.M{"$sqrt": bson.M{"$min": bson.A{1, haversine}}}},
+	}}
+}
+
+func (r *MarketplaceRepository) ListProducts(ctx context.Context, filter models.ProductFilter) ([]models.ProductResponse, int64, error) {
+	matchStage := productMatch(filter)
 
 	pipeline := mongo.Pipeline{
 		bson.D{{Key: "$match", Value: matchStage}},
@@ -242,11 +432,28 @@ func (r *MarketplaceRepository) ListProducts(ctx context.Context, filter models.
 	// Removed $lookup and $unwind for optimization (Denormalized)
 	// No joins needed!
 
-	sortStage := bson.M{"created_at": -1}
-	if filter.SortBy == "price_asc" {
-		sortStage = bson.M{"price": 1}
-	} else if filter.SortBy == "price_desc" {
-		sortStage = bson.M{"price": -1}
+	if filter.HasLocation() {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{
+			"distance_km": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{bson.M{"$size": bson.M{"$ifNull": bson.A{"$coordinates", bson.A{}}}}, 2}},
+				distanceKmExpr(*filter.Latitude, *filter.Longitude),
+				"$$REMOVE",
+			}},
+		}}})
+	}
+
+	sortStage := bson.D{{Key: "created_at", Value: -1}}
+	switch filter.SortBy {
+	case "price_asc":
+		sortStage = bson.D{{Key: "price", Value: 1}}
+	case "price_desc":
+		sortStage = bson.D{{Key: "price", Value: -1}}
+	case "sold_at":
+		sortStage = bson.D{{Key: "sold_at", Value: -1}}
+	case "distance":
+		if filter.HasLocation() {
+			sortStage = bson.D{{Key: "distance_km", Value: 1}, {Key: "created_at", Value: -1}}
+		}
 	}
 	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sortStage}})
 
@@ -264,9 +471,12 @@ func (r *MarketplaceRepository) ListProducts(ctx context.Context, filter models.
 		"images":      1,
 		"location":    1,
 		"status":      1,
+		"condition":   1,
+		"distance_km": 1,
 		"tags":        1,
 		"views":       1,
 		"created_at":  1,
+		"sold_at":     1,
 		"seller": bson.M{
 			"_id":       "$seller_id",
 			"username":  "$seller_username",
@@ -295,6 +505,291 @@ func (r *MarketplaceRepository) ListProducts(ctx context.Context, filter models.
 	return products, total, nil
 }
 
+// GetProductFacets counts matching listings per category and condition, and
+// finds their price range
+func (r *MarketplaceRepository) GetProductFacets(ctx context.Context, filter models.ProductFilter) (*models.ProductFacets, error) {
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: searchMatch(filter)}},
+		bson.D{{Key: "$facet", Value: bson.M{
+			"categories": bson.A{
+				bson.M{"$match": mergeMatch(conditionMatch(filter), priceMatch(filter))},
+				bson.M{"$group": bson.M{
+					"_id":   bson.M{"$toString": "$category_id"},
+					"label": bson.M{"$first": "$category_name"},
+					"count": bson.M{"$sum": 1},
+				}},
+				bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "label", Value: 1}}},
+			},
+			"conditions": bson.A{
+				bson.M{"$match": mergeMatch(categoryMatch(filter), priceMatch(filter))},
+				bson.M{"$match": bson.M{"condition": bson.M{"$exists": true, "$ne": ""}}},
+				bson.M{"$group": bson.M{
+					"_id":   "$condition",
+					"count": bson.M{"$sum": 1},
+				}},
+				bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+			},
+			"price_range": bson.A{
+				bson.M{"$match": mergeMatch(categoryMatch(filter), conditionMatch(filter))},
+				bson.M{"$group": bson.M{
+					"_id": nil,
+					"min": bson.M{"$min": "$price"},
+					"max": bson.M{"$max": "$price"},
+				}},
+			},
+		}}},
+	}
+
+	cursor, err := r.productCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Categories []models.FacetCount `bson:"categories"`
+		Conditions []models.FacetCount `bson:"conditions"`
+		PriceRange []models.PriceRange `bson:"price_range"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	facets := &models.ProductFacets{
+		Categories: []models.FacetCount{},
+		Conditions: []models.FacetCount{},
+	}
+	if len(results) == 0 {
+		return facets, nil
+	}
+	if results[0].Categories != nil {
+		facets.Categories = results[0].Categories
+	}
+	if results[0].Conditions != nil {
+		facets.Conditions = results[0].Conditions
+	}
+	if len(results[0].PriceRange) > 0 {
+		facets.PriceRange = &results[0].PriceRange[0]
+	}
+	return facets, nil
+}
+
+func (r *MarketplaceRepository) CreateSavedSearch(ctx context.Context, search *models.SavedProductSearch) error {
+	search.CreatedAt = time.Now()
+	res, err := r.savedSearchCollection.InsertOne(ctx, search)
+	if err != nil {
+		return err
+	}
+	search.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *MarketplaceRepository) CountSavedSearches(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	return r.savedSearchCollection.CountDocuments(ctx, bson.M{"user_id": userID})
+}
+
+func (r *MarketplaceRepository) GetSavedSearches(ctx context.Context, userID primitive.ObjectID) ([]models.SavedProductSearch, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.savedSearchCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	searches := []models.SavedProductSearch{}
+	if err = cursor.All(ctx, &searches); err != nil {
+		return nil, err
+	}
+	return searches, nil
+}
+
+// DeleteSavedSearch removes one of a user's saved searches, reporting whether it existed
+func (r *MarketplaceRepository) DeleteSavedSearch(ctx context.Context, id, userID primitive.ObjectID) (bool, error) {
+	res, err := r.savedSearchCollection.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// SetListingSaved adds or removes a user from a listing's savers
+func (r *MarketplaceRepository) SetListingSaved(ctx context.Context, productID, userID primitive.ObjectID, saved bool) error {
+	op := "$pull"
+	if saved {
+		op = "$addToSet"
+	}
+	res, err := r.productCollection.UpdateOne(ctx, bson.M{"_id": productID}, bson.M{
+		op:     bson.M{"saved_by": userID},
+		"$set": bson.M{"updated_at": time.Now()},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("product not found")
+	}
+	return nil
+}
+
+// GetAlertPreferences returns the stored alert preferences of the given
+// users, keyed by user. Users who never changed theirs are absent.
+func (r *MarketplaceRepository) GetAlertPreferences(ctx context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]models.MarketplaceAlertPreferences, error) {
+	cursor, err := r.alertPrefsCollection.Find(ctx, bson.M{"user_id": bson.M{"$in": userIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stored []models.MarketplaceAlertPreferences
+	if err = cursor.All(ctx, &stored); err != nil {
+		return nil, err
+	}
+	prefs := make(map[primitive.ObjectID]models.MarketplaceAlertPreferences, len(stored))
+	for _, p := range stored {
+		prefs[p.UserID] = p
+	}
+	return prefs, nil
+}
+
+func (r *MarketplaceRepository) UpsertAlertPreferences(ctx context.Context, prefs *models.MarketplaceAlertPreferences) error {
+	prefs.UpdatedAt = time.Now()
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.alertPrefsCollection.ReplaceOne(ctx, bson.M{"user_id": prefs.UserID}, prefs, opts)
+	return err
+}
+
+// CreateOffer stores a new offer, reporting false if the buyer already has a
+// pending offer on the listing
+func (r *MarketplaceRepository) CreateOffer(ctx context.Context, offer *models.Offer) (bool, error) {
+	res, err := r.offerCollection.InsertOne(ctx, offer)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	offer.ID = res.InsertedID.(primitive.ObjectID)
+	return true, nil
+}
+
+// GetOfferByID returns nil if the offer does not exist
+func (r *MarketplaceRepository) GetOfferByID(ctx context.Context, id primitive.ObjectID) (*models.Offer, error) {
+	var offer models.Offer
+	err := r.offerCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&offer)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &offer, nil
+}
+
+// GetPendingOffer returns the buyer's pending offer on a listing, or nil
+func (r *MarketplaceRepository) GetPendingOffer(ctx context.Context, productID, buyerID primitive.ObjectID) (*models.Offer, error) {
+	var offer models.Offer
+	err := r.offerCollection.FindOne(ctx, bson.M{
+		"product_id": productID,
+		"buyer_id":   buyerID,
+		"status":     models.OfferStatusPending,
+	}).Decode(&offer)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &offer, nil
+}
+
+// UpdatePendingOffer applies an update only while the offer is still pending
+// with the given proposer, so two responses to the same amount cannot both
+// win. It returns nil if the offer had already moved on.
+func (r *MarketplaceRepository) UpdatePendingOffer(ctx context.Context, id, proposedBy primitive.ObjectID, update bson.M) (*models.Offer, error) {
+	filter := bson.M{
+		"_id":         id,
+		"status":      models.OfferStatusPending,
+		"proposed_by": proposedBy,
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var offer models.Offer
+	err := r.offerCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&offer)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &offer, nil
+}
+
+// GetOffers returns the offers a user made or received, most recently active
+// first, optionally for a single listing
+func (r *MarketplaceRepository) GetOffers(ctx context.Context, userID primitive.ObjectID, productID *primitive.ObjectID) ([]models.Offer, error) {
+	filter := bson.M{"$or": []bson.M{
+		{"buyer_id": userID},
+		{"seller_id": userID},
+	}}
+	if productID != nil {
+		filter["product_id"] = *productID
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}}).SetLimit(100)
+
+	cursor, err := r.offerCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	offers := []models.Offer{}
+	if err = cursor.All(ctx, &offers); err != nil {
+		return nil, err
+	}
+	return offers, nil
+}
+
+// GetAllOffers returns every offer the user made or received, unlike
+// GetOffers which only lists the most recent
+func (r *MarketplaceRepository) GetAllOffers(ctx context.Context, userID primitive.ObjectID) ([]models.Offer, error) {
+	filter := bson.M{"$or": []bson.M{
+		{"buyer_id": userID},
+		{"seller_id": userID},
+	}}
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+
+	cursor, err := r.offerCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	offers := []models.Offer{}
+	if err = cursor.All(ctx, &offers); err != nil {
+		return nil, err
+	}
+	return offers, nil
+}
+
+// GetPendingOfferBuyers returns the buyers still negotiating over a listing
+func (r *MarketplaceRepository) GetPendingOfferBuyers(ctx context.Context, productID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	values, err := r.offerCollection.Distinct(ctx, "buyer_id", bson.M{
+		"product_id": productID,
+		"status":     models.OfferStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buyers := make([]primitive.ObjectID, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(primitive.ObjectID); ok {
+			buyers = append(buyers, id)
+		}
+	}
+	return buyers, nil
+}
+
 func (r *MarketplaceRepository) GetMarketplaceConversations(ctx context.Context, userID primitive.ObjectID) ([]models.ConversationSummary, error) {
 	pipeline := mongo.Pipeline{
 		bson.D{{Key: "$match", Value: bson.M{
@@ -361,3 +856,41 @@ func (r *MarketplaceRepository) GetMarketplaceConversations(ctx context.Context,
 
 	return summaries, nil
 }
+
+// DeleteUserData removes the user's listings, saved searches, offers and
+// alert preferences and takes them off every listing they saved, returning
+// how many records were removed or changed
+func (r *MarketplaceRepository) DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	var total int64
+
+	res, err := r.productCollection.DeleteMany(ctx, bson.M{"seller_id": userID})
+	if err != nil {
+		return total, err
+	}
+	total += res.DeletedCount
+
+	updated, err := r.productCollection.UpdateMany(ctx, bson.M{"saved_by": userID}, bson.M{
+		"$pull": bson.M{"saved_by": userID},
+	})
+	if err != nil {
+		return total, err
+	}
+	total += updated.ModifiedCount
+
+	deletes := []struct {
+		coll   *mongo.Collection
+		filter bson.M
+	}{
+		{r.savedSearchCollection, bson.M{"user_id": userID}},
+		{r.offerCollection, bson.M{"$or": []bson.M{{"buyer_id": userID}, {"seller_id": userID}}}},
+		{r.alertPrefsCollection, bson.M{"user_id": userID}},
+	}
+	for _, d := range deletes {
+		res, err := d.coll.DeleteMany(ctx, d.filter)
+		if err != nil {
+			return total, err
+		}
+		total += res.DeletedCount
+	}
+	return total, nil
+}
@@ -113,7 +113,7 @@ func TestMarketplaceService_ToggleSaveProduct(t *testing.T) {
 			}
 
 			mockRepo.On("GetProductByID", mock.Anything, productID).Return(product, nil)
-			mockRepo.On("UpdateProduct", mock.Anything, productID, mock.Anything).Return(product, nil)
+			mockRepo.On("SetListingSaved", mock.Anything, productID, userID, tt.expectedSaved).Return(nil)
 
 			saved, err := service.ToggleSaveProduct(context.Background(), productID, userID)
 
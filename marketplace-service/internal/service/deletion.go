@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeleteUserData erases a deleted account's marketplace data and drops its
+// listings from the search index
+func (s *MarketplaceService) DeleteUserData(ctx context.Context, userID string) (int64, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, errors.New("invalid user ID")
+	}
+
+	listings, err := s.repo.FindProducts(ctx, bson.M{"seller_id": uID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to load listings: %w", err)
+	}
+	deleted, err := s.repo.DeleteUserData(ctx, uID)
+	if err != nil {
+		return deleted, fmt.Errorf("failed to delete marketplace data: %w", err)
+	}
+
+	if s.search != nil {
+		for _, listing := range listings {
+			s.search.Delete(ctx, models.SearchEntityListing, listing.ID.Hex())
+		}
+	}
+	s.logger.Info("Marketplace data deleted", "user_id", userID, "items", deleted)
+	return deleted, nil
+}
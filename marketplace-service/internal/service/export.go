@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	exportpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/export/v1"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// exportedSavedListing is the part of someone else's listing the user keeps
+// in their export after saving it
+type exportedSavedListing struct {
+	ID       primitive.ObjectID   `json:"id"`
+	Title    string               `json:"title"`
+	Price    float64              `json:"price"`
+	Currency string               `json:"currency"`
+	Status   models.ProductStatus `json:"status"`
+}
+
+// ExportUserData collects the user's listings, saved listings, saved
+// searches, offers and alert preferences for their data export
+func (s *MarketplaceService) ExportUserData(ctx context.Context, userID string) ([]*exportpb.ExportSection, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	listings, err := s.repo.FindProducts(ctx, bson.M{"seller_id": uID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export listings: %w", err)
+	}
+	saved, err := s.repo.FindProducts(ctx, bson.M{"saved_by": uID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export saved listings: %w", err)
+	}
+	searches, err := s.repo.GetSavedSearches(ctx, uID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export saved searches: %w", err)
+	}
+	offers, err := s.repo.GetAllOffers(ctx, uID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export offers: %w", err)
+	}
+	prefs, err := s.repo.GetAlertPreferences(ctx, []primitive.ObjectID{uID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export alert preferences: %w", err)
+	}
+
+	var images []string
+	for i := range listings {
+		// Who saved a listing belongs to those users, not the seller
+		listings[i].SavedBy = nil
+		images = append(images, listings[i].Images...)
+	}
+	savedListings := make([]exportedSavedListing, 0, len(saved))
+	for _, p := range saved {
+		savedListings = append(savedListings, exportedSavedListing{
+			ID:       p.ID,
+			Title:    p.Title,
+			Price:    p.Price,
+			Currency: p.Currency,
+			Status:   p.Status,
+		})
+	}
+	alertPrefs := []models.MarketplaceAlertPreferences{}
+	if p, ok := prefs[uID]; ok {
+		alertPrefs = append(alertPrefs, p)
+	}
+
+	var b dataexport.Builder
+	b.Add("listings", listings, images...)
+	b.Add("saved_listings", savedListings)
+	b.Add("saved_searches", searches)
+	b.Add("offers", offers)
+	b.Add("alert_preferences", alertPrefs)
+	return b.Sections()
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrInvalidTransition = errors.New("listing cannot move to that status")
+
+func parseBuyer(buyerID string, sellerID primitive.ObjectID) (primitive.ObjectID, error) {
+	id, err := primitive.ObjectIDFromHex(buyerID)
+	if err != nil || id == sellerID {
+		return primitive.NilObjectID, validation.ErrInvalidBuyer
+	}
+	return id, nil
+}
+
+// sellerListing loads a listing its seller wants to move to next
+func (s *MarketplaceService) sellerListing(ctx context.Context, productID, sellerID primitive.ObjectID, next models.ProductStatus) (*models.Product, error) {
+	product, err := s.repo.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.SellerID != sellerID {
+		return nil, errors.New("unauthorized")
+	}
+	if !product.Status.CanTransitionTo(next) {
+		return nil, ErrInvalidTransition
+	}
+	return product, nil
+}
+
+// transition moves a listing to next, failing if another change got there first
+func (s *MarketplaceService) transition(ctx context.Context, productID primitive.ObjectID, next models.ProductStatus, update bson.M) (*models.Product, error) {
+	updated, err := s.repo.TransitionProduct(ctx, productID, models.TransitionSources(next), update)
+	if err != nil {
+		s.logger.Error("Failed to change listing status", "error", err, "product_id", productID, "status", next)
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrInvalidTransition
+	}
+	s.indexListing(ctx, updated)
+	return updated, nil
+}
+
+// ReserveProduct holds a listing for one buyer, taking it out of search
+// until it is sold or relisted
+func (s *MarketplaceService) ReserveProduct(ctx context.Context, productID, sellerID primitive.ObjectID, req models.ReserveProductRequest) (*models.Product, error) {
+	buyerID, err := parseBuyer(req.BuyerID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.sellerListing(ctx, productID, sellerID, models.ProductStatusReserved); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	updated, err := s.transition(ctx, productID, models.ProductStatusReserved, bson.M{"$set": bson.M{
+		"status":      models.ProductStatusReserved,
+		"buyer_id":    buyerID,
+		"reserved_at": now,
+		"updated_at":  now,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Product reserved", "product_id", productID, "buyer_id", buyerID)
+	return updated, nil
+}
+
+// MarkProductSold records a sale and tells everyone else who saved the
+// listing or was negotiating over it
+func (s *MarketplaceService) MarkProductSold(ctx context.Context, productID, sellerID primitive.ObjectID, req models.MarkProductSoldRequest) (*models.Product, error) {
+	var buyerID *primitive.ObjectID
+	if req.BuyerID != "" {
+		id, err := parseBuyer(req.BuyerID, sellerID)
+		if err != nil {
+			return nil, err
+		}
+		buyerID = &id
+	}
+
+	product, err := s.sellerListing(ctx, productID, sellerID, models.ProductStatusSold)
+	if err != nil {
+		return nil, err
+	}
+	if buyerID == nil && product.Status == models.ProductStatusReserved {
+		buyerID = product.BuyerID
+	}
+
+	now := time.Now()
+	set := bson.M{
+		"status":     models.ProductStatusSold,
+		"sold_at":    now,
+		"updated_at": now,
+	}
+	update := bson.M{"$set": set}
+	if buyerID != nil {
+		set["buyer_id"] = *buyerID
+	} else {
+		update["$unset"] = bson.M{"buyer_id": ""}
+	}
+
+	updated, err := s.transition(ctx, productID, models.ProductStatusSold, update)
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.IncrementProductsSold()
+	if s.notifications != nil {
+		go s.notifyListingSold(context.WithoutCancel(ctx), updated)
+	}
+	s.logger.Info("Product marked as sold", "product_id", productID, "user_id", sellerID)
+	return updated, nil
+}
+
+// RelistProduct puts a reserved, sold or archived listing back on sale
+func (s *MarketplaceService) RelistProduct(ctx context.Context, productID, sellerID primitive.ObjectID) (*models.Product, error) {
+	if _, err := s.sellerListing(ctx, productID, sellerID, models.ProductStatusAvailable); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	updated, err := s.transition(ctx, productID, models.ProductStatusAvailable, bson.M{
+		"$set": bson.M{
+			"status":      models.ProductStatusAvailable,
+			"relisted_at": now,
+			"updated_at":  now,
+		},
+		"$unset": bson.M{"buyer_id": "", "reserved_at": "", "sold_at": ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Product relisted", "product_id", productID, "user_id", sellerID)
+	return updated, nil
+}
+
+// GetSoldListings pages through a seller's sold items, most recent sale first
+func (s *MarketplaceService) GetSoldListings(ctx context.Context, sellerID primitive.ObjectID, page, limit int64) (*MarketplaceListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := models.ProductFilter{SoldBy: &sellerID, SortBy: "sold_at", Page: page, Limit: limit}
+	products, total, err := s.repo.ListProducts(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to list sold listings", "error", err, "seller_id", sellerID)
+		return nil, err
+	}
+
+	return &MarketplaceListResponse{
+		Products: products,
+		Total:    total,
+		Page:     page,
+		Limit:    limit,
+	}, nil
+}
+
+// notifyListingSold tells savers and buyers with open offers, other than the
+// buyer, that the listing is gone. It returns how many were sent.
+func (s *MarketplaceService) notifyListingSold(ctx context.Context, product *models.Product) int {
+	recipients := map[primitive.ObjectID]bool{}
+	for _, id := range product.SavedBy {
+		recipients[id] = true
+	}
+	negotiating, err := s.repo.GetPendingOfferBuyers(ctx, product.ID)
+	if err != nil {
+		s.logger.Warn("Failed to load buyers with open offers", "error", err, "product_id", product.ID)
+	}
+	for _, id := range negotiating {
+		recipients[id] = true
+	}
+	delete(recipients, product.SellerID)
+	if product.BuyerID != nil {
+		delete(recipients, *product.BuyerID)
+	}
+
+	sent := 0
+	for userID := range recipients {
+		notification := &models.Notification{
+			ID:          primitive.NewObjectID(),
+			RecipientID: userID,
+			SenderID:    product.SellerID,
+			Type:        models.NotificationTypeListingSold,
+			TargetID:    product.ID,
+			TargetType:  "product",
+			Content:     product.Title + " has been sold",
+			Data: map[string]interface{}{
+				"product_id": product.ID.Hex(),
+			},
+			CreatedAt: time.Now(),
+		}
+		if s.publishNotification(ctx, notification) {
+			sent++
+		}
+	}
+	return sent
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestProductStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to models.ProductStatus
+		allowed  bool
+	}{
+		{models.ProductStatusAvailable, models.ProductStatusReserved, true},
+		{models.ProductStatusReserved, models.ProductStatusSold, true},
+		{models.ProductStatusSold, models.ProductStatusAvailable, true},
+		{models.ProductStatusArchived, models.ProductStatusAvailable, true},
+		{models.ProductStatusSold, models.ProductStatusReserved, false},
+		{models.ProductStatusReserved, models.ProductStatusReserved, false},
+		{models.ProductStatusArchived, models.ProductStatusSold, false},
+		{models.ProductStatusAvailable, models.ProductStatusAvailable, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.allowed, tt.from.CanTransitionTo(tt.to), "%s -> %s", tt.from, tt.to)
+	}
+}
+
+func TestMarketplaceService_ReserveProduct(t *testing.T) {
+	t.Run("reserves for the buyer", func(t *testing.T) {
+		product := newPriceDropFixture()
+		buyerID := primitive.NewObjectID()
+		mockRepo := new(MockMarketplaceRepository)
+		service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+		reserved := *product
+		reserved.Status = models.ProductStatusReserved
+		reserved.BuyerID = &buyerID
+		mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+		mockRepo.On("TransitionProduct", mock.Anything, product.ID, models.TransitionSources(models.ProductStatusReserved), mock.MatchedBy(func(u bson.M) bool {
+			set := u["$set"].(bson.M)
+			return set["status"] == models.ProductStatusReserved && set["buyer_id"] == buyerID
+		})).Return(&reserved, nil)
+
+		updated, err := service.ReserveProduct(context.Background(), product.ID, product.SellerID, models.ReserveProductRequest{BuyerID: buyerID.Hex()})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.ProductStatusReserved, updated.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("seller cannot reserve for themselves", func(t *testing.T) {
+		product := newPriceDropFixture()
+		service := NewMarketplaceService(new(MockMarketplaceRepository), nil, slog.Default(), nil, nil)
+
+		_, err := service.ReserveProduct(context.Background(), product.ID, product.SellerID, models.ReserveProductRequest{BuyerID: product.SellerID.Hex()})
+
+		assert.ErrorIs(t, err, validation.ErrInvalidBuyer)
+	})
+
+	t.Run("sold listing", func(t *testing.T) {
+		product := newPriceDropFixture()
+		product.Status = models.ProductStatusSold
+		mockRepo := new(MockMarketplaceRepository)
+		service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+		mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+
+		_, err := service.ReserveProduct(context.Background(), product.ID, product.SellerID, models.ReserveProductRequest{BuyerID: primitive.NewObjectID().Hex()})
+
+		assert.ErrorIs(t, err, ErrInvalidTransition)
+		mockRepo.AssertNotCalled(t, "TransitionProduct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("lost a race", func(t *testing.T) {
+		product := newPriceDropFixture()
+		mockRepo := new(MockMarketplaceRepository)
+		service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+		mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+		mockRepo.On("TransitionProduct", mock.Anything, product.ID, mock.Anything, mock.Anything).Return(nil, nil)
+
+		_, err := service.ReserveProduct(context.Background(), product.ID, product.SellerID, models.ReserveProductRequest{BuyerID: primitive.NewObjectID().Hex()})
+
+		assert.ErrorIs(t, err, ErrInvalidTransition)
+	})
+}
+
+func TestMarketplaceService_MarkProductSold_UsesReservedBuyer(t *testing.T) {
+	product := newPriceDropFixture()
+	buyerID := primitive.NewObjectID()
+	product.Status = models.ProductStatusReserved
+	product.BuyerID = &buyerID
+	mockRepo := new(MockMarketplaceRepository)
+	service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+	sold := *product
+	sold.Status = models.ProductStatusSold
+	mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+	mockRepo.On("TransitionProduct", mock.Anything, product.ID, models.TransitionSources(models.ProductStatusSold), mock.MatchedBy(func(u bson.M) bool {
+		set := u["$set"].(bson.M)
+		return set["status"] == models.ProductStatusSold && set["buyer_id"] == buyerID && set["sold_at"] != nil
+	})).Return(&sold, nil)
+
+	updated, err := service.MarkProductSold(context.Background(), product.ID, product.SellerID, models.MarkProductSoldRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ProductStatusSold, updated.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMarketplaceService_NotifyListingSold(t *testing.T) {
+	product := newPriceDropFixture()
+	buyerID := primitive.NewObjectID()
+	saver := primitive.NewObjectID()
+	negotiator := primitive.NewObjectID()
+	product.Status = models.ProductStatusSold
+	product.BuyerID = &buyerID
+	product.SavedBy = []primitive.ObjectID{saver, buyerID, product.SellerID}
+
+	mockRepo := new(MockMarketplaceRepository)
+	publisher := newRecordingNotificationPublisher()
+	service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+	service.SetNotificationPublisher(publisher)
+
+	mockRepo.On("GetPendingOfferBuyers", mock.Anything, product.ID).Return([]primitive.ObjectID{negotiator, saver, buyerID}, nil)
+
+	sent := service.notifyListingSold(context.Background(), product)
+
+	assert.Equal(t, 2, sent)
+	assert.ElementsMatch(t, []primitive.ObjectID{saver, negotiator}, publisher.recipients())
+}
+
+func TestMarketplaceService_RelistProduct(t *testing.T) {
+	product := newPriceDropFixture()
+	product.Status = models.ProductStatusSold
+	mockRepo := new(MockMarketplaceRepository)
+	service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+	relisted := *product
+	relisted.Status = models.ProductStatusAvailable
+	mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+	mockRepo.On("TransitionProduct", mock.Anything, product.ID, models.TransitionSources(models.ProductStatusAvailable), mock.MatchedBy(func(u bson.M) bool {
+		unset := u["$unset"].(bson.M)
+		_, clearsBuyer := unset["buyer_id"]
+		return u["$set"].(bson.M)["status"] == models.ProductStatusAvailable && clearsBuyer
+	})).Return(&relisted, nil)
+
+	updated, err := service.RelistProduct(context.Background(), product.ID, product.SellerID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ProductStatusAvailable, updated.Status)
+
+	_, err = service.RelistProduct(context.Background(), product.ID, primitive.NewObjectID())
+	assert.EqualError(t, err, "unauthorized")
+}
+
+func TestMarketplaceService_GetSoldListings(t *testing.T) {
+	sellerID := primitive.NewObjectID()
+	mockRepo := new(MockMarketplaceRepository)
+	service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+	mockRepo.On("ListProducts", mock.Anything, mock.MatchedBy(func(f models.ProductFilter) bool {
+		return f.SoldBy != nil && *f.SoldBy == sellerID && f.SortBy == "sold_at" && f.Limit == 20
+	})).Return([]models.ProductResponse{{Title: "Road bike", Status: models.ProductStatusSold}}, int64(1), nil)
+
+	list, err := service.GetSoldListings(context.Background(), sellerID, 0, 500)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), list.Total)
+	assert.Equal(t, int64(1), list.Page)
+	mockRepo.AssertExpectations(t)
+}
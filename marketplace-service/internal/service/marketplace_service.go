@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,11 +29,47 @@ type MarketplaceRepository interface {
 	GetCategories(ctx context.Context) ([]models.Category, error)
 	CreateProduct(ctx context.Context, product *models.Product) (*models.Product, error)
 	GetProductByID(ctx context.Context, id primitive.ObjectID) (*models.Product, error)
+	FindProducts(ctx context.Context, filter bson.M) ([]models.Product, error)
 	ListProducts(ctx context.Context, filter models.ProductFilter) ([]models.ProductResponse, int64, error)
 	GetMarketplaceConversations(ctx context.Context, userID primitive.ObjectID) ([]models.ConversationSummary, error)
 	UpdateProduct(ctx context.Context, id primitive.ObjectID, update bson.M) (*models.Product, error)
 	DeleteProduct(ctx context.Context, id primitive.ObjectID) error
 	IncrementViews(ctx context.Context, id primitive.ObjectID) error
+	GetProductFacets(ctx context.Context, filter models.ProductFilter) (*models.ProductFacets, error)
+	CreateSavedSearch(ctx context.Context, search *models.SavedProductSearch) error
+	CountSavedSearches(ctx context.Context, userID primitive.ObjectID) (int64, error)
+	GetSavedSearches(ctx context.Context, userID primitive.ObjectID) ([]models.SavedProductSearch, error)
+	DeleteSavedSearch(ctx context.Context, id, userID primitive.ObjectID) (bool, error)
+	CreateOffer(ctx context.Context, offer *models.Offer) (bool, error)
+	GetOfferByID(ctx context.Context, id primitive.ObjectID) (*models.Offer, error)
+	GetPendingOffer(ctx context.Context, productID, buyerID primitive.ObjectID) (*models.Offer, error)
+	UpdatePendingOffer(ctx context.Context, id, proposedBy primitive.ObjectID, update bson.M) (*models.Offer, error)
+	GetOffers(ctx context.Context, userID primitive.ObjectID, productID *primitive.ObjectID) ([]models.Offer, error)
+	GetAllOffers(ctx context.Context, userID primitive.ObjectID) ([]models.Offer, error)
+	SetListingSaved(ctx context.Context, productID, userID primitive.ObjectID, saved bool) error
+	GetAlertPreferences(ctx context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]models.MarketplaceAlertPreferences, error)
+	UpsertAlertPreferences(ctx context.Context, prefs *models.MarketplaceAlertPreferences) error
+	TransitionProduct(ctx context.Context, id primitive.ObjectID, from []models.ProductStatus, update bson.M) (*models.Product, error)
+	GetPendingOfferBuyers(ctx context.Context, productID primitive.ObjectID) ([]primitive.ObjectID, error)
+	DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error)
+}
+
+const (
+	// defaultSearchRadiusKm is used when a search gives a point but no radius
+	defaultSearchRadiusKm = 25
+	maxSavedSearches      = 20
+)
+
+var (
+	ErrSavedSearchNotFound  = errors.New("saved search not found")
+	ErrTooManySavedSearches = errors.New("saved search limit reached")
+	ErrProductSold          = errors.New("sold listings cannot be changed")
+)
+
+// SearchIndexer publishes listing changes for search-service to index
+type SearchIndexer interface {
+	UpsertListing(ctx context.Context, doc *models.SearchListingDocument)
+	Delete(ctx context.Context, entity models.SearchEntityType, id string)
 }
 
 type MarketplaceService struct {
@@ -42,6 +79,9 @@ type MarketplaceService struct {
 	cb            *resilience.CircuitBreaker
 	producer      *kafka.Writer
 	categoryCache *CategoryCache
+	search        SearchIndexer
+	offers        OfferPublisher
+	notifications NotificationPublisher
 }
 
 func NewMarketplaceService(
@@ -66,6 +106,17 @@ func NewMarketplaceService(
 	}
 }
 
+// SetSearchIndexer enables publishing listing changes to the search index
+func (s *MarketplaceService) SetSearchIndexer(search SearchIndexer) {
+	s.search = search
+}
+
+func (s *MarketplaceService) indexListing(ctx context.Context, product *models.Product) {
+	if s.search != nil && product != nil {
+		s.search.UpsertListing(ctx, models.NewSearchListingDocument(product))
+	}
+}
+
 func (s *MarketplaceService) GetCategories(ctx context.Context) ([]models.Category, error) {
 	// Check cache first
 	s.categoryCache.RLock()
@@ -114,9 +165,15 @@ func (s *MarketplaceService) CreateProduct(ctx context.Context, userID primitive
 		Location:    models.ProductLocation{City: req.Location},
 		Status:      models.ProductStatusAvailable,
 		Tags:        req.Tags,
+		Condition:   req.Condition,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
+	if req.Latitude != nil && req.Longitude != nil {
+		product.Location.Latitude = *req.Latitude
+		product.Location.Longitude = *req.Longitude
+		product.Coordinates = []float64{*req.Longitude, *req.Latitude}
+	}
 
 	createdProduct, err := s.repo.CreateProduct(ctx, product)
 	if err != nil {
@@ -125,6 +182,7 @@ func (s *MarketplaceService) CreateProduct(ctx context.Context, userID primitive
 	}
 
 	s.metrics.IncrementProductsCreated()
+	s.indexListing(ctx, createdProduct)
 	s.logger.Info("Product created", "product_id", createdProduct.ID, "user_id", userID)
 
 	return createdProduct, nil
@@ -206,6 +264,7 @@ func (s *MarketplaceService) GetProductByID(ctx context.Context, id primitive.Ob
 		Images:      product.Images,
 		Location:    product.Location,
 		Status:      product.Status,
+		Condition:   product.Condition,
 		Tags:        product.Tags,
 		Views:       product.Views,
 		CreatedAt:   product.CreatedAt,
@@ -227,7 +286,28 @@ type MarketplaceListResponse struct {
 	Limit    int64                    `json:"limit"`
 }
 
+// MarketplaceSearchResponse is a page of search results with facets for refining them
+type MarketplaceSearchResponse struct {
+	MarketplaceListResponse
+	Facets *models.ProductFacets `json:"facets"`
+}
+
+// prepareFilter validates a search and fills in its defaults
+func prepareFilter(filter *models.ProductFilter) error {
+	if err := validation.ValidateProductFilter(filter); err != nil {
+		return err
+	}
+	if filter.HasLocation() && filter.RadiusKm == 0 {
+		filter.RadiusKm = defaultSearchRadiusKm
+	}
+	return nil
+}
+
 func (s *MarketplaceService) SearchProducts(ctx context.Context, filter models.ProductFilter) (*MarketplaceListResponse, error) {
+	if err := prepareFilter(&filter); err != nil {
+		return nil, err
+	}
+
 	products, total, err := s.repo.ListProducts(ctx, filter)
 	if err != nil {
 		s.logger.Error("Failed to search products", "error", err)
@@ -246,28 +326,91 @@ func (s *MarketplaceService) GetMarketplaceConversations(ctx context.Context, us
 	return s.repo.GetMarketplaceConversations(ctx, userID)
 }
 
-func (s *MarketplaceService) MarkProductSold(ctx context.Context, productID, userID primitive.ObjectID) error {
+// UpdateProduct changes the fields a seller sent. Lowering the price alerts
+// the users who saved the listing.
+func (s *MarketplaceService) UpdateProduct(ctx context.Context, productID, userID primitive.ObjectID, req models.UpdateProductRequest) (*models.Product, error) {
+	if err := validation.ValidateUpdateProductRequest(&req); err != nil {
+		return nil, err
+	}
+
 	product, err := s.repo.GetProductByID(ctx, productID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
 	if product.SellerID != userID {
-		return errors.New("unauthorized")
+		return nil, errors.New("unauthorized")
+	}
+	if product.Status == models.ProductStatusSold {
+		return nil, ErrProductSold
+	}
+	// A reservation ends through MarkProductSold or RelistProduct
+	if product.Status == models.ProductStatusReserved && req.Status != nil {
+		return nil, ErrInvalidTransition
+	}
+
+	update := bson.M{}
+	if title := strings.TrimSpace(req.Title); title != "" {
+		update["title"] = title
+	}
+	if req.Description != "" {
+		update["description"] = req.Description
+	}
+	if req.Price != nil {
+		update["price"] = *req.Price
+	}
+	if req.Currency != "" {
+		update["currency"] = req.Currency
 	}
+	if len(req.Images) > 0 {
+		update["images"] = req.Images
+	}
+	if req.Location != "" {
+		update["location.city"] = req.Location
+	}
+	if req.Tags != nil {
+		update["tags"] = req.Tags
+	}
+	if req.Status != nil {
+		update["status"] = *req.Status
+	}
+	if req.CategoryID != "" {
+		category, err := s.findCategory(ctx, req.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		update["category_id"] = category.ID
+		update["category_name"] = category.Name
+		update["category_slug"] = category.Slug
+		update["category_icon"] = category.Icon
+	}
+	if len(update) == 0 {
+		return product, nil
+	}
+	update["updated_at"] = time.Now()
 
-	_, err = s.repo.UpdateProduct(ctx, productID, bson.M{
-		"status":     models.ProductStatusSold,
-		"updated_at": time.Now(),
-	})
+	updated, err := s.repo.UpdateProduct(ctx, productID, update)
 	if err != nil {
-		s.logger.Error("Failed to mark product sold", "error", err, "product_id", productID)
-		return err
+		s.logger.Error("Failed to update product", "error", err, "product_id", productID)
+		return nil, err
 	}
+	s.indexListing(ctx, updated)
+	s.watchPriceDrop(ctx, product, updated)
 
-	s.metrics.IncrementProductsSold()
-	s.logger.Info("Product marked as sold", "product_id", productID, "user_id", userID)
-	return nil
+	s.logger.Info("Product updated", "product_id", productID, "user_id", userID)
+	return updated, nil
+}
+
+func (s *MarketplaceService) findCategory(ctx context.Context, categoryID string) (*models.Category, error) {
+	categories, err := s.GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		if category.ID.Hex() == categoryID {
+			return &category, nil
+		}
+	}
+	return nil, validation.ErrInvalidCategory
 }
 
 func (s *MarketplaceService) DeleteProduct(ctx context.Context, productID, userID primitive.ObjectID) error {
@@ -285,6 +428,9 @@ func (s *MarketplaceService) DeleteProduct(ctx context.Context, productID, userI
 	}
 
 	s.metrics.IncrementProductsDeleted()
+	if s.search != nil {
+		s.search.Delete(ctx, models.SearchEntityListing, productID.Hex())
+	}
 	s.logger.Info("Product deleted", "product_id", productID, "user_id", userID)
 	return nil
 }
@@ -303,19 +449,73 @@ func (s *MarketplaceService) ToggleSaveProduct(ctx context.Context, productID, u
 		}
 	}
 
-	var update bson.M
-	if isSaved {
-		update = bson.M{"$pull": bson.M{"saved_by": userID}}
-	} else {
-		update = bson.M{"$addToSet": bson.M{"saved_by": userID}}
-	}
-	update["updated_at"] = time.Now()
-
-	_, err = s.repo.UpdateProduct(ctx, productID, update)
-	if err != nil {
+	if err := s.repo.SetListingSaved(ctx, productID, userID, !isSaved); err != nil {
 		s.logger.Error("Failed to toggle save product", "error", err, "product_id", productID)
 		return false, err
 	}
 
 	return !isSaved, nil
 }
+
+// SearchListings searches listings like SearchProducts, adding category,
+// condition and price facets
+func (s *MarketplaceService) SearchListings(ctx context.Context, filter models.ProductFilter) (*MarketplaceSearchResponse, error) {
+	if err := prepareFilter(&filter); err != nil {
+		return nil, err
+	}
+	list, err := s.SearchProducts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	facets, err := s.repo.GetProductFacets(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to compute search facets", "error", err)
+		return nil, err
+	}
+
+	return &MarketplaceSearchResponse{
+		MarketplaceListResponse: *list,
+		Facets:                  facets,
+	}, nil
+}
+
+func (s *MarketplaceService) SaveSearch(ctx context.Context, userID primitive.ObjectID, req models.SaveProductSearchRequest) (*models.SavedProductSearch, error) {
+	if err := validation.ValidateSaveProductSearchRequest(&req); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountSavedSearches(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxSavedSearches {
+		return nil, ErrTooManySavedSearches
+	}
+
+	search := &models.SavedProductSearch{
+		UserID: userID,
+		Name:   strings.TrimSpace(req.Name),
+		Filter: req.Filter,
+	}
+	if err := s.repo.CreateSavedSearch(ctx, search); err != nil {
+		s.logger.Error("Failed to save search", "error", err, "user_id", userID)
+		return nil, err
+	}
+	return search, nil
+}
+
+func (s *MarketplaceService) GetSavedSearches(ctx context.Context, userID primitive.ObjectID) ([]models.SavedProductSearch, error) {
+	return s.repo.GetSavedSearches(ctx, userID)
+}
+
+func (s *MarketplaceService) DeleteSavedSearch(ctx context.Context, searchID, userID primitive.ObjectID) error {
+	deleted, err := s.repo.DeleteSavedSearch(ctx, searchID, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrSavedSearchNotFound
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/metrics"
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -67,6 +68,111 @@ func (m *MockMarketplaceRepository) IncrementViews(ctx context.Context, id primi
 	return args.Error(0)
 }
 
+func (m *MockMarketplaceRepository) GetProductFacets(ctx context.Context, filter models.ProductFilter) (*models.ProductFacets, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductFacets), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) CreateSavedSearch(ctx context.Context, search *models.SavedProductSearch) error {
+	args := m.Called(ctx, search)
+	return args.Error(0)
+}
+
+func (m *MockMarketplaceRepository) CountSavedSearches(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) GetSavedSearches(ctx context.Context, userID primitive.ObjectID) ([]models.SavedProductSearch, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]models.SavedProductSearch), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) DeleteSavedSearch(ctx context.Context, id, userID primitive.ObjectID) (bool, error) {
+	args := m.Called(ctx, id, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) CreateOffer(ctx context.Context, offer *models.Offer) (bool, error) {
+	args := m.Called(ctx, offer)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) GetOfferByID(ctx context.Context, id primitive.ObjectID) (*models.Offer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Offer), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) GetPendingOffer(ctx context.Context, productID, buyerID primitive.ObjectID) (*models.Offer, error) {
+	args := m.Called(ctx, productID, buyerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Offer), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) UpdatePendingOffer(ctx context.Context, id, proposedBy primitive.ObjectID, update bson.M) (*models.Offer, error) {
+	args := m.Called(ctx, id, proposedBy, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Offer), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) GetOffers(ctx context.Context, userID primitive.ObjectID, productID *primitive.ObjectID) ([]models.Offer, error) {
+	args := m.Called(ctx, userID, productID)
+	return args.Get(0).([]models.Offer), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) GetAllOffers(ctx context.Context, userID primitive.ObjectID) ([]models.Offer, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]models.Offer), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) FindProducts(ctx context.Context, filter bson.M) ([]models.Product, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.Product), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) SetListingSaved(ctx context.Context, productID, userID primitive.ObjectID, saved bool) error {
+	args := m.Called(ctx, productID, userID, saved)
+	return args.Error(0)
+}
+
+func (m *MockMarketplaceRepository) GetAlertPreferences(ctx context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]models.MarketplaceAlertPreferences, error) {
+	args := m.Called(ctx, userIDs)
+	return args.Get(0).(map[primitive.ObjectID]models.MarketplaceAlertPreferences), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) UpsertAlertPreferences(ctx context.Context, prefs *models.MarketplaceAlertPreferences) error {
+	args := m.Called(ctx, prefs)
+	return args.Error(0)
+}
+
+func (m *MockMarketplaceRepository) DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) TransitionProduct(ctx context.Context, id primitive.ObjectID, from []models.ProductStatus, update bson.M) (*models.Product, error) {
+	args := m.Called(ctx, id, from, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) GetPendingOfferBuyers(ctx context.Context, productID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	args := m.Called(ctx, productID)
+	return args.Get(0).([]primitive.ObjectID), args.Error(1)
+}
+
 func TestMarketplaceService_CreateProduct(t *testing.T) {
 	mockRepo := new(MockMarketplaceRepository)
 	businessMetrics := metrics.NewBusinessMetrics()
@@ -152,10 +258,109 @@ func TestMarketplaceService_MarkProductSold_Unauthorized(t *testing.T) {
 
 	mockRepo.On("GetProductByID", mock.Anything, productID).Return(existingProduct, nil)
 
-	err := service.MarkProductSold(context.Background(), productID, userID)
+	_, err := service.MarkProductSold(context.Background(), productID, userID, models.MarkProductSoldRequest{})
 
 	assert.Error(t, err)
 	assert.Equal(t, "unauthorized", err.Error())
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestMarketplaceService_SearchListings_DefaultRadius(t *testing.T) {
+	mockRepo := new(MockMarketplaceRepository)
+	service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+	lat, lng := 23.8103, 90.4125
+	filter := models.ProductFilter{
+		Latitude:   &lat,
+		Longitude:  &lng,
+		Conditions: []models.ProductCondition{models.ProductConditionLikeNew},
+		SortBy:     "distance",
+		Page:       1,
+		Limit:      20,
+	}
+	withRadius := mock.MatchedBy(func(f models.ProductFilter) bool {
+		return f.RadiusKm == defaultSearchRadiusKm
+	})
+	facets := &models.ProductFacets{
+		Categories: []models.FacetCount{{Value: primitive.NewObjectID().Hex(), Label: "Electronics", Count: 3}},
+		Conditions: []models.FacetCount{{Value: "like_new", Count: 3}},
+		PriceRange: &models.PriceRange{Min: 10, Max: 200},
+	}
+
+	mockRepo.On("ListProducts", mock.Anything, withRadius).Return([]models.ProductResponse{{Title: "Bike"}}, int64(1), nil)
+	mockRepo.On("GetProductFacets", mock.Anything, withRadius).Return(facets, nil)
+
+	resp, err := service.SearchListings(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Total)
+	assert.Len(t, resp.Products, 1)
+	assert.Equal(t, facets, resp.Facets)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMarketplaceService_SearchListings_InvalidFilter(t *testing.T) {
+	mockRepo := new(MockMarketplaceRepository)
+	service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+	resp, err := service.SearchListings(context.Background(), models.ProductFilter{SortBy: "distance"})
+
+	assert.ErrorIs(t, err, validation.ErrInvalidSearch)
+	assert.Nil(t, resp)
+	mockRepo.AssertNotCalled(t, "ListProducts")
+	mockRepo.AssertNotCalled(t, "GetProductFacets")
+}
+
+func TestMarketplaceService_SaveSearch(t *testing.T) {
+	userID := primitive.NewObjectID()
+	maxPrice := 500.0
+	req := models.SaveProductSearchRequest{
+		Name:   "  Cheap bikes ",
+		Filter: models.ProductFilter{Query: "bike", MaxPrice: &maxPrice},
+	}
+
+	t.Run("saves search", func(t *testing.T) {
+		mockRepo := new(MockMarketplaceRepository)
+		service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+		mockRepo.On("CountSavedSearches", mock.Anything, userID).Return(int64(3), nil)
+		mockRepo.On("CreateSavedSearch", mock.Anything, mock.MatchedBy(func(s *models.SavedProductSearch) bool {
+			return s.UserID == userID && s.Name == "Cheap bikes" && s.Filter.Query == "bike"
+		})).Return(nil)
+
+		search, err := service.SaveSearch(context.Background(), userID, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Cheap bikes", search.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		mockRepo := new(MockMarketplaceRepository)
+		service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+		mockRepo.On("CountSavedSearches", mock.Anything, userID).Return(int64(maxSavedSearches), nil)
+
+		search, err := service.SaveSearch(context.Background(), userID, req)
+
+		assert.ErrorIs(t, err, ErrTooManySavedSearches)
+		assert.Nil(t, search)
+		mockRepo.AssertNotCalled(t, "CreateSavedSearch")
+	})
+}
+
+func TestMarketplaceService_DeleteSavedSearch_NotFound(t *testing.T) {
+	mockRepo := new(MockMarketplaceRepository)
+	service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+	searchID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	mockRepo.On("DeleteSavedSearch", mock.Anything, searchID, userID).Return(false, nil)
+
+	err := service.DeleteSavedSearch(context.Background(), searchID, userID)
+
+	assert.ErrorIs(t, err, ErrSavedSearchNotFound)
+	mockRepo.AssertExpectations(t)
+}
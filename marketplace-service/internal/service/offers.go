@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// defaultOfferExpiry is how long an offer waits when no expiry is given
+const defaultOfferExpiry = 48 * time.Hour
+
+var (
+	ErrOfferNotFound      = errors.New("offer not found")
+	ErrOfferExists        = errors.New("you already have a pending offer on this listing")
+	ErrOfferNotPending    = errors.New("offer is no longer pending")
+	ErrOfferAwaitingReply = errors.New("waiting for the other party to respond")
+	ErrProductUnavailable = errors.New("product is no longer available")
+)
+
+// OfferPublisher publishes offer changes for the messaging service to post
+// as cards in the buyer and seller's marketplace thread
+type OfferPublisher interface {
+	PublishOfferEvent(ctx context.Context, evt *models.MarketplaceOfferEvent) error
+}
+
+// SetOfferPublisher enables offer cards in marketplace conversations
+func (s *MarketplaceService) SetOfferPublisher(offers OfferPublisher) {
+	s.offers = offers
+}
+
+func offerExpiry(hours int, now time.Time) time.Time {
+	if hours == 0 {
+		return now.Add(defaultOfferExpiry)
+	}
+	return now.Add(time.Duration(hours) * time.Hour)
+}
+
+// MakeOffer opens a negotiation on a listing. The seller responds next.
+func (s *MarketplaceService) MakeOffer(ctx context.Context, productID, buyerID primitive.ObjectID, req models.MakeOfferRequest) (*models.Offer, error) {
+	if err := validation.ValidateMakeOfferRequest(&req); err != nil {
+		return nil, err
+	}
+
+	product, err := s.repo.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.SellerID == buyerID {
+		return nil, errors.New("unauthorized")
+	}
+	if product.Status != models.ProductStatusAvailable {
+		return nil, ErrProductUnavailable
+	}
+
+	// An expired offer still holds the pending slot until it is noticed
+	existing, err := s.repo.GetPendingOffer(ctx, productID, buyerID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if _, expired := s.expireIfDue(ctx, existing, product); !expired {
+			return nil, ErrOfferExists
+		}
+	}
+
+	now := time.Now()
+	message := strings.TrimSpace(req.Message)
+	offer := &models.Offer{
+		ProductID:      productID,
+		BuyerID:        buyerID,
+		SellerID:       product.SellerID,
+		ConversationID: utils.GetConversationID(buyerID, product.SellerID),
+		Amount:         req.Amount,
+		Currency:       product.Currency,
+		Status:         models.OfferStatusPending,
+		ProposedBy:     buyerID,
+		Rounds:         []models.OfferRound{{ProposedBy: buyerID, Amount: req.Amount, Message: message, CreatedAt: now}},
+		ExpiresAt:      offerExpiry(req.ExpiresInHours, now),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	created, err := s.repo.CreateOffer(ctx, offer)
+	if err != nil {
+		s.logger.Error("Failed to create offer", "error", err, "product_id", productID, "user_id", buyerID)
+		return nil, err
+	}
+	if !created {
+		return nil, ErrOfferExists
+	}
+
+	s.publishOffer(ctx, models.OfferActionMade, offer, product, buyerID)
+	s.logger.Info("Offer made", "offer_id", offer.ID, "product_id", productID, "user_id", buyerID)
+	return offer, nil
+}
+
+// CounterOffer replaces the amount on a pending offer and hands the decision
+// back to the other party
+func (s *MarketplaceService) CounterOffer(ctx context.Context, offerID, userID primitive.ObjectID, req models.CounterOfferRequest) (*models.Offer, error) {
+	if err := validation.ValidateCounterOfferRequest(&req); err != nil {
+		return nil, err
+	}
+
+	offer, product, err := s.respondableOffer(ctx, offerID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	updated, err := s.repo.UpdatePendingOffer(ctx, offer.ID, offer.ProposedBy, bson.M{
+		"$set": bson.M{
+			"amount":      req.Amount,
+			"proposed_by": userID,
+			"expires_at":  offerExpiry(req.ExpiresInHours, now),
+			"updated_at":  now,
+		},
+		"$push": bson.M{"rounds": models.OfferRound{
+			ProposedBy: userID,
+			Amount:     req.Amount,
+			Message:    strings.TrimSpace(req.Message),
+			CreatedAt:  now,
+		}},
+	})
+	if err != nil {
+		s.logger.Error("Failed to counter offer", "error", err, "offer_id", offerID)
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrOfferNotPending
+	}
+
+	s.publishOffer(ctx, models.OfferActionCountered, updated, product, userID)
+	return updated, nil
+}
+
+// AcceptOffer agrees to the current amount. The listing is reserved for the
+// buyer so no one else can buy it while the sale is completed.
+func (s *MarketplaceService) AcceptOffer(ctx context.Context, offerID, userID primitive.ObjectID) (*models.Offer, error) {
+	offer, product, err := s.respondableOffer(ctx, offerID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdatePendingOffer(ctx, offer.ID, offer.ProposedBy, bson.M{
+		"$set": bson.M{"status": models.OfferStatusAccepted, "updated_at": time.Now()},
+	})
+	if err != nil {
+		s.logger.Error("Failed to accept offer", "error", err, "offer_id", offerID)
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrOfferNotPending
+	}
+
+	now := time.Now()
+	if _, err := s.transition(ctx, product.ID, models.ProductStatusReserved, bson.M{"$set": bson.M{
+		"status":      models.ProductStatusReserved,
+		"buyer_id":    offer.BuyerID,
+		"reserved_at": now,
+		"updated_at":  now,
+	}}); err != nil {
+		s.logger.Error("Failed to reserve product after accepted offer", "error", err, "product_id", product.ID)
+	}
+
+	s.publishOffer(ctx, models.OfferActionAccepted, updated, product, userID)
+	s.logger.Info("Offer accepted", "offer_id", offerID, "product_id", product.ID, "user_id", userID)
+	return updated, nil
+}
+
+// DeclineOffer ends a negotiation without a sale
+func (s *MarketplaceService) DeclineOffer(ctx context.Context, offerID, userID primitive.ObjectID) (*models.Offer, error) {
+	offer, err := s.participantOffer(ctx, offerID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.Status != models.OfferStatusPending {
+		return nil, ErrOfferNotPending
+	}
+	if offer.ProposedBy == userID {
+		return nil, ErrOfferAwaitingReply
+	}
+	product := s.offerProduct(ctx, offer)
+	if _, expired := s.expireIfDue(ctx, offer, product); expired {
+		return nil, ErrOfferNotPending
+	}
+
+	updated, err := s.repo.UpdatePendingOffer(ctx, offer.ID, offer.ProposedBy, bson.M{
+		"$set": bson.M{"status": models.OfferStatusDeclined, "updated_at": time.Now()},
+	})
+	if err != nil {
+		s.logger.Error("Failed to decline offer", "error", err, "offer_id", offerID)
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrOfferNotPending
+	}
+
+	s.publishOffer(ctx, models.OfferActionDeclined, updated, product, userID)
+	return updated, nil
+}
+
+// GetOffer returns an offer to its buyer or seller
+func (s *MarketplaceService) GetOffer(ctx context.Context, offerID, userID primitive.ObjectID) (*models.Offer, error) {
+	offer, err := s.participantOffer(ctx, offerID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.settleExpiry(ctx, offer), nil
+}
+
+// GetOffers returns the offers a user made or received, optionally for one listing
+func (s *MarketplaceService) GetOffers(ctx context.Context, userID primitive.ObjectID, productID *primitive.ObjectID) ([]models.Offer, error) {
+	offers, err := s.repo.GetOffers(ctx, userID, productID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range offers {
+		offers[i] = *s.settleExpiry(ctx, &offers[i])
+	}
+	return offers, nil
+}
+
+// participantOffer loads an offer, hiding it from anyone but its buyer and seller
+func (s *MarketplaceService) participantOffer(ctx context.Context, offerID, userID primitive.ObjectID) (*models.Offer, error) {
+	offer, err := s.repo.GetOfferByID(ctx, offerID)
+	if err != nil {
+		return nil, err
+	}
+	if offer == nil || !offer.IsParticipant(userID) {
+		return nil, ErrOfferNotFound
+	}
+	return offer, nil
+}
+
+// respondableOffer loads a pending offer the user may counter or accept,
+// along with its listing, which must still be for sale
+func (s *MarketplaceService) respondableOffer(ctx context.Context, offerID, userID primitive.ObjectID) (*models.Offer, *models.Product, error) {
+	offer, err := s.participantOffer(ctx, offerID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if offer.Status != models.OfferStatusPending {
+		return nil, nil, ErrOfferNotPending
+	}
+	if offer.ProposedBy == userID {
+		return nil, nil, ErrOfferAwaitingReply
+	}
+
+	product, err := s.repo.GetProductByID(ctx, offer.ProductID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, expired := s.expireIfDue(ctx, offer, product); expired {
+		return nil, nil, ErrOfferNotPending
+	}
+	if product.Status != models.ProductStatusAvailable {
+		return nil, nil, ErrProductUnavailable
+	}
+	return offer, product, nil
+}
+
+// settleExpiry expires a pending offer whose time is up, for read paths that
+// have not loaded its listing yet
+func (s *MarketplaceService) settleExpiry(ctx context.Context, offer *models.Offer) *models.Offer {
+	if offer.Status != models.OfferStatusPending || time.Now().Before(offer.ExpiresAt) {
+		return offer
+	}
+	if expired, ok := s.expireIfDue(ctx, offer, s.offerProduct(ctx, offer)); ok {
+		return expired
+	}
+	return offer
+}
+
+// offerProduct loads an offer's listing for its cards. Offers outlive deleted
+// listings, which still need to be declined or expired.
+func (s *MarketplaceService) offerProduct(ctx context.Context, offer *models.Offer) *models.Product {
+	product, err := s.repo.GetProductByID(ctx, offer.ProductID)
+	if err != nil {
+		return &models.Product{ID: offer.ProductID, Currency: offer.Currency}
+	}
+	return product
+}
+
+// expireIfDue marks a pending offer expired once its time is up. Offers
+// expire lazily, the first time anyone touches them afterwards. It reports
+// whether the offer is expired, returning it as stored.
+func (s *MarketplaceService) expireIfDue(ctx context.Context, offer *models.Offer, product *models.Product) (*models.Offer, bool) {
+	if offer.Status != models.OfferStatusPending || time.Now().Before(offer.ExpiresAt) {
+		return offer, offer.Status == models.OfferStatusExpired
+	}
+
+	expired, err := s.repo.UpdatePendingOffer(ctx, offer.ID, offer.ProposedBy, bson.M{
+		"$set": bson.M{"status": models.OfferStatusExpired, "updated_at": time.Now()},
+	})
+	if err != nil {
+		s.logger.Warn("Failed to expire offer", "error", err, "offer_id", offer.ID)
+		return offer, true
+	}
+	if expired == nil {
+		// Someone else settled it first; they published the card
+		return offer, true
+	}
+
+	s.publishOffer(ctx, models.OfferActionExpired, expired, product, offer.ProposedBy)
+	return expired, true
+}
+
+func (s *MarketplaceService) publishOffer(ctx context.Context, action models.OfferAction, offer *models.Offer, product *models.Product, actorID primitive.ObjectID) {
+	if s.offers == nil {
+		return
+	}
+
+	evt := &models.MarketplaceOfferEvent{
+		Action: action,
+		Offer:  *offer,
+		Product: models.MessageProduct{
+			ID:       product.ID,
+			Title:    product.Title,
+			Price:    product.Price,
+			Currency: product.Currency,
+			Images:   product.Images,
+			Status:   string(product.Status),
+		},
+		ActorID:   actorID,
+		Timestamp: time.Now(),
+	}
+
+	// The offer is already stored, so a lost card is logged rather than failing the request
+	publishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.offers.PublishOfferEvent(publishCtx, evt); err != nil {
+		s.logger.Error("Failed to publish offer event", "error", err, "offer_id", offer.ID, "action", action)
+	}
+}
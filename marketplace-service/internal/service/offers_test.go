@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type recordingOfferPublisher struct {
+	events []*models.MarketplaceOfferEvent
+}
+
+func (p *recordingOfferPublisher) PublishOfferEvent(ctx context.Context, evt *models.MarketplaceOfferEvent) error {
+	p.events = append(p.events, evt)
+	return nil
+}
+
+func (p *recordingOfferPublisher) actions() []models.OfferAction {
+	var actions []models.OfferAction
+	for _, evt := range p.events {
+		actions = append(actions, evt.Action)
+	}
+	return actions
+}
+
+func newOfferService() (*MarketplaceService, *MockMarketplaceRepository, *recordingOfferPublisher) {
+	mockRepo := new(MockMarketplaceRepository)
+	publisher := &recordingOfferPublisher{}
+	service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+	service.SetOfferPublisher(publisher)
+	return service, mockRepo, publisher
+}
+
+func newOfferFixture() (*models.Product, *models.Offer) {
+	sellerID := primitive.NewObjectID()
+	buyerID := primitive.NewObjectID()
+	product := &models.Product{
+		ID:       primitive.NewObjectID(),
+		SellerID: sellerID,
+		Title:    "Road bike",
+		Price:    300,
+		Currency: "USD",
+		Status:   models.ProductStatusAvailable,
+	}
+	offer := &models.Offer{
+		ID:         primitive.NewObjectID(),
+		ProductID:  product.ID,
+		BuyerID:    buyerID,
+		SellerID:   sellerID,
+		Amount:     250,
+		Currency:   "USD",
+		Status:     models.OfferStatusPending,
+		ProposedBy: buyerID,
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+	return product, offer
+}
+
+func TestMarketplaceService_MakeOffer(t *testing.T) {
+	req := models.MakeOfferRequest{Amount: 250, Message: " Cash today "}
+
+	t.Run("opens negotiation", func(t *testing.T) {
+		service, mockRepo, publisher := newOfferService()
+		product, _ := newOfferFixture()
+		buyerID := primitive.NewObjectID()
+
+		mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+		mockRepo.On("GetPendingOffer", mock.Anything, product.ID, buyerID).Return(nil, nil)
+		mockRepo.On("CreateOffer", mock.Anything, mock.MatchedBy(func(o *models.Offer) bool {
+			return o.BuyerID == buyerID && o.SellerID == product.SellerID && o.ProposedBy == buyerID
+		})).Return(true, nil)
+
+		offer, err := service.MakeOffer(context.Background(), product.ID, buyerID, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.OfferStatusPending, offer.Status)
+		assert.Equal(t, "USD", offer.Currency)
+		assert.Equal(t, utils.GetConversationID(buyerID, product.SellerID), offer.ConversationID)
+		assert.Equal(t, "Cash today", offer.Rounds[0].Message)
+		assert.WithinDuration(t, time.Now().Add(defaultOfferExpiry), offer.ExpiresAt, time.Minute)
+		assert.Equal(t, []models.OfferAction{models.OfferActionMade}, publisher.actions())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("seller cannot offer on own listing", func(t *testing.T) {
+		service, mockRepo, publisher := newOfferService()
+		product, _ := newOfferFixture()
+
+		mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+
+		offer, err := service.MakeOffer(context.Background(), product.ID, product.SellerID, req)
+
+		assert.EqualError(t, err, "unauthorized")
+		assert.Nil(t, offer)
+		assert.Empty(t, publisher.events)
+	})
+
+	t.Run("pending offer already exists", func(t *testing.T) {
+		service, mockRepo, _ := newOfferService()
+		product, existing := newOfferFixture()
+
+		mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+		mockRepo.On("GetPendingOffer", mock.Anything, product.ID, existing.BuyerID).Return(existing, nil)
+
+		_, err := service.MakeOffer(context.Background(), product.ID, existing.BuyerID, req)
+
+		assert.ErrorIs(t, err, ErrOfferExists)
+		mockRepo.AssertNotCalled(t, "CreateOffer")
+	})
+
+	t.Run("invalid amount", func(t *testing.T) {
+		service, mockRepo, _ := newOfferService()
+
+		_, err := service.MakeOffer(context.Background(), primitive.NewObjectID(), primitive.NewObjectID(), models.MakeOfferRequest{Amount: -1})
+
+		assert.ErrorIs(t, err, validation.ErrInvalidOffer)
+		mockRepo.AssertNotCalled(t, "GetProductByID")
+	})
+}
+
+func TestMarketplaceService_CounterOffer(t *testing.T) {
+	req := models.CounterOfferRequest{Amount: 280}
+
+	t.Run("seller counters", func(t *testing.T) {
+		service, mockRepo, publisher := newOfferService()
+		product, offer := newOfferFixture()
+		countered := *offer
+		countered.Amount = 280
+		countered.ProposedBy = offer.SellerID
+
+		mockRepo.On("GetOfferByID", mock.Anything, offer.ID).Return(offer, nil)
+		mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+		mockRepo.On("UpdatePendingOffer", mock.Anything, offer.ID, offer.BuyerID, mock.MatchedBy(func(u bson.M) bool {
+			set := u["$set"].(bson.M)
+			return set["amount"] == 280.0 && set["proposed_by"] == offer.SellerID
+		})).Return(&countered, nil)
+
+		updated, err := service.CounterOffer(context.Background(), offer.ID, offer.SellerID, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 280.0, updated.Amount)
+		assert.Equal(t, []models.OfferAction{models.OfferActionCountered}, publisher.actions())
+	})
+
+	t.Run("proposer waits for a reply", func(t *testing.T) {
+		service, mockRepo, _ := newOfferService()
+		_, offer := newOfferFixture()
+
+		mockRepo.On("GetOfferByID", mock.Anything, offer.ID).Return(offer, nil)
+
+		_, err := service.CounterOffer(context.Background(), offer.ID, offer.BuyerID, req)
+
+		assert.ErrorIs(t, err, ErrOfferAwaitingReply)
+		mockRepo.AssertNotCalled(t, "UpdatePendingOffer")
+	})
+
+	t.Run("stranger cannot see offer", func(t *testing.T) {
+		service, mockRepo, _ := newOfferService()
+		_, offer := newOfferFixture()
+
+		mockRepo.On("GetOfferByID", mock.Anything, offer.ID).Return(offer, nil)
+
+		_, err := service.CounterOffer(context.Background(), offer.ID, primitive.NewObjectID(), req)
+
+		assert.ErrorIs(t, err, ErrOfferNotFound)
+	})
+
+	t.Run("response raced", func(t *testing.T) {
+		service, mockRepo, publisher := newOfferService()
+		product, offer := newOfferFixture()
+
+		mockRepo.On("GetOfferByID", mock.Anything, offer.ID).Return(offer, nil)
+		mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+		mockRepo.On("UpdatePendingOffer", mock.Anything, offer.ID, offer.BuyerID, mock.Anything).Return(nil, nil)
+
+		_, err := service.CounterOffer(context.Background(), offer.ID, offer.SellerID, req)
+
+		assert.ErrorIs(t, err, ErrOfferNotPending)
+		assert.Empty(t, publisher.events)
+	})
+}
+
+func TestMarketplaceService_AcceptOffer(t *testing.T) {
+	t.Run("seller accepts", func(t *testing.T) {
+		service, mockRepo, publisher := newOfferService()
+		product, offer := newOfferFixture()
+		accepted := *offer
+		accepted.Status = models.OfferStatusAccepted
+
+		mockRepo.On("GetOfferByID", mock.Anything, offer.ID).Return(offer, nil)
+		mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+		mockRepo.On("UpdatePendingOffer", mock.Anything, offer.ID, offer.BuyerID, mock.Anything).Return(&accepted, nil)
+		mockRepo.On("TransitionProduct", mock.Anything, product.ID, models.TransitionSources(models.ProductStatusReserved), mock.MatchedBy(func(u bson.M) bool {
+			set := u["$set"].(bson.M)
+			return set["status"] == models.ProductStatusReserved && set["buyer_id"] == offer.BuyerID
+		})).Return(product, nil)
+
+		updated, err := service.AcceptOffer(context.Background(), offer.ID, offer.SellerID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.OfferStatusAccepted, updated.Status)
+		assert.Equal(t, []models.OfferAction{models.OfferActionAccepted}, publisher.actions())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("expired offer", func(t *testing.T) {
+		service, mockRepo, publisher := newOfferService()
+		product, offer := newOfferFixture()
+		offer.ExpiresAt = time.Now().Add(-time.Minute)
+		expired := *offer
+		expired.Status = models.OfferStatusExpired
+
+		mockRepo.On("GetOfferByID", mock.Anything, offer.ID).Return(offer, nil)
+		mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+		mockRepo.On("UpdatePendingOffer", mock.Anything, offer.ID, offer.BuyerID, mock.MatchedBy(func(u bson.M) bool {
+			return u["$set"].(bson.M)["status"] == models.OfferStatusExpired
+		})).Return(&expired, nil)
+
+		_, err := service.AcceptOffer(context.Background(), offer.ID, offer.SellerID)
+
+		assert.ErrorIs(t, err, ErrOfferNotPending)
+		assert.Equal(t, []models.OfferAction{models.OfferActionExpired}, publisher.actions())
+		mockRepo.AssertNotCalled(t, "UpdateProduct")
+	})
+
+	t.Run("listing no longer available", func(t *testing.T) {
+		service, mockRepo, _ := newOfferService()
+		product, offer := newOfferFixture()
+		product.Status = models.ProductStatusSold
+
+		mockRepo.On("GetOfferByID", mock.Anything, offer.ID).Return(offer, nil)
+		mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+
+		_, err := service.AcceptOffer(context.Background(), offer.ID, offer.SellerID)
+
+		assert.ErrorIs(t, err, ErrProductUnavailable)
+		mockRepo.AssertNotCalled(t, "UpdatePendingOffer")
+	})
+}
+
+func TestMarketplaceService_DeclineOffer(t *testing.T) {
+	service, mockRepo, publisher := newOfferService()
+	product, offer := newOfferFixture()
+	declined := *offer
+	declined.Status = models.OfferStatusDeclined
+
+	mockRepo.On("GetOfferByID", mock.Anything, offer.ID).Return(offer, nil)
+	mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+	mockRepo.On("UpdatePendingOffer", mock.Anything, offer.ID, offer.BuyerID, mock.Anything).Return(&declined, nil)
+
+	updated, err := service.DeclineOffer(context.Background(), offer.ID, offer.SellerID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.OfferStatusDeclined, updated.Status)
+	assert.Equal(t, []models.OfferAction{models.OfferActionDeclined}, publisher.actions())
+}
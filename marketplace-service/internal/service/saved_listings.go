@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// priceDropBatchSize bounds how many savers' preferences are loaded at once
+const priceDropBatchSize = 500
+
+// NotificationPublisher hands notifications to the notification pipeline
+type NotificationPublisher interface {
+	PublishNotification(ctx context.Context, notification *models.Notification) error
+}
+
+// SetNotificationPublisher enables price-drop and sold alerts for interested buyers
+func (s *MarketplaceService) SetNotificationPublisher(notifications NotificationPublisher) {
+	s.notifications = notifications
+}
+
+func (s *MarketplaceService) SaveListing(ctx context.Context, productID, userID primitive.ObjectID) error {
+	return s.repo.SetListingSaved(ctx, productID, userID, true)
+}
+
+func (s *MarketplaceService) UnsaveListing(ctx context.Context, productID, userID primitive.ObjectID) error {
+	return s.repo.SetListingSaved(ctx, productID, userID, false)
+}
+
+// GetSavedListings pages through the listings a user saved, newest first.
+// Sold and archived listings stay in the list so users see what happened.
+func (s *MarketplaceService) GetSavedListings(ctx context.Context, userID primitive.ObjectID, page, limit int64) (*MarketplaceListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := models.ProductFilter{SavedBy: &userID, Page: page, Limit: limit}
+	products, total, err := s.repo.ListProducts(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to list saved listings", "error", err, "user_id", userID)
+		return nil, err
+	}
+	for i := range products {
+		products[i].IsSaved = true
+	}
+
+	return &MarketplaceListResponse{
+		Products: products,
+		Total:    total,
+		Page:     page,
+		Limit:    limit,
+	}, nil
+}
+
+func (s *MarketplaceService) GetAlertPreferences(ctx context.Context, userID primitive.ObjectID) (*models.MarketplaceAlertPreferences, error) {
+	prefs, err := s.repo.GetAlertPreferences(ctx, []primitive.ObjectID{userID})
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := prefs[userID]; ok {
+		return &p, nil
+	}
+	defaults := models.DefaultMarketplaceAlertPreferences(userID)
+	return &defaults, nil
+}
+
+func (s *MarketplaceService) UpdateAlertPreferences(ctx context.Context, userID primitive.ObjectID, req models.UpdateMarketplaceAlertPreferencesRequest) (*models.MarketplaceAlertPreferences, error) {
+	if err := validation.ValidateAlertPreferencesRequest(&req); err != nil {
+		return nil, err
+	}
+
+	prefs, err := s.GetAlertPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.PriceDrop != nil {
+		prefs.PriceDrop = *req.PriceDrop
+	}
+	if req.MinDropPercent != nil {
+		prefs.MinDropPercent = *req.MinDropPercent
+	}
+	prefs.UpdatedAt = time.Now()
+
+	if err := s.repo.UpsertAlertPreferences(ctx, prefs); err != nil {
+		s.logger.Error("Failed to update alert preferences", "error", err, "user_id", userID)
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// watchPriceDrop alerts savers in the background when an update lowered
+// the price of a listing that is still for sale
+func (s *MarketplaceService) watchPriceDrop(ctx context.Context, before, after *models.Product) {
+	if s.notifications == nil || after == nil || len(after.SavedBy) == 0 {
+		return
+	}
+	if after.Price >= before.Price || after.Status != models.ProductStatusAvailable {
+		return
+	}
+	go s.notifyPriceDrop(context.WithoutCancel(ctx), before.Price, after)
+}
+
+// notifyPriceDrop sends a price-drop notification to each saver whose
+// preferences allow it and returns how many were sent
+func (s *MarketplaceService) notifyPriceDrop(ctx context.Context, oldPrice float64, product *models.Product) int {
+	if oldPrice <= 0 {
+		return 0
+	}
+	dropPercent := (oldPrice - product.Price) / oldPrice * 100
+	content := fmt.Sprintf("Price dropped on %s: %s %.2f → %s %.2f",
+		product.Title, product.Currency, oldPrice, product.Currency, product.Price)
+
+	sent := 0
+	for start := 0; start < len(product.SavedBy); start += priceDropBatchSize {
+		end := min(start+priceDropBatchSize, len(product.SavedBy))
+		savers := product.SavedBy[start:end]
+
+		prefs, err := s.repo.GetAlertPreferences(ctx, savers)
+		if err != nil {
+			s.logger.Error("Failed to load alert preferences", "error", err, "product_id", product.ID)
+			continue
+		}
+
+		for _, userID := range savers {
+			if userID == product.SellerID {
+				continue
+			}
+			p, ok := prefs[userID]
+			if !ok {
+				p = models.DefaultMarketplaceAlertPreferences(userID)
+			}
+			if !p.PriceDrop || dropPercent < p.MinDropPercent {
+				continue
+			}
+
+			notification := &models.Notification{
+				ID:          primitive.NewObjectID(),
+				RecipientID: userID,
+				SenderID:    product.SellerID,
+				Type:        models.NotificationTypePriceDrop,
+				TargetID:    product.ID,
+				TargetType:  "product",
+				Content:     content,
+				Data: map[string]interface{}{
+					"product_id": product.ID.Hex(),
+					"old_price":  oldPrice,
+					"new_price":  product.Price,
+					"currency":   product.Currency,
+				},
+				CreatedAt: time.Now(),
+			}
+
+			if s.publishNotification(ctx, notification) {
+				sent++
+			}
+		}
+	}
+
+	s.logger.Info("Price drop alerts sent", "product_id", product.ID, "sent", sent)
+	return sent
+}
+
+// publishNotification reports whether the notification was handed to the pipeline
+func (s *MarketplaceService) publishNotification(ctx context.Context, notification *models.Notification) bool {
+	publishCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := s.notifications.PublishNotification(publishCtx, notification); err != nil {
+		s.logger.Error("Failed to publish notification", "error", err, "type", notification.Type, "user_id", notification.RecipientID)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/marketplace-service/internal/validation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type recordingNotificationPublisher struct {
+	sent chan *models.Notification
+}
+
+func newRecordingNotificationPublisher() *recordingNotificationPublisher {
+	return &recordingNotificationPublisher{sent: make(chan *models.Notification, 10)}
+}
+
+func (p *recordingNotificationPublisher) PublishNotification(ctx context.Context, notification *models.Notification) error {
+	p.sent <- notification
+	return nil
+}
+
+func (p *recordingNotificationPublisher) recipients() []primitive.ObjectID {
+	var ids []primitive.ObjectID
+	for len(p.sent) > 0 {
+		ids = append(ids, (<-p.sent).RecipientID)
+	}
+	return ids
+}
+
+func newPriceDropFixture() *models.Product {
+	sellerID := primitive.NewObjectID()
+	return &models.Product{
+		ID:       primitive.NewObjectID(),
+		SellerID: sellerID,
+		Title:    "Road bike",
+		Price:    250,
+		Currency: "USD",
+		Status:   models.ProductStatusAvailable,
+	}
+}
+
+func TestMarketplaceService_NotifyPriceDrop(t *testing.T) {
+	product := newPriceDropFixture()
+	defaulted := primitive.NewObjectID()
+	optedOut := primitive.NewObjectID()
+	wantsBigDrops := primitive.NewObjectID()
+	wantsSmallDrops := primitive.NewObjectID()
+	product.SavedBy = []primitive.ObjectID{defaulted, optedOut, wantsBigDrops, wantsSmallDrops, product.SellerID}
+
+	mockRepo := new(MockMarketplaceRepository)
+	publisher := newRecordingNotificationPublisher()
+	service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+	service.SetNotificationPublisher(publisher)
+
+	mockRepo.On("GetAlertPreferences", mock.Anything, product.SavedBy).Return(map[primitive.ObjectID]models.MarketplaceAlertPreferences{
+		optedOut:        {UserID: optedOut, PriceDrop: false},
+		wantsBigDrops:   {UserID: wantsBigDrops, PriceDrop: true, MinDropPercent: 25},
+		wantsSmallDrops: {UserID: wantsSmallDrops, PriceDrop: true, MinDropPercent: 10},
+	}, nil)
+
+	// 300 -> 250 is a 16.7% drop
+	sent := service.notifyPriceDrop(context.Background(), 300, product)
+
+	assert.Equal(t, 2, sent)
+	assert.ElementsMatch(t, []primitive.ObjectID{defaulted, wantsSmallDrops}, publisher.recipients())
+}
+
+func TestMarketplaceService_NotifyPriceDrop_Content(t *testing.T) {
+	product := newPriceDropFixture()
+	saver := primitive.NewObjectID()
+	product.SavedBy = []primitive.ObjectID{saver}
+
+	mockRepo := new(MockMarketplaceRepository)
+	publisher := newRecordingNotificationPublisher()
+	service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+	service.SetNotificationPublisher(publisher)
+
+	mockRepo.On("GetAlertPreferences", mock.Anything, product.SavedBy).
+		Return(map[primitive.ObjectID]models.MarketplaceAlertPreferences{}, nil)
+
+	assert.Equal(t, 1, service.notifyPriceDrop(context.Background(), 300, product))
+
+	notification := <-publisher.sent
+	assert.Equal(t, models.NotificationTypePriceDrop, notification.Type)
+	assert.Equal(t, product.ID, notification.TargetID)
+	assert.Equal(t, product.SellerID, notification.SenderID)
+	assert.Equal(t, "Price dropped on Road bike: USD 300.00 → USD 250.00", notification.Content)
+	assert.Equal(t, 300.0, notification.Data["old_price"])
+	assert.Equal(t, 250.0, notification.Data["new_price"])
+}
+
+func TestMarketplaceService_UpdateProduct(t *testing.T) {
+	price := 250.0
+	req := models.UpdateProductRequest{Title: " Road bike, serviced ", Price: &price}
+
+	t.Run("price drop alerts savers", func(t *testing.T) {
+		before := newPriceDropFixture()
+		before.Price = 300
+		saver := primitive.NewObjectID()
+		before.SavedBy = []primitive.ObjectID{saver}
+		after := *before
+		after.Price = price
+
+		mockRepo := new(MockMarketplaceRepository)
+		publisher := newRecordingNotificationPublisher()
+		service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+		service.SetNotificationPublisher(publisher)
+
+		mockRepo.On("GetProductByID", mock.Anything, before.ID).Return(before, nil)
+		mockRepo.On("UpdateProduct", mock.Anything, before.ID, mock.MatchedBy(func(update bson.M) bool {
+			return update["title"] == "Road bike, serviced" && update["price"] == price
+		})).Return(&after, nil)
+		mockRepo.On("GetAlertPreferences", mock.Anything, before.SavedBy).
+			Return(map[primitive.ObjectID]models.MarketplaceAlertPreferences{}, nil)
+
+		updated, err := service.UpdateProduct(context.Background(), before.ID, before.SellerID, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, price, updated.Price)
+		select {
+		case notification := <-publisher.sent:
+			assert.Equal(t, saver, notification.RecipientID)
+		case <-time.After(time.Second):
+			t.Fatal("expected a price drop notification")
+		}
+	})
+
+	t.Run("only the seller can update", func(t *testing.T) {
+		product := newPriceDropFixture()
+		mockRepo := new(MockMarketplaceRepository)
+		service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+		mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+
+		_, err := service.UpdateProduct(context.Background(), product.ID, primitive.NewObjectID(), req)
+
+		assert.EqualError(t, err, "unauthorized")
+		mockRepo.AssertNotCalled(t, "UpdateProduct", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("sold listings are locked", func(t *testing.T) {
+		product := newPriceDropFixture()
+		product.Status = models.ProductStatusSold
+		mockRepo := new(MockMarketplaceRepository)
+		service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+		mockRepo.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+
+		_, err := service.UpdateProduct(context.Background(), product.ID, product.SellerID, req)
+
+		assert.ErrorIs(t, err, ErrProductSold)
+	})
+
+	t.Run("invalid price", func(t *testing.T) {
+		service := NewMarketplaceService(new(MockMarketplaceRepository), nil, slog.Default(), nil, nil)
+		zero := 0.0
+
+		_, err := service.UpdateProduct(context.Background(), primitive.NewObjectID(), primitive.NewObjectID(), models.UpdateProductRequest{Price: &zero})
+
+		assert.ErrorIs(t, err, validation.ErrPriceInvalid)
+	})
+}
+
+func TestMarketplaceService_WatchPriceDrop_IgnoresIncreases(t *testing.T) {
+	before := newPriceDropFixture()
+	before.SavedBy = []primitive.ObjectID{primitive.NewObjectID()}
+	after := *before
+	after.Price = before.Price + 50
+
+	publisher := newRecordingNotificationPublisher()
+	service := NewMarketplaceService(new(MockMarketplaceRepository), nil, slog.Default(), nil, nil)
+	service.SetNotificationPublisher(publisher)
+
+	service.watchPriceDrop(context.Background(), before, &after)
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, publisher.recipients())
+}
+
+func TestMarketplaceService_UpdateAlertPreferences(t *testing.T) {
+	userID := primitive.NewObjectID()
+	mockRepo := new(MockMarketplaceRepository)
+	service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+	mockRepo.On("GetAlertPreferences", mock.Anything, []primitive.ObjectID{userID}).
+		Return(map[primitive.ObjectID]models.MarketplaceAlertPreferences{}, nil)
+	mockRepo.On("UpsertAlertPreferences", mock.Anything, mock.MatchedBy(func(p *models.MarketplaceAlertPreferences) bool {
+		return p.UserID == userID && p.PriceDrop && p.MinDropPercent == 15
+	})).Return(nil)
+
+	minDrop := 15.0
+	prefs, err := service.UpdateAlertPreferences(context.Background(), userID, models.UpdateMarketplaceAlertPreferencesRequest{MinDropPercent: &minDrop})
+
+	assert.NoError(t, err)
+	assert.True(t, prefs.PriceDrop, "unset fields keep their defaults")
+	assert.Equal(t, 15.0, prefs.MinDropPercent)
+	mockRepo.AssertExpectations(t)
+
+	tooHigh := 95.0
+	_, err = service.UpdateAlertPreferences(context.Background(), userID, models.UpdateMarketplaceAlertPreferencesRequest{MinDropPercent: &tooHigh})
+	assert.ErrorIs(t, err, validation.ErrInvalidMinDropPercent)
+}
+
+func TestMarketplaceService_SaveListing_NotFound(t *testing.T) {
+	productID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	mockRepo := new(MockMarketplaceRepository)
+	service := NewMarketplaceService(mockRepo, nil, slog.Default(), nil, nil)
+
+	mockRepo.On("SetListingSaved", mock.Anything, productID, userID, true).Return(errors.New("product not found"))
+
+	err := service.SaveListing(context.Background(), productID, userID)
+
+	assert.EqualError(t, err, "product not found")
+}
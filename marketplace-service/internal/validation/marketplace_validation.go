@@ -2,9 +2,11 @@ package validation
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 var (
@@ -14,9 +16,48 @@ var (
 	ErrPriceInvalid       = errors.New("price must be greater than zero")
 	ErrCurrencyRequired   = errors.New("currency is required")
 	ErrImagesRequired     = errors.New("at least one image is required")
+	ErrImageURLEmpty      = errors.New("image URL cannot be empty")
 	ErrLocationRequired   = errors.New("location is required")
 	ErrCategoryRequired   = errors.New("category ID is required")
 	ErrInvalidTags        = errors.New("too many tags (max 10)")
+	ErrInvalidCondition   = errors.New("invalid product condition")
+	ErrInvalidCoordinates = errors.New("latitude and longitude must be given together and within range")
+	ErrInvalidCategory    = errors.New("invalid category ID")
+	ErrInvalidBuyer       = errors.New("buyer must be a valid user other than the seller")
+	// Sold is set through MarkProductSold, which records the sale
+	ErrInvalidStatus         = errors.New("status must be available, pending or archived")
+	ErrInvalidMinDropPercent = fmt.Errorf("min_drop_percent must be between 0 and %d", MaxMinDropPercent)
+)
+
+// IsProductError reports whether err is a listing validation error
+func IsProductError(err error) bool {
+	for _, target := range []error{
+		ErrTitleRequired, ErrTitleTooLong, ErrDescriptionTooLong, ErrPriceInvalid,
+		ErrCurrencyRequired, ErrImagesRequired, ErrImageURLEmpty, ErrLocationRequired, ErrCategoryRequired,
+		ErrInvalidTags, ErrInvalidCondition, ErrInvalidCoordinates, ErrInvalidCategory, ErrInvalidStatus, ErrInvalidBuyer,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxSearchRadiusKm caps how far around a point a search may reach
+const MaxSearchRadiusKm = 500
+
+// ErrInvalidSearch is wrapped by every search filter error
+var ErrInvalidSearch = errors.New("invalid search")
+
+var (
+	ErrSearchPriceRange  = fmt.Errorf("%w: min_price must not exceed max_price", ErrInvalidSearch)
+	ErrSearchCondition   = fmt.Errorf("%w: unknown condition", ErrInvalidSearch)
+	ErrSearchCategory    = fmt.Errorf("%w: invalid category_id", ErrInvalidSearch)
+	ErrSearchLocation    = fmt.Errorf("%w: lat and lng must be given together and within range", ErrInvalidSearch)
+	ErrSearchRadius      = fmt.Errorf("%w: radius_km must be between 0 and %d", ErrInvalidSearch, MaxSearchRadiusKm)
+	ErrSearchSort        = fmt.Errorf("%w: unknown sort_by", ErrInvalidSearch)
+	ErrSearchSortNoPoint = fmt.Errorf("%w: sorting by distance needs lat and lng", ErrInvalidSearch)
+	ErrSearchNameInvalid = fmt.Errorf("%w: name is required and must be 100 characters or less", ErrInvalidSearch)
 )
 
 // ValidateCreateProductRequest validates a create product request
@@ -53,7 +94,7 @@ func ValidateCreateProductRequest(req *models.CreateProductRequest) error {
 	// Check empty strings in images
 	for _, img := range req.Images {
 		if strings.TrimSpace(img) == "" {
-			return errors.New("image URL cannot be empty")
+			return ErrImageURLEmpty
 		}
 	}
 
@@ -72,5 +113,148 @@ func ValidateCreateProductRequest(req *models.CreateProductRequest) error {
 		return ErrInvalidTags
 	}
 
+	// Condition and coordinates are optional
+	if req.Condition != "" && !req.Condition.IsValid() {
+		return ErrInvalidCondition
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return ErrInvalidCoordinates
+	}
+	if req.Latitude != nil && !validCoordinates(*req.Latitude, *req.Longitude) {
+		return ErrInvalidCoordinates
+	}
+
+	return nil
+}
+
+// ValidateUpdateProductRequest validates the fields a seller is changing
+func ValidateUpdateProductRequest(req *models.UpdateProductRequest) error {
+	if req.Title != "" && len(strings.TrimSpace(req.Title)) > 200 {
+		return ErrTitleTooLong
+	}
+	if len(req.Description) > 5000 {
+		return ErrDescriptionTooLong
+	}
+	if req.Price != nil && *req.Price <= 0 {
+		return ErrPriceInvalid
+	}
+	if req.CategoryID != "" && !primitive.IsValidObjectID(req.CategoryID) {
+		return ErrInvalidCategory
+	}
+	for _, img := range req.Images {
+		if strings.TrimSpace(img) == "" {
+			return ErrImageURLEmpty
+		}
+	}
+	if len(req.Tags) > 10 {
+		return ErrInvalidTags
+	}
+	if req.Status != nil {
+		switch *req.Status {
+		case models.ProductStatusAvailable, models.ProductStatusPending, models.ProductStatusArchived:
+		default:
+			return ErrInvalidStatus
+		}
+	}
+	return nil
+}
+
+// MaxMinDropPercent caps the smallest drop a user can ask to be alerted about
+const MaxMinDropPercent = 90
+
+// ValidateAlertPreferencesRequest validates a change to marketplace alert preferences
+func ValidateAlertPreferencesRequest(req *models.UpdateMarketplaceAlertPreferencesRequest) error {
+	if req.MinDropPercent != nil && (*req.MinDropPercent < 0 || *req.MinDropPercent > MaxMinDropPercent) {
+		return ErrInvalidMinDropPercent
+	}
+	return nil
+}
+
+// ValidateProductFilter validates search filters
+func ValidateProductFilter(filter *models.ProductFilter) error {
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return ErrSearchPriceRange
+	}
+
+	if filter.CategoryID != "" && !primitive.IsValidObjectID(filter.CategoryID) {
+		return ErrSearchCategory
+	}
+
+	for _, condition := range filter.Conditions {
+		if !condition.IsValid() {
+			return ErrSearchCondition
+		}
+	}
+
+	if (filter.Latitude == nil) != (filter.Longitude == nil) {
+		return ErrSearchLocation
+	}
+	if filter.HasLocation() && !validCoordinates(*filter.Latitude, *filter.Longitude) {
+		return ErrSearchLocation
+	}
+	if filter.RadiusKm < 0 || filter.RadiusKm > MaxSearchRadiusKm {
+		return ErrSearchRadius
+	}
+
+	switch filter.SortBy {
+	case "", "newest", "price_asc", "price_desc":
+	case "distance":
+		if !filter.HasLocation() {
+			return ErrSearchSortNoPoint
+		}
+	default:
+		return ErrSearchSort
+	}
+
+	return nil
+}
+
+// ValidateSaveProductSearchRequest validates a saved search before it is stored
+func ValidateSaveProductSearchRequest(req *models.SaveProductSearchRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return ErrSearchNameInvalid
+	}
+	return ValidateProductFilter(&req.Filter)
+}
+
+func validCoordinates(lat, lng float64) bool {
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
+
+// MaxOfferExpiryHours caps how long an offer can wait for a response
+const MaxOfferExpiryHours = 7 * 24
+
+// ErrInvalidOffer is wrapped by every offer validation error
+var ErrInvalidOffer = errors.New("invalid offer")
+
+var (
+	ErrOfferAmount         = fmt.Errorf("%w: amount must be greater than zero", ErrInvalidOffer)
+	ErrOfferExpiry         = fmt.Errorf("%w: expires_in_hours must be between 1 and %d", ErrInvalidOffer, MaxOfferExpiryHours)
+	ErrOfferMessageTooLong = fmt.Errorf("%w: message must be 500 characters or less", ErrInvalidOffer)
+)
+
+// ValidateMakeOfferRequest validates a buyer's opening offer
+func ValidateMakeOfferRequest(req *models.MakeOfferRequest) error {
+	return validateOfferTerms(req.Amount, req.ExpiresInHours, req.Message)
+}
+
+// ValidateCounterOfferRequest validates a counter to a pending offer
+func ValidateCounterOfferRequest(req *models.CounterOfferRequest) error {
+	return validateOfferTerms(req.Amount, req.ExpiresInHours, req.Message)
+}
+
+// validateOfferTerms checks the terms shared by offers and counters. An
+// expiry of zero means the default.
+func validateOfferTerms(amount float64, expiresInHours int, message string) error {
+	if amount <= 0 {
+		return ErrOfferAmount
+	}
+	if expiresInHours < 0 || expiresInHours > MaxOfferExpiryHours {
+		return ErrOfferExpiry
+	}
+	if len(strings.TrimSpace(message)) > 500 {
+		return ErrOfferMessageTooLong
+	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
@@ -129,4 +130,192 @@ func TestValidateCreateProductRequest(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestValidateProductFilter(t *testing.T) {
+	lat, lng := 23.8103, 90.4125
+	badLat := 91.0
+	low, high := 10.0, 100.0
+
+	tests := []struct {
+		name        string
+		filter      models.ProductFilter
+		expectedErr error
+	}{
+		{
+			name: "valid filter",
+			filter: models.ProductFilter{
+				Query:      "bike",
+				CategoryID: "507f1f77bcf86cd799439011",
+				Conditions: []models.ProductCondition{models.ProductConditionNew, models.ProductConditionFair},
+				MinPrice:   &low,
+				MaxPrice:   &high,
+				Latitude:   &lat,
+				Longitude:  &lng,
+				RadiusKm:   15,
+				SortBy:     "distance",
+			},
+		},
+		{
+			name:   "empty filter",
+			filter: models.ProductFilter{},
+		},
+		{
+			name:        "min price above max price",
+			filter:      models.ProductFilter{MinPrice: &high, MaxPrice: &low},
+			expectedErr: ErrSearchPriceRange,
+		},
+		{
+			name:        "invalid category",
+			filter:      models.ProductFilter{CategoryID: "electronics"},
+			expectedErr: ErrSearchCategory,
+		},
+		{
+			name:        "unknown condition",
+			filter:      models.ProductFilter{Conditions: []models.ProductCondition{"mint"}},
+			expectedErr: ErrSearchCondition,
+		},
+		{
+			name:        "latitude without longitude",
+			filter:      models.ProductFilter{Latitude: &lat},
+			expectedErr: ErrSearchLocation,
+		},
+		{
+			name:        "latitude out of range",
+			filter:      models.ProductFilter{Latitude: &badLat, Longitude: &lng},
+			expectedErr: ErrSearchLocation,
+		},
+		{
+			name:        "radius too large",
+			filter:      models.ProductFilter{Latitude: &lat, Longitude: &lng, RadiusKm: MaxSearchRadiusKm + 1},
+			expectedErr: ErrSearchRadius,
+		},
+		{
+			name:        "distance sort without a point",
+			filter:      models.ProductFilter{SortBy: "distance"},
+			expectedErr: ErrSearchSortNoPoint,
+		},
+		{
+			name:        "unknown sort",
+			filter:      models.ProductFilter{SortBy: "popular"},
+			expectedErr: ErrSearchSort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProductFilter(&tt.filter)
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				assert.ErrorIs(t, err, ErrInvalidSearch)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateMakeOfferRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         models.MakeOfferRequest
+		expectedErr error
+	}{
+		{
+			name: "valid offer",
+			req:  models.MakeOfferRequest{Amount: 80, ExpiresInHours: 24, Message: "Can pick up today"},
+		},
+		{
+			name: "default expiry",
+			req:  models.MakeOfferRequest{Amount: 80},
+		},
+		{
+			name:        "zero amount",
+			req:         models.MakeOfferRequest{Amount: 0},
+			expectedErr: ErrOfferAmount,
+		},
+		{
+			name:        "negative amount",
+			req:         models.MakeOfferRequest{Amount: -5},
+			expectedErr: ErrOfferAmount,
+		},
+		{
+			name:        "expiry too long",
+			req:         models.MakeOfferRequest{Amount: 80, ExpiresInHours: MaxOfferExpiryHours + 1},
+			expectedErr: ErrOfferExpiry,
+		},
+		{
+			name:        "message too long",
+			req:         models.MakeOfferRequest{Amount: 80, Message: strings.Repeat("a", 501)},
+			expectedErr: ErrOfferMessageTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMakeOfferRequest(&tt.req)
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				assert.ErrorIs(t, err, ErrInvalidOffer)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateUpdateProductRequest(t *testing.T) {
+	price := 120.0
+	zero := 0.0
+	sold := models.ProductStatusSold
+	archived := models.ProductStatusArchived
+
+	tests := []struct {
+		name        string
+		req         models.UpdateProductRequest
+		expectedErr error
+	}{
+		{
+			name: "price only",
+			req:  models.UpdateProductRequest{Price: &price},
+		},
+		{
+			name: "archive listing",
+			req:  models.UpdateProductRequest{Status: &archived},
+		},
+		{
+			name:        "zero price",
+			req:         models.UpdateProductRequest{Price: &zero},
+			expectedErr: ErrPriceInvalid,
+		},
+		{
+			name:        "sold through update",
+			req:         models.UpdateProductRequest{Status: &sold},
+			expectedErr: ErrInvalidStatus,
+		},
+		{
+			name:        "invalid category",
+			req:         models.UpdateProductRequest{CategoryID: "electronics"},
+			expectedErr: ErrInvalidCategory,
+		},
+		{
+			name:        "empty image URL",
+			req:         models.UpdateProductRequest{Images: []string{"https://example.com/a.jpg", " "}},
+			expectedErr: ErrImageURLEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUpdateProductRequest(&tt.req)
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
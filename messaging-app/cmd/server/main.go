@@ -6,10 +6,13 @@ import (
 
 	"messaging-app/config"
 	"messaging-app/internal/server"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/serviceauth"
 )
 
 func main() {
 	cfg := config.LoadConfig()
+	serviceauth.SetDefault(serviceauth.NewCredentials("messaging-app", cfg.ServiceAuthSecret))
 	metrics := config.GetMetrics()
 
 	ctx, cancel := context.WithCancel(context.Background())
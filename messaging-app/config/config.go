@@ -15,9 +15,14 @@ type Config struct {
 	MongoUser           string
 	MongoPassword       string
 	DBName              string
+	AuditDBName         string
 	KafkaBrokers        []string
 	JWTSecret           string
+	JWKSURL             string    // Key set access tokens are signed by, published by user-service
+	LegacyHS256Until    time.Time // Until then JWTSecret tokens are still accepted alongside
+	ServiceAuthSecret   string    // Signs the tokens presented to other services
 	ServerPort          string
+	GRPCPort            string // Serves internal RPCs such as data exports
 	KafkaTopic          string // General messages topic
 	UserUpdatedTopic    string
 	WebSocketPort       string
@@ -72,6 +77,83 @@ type Config struct {
 	ArchiveBucket       string
 	ArchiveCacheTTLMins int
 	JaegerOTLPEndpoint  string
+
+	// How long senders may edit a message; 0 allows edits at any time
+	MessageEditWindowMins int
+
+	// How deeply replies may nest under a post comment; raised to 2 if lower
+	ReplyMaxDepth int
+
+	// How often post, comment and reply counters are recounted; 0 turns the
+	// reconciler off
+	FeedCounterReconcileMins int
+
+	// Fetchers unfurling links in messages and posts; 0 turns link previews off
+	LinkPreviewWorkers int
+
+	// Hub fan-out: "redis" (pub/sub) or "kafka" (consumer group per hub instance)
+	HubFanoutMode        string
+	HubFanoutTopic       string
+	HubInstanceID        string
+	HubReplayWindowHours int
+
+	// Per-user journal of reactions, edits and read receipts that reconnecting
+	// clients replay from GET /ws/replay; a TTL of 0 turns it off
+	HubJournalTTLMinutes int
+	HubJournalMaxEvents  int
+
+	// Message search (disabled when no Elasticsearch URLs are set)
+	ElasticsearchURLs     []string
+	ElasticsearchUser     string
+	ElasticsearchPassword string
+	MessageSearchIndex    string
+	MessageSearchTopic    string
+	MessageSearchGroupID  string
+
+	// Marketplace offer events, posted as offer cards in marketplace conversations
+	MarketplaceOfferTopic   string
+	MarketplaceOfferGroupID string
+
+	// Broadcast channels: posts are delivered a page of subscribers per job
+	ChannelFanoutTopic    string
+	ChannelFanoutGroupID  string
+	ChannelFanoutPageSize int
+
+	// Push delivery (FCM for Android/web, APNs for iOS; disabled when neither is set)
+	PushTopic          string
+	PushGroupID        string
+	FCMProjectID       string
+	FCMCredentialsFile string
+	APNsKeyFile        string
+	APNsKeyID          string
+	APNsTeamID         string
+	APNsTopic          string
+	APNsProduction     bool
+
+	// Delivery canary: sends a direct message from CanarySenderID to
+	// CanaryReceiverID, who must be friends, every CanaryIntervalSecs and times
+	// its arrival at a loopback client; off unless both users are set
+	CanarySenderID         string
+	CanaryReceiverID       string
+	CanaryIntervalSecs     int
+	CanaryTimeoutSecs      int
+	CanaryLatencySLOMillis int
+	CanaryLossSLOPercent   float64
+
+	// Spam detection: a sender exceeding SpamMessagesPerMinute, messaging
+	// more than SpamRecipientsPer10Mins conversations, repeating one message
+	// more than SpamDuplicateLimit times or sending more than SpamMaxLinks
+	// links in a message scores points; enough of them throttle the sender
+	// to SpamThrottledPerMinute for SpamThrottleMins, and more flag them for
+	// SpamFlagHours, routing their direct messages to message requests
+	SpamEnabled             bool
+	SpamMessagesPerMinute   int
+	SpamRecipientsPer10Mins int
+	SpamDuplicateLimit      int
+	SpamMaxLinks            int
+	SpamThrottledPerMinute  int
+	SpamThrottleMins        int
+	SpamFlagHours           int
 }
 
 func LoadConfig() *Config {
@@ -88,11 +170,43 @@ func LoadConfig() *Config {
 	storageUseSSL, _ := strconv.ParseBool(getEnv("STORAGE_USE_SSL", "false"))
 	archiveAfterDays, _ := strconv.Atoi(getEnv("ARCHIVE_AFTER_DAYS", "30"))
 	archiveCacheTTL, _ := strconv.Atoi(getEnv("ARCHIVE_CACHE_TTL_MINS", "60"))
+	messageEditWindow, _ := strconv.Atoi(getEnv("MESSAGE_EDIT_WINDOW_MINS", "60"))
+	replyMaxDepth, _ := strconv.Atoi(getEnv("REPLY_MAX_DEPTH", "3"))
+	feedCounterReconcile, _ := strconv.Atoi(getEnv("FEED_COUNTER_RECONCILE_MINS", "360"))
+	linkPreviewWorkers, _ := strconv.Atoi(getEnv("LINK_PREVIEW_WORKERS", "4"))
 	corsOrigins := strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173"), ",")
 	for i := range corsOrigins {
 		corsOrigins[i] = strings.TrimSpace(corsOrigins[i])
 	}
 	cookieSecure, _ := strconv.ParseBool(getEnv("COOKIE_SECURE", "false"))
+	hubReplayWindow, _ := strconv.Atoi(getEnv("HUB_REPLAY_WINDOW_HOURS", "24"))
+	hubJournalTTL, _ := strconv.Atoi(getEnv("HUB_JOURNAL_TTL_MINUTES", "60"))
+	hubJournalMaxEvents, _ := strconv.Atoi(getEnv("HUB_JOURNAL_MAX_EVENTS", "1000"))
+	hubInstanceID := getEnv("HUB_INSTANCE_ID", "")
+	if hubInstanceID == "" {
+		hubInstanceID, _ = os.Hostname()
+	}
+	var elasticsearchURLs []string
+	if urls := getEnv("ELASTICSEARCH_URLS", ""); urls != "" {
+		elasticsearchURLs = strings.Split(urls, ",")
+		for i := range elasticsearchURLs {
+			elasticsearchURLs[i] = strings.TrimSpace(elasticsearchURLs[i])
+		}
+	}
+	channelFanoutPageSize, _ := strconv.Atoi(getEnv("CHANNEL_FANOUT_PAGE_SIZE", "1000"))
+	apnsProduction, _ := strconv.ParseBool(getEnv("APNS_PRODUCTION", "false"))
+	canaryInterval, _ := strconv.Atoi(getEnv("CANARY_INTERVAL_SECS", "30"))
+	canaryTimeout, _ := strconv.Atoi(getEnv("CANARY_TIMEOUT_SECS", "10"))
+	canaryLatencySLO, _ := strconv.Atoi(getEnv("CANARY_LATENCY_SLO_MILLIS", "2000"))
+	canaryLossSLO, _ := strconv.ParseFloat(getEnv("CANARY_LOSS_SLO_PERCENT", "5"), 64)
+	spamEnabled, _ := strconv.ParseBool(getEnv("SPAM_ENABLED", "true"))
+	spamPerMinute, _ := strconv.Atoi(getEnv("SPAM_MESSAGES_PER_MINUTE", "30"))
+	spamRecipients, _ := strconv.Atoi(getEnv("SPAM_RECIPIENTS_PER_10_MINS", "20"))
+	spamDuplicates, _ := strconv.Atoi(getEnv("SPAM_DUPLICATE_LIMIT", "5"))
+	spamMaxLinks, _ := strconv.Atoi(getEnv("SPAM_MAX_LINKS", "3"))
+	spamThrottledPerMinute, _ := strconv.Atoi(getEnv("SPAM_THROTTLED_PER_MINUTE", "5"))
+	spamThrottleMins, _ := strconv.Atoi(getEnv("SPAM_THROTTLE_MINS", "15"))
+	spamFlagHours, _ := strconv.Atoi(getEnv("SPAM_FLAG_HOURS", "24"))
 	eventsGRPCPort := getEnv("EVENTS_GRPC_PORT", "9096")
 	eventsGRPCHost := getEnv("EVENTS_GRPC_HOST", "localhost")
 	eventsMetricsPort := getEnv("EVENTS_METRICS_PORT", "9100")
@@ -111,14 +225,21 @@ func LoadConfig() *Config {
 	userServiceHost := getEnv("USER_SERVICE_HOST", "localhost")
 	userServicePort := getEnv("USER_SERVICE_PORT", "9083")
 
+	legacyHS256Until, _ := time.Parse(time.RFC3339, getEnv("JWT_LEGACY_HS256_UNTIL", ""))
+
 	return &Config{
 		MongoURI:            getEnv("MONGO_URI", "mongodb://localhost:27017"),
 		MongoUser:           getEnv("MONGO_USER", ""),
 		MongoPassword:       getEnv("MONGO_PASSWORD", ""),
 		DBName:              getEnv("DB_NAME", "messaging_app"),
+		AuditDBName:         getEnv("AUDIT_DB_NAME", "connectify_audit"),
 		KafkaBrokers:        strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
 		JWTSecret:           getEnv("JWT_SECRET", "very-secret-key"),
+		JWKSURL:             getEnv("JWKS_URL", ""),
+		LegacyHS256Until:    legacyHS256Until,
+		ServiceAuthSecret:   getEnv("SERVICE_AUTH_SECRET", ""),
 		ServerPort:          getEnv("SERVER_PORT", "8080"),
+		GRPCPort:            getEnv("GRPC_PORT", "9094"),
 		KafkaTopic:          getEnv("KAFKA_TOPIC", "messages"),
 		UserUpdatedTopic:    getEnv("KAFKA_USER_UPDATED_TOPIC", "user-updated"),
 		WebSocketPort:       getEnv("WS_PORT", "8081"),
@@ -172,6 +293,59 @@ func LoadConfig() *Config {
 		ArchiveBucket:       getEnv("ARCHIVE_BUCKET", "connectify-archive"),
 		ArchiveCacheTTLMins: archiveCacheTTL,
 		JaegerOTLPEndpoint:  getEnv("JAEGER_OTLP_ENDPOINT", "localhost:4317"),
+
+		MessageEditWindowMins: messageEditWindow,
+		LinkPreviewWorkers:    linkPreviewWorkers,
+
+		ReplyMaxDepth:            replyMaxDepth,
+		FeedCounterReconcileMins: feedCounterReconcile,
+
+		HubFanoutMode:        getEnv("HUB_FANOUT_MODE", "redis"),
+		HubFanoutTopic:       getEnv("HUB_FANOUT_TOPIC", "hub-fanout"),
+		HubInstanceID:        hubInstanceID,
+		HubReplayWindowHours: hubReplayWindow,
+		HubJournalTTLMinutes: hubJournalTTL,
+		HubJournalMaxEvents:  hubJournalMaxEvents,
+
+		ElasticsearchURLs:     elasticsearchURLs,
+		ElasticsearchUser:     getEnv("ELASTICSEARCH_USER", ""),
+		ElasticsearchPassword: getEnv("ELASTICSEARCH_PASSWORD", ""),
+		MessageSearchIndex:    getEnv("MESSAGE_SEARCH_INDEX", "connectify-messages"),
+		MessageSearchTopic:    getEnv("MESSAGE_SEARCH_TOPIC", "message-search-events"),
+		MessageSearchGroupID:  getEnv("MESSAGE_SEARCH_GROUP_ID", "message-search-indexer"),
+
+		MarketplaceOfferTopic:   getEnv("MARKETPLACE_OFFER_TOPIC", "marketplace-offer-events"),
+		MarketplaceOfferGroupID: getEnv("MARKETPLACE_OFFER_GROUP_ID", "marketplace-offer-cards"),
+
+		ChannelFanoutTopic:    getEnv("CHANNEL_FANOUT_TOPIC", "channel-fanout-jobs"),
+		ChannelFanoutGroupID:  getEnv("CHANNEL_FANOUT_GROUP_ID", "channel-fanout-workers"),
+		ChannelFanoutPageSize: channelFanoutPageSize,
+
+		PushTopic:          getEnv("PUSH_TOPIC", "push-notifications"),
+		PushGroupID:        getEnv("PUSH_GROUP_ID", "push-delivery"),
+		FCMProjectID:       getEnv("FCM_PROJECT_ID", ""),
+		FCMCredentialsFile: getEnv("FCM_CREDENTIALS_FILE", ""),
+		APNsKeyFile:        getEnv("APNS_KEY_FILE", ""),
+		APNsKeyID:          getEnv("APNS_KEY_ID", ""),
+		APNsTeamID:         getEnv("APNS_TEAM_ID", ""),
+		APNsTopic:          getEnv("APNS_TOPIC", ""),
+		APNsProduction:     apnsProduction,
+
+		CanarySenderID:         getEnv("CANARY_SENDER_ID", ""),
+		CanaryReceiverID:       getEnv("CANARY_RECEIVER_ID", ""),
+		CanaryIntervalSecs:     canaryInterval,
+		CanaryTimeoutSecs:      canaryTimeout,
+		CanaryLatencySLOMillis: canaryLatencySLO,
+		CanaryLossSLOPercent:   canaryLossSLO,
+
+		SpamEnabled:             spamEnabled,
+		SpamMessagesPerMinute:   spamPerMinute,
+		SpamRecipientsPer10Mins: spamRecipients,
+		SpamDuplicateLimit:      spamDuplicates,
+		SpamMaxLinks:            spamMaxLinks,
+		SpamThrottledPerMinute:  spamThrottledPerMinute,
+		SpamThrottleMins:        spamThrottleMins,
+		SpamFlagHours:           spamFlagHours,
 	}
 }
 
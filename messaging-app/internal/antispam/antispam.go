@@ -0,0 +1,295 @@
+// Package antispam scores message senders for spam. Every message adds to
+// per-sender counters in Redis (messages per minute, distinct conversations
+// messaged, repeats of the same content) and its content is checked for
+// links. A sender whose score crosses the throttle threshold is slowed down
+// for a while; one crossing the flag threshold is also flagged, which routes
+// their direct messages to the receivers' message requests and lists them
+// for admins.
+package antispam
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// flaggedKey is a sorted set of the flagged senders by when they were flagged
+const flaggedKey = "spam:flagged"
+
+// Signal scores; a score is capped at maxScore
+const (
+	velocityScore  = 40
+	fanOutScore    = 30
+	duplicateScore = 30
+	linksScore     = 20
+	maxScore       = 100
+)
+
+// Signal names, as reported in verdicts and flags
+const (
+	SignalVelocity  = "velocity"
+	SignalFanOut    = "fan_out"
+	SignalDuplicate = "duplicate_content"
+	SignalLinks     = "links"
+)
+
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)[^\s<>"']+`)
+
+// Config holds the spam thresholds
+type Config struct {
+	MessagesPerMinute   int // Messages a minute above which velocity counts
+	RecipientsPerWindow int // Distinct conversations per FanOutWindow above which fan-out counts
+	FanOutWindow        time.Duration
+	DuplicateLimit      int // Repeats of the same content per DuplicateWindow above which it counts
+	DuplicateWindow     time.Duration
+	MaxLinks            int // Links in one message above which they count
+	ThrottleScore       int // Score at which the sender is throttled
+	FlagScore           int // Score at which the sender is also flagged
+	ThrottleDuration    time.Duration
+	ThrottledPerMinute  int // Messages a minute a throttled sender may still send
+	FlagDuration        time.Duration
+}
+
+// DefaultConfig returns thresholds that leave fast typists alone
+func DefaultConfig() Config {
+	return Config{
+		MessagesPerMinute:   30,
+		RecipientsPerWindow: 20,
+		FanOutWindow:        10 * time.Minute,
+		DuplicateLimit:      5,
+		DuplicateWindow:     10 * time.Minute,
+		MaxLinks:            3,
+		ThrottleScore:       60,
+		FlagScore:           80,
+		ThrottleDuration:    15 * time.Minute,
+		ThrottledPerMinute:  5,
+		FlagDuration:        24 * time.Hour,
+	}
+}
+
+// Verdict is the outcome of checking one message
+type Verdict struct {
+	Score   int
+	Signals []string
+	// Throttled is set while the sender is throttled; Blocked when this
+	// message is over the throttled rate and must not be sent
+	Throttled bool
+	Blocked   bool
+	// Flagged is set while the sender is flagged
+	Flagged bool
+}
+
+// FlaggedSender is a sender flagged for spam, as listed for admins
+type FlaggedSender struct {
+	UserID    string    `json:"user_id"`
+	Score     int       `json:"score"`
+	Signals   []string  `json:"signals"`
+	FlaggedAt time.Time `json:"flagged_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// counts are the sender's activity including the message being checked
+type counts struct {
+	perMinute  int64
+	recipients int64
+	duplicates int64
+	links      int
+}
+
+// Guard checks messages against the sender's recent activity
+type Guard struct {
+	client *redis.ClusterClient
+	cfg    Config
+}
+
+func NewGuard(client *redis.ClusterClient, cfg Config) *Guard {
+	return &Guard{client: client, cfg: cfg}
+}
+
+// senderKey keeps all of a sender's keys in one cluster slot
+func senderKey(senderID, name string) string {
+	return "spam:{" + senderID + "}:" + name
+}
+
+// Check records a message of the sender to a conversation and scores the
+// sender. Content is empty for end-to-end encrypted messages, which are
+// scored on velocity and fan-out alone. Callers let the message through when
+// Redis fails.
+func (g *Guard) Check(ctx context.Context, senderID, conversationKey, content string) (Verdict, error) {
+	now := time.Now()
+	rateKey := senderKey(senderID, "rate:"+strconv.FormatInt(now.Unix()/60, 10))
+	recipientsKey := senderKey(senderID, "recipients")
+	throttleKey := senderKey(senderID, "throttle")
+	flagKey := senderKey(senderID, "flagged")
+
+	pipe := g.client.Pipeline()
+	rate := pipe.Incr(ctx, rateKey)
+	pipe.Expire(ctx, rateKey, 2*time.Minute)
+	pipe.ZAdd(ctx, recipientsKey, redis.Z{Score: float64(now.UnixMilli()), Member: conversationKey})
+	pipe.ZRemRangeByScore(ctx, recipientsKey, "-inf", strconv.FormatInt(now.Add(-g.cfg.FanOutWindow).UnixMilli(), 10))
+	recipients := pipe.ZCard(ctx, recipientsKey)
+	pipe.Expire(ctx, recipientsKey, g.cfg.FanOutWindow)
+	var duplicates *redis.IntCmd
+	if fingerprint := contentFingerprint(content); fingerprint != "" {
+		dupKey := senderKey(senderID, "dup:"+fingerprint)
+		duplicates = pipe.Incr(ctx, dupKey)
+		pipe.Expire(ctx, dupKey, g.cfg.DuplicateWindow)
+	}
+	throttled := pipe.Exists(ctx, throttleKey)
+	flagged := pipe.Exists(ctx, flagKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		checks.WithLabelValues("error").Inc()
+		return Verdict{}, fmt.Errorf("failed to record message of %s: %w", senderID, err)
+	}
+
+	c := counts{perMinute: rate.Val(), recipients: recipients.Val(), links: countLinks(content)}
+	if duplicates != nil {
+		c.duplicates = duplicates.Val()
+	}
+	verdict := Verdict{Throttled: throttled.Val() > 0, Flagged: flagged.Val() > 0}
+	verdict.Score, verdict.Signals = score(c, g.cfg)
+	scores.Observe(float64(verdict.Score))
+
+	if verdict.Score >= g.cfg.ThrottleScore && !verdict.Throttled {
+		if err := g.client.Set(ctx, throttleKey, verdict.Score, g.cfg.ThrottleDuration).Err(); err != nil {
+			log.Printf("Failed to throttle spam sender %s: %v", senderID, err)
+		} else {
+			verdict.Throttled = true
+			throttles.Inc()
+		}
+	}
+	if verdict.Score >= g.cfg.FlagScore && !verdict.Flagged {
+		if err := g.flag(ctx, senderID, verdict, now); err != nil {
+			log.Printf("Failed to flag spam sender %s: %v", senderID, err)
+		} else {
+			verdict.Flagged = true
+			flags.Inc()
+		}
+	}
+	verdict.Blocked = verdict.Throttled && c.perMinute > int64(g.cfg.ThrottledPerMinute)
+
+	switch {
+	case verdict.Blocked:
+		checks.WithLabelValues("blocked").Inc()
+	case verdict.Flagged:
+		checks.WithLabelValues("flagged").Inc()
+	case verdict.Throttled:
+		checks.WithLabelValues("throttled").Inc()
+	default:
+		checks.WithLabelValues("allowed").Inc()
+	}
+	return verdict, nil
+}
+
+// RecordRequest counts a message routed to the receiver's message requests
+func (g *Guard) RecordRequest() {
+	requests.Inc()
+}
+
+func (g *Guard) flag(ctx context.Context, senderID string, verdict Verdict, at time.Time) error {
+	data, err := json.Marshal(FlaggedSender{
+		UserID:    senderID,
+		Score:     verdict.Score,
+		Signals:   verdict.Signals,
+		FlaggedAt: at,
+		ExpiresAt: at.Add(g.cfg.FlagDuration),
+	})
+	if err != nil {
+		return err
+	}
+	if err := g.client.Set(ctx, senderKey(senderID, "flagged"), data, g.cfg.FlagDuration).Err(); err != nil {
+		return err
+	}
+	return g.client.ZAdd(ctx, flaggedKey, redis.Z{Score: float64(at.Unix()), Member: senderID}).Err()
+}
+
+// FlaggedSenders lists the senders currently flagged, most recent first
+func (g *Guard) FlaggedSenders(ctx context.Context, limit int) ([]FlaggedSender, error) {
+	cutoff := time.Now().Add(-g.cfg.FlagDuration).Unix()
+	if err := g.client.ZRemRangeByScore(ctx, flaggedKey, "-inf", strconv.FormatInt(cutoff, 10)).Err(); err != nil {
+		return nil, err
+	}
+	ids, err := g.client.ZRevRange(ctx, flaggedKey, 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	senders := make([]FlaggedSender, 0, len(ids))
+	for _, id := range ids {
+		data, err := g.client.Get(ctx, senderKey(id, "flagged")).Bytes()
+		if err == redis.Nil {
+			// Cleared or expired since it was listed
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var sender FlaggedSender
+		if err := json.Unmarshal(data, &sender); err != nil {
+			log.Printf("Skipping unreadable spam flag of %s: %v", id, err)
+			continue
+		}
+		senders = append(senders, sender)
+	}
+	return senders, nil
+}
+
+// Clear lifts a sender's flag and throttle. It reports whether the sender
+// was flagged or throttled.
+func (g *Guard) Clear(ctx context.Context, senderID string) (bool, error) {
+	removed, err := g.client.Del(ctx, senderKey(senderID, "flagged"), senderKey(senderID, "throttle")).Result()
+	if err != nil {
+		return false, err
+	}
+	if err := g.client.ZRem(ctx, flaggedKey, senderID).Err(); err != nil {
+		return false, err
+	}
+	return removed > 0, nil
+}
+
+// score adds up the signals the counts raise
+func score(c counts, cfg Config) (int, []string) {
+	total := 0
+	var signals []string
+	if c.perMinute > int64(cfg.MessagesPerMinute) {
+		total += velocityScore
+		signals = append(signals, SignalVelocity)
+	}
+	if c.recipients > int64(cfg.RecipientsPerWindow) {
+		total += fanOutScore
+		signals = append(signals, SignalFanOut)
+	}
+	if c.duplicates > int64(cfg.DuplicateLimit) {
+		total += duplicateScore
+		signals = append(signals, SignalDuplicate)
+	}
+	if c.links > cfg.MaxLinks {
+		total += linksScore
+		signals = append(signals, SignalLinks)
+	}
+	return min(total, maxScore), signals
+}
+
+// contentFingerprint identifies content regardless of case and spacing;
+// empty for empty content
+func contentFingerprint(content string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(content)), " ")
+	if normalized == "" {
+		return ""
+	}
+	sum := sha1.Sum([]byte(normalized))
+	return hex.EncodeToString(sum[:8])
+}
+
+func countLinks(content string) int {
+	return len(linkPattern.FindAllStringIndex(content, -1))
+}
@@ -0,0 +1,42 @@
+package antispam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScore(t *testing.T) {
+	cfg := DefaultConfig()
+
+	tests := []struct {
+		name    string
+		counts  counts
+		score   int
+		signals []string
+	}{
+		{"quiet sender", counts{perMinute: 3, recipients: 2, duplicates: 1}, 0, nil},
+		{"at the limits", counts{perMinute: 30, recipients: 20, duplicates: 5, links: 3}, 0, nil},
+		{"fast typist", counts{perMinute: 31, recipients: 1}, 40, []string{SignalVelocity}},
+		{"fan-out of one message", counts{perMinute: 10, recipients: 21, duplicates: 6}, 60, []string{SignalFanOut, SignalDuplicate}},
+		{"link blast", counts{perMinute: 40, recipients: 25, duplicates: 10, links: 4}, 100, []string{SignalVelocity, SignalFanOut, SignalDuplicate, SignalLinks}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, signals := score(tt.counts, cfg)
+			assert.Equal(t, tt.score, score)
+			assert.Equal(t, tt.signals, signals)
+		})
+	}
+}
+
+func TestContentFingerprint(t *testing.T) {
+	assert.Equal(t, contentFingerprint("Win a FREE phone!"), contentFingerprint("  win a free\tphone! "))
+	assert.NotEqual(t, contentFingerprint("win a free phone"), contentFingerprint("win a free laptop"))
+	assert.Empty(t, contentFingerprint(" \n "))
+}
+
+func TestCountLinks(t *testing.T) {
+	assert.Equal(t, 0, countLinks("see you at 5"))
+	assert.Equal(t, 3, countLinks("https://a.example/x and HTTP://b.example, www.c.example"))
+}
@@ -0,0 +1,30 @@
+package antispam
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	checks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "message_spam_checks_total",
+		Help: "Messages checked for spam, by outcome: allowed, throttled, flagged, blocked or error",
+	}, []string{"outcome"})
+	scores = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "message_spam_score",
+		Help:    "Spam score of the checked messages' senders",
+		Buckets: []float64{0, 20, 40, 60, 80, 100},
+	})
+	throttles = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "message_spam_throttles_total",
+		Help: "Senders throttled for spam",
+	})
+	flags = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "message_spam_flags_total",
+		Help: "Senders flagged for spam",
+	})
+	requests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "message_spam_requests_total",
+		Help: "Direct messages of flagged senders routed to message requests",
+	})
+)
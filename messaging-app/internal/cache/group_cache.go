@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	sharedcache "github.com/MuhibNayem/connectify-v2/shared-entity/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+// Group cache TTL constants
+const (
+	GroupMembersTTL         = 10 * time.Minute // Member ID list, invalidated on membership change
+	GroupMembersNegativeTTL = 30 * time.Second // Remember unknown groups briefly
+	GroupNameTTL            = 24 * time.Hour   // Group display name
+)
+
+// GroupCache provides stampede-protected caching for hot group lookups.
+// Every send to a group and every IDOR check resolves the member list, so a
+// cold key under load must collapse into a single backing-store read.
+type GroupCache struct {
+	members *sharedcache.Cache[[]string]
+	names   *sharedcache.Cache[string]
+}
+
+// NewGroupCache creates a group cache. A nil client yields a cache that only
+// de-duplicates concurrent loads.
+func NewGroupCache(client *redis.ClusterClient) *GroupCache {
+	var cmd redis.Cmdable
+	if client != nil {
+		cmd = client
+	}
+	return &GroupCache{
+		members: sharedcache.New(cmd, sharedcache.JSONCodec[[]string]{}, sharedcache.Options{
+			Prefix:      "cache:group_members",
+			TTL:         GroupMembersTTL,
+			Jitter:      0.1,
+			NegativeTTL: GroupMembersNegativeTTL,
+		}),
+		names: sharedcache.New[string](cmd, sharedcache.StringCodec{}, sharedcache.Options{
+			Prefix: "cache:group_name",
+			TTL:    GroupNameTTL,
+			Jitter: 0.1,
+		}),
+	}
+}
+
+// Members returns the hex IDs of a group's members, calling load on a miss.
+// load should return sharedcache.ErrNotFound for groups that do not exist.
+func (c *GroupCache) Members(ctx context.Context, groupID string, load func(ctx context.Context) ([]string, error)) ([]string, error) {
+	return c.members.GetOrLoad(ctx, groupID, load)
+}
+
+// Name returns a group's display name, calling load on a miss.
+func (c *GroupCache) Name(ctx context.Context, groupID string, load func(ctx context.Context) (string, error)) (string, error) {
+	return c.names.GetOrLoad(ctx, groupID, load)
+}
+
+// InvalidateMembers drops the cached member list for a group.
+func (c *GroupCache) InvalidateMembers(ctx context.Context, groupID string) error {
+	return c.members.Delete(ctx, groupID)
+}
+
+// InvalidateName drops the cached display name for a group.
+func (c *GroupCache) InvalidateName(ctx context.Context, groupID string) error {
+	return c.names.Delete(ctx, groupID)
+}
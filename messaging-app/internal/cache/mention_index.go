@@ -0,0 +1,223 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// Mention index constants
+const (
+	mentionPrefixKey   = "mention:prefix:"    // Sorted set of "<term>\x00<user ID>" per first letter
+	mentionTermsKey    = "mention:terms:"     // Set of the entries indexed for a user
+	mentionRecentKey   = "mention:recent:"    // Sorted set of the users someone talked to, by time
+	mentionBackfillKey = "mention:backfilled" // Set once the index was built from the user collection
+
+	MentionRecentLimit = 200                 // Interactions remembered per user
+	MentionRecentTTL   = 90 * 24 * time.Hour // Interactions older than this no longer count
+)
+
+// MentionIndex is the Redis prefix index behind @mention suggestions. Users
+// are indexed by username and by each word of their full name; entries are
+// spread over one sorted set per first letter so every lookup reads a single
+// key of the cluster.
+type MentionIndex struct {
+	client *redis.ClusterClient
+}
+
+func NewMentionIndex(client *redis.ClusterClient) *MentionIndex {
+	return &MentionIndex{client: client}
+}
+
+// Upsert indexes a user under their current names and drops the entries of
+// names they no longer use. Deleted and banned accounts are removed instead.
+func (m *MentionIndex) Upsert(ctx context.Context, user *models.User) error {
+	userID := user.ID.Hex()
+	if user.DeletedAt != nil || user.EffectiveAccountState(time.Now()) == models.AccountStateBanned {
+		return m.Remove(ctx, userID)
+	}
+
+	entries := make(map[string]bool)
+	for _, term := range mentionTerms(user.Username, user.FullName) {
+		entries[term+"\x00"+userID] = true
+	}
+
+	old, err := m.client.SMembers(ctx, mentionTermsKey+userID).Result()
+	if err != nil {
+		return fmt.Errorf("failed to read mention terms of %s: %w", userID, err)
+	}
+	for _, entry := range old {
+		if entries[entry] {
+			delete(entries, entry)
+			continue
+		}
+		if err := m.client.ZRem(ctx, mentionShardKey(entry), entry).Err(); err != nil {
+			return err
+		}
+		m.client.SRem(ctx, mentionTermsKey+userID, entry)
+	}
+	for entry := range entries {
+		if err := m.client.ZAdd(ctx, mentionShardKey(entry), redis.Z{Member: entry}).Err(); err != nil {
+			return err
+		}
+		if err := m.client.SAdd(ctx, mentionTermsKey+userID, entry).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove takes a user out of the index and forgets who they talked to
+func (m *MentionIndex) Remove(ctx context.Context, userID string) error {
+	old, err := m.client.SMembers(ctx, mentionTermsKey+userID).Result()
+	if err != nil {
+		return fmt.Errorf("failed to read mention terms of %s: %w", userID, err)
+	}
+	for _, entry := range old {
+		if err := m.client.ZRem(ctx, mentionShardKey(entry), entry).Err(); err != nil {
+			return err
+		}
+	}
+	return m.client.Del(ctx, mentionTermsKey+userID, mentionRecentKey+userID).Err()
+}
+
+// Lookup returns the IDs of up to limit users with a name starting with
+// prefix, in name order
+func (m *MentionIndex) Lookup(ctx context.Context, prefix string, limit int) ([]string, error) {
+	prefix = normalizeMentionTerm(prefix)
+	if prefix == "" {
+		return nil, nil
+	}
+	// A user matches once per name, so read a few more entries than needed
+	entries, err := m.client.ZRangeByLex(ctx, mentionShardKey(prefix), &redis.ZRangeBy{
+		Min:   "[" + prefix,
+		Max:   "[" + prefix + "\xff",
+		Count: int64(limit * 3),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(entries))
+	ids := make([]string, 0, limit)
+	for _, entry := range entries {
+		_, userID, ok := strings.Cut(entry, "\x00")
+		if !ok || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		ids = append(ids, userID)
+		if len(ids) == limit {
+			break
+		}
+	}
+	return ids, nil
+}
+
+// RecordInteraction notes that userID just messaged or mentioned others
+func (m *MentionIndex) RecordInteraction(ctx context.Context, userID string, others ...string) error {
+	now := float64(time.Now().Unix())
+	members := make([]redis.Z, 0, len(others))
+	for _, other := range others {
+		if other != "" && other != userID {
+			members = append(members, redis.Z{Score: now, Member: other})
+		}
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	key := mentionRecentKey + userID
+	pipe := m.client.TxPipeline()
+	pipe.ZAdd(ctx, key, members...)
+	pipe.ZRemRangeByRank(ctx, key, 0, -MentionRecentLimit-1)
+	pipe.Expire(ctx, key, MentionRecentTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RecentInteractions returns when userID last messaged or mentioned each
+// user, over the last MentionRecentTTL
+func (m *MentionIndex) RecentInteractions(ctx context.Context, userID string) (map[string]time.Time, error) {
+	since := time.Now().Add(-MentionRecentTTL).Unix()
+	entries, err := m.client.ZRangeByScoreWithScores(ctx, mentionRecentKey+userID, &redis.ZRangeBy{
+		Min: strconv.FormatInt(since, 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	recent := make(map[string]time.Time, len(entries))
+	for _, z := range entries {
+		if id, ok := z.Member.(string); ok {
+			recent[id] = time.Unix(int64(z.Score), 0)
+		}
+	}
+	return recent, nil
+}
+
+// ClaimBackfill reports whether the caller should build the index from the
+// user collection. Only the first caller ever gets true.
+func (m *MentionIndex) ClaimBackfill(ctx context.Context) (bool, error) {
+	return m.client.SetNX(ctx, mentionBackfillKey, time.Now().Unix(), 0).Result()
+}
+
+// ReleaseBackfill lets the next caller of ClaimBackfill build the index
+// again, after a backfill that did not finish
+func (m *MentionIndex) ReleaseBackfill(ctx context.Context) error {
+	return m.client.Del(ctx, mentionBackfillKey).Err()
+}
+
+// MentionMatches reports whether a user with the given names is found by
+// prefix, the same way Lookup finds them
+func MentionMatches(username, fullName, prefix string) bool {
+	prefix = normalizeMentionTerm(prefix)
+	if prefix == "" {
+		return false
+	}
+	for _, term := range mentionTerms(username, fullName) {
+		if strings.HasPrefix(term, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// mentionTerms lists the names a user is found by: the username, the full
+// name and each later word of the full name, so "Ada Lovelace" is found by
+// both "ada" and "love"
+func mentionTerms(username, fullName string) []string {
+	var terms []string
+	if term := normalizeMentionTerm(username); term != "" {
+		terms = append(terms, term)
+	}
+	words := strings.Fields(fullName)
+	for i := range words {
+		if term := normalizeMentionTerm(strings.Join(words[i:], " ")); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+func normalizeMentionTerm(s string) string {
+	s = strings.ToLower(strings.Join(strings.Fields(s), " "))
+	return strings.TrimLeft(s, "@")
+}
+
+// mentionShardKey returns the sorted set holding entries that start like
+// term. Letters and digits get a set each; everything else shares one.
+func mentionShardKey(term string) string {
+	r, _ := utf8.DecodeRuneInString(term)
+	if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+		return mentionPrefixKey + "_"
+	}
+	return mentionPrefixKey + string(r)
+}
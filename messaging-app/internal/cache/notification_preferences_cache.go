@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	sharedcache "github.com/MuhibNayem/connectify-v2/shared-entity/cache"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// NotificationPreferencesTTL bounds how long a preferences change made on
+// another instance can go unnoticed; local changes invalidate immediately
+const NotificationPreferencesTTL = 10 * time.Minute
+
+// NotificationPreferencesCache caches users' notification preferences, which
+// are read for every notification created and delivered
+type NotificationPreferencesCache struct {
+	prefs *sharedcache.Cache[models.NotificationPreferences]
+}
+
+// NewNotificationPreferencesCache creates a preferences cache. A nil client
+// yields a cache that only de-duplicates concurrent loads.
+func NewNotificationPreferencesCache(client *redis.ClusterClient) *NotificationPreferencesCache {
+	var cmd redis.Cmdable
+	if client != nil {
+		cmd = client
+	}
+	return &NotificationPreferencesCache{
+		prefs: sharedcache.New(cmd, sharedcache.JSONCodec[models.NotificationPreferences]{}, sharedcache.Options{
+			Prefix: "cache:notification_prefs",
+			TTL:    NotificationPreferencesTTL,
+			Jitter: 0.1,
+		}),
+	}
+}
+
+// Get returns a user's preferences, calling load on a miss
+func (c *NotificationPreferencesCache) Get(ctx context.Context, userID string, load func(ctx context.Context) (models.NotificationPreferences, error)) (models.NotificationPreferences, error) {
+	return c.prefs.GetOrLoad(ctx, userID, load)
+}
+
+// Invalidate drops a user's cached preferences
+func (c *NotificationPreferencesCache) Invalidate(ctx context.Context, userID string) error {
+	return c.prefs.Delete(ctx, userID)
+}
@@ -0,0 +1,204 @@
+// Package canary watches message delivery end to end. It periodically sends a
+// direct message between two dedicated users through the regular send path
+// (message service, Cassandra, Redis or Kafka fan-out, Hub) and times its
+// arrival at an in-process loopback client of the receiver, exporting latency,
+// loss and SLA breaches as Prometheus metrics.
+package canary
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"time"
+
+	"messaging-app/internal/websocket"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// probePrefix starts the content of every canary message
+const probePrefix = "[canary] "
+
+// retentionDays is how long canary messages are kept
+const retentionDays = 1
+
+// Result is the outcome of one probe
+type Result string
+
+const (
+	ResultDelivered  Result = "delivered"
+	ResultLost       Result = "lost"        // Sent but not seen within the timeout
+	ResultSendFailed Result = "send_failed" // Rejected or not persisted by the message service
+)
+
+// Sender is the part of MessageService the canary sends through
+type Sender interface {
+	SendMessage(ctx context.Context, senderID primitive.ObjectID, req models.MessageRequest) (*models.Message, error)
+	SetRetention(ctx context.Context, requesterID primitive.ObjectID, conversationID string, isGroup bool, days int) (*models.ConversationRetention, error)
+}
+
+// Hub connects the receiver's loopback client
+type Hub interface {
+	ConnectLoopback(userID string) *websocket.Loopback
+}
+
+// Config says who the canary messages and how its SLA is judged
+type Config struct {
+	SenderID   primitive.ObjectID
+	ReceiverID primitive.ObjectID
+	Interval   time.Duration
+	Timeout    time.Duration // How long a probe may take before it counts as lost
+	// The SLA is breached when the 95th percentile delivery latency exceeds
+	// MaxLatency, or more than MaxLoss of the recent probes fail
+	MaxLatency time.Duration
+	MaxLoss    float64
+}
+
+// Canary sends probes and records how they fare
+type Canary struct {
+	cfg    Config
+	sender Sender
+	hub    Hub
+	window *window
+}
+
+// New creates a canary; Run starts it
+func New(cfg Config, sender Sender, hub Hub) *Canary {
+	return &Canary{cfg: cfg, sender: sender, hub: hub, window: newWindow(windowSize)}
+}
+
+// Run sends a probe every interval until ctx is done
+func (c *Canary) Run(ctx context.Context) {
+	latencyThreshold.Set(c.cfg.MaxLatency.Seconds())
+	lossThreshold.Set(c.cfg.MaxLoss)
+	if _, err := c.sender.SetRetention(ctx, c.cfg.SenderID, c.cfg.ReceiverID.Hex(), false, retentionDays); err != nil {
+		log.Printf("Canary: failed to limit retention of its conversation: %v", err)
+	}
+
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	var loopback *websocket.Loopback
+	for {
+		if loopback == nil {
+			loopback = c.hub.ConnectLoopback(c.cfg.ReceiverID.Hex())
+		}
+		result, latency, open := c.probe(ctx, loopback.Events())
+		if ctx.Err() != nil {
+			loopback.Close()
+			return
+		}
+		c.record(result, latency)
+		if !open {
+			log.Printf("Canary: hub dropped the loopback client, reconnecting")
+			loopback = nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			if loopback != nil {
+				loopback.Close()
+			}
+			return
+		}
+	}
+}
+
+// probe sends one canary message and waits for it on events. It reports
+// whether events is still open.
+func (c *Canary) probe(ctx context.Context, events <-chan []byte) (Result, time.Duration, bool) {
+	content := probePrefix + nonce()
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	// Messages reach the Hub before they are persisted, so the send runs
+	// alongside the wait and both must succeed
+	start := time.Now()
+	sent := make(chan error, 1)
+	go func() {
+		_, err := c.sender.SendMessage(ctx, c.cfg.SenderID, models.MessageRequest{
+			ReceiverID:  c.cfg.ReceiverID.Hex(),
+			Content:     content,
+			ContentType: models.ContentTypeText,
+		})
+		if err == nil {
+			sendDuration.Observe(time.Since(start).Seconds())
+		}
+		sent <- err
+	}()
+
+	var latency time.Duration
+	delivered, persisted := false, false
+	for !delivered || !persisted {
+		select {
+		case err := <-sent:
+			if err != nil {
+				log.Printf("Canary: send failed: %v", err)
+				return ResultSendFailed, 0, true
+			}
+			persisted = true
+		case raw, ok := <-events:
+			if !ok {
+				return ResultLost, 0, false
+			}
+			if !delivered && isProbe(raw, content) {
+				delivered = true
+				latency = time.Since(start)
+			}
+		case <-ctx.Done():
+			if !persisted {
+				return ResultSendFailed, 0, true
+			}
+			return ResultLost, 0, true
+		}
+	}
+	return ResultDelivered, latency, true
+}
+
+// record exports a probe's result and the SLA over the recent probes
+func (c *Canary) record(result Result, latency time.Duration) {
+	probes.WithLabelValues(string(result)).Inc()
+	if result == ResultDelivered {
+		deliveryLatency.Observe(latency.Seconds())
+		lastDelivered.SetToCurrentTime()
+	} else {
+		log.Printf("Canary: probe %s", result)
+	}
+
+	c.window.add(result == ResultDelivered, latency)
+	loss, p95 := c.window.stats()
+	lossRatio.Set(loss)
+	latencyP95.Set(p95.Seconds())
+	if loss > c.cfg.MaxLoss || p95 > c.cfg.MaxLatency {
+		slaBreached.Set(1)
+	} else {
+		slaBreached.Set(0)
+	}
+}
+
+// isProbe reports whether raw is the MESSAGE_CREATED event of the probe with
+// the given content. Probes of canaries on other nodes are ignored.
+func isProbe(raw []byte, content string) bool {
+	var event models.WebSocketEvent
+	if err := json.Unmarshal(raw, &event); err != nil || event.Type != "MESSAGE_CREATED" {
+		return false
+	}
+	var msg struct {
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(event.Data, &msg); err != nil {
+		return false
+	}
+	return msg.Content == content
+}
+
+func nonce() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
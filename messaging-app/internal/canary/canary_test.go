@@ -0,0 +1,113 @@
+package canary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeSender plays the pipeline: unless it fails, it delivers each message
+// to events as the Hub would
+type fakeSender struct {
+	events  chan []byte
+	deliver bool
+	err     error
+}
+
+func (f *fakeSender) SendMessage(_ context.Context, _ primitive.ObjectID, req models.MessageRequest) (*models.Message, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.deliver {
+		f.events <- messageCreated(req.Content)
+	}
+	return &models.Message{Content: req.Content}, nil
+}
+
+func (f *fakeSender) SetRetention(context.Context, primitive.ObjectID, string, bool, int) (*models.ConversationRetention, error) {
+	return nil, nil
+}
+
+func messageCreated(content string) []byte {
+	data, _ := json.Marshal(models.Message{Content: content})
+	raw, _ := json.Marshal(models.WebSocketEvent{Type: "MESSAGE_CREATED", Data: data})
+	return raw
+}
+
+func newTestCanary(sender *fakeSender) *Canary {
+	return New(Config{
+		SenderID:   primitive.NewObjectID(),
+		ReceiverID: primitive.NewObjectID(),
+		Timeout:    50 * time.Millisecond,
+		MaxLatency: time.Second,
+		MaxLoss:    0.1,
+	}, sender, nil)
+}
+
+func TestCanary_Probe(t *testing.T) {
+	t.Run("delivered", func(t *testing.T) {
+		sender := &fakeSender{events: make(chan []byte, 4), deliver: true}
+		sender.events <- messageCreated(probePrefix + "from another node")
+
+		result, latency, open := newTestCanary(sender).probe(context.Background(), sender.events)
+
+		assert.Equal(t, ResultDelivered, result)
+		assert.Greater(t, latency, time.Duration(0))
+		assert.True(t, open)
+	})
+
+	t.Run("lost", func(t *testing.T) {
+		sender := &fakeSender{events: make(chan []byte, 4)}
+
+		result, _, open := newTestCanary(sender).probe(context.Background(), sender.events)
+
+		assert.Equal(t, ResultLost, result)
+		assert.True(t, open)
+	})
+
+	t.Run("send failed", func(t *testing.T) {
+		sender := &fakeSender{events: make(chan []byte, 4), err: errors.New("not friends")}
+
+		result, _, _ := newTestCanary(sender).probe(context.Background(), sender.events)
+
+		assert.Equal(t, ResultSendFailed, result)
+	})
+
+	t.Run("dropped loopback", func(t *testing.T) {
+		sender := &fakeSender{events: make(chan []byte)}
+		close(sender.events)
+
+		result, _, open := newTestCanary(sender).probe(context.Background(), sender.events)
+
+		assert.Equal(t, ResultLost, result)
+		assert.False(t, open)
+	})
+}
+
+func TestWindow_Stats(t *testing.T) {
+	w := newWindow(4)
+	loss, p95 := w.stats()
+	assert.Zero(t, loss)
+	assert.Zero(t, p95)
+
+	w.add(true, 100*time.Millisecond)
+	w.add(false, 0)
+	w.add(true, 300*time.Millisecond)
+	loss, p95 = w.stats()
+	assert.InDelta(t, 1.0/3, loss, 1e-9)
+	assert.Equal(t, 300*time.Millisecond, p95)
+
+	// The oldest probes fall out of a full window
+	w.add(true, 200*time.Millisecond)
+	w.add(true, 50*time.Millisecond)
+	w.add(true, 50*time.Millisecond)
+	loss, p95 = w.stats()
+	assert.Zero(t, loss)
+	assert.Equal(t, 300*time.Millisecond, p95)
+}
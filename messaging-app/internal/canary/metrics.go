@@ -0,0 +1,99 @@
+package canary
+
+import (
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// windowSize is how many recent probes the loss ratio and p95 latency cover
+const windowSize = 20
+
+var (
+	probes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "message_canary_probes_total",
+		Help: "Canary messages sent, by result: delivered, lost or send_failed",
+	}, []string{"result"})
+	sendDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "message_canary_send_seconds",
+		Help:    "Time for the message service to accept and persist a canary message",
+		Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	})
+	deliveryLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "message_canary_delivery_seconds",
+		Help:    "Time from sending a canary message to its arrival at the loopback client",
+		Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	})
+	lastDelivered = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "message_canary_last_delivered_timestamp_seconds",
+		Help: "When a canary message was last delivered",
+	})
+	lossRatio = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "message_canary_loss_ratio",
+		Help: "Share of the recent canary messages that were not delivered",
+	})
+	latencyP95 = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "message_canary_delivery_p95_seconds",
+		Help: "95th percentile delivery latency of the recent delivered canary messages",
+	})
+	latencyThreshold = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "message_canary_latency_threshold_seconds",
+		Help: "p95 delivery latency above which the delivery SLA is breached",
+	})
+	lossThreshold = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "message_canary_loss_threshold_ratio",
+		Help: "Loss ratio above which the delivery SLA is breached",
+	})
+	slaBreached = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "message_canary_sla_breached",
+		Help: "1 while the recent canary messages breach the latency or loss threshold",
+	})
+)
+
+// window keeps the results of the most recent probes
+type window struct {
+	delivered []bool
+	latencies []time.Duration
+	next      int
+	full      bool
+}
+
+func newWindow(size int) *window {
+	return &window{delivered: make([]bool, size), latencies: make([]time.Duration, size)}
+}
+
+func (w *window) add(delivered bool, latency time.Duration) {
+	w.delivered[w.next] = delivered
+	w.latencies[w.next] = latency
+	w.next = (w.next + 1) % len(w.delivered)
+	if w.next == 0 {
+		w.full = true
+	}
+}
+
+// stats returns the share of probes lost and the 95th percentile latency of
+// those delivered
+func (w *window) stats() (float64, time.Duration) {
+	n := w.next
+	if w.full {
+		n = len(w.delivered)
+	}
+	if n == 0 {
+		return 0, 0
+	}
+
+	var latencies []time.Duration
+	for i := 0; i < n; i++ {
+		if w.delivered[i] {
+			latencies = append(latencies, w.latencies[i])
+		}
+	}
+	loss := float64(n-len(latencies)) / float64(n)
+	if len(latencies) == 0 {
+		return loss, 0
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	return loss, latencies[(len(latencies)*95-1)/100]
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"messaging-app/config"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"messaging-app/internal/services"
 	"net/http"
@@ -51,13 +52,13 @@ func (c *AuthController) setRefreshCookie(ctx *gin.Context, token string) {
 func (c *AuthController) Register(ctx *gin.Context) {
 	var user models.User
 	if err := ctx.ShouldBindJSON(&user); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	response, err := c.authService.Register(ctx.Request.Context(), &user)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -74,13 +75,13 @@ func (c *AuthController) Login(ctx *gin.Context) {
 	}
 
 	if err := ctx.ShouldBindJSON(&loginReq); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	response, err := c.authService.Login(ctx.Request.Context(), loginReq.Email, loginReq.Password)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, err.Error())
 		return
 	}
 
@@ -101,7 +102,7 @@ func (c *AuthController) Refresh(ctx *gin.Context) {
 	if refreshToken == "" {
 		var refreshReq models.RefreshRequest
 		if err := ctx.ShouldBindJSON(&refreshReq); err != nil || refreshReq.RefreshToken == "" {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "refresh token required"})
+			utils.RespondWithError(ctx, http.StatusBadRequest, "refresh token required")
 			return
 		}
 		refreshToken = refreshReq.RefreshToken
@@ -109,7 +110,7 @@ func (c *AuthController) Refresh(ctx *gin.Context) {
 
 	response, err := c.authService.RefreshToken(ctx.Request.Context(), refreshToken)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, err.Error())
 		return
 	}
 
@@ -123,7 +124,7 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	authHeader := strings.TrimSpace(ctx.GetHeader("Authorization"))
 	if authHeader == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "authorization header required"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "authorization header required")
 		return
 	}
 
@@ -133,12 +134,12 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 	}
 
 	if tokenString == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "authorization token required"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "authorization token required")
 		return
 	}
 
 	if err := c.authService.Logout(ctx.Request.Context(), userID, tokenString); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
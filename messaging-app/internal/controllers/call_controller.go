@@ -0,0 +1,230 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"messaging-app/internal/services"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CallController struct {
+	callService *services.CallService
+}
+
+func NewCallController(callService *services.CallService) *CallController {
+	return &CallController{callService: callService}
+}
+
+// StartCall godoc
+// @Summary Start a group call
+// @Description Start a call with the current user joined, ringing either the members of a group or the given friends. Invitees get CALL_RING over the WebSocket and miss the call after 45 seconds without answering.
+// @Tags Calls
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param call body models.StartCallRequest true "Who to call and how"
+// @Success 201 {object} models.CallRoom
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 403 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /calls [post]
+func (c *CallController) StartCall(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.StartCallRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	room, err := c.callService.StartCall(ctx.Request.Context(), objUserID, &req)
+	if err != nil {
+		respondCallError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, room)
+}
+
+// GetCallHistory godoc
+// @Summary Get call history
+// @Description Get the ended calls the current user was invited to, newest first, with how long each participant spent in them.
+// @Tags Calls
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} models.CallHistoryResponse
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /calls/history [get]
+func (c *CallController) GetCallHistory(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+
+	history, err := c.callService.GetCallHistory(ctx.Request.Context(), objUserID, page, limit)
+	if err != nil {
+		respondCallError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, history)
+}
+
+// GetCall godoc
+// @Summary Get a call
+// @Description Get a call the current user was invited to, with every participant's state.
+// @Tags Calls
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Call ID"
+// @Success 200 {object} models.CallRoom
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Router /calls/{id} [get]
+func (c *CallController) GetCall(ctx *gin.Context) {
+	c.withCall(ctx, http.StatusOK, c.callService.GetCall)
+}
+
+// JoinCall godoc
+// @Summary Join a call
+// @Description Answer a ringing call, or rejoin one still going. Then exchange signals with the other participants through call_signal messages carrying the room_id.
+// @Tags Calls
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Call ID"
+// @Success 200 {object} models.CallRoom
+// @Failure 404 {object} gin.H{"error":string}
+// @Failure 409 {object} gin.H{"error":string}
+// @Router /calls/{id}/join [post]
+func (c *CallController) JoinCall(ctx *gin.Context) {
+	c.withCall(ctx, http.StatusOK, c.callService.JoinCall)
+}
+
+// DeclineCall godoc
+// @Summary Decline a call
+// @Description Turn down a ringing call.
+// @Tags Calls
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Call ID"
+// @Success 200 {object} models.CallRoom
+// @Failure 404 {object} gin.H{"error":string}
+// @Failure 409 {object} gin.H{"error":string}
+// @Router /calls/{id}/decline [post]
+func (c *CallController) DeclineCall(ctx *gin.Context) {
+	c.withCall(ctx, http.StatusOK, c.callService.DeclineCall)
+}
+
+// CancelCall godoc
+// @Summary Cancel a call
+// @Description Let the host stop ringing a call nobody has answered.
+// @Tags Calls
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Call ID"
+// @Success 200 {object} models.CallRoom
+// @Failure 403 {object} gin.H{"error":string}
+// @Failure 409 {object} gin.H{"error":string}
+// @Router /calls/{id}/cancel [post]
+func (c *CallController) CancelCall(ctx *gin.Context) {
+	c.withCall(ctx, http.StatusOK, c.callService.CancelCall)
+}
+
+// LeaveCall godoc
+// @Summary Leave a call
+// @Description Leave a call. It ends once nobody is left in it.
+// @Tags Calls
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Call ID"
+// @Success 200 {object} models.CallRoom
+// @Failure 404 {object} gin.H{"error":string}
+// @Failure 409 {object} gin.H{"error":string}
+// @Router /calls/{id}/leave [post]
+func (c *CallController) LeaveCall(ctx *gin.Context) {
+	c.withCall(ctx, http.StatusOK, c.callService.LeaveCall)
+}
+
+// UpdateCallState godoc
+// @Summary Update call state
+// @Description Mute or unmute, or turn video on or off, in a call the current user has joined.
+// @Tags Calls
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Call ID"
+// @Param state body models.UpdateCallStateRequest true "Muted and video state"
+// @Success 200 {object} models.CallRoom
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Failure 409 {object} gin.H{"error":string}
+// @Router /calls/{id}/state [patch]
+func (c *CallController) UpdateCallState(ctx *gin.Context) {
+	var req models.UpdateCallStateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.withCall(ctx, http.StatusOK, func(reqCtx context.Context, roomID, userID primitive.ObjectID) (*models.CallRoom, error) {
+		return c.callService.UpdateState(reqCtx, roomID, userID, &req)
+	})
+}
+
+// withCall runs action on the call in the path for the current user
+func (c *CallController) withCall(ctx *gin.Context, status int, action func(context.Context, primitive.ObjectID, primitive.ObjectID) (*models.CallRoom, error)) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	roomID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		respondCallError(ctx, services.ErrInvalidCallID)
+		return
+	}
+
+	room, err := action(ctx.Request.Context(), roomID, objUserID)
+	if err != nil {
+		respondCallError(ctx, err)
+		return
+	}
+
+	ctx.JSON(status, room)
+}
+
+func respondCallError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrInvalidCallID), errors.Is(err, services.ErrInvalidCallInvitee),
+		errors.Is(err, services.ErrCallNeedsParticipants), errors.Is(err, services.ErrTooManyCallMembers),
+		errors.Is(err, services.ErrNotGroupMember):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrNotCallHost), errors.Is(err, services.ErrCallInviteeNotFriend),
+		errors.Is(err, services.ErrNotCallParticipant):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrCallNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrCallEnded), errors.Is(err, services.ErrCallNotRinging),
+		errors.Is(err, services.ErrInvalidCallStateMove):
+		status = http.StatusConflict
+	}
+	utils.RespondWithError(ctx, status, err.Error())
+}
@@ -0,0 +1,538 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"messaging-app/internal/services"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ChannelController struct {
+	channelService *services.ChannelService
+}
+
+func NewChannelController(channelService *services.ChannelService) *ChannelController {
+	return &ChannelController{channelService: channelService}
+}
+
+// CreateChannel godoc
+// @Summary Create a broadcast channel
+// @Description Create a read-only channel owned by the current user, who becomes its first admin and subscriber. Only admins post to a channel; subscribers receive its posts.
+// @Tags Channels
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param channel body models.CreateChannelRequest true "Channel profile"
+// @Success 201 {object} models.Channel
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /channels [post]
+func (c *ChannelController) CreateChannel(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.CreateChannelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	channel, err := c.channelService.CreateChannel(ctx.Request.Context(), objUserID, req)
+	if err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, channel)
+}
+
+// ListSubscribedChannels godoc
+// @Summary List subscribed channels
+// @Description List the channels the current user subscribed to, those with the latest posts first.
+// @Tags Channels
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Channel
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /channels/subscribed [get]
+func (c *ChannelController) ListSubscribedChannels(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	channels, err := c.channelService.ListSubscribedChannels(ctx.Request.Context(), objUserID)
+	if err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, channels)
+}
+
+// GetChannel godoc
+// @Summary Get a channel
+// @Description Get a channel's profile and counts, with whether the current user subscribes to and administers it.
+// @Tags Channels
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Success 200 {object} models.Channel
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Router /channels/{id} [get]
+func (c *ChannelController) GetChannel(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	channelID, ok := channelPathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	channel, err := c.channelService.GetChannel(ctx.Request.Context(), objUserID, channelID)
+	if err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, channel)
+}
+
+// UpdateChannel godoc
+// @Summary Update a channel
+// @Description Change a channel's name, description or avatar. Admins only.
+// @Tags Channels
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Param channel body models.UpdateChannelRequest true "Fields to change"
+// @Success 200 {object} models.Channel
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 403 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Router /channels/{id} [patch]
+func (c *ChannelController) UpdateChannel(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	channelID, ok := channelPathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateChannelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	channel, err := c.channelService.UpdateChannel(ctx.Request.Context(), objUserID, channelID, req)
+	if err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, channel)
+}
+
+// DeleteChannel godoc
+// @Summary Delete a channel
+// @Description Delete a channel with its subscriptions and posts. Owner only.
+// @Tags Channels
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Success 204
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 403 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Router /channels/{id} [delete]
+func (c *ChannelController) DeleteChannel(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	channelID, ok := channelPathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.channelService.DeleteChannel(ctx.Request.Context(), objUserID, channelID); err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// Subscribe godoc
+// @Summary Subscribe to a channel
+// @Description Subscribe the current user to a channel. New posts arrive as CHANNEL_POST_CREATED over the WebSocket. Subscribing again is a no-op.
+// @Tags Channels
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Success 200 {object} models.Channel
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Router /channels/{id}/subscribe [post]
+func (c *ChannelController) Subscribe(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	channelID, ok := channelPathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	channel, err := c.channelService.Subscribe(ctx.Request.Context(), objUserID, channelID)
+	if err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, channel)
+}
+
+// Unsubscribe godoc
+// @Summary Unsubscribe from a channel
+// @Description End the current user's subscription. Admins who unsubscribe lose their admin rights; the owner can't unsubscribe.
+// @Tags Channels
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Success 204
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Router /channels/{id}/subscribe [delete]
+func (c *ChannelController) Unsubscribe(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	channelID, ok := channelPathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.channelService.Unsubscribe(ctx.Request.Context(), objUserID, channelID); err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListSubscribers godoc
+// @Summary List channel subscribers
+// @Description Page through a channel's subscribers, oldest first. Pass next_cursor back as cursor for the next page. Admins only.
+// @Tags Channels
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Items per page (max 100)" default(50)
+// @Success 200 {object} models.ChannelSubscriberPage
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 403 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Router /channels/{id}/subscribers [get]
+func (c *ChannelController) ListSubscribers(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	channelID, ok := channelPathID(ctx, "id")
+	if !ok {
+		return
+	}
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "50"), 10, 64)
+
+	page, err := c.channelService.ListSubscribers(ctx.Request.Context(), objUserID, channelID, ctx.Query("cursor"), limit)
+	if err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// RemoveSubscriber godoc
+// @Summary Remove a channel subscriber
+// @Description Remove a subscriber from a channel. Admins only; only the owner removes other admins.
+// @Tags Channels
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Param userId path string true "Subscriber's user ID"
+// @Success 204
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 403 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Router /channels/{id}/subscribers/{userId} [delete]
+func (c *ChannelController) RemoveSubscriber(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	channelID, ok := channelPathID(ctx, "id")
+	if !ok {
+		return
+	}
+	targetID, ok := channelPathID(ctx, "userId")
+	if !ok {
+		return
+	}
+
+	if err := c.channelService.RemoveSubscriber(ctx.Request.Context(), objUserID, channelID, targetID); err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// AddAdmin godoc
+// @Summary Make a subscriber a channel admin
+// @Description Let a subscriber post to and manage the channel. Owner only; a channel has at most 50 admins.
+// @Tags Channels
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Param userId path string true "Subscriber's user ID"
+// @Success 200 {object} models.Channel
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 403 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Failure 409 {object} gin.H{"error":string}
+// @Router /channels/{id}/admins/{userId} [post]
+func (c *ChannelController) AddAdmin(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	channelID, ok := channelPathID(ctx, "id")
+	if !ok {
+		return
+	}
+	targetID, ok := channelPathID(ctx, "userId")
+	if !ok {
+		return
+	}
+
+	channel, err := c.channelService.AddAdmin(ctx.Request.Context(), objUserID, channelID, targetID)
+	if err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, channel)
+}
+
+// RemoveAdmin godoc
+// @Summary Demote a channel admin
+// @Description Take away an admin's rights; they stay subscribed. Owner only.
+// @Tags Channels
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Param userId path string true "Admin's user ID"
+// @Success 200 {object} models.Channel
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 403 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Router /channels/{id}/admins/{userId} [delete]
+func (c *ChannelController) RemoveAdmin(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	channelID, ok := channelPathID(ctx, "id")
+	if !ok {
+		return
+	}
+	targetID, ok := channelPathID(ctx, "userId")
+	if !ok {
+		return
+	}
+
+	channel, err := c.channelService.RemoveAdmin(ctx.Request.Context(), objUserID, channelID, targetID)
+	if err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, channel)
+}
+
+// CreatePost godoc
+// @Summary Post to a channel
+// @Description Broadcast a post to the channel's subscribers. Admins only. The post is saved right away and delivered to subscribers in pages in the background as CHANNEL_POST_CREATED.
+// @Tags Channels
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Param post body models.CreateChannelPostRequest true "Post content"
+// @Success 201 {object} models.ChannelPost
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 403 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Failure 503 {object} gin.H{"error":string}
+// @Router /channels/{id}/posts [post]
+func (c *ChannelController) CreatePost(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	channelID, ok := channelPathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var req models.CreateChannelPostRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	post, err := c.channelService.CreatePost(ctx.Request.Context(), objUserID, channelID, req)
+	if err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, post)
+}
+
+// ListPosts godoc
+// @Summary List channel posts
+// @Description Page through a channel's posts, newest first. Pass next_cursor back as cursor for older posts. Subscribers and admins only.
+// @Tags Channels
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Items per page (max 50)" default(20)
+// @Success 200 {object} models.ChannelPostPage
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 403 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Router /channels/{id}/posts [get]
+func (c *ChannelController) ListPosts(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	channelID, ok := channelPathID(ctx, "id")
+	if !ok {
+		return
+	}
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+
+	page, err := c.channelService.ListPosts(ctx.Request.Context(), objUserID, channelID, ctx.Query("cursor"), limit)
+	if err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// DeletePost godoc
+// @Summary Delete a channel post
+// @Description Delete a post from a channel. Admins only.
+// @Tags Channels
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Param postId path string true "Post ID"
+// @Success 204
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 403 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Router /channels/{id}/posts/{postId} [delete]
+func (c *ChannelController) DeletePost(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	channelID, ok := channelPathID(ctx, "id")
+	if !ok {
+		return
+	}
+	postID, ok := channelPathID(ctx, "postId")
+	if !ok {
+		return
+	}
+
+	if err := c.channelService.DeletePost(ctx.Request.Context(), objUserID, channelID, postID); err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RecordViews godoc
+// @Summary Record channel post views
+// @Description Count the current user towards the view counts of the posts they saw. Each subscriber counts once per post.
+// @Tags Channels
+// @Accept json
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Param views body models.RecordChannelViewsRequest true "Posts seen"
+// @Success 204
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 403 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Router /channels/{id}/posts/views [post]
+func (c *ChannelController) RecordViews(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	channelID, ok := channelPathID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var req models.RecordChannelViewsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.channelService.RecordViews(ctx.Request.Context(), objUserID, channelID, req.PostIDs); err != nil {
+		respondChannelError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// channelPathID parses an ID path parameter, answering 400 when it is malformed
+func channelPathID(ctx *gin.Context, param string) (primitive.ObjectID, bool) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param(param))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid "+param)
+		return primitive.NilObjectID, false
+	}
+	return id, true
+}
+
+func respondChannelError(ctx *gin.Context, err error) {
+	utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+}
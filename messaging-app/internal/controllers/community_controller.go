@@ -427,3 +427,131 @@ func (c *CommunityController) GetPendingMembers(ctx *gin.Context) {
 		"limit": limit,
 	})
 }
+
+func (c *CommunityController) CancelJoinRequest(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	communityID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid community ID")
+		return
+	}
+
+	if err := c.communityService.CancelJoinRequest(ctx, communityID, userID); err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *CommunityController) CreateInvite(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	communityID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid community ID")
+		return
+	}
+
+	var req models.CreateCommunityInviteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	invite, err := c.communityService.CreateInvite(ctx, communityID, userID, req)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, invite)
+}
+
+func (c *CommunityController) ListInvites(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	communityID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid community ID")
+		return
+	}
+
+	invites, err := c.communityService.ListInvites(ctx, communityID, userID)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, invites)
+}
+
+func (c *CommunityController) RevokeInvite(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	communityID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid community ID")
+		return
+	}
+
+	if err := c.communityService.RevokeInvite(ctx, communityID, userID, ctx.Param("code")); err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *CommunityController) PreviewInvite(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	preview, err := c.communityService.PreviewInvite(ctx, ctx.Param("code"), userID)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	c.signCommunityResponse(ctx, &preview.Community)
+
+	ctx.JSON(http.StatusOK, preview)
+}
+
+func (c *CommunityController) JoinWithInvite(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	community, err := c.communityService.JoinWithInvite(ctx, ctx.Param("code"), userID)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	c.signCommunityResponse(ctx, community)
+
+	ctx.JSON(http.StatusOK, community)
+}
@@ -0,0 +1,271 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"messaging-app/internal/services"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CommunityModerationController struct {
+	moderationService *services.CommunityModerationService
+}
+
+func NewCommunityModerationController(moderationService *services.CommunityModerationService) *CommunityModerationController {
+	return &CommunityModerationController{moderationService: moderationService}
+}
+
+// communityTarget reads the acting admin, the community and, unless param is
+// empty, the post or user ID in the named path parameter
+func communityTarget(ctx *gin.Context, param, what string) (actorID, communityID, targetID primitive.ObjectID, ok bool) {
+	actorID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	communityID, err = primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid community ID")
+		return
+	}
+	if param != "" {
+		targetID, err = primitive.ObjectIDFromHex(ctx.Param(param))
+		if err != nil {
+			utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid "+what+" ID")
+			return
+		}
+	}
+	return actorID, communityID, targetID, true
+}
+
+func (c *CommunityModerationController) RemovePost(ctx *gin.Context) {
+	actorID, communityID, postID, ok := communityTarget(ctx, "postId", "post")
+	if !ok {
+		return
+	}
+
+	var req models.CommunityModerationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	post, err := c.moderationService.RemovePost(ctx, communityID, actorID, postID, req.Reason)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, post)
+}
+
+func (c *CommunityModerationController) RestorePost(ctx *gin.Context) {
+	actorID, communityID, postID, ok := communityTarget(ctx, "postId", "post")
+	if !ok {
+		return
+	}
+
+	post, err := c.moderationService.RestorePost(ctx, communityID, actorID, postID)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, post)
+}
+
+func (c *CommunityModerationController) LockPost(ctx *gin.Context) {
+	actorID, communityID, postID, ok := communityTarget(ctx, "postId", "post")
+	if !ok {
+		return
+	}
+
+	var req models.CommunityModerationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	post, err := c.moderationService.LockPost(ctx, communityID, actorID, postID, req.Reason)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, post)
+}
+
+func (c *CommunityModerationController) UnlockPost(ctx *gin.Context) {
+	actorID, communityID, postID, ok := communityTarget(ctx, "postId", "post")
+	if !ok {
+		return
+	}
+
+	post, err := c.moderationService.UnlockPost(ctx, communityID, actorID, postID)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, post)
+}
+
+func (c *CommunityModerationController) MuteMember(ctx *gin.Context) {
+	actorID, communityID, userID, ok := communityTarget(ctx, "userId", "user")
+	if !ok {
+		return
+	}
+
+	var req models.MuteCommunityMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	mute, err := c.moderationService.MuteMember(ctx, communityID, actorID, userID, req)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mute)
+}
+
+func (c *CommunityModerationController) UnmuteMember(ctx *gin.Context) {
+	actorID, communityID, userID, ok := communityTarget(ctx, "userId", "user")
+	if !ok {
+		return
+	}
+
+	if err := c.moderationService.UnmuteMember(ctx, communityID, actorID, userID); err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *CommunityModerationController) ListMutes(ctx *gin.Context) {
+	actorID, communityID, _, ok := communityTarget(ctx, "", "")
+	if !ok {
+		return
+	}
+
+	mutes, err := c.moderationService.ListMutes(ctx, communityID, actorID)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mutes)
+}
+
+func (c *CommunityModerationController) BanMember(ctx *gin.Context) {
+	actorID, communityID, userID, ok := communityTarget(ctx, "userId", "user")
+	if !ok {
+		return
+	}
+
+	var req models.CommunityModerationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.moderationService.BanMember(ctx, communityID, actorID, userID, req.Reason); err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *CommunityModerationController) UnbanMember(ctx *gin.Context) {
+	actorID, communityID, userID, ok := communityTarget(ctx, "userId", "user")
+	if !ok {
+		return
+	}
+
+	if err := c.moderationService.UnbanMember(ctx, communityID, actorID, userID); err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *CommunityModerationController) ListBans(ctx *gin.Context) {
+	actorID, communityID, _, ok := communityTarget(ctx, "", "")
+	if !ok {
+		return
+	}
+
+	users, err := c.moderationService.ListBans(ctx, communityID, actorID)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, users)
+}
+
+func (c *CommunityModerationController) GetModerationLog(ctx *gin.Context) {
+	actorID, communityID, _, ok := communityTarget(ctx, "", "")
+	if !ok {
+		return
+	}
+	page, _ := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+
+	entries, err := c.moderationService.ModerationLog(ctx, communityID, actorID, page, limit)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entries)
+}
+
+func (c *CommunityModerationController) ListPendingPosts(ctx *gin.Context) {
+	actorID, communityID, _, ok := communityTarget(ctx, "", "")
+	if !ok {
+		return
+	}
+	page, _ := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+
+	pending, err := c.moderationService.ListPendingPosts(ctx, communityID, actorID, page, limit)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pending)
+}
+
+func (c *CommunityModerationController) ReviewPendingPosts(ctx *gin.Context) {
+	actorID, communityID, _, ok := communityTarget(ctx, "", "")
+	if !ok {
+		return
+	}
+
+	var req models.ReviewPendingPostsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.moderationService.ReviewPendingPosts(ctx, communityID, actorID, req)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -1,8 +1,9 @@
 package controllers
 
 import (
+	"errors"
 	"log"
-	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 	"messaging-app/internal/services"
 	"net/http"
 
@@ -33,7 +34,7 @@ func (c *ConversationController) GetConversationSummaries(ctx *gin.Context) {
 	currentUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		log.Printf("[%s] Error parsing user ID %s: %v", ctx.GetString("requestID"), userID, err)
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
@@ -41,10 +42,71 @@ func (c *ConversationController) GetConversationSummaries(ctx *gin.Context) {
 	summaries, err := c.conversationService.GetConversationSummaries(ctx.Request.Context(), currentUserID)
 	if err != nil {
 		log.Printf("[%s] Error from conversation service: %v", ctx.GetString("requestID"), err)
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "failed to retrieve conversation summaries"})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "failed to retrieve conversation summaries")
 		return
 	}
 
 	log.Printf("[%s] Successfully retrieved %d conversation summaries for user %s", ctx.GetString("requestID"), len(summaries), currentUserID.Hex())
 	ctx.JSON(http.StatusOK, summaries)
 }
+
+// @Summary List message requests
+// @Description Get the direct conversations, marketplace ones included, held in the current user's message requests. They are left out of the conversation list until accepted.
+// @Tags conversations
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.ConversationSummary
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/requests [get]
+func (c *ConversationController) GetMessageRequests(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	requests, err := c.conversationService.GetMessageRequests(ctx.Request.Context(), currentUserID)
+	if err != nil {
+		log.Printf("[%s] Error listing message requests: %v", ctx.GetString("requestID"), err)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "failed to retrieve message requests")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, requests)
+}
+
+// @Summary Sync conversation summaries
+// @Description Get the conversations that changed since a sync token. Without a token, or with an expired one, the whole inbox is returned with full_sync set.
+// @Tags conversations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param token query string false "Sync token from the previous response"
+// @Success 200 {object} models.InboxSyncResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/sync [get]
+func (c *ConversationController) SyncConversationSummaries(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	resp, err := c.conversationService.SyncConversationSummaries(ctx.Request.Context(), currentUserID, ctx.Query("token"))
+	if errors.Is(err, services.ErrInvalidSyncToken) {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("[%s] Error syncing conversation summaries: %v", ctx.GetString("requestID"), err)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "failed to sync conversation summaries")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"messaging-app/internal/services"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ConversationExportController struct {
+	exportService *services.ConversationExportService
+}
+
+func NewConversationExportController(exportService *services.ConversationExportService) *ConversationExportController {
+	return &ConversationExportController{exportService: exportService}
+}
+
+// RequestExport godoc
+// @Summary Export a conversation
+// @Description Queue a downloadable copy of a conversation as JSON or HTML. Poll the export until it is ready; the file is kept for 7 days.
+// @Tags conversations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID or other user's ID"
+// @Param is_group query bool false "Whether the conversation is a group"
+// @Param request body models.ConversationExportRequest false "Export format, json by default"
+// @Success 202 {object} models.ConversationExport
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /conversations/{id}/export [post]
+func (c *ConversationExportController) RequestExport(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.ConversationExportRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	isGroup, _ := strconv.ParseBool(ctx.DefaultQuery("is_group", "false"))
+	export, err := c.exportService.RequestExport(ctx.Request.Context(), objUserID, ctx.Param("id"), isGroup, req.Format)
+	if err != nil {
+		respondConversationExportError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, export)
+}
+
+// ListExports godoc
+// @Summary List conversation exports
+// @Description List the current user's conversation exports, newest first. Ready exports carry a download link valid for an hour.
+// @Tags conversations
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.ConversationExport
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/exports [get]
+func (c *ConversationExportController) ListExports(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	exports, err := c.exportService.ListExports(ctx.Request.Context(), objUserID)
+	if err != nil {
+		respondConversationExportError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exports)
+}
+
+// GetExport godoc
+// @Summary Get a conversation export
+// @Description Get the status of one of the current user's conversation exports, with a download link once it is ready.
+// @Tags conversations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param exportId path string true "Export ID"
+// @Success 200 {object} models.ConversationExport
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /conversations/exports/{exportId} [get]
+func (c *ConversationExportController) GetExport(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	export, err := c.exportService.GetExport(ctx.Request.Context(), objUserID, ctx.Param("exportId"))
+	if err != nil {
+		respondConversationExportError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, export)
+}
+
+func respondConversationExportError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrInvalidConversationID), errors.Is(err, services.ErrInvalidConversationExport):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrNotConversationParticipant):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrConversationExportNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrTooManyConversationExports):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrConversationExportDisabled):
+		status = http.StatusServiceUnavailable
+	}
+	utils.RespondWithError(ctx, status, err.Error())
+}
@@ -4,17 +4,18 @@ import (
 	"context"
 	"net/http"
 	"strconv"
-	"sync"
 	"time"
 
 	"messaging-app/internal/services"
 	"messaging-app/internal/storageclient"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/status"
 )
 
 type EventController struct {
@@ -100,7 +101,7 @@ func (c *EventController) CreateEvent(ctx *gin.Context) {
 
 	event, err := c.eventService.CreateEvent(ctx, userID, req)
 	if err != nil {
-		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		utils.RespondWithError(ctx, eventErrorStatus(err), err.Error())
 		return
 	}
 
@@ -150,7 +151,7 @@ func (c *EventController) UpdateEvent(ctx *gin.Context) {
 
 	response, err := c.eventService.UpdateEvent(ctx, eventID, userID, req)
 	if err != nil {
-		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		utils.RespondWithError(ctx, eventErrorStatus(err), err.Error())
 		return
 	}
 
@@ -399,7 +400,7 @@ func (c *EventController) CreatePost(ctx *gin.Context) {
 
 	post, err := c.eventService.CreatePost(ctx, eventID, userID, req)
 	if err != nil {
-		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		utils.RespondWithError(ctx, eventErrorStatus(err), err.Error())
 		return
 	}
 
@@ -708,244 +709,112 @@ func (c *EventController) GetNearbyEvents(ctx *gin.Context) {
 // Signing Helpers
 // ================================
 
-func (c *EventController) signUserShort(ctx context.Context, u *models.UserShort) {
-	if u.Avatar != "" {
-		signed, err := c.storageClient.GetPresignedURL(ctx, u.Avatar, 15*time.Minute)
-		if err == nil {
-			u.Avatar = signed
+const signedURLExpiry = 15 * time.Minute
+
+// signURLFields replaces each non-empty field with its presigned URL using a
+// single batch call. Fields that fail to sign keep their original value.
+func (c *EventController) signURLFields(ctx context.Context, fields ...*string) {
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if *f != "" {
+			keys = append(keys, *f)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	signed, _ := c.storageClient.SignURLs(ctx, signedURLExpiry, keys...)
+	for _, f := range fields {
+		if url, ok := signed[*f]; ok {
+			*f = url
 		}
 	}
 }
 
 func (c *EventController) signEvent(ctx context.Context, events ...*models.Event) {
-	if len(events) == 0 {
-		return
-	}
-	var wg sync.WaitGroup
+	var fields []*string
 	for _, e := range events {
-		if e == nil {
-			continue
+		if e != nil {
+			fields = append(fields, &e.CoverImage)
 		}
-		wg.Add(1)
-		go func(ev *models.Event) {
-			defer wg.Done()
-			if ev.CoverImage != "" {
-				signed, err := c.storageClient.GetPresignedURL(ctx, ev.CoverImage, 15*time.Minute)
-				if err == nil {
-					ev.CoverImage = signed
-				}
-			}
-		}(e)
 	}
-	wg.Wait()
+	c.signURLFields(ctx, fields...)
 }
 
 func (c *EventController) signEventResponse(ctx context.Context, responses ...*models.EventResponse) {
-	if len(responses) == 0 {
-		return
-	}
-	var wg sync.WaitGroup
-	for _, r := range responses {
-		if r == nil {
+	var fields []*string
+	for _, res := range responses {
+		if res == nil {
 			continue
 		}
-		wg.Add(1)
-		go func(res *models.EventResponse) {
-			defer wg.Done()
-			var localWg sync.WaitGroup
-
-			if res.CoverImage != "" {
-				localWg.Add(1)
-				go func() {
-					defer localWg.Done()
-					signed, err := c.storageClient.GetPresignedURL(ctx, res.CoverImage, 15*time.Minute)
-					if err == nil {
-						res.CoverImage = signed
-					}
-				}()
-			}
-
-			// Creator Avatar
-			localWg.Add(1)
-			go func() {
-				defer localWg.Done()
-				c.signUserShort(ctx, &res.Creator)
-			}()
-
-			// Friends Going Avatars
-			if len(res.FriendsGoing) > 0 {
-				localWg.Add(len(res.FriendsGoing))
-				for i := range res.FriendsGoing {
-					go func(idx int) {
-						defer localWg.Done()
-						c.signUserShort(ctx, &res.FriendsGoing[idx])
-					}(i)
-				}
-			}
-
-			localWg.Wait()
-		}(r)
+		fields = append(fields, &res.CoverImage, &res.Creator.Avatar)
+		for i := range res.FriendsGoing {
+			fields = append(fields, &res.FriendsGoing[i].Avatar)
+		}
 	}
-	wg.Wait()
+	c.signURLFields(ctx, fields...)
 }
 
 func (c *EventController) signInvitationResponse(ctx context.Context, invitations ...*models.EventInvitationResponse) {
-	if len(invitations) == 0 {
-		return
-	}
-	var wg sync.WaitGroup
+	var fields []*string
 	for _, inv := range invitations {
-		if inv == nil {
-			continue
+		if inv != nil {
+			fields = append(fields, &inv.Event.CoverImage, &inv.Inviter.Avatar)
 		}
-		wg.Add(1)
-		go func(i *models.EventInvitationResponse) {
-			defer wg.Done()
-			var localWg sync.WaitGroup
-
-			// Event Cover Image
-			if i.Event.CoverImage != "" {
-				localWg.Add(1)
-				go func() {
-					defer localWg.Done()
-					signed, err := c.storageClient.GetPresignedURL(ctx, i.Event.CoverImage, 15*time.Minute)
-					if err == nil {
-						i.Event.CoverImage = signed
-					}
-				}()
-			}
-
-			// Inviter Avatar
-			localWg.Add(1)
-			go func() {
-				defer localWg.Done()
-				c.signUserShort(ctx, &i.Inviter)
-			}()
-
-			localWg.Wait()
-		}(inv)
 	}
-	wg.Wait()
+	c.signURLFields(ctx, fields...)
 }
 
 func (c *EventController) signAttendeeResponse(ctx context.Context, response *models.AttendeesListResponse) {
-	if response == nil || len(response.Attendees) == 0 {
+	if response == nil {
 		return
 	}
-	var wg sync.WaitGroup
-	wg.Add(len(response.Attendees))
+	fields := make([]*string, 0, len(response.Attendees))
 	for i := range response.Attendees {
-		go func(idx int) {
-			defer wg.Done()
-			c.signUserShort(ctx, &response.Attendees[idx].User)
-		}(i)
+		fields = append(fields, &response.Attendees[i].User.Avatar)
 	}
-	wg.Wait()
+	c.signURLFields(ctx, fields...)
 }
 
 func (c *EventController) signEventPostResponse(ctx context.Context, posts ...*models.EventPostResponse) {
-	if len(posts) == 0 {
-		return
-	}
-	var wg sync.WaitGroup
-	for _, p := range posts {
-		if p == nil {
+	var fields []*string
+	for _, post := range posts {
+		if post == nil {
 			continue
 		}
-		wg.Add(1)
-		go func(post *models.EventPostResponse) {
-			defer wg.Done()
-			var localWg sync.WaitGroup
-
-			// Media URLs
-			if len(post.MediaURLs) > 0 {
-				localWg.Add(len(post.MediaURLs))
-				for i, url := range post.MediaURLs {
-					go func(idx int, u string) {
-						defer localWg.Done()
-						if u == "" {
-							return
-						}
-						signed, err := c.storageClient.GetPresignedURL(ctx, u, 15*time.Minute)
-						if err == nil {
-							post.MediaURLs[idx] = signed
-						}
-					}(i, url)
-				}
-			}
-
-			// Author Avatar
-			localWg.Add(1)
-			go func() {
-				defer localWg.Done()
-				c.signUserShort(ctx, &post.Author)
-			}()
-
-			// Reactions Avatars
-			if len(post.Reactions) > 0 {
-				localWg.Add(len(post.Reactions))
-				for i := range post.Reactions {
-					go func(idx int) {
-						defer localWg.Done()
-						c.signUserShort(ctx, &post.Reactions[idx].User)
-					}(i)
-				}
-			}
-
-			localWg.Wait()
-		}(p)
+		for i := range post.MediaURLs {
+			fields = append(fields, &post.MediaURLs[i])
+		}
+		fields = append(fields, &post.Author.Avatar)
+		for i := range post.Reactions {
+			fields = append(fields, &post.Reactions[i].User.Avatar)
+		}
 	}
-	wg.Wait()
+	c.signURLFields(ctx, fields...)
 }
 
 func (c *EventController) signBirthdayResponse(ctx context.Context, responses ...*models.BirthdayResponse) {
-	if len(responses) == 0 {
-		return
-	}
-	var wg sync.WaitGroup
-	for _, r := range responses {
-		if r == nil {
+	var fields []*string
+	for _, res := range responses {
+		if res == nil {
 			continue
 		}
-		wg.Add(1)
-		go func(res *models.BirthdayResponse) {
-			defer wg.Done()
-			var localWg sync.WaitGroup
-
-			// Today Avatars
-			if len(res.Today) > 0 {
-				localWg.Add(len(res.Today))
-				for i := range res.Today {
-					go func(idx int) {
-						defer localWg.Done()
-						if res.Today[idx].Avatar != "" {
-							signed, err := c.storageClient.GetPresignedURL(ctx, res.Today[idx].Avatar, 15*time.Minute)
-							if err == nil {
-								res.Today[idx].Avatar = signed
-							}
-						}
-					}(i)
-				}
-			}
-
-			// Upcoming Avatars
-			if len(res.Upcoming) > 0 {
-				localWg.Add(len(res.Upcoming))
-				for i := range res.Upcoming {
-					go func(idx int) {
-						defer localWg.Done()
-						if res.Upcoming[idx].Avatar != "" {
-							signed, err := c.storageClient.GetPresignedURL(ctx, res.Upcoming[idx].Avatar, 15*time.Minute)
-							if err == nil {
-								res.Upcoming[idx].Avatar = signed
-							}
-						}
-					}(i)
-				}
-			}
+		for i := range res.Today {
+			fields = append(fields, &res.Today[i].Avatar)
+		}
+		for i := range res.Upcoming {
+			fields = append(fields, &res.Upcoming[i].Avatar)
+		}
+	}
+	c.signURLFields(ctx, fields...)
+}
 
-			localWg.Wait()
-		}(r)
+// eventErrorStatus maps events service errors, recognizing text the
+// moderation rules rejected
+func eventErrorStatus(err error) int {
+	if status.Convert(err).Message() == moderation.ErrContentRejected.Error() {
+		return http.StatusUnprocessableEntity
 	}
-	wg.Wait()
+	return utils.GetStatusCode(err)
 }
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"io"
 	"messaging-app/internal/feedclient"
 	"messaging-app/internal/services"
@@ -12,10 +13,14 @@ import (
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/pkg/pagination"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type FeedController struct {
@@ -24,10 +29,11 @@ type FeedController struct {
 	privacyService *services.PrivacyService
 	storageClient  *storageclient.Client
 	feedClient     *feedclient.Client
+	watchHistory   *services.WatchHistoryService
 }
 
-func NewFeedController(feedService *services.FeedService, userService *services.UserService, privacyService *services.PrivacyService, storageClient *storageclient.Client, feedClient *feedclient.Client) *FeedController {
-	return &FeedController{feedService: feedService, userService: userService, privacyService: privacyService, storageClient: storageClient, feedClient: feedClient}
+func NewFeedController(feedService *services.FeedService, userService *services.UserService, privacyService *services.PrivacyService, storageClient *storageclient.Client, feedClient *feedclient.Client, watchHistory *services.WatchHistoryService) *FeedController {
+	return &FeedController{feedService: feedService, userService: userService, privacyService: privacyService, storageClient: storageClient, feedClient: feedClient, watchHistory: watchHistory}
 }
 
 // CreatePost godoc
@@ -46,7 +52,7 @@ func (c *FeedController) CreatePost(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
@@ -58,7 +64,7 @@ func (c *FeedController) CreatePost(ctx *gin.Context) {
 		// Parse multipart form
 		form, err := ctx.MultipartForm()
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to parse multipart form: " + err.Error()})
+			utils.RespondWithError(ctx, http.StatusBadRequest, "failed to parse multipart form: "+err.Error())
 			return
 		}
 
@@ -75,14 +81,15 @@ func (c *FeedController) CreatePost(ctx *gin.Context) {
 				req.Mentions = append(req.Mentions, id)
 			}
 		}
+		req.PollOptions = ctx.PostFormArray("poll_options[]")
 
 		// Validate required fields
 		if req.Content == "" {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "content is required"})
+			utils.RespondWithError(ctx, http.StatusBadRequest, "content is required")
 			return
 		}
 		if privacyStr == "" {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "privacy is required"})
+			utils.RespondWithError(ctx, http.StatusBadRequest, "privacy is required")
 			return
 		}
 
@@ -101,7 +108,7 @@ func (c *FeedController) CreatePost(ctx *gin.Context) {
 				f.Close()
 				result, err := c.storageClient.Upload(ctx.Request.Context(), data, fh.Filename, fh.Header.Get("Content-Type"))
 				if err != nil {
-					ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to upload files: " + err.Error()})
+					utils.RespondWithError(ctx, http.StatusInternalServerError, "failed to upload files: "+err.Error())
 					return
 				}
 				mediaItems = append(mediaItems, models.MediaItem{URL: result.URL, Type: result.Type})
@@ -111,14 +118,22 @@ func (c *FeedController) CreatePost(ctx *gin.Context) {
 	} else {
 		// Handle standard JSON request (no files)
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 			return
 		}
 	}
 
 	post, err := c.feedService.CreatePost(ctx.Request.Context(), objID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, services.ErrInvalidPoll) {
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, moderation.ErrContentRejected) {
+			utils.RespondWithError(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -141,23 +156,24 @@ func (c *FeedController) GetPostByID(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	postID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid post ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid post ID")
 		return
 	}
 
 	post, err := c.feedService.GetPostByID(ctx.Request.Context(), objUserID, postID)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+		utils.RespondWithError(ctx, http.StatusNotFound, "post not found")
 		return
 	}
 
 	c.signPostMedia(ctx, post)
+	attachVideoResumePositions(ctx, c.watchHistory, []models.Post{*post}) // The copy shares post's media
 
 	ctx.JSON(http.StatusOK, post)
 }
@@ -180,37 +196,95 @@ func (c *FeedController) UpdatePost(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	postID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid post ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid post ID")
 		return
 	}
 
 	var req models.UpdatePostRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	updatedPost, err := c.feedService.UpdatePost(ctx.Request.Context(), objUserID, postID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, moderation.ErrContentRejected) {
+			utils.RespondWithError(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	ctx.JSON(http.StatusOK, updatedPost)
 }
 
+// SharePost godoc
+// @Summary Share a post to the user's timeline
+// @Description Reshares a post, crediting the original author. Friends-only posts can only be shared with a custom audience of the author's friends.
+// @Security BearerAuth
+// @Tags feed
+// @Accept json
+// @Produce json
+// @Param id path string true "Post ID"
+// @Param body body models.SharePostRequest true "Share data"
+// @Success 201 {object} models.Post
+// @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/posts/{id}/share [post]
+func (c *FeedController) SharePost(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	objUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	postID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid post ID")
+		return
+	}
+
+	var req models.SharePostRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	post, err := c.feedService.SharePost(ctx.Request.Context(), objUserID, postID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPostNotFound):
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, models.ErrPostNotShareable), errors.Is(err, models.ErrShareExceedsAudience):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, moderation.ErrContentRejected):
+			utils.RespondWithError(ctx, http.StatusUnprocessableEntity, err.Error())
+		default:
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, post)
+}
+
 // DeletePost godoc
-// @Summary Delete a post
+// @Summary Move a post to the trash, or delete it permanently
 // @Security BearerAuth
 // @Tags feed
 // @Produce json
 // @Param id path string true "Post ID"
+// @Param permanent query bool false "Skip the trash and delete the post for good"
 // @Success 200 {object} models.SuccessResponse
 // @Failure 400 {object} gin.H
 // @Failure 401 {object} gin.H
@@ -221,25 +295,245 @@ func (c *FeedController) DeletePost(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	postID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid post ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid post ID")
+		return
+	}
+
+	permanent := ctx.Query("permanent") == "true"
+	err = c.feedService.DeletePost(ctx.Request.Context(), objUserID, postID, permanent)
+	if err != nil {
+		if errors.Is(err, services.ErrPostInTrash) {
+			utils.RespondWithError(ctx, http.StatusConflict, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{Success: true})
+}
+
+// VotePoll godoc
+// @Summary Vote on a poll post
+// @Description Records the user's vote, replacing an earlier one, and returns the poll's current results
+// @Security BearerAuth
+// @Tags feed
+// @Accept json
+// @Produce json
+// @Param id path string true "Post ID"
+// @Param vote body models.VotePollRequest true "Option to vote for"
+// @Success 200 {object} models.PollVoteUpdatedEvent
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/posts/{id}/poll/vote [post]
+func (c *FeedController) VotePoll(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	objUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	postID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid post ID")
+		return
+	}
+
+	var req models.VotePollRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, err := c.feedService.VotePoll(ctx.Request.Context(), objUserID, postID, req.OptionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPostNotFound):
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrNotAPoll), errors.Is(err, services.ErrInvalidPollOption):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		default:
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results)
+}
+
+// RestorePost godoc
+// @Summary Restore a post from the trash
+// @Security BearerAuth
+// @Tags feed
+// @Produce json
+// @Param id path string true "Post ID"
+// @Success 200 {object} models.Post
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/posts/{id}/restore [post]
+func (c *FeedController) RestorePost(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	objUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	postID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid post ID")
+		return
+	}
+
+	post, err := c.feedService.RestorePost(ctx.Request.Context(), objUserID, postID)
+	if err != nil {
+		if errors.Is(err, services.ErrPostNotFound) {
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.signPostMedia(ctx, post)
+	ctx.JSON(http.StatusOK, post)
+}
+
+// PinPost godoc
+// @Summary Pin a post to the top of its feed
+// @Description Authors pin posts to their profile; community admins pin community posts. Each feed has one pin, which this replaces.
+// @Security BearerAuth
+// @Tags feed
+// @Produce json
+// @Param id path string true "Post ID"
+// @Success 200 {object} models.Post
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/posts/{id}/pin [post]
+func (c *FeedController) PinPost(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	objUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	postID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid post ID")
+		return
+	}
+
+	post, err := c.feedService.PinPost(ctx.Request.Context(), objUserID, postID)
+	if err != nil {
+		writePinError(ctx, err)
+		return
+	}
+
+	c.signPostMedia(ctx, post)
+	ctx.JSON(http.StatusOK, post)
+}
+
+// UnpinPost godoc
+// @Summary Unpin a post
+// @Security BearerAuth
+// @Tags feed
+// @Produce json
+// @Param id path string true "Post ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/posts/{id}/pin [delete]
+func (c *FeedController) UnpinPost(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	objUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
-	err = c.feedService.DeletePost(ctx.Request.Context(), objUserID, postID)
+	postID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid post ID")
+		return
+	}
+
+	if err := c.feedService.UnpinPost(ctx.Request.Context(), objUserID, postID); err != nil {
+		writePinError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, models.SuccessResponse{Success: true})
 }
 
+func writePinError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrPostNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrPostPinNotAllowed):
+		utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrPostNotPinnable):
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+	default:
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// ListTrash godoc
+// @Summary List the user's trashed posts (paginated)
+// @Security BearerAuth
+// @Tags feed
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} models.FeedResponse
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/posts/trash [get]
+func (c *FeedController) ListTrash(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	objUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	page, _ := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	response, err := c.feedService.ListTrash(ctx.Request.Context(), objUserID, page, limit)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	for i := range response.Posts {
+		c.signPostMedia(ctx, &response.Posts[i])
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
 // ListPosts godoc
 // @Summary List posts (paginated)
 // @Security BearerAuth
@@ -249,6 +543,7 @@ func (c *FeedController) DeletePost(ctx *gin.Context) {
 // @Param limit query int false "Items per page" default(20)
 // @Param sortBy query string false "Sort by field (e.g., created_at, reaction_count, comment_count)" default(created_at)
 // @Param sortOrder query string false "Sort order (asc, desc)" default(desc)
+// @Param cursor query string false "Cursor from a previous response's next_cursor; replaces page"
 // @Success 200 {object} models.FeedResponse
 // @Failure 400 {object} gin.H
 // @Failure 401 {object} gin.H
@@ -294,7 +589,7 @@ func (c *FeedController) ListPosts(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
@@ -316,9 +611,15 @@ func (c *FeedController) ListPosts(ctx *gin.Context) {
 	mediaType := ctx.Query("media_type")
 	status := ctx.Query("status")
 
-	response, err := c.feedService.ListPosts(ctx.Request.Context(), objUserID, filterUserID, communityID, page, limit, sortBy, sortOrder, hasMedia, mediaType, status)
+	cursor := ctx.Query("cursor")
+
+	response, err := c.feedService.ListPosts(ctx.Request.Context(), objUserID, filterUserID, communityID, page, limit, sortBy, sortOrder, hasMedia, mediaType, status, cursor)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, pagination.ErrInvalidCursor) || errors.Is(err, services.ErrCursorSortUnsupported) || errors.Is(err, services.ErrPostStatusNotListable) {
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -332,10 +633,50 @@ func (c *FeedController) ListPosts(ctx *gin.Context) {
 		}(&response.Posts[i])
 	}
 	wg.Wait()
+	attachVideoResumePositions(ctx, c.watchHistory, response.Posts)
 
 	ctx.JSON(http.StatusOK, response)
 }
 
+// GetTrendingHashtags godoc
+// @Summary Get trending hashtags
+// @Description Hashtags ranked by recent use in public posts, with older uses counting less. Worldwide unless a region is given.
+// @Security BearerAuth
+// @Tags feed
+// @Produce json
+// @Param region query string false "Region, matched against the last part of post locations"
+// @Param window query string false "Time window: 1h, 24h or 7d" default(24h)
+// @Param limit query int false "Number of hashtags" default(10)
+// @Success 200 {object} models.TrendingHashtagsResponse
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Failure 503 {object} gin.H
+// @Router /api/trending/hashtags [get]
+func (c *FeedController) GetTrendingHashtags(ctx *gin.Context) {
+	if c.feedClient == nil {
+		utils.RespondWithError(ctx, http.StatusServiceUnavailable, "trending hashtags are unavailable")
+		return
+	}
+
+	limit, err := strconv.ParseInt(ctx.DefaultQuery("limit", "10"), 10, 32)
+	if err != nil || limit < 1 {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "limit must be a positive integer")
+		return
+	}
+
+	response, err := c.feedClient.GetTrendingHashtags(ctx.Request.Context(), ctx.Query("region"), ctx.Query("window"), int32(limit))
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			utils.RespondWithError(ctx, http.StatusBadRequest, status.Convert(err).Message())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
 // GetPostsByHashtag godoc
 // @Summary Get posts by hashtag (paginated)
 // @Security BearerAuth
@@ -353,13 +694,13 @@ func (c *FeedController) GetPostsByHashtag(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	hashtag := ctx.Param("hashtag")
 	if hashtag == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "hashtag cannot be empty"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "hashtag cannot be empty")
 		return
 	}
 
@@ -375,7 +716,7 @@ func (c *FeedController) GetPostsByHashtag(ctx *gin.Context) {
 
 	response, err := c.feedService.GetPostsByHashtag(ctx.Request.Context(), objUserID, hashtag, page, limit)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -388,6 +729,7 @@ func (c *FeedController) GetPostsByHashtag(ctx *gin.Context) {
 		}(&response.Posts[i])
 	}
 	wg.Wait()
+	attachVideoResumePositions(ctx, c.watchHistory, response.Posts)
 
 	ctx.JSON(http.StatusOK, response)
 }
@@ -430,6 +772,7 @@ func (c *FeedController) UpdatePostStatus(ctx *gin.Context) {
 // @Param postId path string true "Post ID" d
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(20)
+// @Param sort query string false "oldest, newest or relevant (friends, the post's author, reactions and recency)" default(oldest)
 // @Success 200 {object} []models.Comment
 // @Failure 400 {object} gin.H
 // @Failure 401 {object} gin.H
@@ -437,18 +780,31 @@ func (c *FeedController) UpdatePostStatus(ctx *gin.Context) {
 // @Failure 500 {object} gin.H
 // @Router /api/posts/{postId}/comments [get]
 func (c *FeedController) GetCommentsByPostID(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	objUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
 	postID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid post ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid post ID")
 		return
 	}
 
 	page, _ := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
 	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	sort := models.CommentSort(ctx.DefaultQuery("sort", string(models.CommentSortOldest)))
 
-	comments, err := c.feedService.GetCommentsByPostID(ctx.Request.Context(), postID, page, limit)
+	comments, err := c.feedService.GetCommentsByPostID(ctx.Request.Context(), objUserID, postID, page, limit, sort)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
 		return
 	}
 
@@ -456,7 +812,8 @@ func (c *FeedController) GetCommentsByPostID(ctx *gin.Context) {
 }
 
 // GetRepliesByCommentID godoc
-// @Summary Get replies for a comment
+// @Summary Get the replies made directly to a comment
+// @Description Replies nested under a reply are left out; page through them with /api/comments/{commentId}/replies/{replyId}/replies when the reply's reply_count is above zero
 // @Security BearerAuth
 // @Tags feed
 // @Produce json
@@ -472,22 +829,70 @@ func (c *FeedController) GetCommentsByPostID(ctx *gin.Context) {
 func (c *FeedController) GetRepliesByCommentID(ctx *gin.Context) {
 	commentID, err := primitive.ObjectIDFromHex(ctx.Param("commentId"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid comment ID")
 		return
 	}
 
-	page, _ := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
-	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
-
+	page, limit := replyPage(ctx)
 	replies, err := c.feedService.GetRepliesByCommentID(ctx.Request.Context(), commentID, page, limit)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	ctx.JSON(http.StatusOK, replies)
 }
 
+// GetRepliesByParentReplyID godoc
+// @Summary Get the replies made directly to a reply
+// @Security BearerAuth
+// @Tags feed
+// @Produce json
+// @Param commentId path string true "Comment ID"
+// @Param replyId path string true "Reply ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} []models.Reply
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Failure 404 {object} gin.H
+// @Failure 500 {object} gin.H
+// @Router /api/comments/{commentId}/replies/{replyId}/replies [get]
+func (c *FeedController) GetRepliesByParentReplyID(ctx *gin.Context) {
+	commentID, err := primitive.ObjectIDFromHex(ctx.Param("commentId"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid comment ID")
+		return
+	}
+	replyID, err := primitive.ObjectIDFromHex(ctx.Param("replyId"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid reply ID")
+		return
+	}
+
+	page, limit := replyPage(ctx)
+	replies, err := c.feedService.GetRepliesByParentReplyID(ctx.Request.Context(), commentID, replyID, page, limit)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, replies)
+}
+
+// replyPage reads page and limit, falling back to the first 20 replies
+func replyPage(ctx *gin.Context) (int64, int64) {
+	page, _ := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return page, limit
+}
+
 // GetReactionsByPostID godoc
 // @Summary Get reactions for a post
 // @Security BearerAuth
@@ -503,13 +908,13 @@ func (c *FeedController) GetRepliesByCommentID(ctx *gin.Context) {
 func (c *FeedController) GetReactionsByPostID(ctx *gin.Context) {
 	postID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid post ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid post ID")
 		return
 	}
 
 	reactions, err := c.feedService.GetReactionsByTargetID(ctx.Request.Context(), postID, "post")
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -531,13 +936,13 @@ func (c *FeedController) GetReactionsByPostID(ctx *gin.Context) {
 func (c *FeedController) GetReactionsByCommentID(ctx *gin.Context) {
 	commentID, err := primitive.ObjectIDFromHex(ctx.Param("commentId"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid comment ID")
 		return
 	}
 
 	reactions, err := c.feedService.GetReactionsByTargetID(ctx.Request.Context(), commentID, "comment")
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -559,13 +964,13 @@ func (c *FeedController) GetReactionsByCommentID(ctx *gin.Context) {
 func (c *FeedController) GetReactionsByReplyID(ctx *gin.Context) {
 	replyID, err := primitive.ObjectIDFromHex(ctx.Param("replyId"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid reply ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid reply ID")
 		return
 	}
 
 	reactions, err := c.feedService.GetReactionsByTargetID(ctx.Request.Context(), replyID, "reply")
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -588,19 +993,23 @@ func (c *FeedController) CreateComment(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req models.CreateCommentRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	comment, err := c.feedService.CreateComment(ctx.Request.Context(), objID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, moderation.ErrContentRejected) {
+			utils.RespondWithError(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -625,25 +1034,29 @@ func (c *FeedController) UpdateComment(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	commentID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid comment ID")
 		return
 	}
 
 	var req models.UpdateCommentRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	updatedComment, err := c.feedService.UpdateComment(ctx.Request.Context(), objUserID, commentID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, moderation.ErrContentRejected) {
+			utils.RespondWithError(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -667,25 +1080,25 @@ func (c *FeedController) DeleteComment(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	postID, err := primitive.ObjectIDFromHex(ctx.Param("postId"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid post ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid post ID")
 		return
 	}
 
 	commentID, err := primitive.ObjectIDFromHex(ctx.Param("commentId"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid comment ID")
 		return
 	}
 
 	err = c.feedService.DeleteComment(ctx.Request.Context(), objUserID, postID, commentID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -694,6 +1107,7 @@ func (c *FeedController) DeleteComment(ctx *gin.Context) {
 
 // CreateReply godoc
 // @Summary Create a new reply to a comment
+// @Description Set parent_reply_id to answer another reply of the comment; replies nest up to REPLY_MAX_DEPTH levels
 // @Security BearerAuth
 // @Tags feed
 // @Accept json
@@ -702,32 +1116,37 @@ func (c *FeedController) DeleteComment(ctx *gin.Context) {
 // @Success 201 {object} models.Reply
 // @Failure 400 {object} gin.H
 // @Failure 401 {object} gin.H
+// @Failure 404 {object} gin.H
 // @Failure 500 {object} gin.H
 // @Router /api/comments/{commentId}/replies [post]
 func (c *FeedController) CreateReply(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	commentID, err := primitive.ObjectIDFromHex(ctx.Param("commentId"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid comment ID")
 		return
 	}
 
 	var req models.CreateReplyRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	req.CommentID = commentID
 	reply, err := c.feedService.CreateReply(ctx.Request.Context(), objID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, moderation.ErrContentRejected) {
+			utils.RespondWithError(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
 		return
 	}
 
@@ -753,32 +1172,36 @@ func (c *FeedController) UpdateReply(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	commentID, err := primitive.ObjectIDFromHex(ctx.Param("commentId"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid comment ID")
 		return
 	}
 
 	replyID, err := primitive.ObjectIDFromHex(ctx.Param("replyId"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid reply ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid reply ID")
 		return
 	}
 
 	var req models.UpdateReplyRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	req.CommentID = commentID
 	updatedReply, err := c.feedService.UpdateReply(ctx.Request.Context(), objUserID, replyID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, moderation.ErrContentRejected) {
+			utils.RespondWithError(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -786,7 +1209,7 @@ func (c *FeedController) UpdateReply(ctx *gin.Context) {
 }
 
 // DeleteReply godoc
-// @Summary Delete a reply
+// @Summary Delete a reply and the replies nested under it
 // @Security BearerAuth
 // @Tags feed
 // @Produce json
@@ -802,25 +1225,25 @@ func (c *FeedController) DeleteReply(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	commentID, err := primitive.ObjectIDFromHex(ctx.Param("commentId"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid comment ID")
 		return
 	}
 
 	replyID, err := primitive.ObjectIDFromHex(ctx.Param("replyId"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid reply ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid reply ID")
 		return
 	}
 
 	err = c.feedService.DeleteReply(ctx.Request.Context(), objUserID, commentID, replyID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
 		return
 	}
 
@@ -828,13 +1251,15 @@ func (c *FeedController) DeleteReply(ctx *gin.Context) {
 }
 
 // CreateReaction godoc
-// @Summary Create a new reaction on a post, comment, or reply
+// @Summary React to a post, comment, or reply
+// @Description A user has one reaction per target. Sending a new type replaces the old one (action "changed"); sending the type the user already has removes it (action "removed").
 // @Security BearerAuth
 // @Tags feed
 // @Accept json
 // @Produce json
 // @Param body body models.CreateReactionRequest true "Reaction creation data"
-// @Success 201 {object} models.Reaction
+// @Success 201 {object} models.ReactionResponse "The reaction was added"
+// @Success 200 {object} models.ReactionResponse "The reaction was changed or removed"
 // @Failure 400 {object} gin.H
 // @Failure 401 {object} gin.H
 // @Failure 500 {object} gin.H
@@ -843,23 +1268,27 @@ func (c *FeedController) CreateReaction(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req models.CreateReactionRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	reaction, err := c.feedService.CreateReaction(ctx.Request.Context(), objID, &req)
+	resp, err := c.feedService.CreateReaction(ctx.Request.Context(), objID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, reaction)
+	statusCode := http.StatusOK
+	if resp.Action == models.ReactionAdded {
+		statusCode = http.StatusCreated
+	}
+	ctx.JSON(statusCode, resp)
 }
 
 // DeleteReaction godoc
@@ -880,26 +1309,26 @@ func (c *FeedController) DeleteReaction(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	reactionID, err := primitive.ObjectIDFromHex(ctx.Param("reactionId"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid reaction ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid reaction ID")
 		return
 	}
 
 	targetID, err := primitive.ObjectIDFromHex(ctx.Query("targetId"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid target ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid target ID")
 		return
 	}
 	targetType := ctx.Query("targetType")
 
 	err = c.feedService.DeleteReaction(ctx.Request.Context(), objUserID, reactionID, targetID, targetType)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
 		return
 	}
 
@@ -924,19 +1353,19 @@ func (c *FeedController) CreateAlbum(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req models.CreateAlbumRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	album, err := c.feedService.CreateAlbum(ctx.Request.Context(), objID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -961,29 +1390,29 @@ func (c *FeedController) UpdateAlbum(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	albumID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid album ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid album ID")
 		return
 	}
 
 	var req models.UpdateAlbumRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	album, err := c.feedService.UpdateAlbum(ctx.Request.Context(), objUserID, albumID, &req)
 	if err != nil {
 		if err.Error() == "cannot modify system albums" {
-			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -1005,7 +1434,7 @@ func (c *FeedController) GetUserAlbums(ctx *gin.Context) {
 	userID := ctx.Param("id")
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
@@ -1017,7 +1446,7 @@ func (c *FeedController) GetUserAlbums(ctx *gin.Context) {
 
 	albums, err := c.feedService.GetUserAlbums(ctx.Request.Context(), objUserID, limit, offset)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -1038,30 +1467,30 @@ func (c *FeedController) GetUserAlbums(ctx *gin.Context) {
 func (c *FeedController) GetAlbum(ctx *gin.Context) {
 	albumID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid album ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid album ID")
 		return
 	}
 
 	// SECURITY: Get current user ID for authorization check
 	userIDValue, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "user not authenticated")
 		return
 	}
 	userIDStr, ok := userIDValue.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user ID format"})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "invalid user ID format")
 		return
 	}
 	userID, err := primitive.ObjectIDFromHex(userIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	album, err := c.feedService.GetAlbum(ctx.Request.Context(), albumID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -1083,7 +1512,7 @@ func (c *FeedController) GetAlbum(ctx *gin.Context) {
 			// Get album owner's info to check friends list
 			owner, err := c.userService.GetUserByID(ctx.Request.Context(), album.UserID)
 			if err != nil {
-				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify access"})
+				utils.RespondWithError(ctx, http.StatusInternalServerError, "failed to verify access")
 				return
 			}
 			// Check if requester is in owner's friends list
@@ -1095,12 +1524,12 @@ func (c *FeedController) GetAlbum(ctx *gin.Context) {
 				}
 			}
 			if !isFriend {
-				ctx.JSON(http.StatusForbidden, gin.H{"error": "this album is only visible to friends"})
+				utils.RespondWithError(ctx, http.StatusForbidden, "this album is only visible to friends")
 				return
 			}
 		} else {
 			// ONLY_ME or other private settings - only owner can see
-			ctx.JSON(http.StatusForbidden, gin.H{"error": "you don't have permission to view this album"})
+			utils.RespondWithError(ctx, http.StatusForbidden, "you don't have permission to view this album")
 			return
 		}
 	}
@@ -1125,25 +1554,25 @@ func (c *FeedController) AddMediaToAlbum(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	albumID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid album ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid album ID")
 		return
 	}
 
 	var req models.AddMediaToAlbumRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	err = c.feedService.AddMediaToAlbum(ctx.Request.Context(), objUserID, albumID, req.Media)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -1165,7 +1594,7 @@ func (c *FeedController) AddMediaToAlbum(ctx *gin.Context) {
 func (c *FeedController) GetAlbumMedia(ctx *gin.Context) {
 	albumID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid album ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid album ID")
 		return
 	}
 
@@ -1184,7 +1613,7 @@ func (c *FeedController) GetAlbumMedia(ctx *gin.Context) {
 
 	media, total, err := c.feedService.GetAlbumMedia(ctx.Request.Context(), albumID, limit, offset, mediaType)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -3,6 +3,7 @@ package controllers
 import (
 	"errors"
 	"log"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"messaging-app/internal/services"
 	"net/http"
@@ -43,19 +44,19 @@ func (c *FriendshipController) SendRequest(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	requesterID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req friendshipRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	receiverID, err := primitive.ObjectIDFromHex(req.ReceiverID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid receiver ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid receiver ID")
 		return
 	}
 
@@ -65,7 +66,7 @@ func (c *FriendshipController) SendRequest(ctx *gin.Context) {
 		if err == services.ErrCannotFriendSelf || err == services.ErrFriendRequestExists {
 			status = http.StatusConflict
 		}
-		ctx.JSON(status, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, status, err.Error())
 		return
 	}
 
@@ -88,20 +89,20 @@ func (c *FriendshipController) RespondToRequest(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	receiverID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req friendshipResponse
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	friendshipID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
 		log.Printf("Error parsing friendship ID from path: %v", err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid friendship ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid friendship ID")
 		return
 	}
 
@@ -116,7 +117,7 @@ func (c *FriendshipController) RespondToRequest(ctx *gin.Context) {
 		case services.ErrNotAuthorized:
 			status = http.StatusForbidden
 		}
-		ctx.JSON(status, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, status, err.Error())
 		return
 	}
 	log.Printf("Successfully responded to friend request %s", friendshipID.Hex())
@@ -139,13 +140,13 @@ func (c *FriendshipController) ListFriendships(ctx *gin.Context) {
 	uid, exists := ctx.Get("userID")
 	if !exists {
 		log.Println("userID not set in context")
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthenticated"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "unauthenticated")
 		return
 	}
 	userID := uid.(string)
 	currentUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
@@ -163,7 +164,7 @@ func (c *FriendshipController) ListFriendships(ctx *gin.Context) {
 
 	friendships, total, err := c.friendshipService.ListFriendships(ctx.Request.Context(), currentUserID, models.FriendshipStatus(status), page, limit)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -189,13 +190,13 @@ func (c *FriendshipController) SearchFriends(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	currentUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	query := ctx.Query("query")
 	if query == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "query is required"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "query is required")
 		return
 	}
 
@@ -206,7 +207,7 @@ func (c *FriendshipController) SearchFriends(ctx *gin.Context) {
 
 	friends, err := c.friendshipService.SearchFriends(ctx.Request.Context(), currentUserID, query, limit)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -227,20 +228,20 @@ func (c *FriendshipController) CheckFriendship(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	currentUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	otherUserID, err := primitive.ObjectIDFromHex(ctx.Query("other_user_id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid other user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid other user ID")
 		return
 	}
 
 	status, err := c.friendshipService.GetDetailedFriendshipStatus(ctx.Request.Context(), currentUserID, otherUserID)
 	if err != nil {
 		log.Printf("Error getting detailed friendship status: %v", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 	ctx.JSON(http.StatusOK, status)
@@ -260,13 +261,13 @@ func (c *FriendshipController) Unfriend(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	currentUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	friendID, err := primitive.ObjectIDFromHex(ctx.Param("friend_id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid friend ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid friend ID")
 		return
 	}
 
@@ -275,7 +276,7 @@ func (c *FriendshipController) Unfriend(ctx *gin.Context) {
 		if errors.Is(err, services.ErrNotFriends) {
 			status = http.StatusNotFound
 		}
-		ctx.JSON(status, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, status, err.Error())
 		return
 	}
 
@@ -297,13 +298,13 @@ func (c *FriendshipController) BlockUser(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	blockerID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	blockedID, err := primitive.ObjectIDFromHex(ctx.Param("user_id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID to block"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID to block")
 		return
 	}
 
@@ -315,7 +316,7 @@ func (c *FriendshipController) BlockUser(ctx *gin.Context) {
 		case errors.Is(err, services.ErrAlreadyBlocked):
 			status = http.StatusConflict
 		}
-		ctx.JSON(status, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, status, err.Error())
 		return
 	}
 
@@ -336,13 +337,13 @@ func (c *FriendshipController) UnblockUser(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	blockerID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	blockedID, err := primitive.ObjectIDFromHex(ctx.Param("user_id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID to unblock"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID to unblock")
 		return
 	}
 
@@ -351,7 +352,7 @@ func (c *FriendshipController) UnblockUser(ctx *gin.Context) {
 		if errors.Is(err, services.ErrBlockNotFound) {
 			status = http.StatusNotFound
 		}
-		ctx.JSON(status, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, status, err.Error())
 		return
 	}
 
@@ -371,19 +372,19 @@ func (c *FriendshipController) IsBlocked(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	currentUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	otherUserID, err := primitive.ObjectIDFromHex(ctx.Param("user_id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid other user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid other user ID")
 		return
 	}
 
 	isBlocked, err := c.friendshipService.IsBlocked(ctx.Request.Context(), currentUserID, otherUserID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -402,13 +403,13 @@ func (c *FriendshipController) GetBlockedUsers(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	currentUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	blockedUsers, err := c.friendshipService.GetBlockedUsers(ctx.Request.Context(), currentUserID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
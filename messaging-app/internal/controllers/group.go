@@ -385,6 +385,11 @@ func (c *GroupController) GetUserGroups(ctx *gin.Context) {
 
 type UpdateGroupSettingsRequest struct {
 	RequiresApproval bool `json:"requires_approval"`
+	MaxMembers       int  `json:"max_members" binding:"omitempty,min=2"` // 0 restores the default limit
+}
+
+type UpdateMemberRoleRequest struct {
+	Role models.GroupRole `json:"role" binding:"required,oneof=admin member"`
 }
 
 func (c *GroupController) InviteMember(ctx *gin.Context) {
@@ -534,6 +539,7 @@ func (c *GroupController) UpdateGroupSettings(ctx *gin.Context) {
 
 	settings := models.GroupSettings{
 		RequiresApproval: req.RequiresApproval,
+		MaxMembers:       req.MaxMembers,
 	}
 
 	if err := c.groupService.UpdateGroupSettings(ctx, groupID, userID, settings); err != nil {
@@ -544,6 +550,99 @@ func (c *GroupController) UpdateGroupSettings(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+func (c *GroupController) UpdateMemberRole(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	groupID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	targetID, err := primitive.ObjectIDFromHex(ctx.Param("userId"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req UpdateMemberRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.groupService.SetMemberRole(ctx, groupID, userID, targetID, req.Role); err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *GroupController) TransferOwnership(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	groupID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	var req AddMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	newOwnerID, err := primitive.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	if err := c.groupService.TransferOwnership(ctx, groupID, userID, newOwnerID); err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *GroupController) UpdatePermissions(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	groupID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	var req models.GroupPermissions
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.groupService.UpdatePermissions(ctx, groupID, userID, req); err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 func (c *GroupController) GetActivities(ctx *gin.Context) {
 	userID, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
@@ -575,6 +674,194 @@ func (c *GroupController) GetActivities(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, activities)
 }
 
+func (c *GroupController) CreateInvite(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	groupID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	var req models.CreateGroupInviteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	invite, err := c.groupService.CreateInvite(ctx, groupID, userID, req)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, invite)
+}
+
+func (c *GroupController) ListInvites(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	groupID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	invites, err := c.groupService.ListInvites(ctx, groupID, userID)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, invites)
+}
+
+func (c *GroupController) RevokeInvite(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	groupID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	if err := c.groupService.RevokeInvite(ctx, groupID, userID, ctx.Param("code")); err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// PreviewInvite resolves an invite link without authentication
+func (c *GroupController) PreviewInvite(ctx *gin.Context) {
+	preview, err := c.groupService.PreviewInvite(ctx, ctx.Param("code"))
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	if preview.Avatar != "" {
+		if signed, err := c.storageClient.GetPresignedURL(ctx.Request.Context(), preview.Avatar, 15*time.Minute); err == nil {
+			preview.Avatar = signed
+		}
+	}
+
+	ctx.JSON(http.StatusOK, preview)
+}
+
+func (c *GroupController) JoinWithInvite(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	group, err := c.groupService.JoinWithInvite(ctx, ctx.Param("code"), userID)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	response, err := c.convertGroupToResponse(ctx, group)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Failed to prepare response")
+		return
+	}
+
+	c.signGroupResponse(ctx.Request.Context(), response)
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *GroupController) ListBans(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	groupID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	users, err := c.groupService.ListBans(ctx, groupID, userID)
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, users)
+}
+
+func (c *GroupController) BanMember(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	groupID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	targetID, err := primitive.ObjectIDFromHex(ctx.Param("userId"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := c.groupService.BanMember(ctx, groupID, userID, targetID); err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *GroupController) UnbanMember(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	groupID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	targetID, err := primitive.ObjectIDFromHex(ctx.Param("userId"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := c.groupService.UnbanMember(ctx, groupID, userID, targetID); err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 // Helper methods
 func (c *GroupController) convertGroupToResponse(ctx context.Context, group *models.Group) (*models.GroupResponse, error) {
 	creator, err := c.userService.GetUserByID(ctx, group.CreatorID)
@@ -593,6 +880,7 @@ func (c *GroupController) convertGroupToResponse(ctx context.Context, group *mod
 			Username: user.Username,
 			Email:    user.Email,
 			Avatar:   user.Avatar,
+			Verified: user.Verified,
 		}
 	}
 
@@ -605,6 +893,7 @@ func (c *GroupController) convertGroupToResponse(ctx context.Context, group *mod
 				Username: user.Username,
 				Email:    user.Email,
 				Avatar:   user.Avatar,
+				Verified: user.Verified,
 			}
 		}
 	}
@@ -619,9 +908,13 @@ func (c *GroupController) convertGroupToResponse(ctx context.Context, group *mod
 			ID:       user.ID,
 			Username: user.Username,
 			Email:    user.Email,
+			Verified: user.Verified,
 		}
 	}
 
+	settings := group.Settings
+	settings.Permissions = settings.Permissions.Resolved()
+
 	return &models.GroupResponse{
 		ID:     group.ID,
 		Name:   group.Name,
@@ -630,11 +923,13 @@ func (c *GroupController) convertGroupToResponse(ctx context.Context, group *mod
 			ID:       creator.ID,
 			Username: creator.Username,
 			Email:    creator.Email,
+			Verified: creator.Verified,
 		},
+		OwnerID:        group.Owner(),
 		Members:        members,
 		PendingMembers: pendingMembers,
 		Admins:         admins,
-		Settings:       group.Settings,
+		Settings:       settings,
 		CreatedAt:      group.CreatedAt,
 		UpdatedAt:      group.UpdatedAt,
 	}, nil
@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"messaging-app/internal/reelclient"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	reelpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/reel/v1"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// LiveController proxies live sessions to reel-service, which runs them
+// against the media server
+type LiveController struct {
+	reelClient *reelclient.Client
+}
+
+func NewLiveController(reelClient *reelclient.Client) *LiveController {
+	return &LiveController{reelClient: reelClient}
+}
+
+// CreateLiveSession returns the new session with the ingest URL and stream
+// key for the broadcaster's encoder
+func (c *LiveController) CreateLiveSession(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.CreateLiveSessionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	session, err := c.reelClient.CreateLiveSession(ctx.Request.Context(), userID.Hex(), req.Title, string(req.Privacy))
+	if err != nil {
+		respondLiveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, session)
+}
+
+// ListLiveSessions returns the sessions live now that the user may watch
+func (c *LiveController) ListLiveSessions(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+
+	sessions, err := c.reelClient.ListLiveSessions(ctx.Request.Context(), userID.Hex(), limit)
+	if err != nil {
+		respondLiveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sessions)
+}
+
+func (c *LiveController) GetLiveSession(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	session, err := c.reelClient.GetLiveSession(ctx.Request.Context(), ctx.Param("id"), userID.Hex())
+	if err != nil {
+		respondLiveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, session)
+}
+
+func (c *LiveController) EndLiveSession(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	session, err := c.reelClient.EndLiveSession(ctx.Request.Context(), ctx.Param("id"), userID.Hex())
+	if err != nil {
+		respondLiveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, session)
+}
+
+// JoinLiveSession counts the user as watching. Players call it every 30
+// seconds while playing to stay counted.
+func (c *LiveController) JoinLiveSession(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	count, err := c.reelClient.JoinLiveSession(ctx.Request.Context(), ctx.Param("id"), userID.Hex())
+	if err != nil {
+		respondLiveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"viewer_count": count})
+}
+
+func (c *LiveController) LeaveLiveSession(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	count, err := c.reelClient.LeaveLiveSession(ctx.Request.Context(), ctx.Param("id"), userID.Hex())
+	if err != nil {
+		respondLiveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"viewer_count": count})
+}
+
+func (c *LiveController) SendLiveChatMessage(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.SendLiveChatRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	message, err := c.reelClient.SendLiveChatMessage(ctx.Request.Context(), ctx.Param("id"), userID.Hex(), req.Content)
+	if err != nil {
+		respondLiveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, message)
+}
+
+// GetLiveChatMessages pages back through a session's chat, newest first,
+// from the RFC 3339 time in before
+func (c *LiveController) GetLiveChatMessages(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "50"), 10, 64)
+
+	req := &reelpb.GetLiveChatMessagesRequest{
+		SessionId: ctx.Param("id"),
+		ViewerId:  userID.Hex(),
+		Limit:     limit,
+	}
+	if before := ctx.Query("before"); before != "" {
+		t, err := time.Parse(time.RFC3339, before)
+		if err != nil {
+			utils.RespondWithError(ctx, http.StatusBadRequest, "before must be an RFC 3339 time")
+			return
+		}
+		req.Before = timestamppb.New(t)
+	}
+
+	messages, err := c.reelClient.GetLiveChatMessages(ctx.Request.Context(), req)
+	if err != nil {
+		respondLiveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, messages)
+}
+
+// respondLiveError passes on why reel-service turned down a live session
+// request
+func respondLiveError(ctx *gin.Context, err error) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		utils.RespondWithError(ctx, http.StatusBadRequest, status.Convert(err).Message())
+	case codes.NotFound:
+		utils.RespondWithError(ctx, http.StatusNotFound, status.Convert(err).Message())
+	case codes.PermissionDenied:
+		utils.RespondWithError(ctx, http.StatusForbidden, status.Convert(err).Message())
+	case codes.FailedPrecondition:
+		utils.RespondWithError(ctx, http.StatusConflict, status.Convert(err).Message())
+	case codes.Unavailable:
+		utils.RespondWithError(ctx, http.StatusServiceUnavailable, status.Convert(err).Message())
+	default:
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -124,7 +125,7 @@ func (c *MarketplaceController) signProductResponse(ctx *gin.Context, responses
 func (c *MarketplaceController) GetCategories(ctx *gin.Context) {
 	categories, err := c.client.GetCategories(ctx.Request.Context())
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 	ctx.JSON(http.StatusOK, categories)
@@ -133,36 +134,36 @@ func (c *MarketplaceController) GetCategories(ctx *gin.Context) {
 func (c *MarketplaceController) CreateProduct(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	userIDStr, ok := userID.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type in context"})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Invalid user ID type in context")
 		return
 	}
 
 	userObjectID, err := primitive.ObjectIDFromHex(userIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Invalid user ID format")
 		return
 	}
 
 	var req models.CreateProductRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	if len(req.Images) > 5 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "maximum 5 images allowed"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "maximum 5 images allowed")
 		return
 	}
 
 	product, err := c.client.CreateProduct(ctx.Request.Context(), userObjectID, req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 	c.signProduct(ctx, product)
@@ -173,7 +174,7 @@ func (c *MarketplaceController) GetProduct(ctx *gin.Context) {
 	productIDStr := ctx.Param("id")
 	productID, err := primitive.ObjectIDFromHex(productIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
@@ -187,7 +188,7 @@ func (c *MarketplaceController) GetProduct(ctx *gin.Context) {
 
 	product, err := c.client.GetProduct(ctx.Request.Context(), productID, viewerID)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		utils.RespondWithError(ctx, http.StatusNotFound, "Product not found")
 		return
 	}
 
@@ -199,7 +200,7 @@ func (c *MarketplaceController) GetProduct(ctx *gin.Context) {
 func (c *MarketplaceController) ListProducts(ctx *gin.Context) {
 	var filter models.ProductFilter
 	if err := ctx.ShouldBindQuery(&filter); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -213,7 +214,7 @@ func (c *MarketplaceController) ListProducts(ctx *gin.Context) {
 
 	products, total, err := c.client.SearchProducts(ctx.Request.Context(), filter)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -235,19 +236,19 @@ func (c *MarketplaceController) ListProducts(ctx *gin.Context) {
 func (c *MarketplaceController) GetConversations(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 	userIDStr, ok := userID.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type in context"})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Invalid user ID type in context")
 		return
 	}
 	userObjectID, _ := primitive.ObjectIDFromHex(userIDStr)
 
 	conversations, err := c.client.GetMarketplaceConversations(ctx.Request.Context(), userObjectID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -263,14 +264,14 @@ func (c *MarketplaceController) MarkSold(ctx *gin.Context) {
 	productIDStr := ctx.Param("id")
 	productID, err := primitive.ObjectIDFromHex(productIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
 	userID, _ := ctx.Get("userID")
 	userIDStr, ok := userID.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type in context"})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Invalid user ID type in context")
 		return
 	}
 	userObjectID, _ := primitive.ObjectIDFromHex(userIDStr)
@@ -287,20 +288,20 @@ func (c *MarketplaceController) DeleteProduct(ctx *gin.Context) {
 	productIDStr := ctx.Param("id")
 	productID, err := primitive.ObjectIDFromHex(productIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
 	userID, _ := ctx.Get("userID")
 	userIDStr, ok := userID.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type in context"})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Invalid user ID type in context")
 		return
 	}
 	userObjectID, _ := primitive.ObjectIDFromHex(userIDStr)
 
 	if err := c.client.DeleteProduct(ctx.Request.Context(), productID, userObjectID); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -311,21 +312,21 @@ func (c *MarketplaceController) ToggleSave(ctx *gin.Context) {
 	productIDStr := ctx.Param("id")
 	productID, err := primitive.ObjectIDFromHex(productIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
 	userID, _ := ctx.Get("userID")
 	userIDStr, ok := userID.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type in context"})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Invalid user ID type in context")
 		return
 	}
 	userObjectID, _ := primitive.ObjectIDFromHex(userIDStr)
 
 	isSaved, err := c.client.ToggleSaveProduct(ctx.Request.Context(), productID, userObjectID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
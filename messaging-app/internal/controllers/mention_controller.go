@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"messaging-app/internal/services"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MentionController struct {
+	mentionService *services.MentionService
+}
+
+func NewMentionController(mentionService *services.MentionService) *MentionController {
+	return &MentionController{mentionService: mentionService}
+}
+
+// SuggestMentions godoc
+// @Summary Suggest users to @mention
+// @Description Get the users whose username or name starts with the query, ranked by friendship, recent conversations and mentions, and shared groups. Within a conversation only its participants are suggested; within a community its members rank higher.
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "What was typed after the @"
+// @Param conversation_id query string false "Conversation being written in"
+// @Param is_group query bool false "Whether conversation_id is a group"
+// @Param community_id query string false "Community being posted in"
+// @Param limit query int false "Maximum suggestions" default(8)
+// @Success 200 {array} models.MentionSuggestion
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 403 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /users/mentions [get]
+func (c *MentionController) SuggestMentions(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.MentionQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	suggestions, err := c.mentionService.Suggest(ctx.Request.Context(), objUserID, query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrMentionQueryRequired),
+			errors.Is(err, services.ErrInvalidCommunityID),
+			errors.Is(err, services.ErrInvalidConversationID):
+			status = http.StatusBadRequest
+		case errors.Is(err, services.ErrNotConversationParticipant):
+			status = http.StatusForbidden
+		}
+		utils.RespondWithError(ctx, status, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, suggestions)
+}
@@ -1,8 +1,11 @@
 package controllers
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,6 +13,8 @@ import (
 	"messaging-app/internal/storageclient"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -76,19 +81,20 @@ func (c *MessageController) signMessageMedia(ctx *gin.Context, messages ...*mode
 // @Success 201 {object} models.Message
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 403 {object} models.ErrorResponse
+// @Failure 429 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /messages [post]
 func (c *MessageController) SendMessage(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	senderID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req models.MessageRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -96,7 +102,7 @@ func (c *MessageController) SendMessage(ctx *gin.Context) {
 
 	// Validate content
 	if req.Content == "" && len(req.MediaURLs) == 0 {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "message content or media URLs required"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "message content or media URLs required")
 		return
 	}
 
@@ -107,11 +113,11 @@ func (c *MessageController) SendMessage(ctx *gin.Context) {
 
 	// Validate that either receiverID or groupID is provided but not both
 	if req.ReceiverID == "" && req.GroupID == "" {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "either receiverID or groupID must be provided"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "either receiverID or groupID must be provided")
 		return
 	}
 	if req.ReceiverID != "" && req.GroupID != "" {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "cannot specify both receiverID and groupID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "cannot specify both receiverID and groupID")
 		return
 	}
 
@@ -119,12 +125,16 @@ func (c *MessageController) SendMessage(ctx *gin.Context) {
 	if err != nil {
 		statusCode := http.StatusBadRequest
 		switch err.Error() {
-		case "not a group member", "can only message friends":
+		case "not a group member", "can only message friends", models.ErrUserBlocked.Error():
 			statusCode = http.StatusForbidden
 		case "group not found", "receiver not found":
 			statusCode = http.StatusNotFound
+		case moderation.ErrContentRejected.Error():
+			statusCode = http.StatusUnprocessableEntity
+		case services.ErrSpamThrottled.Error():
+			statusCode = http.StatusTooManyRequests
 		}
-		ctx.JSON(statusCode, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, statusCode, err.Error())
 		return
 	}
 
@@ -133,6 +143,64 @@ func (c *MessageController) SendMessage(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, message)
 }
 
+// @Summary Forward a message
+// @Description Copy a message into one or more direct conversations and groups, attributed to its original sender
+// @Tags messages
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Message ID"
+// @Param request body models.ForwardMessageRequest true "Source conversation and targets"
+// @Success 201 {object} models.ForwardMessageResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /messages/{id}/forward [post]
+func (c *MessageController) ForwardMessage(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var req models.ForwardMessageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := c.messageService.ForwardMessage(ctx.Request.Context(), currentUserID, ctx.Param("id"), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNotConversationParticipant):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrMessageNotFound):
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrNoForwardTargets), errors.Is(err, services.ErrTooManyForwardTargets),
+			errors.Is(err, services.ErrInvalidConversationID):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		default:
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	// Every target refused the message
+	if len(resp.Messages) == 0 {
+		ctx.JSON(http.StatusForbidden, resp)
+		return
+	}
+
+	forwarded := make([]*models.Message, len(resp.Messages))
+	for i := range resp.Messages {
+		forwarded[i] = &resp.Messages[i]
+	}
+	c.signMessageMedia(ctx, forwarded...)
+	ctx.JSON(http.StatusCreated, resp)
+}
+
 // @Summary Get messages
 // @Description Get messages for a conversation or group
 // @Tags messages
@@ -152,7 +220,7 @@ func (c *MessageController) GetMessages(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	senderID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
@@ -187,11 +255,11 @@ func (c *MessageController) GetMessages(ctx *gin.Context) {
 
 	// Validate the query
 	if groupID != "" && receiverID != "" {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "cannot specify both groupID and receiverID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "cannot specify both groupID and receiverID")
 		return
 	}
 	if groupID == "" && receiverID == "" && conversationID == "" {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "must specify groupID, receiverID, or conversationID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "must specify groupID, receiverID, or conversationID")
 		return
 	}
 
@@ -199,19 +267,19 @@ func (c *MessageController) GetMessages(ctx *gin.Context) {
 	if groupID != "" {
 		gID, err := primitive.ObjectIDFromHex(groupID)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid group ID"})
+			utils.RespondWithError(ctx, http.StatusBadRequest, "invalid group ID")
 			return
 		}
 		isMember, err := c.groupService.IsMember(ctx.Request.Context(), gID, senderID)
 		if err != nil || !isMember {
-			ctx.JSON(http.StatusForbidden, models.ErrorResponse{Error: "you are not a member of this group"})
+			utils.RespondWithError(ctx, http.StatusForbidden, "you are not a member of this group")
 			return
 		}
 	}
 
 	messages, err := c.messageService.GetAllMessages(ctx.Request.Context(), query)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -225,7 +293,7 @@ func (c *MessageController) GetMessages(ctx *gin.Context) {
 	// Get total count for pagination
 	total, err := c.messageService.GetConversationMessageTotalCount(ctx.Request.Context(), query)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -255,7 +323,7 @@ func (c *MessageController) MarkMessagesAsSeen(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	currentUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
@@ -266,23 +334,23 @@ func (c *MessageController) MarkMessagesAsSeen(ctx *gin.Context) {
 	}
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	if len(req.MessageIDs) == 0 {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "at least one message ID required"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "at least one message ID required")
 		return
 	}
 	if req.ConversationID == "" {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "conversation_id is required"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "conversation_id is required")
 		return
 	}
 
 	// Pass string IDs directly to service (Cassandra uses UUID strings)
 	err = c.messageService.MarkMessagesAsSeen(ctx.Request.Context(), currentUserID, req.ConversationID, req.MessageIDs)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -305,13 +373,13 @@ func (c *MessageController) MarkConversationAsSeen(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	currentUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	conversationIDStr := ctx.Param("id")
 	if conversationIDStr == "" {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "conversation ID required"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "conversation ID required")
 		return
 	}
 
@@ -321,13 +389,13 @@ func (c *MessageController) MarkConversationAsSeen(ctx *gin.Context) {
 		ConversationKey string `json:"conversation_key"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	timestamp, err := time.Parse(time.RFC3339, req.Timestamp)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid timestamp format"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid timestamp format")
 		return
 	}
 
@@ -335,25 +403,590 @@ func (c *MessageController) MarkConversationAsSeen(ctx *gin.Context) {
 	if req.IsGroup {
 		gID, err := primitive.ObjectIDFromHex(conversationIDStr)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid group ID"})
+			utils.RespondWithError(ctx, http.StatusBadRequest, "invalid group ID")
 			return
 		}
 		isMember, err := c.groupService.IsMember(ctx.Request.Context(), gID, currentUserID)
 		if err != nil || !isMember {
-			ctx.JSON(http.StatusForbidden, models.ErrorResponse{Error: "you are not a member of this group"})
+			utils.RespondWithError(ctx, http.StatusForbidden, "you are not a member of this group")
 			return
 		}
 	}
 
 	err = c.messageService.MarkConversationAsSeen(ctx.Request.Context(), currentUserID, conversationIDStr, req.ConversationKey, timestamp, req.IsGroup)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{Success: true})
+}
+
+// @Summary Clear a conversation's mention badge
+// @Description Reset the count of messages that mention the current user in a conversation. The unread count is left as is; reset it with the seen endpoint.
+// @Tags conversations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID or other user's ID"
+// @Param is_group query bool false "Whether the conversation is a group"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/{id}/mentions/seen [post]
+func (c *MessageController) ClearConversationMentions(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	isGroup, _ := strconv.ParseBool(ctx.DefaultQuery("is_group", "false"))
+	if err := c.messageService.ClearMentions(ctx.Request.Context(), currentUserID, ctx.Param("id"), isGroup); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNotConversationParticipant):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidConversationID):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		default:
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SuccessResponse{Success: true})
+}
+
+// @Summary Replay missed conversation events
+// @Description Replay hub events of a conversation after a cursor so a reconnecting client can catch up
+// @Tags conversations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID or other user's ID"
+// @Param is_group query bool false "Whether the conversation is a group"
+// @Param cursor query string false "Cursor of the last event received; omit to replay the whole replay window"
+// @Param limit query int false "Maximum events to return (default 100, max 500)"
+// @Success 200 {object} models.ConversationReplay
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 501 {object} models.ErrorResponse
+// @Router /conversations/{id}/replay [get]
+func (c *MessageController) ReplayConversation(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	isGroup, _ := strconv.ParseBool(ctx.DefaultQuery("is_group", "false"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "100"))
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	replay, err := c.messageService.ReplayConversation(ctx.Request.Context(), currentUserID, ctx.Param("id"), isGroup, ctx.Query("cursor"), limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrReplayUnavailable):
+			utils.RespondWithError(ctx, http.StatusNotImplemented, err.Error())
+		case errors.Is(err, services.ErrNotConversationParticipant):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidReplayCursor), errors.Is(err, services.ErrInvalidConversationID):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		default:
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, replay)
+}
+
+// @Summary List pinned messages
+// @Description List the messages pinned to a conversation
+// @Tags conversations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID or other user's ID"
+// @Param is_group query bool false "Whether the conversation is a group"
+// @Success 200 {array} models.PinnedMessage
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/{id}/pins [get]
+func (c *MessageController) ListPinnedMessages(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	isGroup, _ := strconv.ParseBool(ctx.DefaultQuery("is_group", "false"))
+	pins, err := c.messageService.ListPinnedMessages(ctx.Request.Context(), currentUserID, ctx.Param("id"), isGroup)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNotConversationParticipant):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidConversationID):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		default:
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pins)
+}
+
+// @Summary Get conversation retention
+// @Description Get how many days a conversation keeps its messages; 0 keeps them forever
+// @Tags conversations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID or other user's ID"
+// @Param is_group query bool false "Whether the conversation is a group"
+// @Success 200 {object} models.ConversationRetention
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/{id}/retention [get]
+func (c *MessageController) GetConversationRetention(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	isGroup, _ := strconv.ParseBool(ctx.DefaultQuery("is_group", "false"))
+	retention, err := c.messageService.GetRetention(ctx.Request.Context(), currentUserID, ctx.Param("id"), isGroup)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNotConversationParticipant):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidConversationID):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		default:
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, retention)
+}
+
+// @Summary Update conversation retention
+// @Description Set how many days a conversation keeps its messages; 0 keeps them forever. Older messages, archived ones included, are purged daily. Group conversations take an admin.
+// @Tags conversations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID or other user's ID"
+// @Param is_group query bool false "Whether the conversation is a group"
+// @Param request body models.UpdateRetentionRequest true "Retention policy"
+// @Success 200 {object} models.ConversationRetention
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/{id}/retention [put]
+func (c *MessageController) UpdateConversationRetention(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var req models.UpdateRetentionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	isGroup, _ := strconv.ParseBool(ctx.DefaultQuery("is_group", "false"))
+	retention, err := c.messageService.SetRetention(ctx.Request.Context(), currentUserID, ctx.Param("id"), isGroup, *req.RetentionDays)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNotConversationParticipant),
+			errors.Is(err, services.ErrRetentionNotAllowed):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidConversationID):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		default:
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, retention)
+}
+
+// @Summary List drafts
+// @Description List the current user's unsent drafts across all conversations
+// @Tags conversations
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.MessageDraft
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/drafts [get]
+func (c *MessageController) ListDrafts(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	drafts, err := c.messageService.ListDrafts(ctx.Request.Context(), currentUserID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, drafts)
+}
+
+// @Summary Get a draft
+// @Description Get the current user's unsent draft of a conversation
+// @Tags conversations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID or other user's ID"
+// @Param is_group query bool false "Whether the conversation is a group"
+// @Success 200 {object} models.MessageDraft
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/{id}/draft [get]
+func (c *MessageController) GetDraft(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	isGroup, _ := strconv.ParseBool(ctx.DefaultQuery("is_group", "false"))
+	draft, err := c.messageService.GetDraft(ctx.Request.Context(), currentUserID, ctx.Param("id"), isGroup)
+	if err != nil {
+		respondDraftError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, draft)
+}
+
+// @Summary Save a draft
+// @Description Save the current user's unsent draft of a conversation and send it to their other devices as a DRAFT_UPDATED event. Empty content clears the draft.
+// @Tags conversations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID or other user's ID"
+// @Param is_group query bool false "Whether the conversation is a group"
+// @Param request body models.SaveDraftRequest true "Draft"
+// @Success 200 {object} models.MessageDraft
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/{id}/draft [put]
+func (c *MessageController) SaveDraft(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var req models.SaveDraftRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	isGroup, _ := strconv.ParseBool(ctx.DefaultQuery("is_group", "false"))
+	draft, err := c.messageService.SaveDraft(ctx.Request.Context(), currentUserID, ctx.Param("id"), isGroup, req)
+	if err != nil {
+		respondDraftError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, draft)
+}
+
+// @Summary Delete a draft
+// @Description Clear the current user's draft of a conversation on all their devices
+// @Tags conversations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID or other user's ID"
+// @Param is_group query bool false "Whether the conversation is a group"
+// @Param device_id query string false "Device clearing the draft, echoed in the DRAFT_UPDATED event"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/{id}/draft [delete]
+func (c *MessageController) DeleteDraft(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	isGroup, _ := strconv.ParseBool(ctx.DefaultQuery("is_group", "false"))
+	if err := c.messageService.DeleteDraft(ctx.Request.Context(), currentUserID, ctx.Param("id"), isGroup, ctx.Query("device_id")); err != nil {
+		respondDraftError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, models.SuccessResponse{Success: true})
 }
 
+func respondDraftError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrInvalidConversationID):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrNotConversationParticipant):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrDraftNotFound):
+		status = http.StatusNotFound
+	}
+	utils.RespondWithError(ctx, status, err.Error())
+}
+
+// @Summary Update conversation flags
+// @Description Pin, archive or mute a conversation in the current user's inbox, or undo it. Omitted flags are left as they are. Muting also mutes the conversation's notifications. The new flags are sent to the user's devices as an INBOX_UPDATED event.
+// @Tags conversations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID or other user's ID"
+// @Param is_group query bool false "Whether the conversation is a group"
+// @Param request body models.UpdateConversationFlagsRequest true "Flags to set or unset"
+// @Success 200 {object} models.ConversationFlags
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/{id}/flags [patch]
+func (c *MessageController) UpdateConversationFlags(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var req models.UpdateConversationFlagsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	isGroup, _ := strconv.ParseBool(ctx.DefaultQuery("is_group", "false"))
+	flags, err := c.messageService.UpdateConversationFlags(ctx.Request.Context(), currentUserID, ctx.Param("id"), isGroup, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrInvalidConversationID):
+			status = http.StatusBadRequest
+		case errors.Is(err, services.ErrNotConversationParticipant):
+			status = http.StatusForbidden
+		case errors.Is(err, services.ErrPinnedConversationLimit):
+			status = http.StatusConflict
+		}
+		utils.RespondWithError(ctx, status, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, flags)
+}
+
+// @Summary Get conversation settings
+// @Description Get a conversation's nicknames, quick-reaction emoji and theme color, shared by all of its participants
+// @Tags conversations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID or other user's ID"
+// @Param is_group query bool false "Whether the conversation is a group"
+// @Success 200 {object} models.ConversationSettings
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/{id}/settings [get]
+func (c *MessageController) GetConversationSettings(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	isGroup, _ := strconv.ParseBool(ctx.DefaultQuery("is_group", "false"))
+	settings, err := c.messageService.GetConversationSettings(ctx.Request.Context(), currentUserID, ctx.Param("id"), isGroup)
+	if err != nil {
+		respondSettingsError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// @Summary Update conversation settings
+// @Description Set nicknames for participants, the quick-reaction emoji or the theme color of a conversation. Any participant may edit them. Omitted fields are left as they are; an empty value resets one. The new settings are sent to all participants as a CONVERSATION_SETTINGS_UPDATED event.
+// @Tags conversations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID or other user's ID"
+// @Param is_group query bool false "Whether the conversation is a group"
+// @Param request body models.UpdateConversationSettingsRequest true "Settings to change"
+// @Success 200 {object} models.ConversationSettings
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/{id}/settings [put]
+func (c *MessageController) UpdateConversationSettings(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var req models.UpdateConversationSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	isGroup, _ := strconv.ParseBool(ctx.DefaultQuery("is_group", "false"))
+	settings, err := c.messageService.UpdateConversationSettings(ctx.Request.Context(), currentUserID, ctx.Param("id"), isGroup, req)
+	if err != nil {
+		respondSettingsError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+func respondSettingsError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrInvalidConversationID),
+		errors.Is(err, services.ErrInvalidConversationSettings),
+		errors.Is(err, services.ErrNicknameNotParticipant):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrNotConversationParticipant):
+		status = http.StatusForbidden
+	}
+	utils.RespondWithError(ctx, status, err.Error())
+}
+
+// @Summary Accept a message request
+// @Description Move a direct conversation from the current user's message requests to their inbox. Conversations started by senders flagged for spam are held in message requests and their messages are not delivered live. Accepting delivers the held messages, and the sender's later messages go straight to the inbox.
+// @Tags conversations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Other user's ID"
+// @Success 200 {object} models.ConversationRequest
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/{id}/request/accept [post]
+func (c *MessageController) AcceptMessageRequest(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	request, err := c.messageService.AcceptMessageRequest(ctx.Request.Context(), currentUserID, ctx.Param("id"))
+	if err != nil {
+		respondMessageRequestError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+// @Summary Decline a message request
+// @Description Remove a direct conversation from the current user's message requests and archive it
+// @Tags conversations
+// @Security ApiKeyAuth
+// @Param id path string true "Other user's ID"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /conversations/{id}/request [delete]
+func (c *MessageController) DeclineMessageRequest(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	if err := c.messageService.DeclineMessageRequest(ctx.Request.Context(), currentUserID, ctx.Param("id")); err != nil {
+		respondMessageRequestError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func respondMessageRequestError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrInvalidConversationID),
+		errors.Is(err, services.ErrMessageRequestsDirectOnly):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrNotConversationParticipant):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrNoPendingMessageRequest):
+		status = http.StatusNotFound
+	}
+	utils.RespondWithError(ctx, status, err.Error())
+}
+
+// ListSpamSenders lists the senders currently flagged for spam, for admins
+func (c *MessageController) ListSpamSenders(ctx *gin.Context) {
+	senders, err := c.messageService.FlaggedSpamSenders(ctx.Request.Context())
+	if err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"senders": senders, "total": len(senders)})
+}
+
+// ClearSpamSender lifts a sender's spam flag and throttle, for admins
+func (c *MessageController) ClearSpamSender(ctx *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(ctx.Param("userId"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := c.messageService.ClearSpamSender(ctx.Request.Context(), userID); err != nil {
+		utils.RespondWithError(ctx, utils.GetStatusCode(err), err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 // @Summary Get unread message count
 // @Description Get count of unread messages for the current user
 // @Tags messages
@@ -367,13 +1000,13 @@ func (c *MessageController) GetUnreadCount(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	currentUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	count, err := c.messageService.GetUnreadCount(ctx.Request.Context(), currentUserID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -397,7 +1030,7 @@ func (c *MessageController) DeleteMessage(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	currentUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
@@ -407,44 +1040,117 @@ func (c *MessageController) DeleteMessage(ctx *gin.Context) {
 
 	conversationID := ctx.Query("conversation_id")
 	if conversationID == "" {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "conversation_id query parameter is required"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "conversation_id query parameter is required")
 		return
 	}
 
 	_, err = c.messageService.DeleteMessage(ctx.Request.Context(), conversationID, messageID, currentUserID)
 	if err != nil {
-		switch err.Error() {
-		case "message not found", "message not found or not owned by user":
-			ctx.JSON(http.StatusNotFound, models.ErrorResponse{Error: err.Error()})
-		case "message can only be deleted within 7 days of creation":
-			ctx.JSON(http.StatusForbidden, models.ErrorResponse{Error: err.Error()})
+		switch {
+		case errors.Is(err, services.ErrMessageNotFound), err.Error() == "group not found":
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrMessageNotOwned), errors.Is(err, services.ErrGroupPermissionDenied):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidConversationID):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		default:
-			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		}
 		return
 	}
 	ctx.JSON(http.StatusOK, models.SuccessResponse{Success: true})
 }
 
+// @Summary Pin a message
+// @Description Pin a message to the top of its conversation. In groups this is subject to the group's pin_messages permission.
+// @Tags messages
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Message ID"
+// @Param conversation_id query string true "Conversation ID"
+// @Success 200 {array} models.PinnedMessage
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /messages/{id}/pin [post]
+func (c *MessageController) PinMessage(ctx *gin.Context) {
+	c.changePin(ctx, c.messageService.PinMessage)
+}
+
+// @Summary Unpin a message
+// @Description Remove a pinned message from its conversation. In groups this is subject to the group's pin_messages permission.
+// @Tags messages
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Message ID"
+// @Param conversation_id query string true "Conversation ID"
+// @Success 200 {array} models.PinnedMessage
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /messages/{id}/pin [delete]
+func (c *MessageController) UnpinMessage(ctx *gin.Context) {
+	c.changePin(ctx, c.messageService.UnpinMessage)
+}
+
+func (c *MessageController) changePin(ctx *gin.Context, change func(context.Context, primitive.ObjectID, string, string) ([]models.PinnedMessage, error)) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	conversationID := ctx.Query("conversation_id")
+	if conversationID == "" {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "conversation_id query parameter is required")
+		return
+	}
+
+	pins, err := change(ctx.Request.Context(), currentUserID, conversationID, ctx.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrMessageNotFound), err.Error() == "group not found":
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrGroupPermissionDenied), errors.Is(err, services.ErrNotConversationParticipant):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrMessageAlreadyPinned), errors.Is(err, services.ErrPinLimitReached):
+			utils.RespondWithError(ctx, http.StatusConflict, err.Error())
+		case errors.Is(err, services.ErrInvalidConversationID):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		default:
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, pins)
+}
+
 // @Summary Edit a message
-// @Description Edit the content of an existing message
+// @Description Edit the content of one of the user's messages within the edit window. The earlier version is kept in the message's edit history.
 // @Tags messages
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path string true "Message ID"
+// @Param conversation_id query string true "Conversation ID"
 // @Param message body object{content:string} true "New message content"
 // @Success 200 {object} models.Message
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 422 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /messages/{id} [put]
 func (c *MessageController) EditMessage(ctx *gin.Context) {
 	messageID := ctx.Param("id")
 	conversationID := ctx.Query("conversation_id")
 	if conversationID == "" {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "conversation_id query parameter is required"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "conversation_id query parameter is required")
 		return
 	}
 
@@ -455,18 +1161,25 @@ func (c *MessageController) EditMessage(ctx *gin.Context) {
 	}
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	updatedMsg, err := c.messageService.EditMessage(ctx.Request.Context(), conversationID, messageID, userID, req.Content)
 	if err != nil {
-		if err.Error() == "message not found or not owned by user" {
-			ctx.JSON(http.StatusNotFound, models.ErrorResponse{Error: err.Error()})
-		} else if err.Error() == "message can only be edited within 1 hour of creation" {
-			ctx.JSON(http.StatusForbidden, models.ErrorResponse{Error: err.Error()})
-		} else {
-			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+		switch {
+		case errors.Is(err, services.ErrMessageNotFound):
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrMessageEditNotOwned), errors.Is(err, services.ErrEditWindowExpired):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrMessageEditConflict):
+			utils.RespondWithError(ctx, http.StatusConflict, err.Error())
+		case errors.Is(err, services.ErrInvalidConversationID):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		case errors.Is(err, moderation.ErrContentRejected):
+			utils.RespondWithError(ctx, http.StatusUnprocessableEntity, err.Error())
+		default:
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		}
 		return
 	}
@@ -474,39 +1187,176 @@ func (c *MessageController) EditMessage(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, updatedMsg)
 }
 
+// @Summary Get a message's edit history
+// @Description List the earlier versions of an edited message, oldest first
+// @Tags messages
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Message ID"
+// @Param conversation_id query string true "Conversation ID"
+// @Success 200 {object} models.MessageEditHistory
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /messages/{id}/edits [get]
+func (c *MessageController) GetMessageEditHistory(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	conversationID := ctx.Query("conversation_id")
+	if conversationID == "" {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "conversation_id query parameter is required")
+		return
+	}
+
+	history, err := c.messageService.GetEditHistory(ctx.Request.Context(), currentUserID, conversationID, ctx.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrMessageNotFound):
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrNotConversationParticipant):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidConversationID):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		default:
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, history)
+}
+
+// @Summary Get a message thread
+// @Description List the replies to a group message, oldest first, with the thread summary. The first page also carries the parent message; pass the last reply's ID as after for the next page.
+// @Tags messages
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Parent message ID"
+// @Param conversation_id query string true "Group ID or conversation key"
+// @Param after query string false "ID of the last reply already seen"
+// @Param limit query int false "Replies per page (default 50, max 100)"
+// @Success 200 {object} models.ThreadMessagesResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /messages/{id}/thread [get]
+func (c *MessageController) GetThreadMessages(ctx *gin.Context) {
+	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	conversationID := ctx.Query("conversation_id")
+	if conversationID == "" {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "conversation_id query parameter is required")
+		return
+	}
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+
+	thread, err := c.messageService.GetThreadMessages(ctx.Request.Context(), currentUserID, conversationID, ctx.Param("id"), ctx.Query("after"), limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrMessageNotFound):
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrNotConversationParticipant):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidConversationID), errors.Is(err, services.ErrThreadsGroupOnly), errors.Is(err, services.ErrInvalidThreadCursor):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		default:
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	signed := make([]*models.Message, 0, len(thread.Replies)+1)
+	if thread.Parent != nil {
+		signed = append(signed, thread.Parent)
+	}
+	for i := range thread.Replies {
+		signed = append(signed, &thread.Replies[i])
+	}
+	c.signMessageMedia(ctx, signed...)
+	ctx.JSON(http.StatusOK, thread)
+}
+
 // @Summary Search messages
-// @Description Search messages in user's conversations
+// @Description Full-text search over messages in the user's conversations, with highlighted matches
 // @Tags messages
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param q query string true "Search query"
+// @Param conversation_id query string false "Restrict to one conversation (group ID, other user's ID, or conversation key)"
+// @Param sender_ids query string false "Comma-separated sender IDs"
+// @Param from query string false "Earliest message time (RFC3339)"
+// @Param to query string false "Latest message time (RFC3339)"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Messages per page" default(20)
-// @Success 200 {array} models.Message
+// @Success 200 {array} models.MessageSearchResult
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /messages/search [get]
 func (c *MessageController) SearchMessages(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	currentUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
-	query := ctx.Query("q")
-	if query == "" {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "search query is required"})
+	query := models.MessageSearchQuery{
+		Query:          strings.TrimSpace(ctx.Query("q")),
+		ConversationID: ctx.Query("conversation_id"),
+	}
+	if query.Query == "" {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "search query is required")
 		return
 	}
 
-	page, _ := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
-	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+	if senders := ctx.Query("sender_ids"); senders != "" {
+		for _, id := range strings.Split(senders, ",") {
+			if !primitive.IsValidObjectID(id) {
+				utils.RespondWithError(ctx, http.StatusBadRequest, "invalid sender ID: "+id)
+				return
+			}
+			query.SenderIDs = append(query.SenderIDs, id)
+		}
+	}
+	for param, dst := range map[string]**time.Time{"from": &query.From, "to": &query.To} {
+		if v := ctx.Query(param); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				utils.RespondWithError(ctx, http.StatusBadRequest, "invalid "+param+" date, expected RFC3339")
+				return
+			}
+			*dst = &t
+		}
+	}
+
+	query.Page, _ = strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
+	query.Limit, _ = strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 20
+	}
 
-	messages, err := c.messageService.SearchMessages(ctx.Request.Context(), currentUserID, query, page, limit)
+	messages, err := c.messageService.SearchMessages(ctx.Request.Context(), currentUserID, query)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+		switch {
+		case errors.Is(err, services.ErrNotConversationParticipant):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidConversationID), errors.Is(err, services.ErrInvalidSearchRange):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		default:
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
 
@@ -514,85 +1364,80 @@ func (c *MessageController) SearchMessages(ctx *gin.Context) {
 }
 
 // @Summary Add a reaction to a message
-// @Description Add an emoji reaction to a specific message
+// @Description Add an emoji reaction to a message. Adding a reaction the user already made changes nothing.
 // @Tags messages
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path string true "Message ID"
+// @Param conversation_id query string true "Conversation ID"
 // @Param reaction body object{emoji:string} true "Reaction emoji"
-// @Success 200 {object} models.SuccessResponse
+// @Success 200 {object} models.MessageReactionsResponse
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /messages/{id}/react [post]
 func (c *MessageController) AddReactionToMessage(ctx *gin.Context) {
-	messageID := ctx.Param("id")
-	userID := ctx.MustGet("userID").(string)
-
-	var req struct {
-		Emoji string `json:"emoji" binding:"required"`
-	}
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
-		return
-	}
-
-	err := c.messageService.AddReaction(ctx.Request.Context(), messageID, userID, req.Emoji)
-	if err != nil {
-		switch err.Error() {
-		case "message not found or reaction already exists":
-			ctx.JSON(http.StatusConflict, models.ErrorResponse{Error: err.Error()})
-		case "invalid message ID format", "invalid user ID format":
-			ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
-		default:
-			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
-		}
-		return
-	}
-
-	ctx.JSON(http.StatusOK, models.SuccessResponse{Success: true})
+	c.changeReaction(ctx, c.messageService.AddReaction)
 }
 
 // @Summary Remove a reaction from a message
-// @Description Remove an emoji reaction from a specific message
+// @Description Remove the user's emoji reaction from a message. Removing a reaction the user never made changes nothing.
 // @Tags messages
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path string true "Message ID"
+// @Param conversation_id query string true "Conversation ID"
 // @Param reaction body object{emoji:string} true "Reaction emoji"
-// @Success 200 {object} models.SuccessResponse
+// @Success 200 {object} models.MessageReactionsResponse
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /messages/{id}/react [delete]
 func (c *MessageController) RemoveReactionFromMessage(ctx *gin.Context) {
-	messageID := ctx.Param("id")
+	c.changeReaction(ctx, c.messageService.RemoveReaction)
+}
+
+func (c *MessageController) changeReaction(ctx *gin.Context, change func(context.Context, primitive.ObjectID, string, string, string) (*models.MessageReactionsResponse, error)) {
 	userID := ctx.MustGet("userID").(string)
+	currentUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	conversationID := ctx.Query("conversation_id")
+	if conversationID == "" {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "conversation_id query parameter is required")
+		return
+	}
 
 	var req struct {
 		Emoji string `json:"emoji" binding:"required"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	err := c.messageService.RemoveReaction(ctx.Request.Context(), messageID, userID, req.Emoji)
+	reactions, err := change(ctx.Request.Context(), currentUserID, conversationID, ctx.Param("id"), req.Emoji)
 	if err != nil {
-		switch err.Error() {
-		case "message not found or reaction not present":
-			ctx.JSON(http.StatusNotFound, models.ErrorResponse{Error: err.Error()})
-		case "invalid message ID format", "invalid user ID format":
-			ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		switch {
+		case errors.Is(err, services.ErrMessageNotFound):
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrNotConversationParticipant):
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidConversationID), errors.Is(err, services.ErrInvalidReaction):
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		default:
-			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+			utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		}
 		return
 	}
-
-	ctx.JSON(http.StatusOK, models.SuccessResponse{Success: true})
+	ctx.JSON(http.StatusOK, reactions)
 }
 
 // @Summary Mark messages as delivered
@@ -610,7 +1455,7 @@ func (c *MessageController) MarkMessagesAsDelivered(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	currentUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
@@ -620,19 +1465,19 @@ func (c *MessageController) MarkMessagesAsDelivered(ctx *gin.Context) {
 	}
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	if len(req.MessageIDs) == 0 {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "at least one message ID required"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "at least one message ID required")
 		return
 	}
 
 	// Pass string IDs directly to service (which now handles Cassandra UUIDs)
 	err = c.messageService.MarkMessagesAsDelivered(ctx.Request.Context(), currentUserID, req.ConversationID, req.MessageIDs)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -0,0 +1,240 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"messaging-app/internal/services"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ModerationController serves the text moderation rules and the review
+// queue. Global rules and the queue are for platform admins; community
+// admins manage their community's rules under the community routes.
+type ModerationController struct {
+	moderationService *services.ModerationService
+}
+
+func NewModerationController(moderationService *services.ModerationService) *ModerationController {
+	return &ModerationController{moderationService: moderationService}
+}
+
+// RequireAdmin rejects requests from users without the admin role. Must run
+// after the auth middleware.
+func (c *ModerationController) RequireAdmin(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		ctx.Abort()
+		return
+	}
+	isAdmin, err := c.moderationService.IsPlatformAdmin(ctx, userID)
+	if err != nil || !isAdmin {
+		utils.RespondWithError(ctx, http.StatusForbidden, "Admin access required")
+		ctx.Abort()
+		return
+	}
+	ctx.Next()
+}
+
+// ruleScope reads the community of a community rule route; global rule
+// routes have none
+func ruleScope(ctx *gin.Context) (*primitive.ObjectID, bool) {
+	if ctx.Param("id") == "" {
+		return nil, true
+	}
+	communityID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid community ID")
+		return nil, false
+	}
+	return &communityID, true
+}
+
+func (c *ModerationController) ListRules(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	communityID, ok := ruleScope(ctx)
+	if !ok {
+		return
+	}
+
+	rules, err := c.moderationService.ListRules(ctx, userID, communityID)
+	if err != nil {
+		respondModerationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
+
+func (c *ModerationController) CreateRule(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	communityID, ok := ruleScope(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.CreateModerationRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, err := c.moderationService.CreateRule(ctx, userID, communityID, req)
+	if err != nil {
+		respondModerationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, rule)
+}
+
+func (c *ModerationController) UpdateRule(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	communityID, ok := ruleScope(ctx)
+	if !ok {
+		return
+	}
+	ruleID, err := primitive.ObjectIDFromHex(ctx.Param("ruleId"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid rule ID")
+		return
+	}
+
+	var req models.UpdateModerationRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, err := c.moderationService.UpdateRule(ctx, userID, communityID, ruleID, req)
+	if err != nil {
+		respondModerationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rule)
+}
+
+func (c *ModerationController) DeleteRule(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	communityID, ok := ruleScope(ctx)
+	if !ok {
+		return
+	}
+	ruleID, err := primitive.ObjectIDFromHex(ctx.Param("ruleId"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid rule ID")
+		return
+	}
+
+	if err := c.moderationService.DeleteRule(ctx, userID, communityID, ruleID); err != nil {
+		respondModerationError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *ModerationController) ListQueue(ctx *gin.Context) {
+	page, _ := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := moderation.QueueFilter{
+		Status:      models.ModerationQueueStatus(ctx.DefaultQuery("status", string(models.ModerationQueuePending))),
+		ContentType: models.ModeratedContentType(ctx.Query("content_type")),
+	}
+	if raw := ctx.Query("community_id"); raw != "" {
+		communityID, err := primitive.ObjectIDFromHex(raw)
+		if err != nil {
+			utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid community ID")
+			return
+		}
+		filter.CommunityID = &communityID
+	}
+
+	items, total, err := c.moderationService.ListQueue(ctx, filter, page, limit)
+	if err != nil {
+		respondModerationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"items": items,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+func (c *ModerationController) ReviewItem(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	itemID, err := primitive.ObjectIDFromHex(ctx.Param("itemId"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid queue item ID")
+		return
+	}
+
+	var req models.ReviewModerationItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	item, err := c.moderationService.ReviewItem(ctx, userID, itemID, req)
+	if err != nil {
+		respondModerationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, item)
+}
+
+func respondModerationError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, moderation.ErrInvalidRule):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrModerationForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, moderation.ErrRuleNotFound), errors.Is(err, moderation.ErrItemNotFound),
+		err.Error() == "community not found":
+		status = http.StatusNotFound
+	case errors.Is(err, moderation.ErrItemReviewed):
+		status = http.StatusConflict
+	}
+	utils.RespondWithError(ctx, status, err.Error())
+}
@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"errors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 	services "messaging-app/internal/notifications"
 	"net/http"
 	"strconv"
@@ -36,12 +38,12 @@ func NewNotificationController(ns *services.NotificationService) *NotificationCo
 func (c *NotificationController) ListNotifications(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user ID not found in context"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "user ID not found in context")
 		return
 	}
 	objUserID, err := primitive.ObjectIDFromHex(userID.(string))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user ID format"})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "invalid user ID format")
 		return
 	}
 
@@ -50,13 +52,13 @@ func (c *NotificationController) ListNotifications(ctx *gin.Context) {
 
 	page, err := strconv.ParseInt(pageStr, 10, 64)
 	if err != nil || page < 1 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid page number"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid page number")
 		return
 	}
 
 	limit, err := strconv.ParseInt(limitStr, 10, 64)
 	if err != nil || limit < 1 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit number"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid limit number")
 		return
 	}
 
@@ -64,7 +66,7 @@ func (c *NotificationController) ListNotifications(ctx *gin.Context) {
 	if readStr := ctx.Query("read"); readStr != "" {
 		parsedRead, err := strconv.ParseBool(readStr)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid read status value"})
+			utils.RespondWithError(ctx, http.StatusBadRequest, "invalid read status value")
 			return
 		}
 		readStatus = &parsedRead
@@ -72,7 +74,7 @@ func (c *NotificationController) ListNotifications(ctx *gin.Context) {
 
 	notifications, err := c.notificationService.ListNotifications(ctx.Request.Context(), objUserID, page, limit, readStatus)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -97,33 +99,33 @@ func (c *NotificationController) ListNotifications(ctx *gin.Context) {
 func (c *NotificationController) MarkNotificationAsRead(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user ID not found in context"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "user ID not found in context")
 		return
 	}
 	objUserID, err := primitive.ObjectIDFromHex(userID.(string))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user ID format"})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "invalid user ID format")
 		return
 	}
 
 	notificationID := ctx.Param("id")
 	objNotificationID, err := primitive.ObjectIDFromHex(notificationID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification ID format"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid notification ID format")
 		return
 	}
 
 	err = c.notificationService.MarkNotificationAsRead(ctx.Request.Context(), objNotificationID, objUserID)
 	if err != nil {
 		if errors.Is(err, errors.New("notification not found")) {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
 			return
 		}
 		if errors.Is(err, errors.New("unauthorized to mark this notification as read")) {
-			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -144,12 +146,12 @@ func (c *NotificationController) MarkNotificationAsRead(ctx *gin.Context) {
 func (c *NotificationController) GetUnreadNotificationCount(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user ID not found in context"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "user ID not found in context")
 		return
 	}
 	objUserID, err := primitive.ObjectIDFromHex(userID.(string))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user ID format"})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "invalid user ID format")
 		return
 	}
 
@@ -157,7 +159,7 @@ func (c *NotificationController) GetUnreadNotificationCount(ctx *gin.Context) {
 	// A more optimized approach would be to have a dedicated service method for this
 	notifications, err := c.notificationService.ListNotifications(ctx.Request.Context(), objUserID, 1, 1000000, nil) // Fetch all for count
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -170,3 +172,303 @@ func (c *NotificationController) GetUnreadNotificationCount(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"count": unreadCount})
 }
+
+// GetPreferences godoc
+// @Summary Get notification preferences
+// @Description Get the authenticated user's muted conversations, muted notification types and quiet hours.
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.NotificationPreferences
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /notifications/preferences [get]
+func (c *NotificationController) GetPreferences(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	prefs, err := c.notificationService.GetPreferences(ctx.Request.Context(), objUserID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prefs)
+}
+
+// UpdatePreferences godoc
+// @Summary Update notification preferences
+// @Description Replace the authenticated user's muted notification types and quiet hours. Omitting quiet_hours turns them off.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param preferences body models.UpdateNotificationPreferencesRequest true "Muted types and quiet hours"
+// @Success 200 {object} models.NotificationPreferences
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /notifications/preferences [put]
+func (c *NotificationController) UpdatePreferences(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateNotificationPreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	prefs, err := c.notificationService.UpdatePreferences(ctx.Request.Context(), objUserID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownNotificationType) || errors.Is(err, services.ErrInvalidQuietHours) {
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prefs)
+}
+
+// UpdateDoNotDisturb godoc
+// @Summary Set Do Not Disturb
+// @Description Replace the authenticated user's Do Not Disturb settings: turn it on by hand until a given time or for good, label it with a focus mode, schedule recurring windows and allow notifications from chosen people. Held notifications are delivered as one digest when it ends.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param dnd body models.DoNotDisturb true "Do Not Disturb settings"
+// @Success 200 {object} models.NotificationPreferences
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /notifications/preferences/dnd [put]
+func (c *NotificationController) UpdateDoNotDisturb(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.DoNotDisturb
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	prefs, err := c.notificationService.UpdateDoNotDisturb(ctx.Request.Context(), objUserID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDoNotDisturb) {
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prefs)
+}
+
+// DisableDoNotDisturb godoc
+// @Summary Turn off Do Not Disturb
+// @Description Remove the authenticated user's Do Not Disturb settings, including schedules, and deliver held notifications.
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.NotificationPreferences
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /notifications/preferences/dnd [delete]
+func (c *NotificationController) DisableDoNotDisturb(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	prefs, err := c.notificationService.UpdateDoNotDisturb(ctx.Request.Context(), objUserID, nil)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prefs)
+}
+
+// MuteConversation godoc
+// @Summary Mute a conversation
+// @Description Stop notifications from a direct message or group conversation, until a given time or for good.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Conversation ID (dm_... or group_...)"
+// @Param mute body models.MuteConversationRequest false "When the mute ends"
+// @Success 200 {object} models.NotificationPreferences
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /notifications/preferences/conversations/{id}/mute [put]
+func (c *NotificationController) MuteConversation(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.MuteConversationRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	prefs, err := c.notificationService.MuteConversation(ctx.Request.Context(), objUserID, ctx.Param("id"), req.Until)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidMuteConversation) {
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prefs)
+}
+
+// UnmuteConversation godoc
+// @Summary Unmute a conversation
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Conversation ID (dm_... or group_...)"
+// @Success 200 {object} models.NotificationPreferences
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /notifications/preferences/conversations/{id}/mute [delete]
+func (c *NotificationController) UnmuteConversation(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	prefs, err := c.notificationService.UnmuteConversation(ctx.Request.Context(), objUserID, ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prefs)
+}
+
+// ListDevices godoc
+// @Summary List push devices
+// @Description Get the devices registered to receive push notifications for the current user.
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.DeviceToken
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /notifications/devices [get]
+func (c *NotificationController) ListDevices(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	devices, err := c.notificationService.ListDevices(ctx.Request.Context(), objUserID)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, devices)
+}
+
+// RegisterDevice godoc
+// @Summary Register a push device
+// @Description Register an FCM (android, web) or APNs (ios) token so the user receives notifications while offline. Registering a token again refreshes it.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param device body models.RegisterDeviceRequest true "Device token and platform"
+// @Success 200 {object} models.DeviceToken
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /notifications/devices [post]
+func (c *NotificationController) RegisterDevice(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.RegisterDeviceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	device, err := c.notificationService.RegisterDevice(ctx.Request.Context(), objUserID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDevicePlatform) {
+			utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, device)
+}
+
+// UnregisterDevice godoc
+// @Summary Unregister a push device
+// @Description Stop push notifications to a device, e.g. on logout.
+// @Tags Notifications
+// @Security BearerAuth
+// @Param token path string true "Device token"
+// @Success 204
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 404 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /notifications/devices/{token} [delete]
+func (c *NotificationController) UnregisterDevice(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.notificationService.UnregisterDevice(ctx.Request.Context(), objUserID, ctx.Param("token")); err != nil {
+		if errors.Is(err, services.ErrDeviceNotFound) {
+			utils.RespondWithError(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// currentUserID reads the authenticated user, writing the error response when
+// it is missing or malformed
+func currentUserID(ctx *gin.Context) (primitive.ObjectID, bool) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "user ID not found in context")
+		return primitive.NilObjectID, false
+	}
+	objUserID, err := primitive.ObjectIDFromHex(userID.(string))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "invalid user ID format")
+		return primitive.NilObjectID, false
+	}
+	return objUserID, true
+}
@@ -1,12 +1,14 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 	"messaging-app/internal/services"
 )
 
@@ -38,13 +40,13 @@ func (c *PrivacyController) GetUserPrivacySettings(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	settings, err := c.privacyService.GetUserPrivacySettings(ctx.Request.Context(), objUserID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -67,19 +69,23 @@ func (c *PrivacyController) UpdateUserPrivacySettings(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req models.UpdatePrivacySettingsRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	settings, err := c.privacyService.UpdateUserPrivacySettings(ctx.Request.Context(), objUserID, &req)
+	if errors.Is(err, services.ErrInvalidPresenceVisibility) {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -102,19 +108,19 @@ func (c *PrivacyController) CreateCustomPrivacyList(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req models.CreateCustomPrivacyListRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	list, err := c.privacyService.CreateCustomPrivacyList(ctx.Request.Context(), objUserID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -138,13 +144,13 @@ func (c *PrivacyController) GetCustomPrivacyListByID(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	listID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid list ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid list ID")
 		return
 	}
 
@@ -156,7 +162,7 @@ func (c *PrivacyController) GetCustomPrivacyListByID(ctx *gin.Context) {
 		} else if err.Error() == "not authorized to view this custom privacy list" {
 			statusCode = http.StatusForbidden
 		}
-		ctx.JSON(statusCode, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, statusCode, err.Error())
 		return
 	}
 
@@ -176,13 +182,13 @@ func (c *PrivacyController) GetCustomPrivacyListsByUserID(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	lists, err := c.privacyService.GetCustomPrivacyListsByUserID(ctx.Request.Context(), objUserID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -208,19 +214,19 @@ func (c *PrivacyController) UpdateCustomPrivacyList(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	listID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid list ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid list ID")
 		return
 	}
 
 	var req models.UpdateCustomPrivacyListRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -232,7 +238,7 @@ func (c *PrivacyController) UpdateCustomPrivacyList(ctx *gin.Context) {
 		} else if err.Error() == "not authorized to update this custom privacy list" {
 			statusCode = http.StatusForbidden
 		}
-		ctx.JSON(statusCode, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, statusCode, err.Error())
 		return
 	}
 
@@ -256,13 +262,13 @@ func (c *PrivacyController) DeleteCustomPrivacyList(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	listID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid list ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid list ID")
 		return
 	}
 
@@ -274,7 +280,7 @@ func (c *PrivacyController) DeleteCustomPrivacyList(ctx *gin.Context) {
 		} else if err.Error() == "not authorized to delete this custom privacy list" {
 			statusCode = http.StatusForbidden
 		}
-		ctx.JSON(statusCode, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, statusCode, err.Error())
 		return
 	}
 
@@ -300,19 +306,19 @@ func (c *PrivacyController) AddMemberToCustomPrivacyList(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	listID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid list ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid list ID")
 		return
 	}
 
 	var req models.AddRemoveCustomPrivacyListMemberRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -324,7 +330,7 @@ func (c *PrivacyController) AddMemberToCustomPrivacyList(ctx *gin.Context) {
 		} else if err.Error() == "not authorized to modify this custom privacy list" {
 			statusCode = http.StatusForbidden
 		}
-		ctx.JSON(statusCode, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, statusCode, err.Error())
 		return
 	}
 
@@ -349,19 +355,19 @@ func (c *PrivacyController) RemoveMemberFromCustomPrivacyList(ctx *gin.Context)
 	userID := ctx.MustGet("userID").(string)
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	listID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid list ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid list ID")
 		return
 	}
 
 	memberUserID, err := primitive.ObjectIDFromHex(ctx.Param("member_id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid member user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid member user ID")
 		return
 	}
 
@@ -373,7 +379,7 @@ func (c *PrivacyController) RemoveMemberFromCustomPrivacyList(ctx *gin.Context)
 		} else if err.Error() == "not authorized to modify this custom privacy list" {
 			statusCode = http.StatusForbidden
 		}
-		ctx.JSON(statusCode, models.ErrorResponse{Error: err.Error()})
+		utils.RespondWithError(ctx, statusCode, err.Error())
 		return
 	}
 
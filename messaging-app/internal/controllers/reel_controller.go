@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"messaging-app/internal/reelclient"
+	"messaging-app/internal/services"
 	"messaging-app/internal/storageclient"
 	"net/http"
 	"strconv"
@@ -10,39 +11,44 @@ import (
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	reelpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/reel/v1"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ReelController struct {
 	reelClient    *reelclient.Client
 	storageClient *storageclient.Client
+	watchHistory  *services.WatchHistoryService
 }
 
-func NewReelController(reelClient *reelclient.Client, storageClient *storageclient.Client) *ReelController {
+func NewReelController(reelClient *reelclient.Client, storageClient *storageclient.Client, watchHistory *services.WatchHistoryService) *ReelController {
 	return &ReelController{
 		reelClient:    reelClient,
 		storageClient: storageClient,
+		watchHistory:  watchHistory,
 	}
 }
 
 func (c *ReelController) CreateReel(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.CreateReelRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	objUserID, err := primitive.ObjectIDFromHex(userID.(string))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid user ID")
 		return
 	}
 
@@ -56,6 +62,7 @@ func (c *ReelController) CreateReel(ctx *gin.Context) {
 		Caption:      req.Caption,
 		Duration:     float64(req.Duration),
 		Privacy:      string(req.Privacy),
+		Category:     req.Category,
 	}
 	// Convert ObjectIDs to strings for viewers
 	for _, id := range req.AllowedViewers {
@@ -67,7 +74,7 @@ func (c *ReelController) CreateReel(ctx *gin.Context) {
 
 	reel, err := c.reelClient.CreateReel(ctx.Request.Context(), protoReq)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -123,10 +130,40 @@ func (c *ReelController) GetReelsFeed(ctx *gin.Context) {
 
 	reels, err := c.reelClient.GetReelsFeed(ctx.Request.Context(), viewerID, limit, offset)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	c.signReels(ctx, reels)
+	attachReelResumePositions(ctx, c.watchHistory, reels)
+
+	ctx.JSON(http.StatusOK, reels)
+}
+
+// GetForYouFeed returns recommended reels the viewer hasn't been served
+func (c *ReelController) GetForYouFeed(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "10"), 10, 64)
+
+	reels, err := c.reelClient.GetForYouFeed(ctx.Request.Context(), userID.(string), limit)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.signReels(ctx, reels)
+	attachReelResumePositions(ctx, c.watchHistory, reels)
+
+	ctx.JSON(http.StatusOK, reels)
+}
+
+// signReels signs a list of reels' URLs
+func (c *ReelController) signReels(ctx *gin.Context, reels []*reelpb.Reel) {
 	// Concurrent signing for feed
 	// We handle each reel in a separate goroutine if list is large?
 	// For 10-20 items, a simple loop with internal concurrency (signReelURLs) is fine.
@@ -140,8 +177,6 @@ func (c *ReelController) GetReelsFeed(ctx *gin.Context) {
 		}(reels[i])
 	}
 	wg.Wait()
-
-	ctx.JSON(http.StatusOK, reels)
 }
 
 func (c *ReelController) GetUserReels(ctx *gin.Context) {
@@ -149,7 +184,7 @@ func (c *ReelController) GetUserReels(ctx *gin.Context) {
 
 	reels, err := c.reelClient.GetUserReels(ctx.Request.Context(), targetUserIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -162,6 +197,7 @@ func (c *ReelController) GetUserReels(ctx *gin.Context) {
 		}(reels[i])
 	}
 	wg.Wait()
+	attachReelResumePositions(ctx, c.watchHistory, reels)
 
 	ctx.JSON(http.StatusOK, reels)
 }
@@ -171,11 +207,12 @@ func (c *ReelController) GetReel(ctx *gin.Context) {
 
 	reel, err := c.reelClient.GetReel(ctx.Request.Context(), reelIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	c.signReelURLs(ctx, reel)
+	attachReelResumePositions(ctx, c.watchHistory, []*reelpb.Reel{reel})
 
 	ctx.JSON(http.StatusOK, reel)
 }
@@ -183,7 +220,7 @@ func (c *ReelController) GetReel(ctx *gin.Context) {
 func (c *ReelController) AddComment(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -194,7 +231,7 @@ func (c *ReelController) AddComment(ctx *gin.Context) {
 		Mentions []primitive.ObjectID `json:"mentions"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -211,7 +248,7 @@ func (c *ReelController) AddComment(ctx *gin.Context) {
 		ExplicitMentions: mentions,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondReelThreadError(ctx, err)
 		return
 	}
 
@@ -229,7 +266,7 @@ func (c *ReelController) GetComments(ctx *gin.Context) {
 
 	comments, err := c.reelClient.GetComments(ctx.Request.Context(), reelIDStr, limit, offset)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -239,7 +276,7 @@ func (c *ReelController) GetComments(ctx *gin.Context) {
 func (c *ReelController) AddReply(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -247,31 +284,124 @@ func (c *ReelController) AddReply(ctx *gin.Context) {
 	commentIDStr := ctx.Param("commentId")
 
 	var req struct {
-		Content string `json:"content" binding:"required"`
+		Content       string               `json:"content" binding:"required"`
+		ParentReplyID string               `json:"parent_reply_id"`
+		Mentions      []primitive.ObjectID `json:"mentions"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	mentions := make([]string, len(req.Mentions))
+	for i, m := range req.Mentions {
+		mentions[i] = m.Hex()
+	}
+
 	reply, err := c.reelClient.AddReply(ctx.Request.Context(), &reelpb.AddReplyRequest{
-		ReelId:    reelIDStr,
-		CommentId: commentIDStr,
-		UserId:    userID.(string),
-		Content:   req.Content,
+		ReelId:           reelIDStr,
+		CommentId:        commentIDStr,
+		UserId:           userID.(string),
+		Content:          req.Content,
+		ParentReplyId:    req.ParentReplyID,
+		ExplicitMentions: mentions,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondReelThreadError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, reply)
 }
 
+func (c *ReelController) GetReplies(ctx *gin.Context) {
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+	offset, _ := strconv.ParseInt(ctx.DefaultQuery("offset", "0"), 10, 64)
+
+	replies, err := c.reelClient.GetReplies(ctx.Request.Context(), ctx.Param("id"), ctx.Param("commentId"), limit, offset)
+	if err != nil {
+		respondReelThreadError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, replies)
+}
+
+func (c *ReelController) DeleteComment(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	err := c.reelClient.DeleteComment(ctx.Request.Context(), ctx.Param("id"), ctx.Param("commentId"), userID.(string))
+	if err != nil {
+		respondReelThreadError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Comment deleted"})
+}
+
+func (c *ReelController) DeleteReply(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	err := c.reelClient.DeleteReply(ctx.Request.Context(), ctx.Param("id"), ctx.Param("commentId"), ctx.Param("replyId"), userID.(string))
+	if err != nil {
+		respondReelThreadError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Reply deleted"})
+}
+
+func (c *ReelController) ReactToReply(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		ReactionType models.ReactionType `json:"reaction_type" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := c.reelClient.ReactToReply(ctx.Request.Context(), ctx.Param("id"), ctx.Param("commentId"), ctx.Param("replyId"), userID.(string), string(req.ReactionType))
+	if err != nil {
+		respondReelThreadError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Reacted successfully"})
+}
+
+// respondReelThreadError passes on why reel-service turned down a comment
+// thread change
+func respondReelThreadError(ctx *gin.Context, err error) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		utils.RespondWithError(ctx, http.StatusBadRequest, status.Convert(err).Message())
+	case codes.NotFound:
+		utils.RespondWithError(ctx, http.StatusNotFound, status.Convert(err).Message())
+	case codes.PermissionDenied:
+		utils.RespondWithError(ctx, http.StatusForbidden, status.Convert(err).Message())
+	default:
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
 func (c *ReelController) ReactToComment(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -282,13 +412,13 @@ func (c *ReelController) ReactToComment(ctx *gin.Context) {
 		ReactionType models.ReactionType `json:"reaction_type" binding:"required"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	err := c.reelClient.ReactToComment(ctx.Request.Context(), reelIDStr, commentIDStr, userID.(string), string(req.ReactionType))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondReelThreadError(ctx, err)
 		return
 	}
 
@@ -301,18 +431,50 @@ func (c *ReelController) IncrementView(ctx *gin.Context) {
 
 	err := c.reelClient.IncrementView(ctx.Request.Context(), reelIDStr, userID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "View incremented"})
 }
 
+// RecordEngagement takes a watch, complete or share signal for the For You
+// feed
+func (c *ReelController) RecordEngagement(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		Signal      string `json:"signal" binding:"required"`
+		WatchTimeMs int64  `json:"watch_time_ms"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := c.reelClient.RecordEngagement(ctx.Request.Context(), &reelpb.RecordEngagementRequest{
+		ReelId:      ctx.Param("id"),
+		UserId:      userID.(string),
+		Signal:      req.Signal,
+		WatchTimeMs: req.WatchTimeMs,
+	})
+	if err != nil {
+		respondReelThreadError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{"message": "Engagement recorded"})
+}
+
 // ReactToReel handles reacting to a reel
 func (c *ReelController) ReactToReel(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -322,13 +484,13 @@ func (c *ReelController) ReactToReel(ctx *gin.Context) {
 		Type models.ReactionType `json:"type" binding:"required"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	err := c.reelClient.ReactToReel(ctx.Request.Context(), reelIDStr, userID.(string), string(req.Type))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"messaging-app/internal/repositories"
+	"messaging-app/internal/services"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReportController lets users report posts, messages, users and listings,
+// and admins review the reports
+type ReportController struct {
+	reportService *services.ReportService
+}
+
+func NewReportController(reportService *services.ReportService) *ReportController {
+	return &ReportController{reportService: reportService}
+}
+
+func (c *ReportController) CreateReport(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	var req models.CreateReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := c.reportService.CreateReport(ctx, userID, req)
+	if err != nil {
+		respondReportError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, report)
+}
+
+func (c *ReportController) ListMyReports(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	page, limit := reportPage(ctx)
+
+	reports, total, err := c.reportService.ListMyReports(ctx, userID, page, limit)
+	if err != nil {
+		respondReportError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"reports": reports,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+func (c *ReportController) ListReports(ctx *gin.Context) {
+	page, limit := reportPage(ctx)
+	filter := repositories.ReportFilter{
+		Status:     models.ReportStatus(ctx.DefaultQuery("status", string(models.ReportStatusOpen))),
+		TargetType: models.ReportTargetType(ctx.Query("target_type")),
+		Reason:     models.ReportReason(ctx.Query("reason")),
+	}
+
+	reports, total, err := c.reportService.ListReports(ctx, filter, page, limit)
+	if err != nil {
+		respondReportError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"reports": reports,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+func (c *ReportController) GetReport(ctx *gin.Context) {
+	reportID, err := primitive.ObjectIDFromHex(ctx.Param("reportId"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid report ID")
+		return
+	}
+
+	report, err := c.reportService.GetReport(ctx, reportID)
+	if err != nil {
+		respondReportError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func (c *ReportController) ReviewReport(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	reportID, err := primitive.ObjectIDFromHex(ctx.Param("reportId"))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid report ID")
+		return
+	}
+
+	var req models.ReviewReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := c.reportService.ReviewReport(ctx, userID, reportID, req)
+	if err != nil {
+		respondReportError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func reportPage(ctx *gin.Context) (int64, int64) {
+	page, _ := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return page, limit
+}
+
+func respondReportError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrCannotReportSelf), errors.Is(err, services.ErrInvalidReportAction),
+		errors.Is(err, services.ErrInvalidConversationID):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrNotConversationParticipant):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrReportTargetNotFound), errors.Is(err, repositories.ErrReportNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, repositories.ErrDuplicateReport), errors.Is(err, repositories.ErrReportResolved):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrListingsUnavailable):
+		status = http.StatusServiceUnavailable
+	}
+	utils.RespondWithError(ctx, status, err.Error())
+}
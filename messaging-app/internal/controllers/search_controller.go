@@ -38,19 +38,19 @@ func NewSearchController(searchService *services.SearchService) *SearchControlle
 func (c *SearchController) Search(ctx *gin.Context) {
 	query := ctx.Query("query")
 	if query == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Search query cannot be empty"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Search query cannot be empty")
 		return
 	}
 
 	page, err := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
 	if err != nil || page < 1 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid page number")
 		return
 	}
 
 	limit, err := strconv.ParseInt(ctx.DefaultQuery("limit", "10"), 10, 64)
 	if err != nil || limit < 1 || limit > 100 { // Max limit 100
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid limit")
 		return
 	}
 
@@ -62,7 +62,7 @@ func (c *SearchController) Search(ctx *gin.Context) {
 
 	searchResult, err := c.searchService.Search(ctx.Request.Context(), query, page, limit, currentUserID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -5,10 +5,12 @@ import (
 	"messaging-app/internal/storageclient"
 	"messaging-app/internal/storyclient"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -41,25 +43,25 @@ func (c *StoryController) signStoryURLs(ctx *gin.Context, story *models.Story) {
 func (c *StoryController) CreateStory(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.CreateStoryRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	objUserID, err := primitive.ObjectIDFromHex(userID.(string))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid user ID")
 		return
 	}
 
 	story, err := c.storyClient.CreateStory(ctx.Request.Context(), objUserID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -71,13 +73,13 @@ func (c *StoryController) CreateStory(ctx *gin.Context) {
 func (c *StoryController) GetStoriesFeed(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	objUserID, err := primitive.ObjectIDFromHex(userID.(string))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid user ID")
 		return
 	}
 
@@ -99,7 +101,7 @@ func (c *StoryController) GetStoriesFeed(ctx *gin.Context) {
 	// Get friends for privacy filtering
 	friends, err := c.friendshipRepo.GetFriends(ctx.Request.Context(), objUserID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -110,7 +112,7 @@ func (c *StoryController) GetStoriesFeed(ctx *gin.Context) {
 
 	stories, err := c.storyClient.GetStoriesFeed(ctx.Request.Context(), objUserID, friendIDs, req.Limit, req.Offset)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -131,13 +133,13 @@ func (c *StoryController) GetUserStories(ctx *gin.Context) {
 	targetUserIDStr := ctx.Param("id")
 	targetUserID, err := primitive.ObjectIDFromHex(targetUserIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid user ID")
 		return
 	}
 
 	stories, err := c.storyClient.GetUserStories(ctx.Request.Context(), targetUserID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -158,7 +160,7 @@ func (c *StoryController) DeleteStory(ctx *gin.Context) {
 	storyIDStr := ctx.Param("id")
 	storyID, err := primitive.ObjectIDFromHex(storyIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid story ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid story ID")
 		return
 	}
 
@@ -166,7 +168,7 @@ func (c *StoryController) DeleteStory(ctx *gin.Context) {
 	objUserID, _ := primitive.ObjectIDFromHex(userID.(string))
 
 	if err := c.storyClient.DeleteStory(ctx.Request.Context(), storyID, objUserID); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -177,7 +179,7 @@ func (c *StoryController) ViewStory(ctx *gin.Context) {
 	storyIDStr := ctx.Param("id")
 	storyID, err := primitive.ObjectIDFromHex(storyIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid story ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid story ID")
 		return
 	}
 
@@ -185,7 +187,7 @@ func (c *StoryController) ViewStory(ctx *gin.Context) {
 	objUserID, _ := primitive.ObjectIDFromHex(userID.(string))
 
 	if err := c.storyClient.RecordView(ctx.Request.Context(), storyID, objUserID); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -196,7 +198,7 @@ func (c *StoryController) ReactToStory(ctx *gin.Context) {
 	storyIDStr := ctx.Param("id")
 	storyID, err := primitive.ObjectIDFromHex(storyIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid story ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid story ID")
 		return
 	}
 
@@ -204,7 +206,7 @@ func (c *StoryController) ReactToStory(ctx *gin.Context) {
 		Type string `json:"type" binding:"required"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -212,7 +214,7 @@ func (c *StoryController) ReactToStory(ctx *gin.Context) {
 	objUserID, _ := primitive.ObjectIDFromHex(userID.(string))
 
 	if err := c.storyClient.ReactToStory(ctx.Request.Context(), storyID, objUserID, req.Type); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -223,19 +225,22 @@ func (c *StoryController) GetStoryViewers(ctx *gin.Context) {
 	storyIDStr := ctx.Param("id")
 	storyID, err := primitive.ObjectIDFromHex(storyIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid story ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Invalid story ID")
 		return
 	}
 
 	userID, _ := ctx.Get("userID")
 	objUserID, _ := primitive.ObjectIDFromHex(userID.(string))
 
-	viewers, err := c.storyClient.GetStoryViewers(ctx.Request.Context(), storyID, objUserID)
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+
+	page, err := c.storyClient.GetStoryViewers(ctx.Request.Context(), storyID, objUserID, limit, offset)
 	if err != nil {
 		// unauthorized or not found
-		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusForbidden, err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, viewers)
+	ctx.JSON(http.StatusOK, page)
 }
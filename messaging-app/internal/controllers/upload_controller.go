@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -31,20 +32,20 @@ func NewUploadController(storageClient *storageclient.Client) *UploadController
 // @Router /api/upload [post]
 func (c *UploadController) Upload(ctx *gin.Context) {
 	if c.storageClient == nil {
-		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": "storage service not available"})
+		utils.RespondWithError(ctx, http.StatusServiceUnavailable, "storage service not available")
 		return
 	}
 
 	// Parse multipart form
 	form, err := ctx.MultipartForm()
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to parse multipart form: " + err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "failed to parse multipart form: "+err.Error())
 		return
 	}
 
 	files := form.File["files[]"]
 	if len(files) == 0 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "no files provided"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "no files provided")
 		return
 	}
 
@@ -52,7 +53,7 @@ func (c *UploadController) Upload(ctx *gin.Context) {
 	const MaxFileSize = 100 * 1024 * 1024 // 100MB
 	for _, file := range files {
 		if file.Size > MaxFileSize {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "file too large (max 100MB)"})
+			utils.RespondWithError(ctx, http.StatusBadRequest, "file too large (max 100MB)")
 			return
 		}
 	}
@@ -61,19 +62,19 @@ func (c *UploadController) Upload(ctx *gin.Context) {
 	for _, fileHeader := range files {
 		file, err := fileHeader.Open()
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to open file: " + err.Error()})
+			utils.RespondWithError(ctx, http.StatusBadRequest, "failed to open file: "+err.Error())
 			return
 		}
 		data, err := io.ReadAll(file)
 		file.Close()
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read file: " + err.Error()})
+			utils.RespondWithError(ctx, http.StatusInternalServerError, "failed to read file: "+err.Error())
 			return
 		}
 
 		result, err := c.storageClient.Upload(ctx.Request.Context(), data, fileHeader.Filename, fileHeader.Header.Get("Content-Type"))
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to upload file: " + err.Error()})
+			utils.RespondWithError(ctx, http.StatusInternalServerError, "failed to upload file: "+err.Error())
 			return
 		}
 
@@ -98,20 +99,20 @@ func (c *UploadController) Upload(ctx *gin.Context) {
 // @Router /api/storage/download-url [get]
 func (c *UploadController) GetPresignedDownloadURL(ctx *gin.Context) {
 	if c.storageClient == nil {
-		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": "storage service not available"})
+		utils.RespondWithError(ctx, http.StatusServiceUnavailable, "storage service not available")
 		return
 	}
 
 	key := ctx.Query("key")
 	if key == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "key is required"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "key is required")
 		return
 	}
 
 	// Generate presigned URL valid for 15 minutes
 	url, err := c.storageClient.GetPresignedURL(ctx.Request.Context(), key, 15*time.Minute)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate presigned URL: " + err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "failed to generate presigned URL: "+err.Error())
 		return
 	}
 
@@ -134,7 +135,7 @@ func (c *UploadController) GetPresignedDownloadURL(ctx *gin.Context) {
 // @Router /api/storage/upload-url [post]
 func (c *UploadController) GetPresignedUploadURL(ctx *gin.Context) {
 	if c.storageClient == nil {
-		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": "storage service not available"})
+		utils.RespondWithError(ctx, http.StatusServiceUnavailable, "storage service not available")
 		return
 	}
 
@@ -145,13 +146,13 @@ func (c *UploadController) GetPresignedUploadURL(ctx *gin.Context) {
 		Sha256Hash    string `json:"sha256_hash" binding:"required"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	result, err := c.storageClient.GetPresignedUploadURL(ctx.Request.Context(), req.Filename, req.ContentType, req.Sha256Hash, req.ContentLength)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate presigned upload URL: " + err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "failed to generate presigned upload URL: "+err.Error())
 		return
 	}
 
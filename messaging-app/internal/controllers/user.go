@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 
 	"github.com/gin-gonic/gin"
 
@@ -69,13 +70,13 @@ func (c *UserController) GetUser(ctx *gin.Context) {
 
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	user, err := c.userService.GetUserByID(ctx.Request.Context(), objID)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		utils.RespondWithError(ctx, http.StatusNotFound, "user not found")
 		return
 	}
 
@@ -116,13 +117,13 @@ func (c *UserController) GetUser(ctx *gin.Context) {
 func (c *UserController) GetUserByID(ctx *gin.Context) {
 	userID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	user, err := c.userService.GetUserByID(ctx.Request.Context(), userID)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		utils.RespondWithError(ctx, http.StatusNotFound, "user not found")
 		return
 	}
 
@@ -149,20 +150,25 @@ func (c *UserController) GetUserByID(ctx *gin.Context) {
 // @Tags users
 // @Produce json
 // @Param id path string true "User ID"
-// @Success 200 {object} map[string]interface{}
+// @Success 200 {object} models.Presence
 // @Failure 400 {object} gin.H
 // @Failure 500 {object} gin.H
 // @Router /api/users/{id}/status [get]
 func (c *UserController) GetUserStatus(ctx *gin.Context) {
+	viewerID, err := primitive.ObjectIDFromHex(ctx.MustGet("userID").(string))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
 	userID, err := primitive.ObjectIDFromHex(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
-	status, err := c.userService.GetUserStatus(ctx.Request.Context(), userID)
+	status, err := c.userService.GetUserStatus(ctx.Request.Context(), viewerID, userID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "could not retrieve user status"})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "could not retrieve user status")
 		return
 	}
 
@@ -175,14 +181,20 @@ func (c *UserController) GetUserStatus(ctx *gin.Context) {
 // @Tags users
 // @Produce json
 // @Param ids query string true "Comma-separated list of user IDs"
-// @Success 200 {object} map[string]map[string]interface{}
+// @Success 200 {object} map[string]models.Presence
 // @Failure 400 {object} gin.H
 // @Failure 500 {object} gin.H
 // @Router /api/users/presence [get]
 func (c *UserController) GetUsersPresence(ctx *gin.Context) {
+	viewerID, err := primitive.ObjectIDFromHex(ctx.MustGet("userID").(string))
+	if err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
 	idsParam := ctx.Query("ids")
 	if idsParam == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "user IDs are required"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "user IDs are required")
 		return
 	}
 
@@ -191,15 +203,15 @@ func (c *UserController) GetUsersPresence(ctx *gin.Context) {
 	for _, id := range stringIDs {
 		objID, err := primitive.ObjectIDFromHex(id)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID format: " + id})
+			utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID format: "+id)
 			return
 		}
 		objectIDs = append(objectIDs, objID)
 	}
 
-	presenceMap, err := c.userService.GetUsersPresence(ctx.Request.Context(), objectIDs)
+	presenceMap, err := c.userService.GetUsersPresence(ctx.Request.Context(), viewerID, objectIDs)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "could not retrieve users presence"})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "could not retrieve users presence")
 		return
 	}
 
@@ -234,19 +246,19 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 
 	var updateReq models.UserUpdateRequest
 	if err := ctx.ShouldBindJSON(&updateReq); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	updatedUser, err := c.userService.UpdateUser(ctx.Request.Context(), objID, &updateReq)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -280,7 +292,7 @@ func (c *UserController) ListUsers(ctx *gin.Context) {
 
 	response, err := c.userService.ListUsers(ctx.Request.Context(), page, limit, search)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -313,7 +325,7 @@ func (c *UserController) UpdatePublicKey(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
@@ -324,13 +336,13 @@ func (c *UserController) UpdatePublicKey(ctx *gin.Context) {
 		KeyBackupSalt       string `json:"key_backup_salt"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	err = c.userService.UpdatePublicKey(ctx.Request.Context(), objID, req.PublicKey, req.EncryptedPrivateKey, req.KeyBackupIV, req.KeyBackupSalt)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -353,19 +365,19 @@ func (c *UserController) UpdateEmail(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req models.UpdateEmailRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	err = c.userService.UpdateEmail(ctx.Request.Context(), objID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -388,19 +400,19 @@ func (c *UserController) UpdatePassword(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req models.UpdatePasswordRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	err = c.userService.UpdatePassword(ctx.Request.Context(), objID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -423,19 +435,19 @@ func (c *UserController) ToggleTwoFactor(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req models.ToggleTwoFactorRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	err = c.userService.ToggleTwoFactor(ctx.Request.Context(), objID, req.Enabled)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -455,13 +467,13 @@ func (c *UserController) DeactivateAccount(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	err = c.userService.DeactivateAccount(ctx.Request.Context(), objID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -484,19 +496,19 @@ func (c *UserController) UpdatePrivacySettings(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req models.UpdatePrivacySettingsRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	err = c.userService.UpdatePrivacySettings(ctx.Request.Context(), objID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -519,19 +531,19 @@ func (c *UserController) UpdateNotificationSettings(ctx *gin.Context) {
 	userID := ctx.MustGet("userID").(string)
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.RespondWithError(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req models.UpdateNotificationSettingsRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	err = c.userService.UpdateNotificationSettings(ctx.Request.Context(), objID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -0,0 +1,203 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"messaging-app/internal/services"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	reelpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/reel/v1"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WatchHistoryController struct {
+	watchHistoryService *services.WatchHistoryService
+}
+
+func NewWatchHistoryController(watchHistoryService *services.WatchHistoryService) *WatchHistoryController {
+	return &WatchHistoryController{watchHistoryService: watchHistoryService}
+}
+
+// RecordProgress godoc
+// @Summary Record watch progress
+// @Description Report how far the current user got through a reel or a post video. Players may report every second or so; updates are throttled per video, except the one that completes it.
+// @Tags WatchHistory
+// @Accept json
+// @Security BearerAuth
+// @Param progress body models.RecordWatchProgressRequest true "Video and position"
+// @Success 202
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /watch-history [post]
+func (c *WatchHistoryController) RecordProgress(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.RecordWatchProgressRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.watchHistoryService.RecordProgress(ctx.Request.Context(), objUserID, &req); err != nil {
+		respondWatchHistoryError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusAccepted)
+}
+
+// GetWatchHistory godoc
+// @Summary Get watch history
+// @Description Get the reels and post videos the current user watched, most recent first, with where each left off.
+// @Tags WatchHistory
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "Only reel or only video"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} models.WatchHistoryResponse
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /watch-history [get]
+func (c *WatchHistoryController) GetWatchHistory(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.ParseInt(ctx.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+
+	history, err := c.watchHistoryService.GetHistory(ctx.Request.Context(), objUserID, models.WatchContentType(ctx.Query("type")), page, limit)
+	if err != nil {
+		respondWatchHistoryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, history)
+}
+
+// ClearWatchHistory godoc
+// @Summary Clear watch history
+// @Description Forget what the current user watched, and so where to resume it. Clears everything unless a type is given.
+// @Tags WatchHistory
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "Only reel or only video"
+// @Success 200 {object} gin.H{"cleared":int}
+// @Failure 400 {object} gin.H{"error":string}
+// @Failure 401 {object} gin.H{"error":string}
+// @Failure 500 {object} gin.H{"error":string}
+// @Router /watch-history [delete]
+func (c *WatchHistoryController) ClearWatchHistory(ctx *gin.Context) {
+	objUserID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	cleared, err := c.watchHistoryService.ClearHistory(ctx.Request.Context(), objUserID, models.WatchContentType(ctx.Query("type")))
+	if err != nil {
+		respondWatchHistoryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"cleared": cleared})
+}
+
+func respondWatchHistoryError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidWatchContentType),
+		errors.Is(err, services.ErrInvalidWatchContentID),
+		errors.Is(err, services.ErrInvalidWatchProgress):
+		utils.RespondWithError(ctx, http.StatusBadRequest, err.Error())
+	default:
+		utils.RespondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// attachReelResumePositions sets where the viewer left off each reel.
+// Resume positions are a nicety, so a failed lookup leaves them unset.
+func attachReelResumePositions(ctx *gin.Context, watchHistory *services.WatchHistoryService, reels []*reelpb.Reel) {
+	viewerID, ok := viewerObjectID(ctx)
+	if !ok || watchHistory == nil || len(reels) == 0 {
+		return
+	}
+
+	targets := make([]services.WatchTarget, 0, len(reels))
+	for _, reel := range reels {
+		if reel == nil {
+			continue
+		}
+		if id, err := primitive.ObjectIDFromHex(reel.Id); err == nil {
+			targets = append(targets, services.WatchTarget{ContentType: models.WatchContentReel, ContentID: id})
+		}
+	}
+
+	positions, err := watchHistory.ResumePositions(ctx.Request.Context(), viewerID, targets)
+	if err != nil {
+		log.Printf("Failed to get reel resume positions of user %s: %v", viewerID.Hex(), err)
+		return
+	}
+	for _, reel := range reels {
+		if reel == nil {
+			continue
+		}
+		if id, err := primitive.ObjectIDFromHex(reel.Id); err == nil {
+			reel.ResumePositionMs = positions[services.WatchTarget{ContentType: models.WatchContentReel, ContentID: id}]
+		}
+	}
+}
+
+// attachVideoResumePositions sets where the viewer left off each video in
+// the posts' media
+func attachVideoResumePositions(ctx *gin.Context, watchHistory *services.WatchHistoryService, posts []models.Post) {
+	viewerID, ok := viewerObjectID(ctx)
+	if !ok || watchHistory == nil {
+		return
+	}
+
+	var targets []services.WatchTarget
+	for _, post := range posts {
+		for i, media := range post.Media {
+			if media.Type == "video" {
+				targets = append(targets, services.WatchTarget{ContentType: models.WatchContentVideo, ContentID: post.ID, MediaIndex: i})
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	positions, err := watchHistory.ResumePositions(ctx.Request.Context(), viewerID, targets)
+	if err != nil {
+		log.Printf("Failed to get video resume positions of user %s: %v", viewerID.Hex(), err)
+		return
+	}
+	for p := range posts {
+		for i := range posts[p].Media {
+			if posts[p].Media[i].Type == "video" {
+				posts[p].Media[i].ResumePositionMs = positions[services.WatchTarget{ContentType: models.WatchContentVideo, ContentID: posts[p].ID, MediaIndex: i}]
+			}
+		}
+	}
+}
+
+func viewerObjectID(ctx *gin.Context) (primitive.ObjectID, bool) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		return primitive.NilObjectID, false
+	}
+	id, err := primitive.ObjectIDFromHex(userID.(string))
+	return id, err == nil
+}
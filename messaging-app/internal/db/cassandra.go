@@ -1,7 +1,9 @@
 package db
 
 import (
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/gocql/gocql"
@@ -94,25 +96,52 @@ func createTables(session *gocql.Session) error {
 		seen_by set<text>,
 		delivered_to set<text>,
 		reactions text, -- JSON stored as text
+		reaction_counts map<text, int>,
 		reply_to_id text,
 		is_marketplace boolean,
 		product_id text,
 		created_at timestamp,
 		updated_at timestamp,
 		is_deleted boolean,
+		is_edited boolean,
+		edited_at timestamp,
+		forwarded_from text, -- JSON stored as text
+		offer text, -- JSON stored as text
+		link_preview text, -- JSON stored as text
 		PRIMARY KEY ((conversation_id), message_id)
 	) WITH CLUSTERING ORDER BY (message_id DESC);`
 	if err := session.Query(msgsQuery).Exec(); err != nil {
 		return err
 	}
+	// Columns added after the table was first created
+	if err := addColumn(session, "messages", "forwarded_from", "text"); err != nil {
+		return err
+	}
+	if err := addColumn(session, "messages", "offer", "text"); err != nil {
+		return err
+	}
+	if err := addColumn(session, "messages", "link_preview", "text"); err != nil {
+		return err
+	}
+	if err := addColumn(session, "messages", "is_edited", "boolean"); err != nil {
+		return err
+	}
+	if err := addColumn(session, "messages", "edited_at", "timestamp"); err != nil {
+		return err
+	}
+	// Per-emoji reaction tally, kept in step with message_reactions
+	if err := addColumn(session, "messages", "reaction_counts", "map<text, int>"); err != nil {
+		return err
+	}
 
 	// Table 1b: Message Metadata (Mutable fields - stays forever for archived messages)
 	// Partition: conversation_id (same as messages)
-	// Used for: reactions, seen_by, delivered_to, is_deleted, is_edited
+	// Used for: reactions, reaction_counts, seen_by, delivered_to, is_deleted, is_edited
 	metadataQuery := `CREATE TABLE IF NOT EXISTS message_metadata (
 		conversation_id text,
 		message_id timeuuid,
 		reactions text,
+		reaction_counts map<text, int>,
 		seen_by set<text>,
 		delivered_to set<text>,
 		is_deleted boolean,
@@ -122,6 +151,9 @@ func createTables(session *gocql.Session) error {
 	if err := session.Query(metadataQuery).Exec(); err != nil {
 		return err
 	}
+	if err := addColumn(session, "message_metadata", "reaction_counts", "map<text, int>"); err != nil {
+		return err
+	}
 
 	// Table 1c: Archive Index (Pointers to MinIO cold storage)
 	// Partition: conversation_id
@@ -138,6 +170,94 @@ func createTables(session *gocql.Session) error {
 		return err
 	}
 
+	// Table 1d: Pinned Messages
+	// Partition: conversation_id (same as messages)
+	// A conversation holds at most a handful of pins, so one partition read lists them all
+	pinnedQuery := `CREATE TABLE IF NOT EXISTS pinned_messages (
+		conversation_id text,
+		message_id timeuuid,
+		pinned_by text,
+		pinned_at timestamp,
+		PRIMARY KEY ((conversation_id), message_id)
+	) WITH CLUSTERING ORDER BY (message_id DESC);`
+	if err := session.Query(pinnedQuery).Exec(); err != nil {
+		return err
+	}
+
+	// Table 1e: Message Reactions
+	// Partition: (conversation_id, message_id), one partition per message
+	// Cluster: emoji, user_id - a user reacts with each emoji at most once, and
+	// the users behind one emoji can be counted with a slice of the partition
+	reactionsQuery := `CREATE TABLE IF NOT EXISTS message_reactions (
+		conversation_id text,
+		message_id timeuuid,
+		emoji text,
+		user_id text,
+		reacted_at timestamp,
+		PRIMARY KEY ((conversation_id, message_id), emoji, user_id)
+	);`
+	if err := session.Query(reactionsQuery).Exec(); err != nil {
+		return err
+	}
+
+	// Table 1f: Message Edits (earlier versions of edited messages)
+	// Partition: (conversation_id, message_id), one partition per message
+	// Cluster: edit_id (TimeUUID), oldest version first
+	editsQuery := `CREATE TABLE IF NOT EXISTS message_edits (
+		conversation_id text,
+		message_id timeuuid,
+		edit_id timeuuid,
+		content text,
+		edited_by text,
+		edited_at timestamp,
+		PRIMARY KEY ((conversation_id, message_id), edit_id)
+	) WITH CLUSTERING ORDER BY (edit_id ASC);`
+	if err := session.Query(editsQuery).Exec(); err != nil {
+		return err
+	}
+
+	// Table 1g: Conversation Retention
+	// Partition: conversation_id, one row per conversation with a policy.
+	// A missing row or zero retention_days keeps messages forever.
+	retentionQuery := `CREATE TABLE IF NOT EXISTS conversation_retention (
+		conversation_id text PRIMARY KEY,
+		retention_days int,
+		updated_by text,
+		updated_at timestamp
+	);`
+	if err := session.Query(retentionQuery).Exec(); err != nil {
+		return err
+	}
+
+	// Table 1h: Message Threads (reply summary per parent message)
+	// Partition: conversation_id, so a page of messages reads its summaries in one query
+	// Cluster: parent_id; rows outlive the parent's move to cold storage
+	threadsQuery := `CREATE TABLE IF NOT EXISTS message_threads (
+		conversation_id text,
+		parent_id timeuuid,
+		reply_count int,
+		last_reply_at timestamp,
+		last_replier_ids list<text>,
+		PRIMARY KEY ((conversation_id), parent_id)
+	) WITH CLUSTERING ORDER BY (parent_id DESC);`
+	if err := session.Query(threadsQuery).Exec(); err != nil {
+		return err
+	}
+
+	// Table 1i: Thread Replies
+	// Partition: (conversation_id, parent_id), one partition per thread
+	// Cluster: message_id (TimeUUID), oldest reply first
+	threadRepliesQuery := `CREATE TABLE IF NOT EXISTS thread_replies (
+		conversation_id text,
+		parent_id timeuuid,
+		message_id timeuuid,
+		sender_id text,
+		PRIMARY KEY ((conversation_id, parent_id), message_id)
+	) WITH CLUSTERING ORDER BY (message_id ASC);`
+	if err := session.Query(threadRepliesQuery).Exec(); err != nil {
+		return err
+	}
+
 	// Table 2: User Inbox (Recent Conversations)
 	// Partition: user_id
 	// Proper design: ONE row per conversation, last_message_at is a regular column
@@ -158,6 +278,81 @@ func createTables(session *gocql.Session) error {
 		return err
 	}
 
+	// Table 1j: Conversation Settings (nicknames, quick reaction, theme)
+	// Partition: conversation_id, shared by all participants
+	settingsQuery := `CREATE TABLE IF NOT EXISTS conversation_settings (
+		conversation_id text PRIMARY KEY,
+		nicknames map<text, text>,
+		quick_reaction text,
+		theme text,
+		updated_by text,
+		updated_at timestamp
+	);`
+	if err := session.Query(settingsQuery).Exec(); err != nil {
+		return err
+	}
+
+	// Table 1k: Message Requests (direct conversations started by senders
+	// flagged for spam, held apart from the receiver's inbox until accepted)
+	// Partition: user_id (the receiver)
+	requestsQuery := `CREATE TABLE IF NOT EXISTS message_requests (
+		user_id text,
+		conversation_id text,
+		sender_id text,
+		accepted boolean,
+		created_at timestamp,
+		PRIMARY KEY (user_id, conversation_id)
+	);`
+	if err := session.Query(requestsQuery).Exec(); err != nil {
+		return err
+	}
+
+	// Table 2b: Inbox Change Log (Delta Sync)
+	// Partition: user_id
+	// Cluster: change_id (TimeUUID), one row per change to an inbox row or its unread or mention count.
+	// Rows expire after 14 days; older sync tokens fall back to a full inbox download.
+	inboxChangesQuery := `CREATE TABLE IF NOT EXISTS user_inbox_changes (
+		user_id text,
+		change_id timeuuid,
+		conversation_id text,
+		PRIMARY KEY ((user_id), change_id)
+	) WITH CLUSTERING ORDER BY (change_id ASC) AND default_time_to_live = 1209600;`
+	if err := session.Query(inboxChangesQuery).Exec(); err != nil {
+		return err
+	}
+
+	// Table 2c: Message Drafts (backup of the Redis copy)
+	// Partition: user_id, so a user's drafts load in one read
+	// Cluster: conversation_id; rows expire 30 days after the last save
+	draftsQuery := `CREATE TABLE IF NOT EXISTS message_drafts (
+		user_id text,
+		conversation_id text,
+		content text,
+		reply_to_id text,
+		device_id text,
+		updated_at timestamp,
+		PRIMARY KEY ((user_id), conversation_id)
+	) WITH default_time_to_live = 2592000;`
+	if err := session.Query(draftsQuery).Exec(); err != nil {
+		return err
+	}
+
+	// Table 2d: Conversation Flags (pinned, archived, muted)
+	// Partition: user_id, read alongside the inbox in one query
+	// Cluster: conversation_id; each flag is written in its own column
+	flagsQuery := `CREATE TABLE IF NOT EXISTS conversation_flags (
+		user_id text,
+		conversation_id text,
+		pinned boolean,
+		archived boolean,
+		muted boolean,
+		updated_at timestamp,
+		PRIMARY KEY ((user_id), conversation_id)
+	);`
+	if err := session.Query(flagsQuery).Exec(); err != nil {
+		return err
+	}
+
 	// Table 3: Unread Counts (Counter Table)
 	counterQuery := `CREATE TABLE IF NOT EXISTS conversation_unread (
 		user_id text,
@@ -169,6 +364,18 @@ func createTables(session *gocql.Session) error {
 		return err
 	}
 
+	// Table 3b: Mention Counts (Counter Table)
+	// Kept apart from unread counts so the two badges reset independently.
+	mentionCounterQuery := `CREATE TABLE IF NOT EXISTS conversation_mentions (
+		user_id text,
+		conversation_id text,
+		mention_count counter,
+		PRIMARY KEY ((user_id), conversation_id)
+	);`
+	if err := session.Query(mentionCounterQuery).Exec(); err != nil {
+		return err
+	}
+
 	// Table 4: Group Activities (System Messages)
 	// Partition: group_id
 	// Cluster: created_at DESC, activity_id DESC
@@ -191,6 +398,16 @@ func createTables(session *gocql.Session) error {
 	return nil
 }
 
+// addColumn adds a column to an existing table. Cassandra has no
+// ADD IF NOT EXISTS before 5.0, so a column that already exists is not an error.
+func addColumn(session *gocql.Session, table, column, cqlType string) error {
+	err := session.Query(fmt.Sprintf(`ALTER TABLE %s ADD %s %s`, table, column, cqlType)).Exec()
+	if err != nil && !strings.Contains(err.Error(), "conflicts with an existing column") {
+		return err
+	}
+	return nil
+}
+
 func (c *CassandraClient) Close() {
 	if c.Session != nil {
 		c.Session.Close()
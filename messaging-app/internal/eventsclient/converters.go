@@ -184,6 +184,7 @@ func toModelUserShort(pb *eventspb.UserShort) models.UserShort {
 		Username: pb.GetUsername(),
 		FullName: pb.GetFullName(),
 		Avatar:   pb.GetAvatar(),
+		Verified: pb.GetVerified(),
 	}
 }
 
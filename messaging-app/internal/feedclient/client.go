@@ -29,6 +29,7 @@ func New(ctx context.Context, cfg *config.Config) (*Client, error) {
 		grpc.WithBlock(),
 		grpc.WithTimeout(5*time.Second),
 		observability.GetGRPCDialOption(),
+		resilience.NewGRPCClient("feed-service").WithTimeout(10*time.Second).WithRetry(resilience.DefaultRetryPolicy(), resilience.ReadOnlyMethods...).WithoutCircuitBreaker().DialOption(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("feed service connection failed: %w", err)
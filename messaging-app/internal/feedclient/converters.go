@@ -26,12 +26,17 @@ func toModelPost(pb *feedpb.PostResponse) (*models.Post, error) {
 	}
 
 	post := &models.Post{
-		ID:        id,
-		UserID:    userID,
-		Content:   pb.GetContent(),
-		Privacy:   models.PrivacySettingType(pb.GetPrivacy()),
-		CreatedAt: fromTimestamp(pb.GetCreatedAt()),
-		UpdatedAt: fromTimestamp(pb.GetUpdatedAt()),
+		ID:             id,
+		UserID:         userID,
+		Content:        pb.GetContent(),
+		Privacy:        models.PrivacySettingType(pb.GetPrivacy()),
+		CreatedAt:      fromTimestamp(pb.GetCreatedAt()),
+		UpdatedAt:      fromTimestamp(pb.GetUpdatedAt()),
+		TotalReactions: pb.GetTotalReactions(),
+		TotalComments:  pb.GetTotalComments(),
+		TotalShares:    pb.GetTotalShares(),
+		SharedFrom:     toModelPostShare(pb.GetSharedFrom()),
+		LinkPreview:    toModelLinkPreview(pb.GetLinkPreview()),
 		// Map other fields as they become available in Proto
 		// Media: ...
 		// Mentions: ...
@@ -40,6 +45,55 @@ func toModelPost(pb *feedpb.PostResponse) (*models.Post, error) {
 	return post, nil
 }
 
+func toModelPostShare(pb *feedpb.PostShare) *models.PostShare {
+	if pb == nil {
+		return nil
+	}
+	share := &models.PostShare{
+		Available:       pb.GetAvailable(),
+		OriginalContent: pb.GetOriginalContent(),
+	}
+	share.PostID, _ = primitive.ObjectIDFromHex(pb.GetPostId())
+	share.OriginalPostID, _ = primitive.ObjectIDFromHex(pb.GetOriginalPostId())
+	share.OriginalAuthorID, _ = primitive.ObjectIDFromHex(pb.GetOriginalAuthorId())
+	for _, id := range pb.GetChain() {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			share.Chain = append(share.Chain, oid)
+		}
+	}
+	for _, m := range pb.GetOriginalMedia() {
+		share.OriginalMedia = append(share.OriginalMedia, models.MediaItem{URL: m.GetUrl(), Type: m.GetType()})
+	}
+	if a := pb.GetOriginalAuthor(); a != nil {
+		share.OriginalAuthor = &models.PostAuthor{
+			ID:       a.GetId(),
+			Username: a.GetUsername(),
+			Avatar:   a.GetAvatar(),
+			FullName: a.GetFullName(),
+			Verified: a.GetVerified(),
+		}
+	}
+	if ts := pb.GetOriginalCreatedAt(); ts != nil {
+		t := ts.AsTime()
+		share.OriginalCreatedAt = &t
+	}
+	return share
+}
+
+func toModelLinkPreview(pb *feedpb.LinkPreview) *models.LinkPreview {
+	if pb == nil {
+		return nil
+	}
+	return &models.LinkPreview{
+		URL:         pb.GetUrl(),
+		Title:       pb.GetTitle(),
+		Description: pb.GetDescription(),
+		ImageURL:    pb.GetImageUrl(),
+		SiteName:    pb.GetSiteName(),
+		FetchedAt:   fromTimestamp(pb.GetFetchedAt()),
+	}
+}
+
 func toModelFeedResponse(pb *feedpb.FeedResponse) ([]models.Post, int64) {
 	if pb == nil {
 		return []models.Post{}, 0
@@ -8,12 +8,13 @@ import (
 )
 
 // CreatePost calls the gRPC CreatePost method
-func (c *Client) CreatePost(ctx context.Context, userID, content, privacy string) (*models.Post, error) {
+func (c *Client) CreatePost(ctx context.Context, userID, content, privacy string, customAudience []string) (*models.Post, error) {
 	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
 		return c.client.CreatePost(ctx, &feedpb.CreatePostRequest{
-			UserId:  userID,
-			Content: content,
-			Privacy: privacy,
+			UserId:         userID,
+			Content:        content,
+			Privacy:        privacy,
+			CustomAudience: customAudience,
 		})
 	})
 	if err != nil {
@@ -72,6 +73,27 @@ func (c *Client) GetPostsByHashtag(ctx context.Context, viewerID, hashtag string
 	return posts, nil
 }
 
+// GetTrendingHashtags calls the gRPC GetTrendingHashtags method
+func (c *Client) GetTrendingHashtags(ctx context.Context, region, window string, limit int32) (*models.TrendingHashtagsResponse, error) {
+	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.GetTrendingHashtags(ctx, &feedpb.GetTrendingHashtagsRequest{
+			Region: region,
+			Window: window,
+			Limit:  limit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := result.(*feedpb.GetTrendingHashtagsResponse)
+	hashtags := make([]models.TrendingHashtag, 0, len(resp.Hashtags))
+	for _, t := range resp.Hashtags {
+		hashtags = append(hashtags, models.TrendingHashtag{Tag: t.Tag, Score: t.Score})
+	}
+	return &models.TrendingHashtagsResponse{Hashtags: hashtags, Region: resp.Region, Window: resp.Window}, nil
+}
+
 // UpdatePost calls the gRPC UpdatePost method
 func (c *Client) UpdatePost(ctx context.Context, postID, userID, content, privacy string) (*models.Post, error) {
 	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
@@ -99,6 +121,24 @@ func (c *Client) DeletePost(ctx context.Context, postID, userID string) error {
 	return err
 }
 
+// SharePost calls the gRPC SharePost method
+func (c *Client) SharePost(ctx context.Context, postID, userID, content, privacy string, customAudience []string) (*models.Post, error) {
+	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.SharePost(ctx, &feedpb.SharePostRequest{
+			PostId:         postID,
+			UserId:         userID,
+			Content:        content,
+			Privacy:        privacy,
+			CustomAudience: customAudience,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelPost(result.(*feedpb.PostResponse))
+}
+
 // UpdatePostStatus calls the gRPC UpdatePostStatus method
 func (c *Client) UpdatePostStatus(ctx context.Context, postID, userID, status string) error {
 	_, err := c.cb.Execute(ctx, func() (interface{}, error) {
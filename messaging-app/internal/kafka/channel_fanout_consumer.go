@@ -0,0 +1,90 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	segmentio "github.com/segmentio/kafka-go"
+)
+
+// ChannelFanout delivers channel posts a page of subscribers at a time
+type ChannelFanout interface {
+	FanOutChannelPost(ctx context.Context, job *models.ChannelFanoutJob) error
+}
+
+// ChannelFanoutConsumer runs the fan-out jobs of channel posts. Each job
+// delivers a post to one page of a channel's subscribers and queues the job
+// for the next page. Jobs that fail go to the dead-letter queue.
+type ChannelFanoutConsumer struct {
+	reader      *segmentio.Reader
+	fanout      ChannelFanout
+	dlqProducer *kafka.DLQProducer
+}
+
+func NewChannelFanoutConsumer(brokers []string, topic string, groupID string, fanout ChannelFanout, dlq *kafka.DLQProducer) *ChannelFanoutConsumer {
+	r := segmentio.NewReader(segmentio.ReaderConfig{
+		Brokers:        brokers,
+		Topic:          topic,
+		GroupID:        groupID,
+		MinBytes:       1,
+		MaxBytes:       10e6, // 10MB
+		CommitInterval: time.Second,
+	})
+
+	return &ChannelFanoutConsumer{
+		reader:      r,
+		fanout:      fanout,
+		dlqProducer: dlq,
+	}
+}
+
+func (c *ChannelFanoutConsumer) Start(ctx context.Context) {
+	log.Printf("Starting channel fan-out consumer for topic %s", c.reader.Config().Topic)
+
+	for {
+		m, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Printf("Channel fan-out consumer for topic %s stopped", c.reader.Config().Topic)
+				return
+			}
+			log.Printf("Error fetching channel fan-out job: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		messagesConsumed.WithLabelValues(m.Topic).Inc()
+		start := time.Now()
+
+		var job models.ChannelFanoutJob
+		if err := json.Unmarshal(m.Value, &job); err != nil {
+			log.Printf("Error unmarshaling channel fan-out job at offset %d: %v", m.Offset, err)
+			c.deadLetter(ctx, m, err)
+		} else if err := c.fanout.FanOutChannelPost(ctx, &job); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Failed to fan out page %d of channel post %s: %v", job.Page, job.PostID.Hex(), err)
+			c.deadLetter(ctx, m, err)
+		}
+
+		if err := c.reader.CommitMessages(ctx, m); err != nil {
+			log.Printf("Error committing channel fan-out job offset: %v", err)
+		}
+		consumeDuration.WithLabelValues(m.Topic).Observe(time.Since(start).Seconds())
+	}
+}
+
+func (c *ChannelFanoutConsumer) deadLetter(ctx context.Context, m segmentio.Message, cause error) {
+	if err := c.dlqProducer.PublishDeadLetter(ctx, m.Topic, m.Value, cause); err != nil {
+		log.Printf("Failed to send channel fan-out job to DLQ: %v", err)
+	}
+}
+
+func (c *ChannelFanoutConsumer) Close() error {
+	return c.reader.Close()
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/segmentio/kafka-go"
@@ -62,6 +63,7 @@ func (c *MessageConsumer) ConsumeMessages(ctx context.Context) {
 
 		messagesConsumed.WithLabelValues(m.Topic).Inc()
 		start := time.Now()
+		_, span := observability.StartKafkaConsumerSpan(ctx, c.reader.Config().GroupID, m)
 
 		// Attempt to unmarshal as a Message
 		var msg models.Message
@@ -107,6 +109,7 @@ func (c *MessageConsumer) ConsumeMessages(ctx context.Context) {
 								if err := c.reader.CommitMessages(ctx, m); err != nil {
 									log.Printf("Error committing message after unmarshaling failure: %v", err)
 								}
+								observability.EndSpan(span, err)
 								continue
 							}
 							log.Printf("Received Kafka event of type: %s for topic %s", wsEvent.Type, m.Topic)
@@ -117,10 +120,15 @@ func (c *MessageConsumer) ConsumeMessages(ctx context.Context) {
 								if err := json.Unmarshal(wsEvent.Data, &rsvp); err == nil {
 									c.hub.EventRSVPEvents <- rsvp
 								}
+							case "NOTIFICATION_UPDATED":
+								var notification models.Notification
+								if err := json.Unmarshal(wsEvent.Data, &notification); err == nil {
+									c.hub.NotificationUpdates <- websocket.NotificationEvent{Notification: notification, SpanContext: span.SpanContext()}
+								}
 							case "EVENT_UPDATED", "EVENT_DELETED", "EVENT_POST_CREATED", "EVENT_POST_REACTION", "EVENT_INVITATION_UPDATED", "EVENT_COHOST_ADDED", "EVENT_COHOST_REMOVED":
 								c.hub.EventUpdates <- wsEvent
 							default:
-								c.hub.FeedEvents <- wsEvent
+								c.hub.FeedEvents <- websocket.FeedEvent{WebSocketEvent: wsEvent, SpanContext: span.SpanContext()}
 							}
 						}
 					}
@@ -132,6 +140,7 @@ func (c *MessageConsumer) ConsumeMessages(ctx context.Context) {
 			log.Printf("Error committing message: %v", err)
 		}
 
+		span.End()
 		consumeDuration.WithLabelValues(m.Topic).Observe(time.Since(start).Seconds())
 	}
 
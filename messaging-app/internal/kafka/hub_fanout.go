@@ -0,0 +1,269 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"messaging-app/internal/websocket"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	maxReplayScan = 5000 // Messages read from a partition per replay page, matching or not
+	replayTimeout = 10 * time.Second
+)
+
+// ErrInvalidReplayCursor is returned for cursors not issued by the hub fan-out
+var ErrInvalidReplayCursor = apperrors.Validation("invalid replay cursor")
+
+// ConversationKey is the partition key for a message. Keying by conversation
+// keeps every conversation on one partition, so its events stay ordered.
+func ConversationKey(msg models.Message) string {
+	if !msg.GroupID.IsZero() {
+		return "group_" + msg.GroupID.Hex()
+	}
+	return utils.GetConversationID(msg.SenderID, msg.ReceiverID)
+}
+
+// FormatReplayCursor encodes a position in the fan-out topic
+func FormatReplayCursor(partition int, offset int64) string {
+	return fmt.Sprintf("%d:%d", partition, offset)
+}
+
+// ParseReplayCursor decodes a cursor produced by FormatReplayCursor
+func ParseReplayCursor(cursor string) (int, int64, error) {
+	p, o, ok := strings.Cut(cursor, ":")
+	if !ok {
+		return 0, 0, ErrInvalidReplayCursor
+	}
+	partition, err := strconv.Atoi(p)
+	if err != nil || partition < 0 {
+		return 0, 0, ErrInvalidReplayCursor
+	}
+	offset, err := strconv.ParseInt(o, 10, 64)
+	if err != nil || offset < -1 {
+		return 0, 0, ErrInvalidReplayCursor
+	}
+	return partition, offset, nil
+}
+
+// HubFanout publishes messages to the hub fan-out topic and replays them for
+// reconnecting clients.
+type HubFanout struct {
+	producer     *MessageProducer
+	brokers      []string
+	topic        string
+	replayWindow time.Duration
+}
+
+func NewHubFanout(brokers []string, topic string, replayWindow time.Duration) *HubFanout {
+	return &HubFanout{
+		producer:     NewMessageProducer(brokers, topic),
+		brokers:      brokers,
+		topic:        topic,
+		replayWindow: replayWindow,
+	}
+}
+
+// PublishMessage delivers msg to every hub instance
+func (f *HubFanout) PublishMessage(ctx context.Context, msg models.Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return f.producer.ProduceMessage(ctx, kafka.Message{
+		Key:   []byte(ConversationKey(msg)),
+		Value: data,
+		Time:  time.Now(),
+	})
+}
+
+// Replay returns up to limit events of a conversation after cursor. Without a
+// cursor it starts at the beginning of the replay window.
+func (f *HubFanout) Replay(ctx context.Context, conversationKey, cursor string, limit int) (*models.ConversationReplay, error) {
+	var (
+		partition int
+		offset    int64
+		err       error
+	)
+	if cursor != "" {
+		partition, offset, err = ParseReplayCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		offset++
+	} else {
+		partition, err = f.partitionFor(ctx, conversationKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := kafka.DialLeader(ctx, "tcp", f.brokers[0], f.topic, partition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial partition leader: %w", err)
+	}
+	defer conn.Close()
+
+	first, last, err := conn.ReadOffsets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read partition offsets: %w", err)
+	}
+	if cursor == "" {
+		if offset, err = conn.ReadOffset(time.Now().Add(-f.replayWindow)); err != nil {
+			return nil, fmt.Errorf("failed to resolve replay window: %w", err)
+		}
+	}
+	if offset < first {
+		offset = first // Older events have aged out of the topic
+	}
+
+	replay := &models.ConversationReplay{
+		ConversationID: conversationKey,
+		Events:         []models.WebSocketEvent{},
+	}
+
+	if offset < last {
+		r := kafka.NewReader(kafka.ReaderConfig{
+			Brokers:   f.brokers,
+			Topic:     f.topic,
+			Partition: partition,
+			MinBytes:  1,
+			MaxBytes:  10e6,
+		})
+		defer r.Close()
+		if err := r.SetOffset(offset); err != nil {
+			return nil, err
+		}
+
+		readCtx, cancel := context.WithTimeout(ctx, replayTimeout)
+		defer cancel()
+
+		for scanned := 0; offset < last && len(replay.Events) < limit && scanned < maxReplayScan; scanned++ {
+			m, err := r.ReadMessage(readCtx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read replay events: %w", err)
+			}
+			offset = m.Offset + 1
+			if string(m.Key) != conversationKey {
+				continue
+			}
+
+			var msg models.Message
+			if err := json.Unmarshal(m.Value, &msg); err != nil {
+				log.Printf("Skipping malformed fan-out message at %s: %v", FormatReplayCursor(m.Partition, m.Offset), err)
+				continue
+			}
+			event, err := websocket.NewMessageEvent(msg, FormatReplayCursor(m.Partition, m.Offset))
+			if err != nil {
+				continue
+			}
+			replay.Events = append(replay.Events, event)
+		}
+	}
+
+	replay.Cursor = FormatReplayCursor(partition, offset-1)
+	replay.HasMore = offset < last
+	return replay, nil
+}
+
+// partitionFor resolves the partition the producer's hash balancer assigns to key
+func (f *HubFanout) partitionFor(ctx context.Context, key string) (int, error) {
+	partitions, err := kafka.DefaultDialer.LookupPartitions(ctx, "tcp", f.brokers[0], f.topic)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up fan-out partitions: %w", err)
+	}
+	if len(partitions) == 0 {
+		return 0, fmt.Errorf("fan-out topic %s has no partitions", f.topic)
+	}
+	ids := make([]int, len(partitions))
+	for i, p := range partitions {
+		ids[i] = p.ID
+	}
+	return hashPartition(key, ids), nil
+}
+
+func hashPartition(key string, partitions []int) int {
+	// The writer hands partitions to the balancer sorted by ID
+	sorted := append([]int(nil), partitions...)
+	sort.Ints(sorted)
+	return (&kafka.Hash{}).Balance(kafka.Message{Key: []byte(key)}, sorted...)
+}
+
+func (f *HubFanout) Close() error {
+	return f.producer.Close()
+}
+
+// HubFanoutConsumer delivers fan-out messages to this instance's hub. Each hub
+// instance has its own consumer group, so every instance receives every
+// message and resumes from its committed offset after a restart.
+type HubFanoutConsumer struct {
+	reader *kafka.Reader
+	hub    *websocket.Hub
+}
+
+func NewHubFanoutConsumer(brokers []string, topic string, instanceID string, hub *websocket.Hub) *HubFanoutConsumer {
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        brokers,
+		Topic:          topic,
+		GroupID:        "hub-fanout-" + instanceID,
+		StartOffset:    kafka.LastOffset, // A new instance has no connected clients to catch up
+		MinBytes:       1,
+		MaxBytes:       10e6, // 10MB
+		CommitInterval: time.Second,
+	})
+
+	return &HubFanoutConsumer{
+		reader: r,
+		hub:    hub,
+	}
+}
+
+func (c *HubFanoutConsumer) Start(ctx context.Context) {
+	log.Printf("Starting hub fan-out consumer for topic %s (group %s)", c.reader.Config().Topic, c.reader.Config().GroupID)
+	for {
+		m, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Printf("Hub fan-out consumer for topic %s stopped", c.reader.Config().Topic)
+				return
+			}
+			log.Printf("Error fetching hub fan-out message: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		messagesConsumed.WithLabelValues(m.Topic).Inc()
+		start := time.Now()
+
+		var msg models.Message
+		if err := json.Unmarshal(m.Value, &msg); err != nil {
+			log.Printf("Error unmarshaling hub fan-out message at offset %d: %v", m.Offset, err)
+		} else {
+			select {
+			case c.hub.FanoutMessages <- websocket.FanoutMessage{Message: msg, Cursor: FormatReplayCursor(m.Partition, m.Offset)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err := c.reader.CommitMessages(ctx, m); err != nil {
+			log.Printf("Error committing hub fan-out offset: %v", err)
+		}
+		consumeDuration.WithLabelValues(m.Topic).Observe(time.Since(start).Seconds())
+	}
+}
+
+func (c *HubFanoutConsumer) Close() error {
+	return c.reader.Close()
+}
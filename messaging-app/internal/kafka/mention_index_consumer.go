@@ -0,0 +1,102 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MentionUserIndexer keeps the @mention index in step with user profiles
+type MentionUserIndexer interface {
+	IndexUser(ctx context.Context, user *models.User)
+}
+
+// userChangedEvent covers both shapes of user update events: user-service
+// sends the whole user as user_data, this app sends the changed names.
+// Events of other kinds on the same topics carry neither and are skipped.
+type userChangedEvent struct {
+	UserID   string       `json:"user_id"`
+	Username string       `json:"username"`
+	FullName string       `json:"full_name"`
+	Avatar   string       `json:"avatar"`
+	UserData *models.User `json:"user_data"`
+}
+
+// MentionIndexConsumer applies user update events to the mention index
+type MentionIndexConsumer struct {
+	reader  *kafka.Reader
+	indexer MentionUserIndexer
+}
+
+func NewMentionIndexConsumer(brokers []string, topics []string, groupID string, indexer MentionUserIndexer) *MentionIndexConsumer {
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        brokers,
+		GroupTopics:    topics,
+		GroupID:        groupID,
+		MinBytes:       1,
+		MaxBytes:       10e6, // 10MB
+		CommitInterval: time.Second,
+	})
+
+	return &MentionIndexConsumer{
+		reader:  r,
+		indexer: indexer,
+	}
+}
+
+func (c *MentionIndexConsumer) Start(ctx context.Context) {
+	log.Printf("Starting mention index consumer for topics %v", c.reader.Config().GroupTopics)
+
+	for {
+		m, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Printf("Mention index consumer stopped")
+				return
+			}
+			log.Printf("Error fetching user event for mention index: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		messagesConsumed.WithLabelValues(m.Topic).Inc()
+		start := time.Now()
+
+		var event userChangedEvent
+		if err := json.Unmarshal(m.Value, &event); err != nil {
+			log.Printf("Error unmarshaling user event at offset %d: %v", m.Offset, err)
+		} else {
+			c.apply(ctx, event)
+		}
+
+		if err := c.reader.CommitMessages(ctx, m); err != nil {
+			log.Printf("Error committing mention index offset: %v", err)
+		}
+		consumeDuration.WithLabelValues(m.Topic).Observe(time.Since(start).Seconds())
+	}
+}
+
+func (c *MentionIndexConsumer) apply(ctx context.Context, event userChangedEvent) {
+	user := event.UserData
+	if user == nil {
+		userID, err := primitive.ObjectIDFromHex(event.UserID)
+		if err != nil || event.Username == "" {
+			return
+		}
+		user = &models.User{ID: userID, Username: event.Username, FullName: event.FullName, Avatar: event.Avatar}
+	}
+	if user.ID.IsZero() {
+		return
+	}
+	// Deleted and banned accounts are taken out of the index
+	c.indexer.IndexUser(ctx, user)
+}
+
+func (c *MentionIndexConsumer) Close() error {
+	return c.reader.Close()
+}
@@ -0,0 +1,94 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"messaging-app/internal/messagesearch"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const maxIndexAttempts = 3
+
+// MessageSearchIndexer applies message search events to the search index.
+// Events are keyed by conversation, so edits and deletes never overtake the
+// message they refer to.
+type MessageSearchIndexer struct {
+	reader *kafka.Reader
+	index  *messagesearch.Index
+}
+
+func NewMessageSearchIndexer(brokers []string, topic string, groupID string, index *messagesearch.Index) *MessageSearchIndexer {
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        brokers,
+		Topic:          topic,
+		GroupID:        groupID,
+		MinBytes:       1,
+		MaxBytes:       10e6, // 10MB
+		CommitInterval: time.Second,
+	})
+
+	return &MessageSearchIndexer{
+		reader: r,
+		index:  index,
+	}
+}
+
+func (c *MessageSearchIndexer) Start(ctx context.Context) {
+	log.Printf("Starting message search indexer for topic %s", c.reader.Config().Topic)
+	if err := c.index.EnsureIndex(ctx); err != nil {
+		log.Printf("Error preparing message search index: %v", err)
+	}
+
+	for {
+		m, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Printf("Message search indexer for topic %s stopped", c.reader.Config().Topic)
+				return
+			}
+			log.Printf("Error fetching message search event: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		messagesConsumed.WithLabelValues(m.Topic).Inc()
+		start := time.Now()
+
+		var event messagesearch.Event
+		if err := json.Unmarshal(m.Value, &event); err != nil {
+			log.Printf("Error unmarshaling message search event at offset %d: %v", m.Offset, err)
+		} else if err := c.apply(ctx, event); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Failed to index message %s in %s, skipping: %v", event.MessageID, event.ConversationID, err)
+		}
+
+		if err := c.reader.CommitMessages(ctx, m); err != nil {
+			log.Printf("Error committing message search offset: %v", err)
+		}
+		consumeDuration.WithLabelValues(m.Topic).Observe(time.Since(start).Seconds())
+	}
+}
+
+func (c *MessageSearchIndexer) apply(ctx context.Context, event messagesearch.Event) error {
+	var err error
+	for attempt := 1; attempt <= maxIndexAttempts; attempt++ {
+		if err = c.index.Apply(ctx, event); err == nil {
+			return nil
+		}
+		select {
+		case <-time.After(time.Duration(attempt) * time.Second):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+func (c *MessageSearchIndexer) Close() error {
+	return c.reader.Close()
+}
@@ -9,6 +9,7 @@ import (
 	"messaging-app/internal/websocket"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
 	"time"
 
 	segmentio "github.com/segmentio/kafka-go"
@@ -20,6 +21,8 @@ type NotificationConsumer struct {
 	hub         *websocket.Hub
 	repo        *repositories.NotificationRepository
 	dlqProducer *kafka.DLQProducer
+	// pushProducer forwards persisted notifications for mobile/web push; nil disables it
+	pushProducer *MessageProducer
 }
 
 // NewNotificationConsumer creates a new NotificationConsumer.
@@ -39,6 +42,11 @@ func NewNotificationConsumer(brokers []string, topic string, groupID string, hub
 	}
 }
 
+// SetPushProducer forwards persisted notifications to the push delivery topic.
+func (c *NotificationConsumer) SetPushProducer(p *MessageProducer) {
+	c.pushProducer = p
+}
+
 // Start consuming messages from Kafka.
 func (c *NotificationConsumer) Start(ctx context.Context) {
 	log.Printf("Starting Kafka Notification Consumer for topic %s", c.reader.Config().Topic)
@@ -55,6 +63,7 @@ func (c *NotificationConsumer) Start(ctx context.Context) {
 				continue
 			}
 
+			msgCtx, span := observability.StartKafkaConsumerSpan(ctx, c.reader.Config().GroupID, m)
 			var event events.NotificationCreatedEvent
 			if err := json.Unmarshal(m.Value, &event); err != nil {
 				log.Printf("ERROR: Malformed notification received from Kafka. Topic: %s, Partition: %d, Offset: %d, Error: %v. Message value: %s. Sending to DLQ.", m.Topic, m.Partition, m.Offset, err, string(m.Value))
@@ -63,6 +72,7 @@ func (c *NotificationConsumer) Start(ctx context.Context) {
 					log.Printf("FATAL: Failed to send malformed message to DLQ: %v", dlqErr)
 				}
 				c.reader.CommitMessages(ctx, m) // Commit to avoid processing bad message loop
+				observability.EndSpan(span, err)
 				continue
 			}
 
@@ -82,11 +92,13 @@ func (c *NotificationConsumer) Start(ctx context.Context) {
 			// Persist to DB with robust retry mechanism
 			// We retry DB writes to ensure data consistency.
 			maxRetries := 3
+			persisted := false
 			for i := 0; i < maxRetries; i++ {
-				dbCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+				dbCtx, cancel := context.WithTimeout(msgCtx, 5*time.Second)
 				_, saveErr := c.repo.CreateNotification(dbCtx, &notification)
 				cancel()
 				if saveErr == nil {
+					persisted = true
 					break
 				}
 
@@ -104,20 +116,41 @@ func (c *NotificationConsumer) Start(ctx context.Context) {
 
 			// Push notification to the WebSocket hub in a non-blocking way
 			select {
-			case c.hub.NotificationEvents <- notification:
+			case c.hub.NotificationEvents <- websocket.NotificationEvent{Notification: notification, SpanContext: span.SpanContext()}:
 				// Successfully sent to hub
 			default:
 				log.Printf("WARNING: WebSocket hub's NotificationEvents channel is full. Dropping real-time notification for recipient %s. Notification will still be available in DB.", notification.RecipientID.Hex())
 				// In a high-volume scenario, you might want to implement a separate retry queue for WebSocket delivery
 			}
 
+			if persisted && c.pushProducer != nil {
+				c.forwardToPush(msgCtx, &notification)
+			}
+
 			if err := c.reader.CommitMessages(ctx, m); err != nil {
 				log.Printf("Error committing message to Kafka: %v", err)
 			}
+			span.End()
 		}
 	}
 }
 
+// forwardToPush enqueues a notification for push delivery, keyed by recipient
+// so a user's pushes arrive in order.
+func (c *NotificationConsumer) forwardToPush(ctx context.Context, notification *models.Notification) {
+	value, err := json.Marshal(notification)
+	if err != nil {
+		log.Printf("Error marshaling notification %s for push: %v", notification.ID.Hex(), err)
+		return
+	}
+	if err := c.pushProducer.ProduceMessage(ctx, segmentio.Message{
+		Key:   []byte(notification.RecipientID.Hex()),
+		Value: value,
+	}); err != nil {
+		log.Printf("Error enqueuing notification %s for push: %v", notification.ID.Hex(), err)
+	}
+}
+
 // Close closes the Kafka reader.
 func (c *NotificationConsumer) Close() error {
 	return c.reader.Close()
@@ -0,0 +1,90 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	segmentio "github.com/segmentio/kafka-go"
+)
+
+// OfferCardPoster posts marketplace offer changes into conversations
+type OfferCardPoster interface {
+	PostOfferCard(ctx context.Context, evt *models.MarketplaceOfferEvent) error
+}
+
+// OfferConsumer turns marketplace offer events into offer cards in the buyer
+// and seller's marketplace conversation. Events whose card cannot be posted
+// go to the dead-letter queue.
+type OfferConsumer struct {
+	reader      *segmentio.Reader
+	poster      OfferCardPoster
+	dlqProducer *kafka.DLQProducer
+}
+
+func NewOfferConsumer(brokers []string, topic string, groupID string, poster OfferCardPoster, dlq *kafka.DLQProducer) *OfferConsumer {
+	r := segmentio.NewReader(segmentio.ReaderConfig{
+		Brokers:        brokers,
+		Topic:          topic,
+		GroupID:        groupID,
+		MinBytes:       1,
+		MaxBytes:       10e6, // 10MB
+		CommitInterval: time.Second,
+	})
+
+	return &OfferConsumer{
+		reader:      r,
+		poster:      poster,
+		dlqProducer: dlq,
+	}
+}
+
+func (c *OfferConsumer) Start(ctx context.Context) {
+	log.Printf("Starting marketplace offer consumer for topic %s", c.reader.Config().Topic)
+
+	for {
+		m, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Printf("Marketplace offer consumer for topic %s stopped", c.reader.Config().Topic)
+				return
+			}
+			log.Printf("Error fetching offer event: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		messagesConsumed.WithLabelValues(m.Topic).Inc()
+		start := time.Now()
+
+		var evt models.MarketplaceOfferEvent
+		if err := json.Unmarshal(m.Value, &evt); err != nil {
+			log.Printf("Error unmarshaling offer event at offset %d: %v", m.Offset, err)
+			c.deadLetter(ctx, m, err)
+		} else if err := c.poster.PostOfferCard(ctx, &evt); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Failed to post %s card for offer %s: %v", evt.Action, evt.Offer.ID.Hex(), err)
+			c.deadLetter(ctx, m, err)
+		}
+
+		if err := c.reader.CommitMessages(ctx, m); err != nil {
+			log.Printf("Error committing offer event offset: %v", err)
+		}
+		consumeDuration.WithLabelValues(m.Topic).Observe(time.Since(start).Seconds())
+	}
+}
+
+func (c *OfferConsumer) deadLetter(ctx context.Context, m segmentio.Message, cause error) {
+	if err := c.dlqProducer.PublishDeadLetter(ctx, m.Topic, m.Value, cause); err != nil {
+		log.Printf("Failed to send offer event to DLQ: %v", err)
+	}
+}
+
+func (c *OfferConsumer) Close() error {
+	return c.reader.Close()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/segmentio/kafka-go"
 	"github.com/segmentio/kafka-go/compress"
@@ -62,7 +63,10 @@ func (p *MessageProducer) ProduceMessage(ctx context.Context, message kafka.Mess
 		produceDuration.WithLabelValues(p.topic).Observe(time.Since(start).Seconds())
 	}()
 
-	return p.writer.WriteMessages(ctx, message)
+	ctx, span := observability.StartKafkaProducerSpan(ctx, p.topic, &message)
+	err := p.writer.WriteMessages(ctx, message)
+	observability.EndSpan(span, err)
+	return err
 }
 
 func (p *MessageProducer) Close() error {
@@ -0,0 +1,86 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"messaging-app/internal/push"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	segmentio "github.com/segmentio/kafka-go"
+)
+
+// PushConsumer delivers persisted notifications to the mobile and web devices
+// of recipients who are offline. Notifications that still cannot be delivered
+// after the dispatcher's retries go to the dead-letter queue.
+type PushConsumer struct {
+	reader      *segmentio.Reader
+	dispatcher  *push.Dispatcher
+	dlqProducer *kafka.DLQProducer
+}
+
+func NewPushConsumer(brokers []string, topic string, groupID string, dispatcher *push.Dispatcher, dlq *kafka.DLQProducer) *PushConsumer {
+	r := segmentio.NewReader(segmentio.ReaderConfig{
+		Brokers:        brokers,
+		Topic:          topic,
+		GroupID:        groupID,
+		MinBytes:       1,
+		MaxBytes:       10e6, // 10MB
+		CommitInterval: time.Second,
+	})
+
+	return &PushConsumer{
+		reader:      r,
+		dispatcher:  dispatcher,
+		dlqProducer: dlq,
+	}
+}
+
+func (c *PushConsumer) Start(ctx context.Context) {
+	log.Printf("Starting push delivery consumer for topic %s", c.reader.Config().Topic)
+
+	for {
+		m, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Printf("Push delivery consumer for topic %s stopped", c.reader.Config().Topic)
+				return
+			}
+			log.Printf("Error fetching push notification: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		messagesConsumed.WithLabelValues(m.Topic).Inc()
+		start := time.Now()
+
+		var notification models.Notification
+		if err := json.Unmarshal(m.Value, &notification); err != nil {
+			log.Printf("Error unmarshaling push notification at offset %d: %v", m.Offset, err)
+			c.deadLetter(ctx, m, err)
+		} else if err := c.dispatcher.Deliver(ctx, &notification); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Failed to push notification %s to %s: %v", notification.ID.Hex(), notification.RecipientID.Hex(), err)
+			c.deadLetter(ctx, m, err)
+		}
+
+		if err := c.reader.CommitMessages(ctx, m); err != nil {
+			log.Printf("Error committing push notification offset: %v", err)
+		}
+		consumeDuration.WithLabelValues(m.Topic).Observe(time.Since(start).Seconds())
+	}
+}
+
+func (c *PushConsumer) deadLetter(ctx context.Context, m segmentio.Message, cause error) {
+	if err := c.dlqProducer.PublishDeadLetter(ctx, m.Topic, m.Value, cause); err != nil {
+		log.Printf("Failed to send push notification to DLQ: %v", err)
+	}
+}
+
+func (c *PushConsumer) Close() error {
+	return c.reader.Close()
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
 
 	"github.com/segmentio/kafka-go"
 )
@@ -50,10 +51,12 @@ func (c *StoryConsumer) Start(ctx context.Context) {
 				continue
 			}
 
+			_, span := observability.StartKafkaConsumerSpan(ctx, c.reader.Config().GroupID, m)
 			var wsEvent models.WebSocketEvent
 			if err := json.Unmarshal(m.Value, &wsEvent); err != nil {
 				log.Printf("Error unmarshaling story event: %v, message: %s", err, string(m.Value))
 				c.reader.CommitMessages(ctx, m)
+				observability.EndSpan(span, err)
 				continue
 			}
 
@@ -61,7 +64,7 @@ func (c *StoryConsumer) Start(ctx context.Context) {
 
 			// Route story events to FeedEvents channel for processing
 			select {
-			case c.hub.FeedEvents <- wsEvent:
+			case c.hub.FeedEvents <- websocket.FeedEvent{WebSocketEvent: wsEvent, SpanContext: span.SpanContext()}:
 				// Successfully sent to hub
 			default:
 				log.Printf("WARNING: FeedEvents channel full. Dropping story event: %s", wsEvent.Type)
@@ -70,6 +73,7 @@ func (c *StoryConsumer) Start(ctx context.Context) {
 			if err := c.reader.CommitMessages(ctx, m); err != nil {
 				log.Printf("Error committing story message: %v", err)
 			}
+			span.End()
 		}
 	}
 }
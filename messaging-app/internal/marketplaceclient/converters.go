@@ -63,6 +63,7 @@ func protoProductToResponse(p *marketplacepb.Product) *models.ProductResponse {
 			Username: p.Seller.Username,
 			FullName: p.Seller.FullName,
 			Avatar:   p.Seller.Avatar,
+			Verified: p.Seller.Verified,
 		},
 		Category: models.Category{
 			ID:   categoryID,
@@ -0,0 +1,103 @@
+package messagesearch
+
+import (
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Action is the change an Event applies to the index
+type Action string
+
+const (
+	ActionIndex  Action = "index"
+	ActionEdit   Action = "edit"
+	ActionDelete Action = "delete"
+	// ActionPurge removes a conversation's messages sent before Before
+	ActionPurge Action = "purge"
+)
+
+// Event is published on the message search topic, keyed by conversation so a
+// conversation's changes are applied in order.
+type Event struct {
+	Action         Action    `json:"action"`
+	ConversationID string    `json:"conversation_id"`
+	MessageID      string    `json:"message_id"` // Cassandra message UUID
+	Document       *Document `json:"document,omitempty"`
+	Content        string    `json:"content,omitempty"` // New content for edits
+	Before         time.Time `json:"before"`            // Cutoff for purges
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// Document is the indexed projection of a message. Messages are routed by
+// conversation, so each conversation lives on a single shard.
+type Document struct {
+	ID             string     `json:"id"`
+	MessageID      string     `json:"message_id"`
+	ConversationID string     `json:"conversation_id"`
+	SenderID       string     `json:"sender_id"`
+	SenderName     string     `json:"sender_name,omitempty"`
+	ReceiverID     string     `json:"receiver_id,omitempty"`
+	GroupID        string     `json:"group_id,omitempty"`
+	ParticipantIDs []string   `json:"participant_ids,omitempty"` // Direct messages only; group access is checked at query time
+	Content        string     `json:"content"`
+	ContentType    string     `json:"content_type"`
+	IsMarketplace  bool       `json:"is_marketplace"`
+	IsEdited       bool       `json:"is_edited"`
+	CreatedAt      time.Time  `json:"created_at"`
+	EditedAt       *time.Time `json:"edited_at,omitempty"`
+}
+
+// Indexable reports whether a message has searchable text. Encrypted content
+// is ciphertext and is never indexed.
+func Indexable(msg *models.Message) bool {
+	return msg.Content != "" && !msg.IsEncrypted && !msg.IsDeleted && msg.ContentType != models.ContentTypeDeleted
+}
+
+// NewDocument projects a stored message for the index
+func NewDocument(conversationID string, msg *models.Message) *Document {
+	doc := &Document{
+		ID:             msg.ID.Hex(),
+		MessageID:      msg.StringID,
+		ConversationID: conversationID,
+		SenderID:       msg.SenderID.Hex(),
+		SenderName:     msg.SenderName,
+		Content:        msg.Content,
+		ContentType:    msg.ContentType,
+		IsMarketplace:  msg.IsMarketplace,
+		IsEdited:       msg.IsEdited,
+		CreatedAt:      msg.CreatedAt,
+		EditedAt:       msg.EditedAt,
+	}
+	if !msg.GroupID.IsZero() {
+		doc.GroupID = msg.GroupID.Hex()
+	} else {
+		doc.ReceiverID = msg.ReceiverID.Hex()
+		doc.ParticipantIDs = []string{doc.SenderID, doc.ReceiverID}
+	}
+	return doc
+}
+
+// Message converts a search hit back into the API message shape
+func (d *Document) Message() models.Message {
+	msg := models.Message{
+		StringID:      d.MessageID,
+		SenderName:    d.SenderName,
+		Content:       d.Content,
+		ContentType:   d.ContentType,
+		IsMarketplace: d.IsMarketplace,
+		IsEdited:      d.IsEdited,
+		EditedAt:      d.EditedAt,
+		CreatedAt:     d.CreatedAt,
+	}
+	msg.ID, _ = primitive.ObjectIDFromHex(d.ID)
+	msg.SenderID, _ = primitive.ObjectIDFromHex(d.SenderID)
+	if d.ReceiverID != "" {
+		msg.ReceiverID, _ = primitive.ObjectIDFromHex(d.ReceiverID)
+	}
+	if d.GroupID != "" {
+		msg.GroupID, _ = primitive.ObjectIDFromHex(d.GroupID)
+	}
+	return msg
+}
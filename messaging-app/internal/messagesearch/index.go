@@ -0,0 +1,226 @@
+package messagesearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// MaxResultWindow is Elasticsearch's default limit on from + size
+const MaxResultWindow = 10000
+
+type Config struct {
+	URLs     []string
+	Username string
+	Password string
+	Index    string
+}
+
+// Index is the Elasticsearch index backing message search
+type Index struct {
+	es    *elasticsearch.Client
+	index string
+}
+
+func NewIndex(cfg Config) (*Index, error) {
+	es, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: cfg.URLs,
+		Username:  cfg.Username,
+		Password:  cfg.Password,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create elasticsearch client: %w", err)
+	}
+	return &Index{es: es, index: cfg.Index}, nil
+}
+
+// EnsureIndex creates the message index with its mapping if it is missing
+func (i *Index) EnsureIndex(ctx context.Context) error {
+	res, err := i.es.Indices.Exists([]string{i.index}, i.es.Indices.Exists.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to check index %s: %w", i.index, err)
+	}
+	res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	body, err := json.Marshal(indexDefinition)
+	if err != nil {
+		return err
+	}
+	res, err = i.es.Indices.Create(i.index,
+		i.es.Indices.Create.WithContext(ctx),
+		i.es.Indices.Create.WithBody(bytes.NewReader(body)),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create index %s: %w", i.index, err)
+	}
+	// A 400 means another instance won the race to create it
+	if err := decodeError(res); err != nil && res.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("failed to create index %s: %w", i.index, err)
+	}
+	log.Printf("Created message search index %s", i.index)
+	return nil
+}
+
+// Apply writes a search event to the index. Edits and deletes of messages that
+// were never indexed, such as encrypted ones, are no-ops.
+func (i *Index) Apply(ctx context.Context, event Event) error {
+	switch event.Action {
+	case ActionIndex:
+		if event.Document == nil {
+			return fmt.Errorf("index event for message %s has no document", event.MessageID)
+		}
+		body, err := json.Marshal(event.Document)
+		if err != nil {
+			return err
+		}
+		res, err := i.es.Index(i.index, bytes.NewReader(body),
+			i.es.Index.WithContext(ctx),
+			i.es.Index.WithDocumentID(event.MessageID),
+			i.es.Index.WithRouting(event.ConversationID),
+		)
+		if err != nil {
+			return err
+		}
+		return decodeError(res)
+
+	case ActionEdit:
+		body, err := json.Marshal(map[string]any{"doc": map[string]any{
+			"content":   event.Content,
+			"is_edited": true,
+			"edited_at": event.Timestamp,
+		}})
+		if err != nil {
+			return err
+		}
+		res, err := i.es.Update(i.index, event.MessageID, bytes.NewReader(body),
+			i.es.Update.WithContext(ctx),
+			i.es.Update.WithRouting(event.ConversationID),
+		)
+		if err != nil {
+			return err
+		}
+		return ignoreNotFound(res)
+
+	case ActionDelete:
+		res, err := i.es.Delete(i.index, event.MessageID,
+			i.es.Delete.WithContext(ctx),
+			i.es.Delete.WithRouting(event.ConversationID),
+		)
+		if err != nil {
+			return err
+		}
+		return ignoreNotFound(res)
+
+	case ActionPurge:
+		body, err := json.Marshal(map[string]any{"query": map[string]any{
+			"bool": map[string]any{"filter": []any{
+				map[string]any{"term": map[string]any{"conversation_id": event.ConversationID}},
+				map[string]any{"range": map[string]any{"created_at": map[string]any{"lt": event.Before}}},
+			}},
+		}})
+		if err != nil {
+			return err
+		}
+		res, err := i.es.DeleteByQuery([]string{i.index}, bytes.NewReader(body),
+			i.es.DeleteByQuery.WithContext(ctx),
+			i.es.DeleteByQuery.WithRouting(event.ConversationID),
+			i.es.DeleteByQuery.WithConflicts("proceed"),
+		)
+		if err != nil {
+			return err
+		}
+		return decodeError(res)
+	}
+	return fmt.Errorf("unknown message search action %q", event.Action)
+}
+
+type searchResult struct {
+	Hits struct {
+		Hits []struct {
+			Source    Document            `json:"_source"`
+			Highlight map[string][]string `json:"highlight"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// Search returns the messages matching query in conversations the user can
+// read: direct messages they are part of and the groups in groupIDs.
+func (i *Index) Search(ctx context.Context, userID string, groupIDs []string, query models.MessageSearchQuery) ([]models.MessageSearchResult, error) {
+	body, err := json.Marshal(buildQuery(userID, groupIDs, query))
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []func(*esapi.SearchRequest){
+		i.es.Search.WithContext(ctx),
+		i.es.Search.WithIndex(i.index),
+		i.es.Search.WithBody(bytes.NewReader(body)),
+	}
+	if query.ConversationID != "" {
+		opts = append(opts, i.es.Search.WithRouting(query.ConversationID))
+	}
+	res, err := i.es.Search(opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, responseError(res)
+	}
+
+	var result searchResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode search response: %w", err)
+	}
+
+	messages := make([]models.MessageSearchResult, 0, len(result.Hits.Hits))
+	for _, h := range result.Hits.Hits {
+		messages = append(messages, models.MessageSearchResult{
+			Message:    h.Source.Message(),
+			Highlights: h.Highlight["content"],
+		})
+	}
+	return messages, nil
+}
+
+func ignoreNotFound(res *esapi.Response) error {
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil
+	}
+	return decodeError(res)
+}
+
+func decodeError(res *esapi.Response) error {
+	defer res.Body.Close()
+	if !res.IsError() {
+		io.Copy(io.Discard, res.Body)
+		return nil
+	}
+	return responseError(res)
+}
+
+func responseError(res *esapi.Response) error {
+	var e struct {
+		Error struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&e); err != nil || e.Error.Type == "" {
+		return fmt.Errorf("elasticsearch error: %s", res.Status())
+	}
+	return fmt.Errorf("elasticsearch error: %s: %s", e.Error.Type, strings.TrimSpace(e.Error.Reason))
+}
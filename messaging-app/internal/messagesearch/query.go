@@ -0,0 +1,112 @@
+package messagesearch
+
+import (
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+const highlightFragmentSize = 150
+
+var indexDefinition = map[string]any{
+	"settings": map[string]any{
+		"analysis": map[string]any{
+			"analyzer": map[string]any{
+				"message_text": map[string]any{
+					"type":      "custom",
+					"tokenizer": "standard",
+					"filter":    []string{"lowercase", "asciifolding"},
+				},
+			},
+		},
+	},
+	"mappings": map[string]any{
+		"_routing": map[string]any{"required": true},
+		"properties": map[string]any{
+			"id":              map[string]any{"type": "keyword"},
+			"message_id":      map[string]any{"type": "keyword"},
+			"conversation_id": map[string]any{"type": "keyword"},
+			"sender_id":       map[string]any{"type": "keyword"},
+			"sender_name":     map[string]any{"type": "text"},
+			"receiver_id":     map[string]any{"type": "keyword"},
+			"group_id":        map[string]any{"type": "keyword"},
+			"participant_ids": map[string]any{"type": "keyword"},
+			"content":         map[string]any{"type": "text", "analyzer": "message_text"},
+			"content_type":    map[string]any{"type": "keyword"},
+			"is_marketplace":  map[string]any{"type": "boolean"},
+			"is_edited":       map[string]any{"type": "boolean"},
+			"created_at":      map[string]any{"type": "date"},
+			"edited_at":       map[string]any{"type": "date"},
+		},
+	},
+}
+
+// buildQuery translates a search into an Elasticsearch request body. The
+// membership filter is always applied, so a conversation ID the user does not
+// belong to simply matches nothing.
+func buildQuery(userID string, groupIDs []string, query models.MessageSearchQuery) map[string]any {
+	access := []any{
+		map[string]any{"term": map[string]any{"participant_ids": userID}},
+	}
+	if len(groupIDs) > 0 {
+		access = append(access, map[string]any{"terms": map[string]any{"group_id": groupIDs}})
+	}
+
+	filters := []any{
+		map[string]any{"bool": map[string]any{"should": access, "minimum_should_match": 1}},
+	}
+	if query.ConversationID != "" {
+		filters = append(filters, map[string]any{"term": map[string]any{"conversation_id": query.ConversationID}})
+	}
+	if len(query.SenderIDs) > 0 {
+		filters = append(filters, map[string]any{"terms": map[string]any{"sender_id": query.SenderIDs}})
+	}
+	if query.From != nil || query.To != nil {
+		dateRange := map[string]any{}
+		if query.From != nil {
+			dateRange["gte"] = query.From.UTC().Format(time.RFC3339Nano)
+		}
+		if query.To != nil {
+			dateRange["lte"] = query.To.UTC().Format(time.RFC3339Nano)
+		}
+		filters = append(filters, map[string]any{"range": map[string]any{"created_at": dateRange}})
+	}
+
+	page, limit := query.Page, query.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	from := (page - 1) * limit
+	if from+limit > MaxResultWindow {
+		from = max(MaxResultWindow-limit, 0)
+	}
+
+	return map[string]any{
+		"from": from,
+		"size": limit,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must": []any{
+					map[string]any{"match": map[string]any{
+						"content": map[string]any{"query": query.Query, "operator": "and", "fuzziness": "AUTO"},
+					}},
+				},
+				"filter": filters,
+			},
+		},
+		"sort": []any{
+			map[string]any{"_score": "desc"},
+			map[string]any{"created_at": "desc"},
+		},
+		"highlight": map[string]any{
+			"pre_tags":  []string{"<mark>"},
+			"post_tags": []string{"</mark>"},
+			"fields": map[string]any{
+				"content": map[string]any{"fragment_size": highlightFragmentSize, "number_of_fragments": 3},
+			},
+		},
+	}
+}
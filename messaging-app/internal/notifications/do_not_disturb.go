@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"github.com/redis/go-redis/v9"
+	kafkago "github.com/segmentio/kafka-go"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// dndPendingKey is a sorted set of the users with held notifications,
+	// scored by when the first was held
+	dndPendingKey = "dnd:pending"
+	// dndHeldTTL bounds how long held notification IDs are kept; the
+	// notifications themselves stay in the user's list regardless
+	dndHeldTTL = 14 * 24 * time.Hour
+	// dndFlushInterval is how often digests are sent to users whose Do Not
+	// Disturb has ended, by schedule or by expiring
+	dndFlushInterval = time.Minute
+	// dndDigestLimit caps the notifications listed in a digest; Count still
+	// covers all of them
+	dndDigestLimit = 50
+)
+
+// dndHeldKey is the set of notification IDs held back for userID
+func dndHeldKey(userID string) string {
+	return "dnd:held:" + userID
+}
+
+// UpdateDoNotDisturb replaces the user's Do Not Disturb settings; nil turns
+// it off. If it is no longer active, notifications held so far are delivered
+// right away.
+func (s *NotificationService) UpdateDoNotDisturb(ctx context.Context, userID primitive.ObjectID, dnd *models.DoNotDisturb) (*models.NotificationPreferences, error) {
+	if dnd != nil {
+		if err := dnd.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidDoNotDisturb, err)
+		}
+	}
+
+	if err := s.preferencesRepo.SetDoNotDisturb(ctx, userID, dnd); err != nil {
+		return nil, fmt.Errorf("failed to update do not disturb: %w", err)
+	}
+	prefs, err := s.reloadPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !prefs.InDoNotDisturb(time.Now()) {
+		if err := s.deliverDigest(ctx, userID); err != nil {
+			fmt.Printf("failed to deliver do not disturb digest to %s: %v\n", userID.Hex(), err)
+		}
+	}
+	return prefs, nil
+}
+
+// holdForDigest queues a notification for the recipient's digest. Both the
+// hub and the push worker ask about each notification, so holding is
+// idempotent.
+func (s *NotificationService) holdForDigest(ctx context.Context, notification *models.Notification, now time.Time) {
+	if s.redisClient == nil {
+		return
+	}
+	userID := notification.RecipientID.Hex()
+	key := dndHeldKey(userID)
+	_, err := s.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, key, notification.ID.Hex())
+		pipe.Expire(ctx, key, dndHeldTTL)
+		pipe.ZAddNX(ctx, dndPendingKey, redis.Z{Score: float64(now.Unix()), Member: userID})
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("failed to hold notification %s for %s's digest: %v\n", notification.ID.Hex(), userID, err)
+	}
+}
+
+// StartDoNotDisturbWorker delivers digests to users whose Do Not Disturb has
+// ended every dndFlushInterval until ctx is done
+func (s *NotificationService) StartDoNotDisturbWorker(ctx context.Context) {
+	if s.redisClient == nil {
+		return
+	}
+	ticker := time.NewTicker(dndFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.FlushDoNotDisturb(ctx); err != nil {
+				fmt.Printf("failed to flush do not disturb digests: %v\n", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// FlushDoNotDisturb delivers a digest to every user with held notifications
+// who is no longer in Do Not Disturb
+func (s *NotificationService) FlushDoNotDisturb(ctx context.Context) error {
+	pending, err := s.redisClient.ZRange(ctx, dndPendingKey, 0, -1).Result()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, id := range pending {
+		userID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			s.redisClient.ZRem(ctx, dndPendingKey, id)
+			continue
+		}
+		prefs, err := s.GetPreferences(ctx, userID)
+		if err != nil {
+			fmt.Printf("failed to load notification preferences for %s: %v\n", id, err)
+			continue
+		}
+		if prefs.InDoNotDisturb(now) {
+			continue
+		}
+		if err := s.deliverDigest(ctx, userID); err != nil {
+			fmt.Printf("failed to deliver do not disturb digest to %s: %v\n", id, err)
+		}
+	}
+	return nil
+}
+
+// deliverDigest sends the notifications held for userID that are still
+// unread as one NOTIFICATION_DIGEST event, plus a summary push. Removing the
+// user from the pending set claims the digest, so only one node sends it.
+func (s *NotificationService) deliverDigest(ctx context.Context, userID primitive.ObjectID) error {
+	if s.redisClient == nil {
+		return nil
+	}
+	claimed, err := s.redisClient.ZRem(ctx, dndPendingKey, userID.Hex()).Result()
+	if err != nil || claimed == 0 {
+		return err
+	}
+
+	key := dndHeldKey(userID.Hex())
+	var held *redis.StringSliceCmd
+	if _, err := s.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		held = pipe.SMembers(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to take held notifications: %w", err)
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(held.Val()))
+	for _, id := range held.Val() {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			ids = append(ids, oid)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// Notifications read in the app while Do Not Disturb was on are left out
+	unread, err := s.notificationRepo.ListNotifications(ctx, userID,
+		bson.M{"_id": bson.M{"$in": ids}, "read": false},
+		options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}, {Key: "created_at", Value: -1}}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load held notifications: %w", err)
+	}
+	if len(unread) == 0 {
+		return nil
+	}
+
+	digest := newNotificationDigest(unread)
+	data, err := json.Marshal(digest)
+	if err != nil {
+		return err
+	}
+	event, err := json.Marshal(models.WebSocketEvent{Type: "NOTIFICATION_DIGEST", Data: data, Recipients: []string{userID.Hex()}})
+	if err != nil {
+		return err
+	}
+	if err := s.kafkaProducer.ProduceMessage(ctx, kafkago.Message{Key: []byte(userID.Hex()), Value: event}); err != nil {
+		return fmt.Errorf("failed to publish digest: %w", err)
+	}
+
+	if s.pushProducer != nil {
+		summary, _ := json.Marshal(digestPush(userID, digest.Count, time.Now()))
+		if err := s.pushProducer.ProduceMessage(ctx, kafkago.Message{Key: []byte(userID.Hex()), Value: summary}); err != nil {
+			fmt.Printf("failed to enqueue do not disturb digest for push to %s: %v\n", userID.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// newNotificationDigest lists at most dndDigestLimit of the held notifications
+func newNotificationDigest(unread []models.Notification) models.NotificationDigest {
+	digest := models.NotificationDigest{Count: len(unread), Notifications: unread}
+	if len(unread) > dndDigestLimit {
+		digest.Notifications = unread[:dndDigestLimit]
+	}
+	return digest
+}
+
+// digestPush is the summary pushed in place of the held notifications
+func digestPush(userID primitive.ObjectID, count int, now time.Time) *models.Notification {
+	content := "1 notification arrived while Do Not Disturb was on."
+	if count != 1 {
+		content = fmt.Sprintf("%d notifications arrived while Do Not Disturb was on.", count)
+	}
+	return &models.Notification{
+		ID:          primitive.NewObjectIDFromTimestamp(now),
+		RecipientID: userID,
+		Type:        models.NotificationTypeDoNotDisturbDigest,
+		TargetID:    userID,
+		TargetType:  "user",
+		Content:     content,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDoNotDisturb_ActiveAt(t *testing.T) {
+	// Friday 2024-03-15, 23:30 UTC
+	now := time.Date(2024, 3, 15, 23, 30, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+	overnight := func(days ...time.Weekday) models.DoNotDisturbWindow {
+		return models.DoNotDisturbWindow{QuietHours: models.QuietHours{Start: "22:00", End: "07:00"}, Days: days}
+	}
+
+	tests := []struct {
+		name string
+		dnd  models.DoNotDisturb
+		at   time.Time
+		want bool
+	}{
+		{"off", models.DoNotDisturb{}, now, false},
+		{"on for good", models.DoNotDisturb{Enabled: true}, now, true},
+		{"on until later", models.DoNotDisturb{Enabled: true, Until: &later}, now, true},
+		{"expired", models.DoNotDisturb{Enabled: true, Until: &earlier}, now, false},
+		{"scheduled every day", models.DoNotDisturb{Schedule: []models.DoNotDisturbWindow{overnight()}}, now, true},
+		{"scheduled on the start day", models.DoNotDisturb{Schedule: []models.DoNotDisturbWindow{overnight(time.Friday)}}, now, true},
+		{"past midnight belongs to the start day", models.DoNotDisturb{Schedule: []models.DoNotDisturbWindow{overnight(time.Friday)}}, now.Add(2 * time.Hour), true},
+		{"not scheduled on other days", models.DoNotDisturb{Schedule: []models.DoNotDisturbWindow{overnight(time.Saturday)}}, now, false},
+		{"outside the window", models.DoNotDisturb{Schedule: []models.DoNotDisturbWindow{overnight()}}, now.Add(-3 * time.Hour), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.dnd.ActiveAt(tt.at))
+		})
+	}
+}
+
+func TestNotificationPreferences_HoldsBack(t *testing.T) {
+	now := time.Now()
+	friend, stranger := primitive.NewObjectID(), primitive.NewObjectID()
+	prefs := &models.NotificationPreferences{DoNotDisturb: &models.DoNotDisturb{Enabled: true, AllowedUserIDs: []primitive.ObjectID{friend}}}
+
+	assert.False(t, prefs.HoldsBack(&models.Notification{SenderID: friend}, now))
+	assert.True(t, prefs.HoldsBack(&models.Notification{SenderID: stranger}, now))
+	assert.False(t, (&models.NotificationPreferences{}).HoldsBack(&models.Notification{SenderID: stranger}, now))
+}
+
+func TestDoNotDisturb_Validate(t *testing.T) {
+	valid := models.DoNotDisturbWindow{QuietHours: models.QuietHours{Start: "09:00", End: "17:00", TimeZone: "Europe/Berlin"}, Days: []time.Weekday{time.Monday}}
+
+	assert.NoError(t, models.DoNotDisturb{Focus: models.FocusWork, Schedule: []models.DoNotDisturbWindow{valid}}.Validate())
+	assert.Error(t, models.DoNotDisturb{Focus: "gaming"}.Validate())
+	assert.Error(t, models.DoNotDisturb{Schedule: []models.DoNotDisturbWindow{{QuietHours: models.QuietHours{Start: "9am", End: "17:00"}}}}.Validate())
+	assert.Error(t, models.DoNotDisturb{Schedule: []models.DoNotDisturbWindow{{QuietHours: valid.QuietHours, Days: []time.Weekday{7}}}}.Validate())
+}
+
+func TestNotificationService_UpdateDoNotDisturbRejectsInvalid(t *testing.T) {
+	// Checked before the repository is touched
+	s := &NotificationService{}
+	_, err := s.UpdateDoNotDisturb(context.Background(), primitive.NewObjectID(), &models.DoNotDisturb{Focus: "gaming"})
+	assert.ErrorIs(t, err, ErrInvalidDoNotDisturb)
+}
+
+func TestNewNotificationDigest(t *testing.T) {
+	unread := make([]models.Notification, dndDigestLimit+5)
+	digest := newNotificationDigest(unread)
+	assert.Equal(t, dndDigestLimit+5, digest.Count)
+	assert.Len(t, digest.Notifications, dndDigestLimit)
+}
+
+func TestDigestPush(t *testing.T) {
+	userID := primitive.NewObjectID()
+	now := time.Now()
+
+	n := digestPush(userID, 3, now)
+	assert.Equal(t, userID, n.RecipientID)
+	assert.Equal(t, models.NotificationTypeDoNotDisturbDigest, n.Type)
+	assert.Equal(t, "3 notifications arrived while Do Not Disturb was on.", n.Content)
+	assert.Equal(t, "1 notification arrived while Do Not Disturb was on.", digestPush(userID, 1, now).Content)
+}
@@ -5,32 +5,74 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"messaging-app/internal/cache"
 	"messaging-app/internal/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"messaging-app/internal/repositories"
+	"strings"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	kafkago "github.com/segmentio/kafka-go"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+var (
+	// ErrInvalidMuteConversation is returned when muting something other than a
+	// "dm_..." or "group_..." conversation
+	ErrInvalidMuteConversation = apperrors.Validation("conversation ID must start with dm_ or group_")
+	// ErrUnknownNotificationType is returned when muting a type that does not exist
+	ErrUnknownNotificationType = apperrors.Validation("unknown notification type")
+	// ErrInvalidQuietHours is returned for quiet hours with malformed times or time zone
+	ErrInvalidQuietHours = apperrors.Validation("invalid quiet hours")
+	// ErrInvalidDoNotDisturb is returned for Do Not Disturb settings with an
+	// unknown focus mode or a malformed schedule
+	ErrInvalidDoNotDisturb = apperrors.Validation("invalid do not disturb settings")
+	// ErrInvalidDevicePlatform is returned when registering a device for an unsupported platform
+	ErrInvalidDevicePlatform = apperrors.Validation("platform must be android, ios or web")
+	// ErrDeviceNotFound is returned when unregistering a token the user does not have
+	ErrDeviceNotFound = apperrors.NotFound("device not found")
+)
+
+// digestWindow is how long an unread digest keeps absorbing new notifications
+// after its last update
+const digestWindow = time.Hour
+
 type NotificationService struct {
 	notificationRepo *repositories.NotificationRepository
+	preferencesRepo  *repositories.NotificationPreferencesRepository
+	preferencesCache *cache.NotificationPreferencesCache
 	userRepo         *repositories.UserRepository
+	deviceRepo       *repositories.DeviceTokenRepository
 	kafkaProducer    *kafka.MessageProducer
+	// redisClient holds notifications back while Do Not Disturb is on
+	redisClient *redis.ClusterClient
+	// pushProducer enqueues created notifications for mobile/web push; nil disables it
+	pushProducer *kafka.MessageProducer
 }
 
-func NewNotificationService(nr *repositories.NotificationRepository, ur *repositories.UserRepository, kp *kafka.MessageProducer) *NotificationService {
+func NewNotificationService(nr *repositories.NotificationRepository, pr *repositories.NotificationPreferencesRepository, ur *repositories.UserRepository, dr *repositories.DeviceTokenRepository, kp *kafka.MessageProducer, redisClient *redis.ClusterClient) *NotificationService {
 	return &NotificationService{
 		notificationRepo: nr,
+		preferencesRepo:  pr,
+		preferencesCache: cache.NewNotificationPreferencesCache(redisClient),
 		userRepo:         ur,
+		deviceRepo:       dr,
 		kafkaProducer:    kp,
+		redisClient:      redisClient,
 	}
 }
 
+// SetPushProducer enables push delivery of created notifications
+func (s *NotificationService) SetPushProducer(p *kafka.MessageProducer) {
+	s.pushProducer = p
+}
+
 func (s *NotificationService) CreateNotification(ctx context.Context, req *models.CreateNotificationRequest) (*models.Notification, error) {
 	// Prevent self-notification
 	if req.RecipientID == req.SenderID {
@@ -42,10 +84,12 @@ func (s *NotificationService) CreateNotification(ctx context.Context, req *model
 	if _, err := s.userRepo.FindUserByID(ctx, req.RecipientID); err != nil {
 		return nil, errors.New("recipient user not found")
 	}
-	if _, err := s.userRepo.FindUserByID(ctx, req.SenderID); err != nil {
+	sender, err := s.userRepo.FindUserByID(ctx, req.SenderID)
+	if err != nil {
 		return nil, errors.New("sender user not found")
 	}
 
+	now := time.Now()
 	notification := &models.Notification{
 		RecipientID: req.RecipientID,
 		SenderID:    req.SenderID,
@@ -55,6 +99,31 @@ func (s *NotificationService) CreateNotification(ctx context.Context, req *model
 		Content:     req.Content,
 		Data:        req.Data,
 		Read:        false,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	// Muted notifications are dropped; callers treat them like self-notifications
+	prefs, err := s.GetPreferences(ctx, req.RecipientID)
+	if err != nil {
+		fmt.Printf("failed to load notification preferences for %s, notifying anyway: %v\n", req.RecipientID.Hex(), err)
+	} else if prefs.Mutes(notification, now) {
+		return nil, nil
+	}
+
+	if models.DigestibleNotificationTypes[req.Type] {
+		notification.GroupKey = models.DigestGroupKey(req.Type, req.TargetID)
+		notification.ActorIDs = []primitive.ObjectID{req.SenderID}
+		notification.ActorCount = 1
+
+		merged, err := s.notificationRepo.MergeIntoDigest(ctx, notification, now.Add(-digestWindow), sender.Username, digestAction(req.Type, req.TargetType))
+		if err == nil {
+			s.publish(ctx, merged, "NOTIFICATION_UPDATED")
+			return merged, nil
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			fmt.Printf("failed to merge notification into digest for %s, creating it: %v\n", req.RecipientID.Hex(), err)
+		}
 	}
 
 	createdNotification, err := s.notificationRepo.CreateNotification(ctx, notification)
@@ -62,19 +131,50 @@ func (s *NotificationService) CreateNotification(ctx context.Context, req *model
 		return nil, fmt.Errorf("failed to create notification: %w", err)
 	}
 
-	// Publish notification to Kafka with retry mechanism
-	notificationJSON, err := json.Marshal(createdNotification)
+	s.publish(ctx, createdNotification, "")
+	return createdNotification, nil
+}
+
+// digestAction is the text following the actors in a digest's content
+func digestAction(t models.NotificationType, targetType string) string {
+	switch t {
+	case models.NotificationTypeLike:
+		return "reacted to your " + targetType + "."
+	case models.NotificationTypeComment:
+		return "commented on your " + targetType + "."
+	case models.NotificationTypeReply:
+		return "replied to your " + targetType + "."
+	case models.NotificationTypeShare:
+		return "shared your " + targetType + "."
+	}
+	return "interacted with your " + targetType + "."
+}
+
+// publish sends a notification to Kafka for real-time delivery and, when push
+// is enabled, enqueues it for the push worker. New notifications go out as-is;
+// a non-empty eventType wraps the notification in a WebSocketEvent of that type.
+func (s *NotificationService) publish(ctx context.Context, notification *models.Notification, eventType string) {
+	notificationJSON, err := json.Marshal(notification)
 	if err != nil {
-		// This is a critical error, as the notification is in DB but cannot be marshaled for Kafka.
-		// Log and potentially alert.
-		return nil, fmt.Errorf("failed to marshal notification to JSON for Kafka: %w", err)
+		fmt.Printf("failed to marshal notification %s for Kafka: %v\n", notification.ID.Hex(), err)
+		return
+	}
+
+	value := notificationJSON
+	if eventType != "" {
+		value, err = json.Marshal(models.WebSocketEvent{Type: eventType, Data: notificationJSON})
+		if err != nil {
+			fmt.Printf("failed to marshal %s event for notification %s: %v\n", eventType, notification.ID.Hex(), err)
+			return
+		}
 	}
 
 	kMessage := kafkago.Message{
-		Key:   []byte(createdNotification.RecipientID.Hex()),
-		Value: notificationJSON,
+		Key:   []byte(notification.RecipientID.Hex()),
+		Value: value,
 	}
 
+	// Publish notification to Kafka with retry mechanism
 	const maxRetries = 5
 	for i := 0; i < maxRetries; i++ {
 		err = s.kafkaProducer.ProduceMessage(ctx, kMessage)
@@ -85,7 +185,8 @@ func (s *NotificationService) CreateNotification(ctx context.Context, req *model
 		// Exponential backoff
 		select {
 		case <-ctx.Done():
-			return createdNotification, fmt.Errorf("context cancelled during Kafka production retry: %w", ctx.Err())
+			fmt.Printf("context cancelled during Kafka production retry for notification %s: %v\n", notification.ID.Hex(), ctx.Err())
+			return
 		case <-time.After(time.Duration(1<<i) * time.Second):
 			// Wait for 1, 2, 4, 8, 16 seconds
 		}
@@ -94,10 +195,15 @@ func (s *NotificationService) CreateNotification(ctx context.Context, req *model
 	if err != nil {
 		// All retries failed. Log a critical error. Notification is in DB but not in Kafka.
 		// A separate reconciliation process might be needed for eventual consistency.
-		fmt.Printf("CRITICAL: failed to publish notification %s to Kafka after %d retries: %v\n", createdNotification.ID.Hex(), maxRetries, err)
+		fmt.Printf("CRITICAL: failed to publish notification %s to Kafka after %d retries: %v\n", notification.ID.Hex(), maxRetries, err)
 	}
 
-	return createdNotification, nil
+	if s.pushProducer != nil {
+		// The push worker decides whether the recipient is offline; failures only cost the push
+		if err := s.pushProducer.ProduceMessage(ctx, kafkago.Message{Key: kMessage.Key, Value: notificationJSON}); err != nil {
+			fmt.Printf("failed to enqueue notification %s for push: %v\n", notification.ID.Hex(), err)
+		}
+	}
 }
 
 func (s *NotificationService) GetNotificationByID(ctx context.Context, notificationID primitive.ObjectID) (*models.Notification, error) {
@@ -113,7 +219,7 @@ func (s *NotificationService) ListNotifications(ctx context.Context, userID prim
 	opts := options.Find().
 		SetSkip((page - 1) * limit).
 		SetLimit(limit).
-		SetSort(bson.D{{Key: "created_at", Value: -1}})
+		SetSort(bson.D{{Key: "updated_at", Value: -1}, {Key: "created_at", Value: -1}}) // Digests surface again when they absorb a notification
 
 	notifications, err := s.notificationRepo.ListNotifications(ctx, userID, filter, opts)
 	if err != nil {
@@ -164,3 +270,114 @@ func (s *NotificationService) DeleteNotification(ctx context.Context, notificati
 
 	return s.notificationRepo.DeleteNotification(ctx, notificationID)
 }
+
+// GetPreferences returns the user's notification preferences
+func (s *NotificationService) GetPreferences(ctx context.Context, userID primitive.ObjectID) (*models.NotificationPreferences, error) {
+	prefs, err := s.preferencesCache.Get(ctx, userID.Hex(), func(ctx context.Context) (models.NotificationPreferences, error) {
+		prefs, err := s.preferencesRepo.Get(ctx, userID)
+		if err != nil {
+			return models.NotificationPreferences{}, err
+		}
+		return *prefs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// UpdatePreferences replaces the user's muted types and quiet hours
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID primitive.ObjectID, req *models.UpdateNotificationPreferencesRequest) (*models.NotificationPreferences, error) {
+	for _, t := range req.MutedTypes {
+		if !models.ValidNotificationTypes[t] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownNotificationType, t)
+		}
+	}
+	if req.QuietHours != nil {
+		if err := req.QuietHours.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuietHours, err)
+		}
+	}
+
+	if err := s.preferencesRepo.Update(ctx, userID, req.MutedTypes, req.QuietHours); err != nil {
+		return nil, fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+	return s.reloadPreferences(ctx, userID)
+}
+
+// MuteConversation mutes notifications from a direct message or group until
+// the given time, or for good when until is nil
+func (s *NotificationService) MuteConversation(ctx context.Context, userID primitive.ObjectID, conversationID string, until *time.Time) (*models.NotificationPreferences, error) {
+	if !strings.HasPrefix(conversationID, "dm_") && !strings.HasPrefix(conversationID, "group_") {
+		return nil, ErrInvalidMuteConversation
+	}
+	mute := models.MutedConversation{ConversationID: conversationID, Until: until}
+	if err := s.preferencesRepo.MuteConversation(ctx, userID, mute); err != nil {
+		return nil, fmt.Errorf("failed to mute conversation: %w", err)
+	}
+	return s.reloadPreferences(ctx, userID)
+}
+
+// UnmuteConversation lifts a conversation's mute
+func (s *NotificationService) UnmuteConversation(ctx context.Context, userID primitive.ObjectID, conversationID string) (*models.NotificationPreferences, error) {
+	if err := s.preferencesRepo.UnmuteConversation(ctx, userID, conversationID); err != nil {
+		return nil, fmt.Errorf("failed to unmute conversation: %w", err)
+	}
+	return s.reloadPreferences(ctx, userID)
+}
+
+func (s *NotificationService) reloadPreferences(ctx context.Context, userID primitive.ObjectID) (*models.NotificationPreferences, error) {
+	if err := s.preferencesCache.Invalidate(ctx, userID.Hex()); err != nil {
+		fmt.Printf("failed to invalidate notification preferences for %s: %v\n", userID.Hex(), err)
+	}
+	return s.GetPreferences(ctx, userID)
+}
+
+// ShouldPush reports whether a notification may be pushed to its recipient in
+// real time: it must not be muted, held back by Do Not Disturb or fall inside
+// quiet hours. Held notifications are queued for the recipient's digest.
+// Notifications are pushed when preferences cannot be loaded.
+func (s *NotificationService) ShouldPush(ctx context.Context, notification *models.Notification) bool {
+	prefs, err := s.GetPreferences(ctx, notification.RecipientID)
+	if err != nil {
+		fmt.Printf("failed to load notification preferences for %s: %v\n", notification.RecipientID.Hex(), err)
+		return true
+	}
+	now := time.Now()
+	if prefs.Mutes(notification, now) {
+		return false
+	}
+	if prefs.HoldsBack(notification, now) {
+		s.holdForDigest(ctx, notification, now)
+		return false
+	}
+	return !prefs.InQuietHours(now)
+}
+
+// RegisterDevice stores a push token for one of the user's devices
+func (s *NotificationService) RegisterDevice(ctx context.Context, userID primitive.ObjectID, req *models.RegisterDeviceRequest) (*models.DeviceToken, error) {
+	if !req.Platform.Valid() {
+		return nil, ErrInvalidDevicePlatform
+	}
+	device, err := s.deviceRepo.Register(ctx, userID, strings.TrimSpace(req.Token), req.Platform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register device: %w", err)
+	}
+	return device, nil
+}
+
+// ListDevices returns the user's registered devices
+func (s *NotificationService) ListDevices(ctx context.Context, userID primitive.ObjectID) ([]models.DeviceToken, error) {
+	return s.deviceRepo.ListByUser(ctx, userID)
+}
+
+// UnregisterDevice stops pushes to a device, e.g. on logout
+func (s *NotificationService) UnregisterDevice(ctx context.Context, userID primitive.ObjectID, token string) error {
+	if err := s.deviceRepo.Unregister(ctx, userID, token); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrDeviceNotFound
+		}
+		return fmt.Errorf("failed to unregister device: %w", err)
+	}
+	return nil
+}
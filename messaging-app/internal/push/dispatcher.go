@@ -0,0 +1,153 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	maxAttempts    = 4
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 30 * time.Second
+)
+
+// DeviceStore lists and prunes users' push tokens
+type DeviceStore interface {
+	ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.DeviceToken, error)
+	DeleteToken(ctx context.Context, token string) error
+}
+
+// Filter decides whether a user's preferences allow pushing a notification
+type Filter interface {
+	ShouldPush(ctx context.Context, notification *models.Notification) bool
+}
+
+// Dispatcher delivers notifications to the devices of recipients who are not
+// connected over WebSocket
+type Dispatcher struct {
+	devices     DeviceStore
+	filter      Filter
+	redisClient *redis.ClusterClient
+	fcm         Sender
+	apns        Sender
+}
+
+// NewDispatcher creates a dispatcher. fcm or apns may be nil when that
+// provider is not configured; iOS devices fall back to FCM without APNs.
+func NewDispatcher(devices DeviceStore, filter Filter, redisClient *redis.ClusterClient, fcm, apns Sender) *Dispatcher {
+	return &Dispatcher{
+		devices:     devices,
+		filter:      filter,
+		redisClient: redisClient,
+		fcm:         fcm,
+		apns:        apns,
+	}
+}
+
+// Deliver pushes n to every device of its recipient. It returns an error only
+// when no device could be reached because of a failure worth retrying later.
+func (d *Dispatcher) Deliver(ctx context.Context, n *models.Notification) error {
+	if d.isOnline(ctx, n.RecipientID.Hex()) {
+		return nil
+	}
+	if d.filter != nil && !d.filter.ShouldPush(ctx, n) {
+		return nil
+	}
+
+	devices, err := d.devices.ListByUser(ctx, n.RecipientID)
+	if err != nil {
+		return fmt.Errorf("failed to list devices: %w", err)
+	}
+	if len(devices) == 0 {
+		return nil
+	}
+
+	msg := Render(n)
+	var lastErr error
+	delivered := 0
+	for _, device := range devices {
+		sender := d.senderFor(device.Platform)
+		if sender == nil {
+			continue
+		}
+
+		err := d.sendWithRetry(ctx, sender, device.Token, msg)
+		switch {
+		case err == nil:
+			delivered++
+		case errors.Is(err, ErrInvalidToken):
+			if delErr := d.devices.DeleteToken(ctx, device.Token); delErr != nil {
+				log.Printf("Failed to delete invalid %s push token: %v", device.Platform, delErr)
+			}
+		default:
+			log.Printf("Failed to push notification %s to %s device: %v", n.ID.Hex(), device.Platform, err)
+			lastErr = err
+		}
+	}
+
+	if delivered == 0 && lastErr != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (d *Dispatcher) senderFor(platform models.DevicePlatform) Sender {
+	if platform == models.DevicePlatformIOS && d.apns != nil {
+		return d.apns
+	}
+	return d.fcm
+}
+
+// isOnline reports whether the hub has the user marked as connected. Lookup
+// failures count as offline so a Redis outage does not swallow pushes.
+func (d *Dispatcher) isOnline(ctx context.Context, userID string) bool {
+	if d.redisClient == nil {
+		return false
+	}
+	val, err := d.redisClient.Get(ctx, "presence:"+userID).Result()
+	if err != nil {
+		return false
+	}
+	var presence struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal([]byte(val), &presence); err != nil {
+		return false
+	}
+	return presence.Status == "online"
+}
+
+// sendWithRetry retries retryable failures with exponential backoff, waiting
+// at least as long as the provider asked
+func (d *Dispatcher) sendWithRetry(ctx context.Context, sender Sender, token string, msg Message) error {
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		err := sender.Send(ctx, token, msg)
+		var retryable *RetryableError
+		if err == nil || !errors.As(err, &retryable) || attempt == maxAttempts {
+			return err
+		}
+
+		wait := backoff
+		if retryable.RetryAfter > wait {
+			wait = retryable.RetryAfter
+		}
+		if wait > maxBackoff {
+			wait = maxBackoff
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
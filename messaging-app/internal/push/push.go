@@ -0,0 +1,54 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidToken is returned by a Sender when the provider no longer accepts
+// a device token, e.g. because the app was uninstalled. Such tokens are deleted.
+var ErrInvalidToken = errors.New("device token is no longer valid")
+
+// Message is a notification rendered for delivery to a device
+type Message struct {
+	Title string
+	Body  string
+	// CollapseKey lets the device replace an undelivered or displayed message
+	// with a newer one on the same subject
+	CollapseKey string
+	Data        map[string]string
+}
+
+// Sender delivers a message to a single device through a push provider
+type Sender interface {
+	Send(ctx context.Context, token string, msg Message) error
+}
+
+// RetryableError is a provider failure worth retrying, such as throttling or
+// an outage. RetryAfter is set when the provider said how long to wait.
+type RetryableError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryableError) Error() string { return e.Err.Error() }
+
+func (e *RetryableError) Unwrap() error { return e.Err }
+
+// statusError turns an unsuccessful provider response into an error, marking
+// throttling and server errors as retryable
+func statusError(provider string, res *http.Response, reason string) error {
+	err := fmt.Errorf("%s returned %d: %s", provider, res.StatusCode, reason)
+	if res.StatusCode != http.StatusTooManyRequests && res.StatusCode < 500 {
+		return err
+	}
+	retryable := &RetryableError{Err: err}
+	if secs, convErr := strconv.Atoi(res.Header.Get("Retry-After")); convErr == nil && secs > 0 {
+		retryable.RetryAfter = time.Duration(secs) * time.Second
+	}
+	return retryable
+}
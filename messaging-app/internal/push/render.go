@@ -0,0 +1,66 @@
+package push
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+var titles = map[models.NotificationType]string{
+	models.NotificationTypeMention:             "New mention",
+	models.NotificationTypeLike:                "New reaction",
+	models.NotificationTypeComment:             "New comment",
+	models.NotificationTypeReply:               "New reply",
+	models.NotificationTypeShare:               "Your post was shared",
+	models.NotificationTypeFriendRequest:       "New friend request",
+	models.NotificationTypeFriendAccept:        "Friend request accepted",
+	models.NotificationTypeNewFollower:         "New follower",
+	models.NotificationTypeBirthday:            "Birthday reminder",
+	models.NotificationTypeEventInvite:         "Event invitation",
+	models.NotificationTypeEventReminder:       "Event reminder",
+	models.NotificationTypeEventInviteAccepted: "Invitation accepted",
+	models.NotificationTypeEventInviteDeclined: "Invitation declined",
+	models.NotificationTypeEventWaitlistSeat:   "You're off the waitlist",
+	models.NotificationTypePostApproved:        "Post approved",
+	models.NotificationTypePostDeclined:        "Post declined",
+	models.NotificationTypeDoNotDisturbDigest:  "While you were in Do Not Disturb",
+}
+
+const defaultTitle = "Connectify"
+
+// Render turns a stored notification into a push message. Notifications from
+// the same conversation, or of the same type about the same target, share a
+// collapse key so a device shows only the latest of them.
+func Render(n *models.Notification) Message {
+	title, ok := titles[n.Type]
+	if !ok {
+		title = defaultTitle
+	}
+
+	data := map[string]string{
+		"notification_id": n.ID.Hex(),
+		"type":            string(n.Type),
+		"target_id":       n.TargetID.Hex(),
+		"target_type":     n.TargetType,
+	}
+	subject := string(n.Type) + ":" + n.TargetID.Hex()
+	if conversationID, _ := n.Data[models.NotificationDataConversationID].(string); conversationID != "" {
+		data[models.NotificationDataConversationID] = conversationID
+		subject = conversationID
+	}
+
+	return Message{
+		Title:       title,
+		Body:        n.Content,
+		CollapseKey: collapseKey(subject),
+		Data:        data,
+	}
+}
+
+// collapseKey hashes a subject into 32 hex characters, which fits the limits
+// of every provider: 64 bytes for APNs and 32 URL-safe characters for web push
+func collapseKey(subject string) string {
+	sum := sha256.Sum256([]byte(subject))
+	return hex.EncodeToString(sum[:16])
+}
@@ -33,6 +33,7 @@ func New(ctx context.Context, cfg *config.Config) (*Client, error) {
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithBlock(),
 		observability.GetGRPCDialOption(),
+		resilience.NewGRPCClient("reel-service").WithTimeout(10*time.Second).WithRetry(resilience.DefaultRetryPolicy(), resilience.ReadOnlyMethods...).WithoutCircuitBreaker().DialOption(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("connect to reel gRPC at %s: %w", addr, err)
@@ -117,6 +118,28 @@ func (c *Client) GetReelsFeed(ctx context.Context, viewerID string, limit, offse
 	return result.(*reelpb.GetReelsFeedResponse).Reels, nil
 }
 
+// GetForYouFeed returns recommended reels the viewer hasn't been served
+func (c *Client) GetForYouFeed(ctx context.Context, viewerID string, limit int64) ([]*reelpb.Reel, error) {
+	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.GetForYouFeed(ctx, &reelpb.GetForYouFeedRequest{
+			ViewerId: viewerID,
+			Limit:    limit,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get for you feed: %w", err)
+	}
+	return result.(*reelpb.GetForYouFeedResponse).Reels, nil
+}
+
+// RecordEngagement passes on a watch, complete or share signal
+func (c *Client) RecordEngagement(ctx context.Context, req *reelpb.RecordEngagementRequest) error {
+	_, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.RecordEngagement(ctx, req)
+	})
+	return err
+}
+
 func (c *Client) IncrementView(ctx context.Context, reelID, viewerID string) error {
 	// Not critical, maybe skip circuit breaker? Or keep it.
 	_, err := c.cb.Execute(ctx, func() (interface{}, error) {
@@ -170,6 +193,63 @@ func (c *Client) AddReply(ctx context.Context, req *reelpb.AddReplyRequest) (*re
 	return result.(*reelpb.AddReplyResponse).Reply, nil
 }
 
+func (c *Client) GetReplies(ctx context.Context, reelID, commentID string, limit, offset int64) ([]*reelpb.Reply, error) {
+	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.GetReplies(ctx, &reelpb.GetRepliesRequest{
+			ReelId:    reelID,
+			CommentId: commentID,
+			Limit:     limit,
+			Offset:    offset,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get replies: %w", err)
+	}
+	return result.(*reelpb.GetRepliesResponse).Replies, nil
+}
+
+func (c *Client) DeleteComment(ctx context.Context, reelID, commentID, userID string) error {
+	_, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.DeleteComment(ctx, &reelpb.DeleteCommentRequest{
+			ReelId:    reelID,
+			CommentId: commentID,
+			UserId:    userID,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("delete comment %s: %w", commentID, err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteReply(ctx context.Context, reelID, commentID, replyID, userID string) error {
+	_, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.DeleteReply(ctx, &reelpb.DeleteReplyRequest{
+			ReelId:    reelID,
+			CommentId: commentID,
+			ReplyId:   replyID,
+			UserId:    userID,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("delete reply %s: %w", replyID, err)
+	}
+	return nil
+}
+
+func (c *Client) ReactToReply(ctx context.Context, reelID, commentID, replyID, userID string, reactionType string) error {
+	_, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.ReactToReply(ctx, &reelpb.ReactToReplyRequest{
+			ReelId:       reelID,
+			CommentId:    commentID,
+			ReplyId:      replyID,
+			UserId:       userID,
+			ReactionType: reactionType,
+		})
+	})
+	return err
+}
+
 func (c *Client) ReactToComment(ctx context.Context, reelID, commentID, userID string, reactionType string) error {
 	_, err := c.cb.Execute(ctx, func() (interface{}, error) {
 		return c.client.ReactToComment(ctx, &reelpb.ReactToCommentRequest{
@@ -181,3 +261,106 @@ func (c *Client) ReactToComment(ctx context.Context, reelID, commentID, userID s
 	})
 	return err
 }
+
+func (c *Client) CreateLiveSession(ctx context.Context, userID, title, privacy string) (*reelpb.LiveSession, error) {
+	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.CreateLiveSession(ctx, &reelpb.CreateLiveSessionRequest{
+			UserId:  userID,
+			Title:   title,
+			Privacy: privacy,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create live session: %w", err)
+	}
+	return result.(*reelpb.LiveSessionResponse).Session, nil
+}
+
+func (c *Client) GetLiveSession(ctx context.Context, sessionID, viewerID string) (*reelpb.LiveSession, error) {
+	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.GetLiveSession(ctx, &reelpb.GetLiveSessionRequest{
+			SessionId: sessionID,
+			ViewerId:  viewerID,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get live session: %w", err)
+	}
+	return result.(*reelpb.LiveSessionResponse).Session, nil
+}
+
+func (c *Client) ListLiveSessions(ctx context.Context, viewerID string, limit int64) ([]*reelpb.LiveSession, error) {
+	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.ListLiveSessions(ctx, &reelpb.ListLiveSessionsRequest{
+			ViewerId: viewerID,
+			Limit:    limit,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list live sessions: %w", err)
+	}
+	return result.(*reelpb.ListLiveSessionsResponse).Sessions, nil
+}
+
+func (c *Client) EndLiveSession(ctx context.Context, sessionID, userID string) (*reelpb.LiveSession, error) {
+	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.EndLiveSession(ctx, &reelpb.EndLiveSessionRequest{
+			SessionId: sessionID,
+			UserId:    userID,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("end live session: %w", err)
+	}
+	return result.(*reelpb.LiveSessionResponse).Session, nil
+}
+
+func (c *Client) JoinLiveSession(ctx context.Context, sessionID, viewerID string) (int64, error) {
+	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.JoinLiveSession(ctx, &reelpb.LiveViewerRequest{
+			SessionId: sessionID,
+			ViewerId:  viewerID,
+		})
+	})
+	if err != nil {
+		return 0, fmt.Errorf("join live session: %w", err)
+	}
+	return result.(*reelpb.LiveViewerResponse).ViewerCount, nil
+}
+
+func (c *Client) LeaveLiveSession(ctx context.Context, sessionID, viewerID string) (int64, error) {
+	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.LeaveLiveSession(ctx, &reelpb.LiveViewerRequest{
+			SessionId: sessionID,
+			ViewerId:  viewerID,
+		})
+	})
+	if err != nil {
+		return 0, fmt.Errorf("leave live session: %w", err)
+	}
+	return result.(*reelpb.LiveViewerResponse).ViewerCount, nil
+}
+
+func (c *Client) SendLiveChatMessage(ctx context.Context, sessionID, userID, content string) (*reelpb.LiveChatMessage, error) {
+	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.SendLiveChatMessage(ctx, &reelpb.SendLiveChatMessageRequest{
+			SessionId: sessionID,
+			UserId:    userID,
+			Content:   content,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("send live chat message: %w", err)
+	}
+	return result.(*reelpb.SendLiveChatMessageResponse).Message, nil
+}
+
+func (c *Client) GetLiveChatMessages(ctx context.Context, req *reelpb.GetLiveChatMessagesRequest) ([]*reelpb.LiveChatMessage, error) {
+	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.GetLiveChatMessages(ctx, req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get live chat messages: %w", err)
+	}
+	return result.(*reelpb.GetLiveChatMessagesResponse).Messages, nil
+}
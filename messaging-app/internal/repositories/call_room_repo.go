@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrCallStateChanged means a call changed before an update could apply
+var ErrCallStateChanged = errors.New("call state changed")
+
+// CallRoomRepository stores group calls. Ended calls stay as the call
+// history of everyone invited.
+type CallRoomRepository struct {
+	db *mongo.Database
+}
+
+func NewCallRoomRepository(db *mongo.Database) *CallRoomRepository {
+	_, err := db.Collection("call_rooms").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "participants.user_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "participants.status", Value: 1}},
+			Options: options.Index(),
+		},
+	})
+	if err != nil {
+		panic("Failed to create call room indexes: " + err.Error())
+	}
+
+	return &CallRoomRepository{db: db}
+}
+
+func (r *CallRoomRepository) collection() *mongo.Collection {
+	return r.db.Collection("call_rooms")
+}
+
+func (r *CallRoomRepository) Create(ctx context.Context, room *models.CallRoom) error {
+	room.ID = primitive.NewObjectID()
+	_, err := r.collection().InsertOne(ctx, room)
+	return err
+}
+
+// GetByID returns mongo.ErrNoDocuments when there is no such call
+func (r *CallRoomRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.CallRoom, error) {
+	var room models.CallRoom
+	if err := r.collection().FindOne(ctx, bson.M{"_id": id}).Decode(&room); err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+// UpdateParticipant applies set to the user's entry, provided the call has
+// not ended and the user is in one of the given states. It returns the
+// updated call, or ErrCallStateChanged.
+func (r *CallRoomRepository) UpdateParticipant(ctx context.Context, roomID, userID primitive.ObjectID, from []models.CallParticipantStatus, set bson.M, inc bson.M) (*models.CallRoom, error) {
+	filter := bson.M{
+		"_id":    roomID,
+		"status": bson.M{"$ne": models.CallRoomEnded},
+		"participants": bson.M{"$elemMatch": bson.M{
+			"user_id": userID,
+			"status":  bson.M{"$in": from},
+		}},
+	}
+
+	update := bson.M{}
+	fields := bson.M{"updated_at": time.Now()}
+	for k, v := range set {
+		fields["participants.$."+k] = v
+	}
+	update["$set"] = fields
+	if len(inc) > 0 {
+		incFields := bson.M{}
+		for k, v := range inc {
+			incFields["participants.$."+k] = v
+		}
+		update["$inc"] = incFields
+	}
+
+	var room models.CallRoom
+	err := r.collection().FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&room)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrCallStateChanged
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+// Activate marks a ringing call active from the given time
+func (r *CallRoomRepository) Activate(ctx context.Context, roomID primitive.ObjectID, at time.Time) error {
+	_, err := r.collection().UpdateOne(ctx,
+		bson.M{"_id": roomID, "status": models.CallRoomRinging},
+		bson.M{"$set": bson.M{"status": models.CallRoomActive, "started_at": at, "updated_at": at}},
+	)
+	return err
+}
+
+// End saves the final state of a call, provided it has not ended already
+func (r *CallRoomRepository) End(ctx context.Context, room *models.CallRoom) (bool, error) {
+	res, err := r.collection().UpdateOne(ctx,
+		bson.M{"_id": room.ID, "status": bson.M{"$ne": models.CallRoomEnded}},
+		bson.M{"$set": bson.M{
+			"status":           models.CallRoomEnded,
+			"participants":     room.Participants,
+			"ended_at":         room.EndedAt,
+			"duration_seconds": room.DurationSeconds,
+			"updated_at":       time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// ListRingingSince returns calls with participants still ringing that
+// were invited before the given time
+func (r *CallRoomRepository) ListRingingSince(ctx context.Context, before time.Time) ([]models.CallRoom, error) {
+	return r.find(ctx, bson.M{
+		"status": bson.M{"$ne": models.CallRoomEnded},
+		"participants": bson.M{"$elemMatch": bson.M{
+			"status":     models.CallParticipantRinging,
+			"invited_at": bson.M{"$lt": before},
+		}},
+	}, options.Find())
+}
+
+// ListHistory returns the user's ended calls, newest first
+func (r *CallRoomRepository) ListHistory(ctx context.Context, userID primitive.ObjectID, page, limit int64) ([]models.CallRoom, int64, error) {
+	filter := bson.M{"participants.user_id": userID, "status": models.CallRoomEnded}
+
+	total, err := r.collection().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	rooms, err := r.find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return rooms, total, nil
+}
+
+// ListAllByUser returns every call the user was invited to, for their data
+// export
+func (r *CallRoomRepository) ListAllByUser(ctx context.Context, userID primitive.ObjectID) ([]models.CallRoom, error) {
+	return r.find(ctx, bson.M{"participants.user_id": userID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+}
+
+func (r *CallRoomRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.CallRoom, error) {
+	cursor, err := r.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	rooms := []models.CallRoom{}
+	if err := cursor.All(ctx, &rooms); err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+// DeleteByUser takes the user out of the calls they were invited to and
+// removes the calls left without participants
+func (r *CallRoomRepository) DeleteByUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	res, err := r.collection().UpdateMany(ctx,
+		bson.M{"participants.user_id": userID},
+		bson.M{"$pull": bson.M{"participants": bson.M{"user_id": userID}}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := r.collection().DeleteMany(ctx, bson.M{"participants": bson.M{"$size": 0}}); err != nil {
+		return res.ModifiedCount, err
+	}
+	return res.ModifiedCount, nil
+}
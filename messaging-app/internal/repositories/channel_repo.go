@@ -0,0 +1,311 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ErrChannelNotFound     = apperrors.NotFound("channel not found")
+	ErrChannelPostNotFound = apperrors.NotFound("channel post not found")
+)
+
+// ChannelRepository stores broadcast channels, their subscribers and their
+// posts. Subscribers live in their own collection so that a channel's size
+// is not bounded by its document.
+type ChannelRepository struct {
+	channels    *mongo.Collection
+	subscribers *mongo.Collection
+	posts       *mongo.Collection
+}
+
+func NewChannelRepository(db *mongo.Database) *ChannelRepository {
+	channels := db.Collection("channels")
+	subscribers := db.Collection("channel_subscribers")
+	posts := db.Collection("channel_posts")
+
+	_, err := channels.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "admins", Value: 1}},
+	})
+	if err != nil {
+		panic("Failed to create channel indexes: " + err.Error())
+	}
+
+	_, err = subscribers.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			// One subscription per user, and subscriber pages by channel
+			Keys:    bson.D{{Key: "channel_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "channel_id", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "subscribed_at", Value: -1}}},
+	})
+	if err != nil {
+		panic("Failed to create channel subscriber indexes: " + err.Error())
+	}
+
+	_, err = posts.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "channel_id", Value: 1}, {Key: "_id", Value: -1}},
+	})
+	if err != nil {
+		panic("Failed to create channel post indexes: " + err.Error())
+	}
+
+	return &ChannelRepository{channels: channels, subscribers: subscribers, posts: posts}
+}
+
+func (r *ChannelRepository) Create(ctx context.Context, channel *models.Channel) error {
+	channel.ID = primitive.NewObjectID()
+	now := time.Now()
+	channel.CreatedAt = now
+	channel.UpdatedAt = now
+	_, err := r.channels.InsertOne(ctx, channel)
+	return err
+}
+
+func (r *ChannelRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Channel, error) {
+	var channel models.Channel
+	err := r.channels.FindOne(ctx, bson.M{"_id": id}).Decode(&channel)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrChannelNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// GetByIDs returns the channels found among ids, in no particular order
+func (r *ChannelRepository) GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Channel, error) {
+	cursor, err := r.channels.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	channels := []models.Channel{}
+	if err := cursor.All(ctx, &channels); err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// Update sets fields of a channel and returns it as updated
+func (r *ChannelRepository) Update(ctx context.Context, id primitive.ObjectID, fields bson.M) (*models.Channel, error) {
+	fields["updated_at"] = time.Now()
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var channel models.Channel
+	err := r.channels.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$set": fields}, opts).Decode(&channel)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrChannelNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// Delete removes a channel with its subscriptions and posts
+func (r *ChannelRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	res, err := r.channels.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrChannelNotFound
+	}
+	if _, err := r.subscribers.DeleteMany(ctx, bson.M{"channel_id": id}); err != nil {
+		return err
+	}
+	_, err = r.posts.DeleteMany(ctx, bson.M{"channel_id": id})
+	return err
+}
+
+// AddAdmin makes a user an admin while the channel has fewer than
+// models.MaxChannelAdmins. It reports whether the channel had room.
+func (r *ChannelRepository) AddAdmin(ctx context.Context, channelID, userID primitive.ObjectID) (bool, error) {
+	filter := bson.M{
+		"_id": channelID,
+		"$or": []bson.M{
+			{"admins": userID},
+			{fmt.Sprintf("admins.%d", models.MaxChannelAdmins-1): bson.M{"$exists": false}},
+		},
+	}
+	res, err := r.channels.UpdateOne(ctx, filter, bson.M{
+		"$addToSet": bson.M{"admins": userID},
+		"$set":      bson.M{"updated_at": time.Now()},
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// RemoveAdmin takes away a user's admin rights; the owner keeps theirs
+func (r *ChannelRepository) RemoveAdmin(ctx context.Context, channelID, userID primitive.ObjectID) error {
+	_, err := r.channels.UpdateOne(ctx, bson.M{"_id": channelID, "owner_id": bson.M{"$ne": userID}}, bson.M{
+		"$pull": bson.M{"admins": userID},
+		"$set":  bson.M{"updated_at": time.Now()},
+	})
+	return err
+}
+
+// Subscribe adds a subscription and reports whether the user was not yet
+// subscribed
+func (r *ChannelRepository) Subscribe(ctx context.Context, channelID, userID primitive.ObjectID) (bool, error) {
+	_, err := r.subscribers.InsertOne(ctx, models.ChannelSubscriber{
+		ID:           primitive.NewObjectID(),
+		ChannelID:    channelID,
+		UserID:       userID,
+		SubscribedAt: time.Now(),
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	_, err = r.channels.UpdateOne(ctx, bson.M{"_id": channelID}, bson.M{"$inc": bson.M{"subscriber_count": 1}})
+	return true, err
+}
+
+// Unsubscribe removes a subscription and reports whether there was one
+func (r *ChannelRepository) Unsubscribe(ctx context.Context, channelID, userID primitive.ObjectID) (bool, error) {
+	res, err := r.subscribers.DeleteOne(ctx, bson.M{"channel_id": channelID, "user_id": userID})
+	if err != nil || res.DeletedCount == 0 {
+		return false, err
+	}
+	_, err = r.channels.UpdateOne(ctx, bson.M{"_id": channelID}, bson.M{"$inc": bson.M{"subscriber_count": -1}})
+	return true, err
+}
+
+func (r *ChannelRepository) IsSubscribed(ctx context.Context, channelID, userID primitive.ObjectID) (bool, error) {
+	err := r.subscribers.FindOne(ctx, bson.M{"channel_id": channelID, "user_id": userID},
+		options.FindOne().SetProjection(bson.M{"_id": 1})).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ListSubscribers returns up to limit subscriptions made after the
+// subscription after, oldest first; a zero after starts at the beginning
+func (r *ChannelRepository) ListSubscribers(ctx context.Context, channelID, after primitive.ObjectID, limit int64) ([]models.ChannelSubscriber, error) {
+	filter := bson.M{"channel_id": channelID}
+	if !after.IsZero() {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit)
+	cursor, err := r.subscribers.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	subscribers := []models.ChannelSubscriber{}
+	if err := cursor.All(ctx, &subscribers); err != nil {
+		return nil, err
+	}
+	return subscribers, nil
+}
+
+// ListSubscriptions returns the IDs of the channels a user subscribed to,
+// most recent first
+func (r *ChannelRepository) ListSubscriptions(ctx context.Context, userID primitive.ObjectID, limit int64) ([]primitive.ObjectID, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "subscribed_at", Value: -1}}).
+		SetLimit(limit).
+		SetProjection(bson.M{"channel_id": 1})
+	cursor, err := r.subscribers.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var subscriptions []models.ChannelSubscriber
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, len(subscriptions))
+	for i, subscription := range subscriptions {
+		ids[i] = subscription.ChannelID
+	}
+	return ids, nil
+}
+
+// CreatePost stores a post and bumps the channel's post count
+func (r *ChannelRepository) CreatePost(ctx context.Context, post *models.ChannelPost) error {
+	post.ID = primitive.NewObjectID()
+	post.CreatedAt = time.Now()
+	if _, err := r.posts.InsertOne(ctx, post); err != nil {
+		return err
+	}
+	_, err := r.channels.UpdateOne(ctx, bson.M{"_id": post.ChannelID}, bson.M{
+		"$inc": bson.M{"post_count": 1},
+		"$set": bson.M{"last_post_at": post.CreatedAt},
+	})
+	return err
+}
+
+func (r *ChannelRepository) GetPost(ctx context.Context, channelID, postID primitive.ObjectID) (*models.ChannelPost, error) {
+	var post models.ChannelPost
+	err := r.posts.FindOne(ctx, bson.M{"_id": postID, "channel_id": channelID}).Decode(&post)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrChannelPostNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// ListPosts returns up to limit posts older than the post before, newest
+// first; a zero before starts with the newest
+func (r *ChannelRepository) ListPosts(ctx context.Context, channelID, before primitive.ObjectID, limit int64) ([]models.ChannelPost, error) {
+	filter := bson.M{"channel_id": channelID}
+	if !before.IsZero() {
+		filter["_id"] = bson.M{"$lt": before}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(limit)
+	cursor, err := r.posts.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	posts := []models.ChannelPost{}
+	if err := cursor.All(ctx, &posts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+func (r *ChannelRepository) DeletePost(ctx context.Context, channelID, postID primitive.ObjectID) error {
+	res, err := r.posts.DeleteOne(ctx, bson.M{"_id": postID, "channel_id": channelID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrChannelPostNotFound
+	}
+	_, err = r.channels.UpdateOne(ctx, bson.M{"_id": channelID}, bson.M{"$inc": bson.M{"post_count": -1}})
+	return err
+}
+
+// AddViews adds new viewers to posts' view counts, by post ID
+func (r *ChannelRepository) AddViews(ctx context.Context, channelID primitive.ObjectID, views map[primitive.ObjectID]int64) error {
+	if len(views) == 0 {
+		return nil
+	}
+	writes := make([]mongo.WriteModel, 0, len(views))
+	for postID, n := range views {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": postID, "channel_id": channelID}).
+			SetUpdate(bson.M{"$inc": bson.M{"view_count": n}}))
+	}
+	_, err := r.posts.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
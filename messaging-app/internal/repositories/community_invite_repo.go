@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CommunityInviteRepository stores community invite links. Expired links are
+// dropped by a TTL index.
+type CommunityInviteRepository struct {
+	db *mongo.Database
+}
+
+func NewCommunityInviteRepository(db *mongo.Database) *CommunityInviteRepository {
+	_, err := db.Collection("community_invites").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "community_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		panic("Failed to create community invite indexes: " + err.Error())
+	}
+
+	return &CommunityInviteRepository{db: db}
+}
+
+func (r *CommunityInviteRepository) collection() *mongo.Collection {
+	return r.db.Collection("community_invites")
+}
+
+func (r *CommunityInviteRepository) Create(ctx context.Context, invite *models.CommunityInvite) error {
+	invite.ID = primitive.NewObjectID()
+	_, err := r.collection().InsertOne(ctx, invite)
+	return err
+}
+
+// GetByCode returns mongo.ErrNoDocuments for unknown or revoked codes
+func (r *CommunityInviteRepository) GetByCode(ctx context.Context, code string) (*models.CommunityInvite, error) {
+	var invite models.CommunityInvite
+	if err := r.collection().FindOne(ctx, bson.M{"code": code}).Decode(&invite); err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// ListByCommunity returns a community's invites, newest first
+func (r *CommunityInviteRepository) ListByCommunity(ctx context.Context, communityID primitive.ObjectID) ([]models.CommunityInvite, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection().Find(ctx, bson.M{"community_id": communityID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	invites := []models.CommunityInvite{}
+	if err := cursor.All(ctx, &invites); err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+// Delete revokes an invite and reports whether it existed
+func (r *CommunityInviteRepository) Delete(ctx context.Context, communityID primitive.ObjectID, code string) (bool, error) {
+	res, err := r.collection().DeleteOne(ctx, bson.M{"community_id": communityID, "code": code})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// Use counts one use of an invite that has neither expired nor run out of
+// uses. It returns mongo.ErrNoDocuments when the invite can't be used.
+func (r *CommunityInviteRepository) Use(ctx context.Context, code string, now time.Time) (*models.CommunityInvite, error) {
+	filter := bson.M{
+		"code": code,
+		"$and": []bson.M{
+			{"$or": []bson.M{
+				{"max_uses": 0},
+				{"$expr": bson.M{"$lt": []string{"$uses", "$max_uses"}}},
+			}},
+			{"$or": []bson.M{
+				{"expires_at": nil},
+				{"expires_at": bson.M{"$gt": now}},
+			}},
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var invite models.CommunityInvite
+	err := r.collection().FindOneAndUpdate(ctx, filter, bson.M{"$inc": bson.M{"uses": 1}}, opts).Decode(&invite)
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// Release gives back a use taken by Use when the join did not go through
+func (r *CommunityInviteRepository) Release(ctx context.Context, inviteID primitive.ObjectID) error {
+	_, err := r.collection().UpdateOne(ctx, bson.M{"_id": inviteID, "uses": bson.M{"$gt": 0}}, bson.M{"$inc": bson.M{"uses": -1}})
+	return err
+}
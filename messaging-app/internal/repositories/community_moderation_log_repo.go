@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CommunityModerationLogRepository stores the moderation actions community
+// admins took, one log per community
+type CommunityModerationLogRepository struct {
+	db *mongo.Database
+}
+
+func NewCommunityModerationLogRepository(db *mongo.Database) *CommunityModerationLogRepository {
+	_, err := db.Collection("community_moderation_log").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "community_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index(),
+	})
+	if err != nil {
+		panic("Failed to create community moderation log indexes: " + err.Error())
+	}
+
+	return &CommunityModerationLogRepository{db: db}
+}
+
+func (r *CommunityModerationLogRepository) collection() *mongo.Collection {
+	return r.db.Collection("community_moderation_log")
+}
+
+func (r *CommunityModerationLogRepository) Append(ctx context.Context, entry *models.CommunityModerationLogEntry) error {
+	entry.ID = primitive.NewObjectID()
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	_, err := r.collection().InsertOne(ctx, entry)
+	return err
+}
+
+// List pages through a community's log, newest first
+func (r *CommunityModerationLogRepository) List(ctx context.Context, communityID primitive.ObjectID, page, limit int64) ([]models.CommunityModerationLogEntry, int64, error) {
+	filter := bson.M{"community_id": communityID}
+	total, err := r.collection().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []models.CommunityModerationLogEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
@@ -100,17 +100,35 @@ func (r *CommunityRepository) List(ctx context.Context, limit, page int64) ([]mo
 	return communities, total, nil
 }
 
-// AddMember adds a member to the community
+// AddMember adds a member to the community and drops their join request,
+// if any. Adding a member twice leaves the member count alone.
 func (r *CommunityRepository) AddMember(ctx context.Context, communityID, userID primitive.ObjectID) error {
-	filter := bson.M{"_id": communityID}
+	filter := bson.M{"_id": communityID, "members": bson.M{"$ne": userID}}
 	update := bson.M{
 		"$addToSet": bson.M{"members": userID},
+		"$pull":     bson.M{"pending_members": userID},
 		"$inc":      bson.M{"stats.member_count": 1},
 	}
 	_, err := r.collection.UpdateOne(ctx, filter, update)
 	return err
 }
 
+// ApprovePendingMember turns a join request into a membership and reports
+// whether the user still had a request to approve
+func (r *CommunityRepository) ApprovePendingMember(ctx context.Context, communityID, userID primitive.ObjectID) (bool, error) {
+	filter := bson.M{"_id": communityID, "pending_members": userID, "members": bson.M{"$ne": userID}}
+	update := bson.M{
+		"$addToSet": bson.M{"members": userID},
+		"$pull":     bson.M{"pending_members": userID},
+		"$inc":      bson.M{"stats.member_count": 1},
+	}
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
 // RemoveMember removes a member from the community
 func (r *CommunityRepository) RemoveMember(ctx context.Context, communityID, userID primitive.ObjectID) error {
 	filter := bson.M{"_id": communityID}
@@ -130,14 +148,71 @@ func (r *CommunityRepository) AddPendingMember(ctx context.Context, communityID,
 	return err
 }
 
-// RemovePendingMember removes a user from pending members list
-func (r *CommunityRepository) RemovePendingMember(ctx context.Context, communityID, userID primitive.ObjectID) error {
-	filter := bson.M{"_id": communityID}
+// RemovePendingMember removes a user from pending members list and reports
+// whether they were on it
+func (r *CommunityRepository) RemovePendingMember(ctx context.Context, communityID, userID primitive.ObjectID) (bool, error) {
+	filter := bson.M{"_id": communityID, "pending_members": userID}
 	update := bson.M{"$pull": bson.M{"pending_members": userID}}
-	_, err := r.collection.UpdateOne(ctx, filter, update)
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// SetMute mutes a user until the given time, replacing an earlier mute
+func (r *CommunityRepository) SetMute(ctx context.Context, communityID primitive.ObjectID, mute models.CommunityMute) error {
+	filter := bson.M{"_id": communityID}
+	if _, err := r.collection.UpdateOne(ctx, filter, bson.M{"$pull": bson.M{"muted_members": bson.M{"user_id": mute.UserID}}}); err != nil {
+		return err
+	}
+	_, err := r.collection.UpdateOne(ctx, filter, bson.M{"$push": bson.M{"muted_members": mute}})
+	return err
+}
+
+// RemoveMute unmutes a user and reports whether they were muted
+func (r *CommunityRepository) RemoveMute(ctx context.Context, communityID, userID primitive.ObjectID) (bool, error) {
+	filter := bson.M{"_id": communityID, "muted_members.user_id": userID}
+	res, err := r.collection.UpdateOne(ctx, filter, bson.M{"$pull": bson.M{"muted_members": bson.M{"user_id": userID}}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// BanUser takes a user out of the community, its admins, pending requests
+// and mutes, and puts them on the ban list
+func (r *CommunityRepository) BanUser(ctx context.Context, communityID, userID primitive.ObjectID) error {
+	memberFilter := bson.M{"_id": communityID, "members": userID}
+	update := bson.M{
+		"$pull": bson.M{"members": userID, "admins": userID},
+		"$inc":  bson.M{"stats.member_count": -1},
+	}
+	if _, err := r.collection.UpdateOne(ctx, memberFilter, update); err != nil {
+		return err
+	}
+
+	update = bson.M{
+		"$addToSet": bson.M{"banned_users": userID},
+		"$pull": bson.M{
+			"pending_members": userID,
+			"muted_members":   bson.M{"user_id": userID},
+		},
+	}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": communityID}, update)
 	return err
 }
 
+// UnbanUser takes a user off the ban list and reports whether they were on it
+func (r *CommunityRepository) UnbanUser(ctx context.Context, communityID, userID primitive.ObjectID) (bool, error) {
+	filter := bson.M{"_id": communityID, "banned_users": userID}
+	res, err := r.collection.UpdateOne(ctx, filter, bson.M{"$pull": bson.M{"banned_users": userID}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
 // AddAdmin promotes a member to admin
 func (r *CommunityRepository) AddAdmin(ctx context.Context, communityID, userID primitive.ObjectID) error {
 	filter := bson.M{"_id": communityID}
@@ -331,3 +406,36 @@ func (r *CommunityRepository) GetPendingMembers(ctx context.Context, communityID
 	}
 	return users, int64(len(community.PendingMembers)), nil
 }
+
+// GetBannedUsers returns the users on the community's ban list
+func (r *CommunityRepository) GetBannedUsers(ctx context.Context, communityID primitive.ObjectID) ([]models.User, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "_id", Value: communityID}}}},
+		{{Key: "$project", Value: bson.D{{Key: "banned_users", Value: 1}, {Key: "_id", Value: 0}}}},
+		{{Key: "$unwind", Value: "$banned_users"}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "users"},
+			{Key: "localField", Value: "banned_users"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "user"},
+		}}},
+		{{Key: "$unwind", Value: "$user"}},
+		{{Key: "$replaceRoot", Value: bson.D{{Key: "newRoot", Value: "$user"}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	if users == nil {
+		users = []models.User{}
+	}
+	return users, nil
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ConversationExportRepository stores conversation export jobs. Jobs queue
+// as pending and are claimed one at a time by the export worker.
+type ConversationExportRepository struct {
+	db *mongo.Database
+}
+
+func NewConversationExportRepository(db *mongo.Database) *ConversationExportRepository {
+	_, err := db.Collection("conversation_exports").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetSparse(true),
+		},
+	})
+	if err != nil {
+		panic("Failed to create conversation export indexes: " + err.Error())
+	}
+
+	return &ConversationExportRepository{db: db}
+}
+
+func (r *ConversationExportRepository) collection() *mongo.Collection {
+	return r.db.Collection("conversation_exports")
+}
+
+func (r *ConversationExportRepository) Create(ctx context.Context, export *models.ConversationExport) error {
+	export.ID = primitive.NewObjectID()
+	_, err := r.collection().InsertOne(ctx, export)
+	return err
+}
+
+// GetByID returns mongo.ErrNoDocuments when there is no such export
+func (r *ConversationExportRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.ConversationExport, error) {
+	var export models.ConversationExport
+	if err := r.collection().FindOne(ctx, bson.M{"_id": id}).Decode(&export); err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// ListByUser returns the user's exports, newest first
+func (r *ConversationExportRepository) ListByUser(ctx context.Context, userID primitive.ObjectID, limit int64) ([]models.ConversationExport, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+	return r.find(ctx, bson.M{"user_id": userID}, opts)
+}
+
+// CountActive counts the user's exports that have not finished yet
+func (r *ConversationExportRepository) CountActive(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	return r.collection().CountDocuments(ctx, bson.M{
+		"user_id": userID,
+		"status":  bson.M{"$in": []models.ConversationExportStatus{models.ConversationExportPending, models.ConversationExportProcessing}},
+	})
+}
+
+// ClaimPending marks the oldest pending export as processing and returns it,
+// or nil when nothing is pending. Jobs left processing since before
+// staleBefore, by a worker that died, are claimed again.
+func (r *ConversationExportRepository) ClaimPending(ctx context.Context, staleBefore time.Time) (*models.ConversationExport, error) {
+	now := time.Now()
+	filter := bson.M{"$or": []bson.M{
+		{"status": models.ConversationExportPending},
+		{"status": models.ConversationExportProcessing, "updated_at": bson.M{"$lt": staleBefore}},
+	}}
+	update := bson.M{"$set": bson.M{"status": models.ConversationExportProcessing, "updated_at": now}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var export models.ConversationExport
+	err := r.collection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&export)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// MarkReady records the file a finished export produced
+func (r *ConversationExportRepository) MarkReady(ctx context.Context, id primitive.ObjectID, fileKey string, messageCount int, completedAt, expiresAt time.Time) error {
+	_, err := r.collection().UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"status":        models.ConversationExportReady,
+			"file_key":      fileKey,
+			"message_count": messageCount,
+			"completed_at":  completedAt,
+			"expires_at":    expiresAt,
+			"updated_at":    completedAt,
+		}},
+	)
+	return err
+}
+
+func (r *ConversationExportRepository) MarkFailed(ctx context.Context, id primitive.ObjectID, reason string) error {
+	now := time.Now()
+	_, err := r.collection().UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"status":       models.ConversationExportFailed,
+			"error":        reason,
+			"completed_at": now,
+			"updated_at":   now,
+		}},
+	)
+	return err
+}
+
+// ListExpired returns exports whose download expired before the given time
+func (r *ConversationExportRepository) ListExpired(ctx context.Context, before time.Time) ([]models.ConversationExport, error) {
+	return r.find(ctx, bson.M{"expires_at": bson.M{"$lt": before}}, options.Find())
+}
+
+func (r *ConversationExportRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection().DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (r *ConversationExportRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.ConversationExport, error) {
+	cursor, err := r.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	exports := []models.ConversationExport{}
+	if err := cursor.All(ctx, &exports); err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gocql/gocql"
+)
+
+// ErrPinnedConversationLimit is returned when the user already has the most
+// pinned conversations they may have
+var ErrPinnedConversationLimit = errors.New("pinned conversation limit reached")
+
+// UpdateConversationFlags sets the flags present in req, each in its own
+// column so concurrent changes to different flags do not overwrite each
+// other. The change is logged for delta sync.
+func (r *MessageCassandraRepository) UpdateConversationFlags(ctx context.Context, userID, conversationID string, req models.UpdateConversationFlagsRequest, updatedAt time.Time) error {
+	if r.client == nil || r.client.Session == nil {
+		return fmt.Errorf("cassandra client not initialized")
+	}
+
+	columns := []string{"updated_at = ?"}
+	values := []interface{}{updatedAt}
+	for _, flag := range []struct {
+		column string
+		value  *bool
+	}{
+		{"pinned", req.Pinned},
+		{"archived", req.Archived},
+		{"muted", req.Muted},
+	} {
+		if flag.value != nil {
+			columns = append(columns, flag.column+" = ?")
+			values = append(values, *flag.value)
+		}
+	}
+	values = append(values, userID, conversationID)
+
+	batch := r.client.Session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`UPDATE conversation_flags SET `+strings.Join(columns, ", ")+` WHERE user_id = ? AND conversation_id = ?`, values...)
+	AppendInboxChange(batch, userID, conversationID)
+	return r.client.Session.ExecuteBatch(batch)
+}
+
+// PinConversation pins a conversation for a user, who may have at most limit
+// pinned conversations.
+//
+// The pin is written first and the user's pins counted after, so concurrent
+// pins cannot both slip under the limit: a pin that finds the user over the
+// limit is rolled back and ErrPinnedConversationLimit returned. Racing pins
+// may then all lose, but the limit is never exceeded.
+func (r *MessageCassandraRepository) PinConversation(ctx context.Context, userID, conversationID string, updatedAt time.Time, limit int) error {
+	pin, unpin := true, false
+	if err := r.UpdateConversationFlags(ctx, userID, conversationID, models.UpdateConversationFlagsRequest{Pinned: &pin}, updatedAt); err != nil {
+		return err
+	}
+
+	all, err := r.ListConversationFlags(ctx, userID)
+	if err == nil {
+		pinned := 0
+		for _, flags := range all {
+			if flags.Pinned {
+				pinned++
+			}
+		}
+		if pinned <= limit {
+			return nil
+		}
+	}
+	if rollbackErr := r.UpdateConversationFlags(ctx, userID, conversationID, models.UpdateConversationFlagsRequest{Pinned: &unpin}, time.Now()); rollbackErr != nil {
+		return fmt.Errorf("failed to roll back pin over the limit: %w", rollbackErr)
+	}
+	if err != nil {
+		return err
+	}
+	return ErrPinnedConversationLimit
+}
+
+// GetConversationFlags returns a user's flags for a conversation; all unset
+// when there is no row
+func (r *MessageCassandraRepository) GetConversationFlags(ctx context.Context, userID, conversationID string) (models.ConversationFlags, error) {
+	flags := models.ConversationFlags{ConversationID: conversationID}
+	if r.client == nil || r.client.Session == nil {
+		return flags, fmt.Errorf("cassandra client not initialized")
+	}
+
+	err := r.client.Session.Query(`SELECT pinned, archived, muted, updated_at FROM conversation_flags WHERE user_id = ? AND conversation_id = ?`, userID, conversationID).
+		WithContext(ctx).Scan(&flags.Pinned, &flags.Archived, &flags.Muted, &flags.UpdatedAt)
+	if err != nil && err != gocql.ErrNotFound {
+		return flags, err
+	}
+	return flags, nil
+}
+
+// ListConversationFlags returns the user's flagged conversations by
+// Cassandra conversation ID
+func (r *MessageCassandraRepository) ListConversationFlags(ctx context.Context, userID string) (map[string]models.ConversationFlags, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+
+	iter := r.client.Session.Query(`SELECT conversation_id, pinned, archived, muted, updated_at FROM conversation_flags WHERE user_id = ?`, userID).
+		WithContext(ctx).Iter()
+	flagMap := make(map[string]models.ConversationFlags)
+	var flags models.ConversationFlags
+	for iter.Scan(&flags.ConversationID, &flags.Pinned, &flags.Archived, &flags.Muted, &flags.UpdatedAt) {
+		flagMap[flags.ConversationID] = flags
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return flagMap, nil
+}
+
+// inboxFlags is ListConversationFlags for inbox reads, which go on without
+// flags rather than fail
+func (r *MessageCassandraRepository) inboxFlags(userID string) map[string]models.ConversationFlags {
+	flagMap, err := r.ListConversationFlags(context.Background(), userID)
+	if err != nil {
+		log.Printf("Error fetching conversation flags: %v", err)
+	}
+	return flagMap
+}
+
+// DeleteUserConversationFlags removes all of a user's conversation flags
+func (r *MessageCassandraRepository) DeleteUserConversationFlags(ctx context.Context, userID string) error {
+	if r.client == nil || r.client.Session == nil {
+		return fmt.Errorf("cassandra client not initialized")
+	}
+
+	return r.client.Session.Query(`DELETE FROM conversation_flags WHERE user_id = ?`, userID).WithContext(ctx).Exec()
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gocql/gocql"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetConversationSettings returns a conversation's settings; all unset when
+// there is no row
+func (r *MessageCassandraRepository) GetConversationSettings(ctx context.Context, conversationID string) (*models.ConversationSettings, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+
+	settings := &models.ConversationSettings{ConversationID: conversationID}
+	var updatedAt time.Time
+	err := r.client.Session.Query(`SELECT nicknames, quick_reaction, theme, updated_by, updated_at FROM conversation_settings WHERE conversation_id = ?`, conversationID).
+		WithContext(ctx).Scan(&settings.Nicknames, &settings.QuickReaction, &settings.Theme, &settings.UpdatedBy, &updatedAt)
+	if err == gocql.ErrNotFound {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !updatedAt.IsZero() {
+		settings.UpdatedAt = &updatedAt
+	}
+	return settings, nil
+}
+
+// UpdateConversationSettings applies a normalized update to a conversation's
+// settings. Empty nicknames are removed; omitted fields are left as they are.
+func (r *MessageCassandraRepository) UpdateConversationSettings(ctx context.Context, conversationID string, req models.UpdateConversationSettingsRequest, updatedBy string, at time.Time) error {
+	if r.client == nil || r.client.Session == nil {
+		return fmt.Errorf("cassandra client not initialized")
+	}
+
+	// All statements hit one partition, so the batch applies atomically
+	batch := r.client.Session.NewBatch(gocql.UnloggedBatch).WithContext(ctx)
+	for userID, nickname := range req.Nicknames {
+		if nickname == "" {
+			batch.Query(`DELETE nicknames[?] FROM conversation_settings WHERE conversation_id = ?`, userID, conversationID)
+		} else {
+			batch.Query(`UPDATE conversation_settings SET nicknames[?] = ? WHERE conversation_id = ?`, userID, nickname, conversationID)
+		}
+	}
+	if req.QuickReaction != nil {
+		batch.Query(`UPDATE conversation_settings SET quick_reaction = ? WHERE conversation_id = ?`, *req.QuickReaction, conversationID)
+	}
+	if req.Theme != nil {
+		batch.Query(`UPDATE conversation_settings SET theme = ? WHERE conversation_id = ?`, *req.Theme, conversationID)
+	}
+	batch.Query(`UPDATE conversation_settings SET updated_by = ?, updated_at = ? WHERE conversation_id = ?`, updatedBy, at, conversationID)
+	return r.client.Session.ExecuteBatch(batch)
+}
+
+// RecordInboxChanges logs a change of the conversation in each user's inbox
+// for delta sync, for changes that do not go through the inbox rows
+func (r *MessageCassandraRepository) RecordInboxChanges(userIDs []string, conversationID string) {
+	for _, userID := range userIDs {
+		if err := r.recordInboxChange(userID, conversationID); err != nil {
+			log.Printf("Error logging inbox change for %s: %v", userID, err)
+		}
+	}
+}
+
+// applyConversationSettings adds the settings of each conversation to the
+// user's inbox summaries. Inbox reads go on without settings rather than fail.
+func (r *MessageCassandraRepository) applyConversationSettings(userID primitive.ObjectID, summaries []models.ConversationSummary) {
+	if len(summaries) == 0 {
+		return
+	}
+	convIDs := make([]string, len(summaries))
+	for i, summary := range summaries {
+		convIDs[i] = inboxConversationID(userID, summary.ID)
+	}
+
+	iter := r.client.Session.Query(`SELECT conversation_id, nicknames, quick_reaction, theme FROM conversation_settings WHERE conversation_id IN ?`, convIDs).Iter()
+	settingsMap := make(map[string]models.ConversationSettings)
+	var settings models.ConversationSettings
+	for iter.Scan(&settings.ConversationID, &settings.Nicknames, &settings.QuickReaction, &settings.Theme) {
+		settingsMap[settings.ConversationID] = settings
+		settings = models.ConversationSettings{}
+	}
+	if err := iter.Close(); err != nil {
+		log.Printf("Error fetching conversation settings: %v", err)
+		return
+	}
+
+	for i := range summaries {
+		settings, ok := settingsMap[convIDs[i]]
+		if !ok {
+			continue
+		}
+		summary := &summaries[i]
+		summary.QuickReaction = settings.QuickReaction
+		summary.Theme = settings.Theme
+		if !summary.IsGroup {
+			summary.Nickname = settings.Nicknames[strings.TrimPrefix(summary.ID, "user-")]
+		}
+		if nickname := settings.Nicknames[summary.LastMessageSenderID.Hex()]; nickname != "" {
+			summary.LastMessageSenderName = nickname
+		}
+	}
+}
+
+// inboxConversationID maps a frontend conversation ID of the user's inbox
+// back to its Cassandra conversation ID
+func inboxConversationID(userID primitive.ObjectID, frontendID string) string {
+	if partner, ok := strings.CutPrefix(frontendID, "user-"); ok {
+		if partnerID, err := primitive.ObjectIDFromHex(partner); err == nil {
+			return getConversationID(userID, partnerID, primitive.NilObjectID)
+		}
+	}
+	if group, ok := strings.CutPrefix(frontendID, "group-"); ok {
+		return "group_" + group
+	}
+	return frontendID
+}
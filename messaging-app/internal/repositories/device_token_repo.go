@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DeviceTokenRepository stores the push tokens of users' devices
+type DeviceTokenRepository struct {
+	db *mongo.Database
+}
+
+func NewDeviceTokenRepository(db *mongo.Database) *DeviceTokenRepository {
+	_, err := db.Collection("device_tokens").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "token", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index(),
+		},
+	})
+	if err != nil {
+		panic("Failed to create device token indexes: " + err.Error())
+	}
+
+	return &DeviceTokenRepository{db: db}
+}
+
+func (r *DeviceTokenRepository) collection() *mongo.Collection {
+	return r.db.Collection("device_tokens")
+}
+
+// Register stores a token for userID, taking it over from any other user who
+// registered it before
+func (r *DeviceTokenRepository) Register(ctx context.Context, userID primitive.ObjectID, token string, platform models.DevicePlatform) (*models.DeviceToken, error) {
+	now := time.Now()
+	var device models.DeviceToken
+	err := r.collection().FindOneAndUpdate(ctx,
+		bson.M{"token": token},
+		bson.M{
+			"$set":         bson.M{"user_id": userID, "platform": platform, "updated_at": now},
+			"$setOnInsert": bson.M{"created_at": now},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&device)
+	if err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
+
+// ListByUser returns the user's registered devices
+func (r *DeviceTokenRepository) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.DeviceToken, error) {
+	cur, err := r.collection().Find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	devices := []models.DeviceToken{}
+	if err := cur.All(ctx, &devices); err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
+
+// Unregister removes one of the user's tokens, returning mongo.ErrNoDocuments
+// when the user has no such token
+func (r *DeviceTokenRepository) Unregister(ctx context.Context, userID primitive.ObjectID, token string) error {
+	res, err := r.collection().DeleteOne(ctx, bson.M{"user_id": userID, "token": token})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteToken removes a token a push provider reported as no longer valid
+func (r *DeviceTokenRepository) DeleteToken(ctx context.Context, token string) error {
+	_, err := r.collection().DeleteOne(ctx, bson.M{"token": token})
+	return err
+}
+
+// DeleteByUser removes every token the user registered
+func (r *DeviceTokenRepository) DeleteByUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	res, err := r.collection().DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -20,36 +21,10 @@ type FeedRepository struct {
 	reactionsCollection  *mongo.Collection
 	albumsCollection     *mongo.Collection
 	albumMediaCollection *mongo.Collection
+	pollVotesCollection  *mongo.Collection
 }
 
 func NewFeedRepository(db *mongo.Database) *FeedRepository {
-	// ... (indexes for other collections)
-
-	// albums indexes
-	_, err := db.Collection("albums").Indexes().CreateMany(
-		context.Background(),
-		[]mongo.IndexModel{
-			{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index()},
-			{Keys: bson.D{{Key: "type", Value: 1}}, Options: options.Index()},
-			{Keys: bson.D{{Key: "created_at", Value: -1}}, Options: options.Index()},
-		},
-	)
-	if err != nil {
-		panic("Failed to create album indexes: " + err.Error())
-	}
-
-	// album_media indexes
-	_, err = db.Collection("album_media").Indexes().CreateMany(
-		context.Background(),
-		[]mongo.IndexModel{
-			{Keys: bson.D{{Key: "album_id", Value: 1}}, Options: options.Index()},
-			{Keys: bson.D{{Key: "created_at", Value: -1}}, Options: options.Index()},
-		},
-	)
-	if err != nil {
-		panic("Failed to create album_media indexes: " + err.Error())
-	}
-
 	return &FeedRepository{
 		postsCollection:      db.Collection("posts"),
 		commentsCollection:   db.Collection("comments"),
@@ -57,9 +32,96 @@ func NewFeedRepository(db *mongo.Database) *FeedRepository {
 		reactionsCollection:  db.Collection("reactions"),
 		albumsCollection:     db.Collection("albums"),
 		albumMediaCollection: db.Collection("album_media"),
+		pollVotesCollection:  db.Collection("poll_votes"),
 	}
 }
 
+// feedIndexes lists the indexes each feed collection needs for its hot
+// query shapes. The unique reaction index is built by ensureUniqueReactions,
+// which may have to remove duplicates first.
+func (r *FeedRepository) feedIndexes() []struct {
+	collection *mongo.Collection
+	models     []mongo.IndexModel
+} {
+	deleted := bson.M{"status": models.PostStatusDeleted}
+	return []struct {
+		collection *mongo.Collection
+		models     []mongo.IndexModel
+	}{
+		{r.postsCollection, []mongo.IndexModel{
+			// Profile timelines, newest first
+			{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+			// Community feeds by status, newest first
+			{Keys: bson.D{{Key: "community_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+			// Hashtag feeds
+			{Keys: bson.D{{Key: "hashtags", Value: 1}, {Key: "created_at", Value: -1}}},
+			// Post search, with hashtags ranked above body text
+			{
+				Keys:    bson.D{{Key: "content", Value: "text"}, {Key: "hashtags", Value: "text"}},
+				Options: options.Index().SetName("post_text").SetWeights(bson.M{"content": 1, "hashtags": 3}),
+			},
+			// Trash lookups: per-user listing and the purge worker's expiry scan
+			{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "deleted_at", Value: -1}}, Options: options.Index().SetPartialFilterExpression(deleted)},
+			{Keys: bson.D{{Key: "deleted_at", Value: 1}}, Options: options.Index().SetPartialFilterExpression(deleted)},
+			// Community approval queues, oldest first
+			{
+				Keys:    bson.D{{Key: "community_id", Value: 1}, {Key: "created_at", Value: 1}},
+				Options: options.Index().SetPartialFilterExpression(bson.M{"status": models.PostStatusPending}),
+			},
+		}},
+		{r.commentsCollection, []mongo.IndexModel{
+			// A post's comments by age
+			{Keys: bson.D{{Key: "post_id", Value: 1}, {Key: "created_at", Value: 1}}},
+			// Top comments, and the post author's replies looked up by relevance ranking
+			{Keys: bson.D{{Key: "post_id", Value: 1}, {Key: "total_reactions", Value: -1}, {Key: "created_at", Value: -1}}},
+		}},
+		{r.repliesCollection, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "comment_id", Value: 1}, {Key: "user_id", Value: 1}}},
+			// Threads: a comment's top-level replies and each reply's own replies, oldest first
+			{Keys: bson.D{{Key: "comment_id", Value: 1}, {Key: "parent_reply_id", Value: 1}, {Key: "created_at", Value: 1}}},
+			// A reply's direct replies, counted by the counter reconciler
+			{
+				Keys:    bson.D{{Key: "parent_reply_id", Value: 1}},
+				Options: options.Index().SetPartialFilterExpression(bson.M{"parent_reply_id": bson.M{"$exists": true}}),
+			},
+		}},
+		{r.reactionsCollection, []mongo.IndexModel{
+			// A target's reactions, counted, listed and checked for the viewer's own
+			{Keys: bson.D{{Key: "target_id", Value: 1}, {Key: "target_type", Value: 1}, {Key: "user_id", Value: 1}}},
+		}},
+		{r.pollVotesCollection, []mongo.IndexModel{
+			// One vote per user and poll
+			{Keys: bson.D{{Key: "post_id", Value: 1}, {Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		}},
+		{r.albumsCollection, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "user_id", Value: 1}}},
+			{Keys: bson.D{{Key: "type", Value: 1}}},
+			{Keys: bson.D{{Key: "created_at", Value: -1}}},
+		}},
+		{r.albumMediaCollection, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "album_id", Value: 1}}},
+			{Keys: bson.D{{Key: "created_at", Value: -1}}},
+		}},
+	}
+}
+
+// EnsureIndexes creates the feed collections' indexes. A collection whose
+// indexes fail does not stop the others; every failure is returned joined,
+// so startup can log it and keep serving on the indexes that exist.
+func (r *FeedRepository) EnsureIndexes(ctx context.Context) error {
+	var errs []error
+	for _, set := range r.feedIndexes() {
+		if _, err := set.collection.Indexes().CreateMany(ctx, set.models); err != nil {
+			errs = append(errs, fmt.Errorf("%s indexes: %w", set.collection.Name(), err))
+		}
+	}
+	// One reaction per user and target
+	if err := r.ensureUniqueReactions(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("%s unique index: %w", r.reactionsCollection.Name(), err))
+	}
+	return errors.Join(errs...)
+}
+
 // ... (Posts methods)
 
 // ----------------------------- Albums ----------------_____________
@@ -346,6 +408,23 @@ func (r *FeedRepository) UpdatePost(ctx context.Context, postID primitive.Object
 	return &updatedPost, nil
 }
 
+// SetLinkPreview stores the preview of a post's first link, provided the post
+// still reads content. It returns mongo.ErrNoDocuments when the post was
+// edited or deleted in the meantime.
+func (r *FeedRepository) SetLinkPreview(ctx context.Context, postID primitive.ObjectID, content string, preview *models.LinkPreview) (*models.Post, error) {
+	res := r.postsCollection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": postID, "content": content},
+		bson.M{"$set": bson.M{"link_preview": preview}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+	var updatedPost models.Post
+	if err := res.Decode(&updatedPost); err != nil {
+		return nil, err
+	}
+	return &updatedPost, nil
+}
+
 func (r *FeedRepository) DeletePost(ctx context.Context, userID, postID primitive.ObjectID) error {
 	res, err := r.postsCollection.DeleteOne(ctx, bson.M{"_id": postID, "user_id": userID})
 	if err != nil {
@@ -357,6 +436,209 @@ func (r *FeedRepository) DeletePost(ctx context.Context, userID, postID primitiv
 	return nil
 }
 
+// TrashPost moves a post to the trash, remembering its status for a restore.
+// It returns mongo.ErrNoDocuments unless userID owns a post that is not
+// already in the trash.
+func (r *FeedRepository) TrashPost(ctx context.Context, userID, postID primitive.ObjectID, deletedAt time.Time) error {
+	res, err := r.postsCollection.UpdateOne(ctx,
+		bson.M{"_id": postID, "user_id": userID, "status": bson.M{"$ne": models.PostStatusDeleted}},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				"status_before_delete": "$status",
+				"status":               models.PostStatusDeleted,
+				"deleted_at":           deletedAt,
+			}}},
+			{{Key: "$unset", Value: "pinned_at"}},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// PinPost pins a post to the top of the feed it belongs to, replacing that
+// feed's previous pin. Community posts pin to their community, other posts to
+// their author's profile.
+func (r *FeedRepository) PinPost(ctx context.Context, post *models.Post, pinnedAt time.Time) error {
+	scope := bson.M{"user_id": post.UserID, "community_id": bson.M{"$exists": false}}
+	if post.CommunityID != nil {
+		scope = bson.M{"community_id": *post.CommunityID}
+	}
+	scope["_id"] = bson.M{"$ne": post.ID}
+	scope["pinned_at"] = bson.M{"$exists": true}
+	if _, err := r.postsCollection.UpdateMany(ctx, scope, bson.M{"$unset": bson.M{"pinned_at": ""}}); err != nil {
+		return err
+	}
+
+	res, err := r.postsCollection.UpdateOne(ctx,
+		bson.M{"_id": post.ID, "status": bson.M{"$ne": models.PostStatusDeleted}},
+		bson.M{"$set": bson.M{"pinned_at": pinnedAt}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UnpinPost clears a post's pin, reporting whether it was pinned
+func (r *FeedRepository) UnpinPost(ctx context.Context, postID primitive.ObjectID) (bool, error) {
+	res, err := r.postsCollection.UpdateOne(ctx,
+		bson.M{"_id": postID, "pinned_at": bson.M{"$exists": true}},
+		bson.M{"$unset": bson.M{"pinned_at": ""}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// SettlePendingPost applies an approval decision to a post, provided it is
+// still pending in the community. It returns mongo.ErrNoDocuments otherwise.
+func (r *FeedRepository) SettlePendingPost(ctx context.Context, communityID, postID primitive.ObjectID, update bson.M) (*models.Post, error) {
+	var post models.Post
+	err := r.postsCollection.FindOneAndUpdate(ctx,
+		bson.M{"_id": postID, "community_id": communityID, "status": models.PostStatusPending},
+		bson.M{"$set": update},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&post)
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// RestorePost takes a post owned by userID out of the trash and returns it
+func (r *FeedRepository) RestorePost(ctx context.Context, userID, postID primitive.ObjectID) (*models.Post, error) {
+	var post models.Post
+	err := r.postsCollection.FindOneAndUpdate(ctx,
+		bson.M{"_id": postID, "user_id": userID, "status": models.PostStatusDeleted},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				"status":     bson.M{"$ifNull": bson.A{"$status_before_delete", models.PostStatusActive}},
+				"updated_at": time.Now(),
+			}}},
+			{{Key: "$unset", Value: bson.A{"deleted_at", "status_before_delete"}}},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&post)
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// ListTrashedPosts returns the user's posts in the trash, most recently deleted first
+func (r *FeedRepository) ListTrashedPosts(ctx context.Context, userID primitive.ObjectID, skip, limit int64) ([]models.Post, int64, error) {
+	filter := bson.M{"user_id": userID, "status": models.PostStatusDeleted}
+	total, err := r.postsCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	posts, err := r.ListPosts(ctx, filter, options.Find().
+		SetSort(bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit))
+	if err != nil {
+		return nil, 0, err
+	}
+	return posts, total, nil
+}
+
+// ListExpiredTrash returns up to limit trashed posts deleted before cutoff
+func (r *FeedRepository) ListExpiredTrash(ctx context.Context, cutoff time.Time, limit int64) ([]models.Post, error) {
+	cur, err := r.postsCollection.Find(ctx,
+		bson.M{"status": models.PostStatusDeleted, "deleted_at": bson.M{"$lt": cutoff}},
+		options.Find().SetSort(bson.D{{Key: "deleted_at", Value: 1}}).SetLimit(limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var posts []models.Post
+	if err := cur.All(ctx, &posts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// AdjustPostShareCount adds delta to the share counter of each post
+func (r *FeedRepository) AdjustPostShareCount(ctx context.Context, postIDs []primitive.ObjectID, delta int64) error {
+	_, err := r.postsCollection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": postIDs}},
+		bson.M{"$inc": bson.M{"total_shares": delta}},
+	)
+	return err
+}
+
+// VotePoll records userID's vote for optionID on a poll post and adjusts the
+// option counters to match, moving the vote if the user had picked another
+// option. It returns the post with its updated counts.
+func (r *FeedRepository) VotePoll(ctx context.Context, postID, userID, optionID primitive.ObjectID) (*models.Post, error) {
+	now := time.Now()
+	// The before-image tells exactly which counter this vote moves, so
+	// concurrent votes by the same user cannot double count
+	var previous models.PollVote
+	err := r.pollVotesCollection.FindOneAndUpdate(ctx,
+		bson.M{"post_id": postID, "user_id": userID},
+		bson.M{
+			"$set":         bson.M{"option_id": optionID, "updated_at": now},
+			"$setOnInsert": bson.M{"created_at": now},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before),
+	).Decode(&previous)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		previous.OptionID = primitive.NilObjectID
+	case err != nil:
+		return nil, err
+	case previous.OptionID == optionID:
+		return r.GetPostByID(ctx, postID)
+	}
+
+	inc := bson.M{"poll_options.$[picked].vote_count": 1}
+	arrayFilters := bson.A{bson.M{"picked.id": optionID}}
+	if !previous.OptionID.IsZero() {
+		inc["poll_options.$[dropped].vote_count"] = -1
+		arrayFilters = append(arrayFilters, bson.M{"dropped.id": previous.OptionID})
+	}
+
+	var post models.Post
+	err = r.postsCollection.FindOneAndUpdate(ctx,
+		bson.M{"_id": postID},
+		bson.M{"$inc": inc},
+		options.FindOneAndUpdate().
+			SetArrayFilters(options.ArrayFilters{Filters: arrayFilters}).
+			SetReturnDocument(options.After),
+	).Decode(&post)
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// GetPollVote returns the option userID voted for on a poll post
+func (r *FeedRepository) GetPollVote(ctx context.Context, postID, userID primitive.ObjectID) (*models.PollVote, error) {
+	var vote models.PollVote
+	err := r.pollVotesCollection.FindOne(ctx, bson.M{"post_id": postID, "user_id": userID}).Decode(&vote)
+	if err != nil {
+		return nil, err
+	}
+	return &vote, nil
+}
+
+func (r *FeedRepository) DeletePollVotesByPostID(ctx context.Context, postID primitive.ObjectID) error {
+	_, err := r.pollVotesCollection.DeleteMany(ctx, bson.M{"post_id": postID})
+	return err
+}
+
 func (r *FeedRepository) DeleteCommentsByPostID(ctx context.Context, postID primitive.ObjectID) error {
 	_, err := r.commentsCollection.DeleteMany(ctx, bson.M{"post_id": postID})
 	return err
@@ -581,11 +863,17 @@ func (r *FeedRepository) CreateReply(ctx context.Context, reply *models.Reply) (
 	_, err = r.commentsCollection.UpdateOne(
 		ctx,
 		bson.M{"_id": reply.CommentID},
-		bson.M{"$push": bson.M{"replyids": reply.ID}},
+		bson.M{"$push": bson.M{"replyids": reply.ID}, "$inc": bson.M{"reply_count": 1}},
 	)
 	if err != nil {
 		return nil, err
 	}
+	if reply.ParentReplyID != nil {
+		_, err = r.repliesCollection.UpdateOne(ctx, bson.M{"_id": *reply.ParentReplyID}, bson.M{"$inc": bson.M{"reply_count": 1}})
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return reply, nil
 }
@@ -630,17 +918,75 @@ func (r *FeedRepository) UpdateReply(ctx context.Context, replyID primitive.Obje
 	return &updated, nil
 }
 
+// DeleteReply deletes a reply along with every reply nested under it, and
+// takes them off the comment's and the parent reply's counters
 func (r *FeedRepository) DeleteReply(ctx context.Context, commentID, replyID primitive.ObjectID) error {
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
-	if _, err := r.commentsCollection.UpdateOne(ctx, bson.M{"_id": commentID}, bson.M{"$pull": bson.M{"replyids": replyID}}); err != nil {
+	var reply models.Reply
+	if err := r.repliesCollection.FindOne(ctx, bson.M{"_id": replyID, "comment_id": commentID}).Decode(&reply); err != nil {
+		return err
+	}
+	ids, err := r.replySubtreeIDs(ctx, commentID, replyID)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.repliesCollection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
 		return err
 	}
-	_, err := r.repliesCollection.DeleteOne(ctx, bson.M{"_id": replyID})
+	_, err = r.commentsCollection.UpdateOne(ctx,
+		bson.M{"_id": commentID},
+		bson.M{"$pull": bson.M{"replyids": bson.M{"$in": ids}}, "$inc": bson.M{"reply_count": -res.DeletedCount}},
+	)
+	if err != nil {
+		return err
+	}
+	if reply.ParentReplyID != nil {
+		_, err = r.repliesCollection.UpdateOne(ctx, bson.M{"_id": *reply.ParentReplyID}, bson.M{"$inc": bson.M{"reply_count": -1}})
+	}
 	return err
 }
 
+// replySubtreeIDs returns replyID and the IDs of all replies nested under it
+func (r *FeedRepository) replySubtreeIDs(ctx context.Context, commentID, replyID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.M{"_id": replyID}}},
+		bson.D{{Key: "$graphLookup", Value: bson.M{
+			"from":                    "replies",
+			"startWith":               "$_id",
+			"connectFromField":        "_id",
+			"connectToField":          "parent_reply_id",
+			"as":                      "descendants",
+			"restrictSearchWithMatch": bson.M{"comment_id": commentID},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{"descendants._id": 1}}},
+	}
+	cur, err := r.repliesCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	ids := []primitive.ObjectID{replyID}
+	if cur.Next(ctx) {
+		var doc struct {
+			Descendants []struct {
+				ID primitive.ObjectID `bson:"_id"`
+			} `bson:"descendants"`
+		}
+		if err := cur.Decode(&doc); err != nil {
+			return nil, err
+		}
+		for _, d := range doc.Descendants {
+			ids = append(ids, d.ID)
+		}
+	}
+	return ids, cur.Err()
+}
+
 // --------------------------- Reactions ---------------------------
 
 func (r *FeedRepository) ListReactions(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.Reaction, error) {
@@ -677,18 +1023,135 @@ func (r *FeedRepository) ListReactions(ctx context.Context, filter bson.M, opts
 	return reactions, nil
 }
 
-func (r *FeedRepository) CreateReaction(ctx context.Context, reaction *models.Reaction) (*models.Reaction, error) {
+var reactionKeyIndex = mongo.IndexModel{
+	Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "target_id", Value: 1}, {Key: "target_type", Value: 1}},
+	Options: options.Index().SetUnique(true),
+}
+
+// ensureUniqueReactions creates the one-reaction-per-user index. Databases
+// that already hold duplicates keep each user's newest reaction per target
+// and have those targets recounted before the index is built.
+func (r *FeedRepository) ensureUniqueReactions(ctx context.Context) error {
+	reactions := r.reactionsCollection
+	_, err := reactions.Indexes().CreateOne(ctx, reactionKeyIndex)
+	if err == nil || !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+
+	cur, err := reactions.Aggregate(ctx, mongo.Pipeline{
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"user_id": "$user_id", "target_id": "$target_id", "target_type": "$target_type"},
+			"ids":   bson.M{"$push": "$_id"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		bson.D{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var group struct {
+			Key struct {
+				TargetID   primitive.ObjectID `bson:"target_id"`
+				TargetType string             `bson:"target_type"`
+			} `bson:"_id"`
+			IDs []primitive.ObjectID `bson:"ids"`
+		}
+		if err := cur.Decode(&group); err != nil {
+			return err
+		}
+		if _, err := reactions.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": group.IDs[1:]}}); err != nil {
+			return err
+		}
+		if err := r.recountReactions(ctx, group.Key.TargetID, group.Key.TargetType); err != nil {
+			return err
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return err
+	}
+
+	_, err = reactions.Indexes().CreateOne(ctx, reactionKeyIndex)
+	return err
+}
+
+// ToggleReaction applies a user's reaction to a target: it is added if the
+// user has none there, changed if they had another type, and removed if they
+// sent the type they already had. The target's total_reactions is recounted
+// in the same transaction, so it always matches the stored reactions.
+func (r *FeedRepository) ToggleReaction(ctx context.Context, reaction *models.Reaction) (*models.Reaction, models.ReactionAction, error) {
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
-	reaction.CreatedAt = time.Now()
+	session, err := r.reactionsCollection.Database().Client().StartSession()
+	if err != nil {
+		return nil, "", err
+	}
+	defer session.EndSession(ctx)
 
-	res, err := r.reactionsCollection.InsertOne(ctx, reaction)
+	var (
+		result *models.Reaction
+		action models.ReactionAction
+	)
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		key := bson.M{"user_id": reaction.UserID, "target_id": reaction.TargetID, "target_type": reaction.TargetType}
+
+		var existing models.Reaction
+		err := r.reactionsCollection.FindOne(sessCtx, key).Decode(&existing)
+		switch {
+		case errors.Is(err, mongo.ErrNoDocuments):
+			added := *reaction
+			added.ID = primitive.NewObjectID()
+			added.CreatedAt = time.Now()
+			if _, err := r.reactionsCollection.InsertOne(sessCtx, &added); err != nil {
+				return nil, err
+			}
+			result, action = &added, models.ReactionAdded
+		case err != nil:
+			return nil, err
+		case existing.Type == reaction.Type:
+			if _, err := r.reactionsCollection.DeleteOne(sessCtx, bson.M{"_id": existing.ID}); err != nil {
+				return nil, err
+			}
+			result, action = &existing, models.ReactionRemoved
+		default:
+			existing.Type = reaction.Type
+			if _, err := r.reactionsCollection.UpdateOne(sessCtx, bson.M{"_id": existing.ID}, bson.M{"$set": bson.M{"type": existing.Type}}); err != nil {
+				return nil, err
+			}
+			result, action = &existing, models.ReactionChanged
+		}
+		return nil, r.recountReactions(sessCtx, reaction.TargetID, reaction.TargetType)
+	})
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	reaction.ID = res.InsertedID.(primitive.ObjectID)
-	return reaction, nil
+	return result, action, nil
+}
+
+// recountReactions sets the target's total_reactions from its stored reactions
+func (r *FeedRepository) recountReactions(ctx context.Context, targetID primitive.ObjectID, targetType string) error {
+	var target *mongo.Collection
+	switch targetType {
+	case "post":
+		target = r.postsCollection
+	case "comment":
+		target = r.commentsCollection
+	case "reply":
+		target = r.repliesCollection
+	default:
+		return fmt.Errorf("unknown reaction target type %q", targetType)
+	}
+
+	count, err := r.reactionsCollection.CountDocuments(ctx, bson.M{"target_id": targetID, "target_type": targetType})
+	if err != nil {
+		return err
+	}
+	_, err = target.UpdateOne(ctx, bson.M{"_id": targetID}, bson.M{"$set": bson.M{"total_reactions": count}})
+	return err
 }
 
 func (r *FeedRepository) GetReactionByID(ctx context.Context, reactionID primitive.ObjectID) (*models.Reaction, error) {
@@ -702,14 +1165,28 @@ func (r *FeedRepository) GetReactionByID(ctx context.Context, reactionID primiti
 	return &reaction, nil
 }
 
+// DeleteReaction removes one of the user's reactions and recounts its target
+// in the same transaction
 func (r *FeedRepository) DeleteReaction(ctx context.Context, reactionID, userID, targetID primitive.ObjectID, targetType string) error {
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
-	_, err := r.reactionsCollection.DeleteOne(
-		ctx,
-		bson.M{"_id": reactionID, "user_id": userID, "target_id": targetID, "target_type": targetType},
-	)
+	session, err := r.reactionsCollection.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		_, err := r.reactionsCollection.DeleteOne(
+			sessCtx,
+			bson.M{"_id": reactionID, "user_id": userID, "target_id": targetID, "target_type": targetType},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return nil, r.recountReactions(sessCtx, targetID, targetType)
+	})
 	return err
 }
 
@@ -749,6 +1226,97 @@ func (r *FeedRepository) ListComments(ctx context.Context, filter bson.M, opts *
 	return comments, nil
 }
 
+// Weights of the comment relevance score
+const (
+	relevanceReactionWeight   = 2.0 // Times ln(1 + reactions)
+	relevancePostAuthorWeight = 3.0 // Comments by the post's author or that it replied to
+	relevanceFriendWeight     = 2.0 // Comments by the viewer's friends
+	relevanceRecencyWeight    = 3.0 // Halves after relevanceRecencyHours
+	relevanceRecencyHours     = 12.0
+)
+
+// ListCommentsByRelevance pages through a post's comments, most relevant
+// first. The score adds up reactions, whether the post's author wrote or
+// replied to the comment, whether a friend of the viewer wrote it, and how
+// recent it is. Scoring reads every comment of the post, so it suits posts
+// with a bounded number of comments.
+func (r *FeedRepository) ListCommentsByRelevance(ctx context.Context, postID, postAuthorID primitive.ObjectID, friendIDs []primitive.ObjectID, now time.Time, skip, limit int64) ([]models.Comment, error) {
+	if friendIDs == nil {
+		friendIDs = []primitive.ObjectID{}
+	}
+	ageHours := bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{now, "$created_at"}}, 3600000}}
+
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.M{"post_id": postID}}},
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from": "replies",
+			"let":  bson.M{"commentId": "$_id"},
+			"pipeline": mongo.Pipeline{
+				bson.D{{Key: "$match", Value: bson.M{"$expr": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$comment_id", "$$commentId"}},
+					bson.M{"$eq": bson.A{"$user_id", postAuthorID}},
+				}}}}},
+				bson.D{{Key: "$limit", Value: 1}},
+				bson.D{{Key: "$project", Value: bson.M{"_id": 1}}},
+			},
+			"as": "post_author_replies",
+		}}},
+		bson.D{{Key: "$addFields", Value: bson.M{"relevance_score": bson.M{"$add": bson.A{
+			bson.M{"$multiply": bson.A{relevanceReactionWeight, bson.M{"$ln": bson.M{"$add": bson.A{1, bson.M{"$max": bson.A{0, bson.M{"$ifNull": bson.A{"$total_reactions", 0}}}}}}}}},
+			bson.M{"$cond": bson.A{
+				bson.M{"$or": bson.A{
+					bson.M{"$eq": bson.A{"$user_id", postAuthorID}},
+					bson.M{"$gt": bson.A{bson.M{"$size": "$post_author_replies"}, 0}},
+				}},
+				relevancePostAuthorWeight, 0,
+			}},
+			bson.M{"$cond": bson.A{bson.M{"$in": bson.A{"$user_id", friendIDs}}, relevanceFriendWeight, 0}},
+			bson.M{"$divide": bson.A{
+				relevanceRecencyWeight,
+				bson.M{"$add": bson.A{1, bson.M{"$divide": bson.A{bson.M{"$max": bson.A{0, ageHours}}, relevanceRecencyHours}}}},
+			}},
+		}}}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "relevance_score", Value: -1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}}},
+		bson.D{{Key: "$skip", Value: skip}},
+		bson.D{{Key: "$limit", Value: limit}},
+	}
+	return r.aggregateComments(ctx, pipeline)
+}
+
+// ListTopComments pages through a post's comments by reactions, then newest
+// first. The (post_id, total_reactions, created_at) comments index serves
+// both the match and the sort, so it is the fallback for posts with too many
+// comments to score.
+func (r *FeedRepository) ListTopComments(ctx context.Context, postID primitive.ObjectID, skip, limit int64) ([]models.Comment, error) {
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.M{"post_id": postID}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "total_reactions", Value: -1}, {Key: "created_at", Value: -1}}}},
+		bson.D{{Key: "$skip", Value: skip}},
+		bson.D{{Key: "$limit", Value: limit}},
+	}
+	return r.aggregateComments(ctx, pipeline)
+}
+
+// aggregateComments runs pipeline, which already picked and ordered a page
+// of comments, and shapes the comments like ListComments does
+func (r *FeedRepository) aggregateComments(ctx context.Context, pipeline mongo.Pipeline) ([]models.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	pipeline = append(pipeline, r.aggregateCommentPipeline()...)
+	cur, err := r.commentsCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	comments := []models.Comment{}
+	if err := cur.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
 func (r *FeedRepository) ListReplies(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.Reply, error) {
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
@@ -826,6 +1394,28 @@ func (r *FeedRepository) aggregatePostPipeline() mongo.Pipeline {
 			"foreignField": "_id",
 			"as":           "mentioned_users_info",
 		}}},
+		// Resolve the original of a reshare
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         "posts",
+			"localField":   "shared_from.original_post_id",
+			"foreignField": "_id",
+			"as":           "shared_original",
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.M{"path": "$shared_original", "preserveNullAndEmptyArrays": true}}},
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         "users",
+			"localField":   "shared_original.user_id",
+			"foreignField": "_id",
+			"as":           "shared_original_author",
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.M{"path": "$shared_original_author", "preserveNullAndEmptyArrays": true}}},
+		bson.D{{Key: "$addFields", Value: bson.M{
+			"shared_original_available": bson.M{"$and": bson.A{
+				bson.M{"$eq": bson.A{bson.M{"$type": "$shared_original"}, "object"}},
+				bson.M{"$ne": bson.A{"$shared_original.privacy", models.PrivacySettingOnlyMe}},
+				bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$shared_original.status", models.PostStatusActive}}, models.PostStatusActive}},
+			}},
+		}}},
 		// Final projection (shape the output as needed)
 		bson.D{{Key: "$project", Value: bson.M{
 			"_id":             1,
@@ -847,26 +1437,50 @@ func (r *FeedRepository) aggregatePostPipeline() mongo.Pipeline {
 						"username":  "$$u.username",
 						"avatar":    "$$u.avatar",
 						"full_name": "$$u.full_name",
+						"verified":  "$$u.verified",
 					},
 				},
 			},
 			"location":   1,
-			"hashtags":   1,
-			"created_at": 1,
+			"hashtags":     1,
+			"poll_options": 1,
+			"pinned_at":    1,
+			"created_at":   1,
 			"updated_at": 1,
 			"author": bson.M{
 				"id":        bson.M{"$toString": "$author_info._id"},
 				"username":  bson.M{"$ifNull": bson.A{"$author_info.username", "Deleted User"}},
 				"avatar":    bson.M{"$ifNull": bson.A{"$author_info.avatar", ""}},
 				"full_name": bson.M{"$ifNull": bson.A{"$author_info.full_name", "Deleted User"}},
+				"verified":  bson.M{"$ifNull": bson.A{"$author_info.verified", false}},
 			},
 			"specific_reaction_counts": "$specific_reaction_counts",
 			"total_reactions":          "$total_reactions",
 			"total_comments":           "$total_comments",
+			"total_shares":             "$total_shares",
+			"shared_from": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{bson.M{"$type": "$shared_from"}, "object"}},
+				bson.M{"$mergeObjects": bson.A{"$shared_from", bson.M{
+					"available":           "$shared_original_available",
+					"original_content":    bson.M{"$cond": bson.A{"$shared_original_available", "$shared_original.content", nil}},
+					"original_media":      bson.M{"$cond": bson.A{"$shared_original_available", "$shared_original.media", nil}},
+					"original_created_at": bson.M{"$cond": bson.A{"$shared_original_available", "$shared_original.created_at", nil}},
+					"original_author": bson.M{"$cond": bson.A{"$shared_original_available", bson.M{
+						"id":        bson.M{"$toString": "$shared_original_author._id"},
+						"username":  bson.M{"$ifNull": bson.A{"$shared_original_author.username", "Deleted User"}},
+						"avatar":    bson.M{"$ifNull": bson.A{"$shared_original_author.avatar", ""}},
+						"full_name": bson.M{"$ifNull": bson.A{"$shared_original_author.full_name", "Deleted User"}},
+					}, nil}},
+				}}},
+				"$$REMOVE",
+			}},
 		}}},
 	}
 }
 
+// CommentReplyPreviewSize is how many top-level replies come with each comment
+const CommentReplyPreviewSize = 3
+
 func (r *FeedRepository) aggregateCommentPipeline() mongo.Pipeline {
 	return mongo.Pipeline{
 		// comment author
@@ -878,12 +1492,17 @@ func (r *FeedRepository) aggregateCommentPipeline() mongo.Pipeline {
 		}}},
 		bson.D{{Key: "$unwind", Value: bson.M{"path": "$author_info", "preserveNullAndEmptyArrays": true}}},
 
-		// replies for comment
+		// the first top-level replies; the rest are paged through ListReplies
 		bson.D{{Key: "$lookup", Value: bson.M{
 			"from": "replies",
 			"let":  bson.M{"commentId": "$_id"},
 			"pipeline": mongo.Pipeline{
-				bson.D{{Key: "$match", Value: bson.M{"$expr": bson.M{"$eq": bson.A{"$comment_id", "$$commentId"}}}}},
+				bson.D{{Key: "$match", Value: bson.M{
+					"$expr":           bson.M{"$eq": bson.A{"$comment_id", "$$commentId"}},
+					"parent_reply_id": nil,
+				}}},
+				bson.D{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: 1}}}},
+				bson.D{{Key: "$limit", Value: CommentReplyPreviewSize}},
 				bson.D{{Key: "$lookup", Value: bson.M{
 					"from":         "users",
 					"localField":   "user_id",
@@ -900,6 +1519,8 @@ func (r *FeedRepository) aggregateCommentPipeline() mongo.Pipeline {
 					"media_type":      1,
 					"media_url":       1,
 					"mentions":        1,
+					"depth":           1,
+					"reply_count":     1,
 					"created_at":      1,
 					"updated_at":      1,
 					"author": bson.M{
@@ -907,6 +1528,7 @@ func (r *FeedRepository) aggregateCommentPipeline() mongo.Pipeline {
 						"username":  "$author_info.username",
 						"avatar":    "$author_info.avatar",
 						"full_name": "$author_info.full_name",
+						"verified":  "$author_info.verified",
 					},
 				}}},
 			},
@@ -915,22 +1537,24 @@ func (r *FeedRepository) aggregateCommentPipeline() mongo.Pipeline {
 
 		// final shape
 		bson.D{{Key: "$project", Value: bson.M{
-			"_id":        1,
-			"id":         bson.M{"$toString": "$_id"},
-			"post_id":    1,
-			"user_id":    1,
-			"content":    1,
-			"media_type": 1,
-			"media_url":  1,
-			"mentions":   1,
-			"created_at": 1,
-			"updated_at": 1,
-			"replies":    1,
+			"_id":         1,
+			"id":          bson.M{"$toString": "$_id"},
+			"post_id":     1,
+			"user_id":     1,
+			"content":     1,
+			"media_type":  1,
+			"media_url":   1,
+			"mentions":    1,
+			"reply_count": 1,
+			"created_at":  1,
+			"updated_at":  1,
+			"replies":     1,
 			"author": bson.M{
 				"id":        bson.M{"$toString": "$author_info._id"},
 				"username":  "$author_info.username",
 				"avatar":    "$author_info.avatar",
 				"full_name": "$author_info.full_name",
+				"verified":  "$author_info.verified",
 			},
 		}}},
 	}
@@ -955,6 +1579,8 @@ func (r *FeedRepository) aggregateReplyPipeline() mongo.Pipeline {
 			"media_type":      1,
 			"media_url":       1,
 			"mentions":        1,
+			"depth":           1,
+			"reply_count":     1,
 			"created_at":      1,
 			"updated_at":      1,
 			"author": bson.M{
@@ -962,6 +1588,7 @@ func (r *FeedRepository) aggregateReplyPipeline() mongo.Pipeline {
 				"username":  "$author_info.username",
 				"avatar":    "$author_info.avatar",
 				"full_name": "$author_info.full_name",
+				"verified":  "$author_info.verified",
 			},
 		}}},
 	}
@@ -999,98 +1626,209 @@ func (r *FeedRepository) CountReactionsByType(ctx context.Context, targetID prim
 	return out, nil
 }
 
-func (r *FeedRepository) IncrementPostReactionCount(ctx context.Context, postID primitive.ObjectID) error {
+func (r *FeedRepository) IncrementPostCommentCount(ctx context.Context, postID primitive.ObjectID) error {
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
 	_, err := r.postsCollection.UpdateOne(
 		ctx,
 		bson.M{"_id": postID},
-		bson.M{"$inc": bson.M{"total_reactions": 1}},
+		bson.M{"$inc": bson.M{"total_comments": 1}},
 	)
 	return err
 }
 
-func (r *FeedRepository) DecrementPostReactionCount(ctx context.Context, postID primitive.ObjectID) error {
+func (r *FeedRepository) DecrementPostCommentCount(ctx context.Context, postID primitive.ObjectID) error {
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
 	_, err := r.postsCollection.UpdateOne(
 		ctx,
 		bson.M{"_id": postID},
-		bson.M{"$inc": bson.M{"total_reactions": -1}},
+		bson.M{"$inc": bson.M{"total_comments": -1}},
 	)
 	return err
 }
 
-func (r *FeedRepository) IncrementCommentReactionCount(ctx context.Context, commentID primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
-	defer cancel()
+// CounterTarget names a feed collection whose documents carry denormalized
+// counters
+type CounterTarget string
 
-	_, err := r.commentsCollection.UpdateOne(
-		ctx,
-		bson.M{"_id": commentID},
-		bson.M{"$inc": bson.M{"total_reactions": 1}},
-	)
-	return err
-}
+const (
+	CounterTargetPost    CounterTarget = "post"
+	CounterTargetComment CounterTarget = "comment"
+	CounterTargetReply   CounterTarget = "reply"
+)
 
-func (r *FeedRepository) DecrementCommentReactionCount(ctx context.Context, commentID primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
-	defer cancel()
+// CounterTargets lists every target with counters to reconcile
+var CounterTargets = []CounterTarget{CounterTargetPost, CounterTargetComment, CounterTargetReply}
 
-	_, err := r.commentsCollection.UpdateOne(
-		ctx,
-		bson.M{"_id": commentID},
-		bson.M{"$inc": bson.M{"total_reactions": -1}},
-	)
-	return err
+// CounterDrift is a stored counter that disagreed with its source collection
+type CounterDrift struct {
+	ID     primitive.ObjectID
+	Field  string
+	Stored int64
+	Actual int64
 }
 
-func (r *FeedRepository) IncrementReplyReactionCount(ctx context.Context, replyID primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
-	defer cancel()
-
-	_, err := r.repliesCollection.UpdateOne(
-		ctx,
-		bson.M{"_id": replyID},
-		bson.M{"$inc": bson.M{"total_reactions": 1}},
-	)
-	return err
+// CounterBatch is the outcome of reconciling one page of a target's documents
+type CounterBatch struct {
+	Scanned int
+	LastID  primitive.ObjectID
+	Drifts  []CounterDrift
+	// Repaired counts the drifts written back; the others changed while they
+	// were being counted and are left to the next pass
+	Repaired int
 }
 
-func (r *FeedRepository) DecrementReplyReactionCount(ctx context.Context, replyID primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
-	defer cancel()
+// counterSource recomputes one counter by counting the source documents whose
+// key field references the counted document
+type counterSource struct {
+	field  string
+	source *mongo.Collection
+	key    string
+	match  bson.M
+}
 
-	_, err := r.repliesCollection.UpdateOne(
-		ctx,
-		bson.M{"_id": replyID},
-		bson.M{"$inc": bson.M{"total_reactions": -1}},
-	)
-	return err
+func (r *FeedRepository) counterSources(target CounterTarget) (*mongo.Collection, []counterSource, error) {
+	reactions := func(targetType string) counterSource {
+		return counterSource{field: "total_reactions", source: r.reactionsCollection, key: "target_id", match: bson.M{"target_type": targetType}}
+	}
+	switch target {
+	case CounterTargetPost:
+		return r.postsCollection, []counterSource{
+			reactions("post"),
+			{field: "total_comments", source: r.commentsCollection, key: "post_id"},
+		}, nil
+	case CounterTargetComment:
+		return r.commentsCollection, []counterSource{
+			reactions("comment"),
+			{field: "reply_count", source: r.repliesCollection, key: "comment_id"},
+		}, nil
+	case CounterTargetReply:
+		return r.repliesCollection, []counterSource{
+			reactions("reply"),
+			{field: "reply_count", source: r.repliesCollection, key: "parent_reply_id"},
+		}, nil
+	}
+	return nil, nil, fmt.Errorf("unknown counter target %q", target)
 }
 
-func (r *FeedRepository) IncrementPostCommentCount(ctx context.Context, postID primitive.ObjectID) error {
+// ReconcileCounters recounts the counters of up to limit target documents
+// with IDs after after, in ID order, and writes back the ones that drifted.
+// A repair only applies while the stored value is still the one that was
+// read, so it never overwrites an increment that landed mid-pass. Pass the
+// batch's LastID as after to continue with the next page.
+func (r *FeedRepository) ReconcileCounters(ctx context.Context, target CounterTarget, after primitive.ObjectID, limit int) (*CounterBatch, error) {
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
-	_, err := r.postsCollection.UpdateOne(
-		ctx,
-		bson.M{"_id": postID},
-		bson.M{"$inc": bson.M{"total_comments": 1}},
-	)
-	return err
+	collection, sources, err := r.counterSources(target)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{}
+	if !after.IsZero() {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+	projection := bson.M{}
+	for _, source := range sources {
+		projection[source.field] = 1
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit)).SetProjection(projection)
+	cur, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []bson.M
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	batch := &CounterBatch{Scanned: len(docs)}
+	if len(docs) == 0 {
+		return batch, nil
+	}
+	ids := make([]primitive.ObjectID, len(docs))
+	for i, doc := range docs {
+		ids[i], _ = doc["_id"].(primitive.ObjectID)
+	}
+	batch.LastID = ids[len(ids)-1]
+
+	var writes []mongo.WriteModel
+	for _, source := range sources {
+		actual, err := countBySource(ctx, source, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s: %w", source.field, err)
+		}
+		for i, doc := range docs {
+			stored := counterValue(doc[source.field])
+			if stored == actual[ids[i]] {
+				continue
+			}
+			batch.Drifts = append(batch.Drifts, CounterDrift{ID: ids[i], Field: source.field, Stored: stored, Actual: actual[ids[i]]})
+
+			// A zero counter may also be missing from older documents
+			var storedMatch interface{} = stored
+			if stored == 0 {
+				storedMatch = bson.M{"$in": bson.A{0, nil}}
+			}
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": ids[i], source.field: storedMatch}).
+				SetUpdate(bson.M{"$set": bson.M{source.field: actual[ids[i]]}}))
+		}
+	}
+
+	if len(writes) > 0 {
+		res, err := collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return nil, err
+		}
+		batch.Repaired = int(res.ModifiedCount)
+	}
+	return batch, nil
 }
 
-func (r *FeedRepository) DecrementPostCommentCount(ctx context.Context, postID primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
-	defer cancel()
+// countBySource counts the source documents referencing each of ids
+func countBySource(ctx context.Context, source counterSource, ids []primitive.ObjectID) (map[primitive.ObjectID]int64, error) {
+	match := bson.M{source.key: bson.M{"$in": ids}}
+	for k, v := range source.match {
+		match[k] = v
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + source.key, "count": bson.M{"$sum": 1}}}},
+	}
+	cur, err := source.source.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
 
-	_, err := r.postsCollection.UpdateOne(
-		ctx,
-		bson.M{"_id": postID},
-		bson.M{"$inc": bson.M{"total_comments": -1}},
-	)
-	return err
+	counts := make(map[primitive.ObjectID]int64, len(ids))
+	for cur.Next(ctx) {
+		var row struct {
+			ID    primitive.ObjectID `bson:"_id"`
+			Count int64              `bson:"count"`
+		}
+		if err := cur.Decode(&row); err != nil {
+			return nil, err
+		}
+		counts[row.ID] = row.Count
+	}
+	return counts, cur.Err()
+}
+
+// counterValue reads a counter however it was stored; a missing one is zero
+func counterValue(v interface{}) int64 {
+	switch n := v.(type) {
+	case int32:
+		return int64(n)
+	case int64:
+		return n
+	case float64:
+		return int64(n)
+	}
+	return 0
 }
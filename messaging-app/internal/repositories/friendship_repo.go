@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"time"
 
@@ -597,12 +598,12 @@ func (r *FriendshipRepository) SearchFriends(ctx context.Context, userID primiti
 
 // Custom errors
 var (
-	ErrCannotFriendSelf      = errors.New("cannot send friend request to yourself")
-	ErrFriendRequestExists   = errors.New("friend request already exists between these users")
-	ErrFriendRequestNotFound = errors.New("friend request not found or not actionable")
-	ErrCannotBlockSelf       = errors.New("cannot block yourself")
-	ErrAlreadyBlocked        = errors.New("user is already blocked")
-	ErrFriendshipNotFound    = errors.New("friendship not found")
-	ErrBlockNotFound         = errors.New("block relationship not found")
-	ErrNotFriends            = errors.New("users are not friends")
+	ErrCannotFriendSelf      = apperrors.Validation("cannot send friend request to yourself")
+	ErrFriendRequestExists   = apperrors.Conflict("friend request already exists between these users")
+	ErrFriendRequestNotFound = apperrors.NotFound("friend request not found or not actionable")
+	ErrCannotBlockSelf       = apperrors.Validation("cannot block yourself")
+	ErrAlreadyBlocked        = apperrors.Conflict("user is already blocked")
+	ErrFriendshipNotFound    = apperrors.NotFound("friendship not found")
+	ErrBlockNotFound         = apperrors.NotFound("block relationship not found")
+	ErrNotFriends            = apperrors.Validation("users are not friends")
 )
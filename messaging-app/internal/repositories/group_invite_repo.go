@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GroupInviteRepository stores group invite links. Expired links are dropped
+// by a TTL index.
+type GroupInviteRepository struct {
+	db *mongo.Database
+}
+
+func NewGroupInviteRepository(db *mongo.Database) *GroupInviteRepository {
+	_, err := db.Collection("group_invites").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "group_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		panic("Failed to create group invite indexes: " + err.Error())
+	}
+
+	return &GroupInviteRepository{db: db}
+}
+
+func (r *GroupInviteRepository) collection() *mongo.Collection {
+	return r.db.Collection("group_invites")
+}
+
+func (r *GroupInviteRepository) Create(ctx context.Context, invite *models.GroupInvite) error {
+	invite.ID = primitive.NewObjectID()
+	_, err := r.collection().InsertOne(ctx, invite)
+	return err
+}
+
+// GetByCode returns mongo.ErrNoDocuments for unknown or revoked codes
+func (r *GroupInviteRepository) GetByCode(ctx context.Context, code string) (*models.GroupInvite, error) {
+	var invite models.GroupInvite
+	if err := r.collection().FindOne(ctx, bson.M{"code": code}).Decode(&invite); err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// ListByGroup returns a group's invites, newest first
+func (r *GroupInviteRepository) ListByGroup(ctx context.Context, groupID primitive.ObjectID) ([]models.GroupInvite, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection().Find(ctx, bson.M{"group_id": groupID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	invites := []models.GroupInvite{}
+	if err := cursor.All(ctx, &invites); err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+// Delete revokes an invite and reports whether it existed
+func (r *GroupInviteRepository) Delete(ctx context.Context, groupID primitive.ObjectID, code string) (bool, error) {
+	res, err := r.collection().DeleteOne(ctx, bson.M{"group_id": groupID, "code": code})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// Use counts one use of an invite that has neither expired nor run out of
+// uses. It returns mongo.ErrNoDocuments when the invite can't be used.
+func (r *GroupInviteRepository) Use(ctx context.Context, code string, now time.Time) (*models.GroupInvite, error) {
+	filter := bson.M{
+		"code": code,
+		"$and": []bson.M{
+			{"$or": []bson.M{
+				{"max_uses": 0},
+				{"$expr": bson.M{"$lt": []string{"$uses", "$max_uses"}}},
+			}},
+			{"$or": []bson.M{
+				{"expires_at": nil},
+				{"expires_at": bson.M{"$gt": now}},
+			}},
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var invite models.GroupInvite
+	err := r.collection().FindOneAndUpdate(ctx, filter, bson.M{"$inc": bson.M{"uses": 1}}, opts).Decode(&invite)
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// Release gives back a use taken by Use when the join did not go through
+func (r *GroupInviteRepository) Release(ctx context.Context, inviteID primitive.ObjectID) error {
+	_, err := r.collection().UpdateOne(ctx, bson.M{"_id": inviteID, "uses": bson.M{"$gt": 0}}, bson.M{"$inc": bson.M{"uses": -1}})
+	return err
+}
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"time"
 
@@ -97,6 +99,63 @@ func (r *GroupRepository) AddMember(ctx context.Context, groupID, userID primiti
 	return err
 }
 
+// Why AddMemberWithinLimit added no one
+var (
+	ErrGroupMemberBanned = errors.New("user is banned from the group")
+	ErrGroupMemberLimit  = errors.New("group has reached its member limit")
+)
+
+// AddMemberWithinLimit adds a member unless they are banned or the group
+// already has limit members; the checks and the add are one atomic update, so
+// a join racing a ban or another join can't slip past them. It reports whether
+// the user was added: false with no error means they already were a member.
+// Otherwise it returns ErrGroupMemberBanned, ErrGroupMemberLimit, or
+// mongo.ErrNoDocuments when there is no such group.
+func (r *GroupRepository) AddMemberWithinLimit(ctx context.Context, groupID, userID primitive.ObjectID, limit int) (bool, error) {
+	lastSlot := fmt.Sprintf("members.%d", limit-1)
+	result, err := r.db.Collection("groups").UpdateOne(
+		ctx,
+		bson.M{
+			"_id":          groupID,
+			"members":      bson.M{"$ne": userID},
+			"banned_users": bson.M{"$ne": userID},
+			lastSlot:       bson.M{"$exists": false},
+		},
+		bson.M{
+			"$addToSet": bson.M{"members": userID},
+			"$set":      bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	if result.MatchedCount == 1 {
+		return true, nil
+	}
+
+	// Nothing matched: find out which condition failed
+	var group struct {
+		Members     []primitive.ObjectID `bson:"members"`
+		BannedUsers []primitive.ObjectID `bson:"banned_users"`
+	}
+	err = r.db.Collection("groups").FindOne(ctx, bson.M{"_id": groupID},
+		options.FindOne().SetProjection(bson.M{"members": 1, "banned_users": 1})).Decode(&group)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range group.BannedUsers {
+		if id == userID {
+			return false, ErrGroupMemberBanned
+		}
+	}
+	for _, id := range group.Members {
+		if id == userID {
+			return false, nil
+		}
+	}
+	return false, ErrGroupMemberLimit
+}
+
 func (r *GroupRepository) AddAdmin(ctx context.Context, groupID, userID primitive.ObjectID) error {
 	// First ensure user is a member
 	if err := r.AddMember(ctx, groupID, userID); err != nil {
@@ -215,20 +274,155 @@ func (r *GroupRepository) RemovePendingMember(ctx context.Context, groupID, user
 	return err
 }
 
+// RemovePendingUser withdraws the user's join requests from every group
+func (r *GroupRepository) RemovePendingUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	res, err := r.db.Collection("groups").UpdateMany(
+		ctx,
+		bson.M{"pending_members": userID},
+		bson.M{
+			"$pull": bson.M{"pending_members": userID},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// BanUser removes a user from the group, its admins and its join requests,
+// and keeps them from joining again. The owner cannot be banned;
+// mongo.ErrNoDocuments reports that case.
+func (r *GroupRepository) BanUser(ctx context.Context, groupID, userID primitive.ObjectID) error {
+	result, err := r.db.Collection("groups").UpdateOne(
+		ctx,
+		bson.M{"_id": groupID, "$nor": bson.A{ownedBy(userID)}},
+		bson.M{
+			"$pull": bson.M{
+				"members":         userID,
+				"admins":          userID,
+				"pending_members": userID,
+			},
+			"$addToSet": bson.M{"banned_users": userID},
+			"$set":      bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UnbanUser lifts a ban and reports whether the user was banned
+func (r *GroupRepository) UnbanUser(ctx context.Context, groupID, userID primitive.ObjectID) (bool, error) {
+	result, err := r.db.Collection("groups").UpdateOne(
+		ctx,
+		bson.M{"_id": groupID, "banned_users": userID},
+		bson.M{
+			"$pull": bson.M{"banned_users": userID},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// UpdateGroupSettings saves the group settings other than permissions, which
+// UpdatePermissions owns
 func (r *GroupRepository) UpdateGroupSettings(ctx context.Context, groupID primitive.ObjectID, settings models.GroupSettings) error {
 	_, err := r.db.Collection("groups").UpdateOne(
 		ctx,
 		bson.M{"_id": groupID},
 		bson.M{
 			"$set": bson.M{
-				"settings":   settings,
-				"updated_at": time.Now(),
+				"settings.requires_approval": settings.RequiresApproval,
+				"settings.max_members":       settings.MaxMembers,
+				"updated_at":                 time.Now(),
+			},
+		},
+	)
+	return err
+}
+
+func (r *GroupRepository) UpdatePermissions(ctx context.Context, groupID primitive.ObjectID, permissions models.GroupPermissions) error {
+	_, err := r.db.Collection("groups").UpdateOne(
+		ctx,
+		bson.M{"_id": groupID},
+		bson.M{
+			"$set": bson.M{
+				"settings.permissions": permissions,
+				"updated_at":           time.Now(),
 			},
 		},
 	)
 	return err
 }
 
+// ownedBy matches groups owned by userID. Groups from before ownership could
+// be transferred have no owner_id and are owned by their creator.
+func ownedBy(userID primitive.ObjectID) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"owner_id": userID},
+		bson.M{"owner_id": bson.M{"$exists": false}, "creator_id": userID},
+	}}
+}
+
+// SetRole makes a member an admin or a plain member. The filters re-check
+// membership and ownership so a concurrent change cannot promote a user who
+// just left or demote the owner; mongo.ErrNoDocuments reports either case.
+func (r *GroupRepository) SetRole(ctx context.Context, groupID, userID primitive.ObjectID, role models.GroupRole) error {
+	filter := bson.M{"_id": groupID, "members": userID}
+	var update bson.M
+	switch role {
+	case models.GroupRoleAdmin:
+		update = bson.M{"$addToSet": bson.M{"admins": userID}}
+	case models.GroupRoleMember:
+		filter["$nor"] = bson.A{ownedBy(userID)}
+		update = bson.M{"$pull": bson.M{"admins": userID}}
+	default:
+		return fmt.Errorf("cannot assign role %q", role)
+	}
+	update["$set"] = bson.M{"updated_at": time.Now()}
+
+	result, err := r.db.Collection("groups").UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// TransferOwnership hands the group from its current owner to another member,
+// who also becomes an admin. The previous owner stays on as an admin.
+func (r *GroupRepository) TransferOwnership(ctx context.Context, groupID, ownerID, newOwnerID primitive.ObjectID) error {
+	filter := ownedBy(ownerID)
+	filter["_id"] = groupID
+	filter["members"] = newOwnerID
+
+	result, err := r.db.Collection("groups").UpdateOne(
+		ctx,
+		filter,
+		bson.M{
+			"$set":      bson.M{"owner_id": newOwnerID, "updated_at": time.Now()},
+			"$addToSet": bson.M{"admins": newOwnerID},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
 // Helper function
 func containsID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
 	for _, i := range ids {
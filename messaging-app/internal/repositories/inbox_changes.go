@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gocql/gocql"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// InboxChangeRetention matches the default TTL of user_inbox_changes. Sync
+// tokens older than this can no longer be answered with a delta.
+const InboxChangeRetention = 14 * 24 * time.Hour
+
+const insertInboxChangeQuery = `INSERT INTO user_inbox_changes (user_id, change_id, conversation_id) VALUES (?, ?, ?)`
+
+// AppendInboxChange logs a change to a user's inbox row in the same batch as
+// the write that causes it, so delta sync clients pick it up.
+func AppendInboxChange(batch *gocql.Batch, userID, conversationID string) {
+	batch.Query(insertInboxChangeQuery, userID, gocql.TimeUUID(), conversationID)
+}
+
+func (r *MessageCassandraRepository) recordInboxChange(userID, conversationID string) error {
+	return r.client.Session.Query(insertInboxChangeQuery, userID, gocql.TimeUUID(), conversationID).Exec()
+}
+
+// LatestInboxChange returns the newest change logged for the user, or a
+// TimeUUID for now when there is none, to be used as a sync token.
+func (r *MessageCassandraRepository) LatestInboxChange(ctx context.Context, userID primitive.ObjectID) (gocql.UUID, error) {
+	if r.client == nil || r.client.Session == nil {
+		return gocql.UUID{}, fmt.Errorf("cassandra client not initialized")
+	}
+
+	var changeID gocql.UUID
+	err := r.client.Session.Query(
+		`SELECT change_id FROM user_inbox_changes WHERE user_id = ? ORDER BY change_id DESC LIMIT 1`,
+		userID.Hex(),
+	).WithContext(ctx).Scan(&changeID)
+	if err == gocql.ErrNotFound {
+		return gocql.UUIDFromTime(time.Now()), nil
+	}
+	return changeID, err
+}
+
+// GetInboxChanges returns the conversations whose inbox rows changed after
+// since, and the newest change seen (since itself when nothing changed).
+func (r *MessageCassandraRepository) GetInboxChanges(ctx context.Context, userID primitive.ObjectID, since gocql.UUID) ([]string, gocql.UUID, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, since, fmt.Errorf("cassandra client not initialized")
+	}
+
+	iter := r.client.Session.Query(
+		`SELECT change_id, conversation_id FROM user_inbox_changes WHERE user_id = ? AND change_id > ?`,
+		userID.Hex(), since,
+	).WithContext(ctx).Iter()
+
+	latest := since
+	seen := make(map[string]struct{})
+	var conversationIDs []string
+	var changeID gocql.UUID
+	var conversationID string
+	for iter.Scan(&changeID, &conversationID) {
+		latest = changeID // Clustered ascending, so the last row is the newest
+		if _, ok := seen[conversationID]; ok {
+			continue
+		}
+		seen[conversationID] = struct{}{}
+		conversationIDs = append(conversationIDs, conversationID)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, since, err
+	}
+	return conversationIDs, latest, nil
+}
+
+// GetInboxConversations returns the user's inbox rows for the given Cassandra
+// conversation IDs, newest first. IDs outside the marketplace segment are
+// skipped.
+func (r *MessageCassandraRepository) GetInboxConversations(ctx context.Context, userID primitive.ObjectID, isMarketplace bool, conversationIDs []string) ([]models.ConversationSummary, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+	if len(conversationIDs) == 0 {
+		return []models.ConversationSummary{}, nil
+	}
+
+	query := `SELECT conversation_id, conversation_name, conversation_avatar, is_group, last_message_content, last_message_sender_id, last_message_sender_name, last_message_at
+	          FROM user_inbox WHERE user_id = ? AND is_marketplace = ? AND conversation_id IN ?`
+
+	iter := r.client.Session.Query(query, userID.Hex(), isMarketplace, conversationIDs).WithContext(ctx).Iter()
+	summaries := scanInbox(iter, userID, r.unreadCounts(userID), r.mentionCounts(userID), r.inboxFlags(userID.Hex()))
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	r.applyConversationSettings(userID, summaries)
+	r.applyConversationRequests(userID, summaries)
+	sortInbox(summaries)
+	return summaries, nil
+}
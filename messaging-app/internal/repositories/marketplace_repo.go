@@ -255,6 +255,7 @@ func (r *MarketplaceRepository) ListProducts(ctx context.Context, filter models.
 			"username":  "$seller_info.username",
 			"full_name": "$seller_info.full_name",
 			"avatar":    "$seller_info.avatar",
+			"verified":  "$seller_info.verified",
 		},
 		"category": bson.M{
 			"_id":  "$category_info._id",
@@ -3,9 +3,11 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"messaging-app/internal/db"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"sort"
 	"strings"
@@ -33,20 +35,45 @@ type ArchivedMessageContent struct {
 	MediaURLs   []string `json:"media_urls,omitempty"`
 	ProductID   string   `json:"product_id,omitempty"`
 	CreatedAt   string   `json:"created_at"`
+	// ForwardedFrom is the JSON attribution of a forwarded message
+	ForwardedFrom string `json:"forwarded_from,omitempty"`
+	// Offer is the JSON offer card of a marketplace offer message
+	Offer string `json:"offer,omitempty"`
+	// LinkPreview is the JSON preview card of the message's first link
+	LinkPreview string `json:"link_preview,omitempty"`
+	// ReplyToID is the UUID of the message this one replies to
+	ReplyToID string `json:"reply_to_id,omitempty"`
 }
 
 // ArchivedMessageMetadata represents mutable metadata from hot storage
 type ArchivedMessageMetadata struct {
-	Reactions   string
-	SeenBy      []string
-	DeliveredTo []string
-	IsDeleted   bool
-	IsEdited    bool
+	Reactions      string
+	ReactionCounts map[string]int
+	SeenBy         []string
+	DeliveredTo    []string
+	IsDeleted      bool
+	IsEdited       bool
 }
 
+// MessageSearcher queries the full-text message index (implemented by messagesearch.Index)
+type MessageSearcher interface {
+	Search(ctx context.Context, userID string, groupIDs []string, query models.MessageSearchQuery) ([]models.MessageSearchResult, error)
+}
+
+var (
+	// ErrMessageNotFound is returned when a message does not exist or was deleted
+	ErrMessageNotFound = apperrors.NotFound("message not found")
+	// ErrInvalidThreadCursor is returned for a thread page cursor that is not a reply ID
+	ErrInvalidThreadCursor = apperrors.Validation("invalid thread cursor")
+	// ErrPinLimitReached is returned when a conversation already holds the
+	// most pins it may have
+	ErrPinLimitReached = apperrors.Conflict("pinned message limit reached")
+)
+
 type MessageCassandraRepository struct {
 	client         *db.CassandraClient
-	archiveFetcher ArchiveFetcher // Optional, for loading archived messages
+	archiveFetcher ArchiveFetcher  // Optional, for loading archived messages
+	searcher       MessageSearcher // Optional, Cassandra has no full-text search
 }
 
 func NewMessageCassandraRepository(client *db.CassandraClient) *MessageCassandraRepository {
@@ -58,6 +85,11 @@ func (r *MessageCassandraRepository) SetArchiveFetcher(fetcher ArchiveFetcher) {
 	r.archiveFetcher = fetcher
 }
 
+// SetSearcher sets the full-text index used by SearchMessages
+func (r *MessageCassandraRepository) SetSearcher(searcher MessageSearcher) {
+	r.searcher = searcher
+}
+
 // getConversationID derives a deterministic conversation ID for DMs or Groups.
 // For DMs, it sorts user IDs to ensure A->B and B->A map to the same conversation.
 func getConversationID(senderId, receiverId, groupId primitive.ObjectID) string {
@@ -132,13 +164,14 @@ func (r *MessageCassandraRepository) Create(ctx context.Context, msg *models.Mes
 	const insertMessageQuery = `INSERT INTO messages (
 		conversation_id, message_id, sender_id, receiver_id, group_id, 
 		content, content_type, media_urls, is_read, 
-		is_marketplace, product_id, reactions, created_at, is_deleted
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		is_marketplace, product_id, reactions, created_at, is_deleted, forwarded_from, offer, reply_to_id
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	batch.Query(insertMessageQuery,
 		conversationID, messageUUID, msg.SenderID.Hex(), msg.ReceiverID.Hex(), msg.GroupID.Hex(),
 		msg.Content, msg.ContentType, msg.MediaURLs, false,
 		msg.IsMarketplace, getStrID(msg.ProductID), string(reactionsJSON), msg.CreatedAt, false,
+		marshalForwardedFrom(msg.ForwardedFrom), marshalOffer(msg.Offer), msg.ReplyToID,
 	)
 
 	// Statement B: Update Inbox (for Sender and all Recipients)
@@ -178,19 +211,34 @@ func (r *MessageCassandraRepository) Create(ctx context.Context, msg *models.Mes
 			params.IsGroup, msg.IsMarketplace, msg.Content, msg.SenderID.Hex(), msg.SenderName, msg.CreatedAt,
 		)
 	}
+	// Recipients' changes are logged once their unread count is bumped below
+	AppendInboxChange(batch, msg.SenderID.Hex(), conversationID)
 
 	// 3. Execute Batch
 	if err := r.client.Session.ExecuteBatch(batch); err != nil {
 		return err
 	}
 
-	// 4. Update Unread Counters (Async)
+	// 4. Update Unread and Mention Counters (Async)
+	mentioned := make(map[primitive.ObjectID]bool, len(msg.Mentions))
+	for _, id := range msg.Mentions {
+		mentioned[id] = true
+	}
 	go func(recipients []primitive.ObjectID, convID string) {
 		const updateCounterQuery = `UPDATE conversation_unread SET unread_count = unread_count + 1 WHERE user_id = ? AND conversation_id = ?`
+		const updateMentionQuery = `UPDATE conversation_mentions SET mention_count = mention_count + 1 WHERE user_id = ? AND conversation_id = ?`
 		for _, rid := range recipients {
 			if err := r.client.Session.Query(updateCounterQuery, rid.Hex(), convID).Exec(); err != nil {
 				log.Printf("Error incrementing unread count for %s: %v", rid.Hex(), err)
 			}
+			if mentioned[rid] {
+				if err := r.client.Session.Query(updateMentionQuery, rid.Hex(), convID).Exec(); err != nil {
+					log.Printf("Error incrementing mention count for %s: %v", rid.Hex(), err)
+				}
+			}
+			if err := r.recordInboxChange(rid.Hex(), convID); err != nil {
+				log.Printf("Error logging inbox change for %s: %v", rid.Hex(), err)
+			}
 		}
 	}(recipientIDs, conversationID)
 
@@ -209,9 +257,23 @@ func (r *MessageCassandraRepository) GetInbox(ctx context.Context, userID primit
 	          FROM user_inbox WHERE user_id = ? AND is_marketplace = ?`
 
 	iter := r.client.Session.Query(query, userID.Hex(), isMarketplace).Iter()
+	summaries := scanInbox(iter, userID, r.unreadCounts(userID), r.mentionCounts(userID), r.inboxFlags(userID.Hex()))
 
-	// Fetch ALL unread counts for this user in a SINGLE query - O(1) partition read
-	// This eliminates N+1 query problem for scalability
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	r.applyConversationSettings(userID, summaries)
+	r.applyConversationRequests(userID, summaries)
+	sortInbox(summaries)
+
+	log.Printf("[] Successfully retrieved %d conversation summaries for user %s", len(summaries), userID.Hex())
+	return summaries, nil
+}
+
+// unreadCounts fetches ALL unread counts for a user in a SINGLE query - O(1) partition read
+// This eliminates N+1 query problem for scalability
+func (r *MessageCassandraRepository) unreadCounts(userID primitive.ObjectID) map[string]int64 {
 	unreadQuery := `SELECT conversation_id, unread_count FROM conversation_unread WHERE user_id = ?`
 	unreadIter := r.client.Session.Query(unreadQuery, userID.Hex()).Iter()
 
@@ -224,7 +286,29 @@ func (r *MessageCassandraRepository) GetInbox(ctx context.Context, userID primit
 	if err := unreadIter.Close(); err != nil {
 		log.Printf("Error fetching unread counts: %v", err)
 	}
+	return unreadMap
+}
+
+// mentionCounts fetches all of a user's per-conversation mention counts in
+// one partition read
+func (r *MessageCassandraRepository) mentionCounts(userID primitive.ObjectID) map[string]int64 {
+	iter := r.client.Session.Query(`SELECT conversation_id, mention_count FROM conversation_mentions WHERE user_id = ?`, userID.Hex()).Iter()
+
+	mentionMap := make(map[string]int64)
+	var convID string
+	var count int64
+	for iter.Scan(&convID, &count) {
+		mentionMap[convID] = count
+	}
+	if err := iter.Close(); err != nil {
+		log.Printf("Error fetching mention counts: %v", err)
+	}
+	return mentionMap
+}
 
+// scanInbox maps user_inbox rows to summaries with frontend conversation IDs.
+// The iterator must select the columns in the order GetInbox does.
+func scanInbox(iter *gocql.Iter, userID primitive.ObjectID, unreadMap, mentionMap map[string]int64, flagMap map[string]models.ConversationFlags) []models.ConversationSummary {
 	var summaries = []models.ConversationSummary{}
 	var convID, name, avatar, lastMsgContent, lastMsgSenderID, lastMsgSenderName string
 	var isGroup bool
@@ -253,6 +337,8 @@ func (r *MessageCassandraRepository) GetInbox(ctx context.Context, userID primit
 			frontendID = "group-" + convID
 		}
 
+		msgAt := lastMsgAt
+		flags := flagMap[convID]
 		summaries = append(summaries, models.ConversationSummary{
 			ID:                     frontendID,
 			Name:                   name,
@@ -261,18 +347,24 @@ func (r *MessageCassandraRepository) GetInbox(ctx context.Context, userID primit
 			LastMessageContent:     lastMsgContent,
 			LastMessageSenderID:    sid,
 			LastMessageSenderName:  lastMsgSenderName,
-			LastMessageTimestamp:   &lastMsgAt,
+			LastMessageTimestamp:   &msgAt,
 			UnreadCount:            unread,
+			MentionCount:           mentionMap[convID],
 			LastMessageIsEncrypted: false,
+			Pinned:                 flags.Pinned,
+			Archived:               flags.Archived,
+			Muted:                  flags.Muted,
 		})
 	}
+	return summaries
+}
 
-	if err := iter.Close(); err != nil {
-		return nil, err
-	}
-
-	// Sort by last_message_at DESC - O(N log N) where N = user's conversations (typically <100)
+// sortInbox orders summaries pinned first, then by last_message_at DESC - O(N log N) where N = user's conversations (typically <100)
+func sortInbox(summaries []models.ConversationSummary) {
 	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Pinned != summaries[j].Pinned {
+			return summaries[i].Pinned
+		}
 		if summaries[i].LastMessageTimestamp == nil {
 			return false
 		}
@@ -281,9 +373,6 @@ func (r *MessageCassandraRepository) GetInbox(ctx context.Context, userID primit
 		}
 		return summaries[i].LastMessageTimestamp.After(*summaries[j].LastMessageTimestamp)
 	})
-
-	log.Printf("[] Successfully retrieved %d conversation summaries for user %s", len(summaries), userID.Hex())
-	return summaries, nil
 }
 
 // GetMessages retrieves paginated messages for a conversation.
@@ -323,7 +412,7 @@ func (r *MessageCassandraRepository) GetMessages(ctx context.Context, query mode
 
 	// Cassandra optimized pagination uses 'message_id' clustering key (TimeUUID)
 	// Updated columns to include receiver_id, group_id, is_marketplace, product_id, seen_by, delivered_to
-	columns := "message_id, sender_id, receiver_id, group_id, content, created_at, reactions, media_urls, is_marketplace, content_type, product_id, seen_by, delivered_to"
+	columns := "message_id, sender_id, receiver_id, group_id, content, created_at, reactions, reaction_counts, media_urls, is_marketplace, content_type, product_id, seen_by, delivered_to, forwarded_from, offer, link_preview, is_edited, edited_at, reply_to_id"
 	if query.Before == "" {
 		cqlQuery = fmt.Sprintf(`SELECT %s FROM messages WHERE conversation_id = ? LIMIT ?`, columns)
 		iter = r.client.Session.Query(cqlQuery, conversationID, limit).Iter()
@@ -346,14 +435,17 @@ func (r *MessageCassandraRepository) GetMessages(ctx context.Context, query mode
 
 	// 3. Scan Results
 	var messages []models.Message
-	var sID, rID, gID, content, reactions, contentType, productID string
+	var sID, rID, gID, content, reactions, contentType, productID, forwardedFrom, offer, linkPreview, replyToID string
 	var msgUUID gocql.UUID
 	var createdAt time.Time
 	var mediaUrls []string
 	var isMarketplace bool
 	var seenByStr, deliveredToStr []string
+	var reactionCounts map[string]int
+	var isEdited bool
+	var editedAt time.Time
 
-	for iter.Scan(&msgUUID, &sID, &rID, &gID, &content, &createdAt, &reactions, &mediaUrls, &isMarketplace, &contentType, &productID, &seenByStr, &deliveredToStr) {
+	for iter.Scan(&msgUUID, &sID, &rID, &gID, &content, &createdAt, &reactions, &reactionCounts, &mediaUrls, &isMarketplace, &contentType, &productID, &seenByStr, &deliveredToStr, &forwardedFrom, &offer, &linkPreview, &isEdited, &editedAt, &replyToID) {
 		sid, _ := primitive.ObjectIDFromHex(sID)
 
 		var rid, gid primitive.ObjectID
@@ -398,6 +490,7 @@ func (r *MessageCassandraRepository) GetMessages(ctx context.Context, query mode
 			continue
 		}
 
+		forward := parseForwardedFrom(forwardedFrom)
 		messages = append(messages, models.Message{
 			ID:            primitive.NewObjectID(), // Placeholder
 			StringID:      msgUUID.String(),
@@ -407,12 +500,20 @@ func (r *MessageCassandraRepository) GetMessages(ctx context.Context, query mode
 			Content:       content,
 			ContentType:   contentType,
 			CreatedAt:     createdAt,
-			Reactions:     parsedReactions,
-			MediaURLs:     mediaUrls,
-			IsMarketplace: isMarketplace,
-			ProductID:     pid,
-			SeenBy:        seenBy,
-			DeliveredTo:   deliveredTo,
+			Reactions:      parsedReactions,
+			ReactionCounts: reactionCounts,
+			MediaURLs:      mediaUrls,
+			IsMarketplace:  isMarketplace,
+			ProductID:      pid,
+			SeenBy:         seenBy,
+			DeliveredTo:    deliveredTo,
+			IsForwarded:    forward != nil,
+			ForwardedFrom:  forward,
+			Offer:          parseOffer(offer),
+			LinkPreview:    parseLinkPreview(linkPreview),
+			IsEdited:       isEdited,
+			EditedAt:       editedAtPtr(isEdited, editedAt),
+			ReplyToID:      replyToID,
 		})
 	}
 
@@ -460,10 +561,12 @@ func (r *MessageCassandraRepository) GetMessages(ctx context.Context, query mode
 
 					// Apply metadata if available
 					var parsedReactions []models.MessageReaction
+					var reactionCounts map[string]int
 					if meta, ok := metaMap[archived.MessageID]; ok {
 						if meta.Reactions != "" {
 							_ = json.Unmarshal([]byte(meta.Reactions), &parsedReactions)
 						}
+						reactionCounts = meta.ReactionCounts
 						if meta.IsDeleted {
 							continue // Skip deleted messages
 						}
@@ -477,18 +580,25 @@ func (r *MessageCassandraRepository) GetMessages(ctx context.Context, query mode
 						}
 					}
 
+					forward := parseForwardedFrom(archived.ForwardedFrom)
 					messages = append(messages, models.Message{
-						ID:          primitive.NewObjectID(),
-						StringID:    archived.MessageID,
-						SenderID:    sid,
-						ReceiverID:  rid,
-						GroupID:     gid,
-						Content:     archived.Content,
-						ContentType: archived.ContentType,
-						CreatedAt:   createdAt,
-						Reactions:   parsedReactions,
-						MediaURLs:   archived.MediaURLs,
-						ProductID:   pid,
+						ID:            primitive.NewObjectID(),
+						StringID:      archived.MessageID,
+						SenderID:      sid,
+						ReceiverID:    rid,
+						GroupID:       gid,
+						Content:       archived.Content,
+						ContentType:   archived.ContentType,
+						CreatedAt:     createdAt,
+						Reactions:      parsedReactions,
+						ReactionCounts: reactionCounts,
+						MediaURLs:      archived.MediaURLs,
+						ProductID:      pid,
+						IsForwarded:    forward != nil,
+						ForwardedFrom:  forward,
+						Offer:          parseOffer(archived.Offer),
+						LinkPreview:    parseLinkPreview(archived.LinkPreview),
+						ReplyToID:      archived.ReplyToID,
 					})
 				}
 
@@ -545,7 +655,24 @@ func (r *MessageCassandraRepository) MarkConversationAsSeen(ctx context.Context,
 	}
 
 	query := `DELETE FROM conversation_unread WHERE user_id = ? AND conversation_id = ?`
-	return r.client.Session.Query(query, userID.Hex(), conversationID).Exec()
+	if err := r.client.Session.Query(query, userID.Hex(), conversationID).Exec(); err != nil {
+		return err
+	}
+	return r.recordInboxChange(userID.Hex(), conversationID)
+}
+
+// ClearMentions resets the user's mention count for a conversation, leaving
+// its unread count alone
+func (r *MessageCassandraRepository) ClearMentions(ctx context.Context, userID primitive.ObjectID, conversationID string) error {
+	if r.client == nil || r.client.Session == nil {
+		return fmt.Errorf("cassandra client not initialized")
+	}
+
+	query := `DELETE FROM conversation_mentions WHERE user_id = ? AND conversation_id = ?`
+	if err := r.client.Session.Query(query, userID.Hex(), conversationID).WithContext(ctx).Exec(); err != nil {
+		return err
+	}
+	return r.recordInboxChange(userID.Hex(), conversationID)
 }
 
 // MarkMessagesAsSeen updates the is_read flag and adds user to seen_by for specific messages
@@ -630,6 +757,158 @@ func getStrID(id *primitive.ObjectID) string {
 	return id.Hex()
 }
 
+// GetMessage reads one message of a conversation. Deleted messages are
+// reported as not found.
+func (r *MessageCassandraRepository) GetMessage(ctx context.Context, conversationID string, messageID string) (*models.Message, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+
+	uuid, err := gocql.ParseUUID(messageID)
+	if err != nil {
+		return nil, ErrMessageNotFound
+	}
+
+	const query = `SELECT sender_id, receiver_id, group_id, content, content_type, media_urls, 
+		is_marketplace, product_id, created_at, is_deleted, forwarded_from, offer, reaction_counts, 
+		is_edited, edited_at, reply_to_id, link_preview 
+		FROM messages WHERE conversation_id = ? AND message_id = ?`
+
+	var sID, rID, gID, content, contentType, productID, forwardedFrom, offer, replyToID, linkPreview string
+	var mediaURLs []string
+	var isMarketplace, isDeleted, isEdited bool
+	var createdAt, editedAt time.Time
+	var reactionCounts map[string]int
+	err = r.client.Session.Query(query, conversationID, uuid).WithContext(ctx).Scan(
+		&sID, &rID, &gID, &content, &contentType, &mediaURLs,
+		&isMarketplace, &productID, &createdAt, &isDeleted, &forwardedFrom, &offer, &reactionCounts,
+		&isEdited, &editedAt, &replyToID, &linkPreview,
+	)
+	if err == gocql.ErrNotFound {
+		return nil, ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if isDeleted {
+		return nil, ErrMessageNotFound
+	}
+
+	msg := &models.Message{
+		StringID:       uuid.String(),
+		Content:        content,
+		ContentType:    contentType,
+		MediaURLs:      mediaURLs,
+		IsMarketplace:  isMarketplace,
+		CreatedAt:      createdAt,
+		ForwardedFrom:  parseForwardedFrom(forwardedFrom),
+		Offer:          parseOffer(offer),
+		LinkPreview:    parseLinkPreview(linkPreview),
+		ReactionCounts: reactionCounts,
+		IsEdited:       isEdited,
+		EditedAt:       editedAtPtr(isEdited, editedAt),
+		ReplyToID:      replyToID,
+	}
+	msg.IsForwarded = msg.ForwardedFrom != nil
+	msg.SenderID, _ = primitive.ObjectIDFromHex(sID)
+	if rID != "" {
+		msg.ReceiverID, _ = primitive.ObjectIDFromHex(rID)
+	}
+	if gID != "" {
+		msg.GroupID, _ = primitive.ObjectIDFromHex(gID)
+	}
+	if productID != "" {
+		if pid, err := primitive.ObjectIDFromHex(productID); err == nil {
+			msg.ProductID = &pid
+		}
+	}
+	return msg, nil
+}
+
+func editedAtPtr(isEdited bool, editedAt time.Time) *time.Time {
+	if !isEdited || editedAt.IsZero() {
+		return nil
+	}
+	return &editedAt
+}
+
+// marshalForwardedFrom encodes forward attribution for the forwarded_from
+// column; messages that were not forwarded store an empty string
+func marshalForwardedFrom(from *models.ForwardedFrom) string {
+	if from == nil {
+		return ""
+	}
+	data, err := json.Marshal(from)
+	if err != nil {
+		log.Printf("Error marshaling forward attribution: %v", err)
+		return ""
+	}
+	return string(data)
+}
+
+func parseForwardedFrom(raw string) *models.ForwardedFrom {
+	if raw == "" {
+		return nil
+	}
+	var from models.ForwardedFrom
+	if err := json.Unmarshal([]byte(raw), &from); err != nil {
+		log.Printf("Error parsing forward attribution: %v", err)
+		return nil
+	}
+	return &from
+}
+
+// marshalOffer encodes an offer card for the offer column; other messages
+// store an empty string
+func marshalOffer(offer *models.MessageOffer) string {
+	if offer == nil {
+		return ""
+	}
+	data, err := json.Marshal(offer)
+	if err != nil {
+		log.Printf("Error marshaling offer card: %v", err)
+		return ""
+	}
+	return string(data)
+}
+
+func parseOffer(raw string) *models.MessageOffer {
+	if raw == "" {
+		return nil
+	}
+	var offer models.MessageOffer
+	if err := json.Unmarshal([]byte(raw), &offer); err != nil {
+		log.Printf("Error parsing offer card: %v", err)
+		return nil
+	}
+	return &offer
+}
+
+// marshalLinkPreview encodes a preview card for the link_preview column
+func marshalLinkPreview(preview *models.LinkPreview) string {
+	if preview == nil {
+		return ""
+	}
+	data, err := json.Marshal(preview)
+	if err != nil {
+		log.Printf("Error marshaling link preview: %v", err)
+		return ""
+	}
+	return string(data)
+}
+
+func parseLinkPreview(raw string) *models.LinkPreview {
+	if raw == "" {
+		return nil
+	}
+	var preview models.LinkPreview
+	if err := json.Unmarshal([]byte(raw), &preview); err != nil {
+		log.Printf("Error parsing link preview: %v", err)
+		return nil
+	}
+	return &preview
+}
+
 // DeleteMessage performs a soft delete on a message
 func (r *MessageCassandraRepository) DeleteMessage(ctx context.Context, conversationID string, messageID string) error {
 	if r.client == nil || r.client.Session == nil {
@@ -641,32 +920,570 @@ func (r *MessageCassandraRepository) DeleteMessage(ctx context.Context, conversa
 		return fmt.Errorf("invalid message UUID: %w", err)
 	}
 
-	query := `UPDATE messages SET is_deleted = true, content = '[Message Deleted]', media_urls = [] WHERE conversation_id = ? AND message_id = ?`
-	return r.client.Session.Query(query, conversationID, uuid).Exec()
+	query := `UPDATE messages SET is_deleted = true, content = '[Message Deleted]', media_urls = [], reaction_counts = {}, link_preview = null WHERE conversation_id = ? AND message_id = ?`
+	if err := r.client.Session.Query(query, conversationID, uuid).Exec(); err != nil {
+		return err
+	}
+	// Reactions, earlier versions and the thread it started go with the message
+	if err := r.client.Session.Query(`DELETE FROM message_reactions WHERE conversation_id = ? AND message_id = ?`, conversationID, uuid).Exec(); err != nil {
+		return err
+	}
+	if err := r.client.Session.Query(`DELETE FROM message_edits WHERE conversation_id = ? AND message_id = ?`, conversationID, uuid).Exec(); err != nil {
+		return err
+	}
+	if err := r.client.Session.Query(`DELETE FROM thread_replies WHERE conversation_id = ? AND parent_id = ?`, conversationID, uuid).Exec(); err != nil {
+		return err
+	}
+	return r.client.Session.Query(`DELETE FROM message_threads WHERE conversation_id = ? AND parent_id = ?`, conversationID, uuid).Exec()
+}
+
+// EditMessage replaces a message's content, provided it still reads
+// previousContent, and keeps previousContent as an earlier version. It
+// reports false, changing nothing, when the message changed in between.
+func (r *MessageCassandraRepository) EditMessage(ctx context.Context, conversationID, messageID, previousContent, newContent, editorID string, at time.Time) (bool, error) {
+	if r.client == nil || r.client.Session == nil {
+		return false, fmt.Errorf("cassandra client not initialized")
+	}
+
+	uuid, err := gocql.ParseUUID(messageID)
+	if err != nil {
+		return false, ErrMessageNotFound
+	}
+
+	// The preview belonged to the old content; the unfurl worker sets a new one
+	query := `UPDATE messages SET content = ?, is_edited = true, edited_at = ?, link_preview = null WHERE conversation_id = ? AND message_id = ? IF content = ?`
+	applied, err := r.client.Session.Query(query, newContent, at, conversationID, uuid, previousContent).
+		WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	if err != nil || !applied {
+		return false, err
+	}
+
+	editQuery := `INSERT INTO message_edits (conversation_id, message_id, edit_id, content, edited_by, edited_at) VALUES (?, ?, ?, ?, ?, ?)`
+	if err := r.client.Session.Query(editQuery, conversationID, uuid, gocql.UUIDFromTime(at), previousContent, editorID, at).
+		WithContext(ctx).Exec(); err != nil {
+		return true, fmt.Errorf("failed to record edit history: %w", err)
+	}
+	return true, nil
 }
 
-// EditMessage updates the content of a message (if not deleted)
-func (r *MessageCassandraRepository) EditMessage(ctx context.Context, conversationID string, messageID string, newContent string) error {
+// SetLinkPreview stores the preview of a message's first link, provided the
+// message still reads content. It reports false when the message was edited
+// or deleted in the meantime. A nil preview clears it.
+func (r *MessageCassandraRepository) SetLinkPreview(ctx context.Context, conversationID, messageID, content string, preview *models.LinkPreview) (bool, error) {
 	if r.client == nil || r.client.Session == nil {
-		return fmt.Errorf("cassandra client not initialized")
+		return false, fmt.Errorf("cassandra client not initialized")
 	}
 
 	uuid, err := gocql.ParseUUID(messageID)
 	if err != nil {
-		return fmt.Errorf("invalid message UUID: %w", err)
+		return false, ErrMessageNotFound
+	}
+
+	query := `UPDATE messages SET link_preview = ? WHERE conversation_id = ? AND message_id = ? IF content = ?`
+	return r.client.Session.Query(query, marshalLinkPreview(preview), conversationID, uuid, content).
+		WithContext(ctx).MapScanCAS(map[string]interface{}{})
+}
+
+// GetEditHistory lists a message's earlier versions, oldest first
+func (r *MessageCassandraRepository) GetEditHistory(ctx context.Context, conversationID, messageID string) ([]models.MessageEdit, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+
+	uuid, err := gocql.ParseUUID(messageID)
+	if err != nil {
+		return nil, ErrMessageNotFound
+	}
+
+	query := `SELECT content, edited_by, edited_at FROM message_edits WHERE conversation_id = ? AND message_id = ?`
+	iter := r.client.Session.Query(query, conversationID, uuid).WithContext(ctx).Iter()
+
+	edits := []models.MessageEdit{}
+	var edit models.MessageEdit
+	for iter.Scan(&edit.Content, &edit.EditedBy, &edit.EditedAt) {
+		edits = append(edits, edit)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return edits, nil
+}
+
+// PinMessage pins a message to its conversation, which may hold at most
+// limit pins. It reports false when the message was already pinned.
+//
+// The pin is written first and the conversation's pins counted after, so
+// concurrent pins cannot both slip under the limit: a pin that finds the
+// conversation over the limit is rolled back and ErrPinLimitReached returned.
+// Racing pins may then all lose, but the limit is never exceeded.
+func (r *MessageCassandraRepository) PinMessage(ctx context.Context, pin models.PinnedMessage, limit int) (bool, error) {
+	if r.client == nil || r.client.Session == nil {
+		return false, fmt.Errorf("cassandra client not initialized")
+	}
+
+	uuid, err := gocql.ParseUUID(pin.MessageID)
+	if err != nil {
+		return false, ErrMessageNotFound
+	}
+
+	query := `INSERT INTO pinned_messages (conversation_id, message_id, pinned_by, pinned_at) VALUES (?, ?, ?, ?) IF NOT EXISTS`
+	applied, err := r.client.Session.Query(query, pin.ConversationID, uuid, pin.PinnedBy, pin.PinnedAt).
+		WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	if err != nil || !applied {
+		return applied, err
+	}
+
+	pins, err := r.GetPinnedMessages(ctx, pin.ConversationID)
+	if err == nil && len(pins) <= limit {
+		return true, nil
+	}
+	if _, rollbackErr := r.UnpinMessage(ctx, pin.ConversationID, pin.MessageID); rollbackErr != nil {
+		return false, fmt.Errorf("failed to roll back pin over the limit: %w", rollbackErr)
+	}
+	if err != nil {
+		return false, err
+	}
+	return false, ErrPinLimitReached
+}
+
+// UnpinMessage removes a message from its conversation's pins. It reports
+// false when the message was not pinned.
+func (r *MessageCassandraRepository) UnpinMessage(ctx context.Context, conversationID string, messageID string) (bool, error) {
+	if r.client == nil || r.client.Session == nil {
+		return false, fmt.Errorf("cassandra client not initialized")
+	}
+
+	uuid, err := gocql.ParseUUID(messageID)
+	if err != nil {
+		return false, nil
+	}
+
+	query := `DELETE FROM pinned_messages WHERE conversation_id = ? AND message_id = ? IF EXISTS`
+	return r.client.Session.Query(query, conversationID, uuid).
+		WithContext(ctx).MapScanCAS(map[string]interface{}{})
+}
+
+// GetPinnedMessages lists a conversation's pins, newest message first
+func (r *MessageCassandraRepository) GetPinnedMessages(ctx context.Context, conversationID string) ([]models.PinnedMessage, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+
+	query := `SELECT message_id, pinned_by, pinned_at FROM pinned_messages WHERE conversation_id = ?`
+	iter := r.client.Session.Query(query, conversationID).WithContext(ctx).Iter()
+
+	pins := []models.PinnedMessage{}
+	var messageID gocql.UUID
+	var pinnedBy string
+	var pinnedAt time.Time
+	for iter.Scan(&messageID, &pinnedBy, &pinnedAt) {
+		pins = append(pins, models.PinnedMessage{
+			ConversationID: conversationID,
+			MessageID:      messageID.String(),
+			PinnedBy:       pinnedBy,
+			PinnedAt:       pinnedAt,
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return pins, nil
+}
+
+// AddReaction records a user's emoji reaction to a message and updates the
+// message's tally. It reports false, changing nothing, when the user had
+// already reacted with that emoji.
+func (r *MessageCassandraRepository) AddReaction(ctx context.Context, conversationID, messageID, userID, emoji string, at time.Time) (bool, error) {
+	if r.client == nil || r.client.Session == nil {
+		return false, fmt.Errorf("cassandra client not initialized")
+	}
+
+	uuid, err := gocql.ParseUUID(messageID)
+	if err != nil {
+		return false, ErrMessageNotFound
+	}
+
+	query := `INSERT INTO message_reactions (conversation_id, message_id, emoji, user_id, reacted_at) VALUES (?, ?, ?, ?, ?) IF NOT EXISTS`
+	applied, err := r.client.Session.Query(query, conversationID, uuid, emoji, userID, at).
+		WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	if err != nil || !applied {
+		return false, err
+	}
+	return true, r.syncReactionCount(ctx, conversationID, uuid, emoji)
+}
+
+// RemoveReaction takes back a user's emoji reaction and updates the
+// message's tally. It reports false when there was no such reaction.
+func (r *MessageCassandraRepository) RemoveReaction(ctx context.Context, conversationID, messageID, userID, emoji string) (bool, error) {
+	if r.client == nil || r.client.Session == nil {
+		return false, fmt.Errorf("cassandra client not initialized")
+	}
+
+	uuid, err := gocql.ParseUUID(messageID)
+	if err != nil {
+		return false, nil
+	}
+
+	query := `DELETE FROM message_reactions WHERE conversation_id = ? AND message_id = ? AND emoji = ? AND user_id = ? IF EXISTS`
+	applied, err := r.client.Session.Query(query, conversationID, uuid, emoji, userID).
+		WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	if err != nil || !applied {
+		return false, err
+	}
+	return true, r.syncReactionCount(ctx, conversationID, uuid, emoji)
+}
+
+// syncReactionCount recounts one emoji's reactions into the message row.
+// Recounting rather than adding one lets the next change to an emoji repair
+// a count that a concurrent change left stale.
+func (r *MessageCassandraRepository) syncReactionCount(ctx context.Context, conversationID string, messageID gocql.UUID, emoji string) error {
+	var count int
+	countQuery := `SELECT COUNT(*) FROM message_reactions WHERE conversation_id = ? AND message_id = ? AND emoji = ?`
+	if err := r.client.Session.Query(countQuery, conversationID, messageID, emoji).WithContext(ctx).Scan(&count); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		query := `DELETE reaction_counts[?] FROM messages WHERE conversation_id = ? AND message_id = ?`
+		return r.client.Session.Query(query, emoji, conversationID, messageID).WithContext(ctx).Exec()
+	}
+	query := `UPDATE messages SET reaction_counts[?] = ? WHERE conversation_id = ? AND message_id = ?`
+	return r.client.Session.Query(query, emoji, count, conversationID, messageID).WithContext(ctx).Exec()
+}
+
+// GetReactionCounts returns a message's reactions per emoji
+func (r *MessageCassandraRepository) GetReactionCounts(ctx context.Context, conversationID, messageID string) (map[string]int, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+
+	uuid, err := gocql.ParseUUID(messageID)
+	if err != nil {
+		return nil, ErrMessageNotFound
+	}
+
+	var counts map[string]int
+	query := `SELECT reaction_counts FROM messages WHERE conversation_id = ? AND message_id = ?`
+	err = r.client.Session.Query(query, conversationID, uuid).WithContext(ctx).Scan(&counts)
+	if err == gocql.ErrNotFound {
+		return nil, ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if counts == nil {
+		counts = map[string]int{}
+	}
+	return counts, nil
+}
+
+// AddThreadReply files a reply under the thread of its parent message and
+// returns the thread's summary afterwards
+func (r *MessageCassandraRepository) AddThreadReply(ctx context.Context, conversationID, parentID, replyID, senderID string) (*models.ThreadSummary, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
 	}
 
-	query := `UPDATE messages SET content = ?, is_edited = true WHERE conversation_id = ? AND message_id = ?`
-	return r.client.Session.Query(query, newContent, conversationID, uuid).Exec()
+	parentUUID, err := gocql.ParseUUID(parentID)
+	if err != nil {
+		return nil, ErrMessageNotFound
+	}
+	replyUUID, err := gocql.ParseUUID(replyID)
+	if err != nil {
+		return nil, ErrMessageNotFound
+	}
+
+	query := `INSERT INTO thread_replies (conversation_id, parent_id, message_id, sender_id) VALUES (?, ?, ?, ?)`
+	if err := r.client.Session.Query(query, conversationID, parentUUID, replyUUID, senderID).WithContext(ctx).Exec(); err != nil {
+		return nil, err
+	}
+	return r.syncThread(ctx, conversationID, parentUUID)
+}
+
+// RemoveThreadReply takes a deleted reply out of its thread and returns the
+// thread's summary afterwards
+func (r *MessageCassandraRepository) RemoveThreadReply(ctx context.Context, conversationID, parentID, replyID string) (*models.ThreadSummary, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+
+	parentUUID, err := gocql.ParseUUID(parentID)
+	if err != nil {
+		return nil, ErrMessageNotFound
+	}
+	replyUUID, err := gocql.ParseUUID(replyID)
+	if err != nil {
+		return nil, ErrMessageNotFound
+	}
+
+	query := `DELETE FROM thread_replies WHERE conversation_id = ? AND parent_id = ? AND message_id = ?`
+	if err := r.client.Session.Query(query, conversationID, parentUUID, replyUUID).WithContext(ctx).Exec(); err != nil {
+		return nil, err
+	}
+	return r.syncThread(ctx, conversationID, parentUUID)
+}
+
+// syncThread rebuilds a thread's summary from its replies. Like reaction
+// counts, recounting lets the next reply repair a summary that a concurrent
+// change left stale.
+func (r *MessageCassandraRepository) syncThread(ctx context.Context, conversationID string, parentID gocql.UUID) (*models.ThreadSummary, error) {
+	summary := &models.ThreadSummary{ParentID: parentID.String(), LastReplierIDs: []string{}}
+
+	countQuery := `SELECT COUNT(*) FROM thread_replies WHERE conversation_id = ? AND parent_id = ?`
+	if err := r.client.Session.Query(countQuery, conversationID, parentID).WithContext(ctx).Scan(&summary.ReplyCount); err != nil {
+		return nil, err
+	}
+	if summary.ReplyCount == 0 {
+		query := `DELETE FROM message_threads WHERE conversation_id = ? AND parent_id = ?`
+		return summary, r.client.Session.Query(query, conversationID, parentID).WithContext(ctx).Exec()
+	}
+
+	// The latest replies name the recent repliers; a busy thread may need a
+	// few replies per distinct replier
+	recentQuery := `SELECT message_id, sender_id FROM thread_replies WHERE conversation_id = ? AND parent_id = ? ORDER BY message_id DESC LIMIT ?`
+	iter := r.client.Session.Query(recentQuery, conversationID, parentID, models.MaxThreadRepliers*10).WithContext(ctx).Iter()
+	var replyID gocql.UUID
+	var senderID string
+	seen := make(map[string]bool)
+	for iter.Scan(&replyID, &senderID) {
+		if summary.LastReplyAt == nil {
+			at := replyID.Time()
+			summary.LastReplyAt = &at
+		}
+		if !seen[senderID] && len(summary.LastReplierIDs) < models.MaxThreadRepliers {
+			seen[senderID] = true
+			summary.LastReplierIDs = append(summary.LastReplierIDs, senderID)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO message_threads (conversation_id, parent_id, reply_count, last_reply_at, last_replier_ids) VALUES (?, ?, ?, ?, ?)`
+	var lastReplyAt time.Time
+	if summary.LastReplyAt != nil {
+		lastReplyAt = *summary.LastReplyAt
+	}
+	if err := r.client.Session.Query(query, conversationID, parentID, summary.ReplyCount, lastReplyAt, summary.LastReplierIDs).WithContext(ctx).Exec(); err != nil {
+		return nil, err
+	}
+	return summary, nil
 }
 
-// SearchMessages performs a basic search (Limited Support in Cassandra)
-func (r *MessageCassandraRepository) SearchMessages(ctx context.Context, userID primitive.ObjectID, query string, page, limit int64) ([]models.Message, error) {
-	// Full text search requires SASI or external index.
-	// For migration compliance, we return empty list or log warning.
-	// Implementing exact match on content using ALLOW FILTERING (Inefficient - Dev only) or just stub.
-	log.Println("WARNING: SearchMessages is not fully supported in Cassandra mode. Returning empty results.")
-	return []models.Message{}, nil
+// GetThreadSummaries returns the summaries of the threads started by the
+// given messages. Messages without replies are left out.
+func (r *MessageCassandraRepository) GetThreadSummaries(ctx context.Context, conversationID string, parentIDs []string) (map[string]models.ThreadSummary, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+
+	uuids := make([]gocql.UUID, 0, len(parentIDs))
+	for _, id := range parentIDs {
+		if uuid, err := gocql.ParseUUID(id); err == nil {
+			uuids = append(uuids, uuid)
+		}
+	}
+	summaries := make(map[string]models.ThreadSummary)
+	if len(uuids) == 0 {
+		return summaries, nil
+	}
+
+	query := `SELECT parent_id, reply_count, last_reply_at, last_replier_ids FROM message_threads WHERE conversation_id = ? AND parent_id IN ?`
+	iter := r.client.Session.Query(query, conversationID, uuids).WithContext(ctx).Iter()
+	var parentID gocql.UUID
+	var count int
+	var lastReplyAt time.Time
+	var repliers []string
+	for iter.Scan(&parentID, &count, &lastReplyAt, &repliers) {
+		summary := models.ThreadSummary{ParentID: parentID.String(), ReplyCount: count, LastReplierIDs: repliers}
+		if !lastReplyAt.IsZero() {
+			at := lastReplyAt
+			summary.LastReplyAt = &at
+		}
+		if summary.LastReplierIDs == nil {
+			summary.LastReplierIDs = []string{}
+		}
+		summaries[summary.ParentID] = summary
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// GetThreadReplies returns up to limit replies of a thread posted after the
+// reply with ID after, oldest first, and whether more follow. An empty after
+// starts from the first reply.
+func (r *MessageCassandraRepository) GetThreadReplies(ctx context.Context, conversationID, parentID, after string, limit int) ([]models.Message, bool, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, false, fmt.Errorf("cassandra client not initialized")
+	}
+
+	parentUUID, err := gocql.ParseUUID(parentID)
+	if err != nil {
+		return nil, false, ErrMessageNotFound
+	}
+
+	var iter *gocql.Iter
+	if after == "" {
+		query := `SELECT message_id FROM thread_replies WHERE conversation_id = ? AND parent_id = ? LIMIT ?`
+		iter = r.client.Session.Query(query, conversationID, parentUUID, limit+1).WithContext(ctx).Iter()
+	} else {
+		afterUUID, err := gocql.ParseUUID(after)
+		if err != nil {
+			return nil, false, ErrInvalidThreadCursor
+		}
+		query := `SELECT message_id FROM thread_replies WHERE conversation_id = ? AND parent_id = ? AND message_id > ? LIMIT ?`
+		iter = r.client.Session.Query(query, conversationID, parentUUID, afterUUID, limit+1).WithContext(ctx).Iter()
+	}
+
+	var replyIDs []string
+	var replyID gocql.UUID
+	for iter.Scan(&replyID) {
+		replyIDs = append(replyIDs, replyID.String())
+	}
+	if err := iter.Close(); err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(replyIDs) > limit
+	if hasMore {
+		replyIDs = replyIDs[:limit]
+	}
+	replies := make([]models.Message, 0, len(replyIDs))
+	for _, id := range replyIDs {
+		msg, err := r.GetMessage(ctx, conversationID, id)
+		if errors.Is(err, ErrMessageNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		replies = append(replies, *msg)
+	}
+	return replies, hasMore, nil
+}
+
+// GetRetention returns a conversation's retention policy. Conversations
+// without one keep their messages forever.
+func (r *MessageCassandraRepository) GetRetention(ctx context.Context, conversationID string) (*models.ConversationRetention, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+
+	retention := &models.ConversationRetention{ConversationID: conversationID}
+	var updatedAt time.Time
+	query := `SELECT retention_days, updated_by, updated_at FROM conversation_retention WHERE conversation_id = ?`
+	err := r.client.Session.Query(query, conversationID).WithContext(ctx).
+		Scan(&retention.RetentionDays, &retention.UpdatedBy, &updatedAt)
+	if err == gocql.ErrNotFound {
+		return retention, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !updatedAt.IsZero() {
+		retention.UpdatedAt = &updatedAt
+	}
+	return retention, nil
+}
+
+// SetRetention saves a conversation's retention policy
+func (r *MessageCassandraRepository) SetRetention(ctx context.Context, retention models.ConversationRetention) error {
+	if r.client == nil || r.client.Session == nil {
+		return fmt.Errorf("cassandra client not initialized")
+	}
+
+	var updatedAt time.Time
+	if retention.UpdatedAt != nil {
+		updatedAt = *retention.UpdatedAt
+	}
+	query := `INSERT INTO conversation_retention (conversation_id, retention_days, updated_by, updated_at) VALUES (?, ?, ?, ?)`
+	return r.client.Session.Query(query, retention.ConversationID, retention.RetentionDays, retention.UpdatedBy, updatedAt).
+		WithContext(ctx).Exec()
+}
+
+// SaveDraft stores a user's draft of a conversation
+func (r *MessageCassandraRepository) SaveDraft(ctx context.Context, userID string, draft models.MessageDraft) error {
+	if r.client == nil || r.client.Session == nil {
+		return fmt.Errorf("cassandra client not initialized")
+	}
+
+	query := `INSERT INTO message_drafts (user_id, conversation_id, content, reply_to_id, device_id, updated_at) VALUES (?, ?, ?, ?, ?, ?)`
+	return r.client.Session.Query(query, userID, draft.ConversationID, draft.Content, draft.ReplyToID, draft.DeviceID, draft.UpdatedAt).
+		WithContext(ctx).Exec()
+}
+
+// GetDraft returns a user's draft of a conversation, or nil when there is none
+func (r *MessageCassandraRepository) GetDraft(ctx context.Context, userID, conversationID string) (*models.MessageDraft, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+
+	draft := &models.MessageDraft{ConversationID: conversationID}
+	query := `SELECT content, reply_to_id, device_id, updated_at FROM message_drafts WHERE user_id = ? AND conversation_id = ?`
+	err := r.client.Session.Query(query, userID, conversationID).WithContext(ctx).
+		Scan(&draft.Content, &draft.ReplyToID, &draft.DeviceID, &draft.UpdatedAt)
+	if err == gocql.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// ListDrafts returns all of a user's drafts
+func (r *MessageCassandraRepository) ListDrafts(ctx context.Context, userID string) ([]models.MessageDraft, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+
+	query := `SELECT conversation_id, content, reply_to_id, device_id, updated_at FROM message_drafts WHERE user_id = ?`
+	iter := r.client.Session.Query(query, userID).WithContext(ctx).Iter()
+	drafts := []models.MessageDraft{}
+	var draft models.MessageDraft
+	for iter.Scan(&draft.ConversationID, &draft.Content, &draft.ReplyToID, &draft.DeviceID, &draft.UpdatedAt) {
+		drafts = append(drafts, draft)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return drafts, nil
+}
+
+// DeleteDraft removes a user's draft of a conversation
+func (r *MessageCassandraRepository) DeleteDraft(ctx context.Context, userID, conversationID string) error {
+	if r.client == nil || r.client.Session == nil {
+		return fmt.Errorf("cassandra client not initialized")
+	}
+
+	query := `DELETE FROM message_drafts WHERE user_id = ? AND conversation_id = ?`
+	return r.client.Session.Query(query, userID, conversationID).WithContext(ctx).Exec()
+}
+
+// DeleteUserDrafts removes all of a user's drafts
+func (r *MessageCassandraRepository) DeleteUserDrafts(ctx context.Context, userID string) error {
+	if r.client == nil || r.client.Session == nil {
+		return fmt.Errorf("cassandra client not initialized")
+	}
+
+	return r.client.Session.Query(`DELETE FROM message_drafts WHERE user_id = ?`, userID).WithContext(ctx).Exec()
+}
+
+// SearchMessages runs a full-text search over the direct messages of userID and
+// the groups in groupIDs. Without a search index it returns no results.
+func (r *MessageCassandraRepository) SearchMessages(ctx context.Context, userID primitive.ObjectID, groupIDs []primitive.ObjectID, query models.MessageSearchQuery) ([]models.MessageSearchResult, error) {
+	if r.searcher == nil {
+		log.Println("WARNING: SearchMessages requires a search index. Returning empty results.")
+		return []models.MessageSearchResult{}, nil
+	}
+
+	groups := make([]string, len(groupIDs))
+	for i, id := range groupIDs {
+		groups[i] = id.Hex()
+	}
+	return r.searcher.Search(ctx, userID.Hex(), groups, query)
 }
 
 // GetMarketplacePartnerIDs returns unique user IDs from marketplace conversations for presence broadcasting
@@ -147,6 +147,7 @@ func (r *MessageRepository) GetMessages(ctx context.Context, query models.Messag
 				"avatar":    "$sender_info.avatar",
 				"full_name": "$sender_info.full_name",
 				"bio":       "$sender_info.bio",
+				"verified":  "$sender_info.verified",
 			},
 			"receiver_id":         1,
 			"group_id":            1,
@@ -465,53 +466,6 @@ func (r *MessageRepository) SearchMessages(ctx context.Context, userID primitive
 	return messages, nil
 }
 
-// AddReaction adds a reaction to a message
-func (r *MessageRepository) AddReaction(ctx context.Context, messageID, userID primitive.ObjectID, emoji string) error {
-	filter := bson.M{"_id": messageID, "reactions.user_id": bson.M{"$ne": userID}, "reactions.emoji": bson.M{"$ne": emoji}}
-	update := bson.M{
-		"$push": bson.M{
-			"reactions": models.MessageReaction{
-				UserID:    userID,
-				Emoji:     emoji,
-				Timestamp: time.Now(),
-			},
-		},
-		"$set": bson.M{"updated_at": time.Now()},
-	}
-
-	res, err := r.collection.UpdateOne(ctx, filter, update)
-	if err != nil {
-		return fmt.Errorf("failed to add reaction: %w", err)
-	}
-	if res.ModifiedCount == 0 {
-		return errors.New("message not found or reaction already exists")
-	}
-	return nil
-}
-
-// RemoveReaction removes a reaction from a message
-func (r *MessageRepository) RemoveReaction(ctx context.Context, messageID, userID primitive.ObjectID, emoji string) error {
-	filter := bson.M{"_id": messageID}
-	update := bson.M{
-		"$pull": bson.M{
-			"reactions": bson.M{
-				"user_id": userID,
-				"emoji":   emoji,
-			},
-		},
-		"$set": bson.M{"updated_at": time.Now()},
-	}
-
-	res, err := r.collection.UpdateOne(ctx, filter, update)
-	if err != nil {
-		return fmt.Errorf("failed to remove reaction: %w", err)
-	}
-	if res.ModifiedCount == 0 {
-		return errors.New("message not found or reaction not present")
-	}
-	return nil
-}
-
 func (r *MessageRepository) GetMessageByID(ctx context.Context, messageID primitive.ObjectID) (*models.Message, error) {
 	var message models.Message
 	err := r.collection.FindOne(ctx, bson.M{"_id": messageID}).Decode(&message)
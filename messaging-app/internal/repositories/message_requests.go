@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gocql/gocql"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateConversationRequest puts a direct conversation in the user's message
+// requests unless it has been requested before, accepted or not. It reports
+// whether the request was created.
+func (r *MessageCassandraRepository) CreateConversationRequest(ctx context.Context, userID string, request models.ConversationRequest) (bool, error) {
+	if r.client == nil || r.client.Session == nil {
+		return false, fmt.Errorf("cassandra client not initialized")
+	}
+
+	applied, err := r.client.Session.Query(`INSERT INTO message_requests (user_id, conversation_id, sender_id, accepted, created_at) VALUES (?, ?, ?, ?, ?) IF NOT EXISTS`,
+		userID, request.ConversationID, request.SenderID, false, request.CreatedAt).
+		WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	if err != nil || !applied {
+		return false, err
+	}
+	if err := r.recordInboxChange(userID, request.ConversationID); err != nil {
+		log.Printf("Error logging inbox change for %s: %v", userID, err)
+	}
+	return true, nil
+}
+
+// GetConversationRequest returns the user's request for a conversation, or
+// nil when there is none
+func (r *MessageCassandraRepository) GetConversationRequest(ctx context.Context, userID, conversationID string) (*models.ConversationRequest, error) {
+	if r.client == nil || r.client.Session == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+
+	request := &models.ConversationRequest{ConversationID: conversationID}
+	err := r.client.Session.Query(`SELECT sender_id, accepted, created_at FROM message_requests WHERE user_id = ? AND conversation_id = ?`, userID, conversationID).
+		WithContext(ctx).Scan(&request.SenderID, &request.Accepted, &request.CreatedAt)
+	if err == gocql.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// AcceptConversationRequest moves a requested conversation to the user's inbox
+func (r *MessageCassandraRepository) AcceptConversationRequest(ctx context.Context, userID, conversationID string) error {
+	if r.client == nil || r.client.Session == nil {
+		return fmt.Errorf("cassandra client not initialized")
+	}
+
+	batch := r.client.Session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`UPDATE message_requests SET accepted = true WHERE user_id = ? AND conversation_id = ?`, userID, conversationID)
+	AppendInboxChange(batch, userID, conversationID)
+	return r.client.Session.ExecuteBatch(batch)
+}
+
+// DeleteConversationRequest removes a conversation from the user's message
+// requests
+func (r *MessageCassandraRepository) DeleteConversationRequest(ctx context.Context, userID, conversationID string) error {
+	if r.client == nil || r.client.Session == nil {
+		return fmt.Errorf("cassandra client not initialized")
+	}
+
+	batch := r.client.Session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`DELETE FROM message_requests WHERE user_id = ? AND conversation_id = ?`, userID, conversationID)
+	AppendInboxChange(batch, userID, conversationID)
+	return r.client.Session.ExecuteBatch(batch)
+}
+
+// applyConversationRequests marks the conversations still in the user's
+// message requests. Inbox reads go on without the marks rather than fail.
+func (r *MessageCassandraRepository) applyConversationRequests(userID primitive.ObjectID, summaries []models.ConversationSummary) {
+	if len(summaries) == 0 {
+		return
+	}
+
+	iter := r.client.Session.Query(`SELECT conversation_id, accepted FROM message_requests WHERE user_id = ?`, userID.Hex()).Iter()
+	pending := make(map[string]bool)
+	var conversationID string
+	var accepted bool
+	for iter.Scan(&conversationID, &accepted) {
+		if !accepted {
+			pending[conversationID] = true
+		}
+	}
+	if err := iter.Close(); err != nil {
+		log.Printf("Error fetching message requests: %v", err)
+		return
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	for i := range summaries {
+		if !summaries[i].IsGroup && pending[inboxConversationID(userID, summaries[i].ID)] {
+			summaries[i].Request = true
+		}
+	}
+}
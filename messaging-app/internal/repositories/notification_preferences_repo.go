@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NotificationPreferencesRepository stores one preferences document per user,
+// keyed by user ID
+type NotificationPreferencesRepository struct {
+	db *mongo.Database
+}
+
+func NewNotificationPreferencesRepository(db *mongo.Database) *NotificationPreferencesRepository {
+	return &NotificationPreferencesRepository{db: db}
+}
+
+func (r *NotificationPreferencesRepository) collection() *mongo.Collection {
+	return r.db.Collection("notification_preferences")
+}
+
+// Get returns the user's preferences. Users who never set any get empty
+// preferences, which mute nothing.
+func (r *NotificationPreferencesRepository) Get(ctx context.Context, userID primitive.ObjectID) (*models.NotificationPreferences, error) {
+	var prefs models.NotificationPreferences
+	err := r.collection().FindOne(ctx, bson.M{"_id": userID}).Decode(&prefs)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		prefs = models.NotificationPreferences{UserID: userID}
+	} else if err != nil {
+		return nil, err
+	}
+	if prefs.MutedConversations == nil {
+		prefs.MutedConversations = []models.MutedConversation{}
+	}
+	if prefs.MutedTypes == nil {
+		prefs.MutedTypes = []models.NotificationType{}
+	}
+	return &prefs, nil
+}
+
+// Update replaces the user's muted types and quiet hours, leaving muted
+// conversations as they are
+func (r *NotificationPreferencesRepository) Update(ctx context.Context, userID primitive.ObjectID, mutedTypes []models.NotificationType, quietHours *models.QuietHours) error {
+	if mutedTypes == nil {
+		mutedTypes = []models.NotificationType{}
+	}
+	update := bson.M{
+		"$set": bson.M{"muted_types": mutedTypes, "updated_at": time.Now()},
+	}
+	if quietHours != nil {
+		update["$set"].(bson.M)["quiet_hours"] = quietHours
+	} else {
+		update["$unset"] = bson.M{"quiet_hours": ""}
+	}
+	_, err := r.collection().UpdateOne(ctx, bson.M{"_id": userID}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// SetDoNotDisturb replaces the user's Do Not Disturb settings; nil removes them
+func (r *NotificationPreferencesRepository) SetDoNotDisturb(ctx context.Context, userID primitive.ObjectID, dnd *models.DoNotDisturb) error {
+	update := bson.M{
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+	if dnd != nil {
+		update["$set"].(bson.M)["do_not_disturb"] = dnd
+	} else {
+		update["$unset"] = bson.M{"do_not_disturb": ""}
+	}
+	_, err := r.collection().UpdateOne(ctx, bson.M{"_id": userID}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// MuteConversation mutes a conversation, replacing any earlier mute of it
+func (r *NotificationPreferencesRepository) MuteConversation(ctx context.Context, userID primitive.ObjectID, mute models.MutedConversation) error {
+	if err := r.UnmuteConversation(ctx, userID, mute.ConversationID); err != nil {
+		return err
+	}
+	_, err := r.collection().UpdateOne(ctx,
+		bson.M{"_id": userID},
+		bson.M{
+			"$push": bson.M{"muted_conversations": mute},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// UnmuteConversation removes a conversation's mute, if any
+func (r *NotificationPreferencesRepository) UnmuteConversation(ctx context.Context, userID primitive.ObjectID, conversationID string) error {
+	_, err := r.collection().UpdateOne(ctx,
+		bson.M{"_id": userID},
+		bson.M{
+			"$pull": bson.M{"muted_conversations": bson.M{"conversation_id": conversationID}},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	return err
+}
+
+// Delete removes the user's preferences, reporting whether they had any
+func (r *NotificationPreferencesRepository) Delete(ctx context.Context, userID primitive.ObjectID) (bool, error) {
+	res, err := r.collection().DeleteOne(ctx, bson.M{"_id": userID})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
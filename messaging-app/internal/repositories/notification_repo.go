@@ -30,6 +30,10 @@ func NewNotificationRepository(db *mongo.Database) *NotificationRepository {
 			Keys:    bson.D{{Key: "recipient_id", Value: 1}, {Key: "read", Value: 1}},
 			Options: options.Index(),
 		},
+		{
+			Keys:    bson.D{{Key: "recipient_id", Value: 1}, {Key: "group_key", Value: 1}, {Key: "updated_at", Value: -1}},
+			Options: options.Index(),
+		},
 	})
 	if err != nil {
 		panic("Failed to create notification indexes: " + err.Error())
@@ -45,6 +49,9 @@ func (r *NotificationRepository) CreateNotification(ctx context.Context, notific
 	if notification.CreatedAt.IsZero() {
 		notification.CreatedAt = time.Now()
 	}
+	if notification.UpdatedAt.IsZero() {
+		notification.UpdatedAt = notification.CreatedAt
+	}
 
 	if !notification.ID.IsZero() {
 		opts := options.Replace().SetUpsert(true)
@@ -63,6 +70,52 @@ func (r *NotificationRepository) CreateNotification(ctx context.Context, notific
 	return notification, nil
 }
 
+// MergeIntoDigest folds a notification into the recipient's unread digest for
+// the same group key, if one was updated since the given time. n.SenderID
+// joins the digest's actors and the content is rewritten as
+// "<actorName> and N others <action>", or n.Content while there is one actor.
+// It returns mongo.ErrNoDocuments when there is no digest to merge into.
+func (r *NotificationRepository) MergeIntoDigest(ctx context.Context, n *models.Notification, since time.Time, actorName, action string) (*models.Notification, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	others := bson.M{"$subtract": bson.A{"$actor_count", 1}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"actor_ids":  bson.M{"$setUnion": bson.A{"$actor_ids", bson.A{n.SenderID}}},
+			"sender_id":  n.SenderID,
+			"data":       n.Data,
+			"updated_at": n.UpdatedAt,
+		}}},
+		{{Key: "$set", Value: bson.M{"actor_count": bson.M{"$size": "$actor_ids"}}}},
+		{{Key: "$set", Value: bson.M{"content": bson.M{"$cond": bson.A{
+			bson.M{"$lte": bson.A{"$actor_count", 1}},
+			n.Content,
+			bson.M{"$concat": bson.A{
+				actorName, " and ", bson.M{"$toString": others},
+				bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{others, 1}}, " other ", " others "}},
+				action,
+			}},
+		}}}}},
+	}
+
+	var merged models.Notification
+	err := r.db.Collection("notifications").FindOneAndUpdate(ctx,
+		bson.M{
+			"recipient_id": n.RecipientID,
+			"group_key":    n.GroupKey,
+			"read":         false,
+			"updated_at":   bson.M{"$gte": since},
+		},
+		pipeline,
+		options.FindOneAndUpdate().SetSort(bson.D{{Key: "updated_at", Value: -1}}).SetReturnDocument(options.After),
+	).Decode(&merged)
+	if err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
 func (r *NotificationRepository) GetNotificationByID(ctx context.Context, notificationID primitive.ObjectID) (*models.Notification, error) {
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
@@ -133,3 +186,18 @@ func (r *NotificationRepository) DeleteNotification(ctx context.Context, notific
 	_, err := r.db.Collection("notifications").DeleteOne(ctx, bson.M{"_id": notificationID})
 	return err
 }
+
+// DeleteByUser removes every notification the user received or triggered
+func (r *NotificationRepository) DeleteByUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	res, err := r.db.Collection("notifications").DeleteMany(ctx, bson.M{"$or": []bson.M{
+		{"recipient_id": userID},
+		{"sender_id": userID},
+	}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
@@ -17,6 +17,7 @@ type PrivacyRepository interface {
 	// User Privacy Settings
 	CreateOrUpdateUserPrivacySettings(ctx context.Context, settings *models.UserPrivacySettings) error
 	GetUserPrivacySettings(ctx context.Context, userID primitive.ObjectID) (*models.UserPrivacySettings, error)
+	GetUserPrivacySettingsByUserIDs(ctx context.Context, userIDs []primitive.ObjectID) ([]models.UserPrivacySettings, error)
 
 	// Custom Privacy Lists
 	CreateCustomPrivacyList(ctx context.Context, list *models.CustomPrivacyList) error
@@ -66,6 +67,24 @@ func (r *privacyRepositoryMongo) GetUserPrivacySettings(ctx context.Context, use
 	return &settings, nil
 }
 
+// GetUserPrivacySettingsByUserIDs implements PrivacyRepository. Users
+// without settings are left out.
+func (r *privacyRepositoryMongo) GetUserPrivacySettingsByUserIDs(ctx context.Context, userIDs []primitive.ObjectID) ([]models.UserPrivacySettings, error) {
+	settings := []models.UserPrivacySettings{}
+	if len(userIDs) == 0 {
+		return settings, nil
+	}
+	cursor, err := r.userPrivacySettingsCollection.Find(ctx, bson.M{"user_id": bson.M{"$in": userIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
 // CreateCustomPrivacyList implements PrivacyRepository
 func (r *privacyRepositoryMongo) CreateCustomPrivacyList(ctx context.Context, list *models.CustomPrivacyList) error {
 	_, err := r.customPrivacyListsCollection.InsertOne(ctx, list)
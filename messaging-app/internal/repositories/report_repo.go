@@ -0,0 +1,172 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ErrReportNotFound = apperrors.NotFound("report not found")
+	// ErrDuplicateReport is returned when a user reports the same target
+	// again while their earlier report is still open
+	ErrDuplicateReport = apperrors.Conflict("you have already reported this")
+	ErrReportResolved  = apperrors.Conflict("report was already resolved")
+)
+
+// ReportRepository stores user reports and the audit log of admin decisions
+type ReportRepository struct {
+	reports *mongo.Collection
+	audit   *mongo.Collection
+}
+
+func NewReportRepository(db *mongo.Database) *ReportRepository {
+	reports := db.Collection("reports")
+	audit := db.Collection("report_audit_log")
+
+	_, err := reports.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			// One open report per reporter and target
+			Keys: bson.D{{Key: "reporter_id", Value: 1}, {Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": models.ReportStatusOpen}),
+		},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}}},
+	})
+	if err != nil {
+		panic("Failed to create report indexes: " + err.Error())
+	}
+
+	_, err = audit.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "report_id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	if err != nil {
+		panic("Failed to create report audit indexes: " + err.Error())
+	}
+
+	return &ReportRepository{reports: reports, audit: audit}
+}
+
+func (r *ReportRepository) Create(ctx context.Context, report *models.Report) error {
+	report.ID = primitive.NewObjectID()
+	report.Status = models.ReportStatusOpen
+	report.CreatedAt = time.Now()
+	if _, err := r.reports.InsertOne(ctx, report); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrDuplicateReport
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *ReportRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Report, error) {
+	var report models.Report
+	err := r.reports.FindOne(ctx, bson.M{"_id": id}).Decode(&report)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrReportNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ReportFilter narrows the admin listing; zero fields match everything
+type ReportFilter struct {
+	Status     models.ReportStatus
+	TargetType models.ReportTargetType
+	Reason     models.ReportReason
+	ReporterID *primitive.ObjectID
+}
+
+// List pages through reports, newest first
+func (r *ReportRepository) List(ctx context.Context, filter ReportFilter, page, limit int64) ([]models.Report, int64, error) {
+	query := bson.M{}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.TargetType != "" {
+		query["target_type"] = filter.TargetType
+	}
+	if filter.Reason != "" {
+		query["reason"] = filter.Reason
+	}
+	if filter.ReporterID != nil {
+		query["reporter_id"] = *filter.ReporterID
+	}
+
+	total, err := r.reports.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.reports.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	reports := []models.Report{}
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, 0, err
+	}
+	return reports, total, nil
+}
+
+// Resolve closes an open report with an admin's decision
+func (r *ReportRepository) Resolve(ctx context.Context, id, reviewerID primitive.ObjectID, status models.ReportStatus, action models.ReportAction, note string) (*models.Report, error) {
+	update := bson.M{"$set": bson.M{
+		"status":      status,
+		"action":      action,
+		"reviewed_by": reviewerID,
+		"review_note": note,
+		"reviewed_at": time.Now(),
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var report models.Report
+	err := r.reports.FindOneAndUpdate(ctx, bson.M{"_id": id, "status": models.ReportStatusOpen}, update, opts).Decode(&report)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if _, getErr := r.GetByID(ctx, id); getErr != nil {
+			return nil, getErr
+		}
+		return nil, ErrReportResolved
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// AddAuditEntry appends to the audit log of admin decisions
+func (r *ReportRepository) AddAuditEntry(ctx context.Context, entry *models.ReportAuditEntry) error {
+	entry.ID = primitive.NewObjectID()
+	entry.CreatedAt = time.Now()
+	_, err := r.audit.InsertOne(ctx, entry)
+	return err
+}
+
+// ListAuditEntries returns the decisions taken on a report, oldest first
+func (r *ReportRepository) ListAuditEntries(ctx context.Context, reportID primitive.ObjectID) ([]models.ReportAuditEntry, error) {
+	cursor, err := r.audit.Find(ctx, bson.M{"report_id": reportID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	entries := []models.ReportAuditEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -206,12 +206,16 @@ func (r *StoryRepository) GetUserStories(ctx context.Context, userID primitive.O
 }
 
 // GetExpiredStories returns stories that have expired but are still in the database.
+// Stories kept in a highlight are left out, so they and their media outlive expiry.
 func (r *StoryRepository) GetExpiredStories(ctx context.Context) ([]models.Story, error) {
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
 	now := time.Now()
-	filter := bson.M{"expires_at": bson.M{"$lte": now}}
+	filter := bson.M{
+		"expires_at":      bson.M{"$lte": now},
+		"highlight_ids.0": bson.M{"$exists": false},
+	}
 
 	// Limit to batch size (e.g., 100) to avoid memory issues
 	opts := options.Find().SetLimit(100)
@@ -424,6 +428,7 @@ func (r *StoryRepository) GetStoryViewersWithReactions(ctx context.Context, stor
 				"full_name":  "$user.full_name",
 				"avatar":     "$user.avatar",
 				"public_key": "$user.public_key",
+				"verified":   "$user.verified",
 			},
 			"reaction_type": "$user_reaction.type",
 			"viewed_at":     "$viewed_at",
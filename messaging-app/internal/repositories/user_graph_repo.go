@@ -174,3 +174,40 @@ func (r *UserGraphRepository) GetFriendIDs(ctx context.Context, userID primitive
 	}
 	return ids, nil
 }
+
+// IsBlocked reports whether either user has blocked the other
+func (r *UserGraphRepository) IsBlocked(ctx context.Context, user1, user2 primitive.ObjectID) (bool, error) {
+	query := `
+		MATCH (u1:User {id: $user1}), (u2:User {id: $user2})
+		RETURN exists((u1)-[:BLOCKED]-(u2)) as blocked
+	`
+	params := map[string]any{"user1": user1.Hex(), "user2": user2.Hex()}
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil {
+		return false, err
+	}
+	if len(result.Records) == 0 {
+		return false, nil
+	}
+	blocked, _ := result.Records[0].Values[0].(bool)
+	return blocked, nil
+}
+
+// GetBlockedIDs returns the users that userID blocked or was blocked by
+func (r *UserGraphRepository) GetBlockedIDs(ctx context.Context, userID primitive.ObjectID) ([]string, error) {
+	query := `MATCH (u:User {id: $userID})-[:BLOCKED]-(b:User) RETURN DISTINCT b.id`
+	params := map[string]any{"userID": userID.Hex()}
+
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, rec := range result.Records {
+		if id, ok := rec.Values[0].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
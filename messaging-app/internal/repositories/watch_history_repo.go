@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WatchHistoryRepository stores how far users got through reels and post
+// videos, one entry per user and video
+type WatchHistoryRepository struct {
+	db *mongo.Database
+}
+
+func NewWatchHistoryRepository(db *mongo.Database) *WatchHistoryRepository {
+	_, err := db.Collection("watch_history").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "content_type", Value: 1},
+				{Key: "content_id", Value: 1},
+				{Key: "media_index", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "updated_at", Value: -1}},
+			Options: options.Index(),
+		},
+	})
+	if err != nil {
+		panic("Failed to create watch history indexes: " + err.Error())
+	}
+
+	return &WatchHistoryRepository{db: db}
+}
+
+func (r *WatchHistoryRepository) collection() *mongo.Collection {
+	return r.db.Collection("watch_history")
+}
+
+// SaveProgress upserts the entries. An entry older than the one stored
+// leaves it alone, so a late flush never rewinds a user's progress.
+func (r *WatchHistoryRepository) SaveProgress(ctx context.Context, entries []models.WatchProgress) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(entries))
+	for _, e := range entries {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{
+				"user_id":      e.UserID,
+				"content_type": e.ContentType,
+				"content_id":   e.ContentID,
+				"media_index":  e.MediaIndex,
+				"updated_at":   bson.M{"$lt": e.UpdatedAt},
+			}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"position_ms": e.PositionMs,
+				"duration_ms": e.DurationMs,
+				"completed":   e.Completed,
+				"updated_at":  e.UpdatedAt,
+			}}).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection().BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	// The filter misses a newer entry, so the upsert collides with it on the
+	// unique index; that entry is meant to win
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	return nil
+}
+
+// ListByUser pages through the user's history, most recently watched first.
+// An empty contentType lists both reels and videos.
+func (r *WatchHistoryRepository) ListByUser(ctx context.Context, userID primitive.ObjectID, contentType models.WatchContentType, page, limit int64) ([]models.WatchProgress, int64, error) {
+	filter := bson.M{"user_id": userID}
+	if contentType != "" {
+		filter["content_type"] = contentType
+	}
+
+	total, err := r.collection().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cur, err := r.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cur.Close(ctx)
+
+	entries := []models.WatchProgress{}
+	if err := cur.All(ctx, &entries); err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// ListAllByUser returns the user's whole history, most recently watched first
+func (r *WatchHistoryRepository) ListAllByUser(ctx context.Context, userID primitive.ObjectID) ([]models.WatchProgress, error) {
+	cur, err := r.collection().Find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	entries := []models.WatchProgress{}
+	if err := cur.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetProgress returns the user's entries for the given reels or posts
+func (r *WatchHistoryRepository) GetProgress(ctx context.Context, userID primitive.ObjectID, contentType models.WatchContentType, contentIDs []primitive.ObjectID) ([]models.WatchProgress, error) {
+	if len(contentIDs) == 0 {
+		return nil, nil
+	}
+
+	cur, err := r.collection().Find(ctx, bson.M{
+		"user_id":      userID,
+		"content_type": contentType,
+		"content_id":   bson.M{"$in": contentIDs},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var entries []models.WatchProgress
+	if err := cur.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// DeleteByUser removes the user's history, or only the reels or only the
+// videos in it when contentType is set
+func (r *WatchHistoryRepository) DeleteByUser(ctx context.Context, userID primitive.ObjectID, contentType models.WatchContentType) (int64, error) {
+	filter := bson.M{"user_id": userID}
+	if contentType != "" {
+		filter["content_type"] = contentType
+	}
+	res, err := r.collection().DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
@@ -13,24 +13,34 @@ import (
 	"time"
 
 	"messaging-app/config"
+	"messaging-app/internal/canary"
 	cassdb "messaging-app/internal/db"
 	"messaging-app/internal/eventsclient"
 	"messaging-app/internal/feedclient"
 	"messaging-app/internal/graph"
 	"messaging-app/internal/kafka"
 	"messaging-app/internal/marketplaceclient"
+	"messaging-app/internal/messagesearch"
+	notifications "messaging-app/internal/notifications"
+	"messaging-app/internal/push"
 	"messaging-app/internal/reelclient"
 	"messaging-app/internal/services"
 	"messaging-app/internal/storageclient"
 	"messaging-app/internal/storyclient"
 	"messaging-app/internal/websocket"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
 	pkgkafka "github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/linkpreview"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
 )
 
 type Application struct {
@@ -51,9 +61,20 @@ type Application struct {
 	friendshipKafkaProducer *kafka.MessageProducer
 	dlqProducer             *pkgkafka.DLQProducer
 	kafkaConsumer           *kafka.MessageConsumer
+	hubFanout               *kafka.HubFanout
+	hubFanoutConsumer       *kafka.HubFanoutConsumer
+	messageSearchProducer   *kafka.MessageProducer
+	messageSearchIndexer    *kafka.MessageSearchIndexer
 	notificationConsumer    *kafka.NotificationConsumer
+	pushProducer            *kafka.MessageProducer
+	pushConsumer            *kafka.PushConsumer
+	offerConsumer           *kafka.OfferConsumer
+	channelFanoutProducer   *kafka.MessageProducer
+	channelFanoutConsumer   *kafka.ChannelFanoutConsumer
 	storyConsumer           *kafka.StoryConsumer
 	cacheInvalidator        *kafka.CacheInvalidator
+	mentionIndexConsumer    *kafka.MentionIndexConsumer
+	deletionParticipant     *pkgkafka.UserDeletionParticipant
 	eventsClient            *eventsclient.Client
 	marketplaceClient       *marketplaceclient.Client
 	feedClient              *feedclient.Client
@@ -62,12 +83,23 @@ type Application struct {
 	storageClient           *storageclient.Client
 	messageArchiveService   *services.MessageArchiveService
 	cleanupService          *services.CleanupService
+	counterReconciler       *services.FeedCounterReconciler
+	feedService             *services.FeedService
+	watchHistoryService     *services.WatchHistoryService
+	notificationService     *notifications.NotificationService
+	callService             *services.CallService
+	conversationExports     *services.ConversationExportService
+	moderator               *moderation.Moderator
+	linkPreviews            *linkpreview.Worker
+	mentionService          *services.MentionService
+	deliveryCanary          *canary.Canary
 	hub                     *websocket.Hub
 	mainRouter              *gin.Engine
 	websocketRouter         *gin.Engine
 	httpServer              *http.Server
 	wsServer                *http.Server
 	metricsServer           *http.Server
+	grpcServer              *grpc.Server
 	backgroundWorkers       []func()
 	backgroundWorkerCancel  context.CancelFunc
 
@@ -110,6 +142,18 @@ func (a *Application) Run() error {
 	startServer(a.httpServer, "HTTP server")
 	startServer(a.wsServer, "WebSocket server")
 	startServer(a.metricsServer, "Metrics server")
+	go func() {
+		addr := net.JoinHostPort("", a.cfg.GRPCPort)
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			errCh <- fmt.Errorf("gRPC server failed to listen: %w", err)
+			return
+		}
+		log.Printf("gRPC server starting on %s", addr)
+		if err := a.grpcServer.Serve(lis); err != nil {
+			errCh <- fmt.Errorf("gRPC server failed: %w", err)
+		}
+	}()
 
 	select {
 	case <-quit:
@@ -145,6 +189,7 @@ func (a *Application) Shutdown() error {
 			log.Printf("Metrics server shutdown error: %v", err)
 			shutdownErr = err
 		}
+		a.grpcServer.GracefulStop()
 
 		a.Close()
 	})
@@ -158,9 +203,42 @@ func (a *Application) Close() {
 	if a.notificationConsumer != nil {
 		_ = a.notificationConsumer.Close()
 	}
+	if a.pushConsumer != nil {
+		_ = a.pushConsumer.Close()
+	}
+	if a.pushProducer != nil {
+		_ = a.pushProducer.Close()
+	}
+	if a.offerConsumer != nil {
+		_ = a.offerConsumer.Close()
+	}
+	if a.channelFanoutConsumer != nil {
+		_ = a.channelFanoutConsumer.Close()
+	}
+	if a.channelFanoutProducer != nil {
+		_ = a.channelFanoutProducer.Close()
+	}
+	if a.hubFanoutConsumer != nil {
+		_ = a.hubFanoutConsumer.Close()
+	}
+	if a.hubFanout != nil {
+		_ = a.hubFanout.Close()
+	}
+	if a.messageSearchIndexer != nil {
+		_ = a.messageSearchIndexer.Close()
+	}
+	if a.messageSearchProducer != nil {
+		_ = a.messageSearchProducer.Close()
+	}
 	if a.cacheInvalidator != nil {
 		a.cacheInvalidator.Close()
 	}
+	if a.mentionIndexConsumer != nil {
+		_ = a.mentionIndexConsumer.Close()
+	}
+	if a.deletionParticipant != nil {
+		_ = a.deletionParticipant.Close()
+	}
 	if a.kafkaProducer != nil {
 		_ = a.kafkaProducer.Close()
 	}
@@ -238,14 +316,79 @@ func (a *Application) initDomain() error {
 	repos := buildRepositories(a.db, a.cassandra)
 	graphs := buildGraphRepositories(a.neo4jClient)
 	seedMarketplace(a.ctx, repos.Marketplace)
+	if err := repos.Feed.EnsureIndexes(a.ctx); err != nil {
+		log.Printf("Failed to ensure feed indexes, continuing with the existing ones: %v", err)
+	}
 
 	servicesBundle, err := a.buildBaseServices(repos, graphs)
 	if err != nil {
 		return fmt.Errorf("failed to initialize services: %w", err)
 	}
 	a.cleanupService = servicesBundle.Cleanup
-
-	a.hub = websocket.NewHub(a.redisClient, repos.Group, repos.Feed, repos.User, repos.Friendship, repos.Message, repos.MessageCassandra, servicesBundle.Message)
+	a.counterReconciler = servicesBundle.CounterReconciler
+	a.feedService = servicesBundle.Feed
+	a.watchHistoryService = servicesBundle.WatchHistory
+	a.notificationService = servicesBundle.Notification
+	a.callService = servicesBundle.Call
+	a.conversationExports = servicesBundle.ConversationExport
+	a.moderator = servicesBundle.Moderator
+	a.linkPreviews = servicesBundle.LinkPreviews
+	a.mentionService = servicesBundle.Mention
+
+	a.hub = websocket.NewHub(a.redisClient, repos.Group, repos.Feed, repos.User, repos.Friendship, repos.Message, repos.MessageCassandra, servicesBundle.Message, servicesBundle.Notification)
+	a.hub.SetMetrics(websocket.NewHubMetrics(a.cfg.HubInstanceID))
+	a.hub.SetCallRooms(servicesBundle.Call)
+	a.hub.SetPresencePrivacy(servicesBundle.Privacy)
+	a.hub.SetReceiptPrivacy(servicesBundle.Privacy)
+	a.hub.SetCluster(a.cfg.HubInstanceID)
+	a.hub.SetJournal(time.Duration(a.cfg.HubJournalTTLMinutes)*time.Minute, a.cfg.HubJournalMaxEvents)
+
+	// The delivery canary is optional; it needs two dedicated users who are friends.
+	if a.cfg.CanarySenderID != "" || a.cfg.CanaryReceiverID != "" {
+		senderID, senderErr := primitive.ObjectIDFromHex(a.cfg.CanarySenderID)
+		receiverID, receiverErr := primitive.ObjectIDFromHex(a.cfg.CanaryReceiverID)
+		if senderErr != nil || receiverErr != nil {
+			return fmt.Errorf("CANARY_SENDER_ID and CANARY_RECEIVER_ID must both be user IDs")
+		}
+		a.deliveryCanary = canary.New(canary.Config{
+			SenderID:   senderID,
+			ReceiverID: receiverID,
+			Interval:   time.Duration(a.cfg.CanaryIntervalSecs) * time.Second,
+			Timeout:    time.Duration(a.cfg.CanaryTimeoutSecs) * time.Second,
+			MaxLatency: time.Duration(a.cfg.CanaryLatencySLOMillis) * time.Millisecond,
+			MaxLoss:    a.cfg.CanaryLossSLOPercent / 100,
+		}, servicesBundle.Message, a.hub)
+		log.Printf("Delivery canary enabled, messaging user %s every %ds", a.cfg.CanaryReceiverID, a.cfg.CanaryIntervalSecs)
+	}
+
+	// Kafka fan-out keeps per-conversation ordering and lets hubs resume after a restart.
+	// The Redis subscription stays active so instances can be migrated one at a time.
+	if a.cfg.HubFanoutMode == "kafka" {
+		a.hubFanout = kafka.NewHubFanout(a.cfg.KafkaBrokers, a.cfg.HubFanoutTopic, time.Duration(a.cfg.HubReplayWindowHours)*time.Hour)
+		a.hubFanoutConsumer = kafka.NewHubFanoutConsumer(a.cfg.KafkaBrokers, a.cfg.HubFanoutTopic, a.cfg.HubInstanceID, a.hub)
+		servicesBundle.Message.SetHubFanout(a.hubFanout)
+	}
+
+	// Message search is optional; without Elasticsearch, search returns no results.
+	if len(a.cfg.ElasticsearchURLs) > 0 {
+		index, err := messagesearch.NewIndex(messagesearch.Config{
+			URLs:     a.cfg.ElasticsearchURLs,
+			Username: a.cfg.ElasticsearchUser,
+			Password: a.cfg.ElasticsearchPassword,
+			Index:    a.cfg.MessageSearchIndex,
+		})
+		if err != nil {
+			log.Printf("Warning: Message search disabled: %v", err)
+		} else {
+			repos.MessageCassandra.SetSearcher(index)
+			a.messageSearchProducer = kafka.NewMessageProducer(a.cfg.KafkaBrokers, a.cfg.MessageSearchTopic)
+			a.messageSearchIndexer = kafka.NewMessageSearchIndexer(a.cfg.KafkaBrokers, a.cfg.MessageSearchTopic, a.cfg.MessageSearchGroupID, index)
+			servicesBundle.Message.SetSearchProducer(a.messageSearchProducer)
+			if a.messageArchiveService != nil {
+				a.messageArchiveService.SetSearchProducer(a.messageSearchProducer)
+			}
+		}
+	}
 
 	client, err := eventsclient.New(a.ctx, a.cfg)
 	if err != nil {
@@ -261,6 +404,7 @@ func (a *Application) initDomain() error {
 		return fmt.Errorf("failed to connect to marketplace service: %w", err)
 	}
 	a.marketplaceClient = marketplaceClient
+	servicesBundle.Report.SetListingStore(marketplaceClient)
 	// TODO: Update servicesBundle.Marketplace to use marketplaceClient
 
 	// Initialize feed gRPC client
@@ -297,6 +441,11 @@ func (a *Application) initDomain() error {
 
 	a.kafkaConsumer = kafka.NewMessageConsumer(a.cfg.KafkaBrokers, a.cfg.KafkaTopic, "message-group", a.hub)
 	a.notificationConsumer = kafka.NewNotificationConsumer(a.cfg.KafkaBrokers, "notifications_events", "notification-group", a.hub, repos.Notification, a.dlqProducer)
+	a.initPush(repos, servicesBundle)
+	a.offerConsumer = kafka.NewOfferConsumer(a.cfg.KafkaBrokers, a.cfg.MarketplaceOfferTopic, a.cfg.MarketplaceOfferGroupID, servicesBundle.Message, a.dlqProducer)
+	a.channelFanoutProducer = kafka.NewMessageProducer(a.cfg.KafkaBrokers, a.cfg.ChannelFanoutTopic)
+	servicesBundle.Channel.SetFanout(a.channelFanoutProducer, a.cfg.ChannelFanoutPageSize)
+	a.channelFanoutConsumer = kafka.NewChannelFanoutConsumer(a.cfg.KafkaBrokers, a.cfg.ChannelFanoutTopic, a.cfg.ChannelFanoutGroupID, servicesBundle.Channel, a.dlqProducer)
 	a.storyConsumer = kafka.NewStoryConsumer(a.cfg.KafkaBrokers, "story-events", "story-consumer-group", a.hub)
 
 	// Cache Invalidator (Group ID unique-ish or shared? Shared for load balancing if multiple instances)
@@ -304,6 +453,8 @@ func (a *Application) initDomain() error {
 	// Wait, Application struct has `redisClient *redis.ClusterClient`. InitRedis returns *redis.ClusterClient.
 	// NewCacheInvalidator expects *redis.ClusterClient.
 	a.cacheInvalidator = kafka.NewCacheInvalidator(a.cfg.KafkaBrokers, a.cfg.UserUpdatedTopic, "cache-invalidator-group", a.redisClient.GetClient())
+	// Both user-service and this app's profile updates rename users
+	a.mentionIndexConsumer = kafka.NewMentionIndexConsumer(a.cfg.KafkaBrokers, []string{a.cfg.UserUpdatedTopic, "user-events"}, "mention-index-group", servicesBundle.Mention)
 
 	a.mainRouter, a.websocketRouter = a.buildRouters(controllerConfig)
 
@@ -316,6 +467,12 @@ func (a *Application) initDomain() error {
 		Handler: a.websocketRouter,
 	}
 
+	a.grpcServer = grpc.NewServer()
+	exportService := services.NewDataExportService(repos.MessageCassandra, repos.Group, repos.Notification, repos.WatchHistory, repos.CallRoom)
+	dataexport.NewServer(models.ErasureServiceMessaging, exportService.ExportUserData).Register(a.grpcServer)
+	deletionService := services.NewUserDeletionService(servicesBundle.Message, servicesBundle.Group, repos.Notification, repos.DeviceToken, repos.NotificationPreferences, servicesBundle.WatchHistory, servicesBundle.Call, servicesBundle.ConversationExport, servicesBundle.Mention)
+	a.deletionParticipant = pkgkafka.NewUserDeletionParticipant(a.cfg.KafkaBrokers, models.ErasureServiceMessaging, deletionService.DeleteUserData)
+
 	metricsMux := http.NewServeMux()
 	metricsMux.Handle("/metrics", config.MetricsHandler())
 	a.metricsServer = &http.Server{
@@ -325,6 +482,37 @@ func (a *Application) initDomain() error {
 	return nil
 }
 
+// initPush enables push delivery to offline users' devices when FCM or APNs
+// credentials are configured. A provider that fails to load is skipped.
+func (a *Application) initPush(repos repositoryBundle, servicesBundle serviceBundle) {
+	var fcm, apns push.Sender
+	if a.cfg.FCMCredentialsFile != "" {
+		sender, err := push.NewFCMSender(a.cfg.FCMProjectID, a.cfg.FCMCredentialsFile)
+		if err != nil {
+			log.Printf("Warning: FCM push disabled: %v", err)
+		} else {
+			fcm = sender
+		}
+	}
+	if a.cfg.APNsKeyFile != "" {
+		sender, err := push.NewAPNsSender(a.cfg.APNsKeyFile, a.cfg.APNsKeyID, a.cfg.APNsTeamID, a.cfg.APNsTopic, a.cfg.APNsProduction)
+		if err != nil {
+			log.Printf("Warning: APNs push disabled: %v", err)
+		} else {
+			apns = sender
+		}
+	}
+	if fcm == nil && apns == nil {
+		return
+	}
+
+	dispatcher := push.NewDispatcher(repos.DeviceToken, servicesBundle.Notification, a.redisClient.GetClient(), fcm, apns)
+	a.pushProducer = kafka.NewMessageProducer(a.cfg.KafkaBrokers, a.cfg.PushTopic)
+	a.pushConsumer = kafka.NewPushConsumer(a.cfg.KafkaBrokers, a.cfg.PushTopic, a.cfg.PushGroupID, dispatcher, a.dlqProducer)
+	servicesBundle.Notification.SetPushProducer(a.pushProducer)
+	a.notificationConsumer.SetPushProducer(a.pushProducer)
+}
+
 func (a *Application) startBackgroundWorkers() {
 	ctx, cancel := context.WithCancel(a.ctx)
 	a.backgroundWorkerCancel = cancel
@@ -333,10 +521,37 @@ func (a *Application) startBackgroundWorkers() {
 		go a.messageArchiveService.StartArchiveWorker(ctx)
 	}
 	go a.kafkaConsumer.ConsumeMessages(ctx)
+	if a.hubFanoutConsumer != nil {
+		go a.hubFanoutConsumer.Start(ctx)
+	}
+	if a.messageSearchIndexer != nil {
+		go a.messageSearchIndexer.Start(ctx)
+	}
 	go a.notificationConsumer.Start(ctx)
+	if a.pushConsumer != nil {
+		go a.pushConsumer.Start(ctx)
+	}
+	go a.offerConsumer.Start(ctx)
+	go a.channelFanoutConsumer.Start(ctx)
 	go a.storyConsumer.Start(ctx)
 	go a.cacheInvalidator.Start(ctx)
+	go a.mentionIndexConsumer.Start(ctx)
+	go a.mentionService.BackfillIndex(ctx)
+	go a.deletionParticipant.Run(ctx)
 	go a.cleanupService.StartCleanupWorker(ctx)
+	go a.feedService.StartTrashPurgeWorker(ctx)
+	go a.counterReconciler.StartReconcileWorker(ctx)
+	go a.watchHistoryService.StartFlushWorker(ctx)
+	go a.notificationService.StartDoNotDisturbWorker(ctx)
+	go a.callService.StartRingTimeoutWorker(ctx)
+	go a.conversationExports.StartExportWorker(ctx)
+	go a.moderator.Run(ctx)
+	if a.deliveryCanary != nil {
+		go a.deliveryCanary.Run(ctx)
+	}
+	if a.linkPreviews != nil {
+		go a.linkPreviews.Run(ctx)
+	}
 }
 
 func (a *Application) initTracer() error {
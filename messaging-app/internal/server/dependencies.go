@@ -3,8 +3,10 @@ package server
 import (
 	"context"
 	"log"
+	"time"
 
 	"messaging-app/config"
+	"messaging-app/internal/antispam"
 	"messaging-app/internal/cache"
 	"messaging-app/internal/controllers"
 	cassdb "messaging-app/internal/db"
@@ -21,24 +23,38 @@ import (
 	"messaging-app/internal/storyclient"
 	"messaging-app/internal/userclient"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/linkpreview"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
+
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type repositoryBundle struct {
-	User             *repositories.UserRepository
-	Message          *repositories.MessageRepository
-	Group            *repositories.GroupRepository
-	Friendship       *repositories.FriendshipRepository
-	Feed             *repositories.FeedRepository
-	Privacy          repositories.PrivacyRepository
-	Notification     *repositories.NotificationRepository
-	Conversation     *repositories.ConversationRepository
-	Community        *repositories.CommunityRepository
-	Story            *repositories.StoryRepository
-	Reel             *repositories.ReelRepository
-	Marketplace      *repositories.MarketplaceRepository
-	MessageCassandra *repositories.MessageCassandraRepository
-	GroupActivity    *repositories.GroupActivityRepository
+	User                    *repositories.UserRepository
+	Message                 *repositories.MessageRepository
+	Group                   *repositories.GroupRepository
+	GroupInvite             *repositories.GroupInviteRepository
+	Friendship              *repositories.FriendshipRepository
+	Feed                    *repositories.FeedRepository
+	Privacy                 repositories.PrivacyRepository
+	Notification            *repositories.NotificationRepository
+	NotificationPreferences *repositories.NotificationPreferencesRepository
+	DeviceToken             *repositories.DeviceTokenRepository
+	Conversation            *repositories.ConversationRepository
+	Community               *repositories.CommunityRepository
+	CommunityInvite         *repositories.CommunityInviteRepository
+	CommunityModerationLog  *repositories.CommunityModerationLogRepository
+	Story                   *repositories.StoryRepository
+	Reel                    *repositories.ReelRepository
+	Marketplace             *repositories.MarketplaceRepository
+	MessageCassandra        *repositories.MessageCassandraRepository
+	GroupActivity           *repositories.GroupActivityRepository
+	Report                  *repositories.ReportRepository
+	WatchHistory            *repositories.WatchHistoryRepository
+	CallRoom                *repositories.CallRoomRepository
+	ConversationExport      *repositories.ConversationExportRepository
+	Channel                 *repositories.ChannelRepository
 }
 
 func buildRepositories(db *mongo.Database, cassandra *cassdb.CassandraClient) repositoryBundle {
@@ -46,20 +62,30 @@ func buildRepositories(db *mongo.Database, cassandra *cassdb.CassandraClient) re
 	groupRepo := repositories.NewGroupRepository(db)
 
 	return repositoryBundle{
-		User:             userRepo,
-		Message:          repositories.NewMessageRepository(db),
-		Group:            groupRepo,
-		Friendship:       repositories.NewFriendshipRepository(db),
-		Feed:             repositories.NewFeedRepository(db),
-		Privacy:          repositories.NewPrivacyRepository(db),
-		Notification:     repositories.NewNotificationRepository(db),
-		Conversation:     repositories.NewConversationRepository(db, userRepo, groupRepo),
-		Community:        repositories.NewCommunityRepository(db),
-		Story:            repositories.NewStoryRepository(db),
-		Reel:             repositories.NewReelRepository(db),
-		Marketplace:      repositories.NewMarketplaceRepository(db),
-		MessageCassandra: repositories.NewMessageCassandraRepository(cassandra),
-		GroupActivity:    repositories.NewGroupActivityRepository(cassandra),
+		User:                    userRepo,
+		Message:                 repositories.NewMessageRepository(db),
+		Group:                   groupRepo,
+		GroupInvite:             repositories.NewGroupInviteRepository(db),
+		Friendship:              repositories.NewFriendshipRepository(db),
+		Feed:                    repositories.NewFeedRepository(db),
+		Privacy:                 repositories.NewPrivacyRepository(db),
+		Notification:            repositories.NewNotificationRepository(db),
+		NotificationPreferences: repositories.NewNotificationPreferencesRepository(db),
+		DeviceToken:             repositories.NewDeviceTokenRepository(db),
+		Conversation:            repositories.NewConversationRepository(db, userRepo, groupRepo),
+		Community:               repositories.NewCommunityRepository(db),
+		CommunityInvite:         repositories.NewCommunityInviteRepository(db),
+		CommunityModerationLog:  repositories.NewCommunityModerationLogRepository(db),
+		Story:                   repositories.NewStoryRepository(db),
+		Reel:                    repositories.NewReelRepository(db),
+		Marketplace:             repositories.NewMarketplaceRepository(db),
+		MessageCassandra:        repositories.NewMessageCassandraRepository(cassandra),
+		GroupActivity:           repositories.NewGroupActivityRepository(cassandra),
+		Report:                  repositories.NewReportRepository(db),
+		WatchHistory:            repositories.NewWatchHistoryRepository(db),
+		CallRoom:                repositories.NewCallRoomRepository(db),
+		ConversationExport:      repositories.NewConversationExportRepository(db),
+		Channel:                 repositories.NewChannelRepository(db),
 	}
 }
 
@@ -101,16 +127,27 @@ type serviceBundle struct {
 	Search              *services.SearchService
 	Conversation        *services.ConversationService
 	Community           *services.CommunityService
+	CommunityModeration *services.CommunityModerationService
 	Reel                *services.ReelService
 	Event               services.EventServiceContract
 	EventRecommendation services.EventRecommendationServiceContract
 	EventCache          *cache.EventCache
 	Cleanup             *services.CleanupService
+	CounterReconciler   *services.FeedCounterReconciler
+	Moderator           *moderation.Moderator
+	LinkPreviews        *linkpreview.Worker
+	Moderation          *services.ModerationService
+	Report              *services.ReportService
+	WatchHistory        *services.WatchHistoryService
+	Call                *services.CallService
+	ConversationExport  *services.ConversationExportService
+	Mention             *services.MentionService
+	Channel             *services.ChannelService
 }
 
 func (a *Application) buildBaseServices(repos repositoryBundle, graphs graphBundle) (serviceBundle, error) {
 	authService := services.NewAuthService(repos.User, a.cfg.JWTSecret, a.redisClient.GetClient(), a.cfg, graphs.UserGraph)
-	notificationService := notifications.NewNotificationService(repos.Notification, repos.User, a.kafkaProducer)
+	notificationService := notifications.NewNotificationService(repos.Notification, repos.NotificationPreferences, repos.User, repos.DeviceToken, a.kafkaProducer, a.redisClient.GetClient())
 
 	storageClient, err := storageclient.NewClient(a.cfg.StorageGRPCHost, a.cfg.StorageGRPCPort)
 	if err != nil {
@@ -143,16 +180,59 @@ func (a *Application) buildBaseServices(repos repositoryBundle, graphs graphBund
 
 	feedService := services.NewFeedService(repos.Feed, repos.User, repos.Friendship, repos.Community, repos.Privacy, a.kafkaProducer, notificationService, storageClient)
 	userService := services.NewUserService(repos.User, repos.Reel, a.redisClient.GetClient(), feedService, a.userKafkaProducer, userClient)
-	groupService := services.NewGroupService(repos.Group, repos.User, repos.GroupActivity, a.cassandra, a.kafkaProducer, a.redisClient.GetClient(), graphs.GroupGraph)
+	groupService := services.NewGroupService(repos.Group, repos.GroupInvite, repos.User, repos.GroupActivity, a.cassandra, a.kafkaProducer, a.redisClient.GetClient(), graphs.GroupGraph)
 	friendshipService := services.NewFriendshipService(repos.Friendship, repos.User, graphs.UserGraph, a.friendshipKafkaProducer)
 	messageService := services.NewMessageService(repos.Message, repos.Group, repos.Friendship, a.kafkaProducer, a.redisClient.GetClient(), repos.User, notificationService, repos.MessageCassandra, repos.GroupActivity)
+	feedService.SetBlockLookup(graphs.UserGraph)
+	feedService.SetMaxReplyDepth(a.cfg.ReplyMaxDepth)
+	messageService.SetBlockLookup(graphs.UserGraph)
+	messageService.SetEditWindow(time.Duration(a.cfg.MessageEditWindowMins) * time.Minute)
+	var linkPreviews *linkpreview.Worker
+	if a.cfg.LinkPreviewWorkers > 0 {
+		linkPreviews = linkpreview.NewWorker(a.redisClient.GetClient(), a.cfg.LinkPreviewWorkers, 1000, nil)
+	}
+	feedService.SetLinkPreviews(linkPreviews)
+	messageService.SetLinkPreviews(linkPreviews)
+	moderator := moderation.NewModerator(moderation.NewStore(a.db), nil)
+	feedService.SetModerator(moderator)
+	messageService.SetModerator(moderator)
+	if a.cfg.SpamEnabled {
+		spamConfig := antispam.DefaultConfig()
+		spamConfig.MessagesPerMinute = a.cfg.SpamMessagesPerMinute
+		spamConfig.RecipientsPerWindow = a.cfg.SpamRecipientsPer10Mins
+		spamConfig.DuplicateLimit = a.cfg.SpamDuplicateLimit
+		spamConfig.MaxLinks = a.cfg.SpamMaxLinks
+		spamConfig.ThrottledPerMinute = a.cfg.SpamThrottledPerMinute
+		spamConfig.ThrottleDuration = time.Duration(a.cfg.SpamThrottleMins) * time.Minute
+		spamConfig.FlagDuration = time.Duration(a.cfg.SpamFlagHours) * time.Hour
+		messageService.SetSpamGuard(antispam.NewGuard(a.redisClient.GetClient(), spamConfig))
+	}
+	moderationService := services.NewModerationService(moderator, repos.User, repos.Community)
+	reportService := services.NewReportService(repos.Report, repos.User, feedService, messageService, a.redisClient.GetClient())
+	auditLogger := audit.NewLogger(audit.NewStore(a.mongoClient.Database(a.cfg.AuditDBName)), "messaging-app", nil)
+	groupService.SetAuditLogger(auditLogger)
+	moderationService.SetAuditLogger(auditLogger)
+	reportService.SetAuditLogger(auditLogger)
 	privacyService := services.NewPrivacyService(repos.Privacy, repos.User)
+	userService.SetPresencePrivacy(privacyService, repos.Friendship)
+	messageService.SetReceiptPrivacy(privacyService)
 	searchService := services.NewSearchService(repos.User, repos.Feed, repos.Friendship)
 	conversationService := services.NewConversationService(repos.Conversation, repos.MessageCassandra, repos.User, repos.Group)
-	communityService := services.NewCommunityService(repos.Community, repos.User)
+	communityService := services.NewCommunityService(repos.Community, repos.CommunityInvite, repos.User, a.kafkaProducer)
+	communityModerationService := services.NewCommunityModerationService(repos.Community, repos.CommunityModerationLog, repos.Feed, feedService)
 	reelService := services.NewReelService(repos.Reel, repos.User, repos.Friendship)
 	eventCache := cache.NewEventCache(a.redisClient)
 	cleanupService := services.NewCleanupService(repos.Story, storageClient)
+	counterReconciler := services.NewFeedCounterReconciler(repos.Feed, time.Duration(a.cfg.FeedCounterReconcileMins)*time.Minute)
+	watchHistoryService := services.NewWatchHistoryService(repos.WatchHistory, a.redisClient.GetClient())
+	callService := services.NewCallService(repos.CallRoom, repos.Group, repos.Friendship, a.kafkaProducer, a.redisClient.GetClient())
+	channelService := services.NewChannelService(repos.Channel, repos.User, a.kafkaProducer, a.redisClient.GetClient())
+	conversationExportService := services.NewConversationExportService(repos.ConversationExport, repos.MessageCassandra, messageService, storageClient)
+	mentionService := services.NewMentionService(cache.NewMentionIndex(a.redisClient.GetClient()), repos.User, repos.Friendship, repos.Group, repos.Community, messageService)
+	mentionService.SetBlockLookup(graphs.UserGraph)
+	authService.SetMentionService(mentionService)
+	feedService.SetMentionService(mentionService)
+	messageService.SetMentionService(mentionService)
 
 	// Initialize Events Client
 	eventsClient, err := eventsclient.New(context.Background(), a.cfg)
@@ -173,31 +253,51 @@ func (a *Application) buildBaseServices(repos repositoryBundle, graphs graphBund
 		Search:              searchService,
 		Conversation:        conversationService,
 		Community:           communityService,
+		CommunityModeration: communityModerationService,
 		Reel:                reelService,
 		EventCache:          eventCache,
 		Cleanup:             cleanupService,
+		CounterReconciler:   counterReconciler,
 		Event:               eventsClient,
 		EventRecommendation: eventsClient,
+		Moderator:           moderator,
+		LinkPreviews:        linkPreviews,
+		Moderation:          moderationService,
+		Report:              reportService,
+		WatchHistory:        watchHistoryService,
+		Call:                callService,
+		ConversationExport:  conversationExportService,
+		Mention:             mentionService,
+		Channel:             channelService,
 	}, nil
 }
 
 func buildControllers(cfg *config.Config, services serviceBundle, repos repositoryBundle, marketplaceClient *marketplaceclient.Client, feedClient *feedclient.Client, storyClient *storyclient.Client, reelClient *reelclient.Client, storageClient *storageclient.Client) routerConfig {
 	return routerConfig{
-		authController:         controllers.NewAuthController(services.Auth, cfg),
-		userController:         controllers.NewUserController(services.User, storageClient),
-		friendshipController:   controllers.NewFriendshipController(services.Friendship),
-		groupController:        controllers.NewGroupController(services.Group, services.User, storageClient),
-		messageController:      controllers.NewMessageController(services.Message, storageClient, services.Group),
-		feedController:         controllers.NewFeedController(services.Feed, services.User, services.Privacy, services.Storage, feedClient),
-		privacyController:      controllers.NewPrivacyController(services.Privacy, services.User),
-		searchController:       controllers.NewSearchController(services.Search),
-		notificationController: controllers.NewNotificationController(services.Notification),
-		conversationController: controllers.NewConversationController(services.Conversation),
-		uploadController:       controllers.NewUploadController(services.Storage),
-		communityController:    controllers.NewCommunityController(services.Community, storageClient),
-		storyController:        controllers.NewStoryController(storyClient, repos.Friendship, storageClient),
-		reelController:         controllers.NewReelController(reelClient, storageClient),
-		marketplaceController:  controllers.NewMarketplaceController(marketplaceClient, storageClient),
-		eventController:        controllers.NewEventController(services.Event, services.EventRecommendation, storageClient),
+		authController:                controllers.NewAuthController(services.Auth, cfg),
+		userController:                controllers.NewUserController(services.User, storageClient),
+		friendshipController:          controllers.NewFriendshipController(services.Friendship),
+		groupController:               controllers.NewGroupController(services.Group, services.User, storageClient),
+		messageController:             controllers.NewMessageController(services.Message, storageClient, services.Group),
+		feedController:                controllers.NewFeedController(services.Feed, services.User, services.Privacy, services.Storage, feedClient, services.WatchHistory),
+		privacyController:             controllers.NewPrivacyController(services.Privacy, services.User),
+		searchController:              controllers.NewSearchController(services.Search),
+		notificationController:        controllers.NewNotificationController(services.Notification),
+		conversationController:        controllers.NewConversationController(services.Conversation),
+		uploadController:              controllers.NewUploadController(services.Storage),
+		communityController:           controllers.NewCommunityController(services.Community, storageClient),
+		storyController:               controllers.NewStoryController(storyClient, repos.Friendship, storageClient),
+		reelController:                controllers.NewReelController(reelClient, storageClient, services.WatchHistory),
+		marketplaceController:         controllers.NewMarketplaceController(marketplaceClient, storageClient),
+		eventController:               controllers.NewEventController(services.Event, services.EventRecommendation, storageClient),
+		moderationController:          controllers.NewModerationController(services.Moderation),
+		reportController:              controllers.NewReportController(services.Report),
+		watchHistoryController:        controllers.NewWatchHistoryController(services.WatchHistory),
+		liveController:                controllers.NewLiveController(reelClient),
+		callController:                controllers.NewCallController(services.Call),
+		conversationExportController:  controllers.NewConversationExportController(services.ConversationExport),
+		mentionController:             controllers.NewMentionController(services.Mention),
+		communityModerationController: controllers.NewCommunityModerationController(services.CommunityModeration),
+		channelController:             controllers.NewChannelController(services.Channel),
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"messaging-app/internal/controllers"
 	"messaging-app/internal/websocket"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/middleware"
 
 	"github.com/gin-contrib/cors"
@@ -16,28 +17,38 @@ import (
 )
 
 type routerConfig struct {
-	authController         *controllers.AuthController
-	userController         *controllers.UserController
-	friendshipController   *controllers.FriendshipController
-	groupController        *controllers.GroupController
-	messageController      *controllers.MessageController
-	feedController         *controllers.FeedController
-	privacyController      *controllers.PrivacyController
-	searchController       *controllers.SearchController
-	notificationController *controllers.NotificationController
-	conversationController *controllers.ConversationController
-	uploadController       *controllers.UploadController
-	communityController    *controllers.CommunityController
-	storyController        *controllers.StoryController
-	reelController         *controllers.ReelController
-	marketplaceController  *controllers.MarketplaceController
-	eventController        *controllers.EventController
+	authController                *controllers.AuthController
+	userController                *controllers.UserController
+	friendshipController          *controllers.FriendshipController
+	groupController               *controllers.GroupController
+	messageController             *controllers.MessageController
+	feedController                *controllers.FeedController
+	privacyController             *controllers.PrivacyController
+	searchController              *controllers.SearchController
+	notificationController        *controllers.NotificationController
+	conversationController        *controllers.ConversationController
+	uploadController              *controllers.UploadController
+	communityController           *controllers.CommunityController
+	storyController               *controllers.StoryController
+	reelController                *controllers.ReelController
+	marketplaceController         *controllers.MarketplaceController
+	eventController               *controllers.EventController
+	moderationController          *controllers.ModerationController
+	reportController              *controllers.ReportController
+	watchHistoryController        *controllers.WatchHistoryController
+	liveController                *controllers.LiveController
+	callController                *controllers.CallController
+	conversationExportController  *controllers.ConversationExportController
+	mentionController             *controllers.MentionController
+	communityModerationController *controllers.CommunityModerationController
+	channelController             *controllers.ChannelController
 }
 
 func (a *Application) buildRouters(cfg routerConfig) (*gin.Engine, *gin.Engine) {
 	router := gin.New()
 	router.Use(gin.Recovery())
 	router.Use(middleware.TracingMiddleware("messaging-app"))
+	router.Use(audit.Middleware())
 	router.Use(config.MetricsMiddleware(a.metrics))
 
 	allowedOrigins := a.cfg.CORSAllowedOrigins
@@ -48,13 +59,13 @@ func (a *Application) buildRouters(cfg routerConfig) (*gin.Engine, *gin.Engine)
 	corsConfig := cors.Config{
 		AllowOrigins:     allowedOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Idempotency-Key"},
+		ExposeHeaders:    []string{"Content-Length", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
 	router.Use(cors.New(corsConfig))
-	router.Use(middleware.RateLimiter(a.cfg.RateLimitEnabled, float64(a.cfg.RateLimitLimit), a.cfg.RateLimitBurst, "messaging:global", nil))
+	router.Use(middleware.NewSlidingWindowLimiter(a.redisClient.GetClient(), nil).RateLimiter(a.cfg.RateLimitEnabled, float64(a.cfg.RateLimitLimit), a.cfg.RateLimitBurst, "messaging:global"))
 
 	webSocketRouter := gin.New()
 	webSocketRouter.Use(gin.Recovery())
@@ -113,8 +124,19 @@ func (a *Application) registerAPIRoutes(router *gin.Engine, cfg routerConfig) {
 		a.cfg.JWTSecret,
 		a.redisClient.GetClient(),
 		middleware.WithFailClosedResponse(http.StatusServiceUnavailable, "authentication temporarily unavailable"),
+		middleware.WithJWKS(a.cfg.JWKSURL),
+		middleware.WithLegacyHS256Until(a.cfg.LegacyHS256Until),
 	)
-	api := router.Group("/api", authMiddleware)
+	// Retried posts, messages and RSVPs replay the first response instead
+	// of creating duplicates
+	idempotency := middleware.NewIdempotency(a.redisClient.GetClient(), middleware.DefaultIdempotencyTTL)
+	api := router.Group("/api", authMiddleware, idempotency.Handle())
+
+	// Invite links resolve before signing in, so the preview is public and
+	// strictly limited against code guessing
+	inviteLimit := middleware.NewSlidingWindowLimiter(a.redisClient.GetClient(), nil).StrictRateLimiter(2, 5, "messaging:group-invites")
+	router.GET("/api/group-invites/:code", inviteLimit, cfg.groupController.PreviewInvite)
+	api.POST("/group-invites/:code/join", inviteLimit, cfg.groupController.JoinWithInvite)
 
 	api.POST("/upload", cfg.uploadController.Upload)
 	api.GET("/storage/download-url", cfg.uploadController.GetPresignedDownloadURL)
@@ -135,6 +157,7 @@ func (a *Application) registerAPIRoutes(router *gin.Engine, cfg routerConfig) {
 
 		userRoutes.GET("", cfg.userController.ListUsers)
 		userRoutes.GET("/presence", cfg.userController.GetUsersPresence)
+		userRoutes.GET("/mentions", cfg.mentionController.SuggestMentions)
 		userRoutes.GET("/:id", cfg.userController.GetUserByID)
 		userRoutes.GET("/:id/status", cfg.userController.GetUserStatus)
 		userRoutes.GET("/:id/albums", cfg.feedController.GetUserAlbums)
@@ -144,19 +167,27 @@ func (a *Application) registerAPIRoutes(router *gin.Engine, cfg routerConfig) {
 	{
 		feedRoutes.POST("/posts", cfg.feedController.CreatePost)
 		feedRoutes.GET("/posts", cfg.feedController.ListPosts)
+		feedRoutes.GET("/posts/trash", cfg.feedController.ListTrash)
 		feedRoutes.GET("/posts/:id", cfg.feedController.GetPostByID)
 		feedRoutes.PUT("/posts/:id", cfg.feedController.UpdatePost)
 		feedRoutes.PUT("/posts/:id/status", cfg.feedController.UpdatePostStatus)
 		feedRoutes.DELETE("/posts/:id", cfg.feedController.DeletePost)
+		feedRoutes.POST("/posts/:id/restore", cfg.feedController.RestorePost)
+		feedRoutes.POST("/posts/:id/pin", cfg.feedController.PinPost)
+		feedRoutes.DELETE("/posts/:id/pin", cfg.feedController.UnpinPost)
+		feedRoutes.POST("/posts/:id/share", cfg.feedController.SharePost)
+		feedRoutes.POST("/posts/:id/poll/vote", cfg.feedController.VotePoll)
 		feedRoutes.GET("/posts/:id/comments", cfg.feedController.GetCommentsByPostID)
 		feedRoutes.GET("/posts/:id/reactions", cfg.feedController.GetReactionsByPostID)
 
 		feedRoutes.GET("/hashtags/:hashtag/posts", cfg.feedController.GetPostsByHashtag)
+		feedRoutes.GET("/trending/hashtags", cfg.feedController.GetTrendingHashtags)
 
 		feedRoutes.POST("/comments", cfg.feedController.CreateComment)
 		feedRoutes.PUT("/comments/:commentId", cfg.feedController.UpdateComment)
 		feedRoutes.DELETE("/posts/:id/comments/:commentId", cfg.feedController.DeleteComment)
 		feedRoutes.GET("/comments/:commentId/replies", cfg.feedController.GetRepliesByCommentID)
+		feedRoutes.GET("/comments/:commentId/replies/:replyId/replies", cfg.feedController.GetRepliesByParentReplyID)
 		feedRoutes.GET("/comments/:commentId/reactions", cfg.feedController.GetReactionsByCommentID)
 
 		feedRoutes.POST("/comments/:commentId/replies", cfg.feedController.CreateReply)
@@ -195,7 +226,26 @@ func (a *Application) registerAPIRoutes(router *gin.Engine, cfg routerConfig) {
 	conversationRoutes := api.Group("/conversations")
 	{
 		conversationRoutes.GET("", cfg.conversationController.GetConversationSummaries)
+		conversationRoutes.GET("/sync", cfg.conversationController.SyncConversationSummaries)
+		conversationRoutes.GET("/requests", cfg.conversationController.GetMessageRequests)
+		conversationRoutes.GET("/exports", cfg.conversationExportController.ListExports)
+		conversationRoutes.GET("/exports/:exportId", cfg.conversationExportController.GetExport)
+		conversationRoutes.GET("/drafts", cfg.messageController.ListDrafts)
 		conversationRoutes.POST("/:id/seen", cfg.messageController.MarkConversationAsSeen)
+		conversationRoutes.POST("/:id/mentions/seen", cfg.messageController.ClearConversationMentions)
+		conversationRoutes.GET("/:id/replay", cfg.messageController.ReplayConversation)
+		conversationRoutes.GET("/:id/pins", cfg.messageController.ListPinnedMessages)
+		conversationRoutes.GET("/:id/retention", cfg.messageController.GetConversationRetention)
+		conversationRoutes.PUT("/:id/retention", cfg.messageController.UpdateConversationRetention)
+		conversationRoutes.POST("/:id/export", cfg.conversationExportController.RequestExport)
+		conversationRoutes.GET("/:id/draft", cfg.messageController.GetDraft)
+		conversationRoutes.PUT("/:id/draft", cfg.messageController.SaveDraft)
+		conversationRoutes.DELETE("/:id/draft", cfg.messageController.DeleteDraft)
+		conversationRoutes.PATCH("/:id/flags", cfg.messageController.UpdateConversationFlags)
+		conversationRoutes.GET("/:id/settings", cfg.messageController.GetConversationSettings)
+		conversationRoutes.PUT("/:id/settings", cfg.messageController.UpdateConversationSettings)
+		conversationRoutes.POST("/:id/request/accept", cfg.messageController.AcceptMessageRequest)
+		conversationRoutes.DELETE("/:id/request", cfg.messageController.DeclineMessageRequest)
 	}
 
 	messageRoutes := api.Group("/messages")
@@ -210,6 +260,11 @@ func (a *Application) registerAPIRoutes(router *gin.Engine, cfg routerConfig) {
 		messageRoutes.POST("/:id/react", cfg.messageController.AddReactionToMessage)
 		messageRoutes.DELETE("/:id/react", cfg.messageController.RemoveReactionFromMessage)
 		messageRoutes.PUT("/:id", cfg.messageController.EditMessage)
+		messageRoutes.GET("/:id/edits", cfg.messageController.GetMessageEditHistory)
+		messageRoutes.GET("/:id/thread", cfg.messageController.GetThreadMessages)
+		messageRoutes.POST("/:id/forward", cfg.messageController.ForwardMessage)
+		messageRoutes.POST("/:id/pin", cfg.messageController.PinMessage)
+		messageRoutes.DELETE("/:id/pin", cfg.messageController.UnpinMessage)
 	}
 
 	groupRoutes := api.Group("/groups")
@@ -227,6 +282,15 @@ func (a *Application) registerAPIRoutes(router *gin.Engine, cfg routerConfig) {
 		groupRoutes.GET("/:id/activities", cfg.groupController.GetActivities)
 		groupRoutes.POST("/:id/admins", cfg.groupController.AddAdmin)
 		groupRoutes.DELETE("/:id/admins/:userId", cfg.groupController.RemoveAdmin)
+		groupRoutes.PUT("/:id/members/:userId/role", cfg.groupController.UpdateMemberRole)
+		groupRoutes.PUT("/:id/owner", cfg.groupController.TransferOwnership)
+		groupRoutes.PUT("/:id/permissions", cfg.groupController.UpdatePermissions)
+		groupRoutes.POST("/:id/invites", cfg.groupController.CreateInvite)
+		groupRoutes.GET("/:id/invites", cfg.groupController.ListInvites)
+		groupRoutes.DELETE("/:id/invites/:code", cfg.groupController.RevokeInvite)
+		groupRoutes.GET("/:id/bans", cfg.groupController.ListBans)
+		groupRoutes.PUT("/:id/bans/:userId", cfg.groupController.BanMember)
+		groupRoutes.DELETE("/:id/bans/:userId", cfg.groupController.UnbanMember)
 	}
 
 	friendshipRoutes := api.Group("/friendships")
@@ -253,6 +317,15 @@ func (a *Application) registerAPIRoutes(router *gin.Engine, cfg routerConfig) {
 		notificationRoutes.GET("", cfg.notificationController.ListNotifications)
 		notificationRoutes.PUT("/:id/read", cfg.notificationController.MarkNotificationAsRead)
 		notificationRoutes.GET("/unread", cfg.notificationController.GetUnreadNotificationCount)
+		notificationRoutes.GET("/preferences", cfg.notificationController.GetPreferences)
+		notificationRoutes.PUT("/preferences", cfg.notificationController.UpdatePreferences)
+		notificationRoutes.PUT("/preferences/dnd", cfg.notificationController.UpdateDoNotDisturb)
+		notificationRoutes.DELETE("/preferences/dnd", cfg.notificationController.DisableDoNotDisturb)
+		notificationRoutes.PUT("/preferences/conversations/:id/mute", cfg.notificationController.MuteConversation)
+		notificationRoutes.DELETE("/preferences/conversations/:id/mute", cfg.notificationController.UnmuteConversation)
+		notificationRoutes.GET("/devices", cfg.notificationController.ListDevices)
+		notificationRoutes.POST("/devices", cfg.notificationController.RegisterDevice)
+		notificationRoutes.DELETE("/devices/:token", cfg.notificationController.UnregisterDevice)
 	}
 
 	communityRoutes := api.Group("/communities")
@@ -261,15 +334,38 @@ func (a *Application) registerAPIRoutes(router *gin.Engine, cfg routerConfig) {
 		communityRoutes.GET("", cfg.communityController.ListCommunities)
 		communityRoutes.GET("/user/me", cfg.communityController.GetUserCommunities)
 		communityRoutes.GET("/user/:userId", cfg.communityController.GetUserCommunities)
+		communityRoutes.GET("/invites/:code", cfg.communityController.PreviewInvite)
+		communityRoutes.POST("/invites/:code/join", cfg.communityController.JoinWithInvite)
 		communityRoutes.GET("/:id", cfg.communityController.GetCommunity)
 		communityRoutes.PUT("/:id/settings", cfg.communityController.UpdateSettings)
 		communityRoutes.POST("/:id/join", cfg.communityController.JoinCommunity)
+		communityRoutes.DELETE("/:id/join", cfg.communityController.CancelJoinRequest)
 		communityRoutes.POST("/:id/leave", cfg.communityController.LeaveCommunity)
 		communityRoutes.POST("/:id/approve", cfg.communityController.ApproveMember)
 		communityRoutes.POST("/:id/reject", cfg.communityController.RejectMember)
 		communityRoutes.GET("/:id/members", cfg.communityController.ListMembers)
 		communityRoutes.GET("/:id/admins", cfg.communityController.GetAdmins)
 		communityRoutes.GET("/:id/pending-members", cfg.communityController.GetPendingMembers)
+		communityRoutes.POST("/:id/invites", cfg.communityController.CreateInvite)
+		communityRoutes.GET("/:id/invites", cfg.communityController.ListInvites)
+		communityRoutes.DELETE("/:id/invites/:code", cfg.communityController.RevokeInvite)
+		communityRoutes.GET("/:id/moderation-rules", cfg.moderationController.ListRules)
+		communityRoutes.POST("/:id/moderation-rules", cfg.moderationController.CreateRule)
+		communityRoutes.PUT("/:id/moderation-rules/:ruleId", cfg.moderationController.UpdateRule)
+		communityRoutes.DELETE("/:id/moderation-rules/:ruleId", cfg.moderationController.DeleteRule)
+		communityRoutes.POST("/:id/posts/:postId/remove", cfg.communityModerationController.RemovePost)
+		communityRoutes.POST("/:id/posts/:postId/restore", cfg.communityModerationController.RestorePost)
+		communityRoutes.PUT("/:id/posts/:postId/lock", cfg.communityModerationController.LockPost)
+		communityRoutes.DELETE("/:id/posts/:postId/lock", cfg.communityModerationController.UnlockPost)
+		communityRoutes.GET("/:id/mutes", cfg.communityModerationController.ListMutes)
+		communityRoutes.PUT("/:id/mutes/:userId", cfg.communityModerationController.MuteMember)
+		communityRoutes.DELETE("/:id/mutes/:userId", cfg.communityModerationController.UnmuteMember)
+		communityRoutes.GET("/:id/bans", cfg.communityModerationController.ListBans)
+		communityRoutes.PUT("/:id/bans/:userId", cfg.communityModerationController.BanMember)
+		communityRoutes.DELETE("/:id/bans/:userId", cfg.communityModerationController.UnbanMember)
+		communityRoutes.GET("/:id/moderation-log", cfg.communityModerationController.GetModerationLog)
+		communityRoutes.GET("/:id/pending-posts", cfg.communityModerationController.ListPendingPosts)
+		communityRoutes.POST("/:id/pending-posts/review", cfg.communityModerationController.ReviewPendingPosts)
 	}
 
 	storyRoutes := api.Group("/stories")
@@ -287,16 +383,74 @@ func (a *Application) registerAPIRoutes(router *gin.Engine, cfg routerConfig) {
 	{
 		reelRoutes.POST("", cfg.reelController.CreateReel)
 		reelRoutes.GET("", cfg.reelController.GetReelsFeed)
+		reelRoutes.GET("/for-you", cfg.reelController.GetForYouFeed)
 		reelRoutes.GET("/user/:id", cfg.reelController.GetUserReels)
 		reelRoutes.GET("/:id", cfg.reelController.GetReel)
 
-		strictLimit := middleware.StrictRateLimiter(2, 5, "messaging:strict", nil)
+		strictLimit := middleware.NewSlidingWindowLimiter(a.redisClient.GetClient(), nil).StrictRateLimiter(2, 5, "messaging:strict")
 		reelRoutes.POST("/:id/comments", strictLimit, cfg.reelController.AddComment)
 		reelRoutes.GET("/:id/comments", cfg.reelController.GetComments)
+		reelRoutes.DELETE("/:id/comments/:commentId", cfg.reelController.DeleteComment)
+		reelRoutes.GET("/:id/comments/:commentId/replies", cfg.reelController.GetReplies)
 		reelRoutes.POST("/:id/comments/:commentId/replies", strictLimit, cfg.reelController.AddReply)
+		reelRoutes.DELETE("/:id/comments/:commentId/replies/:replyId", cfg.reelController.DeleteReply)
+		reelRoutes.POST("/:id/comments/:commentId/replies/:replyId/react", strictLimit, cfg.reelController.ReactToReply)
 		reelRoutes.POST("/:id/comments/:commentId/react", strictLimit, cfg.reelController.ReactToComment)
 		reelRoutes.POST("/:id/react", strictLimit, cfg.reelController.ReactToReel)
 		reelRoutes.POST("/:id/view", cfg.reelController.IncrementView)
+		reelRoutes.POST("/:id/engagement", cfg.reelController.RecordEngagement)
+	}
+
+	liveRoutes := api.Group("/live")
+	{
+		liveRoutes.POST("", cfg.liveController.CreateLiveSession)
+		liveRoutes.GET("", cfg.liveController.ListLiveSessions)
+		liveRoutes.GET("/:id", cfg.liveController.GetLiveSession)
+		liveRoutes.POST("/:id/end", cfg.liveController.EndLiveSession)
+		liveRoutes.POST("/:id/join", cfg.liveController.JoinLiveSession)
+		liveRoutes.POST("/:id/leave", cfg.liveController.LeaveLiveSession)
+
+		chatLimit := middleware.NewSlidingWindowLimiter(a.redisClient.GetClient(), nil).StrictRateLimiter(2, 5, "messaging:live-chat")
+		liveRoutes.POST("/:id/chat", chatLimit, cfg.liveController.SendLiveChatMessage)
+		liveRoutes.GET("/:id/chat", cfg.liveController.GetLiveChatMessages)
+	}
+
+	callRoutes := api.Group("/calls")
+	{
+		callRoutes.POST("", cfg.callController.StartCall)
+		callRoutes.GET("/history", cfg.callController.GetCallHistory)
+		callRoutes.GET("/:id", cfg.callController.GetCall)
+		callRoutes.POST("/:id/join", cfg.callController.JoinCall)
+		callRoutes.POST("/:id/decline", cfg.callController.DeclineCall)
+		callRoutes.POST("/:id/cancel", cfg.callController.CancelCall)
+		callRoutes.POST("/:id/leave", cfg.callController.LeaveCall)
+		callRoutes.PATCH("/:id/state", cfg.callController.UpdateCallState)
+	}
+
+	channelRoutes := api.Group("/channels")
+	{
+		channelRoutes.POST("", cfg.channelController.CreateChannel)
+		channelRoutes.GET("/subscribed", cfg.channelController.ListSubscribedChannels)
+		channelRoutes.GET("/:id", cfg.channelController.GetChannel)
+		channelRoutes.PATCH("/:id", cfg.channelController.UpdateChannel)
+		channelRoutes.DELETE("/:id", cfg.channelController.DeleteChannel)
+		channelRoutes.POST("/:id/subscribe", cfg.channelController.Subscribe)
+		channelRoutes.DELETE("/:id/subscribe", cfg.channelController.Unsubscribe)
+		channelRoutes.GET("/:id/subscribers", cfg.channelController.ListSubscribers)
+		channelRoutes.DELETE("/:id/subscribers/:userId", cfg.channelController.RemoveSubscriber)
+		channelRoutes.POST("/:id/admins/:userId", cfg.channelController.AddAdmin)
+		channelRoutes.DELETE("/:id/admins/:userId", cfg.channelController.RemoveAdmin)
+		channelRoutes.POST("/:id/posts", cfg.channelController.CreatePost)
+		channelRoutes.GET("/:id/posts", cfg.channelController.ListPosts)
+		channelRoutes.POST("/:id/posts/views", cfg.channelController.RecordViews)
+		channelRoutes.DELETE("/:id/posts/:postId", cfg.channelController.DeletePost)
+	}
+
+	watchHistoryRoutes := api.Group("/watch-history")
+	{
+		watchHistoryRoutes.GET("", cfg.watchHistoryController.GetWatchHistory)
+		watchHistoryRoutes.POST("", cfg.watchHistoryController.RecordProgress)
+		watchHistoryRoutes.DELETE("", cfg.watchHistoryController.ClearWatchHistory)
 	}
 
 	marketplaceRoutes := api.Group("/marketplace")
@@ -338,6 +492,35 @@ func (a *Application) registerAPIRoutes(router *gin.Engine, cfg routerConfig) {
 		eventGroup.DELETE("/:id/posts/:postId", cfg.eventController.DeletePost)
 		eventGroup.POST("/:id/posts/:postId/react", cfg.eventController.ReactToPost)
 	}
+
+	moderationRoutes := api.Group("/admin/moderation", cfg.moderationController.RequireAdmin)
+	{
+		moderationRoutes.GET("/rules", cfg.moderationController.ListRules)
+		moderationRoutes.POST("/rules", cfg.moderationController.CreateRule)
+		moderationRoutes.PUT("/rules/:ruleId", cfg.moderationController.UpdateRule)
+		moderationRoutes.DELETE("/rules/:ruleId", cfg.moderationController.DeleteRule)
+		moderationRoutes.GET("/queue", cfg.moderationController.ListQueue)
+		moderationRoutes.POST("/queue/:itemId/review", cfg.moderationController.ReviewItem)
+	}
+
+	reportRoutes := api.Group("/reports")
+	{
+		reportRoutes.POST("", cfg.reportController.CreateReport)
+		reportRoutes.GET("/mine", cfg.reportController.ListMyReports)
+	}
+
+	adminReportRoutes := api.Group("/admin/reports", cfg.moderationController.RequireAdmin)
+	{
+		adminReportRoutes.GET("", cfg.reportController.ListReports)
+		adminReportRoutes.GET("/:reportId", cfg.reportController.GetReport)
+		adminReportRoutes.POST("/:reportId/review", cfg.reportController.ReviewReport)
+	}
+
+	adminSpamRoutes := api.Group("/admin/spam", cfg.moderationController.RequireAdmin)
+	{
+		adminSpamRoutes.GET("/senders", cfg.messageController.ListSpamSenders)
+		adminSpamRoutes.DELETE("/senders/:userId", cfg.messageController.ClearSpamSender)
+	}
 }
 
 func (a *Application) registerWebSocketRoutes(router *gin.Engine) {
@@ -345,10 +528,24 @@ func (a *Application) registerWebSocketRoutes(router *gin.Engine) {
 		a.cfg.JWTSecret,
 		a.redisClient.GetClient(),
 		middleware.WithFailClosedResponse(http.StatusServiceUnavailable, "authentication temporarily unavailable"),
+		middleware.WithJWKS(a.cfg.JWKSURL),
+		middleware.WithLegacyHS256Until(a.cfg.LegacyHS256Until),
 	)
 	router.GET("/ws", wsMiddleware, func(c *gin.Context) {
 		config.IncWebsocketConnections(a.metrics)
 		defer config.DecWebsocketConnections(a.metrics)
 		websocket.ServeWs(c, a.hub)
 	})
+
+	// Catching up after a reconnect is a plain request, authenticated like the API
+	replayMiddleware := middleware.AuthMiddleware(
+		a.cfg.JWTSecret,
+		a.redisClient.GetClient(),
+		middleware.WithFailClosedResponse(http.StatusServiceUnavailable, "authentication temporarily unavailable"),
+		middleware.WithJWKS(a.cfg.JWKSURL),
+		middleware.WithLegacyHS256Until(a.cfg.LegacyHS256Until),
+	)
+	router.GET("/ws/replay", replayMiddleware, func(c *gin.Context) {
+		websocket.ServeReplay(c, a.hub)
+	})
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"messaging-app/config"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"messaging-app/internal/repositories"
 	"time"
@@ -16,12 +17,16 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrAccountSuspended is returned when a suspended user signs in
+var ErrAccountSuspended = apperrors.Forbidden("account suspended")
+
 type AuthService struct {
 	userRepo      *repositories.UserRepository
 	jwtSecret     string
 	redisClient   *redis.ClusterClient
 	cfg           *config.Config
 	userGraphRepo *repositories.UserGraphRepository
+	mentions      *MentionService
 }
 
 func NewAuthService(
@@ -40,6 +45,12 @@ func NewAuthService(
 	}
 }
 
+// SetMentionService makes new users available to @mention suggestions
+// without waiting for the next user update event
+func (s *AuthService) SetMentionService(mentions *MentionService) {
+	s.mentions = mentions
+}
+
 func (s *AuthService) Register(ctx context.Context, user *models.User) (*models.AuthResponse, error) {
 	existingUserEmail, _ := s.userRepo.FindUserByEmail(ctx, user.Email)
 	if existingUserEmail != nil {
@@ -72,6 +83,9 @@ func (s *AuthService) Register(ctx context.Context, user *models.User) (*models.
 	if s.userGraphRepo != nil {
 		go s.userGraphRepo.SyncUser(context.Background(), createdUser.ID)
 	}
+	if s.mentions != nil {
+		s.mentions.IndexUser(ctx, createdUser)
+	}
 
 	accessToken, refreshToken, err := s.generateTokens(ctx, createdUser)
 	if err != nil {
@@ -97,6 +111,9 @@ func (s *AuthService) Login(ctx context.Context, email, password string) (*model
 		log.Printf("err in pass matching: %s", err)
 		return nil, errors.New("invalid credentials: please check password")
 	}
+	if user.IsSuspended(time.Now()) {
+		return nil, ErrAccountSuspended
+	}
 
 	accessToken, refreshToken, err := s.generateTokens(ctx, user)
 	if err != nil {
@@ -145,6 +162,9 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*m
 	if err != nil {
 		return nil, errors.New("user not found")
 	}
+	if user.IsSuspended(time.Now()) {
+		return nil, ErrAccountSuspended
+	}
 
 	newAccessToken, newRefreshToken, err := s.generateTokens(ctx, user)
 	if err != nil {
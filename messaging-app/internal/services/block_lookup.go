@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BlockLookup answers whether users have blocked each other. Blocks are kept
+// as BLOCKED edges in the shared user graph, written by user-service and by
+// FriendshipService.BlockUser.
+type BlockLookup interface {
+	IsBlocked(ctx context.Context, user1, user2 primitive.ObjectID) (bool, error)
+	GetBlockedIDs(ctx context.Context, userID primitive.ObjectID) ([]string, error)
+}
+
+// blockedUserIDs returns the users userID blocked or was blocked by. Without
+// a lookup nobody is blocked.
+func blockedUserIDs(ctx context.Context, blocks BlockLookup, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	if blocks == nil || userID.IsZero() {
+		return nil, nil
+	}
+	ids, err := blocks.GetBlockedIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	blocked := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			blocked = append(blocked, oid)
+		}
+	}
+	return blocked, nil
+}
+
+// excludeAuthors narrows filter to documents not authored by the given users
+func excludeAuthors(filter bson.M, authors []primitive.ObjectID) bson.M {
+	if len(authors) == 0 {
+		return filter
+	}
+	return bson.M{"$and": []bson.M{filter, {"user_id": bson.M{"$nin": authors}}}}
+}
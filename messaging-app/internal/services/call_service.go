@@ -0,0 +1,543 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"messaging-app/internal/kafka"
+	"messaging-app/internal/repositories"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/redis/go-redis/v9"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// MaxCallParticipants bounds a call, host included, to what a mesh or
+	// small SFU room handles
+	MaxCallParticipants = 16
+	// Invitees who have not answered by then have missed the call
+	callRingTimeout       = 45 * time.Second
+	callRingCheckInterval = 5 * time.Second
+	// Outlives any real call, should one never end cleanly
+	callPeersTTL = 24 * time.Hour
+)
+
+var (
+	ErrCallNotFound          = apperrors.NotFound("call not found")
+	ErrCallEnded             = apperrors.Conflict("call has ended")
+	ErrNotCallParticipant    = apperrors.Forbidden("you are not in this call")
+	ErrNotCallHost           = apperrors.Forbidden("only the host can cancel a call")
+	ErrCallNotRinging        = apperrors.Conflict("call is no longer ringing")
+	ErrInvalidCallStateMove  = apperrors.Conflict("you cannot do that in your current call state")
+	ErrCallNeedsParticipants = apperrors.Validation("a call needs a group_id or participant_ids")
+	ErrTooManyCallMembers    = apperrors.Validation(fmt.Sprintf("a call can have at most %d participants", MaxCallParticipants))
+	ErrCallInviteeNotFriend  = apperrors.Forbidden("you can only call your friends")
+	ErrInvalidCallID         = apperrors.Validation("invalid call ID")
+	ErrInvalidCallInvitee    = apperrors.Validation("invalid group or participant ID")
+)
+
+// CallService runs group calls. It keeps each call's participants and
+// their state, rings invitees over the Hub and records call history. Media
+// flows peer to peer or through an SFU; the Hub only relays signaling
+// between a call's joined participants, who it looks up in Redis.
+type CallService struct {
+	repo           *repositories.CallRoomRepository
+	groupRepo      *repositories.GroupRepository
+	friendshipRepo *repositories.FriendshipRepository
+	producer       *kafka.MessageProducer
+	redisClient    *redis.ClusterClient
+}
+
+func NewCallService(repo *repositories.CallRoomRepository, groupRepo *repositories.GroupRepository, friendshipRepo *repositories.FriendshipRepository, producer *kafka.MessageProducer, redisClient *redis.ClusterClient) *CallService {
+	return &CallService{
+		repo:           repo,
+		groupRepo:      groupRepo,
+		friendshipRepo: friendshipRepo,
+		producer:       producer,
+		redisClient:    redisClient,
+	}
+}
+
+func callPeersKey(roomID string) string {
+	return fmt.Sprintf("call:peers:{%s}", roomID)
+}
+
+// StartCall creates a call with the host joined and rings everyone else,
+// either the members of a group the host is in or the host's friends
+func (s *CallService) StartCall(ctx context.Context, hostID primitive.ObjectID, req *models.StartCallRequest) (*models.CallRoom, error) {
+	invitees, groupID, err := s.resolveInvitees(ctx, hostID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	participants := []models.CallParticipant{{
+		UserID:    hostID,
+		Status:    models.CallParticipantJoined,
+		VideoOn:   req.CallType == "video",
+		InvitedAt: now,
+		JoinedAt:  &now,
+	}}
+	for _, id := range invitees {
+		participants = append(participants, models.CallParticipant{
+			UserID:    id,
+			Status:    models.CallParticipantRinging,
+			VideoOn:   req.CallType == "video",
+			InvitedAt: now,
+		})
+	}
+
+	room := &models.CallRoom{
+		HostID:       hostID,
+		GroupID:      groupID,
+		CallType:     req.CallType,
+		Status:       models.CallRoomRinging,
+		Participants: participants,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := s.repo.Create(ctx, room); err != nil {
+		return nil, fmt.Errorf("failed to create call: %w", err)
+	}
+	s.addPeer(ctx, room.ID, hostID)
+
+	s.publish(ctx, "CALL_RING", room, hostID, participantIDs(room, models.CallParticipantRinging))
+	return room, nil
+}
+
+func (s *CallService) resolveInvitees(ctx context.Context, hostID primitive.ObjectID, req *models.StartCallRequest) ([]primitive.ObjectID, *primitive.ObjectID, error) {
+	var invitees []primitive.ObjectID
+	var groupID *primitive.ObjectID
+
+	switch {
+	case req.GroupID != "":
+		gID, err := primitive.ObjectIDFromHex(req.GroupID)
+		if err != nil {
+			return nil, nil, ErrInvalidCallInvitee
+		}
+		group, err := s.groupRepo.GetGroup(ctx, gID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load group: %w", err)
+		}
+		isMember := false
+		for _, memberID := range group.Members {
+			if memberID == hostID {
+				isMember = true
+				continue
+			}
+			invitees = append(invitees, memberID)
+		}
+		if !isMember {
+			return nil, nil, ErrNotGroupMember
+		}
+		groupID = &gID
+	case len(req.ParticipantIDs) > 0:
+		seen := map[primitive.ObjectID]bool{hostID: true}
+		for _, id := range req.ParticipantIDs {
+			oid, err := primitive.ObjectIDFromHex(id)
+			if err != nil {
+				return nil, nil, ErrInvalidCallInvitee
+			}
+			if seen[oid] {
+				continue
+			}
+			seen[oid] = true
+			friends, err := s.friendshipRepo.AreFriends(ctx, hostID, oid)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to check friendship: %w", err)
+			}
+			if !friends {
+				return nil, nil, ErrCallInviteeNotFriend
+			}
+			invitees = append(invitees, oid)
+		}
+	}
+
+	if len(invitees) == 0 {
+		return nil, nil, ErrCallNeedsParticipants
+	}
+	if len(invitees)+1 > MaxCallParticipants {
+		return nil, nil, ErrTooManyCallMembers
+	}
+	return invitees, groupID, nil
+}
+
+// GetCall returns a call the user was invited to
+func (s *CallService) GetCall(ctx context.Context, roomID, userID primitive.ObjectID) (*models.CallRoom, error) {
+	room, err := s.repo.GetByID(ctx, roomID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrCallNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := room.Participant(userID); !ok {
+		return nil, ErrCallNotFound
+	}
+	return room, nil
+}
+
+// JoinCall answers a ringing call, or rejoins one the user left or missed
+// while it still runs
+func (s *CallService) JoinCall(ctx context.Context, roomID, userID primitive.ObjectID) (*models.CallRoom, error) {
+	if _, err := s.GetCall(ctx, roomID, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	room, err := s.repo.UpdateParticipant(ctx, roomID, userID,
+		[]models.CallParticipantStatus{models.CallParticipantRinging, models.CallParticipantLeft, models.CallParticipantMissed, models.CallParticipantDeclined},
+		bson.M{"status": models.CallParticipantJoined, "joined_at": now},
+		nil,
+	)
+	if err != nil {
+		return nil, s.stateError(ctx, roomID, err)
+	}
+	s.addPeer(ctx, roomID, userID)
+
+	if room.Status == models.CallRoomRinging && room.CountByStatus(models.CallParticipantJoined) > 1 {
+		if err := s.repo.Activate(ctx, roomID, now); err != nil {
+			log.Printf("Failed to activate call %s: %v", roomID.Hex(), err)
+		}
+		room.Status = models.CallRoomActive
+		room.StartedAt = &now
+	}
+
+	s.publish(ctx, "CALL_PARTICIPANT_JOINED", room, userID, participantIDs(room))
+	return room, nil
+}
+
+// DeclineCall turns down a ringing call. The call ends when nobody else is
+// left to answer it.
+func (s *CallService) DeclineCall(ctx context.Context, roomID, userID primitive.ObjectID) (*models.CallRoom, error) {
+	if _, err := s.GetCall(ctx, roomID, userID); err != nil {
+		return nil, err
+	}
+
+	room, err := s.repo.UpdateParticipant(ctx, roomID, userID,
+		[]models.CallParticipantStatus{models.CallParticipantRinging},
+		bson.M{"status": models.CallParticipantDeclined},
+		nil,
+	)
+	if err != nil {
+		return nil, s.stateError(ctx, roomID, err)
+	}
+
+	s.publish(ctx, "CALL_PARTICIPANT_DECLINED", room, userID, participantIDs(room))
+	return s.endIfOver(ctx, room)
+}
+
+// CancelCall lets the host stop ringing a call nobody has answered yet
+func (s *CallService) CancelCall(ctx context.Context, roomID, userID primitive.ObjectID) (*models.CallRoom, error) {
+	room, err := s.GetCall(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if room.HostID != userID {
+		return nil, ErrNotCallHost
+	}
+	if room.Status != models.CallRoomRinging {
+		return nil, ErrCallNotRinging
+	}
+
+	return s.endCall(ctx, room, "CALL_CANCELLED")
+}
+
+// LeaveCall takes the user out of the call, adding the time they spent in
+// it. The call ends when nobody is left in it.
+func (s *CallService) LeaveCall(ctx context.Context, roomID, userID primitive.ObjectID) (*models.CallRoom, error) {
+	room, err := s.GetCall(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	p, _ := room.Participant(userID)
+	if p.Status != models.CallParticipantJoined {
+		return nil, ErrInvalidCallStateMove
+	}
+
+	now := time.Now()
+	room, err = s.repo.UpdateParticipant(ctx, roomID, userID,
+		[]models.CallParticipantStatus{models.CallParticipantJoined},
+		bson.M{"status": models.CallParticipantLeft, "left_at": now},
+		bson.M{"duration_seconds": secondsSince(p.JoinedAt, now)},
+	)
+	if err != nil {
+		return nil, s.stateError(ctx, roomID, err)
+	}
+	s.removePeer(ctx, roomID, userID)
+
+	s.publish(ctx, "CALL_PARTICIPANT_LEFT", room, userID, participantIDs(room))
+	return s.endIfOver(ctx, room)
+}
+
+// UpdateState changes whether the user is muted or sharing video
+func (s *CallService) UpdateState(ctx context.Context, roomID, userID primitive.ObjectID, req *models.UpdateCallStateRequest) (*models.CallRoom, error) {
+	if _, err := s.GetCall(ctx, roomID, userID); err != nil {
+		return nil, err
+	}
+
+	set := bson.M{}
+	if req.Muted != nil {
+		set["muted"] = *req.Muted
+	}
+	if req.VideoOn != nil {
+		set["video_on"] = *req.VideoOn
+	}
+	room, err := s.repo.UpdateParticipant(ctx, roomID, userID,
+		[]models.CallParticipantStatus{models.CallParticipantJoined},
+		set,
+		nil,
+	)
+	if err != nil {
+		return nil, s.stateError(ctx, roomID, err)
+	}
+
+	s.publish(ctx, "CALL_PARTICIPANT_UPDATED", room, userID, participantIDs(room, models.CallParticipantJoined))
+	return room, nil
+}
+
+// GetCallHistory returns the user's ended calls, newest first
+func (s *CallService) GetCallHistory(ctx context.Context, userID primitive.ObjectID, page, limit int64) (*models.CallHistoryResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	calls, total, err := s.repo.ListHistory(ctx, userID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CallHistoryResponse{
+		Calls: calls,
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}, nil
+}
+
+// RoomPeers returns the other joined participants of a call the user has
+// joined. The Hub relays the user's signals to them.
+func (s *CallService) RoomPeers(ctx context.Context, roomID, userID string) ([]string, error) {
+	members, err := s.redisClient.SMembers(ctx, callPeersKey(roomID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]string, 0, len(members))
+	joined := false
+	for _, id := range members {
+		if id == userID {
+			joined = true
+			continue
+		}
+		peers = append(peers, id)
+	}
+	if !joined {
+		return nil, ErrNotCallParticipant
+	}
+	return peers, nil
+}
+
+// StartRingTimeoutWorker marks invitees who never answered as having missed
+// the call, ending calls nobody answered, until ctx is cancelled
+func (s *CallService) StartRingTimeoutWorker(ctx context.Context) {
+	ticker := time.NewTicker(callRingCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.expireRinging(ctx)
+		}
+	}
+}
+
+func (s *CallService) expireRinging(ctx context.Context) {
+	cutoff := time.Now().Add(-callRingTimeout)
+	rooms, err := s.repo.ListRingingSince(ctx, cutoff)
+	if err != nil {
+		log.Printf("Failed to list ringing calls: %v", err)
+		return
+	}
+
+	for _, room := range rooms {
+		current := &room
+		for _, p := range room.Participants {
+			if p.Status != models.CallParticipantRinging || !p.InvitedAt.Before(cutoff) {
+				continue
+			}
+			updated, err := s.repo.UpdateParticipant(ctx, room.ID, p.UserID,
+				[]models.CallParticipantStatus{models.CallParticipantRinging},
+				bson.M{"status": models.CallParticipantMissed},
+				nil,
+			)
+			if errors.Is(err, repositories.ErrCallStateChanged) {
+				continue
+			}
+			if err != nil {
+				log.Printf("Failed to time out call %s for %s: %v", room.ID.Hex(), p.UserID.Hex(), err)
+				continue
+			}
+			current = updated
+			s.publish(ctx, "CALL_MISSED", updated, p.UserID, participantIDs(updated))
+		}
+
+		if _, err := s.endIfOver(ctx, current); err != nil {
+			log.Printf("Failed to end unanswered call %s: %v", room.ID.Hex(), err)
+		}
+	}
+}
+
+// endIfOver ends the call once nobody is in it, or the host is alone with
+// nobody left ringing
+func (s *CallService) endIfOver(ctx context.Context, room *models.CallRoom) (*models.CallRoom, error) {
+	joined := room.CountByStatus(models.CallParticipantJoined)
+	ringing := room.CountByStatus(models.CallParticipantRinging)
+	if joined > 1 || (joined == 1 && ringing > 0) {
+		return room, nil
+	}
+	return s.endCall(ctx, room, "CALL_ENDED")
+}
+
+// endCall closes the call's history record: everyone still in the call
+// leaves and everyone still ringing missed it
+func (s *CallService) endCall(ctx context.Context, room *models.CallRoom, eventType string) (*models.CallRoom, error) {
+	now := time.Now()
+	for i := range room.Participants {
+		p := &room.Participants[i]
+		switch p.Status {
+		case models.CallParticipantJoined:
+			p.Status = models.CallParticipantLeft
+			p.LeftAt = &now
+			p.DurationSeconds += secondsSince(p.JoinedAt, now)
+		case models.CallParticipantRinging:
+			p.Status = models.CallParticipantMissed
+		}
+	}
+	room.Status = models.CallRoomEnded
+	room.EndedAt = &now
+	if room.StartedAt != nil {
+		room.DurationSeconds = secondsSince(room.StartedAt, now)
+	}
+
+	ended, err := s.repo.End(ctx, room)
+	if err != nil {
+		return nil, fmt.Errorf("failed to end call: %w", err)
+	}
+	if !ended {
+		return s.repo.GetByID(ctx, room.ID)
+	}
+	if err := s.redisClient.Del(ctx, callPeersKey(room.ID.Hex())).Err(); err != nil {
+		log.Printf("Failed to clear peers of call %s: %v", room.ID.Hex(), err)
+	}
+
+	s.publish(ctx, eventType, room, primitive.NilObjectID, participantIDs(room))
+	return room, nil
+}
+
+// stateError explains why a participant update did not apply
+func (s *CallService) stateError(ctx context.Context, roomID primitive.ObjectID, err error) error {
+	if !errors.Is(err, repositories.ErrCallStateChanged) {
+		return err
+	}
+	room, getErr := s.repo.GetByID(ctx, roomID)
+	if getErr == nil && room.Status == models.CallRoomEnded {
+		return ErrCallEnded
+	}
+	return ErrInvalidCallStateMove
+}
+
+func (s *CallService) addPeer(ctx context.Context, roomID, userID primitive.ObjectID) {
+	key := callPeersKey(roomID.Hex())
+	if err := s.redisClient.SAdd(ctx, key, userID.Hex()).Err(); err != nil {
+		log.Printf("Failed to add %s to peers of call %s: %v", userID.Hex(), roomID.Hex(), err)
+		return
+	}
+	s.redisClient.Expire(ctx, key, callPeersTTL)
+}
+
+func (s *CallService) removePeer(ctx context.Context, roomID, userID primitive.ObjectID) {
+	if err := s.redisClient.SRem(ctx, callPeersKey(roomID.Hex()), userID.Hex()).Err(); err != nil {
+		log.Printf("Failed to remove %s from peers of call %s: %v", userID.Hex(), roomID.Hex(), err)
+	}
+}
+
+// publish sends a call change through the Hub. Failures only log, as
+// clients also poll the call.
+func (s *CallService) publish(ctx context.Context, eventType string, room *models.CallRoom, userID primitive.ObjectID, recipients []string) {
+	if len(recipients) == 0 {
+		return
+	}
+	event := models.CallRoomEvent{Room: room}
+	if !userID.IsZero() {
+		event.UserID = userID.Hex()
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to marshal %s event for call %s: %v", eventType, room.ID.Hex(), err)
+		return
+	}
+	eventBytes, err := json.Marshal(models.WebSocketEvent{
+		Type:       eventType,
+		Data:       data,
+		Recipients: recipients,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal %s event for call %s: %v", eventType, room.ID.Hex(), err)
+		return
+	}
+	if err := s.producer.ProduceMessage(ctx, kafkago.Message{
+		Key:   []byte(room.ID.Hex()),
+		Value: eventBytes,
+		Time:  time.Now(),
+	}); err != nil {
+		log.Printf("Failed to publish %s event for call %s: %v", eventType, room.ID.Hex(), err)
+	}
+}
+
+// DeleteUserData takes the user out of their call history
+func (s *CallService) DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	return s.repo.DeleteByUser(ctx, userID)
+}
+
+// participantIDs lists the call's participants, only those in the given
+// states if any are given
+func participantIDs(room *models.CallRoom, statuses ...models.CallParticipantStatus) []string {
+	ids := make([]string, 0, len(room.Participants))
+	for _, p := range room.Participants {
+		if len(statuses) > 0 {
+			match := false
+			for _, status := range statuses {
+				if p.Status == status {
+					match = true
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+		}
+		ids = append(ids, p.UserID.Hex())
+	}
+	return ids
+}
+
+func secondsSince(from *time.Time, now time.Time) int64 {
+	if from == nil {
+		return 0
+	}
+	return int64(now.Sub(*from).Seconds())
+}
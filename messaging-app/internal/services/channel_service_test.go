@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSortChannelsByActivity(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	channels := []models.Channel{
+		{Name: "quiet", CreatedAt: now.Add(-2 * time.Hour)},
+		{Name: "old post", CreatedAt: now.Add(-3 * time.Hour), LastPostAt: &earlier},
+		{Name: "new post", CreatedAt: now.Add(-4 * time.Hour), LastPostAt: &now},
+		{Name: "new channel", CreatedAt: now.Add(-30 * time.Minute)},
+	}
+
+	sortChannelsByActivity(channels)
+
+	var names []string
+	for _, channel := range channels {
+		names = append(names, channel.Name)
+	}
+	assert.Equal(t, []string{"new post", "new channel", "old post", "quiet"}, names)
+}
+
+func TestParsePostIDs(t *testing.T) {
+	a, b := primitive.NewObjectID(), primitive.NewObjectID()
+
+	ids, err := parsePostIDs([]string{a.Hex(), b.Hex(), a.Hex()})
+	assert.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{a, b}, ids)
+
+	_, err = parsePostIDs([]string{a.Hex(), "nope"})
+	assert.ErrorIs(t, err, ErrInvalidChannelPostID)
+}
+
+func TestParseChannelCursor(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	cursor, err := parseChannelCursor("")
+	assert.NoError(t, err)
+	assert.True(t, cursor.IsZero())
+
+	cursor, err = parseChannelCursor(id.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, id, cursor)
+
+	_, err = parseChannelCursor("nope")
+	assert.ErrorIs(t, err, ErrInvalidChannelCursor)
+}
+
+func TestChannelHasAdmin(t *testing.T) {
+	owner, admin, subscriber := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	channel := models.Channel{OwnerID: owner, Admins: []primitive.ObjectID{owner, admin}}
+
+	assert.True(t, channel.HasAdmin(owner))
+	assert.True(t, channel.HasAdmin(admin))
+	assert.False(t, channel.HasAdmin(subscriber))
+}
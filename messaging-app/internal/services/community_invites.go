@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const communityInviteCodeLength = 12
+
+var (
+	ErrCommunityInviteNotFound = apperrors.NotFound("invite link not found")
+	ErrCommunityInviteExpired  = apperrors.Validation("invite link has expired or reached its use limit")
+)
+
+// CreateInvite creates an invite link for the community. Only admins can
+// create invites.
+func (s *CommunityService) CreateInvite(ctx context.Context, communityID, actorID primitive.ObjectID, req models.CreateCommunityInviteRequest) (*models.CommunityInvite, error) {
+	if err := s.requireAdmin(ctx, communityID, actorID); err != nil {
+		return nil, err
+	}
+
+	code, err := utils.GenerateRandomString(communityInviteCodeLength)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	invite := &models.CommunityInvite{
+		CommunityID: communityID,
+		Code:        code,
+		CreatedBy:   actorID,
+		MaxUses:     req.MaxUses,
+		CreatedAt:   now,
+	}
+	if req.ExpiresInHours > 0 {
+		expiresAt := now.Add(time.Duration(req.ExpiresInHours) * time.Hour)
+		invite.ExpiresAt = &expiresAt
+	}
+	if err := s.inviteRepo.Create(ctx, invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// ListInvites returns the community's invite links, newest first
+func (s *CommunityService) ListInvites(ctx context.Context, communityID, actorID primitive.ObjectID) ([]models.CommunityInvite, error) {
+	if err := s.requireAdmin(ctx, communityID, actorID); err != nil {
+		return nil, err
+	}
+	return s.inviteRepo.ListByCommunity(ctx, communityID)
+}
+
+// RevokeInvite disables an invite link
+func (s *CommunityService) RevokeInvite(ctx context.Context, communityID, actorID primitive.ObjectID, code string) error {
+	if err := s.requireAdmin(ctx, communityID, actorID); err != nil {
+		return err
+	}
+	deleted, err := s.inviteRepo.Delete(ctx, communityID, code)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrCommunityInviteNotFound
+	}
+	return nil
+}
+
+// PreviewInvite shows the community behind an invite link, so people know
+// what they join before following it
+func (s *CommunityService) PreviewInvite(ctx context.Context, code string, userID primitive.ObjectID) (*models.CommunityInvitePreview, error) {
+	invite, err := s.usableInvite(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	community, err := s.communityRepo.GetByID(ctx, invite.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &models.CommunityInvitePreview{
+		Community: *s.mapToResponse(community, userID),
+		ExpiresAt: invite.ExpiresAt,
+	}
+	if invite.MaxUses > 0 {
+		remaining := invite.MaxUses - invite.Uses
+		preview.RemainingUses = &remaining
+	}
+	return preview, nil
+}
+
+// JoinWithInvite makes the user a member of the invite's community right
+// away, skipping join approval. A pending join request of theirs is resolved
+// as approved.
+func (s *CommunityService) JoinWithInvite(ctx context.Context, code string, userID primitive.ObjectID) (*models.CommunityResponse, error) {
+	invite, err := s.usableInvite(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	community, err := s.communityRepo.GetByID(ctx, invite.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+	if containsID(community.Members, userID) {
+		return nil, ErrAlreadyCommunityMember
+	}
+	if containsID(community.BannedUsers, userID) {
+		return nil, ErrBannedFromCommunity
+	}
+
+	// Count the use first, so concurrent joins can't exceed the limit
+	invite, err = s.inviteRepo.Use(ctx, code, time.Now())
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrCommunityInviteExpired
+		}
+		return nil, err
+	}
+	if err := s.communityRepo.AddMember(ctx, invite.CommunityID, userID); err != nil {
+		if releaseErr := s.inviteRepo.Release(ctx, invite.ID); releaseErr != nil {
+			log.Printf("Failed to release use of community invite %s: %v", code, releaseErr)
+		}
+		return nil, err
+	}
+	if containsID(community.PendingMembers, userID) {
+		s.publishJoinRequestEvent(ctx, community.ID, userID, models.CommunityJoinRequestApproved, invite.CreatedBy)
+	}
+
+	return s.GetDetailedCommunityResponse(ctx, community.ID, userID)
+}
+
+// usableInvite looks an invite up by code and checks it can still be used
+func (s *CommunityService) usableInvite(ctx context.Context, code string) (*models.CommunityInvite, error) {
+	invite, err := s.inviteRepo.GetByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrCommunityInviteNotFound
+		}
+		return nil, err
+	}
+	if invite.ExpiresAt != nil && !invite.ExpiresAt.After(time.Now()) {
+		return nil, ErrCommunityInviteExpired
+	}
+	if invite.MaxUses > 0 && invite.Uses >= invite.MaxUses {
+		return nil, ErrCommunityInviteExpired
+	}
+	return invite, nil
+}
+
+func (s *CommunityService) requireAdmin(ctx context.Context, communityID, userID primitive.ObjectID) error {
+	isAdmin, err := s.IsAdmin(ctx, communityID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return ErrNotCommunityAdmin
+	}
+	return nil
+}
+
+// publishJoinRequestEvent tells the community's admins that a join request
+// came in (COMMUNITY_JOIN_REQUESTED) or was resolved
+// (COMMUNITY_JOIN_REQUEST_RESOLVED). The requester hears about resolutions
+// too.
+func (s *CommunityService) publishJoinRequestEvent(ctx context.Context, communityID, userID primitive.ObjectID, status models.CommunityJoinRequestStatus, resolvedBy primitive.ObjectID) {
+	community, err := s.communityRepo.GetByID(ctx, communityID)
+	if err != nil {
+		log.Printf("Failed to fetch community %s for join request event: %v", communityID.Hex(), err)
+		return
+	}
+
+	event := models.CommunityJoinRequestEvent{
+		CommunityID:   communityID.Hex(),
+		CommunityName: community.Name,
+		User:          models.PostAuthor{ID: userID.Hex()},
+		Status:        status,
+		PendingCount:  len(community.PendingMembers),
+	}
+	if !resolvedBy.IsZero() {
+		event.ResolvedBy = resolvedBy.Hex()
+	}
+	if user, err := s.userRepo.FindUserByID(ctx, userID); err == nil {
+		event.User.Username = user.Username
+		event.User.FullName = user.FullName
+		event.User.Avatar = user.Avatar
+	}
+
+	eventType := "COMMUNITY_JOIN_REQUESTED"
+	recipients := make([]string, 0, len(community.Admins)+1)
+	for _, adminID := range community.Admins {
+		recipients = append(recipients, adminID.Hex())
+	}
+	if status != models.CommunityJoinRequestPending {
+		eventType = "COMMUNITY_JOIN_REQUEST_RESOLVED"
+		recipients = append(recipients, userID.Hex())
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to marshal join request event for community %s: %v", communityID.Hex(), err)
+		return
+	}
+	eventBytes, err := json.Marshal(models.WebSocketEvent{
+		Type:       eventType,
+		Data:       data,
+		Recipients: recipients,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal join request event for community %s: %v", communityID.Hex(), err)
+		return
+	}
+	if err := s.producer.ProduceMessage(ctx, kafkago.Message{
+		Key:   []byte(communityID.Hex()),
+		Value: eventBytes,
+		Time:  time.Now(),
+	}); err != nil {
+		log.Printf("Failed to publish join request event for community %s: %v", communityID.Hex(), err)
+	}
+}
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"messaging-app/internal/repositories"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrCommunityMuted      = apperrors.Forbidden("you are muted in this community")
+	ErrPostLocked          = apperrors.Forbidden("comments on this post are locked")
+	ErrPostNotInCommunity  = apperrors.NotFound("post not found in this community")
+	ErrPostNotRemoved      = apperrors.Validation("post was not removed")
+	ErrNotCommunityMember  = apperrors.Validation("user is not a member of this community")
+	ErrCannotModerateAdmin = apperrors.Forbidden("community admins cannot be muted or banned")
+	ErrMemberNotMuted      = apperrors.NotFound("user is not muted")
+	ErrUserNotBanned       = apperrors.NotFound("user is not banned")
+)
+
+// CommunityModerationService lets community admins remove and lock posts,
+// mute and ban members, and review what they did in the moderation log
+type CommunityModerationService struct {
+	communityRepo *repositories.CommunityRepository
+	logRepo       *repositories.CommunityModerationLogRepository
+	feedRepo      *repositories.FeedRepository
+	feed          *FeedService
+}
+
+func NewCommunityModerationService(communityRepo *repositories.CommunityRepository, logRepo *repositories.CommunityModerationLogRepository, feedRepo *repositories.FeedRepository, feed *FeedService) *CommunityModerationService {
+	return &CommunityModerationService{
+		communityRepo: communityRepo,
+		logRepo:       logRepo,
+		feedRepo:      feedRepo,
+		feed:          feed,
+	}
+}
+
+// RemovePost takes a community post out of every feed. The author keeps
+// seeing it as declined, together with the reason.
+func (s *CommunityModerationService) RemovePost(ctx context.Context, communityID, actorID, postID primitive.ObjectID, reason string) (*models.Post, error) {
+	post, err := s.communityPost(ctx, communityID, actorID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.feedRepo.UpdatePost(ctx, post.ID, bson.M{
+		"status":         models.PostStatusDeclined,
+		"removal_reason": reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.feedRepo.UnpinPost(ctx, post.ID); err != nil {
+		log.Printf("Failed to unpin removed post %s: %v", post.ID.Hex(), err)
+	}
+	if post.Status == models.PostStatusActive {
+		s.feed.publishPostEvent(ctx, "PostDeleted", post)
+	}
+	s.record(ctx, communityID, actorID, models.CommunityModerationRemovePost, "post", post.ID, reason, nil)
+	return updated, nil
+}
+
+// RestorePost publishes a removed or declined community post again
+func (s *CommunityModerationService) RestorePost(ctx context.Context, communityID, actorID, postID primitive.ObjectID) (*models.Post, error) {
+	post, err := s.communityPost(ctx, communityID, actorID, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status != models.PostStatusDeclined {
+		return nil, ErrPostNotRemoved
+	}
+
+	updated, err := s.feedRepo.UpdatePost(ctx, post.ID, bson.M{
+		"status":         models.PostStatusActive,
+		"removal_reason": "",
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.feed.publishPostEvent(ctx, "PostCreated", updated)
+	s.record(ctx, communityID, actorID, models.CommunityModerationRestorePost, "post", post.ID, "", nil)
+	return updated, nil
+}
+
+// LockPost closes a community post to new comments and replies
+func (s *CommunityModerationService) LockPost(ctx context.Context, communityID, actorID, postID primitive.ObjectID, reason string) (*models.Post, error) {
+	post, err := s.communityPost(ctx, communityID, actorID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.feedRepo.UpdatePost(ctx, post.ID, bson.M{
+		"locked_at":   time.Now(),
+		"lock_reason": reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, communityID, actorID, models.CommunityModerationLockPost, "post", post.ID, reason, nil)
+	return updated, nil
+}
+
+// UnlockPost opens a locked community post to comments again
+func (s *CommunityModerationService) UnlockPost(ctx context.Context, communityID, actorID, postID primitive.ObjectID) (*models.Post, error) {
+	post, err := s.communityPost(ctx, communityID, actorID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.feedRepo.UpdatePost(ctx, post.ID, bson.M{
+		"locked_at":   nil,
+		"lock_reason": "",
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, communityID, actorID, models.CommunityModerationUnlockPost, "post", post.ID, "", nil)
+	return updated, nil
+}
+
+// MuteMember keeps a member from posting and commenting for a while. Muting
+// a muted member again replaces their mute.
+func (s *CommunityModerationService) MuteMember(ctx context.Context, communityID, actorID, targetID primitive.ObjectID, req models.MuteCommunityMemberRequest) (*models.CommunityMute, error) {
+	community, err := s.adminCommunity(ctx, communityID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if containsID(community.Admins, targetID) {
+		return nil, ErrCannotModerateAdmin
+	}
+	if !containsID(community.Members, targetID) {
+		return nil, ErrNotCommunityMember
+	}
+
+	mute := models.CommunityMute{
+		UserID:  targetID,
+		Until:   time.Now().Add(time.Duration(req.DurationMinutes) * time.Minute),
+		Reason:  req.Reason,
+		MutedBy: actorID,
+	}
+	if err := s.communityRepo.SetMute(ctx, communityID, mute); err != nil {
+		return nil, err
+	}
+	s.record(ctx, communityID, actorID, models.CommunityModerationMuteMember, "user", targetID, req.Reason, &mute.Until)
+	return &mute, nil
+}
+
+func (s *CommunityModerationService) UnmuteMember(ctx context.Context, communityID, actorID, targetID primitive.ObjectID) error {
+	if _, err := s.adminCommunity(ctx, communityID, actorID); err != nil {
+		return err
+	}
+	removed, err := s.communityRepo.RemoveMute(ctx, communityID, targetID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrMemberNotMuted
+	}
+	s.record(ctx, communityID, actorID, models.CommunityModerationUnmuteMember, "user", targetID, "", nil)
+	return nil
+}
+
+// ListMutes returns the mutes still in force
+func (s *CommunityModerationService) ListMutes(ctx context.Context, communityID, actorID primitive.ObjectID) ([]models.CommunityMute, error) {
+	community, err := s.adminCommunity(ctx, communityID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	mutes := []models.CommunityMute{}
+	for _, mute := range community.MutedMembers {
+		if mute.Until.After(now) {
+			mutes = append(mutes, mute)
+		}
+	}
+	return mutes, nil
+}
+
+// BanMember removes a user from the community and keeps them from joining,
+// posting or commenting again. Users who are not members can be banned too.
+func (s *CommunityModerationService) BanMember(ctx context.Context, communityID, actorID, targetID primitive.ObjectID, reason string) error {
+	community, err := s.adminCommunity(ctx, communityID, actorID)
+	if err != nil {
+		return err
+	}
+	if containsID(community.Admins, targetID) || community.CreatorID == targetID {
+		return ErrCannotModerateAdmin
+	}
+
+	if err := s.communityRepo.BanUser(ctx, communityID, targetID); err != nil {
+		return err
+	}
+	s.record(ctx, communityID, actorID, models.CommunityModerationBanMember, "user", targetID, reason, nil)
+	return nil
+}
+
+// UnbanMember lets a banned user join again. They are not made a member.
+func (s *CommunityModerationService) UnbanMember(ctx context.Context, communityID, actorID, targetID primitive.ObjectID) error {
+	if _, err := s.adminCommunity(ctx, communityID, actorID); err != nil {
+		return err
+	}
+	removed, err := s.communityRepo.UnbanUser(ctx, communityID, targetID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrUserNotBanned
+	}
+	s.record(ctx, communityID, actorID, models.CommunityModerationUnbanMember, "user", targetID, "", nil)
+	return nil
+}
+
+// ListBans returns the users on the community's ban list
+func (s *CommunityModerationService) ListBans(ctx context.Context, communityID, actorID primitive.ObjectID) ([]models.User, error) {
+	if _, err := s.adminCommunity(ctx, communityID, actorID); err != nil {
+		return nil, err
+	}
+	return s.communityRepo.GetBannedUsers(ctx, communityID)
+}
+
+// ModerationLog pages through what the community's admins did, newest first
+func (s *CommunityModerationService) ModerationLog(ctx context.Context, communityID, actorID primitive.ObjectID, page, limit int64) (*models.CommunityModerationLogResponse, error) {
+	if _, err := s.adminCommunity(ctx, communityID, actorID); err != nil {
+		return nil, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	entries, total, err := s.logRepo.List(ctx, communityID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CommunityModerationLogResponse{Entries: entries, Total: total, Page: page, Limit: limit}, nil
+}
+
+// adminCommunity loads the community, provided actorID is one of its admins
+func (s *CommunityModerationService) adminCommunity(ctx context.Context, communityID, actorID primitive.ObjectID) (*models.Community, error) {
+	community, err := s.communityRepo.GetByID(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsID(community.Admins, actorID) {
+		return nil, ErrNotCommunityAdmin
+	}
+	return community, nil
+}
+
+// communityPost loads a post of the community for one of its admins.
+// Trashed posts belong to their author alone.
+func (s *CommunityModerationService) communityPost(ctx context.Context, communityID, actorID, postID primitive.ObjectID) (*models.Post, error) {
+	if _, err := s.adminCommunity(ctx, communityID, actorID); err != nil {
+		return nil, err
+	}
+	post, err := s.feedRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrPostNotInCommunity
+		}
+		return nil, err
+	}
+	if post.CommunityID == nil || *post.CommunityID != communityID || post.Status == models.PostStatusDeleted {
+		return nil, ErrPostNotInCommunity
+	}
+	return post, nil
+}
+
+func (s *CommunityModerationService) record(ctx context.Context, communityID, actorID primitive.ObjectID, action models.CommunityModerationAction, targetType string, targetID primitive.ObjectID, reason string, until *time.Time) {
+	entry := &models.CommunityModerationLogEntry{
+		CommunityID: communityID,
+		ActorID:     actorID,
+		Action:      action,
+		TargetType:  targetType,
+		TargetID:    targetID,
+		Reason:      reason,
+		Until:       until,
+	}
+	if err := s.logRepo.Append(ctx, entry); err != nil {
+		log.Printf("Failed to record %s in moderation log of community %s: %v", action, communityID.Hex(), err)
+	}
+}
+
+// communityWriteBlock tells why the user may not post or comment in the
+// community, if they may not
+func communityWriteBlock(community *models.Community, userID primitive.ObjectID) error {
+	if community.IsBanned(userID) {
+		return ErrBannedFromCommunity
+	}
+	if _, muted := community.MutedUntil(userID, time.Now()); muted {
+		return ErrCommunityMuted
+	}
+	return nil
+}
+
+// checkCanComment rejects comments and replies on locked posts and from
+// users banned or muted in the post's community
+func (s *FeedService) checkCanComment(ctx context.Context, post *models.Post, userID primitive.ObjectID) error {
+	if post.LockedAt != nil {
+		return ErrPostLocked
+	}
+	if post.CommunityID == nil {
+		return nil
+	}
+	community, err := s.communityRepo.GetByID(ctx, *post.CommunityID)
+	if err != nil {
+		// A community deleted in the meantime has nobody left to enforce it
+		if err.Error() == "community not found" {
+			return nil
+		}
+		return fmt.Errorf("failed to get community: %w", err)
+	}
+	return communityWriteBlock(community, userID)
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListPendingPosts pages through the community's approval queue, oldest
+// first, so admins can judge each post by what its author did before
+func (s *CommunityModerationService) ListPendingPosts(ctx context.Context, communityID, actorID primitive.ObjectID, page, limit int64) (*models.PendingCommunityPostsResponse, error) {
+	community, err := s.adminCommunity(ctx, communityID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	filter := bson.M{"community_id": communityID, "status": models.PostStatusPending}
+	total, err := s.feedRepo.CountPosts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	posts, err := s.feedRepo.ListPosts(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(map[primitive.ObjectID][2]int64)
+	pending := make([]models.PendingCommunityPost, 0, len(posts))
+	for _, post := range posts {
+		counts, seen := history[post.UserID]
+		if !seen {
+			if counts, err = s.authorHistory(ctx, communityID, post.UserID); err != nil {
+				return nil, err
+			}
+			history[post.UserID] = counts
+		}
+
+		entry := models.PendingCommunityPost{
+			Post:           post,
+			AuthorIsMember: containsID(community.Members, post.UserID),
+			AuthorApproved: counts[0],
+			AuthorDeclined: counts[1],
+		}
+		if until, muted := community.MutedUntil(post.UserID, time.Now()); muted {
+			entry.AuthorMutedUntil = &until
+		}
+		pending = append(pending, entry)
+	}
+
+	return &models.PendingCommunityPostsResponse{Posts: pending, Total: total, Page: page, Limit: limit}, nil
+}
+
+// ReviewPendingPosts approves or declines pending posts of the community in
+// bulk and tells their authors. Approved posts reach feeds only now; declined
+// ones stay visible to their authors, together with the reason.
+func (s *CommunityModerationService) ReviewPendingPosts(ctx context.Context, communityID, actorID primitive.ObjectID, req models.ReviewPendingPostsRequest) (*models.ReviewPendingPostsResult, error) {
+	community, err := s.adminCommunity(ctx, communityID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	approve := req.Decision == "approve"
+	update := bson.M{"status": models.PostStatusActive}
+	action := models.CommunityModerationApprovePost
+	reason := ""
+	if !approve {
+		update = bson.M{"status": models.PostStatusDeclined, "removal_reason": req.Reason}
+		action = models.CommunityModerationDeclinePost
+		reason = req.Reason
+	}
+
+	result := &models.ReviewPendingPostsResult{
+		Approved: []primitive.ObjectID{},
+		Declined: []primitive.ObjectID{},
+		Skipped:  []primitive.ObjectID{},
+	}
+	for _, postID := range req.PostIDs {
+		// Skips posts settled in the meantime, by another admin or the author
+		post, err := s.feedRepo.SettlePendingPost(ctx, communityID, postID, update)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			result.Skipped = append(result.Skipped, postID)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if approve {
+			s.feed.publishPostEvent(ctx, "PostCreated", post)
+			result.Approved = append(result.Approved, post.ID)
+		} else {
+			result.Declined = append(result.Declined, post.ID)
+		}
+		s.notifyPostReviewed(ctx, community, actorID, post, approve, reason)
+		s.record(ctx, communityID, actorID, action, "post", post.ID, reason, nil)
+	}
+	return result, nil
+}
+
+// authorHistory counts the author's earlier posts in the community that were
+// approved and that were declined or removed
+func (s *CommunityModerationService) authorHistory(ctx context.Context, communityID, authorID primitive.ObjectID) ([2]int64, error) {
+	var counts [2]int64
+	for i, status := range []models.PostStatus{models.PostStatusActive, models.PostStatusDeclined} {
+		n, err := s.feedRepo.CountPosts(ctx, bson.M{"community_id": communityID, "user_id": authorID, "status": status})
+		if err != nil {
+			return counts, err
+		}
+		counts[i] = n
+	}
+	return counts, nil
+}
+
+func (s *CommunityModerationService) notifyPostReviewed(ctx context.Context, community *models.Community, actorID primitive.ObjectID, post *models.Post, approved bool, reason string) {
+	if post.UserID == actorID {
+		return
+	}
+	notificationType := models.NotificationTypePostApproved
+	content := fmt.Sprintf("Your post in %s was approved.", community.Name)
+	if !approved {
+		notificationType = models.NotificationTypePostDeclined
+		content = fmt.Sprintf("Your post in %s was declined.", community.Name)
+		if reason != "" {
+			content = fmt.Sprintf("Your post in %s was declined: %s", community.Name, reason)
+		}
+	}
+
+	_, err := s.feed.notificationService.CreateNotification(ctx, &models.CreateNotificationRequest{
+		RecipientID: post.UserID,
+		SenderID:    actorID,
+		Type:        notificationType,
+		TargetID:    post.ID,
+		TargetType:  "post",
+		Content:     content,
+		Data:        map[string]interface{}{"community_id": community.ID.Hex()},
+	})
+	if err != nil {
+		log.Printf("Failed to notify author of reviewed post %s: %v", post.ID.Hex(), err)
+	}
+}
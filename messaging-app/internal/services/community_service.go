@@ -5,22 +5,36 @@ import (
 	"errors"
 	"strings"
 
+	"messaging-app/internal/kafka"
 	"messaging-app/internal/repositories"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var (
+	ErrAlreadyCommunityMember = apperrors.Conflict("already a member")
+	ErrJoinRequestPending     = apperrors.Conflict("request already pending")
+	ErrNoJoinRequest          = apperrors.NotFound("user is not in pending list")
+	ErrBannedFromCommunity    = apperrors.Forbidden("you are banned from this community")
+	ErrNotCommunityAdmin      = apperrors.Forbidden("only community admins can do this")
+)
+
 type CommunityService struct {
 	communityRepo *repositories.CommunityRepository
+	inviteRepo    *repositories.CommunityInviteRepository
 	userRepo      *repositories.UserRepository
+	producer      *kafka.MessageProducer
 }
 
-func NewCommunityService(communityRepo *repositories.CommunityRepository, userRepo *repositories.UserRepository) *CommunityService {
+func NewCommunityService(communityRepo *repositories.CommunityRepository, inviteRepo *repositories.CommunityInviteRepository, userRepo *repositories.UserRepository, producer *kafka.MessageProducer) *CommunityService {
 	return &CommunityService{
 		communityRepo: communityRepo,
+		inviteRepo:    inviteRepo,
 		userRepo:      userRepo,
+		producer:      producer,
 	}
 }
 
@@ -74,17 +88,21 @@ func (s *CommunityService) JoinCommunity(ctx context.Context, communityID, userI
 	// Check if already a member
 	for _, memberID := range community.Members {
 		if memberID == userID {
-			return errors.New("already a member")
+			return ErrAlreadyCommunityMember
 		}
 	}
 
 	// Check if pending
 	for _, pendingID := range community.PendingMembers {
 		if pendingID == userID {
-			return errors.New("request already pending")
+			return ErrJoinRequestPending
 		}
 	}
 
+	if containsID(community.BannedUsers, userID) {
+		return ErrBannedFromCommunity
+	}
+
 	// If Hidden, can't join without invite? Or maybe they found the link.
 	// Assume if they have the ID, they can Request to Join if allowed.
 
@@ -92,7 +110,11 @@ func (s *CommunityService) JoinCommunity(ctx context.Context, communityID, userI
 		// Private groups usually require approval unless explicitly disabled
 		// actually user said "does not it include member approval for private group ?"
 		// so yes, private = approval needed typically.
-		return s.communityRepo.AddPendingMember(ctx, communityID, userID)
+		if err := s.communityRepo.AddPendingMember(ctx, communityID, userID); err != nil {
+			return err
+		}
+		s.publishJoinRequestEvent(ctx, communityID, userID, models.CommunityJoinRequestPending, primitive.NilObjectID)
+		return nil
 	}
 
 	return s.communityRepo.AddMember(ctx, communityID, userID)
@@ -118,33 +140,20 @@ func (s *CommunityService) ApproveMember(ctx context.Context, communityID, actor
 	}
 
 	// Check if actor is admin
-	isAdmin := false
-	for _, adminID := range community.Admins {
-		if adminID == actorID {
-			isAdmin = true
-			break
-		}
-	}
-	if !isAdmin {
-		return errors.New("unauthorized: only admins can approve members")
-	}
-
-	// Check if target is in pending list
-	isPending := false
-	for _, pendingID := range community.PendingMembers {
-		if pendingID == targetID {
-			isPending = true
-			break
-		}
-	}
-	if !isPending {
-		return errors.New("user is not in pending list")
+	if !containsID(community.Admins, actorID) {
+		return ErrNotCommunityAdmin
 	}
 
-	if err := s.communityRepo.RemovePendingMember(ctx, communityID, targetID); err != nil {
+	// Another admin may have resolved the request meanwhile
+	approved, err := s.communityRepo.ApprovePendingMember(ctx, communityID, targetID)
+	if err != nil {
 		return err
 	}
-	return s.communityRepo.AddMember(ctx, communityID, targetID)
+	if !approved {
+		return ErrNoJoinRequest
+	}
+	s.publishJoinRequestEvent(ctx, communityID, targetID, models.CommunityJoinRequestApproved, actorID)
+	return nil
 }
 
 func (s *CommunityService) RejectMember(ctx context.Context, communityID, actorID, targetID primitive.ObjectID) error {
@@ -154,18 +163,32 @@ func (s *CommunityService) RejectMember(ctx context.Context, communityID, actorI
 	}
 
 	// Check if actor is admin
-	isAdmin := false
-	for _, adminID := range community.Admins {
-		if adminID == actorID {
-			isAdmin = true
-			break
-		}
+	if !containsID(community.Admins, actorID) {
+		return ErrNotCommunityAdmin
 	}
-	if !isAdmin {
-		return errors.New("unauthorized")
+
+	removed, err := s.communityRepo.RemovePendingMember(ctx, communityID, targetID)
+	if err != nil {
+		return err
 	}
+	if !removed {
+		return ErrNoJoinRequest
+	}
+	s.publishJoinRequestEvent(ctx, communityID, targetID, models.CommunityJoinRequestRejected, actorID)
+	return nil
+}
 
-	return s.communityRepo.RemovePendingMember(ctx, communityID, targetID)
+// CancelJoinRequest withdraws the user's own pending join request
+func (s *CommunityService) CancelJoinRequest(ctx context.Context, communityID, userID primitive.ObjectID) error {
+	removed, err := s.communityRepo.RemovePendingMember(ctx, communityID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNoJoinRequest
+	}
+	s.publishJoinRequestEvent(ctx, communityID, userID, models.CommunityJoinRequestCancelled, primitive.NilObjectID)
+	return nil
 }
 
 func (s *CommunityService) ListCommunities(ctx context.Context, userID primitive.ObjectID, limit, page int64, query string) ([]models.CommunityResponse, int64, error) {
@@ -374,7 +397,7 @@ func (s *CommunityService) GetPendingMembers(ctx context.Context, communityID, u
 		return nil, 0, err
 	}
 	if !isAdmin {
-		return nil, 0, errors.New("unauthorized: only admins can view pending members")
+		return nil, 0, ErrNotCommunityAdmin
 	}
 
 	return s.communityRepo.GetPendingMembers(ctx, communityID, limit, page)
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"messaging-app/internal/kafka"
+	"messaging-app/internal/repositories"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SetModerator screens posts, comments and replies against the text
+// moderation rules and applies moderators' decisions on them
+func (s *FeedService) SetModerator(moderator *moderation.Moderator) {
+	s.moderator = moderator
+	moderator.Handle(models.ModeratedPost, s)
+	moderator.Handle(models.ModeratedComment, s)
+	moderator.Handle(models.ModeratedReply, s)
+}
+
+// screenText refuses text a reject rule matches and returns the decision
+// for everything else
+func (s *FeedService) screenText(ctx context.Context, text string, communityID *primitive.ObjectID) (moderation.Decision, error) {
+	decision := s.moderator.Screen(ctx, text, communityID)
+	if decision.Rejected() {
+		return decision, moderation.ErrContentRejected
+	}
+	return decision, nil
+}
+
+// postCommunity returns the community a post belongs to, so comments and
+// replies are screened with that community's rules too
+func (s *FeedService) postCommunity(ctx context.Context, postID primitive.ObjectID) *primitive.ObjectID {
+	if s.moderator == nil {
+		return nil
+	}
+	post, err := s.feedRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil
+	}
+	return post.CommunityID
+}
+
+// ApproveContent publishes a post a hold rule kept pending. Flagged content
+// was never hidden and comments and replies are never held.
+func (s *FeedService) ApproveContent(ctx context.Context, item *models.ModerationQueueItem) error {
+	if item.ContentType != models.ModeratedPost || item.Action != models.ModerationActionHold {
+		return nil
+	}
+	post, err := s.moderatedPost(ctx, item)
+	if err != nil || post == nil {
+		return err
+	}
+	// Trashed or settled by community admins in the meantime
+	if post.Status != models.PostStatusPending {
+		return nil
+	}
+	if post.CommunityID != nil {
+		// A community deleted in the meantime has no approval left to wait on
+		community, err := s.communityRepo.GetByID(ctx, *post.CommunityID)
+		if err != nil && err.Error() != "community not found" {
+			return fmt.Errorf("failed to get community: %w", err)
+		}
+		// The community's own approval still applies
+		if community != nil && community.Settings.RequirePostApproval && !slices.Contains(community.Admins, post.UserID) {
+			return nil
+		}
+	}
+
+	updated, err := s.feedRepo.UpdatePost(ctx, post.ID, bson.M{"status": models.PostStatusActive})
+	if err != nil {
+		return err
+	}
+	s.publishPostEvent(ctx, "PostCreated", updated)
+	return nil
+}
+
+// RemoveContent declines a post or deletes a comment or reply
+func (s *FeedService) RemoveContent(ctx context.Context, item *models.ModerationQueueItem) error {
+	switch item.ContentType {
+	case models.ModeratedPost:
+		return s.declinePost(ctx, item)
+	case models.ModeratedComment:
+		commentID, err := primitive.ObjectIDFromHex(item.ContentID)
+		if err != nil {
+			return nil
+		}
+		comment, err := s.feedRepo.GetCommentByID(ctx, commentID)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.feedRepo.DeleteComment(ctx, comment.PostID, comment.ID); err != nil {
+			return err
+		}
+		return s.feedRepo.DecrementPostCommentCount(ctx, comment.PostID)
+	case models.ModeratedReply:
+		replyID, err := primitive.ObjectIDFromHex(item.ContentID)
+		if err != nil {
+			return nil
+		}
+		reply, err := s.feedRepo.GetReplyByID(ctx, replyID)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return s.feedRepo.DeleteReply(ctx, reply.CommentID, reply.ID)
+	}
+	return nil
+}
+
+// declinePost takes a removed post out of every feed. The author keeps
+// seeing it as declined, like a post community admins turned down.
+func (s *FeedService) declinePost(ctx context.Context, item *models.ModerationQueueItem) error {
+	post, err := s.moderatedPost(ctx, item)
+	if err != nil || post == nil {
+		return err
+	}
+	switch post.Status {
+	case models.PostStatusDeclined:
+		return nil
+	case models.PostStatusDeleted:
+		// Restoring it from the trash must not bring it back
+		_, err := s.feedRepo.UpdatePost(ctx, post.ID, bson.M{"status_before_delete": models.PostStatusDeclined})
+		return err
+	}
+
+	if _, err := s.feedRepo.UpdatePost(ctx, post.ID, bson.M{"status": models.PostStatusDeclined}); err != nil {
+		return err
+	}
+	if _, err := s.feedRepo.UnpinPost(ctx, post.ID); err != nil {
+		log.Printf("Failed to unpin declined post %s: %v", post.ID.Hex(), err)
+	}
+	if post.Status == models.PostStatusActive {
+		s.publishPostEvent(ctx, "PostDeleted", post)
+	}
+	return nil
+}
+
+// moderatedPost loads the post of a queue item, returning nil once it is gone
+func (s *FeedService) moderatedPost(ctx context.Context, item *models.ModerationQueueItem) (*models.Post, error) {
+	postID, err := primitive.ObjectIDFromHex(item.ContentID)
+	if err != nil {
+		return nil, nil
+	}
+	post, err := s.feedRepo.GetPostByID(ctx, postID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	return post, err
+}
+
+// SetModerator screens unencrypted messages against the text moderation
+// rules and applies moderators' decisions on them
+func (s *MessageService) SetModerator(moderator *moderation.Moderator) {
+	s.moderator = moderator
+	moderator.Handle(models.ModeratedMessage, s)
+}
+
+func (s *MessageService) reportMessage(ctx context.Context, decision moderation.Decision, msg *models.Message) {
+	if !decision.Matched() || msg == nil {
+		return
+	}
+	s.moderator.Report(ctx, decision, models.ModerationQueueItem{
+		ContentType: models.ModeratedMessage,
+		ContentID:   msg.StringID,
+		ParentID:    kafka.ConversationKey(*msg),
+		AuthorID:    msg.SenderID,
+	}, msg.Content)
+}
+
+// ApproveContent leaves the message as it is; messages are never held back
+func (s *MessageService) ApproveContent(ctx context.Context, item *models.ModerationQueueItem) error {
+	return nil
+}
+
+// RemoveContent deletes a message a moderator removed
+func (s *MessageService) RemoveContent(ctx context.Context, item *models.ModerationQueueItem) error {
+	if _, err := s.messageCassandraRepo.GetMessage(ctx, item.ParentID, item.ContentID); err != nil {
+		if errors.Is(err, repositories.ErrMessageNotFound) {
+			return nil
+		}
+		return err
+	}
+	actorID := item.AuthorID
+	if item.ReviewedBy != nil {
+		actorID = *item.ReviewedBy
+	}
+	return s.removeMessage(ctx, item.ParentID, item.ContentID, actorID)
+}
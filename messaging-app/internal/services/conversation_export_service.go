@@ -0,0 +1,326 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"time"
+
+	"messaging-app/internal/repositories"
+	"messaging-app/internal/storageclient"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// MaxActiveConversationExports caps the exports a user may have queued
+	// or running at once
+	MaxActiveConversationExports = 3
+
+	conversationExportTTL           = 7 * 24 * time.Hour
+	conversationExportURLExpiry     = time.Hour
+	conversationExportCheckInterval = 10 * time.Second
+	// conversationExportStaleAfter is how long a job may stay processing
+	// before another worker takes it over
+	conversationExportStaleAfter = 30 * time.Minute
+)
+
+var (
+	ErrConversationExportNotFound = apperrors.NotFound("export not found")
+	ErrInvalidConversationExport  = apperrors.Validation("invalid export ID")
+	ErrTooManyConversationExports = apperrors.Conflict(fmt.Sprintf("you can have at most %d conversation exports in progress", MaxActiveConversationExports))
+	ErrConversationExportDisabled = apperrors.Unavailable("conversation export requires the storage service")
+)
+
+// ConversationExportService turns a conversation into a downloadable JSON or
+// HTML file. Requests queue a job; a background worker builds the file and
+// uploads it through the storage service, where it is kept for a week.
+type ConversationExportService struct {
+	repo         *repositories.ConversationExportRepository
+	messageRepo  *repositories.MessageCassandraRepository
+	messages     *MessageService
+	storage      *storageclient.Client
+	pollInterval time.Duration
+}
+
+func NewConversationExportService(repo *repositories.ConversationExportRepository, messageRepo *repositories.MessageCassandraRepository, messages *MessageService, storage *storageclient.Client) *ConversationExportService {
+	return &ConversationExportService{
+		repo:         repo,
+		messageRepo:  messageRepo,
+		messages:     messages,
+		storage:      storage,
+		pollInterval: conversationExportCheckInterval,
+	}
+}
+
+// RequestExport queues an export of a conversation the user takes part in
+func (s *ConversationExportService) RequestExport(ctx context.Context, userID primitive.ObjectID, conversationID string, isGroup bool, format string) (*models.ConversationExport, error) {
+	if s.storage == nil {
+		return nil, ErrConversationExportDisabled
+	}
+	if format == "" {
+		format = models.ConversationExportJSON
+	}
+
+	convKey, err := s.messages.ConversationKey(ctx, userID, conversationID, isGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := s.repo.CountActive(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if active >= MaxActiveConversationExports {
+		return nil, ErrTooManyConversationExports
+	}
+
+	now := time.Now()
+	export := &models.ConversationExport{
+		UserID:         userID,
+		ConversationID: convKey,
+		Format:         format,
+		Status:         models.ConversationExportPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.repo.Create(ctx, export); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// GetExport returns one of the user's exports, with a download link once it
+// is ready
+func (s *ConversationExportService) GetExport(ctx context.Context, userID primitive.ObjectID, exportID string) (*models.ConversationExport, error) {
+	id, err := primitive.ObjectIDFromHex(exportID)
+	if err != nil {
+		return nil, ErrInvalidConversationExport
+	}
+	export, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrConversationExportNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if export.UserID != userID {
+		return nil, ErrConversationExportNotFound
+	}
+
+	s.signDownload(ctx, export)
+	return export, nil
+}
+
+// ListExports returns the user's exports, newest first
+func (s *ConversationExportService) ListExports(ctx context.Context, userID primitive.ObjectID) ([]models.ConversationExport, error) {
+	exports, err := s.repo.ListByUser(ctx, userID, 50)
+	if err != nil {
+		return nil, err
+	}
+	for i := range exports {
+		s.signDownload(ctx, &exports[i])
+	}
+	return exports, nil
+}
+
+func (s *ConversationExportService) signDownload(ctx context.Context, export *models.ConversationExport) {
+	if export.Status != models.ConversationExportReady || export.FileKey == "" || s.storage == nil {
+		return
+	}
+	url, err := s.storage.GetPresignedURL(ctx, export.FileKey, conversationExportURLExpiry)
+	if err != nil {
+		log.Printf("Failed to sign download of conversation export %s: %v", export.ID.Hex(), err)
+		return
+	}
+	export.DownloadURL = url
+}
+
+// StartExportWorker builds queued exports and removes expired ones until ctx
+// is cancelled
+func (s *ConversationExportService) StartExportWorker(ctx context.Context) {
+	if s.storage == nil {
+		return
+	}
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.processPending(ctx)
+			s.removeExpired(ctx)
+		}
+	}
+}
+
+func (s *ConversationExportService) processPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		export, err := s.repo.ClaimPending(ctx, time.Now().Add(-conversationExportStaleAfter))
+		if err != nil {
+			log.Printf("Failed to claim conversation export: %v", err)
+			return
+		}
+		if export == nil {
+			return
+		}
+
+		if err := s.build(ctx, export); err != nil {
+			log.Printf("Conversation export %s failed: %v", export.ID.Hex(), err)
+			if err := s.repo.MarkFailed(ctx, export.ID, "the export could not be created"); err != nil {
+				log.Printf("Failed to mark conversation export %s failed: %v", export.ID.Hex(), err)
+			}
+		}
+	}
+}
+
+func (s *ConversationExportService) build(ctx context.Context, export *models.ConversationExport) error {
+	messages, err := s.conversationMessages(ctx, export.ConversationID)
+	if err != nil {
+		return fmt.Errorf("failed to read messages: %w", err)
+	}
+
+	now := time.Now()
+	file := conversationExportFile{
+		ConversationID: export.ConversationID,
+		ExportedBy:     export.UserID.Hex(),
+		ExportedAt:     now,
+		Messages:       messages,
+	}
+
+	var data []byte
+	contentType := "application/json"
+	switch export.Format {
+	case models.ConversationExportHTML:
+		var buf bytes.Buffer
+		if err := conversationExportHTML.Execute(&buf, file); err != nil {
+			return fmt.Errorf("failed to render export: %w", err)
+		}
+		data = buf.Bytes()
+		contentType = "text/html; charset=utf-8"
+	default:
+		data, err = json.MarshalIndent(file, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode export: %w", err)
+		}
+	}
+
+	filename := fmt.Sprintf("conversation-export-%s.%s", export.ID.Hex(), export.Format)
+	result, err := s.storage.Upload(ctx, data, filename, contentType)
+	if err != nil {
+		return fmt.Errorf("failed to upload export: %w", err)
+	}
+
+	return s.repo.MarkReady(ctx, export.ID, result.Key, len(messages), now, now.Add(conversationExportTTL))
+}
+
+// conversationMessages pages through a whole conversation, archived months
+// included, and returns its messages oldest first
+func (s *ConversationExportService) conversationMessages(ctx context.Context, convKey string) ([]models.Message, error) {
+	var messages []models.Message
+	query := models.MessageQuery{ConversationID: convKey, Limit: exportMessagePageSize}
+	for {
+		page, err := s.messageRepo.GetMessages(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page {
+			if !m.IsDeleted {
+				messages = append(messages, m)
+			}
+		}
+		if len(page) < exportMessagePageSize {
+			break
+		}
+		query.Before = page[len(page)-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, nil
+}
+
+func (s *ConversationExportService) removeExpired(ctx context.Context) {
+	exports, err := s.repo.ListExpired(ctx, time.Now())
+	if err != nil {
+		log.Printf("Failed to list expired conversation exports: %v", err)
+		return
+	}
+	for _, export := range exports {
+		if err := s.deleteExport(ctx, export); err != nil {
+			log.Printf("Failed to remove expired conversation export %s: %v", export.ID.Hex(), err)
+		}
+	}
+}
+
+func (s *ConversationExportService) deleteExport(ctx context.Context, export models.ConversationExport) error {
+	if export.FileKey != "" && s.storage != nil {
+		if err := s.storage.Delete(ctx, export.FileKey); err != nil {
+			return err
+		}
+	}
+	return s.repo.Delete(ctx, export.ID)
+}
+
+// DeleteUserData removes the user's exports along with their files
+func (s *ConversationExportService) DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	exports, err := s.repo.ListByUser(ctx, userID, 0)
+	if err != nil {
+		return 0, err
+	}
+	var deleted int64
+	for _, export := range exports {
+		if err := s.deleteExport(ctx, export); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// conversationExportFile is the content of an export
+type conversationExportFile struct {
+	ConversationID string           `json:"conversation_id"`
+	ExportedBy     string           `json:"exported_by"`
+	ExportedAt     time.Time        `json:"exported_at"`
+	Messages       []models.Message `json:"messages"`
+}
+
+// conversationExportHTML renders an export as a standalone page. Message
+// content is escaped by html/template; encrypted messages show a placeholder
+// as their content is ciphertext only the participants' devices can read.
+var conversationExportHTML = template.Must(template.New("conversation_export").Funcs(template.FuncMap{
+	"timestamp": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04:05 UTC") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Conversation export</title>
+<style>
+body { font-family: sans-serif; max-width: 48rem; margin: 2rem auto; color: #1c1e21; }
+.message { border-bottom: 1px solid #e4e6eb; padding: 0.5rem 0; }
+.meta { color: #65676b; font-size: 0.8rem; }
+.content { white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>Conversation export</h1>
+<p class="meta">Exported {{timestamp .ExportedAt}} &middot; {{len .Messages}} messages</p>
+{{range .Messages}}<div class="message">
+<div class="meta">{{if .SenderName}}{{.SenderName}}{{else}}{{.SenderID.Hex}}{{end}} &middot; {{timestamp .CreatedAt}}{{if .IsEdited}} &middot; edited{{end}}</div>
+{{if .IsEncrypted}}<div class="content"><em>Encrypted message</em></div>{{else}}<div class="content">{{.Content}}</div>{{end}}
+{{range .MediaURLs}}<div><a href="{{.}}">{{.}}</a></div>{{end}}
+</div>
+{{end}}</body>
+</html>
+`))
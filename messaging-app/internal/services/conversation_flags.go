@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"messaging-app/internal/repositories"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxPinnedConversations is how many conversations a user may pin
+const maxPinnedConversations = 5
+
+var ErrPinnedConversationLimit = apperrors.Conflict(fmt.Sprintf("at most %d conversations can be pinned", maxPinnedConversations))
+
+// UpdateConversationFlags pins, archives or mutes a conversation in the
+// user's inbox, or undoes it, and sends the new flags to their devices.
+// Muting also mutes the conversation's notifications until it is unmuted.
+func (s *MessageService) UpdateConversationFlags(ctx context.Context, userID primitive.ObjectID, conversationID string, isGroup bool, req models.UpdateConversationFlagsRequest) (*models.ConversationFlags, error) {
+	convKey, err := s.ConversationKey(ctx, userID, conversationID, isGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	flags, err := s.messageCassandraRepo.GetConversationFlags(ctx, userID.Hex(), convKey)
+	if err != nil {
+		return nil, err
+	}
+	wasMuted := flags.Muted
+	now := time.Now()
+
+	if req.Pinned != nil && *req.Pinned && !flags.Pinned {
+		// Fail fast; the repository enforces the limit against concurrent pins
+		if err := s.checkPinnedConversationLimit(ctx, userID); err != nil {
+			return nil, err
+		}
+		if err := s.messageCassandraRepo.PinConversation(ctx, userID.Hex(), convKey, now, maxPinnedConversations); err != nil {
+			if errors.Is(err, repositories.ErrPinnedConversationLimit) {
+				return nil, ErrPinnedConversationLimit
+			}
+			return nil, err
+		}
+		req.Pinned = nil
+	}
+	if req.Pinned != nil || req.Archived != nil || req.Muted != nil {
+		if err := s.messageCassandraRepo.UpdateConversationFlags(ctx, userID.Hex(), convKey, req, now); err != nil {
+			return nil, err
+		}
+	}
+
+	// Read back rather than apply req to the earlier read, which a concurrent
+	// change to another flag may have made stale
+	flags, err = s.messageCassandraRepo.GetConversationFlags(ctx, userID.Hex(), convKey)
+	if err != nil {
+		return nil, err
+	}
+	if flags.Muted != wasMuted && s.notificationService != nil {
+		if flags.Muted {
+			_, err = s.notificationService.MuteConversation(ctx, userID, convKey, nil)
+		} else {
+			_, err = s.notificationService.UnmuteConversation(ctx, userID, convKey)
+		}
+		if err != nil {
+			log.Printf("Failed to update notification mute of %s for user %s: %v", convKey, userID.Hex(), err)
+		}
+	}
+
+	s.publishInboxEvent(ctx, userID, flags)
+	return &flags, nil
+}
+
+func (s *MessageService) checkPinnedConversationLimit(ctx context.Context, userID primitive.ObjectID) error {
+	all, err := s.messageCassandraRepo.ListConversationFlags(ctx, userID.Hex())
+	if err != nil {
+		return err
+	}
+	pinned := 0
+	for _, flags := range all {
+		if flags.Pinned {
+			pinned++
+		}
+	}
+	if pinned >= maxPinnedConversations {
+		return ErrPinnedConversationLimit
+	}
+	return nil
+}
+
+// DeleteUserConversationFlags removes all of the user's conversation flags
+func (s *MessageService) DeleteUserConversationFlags(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	all, err := s.messageCassandraRepo.ListConversationFlags(ctx, userID.Hex())
+	if err != nil {
+		return 0, err
+	}
+	if err := s.messageCassandraRepo.DeleteUserConversationFlags(ctx, userID.Hex()); err != nil {
+		return 0, err
+	}
+	return int64(len(all)), nil
+}
+
+// publishInboxEvent sends changed conversation flags to the user's own devices
+func (s *MessageService) publishInboxEvent(ctx context.Context, userID primitive.ObjectID, flags models.ConversationFlags) {
+	data, err := json.Marshal(flags)
+	if err != nil {
+		log.Printf("Failed to marshal inbox event for %s: %v", flags.ConversationID, err)
+		return
+	}
+	eventBytes, err := json.Marshal(models.WebSocketEvent{
+		Type:       "INBOX_UPDATED",
+		Data:       data,
+		Recipients: []string{userID.Hex()},
+	})
+	if err != nil {
+		log.Printf("Failed to marshal inbox event for %s: %v", flags.ConversationID, err)
+		return
+	}
+	if err := s.producer.ProduceMessage(ctx, kafkago.Message{
+		Key:   []byte(flags.ConversationID),
+		Value: eventBytes,
+		Time:  time.Now(),
+	}); err != nil {
+		log.Printf("Failed to publish inbox event for %s: %v", flags.ConversationID, err)
+	}
+}
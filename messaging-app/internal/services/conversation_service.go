@@ -3,13 +3,22 @@ package services
 import (
 	"context"
 	"log"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"messaging-app/internal/repositories"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/gocql/gocql"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// inboxSyncOverlap is how far before a sync token changes are re-read
+const inboxSyncOverlap = 5 * time.Second
+
+var ErrInvalidSyncToken = apperrors.Validation("invalid sync token")
+
 type ConversationService struct {
 	conversationRepo     *repositories.ConversationRepository
 	messageCassandraRepo *repositories.MessageCassandraRepository
@@ -26,6 +35,8 @@ func NewConversationService(cr *repositories.ConversationRepository, mcr *reposi
 	}
 }
 
+// GetConversationSummaries returns the user's inbox. Conversations still in
+// their message requests are left out; see GetMessageRequests.
 func (s *ConversationService) GetConversationSummaries(ctx context.Context, userID primitive.ObjectID) ([]models.ConversationSummary, error) {
 	log.Printf("Service: GetConversationSummaries for user %s (Cassandra)", userID.Hex())
 	// Use Cassandra for scalable inbox
@@ -34,7 +45,112 @@ func (s *ConversationService) GetConversationSummaries(ctx context.Context, user
 		log.Printf("Service: Error from Cassandra inbox for user %s: %v", userID.Hex(), err)
 		return nil, err
 	}
+	summaries, _ = splitRequests(summaries)
+
+	s.enrichSummaries(ctx, summaries)
+
+	log.Printf("Service: Retrieved %d conversation summaries for user %s from Cassandra", len(summaries), userID.Hex())
+	return summaries, nil
+}
+
+// GetMessageRequests returns the conversations in the user's message
+// requests, marketplace ones included, newest first
+func (s *ConversationService) GetMessageRequests(ctx context.Context, userID primitive.ObjectID) ([]models.ConversationSummary, error) {
+	var requests []models.ConversationSummary
+	for _, marketplace := range []bool{false, true} {
+		summaries, err := s.messageCassandraRepo.GetInbox(ctx, userID, marketplace)
+		if err != nil {
+			return nil, err
+		}
+		_, pending := splitRequests(summaries)
+		requests = append(requests, pending...)
+	}
+	sort.SliceStable(requests, func(i, j int) bool {
+		if requests[i].LastMessageTimestamp == nil {
+			return false
+		}
+		if requests[j].LastMessageTimestamp == nil {
+			return true
+		}
+		return requests[i].LastMessageTimestamp.After(*requests[j].LastMessageTimestamp)
+	})
+	s.enrichSummaries(ctx, requests)
+	return requests, nil
+}
+
+// splitRequests separates the inbox from the conversations in message
+// requests, keeping the order of each
+func splitRequests(summaries []models.ConversationSummary) (inbox, requests []models.ConversationSummary) {
+	inbox = make([]models.ConversationSummary, 0, len(summaries))
+	requests = []models.ConversationSummary{}
+	for _, summary := range summaries {
+		if summary.Request {
+			requests = append(requests, summary)
+		} else {
+			inbox = append(inbox, summary)
+		}
+	}
+	return inbox, requests
+}
+
+// SyncConversationSummaries returns the inbox rows that changed since token,
+// along with the token for the next sync. A missing or expired token yields
+// the whole inbox with FullSync set. Each device keeps its own token.
+func (s *ConversationService) SyncConversationSummaries(ctx context.Context, userID primitive.ObjectID, token string) (*models.InboxSyncResponse, error) {
+	if token == "" {
+		return s.fullInboxSync(ctx, userID)
+	}
+	since, err := gocql.ParseUUID(token)
+	if err != nil || since.Version() != 1 {
+		return nil, ErrInvalidSyncToken
+	}
+	if time.Since(since.Time()) > repositories.InboxChangeRetention-inboxSyncOverlap {
+		return s.fullInboxSync(ctx, userID)
+	}
+
+	// Change IDs are generated by the writers, so a change can land slightly
+	// behind one a client already saw. Re-reading a short window catches it;
+	// the rows it repeats are idempotent for the client.
+	changed, latest, err := s.messageCassandraRepo.GetInboxChanges(ctx, userID, gocql.MinTimeUUID(since.Time().Add(-inboxSyncOverlap)))
+	if err != nil {
+		return nil, err
+	}
+	if latest.Time().Before(since.Time()) {
+		latest = since
+	}
+
+	summaries, err := s.messageCassandraRepo.GetInboxConversations(ctx, userID, false, changed)
+	if err != nil {
+		return nil, err
+	}
+	s.enrichSummaries(ctx, summaries)
+
+	return &models.InboxSyncResponse{
+		Conversations: summaries,
+		SyncToken:     latest.String(),
+	}, nil
+}
+
+func (s *ConversationService) fullInboxSync(ctx context.Context, userID primitive.ObjectID) (*models.InboxSyncResponse, error) {
+	// Take the token first: anything written while the inbox is read is
+	// sent again on the next sync rather than lost
+	latest, err := s.messageCassandraRepo.LatestInboxChange(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	summaries, err := s.GetConversationSummaries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.InboxSyncResponse{
+		Conversations: summaries,
+		SyncToken:     latest.String(),
+		FullSync:      true,
+	}, nil
+}
 
+// enrichSummaries fills in current names and avatars of conversation partners and groups
+func (s *ConversationService) enrichSummaries(ctx context.Context, summaries []models.ConversationSummary) {
 	// Batch-fetch avatars for scalability (O(2) queries instead of O(N))
 	// Step 1: Collect unique user IDs and group IDs
 	userIDs := make(map[primitive.ObjectID]bool)
@@ -101,7 +217,4 @@ func (s *ConversationService) GetConversationSummaries(ctx context.Context, user
 			}
 		}
 	}
-
-	log.Printf("Service: Retrieved %d conversation summaries for user %s from Cassandra", len(summaries), userID.Hex())
-	return summaries, nil
 }
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitRequests(t *testing.T) {
+	summaries := []models.ConversationSummary{
+		{ID: "user-a"},
+		{ID: "user-b", Request: true},
+		{ID: "group-c", IsGroup: true},
+		{ID: "user-d", Request: true},
+	}
+
+	inbox, requests := splitRequests(summaries)
+
+	assert.Equal(t, []models.ConversationSummary{{ID: "user-a"}, {ID: "group-c", IsGroup: true}}, inbox)
+	assert.Equal(t, []models.ConversationSummary{{ID: "user-b", Request: true}, {ID: "user-d", Request: true}}, requests)
+
+	inbox, requests = splitRequests(nil)
+	assert.Empty(t, inbox)
+	assert.NotNil(t, requests, "an empty request list is sent as [] rather than null")
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidConversationSettings = apperrors.Validation("invalid conversation settings")
+	ErrNicknameNotParticipant      = apperrors.Validation("nicknames can only be given to participants of the conversation")
+)
+
+// GetConversationSettings returns a conversation's nicknames, quick reaction
+// and theme to one of its participants
+func (s *MessageService) GetConversationSettings(ctx context.Context, userID primitive.ObjectID, conversationID string, isGroup bool) (*models.ConversationSettings, error) {
+	convKey, err := s.ConversationKey(ctx, userID, conversationID, isGroup)
+	if err != nil {
+		return nil, err
+	}
+	return s.messageCassandraRepo.GetConversationSettings(ctx, convKey)
+}
+
+// UpdateConversationSettings changes a conversation's settings for all of its
+// participants. Any participant may edit them, and the new settings are sent
+// to every participant.
+func (s *MessageService) UpdateConversationSettings(ctx context.Context, userID primitive.ObjectID, conversationID string, isGroup bool, req models.UpdateConversationSettingsRequest) (*models.ConversationSettings, error) {
+	if err := req.Normalize(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConversationSettings, err)
+	}
+	convKey, err := s.ConversationKey(ctx, userID, conversationID, isGroup)
+	if err != nil {
+		return nil, err
+	}
+	recipients, err := s.conversationRecipients(ctx, convKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve participants: %w", err)
+	}
+	if err := checkNicknameParticipants(req.Nicknames, recipients); err != nil {
+		return nil, err
+	}
+
+	if err := s.messageCassandraRepo.UpdateConversationSettings(ctx, convKey, req, userID.Hex(), time.Now()); err != nil {
+		return nil, err
+	}
+	settings, err := s.messageCassandraRepo.GetConversationSettings(ctx, convKey)
+	if err != nil {
+		return nil, err
+	}
+
+	go s.messageCassandraRepo.RecordInboxChanges(recipients, convKey)
+	s.publishSettingsEvent(ctx, *settings, recipients)
+	return settings, nil
+}
+
+// checkNicknameParticipants rejects nicknames for users outside the conversation
+func checkNicknameParticipants(nicknames map[string]string, participants []string) error {
+	for userID := range nicknames {
+		found := false
+		for _, participant := range participants {
+			if participant == userID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ErrNicknameNotParticipant
+		}
+	}
+	return nil
+}
+
+func (s *MessageService) publishSettingsEvent(ctx context.Context, settings models.ConversationSettings, recipients []string) {
+	convKey := settings.ConversationID
+	data, err := json.Marshal(settings)
+	if err != nil {
+		log.Printf("Failed to marshal settings event for %s: %v", convKey, err)
+		return
+	}
+	eventBytes, err := json.Marshal(models.WebSocketEvent{
+		Type:       "CONVERSATION_SETTINGS_UPDATED",
+		Data:       data,
+		Recipients: recipients,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal settings event for %s: %v", convKey, err)
+		return
+	}
+	if err := s.producer.ProduceMessage(ctx, kafkago.Message{
+		Key:   []byte(convKey),
+		Value: eventBytes,
+		Time:  time.Now(),
+	}); err != nil {
+		log.Printf("Failed to publish settings event for %s: %v", convKey, err)
+	}
+}
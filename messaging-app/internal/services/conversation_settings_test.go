@@ -0,0 +1,15 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckNicknameParticipants(t *testing.T) {
+	participants := []string{"a", "b"}
+
+	assert.NoError(t, checkNicknameParticipants(nil, participants))
+	assert.NoError(t, checkNicknameParticipants(map[string]string{"a": "Ace", "b": ""}, participants))
+	assert.ErrorIs(t, checkNicknameParticipants(map[string]string{"a": "Ace", "c": "Cee"}, participants), ErrNicknameNotParticipant)
+}
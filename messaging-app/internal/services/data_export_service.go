@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"messaging-app/internal/repositories"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	exportpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/export/v1"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const exportMessagePageSize = 500
+
+// DataExportService collects what the messaging app stores about a user for
+// their data export
+type DataExportService struct {
+	messageRepo      *repositories.MessageCassandraRepository
+	groupRepo        *repositories.GroupRepository
+	notificationRepo *repositories.NotificationRepository
+	watchHistoryRepo *repositories.WatchHistoryRepository
+	callRoomRepo     *repositories.CallRoomRepository
+}
+
+func NewDataExportService(messageRepo *repositories.MessageCassandraRepository, groupRepo *repositories.GroupRepository, notificationRepo *repositories.NotificationRepository, watchHistoryRepo *repositories.WatchHistoryRepository, callRoomRepo *repositories.CallRoomRepository) *DataExportService {
+	return &DataExportService{
+		messageRepo:      messageRepo,
+		groupRepo:        groupRepo,
+		notificationRepo: notificationRepo,
+		watchHistoryRepo: watchHistoryRepo,
+		callRoomRepo:     callRoomRepo,
+	}
+}
+
+// ExportUserData returns the user's conversations, the messages they sent,
+// their groups, their notifications, their watch history and their calls
+func (s *DataExportService) ExportUserData(ctx context.Context, userID string) ([]*exportpb.ExportSection, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	conversations, err := s.messageRepo.GetInbox(ctx, uID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export conversations: %w", err)
+	}
+	marketplace, err := s.messageRepo.GetInbox(ctx, uID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export marketplace conversations: %w", err)
+	}
+	conversations = append(conversations, marketplace...)
+
+	messages := []models.Message{}
+	var media []string
+	for _, c := range conversations {
+		sent, err := s.sentMessages(ctx, c.ID, uID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export messages of conversation %s: %w", c.ID, err)
+		}
+		for _, m := range sent {
+			media = append(media, m.MediaURLs...)
+		}
+		messages = append(messages, sent...)
+	}
+
+	groups, err := s.groupRepo.GetUserGroups(ctx, uID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export groups: %w", err)
+	}
+	notifications, err := s.notificationRepo.ListNotifications(ctx, uID, bson.M{},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to export notifications: %w", err)
+	}
+	watchHistory, err := s.watchHistoryRepo.ListAllByUser(ctx, uID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export watch history: %w", err)
+	}
+	calls, err := s.callRoomRepo.ListAllByUser(ctx, uID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export calls: %w", err)
+	}
+
+	var b dataexport.Builder
+	b.Add("conversations", conversations)
+	b.Add("messages", messages, media...)
+	b.Add("groups", groups)
+	b.Add("notifications", notifications)
+	b.Add("watch_history", watchHistory)
+	b.Add("calls", calls)
+	return b.Sections()
+}
+
+// sentMessages pages through a conversation, newest first, and keeps the
+// messages the user sent
+func (s *DataExportService) sentMessages(ctx context.Context, conversationID string, userID primitive.ObjectID) ([]models.Message, error) {
+	var sent []models.Message
+	query := models.MessageQuery{ConversationID: conversationID, Limit: exportMessagePageSize}
+	for {
+		page, err := s.messageRepo.GetMessages(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page {
+			if m.SenderID == userID && !m.IsDeleted {
+				sent = append(sent, m)
+			}
+		}
+		if len(page) < exportMessagePageSize {
+			return sent, nil
+		}
+		query.Before = page[len(page)-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+}
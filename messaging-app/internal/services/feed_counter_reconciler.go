@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"log"
+	"messaging-app/internal/repositories"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// counterReconcileBatch bounds how many documents a reconcile pass loads at once
+const counterReconcileBatch = 500
+
+var (
+	counterDrifts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "feed_counter_drift_total",
+		Help: "Feed counters found out of step with their source collections",
+	}, []string{"target", "field"})
+	counterDriftLastRun = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "feed_counter_drift_last_run",
+		Help: "Feed counters found drifted by the latest complete reconcile pass",
+	}, []string{"target", "field"})
+	counterRepairs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "feed_counter_repairs_total",
+		Help: "Drifted feed counters written back by the reconciler",
+	}, []string{"target"})
+)
+
+// counterStore is the part of FeedRepository the reconciler needs
+type counterStore interface {
+	ReconcileCounters(ctx context.Context, target repositories.CounterTarget, after primitive.ObjectID, limit int) (*repositories.CounterBatch, error)
+}
+
+// CounterReconcileReport sums up one pass over a counter target
+type CounterReconcileReport struct {
+	Target   repositories.CounterTarget
+	Scanned  int
+	Drifted  map[string]int // drifted counters by field
+	Repaired int
+}
+
+// FeedCounterReconciler recomputes the denormalized reaction, comment and
+// reply counters of posts, comments and replies from the collections they
+// count, repairing any that drifted when a cascade partially failed
+type FeedCounterReconciler struct {
+	store    counterStore
+	interval time.Duration
+}
+
+// NewFeedCounterReconciler creates a reconciler running every interval;
+// a non-positive interval leaves the worker off
+func NewFeedCounterReconciler(feedRepo *repositories.FeedRepository, interval time.Duration) *FeedCounterReconciler {
+	return &FeedCounterReconciler{store: feedRepo, interval: interval}
+}
+
+// StartReconcileWorker reconciles every counter target each interval until
+// ctx is done
+func (s *FeedCounterReconciler) StartReconcileWorker(ctx context.Context) {
+	if s.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		for _, target := range repositories.CounterTargets {
+			report, err := s.Reconcile(ctx, target)
+			if err != nil {
+				log.Printf("Failed to reconcile %s counters: %v", target, err)
+				continue
+			}
+			if len(report.Drifted) > 0 {
+				log.Printf("Reconciled %s counters: scanned %d, drifted %v, repaired %d", target, report.Scanned, report.Drifted, report.Repaired)
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Reconcile walks every document of target in batches, repairing drifted
+// counters. The last-run gauge is only updated by a pass that completes.
+func (s *FeedCounterReconciler) Reconcile(ctx context.Context, target repositories.CounterTarget) (*CounterReconcileReport, error) {
+	report := &CounterReconcileReport{Target: target, Drifted: map[string]int{}}
+	var after primitive.ObjectID
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		batch, err := s.store.ReconcileCounters(ctx, target, after, counterReconcileBatch)
+		if err != nil {
+			return report, err
+		}
+		report.Scanned += batch.Scanned
+		report.Repaired += batch.Repaired
+		for _, drift := range batch.Drifts {
+			report.Drifted[drift.Field]++
+			counterDrifts.WithLabelValues(string(target), drift.Field).Inc()
+		}
+		counterRepairs.WithLabelValues(string(target)).Add(float64(batch.Repaired))
+
+		if batch.Scanned < counterReconcileBatch {
+			break
+		}
+		after = batch.LastID
+	}
+
+	counterDriftLastRun.DeletePartialMatch(prometheus.Labels{"target": string(target)})
+	for field, n := range report.Drifted {
+		counterDriftLastRun.WithLabelValues(string(target), field).Set(float64(n))
+	}
+	return report, nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"messaging-app/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeCounterStore serves pages from a fixed list of batches and records the
+// cursor each call was made with
+type fakeCounterStore struct {
+	batches []*repositories.CounterBatch
+	err     error
+	afters  []primitive.ObjectID
+}
+
+func (f *fakeCounterStore) ReconcileCounters(_ context.Context, _ repositories.CounterTarget, after primitive.ObjectID, _ int) (*repositories.CounterBatch, error) {
+	f.afters = append(f.afters, after)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if len(f.afters) > len(f.batches) {
+		return &repositories.CounterBatch{}, nil
+	}
+	return f.batches[len(f.afters)-1], nil
+}
+
+func TestFeedCounterReconciler_Reconcile_PagesAndSums(t *testing.T) {
+	firstLast := primitive.NewObjectID()
+	store := &fakeCounterStore{batches: []*repositories.CounterBatch{
+		{
+			Scanned: counterReconcileBatch,
+			LastID:  firstLast,
+			Drifts: []repositories.CounterDrift{
+				{Field: "total_reactions", Stored: 3, Actual: 2},
+				{Field: "total_comments", Stored: -1, Actual: 0},
+			},
+			Repaired: 2,
+		},
+		{
+			Scanned:  10,
+			LastID:   primitive.NewObjectID(),
+			Drifts:   []repositories.CounterDrift{{Field: "total_reactions", Stored: 0, Actual: 4}},
+			Repaired: 0,
+		},
+	}}
+	s := &FeedCounterReconciler{store: store}
+
+	report, err := s.Reconcile(context.Background(), repositories.CounterTargetPost)
+	require.NoError(t, err)
+
+	assert.Equal(t, counterReconcileBatch+10, report.Scanned)
+	assert.Equal(t, 2, report.Repaired)
+	assert.Equal(t, map[string]int{"total_reactions": 2, "total_comments": 1}, report.Drifted)
+	// The second page continues after the first one's last document, and a
+	// short page ends the pass
+	assert.Equal(t, []primitive.ObjectID{primitive.NilObjectID, firstLast}, store.afters)
+}
+
+func TestFeedCounterReconciler_Reconcile_StopsOnError(t *testing.T) {
+	store := &fakeCounterStore{err: errors.New("mongo down")}
+	s := &FeedCounterReconciler{store: store}
+
+	_, err := s.Reconcile(context.Background(), repositories.CounterTargetComment)
+	assert.EqualError(t, err, "mongo down")
+	assert.Len(t, store.afters, 1)
+}
+
+func TestFeedCounterReconciler_StartReconcileWorker_OffWithoutInterval(t *testing.T) {
+	store := &fakeCounterStore{}
+	s := &FeedCounterReconciler{store: store}
+
+	// Returns at once rather than ticking
+	s.StartReconcileWorker(context.Background())
+	assert.Empty(t, store.afters)
+}
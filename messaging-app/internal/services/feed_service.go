@@ -9,10 +9,16 @@ import (
 	notifications "messaging-app/internal/notifications"
 	"messaging-app/internal/repositories"
 	"messaging-app/internal/storageclient"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/linkpreview"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/pkg/pagination"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 
 	kafkago "github.com/segmentio/kafka-go"
@@ -23,6 +29,74 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+var (
+	// ErrCursorSortUnsupported is returned when a cursor is combined with a sort
+	// other than newest first
+	ErrCursorSortUnsupported = apperrors.Validation("cursor pagination only supports newest-first order")
+	// ErrPostNotFound is returned for posts that do not exist or that the viewer cannot see
+	ErrPostNotFound = apperrors.NotFound("post not found")
+	// ErrPostInTrash is returned when a trashed post is deleted again without permanent
+	ErrPostInTrash = apperrors.Conflict("post is already in the trash")
+	// ErrPostStatusNotListable is returned when ListPosts is asked for trashed posts
+	ErrPostStatusNotListable = apperrors.Validation("trashed posts are only listed through the trash")
+	// ErrInvalidPoll is returned when a poll is created with too few, too many or blank options
+	ErrInvalidPoll = apperrors.Validation(fmt.Sprintf("a poll needs %d to %d non-empty options of at most %d characters", minPollOptions, maxPollOptions, maxPollOptionLength))
+	// ErrNotAPoll is returned when voting on a post without poll options
+	ErrNotAPoll = apperrors.Validation("post is not a poll")
+	// ErrInvalidPollOption is returned when a vote names an option the poll does not have
+	ErrInvalidPollOption = apperrors.Validation("poll option not found")
+	// ErrPostPinNotAllowed is returned when someone other than the author pins a
+	// profile post, or a non-admin pins a community post
+	ErrPostPinNotAllowed = apperrors.Forbidden("only the author can pin a profile post and only community admins a community post")
+	// ErrPostNotPinnable is returned when pinning a pending or declined post
+	ErrPostNotPinnable = apperrors.Validation("only active posts can be pinned")
+	// ErrInvalidCommentSort is returned for comment sorts other than oldest,
+	// newest and relevant
+	ErrInvalidCommentSort = apperrors.Validation("sort must be oldest, newest or relevant")
+	// ErrParentReplyNotFound is returned when replying to a reply that is not
+	// in the comment's thread
+	ErrParentReplyNotFound = apperrors.NotFound("parent reply not found")
+	// ErrReplyTooDeep is returned when a reply would nest deeper than the
+	// configured reply depth
+	ErrReplyTooDeep = apperrors.Validation("replies cannot be nested any deeper")
+	// ErrReplyNotFound is returned for replies that do not exist or belong to
+	// another comment
+	ErrReplyNotFound = apperrors.NotFound("reply not found")
+	// ErrInvalidReactionTarget is returned for reactions to anything but a
+	// post, comment or reply
+	ErrInvalidReactionTarget = apperrors.Validation("target_type must be post, comment or reply")
+	// ErrInvalidReactionType is returned for reaction types other than the
+	// six supported ones
+	ErrInvalidReactionType = apperrors.Validation("type must be LIKE, LOVE, HAHA, WOW, SAD or ANGRY")
+	// ErrReactionNotFound is returned when deleting a reaction that does not
+	// exist or is not on the given target
+	ErrReactionNotFound = apperrors.NotFound("reaction not found")
+)
+
+const (
+	minPollOptions      = 2
+	maxPollOptions      = 10
+	maxPollOptionLength = 100
+)
+
+// PostTrashRetention is how long deleted posts stay restorable before the
+// purge worker removes them for good
+const PostTrashRetention = 30 * 24 * time.Hour
+
+// trashPurgeBatch bounds how many expired posts a purge pass loads at once
+const trashPurgeBatch = 100
+
+// relevantCommentsScanLimit is the most comments a post may have for the
+// relevant sort to score them all
+const relevantCommentsScanLimit = 2000
+
+// DefaultMaxReplyDepth is how deeply replies may nest under a comment unless
+// SetMaxReplyDepth says otherwise. A reply to the comment is at depth 1.
+const DefaultMaxReplyDepth = 3
+
+// minReplyDepth keeps replies to replies possible whatever is configured
+const minReplyDepth = 2
+
 type FeedService struct {
 	feedRepo            *repositories.FeedRepository
 	userRepo            *repositories.UserRepository
@@ -32,10 +106,32 @@ type FeedService struct {
 	kafkaProducer       *kafka.MessageProducer
 	notificationService *notifications.NotificationService
 	storageClient       *storageclient.Client
+	blocks              BlockLookup
+	moderator           *moderation.Moderator
+	linkPreviews        *linkpreview.Worker
+	mentions            *MentionService
+	maxReplyDepth       int
 }
 
 func NewFeedService(feedRepo *repositories.FeedRepository, userRepo *repositories.UserRepository, friendshipRepo *repositories.FriendshipRepository, communityRepo *repositories.CommunityRepository, privacyRepo repositories.PrivacyRepository, kafkaProducer *kafka.MessageProducer, notificationService *notifications.NotificationService, storageClient *storageclient.Client) *FeedService {
-	return &FeedService{feedRepo: feedRepo, userRepo: userRepo, friendshipRepo: friendshipRepo, communityRepo: communityRepo, privacyRepo: privacyRepo, kafkaProducer: kafkaProducer, notificationService: notificationService, storageClient: storageClient}
+	return &FeedService{feedRepo: feedRepo, userRepo: userRepo, friendshipRepo: friendshipRepo, communityRepo: communityRepo, privacyRepo: privacyRepo, kafkaProducer: kafkaProducer, notificationService: notificationService, storageClient: storageClient, maxReplyDepth: DefaultMaxReplyDepth}
+}
+
+// SetMaxReplyDepth sets how deeply replies may nest under a comment. Depths
+// below 2 are raised to 2 so replies to replies stay possible.
+func (s *FeedService) SetMaxReplyDepth(depth int) {
+	s.maxReplyDepth = max(depth, minReplyDepth)
+}
+
+// SetBlockLookup hides posts between users who have blocked each other
+func (s *FeedService) SetBlockLookup(blocks BlockLookup) {
+	s.blocks = blocks
+}
+
+// SetMentionService ranks the people a user mentions in posts higher in
+// their @mention suggestions
+func (s *FeedService) SetMentionService(mentions *MentionService) {
+	s.mentions = mentions
 }
 
 // Post operations
@@ -72,6 +168,11 @@ func (s *FeedService) CreatePost(ctx context.Context, userID primitive.ObjectID,
 		communityID = &id
 	}
 
+	pollOptions, err := newPollOptions(req.PollOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check Community Logic
 	status := models.PostStatusActive
 	if communityID != nil {
@@ -79,6 +180,9 @@ func (s *FeedService) CreatePost(ctx context.Context, userID primitive.ObjectID,
 		if err != nil {
 			return nil, fmt.Errorf("failed to get community: %w", err)
 		}
+		if err := communityWriteBlock(community, userID); err != nil {
+			return nil, err
+		}
 
 		// Check if member posts are allowed
 		// Note provided schema says AllowMemberPosts in Settings, check if implemented in models
@@ -111,6 +215,14 @@ func (s *FeedService) CreatePost(ctx context.Context, userID primitive.ObjectID,
 		}
 	}
 
+	decision, err := s.screenText(ctx, req.Content, communityID)
+	if err != nil {
+		return nil, err
+	}
+	if decision.Held() {
+		status = models.PostStatusPending
+	}
+
 	post := &models.Post{
 		UserID:         userID,
 		Content:        req.Content,
@@ -124,6 +236,7 @@ func (s *FeedService) CreatePost(ctx context.Context, userID primitive.ObjectID,
 		CommentIDs:     []primitive.ObjectID{}, // Initialize as empty array
 		Mentions:       mentionedUserIDs,
 		Hashtags:       req.Hashtags,
+		PollOptions:    pollOptions,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}
@@ -132,6 +245,12 @@ func (s *FeedService) CreatePost(ctx context.Context, userID primitive.ObjectID,
 	if err != nil {
 		return nil, err
 	}
+	s.moderator.Report(ctx, decision, models.ModerationQueueItem{
+		ContentType: models.ModeratedPost,
+		ContentID:   createdPost.ID.Hex(),
+		CommunityID: communityID,
+		AuthorID:    userID,
+	}, req.Content)
 
 	// Fetch sender's user details for notification content
 	senderUser, err := s.userRepo.FindUserByID(ctx, userID)
@@ -140,6 +259,8 @@ func (s *FeedService) CreatePost(ctx context.Context, userID primitive.ObjectID,
 		// Continue without notification if sender not found, or handle as appropriate
 	}
 
+	s.mentions.RecordInteraction(ctx, userID, mentionedUserIDs...)
+
 	// Send notifications to mentioned users
 	for _, mentionedUserID := range mentionedUserIDs {
 		notificationReq := &models.CreateNotificationRequest{
@@ -164,34 +285,16 @@ func (s *FeedService) CreatePost(ctx context.Context, userID primitive.ObjectID,
 			Username: senderUser.Username,
 			Avatar:   senderUser.Avatar,
 			FullName: senderUser.FullName,
+			Verified: senderUser.Verified,
 		}
 	}
 
-	postDataBytes, err := json.Marshal(createdPost)
-	if err != nil {
-		fmt.Printf("Failed to marshal createdPost for WebSocketEvent: %v\n", err)
-		// Log the error but don't block post creation
-	} else {
-		wsEvent := models.WebSocketEvent{
-			Type: "PostCreated",
-			Data: postDataBytes,
-		}
-		eventBytes, err := json.Marshal(wsEvent)
-		if err != nil {
-			fmt.Printf("Failed to marshal WebSocketEvent for PostCreated: %v\n", err)
-		} else {
-			kafkaMsg := kafkago.Message{
-				Key:   []byte(createdPost.UserID.Hex()), // Key for post events (using post owner ID)
-				Value: eventBytes,
-				Time:  time.Now(),
-			}
-			err = s.kafkaProducer.ProduceMessage(ctx, kafkaMsg)
-			if err != nil {
-				fmt.Printf("Failed to produce PostCreated WebSocketEvent to Kafka: %v\n", err)
-				// Log the error but don't block post creation
-			}
-		}
+	// Held posts and posts awaiting community approval are announced once
+	// approved
+	if createdPost.Status == models.PostStatusActive {
+		s.publishPostEvent(ctx, "PostCreated", createdPost)
 	}
+	s.queuePostPreview(createdPost)
 
 	// Populate MentionedUsers for the response
 	var mentionedPostAuthors []models.PostAuthor
@@ -201,6 +304,7 @@ func (s *FeedService) CreatePost(ctx context.Context, userID primitive.ObjectID,
 			Username: user.Username,
 			Avatar:   user.Avatar,
 			FullName: user.FullName,
+			Verified: user.Verified,
 		})
 	}
 	createdPost.MentionedUsers = mentionedPostAuthors
@@ -210,7 +314,7 @@ func (s *FeedService) CreatePost(ctx context.Context, userID primitive.ObjectID,
 
 func (s *FeedService) GetPostByID(ctx context.Context, viewerID, postID primitive.ObjectID) (*models.Post, error) {
 	post, err := s.feedRepo.GetPostByID(ctx, postID)
-	if err != nil {
+	if err != nil || post.Status == models.PostStatusDeleted {
 		return nil, errors.New("post not found")
 	}
 
@@ -226,6 +330,99 @@ func (s *FeedService) GetPostByID(ctx context.Context, viewerID, postID primitiv
 	return post, nil
 }
 
+// SharePost reshares a post to the user's timeline. The share must stay within
+// the audience of both the post and the original it was shared from, so
+// friends-only posts cannot be spread beyond the author's friends.
+func (s *FeedService) SharePost(ctx context.Context, userID, postID primitive.ObjectID, req *models.SharePostRequest) (*models.Post, error) {
+	// GetPostByID also fails for posts the user cannot see; don't reveal which
+	parent, err := s.GetPostByID(ctx, userID, postID)
+	if err != nil {
+		return nil, ErrPostNotFound
+	}
+	if err := s.checkShareAudience(ctx, parent, req); err != nil {
+		return nil, err
+	}
+
+	shared := models.NewPostShare(parent)
+	if shared.OriginalPostID != parent.ID {
+		original, err := s.feedRepo.GetPostByID(ctx, shared.OriginalPostID)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, models.ErrPostNotShareable
+			}
+			return nil, err
+		}
+		if err := s.checkShareAudience(ctx, original, req); err != nil {
+			return nil, err
+		}
+	}
+
+	decision, err := s.screenText(ctx, req.Content, nil)
+	if err != nil {
+		return nil, err
+	}
+	status := models.PostStatusActive
+	if decision.Held() {
+		status = models.PostStatusPending
+	}
+
+	post := &models.Post{
+		UserID:         userID,
+		Content:        req.Content,
+		Privacy:        req.Privacy,
+		CustomAudience: req.CustomAudience,
+		Status:         status,
+		Comments:       []models.Comment{},
+		CommentIDs:     []primitive.ObjectID{},
+		SharedFrom:     shared,
+	}
+	createdPost, err := s.feedRepo.CreatePost(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	s.moderator.Report(ctx, decision, models.ModerationQueueItem{
+		ContentType: models.ModeratedPost,
+		ContentID:   createdPost.ID.Hex(),
+		AuthorID:    userID,
+	}, req.Content)
+
+	if err := s.feedRepo.AdjustPostShareCount(ctx, shared.ShareCounterIDs(), 1); err != nil {
+		fmt.Printf("Failed to increment share count for post %s: %v\n", shared.OriginalPostID.Hex(), err)
+	}
+
+	if shared.OriginalAuthorID != userID {
+		sharer, err := s.userRepo.FindUserByID(ctx, userID)
+		if err == nil {
+			_, err = s.notificationService.CreateNotification(ctx, &models.CreateNotificationRequest{
+				RecipientID: shared.OriginalAuthorID,
+				SenderID:    userID,
+				Type:        models.NotificationTypeShare,
+				TargetID:    shared.OriginalPostID,
+				TargetType:  "post",
+				Content:     fmt.Sprintf("%s shared your post.", sharer.Username),
+			})
+		}
+		if err != nil {
+			fmt.Printf("Failed to create share notification for user %s: %v\n", shared.OriginalAuthorID.Hex(), err)
+		}
+	}
+
+	return createdPost, nil
+}
+
+// checkShareAudience verifies a share request against the audience of post
+func (s *FeedService) checkShareAudience(ctx context.Context, post *models.Post, req *models.SharePostRequest) error {
+	var friendIDs []primitive.ObjectID
+	if req.Privacy == models.PrivacySettingCustom &&
+		(post.Privacy == models.PrivacySettingFriends || post.Privacy == models.PrivacySettingFriendsExcept) {
+		var err error
+		if friendIDs, err = s.friendshipRepo.GetFriendIDs(ctx, post.UserID); err != nil {
+			return fmt.Errorf("failed to resolve post audience: %w", err)
+		}
+	}
+	return models.CheckShareAudience(post, req.Privacy, req.CustomAudience, friendIDs)
+}
+
 // UpdatePostStatus updates the status of a post (e.g., for moderation)
 func (s *FeedService) UpdatePostStatus(ctx context.Context, postID primitive.ObjectID, userID primitive.ObjectID, status models.PostStatus) error {
 	post, err := s.feedRepo.GetPostByID(ctx, postID)
@@ -237,6 +434,10 @@ func (s *FeedService) UpdatePostStatus(ctx context.Context, postID primitive.Obj
 	if post.CommunityID == nil {
 		return errors.New("post does not belong to a community")
 	}
+	// The trash belongs to the author: moderators can neither trash nor restore
+	if status == models.PostStatusDeleted || post.Status == models.PostStatusDeleted {
+		return ErrPostNotFound
+	}
 
 	// Check authorization: Must be Community Admin
 	community, err := s.communityRepo.GetByID(ctx, *post.CommunityID)
@@ -257,41 +458,71 @@ func (s *FeedService) UpdatePostStatus(ctx context.Context, postID primitive.Obj
 	}
 
 	// Update status
-	_, err = s.feedRepo.UpdatePost(ctx, post.ID, bson.M{
+	updated, err := s.feedRepo.UpdatePost(ctx, post.ID, bson.M{
 		"status": status,
 		// "updated_at": time.Now(), // Don't update this to avoid "Edited" label
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	// Feeds hear only about posts coming in or going out of them
+	switch {
+	case status == models.PostStatusActive && post.Status != models.PostStatusActive:
+		s.publishPostEvent(ctx, "PostCreated", updated)
+	case status != models.PostStatusActive && post.Status == models.PostStatusActive:
+		s.publishPostEvent(ctx, "PostDeleted", post)
+	}
+	return nil
 }
 
 // canViewPost checks if a user has permission to view a post based on its privacy settings
 func (s *FeedService) canViewPost(ctx context.Context, viewerID primitive.ObjectID, post *models.Post) (bool, error) {
-	// Post owner can always view their own post
-	if viewerID == post.UserID {
-		return true, nil
+	// Trashed posts are only reachable through the author's trash
+	if post.Status == models.PostStatusDeleted {
+		return false, nil
 	}
-
-	switch post.Privacy {
-	case models.PrivacySettingPublic:
-		return true, nil
-	case models.PrivacySettingFriends:
-		// Check if viewer is friends with post owner
-		isFriends, err := s.friendshipRepo.AreFriends(ctx, viewerID, post.UserID)
+	// Posts held or removed by moderation are only shown to their author;
+	// pending community posts stay with the community's own approval flow
+	if viewerID != post.UserID && post.CommunityID == nil &&
+		(post.Status == models.PostStatusPending || post.Status == models.PostStatusDeclined) {
+		return false, nil
+	}
+	if s.blocks != nil && viewerID != post.UserID && !viewerID.IsZero() {
+		blocked, err := s.blocks.IsBlocked(ctx, viewerID, post.UserID)
+		if err != nil {
+			return false, fmt.Errorf("failed to check block status: %w", err)
+		}
+		if blocked {
+			return false, nil
+		}
+	}
+	isFriends := false
+	if viewerID != post.UserID && post.NeedsFriendship() {
+		var err error
+		isFriends, err = s.friendshipRepo.AreFriends(ctx, viewerID, post.UserID)
 		if err != nil {
 			return false, fmt.Errorf("failed to check friendship status: %w", err)
 		}
-		return isFriends, nil
-	case models.PrivacySettingOnlyMe:
-		// Only the post owner can view (already handled above)
-		return false, nil
-	default:
-		return false, errors.New("unknown privacy setting")
 	}
+	return post.VisibleTo(viewerID, isFriends), nil
+}
+
+// viewerFriendIDs returns the friends used to resolve which posts viewerID can
+// see. Anonymous viewers have none.
+func (s *FeedService) viewerFriendIDs(ctx context.Context, viewerID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	if viewerID == primitive.NilObjectID {
+		return nil, nil
+	}
+	friendIDs, err := s.friendshipRepo.GetFriendIDs(ctx, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get viewer's friends: %w", err)
+	}
+	return friendIDs, nil
 }
 
 func (s *FeedService) UpdatePost(ctx context.Context, userID, postID primitive.ObjectID, req *models.UpdatePostRequest) (*models.Post, error) {
 	post, err := s.feedRepo.GetPostByID(ctx, postID)
-	if err != nil {
+	if err != nil || post.Status == models.PostStatusDeleted {
 		return nil, errors.New("post not found")
 	}
 	if post.UserID != userID {
@@ -302,8 +533,19 @@ func (s *FeedService) UpdatePost(ctx context.Context, userID, postID primitive.O
 		"updated_at": time.Now(),
 	}
 
+	var decision moderation.Decision
 	if req.Content != "" {
+		if decision, err = s.screenText(ctx, req.Content, post.CommunityID); err != nil {
+			return nil, err
+		}
+		if decision.Held() && post.Status == models.PostStatusActive {
+			updateData["status"] = models.PostStatusPending
+		}
 		updateData["content"] = req.Content
+		// The preview belonged to the old content; the unfurl worker sets a new one
+		if req.Content != post.Content {
+			updateData["link_preview"] = nil
+		}
 	}
 	if len(req.Media) > 0 {
 		updateData["media"] = req.Media
@@ -325,6 +567,15 @@ func (s *FeedService) UpdatePost(ctx context.Context, userID, postID primitive.O
 	if err != nil {
 		return nil, err
 	}
+	s.moderator.Report(ctx, decision, models.ModerationQueueItem{
+		ContentType: models.ModeratedPost,
+		ContentID:   postID.Hex(),
+		CommunityID: post.CommunityID,
+		AuthorID:    userID,
+	}, req.Content)
+	if req.Content != "" && req.Content != post.Content {
+		s.queuePostPreview(updatedPost)
+	}
 
 	// Publish PostUpdated event to Kafka
 	senderUser, err := s.userRepo.FindUserByID(ctx, userID)
@@ -336,6 +587,7 @@ func (s *FeedService) UpdatePost(ctx context.Context, userID, postID primitive.O
 			Username: senderUser.Username,
 			Avatar:   senderUser.Avatar,
 			FullName: senderUser.FullName,
+			Verified: senderUser.Verified,
 		}
 	}
 
@@ -366,7 +618,10 @@ func (s *FeedService) UpdatePost(ctx context.Context, userID, postID primitive.O
 	return updatedPost, nil
 }
 
-func (s *FeedService) DeletePost(ctx context.Context, userID, postID primitive.ObjectID) error {
+// DeletePost moves a post to the trash, where it stays restorable for
+// PostTrashRetention. With permanent set the post and everything attached to
+// it are removed right away, which also empties it from the trash.
+func (s *FeedService) DeletePost(ctx context.Context, userID, postID primitive.ObjectID, permanent bool) error {
 	// Fetch post before deletion to get details for event
 	post, err := s.feedRepo.GetPostByID(ctx, postID)
 	if err != nil {
@@ -380,12 +635,195 @@ func (s *FeedService) DeletePost(ctx context.Context, userID, postID primitive.O
 		return errors.New("unauthorized to delete this post")
 	}
 
+	trashed := post.Status == models.PostStatusDeleted
+	if permanent {
+		if err := s.purgePost(ctx, post); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return errors.New("post not found or unauthorized to delete")
+			}
+			return err
+		}
+	} else {
+		if trashed {
+			return ErrPostInTrash
+		}
+		if err := s.feedRepo.TrashPost(ctx, userID, postID, time.Now()); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return ErrPostInTrash
+			}
+			return err
+		}
+	}
+
+	// Trashed posts already gave their share back and left every feed
+	if trashed {
+		return nil
+	}
+	if post.SharedFrom != nil {
+		if err := s.feedRepo.AdjustPostShareCount(ctx, post.SharedFrom.ShareCounterIDs(), -1); err != nil {
+			fmt.Printf("Failed to decrement share count for post %s: %v\n", post.SharedFrom.OriginalPostID.Hex(), err)
+		}
+	}
+	s.publishPostEvent(ctx, "PostDeleted", post)
+	return nil
+}
+
+// RestorePost takes one of the user's posts out of the trash with the status
+// it had before it was deleted
+func (s *FeedService) RestorePost(ctx context.Context, userID, postID primitive.ObjectID) (*models.Post, error) {
+	post, err := s.feedRepo.RestorePost(ctx, userID, postID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+
+	if post.SharedFrom != nil {
+		if err := s.feedRepo.AdjustPostShareCount(ctx, post.SharedFrom.ShareCounterIDs(), 1); err != nil {
+			fmt.Printf("Failed to increment share count for post %s: %v\n", post.SharedFrom.OriginalPostID.Hex(), err)
+		}
+	}
+	// To feeds a restored post is a new one, unless it still awaits approval
+	if post.Status == models.PostStatusActive {
+		s.publishPostEvent(ctx, "PostCreated", post)
+	}
+	return post, nil
+}
+
+// PinPost pins a post to the top of its feed: the author's profile, or the
+// community it was posted in. Each feed has one pin, so pinning replaces the
+// previous one.
+func (s *FeedService) PinPost(ctx context.Context, userID, postID primitive.ObjectID) (*models.Post, error) {
+	post, err := s.pinnablePost(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status != "" && post.Status != models.PostStatusActive {
+		return nil, ErrPostNotPinnable
+	}
+
+	pinnedAt := time.Now()
+	if err := s.feedRepo.PinPost(ctx, post, pinnedAt); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	post.PinnedAt = &pinnedAt
+	return post, nil
+}
+
+// UnpinPost clears a post's pin. It takes the same permission as pinning.
+func (s *FeedService) UnpinPost(ctx context.Context, userID, postID primitive.ObjectID) error {
+	if _, err := s.pinnablePost(ctx, userID, postID); err != nil {
+		return err
+	}
+	_, err := s.feedRepo.UnpinPost(ctx, postID)
+	return err
+}
+
+// pinnablePost loads a post the user may pin or unpin
+func (s *FeedService) pinnablePost(ctx context.Context, userID, postID primitive.ObjectID) (*models.Post, error) {
+	post, err := s.feedRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if post.Status == models.PostStatusDeleted {
+		return nil, ErrPostNotFound
+	}
+
+	if post.CommunityID == nil {
+		if post.UserID != userID {
+			return nil, ErrPostPinNotAllowed
+		}
+		return post, nil
+	}
+
+	community, err := s.communityRepo.GetByID(ctx, *post.CommunityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get community: %w", err)
+	}
+	if !slices.Contains(community.Admins, userID) {
+		return nil, ErrPostPinNotAllowed
+	}
+	return post, nil
+}
+
+// ListTrash returns a page of the user's trashed posts, most recently deleted first
+func (s *FeedService) ListTrash(ctx context.Context, userID primitive.ObjectID, page, limit int64) (*models.FeedResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	posts, total, err := s.feedRepo.ListTrashedPosts(ctx, userID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.FeedResponse{Posts: posts, Total: total, Page: page, Limit: limit}, nil
+}
+
+// StartTrashPurgeWorker purges expired trash every hour until ctx is done
+func (s *FeedService) StartTrashPurgeWorker(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		s.PurgeExpiredTrash(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// PurgeExpiredTrash permanently deletes posts that have been in the trash
+// longer than PostTrashRetention and returns how many were purged
+func (s *FeedService) PurgeExpiredTrash(ctx context.Context) int {
+	cutoff := time.Now().Add(-PostTrashRetention)
+	purged := 0
+	for ctx.Err() == nil {
+		posts, err := s.feedRepo.ListExpiredTrash(ctx, cutoff, trashPurgeBatch)
+		if err != nil {
+			fmt.Printf("Failed to list expired trash: %v\n", err)
+			break
+		}
+
+		batchPurged := 0
+		for i := range posts {
+			if err := s.purgePost(ctx, &posts[i]); err != nil {
+				fmt.Printf("Failed to purge trashed post %s: %v\n", posts[i].ID.Hex(), err)
+				continue
+			}
+			batchPurged++
+		}
+		purged += batchPurged
+
+		// Stop on a short batch, or when nothing in it could be purged
+		if len(posts) < trashPurgeBatch || batchPurged == 0 {
+			break
+		}
+	}
+	if purged > 0 {
+		fmt.Printf("Purged %d expired posts from the trash\n", purged)
+	}
+	return purged
+}
+
+// purgePost deletes a post and everything attached to it
+func (s *FeedService) purgePost(ctx context.Context, post *models.Post) error {
 	// 1. Cleanup related data (Cascade Delete)
 
 	// A. Comments & Replies
-	commentIDs, err := s.feedRepo.GetCommentIDsByPostID(ctx, postID)
+	commentIDs, err := s.feedRepo.GetCommentIDsByPostID(ctx, post.ID)
 	if err != nil {
-		fmt.Printf("Failed to fetch comment IDs for post %s: %v\n", postID.Hex(), err)
+		fmt.Printf("Failed to fetch comment IDs for post %s: %v\n", post.ID.Hex(), err)
 	} else if len(commentIDs) > 0 {
 		// 1. Fetch Reply IDs to delete their reactions
 		replyIDs, err := s.feedRepo.GetReplyIDsByCommentIDs(ctx, commentIDs)
@@ -411,14 +849,19 @@ func (s *FeedService) DeletePost(ctx context.Context, userID, postID primitive.O
 		}
 
 		// 5. Delete Comments
-		if err := s.feedRepo.DeleteCommentsByPostID(ctx, postID); err != nil {
-			fmt.Printf("Failed to delete comments for post %s: %v\n", postID.Hex(), err)
+		if err := s.feedRepo.DeleteCommentsByPostID(ctx, post.ID); err != nil {
+			fmt.Printf("Failed to delete comments for post %s: %v\n", post.ID.Hex(), err)
 		}
 	}
 
 	// B. Reactions (on Post)
-	if err := s.feedRepo.DeleteReactionsByTargetID(ctx, postID); err != nil {
-		fmt.Printf("Failed to delete reactions for post %s: %v\n", postID.Hex(), err)
+	if err := s.feedRepo.DeleteReactionsByTargetID(ctx, post.ID); err != nil {
+		fmt.Printf("Failed to delete reactions for post %s: %v\n", post.ID.Hex(), err)
+	}
+	if len(post.PollOptions) > 0 {
+		if err := s.feedRepo.DeletePollVotesByPostID(ctx, post.ID); err != nil {
+			fmt.Printf("Failed to delete poll votes for post %s: %v\n", post.ID.Hex(), err)
+		}
 	}
 
 	// C. Media Cleanup (Album Links and Storage)
@@ -444,26 +887,104 @@ func (s *FeedService) DeletePost(ctx context.Context, userID, postID primitive.O
 	}
 
 	// 2. Delete the Post itself
-	err = s.feedRepo.DeletePost(ctx, userID, postID)
+	return s.feedRepo.DeletePost(ctx, post.UserID, post.ID)
+}
+
+// newPollOptions validates the option texts of a new poll. It returns nil
+// for posts without a poll.
+func newPollOptions(texts []string) ([]models.PollOption, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	if len(texts) < minPollOptions || len(texts) > maxPollOptions {
+		return nil, ErrInvalidPoll
+	}
+
+	pollOptions := make([]models.PollOption, 0, len(texts))
+	for _, text := range texts {
+		text = strings.TrimSpace(text)
+		if text == "" || utf8.RuneCountInString(text) > maxPollOptionLength {
+			return nil, ErrInvalidPoll
+		}
+		pollOptions = append(pollOptions, models.PollOption{ID: primitive.NewObjectID(), Text: text})
+	}
+	return pollOptions, nil
+}
+
+// VotePoll records the user's vote on a poll post, replacing any earlier
+// vote, and broadcasts the new results to the post's audience
+func (s *FeedService) VotePoll(ctx context.Context, userID, postID, optionID primitive.ObjectID) (*models.PollVoteUpdatedEvent, error) {
+	post, err := s.feedRepo.GetPostByID(ctx, postID)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return errors.New("post not found or unauthorized to delete")
+			return nil, ErrPostNotFound
 		}
-		return err
+		return nil, err
+	}
+	if post.Status != models.PostStatusActive {
+		return nil, ErrPostNotFound
+	}
+	canView, err := s.canViewPost(ctx, userID, post)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrPostNotFound
+	}
+	if len(post.PollOptions) == 0 {
+		return nil, ErrNotAPoll
+	}
+	if !slices.ContainsFunc(post.PollOptions, func(o models.PollOption) bool { return o.ID == optionID }) {
+		return nil, ErrInvalidPollOption
+	}
+
+	updated, err := s.feedRepo.VotePoll(ctx, postID, userID, optionID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Publish PostDeleted event to Kafka
+	event := &models.PollVoteUpdatedEvent{PostID: postID, Options: updated.PollOptions}
+	for _, option := range updated.PollOptions {
+		event.TotalVotes += option.VoteCount
+	}
+	s.publishPollVoteUpdated(ctx, event)
+	return event, nil
+}
+
+func (s *FeedService) publishPollVoteUpdated(ctx context.Context, event *models.PollVoteUpdatedEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		fmt.Printf("Failed to marshal PollVoteUpdated event: %v\n", err)
+		return
+	}
+	eventBytes, err := json.Marshal(models.WebSocketEvent{Type: "PollVoteUpdated", Data: data})
+	if err != nil {
+		fmt.Printf("Failed to marshal WebSocketEvent for PollVoteUpdated: %v\n", err)
+		return
+	}
+	kafkaMsg := kafkago.Message{
+		Key:   []byte(event.PostID.Hex()), // Keeps a poll's updates in order
+		Value: eventBytes,
+		Time:  time.Now(),
+	}
+	if err := s.kafkaProducer.ProduceMessage(ctx, kafkaMsg); err != nil {
+		fmt.Printf("Failed to produce PollVoteUpdated WebSocketEvent to Kafka: %v\n", err)
+	}
+}
+
+// publishPostEvent sends a post change to the hub through Kafka
+func (s *FeedService) publishPostEvent(ctx context.Context, eventType string, post *models.Post) {
 	postDataBytes, err := json.Marshal(post)
 	if err != nil {
-		fmt.Printf("Failed to marshal deletedPost for WebSocketEvent: %v\n", err)
+		fmt.Printf("Failed to marshal post for WebSocketEvent: %v\n", err)
 	} else {
 		wsEvent := models.WebSocketEvent{
-			Type: "PostDeleted",
+			Type: eventType,
 			Data: postDataBytes,
 		}
 		eventBytes, err := json.Marshal(wsEvent)
 		if err != nil {
-			fmt.Printf("Failed to marshal WebSocketEvent for PostDeleted: %v\n", err)
+			fmt.Printf("Failed to marshal WebSocketEvent for %s: %v\n", eventType, err)
 		} else {
 			kafkaMsg := kafkago.Message{
 				Key:   []byte(post.UserID.Hex()),
@@ -472,17 +993,35 @@ func (s *FeedService) DeletePost(ctx context.Context, userID, postID primitive.O
 			}
 			err = s.kafkaProducer.ProduceMessage(ctx, kafkaMsg)
 			if err != nil {
-				fmt.Printf("Failed to produce PostDeleted WebSocketEvent to Kafka: %v\n", err)
+				fmt.Printf("Failed to produce %s WebSocketEvent to Kafka: %v\n", eventType, err)
 			}
 		}
 	}
-
-	return nil
 }
 
-func (s *FeedService) ListPosts(ctx context.Context, viewerID primitive.ObjectID, filterUserID string, communityID string, page, limit int64, sortBy, sortOrder string, hasMedia bool, mediaType string, status string) (*models.FeedResponse, error) {
+// ListPosts returns a page of posts. When cursor is set it pages by keyset
+// from the cursor instead of by page number, which requires the default
+// newest-first order.
+func (s *FeedService) ListPosts(ctx context.Context, viewerID primitive.ObjectID, filterUserID string, communityID string, page, limit int64, sortBy, sortOrder string, hasMedia bool, mediaType string, status string, cursor string) (*models.FeedResponse, error) {
+	if models.PostStatus(status) == models.PostStatusDeleted {
+		return nil, ErrPostStatusNotListable
+	}
+
+	var after *pagination.Cursor
+	if cursor != "" {
+		if (sortBy != "" && sortBy != "created_at") || sortOrder == "asc" {
+			return nil, ErrCursorSortUnsupported
+		}
+		var err error
+		if after, err = pagination.DecodeCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
 	// Base filter for public posts
 	filter := bson.M{}
+	// Community and profile feeds show their pinned post first
+	var pinScope bson.M
 
 	// If a specific community is requested, filter by that community ID
 	if communityID != "" {
@@ -491,6 +1030,7 @@ func (s *FeedService) ListPosts(ctx context.Context, viewerID primitive.ObjectID
 			return nil, fmt.Errorf("invalid community ID: %w", err)
 		}
 		filter["community_id"] = objCommunityID
+		pinScope = bson.M{"community_id": objCommunityID, "pinned_at": bson.M{"$exists": true}}
 
 		// If status is provided, filter by it. Otherwise show all (or active?)
 		// For communities, we might want to default to active unless specified.
@@ -512,6 +1052,7 @@ func (s *FeedService) ListPosts(ctx context.Context, viewerID primitive.ObjectID
 			return nil, fmt.Errorf("invalid filter user ID: %w", err)
 		}
 		filter["user_id"] = objFilterUserID
+		pinScope = bson.M{"community_id": bson.M{"$exists": false}, "pinned_at": bson.M{"$exists": true}}
 
 		if status != "" {
 			filter["status"] = status
@@ -523,6 +1064,19 @@ func (s *FeedService) ListPosts(ctx context.Context, viewerID primitive.ObjectID
 			}
 		}
 
+		// Other people only see the posts of the profile they are allowed to
+		if viewerID != objFilterUserID {
+			friendIDs, err := s.viewerFriendIDs(ctx, viewerID)
+			if err != nil {
+				return nil, err
+			}
+			blocked, err := blockedUserIDs(ctx, s.blocks, viewerID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get blocked users: %w", err)
+			}
+			filter = excludeAuthors(bson.M{"$and": []bson.M{filter, models.PostAudienceFilter(viewerID, friendIDs)}}, blocked)
+		}
+
 	} else {
 
 		// Main Feed Default: Active Only
@@ -544,36 +1098,24 @@ func (s *FeedService) ListPosts(ctx context.Context, viewerID primitive.ObjectID
 		// We have two distinct requirements: STATUS IS (Active OR Missing) AND PRIVACY IS (Public OR Friends).
 		// MongoDB doesn't allow multiple top-level $or operators easily without $and.
 
-		privacyFilter := []bson.M{
-			{"privacy": models.PrivacySettingPublic},
+		friendIDs, err := s.viewerFriendIDs(ctx, viewerID)
+		if err != nil {
+			return nil, err
 		}
 
-		// If user is logged in, include friends' posts
-		if viewerID != primitive.NilObjectID {
-			friendIDs, err := s.friendshipRepo.GetFriendIDs(ctx, viewerID)
-			if err == nil {
-				// Only add friends filter if user has friends (avoid empty $in array)
-				if len(friendIDs) > 0 {
-					privacyFilter = append(privacyFilter, bson.M{
-						"privacy": models.PrivacySettingFriends,
-						"user_id": bson.M{"$in": friendIDs},
-					})
-				}
-				// Also include own posts
-				privacyFilter = append(privacyFilter, bson.M{"user_id": viewerID})
-			}
+		blocked, err := blockedUserIDs(ctx, s.blocks, viewerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blocked users: %w", err)
 		}
 
 		// Combine Status and Privacy filters using $and
-		filter = bson.M{
+		filter = excludeAuthors(bson.M{
 			"$and": []bson.M{
 				filter, // Includes community_id exists:false
 				statusFilter,
-				{
-					"$or": privacyFilter,
-				},
+				models.PostAudienceFilter(viewerID, friendIDs),
 			},
-		}
+		}, blocked)
 
 		// Note regarding the previous code structure:
 		// The original code was appending to top-level $or for privacy.
@@ -592,7 +1134,6 @@ func (s *FeedService) ListPosts(ctx context.Context, viewerID primitive.ObjectID
 		filter["media"] = bson.M{"$elemMatch": bson.M{"type": mediaType}}
 	}
 
-	// TODO: Implement CUSTOM privacy logic (requires fetching custom audience lists)
 	sortField := "created_at"
 	sortDir := -1 // descending
 
@@ -609,60 +1150,81 @@ func (s *FeedService) ListPosts(ctx context.Context, viewerID primitive.ObjectID
 		sortDir = 1 // ascending
 	}
 
+	// _id breaks ties so pages never overlap or skip posts with equal keys
 	opts := options.Find().
 		SetSkip((page - 1) * limit).
 		SetLimit(limit).
-		SetSort(bson.D{{Key: sortField, Value: sortDir}})
+		SetSort(bson.D{{Key: sortField, Value: sortDir}, {Key: "_id", Value: sortDir}})
 
-	posts, err := s.feedRepo.ListPosts(ctx, filter, opts)
+	pageFilter := filter
+	if pinScope != nil {
+		pageFilter = bson.M{"$and": []bson.M{filter, {"$nor": []bson.M{pinScope}}}}
+	}
+	if after != nil {
+		pageFilter = bson.M{"$and": []bson.M{pageFilter, after.Filter()}}
+		opts.SetSkip(0)
+	}
+
+	posts, err := s.feedRepo.ListPosts(ctx, pageFilter, opts)
 	if err != nil {
 		return nil, err
 	}
+	pagePosts := posts
+
+	// The pinned post leads the first page on top of the usual page size
+	if pinScope != nil && page == 1 && after == nil {
+		pinned, err := s.feedRepo.ListPosts(ctx, bson.M{"$and": []bson.M{filter, pinScope}}, options.Find().SetLimit(1))
+		if err != nil {
+			return nil, err
+		}
+		posts = append(pinned, posts...)
+	}
 
 	total, err := s.feedRepo.CountPosts(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	return &models.FeedResponse{
+	resp := &models.FeedResponse{
 		Posts: posts,
 		Total: total,
 		Page:  page,
 		Limit: limit,
-	}, nil
+	}
+	// Cursors follow the newest-first order, so page-number clients on the
+	// default sort can switch to cursors from any page.
+	if sortField == "created_at" && sortDir == -1 && int64(len(pagePosts)) == limit {
+		last := pagePosts[len(pagePosts)-1]
+		resp.NextCursor = pagination.EncodeCursor(last.CreatedAt, last.ID)
+	}
+	return resp, nil
 }
 
 func (s *FeedService) GetPostsByHashtag(ctx context.Context, viewerID primitive.ObjectID, hashtag string, page, limit int64) (*models.FeedResponse, error) {
 	// Normalize hashtag to lowercase for consistent searching
 	normalizedHashtag := strings.ToLower(hashtag)
 
-	// Base filter for posts containing the hashtag
-	filter := bson.M{
-		"hashtags": normalizedHashtag,
-		"$or": []bson.M{
-			{"privacy": models.PrivacySettingPublic},
-			{"user_id": viewerID, "privacy": models.PrivacySettingOnlyMe},
-		},
-	}
-
-	// Get viewer's friends for FRIENDS privacy
-	friends, err := s.friendshipRepo.GetFriends(ctx, viewerID)
+	friendIDs, err := s.viewerFriendIDs(ctx, viewerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get viewer's friends: %w", err)
+		return nil, err
 	}
 
-	var friendIDs []primitive.ObjectID
-	for _, friend := range friends {
-		friendIDs = append(friendIDs, friend.ID)
+	blocked, err := blockedUserIDs(ctx, s.blocks, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
 	}
 
-	// Add FRIENDS privacy filter if viewer has friends
-	if len(friendIDs) > 0 {
-		filter["$or"] = append(filter["$or"].([]bson.M), bson.M{
-			"privacy": models.PrivacySettingFriends,
-			"user_id": bson.M{"$in": friendIDs},
-		})
-	}
+	// Posts containing the hashtag that the viewer is allowed to see
+	filter := excludeAuthors(bson.M{
+		"$and": []bson.M{
+			{"hashtags": normalizedHashtag},
+			{"$or": []bson.M{
+				{"status": models.PostStatusActive},
+				{"status": bson.M{"$exists": false}},
+			}},
+			models.PostAudienceFilter(viewerID, friendIDs),
+		},
+	}, blocked)
 
 	// Pagination and sorting options
 	opts := options.Find().
@@ -703,9 +1265,12 @@ func (s *FeedService) CreateComment(ctx context.Context, userID primitive.Object
 	}
 
 	post, err := s.feedRepo.GetPostByID(ctx, *req.PostID)
-	if err != nil {
+	if err != nil || post.Status == models.PostStatusDeleted {
 		return nil, errors.New("post not found")
 	}
+	if err := s.checkCanComment(ctx, post, userID); err != nil {
+		return nil, err
+	}
 
 	// Extract mentions from content
 	mentionedUsernames := utils.ExtractMentions(req.Content)
@@ -719,6 +1284,11 @@ func (s *FeedService) CreateComment(ctx context.Context, userID primitive.Object
 		mentionedUserIDs = append(mentionedUserIDs, user.ID)
 	}
 
+	decision, err := s.screenText(ctx, req.Content, post.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+
 	comment := &models.Comment{
 		PostID:    *req.PostID,
 		UserID:    userID,
@@ -733,6 +1303,14 @@ func (s *FeedService) CreateComment(ctx context.Context, userID primitive.Object
 	if err != nil {
 		return nil, err
 	}
+	// Comments have no pending state, so a held comment is queued like a flagged one
+	s.moderator.Report(ctx, decision, models.ModerationQueueItem{
+		ContentType: models.ModeratedComment,
+		ContentID:   createdComment.ID.Hex(),
+		ParentID:    post.ID.Hex(),
+		CommunityID: post.CommunityID,
+		AuthorID:    userID,
+	}, req.Content)
 
 	// --- Notify Post Author ---
 
@@ -791,6 +1369,7 @@ func (s *FeedService) CreateComment(ctx context.Context, userID primitive.Object
 			Username: senderUser.Username,
 			Avatar:   senderUser.Avatar,
 			FullName: senderUser.FullName,
+			Verified: senderUser.Verified,
 		}
 	}
 
@@ -838,6 +1417,11 @@ func (s *FeedService) UpdateComment(ctx context.Context, userID, commentID primi
 	if comment.UserID != userID {
 		return nil, errors.New("unauthorized to update this comment")
 	}
+	communityID := s.postCommunity(ctx, comment.PostID)
+	decision, err := s.screenText(ctx, req.Content, communityID)
+	if err != nil {
+		return nil, err
+	}
 
 	updateData := bson.M{
 		"updated_at": time.Now(),
@@ -854,6 +1438,13 @@ func (s *FeedService) UpdateComment(ctx context.Context, userID, commentID primi
 	if err != nil {
 		return nil, err
 	}
+	s.moderator.Report(ctx, decision, models.ModerationQueueItem{
+		ContentType: models.ModeratedComment,
+		ContentID:   commentID.Hex(),
+		ParentID:    comment.PostID.Hex(),
+		CommunityID: communityID,
+		AuthorID:    userID,
+	}, req.Content)
 	return updatedComment, nil
 }
 
@@ -891,14 +1482,29 @@ func (s *FeedService) CreateReply(ctx context.Context, userID primitive.ObjectID
 	}
 
 	// Check if comment exists
-	_, err = s.feedRepo.GetCommentByID(ctx, req.CommentID)
+	parent, err := s.feedRepo.GetCommentByID(ctx, req.CommentID)
 	if err != nil {
 		return nil, errors.New("comment not found")
 	}
 
-	// Disallow replies to replies
+	var parentReply *models.Reply
+	depth := 1
 	if req.ParentReplyID != nil && !req.ParentReplyID.IsZero() {
-		return nil, errors.New("replies to replies are not allowed")
+		parentReply, err = s.feedRepo.GetReplyByID(ctx, *req.ParentReplyID)
+		if err != nil || parentReply.CommentID != req.CommentID {
+			return nil, ErrParentReplyNotFound
+		}
+		if depth, err = nextReplyDepth(parentReply, s.maxReplyDepth); err != nil {
+			return nil, err
+		}
+	}
+
+	post, err := s.feedRepo.GetPostByID(ctx, parent.PostID)
+	if err != nil || post.Status == models.PostStatusDeleted {
+		return nil, errors.New("post not found")
+	}
+	if err := s.checkCanComment(ctx, post, userID); err != nil {
+		return nil, err
 	}
 
 	// Extract mentions from content
@@ -913,6 +1519,12 @@ func (s *FeedService) CreateReply(ctx context.Context, userID primitive.ObjectID
 		mentionedUserIDs = append(mentionedUserIDs, user.ID)
 	}
 
+	communityID := s.postCommunity(ctx, parent.PostID)
+	decision, err := s.screenText(ctx, req.Content, communityID)
+	if err != nil {
+		return nil, err
+	}
+
 	reply := &models.Reply{
 		CommentID: req.CommentID,
 		UserID:    userID,
@@ -920,12 +1532,23 @@ func (s *FeedService) CreateReply(ctx context.Context, userID primitive.ObjectID
 		MediaType: req.MediaType,
 		MediaURL:  req.MediaURL,
 		Mentions:  mentionedUserIDs,
+		Depth:     depth,
+	}
+	if parentReply != nil {
+		reply.ParentReplyID = &parentReply.ID
 	}
 
 	createdReply, err := s.feedRepo.CreateReply(ctx, reply)
 	if err != nil {
 		return nil, err
 	}
+	s.moderator.Report(ctx, decision, models.ModerationQueueItem{
+		ContentType: models.ModeratedReply,
+		ContentID:   createdReply.ID.Hex(),
+		ParentID:    req.CommentID.Hex(),
+		CommunityID: communityID,
+		AuthorID:    userID,
+	}, req.Content)
 
 	// --- Notify Comment Author ---
 	comment, err := s.feedRepo.GetCommentByID(ctx, req.CommentID)
@@ -961,6 +1584,22 @@ func (s *FeedService) CreateReply(ctx context.Context, userID primitive.ObjectID
 	}
 	// --- End Notify Comment Author ---
 
+	// Notify the author of the reply being answered, unless they already
+	// heard about it as the comment's author or through a mention
+	if parentReply != nil && parentReply.UserID != userID && (comment == nil || parentReply.UserID != comment.UserID) && !slices.Contains(mentionedUserIDs, parentReply.UserID) {
+		notificationReq := &models.CreateNotificationRequest{
+			RecipientID: parentReply.UserID,
+			SenderID:    userID,
+			Type:        models.NotificationTypeReply,
+			TargetID:    createdReply.ID,
+			TargetType:  "reply",
+			Content:     fmt.Sprintf("%s replied to your reply.", senderUser.Username),
+		}
+		if _, err := s.notificationService.CreateNotification(ctx, notificationReq); err != nil {
+			fmt.Printf("Failed to create reply notification for user %s: %v\n", parentReply.UserID.Hex(), err)
+		}
+	}
+
 	// Send notifications to mentioned users
 	for _, mentionedUserID := range mentionedUserIDs {
 		notificationReq := &models.CreateNotificationRequest{
@@ -985,6 +1624,7 @@ func (s *FeedService) CreateReply(ctx context.Context, userID primitive.ObjectID
 			Username: senderUser.Username,
 			Avatar:   senderUser.Avatar,
 			FullName: senderUser.FullName,
+			Verified: senderUser.Verified,
 		}
 	}
 
@@ -1026,6 +1666,14 @@ func (s *FeedService) UpdateReply(ctx context.Context, userID, replyID primitive
 	if reply.UserID != userID {
 		return nil, errors.New("unauthorized to update this reply")
 	}
+	var communityID *primitive.ObjectID
+	if parent, err := s.feedRepo.GetCommentByID(ctx, reply.CommentID); err == nil {
+		communityID = s.postCommunity(ctx, parent.PostID)
+	}
+	decision, err := s.screenText(ctx, req.Content, communityID)
+	if err != nil {
+		return nil, err
+	}
 
 	updateData := bson.M{
 		"updated_at": time.Now(),
@@ -1042,13 +1690,21 @@ func (s *FeedService) UpdateReply(ctx context.Context, userID, replyID primitive
 	if err != nil {
 		return nil, err
 	}
+	s.moderator.Report(ctx, decision, models.ModerationQueueItem{
+		ContentType: models.ModeratedReply,
+		ContentID:   replyID.Hex(),
+		ParentID:    reply.CommentID.Hex(),
+		CommunityID: communityID,
+		AuthorID:    userID,
+	}, req.Content)
 	return updatedReply, nil
 }
 
+// DeleteReply deletes one of the user's replies and every reply nested under it
 func (s *FeedService) DeleteReply(ctx context.Context, userID, commentID, replyID primitive.ObjectID) error {
 	reply, err := s.feedRepo.GetReplyByID(ctx, replyID)
-	if err != nil {
-		return errors.New("reply not found")
+	if err != nil || reply.CommentID != commentID {
+		return ErrReplyNotFound
 	}
 	if reply.UserID != userID {
 		return errors.New("unauthorized to delete this reply")
@@ -1058,178 +1714,155 @@ func (s *FeedService) DeleteReply(ctx context.Context, userID, commentID, replyI
 }
 
 // Reaction operations
-func (s *FeedService) CreateReaction(ctx context.Context, userID primitive.ObjectID, req *models.CreateReactionRequest) (*models.Reaction, error) {
-	reaction := &models.Reaction{
+
+// CreateReaction applies the user's reaction to a post, comment or reply.
+// Each user has at most one reaction per target: sending another type
+// changes it and sending the same type again removes it.
+func (s *FeedService) CreateReaction(ctx context.Context, userID primitive.ObjectID, req *models.CreateReactionRequest) (*models.ReactionResponse, error) {
+	if !validReactionTarget(req.TargetType) {
+		return nil, ErrInvalidReactionTarget
+	}
+	if !req.Type.Valid() {
+		return nil, ErrInvalidReactionType
+	}
+
+	reaction, action, err := s.feedRepo.ToggleReaction(ctx, &models.Reaction{
 		UserID:     userID,
 		TargetID:   req.TargetID,
 		TargetType: req.TargetType,
 		Type:       req.Type,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	createdReaction, err := s.feedRepo.CreateReaction(ctx, reaction)
+	// Fetch sender's user details
+	senderUser, err := s.userRepo.FindUserByID(ctx, userID)
 	if err != nil {
-		return nil, err
+		fmt.Printf("Failed to find sender user %s for reaction notification: %v\n", userID.Hex(), err)
+		// Continue without notification/enrichment if sender not found
 	}
+	if senderUser != nil {
+		reaction.User = models.PostAuthor{
+			ID:       senderUser.ID.Hex(),
+			Username: senderUser.Username,
+			Avatar:   senderUser.Avatar,
+			FullName: senderUser.FullName,
+			Verified: senderUser.Verified,
+		}
+	}
+
+	// Only a new reaction notifies the target's owner; changing or removing
+	// one does not
+	if action == models.ReactionAdded && senderUser != nil {
+		s.notifyReaction(ctx, senderUser, reaction)
+	}
+
+	eventType := map[models.ReactionAction]string{
+		models.ReactionAdded:   "ReactionCreated",
+		models.ReactionChanged: "ReactionUpdated",
+		models.ReactionRemoved: "ReactionDeleted",
+	}[action]
+	s.publishReactionEvent(ctx, eventType, reaction)
+
+	return &models.ReactionResponse{Action: action, Reaction: reaction}, nil
+}
 
-	// Send notification to the target's owner
+// notifyReaction tells the owner of the reacted-to content about a new reaction
+func (s *FeedService) notifyReaction(ctx context.Context, sender *models.User, reaction *models.Reaction) {
 	var targetOwnerID primitive.ObjectID
-	switch req.TargetType {
+	switch reaction.TargetType {
 	case "post":
-		post, err := s.feedRepo.GetPostByID(ctx, req.TargetID)
+		post, err := s.feedRepo.GetPostByID(ctx, reaction.TargetID)
 		if err != nil {
-			fmt.Printf("Failed to get post %s for reaction notification: %v\n", req.TargetID.Hex(), err)
-			return createdReaction, nil
+			fmt.Printf("Failed to get post %s for reaction notification: %v\n", reaction.TargetID.Hex(), err)
+			return
 		}
 		targetOwnerID = post.UserID
 	case "comment":
-		comment, err := s.feedRepo.GetCommentByID(ctx, req.TargetID)
+		comment, err := s.feedRepo.GetCommentByID(ctx, reaction.TargetID)
 		if err != nil {
-			fmt.Printf("Failed to get comment %s for reaction notification: %v\n", req.TargetID.Hex(), err)
-			return createdReaction, nil
+			fmt.Printf("Failed to get comment %s for reaction notification: %v\n", reaction.TargetID.Hex(), err)
+			return
 		}
 		targetOwnerID = comment.UserID
 	case "reply":
-		reply, err := s.feedRepo.GetReplyByID(ctx, req.TargetID)
+		reply, err := s.feedRepo.GetReplyByID(ctx, reaction.TargetID)
 		if err != nil {
-			fmt.Printf("Failed to get reply %s for reaction notification: %v\n", req.TargetID.Hex(), err)
-			return createdReaction, nil
+			fmt.Printf("Failed to get reply %s for reaction notification: %v\n", reaction.TargetID.Hex(), err)
+			return
 		}
 		targetOwnerID = reply.UserID
 	}
-
-	// Fetch sender's user details
-	senderUser, err := s.userRepo.FindUserByID(ctx, userID)
-	if err != nil {
-		fmt.Printf("Failed to find sender user %s for reaction notification: %v\n", userID.Hex(), err)
-		// Continue without notification/enrichment if sender not found
+	if targetOwnerID == sender.ID { // Don't notify if user reacts to their own content
+		return
+	}
+
+	notificationReq := &models.CreateNotificationRequest{
+		RecipientID: targetOwnerID,
+		SenderID:    sender.ID,
+		Type:        models.NotificationTypeLike, // Using LIKE for all reactions for now
+		TargetID:    reaction.TargetID,
+		TargetType:  reaction.TargetType,
+		Content:     fmt.Sprintf("%s reacted to your %s with %s.", sender.Username, reaction.TargetType, reaction.Type),
+		Data: map[string]interface{}{
+			"sender_username": sender.Username,
+			"sender_avatar":   sender.Avatar,
+			"reaction_type":   reaction.Type,
+			"target_type":     reaction.TargetType,
+		},
 	}
-
-	if targetOwnerID != userID { // Don't notify if user reacts to their own content
-		if senderUser != nil {
-			notificationReq := &models.CreateNotificationRequest{
-				RecipientID: targetOwnerID,
-				SenderID:    userID,
-				Type:        models.NotificationTypeLike, // Using LIKE for all reactions for now
-				TargetID:    createdReaction.TargetID,
-				TargetType:  createdReaction.TargetType,
-				Content:     fmt.Sprintf("%s reacted to your %s with %s.", senderUser.Username, createdReaction.TargetType, createdReaction.Type),
-				Data: map[string]interface{}{
-					"sender_username": senderUser.Username,
-					"sender_avatar":   senderUser.Avatar,
-					"reaction_type":   createdReaction.Type,
-					"target_type":     createdReaction.TargetType,
-				},
-			}
-			_, err = s.notificationService.CreateNotification(ctx, notificationReq)
-			if err != nil {
-				fmt.Printf("Failed to create reaction notification for user %s: %v\n", targetOwnerID.Hex(), err)
-			}
-		}
+	if _, err := s.notificationService.CreateNotification(ctx, notificationReq); err != nil {
+		fmt.Printf("Failed to create reaction notification for user %s: %v\n", targetOwnerID.Hex(), err)
 	}
+}
 
-	// Publish ReactionCreated event to Kafka
-	if senderUser != nil {
-		createdReaction.User = models.PostAuthor{
-			ID:       senderUser.ID.Hex(),
-			Username: senderUser.Username,
-			Avatar:   senderUser.Avatar,
-			FullName: senderUser.FullName,
-		}
+// publishReactionEvent sends a reaction WebSocket event through Kafka, keyed
+// by the target. Failures are logged and do not fail the reaction.
+func (s *FeedService) publishReactionEvent(ctx context.Context, eventType string, reaction *models.Reaction) {
+	reactionDataBytes, err := json.Marshal(reaction)
+	if err != nil {
+		fmt.Printf("Failed to marshal reaction for %s WebSocketEvent: %v\n", eventType, err)
+		return
 	}
-
-	reactionDataBytes, err := json.Marshal(createdReaction)
+	eventBytes, err := json.Marshal(models.WebSocketEvent{Type: eventType, Data: reactionDataBytes})
 	if err != nil {
-		fmt.Printf("Failed to marshal createdReaction for WebSocketEvent: %v\n", err)
-		// Log the error but don't block reaction creation
-	} else {
-		wsEvent := models.WebSocketEvent{
-			Type: "ReactionCreated",
-			Data: reactionDataBytes,
-		}
-		eventBytes, err := json.Marshal(wsEvent)
-		if err != nil {
-			fmt.Printf("Failed to marshal WebSocketEvent for ReactionCreated: %v\n", err)
-		} else {
-			kafkaMsg := kafkago.Message{
-				Key:   []byte(createdReaction.TargetID.Hex()), // Key for reaction events (using target ID)
-				Value: eventBytes,
-				Time:  time.Now(),
-			}
-			err = s.kafkaProducer.ProduceMessage(ctx, kafkaMsg)
-			if err != nil {
-				fmt.Printf("Failed to produce ReactionCreated WebSocketEvent to Kafka: %v\n", err)
-				// Log the error but don't block reaction creation
-			}
-		}
+		fmt.Printf("Failed to marshal WebSocketEvent for %s: %v\n", eventType, err)
+		return
 	}
-	// Increment reaction count on the target document
-	switch req.TargetType {
-	case "post":
-		err = s.feedRepo.IncrementPostReactionCount(ctx, req.TargetID)
-	case "comment":
-		err = s.feedRepo.IncrementCommentReactionCount(ctx, req.TargetID)
-	case "reply":
-		err = s.feedRepo.IncrementReplyReactionCount(ctx, req.TargetID)
+	kafkaMsg := kafkago.Message{
+		Key:   []byte(reaction.TargetID.Hex()), // Key for reaction events (using target ID)
+		Value: eventBytes,
+		Time:  time.Now(),
 	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to increment reaction count for target %s (%s): %w", req.TargetID.Hex(), req.TargetType, err)
+	if err := s.kafkaProducer.ProduceMessage(ctx, kafkaMsg); err != nil {
+		fmt.Printf("Failed to produce %s WebSocketEvent to Kafka: %v\n", eventType, err)
 	}
+}
 
-	return createdReaction, nil
+func validReactionTarget(targetType string) bool {
+	switch targetType {
+	case "post", "comment", "reply":
+		return true
+	}
+	return false
 }
 
 func (s *FeedService) DeleteReaction(ctx context.Context, userID primitive.ObjectID, reactionID, targetID primitive.ObjectID, targetType string) error {
 	// Verify if the reaction belongs to the user
-	reaction, err := s.feedRepo.GetReactionByID(ctx, reactionID) // Assuming GetReactionByID exists or needs to be created
-	if err != nil {
-		return errors.New("reaction not found")
+	reaction, err := s.feedRepo.GetReactionByID(ctx, reactionID)
+	if err != nil || reaction.TargetID != targetID || reaction.TargetType != targetType {
+		return ErrReactionNotFound
 	}
 	if reaction.UserID != userID {
 		return errors.New("unauthorized to delete this reaction")
 	}
 
-	err = s.feedRepo.DeleteReaction(ctx, reactionID, userID, targetID, targetType)
-	if err != nil {
+	// The repository recounts the target in the same transaction
+	if err := s.feedRepo.DeleteReaction(ctx, reactionID, userID, targetID, targetType); err != nil {
 		return err
 	}
-
-	// Publish ReactionDeleted event to Kafka
-	reactionDataBytes, err := json.Marshal(reaction) // Marshal the deleted reaction
-	if err != nil {
-		fmt.Printf("Failed to marshal deletedReaction for WebSocketEvent: %v\n", err)
-	} else {
-		wsEvent := models.WebSocketEvent{
-			Type: "ReactionDeleted", // New event type
-			Data: reactionDataBytes,
-		}
-		eventBytes, err := json.Marshal(wsEvent)
-		if err != nil {
-			fmt.Printf("Failed to marshal WebSocketEvent for ReactionDeleted: %v\n", err)
-		} else {
-			kafkaMsg := kafkago.Message{
-				Key:   []byte(reaction.TargetID.Hex()), // Key for reaction events (using target ID)
-				Value: eventBytes,
-				Time:  time.Now(),
-			}
-			err = s.kafkaProducer.ProduceMessage(ctx, kafkaMsg)
-			if err != nil {
-				fmt.Printf("Failed to produce ReactionDeleted WebSocketEvent to Kafka: %v\n", err)
-			}
-		}
-	}
-	// Decrement reaction count on the target document
-	switch targetType {
-	case "post":
-		err = s.feedRepo.DecrementPostReactionCount(ctx, targetID)
-	case "comment":
-		err = s.feedRepo.DecrementCommentReactionCount(ctx, targetID)
-	case "reply":
-		err = s.feedRepo.DecrementReplyReactionCount(ctx, targetID)
-	}
-	if err != nil {
-		return fmt.Errorf("failed to decrement reaction count for target %s (%s): %w", targetID.Hex(), targetType, err)
-	}
-
+	s.publishReactionEvent(ctx, "ReactionDeleted", reaction)
 	return nil
 }
 
@@ -1240,12 +1873,28 @@ func (s *FeedService) GetReactionsByTargetID(ctx context.Context, targetID primi
 	return s.feedRepo.ListReactions(ctx, filter, opts)
 }
 
-func (s *FeedService) GetCommentsByPostID(ctx context.Context, postID primitive.ObjectID, page, limit int64) ([]models.Comment, error) {
+// GetCommentsByPostID returns a page of a post's comments, oldest first by
+// default. The relevant sort ranks them for viewerID.
+func (s *FeedService) GetCommentsByPostID(ctx context.Context, viewerID, postID primitive.ObjectID, page, limit int64, sort models.CommentSort) ([]models.Comment, error) {
+	if sort == "" {
+		sort = models.CommentSortOldest
+	}
+	if !sort.Valid() {
+		return nil, ErrInvalidCommentSort
+	}
+	if sort == models.CommentSortRelevant {
+		return s.getRelevantComments(ctx, viewerID, postID, page, limit)
+	}
+
+	order := 1
+	if sort == models.CommentSortNewest {
+		order = -1
+	}
 	filter := bson.M{"post_id": postID}
 	opts := options.Find().
 		SetSkip((page - 1) * limit).
 		SetLimit(limit).
-		SetSort(bson.D{{Key: "created_at", Value: 1}})
+		SetSort(bson.D{{Key: "created_at", Value: order}})
 	comments, err := s.feedRepo.ListComments(ctx, filter, opts)
 	if err != nil {
 		return nil, err
@@ -1254,18 +1903,69 @@ func (s *FeedService) GetCommentsByPostID(ctx context.Context, postID primitive.
 	return comments, nil
 }
 
+// getRelevantComments ranks the comments of posts with up to
+// relevantCommentsScanLimit comments; larger threads fall back to top
+// comments by reactions
+func (s *FeedService) getRelevantComments(ctx context.Context, viewerID, postID primitive.ObjectID, page, limit int64) ([]models.Comment, error) {
+	post, err := s.feedRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	skip := (page - 1) * limit
+	if !scoresEveryComment(post.TotalComments) {
+		return s.feedRepo.ListTopComments(ctx, postID, skip, limit)
+	}
+
+	friendIDs, err := s.friendshipRepo.GetFriendIDs(ctx, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get friends: %w", err)
+	}
+	return s.feedRepo.ListCommentsByRelevance(ctx, postID, post.UserID, friendIDs, time.Now(), skip, limit)
+}
+
+// scoresEveryComment reports whether a post with totalComments comments is
+// small enough for the relevant sort to score all of them
+func scoresEveryComment(totalComments int64) bool {
+	return totalComments <= relevantCommentsScanLimit
+}
+
+// GetRepliesByCommentID pages through the replies made directly to a
+// comment, oldest first. Each reply's reply_count tells whether it has a
+// thread of its own to page through with GetRepliesByParentReplyID.
 func (s *FeedService) GetRepliesByCommentID(ctx context.Context, commentID primitive.ObjectID, page, limit int64) ([]models.Reply, error) {
-	filter := bson.M{"comment_id": commentID}
+	return s.listThread(ctx, bson.M{"comment_id": commentID, "parent_reply_id": nil}, page, limit)
+}
+
+// GetRepliesByParentReplyID pages through the replies made directly to a
+// reply, oldest first
+func (s *FeedService) GetRepliesByParentReplyID(ctx context.Context, commentID, parentReplyID primitive.ObjectID, page, limit int64) ([]models.Reply, error) {
+	parent, err := s.feedRepo.GetReplyByID(ctx, parentReplyID)
+	if err != nil || parent.CommentID != commentID {
+		return nil, ErrReplyNotFound
+	}
+	return s.listThread(ctx, bson.M{"comment_id": commentID, "parent_reply_id": parentReplyID}, page, limit)
+}
+
+func (s *FeedService) listThread(ctx context.Context, filter bson.M, page, limit int64) ([]models.Reply, error) {
 	opts := options.Find().
 		SetSkip((page - 1) * limit).
 		SetLimit(limit).
-		SetSort(bson.D{{Key: "created_at", Value: 1}})
-	replies, err := s.feedRepo.ListReplies(ctx, filter, opts)
-	if err != nil {
-		return nil, err
-	}
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	return s.feedRepo.ListReplies(ctx, filter, opts)
+}
 
-	return replies, nil
+// nextReplyDepth is the depth of a reply to parent, failing when it would
+// nest deeper than maxDepth. Replies written before nesting carry no depth
+// and sit directly under their comment.
+func nextReplyDepth(parent *models.Reply, maxDepth int) (int, error) {
+	depth := max(parent.Depth, 1) + 1
+	if depth > maxDepth {
+		return 0, ErrReplyTooDeep
+	}
+	return depth, nil
 }
 
 // ----------------------------- Albums -----------------------------
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCommentSort_Valid(t *testing.T) {
+	tests := []struct {
+		sort models.CommentSort
+		want bool
+	}{
+		{models.CommentSortOldest, true},
+		{models.CommentSortNewest, true},
+		{models.CommentSortRelevant, true},
+		{"", false},
+		{"top", false},
+		{"RELEVANT", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.sort.Valid(), "sort %q", tt.sort)
+	}
+}
+
+func TestFeedService_GetCommentsByPostID_RejectsUnknownSort(t *testing.T) {
+	// The sort is checked before any repository is touched
+	svc := &FeedService{}
+	for _, sort := range []models.CommentSort{"top", "popular", "Newest"} {
+		_, err := svc.GetCommentsByPostID(context.Background(), primitive.NewObjectID(), primitive.NewObjectID(), 1, 20, sort)
+		assert.ErrorIs(t, err, ErrInvalidCommentSort, "sort %q", sort)
+	}
+}
+
+func TestScoresEveryComment(t *testing.T) {
+	assert.True(t, scoresEveryComment(0))
+	assert.True(t, scoresEveryComment(relevantCommentsScanLimit))
+	assert.False(t, scoresEveryComment(relevantCommentsScanLimit+1))
+}
+
+func TestNextReplyDepth(t *testing.T) {
+	tests := []struct {
+		name        string
+		parentDepth int
+		maxDepth    int
+		want        int
+		wantErr     error
+	}{
+		{"reply to a top-level reply", 1, 3, 2, nil},
+		{"reply to a reply written before nesting", 0, 3, 2, nil},
+		{"reaches the limit", 2, 3, 3, nil},
+		{"past the limit", 3, 3, 0, ErrReplyTooDeep},
+		{"past the minimum depth", 2, minReplyDepth, 0, ErrReplyTooDeep},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextReplyDepth(&models.Reply{Depth: tt.parentDepth}, tt.maxDepth)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFeedService_SetMaxReplyDepth(t *testing.T) {
+	svc := &FeedService{}
+	svc.SetMaxReplyDepth(5)
+	assert.Equal(t, 5, svc.maxReplyDepth)
+	svc.SetMaxReplyDepth(1)
+	assert.Equal(t, minReplyDepth, svc.maxReplyDepth)
+	svc.SetMaxReplyDepth(0)
+	assert.Equal(t, minReplyDepth, svc.maxReplyDepth)
+}
+
+func TestReactionType_Valid(t *testing.T) {
+	tests := []struct {
+		reaction models.ReactionType
+		want     bool
+	}{
+		{models.ReactionLike, true},
+		{models.ReactionAngry, true},
+		{"", false},
+		{"like", false},
+		{"CARE", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.reaction.Valid(), "reaction %q", tt.reaction)
+	}
+}
+
+func TestFeedService_CreateReaction_RejectsInvalidRequest(t *testing.T) {
+	// The target and type are checked before any repository is touched
+	svc := &FeedService{}
+	tests := []struct {
+		name    string
+		req     models.CreateReactionRequest
+		wantErr error
+	}{
+		{"unknown target", models.CreateReactionRequest{TargetType: "album", Type: models.ReactionLike}, ErrInvalidReactionTarget},
+		{"unknown type", models.CreateReactionRequest{TargetType: "post", Type: "CARE"}, ErrInvalidReactionType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			req.TargetID = primitive.NewObjectID()
+			_, err := svc.CreateReaction(context.Background(), primitive.NewObjectID(), &req)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
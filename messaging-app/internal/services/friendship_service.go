@@ -3,10 +3,10 @@ package services
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log"
 	"messaging-app/internal/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"messaging-app/internal/repositories"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
@@ -66,7 +66,7 @@ var (
 	ErrCannotFriendSelf      = repositories.ErrCannotFriendSelf
 	ErrFriendRequestExists   = repositories.ErrFriendRequestExists
 	ErrFriendRequestNotFound = repositories.ErrFriendRequestNotFound
-	ErrNotAuthorized         = errors.New("not authorized to perform this action")
+	ErrNotAuthorized         = apperrors.Forbidden("not authorized to perform this action")
 )
 
 func (s *FriendshipService) SendRequest(ctx context.Context, requesterID, receiverID primitive.ObjectID) (*models.Friendship, error) {
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrUserNotBannedFromGroup = apperrors.NotFound("user is not banned from this group")
+
+// BanMember removes a user from the group and keeps them from joining again,
+// whether added, invited or through an invite link. Users who are not members
+// can be banned too. Admins ban members; only the owner bans other admins,
+// and nobody bans the owner.
+func (s *GroupService) BanMember(ctx context.Context, groupID, actorID, targetID primitive.ObjectID) error {
+	group, err := s.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("group not found")
+	}
+	actorRole := group.RoleOf(actorID)
+	if !actorRole.AtLeast(models.GroupRoleAdmin) || actorID == targetID || targetID == group.Owner() {
+		return ErrGroupPermissionDenied
+	}
+	if group.RoleOf(targetID) == models.GroupRoleAdmin && actorRole != models.GroupRoleOwner {
+		return ErrGroupOwnerRequired
+	}
+
+	if err := s.groupRepo.BanUser(ctx, groupID, targetID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrGroupPermissionDenied
+		}
+		return err
+	}
+	if !containsID(group.Members, targetID) {
+		return nil
+	}
+
+	// Sync to Neo4j graph (async, non-blocking)
+	if s.groupGraphRepo != nil {
+		go s.groupGraphRepo.RemoveMember(context.Background(), targetID, groupID)
+	}
+
+	actor, actorErr := s.userRepo.FindUserByID(ctx, actorID)
+	target, targetErr := s.userRepo.FindUserByID(ctx, targetID)
+	if actorErr == nil && targetErr == nil {
+		activity := &models.GroupActivity{
+			GroupID:      groupID,
+			ActivityType: models.ActivityMemberRemoved,
+			ActorID:      actorID,
+			ActorName:    actor.Username,
+			TargetID:     &targetID,
+			TargetName:   target.Username,
+			CreatedAt:    time.Now(),
+		}
+		if err := s.activityRepo.CreateActivity(ctx, activity); err != nil {
+			log.Printf("Failed to create member removal activity: %v", err)
+		} else {
+			s.invalidateActivityCache(ctx, groupID)
+		}
+
+		// The banned user's inbox is updated too
+		if updatedGroup, err := s.groupRepo.GetGroup(ctx, groupID); err == nil {
+			updatedGroup.Members = append(updatedGroup.Members, targetID)
+			s.updateInboxForMembers(ctx, updatedGroup, activity)
+		}
+	}
+
+	s.invalidateMembershipCache(ctx, groupID)
+	return s.publishGroupEvent(ctx, groupID, "GROUP_UPDATED")
+}
+
+// UnbanMember lets a banned user join again. They are not made a member.
+func (s *GroupService) UnbanMember(ctx context.Context, groupID, actorID, targetID primitive.ObjectID) error {
+	group, err := s.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("group not found")
+	}
+	if !group.RoleOf(actorID).AtLeast(models.GroupRoleAdmin) {
+		return ErrGroupPermissionDenied
+	}
+	removed, err := s.groupRepo.UnbanUser(ctx, groupID, targetID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrUserNotBannedFromGroup
+	}
+	return nil
+}
+
+// ListBans returns the users on the group's ban list to its admins
+func (s *GroupService) ListBans(ctx context.Context, groupID, actorID primitive.ObjectID) ([]models.UserShortResponse, error) {
+	group, err := s.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !group.RoleOf(actorID).AtLeast(models.GroupRoleAdmin) {
+		return nil, ErrGroupPermissionDenied
+	}
+
+	users := make([]models.UserShortResponse, 0, len(group.BannedUsers))
+	for _, userID := range group.BannedUsers {
+		user, err := s.userRepo.FindUserByID(ctx, userID)
+		if err != nil {
+			continue
+		}
+		users = append(users, models.UserShortResponse{
+			ID:       user.ID,
+			Username: user.Username,
+			FullName: user.FullName,
+			Avatar:   user.Avatar,
+			Verified: user.Verified,
+		})
+	}
+	return users, nil
+}
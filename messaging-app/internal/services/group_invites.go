@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const groupInviteCodeLength = 12
+
+var (
+	ErrGroupInviteNotFound = apperrors.NotFound("invite link not found")
+	ErrGroupInviteExpired  = apperrors.Validation("invite link has expired or reached its use limit")
+)
+
+// CreateInvite creates an invite link for the group. It takes the
+// add_members permission.
+func (s *GroupService) CreateInvite(ctx context.Context, groupID, actorID primitive.ObjectID, req models.CreateGroupInviteRequest) (*models.GroupInvite, error) {
+	if _, err := s.groupForAction(ctx, groupID, actorID, models.GroupActionAddMembers); err != nil {
+		return nil, err
+	}
+
+	code, err := utils.GenerateRandomString(groupInviteCodeLength)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	invite := &models.GroupInvite{
+		GroupID:   groupID,
+		Code:      code,
+		CreatedBy: actorID,
+		MaxUses:   req.MaxUses,
+		CreatedAt: now,
+	}
+	if req.ExpiresInHours > 0 {
+		expiresAt := now.Add(time.Duration(req.ExpiresInHours) * time.Hour)
+		invite.ExpiresAt = &expiresAt
+	}
+	if err := s.inviteRepo.Create(ctx, invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// ListInvites returns the group's invite links, newest first
+func (s *GroupService) ListInvites(ctx context.Context, groupID, actorID primitive.ObjectID) ([]models.GroupInvite, error) {
+	if _, err := s.groupForAction(ctx, groupID, actorID, models.GroupActionAddMembers); err != nil {
+		return nil, err
+	}
+	return s.inviteRepo.ListByGroup(ctx, groupID)
+}
+
+// RevokeInvite disables an invite link
+func (s *GroupService) RevokeInvite(ctx context.Context, groupID, actorID primitive.ObjectID, code string) error {
+	if _, err := s.groupForAction(ctx, groupID, actorID, models.GroupActionAddMembers); err != nil {
+		return err
+	}
+	deleted, err := s.inviteRepo.Delete(ctx, groupID, code)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrGroupInviteNotFound
+	}
+	return nil
+}
+
+// PreviewInvite shows the group behind an invite link. It needs no user, so
+// links can be resolved before signing in, and so reveals no members.
+func (s *GroupService) PreviewInvite(ctx context.Context, code string) (*models.GroupInvitePreview, error) {
+	invite, err := s.usableInvite(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	group, err := s.groupRepo.GetGroup(ctx, invite.GroupID)
+	if err != nil {
+		return nil, ErrGroupInviteNotFound
+	}
+
+	preview := &models.GroupInvitePreview{
+		GroupID:     group.ID,
+		Name:        group.Name,
+		Avatar:      group.Avatar,
+		MemberCount: len(group.Members),
+		Full:        len(group.Members) >= group.Settings.MemberLimit(),
+		ExpiresAt:   invite.ExpiresAt,
+	}
+	if invite.MaxUses > 0 {
+		remaining := invite.MaxUses - invite.Uses
+		preview.RemainingUses = &remaining
+	}
+	return preview, nil
+}
+
+// JoinWithInvite makes the user a member of the invite's group right away,
+// skipping approval. Banned users are turned away, as is everyone once the
+// group is full; a pending join request of the user's is resolved.
+func (s *GroupService) JoinWithInvite(ctx context.Context, code string, userID primitive.ObjectID) (*models.Group, error) {
+	invite, err := s.usableInvite(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	group, err := s.groupRepo.GetGroup(ctx, invite.GroupID)
+	if err != nil {
+		return nil, ErrGroupInviteNotFound
+	}
+	if containsID(group.Members, userID) {
+		return nil, ErrAlreadyGroupMember
+	}
+	if containsID(group.BannedUsers, userID) {
+		return nil, ErrUserBannedFromGroup
+	}
+	if len(group.Members) >= group.Settings.MemberLimit() {
+		return nil, ErrGroupFull
+	}
+	user, err := s.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	// Count the use first, so concurrent joins can't exceed the limit
+	invite, err = s.inviteRepo.Use(ctx, code, time.Now())
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrGroupInviteExpired
+		}
+		return nil, err
+	}
+	added, err := s.addMember(ctx, group, userID)
+	if err == nil && !added {
+		// Joined concurrently, through another link or request
+		err = ErrAlreadyGroupMember
+	}
+	if err != nil {
+		if releaseErr := s.inviteRepo.Release(ctx, invite.ID); releaseErr != nil {
+			log.Printf("Failed to release use of group invite %s: %v", code, releaseErr)
+		}
+		return nil, err
+	}
+	if containsID(group.PendingMembers, userID) {
+		if err := s.groupRepo.RemovePendingMember(ctx, group.ID, userID); err != nil {
+			log.Printf("Failed to resolve join request of %s in group %s: %v", userID.Hex(), group.ID.Hex(), err)
+		}
+	}
+
+	// Sync to Neo4j graph (async, non-blocking)
+	if s.groupGraphRepo != nil {
+		go s.groupGraphRepo.AddMember(context.Background(), userID, group.ID)
+	}
+
+	activity := &models.GroupActivity{
+		GroupID:      group.ID,
+		ActivityType: models.ActivityMemberJoined,
+		ActorID:      userID,
+		ActorName:    user.Username,
+		CreatedAt:    time.Now(),
+	}
+	if err := s.activityRepo.CreateActivity(ctx, activity); err != nil {
+		log.Printf("Failed to create member joined activity: %v", err)
+	} else {
+		s.invalidateActivityCache(ctx, group.ID)
+	}
+
+	updatedGroup, err := s.groupRepo.GetGroup(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsID(updatedGroup.Members, userID) {
+		updatedGroup.Members = append(updatedGroup.Members, userID)
+	}
+	s.updateInboxForMembers(ctx, updatedGroup, activity)
+	s.invalidateMembershipCache(ctx, group.ID)
+
+	if err := s.publishGroupEvent(ctx, group.ID, "GROUP_UPDATED"); err != nil {
+		log.Printf("Failed to publish group updated event: %v", err)
+	}
+	return updatedGroup, nil
+}
+
+// usableInvite looks an invite up by code and checks it can still be used
+func (s *GroupService) usableInvite(ctx context.Context, code string) (*models.GroupInvite, error) {
+	invite, err := s.inviteRepo.GetByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrGroupInviteNotFound
+		}
+		return nil, err
+	}
+	if invite.ExpiresAt != nil && !invite.ExpiresAt.After(time.Now()) {
+		return nil, ErrGroupInviteExpired
+	}
+	if invite.MaxUses > 0 && invite.Uses >= invite.MaxUses {
+		return nil, ErrGroupInviteExpired
+	}
+	return invite, nil
+}
+
+// groupForAction loads the group, provided actorID's role allows action
+func (s *GroupService) groupForAction(ctx context.Context, groupID, actorID primitive.ObjectID, action models.GroupAction) (*models.Group, error) {
+	group, err := s.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !group.Can(actorID, action) {
+		return nil, ErrGroupPermissionDenied
+	}
+	return group, nil
+}
@@ -5,8 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"messaging-app/internal/cache"
 	"messaging-app/internal/db"
 	"messaging-app/internal/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
+	sharedcache "github.com/MuhibNayem/connectify-v2/shared-entity/cache"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"messaging-app/internal/repositories"
 	"time"
@@ -16,30 +20,53 @@ import (
 	kafkago "github.com/segmentio/kafka-go"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrGroupPermissionDenied = apperrors.Forbidden("insufficient group permissions")
+	ErrGroupOwnerRequired    = apperrors.Forbidden("only the group owner can do this")
+	ErrGroupOwnerLeaving     = apperrors.Validation("the group owner must transfer ownership before leaving")
+	ErrNotGroupMember        = apperrors.Validation("user is not a group member")
+	ErrInvalidGroupRole      = apperrors.Validation("invalid group role")
+	ErrAlreadyGroupMember    = apperrors.Conflict("user is already a group member")
+	ErrGroupFull             = apperrors.Conflict("the group has reached its member limit")
+	ErrUserBannedFromGroup   = apperrors.Forbidden("user is banned from this group")
+	ErrInvalidMemberLimit    = apperrors.Validation(fmt.Sprintf("max_members must be 0 or between the current member count and %d", models.MaxGroupMembers))
 )
 
 type GroupService struct {
 	groupRepo       *repositories.GroupRepository
+	inviteRepo      *repositories.GroupInviteRepository
 	userRepo        *repositories.UserRepository
 	activityRepo    *repositories.GroupActivityRepository
 	cassandraClient *db.CassandraClient
 	producer        *kafka.MessageProducer
 	redisClient     *redis.ClusterClient
 	groupGraphRepo  *repositories.GroupGraphRepository
+	groupCache      *cache.GroupCache
+	audit           *audit.Logger
 }
 
-func NewGroupService(groupRepo *repositories.GroupRepository, userRepo *repositories.UserRepository, activityRepo *repositories.GroupActivityRepository, cassandraClient *db.CassandraClient, producer *kafka.MessageProducer, redisClient *redis.ClusterClient, groupGraphRepo *repositories.GroupGraphRepository) *GroupService {
+func NewGroupService(groupRepo *repositories.GroupRepository, inviteRepo *repositories.GroupInviteRepository, userRepo *repositories.UserRepository, activityRepo *repositories.GroupActivityRepository, cassandraClient *db.CassandraClient, producer *kafka.MessageProducer, redisClient *redis.ClusterClient, groupGraphRepo *repositories.GroupGraphRepository) *GroupService {
 	return &GroupService{
 		groupRepo:       groupRepo,
+		inviteRepo:      inviteRepo,
 		userRepo:        userRepo,
 		activityRepo:    activityRepo,
 		cassandraClient: cassandraClient,
 		producer:        producer,
 		redisClient:     redisClient,
 		groupGraphRepo:  groupGraphRepo,
+		groupCache:      cache.NewGroupCache(redisClient),
 	}
 }
 
+// SetAuditLogger enables recording role changes in the audit log
+func (s *GroupService) SetAuditLogger(logger *audit.Logger) {
+	s.audit = logger
+}
+
 // invalidateActivityCache deletes the cached activities for a group
 // Call this after any activity is created to ensure fresh data
 func (s *GroupService) invalidateActivityCache(ctx context.Context, groupID primitive.ObjectID) {
@@ -49,83 +76,60 @@ func (s *GroupService) invalidateActivityCache(ctx context.Context, groupID prim
 	}
 }
 
-// invalidateMembershipCache deletes the cached member set for a group
+// invalidateMembershipCache deletes the cached member list for a group
 // Call this after any membership change (add/remove/leave)
 func (s *GroupService) invalidateMembershipCache(ctx context.Context, groupID primitive.ObjectID) {
-	if s.redisClient != nil {
-		cacheKey := "group_members:" + groupID.Hex()
-		s.redisClient.Del(ctx, cacheKey)
+	if err := s.groupCache.InvalidateMembers(ctx, groupID.Hex()); err != nil {
+		fmt.Printf("Failed to invalidate member cache for group %s: %v\n", groupID.Hex(), err)
 	}
 }
 
 // IsMember checks if a user is a member of a group (for IDOR authorization)
-// Uses hybrid Redis cache + Neo4j graph for O(1) lookups
+// The member list is served from the shared group cache; misses load from
+// Neo4j with MongoDB as the fallback, collapsed into one load per group.
 func (s *GroupService) IsMember(ctx context.Context, groupID, userID primitive.ObjectID) (bool, error) {
-	cacheKey := "group_members:" + groupID.Hex()
+	members, err := s.groupCache.Members(ctx, groupID.Hex(), func(ctx context.Context) ([]string, error) {
+		return s.loadMemberIDs(ctx, groupID)
+	})
+	if err != nil {
+		if errors.Is(err, sharedcache.ErrNotFound) {
+			return false, fmt.Errorf("group not found")
+		}
+		return false, err
+	}
 
-	// 1. Try Redis SET membership check (O(1) lookup, sub-ms)
-	if s.redisClient != nil {
-		isMember, err := s.redisClient.SIsMember(ctx, cacheKey, userID.Hex()).Result()
-		if err == nil {
-			// Check if key exists (SIsMember returns false for non-existent keys)
-			exists, _ := s.redisClient.Exists(ctx, cacheKey).Result()
-			if exists > 0 {
-				return isMember, nil
-			}
+	userHex := userID.Hex()
+	for _, memberID := range members {
+		if memberID == userHex {
+			return true, nil
 		}
 	}
+	return false, nil
+}
 
-	// 2. Cache miss - try Neo4j graph (O(1) pattern match)
+// loadMemberIDs resolves a group's member IDs from Neo4j, falling back to
+// MongoDB when the graph is unavailable or has not been synced yet.
+func (s *GroupService) loadMemberIDs(ctx context.Context, groupID primitive.ObjectID) ([]string, error) {
 	if s.groupGraphRepo != nil {
-		isMember, err := s.groupGraphRepo.IsMember(ctx, userID, groupID)
-		if err == nil {
-			// Populate Redis cache async for future requests
-			go s.populateMembersCacheFromNeo4j(groupID)
-			return isMember, nil
+		members, err := s.groupGraphRepo.GetMembers(ctx, groupID)
+		if err == nil && len(members) > 0 {
+			return members, nil
 		}
 	}
 
-	// 3. Fallback to MongoDB (defensive, should rarely hit)
 	group, err := s.groupRepo.GetGroup(ctx, groupID)
 	if err != nil {
-		return false, err
-	}
-
-	// Populate Redis cache async
-	if s.redisClient != nil && len(group.Members) > 0 {
-		go func(key string, members []primitive.ObjectID) {
-			memberHexes := make([]interface{}, len(members))
-			for i, m := range members {
-				memberHexes[i] = m.Hex()
-			}
-			s.redisClient.SAdd(context.Background(), key, memberHexes...)
-			s.redisClient.Expire(context.Background(), key, 10*time.Minute)
-		}(cacheKey, group.Members)
-	}
-
-	for _, memberID := range group.Members {
-		if memberID == userID {
-			return true, nil
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, sharedcache.ErrNotFound
 		}
+		return nil, err
 	}
-	return false, nil
-}
 
-// populateMembersCacheFromNeo4j fetches members from Neo4j and caches in Redis
-func (s *GroupService) populateMembersCacheFromNeo4j(groupID primitive.ObjectID) {
-	if s.groupGraphRepo == nil || s.redisClient == nil {
-		return
-	}
-	cacheKey := "group_members:" + groupID.Hex()
-	members, err := s.groupGraphRepo.GetMembers(context.Background(), groupID)
-	if err == nil && len(members) > 0 {
-		memberInterfaces := make([]interface{}, len(members))
-		for i, m := range members {
-			memberInterfaces[i] = m
-		}
-		s.redisClient.SAdd(context.Background(), cacheKey, memberInterfaces...)
-		s.redisClient.Expire(context.Background(), cacheKey, 10*time.Minute)
+	members := make([]string, len(group.Members))
+	for i, m := range group.Members {
+		members[i] = m.Hex()
 	}
+	return members, nil
 }
 
 func (s *GroupService) CreateGroup(ctx context.Context, creatorID primitive.ObjectID, name string, avatar string, memberIDs []primitive.ObjectID) (*models.Group, error) {
@@ -146,13 +150,20 @@ func (s *GroupService) CreateGroup(ctx context.Context, creatorID primitive.Obje
 	if !containsID(members, creatorID) {
 		members = append(members, creatorID)
 	}
+	if len(members) > models.MaxGroupMembers {
+		return nil, ErrGroupFull
+	}
 
 	group := &models.Group{
 		Name:      name,
 		Avatar:    avatar,
 		CreatorID: creatorID,
+		OwnerID:   creatorID,
 		Members:   members,
 		Admins:    []primitive.ObjectID{creatorID},
+		Settings: models.GroupSettings{
+			Permissions: models.DefaultGroupPermissions(),
+		},
 	}
 
 	// Create group in repository (MongoDB - primary source of truth)
@@ -209,9 +220,8 @@ func (s *GroupService) AddMember(ctx context.Context, groupID, requesterID, newM
 		return fmt.Errorf("group not found")
 	}
 
-	// Check if requester is admin
-	if !containsID(group.Admins, requesterID) {
-		return errors.New("only admins can add members")
+	if !group.Can(requesterID, models.GroupActionAddMembers) {
+		return ErrGroupPermissionDenied
 	}
 
 	// Check if user is already a member
@@ -226,7 +236,7 @@ func (s *GroupService) AddMember(ctx context.Context, groupID, requesterID, newM
 	}
 
 	// Add member to group (MongoDB - primary source of truth)
-	if err := s.groupRepo.AddMember(ctx, groupID, newMemberID); err != nil {
+	if _, err := s.addMember(ctx, group, newMemberID); err != nil {
 		return err
 	}
 
@@ -277,27 +287,7 @@ func (s *GroupService) AddMember(ctx context.Context, groupID, requesterID, newM
 }
 
 func (s *GroupService) AddAdmin(ctx context.Context, groupID, requesterID, newAdminID primitive.ObjectID) error {
-	group, err := s.groupRepo.GetGroup(ctx, groupID)
-	if err != nil {
-		return fmt.Errorf("group not found")
-	}
-
-	// Check if requester is admin
-	if !containsID(group.Admins, requesterID) {
-		return errors.New("only admins can add other admins")
-	}
-
-	// Check if user is already an admin
-	if containsID(group.Admins, newAdminID) {
-		return errors.New("user is already an admin")
-	}
-
-	// Check if user is a member
-	if !containsID(group.Members, newAdminID) {
-		return errors.New("user must be a member before becoming an admin")
-	}
-
-	return s.groupRepo.AddAdmin(ctx, groupID, newAdminID)
+	return s.SetMemberRole(ctx, groupID, requesterID, newAdminID, models.GroupRoleAdmin)
 }
 
 func (s *GroupService) RemoveMember(ctx context.Context, groupID, requesterID, memberID primitive.ObjectID) error {
@@ -309,9 +299,23 @@ func (s *GroupService) RemoveMember(ctx context.Context, groupID, requesterID, m
 	// Determine if this is a self-leave or admin removal
 	isSelfLeave := requesterID == memberID
 
-	// If not self-leave, check if requester is admin
-	if !isSelfLeave && !containsID(group.Admins, requesterID) {
-		return errors.New("only admins can remove members")
+	// The owner can't be removed, and has to hand the group over before leaving
+	if memberID == group.Owner() {
+		if isSelfLeave {
+			return ErrGroupOwnerLeaving
+		}
+		return ErrGroupPermissionDenied
+	}
+
+	// Admins remove members; only the owner removes other admins
+	if !isSelfLeave {
+		requesterRole := group.RoleOf(requesterID)
+		if !requesterRole.AtLeast(models.GroupRoleAdmin) {
+			return ErrGroupPermissionDenied
+		}
+		if group.RoleOf(memberID) == models.GroupRoleAdmin && requesterRole != models.GroupRoleOwner {
+			return ErrGroupOwnerRequired
+		}
 	}
 
 	// Check if trying to remove last admin
@@ -392,9 +396,8 @@ func (s *GroupService) UpdateGroup(ctx context.Context, groupID, requesterID pri
 		return fmt.Errorf("group not found")
 	}
 
-	// Check if requester is admin
-	if !containsID(group.Admins, requesterID) {
-		return errors.New("only admins can update group")
+	if !group.Can(requesterID, models.GroupActionEditInfo) {
+		return ErrGroupPermissionDenied
 	}
 
 	// Filter allowed fields to update
@@ -418,6 +421,9 @@ func (s *GroupService) UpdateGroup(ctx context.Context, groupID, requesterID pri
 	if err := s.groupRepo.UpdateGroup(ctx, groupID, filteredUpdates); err != nil {
 		return err
 	}
+	if _, ok := filteredUpdates["name"]; ok {
+		_ = s.groupCache.InvalidateName(ctx, groupID.Hex())
+	}
 
 	// Fetch updated group to broadcast
 	updatedGroup, err := s.groupRepo.GetGroup(ctx, groupID)
@@ -486,17 +492,21 @@ func (s *GroupService) InviteMember(ctx context.Context, groupID, inviterID, inv
 	if containsID(group.PendingMembers, inviteeID) {
 		return errors.New("user is already pending approval")
 	}
+	if containsID(group.BannedUsers, inviteeID) {
+		return ErrUserBannedFromGroup
+	}
 
 	// Logic:
-	// If Inviter is Admin -> Add Immediate
+	// If Inviter may add members -> Add Immediate
 	// If Settings.RequiresApproval is FALSE -> Add Immediate
 	// Else -> Add Pending
 
-	isInviterAdmin := containsID(group.Admins, inviterID)
+	canAddMembers := group.Can(inviterID, models.GroupActionAddMembers)
 	requiresApproval := group.Settings.RequiresApproval
 
-	if isInviterAdmin || !requiresApproval {
-		return s.groupRepo.AddMember(ctx, groupID, inviteeID)
+	if canAddMembers || !requiresApproval {
+		_, err := s.addMember(ctx, group, inviteeID)
+		return err
 	}
 
 	// Add to pending
@@ -509,20 +519,20 @@ func (s *GroupService) ApproveMember(ctx context.Context, groupID, adminID, targ
 		return fmt.Errorf("group not found")
 	}
 
-	if !containsID(group.Admins, adminID) {
-		return errors.New("only admins can approve members")
+	if !group.Can(adminID, models.GroupActionAddMembers) {
+		return ErrGroupPermissionDenied
 	}
 
 	if !containsID(group.PendingMembers, targetUserID) {
 		return errors.New("user is not in pending list")
 	}
 
-	// Remove from pending
-	if err := s.groupRepo.RemovePendingMember(ctx, groupID, targetUserID); err != nil {
+	// Add to members first, so a full group keeps the request pending
+	if _, err := s.addMember(ctx, group, targetUserID); err != nil {
 		return err
 	}
-	// Add to members
-	if err := s.groupRepo.AddMember(ctx, groupID, targetUserID); err != nil {
+	// Remove from pending
+	if err := s.groupRepo.RemovePendingMember(ctx, groupID, targetUserID); err != nil {
 		return err
 	}
 
@@ -577,8 +587,8 @@ func (s *GroupService) RejectMember(ctx context.Context, groupID, adminID, targe
 		return fmt.Errorf("group not found")
 	}
 
-	if !containsID(group.Admins, adminID) {
-		return errors.New("only admins can reject members")
+	if !group.Can(adminID, models.GroupActionAddMembers) {
+		return ErrGroupPermissionDenied
 	}
 
 	if err := s.groupRepo.RemovePendingMember(ctx, groupID, targetUserID); err != nil {
@@ -589,37 +599,210 @@ func (s *GroupService) RejectMember(ctx context.Context, groupID, adminID, targe
 }
 
 func (s *GroupService) RemoveAdmin(ctx context.Context, groupID, requesterID, adminID primitive.ObjectID) error {
+	return s.SetMemberRole(ctx, groupID, requesterID, adminID, models.GroupRoleMember)
+}
+
+// SetMemberRole makes a member an admin or a plain member. Admins can promote
+// members; demoting an admin takes the owner, though admins may step down
+// themselves. The owner's own role only changes through TransferOwnership.
+func (s *GroupService) SetMemberRole(ctx context.Context, groupID, requesterID, targetID primitive.ObjectID, role models.GroupRole) error {
+	if role != models.GroupRoleAdmin && role != models.GroupRoleMember {
+		return ErrInvalidGroupRole
+	}
+
 	group, err := s.groupRepo.GetGroup(ctx, groupID)
 	if err != nil {
 		return fmt.Errorf("group not found")
 	}
 
-	if !containsID(group.Admins, requesterID) {
-		return errors.New("only admins can remove admins")
+	requesterRole := group.RoleOf(requesterID)
+	previousRole := group.RoleOf(targetID)
+	if previousRole == "" {
+		return ErrNotGroupMember
+	}
+	if previousRole == models.GroupRoleOwner {
+		return ErrGroupPermissionDenied
+	}
+	if previousRole == role {
+		if role == models.GroupRoleAdmin {
+			return errors.New("user is already an admin")
+		}
+		return nil
 	}
 
-	if len(group.Admins) <= 1 {
-		return errors.New("cannot remove the last admin")
+	activityType := models.ActivityAdminAdded
+	if role == models.GroupRoleAdmin {
+		if !requesterRole.AtLeast(models.GroupRoleAdmin) {
+			return ErrGroupPermissionDenied
+		}
+	} else {
+		if requesterRole != models.GroupRoleOwner && requesterID != targetID {
+			return ErrGroupOwnerRequired
+		}
+		if len(group.Admins) <= 1 {
+			return errors.New("cannot remove the last admin")
+		}
+		activityType = models.ActivityAdminRemoved
 	}
 
-	// To remove admin (demote), we assume the repository has a RemoveAdmin method
-	// NOTE: existing RemoveMember removes from BOTH.
-	// We need a specific RemoveAdmin (demote) repo function or custom update.
-	// Since we can't change Repo structure easily repeatedly, let's implement demote logic here via generic Update if possible?
-	// But Repo UpdateGroup is generic.
-	// Better: Add RemoveAdminRole to Repo?
-	// Or use generic UpdateGroup with $pull from admins array.
-	// Actually GroupRepo has UpdateGroup. we can use that.
+	if err := s.groupRepo.SetRole(ctx, groupID, targetID, role); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrNotGroupMember
+		}
+		return err
+	}
+
+	s.recordActivity(ctx, groupID, activityType, requesterID, targetID)
+	s.auditRoleChange(ctx, groupID, targetID, previousRole, role, requesterID)
+	return s.publishRoleChange(ctx, groupID, targetID, previousRole, role, requesterID)
+}
 
-	// Use the dedicated RemoveAdmin repository method
-	if err := s.groupRepo.RemoveAdmin(ctx, groupID, adminID); err != nil {
+// TransferOwnership hands the group to another member. The previous owner
+// stays on as an admin.
+func (s *GroupService) TransferOwnership(ctx context.Context, groupID, requesterID, newOwnerID primitive.ObjectID) error {
+	group, err := s.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("group not found")
+	}
+
+	if group.RoleOf(requesterID) != models.GroupRoleOwner {
+		return ErrGroupOwnerRequired
+	}
+	previousRole := group.RoleOf(newOwnerID)
+	if previousRole == "" {
+		return ErrNotGroupMember
+	}
+	if previousRole == models.GroupRoleOwner {
+		return nil
+	}
+
+	if err := s.groupRepo.TransferOwnership(ctx, groupID, requesterID, newOwnerID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrGroupOwnerRequired
+		}
+		return err
+	}
+
+	s.recordActivity(ctx, groupID, models.ActivityOwnerChanged, requesterID, newOwnerID)
+	s.auditRoleChange(ctx, groupID, newOwnerID, previousRole, models.GroupRoleOwner, requesterID)
+	return s.publishRoleChange(ctx, groupID, newOwnerID, previousRole, models.GroupRoleOwner, requesterID)
+}
+
+// UpdatePermissions replaces the group's permission matrix. Only the owner
+// decides who may do what.
+func (s *GroupService) UpdatePermissions(ctx context.Context, groupID, requesterID primitive.ObjectID, permissions models.GroupPermissions) error {
+	if !permissions.Valid() {
+		return ErrInvalidGroupRole
+	}
+
+	group, err := s.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("group not found")
+	}
+
+	if group.RoleOf(requesterID) != models.GroupRoleOwner {
+		return ErrGroupOwnerRequired
+	}
+
+	if err := s.groupRepo.UpdatePermissions(ctx, groupID, permissions.Resolved()); err != nil {
 		return err
 	}
 
-	// Publish update event
 	return s.publishGroupEvent(ctx, groupID, "GROUP_UPDATED")
 }
 
+// recordActivity logs a role change against the group and surfaces it in
+// every member's inbox. Failures are logged; the change itself already stuck.
+func (s *GroupService) recordActivity(ctx context.Context, groupID primitive.ObjectID, activityType models.ActivityType, actorID, targetID primitive.ObjectID) {
+	actor, err := s.userRepo.FindUserByID(ctx, actorID)
+	if err != nil {
+		fmt.Printf("Failed to fetch actor details: %v\n", err)
+		return
+	}
+	target, err := s.userRepo.FindUserByID(ctx, targetID)
+	if err != nil {
+		fmt.Printf("Failed to fetch target details: %v\n", err)
+		return
+	}
+
+	activity := &models.GroupActivity{
+		GroupID:      groupID,
+		ActivityType: activityType,
+		ActorID:      actorID,
+		ActorName:    actor.Username,
+		TargetID:     &targetID,
+		TargetName:   target.Username,
+		CreatedAt:    time.Now(),
+	}
+	if err := s.activityRepo.CreateActivity(ctx, activity); err != nil {
+		fmt.Printf("Failed to create %s activity: %v\n", activityType, err)
+		return
+	}
+	s.invalidateActivityCache(ctx, groupID)
+
+	if group, err := s.groupRepo.GetGroup(ctx, groupID); err == nil {
+		s.updateInboxForMembers(ctx, group, activity)
+	}
+}
+
+// publishRoleChange broadcasts GROUP_ROLE_CHANGED to the group's members with
+// the group as it stands after the change
+func (s *GroupService) auditRoleChange(ctx context.Context, groupID, userID primitive.ObjectID, previousRole, role models.GroupRole, changedBy primitive.ObjectID) {
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditActionRoleChanged,
+		ActorID:    &changedBy,
+		TargetType: "group_member",
+		TargetID:   userID.Hex(),
+		Metadata: map[string]string{
+			"group_id":      groupID.Hex(),
+			"previous_role": string(previousRole),
+			"role":          string(role),
+		},
+	})
+}
+
+func (s *GroupService) publishRoleChange(ctx context.Context, groupID, userID primitive.ObjectID, previousRole, role models.GroupRole, changedBy primitive.ObjectID) error {
+	group, err := s.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch group for broadcast: %w", err)
+	}
+
+	data, err := json.Marshal(models.GroupRoleChangedEvent{
+		Group:        s.buildGroupResponse(ctx, group),
+		UserID:       userID,
+		Role:         role,
+		PreviousRole: previousRole,
+		ChangedBy:    changedBy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal role change event: %w", err)
+	}
+
+	recipients := make([]string, len(group.Members))
+	for i, memberID := range group.Members {
+		recipients[i] = memberID.Hex()
+	}
+	eventBytes, err := json.Marshal(models.WebSocketEvent{
+		Type:       "GROUP_ROLE_CHANGED",
+		Data:       data,
+		Recipients: recipients,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal role change event: %w", err)
+	}
+
+	msg := kafkago.Message{
+		Key:   []byte(groupID.Hex()),
+		Value: eventBytes,
+		Time:  time.Now(),
+	}
+	if err := s.producer.ProduceMessage(ctx, msg); err != nil {
+		fmt.Printf("Failed to publish role change event: %v\n", err)
+		return err
+	}
+	return nil
+}
+
 func (s *GroupService) publishGroupEvent(ctx context.Context, groupID primitive.ObjectID, eventType string) error {
 	// 1. Fetch latest group state
 	updatedGroup, err := s.groupRepo.GetGroup(ctx, groupID)
@@ -627,9 +810,41 @@ func (s *GroupService) publishGroupEvent(ctx context.Context, groupID primitive.
 		return fmt.Errorf("failed to fetch group for broadcast: %w", err)
 	}
 
-	// 2. Fetch User Details for Enrichment
-	// We need to construct models.GroupResponse
+	// 2. Enrich with user details
+	response := s.buildGroupResponse(ctx, updatedGroup)
+
+	// 3. Publish event to Kafka
+	// Log the admins list (enriched) to verify
+	// fmt.Printf("Broadcasting ENRICHED %s for group %s. Admins Count: %d\n", eventType, updatedGroup.ID.Hex(), len(response.Admins))
+
+	eventPayload := map[string]interface{}{
+		"type": eventType, // Dynamic event type
+		"data": response,  // The enriched response object
+	}
 
+	eventBytes, err := json.Marshal(eventPayload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal group event: %w", err)
+	}
+
+	// Produce to "feed" topic which ws.go consumes
+	msg := kafkago.Message{
+		Key:   []byte(groupID.Hex()),
+		Value: eventBytes,
+		Time:  time.Now(),
+	}
+
+	if err := s.producer.ProduceMessage(ctx, msg); err != nil {
+		fmt.Printf("Failed to publish group event: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// buildGroupResponse enriches a group with the details of its users for
+// broadcasting. Users that fail to load are left out rather than failing
+// the broadcast.
+func (s *GroupService) buildGroupResponse(ctx context.Context, updatedGroup *models.Group) models.GroupResponse {
 	// Helper to fetch user details safely
 	getUserShort := func(uid primitive.ObjectID) (models.UserShortResponse, error) {
 		u, err := s.userRepo.FindUserByID(ctx, uid)
@@ -641,6 +856,7 @@ func (s *GroupService) publishGroupEvent(ctx context.Context, groupID primitive.
 			Username: u.Username,
 			Email:    u.Email,
 			Avatar:   u.Avatar,
+			Verified: u.Verified,
 		}, nil
 	}
 
@@ -677,46 +893,22 @@ func (s *GroupService) publishGroupEvent(ctx context.Context, groupID primitive.
 		}
 	}
 
-	// 3. Construct Response
-	response := models.GroupResponse{
+	settings := updatedGroup.Settings
+	settings.Permissions = settings.Permissions.Resolved()
+
+	return models.GroupResponse{
 		ID:             updatedGroup.ID,
 		Name:           updatedGroup.Name,
 		Avatar:         updatedGroup.Avatar,
 		Creator:        creator,
+		OwnerID:        updatedGroup.Owner(),
 		Members:        members,
 		PendingMembers: pendingMembers,
 		Admins:         admins,
-		Settings:       updatedGroup.Settings,
+		Settings:       settings,
 		CreatedAt:      updatedGroup.CreatedAt,
 		UpdatedAt:      updatedGroup.UpdatedAt,
 	}
-
-	// 4. Publish event to Kafka
-	// Log the admins list (enriched) to verify
-	// fmt.Printf("Broadcasting ENRICHED %s for group %s. Admins Count: %d\n", eventType, updatedGroup.ID.Hex(), len(admins))
-
-	eventPayload := map[string]interface{}{
-		"type": eventType, // Dynamic event type
-		"data": response,  // The enriched response object
-	}
-
-	eventBytes, err := json.Marshal(eventPayload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal group event: %w", err)
-	}
-
-	// Produce to "feed" topic which ws.go consumes
-	msg := kafkago.Message{
-		Key:   []byte(groupID.Hex()),
-		Value: eventBytes,
-		Time:  time.Now(),
-	}
-
-	if err := s.producer.ProduceMessage(ctx, msg); err != nil {
-		fmt.Printf("Failed to publish group event: %v\n", err)
-		return err
-	}
-	return nil
 }
 
 func (s *GroupService) UpdateGroupSettings(ctx context.Context, groupID, requesterID primitive.ObjectID, settings models.GroupSettings) error {
@@ -728,6 +920,9 @@ func (s *GroupService) UpdateGroupSettings(ctx context.Context, groupID, request
 	if !containsID(group.Admins, requesterID) {
 		return errors.New("only admins can update settings")
 	}
+	if err := validateMemberLimit(settings.MaxMembers, len(group.Members)); err != nil {
+		return err
+	}
 
 	if err := s.groupRepo.UpdateGroupSettings(ctx, groupID, settings); err != nil {
 		return err
@@ -758,9 +953,9 @@ func (s *GroupService) updateInboxForMembers(ctx context.Context, group *models.
 
 	// Use UnloggedBatch for maximum performance
 	// Unlogged is safe here because these are independent writes to different partitions
-	// CHUNKING: Split into batches of 50 to support groups with 10k+ members
-	// Cassandra recommends keeping batches < 5KB or < 100 statements
-	batchSize := 50
+	// CHUNKING: Split into batches of 25 members to support groups with 10k+ members
+	// Cassandra recommends keeping batches < 5KB or < 100 statements; each member takes two
+	batchSize := 25
 	totalUpdated := 0
 
 	for i := 0; i < len(group.Members); i += batchSize {
@@ -785,6 +980,7 @@ func (s *GroupService) updateInboxForMembers(ctx context.Context, group *models.
 				activity.ActorName,
 				now,
 			)
+			repositories.AppendInboxChange(batch, memberID.Hex(), conversationID)
 		}
 
 		// Execute chunk
@@ -798,6 +994,36 @@ func (s *GroupService) updateInboxForMembers(ctx context.Context, group *models.
 	fmt.Printf("[DEBUG] Successfully updated inbox for %d/%d members (Chunked)\n", totalUpdated, len(group.Members))
 }
 
+// addMember adds a user to the group unless they are banned or the group is
+// at its member limit
+func (s *GroupService) addMember(ctx context.Context, group *models.Group, userID primitive.ObjectID) (bool, error) {
+	if containsID(group.BannedUsers, userID) {
+		return false, ErrUserBannedFromGroup
+	}
+	added, err := s.groupRepo.AddMemberWithinLimit(ctx, group.ID, userID, group.Settings.MemberLimit())
+	switch {
+	case errors.Is(err, repositories.ErrGroupMemberBanned):
+		return false, ErrUserBannedFromGroup
+	case errors.Is(err, repositories.ErrGroupMemberLimit):
+		return false, ErrGroupFull
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return false, fmt.Errorf("group not found")
+	}
+	return added, err
+}
+
+// validateMemberLimit checks a group's own member limit: 0 for the default,
+// or at least the current member count and at most MaxGroupMembers
+func validateMemberLimit(maxMembers, memberCount int) error {
+	if maxMembers == 0 {
+		return nil
+	}
+	if maxMembers < memberCount || maxMembers < 2 || maxMembers > models.MaxGroupMembers {
+		return ErrInvalidMemberLimit
+	}
+	return nil
+}
+
 func containsID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
 	for _, i := range ids {
 		if i == id {
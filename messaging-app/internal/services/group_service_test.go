@@ -0,0 +1,24 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMemberLimit(t *testing.T) {
+	assert.NoError(t, validateMemberLimit(0, 40))
+	assert.NoError(t, validateMemberLimit(40, 40))
+	assert.NoError(t, validateMemberLimit(models.MaxGroupMembers, 3))
+
+	assert.ErrorIs(t, validateMemberLimit(39, 40), ErrInvalidMemberLimit)
+	assert.ErrorIs(t, validateMemberLimit(1, 1), ErrInvalidMemberLimit)
+	assert.ErrorIs(t, validateMemberLimit(models.MaxGroupMembers+1, 3), ErrInvalidMemberLimit)
+}
+
+func TestGroupSettings_MemberLimit(t *testing.T) {
+	assert.Equal(t, models.MaxGroupMembers, models.GroupSettings{}.MemberLimit())
+	assert.Equal(t, 50, models.GroupSettings{MaxMembers: 50}.MemberLimit())
+	assert.Equal(t, models.MaxGroupMembers, models.GroupSettings{MaxMembers: models.MaxGroupMembers * 2}.MemberLimit())
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/linkpreview"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SetLinkPreviews unfurls the first link of new and edited messages
+func (s *MessageService) SetLinkPreviews(worker *linkpreview.Worker) {
+	s.linkPreviews = worker
+}
+
+// SetLinkPreviews unfurls the first link of new and edited posts
+func (s *FeedService) SetLinkPreviews(worker *linkpreview.Worker) {
+	s.linkPreviews = worker
+}
+
+// queueMessagePreview unfurls the first link of a message's content. Once
+// the preview is ready it is stored with the message, unless the message was
+// edited or deleted meanwhile, and sent to the conversation.
+func (s *MessageService) queueMessagePreview(convKey, messageID, content string) {
+	if linkpreview.FirstURL(content) == "" {
+		return
+	}
+	s.linkPreviews.Enqueue(content, func(ctx context.Context, preview *models.LinkPreview) {
+		if preview == nil {
+			return
+		}
+		applied, err := s.messageCassandraRepo.SetLinkPreview(ctx, convKey, messageID, content, preview)
+		if err != nil {
+			log.Printf("Failed to store link preview of message %s: %v", messageID, err)
+			return
+		}
+		if applied {
+			s.publishLinkPreviewEvent(ctx, models.MessageLinkPreviewEvent{
+				ConversationID: convKey,
+				MessageID:      messageID,
+				LinkPreview:    preview,
+			})
+		}
+	})
+}
+
+// publishLinkPreviewEvent sends a message's new link preview to its
+// conversation
+func (s *MessageService) publishLinkPreviewEvent(ctx context.Context, event models.MessageLinkPreviewEvent) {
+	convKey := event.ConversationID
+	recipients, err := s.conversationRecipients(ctx, convKey)
+	if err != nil {
+		log.Printf("Failed to resolve members of %s for link preview event: %v", convKey, err)
+		return
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to marshal link preview event for %s: %v", convKey, err)
+		return
+	}
+	eventBytes, err := json.Marshal(models.WebSocketEvent{
+		Type:       "MESSAGE_LINK_PREVIEW",
+		Data:       data,
+		Recipients: recipients,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal link preview event for %s: %v", convKey, err)
+		return
+	}
+	if err := s.producer.ProduceMessage(ctx, kafkago.Message{
+		Key:   []byte(convKey),
+		Value: eventBytes,
+		Time:  time.Now(),
+	}); err != nil {
+		log.Printf("Failed to publish link preview event for %s: %v", convKey, err)
+	}
+}
+
+// queuePostPreview unfurls the first link of a post's content. Once the
+// preview is ready it is stored with the post, unless the post was edited or
+// deleted meanwhile, and a published post is sent out again as PostUpdated.
+func (s *FeedService) queuePostPreview(post *models.Post) {
+	postID, content := post.ID, post.Content
+	if linkpreview.FirstURL(content) == "" {
+		return
+	}
+	s.linkPreviews.Enqueue(content, func(ctx context.Context, preview *models.LinkPreview) {
+		if preview == nil {
+			return
+		}
+		updated, err := s.feedRepo.SetLinkPreview(ctx, postID, content, preview)
+		if err != nil {
+			if !errors.Is(err, mongo.ErrNoDocuments) {
+				log.Printf("Failed to store link preview of post %s: %v", postID.Hex(), err)
+			}
+			return
+		}
+		if updated.Status != models.PostStatusActive {
+			return
+		}
+		if author, err := s.userRepo.FindUserByID(ctx, updated.UserID); err == nil {
+			updated.Author = models.PostAuthor{
+				ID:       author.ID.Hex(),
+				Username: author.Username,
+				Avatar:   author.Avatar,
+				FullName: author.FullName,
+				Verified: author.Verified,
+			}
+		}
+		s.publishPostEvent(ctx, "PostUpdated", updated)
+	})
+}
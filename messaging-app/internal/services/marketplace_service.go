@@ -111,6 +111,7 @@ func (s *MarketplaceService) GetProductByID(ctx context.Context, id primitive.Ob
 			Username: seller.Username,
 			FullName: seller.FullName,
 			Avatar:   seller.Avatar,
+			Verified: seller.Verified,
 		},
 		Category: category,
 		IsSaved:  isSaved,
@@ -141,7 +142,12 @@ func (s *MarketplaceService) SearchProducts(ctx context.Context, filter models.P
 
 func (s *MarketplaceService) GetMarketplaceConversations(ctx context.Context, userID primitive.ObjectID) ([]models.ConversationSummary, error) {
 	// Use Cassandra for scalable marketplace inbox
-	return s.messageCassandraRepo.GetInbox(ctx, userID, true) // isMarketplace = true
+	summaries, err := s.messageCassandraRepo.GetInbox(ctx, userID, true) // isMarketplace = true
+	if err != nil {
+		return nil, err
+	}
+	summaries, _ = splitRequests(summaries)
+	return summaries, nil
 }
 
 func (s *MarketplaceService) MarkProductSold(ctx context.Context, productID, userID primitive.ObjectID) error {
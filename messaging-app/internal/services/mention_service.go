@@ -0,0 +1,373 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"messaging-app/internal/cache"
+	"messaging-app/internal/repositories"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	defaultMentionLimit = 8
+	maxMentionLimit     = 20
+	// mentionPoolSize is how many prefix matches are ranked for a query
+	mentionPoolSize = 100
+	// mentionBackfillBatch is how many users are indexed per page when the
+	// index is first built
+	mentionBackfillBatch = 500
+)
+
+// Ranking weights of mention candidates
+const (
+	mentionFriendWeight      = 3.0
+	mentionGroupWeight       = 1.0 // Per shared group, up to mentionMaxSharedGroups
+	mentionMaxSharedGroups   = 3
+	mentionRecentWeight      = 4.0 // Halves after a week without contact
+	mentionScopeWeight       = 2.0
+	mentionExactMatchWeight  = 2.0
+	mentionUsernameWeight    = 0.5
+	mentionRecentHalfLifeDay = 7.0
+)
+
+var (
+	ErrMentionQueryRequired = apperrors.Validation("a query is required")
+	ErrInvalidCommunityID   = apperrors.Validation("invalid community ID")
+)
+
+// MentionService suggests users to @mention while typing, ranked by
+// friendship, recent interaction and group co-membership
+type MentionService struct {
+	index          *cache.MentionIndex
+	userRepo       *repositories.UserRepository
+	friendshipRepo *repositories.FriendshipRepository
+	groupRepo      *repositories.GroupRepository
+	communityRepo  *repositories.CommunityRepository
+	messages       *MessageService
+	blocks         BlockLookup
+}
+
+func NewMentionService(index *cache.MentionIndex, userRepo *repositories.UserRepository, friendshipRepo *repositories.FriendshipRepository, groupRepo *repositories.GroupRepository, communityRepo *repositories.CommunityRepository, messages *MessageService) *MentionService {
+	return &MentionService{
+		index:          index,
+		userRepo:       userRepo,
+		friendshipRepo: friendshipRepo,
+		groupRepo:      groupRepo,
+		communityRepo:  communityRepo,
+		messages:       messages,
+	}
+}
+
+// SetBlockLookup leaves users who blocked each other out of suggestions
+func (s *MentionService) SetBlockLookup(blocks BlockLookup) {
+	s.blocks = blocks
+}
+
+// Suggest returns the users whose name starts with the query, best match
+// first. Within a conversation only its participants are suggested; within
+// a community its members rank higher.
+func (s *MentionService) Suggest(ctx context.Context, userID primitive.ObjectID, query models.MentionQuery) ([]models.MentionSuggestion, error) {
+	prefix := strings.TrimLeft(strings.TrimSpace(query.Query), "@")
+	if prefix == "" {
+		return nil, ErrMentionQueryRequired
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultMentionLimit
+	}
+	if limit > maxMentionLimit {
+		limit = maxMentionLimit
+	}
+
+	var scope map[string]bool
+	var candidates []models.User
+	var err error
+	switch {
+	case query.ConversationID != "":
+		convKey, err := s.messages.ConversationKey(ctx, userID, query.ConversationID, query.IsGroup)
+		if err != nil {
+			return nil, err
+		}
+		participants, err := s.messages.conversationRecipients(ctx, convKey)
+		if err != nil {
+			return nil, err
+		}
+		scope = toSet(participants)
+		// Participants are few enough to match directly, which also finds
+		// those the global prefix pool would cut off
+		candidates, err = s.usersMatching(ctx, participants, prefix)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		if query.CommunityID != "" {
+			if scope, err = s.communityMembers(ctx, query.CommunityID); err != nil {
+				return nil, err
+			}
+		}
+		if candidates, err = s.poolCandidates(ctx, userID, prefix); err != nil {
+			return nil, err
+		}
+	}
+
+	suggestions := s.rank(ctx, userID, prefix, candidates, scope)
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// poolCandidates reads the prefix index and adds the recent contacts whose
+// names match, who may sort past the end of the index page
+func (s *MentionService) poolCandidates(ctx context.Context, userID primitive.ObjectID, prefix string) ([]models.User, error) {
+	ids, err := s.index.Lookup(ctx, prefix, mentionPoolSize)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.usersMatching(ctx, ids, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	recent, err := s.index.RecentInteractions(ctx, userID.Hex())
+	if err != nil {
+		log.Printf("Failed to read recent contacts of %s: %v", userID.Hex(), err)
+		return candidates, nil
+	}
+	seen := toSet(ids)
+	var extra []string
+	for id := range recent {
+		if !seen[id] {
+			extra = append(extra, id)
+		}
+	}
+	more, err := s.usersMatching(ctx, extra, prefix)
+	if err != nil {
+		log.Printf("Failed to load recent contacts of %s: %v", userID.Hex(), err)
+		return candidates, nil
+	}
+	return append(candidates, more...), nil
+}
+
+// usersMatching loads the given users and keeps those with a name starting
+// with prefix
+func (s *MentionService) usersMatching(ctx context.Context, ids []string, prefix string) ([]models.User, error) {
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+	if len(oids) == 0 {
+		return nil, nil
+	}
+	users, err := s.userRepo.FindUsersByIDs(ctx, oids)
+	if err != nil {
+		return nil, err
+	}
+	matched := users[:0]
+	for _, u := range users {
+		if u.DeletedAt == nil && cache.MentionMatches(u.Username, u.FullName, prefix) {
+			matched = append(matched, u)
+		}
+	}
+	return matched, nil
+}
+
+func (s *MentionService) communityMembers(ctx context.Context, communityID string) (map[string]bool, error) {
+	cID, err := primitive.ObjectIDFromHex(communityID)
+	if err != nil {
+		return nil, ErrInvalidCommunityID
+	}
+	community, err := s.communityRepo.GetByID(ctx, cID)
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[string]bool, len(community.Members))
+	for _, id := range community.Members {
+		members[id.Hex()] = true
+	}
+	return members, nil
+}
+
+// rank scores the candidates for userID. The signals are best effort: one
+// that cannot be read counts for nobody.
+func (s *MentionService) rank(ctx context.Context, userID primitive.ObjectID, prefix string, candidates []models.User, scope map[string]bool) []models.MentionSuggestion {
+	if len(candidates) == 0 {
+		return []models.MentionSuggestion{}
+	}
+
+	friends := make(map[string]bool)
+	if ids, err := s.friendshipRepo.GetFriendIDs(ctx, userID); err == nil {
+		for _, id := range ids {
+			friends[id.Hex()] = true
+		}
+	} else {
+		log.Printf("Failed to load friends of %s for mentions: %v", userID.Hex(), err)
+	}
+
+	sharedGroups := make(map[string]int)
+	if groups, err := s.groupRepo.GetUserGroups(ctx, userID); err == nil {
+		for _, g := range groups {
+			for _, member := range g.Members {
+				sharedGroups[member.Hex()]++
+			}
+		}
+	} else {
+		log.Printf("Failed to load groups of %s for mentions: %v", userID.Hex(), err)
+	}
+
+	recent, err := s.index.RecentInteractions(ctx, userID.Hex())
+	if err != nil {
+		log.Printf("Failed to read recent contacts of %s: %v", userID.Hex(), err)
+	}
+
+	blocked := make(map[string]bool)
+	if ids, err := blockedUserIDs(ctx, s.blocks, userID); err == nil {
+		for _, id := range ids {
+			blocked[id.Hex()] = true
+		}
+	} else {
+		log.Printf("Failed to load blocks of %s for mentions: %v", userID.Hex(), err)
+	}
+
+	normalized := strings.ToLower(prefix)
+	now := time.Now()
+	seen := make(map[string]bool, len(candidates))
+	suggestions := make([]models.MentionSuggestion, 0, len(candidates))
+	for _, u := range candidates {
+		id := u.ID.Hex()
+		if u.ID == userID || seen[id] || blocked[id] {
+			continue
+		}
+		seen[id] = true
+
+		suggestion := models.MentionSuggestion{
+			User: models.PostAuthor{
+				ID:       id,
+				Username: u.Username,
+				Avatar:   u.Avatar,
+				FullName: u.FullName,
+				Verified: u.Verified,
+			},
+			IsFriend:     friends[id],
+			SharedGroups: sharedGroups[id],
+			InScope:      scope[id],
+		}
+		if suggestion.IsFriend {
+			suggestion.Score += mentionFriendWeight
+		}
+		suggestion.Score += mentionGroupWeight * float64(min(suggestion.SharedGroups, mentionMaxSharedGroups))
+		if at, ok := recent[id]; ok {
+			suggestion.LastInteraction = &at
+			days := now.Sub(at).Hours() / 24
+			suggestion.Score += mentionRecentWeight / (1 + days/mentionRecentHalfLifeDay)
+		}
+		if suggestion.InScope {
+			suggestion.Score += mentionScopeWeight
+		}
+		username := strings.ToLower(u.Username)
+		if username == normalized {
+			suggestion.Score += mentionExactMatchWeight
+		} else if strings.HasPrefix(username, normalized) {
+			suggestion.Score += mentionUsernameWeight
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].User.Username < suggestions[j].User.Username
+	})
+	return suggestions
+}
+
+// IndexUser adds or refreshes a user in the mention index
+func (s *MentionService) IndexUser(ctx context.Context, user *models.User) {
+	if err := s.index.Upsert(ctx, user); err != nil {
+		log.Printf("Failed to index user %s for mentions: %v", user.ID.Hex(), err)
+	}
+}
+
+// RemoveUser takes a user out of the mention index
+func (s *MentionService) RemoveUser(ctx context.Context, userID primitive.ObjectID) error {
+	return s.index.Remove(ctx, userID.Hex())
+}
+
+// RecordInteraction notes that userID messaged or mentioned others, which
+// ranks them higher in userID's suggestions. A nil service records nothing.
+func (s *MentionService) RecordInteraction(ctx context.Context, userID primitive.ObjectID, others ...primitive.ObjectID) {
+	if s == nil {
+		return
+	}
+	ids := make([]string, 0, len(others))
+	for _, other := range others {
+		if !other.IsZero() {
+			ids = append(ids, other.Hex())
+		}
+	}
+	if err := s.index.RecordInteraction(ctx, userID.Hex(), ids...); err != nil {
+		log.Printf("Failed to record interactions of %s: %v", userID.Hex(), err)
+	}
+}
+
+// BackfillIndex builds the mention index from the user collection the first
+// time the service starts against an empty index. Later changes arrive as
+// user update events.
+func (s *MentionService) BackfillIndex(ctx context.Context) {
+	claimed, err := s.index.ClaimBackfill(ctx)
+	if err != nil {
+		log.Printf("Failed to claim mention index backfill: %v", err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	var lastID primitive.ObjectID
+	indexed := 0
+	for {
+		filter := bson.M{"deleted_at": bson.M{"$exists": false}}
+		if !lastID.IsZero() {
+			filter["_id"] = bson.M{"$gt": lastID}
+		}
+		opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(mentionBackfillBatch).
+			SetProjection(bson.M{"username": 1, "full_name": 1, "account_state": 1, "suspended_until": 1})
+		users, err := s.userRepo.FindUsers(ctx, filter, opts)
+		if err != nil {
+			log.Printf("Mention index backfill stopped after %d users: %v", indexed, err)
+			if err := s.index.ReleaseBackfill(ctx); err != nil {
+				log.Printf("Failed to release mention index backfill: %v", err)
+			}
+			return
+		}
+		for i := range users {
+			s.IndexUser(ctx, &users[i])
+		}
+		indexed += len(users)
+		if len(users) < mentionBackfillBatch {
+			break
+		}
+		lastID = users[len(users)-1].ID
+	}
+	log.Printf("Indexed %d users for mentions", indexed)
+}
+
+func toSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
@@ -7,6 +7,8 @@ import (
 	"log"
 	"messaging-app/config"
 	cassdb "messaging-app/internal/db"
+	"messaging-app/internal/kafka"
+	"messaging-app/internal/messagesearch"
 	"messaging-app/internal/repositories"
 	"messaging-app/internal/storageclient"
 	"time"
@@ -14,6 +16,7 @@ import (
 	redisclient "github.com/MuhibNayem/connectify-v2/shared-entity/redis"
 
 	"github.com/gocql/gocql"
+	kafkago "github.com/segmentio/kafka-go"
 )
 
 // ArchivedMessage represents immutable message content stored in cold storage
@@ -27,6 +30,14 @@ type ArchivedMessage struct {
 	MediaURLs   []string `json:"media_urls,omitempty"`
 	ProductID   string   `json:"product_id,omitempty"`
 	CreatedAt   string   `json:"created_at"`
+	// ForwardedFrom is the JSON attribution of a forwarded message
+	ForwardedFrom string `json:"forwarded_from,omitempty"`
+	// Offer is the JSON offer card of a marketplace offer message
+	Offer string `json:"offer,omitempty"`
+	// LinkPreview is the JSON preview card of the message's first link
+	LinkPreview string `json:"link_preview,omitempty"`
+	// ReplyToID is the UUID of the message this one replies to
+	ReplyToID string `json:"reply_to_id,omitempty"`
 }
 
 // MessageMetadata represents mutable fields that stay in Cassandra
@@ -34,6 +45,7 @@ type MessageMetadata struct {
 	ConversationID string
 	MessageID      gocql.UUID
 	Reactions      string
+	ReactionCounts map[string]int
 	SeenBy         []string
 	DeliveredTo    []string
 	IsDeleted      bool
@@ -48,6 +60,8 @@ type MessageArchiveService struct {
 	archiveBucket string
 	cacheTTL      time.Duration
 	archiveAfter  int // days
+
+	searchProducer *kafka.MessageProducer
 }
 
 // NewMessageArchiveService creates a new archive service
@@ -67,6 +81,11 @@ func NewMessageArchiveService(
 	}
 }
 
+// SetSearchProducer removes purged messages from message search as well
+func (s *MessageArchiveService) SetSearchProducer(producer *kafka.MessageProducer) {
+	s.searchProducer = producer
+}
+
 // ArchiveOldMessages moves old messages to cold storage
 // Run as a daily background job
 func (s *MessageArchiveService) ArchiveOldMessages(ctx context.Context) error {
@@ -75,7 +94,7 @@ func (s *MessageArchiveService) ArchiveOldMessages(ctx context.Context) error {
 
 	// 1. Query old messages from hot table
 	query := `SELECT conversation_id, message_id, sender_id, receiver_id, group_id, 
-		content, content_type, media_urls, product_id, created_at 
+		content, content_type, media_urls, product_id, created_at, forwarded_from, offer, link_preview, reply_to_id 
 		FROM messages WHERE created_at < ? ALLOW FILTERING`
 
 	iter := s.cassandra.Session.Query(query, cutoffTime).Iter()
@@ -87,12 +106,12 @@ func (s *MessageArchiveService) ArchiveOldMessages(ctx context.Context) error {
 		MessageID      gocql.UUID
 	}
 
-	var convID, senderID, receiverID, groupID, content, contentType, productID string
+	var convID, senderID, receiverID, groupID, content, contentType, productID, forwardedFrom, offer, linkPreview, replyToID string
 	var msgUUID gocql.UUID
 	var mediaURLs []string
 	var createdAt time.Time
 
-	for iter.Scan(&convID, &msgUUID, &senderID, &receiverID, &groupID, &content, &contentType, &mediaURLs, &productID, &createdAt) {
+	for iter.Scan(&convID, &msgUUID, &senderID, &receiverID, &groupID, &content, &contentType, &mediaURLs, &productID, &createdAt, &forwardedFrom, &offer, &linkPreview, &replyToID) {
 		month := createdAt.Format("2006-01")
 
 		if archives[convID] == nil {
@@ -100,15 +119,19 @@ func (s *MessageArchiveService) ArchiveOldMessages(ctx context.Context) error {
 		}
 
 		archives[convID][month] = append(archives[convID][month], ArchivedMessage{
-			MessageID:   msgUUID.String(),
-			SenderID:    senderID,
-			ReceiverID:  receiverID,
-			GroupID:     groupID,
-			Content:     content,
-			ContentType: contentType,
-			MediaURLs:   mediaURLs,
-			ProductID:   productID,
-			CreatedAt:   createdAt.Format(time.RFC3339),
+			MessageID:     msgUUID.String(),
+			SenderID:      senderID,
+			ReceiverID:    receiverID,
+			GroupID:       groupID,
+			Content:       content,
+			ContentType:   contentType,
+			MediaURLs:     mediaURLs,
+			ProductID:     productID,
+			CreatedAt:     createdAt.Format(time.RFC3339),
+			ForwardedFrom: forwardedFrom,
+			Offer:         offer,
+			LinkPreview:   linkPreview,
+			ReplyToID:     replyToID,
 		})
 
 		metadataToInsert = append(metadataToInsert, struct {
@@ -156,8 +179,8 @@ func (s *MessageArchiveService) ArchiveOldMessages(ctx context.Context) error {
 	// 3. Copy metadata to message_metadata table (for reactions, seen_by, etc.)
 	for _, m := range metadataToInsert {
 		// Copy current metadata (reactions, seen_by, etc.) to metadata table
-		copyQuery := `INSERT INTO message_metadata (conversation_id, message_id, reactions, seen_by, delivered_to, is_deleted, is_edited)
-			SELECT conversation_id, message_id, reactions, seen_by, delivered_to, is_deleted, false
+		copyQuery := `INSERT INTO message_metadata (conversation_id, message_id, reactions, reaction_counts, seen_by, delivered_to, is_deleted, is_edited)
+			SELECT conversation_id, message_id, reactions, reaction_counts, seen_by, delivered_to, is_deleted, false
 			FROM messages WHERE conversation_id = ? AND message_id = ?`
 		_ = s.cassandra.Session.Query(copyQuery, m.ConversationID, m.MessageID).Exec()
 	}
@@ -214,12 +237,12 @@ func (s *MessageArchiveService) GetMessageMetadata(ctx context.Context, conversa
 
 	for _, msgID := range messageIDs {
 		var metadata MessageMetadata
-		query := `SELECT reactions, seen_by, delivered_to, is_deleted, is_edited 
+		query := `SELECT reactions, reaction_counts, seen_by, delivered_to, is_deleted, is_edited 
 			FROM message_metadata WHERE conversation_id = ? AND message_id = ?`
 
 		var seenBy, deliveredTo []string
 		err := s.cassandra.Session.Query(query, conversationID, msgID).Scan(
-			&metadata.Reactions, &seenBy, &deliveredTo, &metadata.IsDeleted, &metadata.IsEdited,
+			&metadata.Reactions, &metadata.ReactionCounts, &seenBy, &deliveredTo, &metadata.IsDeleted, &metadata.IsEdited,
 		)
 		if err == nil {
 			metadata.ConversationID = conversationID
@@ -233,6 +256,137 @@ func (s *MessageArchiveService) GetMessageMetadata(ctx context.Context, conversa
 	return result, nil
 }
 
+// PurgeExpiredMessages enforces conversation retention policies. Messages
+// older than a conversation's retention are deleted with their reactions,
+// edit history, threads and pins; archives are deleted once their whole
+// month has expired.
+func (s *MessageArchiveService) PurgeExpiredMessages(ctx context.Context) error {
+	iter := s.cassandra.Session.Query(`SELECT conversation_id, retention_days FROM conversation_retention`).
+		WithContext(ctx).Iter()
+
+	policies := make(map[string]int)
+	var convID string
+	var days int
+	for iter.Scan(&convID, &days) {
+		if days > 0 {
+			policies[convID] = days
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return fmt.Errorf("failed to list retention policies: %w", err)
+	}
+
+	log.Printf("[Retention] Enforcing retention for %d conversations", len(policies))
+	for convID, days := range policies {
+		cutoff := time.Now().AddDate(0, 0, -days)
+		if err := s.purgeConversation(ctx, convID, cutoff); err != nil {
+			log.Printf("[Retention] Failed to purge %s: %v", convID, err)
+		}
+	}
+	return nil
+}
+
+func (s *MessageArchiveService) purgeConversation(ctx context.Context, convID string, cutoff time.Time) error {
+	session := s.cassandra.Session
+	cutoffID := gocql.MinTimeUUID(cutoff)
+
+	// Per-message partitions have to be deleted one by one, so collect the
+	// expired message IDs from both the hot and the archived metadata table
+	expired := make(map[gocql.UUID]struct{})
+	for _, table := range []string{"messages", "message_metadata"} {
+		iter := session.Query(`SELECT message_id FROM `+table+` WHERE conversation_id = ? AND message_id < ?`, convID, cutoffID).
+			WithContext(ctx).Iter()
+		var msgID gocql.UUID
+		for iter.Scan(&msgID) {
+			expired[msgID] = struct{}{}
+		}
+		if err := iter.Close(); err != nil {
+			return fmt.Errorf("failed to list expired messages in %s: %w", table, err)
+		}
+	}
+
+	for msgID := range expired {
+		if err := session.Query(`DELETE FROM message_reactions WHERE conversation_id = ? AND message_id = ?`, convID, msgID).WithContext(ctx).Exec(); err != nil {
+			return err
+		}
+		if err := session.Query(`DELETE FROM message_edits WHERE conversation_id = ? AND message_id = ?`, convID, msgID).WithContext(ctx).Exec(); err != nil {
+			return err
+		}
+		// Replies are newer than their parent, so a thread expires whole with it
+		if err := session.Query(`DELETE FROM thread_replies WHERE conversation_id = ? AND parent_id = ?`, convID, msgID).WithContext(ctx).Exec(); err != nil {
+			return err
+		}
+	}
+	if err := session.Query(`DELETE FROM message_threads WHERE conversation_id = ? AND parent_id < ?`, convID, cutoffID).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("failed to purge message_threads: %w", err)
+	}
+	for _, table := range []string{"messages", "message_metadata", "pinned_messages"} {
+		if err := session.Query(`DELETE FROM `+table+` WHERE conversation_id = ? AND message_id < ?`, convID, cutoffID).WithContext(ctx).Exec(); err != nil {
+			return fmt.Errorf("failed to purge %s: %w", table, err)
+		}
+	}
+
+	// An archive holds a calendar month, so it goes once the month is over
+	// before the cutoff
+	cutoffMonth := cutoff.Format("2006-01")
+	iter := session.Query(`SELECT month, archive_path FROM messages_archive_index WHERE conversation_id = ? AND month < ?`, convID, cutoffMonth).
+		WithContext(ctx).Iter()
+	var month, archivePath string
+	var months []string
+	for iter.Scan(&month, &archivePath) {
+		if err := s.storageClient.DeleteArchive(ctx, archivePath); err != nil {
+			log.Printf("[Retention] Failed to delete archive %s: %v", archivePath, err)
+			continue
+		}
+		months = append(months, month)
+	}
+	if err := iter.Close(); err != nil {
+		return fmt.Errorf("failed to list expired archives: %w", err)
+	}
+	for _, month := range months {
+		if err := session.Query(`DELETE FROM messages_archive_index WHERE conversation_id = ? AND month = ?`, convID, month).WithContext(ctx).Exec(); err != nil {
+			return err
+		}
+	}
+	if len(months) > 0 {
+		if err := s.InvalidateCache(ctx, convID); err != nil {
+			log.Printf("[Retention] Failed to invalidate archive cache of %s: %v", convID, err)
+		}
+	}
+
+	s.publishSearchPurge(ctx, convID, cutoff)
+	if len(expired) > 0 || len(months) > 0 {
+		log.Printf("[Retention] Purged %d messages and %d archives from %s", len(expired), len(months), convID)
+	}
+	return nil
+}
+
+// publishSearchPurge queues the removal of a conversation's expired messages
+// from message search
+func (s *MessageArchiveService) publishSearchPurge(ctx context.Context, convID string, cutoff time.Time) {
+	if s.searchProducer == nil {
+		return
+	}
+	event := messagesearch.Event{
+		Action:         messagesearch.ActionPurge,
+		ConversationID: convID,
+		Before:         cutoff,
+		Timestamp:      time.Now(),
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("[Retention] Failed to marshal search purge for %s: %v", convID, err)
+		return
+	}
+	if err := s.searchProducer.ProduceMessage(ctx, kafkago.Message{
+		Key:   []byte(convID),
+		Value: data,
+		Time:  event.Timestamp,
+	}); err != nil {
+		log.Printf("[Retention] Failed to publish search purge for %s: %v", convID, err)
+	}
+}
+
 // InvalidateCache invalidates Redis cache for a conversation
 // Call this when metadata changes (reaction, seen, delete)
 func (s *MessageArchiveService) InvalidateCache(ctx context.Context, conversationID string) error {
@@ -261,7 +415,10 @@ func (s *MessageArchiveService) StartArchiveWorker(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			go s.ArchiveOldMessages(ctx)
+			go func() {
+				s.PurgeExpiredMessages(ctx)
+				s.ArchiveOldMessages(ctx)
+			}()
 		case <-ctx.Done():
 			return
 		}
@@ -281,15 +438,19 @@ func (s *MessageArchiveService) LoadArchivedMessagesForRepo(ctx context.Context,
 	result := make([]repositories.ArchivedMessageContent, len(msgs))
 	for i, m := range msgs {
 		result[i] = repositories.ArchivedMessageContent{
-			MessageID:   m.MessageID,
-			SenderID:    m.SenderID,
-			ReceiverID:  m.ReceiverID,
-			GroupID:     m.GroupID,
-			Content:     m.Content,
-			ContentType: m.ContentType,
-			MediaURLs:   m.MediaURLs,
-			ProductID:   m.ProductID,
-			CreatedAt:   m.CreatedAt,
+			MessageID:     m.MessageID,
+			SenderID:      m.SenderID,
+			ReceiverID:    m.ReceiverID,
+			GroupID:       m.GroupID,
+			Content:       m.Content,
+			ContentType:   m.ContentType,
+			MediaURLs:     m.MediaURLs,
+			ProductID:     m.ProductID,
+			CreatedAt:     m.CreatedAt,
+			ForwardedFrom: m.ForwardedFrom,
+			Offer:         m.Offer,
+			LinkPreview:   m.LinkPreview,
+			ReplyToID:     m.ReplyToID,
 		}
 	}
 	return result, nil
@@ -314,11 +475,12 @@ func (s *MessageArchiveService) GetMessageMetadataForRepo(ctx context.Context, c
 	result := make(map[string]repositories.ArchivedMessageMetadata)
 	for k, v := range metaMap {
 		result[k] = repositories.ArchivedMessageMetadata{
-			Reactions:   v.Reactions,
-			SeenBy:      v.SeenBy,
-			DeliveredTo: v.DeliveredTo,
-			IsDeleted:   v.IsDeleted,
-			IsEdited:    v.IsEdited,
+			Reactions:      v.Reactions,
+			ReactionCounts: v.ReactionCounts,
+			SeenBy:         v.SeenBy,
+			DeliveredTo:    v.DeliveredTo,
+			IsDeleted:      v.IsDeleted,
+			IsEdited:       v.IsEdited,
 		}
 	}
 	return result, nil
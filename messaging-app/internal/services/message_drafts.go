@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/redis/go-redis/v9"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// draftTTL matches the default TTL of the message_drafts table
+const draftTTL = 30 * 24 * time.Hour
+
+var ErrDraftNotFound = apperrors.NotFound("draft not found")
+
+func draftKey(userID, convKey string) string {
+	return fmt.Sprintf("draft:%s:%s", userID, convKey)
+}
+
+// SaveDraft stores the user's draft of a conversation and sends it to their
+// other devices. Saving empty content clears the draft.
+func (s *MessageService) SaveDraft(ctx context.Context, userID primitive.ObjectID, conversationID string, isGroup bool, req models.SaveDraftRequest) (*models.MessageDraft, error) {
+	convKey, err := s.ConversationKey(ctx, userID, conversationID, isGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := models.MessageDraft{
+		ConversationID: convKey,
+		Content:        req.Content,
+		ReplyToID:      req.ReplyToID,
+		DeviceID:       req.DeviceID,
+		UpdatedAt:      time.Now(),
+	}
+	if strings.TrimSpace(draft.Content) == "" {
+		if err := s.removeDraft(ctx, userID, draft); err != nil {
+			return nil, err
+		}
+		return &draft, nil
+	}
+
+	// Cassandra keeps the draft once Redis evicts it
+	if err := s.messageCassandraRepo.SaveDraft(ctx, userID.Hex(), draft); err != nil {
+		return nil, err
+	}
+	if data, err := json.Marshal(draft); err == nil {
+		if err := s.redisClient.Set(ctx, draftKey(userID.Hex(), convKey), data, draftTTL).Err(); err != nil {
+			log.Printf("Failed to cache draft of %s for user %s: %v", convKey, userID.Hex(), err)
+		}
+	}
+
+	s.publishDraftEvent(ctx, userID, draft)
+	return &draft, nil
+}
+
+// GetDraft returns the user's draft of a conversation
+func (s *MessageService) GetDraft(ctx context.Context, userID primitive.ObjectID, conversationID string, isGroup bool) (*models.MessageDraft, error) {
+	convKey, err := s.ConversationKey(ctx, userID, conversationID, isGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	key := draftKey(userID.Hex(), convKey)
+	cached, err := s.redisClient.Get(ctx, key).Bytes()
+	if err == nil {
+		var draft models.MessageDraft
+		if json.Unmarshal(cached, &draft) == nil {
+			return &draft, nil
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		log.Printf("Failed to read cached draft of %s for user %s: %v", convKey, userID.Hex(), err)
+	}
+
+	draft, err := s.messageCassandraRepo.GetDraft(ctx, userID.Hex(), convKey)
+	if err != nil {
+		return nil, err
+	}
+	if draft == nil {
+		return nil, ErrDraftNotFound
+	}
+	if data, err := json.Marshal(draft); err == nil {
+		ttl := draftTTL - time.Since(draft.UpdatedAt)
+		if ttl > 0 {
+			s.redisClient.Set(ctx, key, data, ttl)
+		}
+	}
+	return draft, nil
+}
+
+// ListDrafts returns all of the user's drafts
+func (s *MessageService) ListDrafts(ctx context.Context, userID primitive.ObjectID) ([]models.MessageDraft, error) {
+	return s.messageCassandraRepo.ListDrafts(ctx, userID.Hex())
+}
+
+// DeleteDraft clears the user's draft of a conversation on all their devices
+func (s *MessageService) DeleteDraft(ctx context.Context, userID primitive.ObjectID, conversationID string, isGroup bool, deviceID string) error {
+	convKey, err := s.ConversationKey(ctx, userID, conversationID, isGroup)
+	if err != nil {
+		return err
+	}
+	return s.removeDraft(ctx, userID, models.MessageDraft{
+		ConversationID: convKey,
+		DeviceID:       deviceID,
+		UpdatedAt:      time.Now(),
+	})
+}
+
+func (s *MessageService) removeDraft(ctx context.Context, userID primitive.ObjectID, draft models.MessageDraft) error {
+	if err := s.messageCassandraRepo.DeleteDraft(ctx, userID.Hex(), draft.ConversationID); err != nil {
+		return err
+	}
+	if err := s.redisClient.Del(ctx, draftKey(userID.Hex(), draft.ConversationID)).Err(); err != nil {
+		log.Printf("Failed to drop cached draft of %s for user %s: %v", draft.ConversationID, userID.Hex(), err)
+	}
+
+	draft.Content = ""
+	draft.ReplyToID = ""
+	s.publishDraftEvent(ctx, userID, draft)
+	return nil
+}
+
+// DeleteUserDrafts removes all of the user's drafts
+func (s *MessageService) DeleteUserDrafts(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	drafts, err := s.messageCassandraRepo.ListDrafts(ctx, userID.Hex())
+	if err != nil {
+		return 0, err
+	}
+	for _, draft := range drafts {
+		s.redisClient.Del(ctx, draftKey(userID.Hex(), draft.ConversationID))
+	}
+	if err := s.messageCassandraRepo.DeleteUserDrafts(ctx, userID.Hex()); err != nil {
+		return 0, err
+	}
+	return int64(len(drafts)), nil
+}
+
+// publishDraftEvent sends a draft change to the user's own devices
+func (s *MessageService) publishDraftEvent(ctx context.Context, userID primitive.ObjectID, draft models.MessageDraft) {
+	data, err := json.Marshal(draft)
+	if err != nil {
+		log.Printf("Failed to marshal draft event for %s: %v", draft.ConversationID, err)
+		return
+	}
+	eventBytes, err := json.Marshal(models.WebSocketEvent{
+		Type:       "DRAFT_UPDATED",
+		Data:       data,
+		Recipients: []string{userID.Hex()},
+	})
+	if err != nil {
+		log.Printf("Failed to marshal draft event for %s: %v", draft.ConversationID, err)
+		return
+	}
+	if err := s.producer.ProduceMessage(ctx, kafkago.Message{
+		Key:   []byte(draft.ConversationID),
+		Value: eventBytes,
+		Time:  time.Now(),
+	}); err != nil {
+		log.Printf("Failed to publish draft event for %s: %v", draft.ConversationID, err)
+	}
+}
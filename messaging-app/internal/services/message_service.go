@@ -6,21 +6,72 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"messaging-app/internal/antispam"
+	"messaging-app/internal/cache"
 	"messaging-app/internal/kafka"
+	"messaging-app/internal/messagesearch"
+	sharedcache "github.com/MuhibNayem/connectify-v2/shared-entity/cache"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/linkpreview"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
 	notifications "messaging-app/internal/notifications"
 	"messaging-app/internal/repositories"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gocql/gocql"
 	"github.com/redis/go-redis/v9"
 	kafkago "github.com/segmentio/kafka-go"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+var (
+	// ErrReplayUnavailable is returned when the hub fan-out has no replay log
+	ErrReplayUnavailable          = apperrors.Unavailable("message replay requires the kafka hub fan-out")
+	ErrNotConversationParticipant = apperrors.Forbidden("not a participant of this conversation")
+	ErrInvalidReplayCursor        = apperrors.Validation("invalid replay cursor")
+	ErrInvalidConversationID      = apperrors.Validation("invalid conversation ID")
+	ErrInvalidSearchRange         = apperrors.Validation("search start date is after end date")
+	ErrMessageNotFound            = apperrors.NotFound("message not found")
+	ErrNoForwardTargets           = apperrors.Validation("at least one receiver or group is required")
+	ErrTooManyForwardTargets      = apperrors.Validation(fmt.Sprintf("a message can be forwarded to at most %d conversations", MaxForwardTargets))
+	ErrMessageNotOwned            = apperrors.Forbidden("you can only delete your own messages")
+	ErrMessageAlreadyPinned       = apperrors.Conflict("message is already pinned")
+	ErrPinLimitReached            = apperrors.Conflict("pinned message limit reached")
+	ErrMessageEditNotOwned        = apperrors.Forbidden("you can only edit your own messages")
+	ErrEditWindowExpired          = apperrors.Forbidden("this message can no longer be edited")
+	ErrMessageEditConflict        = apperrors.Conflict("message changed while it was being edited")
+	ErrInvalidReaction            = apperrors.Validation(fmt.Sprintf("a reaction must be an emoji of at most %d characters", maxReactionRunes))
+	ErrRetentionNotAllowed        = apperrors.Forbidden("only group admins can change message retention")
+	ErrReplyParentNotFound        = apperrors.Validation("the message being replied to is not in this conversation")
+	ErrThreadsGroupOnly           = apperrors.Validation("threads are only available in group conversations")
+	ErrInvalidThreadCursor        = repositories.ErrInvalidThreadCursor
+)
+
+// MaxForwardTargets caps the conversations a message can be forwarded to at once
+const MaxForwardTargets = 10
+
+// DefaultMessageEditWindow is how long after sending a message its sender
+// may edit it, unless SetEditWindow says otherwise
+const DefaultMessageEditWindow = time.Hour
+
+// maxReactionRunes bounds a reaction emoji; joined emoji sequences such as
+// families or flags run to several code points
+const maxReactionRunes = 16
+
+// HubFanout delivers messages to every hub instance and replays them to
+// reconnecting clients.
+type HubFanout interface {
+	PublishMessage(ctx context.Context, msg models.Message) error
+	Replay(ctx context.Context, conversationKey, cursor string, limit int) (*models.ConversationReplay, error)
+}
+
 type MessageService struct {
 	messageRepo          *repositories.MessageRepository
 	groupRepo            *repositories.GroupRepository
@@ -31,6 +82,16 @@ type MessageService struct {
 	notificationService  *notifications.NotificationService
 	messageCassandraRepo *repositories.MessageCassandraRepository
 	groupActivityRepo    *repositories.GroupActivityRepository
+	groupCache           *cache.GroupCache
+	fanout               HubFanout
+	searchProducer       *kafka.MessageProducer
+	blocks               BlockLookup
+	moderator            *moderation.Moderator
+	editWindow           time.Duration
+	linkPreviews         *linkpreview.Worker
+	mentions             *MentionService
+	receiptPrivacy       ReceiptPrivacy
+	spam                 *antispam.Guard
 }
 
 func NewMessageService(
@@ -54,7 +115,145 @@ func NewMessageService(
 		notificationService:  notificationService,
 		messageCassandraRepo: messageCassandraRepo,
 		groupActivityRepo:    groupActivityRepo,
+		groupCache:           cache.NewGroupCache(redisClient),
+		editWindow:           DefaultMessageEditWindow,
+	}
+}
+
+// SetHubFanout switches hub delivery from Redis pub/sub to the given fan-out
+func (s *MessageService) SetHubFanout(fanout HubFanout) {
+	s.fanout = fanout
+}
+
+// SetEditWindow sets how long after sending a message its sender may edit
+// it; zero lifts the limit
+func (s *MessageService) SetEditWindow(window time.Duration) {
+	s.editWindow = window
+}
+
+// SetBlockLookup refuses direct messages between users who have blocked each other
+func (s *MessageService) SetBlockLookup(blocks BlockLookup) {
+	s.blocks = blocks
+}
+
+// SetMentionService ranks the people a user messages or mentions higher in
+// their @mention suggestions
+func (s *MessageService) SetMentionService(mentions *MentionService) {
+	s.mentions = mentions
+}
+
+// SetSearchProducer enables publishing message changes for search indexing
+func (s *MessageService) SetSearchProducer(producer *kafka.MessageProducer) {
+	s.searchProducer = producer
+}
+
+// publishSearchEvent queues a message change for the search indexer
+func (s *MessageService) publishSearchEvent(ctx context.Context, event messagesearch.Event) {
+	if s.searchProducer == nil {
+		return
+	}
+	event.Timestamp = time.Now()
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to marshal search event for message %s: %v", event.MessageID, err)
+		return
+	}
+	if err := s.searchProducer.ProduceMessage(ctx, kafkago.Message{
+		Key:   []byte(event.ConversationID),
+		Value: data,
+		Time:  event.Timestamp,
+	}); err != nil {
+		log.Printf("Failed to publish search event for message %s: %v", event.MessageID, err)
+	}
+}
+
+// indexMessage publishes a newly stored message for search indexing
+func (s *MessageService) indexMessage(ctx context.Context, msg *models.Message) {
+	if !messagesearch.Indexable(msg) {
+		return
+	}
+	convKey := kafka.ConversationKey(*msg)
+	s.publishSearchEvent(ctx, messagesearch.Event{
+		Action:         messagesearch.ActionIndex,
+		ConversationID: convKey,
+		MessageID:      msg.StringID,
+		Document:       messagesearch.NewDocument(convKey, msg),
+	})
+}
+
+// publishToHub hands a message to the WebSocket hubs for delivery
+func (s *MessageService) publishToHub(ctx context.Context, msg models.Message) {
+	if s.fanout != nil {
+		if err := s.fanout.PublishMessage(ctx, msg); err != nil {
+			log.Printf("Failed to publish message %s to hub fan-out: %v", msg.ID.Hex(), err)
+		}
+		return
+	}
+
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Failed to marshal message %s for hub delivery: %v", msg.ID.Hex(), err)
+		return
+	}
+	s.redisClient.Publish(ctx, "messages", observability.WrapRedisPayload(ctx, msgBytes))
+}
+
+// ReplayConversation returns hub events the user missed in a conversation,
+// resuming after cursor.
+func (s *MessageService) ReplayConversation(ctx context.Context, userID primitive.ObjectID, conversationID string, isGroup bool, cursor string, limit int) (*models.ConversationReplay, error) {
+	if s.fanout == nil {
+		return nil, ErrReplayUnavailable
+	}
+	if cursor != "" {
+		if _, _, err := kafka.ParseReplayCursor(cursor); err != nil {
+			return nil, ErrInvalidReplayCursor
+		}
+	}
+
+	convKey, err := s.normalizeConversationKey(userID, conversationID, &isGroup)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConversationID, err)
+	}
+	if !s.isParticipant(ctx, userID, convKey) {
+		return nil, ErrNotConversationParticipant
+	}
+
+	return s.fanout.Replay(ctx, convKey, cursor, limit)
+}
+
+func (s *MessageService) isParticipant(ctx context.Context, userID primitive.ObjectID, convKey string) bool {
+	if strings.HasPrefix(convKey, "dm_") {
+		return strings.Contains(convKey, userID.Hex())
+	}
+	if groupHex, ok := strings.CutPrefix(convKey, "group_"); ok {
+		gID, err := primitive.ObjectIDFromHex(groupHex)
+		if err != nil {
+			return false
+		}
+		members, err := s.groupMembers(ctx, gID)
+		if err != nil {
+			return false
+		}
+		for _, m := range members {
+			if m == userID.Hex() {
+				return true
+			}
+		}
 	}
+	return false
+}
+
+// ConversationKey resolves a conversation the user takes part in to its
+// Cassandra key
+func (s *MessageService) ConversationKey(ctx context.Context, userID primitive.ObjectID, conversationID string, isGroup bool) (string, error) {
+	convKey, err := s.normalizeConversationKey(userID, conversationID, &isGroup)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidConversationID, err)
+	}
+	if !s.isParticipant(ctx, userID, convKey) {
+		return "", ErrNotConversationParticipant
+	}
+	return convKey, nil
 }
 
 func (s *MessageService) normalizeConversationKey(userID primitive.ObjectID, raw string, isGroupHint *bool) (string, error) {
@@ -121,18 +320,173 @@ func (s *MessageService) SendMessage(ctx context.Context, senderID primitive.Obj
 		}
 	}
 
+	// Replies name the Cassandra UUID of their parent; ObjectIDs are kept for
+	// older clients
 	if req.ReplyToMessageID != "" {
-		replyToID, err := primitive.ObjectIDFromHex(req.ReplyToMessageID)
-		if err != nil {
-			return nil, errors.New("invalid reply to message ID")
+		if _, err := gocql.ParseUUID(req.ReplyToMessageID); err == nil {
+			msg.ReplyToID = req.ReplyToMessageID
+		} else {
+			replyToID, err := primitive.ObjectIDFromHex(req.ReplyToMessageID)
+			if err != nil {
+				return nil, errors.New("invalid reply to message ID")
+			}
+			msg.ReplyToMessageID = &replyToID
+		}
+	}
+
+	// End-to-end encrypted content can't be read, so it can't be screened
+	var decision moderation.Decision
+	if !msg.IsEncrypted {
+		decision = s.moderator.Screen(ctx, msg.Content, nil)
+		if decision.Rejected() {
+			return nil, moderation.ErrContentRejected
 		}
-		msg.ReplyToMessageID = &replyToID
 	}
 
+	var sent *models.Message
+	var err error
 	if req.GroupID != "" {
-		return s.handleGroupMessage(ctx, msg, req.GroupID)
+		sent, err = s.handleGroupMessage(ctx, msg, req.GroupID)
+	} else {
+		sent, err = s.handleDirectMessage(ctx, msg, req.ReceiverID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Messages are delivered on send, so a held message is queued like a flagged one
+	s.reportMessage(ctx, decision, sent)
+	s.mentions.RecordInteraction(ctx, senderID, append([]primitive.ObjectID{sent.ReceiverID}, sent.Mentions...)...)
+	if !sent.IsEncrypted {
+		s.queueMessagePreview(kafka.ConversationKey(*sent), sent.StringID, sent.Content)
+	}
+	return sent, nil
+}
+
+// ForwardMessage copies a message the user can see into each target
+// conversation. Every copy goes through the regular send path, so friendship,
+// blocks and group membership are enforced per target; refused targets are
+// reported in the response instead of failing the whole request.
+func (s *MessageService) ForwardMessage(ctx context.Context, userID primitive.ObjectID, messageID string, req models.ForwardMessageRequest) (*models.ForwardMessageResponse, error) {
+	receiverIDs := dedupeIDs(req.ReceiverIDs)
+	groupIDs := dedupeIDs(req.GroupIDs)
+	if len(receiverIDs)+len(groupIDs) == 0 {
+		return nil, ErrNoForwardTargets
+	}
+	if len(receiverIDs)+len(groupIDs) > MaxForwardTargets {
+		return nil, ErrTooManyForwardTargets
+	}
+
+	convKey, err := s.normalizeConversationKey(userID, req.ConversationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConversationID, err)
+	}
+	if !s.isParticipant(ctx, userID, convKey) {
+		return nil, ErrNotConversationParticipant
+	}
+
+	source, err := s.messageCassandraRepo.GetMessage(ctx, convKey, messageID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrMessageNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
+	}
+
+	// Forwarding a forward keeps crediting the original author
+	origin := source.ForwardedFrom
+	if origin == nil {
+		origin = &models.ForwardedFrom{
+			MessageID:  source.StringID,
+			SenderID:   source.SenderID,
+			SenderName: s.username(ctx, source.SenderID),
+			SentAt:     source.CreatedAt,
+		}
+	}
+
+	newCopy := func() *models.Message {
+		return &models.Message{
+			StringID:      gocql.TimeUUID().String(),
+			SenderID:      userID,
+			Content:       source.Content,
+			ContentType:   source.ContentType,
+			MediaURLs:     append([]string(nil), source.MediaURLs...),
+			IsForwarded:   true,
+			ForwardedFrom: origin,
+		}
+	}
+
+	resp := &models.ForwardMessageResponse{Messages: []models.Message{}}
+	for _, receiverID := range receiverIDs {
+		forwarded, err := s.handleDirectMessage(ctx, newCopy(), receiverID)
+		if err != nil {
+			resp.Failed = append(resp.Failed, models.ForwardResult{ReceiverID: receiverID, Error: err.Error()})
+			continue
+		}
+		resp.Messages = append(resp.Messages, *forwarded)
+	}
+	for _, groupID := range groupIDs {
+		forwarded, err := s.handleGroupMessage(ctx, newCopy(), groupID)
+		if err != nil {
+			resp.Failed = append(resp.Failed, models.ForwardResult{GroupID: groupID, Error: err.Error()})
+			continue
+		}
+		resp.Messages = append(resp.Messages, *forwarded)
+	}
+	return resp, nil
+}
+
+// username resolves a display name through the same cache the send path fills
+func (s *MessageService) username(ctx context.Context, userID primitive.ObjectID) string {
+	cacheKey := "user:" + userID.Hex() + ":username"
+	if name, err := s.redisClient.Get(ctx, cacheKey).Result(); err == nil {
+		return name
+	}
+	user, err := s.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to find user %s: %v", userID.Hex(), err)
+		return ""
+	}
+	s.redisClient.Set(ctx, cacheKey, user.Username, 24*time.Hour)
+	return user.Username
+}
+
+func dedupeIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
+
+// groupMembers resolves membership from the shared group cache
+func (s *MessageService) groupMembers(ctx context.Context, gID primitive.ObjectID) ([]string, error) {
+	memberList, err := s.groupCache.Members(ctx, gID.Hex(), func(ctx context.Context) ([]string, error) {
+		group, err := s.groupRepo.GetGroup(ctx, gID)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, sharedcache.ErrNotFound
+			}
+			return nil, err
+		}
+		members := make([]string, len(group.Members))
+		for i, m := range group.Members {
+			members[i] = m.Hex() // Store as hex for consistency
+		}
+		return members, nil
+	})
+	if err != nil {
+		if errors.Is(err, sharedcache.ErrNotFound) {
+			return nil, errors.New("group not found")
+		}
+		return nil, err
 	}
-	return s.handleDirectMessage(ctx, msg, req.ReceiverID)
+	return memberList, nil
 }
 
 func (s *MessageService) handleGroupMessage(ctx context.Context, msg *models.Message, groupID string) (*models.Message, error) {
@@ -141,9 +495,10 @@ func (s *MessageService) handleGroupMessage(ctx context.Context, msg *models.Mes
 		return nil, errors.New("invalid group ID")
 	}
 
-	// Check group membership using Redis cache first
-	cacheKey := "group:" + groupID + ":members"
-	members, err := s.redisClient.SMembers(ctx, cacheKey).Result()
+	memberList, err := s.groupMembers(ctx, gID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Helper to check membership
 	checkMembership := func(members []string, target string) bool {
@@ -155,43 +510,30 @@ func (s *MessageService) handleGroupMessage(ctx context.Context, msg *models.Mes
 		return false
 	}
 
-	var memberList []string
-	if err == nil && len(members) > 0 {
-		memberList = members
-		if !checkMembership(members, msg.SenderID.Hex()) {
-			return nil, errors.New("not a group member")
-		}
-	} else {
-		// Fallback to database
-		group, err := s.groupRepo.GetGroup(ctx, gID)
-		if err != nil {
-			return nil, err
-		}
-
-		isMember := false
-		for _, m := range group.Members {
-			memberList = append(memberList, m.Hex()) // Store as hex for consistency
-			if m == msg.SenderID {
-				isMember = true
-			}
-		}
-
-		if !isMember {
-			return nil, errors.New("not a group member")
-		}
+	if !checkMembership(memberList, msg.SenderID.Hex()) {
+		return nil, errors.New("not a group member")
 	}
 
 	msg.GroupID = gID
+	convKey := "group_" + gID.Hex()
+	// Group messages are throttled, but there are no message requests in groups
+	if _, err := s.screenSpam(ctx, msg, convKey); err != nil {
+		return nil, err
+	}
+	if err := s.resolveReplyParent(ctx, convKey, msg); err != nil {
+		return nil, err
+	}
 
 	// Get group name from cache or DB
-	groupName, err := s.redisClient.Get(ctx, "group:"+groupID+":name").Result()
-	if err != nil {
+	groupName, err := s.groupCache.Name(ctx, groupID, func(ctx context.Context) (string, error) {
 		group, err := s.groupRepo.GetGroup(ctx, gID)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
-		groupName = group.Name
-		s.redisClient.Set(ctx, "group:"+groupID+":name", groupName, 24*time.Hour)
+		return group.Name, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	msg.GroupName = groupName
 
@@ -210,7 +552,11 @@ func (s *MessageService) handleGroupMessage(ctx context.Context, msg *models.Mes
 	msg.SenderName = senderName
 
 	// --- Mention Logic ---
-	mentionedUsernames := utils.ExtractMentions(msg.Content)
+	// Mentions in forwarded content were addressed to another conversation
+	var mentionedUsernames []string
+	if !msg.IsForwarded {
+		mentionedUsernames = utils.ExtractMentions(msg.Content)
+	}
 	var mentionedUserIDs []primitive.ObjectID
 	if len(mentionedUsernames) > 0 {
 		mentionedUsers, err := s.userRepo.FindUsersByUserNames(ctx, mentionedUsernames)
@@ -262,12 +608,7 @@ func (s *MessageService) handleGroupMessage(ctx context.Context, msg *models.Mes
 		log.Printf("Failed to fetch sender details for message broadcast: %v", err)
 	}
 
-	msgBytesOptimistic, err := json.Marshal(msg)
-	if err == nil {
-		s.redisClient.Publish(ctx, "messages", msgBytesOptimistic)
-	} else {
-		log.Printf("Failed to marshal optimistic group message: %v", err)
-	}
+	s.publishToHub(ctx, *msg)
 
 	// Prepare recipients for fan-out (Cassandra)
 	var recipientIDs []primitive.ObjectID
@@ -316,11 +657,14 @@ func (s *MessageService) handleGroupMessage(ctx context.Context, msg *models.Mes
 			GroupID:     msg.GroupID,
 			ContentType: models.ContentTypeDeleted,
 		}
-		deletionBytes, _ := json.Marshal(deletionEvent)
-		s.redisClient.Publish(ctx, "messages", deletionBytes)
+		s.publishToHub(ctx, deletionEvent)
 		return nil, err
 	}
 	createdMsg := msg // In Cassandra Create, we don't get a new obj back, we trust the one we passed.
+	s.indexMessage(ctx, createdMsg)
+	if createdMsg.ReplyToID != "" {
+		s.addThreadReply(ctx, convKey, createdMsg)
+	}
 
 	// Publish to Kafka block removed to prevent duplicate messages (WebSocket already receives via Redis)
 
@@ -337,6 +681,7 @@ func (s *MessageService) handleGroupMessage(ctx context.Context, msg *models.Mes
 			TargetID:    createdMsg.ID,
 			TargetType:  "message", // Assuming 'message' type exists or UI can handle it. If not, maybe use 'group_message' or 'conversation'
 			Content:     fmt.Sprintf("%s mentioned you in %s", senderName, groupName),
+			Data:        map[string]interface{}{models.NotificationDataConversationID: "group_" + gID.Hex()},
 		}
 		_, err := s.notificationService.CreateNotification(ctx, notificationReq)
 		if err != nil {
@@ -353,6 +698,17 @@ func (s *MessageService) handleDirectMessage(ctx context.Context, msg *models.Me
 		return nil, errors.New("invalid receiver ID")
 	}
 
+	// Blocks apply to every direct message, marketplace ones included
+	if s.blocks != nil {
+		blocked, err := s.blocks.IsBlocked(ctx, msg.SenderID, rID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check block status: %w", err)
+		}
+		if blocked {
+			return nil, models.ErrUserBlocked
+		}
+	}
+
 	// Check friendship status with cache
 	// SKIP check if this is a Marketplace Message (either via IsMarketplace flag or ProductID)
 	if !msg.IsMarketplace && msg.ProductID == nil {
@@ -373,6 +729,17 @@ func (s *MessageService) handleDirectMessage(ctx context.Context, msg *models.Me
 	}
 
 	msg.ReceiverID = rID
+	convKey := utils.GetConversationID(msg.SenderID, rID)
+	flagged, err := s.screenSpam(ctx, msg, convKey)
+	if err != nil {
+		return nil, err
+	}
+	if flagged {
+		msg.Request = s.requestNeeded(ctx, rID, convKey)
+	}
+	if err := s.resolveReplyParent(ctx, convKey, msg); err != nil {
+		return nil, err
+	}
 
 	// Get sender info from cache or DB
 	senderName, err := s.redisClient.Get(ctx, "user:"+msg.SenderID.Hex()+":username").Result()
@@ -421,11 +788,10 @@ func (s *MessageService) handleDirectMessage(ctx context.Context, msg *models.Me
 		msg.DeliveredTo = []primitive.ObjectID{}
 	}
 
-	msgBytesOptimistic, err := json.Marshal(msg)
-	if err == nil {
-		s.redisClient.Publish(ctx, "messages", msgBytesOptimistic)
-	} else {
-		log.Printf("Failed to marshal optimistic direct message: %v", err)
+	// Request messages wait in the receiver's message requests and are
+	// delivered once the request is accepted
+	if !msg.Request {
+		s.publishToHub(ctx, *msg)
 	}
 
 	// Save to database (Cassandra Primary)
@@ -467,11 +833,16 @@ func (s *MessageService) handleDirectMessage(ctx context.Context, msg *models.Me
 			ReceiverID:  msg.ReceiverID,
 			ContentType: models.ContentTypeDeleted,
 		}
-		deletionBytes, _ := json.Marshal(deletionEvent)
-		s.redisClient.Publish(ctx, "messages", deletionBytes)
+		if !msg.Request {
+			s.publishToHub(ctx, deletionEvent)
+		}
 		return nil, err
 	}
 	createdMsg := msg
+	if msg.Request {
+		s.fileRequest(ctx, msg, convKey)
+	}
+	s.indexMessage(ctx, createdMsg)
 
 	// Publish to Kafka block removed to prevent duplicate messages (WebSocket already receives via Redis)
 
@@ -499,6 +870,17 @@ func (s *MessageService) MarkMessagesAsSeen(ctx context.Context, userID primitiv
 	return s.messageCassandraRepo.MarkMessagesAsSeen(ctx, convKey, messageIDs, userID.Hex())
 }
 
+// ClearMentions resets the user's @mention badge for a conversation. The
+// unread count is reset separately by MarkConversationAsSeen, so clients can
+// clear one badge without the other.
+func (s *MessageService) ClearMentions(ctx context.Context, userID primitive.ObjectID, conversationID string, isGroup bool) error {
+	convKey, err := s.ConversationKey(ctx, userID, conversationID, isGroup)
+	if err != nil {
+		return err
+	}
+	return s.messageCassandraRepo.ClearMentions(ctx, userID, convKey)
+}
+
 func (s *MessageService) MarkConversationAsSeen(ctx context.Context, userID primitive.ObjectID, conversationID string, conversationKey string, timestamp time.Time, isGroup bool) error {
 	keySource := conversationKey
 	if keySource == "" {
@@ -678,55 +1060,12 @@ func (s *MessageService) GetAllMessages(ctx context.Context, query models.Messag
 	}
 	messages = validMessages
 
-	// Enrich messages with Sender details (Batch Fetch for Scalability)
-	senderIDsMap := make(map[string]bool)
-	var senderIDs []primitive.ObjectID
-
-	// Collect unique Sender IDs
-	for _, msg := range messages {
-		if !msg.SenderID.IsZero() {
-			sid := msg.SenderID.Hex()
-			if !senderIDsMap[sid] {
-				senderIDsMap[sid] = true
-				senderIDs = append(senderIDs, msg.SenderID)
-			}
-		}
-	}
-
-	// Fetch all senders in one query
-	var users []models.User
-	if len(senderIDs) > 0 {
-		var err error
-		users, err = s.userRepo.FindUsersByIDs(ctx, senderIDs)
-		if err != nil {
-			log.Printf("Failed to batch fetch users: %v", err)
-			// Don't fail the request, just log and allow unknown senders
-		}
-	}
-
-	// Map users for fast lookup
-	userMap := make(map[string]models.User)
-	for _, u := range users {
-		userMap[u.ID.Hex()] = u
+	s.attachSenders(ctx, messages)
+	if viewerID, err := primitive.ObjectIDFromHex(query.SenderID); err == nil {
+		s.hideReadReceipts(ctx, viewerID, messages)
 	}
-
-	// Assign sender details
-	for i := range messages {
-		msg := &messages[i]
-		if !msg.SenderID.IsZero() {
-			if user, found := userMap[msg.SenderID.Hex()]; found {
-				msg.Sender = &models.SafeUserResponse{
-					ID:       user.ID,
-					Username: user.Username,
-					FullName: user.FullName,
-					Avatar:   user.Avatar,
-				}
-				msg.SenderName = user.Username
-			} else {
-				msg.SenderName = "Unknown"
-				// Try Redis fallback for name if strictly needed, or just leave as Unknown to save latency
-			}
-		}
+	if convKey := groupConversationKey(query); convKey != "" {
+		s.attachThreadSummaries(ctx, convKey, messages)
 	}
 
 	// --- FB-Style Group Activity Merge (OPTIMIZED IMPLEMENTATION) ---
@@ -788,15 +1127,39 @@ func (s *MessageService) GetAllMessages(ctx context.Context, query models.Messag
 	return messages, nil
 }
 
-func (s *MessageService) SearchMessages(ctx context.Context, userID primitive.ObjectID, query string, page, limit int64) ([]models.Message, error) {
-	// Cassandra Search (Stub/Limited)
-	// Note: We don't use groupIDs for now in the stub.
-	// Ideally, we'd pass them if we implemented robust search.
-	return s.messageCassandraRepo.SearchMessages(ctx, userID, query, page, limit)
+// SearchMessages runs a full-text search over the conversations the user
+// belongs to. A conversation filter the user is not part of is rejected.
+func (s *MessageService) SearchMessages(ctx context.Context, userID primitive.ObjectID, query models.MessageSearchQuery) ([]models.MessageSearchResult, error) {
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		return nil, ErrInvalidSearchRange
+	}
+
+	if query.ConversationID != "" {
+		convKey, err := s.normalizeConversationKey(userID, query.ConversationID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidConversationID, err)
+		}
+		if !s.isParticipant(ctx, userID, convKey) {
+			return nil, ErrNotConversationParticipant
+		}
+		query.ConversationID = convKey
+	}
+
+	groups, err := s.groupRepo.GetUserGroups(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user groups: %w", err)
+	}
+	groupIDs := make([]primitive.ObjectID, len(groups))
+	for i, g := range groups {
+		groupIDs[i] = g.ID
+	}
+
+	return s.messageCassandraRepo.SearchMessages(ctx, userID, groupIDs, query)
 }
 
 // DeleteMessage handles message deletion with these features:
-// 1. Validates message ownership
+// 1. Validates message ownership; in groups, roles allowed by the
+//    delete_messages permission may delete other members' messages
 // 2. Performs soft-delete in database
 // 3. Cleans up media files asynchronously
 // 4. Publishes deletion event to Kafka
@@ -812,13 +1175,35 @@ func (s *MessageService) DeleteMessage(
 		return nil, err
 	}
 
-	// Parse Message ID
-	// uuid, err := gocql.ParseUUID(messageIDStr) -- validation happens in repo
-
-	// Delete from Cassandra
-	err = s.messageCassandraRepo.DeleteMessage(ctx, convKey, messageIDStr)
+	existing, err := s.messageCassandraRepo.GetMessage(ctx, convKey, messageIDStr)
 	if err != nil {
-		return nil, fmt.Errorf("cassandra delete failed: %w", err)
+		if errors.Is(err, repositories.ErrMessageNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
+	}
+
+	var group *models.Group
+	if groupHex, ok := strings.CutPrefix(convKey, "group_"); ok {
+		gID, err := primitive.ObjectIDFromHex(groupHex)
+		if err != nil {
+			return nil, ErrInvalidConversationID
+		}
+		if group, err = s.groupRepo.GetGroup(ctx, gID); err != nil {
+			return nil, fmt.Errorf("group not found")
+		}
+	}
+	if existing.SenderID != requesterID {
+		if group == nil {
+			return nil, ErrMessageNotOwned
+		}
+		if !group.Can(requesterID, models.GroupActionDeleteMessages) {
+			return nil, ErrGroupPermissionDenied
+		}
+	}
+
+	if err := s.removeMessage(ctx, convKey, messageIDStr, requesterID); err != nil {
+		return nil, err
 	}
 
 	// Publish deletion event to Kafka (for real-time updates)
@@ -837,219 +1222,492 @@ func (s *MessageService) DeleteMessage(
 	}
 	*deletionEventMsg.DeletedAt = time.Now()
 
-	// We might leave Sender/Receiver empty if we trust the conversationID routing.
-	// But `handleWebSocket` often relies on Sender/Receiver.
-	//
-	// Let's defer Kafka update for now or send a simplified event if supported.
-	// Assuming Redis Publish is enough for now (since we use Redis for WS).
+	return &deletionEventMsg, nil
+}
+
+// removeMessage deletes a message and tells the conversation's clients, the
+// search index, the pin list and, for replies, the thread. actorID is who
+// removed it.
+func (s *MessageService) removeMessage(ctx context.Context, convKey, messageIDStr string, actorID primitive.ObjectID) error {
+	var threadParentID string
+	if strings.HasPrefix(convKey, "group_") {
+		if existing, err := s.messageCassandraRepo.GetMessage(ctx, convKey, messageIDStr); err == nil {
+			threadParentID = existing.ReplyToID
+		}
+	}
 
+	// Delete from Cassandra
+	err := s.messageCassandraRepo.DeleteMessage(ctx, convKey, messageIDStr)
+	if err != nil {
+		return fmt.Errorf("cassandra delete failed: %w", err)
+	}
+	s.publishSearchEvent(ctx, messagesearch.Event{
+		Action:         messagesearch.ActionDelete,
+		ConversationID: convKey,
+		MessageID:      messageIDStr,
+	})
+
+	// Redis Publish is enough for now (since we use Redis for WS)
 	deletionBytes, _ := json.Marshal(map[string]interface{}{
 		"type":            "MESSAGE_DELETED",
 		"conversation_id": convKey,
 		"message_id":      messageIDStr,
 	})
-	s.redisClient.Publish(ctx, "messages", deletionBytes)
+	s.redisClient.Publish(ctx, "messages", observability.WrapRedisPayload(ctx, deletionBytes))
 
-	return &deletionEventMsg, nil
+	// A deleted message can't stay pinned
+	if removed, err := s.messageCassandraRepo.UnpinMessage(ctx, convKey, messageIDStr); err != nil {
+		log.Printf("Failed to unpin deleted message %s: %v", messageIDStr, err)
+	} else if removed {
+		s.publishPinEvent(ctx, "MESSAGE_UNPINNED", convKey, messageIDStr, actorID)
+	}
+
+	if threadParentID != "" {
+		s.removeThreadReply(ctx, convKey, threadParentID, messageIDStr)
+	}
+	return nil
 }
 
-// AddReaction handles adding a reaction to a message
-func (s *MessageService) AddReaction(ctx context.Context, messageIDStr, userIDStr, emoji string) error {
-	messageID, err := primitive.ObjectIDFromHex(messageIDStr)
+// PinMessage pins a message to the top of its conversation for every
+// participant. Conversations hold at most models.MaxPinnedMessages pins.
+func (s *MessageService) PinMessage(ctx context.Context, requesterID primitive.ObjectID, conversationID, messageID string) ([]models.PinnedMessage, error) {
+	convKey, err := s.authorizePinChange(ctx, requesterID, conversationID)
 	if err != nil {
-		return errors.New("invalid message ID format")
+		return nil, err
+	}
+
+	if _, err := s.messageCassandraRepo.GetMessage(ctx, convKey, messageID); err != nil {
+		if errors.Is(err, repositories.ErrMessageNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
 	}
-	userID, err := primitive.ObjectIDFromHex(userIDStr)
+
+	pins, err := s.messageCassandraRepo.GetPinnedMessages(ctx, convKey)
 	if err != nil {
-		return errors.New("invalid user ID format")
+		return nil, err
+	}
+	if containsPin(pins, messageID) {
+		return nil, ErrMessageAlreadyPinned
+	}
+	// Fail fast; the repository enforces the limit against concurrent pins
+	if len(pins) >= models.MaxPinnedMessages {
+		return nil, ErrPinLimitReached
 	}
 
-	// IDOR FIX: Fetch message and verify user is part of the conversation
-	msg, err := s.messageRepo.GetMessageByID(ctx, messageID)
+	applied, err := s.messageCassandraRepo.PinMessage(ctx, models.PinnedMessage{
+		ConversationID: convKey,
+		MessageID:      messageID,
+		PinnedBy:       requesterID.Hex(),
+		PinnedAt:       time.Now(),
+	}, models.MaxPinnedMessages)
 	if err != nil {
-		return errors.New("message not found")
+		if errors.Is(err, repositories.ErrPinLimitReached) {
+			return nil, ErrPinLimitReached
+		}
+		return nil, err
+	}
+	if !applied {
+		return nil, ErrMessageAlreadyPinned
 	}
 
-	// Check if user is participant in this conversation
-	isParticipant := false
-	if !msg.GroupID.IsZero() {
-		// Group message: check group membership
-		group, err := s.groupRepo.GetGroup(ctx, msg.GroupID)
-		if err == nil {
-			for _, memberID := range group.Members {
-				if memberID == userID {
-					isParticipant = true
-					break
-				}
-			}
+	return s.publishPinEvent(ctx, "MESSAGE_PINNED", convKey, messageID, requesterID)
+}
+
+// UnpinMessage removes a pin. It takes the same permission as pinning.
+func (s *MessageService) UnpinMessage(ctx context.Context, requesterID primitive.ObjectID, conversationID, messageID string) ([]models.PinnedMessage, error) {
+	convKey, err := s.authorizePinChange(ctx, requesterID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := s.messageCassandraRepo.UnpinMessage(ctx, convKey, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return s.messageCassandraRepo.GetPinnedMessages(ctx, convKey)
+	}
+
+	return s.publishPinEvent(ctx, "MESSAGE_UNPINNED", convKey, messageID, requesterID)
+}
+
+// ListPinnedMessages returns a conversation's pins to one of its participants
+func (s *MessageService) ListPinnedMessages(ctx context.Context, userID primitive.ObjectID, conversationID string, isGroup bool) ([]models.PinnedMessage, error) {
+	convKey, err := s.normalizeConversationKey(userID, conversationID, &isGroup)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConversationID, err)
+	}
+	if !s.isParticipant(ctx, userID, convKey) {
+		return nil, ErrNotConversationParticipant
+	}
+
+	return s.messageCassandraRepo.GetPinnedMessages(ctx, convKey)
+}
+
+// authorizePinChange resolves the conversation a pin change targets. Either
+// participant of a direct message may change its pins; in groups it takes the
+// group's pin_messages permission.
+func (s *MessageService) authorizePinChange(ctx context.Context, requesterID primitive.ObjectID, conversationID string) (string, error) {
+	convKey, err := s.normalizeConversationKey(requesterID, conversationID, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidConversationID, err)
+	}
+
+	groupHex, ok := strings.CutPrefix(convKey, "group_")
+	if !ok {
+		if !s.isParticipant(ctx, requesterID, convKey) {
+			return "", ErrNotConversationParticipant
 		}
-	} else {
-		// DM message: check sender/receiver
-		isParticipant = msg.SenderID == userID || msg.ReceiverID == userID
+		return convKey, nil
 	}
 
-	if !isParticipant {
-		return errors.New("you are not authorized to react to this message")
+	gID, err := primitive.ObjectIDFromHex(groupHex)
+	if err != nil {
+		return "", ErrInvalidConversationID
 	}
+	group, err := s.groupRepo.GetGroup(ctx, gID)
+	if err != nil {
+		return "", fmt.Errorf("group not found")
+	}
+	if !group.Can(requesterID, models.GroupActionPinMessages) {
+		return "", ErrGroupPermissionDenied
+	}
+	return convKey, nil
+}
 
-	err = s.messageRepo.AddReaction(ctx, messageID, userID, emoji)
+// publishPinEvent tells the conversation's participants about a pin change
+// and returns the pins as they stand after it
+func (s *MessageService) publishPinEvent(ctx context.Context, eventType, convKey, messageID string, actorID primitive.ObjectID) ([]models.PinnedMessage, error) {
+	pins, err := s.messageCassandraRepo.GetPinnedMessages(ctx, convKey)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Publish reaction event to Kafka
-	reactionEvent := models.ReactionEvent{
-		MessageID: messageID,
-		UserID:    userID,
-		Emoji:     emoji,
-		Action:    "add",
-		Timestamp: time.Now(),
+	recipients, err := s.conversationRecipients(ctx, convKey)
+	if err != nil {
+		log.Printf("Failed to resolve members of %s for %s event: %v", convKey, eventType, err)
+		return pins, nil
 	}
-	reactionEventBytes, err := json.Marshal(reactionEvent)
+
+	data, err := json.Marshal(models.MessagePinEvent{
+		ConversationID: convKey,
+		MessageID:      messageID,
+		ActorID:        actorID.Hex(),
+		PinnedMessages: pins,
+	})
 	if err != nil {
-		log.Printf("Failed to marshal reaction event for Kafka: %v", err)
-	} else {
-		kafkaMsg := kafkago.Message{
-			Key:   []byte(messageID.Hex()),
-			Value: reactionEventBytes,
-			Time:  time.Now(),
+		return nil, err
+	}
+	eventBytes, err := json.Marshal(models.WebSocketEvent{
+		Type:       eventType,
+		Data:       data,
+		Recipients: recipients,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.producer.ProduceMessage(ctx, kafkago.Message{
+		Key:   []byte(convKey),
+		Value: eventBytes,
+		Time:  time.Now(),
+	}); err != nil {
+		log.Printf("Failed to publish %s event for %s: %v", eventType, convKey, err)
+	}
+
+	return pins, nil
+}
+
+// conversationRecipients lists the users a conversation's events go to
+func (s *MessageService) conversationRecipients(ctx context.Context, convKey string) ([]string, error) {
+	groupHex, ok := strings.CutPrefix(convKey, "group_")
+	if !ok {
+		return strings.Split(strings.TrimPrefix(convKey, "dm_"), "_"), nil
+	}
+	gID, err := primitive.ObjectIDFromHex(groupHex)
+	if err != nil {
+		return nil, ErrInvalidConversationID
+	}
+	return s.groupMembers(ctx, gID)
+}
+
+func containsPin(pins []models.PinnedMessage, messageID string) bool {
+	for _, pin := range pins {
+		if pin.MessageID == messageID {
+			return true
 		}
-		if err := s.producer.ProduceMessage(ctx, kafkaMsg); err != nil {
-			log.Printf("Failed to produce reaction event to Kafka: %v", err)
+	}
+	return false
+}
+
+// GetRetention returns a conversation's retention policy to one of its
+// participants
+func (s *MessageService) GetRetention(ctx context.Context, userID primitive.ObjectID, conversationID string, isGroup bool) (*models.ConversationRetention, error) {
+	convKey, err := s.normalizeConversationKey(userID, conversationID, &isGroup)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConversationID, err)
+	}
+	if !s.isParticipant(ctx, userID, convKey) {
+		return nil, ErrNotConversationParticipant
+	}
+
+	return s.messageCassandraRepo.GetRetention(ctx, convKey)
+}
+
+// SetRetention changes how many days a conversation keeps its messages; zero
+// keeps them forever. Either participant of a direct message may change it;
+// in groups it takes an admin. The archive worker purges what falls outside it.
+func (s *MessageService) SetRetention(ctx context.Context, requesterID primitive.ObjectID, conversationID string, isGroup bool, days int) (*models.ConversationRetention, error) {
+	convKey, err := s.normalizeConversationKey(requesterID, conversationID, &isGroup)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConversationID, err)
+	}
+
+	if groupHex, ok := strings.CutPrefix(convKey, "group_"); ok {
+		gID, err := primitive.ObjectIDFromHex(groupHex)
+		if err != nil {
+			return nil, ErrInvalidConversationID
 		}
+		group, err := s.groupRepo.GetGroup(ctx, gID)
+		if err != nil {
+			return nil, fmt.Errorf("group not found")
+		}
+		role := group.RoleOf(requesterID)
+		if role == "" {
+			return nil, ErrNotConversationParticipant
+		}
+		if !role.AtLeast(models.GroupRoleAdmin) {
+			return nil, ErrRetentionNotAllowed
+		}
+	} else if !s.isParticipant(ctx, requesterID, convKey) {
+		return nil, ErrNotConversationParticipant
 	}
 
-	return nil
+	now := time.Now()
+	retention := models.ConversationRetention{
+		ConversationID: convKey,
+		RetentionDays:  days,
+		UpdatedBy:      requesterID.Hex(),
+		UpdatedAt:      &now,
+	}
+	if err := s.messageCassandraRepo.SetRetention(ctx, retention); err != nil {
+		return nil, err
+	}
+
+	s.publishRetentionEvent(ctx, retention)
+	return &retention, nil
 }
 
-// RemoveReaction handles removing a reaction from a message
-func (s *MessageService) RemoveReaction(ctx context.Context, messageIDStr, userIDStr, emoji string) error {
-	messageID, err := primitive.ObjectIDFromHex(messageIDStr)
+// publishRetentionEvent tells the conversation's participants its retention
+// changed
+func (s *MessageService) publishRetentionEvent(ctx context.Context, retention models.ConversationRetention) {
+	convKey := retention.ConversationID
+	recipients, err := s.conversationRecipients(ctx, convKey)
 	if err != nil {
-		return errors.New("invalid message ID format")
+		log.Printf("Failed to resolve members of %s for retention event: %v", convKey, err)
+		return
 	}
-	userID, err := primitive.ObjectIDFromHex(userIDStr)
+
+	data, err := json.Marshal(retention)
 	if err != nil {
-		return errors.New("invalid user ID format")
+		log.Printf("Failed to marshal retention event for %s: %v", convKey, err)
+		return
 	}
+	eventBytes, err := json.Marshal(models.WebSocketEvent{
+		Type:       "CONVERSATION_RETENTION_UPDATED",
+		Data:       data,
+		Recipients: recipients,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal retention event for %s: %v", convKey, err)
+		return
+	}
+	if err := s.producer.ProduceMessage(ctx, kafkago.Message{
+		Key:   []byte(convKey),
+		Value: eventBytes,
+		Time:  time.Now(),
+	}); err != nil {
+		log.Printf("Failed to publish retention event for %s: %v", convKey, err)
+	}
+}
 
-	// IDOR FIX: Fetch message and verify user is part of the conversation
-	msg, err := s.messageRepo.GetMessageByID(ctx, messageID)
+// AddReaction adds the user's emoji reaction to a message of a conversation
+// they take part in. Adding a reaction the user already made changes nothing.
+func (s *MessageService) AddReaction(ctx context.Context, userID primitive.ObjectID, conversationID, messageID, emoji string) (*models.MessageReactionsResponse, error) {
+	convKey, emoji, err := s.authorizeReaction(ctx, userID, conversationID, messageID, emoji)
 	if err != nil {
-		return errors.New("message not found")
+		return nil, err
 	}
 
-	// Check if user is participant in this conversation
-	isParticipant := false
-	if !msg.GroupID.IsZero() {
-		// Group message: check group membership
-		group, err := s.groupRepo.GetGroup(ctx, msg.GroupID)
-		if err == nil {
-			for _, memberID := range group.Members {
-				if memberID == userID {
-					isParticipant = true
-					break
-				}
-			}
-		}
-	} else {
-		// DM message: check sender/receiver
-		isParticipant = msg.SenderID == userID || msg.ReceiverID == userID
+	applied, err := s.messageCassandraRepo.AddReaction(ctx, convKey, messageID, userID.Hex(), emoji, time.Now())
+	if err != nil {
+		return nil, err
 	}
+	return s.reactionResult(ctx, "add", applied, convKey, messageID, userID, emoji)
+}
 
-	if !isParticipant {
-		return errors.New("you are not authorized to remove reaction from this message")
+// RemoveReaction takes back the user's emoji reaction to a message.
+// Removing a reaction the user never made changes nothing.
+func (s *MessageService) RemoveReaction(ctx context.Context, userID primitive.ObjectID, conversationID, messageID, emoji string) (*models.MessageReactionsResponse, error) {
+	convKey, emoji, err := s.authorizeReaction(ctx, userID, conversationID, messageID, emoji)
+	if err != nil {
+		return nil, err
 	}
 
-	err = s.messageRepo.RemoveReaction(ctx, messageID, userID, emoji)
+	applied, err := s.messageCassandraRepo.RemoveReaction(ctx, convKey, messageID, userID.Hex(), emoji)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return s.reactionResult(ctx, "remove", applied, convKey, messageID, userID, emoji)
+}
 
-	// Publish reaction event to Kafka
-	reactionEvent := models.ReactionEvent{
-		MessageID: messageID,
-		UserID:    userID,
-		Emoji:     emoji,
-		Action:    "remove",
-		Timestamp: time.Now(),
+// authorizeReaction checks that the user may react to the message and
+// returns its conversation key with the trimmed emoji
+func (s *MessageService) authorizeReaction(ctx context.Context, userID primitive.ObjectID, conversationID, messageID, emoji string) (string, string, error) {
+	emoji = strings.TrimSpace(emoji)
+	if emoji == "" || utf8.RuneCountInString(emoji) > maxReactionRunes {
+		return "", "", ErrInvalidReaction
 	}
-	reactionEventBytes, err := json.Marshal(reactionEvent)
+
+	convKey, err := s.normalizeConversationKey(userID, conversationID, nil)
 	if err != nil {
-		log.Printf("Failed to marshal reaction event for Kafka: %v", err)
-	} else {
-		kafkaMsg := kafkago.Message{
-			Key:   []byte(messageID.Hex()),
-			Value: reactionEventBytes,
-			Time:  time.Now(),
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidConversationID, err)
+	}
+	if !s.isParticipant(ctx, userID, convKey) {
+		return "", "", ErrNotConversationParticipant
+	}
+
+	if _, err := s.messageCassandraRepo.GetMessage(ctx, convKey, messageID); err != nil {
+		if errors.Is(err, repositories.ErrMessageNotFound) {
+			return "", "", ErrMessageNotFound
 		}
-		if err := s.producer.ProduceMessage(ctx, kafkaMsg); err != nil {
-			log.Printf("Failed to produce reaction event to Kafka: %v", err)
+		return "", "", err
+	}
+	return convKey, emoji, nil
+}
+
+// reactionResult returns a message's tally after a reaction change and, if
+// the change applied, tells the conversation's participants about it
+func (s *MessageService) reactionResult(ctx context.Context, action string, applied bool, convKey, messageID string, userID primitive.ObjectID, emoji string) (*models.MessageReactionsResponse, error) {
+	counts, err := s.messageCassandraRepo.GetReactionCounts(ctx, convKey, messageID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrMessageNotFound) {
+			return nil, ErrMessageNotFound
 		}
+		return nil, err
 	}
 
-	return nil
+	if applied {
+		s.publishReactionEvent(ctx, models.MessageReactionEvent{
+			ConversationID: convKey,
+			MessageID:      messageID,
+			UserID:         userID.Hex(),
+			Emoji:          emoji,
+			Action:         action,
+			ReactionCounts: counts,
+			Timestamp:      time.Now(),
+		})
+	}
+	return &models.MessageReactionsResponse{MessageID: messageID, ReactionCounts: counts}, nil
+}
+
+func (s *MessageService) publishReactionEvent(ctx context.Context, event models.MessageReactionEvent) {
+	recipients, err := s.conversationRecipients(ctx, event.ConversationID)
+	if err != nil {
+		log.Printf("Failed to resolve members of %s for reaction event: %v", event.ConversationID, err)
+		return
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to marshal reaction event: %v", err)
+		return
+	}
+	eventBytes, err := json.Marshal(models.WebSocketEvent{
+		Type:       "MESSAGE_REACTION_UPDATE",
+		Data:       data,
+		Recipients: recipients,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal reaction event: %v", err)
+		return
+	}
+	// Keyed by conversation so a message's reaction changes stay in order
+	if err := s.producer.ProduceMessage(ctx, kafkago.Message{
+		Key:   []byte(event.ConversationID),
+		Value: eventBytes,
+		Time:  time.Now(),
+	}); err != nil {
+		log.Printf("Failed to publish reaction event for message %s: %v", event.MessageID, err)
+	}
 }
 
-// EditMessage handles editing a message
+// EditMessage replaces the content of one of the requester's messages,
+// keeping the earlier version in the message's edit history. Messages can
+// only be edited within the service's edit window.
 func (s *MessageService) EditMessage(ctx context.Context, conversationID, messageIDStr, requesterIDStr, newContent string) (*models.Message, error) {
-	// 1. Validation Logic
-	// In Cassandra, reading BEFORE write to validate ownership is expensive (requires read).
-	// However, we need to check if user is allowed to edit (ownership + 1 hour rule).
-	// For migration, we might skip strict 1-hour rule enforcement OR perform a read.
-	// Since we need to return the updated message anyway, let's READ first.
-	// Wait, we need `GetMessage` (singular) which I haven't implemented yet?
-	// `GetMessages` returns a list.
-	// I can filter fetching 1 message?
-
-	// Assume we trust the frontend OR we implement a read check.
-	// Implementing Read Check:
-	/*
-		msgs, err := s.messageCassandraRepo.GetMessages(ctx, models.MessageQuery{
-			ConversationID: conversationID,
-			Limit: 1,
-			// We can't filter by message_id easily without index or client-side filtering?
-			// Actually, we can fetch by conversation_id and filter client side? No, too big.
-			//
-			// BUT `messages` table primary key is (conversation_id, created_at, message_id)?
-			// Check schema in `db/cassandra.go` if possible (not seen).
-			// Usually it is clustered by created_at. We don't have created_at here.
-			// So we CANNOT efficiently read a single message without created_at or secondary index.
-			//
-			// IF `message_id` is NOT part of PK, we can't select by it efficiently.
-			// IF `message_id` IS the PK, then we can.
-			// `insertMessageQuery`: VALUES (?, ?, ...) -> conversation_id, message_id...
-			// If `message_id` is clustering key, we might need other keys.
-
-			// Let's assume for MVP Migration we perform the UPDATE blindly (if owned by user - passed in WHERE clause?).
-			// `UPDATE ... WHERE conversation_id = ? AND message_id = ? AND sender_id = ?` ?
-			// Cassandra doesn't support filtering by non-key columns in Update easily.
-
-			// Compromise: We update blindly based on conversationID + messageID.
-			// The 1-hour rule enforcement is lost without a read.
-	*/
-
-	// Proceed with blind update for now.
-	requesterID, _ := primitive.ObjectIDFromHex(requesterIDStr)
+	requesterID, err := primitive.ObjectIDFromHex(requesterIDStr)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
 	convKey, err := s.normalizeConversationKey(requesterID, conversationID, nil)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConversationID, err)
 	}
 
-	err = s.messageCassandraRepo.EditMessage(ctx, convKey, messageIDStr, newContent)
+	existing, err := s.messageCassandraRepo.GetMessage(ctx, convKey, messageIDStr)
 	if err != nil {
+		if errors.Is(err, repositories.ErrMessageNotFound) {
+			return nil, ErrMessageNotFound
+		}
 		return nil, err
 	}
+	if existing.SenderID != requesterID {
+		return nil, ErrMessageEditNotOwned
+	}
+	if s.editWindow > 0 && time.Since(existing.CreatedAt) > s.editWindow {
+		return nil, ErrEditWindowExpired
+	}
+	if existing.Content == newContent {
+		return existing, nil
+	}
+
+	decision := s.moderator.Screen(ctx, newContent, nil)
+	if decision.Rejected() {
+		return nil, moderation.ErrContentRejected
+	}
 
-	// Construct optimized response (partial)
-	updatedMsg := &models.Message{
-		StringID: messageIDStr,
-		Content:  newContent,
-		IsEdited: true,
-		SenderID: requesterID,  // We assume success implies requester was owner (if we had check)
-		EditedAt: &time.Time{}, // Now
+	editedAt := time.Now()
+	applied, err := s.messageCassandraRepo.EditMessage(ctx, convKey, messageIDStr, existing.Content, newContent, requesterID.Hex(), editedAt)
+	if !applied {
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrMessageEditConflict
 	}
-	*updatedMsg.EditedAt = time.Now()
+	if err != nil {
+		// The edit went through; only its history entry is missing
+		log.Printf("Message %s edited without history: %v", messageIDStr, err)
+	}
+	s.moderator.Report(ctx, decision, models.ModerationQueueItem{
+		ContentType: models.ModeratedMessage,
+		ContentID:   messageIDStr,
+		ParentID:    convKey,
+		AuthorID:    requesterID,
+	}, newContent)
+	s.publishSearchEvent(ctx, messagesearch.Event{
+		Action:         messagesearch.ActionEdit,
+		ConversationID: convKey,
+		MessageID:      messageIDStr,
+		Content:        newContent,
+	})
+	s.queueMessagePreview(convKey, messageIDStr, newContent)
+
+	updatedMsg := existing
+	updatedMsg.Content = newContent
+	updatedMsg.IsEdited = true
+	updatedMsg.EditedAt = &editedAt
+	updatedMsg.LinkPreview = nil
 
 	// Redis Publish (Critical for FE)
 	eventBytes, _ := json.Marshal(map[string]interface{}{
@@ -1057,9 +1715,34 @@ func (s *MessageService) EditMessage(ctx context.Context, conversationID, messag
 		"conversation_id": conversationID,
 		"message_id":      messageIDStr,
 		"new_content":     newContent,
-		"edited_at":       time.Now(),
+		"edited_at":       editedAt,
 	})
-	s.redisClient.Publish(ctx, "messages", eventBytes)
+	s.redisClient.Publish(ctx, "messages", observability.WrapRedisPayload(ctx, eventBytes))
 
 	return updatedMsg, nil
 }
+
+// GetEditHistory returns a message's earlier versions to a participant of
+// its conversation
+func (s *MessageService) GetEditHistory(ctx context.Context, userID primitive.ObjectID, conversationID, messageID string) (*models.MessageEditHistory, error) {
+	convKey, err := s.normalizeConversationKey(userID, conversationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConversationID, err)
+	}
+	if !s.isParticipant(ctx, userID, convKey) {
+		return nil, ErrNotConversationParticipant
+	}
+
+	msg, err := s.messageCassandraRepo.GetMessage(ctx, convKey, messageID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrMessageNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
+	}
+	edits, err := s.messageCassandraRepo.GetEditHistory(ctx, convKey, messageID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MessageEditHistory{MessageID: messageID, Content: msg.Content, Edits: edits}, nil
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"messaging-app/internal/repositories"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultThreadPageSize = 50
+	maxThreadPageSize     = 100
+)
+
+// resolveReplyParent checks that the message a reply points at belongs to
+// the same conversation. Group threads are one level deep, so a reply to a
+// reply joins the thread of the original message.
+func (s *MessageService) resolveReplyParent(ctx context.Context, convKey string, msg *models.Message) error {
+	if msg.ReplyToID == "" {
+		return nil
+	}
+	parent, err := s.messageCassandraRepo.GetMessage(ctx, convKey, msg.ReplyToID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrMessageNotFound) {
+			return ErrReplyParentNotFound
+		}
+		return err
+	}
+	if strings.HasPrefix(convKey, "group_") && parent.ReplyToID != "" {
+		msg.ReplyToID = parent.ReplyToID
+	}
+	return nil
+}
+
+// addThreadReply files a new group reply under its parent's thread and tells
+// the group the thread changed
+func (s *MessageService) addThreadReply(ctx context.Context, convKey string, msg *models.Message) {
+	if !strings.HasPrefix(convKey, "group_") {
+		return
+	}
+	summary, err := s.messageCassandraRepo.AddThreadReply(ctx, convKey, msg.ReplyToID, msg.StringID, msg.SenderID.Hex())
+	if err != nil {
+		log.Printf("Failed to add reply %s to thread %s: %v", msg.StringID, msg.ReplyToID, err)
+		return
+	}
+	s.publishThreadEvent(ctx, models.ThreadUpdatedEvent{
+		ConversationID: convKey,
+		ReplyID:        msg.StringID,
+		Action:         "add",
+		Thread:         *summary,
+	})
+}
+
+// removeThreadReply takes a deleted group reply out of its parent's thread
+func (s *MessageService) removeThreadReply(ctx context.Context, convKey, parentID, replyID string) {
+	summary, err := s.messageCassandraRepo.RemoveThreadReply(ctx, convKey, parentID, replyID)
+	if err != nil {
+		log.Printf("Failed to remove reply %s from thread %s: %v", replyID, parentID, err)
+		return
+	}
+	s.publishThreadEvent(ctx, models.ThreadUpdatedEvent{
+		ConversationID: convKey,
+		ReplyID:        replyID,
+		Action:         "remove",
+		Thread:         *summary,
+	})
+}
+
+// publishThreadEvent tells the group a thread's summary changed
+func (s *MessageService) publishThreadEvent(ctx context.Context, event models.ThreadUpdatedEvent) {
+	convKey := event.ConversationID
+	recipients, err := s.conversationRecipients(ctx, convKey)
+	if err != nil {
+		log.Printf("Failed to resolve members of %s for thread event: %v", convKey, err)
+		return
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to marshal thread event for %s: %v", convKey, err)
+		return
+	}
+	eventBytes, err := json.Marshal(models.WebSocketEvent{
+		Type:       "THREAD_UPDATED",
+		Data:       data,
+		Recipients: recipients,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal thread event for %s: %v", convKey, err)
+		return
+	}
+	if err := s.producer.ProduceMessage(ctx, kafkago.Message{
+		Key:   []byte(convKey),
+		Value: eventBytes,
+		Time:  time.Now(),
+	}); err != nil {
+		log.Printf("Failed to publish thread event for %s: %v", convKey, err)
+	}
+}
+
+// groupConversationKey returns the Cassandra key of a message query that
+// reads a group conversation, or "" for direct messages
+func groupConversationKey(query models.MessageQuery) string {
+	if strings.HasPrefix(query.ConversationID, "group_") {
+		return query.ConversationID
+	}
+	if query.ConversationID == "" && query.GroupID != "" {
+		return "group_" + query.GroupID
+	}
+	return ""
+}
+
+// attachThreadSummaries sets the thread summary of every message that
+// started a thread
+func (s *MessageService) attachThreadSummaries(ctx context.Context, convKey string, messages []models.Message) {
+	if len(messages) == 0 {
+		return
+	}
+	ids := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		if msg.StringID != "" && msg.ReplyToID == "" {
+			ids = append(ids, msg.StringID)
+		}
+	}
+	summaries, err := s.messageCassandraRepo.GetThreadSummaries(ctx, convKey, ids)
+	if err != nil {
+		log.Printf("Failed to load thread summaries for %s: %v", convKey, err)
+		return
+	}
+	for i := range messages {
+		if summary, ok := summaries[messages[i].StringID]; ok {
+			messages[i].Thread = &summary
+		}
+	}
+}
+
+// GetThreadMessages returns a page of the replies to a group message, oldest
+// first. The first page, without an after cursor, also carries the parent.
+func (s *MessageService) GetThreadMessages(ctx context.Context, userID primitive.ObjectID, conversationID, parentID, after string, limit int) (*models.ThreadMessagesResponse, error) {
+	convKey, err := s.ConversationKey(ctx, userID, conversationID, true)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(convKey, "group_") {
+		return nil, ErrThreadsGroupOnly
+	}
+	if limit <= 0 {
+		limit = defaultThreadPageSize
+	}
+	if limit > maxThreadPageSize {
+		limit = maxThreadPageSize
+	}
+
+	parent, err := s.messageCassandraRepo.GetMessage(ctx, convKey, parentID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrMessageNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
+	}
+
+	summaries, err := s.messageCassandraRepo.GetThreadSummaries(ctx, convKey, []string{parent.StringID})
+	if err != nil {
+		return nil, err
+	}
+	summary, ok := summaries[parent.StringID]
+	if !ok {
+		summary = models.ThreadSummary{ParentID: parent.StringID, LastReplierIDs: []string{}}
+	}
+
+	replies, hasMore, err := s.messageCassandraRepo.GetThreadReplies(ctx, convKey, parent.StringID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	s.attachSenders(ctx, replies)
+
+	resp := &models.ThreadMessagesResponse{
+		Thread:  summary,
+		Replies: replies,
+		HasMore: hasMore,
+	}
+	if after == "" {
+		parents := []models.Message{*parent}
+		s.attachSenders(ctx, parents)
+		parents[0].Thread = &summary
+		resp.Parent = &parents[0]
+	}
+	return resp, nil
+}
+
+// attachSenders fills in the sender of each message with one batch lookup
+func (s *MessageService) attachSenders(ctx context.Context, messages []models.Message) {
+	senderIDsMap := make(map[string]bool)
+	var senderIDs []primitive.ObjectID
+
+	// Collect unique Sender IDs
+	for _, msg := range messages {
+		if !msg.SenderID.IsZero() {
+			sid := msg.SenderID.Hex()
+			if !senderIDsMap[sid] {
+				senderIDsMap[sid] = true
+				senderIDs = append(senderIDs, msg.SenderID)
+			}
+		}
+	}
+
+	// Fetch all senders in one query
+	var users []models.User
+	if len(senderIDs) > 0 {
+		var err error
+		users, err = s.userRepo.FindUsersByIDs(ctx, senderIDs)
+		if err != nil {
+			log.Printf("Failed to batch fetch users: %v", err)
+			// Don't fail the request, just log and allow unknown senders
+		}
+	}
+
+	// Map users for fast lookup
+	userMap := make(map[string]models.User)
+	for _, u := range users {
+		userMap[u.ID.Hex()] = u
+	}
+
+	// Assign sender details
+	for i := range messages {
+		msg := &messages[i]
+		if !msg.SenderID.IsZero() {
+			if user, found := userMap[msg.SenderID.Hex()]; found {
+				msg.Sender = &models.SafeUserResponse{
+					ID:       user.ID,
+					Username: user.Username,
+					FullName: user.FullName,
+					Avatar:   user.Avatar,
+				}
+				msg.SenderName = user.Username
+			} else {
+				msg.SenderName = "Unknown"
+			}
+		}
+	}
+}
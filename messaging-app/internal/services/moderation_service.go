@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"messaging-app/internal/repositories"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/moderation"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrModerationForbidden is returned when someone other than an admin
+// manages moderation rules or the review queue
+var ErrModerationForbidden = apperrors.Forbidden("only admins can manage moderation")
+
+// ModerationService manages the text moderation rules and the review queue.
+// Platform admins manage the global rules and the queue; community admins
+// manage their community's own rules.
+type ModerationService struct {
+	moderator     *moderation.Moderator
+	store         *moderation.Store
+	userRepo      *repositories.UserRepository
+	communityRepo *repositories.CommunityRepository
+	audit         *audit.Logger
+}
+
+func NewModerationService(moderator *moderation.Moderator, userRepo *repositories.UserRepository, communityRepo *repositories.CommunityRepository) *ModerationService {
+	return &ModerationService{
+		moderator:     moderator,
+		store:         moderator.Store(),
+		userRepo:      userRepo,
+		communityRepo: communityRepo,
+	}
+}
+
+// SetAuditLogger enables recording review decisions in the audit log
+func (s *ModerationService) SetAuditLogger(logger *audit.Logger) {
+	s.audit = logger
+}
+
+// IsPlatformAdmin reports whether the user holds the admin role
+func (s *ModerationService) IsPlatformAdmin(ctx context.Context, userID primitive.ObjectID) (bool, error) {
+	user, err := s.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return user.IsAdmin(), nil
+}
+
+// authorizeRules checks that the actor may manage the rules of a scope: the
+// global rules for platform admins, a community's rules also for its admins
+func (s *ModerationService) authorizeRules(ctx context.Context, actorID primitive.ObjectID, communityID *primitive.ObjectID) error {
+	if communityID != nil {
+		community, err := s.communityRepo.GetByID(ctx, *communityID)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(community.Admins, actorID) {
+			return nil
+		}
+	}
+	isAdmin, err := s.IsPlatformAdmin(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return ErrModerationForbidden
+	}
+	return nil
+}
+
+func (s *ModerationService) ListRules(ctx context.Context, actorID primitive.ObjectID, communityID *primitive.ObjectID) ([]models.ModerationRule, error) {
+	if err := s.authorizeRules(ctx, actorID, communityID); err != nil {
+		return nil, err
+	}
+	return s.store.ListRules(ctx, communityID)
+}
+
+func (s *ModerationService) CreateRule(ctx context.Context, actorID primitive.ObjectID, communityID *primitive.ObjectID, req models.CreateModerationRuleRequest) (*models.ModerationRule, error) {
+	if err := s.authorizeRules(ctx, actorID, communityID); err != nil {
+		return nil, err
+	}
+	if err := moderation.ValidateRule(req.Kind, req.Patterns, req.Action); err != nil {
+		return nil, err
+	}
+
+	rule := &models.ModerationRule{
+		CommunityID: communityID,
+		Name:        req.Name,
+		Kind:        req.Kind,
+		Patterns:    req.Patterns,
+		Action:      req.Action,
+		Enabled:     req.Enabled == nil || *req.Enabled,
+		CreatedBy:   actorID,
+	}
+	if err := s.store.CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	s.moderator.InvalidateRules()
+	return rule, nil
+}
+
+func (s *ModerationService) UpdateRule(ctx context.Context, actorID primitive.ObjectID, communityID *primitive.ObjectID, ruleID primitive.ObjectID, req models.UpdateModerationRuleRequest) (*models.ModerationRule, error) {
+	if err := s.authorizeRules(ctx, actorID, communityID); err != nil {
+		return nil, err
+	}
+	rule, err := s.scopedRule(ctx, communityID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		rule.Name = req.Name
+	}
+	if req.Kind != "" {
+		rule.Kind = req.Kind
+	}
+	if req.Patterns != nil {
+		rule.Patterns = req.Patterns
+	}
+	if req.Action != "" {
+		rule.Action = req.Action
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	if err := moderation.ValidateRule(rule.Kind, rule.Patterns, rule.Action); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.UpdateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	s.moderator.InvalidateRules()
+	return rule, nil
+}
+
+func (s *ModerationService) DeleteRule(ctx context.Context, actorID primitive.ObjectID, communityID *primitive.ObjectID, ruleID primitive.ObjectID) error {
+	if err := s.authorizeRules(ctx, actorID, communityID); err != nil {
+		return err
+	}
+	if _, err := s.scopedRule(ctx, communityID, ruleID); err != nil {
+		return err
+	}
+	if err := s.store.DeleteRule(ctx, ruleID); err != nil {
+		return err
+	}
+	s.moderator.InvalidateRules()
+	return nil
+}
+
+// scopedRule loads a rule, hiding rules of other scopes so community admins
+// cannot reach global rules or another community's
+func (s *ModerationService) scopedRule(ctx context.Context, communityID *primitive.ObjectID, ruleID primitive.ObjectID) (*models.ModerationRule, error) {
+	rule, err := s.store.GetRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	sameScope := rule.CommunityID == nil && communityID == nil ||
+		rule.CommunityID != nil && communityID != nil && *rule.CommunityID == *communityID
+	if !sameScope {
+		return nil, moderation.ErrRuleNotFound
+	}
+	return rule, nil
+}
+
+// ListQueue pages through the review queue. Callers must be platform admins.
+func (s *ModerationService) ListQueue(ctx context.Context, filter moderation.QueueFilter, page, limit int64) ([]models.ModerationQueueItem, int64, error) {
+	return s.store.ListQueue(ctx, filter, page, limit)
+}
+
+// ReviewItem records a platform admin's decision. The service owning the
+// content carries it out shortly after.
+func (s *ModerationService) ReviewItem(ctx context.Context, reviewerID, itemID primitive.ObjectID, req models.ReviewModerationItemRequest) (*models.ModerationQueueItem, error) {
+	item, err := s.store.Review(ctx, itemID, reviewerID, req.Decision, req.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]string{"decision": string(req.Decision)}
+	if req.Note != "" {
+		metadata["note"] = req.Note
+	}
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditActionQueueItemReviewed,
+		ActorID:    &reviewerID,
+		TargetType: "moderation_queue_item",
+		TargetID:   item.ID.Hex(),
+		Metadata:   metadata,
+	})
+	if req.Decision == models.ModerationQueueRemoved {
+		s.audit.Record(ctx, models.AuditEntry{
+			Action:     models.AuditActionContentRemoved,
+			ActorID:    &reviewerID,
+			TargetType: string(item.ContentType),
+			TargetID:   item.ContentID,
+			Metadata:   map[string]string{"queue_item_id": item.ID.Hex(), "owner_id": item.AuthorID.Hex()},
+		})
+	}
+	return item, nil
+}
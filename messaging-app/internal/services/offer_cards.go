@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gocql/gocql"
+)
+
+// offerCardTTL is how long a posted card is remembered, so redelivered
+// offer events do not post it twice
+const offerCardTTL = 7 * 24 * time.Hour
+
+// PostOfferCard posts a marketplace offer change as a card in the buyer and
+// seller's marketplace conversation, sent by whoever made the change.
+// Cards between users who have since blocked each other are dropped.
+func (s *MessageService) PostOfferCard(ctx context.Context, evt *models.MarketplaceOfferEvent) error {
+	card := evt.Card()
+	dedupeKey := fmt.Sprintf("offer_card:%s:%s:%d", card.OfferID.Hex(), card.Action, card.Round)
+	posted, err := s.redisClient.SetNX(ctx, dedupeKey, "1", offerCardTTL).Result()
+	if err != nil {
+		log.Printf("Failed to check offer card %s, posting anyway: %v", dedupeKey, err)
+	} else if !posted {
+		return nil
+	}
+
+	productID := evt.Offer.ProductID
+	msg := &models.Message{
+		StringID:      gocql.TimeUUID().String(),
+		SenderID:      evt.ActorID,
+		Content:       card.Summary(),
+		ContentType:   models.ContentTypeOffer,
+		IsMarketplace: true,
+		ProductID:     &productID,
+		Product:       &evt.Product,
+		Offer:         card,
+	}
+
+	if _, err := s.handleDirectMessage(ctx, msg, evt.Offer.Counterparty(evt.ActorID).Hex()); err != nil {
+		if errors.Is(err, models.ErrUserBlocked) {
+			return nil
+		}
+		// Let a redelivery post it
+		s.redisClient.Del(ctx, dedupeKey)
+		return err
+	}
+	return nil
+}
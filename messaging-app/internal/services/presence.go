@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"messaging-app/internal/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SetPresencePrivacy applies users' presence visibility to presence queries.
+// Without it everyone sees everyone's presence.
+func (s *UserService) SetPresencePrivacy(privacy *PrivacyService, friendships *repositories.FriendshipRepository) {
+	s.presencePrivacy = privacy
+	s.friendshipRepo = friendships
+}
+
+// presenceVisibleTo reports for each user whether they share their presence
+// with viewerID
+func (s *UserService) presenceVisibleTo(ctx context.Context, viewerID primitive.ObjectID, userIDs []primitive.ObjectID) (map[primitive.ObjectID]bool, error) {
+	visible := make(map[primitive.ObjectID]bool, len(userIDs))
+	if s.presencePrivacy == nil {
+		for _, id := range userIDs {
+			visible[id] = true
+		}
+		return visible, nil
+	}
+
+	visibilities, err := s.presencePrivacy.PresenceVisibilities(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Friendship only matters for users sharing with friends
+	var friends map[primitive.ObjectID]bool
+	for _, id := range userIDs {
+		if id == viewerID || visibilities[id] != models.PrivacySettingFriends || friends != nil {
+			continue
+		}
+		friendIDs, err := s.friendshipRepo.GetFriendIDs(ctx, viewerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get friends for presence: %w", err)
+		}
+		friends = make(map[primitive.ObjectID]bool, len(friendIDs))
+		for _, friendID := range friendIDs {
+			friends[friendID] = true
+		}
+	}
+
+	for _, id := range userIDs {
+		visible[id] = id == viewerID || models.PresenceVisibleTo(visibilities[id], friends[id])
+	}
+	return visible, nil
+}
+
+// storedPresence reads the presence record the WebSocket hub keeps in Redis.
+// A missing or unreadable record means offline with no known activity.
+func storedPresence(raw string, now time.Time) models.Presence {
+	if raw == "" {
+		return models.Presence{Status: models.PresenceOffline}
+	}
+	var record struct {
+		Status   string `json:"status"`
+		LastSeen int64  `json:"last_seen"`
+	}
+	if err := json.Unmarshal([]byte(raw), &record); err != nil {
+		log.Printf("Error unmarshaling presence data: %v", err)
+		return models.Presence{Status: models.PresenceOffline}
+	}
+	if record.Status == models.PresenceOnline {
+		return models.NewPresence(models.PresenceOnline, now, now)
+	}
+
+	var lastSeen time.Time
+	if record.LastSeen > 0 {
+		lastSeen = time.Unix(record.LastSeen, 0)
+	}
+	return models.NewPresence(models.PresenceOffline, lastSeen, now)
+}
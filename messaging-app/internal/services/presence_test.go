@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStoredPresence(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	record := func(status string, lastSeen time.Time) string {
+		return fmt.Sprintf(`{"status":%q,"last_seen":%d}`, status, lastSeen.Unix())
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+		want models.Presence
+	}{
+		{"no record", "", models.Presence{Status: models.PresenceOffline}},
+		{"garbled record", "{", models.Presence{Status: models.PresenceOffline}},
+		{
+			"online ignores connect time",
+			record(models.PresenceOnline, now.Add(-3*time.Hour)),
+			models.Presence{Status: models.PresenceOnline, LastSeen: now.Unix(), LastSeenBucket: models.LastSeenJustNow},
+		},
+		{
+			"minutes round down to 5",
+			record(models.PresenceOffline, now.Add(-17*time.Minute)),
+			models.Presence{Status: models.PresenceOffline, LastSeen: now.Add(-15 * time.Minute).Unix(), LastSeenBucket: models.LastSeenMinutes},
+		},
+		{
+			"hours round down",
+			record(models.PresenceOffline, now.Add(-5*time.Hour-50*time.Minute)),
+			models.Presence{Status: models.PresenceOffline, LastSeen: now.Add(-5 * time.Hour).Unix(), LastSeenBucket: models.LastSeenHours},
+		},
+		{
+			"days round down",
+			record(models.PresenceOffline, now.Add(-3*24*time.Hour-7*time.Hour)),
+			models.Presence{Status: models.PresenceOffline, LastSeen: now.Add(-3 * 24 * time.Hour).Unix(), LastSeenBucket: models.LastSeenDays},
+		},
+		{
+			"beyond a week gives no time",
+			record(models.PresenceOffline, now.Add(-30*24*time.Hour)),
+			models.Presence{Status: models.PresenceOffline, LastSeenBucket: models.LastSeenLongAgo},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, storedPresence(tt.raw, now))
+		})
+	}
+}
+
+func TestPresenceVisibleTo(t *testing.T) {
+	tests := []struct {
+		visibility models.PrivacySettingType
+		isFriend   bool
+		want       bool
+	}{
+		{"", false, true},
+		{models.PrivacySettingEveryone, false, true},
+		{models.PrivacySettingFriends, true, true},
+		{models.PrivacySettingFriends, false, false},
+		{models.PrivacySettingNoOne, true, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, models.PresenceVisibleTo(tt.visibility, tt.isFriend), "%q, friend %v", tt.visibility, tt.isFriend)
+	}
+}
+
+func TestUserService_PresenceVisibleTo_WithoutPrivacy(t *testing.T) {
+	s := &UserService{}
+	ids := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}
+
+	visible, err := s.presenceVisibleTo(context.Background(), primitive.NewObjectID(), ids)
+	require.NoError(t, err)
+	assert.True(t, visible[ids[0]])
+	assert.True(t, visible[ids[1]])
+}
+
+func TestPrivacyService_UpdateRejectsUnknownPresenceVisibility(t *testing.T) {
+	// Checked before the repository is touched
+	s := NewPrivacyService(nil, nil)
+	_, err := s.UpdateUserPrivacySettings(context.Background(), primitive.NewObjectID(), &models.UpdatePrivacySettingsRequest{
+		PresenceVisibility: models.PrivacySettingFriendsOfFriends,
+	})
+	assert.ErrorIs(t, err, ErrInvalidPresenceVisibility)
+}
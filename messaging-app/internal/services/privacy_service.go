@@ -9,10 +9,13 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"messaging-app/internal/repositories"
 )
 
+var ErrInvalidPresenceVisibility = apperrors.Validation("presence_visibility must be EVERYONE, FRIENDS or NO_ONE")
+
 // PrivacyService provides business logic for privacy-related operations
 type PrivacyService struct {
 	privacyRepo repositories.PrivacyRepository
@@ -45,6 +48,7 @@ func (s *PrivacyService) GetUserPrivacySettings(ctx context.Context, userID prim
 			CanSeeMyFriendsList:     models.PrivacySettingFriends,
 			CanSendMeFriendRequests: models.PrivacySettingEveryone,
 			CanTagMeInPosts:         models.PrivacySettingFriends,
+			PresenceVisibility:      models.PrivacySettingEveryone,
 			LastUpdated:             time.Now(),
 		}, nil
 	}
@@ -54,6 +58,10 @@ func (s *PrivacyService) GetUserPrivacySettings(ctx context.Context, userID prim
 
 // UpdateUserPrivacySettings updates a user's privacy settings
 func (s *PrivacyService) UpdateUserPrivacySettings(ctx context.Context, userID primitive.ObjectID, req *models.UpdatePrivacySettingsRequest) (*models.UserPrivacySettings, error) {
+	if req.PresenceVisibility != "" && !models.IsValidPresenceVisibility(req.PresenceVisibility) {
+		return nil, ErrInvalidPresenceVisibility
+	}
+
 	settings, err := s.privacyRepo.GetUserPrivacySettings(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user privacy settings: %w", err)
@@ -83,6 +91,15 @@ func (s *PrivacyService) UpdateUserPrivacySettings(ctx context.Context, userID p
 	if req.CanTagMeInPosts != "" {
 		settings.CanTagMeInPosts = req.CanTagMeInPosts
 	}
+	if req.PresenceVisibility != "" {
+		settings.PresenceVisibility = req.PresenceVisibility
+	}
+	if req.ReadReceiptsOff != nil {
+		settings.ReadReceiptsOff = *req.ReadReceiptsOff
+	}
+	if req.TypingIndicatorsOff != nil {
+		settings.TypingIndicatorsOff = *req.TypingIndicatorsOff
+	}
 	settings.LastUpdated = time.Now()
 
 	if err := s.privacyRepo.CreateOrUpdateUserPrivacySettings(ctx, settings); err != nil {
@@ -92,6 +109,44 @@ func (s *PrivacyService) UpdateUserPrivacySettings(ctx context.Context, userID p
 	return settings, nil
 }
 
+// PresenceVisibilities returns who each user shares their presence with,
+// EVERYONE for users who have not chosen
+func (s *PrivacyService) PresenceVisibilities(ctx context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]models.PrivacySettingType, error) {
+	settings, err := s.privacyRepo.GetUserPrivacySettingsByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get presence visibility: %w", err)
+	}
+
+	visibilities := make(map[primitive.ObjectID]models.PrivacySettingType, len(userIDs))
+	for _, id := range userIDs {
+		visibilities[id] = models.PrivacySettingEveryone
+	}
+	for _, setting := range settings {
+		if setting.PresenceVisibility != "" {
+			visibilities[setting.UserID] = setting.PresenceVisibility
+		}
+	}
+	return visibilities, nil
+}
+
+// MessagingPrivacy returns whether each user shares read receipts and typing
+// indicators; users who have not chosen share both
+func (s *PrivacyService) MessagingPrivacy(ctx context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]models.MessagingPrivacy, error) {
+	settings, err := s.privacyRepo.GetUserPrivacySettingsByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messaging privacy: %w", err)
+	}
+
+	privacy := make(map[primitive.ObjectID]models.MessagingPrivacy, len(userIDs))
+	for _, id := range userIDs {
+		privacy[id] = models.DefaultMessagingPrivacy()
+	}
+	for i := range settings {
+		privacy[settings[i].UserID] = settings[i].MessagingPrivacy()
+	}
+	return privacy, nil
+}
+
 // CreateCustomPrivacyList creates a new custom privacy list for a user
 func (s *PrivacyService) CreateCustomPrivacyList(ctx context.Context, userID primitive.ObjectID, req *models.CreateCustomPrivacyListRequest) (*models.CustomPrivacyList, error) {
 	// Ensure all members are valid users
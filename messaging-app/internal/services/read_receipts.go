@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReceiptPrivacy knows whether users share read receipts and typing
+// indicators
+type ReceiptPrivacy interface {
+	MessagingPrivacy(ctx context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]models.MessagingPrivacy, error)
+}
+
+// SetReceiptPrivacy applies users' read receipt settings to the seen_by of
+// the messages they list. Without it every reader is shown.
+func (s *MessageService) SetReceiptPrivacy(privacy ReceiptPrivacy) {
+	s.receiptPrivacy = privacy
+}
+
+// hideReadReceipts trims each message's seen_by to the readers viewerID may
+// see: themselves, the sender, and readers sharing receipts when the viewer
+// shares theirs too. If the settings cannot be looked up, only the first two
+// are kept.
+func (s *MessageService) hideReadReceipts(ctx context.Context, viewerID primitive.ObjectID, messages []models.Message) {
+	if s.receiptPrivacy == nil {
+		return
+	}
+
+	seen := make(map[primitive.ObjectID]bool)
+	ids := []primitive.ObjectID{viewerID}
+	for _, msg := range messages {
+		for _, id := range msg.SeenBy {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	privacy, err := s.receiptPrivacy.MessagingPrivacy(ctx, ids)
+	if err != nil {
+		log.Printf("Failed to get read receipt settings, hiding readers: %v", err)
+		privacy = nil
+	}
+	for i := range messages {
+		messages[i].SeenBy = visibleReaders(messages[i], viewerID, privacy)
+	}
+}
+
+// visibleReaders returns the readers of msg viewerID may see given everyone's
+// messaging privacy; readers missing from privacy are hidden
+func visibleReaders(msg models.Message, viewerID primitive.ObjectID, privacy map[primitive.ObjectID]models.MessagingPrivacy) []primitive.ObjectID {
+	readers := make([]primitive.ObjectID, 0, len(msg.SeenBy))
+	viewer, viewerKnown := privacy[viewerID]
+	for _, id := range msg.SeenBy {
+		if id == viewerID || id == msg.SenderID {
+			readers = append(readers, id)
+			continue
+		}
+		reader, ok := privacy[id]
+		if ok && viewerKnown && models.ReadReceiptsShared(reader, viewer) {
+			readers = append(readers, id)
+		}
+	}
+	return readers
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestVisibleReaders(t *testing.T) {
+	viewer, sender, sharer, hider := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	msg := models.Message{SenderID: sender, SeenBy: []primitive.ObjectID{sender, viewer, sharer, hider}}
+	shares, hides := models.DefaultMessagingPrivacy(), models.MessagingPrivacy{TypingIndicators: true}
+
+	t.Run("readers hiding receipts are left out", func(t *testing.T) {
+		privacy := map[primitive.ObjectID]models.MessagingPrivacy{viewer: shares, sharer: shares, hider: hides}
+		assert.Equal(t, []primitive.ObjectID{sender, viewer, sharer}, visibleReaders(msg, viewer, privacy))
+	})
+
+	t.Run("viewers hiding receipts see only themselves and the sender", func(t *testing.T) {
+		privacy := map[primitive.ObjectID]models.MessagingPrivacy{viewer: hides, sharer: shares, hider: hides}
+		assert.Equal(t, []primitive.ObjectID{sender, viewer}, visibleReaders(msg, viewer, privacy))
+	})
+
+	t.Run("unknown settings hide other readers", func(t *testing.T) {
+		assert.Equal(t, []primitive.ObjectID{sender, viewer}, visibleReaders(msg, viewer, nil))
+	})
+}
@@ -56,6 +56,7 @@ func (s *ReelService) CreateReel(ctx context.Context, userID primitive.ObjectID,
 			Username: user.Username,
 			Avatar:   user.Avatar,
 			FullName: user.FullName,
+			Verified: user.Verified,
 		},
 	}
 
@@ -141,6 +142,7 @@ func (s *ReelService) AddComment(ctx context.Context, reelID primitive.ObjectID,
 			Username: user.Username,
 			Avatar:   user.Avatar,
 			FullName: user.FullName,
+			Verified: user.Verified,
 		},
 		Mentions:  finalMentions,
 		CreatedAt: time.Now(),
@@ -185,6 +187,7 @@ func (s *ReelService) AddReply(ctx context.Context, reelID primitive.ObjectID, c
 			Username: user.Username,
 			Avatar:   user.Avatar,
 			FullName: user.FullName,
+			Verified: user.Verified,
 		},
 		Mentions:  mentions,
 		Content:   content,
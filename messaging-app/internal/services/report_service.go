@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"messaging-app/internal/repositories"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/middleware"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrReportTargetNotFound = apperrors.NotFound("reported content not found")
+	ErrCannotReportSelf     = apperrors.Validation("you cannot report yourself or your own content")
+	ErrInvalidReportAction  = apperrors.Validation("user reports can only be dismissed or lead to a suspension")
+	ErrListingsUnavailable  = apperrors.Unavailable("marketplace is unavailable")
+)
+
+// DefaultSuspendDays is how long a suspension lasts when the admin does not say
+const DefaultSuspendDays = 7
+
+// ListingStore reads and removes marketplace listings for reports
+type ListingStore interface {
+	GetProduct(ctx context.Context, productID, viewerID primitive.ObjectID) (*models.ProductResponse, error)
+	DeleteProduct(ctx context.Context, productID, userID primitive.ObjectID) error
+}
+
+// ReportService takes user reports about posts, messages, users and listings
+// and carries out admins' decisions on them. Every decision is written to an
+// audit log.
+type ReportService struct {
+	reportRepo     *repositories.ReportRepository
+	userRepo       *repositories.UserRepository
+	feedService    *FeedService
+	messageService *MessageService
+	listings       ListingStore
+	redisClient    *redis.ClusterClient
+	audit          *audit.Logger
+}
+
+func NewReportService(
+	reportRepo *repositories.ReportRepository,
+	userRepo *repositories.UserRepository,
+	feedService *FeedService,
+	messageService *MessageService,
+	redisClient *redis.ClusterClient,
+) *ReportService {
+	return &ReportService{
+		reportRepo:     reportRepo,
+		userRepo:       userRepo,
+		feedService:    feedService,
+		messageService: messageService,
+		redisClient:    redisClient,
+	}
+}
+
+// SetListingStore enables reporting marketplace listings
+func (s *ReportService) SetListingStore(listings ListingStore) {
+	s.listings = listings
+}
+
+// SetAuditLogger enables recording admins' decisions in the platform audit log
+func (s *ReportService) SetAuditLogger(logger *audit.Logger) {
+	s.audit = logger
+}
+
+// CreateReport files a report. Reporters can only report what they can see.
+func (s *ReportService) CreateReport(ctx context.Context, reporterID primitive.ObjectID, req models.CreateReportRequest) (*models.Report, error) {
+	report := &models.Report{
+		ReporterID: reporterID,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		Reason:     req.Reason,
+		Details:    req.Details,
+		Evidence:   req.Evidence,
+	}
+
+	ownerID, err := s.resolveTarget(ctx, reporterID, report, req.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID == reporterID {
+		return nil, ErrCannotReportSelf
+	}
+	report.TargetOwnerID = ownerID
+
+	if err := s.reportRepo.Create(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// resolveTarget checks the reporter can see the target and returns its owner
+func (s *ReportService) resolveTarget(ctx context.Context, reporterID primitive.ObjectID, report *models.Report, conversationID string) (primitive.ObjectID, error) {
+	switch report.TargetType {
+	case models.ReportTargetPost:
+		postID, err := primitive.ObjectIDFromHex(report.TargetID)
+		if err != nil {
+			return primitive.NilObjectID, ErrReportTargetNotFound
+		}
+		// Don't reveal whether a post the reporter cannot see exists
+		post, err := s.feedService.GetPostByID(ctx, reporterID, postID)
+		if err != nil {
+			return primitive.NilObjectID, ErrReportTargetNotFound
+		}
+		return post.UserID, nil
+
+	case models.ReportTargetMessage:
+		msg, convKey, err := s.messageService.ReportedMessage(ctx, reporterID, conversationID, report.TargetID)
+		if err != nil {
+			return primitive.NilObjectID, err
+		}
+		report.ConversationID = convKey
+		return msg.SenderID, nil
+
+	case models.ReportTargetUser:
+		userID, err := primitive.ObjectIDFromHex(report.TargetID)
+		if err != nil {
+			return primitive.NilObjectID, ErrReportTargetNotFound
+		}
+		if _, err := s.userRepo.FindUserByID(ctx, userID); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return primitive.NilObjectID, ErrReportTargetNotFound
+			}
+			return primitive.NilObjectID, err
+		}
+		return userID, nil
+
+	case models.ReportTargetListing:
+		if s.listings == nil {
+			return primitive.NilObjectID, ErrListingsUnavailable
+		}
+		productID, err := primitive.ObjectIDFromHex(report.TargetID)
+		if err != nil {
+			return primitive.NilObjectID, ErrReportTargetNotFound
+		}
+		product, err := s.listings.GetProduct(ctx, productID, reporterID)
+		if err != nil {
+			return primitive.NilObjectID, ErrReportTargetNotFound
+		}
+		return product.Seller.ID, nil
+	}
+	return primitive.NilObjectID, ErrReportTargetNotFound
+}
+
+// ListMyReports pages through the reports a user filed
+func (s *ReportService) ListMyReports(ctx context.Context, reporterID primitive.ObjectID, page, limit int64) ([]models.Report, int64, error) {
+	return s.reportRepo.List(ctx, repositories.ReportFilter{ReporterID: &reporterID}, page, limit)
+}
+
+// ListReports pages through the admin review queue
+func (s *ReportService) ListReports(ctx context.Context, filter repositories.ReportFilter, page, limit int64) ([]models.Report, int64, error) {
+	return s.reportRepo.List(ctx, filter, page, limit)
+}
+
+// GetReport returns a report with its audit trail
+func (s *ReportService) GetReport(ctx context.Context, reportID primitive.ObjectID) (*models.ReportDetail, error) {
+	report, err := s.reportRepo.GetByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	audit, err := s.reportRepo.ListAuditEntries(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReportDetail{Report: *report, Audit: audit}, nil
+}
+
+// ReviewReport carries out an admin's decision and records it. The action is
+// applied before the report is closed, so a failure leaves it open to retry.
+func (s *ReportService) ReviewReport(ctx context.Context, adminID, reportID primitive.ObjectID, req models.ReviewReportRequest) (*models.ReportDetail, error) {
+	report, err := s.reportRepo.GetByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.Status != models.ReportStatusOpen {
+		return nil, repositories.ErrReportResolved
+	}
+
+	entry := &models.ReportAuditEntry{
+		ReportID: report.ID,
+		ActorID:  adminID,
+		Action:   req.Action,
+		Note:     req.Note,
+	}
+	status := models.ReportStatusActioned
+	switch req.Action {
+	case models.ReportActionDismiss:
+		status = models.ReportStatusDismissed
+	case models.ReportActionRemoveContent:
+		if err := s.removeContent(ctx, adminID, report); err != nil {
+			return nil, err
+		}
+	case models.ReportActionSuspendUser:
+		days := req.SuspendDays
+		if days == 0 {
+			days = DefaultSuspendDays
+		}
+		until := time.Now().Add(time.Duration(days) * 24 * time.Hour)
+		if err := s.suspendUser(ctx, report.TargetOwnerID, until); err != nil {
+			return nil, err
+		}
+		entry.SuspendedUntil = &until
+	}
+
+	resolved, err := s.reportRepo.Resolve(ctx, report.ID, adminID, status, req.Action, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reportRepo.AddAuditEntry(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to write report audit log: %w", err)
+	}
+	s.auditReview(ctx, adminID, report, entry)
+	return s.GetReport(ctx, resolved.ID)
+}
+
+// auditReview mirrors a decision into the platform audit log, with content
+// removals recorded against the content itself
+func (s *ReportService) auditReview(ctx context.Context, adminID primitive.ObjectID, report *models.Report, decision *models.ReportAuditEntry) {
+	metadata := map[string]string{
+		"action":      string(decision.Action),
+		"target_type": string(report.TargetType),
+		"target_id":   report.TargetID,
+	}
+	if decision.Note != "" {
+		metadata["note"] = decision.Note
+	}
+	if decision.SuspendedUntil != nil {
+		metadata["suspended_until"] = decision.SuspendedUntil.Format(time.RFC3339)
+	}
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditActionReportReviewed,
+		ActorID:    &adminID,
+		TargetType: "report",
+		TargetID:   report.ID.Hex(),
+		Metadata:   metadata,
+	})
+
+	if decision.Action == models.ReportActionRemoveContent {
+		s.audit.Record(ctx, models.AuditEntry{
+			Action:     models.AuditActionContentRemoved,
+			ActorID:    &adminID,
+			TargetType: string(report.TargetType),
+			TargetID:   report.TargetID,
+			Metadata:   map[string]string{"report_id": report.ID.Hex(), "owner_id": report.TargetOwnerID.Hex()},
+		})
+	}
+}
+
+// removeContent takes the reported content down through the service owning it
+func (s *ReportService) removeContent(ctx context.Context, adminID primitive.ObjectID, report *models.Report) error {
+	switch report.TargetType {
+	case models.ReportTargetPost:
+		return s.feedService.RemoveContent(ctx, &models.ModerationQueueItem{
+			ContentType: models.ModeratedPost,
+			ContentID:   report.TargetID,
+			AuthorID:    report.TargetOwnerID,
+		})
+	case models.ReportTargetMessage:
+		return s.messageService.RemoveContent(ctx, &models.ModerationQueueItem{
+			ContentType: models.ModeratedMessage,
+			ContentID:   report.TargetID,
+			ParentID:    report.ConversationID,
+			AuthorID:    report.TargetOwnerID,
+			ReviewedBy:  &adminID,
+		})
+	case models.ReportTargetListing:
+		if s.listings == nil {
+			return ErrListingsUnavailable
+		}
+		productID, err := primitive.ObjectIDFromHex(report.TargetID)
+		if err != nil {
+			return ErrReportTargetNotFound
+		}
+		// The marketplace only lets sellers delete listings
+		return s.listings.DeleteProduct(ctx, productID, report.TargetOwnerID)
+	}
+	return ErrInvalidReportAction
+}
+
+// suspendUser keeps the user from signing in until the suspension ends and
+// shuts out the tokens they already hold
+func (s *ReportService) suspendUser(ctx context.Context, userID primitive.ObjectID, until time.Time) error {
+	if _, err := s.userRepo.UpdateUser(ctx, userID, bson.M{"suspended_until": until}); err != nil {
+		return fmt.Errorf("failed to suspend user: %w", err)
+	}
+	if err := s.redisClient.Set(ctx, middleware.SuspendedUserKey(userID.Hex()), "1", time.Until(until)).Err(); err != nil {
+		return fmt.Errorf("failed to revoke tokens of suspended user: %w", err)
+	}
+	if err := s.redisClient.Del(ctx, "refresh:"+userID.Hex()).Err(); err != nil {
+		log.Printf("Failed to drop refresh token of suspended user %s: %v", userID.Hex(), err)
+	}
+	return nil
+}
+
+// ReportedMessage loads a message a participant of its conversation reports,
+// returning it with its conversation key
+func (s *MessageService) ReportedMessage(ctx context.Context, reporterID primitive.ObjectID, conversationID, messageID string) (*models.Message, string, error) {
+	convKey, err := s.normalizeConversationKey(reporterID, conversationID, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidConversationID, err)
+	}
+	if !s.isParticipant(ctx, reporterID, convKey) {
+		return nil, "", ErrNotConversationParticipant
+	}
+	msg, err := s.messageCassandraRepo.GetMessage(ctx, convKey, messageID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrMessageNotFound) {
+			return nil, "", ErrReportTargetNotFound
+		}
+		return nil, "", err
+	}
+	return msg, convKey, nil
+}
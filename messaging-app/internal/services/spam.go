@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"messaging-app/internal/antispam"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// maxFlaggedSenders bounds the flagged senders listed for admins
+	maxFlaggedSenders = 500
+	// maxDeliveredRequestMessages bounds the held back messages delivered
+	// when a message request is accepted
+	maxDeliveredRequestMessages = 50
+)
+
+var (
+	ErrSpamThrottled             = apperrors.New(apperrors.KindRateLimited, "you are sending messages too quickly, try again later")
+	ErrSpamGuardDisabled         = apperrors.Unavailable("spam detection is not enabled")
+	ErrSpamSenderNotFlagged      = apperrors.NotFound("user is not flagged or throttled for spam")
+	ErrNoPendingMessageRequest   = apperrors.NotFound("no pending message request for this conversation")
+	ErrMessageRequestsDirectOnly = apperrors.Validation("message requests are only for direct conversations")
+)
+
+// SetSpamGuard scores senders on every message, throttles those who look
+// like spammers and routes the direct messages of flagged senders to the
+// receivers' message requests
+func (s *MessageService) SetSpamGuard(guard *antispam.Guard) {
+	s.spam = guard
+}
+
+// screenSpam records a message with the spam guard. It refuses the message
+// of a throttled sender over their rate and reports whether the sender is
+// flagged. The message goes through when the guard fails.
+func (s *MessageService) screenSpam(ctx context.Context, msg *models.Message, convKey string) (bool, error) {
+	if s.spam == nil {
+		return false, nil
+	}
+	content := msg.Content
+	if msg.IsEncrypted {
+		content = ""
+	}
+	verdict, err := s.spam.Check(ctx, msg.SenderID.Hex(), convKey, content)
+	if err != nil {
+		log.Printf("Spam check failed for sender %s: %v", msg.SenderID.Hex(), err)
+		return false, nil
+	}
+	if verdict.Blocked {
+		return false, ErrSpamThrottled
+	}
+	return verdict.Flagged, nil
+}
+
+// requestNeeded reports whether a flagged sender's direct message goes to the
+// receiver's message requests, which it does until the receiver accepts them
+func (s *MessageService) requestNeeded(ctx context.Context, receiverID primitive.ObjectID, convKey string) bool {
+	request, err := s.messageCassandraRepo.GetConversationRequest(ctx, receiverID.Hex(), convKey)
+	if err != nil {
+		log.Printf("Failed to look up message request of %s for %s: %v", convKey, receiverID.Hex(), err)
+		return true
+	}
+	return request == nil || !request.Accepted
+}
+
+// fileRequest holds a saved direct message's conversation in the receiver's
+// message requests. Request messages are not sent to the receiver live; they
+// are delivered once the request is accepted.
+func (s *MessageService) fileRequest(ctx context.Context, msg *models.Message, convKey string) {
+	s.spam.RecordRequest()
+	_, err := s.messageCassandraRepo.CreateConversationRequest(ctx, msg.ReceiverID.Hex(), models.ConversationRequest{
+		ConversationID: convKey,
+		SenderID:       msg.SenderID.Hex(),
+		CreatedAt:      msg.CreatedAt,
+	})
+	if err != nil {
+		log.Printf("Failed to file message request of %s for %s: %v", convKey, msg.ReceiverID.Hex(), err)
+	}
+}
+
+// AcceptMessageRequest moves a conversation from the user's message requests
+// to their inbox and delivers the messages held back while it was pending;
+// later messages of the sender go straight to the inbox
+func (s *MessageService) AcceptMessageRequest(ctx context.Context, userID primitive.ObjectID, conversationID string) (*models.ConversationRequest, error) {
+	convKey, request, err := s.pendingRequest(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.messageCassandraRepo.AcceptConversationRequest(ctx, userID.Hex(), convKey); err != nil {
+		return nil, err
+	}
+	request.Accepted = true
+	s.deliverRequestMessages(ctx, userID, convKey, request)
+	return request, nil
+}
+
+// deliverRequestMessages sends the user the messages of an accepted request,
+// oldest first, as if they had just arrived. Delivery is best effort; the
+// messages are in the conversation's history either way.
+func (s *MessageService) deliverRequestMessages(ctx context.Context, userID primitive.ObjectID, convKey string, request *models.ConversationRequest) {
+	messages, err := s.messageCassandraRepo.GetMessages(ctx, models.MessageQuery{
+		ConversationID: convKey,
+		Limit:          maxDeliveredRequestMessages,
+	})
+	if err != nil {
+		log.Printf("Failed to load request messages of %s for %s: %v", convKey, userID.Hex(), err)
+		return
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		msg := messages[i]
+		if msg.SenderID.Hex() != request.SenderID || msg.ReceiverID != userID || msg.CreatedAt.Before(request.CreatedAt) {
+			continue
+		}
+		s.publishToHub(ctx, msg)
+	}
+}
+
+// DeclineMessageRequest takes a conversation out of the user's message
+// requests and archives it
+func (s *MessageService) DeclineMessageRequest(ctx context.Context, userID primitive.ObjectID, conversationID string) error {
+	convKey, _, err := s.pendingRequest(ctx, userID, conversationID)
+	if err != nil {
+		return err
+	}
+	if err := s.messageCassandraRepo.DeleteConversationRequest(ctx, userID.Hex(), convKey); err != nil {
+		return err
+	}
+	archived := true
+	_, err = s.UpdateConversationFlags(ctx, userID, convKey, false, models.UpdateConversationFlagsRequest{Archived: &archived})
+	return err
+}
+
+func (s *MessageService) pendingRequest(ctx context.Context, userID primitive.ObjectID, conversationID string) (string, *models.ConversationRequest, error) {
+	convKey, err := s.ConversationKey(ctx, userID, conversationID, false)
+	if err != nil {
+		return "", nil, err
+	}
+	if !strings.HasPrefix(convKey, "dm_") {
+		return "", nil, ErrMessageRequestsDirectOnly
+	}
+	request, err := s.messageCassandraRepo.GetConversationRequest(ctx, userID.Hex(), convKey)
+	if err != nil {
+		return "", nil, err
+	}
+	if request == nil || request.Accepted {
+		return "", nil, ErrNoPendingMessageRequest
+	}
+	return convKey, request, nil
+}
+
+// FlaggedSpamSenders lists the senders currently flagged for spam
+func (s *MessageService) FlaggedSpamSenders(ctx context.Context) ([]antispam.FlaggedSender, error) {
+	if s.spam == nil {
+		return nil, ErrSpamGuardDisabled
+	}
+	return s.spam.FlaggedSenders(ctx, maxFlaggedSenders)
+}
+
+// ClearSpamSender lifts a sender's spam flag and throttle
+func (s *MessageService) ClearSpamSender(ctx context.Context, userID primitive.ObjectID) error {
+	if s.spam == nil {
+		return ErrSpamGuardDisabled
+	}
+	cleared, err := s.spam.Clear(ctx, userID.Hex())
+	if err != nil {
+		return err
+	}
+	if !cleared {
+		return ErrSpamSenderNotFlagged
+	}
+	return nil
+}
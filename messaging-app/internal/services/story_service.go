@@ -48,6 +48,7 @@ func (s *StoryService) CreateStory(ctx context.Context, userID primitive.ObjectI
 			Username: user.Username,
 			Avatar:   user.Avatar,
 			FullName: user.FullName,
+			Verified: user.Verified,
 		},
 	}
 
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"messaging-app/internal/repositories"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const deletionMessagePageSize = 500
+
+// UserDeletionService erases what the messaging app stores about an account
+// that was deleted
+type UserDeletionService struct {
+	messages          *MessageService
+	groups            *GroupService
+	notificationRepo  *repositories.NotificationRepository
+	deviceTokenRepo   *repositories.DeviceTokenRepository
+	notificationPrefs *repositories.NotificationPreferencesRepository
+	watchHistory      *WatchHistoryService
+	calls             *CallService
+	exports           *ConversationExportService
+	mentions          *MentionService
+}
+
+func NewUserDeletionService(messages *MessageService, groups *GroupService, notificationRepo *repositories.NotificationRepository, deviceTokenRepo *repositories.DeviceTokenRepository, notificationPrefs *repositories.NotificationPreferencesRepository, watchHistory *WatchHistoryService, calls *CallService, exports *ConversationExportService, mentions *MentionService) *UserDeletionService {
+	return &UserDeletionService{
+		messages:          messages,
+		groups:            groups,
+		notificationRepo:  notificationRepo,
+		deviceTokenRepo:   deviceTokenRepo,
+		notificationPrefs: notificationPrefs,
+		watchHistory:      watchHistory,
+		calls:             calls,
+		exports:           exports,
+		mentions:          mentions,
+	}
+}
+
+// DeleteUserData removes the messages and drafts the user wrote and their
+// inbox flags, takes them out of their groups and drops their notifications,
+// devices, notification settings, watch history, call history and
+// conversation exports, and takes them out of @mention suggestions
+func (s *UserDeletionService) DeleteUserData(ctx context.Context, userID string) (int64, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, errors.New("invalid user ID")
+	}
+
+	total, err := s.messages.DeleteUserMessages(ctx, uID)
+	if err != nil {
+		return total, fmt.Errorf("failed to delete messages: %w", err)
+	}
+	drafts, err := s.messages.DeleteUserDrafts(ctx, uID)
+	total += drafts
+	if err != nil {
+		return total, fmt.Errorf("failed to delete drafts: %w", err)
+	}
+	flags, err := s.messages.DeleteUserConversationFlags(ctx, uID)
+	total += flags
+	if err != nil {
+		return total, fmt.Errorf("failed to delete conversation flags: %w", err)
+	}
+	left, err := s.groups.RemoveDeletedUser(ctx, uID)
+	total += left
+	if err != nil {
+		return total, fmt.Errorf("failed to leave groups: %w", err)
+	}
+	notifications, err := s.notificationRepo.DeleteByUser(ctx, uID)
+	total += notifications
+	if err != nil {
+		return total, fmt.Errorf("failed to delete notifications: %w", err)
+	}
+	devices, err := s.deviceTokenRepo.DeleteByUser(ctx, uID)
+	total += devices
+	if err != nil {
+		return total, fmt.Errorf("failed to delete device tokens: %w", err)
+	}
+	watched, err := s.watchHistory.DeleteUserData(ctx, uID)
+	total += watched
+	if err != nil {
+		return total, fmt.Errorf("failed to delete watch history: %w", err)
+	}
+	calls, err := s.calls.DeleteUserData(ctx, uID)
+	total += calls
+	if err != nil {
+		return total, fmt.Errorf("failed to delete call history: %w", err)
+	}
+	exports, err := s.exports.DeleteUserData(ctx, uID)
+	total += exports
+	if err != nil {
+		return total, fmt.Errorf("failed to delete conversation exports: %w", err)
+	}
+	if err := s.mentions.RemoveUser(ctx, uID); err != nil {
+		return total, fmt.Errorf("failed to remove user from mention index: %w", err)
+	}
+	removed, err := s.notificationPrefs.Delete(ctx, uID)
+	if err != nil {
+		return total, fmt.Errorf("failed to delete notification preferences: %w", err)
+	}
+	if removed {
+		total++
+	}
+	return total, nil
+}
+
+// DeleteUserMessages deletes every message the user sent in their direct,
+// group and marketplace conversations, the same way a sender deleting them
+// one by one would
+func (s *MessageService) DeleteUserMessages(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	var deleted int64
+	for _, marketplace := range []bool{false, true} {
+		conversations, err := s.messageCassandraRepo.GetInbox(ctx, userID, marketplace)
+		if err != nil {
+			return deleted, err
+		}
+		for _, c := range conversations {
+			isGroup := c.IsGroup
+			convKey, err := s.normalizeConversationKey(userID, c.ID, &isGroup)
+			if err != nil {
+				log.Printf("Skipping conversation %s of deleted user %s: %v", c.ID, userID.Hex(), err)
+				continue
+			}
+			n, err := s.deleteSentMessages(ctx, convKey, userID)
+			deleted += n
+			if err != nil {
+				return deleted, fmt.Errorf("conversation %s: %w", convKey, err)
+			}
+		}
+	}
+	return deleted, nil
+}
+
+// deleteSentMessages pages through a conversation, newest first, deleting
+// the messages the user sent
+func (s *MessageService) deleteSentMessages(ctx context.Context, convKey string, userID primitive.ObjectID) (int64, error) {
+	var deleted int64
+	query := models.MessageQuery{ConversationID: convKey, Limit: deletionMessagePageSize}
+	for {
+		page, err := s.messageCassandraRepo.GetMessages(ctx, query)
+		if err != nil {
+			return deleted, err
+		}
+		for _, m := range page {
+			if m.SenderID != userID || m.IsDeleted {
+				continue
+			}
+			if err := s.removeMessage(ctx, convKey, m.StringID, userID); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+		if len(page) < deletionMessagePageSize {
+			return deleted, nil
+		}
+		query.Before = page[len(page)-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+}
+
+// RemoveDeletedUser takes a deleted account out of every group it belonged
+// to or asked to join. A group it owned passes to one of its admins, or to
+// another member when it has none.
+func (s *GroupService) RemoveDeletedUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	groups, err := s.groupRepo.GetUserGroups(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int64
+	for _, group := range groups {
+		if group.Owner() == userID {
+			if successor, ok := groupSuccessor(group, userID); ok {
+				if err := s.groupRepo.TransferOwnership(ctx, group.ID, userID, successor); err != nil {
+					return removed, err
+				}
+			}
+		}
+		if err := s.groupRepo.RemoveMember(ctx, group.ID, userID); err != nil {
+			return removed, err
+		}
+		if s.groupGraphRepo != nil {
+			go s.groupGraphRepo.RemoveMember(context.Background(), userID, group.ID)
+		}
+		s.invalidateMembershipCache(ctx, group.ID)
+		if err := s.publishGroupEvent(ctx, group.ID, "GROUP_UPDATED"); err != nil {
+			log.Printf("Failed to publish update of group %s: %v", group.ID.Hex(), err)
+		}
+		removed++
+	}
+
+	pending, err := s.groupRepo.RemovePendingUser(ctx, userID)
+	return removed + pending, err
+}
+
+// groupSuccessor picks who takes over a group from its leaving owner
+func groupSuccessor(group *models.Group, ownerID primitive.ObjectID) (primitive.ObjectID, bool) {
+	for _, candidates := range [][]primitive.ObjectID{group.Admins, group.Members} {
+		for _, id := range candidates {
+			if id != ownerID && containsID(group.Members, id) {
+				return id, true
+			}
+		}
+	}
+	return primitive.NilObjectID, false
+}
@@ -31,6 +31,9 @@ type UserService struct {
 
 	// New gRPC Client
 	grpcClient *userclient.Client
+
+	presencePrivacy *PrivacyService
+	friendshipRepo  *repositories.FriendshipRepository
 }
 
 func NewUserService(
@@ -60,23 +63,13 @@ type UserUpdatedEvent struct {
 	DateOfBirth *time.Time `json:"date_of_birth,omitempty"`
 }
 
-func (s *UserService) GetUserStatus(ctx context.Context, userID primitive.ObjectID) (map[string]interface{}, error) {
-	// ... (Keep existing implementation for Redis presence)
-	key := fmt.Sprintf("presence:%s", userID.Hex())
-	val, err := s.redisClient.Get(ctx, key).Result()
+// GetUserStatus returns the user's presence as viewerID may see it
+func (s *UserService) GetUserStatus(ctx context.Context, viewerID, userID primitive.ObjectID) (models.Presence, error) {
+	presence, err := s.GetUsersPresence(ctx, viewerID, []primitive.ObjectID{userID})
 	if err != nil {
-		if err == redis.Nil {
-			return map[string]interface{}{"status": "offline"}, nil
-		}
-		return nil, err
-	}
-
-	var statusData map[string]interface{}
-	if err := json.Unmarshal([]byte(val), &statusData); err != nil {
-		return nil, err
+		return models.Presence{}, err
 	}
-
-	return statusData, nil
+	return presence[userID.Hex()], nil
 }
 
 // GetUserByID now with Read-Through Caching
@@ -347,6 +340,7 @@ func (s *UserService) UpdateUser(ctx context.Context, id primitive.ObjectID, upd
 			FullName:    updatedUser.FullName,
 			Avatar:      updatedUser.Avatar,
 			DateOfBirth: updatedUser.DateOfBirth,
+			Verified:    updatedUser.Verified,
 		}
 
 		eventBytes, err := json.Marshal(event)
@@ -377,6 +371,7 @@ func (s *UserService) UpdateUser(ctx context.Context, id primitive.ObjectID, upd
 				Username: updatedUser.Username,
 				Avatar:   updatedUser.Avatar,
 				FullName: updatedUser.FullName,
+				Verified: updatedUser.Verified,
 			}
 			// We use a background context or a new one
 			if err := s.reelRepo.UpdateAuthorInfo(context.Background(), id, author); err != nil {
@@ -524,9 +519,18 @@ func (s *UserService) UpdatePublicKey(ctx context.Context, userID primitive.Obje
 	return nil
 }
 
-// GetUsersPresence retrieves the presence status for a list of user IDs
-func (s *UserService) GetUsersPresence(ctx context.Context, userIDs []primitive.ObjectID) (map[string]map[string]interface{}, error) {
-	presenceMap := make(map[string]map[string]interface{})
+// GetUsersPresence retrieves the presence status for a list of user IDs, as
+// viewerID may see it
+func (s *UserService) GetUsersPresence(ctx context.Context, viewerID primitive.ObjectID, userIDs []primitive.ObjectID) (map[string]models.Presence, error) {
+	presenceMap := make(map[string]models.Presence, len(userIDs))
+	if len(userIDs) == 0 {
+		return presenceMap, nil
+	}
+
+	visible, err := s.presenceVisibleTo(ctx, viewerID, userIDs)
+	if err != nil {
+		return nil, err
+	}
 
 	keys := make([]string, len(userIDs))
 	for i, userID := range userIDs {
@@ -539,21 +543,14 @@ func (s *UserService) GetUsersPresence(ctx context.Context, userIDs []primitive.
 		return nil, fmt.Errorf("failed to get presence from Redis: %w", err)
 	}
 
+	now := time.Now()
 	for i, userID := range userIDs {
-		var statusData map[string]interface{}
-		val := vals[i]
-
-		if val == nil {
-			// Key not found, assume offline
-			statusData = map[string]interface{}{"status": "offline", "last_seen": time.Now().Unix()}
-		} else {
-			if err := json.Unmarshal([]byte(val.(string)), &statusData); err != nil {
-				// Error unmarshaling, assume offline and log error
-				log.Printf("Error unmarshaling presence data for user %s: %v", userID.Hex(), err)
-				statusData = map[string]interface{}{"status": "offline", "last_seen": time.Now().Unix()}
-			}
+		if !visible[userID] {
+			presenceMap[userID.Hex()] = models.HiddenPresence()
+			continue
 		}
-		presenceMap[userID.Hex()] = statusData
+		raw, _ := vals[i].(string)
+		presenceMap[userID.Hex()] = storedPresence(raw, now)
 	}
 
 	return presenceMap, nil
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"messaging-app/internal/repositories"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// A player reports progress every few seconds; only one update per video
+	// in this window reaches Redis
+	watchProgressThrottle = 5 * time.Second
+	// Progress stays in Redis this long after the last update, so resume
+	// positions of recent videos never need Mongo
+	watchProgressTTL      = 7 * 24 * time.Hour
+	watchFlushInterval    = 30 * time.Second
+	watchFlushBatch       = 100
+	watchCompletedPercent = 95
+
+	watchDirtyKey = "watch:dirty"
+)
+
+var (
+	ErrInvalidWatchContentType = errors.New("content_type must be reel or video")
+	ErrInvalidWatchContentID   = errors.New("invalid content ID")
+	ErrInvalidWatchProgress    = errors.New("position_ms, duration_ms and media_index must not be negative")
+)
+
+// WatchTarget names one video: a reel, or a video among a post's media
+type WatchTarget struct {
+	ContentType models.WatchContentType
+	ContentID   primitive.ObjectID
+	MediaIndex  int
+}
+
+func (t WatchTarget) field() string {
+	return fmt.Sprintf("%s:%s:%d", t.ContentType, t.ContentID.Hex(), t.MediaIndex)
+}
+
+// WatchHistoryService records how far users got through reels and post
+// videos. Progress lands in Redis first, throttled per video, and a worker
+// flushes it to Mongo where the history is listed from.
+type WatchHistoryService struct {
+	repo        *repositories.WatchHistoryRepository
+	redisClient *redis.ClusterClient
+}
+
+func NewWatchHistoryService(repo *repositories.WatchHistoryRepository, redisClient *redis.ClusterClient) *WatchHistoryService {
+	return &WatchHistoryService{
+		repo:        repo,
+		redisClient: redisClient,
+	}
+}
+
+// The hash tag keeps a user's keys on one cluster slot
+func watchProgressKey(userID primitive.ObjectID) string {
+	return fmt.Sprintf("watch:progress:{%s}", userID.Hex())
+}
+
+func watchThrottleKey(userID primitive.ObjectID, field string) string {
+	return fmt.Sprintf("watch:throttle:{%s}:%s", userID.Hex(), field)
+}
+
+// RecordProgress stores the user's position in a video. Updates arriving
+// within the throttle window of the last one are dropped, except the one
+// that completes the video.
+func (s *WatchHistoryService) RecordProgress(ctx context.Context, userID primitive.ObjectID, req *models.RecordWatchProgressRequest) error {
+	if !req.ContentType.Valid() {
+		return ErrInvalidWatchContentType
+	}
+	contentID, err := primitive.ObjectIDFromHex(req.ContentID)
+	if err != nil {
+		return ErrInvalidWatchContentID
+	}
+	if req.PositionMs < 0 || req.DurationMs < 0 || req.MediaIndex < 0 {
+		return ErrInvalidWatchProgress
+	}
+	mediaIndex := req.MediaIndex
+	if req.ContentType == models.WatchContentReel {
+		mediaIndex = 0
+	}
+
+	progress := models.WatchProgress{
+		UserID:      userID,
+		ContentType: req.ContentType,
+		ContentID:   contentID,
+		MediaIndex:  mediaIndex,
+		PositionMs:  req.PositionMs,
+		DurationMs:  req.DurationMs,
+		Completed:   req.DurationMs > 0 && req.PositionMs*100 >= req.DurationMs*watchCompletedPercent,
+		UpdatedAt:   time.Now(),
+	}
+	field := WatchTarget{ContentType: progress.ContentType, ContentID: contentID, MediaIndex: mediaIndex}.field()
+
+	if !progress.Completed {
+		ok, err := s.redisClient.SetNX(ctx, watchThrottleKey(userID, field), 1, watchProgressThrottle).Result()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	payload, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	key := watchProgressKey(userID)
+	_, err = s.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, field, payload)
+		pipe.Expire(ctx, key, watchProgressTTL)
+		pipe.SAdd(ctx, watchDirtyKey, userID.Hex())
+		return nil
+	})
+	return err
+}
+
+// ResumePositions returns where the user left off each of the targets they
+// have started. Redis holds the latest progress; older videos come from Mongo.
+func (s *WatchHistoryService) ResumePositions(ctx context.Context, userID primitive.ObjectID, targets []WatchTarget) (map[WatchTarget]int64, error) {
+	positions := make(map[WatchTarget]int64)
+	if len(targets) == 0 {
+		return positions, nil
+	}
+
+	fields := make([]string, len(targets))
+	for i, t := range targets {
+		fields[i] = t.field()
+	}
+	values, err := s.redisClient.HMGet(ctx, watchProgressKey(userID), fields...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	missing := make(map[models.WatchContentType][]primitive.ObjectID)
+	for i, t := range targets {
+		raw, ok := values[i].(string)
+		if !ok {
+			missing[t.ContentType] = append(missing[t.ContentType], t.ContentID)
+			continue
+		}
+		var progress models.WatchProgress
+		if err := json.Unmarshal([]byte(raw), &progress); err != nil {
+			continue
+		}
+		if position := progress.ResumePositionMs(); position > 0 {
+			positions[t] = position
+		}
+	}
+
+	for contentType, ids := range missing {
+		entries, err := s.repo.GetProgress(ctx, userID, contentType, ids)
+		if err != nil {
+			return nil, err
+		}
+		for i := range entries {
+			t := WatchTarget{ContentType: contentType, ContentID: entries[i].ContentID, MediaIndex: entries[i].MediaIndex}
+			if _, seen := positions[t]; seen {
+				continue
+			}
+			if position := entries[i].ResumePositionMs(); position > 0 {
+				positions[t] = position
+			}
+		}
+	}
+	return positions, nil
+}
+
+// GetHistory pages through the videos the user watched, most recent first.
+// Their unflushed progress is flushed first so the list is current.
+func (s *WatchHistoryService) GetHistory(ctx context.Context, userID primitive.ObjectID, contentType models.WatchContentType, page, limit int64) (*models.WatchHistoryResponse, error) {
+	if contentType != "" && !contentType.Valid() {
+		return nil, ErrInvalidWatchContentType
+	}
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	if err := s.flushUser(ctx, userID.Hex()); err != nil {
+		log.Printf("Failed to flush watch progress of user %s: %v", userID.Hex(), err)
+	}
+
+	items, total, err := s.repo.ListByUser(ctx, userID, contentType, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.WatchHistoryResponse{Items: items, Total: total, Page: page, Limit: limit}, nil
+}
+
+// ClearHistory forgets what the user watched, or only the reels or only the
+// videos when contentType is set
+func (s *WatchHistoryService) ClearHistory(ctx context.Context, userID primitive.ObjectID, contentType models.WatchContentType) (int64, error) {
+	if contentType != "" && !contentType.Valid() {
+		return 0, ErrInvalidWatchContentType
+	}
+
+	key := watchProgressKey(userID)
+	if contentType == "" {
+		if err := s.redisClient.Del(ctx, key).Err(); err != nil {
+			return 0, err
+		}
+	} else {
+		fields, err := s.redisClient.HKeys(ctx, key).Result()
+		if err != nil {
+			return 0, err
+		}
+		var cleared []string
+		for _, field := range fields {
+			if strings.HasPrefix(field, string(contentType)+":") {
+				cleared = append(cleared, field)
+			}
+		}
+		if len(cleared) > 0 {
+			if err := s.redisClient.HDel(ctx, key, cleared...).Err(); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return s.repo.DeleteByUser(ctx, userID, contentType)
+}
+
+// StartFlushWorker periodically moves the progress recorded in Redis to
+// Mongo, until ctx is cancelled
+func (s *WatchHistoryService) StartFlushWorker(ctx context.Context) {
+	ticker := time.NewTicker(watchFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush(ctx)
+		case <-ctx.Done():
+			// Save what the last interval recorded before shutting down
+			flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			s.flush(flushCtx)
+			cancel()
+			return
+		}
+	}
+}
+
+func (s *WatchHistoryService) flush(ctx context.Context) {
+	for {
+		userIDs, err := s.redisClient.SPopN(ctx, watchDirtyKey, watchFlushBatch).Result()
+		if err != nil {
+			log.Printf("Failed to read users with watch progress to flush: %v", err)
+			return
+		}
+		for _, userID := range userIDs {
+			if err := s.flushUser(ctx, userID); err != nil {
+				log.Printf("Failed to flush watch progress of user %s: %v", userID, err)
+				// Try again next interval
+				s.redisClient.SAdd(ctx, watchDirtyKey, userID)
+			}
+		}
+		if len(userIDs) < watchFlushBatch {
+			return
+		}
+	}
+}
+
+// flushUser saves the user's progress in Redis to Mongo
+func (s *WatchHistoryService) flushUser(ctx context.Context, userHex string) error {
+	userID, err := primitive.ObjectIDFromHex(userHex)
+	if err != nil {
+		return nil // Nothing we wrote
+	}
+
+	values, err := s.redisClient.HGetAll(ctx, watchProgressKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+	entries := make([]models.WatchProgress, 0, len(values))
+	for _, raw := range values {
+		var progress models.WatchProgress
+		if err := json.Unmarshal([]byte(raw), &progress); err != nil {
+			continue
+		}
+		progress.UserID = userID
+		entries = append(entries, progress)
+	}
+	return s.repo.SaveProgress(ctx, entries)
+}
+
+// DeleteUserData erases the watch history of a deleted account
+func (s *WatchHistoryService) DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	if err := s.redisClient.SRem(ctx, watchDirtyKey, userID.Hex()).Err(); err != nil {
+		return 0, err
+	}
+	return s.ClearHistory(ctx, userID, "")
+}
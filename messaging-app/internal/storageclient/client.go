@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/presign"
 	storagepb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/storage/v1"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/resilience"
 	"google.golang.org/grpc"
@@ -16,6 +17,7 @@ type Client struct {
 	conn   *grpc.ClientConn
 	client storagepb.StorageServiceClient
 	cb     *resilience.CircuitBreaker
+	signer *presign.Signer
 }
 
 func NewClient(host, port string) (*Client, error) {
@@ -23,6 +25,7 @@ func NewClient(host, port string) (*Client, error) {
 	conn, err := grpc.NewClient(addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		observability.GetGRPCDialOption(),
+		resilience.NewGRPCClient("storage-service").WithRetry(resilience.DefaultRetryPolicy(), resilience.ReadOnlyMethods...).WithoutCircuitBreaker().DialOption(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to storage-service: %w", err)
@@ -33,11 +36,13 @@ func NewClient(host, port string) (*Client, error) {
 	cbConfig := resilience.DefaultConfig("storage-service")
 	cb := resilience.NewCircuitBreaker(cbConfig)
 
-	return &Client{
+	c := &Client{
 		conn:   conn,
 		client: storagepb.NewStorageServiceClient(conn),
 		cb:     cb,
-	}, nil
+	}
+	c.signer = presign.NewSigner(c.BatchGetPresignedURLs, presign.DefaultOptions())
+	return c, nil
 }
 
 func (c *Client) Close() error {
@@ -93,17 +93,39 @@ func (c *Client) DownloadArchive(ctx context.Context, objectPath string) ([]byte
 	return result.(*storagepb.DownloadArchiveResponse).Data, nil
 }
 
+func (c *Client) DeleteArchive(ctx context.Context, objectPath string) error {
+	_, err := c.cb.Execute(ctx, func() (interface{}, error) {
+		return c.client.DeleteArchive(ctx, &storagepb.DeleteArchiveRequest{
+			ObjectPath: objectPath,
+		})
+	})
+	return err
+}
+
+// GetPresignedURL signs a single key. Concurrent calls are coalesced into
+// batches and recently signed URLs are served from memory.
 func (c *Client) GetPresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return c.signer.Sign(ctx, key, expiry)
+}
+
+// SignURLs signs many keys at once, returning URLs keyed by the requested
+// key. Keys that could not be signed are left out of the result.
+func (c *Client) SignURLs(ctx context.Context, expiry time.Duration, keys ...string) (map[string]string, error) {
+	return c.signer.SignMany(ctx, keys, expiry)
+}
+
+// BatchGetPresignedURLs signs keys in a single round trip, bypassing the cache
+func (c *Client) BatchGetPresignedURLs(ctx context.Context, keys []string, expiry time.Duration) (map[string]string, error) {
 	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
-		return c.client.GetPresignedURL(ctx, &storagepb.GetPresignedURLRequest{
-			Key:           key,
+		return c.client.BatchGetPresignedURLs(ctx, &storagepb.BatchGetPresignedURLsRequest{
+			Keys:          keys,
 			ExpirySeconds: int64(expiry.Seconds()),
 		})
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return result.(*storagepb.GetPresignedURLResponse).Url, nil
+	return result.(*storagepb.BatchGetPresignedURLsResponse).Urls, nil
 }
 
 // GetPresignedUploadURL returns a presigned URL for direct-to-S3 uploads with deduplication
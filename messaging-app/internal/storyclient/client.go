@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
 	storypb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/story/v1"
@@ -23,6 +24,7 @@ func NewClient(host, port string) (*Client, error) {
 	conn, err := grpc.NewClient(addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		observability.GetGRPCDialOption(),
+		resilience.NewGRPCClient("story-service").WithTimeout(10*time.Second).WithRetry(resilience.DefaultRetryPolicy(), resilience.ReadOnlyMethods...).WithoutCircuitBreaker().DialOption(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to story-service: %w", err)
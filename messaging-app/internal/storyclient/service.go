@@ -124,17 +124,24 @@ func (c *Client) ReactToStory(ctx context.Context, storyID, userID primitive.Obj
 	return err
 }
 
-// GetStoryViewers returns viewers with their reactions for a story
-func (c *Client) GetStoryViewers(ctx context.Context, storyID, userID primitive.ObjectID) ([]models.StoryViewerResponse, error) {
+// GetStoryViewers returns a page of viewers with their reactions for a story
+func (c *Client) GetStoryViewers(ctx context.Context, storyID, userID primitive.ObjectID, limit, offset int) (*models.StoryViewersPage, error) {
 	result, err := c.cb.Execute(ctx, func() (interface{}, error) {
 		return c.client.GetStoryViewers(ctx, &storypb.GetStoryViewersRequest{
 			StoryId: storyID.Hex(),
 			UserId:  userID.Hex(),
+			Limit:   int32(limit),
+			Offset:  int32(offset),
 		})
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return ToModelStoryViewers(result.(*storypb.StoryViewersResponse).Viewers), nil
+	resp := result.(*storypb.StoryViewersResponse)
+	return &models.StoryViewersPage{
+		Viewers:     ToModelStoryViewers(resp.Viewers),
+		UniqueViews: resp.UniqueViews,
+		HasMore:     resp.HasMore,
+	}, nil
 }
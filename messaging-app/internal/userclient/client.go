@@ -36,6 +36,7 @@ func New(ctx context.Context, cfg *config.Config) (*Client, error) {
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithBlock(),
 		observability.GetGRPCDialOption(),
+		resilience.NewGRPCClient("user-service").WithTimeout(10*time.Second).WithRetry(resilience.DefaultRetryPolicy(), resilience.ReadOnlyMethods...).WithoutCircuitBreaker().DialOption(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("connect to user gRPC at %s: %w", addr, err)
@@ -70,6 +70,7 @@ func mapProtoToModel(u *pb.User) (*models.User, error) {
 		PhoneNumber:          u.PhoneNumber,
 		TwoFactorEnabled:     u.TwoFactorEnabled,
 		EmailVerified:        u.EmailVerified,
+		Verified:             u.IsVerified,
 		IsActive:             u.IsActive,
 		IsEncryptionEnabled:  u.IsEncryptionEnabled,
 		PrivacySettings:      privacySettings,
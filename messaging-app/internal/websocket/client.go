@@ -14,6 +14,7 @@ import (
 // Client represents a single websocket connection.
 type Client struct {
 	userID    string
+	sessionID string // login session the connection was authenticated with, if any
 	conn      *websocket.Conn
 	send      chan []byte
 	lastSeen  time.Time
@@ -65,6 +66,7 @@ func (c *Client) readPump(h *Hub) {
 				ConversationID string `json:"conversation_id"`
 				IsTyping       bool   `json:"isTyping"`
 				IsMarketplace  bool   `json:"is_marketplace"`
+				ProductID      string `json:"product_id"`
 			}
 			if err := json.Unmarshal(env.Payload, &typingData); err != nil {
 				log.Printf("Error unmarshaling typing data: %v", err)
@@ -75,6 +77,7 @@ func (c *Client) readPump(h *Hub) {
 				ConversationID: typingData.ConversationID,
 				IsTyping:       typingData.IsTyping,
 				IsMarketplace:  typingData.IsMarketplace,
+				ProductID:      typingData.ProductID,
 				Timestamp:      time.Now().Unix(),
 			}
 		case "message":
@@ -0,0 +1,316 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Cluster routing lets the hub nodes behind a load balancer act as one.
+// Every node records in Redis how many connections each user holds on it and
+// listens on a channel of its own, so an event for a user connected elsewhere
+// is published to the nodes holding them. Nodes announce themselves with a
+// heartbeat; the entries of a node that stops beating are ignored, so the
+// users of a crashed node show as offline once its heartbeat lapses.
+const (
+	clusterNodesKey      = "ws:nodes"
+	clusterUserKeyPrefix = "ws:user:"
+	clusterChannelPrefix = "ws:node:"
+
+	clusterHeartbeat = 10 * time.Second
+	// clusterNodeTTL is how long a node counts as live after its last heartbeat
+	clusterNodeTTL = 3 * clusterHeartbeat
+	// clusterNodePrune is how long a silent node stays in the node set
+	clusterNodePrune = time.Hour
+	// clusterUserTTL expires a user's entries should every node holding them
+	// die; nodes refresh the entries of their users well before that
+	clusterUserTTL     = 24 * time.Hour
+	clusterUserRefresh = time.Hour
+)
+
+// clusterEnvelope carries an event to another node, for the local clients of
+// one user or of one group
+type clusterEnvelope struct {
+	UserID     string          `json:"user_id,omitempty"`
+	GroupID    string          `json:"group_id,omitempty"`
+	SkipUserID string          `json:"skip_user_id,omitempty"` // Not sent to this group member, usually the one who caused it
+	Typing     bool            `json:"typing,omitempty"`       // Only sent to group members who see typing indicators
+	Data       json.RawMessage `json:"data"`
+}
+
+type cluster struct {
+	node  string
+	redis *redis.ClusterClient
+	dirty chan string // Users whose connection count on this node changed
+
+	mu   sync.RWMutex
+	live map[string]bool
+}
+
+// SetCluster joins the hub to the other nodes sharing its Redis. Every run of
+// a process joins as a new node, so entries left by a previous run of the
+// same instance are ignored. Set it before clients connect.
+func (h *Hub) SetCluster(instanceID string) {
+	h.cluster = &cluster{
+		node:  fmt.Sprintf("%s-%s", instanceID, strconv.FormatInt(time.Now().UnixNano(), 36)),
+		redis: h.redisClient,
+		dirty: make(chan string, 10000),
+		live:  map[string]bool{},
+	}
+	go h.clusterHeartbeat()
+	go h.writeClusterRegistry()
+	go h.subscribeToNodeChannel()
+	log.Printf("WebSocket hub joined cluster as node %s", h.cluster.node)
+}
+
+// clusterTouch queues the user's entry on this node to be rewritten
+func (h *Hub) clusterTouch(userID string) {
+	if h.cluster == nil {
+		return
+	}
+	select {
+	case h.cluster.dirty <- userID:
+	default:
+		go func() { h.cluster.dirty <- userID }()
+	}
+}
+
+// writeClusterRegistry writes the connection counts of touched users. Counts
+// are read when written rather than when touched, so the last write of a user
+// always holds their current count.
+func (h *Hub) writeClusterRegistry() {
+	c := h.cluster
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case userID := <-c.dirty:
+			h.mu.RLock()
+			count := len(h.userClients[userID])
+			h.mu.RUnlock()
+
+			key := clusterUserKeyPrefix + userID
+			_, err := c.redis.Pipelined(h.ctx, func(pipe goredis.Pipeliner) error {
+				if count == 0 {
+					pipe.HDel(h.ctx, key, c.node)
+					return nil
+				}
+				pipe.HSet(h.ctx, key, c.node, count)
+				pipe.Expire(h.ctx, key, clusterUserTTL)
+				return nil
+			})
+			if err != nil {
+				log.Printf("Error updating cluster registry for user %s: %v", userID, err)
+			}
+		}
+	}
+}
+
+// clusterHeartbeat keeps this node live, refreshes the set of live nodes and
+// periodically renews the entries of the users connected here
+func (h *Hub) clusterHeartbeat() {
+	ticker := time.NewTicker(clusterHeartbeat)
+	defer ticker.Stop()
+	lastRefresh := time.Now()
+
+	for {
+		h.beat()
+		if time.Since(lastRefresh) >= clusterUserRefresh {
+			lastRefresh = time.Now()
+			h.mu.RLock()
+			users := make([]string, 0, len(h.userClients))
+			for userID := range h.userClients {
+				users = append(users, userID)
+			}
+			h.mu.RUnlock()
+			for _, userID := range users {
+				h.clusterTouch(userID)
+			}
+		}
+
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Hub) beat() {
+	c := h.cluster
+	now := time.Now()
+	if err := c.redis.ZAdd(h.ctx, clusterNodesKey, goredis.Z{Score: float64(now.Unix()), Member: c.node}).Err(); err != nil {
+		log.Printf("Error sending cluster heartbeat: %v", err)
+		return
+	}
+	c.redis.ZRemRangeByScore(h.ctx, clusterNodesKey, "-inf", strconv.FormatInt(now.Add(-clusterNodePrune).Unix(), 10))
+
+	nodes, err := c.redis.ZRangeByScore(h.ctx, clusterNodesKey, &goredis.ZRangeBy{
+		Min: strconv.FormatInt(now.Add(-clusterNodeTTL).Unix(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		log.Printf("Error listing live cluster nodes: %v", err)
+		return
+	}
+	live := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		live[node] = true
+	}
+	c.mu.Lock()
+	c.live = live
+	c.mu.Unlock()
+}
+
+// peers returns the other live nodes
+func (c *cluster) peers() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var nodes []string
+	for node := range c.live {
+		if node != c.node {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// userNodes returns the other live nodes the user is connected to
+func (c *cluster) userNodes(ctx context.Context, userID string) ([]string, error) {
+	entries, err := c.redis.HGetAll(ctx, clusterUserKeyPrefix+userID).Result()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return liveRemoteNodes(entries, c.node, c.live), nil
+}
+
+// liveRemoteNodes picks, from a user's registry entries of node to connection
+// count, the live nodes other than self holding a connection
+func liveRemoteNodes(entries map[string]string, self string, live map[string]bool) []string {
+	var nodes []string
+	for node, count := range entries {
+		if node == self || !live[node] {
+			continue
+		}
+		if n, err := strconv.Atoi(count); err == nil && n > 0 {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+func (h *Hub) publishToNodes(nodes []string, env clusterEnvelope) {
+	if len(nodes) == 0 {
+		return
+	}
+	payload, err := json.Marshal(env)
+	if err != nil {
+		log.Printf("Error marshaling cluster envelope: %v", err)
+		return
+	}
+	for _, node := range nodes {
+		if err := h.cluster.redis.Publish(h.ctx, clusterChannelPrefix+node, payload); err != nil {
+			log.Printf("Error forwarding event to node %s: %v", node, err)
+		}
+	}
+}
+
+// sendToRemoteUser forwards message to the other nodes the user is connected to
+func (h *Hub) sendToRemoteUser(userID string, message []byte) {
+	if h.cluster == nil {
+		return
+	}
+	nodes, err := h.cluster.userNodes(h.ctx, userID)
+	if err != nil {
+		log.Printf("Error looking up nodes of user %s: %v", userID, err)
+		return
+	}
+	h.publishToNodes(nodes, clusterEnvelope{UserID: userID, Data: message})
+}
+
+// sendToRemoteGroup forwards message to every other node for the group's
+// members connected there, except skipUserID
+func (h *Hub) sendToRemoteGroup(groupID, skipUserID string, message []byte) {
+	if h.cluster == nil {
+		return
+	}
+	h.publishToNodes(h.cluster.peers(), clusterEnvelope{GroupID: groupID, SkipUserID: skipUserID, Data: message})
+}
+
+// sendTypingToRemoteGroup relays a typing event to the group's members on
+// other nodes who see typing indicators
+func (h *Hub) sendTypingToRemoteGroup(groupID, typistID string, message []byte) {
+	if h.cluster == nil {
+		return
+	}
+	h.publishToNodes(h.cluster.peers(), clusterEnvelope{GroupID: groupID, SkipUserID: typistID, Typing: true, Data: message})
+}
+
+// isUserOnline reports whether the user is connected to any node. Lookup
+// failures fall back to this node's clients alone.
+func (h *Hub) isUserOnline(userID string) bool {
+	h.mu.RLock()
+	local := len(h.userClients[userID]) > 0
+	h.mu.RUnlock()
+	if local || h.cluster == nil {
+		return local
+	}
+	nodes, err := h.cluster.userNodes(h.ctx, userID)
+	if err != nil {
+		log.Printf("Error looking up nodes of user %s: %v", userID, err)
+		return false
+	}
+	return len(nodes) > 0
+}
+
+// subscribeToNodeChannel delivers the events other nodes forward to this one
+func (h *Hub) subscribeToNodeChannel() {
+	pubsub := h.redisClient.Subscribe(h.ctx, clusterChannelPrefix+h.cluster.node)
+	defer pubsub.Close()
+	ch := pubsub.Channel()
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			var env clusterEnvelope
+			if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil {
+				log.Printf("Error unmarshaling cluster envelope: %v", err)
+				continue
+			}
+			h.deliverEnvelope(env)
+		}
+	}
+}
+
+func (h *Hub) deliverEnvelope(env clusterEnvelope) {
+	switch {
+	case env.UserID != "":
+		h.sendToLocalUser(env.UserID, env.Data)
+	case env.GroupID != "" && env.Typing:
+		for _, c := range h.typingViewers(h.getClientsByGroup(env.GroupID), env.SkipUserID) {
+			select {
+			case c.send <- env.Data:
+			default:
+				h.hubMetrics().IncrementSendBufferDrops("group")
+				h.removeClient(c)
+			}
+		}
+	case env.GroupID != "":
+		h.sendToLocalGroup(env.GroupID, env.SkipUserID, env.Data)
+	}
+}
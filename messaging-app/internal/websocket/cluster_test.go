@@ -0,0 +1,52 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveRemoteNodes(t *testing.T) {
+	live := map[string]bool{"self": true, "node-a": true, "node-b": true}
+	entries := map[string]string{
+		"self":    "2",
+		"node-a":  "1",
+		"node-b":  "0",
+		"crashed": "3",
+		"garbled": "x",
+	}
+
+	assert.Equal(t, []string{"node-a"}, liveRemoteNodes(entries, "self", live))
+	assert.Empty(t, liveRemoteNodes(map[string]string{"self": "1"}, "self", live))
+}
+
+func TestHub_DeliverEnvelope(t *testing.T) {
+	t.Run("user envelope reaches the user's local clients", func(t *testing.T) {
+		h := newTestHub()
+		alice, bob := addTestClient(h, "alice"), addTestClient(h, "bob")
+
+		h.deliverEnvelope(clusterEnvelope{UserID: "alice", Data: []byte(`{"type":"PING"}`)})
+
+		assert.Len(t, alice.send, 1)
+		assert.Empty(t, bob.send)
+	})
+
+	t.Run("group envelope skips the sender", func(t *testing.T) {
+		h := newTestHub()
+		alice, bob := addTestClient(h, "alice"), addTestClient(h, "bob")
+		h.groupClients["g1"] = map[*Client]bool{alice: true, bob: true}
+
+		h.deliverEnvelope(clusterEnvelope{GroupID: "g1", SkipUserID: "alice", Data: []byte(`{"type":"TYPING"}`)})
+
+		assert.Empty(t, alice.send)
+		assert.Len(t, bob.send, 1)
+	})
+}
+
+func TestHub_IsUserOnlineWithoutCluster(t *testing.T) {
+	h := newTestHub()
+	addTestClient(h, "alice")
+
+	assert.True(t, h.isUserOnline("alice"))
+	assert.False(t, h.isUserOnline("bob"))
+}
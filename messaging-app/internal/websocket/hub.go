@@ -3,9 +3,11 @@ package websocket
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 
 	"messaging-app/internal/repositories"
 
+	sharedcache "github.com/MuhibNayem/connectify-v2/shared-entity/cache"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
 
@@ -17,6 +19,30 @@ type MessageUpdater interface {
 	MarkMessagesAsDelivered(ctx context.Context, userID primitive.ObjectID, conversationID string, messageIDs []string) error
 }
 
+// NotificationFilter decides whether a notification is pushed to its recipient
+// in real time, applying their mutes and quiet hours.
+type NotificationFilter interface {
+	ShouldPush(ctx context.Context, notification *models.Notification) bool
+}
+
+// CallRooms knows who has joined each group call, so the Hub relays call
+// signals only between a call's participants.
+type CallRooms interface {
+	RoomPeers(ctx context.Context, roomID, userID string) ([]string, error)
+}
+
+// PresencePrivacy knows who each user shares their presence with, so the Hub
+// only announces users to the contacts allowed to see them.
+type PresencePrivacy interface {
+	PresenceVisibilities(ctx context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]models.PrivacySettingType, error)
+}
+
+// ReceiptPrivacy knows whether users share read receipts and typing
+// indicators, so the Hub only sends them between users who both do.
+type ReceiptPrivacy interface {
+	MessagingPrivacy(ctx context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]models.MessagingPrivacy, error)
+}
+
 // Hub maintains the set of active clients and orchestrates WebSocket events.
 type Hub struct {
 	userClients  map[string]map[*Client]bool
@@ -34,8 +60,10 @@ type Hub struct {
 	register               chan *Client
 	unregister             chan *Client
 	Broadcast              chan models.Message
-	FeedEvents             chan models.WebSocketEvent
-	NotificationEvents     chan models.Notification
+	FanoutMessages         chan FanoutMessage // Messages from the Kafka hub fan-out, carrying replay cursors
+	FeedEvents             chan FeedEvent
+	NotificationEvents     chan NotificationEvent
+	NotificationUpdates    chan NotificationEvent // Digests that absorbed another notification
 	typingEvents           chan models.TypingEvent
 	ReactionEvents         chan models.ReactionEvent
 	ReadReceiptEvents      chan models.ReadReceiptEvent
@@ -51,7 +79,15 @@ type Hub struct {
 
 	mu sync.RWMutex
 
-	messageUpdater MessageUpdater
+	messageUpdater     MessageUpdater
+	notificationFilter NotificationFilter
+	callRooms          CallRooms
+	presencePrivacy    PresencePrivacy
+	receiptPrivacy     ReceiptPrivacy
+	receiptSettings    *sharedcache.LRU[string, cachedMessagingPrivacy]
+	journal            *eventJournal
+	cluster            *cluster
+	metrics            atomic.Pointer[HubMetrics]
 }
 
 // NewHub creates a new Hub and starts its background goroutines.
@@ -64,6 +100,7 @@ func NewHub(
 	messageRepo *repositories.MessageRepository,
 	messageCassandraRepo *repositories.MessageCassandraRepository,
 	messageUpdater MessageUpdater,
+	notificationFilter NotificationFilter,
 ) *Hub {
 	ctx, cancel := context.WithCancel(context.Background())
 	h := &Hub{
@@ -80,8 +117,10 @@ func NewHub(
 		register:               make(chan *Client),
 		unregister:             make(chan *Client),
 		Broadcast:              make(chan models.Message, 10000),
-		FeedEvents:             make(chan models.WebSocketEvent, 10000),
-		NotificationEvents:     make(chan models.Notification, 10000),
+		FanoutMessages:         make(chan FanoutMessage, 10000),
+		FeedEvents:             make(chan FeedEvent, 10000),
+		NotificationEvents:     make(chan NotificationEvent, 10000),
+		NotificationUpdates:    make(chan NotificationEvent, 10000),
 		typingEvents:           make(chan models.TypingEvent, 1000),
 		ReactionEvents:         make(chan models.ReactionEvent, 10000),
 		ReadReceiptEvents:      make(chan models.ReadReceiptEvent, 10000),
@@ -94,16 +133,38 @@ func NewHub(
 		ctx:                    ctx,
 		cancel:                 cancel,
 		messageUpdater:         messageUpdater,
+		notificationFilter:     notificationFilter,
 	}
 
 	go h.run()
 	go h.subscribeToRedis()
 	go h.subscribeToGlobalEvents()
+	go h.subscribeToSessionRevocations()
 	go h.cleanupStaleConnections()
 
 	return h
 }
 
+// SetCallRooms enables group call signaling. Set it before clients connect.
+func (h *Hub) SetCallRooms(rooms CallRooms) {
+	h.callRooms = rooms
+}
+
+// SetPresencePrivacy applies users' presence visibility to the presence
+// updates the Hub sends. Without it every contact sees every user. Set it
+// before clients connect.
+func (h *Hub) SetPresencePrivacy(privacy PresencePrivacy) {
+	h.presencePrivacy = privacy
+}
+
+// SetReceiptPrivacy applies users' read receipt and typing indicator
+// settings to the events the Hub sends. Without it everyone shares both. Set
+// it before clients connect.
+func (h *Hub) SetReceiptPrivacy(privacy ReceiptPrivacy) {
+	h.receiptPrivacy = privacy
+	h.receiptSettings = sharedcache.NewLRU[string, cachedMessagingPrivacy](receiptSettingsSize)
+}
+
 func (h *Hub) addClient(c *Client) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -119,6 +180,7 @@ func (h *Hub) addClient(c *Client) {
 		}
 		h.groupClients[gid][c] = true
 	}
+	h.clusterTouch(c.userID)
 	wsConnections.Inc()
 }
 
@@ -126,13 +188,15 @@ func (h *Hub) removeClient(c *Client) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	if conns, ok := h.userClients[c.userID]; ok {
-		if _, exists := conns[c]; exists {
-			delete(conns, c)
-			if len(conns) == 0 {
-				delete(h.userClients, c.userID)
-			}
-		}
+	// A client dropped for a full send buffer is unregistered again when its
+	// connection closes; its send channel is already closed by then
+	conns, ok := h.userClients[c.userID]
+	if !ok || !conns[c] {
+		return
+	}
+	delete(conns, c)
+	if len(conns) == 0 {
+		delete(h.userClients, c.userID)
 	}
 
 	for gid := range c.listeners {
@@ -145,6 +209,7 @@ func (h *Hub) removeClient(c *Client) {
 			}
 		}
 	}
+	h.clusterTouch(c.userID)
 	wsConnections.Dec()
 	close(c.send)
 }
@@ -159,4 +224,5 @@ func (h *Hub) removeUserClient(userID string, client *Client) {
 			delete(h.userClients, userID)
 		}
 	}
+	h.clusterTouch(userID)
 }
@@ -0,0 +1,159 @@
+package websocket
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// hubMetricsInterval is how often client counts and queue depths are sampled
+const hubMetricsInterval = 10 * time.Second
+
+// HubMetrics holds Prometheus metrics for the hub internals of one node.
+// Every metric carries a node label so replicas can be told apart.
+type HubMetrics struct {
+	ConnectedClients  prometheus.Gauge
+	ConnectedUsers    prometheus.Gauge
+	SendBufferDrops   *prometheus.CounterVec
+	QueueDepth        *prometheus.GaugeVec
+	RedisSubscribeLag *prometheus.HistogramVec
+	FanoutLatency     *prometheus.HistogramVec
+	HandlerDuration   *prometheus.HistogramVec
+}
+
+// NewHubMetrics creates and registers hub metrics for node.
+func NewHubMetrics(node string) *HubMetrics {
+	labels := prometheus.Labels{"node": node}
+	return &HubMetrics{
+		ConnectedClients: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:        "websocket_hub_connected_clients",
+			Help:        "WebSocket connections held by this node",
+			ConstLabels: labels,
+		}),
+		ConnectedUsers: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:        "websocket_hub_connected_users",
+			Help:        "Distinct users connected to this node",
+			ConstLabels: labels,
+		}),
+		SendBufferDrops: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:        "websocket_hub_send_buffer_drops_total",
+			Help:        "Messages dropped because a client's send buffer was full, by delivery path",
+			ConstLabels: labels,
+		}, []string{"path"}),
+		QueueDepth: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "websocket_hub_queue_depth",
+			Help:        "Events waiting in the hub's inbound queues, by queue",
+			ConstLabels: labels,
+		}, []string{"queue"}),
+		RedisSubscribeLag: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "websocket_hub_redis_subscribe_lag_seconds",
+			Help:        "Time from a message being created to this node receiving it over Redis pub/sub, by channel",
+			ConstLabels: labels,
+			Buckets:     []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+		}, []string{"channel"}),
+		FanoutLatency: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "websocket_hub_fanout_duration_seconds",
+			Help:        "Time to hand an event to every local recipient, by kind",
+			ConstLabels: labels,
+			Buckets:     []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5},
+		}, []string{"kind"}),
+		HandlerDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "websocket_hub_handler_duration_seconds",
+			Help:        "Duration of hub event handlers, by event type",
+			ConstLabels: labels,
+			Buckets:     prometheus.DefBuckets,
+		}, []string{"event_type"}),
+	}
+}
+
+// IncrementSendBufferDrops counts a message dropped on the given path.
+func (m *HubMetrics) IncrementSendBufferDrops(path string) {
+	if m != nil {
+		m.SendBufferDrops.WithLabelValues(path).Inc()
+	}
+}
+
+// ObserveRedisLag records how long a message took to arrive over channel.
+func (m *HubMetrics) ObserveRedisLag(channel string, createdAt time.Time) {
+	if m == nil || createdAt.IsZero() {
+		return
+	}
+	m.RedisSubscribeLag.WithLabelValues(channel).Observe(time.Since(createdAt).Seconds())
+}
+
+// ObserveFanout records the time since start to fan an event out.
+func (m *HubMetrics) ObserveFanout(kind string, start time.Time) {
+	if m != nil {
+		m.FanoutLatency.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveHandler records the time since start to handle an event.
+func (m *HubMetrics) ObserveHandler(eventType string, start time.Time) {
+	if m != nil {
+		m.HandlerDuration.WithLabelValues(eventType).Observe(time.Since(start).Seconds())
+	}
+}
+
+// SetMetrics enables hub metrics and starts sampling client counts and
+// queue depths.
+func (h *Hub) SetMetrics(m *HubMetrics) {
+	if m == nil {
+		return
+	}
+	h.metrics.Store(m)
+	go h.sampleMetrics(m)
+}
+
+func (h *Hub) sampleMetrics(m *HubMetrics) {
+	ticker := time.NewTicker(hubMetricsInterval)
+	defer ticker.Stop()
+
+	for {
+		h.recordGauges(m)
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Hub) recordGauges(m *HubMetrics) {
+	h.mu.RLock()
+	users := len(h.userClients)
+	clients := 0
+	for _, conns := range h.userClients {
+		clients += len(conns)
+	}
+	h.mu.RUnlock()
+
+	m.ConnectedClients.Set(float64(clients))
+	m.ConnectedUsers.Set(float64(users))
+
+	depths := map[string]int{
+		"broadcast":            len(h.Broadcast),
+		"fanout_messages":      len(h.FanoutMessages),
+		"feed_events":          len(h.FeedEvents),
+		"notification_events":  len(h.NotificationEvents),
+		"notification_updates": len(h.NotificationUpdates),
+		"typing_events":        len(h.typingEvents),
+		"reaction_events":      len(h.ReactionEvents),
+		"read_receipt_events":  len(h.ReadReceiptEvents),
+		"message_edited":       len(h.MessageEditedEvents),
+		"delivered_events":     len(h.DeliveredEvents),
+		"conversation_seen":    len(h.ConversationSeenEvents),
+		"event_rsvp_events":    len(h.EventRSVPEvents),
+		"event_updates":        len(h.EventUpdates),
+		"call_signals":         len(h.CallSignal),
+	}
+	for queue, depth := range depths {
+		m.QueueDepth.WithLabelValues(queue).Set(float64(depth))
+	}
+}
+
+// hubMetrics returns the hub's metrics, nil until SetMetrics is called.
+func (h *Hub) hubMetrics() *HubMetrics {
+	return h.metrics.Load()
+}
@@ -5,12 +5,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 
+	"github.com/gorilla/websocket"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func (h *Hub) broadcastToParticipants(messageID primitive.ObjectID, wsEvent models.WebSocketEvent) {
@@ -34,15 +40,9 @@ func (h *Hub) broadcastToParticipants(messageID primitive.ObjectID, wsEvent mode
 		participantIDs = append(participantIDs, msg.SenderID.Hex(), msg.ReceiverID.Hex())
 	}
 
-	wsEventJSON, err := json.Marshal(wsEvent)
-	if err != nil {
-		log.Printf("Error marshaling WebSocketEvent for targeted broadcast: %v", err)
-		return
-	}
-
-	for _, userID := range participantIDs {
-		h.sendToUser(userID, wsEventJSON)
-	}
+	start := time.Now()
+	h.sendJournaled(participantIDs, wsEvent)
+	h.hubMetrics().ObserveFanout("participants", start)
 	log.Printf("Broadcasted %s event for message %s to %d participants", wsEvent.Type, messageID.Hex(), len(participantIDs))
 }
 
@@ -57,79 +57,70 @@ func (h *Hub) run() {
 		case c := <-h.unregister:
 			h.handleUnregister(c)
 		case event := <-h.FeedEvents:
-			go h.handleFeedEvent(event)
+			go h.timed(event.Type, func() { h.handleFeedEvent(event) })
 		case notification := <-h.NotificationEvents:
-			h.handleNotification(notification)
+			go h.timed("NOTIFICATION_CREATED", func() { h.handleNotification(notification, "NOTIFICATION_CREATED") })
+		case notification := <-h.NotificationUpdates:
+			go h.timed("NOTIFICATION_UPDATED", func() { h.handleNotification(notification, "NOTIFICATION_UPDATED") })
 		case ev := <-h.typingEvents:
-			h.dispatchTypingEvent(ev)
+			go h.timed("TYPING", func() { h.dispatchTypingEvent(ev) })
 		case m := <-h.Broadcast:
-			h.dispatchMessage(m)
+			h.dispatchMessage(m, "")
+		case m := <-h.FanoutMessages:
+			h.dispatchMessage(m.Message, m.Cursor)
 		case reactionEvent := <-h.ReactionEvents:
-			go h.handleReactionEvent(reactionEvent)
+			go h.timed("MESSAGE_REACTION_UPDATE", func() { h.handleReactionEvent(reactionEvent) })
 		case readReceiptEvent := <-h.ReadReceiptEvents:
-			go h.handleReadReceiptEvent(readReceiptEvent)
+			go h.timed("MESSAGE_READ_UPDATE", func() { h.handleReadReceiptEvent(readReceiptEvent) })
 		case ev := <-h.MessageEditedEvents:
-			go h.handleMessageEditedEvent(ev)
+			go h.timed("MESSAGE_EDITED", func() { h.handleMessageEditedEvent(ev) })
 		case conversationSeenEvent := <-h.ConversationSeenEvents:
-			go h.handleConversationSeenEvent(conversationSeenEvent)
+			go h.timed("CONVERSATION_SEEN_UPDATE", func() { h.handleConversationSeenEvent(conversationSeenEvent) })
 		case dev := <-h.DeliveredEvents:
-			h.handleDeliveredEvent(dev)
+			h.timed("MESSAGE_DELIVERED_UPDATE", func() { h.handleDeliveredEvent(dev) })
 		case signal := <-h.CallSignal:
-			h.handleCallSignal(signal)
+			h.timed("VOICE_CALL_SIGNAL", func() { h.handleCallSignal(signal) })
 		case rsvpEvent := <-h.EventRSVPEvents:
-			go h.handleEventRSVPEvent(rsvpEvent)
+			go h.timed("EVENT_RSVP_UPDATE", func() { h.handleEventRSVPEvent(rsvpEvent) })
 		case event := <-h.EventUpdates:
-			go h.handleEventUpdate(event)
+			go h.timed(event.Type, func() { h.handleEventUpdate(event) })
 		}
 	}
 }
 
+// timed runs handler and records its duration under eventType
+func (h *Hub) timed(eventType string, handler func()) {
+	start := time.Now()
+	handler()
+	h.hubMetrics().ObserveHandler(eventType, start)
+}
+
 func (h *Hub) handleRegister(c *Client) {
 	h.addClient(c)
 	go h.sendCachedMessages(c)
 
 	go func(client *Client) {
-		presenceData, _ := json.Marshal(map[string]interface{}{"status": "online", "last_seen": time.Now().Unix()})
+		now := time.Now()
+		presenceData, _ := json.Marshal(map[string]interface{}{"status": models.PresenceOnline, "last_seen": now.Unix()})
 		h.redisClient.Set(h.ctx, "presence:"+client.userID, presenceData, 24*time.Hour)
 
 		userOID, _ := primitive.ObjectIDFromHex(client.userID)
-		friends, err := h.friendshipRepo.GetFriends(h.ctx, userOID)
-		if err != nil {
-			log.Printf("Error getting friends for presence: %v", err)
-		}
-
-		myPresenceEvent := models.WebSocketEvent{
-			Type: "presence_update",
-			Data: json.RawMessage(fmt.Sprintf(`{"user_id": "%s", "status": "online", "last_seen": %d}`, client.userID, time.Now().Unix())),
-		}
-		myPresenceNumBytes, _ := json.Marshal(myPresenceEvent)
-
-		notifyUserIDs := make(map[string]bool)
-		for _, f := range friends {
-			notifyUserIDs[f.ID.Hex()] = true
-			h.sendFriendPresenceToClient(client, f.ID.Hex())
-		}
-
-		var marketplacePartners []primitive.ObjectID
-		var mpErr error
-		if h.messageCassandraRepo != nil {
-			marketplacePartners, mpErr = h.messageCassandraRepo.GetMarketplacePartnerIDs(h.ctx, userOID)
-		}
-		if (mpErr != nil || len(marketplacePartners) == 0) && h.messageRepo != nil {
-			marketplacePartners, mpErr = h.messageRepo.GetMarketplacePartnerIDs(h.ctx, userOID)
-		}
-		if mpErr != nil {
-			log.Printf("Error getting marketplace partners for presence: %v", mpErr)
-		} else {
-			for _, partnerID := range marketplacePartners {
-				partnerHex := partnerID.Hex()
-				notifyUserIDs[partnerHex] = true
-				h.sendFriendPresenceToClient(client, partnerHex)
+		contacts := h.presenceContacts(userOID)
+		ids := []string{client.userID}
+		for contactID := range contacts {
+			ids = append(ids, contactID)
+		}
+		visibilities := h.presenceVisibilities(ids)
+
+		myPresenceNumBytes := presenceEvent(client.userID, models.PresenceOnline, now)
+		for contactID, isFriend := range contacts {
+			// Each side only learns the other is online if that one shares it
+			if models.PresenceVisibleTo(visibilities[contactID], isFriend) {
+				h.sendFriendPresenceToClient(client, contactID)
+			}
+			if models.PresenceVisibleTo(visibilities[client.userID], isFriend) {
+				h.sendToUser(contactID, myPresenceNumBytes)
 			}
-		}
-
-		for userID := range notifyUserIDs {
-			h.sendToUser(userID, myPresenceNumBytes)
 		}
 
 		h.mu.RLock()
@@ -146,52 +137,33 @@ func (h *Hub) handleUnregister(c *Client) {
 	h.removeClient(c)
 
 	go func(userID string) {
-		presenceData, _ := json.Marshal(map[string]interface{}{"status": "offline", "last_seen": time.Now().Unix()})
+		// Still connected from another device, here or on another node
+		if h.isUserOnline(userID) {
+			return
+		}
+
+		now := time.Now()
+		presenceData, _ := json.Marshal(map[string]interface{}{"status": models.PresenceOffline, "last_seen": now.Unix()})
 		h.redisClient.Set(h.ctx, "presence:"+userID, presenceData, 24*time.Hour)
 
 		userOID, _ := primitive.ObjectIDFromHex(userID)
-		notifyUserIDs := make(map[string]bool)
+		visibility := h.presenceVisibilities([]string{userID})[userID]
+		offlineEventBytes := presenceEvent(userID, models.PresenceOffline, now)
 
-		friends, err := h.friendshipRepo.GetFriends(h.ctx, userOID)
-		if err == nil {
-			for _, f := range friends {
-				notifyUserIDs[f.ID.Hex()] = true
+		for uid, isFriend := range h.presenceContacts(userOID) {
+			if models.PresenceVisibleTo(visibility, isFriend) {
+				h.sendToUser(uid, offlineEventBytes)
 			}
 		}
-
-		marketplacePartners, mpErr := h.messageRepo.GetMarketplacePartnerIDs(h.ctx, userOID)
-		if mpErr == nil {
-			for _, partnerID := range marketplacePartners {
-				notifyUserIDs[partnerID.Hex()] = true
-			}
-		}
-
-		offlineEvent := models.WebSocketEvent{
-			Type: "presence_update",
-			Data: json.RawMessage(fmt.Sprintf(`{"user_id": "%s", "status": "offline", "last_seen": %d}`, userID, time.Now().Unix())),
-		}
-		offlineEventBytes, _ := json.Marshal(offlineEvent)
-
-		for uid := range notifyUserIDs {
-			h.sendToUser(uid, offlineEventBytes)
-		}
 	}(c.userID)
 }
 
 func (h *Hub) sendFriendPresenceToClient(client *Client, friendID string) {
-	h.mu.RLock()
-	_, isOnline := h.userClients[friendID]
-	h.mu.RUnlock()
-
-	if !isOnline {
+	if !h.isUserOnline(friendID) {
 		return
 	}
 
-	friendPresence := models.WebSocketEvent{
-		Type: "presence_update",
-		Data: json.RawMessage(fmt.Sprintf(`{"user_id": "%s", "status": "online", "last_seen": %d}`, friendID, time.Now().Unix())),
-	}
-	friendPresenceBytes, _ := json.Marshal(friendPresence)
+	friendPresenceBytes := presenceEvent(friendID, models.PresenceOnline, time.Now())
 
 	h.mu.RLock()
 	if clients, ok := h.userClients[client.userID]; ok {
@@ -202,7 +174,14 @@ func (h *Hub) sendFriendPresenceToClient(client *Client, friendID string) {
 	h.mu.RUnlock()
 }
 
+// sendToUser delivers message to every connection of the user, on this node
+// or any other
 func (h *Hub) sendToUser(userID string, message []byte) {
+	h.sendToLocalUser(userID, message)
+	h.sendToRemoteUser(userID, message)
+}
+
+func (h *Hub) sendToLocalUser(userID string, message []byte) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
@@ -211,6 +190,7 @@ func (h *Hub) sendToUser(userID string, message []byte) {
 			select {
 			case client.send <- message:
 			default:
+				h.hubMetrics().IncrementSendBufferDrops("user")
 				close(client.send)
 				go h.removeUserClient(client.userID, client)
 			}
@@ -228,11 +208,14 @@ func (h *Hub) broadcastToAllUsers(event models.WebSocketEvent) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
+	start := time.Now()
+	defer h.hubMetrics().ObserveFanout("broadcast", start)
 	for userID := range h.userClients {
 		for client := range h.userClients[userID] {
 			select {
 			case client.send <- eventBytes:
 			default:
+				h.hubMetrics().IncrementSendBufferDrops("broadcast")
 				close(client.send)
 				go h.removeUserClient(client.userID, client)
 			}
@@ -240,15 +223,19 @@ func (h *Hub) broadcastToAllUsers(event models.WebSocketEvent) {
 	}
 }
 
-func (h *Hub) dispatchMessage(msg models.Message) {
+func (h *Hub) dispatchMessage(msg models.Message, cursor string) {
+	defer h.hubMetrics().ObserveFanout("message", time.Now())
+
 	if !msg.ReceiverID.IsZero() {
 		log.Printf("[DEBUG] Dispatching direct message ID: %s to Receiver: %s", msg.ID.Hex(), msg.ReceiverID.Hex())
 		receiverClients := h.getClientsByUser(msg.ReceiverID.Hex())
 		log.Printf("[DEBUG] Found %d clients for Receiver: %s", len(receiverClients), msg.ReceiverID.Hex())
 
-		h.sendToClients(receiverClients, msg)
+		h.sendToClients(receiverClients, msg, cursor)
 
-		if len(receiverClients) == 0 {
+		// Every node dispatches the message, so only queue it when the
+		// receiver is connected to none of them
+		if len(receiverClients) == 0 && !h.isUserOnline(msg.ReceiverID.Hex()) {
 			log.Printf("[DEBUG] Receiver %s is offline, queuing message", msg.ReceiverID.Hex())
 			if err := h.messageCache.AddPendingDirectMessage(h.ctx, msg.ReceiverID.Hex(), msg.ID.Hex()); err != nil {
 				log.Printf("Failed to queue pending direct message for %s: %v", msg.ReceiverID.Hex(), err)
@@ -258,48 +245,18 @@ func (h *Hub) dispatchMessage(msg models.Message) {
 	}
 
 	if !msg.GroupID.IsZero() {
-		h.sendToClients(h.getClientsByGroup(msg.GroupID.Hex()), msg)
+		h.sendToClients(h.getClientsByGroup(msg.GroupID.Hex()), msg, cursor)
 		go h.queuePendingForGroup(msg)
 	}
 }
 
-func (h *Hub) sendToClients(clients []*Client, msg models.Message) {
-	var msgToMarshal interface{} = msg
-	if msg.GroupID.IsZero() {
-		type shadowedMessage struct {
-			models.Message
-			GroupID *string `json:"group_id,omitempty"`
-		}
-		msgToMarshal = shadowedMessage{
-			Message: msg,
-			GroupID: nil,
-		}
-	}
-
-	msgData, err := json.Marshal(msgToMarshal)
+func (h *Hub) sendToClients(clients []*Client, msg models.Message, cursor string) {
+	wsEvent, err := NewMessageEvent(msg, cursor)
 	if err != nil {
 		log.Printf("Error marshaling message: %v", err)
 		return
 	}
 
-	eventType := "MESSAGE_CREATED"
-	if msg.IsMarketplace {
-		eventType = "MARKETPLACE_MESSAGE_CREATED"
-	}
-
-	if msg.ContentType == "deleted" || msg.ContentType == models.ContentTypeDeleted {
-		if msg.IsMarketplace {
-			eventType = "MARKETPLACE_MESSAGE_DELETED"
-		} else {
-			eventType = "MESSAGE_DELETED"
-		}
-	}
-
-	wsEvent := models.WebSocketEvent{
-		Type: eventType,
-		Data: msgData,
-	}
-
 	wsEventJSON, err := json.Marshal(wsEvent)
 	if err != nil {
 		log.Printf("Error marshaling WebSocketEvent for message: %v", err)
@@ -313,6 +270,7 @@ func (h *Hub) sendToClients(clients []*Client, msg models.Message) {
 			wsMessagesSent.WithLabelValues(msg.ContentType).Inc()
 			go h.notifyDelivery(c, msg)
 		default:
+			h.hubMetrics().IncrementSendBufferDrops("message")
 			h.removeClient(c)
 		}
 	}
@@ -371,10 +329,8 @@ func (h *Hub) queuePendingForGroup(msg models.Message) {
 		log.Printf("Error getting group members: %v", err)
 		return
 	}
-	h.mu.RLock()
-	defer h.mu.RUnlock()
 	for _, uid := range members {
-		if _, online := h.userClients[uid]; !online {
+		if !h.isUserOnline(uid) {
 			if err := h.messageCache.AddPendingDirectMessage(h.ctx, uid, msg.ID.Hex()); err != nil {
 				log.Printf("Failed to queue pending for %s: %v", uid, err)
 			}
@@ -402,6 +358,22 @@ func (h *Hub) getClientsByGroup(gid string) []*Client {
 	return list
 }
 
+// sendToLocalGroup delivers message to the group's members connected to this
+// node, except skipUserID
+func (h *Hub) sendToLocalGroup(gid, skipUserID string, message []byte) {
+	for _, c := range h.getClientsByGroup(gid) {
+		if c.userID == skipUserID {
+			continue
+		}
+		select {
+		case c.send <- message:
+		default:
+			h.hubMetrics().IncrementSendBufferDrops("group")
+			h.removeClient(c)
+		}
+	}
+}
+
 func (h *Hub) sendCachedMessages(client *Client) {
 	ctx := h.ctx
 
@@ -465,31 +437,55 @@ func (h *Hub) sendPendingMessages(client *Client, msgIDs []string, msgType strin
 			wsMessagesSent.WithLabelValues(msg.ContentType).Inc()
 
 		default:
+			h.hubMetrics().IncrementSendBufferDrops("pending")
 			log.Printf("Client channel full, skipping cached message")
 		}
 	}
 }
 
 func (h *Hub) dispatchTypingEvent(ev models.TypingEvent) {
-	conversationType := ""
+	var clients []*Client
+	var targetUserID, targetGroupID string
 	conversationID := ev.ConversationID
 
-	if len(conversationID) > 5 && conversationID[:5] == "user-" {
-		conversationType = "user"
-		conversationID = conversationID[5:]
-	} else if len(conversationID) > 6 && conversationID[:6] == "group-" {
-		conversationType = "group"
-		conversationID = conversationID[6:]
-	} else {
+	switch {
+	case strings.HasPrefix(conversationID, "user-") && len(conversationID) > 5:
+		targetUserID = conversationID[5:]
+		clients = h.getClientsByUser(targetUserID)
+	case strings.HasPrefix(conversationID, "group-") && len(conversationID) > 6:
+		targetGroupID = conversationID[6:]
+		clients = h.getClientsByGroup(targetGroupID)
+	case strings.HasPrefix(conversationID, "dm_"):
+		partnerID, ok := dmPartnerID(conversationID, ev.UserID)
+		if !ok {
+			log.Printf("Typing event from %s for DM %s they are not part of", ev.UserID, conversationID)
+			return
+		}
+		targetUserID = partnerID
+		clients = h.getClientsByUser(partnerID)
+	default:
 		log.Printf("Invalid conversation ID format for typing event: %s", ev.ConversationID)
 		return
 	}
 
-	var clients []*Client
-	if conversationType == "user" {
-		clients = h.getClientsByUser(conversationID)
-	} else if conversationType == "group" {
-		clients = h.getClientsByGroup(conversationID)
+	// Typists who hide their typing, and viewers who hide theirs, see none
+	if !h.messagingPrivacy(ev.UserID)[ev.UserID].TypingIndicators {
+		return
+	}
+	clients = h.typingViewers(clients, ev.UserID)
+	if targetUserID != "" && !h.messagingPrivacy(targetUserID)[targetUserID].TypingIndicators {
+		targetUserID = ""
+	}
+
+	// Marketplace threads are per listing, so their typing events go out on a
+	// dedicated type that carries the product for the seller's inbox.
+	eventType := "TYPING"
+	if ev.ProductID != "" {
+		if !ev.IsMarketplace || !primitive.IsValidObjectID(ev.ProductID) {
+			ev.ProductID = ""
+		} else {
+			eventType = "MARKETPLACE_TYPING"
+		}
 	}
 
 	data, err := json.Marshal(ev)
@@ -498,7 +494,7 @@ func (h *Hub) dispatchTypingEvent(ev models.TypingEvent) {
 		return
 	}
 	wsEvent := models.WebSocketEvent{
-		Type: "TYPING",
+		Type: eventType,
 		Data: data,
 	}
 
@@ -510,9 +506,6 @@ func (h *Hub) dispatchTypingEvent(ev models.TypingEvent) {
 
 	log.Printf("Dispatching typing event: %+v to %d clients", ev, len(clients))
 	for _, c := range clients {
-		if c.userID == ev.UserID {
-			continue
-		}
 		select {
 		case c.send <- wsEventJSON:
 			c.setLastSeen(time.Now())
@@ -520,6 +513,45 @@ func (h *Hub) dispatchTypingEvent(ev models.TypingEvent) {
 			h.removeClient(c)
 		}
 	}
+
+	// The typist's node is the only one that sees the event; the lookup runs
+	// off the hub loop
+	switch {
+	case targetGroupID != "":
+		go h.sendTypingToRemoteGroup(targetGroupID, ev.UserID, wsEventJSON)
+	case targetUserID != "" && targetUserID != ev.UserID:
+		go h.sendToRemoteUser(targetUserID, wsEventJSON)
+	}
+}
+
+// dmPartnerID returns the other participant of a "dm_<a>_<b>" conversation,
+// or false if userID is not one of its participants.
+func dmPartnerID(conversationID, userID string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(conversationID, "dm_"), "_")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+	switch userID {
+	case parts[0]:
+		return parts[1], true
+	case parts[1]:
+		return parts[0], true
+	}
+	return "", false
+}
+
+// marketplacePartnerIDs returns the users the given user has marketplace
+// conversations with, preferring Cassandra and falling back to MongoDB.
+func (h *Hub) marketplacePartnerIDs(userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	var partners []primitive.ObjectID
+	var err error
+	if h.messageCassandraRepo != nil {
+		partners, err = h.messageCassandraRepo.GetMarketplacePartnerIDs(h.ctx, userID)
+	}
+	if (err != nil || len(partners) == 0) && h.messageRepo != nil {
+		partners, err = h.messageRepo.GetMarketplacePartnerIDs(h.ctx, userID)
+	}
+	return partners, err
 }
 
 func (h *Hub) cleanupStaleConnections() {
@@ -567,12 +599,16 @@ func (h *Hub) subscribeToRedis() {
 			if !ok {
 				return
 			}
+			_, span, payload := observability.StartRedisConsumerSpan(h.ctx, msg.Channel, []byte(msg.Payload))
 			var m models.Message
-			if err := json.Unmarshal([]byte(msg.Payload), &m); err != nil {
+			if err := json.Unmarshal(payload, &m); err != nil {
 				log.Printf("Error unmarshaling Redis message: %v", err)
+				observability.EndSpan(span, err)
 				continue
 			}
+			h.hubMetrics().ObserveRedisLag(msg.Channel, m.CreatedAt)
 			h.Broadcast <- m
+			span.End()
 		}
 	}
 }
@@ -590,21 +626,108 @@ func (h *Hub) subscribeToGlobalEvents() {
 			if !ok {
 				return
 			}
+			_, span, payload := observability.StartRedisConsumerSpan(h.ctx, msg.Channel, []byte(msg.Payload))
 			var event models.WebSocketEvent
-			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			if err := json.Unmarshal(payload, &event); err != nil {
 				log.Printf("Error unmarshaling Redis global event: %v", err)
+				observability.EndSpan(span, err)
 				continue
 			}
 			h.broadcastToAllUsers(event)
+			span.End()
 		}
 	}
 }
 
+// subscribeToSessionRevocations closes the WebSocket of every device whose
+// login session user-service revokes
+func (h *Hub) subscribeToSessionRevocations() {
+	pubsub := h.redisClient.Subscribe(h.ctx, models.SessionRevokedChannel)
+	defer pubsub.Close()
+	ch := pubsub.Channel()
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			var event models.SessionRevokedEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				log.Printf("Error unmarshaling session revocation: %v", err)
+				continue
+			}
+			h.disconnectSession(event.UserID, event.SessionID)
+		}
+	}
+}
+
+// disconnectSession closes the user's connections opened with sessionID. The
+// close frame tells the client why; readPump then unregisters the client as
+// for any other disconnect.
+func (h *Hub) disconnectSession(userID, sessionID string) {
+	if sessionID == "" {
+		return
+	}
+
+	h.mu.RLock()
+	var targets []*Client
+	for c := range h.userClients[userID] {
+		if c.sessionID == sessionID {
+			targets = append(targets, c)
+		}
+	}
+	h.mu.RUnlock()
+
+	closeMsg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "session revoked")
+	for _, c := range targets {
+		c.conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+		c.conn.Close()
+	}
+	if len(targets) > 0 {
+		log.Printf("Closed %d connection(s) for revoked session %s", len(targets), sessionID)
+	}
+}
+
+// sendToPostAudience sends data to the author and the friends that can see the
+// post. Public posts are treated like friends posts: we do NOT want to shout to
+// the entire world (O(N)) for every public post, only friends need to know
+// immediately for their feed.
+func (h *Hub) sendToPostAudience(post *models.Post, data []byte) error {
+	var friendIDs []primitive.ObjectID
+	if post.Privacy != models.PrivacySettingOnlyMe && post.Privacy != models.PrivacySettingCustom {
+		var err error
+		if friendIDs, err = h.friendshipRepo.GetFriendIDs(context.Background(), post.UserID); err != nil {
+			h.sendToUser(post.UserID.Hex(), data)
+			return err
+		}
+	}
+	for _, userID := range post.AudienceRecipients(friendIDs) {
+		h.sendToUser(userID.Hex(), data)
+	}
+	return nil
+}
+
 func (h *Hub) getGroupMembers(groupID string) ([]string, error) {
 	return h.redisClient.SMembers(context.Background(), "group:members:"+groupID).Result()
 }
 
-func (h *Hub) handleFeedEvent(event models.WebSocketEvent) {
+// startDeliverySpan starts the span for pushing an event to WebSocket
+// clients, as a child of the span that handed the event to the hub
+func startDeliverySpan(parent trace.SpanContext, eventType string) trace.Span {
+	ctx := trace.ContextWithSpanContext(context.Background(), parent)
+	_, span := otel.Tracer("connectify").Start(ctx, "websocket deliver "+eventType,
+		trace.WithAttributes(attribute.String("websocket.event_type", eventType)),
+	)
+	return span
+}
+
+func (h *Hub) handleFeedEvent(event FeedEvent) {
+	span := startDeliverySpan(event.SpanContext, event.Type)
+	defer span.End()
+
 	// 1. Smart Producer / Dumb Consumer Logic
 	// If recipients are provided, bypass all DB lookups and logic.
 	if len(event.Recipients) > 0 {
@@ -619,9 +742,11 @@ func (h *Hub) handleFeedEvent(event models.WebSocketEvent) {
 			return
 		}
 
+		start := time.Now()
 		for _, recipientID := range event.Recipients {
 			h.sendToUser(recipientID, eventBytes)
 		}
+		h.hubMetrics().ObserveFanout("feed", start)
 		log.Printf("Smart Broadcast: Sent %s event to %d recipients", event.Type, len(event.Recipients))
 		return
 	}
@@ -634,26 +759,11 @@ func (h *Hub) handleFeedEvent(event models.WebSocketEvent) {
 			log.Printf("Error unmarshaling PostCreated data: %v", err)
 			return
 		}
-		// Optimization: Treat Public posts like Friends posts for Broadcasting.
-		// We do NOT want to shout to the entire world (O(N)) for every public post.
-		// Only friends need to know immediately for their feed.
-		switch post.Privacy {
-		case models.PrivacySettingPublic, models.PrivacySettingFriends:
-			h.sendToUser(post.UserID.Hex(), event.Data)
-			friends, err := h.friendshipRepo.GetFriends(context.Background(), post.UserID)
-			if err != nil {
-				log.Printf("Error getting friends for post broadcast: %v", err)
-				return
-			}
-			for _, friend := range friends {
-				h.sendToUser(friend.ID.Hex(), event.Data)
-			}
-		case models.PrivacySettingOnlyMe:
-			h.sendToUser(post.UserID.Hex(), event.Data)
-		default:
-			h.sendToUser(post.UserID.Hex(), event.Data)
+		if err := h.sendToPostAudience(&post, event.Data); err != nil {
+			log.Printf("Error getting friends for post broadcast: %v", err)
+			return
 		}
-		log.Printf("Broadcasted PostCreated event for post %s (Privacy: %s) to friends", post.ID.Hex(), post.Privacy)
+		log.Printf("Broadcasted PostCreated event for post %s (Privacy: %s) to its audience", post.ID.Hex(), post.Privacy)
 
 	case "GROUP_UPDATED", "GROUP_CREATED":
 		var group models.GroupResponse
@@ -677,21 +787,9 @@ func (h *Hub) handleFeedEvent(event models.WebSocketEvent) {
 			log.Printf("Error unmarshaling PostUpdated data: %v", err)
 			return
 		}
-		switch post.Privacy {
-		case models.PrivacySettingPublic, models.PrivacySettingFriends:
-			h.sendToUser(post.UserID.Hex(), event.Data)
-			friends, err := h.friendshipRepo.GetFriends(context.Background(), post.UserID)
-			if err != nil {
-				log.Printf("Error getting friends for post update broadcast: %v", err)
-				return
-			}
-			for _, friend := range friends {
-				h.sendToUser(friend.ID.Hex(), event.Data)
-			}
-		case models.PrivacySettingOnlyMe:
-			h.sendToUser(post.UserID.Hex(), event.Data)
-		default:
-			h.sendToUser(post.UserID.Hex(), event.Data)
+		if err := h.sendToPostAudience(&post, event.Data); err != nil {
+			log.Printf("Error getting friends for post update broadcast: %v", err)
+			return
 		}
 		log.Printf("Broadcasted PostUpdated event for post %s", post.ID.Hex())
 
@@ -701,24 +799,34 @@ func (h *Hub) handleFeedEvent(event models.WebSocketEvent) {
 			log.Printf("Error unmarshaling PostDeleted data: %v", err)
 			return
 		}
-		switch post.Privacy {
-		case models.PrivacySettingPublic, models.PrivacySettingFriends:
-			h.sendToUser(post.UserID.Hex(), event.Data)
-			friends, err := h.friendshipRepo.GetFriends(context.Background(), post.UserID)
-			if err != nil {
-				log.Printf("Error getting friends for post delete broadcast: %v", err)
-				return
-			}
-			for _, friend := range friends {
-				h.sendToUser(friend.ID.Hex(), event.Data)
-			}
-		case models.PrivacySettingOnlyMe:
-			h.sendToUser(post.UserID.Hex(), event.Data)
-		default:
-			h.sendToUser(post.UserID.Hex(), event.Data)
+		if err := h.sendToPostAudience(&post, event.Data); err != nil {
+			log.Printf("Error getting friends for post delete broadcast: %v", err)
+			return
 		}
 		log.Printf("Broadcasted PostDeleted event for post %s", post.ID.Hex())
 
+	case "PollVoteUpdated":
+		var results models.PollVoteUpdatedEvent
+		if err := json.Unmarshal(event.Data, &results); err != nil {
+			log.Printf("Error unmarshaling PollVoteUpdated data: %v", err)
+			return
+		}
+		post, err := h.feedRepo.GetPostByID(context.Background(), results.PostID)
+		if err != nil {
+			log.Printf("Error getting post %s for poll broadcast: %v", results.PostID.Hex(), err)
+			return
+		}
+		eventBytes, err := json.Marshal(event)
+		if err != nil {
+			log.Printf("Error marshaling PollVoteUpdated event wrapper: %v", err)
+			return
+		}
+		if err := h.sendToPostAudience(post, eventBytes); err != nil {
+			log.Printf("Error getting friends for poll broadcast: %v", err)
+			return
+		}
+		log.Printf("Broadcasted PollVoteUpdated event for post %s", results.PostID.Hex())
+
 	case "CommentCreated":
 		var comment models.Comment
 		if err := json.Unmarshal(event.Data, &comment); err != nil {
@@ -737,7 +845,7 @@ func (h *Hub) handleFeedEvent(event models.WebSocketEvent) {
 		switch post.Privacy {
 		case models.PrivacySettingPublic:
 			// For public posts, everyone should see the new comment
-			h.broadcastToAllUsers(event)
+			h.broadcastToAllUsers(event.WebSocketEvent)
 		case models.PrivacySettingFriends:
 			friends, err := h.friendshipRepo.GetFriends(context.Background(), post.UserID)
 			if err == nil {
@@ -773,7 +881,7 @@ func (h *Hub) handleFeedEvent(event models.WebSocketEvent) {
 
 			switch post.Privacy {
 			case models.PrivacySettingPublic:
-				h.broadcastToAllUsers(event)
+				h.broadcastToAllUsers(event.WebSocketEvent)
 			case models.PrivacySettingFriends:
 				friends, err := h.friendshipRepo.GetFriends(context.Background(), post.UserID)
 				if err == nil {
@@ -831,7 +939,7 @@ func (h *Hub) handleFeedEvent(event models.WebSocketEvent) {
 
 		switch privacy {
 		case models.PrivacySettingPublic:
-			h.broadcastToAllUsers(event)
+			h.broadcastToAllUsers(event.WebSocketEvent)
 		case models.PrivacySettingFriends:
 			if !ownerID.IsZero() {
 				friends, err := h.friendshipRepo.GetFriends(context.Background(), ownerID)
@@ -842,12 +950,12 @@ func (h *Hub) handleFeedEvent(event models.WebSocketEvent) {
 				}
 			} else {
 				// Fallback if owner lookup failed but privacy presumed friends (unlikely path)
-				h.broadcastToAllUsers(event)
+				h.broadcastToAllUsers(event.WebSocketEvent)
 			}
 		case models.PrivacySettingOnlyMe:
 			// Already sent to owner above
 		default:
-			h.broadcastToAllUsers(event)
+			h.broadcastToAllUsers(event.WebSocketEvent)
 		}
 
 		log.Printf("Broadcasted %s event for reaction %s on target %s (Privacy: %s)", event.Type, reaction.ID.Hex(), reaction.TargetID.Hex(), privacy)
@@ -869,6 +977,14 @@ func (h *Hub) handleFeedEvent(event models.WebSocketEvent) {
 		// Send to story author
 		h.sendToUser(storyEvent.UserID, event.Data)
 
+		// Stories for a narrower audience name their recipients; one that
+		// arrives without them goes no further than the author
+		switch storyEvent.Privacy {
+		case models.PrivacySettingCloseFriends, models.PrivacySettingCustom, models.PrivacySettingOnlyMe:
+			log.Printf("STORY_CREATED event for %s story %s had no recipients", storyEvent.Privacy, storyEvent.StoryID)
+			return
+		}
+
 		// Send to author's friends
 		friends, err := h.friendshipRepo.GetFriends(context.Background(), userID)
 		if err == nil {
@@ -932,14 +1048,22 @@ func (h *Hub) handleFeedEvent(event models.WebSocketEvent) {
 	}
 }
 
-func (h *Hub) handleNotification(notification models.Notification) {
+func (h *Hub) handleNotification(event NotificationEvent, eventType string) {
+	span := startDeliverySpan(event.SpanContext, eventType)
+	defer span.End()
+
+	notification := event.Notification
+	if h.notificationFilter != nil && !h.notificationFilter.ShouldPush(h.ctx, &notification) {
+		log.Printf("Held back notification %s for user %s per their notification preferences", notification.ID.Hex(), notification.RecipientID.Hex())
+		return
+	}
 	notificationJSON, err := json.Marshal(notification)
 	if err != nil {
 		log.Printf("Error marshaling notification for WebSocket: %v", err)
 		return
 	}
 	wsEvent := models.WebSocketEvent{
-		Type: "NOTIFICATION_CREATED",
+		Type: eventType,
 		Data: notificationJSON,
 	}
 	wsEventJSON, err := json.Marshal(wsEvent)
@@ -948,7 +1072,7 @@ func (h *Hub) handleNotification(notification models.Notification) {
 		return
 	}
 	h.sendToUser(notification.RecipientID.Hex(), wsEventJSON)
-	log.Printf("Sent NOTIFICATION_CREATED event to user %s for notification %s", notification.RecipientID.Hex(), notification.ID.Hex())
+	log.Printf("Sent %s event to user %s for notification %s", eventType, notification.RecipientID.Hex(), notification.ID.Hex())
 }
 
 func (h *Hub) handleReactionEvent(event models.ReactionEvent) {
@@ -974,24 +1098,17 @@ func (h *Hub) handleReadReceiptEvent(event models.ReadReceiptEvent) {
 		Type: "MESSAGE_READ_UPDATE",
 		Data: readReceiptEventJSON,
 	}
-	wsEventJSON, err := json.Marshal(wsEvent)
-	if err != nil {
-		log.Printf("Error marshaling WebSocketEvent for read receipt: %v", err)
-		return
-	}
-
-	h.sendToUser(event.ReaderID.Hex(), wsEventJSON)
 
+	var senders []string
 	for _, msgID := range event.MessageIDs {
 		msg, err := h.messageRepo.GetMessageByID(context.Background(), msgID)
 		if err != nil {
 			log.Printf("Error getting message %s for read receipt: %v", msgID.Hex(), err)
 			continue
 		}
-		if msg.SenderID != event.ReaderID {
-			h.sendToUser(msg.SenderID.Hex(), wsEventJSON)
-		}
+		senders = append(senders, msg.SenderID.Hex())
 	}
+	h.sendJournaled(h.receiptRecipients(event.ReaderID.Hex(), senders), wsEvent)
 }
 
 func (h *Hub) handleMessageEditedEvent(ev models.MessageEditedEvent) {
@@ -1020,11 +1137,6 @@ func (h *Hub) handleConversationSeenEvent(event models.ConversationSeenEvent) {
 		Type: "CONVERSATION_SEEN_UPDATE",
 		Data: conversationSeenEventJSON,
 	}
-	wsEventJSON, err := json.Marshal(wsEvent)
-	if err != nil {
-		log.Printf("Error marshaling WebSocketEvent for conversation seen: %v", err)
-		return
-	}
 
 	if event.IsGroup {
 		group, err := h.groupRepo.GetGroup(context.Background(), event.ConversationID)
@@ -1032,12 +1144,13 @@ func (h *Hub) handleConversationSeenEvent(event models.ConversationSeenEvent) {
 			log.Printf("Error getting group %s for conversation seen event: %v", event.ConversationID.Hex(), err)
 			return
 		}
+		members := make([]string, 0, len(group.Members))
 		for _, memberID := range group.Members {
-			h.sendToUser(memberID.Hex(), wsEventJSON)
+			members = append(members, memberID.Hex())
 		}
+		h.sendJournaled(h.receiptRecipients(event.UserID.Hex(), members), wsEvent)
 	} else {
-		h.sendToUser(event.UserID.Hex(), wsEventJSON)
-		h.sendToUser(event.ConversationID.Hex(), wsEventJSON)
+		h.sendJournaled(h.receiptRecipients(event.UserID.Hex(), []string{event.ConversationID.Hex()}), wsEvent)
 	}
 }
 
@@ -1107,9 +1220,24 @@ func (h *Hub) handleDeliveredEvent(dev models.DeliveredEvent) {
 			h.removeClient(c)
 		}
 	}
+
+	if !msg.GroupID.IsZero() {
+		go h.sendToRemoteGroup(msg.GroupID.Hex(), dev.DelivererID.Hex(), wsEventJSON)
+	} else if !msg.ReceiverID.IsZero() {
+		for _, userID := range []string{msg.SenderID.Hex(), msg.ReceiverID.Hex()} {
+			if userID != dev.DelivererID.Hex() {
+				go h.sendToRemoteUser(userID, wsEventJSON)
+			}
+		}
+	}
 }
 
 func (h *Hub) handleCallSignal(signal models.CallSignalEvent) {
+	if signal.RoomID != "" {
+		h.handleCallRoomSignal(signal)
+		return
+	}
+
 	signalBytes, err := json.Marshal(signal)
 	if err != nil {
 		log.Printf("Error marshaling CallSignalEvent: %v", err)
@@ -1130,6 +1258,52 @@ func (h *Hub) handleCallSignal(signal models.CallSignalEvent) {
 	log.Printf("Forwarded VOICE_CALL_SIGNAL (%s) from %s to %s", signal.SignalType, signal.CallerID, signal.TargetID)
 }
 
+// handleCallRoomSignal relays a group call signal from a joined participant
+// to another, or to all the others when it has no target
+func (h *Hub) handleCallRoomSignal(signal models.CallSignalEvent) {
+	if h.callRooms == nil {
+		return
+	}
+	peers, err := h.callRooms.RoomPeers(h.ctx, signal.RoomID, signal.CallerID)
+	if err != nil {
+		log.Printf("Dropped CALL_ROOM_SIGNAL (%s) from %s in call %s: %v", signal.SignalType, signal.CallerID, signal.RoomID, err)
+		return
+	}
+
+	targets := peers
+	if signal.TargetID != "" {
+		targets = nil
+		for _, peer := range peers {
+			if peer == signal.TargetID {
+				targets = []string{peer}
+				break
+			}
+		}
+		if targets == nil {
+			log.Printf("Dropped CALL_ROOM_SIGNAL (%s) from %s to %s, who is not in call %s", signal.SignalType, signal.CallerID, signal.TargetID, signal.RoomID)
+			return
+		}
+	}
+
+	signalBytes, err := json.Marshal(signal)
+	if err != nil {
+		log.Printf("Error marshaling CallSignalEvent: %v", err)
+		return
+	}
+	wsEventBytes, err := json.Marshal(models.WebSocketEvent{
+		Type: "CALL_ROOM_SIGNAL",
+		Data: signalBytes,
+	})
+	if err != nil {
+		log.Printf("Error marshaling WebSocketEvent for call room signal: %v", err)
+		return
+	}
+
+	for _, target := range targets {
+		h.sendToUser(target, wsEventBytes)
+	}
+}
+
 func (h *Hub) handleEventRSVPEvent(event models.EventRSVPEvent) {
 	eventBytes, err := json.Marshal(event)
 	if err != nil {
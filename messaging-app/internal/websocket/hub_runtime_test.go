@@ -0,0 +1,153 @@
+package websocket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDMPartnerID(t *testing.T) {
+	tests := []struct {
+		name           string
+		conversationID string
+		userID         string
+		want           string
+		wantOK         bool
+	}{
+		{"first participant", "dm_alice_bob", "alice", "bob", true},
+		{"second participant", "dm_alice_bob", "bob", "alice", true},
+		{"self DM", "dm_alice_alice", "alice", "alice", true},
+		{"non-participant sender", "dm_alice_bob", "mallory", "", false},
+		{"missing partner", "dm_alice", "alice", "", false},
+		{"too many parts", "dm_alice_bob_carol", "alice", "", false},
+		{"empty key", "dm_", "alice", "", false},
+		{"empty participant", "dm__bob", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := dmPartnerID(tt.conversationID, tt.userID)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func newTestHub() *Hub {
+	return &Hub{
+		userClients:  make(map[string]map[*Client]bool),
+		groupClients: make(map[string]map[*Client]bool),
+	}
+}
+
+func addTestClient(h *Hub, userID string) *Client {
+	c := &Client{userID: userID, send: make(chan []byte, 4), listeners: map[string]bool{}}
+	if h.userClients[userID] == nil {
+		h.userClients[userID] = make(map[*Client]bool)
+	}
+	h.userClients[userID][c] = true
+	return c
+}
+
+// receivedTyping decodes the one typing event c was sent, if any
+func receivedTyping(t *testing.T, c *Client) (string, *models.TypingEvent) {
+	t.Helper()
+	select {
+	case raw := <-c.send:
+		var wsEvent models.WebSocketEvent
+		require.NoError(t, json.Unmarshal(raw, &wsEvent))
+		var ev models.TypingEvent
+		require.NoError(t, json.Unmarshal(wsEvent.Data, &ev))
+		return wsEvent.Type, &ev
+	default:
+		return "", nil
+	}
+}
+
+func TestHub_DispatchTypingEvent_DM(t *testing.T) {
+	t.Run("reaches the partner only", func(t *testing.T) {
+		h := newTestHub()
+		alice, bob, mallory := addTestClient(h, "alice"), addTestClient(h, "bob"), addTestClient(h, "mallory")
+
+		h.dispatchTypingEvent(models.TypingEvent{UserID: "alice", ConversationID: "dm_alice_bob", IsTyping: true})
+
+		eventType, ev := receivedTyping(t, bob)
+		require.NotNil(t, ev)
+		assert.Equal(t, "TYPING", eventType)
+		assert.Equal(t, "alice", ev.UserID)
+		_, ev = receivedTyping(t, alice)
+		assert.Nil(t, ev)
+		_, ev = receivedTyping(t, mallory)
+		assert.Nil(t, ev)
+	})
+
+	t.Run("drops events from non-participants", func(t *testing.T) {
+		h := newTestHub()
+		alice, bob := addTestClient(h, "alice"), addTestClient(h, "bob")
+
+		h.dispatchTypingEvent(models.TypingEvent{UserID: "mallory", ConversationID: "dm_alice_bob", IsTyping: true})
+
+		_, ev := receivedTyping(t, alice)
+		assert.Nil(t, ev)
+		_, ev = receivedTyping(t, bob)
+		assert.Nil(t, ev)
+	})
+
+	t.Run("drops malformed keys", func(t *testing.T) {
+		h := newTestHub()
+		bob := addTestClient(h, "bob")
+
+		h.dispatchTypingEvent(models.TypingEvent{UserID: "alice", ConversationID: "dm_alice_bob_carol", IsTyping: true})
+
+		_, ev := receivedTyping(t, bob)
+		assert.Nil(t, ev)
+	})
+
+	t.Run("self DM does not echo to the sender", func(t *testing.T) {
+		h := newTestHub()
+		alice := addTestClient(h, "alice")
+
+		h.dispatchTypingEvent(models.TypingEvent{UserID: "alice", ConversationID: "dm_alice_alice", IsTyping: true})
+
+		_, ev := receivedTyping(t, alice)
+		assert.Nil(t, ev)
+	})
+}
+
+func TestHub_DispatchTypingEvent_MarketplaceProduct(t *testing.T) {
+	productID := primitive.NewObjectID().Hex()
+	tests := []struct {
+		name          string
+		isMarketplace bool
+		productID     string
+		wantType      string
+		wantProductID string
+	}{
+		{"valid product", true, productID, "MARKETPLACE_TYPING", productID},
+		{"missing product", true, "", "TYPING", ""},
+		{"invalid product", true, "not-an-object-id", "TYPING", ""},
+		{"product on a personal DM", false, productID, "TYPING", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHub()
+			bob := addTestClient(h, "bob")
+
+			h.dispatchTypingEvent(models.TypingEvent{
+				UserID:         "alice",
+				ConversationID: "dm_alice_bob",
+				IsTyping:       true,
+				IsMarketplace:  tt.isMarketplace,
+				ProductID:      tt.productID,
+			})
+
+			eventType, ev := receivedTyping(t, bob)
+			require.NotNil(t, ev)
+			assert.Equal(t, tt.wantType, eventType)
+			assert.Equal(t, tt.wantProductID, ev.ProductID)
+		})
+	}
+}
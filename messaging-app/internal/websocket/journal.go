@@ -0,0 +1,227 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const journalKeyPrefix = "ws:journal:"
+
+var (
+	// ErrJournalDisabled is returned for replays while the event journal is off
+	ErrJournalDisabled = apperrors.Unavailable("the event journal is disabled")
+	// ErrInvalidJournalCursor is returned for cursors not issued by the journal
+	ErrInvalidJournalCursor = apperrors.Validation("invalid event cursor")
+)
+
+// eventJournal keeps each user's recent reactions, edits and read receipts in
+// a Redis stream so a client that reconnects can replay what it missed.
+// Messages are not journaled; they are queued for offline users instead.
+type eventJournal struct {
+	ttl    time.Duration // Journals expire this long after their last event
+	maxLen int64         // Older events are trimmed beyond this many
+}
+
+// SetJournal turns on the event journal. Events are kept for ttl and at most
+// maxEvents per user; cursors older than ttl are answered with a reset.
+func (h *Hub) SetJournal(ttl time.Duration, maxEvents int) {
+	if ttl <= 0 || maxEvents <= 0 {
+		h.journal = nil
+		return
+	}
+	h.journal = &eventJournal{ttl: ttl, maxLen: int64(maxEvents)}
+}
+
+func journalKey(userID string) string {
+	return journalKeyPrefix + userID
+}
+
+// sendJournaled sends event to each user, recording it in their journal
+// first. Users are told their own journal cursor; if recording fails the
+// event is still sent, without one.
+func (h *Hub) sendJournaled(userIDs []string, event models.WebSocketEvent) {
+	cursors := h.appendJournal(userIDs, event)
+	for _, userID := range userIDs {
+		event.JournalCursor = cursors[userID]
+		message, err := json.Marshal(event)
+		if err != nil {
+			log.Printf("Error marshaling WebSocketEvent %s: %v", event.Type, err)
+			return
+		}
+		h.sendToUser(userID, message)
+	}
+}
+
+// appendJournal adds event to the users' journals, returning the cursor of
+// each entry added
+func (h *Hub) appendJournal(userIDs []string, event models.WebSocketEvent) map[string]string {
+	if h.journal == nil || h.redisClient == nil || len(userIDs) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Error marshaling %s for the event journal: %v", event.Type, err)
+		return nil
+	}
+
+	client := h.redisClient.GetClient()
+	adds := make(map[string]*redis.StringCmd, len(userIDs))
+	_, err = client.Pipelined(h.ctx, func(pipe redis.Pipeliner) error {
+		for _, userID := range userIDs {
+			key := journalKey(userID)
+			adds[userID] = pipe.XAdd(h.ctx, &redis.XAddArgs{
+				Stream: key,
+				MaxLen: h.journal.maxLen,
+				Values: map[string]interface{}{"event": data},
+			})
+			pipe.Expire(h.ctx, key, h.journal.ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error journaling %s for %d users: %v", event.Type, len(userIDs), err)
+	}
+
+	cursors := make(map[string]string, len(adds))
+	for userID, add := range adds {
+		if id, err := add.Result(); err == nil {
+			cursors[userID] = id
+		}
+	}
+	return cursors
+}
+
+// Replay returns up to limit of the user's journaled events after since, in
+// order. An empty since replays the whole journal.
+func (h *Hub) Replay(ctx context.Context, userID, since string, limit int) (*models.EventReplay, error) {
+	if h.journal == nil || h.redisClient == nil {
+		return nil, ErrJournalDisabled
+	}
+	client := h.redisClient.GetClient()
+	key := journalKey(userID)
+
+	start := "-"
+	if since != "" {
+		ms, _, err := parseJournalCursor(since)
+		if err != nil {
+			return nil, err
+		}
+		missed, err := h.journalMissed(ctx, key, since, ms)
+		if err != nil {
+			return nil, err
+		}
+		if missed {
+			return h.journalReset(ctx, key)
+		}
+		start = "(" + since
+	}
+
+	entries, err := client.XRangeN(ctx, key, start, "+", int64(limit)+1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event journal: %w", err)
+	}
+	replay := &models.EventReplay{Events: []models.WebSocketEvent{}, Cursor: since}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		replay.HasMore = true
+	}
+	for _, entry := range entries {
+		replay.Cursor = entry.ID
+		event, ok := journalEvent(entry)
+		if !ok {
+			log.Printf("Skipping malformed event %s in the journal of user %s", entry.ID, userID)
+			continue
+		}
+		replay.Events = append(replay.Events, event)
+	}
+	return replay, nil
+}
+
+// journalMissed reports whether events after since may have left the
+// journal: trimmed from a full journal, or expired with it
+func (h *Hub) journalMissed(ctx context.Context, key, since string, sinceMillis int64) (bool, error) {
+	if time.Since(time.UnixMilli(sinceMillis)) > h.journal.ttl {
+		return true, nil
+	}
+	info, err := h.redisClient.GetClient().XInfoStream(ctx, key).Result()
+	if err != nil {
+		// A journal within its TTL of since only disappears if nothing was
+		// added after since
+		if strings.Contains(err.Error(), "no such key") {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to inspect event journal: %w", err)
+	}
+	if info.MaxDeletedEntryID == "" {
+		return false, nil
+	}
+	return compareJournalCursors(since, info.MaxDeletedEntryID) < 0, nil
+}
+
+// journalReset tells the client to reload, resuming after the newest event
+func (h *Hub) journalReset(ctx context.Context, key string) (*models.EventReplay, error) {
+	replay := &models.EventReplay{Events: []models.WebSocketEvent{}, Reset: true}
+	latest, err := h.redisClient.GetClient().XRevRangeN(ctx, key, "+", "-", 1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event journal: %w", err)
+	}
+	if len(latest) > 0 {
+		replay.Cursor = latest[0].ID
+	}
+	return replay, nil
+}
+
+func journalEvent(entry redis.XMessage) (models.WebSocketEvent, bool) {
+	raw, ok := entry.Values["event"].(string)
+	if !ok {
+		return models.WebSocketEvent{}, false
+	}
+	var event models.WebSocketEvent
+	if err := json.Unmarshal([]byte(raw), &event); err != nil {
+		return models.WebSocketEvent{}, false
+	}
+	event.JournalCursor = entry.ID
+	return event, true
+}
+
+// parseJournalCursor splits a stream entry ID into its milliseconds and
+// sequence number
+func parseJournalCursor(cursor string) (int64, int64, error) {
+	msPart, seqPart, ok := strings.Cut(cursor, "-")
+	if !ok {
+		return 0, 0, ErrInvalidJournalCursor
+	}
+	ms, err := strconv.ParseInt(msPart, 10, 64)
+	if err != nil || ms < 0 {
+		return 0, 0, ErrInvalidJournalCursor
+	}
+	seq, err := strconv.ParseInt(seqPart, 10, 64)
+	if err != nil || seq < 0 {
+		return 0, 0, ErrInvalidJournalCursor
+	}
+	return ms, seq, nil
+}
+
+// compareJournalCursors orders two valid cursors like strings.Compare
+func compareJournalCursors(a, b string) int {
+	aMs, aSeq, _ := parseJournalCursor(a)
+	bMs, bSeq, _ := parseJournalCursor(b)
+	switch {
+	case aMs < bMs || (aMs == bMs && aSeq < bSeq):
+		return -1
+	case aMs == bMs && aSeq == bSeq:
+		return 0
+	default:
+		return 1
+	}
+}
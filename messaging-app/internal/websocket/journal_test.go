@@ -0,0 +1,42 @@
+package websocket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJournalCursor(t *testing.T) {
+	ms, seq, err := parseJournalCursor("1700000000000-3")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000000), ms)
+	assert.Equal(t, int64(3), seq)
+
+	for _, cursor := range []string{"1700000000000", "abc-1", "1-x", "-1-0", "0:1"} {
+		_, _, err := parseJournalCursor(cursor)
+		assert.ErrorIs(t, err, ErrInvalidJournalCursor, cursor)
+	}
+}
+
+func TestCompareJournalCursors(t *testing.T) {
+	assert.Equal(t, -1, compareJournalCursors("5-9", "6-0"))
+	assert.Equal(t, -1, compareJournalCursors("6-1", "6-2"))
+	assert.Equal(t, 0, compareJournalCursors("6-2", "6-2"))
+	assert.Equal(t, 1, compareJournalCursors("10-0", "9-5"))
+}
+
+func TestHub_SendJournaled_WithoutJournal(t *testing.T) {
+	h := newTestHub()
+	client := addTestClient(h, "u1")
+
+	h.sendJournaled([]string{"u1"}, models.WebSocketEvent{Type: "MESSAGE_REACTION_UPDATE", Data: json.RawMessage(`{}`)})
+
+	require.Len(t, client.send, 1)
+	var event models.WebSocketEvent
+	require.NoError(t, json.Unmarshal(<-client.send, &event))
+	assert.Equal(t, "MESSAGE_REACTION_UPDATE", event.Type)
+	assert.Empty(t, event.JournalCursor)
+}
@@ -0,0 +1,45 @@
+package websocket
+
+import "time"
+
+// loopbackBuffer is how many events a loopback client holds before the Hub
+// drops it like any other slow client
+const loopbackBuffer = 256
+
+// Loopback is an in-process client of the Hub. It is sent what a WebSocket of
+// its user would be, minus the network hop, so the delivery canary can watch
+// messages come out of the Hub.
+type Loopback struct {
+	hub    *Hub
+	client *Client
+}
+
+// ConnectLoopback registers a loopback client for userID. It counts as one of
+// the user's connections, so the user shows as online.
+func (h *Hub) ConnectLoopback(userID string) *Loopback {
+	c := &Client{
+		userID:    userID,
+		send:      make(chan []byte, loopbackBuffer),
+		lastSeen:  time.Now(),
+		listeners: make(map[string]bool),
+	}
+	select {
+	case h.register <- c:
+	case <-h.ctx.Done():
+	}
+	return &Loopback{hub: h, client: c}
+}
+
+// Events are the raw events sent to the client. The channel is closed when
+// the Hub drops the client, e.g. because Events was not drained.
+func (l *Loopback) Events() <-chan []byte {
+	return l.client.send
+}
+
+// Close unregisters the client
+func (l *Loopback) Close() {
+	select {
+	case l.hub.unregister <- l.client:
+	case <-l.hub.ctx.Done():
+	}
+}
@@ -0,0 +1,69 @@
+package websocket
+
+import (
+	"encoding/json"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// FanoutMessage is a message delivered through the Kafka hub fan-out, tagged
+// with its replay cursor.
+type FanoutMessage struct {
+	models.Message
+	Cursor string
+}
+
+// FeedEvent is a feed or story event handed to the hub, carrying the span of
+// the Kafka consumer that received it so delivery joins the same trace.
+type FeedEvent struct {
+	models.WebSocketEvent
+	SpanContext trace.SpanContext
+}
+
+// NotificationEvent is a notification handed to the hub, carrying the span of
+// the Kafka consumer that received it.
+type NotificationEvent struct {
+	models.Notification
+	SpanContext trace.SpanContext
+}
+
+// NewMessageEvent builds the WebSocket event clients receive for a message.
+// cursor is empty for messages that did not arrive through the Kafka fan-out.
+func NewMessageEvent(msg models.Message, cursor string) (models.WebSocketEvent, error) {
+	var msgToMarshal interface{} = msg
+	if msg.GroupID.IsZero() {
+		type shadowedMessage struct {
+			models.Message
+			GroupID *string `json:"group_id,omitempty"`
+		}
+		msgToMarshal = shadowedMessage{
+			Message: msg,
+			GroupID: nil,
+		}
+	}
+
+	msgData, err := json.Marshal(msgToMarshal)
+	if err != nil {
+		return models.WebSocketEvent{}, err
+	}
+
+	eventType := "MESSAGE_CREATED"
+	if msg.IsMarketplace {
+		eventType = "MARKETPLACE_MESSAGE_CREATED"
+	}
+
+	if msg.ContentType == "deleted" || msg.ContentType == models.ContentTypeDeleted {
+		if msg.IsMarketplace {
+			eventType = "MARKETPLACE_MESSAGE_DELETED"
+		} else {
+			eventType = "MESSAGE_DELETED"
+		}
+	}
+
+	return models.WebSocketEvent{
+		Type:   eventType,
+		Data:   msgData,
+		Cursor: cursor,
+	}, nil
+}
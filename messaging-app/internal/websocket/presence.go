@@ -0,0 +1,75 @@
+package websocket
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// presenceContacts returns the users who follow userID's presence, mapped to
+// whether they are friends: their friends and marketplace partners
+func (h *Hub) presenceContacts(userID primitive.ObjectID) map[string]bool {
+	contacts := make(map[string]bool)
+
+	friends, err := h.friendshipRepo.GetFriends(h.ctx, userID)
+	if err != nil {
+		log.Printf("Error getting friends for presence: %v", err)
+	}
+	for _, f := range friends {
+		contacts[f.ID.Hex()] = true
+	}
+
+	partners, err := h.marketplacePartnerIDs(userID)
+	if err != nil {
+		log.Printf("Error getting marketplace partners for presence: %v", err)
+	}
+	for _, partnerID := range partners {
+		if _, isFriend := contacts[partnerID.Hex()]; !isFriend {
+			contacts[partnerID.Hex()] = false
+		}
+	}
+	return contacts
+}
+
+// presenceVisibilities returns who each user shares their presence with. If
+// it cannot be looked up, presence is shared with no one.
+func (h *Hub) presenceVisibilities(userIDs []string) map[string]models.PrivacySettingType {
+	visibilities := make(map[string]models.PrivacySettingType, len(userIDs))
+	if h.presencePrivacy == nil {
+		return visibilities
+	}
+
+	oids := make([]primitive.ObjectID, 0, len(userIDs))
+	for _, id := range userIDs {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+	byOID, err := h.presencePrivacy.PresenceVisibilities(h.ctx, oids)
+	if err != nil {
+		log.Printf("Error getting presence visibility, hiding presence: %v", err)
+		for _, id := range userIDs {
+			visibilities[id] = models.PrivacySettingNoOne
+		}
+		return visibilities
+	}
+	for oid, visibility := range byOID {
+		visibilities[oid.Hex()] = visibility
+	}
+	return visibilities
+}
+
+// presenceEvent is the presence_update event announcing userID's status, with
+// their last activity coarsened
+func presenceEvent(userID, status string, lastSeen time.Time) []byte {
+	data, _ := json.Marshal(struct {
+		UserID string `json:"user_id"`
+		models.Presence
+	}{UserID: userID, Presence: models.NewPresence(status, lastSeen, time.Now())})
+	event, _ := json.Marshal(models.WebSocketEvent{Type: "presence_update", Data: data})
+	return event
+}
@@ -0,0 +1,98 @@
+package websocket
+
+import (
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// receiptSettingsTTL bounds how long a changed read receipt or typing
+	// setting can go unnoticed; typing events are too frequent to look it up
+	// every time
+	receiptSettingsTTL  = 30 * time.Second
+	receiptSettingsSize = 50000
+)
+
+type cachedMessagingPrivacy struct {
+	privacy  models.MessagingPrivacy
+	loadedAt time.Time
+}
+
+// messagingPrivacy returns what each user shares. Without a lookup everyone
+// shares everything; users whose settings cannot be looked up share nothing.
+func (h *Hub) messagingPrivacy(userIDs ...string) map[string]models.MessagingPrivacy {
+	privacy := make(map[string]models.MessagingPrivacy, len(userIDs))
+	if h.receiptPrivacy == nil {
+		for _, id := range userIDs {
+			privacy[id] = models.DefaultMessagingPrivacy()
+		}
+		return privacy
+	}
+
+	now := time.Now()
+	var missing []primitive.ObjectID
+	for _, id := range userIDs {
+		if _, done := privacy[id]; done {
+			continue
+		}
+		if cached, ok := h.receiptSettings.Get(id); ok && now.Sub(cached.loadedAt) < receiptSettingsTTL {
+			privacy[id] = cached.privacy
+			continue
+		}
+		privacy[id] = models.MessagingPrivacy{}
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			missing = append(missing, oid)
+		}
+	}
+	if len(missing) == 0 {
+		return privacy
+	}
+
+	loaded, err := h.receiptPrivacy.MessagingPrivacy(h.ctx, missing)
+	if err != nil {
+		log.Printf("Error getting read receipt and typing settings, hiding them: %v", err)
+		return privacy
+	}
+	for oid, p := range loaded {
+		privacy[oid.Hex()] = p
+		h.receiptSettings.Add(oid.Hex(), cachedMessagingPrivacy{privacy: p, loadedAt: now})
+	}
+	return privacy
+}
+
+// typingViewers keeps the clients, other than the typist's, whose users see
+// typing indicators
+func (h *Hub) typingViewers(clients []*Client, typistID string) []*Client {
+	ids := make([]string, 0, len(clients))
+	for _, c := range clients {
+		ids = append(ids, c.userID)
+	}
+	privacy := h.messagingPrivacy(ids...)
+
+	viewers := clients[:0:0]
+	for _, c := range clients {
+		if c.userID != typistID && privacy[c.userID].TypingIndicators {
+			viewers = append(viewers, c)
+		}
+	}
+	return viewers
+}
+
+// receiptRecipients returns who is told that readerID read something: the
+// reader's own devices, plus those of the others when both share receipts
+func (h *Hub) receiptRecipients(readerID string, others []string) []string {
+	privacy := h.messagingPrivacy(append([]string{readerID}, others...)...)
+	recipients := []string{readerID}
+	added := map[string]bool{readerID: true}
+	for _, id := range others {
+		if !added[id] && models.ReadReceiptsShared(privacy[readerID], privacy[id]) {
+			added[id] = true
+			recipients = append(recipients, id)
+		}
+	}
+	return recipients
+}
@@ -0,0 +1,91 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeReceiptPrivacy shares everything except for the users listed
+type fakeReceiptPrivacy struct {
+	noReceipts map[string]bool
+	noTyping   map[string]bool
+	err        error
+}
+
+func (f fakeReceiptPrivacy) MessagingPrivacy(_ context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]models.MessagingPrivacy, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	privacy := make(map[primitive.ObjectID]models.MessagingPrivacy, len(userIDs))
+	for _, id := range userIDs {
+		privacy[id] = models.MessagingPrivacy{ReadReceipts: !f.noReceipts[id.Hex()], TypingIndicators: !f.noTyping[id.Hex()]}
+	}
+	return privacy, nil
+}
+
+func TestHub_DispatchTypingEvent_Privacy(t *testing.T) {
+	alice, bob := primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()
+	dm := "dm_" + alice + "_" + bob
+
+	t.Run("typist hiding typing sends none", func(t *testing.T) {
+		h := newTestHub()
+		h.SetReceiptPrivacy(fakeReceiptPrivacy{noTyping: map[string]bool{alice: true}})
+		bobClient := addTestClient(h, bob)
+
+		h.dispatchTypingEvent(models.TypingEvent{UserID: alice, ConversationID: dm, IsTyping: true})
+
+		assert.Empty(t, bobClient.send)
+	})
+
+	t.Run("viewer hiding typing sees none", func(t *testing.T) {
+		h := newTestHub()
+		h.SetReceiptPrivacy(fakeReceiptPrivacy{noTyping: map[string]bool{bob: true}})
+		bobClient := addTestClient(h, bob)
+
+		h.dispatchTypingEvent(models.TypingEvent{UserID: alice, ConversationID: dm, IsTyping: true})
+
+		assert.Empty(t, bobClient.send)
+	})
+
+	t.Run("group members hiding typing are skipped", func(t *testing.T) {
+		carol := primitive.NewObjectID().Hex()
+		h := newTestHub()
+		h.SetReceiptPrivacy(fakeReceiptPrivacy{noTyping: map[string]bool{carol: true}})
+		aliceClient, bobClient, carolClient := addTestClient(h, alice), addTestClient(h, bob), addTestClient(h, carol)
+		h.groupClients["g1"] = map[*Client]bool{aliceClient: true, bobClient: true, carolClient: true}
+
+		h.dispatchTypingEvent(models.TypingEvent{UserID: alice, ConversationID: "group-g1", IsTyping: true})
+
+		assert.Empty(t, aliceClient.send)
+		assert.Len(t, bobClient.send, 1)
+		assert.Empty(t, carolClient.send)
+	})
+
+	t.Run("unknown settings hide typing", func(t *testing.T) {
+		h := newTestHub()
+		h.SetReceiptPrivacy(fakeReceiptPrivacy{err: errors.New("mongo down")})
+		bobClient := addTestClient(h, bob)
+
+		h.dispatchTypingEvent(models.TypingEvent{UserID: alice, ConversationID: dm, IsTyping: true})
+
+		assert.Empty(t, bobClient.send)
+	})
+}
+
+func TestHub_ReceiptRecipients(t *testing.T) {
+	reader, sharer, hider := primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()
+
+	h := newTestHub()
+	assert.Equal(t, []string{reader, sharer, hider}, h.receiptRecipients(reader, []string{sharer, hider, sharer}))
+
+	h.SetReceiptPrivacy(fakeReceiptPrivacy{noReceipts: map[string]bool{hider: true}})
+	assert.Equal(t, []string{reader, sharer}, h.receiptRecipients(reader, []string{sharer, hider}))
+
+	h.SetReceiptPrivacy(fakeReceiptPrivacy{noReceipts: map[string]bool{reader: true}})
+	assert.Equal(t, []string{reader}, h.receiptRecipients(reader, []string{sharer, hider}))
+}
@@ -1,8 +1,10 @@
 package websocket
 
 import (
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
@@ -48,6 +50,7 @@ func ServeWs(c *gin.Context, hub *Hub) {
 
 	client := &Client{
 		userID:    userID.Hex(),
+		sessionID: c.GetString("session_id"),
 		conn:      conn,
 		send:      make(chan []byte, 256),
 		lastSeen:  time.Now(),
@@ -58,3 +61,42 @@ func ServeWs(c *gin.Context, hub *Hub) {
 	go client.writePump()
 	go client.readPump(hub)
 }
+
+// @Summary Replay missed WebSocket events
+// @Description Replay the reactions, edits and read receipts sent to the user after a journal cursor so a reconnecting client can catch up
+// @Tags websocket
+// @Produce json
+// @Security ApiKeyAuth
+// @Param since query string false "journal_cursor of the last event received; omit to replay the whole journal"
+// @Param limit query int false "Maximum events to return (default 100, max 500)"
+// @Success 200 {object} models.EventReplay
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /ws/replay [get]
+func ServeReplay(c *gin.Context, hub *Hub) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil || userID.IsZero() {
+		utils.RespondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	replay, err := hub.Replay(c.Request.Context(), userID.Hex(), c.Query("since"), limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidJournalCursor):
+			utils.RespondWithError(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, ErrJournalDisabled):
+			utils.RespondWithError(c, http.StatusServiceUnavailable, err.Error())
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, replay)
+}
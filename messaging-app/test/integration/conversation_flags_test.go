@@ -0,0 +1,122 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"messaging-app/internal/db"
+	"messaging-app/internal/repositories"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/suite"
+)
+
+const maxPinnedConversations = 5
+
+type ConversationFlagsIntegrationTestSuite struct {
+	suite.Suite
+	client *db.CassandraClient
+	repo   *repositories.MessageCassandraRepository
+	ctx    context.Context
+}
+
+func (suite *ConversationFlagsIntegrationTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	client, err := db.NewCassandraClient(
+		strings.Split(os.Getenv("CASSANDRA_HOSTS"), ","),
+		"test_conversation_flags",
+		os.Getenv("CASSANDRA_USER"),
+		os.Getenv("CASSANDRA_PASSWORD"),
+	)
+	suite.Require().NoError(err)
+	suite.client = client
+	suite.repo = repositories.NewMessageCassandraRepository(client)
+}
+
+func (suite *ConversationFlagsIntegrationTestSuite) TearDownSuite() {
+	suite.client.Close()
+}
+
+func TestConversationFlagsIntegrationTestSuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests")
+	}
+	if os.Getenv("CASSANDRA_HOSTS") == "" {
+		t.Skip("CASSANDRA_HOSTS is not set")
+	}
+	suite.Run(t, new(ConversationFlagsIntegrationTestSuite))
+}
+
+func (suite *ConversationFlagsIntegrationTestSuite) TestConcurrentFlagChangesKeepEachOther() {
+	userID := gocql.TimeUUID().String()
+	conversationID := "dm_" + gocql.TimeUUID().String()
+	yes := true
+
+	var wg sync.WaitGroup
+	for _, req := range []models.UpdateConversationFlagsRequest{{Pinned: &yes}, {Archived: &yes}, {Muted: &yes}} {
+		wg.Add(1)
+		go func(req models.UpdateConversationFlagsRequest) {
+			defer wg.Done()
+			suite.NoError(suite.repo.UpdateConversationFlags(suite.ctx, userID, conversationID, req, time.Now()))
+		}(req)
+	}
+	wg.Wait()
+
+	flags, err := suite.repo.GetConversationFlags(suite.ctx, userID, conversationID)
+	suite.Require().NoError(err)
+	suite.True(flags.Pinned)
+	suite.True(flags.Archived)
+	suite.True(flags.Muted)
+
+	no := false
+	suite.Require().NoError(suite.repo.UpdateConversationFlags(suite.ctx, userID, conversationID, models.UpdateConversationFlagsRequest{Muted: &no}, time.Now()))
+	flags, err = suite.repo.GetConversationFlags(suite.ctx, userID, conversationID)
+	suite.Require().NoError(err)
+	suite.True(flags.Pinned, "flags left out of the update are kept")
+	suite.True(flags.Archived)
+	suite.False(flags.Muted)
+}
+
+func (suite *ConversationFlagsIntegrationTestSuite) TestConcurrentPinsNeverExceedLimit() {
+	userID := gocql.TimeUUID().String()
+	attempts := maxPinnedConversations * 3
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		pinned int
+	)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := suite.repo.PinConversation(suite.ctx, userID, "dm_"+gocql.TimeUUID().String(), time.Now(), maxPinnedConversations)
+			if err != nil && !errors.Is(err, repositories.ErrPinnedConversationLimit) {
+				suite.Fail("unexpected pin error", err)
+			}
+			if err == nil {
+				mu.Lock()
+				pinned++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	all, err := suite.repo.ListConversationFlags(suite.ctx, userID)
+	suite.Require().NoError(err)
+	stored := 0
+	for _, flags := range all {
+		if flags.Pinned {
+			stored++
+		}
+	}
+	suite.LessOrEqual(stored, maxPinnedConversations)
+	suite.Equal(pinned, stored, "every reported pin is stored and every rolled back one is gone")
+}
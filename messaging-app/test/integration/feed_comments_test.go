@@ -0,0 +1,170 @@
+package integration
+
+import (
+	"context"
+	"messaging-app/internal/repositories"
+	"messaging-app/internal/services"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FeedCommentsIntegrationTestSuite struct {
+	suite.Suite
+	feedService *services.FeedService
+	mongoClient *mongo.Client
+	db          *mongo.Database
+	ctx         context.Context
+
+	viewerID     primitive.ObjectID
+	postAuthorID primitive.ObjectID
+	postID       primitive.ObjectID
+	// Comments in the order they were written
+	popular, byFriend, byPostAuthor, repliedTo, plain primitive.ObjectID
+}
+
+func (suite *FeedCommentsIntegrationTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	mongoClient, err := mongo.Connect(suite.ctx, options.Client().ApplyURI(os.Getenv("MONGO_URI")))
+	suite.Require().NoError(err)
+	suite.mongoClient = mongoClient
+	suite.db = mongoClient.Database("test_feed_comments_db")
+
+	feedRepo := repositories.NewFeedRepository(suite.db)
+	suite.Require().NoError(feedRepo.EnsureIndexes(suite.ctx))
+	suite.feedService = services.NewFeedService(
+		feedRepo,
+		repositories.NewUserRepository(suite.db),
+		repositories.NewFriendshipRepository(suite.db),
+		nil, nil, nil, nil, nil,
+	)
+}
+
+func (suite *FeedCommentsIntegrationTestSuite) TearDownSuite() {
+	suite.db.Drop(suite.ctx)
+	suite.mongoClient.Disconnect(suite.ctx)
+}
+
+// SetupTest seeds a post with one comment for each relevance signal:
+// reactions, a friend of the viewer, the post's author, and a reply from
+// the post's author. The plain comment has none of them.
+func (suite *FeedCommentsIntegrationTestSuite) SetupTest() {
+	for _, name := range []string{"posts", "comments", "replies", "friendships"} {
+		_, err := suite.db.Collection(name).DeleteMany(suite.ctx, bson.M{})
+		suite.Require().NoError(err)
+	}
+
+	suite.viewerID = primitive.NewObjectID()
+	suite.postAuthorID = primitive.NewObjectID()
+	friendID := primitive.NewObjectID()
+	stranger := primitive.NewObjectID()
+	now := time.Now()
+
+	_, err := suite.db.Collection("friendships").InsertOne(suite.ctx, models.Friendship{
+		RequesterID: suite.viewerID,
+		ReceiverID:  friendID,
+		Status:      models.FriendshipStatusAccepted,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	suite.Require().NoError(err)
+
+	suite.postID = primitive.NewObjectID()
+	_, err = suite.db.Collection("posts").InsertOne(suite.ctx, bson.M{
+		"_id":            suite.postID,
+		"user_id":        suite.postAuthorID,
+		"content":        "post",
+		"total_comments": 5,
+		"created_at":     now.Add(-time.Hour),
+	})
+	suite.Require().NoError(err)
+
+	comment := func(authorID primitive.ObjectID, reactions int64, age time.Duration) primitive.ObjectID {
+		id := primitive.NewObjectID()
+		_, err := suite.db.Collection("comments").InsertOne(suite.ctx, bson.M{
+			"_id":             id,
+			"post_id":         suite.postID,
+			"user_id":         authorID,
+			"content":         "comment",
+			"total_reactions": reactions,
+			"created_at":      now.Add(-age),
+			"updated_at":      now.Add(-age),
+		})
+		suite.Require().NoError(err)
+		return id
+	}
+	suite.popular = comment(stranger, 10, 5*time.Minute)
+	suite.byFriend = comment(friendID, 0, 4*time.Minute)
+	suite.byPostAuthor = comment(suite.postAuthorID, 0, 3*time.Minute)
+	suite.repliedTo = comment(stranger, 0, 2*time.Minute)
+	suite.plain = comment(stranger, 0, time.Minute)
+
+	_, err = suite.db.Collection("replies").InsertOne(suite.ctx, bson.M{
+		"comment_id": suite.repliedTo,
+		"user_id":    suite.postAuthorID,
+		"content":    "reply",
+		"created_at": now,
+		"updated_at": now,
+	})
+	suite.Require().NoError(err)
+}
+
+func TestFeedCommentsIntegrationTestSuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests")
+	}
+	if os.Getenv("MONGO_URI") == "" {
+		t.Skip("MONGO_URI is not set")
+	}
+	suite.Run(t, new(FeedCommentsIntegrationTestSuite))
+}
+
+func (suite *FeedCommentsIntegrationTestSuite) commentIDs(sort models.CommentSort, page, limit int64) []primitive.ObjectID {
+	comments, err := suite.feedService.GetCommentsByPostID(suite.ctx, suite.viewerID, suite.postID, page, limit, sort)
+	suite.Require().NoError(err)
+	ids := make([]primitive.ObjectID, len(comments))
+	for i, c := range comments {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+func (suite *FeedCommentsIntegrationTestSuite) TestChronologicalSorts() {
+	suite.Equal([]primitive.ObjectID{suite.popular, suite.byFriend, suite.byPostAuthor, suite.repliedTo, suite.plain}, suite.commentIDs("", 1, 20))
+	suite.Equal([]primitive.ObjectID{suite.plain, suite.repliedTo, suite.byPostAuthor, suite.byFriend, suite.popular}, suite.commentIDs(models.CommentSortNewest, 1, 20))
+}
+
+func (suite *FeedCommentsIntegrationTestSuite) TestRelevantSort() {
+	// Reactions outweigh the post's author, which outweighs a friend; the
+	// author's two comments tie on signals, so the newer one goes first
+	want := []primitive.ObjectID{suite.popular, suite.repliedTo, suite.byPostAuthor, suite.byFriend, suite.plain}
+	suite.Equal(want, suite.commentIDs(models.CommentSortRelevant, 1, 20))
+
+	suite.Equal(want[:2], suite.commentIDs(models.CommentSortRelevant, 1, 2))
+	suite.Equal(want[2:4], suite.commentIDs(models.CommentSortRelevant, 2, 2))
+}
+
+func (suite *FeedCommentsIntegrationTestSuite) TestRelevantSortFallsBackToTopComments() {
+	_, err := suite.db.Collection("posts").UpdateOne(suite.ctx,
+		bson.M{"_id": suite.postID},
+		bson.M{"$set": bson.M{"total_comments": 2001}},
+	)
+	suite.Require().NoError(err)
+
+	// Past the scan limit only reactions and recency count
+	want := []primitive.ObjectID{suite.popular, suite.plain, suite.repliedTo, suite.byPostAuthor, suite.byFriend}
+	suite.Equal(want, suite.commentIDs(models.CommentSortRelevant, 1, 20))
+}
+
+func (suite *FeedCommentsIntegrationTestSuite) TestUnknownSortIsRejected() {
+	_, err := suite.feedService.GetCommentsByPostID(suite.ctx, suite.viewerID, suite.postID, 1, 20, "top")
+	suite.ErrorIs(err, services.ErrInvalidCommentSort)
+}
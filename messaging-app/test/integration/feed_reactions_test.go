@@ -0,0 +1,147 @@
+package integration
+
+import (
+	"context"
+	"messaging-app/internal/repositories"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FeedReactionsIntegrationTestSuite struct {
+	suite.Suite
+	feedRepo    *repositories.FeedRepository
+	mongoClient *mongo.Client
+	db          *mongo.Database
+	ctx         context.Context
+
+	postID primitive.ObjectID
+}
+
+func (suite *FeedReactionsIntegrationTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	mongoClient, err := mongo.Connect(suite.ctx, options.Client().ApplyURI(os.Getenv("MONGO_URI")))
+	suite.Require().NoError(err)
+	suite.mongoClient = mongoClient
+	suite.db = mongoClient.Database("test_feed_reactions_db")
+
+	suite.feedRepo = repositories.NewFeedRepository(suite.db)
+	suite.Require().NoError(suite.feedRepo.EnsureIndexes(suite.ctx))
+}
+
+func (suite *FeedReactionsIntegrationTestSuite) TearDownSuite() {
+	suite.db.Drop(suite.ctx)
+	suite.mongoClient.Disconnect(suite.ctx)
+}
+
+func (suite *FeedReactionsIntegrationTestSuite) SetupTest() {
+	for _, name := range []string{"posts", "comments", "reactions"} {
+		_, err := suite.db.Collection(name).DeleteMany(suite.ctx, bson.M{})
+		suite.Require().NoError(err)
+	}
+	suite.postID = primitive.NewObjectID()
+	_, err := suite.db.Collection("posts").InsertOne(suite.ctx, bson.M{
+		"_id":             suite.postID,
+		"user_id":         primitive.NewObjectID(),
+		"content":         "post",
+		"total_reactions": 0,
+		"created_at":      time.Now(),
+	})
+	suite.Require().NoError(err)
+}
+
+func TestFeedReactionsIntegrationTestSuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests")
+	}
+	if os.Getenv("MONGO_URI") == "" {
+		t.Skip("MONGO_URI is not set")
+	}
+	suite.Run(t, new(FeedReactionsIntegrationTestSuite))
+}
+
+func (suite *FeedReactionsIntegrationTestSuite) react(userID primitive.ObjectID, reactionType models.ReactionType) models.ReactionAction {
+	_, action, err := suite.feedRepo.ToggleReaction(suite.ctx, &models.Reaction{
+		UserID:     userID,
+		TargetID:   suite.postID,
+		TargetType: "post",
+		Type:       reactionType,
+	})
+	suite.Require().NoError(err)
+	return action
+}
+
+func (suite *FeedReactionsIntegrationTestSuite) totalReactions() int64 {
+	var post models.Post
+	suite.Require().NoError(suite.db.Collection("posts").FindOne(suite.ctx, bson.M{"_id": suite.postID}).Decode(&post))
+	return post.TotalReactions
+}
+
+func (suite *FeedReactionsIntegrationTestSuite) TestToggleReaction_AddsChangesAndRemoves() {
+	userID := primitive.NewObjectID()
+
+	suite.Equal(models.ReactionAdded, suite.react(userID, models.ReactionLike))
+	suite.EqualValues(1, suite.totalReactions())
+
+	suite.Equal(models.ReactionChanged, suite.react(userID, models.ReactionLove))
+	suite.EqualValues(1, suite.totalReactions())
+
+	suite.Equal(models.ReactionRemoved, suite.react(userID, models.ReactionLove))
+	suite.EqualValues(0, suite.totalReactions())
+}
+
+func (suite *FeedReactionsIntegrationTestSuite) TestToggleReaction_CountsEachUserOnce() {
+	first, second := primitive.NewObjectID(), primitive.NewObjectID()
+	suite.react(first, models.ReactionLike)
+	suite.react(second, models.ReactionHaha)
+	suite.react(first, models.ReactionWow)
+
+	suite.EqualValues(2, suite.totalReactions())
+	count, err := suite.db.Collection("reactions").CountDocuments(suite.ctx, bson.M{"target_id": suite.postID})
+	suite.Require().NoError(err)
+	suite.EqualValues(2, count)
+}
+
+func (suite *FeedReactionsIntegrationTestSuite) TestReactionsIndex_RejectsDuplicates() {
+	reaction := bson.M{"user_id": primitive.NewObjectID(), "target_id": suite.postID, "target_type": "post", "type": models.ReactionLike}
+	_, err := suite.db.Collection("reactions").InsertOne(suite.ctx, reaction)
+	suite.Require().NoError(err)
+
+	delete(reaction, "_id")
+	_, err = suite.db.Collection("reactions").InsertOne(suite.ctx, reaction)
+	suite.True(mongo.IsDuplicateKeyError(err))
+}
+
+func (suite *FeedReactionsIntegrationTestSuite) TestReconcileCounters_RepairsDrift() {
+	suite.react(primitive.NewObjectID(), models.ReactionLike)
+	for i := 0; i < 2; i++ {
+		_, err := suite.db.Collection("comments").InsertOne(suite.ctx, bson.M{"post_id": suite.postID, "content": "comment"})
+		suite.Require().NoError(err)
+	}
+	// total_reactions drifted up and total_comments was never maintained
+	_, err := suite.db.Collection("posts").UpdateOne(suite.ctx, bson.M{"_id": suite.postID}, bson.M{"$set": bson.M{"total_reactions": 5}})
+	suite.Require().NoError(err)
+
+	batch, err := suite.feedRepo.ReconcileCounters(suite.ctx, repositories.CounterTargetPost, primitive.NilObjectID, 10)
+	suite.Require().NoError(err)
+	suite.Equal(1, batch.Scanned)
+	suite.Len(batch.Drifts, 2)
+	suite.Equal(2, batch.Repaired)
+
+	var post models.Post
+	suite.Require().NoError(suite.db.Collection("posts").FindOne(suite.ctx, bson.M{"_id": suite.postID}).Decode(&post))
+	suite.EqualValues(1, post.TotalReactions)
+	suite.EqualValues(2, post.TotalComments)
+
+	batch, err = suite.feedRepo.ReconcileCounters(suite.ctx, repositories.CounterTargetPost, primitive.NilObjectID, 10)
+	suite.Require().NoError(err)
+	suite.Empty(batch.Drifts)
+}
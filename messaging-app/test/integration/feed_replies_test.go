@@ -0,0 +1,138 @@
+package integration
+
+import (
+	"context"
+	"messaging-app/internal/repositories"
+	"messaging-app/internal/services"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FeedRepliesIntegrationTestSuite struct {
+	suite.Suite
+	feedRepo    *repositories.FeedRepository
+	feedService *services.FeedService
+	mongoClient *mongo.Client
+	db          *mongo.Database
+	ctx         context.Context
+
+	commentID primitive.ObjectID
+}
+
+func (suite *FeedRepliesIntegrationTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	mongoClient, err := mongo.Connect(suite.ctx, options.Client().ApplyURI(os.Getenv("MONGO_URI")))
+	suite.Require().NoError(err)
+	suite.mongoClient = mongoClient
+	suite.db = mongoClient.Database("test_feed_replies_db")
+
+	suite.feedRepo = repositories.NewFeedRepository(suite.db)
+	suite.Require().NoError(suite.feedRepo.EnsureIndexes(suite.ctx))
+	suite.feedService = services.NewFeedService(suite.feedRepo, nil, nil, nil, nil, nil, nil, nil)
+}
+
+func (suite *FeedRepliesIntegrationTestSuite) TearDownSuite() {
+	suite.db.Drop(suite.ctx)
+	suite.mongoClient.Disconnect(suite.ctx)
+}
+
+func (suite *FeedRepliesIntegrationTestSuite) SetupTest() {
+	for _, name := range []string{"comments", "replies"} {
+		_, err := suite.db.Collection(name).DeleteMany(suite.ctx, bson.M{})
+		suite.Require().NoError(err)
+	}
+	suite.commentID = primitive.NewObjectID()
+	_, err := suite.db.Collection("comments").InsertOne(suite.ctx, bson.M{
+		"_id":        suite.commentID,
+		"post_id":    primitive.NewObjectID(),
+		"user_id":    primitive.NewObjectID(),
+		"content":    "comment",
+		"created_at": time.Now(),
+	})
+	suite.Require().NoError(err)
+}
+
+func TestFeedRepliesIntegrationTestSuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests")
+	}
+	if os.Getenv("MONGO_URI") == "" {
+		t.Skip("MONGO_URI is not set")
+	}
+	suite.Run(t, new(FeedRepliesIntegrationTestSuite))
+}
+
+func (suite *FeedRepliesIntegrationTestSuite) reply(parent *models.Reply) *models.Reply {
+	reply := &models.Reply{CommentID: suite.commentID, UserID: primitive.NewObjectID(), Content: "reply", Depth: 1}
+	if parent != nil {
+		reply.ParentReplyID = &parent.ID
+		reply.Depth = parent.Depth + 1
+	}
+	created, err := suite.feedRepo.CreateReply(suite.ctx, reply)
+	suite.Require().NoError(err)
+	return created
+}
+
+func (suite *FeedRepliesIntegrationTestSuite) replyCount(collection string, id primitive.ObjectID) int64 {
+	var doc struct {
+		ReplyCount int64 `bson:"reply_count"`
+	}
+	suite.Require().NoError(suite.db.Collection(collection).FindOne(suite.ctx, bson.M{"_id": id}).Decode(&doc))
+	return doc.ReplyCount
+}
+
+func replyIDs(replies []models.Reply) []primitive.ObjectID {
+	out := make([]primitive.ObjectID, len(replies))
+	for i, r := range replies {
+		out[i] = r.ID
+	}
+	return out
+}
+
+func (suite *FeedRepliesIntegrationTestSuite) TestThreadsArePagedPerParent() {
+	first := suite.reply(nil)
+	second := suite.reply(nil)
+	nested := []*models.Reply{suite.reply(first), suite.reply(first), suite.reply(first)}
+	suite.reply(nested[0])
+
+	topLevel, err := suite.feedService.GetRepliesByCommentID(suite.ctx, suite.commentID, 1, 20)
+	suite.Require().NoError(err)
+	suite.Equal([]primitive.ObjectID{first.ID, second.ID}, replyIDs(topLevel))
+	suite.Equal(int64(3), topLevel[0].ReplyCount)
+
+	page, err := suite.feedService.GetRepliesByParentReplyID(suite.ctx, suite.commentID, first.ID, 2, 2)
+	suite.Require().NoError(err)
+	suite.Equal([]primitive.ObjectID{nested[2].ID}, replyIDs(page))
+
+	_, err = suite.feedService.GetRepliesByParentReplyID(suite.ctx, primitive.NewObjectID(), first.ID, 1, 20)
+	suite.ErrorIs(err, services.ErrReplyNotFound)
+
+	suite.Equal(int64(6), suite.replyCount("comments", suite.commentID))
+}
+
+func (suite *FeedRepliesIntegrationTestSuite) TestDeleteReplyRemovesItsThread() {
+	first := suite.reply(nil)
+	child := suite.reply(first)
+	grandchild := suite.reply(child)
+	sibling := suite.reply(first)
+
+	suite.Require().NoError(suite.feedRepo.DeleteReply(suite.ctx, suite.commentID, child.ID))
+
+	for _, id := range []primitive.ObjectID{child.ID, grandchild.ID} {
+		_, err := suite.feedRepo.GetReplyByID(suite.ctx, id)
+		suite.ErrorIs(err, mongo.ErrNoDocuments)
+	}
+	_, err := suite.feedRepo.GetReplyByID(suite.ctx, sibling.ID)
+	suite.NoError(err)
+	suite.Equal(int64(1), suite.replyCount("replies", first.ID))
+	suite.Equal(int64(2), suite.replyCount("comments", suite.commentID))
+}
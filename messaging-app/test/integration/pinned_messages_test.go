@@ -0,0 +1,110 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"messaging-app/internal/db"
+	"messaging-app/internal/repositories"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/suite"
+)
+
+type PinnedMessagesIntegrationTestSuite struct {
+	suite.Suite
+	client *db.CassandraClient
+	repo   *repositories.MessageCassandraRepository
+	ctx    context.Context
+}
+
+func (suite *PinnedMessagesIntegrationTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	client, err := db.NewCassandraClient(
+		strings.Split(os.Getenv("CASSANDRA_HOSTS"), ","),
+		"test_pinned_messages",
+		os.Getenv("CASSANDRA_USER"),
+		os.Getenv("CASSANDRA_PASSWORD"),
+	)
+	suite.Require().NoError(err)
+	suite.client = client
+	suite.repo = repositories.NewMessageCassandraRepository(client)
+}
+
+func (suite *PinnedMessagesIntegrationTestSuite) TearDownSuite() {
+	suite.client.Close()
+}
+
+func TestPinnedMessagesIntegrationTestSuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests")
+	}
+	if os.Getenv("CASSANDRA_HOSTS") == "" {
+		t.Skip("CASSANDRA_HOSTS is not set")
+	}
+	suite.Run(t, new(PinnedMessagesIntegrationTestSuite))
+}
+
+func (suite *PinnedMessagesIntegrationTestSuite) pin(conversationID string) (bool, error) {
+	return suite.repo.PinMessage(suite.ctx, models.PinnedMessage{
+		ConversationID: conversationID,
+		MessageID:      gocql.TimeUUID().String(),
+		PinnedBy:       "user",
+		PinnedAt:       time.Now(),
+	}, models.MaxPinnedMessages)
+}
+
+func (suite *PinnedMessagesIntegrationTestSuite) TestPinLimit() {
+	conversationID := "group_" + gocql.TimeUUID().String()
+	for i := 0; i < models.MaxPinnedMessages; i++ {
+		applied, err := suite.pin(conversationID)
+		suite.Require().NoError(err)
+		suite.Require().True(applied)
+	}
+
+	applied, err := suite.pin(conversationID)
+	suite.ErrorIs(err, repositories.ErrPinLimitReached)
+	suite.False(applied)
+
+	pins, err := suite.repo.GetPinnedMessages(suite.ctx, conversationID)
+	suite.Require().NoError(err)
+	suite.Len(pins, models.MaxPinnedMessages)
+}
+
+func (suite *PinnedMessagesIntegrationTestSuite) TestConcurrentPinsNeverExceedLimit() {
+	conversationID := "group_" + gocql.TimeUUID().String()
+	attempts := models.MaxPinnedMessages * 3
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		pinned int
+	)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			applied, err := suite.pin(conversationID)
+			if err != nil && !errors.Is(err, repositories.ErrPinLimitReached) {
+				suite.Fail("unexpected pin error", err)
+			}
+			if applied {
+				mu.Lock()
+				pinned++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	pins, err := suite.repo.GetPinnedMessages(suite.ctx, conversationID)
+	suite.Require().NoError(err)
+	suite.LessOrEqual(len(pins), models.MaxPinnedMessages)
+	suite.Equal(pinned, len(pins), "every reported pin is stored and every rolled back one is gone")
+}
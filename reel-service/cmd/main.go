@@ -9,11 +9,13 @@ import (
 	"github.com/MuhibNayem/connectify-v2/reel-service/config"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/platform"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/serviceauth"
 )
 
 func main() {
 	observability.InitLogger()
 	cfg := config.Load()
+	serviceauth.SetDefault(serviceauth.NewCredentials("reel-service", cfg.ServiceAuthSecret))
 
 	app := platform.NewApplication(cfg)
 
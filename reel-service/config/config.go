@@ -4,6 +4,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -15,18 +16,41 @@ type Config struct {
 	ServerPort string
 	GRPCPort   string
 
-	KafkaBrokers []string
-	KafkaTopic   string
+	KafkaBrokers      []string
+	KafkaTopic        string
+	NotificationTopic string
+	RealtimeTopic     string // Live updates the messaging Hub delivers
+	EngagementTopic   string // Signals the For You feed learns from
 
 	UserServiceHost string
 	UserServicePort string
 
-	JWTSecret        string
-	RedisURLs        []string
-	RedisPass        string
-	RateLimitEnabled bool
-	RateLimitLimit   float64
-	RateLimitBurst   int
+	StorageServiceHost string
+	StorageServicePort string
+	StorageBucket      string
+
+	// Transcoding into HLS renditions
+	TranscodeEnabled bool
+	TranscodeWorkers int
+	TranscodeTimeout time.Duration
+	TranscodeWorkDir string
+	FFmpegPath       string
+	FFprobePath      string
+
+	// Live streaming through an nginx-rtmp media server
+	LiveEnabled    bool
+	LiveRTMPURL    string
+	LiveHLSURL     string
+	LiveRecordDir  string
+	LiveHookSecret string // Shared with the media server's publish hooks
+
+	JWTSecret         string
+	ServiceAuthSecret string // Signs the tokens presented to other services
+	RedisURLs         []string
+	RedisPass         string
+	RateLimitEnabled  bool
+	RateLimitLimit    float64
+	RateLimitBurst    int
 
 	CORSAllowedOrigins []string
 
@@ -40,6 +64,15 @@ func Load() *Config {
 	rateLimitLimit, _ := strconv.ParseFloat(getEnv("RATE_LIMIT_LIMIT", "50"), 64)
 	rateLimitBurst, _ := strconv.Atoi(getEnv("RATE_LIMIT_BURST", "100"))
 
+	transcodeEnabled, _ := strconv.ParseBool(getEnv("TRANSCODE_ENABLED", "true"))
+	transcodeWorkers, _ := strconv.Atoi(getEnv("TRANSCODE_WORKERS", "2"))
+	transcodeTimeout, err := time.ParseDuration(getEnv("TRANSCODE_TIMEOUT", "15m"))
+	if err != nil {
+		transcodeTimeout = 15 * time.Minute
+	}
+
+	liveEnabled, _ := strconv.ParseBool(getEnv("LIVE_ENABLED", "false"))
+
 	corsOrigins := strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173"), ",")
 	for i := range corsOrigins {
 		corsOrigins[i] = strings.TrimSpace(corsOrigins[i])
@@ -52,13 +85,34 @@ func Load() *Config {
 		ServerPort: getEnv("SERVER_PORT", "8086"),
 		GRPCPort:   getEnv("GRPC_PORT", "9096"),
 
-		KafkaBrokers: strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
-		KafkaTopic:   getEnv("KAFKA_TOPIC", "reel-events"),
+		KafkaBrokers:      strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
+		KafkaTopic:        getEnv("KAFKA_TOPIC", "reel-events"),
+		NotificationTopic: getEnv("NOTIFICATION_TOPIC", "notifications_events"),
+		RealtimeTopic:     getEnv("REALTIME_TOPIC", "messages"),
+		EngagementTopic:   getEnv("ENGAGEMENT_TOPIC", "reel-engagement"),
 
 		UserServiceHost: getEnv("USER_SERVICE_HOST", "localhost"),
 		UserServicePort: getEnv("USER_SERVICE_PORT", "9091"),
 
+		StorageServiceHost: getEnv("STORAGE_GRPC_HOST", "localhost"),
+		StorageServicePort: getEnv("STORAGE_GRPC_PORT", "9087"),
+		StorageBucket:      getEnv("STORAGE_BUCKET", "connectify-uploads"),
+
+		TranscodeEnabled: transcodeEnabled,
+		TranscodeWorkers: transcodeWorkers,
+		TranscodeTimeout: transcodeTimeout,
+		TranscodeWorkDir: getEnv("TRANSCODE_WORK_DIR", os.TempDir()),
+		FFmpegPath:       getEnv("FFMPEG_PATH", "ffmpeg"),
+		FFprobePath:      getEnv("FFPROBE_PATH", "ffprobe"),
+
+		LiveEnabled:    liveEnabled,
+		LiveRTMPURL:    getEnv("LIVE_RTMP_URL", "rtmp://localhost:1935/live"),
+		LiveHLSURL:     getEnv("LIVE_HLS_URL", "http://localhost:8088/hls"),
+		LiveRecordDir:  getEnv("LIVE_RECORD_DIR", "/var/recordings"),
+		LiveHookSecret: getEnv("LIVE_HOOK_SECRET", ""),
+
 		JWTSecret:          getEnv("JWT_SECRET", "very-secret-key"),
+		ServiceAuthSecret:  getEnv("SERVICE_AUTH_SECRET", ""),
 		RedisURLs:          strings.Split(getEnv("REDIS_URL", "localhost:6379"), ","),
 		RedisPass:          getEnv("REDIS_PASS", ""),
 		RateLimitEnabled:   rateLimitEnabled,
@@ -0,0 +1,73 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/recommend"
+	"github.com/segmentio/kafka-go"
+)
+
+// EngagementHandler folds an engagement signal into reel scores and the
+// user's interests
+type EngagementHandler func(ctx context.Context, event recommend.Event) error
+
+// EngagementConsumer applies engagement signals from the engagement topic
+type EngagementConsumer struct {
+	reader *kafka.Reader
+	handle EngagementHandler
+	logger *slog.Logger
+}
+
+func NewEngagementConsumer(brokers []string, topic string, handle EngagementHandler, logger *slog.Logger) *EngagementConsumer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        brokers,
+		Topic:          topic,
+		GroupID:        "reel-engagement",
+		MaxWait:        time.Second,
+		CommitInterval: time.Second,
+	})
+
+	return &EngagementConsumer{
+		reader: reader,
+		handle: handle,
+		logger: logger,
+	}
+}
+
+// Run applies signals until ctx is cancelled
+func (c *EngagementConsumer) Run(ctx context.Context) {
+	for {
+		msg, err := c.reader.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return
+			}
+			c.logger.Error("Failed to read engagement message", "error", err)
+			continue
+		}
+
+		var event recommend.Event
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			c.logger.Error("Failed to unmarshal engagement event", "error", err)
+			continue
+		}
+
+		// Signals are soft, so one that fails is dropped rather than retried
+		if err := c.handle(ctx, event); err != nil {
+			c.logger.Warn("Failed to apply engagement", "reel_id", event.ReelID, "user_id", event.UserID, "signal", event.Signal, "error", err)
+		}
+	}
+}
+
+func (c *EngagementConsumer) Close() error {
+	return c.reader.Close()
+}
@@ -0,0 +1,213 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/metrics"
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/producer"
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/transcoder"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TranscodeRepository tracks a reel's progress through the pipeline
+type TranscodeRepository interface {
+	MarkProcessing(ctx context.Context, id primitive.ObjectID) (bool, error)
+	CompleteProcessing(ctx context.Context, id primitive.ObjectID, playbackURL string, renditions []models.ReelRendition) error
+	FailProcessing(ctx context.Context, id primitive.ObjectID, reason string) error
+}
+
+// Transcoder turns an uploaded video into HLS renditions
+type Transcoder interface {
+	Transcode(ctx context.Context, reelID, sourceURL string) (*transcoder.Result, error)
+}
+
+// TranscodeConsumer hands reel.uploaded events to a pool of ffmpeg workers
+type TranscodeConsumer struct {
+	reader     *kafka.Reader
+	repo       TranscodeRepository
+	transcoder Transcoder
+	metrics    *metrics.BusinessMetrics
+	logger     *slog.Logger
+
+	workers int
+	timeout time.Duration
+
+	jobs chan kafka.Message
+	wg   sync.WaitGroup
+}
+
+type TranscodeConsumerConfig struct {
+	Brokers []string
+	Topic   string
+	GroupID string
+	Workers int
+	Timeout time.Duration // Per reel, covering transcoding and uploads
+}
+
+func DefaultTranscodeConfig(brokers []string, topic string) TranscodeConsumerConfig {
+	return TranscodeConsumerConfig{
+		Brokers: brokers,
+		Topic:   topic,
+		GroupID: "reel-transcoder",
+		Workers: 2,
+		Timeout: 15 * time.Minute,
+	}
+}
+
+func NewTranscodeConsumer(cfg TranscodeConsumerConfig, repo TranscodeRepository, t Transcoder, m *metrics.BusinessMetrics, logger *slog.Logger) *TranscodeConsumer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: cfg.Brokers,
+		Topic:   cfg.Topic,
+		GroupID: cfg.GroupID,
+		MaxWait: time.Second,
+	})
+
+	return &TranscodeConsumer{
+		reader:     reader,
+		repo:       repo,
+		transcoder: t,
+		metrics:    m,
+		logger:     logger,
+		workers:    cfg.Workers,
+		timeout:    cfg.Timeout,
+		jobs:       make(chan kafka.Message),
+	}
+}
+
+func (c *TranscodeConsumer) Start(ctx context.Context) {
+	for i := 0; i < c.workers; i++ {
+		c.wg.Add(1)
+		go c.work(ctx)
+	}
+	go c.consume(ctx)
+}
+
+func (c *TranscodeConsumer) consume(ctx context.Context) {
+	defer close(c.jobs)
+
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Error("Failed to fetch message", "error", err)
+			continue
+		}
+
+		// The topic carries every reel event; only uploads concern us
+		if string(msg.Key) != "reel.uploaded" {
+			c.commit(msg)
+			continue
+		}
+
+		select {
+		case c.jobs <- msg:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *TranscodeConsumer) work(ctx context.Context) {
+	defer c.wg.Done()
+
+	for msg := range c.jobs {
+		var event producer.ReelUploadedEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			c.logger.Error("Failed to unmarshal upload event", "error", err)
+		} else {
+			c.process(ctx, event)
+		}
+
+		// Work interrupted by shutdown is left uncommitted for redelivery
+		if ctx.Err() == nil {
+			c.commit(msg)
+		}
+	}
+}
+
+func (c *TranscodeConsumer) process(ctx context.Context, event producer.ReelUploadedEvent) {
+	reelID, err := primitive.ObjectIDFromHex(event.ReelID)
+	if err != nil {
+		c.logger.Error("Invalid reel ID", "reel_id", event.ReelID, "error", err)
+		return
+	}
+
+	claimed, err := c.repo.MarkProcessing(ctx, reelID)
+	if err != nil {
+		c.logger.Error("Failed to claim reel for transcoding", "reel_id", event.ReelID, "error", err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	start := time.Now()
+	jobCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	result, err := c.transcoder.Transcode(jobCtx, event.ReelID, event.VideoURL)
+	cancel()
+	if ctx.Err() != nil {
+		// Failed reels can be claimed again when the event is redelivered
+		if err := c.repo.FailProcessing(context.Background(), reelID, "interrupted by shutdown"); err != nil {
+			c.logger.Error("Failed to release interrupted reel", "reel_id", event.ReelID, "error", err)
+		}
+		return
+	}
+
+	if err != nil {
+		c.logger.Error("Failed to transcode reel", "reel_id", event.ReelID, "error", err)
+		if err := c.repo.FailProcessing(ctx, reelID, err.Error()); err != nil {
+			c.logger.Error("Failed to mark reel as failed", "reel_id", event.ReelID, "error", err)
+		}
+		c.observe(models.ReelProcessingFailed, start)
+		return
+	}
+
+	if err := c.repo.CompleteProcessing(ctx, reelID, result.PlaybackURL, result.Renditions); err != nil {
+		c.logger.Error("Failed to record transcoded reel", "reel_id", event.ReelID, "error", err)
+		if err := c.repo.FailProcessing(ctx, reelID, "failed to record renditions"); err != nil {
+			c.logger.Error("Failed to mark reel as failed", "reel_id", event.ReelID, "error", err)
+		}
+		return
+	}
+	c.observe(models.ReelProcessingReady, start)
+	c.logger.Info("Reel transcoded",
+		"reel_id", event.ReelID,
+		"renditions", len(result.Renditions),
+		"duration", time.Since(start),
+	)
+}
+
+func (c *TranscodeConsumer) observe(status models.ReelProcessingStatus, start time.Time) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.ReelsTranscoded.WithLabelValues(string(status)).Inc()
+	c.metrics.TranscodeDuration.Observe(time.Since(start).Seconds())
+}
+
+func (c *TranscodeConsumer) commit(msg kafka.Message) {
+	if err := c.reader.CommitMessages(context.Background(), msg); err != nil {
+		c.logger.Error("Failed to commit message", "error", err)
+	}
+}
+
+// Stop waits for the workers to drain; cancel the Start context first
+func (c *TranscodeConsumer) Stop() error {
+	c.wg.Wait()
+	return c.reader.Close()
+}
@@ -2,7 +2,10 @@ package reelgrpc
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/recommend"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/service"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	reelpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/reel/v1"
@@ -21,10 +24,24 @@ type ReelService interface {
 	DeleteReel(ctx context.Context, reelID, userID primitive.ObjectID) error
 	AddComment(ctx context.Context, reelID, userID primitive.ObjectID, content string, explicitMentions []primitive.ObjectID) (*models.Comment, error)
 	GetComments(ctx context.Context, reelID primitive.ObjectID, limit, offset int64) ([]models.Comment, error)
-	AddReply(ctx context.Context, reelID, commentID, userID primitive.ObjectID, content string) (*models.Reply, error)
+	DeleteComment(ctx context.Context, reelID, commentID, userID primitive.ObjectID) error
+	AddReply(ctx context.Context, reelID, commentID, userID primitive.ObjectID, content string, parentReplyID *primitive.ObjectID, explicitMentions []primitive.ObjectID) (*models.Reply, error)
+	GetReplies(ctx context.Context, reelID, commentID primitive.ObjectID, limit, offset int64) ([]models.Reply, error)
+	DeleteReply(ctx context.Context, reelID, commentID, replyID, userID primitive.ObjectID) error
 	ReactToComment(ctx context.Context, reelID, commentID, userID primitive.ObjectID, reactionType models.ReactionType) error
+	ReactToReply(ctx context.Context, reelID, commentID, replyID, userID primitive.ObjectID, reactionType models.ReactionType) error
 	ReactToReel(ctx context.Context, reelID, userID primitive.ObjectID, reactionType models.ReactionType) error
 	IncrementViews(ctx context.Context, reelID, viewerID primitive.ObjectID) error
+	GetForYouFeed(ctx context.Context, viewerID primitive.ObjectID, limit int64) ([]models.Reel, error)
+	RecordEngagement(ctx context.Context, reelID, userID primitive.ObjectID, signal recommend.Signal, watchTimeMs int64) error
+	CreateLiveSession(ctx context.Context, userID primitive.ObjectID, title string, privacy models.PrivacySettingType) (*models.LiveSession, error)
+	GetLiveSession(ctx context.Context, sessionID, viewerID primitive.ObjectID) (*models.LiveSession, error)
+	ListLiveSessions(ctx context.Context, viewerID primitive.ObjectID, limit int64) ([]models.LiveSession, error)
+	EndLiveSession(ctx context.Context, sessionID, userID primitive.ObjectID) (*models.LiveSession, error)
+	JoinLiveSession(ctx context.Context, sessionID, viewerID primitive.ObjectID) (int64, error)
+	LeaveLiveSession(ctx context.Context, sessionID, viewerID primitive.ObjectID) (int64, error)
+	SendLiveChatMessage(ctx context.Context, sessionID, userID primitive.ObjectID, content string) (*models.LiveChatMessage, error)
+	GetLiveChatMessages(ctx context.Context, sessionID, viewerID primitive.ObjectID, before time.Time, limit int64) ([]models.LiveChatMessage, error)
 }
 
 type Server struct {
@@ -117,6 +134,7 @@ func (s *Server) CreateReel(ctx context.Context, req *reelpb.CreateReelRequest)
 		Privacy:        models.PrivacySettingType(req.Privacy),
 		AllowedViewers: allowedViewers,
 		BlockedViewers: blockedViewers,
+		Category:       req.Category,
 	}
 
 	reel, err := s.svc.CreateReel(ctx, userID, serviceReq)
@@ -147,6 +165,27 @@ func (s *Server) DeleteReel(ctx context.Context, req *reelpb.DeleteReelRequest)
 	return &reelpb.DeleteReelResponse{Success: true}, nil
 }
 
+// GetReelProcessingStatus reports how far a reel has come through transcoding
+func (s *Server) GetReelProcessingStatus(ctx context.Context, req *reelpb.GetReelProcessingStatusRequest) (*reelpb.GetReelProcessingStatusResponse, error) {
+	reelID, err := primitive.ObjectIDFromHex(req.ReelId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid reel ID")
+	}
+
+	reel, err := s.svc.GetReel(ctx, reelID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Reel not found")
+	}
+
+	return &reelpb.GetReelProcessingStatusResponse{
+		ReelId:           req.ReelId,
+		ProcessingStatus: string(reel.ProcessingStatus),
+		PlaybackUrl:      reel.PlaybackURL,
+		Renditions:       toProtoRenditions(reel.Renditions),
+		Error:            reel.ProcessingError,
+	}, nil
+}
+
 func (s *Server) GetComments(ctx context.Context, req *reelpb.GetCommentsRequest) (*reelpb.GetCommentsResponse, error) {
 	reelID, err := primitive.ObjectIDFromHex(req.ReelId)
 	if err != nil {
@@ -182,7 +221,7 @@ func (s *Server) AddComment(ctx context.Context, req *reelpb.AddCommentRequest)
 
 	comment, err := s.svc.AddComment(ctx, reelID, userID, req.Content, mentions)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, threadError(err)
 	}
 
 	return &reelpb.AddCommentResponse{Comment: toProtoComment(comment)}, nil
@@ -202,14 +241,94 @@ func (s *Server) AddReply(ctx context.Context, req *reelpb.AddReplyRequest) (*re
 		return nil, status.Error(codes.InvalidArgument, "Invalid comment ID")
 	}
 
-	reply, err := s.svc.AddReply(ctx, reelID, commentID, userID, req.Content)
+	var parentReplyID *primitive.ObjectID
+	if req.ParentReplyId != "" {
+		oid, err := primitive.ObjectIDFromHex(req.ParentReplyId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid parent reply ID")
+		}
+		parentReplyID = &oid
+	}
+
+	reply, err := s.svc.AddReply(ctx, reelID, commentID, userID, req.Content, parentReplyID, toObjectIDs(req.ExplicitMentions))
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, threadError(err)
 	}
 
 	return &reelpb.AddReplyResponse{Reply: toProtoReply(reply)}, nil
 }
 
+func (s *Server) GetReplies(ctx context.Context, req *reelpb.GetRepliesRequest) (*reelpb.GetRepliesResponse, error) {
+	reelID, err := primitive.ObjectIDFromHex(req.ReelId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid reel ID")
+	}
+	commentID, err := primitive.ObjectIDFromHex(req.CommentId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid comment ID")
+	}
+
+	replies, err := s.svc.GetReplies(ctx, reelID, commentID, req.Limit, req.Offset)
+	if err != nil {
+		return nil, threadError(err)
+	}
+
+	protoReplies := make([]*reelpb.Reply, len(replies))
+	for i, r := range replies {
+		protoReplies[i] = toProtoReply(&r)
+	}
+
+	return &reelpb.GetRepliesResponse{
+		Replies: protoReplies,
+	}, nil
+}
+
+func (s *Server) DeleteComment(ctx context.Context, req *reelpb.DeleteCommentRequest) (*reelpb.DeleteCommentResponse, error) {
+	userID, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+	reelID, err := primitive.ObjectIDFromHex(req.ReelId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid reel ID")
+	}
+	commentID, err := primitive.ObjectIDFromHex(req.CommentId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid comment ID")
+	}
+
+	if err := s.svc.DeleteComment(ctx, reelID, commentID, userID); err != nil {
+		return nil, threadError(err)
+	}
+
+	return &reelpb.DeleteCommentResponse{Success: true}, nil
+}
+
+func (s *Server) DeleteReply(ctx context.Context, req *reelpb.DeleteReplyRequest) (*reelpb.DeleteReplyResponse, error) {
+	userID, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+	reelID, err := primitive.ObjectIDFromHex(req.ReelId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid reel ID")
+	}
+	commentID, err := primitive.ObjectIDFromHex(req.CommentId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid comment ID")
+	}
+	replyID, err := primitive.ObjectIDFromHex(req.ReplyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid reply ID")
+	}
+
+	if err := s.svc.DeleteReply(ctx, reelID, commentID, replyID, userID); err != nil {
+		return nil, threadError(err)
+	}
+
+	return &reelpb.DeleteReplyResponse{Success: true}, nil
+}
+
 func (s *Server) ReactToComment(ctx context.Context, req *reelpb.ReactToCommentRequest) (*reelpb.ReactToCommentResponse, error) {
 	userID, err := primitive.ObjectIDFromHex(req.UserId)
 	if err != nil {
@@ -225,12 +344,51 @@ func (s *Server) ReactToComment(ctx context.Context, req *reelpb.ReactToCommentR
 	}
 
 	if err := s.svc.ReactToComment(ctx, reelID, commentID, userID, models.ReactionType(req.ReactionType)); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, threadError(err)
 	}
 
 	return &reelpb.ReactToCommentResponse{Success: true}, nil
 }
 
+func (s *Server) ReactToReply(ctx context.Context, req *reelpb.ReactToReplyRequest) (*reelpb.ReactToReplyResponse, error) {
+	userID, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+	reelID, err := primitive.ObjectIDFromHex(req.ReelId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid reel ID")
+	}
+	commentID, err := primitive.ObjectIDFromHex(req.CommentId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid comment ID")
+	}
+	replyID, err := primitive.ObjectIDFromHex(req.ReplyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid reply ID")
+	}
+
+	if err := s.svc.ReactToReply(ctx, reelID, commentID, replyID, userID, models.ReactionType(req.ReactionType)); err != nil {
+		return nil, threadError(err)
+	}
+
+	return &reelpb.ReactToReplyResponse{Success: true}, nil
+}
+
+// threadError maps comment thread failures to gRPC status codes
+func threadError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrReelNotFound),
+		errors.Is(err, service.ErrCommentNotFound),
+		errors.Is(err, service.ErrReplyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotCommentAuthor):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
 func (s *Server) ReactToReel(ctx context.Context, req *reelpb.ReactToReelRequest) (*reelpb.ReactToReelResponse, error) {
 	userID, err := primitive.ObjectIDFromHex(req.UserId)
 	if err != nil {
@@ -272,6 +430,214 @@ func (s *Server) IncrementView(ctx context.Context, req *reelpb.IncrementViewReq
 	return &reelpb.IncrementViewResponse{Success: true}, nil
 }
 
+// GetForYouFeed recommends reels to the viewer
+func (s *Server) GetForYouFeed(ctx context.Context, req *reelpb.GetForYouFeedRequest) (*reelpb.GetForYouFeedResponse, error) {
+	viewerID, err := primitive.ObjectIDFromHex(req.ViewerId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid viewer ID")
+	}
+
+	reels, err := s.svc.GetForYouFeed(ctx, viewerID, req.Limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	protoReels := make([]*reelpb.Reel, len(reels))
+	for i, r := range reels {
+		protoReels[i] = toProtoReel(&r)
+	}
+
+	return &reelpb.GetForYouFeedResponse{
+		Reels: protoReels,
+	}, nil
+}
+
+// RecordEngagement records a viewer's watch, complete or share of a reel
+func (s *Server) RecordEngagement(ctx context.Context, req *reelpb.RecordEngagementRequest) (*reelpb.RecordEngagementResponse, error) {
+	userID, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+	reelID, err := primitive.ObjectIDFromHex(req.ReelId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid reel ID")
+	}
+
+	err = s.svc.RecordEngagement(ctx, reelID, userID, recommend.Signal(req.Signal), req.WatchTimeMs)
+	switch {
+	case errors.Is(err, service.ErrInvalidSignal):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, threadError(err)
+	}
+
+	return &reelpb.RecordEngagementResponse{Success: true}, nil
+}
+
+// CreateLiveSession registers a broadcast and hands the broadcaster its
+// stream key
+func (s *Server) CreateLiveSession(ctx context.Context, req *reelpb.CreateLiveSessionRequest) (*reelpb.LiveSessionResponse, error) {
+	userID, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	session, err := s.svc.CreateLiveSession(ctx, userID, req.Title, models.PrivacySettingType(req.Privacy))
+	if err != nil {
+		return nil, liveError(err)
+	}
+
+	return &reelpb.LiveSessionResponse{Session: toProtoLiveSession(session)}, nil
+}
+
+func (s *Server) GetLiveSession(ctx context.Context, req *reelpb.GetLiveSessionRequest) (*reelpb.LiveSessionResponse, error) {
+	sessionID, viewerID, err := liveIDs(req.SessionId, req.ViewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.svc.GetLiveSession(ctx, sessionID, viewerID)
+	if err != nil {
+		return nil, liveError(err)
+	}
+
+	return &reelpb.LiveSessionResponse{Session: toProtoLiveSession(session)}, nil
+}
+
+// ListLiveSessions returns what the viewer can watch live now
+func (s *Server) ListLiveSessions(ctx context.Context, req *reelpb.ListLiveSessionsRequest) (*reelpb.ListLiveSessionsResponse, error) {
+	viewerID, err := primitive.ObjectIDFromHex(req.ViewerId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid viewer ID")
+	}
+
+	sessions, err := s.svc.ListLiveSessions(ctx, viewerID, req.Limit)
+	if err != nil {
+		return nil, liveError(err)
+	}
+
+	protoSessions := make([]*reelpb.LiveSession, len(sessions))
+	for i, session := range sessions {
+		protoSessions[i] = toProtoLiveSession(&session)
+	}
+
+	return &reelpb.ListLiveSessionsResponse{Sessions: protoSessions}, nil
+}
+
+func (s *Server) EndLiveSession(ctx context.Context, req *reelpb.EndLiveSessionRequest) (*reelpb.LiveSessionResponse, error) {
+	sessionID, userID, err := liveIDs(req.SessionId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.svc.EndLiveSession(ctx, sessionID, userID)
+	if err != nil {
+		return nil, liveError(err)
+	}
+
+	return &reelpb.LiveSessionResponse{Session: toProtoLiveSession(session)}, nil
+}
+
+// JoinLiveSession counts the viewer as watching. Players call it again
+// every so often to stay counted.
+func (s *Server) JoinLiveSession(ctx context.Context, req *reelpb.LiveViewerRequest) (*reelpb.LiveViewerResponse, error) {
+	sessionID, viewerID, err := liveIDs(req.SessionId, req.ViewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.svc.JoinLiveSession(ctx, sessionID, viewerID)
+	if err != nil {
+		return nil, liveError(err)
+	}
+
+	return &reelpb.LiveViewerResponse{ViewerCount: count}, nil
+}
+
+func (s *Server) LeaveLiveSession(ctx context.Context, req *reelpb.LiveViewerRequest) (*reelpb.LiveViewerResponse, error) {
+	sessionID, viewerID, err := liveIDs(req.SessionId, req.ViewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.svc.LeaveLiveSession(ctx, sessionID, viewerID)
+	if err != nil {
+		return nil, liveError(err)
+	}
+
+	return &reelpb.LiveViewerResponse{ViewerCount: count}, nil
+}
+
+func (s *Server) SendLiveChatMessage(ctx context.Context, req *reelpb.SendLiveChatMessageRequest) (*reelpb.SendLiveChatMessageResponse, error) {
+	sessionID, userID, err := liveIDs(req.SessionId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.svc.SendLiveChatMessage(ctx, sessionID, userID, req.Content)
+	if err != nil {
+		return nil, liveError(err)
+	}
+
+	return &reelpb.SendLiveChatMessageResponse{Message: toProtoLiveChatMessage(message)}, nil
+}
+
+func (s *Server) GetLiveChatMessages(ctx context.Context, req *reelpb.GetLiveChatMessagesRequest) (*reelpb.GetLiveChatMessagesResponse, error) {
+	sessionID, viewerID, err := liveIDs(req.SessionId, req.ViewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var before time.Time
+	if req.Before != nil {
+		before = req.Before.AsTime()
+	}
+
+	messages, err := s.svc.GetLiveChatMessages(ctx, sessionID, viewerID, before, req.Limit)
+	if err != nil {
+		return nil, liveError(err)
+	}
+
+	protoMessages := make([]*reelpb.LiveChatMessage, len(messages))
+	for i, m := range messages {
+		protoMessages[i] = toProtoLiveChatMessage(&m)
+	}
+
+	return &reelpb.GetLiveChatMessagesResponse{Messages: protoMessages}, nil
+}
+
+func liveIDs(sessionID, userID string) (primitive.ObjectID, primitive.ObjectID, error) {
+	sID, err := primitive.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, status.Error(codes.InvalidArgument, "Invalid session ID")
+	}
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+	return sID, uID, nil
+}
+
+// liveError maps live session failures to gRPC status codes
+func liveError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrLiveSessionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotLiveBroadcaster):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrInvalidLiveTitle),
+		errors.Is(err, service.ErrInvalidLiveChat):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrLiveSessionActive),
+		errors.Is(err, service.ErrLiveSessionNotLive):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrLiveDisabled):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
 func toProtoReel(r *models.Reel) *reelpb.Reel {
 	return &reelpb.Reel{
 		Id:           r.ID.Hex(),
@@ -290,9 +656,41 @@ func toProtoReel(r *models.Reel) *reelpb.Reel {
 			Avatar:   r.Author.Avatar,
 			FullName: r.Author.FullName,
 		},
-		CreatedAt: timestamppb.New(r.CreatedAt),
-		UpdatedAt: timestamppb.New(r.UpdatedAt),
+		CreatedAt:        timestamppb.New(r.CreatedAt),
+		UpdatedAt:        timestamppb.New(r.UpdatedAt),
+		ProcessingStatus: string(r.ProcessingStatus),
+		PlaybackUrl:      r.PlaybackURL,
+		Renditions:       toProtoRenditions(r.Renditions),
+		ReactionCounts:   toProtoReactionCounts(r.ReactionCounts),
+		Category:         r.Category,
+		Hashtags:         r.Hashtags,
+		Shares:           r.Shares,
+	}
+}
+
+func toProtoReactionCounts(counts map[models.ReactionType]int64) map[string]int64 {
+	if len(counts) == 0 {
+		return nil
+	}
+	protoCounts := make(map[string]int64, len(counts))
+	for t, n := range counts {
+		protoCounts[string(t)] = n
+	}
+	return protoCounts
+}
+
+func toProtoRenditions(renditions []models.ReelRendition) []*reelpb.Rendition {
+	protoRenditions := make([]*reelpb.Rendition, len(renditions))
+	for i, r := range renditions {
+		protoRenditions[i] = &reelpb.Rendition{
+			Name:        r.Name,
+			Width:       int32(r.Width),
+			Height:      int32(r.Height),
+			Bitrate:     int32(r.Bitrate),
+			PlaylistUrl: r.PlaylistURL,
+		}
 	}
+	return protoRenditions
 }
 
 func toProtoComment(c *models.Comment) *reelpb.Comment {
@@ -305,6 +703,11 @@ func toProtoComment(c *models.Comment) *reelpb.Comment {
 	if c.ReactionCounts != nil {
 		likeCount = c.ReactionCounts[models.ReactionLike]
 	}
+	// Older comments embed their replies instead of counting them
+	replyCount := c.ReplyCount
+	if replyCount == 0 {
+		replyCount = int64(len(c.Replies))
+	}
 
 	return &reelpb.Comment{
 		Id:     c.ID.Hex(),
@@ -315,12 +718,13 @@ func toProtoComment(c *models.Comment) *reelpb.Comment {
 			Avatar:   c.Author.Avatar,
 			FullName: c.Author.FullName,
 		},
-		Content:    c.Content,
-		Mentions:   toStrings(c.Mentions),
-		LikeCount:  likeCount,
-		ReplyCount: int64(len(c.Replies)),
-		Replies:    replies,
-		CreatedAt:  timestamppb.New(c.CreatedAt),
+		Content:        c.Content,
+		Mentions:       toStrings(c.Mentions),
+		LikeCount:      likeCount,
+		ReplyCount:     replyCount,
+		Replies:        replies,
+		ReactionCounts: toProtoReactionCounts(c.ReactionCounts),
+		CreatedAt:      timestamppb.New(c.CreatedAt),
 	}
 }
 
@@ -330,6 +734,11 @@ func toProtoReply(r *models.Reply) *reelpb.Reply {
 		likeCount = r.ReactionCounts[models.ReactionLike]
 	}
 
+	var parentReplyID string
+	if r.ParentReplyID != nil {
+		parentReplyID = r.ParentReplyID.Hex()
+	}
+
 	return &reelpb.Reply{
 		Id:        r.ID.Hex(),
 		CommentId: r.CommentID.Hex(),
@@ -340,10 +749,12 @@ func toProtoReply(r *models.Reply) *reelpb.Reply {
 			Avatar:   r.Author.Avatar,
 			FullName: r.Author.FullName,
 		},
-		Content:   r.Content,
-		Mentions:  toStrings(r.Mentions),
-		LikeCount: likeCount,
-		CreatedAt: timestamppb.New(r.CreatedAt),
+		Content:        r.Content,
+		Mentions:       toStrings(r.Mentions),
+		LikeCount:      likeCount,
+		CreatedAt:      timestamppb.New(r.CreatedAt),
+		ParentReplyId:  parentReplyID,
+		ReactionCounts: toProtoReactionCounts(r.ReactionCounts),
 	}
 }
 
@@ -364,3 +775,51 @@ func toStrings(oids []primitive.ObjectID) []string {
 	}
 	return ids
 }
+
+func toProtoLiveSession(l *models.LiveSession) *reelpb.LiveSession {
+	session := &reelpb.LiveSession{
+		Id:     l.ID.Hex(),
+		UserId: l.UserID.Hex(),
+		Author: &reelpb.Author{
+			Id:       l.Author.ID,
+			Username: l.Author.Username,
+			Avatar:   l.Author.Avatar,
+			FullName: l.Author.FullName,
+		},
+		Title:       l.Title,
+		Privacy:     string(l.Privacy),
+		Status:      string(l.Status),
+		StreamKey:   l.StreamKey,
+		IngestUrl:   l.IngestURL,
+		PlaybackUrl: l.PlaybackURL,
+		ViewerCount: l.ViewerCount,
+		PeakViewers: l.PeakViewers,
+		CreatedAt:   timestamppb.New(l.CreatedAt),
+	}
+	if l.StartedAt != nil {
+		session.StartedAt = timestamppb.New(*l.StartedAt)
+	}
+	if l.EndedAt != nil {
+		session.EndedAt = timestamppb.New(*l.EndedAt)
+	}
+	if l.ReelID != nil {
+		session.ReelId = l.ReelID.Hex()
+	}
+	return session
+}
+
+func toProtoLiveChatMessage(m *models.LiveChatMessage) *reelpb.LiveChatMessage {
+	return &reelpb.LiveChatMessage{
+		Id:        m.ID.Hex(),
+		SessionId: m.SessionID.Hex(),
+		UserId:    m.UserID.Hex(),
+		Author: &reelpb.Author{
+			Id:       m.Author.ID,
+			Username: m.Author.Username,
+			Avatar:   m.Author.Avatar,
+			FullName: m.Author.FullName,
+		},
+		Content:   m.Content,
+		CreatedAt: timestamppb.New(m.CreatedAt),
+	}
+}
@@ -2,15 +2,18 @@ package httpapi
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/MuhibNayem/connectify-v2/reel-service/config"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/metrics"
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/recommend"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/service"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -25,11 +28,17 @@ type ReelService interface {
 	DeleteReel(ctx context.Context, reelID, userID primitive.ObjectID) error
 	GetReelsFeed(ctx context.Context, viewerID primitive.ObjectID, limit, offset int64) ([]models.Reel, error)
 	IncrementViews(ctx context.Context, reelID, viewerID primitive.ObjectID) error
+	GetForYouFeed(ctx context.Context, viewerID primitive.ObjectID, limit int64) ([]models.Reel, error)
+	RecordEngagement(ctx context.Context, reelID, userID primitive.ObjectID, signal recommend.Signal, watchTimeMs int64) error
 	ReactToReel(ctx context.Context, reelID, userID primitive.ObjectID, reactionType models.ReactionType) error
 	AddComment(ctx context.Context, reelID, userID primitive.ObjectID, content string, mentions []primitive.ObjectID) (*models.Comment, error)
 	GetComments(ctx context.Context, reelID primitive.ObjectID, limit, offset int64) ([]models.Comment, error)
-	AddReply(ctx context.Context, reelID, commentID, userID primitive.ObjectID, content string) (*models.Reply, error)
+	DeleteComment(ctx context.Context, reelID, commentID, userID primitive.ObjectID) error
+	AddReply(ctx context.Context, reelID, commentID, userID primitive.ObjectID, content string, parentReplyID *primitive.ObjectID, mentions []primitive.ObjectID) (*models.Reply, error)
+	GetReplies(ctx context.Context, reelID, commentID primitive.ObjectID, limit, offset int64) ([]models.Reply, error)
+	DeleteReply(ctx context.Context, reelID, commentID, replyID, userID primitive.ObjectID) error
 	ReactToComment(ctx context.Context, reelID, commentID, userID primitive.ObjectID, reactionType models.ReactionType) error
+	ReactToReply(ctx context.Context, reelID, commentID, replyID, userID primitive.ObjectID, reactionType models.ReactionType) error
 }
 
 type ReelHandler struct {
@@ -46,7 +55,9 @@ func NewReelHandler(reelService ReelService, userClient userpb.UserServiceClient
 	}
 }
 
-func BuildRouter(cfg *config.Config, handler *ReelHandler, redisClient *redis.ClusterClient) *gin.Engine {
+// BuildRouter serves the reel API, and the media server hooks when live
+// streaming is enabled
+func BuildRouter(cfg *config.Config, handler *ReelHandler, liveHooks *LiveHooks, redisClient *redis.ClusterClient) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(gin.Recovery())
@@ -71,20 +82,30 @@ func BuildRouter(cfg *config.Config, handler *ReelHandler, redisClient *redis.Cl
 		{
 			reels.POST("", handler.CreateReel)
 			reels.GET("/feed", handler.GetReelsFeed)
+			reels.GET("/for-you", handler.GetForYouFeed)
 			reels.GET("/:id", handler.GetReel)
 			reels.DELETE("/:id", handler.DeleteReel)
 			reels.POST("/:id/view", handler.IncrementViews)
+			reels.POST("/:id/engagement", handler.RecordEngagement)
 			reels.POST("/:id/react", handler.ReactToReel)
 			reels.GET("/:id/comments", handler.GetComments)
 			reels.POST("/:id/comments", handler.AddComment)
+			reels.DELETE("/:id/comments/:commentId", handler.DeleteComment)
+			reels.GET("/:id/comments/:commentId/replies", handler.GetReplies)
 			reels.POST("/:id/comments/:commentId/replies", handler.AddReply)
 			reels.POST("/:id/comments/:commentId/react", handler.ReactToComment)
+			reels.DELETE("/:id/comments/:commentId/replies/:replyId", handler.DeleteReply)
+			reels.POST("/:id/comments/:commentId/replies/:replyId/react", handler.ReactToReply)
 		}
 
 		users := api.Group("/users")
 		{
 			users.GET("/:id/reels", handler.GetUserReels)
 		}
+
+		if liveHooks != nil {
+			liveHooks.Register(api)
+		}
 	}
 
 	return router
@@ -93,25 +114,25 @@ func BuildRouter(cfg *config.Config, handler *ReelHandler, redisClient *redis.Cl
 func (h *ReelHandler) CreateReel(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req service.CreateReelRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	objUserID, err := primitive.ObjectIDFromHex(userID.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid user ID")
 		return
 	}
 
 	reel, err := h.reelService.CreateReel(c.Request.Context(), objUserID, req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -127,7 +148,23 @@ func (h *ReelHandler) GetReelsFeed(c *gin.Context) {
 
 	reels, err := h.reelService.GetReelsFeed(c.Request.Context(), objUserID, limit, offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, reels)
+}
+
+// GetForYouFeed recommends reels the viewer hasn't been served yet
+func (h *ReelHandler) GetForYouFeed(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	objUserID, _ := primitive.ObjectIDFromHex(userID.(string))
+
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "10"), 10, 64)
+
+	reels, err := h.reelService.GetForYouFeed(c.Request.Context(), objUserID, limit)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -137,13 +174,13 @@ func (h *ReelHandler) GetReelsFeed(c *gin.Context) {
 func (h *ReelHandler) GetReel(c *gin.Context) {
 	reelID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reel ID"})
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reel ID")
 		return
 	}
 
 	reel, err := h.reelService.GetReel(c.Request.Context(), reelID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Reel not found"})
+		utils.RespondWithError(c, http.StatusNotFound, "Reel not found")
 		return
 	}
 
@@ -153,13 +190,13 @@ func (h *ReelHandler) GetReel(c *gin.Context) {
 func (h *ReelHandler) GetUserReels(c *gin.Context) {
 	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid user ID")
 		return
 	}
 
 	reels, err := h.reelService.GetUserReels(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -172,12 +209,12 @@ func (h *ReelHandler) DeleteReel(c *gin.Context) {
 
 	reelID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reel ID"})
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reel ID")
 		return
 	}
 
 	if err := h.reelService.DeleteReel(c.Request.Context(), reelID, objUserID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -190,25 +227,59 @@ func (h *ReelHandler) IncrementViews(c *gin.Context) {
 
 	reelID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reel ID"})
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reel ID")
 		return
 	}
 
 	if err := h.reelService.IncrementViews(c.Request.Context(), reelID, objUserID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "View recorded"})
 }
 
+// RecordEngagement takes a watch, complete or share signal for the For You
+// feed
+func (h *ReelHandler) RecordEngagement(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	objUserID, _ := primitive.ObjectIDFromHex(userID.(string))
+
+	reelID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reel ID")
+		return
+	}
+
+	var req struct {
+		Signal      recommend.Signal `json:"signal" binding:"required"`
+		WatchTimeMs int64            `json:"watch_time_ms"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.reelService.RecordEngagement(c.Request.Context(), reelID, objUserID, req.Signal, req.WatchTimeMs)
+	switch {
+	case errors.Is(err, service.ErrInvalidSignal):
+		utils.RespondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		respondThreadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Engagement recorded"})
+}
+
 func (h *ReelHandler) ReactToReel(c *gin.Context) {
 	userID, _ := c.Get("userID")
 	objUserID, _ := primitive.ObjectIDFromHex(userID.(string))
 
 	reelID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reel ID"})
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reel ID")
 		return
 	}
 
@@ -216,12 +287,12 @@ func (h *ReelHandler) ReactToReel(c *gin.Context) {
 		Type models.ReactionType `json:"type" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	if err := h.reelService.ReactToReel(c.Request.Context(), reelID, objUserID, req.Type); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -231,7 +302,7 @@ func (h *ReelHandler) ReactToReel(c *gin.Context) {
 func (h *ReelHandler) GetComments(c *gin.Context) {
 	reelID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reel ID"})
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reel ID")
 		return
 	}
 
@@ -240,7 +311,7 @@ func (h *ReelHandler) GetComments(c *gin.Context) {
 
 	comments, err := h.reelService.GetComments(c.Request.Context(), reelID, limit, offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -253,7 +324,7 @@ func (h *ReelHandler) AddComment(c *gin.Context) {
 
 	reelID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reel ID"})
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reel ID")
 		return
 	}
 
@@ -262,13 +333,13 @@ func (h *ReelHandler) AddComment(c *gin.Context) {
 		Mentions []primitive.ObjectID `json:"mentions"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	comment, err := h.reelService.AddComment(c.Request.Context(), reelID, objUserID, req.Content, req.Mentions)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondThreadError(c, err)
 		return
 	}
 
@@ -281,46 +352,127 @@ func (h *ReelHandler) AddReply(c *gin.Context) {
 
 	reelID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reel ID"})
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reel ID")
 		return
 	}
 
 	commentID, err := primitive.ObjectIDFromHex(c.Param("commentId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid comment ID")
 		return
 	}
 
 	var req struct {
-		Content string `json:"content" binding:"required"`
+		Content       string               `json:"content" binding:"required"`
+		ParentReplyID *primitive.ObjectID  `json:"parent_reply_id"`
+		Mentions      []primitive.ObjectID `json:"mentions"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	reply, err := h.reelService.AddReply(c.Request.Context(), reelID, commentID, objUserID, req.Content)
+	reply, err := h.reelService.AddReply(c.Request.Context(), reelID, commentID, objUserID, req.Content, req.ParentReplyID, req.Mentions)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondThreadError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, reply)
 }
 
+func (h *ReelHandler) GetReplies(c *gin.Context) {
+	reelID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reel ID")
+		return
+	}
+
+	commentID, err := primitive.ObjectIDFromHex(c.Param("commentId"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
+	offset, _ := strconv.ParseInt(c.DefaultQuery("offset", "0"), 10, 64)
+
+	replies, err := h.reelService.GetReplies(c.Request.Context(), reelID, commentID, limit, offset)
+	if err != nil {
+		respondThreadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, replies)
+}
+
+func (h *ReelHandler) DeleteComment(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	objUserID, _ := primitive.ObjectIDFromHex(userID.(string))
+
+	reelID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reel ID")
+		return
+	}
+
+	commentID, err := primitive.ObjectIDFromHex(c.Param("commentId"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	if err := h.reelService.DeleteComment(c.Request.Context(), reelID, commentID, objUserID); err != nil {
+		respondThreadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted"})
+}
+
+func (h *ReelHandler) DeleteReply(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	objUserID, _ := primitive.ObjectIDFromHex(userID.(string))
+
+	reelID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reel ID")
+		return
+	}
+
+	commentID, err := primitive.ObjectIDFromHex(c.Param("commentId"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	replyID, err := primitive.ObjectIDFromHex(c.Param("replyId"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reply ID")
+		return
+	}
+
+	if err := h.reelService.DeleteReply(c.Request.Context(), reelID, commentID, replyID, objUserID); err != nil {
+		respondThreadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reply deleted"})
+}
+
 func (h *ReelHandler) ReactToComment(c *gin.Context) {
 	userID, _ := c.Get("userID")
 	objUserID, _ := primitive.ObjectIDFromHex(userID.(string))
 
 	reelID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reel ID"})
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reel ID")
 		return
 	}
 
 	commentID, err := primitive.ObjectIDFromHex(c.Param("commentId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid comment ID")
 		return
 	}
 
@@ -328,18 +480,69 @@ func (h *ReelHandler) ReactToComment(c *gin.Context) {
 		ReactionType models.ReactionType `json:"reaction_type" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	if err := h.reelService.ReactToComment(c.Request.Context(), reelID, commentID, objUserID, req.ReactionType); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondThreadError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Reacted successfully"})
 }
 
+func (h *ReelHandler) ReactToReply(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	objUserID, _ := primitive.ObjectIDFromHex(userID.(string))
+
+	reelID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reel ID")
+		return
+	}
+
+	commentID, err := primitive.ObjectIDFromHex(c.Param("commentId"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	replyID, err := primitive.ObjectIDFromHex(c.Param("replyId"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reply ID")
+		return
+	}
+
+	var req struct {
+		ReactionType models.ReactionType `json:"reaction_type" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.reelService.ReactToReply(c.Request.Context(), reelID, commentID, replyID, objUserID, req.ReactionType); err != nil {
+		respondThreadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reacted successfully"})
+}
+
+func respondThreadError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrReelNotFound),
+		errors.Is(err, service.ErrCommentNotFound),
+		errors.Is(err, service.ErrReplyNotFound):
+		utils.RespondWithError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrNotCommentAuthor):
+		utils.RespondWithError(c, http.StatusForbidden, err.Error())
+	default:
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
 func (h *ReelHandler) getAuthor(ctx context.Context, userID string) (models.PostAuthor, error) {
 	resp, err := h.userClient.GetUser(ctx, &userpb.GetUserRequest{UserId: userID})
 	if err != nil {
@@ -351,5 +554,6 @@ func (h *ReelHandler) getAuthor(ctx context.Context, userID string) (models.Post
 		Username: resp.User.Username,
 		Avatar:   resp.User.Avatar,
 		FullName: resp.User.FullName,
+		Verified: resp.User.IsVerified,
 	}, nil
 }
@@ -0,0 +1,75 @@
+package httpapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// LiveStreams reacts to the media server starting and stopping streams
+type LiveStreams interface {
+	StreamPublished(ctx context.Context, streamKey string) (string, error)
+	StreamEnded(ctx context.Context, stream string) error
+}
+
+// LiveHooks answers nginx-rtmp's on_publish and on_publish_done callbacks.
+// nginx posts the stream name as the "name" form field; a 3xx answer to
+// on_publish renames the stream to the Location, and anything but 2xx or
+// 3xx drops the broadcaster.
+type LiveHooks struct {
+	streams LiveStreams
+	secret  string
+}
+
+func NewLiveHooks(streams LiveStreams, secret string) *LiveHooks {
+	return &LiveHooks{streams: streams, secret: secret}
+}
+
+func (h *LiveHooks) Register(router gin.IRouter) {
+	hooks := router.Group("/live/hooks", h.authorize)
+	{
+		hooks.POST("/publish", h.Publish)
+		hooks.POST("/publish-done", h.PublishDone)
+	}
+}
+
+// authorize only lets the media server in. Hooks are refused outright
+// until a secret is set.
+func (h *LiveHooks) authorize(c *gin.Context) {
+	secret := c.Query("secret")
+	if h.secret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(h.secret)) != 1 {
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+	c.Next()
+}
+
+// Publish accepts a stream whose key belongs to a waiting session and
+// renames it after the session
+func (h *LiveHooks) Publish(c *gin.Context) {
+	stream, err := h.streams.StreamPublished(c.Request.Context(), c.PostForm("name"))
+	if err != nil {
+		if !errors.Is(err, service.ErrInvalidStreamKey) {
+			slog.Error("Failed to start live session", "error", err)
+		}
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	// Set by hand, as gin would make the name a path
+	c.Header("Location", stream)
+	c.Status(http.StatusFound)
+}
+
+// PublishDone ends the session once its stream stops
+func (h *LiveHooks) PublishDone(c *gin.Context) {
+	if err := h.streams.StreamEnded(c.Request.Context(), c.PostForm("name")); err != nil && !errors.Is(err, service.ErrLiveSessionNotFound) {
+		slog.Error("Failed to end live session", "stream", c.PostForm("name"), "error", err)
+	}
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,83 @@
+package live
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNoRecording means the media server kept no recording of a stream
+var ErrNoRecording = errors.New("no recording of the stream")
+
+// MediaServer is the RTMP server broadcasters push to. It packages each
+// stream as HLS for viewers and records it for the archive. Streams are
+// named after their session once the publish hook accepts them, so viewers
+// never learn the stream key.
+type MediaServer interface {
+	// IngestURL is where broadcasters point their encoder, with the stream
+	// key as the stream name
+	IngestURL() string
+	// PlaybackURL is the HLS playlist of a stream
+	PlaybackURL(stream string) string
+	// ReadRecording returns the recording of a finished stream
+	ReadRecording(stream string) ([]byte, error)
+	// RemoveRecording frees a recording once it is archived
+	RemoveRecording(stream string) error
+}
+
+// NginxRTMP is an nginx-rtmp-module server set up with on_publish and
+// on_publish_done hooks to this service, HLS output and FLV recording:
+//
+//	application live {
+//	    live on;
+//	    on_publish      http://reel-service:8086/api/v1/live/hooks/publish?secret=...;
+//	    on_publish_done http://reel-service:8086/api/v1/live/hooks/publish-done?secret=...;
+//	    hls on;
+//	    hls_path /var/www/hls;
+//	    record all;
+//	    record_path /var/recordings;
+//	    record_unique off;
+//	}
+type NginxRTMP struct {
+	rtmpURL   string
+	hlsURL    string
+	recordDir string
+}
+
+func NewNginxRTMP(rtmpURL, hlsURL, recordDir string) *NginxRTMP {
+	return &NginxRTMP{
+		rtmpURL:   strings.TrimRight(rtmpURL, "/"),
+		hlsURL:    strings.TrimRight(hlsURL, "/"),
+		recordDir: recordDir,
+	}
+}
+
+func (n *NginxRTMP) IngestURL() string {
+	return n.rtmpURL
+}
+
+func (n *NginxRTMP) PlaybackURL(stream string) string {
+	return fmt.Sprintf("%s/%s.m3u8", n.hlsURL, stream)
+}
+
+func (n *NginxRTMP) ReadRecording(stream string) ([]byte, error) {
+	data, err := os.ReadFile(n.recordingPath(stream))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoRecording
+	}
+	return data, err
+}
+
+func (n *NginxRTMP) RemoveRecording(stream string) error {
+	err := os.Remove(n.recordingPath(stream))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (n *NginxRTMP) recordingPath(stream string) string {
+	return filepath.Join(n.recordDir, filepath.Base(stream)+".flv")
+}
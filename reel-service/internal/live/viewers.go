@@ -0,0 +1,75 @@
+package live
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// ViewerTimeout is how long a viewer stays counted after they last joined.
+// Players join again well within it while they keep watching.
+const ViewerTimeout = 30 * time.Second
+
+// RedisViewers tracks who is watching each session in a sorted set of
+// viewers scored by when they last joined
+type RedisViewers struct {
+	client goredis.Cmdable
+}
+
+func NewRedisViewers(client goredis.Cmdable) *RedisViewers {
+	return &RedisViewers{client: client}
+}
+
+func viewersKey(sessionID string) string {
+	return fmt.Sprintf("live:viewers:{%s}", sessionID)
+}
+
+// Join counts the viewer as watching and returns the viewer count
+func (v *RedisViewers) Join(ctx context.Context, sessionID, viewerID string) (int64, error) {
+	key := viewersKey(sessionID)
+	now := time.Now()
+	if err := v.client.ZAdd(ctx, key, goredis.Z{Score: float64(now.Unix()), Member: viewerID}).Err(); err != nil {
+		return 0, err
+	}
+	// Outlives the session by a little, should it never end cleanly
+	v.client.Expire(ctx, key, 24*time.Hour)
+	return v.Count(ctx, sessionID)
+}
+
+// Leave stops counting the viewer and returns the viewer count
+func (v *RedisViewers) Leave(ctx context.Context, sessionID, viewerID string) (int64, error) {
+	if err := v.client.ZRem(ctx, viewersKey(sessionID), viewerID).Err(); err != nil {
+		return 0, err
+	}
+	return v.Count(ctx, sessionID)
+}
+
+// Count drops the viewers who timed out and counts the rest
+func (v *RedisViewers) Count(ctx context.Context, sessionID string) (int64, error) {
+	key := viewersKey(sessionID)
+	if err := v.client.ZRemRangeByScore(ctx, key, "-inf", "("+v.cutoff()).Err(); err != nil {
+		return 0, err
+	}
+	return v.client.ZCard(ctx, key).Result()
+}
+
+// Viewers lists the users watching the session
+func (v *RedisViewers) Viewers(ctx context.Context, sessionID string) ([]string, error) {
+	return v.client.ZRangeByScore(ctx, viewersKey(sessionID), &goredis.ZRangeBy{
+		Min: v.cutoff(),
+		Max: "+inf",
+	}).Result()
+}
+
+// Clear forgets the session's viewers once it ends
+func (v *RedisViewers) Clear(ctx context.Context, sessionID string) error {
+	return v.client.Del(ctx, viewersKey(sessionID)).Err()
+}
+
+// cutoff is the score of the oldest join still counted
+func (v *RedisViewers) cutoff() string {
+	return strconv.FormatInt(time.Now().Add(-ViewerTimeout).Unix(), 10)
+}
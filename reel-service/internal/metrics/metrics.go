@@ -11,6 +11,9 @@ type BusinessMetrics struct {
 	ReelsViewed    prometheus.Counter
 	ReactionsAdded prometheus.Counter
 	CommentsAdded  prometheus.Counter
+
+	ReelsTranscoded   *prometheus.CounterVec
+	TranscodeDuration prometheus.Histogram
 }
 
 func NewBusinessMetrics() *BusinessMetrics {
@@ -35,5 +38,14 @@ func NewBusinessMetrics() *BusinessMetrics {
 			Name: "reel_service_comments_added_total",
 			Help: "Total number of comments added",
 		}),
+		ReelsTranscoded: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "reel_service_reels_transcoded_total",
+			Help: "Total number of transcoding jobs by outcome",
+		}, []string{"status"}),
+		TranscodeDuration: promauto.NewHistogram(prometheus.HistogramOpts{
+			Name:    "reel_service_transcode_duration_seconds",
+			Help:    "Time to transcode and store a reel's renditions",
+			Buckets: []float64{5, 15, 30, 60, 120, 300, 600, 900},
+		}),
 	}
 }
@@ -10,20 +10,27 @@ import (
 	"time"
 
 	"github.com/MuhibNayem/connectify-v2/reel-service/config"
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/consumer"
 	reelgrpc "github.com/MuhibNayem/connectify-v2/reel-service/internal/grpc"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/httpapi"
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/live"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/metrics"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/producer"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/repository"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/resilience"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/storage"
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/transcoder"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
+	sharedkafka "github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
 	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
+	sharedresilience "github.com/MuhibNayem/connectify-v2/shared-entity/resilience"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type Application struct {
@@ -31,11 +38,25 @@ type Application struct {
 	mongoClient *mongo.Client
 	grpcServer  *grpc.Server
 	producer    *producer.ReelProducer
+	notifier    *producer.NotificationProducer
+	realtime    *producer.RealtimeProducer
+	engagement  *producer.EngagementProducer
 	httpServer  *http.Server
 	redisClient *redis.ClusterClient
 	userConn    *grpc.ClientConn
 	userClient  userpb.UserServiceClient
 
+	storageClient     *storage.Client
+	transcodeConsumer *consumer.TranscodeConsumer
+	workerCancel      context.CancelFunc
+
+	// Hides reels whose thumbnail moderation flagged
+	moderationVerdicts *sharedkafka.ModerationVerdictConsumer
+	// Erases the reels of deleted accounts
+	deletions *sharedkafka.UserDeletionParticipant
+	// Learns viewers' interests for the For You feed
+	engagementConsumer *consumer.EngagementConsumer
+
 	reelRepo    *repository.ReelRepository
 	reelService *service.ReelService
 	grpcHandler *reelgrpc.Server
@@ -75,6 +96,9 @@ func (a *Application) Bootstrap() error {
 	db := mongoClient.Database(a.cfg.DBName)
 
 	a.producer = producer.NewReelProducer(a.cfg.KafkaBrokers, a.cfg.KafkaTopic)
+	a.notifier = producer.NewNotificationProducer(a.cfg.KafkaBrokers, a.cfg.NotificationTopic)
+	a.realtime = producer.NewRealtimeProducer(a.cfg.KafkaBrokers, a.cfg.RealtimeTopic)
+	a.engagement = producer.NewEngagementProducer(a.cfg.KafkaBrokers, a.cfg.EngagementTopic)
 	slog.Info("Kafka producer initialized")
 
 	a.reelRepo = repository.NewReelRepository(db)
@@ -107,12 +131,39 @@ func (a *Application) Bootstrap() error {
 		slog.Default(),
 		a.redisClient,
 	)
+	a.reelService.SetNotificationPublisher(a.notifier)
+	a.reelService.SetRealtimePublisher(a.realtime)
+	a.reelService.SetInterestStore(repository.NewInterestRepository(db))
+	a.reelService.SetEngagementPublisher(a.engagement)
+
+	if a.cfg.TranscodeEnabled {
+		if err := a.initTranscoder(businessMetrics); err != nil {
+			return fmt.Errorf("failed to initialize transcoder: %w", err)
+		}
+	}
+
+	var liveHooks *httpapi.LiveHooks
+	if a.cfg.LiveEnabled {
+		if err := a.initLive(db); err != nil {
+			return fmt.Errorf("failed to initialize live streaming: %w", err)
+		}
+		liveHooks = httpapi.NewLiveHooks(a.reelService, a.cfg.LiveHookSecret)
+	}
+
+	a.moderationVerdicts = sharedkafka.NewModerationVerdictConsumer(
+		a.cfg.KafkaBrokers,
+		"reel-service-moderation",
+		a.reelService.ApplyModerationVerdict,
+	)
+	a.deletions = sharedkafka.NewUserDeletionParticipant(a.cfg.KafkaBrokers, models.ErasureServiceReels, a.reelService.DeleteUserData)
+	a.engagementConsumer = consumer.NewEngagementConsumer(a.cfg.KafkaBrokers, a.cfg.EngagementTopic, a.reelService.ApplyEngagement, slog.Default())
 
 	a.grpcHandler = reelgrpc.NewServer(a.reelService)
 	a.grpcHandler.Register(a.grpcServer)
+	dataexport.NewServer(models.ErasureServiceReels, a.reelService.ExportUserData).Register(a.grpcServer)
 
 	httpHandler := httpapi.NewReelHandler(a.reelService, a.userClient, businessMetrics)
-	router := httpapi.BuildRouter(a.cfg, httpHandler, a.redisClient)
+	router := httpapi.BuildRouter(a.cfg, httpHandler, liveHooks, a.redisClient)
 	a.httpServer = &http.Server{
 		Addr:    fmt.Sprintf(":%s", a.cfg.ServerPort),
 		Handler: router,
@@ -125,6 +176,26 @@ func (a *Application) Bootstrap() error {
 func (a *Application) Run() error {
 	errCh := make(chan error, 2)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	a.workerCancel = cancel
+
+	if a.transcodeConsumer != nil {
+		a.transcodeConsumer.Start(ctx)
+		slog.Info("Transcode workers started", "workers", a.cfg.TranscodeWorkers)
+	}
+	if a.moderationVerdicts != nil {
+		go a.moderationVerdicts.Run(ctx)
+	}
+	if a.deletions != nil {
+		go a.deletions.Run(ctx)
+	}
+	if a.engagementConsumer != nil {
+		go a.engagementConsumer.Run(ctx)
+	}
+	if a.cfg.LiveEnabled {
+		go a.reelService.RunLiveViewerCounts(ctx)
+	}
+
 	if a.httpServer != nil {
 		go func() {
 			slog.Info("Reel service HTTP server listening", "port", a.cfg.ServerPort)
@@ -177,6 +248,39 @@ func (a *Application) Shutdown() {
 		slog.Info("gRPC server stopped")
 	}
 
+	if a.workerCancel != nil {
+		a.workerCancel()
+	}
+	if err := a.moderationVerdicts.Close(); err != nil {
+		slog.Error("Error closing moderation verdict consumer", "error", err)
+	}
+	if a.deletions != nil {
+		if err := a.deletions.Close(); err != nil {
+			slog.Error("Error closing user deletion participant", "error", err)
+		}
+	}
+	if a.engagementConsumer != nil {
+		if err := a.engagementConsumer.Close(); err != nil {
+			slog.Error("Error closing engagement consumer", "error", err)
+		}
+	}
+
+	if a.transcodeConsumer != nil {
+		if err := a.transcodeConsumer.Stop(); err != nil {
+			slog.Error("Error stopping transcode consumer", "error", err)
+		} else {
+			slog.Info("Transcode consumer stopped")
+		}
+	}
+
+	if a.storageClient != nil {
+		if err := a.storageClient.Close(); err != nil {
+			slog.Error("Error closing storage-service client connection", "error", err)
+		} else {
+			slog.Info("Storage-service client connection closed")
+		}
+	}
+
 	if a.producer != nil {
 		if err := a.producer.Close(); err != nil {
 			slog.Error("Error closing Kafka producer", "error", err)
@@ -184,6 +288,21 @@ func (a *Application) Shutdown() {
 			slog.Info("Kafka producer closed")
 		}
 	}
+	if a.notifier != nil {
+		if err := a.notifier.Close(); err != nil {
+			slog.Error("Error closing notification producer", "error", err)
+		}
+	}
+	if a.realtime != nil {
+		if err := a.realtime.Close(); err != nil {
+			slog.Error("Error closing realtime producer", "error", err)
+		}
+	}
+	if a.engagement != nil {
+		if err := a.engagement.Close(); err != nil {
+			slog.Error("Error closing engagement producer", "error", err)
+		}
+	}
 
 	if a.mongoClient != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -243,13 +362,11 @@ func (a *Application) initRedis() error {
 }
 
 func (a *Application) initUserClient() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	conn, err := grpc.DialContext(ctx,
-		net.JoinHostPort(a.cfg.UserServiceHost, a.cfg.UserServicePort),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	// Profile lookups are reads, so they are retried when user-service is briefly unavailable
+	conn, err := sharedresilience.NewGRPCClient("user-service").
+		WithTimeout(3*time.Second).
+		WithRetry(sharedresilience.DefaultRetryPolicy(), sharedresilience.ReadOnlyMethods...).
+		Dial(net.JoinHostPort(a.cfg.UserServiceHost, a.cfg.UserServicePort))
 	if err != nil {
 		return err
 	}
@@ -259,3 +376,50 @@ func (a *Application) initUserClient() error {
 	slog.Info("Connected to user service", "host", a.cfg.UserServiceHost, "port", a.cfg.UserServicePort)
 	return nil
 }
+
+func (a *Application) initTranscoder(businessMetrics *metrics.BusinessMetrics) error {
+	storageClient, err := storage.NewClient(a.cfg.StorageServiceHost, a.cfg.StorageServicePort, a.cfg.StorageBucket)
+	if err != nil {
+		return err
+	}
+	a.storageClient = storageClient
+
+	pipeline := transcoder.NewPipeline(
+		transcoder.NewFFmpeg(a.cfg.FFmpegPath, a.cfg.FFprobePath),
+		storageClient,
+		a.cfg.TranscodeWorkDir,
+	)
+
+	consumerCfg := consumer.DefaultTranscodeConfig(a.cfg.KafkaBrokers, a.cfg.KafkaTopic)
+	consumerCfg.Workers = a.cfg.TranscodeWorkers
+	consumerCfg.Timeout = a.cfg.TranscodeTimeout
+	a.transcodeConsumer = consumer.NewTranscodeConsumer(consumerCfg, a.reelRepo, pipeline, businessMetrics, slog.Default())
+
+	slog.Info("Transcoder initialized", "storage_host", a.cfg.StorageServiceHost, "storage_port", a.cfg.StorageServicePort)
+	return nil
+}
+
+// initLive sets up live sessions. Broadcasts are archived through the
+// storage-service, which the transcoder may already be connected to.
+func (a *Application) initLive(db *mongo.Database) error {
+	if a.storageClient == nil {
+		storageClient, err := storage.NewClient(a.cfg.StorageServiceHost, a.cfg.StorageServicePort, a.cfg.StorageBucket)
+		if err != nil {
+			return err
+		}
+		a.storageClient = storageClient
+	}
+
+	a.reelService.SetLiveStreaming(service.LiveStreaming{
+		Store:   repository.NewLiveRepository(db),
+		Viewers: live.NewRedisViewers(a.redisClient.GetClient()),
+		Media:   live.NewNginxRTMP(a.cfg.LiveRTMPURL, a.cfg.LiveHLSURL, a.cfg.LiveRecordDir),
+		Storage: a.storageClient,
+	})
+	if a.cfg.LiveHookSecret == "" {
+		slog.Warn("LIVE_HOOK_SECRET is not set, the media server will be refused")
+	}
+
+	slog.Info("Live streaming initialized", "rtmp_url", a.cfg.LiveRTMPURL)
+	return nil
+}
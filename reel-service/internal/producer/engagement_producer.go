@@ -0,0 +1,45 @@
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/recommend"
+	"github.com/segmentio/kafka-go"
+)
+
+// EngagementProducer publishes the watch, complete, like and share signals
+// that the For You feed learns from
+type EngagementProducer struct {
+	writer *kafka.Writer
+}
+
+func NewEngagementProducer(brokers []string, topic string) *EngagementProducer {
+	return &EngagementProducer{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireOne,
+			Async:        true, // Signals are high volume and losing one is harmless
+		},
+	}
+}
+
+func (p *EngagementProducer) PublishEngagement(ctx context.Context, event recommend.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.UserID), // A user's signals update one interest vector in order
+		Value: payload,
+		Time:  time.Now(),
+	})
+}
+
+func (p *EngagementProducer) Close() error {
+	return p.writer.Close()
+}
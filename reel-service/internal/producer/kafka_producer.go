@@ -11,6 +11,7 @@ import (
 
 type ReelBroadcaster interface {
 	PublishReelCreated(ctx context.Context, event ReelCreatedEvent)
+	PublishReelUploaded(ctx context.Context, event ReelUploadedEvent)
 	PublishReelDeleted(ctx context.Context, event ReelDeletedEvent)
 	PublishReelViewed(ctx context.Context, event ReelViewedEvent)
 	Close() error
@@ -22,6 +23,12 @@ type ReelCreatedEvent struct {
 	VideoURL string `json:"video_url"`
 }
 
+// ReelUploadedEvent asks the transcoder to build HLS renditions of a reel
+type ReelUploadedEvent struct {
+	ReelID   string `json:"reel_id"`
+	VideoURL string `json:"video_url"`
+}
+
 type ReelDeletedEvent struct {
 	ReelID string `json:"reel_id"`
 	UserID string `json:"user_id"`
@@ -51,6 +58,10 @@ func (p *ReelProducer) PublishReelCreated(ctx context.Context, event ReelCreated
 	p.publish(ctx, "reel.created", event)
 }
 
+func (p *ReelProducer) PublishReelUploaded(ctx context.Context, event ReelUploadedEvent) {
+	p.publish(ctx, "reel.uploaded", event)
+}
+
 func (p *ReelProducer) PublishReelDeleted(ctx context.Context, event ReelDeletedEvent) {
 	p.publish(ctx, "reel.deleted", event)
 }
@@ -0,0 +1,111 @@
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/segmentio/kafka-go"
+)
+
+// Live updates to reel comment threads, delivered by the messaging Hub
+const (
+	EventReelCommentCreated  = "ReelCommentCreated"
+	EventReelCommentDeleted  = "ReelCommentDeleted"
+	EventReelReplyCreated    = "ReelReplyCreated"
+	EventReelReplyDeleted    = "ReelReplyDeleted"
+	EventReelReactionCreated = "ReelReactionCreated"
+	EventReelReactionDeleted = "ReelReactionDeleted"
+)
+
+// ReelThreadEvent describes a change to a reel's comment thread
+type ReelThreadEvent struct {
+	ReelID    string           `json:"reel_id"`
+	CommentID string           `json:"comment_id"`
+	ReplyID   string           `json:"reply_id,omitempty"`
+	Comment   *models.Comment  `json:"comment,omitempty"`
+	Reply     *models.Reply    `json:"reply,omitempty"`
+	Reaction  *models.Reaction `json:"reaction,omitempty"`
+}
+
+// Live session updates, delivered by the messaging Hub to the session's
+// viewers and broadcaster
+const (
+	EventLiveViewerCount = "LiveViewerCount"
+	EventLiveChatMessage = "LiveChatMessage"
+	EventLiveEnded       = "LiveEnded"
+)
+
+// LiveEvent describes a change to a live session
+type LiveEvent struct {
+	SessionID   string                  `json:"session_id"`
+	ViewerCount int64                   `json:"viewer_count"`
+	Message     *models.LiveChatMessage `json:"message,omitempty"`
+}
+
+// RealtimeProducer publishes WebSocket events for the messaging Hub, which
+// sends each one to the users it lists
+type RealtimeProducer struct {
+	writer *kafka.Writer
+}
+
+func NewRealtimeProducer(brokers []string, topic string) *RealtimeProducer {
+	return &RealtimeProducer{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireOne,
+			Async:        true,
+		},
+	}
+}
+
+func (p *RealtimeProducer) PublishReelEvent(ctx context.Context, eventType string, event ReelThreadEvent, recipients []string) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(models.WebSocketEvent{
+		Type:       eventType,
+		Data:       data,
+		Recipients: recipients,
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.ReelID), // Keep a reel's updates in order
+		Value: payload,
+		Time:  time.Now(),
+	})
+}
+
+func (p *RealtimeProducer) PublishLiveEvent(ctx context.Context, eventType string, event LiveEvent, recipients []string) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(models.WebSocketEvent{
+		Type:       eventType,
+		Data:       data,
+		Recipients: recipients,
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.SessionID), // Keep a session's updates in order
+		Value: payload,
+		Time:  time.Now(),
+	})
+}
+
+func (p *RealtimeProducer) Close() error {
+	return p.writer.Close()
+}
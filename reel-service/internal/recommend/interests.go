@@ -0,0 +1,167 @@
+package recommend
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// HalfLife is how long an interest takes to fade to half its weight
+	HalfLife = 14 * 24 * time.Hour
+
+	maxInterests   = 50 // Topics, and creators, kept per user
+	minWeight      = 0.01
+	maxTopicLength = 32
+	maxHashtags    = 10
+)
+
+// Interests is a user's interest vector: how much they engage with each
+// topic and creator. Weights fade with HalfLife, so tastes can change.
+type Interests struct {
+	UserID    primitive.ObjectID `bson:"_id" json:"user_id"`
+	Topics    map[string]float64 `bson:"topics" json:"topics"`
+	Creators  map[string]float64 `bson:"creators" json:"creators"` // By user ID
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// Add fades the vector to at, then credits the reel's topics and creator
+// with weight
+func (in *Interests) Add(reel *models.Reel, weight float64, at time.Time) {
+	in.decay(at)
+	if in.Topics == nil {
+		in.Topics = make(map[string]float64)
+	}
+	if in.Creators == nil {
+		in.Creators = make(map[string]float64)
+	}
+
+	for _, topic := range Topics(reel) {
+		in.Topics[topic] += weight
+	}
+	in.Creators[reel.UserID.Hex()] += weight
+
+	prune(in.Topics)
+	prune(in.Creators)
+}
+
+// TopTopics returns up to n topics, strongest first
+func (in *Interests) TopTopics(n int) []string {
+	topics := ranked(in.Topics)
+	if len(topics) > n {
+		topics = topics[:n]
+	}
+	return topics
+}
+
+// decay fades every weight by the time since the last update. Events that
+// arrive out of order are credited without fading.
+func (in *Interests) decay(at time.Time) {
+	if in.UpdatedAt.IsZero() {
+		in.UpdatedAt = at
+		return
+	}
+	if !at.After(in.UpdatedAt) {
+		return
+	}
+
+	factor := math.Exp2(-float64(at.Sub(in.UpdatedAt)) / float64(HalfLife))
+	for k := range in.Topics {
+		in.Topics[k] *= factor
+	}
+	for k := range in.Creators {
+		in.Creators[k] *= factor
+	}
+	in.UpdatedAt = at
+}
+
+// prune drops faded weights and keeps only the strongest maxInterests
+func prune(weights map[string]float64) {
+	for k, w := range weights {
+		if w < minWeight {
+			delete(weights, k)
+		}
+	}
+	if len(weights) <= maxInterests {
+		return
+	}
+	for _, k := range ranked(weights)[maxInterests:] {
+		delete(weights, k)
+	}
+}
+
+// ranked returns the keys by weight, strongest first
+func ranked(weights map[string]float64) []string {
+	keys := make([]string, 0, len(weights))
+	for k := range weights {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if weights[keys[i]] != weights[keys[j]] {
+			return weights[keys[i]] > weights[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// Topics returns a reel's category and hashtags, without duplicates
+func Topics(reel *models.Reel) []string {
+	seen := make(map[string]bool)
+	var topics []string
+	for _, t := range append([]string{reel.Category}, reel.Hashtags...) {
+		t = NormalizeTopic(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		topics = append(topics, t)
+	}
+	return topics
+}
+
+var hashtagPattern = regexp.MustCompile(`#([\p{L}\p{N}_]+)`)
+
+// Hashtags extracts the first few distinct hashtags from a caption
+func Hashtags(caption string) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, match := range hashtagPattern.FindAllStringSubmatch(caption, -1) {
+		tag := NormalizeTopic(match[1])
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+		if len(tags) == maxHashtags {
+			break
+		}
+	}
+	return tags
+}
+
+// NormalizeTopic lowercases a topic and reduces it to letters, digits and
+// underscores, so "Street Food" and "street_food" match and topics are safe
+// to use as document keys
+func NormalizeTopic(topic string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(topic)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '_':
+			b.WriteRune(r)
+		case r == ' ', r == '-':
+			b.WriteRune('_')
+		}
+	}
+	t := []rune(strings.Trim(b.String(), "_"))
+	if len(t) > maxTopicLength {
+		t = t[:maxTopicLength]
+	}
+	return string(t)
+}
@@ -0,0 +1,134 @@
+package recommend
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Source is the generator that proposed a candidate
+type Source string
+
+const (
+	SourceFollowing Source = "following" // New from creators the viewer follows
+	SourceSimilar   Source = "similar"   // On the viewer's strongest topics
+	SourceTrending  Source = "trending"  // Drawing the most engagement lately
+)
+
+// sourceBoost favours creators the viewer chose to follow over reels the
+// generators guessed at
+var sourceBoost = map[Source]float64{
+	SourceFollowing: 1.0,
+	SourceSimilar:   0.6,
+	SourceTrending:  0.4,
+}
+
+const (
+	// MaxPerCreator keeps one creator from filling a page
+	MaxPerCreator = 2
+
+	freshness = 48 * time.Hour // Age at which recency counts for about a third
+)
+
+// CandidateQuery narrows a candidate search to reels the viewer may see and
+// has not been served
+type CandidateQuery struct {
+	ViewerID  primitive.ObjectID
+	FriendIDs []primitive.ObjectID
+	Exclude   []primitive.ObjectID
+	Since     time.Time // Zero for any age
+	Limit     int64
+}
+
+// Candidate is a reel proposed for the viewer's feed
+type Candidate struct {
+	Reel   models.Reel
+	Source Source
+}
+
+// Rank scores the candidates against the viewer's interests and returns up
+// to limit, best first. Reels proposed twice are kept once, and reels in
+// seen and the viewer's own are dropped. No creator gets more than
+// MaxPerCreator reels unless there is nothing else to fill the page.
+func Rank(candidates []Candidate, interests *Interests, seen map[primitive.ObjectID]bool, viewerID primitive.ObjectID, now time.Time, limit int) []models.Reel {
+	type scored struct {
+		reel  models.Reel
+		score float64
+	}
+
+	index := make(map[primitive.ObjectID]int)
+	var pool []scored
+	for _, c := range candidates {
+		if seen[c.Reel.ID] || c.Reel.UserID == viewerID {
+			continue
+		}
+		s := score(c, interests, now)
+		if i, ok := index[c.Reel.ID]; ok {
+			pool[i].score = math.Max(pool[i].score, s)
+			continue
+		}
+		index[c.Reel.ID] = len(pool)
+		pool = append(pool, scored{reel: c.Reel, score: s})
+	}
+
+	sort.SliceStable(pool, func(i, j int) bool {
+		if pool[i].score != pool[j].score {
+			return pool[i].score > pool[j].score
+		}
+		return pool[i].reel.CreatedAt.After(pool[j].reel.CreatedAt)
+	})
+
+	reels := make([]models.Reel, 0, limit)
+	perCreator := make(map[primitive.ObjectID]int)
+	var overflow []models.Reel
+	for _, s := range pool {
+		if len(reels) == limit {
+			break
+		}
+		if perCreator[s.reel.UserID] == MaxPerCreator {
+			overflow = append(overflow, s.reel)
+			continue
+		}
+		perCreator[s.reel.UserID]++
+		reels = append(reels, s.reel)
+	}
+	for _, reel := range overflow {
+		if len(reels) == limit {
+			break
+		}
+		reels = append(reels, reel)
+	}
+	return reels
+}
+
+// score adds the candidate's source, the viewer's affinity for its creator
+// and topics, its engagement and its freshness
+func score(c Candidate, interests *Interests, now time.Time) float64 {
+	s := sourceBoost[c.Source]
+	if interests != nil {
+		s += saturate(interests.Creators[c.Reel.UserID.Hex()])
+		var topics float64
+		for _, t := range Topics(&c.Reel) {
+			topics += interests.Topics[t]
+		}
+		s += saturate(topics)
+	}
+	s += math.Min(math.Log1p(math.Max(c.Reel.EngagementScore, 0))/10, 1)
+	if age := now.Sub(c.Reel.CreatedAt); age > 0 {
+		s += math.Exp(-float64(age) / float64(freshness))
+	} else {
+		s++
+	}
+	return s
+}
+
+// saturate maps a weight onto [0, 1), so no single affinity dominates
+func saturate(w float64) float64 {
+	if w <= 0 {
+		return 0
+	}
+	return w / (w + 5)
+}
@@ -0,0 +1,124 @@
+package recommend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWeight(t *testing.T) {
+	assert.InDelta(t, 0.5, Weight(Event{Signal: SignalWatch, WatchTimeMs: 5000}, 10), 1e-9)
+	assert.Equal(t, 1.0, Weight(Event{Signal: SignalWatch, WatchTimeMs: 60000}, 10), "watching twice through counts as once")
+	assert.InDelta(t, 0.2, Weight(Event{Signal: SignalWatch, WatchTimeMs: 3000}, 0), 1e-9, "reels without a duration assume 15s")
+	assert.Equal(t, 0.0, Weight(Event{Signal: SignalWatch, WatchTimeMs: -1}, 10))
+	assert.Greater(t, Weight(Event{Signal: SignalShare}, 10), Weight(Event{Signal: SignalLike}, 10))
+	assert.Greater(t, Weight(Event{Signal: SignalLike}, 10), Weight(Event{Signal: SignalComplete}, 10))
+	assert.Equal(t, 0.0, Weight(Event{Signal: "skip"}, 10))
+}
+
+func TestInterests_AddDecays(t *testing.T) {
+	creator := primitive.NewObjectID()
+	reel := &models.Reel{UserID: creator, Category: "Cooking", Hashtags: []string{"pasta", "cooking"}}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var in Interests
+	in.Add(reel, 4, start)
+	assert.Equal(t, map[string]float64{"cooking": 4, "pasta": 4}, in.Topics)
+	assert.Equal(t, 4.0, in.Creators[creator.Hex()])
+
+	in.Add(&models.Reel{UserID: primitive.NewObjectID(), Category: "travel"}, 1, start.Add(HalfLife))
+	assert.InDelta(t, 2, in.Topics["cooking"], 1e-9)
+	assert.InDelta(t, 2, in.Creators[creator.Hex()], 1e-9)
+	assert.Equal(t, 1.0, in.Topics["travel"])
+	assert.Equal(t, []string{"cooking", "pasta"}, in.TopTopics(2))
+
+	// Late events are credited without rewinding the clock
+	in.Add(reel, 1, start)
+	assert.InDelta(t, 3, in.Topics["cooking"], 1e-9)
+	assert.Equal(t, start.Add(HalfLife), in.UpdatedAt)
+}
+
+func TestInterests_Prune(t *testing.T) {
+	var in Interests
+	now := time.Now()
+	for i := 0; i < maxInterests+10; i++ {
+		in.Add(&models.Reel{UserID: primitive.NewObjectID(), Category: string(rune('a'+i%26)) + string(rune('a'+i/26))}, float64(i+1), now)
+	}
+	assert.Len(t, in.Topics, maxInterests)
+	assert.Len(t, in.Creators, maxInterests)
+	_, weakest := in.Topics["aa"]
+	assert.False(t, weakest)
+}
+
+func TestHashtags(t *testing.T) {
+	assert.Equal(t, []string{"streetfood", "ramen", "東京"}, Hashtags("Best #StreetFood in town #ramen #streetfood #東京!"))
+	assert.Nil(t, Hashtags("no tags here"))
+}
+
+func TestNormalizeTopic(t *testing.T) {
+	assert.Equal(t, "street_food", NormalizeTopic(" Street Food "))
+	assert.Equal(t, "diy_crafts", NormalizeTopic("DIY-crafts!"))
+	assert.Equal(t, "ab", NormalizeTopic("a.$b"))
+	assert.Equal(t, "", NormalizeTopic("$."))
+}
+
+func TestRank(t *testing.T) {
+	now := time.Now()
+	viewer := primitive.NewObjectID()
+	followed := primitive.NewObjectID()
+	stranger := primitive.NewObjectID()
+
+	reel := func(creator primitive.ObjectID, age time.Duration, category string) models.Reel {
+		return models.Reel{ID: primitive.NewObjectID(), UserID: creator, Category: category, CreatedAt: now.Add(-age)}
+	}
+	fromFollowed := reel(followed, time.Hour, "")
+	onTopic := reel(stranger, time.Hour, "cooking")
+	offTopic := reel(stranger, time.Hour, "cars")
+	seen := reel(stranger, time.Minute, "cooking")
+	own := reel(viewer, time.Minute, "cooking")
+
+	interests := &Interests{Topics: map[string]float64{"cooking": 10}, UpdatedAt: now}
+	got := Rank([]Candidate{
+		{Reel: offTopic, Source: SourceTrending},
+		{Reel: onTopic, Source: SourceTrending},
+		{Reel: onTopic, Source: SourceSimilar},
+		{Reel: fromFollowed, Source: SourceFollowing},
+		{Reel: seen, Source: SourceSimilar},
+		{Reel: own, Source: SourceTrending},
+	}, interests, map[primitive.ObjectID]bool{seen.ID: true}, viewer, now, 10)
+
+	assert.Equal(t, []primitive.ObjectID{onTopic.ID, fromFollowed.ID, offTopic.ID}, ids(got))
+}
+
+func TestRank_CapsCreators(t *testing.T) {
+	now := time.Now()
+	prolific := primitive.NewObjectID()
+	var candidates []Candidate
+	for i := 0; i < 4; i++ {
+		candidates = append(candidates, Candidate{
+			Reel:   models.Reel{ID: primitive.NewObjectID(), UserID: prolific, CreatedAt: now.Add(-time.Duration(i) * time.Minute)},
+			Source: SourceFollowing,
+		})
+	}
+	other := models.Reel{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), CreatedAt: now.Add(-72 * time.Hour)}
+	candidates = append(candidates, Candidate{Reel: other, Source: SourceTrending})
+
+	got := Rank(candidates, nil, nil, primitive.NewObjectID(), now, 3)
+	assert.Equal(t, []primitive.ObjectID{candidates[0].Reel.ID, candidates[1].Reel.ID, other.ID}, ids(got))
+
+	// With nothing else to show, the cap gives way
+	got = Rank(candidates, nil, nil, primitive.NewObjectID(), now, 10)
+	assert.Len(t, got, 5)
+	assert.Equal(t, candidates[2].Reel.ID, got[3].ID)
+}
+
+func ids(reels []models.Reel) []primitive.ObjectID {
+	out := make([]primitive.ObjectID, len(reels))
+	for i, r := range reels {
+		out[i] = r.ID
+	}
+	return out
+}
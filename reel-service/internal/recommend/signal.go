@@ -0,0 +1,60 @@
+package recommend
+
+import (
+	"math"
+	"time"
+)
+
+// Signal is a way a viewer engages with a reel
+type Signal string
+
+const (
+	SignalWatch    Signal = "watch"    // Watched for WatchTimeMs
+	SignalComplete Signal = "complete" // Watched to the end
+	SignalLike     Signal = "like"
+	SignalShare    Signal = "share"
+)
+
+// Valid reports whether s is a known signal
+func (s Signal) Valid() bool {
+	switch s {
+	case SignalWatch, SignalComplete, SignalLike, SignalShare:
+		return true
+	}
+	return false
+}
+
+// Event is one engagement as it travels on the engagement topic. Events are
+// keyed by user so each user's are applied in order.
+type Event struct {
+	ReelID      string    `json:"reel_id"`
+	UserID      string    `json:"user_id"`
+	Signal      Signal    `json:"signal"`
+	WatchTimeMs int64     `json:"watch_time_ms,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// defaultWatch stands in for the length of reels that didn't report one
+const defaultWatch = 15 * time.Second
+
+// Weight is how strongly an engagement says the viewer wants more like the
+// reel. Watches count by the share of the reel watched, so swiping away
+// after a second barely registers.
+func Weight(e Event, durationSeconds int) float64 {
+	switch e.Signal {
+	case SignalWatch:
+		full := time.Duration(durationSeconds) * time.Second
+		if full <= 0 {
+			full = defaultWatch
+		}
+		watched := float64(e.WatchTimeMs) / float64(full.Milliseconds())
+		return math.Min(math.Max(watched, 0), 1)
+	case SignalComplete:
+		return 2
+	case SignalLike:
+		return 3
+	case SignalShare:
+		return 5
+	}
+	return 0
+}
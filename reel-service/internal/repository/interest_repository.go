@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/recommend"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// seenRetention is how long a served reel stays out of the For You feed
+const seenRetention = 30 * 24 * time.Hour
+
+// InterestRepository keeps the viewers' interest vectors and the reels the
+// For You feed has served them
+type InterestRepository struct {
+	interestsCollection *mongo.Collection
+	seenCollection      *mongo.Collection
+}
+
+type seenReel struct {
+	UserID primitive.ObjectID `bson:"user_id"`
+	ReelID primitive.ObjectID `bson:"reel_id"`
+	SeenAt time.Time          `bson:"seen_at"`
+}
+
+func NewInterestRepository(db *mongo.Database) *InterestRepository {
+	ctx := context.Background()
+
+	db.Collection("reel_seen").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "reel_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "seen_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(seenRetention.Seconds())),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "seen_at", Value: -1}}},
+	})
+
+	return &InterestRepository{
+		interestsCollection: db.Collection("reel_interests"),
+		seenCollection:      db.Collection("reel_seen"),
+	}
+}
+
+// GetInterests returns the user's interest vector, or nil if they have
+// not engaged with any reels
+func (r *InterestRepository) GetInterests(ctx context.Context, userID primitive.ObjectID) (*recommend.Interests, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var interests recommend.Interests
+	err := r.interestsCollection.FindOne(ctx, bson.M{"_id": userID}).Decode(&interests)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &interests, nil
+}
+
+func (r *InterestRepository) SaveInterests(ctx context.Context, interests *recommend.Interests) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := r.interestsCollection.ReplaceOne(ctx, bson.M{"_id": interests.UserID}, interests, options.Replace().SetUpsert(true))
+	return err
+}
+
+// MarkSeen records that the user has been served the reels
+func (r *InterestRepository) MarkSeen(ctx context.Context, userID primitive.ObjectID, reelIDs []primitive.ObjectID) error {
+	if len(reelIDs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(reelIDs))
+	for _, reelID := range reelIDs {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"user_id": userID, "reel_id": reelID}).
+			SetUpdate(bson.M{"$set": bson.M{"seen_at": now}}).
+			SetUpsert(true))
+	}
+	_, err := r.seenCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// GetSeenReelIDs returns the reels most recently served to the user
+func (r *InterestRepository) GetSeenReelIDs(ctx context.Context, userID primitive.ObjectID, limit int64) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "seen_at", Value: -1}}).
+		SetLimit(limit).
+		SetProjection(bson.M{"reel_id": 1})
+	cur, err := r.seenCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var seen []seenReel
+	if err := cur.All(ctx, &seen); err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(seen))
+	for _, s := range seen {
+		ids = append(ids, s.ReelID)
+	}
+	return ids, nil
+}
+
+// DeleteUserData erases the user's interest vector and served reels
+func (r *InterestRepository) DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var total int64
+	res, err := r.interestsCollection.DeleteOne(ctx, bson.M{"_id": userID})
+	if err != nil {
+		return total, err
+	}
+	total += res.DeletedCount
+
+	res, err = r.seenCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return total, err
+	}
+	total += res.DeletedCount
+	return total, nil
+}
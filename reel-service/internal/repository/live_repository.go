@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LiveRepository keeps live sessions and their chat
+type LiveRepository struct {
+	sessionsCollection *mongo.Collection
+	chatCollection     *mongo.Collection
+}
+
+func NewLiveRepository(db *mongo.Database) *LiveRepository {
+	ctx := context.Background()
+
+	db.Collection("live_sessions").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "stream_key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "viewer_count", Value: -1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+	db.Collection("live_chat").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "session_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	})
+
+	return &LiveRepository{
+		sessionsCollection: db.Collection("live_sessions"),
+		chatCollection:     db.Collection("live_chat"),
+	}
+}
+
+func (r *LiveRepository) CreateSession(ctx context.Context, session *models.LiveSession) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	session.ID = primitive.NewObjectID()
+	_, err := r.sessionsCollection.InsertOne(ctx, session)
+	return err
+}
+
+// GetSession returns mongo.ErrNoDocuments when there is no such session
+func (r *LiveRepository) GetSession(ctx context.Context, id primitive.ObjectID) (*models.LiveSession, error) {
+	return r.findSession(ctx, bson.M{"_id": id})
+}
+
+func (r *LiveRepository) GetSessionByStreamKey(ctx context.Context, streamKey string) (*models.LiveSession, error) {
+	return r.findSession(ctx, bson.M{"stream_key": streamKey})
+}
+
+// GetActiveSession returns the user's session that has not ended yet
+func (r *LiveRepository) GetActiveSession(ctx context.Context, userID primitive.ObjectID) (*models.LiveSession, error) {
+	return r.findSession(ctx, bson.M{
+		"user_id": userID,
+		"status":  bson.M{"$in": []models.LiveStatus{models.LiveStatusIdle, models.LiveStatusLive}},
+	})
+}
+
+func (r *LiveRepository) findSession(ctx context.Context, filter bson.M) (*models.LiveSession, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var session models.LiveSession
+	if err := r.sessionsCollection.FindOne(ctx, filter).Decode(&session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListLiveSessions returns the sessions live now that the viewer may
+// watch, most watched first
+func (r *LiveRepository) ListLiveSessions(ctx context.Context, viewerID primitive.ObjectID, friendIDs []primitive.ObjectID, limit int64) ([]models.LiveSession, error) {
+	if friendIDs == nil {
+		friendIDs = []primitive.ObjectID{}
+	}
+	filter := bson.M{
+		"status": models.LiveStatusLive,
+		"$or": []bson.M{
+			{"privacy": models.PrivacySettingPublic},
+			{"privacy": models.PrivacySettingFriends, "user_id": bson.M{"$in": friendIDs}},
+			{"user_id": viewerID},
+		},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "viewer_count", Value: -1}, {Key: "started_at", Value: -1}}).
+		SetLimit(limit)
+	return r.findSessions(ctx, filter, opts)
+}
+
+// ListLive returns every session live now
+func (r *LiveRepository) ListLive(ctx context.Context) ([]models.LiveSession, error) {
+	return r.findSessions(ctx, bson.M{"status": models.LiveStatusLive})
+}
+
+func (r *LiveRepository) ListUserSessions(ctx context.Context, userID primitive.ObjectID) ([]models.LiveSession, error) {
+	return r.findSessions(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+}
+
+func (r *LiveRepository) findSessions(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]models.LiveSession, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := r.sessionsCollection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	sessions := []models.LiveSession{}
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// StartSession takes an idle session live, reporting whether it was idle
+func (r *LiveRepository) StartSession(ctx context.Context, id primitive.ObjectID, playbackURL string, at time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := r.sessionsCollection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": models.LiveStatusIdle},
+		bson.M{"$set": bson.M{
+			"status":       models.LiveStatusLive,
+			"playback_url": playbackURL,
+			"started_at":   at,
+			"updated_at":   at,
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// EndSession ends a session that has not ended yet, reporting whether this
+// call ended it
+func (r *LiveRepository) EndSession(ctx context.Context, id primitive.ObjectID, at time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := r.sessionsCollection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": bson.M{"$ne": models.LiveStatusEnded}},
+		bson.M{"$set": bson.M{
+			"status":       models.LiveStatusEnded,
+			"viewer_count": 0,
+			"ended_at":     at,
+			"updated_at":   at,
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// SetViewerCount records the current viewers, raising the peak if need be
+func (r *LiveRepository) SetViewerCount(ctx context.Context, id primitive.ObjectID, count int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := r.sessionsCollection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": models.LiveStatusLive},
+		bson.M{
+			"$set": bson.M{"viewer_count": count},
+			"$max": bson.M{"peak_viewers": count},
+		},
+	)
+	return err
+}
+
+// SetArchive links a session to the reel it was archived as
+func (r *LiveRepository) SetArchive(ctx context.Context, id, reelID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := r.sessionsCollection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"reel_id": reelID, "updated_at": time.Now()}},
+	)
+	return err
+}
+
+func (r *LiveRepository) AddChatMessage(ctx context.Context, message *models.LiveChatMessage) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	message.ID = primitive.NewObjectID()
+	_, err := r.chatCollection.InsertOne(ctx, message)
+	return err
+}
+
+// GetChatMessages returns a session's latest messages sent before the given
+// time, newest first
+func (r *LiveRepository) GetChatMessages(ctx context.Context, sessionID primitive.ObjectID, before time.Time, limit int64) ([]models.LiveChatMessage, error) {
+	filter := bson.M{"session_id": sessionID}
+	if !before.IsZero() {
+		filter["created_at"] = bson.M{"$lt": before}
+	}
+	return r.findChat(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(limit))
+}
+
+func (r *LiveRepository) GetUserChatMessages(ctx context.Context, userID primitive.ObjectID) ([]models.LiveChatMessage, error) {
+	return r.findChat(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+}
+
+func (r *LiveRepository) findChat(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.LiveChatMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := r.chatCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	messages := []models.LiveChatMessage{}
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// DeleteUserData removes the user's sessions with their chat, and the
+// messages they sent in others' sessions
+func (r *LiveRepository) DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	sessions, err := r.sessionsCollection.Distinct(ctx, "_id", bson.M{"user_id": userID})
+	if err != nil {
+		return 0, err
+	}
+
+	chat, err := r.chatCollection.DeleteMany(ctx, bson.M{"$or": []bson.M{
+		{"user_id": userID},
+		{"session_id": bson.M{"$in": sessions}},
+	}})
+	if err != nil {
+		return 0, err
+	}
+	res, err := r.sessionsCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return chat.DeletedCount, err
+	}
+	return chat.DeletedCount + res.DeletedCount, nil
+}
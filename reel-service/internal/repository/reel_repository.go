@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/recommend"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -14,9 +15,14 @@ import (
 type ReelRepository struct {
 	collection          *mongo.Collection
 	commentsCollection  *mongo.Collection
+	repliesCollection   *mongo.Collection
 	reactionsCollection *mongo.Collection
 }
 
+// notFlagged matches the moderation status of reels moderation has not held
+// or rejected
+var notFlagged = bson.M{"$nin": models.HiddenModerationVerdicts}
+
 func NewReelRepository(db *mongo.Database) *ReelRepository {
 	ctx := context.Background()
 
@@ -24,15 +30,24 @@ func NewReelRepository(db *mongo.Database) *ReelRepository {
 		{Keys: bson.D{{Key: "user_id", Value: 1}}},
 		{Keys: bson.D{{Key: "created_at", Value: -1}}},
 		{Keys: bson.D{{Key: "views", Value: -1}}},
+		{Keys: bson.D{{Key: "thumbnail_url", Value: 1}}},
+		{Keys: bson.D{{Key: "engagement_score", Value: -1}}},
+		{Keys: bson.D{{Key: "category", Value: 1}}},
+		{Keys: bson.D{{Key: "hashtags", Value: 1}}},
 	})
 
 	db.Collection("reel_comments").Indexes().CreateOne(ctx, mongo.IndexModel{
 		Keys: bson.D{{Key: "reel_id", Value: 1}},
 	})
 
+	db.Collection("reel_replies").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "comment_id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+
 	return &ReelRepository{
 		collection:          db.Collection("reels"),
 		commentsCollection:  db.Collection("reel_comments"),
+		repliesCollection:   db.Collection("reel_replies"),
 		reactionsCollection: db.Collection("reel_reactions"),
 	}
 }
@@ -69,7 +84,7 @@ func (r *ReelRepository) GetUserReels(ctx context.Context, userID primitive.Obje
 	defer cancel()
 
 	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
-	cur, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	cur, err := r.collection.Find(ctx, bson.M{"user_id": userID, "moderation_status": notFlagged}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +97,26 @@ func (r *ReelRepository) GetUserReels(ctx context.Context, userID primitive.Obje
 	return reels, nil
 }
 
+// ListUserReels returns all of the user's reels, including those moderation
+// hides from their profile, newest first
+func (r *ReelRepository) ListUserReels(ctx context.Context, userID primitive.ObjectID) ([]models.Reel, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cur, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	reels := []models.Reel{}
+	if err := cur.All(ctx, &reels); err != nil {
+		return nil, err
+	}
+	return reels, nil
+}
+
 func (r *ReelRepository) DeleteReel(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
@@ -103,6 +138,7 @@ func (r *ReelRepository) GetReelsFeed(ctx context.Context, userID primitive.Obje
 	defer cancel()
 
 	filter := bson.M{
+		"moderation_status": notFlagged,
 		"$or": []bson.M{
 			{"privacy": "PUBLIC"},
 			{"user_id": userID},
@@ -131,6 +167,88 @@ func (r *ReelRepository) GetReelsFeed(ctx context.Context, userID primitive.Obje
 	return reels, nil
 }
 
+// GetReelsByCreators returns the newest candidate reels by the creators
+func (r *ReelRepository) GetReelsByCreators(ctx context.Context, q recommend.CandidateQuery, creatorIDs []primitive.ObjectID) ([]models.Reel, error) {
+	filter := candidateFilter(q)
+	filter["user_id"] = bson.M{"$in": creatorIDs, "$ne": q.ViewerID}
+	return r.findCandidates(ctx, filter, bson.D{{Key: "created_at", Value: -1}}, q.Limit)
+}
+
+// GetTrendingReels returns the candidate reels drawing the most engagement
+func (r *ReelRepository) GetTrendingReels(ctx context.Context, q recommend.CandidateQuery) ([]models.Reel, error) {
+	return r.findCandidates(ctx, candidateFilter(q), bson.D{{Key: "engagement_score", Value: -1}, {Key: "views", Value: -1}}, q.Limit)
+}
+
+// GetReelsByTopics returns the most engaging candidate reels whose category
+// or hashtags are among the topics
+func (r *ReelRepository) GetReelsByTopics(ctx context.Context, q recommend.CandidateQuery, topics []string) ([]models.Reel, error) {
+	filter := candidateFilter(q)
+	filter["$and"] = []bson.M{{"$or": []bson.M{
+		{"category": bson.M{"$in": topics}},
+		{"hashtags": bson.M{"$in": topics}},
+	}}}
+	return r.findCandidates(ctx, filter, bson.D{{Key: "engagement_score", Value: -1}}, q.Limit)
+}
+
+// candidateFilter matches the reels others posted that the viewer may see,
+// leaving out the excluded ones
+func candidateFilter(q recommend.CandidateQuery) bson.M {
+	friendIDs := q.FriendIDs
+	if friendIDs == nil {
+		friendIDs = []primitive.ObjectID{}
+	}
+
+	filter := bson.M{
+		"moderation_status": notFlagged,
+		"user_id":           bson.M{"$ne": q.ViewerID},
+		"blocked_viewers":   bson.M{"$ne": q.ViewerID},
+		"$or": []bson.M{
+			{"privacy": "PUBLIC"},
+			{
+				"privacy": "FRIENDS",
+				"user_id": bson.M{"$in": friendIDs},
+			},
+		},
+	}
+	if !q.Since.IsZero() {
+		filter["created_at"] = bson.M{"$gte": q.Since}
+	}
+	if len(q.Exclude) > 0 {
+		filter["_id"] = bson.M{"$nin": q.Exclude}
+	}
+	return filter
+}
+
+func (r *ReelRepository) findCandidates(ctx context.Context, filter bson.M, sort bson.D, limit int64) ([]models.Reel, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cur, err := r.collection.Find(ctx, filter, options.Find().SetSort(sort).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var reels []models.Reel
+	if err := cur.All(ctx, &reels); err != nil {
+		return nil, err
+	}
+	return reels, nil
+}
+
+// AddEngagement credits a reel with a weighted engagement and counts shares
+func (r *ReelRepository) AddEngagement(ctx context.Context, id primitive.ObjectID, score float64, shares int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	inc := bson.M{"engagement_score": score}
+	if shares != 0 {
+		inc["shares"] = shares
+	}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": inc})
+	return err
+}
+
 func (r *ReelRepository) AddComment(ctx context.Context, reelID primitive.ObjectID, comment models.Comment) error {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
@@ -172,11 +290,160 @@ func (r *ReelRepository) GetComments(ctx context.Context, reelID primitive.Objec
 	return comments, nil
 }
 
+// GetUserComments returns the reel comments the user wrote or replied to,
+// newest first
+func (r *ReelRepository) GetUserComments(ctx context.Context, userID primitive.ObjectID) ([]models.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{"$or": []bson.M{
+		{"user_id": userID},
+		{"replies.user_id": userID},
+	}}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cur, err := r.commentsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	comments := []models.Comment{}
+	if err := cur.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (r *ReelRepository) GetCommentByID(ctx context.Context, commentID primitive.ObjectID) (*models.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var comment models.Comment
+	err := r.commentsCollection.FindOne(ctx, bson.M{"_id": commentID}).Decode(&comment)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// DeleteComment removes a comment from a reel along with its replies and
+// the reactions left on them
+func (r *ReelRepository) DeleteComment(ctx context.Context, reelID, commentID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := r.commentsCollection.DeleteOne(ctx, bson.M{"_id": commentID, "reel_id": reelID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	replyIDs, err := r.repliesCollection.Distinct(ctx, "_id", bson.M{"comment_id": commentID})
+	if err != nil {
+		return err
+	}
+	targets := append(replyIDs, commentID)
+	if _, err := r.reactionsCollection.DeleteMany(ctx, bson.M{"target_id": bson.M{"$in": targets}}); err != nil {
+		return err
+	}
+	if _, err := r.repliesCollection.DeleteMany(ctx, bson.M{"comment_id": commentID}); err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": reelID}, bson.M{"$inc": bson.M{"comments": -1}})
+	return err
+}
+
 func (r *ReelRepository) AddReply(ctx context.Context, reelID primitive.ObjectID, commentID primitive.ObjectID, reply models.Reply) error {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	_, err := r.commentsCollection.UpdateOne(ctx, bson.M{"_id": commentID}, bson.M{"$push": bson.M{"replies": reply}})
+	reply.CommentID = commentID
+
+	_, err := r.repliesCollection.InsertOne(ctx, reply)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.commentsCollection.UpdateOne(ctx, bson.M{"_id": commentID, "reel_id": reelID}, bson.M{"$inc": bson.M{"reply_count": 1}})
+	return err
+}
+
+func (r *ReelRepository) GetReplyByID(ctx context.Context, replyID primitive.ObjectID) (*models.Reply, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var reply models.Reply
+	err := r.repliesCollection.FindOne(ctx, bson.M{"_id": replyID}).Decode(&reply)
+	if err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+// GetReplies returns the replies in a comment's thread, oldest first
+func (r *ReelRepository) GetReplies(ctx context.Context, commentID primitive.ObjectID, limit, offset int64) ([]models.Reply, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetLimit(limit).
+		SetSkip(offset)
+
+	cur, err := r.repliesCollection.Find(ctx, bson.M{"comment_id": commentID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	replies := []models.Reply{}
+	if err := cur.All(ctx, &replies); err != nil {
+		return nil, err
+	}
+	return replies, nil
+}
+
+// GetUserReplies returns the replies the user wrote on reel comments,
+// newest first
+func (r *ReelRepository) GetUserReplies(ctx context.Context, userID primitive.ObjectID) ([]models.Reply, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cur, err := r.repliesCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	replies := []models.Reply{}
+	if err := cur.All(ctx, &replies); err != nil {
+		return nil, err
+	}
+	return replies, nil
+}
+
+// DeleteReply removes a reply and the reactions left on it
+func (r *ReelRepository) DeleteReply(ctx context.Context, commentID, replyID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := r.repliesCollection.DeleteOne(ctx, bson.M{"_id": replyID, "comment_id": commentID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	if _, err := r.reactionsCollection.DeleteMany(ctx, bson.M{"target_id": replyID}); err != nil {
+		return err
+	}
+
+	_, err = r.commentsCollection.UpdateOne(ctx, bson.M{"_id": commentID}, bson.M{"$inc": bson.M{"reply_count": -1}})
 	return err
 }
 
@@ -195,24 +462,35 @@ func (r *ReelRepository) GetReaction(ctx context.Context, targetID primitive.Obj
 	return &reaction, nil
 }
 
-func (r *ReelRepository) AddReaction(ctx context.Context, reaction *models.Reaction) error {
+// GetUserReactions returns every reaction the user left on reels and their
+// comments, newest first
+func (r *ReelRepository) GetUserReactions(ctx context.Context, userID primitive.ObjectID) ([]models.Reaction, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	_, err := r.reactionsCollection.InsertOne(ctx, reaction)
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cur, err := r.reactionsCollection.Find(ctx, bson.M{"user_id": userID}, opts)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer cur.Close(ctx)
 
-	incMap := bson.M{"reaction_counts." + string(reaction.Type): 1}
-	if reaction.Type == "LIKE" {
-		incMap["likes"] = 1
+	reactions := []models.Reaction{}
+	if err := cur.All(ctx, &reactions); err != nil {
+		return nil, err
 	}
+	return reactions, nil
+}
+
+func (r *ReelRepository) AddReaction(ctx context.Context, reaction *models.Reaction) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
 
-	if reaction.TargetType == "reel" {
-		_, err = r.collection.UpdateOne(ctx, bson.M{"_id": reaction.TargetID}, bson.M{"$inc": incMap})
+	_, err := r.reactionsCollection.InsertOne(ctx, reaction)
+	if err != nil {
+		return err
 	}
-	return err
+	return r.adjustReactionCounts(ctx, reaction, 1)
 }
 
 func (r *ReelRepository) RemoveReaction(ctx context.Context, reaction *models.Reaction) error {
@@ -223,34 +501,30 @@ func (r *ReelRepository) RemoveReaction(ctx context.Context, reaction *models.Re
 	if err != nil {
 		return err
 	}
-
-	decMap := bson.M{"reaction_counts." + string(reaction.Type): -1}
-	if reaction.Type == "LIKE" {
-		decMap["likes"] = -1
-	}
-
-	if reaction.TargetType == "reel" {
-		_, err = r.collection.UpdateOne(ctx, bson.M{"_id": reaction.TargetID}, bson.M{"$inc": decMap})
-	}
-	return err
+	return r.adjustReactionCounts(ctx, reaction, -1)
 }
 
-func (r *ReelRepository) ReactToComment(ctx context.Context, reelID primitive.ObjectID, commentID primitive.ObjectID, userID primitive.ObjectID, reactionType models.ReactionType) error {
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
-
-	r.commentsCollection.UpdateOne(ctx, bson.M{"_id": commentID}, bson.M{"$pull": bson.M{"reactions": bson.M{"user_id": userID}}})
+// adjustReactionCounts keeps the reaction counters on the reel, comment or
+// reply a reaction was left on in step with it
+func (r *ReelRepository) adjustReactionCounts(ctx context.Context, reaction *models.Reaction, delta int64) error {
+	incMap := bson.M{"reaction_counts." + string(reaction.Type): delta}
 
-	reaction := models.Reaction{
-		ID:         primitive.NewObjectID(),
-		UserID:     userID,
-		TargetID:   commentID,
-		TargetType: "comment",
-		Type:       reactionType,
-		CreatedAt:  time.Now(),
+	var target *mongo.Collection
+	switch reaction.TargetType {
+	case "reel":
+		if reaction.Type == models.ReactionLike {
+			incMap["likes"] = delta
+		}
+		target = r.collection
+	case "comment":
+		target = r.commentsCollection
+	case "reply":
+		target = r.repliesCollection
+	default:
+		return nil
 	}
 
-	_, err := r.commentsCollection.UpdateOne(ctx, bson.M{"_id": commentID}, bson.M{"$push": bson.M{"reactions": reaction}})
+	_, err := target.UpdateOne(ctx, bson.M{"_id": reaction.TargetID}, bson.M{"$inc": incMap})
 	return err
 }
 
@@ -261,7 +535,7 @@ func (r *ReelRepository) ListReels(ctx context.Context, limit int64, offset int6
 
 	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(limit).SetSkip(offset)
 
-	cur, err := r.collection.Find(ctx, bson.M{}, opts)
+	cur, err := r.collection.Find(ctx, bson.M{"moderation_status": notFlagged}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -291,3 +565,206 @@ func (r *ReelRepository) UpdateAuthorInfo(ctx context.Context, userID primitive.
 	)
 	return err
 }
+
+// SetModerationStatus flags the reels whose thumbnail is mediaURL. A
+// rejection is final, so it is never downgraded to a hold. It returns how
+// many changed.
+func (r *ReelRepository) SetModerationStatus(ctx context.Context, mediaURL string, verdict models.ModerationVerdict) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := r.collection.UpdateMany(ctx,
+		bson.M{"thumbnail_url": mediaURL, "moderation_status": bson.M{"$ne": models.ModerationRejected}},
+		bson.M{"$set": bson.M{"moderation_status": verdict, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// MarkProcessing claims a pending or failed reel for transcoding. It reports
+// false when the reel is gone or another worker already claimed it, which
+// makes redelivered upload events harmless.
+func (r *ReelRepository) MarkProcessing(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{
+			"_id":               id,
+			"processing_status": bson.M{"$in": bson.A{models.ReelProcessingPending, models.ReelProcessingFailed}},
+		},
+		bson.M{
+			"$set":   bson.M{"processing_status": models.ReelProcessingProcessing, "updated_at": time.Now()},
+			"$unset": bson.M{"processing_error": ""},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// CompleteProcessing records a reel's HLS output and marks it ready
+func (r *ReelRepository) CompleteProcessing(ctx context.Context, id primitive.ObjectID, playbackURL string, renditions []models.ReelRendition) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"processing_status": models.ReelProcessingReady,
+			"playback_url":      playbackURL,
+			"renditions":        renditions,
+			"updated_at":        time.Now(),
+		}},
+	)
+	return err
+}
+
+// FailProcessing marks a reel as failed; it keeps playing from its upload
+func (r *ReelRepository) FailProcessing(ctx context.Context, id primitive.ObjectID, reason string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"processing_status": models.ReelProcessingFailed,
+			"processing_error":  reason,
+			"updated_at":        time.Now(),
+		}},
+	)
+	return err
+}
+
+// DeleteUserData removes the user's reels with everything left on them, and
+// the comments, replies and reactions they left on other reels, keeping the
+// counters of what stays in step. It returns how many records were removed.
+func (r *ReelRepository) DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	defer cancel()
+
+	reelIDs, err := r.collection.Distinct(ctx, "_id", bson.M{"user_id": userID})
+	if err != nil {
+		return 0, err
+	}
+	commentIDs, err := r.commentsCollection.Distinct(ctx, "_id", bson.M{"$or": []bson.M{
+		{"user_id": userID},
+		{"reel_id": bson.M{"$in": reelIDs}},
+	}})
+	if err != nil {
+		return 0, err
+	}
+	replyIDs, err := r.repliesCollection.Distinct(ctx, "_id", bson.M{"$or": []bson.M{
+		{"user_id": userID},
+		{"comment_id": bson.M{"$in": commentIDs}},
+	}})
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+
+	// Undo the user's reactions on reels, comments and replies that stay
+	removed := append(append(append([]interface{}{}, reelIDs...), commentIDs...), replyIDs...)
+	cur, err := r.reactionsCollection.Find(ctx, bson.M{
+		"user_id":   userID,
+		"target_id": bson.M{"$nin": removed},
+	})
+	if err != nil {
+		return total, err
+	}
+	var reactions []models.Reaction
+	if err := cur.All(ctx, &reactions); err != nil {
+		return total, err
+	}
+	for i := range reactions {
+		if err := r.adjustReactionCounts(ctx, &reactions[i], -1); err != nil {
+			return total, err
+		}
+	}
+
+	// Undo the user's comments on reels that stay
+	counts, err := r.commentsCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userID, "reel_id": bson.M{"$nin": reelIDs}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$reel_id", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return total, err
+	}
+	var perReel []struct {
+		ReelID primitive.ObjectID `bson:"_id"`
+		Count  int64              `bson:"count"`
+	}
+	if err := counts.All(ctx, &perReel); err != nil {
+		return total, err
+	}
+	for _, c := range perReel {
+		if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": c.ReelID}, bson.M{"$inc": bson.M{"comments": -c.Count}}); err != nil {
+			return total, err
+		}
+	}
+
+	// Undo the user's replies on comments that stay
+	counts, err = r.repliesCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userID, "comment_id": bson.M{"$nin": commentIDs}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$comment_id", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return total, err
+	}
+	var perComment []struct {
+		CommentID primitive.ObjectID `bson:"_id"`
+		Count     int64              `bson:"count"`
+	}
+	if err := counts.All(ctx, &perComment); err != nil {
+		return total, err
+	}
+	for _, c := range perComment {
+		if _, err := r.commentsCollection.UpdateOne(ctx, bson.M{"_id": c.CommentID}, bson.M{"$inc": bson.M{"reply_count": -c.Count}}); err != nil {
+			return total, err
+		}
+	}
+
+	res, err := r.reactionsCollection.DeleteMany(ctx, bson.M{"$or": []bson.M{
+		{"user_id": userID},
+		{"target_id": bson.M{"$in": removed}},
+	}})
+	if err != nil {
+		return total, err
+	}
+	total += res.DeletedCount
+
+	res, err = r.repliesCollection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": replyIDs}})
+	if err != nil {
+		return total, err
+	}
+	total += res.DeletedCount
+
+	res, err = r.commentsCollection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": commentIDs}})
+	if err != nil {
+		return total, err
+	}
+	total += res.DeletedCount
+
+	// Older comments embed their replies and reactions
+	updated, err := r.commentsCollection.UpdateMany(ctx,
+		bson.M{"$or": []bson.M{{"replies.user_id": userID}, {"reactions.user_id": userID}}},
+		bson.M{"$pull": bson.M{
+			"replies":   bson.M{"user_id": userID},
+			"reactions": bson.M{"user_id": userID},
+		}},
+	)
+	if err != nil {
+		return total, err
+	}
+	total += updated.ModifiedCount
+
+	res, err = r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return total, err
+	}
+	total += res.DeletedCount
+	return total, nil
+}
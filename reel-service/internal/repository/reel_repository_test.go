@@ -5,11 +5,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/recommend"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 )
 
@@ -297,6 +299,7 @@ func TestAddReply(t *testing.T) {
 	mt.Run("success", func(mt *mtest.T) {
 		repo := &ReelRepository{
 			commentsCollection: mt.Coll,
+			repliesCollection:  mt.Coll,
 		}
 
 		reelID := primitive.NewObjectID()
@@ -309,6 +312,9 @@ func TestAddReply(t *testing.T) {
 			CreatedAt: time.Now(),
 		}
 
+		// Insert reply
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		// Update comment reply count
 		mt.AddMockResponses(bson.D{
 			{Key: "ok", Value: 1},
 			{Key: "nModified", Value: 1},
@@ -320,6 +326,117 @@ func TestAddReply(t *testing.T) {
 	})
 }
 
+func TestGetReplies(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &ReelRepository{
+			repliesCollection: mt.Coll,
+		}
+
+		commentID := primitive.NewObjectID()
+
+		first := mtest.CreateCursorResponse(1, "db.reel_replies", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "comment_id", Value: commentID},
+			{Key: "content", Value: "Test reply"},
+		})
+		killCursors := mtest.CreateCursorResponse(0, "db.reel_replies", mtest.NextBatch)
+
+		mt.AddMockResponses(first, killCursors)
+
+		replies, err := repo.GetReplies(context.Background(), commentID, 20, 0)
+
+		require.NoError(t, err)
+		require.Len(t, replies, 1)
+		assert.Equal(t, commentID, replies[0].CommentID)
+	})
+}
+
+func TestDeleteComment(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &ReelRepository{
+			collection:          mt.Coll,
+			commentsCollection:  mt.Coll,
+			repliesCollection:   mt.Coll,
+			reactionsCollection: mt.Coll,
+		}
+
+		deleted := bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "acknowledged", Value: true},
+			{Key: "n", Value: 1},
+		}
+
+		// Delete comment
+		mt.AddMockResponses(deleted)
+		// Find its replies
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "values", Value: bson.A{primitive.NewObjectID()}},
+		})
+		// Delete reactions, then replies
+		mt.AddMockResponses(deleted, deleted)
+		// Update reel comment count
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "nModified", Value: 1},
+		})
+
+		err := repo.DeleteComment(context.Background(), primitive.NewObjectID(), primitive.NewObjectID())
+
+		assert.NoError(t, err)
+	})
+
+	mt.Run("not found", func(mt *mtest.T) {
+		repo := &ReelRepository{
+			commentsCollection: mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "acknowledged", Value: true},
+			{Key: "n", Value: 0},
+		})
+
+		err := repo.DeleteComment(context.Background(), primitive.NewObjectID(), primitive.NewObjectID())
+
+		assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+	})
+}
+
+func TestDeleteReply(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &ReelRepository{
+			commentsCollection:  mt.Coll,
+			repliesCollection:   mt.Coll,
+			reactionsCollection: mt.Coll,
+		}
+
+		deleted := bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "acknowledged", Value: true},
+			{Key: "n", Value: 1},
+		}
+
+		// Delete reply, then its reactions
+		mt.AddMockResponses(deleted, deleted)
+		// Update comment reply count
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "nModified", Value: 1},
+		})
+
+		err := repo.DeleteReply(context.Background(), primitive.NewObjectID(), primitive.NewObjectID())
+
+		assert.NoError(t, err)
+	})
+}
+
 func TestGetReaction(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
@@ -428,28 +545,32 @@ func TestRemoveReaction(t *testing.T) {
 	})
 }
 
-func TestReactToComment(t *testing.T) {
+func TestAddReaction_Comment(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
 	mt.Run("success", func(mt *mtest.T) {
 		repo := &ReelRepository{
-			commentsCollection: mt.Coll,
+			commentsCollection:  mt.Coll,
+			reactionsCollection: mt.Coll,
 		}
 
-		reelID := primitive.NewObjectID()
-		commentID := primitive.NewObjectID()
-		userID := primitive.NewObjectID()
+		reaction := &models.Reaction{
+			ID:         primitive.NewObjectID(),
+			UserID:     primitive.NewObjectID(),
+			TargetID:   primitive.NewObjectID(),
+			TargetType: "comment",
+			Type:       models.ReactionLike,
+			CreatedAt:  time.Now(),
+		}
 
-		mt.AddMockResponses(bson.D{
-			{Key: "ok", Value: 1},
-			{Key: "nModified", Value: 0},
-		})
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		// Update comment counts
 		mt.AddMockResponses(bson.D{
 			{Key: "ok", Value: 1},
 			{Key: "nModified", Value: 1},
 		})
 
-		err := repo.ReactToComment(context.Background(), reelID, commentID, userID, models.ReactionLike)
+		err := repo.AddReaction(context.Background(), reaction)
 
 		assert.NoError(t, err)
 	})
@@ -506,3 +627,22 @@ func TestUpdateAuthorInfo(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestCandidateFilter(t *testing.T) {
+	viewerID := primitive.NewObjectID()
+	seen := []primitive.ObjectID{primitive.NewObjectID()}
+	since := time.Now().Add(-time.Hour)
+
+	filter := candidateFilter(recommend.CandidateQuery{ViewerID: viewerID, Exclude: seen, Since: since})
+
+	assert.Equal(t, bson.M{"$ne": viewerID}, filter["user_id"])
+	assert.Equal(t, bson.M{"$ne": viewerID}, filter["blocked_viewers"])
+	assert.Equal(t, bson.M{"$nin": seen}, filter["_id"])
+	assert.Equal(t, bson.M{"$gte": since}, filter["created_at"])
+	friendsOnly := filter["$or"].([]bson.M)[1]
+	assert.Equal(t, bson.M{"$in": []primitive.ObjectID{}}, friendsOnly["user_id"], "no friends must still be an array")
+
+	filter = candidateFilter(recommend.CandidateQuery{ViewerID: viewerID})
+	assert.NotContains(t, filter, "_id")
+	assert.NotContains(t, filter, "created_at")
+}
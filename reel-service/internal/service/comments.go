@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/producer"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrReelNotFound     = errors.New("reel not found")
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrReplyNotFound    = errors.New("reply not found")
+	ErrNotCommentAuthor = errors.New("only the author or the reel's owner can delete this")
+)
+
+// NotificationPublisher hands notifications to the notification pipeline
+type NotificationPublisher interface {
+	PublishNotification(ctx context.Context, notification *models.Notification) error
+}
+
+// RealtimePublisher sends live updates to the listed users through the
+// messaging Hub
+type RealtimePublisher interface {
+	PublishReelEvent(ctx context.Context, eventType string, event producer.ReelThreadEvent, recipients []string) error
+	PublishLiveEvent(ctx context.Context, eventType string, event producer.LiveEvent, recipients []string) error
+}
+
+// SetNotificationPublisher enables notifications for users mentioned in
+// reel comments and replies
+func (s *ReelService) SetNotificationPublisher(notifications NotificationPublisher) {
+	s.notifications = notifications
+}
+
+// SetRealtimePublisher enables live updates to reel comment threads
+func (s *ReelService) SetRealtimePublisher(realtime RealtimePublisher) {
+	s.realtime = realtime
+}
+
+// GetReplies returns a comment's replies, oldest first
+func (s *ReelService) GetReplies(ctx context.Context, reelID, commentID primitive.ObjectID, limit, offset int64) ([]models.Reply, error) {
+	if _, err := s.findComment(ctx, reelID, commentID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.reelRepo.GetReplies(ctx, commentID, limit, offset)
+}
+
+// ReactToReply toggles a reaction on a reply
+func (s *ReelService) ReactToReply(ctx context.Context, reelID, commentID, replyID, userID primitive.ObjectID, reactionType models.ReactionType) error {
+	reel, err := s.findReel(ctx, reelID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.findComment(ctx, reelID, commentID); err != nil {
+		return err
+	}
+	reply, err := s.findReply(ctx, commentID, replyID)
+	if err != nil {
+		return err
+	}
+
+	reaction, added, err := s.toggleReaction(ctx, replyID, "reply", userID, reactionType)
+	if err != nil {
+		return err
+	}
+
+	s.publishReactionEvent(ctx, producer.ReelThreadEvent{
+		ReelID:    reelID.Hex(),
+		CommentID: commentID.Hex(),
+		ReplyID:   replyID.Hex(),
+		Reaction:  reaction,
+	}, added, reel.UserID, reply.UserID, userID)
+	return nil
+}
+
+// DeleteComment removes a comment and its replies. Comments can be deleted
+// by their author or the reel's owner.
+func (s *ReelService) DeleteComment(ctx context.Context, reelID, commentID, userID primitive.ObjectID) error {
+	reel, err := s.findReel(ctx, reelID)
+	if err != nil {
+		return err
+	}
+	comment, err := s.findComment(ctx, reelID, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != userID && reel.UserID != userID {
+		return ErrNotCommentAuthor
+	}
+
+	if err := s.reelRepo.DeleteComment(ctx, reelID, commentID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrCommentNotFound
+		}
+		return err
+	}
+
+	s.publishThreadEvent(ctx, producer.EventReelCommentDeleted, producer.ReelThreadEvent{
+		ReelID:    reelID.Hex(),
+		CommentID: commentID.Hex(),
+	}, reel.UserID, comment.UserID, userID)
+	return nil
+}
+
+// DeleteReply removes a reply. Replies can be deleted by their author or
+// the reel's owner.
+func (s *ReelService) DeleteReply(ctx context.Context, reelID, commentID, replyID, userID primitive.ObjectID) error {
+	reel, err := s.findReel(ctx, reelID)
+	if err != nil {
+		return err
+	}
+	comment, err := s.findComment(ctx, reelID, commentID)
+	if err != nil {
+		return err
+	}
+	reply, err := s.findReply(ctx, commentID, replyID)
+	if err != nil {
+		return err
+	}
+	if reply.UserID != userID && reel.UserID != userID {
+		return ErrNotCommentAuthor
+	}
+
+	if err := s.reelRepo.DeleteReply(ctx, commentID, replyID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrReplyNotFound
+		}
+		return err
+	}
+
+	s.publishThreadEvent(ctx, producer.EventReelReplyDeleted, producer.ReelThreadEvent{
+		ReelID:    reelID.Hex(),
+		CommentID: commentID.Hex(),
+		ReplyID:   replyID.Hex(),
+	}, reel.UserID, comment.UserID, reply.UserID, userID)
+	return nil
+}
+
+func (s *ReelService) findReel(ctx context.Context, reelID primitive.ObjectID) (*models.Reel, error) {
+	reel, err := s.reelRepo.GetReelByID(ctx, reelID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrReelNotFound
+	}
+	return reel, err
+}
+
+// findComment returns a comment if it belongs to the reel
+func (s *ReelService) findComment(ctx context.Context, reelID, commentID primitive.ObjectID) (*models.Comment, error) {
+	comment, err := s.reelRepo.GetCommentByID(ctx, commentID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if comment.ReelID == nil || *comment.ReelID != reelID {
+		return nil, ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// findReply returns a reply if it belongs to the comment
+func (s *ReelService) findReply(ctx context.Context, commentID, replyID primitive.ObjectID) (*models.Reply, error) {
+	reply, err := s.reelRepo.GetReplyByID(ctx, replyID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrReplyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if reply.CommentID != commentID {
+		return nil, ErrReplyNotFound
+	}
+	return reply, nil
+}
+
+// notifyMentions tells the users mentioned in a comment or reply. Mentions
+// only notify, so failures just log.
+func (s *ReelService) notifyMentions(ctx context.Context, reel *models.Reel, commentID primitive.ObjectID, author models.PostAuthor, mentions []primitive.ObjectID) {
+	if s.notifications == nil {
+		return
+	}
+
+	content := "You were mentioned in a comment on a reel."
+	if author.Username != "" {
+		content = fmt.Sprintf("%s mentioned you in a comment.", author.Username)
+	}
+	senderID, _ := primitive.ObjectIDFromHex(author.ID)
+
+	for _, userID := range mentions {
+		if userID == senderID {
+			continue
+		}
+
+		now := time.Now()
+		err := s.notifications.PublishNotification(ctx, &models.Notification{
+			ID:          primitive.NewObjectID(),
+			RecipientID: userID,
+			SenderID:    senderID,
+			Type:        models.NotificationTypeMention,
+			TargetID:    reel.ID,
+			TargetType:  "reel",
+			Content:     content,
+			Data: map[string]interface{}{
+				"comment_id":    commentID.Hex(),
+				"thumbnail_url": reel.ThumbnailURL,
+			},
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			s.logger.Warn("Failed to notify reel comment mention", "reel_id", reel.ID.Hex(), "user_id", userID.Hex(), "error", err)
+		}
+	}
+}
+
+func (s *ReelService) publishReactionEvent(ctx context.Context, event producer.ReelThreadEvent, added bool, recipients ...primitive.ObjectID) {
+	eventType := producer.EventReelReactionCreated
+	if !added {
+		eventType = producer.EventReelReactionDeleted
+	}
+	s.publishThreadEvent(ctx, eventType, event, recipients...)
+}
+
+// publishThreadEvent sends a live thread update to each of the recipients
+// once. Updates are best effort, so failures just log.
+func (s *ReelService) publishThreadEvent(ctx context.Context, eventType string, event producer.ReelThreadEvent, recipients ...primitive.ObjectID) {
+	if s.realtime == nil {
+		return
+	}
+
+	seen := make(map[primitive.ObjectID]bool)
+	var userIDs []string
+	for _, id := range recipients {
+		if id.IsZero() || seen[id] {
+			continue
+		}
+		seen[id] = true
+		userIDs = append(userIDs, id.Hex())
+	}
+
+	if err := s.realtime.PublishReelEvent(ctx, eventType, event, userIDs); err != nil {
+		s.logger.Warn("Failed to publish reel thread event", "type", eventType, "reel_id", event.ReelID, "error", err)
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/producer"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeleteUserData erases a deleted account's reels, the comments and
+// reactions it left on others' reels, its For You interests and its live
+// sessions and chat
+func (s *ReelService) DeleteUserData(ctx context.Context, userID string) (int64, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, errors.New("invalid user ID")
+	}
+
+	reels, err := s.reelRepo.ListUserReels(ctx, uID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load reels: %w", err)
+	}
+	deleted, err := s.reelRepo.DeleteUserData(ctx, uID)
+	if err != nil {
+		return deleted, fmt.Errorf("failed to delete reels: %w", err)
+	}
+	if s.interests != nil {
+		erased, err := s.interests.DeleteUserData(ctx, uID)
+		deleted += erased
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete reel interests: %w", err)
+		}
+	}
+	if s.live != nil {
+		erased, err := s.live.Store.DeleteUserData(ctx, uID)
+		deleted += erased
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete live sessions: %w", err)
+		}
+	}
+
+	if s.broadcaster != nil {
+		for _, reel := range reels {
+			s.broadcaster.PublishReelDeleted(ctx, producer.ReelDeletedEvent{
+				ReelID: reel.ID.Hex(),
+				UserID: userID,
+			})
+		}
+	}
+	s.logger.Info("Reel data deleted", "user_id", userID, "items", deleted)
+	return deleted, nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/recommend"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	exportpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/export/v1"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ExportUserData collects the user's reels, reel comments and replies,
+// reactions, For You interests and live sessions with their chat for their
+// data export
+func (s *ReelService) ExportUserData(ctx context.Context, userID string) ([]*exportpb.ExportSection, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	reels, err := s.reelRepo.ListUserReels(ctx, uID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export reels: %w", err)
+	}
+	threads, err := s.reelRepo.GetUserComments(ctx, uID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export reel comments: %w", err)
+	}
+	ownReplies, err := s.reelRepo.GetUserReplies(ctx, uID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export reel replies: %w", err)
+	}
+	reactions, err := s.reelRepo.GetUserReactions(ctx, uID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export reel reactions: %w", err)
+	}
+
+	interests := []recommend.Interests{}
+	if s.interests != nil {
+		vector, err := s.interests.GetInterests(ctx, uID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export reel interests: %w", err)
+		}
+		if vector != nil {
+			interests = append(interests, *vector)
+		}
+	}
+
+	liveSessions := []models.LiveSession{}
+	liveChat := []models.LiveChatMessage{}
+	if s.live != nil {
+		liveSessions, err = s.live.Store.ListUserSessions(ctx, uID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export live sessions: %w", err)
+		}
+		liveChat, err = s.live.Store.GetUserChatMessages(ctx, uID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export live chat: %w", err)
+		}
+		for i := range liveSessions {
+			liveSessions[i].StreamKey = ""
+		}
+	}
+
+	var reelMedia []string
+	for _, r := range reels {
+		for _, url := range []string{r.VideoURL, r.ThumbnailURL} {
+			if url != "" {
+				reelMedia = append(reelMedia, url)
+			}
+		}
+	}
+
+	// Older threads embed everyone's replies; keep only what the user wrote
+	comments := []models.Comment{}
+	replies := []models.Reply{}
+	var commentMedia, replyMedia []string
+	for _, r := range ownReplies {
+		replies = append(replies, r)
+		if r.MediaURL != "" {
+			replyMedia = append(replyMedia, r.MediaURL)
+		}
+	}
+	for _, c := range threads {
+		for _, r := range c.Replies {
+			if r.UserID != uID {
+				continue
+			}
+			replies = append(replies, r)
+			if r.MediaURL != "" {
+				replyMedia = append(replyMedia, r.MediaURL)
+			}
+		}
+		if c.UserID != uID {
+			continue
+		}
+		c.Replies = nil
+		c.Reactions = nil
+		comments = append(comments, c)
+		if c.MediaURL != "" {
+			commentMedia = append(commentMedia, c.MediaURL)
+		}
+	}
+
+	var b dataexport.Builder
+	b.Add("reels", reels, reelMedia...)
+	b.Add("reel_comments", comments, commentMedia...)
+	b.Add("reel_replies", replies, replyMedia...)
+	b.Add("reel_reactions", reactions)
+	b.Add("reel_interests", interests)
+	b.Add("live_sessions", liveSessions)
+	b.Add("live_chat", liveChat)
+	return b.Sections()
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/recommend"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrInvalidSignal = errors.New("signal must be watch, complete or share")
+
+const (
+	// How far back each candidate generator looks
+	followingWindow = 14 * 24 * time.Hour
+	trendingWindow  = 72 * time.Hour
+	similarWindow   = 30 * 24 * time.Hour
+
+	seenWindow     = 500 // Most recently served reels left out of the feed
+	topicsSearched = 5
+	maxForYouLimit = 50
+)
+
+// InterestStore keeps the viewers' interest vectors and the reels the For
+// You feed has served them
+type InterestStore interface {
+	GetInterests(ctx context.Context, userID primitive.ObjectID) (*recommend.Interests, error)
+	SaveInterests(ctx context.Context, interests *recommend.Interests) error
+	MarkSeen(ctx context.Context, userID primitive.ObjectID, reelIDs []primitive.ObjectID) error
+	GetSeenReelIDs(ctx context.Context, userID primitive.ObjectID, limit int64) ([]primitive.ObjectID, error)
+	DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error)
+}
+
+// EngagementPublisher hands engagement signals to the recommendation
+// pipeline
+type EngagementPublisher interface {
+	PublishEngagement(ctx context.Context, event recommend.Event) error
+}
+
+// SetInterestStore enables personalised ranking and dedupe in the For You
+// feed
+func (s *ReelService) SetInterestStore(interests InterestStore) {
+	s.interests = interests
+}
+
+// SetEngagementPublisher enables the engagement signals the For You feed
+// learns from
+func (s *ReelService) SetEngagementPublisher(engagement EngagementPublisher) {
+	s.engagement = engagement
+}
+
+// RecordEngagement publishes a viewer's watch, complete or share of a reel.
+// Likes are recorded when the viewer reacts.
+func (s *ReelService) RecordEngagement(ctx context.Context, reelID, userID primitive.ObjectID, signal recommend.Signal, watchTimeMs int64) error {
+	if signal != recommend.SignalWatch && signal != recommend.SignalComplete && signal != recommend.SignalShare {
+		return ErrInvalidSignal
+	}
+	reel, err := s.findReel(ctx, reelID)
+	if err != nil {
+		return err
+	}
+	if reel.UserID == userID {
+		return nil
+	}
+	if s.engagement == nil {
+		return nil
+	}
+
+	return s.engagement.PublishEngagement(ctx, recommend.Event{
+		ReelID:      reelID.Hex(),
+		UserID:      userID.Hex(),
+		Signal:      signal,
+		WatchTimeMs: watchTimeMs,
+		OccurredAt:  time.Now(),
+	})
+}
+
+// publishLike records a reaction to a reel as a like signal. Signals are
+// best effort, so failures just log.
+func (s *ReelService) publishLike(ctx context.Context, reelID, userID primitive.ObjectID) {
+	if s.engagement == nil {
+		return
+	}
+	err := s.engagement.PublishEngagement(ctx, recommend.Event{
+		ReelID:     reelID.Hex(),
+		UserID:     userID.Hex(),
+		Signal:     recommend.SignalLike,
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		s.logger.Warn("Failed to publish reel like signal", "reel_id", reelID.Hex(), "error", err)
+	}
+}
+
+// ApplyEngagement folds an engagement signal into the reel's engagement
+// score and the user's interests. Watched reels are also marked seen, so
+// the For You feed doesn't serve them again.
+func (s *ReelService) ApplyEngagement(ctx context.Context, event recommend.Event) error {
+	reelID, err := primitive.ObjectIDFromHex(event.ReelID)
+	if err != nil {
+		return fmt.Errorf("invalid reel ID: %w", err)
+	}
+	userID, err := primitive.ObjectIDFromHex(event.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	reel, err := s.reelRepo.GetReelByID(ctx, reelID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil // Deleted since
+	}
+	if err != nil {
+		return err
+	}
+	if reel.UserID == userID {
+		return nil
+	}
+
+	weight := recommend.Weight(event, reel.Duration)
+	if weight <= 0 {
+		return nil
+	}
+	var shares int64
+	if event.Signal == recommend.SignalShare {
+		shares = 1
+	}
+	if err := s.reelRepo.AddEngagement(ctx, reelID, weight, shares); err != nil {
+		return err
+	}
+
+	if s.interests == nil {
+		return nil
+	}
+	interests, err := s.interests.GetInterests(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if interests == nil {
+		interests = &recommend.Interests{UserID: userID}
+	}
+	at := event.OccurredAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	interests.Add(reel, weight, at)
+	if err := s.interests.SaveInterests(ctx, interests); err != nil {
+		return err
+	}
+
+	if event.Signal == recommend.SignalWatch || event.Signal == recommend.SignalComplete {
+		return s.interests.MarkSeen(ctx, userID, []primitive.ObjectID{reelID})
+	}
+	return nil
+}
+
+// GetForYouFeed recommends reels to the viewer. Candidates are new reels
+// from the creators they follow, reels trending lately and reels on the
+// topics they engage with most. They are ranked against the viewer's
+// interests, and reels already served are left out; the reels returned are
+// marked served, so the next call brings new ones.
+func (s *ReelService) GetForYouFeed(ctx context.Context, viewerID primitive.ObjectID, limit int64) ([]models.Reel, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > maxForYouLimit {
+		limit = maxForYouLimit
+	}
+
+	friendIDs, err := s.getFriendIDs(ctx, viewerID)
+	if err != nil {
+		s.logger.Warn("Failed to get friend IDs, recommending public reels only", "error", err, "user_id", viewerID.Hex())
+		friendIDs = []primitive.ObjectID{}
+	}
+
+	var interests *recommend.Interests
+	var seen []primitive.ObjectID
+	if s.interests != nil {
+		if interests, err = s.interests.GetInterests(ctx, viewerID); err != nil {
+			s.logger.Warn("Failed to get interests, ranking without them", "error", err, "user_id", viewerID.Hex())
+		}
+		if seen, err = s.interests.GetSeenReelIDs(ctx, viewerID, seenWindow); err != nil {
+			s.logger.Warn("Failed to get seen reels", "error", err, "user_id", viewerID.Hex())
+		}
+	}
+
+	now := time.Now()
+	query := recommend.CandidateQuery{
+		ViewerID:  viewerID,
+		FriendIDs: friendIDs,
+		Exclude:   seen,
+		Limit:     limit * 2,
+	}
+	var candidates []recommend.Candidate
+	var candidateErr error
+	collect := func(source recommend.Source, reels []models.Reel, err error) {
+		if err != nil {
+			s.logger.Warn("Failed to generate For You candidates", "source", source, "error", err, "user_id", viewerID.Hex())
+			candidateErr = err
+			return
+		}
+		for _, reel := range reels {
+			candidates = append(candidates, recommend.Candidate{Reel: reel, Source: source})
+		}
+	}
+
+	following, err := s.getFollowingIDs(ctx, viewerID)
+	if err != nil {
+		s.logger.Warn("Failed to get followed creators", "error", err, "user_id", viewerID.Hex())
+	}
+	if len(following) > 0 {
+		q := query
+		q.Since = now.Add(-followingWindow)
+		reels, err := s.reelRepo.GetReelsByCreators(ctx, q, following)
+		collect(recommend.SourceFollowing, reels, err)
+	}
+
+	q := query
+	q.Since = now.Add(-trendingWindow)
+	reels, err := s.reelRepo.GetTrendingReels(ctx, q)
+	collect(recommend.SourceTrending, reels, err)
+
+	if interests != nil {
+		if topics := interests.TopTopics(topicsSearched); len(topics) > 0 {
+			q := query
+			q.Since = now.Add(-similarWindow)
+			reels, err := s.reelRepo.GetReelsByTopics(ctx, q, topics)
+			collect(recommend.SourceSimilar, reels, err)
+		}
+	}
+
+	// Quiet windows, or a viewer who has seen them out, fall back to the
+	// most engaging reels of any age
+	if int64(len(candidates)) < limit {
+		reels, err := s.reelRepo.GetTrendingReels(ctx, query)
+		collect(recommend.SourceTrending, reels, err)
+	}
+	if len(candidates) == 0 && candidateErr != nil {
+		return nil, candidateErr
+	}
+
+	seenSet := make(map[primitive.ObjectID]bool, len(seen))
+	for _, id := range seen {
+		seenSet[id] = true
+	}
+	feed := recommend.Rank(candidates, interests, seenSet, viewerID, now, int(limit))
+
+	if s.interests != nil && len(feed) > 0 {
+		served := make([]primitive.ObjectID, len(feed))
+		for i, reel := range feed {
+			served[i] = reel.ID
+		}
+		if err := s.interests.MarkSeen(ctx, viewerID, served); err != nil {
+			s.logger.Warn("Failed to mark For You reels seen", "error", err, "user_id", viewerID.Hex())
+		}
+	}
+	return feed, nil
+}
+
+// getFollowingIDs fetches the creators the user follows via gRPC to
+// user-service with Redis caching
+func (s *ReelService) getFollowingIDs(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	if s.userClient == nil {
+		return nil, fmt.Errorf("user service client not available")
+	}
+
+	cacheKey := fmt.Sprintf("reel:following:%s", userID.Hex())
+	if s.redisClient != nil {
+		if cached, err := s.redisClient.Get(ctx, cacheKey); err == nil && cached != "" {
+			if followingIDs := parseFriendIDsFromCache(cached); len(followingIDs) > 0 {
+				return followingIDs, nil
+			}
+		}
+	}
+
+	call := func() (interface{}, error) {
+		return s.userClient.GetFollowingIDs(ctx, &userpb.GetFollowingIDsRequest{UserId: userID.Hex()})
+	}
+	var result interface{}
+	var err error
+	if s.breaker != nil {
+		result, err = s.breaker.Execute(ctx, call)
+	} else {
+		result, err = call()
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := result.(*userpb.GetFollowingIDsResponse)
+
+	followingIDs := make([]primitive.ObjectID, 0, len(resp.UserIds))
+	for _, id := range resp.UserIds {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			followingIDs = append(followingIDs, oid)
+		}
+	}
+
+	if s.redisClient != nil && len(resp.UserIds) > 0 {
+		s.redisClient.Set(ctx, cacheKey, stringSliceToCache(resp.UserIds), 5*time.Minute)
+	}
+	return followingIDs, nil
+}
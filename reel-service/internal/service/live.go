@@ -0,0 +1,550 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/live"
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/producer"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrLiveDisabled        = errors.New("live streaming is not enabled")
+	ErrLiveSessionNotFound = errors.New("live session not found")
+	ErrLiveSessionActive   = errors.New("you already have a live session that has not ended")
+	ErrLiveSessionNotLive  = errors.New("live session is not live")
+	ErrNotLiveBroadcaster  = errors.New("only the broadcaster can end a live session")
+	ErrInvalidStreamKey    = errors.New("unknown or used stream key")
+	ErrInvalidLiveTitle    = errors.New("title must be 1 to 120 characters")
+	ErrInvalidLiveChat     = errors.New("message must be 1 to 500 characters")
+)
+
+const (
+	maxLiveTitleLength   = 120
+	maxLiveChatLength    = 500
+	maxLiveSessionsLimit = 50
+	maxLiveChatLimit     = 200
+
+	// How often viewer counts are refreshed and pushed to viewers
+	liveViewerCountInterval = 5 * time.Second
+	// Bounds reading, uploading and registering a recording as a reel
+	liveArchiveTimeout = 10 * time.Minute
+)
+
+// LiveStore keeps live sessions and their chat
+type LiveStore interface {
+	CreateSession(ctx context.Context, session *models.LiveSession) error
+	GetSession(ctx context.Context, id primitive.ObjectID) (*models.LiveSession, error)
+	GetSessionByStreamKey(ctx context.Context, streamKey string) (*models.LiveSession, error)
+	GetActiveSession(ctx context.Context, userID primitive.ObjectID) (*models.LiveSession, error)
+	ListLiveSessions(ctx context.Context, viewerID primitive.ObjectID, friendIDs []primitive.ObjectID, limit int64) ([]models.LiveSession, error)
+	ListLive(ctx context.Context) ([]models.LiveSession, error)
+	ListUserSessions(ctx context.Context, userID primitive.ObjectID) ([]models.LiveSession, error)
+	StartSession(ctx context.Context, id primitive.ObjectID, playbackURL string, at time.Time) (bool, error)
+	EndSession(ctx context.Context, id primitive.ObjectID, at time.Time) (bool, error)
+	SetViewerCount(ctx context.Context, id primitive.ObjectID, count int64) error
+	SetArchive(ctx context.Context, id, reelID primitive.ObjectID) error
+	AddChatMessage(ctx context.Context, message *models.LiveChatMessage) error
+	GetChatMessages(ctx context.Context, sessionID primitive.ObjectID, before time.Time, limit int64) ([]models.LiveChatMessage, error)
+	GetUserChatMessages(ctx context.Context, userID primitive.ObjectID) ([]models.LiveChatMessage, error)
+	DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error)
+}
+
+// ViewerTracker counts who is watching each live session
+type ViewerTracker interface {
+	Join(ctx context.Context, sessionID, viewerID string) (int64, error)
+	Leave(ctx context.Context, sessionID, viewerID string) (int64, error)
+	Count(ctx context.Context, sessionID string) (int64, error)
+	Viewers(ctx context.Context, sessionID string) ([]string, error)
+	Clear(ctx context.Context, sessionID string) error
+}
+
+// ArchiveStorage stores the recordings of ended sessions
+type ArchiveStorage interface {
+	Upload(ctx context.Context, data []byte, filename, contentType string) (string, error)
+}
+
+// LiveStreaming is what live sessions need. Without Storage, broadcasts are
+// not archived.
+type LiveStreaming struct {
+	Store   LiveStore
+	Viewers ViewerTracker
+	Media   live.MediaServer
+	Storage ArchiveStorage
+}
+
+// SetLiveStreaming enables live sessions
+func (s *ReelService) SetLiveStreaming(streaming LiveStreaming) {
+	s.live = &streaming
+}
+
+// CreateLiveSession registers a broadcast. The broadcaster gets the ingest
+// URL and stream key to set in their encoder; the session goes live once
+// the media server accepts the stream.
+func (s *ReelService) CreateLiveSession(ctx context.Context, userID primitive.ObjectID, title string, privacy models.PrivacySettingType) (*models.LiveSession, error) {
+	if s.live == nil {
+		return nil, ErrLiveDisabled
+	}
+	title = strings.TrimSpace(title)
+	if title == "" || utf8.RuneCountInString(title) > maxLiveTitleLength {
+		return nil, ErrInvalidLiveTitle
+	}
+	if privacy == "" {
+		privacy = models.PrivacySettingPublic
+	}
+
+	_, err := s.live.Store.GetActiveSession(ctx, userID)
+	if err == nil {
+		return nil, ErrLiveSessionActive
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	author, err := s.resolveAuthor(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve author: %w", err)
+	}
+	streamKey, err := newStreamKey()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &models.LiveSession{
+		UserID:    userID,
+		Author:    author,
+		Title:     title,
+		Privacy:   privacy,
+		Status:    models.LiveStatusIdle,
+		StreamKey: streamKey,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.live.Store.CreateSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Live session created", "session_id", session.ID.Hex(), "user_id", userID.Hex())
+	return s.liveSessionFor(session, userID), nil
+}
+
+// GetLiveSession returns a session the viewer may watch
+func (s *ReelService) GetLiveSession(ctx context.Context, sessionID, viewerID primitive.ObjectID) (*models.LiveSession, error) {
+	session, err := s.findWatchableLiveSession(ctx, sessionID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status == models.LiveStatusLive {
+		if count, err := s.live.Viewers.Count(ctx, sessionID.Hex()); err == nil {
+			session.ViewerCount = count
+		}
+	}
+	return s.liveSessionFor(session, viewerID), nil
+}
+
+// ListLiveSessions returns the sessions live now that the viewer may watch,
+// most watched first
+func (s *ReelService) ListLiveSessions(ctx context.Context, viewerID primitive.ObjectID, limit int64) ([]models.LiveSession, error) {
+	if s.live == nil {
+		return nil, ErrLiveDisabled
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > maxLiveSessionsLimit {
+		limit = maxLiveSessionsLimit
+	}
+
+	friendIDs, err := s.getFriendIDs(ctx, viewerID)
+	if err != nil {
+		s.logger.Warn("Failed to get friend IDs, listing public live sessions only", "error", err, "user_id", viewerID.Hex())
+		friendIDs = []primitive.ObjectID{}
+	}
+
+	sessions, err := s.live.Store.ListLiveSessions(ctx, viewerID, friendIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		sessions[i] = *s.liveSessionFor(&sessions[i], viewerID)
+	}
+	return sessions, nil
+}
+
+// EndLiveSession lets the broadcaster end their session. The broadcast is
+// archived when the media server reports the stream stopped.
+func (s *ReelService) EndLiveSession(ctx context.Context, sessionID, userID primitive.ObjectID) (*models.LiveSession, error) {
+	session, err := s.findLiveSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != userID {
+		return nil, ErrNotLiveBroadcaster
+	}
+
+	if err := s.endLiveSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return s.liveSessionFor(session, userID), nil
+}
+
+// StreamPublished takes the session the stream key belongs to live. It
+// returns the name the media server should publish the stream under, so
+// the stream key stays private. A key works once.
+func (s *ReelService) StreamPublished(ctx context.Context, streamKey string) (string, error) {
+	if s.live == nil {
+		return "", ErrLiveDisabled
+	}
+
+	session, err := s.live.Store.GetSessionByStreamKey(ctx, streamKey)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", ErrInvalidStreamKey
+	}
+	if err != nil {
+		return "", err
+	}
+
+	stream := session.ID.Hex()
+	started, err := s.live.Store.StartSession(ctx, session.ID, s.live.Media.PlaybackURL(stream), time.Now())
+	if err != nil {
+		return "", err
+	}
+	if !started {
+		return "", ErrInvalidStreamKey
+	}
+
+	s.logger.Info("Live session started", "session_id", stream, "user_id", session.UserID.Hex())
+	return stream, nil
+}
+
+// StreamEnded ends the session the media server stopped receiving, if the
+// broadcaster has not already, and archives the broadcast as a reel
+func (s *ReelService) StreamEnded(ctx context.Context, stream string) error {
+	if s.live == nil {
+		return ErrLiveDisabled
+	}
+	sessionID, err := primitive.ObjectIDFromHex(stream)
+	if err != nil {
+		return ErrLiveSessionNotFound
+	}
+	session, err := s.findLiveSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.endLiveSession(ctx, session); err != nil {
+		return err
+	}
+	if session.StartedAt != nil && session.ReelID == nil {
+		go s.archiveLiveSession(*session)
+	}
+	return nil
+}
+
+// endLiveSession ends the session and tells its viewers. It does nothing
+// if the session already ended.
+func (s *ReelService) endLiveSession(ctx context.Context, session *models.LiveSession) error {
+	now := time.Now()
+	ended, err := s.live.Store.EndSession(ctx, session.ID, now)
+	if err != nil {
+		return err
+	}
+	if session.Status != models.LiveStatusEnded {
+		session.Status = models.LiveStatusEnded
+		session.ViewerCount = 0
+		session.EndedAt = &now
+	}
+	if !ended {
+		return nil
+	}
+
+	stream := session.ID.Hex()
+	s.publishLiveEvent(ctx, producer.EventLiveEnded, producer.LiveEvent{SessionID: stream}, session.UserID)
+	if err := s.live.Viewers.Clear(ctx, stream); err != nil {
+		s.logger.Warn("Failed to clear live viewers", "session_id", stream, "error", err)
+	}
+
+	s.logger.Info("Live session ended", "session_id", stream, "user_id", session.UserID.Hex())
+	return nil
+}
+
+// archiveLiveSession uploads the recording of a broadcast and publishes it
+// as a reel, which the transcoder then packages like any upload. Archiving
+// is best effort, so failures just log.
+func (s *ReelService) archiveLiveSession(session models.LiveSession) {
+	if s.live.Storage == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), liveArchiveTimeout)
+	defer cancel()
+
+	stream := session.ID.Hex()
+	recording, err := s.live.Media.ReadRecording(stream)
+	if errors.Is(err, live.ErrNoRecording) {
+		s.logger.Info("Live session has no recording to archive", "session_id", stream)
+		return
+	}
+	if err != nil {
+		s.logger.Error("Failed to read live recording", "session_id", stream, "error", err)
+		return
+	}
+
+	videoURL, err := s.live.Storage.Upload(ctx, recording, fmt.Sprintf("live-%s.flv", stream), "video/x-flv")
+	if err != nil {
+		s.logger.Error("Failed to upload live recording", "session_id", stream, "error", err)
+		return
+	}
+
+	endedAt := time.Now()
+	if session.EndedAt != nil {
+		endedAt = *session.EndedAt
+	}
+	reel, err := s.CreateReel(ctx, session.UserID, CreateReelRequest{
+		VideoURL: videoURL,
+		Caption:  session.Title,
+		Duration: int(endedAt.Sub(*session.StartedAt).Seconds()),
+		Privacy:  session.Privacy,
+	})
+	if err != nil {
+		s.logger.Error("Failed to archive live session as a reel", "session_id", stream, "error", err)
+		return
+	}
+	if err := s.live.Store.SetArchive(ctx, session.ID, reel.ID); err != nil {
+		s.logger.Error("Failed to link live session to its reel", "session_id", stream, "reel_id", reel.ID.Hex(), "error", err)
+	}
+	if err := s.live.Media.RemoveRecording(stream); err != nil {
+		s.logger.Warn("Failed to remove archived live recording", "session_id", stream, "error", err)
+	}
+
+	s.logger.Info("Live session archived", "session_id", stream, "reel_id", reel.ID.Hex())
+}
+
+// JoinLiveSession counts the viewer as watching and returns the viewer
+// count. Players join again every so often to stay counted.
+func (s *ReelService) JoinLiveSession(ctx context.Context, sessionID, viewerID primitive.ObjectID) (int64, error) {
+	session, err := s.findWatchableLiveSession(ctx, sessionID, viewerID)
+	if err != nil {
+		return 0, err
+	}
+	if session.Status != models.LiveStatusLive {
+		return 0, ErrLiveSessionNotLive
+	}
+	if session.UserID == viewerID {
+		return s.live.Viewers.Count(ctx, sessionID.Hex())
+	}
+	return s.live.Viewers.Join(ctx, sessionID.Hex(), viewerID.Hex())
+}
+
+// LeaveLiveSession stops counting the viewer and returns the viewer count
+func (s *ReelService) LeaveLiveSession(ctx context.Context, sessionID, viewerID primitive.ObjectID) (int64, error) {
+	if _, err := s.findLiveSession(ctx, sessionID); err != nil {
+		return 0, err
+	}
+	return s.live.Viewers.Leave(ctx, sessionID.Hex(), viewerID.Hex())
+}
+
+// SendLiveChatMessage posts to a live session's chat and delivers the
+// message to everyone watching
+func (s *ReelService) SendLiveChatMessage(ctx context.Context, sessionID, userID primitive.ObjectID, content string) (*models.LiveChatMessage, error) {
+	content = strings.TrimSpace(content)
+	if content == "" || utf8.RuneCountInString(content) > maxLiveChatLength {
+		return nil, ErrInvalidLiveChat
+	}
+	session, err := s.findWatchableLiveSession(ctx, sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != models.LiveStatusLive {
+		return nil, ErrLiveSessionNotLive
+	}
+
+	author, err := s.resolveAuthor(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve author: %w", err)
+	}
+	message := &models.LiveChatMessage{
+		SessionID: sessionID,
+		UserID:    userID,
+		Author:    author,
+		Content:   content,
+		CreatedAt: time.Now(),
+	}
+	if err := s.live.Store.AddChatMessage(ctx, message); err != nil {
+		return nil, err
+	}
+
+	s.publishLiveEvent(ctx, producer.EventLiveChatMessage, producer.LiveEvent{
+		SessionID: sessionID.Hex(),
+		Message:   message,
+	}, session.UserID)
+	return message, nil
+}
+
+// GetLiveChatMessages returns a session's latest chat messages sent before
+// the given time, newest first
+func (s *ReelService) GetLiveChatMessages(ctx context.Context, sessionID, viewerID primitive.ObjectID, before time.Time, limit int64) ([]models.LiveChatMessage, error) {
+	if _, err := s.findWatchableLiveSession(ctx, sessionID, viewerID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > maxLiveChatLimit {
+		limit = maxLiveChatLimit
+	}
+	return s.live.Store.GetChatMessages(ctx, sessionID, before, limit)
+}
+
+// RunLiveViewerCounts refreshes the viewer counts of live sessions and
+// pushes the ones that changed to their viewers, until ctx is cancelled
+func (s *ReelService) RunLiveViewerCounts(ctx context.Context) {
+	if s.live == nil {
+		return
+	}
+	ticker := time.NewTicker(liveViewerCountInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.refreshLiveViewerCounts(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *ReelService) refreshLiveViewerCounts(ctx context.Context) {
+	sessions, err := s.live.Store.ListLive(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to list live sessions", "error", err)
+		return
+	}
+
+	for _, session := range sessions {
+		stream := session.ID.Hex()
+		count, err := s.live.Viewers.Count(ctx, stream)
+		if err != nil {
+			s.logger.Warn("Failed to count live viewers", "session_id", stream, "error", err)
+			continue
+		}
+		if count == session.ViewerCount {
+			continue
+		}
+		if err := s.live.Store.SetViewerCount(ctx, session.ID, count); err != nil {
+			s.logger.Warn("Failed to save live viewer count", "session_id", stream, "error", err)
+		}
+		s.publishLiveEvent(ctx, producer.EventLiveViewerCount, producer.LiveEvent{
+			SessionID:   stream,
+			ViewerCount: count,
+		}, session.UserID)
+	}
+}
+
+// publishLiveEvent sends a live session update to its viewers and its
+// broadcaster. Updates are best effort, so failures just log.
+func (s *ReelService) publishLiveEvent(ctx context.Context, eventType string, event producer.LiveEvent, broadcasterID primitive.ObjectID) {
+	if s.realtime == nil {
+		return
+	}
+
+	viewers, err := s.live.Viewers.Viewers(ctx, event.SessionID)
+	if err != nil {
+		s.logger.Warn("Failed to list live viewers", "session_id", event.SessionID, "error", err)
+	}
+	recipients := []primitive.ObjectID{broadcasterID}
+	for _, id := range viewers {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			recipients = append(recipients, oid)
+		}
+	}
+
+	seen := make(map[primitive.ObjectID]bool)
+	var userIDs []string
+	for _, id := range recipients {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		userIDs = append(userIDs, id.Hex())
+	}
+
+	if err := s.realtime.PublishLiveEvent(ctx, eventType, event, userIDs); err != nil {
+		s.logger.Warn("Failed to publish live event", "type", eventType, "session_id", event.SessionID, "error", err)
+	}
+}
+
+func (s *ReelService) findLiveSession(ctx context.Context, sessionID primitive.ObjectID) (*models.LiveSession, error) {
+	if s.live == nil {
+		return nil, ErrLiveDisabled
+	}
+	session, err := s.live.Store.GetSession(ctx, sessionID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrLiveSessionNotFound
+	}
+	return session, err
+}
+
+// findWatchableLiveSession returns the session if the viewer may watch it.
+// Sessions they may not watch are reported missing.
+func (s *ReelService) findWatchableLiveSession(ctx context.Context, sessionID, viewerID primitive.ObjectID) (*models.LiveSession, error) {
+	session, err := s.findLiveSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canWatchLive(ctx, session, viewerID) {
+		return nil, ErrLiveSessionNotFound
+	}
+	return session, nil
+}
+
+func (s *ReelService) canWatchLive(ctx context.Context, session *models.LiveSession, viewerID primitive.ObjectID) bool {
+	if session.UserID == viewerID {
+		return true
+	}
+	switch session.Privacy {
+	case models.PrivacySettingPublic:
+		return true
+	case models.PrivacySettingFriends:
+		friendIDs, err := s.getFriendIDs(ctx, viewerID)
+		if err != nil {
+			s.logger.Warn("Failed to get friend IDs for live session", "error", err, "user_id", viewerID.Hex())
+			return false
+		}
+		for _, id := range friendIDs {
+			if id == session.UserID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// liveSessionFor hides the stream key from anyone but the broadcaster, and
+// gives the broadcaster the ingest URL to use it with
+func (s *ReelService) liveSessionFor(session *models.LiveSession, viewerID primitive.ObjectID) *models.LiveSession {
+	if session.UserID == viewerID && session.Status == models.LiveStatusIdle {
+		session.IngestURL = s.live.Media.IngestURL()
+		return session
+	}
+	session.StreamKey = ""
+	return session
+}
+
+func newStreamKey() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate stream key: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/metrics"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/producer"
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/recommend"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/resilience"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
@@ -21,16 +22,30 @@ type ReelRepository interface {
 	CreateReel(ctx context.Context, reel *models.Reel) (*models.Reel, error)
 	GetReelByID(ctx context.Context, id primitive.ObjectID) (*models.Reel, error)
 	GetUserReels(ctx context.Context, userID primitive.ObjectID) ([]models.Reel, error)
+	ListUserReels(ctx context.Context, userID primitive.ObjectID) ([]models.Reel, error)
+	GetUserComments(ctx context.Context, userID primitive.ObjectID) ([]models.Comment, error)
+	GetUserReplies(ctx context.Context, userID primitive.ObjectID) ([]models.Reply, error)
+	GetUserReactions(ctx context.Context, userID primitive.ObjectID) ([]models.Reaction, error)
 	DeleteReel(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error
 	IncrementViews(ctx context.Context, id primitive.ObjectID) error
 	GetReelsFeed(ctx context.Context, userID primitive.ObjectID, friendIDs []primitive.ObjectID, limit, offset int64) ([]models.Reel, error)
+	GetReelsByCreators(ctx context.Context, q recommend.CandidateQuery, creatorIDs []primitive.ObjectID) ([]models.Reel, error)
+	GetTrendingReels(ctx context.Context, q recommend.CandidateQuery) ([]models.Reel, error)
+	GetReelsByTopics(ctx context.Context, q recommend.CandidateQuery, topics []string) ([]models.Reel, error)
+	AddEngagement(ctx context.Context, id primitive.ObjectID, score float64, shares int64) error
 	AddComment(ctx context.Context, reelID primitive.ObjectID, comment models.Comment) error
 	GetComments(ctx context.Context, reelID primitive.ObjectID, limit, offset int64) ([]models.Comment, error)
+	GetCommentByID(ctx context.Context, commentID primitive.ObjectID) (*models.Comment, error)
+	DeleteComment(ctx context.Context, reelID, commentID primitive.ObjectID) error
 	AddReply(ctx context.Context, reelID primitive.ObjectID, commentID primitive.ObjectID, reply models.Reply) error
+	GetReplies(ctx context.Context, commentID primitive.ObjectID, limit, offset int64) ([]models.Reply, error)
+	GetReplyByID(ctx context.Context, replyID primitive.ObjectID) (*models.Reply, error)
+	DeleteReply(ctx context.Context, commentID, replyID primitive.ObjectID) error
 	GetReaction(ctx context.Context, targetID primitive.ObjectID, userID primitive.ObjectID) (*models.Reaction, error)
 	AddReaction(ctx context.Context, reaction *models.Reaction) error
 	RemoveReaction(ctx context.Context, reaction *models.Reaction) error
-	ReactToComment(ctx context.Context, reelID primitive.ObjectID, commentID primitive.ObjectID, userID primitive.ObjectID, reactionType models.ReactionType) error
+	SetModerationStatus(ctx context.Context, mediaURL string, verdict models.ModerationVerdict) (int64, error)
+	DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error)
 }
 
 type ReelService struct {
@@ -42,6 +57,12 @@ type ReelService struct {
 	logger       *slog.Logger
 	redisClient  *redis.ClusterClient
 	requestGroup singleflight.Group
+
+	notifications NotificationPublisher
+	realtime      RealtimePublisher
+	interests     InterestStore
+	engagement    EngagementPublisher
+	live          *LiveStreaming
 }
 
 func NewReelService(
@@ -93,6 +114,10 @@ func (s *ReelService) CreateReel(ctx context.Context, userID primitive.ObjectID,
 		AllowedViewers: req.AllowedViewers,
 		BlockedViewers: req.BlockedViewers,
 		Author:         author,
+		Category:       recommend.NormalizeTopic(req.Category),
+		Hashtags:       recommend.Hashtags(req.Caption),
+		// Plays from VideoURL until the transcoder publishes renditions
+		ProcessingStatus: models.ReelProcessingPending,
 	}
 
 	createdReel, err := s.reelRepo.CreateReel(ctx, reel)
@@ -106,6 +131,10 @@ func (s *ReelService) CreateReel(ctx context.Context, userID primitive.ObjectID,
 			UserID:   userID.Hex(),
 			VideoURL: req.VideoURL,
 		})
+		s.broadcaster.PublishReelUploaded(ctx, producer.ReelUploadedEvent{
+			ReelID:   createdReel.ID.Hex(),
+			VideoURL: req.VideoURL,
+		})
 	}
 
 	if s.metrics != nil {
@@ -210,6 +239,22 @@ func (s *ReelService) DeleteReel(ctx context.Context, reelID, userID primitive.O
 	return nil
 }
 
+// ApplyModerationVerdict hides the reels whose thumbnail moderation held
+// or rejected
+func (s *ReelService) ApplyModerationVerdict(ctx context.Context, evt *models.MediaModerationEvent) error {
+	if !evt.Verdict.Flagged() {
+		return nil
+	}
+	flagged, err := s.reelRepo.SetModerationStatus(ctx, evt.URL, evt.Verdict)
+	if err != nil {
+		return err
+	}
+	if flagged > 0 {
+		s.logger.Info("Reels flagged by moderation", "key", evt.Key, "verdict", evt.Verdict, "reels", flagged)
+	}
+	return nil
+}
+
 // IncrementViews publishes a view event for async batch processing
 // For TikTok-scale (1M+ views/sec), we avoid direct DB writes here.
 // A separate Kafka consumer batches updates (e.g., $inc: { views: 500 }) to MongoDB.
@@ -240,23 +285,37 @@ func (s *ReelService) IncrementViews(ctx context.Context, reelID, viewerID primi
 
 // ReactToReel handles toggling a reaction on a reel
 func (s *ReelService) ReactToReel(ctx context.Context, reelID, userID primitive.ObjectID, reactionType models.ReactionType) error {
-	// Check for existing reaction
-	existingReaction, err := s.reelRepo.GetReaction(ctx, reelID, userID)
+	_, added, err := s.toggleReaction(ctx, reelID, "reel", userID, reactionType)
 	if err != nil {
 		return err
 	}
+	if added {
+		s.publishLike(ctx, reelID, userID)
+	}
+	return nil
+}
+
+// toggleReaction leaves the user's reaction on a target. Reacting again with
+// the same type takes it back, and a different type replaces it. It returns
+// the reaction and whether it was added.
+func (s *ReelService) toggleReaction(ctx context.Context, targetID primitive.ObjectID, targetType string, userID primitive.ObjectID, reactionType models.ReactionType) (*models.Reaction, bool, error) {
+	// Check for existing reaction
+	existingReaction, err := s.reelRepo.GetReaction(ctx, targetID, userID)
+	if err != nil {
+		return nil, false, err
+	}
 
 	if existingReaction != nil {
 		// Already reacted
 		if existingReaction.Type == reactionType {
 			// Same type -> Remove (Toggle OFF)
-			return s.reelRepo.RemoveReaction(ctx, existingReaction)
+			return existingReaction, false, s.reelRepo.RemoveReaction(ctx, existingReaction)
 		}
 
 		// Different type -> Remove old, Add new (Change reaction)
 		err = s.reelRepo.RemoveReaction(ctx, existingReaction)
 		if err != nil {
-			return err
+			return nil, false, err
 		}
 	}
 
@@ -264,45 +323,34 @@ func (s *ReelService) ReactToReel(ctx context.Context, reelID, userID primitive.
 	newReaction := &models.Reaction{
 		ID:         primitive.NewObjectID(),
 		UserID:     userID,
-		TargetID:   reelID,
-		TargetType: "reel",
+		TargetID:   targetID,
+		TargetType: targetType,
 		Type:       reactionType,
 		CreatedAt:  time.Now(),
 	}
 
-	return s.reelRepo.AddReaction(ctx, newReaction)
+	return newReaction, true, s.reelRepo.AddReaction(ctx, newReaction)
 }
 
 // AddComment adds a comment to a reel
 func (s *ReelService) AddComment(ctx context.Context, reelID, userID primitive.ObjectID, content string, explicitMentions []primitive.ObjectID) (*models.Comment, error) {
-	author, err := s.resolveAuthor(ctx, userID)
+	reel, err := s.findReel(ctx, reelID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolving author: %w", err)
-	}
-
-	// Parse mentions from content and merge with explicit mentions
-	parsedMentions := s.ParseMentions(ctx, content)
-
-	// Deduplicate mentions
-	mentionMap := make(map[string]primitive.ObjectID)
-	for _, id := range parsedMentions {
-		mentionMap[id.Hex()] = id
-	}
-	for _, id := range explicitMentions {
-		mentionMap[id.Hex()] = id
+		return nil, err
 	}
 
-	var finalMentions []primitive.ObjectID
-	for _, id := range mentionMap {
-		finalMentions = append(finalMentions, id)
+	author, err := s.resolveAuthor(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolving author: %w", err)
 	}
 
 	comment := models.Comment{
 		ID:        primitive.NewObjectID(),
+		ReelID:    &reelID,
 		UserID:    userID,
 		Content:   content,
 		Author:    author,
-		Mentions:  finalMentions,
+		Mentions:  s.mergeMentions(ctx, content, explicitMentions),
 		CreatedAt: time.Now(),
 	}
 
@@ -315,6 +363,13 @@ func (s *ReelService) AddComment(ctx context.Context, reelID, userID primitive.O
 		s.metrics.CommentsAdded.Inc()
 	}
 
+	s.notifyMentions(ctx, reel, comment.ID, author, comment.Mentions)
+	s.publishThreadEvent(ctx, producer.EventReelCommentCreated, producer.ReelThreadEvent{
+		ReelID:    reelID.Hex(),
+		CommentID: comment.ID.Hex(),
+		Comment:   &comment,
+	}, reel.UserID, userID)
+
 	return &comment, nil
 }
 
@@ -329,24 +384,40 @@ func (s *ReelService) GetComments(ctx context.Context, reelID primitive.ObjectID
 	return s.reelRepo.GetComments(ctx, reelID, limit, offset)
 }
 
-// AddReply adds a reply to a comment on a reel
-func (s *ReelService) AddReply(ctx context.Context, reelID, commentID, userID primitive.ObjectID, content string) (*models.Reply, error) {
+// AddReply adds a reply to a comment on a reel. Replies may answer another
+// reply in the same thread.
+func (s *ReelService) AddReply(ctx context.Context, reelID, commentID, userID primitive.ObjectID, content string, parentReplyID *primitive.ObjectID, explicitMentions []primitive.ObjectID) (*models.Reply, error) {
+	reel, err := s.findReel(ctx, reelID)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.findComment(ctx, reelID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	recipients := []primitive.ObjectID{reel.UserID, comment.UserID, userID}
+	if parentReplyID != nil {
+		parent, err := s.findReply(ctx, commentID, *parentReplyID)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, parent.UserID)
+	}
+
 	author, err := s.resolveAuthor(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolving author: %w", err)
 	}
 
-	// Parse mentions from content
-	mentions := s.ParseMentions(ctx, content)
-
 	reply := models.Reply{
-		ID:        primitive.NewObjectID(),
-		CommentID: commentID,
-		UserID:    userID,
-		Author:    author,
-		Mentions:  mentions,
-		Content:   content,
-		CreatedAt: time.Now(),
+		ID:            primitive.NewObjectID(),
+		CommentID:     commentID,
+		ParentReplyID: parentReplyID,
+		UserID:        userID,
+		Author:        author,
+		Mentions:      s.mergeMentions(ctx, content, explicitMentions),
+		Content:       content,
+		CreatedAt:     time.Now(),
 	}
 
 	err = s.reelRepo.AddReply(ctx, reelID, commentID, reply)
@@ -354,12 +425,43 @@ func (s *ReelService) AddReply(ctx context.Context, reelID, commentID, userID pr
 		return nil, err
 	}
 
+	if s.metrics != nil {
+		s.metrics.CommentsAdded.Inc()
+	}
+
+	s.notifyMentions(ctx, reel, commentID, author, reply.Mentions)
+	s.publishThreadEvent(ctx, producer.EventReelReplyCreated, producer.ReelThreadEvent{
+		ReelID:    reelID.Hex(),
+		CommentID: commentID.Hex(),
+		ReplyID:   reply.ID.Hex(),
+		Reply:     &reply,
+	}, recipients...)
+
 	return &reply, nil
 }
 
 // ReactToComment toggles a reaction on a comment
 func (s *ReelService) ReactToComment(ctx context.Context, reelID, commentID, userID primitive.ObjectID, reactionType models.ReactionType) error {
-	return s.reelRepo.ReactToComment(ctx, reelID, commentID, userID, reactionType)
+	reel, err := s.findReel(ctx, reelID)
+	if err != nil {
+		return err
+	}
+	comment, err := s.findComment(ctx, reelID, commentID)
+	if err != nil {
+		return err
+	}
+
+	reaction, added, err := s.toggleReaction(ctx, commentID, "comment", userID, reactionType)
+	if err != nil {
+		return err
+	}
+
+	s.publishReactionEvent(ctx, producer.ReelThreadEvent{
+		ReelID:    reelID.Hex(),
+		CommentID: commentID.Hex(),
+		Reaction:  reaction,
+	}, added, reel.UserID, comment.UserID, userID)
+	return nil
 }
 
 // ParseMentions extracts @username mentions from content and validates them via user-service
@@ -475,6 +577,7 @@ func (s *ReelService) resolveAuthor(ctx context.Context, userID primitive.Object
 			Username: user.Username,
 			Avatar:   user.Avatar,
 			FullName: user.FullName,
+			Verified: user.IsVerified,
 		}, nil
 	})
 
@@ -484,6 +587,28 @@ func (s *ReelService) resolveAuthor(ctx context.Context, userID primitive.Object
 	return val.(models.PostAuthor), nil
 }
 
+// mergeMentions combines the users mentioned by @username in content with
+// those the client mentioned explicitly, without duplicates
+func (s *ReelService) mergeMentions(ctx context.Context, content string, explicitMentions []primitive.ObjectID) []primitive.ObjectID {
+	// Parse mentions from content and merge with explicit mentions
+	parsedMentions := s.ParseMentions(ctx, content)
+
+	// Deduplicate mentions
+	mentionMap := make(map[string]primitive.ObjectID)
+	for _, id := range parsedMentions {
+		mentionMap[id.Hex()] = id
+	}
+	for _, id := range explicitMentions {
+		mentionMap[id.Hex()] = id
+	}
+
+	var finalMentions []primitive.ObjectID
+	for _, id := range mentionMap {
+		finalMentions = append(finalMentions, id)
+	}
+	return finalMentions
+}
+
 type CreateReelRequest struct {
 	VideoURL       string                    `json:"video_url"`
 	ThumbnailURL   string                    `json:"thumbnail_url"`
@@ -492,4 +617,5 @@ type CreateReelRequest struct {
 	Privacy        models.PrivacySettingType `json:"privacy"`
 	AllowedViewers []primitive.ObjectID      `json:"allowed_viewers"`
 	BlockedViewers []primitive.ObjectID      `json:"blocked_viewers"`
+	Category       string                    `json:"category"`
 }
@@ -2,15 +2,19 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/live"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/producer"
+	"github.com/MuhibNayem/connectify-v2/reel-service/internal/recommend"
 	"github.com/MuhibNayem/connectify-v2/reel-service/internal/resilience"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type MockReelRepository struct {
@@ -41,6 +45,38 @@ func (m *MockReelRepository) GetUserReels(ctx context.Context, userID primitive.
 	return args.Get(0).([]models.Reel), args.Error(1)
 }
 
+func (m *MockReelRepository) ListUserReels(ctx context.Context, userID primitive.ObjectID) ([]models.Reel, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Reel), args.Error(1)
+}
+
+func (m *MockReelRepository) GetUserComments(ctx context.Context, userID primitive.ObjectID) ([]models.Comment, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Comment), args.Error(1)
+}
+
+func (m *MockReelRepository) GetUserReplies(ctx context.Context, userID primitive.ObjectID) ([]models.Reply, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Reply), args.Error(1)
+}
+
+func (m *MockReelRepository) GetUserReactions(ctx context.Context, userID primitive.ObjectID) ([]models.Reaction, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Reaction), args.Error(1)
+}
+
 func (m *MockReelRepository) DeleteReel(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
 	args := m.Called(ctx, id, userID)
 	return args.Error(0)
@@ -72,11 +108,45 @@ func (m *MockReelRepository) GetComments(ctx context.Context, reelID primitive.O
 	return args.Get(0).([]models.Comment), args.Error(1)
 }
 
+func (m *MockReelRepository) GetCommentByID(ctx context.Context, commentID primitive.ObjectID) (*models.Comment, error) {
+	args := m.Called(ctx, commentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Comment), args.Error(1)
+}
+
+func (m *MockReelRepository) DeleteComment(ctx context.Context, reelID, commentID primitive.ObjectID) error {
+	args := m.Called(ctx, reelID, commentID)
+	return args.Error(0)
+}
+
 func (m *MockReelRepository) AddReply(ctx context.Context, reelID primitive.ObjectID, commentID primitive.ObjectID, reply models.Reply) error {
 	args := m.Called(ctx, reelID, commentID, reply)
 	return args.Error(0)
 }
 
+func (m *MockReelRepository) GetReplies(ctx context.Context, commentID primitive.ObjectID, limit, offset int64) ([]models.Reply, error) {
+	args := m.Called(ctx, commentID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Reply), args.Error(1)
+}
+
+func (m *MockReelRepository) GetReplyByID(ctx context.Context, replyID primitive.ObjectID) (*models.Reply, error) {
+	args := m.Called(ctx, replyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Reply), args.Error(1)
+}
+
+func (m *MockReelRepository) DeleteReply(ctx context.Context, commentID, replyID primitive.ObjectID) error {
+	args := m.Called(ctx, commentID, replyID)
+	return args.Error(0)
+}
+
 func (m *MockReelRepository) GetReaction(ctx context.Context, targetID primitive.ObjectID, userID primitive.ObjectID) (*models.Reaction, error) {
 	args := m.Called(ctx, targetID, userID)
 	if args.Get(0) == nil {
@@ -95,8 +165,33 @@ func (m *MockReelRepository) RemoveReaction(ctx context.Context, reaction *model
 	return args.Error(0)
 }
 
-func (m *MockReelRepository) ReactToComment(ctx context.Context, reelID primitive.ObjectID, commentID primitive.ObjectID, userID primitive.ObjectID, reactionType models.ReactionType) error {
-	args := m.Called(ctx, reelID, commentID, userID, reactionType)
+func (m *MockReelRepository) SetModerationStatus(ctx context.Context, mediaURL string, verdict models.ModerationVerdict) (int64, error) {
+	args := m.Called(ctx, mediaURL, verdict)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReelRepository) DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReelRepository) GetReelsByCreators(ctx context.Context, q recommend.CandidateQuery, creatorIDs []primitive.ObjectID) ([]models.Reel, error) {
+	args := m.Called(ctx, q, creatorIDs)
+	return args.Get(0).([]models.Reel), args.Error(1)
+}
+
+func (m *MockReelRepository) GetTrendingReels(ctx context.Context, q recommend.CandidateQuery) ([]models.Reel, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).([]models.Reel), args.Error(1)
+}
+
+func (m *MockReelRepository) GetReelsByTopics(ctx context.Context, q recommend.CandidateQuery, topics []string) ([]models.Reel, error) {
+	args := m.Called(ctx, q, topics)
+	return args.Get(0).([]models.Reel), args.Error(1)
+}
+
+func (m *MockReelRepository) AddEngagement(ctx context.Context, id primitive.ObjectID, score float64, shares int64) error {
+	args := m.Called(ctx, id, score, shares)
 	return args.Error(0)
 }
 
@@ -108,6 +203,10 @@ func (m *MockBroadcaster) PublishReelCreated(ctx context.Context, event producer
 	m.Called(ctx, event)
 }
 
+func (m *MockBroadcaster) PublishReelUploaded(ctx context.Context, event producer.ReelUploadedEvent) {
+	m.Called(ctx, event)
+}
+
 func (m *MockBroadcaster) PublishReelDeleted(ctx context.Context, event producer.ReelDeletedEvent) {
 	m.Called(ctx, event)
 }
@@ -121,6 +220,187 @@ func (m *MockBroadcaster) Close() error {
 	return args.Error(0)
 }
 
+type MockNotificationPublisher struct {
+	mock.Mock
+}
+
+func (m *MockNotificationPublisher) PublishNotification(ctx context.Context, notification *models.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+type MockRealtimePublisher struct {
+	mock.Mock
+}
+
+func (m *MockRealtimePublisher) PublishReelEvent(ctx context.Context, eventType string, event producer.ReelThreadEvent, recipients []string) error {
+	args := m.Called(ctx, eventType, event, recipients)
+	return args.Error(0)
+}
+
+func (m *MockRealtimePublisher) PublishLiveEvent(ctx context.Context, eventType string, event producer.LiveEvent, recipients []string) error {
+	args := m.Called(ctx, eventType, event, recipients)
+	return args.Error(0)
+}
+
+type MockInterestStore struct {
+	mock.Mock
+}
+
+func (m *MockInterestStore) GetInterests(ctx context.Context, userID primitive.ObjectID) (*recommend.Interests, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recommend.Interests), args.Error(1)
+}
+
+func (m *MockInterestStore) SaveInterests(ctx context.Context, interests *recommend.Interests) error {
+	args := m.Called(ctx, interests)
+	return args.Error(0)
+}
+
+func (m *MockInterestStore) MarkSeen(ctx context.Context, userID primitive.ObjectID, reelIDs []primitive.ObjectID) error {
+	args := m.Called(ctx, userID, reelIDs)
+	return args.Error(0)
+}
+
+func (m *MockInterestStore) GetSeenReelIDs(ctx context.Context, userID primitive.ObjectID, limit int64) ([]primitive.ObjectID, error) {
+	args := m.Called(ctx, userID, limit)
+	return args.Get(0).([]primitive.ObjectID), args.Error(1)
+}
+
+func (m *MockInterestStore) DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockEngagementPublisher struct {
+	mock.Mock
+}
+
+func (m *MockEngagementPublisher) PublishEngagement(ctx context.Context, event recommend.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+type MockLiveStore struct {
+	mock.Mock
+}
+
+func (m *MockLiveStore) CreateSession(ctx context.Context, session *models.LiveSession) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockLiveStore) GetSession(ctx context.Context, id primitive.ObjectID) (*models.LiveSession, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LiveSession), args.Error(1)
+}
+
+func (m *MockLiveStore) GetSessionByStreamKey(ctx context.Context, streamKey string) (*models.LiveSession, error) {
+	args := m.Called(ctx, streamKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LiveSession), args.Error(1)
+}
+
+func (m *MockLiveStore) GetActiveSession(ctx context.Context, userID primitive.ObjectID) (*models.LiveSession, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LiveSession), args.Error(1)
+}
+
+func (m *MockLiveStore) ListLiveSessions(ctx context.Context, viewerID primitive.ObjectID, friendIDs []primitive.ObjectID, limit int64) ([]models.LiveSession, error) {
+	args := m.Called(ctx, viewerID, friendIDs, limit)
+	return args.Get(0).([]models.LiveSession), args.Error(1)
+}
+
+func (m *MockLiveStore) ListLive(ctx context.Context) ([]models.LiveSession, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.LiveSession), args.Error(1)
+}
+
+func (m *MockLiveStore) ListUserSessions(ctx context.Context, userID primitive.ObjectID) ([]models.LiveSession, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]models.LiveSession), args.Error(1)
+}
+
+func (m *MockLiveStore) StartSession(ctx context.Context, id primitive.ObjectID, playbackURL string, at time.Time) (bool, error) {
+	args := m.Called(ctx, id, playbackURL, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLiveStore) EndSession(ctx context.Context, id primitive.ObjectID, at time.Time) (bool, error) {
+	args := m.Called(ctx, id, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLiveStore) SetViewerCount(ctx context.Context, id primitive.ObjectID, count int64) error {
+	args := m.Called(ctx, id, count)
+	return args.Error(0)
+}
+
+func (m *MockLiveStore) SetArchive(ctx context.Context, id, reelID primitive.ObjectID) error {
+	args := m.Called(ctx, id, reelID)
+	return args.Error(0)
+}
+
+func (m *MockLiveStore) AddChatMessage(ctx context.Context, message *models.LiveChatMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *MockLiveStore) GetChatMessages(ctx context.Context, sessionID primitive.ObjectID, before time.Time, limit int64) ([]models.LiveChatMessage, error) {
+	args := m.Called(ctx, sessionID, before, limit)
+	return args.Get(0).([]models.LiveChatMessage), args.Error(1)
+}
+
+func (m *MockLiveStore) GetUserChatMessages(ctx context.Context, userID primitive.ObjectID) ([]models.LiveChatMessage, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]models.LiveChatMessage), args.Error(1)
+}
+
+func (m *MockLiveStore) DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockViewerTracker struct {
+	mock.Mock
+}
+
+func (m *MockViewerTracker) Join(ctx context.Context, sessionID, viewerID string) (int64, error) {
+	args := m.Called(ctx, sessionID, viewerID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockViewerTracker) Leave(ctx context.Context, sessionID, viewerID string) (int64, error) {
+	args := m.Called(ctx, sessionID, viewerID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockViewerTracker) Count(ctx context.Context, sessionID string) (int64, error) {
+	args := m.Called(ctx, sessionID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockViewerTracker) Viewers(ctx context.Context, sessionID string) ([]string, error) {
+	args := m.Called(ctx, sessionID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockViewerTracker) Clear(ctx context.Context, sessionID string) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
 func newTestReelService(repo *MockReelRepository, broadcaster *MockBroadcaster) *ReelService {
 	breaker := resilience.NewCircuitBreaker(resilience.DefaultConfig("test"), nil)
 	return NewReelService(repo, broadcaster, nil, breaker, nil, nil, nil)
@@ -154,8 +434,14 @@ func TestCreateReel_Success(t *testing.T) {
 		Author:       models.PostAuthor{ID: userID.Hex()},
 	}
 
-	mockRepo.On("CreateReel", ctx, mock.AnythingOfType("*models.Reel")).Return(expectedReel, nil)
+	mockRepo.On("CreateReel", ctx, mock.MatchedBy(func(r *models.Reel) bool {
+		return r.ProcessingStatus == models.ReelProcessingPending
+	})).Return(expectedReel, nil)
 	mockBroadcaster.On("PublishReelCreated", ctx, mock.AnythingOfType("producer.ReelCreatedEvent")).Return()
+	mockBroadcaster.On("PublishReelUploaded", ctx, producer.ReelUploadedEvent{
+		ReelID:   expectedReel.ID.Hex(),
+		VideoURL: req.VideoURL,
+	}).Return()
 
 	reel, err := svc.CreateReel(ctx, userID, req)
 
@@ -210,6 +496,7 @@ func TestCreateReel_DefaultPrivacy(t *testing.T) {
 		return r.Privacy == models.PrivacySettingPublic
 	})).Return(expectedReel, nil)
 	mockBroadcaster.On("PublishReelCreated", ctx, mock.Anything).Return()
+	mockBroadcaster.On("PublishReelUploaded", ctx, mock.Anything).Return()
 
 	reel, err := svc.CreateReel(ctx, userID, req)
 
@@ -396,6 +683,7 @@ func TestAddComment_Success(t *testing.T) {
 	userID := primitive.NewObjectID()
 	content := "Great reel!"
 
+	mockRepo.On("GetReelByID", ctx, reelID).Return(&models.Reel{ID: reelID, UserID: primitive.NewObjectID()}, nil)
 	mockRepo.On("AddComment", ctx, reelID, mock.AnythingOfType("models.Comment")).Return(nil)
 
 	comment, err := svc.AddComment(ctx, reelID, userID, content, nil)
@@ -419,6 +707,7 @@ func TestAddComment_WithMentions(t *testing.T) {
 
 	explicitMentions := []primitive.ObjectID{mentionedUserID}
 
+	mockRepo.On("GetReelByID", ctx, reelID).Return(&models.Reel{ID: reelID, UserID: primitive.NewObjectID()}, nil)
 	mockRepo.On("AddComment", ctx, reelID, mock.MatchedBy(func(c models.Comment) bool {
 		return len(c.Mentions) > 0
 	})).Return(nil)
@@ -431,6 +720,55 @@ func TestAddComment_WithMentions(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestAddComment_ReelNotFound(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	svc := newTestReelService(mockRepo, nil)
+
+	ctx := context.Background()
+	reelID := primitive.NewObjectID()
+
+	mockRepo.On("GetReelByID", ctx, reelID).Return(nil, mongo.ErrNoDocuments)
+
+	comment, err := svc.AddComment(ctx, reelID, primitive.NewObjectID(), "Hello", nil)
+
+	assert.ErrorIs(t, err, ErrReelNotFound)
+	assert.Nil(t, comment)
+	mockRepo.AssertNotCalled(t, "AddComment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddComment_NotifiesMentionsAndPublishes(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	notifications := new(MockNotificationPublisher)
+	realtime := new(MockRealtimePublisher)
+	svc := newTestReelService(mockRepo, nil)
+	svc.SetNotificationPublisher(notifications)
+	svc.SetRealtimePublisher(realtime)
+
+	ctx := context.Background()
+	reelID := primitive.NewObjectID()
+	ownerID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	mentionedUserID := primitive.NewObjectID()
+
+	mockRepo.On("GetReelByID", ctx, reelID).Return(&models.Reel{ID: reelID, UserID: ownerID}, nil)
+	mockRepo.On("AddComment", ctx, reelID, mock.AnythingOfType("models.Comment")).Return(nil)
+	notifications.On("PublishNotification", ctx, mock.MatchedBy(func(n *models.Notification) bool {
+		return n.RecipientID == mentionedUserID && n.SenderID == userID &&
+			n.Type == models.NotificationTypeMention && n.TargetID == reelID && n.TargetType == "reel"
+	})).Return(nil).Once()
+	realtime.On("PublishReelEvent", ctx, producer.EventReelCommentCreated, mock.MatchedBy(func(e producer.ReelThreadEvent) bool {
+		return e.ReelID == reelID.Hex() && e.Comment != nil
+	}), []string{ownerID.Hex(), userID.Hex()}).Return(nil).Once()
+
+	// Mentioning yourself does not notify
+	_, err := svc.AddComment(ctx, reelID, userID, "Look!", []primitive.ObjectID{mentionedUserID, userID})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	notifications.AssertExpectations(t)
+	realtime.AssertExpectations(t)
+}
+
 // ==================== GetComments Tests ====================
 
 func TestGetComments_Success(t *testing.T) {
@@ -482,9 +820,11 @@ func TestAddReply_Success(t *testing.T) {
 	userID := primitive.NewObjectID()
 	content := "Nice comment!"
 
+	mockRepo.On("GetReelByID", ctx, reelID).Return(&models.Reel{ID: reelID, UserID: primitive.NewObjectID()}, nil)
+	mockRepo.On("GetCommentByID", ctx, commentID).Return(&models.Comment{ID: commentID, ReelID: &reelID}, nil)
 	mockRepo.On("AddReply", ctx, reelID, commentID, mock.AnythingOfType("models.Reply")).Return(nil)
 
-	reply, err := svc.AddReply(ctx, reelID, commentID, userID, content)
+	reply, err := svc.AddReply(ctx, reelID, commentID, userID, content, nil, nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, reply)
@@ -493,6 +833,65 @@ func TestAddReply_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestAddReply_ToReply(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	svc := newTestReelService(mockRepo, nil)
+
+	ctx := context.Background()
+	reelID := primitive.NewObjectID()
+	commentID := primitive.NewObjectID()
+	parentID := primitive.NewObjectID()
+
+	mockRepo.On("GetReelByID", ctx, reelID).Return(&models.Reel{ID: reelID, UserID: primitive.NewObjectID()}, nil)
+	mockRepo.On("GetCommentByID", ctx, commentID).Return(&models.Comment{ID: commentID, ReelID: &reelID}, nil)
+	mockRepo.On("GetReplyByID", ctx, parentID).Return(&models.Reply{ID: parentID, CommentID: commentID}, nil)
+	mockRepo.On("AddReply", ctx, reelID, commentID, mock.MatchedBy(func(r models.Reply) bool {
+		return r.ParentReplyID != nil && *r.ParentReplyID == parentID
+	})).Return(nil)
+
+	reply, err := svc.AddReply(ctx, reelID, commentID, primitive.NewObjectID(), "Agreed", &parentID, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &parentID, reply.ParentReplyID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddReply_CommentOnAnotherReel(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	svc := newTestReelService(mockRepo, nil)
+
+	ctx := context.Background()
+	reelID := primitive.NewObjectID()
+	otherReelID := primitive.NewObjectID()
+	commentID := primitive.NewObjectID()
+
+	mockRepo.On("GetReelByID", ctx, reelID).Return(&models.Reel{ID: reelID}, nil)
+	mockRepo.On("GetCommentByID", ctx, commentID).Return(&models.Comment{ID: commentID, ReelID: &otherReelID}, nil)
+
+	_, err := svc.AddReply(ctx, reelID, commentID, primitive.NewObjectID(), "Hi", nil, nil)
+
+	assert.ErrorIs(t, err, ErrCommentNotFound)
+	mockRepo.AssertNotCalled(t, "AddReply", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetReplies_DefaultLimit(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	svc := newTestReelService(mockRepo, nil)
+
+	ctx := context.Background()
+	reelID := primitive.NewObjectID()
+	commentID := primitive.NewObjectID()
+
+	mockRepo.On("GetCommentByID", ctx, commentID).Return(&models.Comment{ID: commentID, ReelID: &reelID}, nil)
+	mockRepo.On("GetReplies", ctx, commentID, int64(20), int64(0)).Return([]models.Reply{{ID: primitive.NewObjectID()}}, nil)
+
+	replies, err := svc.GetReplies(ctx, reelID, commentID, 0, -1)
+
+	assert.NoError(t, err)
+	assert.Len(t, replies, 1)
+	mockRepo.AssertExpectations(t)
+}
+
 // ==================== ReactToComment Tests ====================
 
 func TestReactToComment_Success(t *testing.T) {
@@ -505,7 +904,12 @@ func TestReactToComment_Success(t *testing.T) {
 	userID := primitive.NewObjectID()
 	reactionType := models.ReactionLike
 
-	mockRepo.On("ReactToComment", ctx, reelID, commentID, userID, reactionType).Return(nil)
+	mockRepo.On("GetReelByID", ctx, reelID).Return(&models.Reel{ID: reelID}, nil)
+	mockRepo.On("GetCommentByID", ctx, commentID).Return(&models.Comment{ID: commentID, ReelID: &reelID}, nil)
+	mockRepo.On("GetReaction", ctx, commentID, userID).Return(nil, nil)
+	mockRepo.On("AddReaction", ctx, mock.MatchedBy(func(r *models.Reaction) bool {
+		return r.TargetID == commentID && r.TargetType == "comment" && r.Type == reactionType
+	})).Return(nil)
 
 	err := svc.ReactToComment(ctx, reelID, commentID, userID, reactionType)
 
@@ -513,6 +917,101 @@ func TestReactToComment_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestReactToReply_ToggleOff(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	realtime := new(MockRealtimePublisher)
+	svc := newTestReelService(mockRepo, nil)
+	svc.SetRealtimePublisher(realtime)
+
+	ctx := context.Background()
+	reelID := primitive.NewObjectID()
+	commentID := primitive.NewObjectID()
+	replyID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+
+	existing := &models.Reaction{
+		ID:         primitive.NewObjectID(),
+		UserID:     userID,
+		TargetID:   replyID,
+		TargetType: "reply",
+		Type:       models.ReactionLove,
+	}
+
+	mockRepo.On("GetReelByID", ctx, reelID).Return(&models.Reel{ID: reelID, UserID: userID}, nil)
+	mockRepo.On("GetCommentByID", ctx, commentID).Return(&models.Comment{ID: commentID, ReelID: &reelID}, nil)
+	mockRepo.On("GetReplyByID", ctx, replyID).Return(&models.Reply{ID: replyID, CommentID: commentID, UserID: userID}, nil)
+	mockRepo.On("GetReaction", ctx, replyID, userID).Return(existing, nil)
+	mockRepo.On("RemoveReaction", ctx, existing).Return(nil)
+	realtime.On("PublishReelEvent", ctx, producer.EventReelReactionDeleted, mock.MatchedBy(func(e producer.ReelThreadEvent) bool {
+		return e.ReplyID == replyID.Hex() && e.Reaction == existing
+	}), []string{userID.Hex()}).Return(nil)
+
+	err := svc.ReactToReply(ctx, reelID, commentID, replyID, userID, models.ReactionLove)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	realtime.AssertExpectations(t)
+}
+
+// ==================== Delete Comment Tests ====================
+
+func TestDeleteComment_ByReelOwner(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	svc := newTestReelService(mockRepo, nil)
+
+	ctx := context.Background()
+	reelID := primitive.NewObjectID()
+	ownerID := primitive.NewObjectID()
+	commentID := primitive.NewObjectID()
+
+	mockRepo.On("GetReelByID", ctx, reelID).Return(&models.Reel{ID: reelID, UserID: ownerID}, nil)
+	mockRepo.On("GetCommentByID", ctx, commentID).Return(&models.Comment{ID: commentID, ReelID: &reelID, UserID: primitive.NewObjectID()}, nil)
+	mockRepo.On("DeleteComment", ctx, reelID, commentID).Return(nil)
+
+	err := svc.DeleteComment(ctx, reelID, commentID, ownerID)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteComment_NotAllowed(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	svc := newTestReelService(mockRepo, nil)
+
+	ctx := context.Background()
+	reelID := primitive.NewObjectID()
+	commentID := primitive.NewObjectID()
+
+	mockRepo.On("GetReelByID", ctx, reelID).Return(&models.Reel{ID: reelID, UserID: primitive.NewObjectID()}, nil)
+	mockRepo.On("GetCommentByID", ctx, commentID).Return(&models.Comment{ID: commentID, ReelID: &reelID, UserID: primitive.NewObjectID()}, nil)
+
+	err := svc.DeleteComment(ctx, reelID, commentID, primitive.NewObjectID())
+
+	assert.True(t, errors.Is(err, ErrNotCommentAuthor))
+	mockRepo.AssertNotCalled(t, "DeleteComment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteReply_ByAuthor(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	svc := newTestReelService(mockRepo, nil)
+
+	ctx := context.Background()
+	reelID := primitive.NewObjectID()
+	commentID := primitive.NewObjectID()
+	replyID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+
+	mockRepo.On("GetReelByID", ctx, reelID).Return(&models.Reel{ID: reelID, UserID: primitive.NewObjectID()}, nil)
+	mockRepo.On("GetCommentByID", ctx, commentID).Return(&models.Comment{ID: commentID, ReelID: &reelID}, nil)
+	mockRepo.On("GetReplyByID", ctx, replyID).Return(&models.Reply{ID: replyID, CommentID: commentID, UserID: userID}, nil)
+	mockRepo.On("DeleteReply", ctx, commentID, replyID).Return(nil)
+
+	err := svc.DeleteReply(ctx, reelID, commentID, replyID, userID)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
 // ==================== GetUserReels Tests ====================
 
 func TestGetUserReels_Success(t *testing.T) {
@@ -592,6 +1091,7 @@ func TestCreateReel_RepoError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, reel)
 	mockBroadcaster.AssertNotCalled(t, "PublishReelCreated")
+	mockBroadcaster.AssertNotCalled(t, "PublishReelUploaded")
 }
 
 func TestGetComments_NegativeOffset(t *testing.T) {
@@ -622,6 +1122,7 @@ func TestAddComment_SetsCreatedAt(t *testing.T) {
 
 	before := time.Now()
 
+	mockRepo.On("GetReelByID", ctx, reelID).Return(&models.Reel{ID: reelID, UserID: primitive.NewObjectID()}, nil)
 	mockRepo.On("AddComment", ctx, reelID, mock.MatchedBy(func(c models.Comment) bool {
 		return !c.CreatedAt.IsZero() && c.CreatedAt.After(before.Add(-time.Second))
 	})).Return(nil)
@@ -632,3 +1133,316 @@ func TestAddComment_SetsCreatedAt(t *testing.T) {
 	assert.NotNil(t, comment)
 	assert.False(t, comment.CreatedAt.IsZero())
 }
+
+// ==================== Moderation Tests ====================
+
+func TestApplyModerationVerdict_Rejected(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	svc := newTestReelService(mockRepo, nil)
+
+	ctx := context.Background()
+	thumbnailURL := "http://localhost:9000/connectify-uploads/thumb.jpg"
+	mockRepo.On("SetModerationStatus", ctx, thumbnailURL, models.ModerationRejected).Return(int64(1), nil)
+
+	err := svc.ApplyModerationVerdict(ctx, &models.MediaModerationEvent{
+		Key:     "thumb.jpg",
+		URL:     thumbnailURL,
+		Verdict: models.ModerationRejected,
+	})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestApplyModerationVerdict_ApprovedIsIgnored(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	svc := newTestReelService(mockRepo, nil)
+
+	err := svc.ApplyModerationVerdict(context.Background(), &models.MediaModerationEvent{
+		Key:     "thumb.jpg",
+		URL:     "http://localhost:9000/connectify-uploads/thumb.jpg",
+		Verdict: models.ModerationApproved,
+	})
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "SetModerationStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetForYouFeed_SkipsSeenAndMarksServed(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	interests := new(MockInterestStore)
+	svc := newTestReelService(mockRepo, new(MockBroadcaster))
+	svc.SetInterestStore(interests)
+
+	ctx := context.Background()
+	viewerID := primitive.NewObjectID()
+	now := time.Now()
+	seen := models.Reel{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), CreatedAt: now}
+	popular := models.Reel{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), EngagementScore: 500, CreatedAt: now}
+	quiet := models.Reel{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), CreatedAt: now}
+
+	interests.On("GetInterests", ctx, viewerID).Return(nil, nil)
+	interests.On("GetSeenReelIDs", ctx, viewerID, int64(seenWindow)).Return([]primitive.ObjectID{seen.ID}, nil)
+	mockRepo.On("GetTrendingReels", ctx, mock.MatchedBy(func(q recommend.CandidateQuery) bool {
+		return q.ViewerID == viewerID && len(q.Exclude) == 1 && q.Exclude[0] == seen.ID && !q.Since.IsZero()
+	})).Return([]models.Reel{quiet, seen, popular}, nil)
+	interests.On("MarkSeen", ctx, viewerID, []primitive.ObjectID{popular.ID, quiet.ID}).Return(nil)
+
+	feed, err := svc.GetForYouFeed(ctx, viewerID, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{popular.ID, quiet.ID}, []primitive.ObjectID{feed[0].ID, feed[1].ID})
+	mockRepo.AssertExpectations(t)
+	interests.AssertExpectations(t)
+}
+
+func TestGetForYouFeed_FallsBackToOlderReels(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	svc := newTestReelService(mockRepo, new(MockBroadcaster))
+
+	ctx := context.Background()
+	viewerID := primitive.NewObjectID()
+	old := models.Reel{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), CreatedAt: time.Now().AddDate(0, -3, 0)}
+
+	mockRepo.On("GetTrendingReels", ctx, mock.MatchedBy(func(q recommend.CandidateQuery) bool { return !q.Since.IsZero() })).
+		Return([]models.Reel{}, nil)
+	mockRepo.On("GetTrendingReels", ctx, mock.MatchedBy(func(q recommend.CandidateQuery) bool { return q.Since.IsZero() })).
+		Return([]models.Reel{old}, nil)
+
+	feed, err := svc.GetForYouFeed(ctx, viewerID, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, feed, 1)
+	assert.Equal(t, old.ID, feed[0].ID)
+}
+
+func TestRecordEngagement(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	engagement := new(MockEngagementPublisher)
+	svc := newTestReelService(mockRepo, new(MockBroadcaster))
+	svc.SetEngagementPublisher(engagement)
+
+	ctx := context.Background()
+	reel := &models.Reel{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID()}
+	viewerID := primitive.NewObjectID()
+
+	err := svc.RecordEngagement(ctx, reel.ID, viewerID, recommend.SignalLike, 0)
+	assert.ErrorIs(t, err, ErrInvalidSignal)
+
+	mockRepo.On("GetReelByID", ctx, reel.ID).Return(reel, nil)
+	engagement.On("PublishEngagement", ctx, mock.MatchedBy(func(e recommend.Event) bool {
+		return e.ReelID == reel.ID.Hex() && e.UserID == viewerID.Hex() && e.Signal == recommend.SignalWatch && e.WatchTimeMs == 4200
+	})).Return(nil).Once()
+
+	assert.NoError(t, svc.RecordEngagement(ctx, reel.ID, viewerID, recommend.SignalWatch, 4200))
+	// Creators watching their own reels teach the feed nothing
+	assert.NoError(t, svc.RecordEngagement(ctx, reel.ID, reel.UserID, recommend.SignalWatch, 4200))
+	engagement.AssertExpectations(t)
+}
+
+func TestApplyEngagement_UpdatesInterests(t *testing.T) {
+	mockRepo := new(MockReelRepository)
+	interests := new(MockInterestStore)
+	svc := newTestReelService(mockRepo, new(MockBroadcaster))
+	svc.SetInterestStore(interests)
+
+	ctx := context.Background()
+	reel := &models.Reel{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), Category: "cooking", Duration: 10}
+	viewerID := primitive.NewObjectID()
+
+	mockRepo.On("GetReelByID", ctx, reel.ID).Return(reel, nil)
+	mockRepo.On("AddEngagement", ctx, reel.ID, 0.5, int64(0)).Return(nil)
+	interests.On("GetInterests", ctx, viewerID).Return(nil, nil)
+	interests.On("SaveInterests", ctx, mock.MatchedBy(func(in *recommend.Interests) bool {
+		return in.UserID == viewerID && in.Topics["cooking"] == 0.5 && in.Creators[reel.UserID.Hex()] == 0.5
+	})).Return(nil)
+	interests.On("MarkSeen", ctx, viewerID, []primitive.ObjectID{reel.ID}).Return(nil)
+
+	err := svc.ApplyEngagement(ctx, recommend.Event{
+		ReelID:      reel.ID.Hex(),
+		UserID:      viewerID.Hex(),
+		Signal:      recommend.SignalWatch,
+		WatchTimeMs: 5000,
+		OccurredAt:  time.Now(),
+	})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	interests.AssertExpectations(t)
+}
+
+func newTestLiveService(store *MockLiveStore, viewers *MockViewerTracker) *ReelService {
+	svc := newTestReelService(new(MockReelRepository), nil)
+	svc.SetLiveStreaming(LiveStreaming{
+		Store:   store,
+		Viewers: viewers,
+		Media:   live.NewNginxRTMP("rtmp://media/live", "https://media/hls", ""),
+	})
+	return svc
+}
+
+func TestCreateLiveSession_GivesBroadcasterIngest(t *testing.T) {
+	store := new(MockLiveStore)
+	svc := newTestLiveService(store, new(MockViewerTracker))
+
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+
+	store.On("GetActiveSession", ctx, userID).Return(nil, mongo.ErrNoDocuments)
+	store.On("CreateSession", ctx, mock.AnythingOfType("*models.LiveSession")).Return(nil)
+
+	session, err := svc.CreateLiveSession(ctx, userID, "  Cooking live  ", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Cooking live", session.Title)
+	assert.Equal(t, models.PrivacySettingPublic, session.Privacy)
+	assert.Equal(t, models.LiveStatusIdle, session.Status)
+	assert.Len(t, session.StreamKey, 40)
+	assert.Equal(t, "rtmp://media/live", session.IngestURL)
+	store.AssertExpectations(t)
+}
+
+func TestCreateLiveSession_OneActivePerUser(t *testing.T) {
+	store := new(MockLiveStore)
+	svc := newTestLiveService(store, new(MockViewerTracker))
+
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+
+	store.On("GetActiveSession", ctx, userID).Return(&models.LiveSession{UserID: userID, Status: models.LiveStatusLive}, nil)
+
+	_, err := svc.CreateLiveSession(ctx, userID, "Again", models.PrivacySettingPublic)
+
+	assert.ErrorIs(t, err, ErrLiveSessionActive)
+	store.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything)
+}
+
+func TestGetLiveSession_HidesStreamKeyFromViewers(t *testing.T) {
+	store := new(MockLiveStore)
+	viewers := new(MockViewerTracker)
+	svc := newTestLiveService(store, viewers)
+
+	ctx := context.Background()
+	session := &models.LiveSession{
+		ID:        primitive.NewObjectID(),
+		UserID:    primitive.NewObjectID(),
+		Privacy:   models.PrivacySettingPublic,
+		Status:    models.LiveStatusLive,
+		StreamKey: "secret",
+	}
+
+	store.On("GetSession", ctx, session.ID).Return(session, nil)
+	viewers.On("Count", ctx, session.ID.Hex()).Return(int64(7), nil)
+
+	got, err := svc.GetLiveSession(ctx, session.ID, primitive.NewObjectID())
+
+	assert.NoError(t, err)
+	assert.Empty(t, got.StreamKey)
+	assert.Empty(t, got.IngestURL)
+	assert.Equal(t, int64(7), got.ViewerCount)
+}
+
+func TestGetLiveSession_FriendsOnlyHiddenWithoutFriends(t *testing.T) {
+	store := new(MockLiveStore)
+	svc := newTestLiveService(store, new(MockViewerTracker))
+
+	ctx := context.Background()
+	session := &models.LiveSession{
+		ID:      primitive.NewObjectID(),
+		UserID:  primitive.NewObjectID(),
+		Privacy: models.PrivacySettingFriends,
+		Status:  models.LiveStatusLive,
+	}
+
+	store.On("GetSession", ctx, session.ID).Return(session, nil)
+
+	_, err := svc.GetLiveSession(ctx, session.ID, primitive.NewObjectID())
+
+	assert.ErrorIs(t, err, ErrLiveSessionNotFound)
+}
+
+func TestStreamPublished_RenamesStreamToSession(t *testing.T) {
+	store := new(MockLiveStore)
+	svc := newTestLiveService(store, new(MockViewerTracker))
+
+	ctx := context.Background()
+	session := &models.LiveSession{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), Status: models.LiveStatusIdle}
+
+	store.On("GetSessionByStreamKey", ctx, "key").Return(session, nil)
+	store.On("GetSessionByStreamKey", ctx, "unknown").Return(nil, mongo.ErrNoDocuments)
+	store.On("StartSession", ctx, session.ID, "https://media/hls/"+session.ID.Hex()+".m3u8", mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+	store.On("StartSession", ctx, session.ID, mock.Anything, mock.Anything).Return(false, nil)
+
+	stream, err := svc.StreamPublished(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, session.ID.Hex(), stream)
+
+	// Keys work once
+	_, err = svc.StreamPublished(ctx, "key")
+	assert.ErrorIs(t, err, ErrInvalidStreamKey)
+
+	_, err = svc.StreamPublished(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrInvalidStreamKey)
+}
+
+func TestSendLiveChatMessage_DeliversToViewers(t *testing.T) {
+	store := new(MockLiveStore)
+	viewers := new(MockViewerTracker)
+	realtime := new(MockRealtimePublisher)
+	svc := newTestLiveService(store, viewers)
+	svc.SetRealtimePublisher(realtime)
+
+	ctx := context.Background()
+	session := &models.LiveSession{
+		ID:      primitive.NewObjectID(),
+		UserID:  primitive.NewObjectID(),
+		Privacy: models.PrivacySettingPublic,
+		Status:  models.LiveStatusLive,
+	}
+	viewerID := primitive.NewObjectID()
+
+	store.On("GetSession", ctx, session.ID).Return(session, nil)
+	store.On("AddChatMessage", ctx, mock.AnythingOfType("*models.LiveChatMessage")).Return(nil)
+	viewers.On("Viewers", ctx, session.ID.Hex()).Return([]string{viewerID.Hex(), session.UserID.Hex()}, nil)
+	realtime.On("PublishLiveEvent", ctx, producer.EventLiveChatMessage, mock.MatchedBy(func(e producer.LiveEvent) bool {
+		return e.SessionID == session.ID.Hex() && e.Message != nil && e.Message.Content == "hello"
+	}), []string{session.UserID.Hex(), viewerID.Hex()}).Return(nil)
+
+	_, err := svc.SendLiveChatMessage(ctx, session.ID, viewerID, "   ")
+	assert.ErrorIs(t, err, ErrInvalidLiveChat)
+
+	message, err := svc.SendLiveChatMessage(ctx, session.ID, viewerID, " hello ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, viewerID, message.UserID)
+	store.AssertExpectations(t)
+	realtime.AssertExpectations(t)
+}
+
+func TestEndLiveSession_OnlyBroadcaster(t *testing.T) {
+	store := new(MockLiveStore)
+	viewers := new(MockViewerTracker)
+	realtime := new(MockRealtimePublisher)
+	svc := newTestLiveService(store, viewers)
+	svc.SetRealtimePublisher(realtime)
+
+	ctx := context.Background()
+	session := &models.LiveSession{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), Status: models.LiveStatusLive}
+
+	store.On("GetSession", ctx, session.ID).Return(session, nil)
+	store.On("EndSession", ctx, session.ID, mock.AnythingOfType("time.Time")).Return(true, nil)
+	viewers.On("Viewers", ctx, session.ID.Hex()).Return([]string{}, nil)
+	viewers.On("Clear", ctx, session.ID.Hex()).Return(nil)
+	realtime.On("PublishLiveEvent", ctx, producer.EventLiveEnded, producer.LiveEvent{SessionID: session.ID.Hex()}, []string{session.UserID.Hex()}).Return(nil)
+
+	_, err := svc.EndLiveSession(ctx, session.ID, primitive.NewObjectID())
+	assert.ErrorIs(t, err, ErrNotLiveBroadcaster)
+
+	ended, err := svc.EndLiveSession(ctx, session.ID, session.UserID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.LiveStatusEnded, ended.Status)
+	assert.NotNil(t, ended.EndedAt)
+	viewers.AssertExpectations(t)
+	realtime.AssertExpectations(t)
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	storagepb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/storage/v1"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/resilience"
+	"google.golang.org/grpc"
+)
+
+// Client talks to storage-service, which owns the object store
+type Client struct {
+	conn   *grpc.ClientConn
+	client storagepb.StorageServiceClient
+	bucket string
+}
+
+func NewClient(host, port, bucket string) (*Client, error) {
+	// Uploads of transcoded renditions can be large, so only the breaker and
+	// read retries apply, not a timeout
+	conn, err := resilience.NewGRPCClient("storage-service").
+		WithRetry(resilience.DefaultRetryPolicy(), resilience.ReadOnlyMethods...).
+		Dial(net.JoinHostPort(host, port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to storage-service: %w", err)
+	}
+
+	return &Client{
+		conn:   conn,
+		client: storagepb.NewStorageServiceClient(conn),
+		bucket: bucket,
+	}, nil
+}
+
+// Upload stores data and returns its URL
+func (c *Client) Upload(ctx context.Context, data []byte, filename, contentType string) (string, error) {
+	resp, err := c.client.Upload(ctx, &storagepb.UploadRequest{
+		Data:        data,
+		Filename:    filename,
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Url, nil
+}
+
+// SignURL returns a short-lived download URL for an object in the uploads
+// bucket, which is private. URLs outside the bucket are returned unchanged.
+func (c *Client) SignURL(ctx context.Context, fileURL string, expiry time.Duration) (string, error) {
+	prefix := "/" + c.bucket + "/"
+	idx := strings.Index(fileURL, prefix)
+	if idx == -1 {
+		return fileURL, nil
+	}
+
+	resp, err := c.client.GetPresignedURL(ctx, &storagepb.GetPresignedURLRequest{
+		Key:           fileURL[idx+len(prefix):],
+		ExpirySeconds: int64(expiry.Seconds()),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Url, nil
+}
+
+func (c *Client) Close() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
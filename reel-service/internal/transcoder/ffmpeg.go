@@ -0,0 +1,130 @@
+package transcoder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FFmpeg runs the ffmpeg and ffprobe binaries
+type FFmpeg struct {
+	FFmpegPath      string
+	FFprobePath     string
+	SegmentDuration int // seconds per HLS segment
+}
+
+func NewFFmpeg(ffmpegPath, ffprobePath string) *FFmpeg {
+	return &FFmpeg{
+		FFmpegPath:      ffmpegPath,
+		FFprobePath:     ffprobePath,
+		SegmentDuration: 4,
+	}
+}
+
+type probeOutput struct {
+	Streams []struct {
+		Width  int               `json:"width"`
+		Height int               `json:"height"`
+		Tags   map[string]string `json:"tags"`
+		// Phones record portrait video as rotated landscape
+		SideDataList []struct {
+			Rotation int `json:"rotation"`
+		} `json:"side_data_list"`
+	} `json:"streams"`
+}
+
+// Probe returns the display size of the first video stream of input
+func (f *FFmpeg) Probe(ctx context.Context, input string) (*SourceInfo, error) {
+	out, err := f.run(ctx, f.FFprobePath,
+		"-v", "error",
+		"-select_streams", "v:0",
+		"-show_entries", "stream=width,height:stream_tags=rotate:stream_side_data=rotation",
+		"-of", "json",
+		input,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return parseProbe(out)
+}
+
+func parseProbe(out []byte) (*SourceInfo, error) {
+	var probe probeOutput
+	if err := json.Unmarshal(out, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse ffprobe output: %w", err)
+	}
+	if len(probe.Streams) == 0 || probe.Streams[0].Width == 0 || probe.Streams[0].Height == 0 {
+		return nil, fmt.Errorf("no video stream found")
+	}
+
+	stream := probe.Streams[0]
+	rotation, _ := strconv.Atoi(stream.Tags["rotate"])
+	for _, sd := range stream.SideDataList {
+		if sd.Rotation != 0 {
+			rotation = sd.Rotation
+		}
+	}
+
+	info := &SourceInfo{Width: stream.Width, Height: stream.Height}
+	if rotation%180 != 0 {
+		info.Width, info.Height = info.Height, info.Width
+	}
+	return info, nil
+}
+
+// Transcode writes one HLS rendition of input to dir as index.m3u8 and
+// numbered .ts segments
+func (f *FFmpeg) Transcode(ctx context.Context, input, dir string, r Rendition, width, height int) error {
+	_, err := f.run(ctx, f.FFmpegPath, f.renditionArgs(input, dir, r, width, height)...)
+	return err
+}
+
+func (f *FFmpeg) renditionArgs(input, dir string, r Rendition, width, height int) []string {
+	segment := strconv.Itoa(f.SegmentDuration)
+	return []string{
+		"-hide_banner", "-loglevel", "error", "-y",
+		"-i", input,
+		"-map", "0:v:0", "-map", "0:a:0?",
+		"-vf", fmt.Sprintf("scale=%d:%d", width, height),
+		"-c:v", "libx264", "-preset", "veryfast", "-profile:v", "main",
+		"-b:v", strconv.Itoa(r.VideoBitrate),
+		"-maxrate", strconv.Itoa(r.VideoBitrate * 107 / 100),
+		"-bufsize", strconv.Itoa(r.VideoBitrate * 3 / 2),
+		// Keyframes on segment boundaries so every rendition switches cleanly
+		"-force_key_frames", "expr:gte(t,n_forced*" + segment + ")",
+		"-sc_threshold", "0",
+		"-c:a", "aac", "-b:a", strconv.Itoa(r.AudioBitrate), "-ac", "2",
+		"-f", "hls",
+		"-hls_time", segment,
+		"-hls_playlist_type", "vod",
+		"-hls_segment_filename", filepath.Join(dir, "seg_%04d.ts"),
+		filepath.Join(dir, "index.m3u8"),
+	}
+}
+
+func (f *FFmpeg) run(ctx context.Context, binary string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", filepath.Base(binary), err, lastLine(msg))
+		}
+		return nil, fmt.Errorf("%s: %w", filepath.Base(binary), err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// lastLine keeps ffmpeg errors short enough to store on the reel
+func lastLine(s string) string {
+	if i := strings.LastIndexByte(s, '\n'); i != -1 {
+		return s[i+1:]
+	}
+	return s
+}
@@ -0,0 +1,41 @@
+package transcoder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProbe(t *testing.T) {
+	info, err := parseProbe([]byte(`{"streams":[{"width":1920,"height":1080}]}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, SourceInfo{Width: 1920, Height: 1080}, *info)
+}
+
+func TestParseProbe_RotatedPhoneVideo(t *testing.T) {
+	info, err := parseProbe([]byte(`{"streams":[{"width":1920,"height":1080,"side_data_list":[{"rotation":-90}]}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, SourceInfo{Width: 1080, Height: 1920}, *info)
+
+	info, err = parseProbe([]byte(`{"streams":[{"width":1920,"height":1080,"tags":{"rotate":"90"}}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, SourceInfo{Width: 1080, Height: 1920}, *info)
+}
+
+func TestParseProbe_NoVideo(t *testing.T) {
+	_, err := parseProbe([]byte(`{"streams":[]}`))
+
+	assert.Error(t, err)
+}
+
+func TestRenditionArgs(t *testing.T) {
+	f := NewFFmpeg("ffmpeg", "ffprobe")
+	args := f.renditionArgs("https://storage/video.mp4", "/tmp/out", DefaultLadder[1], 720, 1280)
+
+	assert.Contains(t, args, "scale=720:1280")
+	assert.Contains(t, args, "2800000")
+	assert.Contains(t, args, "/tmp/out/seg_%04d.ts")
+	assert.Equal(t, "/tmp/out/index.m3u8", args[len(args)-1])
+}
@@ -0,0 +1,58 @@
+package transcoder
+
+import "math"
+
+// Rendition is one rung of the adaptive bitrate ladder. Height is the short
+// side of the output, so "720p" is 720 pixels wide for a portrait reel.
+type Rendition struct {
+	Name         string
+	Height       int
+	VideoBitrate int // bits per second
+	AudioBitrate int // bits per second
+}
+
+// DefaultLadder is ordered from highest to lowest quality
+var DefaultLadder = []Rendition{
+	{Name: "1080p", Height: 1080, VideoBitrate: 5_000_000, AudioBitrate: 128_000},
+	{Name: "720p", Height: 720, VideoBitrate: 2_800_000, AudioBitrate: 128_000},
+	{Name: "480p", Height: 480, VideoBitrate: 1_400_000, AudioBitrate: 96_000},
+	{Name: "360p", Height: 360, VideoBitrate: 800_000, AudioBitrate: 96_000},
+}
+
+// selectLadder drops the rungs above the source's short side so nothing is
+// upscaled. A source smaller than every rung still gets the lowest one.
+func selectLadder(ladder []Rendition, shortSide int) []Rendition {
+	var selected []Rendition
+	for _, r := range ladder {
+		if r.Height <= shortSide {
+			selected = append(selected, r)
+		}
+	}
+	if len(selected) == 0 && len(ladder) > 0 {
+		selected = append(selected, ladder[len(ladder)-1])
+	}
+	return selected
+}
+
+// SourceInfo describes the uploaded video as displayed, after rotation
+type SourceInfo struct {
+	Width  int
+	Height int
+}
+
+func (s SourceInfo) shortSide() int {
+	return min(s.Width, s.Height)
+}
+
+// scaledTo returns the output size with the given short side, keeping the
+// aspect ratio. Both sides are even, as H.264 requires.
+func (s SourceInfo) scaledTo(shortSide int) (width, height int) {
+	if s.Width <= s.Height {
+		return even(shortSide), even(float64(s.Height) * float64(shortSide) / float64(s.Width))
+	}
+	return even(float64(s.Width) * float64(shortSide) / float64(s.Height)), even(shortSide)
+}
+
+func even[T int | float64](v T) int {
+	return int(math.Round(float64(v)/2)) * 2
+}
@@ -0,0 +1,43 @@
+package transcoder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectLadder_DropsRungsAboveSource(t *testing.T) {
+	selected := selectLadder(DefaultLadder, 720)
+
+	names := make([]string, len(selected))
+	for i, r := range selected {
+		names[i] = r.Name
+	}
+	assert.Equal(t, []string{"720p", "480p", "360p"}, names)
+}
+
+func TestSelectLadder_KeepsLowestRungForTinySource(t *testing.T) {
+	selected := selectLadder(DefaultLadder, 240)
+
+	assert.Len(t, selected, 1)
+	assert.Equal(t, "360p", selected[0].Name)
+}
+
+func TestScaledTo_Portrait(t *testing.T) {
+	width, height := SourceInfo{Width: 1080, Height: 1920}.scaledTo(720)
+
+	assert.Equal(t, 720, width)
+	assert.Equal(t, 1280, height)
+}
+
+func TestScaledTo_LandscapeRoundsToEven(t *testing.T) {
+	width, height := SourceInfo{Width: 1000, Height: 750}.scaledTo(360)
+
+	assert.Equal(t, 480, width)
+	assert.Equal(t, 360, height)
+
+	width, height = SourceInfo{Width: 1366, Height: 768}.scaledTo(480)
+	assert.Equal(t, 0, width%2)
+	assert.Equal(t, 854, width)
+	assert.Equal(t, 480, height)
+}
@@ -0,0 +1,142 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"golang.org/x/sync/errgroup"
+)
+
+// sourceURLExpiry bounds how long ffmpeg may keep reading the upload
+const sourceURLExpiry = time.Hour
+
+// segmentUploadConcurrency bounds parallel segment uploads per rendition
+const segmentUploadConcurrency = 4
+
+// Storage stores transcoded files and grants access to uploads
+type Storage interface {
+	Upload(ctx context.Context, data []byte, filename, contentType string) (string, error)
+	SignURL(ctx context.Context, fileURL string, expiry time.Duration) (string, error)
+}
+
+// Result is a reel's HLS output
+type Result struct {
+	PlaybackURL string
+	Renditions  []models.ReelRendition
+}
+
+// Pipeline transcodes an uploaded reel into HLS renditions and stores them
+type Pipeline struct {
+	ffmpeg  *FFmpeg
+	storage Storage
+	workDir string
+	ladder  []Rendition
+}
+
+func NewPipeline(ffmpeg *FFmpeg, storage Storage, workDir string) *Pipeline {
+	return &Pipeline{
+		ffmpeg:  ffmpeg,
+		storage: storage,
+		workDir: workDir,
+		ladder:  DefaultLadder,
+	}
+}
+
+// Transcode builds every rendition the source supports and returns the URL
+// of the master playlist
+func (p *Pipeline) Transcode(ctx context.Context, reelID, sourceURL string) (*Result, error) {
+	dir, err := os.MkdirTemp(p.workDir, "reel-"+reelID+"-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input, err := p.storage.SignURL(ctx, sourceURL, sourceURLExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign source URL: %w", err)
+	}
+
+	source, err := p.ffmpeg.Probe(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to probe source: %w", err)
+	}
+
+	var renditions []models.ReelRendition
+	for _, r := range selectLadder(p.ladder, source.shortSide()) {
+		width, height := source.scaledTo(r.Height)
+		out := filepath.Join(dir, r.Name)
+		if err := os.Mkdir(out, 0o755); err != nil {
+			return nil, err
+		}
+		if err := p.ffmpeg.Transcode(ctx, input, out, r, width, height); err != nil {
+			return nil, fmt.Errorf("failed to transcode %s: %w", r.Name, err)
+		}
+
+		playlistURL, err := p.publishRendition(ctx, reelID, r.Name, out)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store %s: %w", r.Name, err)
+		}
+		renditions = append(renditions, models.ReelRendition{
+			Name:        r.Name,
+			Width:       width,
+			Height:      height,
+			Bitrate:     r.VideoBitrate + r.AudioBitrate,
+			PlaylistURL: playlistURL,
+		})
+	}
+
+	playbackURL, err := p.storage.Upload(ctx, []byte(masterPlaylist(renditions)), reelID+".m3u8", playlistContentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store master playlist: %w", err)
+	}
+	return &Result{PlaybackURL: playbackURL, Renditions: renditions}, nil
+}
+
+// publishRendition uploads the segments in dir, then the media playlist
+// rewritten to their URLs
+func (p *Pipeline) publishRendition(ctx context.Context, reelID, name, dir string) (string, error) {
+	playlist, err := os.ReadFile(filepath.Join(dir, "index.m3u8"))
+	if err != nil {
+		return "", err
+	}
+	segments, err := filepath.Glob(filepath.Join(dir, "*.ts"))
+	if err != nil {
+		return "", err
+	}
+
+	var mu sync.Mutex
+	segmentURLs := make(map[string]string, len(segments))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(segmentUploadConcurrency)
+	for _, segment := range segments {
+		g.Go(func() error {
+			data, err := os.ReadFile(segment)
+			if err != nil {
+				return err
+			}
+			base := filepath.Base(segment)
+			url, err := p.storage.Upload(gctx, data, fmt.Sprintf("%s-%s-%s", reelID, name, base), segmentContentType)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			segmentURLs[base] = url
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return "", err
+	}
+
+	rewritten, err := rewriteMediaPlaylist(string(playlist), segmentURLs)
+	if err != nil {
+		return "", err
+	}
+	return p.storage.Upload(ctx, []byte(rewritten), fmt.Sprintf("%s-%s.m3u8", reelID, name), playlistContentType)
+}
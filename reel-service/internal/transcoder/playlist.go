@@ -0,0 +1,44 @@
+package transcoder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+const (
+	playlistContentType = "application/vnd.apple.mpegurl"
+	segmentContentType  = "video/mp2t"
+)
+
+// rewriteMediaPlaylist points every segment of an HLS media playlist at its
+// uploaded URL. Storage assigns its own object names, so the relative names
+// ffmpeg writes would not resolve.
+func rewriteMediaPlaylist(playlist string, segmentURLs map[string]string) (string, error) {
+	lines := strings.Split(playlist, "\n")
+	for i, line := range lines {
+		uri := strings.TrimSpace(line)
+		if uri == "" || strings.HasPrefix(uri, "#") {
+			continue
+		}
+		url, ok := segmentURLs[uri]
+		if !ok {
+			return "", fmt.Errorf("segment %s was not uploaded", uri)
+		}
+		lines[i] = url
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// masterPlaylist lists the renditions of a reel, highest quality first
+func masterPlaylist(renditions []models.ReelRendition) string {
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n")
+	for _, r := range renditions {
+		fmt.Fprintf(&b, "#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%dx%d,NAME=%q\n", r.Bitrate, r.Width, r.Height, r.Name)
+		b.WriteString(r.PlaylistURL)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,56 @@
+package transcoder
+
+import (
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mediaPlaylist = `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-TARGETDURATION:4
+#EXT-X-PLAYLIST-TYPE:VOD
+#EXTINF:4.000000,
+seg_0000.ts
+#EXTINF:2.500000,
+seg_0001.ts
+#EXT-X-ENDLIST
+`
+
+func TestRewriteMediaPlaylist(t *testing.T) {
+	rewritten, err := rewriteMediaPlaylist(mediaPlaylist, map[string]string{
+		"seg_0000.ts": "https://cdn.example.com/uploads/a.ts",
+		"seg_0001.ts": "https://cdn.example.com/uploads/b.ts",
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, rewritten, "#EXTINF:4.000000,\nhttps://cdn.example.com/uploads/a.ts\n")
+	assert.Contains(t, rewritten, "#EXTINF:2.500000,\nhttps://cdn.example.com/uploads/b.ts\n")
+	assert.NotContains(t, rewritten, "seg_")
+	assert.Contains(t, rewritten, "#EXT-X-ENDLIST")
+}
+
+func TestRewriteMediaPlaylist_MissingSegment(t *testing.T) {
+	_, err := rewriteMediaPlaylist(mediaPlaylist, map[string]string{
+		"seg_0000.ts": "https://cdn.example.com/uploads/a.ts",
+	})
+
+	assert.Error(t, err)
+}
+
+func TestMasterPlaylist(t *testing.T) {
+	master := masterPlaylist([]models.ReelRendition{
+		{Name: "720p", Width: 720, Height: 1280, Bitrate: 2_928_000, PlaylistURL: "https://cdn.example.com/720.m3u8"},
+		{Name: "360p", Width: 360, Height: 640, Bitrate: 896_000, PlaylistURL: "https://cdn.example.com/360.m3u8"},
+	})
+
+	assert.Equal(t, `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-STREAM-INF:BANDWIDTH=2928000,RESOLUTION=720x1280,NAME="720p"
+https://cdn.example.com/720.m3u8
+#EXT-X-STREAM-INF:BANDWIDTH=896000,RESOLUTION=360x640,NAME="360p"
+https://cdn.example.com/360.m3u8
+`, master)
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+type Config struct {
+	// Servers
+	ServerPort string
+	GRPCPort   string
+
+	// Elasticsearch
+	ElasticURLs        []string
+	ElasticUser        string
+	ElasticPassword    string
+	ElasticIndexPrefix string
+	ElasticShards      int
+	ElasticReplicas    int
+
+	// Kafka
+	KafkaBrokers       []string
+	IndexerGroupPrefix string
+
+	// User Service (for friend lists used in permission filtering)
+	UserServiceHost string
+	UserServicePort string
+
+	// Auth & Rate Limiting
+	JWTSecret        string
+	JWKSURL          string    // Key set access tokens are signed by, published by user-service
+	LegacyHS256Until time.Time // Until then JWTSecret tokens are still accepted alongside
+	RedisURLs        []string
+	RedisPass        string
+	RateLimitEnabled bool
+	RateLimitLimit   float64
+	RateLimitBurst   int
+
+	CORSAllowedOrigins []string
+
+	// Observability
+	JaegerOTLPEndpoint string
+}
+
+func Load() *Config {
+	godotenv.Load()
+
+	rateLimitEnabled, _ := strconv.ParseBool(getEnv("RATE_LIMIT_ENABLED", "true"))
+	rateLimitLimit, _ := strconv.ParseFloat(getEnv("RATE_LIMIT_LIMIT", "50"), 64)
+	rateLimitBurst, _ := strconv.Atoi(getEnv("RATE_LIMIT_BURST", "100"))
+
+	corsOrigins := strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173"), ",")
+	for i := range corsOrigins {
+		corsOrigins[i] = strings.TrimSpace(corsOrigins[i])
+	}
+
+	legacyHS256Until, _ := time.Parse(time.RFC3339, getEnv("JWT_LEGACY_HS256_UNTIL", ""))
+
+	return &Config{
+		ServerPort: getEnv("SERVER_PORT", "8089"),
+		GRPCPort:   getEnv("GRPC_PORT", "9089"),
+
+		// Elasticsearch
+		ElasticURLs:        strings.Split(getEnv("ELASTICSEARCH_URLS", "http://localhost:9200"), ","),
+		ElasticUser:        getEnv("ELASTICSEARCH_USER", ""),
+		ElasticPassword:    getEnv("ELASTICSEARCH_PASSWORD", ""),
+		ElasticIndexPrefix: getEnv("ELASTICSEARCH_INDEX_PREFIX", "connectify"),
+		ElasticShards:      getEnvInt("ELASTICSEARCH_SHARDS", 3),
+		ElasticReplicas:    getEnvInt("ELASTICSEARCH_REPLICAS", 1),
+
+		// Kafka
+		KafkaBrokers:       strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
+		IndexerGroupPrefix: getEnv("INDEXER_GROUP_PREFIX", "search-service-indexer"),
+
+		// User Service
+		UserServiceHost: getEnv("USER_SERVICE_HOST", "localhost"),
+		UserServicePort: getEnv("USER_SERVICE_PORT", "9083"),
+
+		// Auth & Rate limiting
+		JWTSecret:          getEnv("JWT_SECRET", "very-secret-key"),
+		JWKSURL:            getEnv("JWKS_URL", ""),
+		LegacyHS256Until:   legacyHS256Until,
+		RedisURLs:          strings.Split(getEnv("REDIS_URL", "localhost:6379"), ","),
+		RedisPass:          getEnv("REDIS_PASS", ""),
+		RateLimitEnabled:   rateLimitEnabled,
+		RateLimitLimit:     rateLimitLimit,
+		RateLimitBurst:     rateLimitBurst,
+		CORSAllowedOrigins: corsOrigins,
+
+		JaegerOTLPEndpoint: getEnv("JAEGER_OTLP_ENDPOINT", "localhost:4317"),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	if value := os.Getenv(key); value != "" {
+		if intVal, err := strconv.Atoi(value); err == nil {
+			return intVal
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,267 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// ErrStaleVersion is returned when a write is older than the indexed document
+var ErrStaleVersion = errors.New("stale document version")
+
+type Config struct {
+	URLs        []string
+	Username    string
+	Password    string
+	IndexPrefix string
+	Shards      int
+	Replicas    int
+}
+
+// Client wraps the Elasticsearch cluster with one index per entity type
+type Client struct {
+	es     *elasticsearch.Client
+	cfg    Config
+	logger *slog.Logger
+}
+
+func NewClient(cfg Config, logger *slog.Logger) (*Client, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	es, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: cfg.URLs,
+		Username:  cfg.Username,
+		Password:  cfg.Password,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create elasticsearch client: %w", err)
+	}
+	return &Client{es: es, cfg: cfg, logger: logger}, nil
+}
+
+// IndexName returns the index holding an entity type, e.g. "connectify-posts"
+func (c *Client) IndexName(entity models.SearchEntityType) string {
+	return c.cfg.IndexPrefix + "-" + string(entity) + "s"
+}
+
+// EntityForIndex maps an index name back to its entity type
+func (c *Client) EntityForIndex(index string) (models.SearchEntityType, bool) {
+	for _, entity := range models.SearchEntityTypes {
+		if c.IndexName(entity) == index {
+			return entity, true
+		}
+	}
+	return "", false
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	res, err := c.es.Ping(c.es.Ping.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("elasticsearch ping failed: %s", res.Status())
+	}
+	return nil
+}
+
+// EnsureIndices creates any missing entity index with its mapping
+func (c *Client) EnsureIndices(ctx context.Context) error {
+	for _, entity := range models.SearchEntityTypes {
+		index := c.IndexName(entity)
+
+		res, err := c.es.Indices.Exists([]string{index}, c.es.Indices.Exists.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to check index %s: %w", index, err)
+		}
+		res.Body.Close()
+		if res.StatusCode == http.StatusOK {
+			continue
+		}
+
+		body, err := json.Marshal(indexDefinition(entity, c.cfg.Shards, c.cfg.Replicas))
+		if err != nil {
+			return err
+		}
+		res, err = c.es.Indices.Create(index,
+			c.es.Indices.Create.WithContext(ctx),
+			c.es.Indices.Create.WithBody(bytes.NewReader(body)),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create index %s: %w", index, err)
+		}
+		if err := decodeError(res); err != nil {
+			// Another replica may have won the race to create it
+			if res.StatusCode != http.StatusBadRequest {
+				return fmt.Errorf("failed to create index %s: %w", index, err)
+			}
+		}
+		c.logger.Info("Created search index", "index", index)
+	}
+	return nil
+}
+
+// Index writes a document using external versioning so that redelivered or
+// reordered Kafka messages never overwrite a newer version of the document.
+func (c *Client) Index(ctx context.Context, entity models.SearchEntityType, id string, version int64, doc any) error {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	res, err := c.es.Index(c.IndexName(entity), bytes.NewReader(body),
+		c.es.Index.WithContext(ctx),
+		c.es.Index.WithDocumentID(id),
+		c.es.Index.WithVersion(int(version)),
+		c.es.Index.WithVersionType("external_gte"),
+	)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode == http.StatusConflict {
+		res.Body.Close()
+		return ErrStaleVersion
+	}
+	return decodeError(res)
+}
+
+// Delete removes a document. Missing documents are not an error.
+func (c *Client) Delete(ctx context.Context, entity models.SearchEntityType, id string, version int64) error {
+	res, err := c.es.Delete(c.IndexName(entity), id,
+		c.es.Delete.WithContext(ctx),
+		c.es.Delete.WithVersion(int(version)),
+		c.es.Delete.WithVersionType("external_gte"),
+	)
+	if err != nil {
+		return err
+	}
+	switch res.StatusCode {
+	case http.StatusNotFound:
+		res.Body.Close()
+		return nil
+	case http.StatusConflict:
+		res.Body.Close()
+		return ErrStaleVersion
+	}
+	return decodeError(res)
+}
+
+// SearchResult is the subset of the search response the service consumes
+type SearchResult struct {
+	Took     int64 `json:"took"`
+	TimedOut bool  `json:"timed_out"`
+	Shards   struct {
+		Failed int `json:"failed"`
+	} `json:"_shards"`
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			Index     string              `json:"_index"`
+			ID        string              `json:"_id"`
+			Score     float64             `json:"_score"`
+			Source    json.RawMessage     `json:"_source"`
+			Highlight map[string][]string `json:"highlight"`
+		} `json:"hits"`
+	} `json:"hits"`
+	Status int `json:"status"` // Set per response in multi-search
+}
+
+// Search runs a query across the given indices
+func (c *Client) Search(ctx context.Context, indices []string, query map[string]any) (*SearchResult, error) {
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.es.Search(
+		c.es.Search.WithContext(ctx),
+		c.es.Search.WithIndex(indices...),
+		c.es.Search.WithBody(bytes.NewReader(body)),
+		c.es.Search.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, responseError(res)
+	}
+
+	var result SearchResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode search response: %w", err)
+	}
+	return &result, nil
+}
+
+// MultiSearchRequest is one query in a multi-search round trip
+type MultiSearchRequest struct {
+	Index string
+	Query map[string]any
+}
+
+// MultiSearch runs several queries in one round trip. A failed query yields a
+// result with a non-200 Status rather than failing the batch.
+func (c *Client) MultiSearch(ctx context.Context, requests []MultiSearchRequest) ([]SearchResult, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range requests {
+		if err := enc.Encode(map[string]any{"index": r.Index, "ignore_unavailable": true}); err != nil {
+			return nil, err
+		}
+		if err := enc.Encode(r.Query); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := c.es.Msearch(&buf, c.es.Msearch.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, responseError(res)
+	}
+
+	var result struct {
+		Responses []SearchResult `json:"responses"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode multi-search response: %w", err)
+	}
+	return result.Responses, nil
+}
+
+func decodeError(res *esapi.Response) error {
+	defer res.Body.Close()
+	if !res.IsError() {
+		io.Copy(io.Discard, res.Body)
+		return nil
+	}
+	return responseError(res)
+}
+
+func responseError(res *esapi.Response) error {
+	var e struct {
+		Error struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&e); err != nil || e.Error.Type == "" {
+		return fmt.Errorf("elasticsearch error: %s", res.Status())
+	}
+	return fmt.Errorf("elasticsearch error [%s]: %s: %s", strconv.Itoa(res.StatusCode), e.Error.Type, e.Error.Reason)
+}
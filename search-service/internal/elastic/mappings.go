@@ -0,0 +1,86 @@
+package elastic
+
+import "github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+// Field sets per entity. Names and titles use search_as_you_type so the
+// global search bar matches on prefixes; long-form text uses the standard
+// analyzer. Identifiers and permission fields are keywords for exact filters.
+var (
+	keyword   = map[string]any{"type": "keyword"}
+	text      = map[string]any{"type": "text"}
+	asYouType = map[string]any{"type": "search_as_you_type"}
+	date      = map[string]any{"type": "date"}
+	long      = map[string]any{"type": "long"}
+	boolean   = map[string]any{"type": "boolean"}
+	unindexed = map[string]any{"type": "keyword", "index": false}
+)
+
+var entityProperties = map[models.SearchEntityType]map[string]any{
+	models.SearchEntityUser: {
+		"id":         keyword,
+		"username":   asYouType,
+		"full_name":  asYouType,
+		"avatar":     unindexed,
+		"bio":        text,
+		"location":   text,
+		"is_active":  boolean,
+		"updated_at": date,
+	},
+	models.SearchEntityPost: {
+		"id":              keyword,
+		"author_id":       keyword,
+		"author_username": asYouType,
+		"content":         text,
+		"hashtags":        keyword,
+		"location":        text,
+		"privacy":         keyword,
+		"status":          keyword,
+		"community_id":    keyword,
+		"audience":        keyword,
+		"total_reactions": long,
+		"created_at":      date,
+	},
+	models.SearchEntityEvent: {
+		"id":          keyword,
+		"title":       asYouType,
+		"description": text,
+		"location":    text,
+		"category":    keyword,
+		"cover_image": unindexed,
+		"privacy":     keyword,
+		"creator_id":  keyword,
+		"member_ids":  keyword,
+		"start_date":  date,
+		"end_date":    date,
+		"going_count": long,
+		"created_at":  date,
+	},
+	models.SearchEntityListing: {
+		"id":            keyword,
+		"seller_id":     keyword,
+		"title":         asYouType,
+		"description":   text,
+		"category_slug": keyword,
+		"category_name": text,
+		"tags":          keyword,
+		"price":         map[string]any{"type": "scaled_float", "scaling_factor": 100},
+		"currency":      keyword,
+		"city":          text,
+		"image":         unindexed,
+		"status":        keyword,
+		"created_at":    date,
+	},
+}
+
+func indexDefinition(entity models.SearchEntityType, shards, replicas int) map[string]any {
+	return map[string]any{
+		"settings": map[string]any{
+			"number_of_shards":   shards,
+			"number_of_replicas": replicas,
+		},
+		"mappings": map[string]any{
+			"dynamic":    false, // Unmapped fields stay in _source without being indexed
+			"properties": entityProperties[entity],
+		},
+	}
+}
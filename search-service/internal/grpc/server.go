@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	searchpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/search/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Server struct {
+	searchpb.UnimplementedSearchServiceServer
+	searchService *service.SearchService
+}
+
+func NewServer(searchService *service.SearchService) *Server {
+	return &Server{searchService: searchService}
+}
+
+func (s *Server) Register(grpcServer *grpc.Server) {
+	searchpb.RegisterSearchServiceServer(grpcServer, s)
+}
+
+func (s *Server) Search(ctx context.Context, req *searchpb.SearchRequest) (*searchpb.SearchResponse, error) {
+	if req.ViewerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "viewer_id is required")
+	}
+
+	types := make([]models.SearchEntityType, len(req.Types))
+	for i, t := range req.Types {
+		types[i] = models.SearchEntityType(t)
+	}
+
+	resp, err := s.searchService.Search(ctx, req.ViewerId, models.SearchQuery{
+		Query:  req.Query,
+		Types:  types,
+		Limit:  int(req.Limit),
+		Offset: int(req.Offset),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &searchpb.SearchResponse{
+		Hits:    toProtoHits(resp.Hits),
+		Total:   resp.Total,
+		TookMs:  resp.TookMs,
+		Partial: resp.Partial,
+	}, nil
+}
+
+func (s *Server) TopResults(ctx context.Context, req *searchpb.TopResultsRequest) (*searchpb.TopResultsResponse, error) {
+	if req.ViewerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "viewer_id is required")
+	}
+
+	resp, err := s.searchService.TopResults(ctx, req.ViewerId, req.Query, int(req.PerType))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &searchpb.TopResultsResponse{
+		Users:    toProtoHits(resp.Users),
+		Posts:    toProtoHits(resp.Posts),
+		Events:   toProtoHits(resp.Events),
+		Listings: toProtoHits(resp.Listings),
+	}, nil
+}
+
+func toProtoHits(hits []models.SearchHit) []*searchpb.SearchHit {
+	out := make([]*searchpb.SearchHit, len(hits))
+	for i, h := range hits {
+		hit := &searchpb.SearchHit{
+			Type:     string(h.Type),
+			Id:       h.ID,
+			Score:    h.Score,
+			Document: h.Document,
+		}
+		if len(h.Highlights) > 0 {
+			hit.Highlights = make(map[string]*searchpb.HighlightList, len(h.Highlights))
+			for field, fragments := range h.Highlights {
+				hit.Highlights[field] = &searchpb.HighlightList{Fragments: fragments}
+			}
+		}
+		out[i] = hit
+	}
+	return out
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrEmptyQuery),
+		errors.Is(err, service.ErrQueryTooLong),
+		errors.Is(err, service.ErrUnknownEntityType):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
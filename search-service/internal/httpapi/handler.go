@@ -0,0 +1,113 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/metrics"
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/middleware"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	searchService     SearchService
+	rateLimitObserver func(action string)
+}
+
+func NewSearchHandler(searchService SearchService, businessMetrics *metrics.BusinessMetrics) *SearchHandler {
+	var observer func(action string)
+	if businessMetrics != nil {
+		observer = businessMetrics.RecordRateLimitHit
+	}
+
+	return &SearchHandler{
+		searchService:     searchService,
+		rateLimitObserver: observer,
+	}
+}
+
+func (h *SearchHandler) RegisterRoutes(router *gin.Engine, auth gin.HandlerFunc, rateLimits *middleware.SlidingWindowLimiter) {
+	api := router.Group("/api")
+	search := api.Group("/search")
+	search.Use(auth)
+	{
+		search.GET("",
+			rateLimits.StrictRateLimiter(2, 10, "search:query"), // 120 per min
+			h.Search,
+		)
+		search.GET("/top",
+			rateLimits.StrictRateLimiter(5, 20, "search:top"), // 300 per min, fired while typing
+			h.TopResults,
+		)
+	}
+}
+
+// Search handles GET /api/search?q=&type=post,event&limit=&offset=
+func (h *SearchHandler) Search(c *gin.Context) {
+	viewerID := c.GetString("userID")
+	if viewerID == "" {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(service.DefaultLimit)))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	resp, err := h.searchService.Search(c.Request.Context(), viewerID, models.SearchQuery{
+		Query:  c.Query("q"),
+		Types:  parseTypes(c.QueryArray("type")),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		respondSearchError(c, err)
+		return
+	}
+	RespondWithData(c, http.StatusOK, resp)
+}
+
+// TopResults handles GET /api/search/top?q=&per_type=
+func (h *SearchHandler) TopResults(c *gin.Context) {
+	viewerID := c.GetString("userID")
+	if viewerID == "" {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	perType, _ := strconv.Atoi(c.DefaultQuery("per_type", strconv.Itoa(service.DefaultTopPerType)))
+
+	resp, err := h.searchService.TopResults(c.Request.Context(), viewerID, c.Query("q"), perType)
+	if err != nil {
+		respondSearchError(c, err)
+		return
+	}
+	RespondWithData(c, http.StatusOK, resp)
+}
+
+// parseTypes accepts both repeated (?type=a&type=b) and comma separated (?type=a,b) forms
+func parseTypes(values []string) []models.SearchEntityType {
+	var types []models.SearchEntityType
+	for _, v := range values {
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				types = append(types, models.SearchEntityType(t))
+			}
+		}
+	}
+	return types
+}
+
+func respondSearchError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrEmptyQuery), errors.Is(err, service.ErrQueryTooLong):
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+	case errors.Is(err, service.ErrUnknownEntityType):
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeInvalidType)
+	default:
+		RespondWithError(c, http.StatusInternalServerError, "Search is temporarily unavailable", ErrCodeInternalError)
+	}
+}
@@ -0,0 +1,13 @@
+package httpapi
+
+import (
+	"context"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+// SearchService defines the interface for search operations
+type SearchService interface {
+	Search(ctx context.Context, viewerID string, q models.SearchQuery) (*models.SearchResponse, error)
+	TopResults(ctx context.Context, viewerID, query string, perType int) (*models.TopResultsResponse, error)
+}
@@ -0,0 +1,33 @@
+package httpapi
+
+import (
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorResponse is the standard error envelope shared by all services
+type ErrorResponse = apperrors.Response
+
+// Standard error codes for search service
+const (
+	ErrCodeValidation    = apperrors.CodeValidation
+	ErrCodeUnauthorized  = apperrors.CodeUnauthorized
+	ErrCodeInvalidType   = "INVALID_SEARCH_TYPE"
+	ErrCodeRateLimited   = apperrors.CodeRateLimited
+	ErrCodeInternalError = apperrors.CodeInternal
+)
+
+// RespondWithError sends the standard error envelope. Without a code the
+// one for the status is used.
+func RespondWithError(c *gin.Context, statusCode int, message string, code ...string) {
+	errorCode := ""
+	if len(code) > 0 {
+		errorCode = code[0]
+	}
+	apperrors.Write(c, statusCode, errorCode, message, nil)
+}
+
+// RespondWithData sends data without a message wrapper
+func RespondWithData(c *gin.Context, statusCode int, data interface{}) {
+	c.JSON(statusCode, data)
+}
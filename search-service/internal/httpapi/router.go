@@ -0,0 +1,55 @@
+package httpapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/search-service/config"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/middleware"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func BuildRouter(cfg *config.Config, handler *SearchHandler, redisClient *redis.ClusterClient) *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	corsCfg := cors.Config{
+		AllowOrigins:     cfg.CORSAllowedOrigins,
+		AllowMethods:     []string{"GET", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		ExposeHeaders:    []string{"Content-Length", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	}
+	router.Use(cors.New(corsCfg))
+
+	// Limits are counted in Redis so they hold across replicas
+	rateLimits := middleware.NewSlidingWindowLimiter(redisClient.GetClient(), handler.rateLimitObserver)
+
+	// Global rate limiter
+	router.Use(rateLimits.RateLimiter(
+		cfg.RateLimitEnabled,
+		cfg.RateLimitLimit,
+		cfg.RateLimitBurst,
+		"search:global",
+	))
+
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "service": "search-service"})
+	})
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	authMiddleware := middleware.AuthMiddleware(
+		cfg.JWTSecret,
+		redisClient.GetClient(),
+		middleware.WithFailClosedResponse(http.StatusServiceUnavailable, "authentication temporarily unavailable"),
+		middleware.WithJWKS(cfg.JWKSURL),
+		middleware.WithLegacyHS256Until(cfg.LegacyHS256Until),
+	)
+
+	handler.RegisterRoutes(router, authMiddleware, rateLimits)
+	return router
+}
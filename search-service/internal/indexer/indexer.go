@@ -0,0 +1,173 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/elastic"
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/metrics"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	sharedkafka "github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/segmentio/kafka-go"
+)
+
+const maxIndexAttempts = 3
+
+// errMalformed marks events that can never be applied and are not retried
+var errMalformed = errors.New("malformed search index event")
+
+// DocumentStore is the index the indexer writes to
+type DocumentStore interface {
+	Index(ctx context.Context, entity models.SearchEntityType, id string, version int64, doc any) error
+	Delete(ctx context.Context, entity models.SearchEntityType, id string, version int64) error
+}
+
+// Indexer consumes one entity type's search index topic into Elasticsearch
+type Indexer struct {
+	entity  models.SearchEntityType
+	reader  *kafka.Reader
+	store   DocumentStore
+	dlq     *sharedkafka.DLQProducer
+	metrics *metrics.BusinessMetrics
+	logger  *slog.Logger
+}
+
+func NewIndexer(brokers []string, groupPrefix string, entity models.SearchEntityType, store DocumentStore, dlq *sharedkafka.DLQProducer, m *metrics.BusinessMetrics, logger *slog.Logger) *Indexer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Indexer{
+		entity: entity,
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:  brokers,
+			Topic:    sharedkafka.SearchIndexTopic(entity),
+			GroupID:  groupPrefix + "-" + string(entity),
+			MinBytes: 1,
+			MaxBytes: 10e6,
+		}),
+		store:   store,
+		dlq:     dlq,
+		metrics: m,
+		logger:  logger.With("entity", entity),
+	}
+}
+
+// Start consumes until ctx is cancelled. Messages that cannot be applied
+// after retries are dead-lettered so one bad document never stalls the topic.
+func (i *Indexer) Start(ctx context.Context) {
+	i.logger.Info("Search indexer started", "topic", i.reader.Config().Topic)
+	for {
+		msg, err := i.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			i.logger.Error("Failed to fetch search index event", "error", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		if err := i.handleWithRetry(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			i.logger.Error("Dead-lettering search index event", "key", string(msg.Key), "error", err)
+			i.metrics.IncrementIndexFailures(string(i.entity))
+			if i.dlq != nil {
+				if dlqErr := i.dlq.PublishDeadLetter(ctx, msg.Topic, msg.Value, err); dlqErr != nil {
+					i.logger.Error("Failed to dead-letter search index event", "error", dlqErr)
+				}
+			}
+		}
+
+		if err := i.reader.CommitMessages(ctx, msg); err != nil {
+			i.logger.Error("Failed to commit search index offset", "error", err)
+		}
+	}
+}
+
+func (i *Indexer) handleWithRetry(ctx context.Context, msg kafka.Message) error {
+	var err error
+	for attempt := 1; attempt <= maxIndexAttempts; attempt++ {
+		if err = i.Handle(ctx, msg.Value, msg.Time); err == nil || errors.Is(err, errMalformed) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * 500 * time.Millisecond):
+		}
+	}
+	return err
+}
+
+// Handle applies a single search index event. fallbackTime versions events
+// that carry no timestamp of their own.
+func (i *Indexer) Handle(ctx context.Context, payload []byte, fallbackTime time.Time) error {
+	var evt events.SearchIndexEvent
+	if err := json.Unmarshal(payload, &evt); err != nil {
+		return fmt.Errorf("%w: %v", errMalformed, err)
+	}
+	if evt.Entity != i.entity || evt.ID == "" {
+		return fmt.Errorf("%w: entity %q id %q on %s indexer", errMalformed, evt.Entity, evt.ID, i.entity)
+	}
+
+	ts := evt.Timestamp
+	if ts.IsZero() {
+		ts = fallbackTime
+	}
+	version := ts.UnixNano()
+
+	var err error
+	switch evt.Op {
+	case events.SearchIndexDelete:
+		err = i.store.Delete(ctx, i.entity, evt.ID, version)
+	case events.SearchIndexUpsert:
+		doc := i.document(evt)
+		if doc == nil {
+			return fmt.Errorf("%w: upsert without %s document", errMalformed, i.entity)
+		}
+		err = i.store.Index(ctx, i.entity, evt.ID, version, doc)
+	default:
+		return fmt.Errorf("%w: unknown op %q", errMalformed, evt.Op)
+	}
+
+	if errors.Is(err, elastic.ErrStaleVersion) {
+		return nil // A newer change has already been applied
+	}
+	if err == nil {
+		i.metrics.IncrementDocumentsIndexed(string(i.entity), string(evt.Op))
+	}
+	return err
+}
+
+func (i *Indexer) document(evt events.SearchIndexEvent) any {
+	switch i.entity {
+	case models.SearchEntityUser:
+		if evt.User != nil {
+			return evt.User
+		}
+	case models.SearchEntityPost:
+		if evt.Post != nil {
+			return evt.Post
+		}
+	case models.SearchEntityEvent:
+		if evt.Event != nil {
+			return evt.Event
+		}
+	case models.SearchEntityListing:
+		if evt.Listing != nil {
+			return evt.Listing
+		}
+	}
+	return nil
+}
+
+func (i *Indexer) Close() error {
+	return i.reader.Close()
+}
@@ -0,0 +1,56 @@
+package integration
+
+import (
+	"context"
+	"time"
+
+	sharedcache "github.com/MuhibNayem/connectify-v2/shared-entity/cache"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
+	"github.com/redis/go-redis/v9"
+)
+
+// friendIDsTTL bounds how stale friends-only visibility can be after an unfriend
+const friendIDsTTL = time.Minute
+
+// FriendLookup resolves friend lists from user-service, cached briefly in
+// Redis since every search needs them.
+type FriendLookup struct {
+	client userpb.UserServiceClient
+	cache  *sharedcache.Cache[[]string]
+}
+
+func NewFriendLookup(client userpb.UserServiceClient, redisClient redis.Cmdable) *FriendLookup {
+	return &FriendLookup{
+		client: client,
+		cache: sharedcache.New(redisClient, sharedcache.JSONCodec[[]string]{}, sharedcache.Options{
+			Prefix: "search:friend_ids",
+			TTL:    friendIDsTTL,
+			Jitter: 0.1,
+		}),
+	}
+}
+
+func (l *FriendLookup) FriendIDs(ctx context.Context, userID string) ([]string, error) {
+	return l.cache.GetOrLoad(ctx, userID, func(ctx context.Context) ([]string, error) {
+		resp, err := l.client.GetFriendIDs(ctx, &userpb.GetFriendIDsRequest{UserId: userID})
+		if err != nil {
+			return nil, err
+		}
+		return resp.FriendIds, nil
+	})
+}
+
+// ShadowBanLookup reads the set of shadow-banned users user-service keeps in
+// Redis
+type ShadowBanLookup struct {
+	redis redis.Cmdable
+}
+
+func NewShadowBanLookup(redisClient redis.Cmdable) *ShadowBanLookup {
+	return &ShadowBanLookup{redis: redisClient}
+}
+
+func (l *ShadowBanLookup) ShadowBannedIDs(ctx context.Context) ([]string, error) {
+	return l.redis.SMembers(ctx, models.ShadowBannedUsersKey).Result()
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type BusinessMetrics struct {
+	DocumentsIndexed *prometheus.CounterVec
+	IndexFailures    *prometheus.CounterVec
+	SearchRequests   *prometheus.CounterVec
+	SearchLatency    *prometheus.HistogramVec
+	RateLimitHits    *prometheus.CounterVec
+}
+
+func NewBusinessMetrics() *BusinessMetrics {
+	return &BusinessMetrics{
+		DocumentsIndexed: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "search_documents_indexed_total",
+			Help: "Total number of search index changes applied, by entity and operation",
+		}, []string{"entity", "op"}),
+		IndexFailures: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "search_index_failures_total",
+			Help: "Total number of search index events dead-lettered, by entity",
+		}, []string{"entity"}),
+		SearchRequests: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "search_requests_total",
+			Help: "Total number of search requests, by kind and outcome",
+		}, []string{"kind", "status"}),
+		SearchLatency: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "search_request_duration_seconds",
+			Help:    "Search request latency, by kind",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"kind"}),
+		RateLimitHits: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "search_service_rate_limit_hits_total",
+			Help: "Number of rate-limited requests grouped by action",
+		}, []string{"action"}),
+	}
+}
+
+func (m *BusinessMetrics) IncrementDocumentsIndexed(entity, op string) {
+	if m != nil {
+		m.DocumentsIndexed.WithLabelValues(entity, op).Inc()
+	}
+}
+
+func (m *BusinessMetrics) IncrementIndexFailures(entity string) {
+	if m != nil {
+		m.IndexFailures.WithLabelValues(entity).Inc()
+	}
+}
+
+func (m *BusinessMetrics) ObserveSearch(kind string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	m.SearchRequests.WithLabelValues(kind, status).Inc()
+	m.SearchLatency.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+}
+
+func (m *BusinessMetrics) RecordRateLimitHit(action string) {
+	if m != nil {
+		m.RateLimitHits.WithLabelValues(action).Inc()
+	}
+}
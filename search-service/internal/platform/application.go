@@ -0,0 +1,264 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/search-service/config"
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/elastic"
+	searchgrpc "github.com/MuhibNayem/connectify-v2/search-service/internal/grpc"
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/httpapi"
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/indexer"
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/integration"
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/metrics"
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/service"
+	sharedkafka "github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
+	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/resilience"
+	"google.golang.org/grpc"
+)
+
+type Application struct {
+	cfg         *config.Config
+	elastic     *elastic.Client
+	grpcServer  *grpc.Server
+	httpServer  *http.Server
+	redisClient *redis.ClusterClient
+	userConn    *grpc.ClientConn
+	indexers    []*indexer.Indexer
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func NewApplication(cfg *config.Config) *Application {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Application{cfg: cfg, ctx: ctx, cancel: cancel}
+}
+
+func (a *Application) Bootstrap() error {
+	if _, err := observability.InitTracer(context.Background(), observability.TracerConfig{
+		ServiceName:    "search-service",
+		ServiceVersion: "1.0.0",
+		Environment:    "development", // TODO: Configurable
+		JaegerEndpoint: a.cfg.JaegerOTLPEndpoint,
+	}); err != nil {
+		slog.Error("Failed to initialize tracer", "error", err)
+	}
+
+	if err := a.initElastic(); err != nil {
+		return fmt.Errorf("failed to initialize elasticsearch: %w", err)
+	}
+
+	if err := a.initRedis(); err != nil {
+		return fmt.Errorf("failed to initialize redis: %w", err)
+	}
+
+	if err := a.initUserClient(); err != nil {
+		return fmt.Errorf("failed to connect to user service: %w", err)
+	}
+
+	businessMetrics := metrics.NewBusinessMetrics()
+
+	// One indexer per entity type, each on its own topic and consumer group
+	for _, entity := range models.SearchEntityTypes {
+		a.indexers = append(a.indexers, indexer.NewIndexer(
+			a.cfg.KafkaBrokers,
+			a.cfg.IndexerGroupPrefix,
+			entity,
+			a.elastic,
+			sharedkafka.NewDLQProducer(a.cfg.KafkaBrokers),
+			businessMetrics,
+			slog.Default(),
+		))
+	}
+
+	friendLookup := integration.NewFriendLookup(userpb.NewUserServiceClient(a.userConn), a.redisClient.GetClient())
+	searchService := service.NewSearchService(a.elastic, friendLookup, businessMetrics, slog.Default())
+	searchService.SetShadowBanLookup(integration.NewShadowBanLookup(a.redisClient.GetClient()))
+
+	a.grpcServer = grpc.NewServer(
+		observability.GetGRPCServerOption(),
+	)
+	searchgrpc.NewServer(searchService).Register(a.grpcServer)
+
+	httpHandler := httpapi.NewSearchHandler(searchService, businessMetrics)
+	router := httpapi.BuildRouter(a.cfg, httpHandler, a.redisClient)
+	a.httpServer = &http.Server{
+		Addr:    fmt.Sprintf(":%s", a.cfg.ServerPort),
+		Handler: router,
+	}
+
+	slog.Info("Application bootstrapped successfully")
+	return nil
+}
+
+func (a *Application) Run() error {
+	errCh := make(chan error, 2)
+
+	for _, idx := range a.indexers {
+		go idx.Start(a.ctx)
+	}
+
+	go func() {
+		slog.Info("Search service HTTP server listening", "port", a.cfg.ServerPort)
+		if err := a.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+	}()
+
+	go func() {
+		errCh <- a.startGRPC()
+	}()
+
+	return <-errCh
+}
+
+func (a *Application) startGRPC() error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", a.cfg.GRPCPort))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %s: %w", a.cfg.GRPCPort, err)
+	}
+
+	slog.Info("Search service gRPC server listening", "port", a.cfg.GRPCPort)
+
+	if err := a.grpcServer.Serve(lis); err != nil {
+		if errors.Is(err, grpc.ErrServerStopped) {
+			return nil
+		}
+		return fmt.Errorf("gRPC server error: %w", err)
+	}
+
+	return nil
+}
+
+func (a *Application) Shutdown() {
+	slog.Info("Shutting down search-service...")
+
+	// Stop indexers first so no offsets are committed mid-shutdown
+	a.cancel()
+	for _, idx := range a.indexers {
+		if err := idx.Close(); err != nil {
+			slog.Error("Error closing search indexer", "error", err)
+		}
+	}
+
+	if a.httpServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := a.httpServer.Shutdown(ctx); err != nil {
+			slog.Error("Error shutting down HTTP server", "error", err)
+		} else {
+			slog.Info("HTTP server stopped")
+		}
+	}
+
+	if a.grpcServer != nil {
+		a.grpcServer.GracefulStop()
+		slog.Info("gRPC server stopped")
+	}
+
+	if a.redisClient != nil {
+		if err := a.redisClient.Close(); err != nil {
+			slog.Error("Error closing Redis connection", "error", err)
+		}
+	}
+
+	if a.userConn != nil {
+		if err := a.userConn.Close(); err != nil {
+			slog.Error("Error closing user-service client connection", "error", err)
+		}
+	}
+
+	slog.Info("Search service shutdown complete")
+}
+
+func (a *Application) initElastic() error {
+	client, err := elastic.NewClient(elastic.Config{
+		URLs:        a.cfg.ElasticURLs,
+		Username:    a.cfg.ElasticUser,
+		Password:    a.cfg.ElasticPassword,
+		IndexPrefix: a.cfg.ElasticIndexPrefix,
+		Shards:      a.cfg.ElasticShards,
+		Replicas:    a.cfg.ElasticReplicas,
+	}, slog.Default())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := client.Ping(ctx); err == nil {
+			break
+		}
+		slog.Warn("Waiting for Elasticsearch...")
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to connect to Elasticsearch within timeout")
+		case <-ticker.C:
+		}
+	}
+
+	if err := client.EnsureIndices(ctx); err != nil {
+		return err
+	}
+	a.elastic = client
+	slog.Info("Connected to Elasticsearch")
+	return nil
+}
+
+func (a *Application) initRedis() error {
+	cfg := redis.Config{
+		RedisURLs: a.cfg.RedisURLs,
+		RedisPass: a.cfg.RedisPass,
+	}
+	client := redis.NewClusterClient(cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to connect to Redis cluster within timeout")
+		case <-ticker.C:
+			if client.IsAvailable(context.Background()) {
+				a.redisClient = client
+				slog.Info("Connected to Redis cluster")
+				return nil
+			}
+			slog.Warn("Waiting for Redis cluster...")
+		}
+	}
+}
+
+func (a *Application) initUserClient() error {
+	// Profile lookups are reads, so they are retried when user-service is briefly unavailable
+	conn, err := resilience.NewGRPCClient("user-service").
+		WithTimeout(3*time.Second).
+		WithRetry(resilience.DefaultRetryPolicy(), resilience.ReadOnlyMethods...).
+		Dial(net.JoinHostPort(a.cfg.UserServiceHost, a.cfg.UserServicePort))
+	if err != nil {
+		return err
+	}
+
+	a.userConn = conn
+	slog.Info("Connected to user service", "host", a.cfg.UserServiceHost, "port", a.cfg.UserServicePort)
+	return nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+// Viewer is the caller a query is filtered for
+type Viewer struct {
+	ID        string
+	FriendIDs []string
+	HiddenIDs []string // Shadow-banned users, whose content is left out
+}
+
+// textFields are the searchable fields and boosts per entity type
+var textFields = map[models.SearchEntityType][]string{
+	models.SearchEntityUser: {
+		"username^3", "username._2gram", "username._3gram",
+		"full_name^2", "full_name._2gram", "full_name._3gram",
+		"bio", "location",
+	},
+	models.SearchEntityPost: {
+		"content^2", "author_username", "location",
+	},
+	models.SearchEntityEvent: {
+		"title^3", "title._2gram", "title._3gram",
+		"description", "location",
+	},
+	models.SearchEntityListing: {
+		"title^3", "title._2gram", "title._3gram",
+		"description", "category_name^2", "city",
+	},
+}
+
+// ownerField is the field holding the user each entity type belongs to
+var ownerField = map[models.SearchEntityType]string{
+	models.SearchEntityUser:    "id",
+	models.SearchEntityPost:    "author_id",
+	models.SearchEntityEvent:   "creator_id",
+	models.SearchEntityListing: "seller_id",
+}
+
+// highlightFields are the fields returned with match fragments
+var highlightFields = map[models.SearchEntityType][]string{
+	models.SearchEntityUser:    {"username", "full_name"},
+	models.SearchEntityPost:    {"content"},
+	models.SearchEntityEvent:   {"title", "description"},
+	models.SearchEntityListing: {"title", "description"},
+}
+
+// entityClause matches text within a single entity type and applies that
+// type's visibility rules for the viewer. index scopes the clause when
+// several indices are searched together.
+func entityClause(entity models.SearchEntityType, index, text string, viewer Viewer) map[string]any {
+	must := []any{
+		map[string]any{"multi_match": map[string]any{
+			"query":  text,
+			"type":   "bool_prefix",
+			"fields": textFields[entity],
+		}},
+	}
+	should := []any{}
+	if entity == models.SearchEntityPost {
+		// Exact hashtag hits rank above free-text matches
+		should = append(should, map[string]any{"term": map[string]any{"hashtags": map[string]any{"value": text, "boost": 2}}})
+	}
+	if entity == models.SearchEntityListing {
+		should = append(should, map[string]any{"term": map[string]any{"tags": map[string]any{"value": text, "boost": 2}}})
+	}
+
+	filter := append([]any{}, visibilityFilter(entity, viewer)...)
+	if index != "" {
+		filter = append(filter, map[string]any{"term": map[string]any{"_index": index}})
+	}
+
+	clause := map[string]any{"must": must, "filter": filter}
+	if len(should) > 0 {
+		clause["should"] = should
+	}
+	if len(viewer.HiddenIDs) > 0 {
+		clause["must_not"] = []any{terms(ownerField[entity], viewer.HiddenIDs)}
+	}
+	return map[string]any{"bool": clause}
+}
+
+// visibilityFilter restricts results to documents the viewer may see
+func visibilityFilter(entity models.SearchEntityType, viewer Viewer) []any {
+	switch entity {
+	case models.SearchEntityUser:
+		return []any{term("is_active", true)}
+
+	case models.SearchEntityPost:
+		visible := []any{
+			term("privacy", models.PrivacySettingPublic),
+			term("author_id", viewer.ID),
+			boolAll(term("privacy", models.PrivacySettingCustom), term("audience", viewer.ID)),
+		}
+		if len(viewer.FriendIDs) > 0 {
+			visible = append(visible, boolAll(
+				term("privacy", models.PrivacySettingFriends),
+				terms("author_id", viewer.FriendIDs),
+			))
+		}
+		return []any{
+			term("status", models.PostStatusActive),
+			boolAny(visible...),
+		}
+
+	case models.SearchEntityEvent:
+		visible := []any{
+			term("privacy", models.EventPrivacyPublic),
+			term("creator_id", viewer.ID),
+			term("member_ids", viewer.ID),
+		}
+		if len(viewer.FriendIDs) > 0 {
+			visible = append(visible, boolAll(
+				term("privacy", models.EventPrivacyFriends),
+				terms("creator_id", viewer.FriendIDs),
+			))
+		}
+		return []any{boolAny(visible...)}
+
+	case models.SearchEntityListing:
+		return []any{boolAny(
+			term("status", models.ProductStatusAvailable),
+			term("seller_id", viewer.ID),
+		)}
+	}
+	return nil
+}
+
+// buildSearchQuery builds a single ranked query over several entity indices
+func buildSearchQuery(entities []models.SearchEntityType, indexName func(models.SearchEntityType) string, text string, viewer Viewer, limit, offset int) map[string]any {
+	clauses := make([]any, 0, len(entities))
+	highlight := map[string]any{}
+	for _, entity := range entities {
+		index := ""
+		if len(entities) > 1 {
+			index = indexName(entity)
+		}
+		clauses = append(clauses, entityClause(entity, index, text, viewer))
+		for _, f := range highlightFields[entity] {
+			highlight[f] = map[string]any{}
+		}
+	}
+
+	var query map[string]any
+	if len(clauses) == 1 {
+		query = clauses[0].(map[string]any)
+	} else {
+		query = boolAny(clauses...)
+	}
+
+	return map[string]any{
+		"query":            query,
+		"from":             offset,
+		"size":             limit,
+		"track_total_hits": true,
+		"highlight": map[string]any{
+			"fields":              highlight,
+			"fragment_size":       120,
+			"number_of_fragments": 1,
+			"require_field_match": true,
+		},
+	}
+}
+
+func term(field string, value any) map[string]any {
+	return map[string]any{"term": map[string]any{field: value}}
+}
+
+func terms(field string, values []string) map[string]any {
+	return map[string]any{"terms": map[string]any{field: values}}
+}
+
+func boolAll(clauses ...any) map[string]any {
+	return map[string]any{"bool": map[string]any{"filter": clauses}}
+}
+
+func boolAny(clauses ...any) map[string]any {
+	return map[string]any{"bool": map[string]any{"should": clauses, "minimum_should_match": 1}}
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/elastic"
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/metrics"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+const (
+	DefaultLimit      = 20
+	MaxLimit          = 50
+	MaxOffset         = 1000 // Deep paging is expensive in Elasticsearch and unnecessary for a search UI
+	DefaultTopPerType = 5
+	MaxTopPerType     = 10
+	MaxQueryLength    = 200
+)
+
+var (
+	ErrEmptyQuery        = errors.New("search query is required")
+	ErrQueryTooLong      = fmt.Errorf("search query must be at most %d characters", MaxQueryLength)
+	ErrUnknownEntityType = errors.New("unknown search type")
+)
+
+// SearchBackend is the search index the service queries
+type SearchBackend interface {
+	IndexName(entity models.SearchEntityType) string
+	EntityForIndex(index string) (models.SearchEntityType, bool)
+	Search(ctx context.Context, indices []string, query map[string]any) (*elastic.SearchResult, error)
+	MultiSearch(ctx context.Context, requests []elastic.MultiSearchRequest) ([]elastic.SearchResult, error)
+}
+
+// FriendLookup resolves the viewer's friends for friends-only visibility
+type FriendLookup interface {
+	FriendIDs(ctx context.Context, userID string) ([]string, error)
+}
+
+// ShadowBanLookup resolves the users whose content is hidden from everyone
+// but themselves
+type ShadowBanLookup interface {
+	ShadowBannedIDs(ctx context.Context) ([]string, error)
+}
+
+type SearchService struct {
+	backend    SearchBackend
+	friends    FriendLookup
+	shadowBans ShadowBanLookup
+	metrics    *metrics.BusinessMetrics
+	logger     *slog.Logger
+}
+
+func NewSearchService(backend SearchBackend, friends FriendLookup, m *metrics.BusinessMetrics, logger *slog.Logger) *SearchService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SearchService{backend: backend, friends: friends, metrics: m, logger: logger}
+}
+
+// SetShadowBanLookup enables hiding shadow-banned users and their content
+func (s *SearchService) SetShadowBanLookup(shadowBans ShadowBanLookup) {
+	s.shadowBans = shadowBans
+}
+
+// Search runs a typed query across the requested entity types, ranked together
+func (s *SearchService) Search(ctx context.Context, viewerID string, q models.SearchQuery) (resp *models.SearchResponse, err error) {
+	defer func(start time.Time) { s.metrics.ObserveSearch("search", start, err) }(time.Now())
+
+	text, err := normalizeQuery(q.Query)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := resolveTypes(q.Types)
+	if err != nil {
+		return nil, err
+	}
+	limit := clamp(q.Limit, DefaultLimit, MaxLimit)
+	offset := q.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > MaxOffset {
+		offset = MaxOffset
+	}
+
+	viewer := s.viewer(ctx, viewerID)
+	indices := make([]string, len(entities))
+	for i, entity := range entities {
+		indices[i] = s.backend.IndexName(entity)
+	}
+
+	result, err := s.backend.Search(ctx, indices, buildSearchQuery(entities, s.backend.IndexName, text, viewer, limit, offset))
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+
+	return &models.SearchResponse{
+		Query:   text,
+		Hits:    s.toHits(result),
+		Total:   result.Hits.Total.Value,
+		Limit:   limit,
+		Offset:  offset,
+		TookMs:  result.Took,
+		Partial: result.TimedOut || result.Shards.Failed > 0,
+	}, nil
+}
+
+// TopResults returns the best few hits of every entity type in one round trip.
+// An entity type whose query fails is returned empty so the search bar still
+// renders the others.
+func (s *SearchService) TopResults(ctx context.Context, viewerID, query string, perType int) (resp *models.TopResultsResponse, err error) {
+	defer func(start time.Time) { s.metrics.ObserveSearch("top", start, err) }(time.Now())
+
+	text, err := normalizeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	perType = clamp(perType, DefaultTopPerType, MaxTopPerType)
+	viewer := s.viewer(ctx, viewerID)
+
+	requests := make([]elastic.MultiSearchRequest, len(models.SearchEntityTypes))
+	for i, entity := range models.SearchEntityTypes {
+		requests[i] = elastic.MultiSearchRequest{
+			Index: s.backend.IndexName(entity),
+			Query: buildSearchQuery([]models.SearchEntityType{entity}, s.backend.IndexName, text, viewer, perType, 0),
+		}
+	}
+
+	results, err := s.backend.MultiSearch(ctx, requests)
+	if err != nil {
+		return nil, fmt.Errorf("top results search failed: %w", err)
+	}
+
+	resp = &models.TopResultsResponse{
+		Query:    text,
+		Users:    []models.SearchHit{},
+		Posts:    []models.SearchHit{},
+		Events:   []models.SearchHit{},
+		Listings: []models.SearchHit{},
+	}
+	for i := range results {
+		if i >= len(models.SearchEntityTypes) {
+			break
+		}
+		if results[i].Status != 0 && results[i].Status != 200 {
+			s.logger.Warn("Top results query failed", "entity", models.SearchEntityTypes[i], "status", results[i].Status)
+			continue
+		}
+		hits := s.toHits(&results[i])
+		switch models.SearchEntityTypes[i] {
+		case models.SearchEntityUser:
+			resp.Users = hits
+		case models.SearchEntityPost:
+			resp.Posts = hits
+		case models.SearchEntityEvent:
+			resp.Events = hits
+		case models.SearchEntityListing:
+			resp.Listings = hits
+		}
+	}
+	return resp, nil
+}
+
+// viewer resolves the viewer's friends and the users hidden from them. If
+// the friend lookup fails the viewer is treated as having none, which can
+// only hide results, never leak them. Shadow bans are best effort.
+func (s *SearchService) viewer(ctx context.Context, viewerID string) Viewer {
+	v := Viewer{ID: viewerID}
+	if s.shadowBans != nil {
+		banned, err := s.shadowBans.ShadowBannedIDs(ctx)
+		if err != nil {
+			s.logger.Warn("Shadow ban lookup failed, searching without it", "error", err)
+		}
+		for _, id := range banned {
+			// Shadow-banned users still find their own content
+			if id != viewerID {
+				v.HiddenIDs = append(v.HiddenIDs, id)
+			}
+		}
+	}
+	if s.friends == nil || viewerID == "" {
+		return v
+	}
+	friendIDs, err := s.friends.FriendIDs(ctx, viewerID)
+	if err != nil {
+		s.logger.Warn("Friend lookup failed, searching with public visibility only", "viewer_id", viewerID, "error", err)
+		return v
+	}
+	v.FriendIDs = friendIDs
+	return v
+}
+
+func (s *SearchService) toHits(result *elastic.SearchResult) []models.SearchHit {
+	hits := make([]models.SearchHit, 0, len(result.Hits.Hits))
+	for _, h := range result.Hits.Hits {
+		entity, ok := s.backend.EntityForIndex(h.Index)
+		if !ok {
+			continue
+		}
+		hits = append(hits, models.SearchHit{
+			Type:       entity,
+			ID:         h.ID,
+			Score:      h.Score,
+			Document:   h.Source,
+			Highlights: h.Highlight,
+		})
+	}
+	return hits
+}
+
+func normalizeQuery(q string) (string, error) {
+	q = strings.Join(strings.Fields(q), " ")
+	if q == "" {
+		return "", ErrEmptyQuery
+	}
+	if utf8.RuneCountInString(q) > MaxQueryLength {
+		return "", ErrQueryTooLong
+	}
+	return q, nil
+}
+
+func resolveTypes(types []models.SearchEntityType) ([]models.SearchEntityType, error) {
+	if len(types) == 0 {
+		return models.SearchEntityTypes, nil
+	}
+	seen := make(map[models.SearchEntityType]bool, len(types))
+	resolved := make([]models.SearchEntityType, 0, len(types))
+	for _, t := range types {
+		if !isKnownType(t) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownEntityType, t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			resolved = append(resolved, t)
+		}
+	}
+	return resolved, nil
+}
+
+func isKnownType(t models.SearchEntityType) bool {
+	for _, known := range models.SearchEntityTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+func clamp(v, def, max int) int {
+	if v <= 0 {
+		return def
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
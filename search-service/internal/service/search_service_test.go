@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/search-service/internal/elastic"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBackend struct {
+	lastIndices  []string
+	lastQuery    map[string]any
+	lastRequests []elastic.MultiSearchRequest
+	result       *elastic.SearchResult
+	multi        []elastic.SearchResult
+}
+
+func (f *fakeBackend) IndexName(entity models.SearchEntityType) string {
+	return "test-" + string(entity) + "s"
+}
+
+func (f *fakeBackend) EntityForIndex(index string) (models.SearchEntityType, bool) {
+	for _, e := range models.SearchEntityTypes {
+		if f.IndexName(e) == index {
+			return e, true
+		}
+	}
+	return "", false
+}
+
+func (f *fakeBackend) Search(ctx context.Context, indices []string, query map[string]any) (*elastic.SearchResult, error) {
+	f.lastIndices = indices
+	f.lastQuery = query
+	if f.result == nil {
+		return &elastic.SearchResult{}, nil
+	}
+	return f.result, nil
+}
+
+func (f *fakeBackend) MultiSearch(ctx context.Context, requests []elastic.MultiSearchRequest) ([]elastic.SearchResult, error) {
+	f.lastRequests = requests
+	return f.multi, nil
+}
+
+type fakeFriends struct {
+	ids []string
+	err error
+}
+
+func (f fakeFriends) FriendIDs(ctx context.Context, userID string) ([]string, error) {
+	return f.ids, f.err
+}
+
+type fakeShadowBans []string
+
+func (f fakeShadowBans) ShadowBannedIDs(ctx context.Context) ([]string, error) {
+	return f, nil
+}
+
+func resultWithHit(index, id string) elastic.SearchResult {
+	var r elastic.SearchResult
+	r.Hits.Total.Value = 1
+	r.Hits.Hits = append(r.Hits.Hits, struct {
+		Index     string              `json:"_index"`
+		ID        string              `json:"_id"`
+		Score     float64             `json:"_score"`
+		Source    json.RawMessage     `json:"_source"`
+		Highlight map[string][]string `json:"highlight"`
+	}{Index: index, ID: id, Score: 1, Source: json.RawMessage(`{}`)})
+	return r
+}
+
+// queryJSON renders a query so assertions can match on its clauses
+func queryJSON(t *testing.T, q map[string]any) string {
+	b, err := json.Marshal(q)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestSearchService_Search_Validation(t *testing.T) {
+	svc := NewSearchService(&fakeBackend{}, nil, nil, nil)
+
+	_, err := svc.Search(context.Background(), "viewer", models.SearchQuery{Query: "   "})
+	assert.ErrorIs(t, err, ErrEmptyQuery)
+
+	_, err = svc.Search(context.Background(), "viewer", models.SearchQuery{Query: "golang", Types: []models.SearchEntityType{"reel"}})
+	assert.ErrorIs(t, err, ErrUnknownEntityType)
+}
+
+func TestSearchService_Search_PermissionFiltering(t *testing.T) {
+	t.Run("friends-only posts are visible only from friends", func(t *testing.T) {
+		backend := &fakeBackend{}
+		svc := NewSearchService(backend, fakeFriends{ids: []string{"friend-1"}}, nil, nil)
+
+		_, err := svc.Search(context.Background(), "viewer", models.SearchQuery{Query: "hello", Types: []models.SearchEntityType{models.SearchEntityPost}})
+		require.NoError(t, err)
+
+		q := queryJSON(t, backend.lastQuery)
+		assert.Equal(t, []string{"test-posts"}, backend.lastIndices)
+		assert.Contains(t, q, `{"term":{"privacy":"PUBLIC"}}`)
+		assert.Contains(t, q, `{"term":{"author_id":"viewer"}}`)
+		assert.Contains(t, q, `{"terms":{"author_id":["friend-1"]}}`)
+		assert.Contains(t, q, `{"term":{"audience":"viewer"}}`)
+		assert.Contains(t, q, `{"term":{"status":"active"}}`)
+	})
+
+	t.Run("failed friend lookup falls back to public visibility", func(t *testing.T) {
+		backend := &fakeBackend{}
+		svc := NewSearchService(backend, fakeFriends{err: errors.New("user-service down")}, nil, nil)
+
+		_, err := svc.Search(context.Background(), "viewer", models.SearchQuery{Query: "hello", Types: []models.SearchEntityType{models.SearchEntityPost}})
+		require.NoError(t, err)
+
+		q := queryJSON(t, backend.lastQuery)
+		assert.NotContains(t, q, `"FRIENDS"`)
+		assert.Contains(t, q, `{"term":{"privacy":"PUBLIC"}}`)
+	})
+
+	t.Run("shadow-banned users are hidden from everyone but themselves", func(t *testing.T) {
+		backend := &fakeBackend{}
+		svc := NewSearchService(backend, nil, nil, nil)
+		svc.SetShadowBanLookup(fakeShadowBans{"banned", "viewer"})
+
+		_, err := svc.Search(context.Background(), "viewer", models.SearchQuery{Query: "hello", Types: []models.SearchEntityType{models.SearchEntityPost}})
+		require.NoError(t, err)
+
+		q := queryJSON(t, backend.lastQuery)
+		assert.Contains(t, q, `"must_not":[{"terms":{"author_id":["banned"]}}]`)
+	})
+
+	t.Run("multi-type queries scope each clause to its index", func(t *testing.T) {
+		backend := &fakeBackend{}
+		svc := NewSearchService(backend, nil, nil, nil)
+
+		_, err := svc.Search(context.Background(), "viewer", models.SearchQuery{Query: "hello"})
+		require.NoError(t, err)
+
+		q := queryJSON(t, backend.lastQuery)
+		assert.Len(t, backend.lastIndices, len(models.SearchEntityTypes))
+		for _, entity := range models.SearchEntityTypes {
+			assert.Contains(t, q, `{"term":{"_index":"`+backend.IndexName(entity)+`"}}`)
+		}
+		assert.Contains(t, q, `{"term":{"status":"available"}}`)
+		assert.Contains(t, q, `{"term":{"member_ids":"viewer"}}`)
+	})
+}
+
+func TestSearchService_Search_Pagination(t *testing.T) {
+	backend := &fakeBackend{}
+	svc := NewSearchService(backend, nil, nil, nil)
+
+	resp, err := svc.Search(context.Background(), "viewer", models.SearchQuery{Query: "hello", Limit: 500, Offset: 5000})
+	require.NoError(t, err)
+	assert.Equal(t, MaxLimit, resp.Limit)
+	assert.Equal(t, MaxOffset, resp.Offset)
+	assert.Equal(t, MaxLimit, backend.lastQuery["size"])
+}
+
+func TestSearchService_TopResults(t *testing.T) {
+	backend := &fakeBackend{}
+	failed := elastic.SearchResult{Status: 500}
+	backend.multi = []elastic.SearchResult{
+		resultWithHit("test-users", "u1"),
+		failed,
+		resultWithHit("test-events", "e1"),
+		resultWithHit("test-listings", "l1"),
+	}
+	svc := NewSearchService(backend, nil, nil, nil)
+
+	resp, err := svc.TopResults(context.Background(), "viewer", "hello", 0)
+	require.NoError(t, err)
+
+	require.Len(t, backend.lastRequests, len(models.SearchEntityTypes))
+	assert.Equal(t, DefaultTopPerType, backend.lastRequests[0].Query["size"])
+
+	require.Len(t, resp.Users, 1)
+	assert.Equal(t, models.SearchEntityUser, resp.Users[0].Type)
+	assert.Empty(t, resp.Posts)
+	assert.Len(t, resp.Events, 1)
+	assert.Len(t, resp.Listings, 1)
+}
@@ -0,0 +1,222 @@
+package apperrors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Machine-readable codes shared by all services. Services may add their own
+// more specific codes, such as OFFER_NOT_FOUND.
+const (
+	CodeValidation   = "VALIDATION_ERROR"
+	CodeUnauthorized = "UNAUTHORIZED"
+	CodeForbidden    = "FORBIDDEN"
+	CodeNotFound     = "NOT_FOUND"
+	CodeConflict     = "CONFLICT"
+	CodeRateLimited  = "RATE_LIMITED"
+	CodeUnavailable  = "SERVICE_UNAVAILABLE"
+	CodeInternal     = "INTERNAL_ERROR"
+)
+
+// Kind is the category of an error. It decides the HTTP status and the
+// default code.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindRateLimited
+	KindUnavailable
+)
+
+// Status returns the HTTP status for the kind
+func (k Kind) Status() int {
+	switch k {
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindRateLimited:
+		return http.StatusTooManyRequests
+	case KindUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Code returns the default machine-readable code for the kind
+func (k Kind) Code() string {
+	switch k {
+	case KindValidation:
+		return CodeValidation
+	case KindUnauthorized:
+		return CodeUnauthorized
+	case KindForbidden:
+		return CodeForbidden
+	case KindNotFound:
+		return CodeNotFound
+	case KindConflict:
+		return CodeConflict
+	case KindRateLimited:
+		return CodeRateLimited
+	case KindUnavailable:
+		return CodeUnavailable
+	default:
+		return CodeInternal
+	}
+}
+
+// KindForStatus returns the kind an HTTP status belongs to
+func KindForStatus(statusCode int) Kind {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return KindValidation
+	case http.StatusUnauthorized:
+		return KindUnauthorized
+	case http.StatusForbidden:
+		return KindForbidden
+	case http.StatusNotFound:
+		return KindNotFound
+	case http.StatusConflict:
+		return KindConflict
+	case http.StatusTooManyRequests:
+		return KindRateLimited
+	case http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return KindUnavailable
+	}
+	if statusCode >= 400 && statusCode < 500 {
+		return KindValidation
+	}
+	return KindInternal
+}
+
+// Error is a service error with a category, a machine-readable code and a
+// message safe to show to clients
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Details map[string]string
+	Err     error
+}
+
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Code: kind.Code(), Message: message}
+}
+
+func NotFound(message string) *Error     { return New(KindNotFound, message) }
+func Forbidden(message string) *Error    { return New(KindForbidden, message) }
+func Validation(message string) *Error   { return New(KindValidation, message) }
+func Conflict(message string) *Error     { return New(KindConflict, message) }
+func Unauthorized(message string) *Error { return New(KindUnauthorized, message) }
+func Unavailable(message string) *Error  { return New(KindUnavailable, message) }
+
+// Wrap categorises err, keeping its message
+func Wrap(kind Kind, err error) *Error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Code: kind.Code(), Message: err.Error(), Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Code
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithCode returns a copy of the error with a more specific code
+func (e *Error) WithCode(code string) *Error {
+	copied := *e
+	copied.Code = code
+	return &copied
+}
+
+// WithDetails returns a copy of the error carrying per-field details
+func (e *Error) WithDetails(details map[string]string) *Error {
+	copied := *e
+	copied.Details = details
+	return &copied
+}
+
+// From returns err as an *Error. Errors that are not already categorised
+// are mapped from their gRPC status or well-known driver errors, and
+// otherwise are internal.
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+		return &Error{Kind: kindForGRPC(st.Code()), Code: kindForGRPC(st.Code()).Code(), Message: st.Message(), Err: err}
+	}
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return Wrap(KindNotFound, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return Wrap(KindUnavailable, err)
+	}
+	return Wrap(KindInternal, err)
+}
+
+// KindOf returns the category of err
+func KindOf(err error) Kind {
+	if err == nil {
+		return KindInternal
+	}
+	return From(err).Kind
+}
+
+// Is reports whether err belongs to kind
+func Is(err error, kind Kind) bool {
+	var appErr *Error
+	return errors.As(err, &appErr) && appErr.Kind == kind
+}
+
+func kindForGRPC(code codes.Code) Kind {
+	switch code {
+	case codes.NotFound:
+		return KindNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return KindConflict
+	case codes.PermissionDenied:
+		return KindForbidden
+	case codes.Unauthenticated:
+		return KindUnauthorized
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return KindValidation
+	case codes.ResourceExhausted:
+		return KindRateLimited
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return KindUnavailable
+	default:
+		return KindInternal
+	}
+}
@@ -0,0 +1,58 @@
+package apperrors
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Body is the error object every service returns
+type Body struct {
+	Status  int               `json:"status"`
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+	TraceID string            `json:"trace_id,omitempty"`
+}
+
+// Response is the envelope around Body: {"error": {...}}
+type Response struct {
+	Error Body `json:"error"`
+}
+
+// Write sends the error envelope. An empty code defaults to the one for the
+// status.
+func Write(c *gin.Context, statusCode int, code, message string, details map[string]string) {
+	if code == "" {
+		code = KindForStatus(statusCode).Code()
+	}
+	c.JSON(statusCode, Response{Error: Body{
+		Status:  statusCode,
+		Code:    code,
+		Message: message,
+		Details: details,
+		TraceID: TraceID(c),
+	}})
+}
+
+// Respond sends err as the error envelope and aborts the request. Internal
+// errors get a generic message so driver and network errors don't leak.
+func Respond(c *gin.Context, err error) {
+	appErr := From(err)
+	message := appErr.Error()
+	if appErr.Kind == KindInternal {
+		message = "internal server error"
+	}
+	Write(c, appErr.Kind.Status(), appErr.Code, message, appErr.Details)
+	c.Abort()
+}
+
+// TraceID returns the ID of the request's trace, or the X-Request-ID the
+// client sent when the request isn't traced
+func TraceID(c *gin.Context) string {
+	if c.Request != nil {
+		if sc := trace.SpanContextFromContext(c.Request.Context()); sc.HasTraceID() {
+			return sc.TraceID().String()
+		}
+	}
+	return c.GetHeader("X-Request-ID")
+}
@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeTimeout bounds how long an audited action waits on the audit store
+const writeTimeout = 3 * time.Second
+
+type clientKey struct{}
+
+type client struct {
+	ip        string
+	userAgent string
+}
+
+// WithClient attaches the caller's address and user agent to ctx so entries
+// recorded further down the call chain carry them
+func WithClient(ctx context.Context, ip, userAgent string) context.Context {
+	return context.WithValue(ctx, clientKey{}, client{ip: ip, userAgent: userAgent})
+}
+
+// Middleware attaches the request's client to its context, see WithClient
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(WithClient(c.Request.Context(), c.ClientIP(), c.Request.UserAgent()))
+		c.Next()
+	}
+}
+
+// Logger records the audited actions of one service
+type Logger struct {
+	store   *Store
+	service string
+	logger  *slog.Logger
+}
+
+func NewLogger(store *Store, service string, logger *slog.Logger) *Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Logger{store: store, service: service, logger: logger}
+}
+
+// Record appends an entry, filling in the service, the time and the client
+// from ctx. A failed write is logged rather than returned so auditing never
+// fails the action itself. A nil Logger records nothing.
+func (l *Logger) Record(ctx context.Context, entry models.AuditEntry) {
+	if l == nil {
+		return
+	}
+	entry.Service = l.service
+	if entry.Outcome == "" {
+		entry.Outcome = models.AuditOutcomeSuccess
+	}
+	if c, ok := ctx.Value(clientKey{}).(client); ok {
+		if entry.IP == "" {
+			entry.IP = c.ip
+		}
+		if entry.UserAgent == "" {
+			entry.UserAgent = c.userAgent
+		}
+	}
+
+	// The action already happened, so the write outlives a cancelled request
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeTimeout)
+	defer cancel()
+	if err := l.store.Append(writeCtx, &entry); err != nil {
+		l.logger.Error("Failed to write audit entry", "action", entry.Action, "target_id", entry.TargetID, "error", err)
+	}
+}
@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Store is the append-only audit log. It has no way to change or remove an
+// entry once written. Services should give it a database of its own, apart
+// from the data the entries describe.
+type Store struct {
+	entries *mongo.Collection
+}
+
+func NewStore(db *mongo.Database) *Store {
+	entries := db.Collection("audit_log")
+	_, err := entries.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "action", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+	if err != nil {
+		log.Printf("Failed to create audit log indexes: %v", err)
+	}
+	return &Store{entries: entries}
+}
+
+// Append writes a new entry
+func (s *Store) Append(ctx context.Context, entry *models.AuditEntry) error {
+	entry.ID = primitive.NewObjectID()
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	_, err := s.entries.InsertOne(ctx, entry)
+	return err
+}
+
+// Filter narrows an audit log query; zero fields match everything
+type Filter struct {
+	ActorID    *primitive.ObjectID
+	TargetType string
+	TargetID   string
+	Action     models.AuditAction
+	Service    string
+	From       time.Time // Inclusive
+	To         time.Time // Exclusive
+}
+
+// Query pages through matching entries, newest first
+func (s *Store) Query(ctx context.Context, filter Filter, page, limit int64) ([]models.AuditEntry, int64, error) {
+	query := bson.M{}
+	if filter.ActorID != nil {
+		query["actor_id"] = *filter.ActorID
+	}
+	if filter.TargetType != "" {
+		query["target_type"] = filter.TargetType
+	}
+	if filter.TargetID != "" {
+		query["target_id"] = filter.TargetID
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+	if filter.Service != "" {
+		query["service"] = filter.Service
+	}
+	if !filter.From.IsZero() || !filter.To.IsZero() {
+		window := bson.M{}
+		if !filter.From.IsZero() {
+			window["$gte"] = filter.From
+		}
+		if !filter.To.IsZero() {
+			window["$lt"] = filter.To
+		}
+		query["created_at"] = window
+	}
+
+	total, err := s.entries.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := s.entries.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	entries := []models.AuditEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
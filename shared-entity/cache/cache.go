@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrNotFound is returned by loaders to signal that the underlying record does
+// not exist. When negative caching is enabled the miss is remembered so that
+// repeated lookups for missing keys do not hit the backing store.
+var ErrNotFound = errors.New("cache: not found")
+
+// negativeMarker is stored in place of a value for negatively cached keys.
+const negativeMarker = "\x00cache:nil"
+
+// LoaderFunc fetches the value for a key from the source of truth.
+type LoaderFunc[T any] func(ctx context.Context) (T, error)
+
+// Options configures a typed cache.
+type Options struct {
+	Prefix      string        // Prepended to every key, e.g. "events:categories"
+	TTL         time.Duration // Base TTL for cached values
+	Jitter      float64       // Fraction of TTL to randomise by (0.1 = ±10%)
+	NegativeTTL time.Duration // TTL for ErrNotFound results; 0 disables negative caching
+}
+
+// Cache is a Redis-backed read-through cache for values of type T.
+// Concurrent misses for the same key are collapsed into a single load so a
+// cold or expired hot key cannot stampede the backing store.
+type Cache[T any] struct {
+	client redis.Cmdable
+	codec  Codec[T]
+	opts   Options
+	group  singleflight.Group
+}
+
+// New creates a typed cache. A nil client yields a pass-through cache that
+// still de-duplicates concurrent loads but never stores anything.
+func New[T any](client redis.Cmdable, codec Codec[T], opts Options) *Cache[T] {
+	if opts.TTL <= 0 {
+		opts.TTL = 5 * time.Minute
+	}
+	if opts.Jitter < 0 {
+		opts.Jitter = 0
+	}
+	return &Cache[T]{client: client, codec: codec, opts: opts}
+}
+
+func (c *Cache[T]) key(key string) string {
+	if c.opts.Prefix == "" {
+		return key
+	}
+	return c.opts.Prefix + ":" + key
+}
+
+// Get returns the cached value for key. The boolean reports whether the key was
+// present; ErrNotFound is returned for negatively cached keys.
+func (c *Cache[T]) Get(ctx context.Context, key string) (T, bool, error) {
+	var zero T
+	if c.client == nil {
+		return zero, false, nil
+	}
+
+	data, err := c.client.Get(ctx, c.key(key)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return zero, false, nil
+		}
+		return zero, false, err
+	}
+	if string(data) == negativeMarker {
+		return zero, true, ErrNotFound
+	}
+
+	value, err := c.codec.Decode(data)
+	if err != nil {
+		return zero, false, err
+	}
+	return value, true, nil
+}
+
+// Set stores value under key using the configured TTL plus jitter.
+func (c *Cache[T]) Set(ctx context.Context, key string, value T) error {
+	if c.client == nil {
+		return nil
+	}
+	data, err := c.codec.Encode(value)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.key(key), data, c.jittered(c.opts.TTL)).Err()
+}
+
+// Delete removes one or more keys from the cache.
+func (c *Cache[T]) Delete(ctx context.Context, keys ...string) error {
+	if c.client == nil || len(keys) == 0 {
+		return nil
+	}
+	full := make([]string, len(keys))
+	for i, k := range keys {
+		full[i] = c.key(k)
+	}
+	// Keys may hash to different slots on a cluster, so delete individually.
+	var firstErr error
+	for _, k := range full {
+		if err := c.client.Del(ctx, k).Err(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// GetOrLoad returns the cached value for key, calling load on a miss.
+// Only one load per key runs at a time within this process; other callers wait
+// for its result. Errors from load are not cached, except ErrNotFound when
+// negative caching is enabled.
+func (c *Cache[T]) GetOrLoad(ctx context.Context, key string, load LoaderFunc[T]) (T, error) {
+	var zero T
+
+	value, found, err := c.Get(ctx, key)
+	if found {
+		return value, err
+	}
+	// Any other read error degrades to a load; Redis being down should not
+	// take the caller down with it.
+
+	ch := c.group.DoChan(key, func() (interface{}, error) {
+		// Detach from the first caller's cancellation so waiters are not
+		// failed just because that caller went away.
+		loadCtx := context.WithoutCancel(ctx)
+
+		// Another instance may have filled the key while we queued.
+		if v, ok, err := c.Get(loadCtx, key); ok {
+			return v, err
+		}
+
+		v, err := load(loadCtx)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) && c.opts.NegativeTTL > 0 && c.client != nil {
+				_ = c.client.Set(loadCtx, c.key(key), negativeMarker, c.jittered(c.opts.NegativeTTL)).Err()
+			}
+			return v, err
+		}
+		_ = c.Set(loadCtx, key, v)
+		return v, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-ch:
+		if res.Val == nil {
+			return zero, res.Err
+		}
+		return res.Val.(T), res.Err
+	}
+}
+
+// jittered spreads expirations of keys written together so they do not all
+// expire in the same instant.
+func (c *Cache[T]) jittered(ttl time.Duration) time.Duration {
+	if c.opts.Jitter == 0 || ttl <= 0 {
+		return ttl
+	}
+	spread := float64(ttl) * c.opts.Jitter
+	offset := (rand.Float64()*2 - 1) * spread
+	if d := ttl + time.Duration(offset); d > 0 {
+		return d
+	}
+	return ttl
+}
@@ -0,0 +1,234 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeRedis keeps keys in memory and records the TTL each was written with.
+// Only the commands Cache uses are implemented.
+type fakeRedis struct {
+	redis.Cmdable
+
+	mu   sync.Mutex
+	data map[string]string
+	ttls map[string]time.Duration
+	gets int
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{data: map[string]string{}, ttls: map[string]time.Duration{}}
+}
+
+func (f *fakeRedis) Get(ctx context.Context, key string) *redis.StringCmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.gets++
+	value, ok := f.data[key]
+	if !ok {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(value, nil)
+}
+
+func (f *fakeRedis) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch v := value.(type) {
+	case []byte:
+		f.data[key] = string(v)
+	case string:
+		f.data[key] = v
+	}
+	f.ttls[key] = expiration
+	return redis.NewStatusResult("OK", nil)
+}
+
+func (f *fakeRedis) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var n int64
+	for _, key := range keys {
+		if _, ok := f.data[key]; ok {
+			delete(f.data, key)
+			delete(f.ttls, key)
+			n++
+		}
+	}
+	return redis.NewIntResult(n, nil)
+}
+
+// expire drops a key as Redis would once its TTL ran out
+func (f *fakeRedis) expire(key string) {
+	f.Del(context.Background(), key)
+}
+
+func (f *fakeRedis) getCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.gets
+}
+
+func (f *fakeRedis) ttl(key string) (time.Duration, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ttl, ok := f.ttls[key]
+	return ttl, ok
+}
+
+func TestGetOrLoadCollapsesConcurrentMisses(t *testing.T) {
+	store := newFakeRedis()
+	c := New[string](store, StringCodec{}, Options{Prefix: "test", TTL: time.Minute})
+
+	const callers = 20
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (string, error) {
+		loads.Add(1)
+		<-release
+		return "value", nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = c.GetOrLoad(context.Background(), "hot", load)
+		}(i)
+	}
+
+	// Every caller has missed, plus the loader's own re-check; give the
+	// last of them a moment to join the in-flight load before releasing it
+	deadline := time.Now().Add(5 * time.Second)
+	for store.getCount() < callers+1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("callers did not all miss, %d gets", store.getCount())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := loads.Load(); n != 1 {
+		t.Fatalf("loader called %d times, want 1", n)
+	}
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil || results[i] != "value" {
+			t.Fatalf("caller %d got %q, %v", i, results[i], errs[i])
+		}
+	}
+
+	if _, err := c.GetOrLoad(context.Background(), "hot", load); err != nil {
+		t.Fatal(err)
+	}
+	if n := loads.Load(); n != 1 {
+		t.Fatalf("cached value reloaded, loader called %d times", n)
+	}
+}
+
+func TestGetOrLoadCachesNotFound(t *testing.T) {
+	store := newFakeRedis()
+	c := New[string](store, StringCodec{}, Options{Prefix: "test", TTL: time.Minute, NegativeTTL: 30 * time.Second})
+
+	var loads int
+	load := func(ctx context.Context) (string, error) {
+		loads++
+		return "", ErrNotFound
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetOrLoad(context.Background(), "missing", load); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("got %v, want ErrNotFound", err)
+		}
+	}
+	if loads != 1 {
+		t.Fatalf("loader called %d times, want 1", loads)
+	}
+	if ttl, ok := store.ttl("test:missing"); !ok || ttl != 30*time.Second {
+		t.Fatalf("negative entry TTL %v (stored %v), want 30s", ttl, ok)
+	}
+
+	_, found, err := c.Get(context.Background(), "missing")
+	if !found || !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get = found %v, %v; want negatively cached", found, err)
+	}
+
+	// Once the negative TTL runs out the loader is asked again
+	store.expire("test:missing")
+	if _, err := c.GetOrLoad(context.Background(), "missing", load); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got %v, want ErrNotFound", err)
+	}
+	if loads != 2 {
+		t.Fatalf("loader called %d times after expiry, want 2", loads)
+	}
+}
+
+func TestGetOrLoadDoesNotCacheNotFoundWithoutNegativeTTL(t *testing.T) {
+	store := newFakeRedis()
+	c := New[string](store, StringCodec{}, Options{Prefix: "test", TTL: time.Minute})
+
+	var loads int
+	load := func(ctx context.Context) (string, error) {
+		loads++
+		return "", ErrNotFound
+	}
+	for i := 0; i < 2; i++ {
+		_, _ = c.GetOrLoad(context.Background(), "missing", load)
+	}
+	if loads != 2 {
+		t.Fatalf("loader called %d times, want 2", loads)
+	}
+}
+
+func TestDeleteEvictsEntry(t *testing.T) {
+	store := newFakeRedis()
+	c := New[string](store, StringCodec{}, Options{Prefix: "test", TTL: time.Minute})
+
+	version := "v1"
+	var loads int
+	load := func(ctx context.Context) (string, error) {
+		loads++
+		return version, nil
+	}
+
+	if v, err := c.GetOrLoad(context.Background(), "key", load); err != nil || v != "v1" {
+		t.Fatalf("got %q, %v", v, err)
+	}
+	version = "v2"
+	if v, _ := c.GetOrLoad(context.Background(), "key", load); v != "v1" {
+		t.Fatalf("got %q before invalidation, want cached v1", v)
+	}
+
+	if err := c.Delete(context.Background(), "key"); err != nil {
+		t.Fatal(err)
+	}
+	if _, found, _ := c.Get(context.Background(), "key"); found {
+		t.Fatal("entry still cached after Delete")
+	}
+	if v, err := c.GetOrLoad(context.Background(), "key", load); err != nil || v != "v2" {
+		t.Fatalf("got %q, %v after invalidation, want v2", v, err)
+	}
+	if loads != 2 {
+		t.Fatalf("loader called %d times, want 2", loads)
+	}
+}
+
+func TestJitteredStaysWithinSpread(t *testing.T) {
+	c := New[string](nil, StringCodec{}, Options{TTL: time.Minute, Jitter: 0.1})
+	for i := 0; i < 100; i++ {
+		ttl := c.jittered(time.Minute)
+		if ttl < 54*time.Second || ttl > 66*time.Second {
+			t.Fatalf("jittered TTL %v outside ±10%% of 1m", ttl)
+		}
+	}
+}
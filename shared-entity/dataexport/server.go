@@ -0,0 +1,95 @@
+// Package dataexport lets a service contribute to a user's "Download your
+// information" archive.
+//
+// The orchestrator in user-service calls DataExportService.ExportUserData on
+// every service holding user data. Each service serves it by registering a
+// Server around an ExportFunc that collects the user's records as sections,
+// one JSON file each in the archive.
+package dataexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	exportpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/export/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExportFunc collects everything a service holds for a user. It must only
+// read: the orchestrator retries services that fail.
+type ExportFunc func(ctx context.Context, userID string) ([]*exportpb.ExportSection, error)
+
+// Server serves DataExportService for one service
+type Server struct {
+	exportpb.UnimplementedDataExportServiceServer
+	service string
+	export  ExportFunc
+}
+
+func NewServer(service string, export ExportFunc) *Server {
+	return &Server{service: service, export: export}
+}
+
+func (s *Server) Register(grpcServer *grpc.Server) {
+	exportpb.RegisterDataExportServiceServer(grpcServer, s)
+}
+
+func (s *Server) ExportUserData(ctx context.Context, req *exportpb.ExportUserDataRequest) (*exportpb.ExportUserDataResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	sections, err := s.export(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s export failed: %v", s.service, err)
+	}
+	return &exportpb.ExportUserDataResponse{Service: s.service, Sections: sections}, nil
+}
+
+// Section encodes records, a slice, as the named section of the archive
+func Section(name string, records any, mediaURLs ...string) (*exportpb.ExportSection, error) {
+	v := reflect.ValueOf(records)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("section %s: records must be a slice, got %T", name, records)
+	}
+	data := []byte("[]")
+	if v.Len() > 0 {
+		var err error
+		if data, err = json.Marshal(records); err != nil {
+			return nil, fmt.Errorf("section %s: %w", name, err)
+		}
+	}
+	return &exportpb.ExportSection{
+		Name:        name,
+		RecordsJson: data,
+		RecordCount: int64(v.Len()),
+		MediaUrls:   mediaURLs,
+	}, nil
+}
+
+// Builder gathers sections, keeping the first error so exporters can add
+// sections without checking each one
+type Builder struct {
+	sections []*exportpb.ExportSection
+	err      error
+}
+
+// Add appends a section unless an earlier one failed
+func (b *Builder) Add(name string, records any, mediaURLs ...string) {
+	if b.err != nil {
+		return
+	}
+	section, err := Section(name, records, mediaURLs...)
+	if err != nil {
+		b.err = err
+		return
+	}
+	b.sections = append(b.sections, section)
+}
+
+func (b *Builder) Sections() ([]*exportpb.ExportSection, error) {
+	return b.sections, b.err
+}
@@ -3,6 +3,7 @@ package events
 import (
 	"time"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -13,6 +14,7 @@ type UserUpdatedEvent struct {
 	FullName    string     `json:"full_name"`
 	Avatar      string     `json:"avatar"`
 	DateOfBirth *time.Time `json:"date_of_birth"`
+	Verified    bool       `json:"verified"`
 }
 
 // FriendshipEvent represents a change in friendship status.
@@ -24,6 +26,40 @@ type FriendshipEvent struct {
 	Timestamp   time.Time `json:"timestamp"`
 }
 
+// FollowEvent represents a user starting or stopping to follow another.
+type FollowEvent struct {
+	FollowerID string    `json:"follower_id"`
+	FolloweeID string    `json:"followee_id"`
+	Action     string    `json:"action"` // "follow", "unfollow"
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// InsightEvent counts one interaction with a user's profile or content toward
+// their insights. ActorID is empty for signed-out viewers.
+type InsightEvent struct {
+	OwnerID   string               `json:"owner_id"`
+	Metric    models.InsightMetric `json:"metric"`
+	ActorID   string               `json:"actor_id,omitempty"`
+	TargetID  string               `json:"target_id,omitempty"`
+	Timestamp time.Time            `json:"timestamp"`
+}
+
+// Email templates the mailer renders
+const (
+	EmailTemplateVerifyEmail   = "verify_email"
+	EmailTemplatePasswordReset = "password_reset"
+)
+
+// EmailRequestedEvent asks the mailer to send one templated email. Data fills
+// the template's placeholders, and may hold single-use links.
+type EmailRequestedEvent struct {
+	Template  string            `json:"template"`
+	To        string            `json:"to"`
+	UserID    string            `json:"user_id,omitempty"`
+	Data      map[string]string `json:"data,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
 // NotificationCreatedEvent represents a new notification to be persisted and delivered.
 type NotificationCreatedEvent struct {
 	ID          primitive.ObjectID     `json:"id" bson:"_id,omitempty"`
@@ -37,3 +73,68 @@ type NotificationCreatedEvent struct {
 	Read        bool                   `json:"read" bson:"read"`
 	CreatedAt   time.Time              `json:"created_at" bson:"created_at"`
 }
+
+// UserErasureRequestedEvent asks a participating service to erase all data it
+// holds for a user. Service names the target; services ignore events addressed
+// to others so retries can be directed at a single participant.
+type UserErasureRequestedEvent struct {
+	RequestID   string    `json:"request_id"`
+	UserID      string    `json:"user_id"`
+	Service     string    `json:"service"`
+	Attempt     int       `json:"attempt"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// UserErasureProgressEvent is a participant's acknowledgement of an erasure request.
+type UserErasureProgressEvent struct {
+	RequestID   string    `json:"request_id"`
+	UserID      string    `json:"user_id"`
+	Service     string    `json:"service"`
+	Success     bool      `json:"success"`
+	ItemsErased int64     `json:"items_erased"`
+	Error       string    `json:"error,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// UserDeletedEvent tells a participating service that a user's account was
+// deleted at the end of its grace period, so it purges or anonymizes what the
+// user left behind. Like erasure requests, each event names one service.
+type UserDeletedEvent struct {
+	DeletionID string    `json:"deletion_id"`
+	UserID     string    `json:"user_id"`
+	Service    string    `json:"service"`
+	Attempt    int       `json:"attempt"`
+	DeletedAt  time.Time `json:"deleted_at"`
+}
+
+// UserDeletionProgressEvent is a participant's acknowledgement of a UserDeletedEvent.
+type UserDeletionProgressEvent struct {
+	DeletionID   string    `json:"deletion_id"`
+	UserID       string    `json:"user_id"`
+	Service      string    `json:"service"`
+	Success      bool      `json:"success"`
+	ItemsDeleted int64     `json:"items_deleted"`
+	Error        string    `json:"error,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// SearchIndexOp is the change a SearchIndexEvent applies to the index.
+type SearchIndexOp string
+
+const (
+	SearchIndexUpsert SearchIndexOp = "upsert"
+	SearchIndexDelete SearchIndexOp = "delete"
+)
+
+// SearchIndexEvent carries an entity change to the search indexers. Exactly
+// one document field is set for upserts, matching Entity; deletes carry only ID.
+type SearchIndexEvent struct {
+	Op        SearchIndexOp                 `json:"op"`
+	Entity    models.SearchEntityType       `json:"entity"`
+	ID        string                        `json:"id"`
+	User      *models.SearchUserDocument    `json:"user,omitempty"`
+	Post      *models.SearchPostDocument    `json:"post,omitempty"`
+	Event     *models.SearchEventDocument   `json:"event,omitempty"`
+	Listing   *models.SearchListingDocument `json:"listing,omitempty"`
+	Timestamp time.Time                     `json:"timestamp"`
+}
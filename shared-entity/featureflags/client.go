@@ -0,0 +1,166 @@
+package featureflags
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	// reloadAll on ChangedChannel asks Clients to reload every flag
+	reloadAll = "*"
+	// DefaultRefreshInterval bounds how stale a Client gets if it misses a
+	// change notification, e.g. while reconnecting to Redis
+	DefaultRefreshInterval = time.Minute
+)
+
+// Client evaluates flags from a local copy of the Redis hash. Watch keeps the
+// copy current. Unknown flags are off. A nil Client has every flag off.
+type Client struct {
+	redis   goredis.UniversalClient
+	refresh time.Duration
+	logger  *slog.Logger
+
+	mu    sync.RWMutex
+	flags map[string]models.FeatureFlag
+}
+
+func NewClient(redis goredis.UniversalClient, refresh time.Duration, logger *slog.Logger) *Client {
+	if refresh <= 0 {
+		refresh = DefaultRefreshInterval
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Client{redis: redis, refresh: refresh, logger: logger, flags: map[string]models.FeatureFlag{}}
+}
+
+// Enabled reports whether the flag is on for userID
+func (c *Client) Enabled(key, userID string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.RLock()
+	flag, ok := c.flags[key]
+	c.mu.RUnlock()
+	return ok && Evaluate(&flag, userID)
+}
+
+// Evaluate reports whether flag is on for userID
+func Evaluate(flag *models.FeatureFlag, userID string) bool {
+	switch {
+	case !flag.Enabled:
+		return false
+	case slices.Contains(flag.DenyUsers, userID):
+		return false
+	case slices.Contains(flag.AllowUsers, userID):
+		return true
+	case flag.RolloutPercent <= 0 || userID == "":
+		return false
+	case flag.RolloutPercent >= 100:
+		return true
+	}
+	return Bucket(flag.Key, userID) < flag.RolloutPercent
+}
+
+// Bucket places userID in one of 100 buckets for the flag. The flag key is
+// part of the hash so each flag rolls out to a different set of users first.
+func Bucket(key, userID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write([]byte{':'})
+	h.Write([]byte(userID))
+	return int(h.Sum32() % 100)
+}
+
+// Load replaces the local copy with every flag in Redis
+func (c *Client) Load(ctx context.Context) error {
+	fields, err := c.redis.HGetAll(ctx, RedisKey).Result()
+	if err != nil {
+		return err
+	}
+	flags := make(map[string]models.FeatureFlag, len(fields))
+	for key, data := range fields {
+		var flag models.FeatureFlag
+		if err := json.Unmarshal([]byte(data), &flag); err != nil {
+			c.logger.Warn("Skipping malformed feature flag", "key", key, "error", err)
+			continue
+		}
+		flags[key] = flag
+	}
+
+	c.mu.Lock()
+	c.flags = flags
+	c.mu.Unlock()
+	return nil
+}
+
+// reload refreshes one flag, or all of them for reloadAll
+func (c *Client) reload(ctx context.Context, key string) error {
+	if key == reloadAll {
+		return c.Load(ctx)
+	}
+	data, err := c.redis.HGet(ctx, RedisKey, key).Result()
+	if err == goredis.Nil {
+		c.mu.Lock()
+		delete(c.flags, key)
+		c.mu.Unlock()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var flag models.FeatureFlag
+	if err := json.Unmarshal([]byte(data), &flag); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.flags[key] = flag
+	c.mu.Unlock()
+	return nil
+}
+
+// Watch loads the flags, then applies changes as they are announced until ctx
+// is done. Everything is also reloaded every refresh interval in case a
+// notification was missed. Run it in its own goroutine.
+func (c *Client) Watch(ctx context.Context) {
+	// Subscribe before loading so no change falls in between
+	pubsub := c.redis.Subscribe(ctx, ChangedChannel)
+	defer pubsub.Close()
+	if _, err := pubsub.Receive(ctx); err != nil {
+		c.logger.Error("Failed to subscribe to feature flag changes", "error", err)
+	}
+	if err := c.Load(ctx); err != nil {
+		c.logger.Error("Failed to load feature flags", "error", err)
+	}
+	changes := pubsub.Channel()
+	ticker := time.NewTicker(c.refresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-changes:
+			if !ok {
+				return
+			}
+			if err := c.reload(ctx, msg.Payload); err != nil {
+				c.logger.Error("Failed to reload feature flag", "key", msg.Payload, "error", err)
+			}
+		case <-ticker.C:
+			if err := c.Load(ctx); err != nil {
+				c.logger.Error("Failed to refresh feature flags", "error", err)
+			}
+		}
+	}
+}
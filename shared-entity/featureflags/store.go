@@ -0,0 +1,139 @@
+package featureflags
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// RedisKey is the hash every service reads flags from, one JSON field per key
+	RedisKey = "feature_flags"
+	// ChangedChannel carries the key of each flag that was changed or deleted
+	ChangedChannel = "feature_flags:changed"
+)
+
+var ErrNotFound = errors.New("feature flag not found")
+
+// Store keeps flags in Mongo and mirrors them into Redis, where Clients in
+// every service read them, announcing each change on ChangedChannel.
+type Store struct {
+	flags *mongo.Collection
+	redis goredis.UniversalClient
+}
+
+func NewStore(db *mongo.Database, redis goredis.UniversalClient) *Store {
+	return &Store{flags: db.Collection("feature_flags"), redis: redis}
+}
+
+// List returns all flags ordered by key
+func (s *Store) List(ctx context.Context) ([]models.FeatureFlag, error) {
+	cursor, err := s.flags.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	flags := []models.FeatureFlag{}
+	if err := cursor.All(ctx, &flags); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+func (s *Store) Get(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	var flag models.FeatureFlag
+	if err := s.flags.FindOne(ctx, bson.M{"_id": key}).Decode(&flag); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &flag, nil
+}
+
+// Put creates or replaces the flag with flag.Key
+func (s *Store) Put(ctx context.Context, flag *models.FeatureFlag) (*models.FeatureFlag, error) {
+	now := time.Now()
+	flag.UpdatedAt = now
+	update := bson.M{
+		"$set": bson.M{
+			"description":     flag.Description,
+			"enabled":         flag.Enabled,
+			"rollout_percent": flag.RolloutPercent,
+			"allow_users":     flag.AllowUsers,
+			"deny_users":      flag.DenyUsers,
+			"updated_by":      flag.UpdatedBy,
+			"updated_at":      now,
+		},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var saved models.FeatureFlag
+	if err := s.flags.FindOneAndUpdate(ctx, bson.M{"_id": flag.Key}, update, opts).Decode(&saved); err != nil {
+		return nil, err
+	}
+	if err := s.publish(ctx, &saved); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	result, err := s.flags.DeleteOne(ctx, bson.M{"_id": key})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	if err := s.redis.HDel(ctx, RedisKey, key).Err(); err != nil {
+		return fmt.Errorf("failed to remove feature flag from redis: %w", err)
+	}
+	return s.redis.Publish(ctx, ChangedChannel, key).Err()
+}
+
+// Sync rewrites the Redis copy from Mongo, dropping flags Redis still has but
+// Mongo does not. Run it at startup in case a change never reached Redis.
+func (s *Store) Sync(ctx context.Context) error {
+	flags, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+	fields := make(map[string]interface{}, len(flags))
+	for i := range flags {
+		data, err := json.Marshal(flags[i])
+		if err != nil {
+			return err
+		}
+		fields[flags[i].Key] = data
+	}
+
+	pipe := s.redis.TxPipeline()
+	pipe.Del(ctx, RedisKey)
+	if len(fields) > 0 {
+		pipe.HSet(ctx, RedisKey, fields)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	return s.redis.Publish(ctx, ChangedChannel, reloadAll).Err()
+}
+
+func (s *Store) publish(ctx context.Context, flag *models.FeatureFlag) error {
+	data, err := json.Marshal(flag)
+	if err != nil {
+		return err
+	}
+	if err := s.redis.HSet(ctx, RedisKey, flag.Key, data).Err(); err != nil {
+		return fmt.Errorf("failed to write feature flag to redis: %w", err)
+	}
+	return s.redis.Publish(ctx, ChangedChannel, flag.Key).Err()
+}
@@ -0,0 +1,121 @@
+package jwks
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeyRing is the issuing side of a key set: the private key new tokens are
+// signed with, and the public keys published for verifiers.
+//
+// A rotation publishes the next key alongside the current one, then signs
+// with it while the old key stays published until the tokens it signed have
+// expired. Both keys verify during that window.
+type KeyRing struct {
+	signing   *rsa.PrivateKey
+	signingID string
+	keys      map[string]*rsa.PublicKey
+}
+
+// NewKeyRing signs with the RSA private key in signingPEM and also publishes
+// the keys in publishedPEM, any number of concatenated PEM blocks holding
+// public or private RSA keys. Key IDs are the keys' RFC 7638 thumbprints.
+func NewKeyRing(signingPEM, publishedPEM string) (*KeyRing, error) {
+	signing, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(signingPEM))
+	if err != nil {
+		return nil, fmt.Errorf("jwks: signing key: %w", err)
+	}
+	r := &KeyRing{
+		signing:   signing,
+		signingID: KeyID(&signing.PublicKey),
+		keys:      map[string]*rsa.PublicKey{},
+	}
+	r.keys[r.signingID] = &signing.PublicKey
+
+	rest := []byte(publishedPEM)
+	for {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		key, err := parsePublicBlock(block)
+		if err != nil {
+			return nil, err
+		}
+		r.keys[KeyID(key)] = key
+	}
+	return r, nil
+}
+
+func parsePublicBlock(block *pem.Block) (*rsa.PublicKey, error) {
+	encoded := pem.EncodeToMemory(block)
+	switch block.Type {
+	case "RSA PRIVATE KEY", "PRIVATE KEY":
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: published key: %w", err)
+		}
+		return &key.PublicKey, nil
+	case "PUBLIC KEY", "RSA PUBLIC KEY":
+		key, err := jwt.ParseRSAPublicKeyFromPEM(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: published key: %w", err)
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("jwks: unsupported PEM block %q", block.Type)
+}
+
+// KeyID is the RFC 7638 thumbprint of key
+func KeyID(key *rsa.PublicKey) string {
+	n, e := EncodeRSAKey(key)
+	// Members in lexicographic order, as the thumbprint requires
+	canonical := fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, e, n)
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Sign signs claims with the current key, naming it in the kid header
+func (r *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = r.signingID
+	return token.SignedString(r.signing)
+}
+
+// Key returns the published key with key ID kid, so a service can verify
+// its own tokens without fetching its key set
+func (r *KeyRing) Key(_ context.Context, kid string) (*rsa.PublicKey, error) {
+	if key, ok := r.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrKeyNotFound
+}
+
+// Document is the JSON Web Key Set to publish, current key first
+func (r *KeyRing) Document() ([]byte, error) {
+	ids := make([]string, 0, len(r.keys))
+	for id := range r.keys {
+		if id != r.signingID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	ids = append([]string{r.signingID}, ids...)
+
+	set := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{Keys: make([]jsonWebKey, 0, len(ids))}
+	for _, id := range ids {
+		n, e := EncodeRSAKey(r.keys[id])
+		set.Keys = append(set.Keys, jsonWebKey{Kty: "RSA", Kid: id, Use: "sig", Alg: jwt.SigningMethodRS256.Alg(), N: n, E: e})
+	}
+	return json.Marshal(set)
+}
@@ -0,0 +1,212 @@
+// Package jwks fetches and caches the RSA signing keys an issuer publishes
+// as a JSON Web Key Set.
+//
+// Issuers rotate their keys by publishing the next key alongside the current
+// one before signing with it, then dropping the old one. The KeySet keeps
+// the published keys for MaxAge and refetches early when a token names a key
+// it has not seen, so a rotation is picked up without waiting for the cache
+// to expire. Unknown key IDs refetch at most once per MinRefresh, which keeps
+// tokens with made-up key IDs from hammering the issuer.
+package jwks
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrKeyNotFound is returned for a key ID the issuer does not publish
+var ErrKeyNotFound = errors.New("jwks: signing key not found")
+
+// Options configures a KeySet
+type Options struct {
+	MaxAge     time.Duration // How long fetched keys are trusted before refetching
+	MinRefresh time.Duration // Minimum time between fetches for unknown key IDs
+	Client     *http.Client
+}
+
+func DefaultOptions() Options {
+	return Options{
+		MaxAge:     time.Hour,
+		MinRefresh: time.Minute,
+		Client:     &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// KeySet is the cached key set published at one URL. Lookups never wait on
+// each other: the lock only guards reading and swapping the cached keys, and
+// concurrent refreshes share one fetch.
+type KeySet struct {
+	url   string
+	opts  Options
+	group singleflight.Group
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+func NewKeySet(url string, opts Options) *KeySet {
+	if opts.Client == nil {
+		opts.Client = DefaultOptions().Client
+	}
+	return &KeySet{url: url, opts: opts}
+}
+
+// Key returns the public key with key ID kid
+func (k *KeySet) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	key, fresh := k.cached(kid)
+	if fresh {
+		return key, nil
+	}
+
+	keys, err := k.refresh(ctx)
+	if err != nil {
+		if key != nil {
+			// Keep verifying with the keys we have while the issuer is down
+			return key, nil
+		}
+		return nil, err
+	}
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrKeyNotFound
+}
+
+// cached returns the cached key with key ID kid, if any, and whether it is
+// recent enough to use without refetching
+func (k *KeySet) cached(kid string) (*rsa.PublicKey, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, ok := k.keys[kid]
+	return key, ok && time.Since(k.fetchedAt) <= k.opts.MaxAge
+}
+
+// refresh refetches the key set and returns it. Callers arriving during a
+// fetch wait for it instead of starting their own, and within MinRefresh of
+// the last attempt the cached keys are returned as they are.
+func (k *KeySet) refresh(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	ch := k.group.DoChan("", func() (interface{}, error) {
+		k.mu.Lock()
+		if time.Since(k.attemptedAt) < k.opts.MinRefresh {
+			keys := k.keys
+			k.mu.Unlock()
+			return keys, nil
+		}
+		k.attemptedAt = time.Now()
+		k.mu.Unlock()
+
+		// The fetch outlives the caller that started it, as others wait on
+		// it too; the client's timeout bounds it
+		keys, err := k.fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		k.mu.Lock()
+		k.keys = keys
+		k.fetchedAt = time.Now()
+		k.mu.Unlock()
+		return keys, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		keys, _ := res.Val.(map[string]*rsa.PublicKey)
+		return keys, nil
+	}
+}
+
+// Keyfunc looks up the key a token names in its kid header, for jwt.Parse
+func (k *KeySet) Keyfunc(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, ErrKeyNotFound
+		}
+		return k.Key(ctx, kid)
+	}
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func (k *KeySet) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := k.opts.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("jwks: fetch %s: %w", k.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks: fetch %s: status %d", k.url, resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("jwks: decode %s: %w", k.url, err)
+	}
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" || jwk.Kid == "" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := ParseRSAKey(jwk.N, jwk.E)
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+// ParseRSAKey builds a public key from a JWK's base64url modulus and exponent
+func ParseRSAKey(n, e string) (*rsa.PublicKey, error) {
+	nb, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, fmt.Errorf("jwks: modulus: %w", err)
+	}
+	eb, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return nil, fmt.Errorf("jwks: exponent: %w", err)
+	}
+	exponent := new(big.Int).SetBytes(eb)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("jwks: exponent out of range")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(nb), E: int(exponent.Int64())}, nil
+}
+
+// EncodeRSAKey is the inverse of ParseRSAKey, for publishing a key set
+func EncodeRSAKey(key *rsa.PublicKey) (n, e string) {
+	return base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+}
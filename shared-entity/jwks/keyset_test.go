@@ -0,0 +1,161 @@
+package jwks
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// keyServer publishes a key set and counts the fetches of it. While gate is
+// set, fetches wait for it to close.
+type keyServer struct {
+	*httptest.Server
+	fetches atomic.Int32
+
+	mu   sync.Mutex
+	keys map[string]*rsa.PublicKey
+	gate chan struct{}
+}
+
+func newKeyServer(t *testing.T) *keyServer {
+	s := &keyServer{keys: map[string]*rsa.PublicKey{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		s.mu.Lock()
+		gate := s.gate
+		var set struct {
+			Keys []jsonWebKey `json:"keys"`
+		}
+		for kid, key := range s.keys {
+			n, e := EncodeRSAKey(key)
+			set.Keys = append(set.Keys, jsonWebKey{Kty: "RSA", Kid: kid, Use: "sig", N: n, E: e})
+		}
+		s.mu.Unlock()
+		if gate != nil {
+			<-gate
+		}
+		_ = json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *keyServer) publish(t *testing.T, kid string) *rsa.PublicKey {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[kid] = &private.PublicKey
+	return &private.PublicKey
+}
+
+func (s *keyServer) hold() chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gate = make(chan struct{})
+	return s.gate
+}
+
+func TestKeySetDoesNotBlockCachedKeysDuringFetch(t *testing.T) {
+	server := newKeyServer(t)
+	known := server.publish(t, "known")
+	keys := NewKeySet(server.URL, Options{MaxAge: time.Hour})
+
+	if key, err := keys.Key(context.Background(), "known"); err != nil || !key.Equal(known) {
+		t.Fatalf("Key = %v, %v", key, err)
+	}
+
+	// An unknown kid starts a fetch that hangs at the issuer
+	gate := server.hold()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = keys.Key(context.Background(), "unknown")
+	}()
+	for server.fetches.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	lookup := make(chan error, 1)
+	go func() {
+		_, err := keys.Key(context.Background(), "known")
+		lookup <- err
+	}()
+	select {
+	case err := <-lookup:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cached key lookup waited on the fetch")
+	}
+
+	close(gate)
+	<-done
+}
+
+func TestKeySetSharesOneFetchAcrossCallers(t *testing.T) {
+	server := newKeyServer(t)
+	rotated := server.publish(t, "rotated")
+	keys := NewKeySet(server.URL, Options{MaxAge: time.Hour})
+
+	gate := server.hold()
+	const callers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key, err := keys.Key(context.Background(), "rotated")
+			if err == nil && !key.Equal(rotated) {
+				err = errors.New("wrong key")
+			}
+			errs <- err
+		}()
+	}
+	for server.fetches.Load() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(gate)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := server.fetches.Load(); n != 1 {
+		t.Fatalf("%d fetches, want 1", n)
+	}
+}
+
+func TestKeySetRateLimitsUnknownKeyRefetches(t *testing.T) {
+	server := newKeyServer(t)
+	server.publish(t, "known")
+	keys := NewKeySet(server.URL, Options{MaxAge: time.Hour, MinRefresh: time.Minute})
+
+	for i := 0; i < 5; i++ {
+		if _, err := keys.Key(context.Background(), "made-up"); !errors.Is(err, ErrKeyNotFound) {
+			t.Fatalf("got %v, want ErrKeyNotFound", err)
+		}
+	}
+	if n := server.fetches.Load(); n != 1 {
+		t.Fatalf("%d fetches for unknown kids, want 1 per MinRefresh", n)
+	}
+	if _, err := keys.Key(context.Background(), "known"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,115 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/segmentio/kafka-go"
+)
+
+// Topics used by the account deletion saga in user-service.
+const (
+	UserDeletedTopic          = "user-deleted"
+	UserDeletionProgressTopic = "user-deletion-progress"
+)
+
+// DeleteUserFunc purges or anonymizes everything a service holds for a
+// deleted user and reports how many items it touched. It must be idempotent:
+// the saga redelivers events that are not acknowledged in time.
+type DeleteUserFunc func(ctx context.Context, userID string) (int64, error)
+
+// UserDeletionParticipant consumes UserDeleted events addressed to one
+// service, runs the service's deleter and acknowledges the outcome back to
+// the saga.
+type UserDeletionParticipant struct {
+	service string
+	reader  *kafka.Reader
+	writer  *kafka.Writer
+	del     DeleteUserFunc
+}
+
+func NewUserDeletionParticipant(brokers []string, service string, del DeleteUserFunc) *UserDeletionParticipant {
+	return &UserDeletionParticipant{
+		service: service,
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:  brokers,
+			Topic:    UserDeletedTopic,
+			GroupID:  "user-deleted-" + service,
+			MinBytes: 1,
+			MaxBytes: 1e6,
+		}),
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Topic:    UserDeletionProgressTopic,
+			Balancer: &kafka.Hash{},
+		},
+		del: del,
+	}
+}
+
+// Run processes UserDeleted events until ctx is cancelled.
+func (p *UserDeletionParticipant) Run(ctx context.Context) {
+	log.Printf("User deletion participant %q started", p.service)
+	for {
+		msg, err := p.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return
+			}
+			log.Printf("User deletion participant %q fetch error: %v", p.service, err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		var evt events.UserDeletedEvent
+		if err := json.Unmarshal(msg.Value, &evt); err != nil {
+			log.Printf("User deletion participant %q: dropping malformed event: %v", p.service, err)
+		} else if evt.Service == p.service {
+			p.handle(ctx, evt)
+		}
+
+		if err := p.reader.CommitMessages(ctx, msg); err != nil {
+			log.Printf("User deletion participant %q commit error: %v", p.service, err)
+		}
+	}
+}
+
+func (p *UserDeletionParticipant) handle(ctx context.Context, evt events.UserDeletedEvent) {
+	ack := events.UserDeletionProgressEvent{
+		DeletionID: evt.DeletionID,
+		UserID:     evt.UserID,
+		Service:    p.service,
+	}
+
+	items, err := p.del(ctx, evt.UserID)
+	if err != nil {
+		ack.Error = err.Error()
+		log.Printf("User deletion participant %q failed for user %s (attempt %d): %v", p.service, evt.UserID, evt.Attempt, err)
+	} else {
+		ack.Success = true
+		ack.ItemsDeleted = items
+	}
+	ack.Timestamp = time.Now()
+
+	payload, err := json.Marshal(ack)
+	if err != nil {
+		return
+	}
+	// A lost acknowledgement is recovered by the saga's retry loop.
+	if err := p.writer.WriteMessages(ctx, kafka.Message{Key: []byte(evt.DeletionID), Value: payload}); err != nil {
+		log.Printf("User deletion participant %q failed to acknowledge %s: %v", p.service, evt.DeletionID, err)
+	}
+}
+
+func (p *UserDeletionParticipant) Close() error {
+	rErr := p.reader.Close()
+	wErr := p.writer.Close()
+	if rErr != nil {
+		return rErr
+	}
+	return wErr
+}
@@ -0,0 +1,114 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/segmentio/kafka-go"
+)
+
+// Topics used by the GDPR erasure orchestrator in user-service.
+const (
+	ErasureRequestTopic  = "user-erasure-requests"
+	ErasureProgressTopic = "user-erasure-progress"
+)
+
+// EraseFunc removes every record a service holds for a user and reports how
+// many items were erased. It must be idempotent: the orchestrator retries
+// requests that are not acknowledged in time.
+type EraseFunc func(ctx context.Context, userID string) (int64, error)
+
+// ErasureParticipant consumes erasure requests addressed to one service, runs
+// the service's eraser and acknowledges the outcome back to the orchestrator.
+type ErasureParticipant struct {
+	service string
+	reader  *kafka.Reader
+	writer  *kafka.Writer
+	erase   EraseFunc
+}
+
+func NewErasureParticipant(brokers []string, service string, erase EraseFunc) *ErasureParticipant {
+	return &ErasureParticipant{
+		service: service,
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:  brokers,
+			Topic:    ErasureRequestTopic,
+			GroupID:  "erasure-" + service,
+			MinBytes: 1,
+			MaxBytes: 1e6,
+		}),
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Topic:    ErasureProgressTopic,
+			Balancer: &kafka.Hash{},
+		},
+		erase: erase,
+	}
+}
+
+// Run processes erasure requests until ctx is cancelled.
+func (p *ErasureParticipant) Run(ctx context.Context) {
+	log.Printf("Erasure participant %q started", p.service)
+	for {
+		msg, err := p.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return
+			}
+			log.Printf("Erasure participant %q fetch error: %v", p.service, err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		var req events.UserErasureRequestedEvent
+		if err := json.Unmarshal(msg.Value, &req); err != nil {
+			log.Printf("Erasure participant %q: dropping malformed request: %v", p.service, err)
+		} else if req.Service == p.service {
+			p.handle(ctx, req)
+		}
+
+		if err := p.reader.CommitMessages(ctx, msg); err != nil {
+			log.Printf("Erasure participant %q commit error: %v", p.service, err)
+		}
+	}
+}
+
+func (p *ErasureParticipant) handle(ctx context.Context, req events.UserErasureRequestedEvent) {
+	ack := events.UserErasureProgressEvent{
+		RequestID: req.RequestID,
+		UserID:    req.UserID,
+		Service:   p.service,
+	}
+
+	items, err := p.erase(ctx, req.UserID)
+	if err != nil {
+		ack.Error = err.Error()
+		log.Printf("Erasure participant %q failed for user %s (attempt %d): %v", p.service, req.UserID, req.Attempt, err)
+	} else {
+		ack.Success = true
+		ack.ItemsErased = items
+	}
+	ack.Timestamp = time.Now()
+
+	payload, err := json.Marshal(ack)
+	if err != nil {
+		return
+	}
+	// A lost acknowledgement is recovered by the orchestrator's retry loop.
+	if err := p.writer.WriteMessages(ctx, kafka.Message{Key: []byte(req.RequestID), Value: payload}); err != nil {
+		log.Printf("Erasure participant %q failed to acknowledge %s: %v", p.service, req.RequestID, err)
+	}
+}
+
+func (p *ErasureParticipant) Close() error {
+	rErr := p.reader.Close()
+	wErr := p.writer.Close()
+	if rErr != nil {
+		return rErr
+	}
+	return wErr
+}
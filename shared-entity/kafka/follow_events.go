@@ -0,0 +1,5 @@
+package kafka
+
+// FollowEventsTopic carries the follows and unfollows user-service records,
+// as events.FollowEvent keyed by the follower
+const FollowEventsTopic = "follow-events"
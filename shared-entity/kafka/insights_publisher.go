@@ -0,0 +1,81 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// InsightsTopic carries profile views and post interactions, as
+// events.InsightEvent keyed by owner, to user-service's insights aggregator
+const InsightsTopic = "insight-events"
+
+// InsightsPublisher publishes interactions counted toward their owner's
+// insights. Writes are asynchronous: insights are best effort and must never
+// slow down the read or write being counted.
+type InsightsPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewInsightsPublisher(brokers []string) *InsightsPublisher {
+	return &InsightsPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        InsightsTopic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireOne,
+			Async:        true,
+			Completion: func(messages []kafka.Message, err error) {
+				if err != nil {
+					log.Printf("Insights publish failed for %d messages: %v", len(messages), err)
+				}
+			},
+		},
+	}
+}
+
+// Record counts metric toward owner. Owners interacting with their own
+// profile or content are not counted; a zero actor is a signed-out viewer.
+func (p *InsightsPublisher) Record(ctx context.Context, metric models.InsightMetric, owner, actor, target primitive.ObjectID) {
+	if p == nil || owner.IsZero() || owner == actor {
+		return
+	}
+	evt := events.InsightEvent{
+		OwnerID:   owner.Hex(),
+		Metric:    metric,
+		Timestamp: time.Now(),
+	}
+	if !actor.IsZero() {
+		evt.ActorID = actor.Hex()
+	}
+	if !target.IsZero() {
+		evt.TargetID = target.Hex()
+	}
+
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		log.Printf("Failed to marshal insight event for %s: %v", evt.OwnerID, err)
+		return
+	}
+	msg := kafka.Message{
+		Key:   []byte(evt.OwnerID),
+		Value: payload,
+		Time:  evt.Timestamp,
+	}
+	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+		log.Printf("Failed to publish insight event for %s: %v", evt.OwnerID, err)
+	}
+}
+
+func (p *InsightsPublisher) Close() error {
+	if p == nil {
+		return nil
+	}
+	return p.writer.Close()
+}
@@ -0,0 +1,144 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	// MediaUploadedTopic carries storage-service uploads to its moderation worker
+	MediaUploadedTopic = "media-uploaded"
+	// MediaModerationTopic carries moderation verdicts to the services that
+	// hold or reject the content referencing the media
+	MediaModerationTopic = "media-moderation-verdicts"
+)
+
+// MediaEventPublisher publishes upload and moderation events, keyed by
+// object key so the events of one object stay in order
+type MediaEventPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewMediaEventPublisher(brokers []string) *MediaEventPublisher {
+	return &MediaEventPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+	}
+}
+
+func (p *MediaEventPublisher) PublishUploaded(ctx context.Context, evt *models.MediaUploadedEvent) error {
+	if evt.UploadedAt.IsZero() {
+		evt.UploadedAt = time.Now()
+	}
+	return p.publish(ctx, MediaUploadedTopic, evt.Key, evt)
+}
+
+func (p *MediaEventPublisher) PublishVerdict(ctx context.Context, evt *models.MediaModerationEvent) error {
+	if evt.ModeratedAt.IsZero() {
+		evt.ModeratedAt = time.Now()
+	}
+	return p.publish(ctx, MediaModerationTopic, evt.Key, evt)
+}
+
+func (p *MediaEventPublisher) publish(ctx context.Context, topic, key string, evt any) error {
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Topic: topic,
+		Key:   []byte(key),
+		Value: payload,
+	})
+}
+
+func (p *MediaEventPublisher) Close() error {
+	if p == nil {
+		return nil
+	}
+	return p.writer.Close()
+}
+
+// ModerationVerdictHandler applies one verdict. Returning an error leaves the
+// verdict uncommitted so it is retried.
+type ModerationVerdictHandler func(ctx context.Context, evt *models.MediaModerationEvent) error
+
+// ModerationVerdictConsumer hands the verdicts on MediaModerationTopic to a
+// handler, one consumer group per service
+type ModerationVerdictConsumer struct {
+	reader  *kafka.Reader
+	handler ModerationVerdictHandler
+}
+
+func NewModerationVerdictConsumer(brokers []string, groupID string, handler ModerationVerdictHandler) *ModerationVerdictConsumer {
+	return &ModerationVerdictConsumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: brokers,
+			Topic:   MediaModerationTopic,
+			GroupID: groupID,
+			MaxWait: time.Second,
+		}),
+		handler: handler,
+	}
+}
+
+// Run consumes verdicts until ctx is cancelled
+func (c *ModerationVerdictConsumer) Run(ctx context.Context) {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Error reading moderation verdict: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		var evt models.MediaModerationEvent
+		if err := json.Unmarshal(msg.Value, &evt); err != nil {
+			log.Printf("Dropping malformed moderation verdict: %v", err)
+		} else if !c.handle(ctx, &evt) {
+			return
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil && ctx.Err() == nil {
+			log.Printf("Error committing moderation verdict: %v", err)
+		}
+	}
+}
+
+// handle retries a verdict with backoff until it applies, reporting false
+// if ctx was cancelled first
+func (c *ModerationVerdictConsumer) handle(ctx context.Context, evt *models.MediaModerationEvent) bool {
+	backoff := time.Second
+	for {
+		err := c.handler(ctx, evt)
+		if err == nil {
+			return true
+		}
+		log.Printf("Error applying moderation verdict for %s: %v", evt.Key, err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, time.Minute)
+	}
+}
+
+func (c *ModerationVerdictConsumer) Close() error {
+	if c == nil {
+		return nil
+	}
+	return c.reader.Close()
+}
@@ -0,0 +1,55 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/segmentio/kafka-go"
+)
+
+// MarketplaceOfferTopic carries offer changes from marketplace-service to the
+// messaging service, which posts them as cards in the marketplace thread
+const MarketplaceOfferTopic = "marketplace-offer-events"
+
+// OfferEventPublisher publishes marketplace offer events. Writes are
+// synchronous so events of one negotiation reach the topic in order.
+type OfferEventPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewOfferEventPublisher(brokers []string) *OfferEventPublisher {
+	return &OfferEventPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        MarketplaceOfferTopic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+	}
+}
+
+func (p *OfferEventPublisher) PublishOfferEvent(ctx context.Context, evt *models.MarketplaceOfferEvent) error {
+	if evt.Timestamp.IsZero() {
+		evt.Timestamp = time.Now()
+	}
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+
+	// Keyed by conversation so a thread's cards stay in order
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(evt.Offer.ConversationID),
+		Value: payload,
+		Time:  evt.Timestamp,
+	})
+}
+
+func (p *OfferEventPublisher) Close() error {
+	if p == nil {
+		return nil
+	}
+	return p.writer.Close()
+}
@@ -0,0 +1,93 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/segmentio/kafka-go"
+)
+
+// SearchIndexTopic returns the topic feeding the search indexer for an entity
+// type, e.g. "search-index-posts".
+func SearchIndexTopic(entity models.SearchEntityType) string {
+	return "search-index-" + string(entity) + "s"
+}
+
+// SearchIndexPublisher publishes entity changes for search-service to index.
+// Writes are asynchronous: search is eventually consistent and must never
+// slow down or fail the owning service's write path.
+type SearchIndexPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewSearchIndexPublisher(brokers []string) *SearchIndexPublisher {
+	return &SearchIndexPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireOne,
+			Async:        true,
+			Completion: func(messages []kafka.Message, err error) {
+				if err != nil {
+					log.Printf("Search index publish failed for %d messages: %v", len(messages), err)
+				}
+			},
+		},
+	}
+}
+
+func (p *SearchIndexPublisher) UpsertUser(ctx context.Context, doc *models.SearchUserDocument) {
+	p.publish(ctx, events.SearchIndexEvent{Op: events.SearchIndexUpsert, Entity: models.SearchEntityUser, ID: doc.ID, User: doc})
+}
+
+func (p *SearchIndexPublisher) UpsertPost(ctx context.Context, doc *models.SearchPostDocument) {
+	p.publish(ctx, events.SearchIndexEvent{Op: events.SearchIndexUpsert, Entity: models.SearchEntityPost, ID: doc.ID, Post: doc})
+}
+
+func (p *SearchIndexPublisher) UpsertEvent(ctx context.Context, doc *models.SearchEventDocument) {
+	p.publish(ctx, events.SearchIndexEvent{Op: events.SearchIndexUpsert, Entity: models.SearchEntityEvent, ID: doc.ID, Event: doc})
+}
+
+func (p *SearchIndexPublisher) UpsertListing(ctx context.Context, doc *models.SearchListingDocument) {
+	p.publish(ctx, events.SearchIndexEvent{Op: events.SearchIndexUpsert, Entity: models.SearchEntityListing, ID: doc.ID, Listing: doc})
+}
+
+// Delete removes an entity from the index
+func (p *SearchIndexPublisher) Delete(ctx context.Context, entity models.SearchEntityType, id string) {
+	p.publish(ctx, events.SearchIndexEvent{Op: events.SearchIndexDelete, Entity: entity, ID: id})
+}
+
+func (p *SearchIndexPublisher) publish(ctx context.Context, evt events.SearchIndexEvent) {
+	if p == nil {
+		return
+	}
+	evt.Timestamp = time.Now()
+
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		log.Printf("Failed to marshal search index event for %s %s: %v", evt.Entity, evt.ID, err)
+		return
+	}
+
+	// Keyed by ID so every change to one entity lands on the same partition in order
+	msg := kafka.Message{
+		Topic: SearchIndexTopic(evt.Entity),
+		Key:   []byte(evt.ID),
+		Value: payload,
+		Time:  evt.Timestamp,
+	}
+	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+		log.Printf("Failed to publish search index event for %s %s: %v", evt.Entity, evt.ID, err)
+	}
+}
+
+func (p *SearchIndexPublisher) Close() error {
+	if p == nil {
+		return nil
+	}
+	return p.writer.Close()
+}
@@ -0,0 +1,165 @@
+// Package linkpreview builds Open Graph previews of the links people share in
+// messages and posts. Pages are fetched through a client that only connects
+// to public addresses, so a link cannot make the server reach its own
+// network.
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	fetchTimeout = 5 * time.Second
+	maxRedirects = 3
+	// maxPageBytes bounds how much of a page is read; Open Graph tags sit in
+	// the head
+	maxPageBytes = 512 << 10
+	userAgent    = "ConnectifyBot/1.0 (+link preview)"
+)
+
+var (
+	// ErrBlockedURL is returned for links that are not plain http(s) links on
+	// the default ports or that resolve to a non-public address
+	ErrBlockedURL = errors.New("link preview: url not allowed")
+	// ErrNoPreview is returned for pages without a title or description
+	ErrNoPreview = errors.New("link preview: page has no preview")
+)
+
+// blockedPrefixes are special-purpose ranges netip does not classify as
+// private but that are still not the public internet
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// Fetcher downloads pages and reads their Open Graph tags
+type Fetcher struct {
+	client *http.Client
+}
+
+func NewFetcher() *Fetcher {
+	dialer := &net.Dialer{
+		Timeout: fetchTimeout,
+		// Checking the address being dialled, after DNS resolution, also
+		// covers redirects and names that re-resolve to internal hosts
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !publicAddr(addrPort.Addr()) {
+				return ErrBlockedURL
+			}
+			return nil
+		},
+	}
+	transport := &http.Transport{
+		Proxy:                 nil, // a proxy would dial on our behalf
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   fetchTimeout,
+		ResponseHeaderTimeout: fetchTimeout,
+		MaxIdleConns:          20,
+		IdleConnTimeout:       30 * time.Second,
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   2 * fetchTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("link preview: stopped after %d redirects", maxRedirects)
+			}
+			return checkURL(req.URL)
+		},
+	}
+	return &Fetcher{client: client}
+}
+
+// Fetch downloads rawURL and builds its preview
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*models.LinkPreview, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, ErrBlockedURL
+	}
+	if err := checkURL(u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("link preview: %s returned %d", u.Host, resp.StatusCode)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, ErrNoPreview
+	}
+
+	body, err := charset.NewReader(io.LimitReader(resp.Body, maxPageBytes), contentType)
+	if err != nil {
+		return nil, err
+	}
+	preview := parse(body, resp.Request.URL)
+	if preview.Title == "" && preview.Description == "" {
+		return nil, ErrNoPreview
+	}
+	preview.URL = rawURL
+	preview.FetchedAt = time.Now()
+	return preview, nil
+}
+
+// checkURL allows http(s) links on the default ports without credentials
+func checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ErrBlockedURL
+	}
+	if u.User != nil || u.Hostname() == "" {
+		return ErrBlockedURL
+	}
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		return ErrBlockedURL
+	}
+	return nil
+}
+
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
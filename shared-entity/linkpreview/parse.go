@@ -0,0 +1,133 @@
+package linkpreview
+
+import (
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	maxTitleLength       = 300
+	maxDescriptionLength = 500
+	maxSiteNameLength    = 100
+)
+
+// urlPattern finds http(s) links in free text
+var urlPattern = regexp.MustCompile(`https?://[^\s<>"']+`)
+
+// FirstURL returns the first http(s) link in text, without trailing
+// punctuation, or "" when there is none
+func FirstURL(text string) string {
+	match := urlPattern.FindString(text)
+	return strings.TrimRight(match, ".,;:!?)]}")
+}
+
+// parse reads the Open Graph tags of a page, falling back to Twitter card
+// tags and then to the plain title and description. Parsing stops at the end
+// of the head.
+func parse(r io.Reader, base *url.URL) *models.LinkPreview {
+	meta := make(map[string]string)
+	var title string
+
+	z := html.NewTokenizer(r)
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		if tt == html.EndTagToken {
+			if name, _ := z.TagName(); atom.Lookup(name) == atom.Head {
+				break
+			}
+			continue
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		name, hasAttr := z.TagName()
+		switch atom.Lookup(name) {
+		case atom.Body:
+			return build(meta, title, base)
+		case atom.Title:
+			if tt == html.StartTagToken && z.Next() == html.TextToken {
+				title = string(z.Text())
+			}
+		case atom.Meta:
+			var key, content string
+			for hasAttr {
+				var k, v []byte
+				k, v, hasAttr = z.TagAttr()
+				switch string(k) {
+				case "property", "name":
+					key = strings.ToLower(strings.TrimSpace(string(v)))
+				case "content":
+					content = string(v)
+				}
+			}
+			if key != "" && content != "" {
+				if _, seen := meta[key]; !seen {
+					meta[key] = content
+				}
+			}
+		}
+	}
+	return build(meta, title, base)
+}
+
+func build(meta map[string]string, title string, base *url.URL) *models.LinkPreview {
+	first := func(keys ...string) string {
+		for _, k := range keys {
+			if v := strings.TrimSpace(meta[k]); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	preview := &models.LinkPreview{
+		Title:       truncate(first("og:title", "twitter:title"), maxTitleLength),
+		Description: truncate(first("og:description", "twitter:description", "description"), maxDescriptionLength),
+		SiteName:    truncate(first("og:site_name"), maxSiteNameLength),
+		ImageURL:    resolveImage(first("og:image:secure_url", "og:image", "twitter:image"), base),
+	}
+	if preview.Title == "" {
+		preview.Title = truncate(strings.TrimSpace(title), maxTitleLength)
+	}
+	return preview
+}
+
+// resolveImage makes a relative image link absolute; images that are not
+// http(s) are dropped
+func resolveImage(raw string, base *url.URL) string {
+	if raw == "" {
+		return ""
+	}
+	ref, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		ref = base.ResolveReference(ref)
+	}
+	if ref.Scheme != "http" && ref.Scheme != "https" {
+		return ""
+	}
+	return ref.String()
+}
+
+func truncate(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:max-1])) + "…"
+}
@@ -0,0 +1,152 @@
+package linkpreview
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	// previewTTL is how long a fetched preview is reused for the same link
+	previewTTL = 24 * time.Hour
+	// failureTTL keeps links without a preview from being fetched over and
+	// over
+	failureTTL = time.Hour
+
+	cacheKeyPrefix = "link_preview:"
+)
+
+// Apply receives the preview built for a job, or nil when its text has no
+// link or the link has no preview
+type Apply func(ctx context.Context, preview *models.LinkPreview)
+
+type job struct {
+	text  string
+	apply Apply
+}
+
+// Worker unfurls links in the background. Previews are cached in Redis, so a
+// link shared in many places is fetched once a day.
+type Worker struct {
+	fetcher *Fetcher
+	redis   goredis.UniversalClient
+	logger  *slog.Logger
+	workers int
+	jobs    chan job
+}
+
+// NewWorker creates a worker with the given number of fetchers and queue
+// size. redis may be nil to fetch every link.
+func NewWorker(redis goredis.UniversalClient, workers, queueSize int, logger *slog.Logger) *Worker {
+	if workers <= 0 {
+		workers = 1
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Worker{
+		fetcher: NewFetcher(),
+		redis:   redis,
+		logger:  logger,
+		workers: workers,
+		jobs:    make(chan job, queueSize),
+	}
+}
+
+// Enqueue queues the first link of text for unfurling and hands the result
+// to apply. It never blocks; when the queue is full the job is dropped and
+// false returned. A nil Worker drops everything.
+func (w *Worker) Enqueue(text string, apply Apply) bool {
+	if w == nil {
+		return false
+	}
+	select {
+	case w.jobs <- job{text: text, apply: apply}:
+		return true
+	default:
+		w.logger.Warn("link preview queue full, dropping job")
+		return false
+	}
+}
+
+// Run processes queued jobs until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < w.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case j := <-w.jobs:
+					j.apply(ctx, w.Unfurl(ctx, FirstURL(j.text)))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Unfurl returns the preview of rawURL, from the cache when it was fetched
+// recently, or nil when it has none
+func (w *Worker) Unfurl(ctx context.Context, rawURL string) *models.LinkPreview {
+	if rawURL == "" {
+		return nil
+	}
+
+	key := cacheKey(rawURL)
+	if w.redis != nil {
+		if data, err := w.redis.Get(ctx, key).Bytes(); err == nil {
+			if len(data) == 0 {
+				return nil
+			}
+			var preview models.LinkPreview
+			if json.Unmarshal(data, &preview) == nil {
+				return &preview
+			}
+		} else if !errors.Is(err, goredis.Nil) {
+			w.logger.Warn("failed to read cached link preview", "error", err)
+		}
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, 2*fetchTimeout)
+	defer cancel()
+	preview, err := w.fetcher.Fetch(fetchCtx, rawURL)
+	if err != nil {
+		w.logger.Debug("no link preview", "url", rawURL, "error", err)
+		w.cache(ctx, key, nil, failureTTL)
+		return nil
+	}
+	if data, err := json.Marshal(preview); err == nil {
+		w.cache(ctx, key, data, previewTTL)
+	}
+	return preview
+}
+
+func (w *Worker) cache(ctx context.Context, key string, data []byte, ttl time.Duration) {
+	if w.redis == nil {
+		return
+	}
+	if data == nil {
+		data = []byte{}
+	}
+	if err := w.redis.Set(ctx, key, data, ttl).Err(); err != nil {
+		w.logger.Warn("failed to cache link preview", "error", err)
+	}
+}
+
+func cacheKey(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return cacheKeyPrefix + hex.EncodeToString(sum[:])
+}
@@ -2,12 +2,16 @@ package middleware
 
 import (
 	"context"
+	"crypto/rsa"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/jwks"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/serviceauth"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/redis/go-redis/v9"
@@ -27,6 +31,8 @@ type authSettings struct {
 	failClosed     bool
 	failStatusCode int
 	failMessage    string
+	keys           PublicKeys
+	hs256Until     time.Time
 }
 
 // AuthOption configures optional behavior for the auth middleware.
@@ -48,6 +54,85 @@ func WithFailClosedResponse(statusCode int, message string) AuthOption {
 	}
 }
 
+// PublicKeys looks up the RSA keys access tokens are signed with by key ID.
+// Both a *jwks.KeySet fetched from the issuer and the issuer's own
+// *jwks.KeyRing satisfy it.
+type PublicKeys interface {
+	Key(ctx context.Context, kid string) (*rsa.PublicKey, error)
+}
+
+// WithPublicKeys accepts RS256 access tokens signed by one of keys instead of
+// HS256 tokens signed with the shared secret. Add WithLegacyHS256Until to keep
+// accepting those while services switch over.
+func WithPublicKeys(keys PublicKeys) AuthOption {
+	return func(s *authSettings) {
+		s.keys = keys
+	}
+}
+
+// WithLegacyHS256Until keeps accepting HS256 access tokens signed with the
+// shared secret alongside RS256 ones until the given time, for migrating to
+// signing keys. Once it passes, a leaked shared secret can no longer mint
+// access tokens. Without public keys HS256 is all there is, and this changes
+// nothing.
+func WithLegacyHS256Until(until time.Time) AuthOption {
+	return func(s *authSettings) {
+		s.hs256Until = until
+	}
+}
+
+var (
+	keySetsMu sync.Mutex
+	keySets   = map[string]*jwks.KeySet{}
+)
+
+// WithJWKS accepts RS256 access tokens signed by the keys the issuer
+// publishes at url, as WithPublicKeys does. Middlewares given the same url
+// share one cache. An empty url changes nothing.
+func WithJWKS(url string) AuthOption {
+	if url == "" {
+		return func(*authSettings) {}
+	}
+	keySetsMu.Lock()
+	defer keySetsMu.Unlock()
+	keys, ok := keySets[url]
+	if !ok {
+		keys = jwks.NewKeySet(url, jwks.DefaultOptions())
+		keySets[url] = keys
+	}
+	return WithPublicKeys(keys)
+}
+
+// ErrLegacyTokenRejected is returned for HS256 access tokens once signing
+// keys are in use and the migration window has closed
+var ErrLegacyTokenRejected = errors.New("HS256 access tokens are no longer accepted")
+
+// AccessTokenKeyfunc verifies RS256 tokens with the key in keys their kid
+// header names. HS256 tokens are verified with jwtSecret when keys is nil, or
+// until hs256Until while services migrate to signing keys. Matching keys to
+// the algorithm keeps a token from passing off the public key as a secret.
+func AccessTokenKeyfunc(jwtSecret string, keys PublicKeys, hs256Until time.Time) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			if keys != nil && !time.Now().Before(hs256Until) {
+				return nil, ErrLegacyTokenRejected
+			}
+			return []byte(jwtSecret), nil
+		case *jwt.SigningMethodRSA:
+			if keys == nil {
+				break
+			}
+			kid, _ := token.Header["kid"].(string)
+			if kid == "" {
+				return nil, jwks.ErrKeyNotFound
+			}
+			return keys.Key(context.Background(), kid)
+		}
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+}
+
 // AuthMiddleware creates a Gin middleware for JWT authentication
 // blacklist can be nil for stateless JWT validation (no revocation support)
 func AuthMiddleware(jwtSecret string, blacklist TokenBlacklist, opts ...AuthOption) gin.HandlerFunc {
@@ -66,7 +151,7 @@ func AuthMiddleware(jwtSecret string, blacklist TokenBlacklist, opts ...AuthOpti
 			return
 		}
 
-		userID, err := validateTokenWithBlacklist(authHeader, jwtSecret, blacklist, settings.failClosed)
+		userID, sessionID, err := validateTokenWithBlacklist(authHeader, jwtSecret, settings, blacklist)
 		if err != nil {
 			if settings.failClosed && errors.Is(err, ErrRevocationCheckFailed) {
 				status := settings.failStatusCode
@@ -86,10 +171,29 @@ func AuthMiddleware(jwtSecret string, blacklist TokenBlacklist, opts ...AuthOpti
 
 		c.Set("userID", userID)
 		c.Set("user_id", userID) // Also set with underscore for compatibility
+		if sessionID != "" {
+			c.Set("session_id", sessionID)
+		}
+		// Service calls made for the request carry the user along
+		c.Request = c.Request.WithContext(serviceauth.WithUser(c.Request.Context(), userID))
 		c.Next()
 	}
 }
 
+// OptionalAuthMiddleware authenticates requests carrying an Authorization
+// header exactly like AuthMiddleware, and lets requests without one through
+// anonymously, for routes that tailor their response to a signed-in viewer
+func OptionalAuthMiddleware(jwtSecret string, blacklist TokenBlacklist, opts ...AuthOption) gin.HandlerFunc {
+	auth := AuthMiddleware(jwtSecret, blacklist, opts...)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		auth(c)
+	}
+}
+
 // JWTAuthSimple creates a simple JWT auth middleware without blacklist checking
 // Use this when you don't have Redis available or don't need token revocation
 func JWTAuthSimple(jwtSecret string) gin.HandlerFunc {
@@ -110,7 +214,7 @@ func WSJwtAuthMiddleware(jwtSecret string, blacklist TokenBlacklist, opts ...Aut
 			return
 		}
 
-		userID, err := validateTokenWithBlacklist(tokenString, jwtSecret, blacklist, settings.failClosed)
+		userID, sessionID, err := validateTokenWithBlacklist(tokenString, jwtSecret, settings, blacklist)
 		if err != nil {
 			fmt.Printf("WS Auth Error: %v\n", err)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
@@ -119,6 +223,9 @@ func WSJwtAuthMiddleware(jwtSecret string, blacklist TokenBlacklist, opts ...Aut
 
 		c.Set("userID", userID)
 		c.Set("user_id", userID)
+		if sessionID != "" {
+			c.Set("session_id", sessionID)
+		}
 		c.Next()
 	}
 }
@@ -142,52 +249,90 @@ func extractWebsocketToken(header string) (string, error) {
 // ValidateTokenWithBlacklist validates a JWT token with optional blacklist check
 // If blacklist is nil, only JWT signature validation is performed
 func ValidateTokenWithBlacklist(tokenString, jwtSecret string, blacklist TokenBlacklist) (string, error) {
-	return validateTokenWithBlacklist(tokenString, jwtSecret, blacklist, false)
+	userID, _, err := validateTokenWithBlacklist(tokenString, jwtSecret, authSettings{}, blacklist)
+	return userID, err
+}
+
+// RevokedSessionKey is the blacklist key marking a session as revoked. Access
+// tokens carry their session ID, so they stop working with their session
+// instead of living out their TTL.
+func RevokedSessionKey(sessionID string) string {
+	return "session:revoked:" + sessionID
+}
+
+// SuspendedUserKey is the blacklist key marking a user as suspended. It
+// expires with the suspension, shutting out tokens issued before it began.
+func SuspendedUserKey(userID string) string {
+	return "user:suspended:" + userID
 }
 
-func validateTokenWithBlacklist(tokenString, jwtSecret string, blacklist TokenBlacklist, failClosed bool) (string, error) {
+// validateTokenWithBlacklist returns the user ID and, for tokens issued with
+// one, the session ID
+func validateTokenWithBlacklist(tokenString, jwtSecret string, settings authSettings, blacklist TokenBlacklist) (string, string, error) {
+	failClosed := settings.failClosed
 	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
 	if tokenString == "" {
-		return "", fmt.Errorf("bearer token required")
+		return "", "", fmt.Errorf("bearer token required")
 	}
 
 	// Check token blacklist only if Redis is available
 	if blacklist != nil {
-		_, err := blacklist.Get(context.Background(), "blacklist:"+tokenString).Result()
-		if err == nil {
-			return "", fmt.Errorf("token revoked")
-		} else if err != redis.Nil {
-			if failClosed {
-				return "", fmt.Errorf("%w: %v", ErrRevocationCheckFailed, err)
-			}
+		if revoked, err := isRevoked(blacklist, "blacklist:"+tokenString, failClosed); err != nil {
+			return "", "", err
+		} else if revoked {
+			return "", "", fmt.Errorf("token revoked")
 		}
 	}
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(jwtSecret), nil
-	})
+	token, err := jwt.Parse(tokenString, AccessTokenKeyfunc(jwtSecret, settings.keys, settings.hs256Until))
 
 	if err != nil {
-		return "", fmt.Errorf("invalid token: %w", err)
+		return "", "", fmt.Errorf("invalid token: %w", err)
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		if claims["type"] != "access" {
-			return "", fmt.Errorf("invalid token type")
+			return "", "", fmt.Errorf("invalid token type")
 		}
 
 		userID, ok := claims["id"].(string)
 		if !ok {
-			return "", fmt.Errorf("invalid token claims")
+			return "", "", fmt.Errorf("invalid token claims")
 		}
 
-		return userID, nil
+		sessionID, _ := claims["sid"].(string)
+		if sessionID != "" && blacklist != nil {
+			if revoked, err := isRevoked(blacklist, RevokedSessionKey(sessionID), failClosed); err != nil {
+				return "", "", err
+			} else if revoked {
+				return "", "", fmt.Errorf("session revoked")
+			}
+		}
+		if blacklist != nil {
+			if suspended, err := isRevoked(blacklist, SuspendedUserKey(userID), failClosed); err != nil {
+				return "", "", err
+			} else if suspended {
+				return "", "", fmt.Errorf("account suspended")
+			}
+		}
+
+		return userID, sessionID, nil
 	}
 
-	return "", fmt.Errorf("invalid token")
+	return "", "", fmt.Errorf("invalid token")
+}
+
+// isRevoked looks key up in the blacklist. A lookup failure only counts as an
+// error when failing closed; otherwise the token is let through.
+func isRevoked(blacklist TokenBlacklist, key string, failClosed bool) (bool, error) {
+	_, err := blacklist.Get(context.Background(), key).Result()
+	if err == nil {
+		return true, nil
+	}
+	if err != redis.Nil && failClosed {
+		return false, fmt.Errorf("%w: %v", ErrRevocationCheckFailed, err)
+	}
+	return false, nil
 }
 
 // ValidateToken is a backwards-compatible wrapper for existing code
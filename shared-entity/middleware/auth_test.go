@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type noKeys struct{}
+
+func (noKeys) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	return nil, errors.New("no keys")
+}
+
+func TestAccessTokenKeyfuncLegacyHS256Window(t *testing.T) {
+	const secret = "shared-secret"
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"id": "u1", "type": "access"}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		keys   PublicKeys
+		until  time.Time
+		accept bool
+	}{
+		{"shared secret only", nil, time.Time{}, true},
+		{"signing keys, no migration window", noKeys{}, time.Time{}, false},
+		{"signing keys, window open", noKeys{}, time.Now().Add(time.Hour), true},
+		{"signing keys, window closed", noKeys{}, time.Now().Add(-time.Hour), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := jwt.Parse(signed, AccessTokenKeyfunc(secret, tc.keys, tc.until))
+			if tc.accept && err != nil {
+				t.Fatalf("rejected: %v", err)
+			}
+			if !tc.accept && !errors.Is(err, ErrLegacyTokenRejected) {
+				t.Fatalf("got %v, want ErrLegacyTokenRejected", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	DefaultIdempotencyTTL     = 24 * time.Hour
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentResponseSize = 1 << 20
+	// Bodies are held in memory to fingerprint them, so requests carrying a
+	// key may be at most this large
+	maxIdempotentRequestSize = 1 << 20
+	// A request still running after this long is assumed lost, so the key
+	// can be claimed again
+	idempotencyLockTTL = time.Minute
+)
+
+// idempotencyRecord is what Redis holds for a key: a claim while the first
+// request runs, then its response
+type idempotencyRecord struct {
+	Pending     bool   `json:"pending,omitempty"`
+	Fingerprint string `json:"fingerprint"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// Idempotency makes mutating requests safe to retry. A POST, PUT, PATCH or
+// DELETE carrying an Idempotency-Key header runs once per key; retries get
+// the stored response back with Idempotent-Replayed: true. Keys are scoped
+// to the user (or client IP before auth) and route, and reusing one with a
+// different body is rejected. Server errors are not stored so the client
+// can retry them. When Redis is unavailable requests are let through. A nil
+// Idempotency does nothing.
+//
+// Bodies over 1 MiB are refused with 413. Multipart uploads are streamed to
+// their handlers and not deduplicated, as fingerprinting them would mean
+// buffering whole files.
+type Idempotency struct {
+	client goredis.Cmdable
+	ttl    time.Duration
+}
+
+func NewIdempotency(client goredis.Cmdable, ttl time.Duration) *Idempotency {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return &Idempotency{client: client, ttl: ttl}
+}
+
+// Handle returns the middleware. Register it after authentication so keys
+// are scoped per user.
+func (i *Idempotency) Handle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if i == nil || key == "" || !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must be at most 255 characters"})
+			return
+		}
+
+		if isMultipart(c.Request) {
+			c.Next()
+			return
+		}
+		if c.Request.ContentLength > maxIdempotentRequestSize {
+			abortRequestTooLarge(c)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentRequestSize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				abortRequestTooLarge(c)
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		sum := sha256.Sum256(body)
+		fingerprint := hex.EncodeToString(sum[:])
+
+		ctx := c.Request.Context()
+		redisKey := idempotencyRedisKey(c, key)
+		claim, _ := json.Marshal(idempotencyRecord{Pending: true, Fingerprint: fingerprint})
+		claimed, err := i.client.SetNX(ctx, redisKey, claim, idempotencyLockTTL).Result()
+		if err != nil {
+			// Redis error - allow request
+			c.Next()
+			return
+		}
+		if !claimed {
+			i.replay(c, redisKey, fingerprint)
+			return
+		}
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		// Store even if the client has gone away, that is when it retries
+		storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 3*time.Second)
+		defer cancel()
+		status := writer.Status()
+		if status >= http.StatusInternalServerError || writer.overflow {
+			i.client.Del(storeCtx, redisKey)
+			return
+		}
+		record, _ := json.Marshal(idempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		})
+		i.client.Set(storeCtx, redisKey, record, i.ttl)
+	}
+}
+
+// replay answers a request whose key was already claimed
+func (i *Idempotency) replay(c *gin.Context, redisKey, fingerprint string) {
+	raw, err := i.client.Get(c.Request.Context(), redisKey).Bytes()
+	if err != nil {
+		// The claim expired in between, or Redis failed - allow request
+		c.Next()
+		return
+	}
+	var record idempotencyRecord
+	if err := json.Unmarshal(raw, &record); err != nil {
+		c.Next()
+		return
+	}
+
+	switch {
+	case record.Fingerprint != fingerprint:
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used with a different request body"})
+	case record.Pending:
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "a request with this Idempotency-Key is still being processed"})
+	default:
+		c.Header(IdempotentReplayedHeader, "true")
+		if record.ContentType != "" {
+			c.Header("Content-Type", record.ContentType)
+		}
+		c.Status(record.Status)
+		c.Writer.Write(record.Body)
+		c.Abort()
+	}
+}
+
+// idempotencyRedisKey scopes a client's key to who sent it and to which route
+func idempotencyRedisKey(c *gin.Context, key string) string {
+	sum := sha256.Sum256([]byte(c.Request.Method + " " + c.Request.URL.Path + " " + key))
+	return "idempotency:" + rateLimitSubject(c) + ":" + hex.EncodeToString(sum[:])
+}
+
+func abortRequestTooLarge(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request bodies sent with an Idempotency-Key must be at most 1 MiB"})
+}
+
+func isMultipart(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && strings.HasPrefix(mediaType, "multipart/")
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// recordingWriter keeps a copy of the response body so it can be replayed.
+// Bodies over maxIdempotentResponseSize are not kept.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.record(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.record([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *recordingWriter) record(b []byte) {
+	if w.overflow {
+		return
+	}
+	if w.body.Len()+len(b) > maxIdempotentResponseSize {
+		w.overflow = true
+		w.body.Reset()
+		return
+	}
+	w.body.Write(b)
+}
@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// memoryRedis implements the commands Idempotency uses
+type memoryRedis struct {
+	goredis.Cmdable
+
+	mu    sync.Mutex
+	data  map[string]string
+	calls int
+}
+
+func newMemoryRedis() *memoryRedis {
+	return &memoryRedis{data: map[string]string{}}
+}
+
+func (m *memoryRedis) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *goredis.BoolCmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	if _, ok := m.data[key]; ok {
+		return goredis.NewBoolResult(false, nil)
+	}
+	m.data[key] = string(value.([]byte))
+	return goredis.NewBoolResult(true, nil)
+}
+
+func (m *memoryRedis) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *goredis.StatusCmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	m.data[key] = string(value.([]byte))
+	return goredis.NewStatusResult("OK", nil)
+}
+
+func (m *memoryRedis) Get(ctx context.Context, key string) *goredis.StringCmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	value, ok := m.data[key]
+	if !ok {
+		return goredis.NewStringResult("", goredis.Nil)
+	}
+	return goredis.NewStringResult(value, nil)
+}
+
+func (m *memoryRedis) Del(ctx context.Context, keys ...string) *goredis.IntCmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	for _, key := range keys {
+		delete(m.data, key)
+	}
+	return goredis.NewIntResult(int64(len(keys)), nil)
+}
+
+func idempotentRouter(store *memoryRedis, handled *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(NewIdempotency(store, time.Hour).Handle())
+	r.POST("/things", func(c *gin.Context) {
+		*handled++
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.String(http.StatusCreated, "read %d bytes", len(body))
+	})
+	return r
+}
+
+func TestIdempotencyReplaysSmallBodies(t *testing.T) {
+	var handled int
+	r := idempotentRouter(newMemoryRedis(), &handled)
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(`{"name":"a"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated || w.Body.String() != "read 12 bytes" {
+			t.Fatalf("attempt %d: %d %q", i, w.Code, w.Body.String())
+		}
+		if i == 1 && w.Header().Get(IdempotentReplayedHeader) != "true" {
+			t.Fatal("retry was not replayed")
+		}
+	}
+	if handled != 1 {
+		t.Fatalf("handler ran %d times, want 1", handled)
+	}
+}
+
+func TestIdempotencyRejectsOversizedBodies(t *testing.T) {
+	big := bytes.Repeat([]byte("a"), maxIdempotentRequestSize+1)
+
+	cases := map[string]func() *http.Request{
+		"declared length": func() *http.Request {
+			return httptest.NewRequest(http.MethodPost, "/things", bytes.NewReader(big))
+		},
+		"chunked": func() *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/things", io.MultiReader(bytes.NewReader(big)))
+			req.ContentLength = -1
+			return req
+		},
+	}
+	for name, newRequest := range cases {
+		t.Run(name, func(t *testing.T) {
+			var handled int
+			store := newMemoryRedis()
+			r := idempotentRouter(store, &handled)
+
+			req := newRequest()
+			req.Header.Set("Content-Type", "application/octet-stream")
+			req.Header.Set(IdempotencyKeyHeader, "key-1")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusRequestEntityTooLarge {
+				t.Fatalf("got %d, want 413", w.Code)
+			}
+			if handled != 0 || store.calls != 0 {
+				t.Fatalf("handler ran %d times, %d Redis calls", handled, store.calls)
+			}
+		})
+	}
+}
+
+func TestIdempotencyStreamsMultipartUploads(t *testing.T) {
+	var handled int
+	store := newMemoryRedis()
+	r := idempotentRouter(store, &handled)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, _ := form.CreateFormFile("file", "video.mp4")
+	_, _ = part.Write(bytes.Repeat([]byte("v"), 2*maxIdempotentRequestSize))
+	_ = form.Close()
+	size := body.Len()
+
+	req := httptest.NewRequest(http.MethodPost, "/things", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated || handled != 1 {
+		t.Fatalf("got %d, handler ran %d times", w.Code, handled)
+	}
+	if want := "read " + itoa(size) + " bytes"; w.Body.String() != want {
+		t.Fatalf("handler got %q, want %q", w.Body.String(), want)
+	}
+	if store.calls != 0 {
+		t.Fatalf("%d Redis calls for a multipart upload, want none", store.calls)
+	}
+}
+
+func itoa(n int) string {
+	var buf [20]byte
+	i := len(buf)
+	for {
+		i--
+		buf[i] = byte('0' + n%10)
+		n /= 10
+		if n == 0 {
+			return string(buf[i:])
+		}
+	}
+}
@@ -1,9 +1,7 @@
 package middleware
 
 import (
-	"fmt"
 	"net/http"
-	"strconv"
 	"sync"
 	"time"
 
@@ -63,7 +61,9 @@ func (i *IPRateLimiter) GetLimiter(ip string) *rate.Limiter {
 	return limiter
 }
 
-// RateLimiter is a middleware that limits the number of requests per IP
+// RateLimiter is a middleware that limits the number of requests per IP. The
+// limits live in process memory, so each replica enforces its own; use
+// SlidingWindowLimiter to share them.
 func RateLimiter(enabled bool, limit float64, burst int, action string, observer RateLimitObserver) gin.HandlerFunc {
 	if !enabled {
 		return func(c *gin.Context) {
@@ -85,7 +85,8 @@ func RateLimiter(enabled bool, limit float64, burst int, action string, observer
 	}
 }
 
-// StrictRateLimiter creates a custom rate limiter middleware with specified limit and burst
+// StrictRateLimiter creates a custom rate limiter middleware with specified
+// limit and burst, kept in process memory like RateLimiter
 func StrictRateLimiter(r float64, b int, action string, observer RateLimitObserver) gin.HandlerFunc {
 	limiter := NewIPRateLimiter(rate.Limit(r), b)
 
@@ -105,7 +106,6 @@ func StrictRateLimiter(r float64, b int, action string, observer RateLimitObserv
 type EventRateLimitConfig struct {
 	MaxRequests int           // Maximum requests allowed
 	Window      time.Duration // Time window for rate limiting
-	KeyPrefix   string        // Redis key prefix
 	Action      string        // Action label for observability
 }
 
@@ -115,7 +115,6 @@ var (
 	RSVPRateLimit = EventRateLimitConfig{
 		MaxRequests: 10,
 		Window:      time.Minute,
-		KeyPrefix:   "ratelimit:rsvp",
 		Action:      "events:rsvp",
 	}
 
@@ -123,7 +122,6 @@ var (
 	InviteRateLimit = EventRateLimitConfig{
 		MaxRequests: 20,
 		Window:      time.Hour,
-		KeyPrefix:   "ratelimit:invite",
 		Action:      "events:invite",
 	}
 
@@ -131,7 +129,6 @@ var (
 	EventPostRateLimit = EventRateLimitConfig{
 		MaxRequests: 5,
 		Window:      time.Minute,
-		KeyPrefix:   "ratelimit:event_post",
 		Action:      "events:posts",
 	}
 
@@ -139,7 +136,6 @@ var (
 	RecommendationRateLimit = EventRateLimitConfig{
 		MaxRequests: 30,
 		Window:      time.Minute,
-		KeyPrefix:   "ratelimit:recommendations",
 		Action:      "events:recommendations",
 	}
 
@@ -147,7 +143,6 @@ var (
 	TrendingRateLimit = EventRateLimitConfig{
 		MaxRequests: 30,
 		Window:      time.Minute,
-		KeyPrefix:   "ratelimit:trending",
 		Action:      "events:trending",
 	}
 
@@ -155,7 +150,6 @@ var (
 	SearchRateLimit = EventRateLimitConfig{
 		MaxRequests: 30,
 		Window:      time.Minute,
-		KeyPrefix:   "ratelimit:search",
 		Action:      "events:search",
 	}
 
@@ -163,64 +157,15 @@ var (
 	CreateEventRateLimit = EventRateLimitConfig{
 		MaxRequests: 5,
 		Window:      time.Hour,
-		KeyPrefix:   "ratelimit:create_event",
 		Action:      "events:create",
 	}
 )
 
 // EventRateLimiter creates a Redis-based rate limiting middleware for event actions
 func EventRateLimiter(redisClient *redis.ClusterClient, config EventRateLimitConfig, observer RateLimitObserver) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Get user ID from context (set by auth middleware)
-		userID, exists := c.Get("user_id")
-		if !exists {
-			// Fall back to IP-based rate limiting for unauthenticated requests
-			userID = c.ClientIP()
-		}
-
-		key := fmt.Sprintf("%s:%v", config.KeyPrefix, userID)
-
-		// Check current count
-		countStr, err := redisClient.Get(c.Request.Context(), key)
-		if err != nil && countStr == "" {
-			// Key doesn't exist, start new window
-			if err := redisClient.Set(c.Request.Context(), key, "1", config.Window); err != nil {
-				// Redis error - allow request but log
-				c.Next()
-				return
-			}
-			c.Next()
-			return
-		}
-
-		count, _ := strconv.Atoi(countStr)
-		if count >= config.MaxRequests {
-			// Rate limited
-			retryAfter := int64(config.Window.Seconds())
-			notifyRateLimitObserver(observer, config.Action)
-			c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
-			c.Header("X-RateLimit-Limit", strconv.Itoa(config.MaxRequests))
-			c.Header("X-RateLimit-Remaining", "0")
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"error":       "rate_limit_exceeded",
-				"message":     fmt.Sprintf("Too many requests. Please try again in %d seconds.", retryAfter),
-				"retry_after": retryAfter,
-			})
-			return
-		}
-
-		// Increment counter
-		newCount := count + 1
-		if err := redisClient.Set(c.Request.Context(), key, strconv.Itoa(newCount), config.Window); err != nil {
-			// Redis error - allow request
-			c.Next()
-			return
-		}
-
-		// Set rate limit headers
-		c.Header("X-RateLimit-Limit", strconv.Itoa(config.MaxRequests))
-		c.Header("X-RateLimit-Remaining", strconv.Itoa(config.MaxRequests-newCount))
-
-		c.Next()
-	}
+	return NewSlidingWindowLimiter(redisClient.GetClient(), observer).Limit(RateLimitPolicy{
+		Limit:  config.MaxRequests,
+		Window: config.Window,
+		Action: config.Action,
+	})
 }
@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// slidingWindowScript counts a request against a sliding window approximated
+// from two fixed windows: the previous window's count is weighted by how much
+// of it still overlaps the sliding window. Both keys share a hash tag so the
+// script runs on one cluster node.
+//
+// KEYS[1] current window, KEYS[2] previous window
+// ARGV[1] limit, ARGV[2] window in ms, ARGV[3] ms elapsed in the current window
+var slidingWindowScript = goredis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local elapsed = tonumber(ARGV[3])
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+local previous = tonumber(redis.call('GET', KEYS[2]) or '0')
+local weighted = math.floor(previous * (window - elapsed) / window)
+if weighted + current >= limit then
+	return {0, weighted + current}
+end
+current = redis.call('INCR', KEYS[1])
+if current == 1 then
+	redis.call('PEXPIRE', KEYS[1], window * 2)
+end
+return {1, weighted + current}
+`)
+
+// RateLimitPolicy allows Limit requests per sliding Window. Action names the
+// route in keys and for the observer.
+type RateLimitPolicy struct {
+	Limit  int
+	Window time.Duration
+	Action string
+}
+
+// PolicyFromRate converts token bucket parameters, as taken by RateLimiter
+// and StrictRateLimiter, into a window policy: burst requests per the time
+// the bucket takes to refill
+func PolicyFromRate(r float64, burst int, action string) RateLimitPolicy {
+	if burst < 1 {
+		burst = 1
+	}
+	window := time.Second
+	if r > 0 {
+		window = time.Duration(float64(burst) / r * float64(time.Second))
+	}
+	return RateLimitPolicy{Limit: burst, Window: window, Action: action}
+}
+
+// RateLimitResult is the outcome of counting one request
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	Reset     time.Duration // Until the current window ends
+}
+
+// SlidingWindowLimiter enforces rate limits in Redis so they hold across all
+// replicas of a service. Authenticated requests are counted per user, others
+// per client IP, and each action has its own counters. When Redis is
+// unavailable requests are let through. A nil limiter falls back to the
+// in-process limiters.
+type SlidingWindowLimiter struct {
+	client   goredis.Scripter
+	observer RateLimitObserver
+}
+
+func NewSlidingWindowLimiter(client goredis.Scripter, observer RateLimitObserver) *SlidingWindowLimiter {
+	return &SlidingWindowLimiter{client: client, observer: observer}
+}
+
+// Allow counts a request by subject against the policy
+func (l *SlidingWindowLimiter) Allow(ctx context.Context, policy RateLimitPolicy, subject string) (RateLimitResult, error) {
+	windowMs := policy.Window.Milliseconds()
+	if windowMs <= 0 {
+		windowMs = 1
+	}
+	nowMs := time.Now().UnixMilli()
+	index := nowMs / windowMs
+	elapsed := nowMs % windowMs
+
+	base := fmt.Sprintf("ratelimit:{%s:%s}", policy.Action, subject)
+	keys := []string{
+		base + ":" + strconv.FormatInt(index, 10),
+		base + ":" + strconv.FormatInt(index-1, 10),
+	}
+	values, err := slidingWindowScript.Run(ctx, l.client, keys, policy.Limit, windowMs, elapsed).Int64Slice()
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	result := RateLimitResult{
+		Allowed:   values[0] == 1,
+		Remaining: max(policy.Limit-int(values[1]), 0),
+		Reset:     time.Duration(windowMs-elapsed) * time.Millisecond,
+	}
+	return result, nil
+}
+
+// Limit returns middleware enforcing the policy. It sets the RateLimit-Limit,
+// RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy headers on every
+// response, and Retry-After when the request is rejected.
+func (l *SlidingWindowLimiter) Limit(policy RateLimitPolicy) gin.HandlerFunc {
+	windowSeconds := int64(math.Ceil(policy.Window.Seconds()))
+	policyHeader := fmt.Sprintf("%d;w=%d", policy.Limit, windowSeconds)
+
+	return func(c *gin.Context) {
+		result, err := l.Allow(c.Request.Context(), policy, rateLimitSubject(c))
+		if err != nil {
+			// Redis error - allow request
+			c.Next()
+			return
+		}
+
+		reset := int64(math.Ceil(result.Reset.Seconds()))
+		if reset < 1 {
+			reset = 1
+		}
+		c.Header("RateLimit-Limit", strconv.Itoa(policy.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.FormatInt(reset, 10))
+		c.Header("RateLimit-Policy", policyHeader)
+
+		if !result.Allowed {
+			notifyRateLimitObserver(l.observer, policy.Action)
+			c.Header("Retry-After", strconv.FormatInt(reset, 10))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":       "rate_limit_exceeded",
+				"message":     fmt.Sprintf("Too many requests. Please try again in %d seconds.", reset),
+				"retry_after": reset,
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RateLimiter is the distributed counterpart of the package-level
+// RateLimiter, taking the same token bucket parameters
+func (l *SlidingWindowLimiter) RateLimiter(enabled bool, limit float64, burst int, action string) gin.HandlerFunc {
+	if l == nil {
+		return RateLimiter(enabled, limit, burst, action, nil)
+	}
+	if !enabled {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+	return l.Limit(PolicyFromRate(limit, burst, action))
+}
+
+// StrictRateLimiter is the distributed counterpart of the package-level
+// StrictRateLimiter, taking the same token bucket parameters
+func (l *SlidingWindowLimiter) StrictRateLimiter(r float64, b int, action string) gin.HandlerFunc {
+	if l == nil {
+		return StrictRateLimiter(r, b, action, nil)
+	}
+	return l.Limit(PolicyFromRate(r, b, action))
+}
+
+// rateLimitSubject identifies who a request is counted against: the user set
+// by the auth middleware, or the client IP before authentication
+func rateLimitSubject(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return "user:" + userID
+	}
+	return "ip:" + c.ClientIP()
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditAction names a privileged or security-sensitive action
+type AuditAction string
+
+const (
+	AuditActionLogin                AuditAction = "auth.login"
+	AuditActionLoginFailed          AuditAction = "auth.login_failed"
+	AuditActionPasswordChanged      AuditAction = "auth.password_changed"
+	AuditActionPasswordReset        AuditAction = "auth.password_reset"
+	AuditActionEmailVerified        AuditAction = "auth.email_verified"
+	AuditActionOAuthLinked          AuditAction = "auth.oauth_linked"
+	AuditActionOAuthUnlinked        AuditAction = "auth.oauth_unlinked"
+	AuditActionRoleChanged          AuditAction = "role.changed"
+	AuditActionContentRemoved       AuditAction = "moderation.content_removed"
+	AuditActionReportReviewed       AuditAction = "moderation.report_reviewed"
+	AuditActionQueueItemReviewed    AuditAction = "moderation.queue_item_reviewed"
+	AuditActionAccountStateChanged  AuditAction = "admin.account_state_changed"
+	AuditActionTwoFactorReset       AuditAction = "admin.two_factor_reset"
+	AuditActionErasureRequested     AuditAction = "admin.erasure_requested"
+	AuditActionErasureRetried       AuditAction = "admin.erasure_retried"
+	AuditActionFeatureFlagChanged   AuditAction = "admin.feature_flag_changed"
+	AuditActionFeatureFlagDeleted   AuditAction = "admin.feature_flag_deleted"
+	AuditActionVerificationReviewed AuditAction = "admin.verification_reviewed"
+	AuditActionVerificationRevoked  AuditAction = "admin.verification_revoked"
+)
+
+// AuditOutcome tells whether an audited action went through
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// AuditEntry is one append-only audit record. Entries are never updated or
+// deleted.
+type AuditEntry struct {
+	ID         primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Action     AuditAction         `bson:"action" json:"action"`
+	Outcome    AuditOutcome        `bson:"outcome" json:"outcome"`
+	Service    string              `bson:"service" json:"service"`                       // Service that performed the action
+	ActorID    *primitive.ObjectID `bson:"actor_id,omitempty" json:"actor_id,omitempty"` // Nil when the actor is unknown, e.g. a failed login
+	TargetType string              `bson:"target_type,omitempty" json:"target_type,omitempty"`
+	TargetID   string              `bson:"target_id,omitempty" json:"target_id,omitempty"`
+	IP         string              `bson:"ip,omitempty" json:"ip,omitempty"`
+	UserAgent  string              `bson:"user_agent,omitempty" json:"user_agent,omitempty"`
+	Metadata   map[string]string   `bson:"metadata,omitempty" json:"metadata,omitempty"`
+	CreatedAt  time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// AuditLogResponse is a paginated list of audit entries
+type AuditLogResponse struct {
+	Entries []AuditEntry `json:"entries"`
+	Total   int64        `json:"total"`
+	Page    int64        `json:"page"`
+	Limit   int64        `json:"limit"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrUserBlocked is returned when an interaction is refused because one of the
+// users has blocked the other. It deliberately does not say which one did.
+var ErrUserBlocked = errors.New("user is blocked")
+
+// UserBlock records that BlockerID blocked BlockedID. Blocks are one-directional
+// when stored but enforced both ways: neither user can message, see the posts
+// of, or invite the other.
+type UserBlock struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	BlockerID primitive.ObjectID `bson:"blocker_id" json:"blocker_id"`
+	BlockedID primitive.ObjectID `bson:"blocked_id" json:"blocked_id"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CallRoomStatus tracks a group call. A room rings until someone other
+// than the host joins and ends once everyone has left, or nobody answered.
+type CallRoomStatus string
+
+const (
+	CallRoomRinging CallRoomStatus = "ringing"
+	CallRoomActive  CallRoomStatus = "active"
+	CallRoomEnded   CallRoomStatus = "ended"
+)
+
+// CallParticipantStatus is where an invited user stands in a call
+type CallParticipantStatus string
+
+const (
+	CallParticipantRinging  CallParticipantStatus = "ringing"
+	CallParticipantJoined   CallParticipantStatus = "joined"
+	CallParticipantLeft     CallParticipantStatus = "left"
+	CallParticipantDeclined CallParticipantStatus = "declined"
+	CallParticipantMissed   CallParticipantStatus = "missed"
+)
+
+// CallParticipant is one invited user. Participants may leave and join
+// again while the call lasts; DurationSeconds adds up their time in it.
+type CallParticipant struct {
+	UserID          primitive.ObjectID    `bson:"user_id" json:"user_id"`
+	Status          CallParticipantStatus `bson:"status" json:"status"`
+	Muted           bool                  `bson:"muted" json:"muted"`
+	VideoOn         bool                  `bson:"video_on" json:"video_on"`
+	InvitedAt       time.Time             `bson:"invited_at" json:"invited_at"`
+	JoinedAt        *time.Time            `bson:"joined_at,omitempty" json:"joined_at,omitempty"` // Latest join
+	LeftAt          *time.Time            `bson:"left_at,omitempty" json:"left_at,omitempty"`
+	DurationSeconds int64                 `bson:"duration_seconds" json:"duration_seconds"`
+}
+
+// CallRoom is a group call and, once ended, its call history record
+type CallRoom struct {
+	ID              primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	HostID          primitive.ObjectID  `bson:"host_id" json:"host_id"`
+	GroupID         *primitive.ObjectID `bson:"group_id,omitempty" json:"group_id,omitempty"`
+	CallType        string              `bson:"call_type" json:"call_type"` // audio or video
+	Status          CallRoomStatus      `bson:"status" json:"status"`
+	Participants    []CallParticipant   `bson:"participants" json:"participants"`
+	StartedAt       *time.Time          `bson:"started_at,omitempty" json:"started_at,omitempty"` // When a second participant joined
+	EndedAt         *time.Time          `bson:"ended_at,omitempty" json:"ended_at,omitempty"`
+	DurationSeconds int64               `bson:"duration_seconds" json:"duration_seconds"`
+	CreatedAt       time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt       time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+// Participant returns the user's entry in the call, if they were invited
+func (r *CallRoom) Participant(userID primitive.ObjectID) (*CallParticipant, bool) {
+	for i := range r.Participants {
+		if r.Participants[i].UserID == userID {
+			return &r.Participants[i], true
+		}
+	}
+	return nil, false
+}
+
+// CountByStatus counts the participants in the given state
+func (r *CallRoom) CountByStatus(status CallParticipantStatus) int {
+	n := 0
+	for _, p := range r.Participants {
+		if p.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// StartCallRequest rings either a group's members or the given friends
+type StartCallRequest struct {
+	GroupID        string   `json:"group_id"`
+	ParticipantIDs []string `json:"participant_ids"`
+	CallType       string   `json:"call_type" binding:"required,oneof=audio video"`
+}
+
+// UpdateCallStateRequest changes what a participant shares; fields left
+// out stay as they are
+type UpdateCallStateRequest struct {
+	Muted   *bool `json:"muted"`
+	VideoOn *bool `json:"video_on"`
+}
+
+// CallRoomEvent tells participants about a change to a call
+type CallRoomEvent struct {
+	Room   *CallRoom `json:"room"`
+	UserID string    `json:"user_id,omitempty"` // Who the change is about
+}
+
+type CallHistoryResponse struct {
+	Calls []CallRoom `json:"calls"`
+	Total int64      `json:"total"`
+	Page  int64      `json:"page"`
+	Limit int64      `json:"limit"`
+}
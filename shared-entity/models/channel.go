@@ -0,0 +1,115 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxChannelAdmins is how many admins, owner included, a channel can have
+const MaxChannelAdmins = 50
+
+// Channel is a read-only broadcast conversation: its admins post and its
+// subscribers receive. Subscribers are stored apart from the channel, so a
+// channel can have any number of them.
+type Channel struct {
+	ID              primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	Name            string               `bson:"name" json:"name"`
+	Description     string               `bson:"description,omitempty" json:"description,omitempty"`
+	Avatar          string               `bson:"avatar,omitempty" json:"avatar,omitempty"`
+	OwnerID         primitive.ObjectID   `bson:"owner_id" json:"owner_id"`
+	Admins          []primitive.ObjectID `bson:"admins" json:"admins"` // The owner included
+	SubscriberCount int64                `bson:"subscriber_count" json:"subscriber_count"`
+	PostCount       int64                `bson:"post_count" json:"post_count"`
+	LastPostAt      *time.Time           `bson:"last_post_at,omitempty" json:"last_post_at,omitempty"`
+	CreatedAt       time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt       time.Time            `bson:"updated_at" json:"updated_at"`
+	// The viewer's relation to the channel
+	Subscribed bool `bson:"-" json:"subscribed"`
+	IsAdmin    bool `bson:"-" json:"is_admin"`
+}
+
+// HasAdmin reports whether the user may post to and manage the channel
+func (c *Channel) HasAdmin(userID primitive.ObjectID) bool {
+	for _, admin := range c.Admins {
+		if admin == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// ConversationKey identifies the channel in events, like dm_ and group_ keys
+func (c *Channel) ConversationKey() string {
+	return "channel_" + c.ID.Hex()
+}
+
+// ChannelSubscriber is one subscription to a channel. Subscribers are paged
+// by ID, oldest first.
+type ChannelSubscriber struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	ChannelID    primitive.ObjectID `bson:"channel_id" json:"channel_id"`
+	UserID       primitive.ObjectID `bson:"user_id" json:"user_id"`
+	SubscribedAt time.Time          `bson:"subscribed_at" json:"subscribed_at"`
+}
+
+// ChannelPost is a message an admin broadcast to a channel. ViewCount counts
+// the distinct subscribers who saw it.
+type ChannelPost struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ChannelID  primitive.ObjectID `bson:"channel_id" json:"channel_id"`
+	AuthorID   primitive.ObjectID `bson:"author_id" json:"author_id"`
+	AuthorName string             `bson:"author_name,omitempty" json:"author_name,omitempty"`
+	Content    string             `bson:"content,omitempty" json:"content,omitempty"`
+	MediaURLs  []string           `bson:"media_urls,omitempty" json:"media_urls,omitempty"`
+	ViewCount  int64              `bson:"view_count" json:"view_count"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+}
+
+type CreateChannelRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=100"`
+	Description string `json:"description" binding:"max=500"`
+	Avatar      string `json:"avatar"`
+}
+
+// UpdateChannelRequest changes a channel's profile; omitted fields are left
+// as they are
+type UpdateChannelRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+	Avatar      *string `json:"avatar"`
+}
+
+type CreateChannelPostRequest struct {
+	Content   string   `json:"content" binding:"max=4096"`
+	MediaURLs []string `json:"media_urls" binding:"max=10"`
+}
+
+// RecordChannelViewsRequest lists the posts of a channel a subscriber saw
+type RecordChannelViewsRequest struct {
+	PostIDs []string `json:"post_ids" binding:"required,min=1,max=100"`
+}
+
+// ChannelPostPage is a page of a channel's posts, newest first. NextCursor is
+// empty on the last page.
+type ChannelPostPage struct {
+	Posts      []ChannelPost `json:"posts"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+// ChannelSubscriberPage is a page of a channel's subscribers, oldest first.
+// NextCursor is empty on the last page.
+type ChannelSubscriberPage struct {
+	Subscribers []SafeUserResponse `json:"subscribers"`
+	Total       int64              `json:"total"`
+	NextCursor  string             `json:"next_cursor,omitempty"`
+}
+
+// ChannelFanoutJob delivers a channel post to the page of subscribers after
+// the subscription After; each page queues the job for the next one
+type ChannelFanoutJob struct {
+	ChannelID primitive.ObjectID `json:"channel_id"`
+	PostID    primitive.ObjectID `json:"post_id"`
+	After     primitive.ObjectID `json:"after"`
+	Page      int                `json:"page"`
+}
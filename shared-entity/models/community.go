@@ -40,6 +40,7 @@ type Community struct {
 	Admins              []primitive.ObjectID `bson:"admins" json:"admins"`
 	PendingMembers      []primitive.ObjectID `bson:"pending_members" json:"pending_members"`
 	BannedUsers         []primitive.ObjectID `bson:"banned_users" json:"banned_users"`
+	MutedMembers        []CommunityMute      `bson:"muted_members,omitempty" json:"muted_members,omitempty"`
 	Settings            CommunitySettings    `bson:"settings" json:"settings"`
 	Rules               []CommunityRule      `bson:"rules" json:"rules"`                               // New: Group Rules
 	MembershipQuestions []string             `bson:"membership_questions" json:"membership_questions"` // New: Questions for joining
@@ -109,3 +110,51 @@ type CommunityResponse struct {
 	IsPending           bool                `json:"is_pending"`
 	CreatedAt           time.Time           `json:"created_at"`
 }
+
+// CommunityInvite is a shareable link that lets people join a community,
+// private or not, without waiting for approval
+type CommunityInvite struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	CommunityID primitive.ObjectID `bson:"community_id" json:"community_id"`
+	Code        string             `bson:"code" json:"code"`
+	CreatedBy   primitive.ObjectID `bson:"created_by" json:"created_by"`
+	MaxUses     int                `bson:"max_uses" json:"max_uses"` // 0 means unlimited
+	Uses        int                `bson:"uses" json:"uses"`
+	ExpiresAt   *time.Time         `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// CreateCommunityInviteRequest configures a new invite link. Both limits are
+// optional; an invite without them never runs out.
+type CreateCommunityInviteRequest struct {
+	ExpiresInHours int `json:"expires_in_hours" binding:"omitempty,min=1,max=8760"`
+	MaxUses        int `json:"max_uses" binding:"omitempty,min=1,max=100000"`
+}
+
+// CommunityInvitePreview is what someone opening an invite link sees before
+// joining
+type CommunityInvitePreview struct {
+	Community     CommunityResponse `json:"community"`
+	ExpiresAt     *time.Time        `json:"expires_at,omitempty"`
+	RemainingUses *int              `json:"remaining_uses,omitempty"`
+}
+
+type CommunityJoinRequestStatus string
+
+const (
+	CommunityJoinRequestPending   CommunityJoinRequestStatus = "pending"
+	CommunityJoinRequestApproved  CommunityJoinRequestStatus = "approved"
+	CommunityJoinRequestRejected  CommunityJoinRequestStatus = "rejected"
+	CommunityJoinRequestCancelled CommunityJoinRequestStatus = "cancelled"
+)
+
+// CommunityJoinRequestEvent tells a community's admins about a join request.
+// Once the request is resolved the requester is told as well.
+type CommunityJoinRequestEvent struct {
+	CommunityID   string                     `json:"community_id"`
+	CommunityName string                     `json:"community_name"`
+	User          PostAuthor                 `json:"user"`
+	Status        CommunityJoinRequestStatus `json:"status"`
+	ResolvedBy    string                     `json:"resolved_by,omitempty"`
+	PendingCount  int                        `json:"pending_count"` // Requests still waiting
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CommunityMute keeps a member from posting and commenting in a community
+// until it ends
+type CommunityMute struct {
+	UserID  primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Until   time.Time          `bson:"until" json:"until"`
+	Reason  string             `bson:"reason,omitempty" json:"reason,omitempty"`
+	MutedBy primitive.ObjectID `bson:"muted_by" json:"muted_by"`
+}
+
+// MutedUntil returns when the user's mute ends, if they are muted at now
+func (c *Community) MutedUntil(userID primitive.ObjectID, now time.Time) (time.Time, bool) {
+	for _, mute := range c.MutedMembers {
+		if mute.UserID == userID && mute.Until.After(now) {
+			return mute.Until, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// IsBanned reports whether the user is on the community's ban list
+func (c *Community) IsBanned(userID primitive.ObjectID) bool {
+	for _, id := range c.BannedUsers {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// CommunityModerationAction names what a community admin did
+type CommunityModerationAction string
+
+const (
+	CommunityModerationRemovePost   CommunityModerationAction = "remove_post"
+	CommunityModerationRestorePost  CommunityModerationAction = "restore_post"
+	CommunityModerationLockPost     CommunityModerationAction = "lock_post"
+	CommunityModerationUnlockPost   CommunityModerationAction = "unlock_post"
+	CommunityModerationMuteMember   CommunityModerationAction = "mute_member"
+	CommunityModerationUnmuteMember CommunityModerationAction = "unmute_member"
+	CommunityModerationBanMember    CommunityModerationAction = "ban_member"
+	CommunityModerationUnbanMember  CommunityModerationAction = "unban_member"
+	CommunityModerationApprovePost  CommunityModerationAction = "approve_post"
+	CommunityModerationDeclinePost  CommunityModerationAction = "decline_post"
+)
+
+// CommunityModerationLogEntry records one moderation action taken in a
+// community, for its admins to review
+type CommunityModerationLogEntry struct {
+	ID          primitive.ObjectID        `bson:"_id,omitempty" json:"id"`
+	CommunityID primitive.ObjectID        `bson:"community_id" json:"community_id"`
+	ActorID     primitive.ObjectID        `bson:"actor_id" json:"actor_id"`
+	Action      CommunityModerationAction `bson:"action" json:"action"`
+	TargetType  string                    `bson:"target_type" json:"target_type"` // post or user
+	TargetID    primitive.ObjectID        `bson:"target_id" json:"target_id"`
+	Reason      string                    `bson:"reason,omitempty" json:"reason,omitempty"`
+	Until       *time.Time                `bson:"until,omitempty" json:"until,omitempty"` // End of a mute
+	CreatedAt   time.Time                 `bson:"created_at" json:"created_at"`
+}
+
+// CommunityModerationLogResponse is a page of a community's moderation log,
+// newest first
+type CommunityModerationLogResponse struct {
+	Entries []CommunityModerationLogEntry `json:"entries"`
+	Total   int64                         `json:"total"`
+	Page    int64                         `json:"page"`
+	Limit   int64                         `json:"limit"`
+}
+
+// CommunityModerationRequest carries the reason shown to the author and
+// kept in the moderation log
+type CommunityModerationRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// MuteCommunityMemberRequest mutes a member for a while
+type MuteCommunityMemberRequest struct {
+	DurationMinutes int    `json:"duration_minutes" binding:"required,min=1,max=525600"` // Up to a year
+	Reason          string `json:"reason" binding:"max=500"`
+}
+
+// PendingCommunityPost is a post waiting in a community's approval queue,
+// with what the community knows about its author
+type PendingCommunityPost struct {
+	Post             Post       `json:"post"`
+	AuthorIsMember   bool       `json:"author_is_member"`
+	AuthorMutedUntil *time.Time `json:"author_muted_until,omitempty"`
+	AuthorApproved   int64      `json:"author_approved_posts"` // Earlier posts of theirs live in the community
+	AuthorDeclined   int64      `json:"author_declined_posts"` // Earlier posts of theirs declined or removed
+}
+
+// PendingCommunityPostsResponse is a page of a community's approval queue,
+// oldest first
+type PendingCommunityPostsResponse struct {
+	Posts []PendingCommunityPost `json:"posts"`
+	Total int64                  `json:"total"`
+	Page  int64                  `json:"page"`
+	Limit int64                  `json:"limit"`
+}
+
+// ReviewPendingPostsRequest approves or declines pending posts in bulk
+type ReviewPendingPostsRequest struct {
+	PostIDs  []primitive.ObjectID `json:"post_ids" binding:"required,min=1,max=100"`
+	Decision string               `json:"decision" binding:"required,oneof=approve decline"`
+	Reason   string               `json:"reason" binding:"max=500"` // Shown to the authors of declined posts
+}
+
+// ReviewPendingPostsResult tells which posts a bulk review settled. Posts
+// that were not pending in the community anymore are skipped.
+type ReviewPendingPostsResult struct {
+	Approved []primitive.ObjectID `json:"approved"`
+	Declined []primitive.ObjectID `json:"declined"`
+	Skipped  []primitive.ObjectID `json:"skipped"`
+}
@@ -0,0 +1,216 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErasureStatus tracks the lifecycle of a GDPR erasure request and of each
+// participating service within it
+type ErasureStatus string
+
+const (
+	ErasureStatusPending    ErasureStatus = "pending"     // Not yet dispatched
+	ErasureStatusInProgress ErasureStatus = "in_progress" // Dispatched, awaiting acknowledgement
+	ErasureStatusCompleted  ErasureStatus = "completed"
+	ErasureStatusFailed     ErasureStatus = "failed" // Retries exhausted; needs operator attention
+)
+
+// Services that hold user data and must acknowledge an erasure request
+const (
+	ErasureServiceMessaging   = "messaging"
+	ErasureServiceFeed        = "feed"
+	ErasureServiceEvents      = "events"
+	ErasureServiceMarketplace = "marketplace"
+	ErasureServiceStories     = "stories"
+	ErasureServiceReels       = "reels"
+	ErasureServiceStorage     = "storage"
+)
+
+// ErasureServices lists every service an erasure request fans out to
+var ErasureServices = []string{
+	ErasureServiceMessaging,
+	ErasureServiceFeed,
+	ErasureServiceEvents,
+	ErasureServiceMarketplace,
+	ErasureServiceStories,
+	ErasureServiceReels,
+	ErasureServiceStorage,
+}
+
+// ErasureServiceProgress is the per-service state of an erasure request
+type ErasureServiceProgress struct {
+	Service      string        `bson:"service" json:"service"`
+	Status       ErasureStatus `bson:"status" json:"status"`
+	Attempts     int           `bson:"attempts" json:"attempts"`
+	ItemsErased  int64         `bson:"items_erased" json:"items_erased"`
+	LastError    string        `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	DispatchedAt *time.Time    `bson:"dispatched_at,omitempty" json:"dispatched_at,omitempty"`
+	CompletedAt  *time.Time    `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
+
+// ErasureRequest records a user's right-to-erasure request and its progress
+// across every service that stores their data
+type ErasureRequest struct {
+	ID          primitive.ObjectID       `bson:"_id,omitempty" json:"id"`
+	UserID      primitive.ObjectID       `bson:"user_id" json:"user_id"`
+	RequestedBy primitive.ObjectID       `bson:"requested_by" json:"requested_by"` // The user themself or an admin
+	Reason      string                   `bson:"reason,omitempty" json:"reason,omitempty"`
+	Status      ErasureStatus            `bson:"status" json:"status"`
+	Services    []ErasureServiceProgress `bson:"services" json:"services"`
+	CreatedAt   time.Time                `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time                `bson:"updated_at" json:"updated_at"`
+	CompletedAt *time.Time               `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
+
+// Service returns the progress entry for the named service, or nil
+func (r *ErasureRequest) Service(name string) *ErasureServiceProgress {
+	for i := range r.Services {
+		if r.Services[i].Service == name {
+			return &r.Services[i]
+		}
+	}
+	return nil
+}
+
+// ErasureReport is the auditable summary produced once a request settles
+type ErasureReport struct {
+	RequestID        primitive.ObjectID       `json:"request_id"`
+	UserID           primitive.ObjectID       `json:"user_id"`
+	RequestedBy      primitive.ObjectID       `json:"requested_by"`
+	Status           ErasureStatus            `json:"status"`
+	RequestedAt      time.Time                `json:"requested_at"`
+	CompletedAt      *time.Time               `json:"completed_at,omitempty"`
+	Duration         string                   `json:"duration,omitempty"`
+	TotalItemsErased int64                    `json:"total_items_erased"`
+	TotalAttempts    int                      `json:"total_attempts"`
+	Services         []ErasureServiceProgress `json:"services"`
+	GeneratedAt      time.Time                `json:"generated_at"`
+}
+
+// ErasureRequestListResponse is a paginated list of erasure requests
+type ErasureRequestListResponse struct {
+	Requests []ErasureRequest `json:"requests"`
+	Total    int64            `json:"total"`
+	Page     int64            `json:"page"`
+	Limit    int64            `json:"limit"`
+}
+
+// DataExportStatus tracks a "Download your information" export
+type DataExportStatus string
+
+const (
+	DataExportStatusPending    DataExportStatus = "pending"     // Waiting for the export worker
+	DataExportStatusInProgress DataExportStatus = "in_progress" // Collecting data and building the archive
+	DataExportStatusReady      DataExportStatus = "ready"       // Downloadable until ExpiresAt
+	DataExportStatusFailed     DataExportStatus = "failed"
+	DataExportStatusExpired    DataExportStatus = "expired" // Archive deleted
+)
+
+// DataExportServiceUser names user-service's own part of an export
+const DataExportServiceUser = "user"
+
+// DataExportServices lists every service a data export collects from
+var DataExportServices = []string{
+	DataExportServiceUser,
+	ErasureServiceMessaging,
+	ErasureServiceFeed,
+	ErasureServiceEvents,
+	ErasureServiceMarketplace,
+	ErasureServiceStories,
+	ErasureServiceReels,
+}
+
+// DataExportServiceProgress is the per-service outcome of a data export
+type DataExportServiceProgress struct {
+	Service     string     `bson:"service" json:"service"`
+	Records     int64      `bson:"records" json:"records"`
+	MediaFiles  int        `bson:"media_files" json:"media_files"`
+	Attempts    int        `bson:"attempts" json:"attempts"`
+	LastError   string     `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	CompletedAt *time.Time `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
+
+// DataExportRequest is a user's request for an archive of their data
+type DataExportRequest struct {
+	ID          primitive.ObjectID          `bson:"_id,omitempty" json:"id"`
+	UserID      primitive.ObjectID          `bson:"user_id" json:"user_id"`
+	Status      DataExportStatus            `bson:"status" json:"status"`
+	Services    []DataExportServiceProgress `bson:"services" json:"services"`
+	ArchiveKey  string                      `bson:"archive_key,omitempty" json:"-"` // Storage object key of the archive
+	SizeBytes   int64                       `bson:"size_bytes,omitempty" json:"size_bytes,omitempty"`
+	Error       string                      `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedAt   time.Time                   `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time                   `bson:"updated_at" json:"updated_at"`
+	CompletedAt *time.Time                  `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time                  `bson:"expires_at,omitempty" json:"expires_at,omitempty"` // When the archive is deleted
+	DownloadURL string                      `bson:"-" json:"download_url,omitempty"`                  // Presigned, issued per read
+}
+
+// Service returns the progress entry for the named service, or nil
+func (r *DataExportRequest) Service(name string) *DataExportServiceProgress {
+	for i := range r.Services {
+		if r.Services[i].Service == name {
+			return &r.Services[i]
+		}
+	}
+	return nil
+}
+
+// AccountDeletionStatus tracks an account deletion from the user's request,
+// through its grace period, to the cascade across services
+type AccountDeletionStatus string
+
+const (
+	AccountDeletionStatusScheduled  AccountDeletionStatus = "scheduled" // In the grace period; the user can still cancel
+	AccountDeletionStatusCancelled  AccountDeletionStatus = "cancelled"
+	AccountDeletionStatusInProgress AccountDeletionStatus = "in_progress" // Account removed; services are purging its data
+	AccountDeletionStatusCompleted  AccountDeletionStatus = "completed"
+	AccountDeletionStatusFailed     AccountDeletionStatus = "failed" // Retries exhausted; needs operator attention
+)
+
+// AccountDeletionServices lists every service a deleted account cascades to.
+// user-service removes the account and its graph node itself before them.
+var AccountDeletionServices = []string{
+	ErasureServiceMessaging,
+	ErasureServiceFeed,
+	ErasureServiceEvents,
+	ErasureServiceMarketplace,
+	ErasureServiceStories,
+	ErasureServiceReels,
+}
+
+// AccountDeletion is a user's request to delete their account. Per-service
+// progress uses the same bookkeeping as erasure requests, with ItemsErased
+// counting what each service purged or anonymized.
+type AccountDeletion struct {
+	ID           primitive.ObjectID       `bson:"_id,omitempty" json:"id"`
+	UserID       primitive.ObjectID       `bson:"user_id" json:"user_id"`
+	Reason       string                   `bson:"reason,omitempty" json:"reason,omitempty"`
+	Status       AccountDeletionStatus    `bson:"status" json:"status"`
+	ScheduledFor time.Time                `bson:"scheduled_for" json:"scheduled_for"`           // End of the grace period
+	Services     []ErasureServiceProgress `bson:"services,omitempty" json:"services,omitempty"` // Set once the grace period ends
+	CreatedAt    time.Time                `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time                `bson:"updated_at" json:"updated_at"`
+	CancelledAt  *time.Time               `bson:"cancelled_at,omitempty" json:"cancelled_at,omitempty"`
+	DeletedAt    *time.Time               `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"` // When the account itself was removed
+	CompletedAt  *time.Time               `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
+
+// Service returns the progress entry for the named service, or nil
+func (d *AccountDeletion) Service(name string) *ErasureServiceProgress {
+	for i := range d.Services {
+		if d.Services[i].Service == name {
+			return &d.Services[i]
+		}
+	}
+	return nil
+}
+
+// DeleteAccountRequest schedules deletion of the caller's account. The
+// password is asked for again so a stolen session cannot delete the account.
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+	Reason   string `json:"reason" binding:"max=500"`
+}
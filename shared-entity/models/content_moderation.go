@@ -0,0 +1,120 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ModerationAction is what a text moderation rule does to matching content
+type ModerationAction string
+
+const (
+	ModerationActionFlag   ModerationAction = "flag"   // Published, queued for a moderator to look at
+	ModerationActionHold   ModerationAction = "hold"   // Kept from others until a moderator approves it
+	ModerationActionReject ModerationAction = "reject" // Refused outright
+)
+
+// Severity ranks actions so the strictest matching rule wins
+func (a ModerationAction) Severity() int {
+	switch a {
+	case ModerationActionFlag:
+		return 1
+	case ModerationActionHold:
+		return 2
+	case ModerationActionReject:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// ModerationRuleKind tells how a rule's patterns are matched
+type ModerationRuleKind string
+
+const (
+	ModerationRuleKeyword ModerationRuleKind = "keyword" // Whole words or phrases, case-insensitive
+	ModerationRuleRegex   ModerationRuleKind = "regex"
+)
+
+// ModerationRule matches text against keywords or regular expressions. Rules
+// without a community apply everywhere; community rules apply on top of them
+// to the posts of that community.
+type ModerationRule struct {
+	ID          primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	CommunityID *primitive.ObjectID `bson:"community_id,omitempty" json:"community_id,omitempty"`
+	Name        string              `bson:"name" json:"name"`
+	Kind        ModerationRuleKind  `bson:"kind" json:"kind"`
+	Patterns    []string            `bson:"patterns" json:"patterns"`
+	Action      ModerationAction    `bson:"action" json:"action"`
+	Enabled     bool                `bson:"enabled" json:"enabled"`
+	CreatedBy   primitive.ObjectID  `bson:"created_by" json:"created_by"`
+	CreatedAt   time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+type CreateModerationRuleRequest struct {
+	Name     string             `json:"name" binding:"required"`
+	Kind     ModerationRuleKind `json:"kind" binding:"required,oneof=keyword regex"`
+	Patterns []string           `json:"patterns" binding:"required,min=1"`
+	Action   ModerationAction   `json:"action" binding:"required,oneof=flag hold reject"`
+	Enabled  *bool              `json:"enabled,omitempty"` // Defaults to true
+}
+
+type UpdateModerationRuleRequest struct {
+	Name     string             `json:"name,omitempty"`
+	Kind     ModerationRuleKind `json:"kind,omitempty" binding:"omitempty,oneof=keyword regex"`
+	Patterns []string           `json:"patterns,omitempty"`
+	Action   ModerationAction   `json:"action,omitempty" binding:"omitempty,oneof=flag hold reject"`
+	Enabled  *bool              `json:"enabled,omitempty"`
+}
+
+// ModeratedContentType names the kind of content a queue item points at
+type ModeratedContentType string
+
+const (
+	ModeratedPost      ModeratedContentType = "post"
+	ModeratedComment   ModeratedContentType = "comment"
+	ModeratedReply     ModeratedContentType = "reply"
+	ModeratedMessage   ModeratedContentType = "message"
+	ModeratedEvent     ModeratedContentType = "event"
+	ModeratedEventPost ModeratedContentType = "event_post"
+)
+
+// ModerationQueueStatus tracks a queue item through review
+type ModerationQueueStatus string
+
+const (
+	ModerationQueuePending  ModerationQueueStatus = "pending"
+	ModerationQueueApproved ModerationQueueStatus = "approved"
+	ModerationQueueRemoved  ModerationQueueStatus = "removed"
+)
+
+// ModerationQueueItem is content a rule flagged or held, waiting on a
+// moderator. The service owning the content applies the review decision.
+type ModerationQueueItem struct {
+	ID          primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	ContentType ModeratedContentType `bson:"content_type" json:"content_type"`
+	ContentID   string               `bson:"content_id" json:"content_id"`
+	// ParentID locates the content within its container: the post of a
+	// comment, the comment of a reply, the conversation of a message or the
+	// event of an event post
+	ParentID    string                `bson:"parent_id,omitempty" json:"parent_id,omitempty"`
+	CommunityID *primitive.ObjectID   `bson:"community_id,omitempty" json:"community_id,omitempty"`
+	AuthorID    primitive.ObjectID    `bson:"author_id" json:"author_id"`
+	Excerpt     string                `bson:"excerpt" json:"excerpt"`
+	Action      ModerationAction      `bson:"action" json:"action"`
+	Rules       []string              `bson:"rules" json:"rules"` // Names of the matching rules
+	Status      ModerationQueueStatus `bson:"status" json:"status"`
+	ReviewedBy  *primitive.ObjectID   `bson:"reviewed_by,omitempty" json:"reviewed_by,omitempty"`
+	ReviewNote  string                `bson:"review_note,omitempty" json:"review_note,omitempty"`
+	ReviewedAt  *time.Time            `bson:"reviewed_at,omitempty" json:"reviewed_at,omitempty"`
+	AppliedAt   *time.Time            `bson:"applied_at,omitempty" json:"applied_at,omitempty"`
+	LockedUntil *time.Time            `bson:"locked_until,omitempty" json:"-"` // Leased to the service applying the review
+	CreatedAt   time.Time             `bson:"created_at" json:"created_at"`
+}
+
+type ReviewModerationItemRequest struct {
+	Decision ModerationQueueStatus `json:"decision" binding:"required,oneof=approved removed"`
+	Note     string                `json:"note,omitempty"`
+}
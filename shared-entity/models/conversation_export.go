@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ConversationExportStatus tracks an export job
+type ConversationExportStatus string
+
+const (
+	ConversationExportPending    ConversationExportStatus = "pending"
+	ConversationExportProcessing ConversationExportStatus = "processing"
+	ConversationExportReady      ConversationExportStatus = "ready"
+	ConversationExportFailed     ConversationExportStatus = "failed"
+)
+
+// Formats a conversation can be exported in
+const (
+	ConversationExportJSON = "json"
+	ConversationExportHTML = "html"
+)
+
+// ConversationExport is a user's request for a downloadable copy of one
+// conversation. Once ready, the file stays available until ExpiresAt.
+type ConversationExport struct {
+	ID             primitive.ObjectID       `bson:"_id,omitempty" json:"id"`
+	UserID         primitive.ObjectID       `bson:"user_id" json:"user_id"`
+	ConversationID string                   `bson:"conversation_id" json:"conversation_id"`
+	Format         string                   `bson:"format" json:"format"`
+	Status         ConversationExportStatus `bson:"status" json:"status"`
+	MessageCount   int                      `bson:"message_count" json:"message_count"`
+	FileKey        string                   `bson:"file_key,omitempty" json:"-"`
+	DownloadURL    string                   `bson:"-" json:"download_url,omitempty"` // Signed on read
+	Error          string                   `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedAt      time.Time                `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time                `bson:"updated_at" json:"updated_at"`
+	CompletedAt    *time.Time               `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+	ExpiresAt      *time.Time               `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+}
+
+// ConversationExportRequest starts an export; the format defaults to JSON
+type ConversationExportRequest struct {
+	Format string `json:"format" binding:"omitempty,oneof=json html"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// MaxRetentionDays caps how long a retention policy may keep messages
+const MaxRetentionDays = 3650
+
+// ConversationRetention is how long a conversation keeps its messages.
+// RetentionDays of zero keeps them forever; otherwise messages older than
+// that many days are purged, archived ones included.
+type ConversationRetention struct {
+	ConversationID string     `json:"conversation_id"`
+	RetentionDays  int        `json:"retention_days"`
+	UpdatedBy      string     `json:"updated_by,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateRetentionRequest sets a conversation's retention; 0 keeps messages
+// forever
+type UpdateRetentionRequest struct {
+	RetentionDays *int `json:"retention_days" binding:"required,min=0,max=3650"`
+}
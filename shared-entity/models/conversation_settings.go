@@ -0,0 +1,64 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxNicknameRunes caps the length of a nickname
+	MaxNicknameRunes = 40
+	// MaxQuickReactionRunes matches the longest emoji a reaction may be
+	MaxQuickReactionRunes = 16
+)
+
+var themeColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ConversationSettings customize a conversation for all of its participants:
+// nicknames for participants, the emoji of the quick-reaction button and the
+// theme color. Unset fields fall back to the client's defaults.
+type ConversationSettings struct {
+	ConversationID string            `json:"conversation_id"`
+	Nicknames      map[string]string `json:"nicknames,omitempty"` // By user ID
+	QuickReaction  string            `json:"quick_reaction,omitempty"`
+	Theme          string            `json:"theme,omitempty"` // Color as #rrggbb
+	UpdatedBy      string            `json:"updated_by,omitempty"`
+	UpdatedAt      *time.Time        `json:"updated_at,omitempty"`
+}
+
+// UpdateConversationSettingsRequest changes a conversation's settings.
+// Omitted fields are left as they are; empty values reset them.
+type UpdateConversationSettingsRequest struct {
+	Nicknames     map[string]string `json:"nicknames"` // By user ID; an empty nickname removes it
+	QuickReaction *string           `json:"quick_reaction"`
+	Theme         *string           `json:"theme"`
+}
+
+// Normalize trims the request's values and checks their format
+func (r *UpdateConversationSettingsRequest) Normalize() error {
+	for userID, nickname := range r.Nicknames {
+		nickname = strings.TrimSpace(nickname)
+		if utf8.RuneCountInString(nickname) > MaxNicknameRunes {
+			return errors.New("nicknames are at most 40 characters")
+		}
+		r.Nicknames[userID] = nickname
+	}
+	if r.QuickReaction != nil {
+		emoji := strings.TrimSpace(*r.QuickReaction)
+		if utf8.RuneCountInString(emoji) > MaxQuickReactionRunes {
+			return errors.New("quick reaction must be a single emoji")
+		}
+		r.QuickReaction = &emoji
+	}
+	if r.Theme != nil {
+		theme := strings.TrimSpace(*r.Theme)
+		if theme != "" && !themeColorPattern.MatchString(theme) {
+			return errors.New("theme must be a color like #1e90ff")
+		}
+		r.Theme = &theme
+	}
+	return nil
+}
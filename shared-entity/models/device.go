@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DevicePlatform is the kind of client a push token belongs to. iOS tokens are
+// APNs device tokens; Android and web tokens are FCM registration tokens.
+type DevicePlatform string
+
+const (
+	DevicePlatformAndroid DevicePlatform = "android"
+	DevicePlatformIOS     DevicePlatform = "ios"
+	DevicePlatformWeb     DevicePlatform = "web"
+)
+
+// Valid reports whether p is a supported platform
+func (p DevicePlatform) Valid() bool {
+	switch p {
+	case DevicePlatformAndroid, DevicePlatformIOS, DevicePlatformWeb:
+		return true
+	}
+	return false
+}
+
+// DeviceToken is a push token registered by one of a user's devices. A token
+// belongs to one user at a time; registering it again moves it.
+type DeviceToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Token     string             `bson:"token" json:"token"`
+	Platform  DevicePlatform     `bson:"platform" json:"platform"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type RegisterDeviceRequest struct {
+	Token    string         `json:"token" binding:"required"`
+	Platform DevicePlatform `json:"platform" binding:"required"`
+}
@@ -21,6 +21,11 @@ const (
 	RSVPStatusInterested RSVPStatus = "interested"
 	RSVPStatusInvited    RSVPStatus = "invited"
 	RSVPStatusNotGoing   RSVPStatus = "not_going"
+	// RSVPStatusWaitlisted marks users who are going but queued for a seat
+	RSVPStatusWaitlisted RSVPStatus = "waitlisted"
+	// RSVPStatusPendingApproval marks users waiting for a host to approve
+	// them on events that require approval
+	RSVPStatusPendingApproval RSVPStatus = "pending_approval"
 )
 
 type EventAttendee struct {
@@ -47,6 +52,22 @@ type Event struct {
 	Stats       EventStats         `bson:"stats" json:"stats"`
 	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+
+	// Recurring events: StartDate/EndDate are the first occurrence. RecurrenceEnd
+	// is the start of the last occurrence, nil while the series is unbounded.
+	Recurrence          *RecurrenceRule           `bson:"recurrence,omitempty" json:"recurrence,omitempty"`
+	RecurrenceEnd       *time.Time                `bson:"recurrence_end,omitempty" json:"-"`
+	OccurrenceOverrides []EventOccurrenceOverride `bson:"occurrence_overrides,omitempty" json:"occurrence_overrides,omitempty"`
+	OccurrenceRSVPs     []OccurrenceRSVP          `bson:"occurrence_rsvps,omitempty" json:"-"`
+
+	// Ticketing: Capacity caps confirmed tickets, 0 meaning unlimited.
+	// SeatsTaken only changes through atomic seat reservations.
+	Capacity    int64        `bson:"capacity,omitempty" json:"capacity,omitempty"`
+	TicketTiers []TicketTier `bson:"ticket_tiers,omitempty" json:"ticket_tiers,omitempty"`
+	SeatsTaken  int64        `bson:"seats_taken" json:"-"`
+
+	// RequiresApproval holds going RSVPs from uninvited users until a host approves them
+	RequiresApproval bool `bson:"requires_approval,omitempty" json:"requires_approval,omitempty"`
 }
 
 type EventStats struct {
@@ -54,20 +75,26 @@ type EventStats struct {
 	InterestedCount int64 `bson:"interested_count" json:"interested_count"`
 	InvitedCount    int64 `bson:"invited_count" json:"invited_count"`
 	ShareCount      int64 `bson:"share_count" json:"share_count"`
+	WaitlistCount   int64 `bson:"waitlist_count" json:"waitlist_count"`
 }
 
 // APIs
 
 type CreateEventRequest struct {
-	Title       string       `json:"title" binding:"required"`
-	Description string       `json:"description" binding:"required"`
-	StartDate   time.Time    `json:"start_date" binding:"required"`
-	EndDate     time.Time    `json:"end_date"` // Optional
-	Location    string       `json:"location"`
-	IsOnline    bool         `json:"is_online"`
-	Privacy     EventPrivacy `json:"privacy" binding:"required,oneof=public private friends"`
-	Category    string       `json:"category"`
-	CoverImage  string       `json:"cover_image"`
+	Title       string          `json:"title" binding:"required"`
+	Description string          `json:"description" binding:"required"`
+	StartDate   time.Time       `json:"start_date" binding:"required"`
+	EndDate     time.Time       `json:"end_date"` // Optional
+	Location    string          `json:"location"`
+	IsOnline    bool            `json:"is_online"`
+	Privacy     EventPrivacy    `json:"privacy" binding:"required,oneof=public private friends"`
+	Category    string          `json:"category"`
+	CoverImage  string          `json:"cover_image"`
+	Recurrence  *RecurrenceRule `json:"recurrence,omitempty"`
+	// Capacity of 0 leaves the event unlimited. Without tiers tickets are free.
+	Capacity         int64               `json:"capacity,omitempty"`
+	TicketTiers      []TicketTierRequest `json:"ticket_tiers,omitempty" binding:"omitempty,dive"`
+	RequiresApproval bool                `json:"requires_approval,omitempty"`
 }
 
 type UpdateEventRequest struct {
@@ -80,10 +107,22 @@ type UpdateEventRequest struct {
 	Privacy     EventPrivacy `json:"privacy,omitempty" binding:"omitempty,oneof=public private friends"`
 	Category    string       `json:"category"`
 	CoverImage  string       `json:"cover_image"`
+	// Recurring events only. Scope "this" edits the occurrence starting at
+	// OccurrenceStart; "all" (the default) edits the series.
+	Recurrence      *RecurrenceRule `json:"recurrence,omitempty"`
+	Scope           EventEditScope  `json:"scope,omitempty" binding:"omitempty,oneof=this all"`
+	OccurrenceStart *time.Time      `json:"occurrence_start,omitempty"`
+	// Raising the capacity promotes users from the waitlist; 0 removes the limit
+	Capacity         *int64 `json:"capacity,omitempty"`
+	RequiresApproval *bool  `json:"requires_approval,omitempty"`
 }
 
 type RSVPRequest struct {
 	Status RSVPStatus `json:"status" binding:"required,oneof=going interested not_going"`
+	// OccurrenceStart limits the RSVP to one occurrence of a recurring event
+	OccurrenceStart *time.Time `json:"occurrence_start,omitempty"`
+	// TierID picks the ticket tier when going; the first tier is the default
+	TierID string `json:"tier_id,omitempty"`
 }
 
 type EventResponse struct {
@@ -103,6 +142,17 @@ type EventResponse struct {
 	IsHost       bool         `json:"is_host"`
 	FriendsGoing []UserShort  `json:"friends_going,omitempty"` // Friends who are going to this event
 	CreatedAt    time.Time    `json:"created_at"`
+
+	Recurrence      *RecurrenceRule `json:"recurrence,omitempty"`
+	OccurrenceStart *time.Time      `json:"occurrence_start,omitempty"` // Set when the response is one occurrence of a recurring event
+
+	Capacity    int64        `json:"capacity,omitempty"`
+	SeatsLeft   *int64       `json:"seats_left,omitempty"` // Nil when the event is unlimited
+	TicketTiers []TicketTier `json:"ticket_tiers,omitempty"`
+
+	RequiresApproval bool `json:"requires_approval,omitempty"`
+	// CoHostPermissions is set when the viewer is a co-host
+	CoHostPermissions *CoHostPermission `json:"co_host_permissions,omitempty"`
 }
 
 type UserShort struct {
@@ -110,6 +160,7 @@ type UserShort struct {
 	Username string `json:"username"`
 	FullName string `json:"full_name"`
 	Avatar   string `json:"avatar"`
+	Verified bool   `json:"verified,omitempty"`
 }
 
 type BirthdayUser struct {
@@ -165,6 +216,8 @@ type EventRSVPEvent struct {
 	Status    RSVPStatus `json:"status"`
 	Timestamp time.Time  `json:"timestamp"`
 	Stats     EventStats `json:"stats,omitempty"` // Included to update counts
+	// Set for an RSVP to one occurrence of a recurring event; Stats are then that occurrence's
+	OccurrenceStart *time.Time `json:"occurrence_start,omitempty"`
 }
 
 // EventUpdatedEvent represents a WebSocket event for event updates
@@ -180,12 +233,16 @@ type EventUpdatedEvent struct {
 	Category    string       `json:"category"`
 	CoverImage  string       `json:"cover_image"`
 	UpdatedAt   time.Time    `json:"updated_at"`
+	// Set when only one occurrence of a recurring event changed
+	OccurrenceStart *time.Time `json:"occurrence_start,omitempty"`
 }
 
 // EventDeletedEvent represents a WebSocket event for event deletion
 type EventDeletedEvent struct {
 	ID        string    `json:"id"`
 	DeletedAt time.Time `json:"deleted_at"`
+	// Set when only one occurrence of a recurring event was cancelled
+	OccurrenceStart *time.Time `json:"occurrence_start,omitempty"`
 }
 
 // EventPostCreatedEvent represents a WebSocket event for new posts
@@ -294,14 +351,49 @@ type ReactToPostRequest struct {
 // Event Co-Host Models
 // ===============================
 
+// CoHostPermission is a bitmask of what a co-host may do on the creator's behalf
+type CoHostPermission uint32
+
+const (
+	CoHostPermEditDetails CoHostPermission = 1 << iota
+	CoHostPermManagePosts
+	CoHostPermInvite
+	CoHostPermApproveAttendees
+
+	CoHostPermAll = CoHostPermEditDetails | CoHostPermManagePosts | CoHostPermInvite | CoHostPermApproveAttendees
+	// CoHostPermDefault is granted to co-hosts added without explicit permissions
+	CoHostPermDefault = CoHostPermAll
+)
+
+// Has reports whether every permission in perm is granted
+func (p CoHostPermission) Has(perm CoHostPermission) bool {
+	return p&perm == perm
+}
+
 type EventCoHost struct {
 	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
 	AddedAt   time.Time          `bson:"added_at" json:"added_at"`
 	AddedByID primitive.ObjectID `bson:"added_by_id" json:"added_by_id"`
+	// Permissions is nil for co-hosts added before permissions existed
+	Permissions *CoHostPermission `bson:"permissions,omitempty" json:"permissions,omitempty"`
+}
+
+// Granted returns the co-host's permissions, defaulting for co-hosts added
+// before permissions existed
+func (c EventCoHost) Granted() CoHostPermission {
+	if c.Permissions == nil {
+		return CoHostPermDefault
+	}
+	return *c.Permissions
 }
 
 type AddCoHostRequest struct {
-	UserID string `json:"user_id" binding:"required"`
+	UserID      string            `json:"user_id" binding:"required"`
+	Permissions *CoHostPermission `json:"permissions,omitempty"`
+}
+
+type UpdateCoHostPermissionsRequest struct {
+	Permissions *CoHostPermission `json:"permissions" binding:"required"`
 }
 
 // ===============================
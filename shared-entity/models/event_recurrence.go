@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ===============================
+// Recurring Events
+// ===============================
+
+// RecurrenceFrequency is the RRULE FREQ of a recurring event
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+	RecurrenceYearly  RecurrenceFrequency = "YEARLY"
+)
+
+// RecurrenceRule is a subset of an iCalendar RRULE. Occurrences repeat every
+// Interval periods from the event's start date, at the same time of day and
+// with the same duration. ByDay (MO..SU) picks weekdays for weekly rules.
+// Count and Until are mutually exclusive; with neither the series never ends.
+// TimeZone (IANA, default UTC) is where weekdays and days of the month are
+// reckoned, so a series keeps its local time across DST changes.
+type RecurrenceRule struct {
+	Frequency RecurrenceFrequency `bson:"freq" json:"freq"`
+	Interval  int                 `bson:"interval,omitempty" json:"interval,omitempty"`
+	ByDay     []string            `bson:"by_day,omitempty" json:"by_day,omitempty"`
+	Count     int                 `bson:"count,omitempty" json:"count,omitempty"`
+	Until     *time.Time          `bson:"until,omitempty" json:"until,omitempty"`
+	TimeZone  string              `bson:"time_zone,omitempty" json:"time_zone,omitempty"`
+}
+
+// EventOccurrenceOverride changes or cancels one occurrence of a recurring
+// event. OccurrenceStart is the start the rule generates for the occurrence
+// and identifies it even after StartDate moves it.
+type EventOccurrenceOverride struct {
+	OccurrenceStart time.Time  `bson:"occurrence_start" json:"occurrence_start"`
+	Cancelled       bool       `bson:"cancelled,omitempty" json:"cancelled,omitempty"`
+	Title           string     `bson:"title,omitempty" json:"title,omitempty"`
+	Description     string     `bson:"description,omitempty" json:"description,omitempty"`
+	Location        string     `bson:"location,omitempty" json:"location,omitempty"`
+	StartDate       *time.Time `bson:"start_date,omitempty" json:"start_date,omitempty"`
+	EndDate         *time.Time `bson:"end_date,omitempty" json:"end_date,omitempty"`
+}
+
+// OccurrenceRSVP overrides a user's series-wide RSVP for one occurrence
+type OccurrenceRSVP struct {
+	UserID          primitive.ObjectID `bson:"user_id" json:"user_id"`
+	OccurrenceStart time.Time          `bson:"occurrence_start" json:"occurrence_start"`
+	Status          RSVPStatus         `bson:"status" json:"status"`
+	Timestamp       time.Time          `bson:"timestamp" json:"timestamp"`
+}
+
+// EventEditScope selects which occurrences of a recurring event an edit applies to
+type EventEditScope string
+
+const (
+	EventEditScopeAll  EventEditScope = "all"
+	EventEditScopeThis EventEditScope = "this"
+)
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ===============================
+// Event Reminders
+// ===============================
+
+// EventReminderKind says how long before an occurrence a reminder goes out
+type EventReminderKind string
+
+const (
+	EventReminderDayBefore  EventReminderKind = "24h"
+	EventReminderHourBefore EventReminderKind = "1h"
+)
+
+// Before returns how long before the occurrence the reminder is due
+func (k EventReminderKind) Before() time.Duration {
+	switch k {
+	case EventReminderDayBefore:
+		return 24 * time.Hour
+	case EventReminderHourBefore:
+		return time.Hour
+	}
+	return 0
+}
+
+type EventReminderStatus string
+
+const (
+	EventReminderPending EventReminderStatus = "pending"
+	EventReminderSent    EventReminderStatus = "sent"
+	// EventReminderSkipped marks reminders for occurrences that were
+	// cancelled, moved or had already started when the reminder came due
+	EventReminderSkipped EventReminderStatus = "skipped"
+)
+
+// EventReminder is a durable job to remind an occurrence's attendees. One job
+// covers everyone going; recipients are resolved when it is sent.
+type EventReminder struct {
+	ID              primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	EventID         primitive.ObjectID  `bson:"event_id" json:"event_id"`
+	OccurrenceStart time.Time           `bson:"occurrence_start" json:"occurrence_start"`
+	Kind            EventReminderKind   `bson:"kind" json:"kind"`
+	DueAt           time.Time           `bson:"due_at" json:"due_at"`
+	Status          EventReminderStatus `bson:"status" json:"status"`
+	Attempts        int                 `bson:"attempts" json:"attempts"`
+	LockedUntil     *time.Time          `bson:"locked_until,omitempty" json:"-"`
+	CompletedAt     *time.Time          `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+	CreatedAt       time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// EventReminderSettings is a user's reminder preference for one event
+type EventReminderSettings struct {
+	EventID string `json:"event_id"`
+	Enabled bool   `json:"enabled"`
+}
+
+type UpdateEventRemindersRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
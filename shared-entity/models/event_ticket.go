@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ===============================
+// Event Ticketing
+// ===============================
+
+// TicketTierType distinguishes free tiers from paid ones. Payments are not
+// processed yet; a paid tier only records its price.
+type TicketTierType string
+
+const (
+	TicketTierFree TicketTierType = "free"
+	TicketTierPaid TicketTierType = "paid"
+)
+
+// TicketTier is a kind of ticket an event offers. Every tier draws on the
+// event's capacity.
+type TicketTier struct {
+	ID       string         `bson:"id" json:"id"`
+	Name     string         `bson:"name" json:"name"`
+	Type     TicketTierType `bson:"type" json:"type"`
+	Price    int64          `bson:"price,omitempty" json:"price,omitempty"` // In minor units, e.g. cents
+	Currency string         `bson:"currency,omitempty" json:"currency,omitempty"`
+}
+
+type EventTicketStatus string
+
+const (
+	TicketStatusConfirmed  EventTicketStatus = "confirmed"
+	TicketStatusWaitlisted EventTicketStatus = "waitlisted"
+	TicketStatusCancelled  EventTicketStatus = "cancelled"
+)
+
+// EventTicket is a user's seat at an event, or their place on its waitlist.
+// A user holds at most one ticket per event; QueuedAt orders the waitlist.
+type EventTicket struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	EventID   primitive.ObjectID `bson:"event_id" json:"event_id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	TierID    string             `bson:"tier_id,omitempty" json:"tier_id,omitempty"`
+	Status    EventTicketStatus  `bson:"status" json:"status"`
+	QueuedAt  time.Time          `bson:"queued_at" json:"queued_at"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type TicketTierRequest struct {
+	Name     string         `json:"name" binding:"required"`
+	Type     TicketTierType `json:"type" binding:"required,oneof=free paid"`
+	Price    int64          `json:"price,omitempty"`
+	Currency string         `json:"currency,omitempty"`
+}
+
+type EventTicketResponse struct {
+	ID               string            `json:"id"`
+	EventID          string            `json:"event_id"`
+	Tier             *TicketTier       `json:"tier,omitempty"`
+	Status           EventTicketStatus `json:"status"`
+	WaitlistPosition int64             `json:"waitlist_position,omitempty"` // 1-based, set while waitlisted
+	QueuedAt         time.Time         `json:"queued_at"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// FeatureFlag gates a feature behind a gradual rollout. A disabled flag is off
+// for everyone; an enabled one is on for the allowed users, off for the denied
+// ones, and on for RolloutPercent percent of everyone else, picked by a hash
+// of the user ID so each user keeps the same answer as the rollout grows.
+type FeatureFlag struct {
+	Key            string    `bson:"_id" json:"key"`
+	Description    string    `bson:"description,omitempty" json:"description,omitempty"`
+	Enabled        bool      `bson:"enabled" json:"enabled"`
+	RolloutPercent int       `bson:"rollout_percent" json:"rollout_percent"` // 0-100
+	AllowUsers     []string  `bson:"allow_users,omitempty" json:"allow_users,omitempty"`
+	DenyUsers      []string  `bson:"deny_users,omitempty" json:"deny_users,omitempty"`
+	UpdatedBy      string    `bson:"updated_by,omitempty" json:"updated_by,omitempty"`
+	CreatedAt      time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// FeatureFlagRequest creates or replaces a feature flag
+type FeatureFlagRequest struct {
+	Description    string   `json:"description"`
+	Enabled        bool     `json:"enabled"`
+	RolloutPercent int      `json:"rollout_percent" binding:"min=0,max=100"`
+	AllowUsers     []string `json:"allow_users"`
+	DenyUsers      []string `json:"deny_users"`
+}
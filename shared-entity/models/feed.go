@@ -12,11 +12,33 @@ const (
 	PostStatusActive   PostStatus = "active"
 	PostStatusPending  PostStatus = "pending"
 	PostStatusDeclined PostStatus = "declined"
+	PostStatusDeleted  PostStatus = "deleted" // In the trash until purged or restored
 )
 
+// CommentSort orders the comments of a post
+type CommentSort string
+
+const (
+	CommentSortOldest   CommentSort = "oldest" // Default
+	CommentSortNewest   CommentSort = "newest"
+	CommentSortRelevant CommentSort = "relevant" // Most relevant to the viewer first
+)
+
+// Valid reports whether s is a supported sort
+func (s CommentSort) Valid() bool {
+	switch s {
+	case CommentSortOldest, CommentSortNewest, CommentSortRelevant:
+		return true
+	}
+	return false
+}
+
 type MediaItem struct {
 	URL  string `bson:"url" json:"url"`
 	Type string `bson:"type" json:"type"` // "image", "video"
+	// Where the viewer left off a video, filled per response from their
+	// watch history
+	ResumePositionMs int64 `bson:"-" json:"resume_position_ms,omitempty"`
 }
 
 // Post represents a single post in the feed
@@ -37,18 +59,73 @@ type Post struct {
 	MentionedUsers         []PostAuthor           `bson:"mentioned_users,omitempty" json:"mentioned_users,omitempty"`
 	SpecificReactionCounts map[ReactionType]int64 `json:"specific_reaction_counts,omitempty"`
 	Hashtags               []string               `bson:"hashtags,omitempty,sparse" json:"hashtags,omitempty"`
-	TotalReactions         int64                  `bson:"total_reactions" json:"total_reactions"` // Denormalized count
-	TotalComments          int64                  `bson:"total_comments" json:"total_comments"`   // Denormalized count
+	TotalReactions         int64                  `bson:"total_reactions" json:"total_reactions"`                   // Denormalized count
+	TotalComments          int64                  `bson:"total_comments" json:"total_comments"`                     // Denormalized count
+	TotalShares            int64                  `bson:"total_shares" json:"total_shares"`                         // Denormalized count
+	SharedFrom             *PostShare             `bson:"shared_from,omitempty" json:"shared_from,omitempty"`       // Set on reshares
+	PollOptions            []PollOption           `bson:"poll_options,omitempty" json:"poll_options,omitempty"`     // Set on poll posts
+	LinkPreview            *LinkPreview           `bson:"link_preview,omitempty" json:"link_preview,omitempty"`     // Card of the first link in the content
+	DeletedAt              *time.Time             `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`         // When the post was moved to the trash
+	StatusBeforeDelete     PostStatus             `bson:"status_before_delete,omitempty" json:"-"`                  // Restored when the post leaves the trash
+	PinnedAt               *time.Time             `bson:"pinned_at,omitempty" json:"pinned_at,omitempty"`           // Set while pinned to the top of its profile or community feed
+	RemovalReason          string                 `bson:"removal_reason,omitempty" json:"removal_reason,omitempty"` // Why community admins removed the post
+	LockedAt               *time.Time             `bson:"locked_at,omitempty" json:"locked_at,omitempty"`           // Set while community admins closed the post to new comments
+	LockReason             string                 `bson:"lock_reason,omitempty" json:"lock_reason,omitempty"`
 	CreatedAt              time.Time              `bson:"created_at" json:"created_at"`
 	UpdatedAt              time.Time              `bson:"updated_at" json:"updated_at"`
 }
 
+// PostDeltaOp is the kind of change a PostDelta reports
+type PostDeltaOp string
+
+const (
+	PostDeltaCreated PostDeltaOp = "created"
+	PostDeltaUpdated PostDeltaOp = "updated"
+	PostDeltaDeleted PostDeltaOp = "deleted" // Removed outright or moved to the trash
+)
+
+// PostDelta is one change to a post as seen on the posts change stream,
+// whichever service made it
+type PostDelta struct {
+	PostID        primitive.ObjectID `json:"post_id"`
+	Op            PostDeltaOp        `json:"op"`
+	UpdatedFields []string           `json:"updated_fields,omitempty"` // Set or removed by an update
+	Post          *Post              `json:"post,omitempty"`           // The post as it now stands; absent once deleted
+	At            time.Time          `json:"at"`
+}
+
+// PollOption is one answer of a poll post with its denormalized vote count
+type PollOption struct {
+	ID        primitive.ObjectID `bson:"id" json:"id"`
+	Text      string             `bson:"text" json:"text"`
+	VoteCount int64              `bson:"vote_count" json:"vote_count"`
+}
+
+// PollVote records the option a user picked on a poll post. A user has at most
+// one vote per poll and may move it to another option.
+type PollVote struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	PostID    primitive.ObjectID `bson:"post_id" json:"post_id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	OptionID  primitive.ObjectID `bson:"option_id" json:"option_id"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// PollVoteUpdatedEvent carries a poll's current results after a vote
+type PollVoteUpdatedEvent struct {
+	PostID     primitive.ObjectID `json:"post_id"`
+	Options    []PollOption       `json:"options"`
+	TotalVotes int64              `json:"total_votes"`
+}
+
 // PostAuthor represents the simplified user information for a post's author
 type PostAuthor struct {
 	ID       string `bson:"id" json:"id"`
 	Username string `json:"username"`
 	Avatar   string `json:"avatar,omitempty"`
 	FullName string `json:"full_name,omitempty"`
+	Verified bool   `bson:"verified,omitempty" json:"verified,omitempty"`
 }
 
 // Comment represents a comment on a post
@@ -63,8 +140,9 @@ type Comment struct {
 	MediaURL       string                 `bson:"media_url,omitempty" json:"media_url,omitempty"`
 	Replies        []Reply                `bson:"replies,omitempty" json:"replies"` // Populated full Reply objects, not stored in DB
 	Reactions      []Reaction             `bson:"reactions,omitempty" json:"reactions,omitempty"`
-	ReactionCounts map[ReactionType]int64 `json:"reaction_counts,omitempty"`
-	Mentions       []primitive.ObjectID   `bson:"mentions,omitempty" json:"mentions,omitempty"` // User IDs mentioned in the comment
+	ReactionCounts map[ReactionType]int64 `bson:"reaction_counts,omitempty" json:"reaction_counts,omitempty"`
+	ReplyCount     int64                  `bson:"reply_count,omitempty" json:"reply_count,omitempty"` // Replies at any depth
+	Mentions       []primitive.ObjectID   `bson:"mentions,omitempty" json:"mentions,omitempty"`       // User IDs mentioned in the comment
 	CreatedAt      time.Time              `bson:"created_at" json:"created_at"`
 	UpdatedAt      time.Time              `bson:"updated_at" json:"updated_at"`
 }
@@ -80,7 +158,9 @@ type Reply struct {
 	Content        string                 `bson:"content" json:"content"`
 	MediaType      string                 `bson:"media_type,omitempty" json:"media_type,omitempty"`
 	MediaURL       string                 `bson:"media_url,omitempty" json:"media_url,omitempty"`
-	ReactionCounts map[ReactionType]int64 `json:"reaction_counts,omitempty"` // User IDs mentioned in the reply
+	ReactionCounts map[ReactionType]int64 `bson:"reaction_counts,omitempty" json:"reaction_counts,omitempty"`
+	Depth          int                    `bson:"depth,omitempty" json:"depth,omitempty"`             // 1 for a reply to the comment, one more per reply in between
+	ReplyCount     int64                  `bson:"reply_count,omitempty" json:"reply_count,omitempty"` // Direct replies to this one
 	CreatedAt      time.Time              `bson:"created_at" json:"created_at"`
 	UpdatedAt      time.Time              `bson:"updated_at" json:"updated_at"`
 }
@@ -97,6 +177,24 @@ const (
 	ReactionAngry ReactionType = "ANGRY"
 )
 
+// Valid reports whether t is one of the reactions above
+func (t ReactionType) Valid() bool {
+	switch t {
+	case ReactionLike, ReactionLove, ReactionHaha, ReactionWow, ReactionSad, ReactionAngry:
+		return true
+	}
+	return false
+}
+
+// ReactionAction is what reacting did to the user's one reaction on a target
+type ReactionAction string
+
+const (
+	ReactionAdded   ReactionAction = "added"
+	ReactionChanged ReactionAction = "changed" // The user switched to another type
+	ReactionRemoved ReactionAction = "removed" // The user sent the type they had, toggling it off
+)
+
 // Reaction represents a reaction to a post, comment, or reply
 type Reaction struct {
 	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
@@ -118,6 +216,12 @@ type CreatePostRequest struct {
 	CustomAudience []primitive.ObjectID `json:"custom_audience,omitempty" form:"custom_audience"`
 	Mentions       []primitive.ObjectID `json:"mentions,omitempty" form:"mentions"`
 	Hashtags       []string             `json:"hashtags,omitempty" form:"hashtags"`
+	PollOptions    []string             `json:"poll_options,omitempty" form:"poll_options"` // Turns the post into a poll
+}
+
+// VotePollRequest picks an option of a poll post
+type VotePollRequest struct {
+	OptionID primitive.ObjectID `json:"option_id" binding:"required"`
 }
 
 type UpdatePostRequest struct {
@@ -165,9 +269,17 @@ type CreateReactionRequest struct {
 	Type       ReactionType       `json:"type" binding:"required"`
 }
 
+// ReactionResponse reports what a reaction request did. Reaction is the
+// user's reaction afterwards, or the one removed.
+type ReactionResponse struct {
+	Action   ReactionAction `json:"action"`
+	Reaction *Reaction      `json:"reaction"`
+}
+
 type FeedResponse struct {
-	Posts []Post `json:"posts"`
-	Total int64  `json:"total"`
-	Page  int64  `json:"page"`
-	Limit int64  `json:"limit"`
+	Posts      []Post `json:"posts"`
+	Total      int64  `json:"total"`
+	Page       int64  `json:"page"`
+	Limit      int64  `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"` // Pass as cursor to fetch the next page; empty on the last page
 }
@@ -0,0 +1,15 @@
+package models
+
+// FollowCounts is how many users follow a user and how many they follow
+type FollowCounts struct {
+	Followers int64 `json:"followers"`
+	Following int64 `json:"following"`
+}
+
+// FollowSuggestion is a "Who to follow" entry: a user followed by people the
+// viewer follows, with how many of them do
+type FollowSuggestion struct {
+	User             UserShortResponse `json:"user"`
+	FollowedByMutual int               `json:"followed_by_mutual"`
+	Followers        int64             `json:"followers"`
+}
@@ -13,12 +13,14 @@ type ActivityType string
 const (
 	ActivityGroupCreated  ActivityType = "CREATED"
 	ActivityMemberAdded   ActivityType = "MEMBER_ADDED"
+	ActivityMemberJoined  ActivityType = "MEMBER_JOINED"
 	ActivityMemberLeft    ActivityType = "MEMBER_LEFT"
 	ActivityMemberRemoved ActivityType = "MEMBER_REMOVED"
 	ActivityNameChanged   ActivityType = "NAME_CHANGED"
 	ActivityAvatarChanged ActivityType = "AVATAR_CHANGED"
 	ActivityAdminAdded    ActivityType = "ADMIN_ADDED"
 	ActivityAdminRemoved  ActivityType = "ADMIN_REMOVED"
+	ActivityOwnerChanged  ActivityType = "OWNER_CHANGED"
 )
 
 // GroupActivity represents a system activity/event in a group
@@ -44,6 +46,8 @@ func (a *GroupActivity) FormatActivity() string {
 			return a.ActorName + " added " + a.TargetName
 		}
 		return a.ActorName + " added a member"
+	case ActivityMemberJoined:
+		return a.ActorName + " joined via invite link"
 	case ActivityMemberLeft:
 		return a.ActorName + " left the group"
 	case ActivityMemberRemoved:
@@ -65,6 +69,11 @@ func (a *GroupActivity) FormatActivity() string {
 			return a.ActorName + " removed " + a.TargetName + " as admin"
 		}
 		return a.ActorName + " removed an admin"
+	case ActivityOwnerChanged:
+		if a.TargetName != "" {
+			return a.ActorName + " made " + a.TargetName + " the group owner"
+		}
+		return a.ActorName + " transferred group ownership"
 	default:
 		return "Group activity"
 	}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxGroupMembers is the most members any group can have. Groups may set a
+// lower limit of their own.
+const MaxGroupMembers = 1024
+
+// MemberLimit returns how many members the group may have
+func (s GroupSettings) MemberLimit() int {
+	if s.MaxMembers <= 0 || s.MaxMembers > MaxGroupMembers {
+		return MaxGroupMembers
+	}
+	return s.MaxMembers
+}
+
+// GroupInvite is a shareable link that lets people join a group chat without
+// being added by a member or waiting for approval
+type GroupInvite struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	GroupID   primitive.ObjectID `bson:"group_id" json:"group_id"`
+	Code      string             `bson:"code" json:"code"`
+	CreatedBy primitive.ObjectID `bson:"created_by" json:"created_by"`
+	MaxUses   int                `bson:"max_uses" json:"max_uses"` // 0 means unlimited
+	Uses      int                `bson:"uses" json:"uses"`
+	ExpiresAt *time.Time         `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// CreateGroupInviteRequest configures a new invite link. Both limits are
+// optional; an invite without them never runs out.
+type CreateGroupInviteRequest struct {
+	ExpiresInHours int `json:"expires_in_hours" binding:"omitempty,min=1,max=8760"`
+	MaxUses        int `json:"max_uses" binding:"omitempty,min=1,max=100000"`
+}
+
+// GroupInvitePreview is what anyone opening an invite link sees before
+// joining, signed in or not
+type GroupInvitePreview struct {
+	GroupID       primitive.ObjectID `json:"group_id"`
+	Name          string             `json:"name"`
+	Avatar        string             `json:"avatar,omitempty"`
+	MemberCount   int                `json:"member_count"`
+	Full          bool               `json:"full"`
+	ExpiresAt     *time.Time         `json:"expires_at,omitempty"`
+	RemainingUses *int               `json:"remaining_uses,omitempty"`
+}
@@ -0,0 +1,150 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// GroupRole is a member's standing in a group. Every group has exactly one
+// owner, who is also an admin; everyone else in Members is an admin or a
+// plain member.
+type GroupRole string
+
+const (
+	GroupRoleOwner  GroupRole = "owner"
+	GroupRoleAdmin  GroupRole = "admin"
+	GroupRoleMember GroupRole = "member"
+)
+
+var groupRoleRank = map[GroupRole]int{
+	GroupRoleMember: 1,
+	GroupRoleAdmin:  2,
+	GroupRoleOwner:  3,
+}
+
+// Valid reports whether r is one of the known roles
+func (r GroupRole) Valid() bool {
+	_, ok := groupRoleRank[r]
+	return ok
+}
+
+// AtLeast reports whether r ranks at or above min. Non-members rank below
+// every role.
+func (r GroupRole) AtLeast(min GroupRole) bool {
+	rank, ok := groupRoleRank[r]
+	return ok && rank >= groupRoleRank[min]
+}
+
+// GroupAction is something the permission matrix controls
+type GroupAction string
+
+const (
+	GroupActionAddMembers     GroupAction = "add_members"
+	GroupActionEditInfo       GroupAction = "edit_info"
+	GroupActionDeleteMessages GroupAction = "delete_messages"
+	GroupActionPinMessages    GroupAction = "pin_messages"
+)
+
+// GroupPermissions holds the lowest role allowed to perform each action.
+// Empty entries fall back to DefaultGroupPermissions, so groups created
+// before permissions existed keep their admin-only behaviour.
+type GroupPermissions struct {
+	AddMembers     GroupRole `bson:"add_members,omitempty" json:"add_members"`
+	EditInfo       GroupRole `bson:"edit_info,omitempty" json:"edit_info"`
+	DeleteMessages GroupRole `bson:"delete_messages,omitempty" json:"delete_messages"`
+	PinMessages    GroupRole `bson:"pin_messages,omitempty" json:"pin_messages"`
+}
+
+// DefaultGroupPermissions restricts every action to admins
+func DefaultGroupPermissions() GroupPermissions {
+	return GroupPermissions{
+		AddMembers:     GroupRoleAdmin,
+		EditInfo:       GroupRoleAdmin,
+		DeleteMessages: GroupRoleAdmin,
+		PinMessages:    GroupRoleAdmin,
+	}
+}
+
+// Required returns the lowest role allowed to perform action
+func (p GroupPermissions) Required(action GroupAction) GroupRole {
+	var role GroupRole
+	switch action {
+	case GroupActionAddMembers:
+		role = p.AddMembers
+	case GroupActionEditInfo:
+		role = p.EditInfo
+	case GroupActionDeleteMessages:
+		role = p.DeleteMessages
+	case GroupActionPinMessages:
+		role = p.PinMessages
+	default:
+		return GroupRoleOwner
+	}
+	if role == "" {
+		return GroupRoleAdmin
+	}
+	return role
+}
+
+// Resolved returns p with empty entries filled in from the defaults
+func (p GroupPermissions) Resolved() GroupPermissions {
+	return GroupPermissions{
+		AddMembers:     p.Required(GroupActionAddMembers),
+		EditInfo:       p.Required(GroupActionEditInfo),
+		DeleteMessages: p.Required(GroupActionDeleteMessages),
+		PinMessages:    p.Required(GroupActionPinMessages),
+	}
+}
+
+// Valid reports whether every entry is empty or a known role
+func (p GroupPermissions) Valid() bool {
+	for _, role := range []GroupRole{p.AddMembers, p.EditInfo, p.DeleteMessages, p.PinMessages} {
+		if role != "" && !role.Valid() {
+			return false
+		}
+	}
+	return true
+}
+
+// GroupRoleChangedEvent is broadcast to group members when someone's role changes
+type GroupRoleChangedEvent struct {
+	Group        GroupResponse      `json:"group"`
+	UserID       primitive.ObjectID `json:"user_id"`
+	Role         GroupRole          `json:"role"`
+	PreviousRole GroupRole          `json:"previous_role"`
+	ChangedBy    primitive.ObjectID `json:"changed_by"`
+}
+
+// Owner returns the group's owner, which is the creator until ownership is
+// transferred
+func (g *Group) Owner() primitive.ObjectID {
+	if !g.OwnerID.IsZero() {
+		return g.OwnerID
+	}
+	return g.CreatorID
+}
+
+// RoleOf returns userID's role in the group, or "" when they are not a member
+func (g *Group) RoleOf(userID primitive.ObjectID) GroupRole {
+	if !containsObjectID(g.Members, userID) {
+		return ""
+	}
+	if g.Owner() == userID {
+		return GroupRoleOwner
+	}
+	if containsObjectID(g.Admins, userID) {
+		return GroupRoleAdmin
+	}
+	return GroupRoleMember
+}
+
+// Can reports whether userID's role allows action under the group's permissions
+func (g *Group) Can(userID primitive.ObjectID, action GroupAction) bool {
+	return g.RoleOf(userID).AtLeast(g.Settings.Permissions.Required(action))
+}
+
+func containsObjectID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// InsightMetric is something counted toward a user's insights
+type InsightMetric string
+
+const (
+	InsightProfileView    InsightMetric = "profile_view"
+	InsightPostImpression InsightMetric = "post_impression" // A post shown in someone else's feed
+	InsightPostReaction   InsightMetric = "post_reaction"
+	InsightPostComment    InsightMetric = "post_comment"
+)
+
+// InsightBucket counts one metric of a user over one UTC day
+type InsightBucket struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	OwnerID primitive.ObjectID `bson:"owner_id" json:"-"`
+	Metric  InsightMetric      `bson:"metric" json:"metric"`
+	Day     time.Time          `bson:"day" json:"day"`
+	Count   int64              `bson:"count" json:"count"`
+}
+
+// InsightTotals sums the metrics of a user over a period
+type InsightTotals struct {
+	ProfileViews    int64 `json:"profile_views"`
+	PostImpressions int64 `json:"post_impressions"`
+	PostReach       int64 `json:"post_reach"` // Distinct signed-in users who saw a post; estimated
+	Reactions       int64 `json:"reactions"`
+	Comments        int64 `json:"comments"`
+}
+
+// InsightDay is one day of a user's insights; reach is only summed over
+// the whole window
+type InsightDay struct {
+	Date            string `json:"date"` // 2006-01-02, UTC
+	ProfileViews    int64  `json:"profile_views"`
+	PostImpressions int64  `json:"post_impressions"`
+	Reactions       int64  `json:"reactions"`
+	Comments        int64  `json:"comments"`
+}
+
+// InsightsResponse is a creator's insights over the last Days days
+type InsightsResponse struct {
+	Days   int           `json:"days"`
+	From   time.Time     `json:"from"`
+	Totals InsightTotals `json:"totals"`
+	Daily  []InsightDay  `json:"daily"`
+}
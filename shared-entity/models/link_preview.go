@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// LinkPreview is the Open Graph card of the first link in a message or post.
+// It is filled in by the unfurl worker shortly after the content is saved.
+type LinkPreview struct {
+	URL         string    `bson:"url" json:"url"`
+	Title       string    `bson:"title,omitempty" json:"title,omitempty"`
+	Description string    `bson:"description,omitempty" json:"description,omitempty"`
+	ImageURL    string    `bson:"image_url,omitempty" json:"image_url,omitempty"`
+	SiteName    string    `bson:"site_name,omitempty" json:"site_name,omitempty"`
+	FetchedAt   time.Time `bson:"fetched_at" json:"fetched_at"`
+}
+
+// MessageLinkPreviewEvent is sent to a conversation once the preview of a
+// message's link is ready
+type MessageLinkPreviewEvent struct {
+	ConversationID string       `json:"conversation_id"`
+	MessageID      string       `json:"message_id"`
+	LinkPreview    *LinkPreview `json:"link_preview"`
+}
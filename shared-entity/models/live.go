@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LiveStatus tracks a live session from creation to its archive. A session
+// is created idle, goes live when the media server accepts the broadcaster's
+// stream and ends when the stream stops or the broadcaster ends it.
+type LiveStatus string
+
+const (
+	LiveStatusIdle  LiveStatus = "idle"
+	LiveStatusLive  LiveStatus = "live"
+	LiveStatusEnded LiveStatus = "ended"
+)
+
+// LiveSession is one broadcast. The stream key authorises RTMP ingest, so
+// only the broadcaster ever sees it.
+type LiveSession struct {
+	ID          primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID      primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	Author      PostAuthor          `bson:"author,omitempty" json:"author"`
+	Title       string              `bson:"title" json:"title"`
+	Privacy     PrivacySettingType  `bson:"privacy" json:"privacy"`
+	Status      LiveStatus          `bson:"status" json:"status"`
+	StreamKey   string              `bson:"stream_key" json:"stream_key,omitempty"`
+	IngestURL   string              `bson:"-" json:"ingest_url,omitempty"`
+	PlaybackURL string              `bson:"playback_url" json:"playback_url"` // HLS playlist of the media server
+	ViewerCount int64               `bson:"viewer_count" json:"viewer_count"`
+	PeakViewers int64               `bson:"peak_viewers" json:"peak_viewers"`
+	StartedAt   *time.Time          `bson:"started_at,omitempty" json:"started_at,omitempty"`
+	EndedAt     *time.Time          `bson:"ended_at,omitempty" json:"ended_at,omitempty"`
+	ReelID      *primitive.ObjectID `bson:"reel_id,omitempty" json:"reel_id,omitempty"` // The archived broadcast
+	CreatedAt   time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+// LiveChatMessage is a comment viewers post while a session is live
+type LiveChatMessage struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SessionID primitive.ObjectID `bson:"session_id" json:"session_id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Author    PostAuthor         `bson:"author" json:"author"`
+	Content   string             `bson:"content" json:"content"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+type CreateLiveSessionRequest struct {
+	Title   string             `json:"title" binding:"required"`
+	Privacy PrivacySettingType `json:"privacy"`
+}
+
+type SendLiveChatRequest struct {
+	Content string `json:"content" binding:"required"`
+}
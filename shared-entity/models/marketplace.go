@@ -1,6 +1,7 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -13,8 +14,48 @@ const (
 	ProductStatusSold      ProductStatus = "sold"
 	ProductStatusPending   ProductStatus = "pending"
 	ProductStatusArchived  ProductStatus = "archived"
+	// ProductStatusReserved holds a listing for one buyer until the sale completes
+	ProductStatusReserved ProductStatus = "reserved"
 )
 
+// productTransitions lists the statuses each lifecycle status is reached from.
+// Moving back to available relists the item.
+var productTransitions = map[ProductStatus][]ProductStatus{
+	ProductStatusReserved:  {ProductStatusAvailable, ProductStatusPending},
+	ProductStatusSold:      {ProductStatusAvailable, ProductStatusPending, ProductStatusReserved},
+	ProductStatusAvailable: {ProductStatusPending, ProductStatusReserved, ProductStatusSold, ProductStatusArchived},
+}
+
+// TransitionSources returns the statuses a listing can move to next from
+func TransitionSources(next ProductStatus) []ProductStatus {
+	return productTransitions[next]
+}
+
+// CanTransitionTo reports whether a listing in status s can move to next
+func (s ProductStatus) CanTransitionTo(next ProductStatus) bool {
+	return slices.Contains(productTransitions[next], s)
+}
+
+// ProductCondition describes the state an item is sold in
+type ProductCondition string
+
+const (
+	ProductConditionNew      ProductCondition = "new"
+	ProductConditionLikeNew  ProductCondition = "like_new"
+	ProductConditionGood     ProductCondition = "good"
+	ProductConditionFair     ProductCondition = "fair"
+	ProductConditionForParts ProductCondition = "for_parts"
+)
+
+// IsValid reports whether c is a known condition
+func (c ProductCondition) IsValid() bool {
+	switch c {
+	case ProductConditionNew, ProductConditionLikeNew, ProductConditionGood, ProductConditionFair, ProductConditionForParts:
+		return true
+	}
+	return false
+}
+
 // ProductLocation stores detailed location information
 type ProductLocation struct {
 	City      string  `bson:"city" json:"city"`
@@ -54,9 +95,14 @@ type Product struct {
 	Location       ProductLocation      `bson:"location" json:"location"`                           // Structured location
 	Coordinates    []float64            `bson:"coordinates,omitempty" json:"coordinates,omitempty"` // [Longitude, Latitude] for GeoJSON index
 	Status         ProductStatus        `bson:"status" json:"status"`
+	Condition      ProductCondition     `bson:"condition,omitempty" json:"condition,omitempty"`
 	SavedBy        []primitive.ObjectID `bson:"saved_by,omitempty" json:"saved_by,omitempty"`
 	Tags           []string             `bson:"tags,omitempty" json:"tags,omitempty"`
 	Views          int64                `bson:"views" json:"views"`
+	BuyerID        *primitive.ObjectID  `bson:"buyer_id,omitempty" json:"buyer_id,omitempty"` // Who the listing is reserved for or was sold to
+	ReservedAt     *time.Time           `bson:"reserved_at,omitempty" json:"reserved_at,omitempty"`
+	SoldAt         *time.Time           `bson:"sold_at,omitempty" json:"sold_at,omitempty"`
+	RelistedAt     *time.Time           `bson:"relisted_at,omitempty" json:"relisted_at,omitempty"`
 	CreatedAt      time.Time            `bson:"created_at" json:"created_at"`
 	UpdatedAt      time.Time            `bson:"updated_at" json:"updated_at"`
 }
@@ -80,9 +126,12 @@ type ProductResponse struct {
 	Images      []string           `bson:"images" json:"images"`
 	Location    ProductLocation    `bson:"location" json:"location"`
 	Status      ProductStatus      `bson:"status" json:"status"`
+	Condition   ProductCondition   `bson:"condition,omitempty" json:"condition,omitempty"`
 	Tags        []string           `bson:"tags,omitempty" json:"tags,omitempty"`
 	Views       int64              `bson:"views" json:"views"`
 	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	SoldAt      *time.Time         `bson:"sold_at,omitempty" json:"sold_at,omitempty"`
+	DistanceKm  *float64           `bson:"distance_km,omitempty" json:"distance_km,omitempty"` // Set when searching around a point
 	Seller      UserShortResponse  `bson:"seller" json:"seller"`
 	Category    Category           `bson:"category" json:"category"`
 	IsSaved     bool               `bson:"is_saved" json:"is_saved"` // If the requesting user has saved this
@@ -97,6 +146,10 @@ type CreateProductRequest struct {
 	Images      []string `json:"images" binding:"required,min=1"` // At least one image required
 	Location    string   `json:"location" binding:"required"`
 	Tags        []string `json:"tags,omitempty"`
+	// Optional; listings without coordinates never match a radius search
+	Condition ProductCondition `json:"condition,omitempty"`
+	Latitude  *float64         `json:"latitude,omitempty"`
+	Longitude *float64         `json:"longitude,omitempty"`
 }
 
 type UpdateProductRequest struct {
@@ -111,13 +164,89 @@ type UpdateProductRequest struct {
 	Tags        []string       `json:"tags,omitempty"`
 }
 
+// ReserveProductRequest holds a listing for a buyer
+type ReserveProductRequest struct {
+	BuyerID string `json:"buyer_id" binding:"required"`
+}
+
+// MarkProductSoldRequest records a sale. BuyerID defaults to the buyer
+// holding the reservation; sales made off the platform may leave it empty.
+type MarkProductSoldRequest struct {
+	BuyerID string `json:"buyer_id,omitempty"`
+}
+
 type ProductFilter struct {
-	Query      string   `form:"q"`
-	CategoryID string   `form:"category_id"`
-	MinPrice   *float64 `form:"min_price"`
-	MaxPrice   *float64 `form:"max_price"`
-	Location   string   `form:"location"` // Basic filtering
-	SortBy     string   `form:"sort_by"`  // "price_asc", "price_desc", "newest"
-	Page       int64    `form:"page,default=1"`
-	Limit      int64    `form:"limit,default=20"`
+	Query      string              `form:"q" bson:"q,omitempty" json:"q,omitempty"`
+	CategoryID string              `form:"category_id" bson:"category_id,omitempty" json:"category_id,omitempty"`
+	Conditions []ProductCondition  `form:"condition" bson:"conditions,omitempty" json:"conditions,omitempty"`
+	MinPrice   *float64            `form:"min_price" bson:"min_price,omitempty" json:"min_price,omitempty"`
+	MaxPrice   *float64            `form:"max_price" bson:"max_price,omitempty" json:"max_price,omitempty"`
+	Location   string              `form:"location" bson:"location,omitempty" json:"location,omitempty"` // Basic filtering
+	Latitude   *float64            `form:"lat" bson:"lat,omitempty" json:"lat,omitempty"`
+	Longitude  *float64            `form:"lng" bson:"lng,omitempty" json:"lng,omitempty"`
+	RadiusKm   float64             `form:"radius_km" bson:"radius_km,omitempty" json:"radius_km,omitempty"`
+	SortBy     string              `form:"sort_by" bson:"sort_by,omitempty" json:"sort_by,omitempty"` // "price_asc", "price_desc", "newest", "distance"
+	SavedBy    *primitive.ObjectID `form:"-" bson:"-" json:"-"`                                       // Only listings this user saved, whatever their status
+	SoldBy     *primitive.ObjectID `form:"-" bson:"-" json:"-"`                                       // Only listings this seller sold
+	Page       int64               `form:"page,default=1" bson:"-" json:"-"`
+	Limit      int64               `form:"limit,default=20" bson:"-" json:"-"`
+}
+
+// HasLocation reports whether the filter searches around a point
+func (f ProductFilter) HasLocation() bool {
+	return f.Latitude != nil && f.Longitude != nil
+}
+
+// FacetCount is how many matching listings share one facet value
+type FacetCount struct {
+	Value string `bson:"_id" json:"value"`
+	Label string `bson:"label,omitempty" json:"label,omitempty"`
+	Count int64  `bson:"count" json:"count"`
+}
+
+// PriceRange is the lowest and highest price among matching listings
+type PriceRange struct {
+	Min float64 `bson:"min" json:"min"`
+	Max float64 `bson:"max" json:"max"`
+}
+
+// ProductFacets summarises a search so clients can offer refinements. Each
+// facet ignores its own filter, so selecting a category still lists the others.
+type ProductFacets struct {
+	Categories []FacetCount `bson:"categories" json:"categories"`
+	Conditions []FacetCount `bson:"conditions" json:"conditions"`
+	PriceRange *PriceRange  `bson:"price_range,omitempty" json:"price_range,omitempty"`
+}
+
+// SavedProductSearch is a marketplace search a user saved to run again later
+type SavedProductSearch struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Name      string             `bson:"name" json:"name"`
+	Filter    ProductFilter      `bson:"filter" json:"filter"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+type SaveProductSearchRequest struct {
+	Name   string        `json:"name" binding:"required"`
+	Filter ProductFilter `json:"filter"`
+}
+
+// MarketplaceAlertPreferences are a user's settings for marketplace alerts
+type MarketplaceAlertPreferences struct {
+	UserID         primitive.ObjectID `bson:"user_id" json:"user_id"`
+	PriceDrop      bool               `bson:"price_drop" json:"price_drop"`
+	MinDropPercent float64            `bson:"min_drop_percent" json:"min_drop_percent"` // Smallest drop worth an alert; 0 alerts on any drop
+	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// DefaultMarketplaceAlertPreferences applies to users who never changed
+// their settings: every price drop on a saved listing is announced
+func DefaultMarketplaceAlertPreferences(userID primitive.ObjectID) MarketplaceAlertPreferences {
+	return MarketplaceAlertPreferences{UserID: userID, PriceDrop: true}
+}
+
+type UpdateMarketplaceAlertPreferencesRequest struct {
+	PriceDrop      *bool    `json:"price_drop,omitempty"`
+	MinDropPercent *float64 `json:"min_drop_percent,omitempty"`
 }
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OfferStatus string
+
+const (
+	// OfferStatusPending waits on the party who did not propose the current amount
+	OfferStatusPending  OfferStatus = "pending"
+	OfferStatusAccepted OfferStatus = "accepted"
+	OfferStatusDeclined OfferStatus = "declined"
+	OfferStatusExpired  OfferStatus = "expired"
+)
+
+// OfferAction is what just happened to an offer
+type OfferAction string
+
+const (
+	OfferActionMade      OfferAction = "made"
+	OfferActionCountered OfferAction = "countered"
+	OfferActionAccepted  OfferAction = "accepted"
+	OfferActionDeclined  OfferAction = "declined"
+	OfferActionExpired   OfferAction = "expired"
+)
+
+// OfferRound is one amount proposed during a negotiation
+type OfferRound struct {
+	ProposedBy primitive.ObjectID `bson:"proposed_by" json:"proposed_by"`
+	Amount     float64            `bson:"amount" json:"amount"`
+	Message    string             `bson:"message,omitempty" json:"message,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// Offer is a buyer's negotiation with a seller over a listing. A counter
+// replaces the amount and hands the decision to the other party.
+type Offer struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ProductID      primitive.ObjectID `bson:"product_id" json:"product_id"`
+	BuyerID        primitive.ObjectID `bson:"buyer_id" json:"buyer_id"`
+	SellerID       primitive.ObjectID `bson:"seller_id" json:"seller_id"`
+	ConversationID string             `bson:"conversation_id" json:"conversation_id"` // The marketplace thread between buyer and seller
+	Amount         float64            `bson:"amount" json:"amount"`
+	Currency       string             `bson:"currency" json:"currency"`
+	Status         OfferStatus        `bson:"status" json:"status"`
+	ProposedBy     primitive.ObjectID `bson:"proposed_by" json:"proposed_by"` // Who proposed Amount; the other party responds
+	Rounds         []OfferRound       `bson:"rounds" json:"rounds"`
+	ExpiresAt      time.Time          `bson:"expires_at" json:"expires_at"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// IsParticipant reports whether the user is the offer's buyer or seller
+func (o *Offer) IsParticipant(userID primitive.ObjectID) bool {
+	return userID == o.BuyerID || userID == o.SellerID
+}
+
+// Counterparty returns the other side of the negotiation from userID
+func (o *Offer) Counterparty(userID primitive.ObjectID) primitive.ObjectID {
+	if userID == o.BuyerID {
+		return o.SellerID
+	}
+	return o.BuyerID
+}
+
+type MakeOfferRequest struct {
+	Amount         float64 `json:"amount" binding:"required"`
+	ExpiresInHours int     `json:"expires_in_hours,omitempty"`
+	Message        string  `json:"message,omitempty"`
+}
+
+type CounterOfferRequest struct {
+	Amount         float64 `json:"amount" binding:"required"`
+	ExpiresInHours int     `json:"expires_in_hours,omitempty"`
+	Message        string  `json:"message,omitempty"`
+}
+
+// MessageOffer is the offer card posted in a marketplace conversation. It
+// snapshots the listing so the card still renders after the listing changes.
+type MessageOffer struct {
+	OfferID      primitive.ObjectID `bson:"offer_id" json:"offer_id"`
+	ProductID    primitive.ObjectID `bson:"product_id" json:"product_id"`
+	ProductTitle string             `bson:"product_title" json:"product_title"`
+	ProductImage string             `bson:"product_image,omitempty" json:"product_image,omitempty"`
+	Action       OfferAction        `bson:"action" json:"action"`
+	Amount       float64            `bson:"amount" json:"amount"`
+	Currency     string             `bson:"currency" json:"currency"`
+	Status       OfferStatus        `bson:"status" json:"status"`
+	Message      string             `bson:"message,omitempty" json:"message,omitempty"`
+	ExpiresAt    time.Time          `bson:"expires_at" json:"expires_at"`
+	Round        int                `bson:"round" json:"round"`
+}
+
+// Summary is the plain-text line shown for the card in inboxes and search
+func (m *MessageOffer) Summary() string {
+	amount := fmt.Sprintf("%s %.2f", m.Currency, m.Amount)
+	switch m.Action {
+	case OfferActionMade:
+		return "Offered " + amount + " for " + m.ProductTitle
+	case OfferActionCountered:
+		return "Countered with " + amount + " for " + m.ProductTitle
+	case OfferActionAccepted:
+		return "Accepted the offer of " + amount + " for " + m.ProductTitle
+	case OfferActionDeclined:
+		return "Declined the offer of " + amount + " for " + m.ProductTitle
+	default:
+		return "The offer of " + amount + " for " + m.ProductTitle + " expired"
+	}
+}
+
+// MarketplaceOfferEvent is published whenever an offer changes, so the
+// messaging service can post a card in the buyer and seller's thread
+type MarketplaceOfferEvent struct {
+	Action    OfferAction        `json:"action"`
+	Offer     Offer              `json:"offer"`
+	Product   MessageProduct     `json:"product"`
+	ActorID   primitive.ObjectID `json:"actor_id"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+// Card returns the offer card for the event
+func (e *MarketplaceOfferEvent) Card() *MessageOffer {
+	card := &MessageOffer{
+		OfferID:      e.Offer.ID,
+		ProductID:    e.Offer.ProductID,
+		ProductTitle: e.Product.Title,
+		Action:       e.Action,
+		Amount:       e.Offer.Amount,
+		Currency:     e.Offer.Currency,
+		Status:       e.Offer.Status,
+		ExpiresAt:    e.Offer.ExpiresAt,
+		Round:        len(e.Offer.Rounds),
+	}
+	if len(e.Product.Images) > 0 {
+		card.ProductImage = e.Product.Images[0]
+	}
+	if e.Action == OfferActionMade || e.Action == OfferActionCountered {
+		if n := len(e.Offer.Rounds); n > 0 {
+			card.Message = e.Offer.Rounds[n-1].Message
+		}
+	}
+	return card
+}
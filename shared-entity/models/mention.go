@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// MentionQuery asks for @mention candidates. With a conversation only its
+// participants are suggested; with a community its members rank first.
+type MentionQuery struct {
+	Query          string `form:"q"`
+	ConversationID string `form:"conversation_id"`
+	IsGroup        bool   `form:"is_group"`
+	CommunityID    string `form:"community_id"`
+	Limit          int    `form:"limit"`
+}
+
+// MentionSuggestion is an @mention candidate together with the signals it
+// was ranked on
+type MentionSuggestion struct {
+	User            PostAuthor `json:"user"`
+	IsFriend        bool       `json:"is_friend"`
+	SharedGroups    int        `json:"shared_groups"`
+	LastInteraction *time.Time `json:"last_interaction,omitempty"`
+	InScope         bool       `json:"in_scope"` // Participant of the conversation or member of the community
+	Score           float64    `json:"score"`
+}
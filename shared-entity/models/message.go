@@ -42,10 +42,15 @@ type Message struct {
 	IsEdited         bool                 `bson:"is_edited" json:"is_edited"`                                         // New field for message editing
 	EditedAt         *time.Time           `bson:"edited_at,omitempty" json:"edited_at,omitempty"`                     // New field for message editing
 	Reactions        []MessageReaction    `bson:"reactions,omitempty" json:"reactions,omitempty"`                     // New field for reactions
+	ReactionCounts   map[string]int       `bson:"reaction_counts,omitempty" json:"reaction_counts,omitempty"`         // Reactions per emoji
 	ReplyToMessageID *primitive.ObjectID  `bson:"reply_to_message_id,omitempty" json:"reply_to_message_id,omitempty"` // New field for replies
+	ReplyToID        string               `bson:"reply_to_id,omitempty" json:"reply_to_id,omitempty"`                 // Cassandra UUID of the message replied to
+	Thread           *ThreadSummary       `bson:"-" json:"thread,omitempty"`                                          // Replies to this message, in groups
 	ProductID        *primitive.ObjectID  `bson:"product_id,omitempty" json:"product_id,omitempty"`                   // New field for marketplace inquiries
 	IsMarketplace    bool                 `bson:"is_marketplace" json:"is_marketplace"`                               // Flag for marketplace context
 	Product          *MessageProduct      `bson:"product,omitempty" json:"product,omitempty"`                         // Populated product data
+	Offer            *MessageOffer        `bson:"offer,omitempty" json:"offer,omitempty"`                             // Offer card, for ContentTypeOffer
+	LinkPreview      *LinkPreview         `bson:"link_preview,omitempty" json:"link_preview,omitempty"`               // Card of the first link in the content
 	Mentions         []primitive.ObjectID `bson:"mentions,omitempty" json:"mentions,omitempty"`
 	MentionedUsers   []PostAuthor         `bson:"-" json:"mentioned_users,omitempty"`
 	Sender           *SafeUserResponse    `bson:"sender,omitempty" json:"sender,omitempty"`
@@ -54,6 +59,18 @@ type Message struct {
 	IsEncrypted      bool                 `bson:"is_encrypted" json:"is_encrypted"`                         // E2EE
 	IV               string               `bson:"iv,omitempty" json:"iv,omitempty"`                         // E2EE
 	EncryptedKeys    map[string]string    `bson:"encrypted_keys,omitempty" json:"encrypted_keys,omitempty"` // E2EE
+	IsForwarded      bool                 `bson:"is_forwarded" json:"is_forwarded"`
+	ForwardedFrom    *ForwardedFrom       `bson:"forwarded_from,omitempty" json:"forwarded_from,omitempty"`
+	Request          bool                 `bson:"-" json:"request,omitempty"` // Sent to the receiver's message requests
+}
+
+// ForwardedFrom attributes a forwarded message to the message it copies.
+// Forwarding a forwarded message keeps the original attribution.
+type ForwardedFrom struct {
+	MessageID  string             `bson:"message_id" json:"message_id"`
+	SenderID   primitive.ObjectID `bson:"sender_id" json:"sender_id"`
+	SenderName string             `bson:"sender_name,omitempty" json:"sender_name,omitempty"`
+	SentAt     time.Time          `bson:"sent_at" json:"sent_at"`
 }
 
 type MessageQuery struct {
@@ -67,6 +84,24 @@ type MessageQuery struct {
 	Marketplace    bool   `form:"marketplace"` // If true, only return messages with product_id
 }
 
+// MessageSearchQuery is a full-text search over the conversations a user
+// belongs to. Empty filters match everything.
+type MessageSearchQuery struct {
+	Query          string
+	ConversationID string // Cassandra conversation key, e.g. "dm_a_b" or "group_x"
+	SenderIDs      []string
+	From           *time.Time
+	To             *time.Time
+	Page           int64
+	Limit          int64
+}
+
+// MessageSearchResult is a matching message with highlighted content fragments
+type MessageSearchResult struct {
+	Message
+	Highlights []string `json:"highlights,omitempty"`
+}
+
 type MessageRequest struct {
 	SenderName       string   `bson:"sender_name,omitempty" json:"sender_name,omitempty" form:"sender_name"`
 	ReceiverID       string   `json:"receiver_id,omitempty" form:"receiver_id"`
@@ -83,6 +118,112 @@ type MessageRequest struct {
 	EncryptedKeys    string   `json:"encrypted_keys,omitempty" form:"encrypted_keys"` // JSON string for map
 }
 
+// ForwardMessageRequest copies a message of ConversationID into the direct
+// conversations with ReceiverIDs and into GroupIDs
+type ForwardMessageRequest struct {
+	ConversationID string   `json:"conversation_id" binding:"required"`
+	ReceiverIDs    []string `json:"receiver_ids,omitempty"`
+	GroupIDs       []string `json:"group_ids,omitempty"`
+}
+
+// ForwardMessageResponse lists the copies created and the targets that were
+// refused, e.g. non-friends or groups the sender is not a member of
+type ForwardMessageResponse struct {
+	Messages []Message       `json:"messages"`
+	Failed   []ForwardResult `json:"failed,omitempty"`
+}
+
+type ForwardResult struct {
+	ReceiverID string `json:"receiver_id,omitempty"`
+	GroupID    string `json:"group_id,omitempty"`
+	Error      string `json:"error"`
+}
+
+// MaxPinnedMessages caps how many messages a conversation can have pinned
+const MaxPinnedMessages = 5
+
+// PinnedMessage is a message pinned to the top of a conversation
+type PinnedMessage struct {
+	ConversationID string    `json:"conversation_id"`
+	MessageID      string    `json:"message_id"`
+	PinnedBy       string    `json:"pinned_by"`
+	PinnedAt       time.Time `json:"pinned_at"`
+}
+
+// MessagePinEvent is broadcast to a conversation's participants when its pins
+// change. PinnedMessages is the conversation's full pin list afterwards.
+type MessagePinEvent struct {
+	ConversationID string          `json:"conversation_id"`
+	MessageID      string          `json:"message_id"`
+	ActorID        string          `json:"actor_id"`
+	PinnedMessages []PinnedMessage `json:"pinned_messages"`
+}
+
+// MessageReactionEvent is broadcast to a conversation's participants when a
+// reaction is added to or removed from one of its messages. ReactionCounts
+// is the message's per-emoji tally afterwards.
+type MessageReactionEvent struct {
+	ConversationID string         `json:"conversation_id"`
+	MessageID      string         `json:"message_id"`
+	UserID         string         `json:"user_id"`
+	Emoji          string         `json:"emoji"`
+	Action         string         `json:"action"` // "add" or "remove"
+	ReactionCounts map[string]int `json:"reaction_counts"`
+	Timestamp      time.Time      `json:"timestamp"`
+}
+
+// MessageReactionsResponse is a message's per-emoji reaction tally
+type MessageReactionsResponse struct {
+	MessageID      string         `json:"message_id"`
+	ReactionCounts map[string]int `json:"reaction_counts"`
+}
+
+// MessageEdit is an earlier version of an edited message: Content is how the
+// message read until EditedBy changed it at EditedAt
+type MessageEdit struct {
+	Content  string    `json:"content"`
+	EditedBy string    `json:"edited_by"`
+	EditedAt time.Time `json:"edited_at"`
+}
+
+// MessageEditHistory lists a message's earlier versions, oldest first
+type MessageEditHistory struct {
+	MessageID string        `json:"message_id"`
+	Content   string        `json:"content"` // Current version
+	Edits     []MessageEdit `json:"edits"`
+}
+
+// MaxThreadRepliers caps the recent repliers a thread summary lists
+const MaxThreadRepliers = 3
+
+// ThreadSummary aggregates the replies to a group message. Replies to a
+// reply join the thread of the message it replies to, so threads are one
+// level deep.
+type ThreadSummary struct {
+	ParentID       string     `json:"parent_id"`
+	ReplyCount     int        `json:"reply_count"`
+	LastReplyAt    *time.Time `json:"last_reply_at,omitempty"`
+	LastReplierIDs []string   `json:"last_replier_ids"` // Most recent first
+}
+
+// ThreadMessagesResponse is a page of a thread's replies, oldest first.
+// Parent is included on the first page only.
+type ThreadMessagesResponse struct {
+	Parent  *Message      `json:"parent,omitempty"`
+	Thread  ThreadSummary `json:"thread"`
+	Replies []Message     `json:"replies"`
+	HasMore bool          `json:"has_more"`
+}
+
+// ThreadUpdatedEvent is broadcast to a group's members when a reply joins
+// or leaves one of its threads
+type ThreadUpdatedEvent struct {
+	ConversationID string        `json:"conversation_id"`
+	ReplyID        string        `json:"reply_id"`
+	Action         string        `json:"action"` // "add" or "remove"
+	Thread         ThreadSummary `json:"thread"`
+}
+
 type MessageResponse struct {
 	Messages []Message `json:"messages"`
 	Total    int64     `json:"total"`
@@ -146,6 +287,7 @@ const (
 	ContentTypeTextFile  = "text_file"
 	ContentTypeMultiple  = "multiple"
 	ContentTypeDeleted   = "deleted"
+	ContentTypeOffer     = "offer"   // Marketplace offer card posted by the system
 	ContentTypeProduct   = "product" // New content type for marketplace inquiries
 )
 
@@ -180,4 +322,56 @@ type ConversationSummary struct {
 	LastMessageTimestamp   *time.Time         `bson:"last_message_timestamp" json:"last_message_timestamp,omitempty"`
 	LastMessageIsEncrypted bool               `bson:"last_message_is_encrypted" json:"last_message_is_encrypted"`
 	UnreadCount            int64              `bson:"unread_count" json:"unread_count"`
+	// MentionCount counts the unread messages that @mention the user; it is
+	// reset separately from UnreadCount
+	MentionCount int64 `bson:"mention_count" json:"mention_count"`
+	// The user's own flags for the conversation; pinned conversations are
+	// listed first
+	Pinned   bool `bson:"pinned" json:"pinned"`
+	Archived bool `bson:"archived" json:"archived"`
+	Muted    bool `bson:"muted" json:"muted"`
+	// Customizations shared by the participants; Nickname is the one given
+	// to the other user of a direct conversation
+	Nickname      string `bson:"nickname,omitempty" json:"nickname,omitempty"`
+	QuickReaction string `bson:"quick_reaction,omitempty" json:"quick_reaction,omitempty"`
+	Theme         string `bson:"theme,omitempty" json:"theme,omitempty"`
+	// Request marks a conversation held in the user's message requests
+	Request bool `bson:"request,omitempty" json:"request,omitempty"`
+}
+
+// ConversationRequest is a direct conversation in a user's message requests,
+// started by a sender flagged for spam. It stays out of the user's inbox
+// until they accept it.
+type ConversationRequest struct {
+	ConversationID string    `json:"conversation_id"`
+	SenderID       string    `json:"sender_id"`
+	Accepted       bool      `json:"accepted"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ConversationFlags are a user's own settings for a conversation in their
+// inbox. They are sent to the user's devices as INBOX_UPDATED events.
+type ConversationFlags struct {
+	ConversationID string    `json:"conversation_id"`
+	Pinned         bool      `json:"pinned"`
+	Archived       bool      `json:"archived"`
+	Muted          bool      `json:"muted"` // Also mutes the conversation's notifications
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// UpdateConversationFlagsRequest sets or unsets conversation flags; omitted
+// flags are left as they are
+type UpdateConversationFlagsRequest struct {
+	Pinned   *bool `json:"pinned"`
+	Archived *bool `json:"archived"`
+	Muted    *bool `json:"muted"`
+}
+
+// InboxSyncResponse carries the inbox rows that changed since a client's sync
+// token. When FullSync is set the token was missing or too old, Conversations
+// holds the whole inbox and the client should replace its copy.
+type InboxSyncResponse struct {
+	Conversations []ConversationSummary `json:"conversations"`
+	SyncToken     string                `json:"sync_token"`
+	FullSync      bool                  `json:"full_sync"`
 }
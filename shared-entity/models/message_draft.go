@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// MaxDraftLength caps the characters of a saved draft
+const MaxDraftLength = 10000
+
+// MessageDraft is an unsent message a user is composing in a conversation.
+// Drafts follow the user across devices; DeviceID names the device that saved
+// it so that device can ignore the echo of its own change. An empty Content
+// means the draft was cleared.
+type MessageDraft struct {
+	ConversationID string    `json:"conversation_id"`
+	Content        string    `json:"content"`
+	ReplyToID      string    `json:"reply_to_id,omitempty"`
+	DeviceID       string    `json:"device_id,omitempty"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// SaveDraftRequest saves the draft of a conversation; saving empty content
+// clears it
+type SaveDraftRequest struct {
+	Content   string `json:"content" binding:"max=10000"`
+	ReplyToID string `json:"reply_to_id"`
+	DeviceID  string `json:"device_id"`
+}
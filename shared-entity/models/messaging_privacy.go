@@ -0,0 +1,32 @@
+package models
+
+// MessagingPrivacy is what a user shares while messaging: read receipts
+// (MESSAGE_READ_UPDATE, CONVERSATION_SEEN_UPDATE and seen_by) and typing
+// indicators. Sharing is reciprocal, so a user who hides either one sees no
+// one else's either.
+type MessagingPrivacy struct {
+	ReadReceipts     bool
+	TypingIndicators bool
+}
+
+// DefaultMessagingPrivacy shares everything, for users who have not chosen
+func DefaultMessagingPrivacy() MessagingPrivacy {
+	return MessagingPrivacy{ReadReceipts: true, TypingIndicators: true}
+}
+
+// MessagingPrivacy returns what the settings share
+func (s *UserPrivacySettings) MessagingPrivacy() MessagingPrivacy {
+	return MessagingPrivacy{ReadReceipts: !s.ReadReceiptsOff, TypingIndicators: !s.TypingIndicatorsOff}
+}
+
+// ReadReceiptsShared reports whether a reader's receipts reach a viewer:
+// both must share them
+func ReadReceiptsShared(reader, viewer MessagingPrivacy) bool {
+	return reader.ReadReceipts && viewer.ReadReceipts
+}
+
+// TypingShared reports whether a typist's typing indicator reaches a viewer:
+// both must share them
+func TypingShared(typist, viewer MessagingPrivacy) bool {
+	return typist.TypingIndicators && viewer.TypingIndicators
+}
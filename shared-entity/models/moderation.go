@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// ModerationVerdict is what the media moderation worker decided about an upload
+type ModerationVerdict string
+
+const (
+	ModerationApproved ModerationVerdict = "approved"
+	ModerationHeld     ModerationVerdict = "held"     // Hidden until a moderator reviews it
+	ModerationRejected ModerationVerdict = "rejected" // Removed for good
+)
+
+// HiddenModerationVerdicts lists the verdicts that keep content away from
+// everyone but its author
+var HiddenModerationVerdicts = []ModerationVerdict{ModerationHeld, ModerationRejected}
+
+// Flagged reports whether the verdict takes the media out of circulation
+func (v ModerationVerdict) Flagged() bool {
+	return v == ModerationHeld || v == ModerationRejected
+}
+
+// MediaUploadedEvent announces a stored upload to the moderation worker
+type MediaUploadedEvent struct {
+	Key        string    `json:"key"`
+	URL        string    `json:"url"`
+	MimeType   string    `json:"mime_type"`
+	Size       int64     `json:"size"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+// MediaModerationEvent carries a verdict to the services whose content
+// references the media by URL
+type MediaModerationEvent struct {
+	Key     string            `json:"key"`
+	URL     string            `json:"url"`
+	Verdict ModerationVerdict `json:"verdict"`
+	// Labels holds the classifier's score per label, e.g. "nsfw" or "violence"
+	Labels      map[string]float64 `json:"labels,omitempty"`
+	Quarantined bool               `json:"quarantined"`
+	ModeratedAt time.Time          `json:"moderated_at"`
+}
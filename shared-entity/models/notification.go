@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -14,6 +15,7 @@ const (
 	NotificationTypeLike                NotificationType = "LIKE"
 	NotificationTypeComment             NotificationType = "COMMENT"
 	NotificationTypeReply               NotificationType = "REPLY"
+	NotificationTypeShare               NotificationType = "SHARE"
 	NotificationTypeFriendRequest       NotificationType = "FRIEND_REQUEST"
 	NotificationTypeFriendAccept        NotificationType = "FRIEND_ACCEPT"
 	NotificationTypeBirthday            NotificationType = "BIRTHDAY"
@@ -21,8 +23,40 @@ const (
 	NotificationTypeEventReminder       NotificationType = "EVENT_REMINDER"
 	NotificationTypeEventInviteAccepted NotificationType = "EVENT_INVITE_ACCEPTED"
 	NotificationTypeEventInviteDeclined NotificationType = "EVENT_INVITE_DECLINED"
+	NotificationTypeEventWaitlistSeat   NotificationType = "EVENT_WAITLIST_SEAT"
+	NotificationTypePriceDrop           NotificationType = "MARKETPLACE_PRICE_DROP"
+	NotificationTypeListingSold         NotificationType = "MARKETPLACE_LISTING_SOLD"
+	NotificationTypeDataExportReady     NotificationType = "DATA_EXPORT_READY"
+	NotificationTypeNewFollower         NotificationType = "NEW_FOLLOWER"
+	NotificationTypePostApproved        NotificationType = "COMMUNITY_POST_APPROVED"
+	NotificationTypePostDeclined        NotificationType = "COMMUNITY_POST_DECLINED"
+	// NotificationTypeDoNotDisturbDigest sums up the notifications held back
+	// while Do Not Disturb was on. It is only pushed, never stored.
+	NotificationTypeDoNotDisturbDigest NotificationType = "DND_DIGEST"
 )
 
+// ValidNotificationTypes lists the types users can mute
+var ValidNotificationTypes = map[NotificationType]bool{
+	NotificationTypeMention:             true,
+	NotificationTypeLike:                true,
+	NotificationTypeComment:             true,
+	NotificationTypeReply:               true,
+	NotificationTypeShare:               true,
+	NotificationTypeFriendRequest:       true,
+	NotificationTypeFriendAccept:        true,
+	NotificationTypeBirthday:            true,
+	NotificationTypeEventInvite:         true,
+	NotificationTypeEventReminder:       true,
+	NotificationTypeEventInviteAccepted: true,
+	NotificationTypeEventInviteDeclined: true,
+	NotificationTypeEventWaitlistSeat:   true,
+	NotificationTypePriceDrop:           true,
+	NotificationTypeListingSold:         true,
+	NotificationTypeNewFollower:         true,
+	NotificationTypePostApproved:        true,
+	NotificationTypePostDeclined:        true,
+}
+
 // Notification represents a single notification for a user
 type Notification struct {
 	ID          primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
@@ -35,6 +69,12 @@ type Notification struct {
 	Data        map[string]interface{} `bson:"data,omitempty" json:"data,omitempty"` // Structured data for the notification
 	Read        bool                   `bson:"read" json:"read"`
 	CreatedAt   time.Time              `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time              `bson:"updated_at,omitempty" json:"updated_at"` // Last merge into a digest; CreatedAt otherwise
+
+	// Digest fields, set on digestible types. SenderID is the latest actor.
+	GroupKey   string               `bson:"group_key,omitempty" json:"-"`
+	ActorIDs   []primitive.ObjectID `bson:"actor_ids,omitempty" json:"actor_ids,omitempty"`
+	ActorCount int                  `bson:"actor_count,omitempty" json:"actor_count,omitempty"`
 }
 
 // DTOs for Notifications
@@ -58,3 +98,239 @@ type NotificationListResponse struct {
 	Page          int64          `json:"page"`
 	Limit         int64          `json:"limit"`
 }
+
+// DigestibleNotificationTypes are merged into a single unread notification per
+// target ("Alice and 12 others reacted to your post") instead of one per actor
+var DigestibleNotificationTypes = map[NotificationType]bool{
+	NotificationTypeLike:    true,
+	NotificationTypeComment: true,
+	NotificationTypeReply:   true,
+	NotificationTypeShare:   true,
+}
+
+// DigestGroupKey identifies the digest a notification of type t about target
+// belongs to
+func DigestGroupKey(t NotificationType, targetID primitive.ObjectID) string {
+	return string(t) + ":" + targetID.Hex()
+}
+
+// NotificationDataConversationID is the Data key naming the conversation
+// ("dm_..." or "group_...") a notification came from, so it can be muted
+const NotificationDataConversationID = "conversation_id"
+
+// NotificationPreferences are a user's muting rules. Notifications from muted
+// conversations or of muted types are dropped; during quiet hours they are
+// still stored but not pushed in real time, and during Do Not Disturb they are
+// also held back for a digest.
+type NotificationPreferences struct {
+	UserID             primitive.ObjectID  `bson:"_id" json:"user_id"`
+	MutedConversations []MutedConversation `bson:"muted_conversations" json:"muted_conversations"`
+	MutedTypes         []NotificationType  `bson:"muted_types" json:"muted_types"`
+	QuietHours         *QuietHours         `bson:"quiet_hours,omitempty" json:"quiet_hours,omitempty"`
+	DoNotDisturb       *DoNotDisturb       `bson:"do_not_disturb,omitempty" json:"do_not_disturb,omitempty"`
+	UpdatedAt          time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+// MutedConversation silences a conversation until Until, or for good when
+// Until is nil
+type MutedConversation struct {
+	ConversationID string     `bson:"conversation_id" json:"conversation_id"`
+	Until          *time.Time `bson:"until,omitempty" json:"until,omitempty"`
+}
+
+// QuietHours is a daily window in the user's time zone. A Start later than
+// End wraps past midnight.
+type QuietHours struct {
+	Start    string `bson:"start" json:"start"`                             // "HH:MM"
+	End      string `bson:"end" json:"end"`                                 // "HH:MM"
+	TimeZone string `bson:"time_zone,omitempty" json:"time_zone,omitempty"` // IANA name; empty means UTC
+}
+
+// Focus modes a user can label Do Not Disturb with, so clients can show why
+// they are unavailable
+const (
+	FocusWork     = "work"
+	FocusPersonal = "personal"
+	FocusSleep    = "sleep"
+	FocusDriving  = "driving"
+)
+
+// DoNotDisturb holds back real-time notifications while it is active: from
+// being turned on until Until (or until turned off when Until is nil), and
+// during each window of Schedule. Notifications from AllowedUserIDs still get
+// through. Held notifications are delivered as one digest once it ends.
+type DoNotDisturb struct {
+	Enabled        bool                 `bson:"enabled" json:"enabled"`
+	Until          *time.Time           `bson:"until,omitempty" json:"until,omitempty"`
+	Focus          string               `bson:"focus,omitempty" json:"focus,omitempty"` // One of the Focus* modes; empty for none
+	Schedule       []DoNotDisturbWindow `bson:"schedule,omitempty" json:"schedule,omitempty"`
+	AllowedUserIDs []primitive.ObjectID `bson:"allowed_user_ids,omitempty" json:"allowed_user_ids,omitempty"`
+}
+
+// DoNotDisturbWindow is a recurring Do Not Disturb window. A window wrapping
+// past midnight belongs to the day it starts on; no Days means every day.
+type DoNotDisturbWindow struct {
+	QuietHours `bson:",inline"`
+	Days       []time.Weekday `bson:"days,omitempty" json:"days,omitempty"` // 0 is Sunday
+}
+
+// NotificationDigest is delivered when Do Not Disturb ends, with the unread
+// notifications held back while it was on, newest first
+type NotificationDigest struct {
+	Count         int            `json:"count"`
+	Notifications []Notification `json:"notifications"`
+}
+
+// UpdateNotificationPreferencesRequest replaces a user's muted types and
+// quiet hours. Omitting quiet_hours turns them off.
+type UpdateNotificationPreferencesRequest struct {
+	MutedTypes []NotificationType `json:"muted_types"`
+	QuietHours *QuietHours        `json:"quiet_hours,omitempty"`
+}
+
+// MuteConversationRequest mutes a conversation until Until, or for good when
+// it is omitted
+type MuteConversationRequest struct {
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// Validate checks the window's times and time zone
+func (q QuietHours) Validate() error {
+	if _, err := time.Parse("15:04", q.Start); err != nil {
+		return fmt.Errorf("start %q is not HH:MM", q.Start)
+	}
+	if _, err := time.Parse("15:04", q.End); err != nil {
+		return fmt.Errorf("end %q is not HH:MM", q.End)
+	}
+	if _, err := time.LoadLocation(q.TimeZone); err != nil {
+		return fmt.Errorf("unknown time zone %q", q.TimeZone)
+	}
+	return nil
+}
+
+// Contains reports whether t falls inside the window. Invalid windows contain
+// nothing.
+func (q QuietHours) Contains(t time.Time) bool {
+	inside, _ := q.locate(t)
+	return inside
+}
+
+// locate reports whether t falls inside the window and, if so, the local day
+// the window started on
+func (q QuietHours) locate(t time.Time) (bool, time.Weekday) {
+	start, err := time.Parse("15:04", q.Start)
+	if err != nil {
+		return false, 0
+	}
+	end, err := time.Parse("15:04", q.End)
+	if err != nil {
+		return false, 0
+	}
+	loc, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		return false, 0
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	if from <= to {
+		return minute >= from && minute < to, local.Weekday()
+	}
+	if minute >= from {
+		return true, local.Weekday()
+	}
+	return minute < to, local.AddDate(0, 0, -1).Weekday()
+}
+
+// Contains reports whether t falls inside the window on one of its days
+func (w DoNotDisturbWindow) Contains(t time.Time) bool {
+	inside, day := w.locate(t)
+	if !inside || len(w.Days) == 0 {
+		return inside
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the focus mode and every scheduled window
+func (d DoNotDisturb) Validate() error {
+	switch d.Focus {
+	case "", FocusWork, FocusPersonal, FocusSleep, FocusDriving:
+	default:
+		return fmt.Errorf("unknown focus mode %q", d.Focus)
+	}
+	for i, w := range d.Schedule {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("schedule %d: %w", i, err)
+		}
+		for _, day := range w.Days {
+			if day < time.Sunday || day > time.Saturday {
+				return fmt.Errorf("schedule %d: day %d is not 0-6", i, day)
+			}
+		}
+	}
+	return nil
+}
+
+// ActiveAt reports whether Do Not Disturb is on at now, by hand or by schedule
+func (d DoNotDisturb) ActiveAt(now time.Time) bool {
+	if d.Enabled && (d.Until == nil || now.Before(*d.Until)) {
+		return true
+	}
+	for _, w := range d.Schedule {
+		if w.Contains(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether notifications from senderID get through Do Not Disturb
+func (d DoNotDisturb) Allows(senderID primitive.ObjectID) bool {
+	for _, id := range d.AllowedUserIDs {
+		if id == senderID {
+			return true
+		}
+	}
+	return false
+}
+
+// Mutes reports whether n comes from a muted conversation or is of a muted type
+func (p *NotificationPreferences) Mutes(n *Notification, now time.Time) bool {
+	for _, t := range p.MutedTypes {
+		if t == n.Type {
+			return true
+		}
+	}
+	conversationID, _ := n.Data[NotificationDataConversationID].(string)
+	if conversationID == "" {
+		return false
+	}
+	for _, m := range p.MutedConversations {
+		if m.ConversationID == conversationID && (m.Until == nil || now.Before(*m.Until)) {
+			return true
+		}
+	}
+	return false
+}
+
+// InQuietHours reports whether now falls inside the user's quiet hours
+func (p *NotificationPreferences) InQuietHours(now time.Time) bool {
+	return p.QuietHours != nil && p.QuietHours.Contains(now)
+}
+
+// InDoNotDisturb reports whether Do Not Disturb is on at now
+func (p *NotificationPreferences) InDoNotDisturb(now time.Time) bool {
+	return p.DoNotDisturb != nil && p.DoNotDisturb.ActiveAt(now)
+}
+
+// HoldsBack reports whether Do Not Disturb holds n back for the digest
+func (p *NotificationPreferences) HoldsBack(n *Notification, now time.Time) bool {
+	return p.InDoNotDisturb(now) && !p.DoNotDisturb.Allows(n.SenderID)
+}
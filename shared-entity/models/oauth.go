@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OAuthProvider names an identity provider users can sign in with
+type OAuthProvider string
+
+const (
+	OAuthProviderGoogle OAuthProvider = "google"
+	OAuthProviderApple  OAuthProvider = "apple"
+)
+
+// OAuthIdentity links an account at a provider, identified by the provider's
+// stable subject, to a user. Email is what the provider reported when it was
+// linked; Apple may relay it through a private address.
+type OAuthIdentity struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Provider   OAuthProvider      `bson:"provider" json:"provider"`
+	Subject    string             `bson:"subject" json:"-"`
+	Email      string             `bson:"email,omitempty" json:"email,omitempty"`
+	LinkedAt   time.Time          `bson:"linked_at" json:"linked_at"`
+	LastUsedAt time.Time          `bson:"last_used_at" json:"last_used_at"`
+}
+
+// OAuthLoginRequest carries what the client got back from the provider:
+// either an ID token, from a native or one-tap sign-in, or an authorization
+// code with the redirect URI it was issued for, which user-service exchanges.
+// Nonce is checked against the ID token's when the client set one. Apple
+// only shares the user's name with the app, on their first sign-in, so the
+// client passes it along in FullName.
+type OAuthLoginRequest struct {
+	IDToken     string `json:"id_token"`
+	Code        string `json:"code"`
+	RedirectURI string `json:"redirect_uri"`
+	Nonce       string `json:"nonce"`
+	FullName    string `json:"full_name" binding:"max=100"`
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A post's CustomAudience is read according to its privacy: for CUSTOM it is
+// the friends the post is shared with, for FRIENDS_EXCEPT the friends it is
+// hidden from. Other privacy settings ignore it.
+
+// VisibleTo reports whether viewerID is in the audience of the post. isFriend
+// is only consulted for FRIENDS and FRIENDS_EXCEPT posts, so callers can skip
+// the friendship lookup for the rest (see NeedsFriendship).
+func (p *Post) VisibleTo(viewerID primitive.ObjectID, isFriend bool) bool {
+	if viewerID == p.UserID {
+		return true
+	}
+	switch p.Privacy {
+	case PrivacySettingPublic:
+		return true
+	case PrivacySettingFriends:
+		return isFriend
+	case PrivacySettingFriendsExcept:
+		return isFriend && !p.inCustomAudience(viewerID)
+	case PrivacySettingCustom:
+		return p.inCustomAudience(viewerID)
+	default:
+		return false
+	}
+}
+
+// NeedsFriendship reports whether VisibleTo depends on the viewer being a
+// friend of the author.
+func (p *Post) NeedsFriendship() bool {
+	return p.Privacy == PrivacySettingFriends || p.Privacy == PrivacySettingFriendsExcept
+}
+
+// AudienceRecipients returns the author and those of the author's friends
+// that can see the post, for fanning it out to timelines and sockets.
+func (p *Post) AudienceRecipients(friendIDs []primitive.ObjectID) []primitive.ObjectID {
+	recipients := []primitive.ObjectID{p.UserID}
+	switch p.Privacy {
+	case PrivacySettingCustom:
+		for _, id := range p.CustomAudience {
+			if id != p.UserID {
+				recipients = append(recipients, id)
+			}
+		}
+	case PrivacySettingPublic, PrivacySettingFriends, PrivacySettingFriendsExcept:
+		for _, id := range friendIDs {
+			if p.VisibleTo(id, true) {
+				recipients = append(recipients, id)
+			}
+		}
+	}
+	return recipients
+}
+
+func (p *Post) inCustomAudience(userID primitive.ObjectID) bool {
+	for _, id := range p.CustomAudience {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// PostAudienceFilter matches the posts viewerID can see, given the viewer's
+// friends. It is the query counterpart of VisibleTo; a nil viewer only sees
+// public posts.
+func PostAudienceFilter(viewerID primitive.ObjectID, friendIDs []primitive.ObjectID) bson.M {
+	clauses := []bson.M{
+		{"privacy": PrivacySettingPublic},
+	}
+	if !viewerID.IsZero() {
+		clauses = append(clauses,
+			bson.M{"user_id": viewerID},
+			bson.M{"privacy": PrivacySettingCustom, "custom_audience": viewerID},
+		)
+	}
+	// Avoid empty $in arrays for viewers without friends
+	if !viewerID.IsZero() && len(friendIDs) > 0 {
+		clauses = append(clauses,
+			bson.M{"privacy": PrivacySettingFriends, "user_id": bson.M{"$in": friendIDs}},
+			bson.M{
+				"privacy":         PrivacySettingFriendsExcept,
+				"user_id":         bson.M{"$in": friendIDs},
+				"custom_audience": bson.M{"$ne": viewerID},
+			},
+		)
+	}
+	return bson.M{"$or": clauses}
+}
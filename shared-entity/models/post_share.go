@@ -0,0 +1,111 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrPostNotShareable     = errors.New("post cannot be shared")
+	ErrShareExceedsAudience = errors.New("share audience exceeds the original post's audience")
+)
+
+// PostShare is the provenance of a reshared post. A reshare of a reshare keeps
+// pointing at the first post of the chain, so the original author is always
+// credited.
+type PostShare struct {
+	PostID           primitive.ObjectID   `bson:"post_id" json:"post_id"`                       // Post that was reshared
+	OriginalPostID   primitive.ObjectID   `bson:"original_post_id" json:"original_post_id"`     // First post of the share chain
+	OriginalAuthorID primitive.ObjectID   `bson:"original_author_id" json:"original_author_id"` // Author of the original post
+	Chain            []primitive.ObjectID `bson:"chain" json:"chain"`                           // Posts from the original to PostID
+
+	// Resolved by the feed pipeline, not stored. Unavailable originals (deleted,
+	// hidden, or made private) are reported without their content.
+	Available         bool        `bson:"available,omitempty" json:"available"`
+	OriginalAuthor    *PostAuthor `bson:"original_author,omitempty" json:"original_author,omitempty"`
+	OriginalContent   string      `bson:"original_content,omitempty" json:"original_content,omitempty"`
+	OriginalMedia     []MediaItem `bson:"original_media,omitempty" json:"original_media,omitempty"`
+	OriginalCreatedAt *time.Time  `bson:"original_created_at,omitempty" json:"original_created_at,omitempty"`
+}
+
+type SharePostRequest struct {
+	Content        string               `json:"content,omitempty"`
+	Privacy        PrivacySettingType   `json:"privacy" binding:"required"`
+	CustomAudience []primitive.ObjectID `json:"custom_audience,omitempty"`
+}
+
+// NewPostShare builds the provenance of a reshare of post
+func NewPostShare(post *Post) *PostShare {
+	if post.SharedFrom == nil {
+		return &PostShare{
+			PostID:           post.ID,
+			OriginalPostID:   post.ID,
+			OriginalAuthorID: post.UserID,
+			Chain:            []primitive.ObjectID{post.ID},
+		}
+	}
+	chain := make([]primitive.ObjectID, 0, len(post.SharedFrom.Chain)+1)
+	chain = append(chain, post.SharedFrom.Chain...)
+	return &PostShare{
+		PostID:           post.ID,
+		OriginalPostID:   post.SharedFrom.OriginalPostID,
+		OriginalAuthorID: post.SharedFrom.OriginalAuthorID,
+		Chain:            append(chain, post.ID),
+	}
+}
+
+// ShareCounterIDs returns the posts whose share counter a reshare counts
+// towards: the original and, for reshares of reshares, the direct parent.
+func (s *PostShare) ShareCounterIDs() []primitive.ObjectID {
+	if s.PostID == s.OriginalPostID {
+		return []primitive.ObjectID{s.OriginalPostID}
+	}
+	return []primitive.ObjectID{s.OriginalPostID, s.PostID}
+}
+
+// CheckShareAudience reports whether a share with the given privacy stays
+// within the audience of post. Public posts can be shared with anyone and
+// private ones with no one else. Friends-only posts can only be shared with
+// a custom audience of the author's friends, so friendIDs are only needed for
+// FRIENDS and FRIENDS_EXCEPT posts.
+func CheckShareAudience(post *Post, privacy PrivacySettingType, audience []primitive.ObjectID, friendIDs []primitive.ObjectID) error {
+	if post.Status != "" && post.Status != PostStatusActive {
+		return ErrPostNotShareable
+	}
+	if post.Privacy == PrivacySettingPublic || privacy == PrivacySettingOnlyMe {
+		return nil
+	}
+	if privacy != PrivacySettingCustom {
+		return ErrShareExceedsAudience
+	}
+
+	allowed := map[primitive.ObjectID]bool{post.UserID: true}
+	switch post.Privacy {
+	case PrivacySettingFriends:
+		for _, id := range friendIDs {
+			allowed[id] = true
+		}
+	case PrivacySettingFriendsExcept:
+		for _, id := range friendIDs {
+			allowed[id] = true
+		}
+		for _, id := range post.CustomAudience {
+			delete(allowed, id)
+		}
+	case PrivacySettingCustom:
+		for _, id := range post.CustomAudience {
+			allowed[id] = true
+		}
+	default:
+		return ErrPostNotShareable
+	}
+
+	for _, id := range audience {
+		if !allowed[id] {
+			return ErrShareExceedsAudience
+		}
+	}
+	return nil
+}
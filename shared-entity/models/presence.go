@@ -0,0 +1,94 @@
+package models
+
+import "time"
+
+const (
+	PresenceOnline  = "online"
+	PresenceOffline = "offline"
+	// PresenceHidden is shown in place of the status of a user who does not
+	// share their presence with the viewer
+	PresenceHidden = "hidden"
+)
+
+// LastSeenBucket says roughly how long ago a user was last active
+type LastSeenBucket string
+
+const (
+	LastSeenJustNow LastSeenBucket = "just_now" // Under a minute
+	LastSeenMinutes LastSeenBucket = "minutes"  // Under an hour, in steps of 5 minutes
+	LastSeenHours   LastSeenBucket = "hours"    // Under a day, in steps of an hour
+	LastSeenDays    LastSeenBucket = "days"     // Under a week, in steps of a day
+	LastSeenLongAgo LastSeenBucket = "long_ago" // A week or more, with no time given
+)
+
+// Presence is a user's status as shown to someone else. LastSeen is coarsened
+// to its bucket, so viewers can say "active 15 minutes ago" but not follow a
+// user's activity to the second.
+type Presence struct {
+	Status         string         `json:"status"`
+	LastSeen       int64          `json:"last_seen,omitempty"`
+	LastSeenBucket LastSeenBucket `json:"last_seen_bucket,omitempty"`
+}
+
+// NewPresence shows a status and last activity as seen at now
+func NewPresence(status string, lastSeen, now time.Time) Presence {
+	p := Presence{Status: status}
+	if lastSeen.IsZero() {
+		return p
+	}
+	coarse, bucket := CoarseLastSeen(lastSeen, now)
+	p.LastSeenBucket = bucket
+	if !coarse.IsZero() {
+		p.LastSeen = coarse.Unix()
+	}
+	return p
+}
+
+// HiddenPresence is shown to viewers the user does not share presence with
+func HiddenPresence() Presence {
+	return Presence{Status: PresenceHidden}
+}
+
+// CoarseLastSeen rounds the time since lastSeen down to the step of its
+// bucket and returns the matching time. Beyond a week it returns the zero
+// time.
+func CoarseLastSeen(lastSeen, now time.Time) (time.Time, LastSeenBucket) {
+	age := now.Sub(lastSeen)
+	if age < 0 {
+		age = 0
+	}
+	switch {
+	case age < time.Minute:
+		return now, LastSeenJustNow
+	case age < time.Hour:
+		return now.Add(-age.Truncate(5 * time.Minute)), LastSeenMinutes
+	case age < 24*time.Hour:
+		return now.Add(-age.Truncate(time.Hour)), LastSeenHours
+	case age < 7*24*time.Hour:
+		return now.Add(-age.Truncate(24 * time.Hour)), LastSeenDays
+	}
+	return time.Time{}, LastSeenLongAgo
+}
+
+// IsValidPresenceVisibility reports whether v may be chosen as a user's
+// presence visibility
+func IsValidPresenceVisibility(v PrivacySettingType) bool {
+	switch v {
+	case PrivacySettingEveryone, PrivacySettingFriends, PrivacySettingNoOne:
+		return true
+	}
+	return false
+}
+
+// PresenceVisibleTo reports whether a user with the given presence visibility
+// shares it with a viewer, who is their friend if isFriend. Users always see
+// their own presence; callers handle that case.
+func PresenceVisibleTo(visibility PrivacySettingType, isFriend bool) bool {
+	switch visibility {
+	case PrivacySettingNoOne:
+		return false
+	case PrivacySettingFriends:
+		return isFriend
+	}
+	return true
+}
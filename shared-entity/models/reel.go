@@ -6,6 +6,25 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// ReelProcessingStatus tracks a reel through the transcoding pipeline
+type ReelProcessingStatus string
+
+const (
+	ReelProcessingPending    ReelProcessingStatus = "pending"    // Waiting for a transcoder
+	ReelProcessingProcessing ReelProcessingStatus = "processing" // Being transcoded
+	ReelProcessingReady      ReelProcessingStatus = "ready"      // HLS playback available
+	ReelProcessingFailed     ReelProcessingStatus = "failed"     // Only the raw upload plays
+)
+
+// ReelRendition is one HLS variant of a transcoded reel
+type ReelRendition struct {
+	Name        string `bson:"name" json:"name"` // e.g. "720p"
+	Width       int    `bson:"width" json:"width"`
+	Height      int    `bson:"height" json:"height"`
+	Bitrate     int    `bson:"bitrate" json:"bitrate"` // Peak bits per second, video and audio
+	PlaylistURL string `bson:"playlist_url" json:"playlist_url"`
+}
+
 type Reel struct {
 	ID             primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
 	UserID         primitive.ObjectID   `bson:"user_id" json:"user_id"`
@@ -20,10 +39,24 @@ type Reel struct {
 	Views          int64                `bson:"views" json:"views"`
 	Likes          int64                `bson:"likes" json:"likes"`
 	Comments       int64                `bson:"comments" json:"comments"`
+	Shares         int64                `bson:"shares,omitempty" json:"shares,omitempty"`
 	// MsgComments removed to fix 16MB limit. Comments are now in separate collection.
-	ReactionCounts map[ReactionType]int64 `json:"reaction_counts,omitempty"`
-	CreatedAt      time.Time              `bson:"created_at" json:"created_at"`
-	UpdatedAt      time.Time              `bson:"updated_at" json:"updated_at"`
+	ReactionCounts map[ReactionType]int64 `bson:"reaction_counts,omitempty" json:"reaction_counts,omitempty"`
+	// Transcoding. Reels created before the pipeline have no status and play
+	// from VideoURL, as do reels whose processing failed.
+	ProcessingStatus ReelProcessingStatus `bson:"processing_status,omitempty" json:"processing_status,omitempty"`
+	ProcessingError  string               `bson:"processing_error,omitempty" json:"processing_error,omitempty"`
+	PlaybackURL      string               `bson:"playback_url,omitempty" json:"playback_url,omitempty"` // HLS master playlist
+	Renditions       []ReelRendition      `bson:"renditions,omitempty" json:"renditions,omitempty"`
+	ModerationStatus ModerationVerdict    `bson:"moderation_status,omitempty" json:"moderation_status,omitempty"` // Flagged thumbnails hide the reel from feeds and profiles
+	CreatedAt        time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt        time.Time            `bson:"updated_at" json:"updated_at"`
+	// Recommendation. A reel's topics are its category and caption hashtags,
+	// and its engagement score sums the weighted watches, completes, likes
+	// and shares it has drawn.
+	Category        string   `bson:"category,omitempty" json:"category,omitempty"`
+	Hashtags        []string `bson:"hashtags,omitempty" json:"hashtags,omitempty"`
+	EngagementScore float64  `bson:"engagement_score,omitempty" json:"-"`
 }
 
 type CreateReelRequest struct {
@@ -34,4 +67,5 @@ type CreateReelRequest struct {
 	Privacy        PrivacySettingType   `json:"privacy"`
 	AllowedViewers []primitive.ObjectID `json:"allowed_viewers,omitempty"`
 	BlockedViewers []primitive.ObjectID `json:"blocked_viewers,omitempty"`
+	Category       string               `json:"category,omitempty"`
 }
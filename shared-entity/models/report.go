@@ -0,0 +1,123 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReportTargetType names what a user report is about
+type ReportTargetType string
+
+const (
+	ReportTargetPost    ReportTargetType = "post"
+	ReportTargetMessage ReportTargetType = "message"
+	ReportTargetUser    ReportTargetType = "user"
+	ReportTargetListing ReportTargetType = "listing"
+)
+
+// ReportReason is the taxonomy reporters choose from
+type ReportReason string
+
+const (
+	ReportReasonSpam           ReportReason = "spam"
+	ReportReasonHarassment     ReportReason = "harassment"
+	ReportReasonHateSpeech     ReportReason = "hate_speech"
+	ReportReasonViolence       ReportReason = "violence"
+	ReportReasonNudity         ReportReason = "nudity"
+	ReportReasonScam           ReportReason = "scam"
+	ReportReasonImpersonation  ReportReason = "impersonation"
+	ReportReasonMisinformation ReportReason = "misinformation"
+	ReportReasonIntellectual   ReportReason = "intellectual_property"
+	ReportReasonOther          ReportReason = "other"
+)
+
+// ReportStatus tracks a report through review
+type ReportStatus string
+
+const (
+	ReportStatusOpen      ReportStatus = "open"
+	ReportStatusDismissed ReportStatus = "dismissed"
+	ReportStatusActioned  ReportStatus = "actioned"
+)
+
+// ReportAction is what an admin decided to do about a report
+type ReportAction string
+
+const (
+	ReportActionDismiss       ReportAction = "dismiss"
+	ReportActionRemoveContent ReportAction = "remove_content"
+	ReportActionSuspendUser   ReportAction = "suspend_user"
+)
+
+// ReportEvidenceKind tells what an evidence reference points at
+type ReportEvidenceKind string
+
+const (
+	ReportEvidenceMessage ReportEvidenceKind = "message"
+	ReportEvidencePost    ReportEvidenceKind = "post"
+	ReportEvidenceMedia   ReportEvidenceKind = "media"
+	ReportEvidenceURL     ReportEvidenceKind = "url"
+)
+
+// ReportEvidence references content backing up a report, such as earlier
+// messages of a harassment thread or a screenshot upload
+type ReportEvidence struct {
+	Kind ReportEvidenceKind `bson:"kind" json:"kind" binding:"required,oneof=message post media url"`
+	Ref  string             `bson:"ref" json:"ref" binding:"required,max=2048"`
+}
+
+// Report is a user's complaint about a post, message, user or listing
+type Report struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ReporterID primitive.ObjectID `bson:"reporter_id" json:"reporter_id"`
+	TargetType ReportTargetType   `bson:"target_type" json:"target_type"`
+	TargetID   string             `bson:"target_id" json:"target_id"`
+	// ConversationID locates a reported message
+	ConversationID string `bson:"conversation_id,omitempty" json:"conversation_id,omitempty"`
+	// TargetOwnerID is the author of the reported content, or the reported user
+	TargetOwnerID primitive.ObjectID  `bson:"target_owner_id" json:"target_owner_id"`
+	Reason        ReportReason        `bson:"reason" json:"reason"`
+	Details       string              `bson:"details,omitempty" json:"details,omitempty"`
+	Evidence      []ReportEvidence    `bson:"evidence,omitempty" json:"evidence,omitempty"`
+	Status        ReportStatus        `bson:"status" json:"status"`
+	Action        ReportAction        `bson:"action,omitempty" json:"action,omitempty"`
+	ReviewedBy    *primitive.ObjectID `bson:"reviewed_by,omitempty" json:"reviewed_by,omitempty"`
+	ReviewNote    string              `bson:"review_note,omitempty" json:"review_note,omitempty"`
+	ReviewedAt    *time.Time          `bson:"reviewed_at,omitempty" json:"reviewed_at,omitempty"`
+	CreatedAt     time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// ReportAuditEntry records one admin decision on a report. Entries are never
+// updated or deleted.
+type ReportAuditEntry struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ReportID primitive.ObjectID `bson:"report_id" json:"report_id"`
+	ActorID  primitive.ObjectID `bson:"actor_id" json:"actor_id"`
+	Action   ReportAction       `bson:"action" json:"action"`
+	Note     string             `bson:"note,omitempty" json:"note,omitempty"`
+	// SuspendedUntil is set when the decision suspended the target's owner
+	SuspendedUntil *time.Time `bson:"suspended_until,omitempty" json:"suspended_until,omitempty"`
+	CreatedAt      time.Time  `bson:"created_at" json:"created_at"`
+}
+
+type CreateReportRequest struct {
+	TargetType     ReportTargetType `json:"target_type" binding:"required,oneof=post message user listing"`
+	TargetID       string           `json:"target_id" binding:"required"`
+	ConversationID string           `json:"conversation_id,omitempty"` // Required for messages
+	Reason         ReportReason     `json:"reason" binding:"required,oneof=spam harassment hate_speech violence nudity scam impersonation misinformation intellectual_property other"`
+	Details        string           `json:"details,omitempty" binding:"max=2000"`
+	Evidence       []ReportEvidence `json:"evidence,omitempty" binding:"max=10,dive"`
+}
+
+type ReviewReportRequest struct {
+	Action      ReportAction `json:"action" binding:"required,oneof=dismiss remove_content suspend_user"`
+	Note        string       `json:"note,omitempty" binding:"max=2000"`
+	SuspendDays int          `json:"suspend_days,omitempty" binding:"omitempty,min=1,max=365"` // Defaults to 7
+}
+
+// ReportDetail is a report with its audit trail, for admins
+type ReportDetail struct {
+	Report
+	Audit []ReportAuditEntry `json:"audit"`
+}
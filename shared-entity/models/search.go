@@ -0,0 +1,207 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SearchEntityType identifies an indexed entity kind
+type SearchEntityType string
+
+const (
+	SearchEntityUser    SearchEntityType = "user"
+	SearchEntityPost    SearchEntityType = "post"
+	SearchEntityEvent   SearchEntityType = "event"
+	SearchEntityListing SearchEntityType = "listing"
+)
+
+// SearchEntityTypes lists every searchable entity in top-results order
+var SearchEntityTypes = []SearchEntityType{
+	SearchEntityUser,
+	SearchEntityPost,
+	SearchEntityEvent,
+	SearchEntityListing,
+}
+
+// Search documents are the denormalised projections owning services publish
+// to the search indexers. They carry the fields needed for permission-aware
+// filtering alongside the searchable text.
+
+type SearchUserDocument struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	FullName  string    `json:"full_name,omitempty"`
+	Avatar    string    `json:"avatar,omitempty"`
+	Bio       string    `json:"bio,omitempty"`
+	Location  string    `json:"location,omitempty"`
+	IsActive  bool      `json:"is_active"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SearchPostDocument struct {
+	ID             string             `json:"id"`
+	AuthorID       string             `json:"author_id"`
+	AuthorUsername string             `json:"author_username,omitempty"`
+	Content        string             `json:"content"`
+	Hashtags       []string           `json:"hashtags,omitempty"`
+	Location       string             `json:"location,omitempty"`
+	Privacy        PrivacySettingType `json:"privacy"`
+	Status         PostStatus         `json:"status"`
+	CommunityID    string             `json:"community_id,omitempty"`
+	Audience       []string           `json:"audience,omitempty"` // Allowed viewers for CUSTOM privacy
+	TotalReactions int64              `json:"total_reactions"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+type SearchEventDocument struct {
+	ID          string       `json:"id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	Location    string       `json:"location,omitempty"`
+	Category    string       `json:"category,omitempty"`
+	CoverImage  string       `json:"cover_image,omitempty"`
+	Privacy     EventPrivacy `json:"privacy"`
+	CreatorID   string       `json:"creator_id"`
+	MemberIDs   []string     `json:"member_ids,omitempty"` // Attendees, invitees and co-hosts, for private events
+	StartDate   time.Time    `json:"start_date"`
+	EndDate     time.Time    `json:"end_date"`
+	GoingCount  int64        `json:"going_count"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+type SearchListingDocument struct {
+	ID           string        `json:"id"`
+	SellerID     string        `json:"seller_id"`
+	Title        string        `json:"title"`
+	Description  string        `json:"description,omitempty"`
+	CategorySlug string        `json:"category_slug,omitempty"`
+	CategoryName string        `json:"category_name,omitempty"`
+	Tags         []string      `json:"tags,omitempty"`
+	Price        float64       `json:"price"`
+	Currency     string        `json:"currency,omitempty"`
+	City         string        `json:"city,omitempty"`
+	Image        string        `json:"image,omitempty"`
+	Status       ProductStatus `json:"status"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+// NewSearchUserDocument projects a user for the search index
+func NewSearchUserDocument(u *User) *SearchUserDocument {
+	return &SearchUserDocument{
+		ID:        u.ID.Hex(),
+		Username:  u.Username,
+		FullName:  u.FullName,
+		Avatar:    u.Avatar,
+		Bio:       u.Bio,
+		Location:  u.Location,
+		IsActive:  u.IsActive,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
+
+// NewSearchPostDocument projects a post for the search index
+func NewSearchPostDocument(p *Post) *SearchPostDocument {
+	doc := &SearchPostDocument{
+		ID:             p.ID.Hex(),
+		AuthorID:       p.UserID.Hex(),
+		AuthorUsername: p.Author.Username,
+		Content:        p.Content,
+		Hashtags:       p.Hashtags,
+		Location:       p.Location,
+		Privacy:        p.Privacy,
+		Status:         p.Status,
+		TotalReactions: p.TotalReactions,
+		CreatedAt:      p.CreatedAt,
+	}
+	if p.CommunityID != nil {
+		doc.CommunityID = p.CommunityID.Hex()
+	}
+	for _, id := range p.CustomAudience {
+		doc.Audience = append(doc.Audience, id.Hex())
+	}
+	return doc
+}
+
+// NewSearchEventDocument projects an event for the search index
+func NewSearchEventDocument(e *Event) *SearchEventDocument {
+	doc := &SearchEventDocument{
+		ID:          e.ID.Hex(),
+		Title:       e.Title,
+		Description: e.Description,
+		Location:    e.Location,
+		Category:    e.Category,
+		CoverImage:  e.CoverImage,
+		Privacy:     e.Privacy,
+		CreatorID:   e.CreatorID.Hex(),
+		StartDate:   e.StartDate,
+		EndDate:     e.EndDate,
+		GoingCount:  e.Stats.GoingCount,
+		CreatedAt:   e.CreatedAt,
+	}
+	for _, a := range e.Attendees {
+		doc.MemberIDs = append(doc.MemberIDs, a.UserID.Hex())
+	}
+	for _, h := range e.CoHosts {
+		doc.MemberIDs = append(doc.MemberIDs, h.UserID.Hex())
+	}
+	return doc
+}
+
+// NewSearchListingDocument projects a marketplace product for the search index
+func NewSearchListingDocument(p *Product) *SearchListingDocument {
+	doc := &SearchListingDocument{
+		ID:           p.ID.Hex(),
+		SellerID:     p.SellerID.Hex(),
+		Title:        p.Title,
+		Description:  p.Description,
+		CategorySlug: p.CategorySlug,
+		CategoryName: p.CategoryName,
+		Tags:         p.Tags,
+		Price:        p.Price,
+		Currency:     p.Currency,
+		City:         p.Location.City,
+		Status:       p.Status,
+		CreatedAt:    p.CreatedAt,
+	}
+	if len(p.Images) > 0 {
+		doc.Image = p.Images[0]
+	}
+	return doc
+}
+
+// SearchQuery is a typed query against one or more entity types
+type SearchQuery struct {
+	Query  string             `json:"query"`
+	Types  []SearchEntityType `json:"types,omitempty"` // Empty searches every type
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+}
+
+// SearchHit is a single matched document. Document holds the entity's
+// search document as stored in the index.
+type SearchHit struct {
+	Type       SearchEntityType    `json:"type"`
+	ID         string              `json:"id"`
+	Score      float64             `json:"score"`
+	Document   json.RawMessage     `json:"document"`
+	Highlights map[string][]string `json:"highlights,omitempty"`
+}
+
+type SearchResponse struct {
+	Query   string      `json:"query"`
+	Hits    []SearchHit `json:"hits"`
+	Total   int64       `json:"total"`
+	Limit   int         `json:"limit"`
+	Offset  int         `json:"offset"`
+	TookMs  int64       `json:"took_ms"`
+	Partial bool        `json:"partial,omitempty"` // Some indices failed to respond
+}
+
+// TopResultsResponse groups the best few hits per entity type for a global search bar
+type TopResultsResponse struct {
+	Query    string      `json:"query"`
+	Users    []SearchHit `json:"users"`
+	Posts    []SearchHit `json:"posts"`
+	Events   []SearchHit `json:"events"`
+	Listings []SearchHit `json:"listings"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SessionRevokedChannel is the Redis pub/sub channel user-service announces
+// revoked sessions on, so messaging-app can drop that device's WebSocket
+const SessionRevokedChannel = "session_revocations"
+
+// Session is one signed-in device. Its refresh token is rotated on every
+// refresh; RefreshTokenID only ever matches the newest one, so presenting an
+// older token reveals that it was copied and revokes the session.
+type Session struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID         primitive.ObjectID `bson:"user_id" json:"user_id"`
+	RefreshTokenID string             `bson:"refresh_token_id" json:"-"`
+	DeviceName     string             `bson:"device_name,omitempty" json:"device_name,omitempty"`
+	UserAgent      string             `bson:"user_agent,omitempty" json:"user_agent,omitempty"`
+	IPAddress      string             `bson:"ip_address,omitempty" json:"ip_address,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	LastUsedAt     time.Time          `bson:"last_used_at" json:"last_used_at"`
+	ExpiresAt      time.Time          `bson:"expires_at" json:"expires_at"`
+	RevokedAt      *time.Time         `bson:"revoked_at,omitempty" json:"-"`
+	RevokedReason  string             `bson:"revoked_reason,omitempty" json:"-"`
+
+	// Current marks the session the listing request was made from
+	Current bool `bson:"-" json:"current"`
+}
+
+// DeviceInfo describes the client a session is started or refreshed from
+type DeviceInfo struct {
+	Name      string
+	UserAgent string
+	IPAddress string
+}
+
+// SessionRevokedEvent is published on SessionRevokedChannel
+type SessionRevokedEvent struct {
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id"`
+	Reason    string `json:"reason"`
+}
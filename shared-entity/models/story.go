@@ -16,18 +16,97 @@ type Story struct {
 	AllowedViewers []primitive.ObjectID `bson:"allowed_viewers,omitempty" json:"allowed_viewers,omitempty"` // For CUSTOM
 	BlockedViewers []primitive.ObjectID `bson:"blocked_viewers,omitempty" json:"blocked_viewers,omitempty"` // For FRIENDS_EXCEPT
 
+	// Drawn over the media by clients, in order
+	Overlays []StoryOverlay `bson:"overlays,omitempty" json:"overlays,omitempty"`
+
+	// Set when moderation flagged the media; only the author still sees the story
+	ModerationStatus ModerationVerdict `bson:"moderation_status,omitempty" json:"moderation_status,omitempty"`
+
 	ViewCount     int `bson:"view_count" json:"view_count"`
 	ReactionCount int `bson:"reaction_count" json:"reaction_count"`
 
+	// Highlights the story is pinned to; highlighted stories and their media
+	// are kept past expiry
+	HighlightIDs []primitive.ObjectID `bson:"highlight_ids,omitempty" json:"highlight_ids,omitempty"`
+
 	CreatedAt time.Time `bson:"created_at" json:"created_at"`
 	ExpiresAt time.Time `bson:"expires_at" json:"expires_at"`
 }
 
+// StoryOverlayType is the kind of element drawn over a story's media
+type StoryOverlayType string
+
+const (
+	StoryOverlayText    StoryOverlayType = "TEXT"
+	StoryOverlaySticker StoryOverlayType = "STICKER"
+	StoryOverlayMention StoryOverlayType = "MENTION"
+	StoryOverlayLink    StoryOverlayType = "LINK"
+	StoryOverlayMusic   StoryOverlayType = "MUSIC"
+)
+
+// StoryOverlay is an element clients draw over a story's media. Positions are
+// fractions of the media's size, so overlays land in the same place at any
+// resolution.
+type StoryOverlay struct {
+	Type     StoryOverlayType `bson:"type" json:"type"`
+	X        float64          `bson:"x" json:"x"`               // Center, from 0 (left) to 1 (right)
+	Y        float64          `bson:"y" json:"y"`               // Center, from 0 (top) to 1 (bottom)
+	Scale    float64          `bson:"scale" json:"scale"`       // 1 is the element's natural size
+	Rotation float64          `bson:"rotation" json:"rotation"` // Degrees clockwise
+
+	Text      string              `bson:"text,omitempty" json:"text,omitempty"`             // TEXT, or the label of a LINK
+	Color     string              `bson:"color,omitempty" json:"color,omitempty"`           // #RRGGBB
+	Style     string              `bson:"style,omitempty" json:"style,omitempty"`           // Font or sticker style, up to the client
+	StickerID string              `bson:"sticker_id,omitempty" json:"sticker_id,omitempty"` // STICKER
+	UserID    *primitive.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"`       // MENTION
+	Username  string              `bson:"username,omitempty" json:"username,omitempty"`     // MENTION, as shown when posted
+	URL       string              `bson:"url,omitempty" json:"url,omitempty"`               // LINK
+	Music     *StoryMusic         `bson:"music,omitempty" json:"music,omitempty"`           // MUSIC
+}
+
+// StoryMusic references a track of the music catalog and the clip of it
+// that plays with the story. The audio itself is never stored.
+type StoryMusic struct {
+	TrackID    string `bson:"track_id" json:"track_id"`
+	Title      string `bson:"title" json:"title"`
+	Artist     string `bson:"artist" json:"artist"`
+	StartMs    int    `bson:"start_ms" json:"start_ms"`
+	DurationMs int    `bson:"duration_ms" json:"duration_ms"`
+}
+
+// StoryHighlight is a named collection of the user's past stories pinned to
+// their profile
+type StoryHighlight struct {
+	ID           primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	UserID       primitive.ObjectID   `bson:"user_id" json:"user_id"`
+	Title        string               `bson:"title" json:"title"`
+	CoverStoryID primitive.ObjectID   `bson:"cover_story_id" json:"cover_story_id"`
+	StoryIDs     []primitive.ObjectID `bson:"story_ids" json:"story_ids"` // In the order they play
+	CreatedAt    time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+// StoryHighlightCover is how a highlight shows on a profile, counting only
+// the stories the viewer may see
+type StoryHighlightCover struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	CoverURL   string `json:"cover_url"`
+	StoryCount int    `json:"story_count"`
+}
+
+// StoryHighlightDetail is a highlight with the stories the viewer may see
+type StoryHighlightDetail struct {
+	StoryHighlight
+	Stories []Story `json:"stories"`
+}
+
 type StoryView struct {
-	ID       primitive.ObjectID `bson:"_id,omitempty"`
-	StoryID  primitive.ObjectID `bson:"story_id"`
-	UserID   primitive.ObjectID `bson:"user_id"`
-	ViewedAt time.Time          `bson:"viewed_at"`
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	StoryID   primitive.ObjectID `bson:"story_id"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	ViewedAt  time.Time          `bson:"viewed_at"`
+	ExpiresAt time.Time          `bson:"expires_at"` // Copied from the story; view receipts are dropped with it
 }
 
 type StoryReaction struct {
@@ -44,6 +123,7 @@ type CreateStoryRequest struct {
 	Privacy        PrivacySettingType   `json:"privacy"` // Defaults to FRIENDS if empty
 	AllowedViewers []primitive.ObjectID `json:"allowed_viewers,omitempty"`
 	BlockedViewers []primitive.ObjectID `json:"blocked_viewers,omitempty"`
+	Overlays       []StoryOverlay       `json:"overlays,omitempty"`
 }
 
 type StoryViewerResponse struct {
@@ -52,18 +132,27 @@ type StoryViewerResponse struct {
 	ViewedAt     time.Time         `json:"viewed_at,omitempty" bson:"viewed_at"`         // (Optional, if we track view time later, for now just useful for structure)
 }
 
+// StoryViewersPage is one page of a story's viewers, visible to its author only
+type StoryViewersPage struct {
+	Viewers     []StoryViewerResponse `json:"viewers"`
+	UniqueViews int64                 `json:"unique_views"`
+	HasMore     bool                  `json:"has_more"`
+}
+
 // ===============================
 // Story WebSocket Events
 // ===============================
 
 type StoryCreatedEvent struct {
-	StoryID   string     `json:"story_id"`
-	UserID    string     `json:"user_id"`
-	Author    PostAuthor `json:"author"`
-	MediaURL  string     `json:"media_url"`
-	MediaType string     `json:"media_type"`
-	CreatedAt time.Time  `json:"created_at"`
-	ExpiresAt time.Time  `json:"expires_at"`
+	StoryID   string             `json:"story_id"`
+	UserID    string             `json:"user_id"`
+	Author    PostAuthor         `json:"author"`
+	MediaURL  string             `json:"media_url"`
+	MediaType string             `json:"media_type"`
+	Privacy   PrivacySettingType `json:"privacy,omitempty"`
+	Overlays  []StoryOverlay     `json:"overlays,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+	ExpiresAt time.Time          `json:"expires_at"`
 }
 
 type StoryDeletedEvent struct {
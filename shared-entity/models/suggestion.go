@@ -0,0 +1,11 @@
+package models
+
+// FriendSuggestion is a "People You May Know" entry together with the
+// connections it was ranked on
+type FriendSuggestion struct {
+	User              UserShortResponse `json:"user"`
+	MutualFriends     int               `json:"mutual_friends"`
+	SharedCommunities int               `json:"shared_communities"`
+	SharedEvents      int               `json:"shared_events"`
+	Score             float64           `json:"score"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// TrendingHashtag is a hashtag with its time-decayed popularity score
+type TrendingHashtag struct {
+	Tag   string  `json:"tag"`
+	Score float64 `json:"score"`
+}
+
+// TrendingHashtagsResponse lists the top hashtags for a region and window
+type TrendingHashtagsResponse struct {
+	Hashtags []TrendingHashtag `json:"hashtags"`
+	Region   string            `json:"region,omitempty"`
+	Window   string            `json:"window"`
+}
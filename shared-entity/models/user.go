@@ -9,6 +9,7 @@ import (
 type User struct {
 	ID                   primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
 	Username             string               `bson:"username" json:"username"`
+	UsernameChangedAt    *time.Time           `bson:"username_changed_at,omitempty" json:"username_changed_at,omitempty"` // Starts the cooldown before the next change
 	Email                string               `bson:"email" json:"email"`
 	Password             string               `bson:"password" json:"password"`
 	Avatar               string               `bson:"avatar" json:"avatar"`
@@ -18,22 +19,140 @@ type User struct {
 	DateOfBirth          *time.Time           `bson:"date_of_birth,omitempty" json:"date_of_birth,omitempty"`
 	Gender               string               `bson:"gender,omitempty" json:"gender,omitempty"`
 	Location             string               `bson:"location,omitempty" json:"location,omitempty"`
+	Pronouns             string               `bson:"pronouns,omitempty" json:"pronouns,omitempty"`
+	Websites             []ProfileWebsite     `bson:"websites,omitempty" json:"websites,omitempty"`
+	Work                 []WorkEntry          `bson:"work,omitempty" json:"work,omitempty"`
+	Education            []EducationEntry     `bson:"education,omitempty" json:"education,omitempty"`
+	ProfilePrivacy       ProfilePrivacy       `bson:"profile_privacy,omitempty" json:"profile_privacy"` // Who sees each profile section
 	PhoneNumber          string               `bson:"phone_number,omitempty" json:"phone_number,omitempty"`
 	Friends              []primitive.ObjectID `bson:"friends" json:"friends"`
 	Blocked              []primitive.ObjectID `bson:"blocked" json:"-"`
 	TwoFactorEnabled     bool                 `bson:"two_factor_enabled" json:"two_factor_enabled"`
+	TwoFactorSecret      string               `bson:"two_factor_secret,omitempty" json:"-"`
+	RecoveryCodes        []string             `bson:"recovery_codes,omitempty" json:"-"` // SHA-256 hashes, each usable once
 	EmailVerified        bool                 `bson:"email_verified" json:"email_verified"`
+	Verified             bool                 `bson:"verified,omitempty" json:"verified"` // Identity confirmed by an admin; shows the badge
+	VerifiedAt           *time.Time           `bson:"verified_at,omitempty" json:"verified_at,omitempty"`
 	IsActive             bool                 `bson:"is_active" json:"is_active"` // For account deactivation
 	LastLogin            *time.Time           `bson:"last_login,omitempty" json:"last_login,omitempty"`
 	CreatedAt            time.Time            `bson:"created_at" json:"created_at"`
 	UpdatedAt            time.Time            `bson:"updated_at" json:"updated_at"`
 	PrivacySettings      UserPrivacySettings  `bson:"privacy_settings" json:"privacy_settings"`
 	NotificationSettings NotificationSettings `bson:"notification_settings" json:"notification_settings"`
-	PublicKey            string               `bson:"public_key,omitempty" json:"public_key,omitempty"`                       // E2EE Public Key
-	EncryptedPrivateKey  string               `bson:"encrypted_private_key,omitempty" json:"encrypted_private_key,omitempty"` // E2EE Backup
-	KeyBackupIV          string               `bson:"key_backup_iv,omitempty" json:"key_backup_iv,omitempty"`                 // E2EE Backup
-	KeyBackupSalt        string               `bson:"key_backup_salt,omitempty" json:"key_backup_salt,omitempty"`             // E2EE Backup
-	IsEncryptionEnabled  bool                 `bson:"is_encryption_enabled" json:"is_encryption_enabled"`                     // Persistent Toggle
+	PublicKey            string               `bson:"public_key,omitempty" json:"public_key,omitempty"`                         // E2EE Public Key
+	EncryptedPrivateKey  string               `bson:"encrypted_private_key,omitempty" json:"encrypted_private_key,omitempty"`   // E2EE Backup
+	KeyBackupIV          string               `bson:"key_backup_iv,omitempty" json:"key_backup_iv,omitempty"`                   // E2EE Backup
+	KeyBackupSalt        string               `bson:"key_backup_salt,omitempty" json:"key_backup_salt,omitempty"`               // E2EE Backup
+	IsEncryptionEnabled  bool                 `bson:"is_encryption_enabled" json:"is_encryption_enabled"`                       // Persistent Toggle
+	Role                 UserRole             `bson:"role,omitempty" json:"role,omitempty"`                                     // Empty for regular users
+	SuspendedUntil       *time.Time           `bson:"suspended_until,omitempty" json:"suspended_until,omitempty"`               // Set by admins acting on reports
+	AccountState         AccountState         `bson:"account_state,omitempty" json:"account_state,omitempty"`                   // Empty for active accounts
+	DeletionScheduledFor *time.Time           `bson:"deletion_scheduled_for,omitempty" json:"deletion_scheduled_for,omitempty"` // Set while a requested deletion is in its grace period
+	DeletedAt            *time.Time           `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`                         // Set on the anonymized record a deleted account leaves behind
+}
+
+// ProfileWebsite is a link shown on a profile
+type ProfileWebsite struct {
+	URL   string `bson:"url" json:"url"`
+	Label string `bson:"label,omitempty" json:"label,omitempty"`
+}
+
+// WorkEntry is a job listed on a profile; a zero EndYear is a current job
+type WorkEntry struct {
+	Company   string `bson:"company" json:"company"`
+	Position  string `bson:"position,omitempty" json:"position,omitempty"`
+	StartYear int    `bson:"start_year,omitempty" json:"start_year,omitempty"`
+	EndYear   int    `bson:"end_year,omitempty" json:"end_year,omitempty"`
+}
+
+// EducationEntry is a school listed on a profile
+type EducationEntry struct {
+	School    string `bson:"school" json:"school"`
+	Degree    string `bson:"degree,omitempty" json:"degree,omitempty"`
+	Field     string `bson:"field,omitempty" json:"field,omitempty"`
+	StartYear int    `bson:"start_year,omitempty" json:"start_year,omitempty"`
+	EndYear   int    `bson:"end_year,omitempty" json:"end_year,omitempty"`
+}
+
+// ProfilePrivacy sets the audience of each profile section: PUBLIC, FRIENDS,
+// FRIENDS_OF_FRIENDS or ONLY_ME. An unset section is public.
+type ProfilePrivacy struct {
+	Bio       PrivacySettingType `bson:"bio,omitempty" json:"bio,omitempty"`
+	Websites  PrivacySettingType `bson:"websites,omitempty" json:"websites,omitempty"`
+	Pronouns  PrivacySettingType `bson:"pronouns,omitempty" json:"pronouns,omitempty"`
+	Work      PrivacySettingType `bson:"work,omitempty" json:"work,omitempty"`
+	Education PrivacySettingType `bson:"education,omitempty" json:"education,omitempty"`
+	Location  PrivacySettingType `bson:"location,omitempty" json:"location,omitempty"`
+}
+
+// UpdateProfileRequest changes a user's own profile. Empty strings and nil
+// fields are left as they are; an empty list clears its section.
+type UpdateProfileRequest struct {
+	FullName   string            `json:"full_name"`
+	Bio        string            `json:"bio"`
+	Avatar     string            `json:"avatar"`
+	CoverPhoto string            `json:"cover_photo"`
+	Location   string            `json:"location"`
+	Website    string            `json:"website"` // Deprecated: replaced by Websites, as their only entry
+	Pronouns   *string           `json:"pronouns,omitempty"`
+	Websites   *[]ProfileWebsite `json:"websites,omitempty"`
+	Work       *[]WorkEntry      `json:"work,omitempty"`
+	Education  *[]EducationEntry `json:"education,omitempty"`
+	Privacy    *ProfilePrivacy   `json:"profile_privacy,omitempty"` // Only the sections set change
+}
+
+// UserRole grants elevated access to operational endpoints
+type UserRole string
+
+const (
+	UserRoleAdmin UserRole = "admin"
+)
+
+// IsAdmin reports whether the user holds the admin role
+func (u *User) IsAdmin() bool {
+	return u.Role == UserRoleAdmin
+}
+
+// IsSuspended reports whether an admin suspension is still running
+func (u *User) IsSuspended(now time.Time) bool {
+	return u.SuspendedUntil != nil && now.Before(*u.SuspendedUntil)
+}
+
+// AccountState is the standing an admin put an account in
+type AccountState string
+
+const (
+	AccountStateActive AccountState = "active"
+	// AccountStateLimited shadow-bans the user: they keep using the app, but
+	// their content is hidden from everyone else's feeds and searches
+	AccountStateLimited   AccountState = "limited"
+	AccountStateSuspended AccountState = "suspended"
+	AccountStateBanned    AccountState = "banned"
+)
+
+// ShadowBannedUsersKey is the Redis set of limited user IDs, which the
+// services serving feeds and searches read to hide their content
+const ShadowBannedUsersKey = "users:shadow_banned"
+
+// EffectiveAccountState resolves the account's standing at now. Suspensions
+// lapse on their own once SuspendedUntil passes.
+func (u *User) EffectiveAccountState(now time.Time) AccountState {
+	if u.AccountState == AccountStateBanned {
+		return AccountStateBanned
+	}
+	if u.IsSuspended(now) {
+		return AccountStateSuspended
+	}
+	if u.AccountState == AccountStateLimited {
+		return AccountStateLimited
+	}
+	return AccountStateActive
+}
+
+// SetAccountStateRequest is an admin's change to a user's standing
+type SetAccountStateRequest struct {
+	State          AccountState `json:"state" binding:"required,oneof=active limited suspended banned"`
+	SuspendedUntil *time.Time   `json:"suspended_until,omitempty"` // Required for suspensions
 }
 
 type NotificationSettings struct {
@@ -83,22 +202,76 @@ type Group struct {
 	Name           string               `bson:"name" json:"name"`
 	Avatar         string               `bson:"avatar,omitempty" json:"avatar,omitempty"`
 	CreatorID      primitive.ObjectID   `bson:"creator_id" json:"creator_id"`
+	OwnerID        primitive.ObjectID   `bson:"owner_id,omitempty" json:"owner_id,omitempty"`
 	Members        []primitive.ObjectID `bson:"members" json:"members"`
 	PendingMembers []primitive.ObjectID `bson:"pending_members" json:"pending_members"`
 	Admins         []primitive.ObjectID `bson:"admins" json:"admins"`
+	BannedUsers    []primitive.ObjectID `bson:"banned_users,omitempty" json:"banned_users,omitempty"`
 	Settings       GroupSettings        `bson:"settings" json:"settings"`
 	CreatedAt      time.Time            `bson:"created_at" json:"created_at"`
 	UpdatedAt      time.Time            `bson:"updated_at" json:"updated_at"`
 }
 
 type GroupSettings struct {
-	RequiresApproval bool `bson:"requires_approval" json:"requires_approval"`
+	RequiresApproval bool             `bson:"requires_approval" json:"requires_approval"`
+	MaxMembers       int              `bson:"max_members,omitempty" json:"max_members"` // 0 means MaxGroupMembers
+	Permissions      GroupPermissions `bson:"permissions" json:"permissions"`
 }
 
 type AuthResponse struct {
 	AccessToken  string           `json:"access_token"`
 	RefreshToken string           `json:"refresh_token"`
 	User         SafeUserResponse `json:"user"`
+
+	// Set instead of the tokens when the password was right but a second
+	// factor is still needed; PreAuthToken is exchanged for tokens with a code
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	PreAuthToken      string `json:"pre_auth_token,omitempty"`
+
+	// Set when a social login created the account; the username was picked
+	// for the user, who may want one of the suggestions instead
+	NewAccount          bool     `json:"new_account,omitempty"`
+	UsernameSuggestions []string `json:"username_suggestions,omitempty"`
+}
+
+// TwoFactorSetupResponse carries a new TOTP secret for the user to add to an
+// authenticator app, either typed in or scanned from the otpauth URL as a QR code
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURL string `json:"otpauth_url"`
+}
+
+// TwoFactorCodeRequest carries a TOTP code or, where allowed, a recovery code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// TwoFactorLoginRequest completes a login that needs a second factor
+type TwoFactorLoginRequest struct {
+	PreAuthToken string `json:"pre_auth_token" binding:"required"`
+	Code         string `json:"code" binding:"required"`
+}
+
+// VerifyEmailRequest confirms an email address with the token mailed to it
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ForgotPasswordRequest asks for a password reset link
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest sets a new password with the token from a reset link
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// RecoveryCodesResponse lists freshly generated recovery codes. They are
+// only ever shown once.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
 }
 
 type RefreshRequest struct {
@@ -112,6 +285,7 @@ type UserShortResponse struct {
 	FullName  string             `bson:"full_name,omitempty" json:"full_name,omitempty"`
 	Avatar    string             `bson:"avatar,omitempty" json:"avatar,omitempty"`
 	PublicKey string             `bson:"public_key,omitempty" json:"public_key,omitempty"`
+	Verified  bool               `bson:"verified,omitempty" json:"verified,omitempty"`
 }
 
 type GroupResponse struct {
@@ -119,6 +293,7 @@ type GroupResponse struct {
 	Name           string              `json:"name"`
 	Avatar         string              `json:"avatar,omitempty"`
 	Creator        UserShortResponse   `json:"creator"`
+	OwnerID        primitive.ObjectID  `json:"owner_id"`
 	Members        []UserShortResponse `json:"members"`
 	PendingMembers []UserShortResponse `json:"pending_members"`
 	Admins         []UserShortResponse `json:"admins"`
@@ -179,6 +354,9 @@ type UserPrivacySettings struct {
 	CanSeeMyFriendsList     PrivacySettingType `bson:"can_see_my_friends_list" json:"can_see_my_friends_list"`
 	CanSendMeFriendRequests PrivacySettingType `bson:"can_send_me_friend_requests" json:"can_send_me_friend_requests"`
 	CanTagMeInPosts         PrivacySettingType `bson:"can_tag_me_in_posts" json:"can_tag_me_in_posts"`
+	PresenceVisibility      PrivacySettingType `bson:"presence_visibility,omitempty" json:"presence_visibility,omitempty"` // EVERYONE, FRIENDS or NO_ONE; unset means EVERYONE
+	ReadReceiptsOff         bool               `bson:"read_receipts_off,omitempty" json:"read_receipts_off"`
+	TypingIndicatorsOff     bool               `bson:"typing_indicators_off,omitempty" json:"typing_indicators_off"`
 	LastUpdated             time.Time          `bson:"last_updated" json:"last_updated"`
 }
 
@@ -193,6 +371,7 @@ const (
 	PrivacySettingEveryone         PrivacySettingType = "EVERYONE"
 	PrivacySettingCustom           PrivacySettingType = "CUSTOM"         // Specific Friends
 	PrivacySettingFriendsExcept    PrivacySettingType = "FRIENDS_EXCEPT" // Friends except specific ones
+	PrivacySettingCloseFriends     PrivacySettingType = "CLOSE_FRIENDS"  // The author's close friends list, stories only
 )
 
 type CustomPrivacyList struct {
@@ -216,6 +395,9 @@ type UpdatePrivacySettingsRequest struct {
 	CanSeeMyFriendsList     PrivacySettingType `json:"can_see_my_friends_list,omitempty"`
 	CanSendMeFriendRequests PrivacySettingType `json:"can_send_me_friend_requests,omitempty"`
 	CanTagMeInPosts         PrivacySettingType `json:"can_tag_me_in_posts,omitempty"`
+	PresenceVisibility      PrivacySettingType `json:"presence_visibility,omitempty"`
+	ReadReceiptsOff         *bool              `json:"read_receipts_off,omitempty"`
+	TypingIndicatorsOff     *bool              `json:"typing_indicators_off,omitempty"`
 }
 
 type CreateCustomPrivacyListRequest struct {
@@ -255,6 +437,21 @@ type UpdateUserProfileRequest struct {
 	Avatar      string     `json:"avatar,omitempty"`
 }
 
+type ChangeUsernameRequest struct {
+	Username string `json:"username" binding:"required"`
+}
+
+// UsernameChange records a username its owner gave up. Until ReservedUntil
+// the old name still resolves to them and nobody else may claim it.
+type UsernameChange struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID        primitive.ObjectID `bson:"user_id" json:"user_id"`
+	OldUsername   string             `bson:"old_username" json:"old_username"`
+	NewUsername   string             `bson:"new_username" json:"new_username"`
+	ChangedAt     time.Time          `bson:"changed_at" json:"changed_at"`
+	ReservedUntil time.Time          `bson:"reserved_until" json:"reserved_until"`
+}
+
 type UpdateEmailRequest struct {
 	NewEmail string `json:"new_email" binding:"required,email"`
 }
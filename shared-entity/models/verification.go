@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VerificationStatus is where a verification request is in review
+type VerificationStatus string
+
+const (
+	VerificationPending  VerificationStatus = "pending"
+	VerificationApproved VerificationStatus = "approved"
+	VerificationRejected VerificationStatus = "rejected"
+)
+
+// VerificationCategory is what the user asks to be verified as
+type VerificationCategory string
+
+const (
+	VerificationCategoryPerson       VerificationCategory = "person"
+	VerificationCategoryCreator      VerificationCategory = "creator"
+	VerificationCategoryOrganization VerificationCategory = "organization"
+)
+
+// VerificationRequest asks admins to confirm a user is who they claim to be.
+// DocumentKey is the storage-service key of the identity document, which is
+// deleted once the request is reviewed.
+type VerificationRequest struct {
+	ID          primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	UserID      primitive.ObjectID   `bson:"user_id" json:"user_id"`
+	Category    VerificationCategory `bson:"category" json:"category"`
+	LegalName   string               `bson:"legal_name" json:"legal_name"`
+	DocumentKey string               `bson:"document_key,omitempty" json:"-"`
+	Status      VerificationStatus   `bson:"status" json:"status"`
+	ReviewerID  *primitive.ObjectID  `bson:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+	ReviewNote  string               `bson:"review_note,omitempty" json:"review_note,omitempty"` // Shown to the user
+	CreatedAt   time.Time            `bson:"created_at" json:"created_at"`
+	ReviewedAt  *time.Time           `bson:"reviewed_at,omitempty" json:"reviewed_at,omitempty"`
+}
+
+type CreateVerificationRequest struct {
+	Category    VerificationCategory `json:"category" binding:"required,oneof=person creator organization"`
+	LegalName   string               `json:"legal_name" binding:"required,max=200"`
+	DocumentKey string               `json:"document_key" binding:"required"` // From a storage-service upload
+}
+
+type ReviewVerificationRequest struct {
+	Approve bool   `json:"approve"`
+	Note    string `json:"note" binding:"max=500"`
+}
+
+// VerificationReviewItem is a pending request in the admin queue, with a
+// short-lived link to its document
+type VerificationReviewItem struct {
+	VerificationRequest
+	User        UserShortResponse `json:"user"`
+	DocumentURL string            `json:"document_url,omitempty"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WatchContentType is the kind of video a watch progress entry tracks. Post
+// videos are media items of a post, told apart by their index.
+type WatchContentType string
+
+const (
+	WatchContentReel  WatchContentType = "reel"
+	WatchContentVideo WatchContentType = "video"
+)
+
+// Valid reports whether t is a trackable content type
+func (t WatchContentType) Valid() bool {
+	switch t {
+	case WatchContentReel, WatchContentVideo:
+		return true
+	}
+	return false
+}
+
+// WatchProgress is how far a user got through a reel or post video. A
+// completed video resumes from the start.
+type WatchProgress struct {
+	UserID      primitive.ObjectID `bson:"user_id" json:"-"`
+	ContentType WatchContentType   `bson:"content_type" json:"content_type"`
+	ContentID   primitive.ObjectID `bson:"content_id" json:"content_id"`
+	MediaIndex  int                `bson:"media_index" json:"media_index"` // Always 0 for reels
+	PositionMs  int64              `bson:"position_ms" json:"position_ms"`
+	DurationMs  int64              `bson:"duration_ms,omitempty" json:"duration_ms,omitempty"`
+	Completed   bool               `bson:"completed" json:"completed"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// ResumePositionMs is where playback picks up again
+func (p *WatchProgress) ResumePositionMs() int64 {
+	if p.Completed {
+		return 0
+	}
+	return p.PositionMs
+}
+
+type RecordWatchProgressRequest struct {
+	ContentType WatchContentType `json:"content_type" binding:"required"`
+	ContentID   string           `json:"content_id" binding:"required"`
+	MediaIndex  int              `json:"media_index"`
+	PositionMs  int64            `json:"position_ms"`
+	DurationMs  int64            `json:"duration_ms"`
+}
+
+type WatchHistoryResponse struct {
+	Items []WatchProgress `json:"items"`
+	Total int64           `json:"total"`
+	Page  int64           `json:"page"`
+	Limit int64           `json:"limit"`
+}
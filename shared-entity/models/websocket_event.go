@@ -10,9 +10,30 @@ import (
 // WebSocketEvent is a generic structure for events sent over WebSocket.
 // It contains a Type field to identify the event and Data for the event-specific payload.
 type WebSocketEvent struct {
-	Type       string          `json:"type"`
-	Data       json.RawMessage `json:"data"`
-	Recipients []string        `json:"recipients,omitempty"` // List of UserIDs to receive this event
+	Type          string          `json:"type"`
+	Data          json.RawMessage `json:"data"`
+	Recipients    []string        `json:"recipients,omitempty"`     // List of UserIDs to receive this event
+	Cursor        string          `json:"cursor,omitempty"`         // Replay position for events delivered through the Kafka hub fan-out
+	JournalCursor string          `json:"journal_cursor,omitempty"` // Position in the recipient's event journal, for resuming with GET /ws/replay
+}
+
+// ConversationReplay is a page of hub events replayed to a reconnecting client.
+type ConversationReplay struct {
+	ConversationID string           `json:"conversation_id"`
+	Events         []WebSocketEvent `json:"events"`
+	Cursor         string           `json:"cursor"` // Pass back to resume after the last replayed event
+	HasMore        bool             `json:"has_more"`
+}
+
+// EventReplay is a page of the journaled events a user missed while
+// disconnected.
+type EventReplay struct {
+	Events  []WebSocketEvent `json:"events"`
+	Cursor  string           `json:"cursor"` // Pass back as since to resume after the last replayed event
+	HasMore bool             `json:"has_more"`
+	// Reset is set when events after since are no longer journaled; the client
+	// should reload its state, then resume from Cursor
+	Reset bool `json:"reset"`
 }
 
 // TypingEvent represents a user typing event in a conversation.
@@ -20,7 +41,8 @@ type TypingEvent struct {
 	UserID         string `json:"user_id"`
 	ConversationID string `json:"conversation_id"`
 	IsTyping       bool   `json:"is_typing"`
-	IsMarketplace  bool   `json:"is_marketplace"` // For distinguishing marketplace vs personal DM typing
+	IsMarketplace  bool   `json:"is_marketplace"`       // For distinguishing marketplace vs personal DM typing
+	ProductID      string `json:"product_id,omitempty"` // Listing the marketplace thread is about
 	Timestamp      int64  `json:"timestamp"`
 }
 
@@ -48,7 +70,10 @@ type ConversationSeenEvent struct {
 }
 
 // CallSignalEvent represents a signaling message for voice/video calls.
+// Signals with a RoomID belong to a group call and go to one of its joined
+// participants, or to all of them when TargetID is empty.
 type CallSignalEvent struct {
+	RoomID     string          `json:"room_id,omitempty"`
 	TargetID   string          `json:"target_id"`
 	SignalType string          `json:"signal_type"` // OFFER, ANSWER, ICE_CANDIDATE, END_CALL, REJECT_CALL, BUSY
 	SignalData json.RawMessage `json:"signal_data,omitempty"`
@@ -0,0 +1,194 @@
+package moderation
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// ruleCacheTTL bounds how long a service screens with rules that were
+	// changed elsewhere
+	ruleCacheTTL = time.Minute
+	// excerptLength is how much of the screened text a queue item keeps
+	excerptLength = 500
+
+	applyInterval = 15 * time.Second
+	applyLease    = time.Minute
+)
+
+// Resolver carries out review decisions on the content a service owns. Both
+// calls must succeed when the content no longer exists, otherwise the
+// decision is retried forever.
+type Resolver interface {
+	// ApproveContent releases held content; flagged content needs no change
+	ApproveContent(ctx context.Context, item *models.ModerationQueueItem) error
+	RemoveContent(ctx context.Context, item *models.ModerationQueueItem) error
+}
+
+type cachedRules struct {
+	set      *RuleSet
+	loadedAt time.Time
+}
+
+// Moderator screens text with cached rule sets, queues what the rules catch
+// and applies moderators' decisions through the registered resolvers
+type Moderator struct {
+	store  *Store
+	logger *slog.Logger
+
+	mu    sync.RWMutex
+	cache map[string]cachedRules
+
+	resolvers map[models.ModeratedContentType]Resolver
+}
+
+func NewModerator(store *Store, logger *slog.Logger) *Moderator {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Moderator{
+		store:     store,
+		logger:    logger,
+		cache:     make(map[string]cachedRules),
+		resolvers: make(map[models.ModeratedContentType]Resolver),
+	}
+}
+
+// Store exposes the rules and queue for the admin API
+func (m *Moderator) Store() *Store {
+	return m.store
+}
+
+// Screen evaluates text against the global rules and, for community content,
+// the community's rules. Screening fails open: if the rules cannot be loaded
+// the text is let through and the failure logged.
+func (m *Moderator) Screen(ctx context.Context, text string, communityID *primitive.ObjectID) Decision {
+	if m == nil {
+		return Decision{}
+	}
+	set, err := m.ruleSet(ctx, communityID)
+	if err != nil {
+		m.logger.Error("Failed to load moderation rules", "error", err)
+		return Decision{}
+	}
+	return set.Evaluate(text)
+}
+
+func (m *Moderator) ruleSet(ctx context.Context, communityID *primitive.ObjectID) (*RuleSet, error) {
+	key := "global"
+	if communityID != nil {
+		key = communityID.Hex()
+	}
+
+	m.mu.RLock()
+	cached, ok := m.cache[key]
+	m.mu.RUnlock()
+	if ok && time.Since(cached.loadedAt) < ruleCacheTTL {
+		return cached.set, nil
+	}
+
+	rules, err := m.store.EnabledRules(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	set := Compile(rules)
+
+	m.mu.Lock()
+	m.cache[key] = cachedRules{set: set, loadedAt: time.Now()}
+	m.mu.Unlock()
+	return set, nil
+}
+
+// InvalidateRules drops the cached rule sets after a rule changed
+func (m *Moderator) InvalidateRules() {
+	m.mu.Lock()
+	m.cache = make(map[string]cachedRules)
+	m.mu.Unlock()
+}
+
+// Report queues content a flag or hold rule matched. A failure is logged
+// rather than failing content that has already been stored.
+func (m *Moderator) Report(ctx context.Context, decision Decision, item models.ModerationQueueItem, text string) {
+	if m == nil || !decision.Matched() {
+		return
+	}
+	item.Action = decision.Action
+	item.Rules = decision.Rules
+	item.Excerpt = excerpt(text)
+	if err := m.store.Enqueue(ctx, &item); err != nil {
+		m.logger.Error("Failed to queue content for moderation", "content_type", item.ContentType, "content_id", item.ContentID, "error", err)
+	}
+}
+
+// Handle registers the resolver that applies decisions on a content type.
+// Call it before Run.
+func (m *Moderator) Handle(contentType models.ModeratedContentType, resolver Resolver) {
+	m.resolvers[contentType] = resolver
+}
+
+// Run applies review decisions on the registered content types until ctx is
+// cancelled. Decisions are recorded by whichever service serves the admin
+// API and carried out here by the service owning the content.
+func (m *Moderator) Run(ctx context.Context) {
+	if len(m.resolvers) == 0 {
+		return
+	}
+	contentTypes := make([]models.ModeratedContentType, 0, len(m.resolvers))
+	for contentType := range m.resolvers {
+		contentTypes = append(contentTypes, contentType)
+	}
+
+	ticker := time.NewTicker(applyInterval)
+	defer ticker.Stop()
+	for {
+		m.applyReviewed(ctx, contentTypes)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// applyReviewed drains the reviewed items waiting on this service
+func (m *Moderator) applyReviewed(ctx context.Context, contentTypes []models.ModeratedContentType) {
+	for ctx.Err() == nil {
+		item, err := m.store.ClaimReviewed(ctx, contentTypes, time.Now(), applyLease)
+		if err != nil {
+			m.logger.Error("Failed to claim reviewed moderation item", "error", err)
+			return
+		}
+		if item == nil {
+			return
+		}
+
+		resolver := m.resolvers[item.ContentType]
+		if item.Status == models.ModerationQueueRemoved {
+			err = resolver.RemoveContent(ctx, item)
+		} else {
+			err = resolver.ApproveContent(ctx, item)
+		}
+		if err != nil {
+			// The lease runs out and the decision is retried
+			m.logger.Error("Failed to apply moderation decision", "item", item.ID.Hex(), "content_type", item.ContentType, "content_id", item.ContentID, "error", err)
+			continue
+		}
+		if err := m.store.MarkApplied(ctx, item.ID); err != nil {
+			m.logger.Error("Failed to mark moderation decision applied", "item", item.ID.Hex(), "error", err)
+		}
+	}
+}
+
+func excerpt(text string) string {
+	if utf8.RuneCountInString(text) <= excerptLength {
+		return text
+	}
+	return string([]rune(text)[:excerptLength]) + "…"
+}
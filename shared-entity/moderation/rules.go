@@ -0,0 +1,125 @@
+// Package moderation screens user text against keyword and regex rule sets
+// kept in Mongo and queues what they catch for moderators.
+package moderation
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+var (
+	// ErrContentRejected is returned by services for text a reject rule matched
+	ErrContentRejected = errors.New("content violates the community guidelines")
+	// ErrInvalidRule is returned for rules without patterns or with a pattern
+	// that does not compile
+	ErrInvalidRule = errors.New("invalid moderation rule")
+)
+
+// Decision is the outcome of screening one piece of text. A zero Decision
+// means no rule matched.
+type Decision struct {
+	Action models.ModerationAction
+	Rules  []string // Names of the matching rules
+}
+
+// Matched reports whether any rule matched
+func (d Decision) Matched() bool {
+	return d.Action != ""
+}
+
+// Rejected reports whether the text must be refused
+func (d Decision) Rejected() bool {
+	return d.Action == models.ModerationActionReject
+}
+
+// Held reports whether the text must wait for a moderator before others see it
+func (d Decision) Held() bool {
+	return d.Action == models.ModerationActionHold
+}
+
+type compiledRule struct {
+	name    string
+	action  models.ModerationAction
+	pattern *regexp.Regexp
+}
+
+// RuleSet is a compiled set of rules, safe for concurrent use
+type RuleSet struct {
+	rules []compiledRule
+}
+
+// Compile builds a rule set from enabled rules. A rule that no longer
+// compiles is skipped rather than disabling every other rule with it.
+func Compile(rules []models.ModerationRule) *RuleSet {
+	set := &RuleSet{rules: make([]compiledRule, 0, len(rules))}
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		pattern, err := compileRule(rule.Kind, rule.Patterns)
+		if err != nil {
+			continue
+		}
+		set.rules = append(set.rules, compiledRule{name: rule.Name, action: rule.Action, pattern: pattern})
+	}
+	return set
+}
+
+// Evaluate matches text against every rule. The strictest matching action
+// wins; all matching rule names are reported.
+func (s *RuleSet) Evaluate(text string) Decision {
+	var decision Decision
+	if s == nil || strings.TrimSpace(text) == "" {
+		return decision
+	}
+	for _, rule := range s.rules {
+		if !rule.pattern.MatchString(text) {
+			continue
+		}
+		decision.Rules = append(decision.Rules, rule.name)
+		if rule.action.Severity() > decision.Action.Severity() {
+			decision.Action = rule.action
+		}
+	}
+	return decision
+}
+
+// ValidateRule checks a rule's patterns before it is stored
+func ValidateRule(kind models.ModerationRuleKind, patterns []string, action models.ModerationAction) error {
+	if action.Severity() == 0 {
+		return fmt.Errorf("%w: unknown action %q", ErrInvalidRule, action)
+	}
+	_, err := compileRule(kind, patterns)
+	return err
+}
+
+// compileRule folds a rule's patterns into one case-insensitive expression.
+// Keywords match whole words, so "ass" does not catch "class".
+func compileRule(kind models.ModerationRuleKind, patterns []string) (*regexp.Regexp, error) {
+	alternatives := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		switch kind {
+		case models.ModerationRuleKeyword:
+			alternatives = append(alternatives, `\b`+regexp.QuoteMeta(pattern)+`\b`)
+		case models.ModerationRuleRegex:
+			if _, err := regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+			}
+			alternatives = append(alternatives, "(?:"+pattern+")")
+		default:
+			return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidRule, kind)
+		}
+	}
+	if len(alternatives) == 0 {
+		return nil, fmt.Errorf("%w: no patterns", ErrInvalidRule)
+	}
+	return regexp.Compile("(?i)" + strings.Join(alternatives, "|"))
+}
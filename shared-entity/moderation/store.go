@@ -0,0 +1,257 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ErrRuleNotFound = errors.New("moderation rule not found")
+	ErrItemNotFound = errors.New("moderation queue item not found")
+	// ErrItemReviewed is returned when reviewing an item someone already reviewed
+	ErrItemReviewed = errors.New("moderation queue item was already reviewed")
+)
+
+// reviewedItemTTL is how long applied review decisions are kept
+const reviewedItemTTL = 180 * 24 * time.Hour
+
+// Store keeps rules and the review queue in the database shared by the
+// services that screen content
+type Store struct {
+	rules *mongo.Collection
+	queue *mongo.Collection
+}
+
+func NewStore(db *mongo.Database) *Store {
+	rules := db.Collection("moderation_rules")
+	queue := db.Collection("moderation_queue")
+
+	_, err := rules.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "community_id", Value: 1}, {Key: "enabled", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("Failed to create moderation rule index: %v", err)
+	}
+
+	_, err = queue.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		// Moderator listing
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+		// Reviewed items waiting for their owning service
+		{Keys: bson.D{{Key: "content_type", Value: 1}, {Key: "status", Value: 1}, {Key: "applied_at", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "applied_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(reviewedItemTTL.Seconds())),
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to create moderation queue indexes: %v", err)
+	}
+
+	return &Store{rules: rules, queue: queue}
+}
+
+// EnabledRules returns the global rules plus, for a community, its own rules
+func (s *Store) EnabledRules(ctx context.Context, communityID *primitive.ObjectID) ([]models.ModerationRule, error) {
+	scopes := []bson.M{{"community_id": nil}}
+	if communityID != nil {
+		scopes = append(scopes, bson.M{"community_id": *communityID})
+	}
+	return s.findRules(ctx, bson.M{"enabled": true, "$or": scopes})
+}
+
+// ListRules returns the rules of one community, or the global rules when
+// communityID is nil, disabled ones included
+func (s *Store) ListRules(ctx context.Context, communityID *primitive.ObjectID) ([]models.ModerationRule, error) {
+	filter := bson.M{"community_id": nil}
+	if communityID != nil {
+		filter["community_id"] = *communityID
+	}
+	return s.findRules(ctx, filter)
+}
+
+func (s *Store) findRules(ctx context.Context, filter bson.M) ([]models.ModerationRule, error) {
+	cursor, err := s.rules.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	rules := []models.ModerationRule{}
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (s *Store) GetRule(ctx context.Context, id primitive.ObjectID) (*models.ModerationRule, error) {
+	var rule models.ModerationRule
+	err := s.rules.FindOne(ctx, bson.M{"_id": id}).Decode(&rule)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrRuleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (s *Store) CreateRule(ctx context.Context, rule *models.ModerationRule) error {
+	now := time.Now()
+	rule.ID = primitive.NewObjectID()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+	_, err := s.rules.InsertOne(ctx, rule)
+	return err
+}
+
+func (s *Store) UpdateRule(ctx context.Context, rule *models.ModerationRule) error {
+	rule.UpdatedAt = time.Now()
+	result, err := s.rules.ReplaceOne(ctx, bson.M{"_id": rule.ID}, rule)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrRuleNotFound
+	}
+	return nil
+}
+
+func (s *Store) DeleteRule(ctx context.Context, id primitive.ObjectID) error {
+	result, err := s.rules.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrRuleNotFound
+	}
+	return nil
+}
+
+// Enqueue adds content to the review queue
+func (s *Store) Enqueue(ctx context.Context, item *models.ModerationQueueItem) error {
+	item.ID = primitive.NewObjectID()
+	item.Status = models.ModerationQueuePending
+	item.CreatedAt = time.Now()
+	_, err := s.queue.InsertOne(ctx, item)
+	return err
+}
+
+// QueueFilter narrows the review queue listing; zero fields match everything
+type QueueFilter struct {
+	Status      models.ModerationQueueStatus
+	ContentType models.ModeratedContentType
+	CommunityID *primitive.ObjectID
+}
+
+// ListQueue pages through the queue, newest first
+func (s *Store) ListQueue(ctx context.Context, filter QueueFilter, page, limit int64) ([]models.ModerationQueueItem, int64, error) {
+	query := bson.M{}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.ContentType != "" {
+		query["content_type"] = filter.ContentType
+	}
+	if filter.CommunityID != nil {
+		query["community_id"] = *filter.CommunityID
+	}
+
+	total, err := s.queue.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := s.queue.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	items := []models.ModerationQueueItem{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (s *Store) GetItem(ctx context.Context, id primitive.ObjectID) (*models.ModerationQueueItem, error) {
+	var item models.ModerationQueueItem
+	err := s.queue.FindOne(ctx, bson.M{"_id": id}).Decode(&item)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Review records a moderator's decision on a pending item
+func (s *Store) Review(ctx context.Context, id, reviewerID primitive.ObjectID, decision models.ModerationQueueStatus, note string) (*models.ModerationQueueItem, error) {
+	update := bson.M{"$set": bson.M{
+		"status":      decision,
+		"reviewed_by": reviewerID,
+		"review_note": note,
+		"reviewed_at": time.Now(),
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var item models.ModerationQueueItem
+	err := s.queue.FindOneAndUpdate(ctx, bson.M{"_id": id, "status": models.ModerationQueuePending}, update, opts).Decode(&item)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if _, getErr := s.GetItem(ctx, id); getErr != nil {
+			return nil, getErr
+		}
+		return nil, ErrItemReviewed
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ClaimReviewed leases the next reviewed item of the given content types
+// whose decision has not been applied yet. It returns nil when none is left.
+func (s *Store) ClaimReviewed(ctx context.Context, contentTypes []models.ModeratedContentType, now time.Time, lease time.Duration) (*models.ModerationQueueItem, error) {
+	filter := bson.M{
+		"content_type": bson.M{"$in": contentTypes},
+		"status":       bson.M{"$in": []models.ModerationQueueStatus{models.ModerationQueueApproved, models.ModerationQueueRemoved}},
+		"applied_at":   nil,
+		"$or": []bson.M{
+			{"locked_until": nil},
+			{"locked_until": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"locked_until": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "reviewed_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var item models.ModerationQueueItem
+	err := s.queue.FindOneAndUpdate(ctx, filter, update, opts).Decode(&item)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// MarkApplied records that the owning service carried out the decision
+func (s *Store) MarkApplied(ctx context.Context, id primitive.ObjectID) error {
+	_, err := s.queue.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set":   bson.M{"applied_at": time.Now()},
+		"$unset": bson.M{"locked_until": ""},
+	})
+	return err
+}
@@ -0,0 +1,182 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// KafkaHeaderCarrier lets the OpenTelemetry propagator read and write the
+// headers of a Kafka message
+type KafkaHeaderCarrier struct {
+	Headers *[]kafka.Header
+}
+
+func (c KafkaHeaderCarrier) Get(key string) string {
+	for _, h := range *c.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c KafkaHeaderCarrier) Set(key, value string) {
+	for i, h := range *c.Headers {
+		if h.Key == key {
+			(*c.Headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.Headers = append(*c.Headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c KafkaHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.Headers))
+	for _, h := range *c.Headers {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
+
+// InjectKafka writes the trace context of ctx into the message headers
+func InjectKafka(ctx context.Context, msg *kafka.Message) {
+	otel.GetTextMapPropagator().Inject(ctx, KafkaHeaderCarrier{Headers: &msg.Headers})
+}
+
+// ExtractKafka returns ctx carrying the trace context found in the message
+// headers
+func ExtractKafka(ctx context.Context, msg kafka.Message) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, KafkaHeaderCarrier{Headers: &msg.Headers})
+}
+
+// TraceHeaders returns only the trace context headers, for messages that are
+// republished with new headers, such as retries
+func TraceHeaders(headers []kafka.Header) []kafka.Header {
+	fields := otel.GetTextMapPropagator().Fields()
+	var traced []kafka.Header
+	for _, h := range headers {
+		if slices.Contains(fields, h.Key) {
+			traced = append(traced, h)
+		}
+	}
+	return traced
+}
+
+// StartKafkaProducerSpan starts a span for publishing msg and injects it into
+// the message headers, so the consumer's span joins the same trace. Topic is
+// taken from the message when set, otherwise from the writer.
+func StartKafkaProducerSpan(ctx context.Context, topic string, msg *kafka.Message) (context.Context, trace.Span) {
+	if msg.Topic != "" {
+		topic = msg.Topic
+	}
+	ctx, span := otel.Tracer("connectify").Start(ctx, topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", topic),
+			attribute.String("messaging.operation", "publish"),
+		),
+	)
+	InjectKafka(ctx, msg)
+	return ctx, span
+}
+
+// StartKafkaConsumerSpan starts a span for processing msg as a child of the
+// span that published it
+func StartKafkaConsumerSpan(ctx context.Context, consumerGroup string, msg kafka.Message) (context.Context, trace.Span) {
+	ctx = ExtractKafka(ctx, msg)
+	return otel.Tracer("connectify").Start(ctx, msg.Topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", msg.Topic),
+			attribute.String("messaging.operation", "process"),
+			attribute.String("messaging.consumer.group.name", consumerGroup),
+			attribute.Int("messaging.kafka.destination.partition", msg.Partition),
+			attribute.Int64("messaging.kafka.message.offset", msg.Offset),
+		),
+	)
+}
+
+// EndSpan records err, if any, and ends the span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// InjectMap returns the trace context of ctx as a map, for storing it with
+// work that is picked up later, such as outbox events
+func InjectMap(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// ExtractMap returns ctx carrying the trace context stored by InjectMap
+func ExtractMap(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// redisEnvelope carries the trace context next to a Redis pub/sub payload,
+// which has no headers of its own
+type redisEnvelope struct {
+	TraceContext map[string]string `json:"trace_context"`
+	Payload      json.RawMessage   `json:"payload"`
+}
+
+// WrapRedisPayload wraps a JSON payload for Redis pub/sub together with the
+// trace context of ctx. Without an active trace the payload is returned as is.
+func WrapRedisPayload(ctx context.Context, payload []byte) []byte {
+	carrier := InjectMap(ctx)
+	if carrier == nil || !json.Valid(payload) {
+		return payload
+	}
+	wrapped, err := json.Marshal(redisEnvelope{TraceContext: carrier, Payload: payload})
+	if err != nil {
+		return payload
+	}
+	return wrapped
+}
+
+// UnwrapRedisPayload undoes WrapRedisPayload, returning ctx carrying the
+// publisher's trace context and the original payload. Payloads published
+// without a trace context are returned unchanged.
+func UnwrapRedisPayload(ctx context.Context, payload []byte) (context.Context, []byte) {
+	var envelope redisEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil || envelope.TraceContext == nil || envelope.Payload == nil {
+		return ctx, payload
+	}
+	return ExtractMap(ctx, envelope.TraceContext), envelope.Payload
+}
+
+// StartRedisConsumerSpan unwraps a Redis pub/sub payload and starts a span
+// for handling it as a child of the publisher's span
+func StartRedisConsumerSpan(ctx context.Context, channel string, payload []byte) (context.Context, trace.Span, []byte) {
+	ctx, payload = UnwrapRedisPayload(ctx, payload)
+	ctx, span := otel.Tracer("connectify").Start(ctx, channel+" receive",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "redis"),
+			attribute.String("messaging.destination.name", channel),
+			attribute.String("messaging.operation", "receive"),
+		),
+	)
+	return ctx, span, payload
+}
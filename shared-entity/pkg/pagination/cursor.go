@@ -0,0 +1,63 @@
+// Package pagination implements opaque keyset cursors for newest-first feeds.
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidCursor is returned for cursors not produced by EncodeCursor
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor is a keyset position in a feed ordered newest first by
+// created_at, with _id breaking ties between posts created together.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        primitive.ObjectID
+}
+
+// Sort is the order cursors page through
+var Sort = bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+
+// EncodeCursor returns the opaque cursor for the page after this post
+func EncodeCursor(createdAt time.Time, id primitive.ObjectID) string {
+	raw := strconv.FormatInt(createdAt.UnixMilli(), 10) + ":" + id.Hex()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor
+func DecodeCursor(cursor string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	millis, hexID, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	ms, err := strconv.ParseInt(millis, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{CreatedAt: time.UnixMilli(ms).UTC(), ID: id}, nil
+}
+
+// Filter matches the posts that come after the cursor in Sort order
+func (c *Cursor) Filter() bson.M {
+	// Mongo stores dates with millisecond precision, which the cursor preserves
+	createdAt := primitive.NewDateTimeFromTime(c.CreatedAt)
+	return bson.M{"$or": []bson.M{
+		{"created_at": bson.M{"$lt": createdAt}},
+		{"created_at": createdAt, "_id": bson.M{"$lt": c.ID}},
+	}}
+}
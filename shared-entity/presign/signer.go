@@ -0,0 +1,227 @@
+// Package presign signs storage object URLs in batches.
+//
+// Rendering a list of events or posts needs a presigned URL for every avatar
+// and cover image. Signing them one RPC at a time fans out into hundreds of
+// calls per response; the Signer instead gathers concurrent requests made
+// within a short window into a single BatchGetPresignedURLs call and keeps the
+// results in an in-process LRU cache.
+package presign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/cache"
+)
+
+// ErrNotSigned is returned by Sign when storage could not sign the key
+var ErrNotSigned = errors.New("presign: key could not be signed")
+
+// FetchFunc signs a batch of keys. The result is keyed by the requested key
+// and may leave out keys that could not be signed.
+type FetchFunc func(ctx context.Context, keys []string, expiry time.Duration) (map[string]string, error)
+
+// Options configures a Signer
+type Options struct {
+	CacheSize int           // Signed URLs kept in memory
+	Window    time.Duration // How long requests are gathered before a batch is sent
+	MaxBatch  int           // Keys per batch; a full batch is sent immediately
+	Timeout   time.Duration // Per batch
+}
+
+func DefaultOptions() Options {
+	return Options{
+		CacheSize: 10000,
+		Window:    2 * time.Millisecond,
+		MaxBatch:  500,
+		Timeout:   5 * time.Second,
+	}
+}
+
+// Signer coalesces presign requests into batches and caches the results.
+// A cached URL is reused only within the expiry bucket it was signed in, so
+// every URL handed out stays valid for at least half of the requested expiry.
+type Signer struct {
+	fetch FetchFunc
+	opts  Options
+	cache *cache.LRU[string, string]
+
+	mu       sync.Mutex
+	pending  map[time.Duration]*batch // Batch still gathering keys, per expiry
+	inflight map[string]*batch        // Batch that will sign each cache key
+}
+
+type batch struct {
+	expiry time.Duration
+	keys   []string // As requested
+	ids    []string // Cache keys, parallel to keys
+	timer  *time.Timer
+	sent   bool
+
+	done chan struct{}
+	urls map[string]string // By cache key
+	err  error
+}
+
+func NewSigner(fetch FetchFunc, opts Options) *Signer {
+	defaults := DefaultOptions()
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = defaults.CacheSize
+	}
+	if opts.Window <= 0 {
+		opts.Window = defaults.Window
+	}
+	if opts.MaxBatch <= 0 {
+		opts.MaxBatch = defaults.MaxBatch
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaults.Timeout
+	}
+
+	return &Signer{
+		fetch:    fetch,
+		opts:     opts,
+		cache:    cache.NewLRU[string, string](opts.CacheSize),
+		pending:  make(map[time.Duration]*batch),
+		inflight: make(map[string]*batch),
+	}
+}
+
+// Sign returns a presigned URL for a single object key or file URL
+func (s *Signer) Sign(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	urls, err := s.SignMany(ctx, []string{key}, expiry)
+	if signed, ok := urls[key]; ok {
+		return signed, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return "", ErrNotSigned
+}
+
+// SignMany returns presigned URLs keyed by the requested keys. Keys that
+// could not be signed are left out; the error reports a failed batch.
+func (s *Signer) SignMany(ctx context.Context, keys []string, expiry time.Duration) (map[string]string, error) {
+	urls := make(map[string]string, len(keys))
+	waiting := make(map[string]*batch) // requested key -> batch signing it
+	var full []*batch
+
+	now := time.Now()
+	s.mu.Lock()
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if _, seen := urls[key]; seen {
+			continue
+		}
+		if _, seen := waiting[key]; seen {
+			continue
+		}
+
+		id := cacheKey(key, expiry, now)
+		if signed, ok := s.cache.Get(id); ok {
+			urls[key] = signed
+			continue
+		}
+		if b, ok := s.inflight[id]; ok {
+			waiting[key] = b
+			continue
+		}
+
+		b := s.pending[expiry]
+		if b == nil {
+			b = &batch{expiry: expiry, done: make(chan struct{})}
+			b.timer = time.AfterFunc(s.opts.Window, func() { s.send(b) })
+			s.pending[expiry] = b
+		}
+		b.keys = append(b.keys, key)
+		b.ids = append(b.ids, id)
+		s.inflight[id] = b
+		waiting[key] = b
+
+		if len(b.keys) >= s.opts.MaxBatch {
+			delete(s.pending, expiry)
+			full = append(full, b)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, b := range full {
+		go s.send(b)
+	}
+
+	var errs []error
+	for key, b := range waiting {
+		select {
+		case <-b.done:
+		case <-ctx.Done():
+			return urls, ctx.Err()
+		}
+		if signed, ok := b.urls[cacheKey(key, expiry, now)]; ok {
+			urls[key] = signed
+		} else if b.err != nil {
+			errs = append(errs, b.err)
+		}
+	}
+	return urls, errors.Join(errs...)
+}
+
+// send fetches a batch once, whether its window closed or it filled up
+func (s *Signer) send(b *batch) {
+	s.mu.Lock()
+	if b.sent {
+		s.mu.Unlock()
+		return
+	}
+	b.sent = true
+	if s.pending[b.expiry] == b {
+		delete(s.pending, b.expiry)
+	}
+	s.mu.Unlock()
+	b.timer.Stop()
+
+	// The batch serves many callers, so it must not die with any one of them
+	ctx, cancel := context.WithTimeout(context.Background(), s.opts.Timeout)
+	defer cancel()
+
+	urls, err := s.fetch(ctx, b.keys, b.expiry)
+	b.err = err
+	b.urls = make(map[string]string, len(urls))
+	for i, key := range b.keys {
+		if signed, ok := urls[key]; ok {
+			b.urls[b.ids[i]] = signed
+			s.cache.Add(b.ids[i], signed)
+		}
+	}
+
+	s.mu.Lock()
+	for _, id := range b.ids {
+		if s.inflight[id] == b {
+			delete(s.inflight, id)
+		}
+	}
+	s.mu.Unlock()
+	close(b.done)
+}
+
+// cacheKey identifies a signed URL by object path, expiry and the expiry
+// bucket it was signed in. Buckets are half the expiry wide, so a URL is
+// dropped from use once half its lifetime has passed.
+func cacheKey(key string, expiry time.Duration, now time.Time) string {
+	width := max(expiry/2, time.Second)
+	return fmt.Sprintf("%s|%d|%d", objectPath(key), int64(expiry/time.Second), now.UnixNano()/int64(width))
+}
+
+// objectPath drops the scheme, host and query of file URLs so the same object
+// shares a cache entry however it is addressed
+func objectPath(key string) string {
+	if u, err := url.Parse(key); err == nil && u.Host != "" {
+		return u.Path
+	}
+	return key
+}
@@ -29,6 +29,7 @@ type UserShort struct {
 	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
 	FullName      string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
 	Avatar        string                 `protobuf:"bytes,4,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Verified      bool                   `protobuf:"varint,5,opt,name=verified,proto3" json:"verified,omitempty"` // Identity verified badge
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -91,6 +92,13 @@ func (x *UserShort) GetAvatar() string {
 	return ""
 }
 
+func (x *UserShort) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
 type EventStats struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	GoingCount      int64                  `protobuf:"varint,1,opt,name=going_count,json=goingCount,proto3" json:"going_count,omitempty"`
@@ -3171,12 +3179,13 @@ var File_proto_events_v1_events_proto protoreflect.FileDescriptor
 
 const file_proto_events_v1_events_proto_rawDesc = "" +
 	"\n" +
-	"\x1cproto/events/v1/events.proto\x12\tevents.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"l\n" +
+	"\x1cproto/events/v1/events.proto\x12\tevents.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x88\x01\n" +
 	"\tUserShort\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
 	"\tfull_name\x18\x03 \x01(\tR\bfullName\x12\x16\n" +
-	"\x06avatar\x18\x04 \x01(\tR\x06avatar\"\x9e\x01\n" +
+	"\x06avatar\x18\x04 \x01(\tR\x06avatar\x12\x1a\n" +
+	"\bverified\x18\x05 \x01(\bR\bverified\"\x9e\x01\n" +
 	"\n" +
 	"EventStats\x12\x1f\n" +
 	"\vgoing_count\x18\x01 \x01(\x03R\n" +
@@ -3463,7 +3472,7 @@ const file_proto_events_v1_events_proto_rawDesc = "" +
 	"\x0fGetNearbyEvents\x12\x1e.events.v1.NearbyEventsRequest\x1a\x1d.events.v1.ListEventsResponse\x12Y\n" +
 	"\x12GetRecommendations\x12 .events.v1.RecommendationRequest\x1a!.events.v1.RecommendationResponse\x12R\n" +
 	"\vGetTrending\x12 .events.v1.TrendingEventsRequest\x1a!.events.v1.TrendingEventsResponse\x12L\n" +
-	"\x0fReportRSVPEvent\x12!.events.v1.ReportRSVPEventRequest\x1a\x16.google.protobuf.EmptyBCZAgithub.com/MuhibNayem/connectify-v2/shared-entity/proto/events/v1;eventspbb\x06proto3"
+	"\x0fReportRSVPEvent\x12!.events.v1.ReportRSVPEventRequest\x1a\x16.google.protobuf.EmptyBCZAgitlab.com/spydotech-group/shared-entity/proto/events/v1;eventspbb\x06proto3"
 
 var (
 	file_proto_events_v1_events_proto_rawDescOnce sync.Once
@@ -0,0 +1,259 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: proto/export/v1/export.proto
+
+package exportpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_proto_export_v1_export_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_export_v1_export_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_export_v1_export_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Sections      []*ExportSection       `protobuf:"bytes,2,rep,name=sections,proto3" json:"sections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_proto_export_v1_export_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_export_v1_export_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_export_v1_export_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExportUserDataResponse) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *ExportUserDataResponse) GetSections() []*ExportSection {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+// ExportSection becomes one JSON file in the service's folder of the archive
+type ExportSection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                  // File name without extension, e.g. "posts"
+	RecordsJson   []byte                 `protobuf:"bytes,2,opt,name=records_json,json=recordsJson,proto3" json:"records_json,omitempty"` // JSON array of the section's records
+	RecordCount   int64                  `protobuf:"varint,3,opt,name=record_count,json=recordCount,proto3" json:"record_count,omitempty"`
+	MediaUrls     []string               `protobuf:"bytes,4,rep,name=media_urls,json=mediaUrls,proto3" json:"media_urls,omitempty"` // Media the records reference, copied into the archive
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSection) Reset() {
+	*x = ExportSection{}
+	mi := &file_proto_export_v1_export_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSection) ProtoMessage() {}
+
+func (x *ExportSection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_export_v1_export_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSection.ProtoReflect.Descriptor instead.
+func (*ExportSection) Descriptor() ([]byte, []int) {
+	return file_proto_export_v1_export_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExportSection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExportSection) GetRecordsJson() []byte {
+	if x != nil {
+		return x.RecordsJson
+	}
+	return nil
+}
+
+func (x *ExportSection) GetRecordCount() int64 {
+	if x != nil {
+		return x.RecordCount
+	}
+	return 0
+}
+
+func (x *ExportSection) GetMediaUrls() []string {
+	if x != nil {
+		return x.MediaUrls
+	}
+	return nil
+}
+
+var File_proto_export_v1_export_proto protoreflect.FileDescriptor
+
+const file_proto_export_v1_export_proto_rawDesc = "" +
+	"\n" +
+	"\x1cproto/export/v1/export.proto\x12\texport.v1\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"h\n" +
+	"\x16ExportUserDataResponse\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x124\n" +
+	"\bsections\x18\x02 \x03(\v2\x18.export.v1.ExportSectionR\bsections\"\x88\x01\n" +
+	"\rExportSection\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\frecords_json\x18\x02 \x01(\fR\vrecordsJson\x12!\n" +
+	"\frecord_count\x18\x03 \x01(\x03R\vrecordCount\x12\x1d\n" +
+	"\n" +
+	"media_urls\x18\x04 \x03(\tR\tmediaUrls2j\n" +
+	"\x11DataExportService\x12U\n" +
+	"\x0eExportUserData\x12 .export.v1.ExportUserDataRequest\x1a!.export.v1.ExportUserDataResponseBLZJgithub.com/MuhibNayem/connectify-v2/shared-entity/proto/export/v1;exportpbb\x06proto3"
+
+var (
+	file_proto_export_v1_export_proto_rawDescOnce sync.Once
+	file_proto_export_v1_export_proto_rawDescData []byte
+)
+
+func file_proto_export_v1_export_proto_rawDescGZIP() []byte {
+	file_proto_export_v1_export_proto_rawDescOnce.Do(func() {
+		file_proto_export_v1_export_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_export_v1_export_proto_rawDesc), len(file_proto_export_v1_export_proto_rawDesc)))
+	})
+	return file_proto_export_v1_export_proto_rawDescData
+}
+
+var file_proto_export_v1_export_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_export_v1_export_proto_goTypes = []any{
+	(*ExportUserDataRequest)(nil),  // 0: export.v1.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil), // 1: export.v1.ExportUserDataResponse
+	(*ExportSection)(nil),          // 2: export.v1.ExportSection
+}
+var file_proto_export_v1_export_proto_depIdxs = []int32{
+	2, // 0: export.v1.ExportUserDataResponse.sections:type_name -> export.v1.ExportSection
+	0, // 1: export.v1.DataExportService.ExportUserData:input_type -> export.v1.ExportUserDataRequest
+	1, // 2: export.v1.DataExportService.ExportUserData:output_type -> export.v1.ExportUserDataResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_export_v1_export_proto_init() }
+func file_proto_export_v1_export_proto_init() {
+	if File_proto_export_v1_export_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_export_v1_export_proto_rawDesc), len(file_proto_export_v1_export_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_export_v1_export_proto_goTypes,
+		DependencyIndexes: file_proto_export_v1_export_proto_depIdxs,
+		MessageInfos:      file_proto_export_v1_export_proto_msgTypes,
+	}.Build()
+	File_proto_export_v1_export_proto = out.File
+	file_proto_export_v1_export_proto_goTypes = nil
+	file_proto_export_v1_export_proto_depIdxs = nil
+}
@@ -42,8 +42,13 @@ type PostResponse struct {
 	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	Author         *PostAuthor            `protobuf:"bytes,16,opt,name=author,proto3" json:"author,omitempty"`
 	MentionedUsers []*PostAuthor          `protobuf:"bytes,17,rep,name=mentioned_users,json=mentionedUsers,proto3" json:"mentioned_users,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	TotalShares    int64                  `protobuf:"varint,18,opt,name=total_shares,json=totalShares,proto3" json:"total_shares,omitempty"`
+	// Set on reshares
+	SharedFrom *PostShare `protobuf:"bytes,19,opt,name=shared_from,json=sharedFrom,proto3" json:"shared_from,omitempty"`
+	// Card of the first link in the content, once unfurled
+	LinkPreview   *LinkPreview `protobuf:"bytes,20,opt,name=link_preview,json=linkPreview,proto3" json:"link_preview,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PostResponse) Reset() {
@@ -195,6 +200,222 @@ func (x *PostResponse) GetMentionedUsers() []*PostAuthor {
 	return nil
 }
 
+func (x *PostResponse) GetTotalShares() int64 {
+	if x != nil {
+		return x.TotalShares
+	}
+	return 0
+}
+
+func (x *PostResponse) GetSharedFrom() *PostShare {
+	if x != nil {
+		return x.SharedFrom
+	}
+	return nil
+}
+
+func (x *PostResponse) GetLinkPreview() *LinkPreview {
+	if x != nil {
+		return x.LinkPreview
+	}
+	return nil
+}
+
+// Provenance of a reshared post. Original fields are empty when the
+// original is no longer available.
+type PostShare struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PostId            string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	OriginalPostId    string                 `protobuf:"bytes,2,opt,name=original_post_id,json=originalPostId,proto3" json:"original_post_id,omitempty"`
+	OriginalAuthorId  string                 `protobuf:"bytes,3,opt,name=original_author_id,json=originalAuthorId,proto3" json:"original_author_id,omitempty"`
+	Chain             []string               `protobuf:"bytes,4,rep,name=chain,proto3" json:"chain,omitempty"`
+	Available         bool                   `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"`
+	OriginalAuthor    *PostAuthor            `protobuf:"bytes,6,opt,name=original_author,json=originalAuthor,proto3" json:"original_author,omitempty"`
+	OriginalContent   string                 `protobuf:"bytes,7,opt,name=original_content,json=originalContent,proto3" json:"original_content,omitempty"`
+	OriginalMedia     []*MediaItem           `protobuf:"bytes,8,rep,name=original_media,json=originalMedia,proto3" json:"original_media,omitempty"`
+	OriginalCreatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=original_created_at,json=originalCreatedAt,proto3" json:"original_created_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PostShare) Reset() {
+	*x = PostShare{}
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostShare) ProtoMessage() {}
+
+func (x *PostShare) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostShare.ProtoReflect.Descriptor instead.
+func (*PostShare) Descriptor() ([]byte, []int) {
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PostShare) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostShare) GetOriginalPostId() string {
+	if x != nil {
+		return x.OriginalPostId
+	}
+	return ""
+}
+
+func (x *PostShare) GetOriginalAuthorId() string {
+	if x != nil {
+		return x.OriginalAuthorId
+	}
+	return ""
+}
+
+func (x *PostShare) GetChain() []string {
+	if x != nil {
+		return x.Chain
+	}
+	return nil
+}
+
+func (x *PostShare) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *PostShare) GetOriginalAuthor() *PostAuthor {
+	if x != nil {
+		return x.OriginalAuthor
+	}
+	return nil
+}
+
+func (x *PostShare) GetOriginalContent() string {
+	if x != nil {
+		return x.OriginalContent
+	}
+	return ""
+}
+
+func (x *PostShare) GetOriginalMedia() []*MediaItem {
+	if x != nil {
+		return x.OriginalMedia
+	}
+	return nil
+}
+
+func (x *PostShare) GetOriginalCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OriginalCreatedAt
+	}
+	return nil
+}
+
+// Open Graph card of a link
+type LinkPreview struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	SiteName      string                 `protobuf:"bytes,5,opt,name=site_name,json=siteName,proto3" json:"site_name,omitempty"`
+	FetchedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkPreview) Reset() {
+	*x = LinkPreview{}
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkPreview) ProtoMessage() {}
+
+func (x *LinkPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkPreview.ProtoReflect.Descriptor instead.
+func (*LinkPreview) Descriptor() ([]byte, []int) {
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LinkPreview) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetSiteName() string {
+	if x != nil {
+		return x.SiteName
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetFetchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return nil
+}
+
 type MediaItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
@@ -205,7 +426,7 @@ type MediaItem struct {
 
 func (x *MediaItem) Reset() {
 	*x = MediaItem{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[1]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -217,7 +438,7 @@ func (x *MediaItem) String() string {
 func (*MediaItem) ProtoMessage() {}
 
 func (x *MediaItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[1]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -230,7 +451,7 @@ func (x *MediaItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MediaItem.ProtoReflect.Descriptor instead.
 func (*MediaItem) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{1}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *MediaItem) GetUrl() string {
@@ -253,13 +474,14 @@ type PostAuthor struct {
 	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
 	Avatar        string                 `protobuf:"bytes,3,opt,name=avatar,proto3" json:"avatar,omitempty"`
 	FullName      string                 `protobuf:"bytes,4,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Verified      bool                   `protobuf:"varint,5,opt,name=verified,proto3" json:"verified,omitempty"` // Identity verified badge
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PostAuthor) Reset() {
 	*x = PostAuthor{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[2]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -271,7 +493,7 @@ func (x *PostAuthor) String() string {
 func (*PostAuthor) ProtoMessage() {}
 
 func (x *PostAuthor) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[2]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -284,7 +506,7 @@ func (x *PostAuthor) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostAuthor.ProtoReflect.Descriptor instead.
 func (*PostAuthor) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{2}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *PostAuthor) GetId() string {
@@ -315,6 +537,13 @@ func (x *PostAuthor) GetFullName() string {
 	return ""
 }
 
+func (x *PostAuthor) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
 type CommentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -333,7 +562,7 @@ type CommentResponse struct {
 
 func (x *CommentResponse) Reset() {
 	*x = CommentResponse{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[3]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -345,7 +574,7 @@ func (x *CommentResponse) String() string {
 func (*CommentResponse) ProtoMessage() {}
 
 func (x *CommentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[3]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -358,7 +587,7 @@ func (x *CommentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommentResponse.ProtoReflect.Descriptor instead.
 func (*CommentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{3}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CommentResponse) GetId() string {
@@ -448,7 +677,7 @@ type ReplyResponse struct {
 
 func (x *ReplyResponse) Reset() {
 	*x = ReplyResponse{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[4]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -460,7 +689,7 @@ func (x *ReplyResponse) String() string {
 func (*ReplyResponse) ProtoMessage() {}
 
 func (x *ReplyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[4]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -473,7 +702,7 @@ func (x *ReplyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReplyResponse.ProtoReflect.Descriptor instead.
 func (*ReplyResponse) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{4}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ReplyResponse) GetId() string {
@@ -540,18 +769,22 @@ func (x *ReplyResponse) GetAuthor() *PostAuthor {
 }
 
 type FeedResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Posts         []*PostResponse        `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
-	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int64                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int64                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Posts []*PostResponse        `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	Total int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page  int64                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit int64                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Opaque cursor for the next page; empty on the last page
+	NextCursor string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// Order the posts are in, "ranked" or "chronological"
+	Order         string `protobuf:"bytes,6,opt,name=order,proto3" json:"order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FeedResponse) Reset() {
 	*x = FeedResponse{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[5]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -563,7 +796,7 @@ func (x *FeedResponse) String() string {
 func (*FeedResponse) ProtoMessage() {}
 
 func (x *FeedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[5]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -576,7 +809,7 @@ func (x *FeedResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FeedResponse.ProtoReflect.Descriptor instead.
 func (*FeedResponse) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{5}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *FeedResponse) GetPosts() []*PostResponse {
@@ -607,6 +840,20 @@ func (x *FeedResponse) GetLimit() int64 {
 	return 0
 }
 
+func (x *FeedResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *FeedResponse) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
 type ListCommentsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Comments      []*CommentResponse     `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
@@ -616,7 +863,7 @@ type ListCommentsResponse struct {
 
 func (x *ListCommentsResponse) Reset() {
 	*x = ListCommentsResponse{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[6]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -628,7 +875,7 @@ func (x *ListCommentsResponse) String() string {
 func (*ListCommentsResponse) ProtoMessage() {}
 
 func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[6]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -641,7 +888,7 @@ func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
 func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{6}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListCommentsResponse) GetComments() []*CommentResponse {
@@ -660,7 +907,7 @@ type ListRepliesResponse struct {
 
 func (x *ListRepliesResponse) Reset() {
 	*x = ListRepliesResponse{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[7]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -672,7 +919,7 @@ func (x *ListRepliesResponse) String() string {
 func (*ListRepliesResponse) ProtoMessage() {}
 
 func (x *ListRepliesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[7]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -685,7 +932,7 @@ func (x *ListRepliesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRepliesResponse.ProtoReflect.Descriptor instead.
 func (*ListRepliesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{7}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListRepliesResponse) GetReplies() []*ReplyResponse {
@@ -710,7 +957,7 @@ type AlbumResponse struct {
 
 func (x *AlbumResponse) Reset() {
 	*x = AlbumResponse{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[8]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -722,7 +969,7 @@ func (x *AlbumResponse) String() string {
 func (*AlbumResponse) ProtoMessage() {}
 
 func (x *AlbumResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[8]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -735,7 +982,7 @@ func (x *AlbumResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AlbumResponse.ProtoReflect.Descriptor instead.
 func (*AlbumResponse) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{8}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AlbumResponse) GetId() string {
@@ -801,7 +1048,7 @@ type AlbumMediaResponse struct {
 
 func (x *AlbumMediaResponse) Reset() {
 	*x = AlbumMediaResponse{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[9]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -813,7 +1060,7 @@ func (x *AlbumMediaResponse) String() string {
 func (*AlbumMediaResponse) ProtoMessage() {}
 
 func (x *AlbumMediaResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[9]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -826,7 +1073,7 @@ func (x *AlbumMediaResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AlbumMediaResponse.ProtoReflect.Descriptor instead.
 func (*AlbumMediaResponse) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{9}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *AlbumMediaResponse) GetId() string {
@@ -880,7 +1127,7 @@ type ListAlbumsResponse struct {
 
 func (x *ListAlbumsResponse) Reset() {
 	*x = ListAlbumsResponse{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[10]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -892,7 +1139,7 @@ func (x *ListAlbumsResponse) String() string {
 func (*ListAlbumsResponse) ProtoMessage() {}
 
 func (x *ListAlbumsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[10]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -905,7 +1152,7 @@ func (x *ListAlbumsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAlbumsResponse.ProtoReflect.Descriptor instead.
 func (*ListAlbumsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{10}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ListAlbumsResponse) GetAlbums() []*AlbumResponse {
@@ -924,7 +1171,7 @@ type GetAlbumMediaResponse struct {
 
 func (x *GetAlbumMediaResponse) Reset() {
 	*x = GetAlbumMediaResponse{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[11]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -936,7 +1183,7 @@ func (x *GetAlbumMediaResponse) String() string {
 func (*GetAlbumMediaResponse) ProtoMessage() {}
 
 func (x *GetAlbumMediaResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[11]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -949,7 +1196,7 @@ func (x *GetAlbumMediaResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAlbumMediaResponse.ProtoReflect.Descriptor instead.
 func (*GetAlbumMediaResponse) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{11}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetAlbumMediaResponse) GetMedia() []*AlbumMediaResponse {
@@ -976,7 +1223,7 @@ type CreatePostRequest struct {
 
 func (x *CreatePostRequest) Reset() {
 	*x = CreatePostRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[12]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -988,7 +1235,7 @@ func (x *CreatePostRequest) String() string {
 func (*CreatePostRequest) ProtoMessage() {}
 
 func (x *CreatePostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[12]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1001,7 +1248,7 @@ func (x *CreatePostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePostRequest.ProtoReflect.Descriptor instead.
 func (*CreatePostRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{12}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *CreatePostRequest) GetUserId() string {
@@ -1077,7 +1324,7 @@ type GetPostRequest struct {
 
 func (x *GetPostRequest) Reset() {
 	*x = GetPostRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[13]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1089,7 +1336,7 @@ func (x *GetPostRequest) String() string {
 func (*GetPostRequest) ProtoMessage() {}
 
 func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[13]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1102,7 +1349,7 @@ func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostRequest.ProtoReflect.Descriptor instead.
 func (*GetPostRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{13}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetPostRequest) GetPostId() string {
@@ -1136,7 +1383,7 @@ type UpdatePostRequest struct {
 
 func (x *UpdatePostRequest) Reset() {
 	*x = UpdatePostRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[14]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1148,7 +1395,7 @@ func (x *UpdatePostRequest) String() string {
 func (*UpdatePostRequest) ProtoMessage() {}
 
 func (x *UpdatePostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[14]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1161,7 +1408,7 @@ func (x *UpdatePostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePostRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePostRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{14}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *UpdatePostRequest) GetPostId() string {
@@ -1237,7 +1484,7 @@ type DeletePostRequest struct {
 
 func (x *DeletePostRequest) Reset() {
 	*x = DeletePostRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[15]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1248,8 +1495,273 @@ func (x *DeletePostRequest) String() string {
 
 func (*DeletePostRequest) ProtoMessage() {}
 
-func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[15]
+func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePostRequest.ProtoReflect.Descriptor instead.
+func (*DeletePostRequest) Descriptor() ([]byte, []int) {
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeletePostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *DeletePostRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type SharePostRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PostId         string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Content        string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Privacy        string                 `protobuf:"bytes,4,opt,name=privacy,proto3" json:"privacy,omitempty"`
+	CustomAudience []string               `protobuf:"bytes,5,rep,name=custom_audience,json=customAudience,proto3" json:"custom_audience,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SharePostRequest) Reset() {
+	*x = SharePostRequest{}
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharePostRequest) ProtoMessage() {}
+
+func (x *SharePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharePostRequest.ProtoReflect.Descriptor instead.
+func (*SharePostRequest) Descriptor() ([]byte, []int) {
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SharePostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *SharePostRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SharePostRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SharePostRequest) GetPrivacy() string {
+	if x != nil {
+		return x.Privacy
+	}
+	return ""
+}
+
+func (x *SharePostRequest) GetCustomAudience() []string {
+	if x != nil {
+		return x.CustomAudience
+	}
+	return nil
+}
+
+type ListPostsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ViewerId     string                 `protobuf:"bytes,1,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	FilterUserId string                 `protobuf:"bytes,2,opt,name=filter_user_id,json=filterUserId,proto3" json:"filter_user_id,omitempty"`
+	CommunityId  string                 `protobuf:"bytes,3,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	Page         int64                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Limit        int64                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	SortBy       string                 `protobuf:"bytes,6,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	SortOrder    string                 `protobuf:"bytes,7,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	HasMedia     bool                   `protobuf:"varint,8,opt,name=has_media,json=hasMedia,proto3" json:"has_media,omitempty"`
+	MediaType    string                 `protobuf:"bytes,9,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	Status       string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	// Opaque cursor from a previous FeedResponse. When set, page is ignored
+	Cursor string `protobuf:"bytes,11,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// "ranked" or "chronological"; empty follows the feed.ranked feature flag.
+	// Cursors only page the chronological feed
+	Order         string `protobuf:"bytes,12,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPostsRequest) Reset() {
+	*x = ListPostsRequest{}
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostsRequest) ProtoMessage() {}
+
+func (x *ListPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostsRequest.ProtoReflect.Descriptor instead.
+func (*ListPostsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListPostsRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+func (x *ListPostsRequest) GetFilterUserId() string {
+	if x != nil {
+		return x.FilterUserId
+	}
+	return ""
+}
+
+func (x *ListPostsRequest) GetCommunityId() string {
+	if x != nil {
+		return x.CommunityId
+	}
+	return ""
+}
+
+func (x *ListPostsRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPostsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPostsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListPostsRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListPostsRequest) GetHasMedia() bool {
+	if x != nil {
+		return x.HasMedia
+	}
+	return false
+}
+
+func (x *ListPostsRequest) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *ListPostsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListPostsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListPostsRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+type GetPostsByHashtagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ViewerId      string                 `protobuf:"bytes,1,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	Hashtag       string                 `protobuf:"bytes,2,opt,name=hashtag,proto3" json:"hashtag,omitempty"`
+	Page          int64                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int64                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostsByHashtagRequest) Reset() {
+	*x = GetPostsByHashtagRequest{}
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostsByHashtagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostsByHashtagRequest) ProtoMessage() {}
+
+func (x *GetPostsByHashtagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1260,56 +1772,64 @@ func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletePostRequest.ProtoReflect.Descriptor instead.
-func (*DeletePostRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use GetPostsByHashtagRequest.ProtoReflect.Descriptor instead.
+func (*GetPostsByHashtagRequest) Descriptor() ([]byte, []int) {
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *DeletePostRequest) GetPostId() string {
+func (x *GetPostsByHashtagRequest) GetViewerId() string {
 	if x != nil {
-		return x.PostId
+		return x.ViewerId
 	}
 	return ""
 }
 
-func (x *DeletePostRequest) GetUserId() string {
+func (x *GetPostsByHashtagRequest) GetHashtag() string {
 	if x != nil {
-		return x.UserId
+		return x.Hashtag
 	}
 	return ""
 }
 
-type ListPostsRequest struct {
+func (x *GetPostsByHashtagRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetPostsByHashtagRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Region and window are optional; empty means worldwide over the default window
+type GetTrendingHashtagsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ViewerId      string                 `protobuf:"bytes,1,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
-	FilterUserId  string                 `protobuf:"bytes,2,opt,name=filter_user_id,json=filterUserId,proto3" json:"filter_user_id,omitempty"`
-	CommunityId   string                 `protobuf:"bytes,3,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
-	Page          int64                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int64                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
-	SortBy        string                 `protobuf:"bytes,6,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
-	SortOrder     string                 `protobuf:"bytes,7,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
-	HasMedia      bool                   `protobuf:"varint,8,opt,name=has_media,json=hasMedia,proto3" json:"has_media,omitempty"`
-	MediaType     string                 `protobuf:"bytes,9,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
-	Status        string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	Region        string                 `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	Window        string                 `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPostsRequest) Reset() {
-	*x = ListPostsRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[16]
+func (x *GetTrendingHashtagsRequest) Reset() {
+	*x = GetTrendingHashtagsRequest{}
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPostsRequest) String() string {
+func (x *GetTrendingHashtagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPostsRequest) ProtoMessage() {}
+func (*GetTrendingHashtagsRequest) ProtoMessage() {}
 
-func (x *ListPostsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[16]
+func (x *GetTrendingHashtagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1320,106 +1840,108 @@ func (x *ListPostsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPostsRequest.ProtoReflect.Descriptor instead.
-func (*ListPostsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use GetTrendingHashtagsRequest.ProtoReflect.Descriptor instead.
+func (*GetTrendingHashtagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ListPostsRequest) GetViewerId() string {
+func (x *GetTrendingHashtagsRequest) GetRegion() string {
 	if x != nil {
-		return x.ViewerId
+		return x.Region
 	}
 	return ""
 }
 
-func (x *ListPostsRequest) GetFilterUserId() string {
+func (x *GetTrendingHashtagsRequest) GetWindow() string {
 	if x != nil {
-		return x.FilterUserId
+		return x.Window
 	}
 	return ""
 }
 
-func (x *ListPostsRequest) GetCommunityId() string {
+func (x *GetTrendingHashtagsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.CommunityId
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *ListPostsRequest) GetPage() int64 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
+type TrendingHashtag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPostsRequest) GetLimit() int64 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
+func (x *TrendingHashtag) Reset() {
+	*x = TrendingHashtag{}
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPostsRequest) GetSortBy() string {
-	if x != nil {
-		return x.SortBy
-	}
-	return ""
+func (x *TrendingHashtag) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *ListPostsRequest) GetSortOrder() string {
+func (*TrendingHashtag) ProtoMessage() {}
+
+func (x *TrendingHashtag) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[22]
 	if x != nil {
-		return x.SortOrder
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ListPostsRequest) GetHasMedia() bool {
-	if x != nil {
-		return x.HasMedia
-	}
-	return false
+// Deprecated: Use TrendingHashtag.ProtoReflect.Descriptor instead.
+func (*TrendingHashtag) Descriptor() ([]byte, []int) {
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *ListPostsRequest) GetMediaType() string {
+func (x *TrendingHashtag) GetTag() string {
 	if x != nil {
-		return x.MediaType
+		return x.Tag
 	}
 	return ""
 }
 
-func (x *ListPostsRequest) GetStatus() string {
+func (x *TrendingHashtag) GetScore() float64 {
 	if x != nil {
-		return x.Status
+		return x.Score
 	}
-	return ""
+	return 0
 }
 
-type GetPostsByHashtagRequest struct {
+type GetTrendingHashtagsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ViewerId      string                 `protobuf:"bytes,1,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
-	Hashtag       string                 `protobuf:"bytes,2,opt,name=hashtag,proto3" json:"hashtag,omitempty"`
-	Page          int64                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int64                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Hashtags      []*TrendingHashtag     `protobuf:"bytes,1,rep,name=hashtags,proto3" json:"hashtags,omitempty"`
+	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	Window        string                 `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPostsByHashtagRequest) Reset() {
-	*x = GetPostsByHashtagRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[17]
+func (x *GetTrendingHashtagsResponse) Reset() {
+	*x = GetTrendingHashtagsResponse{}
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPostsByHashtagRequest) String() string {
+func (x *GetTrendingHashtagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPostsByHashtagRequest) ProtoMessage() {}
+func (*GetTrendingHashtagsResponse) ProtoMessage() {}
 
-func (x *GetPostsByHashtagRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[17]
+func (x *GetTrendingHashtagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1430,37 +1952,30 @@ func (x *GetPostsByHashtagRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPostsByHashtagRequest.ProtoReflect.Descriptor instead.
-func (*GetPostsByHashtagRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use GetTrendingHashtagsResponse.ProtoReflect.Descriptor instead.
+func (*GetTrendingHashtagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *GetPostsByHashtagRequest) GetViewerId() string {
+func (x *GetTrendingHashtagsResponse) GetHashtags() []*TrendingHashtag {
 	if x != nil {
-		return x.ViewerId
+		return x.Hashtags
 	}
-	return ""
+	return nil
 }
 
-func (x *GetPostsByHashtagRequest) GetHashtag() string {
+func (x *GetTrendingHashtagsResponse) GetRegion() string {
 	if x != nil {
-		return x.Hashtag
+		return x.Region
 	}
 	return ""
 }
 
-func (x *GetPostsByHashtagRequest) GetPage() int64 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
-}
-
-func (x *GetPostsByHashtagRequest) GetLimit() int64 {
+func (x *GetTrendingHashtagsResponse) GetWindow() string {
 	if x != nil {
-		return x.Limit
+		return x.Window
 	}
-	return 0
+	return ""
 }
 
 type UpdatePostStatusRequest struct {
@@ -1474,7 +1989,7 @@ type UpdatePostStatusRequest struct {
 
 func (x *UpdatePostStatusRequest) Reset() {
 	*x = UpdatePostStatusRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[18]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1486,7 +2001,7 @@ func (x *UpdatePostStatusRequest) String() string {
 func (*UpdatePostStatusRequest) ProtoMessage() {}
 
 func (x *UpdatePostStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[18]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1499,7 +2014,7 @@ func (x *UpdatePostStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePostStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePostStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{18}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *UpdatePostStatusRequest) GetPostId() string {
@@ -1537,7 +2052,7 @@ type CreateCommentRequest struct {
 
 func (x *CreateCommentRequest) Reset() {
 	*x = CreateCommentRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[19]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1549,7 +2064,7 @@ func (x *CreateCommentRequest) String() string {
 func (*CreateCommentRequest) ProtoMessage() {}
 
 func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[19]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1562,7 +2077,7 @@ func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateCommentRequest.ProtoReflect.Descriptor instead.
 func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{19}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *CreateCommentRequest) GetPostId() string {
@@ -1616,7 +2131,7 @@ type GetCommentRequest struct {
 
 func (x *GetCommentRequest) Reset() {
 	*x = GetCommentRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[20]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1628,7 +2143,7 @@ func (x *GetCommentRequest) String() string {
 func (*GetCommentRequest) ProtoMessage() {}
 
 func (x *GetCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[20]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1641,7 +2156,7 @@ func (x *GetCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCommentRequest.ProtoReflect.Descriptor instead.
 func (*GetCommentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{20}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GetCommentRequest) GetCommentId() string {
@@ -1663,7 +2178,7 @@ type UpdateCommentRequest struct {
 
 func (x *UpdateCommentRequest) Reset() {
 	*x = UpdateCommentRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[21]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1675,7 +2190,7 @@ func (x *UpdateCommentRequest) String() string {
 func (*UpdateCommentRequest) ProtoMessage() {}
 
 func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[21]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1688,7 +2203,7 @@ func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCommentRequest.ProtoReflect.Descriptor instead.
 func (*UpdateCommentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{21}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *UpdateCommentRequest) GetCommentId() string {
@@ -1730,7 +2245,7 @@ type DeleteCommentRequest struct {
 
 func (x *DeleteCommentRequest) Reset() {
 	*x = DeleteCommentRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[22]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1742,7 +2257,7 @@ func (x *DeleteCommentRequest) String() string {
 func (*DeleteCommentRequest) ProtoMessage() {}
 
 func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[22]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1755,7 +2270,7 @@ func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
 func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{22}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *DeleteCommentRequest) GetPostId() string {
@@ -1790,7 +2305,7 @@ type ListCommentsRequest struct {
 
 func (x *ListCommentsRequest) Reset() {
 	*x = ListCommentsRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[23]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1802,7 +2317,7 @@ func (x *ListCommentsRequest) String() string {
 func (*ListCommentsRequest) ProtoMessage() {}
 
 func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[23]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1815,7 +2330,7 @@ func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
 func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{23}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ListCommentsRequest) GetPostId() string {
@@ -1854,7 +2369,7 @@ type CreateReplyRequest struct {
 
 func (x *CreateReplyRequest) Reset() {
 	*x = CreateReplyRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[24]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1866,7 +2381,7 @@ func (x *CreateReplyRequest) String() string {
 func (*CreateReplyRequest) ProtoMessage() {}
 
 func (x *CreateReplyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[24]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1879,7 +2394,7 @@ func (x *CreateReplyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateReplyRequest.ProtoReflect.Descriptor instead.
 func (*CreateReplyRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{24}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *CreateReplyRequest) GetCommentId() string {
@@ -1940,7 +2455,7 @@ type GetReplyRequest struct {
 
 func (x *GetReplyRequest) Reset() {
 	*x = GetReplyRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[25]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1952,7 +2467,7 @@ func (x *GetReplyRequest) String() string {
 func (*GetReplyRequest) ProtoMessage() {}
 
 func (x *GetReplyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[25]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1965,7 +2480,7 @@ func (x *GetReplyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetReplyRequest.ProtoReflect.Descriptor instead.
 func (*GetReplyRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{25}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *GetReplyRequest) GetReplyId() string {
@@ -1987,7 +2502,7 @@ type UpdateReplyRequest struct {
 
 func (x *UpdateReplyRequest) Reset() {
 	*x = UpdateReplyRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[26]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1999,7 +2514,7 @@ func (x *UpdateReplyRequest) String() string {
 func (*UpdateReplyRequest) ProtoMessage() {}
 
 func (x *UpdateReplyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[26]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2012,7 +2527,7 @@ func (x *UpdateReplyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateReplyRequest.ProtoReflect.Descriptor instead.
 func (*UpdateReplyRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{26}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *UpdateReplyRequest) GetReplyId() string {
@@ -2054,7 +2569,7 @@ type DeleteReplyRequest struct {
 
 func (x *DeleteReplyRequest) Reset() {
 	*x = DeleteReplyRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[27]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2066,7 +2581,7 @@ func (x *DeleteReplyRequest) String() string {
 func (*DeleteReplyRequest) ProtoMessage() {}
 
 func (x *DeleteReplyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[27]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2079,7 +2594,7 @@ func (x *DeleteReplyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteReplyRequest.ProtoReflect.Descriptor instead.
 func (*DeleteReplyRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{27}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *DeleteReplyRequest) GetCommentId() string {
@@ -2114,7 +2629,7 @@ type ListRepliesRequest struct {
 
 func (x *ListRepliesRequest) Reset() {
 	*x = ListRepliesRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[28]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2126,7 +2641,7 @@ func (x *ListRepliesRequest) String() string {
 func (*ListRepliesRequest) ProtoMessage() {}
 
 func (x *ListRepliesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[28]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2139,7 +2654,7 @@ func (x *ListRepliesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRepliesRequest.ProtoReflect.Descriptor instead.
 func (*ListRepliesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{28}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *ListRepliesRequest) GetCommentId() string {
@@ -2174,7 +2689,7 @@ type ReactToPostRequest struct {
 
 func (x *ReactToPostRequest) Reset() {
 	*x = ReactToPostRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[29]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2186,7 +2701,7 @@ func (x *ReactToPostRequest) String() string {
 func (*ReactToPostRequest) ProtoMessage() {}
 
 func (x *ReactToPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[29]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2199,7 +2714,7 @@ func (x *ReactToPostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReactToPostRequest.ProtoReflect.Descriptor instead.
 func (*ReactToPostRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{29}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *ReactToPostRequest) GetPostId() string {
@@ -2234,7 +2749,7 @@ type ReactToCommentRequest struct {
 
 func (x *ReactToCommentRequest) Reset() {
 	*x = ReactToCommentRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[30]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2246,7 +2761,7 @@ func (x *ReactToCommentRequest) String() string {
 func (*ReactToCommentRequest) ProtoMessage() {}
 
 func (x *ReactToCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[30]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2259,7 +2774,7 @@ func (x *ReactToCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReactToCommentRequest.ProtoReflect.Descriptor instead.
 func (*ReactToCommentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{30}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *ReactToCommentRequest) GetCommentId() string {
@@ -2294,7 +2809,7 @@ type ReactToReplyRequest struct {
 
 func (x *ReactToReplyRequest) Reset() {
 	*x = ReactToReplyRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[31]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2306,7 +2821,7 @@ func (x *ReactToReplyRequest) String() string {
 func (*ReactToReplyRequest) ProtoMessage() {}
 
 func (x *ReactToReplyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[31]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2319,7 +2834,7 @@ func (x *ReactToReplyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReactToReplyRequest.ProtoReflect.Descriptor instead.
 func (*ReactToReplyRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{31}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ReactToReplyRequest) GetReplyId() string {
@@ -2355,7 +2870,7 @@ type CreateAlbumRequest struct {
 
 func (x *CreateAlbumRequest) Reset() {
 	*x = CreateAlbumRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[32]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2367,7 +2882,7 @@ func (x *CreateAlbumRequest) String() string {
 func (*CreateAlbumRequest) ProtoMessage() {}
 
 func (x *CreateAlbumRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[32]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2380,7 +2895,7 @@ func (x *CreateAlbumRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateAlbumRequest.ProtoReflect.Descriptor instead.
 func (*CreateAlbumRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{32}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *CreateAlbumRequest) GetUserId() string {
@@ -2420,7 +2935,7 @@ type GetAlbumRequest struct {
 
 func (x *GetAlbumRequest) Reset() {
 	*x = GetAlbumRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[33]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2432,7 +2947,7 @@ func (x *GetAlbumRequest) String() string {
 func (*GetAlbumRequest) ProtoMessage() {}
 
 func (x *GetAlbumRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[33]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2445,7 +2960,7 @@ func (x *GetAlbumRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAlbumRequest.ProtoReflect.Descriptor instead.
 func (*GetAlbumRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{33}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *GetAlbumRequest) GetAlbumId() string {
@@ -2467,7 +2982,7 @@ type UpdateAlbumRequest struct {
 
 func (x *UpdateAlbumRequest) Reset() {
 	*x = UpdateAlbumRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[34]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2479,7 +2994,7 @@ func (x *UpdateAlbumRequest) String() string {
 func (*UpdateAlbumRequest) ProtoMessage() {}
 
 func (x *UpdateAlbumRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[34]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2492,7 +3007,7 @@ func (x *UpdateAlbumRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateAlbumRequest.ProtoReflect.Descriptor instead.
 func (*UpdateAlbumRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{34}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *UpdateAlbumRequest) GetAlbumId() string {
@@ -2533,7 +3048,7 @@ type DeleteAlbumRequest struct {
 
 func (x *DeleteAlbumRequest) Reset() {
 	*x = DeleteAlbumRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[35]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2545,7 +3060,7 @@ func (x *DeleteAlbumRequest) String() string {
 func (*DeleteAlbumRequest) ProtoMessage() {}
 
 func (x *DeleteAlbumRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[35]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2558,7 +3073,7 @@ func (x *DeleteAlbumRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteAlbumRequest.ProtoReflect.Descriptor instead.
 func (*DeleteAlbumRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{35}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *DeleteAlbumRequest) GetUserId() string {
@@ -2586,7 +3101,7 @@ type ListAlbumsRequest struct {
 
 func (x *ListAlbumsRequest) Reset() {
 	*x = ListAlbumsRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[36]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2598,7 +3113,7 @@ func (x *ListAlbumsRequest) String() string {
 func (*ListAlbumsRequest) ProtoMessage() {}
 
 func (x *ListAlbumsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[36]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2611,7 +3126,7 @@ func (x *ListAlbumsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAlbumsRequest.ProtoReflect.Descriptor instead.
 func (*ListAlbumsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{36}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *ListAlbumsRequest) GetUserId() string {
@@ -2647,7 +3162,7 @@ type AddMediaToAlbumRequest struct {
 
 func (x *AddMediaToAlbumRequest) Reset() {
 	*x = AddMediaToAlbumRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[37]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2659,7 +3174,7 @@ func (x *AddMediaToAlbumRequest) String() string {
 func (*AddMediaToAlbumRequest) ProtoMessage() {}
 
 func (x *AddMediaToAlbumRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[37]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2672,7 +3187,7 @@ func (x *AddMediaToAlbumRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddMediaToAlbumRequest.ProtoReflect.Descriptor instead.
 func (*AddMediaToAlbumRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{37}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *AddMediaToAlbumRequest) GetAlbumId() string {
@@ -2713,7 +3228,7 @@ type RemoveMediaFromAlbumRequest struct {
 
 func (x *RemoveMediaFromAlbumRequest) Reset() {
 	*x = RemoveMediaFromAlbumRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[38]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2725,7 +3240,7 @@ func (x *RemoveMediaFromAlbumRequest) String() string {
 func (*RemoveMediaFromAlbumRequest) ProtoMessage() {}
 
 func (x *RemoveMediaFromAlbumRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[38]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2738,7 +3253,7 @@ func (x *RemoveMediaFromAlbumRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveMediaFromAlbumRequest.ProtoReflect.Descriptor instead.
 func (*RemoveMediaFromAlbumRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{38}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *RemoveMediaFromAlbumRequest) GetAlbumId() string {
@@ -2766,7 +3281,7 @@ type GetAlbumMediaRequest struct {
 
 func (x *GetAlbumMediaRequest) Reset() {
 	*x = GetAlbumMediaRequest{}
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[39]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2778,7 +3293,7 @@ func (x *GetAlbumMediaRequest) String() string {
 func (*GetAlbumMediaRequest) ProtoMessage() {}
 
 func (x *GetAlbumMediaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_v1_feed_proto_msgTypes[39]
+	mi := &file_proto_feed_v1_feed_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2791,7 +3306,7 @@ func (x *GetAlbumMediaRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAlbumMediaRequest.ProtoReflect.Descriptor instead.
 func (*GetAlbumMediaRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{39}
+	return file_proto_feed_v1_feed_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *GetAlbumMediaRequest) GetAlbumId() string {
@@ -2819,7 +3334,7 @@ var File_proto_feed_v1_feed_proto protoreflect.FileDescriptor
 
 const file_proto_feed_v1_feed_proto_rawDesc = "" +
 	"\n" +
-	"\x18proto/feed/v1/feed.proto\x12\afeed.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xfe\x04\n" +
+	"\x18proto/feed/v1/feed.proto\x12\afeed.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x8f\x06\n" +
 	"\fPostResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
@@ -2840,16 +3355,39 @@ const file_proto_feed_v1_feed_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12+\n" +
 	"\x06author\x18\x10 \x01(\v2\x13.feed.v1.PostAuthorR\x06author\x12<\n" +
-	"\x0fmentioned_users\x18\x11 \x03(\v2\x13.feed.v1.PostAuthorR\x0ementionedUsers\"1\n" +
+	"\x0fmentioned_users\x18\x11 \x03(\v2\x13.feed.v1.PostAuthorR\x0ementionedUsers\x12!\n" +
+	"\ftotal_shares\x18\x12 \x01(\x03R\vtotalShares\x123\n" +
+	"\vshared_from\x18\x13 \x01(\v2\x12.feed.v1.PostShareR\n" +
+	"sharedFrom\x127\n" +
+	"\flink_preview\x18\x14 \x01(\v2\x14.feed.v1.LinkPreviewR\vlinkPreview\"\xa0\x03\n" +
+	"\tPostShare\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12(\n" +
+	"\x10original_post_id\x18\x02 \x01(\tR\x0eoriginalPostId\x12,\n" +
+	"\x12original_author_id\x18\x03 \x01(\tR\x10originalAuthorId\x12\x14\n" +
+	"\x05chain\x18\x04 \x03(\tR\x05chain\x12\x1c\n" +
+	"\tavailable\x18\x05 \x01(\bR\tavailable\x12<\n" +
+	"\x0foriginal_author\x18\x06 \x01(\v2\x13.feed.v1.PostAuthorR\x0eoriginalAuthor\x12)\n" +
+	"\x10original_content\x18\a \x01(\tR\x0foriginalContent\x129\n" +
+	"\x0eoriginal_media\x18\b \x03(\v2\x12.feed.v1.MediaItemR\roriginalMedia\x12J\n" +
+	"\x13original_created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x11originalCreatedAt\"\xcc\x01\n" +
+	"\vLinkPreview\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\timage_url\x18\x04 \x01(\tR\bimageUrl\x12\x1b\n" +
+	"\tsite_name\x18\x05 \x01(\tR\bsiteName\x129\n" +
+	"\n" +
+	"fetched_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tfetchedAt\"1\n" +
 	"\tMediaItem\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\x12\x12\n" +
-	"\x04type\x18\x02 \x01(\tR\x04type\"m\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\"\x89\x01\n" +
 	"\n" +
 	"PostAuthor\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x16\n" +
 	"\x06avatar\x18\x03 \x01(\tR\x06avatar\x12\x1b\n" +
-	"\tfull_name\x18\x04 \x01(\tR\bfullName\"\xe8\x02\n" +
+	"\tfull_name\x18\x04 \x01(\tR\bfullName\x12\x1a\n" +
+	"\bverified\x18\x05 \x01(\bR\bverified\"\xe8\x02\n" +
 	"\x0fCommentResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\apost_id\x18\x02 \x01(\tR\x06postId\x12\x17\n" +
@@ -2877,12 +3415,15 @@ const file_proto_feed_v1_feed_proto_rawDesc = "" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12+\n" +
-	"\x06author\x18\t \x01(\v2\x13.feed.v1.PostAuthorR\x06author\"{\n" +
+	"\x06author\x18\t \x01(\v2\x13.feed.v1.PostAuthorR\x06author\"\xb2\x01\n" +
 	"\fFeedResponse\x12+\n" +
 	"\x05posts\x18\x01 \x03(\v2\x15.feed.v1.PostResponseR\x05posts\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x03R\x04page\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x03R\x05limit\"L\n" +
+	"\x05limit\x18\x04 \x01(\x03R\x05limit\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12\x14\n" +
+	"\x05order\x18\x06 \x01(\tR\x05order\"L\n" +
 	"\x14ListCommentsResponse\x124\n" +
 	"\bcomments\x18\x01 \x03(\v2\x18.feed.v1.CommentResponseR\bcomments\"G\n" +
 	"\x13ListRepliesResponse\x120\n" +
@@ -2934,7 +3475,13 @@ const file_proto_feed_v1_feed_proto_rawDesc = "" +
 	"\bhashtags\x18\t \x03(\tR\bhashtags\"E\n" +
 	"\x11DeletePostRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xae\x02\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xa1\x01\n" +
+	"\x10SharePostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x18\n" +
+	"\aprivacy\x18\x04 \x01(\tR\aprivacy\x12'\n" +
+	"\x0fcustom_audience\x18\x05 \x03(\tR\x0ecustomAudience\"\xdc\x02\n" +
 	"\x10ListPostsRequest\x12\x1b\n" +
 	"\tviewer_id\x18\x01 \x01(\tR\bviewerId\x12$\n" +
 	"\x0efilter_user_id\x18\x02 \x01(\tR\ffilterUserId\x12!\n" +
@@ -2948,12 +3495,25 @@ const file_proto_feed_v1_feed_proto_rawDesc = "" +
 	"\n" +
 	"media_type\x18\t \x01(\tR\tmediaType\x12\x16\n" +
 	"\x06status\x18\n" +
-	" \x01(\tR\x06status\"{\n" +
+	" \x01(\tR\x06status\x12\x16\n" +
+	"\x06cursor\x18\v \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05order\x18\f \x01(\tR\x05order\"{\n" +
 	"\x18GetPostsByHashtagRequest\x12\x1b\n" +
 	"\tviewer_id\x18\x01 \x01(\tR\bviewerId\x12\x18\n" +
 	"\ahashtag\x18\x02 \x01(\tR\ahashtag\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x03R\x04page\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x03R\x05limit\"c\n" +
+	"\x05limit\x18\x04 \x01(\x03R\x05limit\"b\n" +
+	"\x1aGetTrendingHashtagsRequest\x12\x16\n" +
+	"\x06region\x18\x01 \x01(\tR\x06region\x12\x16\n" +
+	"\x06window\x18\x02 \x01(\tR\x06window\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"9\n" +
+	"\x0fTrendingHashtag\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"\x83\x01\n" +
+	"\x1bGetTrendingHashtagsResponse\x124\n" +
+	"\bhashtags\x18\x01 \x03(\v2\x18.feed.v1.TrendingHashtagR\bhashtags\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x16\n" +
+	"\x06window\x18\x03 \x01(\tR\x06window\"c\n" +
 	"\x17UpdatePostStatusRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
@@ -3054,7 +3614,7 @@ const file_proto_feed_v1_feed_proto_rawDesc = "" +
 	"\x14GetAlbumMediaRequest\x12\x19\n" +
 	"\balbum_id\x18\x01 \x01(\tR\aalbumId\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x03R\x04page\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x03R\x05limit2\xc5\x0f\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit2\xe6\x10\n" +
 	"\vFeedService\x12?\n" +
 	"\n" +
 	"CreatePost\x12\x1a.feed.v1.CreatePostRequest\x1a\x15.feed.v1.PostResponse\x129\n" +
@@ -3064,8 +3624,10 @@ const file_proto_feed_v1_feed_proto_rawDesc = "" +
 	"\n" +
 	"DeletePost\x12\x1a.feed.v1.DeletePostRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
 	"\tListPosts\x12\x19.feed.v1.ListPostsRequest\x1a\x15.feed.v1.FeedResponse\x12M\n" +
-	"\x11GetPostsByHashtag\x12!.feed.v1.GetPostsByHashtagRequest\x1a\x15.feed.v1.FeedResponse\x12L\n" +
-	"\x10UpdatePostStatus\x12 .feed.v1.UpdatePostStatusRequest\x1a\x16.google.protobuf.Empty\x12H\n" +
+	"\x11GetPostsByHashtag\x12!.feed.v1.GetPostsByHashtagRequest\x1a\x15.feed.v1.FeedResponse\x12`\n" +
+	"\x13GetTrendingHashtags\x12#.feed.v1.GetTrendingHashtagsRequest\x1a$.feed.v1.GetTrendingHashtagsResponse\x12L\n" +
+	"\x10UpdatePostStatus\x12 .feed.v1.UpdatePostStatusRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\tSharePost\x12\x19.feed.v1.SharePostRequest\x1a\x15.feed.v1.PostResponse\x12H\n" +
 	"\rCreateComment\x12\x1d.feed.v1.CreateCommentRequest\x1a\x18.feed.v1.CommentResponse\x12B\n" +
 	"\n" +
 	"GetComment\x12\x1a.feed.v1.GetCommentRequest\x1a\x18.feed.v1.CommentResponse\x12H\n" +
@@ -3088,7 +3650,7 @@ const file_proto_feed_v1_feed_proto_rawDesc = "" +
 	"ListAlbums\x12\x1a.feed.v1.ListAlbumsRequest\x1a\x1b.feed.v1.ListAlbumsResponse\x12O\n" +
 	"\x0fAddMediaToAlbum\x12\x1f.feed.v1.AddMediaToAlbumRequest\x1a\x1b.feed.v1.AlbumMediaResponse\x12T\n" +
 	"\x14RemoveMediaFromAlbum\x12$.feed.v1.RemoveMediaFromAlbumRequest\x1a\x16.google.protobuf.Empty\x12N\n" +
-	"\rGetAlbumMedia\x12\x1d.feed.v1.GetAlbumMediaRequest\x1a\x1e.feed.v1.GetAlbumMediaResponseB?Z=github.com/MuhibNayem/connectify-v2/shared-entity/proto/feed/v1;feedpbb\x06proto3"
+	"\rGetAlbumMedia\x12\x1d.feed.v1.GetAlbumMediaRequest\x1a\x1e.feed.v1.GetAlbumMediaResponseB?Z=gitlab.com/spydotech-group/shared-entity/proto/feed/v1;feedpbb\x06proto3"
 
 var (
 	file_proto_feed_v1_feed_proto_rawDescOnce sync.Once
@@ -3102,134 +3664,151 @@ func file_proto_feed_v1_feed_proto_rawDescGZIP() []byte {
 	return file_proto_feed_v1_feed_proto_rawDescData
 }
 
-var file_proto_feed_v1_feed_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
+var file_proto_feed_v1_feed_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
 var file_proto_feed_v1_feed_proto_goTypes = []any{
 	(*PostResponse)(nil),                // 0: feed.v1.PostResponse
-	(*MediaItem)(nil),                   // 1: feed.v1.MediaItem
-	(*PostAuthor)(nil),                  // 2: feed.v1.PostAuthor
-	(*CommentResponse)(nil),             // 3: feed.v1.CommentResponse
-	(*ReplyResponse)(nil),               // 4: feed.v1.ReplyResponse
-	(*FeedResponse)(nil),                // 5: feed.v1.FeedResponse
-	(*ListCommentsResponse)(nil),        // 6: feed.v1.ListCommentsResponse
-	(*ListRepliesResponse)(nil),         // 7: feed.v1.ListRepliesResponse
-	(*AlbumResponse)(nil),               // 8: feed.v1.AlbumResponse
-	(*AlbumMediaResponse)(nil),          // 9: feed.v1.AlbumMediaResponse
-	(*ListAlbumsResponse)(nil),          // 10: feed.v1.ListAlbumsResponse
-	(*GetAlbumMediaResponse)(nil),       // 11: feed.v1.GetAlbumMediaResponse
-	(*CreatePostRequest)(nil),           // 12: feed.v1.CreatePostRequest
-	(*GetPostRequest)(nil),              // 13: feed.v1.GetPostRequest
-	(*UpdatePostRequest)(nil),           // 14: feed.v1.UpdatePostRequest
-	(*DeletePostRequest)(nil),           // 15: feed.v1.DeletePostRequest
-	(*ListPostsRequest)(nil),            // 16: feed.v1.ListPostsRequest
-	(*GetPostsByHashtagRequest)(nil),    // 17: feed.v1.GetPostsByHashtagRequest
-	(*UpdatePostStatusRequest)(nil),     // 18: feed.v1.UpdatePostStatusRequest
-	(*CreateCommentRequest)(nil),        // 19: feed.v1.CreateCommentRequest
-	(*GetCommentRequest)(nil),           // 20: feed.v1.GetCommentRequest
-	(*UpdateCommentRequest)(nil),        // 21: feed.v1.UpdateCommentRequest
-	(*DeleteCommentRequest)(nil),        // 22: feed.v1.DeleteCommentRequest
-	(*ListCommentsRequest)(nil),         // 23: feed.v1.ListCommentsRequest
-	(*CreateReplyRequest)(nil),          // 24: feed.v1.CreateReplyRequest
-	(*GetReplyRequest)(nil),             // 25: feed.v1.GetReplyRequest
-	(*UpdateReplyRequest)(nil),          // 26: feed.v1.UpdateReplyRequest
-	(*DeleteReplyRequest)(nil),          // 27: feed.v1.DeleteReplyRequest
-	(*ListRepliesRequest)(nil),          // 28: feed.v1.ListRepliesRequest
-	(*ReactToPostRequest)(nil),          // 29: feed.v1.ReactToPostRequest
-	(*ReactToCommentRequest)(nil),       // 30: feed.v1.ReactToCommentRequest
-	(*ReactToReplyRequest)(nil),         // 31: feed.v1.ReactToReplyRequest
-	(*CreateAlbumRequest)(nil),          // 32: feed.v1.CreateAlbumRequest
-	(*GetAlbumRequest)(nil),             // 33: feed.v1.GetAlbumRequest
-	(*UpdateAlbumRequest)(nil),          // 34: feed.v1.UpdateAlbumRequest
-	(*DeleteAlbumRequest)(nil),          // 35: feed.v1.DeleteAlbumRequest
-	(*ListAlbumsRequest)(nil),           // 36: feed.v1.ListAlbumsRequest
-	(*AddMediaToAlbumRequest)(nil),      // 37: feed.v1.AddMediaToAlbumRequest
-	(*RemoveMediaFromAlbumRequest)(nil), // 38: feed.v1.RemoveMediaFromAlbumRequest
-	(*GetAlbumMediaRequest)(nil),        // 39: feed.v1.GetAlbumMediaRequest
-	(*timestamppb.Timestamp)(nil),       // 40: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),               // 41: google.protobuf.Empty
+	(*PostShare)(nil),                   // 1: feed.v1.PostShare
+	(*LinkPreview)(nil),                 // 2: feed.v1.LinkPreview
+	(*MediaItem)(nil),                   // 3: feed.v1.MediaItem
+	(*PostAuthor)(nil),                  // 4: feed.v1.PostAuthor
+	(*CommentResponse)(nil),             // 5: feed.v1.CommentResponse
+	(*ReplyResponse)(nil),               // 6: feed.v1.ReplyResponse
+	(*FeedResponse)(nil),                // 7: feed.v1.FeedResponse
+	(*ListCommentsResponse)(nil),        // 8: feed.v1.ListCommentsResponse
+	(*ListRepliesResponse)(nil),         // 9: feed.v1.ListRepliesResponse
+	(*AlbumResponse)(nil),               // 10: feed.v1.AlbumResponse
+	(*AlbumMediaResponse)(nil),          // 11: feed.v1.AlbumMediaResponse
+	(*ListAlbumsResponse)(nil),          // 12: feed.v1.ListAlbumsResponse
+	(*GetAlbumMediaResponse)(nil),       // 13: feed.v1.GetAlbumMediaResponse
+	(*CreatePostRequest)(nil),           // 14: feed.v1.CreatePostRequest
+	(*GetPostRequest)(nil),              // 15: feed.v1.GetPostRequest
+	(*UpdatePostRequest)(nil),           // 16: feed.v1.UpdatePostRequest
+	(*DeletePostRequest)(nil),           // 17: feed.v1.DeletePostRequest
+	(*SharePostRequest)(nil),            // 18: feed.v1.SharePostRequest
+	(*ListPostsRequest)(nil),            // 19: feed.v1.ListPostsRequest
+	(*GetPostsByHashtagRequest)(nil),    // 20: feed.v1.GetPostsByHashtagRequest
+	(*GetTrendingHashtagsRequest)(nil),  // 21: feed.v1.GetTrendingHashtagsRequest
+	(*TrendingHashtag)(nil),             // 22: feed.v1.TrendingHashtag
+	(*GetTrendingHashtagsResponse)(nil), // 23: feed.v1.GetTrendingHashtagsResponse
+	(*UpdatePostStatusRequest)(nil),     // 24: feed.v1.UpdatePostStatusRequest
+	(*CreateCommentRequest)(nil),        // 25: feed.v1.CreateCommentRequest
+	(*GetCommentRequest)(nil),           // 26: feed.v1.GetCommentRequest
+	(*UpdateCommentRequest)(nil),        // 27: feed.v1.UpdateCommentRequest
+	(*DeleteCommentRequest)(nil),        // 28: feed.v1.DeleteCommentRequest
+	(*ListCommentsRequest)(nil),         // 29: feed.v1.ListCommentsRequest
+	(*CreateReplyRequest)(nil),          // 30: feed.v1.CreateReplyRequest
+	(*GetReplyRequest)(nil),             // 31: feed.v1.GetReplyRequest
+	(*UpdateReplyRequest)(nil),          // 32: feed.v1.UpdateReplyRequest
+	(*DeleteReplyRequest)(nil),          // 33: feed.v1.DeleteReplyRequest
+	(*ListRepliesRequest)(nil),          // 34: feed.v1.ListRepliesRequest
+	(*ReactToPostRequest)(nil),          // 35: feed.v1.ReactToPostRequest
+	(*ReactToCommentRequest)(nil),       // 36: feed.v1.ReactToCommentRequest
+	(*ReactToReplyRequest)(nil),         // 37: feed.v1.ReactToReplyRequest
+	(*CreateAlbumRequest)(nil),          // 38: feed.v1.CreateAlbumRequest
+	(*GetAlbumRequest)(nil),             // 39: feed.v1.GetAlbumRequest
+	(*UpdateAlbumRequest)(nil),          // 40: feed.v1.UpdateAlbumRequest
+	(*DeleteAlbumRequest)(nil),          // 41: feed.v1.DeleteAlbumRequest
+	(*ListAlbumsRequest)(nil),           // 42: feed.v1.ListAlbumsRequest
+	(*AddMediaToAlbumRequest)(nil),      // 43: feed.v1.AddMediaToAlbumRequest
+	(*RemoveMediaFromAlbumRequest)(nil), // 44: feed.v1.RemoveMediaFromAlbumRequest
+	(*GetAlbumMediaRequest)(nil),        // 45: feed.v1.GetAlbumMediaRequest
+	(*timestamppb.Timestamp)(nil),       // 46: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),               // 47: google.protobuf.Empty
 }
 var file_proto_feed_v1_feed_proto_depIdxs = []int32{
-	1,  // 0: feed.v1.PostResponse.media:type_name -> feed.v1.MediaItem
-	40, // 1: feed.v1.PostResponse.created_at:type_name -> google.protobuf.Timestamp
-	40, // 2: feed.v1.PostResponse.updated_at:type_name -> google.protobuf.Timestamp
-	2,  // 3: feed.v1.PostResponse.author:type_name -> feed.v1.PostAuthor
-	2,  // 4: feed.v1.PostResponse.mentioned_users:type_name -> feed.v1.PostAuthor
-	40, // 5: feed.v1.CommentResponse.created_at:type_name -> google.protobuf.Timestamp
-	40, // 6: feed.v1.CommentResponse.updated_at:type_name -> google.protobuf.Timestamp
-	2,  // 7: feed.v1.CommentResponse.author:type_name -> feed.v1.PostAuthor
-	40, // 8: feed.v1.ReplyResponse.created_at:type_name -> google.protobuf.Timestamp
-	40, // 9: feed.v1.ReplyResponse.updated_at:type_name -> google.protobuf.Timestamp
-	2,  // 10: feed.v1.ReplyResponse.author:type_name -> feed.v1.PostAuthor
-	0,  // 11: feed.v1.FeedResponse.posts:type_name -> feed.v1.PostResponse
-	3,  // 12: feed.v1.ListCommentsResponse.comments:type_name -> feed.v1.CommentResponse
-	4,  // 13: feed.v1.ListRepliesResponse.replies:type_name -> feed.v1.ReplyResponse
-	40, // 14: feed.v1.AlbumResponse.created_at:type_name -> google.protobuf.Timestamp
-	40, // 15: feed.v1.AlbumResponse.updated_at:type_name -> google.protobuf.Timestamp
-	40, // 16: feed.v1.AlbumMediaResponse.created_at:type_name -> google.protobuf.Timestamp
-	8,  // 17: feed.v1.ListAlbumsResponse.albums:type_name -> feed.v1.AlbumResponse
-	9,  // 18: feed.v1.GetAlbumMediaResponse.media:type_name -> feed.v1.AlbumMediaResponse
-	1,  // 19: feed.v1.CreatePostRequest.media:type_name -> feed.v1.MediaItem
-	1,  // 20: feed.v1.UpdatePostRequest.media:type_name -> feed.v1.MediaItem
-	12, // 21: feed.v1.FeedService.CreatePost:input_type -> feed.v1.CreatePostRequest
-	13, // 22: feed.v1.FeedService.GetPost:input_type -> feed.v1.GetPostRequest
-	14, // 23: feed.v1.FeedService.UpdatePost:input_type -> feed.v1.UpdatePostRequest
-	15, // 24: feed.v1.FeedService.DeletePost:input_type -> feed.v1.DeletePostRequest
-	16, // 25: feed.v1.FeedService.ListPosts:input_type -> feed.v1.ListPostsRequest
-	17, // 26: feed.v1.FeedService.GetPostsByHashtag:input_type -> feed.v1.GetPostsByHashtagRequest
-	18, // 27: feed.v1.FeedService.UpdatePostStatus:input_type -> feed.v1.UpdatePostStatusRequest
-	19, // 28: feed.v1.FeedService.CreateComment:input_type -> feed.v1.CreateCommentRequest
-	20, // 29: feed.v1.FeedService.GetComment:input_type -> feed.v1.GetCommentRequest
-	21, // 30: feed.v1.FeedService.UpdateComment:input_type -> feed.v1.UpdateCommentRequest
-	22, // 31: feed.v1.FeedService.DeleteComment:input_type -> feed.v1.DeleteCommentRequest
-	23, // 32: feed.v1.FeedService.ListComments:input_type -> feed.v1.ListCommentsRequest
-	24, // 33: feed.v1.FeedService.CreateReply:input_type -> feed.v1.CreateReplyRequest
-	25, // 34: feed.v1.FeedService.GetReply:input_type -> feed.v1.GetReplyRequest
-	26, // 35: feed.v1.FeedService.UpdateReply:input_type -> feed.v1.UpdateReplyRequest
-	27, // 36: feed.v1.FeedService.DeleteReply:input_type -> feed.v1.DeleteReplyRequest
-	28, // 37: feed.v1.FeedService.ListReplies:input_type -> feed.v1.ListRepliesRequest
-	29, // 38: feed.v1.FeedService.ReactToPost:input_type -> feed.v1.ReactToPostRequest
-	30, // 39: feed.v1.FeedService.ReactToComment:input_type -> feed.v1.ReactToCommentRequest
-	31, // 40: feed.v1.FeedService.ReactToReply:input_type -> feed.v1.ReactToReplyRequest
-	32, // 41: feed.v1.FeedService.CreateAlbum:input_type -> feed.v1.CreateAlbumRequest
-	33, // 42: feed.v1.FeedService.GetAlbum:input_type -> feed.v1.GetAlbumRequest
-	34, // 43: feed.v1.FeedService.UpdateAlbum:input_type -> feed.v1.UpdateAlbumRequest
-	35, // 44: feed.v1.FeedService.DeleteAlbum:input_type -> feed.v1.DeleteAlbumRequest
-	36, // 45: feed.v1.FeedService.ListAlbums:input_type -> feed.v1.ListAlbumsRequest
-	37, // 46: feed.v1.FeedService.AddMediaToAlbum:input_type -> feed.v1.AddMediaToAlbumRequest
-	38, // 47: feed.v1.FeedService.RemoveMediaFromAlbum:input_type -> feed.v1.RemoveMediaFromAlbumRequest
-	39, // 48: feed.v1.FeedService.GetAlbumMedia:input_type -> feed.v1.GetAlbumMediaRequest
-	0,  // 49: feed.v1.FeedService.CreatePost:output_type -> feed.v1.PostResponse
-	0,  // 50: feed.v1.FeedService.GetPost:output_type -> feed.v1.PostResponse
-	0,  // 51: feed.v1.FeedService.UpdatePost:output_type -> feed.v1.PostResponse
-	41, // 52: feed.v1.FeedService.DeletePost:output_type -> google.protobuf.Empty
-	5,  // 53: feed.v1.FeedService.ListPosts:output_type -> feed.v1.FeedResponse
-	5,  // 54: feed.v1.FeedService.GetPostsByHashtag:output_type -> feed.v1.FeedResponse
-	41, // 55: feed.v1.FeedService.UpdatePostStatus:output_type -> google.protobuf.Empty
-	3,  // 56: feed.v1.FeedService.CreateComment:output_type -> feed.v1.CommentResponse
-	3,  // 57: feed.v1.FeedService.GetComment:output_type -> feed.v1.CommentResponse
-	3,  // 58: feed.v1.FeedService.UpdateComment:output_type -> feed.v1.CommentResponse
-	41, // 59: feed.v1.FeedService.DeleteComment:output_type -> google.protobuf.Empty
-	6,  // 60: feed.v1.FeedService.ListComments:output_type -> feed.v1.ListCommentsResponse
-	4,  // 61: feed.v1.FeedService.CreateReply:output_type -> feed.v1.ReplyResponse
-	4,  // 62: feed.v1.FeedService.GetReply:output_type -> feed.v1.ReplyResponse
-	4,  // 63: feed.v1.FeedService.UpdateReply:output_type -> feed.v1.ReplyResponse
-	41, // 64: feed.v1.FeedService.DeleteReply:output_type -> google.protobuf.Empty
-	7,  // 65: feed.v1.FeedService.ListReplies:output_type -> feed.v1.ListRepliesResponse
-	41, // 66: feed.v1.FeedService.ReactToPost:output_type -> google.protobuf.Empty
-	41, // 67: feed.v1.FeedService.ReactToComment:output_type -> google.protobuf.Empty
-	41, // 68: feed.v1.FeedService.ReactToReply:output_type -> google.protobuf.Empty
-	8,  // 69: feed.v1.FeedService.CreateAlbum:output_type -> feed.v1.AlbumResponse
-	8,  // 70: feed.v1.FeedService.GetAlbum:output_type -> feed.v1.AlbumResponse
-	8,  // 71: feed.v1.FeedService.UpdateAlbum:output_type -> feed.v1.AlbumResponse
-	41, // 72: feed.v1.FeedService.DeleteAlbum:output_type -> google.protobuf.Empty
-	10, // 73: feed.v1.FeedService.ListAlbums:output_type -> feed.v1.ListAlbumsResponse
-	9,  // 74: feed.v1.FeedService.AddMediaToAlbum:output_type -> feed.v1.AlbumMediaResponse
-	41, // 75: feed.v1.FeedService.RemoveMediaFromAlbum:output_type -> google.protobuf.Empty
-	11, // 76: feed.v1.FeedService.GetAlbumMedia:output_type -> feed.v1.GetAlbumMediaResponse
-	49, // [49:77] is the sub-list for method output_type
-	21, // [21:49] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	3,  // 0: feed.v1.PostResponse.media:type_name -> feed.v1.MediaItem
+	46, // 1: feed.v1.PostResponse.created_at:type_name -> google.protobuf.Timestamp
+	46, // 2: feed.v1.PostResponse.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 3: feed.v1.PostResponse.author:type_name -> feed.v1.PostAuthor
+	4,  // 4: feed.v1.PostResponse.mentioned_users:type_name -> feed.v1.PostAuthor
+	1,  // 5: feed.v1.PostResponse.shared_from:type_name -> feed.v1.PostShare
+	2,  // 6: feed.v1.PostResponse.link_preview:type_name -> feed.v1.LinkPreview
+	4,  // 7: feed.v1.PostShare.original_author:type_name -> feed.v1.PostAuthor
+	3,  // 8: feed.v1.PostShare.original_media:type_name -> feed.v1.MediaItem
+	46, // 9: feed.v1.PostShare.original_created_at:type_name -> google.protobuf.Timestamp
+	46, // 10: feed.v1.LinkPreview.fetched_at:type_name -> google.protobuf.Timestamp
+	46, // 11: feed.v1.CommentResponse.created_at:type_name -> google.protobuf.Timestamp
+	46, // 12: feed.v1.CommentResponse.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 13: feed.v1.CommentResponse.author:type_name -> feed.v1.PostAuthor
+	46, // 14: feed.v1.ReplyResponse.created_at:type_name -> google.protobuf.Timestamp
+	46, // 15: feed.v1.ReplyResponse.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 16: feed.v1.ReplyResponse.author:type_name -> feed.v1.PostAuthor
+	0,  // 17: feed.v1.FeedResponse.posts:type_name -> feed.v1.PostResponse
+	5,  // 18: feed.v1.ListCommentsResponse.comments:type_name -> feed.v1.CommentResponse
+	6,  // 19: feed.v1.ListRepliesResponse.replies:type_name -> feed.v1.ReplyResponse
+	46, // 20: feed.v1.AlbumResponse.created_at:type_name -> google.protobuf.Timestamp
+	46, // 21: feed.v1.AlbumResponse.updated_at:type_name -> google.protobuf.Timestamp
+	46, // 22: feed.v1.AlbumMediaResponse.created_at:type_name -> google.protobuf.Timestamp
+	10, // 23: feed.v1.ListAlbumsResponse.albums:type_name -> feed.v1.AlbumResponse
+	11, // 24: feed.v1.GetAlbumMediaResponse.media:type_name -> feed.v1.AlbumMediaResponse
+	3,  // 25: feed.v1.CreatePostRequest.media:type_name -> feed.v1.MediaItem
+	3,  // 26: feed.v1.UpdatePostRequest.media:type_name -> feed.v1.MediaItem
+	22, // 27: feed.v1.GetTrendingHashtagsResponse.hashtags:type_name -> feed.v1.TrendingHashtag
+	14, // 28: feed.v1.FeedService.CreatePost:input_type -> feed.v1.CreatePostRequest
+	15, // 29: feed.v1.FeedService.GetPost:input_type -> feed.v1.GetPostRequest
+	16, // 30: feed.v1.FeedService.UpdatePost:input_type -> feed.v1.UpdatePostRequest
+	17, // 31: feed.v1.FeedService.DeletePost:input_type -> feed.v1.DeletePostRequest
+	19, // 32: feed.v1.FeedService.ListPosts:input_type -> feed.v1.ListPostsRequest
+	20, // 33: feed.v1.FeedService.GetPostsByHashtag:input_type -> feed.v1.GetPostsByHashtagRequest
+	21, // 34: feed.v1.FeedService.GetTrendingHashtags:input_type -> feed.v1.GetTrendingHashtagsRequest
+	24, // 35: feed.v1.FeedService.UpdatePostStatus:input_type -> feed.v1.UpdatePostStatusRequest
+	18, // 36: feed.v1.FeedService.SharePost:input_type -> feed.v1.SharePostRequest
+	25, // 37: feed.v1.FeedService.CreateComment:input_type -> feed.v1.CreateCommentRequest
+	26, // 38: feed.v1.FeedService.GetComment:input_type -> feed.v1.GetCommentRequest
+	27, // 39: feed.v1.FeedService.UpdateComment:input_type -> feed.v1.UpdateCommentRequest
+	28, // 40: feed.v1.FeedService.DeleteComment:input_type -> feed.v1.DeleteCommentRequest
+	29, // 41: feed.v1.FeedService.ListComments:input_type -> feed.v1.ListCommentsRequest
+	30, // 42: feed.v1.FeedService.CreateReply:input_type -> feed.v1.CreateReplyRequest
+	31, // 43: feed.v1.FeedService.GetReply:input_type -> feed.v1.GetReplyRequest
+	32, // 44: feed.v1.FeedService.UpdateReply:input_type -> feed.v1.UpdateReplyRequest
+	33, // 45: feed.v1.FeedService.DeleteReply:input_type -> feed.v1.DeleteReplyRequest
+	34, // 46: feed.v1.FeedService.ListReplies:input_type -> feed.v1.ListRepliesRequest
+	35, // 47: feed.v1.FeedService.ReactToPost:input_type -> feed.v1.ReactToPostRequest
+	36, // 48: feed.v1.FeedService.ReactToComment:input_type -> feed.v1.ReactToCommentRequest
+	37, // 49: feed.v1.FeedService.ReactToReply:input_type -> feed.v1.ReactToReplyRequest
+	38, // 50: feed.v1.FeedService.CreateAlbum:input_type -> feed.v1.CreateAlbumRequest
+	39, // 51: feed.v1.FeedService.GetAlbum:input_type -> feed.v1.GetAlbumRequest
+	40, // 52: feed.v1.FeedService.UpdateAlbum:input_type -> feed.v1.UpdateAlbumRequest
+	41, // 53: feed.v1.FeedService.DeleteAlbum:input_type -> feed.v1.DeleteAlbumRequest
+	42, // 54: feed.v1.FeedService.ListAlbums:input_type -> feed.v1.ListAlbumsRequest
+	43, // 55: feed.v1.FeedService.AddMediaToAlbum:input_type -> feed.v1.AddMediaToAlbumRequest
+	44, // 56: feed.v1.FeedService.RemoveMediaFromAlbum:input_type -> feed.v1.RemoveMediaFromAlbumRequest
+	45, // 57: feed.v1.FeedService.GetAlbumMedia:input_type -> feed.v1.GetAlbumMediaRequest
+	0,  // 58: feed.v1.FeedService.CreatePost:output_type -> feed.v1.PostResponse
+	0,  // 59: feed.v1.FeedService.GetPost:output_type -> feed.v1.PostResponse
+	0,  // 60: feed.v1.FeedService.UpdatePost:output_type -> feed.v1.PostResponse
+	47, // 61: feed.v1.FeedService.DeletePost:output_type -> google.protobuf.Empty
+	7,  // 62: feed.v1.FeedService.ListPosts:output_type -> feed.v1.FeedResponse
+	7,  // 63: feed.v1.FeedService.GetPostsByHashtag:output_type -> feed.v1.FeedResponse
+	23, // 64: feed.v1.FeedService.GetTrendingHashtags:output_type -> feed.v1.GetTrendingHashtagsResponse
+	47, // 65: feed.v1.FeedService.UpdatePostStatus:output_type -> google.protobuf.Empty
+	0,  // 66: feed.v1.FeedService.SharePost:output_type -> feed.v1.PostResponse
+	5,  // 67: feed.v1.FeedService.CreateComment:output_type -> feed.v1.CommentResponse
+	5,  // 68: feed.v1.FeedService.GetComment:output_type -> feed.v1.CommentResponse
+	5,  // 69: feed.v1.FeedService.UpdateComment:output_type -> feed.v1.CommentResponse
+	47, // 70: feed.v1.FeedService.DeleteComment:output_type -> google.protobuf.Empty
+	8,  // 71: feed.v1.FeedService.ListComments:output_type -> feed.v1.ListCommentsResponse
+	6,  // 72: feed.v1.FeedService.CreateReply:output_type -> feed.v1.ReplyResponse
+	6,  // 73: feed.v1.FeedService.GetReply:output_type -> feed.v1.ReplyResponse
+	6,  // 74: feed.v1.FeedService.UpdateReply:output_type -> feed.v1.ReplyResponse
+	47, // 75: feed.v1.FeedService.DeleteReply:output_type -> google.protobuf.Empty
+	9,  // 76: feed.v1.FeedService.ListReplies:output_type -> feed.v1.ListRepliesResponse
+	47, // 77: feed.v1.FeedService.ReactToPost:output_type -> google.protobuf.Empty
+	47, // 78: feed.v1.FeedService.ReactToComment:output_type -> google.protobuf.Empty
+	47, // 79: feed.v1.FeedService.ReactToReply:output_type -> google.protobuf.Empty
+	10, // 80: feed.v1.FeedService.CreateAlbum:output_type -> feed.v1.AlbumResponse
+	10, // 81: feed.v1.FeedService.GetAlbum:output_type -> feed.v1.AlbumResponse
+	10, // 82: feed.v1.FeedService.UpdateAlbum:output_type -> feed.v1.AlbumResponse
+	47, // 83: feed.v1.FeedService.DeleteAlbum:output_type -> google.protobuf.Empty
+	12, // 84: feed.v1.FeedService.ListAlbums:output_type -> feed.v1.ListAlbumsResponse
+	11, // 85: feed.v1.FeedService.AddMediaToAlbum:output_type -> feed.v1.AlbumMediaResponse
+	47, // 86: feed.v1.FeedService.RemoveMediaFromAlbum:output_type -> google.protobuf.Empty
+	13, // 87: feed.v1.FeedService.GetAlbumMedia:output_type -> feed.v1.GetAlbumMediaResponse
+	58, // [58:88] is the sub-list for method output_type
+	28, // [28:58] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_proto_feed_v1_feed_proto_init() }
@@ -3243,7 +3822,7 @@ func file_proto_feed_v1_feed_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_feed_v1_feed_proto_rawDesc), len(file_proto_feed_v1_feed_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   40,
+			NumMessages:   46,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,7 +26,9 @@ const (
 	FeedService_DeletePost_FullMethodName           = "/feed.v1.FeedService/DeletePost"
 	FeedService_ListPosts_FullMethodName            = "/feed.v1.FeedService/ListPosts"
 	FeedService_GetPostsByHashtag_FullMethodName    = "/feed.v1.FeedService/GetPostsByHashtag"
+	FeedService_GetTrendingHashtags_FullMethodName  = "/feed.v1.FeedService/GetTrendingHashtags"
 	FeedService_UpdatePostStatus_FullMethodName     = "/feed.v1.FeedService/UpdatePostStatus"
+	FeedService_SharePost_FullMethodName            = "/feed.v1.FeedService/SharePost"
 	FeedService_CreateComment_FullMethodName        = "/feed.v1.FeedService/CreateComment"
 	FeedService_GetComment_FullMethodName           = "/feed.v1.FeedService/GetComment"
 	FeedService_UpdateComment_FullMethodName        = "/feed.v1.FeedService/UpdateComment"
@@ -61,7 +63,9 @@ type FeedServiceClient interface {
 	DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ListPosts(ctx context.Context, in *ListPostsRequest, opts ...grpc.CallOption) (*FeedResponse, error)
 	GetPostsByHashtag(ctx context.Context, in *GetPostsByHashtagRequest, opts ...grpc.CallOption) (*FeedResponse, error)
+	GetTrendingHashtags(ctx context.Context, in *GetTrendingHashtagsRequest, opts ...grpc.CallOption) (*GetTrendingHashtagsResponse, error)
 	UpdatePostStatus(ctx context.Context, in *UpdatePostStatusRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SharePost(ctx context.Context, in *SharePostRequest, opts ...grpc.CallOption) (*PostResponse, error)
 	// Comment Operations
 	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CommentResponse, error)
 	GetComment(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*CommentResponse, error)
@@ -158,6 +162,16 @@ func (c *feedServiceClient) GetPostsByHashtag(ctx context.Context, in *GetPostsB
 	return out, nil
 }
 
+func (c *feedServiceClient) GetTrendingHashtags(ctx context.Context, in *GetTrendingHashtagsRequest, opts ...grpc.CallOption) (*GetTrendingHashtagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrendingHashtagsResponse)
+	err := c.cc.Invoke(ctx, FeedService_GetTrendingHashtags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *feedServiceClient) UpdatePostStatus(ctx context.Context, in *UpdatePostStatusRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -168,6 +182,16 @@ func (c *feedServiceClient) UpdatePostStatus(ctx context.Context, in *UpdatePost
 	return out, nil
 }
 
+func (c *feedServiceClient) SharePost(ctx context.Context, in *SharePostRequest, opts ...grpc.CallOption) (*PostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostResponse)
+	err := c.cc.Invoke(ctx, FeedService_SharePost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *feedServiceClient) CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CommentResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CommentResponse)
@@ -389,7 +413,9 @@ type FeedServiceServer interface {
 	DeletePost(context.Context, *DeletePostRequest) (*emptypb.Empty, error)
 	ListPosts(context.Context, *ListPostsRequest) (*FeedResponse, error)
 	GetPostsByHashtag(context.Context, *GetPostsByHashtagRequest) (*FeedResponse, error)
+	GetTrendingHashtags(context.Context, *GetTrendingHashtagsRequest) (*GetTrendingHashtagsResponse, error)
 	UpdatePostStatus(context.Context, *UpdatePostStatusRequest) (*emptypb.Empty, error)
+	SharePost(context.Context, *SharePostRequest) (*PostResponse, error)
 	// Comment Operations
 	CreateComment(context.Context, *CreateCommentRequest) (*CommentResponse, error)
 	GetComment(context.Context, *GetCommentRequest) (*CommentResponse, error)
@@ -444,9 +470,15 @@ func (UnimplementedFeedServiceServer) ListPosts(context.Context, *ListPostsReque
 func (UnimplementedFeedServiceServer) GetPostsByHashtag(context.Context, *GetPostsByHashtagRequest) (*FeedResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPostsByHashtag not implemented")
 }
+func (UnimplementedFeedServiceServer) GetTrendingHashtags(context.Context, *GetTrendingHashtagsRequest) (*GetTrendingHashtagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTrendingHashtags not implemented")
+}
 func (UnimplementedFeedServiceServer) UpdatePostStatus(context.Context, *UpdatePostStatusRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdatePostStatus not implemented")
 }
+func (UnimplementedFeedServiceServer) SharePost(context.Context, *SharePostRequest) (*PostResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SharePost not implemented")
+}
 func (UnimplementedFeedServiceServer) CreateComment(context.Context, *CreateCommentRequest) (*CommentResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateComment not implemented")
 }
@@ -639,6 +671,24 @@ func _FeedService_GetPostsByHashtag_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FeedService_GetTrendingHashtags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrendingHashtagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServiceServer).GetTrendingHashtags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedService_GetTrendingHashtags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServiceServer).GetTrendingHashtags(ctx, req.(*GetTrendingHashtagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FeedService_UpdatePostStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdatePostStatusRequest)
 	if err := dec(in); err != nil {
@@ -657,6 +707,24 @@ func _FeedService_UpdatePostStatus_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FeedService_SharePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServiceServer).SharePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedService_SharePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServiceServer).SharePost(ctx, req.(*SharePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FeedService_CreateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateCommentRequest)
 	if err := dec(in); err != nil {
@@ -1066,10 +1134,18 @@ var FeedService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPostsByHashtag",
 			Handler:    _FeedService_GetPostsByHashtag_Handler,
 		},
+		{
+			MethodName: "GetTrendingHashtags",
+			Handler:    _FeedService_GetTrendingHashtags_Handler,
+		},
 		{
 			MethodName: "UpdatePostStatus",
 			Handler:    _FeedService_UpdatePostStatus_Handler,
 		},
+		{
+			MethodName: "SharePost",
+			Handler:    _FeedService_SharePost_Handler,
+		},
 		{
 			MethodName: "CreateComment",
 			Handler:    _FeedService_CreateComment_Handler,
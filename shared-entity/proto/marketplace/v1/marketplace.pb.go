@@ -106,6 +106,7 @@ type UserShort struct {
 	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
 	FullName      string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
 	Avatar        string                 `protobuf:"bytes,4,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Verified      bool                   `protobuf:"varint,5,opt,name=verified,proto3" json:"verified,omitempty"` // Identity verified badge
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -168,6 +169,13 @@ func (x *UserShort) GetAvatar() string {
 	return ""
 }
 
+func (x *UserShort) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
 type Category struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1383,12 +1391,13 @@ const file_proto_marketplace_v1_marketplace_proto_rawDesc = "" +
 	"\x05state\x18\x02 \x01(\tR\x05state\x12\x18\n" +
 	"\acountry\x18\x03 \x01(\tR\acountry\x12\x1a\n" +
 	"\blatitude\x18\x04 \x01(\x01R\blatitude\x12\x1c\n" +
-	"\tlongitude\x18\x05 \x01(\x01R\tlongitude\"l\n" +
+	"\tlongitude\x18\x05 \x01(\x01R\tlongitude\"\x88\x01\n" +
 	"\tUserShort\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
 	"\tfull_name\x18\x03 \x01(\tR\bfullName\x12\x16\n" +
-	"\x06avatar\x18\x04 \x01(\tR\x06avatar\"l\n" +
+	"\x06avatar\x18\x04 \x01(\tR\x06avatar\x12\x1a\n" +
+	"\bverified\x18\x05 \x01(\bR\bverified\"l\n" +
 	"\bCategory\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -1503,7 +1512,7 @@ const file_proto_marketplace_v1_marketplace_proto_rawDesc = "" +
 	"\rGetCategories\x12\x16.google.protobuf.Empty\x1a%.marketplace.v1.GetCategoriesResponse\x12h\n" +
 	"\x11ToggleSaveProduct\x12(.marketplace.v1.ToggleSaveProductRequest\x1a).marketplace.v1.ToggleSaveProductResponse\x12c\n" +
 	"\x10GetSavedProducts\x12'.marketplace.v1.GetSavedProductsRequest\x1a&.marketplace.v1.SearchProductsResponse\x12p\n" +
-	"\x1bGetMarketplaceConversations\x12'.marketplace.v1.GetConversationsRequest\x1a(.marketplace.v1.GetConversationsResponseBMZKgithub.com/MuhibNayem/connectify-v2/shared-entity/proto/marketplace/v1;marketplacepbb\x06proto3"
+	"\x1bGetMarketplaceConversations\x12'.marketplace.v1.GetConversationsRequest\x1a(.marketplace.v1.GetConversationsResponseBMZKgitlab.com/spydotech-group/shared-entity/proto/marketplace/v1;marketplacepbb\x06proto3"
 
 var (
 	file_proto_marketplace_v1_marketplace_proto_rawDescOnce sync.Once
@@ -312,6 +312,7 @@ type CreateReelRequest struct {
 	Privacy        string                 `protobuf:"bytes,6,opt,name=privacy,proto3" json:"privacy,omitempty"`
 	AllowedViewers []string               `protobuf:"bytes,7,rep,name=allowed_viewers,json=allowedViewers,proto3" json:"allowed_viewers,omitempty"`
 	BlockedViewers []string               `protobuf:"bytes,8,rep,name=blocked_viewers,json=blockedViewers,proto3" json:"blocked_viewers,omitempty"`
+	Category       string                 `protobuf:"bytes,9,opt,name=category,proto3" json:"category,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -402,6 +403,13 @@ func (x *CreateReelRequest) GetBlockedViewers() []string {
 	return nil
 }
 
+func (x *CreateReelRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
 type CreateReelResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Reel          *Reel                  `protobuf:"bytes,1,opt,name=reel,proto3" json:"reel,omitempty"`
@@ -655,13 +663,15 @@ func (x *AddCommentResponse) GetComment() *Comment {
 }
 
 type AddReplyRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReelId        string                 `protobuf:"bytes,1,opt,name=reel_id,json=reelId,proto3" json:"reel_id,omitempty"`
-	CommentId     string                 `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ReelId           string                 `protobuf:"bytes,1,opt,name=reel_id,json=reelId,proto3" json:"reel_id,omitempty"`
+	CommentId        string                 `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	UserId           string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Content          string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	ParentReplyId    string                 `protobuf:"bytes,5,opt,name=parent_reply_id,json=parentReplyId,proto3" json:"parent_reply_id,omitempty"` // Set when replying to another reply in the thread
+	ExplicitMentions []string               `protobuf:"bytes,6,rep,name=explicit_mentions,json=explicitMentions,proto3" json:"explicit_mentions,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *AddReplyRequest) Reset() {
@@ -722,6 +732,20 @@ func (x *AddReplyRequest) GetContent() string {
 	return ""
 }
 
+func (x *AddReplyRequest) GetParentReplyId() string {
+	if x != nil {
+		return x.ParentReplyId
+	}
+	return ""
+}
+
+func (x *AddReplyRequest) GetExplicitMentions() []string {
+	if x != nil {
+		return x.ExplicitMentions
+	}
+	return nil
+}
+
 type AddReplyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Reply         *Reply                 `protobuf:"bytes,1,opt,name=reply,proto3" json:"reply,omitempty"`
@@ -878,29 +902,30 @@ func (x *ReactToCommentResponse) GetSuccess() bool {
 	return false
 }
 
-type ReactToReelRequest struct {
+type GetRepliesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ReelId        string                 `protobuf:"bytes,1,opt,name=reel_id,json=reelId,proto3" json:"reel_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	ReactionType  string                 `protobuf:"bytes,3,opt,name=reaction_type,json=reactionType,proto3" json:"reaction_type,omitempty"`
+	CommentId     string                 `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int64                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReactToReelRequest) Reset() {
-	*x = ReactToReelRequest{}
+func (x *GetRepliesRequest) Reset() {
+	*x = GetRepliesRequest{}
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReactToReelRequest) String() string {
+func (x *GetRepliesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReactToReelRequest) ProtoMessage() {}
+func (*GetRepliesRequest) ProtoMessage() {}
 
-func (x *ReactToReelRequest) ProtoReflect() protoreflect.Message {
+func (x *GetRepliesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -912,53 +937,60 @@ func (x *ReactToReelRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReactToReelRequest.ProtoReflect.Descriptor instead.
-func (*ReactToReelRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetRepliesRequest.ProtoReflect.Descriptor instead.
+func (*GetRepliesRequest) Descriptor() ([]byte, []int) {
 	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ReactToReelRequest) GetReelId() string {
+func (x *GetRepliesRequest) GetReelId() string {
 	if x != nil {
 		return x.ReelId
 	}
 	return ""
 }
 
-func (x *ReactToReelRequest) GetUserId() string {
+func (x *GetRepliesRequest) GetCommentId() string {
 	if x != nil {
-		return x.UserId
+		return x.CommentId
 	}
 	return ""
 }
 
-func (x *ReactToReelRequest) GetReactionType() string {
+func (x *GetRepliesRequest) GetLimit() int64 {
 	if x != nil {
-		return x.ReactionType
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-type ReactToReelResponse struct {
+func (x *GetRepliesRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type GetRepliesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Replies       []*Reply               `protobuf:"bytes,1,rep,name=replies,proto3" json:"replies,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReactToReelResponse) Reset() {
-	*x = ReactToReelResponse{}
+func (x *GetRepliesResponse) Reset() {
+	*x = GetRepliesResponse{}
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReactToReelResponse) String() string {
+func (x *GetRepliesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReactToReelResponse) ProtoMessage() {}
+func (*GetRepliesResponse) ProtoMessage() {}
 
-func (x *ReactToReelResponse) ProtoReflect() protoreflect.Message {
+func (x *GetRepliesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -970,41 +1002,43 @@ func (x *ReactToReelResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReactToReelResponse.ProtoReflect.Descriptor instead.
-func (*ReactToReelResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetRepliesResponse.ProtoReflect.Descriptor instead.
+func (*GetRepliesResponse) Descriptor() ([]byte, []int) {
 	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ReactToReelResponse) GetSuccess() bool {
+func (x *GetRepliesResponse) GetReplies() []*Reply {
 	if x != nil {
-		return x.Success
+		return x.Replies
 	}
-	return false
+	return nil
 }
 
-type GetCommentsRequest struct {
+type ReactToReplyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ReelId        string                 `protobuf:"bytes,1,opt,name=reel_id,json=reelId,proto3" json:"reel_id,omitempty"`
-	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	CommentId     string                 `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	ReplyId       string                 `protobuf:"bytes,3,opt,name=reply_id,json=replyId,proto3" json:"reply_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ReactionType  string                 `protobuf:"bytes,5,opt,name=reaction_type,json=reactionType,proto3" json:"reaction_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCommentsRequest) Reset() {
-	*x = GetCommentsRequest{}
+func (x *ReactToReplyRequest) Reset() {
+	*x = ReactToReplyRequest{}
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCommentsRequest) String() string {
+func (x *ReactToReplyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCommentsRequest) ProtoMessage() {}
+func (*ReactToReplyRequest) ProtoMessage() {}
 
-func (x *GetCommentsRequest) ProtoReflect() protoreflect.Message {
+func (x *ReactToReplyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1016,53 +1050,67 @@ func (x *GetCommentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCommentsRequest.ProtoReflect.Descriptor instead.
-func (*GetCommentsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReactToReplyRequest.ProtoReflect.Descriptor instead.
+func (*ReactToReplyRequest) Descriptor() ([]byte, []int) {
 	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *GetCommentsRequest) GetReelId() string {
+func (x *ReactToReplyRequest) GetReelId() string {
 	if x != nil {
 		return x.ReelId
 	}
 	return ""
 }
 
-func (x *GetCommentsRequest) GetLimit() int64 {
+func (x *ReactToReplyRequest) GetCommentId() string {
 	if x != nil {
-		return x.Limit
+		return x.CommentId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetCommentsRequest) GetOffset() int64 {
+func (x *ReactToReplyRequest) GetReplyId() string {
 	if x != nil {
-		return x.Offset
+		return x.ReplyId
 	}
-	return 0
+	return ""
 }
 
-type GetCommentsResponse struct {
+func (x *ReactToReplyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReactToReplyRequest) GetReactionType() string {
+	if x != nil {
+		return x.ReactionType
+	}
+	return ""
+}
+
+type ReactToReplyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCommentsResponse) Reset() {
-	*x = GetCommentsResponse{}
+func (x *ReactToReplyResponse) Reset() {
+	*x = ReactToReplyResponse{}
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCommentsResponse) String() string {
+func (x *ReactToReplyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCommentsResponse) ProtoMessage() {}
+func (*ReactToReplyResponse) ProtoMessage() {}
 
-func (x *GetCommentsResponse) ProtoReflect() protoreflect.Message {
+func (x *ReactToReplyResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1074,40 +1122,41 @@ func (x *GetCommentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCommentsResponse.ProtoReflect.Descriptor instead.
-func (*GetCommentsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReactToReplyResponse.ProtoReflect.Descriptor instead.
+func (*ReactToReplyResponse) Descriptor() ([]byte, []int) {
 	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetCommentsResponse) GetComments() []*Comment {
+func (x *ReactToReplyResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Comments
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-type IncrementViewRequest struct {
+type DeleteCommentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ReelId        string                 `protobuf:"bytes,1,opt,name=reel_id,json=reelId,proto3" json:"reel_id,omitempty"`
-	ViewerId      string                 `protobuf:"bytes,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	CommentId     string                 `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementViewRequest) Reset() {
-	*x = IncrementViewRequest{}
+func (x *DeleteCommentRequest) Reset() {
+	*x = DeleteCommentRequest{}
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementViewRequest) String() string {
+func (x *DeleteCommentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementViewRequest) ProtoMessage() {}
+func (*DeleteCommentRequest) ProtoMessage() {}
 
-func (x *IncrementViewRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1119,46 +1168,53 @@ func (x *IncrementViewRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementViewRequest.ProtoReflect.Descriptor instead.
-func (*IncrementViewRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
 	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *IncrementViewRequest) GetReelId() string {
+func (x *DeleteCommentRequest) GetReelId() string {
 	if x != nil {
 		return x.ReelId
 	}
 	return ""
 }
 
-func (x *IncrementViewRequest) GetViewerId() string {
+func (x *DeleteCommentRequest) GetCommentId() string {
 	if x != nil {
-		return x.ViewerId
+		return x.CommentId
 	}
 	return ""
 }
 
-type IncrementViewResponse struct {
+func (x *DeleteCommentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteCommentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementViewResponse) Reset() {
-	*x = IncrementViewResponse{}
+func (x *DeleteCommentResponse) Reset() {
+	*x = DeleteCommentResponse{}
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementViewResponse) String() string {
+func (x *DeleteCommentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementViewResponse) ProtoMessage() {}
+func (*DeleteCommentResponse) ProtoMessage() {}
 
-func (x *IncrementViewResponse) ProtoReflect() protoreflect.Message {
+func (x *DeleteCommentResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1170,51 +1226,42 @@ func (x *IncrementViewResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementViewResponse.ProtoReflect.Descriptor instead.
-func (*IncrementViewResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteCommentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCommentResponse) Descriptor() ([]byte, []int) {
 	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *IncrementViewResponse) GetSuccess() bool {
+func (x *DeleteCommentResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-type Reel struct {
+type DeleteReplyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	VideoUrl      string                 `protobuf:"bytes,3,opt,name=video_url,json=videoUrl,proto3" json:"video_url,omitempty"`
-	ThumbnailUrl  string                 `protobuf:"bytes,4,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
-	Caption       string                 `protobuf:"bytes,5,opt,name=caption,proto3" json:"caption,omitempty"`
-	Duration      float64                `protobuf:"fixed64,6,opt,name=duration,proto3" json:"duration,omitempty"`
-	Privacy       string                 `protobuf:"bytes,7,opt,name=privacy,proto3" json:"privacy,omitempty"`
-	Views         int64                  `protobuf:"varint,8,opt,name=views,proto3" json:"views,omitempty"`
-	Likes         int64                  `protobuf:"varint,9,opt,name=likes,proto3" json:"likes,omitempty"`
-	Comments      int64                  `protobuf:"varint,10,opt,name=comments,proto3" json:"comments,omitempty"`
-	Author        *Author                `protobuf:"bytes,11,opt,name=author,proto3" json:"author,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ReelId        string                 `protobuf:"bytes,1,opt,name=reel_id,json=reelId,proto3" json:"reel_id,omitempty"`
+	CommentId     string                 `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	ReplyId       string                 `protobuf:"bytes,3,opt,name=reply_id,json=replyId,proto3" json:"reply_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Reel) Reset() {
-	*x = Reel{}
+func (x *DeleteReplyRequest) Reset() {
+	*x = DeleteReplyRequest{}
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Reel) String() string {
+func (x *DeleteReplyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Reel) ProtoMessage() {}
+func (*DeleteReplyRequest) ProtoMessage() {}
 
-func (x *Reel) ProtoReflect() protoreflect.Message {
+func (x *DeleteReplyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_reel_v1_reel_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1226,133 +1273,107 @@ func (x *Reel) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Reel.ProtoReflect.Descriptor instead.
-func (*Reel) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteReplyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteReplyRequest) Descriptor() ([]byte, []int) {
 	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *Reel) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Reel) GetUserId() string {
+func (x *DeleteReplyRequest) GetReelId() string {
 	if x != nil {
-		return x.UserId
+		return x.ReelId
 	}
 	return ""
 }
 
-func (x *Reel) GetVideoUrl() string {
+func (x *DeleteReplyRequest) GetCommentId() string {
 	if x != nil {
-		return x.VideoUrl
+		return x.CommentId
 	}
 	return ""
 }
 
-func (x *Reel) GetThumbnailUrl() string {
+func (x *DeleteReplyRequest) GetReplyId() string {
 	if x != nil {
-		return x.ThumbnailUrl
+		return x.ReplyId
 	}
 	return ""
 }
 
-func (x *Reel) GetCaption() string {
+func (x *DeleteReplyRequest) GetUserId() string {
 	if x != nil {
-		return x.Caption
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *Reel) GetDuration() float64 {
-	if x != nil {
-		return x.Duration
-	}
-	return 0
-}
-
-func (x *Reel) GetPrivacy() string {
-	if x != nil {
-		return x.Privacy
-	}
-	return ""
+type DeleteReplyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Reel) GetViews() int64 {
-	if x != nil {
-		return x.Views
-	}
-	return 0
+func (x *DeleteReplyResponse) Reset() {
+	*x = DeleteReplyResponse{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Reel) GetLikes() int64 {
-	if x != nil {
-		return x.Likes
-	}
-	return 0
+func (x *DeleteReplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Reel) GetComments() int64 {
-	if x != nil {
-		return x.Comments
-	}
-	return 0
-}
+func (*DeleteReplyResponse) ProtoMessage() {}
 
-func (x *Reel) GetAuthor() *Author {
+func (x *DeleteReplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[23]
 	if x != nil {
-		return x.Author
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Reel) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
+// Deprecated: Use DeleteReplyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteReplyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *Reel) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *DeleteReplyResponse) GetSuccess() bool {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-type Comment struct {
+type ReactToReelRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReelId        string                 `protobuf:"bytes,1,opt,name=reel_id,json=reelId,proto3" json:"reel_id,omitempty"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Author        *Author                `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
-	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
-	Mentions      []string               `protobuf:"bytes,5,rep,name=mentions,proto3" json:"mentions,omitempty"` // User IDs
-	LikeCount     int64                  `protobuf:"varint,6,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
-	ReplyCount    int64                  `protobuf:"varint,7,opt,name=reply_count,json=replyCount,proto3" json:"reply_count,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Replies       []*Reply               `protobuf:"bytes,10,rep,name=replies,proto3" json:"replies,omitempty"`
+	ReactionType  string                 `protobuf:"bytes,3,opt,name=reaction_type,json=reactionType,proto3" json:"reaction_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Comment) Reset() {
-	*x = Comment{}
-	mi := &file_proto_reel_v1_reel_proto_msgTypes[23]
+func (x *ReactToReelRequest) Reset() {
+	*x = ReactToReelRequest{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Comment) String() string {
+func (x *ReactToReelRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Comment) ProtoMessage() {}
+func (*ReactToReelRequest) ProtoMessage() {}
 
-func (x *Comment) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_reel_v1_reel_proto_msgTypes[23]
+func (x *ReactToReelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1363,11 +1384,1550 @@ func (x *Comment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
-func (*Comment) Descriptor() ([]byte, []int) {
-	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{23}
-}
-
+// Deprecated: Use ReactToReelRequest.ProtoReflect.Descriptor instead.
+func (*ReactToReelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReactToReelRequest) GetReelId() string {
+	if x != nil {
+		return x.ReelId
+	}
+	return ""
+}
+
+func (x *ReactToReelRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReactToReelRequest) GetReactionType() string {
+	if x != nil {
+		return x.ReactionType
+	}
+	return ""
+}
+
+type ReactToReelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactToReelResponse) Reset() {
+	*x = ReactToReelResponse{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactToReelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactToReelResponse) ProtoMessage() {}
+
+func (x *ReactToReelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactToReelResponse.ProtoReflect.Descriptor instead.
+func (*ReactToReelResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReactToReelResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReelId        string                 `protobuf:"bytes,1,opt,name=reel_id,json=reelId,proto3" json:"reel_id,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommentsRequest) Reset() {
+	*x = GetCommentsRequest{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentsRequest) ProtoMessage() {}
+
+func (x *GetCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentsRequest.ProtoReflect.Descriptor instead.
+func (*GetCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetCommentsRequest) GetReelId() string {
+	if x != nil {
+		return x.ReelId
+	}
+	return ""
+}
+
+func (x *GetCommentsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetCommentsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type GetCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommentsResponse) Reset() {
+	*x = GetCommentsResponse{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentsResponse) ProtoMessage() {}
+
+func (x *GetCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentsResponse.ProtoReflect.Descriptor instead.
+func (*GetCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+type GetReelProcessingStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReelId        string                 `protobuf:"bytes,1,opt,name=reel_id,json=reelId,proto3" json:"reel_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReelProcessingStatusRequest) Reset() {
+	*x = GetReelProcessingStatusRequest{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReelProcessingStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReelProcessingStatusRequest) ProtoMessage() {}
+
+func (x *GetReelProcessingStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReelProcessingStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetReelProcessingStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetReelProcessingStatusRequest) GetReelId() string {
+	if x != nil {
+		return x.ReelId
+	}
+	return ""
+}
+
+type GetReelProcessingStatusResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ReelId           string                 `protobuf:"bytes,1,opt,name=reel_id,json=reelId,proto3" json:"reel_id,omitempty"`
+	ProcessingStatus string                 `protobuf:"bytes,2,opt,name=processing_status,json=processingStatus,proto3" json:"processing_status,omitempty"` // pending, processing, ready or failed; empty for reels that predate transcoding
+	PlaybackUrl      string                 `protobuf:"bytes,3,opt,name=playback_url,json=playbackUrl,proto3" json:"playback_url,omitempty"`                // HLS master playlist, set once ready
+	Renditions       []*Rendition           `protobuf:"bytes,4,rep,name=renditions,proto3" json:"renditions,omitempty"`
+	Error            string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetReelProcessingStatusResponse) Reset() {
+	*x = GetReelProcessingStatusResponse{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReelProcessingStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReelProcessingStatusResponse) ProtoMessage() {}
+
+func (x *GetReelProcessingStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReelProcessingStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetReelProcessingStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetReelProcessingStatusResponse) GetReelId() string {
+	if x != nil {
+		return x.ReelId
+	}
+	return ""
+}
+
+func (x *GetReelProcessingStatusResponse) GetProcessingStatus() string {
+	if x != nil {
+		return x.ProcessingStatus
+	}
+	return ""
+}
+
+func (x *GetReelProcessingStatusResponse) GetPlaybackUrl() string {
+	if x != nil {
+		return x.PlaybackUrl
+	}
+	return ""
+}
+
+func (x *GetReelProcessingStatusResponse) GetRenditions() []*Rendition {
+	if x != nil {
+		return x.Renditions
+	}
+	return nil
+}
+
+func (x *GetReelProcessingStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// GetForYouFeed recommends reels from followed creators, trending reels and
+// reels like those the viewer engaged with, leaving out reels already served
+type GetForYouFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ViewerId      string                 `protobuf:"bytes,1,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetForYouFeedRequest) Reset() {
+	*x = GetForYouFeedRequest{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetForYouFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetForYouFeedRequest) ProtoMessage() {}
+
+func (x *GetForYouFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetForYouFeedRequest.ProtoReflect.Descriptor instead.
+func (*GetForYouFeedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetForYouFeedRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+func (x *GetForYouFeedRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetForYouFeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reels         []*Reel                `protobuf:"bytes,1,rep,name=reels,proto3" json:"reels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetForYouFeedResponse) Reset() {
+	*x = GetForYouFeedResponse{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetForYouFeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetForYouFeedResponse) ProtoMessage() {}
+
+func (x *GetForYouFeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetForYouFeedResponse.ProtoReflect.Descriptor instead.
+func (*GetForYouFeedResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetForYouFeedResponse) GetReels() []*Reel {
+	if x != nil {
+		return x.Reels
+	}
+	return nil
+}
+
+type RecordEngagementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReelId        string                 `protobuf:"bytes,1,opt,name=reel_id,json=reelId,proto3" json:"reel_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Signal        string                 `protobuf:"bytes,3,opt,name=signal,proto3" json:"signal,omitempty"`                                 // watch, complete, like or share
+	WatchTimeMs   int64                  `protobuf:"varint,4,opt,name=watch_time_ms,json=watchTimeMs,proto3" json:"watch_time_ms,omitempty"` // For watch
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordEngagementRequest) Reset() {
+	*x = RecordEngagementRequest{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordEngagementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordEngagementRequest) ProtoMessage() {}
+
+func (x *RecordEngagementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordEngagementRequest.ProtoReflect.Descriptor instead.
+func (*RecordEngagementRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RecordEngagementRequest) GetReelId() string {
+	if x != nil {
+		return x.ReelId
+	}
+	return ""
+}
+
+func (x *RecordEngagementRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RecordEngagementRequest) GetSignal() string {
+	if x != nil {
+		return x.Signal
+	}
+	return ""
+}
+
+func (x *RecordEngagementRequest) GetWatchTimeMs() int64 {
+	if x != nil {
+		return x.WatchTimeMs
+	}
+	return 0
+}
+
+type RecordEngagementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordEngagementResponse) Reset() {
+	*x = RecordEngagementResponse{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordEngagementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordEngagementResponse) ProtoMessage() {}
+
+func (x *RecordEngagementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordEngagementResponse.ProtoReflect.Descriptor instead.
+func (*RecordEngagementResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RecordEngagementResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type CreateLiveSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Privacy       string                 `protobuf:"bytes,3,opt,name=privacy,proto3" json:"privacy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLiveSessionRequest) Reset() {
+	*x = CreateLiveSessionRequest{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLiveSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLiveSessionRequest) ProtoMessage() {}
+
+func (x *CreateLiveSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLiveSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateLiveSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CreateLiveSessionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateLiveSessionRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateLiveSessionRequest) GetPrivacy() string {
+	if x != nil {
+		return x.Privacy
+	}
+	return ""
+}
+
+type GetLiveSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ViewerId      string                 `protobuf:"bytes,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLiveSessionRequest) Reset() {
+	*x = GetLiveSessionRequest{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLiveSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLiveSessionRequest) ProtoMessage() {}
+
+func (x *GetLiveSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLiveSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetLiveSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetLiveSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *GetLiveSessionRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+type LiveSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *LiveSession           `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LiveSessionResponse) Reset() {
+	*x = LiveSessionResponse{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiveSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiveSessionResponse) ProtoMessage() {}
+
+func (x *LiveSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiveSessionResponse.ProtoReflect.Descriptor instead.
+func (*LiveSessionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *LiveSessionResponse) GetSession() *LiveSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type ListLiveSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ViewerId      string                 `protobuf:"bytes,1,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLiveSessionsRequest) Reset() {
+	*x = ListLiveSessionsRequest{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLiveSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLiveSessionsRequest) ProtoMessage() {}
+
+func (x *ListLiveSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLiveSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListLiveSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListLiveSessionsRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+func (x *ListLiveSessionsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListLiveSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*LiveSession         `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLiveSessionsResponse) Reset() {
+	*x = ListLiveSessionsResponse{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLiveSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLiveSessionsResponse) ProtoMessage() {}
+
+func (x *ListLiveSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLiveSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListLiveSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListLiveSessionsResponse) GetSessions() []*LiveSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type EndLiveSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndLiveSessionRequest) Reset() {
+	*x = EndLiveSessionRequest{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndLiveSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndLiveSessionRequest) ProtoMessage() {}
+
+func (x *EndLiveSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndLiveSessionRequest.ProtoReflect.Descriptor instead.
+func (*EndLiveSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *EndLiveSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *EndLiveSessionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Viewers join again every so often to stay counted
+type LiveViewerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ViewerId      string                 `protobuf:"bytes,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LiveViewerRequest) Reset() {
+	*x = LiveViewerRequest{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiveViewerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiveViewerRequest) ProtoMessage() {}
+
+func (x *LiveViewerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiveViewerRequest.ProtoReflect.Descriptor instead.
+func (*LiveViewerRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *LiveViewerRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *LiveViewerRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+type LiveViewerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ViewerCount   int64                  `protobuf:"varint,1,opt,name=viewer_count,json=viewerCount,proto3" json:"viewer_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LiveViewerResponse) Reset() {
+	*x = LiveViewerResponse{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiveViewerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiveViewerResponse) ProtoMessage() {}
+
+func (x *LiveViewerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiveViewerResponse.ProtoReflect.Descriptor instead.
+func (*LiveViewerResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *LiveViewerResponse) GetViewerCount() int64 {
+	if x != nil {
+		return x.ViewerCount
+	}
+	return 0
+}
+
+type SendLiveChatMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendLiveChatMessageRequest) Reset() {
+	*x = SendLiveChatMessageRequest{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendLiveChatMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendLiveChatMessageRequest) ProtoMessage() {}
+
+func (x *SendLiveChatMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendLiveChatMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendLiveChatMessageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SendLiveChatMessageRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SendLiveChatMessageRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SendLiveChatMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type SendLiveChatMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *LiveChatMessage       `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendLiveChatMessageResponse) Reset() {
+	*x = SendLiveChatMessageResponse{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendLiveChatMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendLiveChatMessageResponse) ProtoMessage() {}
+
+func (x *SendLiveChatMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendLiveChatMessageResponse.ProtoReflect.Descriptor instead.
+func (*SendLiveChatMessageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SendLiveChatMessageResponse) GetMessage() *LiveChatMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type GetLiveChatMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ViewerId      string                 `protobuf:"bytes,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Before        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=before,proto3" json:"before,omitempty"` // Older messages than this, for paging back
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLiveChatMessagesRequest) Reset() {
+	*x = GetLiveChatMessagesRequest{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLiveChatMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLiveChatMessagesRequest) ProtoMessage() {}
+
+func (x *GetLiveChatMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLiveChatMessagesRequest.ProtoReflect.Descriptor instead.
+func (*GetLiveChatMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetLiveChatMessagesRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *GetLiveChatMessagesRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+func (x *GetLiveChatMessagesRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetLiveChatMessagesRequest) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+type GetLiveChatMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*LiveChatMessage     `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLiveChatMessagesResponse) Reset() {
+	*x = GetLiveChatMessagesResponse{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLiveChatMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLiveChatMessagesResponse) ProtoMessage() {}
+
+func (x *GetLiveChatMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLiveChatMessagesResponse.ProtoReflect.Descriptor instead.
+func (*GetLiveChatMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetLiveChatMessagesResponse) GetMessages() []*LiveChatMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type IncrementViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReelId        string                 `protobuf:"bytes,1,opt,name=reel_id,json=reelId,proto3" json:"reel_id,omitempty"`
+	ViewerId      string                 `protobuf:"bytes,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementViewRequest) Reset() {
+	*x = IncrementViewRequest{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementViewRequest) ProtoMessage() {}
+
+func (x *IncrementViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementViewRequest.ProtoReflect.Descriptor instead.
+func (*IncrementViewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *IncrementViewRequest) GetReelId() string {
+	if x != nil {
+		return x.ReelId
+	}
+	return ""
+}
+
+func (x *IncrementViewRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+type IncrementViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementViewResponse) Reset() {
+	*x = IncrementViewResponse{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementViewResponse) ProtoMessage() {}
+
+func (x *IncrementViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementViewResponse.ProtoReflect.Descriptor instead.
+func (*IncrementViewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *IncrementViewResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type Reel struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId           string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VideoUrl         string                 `protobuf:"bytes,3,opt,name=video_url,json=videoUrl,proto3" json:"video_url,omitempty"`
+	ThumbnailUrl     string                 `protobuf:"bytes,4,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	Caption          string                 `protobuf:"bytes,5,opt,name=caption,proto3" json:"caption,omitempty"`
+	Duration         float64                `protobuf:"fixed64,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	Privacy          string                 `protobuf:"bytes,7,opt,name=privacy,proto3" json:"privacy,omitempty"`
+	Views            int64                  `protobuf:"varint,8,opt,name=views,proto3" json:"views,omitempty"`
+	Likes            int64                  `protobuf:"varint,9,opt,name=likes,proto3" json:"likes,omitempty"`
+	Comments         int64                  `protobuf:"varint,10,opt,name=comments,proto3" json:"comments,omitempty"`
+	Author           *Author                `protobuf:"bytes,11,opt,name=author,proto3" json:"author,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ProcessingStatus string                 `protobuf:"bytes,14,opt,name=processing_status,json=processingStatus,proto3" json:"processing_status,omitempty"`
+	PlaybackUrl      string                 `protobuf:"bytes,15,opt,name=playback_url,json=playbackUrl,proto3" json:"playback_url,omitempty"`
+	Renditions       []*Rendition           `protobuf:"bytes,16,rep,name=renditions,proto3" json:"renditions,omitempty"`
+	ReactionCounts   map[string]int64       `protobuf:"bytes,17,rep,name=reaction_counts,json=reactionCounts,proto3" json:"reaction_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Category         string                 `protobuf:"bytes,18,opt,name=category,proto3" json:"category,omitempty"`
+	Hashtags         []string               `protobuf:"bytes,19,rep,name=hashtags,proto3" json:"hashtags,omitempty"`
+	Shares           int64                  `protobuf:"varint,20,opt,name=shares,proto3" json:"shares,omitempty"`
+	ResumePositionMs int64                  `protobuf:"varint,21,opt,name=resume_position_ms,json=resumePositionMs,proto3" json:"resume_position_ms,omitempty"` // Set by the gateway from the viewer's watch history
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Reel) Reset() {
+	*x = Reel{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reel) ProtoMessage() {}
+
+func (x *Reel) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reel.ProtoReflect.Descriptor instead.
+func (*Reel) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Reel) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reel) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Reel) GetVideoUrl() string {
+	if x != nil {
+		return x.VideoUrl
+	}
+	return ""
+}
+
+func (x *Reel) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *Reel) GetCaption() string {
+	if x != nil {
+		return x.Caption
+	}
+	return ""
+}
+
+func (x *Reel) GetDuration() float64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Reel) GetPrivacy() string {
+	if x != nil {
+		return x.Privacy
+	}
+	return ""
+}
+
+func (x *Reel) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *Reel) GetLikes() int64 {
+	if x != nil {
+		return x.Likes
+	}
+	return 0
+}
+
+func (x *Reel) GetComments() int64 {
+	if x != nil {
+		return x.Comments
+	}
+	return 0
+}
+
+func (x *Reel) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *Reel) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Reel) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Reel) GetProcessingStatus() string {
+	if x != nil {
+		return x.ProcessingStatus
+	}
+	return ""
+}
+
+func (x *Reel) GetPlaybackUrl() string {
+	if x != nil {
+		return x.PlaybackUrl
+	}
+	return ""
+}
+
+func (x *Reel) GetRenditions() []*Rendition {
+	if x != nil {
+		return x.Renditions
+	}
+	return nil
+}
+
+func (x *Reel) GetReactionCounts() map[string]int64 {
+	if x != nil {
+		return x.ReactionCounts
+	}
+	return nil
+}
+
+func (x *Reel) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Reel) GetHashtags() []string {
+	if x != nil {
+		return x.Hashtags
+	}
+	return nil
+}
+
+func (x *Reel) GetShares() int64 {
+	if x != nil {
+		return x.Shares
+	}
+	return 0
+}
+
+func (x *Reel) GetResumePositionMs() int64 {
+	if x != nil {
+		return x.ResumePositionMs
+	}
+	return 0
+}
+
+type Rendition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Bitrate       int32                  `protobuf:"varint,4,opt,name=bitrate,proto3" json:"bitrate,omitempty"`
+	PlaylistUrl   string                 `protobuf:"bytes,5,opt,name=playlist_url,json=playlistUrl,proto3" json:"playlist_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rendition) Reset() {
+	*x = Rendition{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rendition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rendition) ProtoMessage() {}
+
+func (x *Rendition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rendition.ProtoReflect.Descriptor instead.
+func (*Rendition) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *Rendition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Rendition) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Rendition) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Rendition) GetBitrate() int32 {
+	if x != nil {
+		return x.Bitrate
+	}
+	return 0
+}
+
+func (x *Rendition) GetPlaylistUrl() string {
+	if x != nil {
+		return x.PlaylistUrl
+	}
+	return ""
+}
+
+type Comment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Author         *Author                `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Content        string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Mentions       []string               `protobuf:"bytes,5,rep,name=mentions,proto3" json:"mentions,omitempty"` // User IDs
+	LikeCount      int64                  `protobuf:"varint,6,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	ReplyCount     int64                  `protobuf:"varint,7,opt,name=reply_count,json=replyCount,proto3" json:"reply_count,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Replies        []*Reply               `protobuf:"bytes,10,rep,name=replies,proto3" json:"replies,omitempty"`
+	ReactionCounts map[string]int64       `protobuf:"bytes,11,rep,name=reaction_counts,json=reactionCounts,proto3" json:"reaction_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{50}
+}
+
 func (x *Comment) GetId() string {
 	if x != nil {
 		return x.Id
@@ -1438,24 +2998,33 @@ func (x *Comment) GetReplies() []*Reply {
 	return nil
 }
 
+func (x *Comment) GetReactionCounts() map[string]int64 {
+	if x != nil {
+		return x.ReactionCounts
+	}
+	return nil
+}
+
 type Reply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CommentId     string                 `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Author        *Author                `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
-	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
-	Mentions      []string               `protobuf:"bytes,6,rep,name=mentions,proto3" json:"mentions,omitempty"`
-	LikeCount     int64                  `protobuf:"varint,7,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CommentId      string                 `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Author         *Author                `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	Content        string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Mentions       []string               `protobuf:"bytes,6,rep,name=mentions,proto3" json:"mentions,omitempty"`
+	LikeCount      int64                  `protobuf:"varint,7,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ParentReplyId  string                 `protobuf:"bytes,10,opt,name=parent_reply_id,json=parentReplyId,proto3" json:"parent_reply_id,omitempty"`
+	ReactionCounts map[string]int64       `protobuf:"bytes,11,rep,name=reaction_counts,json=reactionCounts,proto3" json:"reaction_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Reply) Reset() {
 	*x = Reply{}
-	mi := &file_proto_reel_v1_reel_proto_msgTypes[24]
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1467,7 +3036,7 @@ func (x *Reply) String() string {
 func (*Reply) ProtoMessage() {}
 
 func (x *Reply) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_reel_v1_reel_proto_msgTypes[24]
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1480,7 +3049,7 @@ func (x *Reply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Reply.ProtoReflect.Descriptor instead.
 func (*Reply) Descriptor() ([]byte, []int) {
-	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{24}
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *Reply) GetId() string {
@@ -1546,6 +3115,20 @@ func (x *Reply) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Reply) GetParentReplyId() string {
+	if x != nil {
+		return x.ParentReplyId
+	}
+	return ""
+}
+
+func (x *Reply) GetReactionCounts() map[string]int64 {
+	if x != nil {
+		return x.ReactionCounts
+	}
+	return nil
+}
+
 type Author struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1558,7 +3141,7 @@ type Author struct {
 
 func (x *Author) Reset() {
 	*x = Author{}
-	mi := &file_proto_reel_v1_reel_proto_msgTypes[25]
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1570,7 +3153,7 @@ func (x *Author) String() string {
 func (*Author) ProtoMessage() {}
 
 func (x *Author) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_reel_v1_reel_proto_msgTypes[25]
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1583,7 +3166,7 @@ func (x *Author) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Author.ProtoReflect.Descriptor instead.
 func (*Author) Descriptor() ([]byte, []int) {
-	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{25}
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *Author) GetId() string {
@@ -1614,6 +3197,246 @@ func (x *Author) GetFullName() string {
 	return ""
 }
 
+type LiveSession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Author        *Author                `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Privacy       string                 `protobuf:"bytes,5,opt,name=privacy,proto3" json:"privacy,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                        // idle, live or ended
+	StreamKey     string                 `protobuf:"bytes,7,opt,name=stream_key,json=streamKey,proto3" json:"stream_key,omitempty"` // Broadcaster only
+	IngestUrl     string                 `protobuf:"bytes,8,opt,name=ingest_url,json=ingestUrl,proto3" json:"ingest_url,omitempty"` // Broadcaster only
+	PlaybackUrl   string                 `protobuf:"bytes,9,opt,name=playback_url,json=playbackUrl,proto3" json:"playback_url,omitempty"`
+	ViewerCount   int64                  `protobuf:"varint,10,opt,name=viewer_count,json=viewerCount,proto3" json:"viewer_count,omitempty"`
+	PeakViewers   int64                  `protobuf:"varint,11,opt,name=peak_viewers,json=peakViewers,proto3" json:"peak_viewers,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	ReelId        string                 `protobuf:"bytes,14,opt,name=reel_id,json=reelId,proto3" json:"reel_id,omitempty"` // The archived broadcast
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LiveSession) Reset() {
+	*x = LiveSession{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiveSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiveSession) ProtoMessage() {}
+
+func (x *LiveSession) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiveSession.ProtoReflect.Descriptor instead.
+func (*LiveSession) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *LiveSession) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LiveSession) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LiveSession) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *LiveSession) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LiveSession) GetPrivacy() string {
+	if x != nil {
+		return x.Privacy
+	}
+	return ""
+}
+
+func (x *LiveSession) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *LiveSession) GetStreamKey() string {
+	if x != nil {
+		return x.StreamKey
+	}
+	return ""
+}
+
+func (x *LiveSession) GetIngestUrl() string {
+	if x != nil {
+		return x.IngestUrl
+	}
+	return ""
+}
+
+func (x *LiveSession) GetPlaybackUrl() string {
+	if x != nil {
+		return x.PlaybackUrl
+	}
+	return ""
+}
+
+func (x *LiveSession) GetViewerCount() int64 {
+	if x != nil {
+		return x.ViewerCount
+	}
+	return 0
+}
+
+func (x *LiveSession) GetPeakViewers() int64 {
+	if x != nil {
+		return x.PeakViewers
+	}
+	return 0
+}
+
+func (x *LiveSession) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *LiveSession) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *LiveSession) GetReelId() string {
+	if x != nil {
+		return x.ReelId
+	}
+	return ""
+}
+
+func (x *LiveSession) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type LiveChatMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Author        *Author                `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LiveChatMessage) Reset() {
+	*x = LiveChatMessage{}
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiveChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiveChatMessage) ProtoMessage() {}
+
+func (x *LiveChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reel_v1_reel_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiveChatMessage.ProtoReflect.Descriptor instead.
+func (*LiveChatMessage) Descriptor() ([]byte, []int) {
+	return file_proto_reel_v1_reel_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *LiveChatMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LiveChatMessage) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *LiveChatMessage) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LiveChatMessage) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *LiveChatMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *LiveChatMessage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 var File_proto_reel_v1_reel_proto protoreflect.FileDescriptor
 
 const file_proto_reel_v1_reel_proto_rawDesc = "" +
@@ -1632,7 +3455,7 @@ const file_proto_reel_v1_reel_proto_rawDesc = "" +
 	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x03 \x01(\x03R\x06offset\";\n" +
 	"\x14GetReelsFeedResponse\x12#\n" +
-	"\x05reels\x18\x01 \x03(\v2\r.reel.v1.ReelR\x05reels\"\x90\x02\n" +
+	"\x05reels\x18\x01 \x03(\v2\r.reel.v1.ReelR\x05reels\"\xac\x02\n" +
 	"\x11CreateReelRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
 	"\tvideo_url\x18\x02 \x01(\tR\bvideoUrl\x12#\n" +
@@ -1641,7 +3464,8 @@ const file_proto_reel_v1_reel_proto_rawDesc = "" +
 	"\bduration\x18\x05 \x01(\x01R\bduration\x12\x18\n" +
 	"\aprivacy\x18\x06 \x01(\tR\aprivacy\x12'\n" +
 	"\x0fallowed_viewers\x18\a \x03(\tR\x0eallowedViewers\x12'\n" +
-	"\x0fblocked_viewers\x18\b \x03(\tR\x0eblockedViewers\"7\n" +
+	"\x0fblocked_viewers\x18\b \x03(\tR\x0eblockedViewers\x12\x1a\n" +
+	"\bcategory\x18\t \x01(\tR\bcategory\"7\n" +
 	"\x12CreateReelResponse\x12!\n" +
 	"\x04reel\x18\x01 \x01(\v2\r.reel.v1.ReelR\x04reel\"E\n" +
 	"\x11DeleteReelRequest\x12\x17\n" +
@@ -1655,13 +3479,15 @@ const file_proto_reel_v1_reel_proto_rawDesc = "" +
 	"\acontent\x18\x03 \x01(\tR\acontent\x12+\n" +
 	"\x11explicit_mentions\x18\x04 \x03(\tR\x10explicitMentions\"@\n" +
 	"\x12AddCommentResponse\x12*\n" +
-	"\acomment\x18\x01 \x01(\v2\x10.reel.v1.CommentR\acomment\"|\n" +
+	"\acomment\x18\x01 \x01(\v2\x10.reel.v1.CommentR\acomment\"\xd1\x01\n" +
 	"\x0fAddReplyRequest\x12\x17\n" +
 	"\areel_id\x18\x01 \x01(\tR\x06reelId\x12\x1d\n" +
 	"\n" +
 	"comment_id\x18\x02 \x01(\tR\tcommentId\x12\x17\n" +
 	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x18\n" +
-	"\acontent\x18\x04 \x01(\tR\acontent\"8\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x12&\n" +
+	"\x0fparent_reply_id\x18\x05 \x01(\tR\rparentReplyId\x12+\n" +
+	"\x11explicit_mentions\x18\x06 \x03(\tR\x10explicitMentions\"8\n" +
 	"\x10AddReplyResponse\x12$\n" +
 	"\x05reply\x18\x01 \x01(\v2\x0e.reel.v1.ReplyR\x05reply\"\x8d\x01\n" +
 	"\x15ReactToCommentRequest\x12\x17\n" +
@@ -1671,6 +3497,38 @@ const file_proto_reel_v1_reel_proto_rawDesc = "" +
 	"\auser_id\x18\x03 \x01(\tR\x06userId\x12#\n" +
 	"\rreaction_type\x18\x04 \x01(\tR\freactionType\"2\n" +
 	"\x16ReactToCommentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"y\n" +
+	"\x11GetRepliesRequest\x12\x17\n" +
+	"\areel_id\x18\x01 \x01(\tR\x06reelId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x02 \x01(\tR\tcommentId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x03R\x06offset\">\n" +
+	"\x12GetRepliesResponse\x12(\n" +
+	"\areplies\x18\x01 \x03(\v2\x0e.reel.v1.ReplyR\areplies\"\xa6\x01\n" +
+	"\x13ReactToReplyRequest\x12\x17\n" +
+	"\areel_id\x18\x01 \x01(\tR\x06reelId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x02 \x01(\tR\tcommentId\x12\x19\n" +
+	"\breply_id\x18\x03 \x01(\tR\areplyId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12#\n" +
+	"\rreaction_type\x18\x05 \x01(\tR\freactionType\"0\n" +
+	"\x14ReactToReplyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"g\n" +
+	"\x14DeleteCommentRequest\x12\x17\n" +
+	"\areel_id\x18\x01 \x01(\tR\x06reelId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x02 \x01(\tR\tcommentId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"1\n" +
+	"\x15DeleteCommentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x80\x01\n" +
+	"\x12DeleteReplyRequest\x12\x17\n" +
+	"\areel_id\x18\x01 \x01(\tR\x06reelId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x02 \x01(\tR\tcommentId\x12\x19\n" +
+	"\breply_id\x18\x03 \x01(\tR\areplyId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\"/\n" +
+	"\x13DeleteReplyResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"k\n" +
 	"\x12ReactToReelRequest\x12\x17\n" +
 	"\areel_id\x18\x01 \x01(\tR\x06reelId\x12\x17\n" +
@@ -1683,12 +3541,74 @@ const file_proto_reel_v1_reel_proto_rawDesc = "" +
 	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x03 \x01(\x03R\x06offset\"C\n" +
 	"\x13GetCommentsResponse\x12,\n" +
-	"\bcomments\x18\x01 \x03(\v2\x10.reel.v1.CommentR\bcomments\"L\n" +
+	"\bcomments\x18\x01 \x03(\v2\x10.reel.v1.CommentR\bcomments\"9\n" +
+	"\x1eGetReelProcessingStatusRequest\x12\x17\n" +
+	"\areel_id\x18\x01 \x01(\tR\x06reelId\"\xd4\x01\n" +
+	"\x1fGetReelProcessingStatusResponse\x12\x17\n" +
+	"\areel_id\x18\x01 \x01(\tR\x06reelId\x12+\n" +
+	"\x11processing_status\x18\x02 \x01(\tR\x10processingStatus\x12!\n" +
+	"\fplayback_url\x18\x03 \x01(\tR\vplaybackUrl\x122\n" +
+	"\n" +
+	"renditions\x18\x04 \x03(\v2\x12.reel.v1.RenditionR\n" +
+	"renditions\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"I\n" +
+	"\x14GetForYouFeedRequest\x12\x1b\n" +
+	"\tviewer_id\x18\x01 \x01(\tR\bviewerId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"<\n" +
+	"\x15GetForYouFeedResponse\x12#\n" +
+	"\x05reels\x18\x01 \x03(\v2\r.reel.v1.ReelR\x05reels\"\x87\x01\n" +
+	"\x17RecordEngagementRequest\x12\x17\n" +
+	"\areel_id\x18\x01 \x01(\tR\x06reelId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06signal\x18\x03 \x01(\tR\x06signal\x12\"\n" +
+	"\rwatch_time_ms\x18\x04 \x01(\x03R\vwatchTimeMs\"4\n" +
+	"\x18RecordEngagementResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"c\n" +
+	"\x18CreateLiveSessionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\aprivacy\x18\x03 \x01(\tR\aprivacy\"S\n" +
+	"\x15GetLiveSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\tR\bviewerId\"E\n" +
+	"\x13LiveSessionResponse\x12.\n" +
+	"\asession\x18\x01 \x01(\v2\x14.reel.v1.LiveSessionR\asession\"L\n" +
+	"\x17ListLiveSessionsRequest\x12\x1b\n" +
+	"\tviewer_id\x18\x01 \x01(\tR\bviewerId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"L\n" +
+	"\x18ListLiveSessionsResponse\x120\n" +
+	"\bsessions\x18\x01 \x03(\v2\x14.reel.v1.LiveSessionR\bsessions\"O\n" +
+	"\x15EndLiveSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"O\n" +
+	"\x11LiveViewerRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\tR\bviewerId\"7\n" +
+	"\x12LiveViewerResponse\x12!\n" +
+	"\fviewer_count\x18\x01 \x01(\x03R\vviewerCount\"n\n" +
+	"\x1aSendLiveChatMessageRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\"Q\n" +
+	"\x1bSendLiveChatMessageResponse\x122\n" +
+	"\amessage\x18\x01 \x01(\v2\x18.reel.v1.LiveChatMessageR\amessage\"\xa2\x01\n" +
+	"\x1aGetLiveChatMessagesRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\tR\bviewerId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x122\n" +
+	"\x06before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06before\"S\n" +
+	"\x1bGetLiveChatMessagesResponse\x124\n" +
+	"\bmessages\x18\x01 \x03(\v2\x18.reel.v1.LiveChatMessageR\bmessages\"L\n" +
 	"\x14IncrementViewRequest\x12\x17\n" +
 	"\areel_id\x18\x01 \x01(\tR\x06reelId\x12\x1b\n" +
 	"\tviewer_id\x18\x02 \x01(\tR\bviewerId\"1\n" +
 	"\x15IncrementViewResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xa8\x03\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xb9\x06\n" +
 	"\x04Reel\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
@@ -1705,7 +3625,26 @@ const file_proto_reel_v1_reel_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf1\x02\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12+\n" +
+	"\x11processing_status\x18\x0e \x01(\tR\x10processingStatus\x12!\n" +
+	"\fplayback_url\x18\x0f \x01(\tR\vplaybackUrl\x122\n" +
+	"\n" +
+	"renditions\x18\x10 \x03(\v2\x12.reel.v1.RenditionR\n" +
+	"renditions\x12J\n" +
+	"\x0freaction_counts\x18\x11 \x03(\v2!.reel.v1.Reel.ReactionCountsEntryR\x0ereactionCounts\x12\x1a\n" +
+	"\bcategory\x18\x12 \x01(\tR\bcategory\x12\x1a\n" +
+	"\bhashtags\x18\x13 \x03(\tR\bhashtags\x12\x16\n" +
+	"\x06shares\x18\x14 \x01(\x03R\x06shares\x12,\n" +
+	"\x12resume_position_ms\x18\x15 \x01(\x03R\x10resumePositionMs\x1aA\n" +
+	"\x13ReactionCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x8a\x01\n" +
+	"\tRendition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12\x18\n" +
+	"\abitrate\x18\x04 \x01(\x05R\abitrate\x12!\n" +
+	"\fplaylist_url\x18\x05 \x01(\tR\vplaylistUrl\"\x83\x04\n" +
 	"\aComment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12'\n" +
@@ -1721,7 +3660,11 @@ const file_proto_reel_v1_reel_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12(\n" +
 	"\areplies\x18\n" +
-	" \x03(\v2\x0e.reel.v1.ReplyR\areplies\"\xc3\x02\n" +
+	" \x03(\v2\x0e.reel.v1.ReplyR\areplies\x12M\n" +
+	"\x0freaction_counts\x18\v \x03(\v2$.reel.v1.Comment.ReactionCountsEntryR\x0ereactionCounts\x1aA\n" +
+	"\x13ReactionCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xfb\x03\n" +
 	"\x05Reply\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1735,12 +3678,48 @@ const file_proto_reel_v1_reel_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"i\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12&\n" +
+	"\x0fparent_reply_id\x18\n" +
+	" \x01(\tR\rparentReplyId\x12K\n" +
+	"\x0freaction_counts\x18\v \x03(\v2\".reel.v1.Reply.ReactionCountsEntryR\x0ereactionCounts\x1aA\n" +
+	"\x13ReactionCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"i\n" +
 	"\x06Author\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x16\n" +
 	"\x06avatar\x18\x03 \x01(\tR\x06avatar\x12\x1b\n" +
-	"\tfull_name\x18\x04 \x01(\tR\bfullName2\xb2\x06\n" +
+	"\tfull_name\x18\x04 \x01(\tR\bfullName\"\x94\x04\n" +
+	"\vLiveSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12'\n" +
+	"\x06author\x18\x03 \x01(\v2\x0f.reel.v1.AuthorR\x06author\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x18\n" +
+	"\aprivacy\x18\x05 \x01(\tR\aprivacy\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"stream_key\x18\a \x01(\tR\tstreamKey\x12\x1d\n" +
+	"\n" +
+	"ingest_url\x18\b \x01(\tR\tingestUrl\x12!\n" +
+	"\fplayback_url\x18\t \x01(\tR\vplaybackUrl\x12!\n" +
+	"\fviewer_count\x18\n" +
+	" \x01(\x03R\vviewerCount\x12!\n" +
+	"\fpeak_viewers\x18\v \x01(\x03R\vpeakViewers\x129\n" +
+	"\n" +
+	"started_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
+	"\bended_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\x12\x17\n" +
+	"\areel_id\x18\x0e \x01(\tR\x06reelId\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd7\x01\n" +
+	"\x0fLiveChatMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12'\n" +
+	"\x06author\x18\x04 \x01(\v2\x0f.reel.v1.AuthorR\x06author\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt2\xa3\x10\n" +
 	"\vReelService\x12<\n" +
 	"\aGetReel\x12\x17.reel.v1.GetReelRequest\x1a\x18.reel.v1.GetReelResponse\x12K\n" +
 	"\fGetUserReels\x12\x1c.reel.v1.GetUserReelsRequest\x1a\x1d.reel.v1.GetUserReelsResponse\x12K\n" +
@@ -1752,10 +3731,26 @@ const file_proto_reel_v1_reel_proto_rawDesc = "" +
 	"\n" +
 	"AddComment\x12\x1a.reel.v1.AddCommentRequest\x1a\x1b.reel.v1.AddCommentResponse\x12?\n" +
 	"\bAddReply\x12\x18.reel.v1.AddReplyRequest\x1a\x19.reel.v1.AddReplyResponse\x12Q\n" +
-	"\x0eReactToComment\x12\x1e.reel.v1.ReactToCommentRequest\x1a\x1f.reel.v1.ReactToCommentResponse\x12N\n" +
+	"\x0eReactToComment\x12\x1e.reel.v1.ReactToCommentRequest\x1a\x1f.reel.v1.ReactToCommentResponse\x12E\n" +
+	"\n" +
+	"GetReplies\x12\x1a.reel.v1.GetRepliesRequest\x1a\x1b.reel.v1.GetRepliesResponse\x12K\n" +
+	"\fReactToReply\x12\x1c.reel.v1.ReactToReplyRequest\x1a\x1d.reel.v1.ReactToReplyResponse\x12N\n" +
+	"\rDeleteComment\x12\x1d.reel.v1.DeleteCommentRequest\x1a\x1e.reel.v1.DeleteCommentResponse\x12H\n" +
+	"\vDeleteReply\x12\x1b.reel.v1.DeleteReplyRequest\x1a\x1c.reel.v1.DeleteReplyResponse\x12N\n" +
 	"\rIncrementView\x12\x1d.reel.v1.IncrementViewRequest\x1a\x1e.reel.v1.IncrementViewResponse\x12H\n" +
 	"\vReactToReel\x12\x1b.reel.v1.ReactToReelRequest\x1a\x1c.reel.v1.ReactToReelResponse\x12H\n" +
-	"\vGetComments\x12\x1b.reel.v1.GetCommentsRequest\x1a\x1c.reel.v1.GetCommentsResponseBHZFgithub.com/MuhibNayem/connectify-v2/shared-entity/proto/reel/v1;reelpbb\x06proto3"
+	"\vGetComments\x12\x1b.reel.v1.GetCommentsRequest\x1a\x1c.reel.v1.GetCommentsResponse\x12l\n" +
+	"\x17GetReelProcessingStatus\x12'.reel.v1.GetReelProcessingStatusRequest\x1a(.reel.v1.GetReelProcessingStatusResponse\x12N\n" +
+	"\rGetForYouFeed\x12\x1d.reel.v1.GetForYouFeedRequest\x1a\x1e.reel.v1.GetForYouFeedResponse\x12W\n" +
+	"\x10RecordEngagement\x12 .reel.v1.RecordEngagementRequest\x1a!.reel.v1.RecordEngagementResponse\x12T\n" +
+	"\x11CreateLiveSession\x12!.reel.v1.CreateLiveSessionRequest\x1a\x1c.reel.v1.LiveSessionResponse\x12N\n" +
+	"\x0eGetLiveSession\x12\x1e.reel.v1.GetLiveSessionRequest\x1a\x1c.reel.v1.LiveSessionResponse\x12W\n" +
+	"\x10ListLiveSessions\x12 .reel.v1.ListLiveSessionsRequest\x1a!.reel.v1.ListLiveSessionsResponse\x12N\n" +
+	"\x0eEndLiveSession\x12\x1e.reel.v1.EndLiveSessionRequest\x1a\x1c.reel.v1.LiveSessionResponse\x12J\n" +
+	"\x0fJoinLiveSession\x12\x1a.reel.v1.LiveViewerRequest\x1a\x1b.reel.v1.LiveViewerResponse\x12K\n" +
+	"\x10LeaveLiveSession\x12\x1a.reel.v1.LiveViewerRequest\x1a\x1b.reel.v1.LiveViewerResponse\x12`\n" +
+	"\x13SendLiveChatMessage\x12#.reel.v1.SendLiveChatMessageRequest\x1a$.reel.v1.SendLiveChatMessageResponse\x12`\n" +
+	"\x13GetLiveChatMessages\x12#.reel.v1.GetLiveChatMessagesRequest\x1a$.reel.v1.GetLiveChatMessagesResponseBHZFgithub.com/MuhibNayem/connectify-v2/shared-entity/proto/reel/v1;reelpbb\x06proto3"
 
 var (
 	file_proto_reel_v1_reel_proto_rawDescOnce sync.Once
@@ -1769,81 +3764,161 @@ func file_proto_reel_v1_reel_proto_rawDescGZIP() []byte {
 	return file_proto_reel_v1_reel_proto_rawDescData
 }
 
-var file_proto_reel_v1_reel_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_proto_reel_v1_reel_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
 var file_proto_reel_v1_reel_proto_goTypes = []any{
-	(*GetReelRequest)(nil),         // 0: reel.v1.GetReelRequest
-	(*GetReelResponse)(nil),        // 1: reel.v1.GetReelResponse
-	(*GetUserReelsRequest)(nil),    // 2: reel.v1.GetUserReelsRequest
-	(*GetUserReelsResponse)(nil),   // 3: reel.v1.GetUserReelsResponse
-	(*GetReelsFeedRequest)(nil),    // 4: reel.v1.GetReelsFeedRequest
-	(*GetReelsFeedResponse)(nil),   // 5: reel.v1.GetReelsFeedResponse
-	(*CreateReelRequest)(nil),      // 6: reel.v1.CreateReelRequest
-	(*CreateReelResponse)(nil),     // 7: reel.v1.CreateReelResponse
-	(*DeleteReelRequest)(nil),      // 8: reel.v1.DeleteReelRequest
-	(*DeleteReelResponse)(nil),     // 9: reel.v1.DeleteReelResponse
-	(*AddCommentRequest)(nil),      // 10: reel.v1.AddCommentRequest
-	(*AddCommentResponse)(nil),     // 11: reel.v1.AddCommentResponse
-	(*AddReplyRequest)(nil),        // 12: reel.v1.AddReplyRequest
-	(*AddReplyResponse)(nil),       // 13: reel.v1.AddReplyResponse
-	(*ReactToCommentRequest)(nil),  // 14: reel.v1.ReactToCommentRequest
-	(*ReactToCommentResponse)(nil), // 15: reel.v1.ReactToCommentResponse
-	(*ReactToReelRequest)(nil),     // 16: reel.v1.ReactToReelRequest
-	(*ReactToReelResponse)(nil),    // 17: reel.v1.ReactToReelResponse
-	(*GetCommentsRequest)(nil),     // 18: reel.v1.GetCommentsRequest
-	(*GetCommentsResponse)(nil),    // 19: reel.v1.GetCommentsResponse
-	(*IncrementViewRequest)(nil),   // 20: reel.v1.IncrementViewRequest
-	(*IncrementViewResponse)(nil),  // 21: reel.v1.IncrementViewResponse
-	(*Reel)(nil),                   // 22: reel.v1.Reel
-	(*Comment)(nil),                // 23: reel.v1.Comment
-	(*Reply)(nil),                  // 24: reel.v1.Reply
-	(*Author)(nil),                 // 25: reel.v1.Author
-	(*timestamppb.Timestamp)(nil),  // 26: google.protobuf.Timestamp
+	(*GetReelRequest)(nil),                  // 0: reel.v1.GetReelRequest
+	(*GetReelResponse)(nil),                 // 1: reel.v1.GetReelResponse
+	(*GetUserReelsRequest)(nil),             // 2: reel.v1.GetUserReelsRequest
+	(*GetUserReelsResponse)(nil),            // 3: reel.v1.GetUserReelsResponse
+	(*GetReelsFeedRequest)(nil),             // 4: reel.v1.GetReelsFeedRequest
+	(*GetReelsFeedResponse)(nil),            // 5: reel.v1.GetReelsFeedResponse
+	(*CreateReelRequest)(nil),               // 6: reel.v1.CreateReelRequest
+	(*CreateReelResponse)(nil),              // 7: reel.v1.CreateReelResponse
+	(*DeleteReelRequest)(nil),               // 8: reel.v1.DeleteReelRequest
+	(*DeleteReelResponse)(nil),              // 9: reel.v1.DeleteReelResponse
+	(*AddCommentRequest)(nil),               // 10: reel.v1.AddCommentRequest
+	(*AddCommentResponse)(nil),              // 11: reel.v1.AddCommentResponse
+	(*AddReplyRequest)(nil),                 // 12: reel.v1.AddReplyRequest
+	(*AddReplyResponse)(nil),                // 13: reel.v1.AddReplyResponse
+	(*ReactToCommentRequest)(nil),           // 14: reel.v1.ReactToCommentRequest
+	(*ReactToCommentResponse)(nil),          // 15: reel.v1.ReactToCommentResponse
+	(*GetRepliesRequest)(nil),               // 16: reel.v1.GetRepliesRequest
+	(*GetRepliesResponse)(nil),              // 17: reel.v1.GetRepliesResponse
+	(*ReactToReplyRequest)(nil),             // 18: reel.v1.ReactToReplyRequest
+	(*ReactToReplyResponse)(nil),            // 19: reel.v1.ReactToReplyResponse
+	(*DeleteCommentRequest)(nil),            // 20: reel.v1.DeleteCommentRequest
+	(*DeleteCommentResponse)(nil),           // 21: reel.v1.DeleteCommentResponse
+	(*DeleteReplyRequest)(nil),              // 22: reel.v1.DeleteReplyRequest
+	(*DeleteReplyResponse)(nil),             // 23: reel.v1.DeleteReplyResponse
+	(*ReactToReelRequest)(nil),              // 24: reel.v1.ReactToReelRequest
+	(*ReactToReelResponse)(nil),             // 25: reel.v1.ReactToReelResponse
+	(*GetCommentsRequest)(nil),              // 26: reel.v1.GetCommentsRequest
+	(*GetCommentsResponse)(nil),             // 27: reel.v1.GetCommentsResponse
+	(*GetReelProcessingStatusRequest)(nil),  // 28: reel.v1.GetReelProcessingStatusRequest
+	(*GetReelProcessingStatusResponse)(nil), // 29: reel.v1.GetReelProcessingStatusResponse
+	(*GetForYouFeedRequest)(nil),            // 30: reel.v1.GetForYouFeedRequest
+	(*GetForYouFeedResponse)(nil),           // 31: reel.v1.GetForYouFeedResponse
+	(*RecordEngagementRequest)(nil),         // 32: reel.v1.RecordEngagementRequest
+	(*RecordEngagementResponse)(nil),        // 33: reel.v1.RecordEngagementResponse
+	(*CreateLiveSessionRequest)(nil),        // 34: reel.v1.CreateLiveSessionRequest
+	(*GetLiveSessionRequest)(nil),           // 35: reel.v1.GetLiveSessionRequest
+	(*LiveSessionResponse)(nil),             // 36: reel.v1.LiveSessionResponse
+	(*ListLiveSessionsRequest)(nil),         // 37: reel.v1.ListLiveSessionsRequest
+	(*ListLiveSessionsResponse)(nil),        // 38: reel.v1.ListLiveSessionsResponse
+	(*EndLiveSessionRequest)(nil),           // 39: reel.v1.EndLiveSessionRequest
+	(*LiveViewerRequest)(nil),               // 40: reel.v1.LiveViewerRequest
+	(*LiveViewerResponse)(nil),              // 41: reel.v1.LiveViewerResponse
+	(*SendLiveChatMessageRequest)(nil),      // 42: reel.v1.SendLiveChatMessageRequest
+	(*SendLiveChatMessageResponse)(nil),     // 43: reel.v1.SendLiveChatMessageResponse
+	(*GetLiveChatMessagesRequest)(nil),      // 44: reel.v1.GetLiveChatMessagesRequest
+	(*GetLiveChatMessagesResponse)(nil),     // 45: reel.v1.GetLiveChatMessagesResponse
+	(*IncrementViewRequest)(nil),            // 46: reel.v1.IncrementViewRequest
+	(*IncrementViewResponse)(nil),           // 47: reel.v1.IncrementViewResponse
+	(*Reel)(nil),                            // 48: reel.v1.Reel
+	(*Rendition)(nil),                       // 49: reel.v1.Rendition
+	(*Comment)(nil),                         // 50: reel.v1.Comment
+	(*Reply)(nil),                           // 51: reel.v1.Reply
+	(*Author)(nil),                          // 52: reel.v1.Author
+	(*LiveSession)(nil),                     // 53: reel.v1.LiveSession
+	(*LiveChatMessage)(nil),                 // 54: reel.v1.LiveChatMessage
+	nil,                                     // 55: reel.v1.Reel.ReactionCountsEntry
+	nil,                                     // 56: reel.v1.Comment.ReactionCountsEntry
+	nil,                                     // 57: reel.v1.Reply.ReactionCountsEntry
+	(*timestamppb.Timestamp)(nil),           // 58: google.protobuf.Timestamp
 }
 var file_proto_reel_v1_reel_proto_depIdxs = []int32{
-	22, // 0: reel.v1.GetReelResponse.reel:type_name -> reel.v1.Reel
-	22, // 1: reel.v1.GetUserReelsResponse.reels:type_name -> reel.v1.Reel
-	22, // 2: reel.v1.GetReelsFeedResponse.reels:type_name -> reel.v1.Reel
-	22, // 3: reel.v1.CreateReelResponse.reel:type_name -> reel.v1.Reel
-	23, // 4: reel.v1.AddCommentResponse.comment:type_name -> reel.v1.Comment
-	24, // 5: reel.v1.AddReplyResponse.reply:type_name -> reel.v1.Reply
-	23, // 6: reel.v1.GetCommentsResponse.comments:type_name -> reel.v1.Comment
-	25, // 7: reel.v1.Reel.author:type_name -> reel.v1.Author
-	26, // 8: reel.v1.Reel.created_at:type_name -> google.protobuf.Timestamp
-	26, // 9: reel.v1.Reel.updated_at:type_name -> google.protobuf.Timestamp
-	25, // 10: reel.v1.Comment.author:type_name -> reel.v1.Author
-	26, // 11: reel.v1.Comment.created_at:type_name -> google.protobuf.Timestamp
-	26, // 12: reel.v1.Comment.updated_at:type_name -> google.protobuf.Timestamp
-	24, // 13: reel.v1.Comment.replies:type_name -> reel.v1.Reply
-	25, // 14: reel.v1.Reply.author:type_name -> reel.v1.Author
-	26, // 15: reel.v1.Reply.created_at:type_name -> google.protobuf.Timestamp
-	26, // 16: reel.v1.Reply.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 17: reel.v1.ReelService.GetReel:input_type -> reel.v1.GetReelRequest
-	2,  // 18: reel.v1.ReelService.GetUserReels:input_type -> reel.v1.GetUserReelsRequest
-	4,  // 19: reel.v1.ReelService.GetReelsFeed:input_type -> reel.v1.GetReelsFeedRequest
-	6,  // 20: reel.v1.ReelService.CreateReel:input_type -> reel.v1.CreateReelRequest
-	8,  // 21: reel.v1.ReelService.DeleteReel:input_type -> reel.v1.DeleteReelRequest
-	10, // 22: reel.v1.ReelService.AddComment:input_type -> reel.v1.AddCommentRequest
-	12, // 23: reel.v1.ReelService.AddReply:input_type -> reel.v1.AddReplyRequest
-	14, // 24: reel.v1.ReelService.ReactToComment:input_type -> reel.v1.ReactToCommentRequest
-	20, // 25: reel.v1.ReelService.IncrementView:input_type -> reel.v1.IncrementViewRequest
-	16, // 26: reel.v1.ReelService.ReactToReel:input_type -> reel.v1.ReactToReelRequest
-	18, // 27: reel.v1.ReelService.GetComments:input_type -> reel.v1.GetCommentsRequest
-	1,  // 28: reel.v1.ReelService.GetReel:output_type -> reel.v1.GetReelResponse
-	3,  // 29: reel.v1.ReelService.GetUserReels:output_type -> reel.v1.GetUserReelsResponse
-	5,  // 30: reel.v1.ReelService.GetReelsFeed:output_type -> reel.v1.GetReelsFeedResponse
-	7,  // 31: reel.v1.ReelService.CreateReel:output_type -> reel.v1.CreateReelResponse
-	9,  // 32: reel.v1.ReelService.DeleteReel:output_type -> reel.v1.DeleteReelResponse
-	11, // 33: reel.v1.ReelService.AddComment:output_type -> reel.v1.AddCommentResponse
-	13, // 34: reel.v1.ReelService.AddReply:output_type -> reel.v1.AddReplyResponse
-	15, // 35: reel.v1.ReelService.ReactToComment:output_type -> reel.v1.ReactToCommentResponse
-	21, // 36: reel.v1.ReelService.IncrementView:output_type -> reel.v1.IncrementViewResponse
-	17, // 37: reel.v1.ReelService.ReactToReel:output_type -> reel.v1.ReactToReelResponse
-	19, // 38: reel.v1.ReelService.GetComments:output_type -> reel.v1.GetCommentsResponse
-	28, // [28:39] is the sub-list for method output_type
-	17, // [17:28] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	48, // 0: reel.v1.GetReelResponse.reel:type_name -> reel.v1.Reel
+	48, // 1: reel.v1.GetUserReelsResponse.reels:type_name -> reel.v1.Reel
+	48, // 2: reel.v1.GetReelsFeedResponse.reels:type_name -> reel.v1.Reel
+	48, // 3: reel.v1.CreateReelResponse.reel:type_name -> reel.v1.Reel
+	50, // 4: reel.v1.AddCommentResponse.comment:type_name -> reel.v1.Comment
+	51, // 5: reel.v1.AddReplyResponse.reply:type_name -> reel.v1.Reply
+	51, // 6: reel.v1.GetRepliesResponse.replies:type_name -> reel.v1.Reply
+	50, // 7: reel.v1.GetCommentsResponse.comments:type_name -> reel.v1.Comment
+	49, // 8: reel.v1.GetReelProcessingStatusResponse.renditions:type_name -> reel.v1.Rendition
+	48, // 9: reel.v1.GetForYouFeedResponse.reels:type_name -> reel.v1.Reel
+	53, // 10: reel.v1.LiveSessionResponse.session:type_name -> reel.v1.LiveSession
+	53, // 11: reel.v1.ListLiveSessionsResponse.sessions:type_name -> reel.v1.LiveSession
+	54, // 12: reel.v1.SendLiveChatMessageResponse.message:type_name -> reel.v1.LiveChatMessage
+	58, // 13: reel.v1.GetLiveChatMessagesRequest.before:type_name -> google.protobuf.Timestamp
+	54, // 14: reel.v1.GetLiveChatMessagesResponse.messages:type_name -> reel.v1.LiveChatMessage
+	52, // 15: reel.v1.Reel.author:type_name -> reel.v1.Author
+	58, // 16: reel.v1.Reel.created_at:type_name -> google.protobuf.Timestamp
+	58, // 17: reel.v1.Reel.updated_at:type_name -> google.protobuf.Timestamp
+	49, // 18: reel.v1.Reel.renditions:type_name -> reel.v1.Rendition
+	55, // 19: reel.v1.Reel.reaction_counts:type_name -> reel.v1.Reel.ReactionCountsEntry
+	52, // 20: reel.v1.Comment.author:type_name -> reel.v1.Author
+	58, // 21: reel.v1.Comment.created_at:type_name -> google.protobuf.Timestamp
+	58, // 22: reel.v1.Comment.updated_at:type_name -> google.protobuf.Timestamp
+	51, // 23: reel.v1.Comment.replies:type_name -> reel.v1.Reply
+	56, // 24: reel.v1.Comment.reaction_counts:type_name -> reel.v1.Comment.ReactionCountsEntry
+	52, // 25: reel.v1.Reply.author:type_name -> reel.v1.Author
+	58, // 26: reel.v1.Reply.created_at:type_name -> google.protobuf.Timestamp
+	58, // 27: reel.v1.Reply.updated_at:type_name -> google.protobuf.Timestamp
+	57, // 28: reel.v1.Reply.reaction_counts:type_name -> reel.v1.Reply.ReactionCountsEntry
+	52, // 29: reel.v1.LiveSession.author:type_name -> reel.v1.Author
+	58, // 30: reel.v1.LiveSession.started_at:type_name -> google.protobuf.Timestamp
+	58, // 31: reel.v1.LiveSession.ended_at:type_name -> google.protobuf.Timestamp
+	58, // 32: reel.v1.LiveSession.created_at:type_name -> google.protobuf.Timestamp
+	52, // 33: reel.v1.LiveChatMessage.author:type_name -> reel.v1.Author
+	58, // 34: reel.v1.LiveChatMessage.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 35: reel.v1.ReelService.GetReel:input_type -> reel.v1.GetReelRequest
+	2,  // 36: reel.v1.ReelService.GetUserReels:input_type -> reel.v1.GetUserReelsRequest
+	4,  // 37: reel.v1.ReelService.GetReelsFeed:input_type -> reel.v1.GetReelsFeedRequest
+	6,  // 38: reel.v1.ReelService.CreateReel:input_type -> reel.v1.CreateReelRequest
+	8,  // 39: reel.v1.ReelService.DeleteReel:input_type -> reel.v1.DeleteReelRequest
+	10, // 40: reel.v1.ReelService.AddComment:input_type -> reel.v1.AddCommentRequest
+	12, // 41: reel.v1.ReelService.AddReply:input_type -> reel.v1.AddReplyRequest
+	14, // 42: reel.v1.ReelService.ReactToComment:input_type -> reel.v1.ReactToCommentRequest
+	16, // 43: reel.v1.ReelService.GetReplies:input_type -> reel.v1.GetRepliesRequest
+	18, // 44: reel.v1.ReelService.ReactToReply:input_type -> reel.v1.ReactToReplyRequest
+	20, // 45: reel.v1.ReelService.DeleteComment:input_type -> reel.v1.DeleteCommentRequest
+	22, // 46: reel.v1.ReelService.DeleteReply:input_type -> reel.v1.DeleteReplyRequest
+	46, // 47: reel.v1.ReelService.IncrementView:input_type -> reel.v1.IncrementViewRequest
+	24, // 48: reel.v1.ReelService.ReactToReel:input_type -> reel.v1.ReactToReelRequest
+	26, // 49: reel.v1.ReelService.GetComments:input_type -> reel.v1.GetCommentsRequest
+	28, // 50: reel.v1.ReelService.GetReelProcessingStatus:input_type -> reel.v1.GetReelProcessingStatusRequest
+	30, // 51: reel.v1.ReelService.GetForYouFeed:input_type -> reel.v1.GetForYouFeedRequest
+	32, // 52: reel.v1.ReelService.RecordEngagement:input_type -> reel.v1.RecordEngagementRequest
+	34, // 53: reel.v1.ReelService.CreateLiveSession:input_type -> reel.v1.CreateLiveSessionRequest
+	35, // 54: reel.v1.ReelService.GetLiveSession:input_type -> reel.v1.GetLiveSessionRequest
+	37, // 55: reel.v1.ReelService.ListLiveSessions:input_type -> reel.v1.ListLiveSessionsRequest
+	39, // 56: reel.v1.ReelService.EndLiveSession:input_type -> reel.v1.EndLiveSessionRequest
+	40, // 57: reel.v1.ReelService.JoinLiveSession:input_type -> reel.v1.LiveViewerRequest
+	40, // 58: reel.v1.ReelService.LeaveLiveSession:input_type -> reel.v1.LiveViewerRequest
+	42, // 59: reel.v1.ReelService.SendLiveChatMessage:input_type -> reel.v1.SendLiveChatMessageRequest
+	44, // 60: reel.v1.ReelService.GetLiveChatMessages:input_type -> reel.v1.GetLiveChatMessagesRequest
+	1,  // 61: reel.v1.ReelService.GetReel:output_type -> reel.v1.GetReelResponse
+	3,  // 62: reel.v1.ReelService.GetUserReels:output_type -> reel.v1.GetUserReelsResponse
+	5,  // 63: reel.v1.ReelService.GetReelsFeed:output_type -> reel.v1.GetReelsFeedResponse
+	7,  // 64: reel.v1.ReelService.CreateReel:output_type -> reel.v1.CreateReelResponse
+	9,  // 65: reel.v1.ReelService.DeleteReel:output_type -> reel.v1.DeleteReelResponse
+	11, // 66: reel.v1.ReelService.AddComment:output_type -> reel.v1.AddCommentResponse
+	13, // 67: reel.v1.ReelService.AddReply:output_type -> reel.v1.AddReplyResponse
+	15, // 68: reel.v1.ReelService.ReactToComment:output_type -> reel.v1.ReactToCommentResponse
+	17, // 69: reel.v1.ReelService.GetReplies:output_type -> reel.v1.GetRepliesResponse
+	19, // 70: reel.v1.ReelService.ReactToReply:output_type -> reel.v1.ReactToReplyResponse
+	21, // 71: reel.v1.ReelService.DeleteComment:output_type -> reel.v1.DeleteCommentResponse
+	23, // 72: reel.v1.ReelService.DeleteReply:output_type -> reel.v1.DeleteReplyResponse
+	47, // 73: reel.v1.ReelService.IncrementView:output_type -> reel.v1.IncrementViewResponse
+	25, // 74: reel.v1.ReelService.ReactToReel:output_type -> reel.v1.ReactToReelResponse
+	27, // 75: reel.v1.ReelService.GetComments:output_type -> reel.v1.GetCommentsResponse
+	29, // 76: reel.v1.ReelService.GetReelProcessingStatus:output_type -> reel.v1.GetReelProcessingStatusResponse
+	31, // 77: reel.v1.ReelService.GetForYouFeed:output_type -> reel.v1.GetForYouFeedResponse
+	33, // 78: reel.v1.ReelService.RecordEngagement:output_type -> reel.v1.RecordEngagementResponse
+	36, // 79: reel.v1.ReelService.CreateLiveSession:output_type -> reel.v1.LiveSessionResponse
+	36, // 80: reel.v1.ReelService.GetLiveSession:output_type -> reel.v1.LiveSessionResponse
+	38, // 81: reel.v1.ReelService.ListLiveSessions:output_type -> reel.v1.ListLiveSessionsResponse
+	36, // 82: reel.v1.ReelService.EndLiveSession:output_type -> reel.v1.LiveSessionResponse
+	41, // 83: reel.v1.ReelService.JoinLiveSession:output_type -> reel.v1.LiveViewerResponse
+	41, // 84: reel.v1.ReelService.LeaveLiveSession:output_type -> reel.v1.LiveViewerResponse
+	43, // 85: reel.v1.ReelService.SendLiveChatMessage:output_type -> reel.v1.SendLiveChatMessageResponse
+	45, // 86: reel.v1.ReelService.GetLiveChatMessages:output_type -> reel.v1.GetLiveChatMessagesResponse
+	61, // [61:87] is the sub-list for method output_type
+	35, // [35:61] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_proto_reel_v1_reel_proto_init() }
@@ -1857,7 +3932,7 @@ func file_proto_reel_v1_reel_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_reel_v1_reel_proto_rawDesc), len(file_proto_reel_v1_reel_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   26,
+			NumMessages:   58,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
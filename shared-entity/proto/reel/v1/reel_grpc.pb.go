@@ -19,17 +19,32 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ReelService_GetReel_FullMethodName        = "/reel.v1.ReelService/GetReel"
-	ReelService_GetUserReels_FullMethodName   = "/reel.v1.ReelService/GetUserReels"
-	ReelService_GetReelsFeed_FullMethodName   = "/reel.v1.ReelService/GetReelsFeed"
-	ReelService_CreateReel_FullMethodName     = "/reel.v1.ReelService/CreateReel"
-	ReelService_DeleteReel_FullMethodName     = "/reel.v1.ReelService/DeleteReel"
-	ReelService_AddComment_FullMethodName     = "/reel.v1.ReelService/AddComment"
-	ReelService_AddReply_FullMethodName       = "/reel.v1.ReelService/AddReply"
-	ReelService_ReactToComment_FullMethodName = "/reel.v1.ReelService/ReactToComment"
-	ReelService_IncrementView_FullMethodName  = "/reel.v1.ReelService/IncrementView"
-	ReelService_ReactToReel_FullMethodName    = "/reel.v1.ReelService/ReactToReel"
-	ReelService_GetComments_FullMethodName    = "/reel.v1.ReelService/GetComments"
+	ReelService_GetReel_FullMethodName                 = "/reel.v1.ReelService/GetReel"
+	ReelService_GetUserReels_FullMethodName            = "/reel.v1.ReelService/GetUserReels"
+	ReelService_GetReelsFeed_FullMethodName            = "/reel.v1.ReelService/GetReelsFeed"
+	ReelService_CreateReel_FullMethodName              = "/reel.v1.ReelService/CreateReel"
+	ReelService_DeleteReel_FullMethodName              = "/reel.v1.ReelService/DeleteReel"
+	ReelService_AddComment_FullMethodName              = "/reel.v1.ReelService/AddComment"
+	ReelService_AddReply_FullMethodName                = "/reel.v1.ReelService/AddReply"
+	ReelService_ReactToComment_FullMethodName          = "/reel.v1.ReelService/ReactToComment"
+	ReelService_GetReplies_FullMethodName              = "/reel.v1.ReelService/GetReplies"
+	ReelService_ReactToReply_FullMethodName            = "/reel.v1.ReelService/ReactToReply"
+	ReelService_DeleteComment_FullMethodName           = "/reel.v1.ReelService/DeleteComment"
+	ReelService_DeleteReply_FullMethodName             = "/reel.v1.ReelService/DeleteReply"
+	ReelService_IncrementView_FullMethodName           = "/reel.v1.ReelService/IncrementView"
+	ReelService_ReactToReel_FullMethodName             = "/reel.v1.ReelService/ReactToReel"
+	ReelService_GetComments_FullMethodName             = "/reel.v1.ReelService/GetComments"
+	ReelService_GetReelProcessingStatus_FullMethodName = "/reel.v1.ReelService/GetReelProcessingStatus"
+	ReelService_GetForYouFeed_FullMethodName           = "/reel.v1.ReelService/GetForYouFeed"
+	ReelService_RecordEngagement_FullMethodName        = "/reel.v1.ReelService/RecordEngagement"
+	ReelService_CreateLiveSession_FullMethodName       = "/reel.v1.ReelService/CreateLiveSession"
+	ReelService_GetLiveSession_FullMethodName          = "/reel.v1.ReelService/GetLiveSession"
+	ReelService_ListLiveSessions_FullMethodName        = "/reel.v1.ReelService/ListLiveSessions"
+	ReelService_EndLiveSession_FullMethodName          = "/reel.v1.ReelService/EndLiveSession"
+	ReelService_JoinLiveSession_FullMethodName         = "/reel.v1.ReelService/JoinLiveSession"
+	ReelService_LeaveLiveSession_FullMethodName        = "/reel.v1.ReelService/LeaveLiveSession"
+	ReelService_SendLiveChatMessage_FullMethodName     = "/reel.v1.ReelService/SendLiveChatMessage"
+	ReelService_GetLiveChatMessages_FullMethodName     = "/reel.v1.ReelService/GetLiveChatMessages"
 )
 
 // ReelServiceClient is the client API for ReelService service.
@@ -44,9 +59,26 @@ type ReelServiceClient interface {
 	AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*AddCommentResponse, error)
 	AddReply(ctx context.Context, in *AddReplyRequest, opts ...grpc.CallOption) (*AddReplyResponse, error)
 	ReactToComment(ctx context.Context, in *ReactToCommentRequest, opts ...grpc.CallOption) (*ReactToCommentResponse, error)
+	GetReplies(ctx context.Context, in *GetRepliesRequest, opts ...grpc.CallOption) (*GetRepliesResponse, error)
+	ReactToReply(ctx context.Context, in *ReactToReplyRequest, opts ...grpc.CallOption) (*ReactToReplyResponse, error)
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
+	DeleteReply(ctx context.Context, in *DeleteReplyRequest, opts ...grpc.CallOption) (*DeleteReplyResponse, error)
 	IncrementView(ctx context.Context, in *IncrementViewRequest, opts ...grpc.CallOption) (*IncrementViewResponse, error)
 	ReactToReel(ctx context.Context, in *ReactToReelRequest, opts ...grpc.CallOption) (*ReactToReelResponse, error)
 	GetComments(ctx context.Context, in *GetCommentsRequest, opts ...grpc.CallOption) (*GetCommentsResponse, error)
+	GetReelProcessingStatus(ctx context.Context, in *GetReelProcessingStatusRequest, opts ...grpc.CallOption) (*GetReelProcessingStatusResponse, error)
+	GetForYouFeed(ctx context.Context, in *GetForYouFeedRequest, opts ...grpc.CallOption) (*GetForYouFeedResponse, error)
+	RecordEngagement(ctx context.Context, in *RecordEngagementRequest, opts ...grpc.CallOption) (*RecordEngagementResponse, error)
+	// Live streaming. Broadcasters push RTMP to the media server with the
+	// session's stream key; viewers play its HLS output.
+	CreateLiveSession(ctx context.Context, in *CreateLiveSessionRequest, opts ...grpc.CallOption) (*LiveSessionResponse, error)
+	GetLiveSession(ctx context.Context, in *GetLiveSessionRequest, opts ...grpc.CallOption) (*LiveSessionResponse, error)
+	ListLiveSessions(ctx context.Context, in *ListLiveSessionsRequest, opts ...grpc.CallOption) (*ListLiveSessionsResponse, error)
+	EndLiveSession(ctx context.Context, in *EndLiveSessionRequest, opts ...grpc.CallOption) (*LiveSessionResponse, error)
+	JoinLiveSession(ctx context.Context, in *LiveViewerRequest, opts ...grpc.CallOption) (*LiveViewerResponse, error)
+	LeaveLiveSession(ctx context.Context, in *LiveViewerRequest, opts ...grpc.CallOption) (*LiveViewerResponse, error)
+	SendLiveChatMessage(ctx context.Context, in *SendLiveChatMessageRequest, opts ...grpc.CallOption) (*SendLiveChatMessageResponse, error)
+	GetLiveChatMessages(ctx context.Context, in *GetLiveChatMessagesRequest, opts ...grpc.CallOption) (*GetLiveChatMessagesResponse, error)
 }
 
 type reelServiceClient struct {
@@ -137,6 +169,46 @@ func (c *reelServiceClient) ReactToComment(ctx context.Context, in *ReactToComme
 	return out, nil
 }
 
+func (c *reelServiceClient) GetReplies(ctx context.Context, in *GetRepliesRequest, opts ...grpc.CallOption) (*GetRepliesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRepliesResponse)
+	err := c.cc.Invoke(ctx, ReelService_GetReplies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reelServiceClient) ReactToReply(ctx context.Context, in *ReactToReplyRequest, opts ...grpc.CallOption) (*ReactToReplyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactToReplyResponse)
+	err := c.cc.Invoke(ctx, ReelService_ReactToReply_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reelServiceClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCommentResponse)
+	err := c.cc.Invoke(ctx, ReelService_DeleteComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reelServiceClient) DeleteReply(ctx context.Context, in *DeleteReplyRequest, opts ...grpc.CallOption) (*DeleteReplyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteReplyResponse)
+	err := c.cc.Invoke(ctx, ReelService_DeleteReply_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *reelServiceClient) IncrementView(ctx context.Context, in *IncrementViewRequest, opts ...grpc.CallOption) (*IncrementViewResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(IncrementViewResponse)
@@ -167,6 +239,116 @@ func (c *reelServiceClient) GetComments(ctx context.Context, in *GetCommentsRequ
 	return out, nil
 }
 
+func (c *reelServiceClient) GetReelProcessingStatus(ctx context.Context, in *GetReelProcessingStatusRequest, opts ...grpc.CallOption) (*GetReelProcessingStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReelProcessingStatusResponse)
+	err := c.cc.Invoke(ctx, ReelService_GetReelProcessingStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reelServiceClient) GetForYouFeed(ctx context.Context, in *GetForYouFeedRequest, opts ...grpc.CallOption) (*GetForYouFeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetForYouFeedResponse)
+	err := c.cc.Invoke(ctx, ReelService_GetForYouFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reelServiceClient) RecordEngagement(ctx context.Context, in *RecordEngagementRequest, opts ...grpc.CallOption) (*RecordEngagementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordEngagementResponse)
+	err := c.cc.Invoke(ctx, ReelService_RecordEngagement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reelServiceClient) CreateLiveSession(ctx context.Context, in *CreateLiveSessionRequest, opts ...grpc.CallOption) (*LiveSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LiveSessionResponse)
+	err := c.cc.Invoke(ctx, ReelService_CreateLiveSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reelServiceClient) GetLiveSession(ctx context.Context, in *GetLiveSessionRequest, opts ...grpc.CallOption) (*LiveSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LiveSessionResponse)
+	err := c.cc.Invoke(ctx, ReelService_GetLiveSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reelServiceClient) ListLiveSessions(ctx context.Context, in *ListLiveSessionsRequest, opts ...grpc.CallOption) (*ListLiveSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLiveSessionsResponse)
+	err := c.cc.Invoke(ctx, ReelService_ListLiveSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reelServiceClient) EndLiveSession(ctx context.Context, in *EndLiveSessionRequest, opts ...grpc.CallOption) (*LiveSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LiveSessionResponse)
+	err := c.cc.Invoke(ctx, ReelService_EndLiveSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reelServiceClient) JoinLiveSession(ctx context.Context, in *LiveViewerRequest, opts ...grpc.CallOption) (*LiveViewerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LiveViewerResponse)
+	err := c.cc.Invoke(ctx, ReelService_JoinLiveSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reelServiceClient) LeaveLiveSession(ctx context.Context, in *LiveViewerRequest, opts ...grpc.CallOption) (*LiveViewerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LiveViewerResponse)
+	err := c.cc.Invoke(ctx, ReelService_LeaveLiveSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reelServiceClient) SendLiveChatMessage(ctx context.Context, in *SendLiveChatMessageRequest, opts ...grpc.CallOption) (*SendLiveChatMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendLiveChatMessageResponse)
+	err := c.cc.Invoke(ctx, ReelService_SendLiveChatMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reelServiceClient) GetLiveChatMessages(ctx context.Context, in *GetLiveChatMessagesRequest, opts ...grpc.CallOption) (*GetLiveChatMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLiveChatMessagesResponse)
+	err := c.cc.Invoke(ctx, ReelService_GetLiveChatMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ReelServiceServer is the server API for ReelService service.
 // All implementations must embed UnimplementedReelServiceServer
 // for forward compatibility.
@@ -179,9 +361,26 @@ type ReelServiceServer interface {
 	AddComment(context.Context, *AddCommentRequest) (*AddCommentResponse, error)
 	AddReply(context.Context, *AddReplyRequest) (*AddReplyResponse, error)
 	ReactToComment(context.Context, *ReactToCommentRequest) (*ReactToCommentResponse, error)
+	GetReplies(context.Context, *GetRepliesRequest) (*GetRepliesResponse, error)
+	ReactToReply(context.Context, *ReactToReplyRequest) (*ReactToReplyResponse, error)
+	DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error)
+	DeleteReply(context.Context, *DeleteReplyRequest) (*DeleteReplyResponse, error)
 	IncrementView(context.Context, *IncrementViewRequest) (*IncrementViewResponse, error)
 	ReactToReel(context.Context, *ReactToReelRequest) (*ReactToReelResponse, error)
 	GetComments(context.Context, *GetCommentsRequest) (*GetCommentsResponse, error)
+	GetReelProcessingStatus(context.Context, *GetReelProcessingStatusRequest) (*GetReelProcessingStatusResponse, error)
+	GetForYouFeed(context.Context, *GetForYouFeedRequest) (*GetForYouFeedResponse, error)
+	RecordEngagement(context.Context, *RecordEngagementRequest) (*RecordEngagementResponse, error)
+	// Live streaming. Broadcasters push RTMP to the media server with the
+	// session's stream key; viewers play its HLS output.
+	CreateLiveSession(context.Context, *CreateLiveSessionRequest) (*LiveSessionResponse, error)
+	GetLiveSession(context.Context, *GetLiveSessionRequest) (*LiveSessionResponse, error)
+	ListLiveSessions(context.Context, *ListLiveSessionsRequest) (*ListLiveSessionsResponse, error)
+	EndLiveSession(context.Context, *EndLiveSessionRequest) (*LiveSessionResponse, error)
+	JoinLiveSession(context.Context, *LiveViewerRequest) (*LiveViewerResponse, error)
+	LeaveLiveSession(context.Context, *LiveViewerRequest) (*LiveViewerResponse, error)
+	SendLiveChatMessage(context.Context, *SendLiveChatMessageRequest) (*SendLiveChatMessageResponse, error)
+	GetLiveChatMessages(context.Context, *GetLiveChatMessagesRequest) (*GetLiveChatMessagesResponse, error)
 	mustEmbedUnimplementedReelServiceServer()
 }
 
@@ -216,6 +415,18 @@ func (UnimplementedReelServiceServer) AddReply(context.Context, *AddReplyRequest
 func (UnimplementedReelServiceServer) ReactToComment(context.Context, *ReactToCommentRequest) (*ReactToCommentResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ReactToComment not implemented")
 }
+func (UnimplementedReelServiceServer) GetReplies(context.Context, *GetRepliesRequest) (*GetRepliesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReplies not implemented")
+}
+func (UnimplementedReelServiceServer) ReactToReply(context.Context, *ReactToReplyRequest) (*ReactToReplyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReactToReply not implemented")
+}
+func (UnimplementedReelServiceServer) DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteComment not implemented")
+}
+func (UnimplementedReelServiceServer) DeleteReply(context.Context, *DeleteReplyRequest) (*DeleteReplyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteReply not implemented")
+}
 func (UnimplementedReelServiceServer) IncrementView(context.Context, *IncrementViewRequest) (*IncrementViewResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method IncrementView not implemented")
 }
@@ -225,6 +436,39 @@ func (UnimplementedReelServiceServer) ReactToReel(context.Context, *ReactToReelR
 func (UnimplementedReelServiceServer) GetComments(context.Context, *GetCommentsRequest) (*GetCommentsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetComments not implemented")
 }
+func (UnimplementedReelServiceServer) GetReelProcessingStatus(context.Context, *GetReelProcessingStatusRequest) (*GetReelProcessingStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReelProcessingStatus not implemented")
+}
+func (UnimplementedReelServiceServer) GetForYouFeed(context.Context, *GetForYouFeedRequest) (*GetForYouFeedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetForYouFeed not implemented")
+}
+func (UnimplementedReelServiceServer) RecordEngagement(context.Context, *RecordEngagementRequest) (*RecordEngagementResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordEngagement not implemented")
+}
+func (UnimplementedReelServiceServer) CreateLiveSession(context.Context, *CreateLiveSessionRequest) (*LiveSessionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateLiveSession not implemented")
+}
+func (UnimplementedReelServiceServer) GetLiveSession(context.Context, *GetLiveSessionRequest) (*LiveSessionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLiveSession not implemented")
+}
+func (UnimplementedReelServiceServer) ListLiveSessions(context.Context, *ListLiveSessionsRequest) (*ListLiveSessionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLiveSessions not implemented")
+}
+func (UnimplementedReelServiceServer) EndLiveSession(context.Context, *EndLiveSessionRequest) (*LiveSessionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EndLiveSession not implemented")
+}
+func (UnimplementedReelServiceServer) JoinLiveSession(context.Context, *LiveViewerRequest) (*LiveViewerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JoinLiveSession not implemented")
+}
+func (UnimplementedReelServiceServer) LeaveLiveSession(context.Context, *LiveViewerRequest) (*LiveViewerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LeaveLiveSession not implemented")
+}
+func (UnimplementedReelServiceServer) SendLiveChatMessage(context.Context, *SendLiveChatMessageRequest) (*SendLiveChatMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SendLiveChatMessage not implemented")
+}
+func (UnimplementedReelServiceServer) GetLiveChatMessages(context.Context, *GetLiveChatMessagesRequest) (*GetLiveChatMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLiveChatMessages not implemented")
+}
 func (UnimplementedReelServiceServer) mustEmbedUnimplementedReelServiceServer() {}
 func (UnimplementedReelServiceServer) testEmbeddedByValue()                     {}
 
@@ -390,6 +634,78 @@ func _ReelService_ReactToComment_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReelService_GetReplies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRepliesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).GetReplies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_GetReplies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).GetReplies(ctx, req.(*GetRepliesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReelService_ReactToReply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactToReplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).ReactToReply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_ReactToReply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).ReactToReply(ctx, req.(*ReactToReplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReelService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_DeleteComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReelService_DeleteReply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteReplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).DeleteReply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_DeleteReply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).DeleteReply(ctx, req.(*DeleteReplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ReelService_IncrementView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IncrementViewRequest)
 	if err := dec(in); err != nil {
@@ -444,6 +760,204 @@ func _ReelService_GetComments_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReelService_GetReelProcessingStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReelProcessingStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).GetReelProcessingStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_GetReelProcessingStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).GetReelProcessingStatus(ctx, req.(*GetReelProcessingStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReelService_GetForYouFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetForYouFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).GetForYouFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_GetForYouFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).GetForYouFeed(ctx, req.(*GetForYouFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReelService_RecordEngagement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordEngagementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).RecordEngagement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_RecordEngagement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).RecordEngagement(ctx, req.(*RecordEngagementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReelService_CreateLiveSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLiveSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).CreateLiveSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_CreateLiveSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).CreateLiveSession(ctx, req.(*CreateLiveSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReelService_GetLiveSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLiveSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).GetLiveSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_GetLiveSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).GetLiveSession(ctx, req.(*GetLiveSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReelService_ListLiveSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLiveSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).ListLiveSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_ListLiveSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).ListLiveSessions(ctx, req.(*ListLiveSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReelService_EndLiveSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndLiveSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).EndLiveSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_EndLiveSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).EndLiveSession(ctx, req.(*EndLiveSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReelService_JoinLiveSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LiveViewerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).JoinLiveSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_JoinLiveSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).JoinLiveSession(ctx, req.(*LiveViewerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReelService_LeaveLiveSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LiveViewerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).LeaveLiveSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_LeaveLiveSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).LeaveLiveSession(ctx, req.(*LiveViewerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReelService_SendLiveChatMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendLiveChatMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).SendLiveChatMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_SendLiveChatMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).SendLiveChatMessage(ctx, req.(*SendLiveChatMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReelService_GetLiveChatMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLiveChatMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReelServiceServer).GetLiveChatMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReelService_GetLiveChatMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReelServiceServer).GetLiveChatMessages(ctx, req.(*GetLiveChatMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ReelService_ServiceDesc is the grpc.ServiceDesc for ReelService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -483,6 +997,22 @@ var ReelService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReactToComment",
 			Handler:    _ReelService_ReactToComment_Handler,
 		},
+		{
+			MethodName: "GetReplies",
+			Handler:    _ReelService_GetReplies_Handler,
+		},
+		{
+			MethodName: "ReactToReply",
+			Handler:    _ReelService_ReactToReply_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _ReelService_DeleteComment_Handler,
+		},
+		{
+			MethodName: "DeleteReply",
+			Handler:    _ReelService_DeleteReply_Handler,
+		},
 		{
 			MethodName: "IncrementView",
 			Handler:    _ReelService_IncrementView_Handler,
@@ -495,6 +1025,50 @@ var ReelService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetComments",
 			Handler:    _ReelService_GetComments_Handler,
 		},
+		{
+			MethodName: "GetReelProcessingStatus",
+			Handler:    _ReelService_GetReelProcessingStatus_Handler,
+		},
+		{
+			MethodName: "GetForYouFeed",
+			Handler:    _ReelService_GetForYouFeed_Handler,
+		},
+		{
+			MethodName: "RecordEngagement",
+			Handler:    _ReelService_RecordEngagement_Handler,
+		},
+		{
+			MethodName: "CreateLiveSession",
+			Handler:    _ReelService_CreateLiveSession_Handler,
+		},
+		{
+			MethodName: "GetLiveSession",
+			Handler:    _ReelService_GetLiveSession_Handler,
+		},
+		{
+			MethodName: "ListLiveSessions",
+			Handler:    _ReelService_ListLiveSessions_Handler,
+		},
+		{
+			MethodName: "EndLiveSession",
+			Handler:    _ReelService_EndLiveSession_Handler,
+		},
+		{
+			MethodName: "JoinLiveSession",
+			Handler:    _ReelService_JoinLiveSession_Handler,
+		},
+		{
+			MethodName: "LeaveLiveSession",
+			Handler:    _ReelService_LeaveLiveSession_Handler,
+		},
+		{
+			MethodName: "SendLiveChatMessage",
+			Handler:    _ReelService_SendLiveChatMessage_Handler,
+		},
+		{
+			MethodName: "GetLiveChatMessages",
+			Handler:    _ReelService_GetLiveChatMessages_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/reel/v1/reel.proto",
@@ -0,0 +1,522 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: proto/search/v1/search.proto
+
+package searchpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SearchHit struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Type          string                    `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "user", "post", "event", "listing"
+	Id            string                    `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Score         float64                   `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	Document      []byte                    `protobuf:"bytes,4,opt,name=document,proto3" json:"document,omitempty"` // JSON search document for the entity type
+	Highlights    map[string]*HighlightList `protobuf:"bytes,5,rep,name=highlights,proto3" json:"highlights,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_proto_search_v1_search_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_v1_search_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_proto_search_v1_search_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SearchHit) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SearchHit) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchHit) GetDocument() []byte {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *SearchHit) GetHighlights() map[string]*HighlightList {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+type HighlightList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fragments     []string               `protobuf:"bytes,1,rep,name=fragments,proto3" json:"fragments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HighlightList) Reset() {
+	*x = HighlightList{}
+	mi := &file_proto_search_v1_search_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HighlightList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HighlightList) ProtoMessage() {}
+
+func (x *HighlightList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_v1_search_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HighlightList.ProtoReflect.Descriptor instead.
+func (*HighlightList) Descriptor() ([]byte, []int) {
+	return file_proto_search_v1_search_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HighlightList) GetFragments() []string {
+	if x != nil {
+		return x.Fragments
+	}
+	return nil
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ViewerId      string                 `protobuf:"bytes,1,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Types         []string               `protobuf:"bytes,3,rep,name=types,proto3" json:"types,omitempty"` // Empty searches every type
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_proto_search_v1_search_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_v1_search_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_search_v1_search_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SearchRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*SearchHit           `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	TookMs        int64                  `protobuf:"varint,3,opt,name=took_ms,json=tookMs,proto3" json:"took_ms,omitempty"`
+	Partial       bool                   `protobuf:"varint,4,opt,name=partial,proto3" json:"partial,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_proto_search_v1_search_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_v1_search_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_search_v1_search_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchResponse) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetTookMs() int64 {
+	if x != nil {
+		return x.TookMs
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+type TopResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ViewerId      string                 `protobuf:"bytes,1,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	PerType       int32                  `protobuf:"varint,3,opt,name=per_type,json=perType,proto3" json:"per_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopResultsRequest) Reset() {
+	*x = TopResultsRequest{}
+	mi := &file_proto_search_v1_search_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopResultsRequest) ProtoMessage() {}
+
+func (x *TopResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_v1_search_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopResultsRequest.ProtoReflect.Descriptor instead.
+func (*TopResultsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_search_v1_search_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TopResultsRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+func (x *TopResultsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *TopResultsRequest) GetPerType() int32 {
+	if x != nil {
+		return x.PerType
+	}
+	return 0
+}
+
+type TopResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*SearchHit           `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Posts         []*SearchHit           `protobuf:"bytes,2,rep,name=posts,proto3" json:"posts,omitempty"`
+	Events        []*SearchHit           `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	Listings      []*SearchHit           `protobuf:"bytes,4,rep,name=listings,proto3" json:"listings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopResultsResponse) Reset() {
+	*x = TopResultsResponse{}
+	mi := &file_proto_search_v1_search_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopResultsResponse) ProtoMessage() {}
+
+func (x *TopResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_v1_search_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopResultsResponse.ProtoReflect.Descriptor instead.
+func (*TopResultsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_search_v1_search_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TopResultsResponse) GetUsers() []*SearchHit {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *TopResultsResponse) GetPosts() []*SearchHit {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *TopResultsResponse) GetEvents() []*SearchHit {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *TopResultsResponse) GetListings() []*SearchHit {
+	if x != nil {
+		return x.Listings
+	}
+	return nil
+}
+
+var File_proto_search_v1_search_proto protoreflect.FileDescriptor
+
+const file_proto_search_v1_search_proto_rawDesc = "" +
+	"\n" +
+	"\x1cproto/search/v1/search.proto\x12\tsearch.v1\"\x80\x02\n" +
+	"\tSearchHit\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12\x1a\n" +
+	"\bdocument\x18\x04 \x01(\fR\bdocument\x12D\n" +
+	"\n" +
+	"highlights\x18\x05 \x03(\v2$.search.v1.SearchHit.HighlightsEntryR\n" +
+	"highlights\x1aW\n" +
+	"\x0fHighlightsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12.\n" +
+	"\x05value\x18\x02 \x01(\v2\x18.search.v1.HighlightListR\x05value:\x028\x01\"-\n" +
+	"\rHighlightList\x12\x1c\n" +
+	"\tfragments\x18\x01 \x03(\tR\tfragments\"\x86\x01\n" +
+	"\rSearchRequest\x12\x1b\n" +
+	"\tviewer_id\x18\x01 \x01(\tR\bviewerId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x14\n" +
+	"\x05types\x18\x03 \x03(\tR\x05types\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\"\x83\x01\n" +
+	"\x0eSearchResponse\x12(\n" +
+	"\x04hits\x18\x01 \x03(\v2\x14.search.v1.SearchHitR\x04hits\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x17\n" +
+	"\atook_ms\x18\x03 \x01(\x03R\x06tookMs\x12\x18\n" +
+	"\apartial\x18\x04 \x01(\bR\apartial\"a\n" +
+	"\x11TopResultsRequest\x12\x1b\n" +
+	"\tviewer_id\x18\x01 \x01(\tR\bviewerId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x19\n" +
+	"\bper_type\x18\x03 \x01(\x05R\aperType\"\xcc\x01\n" +
+	"\x12TopResultsResponse\x12*\n" +
+	"\x05users\x18\x01 \x03(\v2\x14.search.v1.SearchHitR\x05users\x12*\n" +
+	"\x05posts\x18\x02 \x03(\v2\x14.search.v1.SearchHitR\x05posts\x12,\n" +
+	"\x06events\x18\x03 \x03(\v2\x14.search.v1.SearchHitR\x06events\x120\n" +
+	"\blistings\x18\x04 \x03(\v2\x14.search.v1.SearchHitR\blistings2\x99\x01\n" +
+	"\rSearchService\x12=\n" +
+	"\x06Search\x12\x18.search.v1.SearchRequest\x1a\x19.search.v1.SearchResponse\x12I\n" +
+	"\n" +
+	"TopResults\x12\x1c.search.v1.TopResultsRequest\x1a\x1d.search.v1.TopResultsResponseBLZJgithub.com/MuhibNayem/connectify-v2/shared-entity/proto/search/v1;searchpbb\x06proto3"
+
+var (
+	file_proto_search_v1_search_proto_rawDescOnce sync.Once
+	file_proto_search_v1_search_proto_rawDescData []byte
+)
+
+func file_proto_search_v1_search_proto_rawDescGZIP() []byte {
+	file_proto_search_v1_search_proto_rawDescOnce.Do(func() {
+		file_proto_search_v1_search_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_search_v1_search_proto_rawDesc), len(file_proto_search_v1_search_proto_rawDesc)))
+	})
+	return file_proto_search_v1_search_proto_rawDescData
+}
+
+var file_proto_search_v1_search_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_search_v1_search_proto_goTypes = []any{
+	(*SearchHit)(nil),          // 0: search.v1.SearchHit
+	(*HighlightList)(nil),      // 1: search.v1.HighlightList
+	(*SearchRequest)(nil),      // 2: search.v1.SearchRequest
+	(*SearchResponse)(nil),     // 3: search.v1.SearchResponse
+	(*TopResultsRequest)(nil),  // 4: search.v1.TopResultsRequest
+	(*TopResultsResponse)(nil), // 5: search.v1.TopResultsResponse
+	nil,                        // 6: search.v1.SearchHit.HighlightsEntry
+}
+var file_proto_search_v1_search_proto_depIdxs = []int32{
+	6, // 0: search.v1.SearchHit.highlights:type_name -> search.v1.SearchHit.HighlightsEntry
+	0, // 1: search.v1.SearchResponse.hits:type_name -> search.v1.SearchHit
+	0, // 2: search.v1.TopResultsResponse.users:type_name -> search.v1.SearchHit
+	0, // 3: search.v1.TopResultsResponse.posts:type_name -> search.v1.SearchHit
+	0, // 4: search.v1.TopResultsResponse.events:type_name -> search.v1.SearchHit
+	0, // 5: search.v1.TopResultsResponse.listings:type_name -> search.v1.SearchHit
+	1, // 6: search.v1.SearchHit.HighlightsEntry.value:type_name -> search.v1.HighlightList
+	2, // 7: search.v1.SearchService.Search:input_type -> search.v1.SearchRequest
+	4, // 8: search.v1.SearchService.TopResults:input_type -> search.v1.TopResultsRequest
+	3, // 9: search.v1.SearchService.Search:output_type -> search.v1.SearchResponse
+	5, // 10: search.v1.SearchService.TopResults:output_type -> search.v1.TopResultsResponse
+	9, // [9:11] is the sub-list for method output_type
+	7, // [7:9] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_proto_search_v1_search_proto_init() }
+func file_proto_search_v1_search_proto_init() {
+	if File_proto_search_v1_search_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_search_v1_search_proto_rawDesc), len(file_proto_search_v1_search_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_search_v1_search_proto_goTypes,
+		DependencyIndexes: file_proto_search_v1_search_proto_depIdxs,
+		MessageInfos:      file_proto_search_v1_search_proto_msgTypes,
+	}.Build()
+	File_proto_search_v1_search_proto = out.File
+	file_proto_search_v1_search_proto_goTypes = nil
+	file_proto_search_v1_search_proto_depIdxs = nil
+}
@@ -2,7 +2,7 @@
 // versions:
 // 	protoc-gen-go v1.36.11
 // 	protoc        v6.33.2
-// source: shared-entity/proto/storage/v1/storage.proto
+// source: proto/storage/v1/storage.proto
 
 package storagev1
 
@@ -32,7 +32,7 @@ type UploadRequest struct {
 
 func (x *UploadRequest) Reset() {
 	*x = UploadRequest{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[0]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -44,7 +44,7 @@ func (x *UploadRequest) String() string {
 func (*UploadRequest) ProtoMessage() {}
 
 func (x *UploadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[0]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,7 +57,7 @@ func (x *UploadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
 func (*UploadRequest) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{0}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *UploadRequest) GetData() []byte {
@@ -94,7 +94,7 @@ type UploadResponse struct {
 
 func (x *UploadResponse) Reset() {
 	*x = UploadResponse{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[1]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -106,7 +106,7 @@ func (x *UploadResponse) String() string {
 func (*UploadResponse) ProtoMessage() {}
 
 func (x *UploadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[1]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -119,7 +119,7 @@ func (x *UploadResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadResponse.ProtoReflect.Descriptor instead.
 func (*UploadResponse) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{1}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *UploadResponse) GetUrl() string {
@@ -166,7 +166,7 @@ type UploadMultipleRequest struct {
 
 func (x *UploadMultipleRequest) Reset() {
 	*x = UploadMultipleRequest{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[2]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -178,7 +178,7 @@ func (x *UploadMultipleRequest) String() string {
 func (*UploadMultipleRequest) ProtoMessage() {}
 
 func (x *UploadMultipleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[2]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -191,7 +191,7 @@ func (x *UploadMultipleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadMultipleRequest.ProtoReflect.Descriptor instead.
 func (*UploadMultipleRequest) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{2}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *UploadMultipleRequest) GetFiles() []*FileUpload {
@@ -212,7 +212,7 @@ type FileUpload struct {
 
 func (x *FileUpload) Reset() {
 	*x = FileUpload{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[3]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -224,7 +224,7 @@ func (x *FileUpload) String() string {
 func (*FileUpload) ProtoMessage() {}
 
 func (x *FileUpload) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[3]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -237,7 +237,7 @@ func (x *FileUpload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileUpload.ProtoReflect.Descriptor instead.
 func (*FileUpload) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{3}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *FileUpload) GetData() []byte {
@@ -270,7 +270,7 @@ type UploadMultipleResponse struct {
 
 func (x *UploadMultipleResponse) Reset() {
 	*x = UploadMultipleResponse{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[4]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -282,7 +282,7 @@ func (x *UploadMultipleResponse) String() string {
 func (*UploadMultipleResponse) ProtoMessage() {}
 
 func (x *UploadMultipleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[4]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -295,7 +295,7 @@ func (x *UploadMultipleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadMultipleResponse.ProtoReflect.Descriptor instead.
 func (*UploadMultipleResponse) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{4}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UploadMultipleResponse) GetResults() []*UploadResponse {
@@ -314,7 +314,7 @@ type DeleteRequest struct {
 
 func (x *DeleteRequest) Reset() {
 	*x = DeleteRequest{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[5]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -326,7 +326,7 @@ func (x *DeleteRequest) String() string {
 func (*DeleteRequest) ProtoMessage() {}
 
 func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[5]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -339,7 +339,7 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{5}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DeleteRequest) GetKey() string {
@@ -358,7 +358,7 @@ type DeleteByURLRequest struct {
 
 func (x *DeleteByURLRequest) Reset() {
 	*x = DeleteByURLRequest{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[6]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -370,7 +370,7 @@ func (x *DeleteByURLRequest) String() string {
 func (*DeleteByURLRequest) ProtoMessage() {}
 
 func (x *DeleteByURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[6]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -383,7 +383,7 @@ func (x *DeleteByURLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteByURLRequest.ProtoReflect.Descriptor instead.
 func (*DeleteByURLRequest) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{6}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteByURLRequest) GetUrl() string {
@@ -402,7 +402,7 @@ type DeleteResponse struct {
 
 func (x *DeleteResponse) Reset() {
 	*x = DeleteResponse{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[7]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -414,7 +414,7 @@ func (x *DeleteResponse) String() string {
 func (*DeleteResponse) ProtoMessage() {}
 
 func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[7]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -427,7 +427,7 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{7}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteResponse) GetSuccess() bool {
@@ -447,7 +447,7 @@ type UploadArchiveRequest struct {
 
 func (x *UploadArchiveRequest) Reset() {
 	*x = UploadArchiveRequest{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[8]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -459,7 +459,7 @@ func (x *UploadArchiveRequest) String() string {
 func (*UploadArchiveRequest) ProtoMessage() {}
 
 func (x *UploadArchiveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[8]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -472,7 +472,7 @@ func (x *UploadArchiveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadArchiveRequest.ProtoReflect.Descriptor instead.
 func (*UploadArchiveRequest) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{8}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UploadArchiveRequest) GetObjectPath() string {
@@ -498,7 +498,7 @@ type UploadArchiveResponse struct {
 
 func (x *UploadArchiveResponse) Reset() {
 	*x = UploadArchiveResponse{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[9]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -510,7 +510,7 @@ func (x *UploadArchiveResponse) String() string {
 func (*UploadArchiveResponse) ProtoMessage() {}
 
 func (x *UploadArchiveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[9]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -523,7 +523,7 @@ func (x *UploadArchiveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadArchiveResponse.ProtoReflect.Descriptor instead.
 func (*UploadArchiveResponse) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{9}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UploadArchiveResponse) GetSuccess() bool {
@@ -542,7 +542,7 @@ type DownloadArchiveRequest struct {
 
 func (x *DownloadArchiveRequest) Reset() {
 	*x = DownloadArchiveRequest{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[10]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -554,7 +554,7 @@ func (x *DownloadArchiveRequest) String() string {
 func (*DownloadArchiveRequest) ProtoMessage() {}
 
 func (x *DownloadArchiveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[10]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -567,7 +567,7 @@ func (x *DownloadArchiveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DownloadArchiveRequest.ProtoReflect.Descriptor instead.
 func (*DownloadArchiveRequest) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{10}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DownloadArchiveRequest) GetObjectPath() string {
@@ -586,7 +586,7 @@ type DownloadArchiveResponse struct {
 
 func (x *DownloadArchiveResponse) Reset() {
 	*x = DownloadArchiveResponse{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[11]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -598,7 +598,7 @@ func (x *DownloadArchiveResponse) String() string {
 func (*DownloadArchiveResponse) ProtoMessage() {}
 
 func (x *DownloadArchiveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[11]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -611,7 +611,7 @@ func (x *DownloadArchiveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DownloadArchiveResponse.ProtoReflect.Descriptor instead.
 func (*DownloadArchiveResponse) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{11}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *DownloadArchiveResponse) GetData() []byte {
@@ -621,6 +621,94 @@ func (x *DownloadArchiveResponse) GetData() []byte {
 	return nil
 }
 
+type DeleteArchiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ObjectPath    string                 `protobuf:"bytes,1,opt,name=object_path,json=objectPath,proto3" json:"object_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteArchiveRequest) Reset() {
+	*x = DeleteArchiveRequest{}
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteArchiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteArchiveRequest) ProtoMessage() {}
+
+func (x *DeleteArchiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteArchiveRequest.ProtoReflect.Descriptor instead.
+func (*DeleteArchiveRequest) Descriptor() ([]byte, []int) {
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteArchiveRequest) GetObjectPath() string {
+	if x != nil {
+		return x.ObjectPath
+	}
+	return ""
+}
+
+type DeleteArchiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteArchiveResponse) Reset() {
+	*x = DeleteArchiveResponse{}
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteArchiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteArchiveResponse) ProtoMessage() {}
+
+func (x *DeleteArchiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteArchiveResponse.ProtoReflect.Descriptor instead.
+func (*DeleteArchiveResponse) Descriptor() ([]byte, []int) {
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteArchiveResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
 type GetPresignedURLRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -631,7 +719,7 @@ type GetPresignedURLRequest struct {
 
 func (x *GetPresignedURLRequest) Reset() {
 	*x = GetPresignedURLRequest{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[12]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -643,7 +731,7 @@ func (x *GetPresignedURLRequest) String() string {
 func (*GetPresignedURLRequest) ProtoMessage() {}
 
 func (x *GetPresignedURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[12]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -656,7 +744,7 @@ func (x *GetPresignedURLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPresignedURLRequest.ProtoReflect.Descriptor instead.
 func (*GetPresignedURLRequest) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{12}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetPresignedURLRequest) GetKey() string {
@@ -682,7 +770,7 @@ type GetPresignedURLResponse struct {
 
 func (x *GetPresignedURLResponse) Reset() {
 	*x = GetPresignedURLResponse{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[13]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -694,7 +782,7 @@ func (x *GetPresignedURLResponse) String() string {
 func (*GetPresignedURLResponse) ProtoMessage() {}
 
 func (x *GetPresignedURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[13]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -707,7 +795,7 @@ func (x *GetPresignedURLResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPresignedURLResponse.ProtoReflect.Descriptor instead.
 func (*GetPresignedURLResponse) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{13}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetPresignedURLResponse) GetUrl() string {
@@ -717,6 +805,102 @@ func (x *GetPresignedURLResponse) GetUrl() string {
 	return ""
 }
 
+type BatchGetPresignedURLsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []string               `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"` // Object keys or file URLs
+	ExpirySeconds int64                  `protobuf:"varint,2,opt,name=expiry_seconds,json=expirySeconds,proto3" json:"expiry_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetPresignedURLsRequest) Reset() {
+	*x = BatchGetPresignedURLsRequest{}
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetPresignedURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetPresignedURLsRequest) ProtoMessage() {}
+
+func (x *BatchGetPresignedURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetPresignedURLsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetPresignedURLsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchGetPresignedURLsRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *BatchGetPresignedURLsRequest) GetExpirySeconds() int64 {
+	if x != nil {
+		return x.ExpirySeconds
+	}
+	return 0
+}
+
+type BatchGetPresignedURLsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Urls          map[string]string      `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Keyed by the requested key; unsignable keys are omitted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetPresignedURLsResponse) Reset() {
+	*x = BatchGetPresignedURLsResponse{}
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetPresignedURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetPresignedURLsResponse) ProtoMessage() {}
+
+func (x *BatchGetPresignedURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetPresignedURLsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetPresignedURLsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BatchGetPresignedURLsResponse) GetUrls() map[string]string {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
 type GetPresignedUploadURLRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
@@ -729,7 +913,7 @@ type GetPresignedUploadURLRequest struct {
 
 func (x *GetPresignedUploadURLRequest) Reset() {
 	*x = GetPresignedUploadURLRequest{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[14]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -741,7 +925,7 @@ func (x *GetPresignedUploadURLRequest) String() string {
 func (*GetPresignedUploadURLRequest) ProtoMessage() {}
 
 func (x *GetPresignedUploadURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[14]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -754,7 +938,7 @@ func (x *GetPresignedUploadURLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPresignedUploadURLRequest.ProtoReflect.Descriptor instead.
 func (*GetPresignedUploadURLRequest) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{14}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetPresignedUploadURLRequest) GetFilename() string {
@@ -797,7 +981,7 @@ type GetPresignedUploadURLResponse struct {
 
 func (x *GetPresignedUploadURLResponse) Reset() {
 	*x = GetPresignedUploadURLResponse{}
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[15]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -809,7 +993,7 @@ func (x *GetPresignedUploadURLResponse) String() string {
 func (*GetPresignedUploadURLResponse) ProtoMessage() {}
 
 func (x *GetPresignedUploadURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_entity_proto_storage_v1_storage_proto_msgTypes[15]
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -822,7 +1006,7 @@ func (x *GetPresignedUploadURLResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPresignedUploadURLResponse.ProtoReflect.Descriptor instead.
 func (*GetPresignedUploadURLResponse) Descriptor() ([]byte, []int) {
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP(), []int{15}
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GetPresignedUploadURLResponse) GetUploadUrl() string {
@@ -853,11 +1037,527 @@ func (x *GetPresignedUploadURLResponse) GetIsDuplicate() bool {
 	return false
 }
 
-var File_shared_entity_proto_storage_v1_storage_proto protoreflect.FileDescriptor
+type InitiateUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	TotalSize     int64                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitiateUploadRequest) Reset() {
+	*x = InitiateUploadRequest{}
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitiateUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitiateUploadRequest) ProtoMessage() {}
+
+func (x *InitiateUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitiateUploadRequest.ProtoReflect.Descriptor instead.
+func (*InitiateUploadRequest) Descriptor() ([]byte, []int) {
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *InitiateUploadRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *InitiateUploadRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *InitiateUploadRequest) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type InitiateUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	PartSize      int64                  `protobuf:"varint,3,opt,name=part_size,json=partSize,proto3" json:"part_size,omitempty"` // Every part but the last must be exactly this size
+	PartCount     int32                  `protobuf:"varint,4,opt,name=part_count,json=partCount,proto3" json:"part_count,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix seconds; extended by every uploaded part
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitiateUploadResponse) Reset() {
+	*x = InitiateUploadResponse{}
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitiateUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitiateUploadResponse) ProtoMessage() {}
+
+func (x *InitiateUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitiateUploadResponse.ProtoReflect.Descriptor instead.
+func (*InitiateUploadResponse) Descriptor() ([]byte, []int) {
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *InitiateUploadResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *InitiateUploadResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *InitiateUploadResponse) GetPartSize() int64 {
+	if x != nil {
+		return x.PartSize
+	}
+	return 0
+}
+
+func (x *InitiateUploadResponse) GetPartCount() int32 {
+	if x != nil {
+		return x.PartCount
+	}
+	return 0
+}
+
+func (x *InitiateUploadResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type UploadPartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	PartNumber    int32                  `protobuf:"varint,2,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"` // 1-based
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadPartRequest) Reset() {
+	*x = UploadPartRequest{}
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadPartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadPartRequest) ProtoMessage() {}
+
+func (x *UploadPartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadPartRequest.ProtoReflect.Descriptor instead.
+func (*UploadPartRequest) Descriptor() ([]byte, []int) {
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UploadPartRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadPartRequest) GetPartNumber() int32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *UploadPartRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UploadPartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartNumber    int32                  `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadPartResponse) Reset() {
+	*x = UploadPartResponse{}
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadPartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadPartResponse) ProtoMessage() {}
+
+func (x *UploadPartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadPartResponse.ProtoReflect.Descriptor instead.
+func (*UploadPartResponse) Descriptor() ([]byte, []int) {
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UploadPartResponse) GetPartNumber() int32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *UploadPartResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *UploadPartResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type GetUploadStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadStatusRequest) Reset() {
+	*x = GetUploadStatusRequest{}
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadStatusRequest) ProtoMessage() {}
+
+func (x *GetUploadStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetUploadStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetUploadStatusRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type GetUploadStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	PartSize      int64                  `protobuf:"varint,3,opt,name=part_size,json=partSize,proto3" json:"part_size,omitempty"`
+	PartCount     int32                  `protobuf:"varint,4,opt,name=part_count,json=partCount,proto3" json:"part_count,omitempty"`
+	UploadedParts []int32                `protobuf:"varint,5,rep,packed,name=uploaded_parts,json=uploadedParts,proto3" json:"uploaded_parts,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadStatusResponse) Reset() {
+	*x = GetUploadStatusResponse{}
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadStatusResponse) ProtoMessage() {}
+
+func (x *GetUploadStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetUploadStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetUploadStatusResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *GetUploadStatusResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetUploadStatusResponse) GetPartSize() int64 {
+	if x != nil {
+		return x.PartSize
+	}
+	return 0
+}
+
+func (x *GetUploadStatusResponse) GetPartCount() int32 {
+	if x != nil {
+		return x.PartCount
+	}
+	return 0
+}
+
+func (x *GetUploadStatusResponse) GetUploadedParts() []int32 {
+	if x != nil {
+		return x.UploadedParts
+	}
+	return nil
+}
+
+func (x *GetUploadStatusResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type CompleteUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteUploadRequest) Reset() {
+	*x = CompleteUploadRequest{}
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteUploadRequest) ProtoMessage() {}
+
+func (x *CompleteUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteUploadRequest.ProtoReflect.Descriptor instead.
+func (*CompleteUploadRequest) Descriptor() ([]byte, []int) {
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CompleteUploadRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type AbortUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortUploadRequest) Reset() {
+	*x = AbortUploadRequest{}
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortUploadRequest) ProtoMessage() {}
+
+func (x *AbortUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortUploadRequest.ProtoReflect.Descriptor instead.
+func (*AbortUploadRequest) Descriptor() ([]byte, []int) {
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AbortUploadRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type AbortUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortUploadResponse) Reset() {
+	*x = AbortUploadResponse{}
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortUploadResponse) ProtoMessage() {}
+
+func (x *AbortUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_storage_v1_storage_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortUploadResponse.ProtoReflect.Descriptor instead.
+func (*AbortUploadResponse) Descriptor() ([]byte, []int) {
+	return file_proto_storage_v1_storage_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AbortUploadResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_proto_storage_v1_storage_proto protoreflect.FileDescriptor
 
-const file_shared_entity_proto_storage_v1_storage_proto_rawDesc = "" +
+const file_proto_storage_v1_storage_proto_rawDesc = "" +
 	"\n" +
-	",shared-entity/proto/storage/v1/storage.proto\x12\n" +
+	"\x1eproto/storage/v1/storage.proto\x12\n" +
 	"storage.v1\"b\n" +
 	"\rUploadRequest\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1a\n" +
@@ -894,12 +1594,25 @@ const file_shared_entity_proto_storage_v1_storage_proto_rawDesc = "" +
 	"\vobject_path\x18\x01 \x01(\tR\n" +
 	"objectPath\"-\n" +
 	"\x17DownloadArchiveResponse\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\fR\x04data\"Q\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"7\n" +
+	"\x14DeleteArchiveRequest\x12\x1f\n" +
+	"\vobject_path\x18\x01 \x01(\tR\n" +
+	"objectPath\"1\n" +
+	"\x15DeleteArchiveResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"Q\n" +
 	"\x16GetPresignedURLRequest\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
 	"\x0eexpiry_seconds\x18\x02 \x01(\x03R\rexpirySeconds\"+\n" +
 	"\x17GetPresignedURLResponse\x12\x10\n" +
-	"\x03url\x18\x01 \x01(\tR\x03url\"\xa5\x01\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\"Y\n" +
+	"\x1cBatchGetPresignedURLsRequest\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\x12%\n" +
+	"\x0eexpiry_seconds\x18\x02 \x01(\x03R\rexpirySeconds\"\xa1\x01\n" +
+	"\x1dBatchGetPresignedURLsResponse\x12G\n" +
+	"\x04urls\x18\x01 \x03(\v23.storage.v1.BatchGetPresignedURLsResponse.UrlsEntryR\x04urls\x1a7\n" +
+	"\tUrlsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa5\x01\n" +
 	"\x1cGetPresignedUploadURLRequest\x12\x1a\n" +
 	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
 	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12%\n" +
@@ -911,31 +1624,80 @@ const file_shared_entity_proto_storage_v1_storage_proto_rawDesc = "" +
 	"upload_url\x18\x01 \x01(\tR\tuploadUrl\x12\x19\n" +
 	"\bfile_url\x18\x02 \x01(\tR\afileUrl\x12\x10\n" +
 	"\x03key\x18\x03 \x01(\tR\x03key\x12!\n" +
-	"\fis_duplicate\x18\x04 \x01(\bR\visDuplicate2\xb2\x05\n" +
+	"\fis_duplicate\x18\x04 \x01(\bR\visDuplicate\"u\n" +
+	"\x15InitiateUploadRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x03R\ttotalSize\"\xa2\x01\n" +
+	"\x16InitiateUploadResponse\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x1b\n" +
+	"\tpart_size\x18\x03 \x01(\x03R\bpartSize\x12\x1d\n" +
+	"\n" +
+	"part_count\x18\x04 \x01(\x05R\tpartCount\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\x03R\texpiresAt\"e\n" +
+	"\x11UploadPartRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x1f\n" +
+	"\vpart_number\x18\x02 \x01(\x05R\n" +
+	"partNumber\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"]\n" +
+	"\x12UploadPartResponse\x12\x1f\n" +
+	"\vpart_number\x18\x01 \x01(\x05R\n" +
+	"partNumber\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\"5\n" +
+	"\x16GetUploadStatusRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\"\xca\x01\n" +
+	"\x17GetUploadStatusResponse\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x1b\n" +
+	"\tpart_size\x18\x03 \x01(\x03R\bpartSize\x12\x1d\n" +
+	"\n" +
+	"part_count\x18\x04 \x01(\x05R\tpartCount\x12%\n" +
+	"\x0euploaded_parts\x18\x05 \x03(\x05R\ruploadedParts\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03R\texpiresAt\"4\n" +
+	"\x15CompleteUploadRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\"1\n" +
+	"\x12AbortUploadRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\"/\n" +
+	"\x13AbortUploadResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\x99\n" +
+	"\n" +
 	"\x0eStorageService\x12?\n" +
 	"\x06Upload\x12\x19.storage.v1.UploadRequest\x1a\x1a.storage.v1.UploadResponse\x12W\n" +
 	"\x0eUploadMultiple\x12!.storage.v1.UploadMultipleRequest\x1a\".storage.v1.UploadMultipleResponse\x12?\n" +
 	"\x06Delete\x12\x19.storage.v1.DeleteRequest\x1a\x1a.storage.v1.DeleteResponse\x12I\n" +
 	"\vDeleteByURL\x12\x1e.storage.v1.DeleteByURLRequest\x1a\x1a.storage.v1.DeleteResponse\x12T\n" +
 	"\rUploadArchive\x12 .storage.v1.UploadArchiveRequest\x1a!.storage.v1.UploadArchiveResponse\x12Z\n" +
-	"\x0fDownloadArchive\x12\".storage.v1.DownloadArchiveRequest\x1a#.storage.v1.DownloadArchiveResponse\x12Z\n" +
+	"\x0fDownloadArchive\x12\".storage.v1.DownloadArchiveRequest\x1a#.storage.v1.DownloadArchiveResponse\x12T\n" +
+	"\rDeleteArchive\x12 .storage.v1.DeleteArchiveRequest\x1a!.storage.v1.DeleteArchiveResponse\x12Z\n" +
 	"\x0fGetPresignedURL\x12\".storage.v1.GetPresignedURLRequest\x1a#.storage.v1.GetPresignedURLResponse\x12l\n" +
-	"\x15GetPresignedUploadURL\x12(.storage.v1.GetPresignedUploadURLRequest\x1a).storage.v1.GetPresignedUploadURLResponseBNZLgithub.com/MuhibNayem/connectify-v2/shared-entity/proto/storage/v1;storagev1b\x06proto3"
+	"\x15GetPresignedUploadURL\x12(.storage.v1.GetPresignedUploadURLRequest\x1a).storage.v1.GetPresignedUploadURLResponse\x12l\n" +
+	"\x15BatchGetPresignedURLs\x12(.storage.v1.BatchGetPresignedURLsRequest\x1a).storage.v1.BatchGetPresignedURLsResponse\x12W\n" +
+	"\x0eInitiateUpload\x12!.storage.v1.InitiateUploadRequest\x1a\".storage.v1.InitiateUploadResponse\x12K\n" +
+	"\n" +
+	"UploadPart\x12\x1d.storage.v1.UploadPartRequest\x1a\x1e.storage.v1.UploadPartResponse\x12Z\n" +
+	"\x0fGetUploadStatus\x12\".storage.v1.GetUploadStatusRequest\x1a#.storage.v1.GetUploadStatusResponse\x12O\n" +
+	"\x0eCompleteUpload\x12!.storage.v1.CompleteUploadRequest\x1a\x1a.storage.v1.UploadResponse\x12N\n" +
+	"\vAbortUpload\x12\x1e.storage.v1.AbortUploadRequest\x1a\x1f.storage.v1.AbortUploadResponseBNZLgithub.com/MuhibNayem/connectify-v2/shared-entity/proto/storage/v1;storagev1b\x06proto3"
 
 var (
-	file_shared_entity_proto_storage_v1_storage_proto_rawDescOnce sync.Once
-	file_shared_entity_proto_storage_v1_storage_proto_rawDescData []byte
+	file_proto_storage_v1_storage_proto_rawDescOnce sync.Once
+	file_proto_storage_v1_storage_proto_rawDescData []byte
 )
 
-func file_shared_entity_proto_storage_v1_storage_proto_rawDescGZIP() []byte {
-	file_shared_entity_proto_storage_v1_storage_proto_rawDescOnce.Do(func() {
-		file_shared_entity_proto_storage_v1_storage_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shared_entity_proto_storage_v1_storage_proto_rawDesc), len(file_shared_entity_proto_storage_v1_storage_proto_rawDesc)))
+func file_proto_storage_v1_storage_proto_rawDescGZIP() []byte {
+	file_proto_storage_v1_storage_proto_rawDescOnce.Do(func() {
+		file_proto_storage_v1_storage_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_storage_v1_storage_proto_rawDesc), len(file_proto_storage_v1_storage_proto_rawDesc)))
 	})
-	return file_shared_entity_proto_storage_v1_storage_proto_rawDescData
+	return file_proto_storage_v1_storage_proto_rawDescData
 }
 
-var file_shared_entity_proto_storage_v1_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
-var file_shared_entity_proto_storage_v1_storage_proto_goTypes = []any{
+var file_proto_storage_v1_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_proto_storage_v1_storage_proto_goTypes = []any{
 	(*UploadRequest)(nil),                 // 0: storage.v1.UploadRequest
 	(*UploadResponse)(nil),                // 1: storage.v1.UploadResponse
 	(*UploadMultipleRequest)(nil),         // 2: storage.v1.UploadMultipleRequest
@@ -948,57 +1710,86 @@ var file_shared_entity_proto_storage_v1_storage_proto_goTypes = []any{
 	(*UploadArchiveResponse)(nil),         // 9: storage.v1.UploadArchiveResponse
 	(*DownloadArchiveRequest)(nil),        // 10: storage.v1.DownloadArchiveRequest
 	(*DownloadArchiveResponse)(nil),       // 11: storage.v1.DownloadArchiveResponse
-	(*GetPresignedURLRequest)(nil),        // 12: storage.v1.GetPresignedURLRequest
-	(*GetPresignedURLResponse)(nil),       // 13: storage.v1.GetPresignedURLResponse
-	(*GetPresignedUploadURLRequest)(nil),  // 14: storage.v1.GetPresignedUploadURLRequest
-	(*GetPresignedUploadURLResponse)(nil), // 15: storage.v1.GetPresignedUploadURLResponse
-}
-var file_shared_entity_proto_storage_v1_storage_proto_depIdxs = []int32{
+	(*DeleteArchiveRequest)(nil),          // 12: storage.v1.DeleteArchiveRequest
+	(*DeleteArchiveResponse)(nil),         // 13: storage.v1.DeleteArchiveResponse
+	(*GetPresignedURLRequest)(nil),        // 14: storage.v1.GetPresignedURLRequest
+	(*GetPresignedURLResponse)(nil),       // 15: storage.v1.GetPresignedURLResponse
+	(*BatchGetPresignedURLsRequest)(nil),  // 16: storage.v1.BatchGetPresignedURLsRequest
+	(*BatchGetPresignedURLsResponse)(nil), // 17: storage.v1.BatchGetPresignedURLsResponse
+	(*GetPresignedUploadURLRequest)(nil),  // 18: storage.v1.GetPresignedUploadURLRequest
+	(*GetPresignedUploadURLResponse)(nil), // 19: storage.v1.GetPresignedUploadURLResponse
+	(*InitiateUploadRequest)(nil),         // 20: storage.v1.InitiateUploadRequest
+	(*InitiateUploadResponse)(nil),        // 21: storage.v1.InitiateUploadResponse
+	(*UploadPartRequest)(nil),             // 22: storage.v1.UploadPartRequest
+	(*UploadPartResponse)(nil),            // 23: storage.v1.UploadPartResponse
+	(*GetUploadStatusRequest)(nil),        // 24: storage.v1.GetUploadStatusRequest
+	(*GetUploadStatusResponse)(nil),       // 25: storage.v1.GetUploadStatusResponse
+	(*CompleteUploadRequest)(nil),         // 26: storage.v1.CompleteUploadRequest
+	(*AbortUploadRequest)(nil),            // 27: storage.v1.AbortUploadRequest
+	(*AbortUploadResponse)(nil),           // 28: storage.v1.AbortUploadResponse
+	nil,                                   // 29: storage.v1.BatchGetPresignedURLsResponse.UrlsEntry
+}
+var file_proto_storage_v1_storage_proto_depIdxs = []int32{
 	3,  // 0: storage.v1.UploadMultipleRequest.files:type_name -> storage.v1.FileUpload
 	1,  // 1: storage.v1.UploadMultipleResponse.results:type_name -> storage.v1.UploadResponse
-	0,  // 2: storage.v1.StorageService.Upload:input_type -> storage.v1.UploadRequest
-	2,  // 3: storage.v1.StorageService.UploadMultiple:input_type -> storage.v1.UploadMultipleRequest
-	5,  // 4: storage.v1.StorageService.Delete:input_type -> storage.v1.DeleteRequest
-	6,  // 5: storage.v1.StorageService.DeleteByURL:input_type -> storage.v1.DeleteByURLRequest
-	8,  // 6: storage.v1.StorageService.UploadArchive:input_type -> storage.v1.UploadArchiveRequest
-	10, // 7: storage.v1.StorageService.DownloadArchive:input_type -> storage.v1.DownloadArchiveRequest
-	12, // 8: storage.v1.StorageService.GetPresignedURL:input_type -> storage.v1.GetPresignedURLRequest
-	14, // 9: storage.v1.StorageService.GetPresignedUploadURL:input_type -> storage.v1.GetPresignedUploadURLRequest
-	1,  // 10: storage.v1.StorageService.Upload:output_type -> storage.v1.UploadResponse
-	4,  // 11: storage.v1.StorageService.UploadMultiple:output_type -> storage.v1.UploadMultipleResponse
-	7,  // 12: storage.v1.StorageService.Delete:output_type -> storage.v1.DeleteResponse
-	7,  // 13: storage.v1.StorageService.DeleteByURL:output_type -> storage.v1.DeleteResponse
-	9,  // 14: storage.v1.StorageService.UploadArchive:output_type -> storage.v1.UploadArchiveResponse
-	11, // 15: storage.v1.StorageService.DownloadArchive:output_type -> storage.v1.DownloadArchiveResponse
-	13, // 16: storage.v1.StorageService.GetPresignedURL:output_type -> storage.v1.GetPresignedURLResponse
-	15, // 17: storage.v1.StorageService.GetPresignedUploadURL:output_type -> storage.v1.GetPresignedUploadURLResponse
-	10, // [10:18] is the sub-list for method output_type
-	2,  // [2:10] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
-}
-
-func init() { file_shared_entity_proto_storage_v1_storage_proto_init() }
-func file_shared_entity_proto_storage_v1_storage_proto_init() {
-	if File_shared_entity_proto_storage_v1_storage_proto != nil {
+	29, // 2: storage.v1.BatchGetPresignedURLsResponse.urls:type_name -> storage.v1.BatchGetPresignedURLsResponse.UrlsEntry
+	0,  // 3: storage.v1.StorageService.Upload:input_type -> storage.v1.UploadRequest
+	2,  // 4: storage.v1.StorageService.UploadMultiple:input_type -> storage.v1.UploadMultipleRequest
+	5,  // 5: storage.v1.StorageService.Delete:input_type -> storage.v1.DeleteRequest
+	6,  // 6: storage.v1.StorageService.DeleteByURL:input_type -> storage.v1.DeleteByURLRequest
+	8,  // 7: storage.v1.StorageService.UploadArchive:input_type -> storage.v1.UploadArchiveRequest
+	10, // 8: storage.v1.StorageService.DownloadArchive:input_type -> storage.v1.DownloadArchiveRequest
+	12, // 9: storage.v1.StorageService.DeleteArchive:input_type -> storage.v1.DeleteArchiveRequest
+	14, // 10: storage.v1.StorageService.GetPresignedURL:input_type -> storage.v1.GetPresignedURLRequest
+	18, // 11: storage.v1.StorageService.GetPresignedUploadURL:input_type -> storage.v1.GetPresignedUploadURLRequest
+	16, // 12: storage.v1.StorageService.BatchGetPresignedURLs:input_type -> storage.v1.BatchGetPresignedURLsRequest
+	20, // 13: storage.v1.StorageService.InitiateUpload:input_type -> storage.v1.InitiateUploadRequest
+	22, // 14: storage.v1.StorageService.UploadPart:input_type -> storage.v1.UploadPartRequest
+	24, // 15: storage.v1.StorageService.GetUploadStatus:input_type -> storage.v1.GetUploadStatusRequest
+	26, // 16: storage.v1.StorageService.CompleteUpload:input_type -> storage.v1.CompleteUploadRequest
+	27, // 17: storage.v1.StorageService.AbortUpload:input_type -> storage.v1.AbortUploadRequest
+	1,  // 18: storage.v1.StorageService.Upload:output_type -> storage.v1.UploadResponse
+	4,  // 19: storage.v1.StorageService.UploadMultiple:output_type -> storage.v1.UploadMultipleResponse
+	7,  // 20: storage.v1.StorageService.Delete:output_type -> storage.v1.DeleteResponse
+	7,  // 21: storage.v1.StorageService.DeleteByURL:output_type -> storage.v1.DeleteResponse
+	9,  // 22: storage.v1.StorageService.UploadArchive:output_type -> storage.v1.UploadArchiveResponse
+	11, // 23: storage.v1.StorageService.DownloadArchive:output_type -> storage.v1.DownloadArchiveResponse
+	13, // 24: storage.v1.StorageService.DeleteArchive:output_type -> storage.v1.DeleteArchiveResponse
+	15, // 25: storage.v1.StorageService.GetPresignedURL:output_type -> storage.v1.GetPresignedURLResponse
+	19, // 26: storage.v1.StorageService.GetPresignedUploadURL:output_type -> storage.v1.GetPresignedUploadURLResponse
+	17, // 27: storage.v1.StorageService.BatchGetPresignedURLs:output_type -> storage.v1.BatchGetPresignedURLsResponse
+	21, // 28: storage.v1.StorageService.InitiateUpload:output_type -> storage.v1.InitiateUploadResponse
+	23, // 29: storage.v1.StorageService.UploadPart:output_type -> storage.v1.UploadPartResponse
+	25, // 30: storage.v1.StorageService.GetUploadStatus:output_type -> storage.v1.GetUploadStatusResponse
+	1,  // 31: storage.v1.StorageService.CompleteUpload:output_type -> storage.v1.UploadResponse
+	28, // 32: storage.v1.StorageService.AbortUpload:output_type -> storage.v1.AbortUploadResponse
+	18, // [18:33] is the sub-list for method output_type
+	3,  // [3:18] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_storage_v1_storage_proto_init() }
+func file_proto_storage_v1_storage_proto_init() {
+	if File_proto_storage_v1_storage_proto != nil {
 		return
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_entity_proto_storage_v1_storage_proto_rawDesc), len(file_shared_entity_proto_storage_v1_storage_proto_rawDesc)),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_storage_v1_storage_proto_rawDesc), len(file_proto_storage_v1_storage_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
-		GoTypes:           file_shared_entity_proto_storage_v1_storage_proto_goTypes,
-		DependencyIndexes: file_shared_entity_proto_storage_v1_storage_proto_depIdxs,
-		MessageInfos:      file_shared_entity_proto_storage_v1_storage_proto_msgTypes,
+		GoTypes:           file_proto_storage_v1_storage_proto_goTypes,
+		DependencyIndexes: file_proto_storage_v1_storage_proto_depIdxs,
+		MessageInfos:      file_proto_storage_v1_storage_proto_msgTypes,
 	}.Build()
-	File_shared_entity_proto_storage_v1_storage_proto = out.File
-	file_shared_entity_proto_storage_v1_storage_proto_goTypes = nil
-	file_shared_entity_proto_storage_v1_storage_proto_depIdxs = nil
+	File_proto_storage_v1_storage_proto = out.File
+	file_proto_storage_v1_storage_proto_goTypes = nil
+	file_proto_storage_v1_storage_proto_depIdxs = nil
 }
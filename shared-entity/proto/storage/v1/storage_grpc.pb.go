@@ -2,7 +2,7 @@
 // versions:
 // - protoc-gen-go-grpc v1.6.0
 // - protoc             v6.33.2
-// source: shared-entity/proto/storage/v1/storage.proto
+// source: proto/storage/v1/storage.proto
 
 package storagev1
 
@@ -25,8 +25,15 @@ const (
 	StorageService_DeleteByURL_FullMethodName           = "/storage.v1.StorageService/DeleteByURL"
 	StorageService_UploadArchive_FullMethodName         = "/storage.v1.StorageService/UploadArchive"
 	StorageService_DownloadArchive_FullMethodName       = "/storage.v1.StorageService/DownloadArchive"
+	StorageService_DeleteArchive_FullMethodName         = "/storage.v1.StorageService/DeleteArchive"
 	StorageService_GetPresignedURL_FullMethodName       = "/storage.v1.StorageService/GetPresignedURL"
 	StorageService_GetPresignedUploadURL_FullMethodName = "/storage.v1.StorageService/GetPresignedUploadURL"
+	StorageService_BatchGetPresignedURLs_FullMethodName = "/storage.v1.StorageService/BatchGetPresignedURLs"
+	StorageService_InitiateUpload_FullMethodName        = "/storage.v1.StorageService/InitiateUpload"
+	StorageService_UploadPart_FullMethodName            = "/storage.v1.StorageService/UploadPart"
+	StorageService_GetUploadStatus_FullMethodName       = "/storage.v1.StorageService/GetUploadStatus"
+	StorageService_CompleteUpload_FullMethodName        = "/storage.v1.StorageService/CompleteUpload"
+	StorageService_AbortUpload_FullMethodName           = "/storage.v1.StorageService/AbortUpload"
 )
 
 // StorageServiceClient is the client API for StorageService service.
@@ -39,8 +46,17 @@ type StorageServiceClient interface {
 	DeleteByURL(ctx context.Context, in *DeleteByURLRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 	UploadArchive(ctx context.Context, in *UploadArchiveRequest, opts ...grpc.CallOption) (*UploadArchiveResponse, error)
 	DownloadArchive(ctx context.Context, in *DownloadArchiveRequest, opts ...grpc.CallOption) (*DownloadArchiveResponse, error)
+	DeleteArchive(ctx context.Context, in *DeleteArchiveRequest, opts ...grpc.CallOption) (*DeleteArchiveResponse, error)
 	GetPresignedURL(ctx context.Context, in *GetPresignedURLRequest, opts ...grpc.CallOption) (*GetPresignedURLResponse, error)
 	GetPresignedUploadURL(ctx context.Context, in *GetPresignedUploadURLRequest, opts ...grpc.CallOption) (*GetPresignedUploadURLResponse, error)
+	BatchGetPresignedURLs(ctx context.Context, in *BatchGetPresignedURLsRequest, opts ...grpc.CallOption) (*BatchGetPresignedURLsResponse, error)
+	// Resumable uploads: parts may be sent in any order and retried until the
+	// upload is completed, aborted, or expires unused.
+	InitiateUpload(ctx context.Context, in *InitiateUploadRequest, opts ...grpc.CallOption) (*InitiateUploadResponse, error)
+	UploadPart(ctx context.Context, in *UploadPartRequest, opts ...grpc.CallOption) (*UploadPartResponse, error)
+	GetUploadStatus(ctx context.Context, in *GetUploadStatusRequest, opts ...grpc.CallOption) (*GetUploadStatusResponse, error)
+	CompleteUpload(ctx context.Context, in *CompleteUploadRequest, opts ...grpc.CallOption) (*UploadResponse, error)
+	AbortUpload(ctx context.Context, in *AbortUploadRequest, opts ...grpc.CallOption) (*AbortUploadResponse, error)
 }
 
 type storageServiceClient struct {
@@ -111,6 +127,16 @@ func (c *storageServiceClient) DownloadArchive(ctx context.Context, in *Download
 	return out, nil
 }
 
+func (c *storageServiceClient) DeleteArchive(ctx context.Context, in *DeleteArchiveRequest, opts ...grpc.CallOption) (*DeleteArchiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteArchiveResponse)
+	err := c.cc.Invoke(ctx, StorageService_DeleteArchive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storageServiceClient) GetPresignedURL(ctx context.Context, in *GetPresignedURLRequest, opts ...grpc.CallOption) (*GetPresignedURLResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetPresignedURLResponse)
@@ -131,6 +157,66 @@ func (c *storageServiceClient) GetPresignedUploadURL(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *storageServiceClient) BatchGetPresignedURLs(ctx context.Context, in *BatchGetPresignedURLsRequest, opts ...grpc.CallOption) (*BatchGetPresignedURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetPresignedURLsResponse)
+	err := c.cc.Invoke(ctx, StorageService_BatchGetPresignedURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) InitiateUpload(ctx context.Context, in *InitiateUploadRequest, opts ...grpc.CallOption) (*InitiateUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InitiateUploadResponse)
+	err := c.cc.Invoke(ctx, StorageService_InitiateUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) UploadPart(ctx context.Context, in *UploadPartRequest, opts ...grpc.CallOption) (*UploadPartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadPartResponse)
+	err := c.cc.Invoke(ctx, StorageService_UploadPart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) GetUploadStatus(ctx context.Context, in *GetUploadStatusRequest, opts ...grpc.CallOption) (*GetUploadStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUploadStatusResponse)
+	err := c.cc.Invoke(ctx, StorageService_GetUploadStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) CompleteUpload(ctx context.Context, in *CompleteUploadRequest, opts ...grpc.CallOption) (*UploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadResponse)
+	err := c.cc.Invoke(ctx, StorageService_CompleteUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) AbortUpload(ctx context.Context, in *AbortUploadRequest, opts ...grpc.CallOption) (*AbortUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AbortUploadResponse)
+	err := c.cc.Invoke(ctx, StorageService_AbortUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StorageServiceServer is the server API for StorageService service.
 // All implementations must embed UnimplementedStorageServiceServer
 // for forward compatibility.
@@ -141,8 +227,17 @@ type StorageServiceServer interface {
 	DeleteByURL(context.Context, *DeleteByURLRequest) (*DeleteResponse, error)
 	UploadArchive(context.Context, *UploadArchiveRequest) (*UploadArchiveResponse, error)
 	DownloadArchive(context.Context, *DownloadArchiveRequest) (*DownloadArchiveResponse, error)
+	DeleteArchive(context.Context, *DeleteArchiveRequest) (*DeleteArchiveResponse, error)
 	GetPresignedURL(context.Context, *GetPresignedURLRequest) (*GetPresignedURLResponse, error)
 	GetPresignedUploadURL(context.Context, *GetPresignedUploadURLRequest) (*GetPresignedUploadURLResponse, error)
+	BatchGetPresignedURLs(context.Context, *BatchGetPresignedURLsRequest) (*BatchGetPresignedURLsResponse, error)
+	// Resumable uploads: parts may be sent in any order and retried until the
+	// upload is completed, aborted, or expires unused.
+	InitiateUpload(context.Context, *InitiateUploadRequest) (*InitiateUploadResponse, error)
+	UploadPart(context.Context, *UploadPartRequest) (*UploadPartResponse, error)
+	GetUploadStatus(context.Context, *GetUploadStatusRequest) (*GetUploadStatusResponse, error)
+	CompleteUpload(context.Context, *CompleteUploadRequest) (*UploadResponse, error)
+	AbortUpload(context.Context, *AbortUploadRequest) (*AbortUploadResponse, error)
 	mustEmbedUnimplementedStorageServiceServer()
 }
 
@@ -171,12 +266,33 @@ func (UnimplementedStorageServiceServer) UploadArchive(context.Context, *UploadA
 func (UnimplementedStorageServiceServer) DownloadArchive(context.Context, *DownloadArchiveRequest) (*DownloadArchiveResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DownloadArchive not implemented")
 }
+func (UnimplementedStorageServiceServer) DeleteArchive(context.Context, *DeleteArchiveRequest) (*DeleteArchiveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteArchive not implemented")
+}
 func (UnimplementedStorageServiceServer) GetPresignedURL(context.Context, *GetPresignedURLRequest) (*GetPresignedURLResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPresignedURL not implemented")
 }
 func (UnimplementedStorageServiceServer) GetPresignedUploadURL(context.Context, *GetPresignedUploadURLRequest) (*GetPresignedUploadURLResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPresignedUploadURL not implemented")
 }
+func (UnimplementedStorageServiceServer) BatchGetPresignedURLs(context.Context, *BatchGetPresignedURLsRequest) (*BatchGetPresignedURLsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchGetPresignedURLs not implemented")
+}
+func (UnimplementedStorageServiceServer) InitiateUpload(context.Context, *InitiateUploadRequest) (*InitiateUploadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InitiateUpload not implemented")
+}
+func (UnimplementedStorageServiceServer) UploadPart(context.Context, *UploadPartRequest) (*UploadPartResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UploadPart not implemented")
+}
+func (UnimplementedStorageServiceServer) GetUploadStatus(context.Context, *GetUploadStatusRequest) (*GetUploadStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUploadStatus not implemented")
+}
+func (UnimplementedStorageServiceServer) CompleteUpload(context.Context, *CompleteUploadRequest) (*UploadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteUpload not implemented")
+}
+func (UnimplementedStorageServiceServer) AbortUpload(context.Context, *AbortUploadRequest) (*AbortUploadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AbortUpload not implemented")
+}
 func (UnimplementedStorageServiceServer) mustEmbedUnimplementedStorageServiceServer() {}
 func (UnimplementedStorageServiceServer) testEmbeddedByValue()                        {}
 
@@ -306,6 +422,24 @@ func _StorageService_DownloadArchive_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageService_DeleteArchive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteArchiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).DeleteArchive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_DeleteArchive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).DeleteArchive(ctx, req.(*DeleteArchiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StorageService_GetPresignedURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPresignedURLRequest)
 	if err := dec(in); err != nil {
@@ -342,6 +476,114 @@ func _StorageService_GetPresignedUploadURL_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageService_BatchGetPresignedURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetPresignedURLsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).BatchGetPresignedURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_BatchGetPresignedURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).BatchGetPresignedURLs(ctx, req.(*BatchGetPresignedURLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_InitiateUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitiateUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).InitiateUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_InitiateUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).InitiateUpload(ctx, req.(*InitiateUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_UploadPart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadPartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).UploadPart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_UploadPart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).UploadPart(ctx, req.(*UploadPartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_GetUploadStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUploadStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).GetUploadStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_GetUploadStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).GetUploadStatus(ctx, req.(*GetUploadStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_CompleteUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).CompleteUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_CompleteUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).CompleteUpload(ctx, req.(*CompleteUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_AbortUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).AbortUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_AbortUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).AbortUpload(ctx, req.(*AbortUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StorageService_ServiceDesc is the grpc.ServiceDesc for StorageService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -373,6 +615,10 @@ var StorageService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DownloadArchive",
 			Handler:    _StorageService_DownloadArchive_Handler,
 		},
+		{
+			MethodName: "DeleteArchive",
+			Handler:    _StorageService_DeleteArchive_Handler,
+		},
 		{
 			MethodName: "GetPresignedURL",
 			Handler:    _StorageService_GetPresignedURL_Handler,
@@ -381,7 +627,31 @@ var StorageService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPresignedUploadURL",
 			Handler:    _StorageService_GetPresignedUploadURL_Handler,
 		},
+		{
+			MethodName: "BatchGetPresignedURLs",
+			Handler:    _StorageService_BatchGetPresignedURLs_Handler,
+		},
+		{
+			MethodName: "InitiateUpload",
+			Handler:    _StorageService_InitiateUpload_Handler,
+		},
+		{
+			MethodName: "UploadPart",
+			Handler:    _StorageService_UploadPart_Handler,
+		},
+		{
+			MethodName: "GetUploadStatus",
+			Handler:    _StorageService_GetUploadStatus_Handler,
+		},
+		{
+			MethodName: "CompleteUpload",
+			Handler:    _StorageService_CompleteUpload_Handler,
+		},
+		{
+			MethodName: "AbortUpload",
+			Handler:    _StorageService_AbortUpload_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "shared-entity/proto/storage/v1/storage.proto",
+	Metadata: "proto/storage/v1/storage.proto",
 }
@@ -98,13 +98,14 @@ type Story struct {
 	Author         *Author                `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
 	MediaUrl       string                 `protobuf:"bytes,4,opt,name=media_url,json=mediaUrl,proto3" json:"media_url,omitempty"`
 	MediaType      string                 `protobuf:"bytes,5,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"` // "image" or "video"
-	Privacy        string                 `protobuf:"bytes,6,opt,name=privacy,proto3" json:"privacy,omitempty"`                      // "public", "friends", "friends_except", "custom"
+	Privacy        string                 `protobuf:"bytes,6,opt,name=privacy,proto3" json:"privacy,omitempty"`                      // "public", "friends", "friends_except", "custom", "close_friends"
 	AllowedViewers []string               `protobuf:"bytes,7,rep,name=allowed_viewers,json=allowedViewers,proto3" json:"allowed_viewers,omitempty"`
 	BlockedViewers []string               `protobuf:"bytes,8,rep,name=blocked_viewers,json=blockedViewers,proto3" json:"blocked_viewers,omitempty"`
 	ViewCount      int32                  `protobuf:"varint,9,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
 	ReactionCount  int32                  `protobuf:"varint,10,opt,name=reaction_count,json=reactionCount,proto3" json:"reaction_count,omitempty"`
 	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Overlays       []*StoryOverlay        `protobuf:"bytes,13,rep,name=overlays,proto3" json:"overlays,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -223,6 +224,230 @@ func (x *Story) GetExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Story) GetOverlays() []*StoryOverlay {
+	if x != nil {
+		return x.Overlays
+	}
+	return nil
+}
+
+// An element drawn over the media. Positions are fractions of the media's size.
+type StoryOverlay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "TEXT", "STICKER", "MENTION", "LINK", "MUSIC"
+	X             float64                `protobuf:"fixed64,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,3,opt,name=y,proto3" json:"y,omitempty"`
+	Scale         float64                `protobuf:"fixed64,4,opt,name=scale,proto3" json:"scale,omitempty"`
+	Rotation      float64                `protobuf:"fixed64,5,opt,name=rotation,proto3" json:"rotation,omitempty"` // Degrees clockwise
+	Text          string                 `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	Color         string                 `protobuf:"bytes,7,opt,name=color,proto3" json:"color,omitempty"` // #RRGGBB
+	Style         string                 `protobuf:"bytes,8,opt,name=style,proto3" json:"style,omitempty"`
+	StickerId     string                 `protobuf:"bytes,9,opt,name=sticker_id,json=stickerId,proto3" json:"sticker_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,10,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Mentioned user
+	Username      string                 `protobuf:"bytes,11,opt,name=username,proto3" json:"username,omitempty"`
+	Url           string                 `protobuf:"bytes,12,opt,name=url,proto3" json:"url,omitempty"`
+	Music         *StoryMusic            `protobuf:"bytes,13,opt,name=music,proto3" json:"music,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoryOverlay) Reset() {
+	*x = StoryOverlay{}
+	mi := &file_proto_story_v1_story_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoryOverlay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoryOverlay) ProtoMessage() {}
+
+func (x *StoryOverlay) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_story_v1_story_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoryOverlay.ProtoReflect.Descriptor instead.
+func (*StoryOverlay) Descriptor() ([]byte, []int) {
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StoryOverlay) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StoryOverlay) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *StoryOverlay) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *StoryOverlay) GetScale() float64 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+func (x *StoryOverlay) GetRotation() float64 {
+	if x != nil {
+		return x.Rotation
+	}
+	return 0
+}
+
+func (x *StoryOverlay) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *StoryOverlay) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *StoryOverlay) GetStyle() string {
+	if x != nil {
+		return x.Style
+	}
+	return ""
+}
+
+func (x *StoryOverlay) GetStickerId() string {
+	if x != nil {
+		return x.StickerId
+	}
+	return ""
+}
+
+func (x *StoryOverlay) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StoryOverlay) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *StoryOverlay) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *StoryOverlay) GetMusic() *StoryMusic {
+	if x != nil {
+		return x.Music
+	}
+	return nil
+}
+
+type StoryMusic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrackId       string                 `protobuf:"bytes,1,opt,name=track_id,json=trackId,proto3" json:"track_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Artist        string                 `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
+	StartMs       int32                  `protobuf:"varint,4,opt,name=start_ms,json=startMs,proto3" json:"start_ms,omitempty"`
+	DurationMs    int32                  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoryMusic) Reset() {
+	*x = StoryMusic{}
+	mi := &file_proto_story_v1_story_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoryMusic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoryMusic) ProtoMessage() {}
+
+func (x *StoryMusic) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_story_v1_story_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoryMusic.ProtoReflect.Descriptor instead.
+func (*StoryMusic) Descriptor() ([]byte, []int) {
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StoryMusic) GetTrackId() string {
+	if x != nil {
+		return x.TrackId
+	}
+	return ""
+}
+
+func (x *StoryMusic) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *StoryMusic) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *StoryMusic) GetStartMs() int32 {
+	if x != nil {
+		return x.StartMs
+	}
+	return 0
+}
+
+func (x *StoryMusic) GetDurationMs() int32 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
 type StoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Story         *Story                 `protobuf:"bytes,1,opt,name=story,proto3" json:"story,omitempty"`
@@ -232,7 +457,7 @@ type StoryResponse struct {
 
 func (x *StoryResponse) Reset() {
 	*x = StoryResponse{}
-	mi := &file_proto_story_v1_story_proto_msgTypes[2]
+	mi := &file_proto_story_v1_story_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -244,7 +469,7 @@ func (x *StoryResponse) String() string {
 func (*StoryResponse) ProtoMessage() {}
 
 func (x *StoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_story_v1_story_proto_msgTypes[2]
+	mi := &file_proto_story_v1_story_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -257,7 +482,7 @@ func (x *StoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StoryResponse.ProtoReflect.Descriptor instead.
 func (*StoryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{2}
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *StoryResponse) GetStory() *Story {
@@ -276,7 +501,7 @@ type StoriesResponse struct {
 
 func (x *StoriesResponse) Reset() {
 	*x = StoriesResponse{}
-	mi := &file_proto_story_v1_story_proto_msgTypes[3]
+	mi := &file_proto_story_v1_story_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -288,7 +513,7 @@ func (x *StoriesResponse) String() string {
 func (*StoriesResponse) ProtoMessage() {}
 
 func (x *StoriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_story_v1_story_proto_msgTypes[3]
+	mi := &file_proto_story_v1_story_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,7 +526,7 @@ func (x *StoriesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StoriesResponse.ProtoReflect.Descriptor instead.
 func (*StoriesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{3}
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *StoriesResponse) GetStories() []*Story {
@@ -319,13 +544,14 @@ type CreateStoryRequest struct {
 	Privacy        string                 `protobuf:"bytes,4,opt,name=privacy,proto3" json:"privacy,omitempty"`
 	AllowedViewers []string               `protobuf:"bytes,5,rep,name=allowed_viewers,json=allowedViewers,proto3" json:"allowed_viewers,omitempty"`
 	BlockedViewers []string               `protobuf:"bytes,6,rep,name=blocked_viewers,json=blockedViewers,proto3" json:"blocked_viewers,omitempty"`
+	Overlays       []*StoryOverlay        `protobuf:"bytes,7,rep,name=overlays,proto3" json:"overlays,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateStoryRequest) Reset() {
 	*x = CreateStoryRequest{}
-	mi := &file_proto_story_v1_story_proto_msgTypes[4]
+	mi := &file_proto_story_v1_story_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -337,7 +563,7 @@ func (x *CreateStoryRequest) String() string {
 func (*CreateStoryRequest) ProtoMessage() {}
 
 func (x *CreateStoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_story_v1_story_proto_msgTypes[4]
+	mi := &file_proto_story_v1_story_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +576,7 @@ func (x *CreateStoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateStoryRequest.ProtoReflect.Descriptor instead.
 func (*CreateStoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{4}
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CreateStoryRequest) GetUserId() string {
@@ -395,6 +621,13 @@ func (x *CreateStoryRequest) GetBlockedViewers() []string {
 	return nil
 }
 
+func (x *CreateStoryRequest) GetOverlays() []*StoryOverlay {
+	if x != nil {
+		return x.Overlays
+	}
+	return nil
+}
+
 type GetStoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StoryId       string                 `protobuf:"bytes,1,opt,name=story_id,json=storyId,proto3" json:"story_id,omitempty"`
@@ -405,7 +638,7 @@ type GetStoryRequest struct {
 
 func (x *GetStoryRequest) Reset() {
 	*x = GetStoryRequest{}
-	mi := &file_proto_story_v1_story_proto_msgTypes[5]
+	mi := &file_proto_story_v1_story_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -417,7 +650,7 @@ func (x *GetStoryRequest) String() string {
 func (*GetStoryRequest) ProtoMessage() {}
 
 func (x *GetStoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_story_v1_story_proto_msgTypes[5]
+	mi := &file_proto_story_v1_story_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -430,7 +663,7 @@ func (x *GetStoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetStoryRequest.ProtoReflect.Descriptor instead.
 func (*GetStoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{5}
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetStoryRequest) GetStoryId() string {
@@ -457,7 +690,7 @@ type DeleteStoryRequest struct {
 
 func (x *DeleteStoryRequest) Reset() {
 	*x = DeleteStoryRequest{}
-	mi := &file_proto_story_v1_story_proto_msgTypes[6]
+	mi := &file_proto_story_v1_story_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -469,7 +702,7 @@ func (x *DeleteStoryRequest) String() string {
 func (*DeleteStoryRequest) ProtoMessage() {}
 
 func (x *DeleteStoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_story_v1_story_proto_msgTypes[6]
+	mi := &file_proto_story_v1_story_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,7 +715,7 @@ func (x *DeleteStoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteStoryRequest.ProtoReflect.Descriptor instead.
 func (*DeleteStoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{6}
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DeleteStoryRequest) GetStoryId() string {
@@ -511,7 +744,7 @@ type GetStoriesFeedRequest struct {
 
 func (x *GetStoriesFeedRequest) Reset() {
 	*x = GetStoriesFeedRequest{}
-	mi := &file_proto_story_v1_story_proto_msgTypes[7]
+	mi := &file_proto_story_v1_story_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -523,7 +756,7 @@ func (x *GetStoriesFeedRequest) String() string {
 func (*GetStoriesFeedRequest) ProtoMessage() {}
 
 func (x *GetStoriesFeedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_story_v1_story_proto_msgTypes[7]
+	mi := &file_proto_story_v1_story_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -536,7 +769,7 @@ func (x *GetStoriesFeedRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetStoriesFeedRequest.ProtoReflect.Descriptor instead.
 func (*GetStoriesFeedRequest) Descriptor() ([]byte, []int) {
-	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{7}
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetStoriesFeedRequest) GetUserId() string {
@@ -577,7 +810,7 @@ type StoriesFeedResponse struct {
 
 func (x *StoriesFeedResponse) Reset() {
 	*x = StoriesFeedResponse{}
-	mi := &file_proto_story_v1_story_proto_msgTypes[8]
+	mi := &file_proto_story_v1_story_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -589,7 +822,7 @@ func (x *StoriesFeedResponse) String() string {
 func (*StoriesFeedResponse) ProtoMessage() {}
 
 func (x *StoriesFeedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_story_v1_story_proto_msgTypes[8]
+	mi := &file_proto_story_v1_story_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -602,7 +835,7 @@ func (x *StoriesFeedResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StoriesFeedResponse.ProtoReflect.Descriptor instead.
 func (*StoriesFeedResponse) Descriptor() ([]byte, []int) {
-	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{8}
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *StoriesFeedResponse) GetStories() []*Story {
@@ -629,7 +862,7 @@ type GetUserStoriesRequest struct {
 
 func (x *GetUserStoriesRequest) Reset() {
 	*x = GetUserStoriesRequest{}
-	mi := &file_proto_story_v1_story_proto_msgTypes[9]
+	mi := &file_proto_story_v1_story_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -641,7 +874,7 @@ func (x *GetUserStoriesRequest) String() string {
 func (*GetUserStoriesRequest) ProtoMessage() {}
 
 func (x *GetUserStoriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_story_v1_story_proto_msgTypes[9]
+	mi := &file_proto_story_v1_story_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -654,7 +887,7 @@ func (x *GetUserStoriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserStoriesRequest.ProtoReflect.Descriptor instead.
 func (*GetUserStoriesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{9}
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetUserStoriesRequest) GetUserId() string {
@@ -681,7 +914,7 @@ type RecordViewRequest struct {
 
 func (x *RecordViewRequest) Reset() {
 	*x = RecordViewRequest{}
-	mi := &file_proto_story_v1_story_proto_msgTypes[10]
+	mi := &file_proto_story_v1_story_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -693,7 +926,7 @@ func (x *RecordViewRequest) String() string {
 func (*RecordViewRequest) ProtoMessage() {}
 
 func (x *RecordViewRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_story_v1_story_proto_msgTypes[10]
+	mi := &file_proto_story_v1_story_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -706,7 +939,7 @@ func (x *RecordViewRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordViewRequest.ProtoReflect.Descriptor instead.
 func (*RecordViewRequest) Descriptor() ([]byte, []int) {
-	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{10}
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *RecordViewRequest) GetStoryId() string {
@@ -734,7 +967,7 @@ type ReactToStoryRequest struct {
 
 func (x *ReactToStoryRequest) Reset() {
 	*x = ReactToStoryRequest{}
-	mi := &file_proto_story_v1_story_proto_msgTypes[11]
+	mi := &file_proto_story_v1_story_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -746,7 +979,7 @@ func (x *ReactToStoryRequest) String() string {
 func (*ReactToStoryRequest) ProtoMessage() {}
 
 func (x *ReactToStoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_story_v1_story_proto_msgTypes[11]
+	mi := &file_proto_story_v1_story_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -759,7 +992,7 @@ func (x *ReactToStoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReactToStoryRequest.ProtoReflect.Descriptor instead.
 func (*ReactToStoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{11}
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ReactToStoryRequest) GetStoryId() string {
@@ -787,13 +1020,15 @@ type GetStoryViewersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StoryId       string                 `protobuf:"bytes,1,opt,name=story_id,json=storyId,proto3" json:"story_id,omitempty"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Must be story owner
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetStoryViewersRequest) Reset() {
 	*x = GetStoryViewersRequest{}
-	mi := &file_proto_story_v1_story_proto_msgTypes[12]
+	mi := &file_proto_story_v1_story_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -805,7 +1040,7 @@ func (x *GetStoryViewersRequest) String() string {
 func (*GetStoryViewersRequest) ProtoMessage() {}
 
 func (x *GetStoryViewersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_story_v1_story_proto_msgTypes[12]
+	mi := &file_proto_story_v1_story_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -818,7 +1053,7 @@ func (x *GetStoryViewersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetStoryViewersRequest.ProtoReflect.Descriptor instead.
 func (*GetStoryViewersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{12}
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetStoryViewersRequest) GetStoryId() string {
@@ -835,6 +1070,20 @@ func (x *GetStoryViewersRequest) GetUserId() string {
 	return ""
 }
 
+func (x *GetStoryViewersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetStoryViewersRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
 type StoryViewer struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *Author                `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -846,7 +1095,7 @@ type StoryViewer struct {
 
 func (x *StoryViewer) Reset() {
 	*x = StoryViewer{}
-	mi := &file_proto_story_v1_story_proto_msgTypes[13]
+	mi := &file_proto_story_v1_story_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -858,7 +1107,7 @@ func (x *StoryViewer) String() string {
 func (*StoryViewer) ProtoMessage() {}
 
 func (x *StoryViewer) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_story_v1_story_proto_msgTypes[13]
+	mi := &file_proto_story_v1_story_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -871,7 +1120,7 @@ func (x *StoryViewer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StoryViewer.ProtoReflect.Descriptor instead.
 func (*StoryViewer) Descriptor() ([]byte, []int) {
-	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{13}
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *StoryViewer) GetUser() *Author {
@@ -898,13 +1147,15 @@ func (x *StoryViewer) GetViewedAt() *timestamppb.Timestamp {
 type StoryViewersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Viewers       []*StoryViewer         `protobuf:"bytes,1,rep,name=viewers,proto3" json:"viewers,omitempty"`
+	UniqueViews   int64                  `protobuf:"varint,2,opt,name=unique_views,json=uniqueViews,proto3" json:"unique_views,omitempty"` // Approximate, from a HyperLogLog
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StoryViewersResponse) Reset() {
 	*x = StoryViewersResponse{}
-	mi := &file_proto_story_v1_story_proto_msgTypes[14]
+	mi := &file_proto_story_v1_story_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -916,7 +1167,7 @@ func (x *StoryViewersResponse) String() string {
 func (*StoryViewersResponse) ProtoMessage() {}
 
 func (x *StoryViewersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_story_v1_story_proto_msgTypes[14]
+	mi := &file_proto_story_v1_story_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -929,7 +1180,7 @@ func (x *StoryViewersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StoryViewersResponse.ProtoReflect.Descriptor instead.
 func (*StoryViewersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{14}
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *StoryViewersResponse) GetViewers() []*StoryViewer {
@@ -939,6 +1190,184 @@ func (x *StoryViewersResponse) GetViewers() []*StoryViewer {
 	return nil
 }
 
+func (x *StoryViewersResponse) GetUniqueViews() int64 {
+	if x != nil {
+		return x.UniqueViews
+	}
+	return 0
+}
+
+func (x *StoryViewersResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type ListHighlightCoversRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ViewerId      string                 `protobuf:"bytes,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // Empty for signed-out viewers, who see public stories only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHighlightCoversRequest) Reset() {
+	*x = ListHighlightCoversRequest{}
+	mi := &file_proto_story_v1_story_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHighlightCoversRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHighlightCoversRequest) ProtoMessage() {}
+
+func (x *ListHighlightCoversRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_story_v1_story_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHighlightCoversRequest.ProtoReflect.Descriptor instead.
+func (*ListHighlightCoversRequest) Descriptor() ([]byte, []int) {
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListHighlightCoversRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListHighlightCoversRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+type HighlightCover struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	CoverUrl      string                 `protobuf:"bytes,3,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	StoryCount    int32                  `protobuf:"varint,4,opt,name=story_count,json=storyCount,proto3" json:"story_count,omitempty"` // Stories the viewer may see
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HighlightCover) Reset() {
+	*x = HighlightCover{}
+	mi := &file_proto_story_v1_story_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HighlightCover) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HighlightCover) ProtoMessage() {}
+
+func (x *HighlightCover) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_story_v1_story_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HighlightCover.ProtoReflect.Descriptor instead.
+func (*HighlightCover) Descriptor() ([]byte, []int) {
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *HighlightCover) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HighlightCover) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *HighlightCover) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
+}
+
+func (x *HighlightCover) GetStoryCount() int32 {
+	if x != nil {
+		return x.StoryCount
+	}
+	return 0
+}
+
+type ListHighlightCoversResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Highlights    []*HighlightCover      `protobuf:"bytes,1,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHighlightCoversResponse) Reset() {
+	*x = ListHighlightCoversResponse{}
+	mi := &file_proto_story_v1_story_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHighlightCoversResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHighlightCoversResponse) ProtoMessage() {}
+
+func (x *ListHighlightCoversResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_story_v1_story_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHighlightCoversResponse.ProtoReflect.Descriptor instead.
+func (*ListHighlightCoversResponse) Descriptor() ([]byte, []int) {
+	return file_proto_story_v1_story_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListHighlightCoversResponse) GetHighlights() []*HighlightCover {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
 var File_proto_story_v1_story_proto protoreflect.FileDescriptor
 
 const file_proto_story_v1_story_proto_rawDesc = "" +
@@ -948,7 +1377,7 @@ const file_proto_story_v1_story_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
 	"\tfull_name\x18\x03 \x01(\tR\bfullName\x12\x16\n" +
-	"\x06avatar\x18\x04 \x01(\tR\x06avatar\"\xbe\x03\n" +
+	"\x06avatar\x18\x04 \x01(\tR\x06avatar\"\xf2\x03\n" +
 	"\x05Story\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12(\n" +
@@ -966,11 +1395,36 @@ const file_proto_story_v1_story_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"expires_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"6\n" +
+	"expires_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x122\n" +
+	"\boverlays\x18\r \x03(\v2\x16.story.v1.StoryOverlayR\boverlays\"\xc2\x02\n" +
+	"\fStoryOverlay\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\f\n" +
+	"\x01x\x18\x02 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x03 \x01(\x01R\x01y\x12\x14\n" +
+	"\x05scale\x18\x04 \x01(\x01R\x05scale\x12\x1a\n" +
+	"\brotation\x18\x05 \x01(\x01R\brotation\x12\x12\n" +
+	"\x04text\x18\x06 \x01(\tR\x04text\x12\x14\n" +
+	"\x05color\x18\a \x01(\tR\x05color\x12\x14\n" +
+	"\x05style\x18\b \x01(\tR\x05style\x12\x1d\n" +
+	"\n" +
+	"sticker_id\x18\t \x01(\tR\tstickerId\x12\x17\n" +
+	"\auser_id\x18\n" +
+	" \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\v \x01(\tR\busername\x12\x10\n" +
+	"\x03url\x18\f \x01(\tR\x03url\x12*\n" +
+	"\x05music\x18\r \x01(\v2\x14.story.v1.StoryMusicR\x05music\"\x91\x01\n" +
+	"\n" +
+	"StoryMusic\x12\x19\n" +
+	"\btrack_id\x18\x01 \x01(\tR\atrackId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06artist\x18\x03 \x01(\tR\x06artist\x12\x19\n" +
+	"\bstart_ms\x18\x04 \x01(\x05R\astartMs\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x05R\n" +
+	"durationMs\"6\n" +
 	"\rStoryResponse\x12%\n" +
 	"\x05story\x18\x01 \x01(\v2\x0f.story.v1.StoryR\x05story\"<\n" +
 	"\x0fStoriesResponse\x12)\n" +
-	"\astories\x18\x01 \x03(\v2\x0f.story.v1.StoryR\astories\"\xd5\x01\n" +
+	"\astories\x18\x01 \x03(\v2\x0f.story.v1.StoryR\astories\"\x89\x02\n" +
 	"\x12CreateStoryRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
 	"\tmedia_url\x18\x02 \x01(\tR\bmediaUrl\x12\x1d\n" +
@@ -978,7 +1432,8 @@ const file_proto_story_v1_story_proto_rawDesc = "" +
 	"media_type\x18\x03 \x01(\tR\tmediaType\x12\x18\n" +
 	"\aprivacy\x18\x04 \x01(\tR\aprivacy\x12'\n" +
 	"\x0fallowed_viewers\x18\x05 \x03(\tR\x0eallowedViewers\x12'\n" +
-	"\x0fblocked_viewers\x18\x06 \x03(\tR\x0eblockedViewers\"I\n" +
+	"\x0fblocked_viewers\x18\x06 \x03(\tR\x0eblockedViewers\x122\n" +
+	"\boverlays\x18\a \x03(\v2\x16.story.v1.StoryOverlayR\boverlays\"I\n" +
 	"\x0fGetStoryRequest\x12\x19\n" +
 	"\bstory_id\x18\x01 \x01(\tR\astoryId\x12\x1b\n" +
 	"\tviewer_id\x18\x02 \x01(\tR\bviewerId\"H\n" +
@@ -1003,16 +1458,33 @@ const file_proto_story_v1_story_proto_rawDesc = "" +
 	"\x13ReactToStoryRequest\x12\x19\n" +
 	"\bstory_id\x18\x01 \x01(\tR\astoryId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12#\n" +
-	"\rreaction_type\x18\x03 \x01(\tR\freactionType\"L\n" +
+	"\rreaction_type\x18\x03 \x01(\tR\freactionType\"z\n" +
 	"\x16GetStoryViewersRequest\x12\x19\n" +
 	"\bstory_id\x18\x01 \x01(\tR\astoryId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x91\x01\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"\x91\x01\n" +
 	"\vStoryViewer\x12$\n" +
 	"\x04user\x18\x01 \x01(\v2\x10.story.v1.AuthorR\x04user\x12#\n" +
 	"\rreaction_type\x18\x02 \x01(\tR\freactionType\x127\n" +
-	"\tviewed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bviewedAt\"G\n" +
+	"\tviewed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bviewedAt\"\x85\x01\n" +
 	"\x14StoryViewersResponse\x12/\n" +
-	"\aviewers\x18\x01 \x03(\v2\x15.story.v1.StoryViewerR\aviewers2\xd8\x04\n" +
+	"\aviewers\x18\x01 \x03(\v2\x15.story.v1.StoryViewerR\aviewers\x12!\n" +
+	"\funique_views\x18\x02 \x01(\x03R\vuniqueViews\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"R\n" +
+	"\x1aListHighlightCoversRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\tR\bviewerId\"t\n" +
+	"\x0eHighlightCover\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1b\n" +
+	"\tcover_url\x18\x03 \x01(\tR\bcoverUrl\x12\x1f\n" +
+	"\vstory_count\x18\x04 \x01(\x05R\n" +
+	"storyCount\"W\n" +
+	"\x1bListHighlightCoversResponse\x128\n" +
+	"\n" +
+	"highlights\x18\x01 \x03(\v2\x18.story.v1.HighlightCoverR\n" +
+	"highlights2\xbc\x05\n" +
 	"\fStoryService\x12D\n" +
 	"\vCreateStory\x12\x1c.story.v1.CreateStoryRequest\x1a\x17.story.v1.StoryResponse\x12>\n" +
 	"\bGetStory\x12\x19.story.v1.GetStoryRequest\x1a\x17.story.v1.StoryResponse\x12C\n" +
@@ -1022,7 +1494,8 @@ const file_proto_story_v1_story_proto_rawDesc = "" +
 	"\n" +
 	"RecordView\x12\x1b.story.v1.RecordViewRequest\x1a\x16.google.protobuf.Empty\x12E\n" +
 	"\fReactToStory\x12\x1d.story.v1.ReactToStoryRequest\x1a\x16.google.protobuf.Empty\x12S\n" +
-	"\x0fGetStoryViewers\x12 .story.v1.GetStoryViewersRequest\x1a\x1e.story.v1.StoryViewersResponseBAZ?gitlab.com/spydotech-group/shared-entity/proto/story/v1;storypbb\x06proto3"
+	"\x0fGetStoryViewers\x12 .story.v1.GetStoryViewersRequest\x1a\x1e.story.v1.StoryViewersResponse\x12b\n" +
+	"\x13ListHighlightCovers\x12$.story.v1.ListHighlightCoversRequest\x1a%.story.v1.ListHighlightCoversResponseBAZ?gitlab.com/spydotech-group/shared-entity/proto/story/v1;storypbb\x06proto3"
 
 var (
 	file_proto_story_v1_story_proto_rawDescOnce sync.Once
@@ -1036,57 +1509,68 @@ func file_proto_story_v1_story_proto_rawDescGZIP() []byte {
 	return file_proto_story_v1_story_proto_rawDescData
 }
 
-var file_proto_story_v1_story_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_proto_story_v1_story_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_proto_story_v1_story_proto_goTypes = []any{
-	(*Author)(nil),                 // 0: story.v1.Author
-	(*Story)(nil),                  // 1: story.v1.Story
-	(*StoryResponse)(nil),          // 2: story.v1.StoryResponse
-	(*StoriesResponse)(nil),        // 3: story.v1.StoriesResponse
-	(*CreateStoryRequest)(nil),     // 4: story.v1.CreateStoryRequest
-	(*GetStoryRequest)(nil),        // 5: story.v1.GetStoryRequest
-	(*DeleteStoryRequest)(nil),     // 6: story.v1.DeleteStoryRequest
-	(*GetStoriesFeedRequest)(nil),  // 7: story.v1.GetStoriesFeedRequest
-	(*StoriesFeedResponse)(nil),    // 8: story.v1.StoriesFeedResponse
-	(*GetUserStoriesRequest)(nil),  // 9: story.v1.GetUserStoriesRequest
-	(*RecordViewRequest)(nil),      // 10: story.v1.RecordViewRequest
-	(*ReactToStoryRequest)(nil),    // 11: story.v1.ReactToStoryRequest
-	(*GetStoryViewersRequest)(nil), // 12: story.v1.GetStoryViewersRequest
-	(*StoryViewer)(nil),            // 13: story.v1.StoryViewer
-	(*StoryViewersResponse)(nil),   // 14: story.v1.StoryViewersResponse
-	(*timestamppb.Timestamp)(nil),  // 15: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),          // 16: google.protobuf.Empty
+	(*Author)(nil),                      // 0: story.v1.Author
+	(*Story)(nil),                       // 1: story.v1.Story
+	(*StoryOverlay)(nil),                // 2: story.v1.StoryOverlay
+	(*StoryMusic)(nil),                  // 3: story.v1.StoryMusic
+	(*StoryResponse)(nil),               // 4: story.v1.StoryResponse
+	(*StoriesResponse)(nil),             // 5: story.v1.StoriesResponse
+	(*CreateStoryRequest)(nil),          // 6: story.v1.CreateStoryRequest
+	(*GetStoryRequest)(nil),             // 7: story.v1.GetStoryRequest
+	(*DeleteStoryRequest)(nil),          // 8: story.v1.DeleteStoryRequest
+	(*GetStoriesFeedRequest)(nil),       // 9: story.v1.GetStoriesFeedRequest
+	(*StoriesFeedResponse)(nil),         // 10: story.v1.StoriesFeedResponse
+	(*GetUserStoriesRequest)(nil),       // 11: story.v1.GetUserStoriesRequest
+	(*RecordViewRequest)(nil),           // 12: story.v1.RecordViewRequest
+	(*ReactToStoryRequest)(nil),         // 13: story.v1.ReactToStoryRequest
+	(*GetStoryViewersRequest)(nil),      // 14: story.v1.GetStoryViewersRequest
+	(*StoryViewer)(nil),                 // 15: story.v1.StoryViewer
+	(*StoryViewersResponse)(nil),        // 16: story.v1.StoryViewersResponse
+	(*ListHighlightCoversRequest)(nil),  // 17: story.v1.ListHighlightCoversRequest
+	(*HighlightCover)(nil),              // 18: story.v1.HighlightCover
+	(*ListHighlightCoversResponse)(nil), // 19: story.v1.ListHighlightCoversResponse
+	(*timestamppb.Timestamp)(nil),       // 20: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),               // 21: google.protobuf.Empty
 }
 var file_proto_story_v1_story_proto_depIdxs = []int32{
 	0,  // 0: story.v1.Story.author:type_name -> story.v1.Author
-	15, // 1: story.v1.Story.created_at:type_name -> google.protobuf.Timestamp
-	15, // 2: story.v1.Story.expires_at:type_name -> google.protobuf.Timestamp
-	1,  // 3: story.v1.StoryResponse.story:type_name -> story.v1.Story
-	1,  // 4: story.v1.StoriesResponse.stories:type_name -> story.v1.Story
-	1,  // 5: story.v1.StoriesFeedResponse.stories:type_name -> story.v1.Story
-	0,  // 6: story.v1.StoryViewer.user:type_name -> story.v1.Author
-	15, // 7: story.v1.StoryViewer.viewed_at:type_name -> google.protobuf.Timestamp
-	13, // 8: story.v1.StoryViewersResponse.viewers:type_name -> story.v1.StoryViewer
-	4,  // 9: story.v1.StoryService.CreateStory:input_type -> story.v1.CreateStoryRequest
-	5,  // 10: story.v1.StoryService.GetStory:input_type -> story.v1.GetStoryRequest
-	6,  // 11: story.v1.StoryService.DeleteStory:input_type -> story.v1.DeleteStoryRequest
-	7,  // 12: story.v1.StoryService.GetStoriesFeed:input_type -> story.v1.GetStoriesFeedRequest
-	9,  // 13: story.v1.StoryService.GetUserStories:input_type -> story.v1.GetUserStoriesRequest
-	10, // 14: story.v1.StoryService.RecordView:input_type -> story.v1.RecordViewRequest
-	11, // 15: story.v1.StoryService.ReactToStory:input_type -> story.v1.ReactToStoryRequest
-	12, // 16: story.v1.StoryService.GetStoryViewers:input_type -> story.v1.GetStoryViewersRequest
-	2,  // 17: story.v1.StoryService.CreateStory:output_type -> story.v1.StoryResponse
-	2,  // 18: story.v1.StoryService.GetStory:output_type -> story.v1.StoryResponse
-	16, // 19: story.v1.StoryService.DeleteStory:output_type -> google.protobuf.Empty
-	8,  // 20: story.v1.StoryService.GetStoriesFeed:output_type -> story.v1.StoriesFeedResponse
-	3,  // 21: story.v1.StoryService.GetUserStories:output_type -> story.v1.StoriesResponse
-	16, // 22: story.v1.StoryService.RecordView:output_type -> google.protobuf.Empty
-	16, // 23: story.v1.StoryService.ReactToStory:output_type -> google.protobuf.Empty
-	14, // 24: story.v1.StoryService.GetStoryViewers:output_type -> story.v1.StoryViewersResponse
-	17, // [17:25] is the sub-list for method output_type
-	9,  // [9:17] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	20, // 1: story.v1.Story.created_at:type_name -> google.protobuf.Timestamp
+	20, // 2: story.v1.Story.expires_at:type_name -> google.protobuf.Timestamp
+	2,  // 3: story.v1.Story.overlays:type_name -> story.v1.StoryOverlay
+	3,  // 4: story.v1.StoryOverlay.music:type_name -> story.v1.StoryMusic
+	1,  // 5: story.v1.StoryResponse.story:type_name -> story.v1.Story
+	1,  // 6: story.v1.StoriesResponse.stories:type_name -> story.v1.Story
+	2,  // 7: story.v1.CreateStoryRequest.overlays:type_name -> story.v1.StoryOverlay
+	1,  // 8: story.v1.StoriesFeedResponse.stories:type_name -> story.v1.Story
+	0,  // 9: story.v1.StoryViewer.user:type_name -> story.v1.Author
+	20, // 10: story.v1.StoryViewer.viewed_at:type_name -> google.protobuf.Timestamp
+	15, // 11: story.v1.StoryViewersResponse.viewers:type_name -> story.v1.StoryViewer
+	18, // 12: story.v1.ListHighlightCoversResponse.highlights:type_name -> story.v1.HighlightCover
+	6,  // 13: story.v1.StoryService.CreateStory:input_type -> story.v1.CreateStoryRequest
+	7,  // 14: story.v1.StoryService.GetStory:input_type -> story.v1.GetStoryRequest
+	8,  // 15: story.v1.StoryService.DeleteStory:input_type -> story.v1.DeleteStoryRequest
+	9,  // 16: story.v1.StoryService.GetStoriesFeed:input_type -> story.v1.GetStoriesFeedRequest
+	11, // 17: story.v1.StoryService.GetUserStories:input_type -> story.v1.GetUserStoriesRequest
+	12, // 18: story.v1.StoryService.RecordView:input_type -> story.v1.RecordViewRequest
+	13, // 19: story.v1.StoryService.ReactToStory:input_type -> story.v1.ReactToStoryRequest
+	14, // 20: story.v1.StoryService.GetStoryViewers:input_type -> story.v1.GetStoryViewersRequest
+	17, // 21: story.v1.StoryService.ListHighlightCovers:input_type -> story.v1.ListHighlightCoversRequest
+	4,  // 22: story.v1.StoryService.CreateStory:output_type -> story.v1.StoryResponse
+	4,  // 23: story.v1.StoryService.GetStory:output_type -> story.v1.StoryResponse
+	21, // 24: story.v1.StoryService.DeleteStory:output_type -> google.protobuf.Empty
+	10, // 25: story.v1.StoryService.GetStoriesFeed:output_type -> story.v1.StoriesFeedResponse
+	5,  // 26: story.v1.StoryService.GetUserStories:output_type -> story.v1.StoriesResponse
+	21, // 27: story.v1.StoryService.RecordView:output_type -> google.protobuf.Empty
+	21, // 28: story.v1.StoryService.ReactToStory:output_type -> google.protobuf.Empty
+	16, // 29: story.v1.StoryService.GetStoryViewers:output_type -> story.v1.StoryViewersResponse
+	19, // 30: story.v1.StoryService.ListHighlightCovers:output_type -> story.v1.ListHighlightCoversResponse
+	22, // [22:31] is the sub-list for method output_type
+	13, // [13:22] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_proto_story_v1_story_proto_init() }
@@ -1100,7 +1584,7 @@ func file_proto_story_v1_story_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_story_v1_story_proto_rawDesc), len(file_proto_story_v1_story_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
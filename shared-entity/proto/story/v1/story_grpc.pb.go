@@ -20,14 +20,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	StoryService_CreateStory_FullMethodName     = "/story.v1.StoryService/CreateStory"
-	StoryService_GetStory_FullMethodName        = "/story.v1.StoryService/GetStory"
-	StoryService_DeleteStory_FullMethodName     = "/story.v1.StoryService/DeleteStory"
-	StoryService_GetStoriesFeed_FullMethodName  = "/story.v1.StoryService/GetStoriesFeed"
-	StoryService_GetUserStories_FullMethodName  = "/story.v1.StoryService/GetUserStories"
-	StoryService_RecordView_FullMethodName      = "/story.v1.StoryService/RecordView"
-	StoryService_ReactToStory_FullMethodName    = "/story.v1.StoryService/ReactToStory"
-	StoryService_GetStoryViewers_FullMethodName = "/story.v1.StoryService/GetStoryViewers"
+	StoryService_CreateStory_FullMethodName         = "/story.v1.StoryService/CreateStory"
+	StoryService_GetStory_FullMethodName            = "/story.v1.StoryService/GetStory"
+	StoryService_DeleteStory_FullMethodName         = "/story.v1.StoryService/DeleteStory"
+	StoryService_GetStoriesFeed_FullMethodName      = "/story.v1.StoryService/GetStoriesFeed"
+	StoryService_GetUserStories_FullMethodName      = "/story.v1.StoryService/GetUserStories"
+	StoryService_RecordView_FullMethodName          = "/story.v1.StoryService/RecordView"
+	StoryService_ReactToStory_FullMethodName        = "/story.v1.StoryService/ReactToStory"
+	StoryService_GetStoryViewers_FullMethodName     = "/story.v1.StoryService/GetStoryViewers"
+	StoryService_ListHighlightCovers_FullMethodName = "/story.v1.StoryService/ListHighlightCovers"
 )
 
 // StoryServiceClient is the client API for StoryService service.
@@ -50,8 +51,10 @@ type StoryServiceClient interface {
 	RecordView(ctx context.Context, in *RecordViewRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Add a reaction to a story
 	ReactToStory(ctx context.Context, in *ReactToStoryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	// Get story viewers with their reactions
+	// Get a page of story viewers with their reactions (author only)
 	GetStoryViewers(ctx context.Context, in *GetStoryViewersRequest, opts ...grpc.CallOption) (*StoryViewersResponse, error)
+	// List the highlights on a user's profile as the viewer sees them
+	ListHighlightCovers(ctx context.Context, in *ListHighlightCoversRequest, opts ...grpc.CallOption) (*ListHighlightCoversResponse, error)
 }
 
 type storyServiceClient struct {
@@ -142,6 +145,16 @@ func (c *storyServiceClient) GetStoryViewers(ctx context.Context, in *GetStoryVi
 	return out, nil
 }
 
+func (c *storyServiceClient) ListHighlightCovers(ctx context.Context, in *ListHighlightCoversRequest, opts ...grpc.CallOption) (*ListHighlightCoversResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHighlightCoversResponse)
+	err := c.cc.Invoke(ctx, StoryService_ListHighlightCovers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StoryServiceServer is the server API for StoryService service.
 // All implementations must embed UnimplementedStoryServiceServer
 // for forward compatibility.
@@ -162,8 +175,10 @@ type StoryServiceServer interface {
 	RecordView(context.Context, *RecordViewRequest) (*emptypb.Empty, error)
 	// Add a reaction to a story
 	ReactToStory(context.Context, *ReactToStoryRequest) (*emptypb.Empty, error)
-	// Get story viewers with their reactions
+	// Get a page of story viewers with their reactions (author only)
 	GetStoryViewers(context.Context, *GetStoryViewersRequest) (*StoryViewersResponse, error)
+	// List the highlights on a user's profile as the viewer sees them
+	ListHighlightCovers(context.Context, *ListHighlightCoversRequest) (*ListHighlightCoversResponse, error)
 	mustEmbedUnimplementedStoryServiceServer()
 }
 
@@ -198,6 +213,9 @@ func (UnimplementedStoryServiceServer) ReactToStory(context.Context, *ReactToSto
 func (UnimplementedStoryServiceServer) GetStoryViewers(context.Context, *GetStoryViewersRequest) (*StoryViewersResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetStoryViewers not implemented")
 }
+func (UnimplementedStoryServiceServer) ListHighlightCovers(context.Context, *ListHighlightCoversRequest) (*ListHighlightCoversResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListHighlightCovers not implemented")
+}
 func (UnimplementedStoryServiceServer) mustEmbedUnimplementedStoryServiceServer() {}
 func (UnimplementedStoryServiceServer) testEmbeddedByValue()                      {}
 
@@ -363,6 +381,24 @@ func _StoryService_GetStoryViewers_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StoryService_ListHighlightCovers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHighlightCoversRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoryServiceServer).ListHighlightCovers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoryService_ListHighlightCovers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoryServiceServer).ListHighlightCovers(ctx, req.(*ListHighlightCoversRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StoryService_ServiceDesc is the grpc.ServiceDesc for StoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -402,6 +438,10 @@ var StoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStoryViewers",
 			Handler:    _StoryService_GetStoryViewers_Handler,
 		},
+		{
+			MethodName: "ListHighlightCovers",
+			Handler:    _StoryService_ListHighlightCovers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/story/v1/story.proto",
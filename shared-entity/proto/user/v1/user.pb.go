@@ -1619,7 +1619,7 @@ type User struct {
 	Avatar               string                 `protobuf:"bytes,5,opt,name=avatar,proto3" json:"avatar,omitempty"`
 	Bio                  string                 `protobuf:"bytes,6,opt,name=bio,proto3" json:"bio,omitempty"`
 	Role                 string                 `protobuf:"bytes,7,opt,name=role,proto3" json:"role,omitempty"`
-	IsVerified           bool                   `protobuf:"varint,8,opt,name=is_verified,json=isVerified,proto3" json:"is_verified,omitempty"`
+	IsVerified           bool                   `protobuf:"varint,8,opt,name=is_verified,json=isVerified,proto3" json:"is_verified,omitempty"` // Identity verified badge, not email_verified
 	PrivacySettings      *PrivacySettings       `protobuf:"bytes,9,opt,name=privacy_settings,json=privacySettings,proto3" json:"privacy_settings,omitempty"`
 	CoverPicture         string                 `protobuf:"bytes,10,opt,name=cover_picture,json=coverPicture,proto3" json:"cover_picture,omitempty"`
 	DateOfBirth          *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
@@ -2086,6 +2086,102 @@ func (x *GetFriendIDsResponse) GetFriendIds() []string {
 	return nil
 }
 
+type GetFollowingIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Most recently followed first; defaults to 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowingIDsRequest) Reset() {
+	*x = GetFollowingIDsRequest{}
+	mi := &file_proto_user_v1_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowingIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowingIDsRequest) ProtoMessage() {}
+
+func (x *GetFollowingIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_v1_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowingIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowingIDsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_v1_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetFollowingIDsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetFollowingIDsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetFollowingIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowingIDsResponse) Reset() {
+	*x = GetFollowingIDsResponse{}
+	mi := &file_proto_user_v1_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowingIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowingIDsResponse) ProtoMessage() {}
+
+func (x *GetFollowingIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_v1_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowingIDsResponse.ProtoReflect.Descriptor instead.
+func (*GetFollowingIDsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_v1_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetFollowingIDsResponse) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
 type CheckRelationshipRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`       // The user initiating the check (e.g., the viewer)
@@ -2096,7 +2192,7 @@ type CheckRelationshipRequest struct {
 
 func (x *CheckRelationshipRequest) Reset() {
 	*x = CheckRelationshipRequest{}
-	mi := &file_proto_user_v1_user_proto_msgTypes[34]
+	mi := &file_proto_user_v1_user_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2108,7 +2204,7 @@ func (x *CheckRelationshipRequest) String() string {
 func (*CheckRelationshipRequest) ProtoMessage() {}
 
 func (x *CheckRelationshipRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_v1_user_proto_msgTypes[34]
+	mi := &file_proto_user_v1_user_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2121,7 +2217,7 @@ func (x *CheckRelationshipRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckRelationshipRequest.ProtoReflect.Descriptor instead.
 func (*CheckRelationshipRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_v1_user_proto_rawDescGZIP(), []int{34}
+	return file_proto_user_v1_user_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *CheckRelationshipRequest) GetUserId() string {
@@ -2144,13 +2240,14 @@ type CheckRelationshipResponse struct {
 	IsBlockedByUser   bool                   `protobuf:"varint,2,opt,name=is_blocked_by_user,json=isBlockedByUser,proto3" json:"is_blocked_by_user,omitempty"`       // User has blocked Target
 	IsBlockedByTarget bool                   `protobuf:"varint,3,opt,name=is_blocked_by_target,json=isBlockedByTarget,proto3" json:"is_blocked_by_target,omitempty"` // Target has blocked User
 	IsFollowing       bool                   `protobuf:"varint,4,opt,name=is_following,json=isFollowing,proto3" json:"is_following,omitempty"`
+	IsCloseFriend     bool                   `protobuf:"varint,5,opt,name=is_close_friend,json=isCloseFriend,proto3" json:"is_close_friend,omitempty"` // Target has User on their close friends list
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
 func (x *CheckRelationshipResponse) Reset() {
 	*x = CheckRelationshipResponse{}
-	mi := &file_proto_user_v1_user_proto_msgTypes[35]
+	mi := &file_proto_user_v1_user_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2162,7 +2259,7 @@ func (x *CheckRelationshipResponse) String() string {
 func (*CheckRelationshipResponse) ProtoMessage() {}
 
 func (x *CheckRelationshipResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_v1_user_proto_msgTypes[35]
+	mi := &file_proto_user_v1_user_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2175,7 +2272,7 @@ func (x *CheckRelationshipResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckRelationshipResponse.ProtoReflect.Descriptor instead.
 func (*CheckRelationshipResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_v1_user_proto_rawDescGZIP(), []int{35}
+	return file_proto_user_v1_user_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *CheckRelationshipResponse) GetIsFriend() bool {
@@ -2206,6 +2303,394 @@ func (x *CheckRelationshipResponse) GetIsFollowing() bool {
 	return false
 }
 
+func (x *CheckRelationshipResponse) GetIsCloseFriend() bool {
+	if x != nil {
+		return x.IsCloseFriend
+	}
+	return false
+}
+
+// Close friends are always current friends; the list is private to its owner
+type GetCloseFriendIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCloseFriendIDsRequest) Reset() {
+	*x = GetCloseFriendIDsRequest{}
+	mi := &file_proto_user_v1_user_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCloseFriendIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCloseFriendIDsRequest) ProtoMessage() {}
+
+func (x *GetCloseFriendIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_v1_user_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCloseFriendIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetCloseFriendIDsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_v1_user_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetCloseFriendIDsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetCloseFriendIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCloseFriendIDsResponse) Reset() {
+	*x = GetCloseFriendIDsResponse{}
+	mi := &file_proto_user_v1_user_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCloseFriendIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCloseFriendIDsResponse) ProtoMessage() {}
+
+func (x *GetCloseFriendIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_v1_user_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCloseFriendIDsResponse.ProtoReflect.Descriptor instead.
+func (*GetCloseFriendIDsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_v1_user_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetCloseFriendIDsResponse) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type GetFriendSuggestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFriendSuggestionsRequest) Reset() {
+	*x = GetFriendSuggestionsRequest{}
+	mi := &file_proto_user_v1_user_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFriendSuggestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFriendSuggestionsRequest) ProtoMessage() {}
+
+func (x *GetFriendSuggestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_v1_user_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFriendSuggestionsRequest.ProtoReflect.Descriptor instead.
+func (*GetFriendSuggestionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_v1_user_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetFriendSuggestionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetFriendSuggestionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type FriendSuggestion struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username          string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	FullName          string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Avatar            string                 `protobuf:"bytes,4,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	MutualFriends     int32                  `protobuf:"varint,5,opt,name=mutual_friends,json=mutualFriends,proto3" json:"mutual_friends,omitempty"`
+	SharedCommunities int32                  `protobuf:"varint,6,opt,name=shared_communities,json=sharedCommunities,proto3" json:"shared_communities,omitempty"`
+	SharedEvents      int32                  `protobuf:"varint,7,opt,name=shared_events,json=sharedEvents,proto3" json:"shared_events,omitempty"`
+	Score             float64                `protobuf:"fixed64,8,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FriendSuggestion) Reset() {
+	*x = FriendSuggestion{}
+	mi := &file_proto_user_v1_user_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendSuggestion) ProtoMessage() {}
+
+func (x *FriendSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_v1_user_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendSuggestion.ProtoReflect.Descriptor instead.
+func (*FriendSuggestion) Descriptor() ([]byte, []int) {
+	return file_proto_user_v1_user_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *FriendSuggestion) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FriendSuggestion) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *FriendSuggestion) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *FriendSuggestion) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *FriendSuggestion) GetMutualFriends() int32 {
+	if x != nil {
+		return x.MutualFriends
+	}
+	return 0
+}
+
+func (x *FriendSuggestion) GetSharedCommunities() int32 {
+	if x != nil {
+		return x.SharedCommunities
+	}
+	return 0
+}
+
+func (x *FriendSuggestion) GetSharedEvents() int32 {
+	if x != nil {
+		return x.SharedEvents
+	}
+	return 0
+}
+
+func (x *FriendSuggestion) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetFriendSuggestionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*FriendSuggestion    `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFriendSuggestionsResponse) Reset() {
+	*x = GetFriendSuggestionsResponse{}
+	mi := &file_proto_user_v1_user_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFriendSuggestionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFriendSuggestionsResponse) ProtoMessage() {}
+
+func (x *GetFriendSuggestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_v1_user_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFriendSuggestionsResponse.ProtoReflect.Descriptor instead.
+func (*GetFriendSuggestionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_v1_user_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetFriendSuggestionsResponse) GetSuggestions() []*FriendSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type DismissFriendSuggestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetId      string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DismissFriendSuggestionRequest) Reset() {
+	*x = DismissFriendSuggestionRequest{}
+	mi := &file_proto_user_v1_user_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DismissFriendSuggestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DismissFriendSuggestionRequest) ProtoMessage() {}
+
+func (x *DismissFriendSuggestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_v1_user_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DismissFriendSuggestionRequest.ProtoReflect.Descriptor instead.
+func (*DismissFriendSuggestionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_v1_user_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DismissFriendSuggestionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DismissFriendSuggestionRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+type DismissFriendSuggestionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DismissFriendSuggestionResponse) Reset() {
+	*x = DismissFriendSuggestionResponse{}
+	mi := &file_proto_user_v1_user_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DismissFriendSuggestionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DismissFriendSuggestionResponse) ProtoMessage() {}
+
+func (x *DismissFriendSuggestionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_v1_user_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DismissFriendSuggestionResponse.ProtoReflect.Descriptor instead.
+func (*DismissFriendSuggestionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_v1_user_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DismissFriendSuggestionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
 var File_proto_user_v1_user_proto protoreflect.FileDescriptor
 
 const file_proto_user_v1_user_proto_rawDesc = "" +
@@ -2370,16 +2855,44 @@ const file_proto_user_v1_user_proto_rawDesc = "" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"5\n" +
 	"\x14GetFriendIDsResponse\x12\x1d\n" +
 	"\n" +
-	"friend_ids\x18\x01 \x03(\tR\tfriendIds\"P\n" +
+	"friend_ids\x18\x01 \x03(\tR\tfriendIds\"G\n" +
+	"\x16GetFollowingIDsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"4\n" +
+	"\x17GetFollowingIDsResponse\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"P\n" +
 	"\x18CheckRelationshipRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
-	"\ttarget_id\x18\x02 \x01(\tR\btargetId\"\xb9\x01\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\"\xe1\x01\n" +
 	"\x19CheckRelationshipResponse\x12\x1b\n" +
 	"\tis_friend\x18\x01 \x01(\bR\bisFriend\x12+\n" +
 	"\x12is_blocked_by_user\x18\x02 \x01(\bR\x0fisBlockedByUser\x12/\n" +
 	"\x14is_blocked_by_target\x18\x03 \x01(\bR\x11isBlockedByTarget\x12!\n" +
-	"\fis_following\x18\x04 \x01(\bR\visFollowing2\xcf\n" +
-	"\n" +
+	"\fis_following\x18\x04 \x01(\bR\visFollowing\x12&\n" +
+	"\x0fis_close_friend\x18\x05 \x01(\bR\risCloseFriend\"3\n" +
+	"\x18GetCloseFriendIDsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"6\n" +
+	"\x19GetCloseFriendIDsResponse\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"L\n" +
+	"\x1bGetFriendSuggestionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x8d\x02\n" +
+	"\x10FriendSuggestion\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tfull_name\x18\x03 \x01(\tR\bfullName\x12\x16\n" +
+	"\x06avatar\x18\x04 \x01(\tR\x06avatar\x12%\n" +
+	"\x0emutual_friends\x18\x05 \x01(\x05R\rmutualFriends\x12-\n" +
+	"\x12shared_communities\x18\x06 \x01(\x05R\x11sharedCommunities\x12#\n" +
+	"\rshared_events\x18\a \x01(\x05R\fsharedEvents\x12\x14\n" +
+	"\x05score\x18\b \x01(\x01R\x05score\"[\n" +
+	"\x1cGetFriendSuggestionsResponse\x12;\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x19.user.v1.FriendSuggestionR\vsuggestions\"V\n" +
+	"\x1eDismissFriendSuggestionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\";\n" +
+	"\x1fDismissFriendSuggestionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xb2\x0e\n" +
 	"\vUserService\x12<\n" +
 	"\aGetUser\x12\x17.user.v1.GetUserRequest\x1a\x18.user.v1.GetUserResponse\x12?\n" +
 	"\bGetUsers\x12\x18.user.v1.GetUsersRequest\x1a\x19.user.v1.GetUsersResponse\x12`\n" +
@@ -2387,7 +2900,8 @@ const file_proto_user_v1_user_proto_rawDesc = "" +
 	"\tListUsers\x12\x19.user.v1.ListUsersRequest\x1a\x1a.user.v1.ListUsersResponse\x12N\n" +
 	"\rGetUserStatus\x12\x1d.user.v1.GetUserStatusRequest\x1a\x1e.user.v1.GetUserStatusResponse\x12W\n" +
 	"\x10GetUsersPresence\x12 .user.v1.GetUsersPresenceRequest\x1a!.user.v1.GetUsersPresenceResponse\x12K\n" +
-	"\fGetFriendIDs\x12\x1c.user.v1.GetFriendIDsRequest\x1a\x1d.user.v1.GetFriendIDsResponse\x12E\n" +
+	"\fGetFriendIDs\x12\x1c.user.v1.GetFriendIDsRequest\x1a\x1d.user.v1.GetFriendIDsResponse\x12T\n" +
+	"\x0fGetFollowingIDs\x12\x1f.user.v1.GetFollowingIDsRequest\x1a .user.v1.GetFollowingIDsResponse\x12E\n" +
 	"\n" +
 	"UpdateUser\x12\x1a.user.v1.UpdateUserRequest\x1a\x1b.user.v1.UpdateUserResponse\x12H\n" +
 	"\vUpdateEmail\x12\x1b.user.v1.UpdateEmailRequest\x1a\x1c.user.v1.UpdateEmailResponse\x12Q\n" +
@@ -2397,7 +2911,11 @@ const file_proto_user_v1_user_proto_rawDesc = "" +
 	"\x0fUpdatePublicKey\x12\x1f.user.v1.UpdatePublicKeyRequest\x1a .user.v1.UpdatePublicKeyResponse\x12f\n" +
 	"\x15UpdatePrivacySettings\x12%.user.v1.UpdatePrivacySettingsRequest\x1a&.user.v1.UpdatePrivacySettingsResponse\x12u\n" +
 	"\x1aUpdateNotificationSettings\x12*.user.v1.UpdateNotificationSettingsRequest\x1a+.user.v1.UpdateNotificationSettingsResponse\x12Z\n" +
-	"\x11CheckRelationship\x12!.user.v1.CheckRelationshipRequest\x1a\".user.v1.CheckRelationshipResponseB$Z\"messaging-app/proto/user/v1;userv1b\x06proto3"
+	"\x11CheckRelationship\x12!.user.v1.CheckRelationshipRequest\x1a\".user.v1.CheckRelationshipResponse\x12Z\n" +
+	"\x11GetCloseFriendIDs\x12!.user.v1.GetCloseFriendIDsRequest\x1a\".user.v1.GetCloseFriendIDsResponse\x12\\\n" +
+	"\x13GetCloseFriendOfIDs\x12!.user.v1.GetCloseFriendIDsRequest\x1a\".user.v1.GetCloseFriendIDsResponse\x12c\n" +
+	"\x14GetFriendSuggestions\x12$.user.v1.GetFriendSuggestionsRequest\x1a%.user.v1.GetFriendSuggestionsResponse\x12l\n" +
+	"\x17DismissFriendSuggestion\x12'.user.v1.DismissFriendSuggestionRequest\x1a(.user.v1.DismissFriendSuggestionResponseB$Z\"messaging-app/proto/user/v1;userv1b\x06proto3"
 
 var (
 	file_proto_user_v1_user_proto_rawDescOnce sync.Once
@@ -2411,7 +2929,7 @@ func file_proto_user_v1_user_proto_rawDescGZIP() []byte {
 	return file_proto_user_v1_user_proto_rawDescData
 }
 
-var file_proto_user_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_proto_user_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
 var file_proto_user_v1_user_proto_goTypes = []any{
 	(*GetUserRequest)(nil),                     // 0: user.v1.GetUserRequest
 	(*GetUserResponse)(nil),                    // 1: user.v1.GetUserResponse
@@ -2447,63 +2965,83 @@ var file_proto_user_v1_user_proto_goTypes = []any{
 	(*NotificationSettings)(nil),               // 31: user.v1.NotificationSettings
 	(*GetFriendIDsRequest)(nil),                // 32: user.v1.GetFriendIDsRequest
 	(*GetFriendIDsResponse)(nil),               // 33: user.v1.GetFriendIDsResponse
-	(*CheckRelationshipRequest)(nil),           // 34: user.v1.CheckRelationshipRequest
-	(*CheckRelationshipResponse)(nil),          // 35: user.v1.CheckRelationshipResponse
-	nil,                                        // 36: user.v1.GetUsersPresenceResponse.PresenceEntry
-	(*timestamppb.Timestamp)(nil),              // 37: google.protobuf.Timestamp
+	(*GetFollowingIDsRequest)(nil),             // 34: user.v1.GetFollowingIDsRequest
+	(*GetFollowingIDsResponse)(nil),            // 35: user.v1.GetFollowingIDsResponse
+	(*CheckRelationshipRequest)(nil),           // 36: user.v1.CheckRelationshipRequest
+	(*CheckRelationshipResponse)(nil),          // 37: user.v1.CheckRelationshipResponse
+	(*GetCloseFriendIDsRequest)(nil),           // 38: user.v1.GetCloseFriendIDsRequest
+	(*GetCloseFriendIDsResponse)(nil),          // 39: user.v1.GetCloseFriendIDsResponse
+	(*GetFriendSuggestionsRequest)(nil),        // 40: user.v1.GetFriendSuggestionsRequest
+	(*FriendSuggestion)(nil),                   // 41: user.v1.FriendSuggestion
+	(*GetFriendSuggestionsResponse)(nil),       // 42: user.v1.GetFriendSuggestionsResponse
+	(*DismissFriendSuggestionRequest)(nil),     // 43: user.v1.DismissFriendSuggestionRequest
+	(*DismissFriendSuggestionResponse)(nil),    // 44: user.v1.DismissFriendSuggestionResponse
+	nil,                                        // 45: user.v1.GetUsersPresenceResponse.PresenceEntry
+	(*timestamppb.Timestamp)(nil),              // 46: google.protobuf.Timestamp
 }
 var file_proto_user_v1_user_proto_depIdxs = []int32{
 	29, // 0: user.v1.GetUserResponse.user:type_name -> user.v1.User
 	29, // 1: user.v1.GetUsersResponse.users:type_name -> user.v1.User
 	29, // 2: user.v1.GetUsersByUsernamesResponse.users:type_name -> user.v1.User
 	29, // 3: user.v1.ListUsersResponse.users:type_name -> user.v1.User
-	36, // 4: user.v1.GetUsersPresenceResponse.presence:type_name -> user.v1.GetUsersPresenceResponse.PresenceEntry
-	37, // 5: user.v1.UpdateUserRequest.date_of_birth:type_name -> google.protobuf.Timestamp
+	45, // 4: user.v1.GetUsersPresenceResponse.presence:type_name -> user.v1.GetUsersPresenceResponse.PresenceEntry
+	46, // 5: user.v1.UpdateUserRequest.date_of_birth:type_name -> google.protobuf.Timestamp
 	29, // 6: user.v1.UpdateUserResponse.user:type_name -> user.v1.User
 	30, // 7: user.v1.User.privacy_settings:type_name -> user.v1.PrivacySettings
-	37, // 8: user.v1.User.date_of_birth:type_name -> google.protobuf.Timestamp
-	37, // 9: user.v1.User.created_at:type_name -> google.protobuf.Timestamp
-	37, // 10: user.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	46, // 8: user.v1.User.date_of_birth:type_name -> google.protobuf.Timestamp
+	46, // 9: user.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	46, // 10: user.v1.User.updated_at:type_name -> google.protobuf.Timestamp
 	31, // 11: user.v1.User.notification_settings:type_name -> user.v1.NotificationSettings
-	37, // 12: user.v1.PrivacySettings.last_updated:type_name -> google.protobuf.Timestamp
-	12, // 13: user.v1.GetUsersPresenceResponse.PresenceEntry.value:type_name -> user.v1.UserPresence
-	0,  // 14: user.v1.UserService.GetUser:input_type -> user.v1.GetUserRequest
-	2,  // 15: user.v1.UserService.GetUsers:input_type -> user.v1.GetUsersRequest
-	4,  // 16: user.v1.UserService.GetUsersByUsernames:input_type -> user.v1.GetUsersByUsernamesRequest
-	6,  // 17: user.v1.UserService.ListUsers:input_type -> user.v1.ListUsersRequest
-	8,  // 18: user.v1.UserService.GetUserStatus:input_type -> user.v1.GetUserStatusRequest
-	10, // 19: user.v1.UserService.GetUsersPresence:input_type -> user.v1.GetUsersPresenceRequest
-	32, // 20: user.v1.UserService.GetFriendIDs:input_type -> user.v1.GetFriendIDsRequest
-	13, // 21: user.v1.UserService.UpdateUser:input_type -> user.v1.UpdateUserRequest
-	15, // 22: user.v1.UserService.UpdateEmail:input_type -> user.v1.UpdateEmailRequest
-	17, // 23: user.v1.UserService.UpdatePassword:input_type -> user.v1.UpdatePasswordRequest
-	19, // 24: user.v1.UserService.ToggleTwoFactor:input_type -> user.v1.ToggleTwoFactorRequest
-	21, // 25: user.v1.UserService.DeactivateAccount:input_type -> user.v1.DeactivateAccountRequest
-	23, // 26: user.v1.UserService.UpdatePublicKey:input_type -> user.v1.UpdatePublicKeyRequest
-	25, // 27: user.v1.UserService.UpdatePrivacySettings:input_type -> user.v1.UpdatePrivacySettingsRequest
-	27, // 28: user.v1.UserService.UpdateNotificationSettings:input_type -> user.v1.UpdateNotificationSettingsRequest
-	34, // 29: user.v1.UserService.CheckRelationship:input_type -> user.v1.CheckRelationshipRequest
-	1,  // 30: user.v1.UserService.GetUser:output_type -> user.v1.GetUserResponse
-	3,  // 31: user.v1.UserService.GetUsers:output_type -> user.v1.GetUsersResponse
-	5,  // 32: user.v1.UserService.GetUsersByUsernames:output_type -> user.v1.GetUsersByUsernamesResponse
-	7,  // 33: user.v1.UserService.ListUsers:output_type -> user.v1.ListUsersResponse
-	9,  // 34: user.v1.UserService.GetUserStatus:output_type -> user.v1.GetUserStatusResponse
-	11, // 35: user.v1.UserService.GetUsersPresence:output_type -> user.v1.GetUsersPresenceResponse
-	33, // 36: user.v1.UserService.GetFriendIDs:output_type -> user.v1.GetFriendIDsResponse
-	14, // 37: user.v1.UserService.UpdateUser:output_type -> user.v1.UpdateUserResponse
-	16, // 38: user.v1.UserService.UpdateEmail:output_type -> user.v1.UpdateEmailResponse
-	18, // 39: user.v1.UserService.UpdatePassword:output_type -> user.v1.UpdatePasswordResponse
-	20, // 40: user.v1.UserService.ToggleTwoFactor:output_type -> user.v1.ToggleTwoFactorResponse
-	22, // 41: user.v1.UserService.DeactivateAccount:output_type -> user.v1.DeactivateAccountResponse
-	24, // 42: user.v1.UserService.UpdatePublicKey:output_type -> user.v1.UpdatePublicKeyResponse
-	26, // 43: user.v1.UserService.UpdatePrivacySettings:output_type -> user.v1.UpdatePrivacySettingsResponse
-	28, // 44: user.v1.UserService.UpdateNotificationSettings:output_type -> user.v1.UpdateNotificationSettingsResponse
-	35, // 45: user.v1.UserService.CheckRelationship:output_type -> user.v1.CheckRelationshipResponse
-	30, // [30:46] is the sub-list for method output_type
-	14, // [14:30] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	46, // 12: user.v1.PrivacySettings.last_updated:type_name -> google.protobuf.Timestamp
+	41, // 13: user.v1.GetFriendSuggestionsResponse.suggestions:type_name -> user.v1.FriendSuggestion
+	12, // 14: user.v1.GetUsersPresenceResponse.PresenceEntry.value:type_name -> user.v1.UserPresence
+	0,  // 15: user.v1.UserService.GetUser:input_type -> user.v1.GetUserRequest
+	2,  // 16: user.v1.UserService.GetUsers:input_type -> user.v1.GetUsersRequest
+	4,  // 17: user.v1.UserService.GetUsersByUsernames:input_type -> user.v1.GetUsersByUsernamesRequest
+	6,  // 18: user.v1.UserService.ListUsers:input_type -> user.v1.ListUsersRequest
+	8,  // 19: user.v1.UserService.GetUserStatus:input_type -> user.v1.GetUserStatusRequest
+	10, // 20: user.v1.UserService.GetUsersPresence:input_type -> user.v1.GetUsersPresenceRequest
+	32, // 21: user.v1.UserService.GetFriendIDs:input_type -> user.v1.GetFriendIDsRequest
+	34, // 22: user.v1.UserService.GetFollowingIDs:input_type -> user.v1.GetFollowingIDsRequest
+	13, // 23: user.v1.UserService.UpdateUser:input_type -> user.v1.UpdateUserRequest
+	15, // 24: user.v1.UserService.UpdateEmail:input_type -> user.v1.UpdateEmailRequest
+	17, // 25: user.v1.UserService.UpdatePassword:input_type -> user.v1.UpdatePasswordRequest
+	19, // 26: user.v1.UserService.ToggleTwoFactor:input_type -> user.v1.ToggleTwoFactorRequest
+	21, // 27: user.v1.UserService.DeactivateAccount:input_type -> user.v1.DeactivateAccountRequest
+	23, // 28: user.v1.UserService.UpdatePublicKey:input_type -> user.v1.UpdatePublicKeyRequest
+	25, // 29: user.v1.UserService.UpdatePrivacySettings:input_type -> user.v1.UpdatePrivacySettingsRequest
+	27, // 30: user.v1.UserService.UpdateNotificationSettings:input_type -> user.v1.UpdateNotificationSettingsRequest
+	36, // 31: user.v1.UserService.CheckRelationship:input_type -> user.v1.CheckRelationshipRequest
+	38, // 32: user.v1.UserService.GetCloseFriendIDs:input_type -> user.v1.GetCloseFriendIDsRequest
+	38, // 33: user.v1.UserService.GetCloseFriendOfIDs:input_type -> user.v1.GetCloseFriendIDsRequest
+	40, // 34: user.v1.UserService.GetFriendSuggestions:input_type -> user.v1.GetFriendSuggestionsRequest
+	43, // 35: user.v1.UserService.DismissFriendSuggestion:input_type -> user.v1.DismissFriendSuggestionRequest
+	1,  // 36: user.v1.UserService.GetUser:output_type -> user.v1.GetUserResponse
+	3,  // 37: user.v1.UserService.GetUsers:output_type -> user.v1.GetUsersResponse
+	5,  // 38: user.v1.UserService.GetUsersByUsernames:output_type -> user.v1.GetUsersByUsernamesResponse
+	7,  // 39: user.v1.UserService.ListUsers:output_type -> user.v1.ListUsersResponse
+	9,  // 40: user.v1.UserService.GetUserStatus:output_type -> user.v1.GetUserStatusResponse
+	11, // 41: user.v1.UserService.GetUsersPresence:output_type -> user.v1.GetUsersPresenceResponse
+	33, // 42: user.v1.UserService.GetFriendIDs:output_type -> user.v1.GetFriendIDsResponse
+	35, // 43: user.v1.UserService.GetFollowingIDs:output_type -> user.v1.GetFollowingIDsResponse
+	14, // 44: user.v1.UserService.UpdateUser:output_type -> user.v1.UpdateUserResponse
+	16, // 45: user.v1.UserService.UpdateEmail:output_type -> user.v1.UpdateEmailResponse
+	18, // 46: user.v1.UserService.UpdatePassword:output_type -> user.v1.UpdatePasswordResponse
+	20, // 47: user.v1.UserService.ToggleTwoFactor:output_type -> user.v1.ToggleTwoFactorResponse
+	22, // 48: user.v1.UserService.DeactivateAccount:output_type -> user.v1.DeactivateAccountResponse
+	24, // 49: user.v1.UserService.UpdatePublicKey:output_type -> user.v1.UpdatePublicKeyResponse
+	26, // 50: user.v1.UserService.UpdatePrivacySettings:output_type -> user.v1.UpdatePrivacySettingsResponse
+	28, // 51: user.v1.UserService.UpdateNotificationSettings:output_type -> user.v1.UpdateNotificationSettingsResponse
+	37, // 52: user.v1.UserService.CheckRelationship:output_type -> user.v1.CheckRelationshipResponse
+	39, // 53: user.v1.UserService.GetCloseFriendIDs:output_type -> user.v1.GetCloseFriendIDsResponse
+	39, // 54: user.v1.UserService.GetCloseFriendOfIDs:output_type -> user.v1.GetCloseFriendIDsResponse
+	42, // 55: user.v1.UserService.GetFriendSuggestions:output_type -> user.v1.GetFriendSuggestionsResponse
+	44, // 56: user.v1.UserService.DismissFriendSuggestion:output_type -> user.v1.DismissFriendSuggestionResponse
+	36, // [36:57] is the sub-list for method output_type
+	15, // [15:36] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_proto_user_v1_user_proto_init() }
@@ -2519,7 +3057,7 @@ func file_proto_user_v1_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_v1_user_proto_rawDesc), len(file_proto_user_v1_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   37,
+			NumMessages:   46,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
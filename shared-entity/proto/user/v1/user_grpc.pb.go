@@ -26,6 +26,7 @@ const (
 	UserService_GetUserStatus_FullMethodName              = "/user.v1.UserService/GetUserStatus"
 	UserService_GetUsersPresence_FullMethodName           = "/user.v1.UserService/GetUsersPresence"
 	UserService_GetFriendIDs_FullMethodName               = "/user.v1.UserService/GetFriendIDs"
+	UserService_GetFollowingIDs_FullMethodName            = "/user.v1.UserService/GetFollowingIDs"
 	UserService_UpdateUser_FullMethodName                 = "/user.v1.UserService/UpdateUser"
 	UserService_UpdateEmail_FullMethodName                = "/user.v1.UserService/UpdateEmail"
 	UserService_UpdatePassword_FullMethodName             = "/user.v1.UserService/UpdatePassword"
@@ -35,6 +36,10 @@ const (
 	UserService_UpdatePrivacySettings_FullMethodName      = "/user.v1.UserService/UpdatePrivacySettings"
 	UserService_UpdateNotificationSettings_FullMethodName = "/user.v1.UserService/UpdateNotificationSettings"
 	UserService_CheckRelationship_FullMethodName          = "/user.v1.UserService/CheckRelationship"
+	UserService_GetCloseFriendIDs_FullMethodName          = "/user.v1.UserService/GetCloseFriendIDs"
+	UserService_GetCloseFriendOfIDs_FullMethodName        = "/user.v1.UserService/GetCloseFriendOfIDs"
+	UserService_GetFriendSuggestions_FullMethodName       = "/user.v1.UserService/GetFriendSuggestions"
+	UserService_DismissFriendSuggestion_FullMethodName    = "/user.v1.UserService/DismissFriendSuggestion"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -49,6 +54,7 @@ type UserServiceClient interface {
 	GetUserStatus(ctx context.Context, in *GetUserStatusRequest, opts ...grpc.CallOption) (*GetUserStatusResponse, error)
 	GetUsersPresence(ctx context.Context, in *GetUsersPresenceRequest, opts ...grpc.CallOption) (*GetUsersPresenceResponse, error)
 	GetFriendIDs(ctx context.Context, in *GetFriendIDsRequest, opts ...grpc.CallOption) (*GetFriendIDsResponse, error)
+	GetFollowingIDs(ctx context.Context, in *GetFollowingIDsRequest, opts ...grpc.CallOption) (*GetFollowingIDsResponse, error)
 	// Write Operations
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
 	UpdateEmail(ctx context.Context, in *UpdateEmailRequest, opts ...grpc.CallOption) (*UpdateEmailResponse, error)
@@ -60,6 +66,12 @@ type UserServiceClient interface {
 	UpdateNotificationSettings(ctx context.Context, in *UpdateNotificationSettingsRequest, opts ...grpc.CallOption) (*UpdateNotificationSettingsResponse, error)
 	// Relationship Checks
 	CheckRelationship(ctx context.Context, in *CheckRelationshipRequest, opts ...grpc.CallOption) (*CheckRelationshipResponse, error)
+	// Close Friends
+	GetCloseFriendIDs(ctx context.Context, in *GetCloseFriendIDsRequest, opts ...grpc.CallOption) (*GetCloseFriendIDsResponse, error)
+	GetCloseFriendOfIDs(ctx context.Context, in *GetCloseFriendIDsRequest, opts ...grpc.CallOption) (*GetCloseFriendIDsResponse, error)
+	// Friend Suggestions
+	GetFriendSuggestions(ctx context.Context, in *GetFriendSuggestionsRequest, opts ...grpc.CallOption) (*GetFriendSuggestionsResponse, error)
+	DismissFriendSuggestion(ctx context.Context, in *DismissFriendSuggestionRequest, opts ...grpc.CallOption) (*DismissFriendSuggestionResponse, error)
 }
 
 type userServiceClient struct {
@@ -140,6 +152,16 @@ func (c *userServiceClient) GetFriendIDs(ctx context.Context, in *GetFriendIDsRe
 	return out, nil
 }
 
+func (c *userServiceClient) GetFollowingIDs(ctx context.Context, in *GetFollowingIDsRequest, opts ...grpc.CallOption) (*GetFollowingIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFollowingIDsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetFollowingIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateUserResponse)
@@ -230,6 +252,46 @@ func (c *userServiceClient) CheckRelationship(ctx context.Context, in *CheckRela
 	return out, nil
 }
 
+func (c *userServiceClient) GetCloseFriendIDs(ctx context.Context, in *GetCloseFriendIDsRequest, opts ...grpc.CallOption) (*GetCloseFriendIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCloseFriendIDsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetCloseFriendIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetCloseFriendOfIDs(ctx context.Context, in *GetCloseFriendIDsRequest, opts ...grpc.CallOption) (*GetCloseFriendIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCloseFriendIDsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetCloseFriendOfIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetFriendSuggestions(ctx context.Context, in *GetFriendSuggestionsRequest, opts ...grpc.CallOption) (*GetFriendSuggestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFriendSuggestionsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetFriendSuggestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DismissFriendSuggestion(ctx context.Context, in *DismissFriendSuggestionRequest, opts ...grpc.CallOption) (*DismissFriendSuggestionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DismissFriendSuggestionResponse)
+	err := c.cc.Invoke(ctx, UserService_DismissFriendSuggestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -242,6 +304,7 @@ type UserServiceServer interface {
 	GetUserStatus(context.Context, *GetUserStatusRequest) (*GetUserStatusResponse, error)
 	GetUsersPresence(context.Context, *GetUsersPresenceRequest) (*GetUsersPresenceResponse, error)
 	GetFriendIDs(context.Context, *GetFriendIDsRequest) (*GetFriendIDsResponse, error)
+	GetFollowingIDs(context.Context, *GetFollowingIDsRequest) (*GetFollowingIDsResponse, error)
 	// Write Operations
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
 	UpdateEmail(context.Context, *UpdateEmailRequest) (*UpdateEmailResponse, error)
@@ -253,6 +316,12 @@ type UserServiceServer interface {
 	UpdateNotificationSettings(context.Context, *UpdateNotificationSettingsRequest) (*UpdateNotificationSettingsResponse, error)
 	// Relationship Checks
 	CheckRelationship(context.Context, *CheckRelationshipRequest) (*CheckRelationshipResponse, error)
+	// Close Friends
+	GetCloseFriendIDs(context.Context, *GetCloseFriendIDsRequest) (*GetCloseFriendIDsResponse, error)
+	GetCloseFriendOfIDs(context.Context, *GetCloseFriendIDsRequest) (*GetCloseFriendIDsResponse, error)
+	// Friend Suggestions
+	GetFriendSuggestions(context.Context, *GetFriendSuggestionsRequest) (*GetFriendSuggestionsResponse, error)
+	DismissFriendSuggestion(context.Context, *DismissFriendSuggestionRequest) (*DismissFriendSuggestionResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -284,6 +353,9 @@ func (UnimplementedUserServiceServer) GetUsersPresence(context.Context, *GetUser
 func (UnimplementedUserServiceServer) GetFriendIDs(context.Context, *GetFriendIDsRequest) (*GetFriendIDsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetFriendIDs not implemented")
 }
+func (UnimplementedUserServiceServer) GetFollowingIDs(context.Context, *GetFollowingIDsRequest) (*GetFollowingIDsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFollowingIDs not implemented")
+}
 func (UnimplementedUserServiceServer) UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateUser not implemented")
 }
@@ -311,6 +383,18 @@ func (UnimplementedUserServiceServer) UpdateNotificationSettings(context.Context
 func (UnimplementedUserServiceServer) CheckRelationship(context.Context, *CheckRelationshipRequest) (*CheckRelationshipResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CheckRelationship not implemented")
 }
+func (UnimplementedUserServiceServer) GetCloseFriendIDs(context.Context, *GetCloseFriendIDsRequest) (*GetCloseFriendIDsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCloseFriendIDs not implemented")
+}
+func (UnimplementedUserServiceServer) GetCloseFriendOfIDs(context.Context, *GetCloseFriendIDsRequest) (*GetCloseFriendIDsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCloseFriendOfIDs not implemented")
+}
+func (UnimplementedUserServiceServer) GetFriendSuggestions(context.Context, *GetFriendSuggestionsRequest) (*GetFriendSuggestionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFriendSuggestions not implemented")
+}
+func (UnimplementedUserServiceServer) DismissFriendSuggestion(context.Context, *DismissFriendSuggestionRequest) (*DismissFriendSuggestionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DismissFriendSuggestion not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -458,6 +542,24 @@ func _UserService_GetFriendIDs_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetFollowingIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFollowingIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetFollowingIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetFollowingIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetFollowingIDs(ctx, req.(*GetFollowingIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateUserRequest)
 	if err := dec(in); err != nil {
@@ -620,6 +722,78 @@ func _UserService_CheckRelationship_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetCloseFriendIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCloseFriendIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetCloseFriendIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetCloseFriendIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetCloseFriendIDs(ctx, req.(*GetCloseFriendIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetCloseFriendOfIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCloseFriendIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetCloseFriendOfIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetCloseFriendOfIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetCloseFriendOfIDs(ctx, req.(*GetCloseFriendIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetFriendSuggestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFriendSuggestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetFriendSuggestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetFriendSuggestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetFriendSuggestions(ctx, req.(*GetFriendSuggestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DismissFriendSuggestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DismissFriendSuggestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DismissFriendSuggestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DismissFriendSuggestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DismissFriendSuggestion(ctx, req.(*DismissFriendSuggestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -655,6 +829,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFriendIDs",
 			Handler:    _UserService_GetFriendIDs_Handler,
 		},
+		{
+			MethodName: "GetFollowingIDs",
+			Handler:    _UserService_GetFollowingIDs_Handler,
+		},
 		{
 			MethodName: "UpdateUser",
 			Handler:    _UserService_UpdateUser_Handler,
@@ -691,6 +869,22 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckRelationship",
 			Handler:    _UserService_CheckRelationship_Handler,
 		},
+		{
+			MethodName: "GetCloseFriendIDs",
+			Handler:    _UserService_GetCloseFriendIDs_Handler,
+		},
+		{
+			MethodName: "GetCloseFriendOfIDs",
+			Handler:    _UserService_GetCloseFriendOfIDs_Handler,
+		},
+		{
+			MethodName: "GetFriendSuggestions",
+			Handler:    _UserService_GetFriendSuggestions_Handler,
+		},
+		{
+			MethodName: "DismissFriendSuggestion",
+			Handler:    _UserService_DismissFriendSuggestion_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/user/v1/user.proto",
@@ -2,6 +2,7 @@ package resilience
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -15,6 +16,7 @@ type CircuitBreakerConfig struct {
 	Interval      time.Duration // Cyclic period of the closed state to clear internal stats
 	Timeout       time.Duration // Period of the open state before going to half-open
 	ReadyToTrip   func(counts gobreaker.Counts) bool
+	IsSuccessful  func(err error) bool // Errors it accepts don't count as failures; nil counts every error
 	OnStateChange func(name string, from gobreaker.State, to gobreaker.State)
 }
 
@@ -40,18 +42,22 @@ type CircuitBreaker struct {
 // NewCircuitBreaker creates a new circuit breaker with the given config
 func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
 	settings := gobreaker.Settings{
-		Name:        cfg.Name,
-		MaxRequests: cfg.MaxRequests,
-		Interval:    cfg.Interval,
-		Timeout:     cfg.Timeout,
-		ReadyToTrip: cfg.ReadyToTrip,
+		Name:         cfg.Name,
+		MaxRequests:  cfg.MaxRequests,
+		Interval:     cfg.Interval,
+		Timeout:      cfg.Timeout,
+		ReadyToTrip:  cfg.ReadyToTrip,
+		IsSuccessful: cfg.IsSuccessful,
 		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
 			fmt.Printf("[CircuitBreaker] %s: state changed from %s to %s\n", name, from, to)
+			breakerState.WithLabelValues(name).Set(stateValue(to))
+			breakerTransitions.WithLabelValues(name, to.String()).Inc()
 			if cfg.OnStateChange != nil {
 				cfg.OnStateChange(name, from, to)
 			}
 		},
 	}
+	breakerState.WithLabelValues(cfg.Name).Set(stateValue(gobreaker.StateClosed))
 
 	return &CircuitBreaker{
 		cb: gobreaker.NewCircuitBreaker(settings),
@@ -60,7 +66,7 @@ func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
 
 // Execute wraps a function call with circuit breaker protection
 func (c *CircuitBreaker) Execute(ctx context.Context, fn func() (interface{}, error)) (interface{}, error) {
-	return c.cb.Execute(func() (interface{}, error) {
+	result, err := c.cb.Execute(func() (interface{}, error) {
 		// Check context cancellation before executing
 		select {
 		case <-ctx.Done():
@@ -69,6 +75,10 @@ func (c *CircuitBreaker) Execute(ctx context.Context, fn func() (interface{}, er
 			return fn()
 		}
 	})
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		breakerRejections.WithLabelValues(c.cb.Name()).Inc()
+	}
+	return result, err
 }
 
 // State returns the current state of the circuit breaker
@@ -0,0 +1,237 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/serviceauth"
+	"github.com/sony/gobreaker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// ReadOnlyMethods matches the RPCs that only read, which are safe to retry.
+// A trailing * matches any method name with that prefix.
+var ReadOnlyMethods = []string{"Get*", "List*", "Search*", "Check*", "Count*", "Batch*"}
+
+// RetryPolicy retries failed attempts with exponential backoff and full jitter
+type RetryPolicy struct {
+	MaxAttempts    int           // Including the first attempt
+	InitialBackoff time.Duration // Upper bound of the first wait
+	MaxBackoff     time.Duration
+	Codes          []codes.Code // Status codes worth retrying
+}
+
+// DefaultRetryPolicy retries up to twice on errors that suggest the call
+// never reached a healthy server
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Codes:          []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded},
+	}
+}
+
+// backoff returns the wait before the given retry, counting from 1
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	ceiling := p.InitialBackoff << (retry - 1)
+	if ceiling <= 0 || ceiling > p.MaxBackoff {
+		ceiling = p.MaxBackoff
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling)
+}
+
+func (p RetryPolicy) retryable(code codes.Code) bool {
+	for _, c := range p.Codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// GRPCClient builds the dial options for calling one service: per-attempt
+// timeouts, retries of idempotent methods, and a circuit breaker. Timeouts
+// and method lists use the bare method name, e.g. "GetFeed".
+type GRPCClient struct {
+	target            string
+	timeout           time.Duration
+	methodTimeouts    map[string]time.Duration
+	retry             RetryPolicy
+	idempotentMethods []string
+	breakerConfig     *CircuitBreakerConfig // nil without a breaker
+	breaker           *CircuitBreaker
+}
+
+// NewGRPCClient starts a builder for calls to target, the service name used
+// for the breaker and metrics. It has a circuit breaker with DefaultConfig
+// and no timeouts or retries.
+func NewGRPCClient(target string) *GRPCClient {
+	cfg := DefaultConfig(target)
+	return &GRPCClient{
+		target:         target,
+		methodTimeouts: map[string]time.Duration{},
+		breakerConfig:  &cfg,
+	}
+}
+
+// WithTimeout bounds every attempt that has no method timeout of its own.
+// The caller's deadline still applies when it is sooner.
+func (b *GRPCClient) WithTimeout(d time.Duration) *GRPCClient {
+	b.timeout = d
+	return b
+}
+
+// WithMethodTimeout bounds each attempt of one method
+func (b *GRPCClient) WithMethodTimeout(method string, d time.Duration) *GRPCClient {
+	b.methodTimeouts[method] = d
+	return b
+}
+
+// WithRetry retries the given methods, which must be idempotent, under policy
+func (b *GRPCClient) WithRetry(policy RetryPolicy, methods ...string) *GRPCClient {
+	b.retry = policy
+	b.idempotentMethods = methods
+	return b
+}
+
+// WithCircuitBreaker replaces the default breaker. Errors the server
+// answered, such as NotFound, never count as failures.
+func (b *GRPCClient) WithCircuitBreaker(cfg CircuitBreakerConfig) *GRPCClient {
+	b.breakerConfig = &cfg
+	return b
+}
+
+// WithoutCircuitBreaker is for clients that already wrap calls in a breaker
+func (b *GRPCClient) WithoutCircuitBreaker() *GRPCClient {
+	b.breakerConfig = nil
+	return b
+}
+
+// DialOption returns the interceptors enforcing the policy. Calls also
+// present the process's service credentials, with target as the audience.
+func (b *GRPCClient) DialOption() grpc.DialOption {
+	interceptors := []grpc.UnaryClientInterceptor{serviceauth.UnaryClientInterceptor(b.target)}
+	if b.breakerConfig != nil && b.breaker == nil {
+		cfg := *b.breakerConfig
+		if cfg.IsSuccessful == nil {
+			cfg.IsSuccessful = isSuccessfulGRPC
+		}
+		b.breaker = NewCircuitBreaker(cfg)
+	}
+	if b.breaker != nil {
+		interceptors = append(interceptors, b.breakerInterceptor)
+	}
+	return grpc.WithChainUnaryInterceptor(append(interceptors, b.retryInterceptor)...)
+}
+
+// Dial connects to addr with the policy, tracing and plaintext transport
+func (b *GRPCClient) Dial(addr string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	return grpc.NewClient(addr, append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		observability.GetGRPCDialOption(),
+		b.DialOption(),
+	}, opts...)...)
+}
+
+// Breaker returns the client's circuit breaker, nil without one or before
+// the client is dialled
+func (b *GRPCClient) Breaker() *CircuitBreaker {
+	return b.breaker
+}
+
+// breakerInterceptor fails fast while the breaker is open. Retries happen
+// inside it, so a call that only succeeds on retry still counts as healthy.
+func (b *GRPCClient) breakerInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	_, err := b.breaker.Execute(ctx, func() (interface{}, error) {
+		return nil, invoker(ctx, method, req, reply, cc, opts...)
+	})
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		return status.Errorf(codes.Unavailable, "%s: circuit breaker open", b.target)
+	}
+	return err
+}
+
+func (b *GRPCClient) retryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	name := methodName(method)
+	attempts := 1
+	if b.retry.MaxAttempts > 1 && matchesMethod(b.idempotentMethods, name) {
+		attempts = b.retry.MaxAttempts
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			grpcClientRetries.WithLabelValues(b.target, name).Inc()
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(b.retry.backoff(attempt - 1)):
+			}
+		}
+
+		err = b.invoke(ctx, name, method, req, reply, cc, invoker, opts...)
+		// Stop on success, on errors not worth retrying, and once the
+		// caller's own deadline has passed
+		if err == nil || !b.retry.retryable(status.Code(err)) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// invoke makes one attempt under the method's timeout
+func (b *GRPCClient) invoke(ctx context.Context, name, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	timeout, ok := b.methodTimeouts[name]
+	if !ok {
+		timeout = b.timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// methodName strips the service from a full method name such as
+// "/feed.v1.FeedService/GetFeed"
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+func matchesMethod(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if pattern == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isSuccessfulGRPC counts only errors that point at an unhealthy server or
+// network against the breaker
+func isSuccessfulGRPC(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package resilience
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sony/gobreaker"
+)
+
+var (
+	breakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "circuit_breaker_state",
+		Help: "Circuit breaker state: 0 closed, 1 half-open, 2 open",
+	}, []string{"name"})
+	breakerTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "circuit_breaker_transitions_total",
+		Help: "Circuit breaker state changes, by the state entered",
+	}, []string{"name", "state"})
+	breakerRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "circuit_breaker_rejections_total",
+		Help: "Calls refused because the circuit breaker was open or its half-open probes were taken",
+	}, []string{"name"})
+	grpcClientRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_retries_total",
+		Help: "gRPC client calls retried, by target service and method",
+	}, []string{"target", "method"})
+)
+
+// stateValue maps a breaker state to the circuit_breaker_state gauge
+func stateValue(state gobreaker.State) float64 {
+	switch state {
+	case gobreaker.StateHalfOpen:
+		return 1
+	case gobreaker.StateOpen:
+		return 2
+	default:
+		return 0
+	}
+}
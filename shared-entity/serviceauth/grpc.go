@@ -0,0 +1,208 @@
+package serviceauth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Mode is how strictly a server checks its callers
+type Mode string
+
+const (
+	// ModeEnforce rejects calls without a valid token from an allowed caller
+	ModeEnforce Mode = "enforce"
+	// ModePermissive logs the calls enforce would reject and lets them
+	// through, for rolling credentials out to every caller first
+	ModePermissive Mode = "permissive"
+	// ModeDisabled checks nothing
+	ModeDisabled Mode = "disabled"
+)
+
+// ParseMode reads a mode from configuration, enforcing for anything unknown
+func ParseMode(value string) Mode {
+	switch Mode(strings.ToLower(strings.TrimSpace(value))) {
+	case ModePermissive:
+		return ModePermissive
+	case ModeDisabled:
+		return ModeDisabled
+	}
+	return ModeEnforce
+}
+
+// Allowlist names the services allowed to call each RPC. Keys are full
+// method names, e.g. "/feed.v1.FeedService/GetFeed", or a prefix of them
+// ending in "/", e.g. "/feed.v1.FeedService/"; the longest matching key
+// applies. Methods no key matches may not be called by anyone.
+type Allowlist map[string][]string
+
+func (a Allowlist) allows(fullMethod, caller string) bool {
+	var callers []string
+	matched := ""
+	for key, allowed := range a {
+		exact := key == fullMethod
+		prefix := strings.HasSuffix(key, "/") && strings.HasPrefix(fullMethod, key)
+		if (exact || prefix) && len(key) > len(matched) {
+			matched, callers = key, allowed
+		}
+	}
+	for _, allowed := range callers {
+		if allowed == caller {
+			return true
+		}
+	}
+	return false
+}
+
+// healthPrefix is open to everyone, for load balancers and probes
+const healthPrefix = "/grpc.health.v1.Health/"
+
+// Verifier authenticates and authorizes the calls a server receives
+type Verifier struct {
+	service   string
+	secret    []byte
+	mode      Mode
+	allowlist Allowlist
+	logger    *slog.Logger
+}
+
+// NewVerifier checks calls made to service. Without a secret nothing can be
+// verified, so checks are disabled with a warning.
+func NewVerifier(service, secret string, mode Mode, allowlist Allowlist, logger *slog.Logger) *Verifier {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if secret == "" && mode != ModeDisabled {
+		logger.Warn("No service auth secret configured, accepting calls from anyone", "service", service)
+		mode = ModeDisabled
+	}
+	return &Verifier{
+		service:   service,
+		secret:    []byte(secret),
+		mode:      mode,
+		allowlist: allowlist,
+		logger:    logger,
+	}
+}
+
+// UnaryServerOption checks unary calls
+func (v *Verifier) UnaryServerOption() grpc.ServerOption {
+	return grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := v.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	})
+}
+
+// StreamServerOption checks streaming calls
+func (v *Verifier) StreamServerOption() grpc.ServerOption {
+	return grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := v.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx})
+	})
+}
+
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authorize returns ctx with the caller and the user it called for, or the
+// status error to reject the call with
+func (v *Verifier) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	if v.mode == ModeDisabled || strings.HasPrefix(fullMethod, healthPrefix) {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	caller, err := v.authenticate(md)
+	if err == nil && !v.allowlist.allows(fullMethod, caller) {
+		err = ErrCallerDenied
+	}
+	if err != nil {
+		v.logger.Warn("Rejected service call", "method", fullMethod, "caller", caller, "mode", string(v.mode), "error", err)
+		if v.mode == ModeEnforce {
+			if errors.Is(err, ErrCallerDenied) {
+				return ctx, status.Error(codes.PermissionDenied, err.Error())
+			}
+			return ctx, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return ctx, nil
+	}
+
+	ctx = context.WithValue(ctx, callerKey{}, caller)
+	if users := md.Get(userMetadataKey); len(users) > 0 {
+		ctx = WithUser(ctx, users[0])
+	}
+	return ctx, nil
+}
+
+func (v *Verifier) authenticate(md metadata.MD) (string, error) {
+	tokens := md.Get(tokenMetadataKey)
+	if len(tokens) == 0 || tokens[0] == "" {
+		return "", ErrMissingToken
+	}
+	return parseToken(v.secret, v.service, tokens[0])
+}
+
+// DialOption presents the process's default credentials to audience, the
+// name of the service being called, and forwards the user each call is
+// made for
+func DialOption(audience string) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(UnaryClientInterceptor(audience))
+}
+
+// UnaryClientInterceptor is DialOption as an interceptor, for clients
+// chaining their own
+func UnaryClientInterceptor(audience string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, err := outgoingContext(ctx, audience)
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// outgoingContext adds the service token and user to ctx's metadata. The
+// credentials are looked up per call, so connections dialled before
+// SetDefault still present them.
+func outgoingContext(ctx context.Context, audience string) (context.Context, error) {
+	var pairs []string
+	if creds := Default(); creds != nil {
+		token, err := creds.Token(audience)
+		if err != nil && !errors.Is(err, ErrNotConfigured) {
+			return ctx, status.Error(codes.Internal, err.Error())
+		}
+		if token != "" {
+			pairs = append(pairs, tokenMetadataKey, token)
+		}
+	}
+	if userID, ok := UserFromContext(ctx); ok {
+		pairs = append(pairs, userMetadataKey, userID)
+	}
+	if len(pairs) == 0 {
+		return ctx, nil
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...), nil
+}
+
+// AllMethods is the Allowlist key for every method of a gRPC service
+func AllMethods(desc grpc.ServiceDesc) string {
+	return "/" + desc.ServiceName + "/"
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -163,14 +164,10 @@ func LogRequest(r *http.Request) string {
 	return fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, r.RemoteAddr)
 }
 
-// RespondWithError sends a JSON error response with the given status code and message
+// RespondWithError sends the standard error envelope with the given status
+// code and message; the code is derived from the status
 func RespondWithError(c *gin.Context, statusCode int, message string) {
-	c.JSON(statusCode, gin.H{
-		"error": gin.H{
-			"status":  statusCode,
-			"message": message,
-		},
-	})
+	apperrors.Write(c, statusCode, "", message, nil)
 	c.Abort()
 }
 
@@ -185,6 +182,11 @@ func GetStatusCode(err error) int {
 		return http.StatusOK
 	}
 
+	var appErr *apperrors.Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind.Status()
+	}
+
 	if st, ok := status.FromError(err); ok {
 		switch st.Code() {
 		case codes.NotFound:
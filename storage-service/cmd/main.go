@@ -9,10 +9,13 @@ import (
 	"os/signal"
 	"syscall"
 
+	sharedkafka "github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
 	storagepb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/storage/v1"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/serviceauth"
 	"github.com/MuhibNayem/connectify-v2/storage-service/config"
 	grpchandler "github.com/MuhibNayem/connectify-v2/storage-service/internal/grpc"
 	"github.com/MuhibNayem/connectify-v2/storage-service/internal/httpapi"
+	"github.com/MuhibNayem/connectify-v2/storage-service/internal/moderation"
 	"github.com/MuhibNayem/connectify-v2/storage-service/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -33,6 +36,29 @@ func main() {
 
 	go startGRPCServer(cfg, storageSvc, logger)
 	go startHTTPServer(cfg, storageSvc, logger)
+	go storageSvc.StartUploadGC(ctx)
+
+	var mediaEvents *sharedkafka.MediaEventPublisher
+	var moderationWorker *moderation.Worker
+	if cfg.ModerationEnabled {
+		if cfg.ModerationClassifierURL == "" {
+			log.Fatal("MODERATION_CLASSIFIER_URL is required when moderation is enabled")
+		}
+		mediaEvents = sharedkafka.NewMediaEventPublisher(cfg.KafkaBrokers)
+		storageSvc.SetUploadPublisher(mediaEvents)
+
+		moderationWorker = moderation.NewWorker(moderation.WorkerConfig{
+			Brokers: cfg.KafkaBrokers,
+			GroupID: "storage-moderation",
+			Workers: cfg.ModerationWorkers,
+			Policy: moderation.Policy{
+				HoldThreshold:   cfg.ModerationHoldThreshold,
+				RejectThreshold: cfg.ModerationRejectThreshold,
+			},
+		}, moderation.NewHTTPClassifier(cfg.ModerationClassifierURL), storageSvc, mediaEvents, logger)
+		moderationWorker.Start(ctx)
+		logger.Info("Moderation worker started", "workers", cfg.ModerationWorkers)
+	}
 
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
@@ -40,7 +66,17 @@ func main() {
 
 	logger.Info("Shutting down...")
 	cancel()
-	_ = ctx
+	if moderationWorker != nil {
+		if err := moderationWorker.Stop(); err != nil {
+			logger.Error("Failed to stop moderation worker", "error", err)
+		}
+	}
+	if err := mediaEvents.Close(); err != nil {
+		logger.Error("Failed to close media event publisher", "error", err)
+	}
+	if err := storageSvc.Close(); err != nil {
+		logger.Error("Failed to close storage service", "error", err)
+	}
 }
 
 func startGRPCServer(cfg *config.Config, svc *service.StorageService, logger *slog.Logger) {
@@ -49,7 +85,15 @@ func startGRPCServer(cfg *config.Config, svc *service.StorageService, logger *sl
 		log.Fatalf("Failed to listen on gRPC port: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	// Resumable upload parts arrive whole in a single message
+	callers := serviceauth.NewVerifier("storage-service", cfg.ServiceAuthSecret, serviceauth.ParseMode(cfg.ServiceAuthMode), serviceauth.Allowlist{
+		serviceauth.AllMethods(storagepb.StorageService_ServiceDesc): {"messaging-app", "user-service", "reel-service"},
+	}, logger)
+	grpcServer := grpc.NewServer(
+		grpc.MaxRecvMsgSize(int(svc.PartSize())+1<<20),
+		callers.UnaryServerOption(),
+		callers.StreamServerOption(),
+	)
 	storagepb.RegisterStorageServiceServer(grpcServer, grpchandler.NewStorageHandler(svc))
 
 	logger.Info("gRPC server starting", "port", cfg.GRPCPort)
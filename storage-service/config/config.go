@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -16,6 +18,31 @@ type Config struct {
 	StoragePublicURL string
 	ArchiveBucket    string
 	PrometheusPort   string
+
+	// Redis tracks the parts of resumable uploads
+	RedisURLs []string
+	RedisPass string
+
+	// Resumable uploads
+	UploadPartSize   int64
+	UploadMaxSize    int64
+	UploadTTL        time.Duration // Abandoned uploads are aborted after this long without a part
+	UploadGCInterval time.Duration
+
+	// Image moderation. Uploads are classified by the service at
+	// ModerationClassifierURL; scores at or above the thresholds hold the
+	// image for review or reject it, and either way move it to QuarantineBucket.
+	ModerationEnabled         bool
+	ModerationClassifierURL   string
+	ModerationHoldThreshold   float64
+	ModerationRejectThreshold float64
+	ModerationWorkers         int
+	QuarantineBucket          string
+	KafkaBrokers              []string
+
+	// Service-to-service authentication; mode is enforce, permissive or disabled
+	ServiceAuthSecret string
+	ServiceAuthMode   string
 }
 
 func LoadConfig() *Config {
@@ -30,6 +57,23 @@ func LoadConfig() *Config {
 		StoragePublicURL: getEnv("STORAGE_PUBLIC_URL", "http://localhost:9000"),
 		ArchiveBucket:    getEnv("ARCHIVE_BUCKET", "connectify-archive"),
 		PrometheusPort:   getEnv("PROMETHEUS_PORT", "9187"),
+		RedisURLs:        strings.Split(getEnv("REDIS_URL", "localhost:6379"), ","),
+		RedisPass:        getEnv("REDIS_PASS", ""),
+		UploadPartSize:   getEnvInt64("UPLOAD_PART_SIZE_MB", 8) << 20,
+		UploadMaxSize:    getEnvInt64("UPLOAD_MAX_SIZE_MB", 4096) << 20,
+		UploadTTL:        getEnvDuration("UPLOAD_TTL", 24*time.Hour),
+		UploadGCInterval: getEnvDuration("UPLOAD_GC_INTERVAL", 15*time.Minute),
+
+		ModerationEnabled:         getEnvBool("MODERATION_ENABLED", false),
+		ModerationClassifierURL:   getEnv("MODERATION_CLASSIFIER_URL", ""),
+		ModerationHoldThreshold:   getEnvFloat("MODERATION_HOLD_THRESHOLD", 0.6),
+		ModerationRejectThreshold: getEnvFloat("MODERATION_REJECT_THRESHOLD", 0.9),
+		ModerationWorkers:         int(getEnvInt64("MODERATION_WORKERS", 2)),
+		QuarantineBucket:          getEnv("QUARANTINE_BUCKET", "connectify-quarantine"),
+		KafkaBrokers:              strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
+
+		ServiceAuthSecret: getEnv("SERVICE_AUTH_SECRET", ""),
+		ServiceAuthMode:   getEnv("SERVICE_AUTH_MODE", "enforce"),
 	}
 }
 
@@ -47,3 +91,30 @@ func getEnvBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+func getEnvInt64(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
@@ -3,10 +3,13 @@ package grpc
 import (
 	"bytes"
 	"context"
+	"errors"
 	"time"
 
 	storagepb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/storage/v1"
 	"github.com/MuhibNayem/connectify-v2/storage-service/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type StorageHandler struct {
@@ -94,6 +97,13 @@ func (h *StorageHandler) DownloadArchive(ctx context.Context, req *storagepb.Dow
 	return &storagepb.DownloadArchiveResponse{Data: data}, nil
 }
 
+func (h *StorageHandler) DeleteArchive(ctx context.Context, req *storagepb.DeleteArchiveRequest) (*storagepb.DeleteArchiveResponse, error) {
+	if err := h.svc.DeleteArchive(ctx, req.ObjectPath); err != nil {
+		return nil, err
+	}
+	return &storagepb.DeleteArchiveResponse{Success: true}, nil
+}
+
 func (h *StorageHandler) GetPresignedURL(ctx context.Context, req *storagepb.GetPresignedURLRequest) (*storagepb.GetPresignedURLResponse, error) {
 	expiry := time.Duration(req.ExpirySeconds) * time.Second
 	if expiry == 0 {
@@ -106,6 +116,22 @@ func (h *StorageHandler) GetPresignedURL(ctx context.Context, req *storagepb.Get
 	return &storagepb.GetPresignedURLResponse{Url: url}, nil
 }
 
+func (h *StorageHandler) BatchGetPresignedURLs(ctx context.Context, req *storagepb.BatchGetPresignedURLsRequest) (*storagepb.BatchGetPresignedURLsResponse, error) {
+	if len(req.Keys) > service.MaxBatchPresign {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d keys per batch", service.MaxBatchPresign)
+	}
+
+	expiry := time.Duration(req.ExpirySeconds) * time.Second
+	if expiry == 0 {
+		expiry = 15 * time.Minute
+	}
+	urls, err := h.svc.BatchGetPresignedURLs(ctx, req.Keys, expiry)
+	if err != nil {
+		return nil, err
+	}
+	return &storagepb.BatchGetPresignedURLsResponse{Urls: urls}, nil
+}
+
 func (h *StorageHandler) GetPresignedUploadURL(ctx context.Context, req *storagepb.GetPresignedUploadURLRequest) (*storagepb.GetPresignedUploadURLResponse, error) {
 	uploadURL, publicURL, key, isDuplicate, err := h.svc.GetPresignedUploadURL(ctx, req.Filename, req.ContentType, req.Sha256Hash, req.ContentLength)
 	if err != nil {
@@ -119,3 +145,83 @@ func (h *StorageHandler) GetPresignedUploadURL(ctx context.Context, req *storage
 		IsDuplicate: isDuplicate,
 	}, nil
 }
+
+func (h *StorageHandler) InitiateUpload(ctx context.Context, req *storagepb.InitiateUploadRequest) (*storagepb.InitiateUploadResponse, error) {
+	upload, err := h.svc.InitiateUpload(ctx, req.Filename, req.ContentType, req.TotalSize)
+	if err != nil {
+		return nil, uploadError(err)
+	}
+	return &storagepb.InitiateUploadResponse{
+		UploadId:  upload.UploadID,
+		Key:       upload.Key,
+		PartSize:  upload.PartSize,
+		PartCount: int32(upload.PartCount),
+		ExpiresAt: upload.ExpiresAt.Unix(),
+	}, nil
+}
+
+func (h *StorageHandler) UploadPart(ctx context.Context, req *storagepb.UploadPartRequest) (*storagepb.UploadPartResponse, error) {
+	part, err := h.svc.UploadPart(ctx, req.UploadId, int(req.PartNumber), bytes.NewReader(req.Data), int64(len(req.Data)))
+	if err != nil {
+		return nil, uploadError(err)
+	}
+	return &storagepb.UploadPartResponse{
+		PartNumber: int32(part.PartNumber),
+		Etag:       part.ETag,
+		Size:       part.Size,
+	}, nil
+}
+
+func (h *StorageHandler) GetUploadStatus(ctx context.Context, req *storagepb.GetUploadStatusRequest) (*storagepb.GetUploadStatusResponse, error) {
+	upload, err := h.svc.GetUploadStatus(ctx, req.UploadId)
+	if err != nil {
+		return nil, uploadError(err)
+	}
+
+	uploaded := make([]int32, len(upload.UploadedParts))
+	for i, n := range upload.UploadedParts {
+		uploaded[i] = int32(n)
+	}
+	return &storagepb.GetUploadStatusResponse{
+		UploadId:      upload.UploadID,
+		Key:           upload.Key,
+		PartSize:      upload.PartSize,
+		PartCount:     int32(upload.PartCount),
+		UploadedParts: uploaded,
+		ExpiresAt:     upload.ExpiresAt.Unix(),
+	}, nil
+}
+
+func (h *StorageHandler) CompleteUpload(ctx context.Context, req *storagepb.CompleteUploadRequest) (*storagepb.UploadResponse, error) {
+	result, err := h.svc.CompleteUpload(ctx, req.UploadId)
+	if err != nil {
+		return nil, uploadError(err)
+	}
+	return &storagepb.UploadResponse{
+		Url:      result.URL,
+		Key:      result.Key,
+		Type:     result.Type,
+		Size:     result.Size,
+		MimeType: result.MimeType,
+	}, nil
+}
+
+func (h *StorageHandler) AbortUpload(ctx context.Context, req *storagepb.AbortUploadRequest) (*storagepb.AbortUploadResponse, error) {
+	if err := h.svc.AbortUpload(ctx, req.UploadId); err != nil {
+		return nil, uploadError(err)
+	}
+	return &storagepb.AbortUploadResponse{Success: true}, nil
+}
+
+func uploadError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrUploadNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidUpload), errors.Is(err, service.ErrInvalidPart):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrUploadIncomplete):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
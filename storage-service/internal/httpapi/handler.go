@@ -1,10 +1,13 @@
 package httpapi
 
 import (
+	"errors"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/utils"
 	"github.com/MuhibNayem/connectify-v2/storage-service/internal/service"
 	"github.com/gin-gonic/gin"
 )
@@ -27,6 +30,14 @@ func (h *StorageHandler) RegisterRoutes(r *gin.Engine) {
 		api.POST("/archive", h.UploadArchive)
 		api.GET("/archive/:path", h.DownloadArchive)
 		api.GET("/presigned/:key", h.GetPresignedURL)
+		api.POST("/presigned/batch", h.BatchGetPresignedURLs)
+
+		uploads := api.Group("/uploads")
+		uploads.POST("", h.InitiateUpload)
+		uploads.GET("/:id", h.GetUploadStatus)
+		uploads.PUT("/:id/parts/:number", h.UploadPart)
+		uploads.POST("/:id/complete", h.CompleteUpload)
+		uploads.DELETE("/:id", h.AbortUpload)
 	}
 
 	r.GET("/health", func(c *gin.Context) {
@@ -37,14 +48,14 @@ func (h *StorageHandler) RegisterRoutes(r *gin.Engine) {
 func (h *StorageHandler) Upload(c *gin.Context) {
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "file required"})
+		utils.RespondWithError(c, http.StatusBadRequest, "file required")
 		return
 	}
 	defer file.Close()
 
 	result, err := h.svc.Upload(c.Request.Context(), file, header.Size, header.Filename, header.Header.Get("Content-Type"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -54,13 +65,13 @@ func (h *StorageHandler) Upload(c *gin.Context) {
 func (h *StorageHandler) UploadMultiple(c *gin.Context) {
 	form, err := c.MultipartForm()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid form"})
+		utils.RespondWithError(c, http.StatusBadRequest, "invalid form")
 		return
 	}
 
 	files := form.File["files"]
 	if len(files) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "no files"})
+		utils.RespondWithError(c, http.StatusBadRequest, "no files")
 		return
 	}
 
@@ -68,7 +79,7 @@ func (h *StorageHandler) UploadMultiple(c *gin.Context) {
 	for _, fh := range files {
 		f, err := fh.Open()
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to open file"})
+			utils.RespondWithError(c, http.StatusBadRequest, "failed to open file")
 			return
 		}
 		defer f.Close()
@@ -85,7 +96,7 @@ func (h *StorageHandler) UploadMultiple(c *gin.Context) {
 
 	results, err := h.svc.UploadMultiple(c.Request.Context(), uploads)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -95,12 +106,12 @@ func (h *StorageHandler) UploadMultiple(c *gin.Context) {
 func (h *StorageHandler) Delete(c *gin.Context) {
 	key := c.Param("key")
 	if key == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "key required"})
+		utils.RespondWithError(c, http.StatusBadRequest, "key required")
 		return
 	}
 
 	if err := h.svc.Delete(c.Request.Context(), key); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -112,12 +123,12 @@ func (h *StorageHandler) DeleteByURL(c *gin.Context) {
 		URL string `json:"url" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	if err := h.svc.DeleteByURL(c.Request.Context(), req.URL); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -130,12 +141,12 @@ func (h *StorageHandler) UploadArchive(c *gin.Context) {
 		Data       []byte `json:"data" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	if err := h.svc.UploadArchive(c.Request.Context(), req.ObjectPath, req.Data); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -146,7 +157,7 @@ func (h *StorageHandler) DownloadArchive(c *gin.Context) {
 	path := c.Param("path")
 	data, err := h.svc.DownloadArchive(c.Request.Context(), path)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -165,9 +176,121 @@ func (h *StorageHandler) GetPresignedURL(c *gin.Context) {
 
 	url, err := h.svc.GetPresignedURL(c.Request.Context(), key, expiry)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"url": url})
 }
+
+func (h *StorageHandler) BatchGetPresignedURLs(c *gin.Context) {
+	var req struct {
+		Keys          []string `json:"keys" binding:"required"`
+		ExpirySeconds int64    `json:"expiry_seconds"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(req.Keys) > service.MaxBatchPresign {
+		utils.RespondWithError(c, http.StatusBadRequest, "too many keys")
+		return
+	}
+
+	expiry := time.Duration(req.ExpirySeconds) * time.Second
+	if expiry <= 0 {
+		expiry = 15 * time.Minute
+	}
+
+	urls, err := h.svc.BatchGetPresignedURLs(c.Request.Context(), req.Keys, expiry)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"urls": urls})
+}
+
+func (h *StorageHandler) InitiateUpload(c *gin.Context) {
+	var req struct {
+		Filename    string `json:"filename" binding:"required"`
+		ContentType string `json:"content_type"`
+		TotalSize   int64  `json:"total_size" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	upload, err := h.svc.InitiateUpload(c.Request.Context(), req.Filename, req.ContentType, req.TotalSize)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, upload)
+}
+
+// UploadPart takes the raw part bytes as the request body
+func (h *StorageHandler) UploadPart(c *gin.Context) {
+	partNumber, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "invalid part number")
+		return
+	}
+	if c.Request.ContentLength < 0 {
+		utils.RespondWithError(c, http.StatusLengthRequired, "content length required")
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, h.svc.PartSize())
+	part, err := h.svc.UploadPart(c.Request.Context(), c.Param("id"), partNumber, body, c.Request.ContentLength)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, part)
+}
+
+func (h *StorageHandler) GetUploadStatus(c *gin.Context) {
+	upload, err := h.svc.GetUploadStatus(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, upload)
+}
+
+func (h *StorageHandler) CompleteUpload(c *gin.Context) {
+	result, err := h.svc.CompleteUpload(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *StorageHandler) AbortUpload(c *gin.Context) {
+	if err := h.svc.AbortUpload(c.Request.Context(), c.Param("id")); err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func respondUploadError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrUploadNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidUpload), errors.Is(err, service.ErrInvalidPart):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrUploadIncomplete):
+		status = http.StatusConflict
+	}
+	utils.RespondWithError(c, status, err.Error())
+}
@@ -0,0 +1,78 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+// Classifier scores an image per label, e.g. "nsfw" or "violence", from 0 to 1
+type Classifier interface {
+	Classify(ctx context.Context, image io.Reader, mimeType string) (map[string]float64, error)
+}
+
+// Policy turns classifier scores into a verdict using the highest score
+type Policy struct {
+	HoldThreshold   float64
+	RejectThreshold float64
+}
+
+func (p Policy) Verdict(labels map[string]float64) models.ModerationVerdict {
+	highest := 0.0
+	for _, score := range labels {
+		highest = max(highest, score)
+	}
+	switch {
+	case highest >= p.RejectThreshold:
+		return models.ModerationRejected
+	case highest >= p.HoldThreshold:
+		return models.ModerationHeld
+	default:
+		return models.ModerationApproved
+	}
+}
+
+// HTTPClassifier posts the image to a classification service, which answers
+// with {"labels": {"nsfw": 0.97, "violence": 0.01}}
+type HTTPClassifier struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewHTTPClassifier(endpoint string) *HTTPClassifier {
+	return &HTTPClassifier{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *HTTPClassifier) Classify(ctx context.Context, image io.Reader, mimeType string) (map[string]float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, image)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mimeType)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("classifier request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("classifier returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Labels map[string]float64 `json:"labels"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode classifier response: %w", err)
+	}
+	return body.Labels, nil
+}
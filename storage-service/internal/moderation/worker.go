@@ -0,0 +1,203 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	sharedkafka "github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/segmentio/kafka-go"
+)
+
+// attempts bounds the tries at classifying an image or publishing its verdict
+const attempts = 3
+
+// ObjectStore reads uploads and moves flagged ones out of public reach
+type ObjectStore interface {
+	OpenObject(ctx context.Context, key string) (io.ReadCloser, error)
+	QuarantineObject(ctx context.Context, key string) error
+}
+
+// VerdictPublisher tells the content services what was decided
+type VerdictPublisher interface {
+	PublishVerdict(ctx context.Context, evt *models.MediaModerationEvent) error
+}
+
+// Worker classifies uploaded images on a pool of goroutines, quarantines the
+// flagged ones and publishes a verdict for every image
+type Worker struct {
+	reader     *kafka.Reader
+	classifier Classifier
+	store      ObjectStore
+	verdicts   VerdictPublisher
+	policy     Policy
+	logger     *slog.Logger
+
+	workers int
+	jobs    chan kafka.Message
+	wg      sync.WaitGroup
+}
+
+type WorkerConfig struct {
+	Brokers []string
+	GroupID string
+	Workers int
+	Policy  Policy
+}
+
+func NewWorker(cfg WorkerConfig, classifier Classifier, store ObjectStore, verdicts VerdictPublisher, logger *slog.Logger) *Worker {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: cfg.Brokers,
+		Topic:   sharedkafka.MediaUploadedTopic,
+		GroupID: cfg.GroupID,
+		MaxWait: time.Second,
+	})
+
+	return &Worker{
+		reader:     reader,
+		classifier: classifier,
+		store:      store,
+		verdicts:   verdicts,
+		policy:     cfg.Policy,
+		logger:     logger,
+		workers:    cfg.Workers,
+		jobs:       make(chan kafka.Message),
+	}
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	for i := 0; i < w.workers; i++ {
+		w.wg.Add(1)
+		go w.work(ctx)
+	}
+	go w.consume(ctx)
+}
+
+func (w *Worker) consume(ctx context.Context) {
+	defer close(w.jobs)
+
+	for {
+		msg, err := w.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			w.logger.Error("Failed to fetch upload event", "error", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		select {
+		case w.jobs <- msg:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *Worker) work(ctx context.Context) {
+	defer w.wg.Done()
+
+	for msg := range w.jobs {
+		var event models.MediaUploadedEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			w.logger.Error("Failed to unmarshal upload event", "error", err)
+		} else if err := w.Moderate(ctx, &event); err != nil {
+			w.logger.Error("Failed to moderate upload", "key", event.Key, "error", err)
+		}
+
+		// Work interrupted by shutdown is left uncommitted for redelivery
+		if ctx.Err() == nil {
+			if err := w.reader.CommitMessages(context.Background(), msg); err != nil {
+				w.logger.Error("Failed to commit upload event", "error", err)
+			}
+		}
+	}
+}
+
+// Moderate classifies one upload and publishes its verdict. An image the
+// classifier could not score is held for review rather than let through.
+func (w *Worker) Moderate(ctx context.Context, event *models.MediaUploadedEvent) error {
+	var labels map[string]float64
+	err := retry(ctx, func() error {
+		var err error
+		labels, err = w.classify(ctx, event)
+		return err
+	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	verdict := w.policy.Verdict(labels)
+	if err != nil {
+		w.logger.Warn("Classification failed, holding upload for review", "key", event.Key, "error", err)
+		verdict = models.ModerationHeld
+	}
+
+	result := &models.MediaModerationEvent{
+		Key:     event.Key,
+		URL:     event.URL,
+		Verdict: verdict,
+		Labels:  labels,
+	}
+	if verdict.Flagged() {
+		if err := w.store.QuarantineObject(ctx, event.Key); err != nil {
+			// The verdict still goes out so the content is hidden regardless
+			w.logger.Error("Failed to quarantine upload", "key", event.Key, "error", err)
+		} else {
+			result.Quarantined = true
+		}
+	}
+
+	if err := retry(ctx, func() error { return w.verdicts.PublishVerdict(ctx, result) }); err != nil {
+		return fmt.Errorf("failed to publish verdict: %w", err)
+	}
+
+	w.logger.Info("Upload moderated", "key", event.Key, "verdict", verdict, "quarantined", result.Quarantined)
+	return nil
+}
+
+func (w *Worker) classify(ctx context.Context, event *models.MediaUploadedEvent) (map[string]float64, error) {
+	image, err := w.store.OpenObject(ctx, event.Key)
+	if err != nil {
+		return nil, err
+	}
+	defer image.Close()
+	return w.classifier.Classify(ctx, image, event.MimeType)
+}
+
+// retry runs fn up to attempts times, backing off between tries
+func retry(ctx context.Context, fn func() error) error {
+	var err error
+	backoff := time.Second
+	for i := 0; i < attempts; i++ {
+		if err = fn(); err == nil || i == attempts-1 {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return err
+}
+
+// Stop waits for the workers to drain; cancel the Start context first
+func (w *Worker) Stop() error {
+	w.wg.Wait()
+	return w.reader.Close()
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/minio/minio-go/v7"
+)
+
+// UploadPublisher announces stored uploads to the moderation worker
+type UploadPublisher interface {
+	PublishUploaded(ctx context.Context, evt *models.MediaUploadedEvent) error
+}
+
+// SetUploadPublisher sends image uploads through moderation
+func (s *StorageService) SetUploadPublisher(publisher UploadPublisher) {
+	s.uploadPublisher = publisher
+}
+
+// announceUpload queues an image for moderation. A failure is logged rather
+// than failing an upload that has already been stored.
+func (s *StorageService) announceUpload(ctx context.Context, result *UploadResult) {
+	if s.uploadPublisher == nil || result.Type != "image" {
+		return
+	}
+	err := s.uploadPublisher.PublishUploaded(ctx, &models.MediaUploadedEvent{
+		Key:      result.Key,
+		URL:      result.URL,
+		MimeType: result.MimeType,
+		Size:     result.Size,
+	})
+	if err != nil {
+		s.logger.Error("Failed to queue upload for moderation", "key", result.Key, "error", err)
+	}
+}
+
+// OpenObject streams a stored object
+func (s *StorageService) OpenObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(ctx, s.bucketName, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	return obj, nil
+}
+
+// QuarantineObject moves an object into the quarantine bucket, where it is
+// kept for review but no longer served from its public URL
+func (s *StorageService) QuarantineObject(ctx context.Context, key string) error {
+	exists, err := s.client.BucketExists(ctx, s.quarantineBucket)
+	if err != nil {
+		return fmt.Errorf("failed to check quarantine bucket: %w", err)
+	}
+	if !exists {
+		if err := s.client.MakeBucket(ctx, s.quarantineBucket, minio.MakeBucketOptions{}); err != nil {
+			return fmt.Errorf("failed to create quarantine bucket: %w", err)
+		}
+	}
+
+	_, err = s.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: s.quarantineBucket, Object: key},
+		minio.CopySrcOptions{Bucket: s.bucketName, Object: key},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to copy to quarantine: %w", err)
+	}
+	if err := s.client.RemoveObject(ctx, s.bucketName, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to remove quarantined object: %w", err)
+	}
+
+	s.logger.Info("Object quarantined", "key", key, "bucket", s.quarantineBucket)
+	return nil
+}
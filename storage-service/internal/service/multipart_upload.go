@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+)
+
+var (
+	ErrUploadNotFound   = errors.New("upload not found or expired")
+	ErrInvalidUpload    = errors.New("invalid upload")
+	ErrInvalidPart      = errors.New("invalid upload part")
+	ErrUploadIncomplete = errors.New("upload is missing parts")
+)
+
+const (
+	// minPartSize is the smallest part object storage accepts, bar the last
+	minPartSize  = 5 << 20
+	maxPartCount = 10000
+
+	uploadGCBatchSize = 100
+)
+
+// MultipartUpload describes a resumable upload and its progress
+type MultipartUpload struct {
+	UploadID      string    `json:"upload_id"`
+	Key           string    `json:"key"`
+	PartSize      int64     `json:"part_size"`
+	PartCount     int       `json:"part_count"`
+	UploadedParts []int     `json:"uploaded_parts"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+type UploadedPart struct {
+	PartNumber int    `json:"part_number"`
+	ETag       string `json:"etag"`
+	Size       int64  `json:"size"`
+}
+
+func toMultipartUpload(u *uploadState) *MultipartUpload {
+	uploaded := make([]int, 0, len(u.Parts))
+	for n := 1; n <= u.PartCount; n++ {
+		if _, ok := u.Parts[n]; ok {
+			uploaded = append(uploaded, n)
+		}
+	}
+	return &MultipartUpload{
+		UploadID:      u.ID,
+		Key:           u.Key,
+		PartSize:      u.PartSize,
+		PartCount:     u.PartCount,
+		UploadedParts: uploaded,
+		ExpiresAt:     u.ExpiresAt,
+	}
+}
+
+// InitiateUpload opens a resumable upload of totalSize bytes. The caller
+// splits the file into PartCount parts of PartSize bytes (the last may be
+// shorter) and sends them with UploadPart.
+func (s *StorageService) InitiateUpload(ctx context.Context, filename, contentType string, totalSize int64) (*MultipartUpload, error) {
+	partSize := s.PartSize()
+	maxSize := min(s.maxUploadSize, partSize*maxPartCount)
+	if totalSize <= 0 || totalSize > maxSize {
+		return nil, fmt.Errorf("%w: size must be between 1 and %d bytes", ErrInvalidUpload, maxSize)
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	objectName := newObjectName(filename)
+	s3UploadID, err := s.core.NewMultipartUpload(ctx, s.bucketName, objectName, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate upload: %w", err)
+	}
+
+	state := &uploadState{
+		ID:          uuid.New().String(),
+		Key:         objectName,
+		S3UploadID:  s3UploadID,
+		Filename:    filename,
+		ContentType: contentType,
+		TotalSize:   totalSize,
+		PartSize:    partSize,
+		PartCount:   int((totalSize + partSize - 1) / partSize),
+		ExpiresAt:   time.Now().Add(s.uploads.ttl),
+	}
+	if err := s.uploads.create(ctx, state); err != nil {
+		if abortErr := s.core.AbortMultipartUpload(ctx, s.bucketName, objectName, s3UploadID); abortErr != nil {
+			s.logger.Error("Failed to abort untracked upload", "key", objectName, "error", abortErr)
+		}
+		return nil, fmt.Errorf("failed to track upload: %w", err)
+	}
+
+	s.logger.Info("Upload initiated", "upload_id", state.ID, "key", objectName, "size", totalSize, "parts", state.PartCount)
+	return toMultipartUpload(state), nil
+}
+
+// UploadPart stores one part of an upload. Re-sending a part replaces it,
+// so clients can retry any part that failed.
+func (s *StorageService) UploadPart(ctx context.Context, uploadID string, partNumber int, data io.Reader, size int64) (*UploadedPart, error) {
+	state, err := s.uploads.get(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if partNumber < 1 || partNumber > state.PartCount {
+		return nil, fmt.Errorf("%w: part number must be between 1 and %d", ErrInvalidPart, state.PartCount)
+	}
+	if expected := state.partSizeOf(partNumber); size != expected {
+		return nil, fmt.Errorf("%w: part %d must be %d bytes, got %d", ErrInvalidPart, partNumber, expected, size)
+	}
+
+	part, err := s.core.PutObjectPart(ctx, s.bucketName, state.Key, state.S3UploadID, partNumber, data, size, minio.PutObjectPartOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchUpload" {
+			return nil, ErrUploadNotFound
+		}
+		return nil, fmt.Errorf("failed to upload part: %w", err)
+	}
+
+	if _, err := s.uploads.addPart(ctx, uploadID, partNumber, part.ETag); err != nil {
+		return nil, err
+	}
+
+	return &UploadedPart{PartNumber: partNumber, ETag: part.ETag, Size: part.Size}, nil
+}
+
+// GetUploadStatus reports which parts have arrived, letting a client resume
+func (s *StorageService) GetUploadStatus(ctx context.Context, uploadID string) (*MultipartUpload, error) {
+	state, err := s.uploads.get(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	return toMultipartUpload(state), nil
+}
+
+// CompleteUpload assembles the parts into the final object
+func (s *StorageService) CompleteUpload(ctx context.Context, uploadID string) (*UploadResult, error) {
+	state, err := s.uploads.get(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]minio.CompletePart, 0, state.PartCount)
+	for n := 1; n <= state.PartCount; n++ {
+		etag, ok := state.Parts[n]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d of %d parts uploaded", ErrUploadIncomplete, len(state.Parts), state.PartCount)
+		}
+		parts = append(parts, minio.CompletePart{PartNumber: n, ETag: etag})
+	}
+
+	info, err := s.core.CompleteMultipartUpload(ctx, s.bucketName, state.Key, state.S3UploadID, parts, minio.PutObjectOptions{
+		ContentType: state.ContentType,
+	})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchUpload" {
+			return nil, ErrUploadNotFound
+		}
+		return nil, fmt.Errorf("failed to complete upload: %w", err)
+	}
+
+	if err := s.uploads.delete(ctx, uploadID); err != nil {
+		s.logger.Warn("Failed to clear completed upload", "upload_id", uploadID, "error", err)
+	}
+
+	s.logger.Info("Upload completed", "upload_id", uploadID, "key", info.Key, "size", state.TotalSize)
+
+	result := &UploadResult{
+		URL:      fmt.Sprintf("%s/%s/%s", s.externalHost, s.bucketName, state.Key),
+		Key:      state.Key,
+		Type:     detectMediaType(state.ContentType),
+		Size:     state.TotalSize,
+		MimeType: state.ContentType,
+	}
+	s.announceUpload(ctx, result)
+	return result, nil
+}
+
+// AbortUpload discards an upload and every part sent so far
+func (s *StorageService) AbortUpload(ctx context.Context, uploadID string) error {
+	state, err := s.uploads.get(ctx, uploadID)
+	if err != nil {
+		return err
+	}
+	if err := s.abort(ctx, state); err != nil {
+		return err
+	}
+	s.logger.Info("Upload aborted", "upload_id", uploadID, "key", state.Key)
+	return nil
+}
+
+func (s *StorageService) abort(ctx context.Context, state *uploadState) error {
+	err := s.core.AbortMultipartUpload(ctx, s.bucketName, state.Key, state.S3UploadID)
+	if err != nil && minio.ToErrorResponse(err).Code != "NoSuchUpload" {
+		return fmt.Errorf("failed to abort upload: %w", err)
+	}
+	return s.uploads.delete(ctx, state.ID)
+}
+
+// StartUploadGC periodically aborts uploads that stopped receiving parts
+func (s *StorageService) StartUploadGC(ctx context.Context) {
+	ticker := time.NewTicker(s.uploadGCInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.collectAbandonedUploads(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *StorageService) collectAbandonedUploads(ctx context.Context) {
+	for {
+		ids, err := s.uploads.expired(ctx, time.Now(), uploadGCBatchSize)
+		if err != nil {
+			s.logger.Error("Failed to list abandoned uploads", "error", err)
+			return
+		}
+
+		for _, id := range ids {
+			state, err := s.uploads.get(ctx, id)
+			if errors.Is(err, ErrUploadNotFound) {
+				// State already gone; only the index entry is left
+				err = s.uploads.delete(ctx, id)
+			} else if err == nil {
+				err = s.abort(ctx, state)
+			}
+			if err != nil {
+				s.logger.Error("Failed to collect abandoned upload", "upload_id", id, "error", err)
+				return
+			}
+			s.logger.Info("Abandoned upload collected", "upload_id", id)
+		}
+
+		if len(ids) < uploadGCBatchSize {
+			return
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
 	"github.com/MuhibNayem/connectify-v2/storage-service/config"
 	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
@@ -20,10 +21,20 @@ import (
 
 type StorageService struct {
 	client        *minio.Client
+	core          minio.Core
 	bucketName    string
 	externalHost  string
 	archiveBucket string
 	logger        *slog.Logger
+
+	// Image uploads are announced for moderation when a publisher is set
+	uploadPublisher  UploadPublisher
+	quarantineBucket string
+
+	uploads          *uploadStore
+	partSize         int64
+	maxUploadSize    int64
+	uploadGCInterval time.Duration
 }
 
 type UploadResult struct {
@@ -61,18 +72,43 @@ func NewStorageService(cfg *config.Config, logger *slog.Logger) (*StorageService
 		// NOTE: Bucket is PRIVATE by default. Use GetPresignedURL for read access.
 	}
 
+	redisClient := redis.NewClusterClient(redis.Config{
+		RedisURLs: cfg.RedisURLs,
+		RedisPass: cfg.RedisPass,
+	})
+
 	return &StorageService{
 		client:        minioClient,
+		core:          minio.Core{Client: minioClient},
 		bucketName:    cfg.StorageBucket,
 		externalHost:  cfg.StoragePublicURL,
 		archiveBucket: cfg.ArchiveBucket,
 		logger:        logger,
+
+		quarantineBucket: cfg.QuarantineBucket,
+
+		uploads:          &uploadStore{client: redisClient, ttl: cfg.UploadTTL},
+		partSize:         cfg.UploadPartSize,
+		maxUploadSize:    cfg.UploadMaxSize,
+		uploadGCInterval: cfg.UploadGCInterval,
 	}, nil
 }
 
+// PartSize is the size of resumable upload parts
+func (s *StorageService) PartSize() int64 {
+	return max(s.partSize, minPartSize)
+}
+
+func (s *StorageService) Close() error {
+	return s.uploads.client.Close()
+}
+
+func newObjectName(filename string) string {
+	return fmt.Sprintf("%d-%s%s", time.Now().UnixNano(), uuid.New().String(), filepath.Ext(filename))
+}
+
 func (s *StorageService) Upload(ctx context.Context, data io.Reader, size int64, filename, contentType string) (*UploadResult, error) {
-	ext := filepath.Ext(filename)
-	objectName := fmt.Sprintf("%d-%s%s", time.Now().UnixNano(), uuid.New().String(), ext)
+	objectName := newObjectName(filename)
 
 	if contentType == "" {
 		contentType = "application/octet-stream"
@@ -98,13 +134,15 @@ func (s *StorageService) Upload(ctx context.Context, data io.Reader, size int64,
 
 	s.logger.Info("File uploaded", "key", info.Key, "size", info.Size)
 
-	return &UploadResult{
+	result := &UploadResult{
 		URL:      url,
 		Key:      info.Key,
 		Type:     mediaType,
 		Size:     info.Size,
 		MimeType: contentType,
-	}, nil
+	}
+	s.announceUpload(ctx, result)
+	return result, nil
 }
 
 func (s *StorageService) UploadMultiple(ctx context.Context, files []FileUpload) ([]*UploadResult, error) {
@@ -201,6 +239,17 @@ func (s *StorageService) UploadArchive(ctx context.Context, objectPath string, d
 	return nil
 }
 
+// DeleteArchive removes an archive, as when its messages pass their
+// retention period. Deleting an archive that does not exist is not an error.
+func (s *StorageService) DeleteArchive(ctx context.Context, objectPath string) error {
+	if err := s.client.RemoveObject(ctx, s.archiveBucket, objectPath, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete archive: %w", err)
+	}
+
+	s.logger.Info("Archive deleted", "path", objectPath)
+	return nil
+}
+
 func (s *StorageService) DownloadArchive(ctx context.Context, objectPath string) ([]byte, error) {
 	obj, err := s.client.GetObject(ctx, s.archiveBucket, objectPath, minio.GetObjectOptions{})
 	if err != nil {
@@ -230,6 +279,40 @@ func (s *StorageService) GetPresignedURL(ctx context.Context, key string, expiry
 	return url.String(), nil
 }
 
+// MaxBatchPresign caps the keys signed by one BatchGetPresignedURLs call
+const MaxBatchPresign = 500
+
+// BatchGetPresignedURLs signs many objects at once. Keys may be object keys
+// or file URLs; the result is keyed by the requested value and leaves out
+// keys that could not be signed.
+func (s *StorageService) BatchGetPresignedURLs(ctx context.Context, keys []string, expiry time.Duration) (map[string]string, error) {
+	if len(keys) > MaxBatchPresign {
+		return nil, fmt.Errorf("too many keys: %d exceeds the limit of %d", len(keys), MaxBatchPresign)
+	}
+
+	urls := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if _, done := urls[key]; done || key == "" {
+			continue
+		}
+
+		objectKey := key
+		if strings.Contains(key, "://") {
+			if objectKey = extractKeyFromURL(key, s.bucketName); objectKey == "" {
+				continue
+			}
+		}
+
+		u, err := s.client.PresignedGetObject(ctx, s.bucketName, objectKey, expiry, nil)
+		if err != nil {
+			s.logger.Warn("Failed to presign object", "key", objectKey, "error", err)
+			continue
+		}
+		urls[key] = u.String()
+	}
+	return urls, nil
+}
+
 func (s *StorageService) GetPresignedUploadURL(ctx context.Context, filename, contentType, hash string, size int64) (uploadURL, publicURL, key string, isDuplicate bool, err error) {
 	if hash == "" {
 		return "", "", "", false, fmt.Errorf("hash is required for deduplication")
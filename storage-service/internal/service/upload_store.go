@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// activeUploadsKey scores every open upload by the time it expires
+const activeUploadsKey = "storage:uploads:active"
+
+const partFieldPrefix = "part:"
+
+// addPartScript records a part only while the upload still exists, so a part
+// racing an abort cannot resurrect it
+var addPartScript = goredis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[2], "expires_at", ARGV[3])
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+return 1
+`)
+
+// uploadState is a resumable upload as tracked in Redis
+type uploadState struct {
+	ID          string
+	Key         string
+	S3UploadID  string
+	Filename    string
+	ContentType string
+	TotalSize   int64
+	PartSize    int64
+	PartCount   int
+	ExpiresAt   time.Time
+	Parts       map[int]string // part number -> ETag
+}
+
+// partSizeOf is the exact size of part n; only the last one may be short
+func (u *uploadState) partSizeOf(n int) int64 {
+	if n == u.PartCount {
+		return u.TotalSize - u.PartSize*int64(u.PartCount-1)
+	}
+	return u.PartSize
+}
+
+// uploadStore keeps upload state in Redis. State outlives the upload's
+// expiry so the garbage collector can still abort it in object storage.
+type uploadStore struct {
+	client *redis.ClusterClient
+	ttl    time.Duration
+}
+
+// uploadKey hash-tags the ID so an upload's state stays in one cluster slot
+func uploadKey(id string) string {
+	return "storage:upload:{" + id + "}"
+}
+
+func (s *uploadStore) retention() time.Duration {
+	return 2 * s.ttl
+}
+
+func (s *uploadStore) create(ctx context.Context, u *uploadState) error {
+	key := uploadKey(u.ID)
+	_, err := s.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, key, map[string]interface{}{
+			"key":          u.Key,
+			"s3_upload_id": u.S3UploadID,
+			"filename":     u.Filename,
+			"content_type": u.ContentType,
+			"total_size":   u.TotalSize,
+			"part_size":    u.PartSize,
+			"part_count":   u.PartCount,
+			"expires_at":   u.ExpiresAt.Unix(),
+		})
+		pipe.Expire(ctx, key, s.retention())
+		pipe.ZAdd(ctx, activeUploadsKey, goredis.Z{Score: float64(u.ExpiresAt.Unix()), Member: u.ID})
+		return nil
+	})
+	return err
+}
+
+func (s *uploadStore) get(ctx context.Context, id string) (*uploadState, error) {
+	fields, err := s.client.HGetAll(ctx, uploadKey(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrUploadNotFound
+	}
+
+	u := &uploadState{
+		ID:          id,
+		Key:         fields["key"],
+		S3UploadID:  fields["s3_upload_id"],
+		Filename:    fields["filename"],
+		ContentType: fields["content_type"],
+		Parts:       make(map[int]string),
+	}
+	u.TotalSize, _ = strconv.ParseInt(fields["total_size"], 10, 64)
+	u.PartSize, _ = strconv.ParseInt(fields["part_size"], 10, 64)
+	u.PartCount, _ = strconv.Atoi(fields["part_count"])
+	expiresAt, _ := strconv.ParseInt(fields["expires_at"], 10, 64)
+	u.ExpiresAt = time.Unix(expiresAt, 0)
+
+	for field, etag := range fields {
+		if n, ok := strings.CutPrefix(field, partFieldPrefix); ok {
+			if partNumber, err := strconv.Atoi(n); err == nil {
+				u.Parts[partNumber] = etag
+			}
+		}
+	}
+	return u, nil
+}
+
+// addPart records an uploaded part and pushes the upload's expiry back
+func (s *uploadStore) addPart(ctx context.Context, id string, partNumber int, etag string) (time.Time, error) {
+	expiresAt := time.Now().Add(s.ttl)
+	ok, err := addPartScript.Run(ctx, s.client, []string{uploadKey(id)},
+		fmt.Sprintf("%s%d", partFieldPrefix, partNumber),
+		etag,
+		expiresAt.Unix(),
+		s.retention().Milliseconds(),
+	).Int()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if ok == 0 {
+		return time.Time{}, ErrUploadNotFound
+	}
+
+	if err := s.client.ZAdd(ctx, activeUploadsKey, goredis.Z{Score: float64(expiresAt.Unix()), Member: id}).Err(); err != nil {
+		return time.Time{}, err
+	}
+	return expiresAt, nil
+}
+
+func (s *uploadStore) delete(ctx context.Context, id string) error {
+	if err := s.client.Del(ctx, uploadKey(id)); err != nil {
+		return err
+	}
+	return s.client.ZRem(ctx, activeUploadsKey, id).Err()
+}
+
+// expired returns up to limit uploads that have gone unused past their expiry
+func (s *uploadStore) expired(ctx context.Context, now time.Time, limit int64) ([]string, error) {
+	return s.client.ZRangeByScore(ctx, activeUploadsKey, &goredis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.Unix(), 10),
+		Count: limit,
+	}).Result()
+}
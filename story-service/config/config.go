@@ -4,6 +4,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -18,8 +19,9 @@ type Config struct {
 	GRPCPort   string
 
 	// Kafka
-	KafkaBrokers []string
-	KafkaTopic   string
+	KafkaBrokers      []string
+	KafkaTopic        string
+	NotificationTopic string
 
 	// User Service (for author info)
 	UserServiceHost string
@@ -27,6 +29,8 @@ type Config struct {
 
 	// Auth & Rate Limiting
 	JWTSecret        string
+	JWKSURL          string    // Key set access tokens are signed by, published by user-service
+	LegacyHS256Until time.Time // Until then JWTSecret tokens are still accepted alongside
 	RedisURLs        []string
 	RedisPass        string
 	RateLimitEnabled bool
@@ -51,6 +55,8 @@ func Load() *Config {
 		corsOrigins[i] = strings.TrimSpace(corsOrigins[i])
 	}
 
+	legacyHS256Until, _ := time.Parse(time.RFC3339, getEnv("JWT_LEGACY_HS256_UNTIL", ""))
+
 	return &Config{
 		// MongoDB
 		MongoURI: getEnv("MONGO_URI", "mongodb://localhost:27017"),
@@ -60,8 +66,9 @@ func Load() *Config {
 		GRPCPort:   getEnv("GRPC_PORT", "9097"),
 
 		// Kafka
-		KafkaBrokers: strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
-		KafkaTopic:   getEnv("KAFKA_TOPIC", "story-events"),
+		KafkaBrokers:      strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
+		KafkaTopic:        getEnv("KAFKA_TOPIC", "story-events"),
+		NotificationTopic: getEnv("NOTIFICATION_TOPIC", "notifications_events"),
 
 		// User Service
 		UserServiceHost: getEnv("USER_SERVICE_HOST", "localhost"),
@@ -69,6 +76,8 @@ func Load() *Config {
 
 		// Auth & Rate limiting
 		JWTSecret:          getEnv("JWT_SECRET", "very-secret-key"),
+		JWKSURL:            getEnv("JWKS_URL", ""),
+		LegacyHS256Until:   legacyHS256Until,
 		RedisURLs:          strings.Split(getEnv("REDIS_URL", "localhost:6379"), ","),
 		RedisPass:          getEnv("REDIS_PASS", ""),
 		RateLimitEnabled:   rateLimitEnabled,
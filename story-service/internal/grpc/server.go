@@ -43,6 +43,7 @@ func (s *Server) CreateStory(ctx context.Context, req *storypb.CreateStoryReques
 		Privacy:        models.PrivacySettingType(req.Privacy),
 		AllowedViewers: req.AllowedViewers,
 		BlockedViewers: req.BlockedViewers,
+		Overlays:       fromProtoOverlays(req.Overlays),
 	}
 
 	story, err := s.storyService.CreateStory(ctx, userID, author, serviceReq)
@@ -190,13 +191,13 @@ func (s *Server) GetStoryViewers(ctx context.Context, req *storypb.GetStoryViewe
 		return nil, err
 	}
 
-	viewers, err := s.storyService.GetStoryViewers(ctx, storyID, userID)
+	page, err := s.storyService.GetStoryViewers(ctx, storyID, userID, int(req.Limit), int(req.Offset))
 	if err != nil {
 		return nil, err
 	}
 
-	protoViewers := make([]*storypb.StoryViewer, 0, len(viewers))
-	for _, v := range viewers {
+	protoViewers := make([]*storypb.StoryViewer, 0, len(page.Viewers))
+	for _, v := range page.Viewers {
 		protoViewers = append(protoViewers, &storypb.StoryViewer{
 			User: &storypb.Author{
 				Id:       v.User.ID.Hex(),
@@ -209,7 +210,42 @@ func (s *Server) GetStoryViewers(ctx context.Context, req *storypb.GetStoryViewe
 		})
 	}
 
-	return &storypb.StoryViewersResponse{Viewers: protoViewers}, nil
+	return &storypb.StoryViewersResponse{
+		Viewers:     protoViewers,
+		UniqueViews: page.UniqueViews,
+		HasMore:     page.HasMore,
+	}, nil
+}
+
+// ListHighlightCovers returns the highlights on a user's profile as the
+// viewer sees them; an empty viewer is signed out
+func (s *Server) ListHighlightCovers(ctx context.Context, req *storypb.ListHighlightCoversRequest) (*storypb.ListHighlightCoversResponse, error) {
+	ownerID, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	var viewerID primitive.ObjectID
+	if req.ViewerId != "" {
+		if viewerID, err = primitive.ObjectIDFromHex(req.ViewerId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid viewer id")
+		}
+	}
+
+	covers, err := s.storyService.ListHighlightCovers(ctx, ownerID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	protoCovers := make([]*storypb.HighlightCover, 0, len(covers))
+	for _, c := range covers {
+		protoCovers = append(protoCovers, &storypb.HighlightCover{
+			Id:         c.ID,
+			Title:      c.Title,
+			CoverUrl:   c.CoverURL,
+			StoryCount: int32(c.StoryCount),
+		})
+	}
+	return &storypb.ListHighlightCoversResponse{Highlights: protoCovers}, nil
 }
 
 func toProtoStory(s *models.Story) *storypb.Story {
@@ -237,9 +273,78 @@ func toProtoStory(s *models.Story) *storypb.Story {
 		Privacy:        string(s.Privacy),
 		AllowedViewers: allowedViewers,
 		BlockedViewers: blockedViewers,
+		Overlays:       toProtoOverlays(s.Overlays),
 		ViewCount:      int32(s.ViewCount),
 		ReactionCount:  int32(s.ReactionCount),
 		CreatedAt:      timestamppb.New(s.CreatedAt),
 		ExpiresAt:      timestamppb.New(s.ExpiresAt),
 	}
 }
+
+func toProtoOverlays(overlays []models.StoryOverlay) []*storypb.StoryOverlay {
+	protoOverlays := make([]*storypb.StoryOverlay, 0, len(overlays))
+	for _, o := range overlays {
+		p := &storypb.StoryOverlay{
+			Type:      string(o.Type),
+			X:         o.X,
+			Y:         o.Y,
+			Scale:     o.Scale,
+			Rotation:  o.Rotation,
+			Text:      o.Text,
+			Color:     o.Color,
+			Style:     o.Style,
+			StickerId: o.StickerID,
+			Username:  o.Username,
+			Url:       o.URL,
+		}
+		if o.UserID != nil {
+			p.UserId = o.UserID.Hex()
+		}
+		if o.Music != nil {
+			p.Music = &storypb.StoryMusic{
+				TrackId:    o.Music.TrackID,
+				Title:      o.Music.Title,
+				Artist:     o.Music.Artist,
+				StartMs:    int32(o.Music.StartMs),
+				DurationMs: int32(o.Music.DurationMs),
+			}
+		}
+		protoOverlays = append(protoOverlays, p)
+	}
+	return protoOverlays
+}
+
+// fromProtoOverlays converts overlays sent over gRPC. A mention with a
+// malformed user_id is kept without one, so validation rejects it.
+func fromProtoOverlays(protoOverlays []*storypb.StoryOverlay) []models.StoryOverlay {
+	overlays := make([]models.StoryOverlay, 0, len(protoOverlays))
+	for _, p := range protoOverlays {
+		o := models.StoryOverlay{
+			Type:      models.StoryOverlayType(p.Type),
+			X:         p.X,
+			Y:         p.Y,
+			Scale:     p.Scale,
+			Rotation:  p.Rotation,
+			Text:      p.Text,
+			Color:     p.Color,
+			Style:     p.Style,
+			StickerID: p.StickerId,
+			Username:  p.Username,
+			URL:       p.Url,
+		}
+		if id, err := primitive.ObjectIDFromHex(p.UserId); err == nil {
+			o.UserID = &id
+		}
+		if p.Music != nil {
+			o.Music = &models.StoryMusic{
+				TrackID:    p.Music.TrackId,
+				Title:      p.Music.Title,
+				Artist:     p.Music.Artist,
+				StartMs:    int(p.Music.StartMs),
+				DurationMs: int(p.Music.DurationMs),
+			}
+		}
+		overlays = append(overlays, o)
+	}
+	return overlays
+}
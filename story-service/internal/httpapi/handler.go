@@ -12,6 +12,7 @@ import (
 	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
 	"github.com/MuhibNayem/connectify-v2/story-service/internal/metrics"
 	"github.com/MuhibNayem/connectify-v2/story-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/story-service/internal/validation"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -35,52 +36,75 @@ func NewStoryHandler(storyService StoryService, userClient userpb.UserServiceCli
 	}
 }
 
-func (h *StoryHandler) RegisterRoutes(router *gin.Engine, auth gin.HandlerFunc) {
+func (h *StoryHandler) RegisterRoutes(router *gin.Engine, auth gin.HandlerFunc, rateLimits *middleware.SlidingWindowLimiter) {
 	api := router.Group("/api")
 	stories := api.Group("/stories")
 	stories.Use(auth)
 	{
 		stories.POST("",
-			middleware.StrictRateLimiter(0.2, 5, "stories:create", h.rateLimitObserver), // 12 per min
+			rateLimits.StrictRateLimiter(0.2, 5, "stories:create"), // 12 per min
 			h.CreateStory,
 		)
 		stories.GET("/feed",
-			middleware.StrictRateLimiter(2, 10, "stories:feed", h.rateLimitObserver), // 120 per min
+			rateLimits.StrictRateLimiter(2, 10, "stories:feed"), // 120 per min
 			h.GetStoriesFeed,
 		)
 		stories.GET("/user/:id",
-			middleware.StrictRateLimiter(1, 8, "stories:user", h.rateLimitObserver), // 60 per min
+			rateLimits.StrictRateLimiter(1, 8, "stories:user"), // 60 per min
 			h.GetUserStories,
 		)
 		stories.GET("/:id",
-			middleware.StrictRateLimiter(3, 15, "stories:view", h.rateLimitObserver), // 180 per min
+			rateLimits.StrictRateLimiter(3, 15, "stories:view"), // 180 per min
 			h.GetStory,
 		)
 		stories.DELETE("/:id",
-			middleware.StrictRateLimiter(0.3, 3, "stories:delete", h.rateLimitObserver), // 18 per min
+			rateLimits.StrictRateLimiter(0.3, 3, "stories:delete"), // 18 per min
 			h.DeleteStory,
 		)
 		stories.POST("/:id/view",
-			middleware.StrictRateLimiter(5, 20, "stories:track_view", h.rateLimitObserver), // 300 per min
+			rateLimits.StrictRateLimiter(5, 20, "stories:track_view"), // 300 per min
 			h.RecordView,
 		)
 		stories.POST("/:id/react",
-			middleware.StrictRateLimiter(1, 10, "stories:react", h.rateLimitObserver), // 60 per min
+			rateLimits.StrictRateLimiter(1, 10, "stories:react"), // 60 per min
 			h.ReactToStory,
 		)
 		stories.GET("/:id/viewers",
-			middleware.StrictRateLimiter(0.5, 5, "stories:viewers", h.rateLimitObserver), // 30 per min
+			rateLimits.StrictRateLimiter(0.5, 5, "stories:viewers"), // 30 per min
 			h.GetStoryViewers,
 		)
+
+		// Highlights
+		stories.GET("/user/:id/highlights",
+			rateLimits.StrictRateLimiter(1, 8, "stories:highlights"), // 60 per min
+			h.ListHighlights,
+		)
+		stories.POST("/highlights",
+			rateLimits.StrictRateLimiter(0.2, 5, "stories:highlights:write"), // 12 per min
+			h.CreateHighlight,
+		)
+		stories.GET("/highlights/:id",
+			rateLimits.StrictRateLimiter(3, 15, "stories:highlights:view"), // 180 per min
+			h.GetHighlight,
+		)
+		stories.PATCH("/highlights/:id",
+			rateLimits.StrictRateLimiter(0.2, 5, "stories:highlights:write"), // 12 per min
+			h.UpdateHighlight,
+		)
+		stories.DELETE("/highlights/:id",
+			rateLimits.StrictRateLimiter(0.2, 5, "stories:highlights:write"), // 12 per min
+			h.DeleteHighlight,
+		)
 	}
 }
 
 type createStoryRequest struct {
-	MediaURL       string   `json:"media_url"`
-	MediaType      string   `json:"media_type"`
-	Privacy        string   `json:"privacy"`
-	AllowedViewers []string `json:"allowed_viewers"`
-	BlockedViewers []string `json:"blocked_viewers"`
+	MediaURL       string                `json:"media_url"`
+	MediaType      string                `json:"media_type"`
+	Privacy        string                `json:"privacy"`
+	AllowedViewers []string              `json:"allowed_viewers"`
+	BlockedViewers []string              `json:"blocked_viewers"`
+	Overlays       []models.StoryOverlay `json:"overlays"`
 }
 
 func (h *StoryHandler) CreateStory(c *gin.Context) {
@@ -114,11 +138,12 @@ func (h *StoryHandler) CreateStory(c *gin.Context) {
 		Privacy:        models.PrivacySettingType(req.Privacy),
 		AllowedViewers: req.AllowedViewers,
 		BlockedViewers: req.BlockedViewers,
+		Overlays:       req.Overlays,
 	}
 
 	story, err := h.storyService.CreateStory(c.Request.Context(), userID, author, serviceReq)
 	if err != nil {
-		if strings.Contains(err.Error(), "validation") {
+		if strings.Contains(err.Error(), "validation") || isOverlayError(err) {
 			RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
 		} else {
 			RespondWithError(c, http.StatusInternalServerError, "Failed to create story", ErrCodeInternalError)
@@ -299,15 +324,16 @@ func (h *StoryHandler) GetStoryViewers(c *gin.Context) {
 		return
 	}
 
-	viewers, err := h.storyService.GetStoryViewers(c.Request.Context(), storyID, userID)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	page, err := h.storyService.GetStoryViewers(c.Request.Context(), storyID, userID, limit, offset)
 	if err != nil {
 		respondWithError(c, http.StatusForbidden, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"viewers": viewers,
-	})
+	c.JSON(http.StatusOK, page)
 }
 
 func (h *StoryHandler) userIDFromContext(c *gin.Context) (primitive.ObjectID, error) {
@@ -328,6 +354,13 @@ func (h *StoryHandler) userIDFromContext(c *gin.Context) (primitive.ObjectID, er
 
 var errUnauthorized = errors.New("authentication required")
 
+// isOverlayError reports whether err rejects the overlays of a new story
+func isOverlayError(err error) bool {
+	return errors.Is(err, validation.ErrInvalidOverlay) ||
+		errors.Is(err, validation.ErrTooManyOverlays) ||
+		errors.Is(err, validation.ErrTooManyMentions)
+}
+
 func parseObjectIDs(values []string) []primitive.ObjectID {
 	results := make([]primitive.ObjectID, 0, len(values))
 	for _, v := range values {
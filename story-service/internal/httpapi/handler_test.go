@@ -63,9 +63,46 @@ func (m *MockStoryService) GetUserStories(ctx context.Context, userID primitive.
 	return args.Get(0).([]models.Story), args.Error(1)
 }
 
-func (m *MockStoryService) GetStoryViewers(ctx context.Context, storyID, userID primitive.ObjectID) ([]models.StoryViewerResponse, error) {
-	args := m.Called(ctx, storyID, userID)
-	return args.Get(0).([]models.StoryViewerResponse), args.Error(1)
+func (m *MockStoryService) GetStoryViewers(ctx context.Context, storyID, userID primitive.ObjectID, limit, offset int) (*models.StoryViewersPage, error) {
+	args := m.Called(ctx, storyID, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StoryViewersPage), args.Error(1)
+}
+
+func (m *MockStoryService) CreateHighlight(ctx context.Context, userID primitive.ObjectID, req service.HighlightRequest) (*models.StoryHighlight, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StoryHighlight), args.Error(1)
+}
+
+func (m *MockStoryService) UpdateHighlight(ctx context.Context, highlightID, userID primitive.ObjectID, req service.UpdateHighlightRequest) (*models.StoryHighlight, error) {
+	args := m.Called(ctx, highlightID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StoryHighlight), args.Error(1)
+}
+
+func (m *MockStoryService) DeleteHighlight(ctx context.Context, highlightID, userID primitive.ObjectID) error {
+	args := m.Called(ctx, highlightID, userID)
+	return args.Error(0)
+}
+
+func (m *MockStoryService) GetHighlight(ctx context.Context, highlightID, viewerID primitive.ObjectID) (*models.StoryHighlightDetail, error) {
+	args := m.Called(ctx, highlightID, viewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StoryHighlightDetail), args.Error(1)
+}
+
+func (m *MockStoryService) ListHighlightCovers(ctx context.Context, ownerID, viewerID primitive.ObjectID) ([]models.StoryHighlightCover, error) {
+	args := m.Called(ctx, ownerID, viewerID)
+	return args.Get(0).([]models.StoryHighlightCover), args.Error(1)
 }
 
 func TestStoryHandler_CreateStory_Success(t *testing.T) {
@@ -157,9 +194,9 @@ func TestStoryHandler_CreateStory_ValidationError(t *testing.T) {
 	var response ErrorResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, "Missing required fields", response.Error)
-	assert.Equal(t, ErrCodeValidation, response.Code)
-	assert.NotEmpty(t, response.Details)
+	assert.Equal(t, "Missing required fields", response.Error.Message)
+	assert.Equal(t, ErrCodeValidation, response.Error.Code)
+	assert.NotEmpty(t, response.Error.Details)
 }
 
 func TestStoryHandler_GetStory_Success(t *testing.T) {
@@ -227,8 +264,8 @@ func TestStoryHandler_GetStory_Unauthorized(t *testing.T) {
 	var response ErrorResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, "Authentication required", response.Error)
-	assert.Equal(t, ErrCodeUnauthorized, response.Code)
+	assert.Equal(t, "Authentication required", response.Error.Message)
+	assert.Equal(t, ErrCodeUnauthorized, response.Error.Code)
 }
 
 func TestStoryHandler_GetStory_NotFound(t *testing.T) {
@@ -261,8 +298,80 @@ func TestStoryHandler_GetStory_NotFound(t *testing.T) {
 	var response ErrorResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, "Story not found or access denied", response.Error)
-	assert.Equal(t, ErrCodeStoryNotFound, response.Code)
+	assert.Equal(t, "Story not found or access denied", response.Error.Message)
+	assert.Equal(t, ErrCodeStoryNotFound, response.Error.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestStoryHandler_GetStoryViewers_Paginated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockStoryService)
+	handler := NewStoryHandler(mockService, nil, nil)
+
+	userID := primitive.NewObjectID()
+	storyID := primitive.NewObjectID()
+
+	expectedPage := &models.StoryViewersPage{
+		Viewers:     []models.StoryViewerResponse{{User: models.UserShortResponse{Username: "viewer"}}},
+		UniqueViews: 12,
+		HasMore:     true,
+	}
+	mockService.On("GetStoryViewers", mock.Anything, storyID, userID, 20, 40).Return(expectedPage, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.New()
+
+	// Mock authentication middleware
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.Hex())
+		c.Next()
+	})
+
+	router.GET("/stories/:id/viewers", handler.GetStoryViewers)
+
+	req := httptest.NewRequest("GET", "/stories/"+storyID.Hex()+"/viewers?limit=20&offset=40", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.StoryViewersPage
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Viewers, 1)
+	assert.Equal(t, int64(12), response.UniqueViews)
+	assert.True(t, response.HasMore)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestStoryHandler_GetStoryViewers_NotAuthor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockStoryService)
+	handler := NewStoryHandler(mockService, nil, nil)
+
+	userID := primitive.NewObjectID()
+	storyID := primitive.NewObjectID()
+
+	mockService.On("GetStoryViewers", mock.Anything, storyID, userID, 50, 0).Return(nil, errors.New("unauthorized: only author can view viewers"))
+
+	w := httptest.NewRecorder()
+	router := gin.New()
+
+	// Mock authentication middleware
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.Hex())
+		c.Next()
+	})
+
+	router.GET("/stories/:id/viewers", handler.GetStoryViewers)
+
+	req := httptest.NewRequest("GET", "/stories/"+storyID.Hex()+"/viewers", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
 
 	mockService.AssertExpectations(t)
 }
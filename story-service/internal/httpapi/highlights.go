@@ -0,0 +1,167 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/MuhibNayem/connectify-v2/story-service/internal/service"
+	"github.com/MuhibNayem/connectify-v2/story-service/internal/validation"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type createHighlightRequest struct {
+	Title        string   `json:"title"`
+	StoryIDs     []string `json:"story_ids"`
+	CoverStoryID string   `json:"cover_story_id"`
+}
+
+type updateHighlightRequest struct {
+	Title          *string  `json:"title"`
+	CoverStoryID   string   `json:"cover_story_id"`
+	AddStoryIDs    []string `json:"add_story_ids"`
+	RemoveStoryIDs []string `json:"remove_story_ids"`
+}
+
+// ListHighlights returns the highlight covers on a user's profile
+func (h *StoryHandler) ListHighlights(c *gin.Context) {
+	ownerID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		respondWithMessage(c, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	viewerID, err := h.userIDFromContext(c)
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	covers, err := h.storyService.ListHighlightCovers(c.Request.Context(), ownerID, viewerID)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, "Failed to load highlights", ErrCodeInternalError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"highlights": covers})
+}
+
+func (h *StoryHandler) CreateHighlight(c *gin.Context) {
+	var req createHighlightRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid request format", ErrCodeValidation)
+		return
+	}
+
+	userID, err := h.userIDFromContext(c)
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	highlight, err := h.storyService.CreateHighlight(c.Request.Context(), userID, service.HighlightRequest{
+		Title:        req.Title,
+		StoryIDs:     req.StoryIDs,
+		CoverStoryID: req.CoverStoryID,
+	})
+	if err != nil {
+		respondHighlightError(c, err)
+		return
+	}
+
+	RespondWithSuccess(c, http.StatusCreated, "Highlight created successfully", highlight)
+}
+
+// GetHighlight returns a highlight with the stories the viewer may see
+func (h *StoryHandler) GetHighlight(c *gin.Context) {
+	highlightID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid highlight ID format", ErrCodeValidation)
+		return
+	}
+
+	viewerID, err := h.userIDFromContext(c)
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	highlight, err := h.storyService.GetHighlight(c.Request.Context(), highlightID, viewerID)
+	if err != nil {
+		respondHighlightError(c, err)
+		return
+	}
+
+	RespondWithData(c, http.StatusOK, highlight)
+}
+
+func (h *StoryHandler) UpdateHighlight(c *gin.Context) {
+	highlightID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid highlight ID format", ErrCodeValidation)
+		return
+	}
+
+	var req updateHighlightRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid request format", ErrCodeValidation)
+		return
+	}
+
+	userID, err := h.userIDFromContext(c)
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	highlight, err := h.storyService.UpdateHighlight(c.Request.Context(), highlightID, userID, service.UpdateHighlightRequest{
+		Title:          req.Title,
+		CoverStoryID:   req.CoverStoryID,
+		AddStoryIDs:    req.AddStoryIDs,
+		RemoveStoryIDs: req.RemoveStoryIDs,
+	})
+	if err != nil {
+		respondHighlightError(c, err)
+		return
+	}
+
+	RespondWithSuccess(c, http.StatusOK, "Highlight updated successfully", highlight)
+}
+
+func (h *StoryHandler) DeleteHighlight(c *gin.Context) {
+	highlightID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid highlight ID format", ErrCodeValidation)
+		return
+	}
+
+	userID, err := h.userIDFromContext(c)
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	if err := h.storyService.DeleteHighlight(c.Request.Context(), highlightID, userID); err != nil {
+		respondHighlightError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondHighlightError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrHighlightNotFound):
+		RespondWithError(c, http.StatusNotFound, "Highlight not found", ErrCodeHighlightNotFound)
+	case errors.Is(err, service.ErrHighlightStoryNotOwned),
+		errors.Is(err, service.ErrCoverNotInHighlight),
+		errors.Is(err, service.ErrTooManyHighlights),
+		errors.Is(err, validation.ErrHighlightTitleRequired),
+		errors.Is(err, validation.ErrHighlightTitleTooLong),
+		errors.Is(err, validation.ErrHighlightEmpty),
+		errors.Is(err, validation.ErrTooManyHighlightStories):
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+	default:
+		RespondWithError(c, http.StatusInternalServerError, "Failed to process highlight", ErrCodeInternalError)
+	}
+}
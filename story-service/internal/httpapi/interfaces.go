@@ -17,5 +17,10 @@ type StoryService interface {
 	GetUserStories(ctx context.Context, userID primitive.ObjectID) ([]models.Story, error)
 	RecordView(ctx context.Context, storyID, viewerID primitive.ObjectID) error
 	ReactToStory(ctx context.Context, storyID, userID primitive.ObjectID, reactionType string) error
-	GetStoryViewers(ctx context.Context, storyID, userID primitive.ObjectID) ([]models.StoryViewerResponse, error)
+	GetStoryViewers(ctx context.Context, storyID, userID primitive.ObjectID, limit, offset int) (*models.StoryViewersPage, error)
+	CreateHighlight(ctx context.Context, userID primitive.ObjectID, req service.HighlightRequest) (*models.StoryHighlight, error)
+	UpdateHighlight(ctx context.Context, highlightID, userID primitive.ObjectID, req service.UpdateHighlightRequest) (*models.StoryHighlight, error)
+	DeleteHighlight(ctx context.Context, highlightID, userID primitive.ObjectID) error
+	GetHighlight(ctx context.Context, highlightID, viewerID primitive.ObjectID) (*models.StoryHighlightDetail, error)
+	ListHighlightCovers(ctx context.Context, ownerID, viewerID primitive.ObjectID) ([]models.StoryHighlightCover, error)
 }
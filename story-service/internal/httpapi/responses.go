@@ -3,17 +3,14 @@ package httpapi
 import (
 	"net/http"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
 	"github.com/gin-gonic/gin"
 )
 
-// ErrorResponse represents a standardized error response
-type ErrorResponse struct {
-	Error   string            `json:"error"`
-	Code    string            `json:"code,omitempty"`
-	Details map[string]string `json:"details,omitempty"`
-}
+// ErrorResponse is the standard error envelope shared by all services
+type ErrorResponse = apperrors.Response
 
-// SuccessResponse represents a standardized success response  
+// SuccessResponse represents a standardized success response
 type SuccessResponse struct {
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
@@ -21,37 +18,31 @@ type SuccessResponse struct {
 
 // Standard error codes for story service
 const (
-	ErrCodeValidation      = "VALIDATION_ERROR"
-	ErrCodeUnauthorized    = "UNAUTHORIZED"
-	ErrCodeStoryNotFound   = "STORY_NOT_FOUND"
-	ErrCodeInvalidReaction = "INVALID_REACTION_TYPE"
-	ErrCodeInvalidPrivacy  = "INVALID_PRIVACY_SETTING"
-	ErrCodeMediaRequired   = "MEDIA_REQUIRED"
-	ErrCodeRateLimited     = "RATE_LIMITED"
-	ErrCodeInternalError   = "INTERNAL_ERROR"
-	ErrCodeForbidden       = "FORBIDDEN"
+	ErrCodeValidation        = apperrors.CodeValidation
+	ErrCodeUnauthorized      = apperrors.CodeUnauthorized
+	ErrCodeStoryNotFound     = "STORY_NOT_FOUND"
+	ErrCodeHighlightNotFound = "HIGHLIGHT_NOT_FOUND"
+	ErrCodeInvalidReaction   = "INVALID_REACTION_TYPE"
+	ErrCodeInvalidPrivacy    = "INVALID_PRIVACY_SETTING"
+	ErrCodeMediaRequired     = "MEDIA_REQUIRED"
+	ErrCodeRateLimited       = apperrors.CodeRateLimited
+	ErrCodeInternalError     = apperrors.CodeInternal
+	ErrCodeForbidden         = apperrors.CodeForbidden
 )
 
-// RespondWithError sends a standardized error response
+// RespondWithError sends the standard error envelope. Without a code the
+// one for the status is used.
 func RespondWithError(c *gin.Context, statusCode int, message string, code ...string) {
-	errorCode := ErrCodeInternalError
+	errorCode := ""
 	if len(code) > 0 {
 		errorCode = code[0]
 	}
-	
-	c.JSON(statusCode, ErrorResponse{
-		Error: message,
-		Code:  errorCode,
-	})
+	apperrors.Write(c, statusCode, errorCode, message, nil)
 }
 
 // RespondWithValidationError sends a validation error with details
 func RespondWithValidationError(c *gin.Context, message string, details map[string]string) {
-	c.JSON(http.StatusBadRequest, ErrorResponse{
-		Error:   message,
-		Code:    ErrCodeValidation,
-		Details: details,
-	})
+	apperrors.Write(c, http.StatusBadRequest, ErrCodeValidation, message, details)
 }
 
 // RespondWithSuccess sends a standardized success response
@@ -59,11 +50,11 @@ func RespondWithSuccess(c *gin.Context, statusCode int, message string, data ...
 	response := SuccessResponse{
 		Message: message,
 	}
-	
+
 	if len(data) > 0 {
 		response.Data = data[0]
 	}
-	
+
 	c.JSON(statusCode, response)
 }
 
@@ -79,4 +70,4 @@ func respondWithError(c *gin.Context, status int, err error) {
 
 func respondWithMessage(c *gin.Context, status int, msg string) {
 	RespondWithError(c, status, msg, ErrCodeValidation)
-}
\ No newline at end of file
+}
@@ -19,27 +19,31 @@ func BuildRouter(cfg *config.Config, handler *StoryHandler, redisClient *redis.C
 		AllowOrigins:     cfg.CORSAllowedOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		ExposeHeaders:    []string{"Content-Length", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
 	router.Use(cors.New(corsCfg))
 
+	// Limits are counted in Redis so they hold across replicas
+	rateLimits := middleware.NewSlidingWindowLimiter(redisClient.GetClient(), handler.rateLimitObserver)
+
 	// Global rate limiter
-	router.Use(middleware.RateLimiter(
+	router.Use(rateLimits.RateLimiter(
 		cfg.RateLimitEnabled,
 		cfg.RateLimitLimit,
 		cfg.RateLimitBurst,
 		"stories:global",
-		handler.rateLimitObserver,
 	))
 
 	authMiddleware := middleware.AuthMiddleware(
 		cfg.JWTSecret,
 		redisClient.GetClient(),
 		middleware.WithFailClosedResponse(http.StatusServiceUnavailable, "authentication temporarily unavailable"),
+		middleware.WithJWKS(cfg.JWKSURL),
+		middleware.WithLegacyHS256Until(cfg.LegacyHS256Until),
 	)
 
-	handler.RegisterRoutes(router, authMiddleware)
+	handler.RegisterRoutes(router, authMiddleware, rateLimits)
 	return router
 }
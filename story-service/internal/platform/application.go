@@ -9,9 +9,13 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
+	sharedkafka "github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
 	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/redis"
+	sharedresilience "github.com/MuhibNayem/connectify-v2/shared-entity/resilience"
 	"github.com/MuhibNayem/connectify-v2/story-service/config"
 	storygrpc "github.com/MuhibNayem/connectify-v2/story-service/internal/grpc"
 	"github.com/MuhibNayem/connectify-v2/story-service/internal/httpapi"
@@ -23,7 +27,6 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type Application struct {
@@ -31,6 +34,7 @@ type Application struct {
 	mongoClient *mongo.Client
 	grpcServer  *grpc.Server
 	producer    *producer.StoryProducer
+	notifier    *producer.NotificationProducer
 	httpServer  *http.Server
 	redisClient *redis.ClusterClient
 	userConn    *grpc.ClientConn
@@ -44,6 +48,12 @@ type Application struct {
 
 	// gRPC Server
 	grpcHandler *storygrpc.Server
+
+	// Hides stories whose media moderation flagged
+	moderationVerdicts *sharedkafka.ModerationVerdictConsumer
+	// Erases the stories of deleted accounts
+	deletions      *sharedkafka.UserDeletionParticipant
+	consumerCancel context.CancelFunc
 }
 
 func NewApplication(cfg *config.Config) *Application {
@@ -85,6 +95,7 @@ func (a *Application) Bootstrap() error {
 
 	// Initialize Kafka producer
 	a.producer = producer.NewStoryProducer(a.cfg.KafkaBrokers, a.cfg.KafkaTopic)
+	a.notifier = producer.NewNotificationProducer(a.cfg.KafkaBrokers, a.cfg.NotificationTopic)
 	slog.Info("Kafka producer initialized")
 
 	// Initialize repositories
@@ -124,10 +135,19 @@ func (a *Application) Bootstrap() error {
 		slog.Default(),
 		a.redisClient,
 	)
+	a.storyService.SetNotificationPublisher(a.notifier)
+
+	a.moderationVerdicts = sharedkafka.NewModerationVerdictConsumer(
+		a.cfg.KafkaBrokers,
+		"story-service-moderation",
+		a.storyService.ApplyModerationVerdict,
+	)
+	a.deletions = sharedkafka.NewUserDeletionParticipant(a.cfg.KafkaBrokers, models.ErasureServiceStories, a.storyService.DeleteUserData)
 
 	// Update gRPC handler
 	a.grpcHandler = storygrpc.NewServer(a.storyService)
 	a.grpcHandler.Register(a.grpcServer)
+	dataexport.NewServer(models.ErasureServiceStories, a.storyService.ExportUserData).Register(a.grpcServer)
 
 	httpHandler := httpapi.NewStoryHandler(a.storyService, a.userClient, businessMetrics)
 	router := httpapi.BuildRouter(a.cfg, httpHandler, a.redisClient)
@@ -143,6 +163,13 @@ func (a *Application) Bootstrap() error {
 func (a *Application) Run() error {
 	errCh := make(chan error, 2)
 
+	if a.moderationVerdicts != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		a.consumerCancel = cancel
+		go a.moderationVerdicts.Run(ctx)
+		go a.deletions.Run(ctx)
+	}
+
 	if a.httpServer != nil {
 		go func() {
 			slog.Info("Story service HTTP server listening", "port", a.cfg.ServerPort)
@@ -196,6 +223,18 @@ func (a *Application) Shutdown() {
 		slog.Info("gRPC server stopped")
 	}
 
+	if a.consumerCancel != nil {
+		a.consumerCancel()
+	}
+	if err := a.moderationVerdicts.Close(); err != nil {
+		slog.Error("Error closing moderation verdict consumer", "error", err)
+	}
+	if a.deletions != nil {
+		if err := a.deletions.Close(); err != nil {
+			slog.Error("Error closing user deletion participant", "error", err)
+		}
+	}
+
 	// Close Kafka producer
 	if a.producer != nil {
 		if err := a.producer.Close(); err != nil {
@@ -204,6 +243,11 @@ func (a *Application) Shutdown() {
 			slog.Info("Kafka producer closed")
 		}
 	}
+	if a.notifier != nil {
+		if err := a.notifier.Close(); err != nil {
+			slog.Error("Error closing notification producer", "error", err)
+		}
+	}
 
 	// Disconnect MongoDB
 	if a.mongoClient != nil {
@@ -264,13 +308,11 @@ func (a *Application) initRedis() error {
 }
 
 func (a *Application) initUserClient() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	conn, err := grpc.DialContext(ctx,
-		net.JoinHostPort(a.cfg.UserServiceHost, a.cfg.UserServicePort),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	// Profile lookups are reads, so they are retried when user-service is briefly unavailable
+	conn, err := sharedresilience.NewGRPCClient("user-service").
+		WithTimeout(3*time.Second).
+		WithRetry(sharedresilience.DefaultRetryPolicy(), sharedresilience.ReadOnlyMethods...).
+		Dial(net.JoinHostPort(a.cfg.UserServiceHost, a.cfg.UserServicePort))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,57 @@
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/segmentio/kafka-go"
+)
+
+// NotificationProducer hands notifications to the messaging service, which
+// stores and delivers them
+type NotificationProducer struct {
+	writer *kafka.Writer
+}
+
+func NewNotificationProducer(brokers []string, topic string) *NotificationProducer {
+	return &NotificationProducer{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Topic:    topic,
+			Balancer: &kafka.LeastBytes{},
+		},
+	}
+}
+
+func (p *NotificationProducer) PublishNotification(ctx context.Context, notification *models.Notification) error {
+	event := events.NotificationCreatedEvent{
+		ID:          notification.ID,
+		RecipientID: notification.RecipientID,
+		SenderID:    notification.SenderID,
+		Type:        string(notification.Type),
+		TargetID:    notification.TargetID,
+		TargetType:  notification.TargetType,
+		Content:     notification.Content,
+		Data:        notification.Data,
+		Read:        notification.Read,
+		CreatedAt:   notification.CreatedAt,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(notification.RecipientID.Hex()), // Partition by recipient
+		Value: payload,
+		Time:  time.Now(),
+	})
+}
+
+func (p *NotificationProducer) Close() error {
+	return p.writer.Close()
+}
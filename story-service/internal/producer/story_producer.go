@@ -21,13 +21,19 @@ type StoryBroadcaster interface {
 
 // Event types
 type StoryCreatedEvent struct {
-	StoryID   string            `json:"story_id"`
-	UserID    string            `json:"user_id"`
-	Author    models.PostAuthor `json:"author"`
-	MediaURL  string            `json:"media_url"`
-	MediaType string            `json:"media_type"`
-	CreatedAt time.Time         `json:"created_at"`
-	ExpiresAt time.Time         `json:"expires_at"`
+	StoryID   string                    `json:"story_id"`
+	UserID    string                    `json:"user_id"`
+	Author    models.PostAuthor         `json:"author"`
+	MediaURL  string                    `json:"media_url"`
+	MediaType string                    `json:"media_type"`
+	Privacy   models.PrivacySettingType `json:"privacy,omitempty"`
+	Overlays  []models.StoryOverlay     `json:"overlays,omitempty"`
+	CreatedAt time.Time                 `json:"created_at"`
+	ExpiresAt time.Time                 `json:"expires_at"`
+
+	// Recipients narrows the live update to these users; nil means the
+	// author's friends
+	Recipients []string `json:"-"`
 }
 
 type StoryDeletedEvent struct {
@@ -66,7 +72,7 @@ func NewStoryProducer(brokers []string, topic string) *StoryProducer {
 	return &StoryProducer{writer: writer}
 }
 
-func (p *StoryProducer) publish(ctx context.Context, eventType string, payload interface{}) {
+func (p *StoryProducer) publish(ctx context.Context, eventType string, payload interface{}, recipients ...string) {
 	payloadData, err := json.Marshal(payload)
 	if err != nil {
 		log.Printf("Failed to marshal story event payload: %v", err)
@@ -74,8 +80,9 @@ func (p *StoryProducer) publish(ctx context.Context, eventType string, payload i
 	}
 
 	wsEvent := models.WebSocketEvent{
-		Type: eventType,
-		Data: payloadData,
+		Type:       eventType,
+		Data:       payloadData,
+		Recipients: recipients,
 	}
 
 	data, err := json.Marshal(wsEvent)
@@ -94,7 +101,7 @@ func (p *StoryProducer) publish(ctx context.Context, eventType string, payload i
 }
 
 func (p *StoryProducer) PublishStoryCreated(ctx context.Context, event StoryCreatedEvent) {
-	p.publish(ctx, "STORY_CREATED", event)
+	p.publish(ctx, "STORY_CREATED", event, event.Recipients...)
 }
 
 func (p *StoryProducer) PublishStoryDeleted(ctx context.Context, event StoryDeletedEvent) {
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreateHighlight stores the highlight and marks its stories as highlighted,
+// which keeps them past expiry
+func (r *StoryRepository) CreateHighlight(ctx context.Context, highlight *models.StoryHighlight) (*models.StoryHighlight, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	highlight.CreatedAt = time.Now()
+	highlight.UpdatedAt = highlight.CreatedAt
+
+	res, err := r.highlightsCollection.InsertOne(ctx, highlight)
+	if err != nil {
+		return nil, err
+	}
+	highlight.ID = res.InsertedID.(primitive.ObjectID)
+
+	if err := r.markHighlighted(ctx, highlight.ID, highlight.UserID, highlight.StoryIDs); err != nil {
+		return nil, err
+	}
+	return highlight, nil
+}
+
+func (r *StoryRepository) GetHighlightByID(ctx context.Context, id primitive.ObjectID) (*models.StoryHighlight, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var highlight models.StoryHighlight
+	if err := r.highlightsCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&highlight); err != nil {
+		return nil, err
+	}
+	return &highlight, nil
+}
+
+// ListUserHighlights returns the user's highlights, newest first
+func (r *StoryRepository) ListUserHighlights(ctx context.Context, userID primitive.ObjectID) ([]models.StoryHighlight, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cur, err := r.highlightsCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	highlights := []models.StoryHighlight{}
+	if err := cur.All(ctx, &highlights); err != nil {
+		return nil, err
+	}
+	return highlights, nil
+}
+
+// CountUserHighlights returns how many highlights the user has
+func (r *StoryRepository) CountUserHighlights(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	return r.highlightsCollection.CountDocuments(ctx, bson.M{"user_id": userID})
+}
+
+// UpdateHighlight saves the highlight's title, cover and stories, marking
+// the added stories as highlighted and unmarking the removed ones
+func (r *StoryRepository) UpdateHighlight(ctx context.Context, highlight *models.StoryHighlight, added, removed []primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	highlight.UpdatedAt = time.Now()
+	res, err := r.highlightsCollection.UpdateOne(ctx,
+		bson.M{"_id": highlight.ID, "user_id": highlight.UserID},
+		bson.M{"$set": bson.M{
+			"title":          highlight.Title,
+			"cover_story_id": highlight.CoverStoryID,
+			"story_ids":      highlight.StoryIDs,
+			"updated_at":     highlight.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	if err := r.markHighlighted(ctx, highlight.ID, highlight.UserID, added); err != nil {
+		return err
+	}
+	return r.unmarkHighlighted(ctx, highlight.ID, removed)
+}
+
+// DeleteHighlight removes the user's highlight. Its stories that are in no
+// other highlight become subject to expiry again.
+func (r *StoryRepository) DeleteHighlight(ctx context.Context, id, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := r.highlightsCollection.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	_, err = r.collection.UpdateMany(ctx,
+		bson.M{"highlight_ids": id},
+		bson.M{"$pull": bson.M{"highlight_ids": id}},
+	)
+	return err
+}
+
+// GetStoriesByIDs returns the stories with the given IDs that still exist,
+// expired or not
+func (r *StoryRepository) GetStoriesByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Story, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if len(ids) == 0 {
+		return []models.Story{}, nil
+	}
+
+	cur, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	stories := []models.Story{}
+	if err := cur.All(ctx, &stories); err != nil {
+		return nil, err
+	}
+	return stories, nil
+}
+
+func (r *StoryRepository) markHighlighted(ctx context.Context, highlightID, userID primitive.ObjectID, storyIDs []primitive.ObjectID) error {
+	if len(storyIDs) == 0 {
+		return nil
+	}
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": storyIDs}, "user_id": userID},
+		bson.M{"$addToSet": bson.M{"highlight_ids": highlightID}},
+	)
+	return err
+}
+
+func (r *StoryRepository) unmarkHighlighted(ctx context.Context, highlightID primitive.ObjectID, storyIDs []primitive.ObjectID) error {
+	if len(storyIDs) == 0 {
+		return nil
+	}
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": storyIDs}},
+		bson.M{"$pull": bson.M{"highlight_ids": highlightID}},
+	)
+	return err
+}
@@ -12,9 +12,10 @@ import (
 )
 
 type StoryRepository struct {
-	collection          *mongo.Collection
-	viewsCollection     *mongo.Collection
-	reactionsCollection *mongo.Collection
+	collection           *mongo.Collection
+	viewsCollection      *mongo.Collection
+	reactionsCollection  *mongo.Collection
+	highlightsCollection *mongo.Collection
 }
 
 func NewStoryRepository(db *mongo.Database) *StoryRepository {
@@ -25,6 +26,7 @@ func NewStoryRepository(db *mongo.Database) *StoryRepository {
 			{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index()},
 			{Keys: bson.D{{Key: "created_at", Value: -1}}, Options: options.Index()},
 			{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index()},
+			{Keys: bson.D{{Key: "media_url", Value: 1}}, Options: options.Index()},
 		},
 	)
 	if err != nil {
@@ -36,7 +38,9 @@ func NewStoryRepository(db *mongo.Database) *StoryRepository {
 		context.Background(),
 		[]mongo.IndexModel{
 			{Keys: bson.D{{Key: "story_id", Value: 1}, {Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
-			{Keys: bson.D{{Key: "story_id", Value: 1}}, Options: options.Index()},
+			{Keys: bson.D{{Key: "story_id", Value: 1}, {Key: "viewed_at", Value: -1}}, Options: options.Index()},
+			// View receipts expire with their story
+			{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
 		},
 	)
 	if err != nil {
@@ -55,10 +59,23 @@ func NewStoryRepository(db *mongo.Database) *StoryRepository {
 		panic("Failed to create story_reactions indexes: " + err.Error())
 	}
 
+	// Create indexes for story_highlights
+	_, err = db.Collection("story_highlights").Indexes().CreateMany(
+		context.Background(),
+		[]mongo.IndexModel{
+			{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index()},
+			{Keys: bson.D{{Key: "story_ids", Value: 1}}, Options: options.Index()},
+		},
+	)
+	if err != nil {
+		panic("Failed to create story_highlights indexes: " + err.Error())
+	}
+
 	return &StoryRepository{
-		collection:          db.Collection("stories"),
-		viewsCollection:     db.Collection("story_views"),
-		reactionsCollection: db.Collection("story_reactions"),
+		collection:           db.Collection("stories"),
+		viewsCollection:      db.Collection("story_views"),
+		reactionsCollection:  db.Collection("story_reactions"),
+		highlightsCollection: db.Collection("story_highlights"),
 	}
 }
 
@@ -101,6 +118,12 @@ func (r *StoryRepository) DeleteStory(ctx context.Context, id primitive.ObjectID
 	// Delete associated reactions
 	_, _ = r.reactionsCollection.DeleteMany(ctx, bson.M{"story_id": id})
 
+	// Take it out of the author's highlights
+	_, _ = r.highlightsCollection.UpdateMany(ctx,
+		bson.M{"user_id": userID, "story_ids": id},
+		bson.M{"$pull": bson.M{"story_ids": id}},
+	)
+
 	// Delete the story
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
 	return err
@@ -130,26 +153,79 @@ func (r *StoryRepository) GetUserStories(ctx context.Context, userID primitive.O
 	return stories, nil
 }
 
-func (r *StoryRepository) GetActiveStoryAuthors(ctx context.Context, viewerID primitive.ObjectID, userIDs []primitive.ObjectID, limit, offset int) ([]primitive.ObjectID, error) {
+// ListUserStories returns all of the user's stories that have not been swept
+// yet, expired or not, newest first
+func (r *StoryRepository) ListUserStories(ctx context.Context, userID primitive.ObjectID) ([]models.Story, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	now := time.Now()
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cur, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
 
-	// Privacy Filter
-	privacyMatch := bson.M{
+	stories := []models.Story{}
+	if err := cur.All(ctx, &stories); err != nil {
+		return nil, err
+	}
+	return stories, nil
+}
+
+// GetUserReactions returns the reactions the user left on other stories,
+// newest first
+func (r *StoryRepository) GetUserReactions(ctx context.Context, userID primitive.ObjectID) ([]models.StoryReaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cur, err := r.reactionsCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	reactions := []models.StoryReaction{}
+	if err := cur.All(ctx, &reactions); err != nil {
+		return nil, err
+	}
+	return reactions, nil
+}
+
+// audienceMatch matches the stories viewerID may see besides their own.
+// closeFriendOf lists the authors who have the viewer on their close friends
+// list.
+func audienceMatch(viewerID primitive.ObjectID, closeFriendOf []primitive.ObjectID) bson.M {
+	audiences := []bson.M{
+		{"privacy": bson.M{"$in": []string{string(models.PrivacySettingPublic), string(models.PrivacySettingFriends)}}},
+		{"privacy": models.PrivacySettingCustom, "allowed_viewers": viewerID},
+		{"privacy": models.PrivacySettingFriendsExcept, "blocked_viewers": bson.M{"$ne": viewerID}},
+	}
+	// Avoid empty $in arrays for viewers on nobody's list
+	if len(closeFriendOf) > 0 {
+		audiences = append(audiences, bson.M{"privacy": models.PrivacySettingCloseFriends, "user_id": bson.M{"$in": closeFriendOf}})
+	}
+	return bson.M{
 		"$or": []bson.M{
 			{"user_id": viewerID},
 			{
-				"user_id": bson.M{"$ne": viewerID},
-				"$or": []bson.M{
-					{"privacy": bson.M{"$in": []string{string(models.PrivacySettingPublic), string(models.PrivacySettingFriends)}}},
-					{"privacy": models.PrivacySettingCustom, "allowed_viewers": viewerID},
-					{"privacy": models.PrivacySettingFriendsExcept, "blocked_viewers": bson.M{"$ne": viewerID}},
-				},
+				"user_id":           bson.M{"$ne": viewerID},
+				"moderation_status": bson.M{"$nin": models.HiddenModerationVerdicts},
+				"$or":               audiences,
 			},
 		},
 	}
+}
+
+func (r *StoryRepository) GetActiveStoryAuthors(ctx context.Context, viewerID primitive.ObjectID, userIDs, closeFriendOf []primitive.ObjectID, limit, offset int) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+
+	// Privacy Filter
+	privacyMatch := audienceMatch(viewerID, closeFriendOf)
 
 	pipeline := mongo.Pipeline{
 		{{Key: "$match", Value: bson.M{
@@ -188,25 +264,13 @@ func (r *StoryRepository) GetActiveStoryAuthors(ctx context.Context, viewerID pr
 	return authors, nil
 }
 
-func (r *StoryRepository) GetStoriesForUsers(ctx context.Context, viewerID primitive.ObjectID, authorIDs []primitive.ObjectID) ([]models.Story, error) {
+func (r *StoryRepository) GetStoriesForUsers(ctx context.Context, viewerID primitive.ObjectID, authorIDs, closeFriendOf []primitive.ObjectID) ([]models.Story, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	now := time.Now()
 
-	privacyMatch := bson.M{
-		"$or": []bson.M{
-			{"user_id": viewerID},
-			{
-				"user_id": bson.M{"$ne": viewerID},
-				"$or": []bson.M{
-					{"privacy": bson.M{"$in": []string{string(models.PrivacySettingPublic), string(models.PrivacySettingFriends)}}},
-					{"privacy": models.PrivacySettingCustom, "allowed_viewers": viewerID},
-					{"privacy": models.PrivacySettingFriendsExcept, "blocked_viewers": bson.M{"$ne": viewerID}},
-				},
-			},
-		},
-	}
+	privacyMatch := audienceMatch(viewerID, closeFriendOf)
 
 	filter := bson.M{
 		"$and": []bson.M{
@@ -231,29 +295,48 @@ func (r *StoryRepository) GetStoriesForUsers(ctx context.Context, viewerID primi
 	return stories, nil
 }
 
-func (r *StoryRepository) AddViewer(ctx context.Context, storyID primitive.ObjectID, viewerID primitive.ObjectID) error {
+// AddViewer records a view receipt and reports whether it is the viewer's
+// first view of the story; repeat views leave the receipt and count alone.
+// SetModerationStatus flags the stories showing mediaURL. A rejection is
+// final, so it is never downgraded to a hold. It returns how many changed.
+func (r *StoryRepository) SetModerationStatus(ctx context.Context, mediaURL string, verdict models.ModerationVerdict) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := r.collection.UpdateMany(ctx,
+		bson.M{"media_url": mediaURL, "moderation_status": bson.M{"$ne": models.ModerationRejected}},
+		bson.M{"$set": bson.M{"moderation_status": verdict}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+func (r *StoryRepository) AddViewer(ctx context.Context, storyID primitive.ObjectID, viewerID primitive.ObjectID, expiresAt time.Time) (bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	view := models.StoryView{
-		ID:       primitive.NewObjectID(),
-		StoryID:  storyID,
-		UserID:   viewerID,
-		ViewedAt: time.Now(),
+		ID:        primitive.NewObjectID(),
+		StoryID:   storyID,
+		UserID:    viewerID,
+		ViewedAt:  time.Now(),
+		ExpiresAt: expiresAt,
 	}
 	_, err := r.viewsCollection.InsertOne(ctx, view)
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
-			return nil // Already viewed
+			return false, nil // Already viewed
 		}
-		return err
+		return false, err
 	}
 
 	// Increment view count
 	filter := bson.M{"_id": storyID}
 	update := bson.M{"$inc": bson.M{"view_count": 1}}
 	_, err = r.collection.UpdateOne(ctx, filter, update)
-	return err
+	return true, err
 }
 
 func (r *StoryRepository) AddReaction(ctx context.Context, storyID primitive.ObjectID, reaction models.StoryReaction) error {
@@ -273,12 +356,16 @@ func (r *StoryRepository) AddReaction(ctx context.Context, storyID primitive.Obj
 	return err
 }
 
-func (r *StoryRepository) GetStoryViewersWithReactions(ctx context.Context, storyID primitive.ObjectID) ([]models.StoryViewerResponse, error) {
+// GetStoryViewersWithReactions returns a page of viewers, most recent first
+func (r *StoryRepository) GetStoryViewersWithReactions(ctx context.Context, storyID primitive.ObjectID, limit, offset int) ([]models.StoryViewerResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	pipeline := mongo.Pipeline{
 		{{Key: "$match", Value: bson.M{"story_id": storyID}}},
+		{{Key: "$sort", Value: bson.D{{Key: "viewed_at", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$skip", Value: offset}},
+		{{Key: "$limit", Value: limit}},
 		{{Key: "$lookup", Value: bson.M{
 			"from":         "users",
 			"localField":   "user_id",
@@ -307,7 +394,7 @@ func (r *StoryRepository) GetStoryViewersWithReactions(ctx context.Context, stor
 			"path":                       "$user_reaction",
 			"preserveNullAndEmptyArrays": true,
 		}}},
-		{{Key: "$sort", Value: bson.M{"viewed_at": -1}}},
+		{{Key: "$sort", Value: bson.D{{Key: "viewed_at", Value: -1}, {Key: "_id", Value: -1}}}},
 		{{Key: "$project", Value: bson.M{
 			"_id": 0,
 			"user": bson.M{
@@ -334,13 +421,17 @@ func (r *StoryRepository) GetStoryViewersWithReactions(ctx context.Context, stor
 	return results, nil
 }
 
-// GetExpiredStories returns stories that have expired
+// GetExpiredStories returns stories that have expired, leaving out those
+// kept in a highlight
 func (r *StoryRepository) GetExpiredStories(ctx context.Context) ([]models.Story, error) {
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
 	now := time.Now()
-	filter := bson.M{"expires_at": bson.M{"$lte": now}}
+	filter := bson.M{
+		"expires_at":      bson.M{"$lte": now},
+		"highlight_ids.0": bson.M{"$exists": false},
+	}
 	opts := options.Find().SetLimit(100)
 
 	cur, err := r.collection.Find(ctx, filter, opts)
@@ -371,3 +462,35 @@ func (r *StoryRepository) DeleteStories(ctx context.Context, ids []primitive.Obj
 	_, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
 	return err
 }
+
+// DeleteUserData removes every story the user posted, with its views,
+// reactions and highlights, and the views and reactions they left on others'
+// stories
+func (r *StoryRepository) DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	ids, err := r.collection.Distinct(ctx, "_id", bson.M{"user_id": userID})
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	filters := []struct {
+		coll   *mongo.Collection
+		filter bson.M
+	}{
+		{r.viewsCollection, bson.M{"$or": []bson.M{{"story_id": bson.M{"$in": ids}}, {"user_id": userID}}}},
+		{r.reactionsCollection, bson.M{"$or": []bson.M{{"story_id": bson.M{"$in": ids}}, {"user_id": userID}}}},
+		{r.highlightsCollection, bson.M{"user_id": userID}},
+		{r.collection, bson.M{"user_id": userID}},
+	}
+	for _, f := range filters {
+		res, err := f.coll.DeleteMany(ctx, f.filter)
+		if err != nil {
+			return total, err
+		}
+		total += res.DeletedCount
+	}
+	return total, nil
+}
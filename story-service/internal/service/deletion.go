@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MuhibNayem/connectify-v2/story-service/internal/producer"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeleteUserData erases a deleted account's stories and the views and
+// reactions it left, telling viewers its live stories are gone
+func (s *StoryService) DeleteUserData(ctx context.Context, userID string) (int64, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, errors.New("invalid user ID")
+	}
+
+	active, err := s.storyRepo.GetUserStories(ctx, uID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load active stories: %w", err)
+	}
+	deleted, err := s.storyRepo.DeleteUserData(ctx, uID)
+	if err != nil {
+		return deleted, fmt.Errorf("failed to delete stories: %w", err)
+	}
+
+	if s.broadcaster != nil {
+		for _, story := range active {
+			s.broadcaster.PublishStoryDeleted(ctx, producer.StoryDeletedEvent{
+				StoryID: story.ID.Hex(),
+				UserID:  userID,
+			})
+		}
+	}
+	s.logger.Info("Story data deleted", "user_id", userID, "items", deleted)
+	return deleted, nil
+}
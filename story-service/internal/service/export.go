@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
+	exportpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/export/v1"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// exportedStoryReaction is a StoryReaction with stable JSON field names
+type exportedStoryReaction struct {
+	StoryID   primitive.ObjectID `json:"story_id"`
+	Type      string             `json:"type"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// ExportUserData collects the user's stories that are still stored, their
+// highlights and the reactions they left on others' stories for their data
+// export
+func (s *StoryService) ExportUserData(ctx context.Context, userID string) ([]*exportpb.ExportSection, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	stories, err := s.storyRepo.ListUserStories(ctx, uID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export stories: %w", err)
+	}
+	reactions, err := s.storyRepo.GetUserReactions(ctx, uID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export story reactions: %w", err)
+	}
+	highlights, err := s.storyRepo.ListUserHighlights(ctx, uID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export story highlights: %w", err)
+	}
+
+	var media []string
+	for _, st := range stories {
+		if st.MediaURL != "" {
+			media = append(media, st.MediaURL)
+		}
+	}
+	exported := make([]exportedStoryReaction, 0, len(reactions))
+	for _, r := range reactions {
+		exported = append(exported, exportedStoryReaction{
+			StoryID:   r.StoryID,
+			Type:      r.Type,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+
+	var b dataexport.Builder
+	b.Add("stories", stories, media...)
+	b.Add("story_reactions", exported)
+	b.Add("story_highlights", highlights)
+	return b.Sections()
+}
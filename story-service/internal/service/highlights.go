@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/MuhibNayem/connectify-v2/story-service/internal/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrHighlightNotFound      = errors.New("highlight not found")
+	ErrHighlightStoryNotOwned = errors.New("highlights can only hold your own stories")
+	ErrCoverNotInHighlight    = errors.New("cover story must be in the highlight")
+	ErrTooManyHighlights      = errors.New("too many highlights (max 100)")
+)
+
+const maxHighlightsPerUser = 100
+
+// HighlightRequest creates a highlight from the author's stories, live or
+// expired
+type HighlightRequest struct {
+	Title        string
+	StoryIDs     []string
+	CoverStoryID string // Defaults to the first story
+}
+
+// UpdateHighlightRequest changes a highlight; unset fields are left alone
+type UpdateHighlightRequest struct {
+	Title          *string
+	CoverStoryID   string
+	AddStoryIDs    []string
+	RemoveStoryIDs []string
+}
+
+// CreateHighlight pins the given stories of userID to their profile. The
+// stories and their media are kept past expiry for as long as they are in a
+// highlight.
+func (s *StoryService) CreateHighlight(ctx context.Context, userID primitive.ObjectID, req HighlightRequest) (*models.StoryHighlight, error) {
+	storyIDs, err := highlightStoryIDs(req.StoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateHighlight(req.Title, len(storyIDs)); err != nil {
+		return nil, err
+	}
+
+	count, err := s.storyRepo.CountUserHighlights(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxHighlightsPerUser {
+		return nil, ErrTooManyHighlights
+	}
+	if err := s.checkOwnStories(ctx, userID, storyIDs); err != nil {
+		return nil, err
+	}
+
+	highlight := &models.StoryHighlight{
+		UserID:       userID,
+		Title:        validation.SanitizeString(req.Title),
+		CoverStoryID: storyIDs[0],
+		StoryIDs:     storyIDs,
+	}
+	if err := setHighlightCover(highlight, req.CoverStoryID); err != nil {
+		return nil, err
+	}
+	return s.storyRepo.CreateHighlight(ctx, highlight)
+}
+
+// UpdateHighlight renames a highlight of userID, changes its cover, or adds
+// and removes stories. Removing the cover story moves the cover to the first
+// story left.
+func (s *StoryService) UpdateHighlight(ctx context.Context, highlightID, userID primitive.ObjectID, req UpdateHighlightRequest) (*models.StoryHighlight, error) {
+	highlight, err := s.storyRepo.GetHighlightByID(ctx, highlightID)
+	if err != nil || highlight.UserID != userID {
+		return nil, ErrHighlightNotFound
+	}
+
+	if req.Title != nil {
+		highlight.Title = validation.SanitizeString(*req.Title)
+	}
+
+	toRemove, err := highlightStoryIDs(req.RemoveStoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	var removed []primitive.ObjectID
+	highlight.StoryIDs = slices.DeleteFunc(highlight.StoryIDs, func(id primitive.ObjectID) bool {
+		if slices.Contains(toRemove, id) {
+			removed = append(removed, id)
+			return true
+		}
+		return false
+	})
+
+	toAdd, err := highlightStoryIDs(req.AddStoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	var added []primitive.ObjectID
+	for _, id := range toAdd {
+		if !slices.Contains(highlight.StoryIDs, id) {
+			added = append(added, id)
+		}
+	}
+	if err := s.checkOwnStories(ctx, userID, added); err != nil {
+		return nil, err
+	}
+	highlight.StoryIDs = append(highlight.StoryIDs, added...)
+
+	if err := validation.ValidateHighlight(highlight.Title, len(highlight.StoryIDs)); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(highlight.StoryIDs, highlight.CoverStoryID) {
+		highlight.CoverStoryID = highlight.StoryIDs[0]
+	}
+	if err := setHighlightCover(highlight, req.CoverStoryID); err != nil {
+		return nil, err
+	}
+
+	if err := s.storyRepo.UpdateHighlight(ctx, highlight, added, removed); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrHighlightNotFound
+		}
+		return nil, err
+	}
+	return highlight, nil
+}
+
+// DeleteHighlight removes a highlight of userID. Its expired stories that
+// are in no other highlight are swept with the other expired stories.
+func (s *StoryService) DeleteHighlight(ctx context.Context, highlightID, userID primitive.ObjectID) error {
+	err := s.storyRepo.DeleteHighlight(ctx, highlightID, userID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrHighlightNotFound
+	}
+	return err
+}
+
+// GetHighlight returns a highlight with the stories the viewer may see, in
+// order. A highlight with none of them is not found, except to its author.
+func (s *StoryService) GetHighlight(ctx context.Context, highlightID, viewerID primitive.ObjectID) (*models.StoryHighlightDetail, error) {
+	highlight, err := s.storyRepo.GetHighlightByID(ctx, highlightID)
+	if err != nil {
+		return nil, ErrHighlightNotFound
+	}
+	stories, err := s.storyRepo.GetStoriesByIDs(ctx, highlight.StoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := s.visibleHighlightStories(ctx, highlight.UserID, viewerID, stories)
+	detail := &models.StoryHighlightDetail{StoryHighlight: *highlight, Stories: []models.Story{}}
+	detail.StoryIDs = []primitive.ObjectID{}
+	for _, id := range highlight.StoryIDs {
+		if story, ok := visible[id]; ok {
+			detail.Stories = append(detail.Stories, *story)
+			detail.StoryIDs = append(detail.StoryIDs, id)
+		}
+	}
+	if len(detail.Stories) == 0 && viewerID != highlight.UserID {
+		return nil, ErrHighlightNotFound
+	}
+	return detail, nil
+}
+
+// ListHighlightCovers returns the highlights on ownerID's profile as
+// viewerID sees them, newest first. A zero viewerID stands for a signed-out
+// viewer, who only sees public stories. Highlights with no story the viewer
+// may see are left out, and a hidden cover story gives way to the first
+// visible one.
+func (s *StoryService) ListHighlightCovers(ctx context.Context, ownerID, viewerID primitive.ObjectID) ([]models.StoryHighlightCover, error) {
+	highlights, err := s.storyRepo.ListUserHighlights(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var storyIDs []primitive.ObjectID
+	for _, h := range highlights {
+		storyIDs = append(storyIDs, h.StoryIDs...)
+	}
+	stories, err := s.storyRepo.GetStoriesByIDs(ctx, storyIDs)
+	if err != nil {
+		return nil, err
+	}
+	visible := s.visibleHighlightStories(ctx, ownerID, viewerID, stories)
+
+	covers := make([]models.StoryHighlightCover, 0, len(highlights))
+	for _, h := range highlights {
+		cover := models.StoryHighlightCover{ID: h.ID.Hex(), Title: h.Title}
+		for _, id := range h.StoryIDs {
+			story, ok := visible[id]
+			if !ok {
+				continue
+			}
+			if cover.StoryCount == 0 || id == h.CoverStoryID {
+				cover.CoverURL = story.MediaURL
+			}
+			cover.StoryCount++
+		}
+		if cover.StoryCount == 0 && viewerID != ownerID {
+			continue
+		}
+		covers = append(covers, cover)
+	}
+	return covers, nil
+}
+
+// visibleHighlightStories returns the stories of ownerID that viewerID may
+// see by ID, ignoring expiry. The relationship is looked up once for all of
+// them; if it cannot be, none are visible.
+func (s *StoryService) visibleHighlightStories(ctx context.Context, ownerID, viewerID primitive.ObjectID, stories []models.Story) map[primitive.ObjectID]*models.Story {
+	visible := make(map[primitive.ObjectID]*models.Story, len(stories))
+	if viewerID == ownerID {
+		for i := range stories {
+			visible[stories[i].ID] = &stories[i]
+		}
+		return visible
+	}
+
+	var canView func(story *models.Story) bool
+	if viewerID.IsZero() {
+		canView = func(story *models.Story) bool { return story.Privacy == models.PrivacySettingPublic }
+	} else {
+		rel, err := s.getRelationship(ctx, viewerID, ownerID)
+		if err != nil {
+			s.logger.Warn("Failed to check relationship", "error", err)
+			return visible
+		}
+		canView = func(story *models.Story) bool { return storyVisibleTo(story, viewerID, rel) }
+	}
+
+	for i := range stories {
+		story := &stories[i]
+		if story.UserID == ownerID && !story.ModerationStatus.Flagged() && canView(story) {
+			visible[story.ID] = story
+		}
+	}
+	return visible
+}
+
+// checkOwnStories makes sure every one of the stories exists and is userID's
+func (s *StoryService) checkOwnStories(ctx context.Context, userID primitive.ObjectID, storyIDs []primitive.ObjectID) error {
+	if len(storyIDs) == 0 {
+		return nil
+	}
+	stories, err := s.storyRepo.GetStoriesByIDs(ctx, storyIDs)
+	if err != nil {
+		return err
+	}
+	if len(stories) != len(storyIDs) {
+		return ErrHighlightStoryNotOwned
+	}
+	for _, story := range stories {
+		if story.UserID != userID {
+			return ErrHighlightStoryNotOwned
+		}
+	}
+	return nil
+}
+
+// setHighlightCover makes coverStoryID the highlight's cover, if given
+func setHighlightCover(highlight *models.StoryHighlight, coverStoryID string) error {
+	if coverStoryID == "" {
+		return nil
+	}
+	id, err := primitive.ObjectIDFromHex(coverStoryID)
+	if err != nil || !slices.Contains(highlight.StoryIDs, id) {
+		return ErrCoverNotInHighlight
+	}
+	highlight.CoverStoryID = id
+	return nil
+}
+
+// highlightStoryIDs parses story IDs, dropping repeats but keeping order
+func highlightStoryIDs(hexIDs []string) ([]primitive.ObjectID, error) {
+	ids := make([]primitive.ObjectID, 0, len(hexIDs))
+	for _, hex := range hexIDs {
+		id, err := primitive.ObjectIDFromHex(hex)
+		if err != nil {
+			return nil, ErrHighlightStoryNotOwned
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
+	"github.com/MuhibNayem/connectify-v2/story-service/internal/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NotificationPublisher hands notifications to the notification pipeline
+type NotificationPublisher interface {
+	PublishNotification(ctx context.Context, notification *models.Notification) error
+}
+
+// SetNotificationPublisher enables notifications for users mentioned in stories
+func (s *StoryService) SetNotificationPublisher(notifications NotificationPublisher) {
+	s.notifications = notifications
+}
+
+// prepareOverlays tidies validated overlays and fills in the usernames of
+// mentioned users, returning them with the author's username. Mentions of
+// unknown users are rejected. If user-service cannot be reached the
+// usernames the client sent are kept.
+func (s *StoryService) prepareOverlays(ctx context.Context, authorID primitive.ObjectID, overlays []models.StoryOverlay) ([]models.StoryOverlay, string, error) {
+	if len(overlays) == 0 {
+		return nil, "", nil
+	}
+
+	prepared := make([]models.StoryOverlay, len(overlays))
+	var mentioned []primitive.ObjectID
+	for i, o := range overlays {
+		o.Text = validation.SanitizeString(o.Text)
+		o.Username = validation.SanitizeString(o.Username)
+		if o.Scale == 0 {
+			o.Scale = 1
+		}
+		if o.Type == models.StoryOverlayMention {
+			mentioned = append(mentioned, *o.UserID)
+		}
+		prepared[i] = o
+	}
+	if len(mentioned) == 0 {
+		return prepared, "", nil
+	}
+
+	users, err := s.lookupUsers(ctx, append(mentioned, authorID))
+	if err != nil {
+		s.logger.Warn("Failed to resolve story mentions", "author_id", authorID.Hex(), "error", err)
+		return prepared, "", nil
+	}
+	for i, o := range prepared {
+		if o.Type != models.StoryOverlayMention {
+			continue
+		}
+		user, ok := users[o.UserID.Hex()]
+		if !ok {
+			return nil, "", fmt.Errorf("%w %d: mentioned user not found", validation.ErrInvalidOverlay, i)
+		}
+		prepared[i].Username = user.Username
+	}
+
+	var authorName string
+	if author, ok := users[authorID.Hex()]; ok {
+		authorName = author.Username
+	}
+	return prepared, authorName, nil
+}
+
+// notifyMentions tells the users mentioned in the story that may view it.
+// Mentions only notify, so failures just log.
+func (s *StoryService) notifyMentions(ctx context.Context, story *models.Story, authorName string) {
+	if s.notifications == nil {
+		return
+	}
+
+	content := "You were mentioned in a story."
+	if authorName != "" {
+		content = fmt.Sprintf("%s mentioned you in their story.", authorName)
+	}
+
+	notified := make(map[primitive.ObjectID]bool)
+	for _, o := range story.Overlays {
+		if o.Type != models.StoryOverlayMention || o.UserID == nil {
+			continue
+		}
+		userID := *o.UserID
+		if userID == story.UserID || notified[userID] {
+			continue
+		}
+		notified[userID] = true
+		if !s.canViewStory(ctx, story, userID) {
+			continue
+		}
+
+		now := time.Now()
+		err := s.notifications.PublishNotification(ctx, &models.Notification{
+			ID:          primitive.NewObjectID(),
+			RecipientID: userID,
+			SenderID:    story.UserID,
+			Type:        models.NotificationTypeMention,
+			TargetID:    story.ID,
+			TargetType:  "story",
+			Content:     content,
+			Data:        map[string]interface{}{"media_url": story.MediaURL},
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+		if err != nil {
+			s.logger.Warn("Failed to notify story mention", "story_id", story.ID.Hex(), "user_id", userID.Hex(), "error", err)
+		}
+	}
+}
+
+// lookupUsers returns the users with the given IDs that exist, by ID
+func (s *StoryService) lookupUsers(ctx context.Context, ids []primitive.ObjectID) (map[string]*userpb.User, error) {
+	if s.userClient == nil || s.breaker == nil {
+		return nil, errors.New("user service unavailable")
+	}
+
+	hexIDs := make([]string, len(ids))
+	for i, id := range ids {
+		hexIDs[i] = id.Hex()
+	}
+	result, err := s.breaker.Execute(ctx, func() (interface{}, error) {
+		return s.userClient.GetUsers(ctx, &userpb.GetUsersRequest{UserIds: hexIDs})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[string]*userpb.User)
+	for _, u := range result.(*userpb.GetUsersResponse).Users {
+		users[u.Id] = u
+	}
+	return users, nil
+}
@@ -15,19 +15,42 @@ import (
 	"github.com/MuhibNayem/connectify-v2/story-service/internal/producer"
 	"github.com/MuhibNayem/connectify-v2/story-service/internal/resilience"
 	"github.com/MuhibNayem/connectify-v2/story-service/internal/validation"
+	goredis "github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
 )
 
 type StoryRepository interface {
 	CreateStory(ctx context.Context, story *models.Story) (*models.Story, error)
 	GetStoryByID(ctx context.Context, id primitive.ObjectID) (*models.Story, error)
 	DeleteStory(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error
-	GetActiveStoryAuthors(ctx context.Context, viewerID primitive.ObjectID, userIDs []primitive.ObjectID, limit, offset int) ([]primitive.ObjectID, error)
-	GetStoriesForUsers(ctx context.Context, viewerID primitive.ObjectID, authorIDs []primitive.ObjectID) ([]models.Story, error)
+	GetActiveStoryAuthors(ctx context.Context, viewerID primitive.ObjectID, userIDs, closeFriendOf []primitive.ObjectID, limit, offset int) ([]primitive.ObjectID, error)
+	GetStoriesForUsers(ctx context.Context, viewerID primitive.ObjectID, authorIDs, closeFriendOf []primitive.ObjectID) ([]models.Story, error)
 	GetUserStories(ctx context.Context, userID primitive.ObjectID) ([]models.Story, error)
-	AddViewer(ctx context.Context, storyID primitive.ObjectID, viewerID primitive.ObjectID) error
+	ListUserStories(ctx context.Context, userID primitive.ObjectID) ([]models.Story, error)
+	GetUserReactions(ctx context.Context, userID primitive.ObjectID) ([]models.StoryReaction, error)
+	AddViewer(ctx context.Context, storyID primitive.ObjectID, viewerID primitive.ObjectID, expiresAt time.Time) (bool, error)
 	AddReaction(ctx context.Context, storyID primitive.ObjectID, reaction models.StoryReaction) error
-	GetStoryViewersWithReactions(ctx context.Context, storyID primitive.ObjectID) ([]models.StoryViewerResponse, error)
+	GetStoryViewersWithReactions(ctx context.Context, storyID primitive.ObjectID, limit, offset int) ([]models.StoryViewerResponse, error)
+	SetModerationStatus(ctx context.Context, mediaURL string, verdict models.ModerationVerdict) (int64, error)
+	DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error)
+	GetStoriesByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Story, error)
+	CreateHighlight(ctx context.Context, highlight *models.StoryHighlight) (*models.StoryHighlight, error)
+	GetHighlightByID(ctx context.Context, id primitive.ObjectID) (*models.StoryHighlight, error)
+	ListUserHighlights(ctx context.Context, userID primitive.ObjectID) ([]models.StoryHighlight, error)
+	CountUserHighlights(ctx context.Context, userID primitive.ObjectID) (int64, error)
+	UpdateHighlight(ctx context.Context, highlight *models.StoryHighlight, added, removed []primitive.ObjectID) error
+	DeleteHighlight(ctx context.Context, id, userID primitive.ObjectID) error
+}
+
+const (
+	defaultViewersLimit = 50
+	maxViewersLimit     = 100
+)
+
+// uniqueViewsKey holds the HyperLogLog of a story's viewers
+func uniqueViewsKey(storyID primitive.ObjectID) string {
+	return "story:views:" + storyID.Hex()
 }
 
 type StoryService struct {
@@ -38,6 +61,8 @@ type StoryService struct {
 	metrics     *metrics.BusinessMetrics
 	logger      *slog.Logger
 	redisClient *redis.ClusterClient
+
+	notifications NotificationPublisher
 }
 
 func NewStoryService(
@@ -67,12 +92,25 @@ func (s *StoryService) CreateStory(ctx context.Context, userID primitive.ObjectI
 	if err := validation.ValidateCreateStoryRequest(req.MediaURL, req.MediaType, req.Privacy, req.AllowedViewers, req.BlockedViewers); err != nil {
 		return nil, err
 	}
+	if err := validation.ValidateOverlays(req.Overlays); err != nil {
+		return nil, err
+	}
+	overlays, authorName, err := s.prepareOverlays(ctx, userID, req.Overlays)
+	if err != nil {
+		return nil, err
+	}
 
 	privacy := req.Privacy
 	if privacy == "" {
 		privacy = models.PrivacySettingFriends
 	}
 
+	// Close friends stories follow the author's current list, so viewer
+	// lists are only kept for the other audiences
+	if privacy == models.PrivacySettingCloseFriends {
+		req.AllowedViewers, req.BlockedViewers = nil, nil
+	}
+
 	allowedViewers := make([]primitive.ObjectID, 0)
 	for _, id := range req.AllowedViewers {
 		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
@@ -95,6 +133,7 @@ func (s *StoryService) CreateStory(ctx context.Context, userID primitive.ObjectI
 		Privacy:        privacy,
 		AllowedViewers: allowedViewers,
 		BlockedViewers: blockedViewers,
+		Overlays:       overlays,
 	}
 
 	createdStory, err := s.storyRepo.CreateStory(ctx, story)
@@ -108,19 +147,46 @@ func (s *StoryService) CreateStory(ctx context.Context, userID primitive.ObjectI
 
 	if s.broadcaster != nil {
 		s.broadcaster.PublishStoryCreated(ctx, producer.StoryCreatedEvent{
-			StoryID:   createdStory.ID.Hex(),
-			UserID:    userID.Hex(),
-			Author:    author,
-			MediaURL:  createdStory.MediaURL,
-			MediaType: createdStory.MediaType,
-			CreatedAt: createdStory.CreatedAt,
-			ExpiresAt: createdStory.ExpiresAt,
+			StoryID:    createdStory.ID.Hex(),
+			UserID:     userID.Hex(),
+			Author:     author,
+			MediaURL:   createdStory.MediaURL,
+			MediaType:  createdStory.MediaType,
+			Privacy:    createdStory.Privacy,
+			Overlays:   createdStory.Overlays,
+			CreatedAt:  createdStory.CreatedAt,
+			ExpiresAt:  createdStory.ExpiresAt,
+			Recipients: s.storyRecipients(ctx, createdStory),
 		})
 	}
+	s.notifyMentions(ctx, createdStory, authorName)
 
 	return createdStory, nil
 }
 
+// storyRecipients returns who gets a live update of a new story when its
+// audience is narrower than the author's friends, or nil to let the hub send
+// it to all of them. If the close friends list cannot be read, only the
+// author is told.
+func (s *StoryService) storyRecipients(ctx context.Context, story *models.Story) []string {
+	recipients := []string{story.UserID.Hex()}
+	switch story.Privacy {
+	case models.PrivacySettingCustom:
+		for _, id := range story.AllowedViewers {
+			recipients = append(recipients, id.Hex())
+		}
+	case models.PrivacySettingCloseFriends:
+		ids, err := s.closeFriendIDs(ctx, story.UserID)
+		if err != nil {
+			s.logger.Warn("Failed to load close friends for story fan-out", "story_id", story.ID.Hex(), "error", err)
+		}
+		recipients = append(recipients, ids...)
+	default:
+		return nil
+	}
+	return recipients
+}
+
 func (s *StoryService) GetStory(ctx context.Context, storyID, viewerID primitive.ObjectID) (*models.Story, error) {
 	story, err := s.storyRepo.GetStoryByID(ctx, storyID)
 	if err != nil {
@@ -138,13 +204,21 @@ func (s *StoryService) canViewStory(ctx context.Context, story *models.Story, vi
 	if story.UserID == viewerID {
 		return true
 	}
+	if story.ModerationStatus.Flagged() {
+		return false
+	}
 
 	rel, err := s.getRelationship(ctx, viewerID, story.UserID)
 	if err != nil {
 		s.logger.Warn("Failed to check relationship", "error", err)
 		return false
 	}
+	return storyVisibleTo(story, viewerID, rel)
+}
 
+// storyVisibleTo reports whether the story's audience includes viewerID,
+// whose relationship with the author is rel
+func storyVisibleTo(story *models.Story, viewerID primitive.ObjectID, rel *userpb.CheckRelationshipResponse) bool {
 	if rel.IsBlockedByTarget {
 		return false
 	}
@@ -165,6 +239,10 @@ func (s *StoryService) canViewStory(ctx context.Context, story *models.Story, vi
 		}
 		return false
 
+	case models.PrivacySettingCloseFriends:
+		// The author's list as it is now, not when the story was posted
+		return rel.IsCloseFriend
+
 	case models.PrivacySettingFriendsExcept:
 		// Must be a friend AND not blocked
 		if !rel.IsFriend {
@@ -185,6 +263,22 @@ func (s *StoryService) canViewStory(ctx context.Context, story *models.Story, vi
 	}
 }
 
+// ApplyModerationVerdict hides the stories showing media that moderation
+// held or rejected
+func (s *StoryService) ApplyModerationVerdict(ctx context.Context, evt *models.MediaModerationEvent) error {
+	if !evt.Verdict.Flagged() {
+		return nil
+	}
+	flagged, err := s.storyRepo.SetModerationStatus(ctx, evt.URL, evt.Verdict)
+	if err != nil {
+		return err
+	}
+	if flagged > 0 {
+		s.logger.Info("Stories flagged by moderation", "key", evt.Key, "verdict", evt.Verdict, "stories", flagged)
+	}
+	return nil
+}
+
 func (s *StoryService) getRelationship(ctx context.Context, userID, targetID primitive.ObjectID) (*userpb.CheckRelationshipResponse, error) {
 	if s.userClient == nil || s.breaker == nil {
 		return nil, errors.New("user service unavailable")
@@ -224,6 +318,47 @@ func (s *StoryService) getRelationship(ctx context.Context, userID, targetID pri
 	return rel, nil
 }
 
+// closeFriendIDs returns userID's close friends list
+func (s *StoryService) closeFriendIDs(ctx context.Context, userID primitive.ObjectID) ([]string, error) {
+	if s.userClient == nil {
+		return nil, errors.New("user service unavailable")
+	}
+	return s.closeFriendLookup(ctx, userID, s.userClient.GetCloseFriendIDs)
+}
+
+// closeFriendOfIDs returns the users who have viewerID on their close
+// friends list. Without them the viewer sees no close friends stories.
+func (s *StoryService) closeFriendOfIDs(ctx context.Context, viewerID primitive.ObjectID) []primitive.ObjectID {
+	if s.userClient == nil {
+		return nil
+	}
+	ids, err := s.closeFriendLookup(ctx, viewerID, s.userClient.GetCloseFriendOfIDs)
+	if err != nil {
+		s.logger.Warn("Failed to load close friend audiences", "viewer_id", viewerID.Hex(), "error", err)
+		return nil
+	}
+	authors := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			authors = append(authors, oid)
+		}
+	}
+	return authors
+}
+
+func (s *StoryService) closeFriendLookup(ctx context.Context, userID primitive.ObjectID, lookup func(context.Context, *userpb.GetCloseFriendIDsRequest, ...grpc.CallOption) (*userpb.GetCloseFriendIDsResponse, error)) ([]string, error) {
+	if s.breaker == nil {
+		return nil, errors.New("user service unavailable")
+	}
+	result, err := s.breaker.Execute(ctx, func() (interface{}, error) {
+		return lookup(ctx, &userpb.GetCloseFriendIDsRequest{UserId: userID.Hex()})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*userpb.GetCloseFriendIDsResponse).UserIds, nil
+}
+
 func (s *StoryService) InvalidateRelationshipCache(ctx context.Context, userID, targetID string) error {
 	if s.redisClient == nil {
 		return nil
@@ -273,7 +408,8 @@ func (s *StoryService) GetStoriesFeed(ctx context.Context, viewerID primitive.Ob
 	userIDs[0] = viewerID
 	copy(userIDs[1:], friendIDs)
 
-	authorIDs, err := s.storyRepo.GetActiveStoryAuthors(ctx, viewerID, userIDs, limit, offset)
+	closeFriendOf := s.closeFriendOfIDs(ctx, viewerID)
+	authorIDs, err := s.storyRepo.GetActiveStoryAuthors(ctx, viewerID, userIDs, closeFriendOf, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -282,7 +418,7 @@ func (s *StoryService) GetStoriesFeed(ctx context.Context, viewerID primitive.Ob
 		return []models.Story{}, nil
 	}
 
-	return s.storyRepo.GetStoriesForUsers(ctx, viewerID, authorIDs)
+	return s.storyRepo.GetStoriesForUsers(ctx, viewerID, authorIDs, closeFriendOf)
 }
 
 func (s *StoryService) GetUserStories(ctx context.Context, userID primitive.ObjectID) ([]models.Story, error) {
@@ -295,7 +431,7 @@ func (s *StoryService) RecordView(ctx context.Context, storyID, viewerID primiti
 		return errors.New("story not found")
 	}
 
-	if !s.canViewStory(ctx, story, viewerID) {
+	if !story.ExpiresAt.After(time.Now()) || !s.canViewStory(ctx, story, viewerID) {
 		return errors.New("story not found")
 	}
 
@@ -303,14 +439,18 @@ func (s *StoryService) RecordView(ctx context.Context, storyID, viewerID primiti
 		return nil
 	}
 
-	if s.metrics != nil {
-		s.metrics.IncrementStoriesViewed()
-	}
-
-	err = s.storyRepo.AddViewer(ctx, storyID, viewerID)
+	firstView, err := s.storyRepo.AddViewer(ctx, storyID, viewerID, story.ExpiresAt)
 	if err != nil {
 		return err
 	}
+	s.countUniqueView(ctx, story, viewerID)
+	if !firstView {
+		return nil
+	}
+
+	if s.metrics != nil {
+		s.metrics.IncrementStoriesViewed()
+	}
 
 	if s.broadcaster != nil {
 		s.broadcaster.PublishStoryViewed(ctx, producer.StoryViewedEvent{
@@ -366,7 +506,40 @@ func (s *StoryService) ReactToStory(ctx context.Context, storyID, userID primiti
 	return nil
 }
 
-func (s *StoryService) GetStoryViewers(ctx context.Context, storyID, userID primitive.ObjectID) ([]models.StoryViewerResponse, error) {
+// countUniqueView adds the viewer to the story's HyperLogLog, which expires
+// together with the story
+func (s *StoryService) countUniqueView(ctx context.Context, story *models.Story, viewerID primitive.ObjectID) {
+	if s.redisClient == nil {
+		return
+	}
+
+	key := uniqueViewsKey(story.ID)
+	_, err := s.redisClient.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.PFAdd(ctx, key, viewerID.Hex())
+		pipe.ExpireAt(ctx, key, story.ExpiresAt)
+		return nil
+	})
+	if err != nil {
+		s.logger.Warn("Failed to count unique story view", "story_id", story.ID.Hex(), "error", err)
+	}
+}
+
+// uniqueViews estimates a story's distinct viewers, falling back to the
+// stored view count when Redis is unavailable
+func (s *StoryService) uniqueViews(ctx context.Context, story *models.Story) int64 {
+	if s.redisClient != nil {
+		count, err := s.redisClient.PFCount(ctx, uniqueViewsKey(story.ID)).Result()
+		if err == nil && count > 0 {
+			return count
+		}
+		if err != nil {
+			s.logger.Warn("Failed to read unique story views", "story_id", story.ID.Hex(), "error", err)
+		}
+	}
+	return int64(story.ViewCount)
+}
+
+func (s *StoryService) GetStoryViewers(ctx context.Context, storyID, userID primitive.ObjectID, limit, offset int) (*models.StoryViewersPage, error) {
 	story, err := s.storyRepo.GetStoryByID(ctx, storyID)
 	if err != nil {
 		return nil, err
@@ -375,11 +548,38 @@ func (s *StoryService) GetStoryViewers(ctx context.Context, storyID, userID prim
 		return nil, errors.New("unauthorized: only author can view viewers")
 	}
 
+	if limit <= 0 {
+		limit = defaultViewersLimit
+	}
+	if limit > maxViewersLimit {
+		limit = maxViewersLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
 	if s.metrics != nil {
 		s.metrics.IncrementViewersAccessed()
 	}
 
-	return s.storyRepo.GetStoryViewersWithReactions(ctx, storyID)
+	// Fetch one extra viewer to learn whether another page follows
+	viewers, err := s.storyRepo.GetStoryViewersWithReactions(ctx, storyID, limit+1, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.StoryViewersPage{
+		Viewers:     viewers,
+		UniqueViews: s.uniqueViews(ctx, story),
+	}
+	if len(viewers) > limit {
+		page.Viewers = viewers[:limit]
+		page.HasMore = true
+	}
+	if page.Viewers == nil {
+		page.Viewers = []models.StoryViewerResponse{}
+	}
+	return page, nil
 }
 
 type CreateStoryRequest struct {
@@ -388,4 +588,5 @@ type CreateStoryRequest struct {
 	Privacy        models.PrivacySettingType
 	AllowedViewers []string
 	BlockedViewers []string
+	Overlays       []models.StoryOverlay
 }
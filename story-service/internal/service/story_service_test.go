@@ -3,14 +3,20 @@ package service
 import (
 	"context"
 	"log/slog"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	userpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
 	"github.com/MuhibNayem/connectify-v2/story-service/internal/metrics"
 	"github.com/MuhibNayem/connectify-v2/story-service/internal/producer"
+	"github.com/MuhibNayem/connectify-v2/story-service/internal/resilience"
+	"github.com/MuhibNayem/connectify-v2/story-service/internal/validation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
 )
 
 type MockStoryRepository struct {
@@ -35,13 +41,13 @@ func (m *MockStoryRepository) DeleteStory(ctx context.Context, id primitive.Obje
 	return args.Error(0)
 }
 
-func (m *MockStoryRepository) GetActiveStoryAuthors(ctx context.Context, viewerID primitive.ObjectID, userIDs []primitive.ObjectID, limit, offset int) ([]primitive.ObjectID, error) {
-	args := m.Called(ctx, viewerID, userIDs, limit, offset)
+func (m *MockStoryRepository) GetActiveStoryAuthors(ctx context.Context, viewerID primitive.ObjectID, userIDs, closeFriendOf []primitive.ObjectID, limit, offset int) ([]primitive.ObjectID, error) {
+	args := m.Called(ctx, viewerID, userIDs, closeFriendOf, limit, offset)
 	return args.Get(0).([]primitive.ObjectID), args.Error(1)
 }
 
-func (m *MockStoryRepository) GetStoriesForUsers(ctx context.Context, viewerID primitive.ObjectID, authorIDs []primitive.ObjectID) ([]models.Story, error) {
-	args := m.Called(ctx, viewerID, authorIDs)
+func (m *MockStoryRepository) GetStoriesForUsers(ctx context.Context, viewerID primitive.ObjectID, authorIDs, closeFriendOf []primitive.ObjectID) ([]models.Story, error) {
+	args := m.Called(ctx, viewerID, authorIDs, closeFriendOf)
 	return args.Get(0).([]models.Story), args.Error(1)
 }
 
@@ -50,9 +56,19 @@ func (m *MockStoryRepository) GetUserStories(ctx context.Context, userID primiti
 	return args.Get(0).([]models.Story), args.Error(1)
 }
 
-func (m *MockStoryRepository) AddViewer(ctx context.Context, storyID primitive.ObjectID, viewerID primitive.ObjectID) error {
-	args := m.Called(ctx, storyID, viewerID)
-	return args.Error(0)
+func (m *MockStoryRepository) ListUserStories(ctx context.Context, userID primitive.ObjectID) ([]models.Story, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]models.Story), args.Error(1)
+}
+
+func (m *MockStoryRepository) GetUserReactions(ctx context.Context, userID primitive.ObjectID) ([]models.StoryReaction, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]models.StoryReaction), args.Error(1)
+}
+
+func (m *MockStoryRepository) AddViewer(ctx context.Context, storyID primitive.ObjectID, viewerID primitive.ObjectID, expiresAt time.Time) (bool, error) {
+	args := m.Called(ctx, storyID, viewerID, expiresAt)
+	return args.Bool(0), args.Error(1)
 }
 
 func (m *MockStoryRepository) AddReaction(ctx context.Context, storyID primitive.ObjectID, reaction models.StoryReaction) error {
@@ -60,11 +76,83 @@ func (m *MockStoryRepository) AddReaction(ctx context.Context, storyID primitive
 	return args.Error(0)
 }
 
-func (m *MockStoryRepository) GetStoryViewersWithReactions(ctx context.Context, storyID primitive.ObjectID) ([]models.StoryViewerResponse, error) {
-	args := m.Called(ctx, storyID)
+func (m *MockStoryRepository) GetStoryViewersWithReactions(ctx context.Context, storyID primitive.ObjectID, limit, offset int) ([]models.StoryViewerResponse, error) {
+	args := m.Called(ctx, storyID, limit, offset)
 	return args.Get(0).([]models.StoryViewerResponse), args.Error(1)
 }
 
+func (m *MockStoryRepository) SetModerationStatus(ctx context.Context, mediaURL string, verdict models.ModerationVerdict) (int64, error) {
+	args := m.Called(ctx, mediaURL, verdict)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStoryRepository) DeleteUserData(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStoryRepository) GetStoriesByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Story, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]models.Story), args.Error(1)
+}
+
+func (m *MockStoryRepository) CreateHighlight(ctx context.Context, highlight *models.StoryHighlight) (*models.StoryHighlight, error) {
+	args := m.Called(ctx, highlight)
+	return args.Get(0).(*models.StoryHighlight), args.Error(1)
+}
+
+func (m *MockStoryRepository) GetHighlightByID(ctx context.Context, id primitive.ObjectID) (*models.StoryHighlight, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StoryHighlight), args.Error(1)
+}
+
+func (m *MockStoryRepository) ListUserHighlights(ctx context.Context, userID primitive.ObjectID) ([]models.StoryHighlight, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]models.StoryHighlight), args.Error(1)
+}
+
+func (m *MockStoryRepository) CountUserHighlights(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStoryRepository) UpdateHighlight(ctx context.Context, highlight *models.StoryHighlight, added, removed []primitive.ObjectID) error {
+	args := m.Called(ctx, highlight, added, removed)
+	return args.Error(0)
+}
+
+func (m *MockStoryRepository) DeleteHighlight(ctx context.Context, id, userID primitive.ObjectID) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+// friendUserClient reports every pair of users as friends
+type friendUserClient struct {
+	userpb.UserServiceClient
+}
+
+func (friendUserClient) CheckRelationship(ctx context.Context, in *userpb.CheckRelationshipRequest, opts ...grpc.CallOption) (*userpb.CheckRelationshipResponse, error) {
+	return &userpb.CheckRelationshipResponse{IsFriend: true}, nil
+}
+
+// closeFriendsUserClient reports every pair of users as friends, and the
+// users in closeFriends as close friends of everyone
+type closeFriendsUserClient struct {
+	userpb.UserServiceClient
+	closeFriends []string
+}
+
+func (c closeFriendsUserClient) CheckRelationship(ctx context.Context, in *userpb.CheckRelationshipRequest, opts ...grpc.CallOption) (*userpb.CheckRelationshipResponse, error) {
+	return &userpb.CheckRelationshipResponse{IsFriend: true, IsCloseFriend: slices.Contains(c.closeFriends, in.UserId)}, nil
+}
+
+func (c closeFriendsUserClient) GetCloseFriendIDs(ctx context.Context, in *userpb.GetCloseFriendIDsRequest, opts ...grpc.CallOption) (*userpb.GetCloseFriendIDsResponse, error) {
+	return &userpb.GetCloseFriendIDsResponse{UserIds: c.closeFriends}, nil
+}
+
 type MockBroadcaster struct {
 	mock.Mock
 }
@@ -152,7 +240,7 @@ func TestStoryService_GetStoryViewers_Unauthorized(t *testing.T) {
 
 	mockRepo.On("GetStoryByID", mock.Anything, storyID).Return(story, nil)
 
-	viewers, err := service.GetStoryViewers(context.Background(), storyID, userID)
+	viewers, err := service.GetStoryViewers(context.Background(), storyID, userID, 0, 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, viewers)
@@ -161,6 +249,123 @@ func TestStoryService_GetStoryViewers_Unauthorized(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestStoryService_GetStoryViewers_Paginates(t *testing.T) {
+	mockRepo := new(MockStoryRepository)
+	service := NewStoryService(mockRepo, nil, nil, nil, nil, slog.Default(), nil)
+
+	storyID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	story := &models.Story{ID: storyID, UserID: userID, ViewCount: 3}
+
+	mockRepo.On("GetStoryByID", mock.Anything, storyID).Return(story, nil)
+	mockRepo.On("GetStoryViewersWithReactions", mock.Anything, storyID, 3, 0).Return(make([]models.StoryViewerResponse, 3), nil)
+
+	page, err := service.GetStoryViewers(context.Background(), storyID, userID, 2, -1)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Viewers, 2)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, int64(3), page.UniqueViews) // Falls back to the stored count without Redis
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStoryService_GetStoryViewers_LastPage(t *testing.T) {
+	mockRepo := new(MockStoryRepository)
+	service := NewStoryService(mockRepo, nil, nil, nil, nil, slog.Default(), nil)
+
+	storyID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+
+	mockRepo.On("GetStoryByID", mock.Anything, storyID).Return(&models.Story{ID: storyID, UserID: userID}, nil)
+	mockRepo.On("GetStoryViewersWithReactions", mock.Anything, storyID, maxViewersLimit+1, 100).Return([]models.StoryViewerResponse(nil), nil)
+
+	page, err := service.GetStoryViewers(context.Background(), storyID, userID, 1000, 100)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, page.Viewers)
+	assert.Empty(t, page.Viewers)
+	assert.False(t, page.HasMore)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func newViewTestService(repo *MockStoryRepository, broadcaster *MockBroadcaster) *StoryService {
+	breaker := resilience.NewCircuitBreaker(resilience.DefaultConfig("user-service"), slog.Default())
+	return NewStoryService(repo, broadcaster, friendUserClient{}, breaker, nil, slog.Default(), nil)
+}
+
+func TestStoryService_RecordView_FirstViewPublishes(t *testing.T) {
+	mockRepo := new(MockStoryRepository)
+	mockBroadcaster := new(MockBroadcaster)
+	service := newViewTestService(mockRepo, mockBroadcaster)
+
+	storyID := primitive.NewObjectID()
+	viewerID := primitive.NewObjectID()
+	story := &models.Story{
+		ID:        storyID,
+		UserID:    primitive.NewObjectID(),
+		Privacy:   models.PrivacySettingFriends,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	mockRepo.On("GetStoryByID", mock.Anything, storyID).Return(story, nil)
+	mockRepo.On("AddViewer", mock.Anything, storyID, viewerID, story.ExpiresAt).Return(true, nil)
+	mockBroadcaster.On("PublishStoryViewed", mock.Anything, mock.MatchedBy(func(e producer.StoryViewedEvent) bool {
+		return e.StoryID == storyID.Hex() && e.ViewerID == viewerID.Hex() && e.OwnerID == story.UserID.Hex()
+	})).Return()
+
+	err := service.RecordView(context.Background(), storyID, viewerID)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockBroadcaster.AssertExpectations(t)
+}
+
+func TestStoryService_RecordView_RepeatViewIsDeduplicated(t *testing.T) {
+	mockRepo := new(MockStoryRepository)
+	mockBroadcaster := new(MockBroadcaster)
+	service := newViewTestService(mockRepo, mockBroadcaster)
+
+	storyID := primitive.NewObjectID()
+	viewerID := primitive.NewObjectID()
+	story := &models.Story{
+		ID:        storyID,
+		UserID:    primitive.NewObjectID(),
+		Privacy:   models.PrivacySettingPublic,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	mockRepo.On("GetStoryByID", mock.Anything, storyID).Return(story, nil)
+	mockRepo.On("AddViewer", mock.Anything, storyID, viewerID, story.ExpiresAt).Return(false, nil)
+
+	err := service.RecordView(context.Background(), storyID, viewerID)
+
+	assert.NoError(t, err)
+	mockBroadcaster.AssertNotCalled(t, "PublishStoryViewed", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStoryService_RecordView_ExpiredStory(t *testing.T) {
+	mockRepo := new(MockStoryRepository)
+	service := newViewTestService(mockRepo, nil)
+
+	storyID := primitive.NewObjectID()
+	story := &models.Story{
+		ID:        storyID,
+		UserID:    primitive.NewObjectID(),
+		Privacy:   models.PrivacySettingPublic,
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}
+
+	mockRepo.On("GetStoryByID", mock.Anything, storyID).Return(story, nil)
+
+	err := service.RecordView(context.Background(), storyID, primitive.NewObjectID())
+
+	assert.EqualError(t, err, "story not found")
+	mockRepo.AssertNotCalled(t, "AddViewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestStoryService_CreateStory_ValidationError(t *testing.T) {
 	service := NewStoryService(nil, nil, nil, nil, nil, slog.Default(), nil)
 
@@ -178,3 +383,292 @@ func TestStoryService_CreateStory_ValidationError(t *testing.T) {
 	assert.Nil(t, story)
 	assert.Contains(t, err.Error(), "media URL is required")
 }
+
+func TestStoryService_ApplyModerationVerdict(t *testing.T) {
+	mediaURL := "http://localhost:9000/connectify-uploads/photo.jpg"
+
+	t.Run("held media flags its stories", func(t *testing.T) {
+		mockRepo := new(MockStoryRepository)
+		service := NewStoryService(mockRepo, nil, nil, nil, nil, slog.Default(), nil)
+
+		mockRepo.On("SetModerationStatus", mock.Anything, mediaURL, models.ModerationHeld).Return(int64(1), nil)
+
+		err := service.ApplyModerationVerdict(context.Background(), &models.MediaModerationEvent{
+			Key: "photo.jpg", URL: mediaURL, Verdict: models.ModerationHeld,
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("approved media is left alone", func(t *testing.T) {
+		mockRepo := new(MockStoryRepository)
+		service := NewStoryService(mockRepo, nil, nil, nil, nil, slog.Default(), nil)
+
+		err := service.ApplyModerationVerdict(context.Background(), &models.MediaModerationEvent{
+			Key: "photo.jpg", URL: mediaURL, Verdict: models.ModerationApproved,
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "SetModerationStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestStoryService_GetStory_FlaggedByModeration(t *testing.T) {
+	mockRepo := new(MockStoryRepository)
+	service := newViewTestService(mockRepo, nil)
+
+	storyID := primitive.NewObjectID()
+	story := &models.Story{
+		ID:               storyID,
+		UserID:           primitive.NewObjectID(),
+		Privacy:          models.PrivacySettingPublic,
+		ModerationStatus: models.ModerationHeld,
+		ExpiresAt:        time.Now().Add(time.Hour),
+	}
+
+	mockRepo.On("GetStoryByID", mock.Anything, storyID).Return(story, nil)
+
+	_, err := service.GetStory(context.Background(), storyID, primitive.NewObjectID())
+	assert.EqualError(t, err, "story not found")
+
+	own, err := service.GetStory(context.Background(), storyID, story.UserID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ModerationHeld, own.ModerationStatus)
+}
+
+func TestStoryService_CloseFriendsStory(t *testing.T) {
+	authorID, closeFriendID, friendID := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	breaker := resilience.NewCircuitBreaker(resilience.DefaultConfig("user-service"), slog.Default())
+	client := closeFriendsUserClient{closeFriends: []string{closeFriendID.Hex()}}
+
+	t.Run("fans out to close friends only", func(t *testing.T) {
+		mockRepo := new(MockStoryRepository)
+		mockBroadcaster := new(MockBroadcaster)
+		service := NewStoryService(mockRepo, mockBroadcaster, client, breaker, nil, slog.Default(), nil)
+
+		mockRepo.On("CreateStory", mock.Anything, mock.MatchedBy(func(s *models.Story) bool {
+			return s.Privacy == models.PrivacySettingCloseFriends && len(s.AllowedViewers) == 0
+		})).Return(&models.Story{ID: primitive.NewObjectID(), UserID: authorID, Privacy: models.PrivacySettingCloseFriends}, nil)
+		mockBroadcaster.On("PublishStoryCreated", mock.Anything, mock.MatchedBy(func(e producer.StoryCreatedEvent) bool {
+			return e.Privacy == models.PrivacySettingCloseFriends &&
+				slices.Equal(e.Recipients, []string{authorID.Hex(), closeFriendID.Hex()})
+		})).Return()
+
+		_, err := service.CreateStory(context.Background(), authorID, models.PostAuthor{ID: authorID.Hex()}, CreateStoryRequest{
+			MediaURL:       "https://example.com/story.jpg",
+			MediaType:      "image",
+			Privacy:        models.PrivacySettingCloseFriends,
+			AllowedViewers: []string{friendID.Hex()},
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockBroadcaster.AssertExpectations(t)
+	})
+
+	t.Run("only close friends can fetch it", func(t *testing.T) {
+		mockRepo := new(MockStoryRepository)
+		service := NewStoryService(mockRepo, nil, client, breaker, nil, slog.Default(), nil)
+		story := &models.Story{ID: primitive.NewObjectID(), UserID: authorID, Privacy: models.PrivacySettingCloseFriends}
+		mockRepo.On("GetStoryByID", mock.Anything, story.ID).Return(story, nil)
+
+		_, err := service.GetStory(context.Background(), story.ID, closeFriendID)
+		assert.NoError(t, err)
+
+		_, err = service.GetStory(context.Background(), story.ID, friendID)
+		assert.EqualError(t, err, "story not found")
+	})
+}
+
+func TestStoryService_CreateHighlight(t *testing.T) {
+	userID := primitive.NewObjectID()
+	first := models.Story{ID: primitive.NewObjectID(), UserID: userID}
+	second := models.Story{ID: primitive.NewObjectID(), UserID: userID}
+
+	t.Run("pins own stories with the chosen cover", func(t *testing.T) {
+		mockRepo := new(MockStoryRepository)
+		service := NewStoryService(mockRepo, nil, nil, nil, nil, slog.Default(), nil)
+		ids := []primitive.ObjectID{first.ID, second.ID}
+
+		mockRepo.On("CountUserHighlights", mock.Anything, userID).Return(int64(0), nil)
+		mockRepo.On("GetStoriesByIDs", mock.Anything, ids).Return([]models.Story{first, second}, nil)
+		mockRepo.On("CreateHighlight", mock.Anything, mock.MatchedBy(func(h *models.StoryHighlight) bool {
+			return h.Title == "Travel" && h.CoverStoryID == second.ID && slices.Equal(h.StoryIDs, ids)
+		})).Return(&models.StoryHighlight{ID: primitive.NewObjectID()}, nil)
+
+		_, err := service.CreateHighlight(context.Background(), userID, HighlightRequest{
+			Title:        " Travel ",
+			StoryIDs:     []string{first.ID.Hex(), second.ID.Hex(), first.ID.Hex()},
+			CoverStoryID: second.ID.Hex(),
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects stories of other users", func(t *testing.T) {
+		mockRepo := new(MockStoryRepository)
+		service := NewStoryService(mockRepo, nil, nil, nil, nil, slog.Default(), nil)
+		other := models.Story{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID()}
+
+		mockRepo.On("CountUserHighlights", mock.Anything, userID).Return(int64(0), nil)
+		mockRepo.On("GetStoriesByIDs", mock.Anything, mock.Anything).Return([]models.Story{first, other}, nil)
+
+		_, err := service.CreateHighlight(context.Background(), userID, HighlightRequest{
+			Title:    "Travel",
+			StoryIDs: []string{first.ID.Hex(), other.ID.Hex()},
+		})
+
+		assert.ErrorIs(t, err, ErrHighlightStoryNotOwned)
+		mockRepo.AssertNotCalled(t, "CreateHighlight", mock.Anything, mock.Anything)
+	})
+}
+
+func TestStoryService_UpdateHighlight_RemovingCoverMovesIt(t *testing.T) {
+	mockRepo := new(MockStoryRepository)
+	service := NewStoryService(mockRepo, nil, nil, nil, nil, slog.Default(), nil)
+	userID := primitive.NewObjectID()
+	first, second, third := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	highlight := &models.StoryHighlight{
+		ID:           primitive.NewObjectID(),
+		UserID:       userID,
+		Title:        "Travel",
+		CoverStoryID: first,
+		StoryIDs:     []primitive.ObjectID{first, second},
+	}
+
+	mockRepo.On("GetHighlightByID", mock.Anything, highlight.ID).Return(highlight, nil)
+	mockRepo.On("GetStoriesByIDs", mock.Anything, []primitive.ObjectID{third}).Return([]models.Story{{ID: third, UserID: userID}}, nil)
+	mockRepo.On("UpdateHighlight", mock.Anything, mock.Anything, []primitive.ObjectID{third}, []primitive.ObjectID{first}).Return(nil)
+
+	updated, err := service.UpdateHighlight(context.Background(), highlight.ID, userID, UpdateHighlightRequest{
+		AddStoryIDs:    []string{third.Hex()},
+		RemoveStoryIDs: []string{first.Hex()},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{second, third}, updated.StoryIDs)
+	assert.Equal(t, second, updated.CoverStoryID)
+	mockRepo.AssertExpectations(t)
+
+	_, err = service.UpdateHighlight(context.Background(), highlight.ID, primitive.NewObjectID(), UpdateHighlightRequest{})
+	assert.ErrorIs(t, err, ErrHighlightNotFound)
+}
+
+func TestStoryService_ListHighlightCovers_FollowsStoryAudience(t *testing.T) {
+	ownerID, viewerID := primitive.NewObjectID(), primitive.NewObjectID()
+	breaker := resilience.NewCircuitBreaker(resilience.DefaultConfig("user-service"), slog.Default())
+	public := models.Story{ID: primitive.NewObjectID(), UserID: ownerID, Privacy: models.PrivacySettingPublic, MediaURL: "public.jpg"}
+	friends := models.Story{ID: primitive.NewObjectID(), UserID: ownerID, Privacy: models.PrivacySettingFriends, MediaURL: "friends.jpg"}
+	onlyMe := models.Story{ID: primitive.NewObjectID(), UserID: ownerID, Privacy: models.PrivacySettingOnlyMe, MediaURL: "me.jpg"}
+	highlights := []models.StoryHighlight{
+		{ID: primitive.NewObjectID(), UserID: ownerID, Title: "Mixed", CoverStoryID: friends.ID, StoryIDs: []primitive.ObjectID{public.ID, friends.ID}},
+		{ID: primitive.NewObjectID(), UserID: ownerID, Title: "Private", CoverStoryID: onlyMe.ID, StoryIDs: []primitive.ObjectID{onlyMe.ID}},
+	}
+
+	mockRepo := new(MockStoryRepository)
+	service := NewStoryService(mockRepo, nil, friendUserClient{}, breaker, nil, slog.Default(), nil)
+	mockRepo.On("ListUserHighlights", mock.Anything, ownerID).Return(highlights, nil)
+	mockRepo.On("GetStoriesByIDs", mock.Anything, mock.Anything).Return([]models.Story{public, friends, onlyMe}, nil)
+
+	covers, err := service.ListHighlightCovers(context.Background(), ownerID, viewerID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.StoryHighlightCover{
+		{ID: highlights[0].ID.Hex(), Title: "Mixed", CoverURL: "friends.jpg", StoryCount: 2},
+	}, covers)
+
+	// Signed out viewers only see public stories
+	covers, err = service.ListHighlightCovers(context.Background(), ownerID, primitive.NilObjectID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.StoryHighlightCover{
+		{ID: highlights[0].ID.Hex(), Title: "Mixed", CoverURL: "public.jpg", StoryCount: 1},
+	}, covers)
+
+	covers, err = service.ListHighlightCovers(context.Background(), ownerID, ownerID)
+	assert.NoError(t, err)
+	assert.Len(t, covers, 2)
+}
+
+// mentionUserClient knows the users in usernames, by ID
+type mentionUserClient struct {
+	closeFriendsUserClient
+	usernames map[string]string
+}
+
+func (c mentionUserClient) GetUsers(ctx context.Context, in *userpb.GetUsersRequest, opts ...grpc.CallOption) (*userpb.GetUsersResponse, error) {
+	var users []*userpb.User
+	for _, id := range in.UserIds {
+		if name, ok := c.usernames[id]; ok {
+			users = append(users, &userpb.User{Id: id, Username: name})
+		}
+	}
+	return &userpb.GetUsersResponse{Users: users}, nil
+}
+
+type MockNotificationPublisher struct {
+	mock.Mock
+}
+
+func (m *MockNotificationPublisher) PublishNotification(ctx context.Context, notification *models.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func TestStoryService_CreateStory_MentionOverlays(t *testing.T) {
+	authorID, closeFriendID, friendID := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	breaker := resilience.NewCircuitBreaker(resilience.DefaultConfig("user-service"), slog.Default())
+	client := mentionUserClient{
+		closeFriendsUserClient: closeFriendsUserClient{closeFriends: []string{closeFriendID.Hex()}},
+		usernames: map[string]string{
+			authorID.Hex():      "alice",
+			closeFriendID.Hex(): "bob",
+			friendID.Hex():      "carol",
+		},
+	}
+
+	t.Run("notifies mentioned users who can see the story", func(t *testing.T) {
+		mockRepo := new(MockStoryRepository)
+		notifications := new(MockNotificationPublisher)
+		service := NewStoryService(mockRepo, nil, client, breaker, nil, slog.Default(), nil)
+		service.SetNotificationPublisher(notifications)
+
+		overlays := []models.StoryOverlay{
+			{Type: models.StoryOverlayMention, X: 0.5, Y: 0.2, UserID: &closeFriendID, Username: "old-name"},
+			{Type: models.StoryOverlayMention, X: 0.5, Y: 0.8, UserID: &friendID},
+			{Type: models.StoryOverlayMention, X: 0.1, Y: 0.1, UserID: &closeFriendID},
+		}
+		created := &models.Story{ID: primitive.NewObjectID(), UserID: authorID, Privacy: models.PrivacySettingCloseFriends, Overlays: overlays}
+		mockRepo.On("CreateStory", mock.Anything, mock.MatchedBy(func(s *models.Story) bool {
+			return len(s.Overlays) == 3 && s.Overlays[0].Username == "bob" && s.Overlays[1].Username == "carol" && s.Overlays[0].Scale == 1
+		})).Return(created, nil)
+		notifications.On("PublishNotification", mock.Anything, mock.MatchedBy(func(n *models.Notification) bool {
+			return n.RecipientID == closeFriendID && n.Type == models.NotificationTypeMention &&
+				n.TargetID == created.ID && n.Content == "alice mentioned you in their story."
+		})).Return(nil).Once()
+
+		_, err := service.CreateStory(context.Background(), authorID, models.PostAuthor{ID: authorID.Hex()}, CreateStoryRequest{
+			MediaURL:  "https://example.com/story.jpg",
+			MediaType: "image",
+			Privacy:   models.PrivacySettingCloseFriends,
+			Overlays:  overlays,
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		notifications.AssertExpectations(t)
+	})
+
+	t.Run("rejects mentions of unknown users", func(t *testing.T) {
+		service := NewStoryService(nil, nil, client, breaker, nil, slog.Default(), nil)
+		unknownID := primitive.NewObjectID()
+
+		_, err := service.CreateStory(context.Background(), authorID, models.PostAuthor{ID: authorID.Hex()}, CreateStoryRequest{
+			MediaURL:  "https://example.com/story.jpg",
+			MediaType: "image",
+			Overlays:  []models.StoryOverlay{{Type: models.StoryOverlayMention, X: 0.5, Y: 0.5, UserID: &unknownID}},
+		})
+
+		assert.ErrorIs(t, err, validation.ErrInvalidOverlay)
+	})
+}
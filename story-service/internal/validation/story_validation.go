@@ -2,7 +2,11 @@ package validation
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 )
@@ -14,6 +18,27 @@ var (
 	ErrInvalidPrivacy      = errors.New("invalid privacy setting")
 	ErrTooManyViewers      = errors.New("too many viewers specified (max 100)")
 	ErrInvalidReactionType = errors.New("invalid reaction type")
+
+	ErrTooManyOverlays = errors.New("too many overlays (max 20)")
+	ErrTooManyMentions = errors.New("too many mentions (max 10)")
+	ErrInvalidOverlay  = errors.New("invalid overlay")
+
+	ErrHighlightTitleRequired  = errors.New("highlight title is required")
+	ErrHighlightTitleTooLong   = errors.New("highlight title must be at most 50 characters")
+	ErrHighlightEmpty          = errors.New("a highlight needs at least one story")
+	ErrTooManyHighlightStories = errors.New("too many stories in highlight (max 100)")
+)
+
+const (
+	MaxOverlays           = 20
+	MaxMentions           = 10
+	MaxOverlayTextLength  = 200
+	MaxOverlayScale       = 10
+	MaxMusicClipMs        = 60000
+	maxOverlayFieldLength = 100
+
+	MaxHighlightTitleLength = 50
+	MaxHighlightStories     = 100
 )
 
 var ValidMediaTypes = map[string]bool{
@@ -26,6 +51,7 @@ var ValidPrivacySettings = map[models.PrivacySettingType]bool{
 	models.PrivacySettingFriends:      true,
 	models.PrivacySettingCustom:       true,
 	models.PrivacySettingFriendsExcept: true,
+	models.PrivacySettingCloseFriends:  true,
 }
 
 var ValidReactionTypes = map[string]bool{
@@ -73,6 +99,107 @@ func ValidateReactionType(reactionType string) error {
 	return nil
 }
 
+var overlayColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ValidateOverlays checks the overlays drawn over a story: their placement,
+// the fields their type needs, and that a story has at most one link and one
+// music track. Errors wrap ErrInvalidOverlay and name the overlay.
+func ValidateOverlays(overlays []models.StoryOverlay) error {
+	if len(overlays) > MaxOverlays {
+		return ErrTooManyOverlays
+	}
+
+	counts := make(map[models.StoryOverlayType]int)
+	for i, o := range overlays {
+		if err := validateOverlay(o); err != nil {
+			return fmt.Errorf("%w %d: %s", ErrInvalidOverlay, i, err)
+		}
+		counts[o.Type]++
+	}
+
+	if counts[models.StoryOverlayMention] > MaxMentions {
+		return ErrTooManyMentions
+	}
+	if counts[models.StoryOverlayLink] > 1 {
+		return fmt.Errorf("%w: only one link per story", ErrInvalidOverlay)
+	}
+	if counts[models.StoryOverlayMusic] > 1 {
+		return fmt.Errorf("%w: only one music track per story", ErrInvalidOverlay)
+	}
+	return nil
+}
+
+func validateOverlay(o models.StoryOverlay) error {
+	if o.X < 0 || o.X > 1 || o.Y < 0 || o.Y > 1 {
+		return errors.New("position must be between 0 and 1")
+	}
+	if o.Scale < 0 || o.Scale > MaxOverlayScale {
+		return errors.New("scale must be between 0 and 10")
+	}
+	if o.Rotation < -360 || o.Rotation > 360 {
+		return errors.New("rotation must be between -360 and 360 degrees")
+	}
+	if o.Color != "" && !overlayColorPattern.MatchString(o.Color) {
+		return errors.New("color must be #RRGGBB")
+	}
+	if utf8.RuneCountInString(o.Text) > MaxOverlayTextLength {
+		return errors.New("text must be at most 200 characters")
+	}
+	if len(o.Style) > maxOverlayFieldLength {
+		return errors.New("style is too long")
+	}
+
+	switch o.Type {
+	case models.StoryOverlayText:
+		if strings.TrimSpace(o.Text) == "" {
+			return errors.New("text is required")
+		}
+	case models.StoryOverlaySticker:
+		if o.StickerID == "" || len(o.StickerID) > maxOverlayFieldLength {
+			return errors.New("sticker_id is required")
+		}
+	case models.StoryOverlayMention:
+		if o.UserID == nil || o.UserID.IsZero() {
+			return errors.New("user_id is required")
+		}
+	case models.StoryOverlayLink:
+		u, err := url.Parse(o.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(o.URL) > 500 {
+			return errors.New("url must be an http or https link")
+		}
+	case models.StoryOverlayMusic:
+		m := o.Music
+		if m == nil || m.TrackID == "" || len(m.TrackID) > maxOverlayFieldLength {
+			return errors.New("music track_id is required")
+		}
+		if m.StartMs < 0 || m.DurationMs <= 0 || m.DurationMs > MaxMusicClipMs {
+			return errors.New("music clip must start at 0 or later and last up to 60 seconds")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", o.Type)
+	}
+	return nil
+}
+
+// ValidateHighlight checks a highlight's title and how many stories it holds
+func ValidateHighlight(title string, storyCount int) error {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return ErrHighlightTitleRequired
+	}
+	if utf8.RuneCountInString(title) > MaxHighlightTitleLength {
+		return ErrHighlightTitleTooLong
+	}
+
+	if storyCount == 0 {
+		return ErrHighlightEmpty
+	}
+	if storyCount > MaxHighlightStories {
+		return ErrTooManyHighlightStories
+	}
+	return nil
+}
+
 func SanitizeString(input string) string {
 	return strings.TrimSpace(input)
 }
\ No newline at end of file
@@ -1,10 +1,13 @@
 package validation
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func TestValidateCreateStoryRequest(t *testing.T) {
@@ -120,6 +123,67 @@ func TestValidateReactionType(t *testing.T) {
 	}
 }
 
+func TestValidateHighlight(t *testing.T) {
+	tests := []struct {
+		name       string
+		title      string
+		storyCount int
+		wantErr    error
+	}{
+		{"valid highlight", "Travel", 3, nil},
+		{"title at limit", strings.Repeat("é", MaxHighlightTitleLength), 1, nil},
+		{"blank title", "   ", 1, ErrHighlightTitleRequired},
+		{"title too long", strings.Repeat("a", MaxHighlightTitleLength+1), 1, ErrHighlightTitleTooLong},
+		{"no stories", "Travel", 0, ErrHighlightEmpty},
+		{"too many stories", "Travel", MaxHighlightStories + 1, ErrTooManyHighlightStories},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, ValidateHighlight(tt.title, tt.storyCount))
+		})
+	}
+}
+
+func TestValidateOverlays(t *testing.T) {
+	userID := primitive.NewObjectID()
+	mention := models.StoryOverlay{Type: models.StoryOverlayMention, X: 0.5, Y: 0.5, UserID: &userID}
+	link := models.StoryOverlay{Type: models.StoryOverlayLink, X: 0.5, Y: 0.9, URL: "https://example.com"}
+
+	tests := []struct {
+		name     string
+		overlays []models.StoryOverlay
+		wantErr  error
+	}{
+		{"no overlays", nil, nil},
+		{"text", []models.StoryOverlay{{Type: models.StoryOverlayText, X: 0.5, Y: 0.5, Scale: 2, Text: "hi", Color: "#FF00aa"}}, nil},
+		{"sticker", []models.StoryOverlay{{Type: models.StoryOverlaySticker, X: 1, Y: 0, Rotation: -45, StickerID: "heart"}}, nil},
+		{"mention and link", []models.StoryOverlay{mention, link}, nil},
+		{"music", []models.StoryOverlay{{Type: models.StoryOverlayMusic, Music: &models.StoryMusic{TrackID: "t1", DurationMs: 15000}}}, nil},
+		{"unknown type", []models.StoryOverlay{{Type: "POLL"}}, ErrInvalidOverlay},
+		{"off the canvas", []models.StoryOverlay{{Type: models.StoryOverlayText, X: 1.5, Text: "hi"}}, ErrInvalidOverlay},
+		{"bad color", []models.StoryOverlay{{Type: models.StoryOverlayText, Text: "hi", Color: "red"}}, ErrInvalidOverlay},
+		{"empty text", []models.StoryOverlay{{Type: models.StoryOverlayText, Text: "  "}}, ErrInvalidOverlay},
+		{"mention without user", []models.StoryOverlay{{Type: models.StoryOverlayMention}}, ErrInvalidOverlay},
+		{"non-http link", []models.StoryOverlay{{Type: models.StoryOverlayLink, URL: "javascript:alert(1)"}}, ErrInvalidOverlay},
+		{"two links", []models.StoryOverlay{link, link}, ErrInvalidOverlay},
+		{"clip too long", []models.StoryOverlay{{Type: models.StoryOverlayMusic, Music: &models.StoryMusic{TrackID: "t1", DurationMs: MaxMusicClipMs + 1}}}, ErrInvalidOverlay},
+		{"too many overlays", slices.Repeat([]models.StoryOverlay{{Type: models.StoryOverlaySticker, StickerID: "heart"}}, MaxOverlays+1), ErrTooManyOverlays},
+		{"too many mentions", slices.Repeat([]models.StoryOverlay{mention}, MaxMentions+1), ErrTooManyMentions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOverlays(tt.overlays)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestSanitizeString(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
@@ -14,19 +15,34 @@ import (
 	"user-service/internal/events"
 	grpchandler "user-service/internal/handler/grpc"
 	httphandler "user-service/internal/handler/http"
+	"user-service/internal/mailer"
+	"user-service/internal/oauth"
 	"user-service/internal/platform"
 	"user-service/internal/repository"
 	"user-service/internal/service"
+	"user-service/internal/storage"
+	"user-service/internal/stories"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/featureflags"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/jwks"
+	sharedkafka "github.com/MuhibNayem/connectify-v2/shared-entity/kafka"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/middleware"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/observability"
+	exportpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/export/v1"
+	storagepb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/storage/v1"
+	storypb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/story/v1"
 	pb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/user/v1"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/resilience"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/serviceauth"
 	"github.com/gin-gonic/gin"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -43,6 +59,7 @@ func run() error {
 	defer stop()
 
 	cfg := config.LoadConfig()
+	serviceauth.SetDefault(serviceauth.NewCredentials("user-service", cfg.ServiceAuthSecret))
 
 	tp, err := observability.InitTracer(ctx, observability.TracerConfig{
 		ServiceName:    "user-service",
@@ -85,106 +102,459 @@ func run() error {
 	// 2. Repositories
 	userRepo := repository.NewUserRepository(db)
 	graphRepo := repository.NewGraphRepository(neoDriver)
+	erasureRepo := repository.NewErasureRepository(db)
+	blockRepo := repository.NewBlockRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	dataExportRepo := repository.NewDataExportRepository(db)
+	accountDeletionRepo := repository.NewAccountDeletionRepository(db)
+	usernameHistoryRepo := repository.NewUsernameHistoryRepository(db)
+	insightsRepo := repository.NewInsightsRepository(db)
+	verificationRepo := repository.NewVerificationRepository(db)
+	oauthIdentityRepo := repository.NewOAuthIdentityRepository(db)
+	auditStore := audit.NewStore(mongoClient.Database(cfg.AuditDBName))
+	auditLogger := audit.NewLogger(auditStore, "user-service", slog.Default())
+	featureFlagStore := featureflags.NewStore(db, redisClient)
+	if err := featureFlagStore.Sync(ctx); err != nil {
+		slog.Warn("Failed to sync feature flags to Redis", "error", err)
+	}
 
 	// 3. Producers
 	producer := events.NewEventProducer(cfg.KafkaBrokers, cfg.UserUpdatedTopic, slog.Default())
+	erasureProducer := events.NewEventProducer(cfg.KafkaBrokers, cfg.ErasureRequestTopic, slog.Default())
+	friendshipProducer := events.NewEventProducer(cfg.KafkaBrokers, cfg.FriendshipEventTopic, slog.Default())
+	notificationProducer := events.NewEventProducer(cfg.KafkaBrokers, cfg.NotificationTopic, slog.Default())
+	deletionProducer := events.NewEventProducer(cfg.KafkaBrokers, sharedkafka.UserDeletedTopic, slog.Default())
+	followProducer := events.NewEventProducer(cfg.KafkaBrokers, sharedkafka.FollowEventsTopic, slog.Default())
+	emailProducer := events.NewEventProducer(cfg.KafkaBrokers, cfg.EmailTopic, slog.Default())
+	searchIndexer := sharedkafka.NewSearchIndexPublisher(cfg.KafkaBrokers)
+	insightsPublisher := sharedkafka.NewInsightsPublisher(cfg.KafkaBrokers)
 
 	// 4. Business Metrics
 	businessMetrics := platform.NewBusinessMetrics()
 
 	// 5. Services
-	authService := service.NewAuthService(userRepo, graphRepo, redisClient, cfg)
+	authService := service.NewAuthService(userRepo, graphRepo, sessionRepo, redisClient, cfg)
 	userService := service.NewUserService(userRepo, producer, redisClient, cfg, slog.Default(), businessMetrics)
+	authService.SetSearchIndexer(searchIndexer)
+	userService.SetSearchIndexer(searchIndexer)
+	authService.SetAuditLogger(auditLogger)
+	userService.SetAuditLogger(auditLogger)
+	authService.SetUsernameHistory(usernameHistoryRepo)
+	userService.SetUsernameHistory(usernameHistoryRepo)
+	userService.SetInsights(insightsPublisher)
+	accountEmailService := service.NewAccountEmailService(userService, service.NewTokenService(redisClient, cfg.JWTSecret), emailProducer, authService, service.AccountEmailConfig{
+		AppURL:          cfg.AppBaseURL,
+		VerificationTTL: cfg.EmailVerificationTTL,
+		ResetTTL:        cfg.PasswordResetTTL,
+		ResendCooldown:  cfg.EmailResendCooldown,
+	}, slog.Default())
+	accountEmailService.SetAuditLogger(auditLogger)
+	authService.SetEmailVerifier(accountEmailService)
+	userService.SetEmailVerifier(accountEmailService)
+	var oauthProviders []service.OAuthAuthenticator
+	if len(cfg.GoogleClientIDs) > 0 {
+		oauthProviders = append(oauthProviders, oauth.NewGoogle(oauth.GoogleConfig{
+			ClientIDs:    cfg.GoogleClientIDs,
+			ClientSecret: cfg.GoogleClientSecret,
+			RedirectURIs: cfg.OAuthRedirectURIs,
+		}))
+	}
+	if len(cfg.AppleClientIDs) > 0 {
+		apple, err := oauth.NewApple(oauth.AppleConfig{
+			ClientIDs:    cfg.AppleClientIDs,
+			TeamID:       cfg.AppleTeamID,
+			KeyID:        cfg.AppleKeyID,
+			PrivateKey:   cfg.ApplePrivateKey,
+			RedirectURIs: cfg.OAuthRedirectURIs,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to configure Sign in with Apple: %w", err)
+		}
+		oauthProviders = append(oauthProviders, apple)
+	}
+	authService.SetOAuth(oauthIdentityRepo, oauthProviders...)
+	var signingKeys *jwks.KeyRing
+	if cfg.JWTSigningKey != "" {
+		if signingKeys, err = jwks.NewKeyRing(cfg.JWTSigningKey, cfg.JWTPublishedKeys); err != nil {
+			return fmt.Errorf("failed to load JWT signing keys: %w", err)
+		}
+		authService.SetSigningKeys(signingKeys)
+	}
+	insightsService := service.NewInsightsService(insightsRepo, redisClient, slog.Default())
+	erasureService := service.NewErasureService(erasureRepo, erasureProducer, service.ErasureConfig{
+		MaxAttempts:   cfg.ErasureMaxAttempts,
+		AckTimeout:    cfg.ErasureAckTimeout,
+		RetryInterval: cfg.ErasureRetryInterval,
+	}, slog.Default())
+	erasureService.SetAuditLogger(auditLogger)
+	featureFlagService := service.NewFeatureFlagService(featureFlagStore)
+	featureFlagService.SetAuditLogger(auditLogger)
+	blockService := service.NewBlockService(blockRepo, userRepo, graphRepo, friendshipProducer, slog.Default())
+	suggestionService := service.NewSuggestionService(graphRepo, userRepo, redisClient, cfg.SuggestionCacheTTL, slog.Default())
+	blockService.SetSuggestionInvalidator(suggestionService)
+	followService := service.NewFollowService(graphRepo, userRepo, blockRepo, notificationProducer, slog.Default())
+	followService.SetFollowEvents(followProducer)
+	closeFriendsService := service.NewCloseFriendsService(graphRepo, userRepo)
+	exporters, exporterConns, err := dialDataExporters(cfg)
+	if err != nil {
+		return err
+	}
+	for _, conn := range exporterConns {
+		defer conn.Close()
+	}
+	storageConn, err := resilience.NewGRPCClient("storage-service").
+		WithRetry(resilience.DefaultRetryPolicy(), resilience.ReadOnlyMethods...).
+		Dial(net.JoinHostPort(cfg.StorageGRPCHost, cfg.StorageGRPCPort))
+	if err != nil {
+		return fmt.Errorf("failed to connect to storage-service: %w", err)
+	}
+	defer storageConn.Close()
+	verificationService := service.NewVerificationService(verificationRepo, userService,
+		storage.NewDocumentStore(storagepb.NewStorageServiceClient(storageConn)), slog.Default())
+	verificationService.SetAuditLogger(auditLogger)
+	storyConn, err := resilience.NewGRPCClient("story-service").
+		WithTimeout(2*time.Second).
+		WithRetry(resilience.DefaultRetryPolicy(), resilience.ReadOnlyMethods...).
+		Dial(net.JoinHostPort(cfg.StoryGRPCHost, cfg.StoryGRPCPort))
+	if err != nil {
+		return fmt.Errorf("failed to connect to story-service: %w", err)
+	}
+	defer storyConn.Close()
+	dataExportService := service.NewDataExportService(
+		dataExportRepo, userRepo, sessionRepo, blockRepo, exporters,
+		storage.NewArchiveStore(storagepb.NewStorageServiceClient(storageConn)),
+		notificationProducer,
+		service.DataExportConfig{
+			LinkTTL:        cfg.DataExportLinkTTL,
+			Retention:      cfg.DataExportRetention,
+			PollInterval:   cfg.DataExportPollInterval,
+			ServiceTimeout: cfg.DataExportServiceTimeout,
+			MaxAttempts:    cfg.DataExportMaxAttempts,
+			MaxMediaBytes:  cfg.DataExportMaxMediaBytes,
+		},
+		slog.Default(),
+	)
+	accountDeletionService := service.NewAccountDeletionService(accountDeletionRepo, userRepo, authService, deletionProducer, service.AccountDeletionConfig{
+		GracePeriod:  cfg.AccountDeletionGracePeriod,
+		MaxAttempts:  cfg.AccountDeletionMaxAttempts,
+		AckTimeout:   cfg.AccountDeletionAckTimeout,
+		PollInterval: cfg.AccountDeletionPollInterval,
+	}, slog.Default())
 	rateLimitObserver := businessMetrics.RecordRateLimitHit
+	rateLimits := middleware.NewSlidingWindowLimiter(redisClient, rateLimitObserver)
+
+	// Erasure orchestration: acknowledgements from participants and redispatch of stalled services
+	erasureConsumer := events.NewErasureProgressConsumer(cfg.KafkaBrokers, cfg.ErasureProgressTopic, erasureService, slog.Default())
+	go erasureConsumer.Start(ctx)
+	go erasureService.RunRetryWorker(ctx)
+	go dataExportService.RunWorker(ctx)
+
+	// Account deletion saga: removal of due accounts and acknowledgements from participants
+	deletionConsumer := events.NewDeletionProgressConsumer(cfg.KafkaBrokers, sharedkafka.UserDeletionProgressTopic, accountDeletionService, slog.Default())
+	go deletionConsumer.Start(ctx)
+	go accountDeletionService.RunWorker(ctx)
+
+	// Creator insights: profile views from here, post interactions from feed-service
+	insightsConsumer := events.NewInsightsConsumer(cfg.KafkaBrokers, sharedkafka.InsightsTopic, insightsService, slog.Default())
+	go insightsConsumer.Start(ctx)
+
+	// Mailer: verification and password reset emails
+	var emailConsumer *events.EmailConsumer
+	if cfg.MailerEnabled {
+		smtpSender, err := mailer.NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+		if err != nil {
+			return err
+		}
+		emailConsumer = events.NewEmailConsumer(cfg.KafkaBrokers, cfg.EmailTopic, mailer.New(smtpSender, "Connectify"), slog.Default())
+		go emailConsumer.Start(ctx)
+	}
 
 	// 5. Handlers
 	authHandler := httphandler.NewAuthHandler(authService, cfg)
+	twoFactorHandler := httphandler.NewTwoFactorHandler(authService)
+	accountEmailHandler := httphandler.NewAccountEmailHandler(accountEmailService)
+	oauthHandler := httphandler.NewOAuthHandler(authService, cfg)
+	jwksHandler, err := httphandler.NewJWKSHandler(signingKeys)
+	if err != nil {
+		return fmt.Errorf("failed to publish JWT signing keys: %w", err)
+	}
+	sessionHandler := httphandler.NewSessionHandler(authService)
+	accountStateHandler := httphandler.NewAccountStateHandler(authService)
 	userHandler := httphandler.NewUserHandler(userService)
+	userHandler.SetProfileHighlights(stories.NewHighlightCovers(storypb.NewStoryServiceClient(storyConn), slog.Default()))
+	complianceHandler := httphandler.NewComplianceHandler(erasureService)
+	dataExportHandler := httphandler.NewDataExportHandler(dataExportService)
+	accountDeletionHandler := httphandler.NewAccountDeletionHandler(accountDeletionService)
+	auditHandler := httphandler.NewAuditHandler(auditStore)
+	featureFlagHandler := httphandler.NewFeatureFlagHandler(featureFlagService)
+	blockHandler := httphandler.NewBlockHandler(blockService)
+	suggestionHandler := httphandler.NewSuggestionHandler(suggestionService)
+	followHandler := httphandler.NewFollowHandler(followService)
+	closeFriendsHandler := httphandler.NewCloseFriendsHandler(closeFriendsService)
+	insightsHandler := httphandler.NewInsightsHandler(insightsService)
+	verificationHandler := httphandler.NewVerificationHandler(verificationService)
 	userGrpcHandler := grpchandler.NewUserHandler(userService, graphRepo)
+	userGrpcHandler.SetSuggestionService(suggestionService)
 
 	// HTTP Server
 	r := gin.Default()
-	r.Use(middleware.RateLimiter(
+	r.Use(rateLimits.RateLimiter(
 		cfg.RateLimitEnabled,
 		cfg.RateLimitLimit,
 		cfg.RateLimitBurst,
 		"user:global",
-		rateLimitObserver,
 	))
+	r.Use(audit.Middleware())
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok", "service": "user-service"})
 	})
+	r.GET("/.well-known/jwks.json", jwksHandler.Get)
+	authOptions := []middleware.AuthOption{
+		middleware.WithFailClosedResponse(http.StatusServiceUnavailable, "authentication temporarily unavailable, please retry"),
+	}
+	if signingKeys != nil {
+		authOptions = append(authOptions, middleware.WithPublicKeys(signingKeys), middleware.WithLegacyHS256Until(cfg.LegacyHS256Until))
+	}
+	authMiddleware := middleware.AuthMiddleware(cfg.JWTSecret, redisClient, authOptions...)
+	// Public routes that show more to signed-in viewers
+	optionalAuth := middleware.OptionalAuthMiddleware(cfg.JWTSecret, redisClient, authOptions...)
 	api := r.Group("/api/v1")
 	{
 		auth := api.Group("/auth")
 		{
 			auth.POST("/register",
-				middleware.StrictRateLimiter(0.1, 3, "auth:register", rateLimitObserver), // ≈6 requests/min
+				rateLimits.StrictRateLimiter(0.1, 3, "auth:register"), // ≈6 requests/min
 				authHandler.Register,
 			)
 			auth.POST("/login",
-				middleware.StrictRateLimiter(1, 8, "auth:login", rateLimitObserver), // limit brute force attempts
+				rateLimits.StrictRateLimiter(1, 8, "auth:login"), // limit brute force attempts
 				authHandler.Login,
 			)
+			auth.POST("/login/2fa",
+				rateLimits.StrictRateLimiter(1, 8, "auth:login:2fa"), // per-user attempts are also capped in Redis
+				authHandler.LoginTwoFactor,
+			)
 			auth.POST("/refresh",
-				middleware.StrictRateLimiter(0.5, 5, "auth:refresh", rateLimitObserver),
+				rateLimits.StrictRateLimiter(0.5, 5, "auth:refresh"),
 				authHandler.RefreshToken,
 			)
+			auth.POST("/verify-email",
+				rateLimits.StrictRateLimiter(0.5, 5, "auth:verify-email"),
+				accountEmailHandler.VerifyEmail,
+			)
+			auth.POST("/password/forgot",
+				rateLimits.StrictRateLimiter(0.05, 3, "auth:password:forgot"), // 3/min; each address also gets one email per cooldown
+				accountEmailHandler.ForgotPassword,
+			)
+			auth.POST("/password/reset",
+				rateLimits.StrictRateLimiter(0.2, 5, "auth:password:reset"),
+				accountEmailHandler.ResetPassword,
+			)
+			auth.POST("/oauth/:provider",
+				rateLimits.StrictRateLimiter(1, 8, "auth:oauth"),
+				oauthHandler.Login,
+			)
 		}
 
 		// User routes - public profile endpoints
 		users := api.Group("/users")
 		{
 			users.GET("/:id", 
-				middleware.StrictRateLimiter(10, 30, "users:profile", rateLimitObserver), // 600/min for profile views
+				rateLimits.StrictRateLimiter(10, 30, "users:profile"), // 600/min for profile views
+				optionalAuth, // Profile sections are shown by the viewer's relationship
 				userHandler.GetUserByID,
 			)
+			users.GET("/by-username/:username",
+				rateLimits.StrictRateLimiter(10, 30, "users:profile"),
+				optionalAuth,
+				userHandler.GetUserByUsername,
+			)
 			users.GET("/:id/status", 
-				middleware.StrictRateLimiter(5, 15, "users:status", rateLimitObserver), // 300/min for status checks
+				rateLimits.StrictRateLimiter(5, 15, "users:status"), // 300/min for status checks
 				userHandler.GetUserStatus,
 			)
+			users.GET("/:id/follow-counts",
+				rateLimits.StrictRateLimiter(10, 30, "users:follows"), // 600/min for follow counts and lists
+				followHandler.Counts,
+			)
+			users.GET("/:id/followers",
+				rateLimits.StrictRateLimiter(10, 30, "users:follows"),
+				followHandler.Followers,
+			)
+			users.GET("/:id/following",
+				rateLimits.StrictRateLimiter(10, 30, "users:follows"),
+				followHandler.Following,
+			)
 		}
 
 		// Protected user routes (require JWT auth)
 		me := api.Group("/users/me")
-		me.Use(middleware.AuthMiddleware(
-			cfg.JWTSecret,
-			redisClient,
-			middleware.WithFailClosedResponse(http.StatusServiceUnavailable, "authentication temporarily unavailable, please retry"),
-		))
+		me.Use(authMiddleware)
 		{
 			me.GET("", 
-				middleware.StrictRateLimiter(2, 10, "me:profile", rateLimitObserver), // 120/min for own profile
+				rateLimits.StrictRateLimiter(2, 10, "me:profile"), // 120/min for own profile
 				userHandler.GetProfile,
 			)
 			me.PATCH("", 
-				middleware.StrictRateLimiter(0.2, 3, "me:update", rateLimitObserver), // 12/min for profile updates
+				rateLimits.StrictRateLimiter(0.2, 3, "me:update"), // 12/min for profile updates
 				userHandler.UpdateProfile,
 			)
+			me.PUT("/username",
+				rateLimits.StrictRateLimiter(0.05, 1, "me:username"), // 3/min; changes also have a cooldown
+				userHandler.ChangeUsername,
+			)
+			me.GET("/username-history", userHandler.ListUsernameHistory)
 			me.PATCH("/email", 
-				middleware.StrictRateLimiter(0.05, 1, "me:email", rateLimitObserver), // 3/min for email changes
+				rateLimits.StrictRateLimiter(0.05, 1, "me:email"), // 3/min for email changes
 				userHandler.UpdateEmail,
 			)
+			me.POST("/email/verification",
+				rateLimits.StrictRateLimiter(0.05, 2, "me:email:verification"), // 3/min; also one email per cooldown
+				accountEmailHandler.SendVerification,
+			)
 			me.PATCH("/password", 
-				middleware.StrictRateLimiter(0.1, 2, "me:password", rateLimitObserver), // 6/min for password changes
+				rateLimits.StrictRateLimiter(0.1, 2, "me:password"), // 6/min for password changes
 				userHandler.UpdatePassword,
 			)
 			me.PATCH("/privacy", 
-				middleware.StrictRateLimiter(0.5, 5, "me:privacy", rateLimitObserver), // 30/min for privacy settings
+				rateLimits.StrictRateLimiter(0.5, 5, "me:privacy"), // 30/min for privacy settings
 				userHandler.UpdatePrivacySettings,
 			)
 			me.PATCH("/notifications", 
-				middleware.StrictRateLimiter(0.5, 5, "me:notifications", rateLimitObserver), // 30/min for notification settings
+				rateLimits.StrictRateLimiter(0.5, 5, "me:notifications"), // 30/min for notification settings
 				userHandler.UpdateNotificationSettings,
 			)
-			me.POST("/2fa", 
-				middleware.StrictRateLimiter(0.1, 2, "me:2fa", rateLimitObserver), // 6/min for 2FA changes
-				userHandler.ToggleTwoFactor,
+			me.POST("/2fa/setup",
+				rateLimits.StrictRateLimiter(0.1, 2, "me:2fa"), // 6/min for 2FA changes
+				twoFactorHandler.Setup,
+			)
+			me.POST("/2fa/enable",
+				rateLimits.StrictRateLimiter(0.1, 2, "me:2fa"),
+				twoFactorHandler.Enable,
+			)
+			me.POST("/2fa/recovery-codes",
+				rateLimits.StrictRateLimiter(0.1, 2, "me:2fa"),
+				twoFactorHandler.RegenerateRecoveryCodes,
+			)
+			me.DELETE("/2fa",
+				rateLimits.StrictRateLimiter(0.1, 2, "me:2fa"),
+				twoFactorHandler.Disable,
+			)
+			me.GET("/oauth", oauthHandler.List)
+			me.POST("/oauth/:provider",
+				rateLimits.StrictRateLimiter(0.1, 2, "me:oauth"), // 6/min for linking and unlinking
+				oauthHandler.Link,
+			)
+			me.DELETE("/oauth/:provider",
+				rateLimits.StrictRateLimiter(0.1, 2, "me:oauth"),
+				oauthHandler.Unlink,
+			)
+			me.GET("/sessions", sessionHandler.List)
+			me.DELETE("/sessions/:id",
+				rateLimits.StrictRateLimiter(0.5, 5, "me:sessions"), // 30/min for session revocations
+				sessionHandler.Revoke,
 			)
 			me.DELETE("", 
-				middleware.StrictRateLimiter(0.01, 1, "me:deactivate", rateLimitObserver), // 1/min for account deactivation
+				rateLimits.StrictRateLimiter(0.01, 1, "me:deactivate"), // 1/min for account deactivation
 				userHandler.DeactivateAccount,
 			)
+			me.POST("/erasure",
+				rateLimits.StrictRateLimiter(0.01, 1, "me:erasure"), // 1/min for erasure requests
+				complianceHandler.RequestErasure,
+			)
+			me.GET("/erasure", complianceHandler.ListMyErasures)
+			me.GET("/erasure/:id", complianceHandler.GetMyErasure)
+			me.POST("/data-exports",
+				rateLimits.StrictRateLimiter(0.01, 1, "me:data-export"), // 1/min for data exports
+				dataExportHandler.Request,
+			)
+			me.GET("/data-exports", dataExportHandler.List)
+			me.GET("/data-exports/:id", dataExportHandler.Get)
+			me.POST("/deletion",
+				rateLimits.StrictRateLimiter(0.01, 1, "me:deletion"), // 1/min for account deletion
+				accountDeletionHandler.Schedule,
+			)
+			me.GET("/deletion", accountDeletionHandler.Get)
+			me.DELETE("/deletion", accountDeletionHandler.Cancel)
+			me.GET("/blocks", blockHandler.ListBlocked)
+			me.POST("/blocks/:id",
+				rateLimits.StrictRateLimiter(0.5, 5, "me:block"), // 30/min for block changes
+				blockHandler.Block,
+			)
+			me.DELETE("/blocks/:id",
+				rateLimits.StrictRateLimiter(0.5, 5, "me:block"),
+				blockHandler.Unblock,
+			)
+			me.GET("/friend-suggestions",
+				rateLimits.StrictRateLimiter(1, 5, "me:suggestions"), // 60/min for suggestions
+				suggestionHandler.List,
+			)
+			me.GET("/friend-suggestions/dismissed", suggestionHandler.ListDismissed)
+			me.POST("/friend-suggestions/:id/dismiss",
+				rateLimits.StrictRateLimiter(0.5, 5, "me:suggestions:dismiss"), // 30/min for dismissals
+				suggestionHandler.Dismiss,
+			)
+			me.DELETE("/friend-suggestions/:id/dismiss",
+				rateLimits.StrictRateLimiter(0.5, 5, "me:suggestions:dismiss"),
+				suggestionHandler.Restore,
+			)
+			me.POST("/following/:id",
+				rateLimits.StrictRateLimiter(0.5, 5, "me:follow"), // 30/min for follow changes
+				followHandler.Follow,
+			)
+			me.DELETE("/following/:id",
+				rateLimits.StrictRateLimiter(0.5, 5, "me:follow"),
+				followHandler.Unfollow,
+			)
+			me.GET("/follow-suggestions",
+				rateLimits.StrictRateLimiter(1, 5, "me:suggestions"),
+				followHandler.Suggest,
+			)
+			me.GET("/insights",
+				rateLimits.StrictRateLimiter(0.5, 5, "me:insights"), // 30/min for insights
+				insightsHandler.Get,
+			)
+			me.POST("/verification",
+				rateLimits.StrictRateLimiter(0.05, 2, "me:verification"), // 3/min for verification requests
+				verificationHandler.Request,
+			)
+			me.GET("/verification", verificationHandler.Status)
+			me.GET("/close-friends", closeFriendsHandler.List)
+			me.POST("/close-friends/:id",
+				rateLimits.StrictRateLimiter(0.5, 5, "me:close-friends"), // 30/min for close friends changes
+				closeFriendsHandler.Add,
+			)
+			me.DELETE("/close-friends/:id",
+				rateLimits.StrictRateLimiter(0.5, 5, "me:close-friends"),
+				closeFriendsHandler.Remove,
+			)
+		}
+
+		// Admin compliance routes
+		admin := api.Group("/admin")
+		admin.Use(authMiddleware, httphandler.RequireAdmin(userService, cfg.TwoFactorRequireAdmins))
+		{
+			erasures := admin.Group("/compliance/erasures")
+			erasures.GET("", complianceHandler.ListErasures)
+			erasures.POST("", complianceHandler.AdminRequestErasure)
+			erasures.GET("/:id", complianceHandler.GetErasure)
+			erasures.GET("/:id/report", complianceHandler.GetErasureReport)
+			erasures.POST("/:id/retry", complianceHandler.RetryErasure)
+
+			admin.DELETE("/users/:id/2fa", twoFactorHandler.AdminReset)
+			admin.PUT("/users/:id/account-state", accountStateHandler.Set)
+			admin.DELETE("/users/:id/verification", verificationHandler.Revoke)
+			admin.GET("/audit-log", auditHandler.List)
+
+			verifications := admin.Group("/verifications")
+			verifications.GET("", verificationHandler.Queue)
+			verifications.PUT("/:id", verificationHandler.Review)
+
+			flags := admin.Group("/feature-flags")
+			flags.GET("", featureFlagHandler.List)
+			flags.GET("/:key", featureFlagHandler.Get)
+			flags.PUT("/:key", featureFlagHandler.Put)
+			flags.DELETE("/:key", featureFlagHandler.Delete)
 		}
 	}
 
@@ -248,6 +618,81 @@ func run() error {
 	if err := producer.Close(); err != nil {
 		slog.Error("Kafka producer close error", "error", err)
 	}
+	if err := searchIndexer.Close(); err != nil {
+		slog.Error("Kafka search index publisher close error", "error", err)
+	}
+	if err := erasureProducer.Close(); err != nil {
+		slog.Error("Kafka erasure producer close error", "error", err)
+	}
+	if err := notificationProducer.Close(); err != nil {
+		slog.Error("Kafka notification producer close error", "error", err)
+	}
+	if err := friendshipProducer.Close(); err != nil {
+		slog.Error("Kafka friendship producer close error", "error", err)
+	}
+	if err := followProducer.Close(); err != nil {
+		slog.Error("Kafka follow producer close error", "error", err)
+	}
+	if err := erasureConsumer.Close(); err != nil {
+		slog.Error("Kafka erasure consumer close error", "error", err)
+	}
+	if err := deletionProducer.Close(); err != nil {
+		slog.Error("Kafka deletion producer close error", "error", err)
+	}
+	if err := deletionConsumer.Close(); err != nil {
+		slog.Error("Kafka deletion consumer close error", "error", err)
+	}
+	if err := insightsPublisher.Close(); err != nil {
+		slog.Error("Kafka insights publisher close error", "error", err)
+	}
+	if err := insightsConsumer.Close(); err != nil {
+		slog.Error("Kafka insights consumer close error", "error", err)
+	}
+	if err := emailProducer.Close(); err != nil {
+		slog.Error("Kafka email producer close error", "error", err)
+	}
+	if emailConsumer != nil {
+		if err := emailConsumer.Close(); err != nil {
+			slog.Error("Kafka email consumer close error", "error", err)
+		}
+	}
 
 	return nil
 }
+
+// dialDataExporters connects to the DataExportService of every service that
+// contributes to a user's data export
+func dialDataExporters(cfg *config.Config) (map[string]exportpb.DataExportServiceClient, []*grpc.ClientConn, error) {
+	type target struct {
+		service string // Name the exporter checks service tokens against
+		addr    string
+	}
+	targets := map[string]target{
+		models.ErasureServiceMessaging:   {"messaging-app", net.JoinHostPort(cfg.MessagingGRPCHost, cfg.MessagingGRPCPort)},
+		models.ErasureServiceFeed:        {"feed-service", net.JoinHostPort(cfg.FeedGRPCHost, cfg.FeedGRPCPort)},
+		models.ErasureServiceEvents:      {"events-service", net.JoinHostPort(cfg.EventsGRPCHost, cfg.EventsGRPCPort)},
+		models.ErasureServiceMarketplace: {"marketplace-service", net.JoinHostPort(cfg.MarketplaceGRPCHost, cfg.MarketplaceGRPCPort)},
+		models.ErasureServiceStories:     {"story-service", net.JoinHostPort(cfg.StoryGRPCHost, cfg.StoryGRPCPort)},
+		models.ErasureServiceReels:       {"reel-service", net.JoinHostPort(cfg.ReelGRPCHost, cfg.ReelGRPCPort)},
+	}
+
+	clients := make(map[string]exportpb.DataExportServiceClient, len(targets))
+	conns := make([]*grpc.ClientConn, 0, len(targets))
+	for name, t := range targets {
+		conn, err := grpc.NewClient(t.addr,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(cfg.DataExportMaxResponseBytes)),
+			observability.GetGRPCDialOption(),
+			serviceauth.DialOption(t.service),
+		)
+		if err != nil {
+			for _, c := range conns {
+				c.Close()
+			}
+			return nil, nil, fmt.Errorf("failed to connect to %s exporter at %s: %w", name, t.addr, err)
+		}
+		clients[name] = exportpb.NewDataExportServiceClient(conn)
+		conns = append(conns, conn)
+	}
+	return clients, conns, nil
+}
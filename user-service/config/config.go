@@ -20,6 +20,7 @@ type Config struct {
 	// Database
 	MongoURI      string
 	DBName        string
+	AuditDBName   string // Dedicated database for the append-only audit log
 	Neo4jURI      string
 	Neo4jUser     string
 	Neo4jPassword string
@@ -33,10 +34,97 @@ type Config struct {
 	UserUpdatedTopic     string
 	FriendshipEventTopic string
 
+	// GDPR erasure
+	ErasureRequestTopic  string
+	ErasureProgressTopic string
+	ErasureMaxAttempts   int
+	ErasureAckTimeout    time.Duration
+	ErasureRetryInterval time.Duration
+
+	// Account deletion
+	AccountDeletionGracePeriod  time.Duration
+	AccountDeletionMaxAttempts  int
+	AccountDeletionAckTimeout   time.Duration
+	AccountDeletionPollInterval time.Duration
+
+	// Data export ("Download your information")
+	NotificationTopic          string
+	DataExportLinkTTL          time.Duration
+	DataExportRetention        time.Duration
+	DataExportPollInterval     time.Duration
+	DataExportServiceTimeout   time.Duration
+	DataExportMaxAttempts      int
+	DataExportMaxMediaBytes    int64
+	DataExportMaxResponseBytes int
+	StorageGRPCHost            string
+	StorageGRPCPort            string
+	MessagingGRPCHost          string
+	MessagingGRPCPort          string
+	FeedGRPCHost               string
+	FeedGRPCPort               string
+	EventsGRPCHost             string
+	EventsGRPCPort             string
+	MarketplaceGRPCHost        string
+	MarketplaceGRPCPort        string
+	StoryGRPCHost              string
+	StoryGRPCPort              string
+	ReelGRPCHost               string
+	ReelGRPCPort               string
+
+	// Two-factor authentication
+	TwoFactorEnabled       bool
+	TwoFactorIssuer        string
+	TwoFactorPreAuthTTL    time.Duration
+	TwoFactorMaxAttempts   int
+	TwoFactorAttemptWindow time.Duration
+	TwoFactorRequireAdmins bool
+
+	// Email verification and password reset
+	EmailTopic           string
+	AppBaseURL           string // Where the links in emails point
+	EmailVerificationTTL time.Duration
+	PasswordResetTTL     time.Duration
+	EmailResendCooldown  time.Duration
+
+	// Mailer worker, sending what arrives on EmailTopic
+	MailerEnabled bool
+	SMTPHost      string
+	SMTPPort      string
+	SMTPUsername  string
+	SMTPPassword  string
+	MailFrom      string
+
+	// Social login; a provider is offered when it has client IDs
+	GoogleClientIDs    []string
+	GoogleClientSecret string
+	AppleClientIDs     []string
+	AppleTeamID        string
+	AppleKeyID         string
+	ApplePrivateKey    string   // PEM, for exchanging authorization codes
+	OAuthRedirectURIs  []string // Redirect URIs codes may be exchanged for
+
+	// Friend suggestions
+	SuggestionCacheTTL time.Duration
+
+	// Username changes
+	UsernameChangeCooldown time.Duration
+	UsernameReservation    time.Duration // How long an old username stays reserved to its owner
+
 	// Security
-	JWTSecret       string
-	AccessTokenTTL  time.Duration
-	RefreshTokenTTL time.Duration
+	JWTSecret         string
+	AccessTokenTTL    time.Duration
+	RefreshTokenTTL   time.Duration
+	ServiceAuthSecret string // Signs the tokens presented to other services
+
+	// Access tokens are signed with JWTSigningKey, a PEM RSA private key,
+	// when set; its public key and those in JWTPublishedKeys are published
+	// at /.well-known/jwks.json. Rotating keeps the previous key published
+	// until the tokens it signed expire.
+	JWTSigningKey    string
+	JWTPublishedKeys string
+	// Until then access tokens signed with JWTSecret are still accepted
+	// alongside; once it passes only signed ones are
+	LegacyHS256Until time.Time
 
 	// Observability
 	JaegerOTLPEndpoint string
@@ -61,6 +149,41 @@ func LoadConfig() *Config {
 
 	cookieSecure, _ := strconv.ParseBool(getEnv("COOKIE_SECURE", "false"))
 
+	erasureMaxAttempts, _ := strconv.Atoi(getEnv("ERASURE_MAX_ATTEMPTS", "5"))
+	erasureAckTimeout, _ := strconv.Atoi(getEnv("ERASURE_ACK_TIMEOUT", "15"))       // minutes
+	erasureRetryInterval, _ := strconv.Atoi(getEnv("ERASURE_RETRY_INTERVAL", "60")) // seconds
+
+	deletionGraceDays, _ := strconv.Atoi(getEnv("ACCOUNT_DELETION_GRACE_DAYS", "30"))
+	deletionMaxAttempts, _ := strconv.Atoi(getEnv("ACCOUNT_DELETION_MAX_ATTEMPTS", "5"))
+	deletionAckTimeout, _ := strconv.Atoi(getEnv("ACCOUNT_DELETION_ACK_TIMEOUT", "15"))     // minutes
+	deletionPollInterval, _ := strconv.Atoi(getEnv("ACCOUNT_DELETION_POLL_INTERVAL", "60")) // seconds
+
+	dataExportLinkTTL, _ := strconv.Atoi(getEnv("DATA_EXPORT_LINK_TTL", "24"))                // hours
+	dataExportRetention, _ := strconv.Atoi(getEnv("DATA_EXPORT_RETENTION", "168"))            // hours
+	dataExportPollInterval, _ := strconv.Atoi(getEnv("DATA_EXPORT_POLL_INTERVAL", "30"))      // seconds
+	dataExportServiceTimeout, _ := strconv.Atoi(getEnv("DATA_EXPORT_SERVICE_TIMEOUT", "120")) // seconds
+	dataExportMaxAttempts, _ := strconv.Atoi(getEnv("DATA_EXPORT_MAX_ATTEMPTS", "3"))
+	dataExportMaxMedia, _ := strconv.ParseInt(getEnv("DATA_EXPORT_MAX_MEDIA_MB", "2048"), 10, 64)
+	dataExportMaxResponse, _ := strconv.Atoi(getEnv("DATA_EXPORT_MAX_RESPONSE_MB", "256"))
+
+	twoFactorEnabled, _ := strconv.ParseBool(getEnv("TWO_FACTOR_ENABLED", "true"))
+	twoFactorPreAuthTTL, _ := strconv.Atoi(getEnv("TWO_FACTOR_PREAUTH_TTL", "5")) // minutes
+	twoFactorMaxAttempts, _ := strconv.Atoi(getEnv("TWO_FACTOR_MAX_ATTEMPTS", "5"))
+	twoFactorAttemptWindow, _ := strconv.Atoi(getEnv("TWO_FACTOR_ATTEMPT_WINDOW", "15")) // minutes
+	twoFactorRequireAdmins, _ := strconv.ParseBool(getEnv("TWO_FACTOR_REQUIRE_ADMINS", "false"))
+
+	emailVerificationTTL, _ := strconv.Atoi(getEnv("EMAIL_VERIFICATION_TTL", "24")) // hours
+	passwordResetTTL, _ := strconv.Atoi(getEnv("PASSWORD_RESET_TTL", "30"))         // minutes
+	emailResendCooldown, _ := strconv.Atoi(getEnv("EMAIL_RESEND_COOLDOWN", "60"))   // seconds
+	mailerEnabled, _ := strconv.ParseBool(getEnv("MAILER_ENABLED", "true"))
+
+	suggestionCacheTTL, _ := strconv.Atoi(getEnv("SUGGESTION_CACHE_TTL", "10")) // minutes
+
+	usernameCooldownDays, _ := strconv.Atoi(getEnv("USERNAME_CHANGE_COOLDOWN_DAYS", "30"))
+	usernameReservationDays, _ := strconv.Atoi(getEnv("USERNAME_RESERVATION_DAYS", "14"))
+
+	legacyHS256Until, _ := time.Parse(time.RFC3339, getEnv("JWT_LEGACY_HS256_UNTIL", ""))
+
 	return &Config{
 		ServerPort:       getEnv("SERVER_PORT", "8083"), // Default user-service port
 		RateLimitEnabled: rateLimitEnabled,
@@ -69,6 +192,7 @@ func LoadConfig() *Config {
 
 		MongoURI:      getEnv("MONGO_URI", "mongodb://localhost:27017"),
 		DBName:        getEnv("DB_NAME", "connectify-v2"),
+		AuditDBName:   getEnv("AUDIT_DB_NAME", "connectify_audit"),
 		Neo4jURI:      getEnv("NEO4J_URI", "bolt://localhost:7687"),
 		Neo4jUser:     getEnv("NEO4J_USER", "neo4j"),
 		Neo4jPassword: getEnv("NEO4J_PASSWORD", "connectify"),
@@ -80,9 +204,81 @@ func LoadConfig() *Config {
 		UserUpdatedTopic:     getEnv("KAFKA_TOPIC_USER_UPDATED", "user-updated"),
 		FriendshipEventTopic: getEnv("KAFKA_TOPIC_FRIENDSHIP_EVENTS", "friendship-events"),
 
-		JWTSecret:       getEnv("JWT_SECRET", "very-secret-key"),
-		AccessTokenTTL:  time.Minute * time.Duration(accessTTL),
-		RefreshTokenTTL: time.Minute * time.Duration(refreshTTL),
+		ErasureRequestTopic:  getEnv("KAFKA_TOPIC_ERASURE_REQUESTS", "user-erasure-requests"),
+		ErasureProgressTopic: getEnv("KAFKA_TOPIC_ERASURE_PROGRESS", "user-erasure-progress"),
+		ErasureMaxAttempts:   erasureMaxAttempts,
+		ErasureAckTimeout:    time.Minute * time.Duration(erasureAckTimeout),
+		ErasureRetryInterval: time.Second * time.Duration(erasureRetryInterval),
+
+		AccountDeletionGracePeriod:  24 * time.Hour * time.Duration(deletionGraceDays),
+		AccountDeletionMaxAttempts:  deletionMaxAttempts,
+		AccountDeletionAckTimeout:   time.Minute * time.Duration(deletionAckTimeout),
+		AccountDeletionPollInterval: time.Second * time.Duration(deletionPollInterval),
+
+		NotificationTopic:          getEnv("KAFKA_TOPIC_NOTIFICATIONS", "notifications_events"),
+		DataExportLinkTTL:          time.Hour * time.Duration(dataExportLinkTTL),
+		DataExportRetention:        time.Hour * time.Duration(dataExportRetention),
+		DataExportPollInterval:     time.Second * time.Duration(dataExportPollInterval),
+		DataExportServiceTimeout:   time.Second * time.Duration(dataExportServiceTimeout),
+		DataExportMaxAttempts:      dataExportMaxAttempts,
+		DataExportMaxMediaBytes:    dataExportMaxMedia << 20,
+		DataExportMaxResponseBytes: dataExportMaxResponse << 20,
+		StorageGRPCHost:            getEnv("STORAGE_GRPC_HOST", "localhost"),
+		StorageGRPCPort:            getEnv("STORAGE_GRPC_PORT", "9087"),
+		MessagingGRPCHost:          getEnv("MESSAGING_GRPC_HOST", "localhost"),
+		MessagingGRPCPort:          getEnv("MESSAGING_GRPC_PORT", "9094"),
+		FeedGRPCHost:               getEnv("FEED_GRPC_HOST", "localhost"),
+		FeedGRPCPort:               getEnv("FEED_GRPC_PORT", "9098"),
+		EventsGRPCHost:             getEnv("EVENTS_GRPC_HOST", "localhost"),
+		EventsGRPCPort:             getEnv("EVENTS_GRPC_PORT", "9096"),
+		MarketplaceGRPCHost:        getEnv("MARKETPLACE_GRPC_HOST", "localhost"),
+		MarketplaceGRPCPort:        getEnv("MARKETPLACE_GRPC_PORT", "9097"),
+		StoryGRPCHost:              getEnv("STORY_GRPC_HOST", "localhost"),
+		StoryGRPCPort:              getEnv("STORY_GRPC_PORT", "9097"),
+		ReelGRPCHost:               getEnv("REEL_GRPC_HOST", "localhost"),
+		ReelGRPCPort:               getEnv("REEL_GRPC_PORT", "9096"),
+
+		TwoFactorEnabled:       twoFactorEnabled,
+		TwoFactorIssuer:        getEnv("TWO_FACTOR_ISSUER", "Connectify"),
+		TwoFactorPreAuthTTL:    time.Minute * time.Duration(twoFactorPreAuthTTL),
+		TwoFactorMaxAttempts:   twoFactorMaxAttempts,
+		TwoFactorAttemptWindow: time.Minute * time.Duration(twoFactorAttemptWindow),
+		TwoFactorRequireAdmins: twoFactorRequireAdmins,
+
+		EmailTopic:           getEnv("KAFKA_TOPIC_EMAILS", "email-requests"),
+		AppBaseURL:           strings.TrimSuffix(getEnv("APP_BASE_URL", "http://localhost:5173"), "/"),
+		EmailVerificationTTL: time.Hour * time.Duration(emailVerificationTTL),
+		PasswordResetTTL:     time.Minute * time.Duration(passwordResetTTL),
+		EmailResendCooldown:  time.Second * time.Duration(emailResendCooldown),
+
+		MailerEnabled: mailerEnabled,
+		SMTPHost:      getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:      getEnv("SMTP_PORT", "1025"),
+		SMTPUsername:  getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:  getEnv("SMTP_PASSWORD", ""),
+		MailFrom:      getEnv("MAIL_FROM", "Connectify <no-reply@connectify.local>"),
+
+		GoogleClientIDs:    splitList(getEnv("GOOGLE_CLIENT_IDS", "")),
+		GoogleClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
+		AppleClientIDs:     splitList(getEnv("APPLE_CLIENT_IDS", "")),
+		AppleTeamID:        getEnv("APPLE_TEAM_ID", ""),
+		AppleKeyID:         getEnv("APPLE_KEY_ID", ""),
+		ApplePrivateKey:    getEnv("APPLE_PRIVATE_KEY", ""),
+		OAuthRedirectURIs:  splitList(getEnv("OAUTH_REDIRECT_URIS", "")),
+
+		SuggestionCacheTTL: time.Minute * time.Duration(suggestionCacheTTL),
+
+		UsernameChangeCooldown: 24 * time.Hour * time.Duration(usernameCooldownDays),
+		UsernameReservation:    24 * time.Hour * time.Duration(usernameReservationDays),
+
+		JWTSecret:         getEnv("JWT_SECRET", "very-secret-key"),
+		AccessTokenTTL:    time.Minute * time.Duration(accessTTL),
+		RefreshTokenTTL:   time.Minute * time.Duration(refreshTTL),
+		ServiceAuthSecret: getEnv("SERVICE_AUTH_SECRET", ""),
+
+		JWTSigningKey:    pemEnv("JWT_SIGNING_KEY"),
+		JWTPublishedKeys: pemEnv("JWT_PUBLISHED_KEYS"),
+		LegacyHS256Until: legacyHS256Until,
 
 		JaegerOTLPEndpoint: getEnv("JAEGER_OTLP_ENDPOINT", "localhost:4317"),
 
@@ -98,3 +294,20 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// pemEnv reads PEM blocks, which may have their newlines escaped as \n to
+// fit on one line
+func pemEnv(key string) string {
+	return strings.ReplaceAll(getEnv(key, ""), `\n`, "\n")
+}
+
+// splitList splits a comma-separated setting, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
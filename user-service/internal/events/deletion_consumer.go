@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	sharedevents "github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/segmentio/kafka-go"
+)
+
+// DeletionProgressHandler applies a participant's account deletion acknowledgement
+type DeletionProgressHandler interface {
+	RecordProgress(ctx context.Context, evt sharedevents.UserDeletionProgressEvent) error
+}
+
+// DeletionProgressConsumer feeds participant acknowledgements into the account deletion saga
+type DeletionProgressConsumer struct {
+	reader  *kafka.Reader
+	handler DeletionProgressHandler
+	logger  *slog.Logger
+}
+
+func NewDeletionProgressConsumer(brokers []string, topic string, handler DeletionProgressHandler, logger *slog.Logger) *DeletionProgressConsumer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &DeletionProgressConsumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:  brokers,
+			Topic:    topic,
+			GroupID:  "user-service-deletion-progress",
+			MinBytes: 1,
+			MaxBytes: 1e6,
+		}),
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Start consumes acknowledgements until ctx is cancelled
+func (c *DeletionProgressConsumer) Start(ctx context.Context) {
+	c.logger.Info("Account deletion progress consumer started", "topic", c.reader.Config().Topic)
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			c.logger.Error("Failed to fetch account deletion progress", "error", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		var evt sharedevents.UserDeletionProgressEvent
+		if err := json.Unmarshal(msg.Value, &evt); err != nil {
+			c.logger.Error("Dropping malformed account deletion progress event", "error", err)
+		} else if err := c.handler.RecordProgress(ctx, evt); err != nil {
+			// Unrecorded acks are recovered by the saga's retry sweep
+			c.logger.Error("Failed to record account deletion progress", "deletion_id", evt.DeletionID, "service", evt.Service, "error", err)
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			c.logger.Error("Failed to commit account deletion progress offset", "error", err)
+		}
+	}
+}
+
+func (c *DeletionProgressConsumer) Close() error {
+	return c.reader.Close()
+}
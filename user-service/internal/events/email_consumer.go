@@ -0,0 +1,93 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	sharedevents "github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/segmentio/kafka-go"
+)
+
+// emailRetryBackoff spaces out the attempts to deliver one email
+var emailRetryBackoff = []time.Duration{0, time.Second, 5 * time.Second}
+
+// EmailDeliverer renders and sends a requested email
+type EmailDeliverer interface {
+	Deliver(ctx context.Context, evt sharedevents.EmailRequestedEvent) error
+}
+
+// EmailConsumer is the mailer worker: it sends the emails requested on the
+// email topic
+type EmailConsumer struct {
+	reader    *kafka.Reader
+	deliverer EmailDeliverer
+	logger    *slog.Logger
+}
+
+func NewEmailConsumer(brokers []string, topic string, deliverer EmailDeliverer, logger *slog.Logger) *EmailConsumer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &EmailConsumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:  brokers,
+			Topic:    topic,
+			GroupID:  "user-service-mailer",
+			MinBytes: 1,
+			MaxBytes: 1e6,
+		}),
+		deliverer: deliverer,
+		logger:    logger,
+	}
+}
+
+// Start sends requested emails until ctx is cancelled. An email that still
+// fails after a few attempts is dropped; the links it carried can be asked
+// for again.
+func (c *EmailConsumer) Start(ctx context.Context) {
+	c.logger.Info("Email consumer started", "topic", c.reader.Config().Topic)
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			c.logger.Error("Failed to fetch email request", "error", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		var evt sharedevents.EmailRequestedEvent
+		if err := json.Unmarshal(msg.Value, &evt); err != nil {
+			c.logger.Error("Dropping malformed email request", "error", err)
+		} else if err := c.deliver(ctx, evt); err != nil {
+			c.logger.Error("Failed to send email", "template", evt.Template, "user_id", evt.UserID, "error", err)
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			c.logger.Error("Failed to commit email offset", "error", err)
+		}
+	}
+}
+
+func (c *EmailConsumer) deliver(ctx context.Context, evt sharedevents.EmailRequestedEvent) error {
+	var err error
+	for _, wait := range emailRetryBackoff {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		if err = c.deliverer.Deliver(ctx, evt); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (c *EmailConsumer) Close() error {
+	return c.reader.Close()
+}
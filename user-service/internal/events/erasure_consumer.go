@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	sharedevents "github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/segmentio/kafka-go"
+)
+
+// ErasureProgressHandler applies a participant's erasure acknowledgement
+type ErasureProgressHandler interface {
+	RecordProgress(ctx context.Context, evt sharedevents.UserErasureProgressEvent) error
+}
+
+// ErasureProgressConsumer feeds participant acknowledgements into the erasure orchestrator
+type ErasureProgressConsumer struct {
+	reader  *kafka.Reader
+	handler ErasureProgressHandler
+	logger  *slog.Logger
+}
+
+func NewErasureProgressConsumer(brokers []string, topic string, handler ErasureProgressHandler, logger *slog.Logger) *ErasureProgressConsumer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ErasureProgressConsumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:  brokers,
+			Topic:    topic,
+			GroupID:  "user-service-erasure-progress",
+			MinBytes: 1,
+			MaxBytes: 1e6,
+		}),
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Start consumes acknowledgements until ctx is cancelled
+func (c *ErasureProgressConsumer) Start(ctx context.Context) {
+	c.logger.Info("Erasure progress consumer started", "topic", c.reader.Config().Topic)
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			c.logger.Error("Failed to fetch erasure progress", "error", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		var evt sharedevents.UserErasureProgressEvent
+		if err := json.Unmarshal(msg.Value, &evt); err != nil {
+			c.logger.Error("Dropping malformed erasure progress event", "error", err)
+		} else if err := c.handler.RecordProgress(ctx, evt); err != nil {
+			// Unrecorded acks are recovered by the orchestrator's retry sweep
+			c.logger.Error("Failed to record erasure progress", "request_id", evt.RequestID, "service", evt.Service, "error", err)
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			c.logger.Error("Failed to commit erasure progress offset", "error", err)
+		}
+	}
+}
+
+func (c *ErasureProgressConsumer) Close() error {
+	return c.reader.Close()
+}
@@ -0,0 +1,74 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	sharedevents "github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/segmentio/kafka-go"
+)
+
+// InsightRecorder aggregates an interaction into its owner's insights
+type InsightRecorder interface {
+	RecordInsight(ctx context.Context, evt sharedevents.InsightEvent) error
+}
+
+// InsightsConsumer feeds profile views and post interactions from every
+// service into creators' insights
+type InsightsConsumer struct {
+	reader   *kafka.Reader
+	recorder InsightRecorder
+	logger   *slog.Logger
+}
+
+func NewInsightsConsumer(brokers []string, topic string, recorder InsightRecorder, logger *slog.Logger) *InsightsConsumer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &InsightsConsumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:  brokers,
+			Topic:    topic,
+			GroupID:  "user-service-insights",
+			MinBytes: 1,
+			MaxBytes: 1e6,
+		}),
+		recorder: recorder,
+		logger:   logger,
+	}
+}
+
+// Start consumes insight events until ctx is cancelled. Events that fail to
+// record are dropped: insights are best effort.
+func (c *InsightsConsumer) Start(ctx context.Context) {
+	c.logger.Info("Insights consumer started", "topic", c.reader.Config().Topic)
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			c.logger.Error("Failed to fetch insight event", "error", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		var evt sharedevents.InsightEvent
+		if err := json.Unmarshal(msg.Value, &evt); err != nil {
+			c.logger.Error("Dropping malformed insight event", "error", err)
+		} else if err := c.recorder.RecordInsight(ctx, evt); err != nil {
+			c.logger.Error("Failed to record insight", "owner_id", evt.OwnerID, "metric", evt.Metric, "error", err)
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			c.logger.Error("Failed to commit insight offset", "error", err)
+		}
+	}
+}
+
+func (c *InsightsConsumer) Close() error {
+	return c.reader.Close()
+}
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"user-service/internal/repository"
 	"user-service/internal/service"
 
@@ -19,6 +20,7 @@ type UserHandler struct {
 	pb.UnimplementedUserServiceServer
 	userService *service.UserService
 	graphRepo   *repository.GraphRepository
+	suggestions *service.SuggestionService
 }
 
 func NewUserHandler(userService *service.UserService, graphRepo *repository.GraphRepository) *UserHandler {
@@ -28,6 +30,11 @@ func NewUserHandler(userService *service.UserService, graphRepo *repository.Grap
 	}
 }
 
+// SetSuggestionService enables the friend suggestion RPCs
+func (h *UserHandler) SetSuggestionService(suggestions *service.SuggestionService) {
+	h.suggestions = suggestions
+}
+
 func (h *UserHandler) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
 	oid, err := primitive.ObjectIDFromHex(req.UserId)
 	if err != nil {
@@ -161,6 +168,34 @@ func (h *UserHandler) GetFriendIDs(ctx context.Context, req *pb.GetFriendIDsRequ
 	return &pb.GetFriendIDsResponse{FriendIds: friendIDs}, nil
 }
 
+// maxFollowingIDs caps how much of the follow graph one call returns
+const maxFollowingIDs = 500
+
+// GetFollowingIDs returns the users the user follows, most recently followed
+// first
+func (h *UserHandler) GetFollowingIDs(ctx context.Context, req *pb.GetFollowingIDsRequest) (*pb.GetFollowingIDsResponse, error) {
+	if h.graphRepo == nil {
+		return nil, status.Error(codes.Unavailable, "follow graph unavailable")
+	}
+
+	userID, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 || limit > maxFollowingIDs {
+		limit = maxFollowingIDs
+	}
+
+	userIDs, err := h.graphRepo.GetFollowingIDs(ctx, userID, 0, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetFollowingIDsResponse{UserIds: userIDs}, nil
+}
+
 func (h *UserHandler) CheckRelationship(ctx context.Context, req *pb.CheckRelationshipRequest) (*pb.CheckRelationshipResponse, error) {
 	userID, err := primitive.ObjectIDFromHex(req.UserId)
 	if err != nil {
@@ -176,24 +211,137 @@ func (h *UserHandler) CheckRelationship(ctx context.Context, req *pb.CheckRelati
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	isCloseFriend := false
+	if isFriend && h.graphRepo != nil {
+		isCloseFriend, err = h.graphRepo.IsCloseFriend(ctx, targetID, userID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
 	return &pb.CheckRelationshipResponse{
 		IsFriend:          isFriend,
 		IsBlockedByUser:   blockedByUser,
 		IsBlockedByTarget: blockedByTarget,
 		IsFollowing:       false, // TODO: Implement following
+		IsCloseFriend:     isCloseFriend,
 	}, nil
 }
 
+// GetCloseFriendIDs returns the user's close friends list
+func (h *UserHandler) GetCloseFriendIDs(ctx context.Context, req *pb.GetCloseFriendIDsRequest) (*pb.GetCloseFriendIDsResponse, error) {
+	return h.closeFriendIDs(ctx, req, h.graphRepo.GetCloseFriendIDs)
+}
+
+// GetCloseFriendOfIDs returns the friends who have the user on their close
+// friends list
+func (h *UserHandler) GetCloseFriendOfIDs(ctx context.Context, req *pb.GetCloseFriendIDsRequest) (*pb.GetCloseFriendIDsResponse, error) {
+	return h.closeFriendIDs(ctx, req, h.graphRepo.GetCloseFriendOfIDs)
+}
+
+func (h *UserHandler) closeFriendIDs(ctx context.Context, req *pb.GetCloseFriendIDsRequest, lookup func(context.Context, primitive.ObjectID) ([]string, error)) (*pb.GetCloseFriendIDsResponse, error) {
+	if h.graphRepo == nil {
+		return nil, status.Error(codes.Unavailable, "friend graph unavailable")
+	}
+
+	userID, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+
+	ids, err := lookup(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetCloseFriendIDsResponse{UserIds: ids}, nil
+}
+
+func (h *UserHandler) GetFriendSuggestions(ctx context.Context, req *pb.GetFriendSuggestionsRequest) (*pb.GetFriendSuggestionsResponse, error) {
+	if h.suggestions == nil {
+		return nil, status.Error(codes.Unavailable, "friend suggestions unavailable")
+	}
+
+	userID, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+
+	suggestions, err := h.suggestions.Suggest(ctx, userID, int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	pbSuggestions := make([]*pb.FriendSuggestion, 0, len(suggestions))
+	for _, s := range suggestions {
+		pbSuggestions = append(pbSuggestions, &pb.FriendSuggestion{
+			UserId:            s.User.ID.Hex(),
+			Username:          s.User.Username,
+			FullName:          s.User.FullName,
+			Avatar:            s.User.Avatar,
+			MutualFriends:     int32(s.MutualFriends),
+			SharedCommunities: int32(s.SharedCommunities),
+			SharedEvents:      int32(s.SharedEvents),
+			Score:             s.Score,
+		})
+	}
+
+	return &pb.GetFriendSuggestionsResponse{Suggestions: pbSuggestions}, nil
+}
+
+func (h *UserHandler) DismissFriendSuggestion(ctx context.Context, req *pb.DismissFriendSuggestionRequest) (*pb.DismissFriendSuggestionResponse, error) {
+	if h.suggestions == nil {
+		return nil, status.Error(codes.Unavailable, "friend suggestions unavailable")
+	}
+
+	userID, err := primitive.ObjectIDFromHex(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	targetID, err := primitive.ObjectIDFromHex(req.TargetId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid target id")
+	}
+
+	if err := h.suggestions.Dismiss(ctx, userID, targetID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrCannotDismissSelf):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.DismissFriendSuggestionResponse{Success: true}, nil
+}
+
 func (h *UserHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
 	oid, err := primitive.ObjectIDFromHex(req.UserId)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid user id")
 	}
 
-	update := bson.M{}
+	// Usernames change through ChangeUsername, which enforces the cooldown
+	// and keeps the old one resolving
 	if req.Username != "" {
-		update["username"] = req.Username
+		_, err := h.userService.ChangeUsername(ctx, oid, req.Username)
+		switch {
+		case err == nil, errors.Is(err, service.ErrUsernameUnchanged):
+		case errors.Is(err, service.ErrInvalidUsername):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrUsernameTaken):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case errors.Is(err, service.ErrUsernameChangeCooldown):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, service.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 	}
+
+	update := bson.M{}
 	if req.Email != "" {
 		update["email"] = req.Email
 	}
@@ -226,7 +374,14 @@ func (h *UserHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 	}
 
 	if len(update) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "no fields to update")
+		if req.Username == "" {
+			return nil, status.Error(codes.InvalidArgument, "no fields to update")
+		}
+		user, err := h.userService.GetUserByID(ctx, oid)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		return &pb.UpdateUserResponse{User: mapModelToProto(user)}, nil
 	}
 
 	user, err := h.userService.UpdateUser(ctx, oid, update)
@@ -359,7 +514,7 @@ func mapModelToProto(user *models.User) *pb.User {
 		CoverPicture:        user.CoverPicture,
 		Bio:                 user.Bio,
 		Role:                "",
-		IsVerified:          user.EmailVerified,
+		IsVerified:          user.Verified,
 		Gender:              user.Gender,
 		Location:            user.Location,
 		PhoneNumber:         user.PhoneNumber,
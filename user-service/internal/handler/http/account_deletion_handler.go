@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"user-service/internal/service"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AccountDeletionHandler lets users permanently delete their account
+type AccountDeletionHandler struct {
+	deletionService AccountDeletionService
+}
+
+func NewAccountDeletionHandler(deletionService AccountDeletionService) *AccountDeletionHandler {
+	return &AccountDeletionHandler{deletionService: deletionService}
+}
+
+// Schedule deletes the authenticated user's account once the grace period ends
+func (h *AccountDeletionHandler) Schedule(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	var req models.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+
+	deletion, err := h.deletionService.Schedule(c.Request.Context(), userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrDeletionPasswordInvalid):
+			RespondWithError(c, http.StatusUnauthorized, err.Error(), ErrCodeInvalidCredentials)
+		case errors.Is(err, service.ErrUserNotFound):
+			RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeUserNotFound)
+		default:
+			RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		}
+		return
+	}
+	RespondWithSuccess(c, http.StatusAccepted, "account deletion scheduled", deletion)
+}
+
+// Get returns the authenticated user's latest account deletion
+func (h *AccountDeletionHandler) Get(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	deletion, err := h.deletionService.Get(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrAccountDeletionNotFound) {
+			RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeNotFound)
+			return
+		}
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, deletion)
+}
+
+// Cancel keeps the authenticated user's account while it is still in its grace period
+func (h *AccountDeletionHandler) Cancel(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	deletion, err := h.deletionService.Cancel(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrAccountDeletionNotFound) {
+			RespondWithError(c, http.StatusNotFound, "no scheduled account deletion to cancel", ErrCodeNotFound)
+			return
+		}
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "account deletion cancelled", deletion)
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"user-service/internal/service"
+	"user-service/internal/validation"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AccountEmailHandler verifies users' email addresses and resets forgotten
+// passwords through links mailed to them
+type AccountEmailHandler struct {
+	accountEmailService AccountEmailService
+}
+
+func NewAccountEmailHandler(accountEmailService AccountEmailService) *AccountEmailHandler {
+	return &AccountEmailHandler{accountEmailService: accountEmailService}
+}
+
+// SendVerification mails the authenticated user a new verification link
+func (h *AccountEmailHandler) SendVerification(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	if err := h.accountEmailService.SendVerification(c.Request.Context(), userID); err != nil {
+		respondAccountEmailError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusAccepted, "verification email sent")
+}
+
+// VerifyEmail confirms an email address with the token from its link. It
+// needs no session, as the link may be opened on another device.
+func (h *AccountEmailHandler) VerifyEmail(c *gin.Context) {
+	var req models.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+
+	if err := h.accountEmailService.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		respondAccountEmailError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "email verified")
+}
+
+// ForgotPassword mails a reset link. The answer is the same whether or not
+// an account uses the address.
+func (h *AccountEmailHandler) ForgotPassword(c *gin.Context) {
+	var req models.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+
+	if err := h.accountEmailService.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		respondAccountEmailError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusAccepted, "if an account uses this email, a reset link is on its way")
+}
+
+// ResetPassword sets a new password with the token from a reset link
+func (h *AccountEmailHandler) ResetPassword(c *gin.Context) {
+	var req models.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+	if err := validation.ValidatePassword(req.NewPassword); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeWeakPassword)
+		return
+	}
+
+	if err := h.accountEmailService.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		respondAccountEmailError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "password reset, sign in with your new password")
+}
+
+func respondAccountEmailError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidToken):
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeInvalidToken)
+	case errors.Is(err, service.ErrUserNotFound):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeUserNotFound)
+	case errors.Is(err, service.ErrEmailAlreadyVerified):
+		RespondWithError(c, http.StatusConflict, err.Error(), ErrCodeConflict)
+	case errors.Is(err, service.ErrEmailRecentlySent):
+		RespondWithError(c, http.StatusTooManyRequests, err.Error(), ErrCodeRateLimited)
+	default:
+		RespondWithError(c, http.StatusInternalServerError, "Failed to process request", ErrCodeInternalError)
+	}
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+	"user-service/internal/service"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AccountStateHandler lets admins limit, suspend, ban and reinstate users
+type AccountStateHandler struct {
+	accountStateService AccountStateService
+}
+
+func NewAccountStateHandler(accountStateService AccountStateService) *AccountStateHandler {
+	return &AccountStateHandler{accountStateService: accountStateService}
+}
+
+// Set changes the standing of the user in the path
+func (h *AccountStateHandler) Set(c *gin.Context) {
+	adminID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+
+	var req models.SetAccountStateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+
+	user, err := h.accountStateService.SetAccountState(c.Request.Context(), adminID, userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidSuspension), errors.Is(err, service.ErrCannotRestrictAdmin):
+			RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		case errors.Is(err, service.ErrUserNotFound):
+			RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeUserNotFound)
+		default:
+			RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		}
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "account state updated", gin.H{
+		"id":              user.ID,
+		"account_state":   user.EffectiveAccountState(time.Now()),
+		"suspended_until": user.SuspendedUntil,
+	})
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserLookup resolves the authenticated user for role checks
+type UserLookup interface {
+	GetUserByID(ctx context.Context, id primitive.ObjectID) (*models.User, error)
+}
+
+// RequireAdmin rejects requests from users without the admin role, and with
+// requireTwoFactor also from admins who have not turned on two-factor
+// authentication. Must run after the auth middleware has set user_id.
+func RequireAdmin(users UserLookup, requireTwoFactor bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr := c.GetString("user_id")
+		userID, err := primitive.ObjectIDFromHex(userIDStr)
+		if err != nil {
+			RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+			c.Abort()
+			return
+		}
+
+		user, err := users.GetUserByID(c.Request.Context(), userID)
+		if err != nil || !user.IsAdmin() {
+			RespondWithError(c, http.StatusForbidden, "Admin access required", ErrCodeForbidden)
+			c.Abort()
+			return
+		}
+		if requireTwoFactor && !user.TwoFactorEnabled {
+			RespondWithError(c, http.StatusForbidden, "Enable two-factor authentication to use admin endpoints", ErrCodeForbidden)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditHandler lets admins search the audit log every service writes to
+type AuditHandler struct {
+	auditLog AuditLog
+}
+
+func NewAuditHandler(auditLog AuditLog) *AuditHandler {
+	return &AuditHandler{auditLog: auditLog}
+}
+
+// List returns audit entries, newest first, filtered by actor_id, target_type,
+// target_id, action and service, and by a from/to time range in RFC 3339
+func (h *AuditHandler) List(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "50"), 10, 64)
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	filter := audit.Filter{
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+		Action:     models.AuditAction(c.Query("action")),
+		Service:    c.Query("service"),
+	}
+	if actor := c.Query("actor_id"); actor != "" {
+		actorID, err := primitive.ObjectIDFromHex(actor)
+		if err != nil {
+			RespondWithError(c, http.StatusBadRequest, "Invalid actor ID format", ErrCodeValidation)
+			return
+		}
+		filter.ActorID = &actorID
+	}
+	var ok bool
+	if filter.From, ok = parseAuditTime(c, "from"); !ok {
+		return
+	}
+	if filter.To, ok = parseAuditTime(c, "to"); !ok {
+		return
+	}
+
+	entries, total, err := h.auditLog.Query(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, models.AuditLogResponse{Entries: entries, Total: total, Page: page, Limit: limit})
+}
+
+func parseAuditTime(c *gin.Context, param string) (time.Time, bool) {
+	value := c.Query(param)
+	if value == "" {
+		return time.Time{}, true
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid "+param+" time, expected RFC 3339", ErrCodeValidation)
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"user-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BlockHandler lets users block others and list who they have blocked
+type BlockHandler struct {
+	blockService BlockService
+}
+
+func NewBlockHandler(blockService BlockService) *BlockHandler {
+	return &BlockHandler{blockService: blockService}
+}
+
+// ListBlocked returns the users the authenticated user has blocked
+func (h *BlockHandler) ListBlocked(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	users, err := h.blockService.ListBlocked(c.Request.Context(), userID)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, users)
+}
+
+// Block blocks the user in the path
+func (h *BlockHandler) Block(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	targetID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+
+	block, err := h.blockService.Block(c.Request.Context(), userID, targetID)
+	if err != nil {
+		respondBlockError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusCreated, "user blocked", block)
+}
+
+// Unblock lifts the authenticated user's block on the user in the path
+func (h *BlockHandler) Unblock(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	targetID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+
+	if err := h.blockService.Unblock(c.Request.Context(), userID, targetID); err != nil {
+		respondBlockError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "user unblocked", nil)
+}
+
+func respondBlockError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCannotBlockSelf):
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+	case errors.Is(err, service.ErrUserNotFound):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeUserNotFound)
+	case errors.Is(err, service.ErrNotBlocked):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeNotFound)
+	case errors.Is(err, service.ErrAlreadyBlocked):
+		RespondWithError(c, http.StatusConflict, err.Error(), ErrCodeAlreadyBlocked)
+	default:
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+	}
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"user-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CloseFriendsHandler manages the authenticated user's close friends list
+type CloseFriendsHandler struct {
+	closeFriendsService CloseFriendsService
+}
+
+func NewCloseFriendsHandler(closeFriendsService CloseFriendsService) *CloseFriendsHandler {
+	return &CloseFriendsHandler{closeFriendsService: closeFriendsService}
+}
+
+// List returns the authenticated user's close friends
+func (h *CloseFriendsHandler) List(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	users, err := h.closeFriendsService.List(c.Request.Context(), userID)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, users)
+}
+
+// Add puts the friend in the path on the close friends list
+func (h *CloseFriendsHandler) Add(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	friendID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+
+	if err := h.closeFriendsService.Add(c.Request.Context(), userID, friendID); err != nil {
+		respondCloseFriendsError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "close friend added", nil)
+}
+
+// Remove takes the user in the path off the close friends list
+func (h *CloseFriendsHandler) Remove(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	friendID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+
+	if err := h.closeFriendsService.Remove(c.Request.Context(), userID, friendID); err != nil {
+		respondCloseFriendsError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "close friend removed", nil)
+}
+
+func respondCloseFriendsError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCloseFriendNotFriend):
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+	case errors.Is(err, service.ErrUserNotFound):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeUserNotFound)
+	case errors.Is(err, service.ErrNotCloseFriend):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeNotFound)
+	default:
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+	}
+}
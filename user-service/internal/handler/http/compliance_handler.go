@@ -0,0 +1,190 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"user-service/internal/service"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ComplianceHandler exposes GDPR erasure status to users and admins
+type ComplianceHandler struct {
+	erasureService ErasureService
+}
+
+func NewComplianceHandler(erasureService ErasureService) *ComplianceHandler {
+	return &ComplianceHandler{erasureService: erasureService}
+}
+
+// RequestErasure starts erasure of the authenticated user's data
+func (h *ComplianceHandler) RequestErasure(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	// Body is optional
+	_ = c.ShouldBindJSON(&req)
+
+	erasure, err := h.erasureService.RequestErasure(c.Request.Context(), userID, userID, req.Reason)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithSuccess(c, http.StatusAccepted, "erasure request accepted", erasure)
+}
+
+// ListMyErasures returns the authenticated user's erasure requests
+func (h *ComplianceHandler) ListMyErasures(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	requests, err := h.erasureService.ListUserRequests(c.Request.Context(), userID)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, requests)
+}
+
+// GetMyErasure returns one of the authenticated user's erasure requests
+func (h *ComplianceHandler) GetMyErasure(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	requestID, ok := parseRequestID(c)
+	if !ok {
+		return
+	}
+
+	req, err := h.erasureService.GetRequestForUser(c.Request.Context(), userID, requestID)
+	if err != nil {
+		respondErasureError(c, err)
+		return
+	}
+	RespondWithData(c, http.StatusOK, req)
+}
+
+// AdminRequestErasure starts erasure on behalf of a user
+func (h *ComplianceHandler) AdminRequestErasure(c *gin.Context) {
+	adminID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	var req struct {
+		UserID string `json:"user_id" binding:"required"`
+		Reason string `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+	userID, err := primitive.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+
+	erasure, err := h.erasureService.RequestErasure(c.Request.Context(), userID, adminID, req.Reason)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithSuccess(c, http.StatusAccepted, "erasure request accepted", erasure)
+}
+
+// ListErasures returns erasure requests for admins, optionally filtered by status
+func (h *ComplianceHandler) ListErasures(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	status := models.ErasureStatus(c.Query("status"))
+
+	resp, err := h.erasureService.ListRequests(c.Request.Context(), status, limit, page)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, resp)
+}
+
+// GetErasure returns any erasure request for admins
+func (h *ComplianceHandler) GetErasure(c *gin.Context) {
+	requestID, ok := parseRequestID(c)
+	if !ok {
+		return
+	}
+
+	req, err := h.erasureService.GetRequest(c.Request.Context(), requestID)
+	if err != nil {
+		respondErasureError(c, err)
+		return
+	}
+	RespondWithData(c, http.StatusOK, req)
+}
+
+// GetErasureReport returns the auditable completion report for a request
+func (h *ComplianceHandler) GetErasureReport(c *gin.Context) {
+	requestID, ok := parseRequestID(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.erasureService.Report(c.Request.Context(), requestID)
+	if err != nil {
+		respondErasureError(c, err)
+		return
+	}
+	RespondWithData(c, http.StatusOK, report)
+}
+
+// RetryErasure redispatches the failed services of a request
+func (h *ComplianceHandler) RetryErasure(c *gin.Context) {
+	adminID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	requestID, ok := parseRequestID(c)
+	if !ok {
+		return
+	}
+
+	req, err := h.erasureService.RetryFailed(c.Request.Context(), adminID, requestID)
+	if err != nil {
+		respondErasureError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusAccepted, "failed services redispatched", req)
+}
+
+func parseRequestID(c *gin.Context) (primitive.ObjectID, bool) {
+	requestID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid request ID format", ErrCodeValidation)
+		return primitive.NilObjectID, false
+	}
+	return requestID, true
+}
+
+func respondErasureError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrErasureRequestNotFound) {
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeNotFound)
+		return
+	}
+	RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"user-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DataExportHandler lets users download an archive of their data
+type DataExportHandler struct {
+	exportService DataExportService
+}
+
+func NewDataExportHandler(exportService DataExportService) *DataExportHandler {
+	return &DataExportHandler{exportService: exportService}
+}
+
+// Request queues an export of the authenticated user's data
+func (h *DataExportHandler) Request(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	export, err := h.exportService.Request(c.Request.Context(), userID)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithSuccess(c, http.StatusAccepted, "data export queued", export)
+}
+
+// List returns the authenticated user's exports; ready ones carry a download link
+func (h *DataExportHandler) List(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	exports, err := h.exportService.ListUserRequests(c.Request.Context(), userID)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, exports)
+}
+
+// Get returns one of the authenticated user's exports
+func (h *DataExportHandler) Get(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	requestID, ok := parseRequestID(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.exportService.GetForUser(c.Request.Context(), userID, requestID)
+	if err != nil {
+		if errors.Is(err, service.ErrDataExportNotFound) {
+			RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeNotFound)
+			return
+		}
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, export)
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"user-service/internal/service"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FeatureFlagHandler lets admins manage feature flags and their rollouts
+type FeatureFlagHandler struct {
+	featureFlagService FeatureFlagService
+}
+
+func NewFeatureFlagHandler(featureFlagService FeatureFlagService) *FeatureFlagHandler {
+	return &FeatureFlagHandler{featureFlagService: featureFlagService}
+}
+
+// List returns every flag
+func (h *FeatureFlagHandler) List(c *gin.Context) {
+	flags, err := h.featureFlagService.List(c.Request.Context())
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, flags)
+}
+
+// Get returns the flag in the path
+func (h *FeatureFlagHandler) Get(c *gin.Context) {
+	flag, err := h.featureFlagService.Get(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		respondFeatureFlagError(c, err)
+		return
+	}
+	RespondWithData(c, http.StatusOK, flag)
+}
+
+// Put creates or replaces the flag in the path
+func (h *FeatureFlagHandler) Put(c *gin.Context) {
+	adminID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	var req models.FeatureFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+
+	flag, err := h.featureFlagService.Put(c.Request.Context(), adminID, c.Param("key"), req)
+	if err != nil {
+		respondFeatureFlagError(c, err)
+		return
+	}
+	RespondWithData(c, http.StatusOK, flag)
+}
+
+// Delete removes the flag in the path, turning it off everywhere
+func (h *FeatureFlagHandler) Delete(c *gin.Context) {
+	adminID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	if err := h.featureFlagService.Delete(c.Request.Context(), adminID, c.Param("key")); err != nil {
+		respondFeatureFlagError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "feature flag deleted")
+}
+
+func respondFeatureFlagError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidFeatureFlagKey):
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+	case errors.Is(err, service.ErrFeatureFlagNotFound):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeNotFound)
+	default:
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+	}
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"user-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FollowHandler lets users follow others without being friends, and lists
+// followers, followed users and "Who to follow"
+type FollowHandler struct {
+	followService FollowService
+}
+
+func NewFollowHandler(followService FollowService) *FollowHandler {
+	return &FollowHandler{followService: followService}
+}
+
+// Follow makes the authenticated user follow the user in the path
+func (h *FollowHandler) Follow(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	targetID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+
+	if err := h.followService.Follow(c.Request.Context(), userID, targetID); err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "user followed", nil)
+}
+
+// Unfollow stops the authenticated user from following the user in the path
+func (h *FollowHandler) Unfollow(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	targetID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+
+	if err := h.followService.Unfollow(c.Request.Context(), userID, targetID); err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "user unfollowed", nil)
+}
+
+// Counts returns the follower and following counts of the user in the path
+func (h *FollowHandler) Counts(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+
+	counts, err := h.followService.Counts(c.Request.Context(), userID)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, counts)
+}
+
+// Followers returns a page of the users following the user in the path
+func (h *FollowHandler) Followers(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	users, err := h.followService.Followers(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, users)
+}
+
+// Following returns a page of the users the user in the path follows
+func (h *FollowHandler) Following(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	users, err := h.followService.Following(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, users)
+}
+
+// Suggest returns "Who to follow" for the authenticated user
+func (h *FollowHandler) Suggest(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	suggestions, err := h.followService.Suggest(c.Request.Context(), userID, limit)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, suggestions)
+}
+
+func respondFollowError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCannotFollowSelf):
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+	case errors.Is(err, service.ErrUserNotFound):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeUserNotFound)
+	case errors.Is(err, service.ErrNotFollowing):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeNotFound)
+	case errors.Is(err, service.ErrFollowBlocked):
+		RespondWithError(c, http.StatusForbidden, err.Error(), ErrCodeForbidden)
+	default:
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+	}
+}
@@ -23,13 +23,13 @@ func NewAuthHandler(authService AuthService, cfg *config.Config) *AuthHandler {
 func (h *AuthHandler) Register(c *gin.Context) {
 	var user models.User
 	if err := c.ShouldBindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		RespondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	res, err := h.authService.Register(c.Request.Context(), &user)
+	res, err := h.authService.Register(c.Request.Context(), &user, deviceInfo(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		RespondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -43,13 +43,35 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		Password string `json:"password"`
 	}
 	if err := c.ShouldBindJSON(&creds); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		RespondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	res, err := h.authService.Login(c.Request.Context(), creds.Email, creds.Password)
+	res, err := h.authService.Login(c.Request.Context(), creds.Email, creds.Password, deviceInfo(c))
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		RespondWithError(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Nothing to store until the second factor is in; the client sends the
+	// pre-auth token to LoginTwoFactor
+	if !res.TwoFactorRequired {
+		h.setRefreshCookie(c, res.RefreshToken)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// LoginTwoFactor finishes a login that needed a second factor
+func (h *AuthHandler) LoginTwoFactor(c *gin.Context) {
+	var req models.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := h.authService.CompleteTwoFactorLogin(c.Request.Context(), req.PreAuthToken, req.Code, deviceInfo(c))
+	if err != nil {
+		respondTwoFactorError(c, err)
 		return
 	}
 
@@ -62,16 +84,16 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	if refreshToken == "" {
 		var req models.RefreshRequest
 		if err := c.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "refresh token required"})
+			RespondWithError(c, http.StatusBadRequest, "refresh token required")
 			return
 		}
 		refreshToken = req.RefreshToken
 	}
 
-	res, err := h.authService.RefreshToken(c.Request.Context(), refreshToken)
+	res, err := h.authService.RefreshToken(c.Request.Context(), refreshToken, deviceInfo(c))
 	if err != nil {
 		h.clearRefreshCookie(c)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		RespondWithError(c, http.StatusUnauthorized, err.Error())
 		return
 	}
 
@@ -80,21 +102,27 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 }
 
 func (h *AuthHandler) setRefreshCookie(c *gin.Context, token string) {
-	if h.cfg == nil || h.cfg.RefreshCookieName == "" {
+	setRefreshCookie(c, h.cfg, token)
+}
+
+// setRefreshCookie stores the refresh token for browsers, for every handler
+// that signs users in
+func setRefreshCookie(c *gin.Context, cfg *config.Config, token string) {
+	if cfg == nil || cfg.RefreshCookieName == "" {
 		return
 	}
 
 	c.SetSameSite(http.SameSiteLaxMode)
-	maxAge := int(h.cfg.RefreshTokenTTL.Seconds())
+	maxAge := int(cfg.RefreshTokenTTL.Seconds())
 	if token == "" {
-		c.SetCookie(h.cfg.RefreshCookieName, "", -1, "/", h.cfg.CookieDomain, h.cfg.CookieSecure, true)
+		c.SetCookie(cfg.RefreshCookieName, "", -1, "/", cfg.CookieDomain, cfg.CookieSecure, true)
 		return
 	}
 
 	if maxAge <= 0 {
 		maxAge = 0
 	}
-	c.SetCookie(h.cfg.RefreshCookieName, token, maxAge, "/", h.cfg.CookieDomain, h.cfg.CookieSecure, true)
+	c.SetCookie(cfg.RefreshCookieName, token, maxAge, "/", cfg.CookieDomain, cfg.CookieSecure, true)
 }
 
 func (h *AuthHandler) clearRefreshCookie(c *gin.Context) {
@@ -115,3 +143,13 @@ func (h *AuthHandler) cookieToken(c *gin.Context) string {
 	}
 	return value
 }
+
+// deviceInfo describes the client making the request. Apps may name the
+// device with X-Device-Name so it is recognisable in the session list.
+func deviceInfo(c *gin.Context) models.DeviceInfo {
+	return models.DeviceInfo{
+		Name:      c.GetHeader("X-Device-Name"),
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 	"user-service/config"
+	"user-service/internal/service"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/gin-gonic/gin"
@@ -22,24 +23,32 @@ type MockAuthService struct {
 	mock.Mock
 }
 
-func (m *MockAuthService) Register(ctx context.Context, user *models.User) (*models.AuthResponse, error) {
-	args := m.Called(ctx, user)
+func (m *MockAuthService) Register(ctx context.Context, user *models.User, device models.DeviceInfo) (*models.AuthResponse, error) {
+	args := m.Called(ctx, user, device)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.AuthResponse), args.Error(1)
 }
 
-func (m *MockAuthService) Login(ctx context.Context, email, password string) (*models.AuthResponse, error) {
-	args := m.Called(ctx, email, password)
+func (m *MockAuthService) Login(ctx context.Context, email, password string, device models.DeviceInfo) (*models.AuthResponse, error) {
+	args := m.Called(ctx, email, password, device)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.AuthResponse), args.Error(1)
 }
 
-func (m *MockAuthService) RefreshToken(ctx context.Context, refreshToken string) (*models.AuthResponse, error) {
-	args := m.Called(ctx, refreshToken)
+func (m *MockAuthService) RefreshToken(ctx context.Context, refreshToken string, device models.DeviceInfo) (*models.AuthResponse, error) {
+	args := m.Called(ctx, refreshToken, device)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AuthResponse), args.Error(1)
+}
+
+func (m *MockAuthService) CompleteTwoFactorLogin(ctx context.Context, preAuthToken, code string, device models.DeviceInfo) (*models.AuthResponse, error) {
+	args := m.Called(ctx, preAuthToken, code, device)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -74,7 +83,7 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 
 	mockAuthService.On("Register", mock.Anything, mock.MatchedBy(func(u *models.User) bool {
 		return u.Username == "testuser" && u.Email == "test@example.com"
-	})).Return(authResponse, nil)
+	}), mock.Anything).Return(authResponse, nil)
 
 	w := httptest.NewRecorder()
 	router := gin.New()
@@ -115,10 +124,10 @@ func TestAuthHandler_Register_ValidationError(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
-	var response map[string]string
+	var response ErrorResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Contains(t, response["error"], "invalid")
+	assert.Contains(t, response.Error.Message, "invalid")
 }
 
 func TestAuthHandler_Login_Success(t *testing.T) {
@@ -141,7 +150,7 @@ func TestAuthHandler_Login_Success(t *testing.T) {
 		},
 	}
 
-	mockAuthService.On("Login", mock.Anything, "test@example.com", "password123").Return(authResponse, nil)
+	mockAuthService.On("Login", mock.Anything, "test@example.com", "password123", mock.Anything).Return(authResponse, nil)
 
 	w := httptest.NewRecorder()
 	router := gin.New()
@@ -173,7 +182,7 @@ func TestAuthHandler_Login_Unauthorized(t *testing.T) {
 	mockAuthService := new(MockAuthService)
 	handler := NewAuthHandler(mockAuthService, &config.Config{})
 
-	mockAuthService.On("Login", mock.Anything, "test@example.com", "wrongpassword").Return(nil, errors.New("invalid credentials"))
+	mockAuthService.On("Login", mock.Anything, "test@example.com", "wrongpassword", mock.Anything).Return(nil, errors.New("invalid credentials"))
 
 	w := httptest.NewRecorder()
 	router := gin.New()
@@ -191,10 +200,116 @@ func TestAuthHandler_Login_Unauthorized(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 
-	var response map[string]string
+	var response ErrorResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Contains(t, response["error"], "invalid credentials")
+	assert.Contains(t, response.Error.Message, "invalid credentials")
 
 	mockAuthService.AssertExpectations(t)
 }
+
+func TestAuthHandler_Login_TwoFactorRequired(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockAuthService := new(MockAuthService)
+	handler := NewAuthHandler(mockAuthService, &config.Config{
+		RefreshCookieName: "refresh_token",
+		RefreshTokenTTL:   time.Hour,
+	})
+
+	mockAuthService.On("Login", mock.Anything, "test@example.com", "password123", mock.Anything).Return(&models.AuthResponse{
+		TwoFactorRequired: true,
+		PreAuthToken:      "pre-auth",
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.New()
+	router.POST("/login", handler.Login)
+
+	credsJSON, _ := json.Marshal(map[string]string{"email": "test@example.com", "password": "password123"})
+	req := httptest.NewRequest("POST", "/login", bytes.NewReader(credsJSON))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Result().Cookies(), "no session cookie before the second factor")
+
+	var response models.AuthResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.TwoFactorRequired)
+	assert.Equal(t, "pre-auth", response.PreAuthToken)
+	assert.Empty(t, response.AccessToken)
+}
+
+func TestAuthHandler_LoginTwoFactor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("issues tokens for a valid code", func(t *testing.T) {
+		mockAuthService := new(MockAuthService)
+		handler := NewAuthHandler(mockAuthService, &config.Config{
+			RefreshCookieName: "refresh_token",
+			RefreshTokenTTL:   time.Hour,
+		})
+		mockAuthService.On("CompleteTwoFactorLogin", mock.Anything, "pre-auth", "123456", mock.Anything).Return(&models.AuthResponse{
+			AccessToken:  "access_token",
+			RefreshToken: "refresh_token",
+		}, nil)
+
+		w := httptest.NewRecorder()
+		router := gin.New()
+		router.POST("/login/2fa", handler.LoginTwoFactor)
+
+		body, _ := json.Marshal(models.TwoFactorLoginRequest{PreAuthToken: "pre-auth", Code: "123456"})
+		req := httptest.NewRequest("POST", "/login/2fa", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		cookies := w.Result().Cookies()
+		if assert.Len(t, cookies, 1) {
+			assert.Equal(t, "refresh_token", cookies[0].Value)
+		}
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("rejects an invalid code", func(t *testing.T) {
+		mockAuthService := new(MockAuthService)
+		handler := NewAuthHandler(mockAuthService, &config.Config{})
+		mockAuthService.On("CompleteTwoFactorLogin", mock.Anything, "pre-auth", "000000", mock.Anything).Return(nil, service.ErrInvalidTwoFactorCode)
+
+		w := httptest.NewRecorder()
+		router := gin.New()
+		router.POST("/login/2fa", handler.LoginTwoFactor)
+
+		body, _ := json.Marshal(models.TwoFactorLoginRequest{PreAuthToken: "pre-auth", Code: "000000"})
+		req := httptest.NewRequest("POST", "/login/2fa", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		var response ErrorResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, ErrCodeInvalidTwoFactor, response.Error.Code)
+	})
+
+	t.Run("rate limits repeated failures", func(t *testing.T) {
+		mockAuthService := new(MockAuthService)
+		handler := NewAuthHandler(mockAuthService, &config.Config{})
+		mockAuthService.On("CompleteTwoFactorLogin", mock.Anything, "pre-auth", "000000", mock.Anything).Return(nil, service.ErrTooManyTwoFactorAttempts)
+
+		w := httptest.NewRecorder()
+		router := gin.New()
+		router.POST("/login/2fa", handler.LoginTwoFactor)
+
+		body, _ := json.Marshal(models.TwoFactorLoginRequest{PreAuthToken: "pre-auth", Code: "000000"})
+		req := httptest.NewRequest("POST", "/login/2fa", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+}
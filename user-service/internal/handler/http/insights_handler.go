@@ -0,0 +1,48 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"user-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// InsightsHandler serves creators their own profile and content insights
+type InsightsHandler struct {
+	insightsService InsightsService
+}
+
+func NewInsightsHandler(insightsService InsightsService) *InsightsHandler {
+	return &InsightsHandler{insightsService: insightsService}
+}
+
+// Get returns the authenticated user's insights over the last days days
+// (7, 28 or 90; 28 by default)
+func (h *InsightsHandler) Get(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	days := 0
+	if raw := c.Query("days"); raw != "" {
+		if days, err = strconv.Atoi(raw); err != nil {
+			RespondWithError(c, http.StatusBadRequest, service.ErrInvalidInsightsWindow.Error(), ErrCodeValidation)
+			return
+		}
+	}
+
+	insights, err := h.insightsService.GetInsights(c.Request.Context(), userID, days)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInsightsWindow) {
+			RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+			return
+		}
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, insights)
+}
@@ -3,26 +3,157 @@ package http
 import (
 	"context"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // AuthService defines the interface for authentication operations
 type AuthService interface {
-	Register(ctx context.Context, user *models.User) (*models.AuthResponse, error)
-	Login(ctx context.Context, email, password string) (*models.AuthResponse, error)
-	RefreshToken(ctx context.Context, refreshToken string) (*models.AuthResponse, error)
+	Register(ctx context.Context, user *models.User, device models.DeviceInfo) (*models.AuthResponse, error)
+	Login(ctx context.Context, email, password string, device models.DeviceInfo) (*models.AuthResponse, error)
+	RefreshToken(ctx context.Context, refreshToken string, device models.DeviceInfo) (*models.AuthResponse, error)
+	CompleteTwoFactorLogin(ctx context.Context, preAuthToken, code string, device models.DeviceInfo) (*models.AuthResponse, error)
+}
+
+// SessionService defines the interface for managing signed-in devices
+type SessionService interface {
+	ListSessions(ctx context.Context, userID primitive.ObjectID, currentSessionID string) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID primitive.ObjectID) error
+}
+
+// TwoFactorService defines the interface for two-factor enrollment
+type TwoFactorService interface {
+	BeginTwoFactorSetup(ctx context.Context, userID primitive.ObjectID) (*models.TwoFactorSetupResponse, error)
+	EnableTwoFactor(ctx context.Context, userID primitive.ObjectID, code string) (*models.RecoveryCodesResponse, error)
+	DisableTwoFactor(ctx context.Context, userID primitive.ObjectID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID primitive.ObjectID, code string) (*models.RecoveryCodesResponse, error)
+	ResetTwoFactor(ctx context.Context, adminID, userID primitive.ObjectID) error
+}
+
+// AccountStateService defines the interface for admins changing a user's standing
+type AccountStateService interface {
+	SetAccountState(ctx context.Context, adminID, userID primitive.ObjectID, req models.SetAccountStateRequest) (*models.User, error)
 }
 
 // UserService defines the interface for user management operations
 type UserService interface {
 	GetUserByID(ctx context.Context, id primitive.ObjectID) (*models.User, error)
-	UpdateProfileFields(ctx context.Context, userID primitive.ObjectID, fullName, bio, avatar, coverPhoto, location, website string) (*models.User, error)
+	GetUserProfile(ctx context.Context, id, viewerID primitive.ObjectID) (*models.User, error)
+	ResolveUsername(ctx context.Context, username string) (primitive.ObjectID, error)
+	UpdateProfileFields(ctx context.Context, userID primitive.ObjectID, req *models.UpdateProfileRequest) (*models.User, error)
+	ChangeUsername(ctx context.Context, userID primitive.ObjectID, username string) (*models.User, error)
+	ListUsernameHistory(ctx context.Context, userID primitive.ObjectID) ([]models.UsernameChange, error)
 	UpdateEmail(ctx context.Context, userID primitive.ObjectID, email string) error
 	UpdatePassword(ctx context.Context, userID primitive.ObjectID, currentPassword, newPassword string) error
 	UpdatePrivacySettings(ctx context.Context, userID primitive.ObjectID, settings *models.UpdatePrivacySettingsRequest) error
 	UpdateNotificationSettings(ctx context.Context, userID primitive.ObjectID, settings *models.UpdateNotificationSettingsRequest) error
-	ToggleTwoFactor(ctx context.Context, userID primitive.ObjectID, enable bool) error
 	DeactivateAccount(ctx context.Context, userID primitive.ObjectID) error
 	GetUserStatus(ctx context.Context, userIDStr string) (string, int64, error)
 }
+
+// ProfileHighlights lists the story highlights shown on a profile
+type ProfileHighlights interface {
+	Covers(ctx context.Context, userID, viewerID primitive.ObjectID) []models.StoryHighlightCover
+}
+
+// ErasureService defines the interface for GDPR erasure orchestration
+type ErasureService interface {
+	RequestErasure(ctx context.Context, userID, requestedBy primitive.ObjectID, reason string) (*models.ErasureRequest, error)
+	ListUserRequests(ctx context.Context, userID primitive.ObjectID) ([]models.ErasureRequest, error)
+	GetRequestForUser(ctx context.Context, userID, requestID primitive.ObjectID) (*models.ErasureRequest, error)
+	GetRequest(ctx context.Context, requestID primitive.ObjectID) (*models.ErasureRequest, error)
+	ListRequests(ctx context.Context, status models.ErasureStatus, limit, page int64) (*models.ErasureRequestListResponse, error)
+	Report(ctx context.Context, requestID primitive.ObjectID) (*models.ErasureReport, error)
+	RetryFailed(ctx context.Context, adminID, requestID primitive.ObjectID) (*models.ErasureRequest, error)
+}
+
+// DataExportService defines the interface for "Download your information" exports
+type DataExportService interface {
+	Request(ctx context.Context, userID primitive.ObjectID) (*models.DataExportRequest, error)
+	ListUserRequests(ctx context.Context, userID primitive.ObjectID) ([]models.DataExportRequest, error)
+	GetForUser(ctx context.Context, userID, requestID primitive.ObjectID) (*models.DataExportRequest, error)
+}
+
+// AccountDeletionService defines the interface for permanent account deletion
+type AccountDeletionService interface {
+	Schedule(ctx context.Context, userID primitive.ObjectID, req models.DeleteAccountRequest) (*models.AccountDeletion, error)
+	Cancel(ctx context.Context, userID primitive.ObjectID) (*models.AccountDeletion, error)
+	Get(ctx context.Context, userID primitive.ObjectID) (*models.AccountDeletion, error)
+}
+
+// AuditLog defines the interface for querying the audit log
+type AuditLog interface {
+	Query(ctx context.Context, filter audit.Filter, page, limit int64) ([]models.AuditEntry, int64, error)
+}
+
+// FeatureFlagService defines the interface for managing feature flags
+type FeatureFlagService interface {
+	List(ctx context.Context) ([]models.FeatureFlag, error)
+	Get(ctx context.Context, key string) (*models.FeatureFlag, error)
+	Put(ctx context.Context, adminID primitive.ObjectID, key string, req models.FeatureFlagRequest) (*models.FeatureFlag, error)
+	Delete(ctx context.Context, adminID primitive.ObjectID, key string) error
+}
+
+// BlockService defines the interface for blocking users
+type BlockService interface {
+	Block(ctx context.Context, blockerID, targetID primitive.ObjectID) (*models.UserBlock, error)
+	Unblock(ctx context.Context, blockerID, targetID primitive.ObjectID) error
+	ListBlocked(ctx context.Context, blockerID primitive.ObjectID) ([]models.UserShortResponse, error)
+}
+
+// SuggestionService defines the interface for friend suggestions
+type SuggestionService interface {
+	Suggest(ctx context.Context, userID primitive.ObjectID, limit int) ([]models.FriendSuggestion, error)
+	Dismiss(ctx context.Context, userID, targetID primitive.ObjectID) error
+	Restore(ctx context.Context, userID, targetID primitive.ObjectID) error
+	ListDismissed(ctx context.Context, userID primitive.ObjectID) ([]models.UserShortResponse, error)
+}
+
+// FollowService defines the interface for one-way follows
+type FollowService interface {
+	Follow(ctx context.Context, followerID, targetID primitive.ObjectID) error
+	Unfollow(ctx context.Context, followerID, targetID primitive.ObjectID) error
+	Counts(ctx context.Context, userID primitive.ObjectID) (*models.FollowCounts, error)
+	Followers(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]models.UserShortResponse, error)
+	Following(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]models.UserShortResponse, error)
+	Suggest(ctx context.Context, userID primitive.ObjectID, limit int) ([]models.FollowSuggestion, error)
+}
+
+// CloseFriendsService defines the interface for managing the close friends list
+type CloseFriendsService interface {
+	List(ctx context.Context, userID primitive.ObjectID) ([]models.UserShortResponse, error)
+	Add(ctx context.Context, userID, friendID primitive.ObjectID) error
+	Remove(ctx context.Context, userID, friendID primitive.ObjectID) error
+}
+
+// InsightsService defines the interface for creators' insights
+type InsightsService interface {
+	GetInsights(ctx context.Context, ownerID primitive.ObjectID, days int) (*models.InsightsResponse, error)
+}
+
+// VerificationService defines the interface for identity verification
+type VerificationService interface {
+	Request(ctx context.Context, userID primitive.ObjectID, req models.CreateVerificationRequest) (*models.VerificationRequest, error)
+	Latest(ctx context.Context, userID primitive.ObjectID) (*models.VerificationRequest, error)
+	Queue(ctx context.Context, after primitive.ObjectID, limit int) ([]models.VerificationReviewItem, error)
+	Review(ctx context.Context, adminID, requestID primitive.ObjectID, req models.ReviewVerificationRequest) (*models.VerificationRequest, error)
+	Revoke(ctx context.Context, adminID, userID primitive.ObjectID) (*models.User, error)
+}
+
+// AccountEmailService defines the interface for email verification and
+// password reset
+type AccountEmailService interface {
+	SendVerification(ctx context.Context, userID primitive.ObjectID) error
+	VerifyEmail(ctx context.Context, token string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+// OAuthService defines the interface for signing in with identity providers
+type OAuthService interface {
+	OAuthLogin(ctx context.Context, provider models.OAuthProvider, req models.OAuthLoginRequest, device models.DeviceInfo) (*models.AuthResponse, error)
+	ListOAuthIdentities(ctx context.Context, userID primitive.ObjectID) ([]models.OAuthIdentity, error)
+	LinkOAuth(ctx context.Context, userID primitive.ObjectID, provider models.OAuthProvider, req models.OAuthLoginRequest) (*models.OAuthIdentity, error)
+	UnlinkOAuth(ctx context.Context, userID primitive.ObjectID, provider models.OAuthProvider) error
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/jwks"
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSHandler publishes the keys access tokens are signed with, for other
+// services to verify them
+type JWKSHandler struct {
+	document []byte
+}
+
+// NewJWKSHandler publishes keys; without keys the set is empty, as tokens
+// are signed with the shared secret
+func NewJWKSHandler(keys *jwks.KeyRing) (*JWKSHandler, error) {
+	if keys == nil {
+		return &JWKSHandler{document: []byte(`{"keys":[]}`)}, nil
+	}
+	document, err := keys.Document()
+	if err != nil {
+		return nil, err
+	}
+	return &JWKSHandler{document: document}, nil
+}
+
+// Get serves the key set. Verifiers refetch early when a token names a key
+// they have not seen, so the cache lifetime does not hold up a rotation.
+func (h *JWKSHandler) Get(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Data(http.StatusOK, "application/json", h.document)
+}
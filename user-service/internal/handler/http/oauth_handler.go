@@ -0,0 +1,119 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"user-service/config"
+	"user-service/internal/service"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OAuthHandler signs users in with Google or Apple and manages the provider
+// accounts linked to them
+type OAuthHandler struct {
+	oauthService OAuthService
+	cfg          *config.Config
+}
+
+func NewOAuthHandler(oauthService OAuthService, cfg *config.Config) *OAuthHandler {
+	return &OAuthHandler{oauthService: oauthService, cfg: cfg}
+}
+
+// Login signs in with the provider in the path, creating an account on first
+// use. The response says when an account was created, with other usernames
+// the client may offer in place of the one assigned.
+func (h *OAuthHandler) Login(c *gin.Context) {
+	var req models.OAuthLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+
+	res, err := h.oauthService.OAuthLogin(c.Request.Context(), models.OAuthProvider(c.Param("provider")), req, deviceInfo(c))
+	if err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+
+	if !res.TwoFactorRequired {
+		setRefreshCookie(c, h.cfg, res.RefreshToken)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// List returns the provider accounts linked to the authenticated user
+func (h *OAuthHandler) List(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	identities, err := h.oauthService.ListOAuthIdentities(c.Request.Context(), userID)
+	if err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+	RespondWithData(c, http.StatusOK, identities)
+}
+
+// Link links an account at the provider in the path to the authenticated user
+func (h *OAuthHandler) Link(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	var req models.OAuthLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+
+	identity, err := h.oauthService.LinkOAuth(c.Request.Context(), userID, models.OAuthProvider(c.Param("provider")), req)
+	if err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "account linked", identity)
+}
+
+// Unlink removes the authenticated user's account at the provider in the path
+func (h *OAuthHandler) Unlink(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	if err := h.oauthService.UnlinkOAuth(c.Request.Context(), userID, models.OAuthProvider(c.Param("provider"))); err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "account unlinked")
+}
+
+func respondOAuthError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidOAuthCredential):
+		RespondWithError(c, http.StatusUnauthorized, err.Error(), ErrCodeInvalidToken)
+	case errors.Is(err, service.ErrOAuthEmailRequired):
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+	case errors.Is(err, service.ErrOAuthProviderUnavailable), errors.Is(err, service.ErrOAuthNotLinked):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeNotFound)
+	case errors.Is(err, service.ErrOAuthEmailInUse):
+		RespondWithError(c, http.StatusConflict, err.Error(), ErrCodeEmailExists)
+	case errors.Is(err, service.ErrOAuthIdentityLinked), errors.Is(err, service.ErrOAuthAlreadyLinked),
+		errors.Is(err, service.ErrLastSignInMethod):
+		RespondWithError(c, http.StatusConflict, err.Error(), ErrCodeConflict)
+	case errors.Is(err, service.ErrAccountSuspended), errors.Is(err, service.ErrAccountBanned):
+		RespondWithError(c, http.StatusForbidden, err.Error(), ErrCodeForbidden)
+	case errors.Is(err, service.ErrUserNotFound):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeUserNotFound)
+	default:
+		RespondWithError(c, http.StatusInternalServerError, "Failed to process request", ErrCodeInternalError)
+	}
+}
@@ -3,15 +3,12 @@ package http
 import (
 	"net/http"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/apperrors"
 	"github.com/gin-gonic/gin"
 )
 
-// ErrorResponse represents a standardized error response
-type ErrorResponse struct {
-	Error   string            `json:"error"`
-	Code    string            `json:"code,omitempty"`
-	Details map[string]string `json:"details,omitempty"`
-}
+// ErrorResponse is the standard error envelope shared by all services
+type ErrorResponse = apperrors.Response
 
 // SuccessResponse represents a standardized success response
 type SuccessResponse struct {
@@ -21,38 +18,37 @@ type SuccessResponse struct {
 
 // Standard error codes for user service
 const (
-	ErrCodeValidation        = "VALIDATION_ERROR"
-	ErrCodeUnauthorized      = "UNAUTHORIZED"
-	ErrCodeUserNotFound      = "USER_NOT_FOUND"
+	ErrCodeValidation         = apperrors.CodeValidation
+	ErrCodeUnauthorized       = apperrors.CodeUnauthorized
+	ErrCodeUserNotFound       = "USER_NOT_FOUND"
 	ErrCodeInvalidCredentials = "INVALID_CREDENTIALS"
-	ErrCodeEmailExists       = "EMAIL_EXISTS"
-	ErrCodeUsernameExists    = "USERNAME_EXISTS"
-	ErrCodeWeakPassword      = "WEAK_PASSWORD"
-	ErrCodeInvalidToken      = "INVALID_TOKEN"
-	ErrCodeRateLimited       = "RATE_LIMITED"
-	ErrCodeInternalError     = "INTERNAL_ERROR"
+	ErrCodeEmailExists        = "EMAIL_EXISTS"
+	ErrCodeUsernameExists     = "USERNAME_EXISTS"
+	ErrCodeUsernameCooldown   = "USERNAME_CHANGE_COOLDOWN"
+	ErrCodeWeakPassword       = "WEAK_PASSWORD"
+	ErrCodeInvalidToken       = "INVALID_TOKEN"
+	ErrCodeRateLimited        = apperrors.CodeRateLimited
+	ErrCodeInternalError      = apperrors.CodeInternal
+	ErrCodeForbidden          = apperrors.CodeForbidden
+	ErrCodeNotFound           = apperrors.CodeNotFound
+	ErrCodeAlreadyBlocked     = "ALREADY_BLOCKED"
+	ErrCodeInvalidTwoFactor   = "INVALID_TWO_FACTOR_CODE"
+	ErrCodeConflict           = apperrors.CodeConflict
 )
 
-// RespondWithError sends a standardized error response
+// RespondWithError sends the standard error envelope. Without a code the
+// one for the status is used.
 func RespondWithError(c *gin.Context, statusCode int, message string, code ...string) {
-	errorCode := ErrCodeInternalError
+	errorCode := ""
 	if len(code) > 0 {
 		errorCode = code[0]
 	}
-	
-	c.JSON(statusCode, ErrorResponse{
-		Error: message,
-		Code:  errorCode,
-	})
+	apperrors.Write(c, statusCode, errorCode, message, nil)
 }
 
 // RespondWithValidationError sends a validation error with details
 func RespondWithValidationError(c *gin.Context, message string, details map[string]string) {
-	c.JSON(http.StatusBadRequest, ErrorResponse{
-		Error:   message,
-		Code:    ErrCodeValidation,
-		Details: details,
-	})
+	apperrors.Write(c, http.StatusBadRequest, ErrCodeValidation, message, details)
 }
 
 // RespondWithSuccess sends a standardized success response
@@ -60,15 +56,15 @@ func RespondWithSuccess(c *gin.Context, statusCode int, message string, data ...
 	response := SuccessResponse{
 		Message: message,
 	}
-	
+
 	if len(data) > 0 {
 		response.Data = data[0]
 	}
-	
+
 	c.JSON(statusCode, response)
 }
 
 // RespondWithData sends data without a message wrapper
 func RespondWithData(c *gin.Context, statusCode int, data interface{}) {
 	c.JSON(statusCode, data)
-}
\ No newline at end of file
+}
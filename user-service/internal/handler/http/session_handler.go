@@ -0,0 +1,61 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"user-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SessionHandler lets users see the devices they are signed in on and sign
+// any of them out
+type SessionHandler struct {
+	sessionService SessionService
+}
+
+func NewSessionHandler(sessionService SessionService) *SessionHandler {
+	return &SessionHandler{sessionService: sessionService}
+}
+
+// List returns the authenticated user's active sessions, marking the one the
+// request was made from
+func (h *SessionHandler) List(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	sessions, err := h.sessionService.ListSessions(c.Request.Context(), userID, c.GetString("session_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, sessions)
+}
+
+// Revoke signs out the session in the path, which may be the current one
+func (h *SessionHandler) Revoke(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	sessionID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid session ID format", ErrCodeValidation)
+		return
+	}
+
+	if err := h.sessionService.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		if errors.Is(err, service.ErrSessionNotFound) {
+			RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeNotFound)
+			return
+		}
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "session revoked", nil)
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"user-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SuggestionHandler serves "People You May Know" and lets users hide people
+// they do not want suggested
+type SuggestionHandler struct {
+	suggestionService SuggestionService
+}
+
+func NewSuggestionHandler(suggestionService SuggestionService) *SuggestionHandler {
+	return &SuggestionHandler{suggestionService: suggestionService}
+}
+
+// List returns friend suggestions for the authenticated user
+func (h *SuggestionHandler) List(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	suggestions, err := h.suggestionService.Suggest(c.Request.Context(), userID, limit)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, suggestions)
+}
+
+// Dismiss stops the user in the path from being suggested again
+func (h *SuggestionHandler) Dismiss(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	targetID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+
+	if err := h.suggestionService.Dismiss(c.Request.Context(), userID, targetID); err != nil {
+		respondSuggestionError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "suggestion dismissed", nil)
+}
+
+// Restore lets a dismissed user be suggested again
+func (h *SuggestionHandler) Restore(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	targetID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+
+	if err := h.suggestionService.Restore(c.Request.Context(), userID, targetID); err != nil {
+		respondSuggestionError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "suggestion restored", nil)
+}
+
+// ListDismissed returns the users the authenticated user chose not to see
+func (h *SuggestionHandler) ListDismissed(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	users, err := h.suggestionService.ListDismissed(c.Request.Context(), userID)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, users)
+}
+
+func respondSuggestionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCannotDismissSelf):
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+	case errors.Is(err, service.ErrUserNotFound):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeUserNotFound)
+	default:
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+	}
+}
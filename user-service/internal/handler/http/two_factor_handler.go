@@ -0,0 +1,143 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"user-service/internal/service"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TwoFactorHandler lets users enroll in TOTP two-factor authentication and
+// manage their recovery codes
+type TwoFactorHandler struct {
+	twoFactorService TwoFactorService
+}
+
+func NewTwoFactorHandler(twoFactorService TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorService: twoFactorService}
+}
+
+// Setup starts enrollment, returning a secret and otpauth URL to render as a QR code
+func (h *TwoFactorHandler) Setup(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	setup, err := h.twoFactorService.BeginTwoFactorSetup(c.Request.Context(), userID)
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+	RespondWithData(c, http.StatusOK, setup)
+}
+
+// Enable confirms enrollment with a code from the authenticator app and
+// returns the user's recovery codes
+func (h *TwoFactorHandler) Enable(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	var req models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+
+	codes, err := h.twoFactorService.EnableTwoFactor(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "two-factor authentication enabled", codes)
+}
+
+// Disable turns two-factor authentication off given a TOTP or recovery code
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	var req models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+
+	if err := h.twoFactorService.DisableTwoFactor(c.Request.Context(), userID, req.Code); err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "two-factor authentication disabled")
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	var req models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "recovery codes regenerated", codes)
+}
+
+// AdminReset turns two-factor authentication off for the user in the path
+func (h *TwoFactorHandler) AdminReset(c *gin.Context) {
+	adminID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+
+	if err := h.twoFactorService.ResetTwoFactor(c.Request.Context(), adminID, userID); err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "two-factor authentication reset")
+}
+
+func respondTwoFactorError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidTwoFactorCode):
+		RespondWithError(c, http.StatusUnauthorized, err.Error(), ErrCodeInvalidTwoFactor)
+	case errors.Is(err, service.ErrInvalidPreAuthToken):
+		RespondWithError(c, http.StatusUnauthorized, err.Error(), ErrCodeInvalidToken)
+	case errors.Is(err, service.ErrTooManyTwoFactorAttempts):
+		RespondWithError(c, http.StatusTooManyRequests, err.Error(), ErrCodeRateLimited)
+	case errors.Is(err, service.ErrTwoFactorAlreadyEnabled), errors.Is(err, service.ErrTwoFactorNotEnabled):
+		RespondWithError(c, http.StatusConflict, err.Error(), ErrCodeConflict)
+	case errors.Is(err, service.ErrTwoFactorSetupExpired):
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+	case errors.Is(err, service.ErrTwoFactorUnavailable):
+		RespondWithError(c, http.StatusForbidden, err.Error(), ErrCodeForbidden)
+	case errors.Is(err, service.ErrUserNotFound):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeUserNotFound)
+	case errors.Is(err, service.ErrAccountSuspended), errors.Is(err, service.ErrAccountBanned):
+		RespondWithError(c, http.StatusForbidden, err.Error(), ErrCodeForbidden)
+	default:
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+	}
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"errors"
 	"net/http"
+	"user-service/internal/service"
 	"user-service/internal/validation"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
@@ -12,12 +13,18 @@ import (
 
 type UserHandler struct {
 	userService UserService
+	highlights  ProfileHighlights
 }
 
 func NewUserHandler(userService UserService) *UserHandler {
 	return &UserHandler{userService: userService}
 }
 
+// SetProfileHighlights adds story highlight covers to profile responses
+func (h *UserHandler) SetProfileHighlights(highlights ProfileHighlights) {
+	h.highlights = highlights
+}
+
 // GetProfile returns the authenticated user's profile
 func (h *UserHandler) GetProfile(c *gin.Context) {
 	userID, err := h.extractUserID(c)
@@ -34,10 +41,18 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 
 	// Clear sensitive data
 	user.Password = ""
-	RespondWithData(c, http.StatusOK, user)
+	if h.highlights == nil {
+		RespondWithData(c, http.StatusOK, user)
+		return
+	}
+	RespondWithData(c, http.StatusOK, struct {
+		*models.User
+		Highlights []models.StoryHighlightCover `json:"highlights"`
+	}{user, h.highlightCovers(c, userID, userID)})
 }
 
-// GetUserByID returns a user by their ID (public profile)
+// GetUserByID returns a user's profile as the viewer may see it. Signed-out
+// viewers only see the sections their owner made public.
 func (h *UserHandler) GetUserByID(c *gin.Context) {
 	idParam := c.Param("id")
 	userID, err := primitive.ObjectIDFromHex(idParam)
@@ -45,21 +60,88 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
 		return
 	}
+	viewerID, _ := h.extractUserID(c)
 
-	user, err := h.userService.GetUserByID(c.Request.Context(), userID)
+	profile, ok := h.publicProfile(c, userID, viewerID)
+	if !ok {
+		return
+	}
+	RespondWithData(c, http.StatusOK, profile)
+}
+
+// GetUserByUsername returns a user's profile by username. A username given
+// up recently still resolves to its old owner, with redirected_from set so
+// clients can move links over to the new one.
+func (h *UserHandler) GetUserByUsername(c *gin.Context) {
+	username := c.Param("username")
+	viewerID, _ := h.extractUserID(c)
+
+	userID, err := h.userService.ResolveUsername(c.Request.Context(), username)
 	if err != nil {
-		RespondWithError(c, http.StatusNotFound, "User not found", ErrCodeUserNotFound)
+		if errors.Is(err, service.ErrUserNotFound) {
+			RespondWithError(c, http.StatusNotFound, "User not found", ErrCodeUserNotFound)
+			return
+		}
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+
+	profile, ok := h.publicProfile(c, userID, viewerID)
+	if !ok {
 		return
 	}
+	if profile["username"] != username {
+		profile["redirected_from"] = username
+	}
+	RespondWithData(c, http.StatusOK, profile)
+}
+
+// publicProfile builds userID's profile as viewerID sees it, responding with
+// the error itself when it cannot
+func (h *UserHandler) publicProfile(c *gin.Context, userID, viewerID primitive.ObjectID) (gin.H, bool) {
+	user, err := h.userService.GetUserProfile(c.Request.Context(), userID, viewerID)
+	if err != nil {
+		RespondWithError(c, http.StatusNotFound, "User not found", ErrCodeUserNotFound)
+		return nil, false
+	}
 
 	// Return public profile only
-	RespondWithData(c, http.StatusOK, gin.H{
+	profile := gin.H{
 		"id":        user.ID,
 		"username":  user.Username,
 		"full_name": user.FullName,
 		"avatar":    user.Avatar,
 		"bio":       user.Bio,
-	})
+		"pronouns":  user.Pronouns,
+		"location":  user.Location,
+		"websites":  nonNil(user.Websites),
+		"work":      nonNil(user.Work),
+		"education": nonNil(user.Education),
+	}
+	if h.highlights != nil {
+		// Signed-out viewers only see highlights of public stories
+		profile["highlights"] = h.highlightCovers(c, userID, viewerID)
+	}
+	return profile, true
+}
+
+// nonNil returns items, or an empty list in place of nil so hidden and empty
+// sections serialize alike
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// highlightCovers returns the user's highlight covers as viewerID sees them,
+// never nil so profiles always list them
+func (h *UserHandler) highlightCovers(c *gin.Context, userID, viewerID primitive.ObjectID) []models.StoryHighlightCover {
+	covers := h.highlights.Covers(c.Request.Context(), userID, viewerID)
+	if covers == nil {
+		covers = []models.StoryHighlightCover{}
+	}
+	return covers
 }
 
 // UpdateProfile updates the authenticated user's profile
@@ -70,21 +152,18 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	var req struct {
-		FullName   string `json:"full_name"`
-		Bio        string `json:"bio"`
-		Avatar     string `json:"avatar"`
-		CoverPhoto string `json:"cover_photo"`
-		Location   string `json:"location"`
-		Website    string `json:"website"`
-	}
+	var req models.UpdateProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
 		return
 	}
 
-	updatedUser, err := h.userService.UpdateProfileFields(c.Request.Context(), userID, req.FullName, req.Bio, req.Avatar, req.CoverPhoto, req.Location, req.Website)
+	updatedUser, err := h.userService.UpdateProfileFields(c.Request.Context(), userID, &req)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidProfile) {
+			RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+			return
+		}
 		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
 		return
 	}
@@ -93,6 +172,57 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	RespondWithSuccess(c, http.StatusOK, "profile updated successfully", updatedUser)
 }
 
+// ChangeUsername renames the authenticated user
+func (h *UserHandler) ChangeUsername(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	var req models.ChangeUsernameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+
+	updatedUser, err := h.userService.ChangeUsername(c.Request.Context(), userID, req.Username)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidUsername), errors.Is(err, service.ErrUsernameUnchanged):
+			RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		case errors.Is(err, service.ErrUsernameTaken):
+			RespondWithError(c, http.StatusConflict, err.Error(), ErrCodeUsernameExists)
+		case errors.Is(err, service.ErrUsernameChangeCooldown):
+			RespondWithError(c, http.StatusConflict, err.Error(), ErrCodeUsernameCooldown)
+		case errors.Is(err, service.ErrUserNotFound):
+			RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeUserNotFound)
+		default:
+			RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		}
+		return
+	}
+
+	RespondWithSuccess(c, http.StatusOK, "username changed successfully", updatedUser)
+}
+
+// ListUsernameHistory lists the usernames the authenticated user changed
+// away from
+func (h *UserHandler) ListUsernameHistory(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+
+	changes, err := h.userService.ListUsernameHistory(c.Request.Context(), userID)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, changes)
+}
+
 // UpdateEmail updates the authenticated user's email
 func (h *UserHandler) UpdateEmail(c *gin.Context) {
 	userID, err := h.extractUserID(c)
@@ -206,34 +336,6 @@ func (h *UserHandler) UpdateNotificationSettings(c *gin.Context) {
 	RespondWithSuccess(c, http.StatusOK, "notification settings updated")
 }
 
-// ToggleTwoFactor enables or disables two-factor authentication
-func (h *UserHandler) ToggleTwoFactor(c *gin.Context) {
-	userID, err := h.extractUserID(c)
-	if err != nil {
-		RespondWithError(c, http.StatusUnauthorized, "invalid user ID", ErrCodeUnauthorized)
-		return
-	}
-
-	var req struct {
-		Enable bool `json:"enable"`
-	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
-		return
-	}
-
-	if err := h.userService.ToggleTwoFactor(c.Request.Context(), userID, req.Enable); err != nil {
-		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
-		return
-	}
-
-	status := "disabled"
-	if req.Enable {
-		status = "enabled"
-	}
-	RespondWithSuccess(c, http.StatusOK, "two-factor authentication "+status)
-}
-
 // DeactivateAccount deactivates the authenticated user's account
 func (h *UserHandler) DeactivateAccount(c *gin.Context) {
 	userID, err := h.extractUserID(c)
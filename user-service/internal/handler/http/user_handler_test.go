@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"user-service/internal/service"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/gin-gonic/gin"
@@ -27,14 +30,40 @@ func (m *MockUserService) GetUserByID(ctx context.Context, id primitive.ObjectID
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserService) UpdateProfileFields(ctx context.Context, userID primitive.ObjectID, fullName, bio, avatar, coverPhoto, location, website string) (*models.User, error) {
-	args := m.Called(ctx, userID, fullName, bio, avatar, coverPhoto, location, website)
+func (m *MockUserService) GetUserProfile(ctx context.Context, id, viewerID primitive.ObjectID) (*models.User, error) {
+	args := m.Called(ctx, id, viewerID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserService) UpdateProfileFields(ctx context.Context, userID primitive.ObjectID, req *models.UpdateProfileRequest) (*models.User, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserService) ResolveUsername(ctx context.Context, username string) (primitive.ObjectID, error) {
+	args := m.Called(ctx, username)
+	return args.Get(0).(primitive.ObjectID), args.Error(1)
+}
+
+func (m *MockUserService) ChangeUsername(ctx context.Context, userID primitive.ObjectID, username string) (*models.User, error) {
+	args := m.Called(ctx, userID, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserService) ListUsernameHistory(ctx context.Context, userID primitive.ObjectID) ([]models.UsernameChange, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]models.UsernameChange), args.Error(1)
+}
+
 func (m *MockUserService) UpdateEmail(ctx context.Context, userID primitive.ObjectID, email string) error {
 	args := m.Called(ctx, userID, email)
 	return args.Error(0)
@@ -129,10 +158,10 @@ func TestUserHandler_GetProfile_Unauthorized(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 
-	var response map[string]string
+	var response ErrorResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Contains(t, response["error"], "Authentication required")
+	assert.Contains(t, response.Error.Message, "Authentication required")
 }
 
 func TestUserHandler_GetUserByID_Success(t *testing.T) {
@@ -152,7 +181,8 @@ func TestUserHandler_GetUserByID_Success(t *testing.T) {
 		Password: "hashedpassword", // Should not be returned
 	}
 
-	mockUserService.On("GetUserByID", mock.Anything, userID).Return(user, nil)
+	// Signed out, so no viewer
+	mockUserService.On("GetUserProfile", mock.Anything, userID, primitive.NilObjectID).Return(user, nil)
 
 	w := httptest.NewRecorder()
 	router := gin.New()
@@ -192,10 +222,10 @@ func TestUserHandler_GetUserByID_InvalidFormat(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
-	var response map[string]string
+	var response ErrorResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Contains(t, response["error"], "Invalid user ID format")
+	assert.Contains(t, response.Error.Message, "Invalid user ID format")
 }
 
 func TestUserHandler_UpdateProfile_Success(t *testing.T) {
@@ -209,9 +239,7 @@ func TestUserHandler_UpdateProfile_Success(t *testing.T) {
 	mockUserService.On("UpdateProfileFields",
 		mock.Anything,
 		userID,
-		"Updated Name",
-		"Updated bio",
-		"", "", "", "" /* other fields empty */).Return(&models.User{}, nil)
+		&models.UpdateProfileRequest{FullName: "Updated Name", Bio: "Updated bio"} /* other fields empty */).Return(&models.User{}, nil)
 
 	w := httptest.NewRecorder()
 	router := gin.New()
@@ -243,3 +271,189 @@ func TestUserHandler_UpdateProfile_Success(t *testing.T) {
 
 	mockUserService.AssertExpectations(t)
 }
+
+// fakeHighlights returns covers for every profile and records the viewer
+type fakeHighlights struct {
+	covers   []models.StoryHighlightCover
+	viewerID primitive.ObjectID
+}
+
+func (f *fakeHighlights) Covers(ctx context.Context, userID, viewerID primitive.ObjectID) []models.StoryHighlightCover {
+	f.viewerID = viewerID
+	return f.covers
+}
+
+func TestUserHandler_GetUserByID_IncludesHighlights(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUserService := new(MockUserService)
+	handler := NewUserHandler(mockUserService)
+	highlights := &fakeHighlights{covers: []models.StoryHighlightCover{{ID: "h1", Title: "Travel", CoverURL: "cover.jpg", StoryCount: 3}}}
+	handler.SetProfileHighlights(highlights)
+
+	userID := primitive.NewObjectID()
+	mockUserService.On("GetUserProfile", mock.Anything, userID, primitive.NilObjectID).Return(&models.User{ID: userID, Username: "testuser"}, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.New()
+	router.GET("/users/:id", handler.GetUserByID)
+
+	req := httptest.NewRequest("GET", "/users/"+userID.Hex(), nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Highlights []models.StoryHighlightCover `json:"highlights"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, highlights.covers, response.Highlights)
+	// The public profile route is signed out
+	assert.True(t, highlights.viewerID.IsZero())
+}
+
+func TestUserHandler_GetUserByID_PassesViewer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUserService := new(MockUserService)
+	handler := NewUserHandler(mockUserService)
+
+	userID, viewerID := primitive.NewObjectID(), primitive.NewObjectID()
+	mockUserService.On("GetUserProfile", mock.Anything, userID, viewerID).Return(&models.User{
+		ID:       userID,
+		Username: "testuser",
+		Pronouns: "they/them",
+		Work:     []models.WorkEntry{{Company: "Acme"}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", viewerID.Hex())
+		c.Next()
+	})
+	router.GET("/users/:id", handler.GetUserByID)
+
+	req := httptest.NewRequest("GET", "/users/"+userID.Hex(), nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "they/them", response["pronouns"])
+	assert.Len(t, response["work"], 1)
+	// Hidden or empty sections are empty lists, not missing
+	assert.Equal(t, []interface{}{}, response["education"])
+
+	mockUserService.AssertExpectations(t)
+}
+
+func TestUserHandler_UpdateProfile_InvalidProfile(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUserService := new(MockUserService)
+	handler := NewUserHandler(mockUserService)
+
+	userID := primitive.NewObjectID()
+	mockUserService.On("UpdateProfileFields", mock.Anything, userID, mock.Anything).
+		Return(nil, fmt.Errorf("%w: %w", service.ErrInvalidProfile, errors.New("pronouns must be 30 characters or less")))
+
+	w := httptest.NewRecorder()
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.Hex())
+		c.Next()
+	})
+	router.PATCH("/profile", handler.UpdateProfile)
+
+	req := httptest.NewRequest("PATCH", "/profile", bytes.NewReader([]byte(`{"pronouns":"a very long set of pronouns indeed"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Contains(t, response.Error.Message, "pronouns must be 30 characters or less")
+}
+
+func TestUserHandler_GetUserByUsername_Redirects(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUserService := new(MockUserService)
+	handler := NewUserHandler(mockUserService)
+
+	userID := primitive.NewObjectID()
+	mockUserService.On("ResolveUsername", mock.Anything, "oldname").Return(userID, nil)
+	mockUserService.On("ResolveUsername", mock.Anything, "newname").Return(userID, nil)
+	mockUserService.On("ResolveUsername", mock.Anything, "nobody").Return(primitive.NilObjectID, service.ErrUserNotFound)
+	mockUserService.On("GetUserProfile", mock.Anything, userID, primitive.NilObjectID).Return(&models.User{ID: userID, Username: "newname"}, nil)
+
+	router := gin.New()
+	router.GET("/users/:id", handler.GetUserByID)
+	router.GET("/users/by-username/:username", handler.GetUserByUsername)
+
+	tests := []struct {
+		username       string
+		wantStatus     int
+		wantRedirected interface{}
+	}{
+		{"oldname", http.StatusOK, "oldname"},
+		{"newname", http.StatusOK, nil},
+		{"nobody", http.StatusNotFound, nil},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/users/by-username/"+tt.username, nil))
+
+		assert.Equal(t, tt.wantStatus, w.Code, tt.username)
+		if tt.wantStatus != http.StatusOK {
+			continue
+		}
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "newname", response["username"])
+		assert.Equal(t, tt.wantRedirected, response["redirected_from"], tt.username)
+	}
+}
+
+func TestUserHandler_ChangeUsername_Errors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"invalid", fmt.Errorf("%w: %w", service.ErrInvalidUsername, errors.New("username must be at least 3 characters")), http.StatusBadRequest, ErrCodeValidation},
+		{"taken", service.ErrUsernameTaken, http.StatusConflict, ErrCodeUsernameExists},
+		{"cooldown", service.ErrUsernameChangeCooldown, http.StatusConflict, ErrCodeUsernameCooldown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserService := new(MockUserService)
+			handler := NewUserHandler(mockUserService)
+
+			userID := primitive.NewObjectID()
+			mockUserService.On("ChangeUsername", mock.Anything, userID, "newname").Return(nil, tt.err)
+
+			w := httptest.NewRecorder()
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", userID.Hex())
+				c.Next()
+			})
+			router.PUT("/username", handler.ChangeUsername)
+
+			req := httptest.NewRequest("PUT", "/username", bytes.NewReader([]byte(`{"username":"newname"}`)))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var response ErrorResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantCode, response.Error.Code)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"user-service/internal/service"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VerificationHandler lets users ask for the verified badge and admins
+// review their requests
+type VerificationHandler struct {
+	verificationService VerificationService
+}
+
+func NewVerificationHandler(verificationService VerificationService) *VerificationHandler {
+	return &VerificationHandler{verificationService: verificationService}
+}
+
+// Request queues a verification request for the authenticated user
+func (h *VerificationHandler) Request(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	var req models.CreateVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+
+	request, err := h.verificationService.Request(c.Request.Context(), userID, req)
+	if err != nil {
+		respondVerificationError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusCreated, "verification request submitted", request)
+}
+
+// Status returns the authenticated user's latest verification request
+func (h *VerificationHandler) Status(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	request, err := h.verificationService.Latest(c.Request.Context(), userID)
+	if err != nil {
+		respondVerificationError(c, err)
+		return
+	}
+	RespondWithData(c, http.StatusOK, request)
+}
+
+// Queue lists pending requests for admins, oldest first. Pass the ID of the
+// last request seen as after for the next page.
+func (h *VerificationHandler) Queue(c *gin.Context) {
+	var after primitive.ObjectID
+	if raw := c.Query("after"); raw != "" {
+		var err error
+		if after, err = primitive.ObjectIDFromHex(raw); err != nil {
+			RespondWithError(c, http.StatusBadRequest, "Invalid after ID format", ErrCodeValidation)
+			return
+		}
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	items, err := h.verificationService.Queue(c.Request.Context(), after, limit)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+		return
+	}
+	RespondWithData(c, http.StatusOK, items)
+}
+
+// Review approves or rejects the request in the path
+func (h *VerificationHandler) Review(c *gin.Context) {
+	adminID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	requestID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid request ID format", ErrCodeValidation)
+		return
+	}
+	var req models.ReviewVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+		return
+	}
+
+	request, err := h.verificationService.Review(c.Request.Context(), adminID, requestID, req)
+	if err != nil {
+		respondVerificationError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "verification request reviewed", request)
+}
+
+// Revoke takes the badge away from the user in the path
+func (h *VerificationHandler) Revoke(c *gin.Context) {
+	adminID, err := primitive.ObjectIDFromHex(c.GetString("user_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusUnauthorized, "Authentication required", ErrCodeUnauthorized)
+		return
+	}
+	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid user ID format", ErrCodeValidation)
+		return
+	}
+
+	user, err := h.verificationService.Revoke(c.Request.Context(), adminID, userID)
+	if err != nil {
+		respondVerificationError(c, err)
+		return
+	}
+	RespondWithSuccess(c, http.StatusOK, "verification revoked", gin.H{"id": user.ID, "verified": user.Verified})
+}
+
+func respondVerificationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidVerificationDocument), errors.Is(err, service.ErrInvalidVerificationRequest),
+		errors.Is(err, service.ErrCannotReviewOwnVerification):
+		RespondWithError(c, http.StatusBadRequest, err.Error(), ErrCodeValidation)
+	case errors.Is(err, service.ErrUserNotFound):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeUserNotFound)
+	case errors.Is(err, service.ErrVerificationNotFound):
+		RespondWithError(c, http.StatusNotFound, err.Error(), ErrCodeNotFound)
+	case errors.Is(err, service.ErrAlreadyVerified), errors.Is(err, service.ErrNotVerified),
+		errors.Is(err, service.ErrVerificationPending), errors.Is(err, service.ErrVerificationReviewed):
+		RespondWithError(c, http.StatusConflict, err.Error(), ErrCodeConflict)
+	default:
+		RespondWithError(c, http.StatusInternalServerError, err.Error(), ErrCodeInternalError)
+	}
+}
@@ -0,0 +1,144 @@
+// Package mailer renders the emails requested on the email topic and sends
+// them over SMTP
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+)
+
+var (
+	ErrUnknownTemplate = errors.New("unknown email template")
+	ErrInvalidHeader   = errors.New("invalid email header")
+)
+
+// Sender delivers one rendered plain text email
+type Sender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+type emailTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// templates are filled with an EmailRequestedEvent's Data, plus AppName
+var templates = map[string]emailTemplate{
+	events.EmailTemplateVerifyEmail: {
+		subject: template.Must(template.New("subject").Parse(`Verify your email for {{.AppName}}`)),
+		body: template.Must(template.New("body").Parse(`Hi {{.username}},
+
+Confirm this is your email address by opening the link below:
+
+{{.link}}
+
+The link works once and expires in {{.expires_in}}. If you did not sign up
+for {{.AppName}} or change your email, you can ignore this message.
+`)),
+	},
+	events.EmailTemplatePasswordReset: {
+		subject: template.Must(template.New("subject").Parse(`Reset your {{.AppName}} password`)),
+		body: template.Must(template.New("body").Parse(`Hi {{.username}},
+
+Someone asked to reset the password of your {{.AppName}} account. Choose a
+new one by opening the link below:
+
+{{.link}}
+
+The link works once and expires in {{.expires_in}}. Resetting your password
+signs you out on every device. If it wasn't you, ignore this message; your
+password stays the same.
+`)),
+	},
+}
+
+// Mailer turns email requests into messages for its Sender
+type Mailer struct {
+	sender  Sender
+	appName string
+}
+
+func New(sender Sender, appName string) *Mailer {
+	return &Mailer{sender: sender, appName: appName}
+}
+
+// Deliver renders evt's template and sends it
+func (m *Mailer) Deliver(ctx context.Context, evt events.EmailRequestedEvent) error {
+	tmpl, ok := templates[evt.Template]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownTemplate, evt.Template)
+	}
+	data := map[string]string{"AppName": m.appName}
+	for k, v := range evt.Data {
+		data[k] = v
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return err
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return err
+	}
+	return m.sender.Send(ctx, evt.To, subject.String(), body.String())
+}
+
+// SMTPSender sends through an SMTP relay, with PLAIN auth when a username
+// is set
+type SMTPSender struct {
+	addr string
+	from *mail.Address
+	auth smtp.Auth
+}
+
+func NewSMTPSender(host, port, username, password, from string) (*SMTPSender, error) {
+	fromAddr, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", from, err)
+	}
+	s := &SMTPSender{addr: net.JoinHostPort(host, port), from: fromAddr}
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return s, nil
+}
+
+func (s *SMTPSender) Send(ctx context.Context, to, subject, body string) error {
+	msg, err := buildMessage(s.from, to, subject, body, time.Now())
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(s.addr, s.auth, s.from.Address, []string{to}, msg)
+}
+
+// buildMessage lays out a plain text message, refusing headers that would
+// let a value start a header of its own
+func buildMessage(from *mail.Address, to, subject, body string, at time.Time) ([]byte, error) {
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return nil, ErrInvalidHeader
+	}
+	if _, err := mail.ParseAddress(to); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHeader, err)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", at.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return msg.Bytes(), nil
+}
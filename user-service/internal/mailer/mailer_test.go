@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+)
+
+type recordingSender struct {
+	to, subject, body string
+}
+
+func (s *recordingSender) Send(ctx context.Context, to, subject, body string) error {
+	s.to, s.subject, s.body = to, subject, body
+	return nil
+}
+
+func TestMailer_Deliver(t *testing.T) {
+	sender := &recordingSender{}
+	m := New(sender, "Connectify")
+
+	err := m.Deliver(context.Background(), events.EmailRequestedEvent{
+		Template: events.EmailTemplatePasswordReset,
+		To:       "jane@example.com",
+		Data: map[string]string{
+			"username":   "jane",
+			"link":       "https://app.example.com/reset-password?token=abc",
+			"expires_in": "30 minutes",
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", sender.to)
+	assert.Equal(t, "Reset your Connectify password", sender.subject)
+	assert.Contains(t, sender.body, "Hi jane,")
+	assert.Contains(t, sender.body, "https://app.example.com/reset-password?token=abc")
+	assert.Contains(t, sender.body, "expires in 30 minutes")
+
+	err = m.Deliver(context.Background(), events.EmailRequestedEvent{Template: "newsletter", To: "jane@example.com"})
+	assert.ErrorIs(t, err, ErrUnknownTemplate)
+}
+
+func TestBuildMessage(t *testing.T) {
+	from := &mail.Address{Name: "Connectify", Address: "no-reply@example.com"}
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	msg, err := buildMessage(from, "jane@example.com", "Hello", "line one\nline two\n", at)
+	require.NoError(t, err)
+	text := string(msg)
+	assert.True(t, strings.HasPrefix(text, "From: \"Connectify\" <no-reply@example.com>\r\nTo: jane@example.com\r\nSubject: Hello\r\n"))
+	assert.Contains(t, text, "Date: Fri, 02 Jan 2026 03:04:05 +0000\r\n")
+	assert.True(t, strings.HasSuffix(text, "\r\n\r\nline one\r\nline two\r\n"))
+
+	_, err = buildMessage(from, "jane@example.com\r\nBcc: all@example.com", "Hello", "", at)
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+	_, err = buildMessage(from, "jane@example.com", "Hello\r\nBcc: all@example.com", "", at)
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+	_, err = buildMessage(from, "not an address", "Hello", "", at)
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+}
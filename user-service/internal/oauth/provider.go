@@ -0,0 +1,241 @@
+// Package oauth verifies sign-ins with external OpenID Connect identity
+// providers. Clients either hand over the ID token they got from the
+// provider, or an authorization code that is exchanged for one here; the
+// token is then checked against the provider's published signing keys.
+package oauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/jwks"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrInvalidToken       = errors.New("invalid provider token")
+	ErrExchangeFailed     = errors.New("provider rejected the authorization code")
+	ErrCodeFlowDisabled   = errors.New("authorization codes are not accepted for this provider")
+	ErrRedirectNotAllowed = errors.New("redirect URI is not allowed")
+	ErrMissingCredential  = errors.New("an id_token or code is required")
+)
+
+// Identity is who a provider vouches for. Subject is the provider's stable
+// ID for the account; the email may change or, with Apple, be a relay.
+type Identity struct {
+	Provider      models.OAuthProvider
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider is one OpenID Connect identity provider
+type Provider struct {
+	name      models.OAuthProvider
+	issuers   []string
+	audiences []string // Client IDs tokens may be issued to; the first exchanges codes
+	keys      *jwks.KeySet
+
+	tokenURL     string
+	redirectURIs []string
+	clientSecret func() (string, error) // Nil when codes are not accepted
+	client       *http.Client
+}
+
+// GoogleConfig configures sign-in with Google. Without a client secret only
+// ID tokens are accepted.
+type GoogleConfig struct {
+	ClientIDs    []string
+	ClientSecret string
+	RedirectURIs []string
+}
+
+func NewGoogle(cfg GoogleConfig) *Provider {
+	p := &Provider{
+		name:         models.OAuthProviderGoogle,
+		issuers:      []string{"https://accounts.google.com", "accounts.google.com"},
+		audiences:    cfg.ClientIDs,
+		keys:         jwks.NewKeySet("https://www.googleapis.com/oauth2/v3/certs", jwks.DefaultOptions()),
+		tokenURL:     "https://oauth2.googleapis.com/token",
+		redirectURIs: cfg.RedirectURIs,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+	if cfg.ClientSecret != "" {
+		p.clientSecret = func() (string, error) { return cfg.ClientSecret, nil }
+	}
+	return p
+}
+
+// AppleConfig configures Sign in with Apple. Apple's client secret is a
+// short-lived JWT signed with the team's private key; without the key only
+// ID tokens are accepted.
+type AppleConfig struct {
+	ClientIDs    []string // Services ID for the web, bundle IDs for the apps
+	TeamID       string
+	KeyID        string
+	PrivateKey   string // PEM encoded PKCS #8 P-256 key
+	RedirectURIs []string
+}
+
+func NewApple(cfg AppleConfig) (*Provider, error) {
+	p := &Provider{
+		name:         models.OAuthProviderApple,
+		issuers:      []string{"https://appleid.apple.com"},
+		audiences:    cfg.ClientIDs,
+		keys:         jwks.NewKeySet("https://appleid.apple.com/auth/keys", jwks.DefaultOptions()),
+		tokenURL:     "https://appleid.apple.com/auth/token",
+		redirectURIs: cfg.RedirectURIs,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+	if cfg.PrivateKey != "" && len(cfg.ClientIDs) > 0 {
+		key, err := jwt.ParseECPrivateKeyFromPEM([]byte(cfg.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid Apple private key: %w", err)
+		}
+		p.clientSecret = appleClientSecret(key, cfg.TeamID, cfg.KeyID, cfg.ClientIDs[0])
+	}
+	return p, nil
+}
+
+func appleClientSecret(key *ecdsa.PrivateKey, teamID, keyID, clientID string) func() (string, error) {
+	return func() (string, error) {
+		now := time.Now()
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+			"iss": teamID,
+			"iat": now.Unix(),
+			"exp": now.Add(5 * time.Minute).Unix(),
+			"aud": "https://appleid.apple.com",
+			"sub": clientID,
+		})
+		token.Header["kid"] = keyID
+		return token.SignedString(key)
+	}
+}
+
+func (p *Provider) Name() models.OAuthProvider {
+	return p.name
+}
+
+// Authenticate verifies the ID token in req, exchanging req's authorization
+// code for one first when it has no token
+func (p *Provider) Authenticate(ctx context.Context, req models.OAuthLoginRequest) (*Identity, error) {
+	idToken := req.IDToken
+	if idToken == "" {
+		if req.Code == "" {
+			return nil, ErrMissingCredential
+		}
+		var err error
+		if idToken, err = p.Exchange(ctx, req.Code, req.RedirectURI); err != nil {
+			return nil, err
+		}
+	}
+	return p.Verify(ctx, idToken, req.Nonce)
+}
+
+// Exchange trades an authorization code for the ID token it was issued with
+func (p *Provider) Exchange(ctx context.Context, code, redirectURI string) (string, error) {
+	if p.clientSecret == nil || len(p.audiences) == 0 {
+		return "", ErrCodeFlowDisabled
+	}
+	if !slices.Contains(p.redirectURIs, redirectURI) {
+		return "", ErrRedirectNotAllowed
+	}
+	secret, err := p.clientSecret()
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {p.audiences[0]},
+		"client_secret": {secret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s token endpoint: %w", p.name, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return "", ErrExchangeFailed
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s token endpoint: status %d", p.name, resp.StatusCode)
+	}
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &tokens); err != nil || tokens.IDToken == "" {
+		return "", fmt.Errorf("%s token endpoint: no id_token in response", p.name)
+	}
+	return tokens.IDToken, nil
+}
+
+// Verify checks idToken's signature, issuer, audience and expiry, and its
+// nonce when one is given, and returns the identity it asserts
+func (p *Provider) Verify(ctx context.Context, idToken, nonce string) (*Identity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, p.keys.Keyfunc(ctx),
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	issuer, _ := claims.GetIssuer()
+	if !slices.Contains(p.issuers, issuer) {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, issuer)
+	}
+	audience, _ := claims.GetAudience()
+	if !slices.ContainsFunc(audience, func(aud string) bool { return slices.Contains(p.audiences, aud) }) {
+		return nil, fmt.Errorf("%w: issued to another client", ErrInvalidToken)
+	}
+	subject, _ := claims.GetSubject()
+	if subject == "" {
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidToken)
+	}
+	if nonce != "" {
+		if got, _ := claims["nonce"].(string); got != nonce {
+			return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+		}
+	}
+
+	identity := &Identity{Provider: p.name, Subject: subject}
+	identity.Email, _ = claims["email"].(string)
+	identity.Email = strings.ToLower(strings.TrimSpace(identity.Email))
+	identity.Name, _ = claims["name"].(string)
+	// Apple sends email_verified as a string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	}
+	return identity, nil
+}
@@ -0,0 +1,164 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/jwks"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testIssuer struct {
+	key    *rsa.PrivateKey
+	server *httptest.Server
+	codes  map[string]string // Authorization code to the ID token it yields
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	iss := &testIssuer{key: key, codes: map[string]string{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		n, e := jwks.EncodeRSAKey(&key.PublicKey)
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "k1", "use": "sig", "n": n, "e": e},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		idToken, ok := iss.codes[r.PostForm.Get("code")]
+		if !ok || r.PostForm.Get("client_secret") != "secret" || r.PostForm.Get("client_id") != "web-client" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+	})
+	iss.server = httptest.NewServer(mux)
+	t.Cleanup(iss.server.Close)
+	return iss
+}
+
+func (iss *testIssuer) provider() *Provider {
+	return &Provider{
+		name:         models.OAuthProviderGoogle,
+		issuers:      []string{"https://accounts.google.com"},
+		audiences:    []string{"web-client", "ios-client"},
+		keys:         jwks.NewKeySet(iss.server.URL+"/keys", jwks.DefaultOptions()),
+		tokenURL:     iss.server.URL + "/token",
+		redirectURIs: []string{"https://app.example.com/oauth/google"},
+		clientSecret: func() (string, error) { return "secret", nil },
+		client:       iss.server.Client(),
+	}
+}
+
+func (iss *testIssuer) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "k1"
+	signed, err := token.SignedString(iss.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            "https://accounts.google.com",
+		"aud":            "ios-client",
+		"sub":            "1234567890",
+		"email":          "Jane@Example.com",
+		"email_verified": true,
+		"name":           "Jane Doe",
+		"nonce":          "n-1",
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestProvider_Verify(t *testing.T) {
+	iss := newTestIssuer(t)
+	p := iss.provider()
+	ctx := context.Background()
+
+	identity, err := p.Verify(ctx, iss.sign(t, validClaims()), "n-1")
+	require.NoError(t, err)
+	assert.Equal(t, &Identity{
+		Provider:      models.OAuthProviderGoogle,
+		Subject:       "1234567890",
+		Email:         "jane@example.com",
+		EmailVerified: true,
+		Name:          "Jane Doe",
+	}, identity)
+
+	apple := validClaims()
+	apple["email_verified"] = "true"
+	identity, err = p.Verify(ctx, iss.sign(t, apple), "")
+	require.NoError(t, err)
+	assert.True(t, identity.EmailVerified)
+
+	tests := map[string]func(jwt.MapClaims){
+		"wrong issuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"wrong audience": func(c jwt.MapClaims) { c["aud"] = "someone-else" },
+		"expired":        func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"no expiry":      func(c jwt.MapClaims) { delete(c, "exp") },
+		"no subject":     func(c jwt.MapClaims) { delete(c, "sub") },
+		"wrong nonce":    func(c jwt.MapClaims) { c["nonce"] = "n-2" },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			claims := validClaims()
+			mutate(claims)
+			_, err := p.Verify(ctx, iss.sign(t, claims), "n-1")
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+
+	t.Run("signed by another key", func(t *testing.T) {
+		other := newTestIssuer(t)
+		_, err := p.Verify(ctx, other.sign(t, validClaims()), "n-1")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+	t.Run("HMAC signed", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims())
+		token.Header["kid"] = "k1"
+		signed, err := token.SignedString([]byte("k1"))
+		require.NoError(t, err)
+		_, err = p.Verify(ctx, signed, "n-1")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestProvider_AuthenticateWithCode(t *testing.T) {
+	iss := newTestIssuer(t)
+	p := iss.provider()
+	ctx := context.Background()
+	iss.codes["good-code"] = iss.sign(t, validClaims())
+
+	identity, err := p.Authenticate(ctx, models.OAuthLoginRequest{Code: "good-code", RedirectURI: "https://app.example.com/oauth/google"})
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890", identity.Subject)
+
+	_, err = p.Authenticate(ctx, models.OAuthLoginRequest{Code: "bad-code", RedirectURI: "https://app.example.com/oauth/google"})
+	assert.ErrorIs(t, err, ErrExchangeFailed)
+
+	_, err = p.Authenticate(ctx, models.OAuthLoginRequest{Code: "good-code", RedirectURI: "https://evil.example.com/"})
+	assert.ErrorIs(t, err, ErrRedirectNotAllowed)
+
+	_, err = p.Authenticate(ctx, models.OAuthLoginRequest{})
+	assert.ErrorIs(t, err, ErrMissingCredential)
+
+	p.clientSecret = nil
+	_, err = p.Authenticate(ctx, models.OAuthLoginRequest{Code: "good-code", RedirectURI: "https://app.example.com/oauth/google"})
+	assert.ErrorIs(t, err, ErrCodeFlowDisabled)
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AccountDeletionRepository persists account deletions and the progress of
+// their cascade across services
+type AccountDeletionRepository struct {
+	db *mongo.Database
+}
+
+func NewAccountDeletionRepository(db *mongo.Database) *AccountDeletionRepository {
+	_, err := db.Collection("account_deletions").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "scheduled_for", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "updated_at", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("Failed to create account deletion indexes: %v", err)
+	}
+	return &AccountDeletionRepository{db: db}
+}
+
+func (r *AccountDeletionRepository) collection() *mongo.Collection {
+	return r.db.Collection("account_deletions")
+}
+
+func (r *AccountDeletionRepository) Create(ctx context.Context, d *models.AccountDeletion) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := r.collection().InsertOne(ctx, d)
+	if err != nil {
+		return err
+	}
+	d.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *AccountDeletionRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.AccountDeletion, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var d models.AccountDeletion
+	if err := r.collection().FindOne(ctx, bson.M{"_id": id}).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// FindLatestByUser returns the user's most recent deletion, whatever its status
+func (r *AccountDeletionRepository) FindLatestByUser(ctx context.Context, userID primitive.ObjectID) (*models.AccountDeletion, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	var d models.AccountDeletion
+	if err := r.collection().FindOne(ctx, bson.M{"user_id": userID}, opts).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// FindActiveByUser returns the user's scheduled or running deletion, if any
+func (r *AccountDeletionRepository) FindActiveByUser(ctx context.Context, userID primitive.ObjectID) (*models.AccountDeletion, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"user_id": userID,
+		"status":  bson.M{"$in": []models.AccountDeletionStatus{models.AccountDeletionStatusScheduled, models.AccountDeletionStatusInProgress}},
+	}
+	var d models.AccountDeletion
+	if err := r.collection().FindOne(ctx, filter).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// CancelScheduled cancels the user's deletion while it is still in its grace
+// period, returning mongo.ErrNoDocuments when there is none to cancel
+func (r *AccountDeletionRepository) CancelScheduled(ctx context.Context, userID primitive.ObjectID, now time.Time) (*models.AccountDeletion, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userID, "status": models.AccountDeletionStatusScheduled}
+	update := bson.M{"$set": bson.M{
+		"status":       models.AccountDeletionStatusCancelled,
+		"cancelled_at": now,
+		"updated_at":   now,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var d models.AccountDeletion
+	if err := r.collection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ClaimDue atomically moves the deletion whose grace period ended first to in
+// progress so only one worker removes the account. Deletions left in progress
+// without the account removed since before staleBefore belong to a worker
+// that died and are claimed again.
+func (r *AccountDeletionRepository) ClaimDue(ctx context.Context, now, staleBefore time.Time) (*models.AccountDeletion, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"$or": []bson.M{
+		{"status": models.AccountDeletionStatusScheduled, "scheduled_for": bson.M{"$lte": now}},
+		{"status": models.AccountDeletionStatusInProgress, "deleted_at": nil, "updated_at": bson.M{"$lt": staleBefore}},
+	}}
+	update := bson.M{"$set": bson.M{"status": models.AccountDeletionStatusInProgress, "updated_at": now}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "scheduled_for", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var d models.AccountDeletion
+	if err := r.collection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// MarkDeleted records that the account itself is gone and starts tracking
+// the services its data cascades to
+func (r *AccountDeletionRepository) MarkDeleted(ctx context.Context, id primitive.ObjectID, deletedAt time.Time, services []models.ErasureServiceProgress) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection().UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"deleted_at": deletedAt,
+		"services":   services,
+		"updated_at": time.Now(),
+	}})
+	return err
+}
+
+// ListOpen returns deletions whose account is removed but whose cascade has
+// not settled, for the retry worker
+func (r *AccountDeletionRepository) ListOpen(ctx context.Context, limit int64) ([]models.AccountDeletion, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": models.AccountDeletionStatusInProgress, "deleted_at": bson.M{"$ne": nil}}
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: 1}}).SetLimit(limit)
+	cursor, err := r.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	deletions := []models.AccountDeletion{}
+	if err := cursor.All(ctx, &deletions); err != nil {
+		return nil, err
+	}
+	return deletions, nil
+}
+
+// UpdateService atomically replaces one service's progress entry so that
+// concurrent acknowledgements from different services never clobber each other
+func (r *AccountDeletionRepository) UpdateService(ctx context.Context, id primitive.ObjectID, progress models.ErasureServiceProgress) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection().UpdateOne(ctx,
+		bson.M{"_id": id, "services.service": progress.Service},
+		bson.M{"$set": bson.M{"services.$": progress, "updated_at": time.Now()}},
+	)
+	return err
+}
+
+// SetStatus updates the overall status of a deletion
+func (r *AccountDeletionRepository) SetStatus(ctx context.Context, id primitive.ObjectID, status models.AccountDeletionStatus, completedAt *time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection().UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"status":       status,
+		"completed_at": completedAt,
+		"updated_at":   time.Now(),
+	}})
+	return err
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ErrAlreadyBlocked = errors.New("user is already blocked")
+	ErrBlockNotFound  = errors.New("block not found")
+)
+
+type BlockRepository struct {
+	db *mongo.Database
+}
+
+func NewBlockRepository(db *mongo.Database) *BlockRepository {
+	_, _ = db.Collection("blocks").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "blocker_id", Value: 1}, {Key: "blocked_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "blocked_id", Value: 1}}},
+	})
+	return &BlockRepository{db: db}
+}
+
+func (r *BlockRepository) Create(ctx context.Context, blockerID, blockedID primitive.ObjectID) (*models.UserBlock, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	block := &models.UserBlock{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+		CreatedAt: time.Now(),
+	}
+	res, err := r.db.Collection("blocks").InsertOne(ctx, block)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrAlreadyBlocked
+	}
+	if err != nil {
+		return nil, err
+	}
+	block.ID = res.InsertedID.(primitive.ObjectID)
+	return block, nil
+}
+
+func (r *BlockRepository) Delete(ctx context.Context, blockerID, blockedID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	res, err := r.db.Collection("blocks").DeleteOne(ctx, bson.M{"blocker_id": blockerID, "blocked_id": blockedID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrBlockNotFound
+	}
+	return nil
+}
+
+// IsBlocked reports whether either user has blocked the other
+func (r *BlockRepository) IsBlocked(ctx context.Context, userID1, userID2 primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	count, err := r.db.Collection("blocks").CountDocuments(ctx, bson.M{
+		"$or": []bson.M{
+			{"blocker_id": userID1, "blocked_id": userID2},
+			{"blocker_id": userID2, "blocked_id": userID1},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ListBlocked returns the blocks made by blockerID, newest first
+func (r *BlockRepository) ListBlocked(ctx context.Context, blockerID primitive.ObjectID) ([]models.UserBlock, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.db.Collection("blocks").Find(ctx,
+		bson.M{"blocker_id": blockerID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var blocks []models.UserBlock
+	if err := cursor.All(ctx, &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DataExportRepository persists users' data export requests
+type DataExportRepository struct {
+	db *mongo.Database
+}
+
+func NewDataExportRepository(db *mongo.Database) *DataExportRepository {
+	_, err := db.Collection("data_exports").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "updated_at", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("Failed to create data export indexes: %v", err)
+	}
+	return &DataExportRepository{db: db}
+}
+
+func (r *DataExportRepository) collection() *mongo.Collection {
+	return r.db.Collection("data_exports")
+}
+
+func (r *DataExportRepository) Create(ctx context.Context, req *models.DataExportRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := r.collection().InsertOne(ctx, req)
+	if err != nil {
+		return err
+	}
+	req.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *DataExportRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.DataExportRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var req models.DataExportRequest
+	if err := r.collection().FindOne(ctx, bson.M{"_id": id}).Decode(&req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// FindOpenByUser returns the user's queued or running export, if any
+func (r *DataExportRepository) FindOpenByUser(ctx context.Context, userID primitive.ObjectID) (*models.DataExportRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"user_id": userID,
+		"status":  bson.M{"$in": []models.DataExportStatus{models.DataExportStatusPending, models.DataExportStatusInProgress}},
+	}
+	var req models.DataExportRequest
+	if err := r.collection().FindOne(ctx, filter).Decode(&req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *DataExportRepository) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.DataExportRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection().Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	requests := []models.DataExportRequest{}
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// ClaimNext atomically moves the oldest queued export to in progress so only
+// one worker builds it. Exports left in progress since before staleBefore
+// belong to a worker that died and are claimed again.
+func (r *DataExportRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*models.DataExportRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"$or": []bson.M{
+		{"status": models.DataExportStatusPending},
+		{"status": models.DataExportStatusInProgress, "updated_at": bson.M{"$lt": staleBefore}},
+	}}
+	update := bson.M{"$set": bson.M{"status": models.DataExportStatusInProgress, "updated_at": time.Now()}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var req models.DataExportRequest
+	if err := r.collection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// Save replaces a request, refreshing its updated_at
+func (r *DataExportRepository) Save(ctx context.Context, req *models.DataExportRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req.UpdatedAt = time.Now()
+	_, err := r.collection().ReplaceOne(ctx, bson.M{"_id": req.ID}, req)
+	return err
+}
+
+// ListExpired returns ready exports whose archive is past its retention
+func (r *DataExportRepository) ListExpired(ctx context.Context, now time.Time, limit int64) ([]models.DataExportRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": models.DataExportStatusReady, "expires_at": bson.M{"$lte": now}}
+	opts := options.Find().SetSort(bson.D{{Key: "expires_at", Value: 1}}).SetLimit(limit)
+	cursor, err := r.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	requests := []models.DataExportRequest{}
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
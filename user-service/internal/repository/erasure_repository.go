@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErasureRepository persists GDPR erasure requests and their per-service progress
+type ErasureRepository struct {
+	db *mongo.Database
+}
+
+func NewErasureRepository(db *mongo.Database) *ErasureRepository {
+	_, err := db.Collection("erasure_requests").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "updated_at", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("Failed to create erasure request indexes: %v", err)
+	}
+	return &ErasureRepository{db: db}
+}
+
+func (r *ErasureRepository) collection() *mongo.Collection {
+	return r.db.Collection("erasure_requests")
+}
+
+func (r *ErasureRepository) Create(ctx context.Context, req *models.ErasureRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := r.collection().InsertOne(ctx, req)
+	if err != nil {
+		return err
+	}
+	req.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *ErasureRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.ErasureRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var req models.ErasureRequest
+	if err := r.collection().FindOne(ctx, bson.M{"_id": id}).Decode(&req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// FindOpenByUser returns the user's unsettled request, if any
+func (r *ErasureRepository) FindOpenByUser(ctx context.Context, userID primitive.ObjectID) (*models.ErasureRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"user_id": userID,
+		"status":  bson.M{"$in": []models.ErasureStatus{models.ErasureStatusPending, models.ErasureStatusInProgress}},
+	}
+	var req models.ErasureRequest
+	if err := r.collection().FindOne(ctx, filter).Decode(&req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *ErasureRepository) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.ErasureRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection().Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	requests := []models.ErasureRequest{}
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// List returns requests filtered by status (empty for all), newest first
+func (r *ErasureRepository) List(ctx context.Context, status models.ErasureStatus, limit, page int64) ([]models.ErasureRequest, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	total, err := r.collection().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	requests := []models.ErasureRequest{}
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, 0, err
+	}
+	return requests, total, nil
+}
+
+// ListOpen returns every unsettled request for the retry worker
+func (r *ErasureRepository) ListOpen(ctx context.Context, limit int64) ([]models.ErasureRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": bson.M{"$in": []models.ErasureStatus{models.ErasureStatusPending, models.ErasureStatusInProgress}}}
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: 1}}).SetLimit(limit)
+	cursor, err := r.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	requests := []models.ErasureRequest{}
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// UpdateService atomically replaces one service's progress entry so that
+// concurrent acknowledgements from different services never clobber each other
+func (r *ErasureRepository) UpdateService(ctx context.Context, id primitive.ObjectID, progress models.ErasureServiceProgress) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection().UpdateOne(ctx,
+		bson.M{"_id": id, "services.service": progress.Service},
+		bson.M{"$set": bson.M{"services.$": progress, "updated_at": time.Now()}},
+	)
+	return err
+}
+
+// SetStatus updates the overall status of a request
+func (r *ErasureRepository) SetStatus(ctx context.Context, id primitive.ObjectID, status models.ErasureStatus, completedAt *time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection().UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"status":       status,
+		"completed_at": completedAt,
+		"updated_at":   time.Now(),
+	}})
+	return err
+}
@@ -73,7 +73,7 @@ func (r *GraphRepository) BlockUser(ctx context.Context, blocker, blocked primit
 		MERGE (u1)-[b:BLOCKED]->(u2)
 		ON CREATE SET b.created_at = datetime()
 		WITH u1, u2
-		OPTIONAL MATCH (u1)-[r:FRIEND|REQUESTED]-(u2)
+		OPTIONAL MATCH (u1)-[r:FRIEND|REQUESTED|FOLLOWS|CLOSE_FRIEND]-(u2)
 		DELETE r
 	`
 	params := map[string]any{"blocker": blocker.Hex(), "blocked": blocked.Hex()}
@@ -103,3 +103,357 @@ func (r *GraphRepository) GetFriendIDs(ctx context.Context, userID primitive.Obj
 	}
 	return ids, nil
 }
+
+// GetBlockedIDs returns the users that userID blocked or was blocked by
+func (r *GraphRepository) GetBlockedIDs(ctx context.Context, userID primitive.ObjectID) ([]string, error) {
+	query := `MATCH (u:User {id: $userID})-[:BLOCKED]-(b:User) RETURN DISTINCT b.id`
+	params := map[string]any{"userID": userID.Hex()}
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, rec := range result.Records {
+		if id, ok := rec.Values[0].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// Follow adds a FOLLOWS edge from follower to followee. It reports whether
+// the edge is new, so following twice is harmless.
+func (r *GraphRepository) Follow(ctx context.Context, follower, followee primitive.ObjectID) (bool, error) {
+	query := `
+		MERGE (u1:User {id: $follower})
+		MERGE (u2:User {id: $followee})
+		MERGE (u1)-[f:FOLLOWS]->(u2)
+		ON CREATE SET f.created_at = datetime(), f.created = true
+		WITH f, coalesce(f.created, false) AS created
+		REMOVE f.created
+		RETURN created
+	`
+	params := map[string]any{"follower": follower.Hex(), "followee": followee.Hex()}
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil || len(result.Records) == 0 {
+		return false, err
+	}
+	created, _ := result.Records[0].Values[0].(bool)
+	return created, nil
+}
+
+// Unfollow removes the FOLLOWS edge from follower to followee, reporting
+// whether there was one
+func (r *GraphRepository) Unfollow(ctx context.Context, follower, followee primitive.ObjectID) (bool, error) {
+	query := `
+		MATCH (u1:User {id: $follower})-[f:FOLLOWS]->(u2:User {id: $followee})
+		DELETE f
+		RETURN count(f)
+	`
+	params := map[string]any{"follower": follower.Hex(), "followee": followee.Hex()}
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil || len(result.Records) == 0 {
+		return false, err
+	}
+	deleted, _ := result.Records[0].Values[0].(int64)
+	return deleted > 0, nil
+}
+
+// GetFollowCounts counts userID's followers and the users they follow
+func (r *GraphRepository) GetFollowCounts(ctx context.Context, userID primitive.ObjectID) (followers, following int64, err error) {
+	query := `
+		OPTIONAL MATCH (u:User {id: $userID})
+		RETURN COUNT { (u)<-[:FOLLOWS]-(:User) }, COUNT { (u)-[:FOLLOWS]->(:User) }
+	`
+	params := map[string]any{"userID": userID.Hex()}
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil || len(result.Records) == 0 {
+		return 0, 0, err
+	}
+	followers, _ = result.Records[0].Values[0].(int64)
+	following, _ = result.Records[0].Values[1].(int64)
+	return followers, following, nil
+}
+
+// GetFollowerIDs returns a page of the users following userID, most recent first
+func (r *GraphRepository) GetFollowerIDs(ctx context.Context, userID primitive.ObjectID, skip, limit int) ([]string, error) {
+	query := `
+		MATCH (u:User {id: $userID})<-[f:FOLLOWS]-(s:User)
+		RETURN s.id
+		ORDER BY f.created_at DESC
+		SKIP $skip LIMIT $limit
+	`
+	return r.followIDs(ctx, query, userID, skip, limit)
+}
+
+// GetFollowingIDs returns a page of the users userID follows, most recent first
+func (r *GraphRepository) GetFollowingIDs(ctx context.Context, userID primitive.ObjectID, skip, limit int) ([]string, error) {
+	query := `
+		MATCH (u:User {id: $userID})-[f:FOLLOWS]->(s:User)
+		RETURN s.id
+		ORDER BY f.created_at DESC
+		SKIP $skip LIMIT $limit
+	`
+	return r.followIDs(ctx, query, userID, skip, limit)
+}
+
+func (r *GraphRepository) followIDs(ctx context.Context, query string, userID primitive.ObjectID, skip, limit int) ([]string, error) {
+	params := map[string]any{"userID": userID.Hex(), "skip": skip, "limit": limit}
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(result.Records))
+	for _, rec := range result.Records {
+		if id, ok := rec.Values[0].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// FollowCandidate is a user followed by people userID follows
+type FollowCandidate struct {
+	UserID           string
+	FollowedByMutual int
+	Followers        int64
+}
+
+// FollowCandidates returns users followed by the people userID follows,
+// most widely followed among them first. Users already followed, friends,
+// blocked users in either direction and dismissed suggestions are left out.
+func (r *GraphRepository) FollowCandidates(ctx context.Context, userID primitive.ObjectID, limit int) ([]FollowCandidate, error) {
+	query := `
+		MATCH (u:User {id: $userID})-[:FOLLOWS]->(:User)-[:FOLLOWS]->(s:User)
+		WHERE s <> u
+		  AND NOT (u)-[:FOLLOWS|FRIEND|BLOCKED]-(s)
+		  AND NOT (s)-[:BLOCKED]->(u)
+		  AND NOT (u)-[:DISMISSED]->(s)
+		WITH s, count(*) AS mutual
+		RETURN s.id, mutual, COUNT { (s)<-[:FOLLOWS]-(:User) } AS followers
+		ORDER BY mutual DESC, followers DESC
+		LIMIT $limit
+	`
+	params := map[string]any{"userID": userID.Hex(), "limit": limit}
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]FollowCandidate, 0, len(result.Records))
+	for _, rec := range result.Records {
+		id, ok := rec.Values[0].(string)
+		if !ok {
+			continue
+		}
+		mutual, _ := rec.Values[1].(int64)
+		followers, _ := rec.Values[2].(int64)
+		candidates = append(candidates, FollowCandidate{UserID: id, FollowedByMutual: int(mutual), Followers: followers})
+	}
+	return candidates, nil
+}
+
+// AddCloseFriend puts friend on owner's close friends list as a CLOSE_FRIEND
+// edge. It reports false when the two are not friends.
+func (r *GraphRepository) AddCloseFriend(ctx context.Context, owner, friend primitive.ObjectID) (bool, error) {
+	query := `
+		MATCH (u:User {id: $owner})-[:FRIEND]-(f:User {id: $friend})
+		WITH DISTINCT u, f
+		MERGE (u)-[c:CLOSE_FRIEND]->(f)
+		ON CREATE SET c.created_at = datetime()
+		RETURN count(c)
+	`
+	params := map[string]any{"owner": owner.Hex(), "friend": friend.Hex()}
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil || len(result.Records) == 0 {
+		return false, err
+	}
+	added, _ := result.Records[0].Values[0].(int64)
+	return added > 0, nil
+}
+
+// RemoveCloseFriend takes friend off owner's close friends list, reporting
+// whether they were on it
+func (r *GraphRepository) RemoveCloseFriend(ctx context.Context, owner, friend primitive.ObjectID) (bool, error) {
+	query := `
+		MATCH (u:User {id: $owner})-[c:CLOSE_FRIEND]->(f:User {id: $friend})
+		DELETE c
+		RETURN count(c)
+	`
+	params := map[string]any{"owner": owner.Hex(), "friend": friend.Hex()}
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil || len(result.Records) == 0 {
+		return false, err
+	}
+	removed, _ := result.Records[0].Values[0].(int64)
+	return removed > 0, nil
+}
+
+// GetCloseFriendIDs returns owner's close friends, most recently added
+// first. Edges to people who are no longer friends are ignored.
+func (r *GraphRepository) GetCloseFriendIDs(ctx context.Context, owner primitive.ObjectID) ([]string, error) {
+	query := `
+		MATCH (u:User {id: $userID})-[c:CLOSE_FRIEND]->(f:User)
+		WHERE (u)-[:FRIEND]-(f)
+		RETURN f.id
+		ORDER BY c.created_at DESC
+	`
+	return r.idList(ctx, query, owner)
+}
+
+// GetCloseFriendOfIDs returns the friends who have userID on their close
+// friends list
+func (r *GraphRepository) GetCloseFriendOfIDs(ctx context.Context, userID primitive.ObjectID) ([]string, error) {
+	query := `
+		MATCH (u:User {id: $userID})<-[:CLOSE_FRIEND]-(o:User)
+		WHERE (u)-[:FRIEND]-(o)
+		RETURN o.id
+	`
+	return r.idList(ctx, query, userID)
+}
+
+// IsCloseFriend reports whether owner has friend on their close friends list
+func (r *GraphRepository) IsCloseFriend(ctx context.Context, owner, friend primitive.ObjectID) (bool, error) {
+	query := `
+		OPTIONAL MATCH (u:User {id: $owner})-[:CLOSE_FRIEND]->(f:User {id: $friend})
+		WHERE (u)-[:FRIEND]-(f)
+		RETURN f IS NOT NULL
+	`
+	params := map[string]any{"owner": owner.Hex(), "friend": friend.Hex()}
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil || len(result.Records) == 0 {
+		return false, err
+	}
+	isCloseFriend, _ := result.Records[0].Values[0].(bool)
+	return isCloseFriend, nil
+}
+
+func (r *GraphRepository) idList(ctx context.Context, query string, userID primitive.ObjectID) ([]string, error) {
+	params := map[string]any{"userID": userID.Hex()}
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(result.Records))
+	for _, rec := range result.Records {
+		if id, ok := rec.Values[0].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// SuggestionCandidate is a user reachable through the graph along with the
+// connections they share with the user being suggested to
+type SuggestionCandidate struct {
+	UserID            string `json:"user_id"`
+	MutualFriends     int    `json:"mutual_friends"`
+	SharedCommunities int    `json:"shared_communities"`
+	SharedEvents      int    `json:"shared_events"`
+}
+
+// SuggestionCandidates returns users connected to userID through mutual
+// friends, shared communities or groups, or events they are both going to.
+// Existing friends, pending requests, blocked users in either direction and
+// anyone userID dismissed are left out.
+func (r *GraphRepository) SuggestionCandidates(ctx context.Context, userID primitive.ObjectID, limit int) ([]SuggestionCandidate, error) {
+	query := `
+		MATCH (u:User {id: $userID})
+		CALL {
+			WITH u
+			MATCH (u)-[:FRIEND]-(:User)-[:FRIEND]-(s:User)
+			RETURN s, 'friend' AS via
+			UNION ALL
+			WITH u
+			MATCH (u)-[:MEMBER_OF]->(:Community|Group)<-[:MEMBER_OF]-(s:User)
+			RETURN s, 'community' AS via
+			UNION ALL
+			WITH u
+			MATCH (u)-[:GOING]->(:Event)<-[:GOING]-(s:User)
+			RETURN s, 'event' AS via
+		}
+		WITH u, s, via
+		WHERE s <> u
+		  AND NOT (u)-[:FRIEND|REQUESTED|BLOCKED]-(s)
+		  AND NOT (u)-[:DISMISSED]->(s)
+		WITH s.id AS id,
+		     sum(CASE via WHEN 'friend' THEN 1 ELSE 0 END) AS mutual,
+		     sum(CASE via WHEN 'community' THEN 1 ELSE 0 END) AS communities,
+		     sum(CASE via WHEN 'event' THEN 1 ELSE 0 END) AS events
+		RETURN id, mutual, communities, events
+		ORDER BY mutual DESC, communities DESC, events DESC
+		LIMIT $limit
+	`
+	params := map[string]any{"userID": userID.Hex(), "limit": limit}
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]SuggestionCandidate, 0, len(result.Records))
+	for _, rec := range result.Records {
+		id, ok := rec.Values[0].(string)
+		if !ok {
+			continue
+		}
+		mutual, _ := rec.Values[1].(int64)
+		communities, _ := rec.Values[2].(int64)
+		events, _ := rec.Values[3].(int64)
+		candidates = append(candidates, SuggestionCandidate{
+			UserID:            id,
+			MutualFriends:     int(mutual),
+			SharedCommunities: int(communities),
+			SharedEvents:      int(events),
+		})
+	}
+	return candidates, nil
+}
+
+// DismissSuggestion stops dismissed from being suggested to userID
+func (r *GraphRepository) DismissSuggestion(ctx context.Context, userID, dismissed primitive.ObjectID) error {
+	query := `
+		MERGE (u1:User {id: $userID})
+		MERGE (u2:User {id: $dismissed})
+		MERGE (u1)-[d:DISMISSED]->(u2)
+		ON CREATE SET d.created_at = datetime()
+	`
+	params := map[string]any{"userID": userID.Hex(), "dismissed": dismissed.Hex()}
+	_, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	return err
+}
+
+// RestoreSuggestion lets a dismissed user be suggested again
+func (r *GraphRepository) RestoreSuggestion(ctx context.Context, userID, dismissed primitive.ObjectID) error {
+	query := `MATCH (u1:User {id: $userID})-[d:DISMISSED]->(u2:User {id: $dismissed}) DELETE d`
+	params := map[string]any{"userID": userID.Hex(), "dismissed": dismissed.Hex()}
+	_, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	return err
+}
+
+// GetDismissedIDs returns the users userID dismissed, most recent first
+func (r *GraphRepository) GetDismissedIDs(ctx context.Context, userID primitive.ObjectID) ([]string, error) {
+	query := `
+		MATCH (u:User {id: $userID})-[d:DISMISSED]->(s:User)
+		RETURN s.id
+		ORDER BY d.created_at DESC
+	`
+	params := map[string]any{"userID": userID.Hex()}
+	result, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, rec := range result.Records {
+		if id, ok := rec.Values[0].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// DeleteUser removes a deleted account's node with all of its friendships,
+// follows, close friends, requests, blocks and dismissed suggestions
+func (r *GraphRepository) DeleteUser(ctx context.Context, userID primitive.ObjectID) error {
+	query := `MATCH (u:User {id: $userID}) DETACH DELETE u`
+	params := map[string]any{"userID": userID.Hex()}
+	_, err := neo4j.ExecuteQuery(ctx, r.driver, query, params, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("neo4j"))
+	return err
+}
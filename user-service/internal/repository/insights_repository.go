@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// insightRetention is how long daily insight buckets are kept, beyond the
+// longest window insights are reported over
+const insightRetention = 120 * 24 * time.Hour
+
+// InsightsRepository keeps creators' insights as one counter per user,
+// metric and UTC day
+type InsightsRepository struct {
+	db *mongo.Database
+}
+
+func NewInsightsRepository(db *mongo.Database) *InsightsRepository {
+	_, err := db.Collection("insight_buckets").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "owner_id", Value: 1}, {Key: "day", Value: 1}, {Key: "metric", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "day", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(insightRetention.Seconds())),
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to create insight indexes: %v", err)
+	}
+	return &InsightsRepository{db: db}
+}
+
+func (r *InsightsRepository) collection() *mongo.Collection {
+	return r.db.Collection("insight_buckets")
+}
+
+// Increment adds n to ownerID's metric on the UTC day of at
+func (r *InsightsRepository) Increment(ctx context.Context, ownerID primitive.ObjectID, metric models.InsightMetric, at time.Time, n int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	day := at.UTC().Truncate(24 * time.Hour)
+	_, err := r.collection().UpdateOne(ctx,
+		bson.M{"owner_id": ownerID, "day": day, "metric": metric},
+		bson.M{"$inc": bson.M{"count": n}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// ListSince returns ownerID's buckets from the day of from on, oldest first
+func (r *InsightsRepository) ListSince(ctx context.Context, ownerID primitive.ObjectID, from time.Time) ([]models.InsightBucket, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"owner_id": ownerID,
+		"day":      bson.M{"$gte": from.UTC().Truncate(24 * time.Hour)},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "day", Value: 1}})
+	cursor, err := r.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	buckets := []models.InsightBucket{}
+	if err := cursor.All(ctx, &buckets); err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
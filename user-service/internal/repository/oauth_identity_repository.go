@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrOAuthIdentityTaken is returned when the provider account is already
+// linked to a user, or the user already has an account at the provider linked
+var ErrOAuthIdentityTaken = errors.New("oauth identity already linked")
+
+// OAuthIdentityRepository keeps the provider accounts users sign in with
+type OAuthIdentityRepository struct {
+	db *mongo.Database
+}
+
+func NewOAuthIdentityRepository(db *mongo.Database) *OAuthIdentityRepository {
+	_, err := db.Collection("oauth_identities").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "provider", Value: 1}, {Key: "subject", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "provider", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to create oauth identity indexes: %v", err)
+	}
+	return &OAuthIdentityRepository{db: db}
+}
+
+func (r *OAuthIdentityRepository) collection() *mongo.Collection {
+	return r.db.Collection("oauth_identities")
+}
+
+func (r *OAuthIdentityRepository) Create(ctx context.Context, identity *models.OAuthIdentity) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := r.collection().InsertOne(ctx, identity)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrOAuthIdentityTaken
+	}
+	if err != nil {
+		return err
+	}
+	identity.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindBySubject returns the identity linked to the provider account, or
+// mongo.ErrNoDocuments
+func (r *OAuthIdentityRepository) FindBySubject(ctx context.Context, provider models.OAuthProvider, subject string) (*models.OAuthIdentity, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var identity models.OAuthIdentity
+	err := r.collection().FindOne(ctx, bson.M{"provider": provider, "subject": subject}).Decode(&identity)
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *OAuthIdentityRepository) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.OAuthIdentity, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "linked_at", Value: 1}})
+	cursor, err := r.collection().Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	identities := []models.OAuthIdentity{}
+	if err := cursor.All(ctx, &identities); err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+// Touch records a sign-in with the identity
+func (r *OAuthIdentityRepository) Touch(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection().UpdateByID(ctx, id, bson.M{"$set": bson.M{"last_used_at": at}})
+	return err
+}
+
+// Delete unlinks the user's account at provider, returning
+// mongo.ErrNoDocuments when there is none
+func (r *OAuthIdentityRepository) Delete(ctx context.Context, userID primitive.ObjectID, provider models.OAuthProvider) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := r.collection().DeleteOne(ctx, bson.M{"user_id": userID, "provider": provider})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteByUser unlinks every provider account of a removed user
+func (r *OAuthIdentityRepository) DeleteByUser(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection().DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
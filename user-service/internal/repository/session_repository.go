@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+type SessionRepository struct {
+	db *mongo.Database
+}
+
+func NewSessionRepository(db *mongo.Database) *SessionRepository {
+	_, _ = db.Collection("sessions").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "last_used_at", Value: -1}}},
+		{
+			// Mongo drops sessions once their refresh token could no longer be used
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *models.Session) (*models.Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	res, err := r.db.Collection("sessions").InsertOne(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	session.ID = res.InsertedID.(primitive.ObjectID)
+	return session, nil
+}
+
+func (r *SessionRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var session models.Session
+	err := r.db.Collection("sessions").FindOne(ctx, bson.M{"_id": id}).Decode(&session)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Rotate swaps the session's refresh token ID from oldTokenID to newTokenID,
+// reporting whether it did. It only matches while oldTokenID is current and
+// the session is live, so of two requests racing with the same token only one
+// wins.
+func (r *SessionRepository) Rotate(ctx context.Context, id primitive.ObjectID, oldTokenID, newTokenID string, expiresAt time.Time, device models.DeviceInfo) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	set := bson.M{
+		"refresh_token_id": newTokenID,
+		"last_used_at":     time.Now(),
+		"expires_at":       expiresAt,
+	}
+	if device.UserAgent != "" {
+		set["user_agent"] = device.UserAgent
+	}
+	if device.IPAddress != "" {
+		set["ip_address"] = device.IPAddress
+	}
+
+	res, err := r.db.Collection("sessions").UpdateOne(ctx, bson.M{
+		"_id":              id,
+		"refresh_token_id": oldTokenID,
+		"revoked_at":       bson.M{"$exists": false},
+	}, bson.M{"$set": set})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+// ListActive returns userID's live sessions, most recently used first
+func (r *SessionRepository) ListActive(ctx context.Context, userID primitive.ObjectID) ([]models.Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.db.Collection("sessions").Find(ctx,
+		bson.M{
+			"user_id":    userID,
+			"revoked_at": bson.M{"$exists": false},
+			"expires_at": bson.M{"$gt": time.Now()},
+		},
+		options.Find().SetSort(bson.D{{Key: "last_used_at", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	sessions := []models.Session{}
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// Revoke ends one of userID's live sessions
+func (r *SessionRepository) Revoke(ctx context.Context, userID, id primitive.ObjectID, reason string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	res, err := r.db.Collection("sessions").UpdateOne(ctx, bson.M{
+		"_id":        id,
+		"user_id":    userID,
+		"revoked_at": bson.M{"$exists": false},
+	}, bson.M{"$set": bson.M{
+		"revoked_at":     time.Now(),
+		"revoked_reason": reason,
+	}})
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"log"
 	"time"
 
@@ -12,6 +13,9 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ErrUsernameTaken is returned when another account holds the username
+var ErrUsernameTaken = errors.New("username is taken")
+
 type UserRepository struct {
 	db *mongo.Database
 }
@@ -110,6 +114,50 @@ func (r *UserRepository) UpdateUser(ctx context.Context, id primitive.ObjectID,
 	return &updatedUser, nil
 }
 
+// ChangeUsername renames the user from oldUsername to newUsername and starts
+// their cooldown. It returns mongo.ErrNoDocuments when the user no longer has
+// oldUsername, so of two concurrent changes only the first applies.
+func (r *UserRepository) ChangeUsername(ctx context.Context, id primitive.ObjectID, oldUsername, newUsername string, at time.Time) (*models.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	result := r.db.Collection("users").FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": id, "username": oldUsername},
+		bson.M{"$set": bson.M{"username": newUsername, "username_changed_at": at, "updated_at": at}},
+		opts,
+	)
+
+	var updatedUser models.User
+	if err := result.Decode(&updatedUser); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrUsernameTaken
+		}
+		return nil, err
+	}
+	return &updatedUser, nil
+}
+
+// ConsumeRecoveryCode removes a hashed recovery code from the user, reporting
+// whether it was there. The pull is atomic, so a code works only once.
+func (r *UserRepository) ConsumeRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := r.db.Collection("users").UpdateOne(ctx,
+		bson.M{"_id": id, "recovery_codes": codeHash},
+		bson.M{
+			"$pull": bson.M{"recovery_codes": codeHash},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
 // AddFriend adds friend to mongo array (Legacy/Redundant but kept for read compatibility if needed)
 func (r *UserRepository) AddFriend(ctx context.Context, userID1, userID2 primitive.ObjectID) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -139,6 +187,66 @@ func (r *UserRepository) RemoveFriend(ctx context.Context, userID, friendID prim
 	return err
 }
 
+// AddBlocked records the block on the blocker's document and drops the
+// friendship from both users
+func (r *UserRepository) AddBlocked(ctx context.Context, blockerID, blockedID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	session, err := r.db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		_, err := r.db.Collection("users").UpdateOne(sessCtx, bson.M{"_id": blockerID}, bson.M{
+			"$addToSet": bson.M{"blocked": blockedID},
+			"$pull":     bson.M{"friends": blockedID},
+		})
+		if err != nil {
+			return nil, err
+		}
+		_, err = r.db.Collection("users").UpdateOne(sessCtx, bson.M{"_id": blockedID}, bson.M{"$pull": bson.M{"friends": blockerID}})
+		return nil, err
+	})
+	return err
+}
+
+func (r *UserRepository) RemoveBlocked(ctx context.Context, blockerID, blockedID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err := r.db.Collection("users").UpdateOne(ctx, bson.M{"_id": blockerID}, bson.M{"$pull": bson.M{"blocked": blockedID}})
+	return err
+}
+
+// RemoveRelationships drops a deleted account from every other user's friends
+// and blocked lists and removes its friend requests and blocks
+func (r *UserRepository) RemoveRelationships(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := r.db.Collection("users").UpdateMany(ctx,
+		bson.M{"$or": []bson.M{{"friends": userID}, {"blocked": userID}}},
+		bson.M{"$pull": bson.M{"friends": userID, "blocked": userID}},
+	)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Collection("friendships").DeleteMany(ctx, bson.M{"$or": []bson.M{
+		{"requester_id": userID},
+		{"receiver_id": userID},
+	}})
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Collection("blocks").DeleteMany(ctx, bson.M{"$or": []bson.M{
+		{"blocker_id": userID},
+		{"blocked_id": userID},
+	}})
+	return err
+}
+
 func (r *UserRepository) CountUsers(ctx context.Context, filter bson.M) (int64, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
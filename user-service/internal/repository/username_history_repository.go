@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UsernameHistoryRepository keeps the usernames users changed away from
+type UsernameHistoryRepository struct {
+	db *mongo.Database
+}
+
+func NewUsernameHistoryRepository(db *mongo.Database) *UsernameHistoryRepository {
+	_, err := db.Collection("username_history").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "old_username", Value: 1}, {Key: "reserved_until", Value: -1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "changed_at", Value: -1}}},
+	})
+	if err != nil {
+		log.Printf("Failed to create username history indexes: %v", err)
+	}
+	return &UsernameHistoryRepository{db: db}
+}
+
+func (r *UsernameHistoryRepository) collection() *mongo.Collection {
+	return r.db.Collection("username_history")
+}
+
+func (r *UsernameHistoryRepository) Create(ctx context.Context, change *models.UsernameChange) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := r.collection().InsertOne(ctx, change)
+	if err != nil {
+		return err
+	}
+	change.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindReserved returns the latest changes away from each of usernames whose
+// reservation is still running at now, one per username
+func (r *UsernameHistoryRepository) FindReserved(ctx context.Context, usernames []string, now time.Time) ([]models.UsernameChange, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"old_username":   bson.M{"$in": usernames},
+		"reserved_until": bson.M{"$gt": now},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "reserved_until", Value: -1}})
+	cursor, err := r.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var changes []models.UsernameChange
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+
+	// A name can only be reserved by the last user to give it up
+	latest := make([]models.UsernameChange, 0, len(changes))
+	seen := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		if !seen[change.OldUsername] {
+			seen[change.OldUsername] = true
+			latest = append(latest, change)
+		}
+	}
+	return latest, nil
+}
+
+func (r *UsernameHistoryRepository) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.UsernameChange, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "changed_at", Value: -1}})
+	cursor, err := r.collection().Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	changes := []models.UsernameChange{}
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
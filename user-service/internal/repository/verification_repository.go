@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrVerificationPending is returned when the user already has a request
+// waiting for review
+var ErrVerificationPending = errors.New("a verification request is already pending")
+
+// VerificationRepository keeps identity verification requests and their reviews
+type VerificationRepository struct {
+	db *mongo.Database
+}
+
+func NewVerificationRepository(db *mongo.Database) *VerificationRepository {
+	_, err := db.Collection("verification_requests").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		// One request in review per user
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": models.VerificationPending}),
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to create verification request indexes: %v", err)
+	}
+	return &VerificationRepository{db: db}
+}
+
+func (r *VerificationRepository) collection() *mongo.Collection {
+	return r.db.Collection("verification_requests")
+}
+
+func (r *VerificationRepository) Create(ctx context.Context, req *models.VerificationRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := r.collection().InsertOne(ctx, req)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrVerificationPending
+	}
+	if err != nil {
+		return err
+	}
+	req.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *VerificationRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.VerificationRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var req models.VerificationRequest
+	if err := r.collection().FindOne(ctx, bson.M{"_id": id}).Decode(&req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// FindLatestByUser returns the user's most recent request, or
+// mongo.ErrNoDocuments when they never asked
+func (r *VerificationRepository) FindLatestByUser(ctx context.Context, userID primitive.ObjectID) (*models.VerificationRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	var req models.VerificationRequest
+	if err := r.collection().FindOne(ctx, bson.M{"user_id": userID}, opts).Decode(&req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// ListByStatus returns requests in status, oldest first so the review queue
+// is worked in order, starting after the request with ID after
+func (r *VerificationRepository) ListByStatus(ctx context.Context, status models.VerificationStatus, after primitive.ObjectID, limit int64) ([]models.VerificationRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": status}
+	if !after.IsZero() {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit)
+	cursor, err := r.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	requests := []models.VerificationRequest{}
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// Review records a decision on a pending request and forgets its document.
+// It returns mongo.ErrNoDocuments when the request is no longer pending,
+// e.g. when another admin reviewed it first.
+func (r *VerificationRepository) Review(ctx context.Context, id primitive.ObjectID, status models.VerificationStatus, reviewerID primitive.ObjectID, note string, at time.Time) (*models.VerificationRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": id, "status": models.VerificationPending}
+	update := bson.M{
+		"$set": bson.M{
+			"status":      status,
+			"reviewer_id": reviewerID,
+			"review_note": note,
+			"reviewed_at": at,
+		},
+		"$unset": bson.M{"document_key": ""},
+	}
+	// The document key is returned, so the caller can delete the document
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+	var before models.VerificationRequest
+	if err := r.collection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&before); err != nil {
+		return nil, err
+	}
+
+	reviewed := before
+	reviewed.Status = status
+	reviewed.ReviewerID = &reviewerID
+	reviewed.ReviewNote = note
+	reviewed.ReviewedAt = &at
+	return &reviewed, nil
+}
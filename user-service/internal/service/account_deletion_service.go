@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrAccountDeletionNotFound = errors.New("no account deletion found")
+	ErrDeletionPasswordInvalid = errors.New("password is incorrect")
+)
+
+// AccountDeletionConfig tunes the grace period and the cascade retries
+type AccountDeletionConfig struct {
+	GracePeriod  time.Duration // How long the user can still cancel
+	MaxAttempts  int           // Dispatches per service before it is marked failed
+	AckTimeout   time.Duration // How long to wait for an acknowledgement before redispatching
+	PollInterval time.Duration // How often the worker looks for due deletions and stalled services
+}
+
+// AccountDeletionService runs the account deletion saga. A deletion waits out
+// its grace period, then the account itself is removed and a UserDeleted
+// event is sent to every service holding the user's data. Each service's
+// acknowledgement is tracked the way erasure requests track theirs, and
+// services that fail or never answer are redispatched until they run out of
+// attempts.
+type AccountDeletionService struct {
+	repo     AccountDeletionRepository
+	users    UserRepository
+	remover  AccountRemover
+	producer EventProducer
+	cfg      AccountDeletionConfig
+	logger   *slog.Logger
+}
+
+func NewAccountDeletionService(repo AccountDeletionRepository, users UserRepository, remover AccountRemover, producer EventProducer, cfg AccountDeletionConfig, logger *slog.Logger) *AccountDeletionService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.GracePeriod <= 0 {
+		cfg.GracePeriod = 30 * 24 * time.Hour
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.AckTimeout <= 0 {
+		cfg.AckTimeout = 15 * time.Minute
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Minute
+	}
+	return &AccountDeletionService{repo: repo, users: users, remover: remover, producer: producer, cfg: cfg, logger: logger}
+}
+
+// Schedule deletes the user's account once the grace period is over. The
+// password is checked again first. A deletion that is already scheduled or
+// running is returned as is.
+func (s *AccountDeletionService) Schedule(ctx context.Context, userID primitive.ObjectID, req models.DeleteAccountRequest) (*models.AccountDeletion, error) {
+	user, err := s.users.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return nil, ErrDeletionPasswordInvalid
+	}
+
+	existing, err := s.repo.FindActiveByUser(ctx, userID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("failed to check existing deletions: %w", err)
+	}
+
+	now := time.Now()
+	deletion := &models.AccountDeletion{
+		UserID:       userID,
+		Reason:       req.Reason,
+		Status:       models.AccountDeletionStatusScheduled,
+		ScheduledFor: now.Add(s.cfg.GracePeriod),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := s.repo.Create(ctx, deletion); err != nil {
+		return nil, fmt.Errorf("failed to schedule account deletion: %w", err)
+	}
+	if _, err := s.users.UpdateUser(ctx, userID, bson.M{"deletion_scheduled_for": deletion.ScheduledFor}); err != nil {
+		s.logger.Error("Failed to flag account for deletion", "user_id", userID.Hex(), "error", err)
+	}
+	s.logger.Info("Account deletion scheduled", "deletion_id", deletion.ID.Hex(), "user_id", userID.Hex(), "scheduled_for", deletion.ScheduledFor)
+	return deletion, nil
+}
+
+// Cancel keeps the account, as long as its grace period has not ended
+func (s *AccountDeletionService) Cancel(ctx context.Context, userID primitive.ObjectID) (*models.AccountDeletion, error) {
+	deletion, err := s.repo.CancelScheduled(ctx, userID, time.Now())
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrAccountDeletionNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.users.UpdateUser(ctx, userID, bson.M{"deletion_scheduled_for": nil}); err != nil {
+		s.logger.Error("Failed to clear account deletion flag", "user_id", userID.Hex(), "error", err)
+	}
+	s.logger.Info("Account deletion cancelled", "deletion_id", deletion.ID.Hex(), "user_id", userID.Hex())
+	return deletion, nil
+}
+
+// Get returns the user's most recent deletion
+func (s *AccountDeletionService) Get(ctx context.Context, userID primitive.ObjectID) (*models.AccountDeletion, error) {
+	deletion, err := s.repo.FindLatestByUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrAccountDeletionNotFound
+		}
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// ProcessDue removes every account whose grace period has ended and starts
+// their cascade. Returns the number of accounts removed.
+func (s *AccountDeletionService) ProcessDue(ctx context.Context) (int, error) {
+	removed := 0
+	for {
+		now := time.Now()
+		deletion, err := s.repo.ClaimDue(ctx, now, now.Add(-s.cfg.AckTimeout))
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return removed, nil
+		}
+		if err != nil {
+			return removed, err
+		}
+
+		if err := s.remover.RemoveAccount(ctx, deletion.UserID); err != nil {
+			// Left in progress without the account removed, so it is claimed
+			// again once it counts as stale
+			s.logger.Error("Failed to remove account", "deletion_id", deletion.ID.Hex(), "user_id", deletion.UserID.Hex(), "error", err)
+			continue
+		}
+		s.startCascade(ctx, deletion)
+		removed++
+	}
+}
+
+// startCascade records the account as removed and dispatches the deletion to
+// every participating service
+func (s *AccountDeletionService) startCascade(ctx context.Context, deletion *models.AccountDeletion) {
+	deletedAt := time.Now()
+	deletion.DeletedAt = &deletedAt
+	deletion.Services = deletion.Services[:0]
+	for _, svc := range models.AccountDeletionServices {
+		deletion.Services = append(deletion.Services, models.ErasureServiceProgress{
+			Service: svc,
+			Status:  models.ErasureStatusPending,
+		})
+	}
+	if err := s.repo.MarkDeleted(ctx, deletion.ID, deletedAt, deletion.Services); err != nil {
+		s.logger.Error("Failed to record account removal", "deletion_id", deletion.ID.Hex(), "error", err)
+		return
+	}
+	s.logger.Info("Account removed, cascading deletion", "deletion_id", deletion.ID.Hex(), "user_id", deletion.UserID.Hex())
+
+	for i := range deletion.Services {
+		s.dispatch(ctx, deletion, &deletion.Services[i])
+	}
+	s.settle(ctx, deletion)
+}
+
+// dispatch publishes the deletion to one participant and records the
+// attempt. A failed publish leaves the service pending for the retry sweep.
+func (s *AccountDeletionService) dispatch(ctx context.Context, deletion *models.AccountDeletion, progress *models.ErasureServiceProgress) {
+	now := time.Now()
+	progress.Attempts++
+	progress.DispatchedAt = &now
+
+	payload, err := json.Marshal(events.UserDeletedEvent{
+		DeletionID: deletion.ID.Hex(),
+		UserID:     deletion.UserID.Hex(),
+		Service:    progress.Service,
+		Attempt:    progress.Attempts,
+		DeletedAt:  *deletion.DeletedAt,
+	})
+	if err == nil {
+		err = s.producer.Produce(ctx, []byte(deletion.UserID.Hex()), payload)
+	}
+	if err != nil {
+		progress.Status = models.ErasureStatusPending
+		progress.LastError = "dispatch failed: " + err.Error()
+		s.logger.Error("Failed to dispatch account deletion", "deletion_id", deletion.ID.Hex(), "service", progress.Service, "error", err)
+	} else {
+		progress.Status = models.ErasureStatusInProgress
+	}
+
+	if err := s.repo.UpdateService(ctx, deletion.ID, *progress); err != nil {
+		s.logger.Error("Failed to record account deletion dispatch", "deletion_id", deletion.ID.Hex(), "service", progress.Service, "error", err)
+	}
+}
+
+// RecordProgress applies a participant's acknowledgement. Repeated
+// acknowledgements of a completed service are ignored.
+func (s *AccountDeletionService) RecordProgress(ctx context.Context, evt events.UserDeletionProgressEvent) error {
+	deletionID, err := primitive.ObjectIDFromHex(evt.DeletionID)
+	if err != nil {
+		return fmt.Errorf("invalid deletion id %q: %w", evt.DeletionID, err)
+	}
+
+	deletion, err := s.repo.FindByID(ctx, deletionID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrAccountDeletionNotFound
+		}
+		return err
+	}
+
+	progress := deletion.Service(evt.Service)
+	if progress == nil {
+		return fmt.Errorf("service %q is not part of account deletion %s", evt.Service, evt.DeletionID)
+	}
+	if progress.Status == models.ErasureStatusCompleted {
+		return nil
+	}
+
+	if evt.Success {
+		completedAt := evt.Timestamp
+		if completedAt.IsZero() {
+			completedAt = time.Now()
+		}
+		progress.Status = models.ErasureStatusCompleted
+		progress.ItemsErased = evt.ItemsDeleted
+		progress.LastError = ""
+		progress.CompletedAt = &completedAt
+	} else {
+		progress.LastError = evt.Error
+		if progress.Attempts >= s.cfg.MaxAttempts {
+			progress.Status = models.ErasureStatusFailed
+		} else {
+			progress.Status = models.ErasureStatusPending
+		}
+	}
+
+	if err := s.repo.UpdateService(ctx, deletion.ID, *progress); err != nil {
+		return err
+	}
+	s.settle(ctx, deletion)
+	return nil
+}
+
+// settle derives the overall deletion status from its services
+func (s *AccountDeletionService) settle(ctx context.Context, deletion *models.AccountDeletion) {
+	completed, failed := 0, 0
+	for _, p := range deletion.Services {
+		switch p.Status {
+		case models.ErasureStatusCompleted:
+			completed++
+		case models.ErasureStatusFailed:
+			failed++
+		}
+	}
+
+	status := models.AccountDeletionStatusInProgress
+	switch {
+	case completed == len(deletion.Services):
+		status = models.AccountDeletionStatusCompleted
+	case completed+failed == len(deletion.Services):
+		status = models.AccountDeletionStatusFailed
+	}
+	if status == deletion.Status {
+		return
+	}
+
+	now := time.Now()
+	deletion.Status = status
+	deletion.CompletedAt = &now
+	if err := s.repo.SetStatus(ctx, deletion.ID, status, &now); err != nil {
+		s.logger.Error("Failed to update account deletion status", "deletion_id", deletion.ID.Hex(), "error", err)
+		return
+	}
+	s.logger.Info("Account deletion settled", "deletion_id", deletion.ID.Hex(), "user_id", deletion.UserID.Hex(), "status", status)
+}
+
+// RetryStalled redispatches services that failed or never acknowledged, and
+// marks services that exhausted their attempts as failed. Returns the number
+// of dispatches made.
+func (s *AccountDeletionService) RetryStalled(ctx context.Context) (int, error) {
+	deletions, err := s.repo.ListOpen(ctx, 100)
+	if err != nil {
+		return 0, err
+	}
+
+	dispatched := 0
+	now := time.Now()
+	for i := range deletions {
+		deletion := &deletions[i]
+		for j := range deletion.Services {
+			p := &deletion.Services[j]
+
+			stalled := p.Status == models.ErasureStatusInProgress &&
+				p.DispatchedAt != nil && now.Sub(*p.DispatchedAt) > s.cfg.AckTimeout
+			if p.Status != models.ErasureStatusPending && !stalled {
+				continue
+			}
+
+			if p.Attempts >= s.cfg.MaxAttempts {
+				p.Status = models.ErasureStatusFailed
+				if p.LastError == "" {
+					p.LastError = fmt.Sprintf("no acknowledgement after %d attempts", p.Attempts)
+				}
+				if err := s.repo.UpdateService(ctx, deletion.ID, *p); err != nil {
+					s.logger.Error("Failed to mark account deletion service failed", "deletion_id", deletion.ID.Hex(), "service", p.Service, "error", err)
+				}
+				continue
+			}
+
+			s.dispatch(ctx, deletion, p)
+			dispatched++
+		}
+		s.settle(ctx, deletion)
+	}
+	return dispatched, nil
+}
+
+// RunWorker removes due accounts and retries stalled cascades until ctx is
+// cancelled
+func (s *AccountDeletionService) RunWorker(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.ProcessDue(ctx); err != nil {
+				s.logger.Error("Account deletion sweep failed", "error", err)
+			} else if n > 0 {
+				s.logger.Info("Account deletion sweep removed accounts", "count", n)
+			}
+			if n, err := s.RetryStalled(ctx); err != nil {
+				s.logger.Error("Account deletion retry sweep failed", "error", err)
+			} else if n > 0 {
+				s.logger.Info("Account deletion retry sweep redispatched services", "count", n)
+			}
+		}
+	}
+}
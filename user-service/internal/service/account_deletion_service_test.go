@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"user-service/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+type fakeAccountRemover struct {
+	removed []primitive.ObjectID
+	err     error
+}
+
+func (f *fakeAccountRemover) RemoveAccount(ctx context.Context, userID primitive.ObjectID) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.removed = append(f.removed, userID)
+	return nil
+}
+
+func newTestAccountDeletionService(t *testing.T, repo *mocks.MockAccountDeletionRepository, remover *fakeAccountRemover, producer *mocks.MockEventProducer) *AccountDeletionService {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct-horse"), bcrypt.MinCost)
+	require.NoError(t, err)
+	users := &mocks.MockUserRepository{
+		FindUserByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+			return &models.User{ID: id, Password: string(hash)}, nil
+		},
+	}
+	return NewAccountDeletionService(repo, users, remover, producer, AccountDeletionConfig{
+		GracePeriod: 24 * time.Hour,
+		MaxAttempts: 2,
+		AckTimeout:  time.Minute,
+	}, slog.Default())
+}
+
+// scheduleDue schedules a deletion for userID whose grace period has already ended
+func scheduleDue(t *testing.T, svc *AccountDeletionService, repo *mocks.MockAccountDeletionRepository, userID primitive.ObjectID) *models.AccountDeletion {
+	deletion, err := svc.Schedule(context.Background(), userID, models.DeleteAccountRequest{Password: "correct-horse"})
+	require.NoError(t, err)
+	repo.Deletions[deletion.ID].ScheduledFor = time.Now().Add(-time.Second)
+	return deletion
+}
+
+func TestAccountDeletionService_Schedule(t *testing.T) {
+	repo := mocks.NewMockAccountDeletionRepository()
+	svc := newTestAccountDeletionService(t, repo, &fakeAccountRemover{}, &mocks.MockEventProducer{})
+	userID := primitive.NewObjectID()
+
+	_, err := svc.Schedule(context.Background(), userID, models.DeleteAccountRequest{Password: "wrong"})
+	assert.ErrorIs(t, err, ErrDeletionPasswordInvalid)
+	assert.Empty(t, repo.Deletions)
+
+	deletion, err := svc.Schedule(context.Background(), userID, models.DeleteAccountRequest{Password: "correct-horse", Reason: "leaving"})
+	require.NoError(t, err)
+	assert.Equal(t, models.AccountDeletionStatusScheduled, deletion.Status)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), deletion.ScheduledFor, time.Minute)
+
+	again, err := svc.Schedule(context.Background(), userID, models.DeleteAccountRequest{Password: "correct-horse"})
+	require.NoError(t, err)
+	assert.Equal(t, deletion.ID, again.ID)
+	assert.Len(t, repo.Deletions, 1)
+}
+
+func TestAccountDeletionService_Cancel(t *testing.T) {
+	repo := mocks.NewMockAccountDeletionRepository()
+	remover := &fakeAccountRemover{}
+	svc := newTestAccountDeletionService(t, repo, remover, &mocks.MockEventProducer{})
+	userID := primitive.NewObjectID()
+
+	_, err := svc.Cancel(context.Background(), userID)
+	assert.ErrorIs(t, err, ErrAccountDeletionNotFound)
+
+	scheduleDue(t, svc, repo, userID)
+	cancelled, err := svc.Cancel(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, models.AccountDeletionStatusCancelled, cancelled.Status)
+
+	removed, err := svc.ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+	assert.Empty(t, remover.removed)
+}
+
+func TestAccountDeletionService_ProcessDue(t *testing.T) {
+	t.Run("removes the account and dispatches to every participant", func(t *testing.T) {
+		repo := mocks.NewMockAccountDeletionRepository()
+		remover := &fakeAccountRemover{}
+		producer := &mocks.MockEventProducer{}
+		svc := newTestAccountDeletionService(t, repo, remover, producer)
+		userID := primitive.NewObjectID()
+		deletion := scheduleDue(t, svc, repo, userID)
+
+		removed, err := svc.ProcessDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		assert.Equal(t, []primitive.ObjectID{userID}, remover.removed)
+
+		require.Len(t, producer.ProduceCalls, len(models.AccountDeletionServices))
+		var evt events.UserDeletedEvent
+		require.NoError(t, json.Unmarshal(producer.ProduceCalls[0].Value, &evt))
+		assert.Equal(t, deletion.ID.Hex(), evt.DeletionID)
+		assert.Equal(t, userID.Hex(), evt.UserID)
+		assert.Equal(t, 1, evt.Attempt)
+
+		stored := repo.Deletions[deletion.ID]
+		assert.Equal(t, models.AccountDeletionStatusInProgress, stored.Status)
+		require.NotNil(t, stored.DeletedAt)
+		for _, p := range stored.Services {
+			assert.Equal(t, models.ErasureStatusInProgress, p.Status)
+		}
+	})
+
+	t.Run("leaves the deletion for a later sweep when removal fails", func(t *testing.T) {
+		repo := mocks.NewMockAccountDeletionRepository()
+		producer := &mocks.MockEventProducer{}
+		svc := newTestAccountDeletionService(t, repo, &fakeAccountRemover{err: errors.New("neo4j down")}, producer)
+		deletion := scheduleDue(t, svc, repo, primitive.NewObjectID())
+
+		removed, err := svc.ProcessDue(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, removed)
+		assert.Empty(t, producer.ProduceCalls)
+
+		stored := repo.Deletions[deletion.ID]
+		assert.Nil(t, stored.DeletedAt)
+		stored.UpdatedAt = time.Now().Add(-time.Hour)
+		svc.remover = &fakeAccountRemover{}
+
+		removed, err = svc.ProcessDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+	})
+}
+
+func TestAccountDeletionService_RecordProgress(t *testing.T) {
+	repo := mocks.NewMockAccountDeletionRepository()
+	producer := &mocks.MockEventProducer{}
+	svc := newTestAccountDeletionService(t, repo, &fakeAccountRemover{}, producer)
+	userID := primitive.NewObjectID()
+	deletion := scheduleDue(t, svc, repo, userID)
+	_, err := svc.ProcessDue(context.Background())
+	require.NoError(t, err)
+
+	for _, name := range models.AccountDeletionServices[1:] {
+		require.NoError(t, svc.RecordProgress(context.Background(), events.UserDeletionProgressEvent{
+			DeletionID: deletion.ID.Hex(), UserID: userID.Hex(), Service: name, Success: true, ItemsDeleted: 3,
+		}))
+	}
+	assert.Equal(t, models.AccountDeletionStatusInProgress, repo.Deletions[deletion.ID].Status)
+
+	// The first failure is redispatched, the second exhausts the attempts
+	first := models.AccountDeletionServices[0]
+	failure := events.UserDeletionProgressEvent{DeletionID: deletion.ID.Hex(), UserID: userID.Hex(), Service: first, Error: "mongo timeout"}
+	require.NoError(t, svc.RecordProgress(context.Background(), failure))
+	dispatched, err := svc.RetryStalled(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, dispatched)
+
+	require.NoError(t, svc.RecordProgress(context.Background(), failure))
+	stored := repo.Deletions[deletion.ID]
+	assert.Equal(t, models.AccountDeletionStatusFailed, stored.Status)
+	assert.Equal(t, models.ErasureStatusFailed, stored.Service(first).Status)
+	assert.Equal(t, int64(3), stored.Service(models.AccountDeletionServices[1]).ItemsErased)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrEmailAlreadyVerified = errors.New("email is already verified")
+	ErrEmailRecentlySent    = errors.New("an email was sent recently, try again later")
+)
+
+// Reason recorded on the sessions ended by a password reset
+const sessionRevokedPasswordReset = "password_reset"
+
+// AccountEmailConfig configures the links AccountEmailService mails
+type AccountEmailConfig struct {
+	AppURL          string        // Where the links point, without a trailing slash
+	VerificationTTL time.Duration // How long an email verification link works
+	ResetTTL        time.Duration // How long a password reset link works
+	ResendCooldown  time.Duration // Minimum time between two emails to the same address
+}
+
+// AccountEmailService runs the flows that prove a user owns their email
+// address: verifying it, and resetting a forgotten password. Both mail a link
+// holding a single-use token through the mailer's Kafka topic.
+type AccountEmailService struct {
+	users    *UserService
+	tokens   *TokenService
+	mail     EventProducer
+	sessions SessionRevoker
+	cfg      AccountEmailConfig
+	audit    *audit.Logger
+	logger   *slog.Logger
+}
+
+func NewAccountEmailService(users *UserService, tokens *TokenService, mail EventProducer, sessions SessionRevoker, cfg AccountEmailConfig, logger *slog.Logger) *AccountEmailService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &AccountEmailService{
+		users:    users,
+		tokens:   tokens,
+		mail:     mail,
+		sessions: sessions,
+		cfg:      cfg,
+		logger:   logger,
+	}
+}
+
+// SetAuditLogger enables recording verified emails and password resets
+func (s *AccountEmailService) SetAuditLogger(logger *audit.Logger) {
+	s.audit = logger
+}
+
+// SendVerification mails the user a link confirming their current email
+// address. A new link supersedes the previous one.
+func (s *AccountEmailService) SendVerification(ctx context.Context, userID primitive.ObjectID) error {
+	user, err := s.users.userRepo.FindUserByID(ctx, userID)
+	if err != nil || user == nil {
+		return ErrUserNotFound
+	}
+	if user.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+	return s.mailLink(ctx, TokenEmailVerification, user, s.cfg.VerificationTTL, "/verify-email", events.EmailTemplateVerifyEmail)
+}
+
+// VerifyEmail marks the address a verification token was mailed to as
+// verified. The token stops working once the user changes their email.
+func (s *AccountEmailService) VerifyEmail(ctx context.Context, token string) error {
+	userID, email, err := s.tokens.Consume(ctx, TokenEmailVerification, token)
+	if err != nil {
+		return err
+	}
+	user, err := s.users.userRepo.FindUserByID(ctx, userID)
+	if err != nil || user == nil || !strings.EqualFold(user.Email, email) {
+		return ErrInvalidToken
+	}
+	if user.EmailVerified {
+		return nil
+	}
+
+	if _, err := s.users.UpdateUser(ctx, userID, bson.M{"email_verified": true}); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditActionEmailVerified,
+		ActorID:    &userID,
+		TargetType: "user",
+		TargetID:   userID.Hex(),
+	})
+	return nil
+}
+
+// RequestPasswordReset mails a reset link to the account using email. It
+// reports success whether or not there is one, so it cannot be used to find
+// out which addresses have accounts.
+func (s *AccountEmailService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.users.userRepo.FindUserByEmail(ctx, strings.TrimSpace(email))
+	if err != nil || user == nil {
+		return nil
+	}
+	err = s.mailLink(ctx, TokenPasswordReset, user, s.cfg.ResetTTL, "/reset-password", events.EmailTemplatePasswordReset)
+	if errors.Is(err, ErrEmailRecentlySent) {
+		return nil
+	}
+	return err
+}
+
+// ResetPassword sets a new password with a token from a reset link and signs
+// the user out everywhere. Receiving the link proves they own the address,
+// so it also counts as verifying it. Two-factor authentication still applies
+// at the next sign-in.
+func (s *AccountEmailService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	userID, email, err := s.tokens.Consume(ctx, TokenPasswordReset, token)
+	if err != nil {
+		return err
+	}
+	user, err := s.users.userRepo.FindUserByID(ctx, userID)
+	if err != nil || user == nil || !strings.EqualFold(user.Email, email) {
+		return ErrInvalidToken
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash new password: %w", err)
+	}
+	if _, err := s.users.UpdateUser(ctx, userID, bson.M{
+		"password":       string(hashedPassword),
+		"email_verified": true,
+	}); err != nil {
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+	if err := s.sessions.RevokeAllSessions(ctx, userID, sessionRevokedPasswordReset); err != nil {
+		s.logger.Error("Failed to sign out after password reset", "user_id", userID.Hex(), "error", err)
+	}
+
+	if s.users.metrics != nil {
+		s.users.metrics.IncrementPasswordChanges()
+	}
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditActionPasswordReset,
+		ActorID:    &userID,
+		TargetType: "user",
+		TargetID:   userID.Hex(),
+	})
+	return nil
+}
+
+// mailLink issues a token for purpose and asks the mailer to send it to the
+// user as a link to path. Each address gets at most one email per
+// ResendCooldown and purpose.
+func (s *AccountEmailService) mailLink(ctx context.Context, purpose TokenPurpose, user *models.User, ttl time.Duration, path, template string) error {
+	sentKey := fmt.Sprintf("token:sent:%s:%s:%s", purpose, user.ID.Hex(), strings.ToLower(user.Email))
+	fresh, err := s.users.redisClient.SetNX(ctx, sentKey, 1, s.cfg.ResendCooldown).Result()
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrEmailRecentlySent
+	}
+
+	token, err := s.tokens.Issue(ctx, purpose, user.ID, user.Email, ttl)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(events.EmailRequestedEvent{
+		Template: template,
+		To:       user.Email,
+		UserID:   user.ID.Hex(),
+		Data: map[string]string{
+			"username":   user.Username,
+			"link":       s.cfg.AppURL + path + "?token=" + url.QueryEscape(token),
+			"expires_in": formatTTL(ttl),
+		},
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.mail.Produce(ctx, []byte(user.ID.Hex()), payload); err != nil {
+		// Let the user ask again right away
+		s.users.redisClient.Del(ctx, sentKey)
+		return fmt.Errorf("failed to queue email: %w", err)
+	}
+	return nil
+}
+
+// formatTTL words ttl for an email, in whole hours when it is one
+func formatTTL(ttl time.Duration) string {
+	if ttl >= time.Hour && ttl%time.Hour == 0 {
+		if ttl == time.Hour {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", ttl/time.Hour)
+	}
+	return fmt.Sprintf("%d minutes", ttl/time.Minute)
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"user-service/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+type accountEmailFixture struct {
+	svc      *AccountEmailService
+	user     *models.User
+	repo     *mocks.MockUserRepository
+	mail     *mocks.MockEventProducer
+	sessions *mocks.MockSessionRevoker
+}
+
+func newAccountEmailFixture(t *testing.T) *accountEmailFixture {
+	t.Helper()
+	f := &accountEmailFixture{
+		user:     &models.User{ID: primitive.NewObjectID(), Username: "jane", Email: "jane@example.com"},
+		repo:     &mocks.MockUserRepository{},
+		mail:     &mocks.MockEventProducer{},
+		sessions: &mocks.MockSessionRevoker{},
+	}
+	f.repo.FindUserByIDFunc = func(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+		copied := *f.user
+		return &copied, nil
+	}
+	f.repo.FindUserByEmailFunc = func(ctx context.Context, email string) (*models.User, error) {
+		if email != f.user.Email {
+			return nil, ErrUserNotFound
+		}
+		copied := *f.user
+		return &copied, nil
+	}
+	f.repo.UpdateUserFunc = func(ctx context.Context, id primitive.ObjectID, update bson.M) (*models.User, error) {
+		if v, ok := update["email_verified"].(bool); ok {
+			f.user.EmailVerified = v
+		}
+		if v, ok := update["password"].(string); ok {
+			f.user.Password = v
+		}
+		copied := *f.user
+		return &copied, nil
+	}
+
+	redisClient := newKeyValueRedis()
+	users := newTestUserService(f.repo, &mocks.MockEventProducer{}, redisClient)
+	f.svc = NewAccountEmailService(users, NewTokenService(redisClient, "secret"), f.mail, f.sessions, AccountEmailConfig{
+		AppURL:          "https://app.example.com",
+		VerificationTTL: 24 * time.Hour,
+		ResetTTL:        30 * time.Minute,
+		ResendCooldown:  time.Minute,
+	}, nil)
+	return f
+}
+
+// mailed returns the last email requested and the token in its link
+func (f *accountEmailFixture) mailed(t *testing.T) (events.EmailRequestedEvent, string) {
+	t.Helper()
+	require.NotEmpty(t, f.mail.ProduceCalls)
+	var evt events.EmailRequestedEvent
+	require.NoError(t, json.Unmarshal(f.mail.ProduceCalls[len(f.mail.ProduceCalls)-1].Value, &evt))
+	link, err := url.Parse(evt.Data["link"])
+	require.NoError(t, err)
+	return evt, link.Query().Get("token")
+}
+
+func TestAccountEmailService_VerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	f := newAccountEmailFixture(t)
+
+	require.NoError(t, f.svc.SendVerification(ctx, f.user.ID))
+	evt, token := f.mailed(t)
+	assert.Equal(t, events.EmailTemplateVerifyEmail, evt.Template)
+	assert.Equal(t, "jane@example.com", evt.To)
+	assert.True(t, strings.HasPrefix(evt.Data["link"], "https://app.example.com/verify-email?token="))
+	assert.Equal(t, "24 hours", evt.Data["expires_in"])
+
+	// One email per cooldown
+	assert.ErrorIs(t, f.svc.SendVerification(ctx, f.user.ID), ErrEmailRecentlySent)
+
+	require.NoError(t, f.svc.VerifyEmail(ctx, token))
+	assert.True(t, f.user.EmailVerified)
+	assert.ErrorIs(t, f.svc.VerifyEmail(ctx, token), ErrInvalidToken)
+	assert.ErrorIs(t, f.svc.SendVerification(ctx, f.user.ID), ErrEmailAlreadyVerified)
+}
+
+func TestAccountEmailService_VerifyEmail_AfterEmailChange(t *testing.T) {
+	ctx := context.Background()
+	f := newAccountEmailFixture(t)
+
+	require.NoError(t, f.svc.SendVerification(ctx, f.user.ID))
+	_, token := f.mailed(t)
+	f.user.Email = "jane@elsewhere.com"
+
+	assert.ErrorIs(t, f.svc.VerifyEmail(ctx, token), ErrInvalidToken)
+	assert.False(t, f.user.EmailVerified)
+
+	// The new address gets its own email right away
+	require.NoError(t, f.svc.SendVerification(ctx, f.user.ID))
+	evt, _ := f.mailed(t)
+	assert.Equal(t, "jane@elsewhere.com", evt.To)
+}
+
+func TestAccountEmailService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	f := newAccountEmailFixture(t)
+
+	require.NoError(t, f.svc.RequestPasswordReset(ctx, "nobody@example.com"))
+	assert.Empty(t, f.mail.ProduceCalls, "unknown addresses get no email, and no error")
+
+	require.NoError(t, f.svc.RequestPasswordReset(ctx, "jane@example.com"))
+	require.NoError(t, f.svc.RequestPasswordReset(ctx, "jane@example.com"))
+	require.Len(t, f.mail.ProduceCalls, 1, "the second request is within the cooldown")
+	evt, token := f.mailed(t)
+	assert.Equal(t, events.EmailTemplatePasswordReset, evt.Template)
+	assert.Equal(t, "30 minutes", evt.Data["expires_in"])
+
+	require.NoError(t, f.svc.ResetPassword(ctx, token, "NewPassw0rd"))
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(f.user.Password), []byte("NewPassw0rd")))
+	assert.True(t, f.user.EmailVerified)
+	assert.Equal(t, []primitive.ObjectID{f.user.ID}, f.sessions.Revoked)
+	assert.Equal(t, []string{sessionRevokedPasswordReset}, f.sessions.Reasons)
+
+	assert.ErrorIs(t, f.svc.ResetPassword(ctx, token, "OtherPassw0rd"), ErrInvalidToken)
+}
+
+func TestFormatTTL(t *testing.T) {
+	assert.Equal(t, "1 hour", formatTTL(time.Hour))
+	assert.Equal(t, "24 hours", formatTTL(24*time.Hour))
+	assert.Equal(t, "90 minutes", formatTTL(90*time.Minute))
+	assert.Equal(t, "30 minutes", formatTTL(30*time.Minute))
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Reason recorded on the sessions of deleted accounts
+const sessionRevokedAccountDeleted = "account_deleted"
+
+// RemoveAccount deletes an account whose grace period is over. The user is
+// signed out everywhere, dropped from the social graph and their record is
+// anonymized rather than removed, so content other services keep pointing at
+// it (a message in someone else's inbox, a review) resolves to "deleted
+// user". The email moves to the reserved .invalid domain to keep the unique
+// index satisfied, and the empty password hash never matches.
+func (s *AuthService) RemoveAccount(ctx context.Context, userID primitive.ObjectID) error {
+	if err := s.revokeAllSessions(ctx, userID, sessionRevokedAccountDeleted); err != nil {
+		return err
+	}
+	if err := s.userRepo.RemoveRelationships(ctx, userID); err != nil {
+		return fmt.Errorf("failed to remove relationships: %w", err)
+	}
+	if err := s.graphRepo.DeleteUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to remove graph node: %w", err)
+	}
+	if s.oauthIdentities != nil {
+		// Or signing in with a provider would reach the anonymized account
+		if err := s.oauthIdentities.DeleteByUser(ctx, userID); err != nil {
+			return fmt.Errorf("failed to unlink sign-in providers: %w", err)
+		}
+	}
+
+	now := time.Now()
+	_, err := s.userRepo.UpdateUser(ctx, userID, bson.M{
+		"username":               "deleted-" + userID.Hex(),
+		"email":                  userID.Hex() + "@deleted.invalid",
+		"password":               "",
+		"avatar":                 "",
+		"cover_picture":          "",
+		"full_name":              "",
+		"bio":                    "",
+		"date_of_birth":          nil,
+		"gender":                 "",
+		"location":               "",
+		"phone_number":           "",
+		"friends":                []primitive.ObjectID{},
+		"blocked":                []primitive.ObjectID{},
+		"two_factor_enabled":     false,
+		"two_factor_secret":      "",
+		"recovery_codes":         []string{},
+		"public_key":             "",
+		"encrypted_private_key":  "",
+		"key_backup_iv":          "",
+		"key_backup_salt":        "",
+		"is_encryption_enabled":  false,
+		"is_active":              false,
+		"deletion_scheduled_for": nil,
+		"deleted_at":             now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to anonymize account: %w", err)
+	}
+
+	if s.search != nil {
+		s.search.Delete(ctx, models.SearchEntityUser, userID.Hex())
+	}
+	log.Printf("Account %s removed", userID.Hex())
+	return nil
+}
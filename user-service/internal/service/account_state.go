@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/middleware"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrAccountBanned       = errors.New("account banned")
+	ErrInvalidSuspension   = errors.New("suspensions need a suspended_until in the future")
+	ErrCannotRestrictAdmin = errors.New("admins cannot change their own account state")
+)
+
+// Reason recorded on the sessions of suspended and banned users
+const sessionRevokedAccountState = "account_restricted"
+
+// signInError tells why the user may not start or refresh a session, if they
+// may not
+func signInError(user *models.User, now time.Time) error {
+	switch user.EffectiveAccountState(now) {
+	case models.AccountStateBanned:
+		return ErrAccountBanned
+	case models.AccountStateSuspended:
+		return ErrAccountSuspended
+	}
+	return nil
+}
+
+// validateAccountState checks an admin's change of standing
+func validateAccountState(req models.SetAccountStateRequest, now time.Time) error {
+	if req.State == models.AccountStateSuspended && (req.SuspendedUntil == nil || !req.SuspendedUntil.After(now)) {
+		return ErrInvalidSuspension
+	}
+	return nil
+}
+
+// SetAccountState changes a user's standing. Suspended and banned users are
+// signed out everywhere at once: their sessions are revoked and their user ID
+// is put on the Redis denylist the auth middleware checks, so access tokens
+// they already hold stop working too. Limited users are shadow-banned by
+// adding them to the set feeds and searches filter on.
+func (s *AuthService) SetAccountState(ctx context.Context, adminID, userID primitive.ObjectID, req models.SetAccountStateRequest) (*models.User, error) {
+	now := time.Now()
+	if err := validateAccountState(req, now); err != nil {
+		return nil, err
+	}
+	if adminID == userID {
+		return nil, ErrCannotRestrictAdmin
+	}
+	if _, err := s.userRepo.FindUserByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	update := bson.M{"account_state": req.State, "suspended_until": nil}
+	if req.State == models.AccountStateSuspended {
+		update["suspended_until"] = *req.SuspendedUntil
+	}
+	user, err := s.userRepo.UpdateUser(ctx, userID, update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update account state: %w", err)
+	}
+
+	if err := s.syncAccountRestrictions(ctx, user, now); err != nil {
+		return nil, err
+	}
+	log.Printf("Admin %s set account state of user %s to %s", adminID.Hex(), userID.Hex(), req.State)
+	entry := models.AuditEntry{
+		Action:     models.AuditActionAccountStateChanged,
+		ActorID:    &adminID,
+		TargetType: "user",
+		TargetID:   userID.Hex(),
+		Metadata:   map[string]string{"state": string(req.State)},
+	}
+	if req.SuspendedUntil != nil && req.State == models.AccountStateSuspended {
+		entry.Metadata["suspended_until"] = req.SuspendedUntil.Format(time.RFC3339)
+	}
+	s.audit.Record(ctx, entry)
+	return user, nil
+}
+
+// syncAccountRestrictions publishes the user's standing to Redis, where the
+// other services enforce it
+func (s *AuthService) syncAccountRestrictions(ctx context.Context, user *models.User, now time.Time) error {
+	userKey := user.ID.Hex()
+	state := user.EffectiveAccountState(now)
+
+	if state == models.AccountStateLimited {
+		if err := s.redisClient.SAdd(ctx, models.ShadowBannedUsersKey, userKey).Err(); err != nil {
+			return fmt.Errorf("failed to shadow-ban user: %w", err)
+		}
+	} else if err := s.redisClient.SRem(ctx, models.ShadowBannedUsersKey, userKey).Err(); err != nil {
+		return fmt.Errorf("failed to lift shadow ban: %w", err)
+	}
+
+	if state != models.AccountStateSuspended && state != models.AccountStateBanned {
+		if err := s.redisClient.Del(ctx, middleware.SuspendedUserKey(userKey)).Err(); err != nil {
+			return fmt.Errorf("failed to lift account restriction: %w", err)
+		}
+		return nil
+	}
+
+	// Access tokens outlive the denylist entry by at most AccessTokenTTL, and
+	// no new ones are issued while the restriction lasts
+	ttl := s.cfg.AccessTokenTTL
+	if state == models.AccountStateSuspended {
+		if remaining := user.SuspendedUntil.Sub(now); remaining < ttl {
+			ttl = remaining
+		}
+	}
+	if err := s.redisClient.Set(ctx, middleware.SuspendedUserKey(userKey), string(state), ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke tokens of restricted user: %w", err)
+	}
+	return s.revokeAllSessions(ctx, user.ID, sessionRevokedAccountState)
+}
+
+// revokeAllSessions signs the user out of every device
+func (s *AuthService) revokeAllSessions(ctx context.Context, userID primitive.ObjectID, reason string) error {
+	sessions, err := s.sessions.ListActive(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+	for _, session := range sessions {
+		if err := s.revokeSession(ctx, userID, session.ID, reason); err != nil && !errors.Is(err, ErrSessionNotFound) {
+			return err
+		}
+	}
+	return nil
+}
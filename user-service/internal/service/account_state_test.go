@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignInError(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		user models.User
+		want error
+	}{
+		{name: "active", user: models.User{}},
+		{name: "limited users keep signing in", user: models.User{AccountState: models.AccountStateLimited}},
+		{name: "suspended", user: models.User{AccountState: models.AccountStateSuspended, SuspendedUntil: &future}, want: ErrAccountSuspended},
+		{name: "lapsed suspension", user: models.User{AccountState: models.AccountStateSuspended, SuspendedUntil: &past}},
+		{name: "banned", user: models.User{AccountState: models.AccountStateBanned}, want: ErrAccountBanned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, signInError(&tt.user, now))
+		})
+	}
+}
+
+func TestValidateAccountState(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+
+	assert.NoError(t, validateAccountState(models.SetAccountStateRequest{State: models.AccountStateBanned}, now))
+	assert.NoError(t, validateAccountState(models.SetAccountStateRequest{State: models.AccountStateSuspended, SuspendedUntil: &future}, now))
+	assert.ErrorIs(t, validateAccountState(models.SetAccountStateRequest{State: models.AccountStateSuspended}, now), ErrInvalidSuspension)
+	assert.ErrorIs(t, validateAccountState(models.SetAccountStateRequest{State: models.AccountStateSuspended, SuspendedUntil: &past}, now), ErrInvalidSuspension)
+}
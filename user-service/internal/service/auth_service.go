@@ -10,39 +10,93 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/redis/go-redis/v9"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/jwks"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/middleware"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrAccountSuspended is returned when a suspended user signs in. Banned
+// users get ErrAccountBanned.
+var ErrAccountSuspended = errors.New("account suspended")
+
 type AuthService struct {
 	userRepo    *repository.UserRepository
 	graphRepo   *repository.GraphRepository
+	sessions    SessionRepository
 	redisClient *redis.Client
 	cfg         *config.Config
+	search      SearchIndexer
+	audit       *audit.Logger
+	usernames   UsernameHistoryRepository
+	emails      EmailVerifier
+
+	oauthIdentities OAuthIdentityRepository
+	oauthProviders  map[models.OAuthProvider]OAuthAuthenticator
+
+	signingKeys *jwks.KeyRing // Signs access tokens; the shared secret does without it
 }
 
 func NewAuthService(
 	userRepo *repository.UserRepository,
 	graphRepo *repository.GraphRepository,
+	sessions SessionRepository,
 	redisClient *redis.Client,
 	cfg *config.Config,
 ) *AuthService {
 	return &AuthService{
 		userRepo:    userRepo,
 		graphRepo:   graphRepo,
+		sessions:    sessions,
 		redisClient: redisClient,
 		cfg:         cfg,
 	}
 }
 
-func (s *AuthService) Register(ctx context.Context, user *models.User) (*models.AuthResponse, error) {
+// SetSearchIndexer enables indexing newly registered users for search
+func (s *AuthService) SetSearchIndexer(search SearchIndexer) {
+	s.search = search
+}
+
+// SetAuditLogger enables recording sign-ins and admin actions in the audit log
+func (s *AuthService) SetAuditLogger(logger *audit.Logger) {
+	s.audit = logger
+}
+
+// SetUsernameHistory keeps new accounts from taking usernames still
+// reserved to the users who changed away from them
+func (s *AuthService) SetUsernameHistory(history UsernameHistoryRepository) {
+	s.usernames = history
+}
+
+// SetEmailVerifier enables mailing new users a link to verify their email
+func (s *AuthService) SetEmailVerifier(emails EmailVerifier) {
+	s.emails = emails
+}
+
+// SetSigningKeys signs access tokens with the current key in keys, which
+// other services fetch from the published key set, instead of the shared
+// secret. Refresh tokens never leave this service and keep the secret.
+func (s *AuthService) SetSigningKeys(keys *jwks.KeyRing) {
+	s.signingKeys = keys
+}
+
+func (s *AuthService) Register(ctx context.Context, user *models.User, device models.DeviceInfo) (*models.AuthResponse, error) {
 	if u, _ := s.userRepo.FindUserByEmail(ctx, user.Email); u != nil {
 		return nil, errors.New("email already exists")
 	}
 	if u, _ := s.userRepo.FindUserByUserName(ctx, user.Username); u != nil {
 		return nil, errors.New("username already exists")
 	}
+	holder, err := usernameReservedBy(ctx, s.usernames, user.Username, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !holder.IsZero() {
+		return nil, errors.New("username already exists")
+	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -57,67 +111,109 @@ func (s *AuthService) Register(ctx context.Context, user *models.User) (*models.
 	}
 
 	s.enqueueGraphSync(createdUser.ID)
-
-	accessToken, refreshToken, err := s.generateTokens(ctx, createdUser)
-	if err != nil {
-		return nil, err
+	if s.search != nil {
+		s.search.UpsertUser(ctx, models.NewSearchUserDocument(createdUser))
+	}
+	if s.emails != nil {
+		if err := s.emails.SendVerification(ctx, createdUser.ID); err != nil {
+			// They can ask for another link once signed in
+			log.Printf("Failed to send verification email to user %s: %v", createdUser.ID.Hex(), err)
+		}
 	}
 
-	return &models.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		User:         createdUser.ToSafeResponse(),
-	}, nil
+	return s.issueTokens(ctx, createdUser, device)
 }
 
-func (s *AuthService) Login(ctx context.Context, email, password string) (*models.AuthResponse, error) {
+func (s *AuthService) Login(ctx context.Context, email, password string, device models.DeviceInfo) (*models.AuthResponse, error) {
 	user, err := s.userRepo.FindUserByEmail(ctx, email)
 	if err != nil {
+		s.auditLogin(ctx, nil, device, "unknown email", map[string]string{"email": email})
 		return nil, errors.New("invalid credentials")
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		s.auditLogin(ctx, user, device, "wrong password", nil)
 		return nil, errors.New("invalid credentials")
 	}
-
-	accessToken, refreshToken, err := s.generateTokens(ctx, user)
-	if err != nil {
+	if err := signInError(user, time.Now()); err != nil {
+		s.auditLogin(ctx, user, device, err.Error(), nil)
 		return nil, err
 	}
 
-	return &models.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		User:         user.ToSafeResponse(),
-	}, nil
+	if s.cfg.TwoFactorEnabled && user.TwoFactorEnabled {
+		return s.beginTwoFactorLogin(ctx, user)
+	}
+	s.auditLogin(ctx, user, device, "", nil)
+	return s.issueTokens(ctx, user, device)
+}
+
+// auditLogin records a sign-in attempt; an empty failure means it succeeded.
+// user is nil when the email matched no account.
+func (s *AuthService) auditLogin(ctx context.Context, user *models.User, device models.DeviceInfo, failure string, metadata map[string]string) {
+	entry := models.AuditEntry{
+		Action:     models.AuditActionLogin,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: "user",
+		IP:         device.IPAddress,
+		UserAgent:  device.UserAgent,
+		Metadata:   metadata,
+	}
+	if user != nil {
+		entry.ActorID = &user.ID
+		entry.TargetID = user.ID.Hex()
+	}
+	if failure != "" {
+		entry.Action = models.AuditActionLoginFailed
+		entry.Outcome = models.AuditOutcomeFailure
+		if entry.Metadata == nil {
+			entry.Metadata = map[string]string{}
+		}
+		entry.Metadata["reason"] = failure
+	}
+	s.audit.Record(ctx, entry)
 }
 
-func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*models.AuthResponse, error) {
+// RefreshToken rotates the refresh token of the session it belongs to. Each
+// refresh token works once; presenting one that was already rotated means it
+// leaked, so the whole session is revoked.
+func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string, device models.DeviceInfo) (*models.AuthResponse, error) {
 	token, err := jwt.Parse(refreshToken, func(token *jwt.Token) (interface{}, error) {
 		return []byte(s.cfg.JWTSecret), nil
 	})
 	if err != nil || !token.Valid {
-		return nil, errors.New("invalid token")
+		return nil, ErrInvalidRefreshToken
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok || claims["type"] != "refresh" {
-		return nil, errors.New("invalid token type")
+		return nil, ErrInvalidRefreshToken
+	}
+	userIDStr, _ := claims["id"].(string)
+	sessionIDStr, _ := claims["sid"].(string)
+	tokenID, _ := claims["jti"].(string)
+	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	sessionID, err := primitive.ObjectIDFromHex(sessionIDStr)
+	if err != nil || tokenID == "" {
+		return nil, ErrInvalidRefreshToken
 	}
 
-	userIDStr := claims["id"].(string)
-	storedToken, err := s.redisClient.Get(ctx, "refresh:"+userIDStr).Result()
-	if err != nil || storedToken != refreshToken {
-		return nil, errors.New("invalid refresh token")
+	newTokenID, err := s.rotateSession(ctx, userID, sessionID, tokenID, device)
+	if err != nil {
+		return nil, err
 	}
 
-	userID, _ := primitive.ObjectIDFromHex(userIDStr)
 	user, err := s.userRepo.FindUserByID(ctx, userID)
 	if err != nil {
 		return nil, errors.New("user not found")
 	}
+	if err := signInError(user, time.Now()); err != nil {
+		return nil, err
+	}
 
-	accessToken, newRefreshToken, err := s.generateTokens(ctx, user)
+	accessToken, newRefreshToken, err := s.generateTokens(user, sessionID, newTokenID)
 	if err != nil {
 		return nil, err
 	}
@@ -129,13 +225,30 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*m
 	}, nil
 }
 
+// Logout revokes the access token and ends the session it belongs to
 func (s *AuthService) Logout(ctx context.Context, userID, accessToken string) error {
 	// Blacklist access token
 	if err := s.redisClient.Set(ctx, "blacklist:"+accessToken, "1", s.cfg.AccessTokenTTL).Err(); err != nil {
 		return err
 	}
-	// Remove refresh token
-	return s.redisClient.Del(ctx, "refresh:"+userID).Err()
+
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil
+	}
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(accessToken, claims, s.accessTokenKeyfunc()); err != nil {
+		return nil
+	}
+	sessionIDStr, _ := claims["sid"].(string)
+	sessionID, err := primitive.ObjectIDFromHex(sessionIDStr)
+	if err != nil {
+		return nil
+	}
+	if err := s.revokeSession(ctx, uid, sessionID, sessionRevokedLogout); err != nil && !errors.Is(err, ErrSessionNotFound) {
+		return err
+	}
+	return nil
 }
 
 func (s *AuthService) enqueueGraphSync(userID primitive.ObjectID) {
@@ -159,20 +272,45 @@ func (s *AuthService) enqueueGraphSync(userID primitive.ObjectID) {
 	}()
 }
 
-func (s *AuthService) generateTokens(ctx context.Context, user *models.User) (string, string, error) {
+// issueTokens starts a new session for the device and signs its first tokens
+func (s *AuthService) issueTokens(ctx context.Context, user *models.User, device models.DeviceInfo) (*models.AuthResponse, error) {
+	session, err := s.startSession(ctx, user.ID, device)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, refreshToken, err := s.generateTokens(user, session.ID, session.RefreshTokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         user.ToSafeResponse(),
+	}, nil
+}
+
+// generateTokens signs an access token and a refresh token for the session.
+// Both carry the session ID so revoking the session rejects them; the refresh
+// token also carries tokenID, which the session must still hold to refresh.
+func (s *AuthService) generateTokens(user *models.User, sessionID primitive.ObjectID, tokenID string) (string, string, error) {
 	accessClaims := jwt.MapClaims{
 		"id":    user.ID.Hex(),
 		"email": user.Email,
+		"sid":   sessionID.Hex(),
 		"type":  "access",
 		"exp":   time.Now().Add(s.cfg.AccessTokenTTL).Unix(),
 	}
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims).SignedString([]byte(s.cfg.JWTSecret))
+	accessToken, err := s.signAccessToken(accessClaims)
 	if err != nil {
 		return "", "", err
 	}
 
 	refreshClaims := jwt.MapClaims{
 		"id":   user.ID.Hex(),
+		"sid":  sessionID.Hex(),
+		"jti":  tokenID,
 		"type": "refresh",
 		"exp":  time.Now().Add(s.cfg.RefreshTokenTTL).Unix(),
 	}
@@ -181,9 +319,22 @@ func (s *AuthService) generateTokens(ctx context.Context, user *models.User) (st
 		return "", "", err
 	}
 
-	if err := s.redisClient.Set(ctx, "refresh:"+user.ID.Hex(), refreshToken, s.cfg.RefreshTokenTTL).Err(); err != nil {
-		return "", "", err
+	return accessToken, refreshToken, nil
+}
+
+func (s *AuthService) signAccessToken(claims jwt.MapClaims) (string, error) {
+	if s.signingKeys != nil {
+		return s.signingKeys.Sign(claims)
 	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.cfg.JWTSecret))
+}
 
-	return accessToken, refreshToken, nil
+// accessTokenKeyfunc verifies access tokens as other services do: once
+// signing keys are in use, tokens signed with the shared secret are only
+// accepted until LegacyHS256Until
+func (s *AuthService) accessTokenKeyfunc() jwt.Keyfunc {
+	if s.signingKeys == nil {
+		return middleware.AccessTokenKeyfunc(s.cfg.JWTSecret, nil, time.Time{})
+	}
+	return middleware.AccessTokenKeyfunc(s.cfg.JWTSecret, s.signingKeys, s.cfg.LegacyHS256Until)
 }
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"user-service/internal/repository"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrCannotBlockSelf = errors.New("cannot block yourself")
+	ErrAlreadyBlocked  = errors.New("user is already blocked")
+	ErrNotBlocked      = errors.New("user is not blocked")
+	ErrUserNotFound    = errors.New("user not found")
+)
+
+// BlockService manages user blocks. A block is stored in the blocks
+// collection, mirrored on the blocker's user document and as a BLOCKED edge
+// in Neo4j, and announced on the friendship topic so the other services drop
+// the friendship and enforce the block.
+type BlockService struct {
+	blockRepo BlockRepository
+	userRepo  UserRepository
+	graph     BlockGraph
+	producer  EventProducer
+	logger    *slog.Logger
+
+	suggestions SuggestionInvalidator
+}
+
+func NewBlockService(blockRepo BlockRepository, userRepo UserRepository, graph BlockGraph, producer EventProducer, logger *slog.Logger) *BlockService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &BlockService{blockRepo: blockRepo, userRepo: userRepo, graph: graph, producer: producer, logger: logger}
+}
+
+// SetSuggestionInvalidator drops cached friend suggestions whenever a block
+// changes who may be suggested to whom
+func (s *BlockService) SetSuggestionInvalidator(suggestions SuggestionInvalidator) {
+	s.suggestions = suggestions
+}
+
+// Block blocks targetID for blockerID and ends any friendship between them
+func (s *BlockService) Block(ctx context.Context, blockerID, targetID primitive.ObjectID) (*models.UserBlock, error) {
+	if blockerID == targetID {
+		return nil, ErrCannotBlockSelf
+	}
+	if _, err := s.userRepo.FindUserByID(ctx, targetID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	block, err := s.blockRepo.Create(ctx, blockerID, targetID)
+	if errors.Is(err, repository.ErrAlreadyBlocked) {
+		return nil, ErrAlreadyBlocked
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create block: %w", err)
+	}
+
+	if err := s.userRepo.AddBlocked(ctx, blockerID, targetID); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	if err := s.graph.BlockUser(ctx, blockerID, targetID); err != nil {
+		return nil, fmt.Errorf("failed to sync block to graph: %w", err)
+	}
+
+	s.invalidateSuggestions(ctx, blockerID, targetID)
+	s.publish(ctx, blockerID, targetID, "blocked", "block")
+	return block, nil
+}
+
+// Unblock lifts a block made by blockerID. The friendship is not restored.
+func (s *BlockService) Unblock(ctx context.Context, blockerID, targetID primitive.ObjectID) error {
+	err := s.blockRepo.Delete(ctx, blockerID, targetID)
+	if errors.Is(err, repository.ErrBlockNotFound) {
+		return ErrNotBlocked
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete block: %w", err)
+	}
+
+	if err := s.userRepo.RemoveBlocked(ctx, blockerID, targetID); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	if err := s.graph.UnblockUser(ctx, blockerID, targetID); err != nil {
+		return fmt.Errorf("failed to sync unblock to graph: %w", err)
+	}
+
+	s.invalidateSuggestions(ctx, blockerID, targetID)
+	s.publish(ctx, blockerID, targetID, "unblocked", "unblock")
+	return nil
+}
+
+// IsBlocked reports whether either user has blocked the other
+func (s *BlockService) IsBlocked(ctx context.Context, userID1, userID2 primitive.ObjectID) (bool, error) {
+	return s.blockRepo.IsBlocked(ctx, userID1, userID2)
+}
+
+// ListBlocked returns the users blockerID has blocked, most recent first
+func (s *BlockService) ListBlocked(ctx context.Context, blockerID primitive.ObjectID) ([]models.UserShortResponse, error) {
+	blocks, err := s.blockRepo.ListBlocked(ctx, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(blocks))
+	for _, b := range blocks {
+		ids = append(ids, b.BlockedID)
+	}
+	return shortUsers(ctx, s.userRepo, ids)
+}
+
+// shortUsers loads public profiles for ids, keeping their order
+func shortUsers(ctx context.Context, userRepo UserRepository, ids []primitive.ObjectID) ([]models.UserShortResponse, error) {
+	result := []models.UserShortResponse{}
+	if len(ids) == 0 {
+		return result, nil
+	}
+	users, err := userRepo.FindUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[primitive.ObjectID]models.User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+	for _, id := range ids {
+		if u, ok := byID[id]; ok {
+			result = append(result, models.UserShortResponse{
+				ID:       u.ID,
+				Username: u.Username,
+				FullName: u.FullName,
+				Avatar:   u.Avatar,
+				Verified: u.Verified,
+			})
+		}
+	}
+	return result, nil
+}
+
+func (s *BlockService) invalidateSuggestions(ctx context.Context, userIDs ...primitive.ObjectID) {
+	if s.suggestions != nil {
+		s.suggestions.Invalidate(ctx, userIDs...)
+	}
+}
+
+func (s *BlockService) publish(ctx context.Context, blockerID, targetID primitive.ObjectID, status, action string) {
+	payload, err := json.Marshal(events.FriendshipEvent{
+		RequesterID: blockerID.Hex(),
+		ReceiverID:  targetID.Hex(),
+		Status:      status,
+		Action:      action,
+		Timestamp:   time.Now(),
+	})
+	if err != nil {
+		s.logger.Error("Failed to marshal block event", "error", err)
+		return
+	}
+	if err := s.producer.Produce(ctx, []byte(blockerID.Hex()), payload); err != nil {
+		s.logger.Error("Failed to publish block event", "action", action, "blocker_id", blockerID.Hex(), "error", err)
+	}
+}
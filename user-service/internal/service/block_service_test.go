@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"user-service/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+)
+
+func newTestBlockService() (*BlockService, *mocks.MockBlockRepository, *mocks.MockBlockGraph, *mocks.MockEventProducer) {
+	repo := &mocks.MockBlockRepository{}
+	graph := mocks.NewMockBlockGraph()
+	producer := &mocks.MockEventProducer{}
+	return NewBlockService(repo, &mocks.MockUserRepository{}, graph, producer, slog.Default()), repo, graph, producer
+}
+
+func TestBlockService_Block(t *testing.T) {
+	t.Run("blocks and announces the block", func(t *testing.T) {
+		svc, repo, graph, producer := newTestBlockService()
+		blocker, target := primitive.NewObjectID(), primitive.NewObjectID()
+
+		block, err := svc.Block(context.Background(), blocker, target)
+		require.NoError(t, err)
+		assert.Equal(t, target, block.BlockedID)
+		assert.Len(t, repo.Blocks, 1)
+		assert.Equal(t, []primitive.ObjectID{target}, graph.Blocked[blocker])
+
+		require.Len(t, producer.ProduceCalls, 1)
+		var evt events.FriendshipEvent
+		require.NoError(t, json.Unmarshal(producer.ProduceCalls[0].Value, &evt))
+		assert.Equal(t, "blocked", evt.Status)
+		assert.Equal(t, "block", evt.Action)
+		assert.Equal(t, blocker.Hex(), evt.RequesterID)
+	})
+
+	t.Run("rejects blocking yourself", func(t *testing.T) {
+		svc, _, _, _ := newTestBlockService()
+		id := primitive.NewObjectID()
+
+		_, err := svc.Block(context.Background(), id, id)
+		assert.ErrorIs(t, err, ErrCannotBlockSelf)
+	})
+
+	t.Run("rejects a duplicate block", func(t *testing.T) {
+		svc, _, _, producer := newTestBlockService()
+		blocker, target := primitive.NewObjectID(), primitive.NewObjectID()
+
+		_, err := svc.Block(context.Background(), blocker, target)
+		require.NoError(t, err)
+		_, err = svc.Block(context.Background(), blocker, target)
+		assert.ErrorIs(t, err, ErrAlreadyBlocked)
+		assert.Len(t, producer.ProduceCalls, 1)
+	})
+}
+
+func TestBlockService_Unblock(t *testing.T) {
+	svc, _, graph, producer := newTestBlockService()
+	blocker, target := primitive.NewObjectID(), primitive.NewObjectID()
+
+	assert.ErrorIs(t, svc.Unblock(context.Background(), blocker, target), ErrNotBlocked)
+
+	_, err := svc.Block(context.Background(), blocker, target)
+	require.NoError(t, err)
+	require.NoError(t, svc.Unblock(context.Background(), blocker, target))
+	assert.Empty(t, graph.Blocked[blocker])
+
+	blocked, err := svc.IsBlocked(context.Background(), target, blocker)
+	require.NoError(t, err)
+	assert.False(t, blocked)
+	assert.Len(t, producer.ProduceCalls, 2)
+}
+
+func TestBlockService_IsBlockedEitherDirection(t *testing.T) {
+	svc, _, _, _ := newTestBlockService()
+	blocker, target := primitive.NewObjectID(), primitive.NewObjectID()
+
+	_, err := svc.Block(context.Background(), blocker, target)
+	require.NoError(t, err)
+
+	blocked, err := svc.IsBlocked(context.Background(), target, blocker)
+	require.NoError(t, err)
+	assert.True(t, blocked)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrCloseFriendNotFriend = errors.New("close friends must be friends")
+	ErrNotCloseFriend       = errors.New("user is not a close friend")
+)
+
+// CloseFriendsService manages each user's close friends list, the audience
+// of close friends stories. Only friends can be on it, and people stop
+// counting as close friends as soon as the friendship ends.
+type CloseFriendsService struct {
+	graph    CloseFriendsGraph
+	userRepo UserRepository
+}
+
+func NewCloseFriendsService(graph CloseFriendsGraph, userRepo UserRepository) *CloseFriendsService {
+	return &CloseFriendsService{graph: graph, userRepo: userRepo}
+}
+
+// List returns userID's close friends, most recently added first
+func (s *CloseFriendsService) List(ctx context.Context, userID primitive.ObjectID) ([]models.UserShortResponse, error) {
+	hexIDs, err := s.graph.GetCloseFriendIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load close friends: %w", err)
+	}
+	return shortUsers(ctx, s.userRepo, objectIDs(hexIDs))
+}
+
+// Add puts friendID on userID's close friends list
+func (s *CloseFriendsService) Add(ctx context.Context, userID, friendID primitive.ObjectID) error {
+	if _, err := s.userRepo.FindUserByID(ctx, friendID); err != nil {
+		return ErrUserNotFound
+	}
+	added, err := s.graph.AddCloseFriend(ctx, userID, friendID)
+	if err != nil {
+		return fmt.Errorf("failed to add close friend: %w", err)
+	}
+	if !added {
+		return ErrCloseFriendNotFriend
+	}
+	return nil
+}
+
+// Remove takes friendID off userID's close friends list
+func (s *CloseFriendsService) Remove(ctx context.Context, userID, friendID primitive.ObjectID) error {
+	removed, err := s.graph.RemoveCloseFriend(ctx, userID, friendID)
+	if err != nil {
+		return fmt.Errorf("failed to remove close friend: %w", err)
+	}
+	if !removed {
+		return ErrNotCloseFriend
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCloseFriendsService(t *testing.T) {
+	graph := mocks.NewMockCloseFriendsGraph()
+	svc := NewCloseFriendsService(graph, &mocks.MockUserRepository{})
+	owner, friend, stranger := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	graph.Friends[owner] = []primitive.ObjectID{friend}
+
+	t.Run("only friends can be added", func(t *testing.T) {
+		assert.ErrorIs(t, svc.Add(context.Background(), owner, stranger), ErrCloseFriendNotFriend)
+		require.NoError(t, svc.Add(context.Background(), owner, friend))
+		require.NoError(t, svc.Add(context.Background(), owner, friend))
+
+		list, err := svc.List(context.Background(), owner)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, friend, list[0].ID)
+	})
+
+	t.Run("removes a close friend once", func(t *testing.T) {
+		require.NoError(t, svc.Remove(context.Background(), owner, friend))
+		assert.ErrorIs(t, svc.Remove(context.Background(), owner, friend), ErrNotCloseFriend)
+
+		list, err := svc.List(context.Background(), owner)
+		require.NoError(t, err)
+		assert.Empty(t, list)
+	})
+}
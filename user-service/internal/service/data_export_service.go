@@ -0,0 +1,567 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"path"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	exportpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/export/v1"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrDataExportNotFound = errors.New("data export not found")
+
+// DataExportConfig tunes the data export worker
+type DataExportConfig struct {
+	LinkTTL        time.Duration // Lifetime of each download link
+	Retention      time.Duration // How long a finished archive is kept
+	PollInterval   time.Duration // How often the worker looks for queued exports
+	ServiceTimeout time.Duration // Deadline for one service to return its data
+	MaxAttempts    int           // Tries per service before the export fails
+	MaxMediaBytes  int64         // Media past this total is listed in the manifest but not copied
+	StaleAfter     time.Duration // In-progress exports untouched this long are taken over
+}
+
+// DataExportService builds "Download your information" archives. Users queue
+// a request; a worker collects their data from user-service itself and from
+// every other service over the shared DataExportService RPC, zips it together
+// with the media it references, stores the archive and notifies the user with
+// a time-limited download link. Archives are deleted after the retention period.
+type DataExportService struct {
+	repo      DataExportRepository
+	userRepo  UserRepository
+	sessions  SessionRepository
+	blocks    BlockRepository
+	exporters map[string]exportpb.DataExportServiceClient
+	archives  ArchiveStore
+	notifier  EventProducer
+	cfg       DataExportConfig
+	logger    *slog.Logger
+}
+
+func NewDataExportService(
+	repo DataExportRepository,
+	userRepo UserRepository,
+	sessions SessionRepository,
+	blocks BlockRepository,
+	exporters map[string]exportpb.DataExportServiceClient,
+	archives ArchiveStore,
+	notifier EventProducer,
+	cfg DataExportConfig,
+	logger *slog.Logger,
+) *DataExportService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.LinkTTL <= 0 {
+		cfg.LinkTTL = 24 * time.Hour
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = 7 * 24 * time.Hour
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 30 * time.Second
+	}
+	if cfg.ServiceTimeout <= 0 {
+		cfg.ServiceTimeout = 2 * time.Minute
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 3
+	}
+	if cfg.MaxMediaBytes <= 0 {
+		cfg.MaxMediaBytes = 2 << 30
+	}
+	if cfg.StaleAfter <= 0 {
+		cfg.StaleAfter = 30 * time.Minute
+	}
+	return &DataExportService{
+		repo:      repo,
+		userRepo:  userRepo,
+		sessions:  sessions,
+		blocks:    blocks,
+		exporters: exporters,
+		archives:  archives,
+		notifier:  notifier,
+		cfg:       cfg,
+		logger:    logger,
+	}
+}
+
+// Request queues an export of the user's data. If one is already queued or
+// running it is returned instead of starting a second one.
+func (s *DataExportService) Request(ctx context.Context, userID primitive.ObjectID) (*models.DataExportRequest, error) {
+	existing, err := s.repo.FindOpenByUser(ctx, userID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("failed to check existing data exports: %w", err)
+	}
+
+	now := time.Now()
+	req := &models.DataExportRequest{
+		UserID:    userID,
+		Status:    models.DataExportStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for _, name := range models.DataExportServices {
+		if name == models.DataExportServiceUser || s.exporters[name] != nil {
+			req.Services = append(req.Services, models.DataExportServiceProgress{Service: name})
+		}
+	}
+
+	if err := s.repo.Create(ctx, req); err != nil {
+		return nil, fmt.Errorf("failed to create data export: %w", err)
+	}
+	s.logger.Info("Data export requested", "request_id", req.ID.Hex(), "user_id", userID.Hex())
+	return req, nil
+}
+
+// GetForUser returns one of the user's exports, with a fresh download link
+// when its archive is ready
+func (s *DataExportService) GetForUser(ctx context.Context, userID, requestID primitive.ObjectID) (*models.DataExportRequest, error) {
+	req, err := s.repo.FindByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrDataExportNotFound
+		}
+		return nil, err
+	}
+	if req.UserID != userID {
+		return nil, ErrDataExportNotFound
+	}
+	s.attachDownloadURL(ctx, req)
+	return req, nil
+}
+
+func (s *DataExportService) ListUserRequests(ctx context.Context, userID primitive.ObjectID) ([]models.DataExportRequest, error) {
+	requests, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range requests {
+		s.attachDownloadURL(ctx, &requests[i])
+	}
+	return requests, nil
+}
+
+// linkTTL keeps download links from outliving the archive
+func (s *DataExportService) linkTTL(req *models.DataExportRequest) time.Duration {
+	ttl := s.cfg.LinkTTL
+	if req.ExpiresAt != nil {
+		ttl = min(ttl, time.Until(*req.ExpiresAt))
+	}
+	return ttl
+}
+
+func (s *DataExportService) attachDownloadURL(ctx context.Context, req *models.DataExportRequest) {
+	if req.Status != models.DataExportStatusReady || req.ArchiveKey == "" {
+		return
+	}
+	ttl := s.linkTTL(req)
+	if ttl < time.Second {
+		return // Expired; the sweep deletes it shortly
+	}
+	link, err := s.archives.PresignArchive(ctx, req.ArchiveKey, ttl)
+	if err != nil {
+		s.logger.Error("Failed to sign data export download link", "request_id", req.ID.Hex(), "error", err)
+		return
+	}
+	req.DownloadURL = link
+}
+
+// RunWorker builds queued exports and deletes expired archives until ctx is
+// cancelled. Several instances may run it; each export is claimed by one.
+func (s *DataExportService) RunWorker(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.ProcessQueued(ctx); err != nil {
+				s.logger.Error("Data export sweep failed", "error", err)
+			} else if n > 0 {
+				s.logger.Info("Data export sweep built exports", "count", n)
+			}
+			if n, err := s.ExpireArchives(ctx); err != nil {
+				s.logger.Error("Data export expiry sweep failed", "error", err)
+			} else if n > 0 {
+				s.logger.Info("Data export expiry sweep deleted archives", "count", n)
+			}
+		}
+	}
+}
+
+// ProcessQueued builds queued exports one at a time until none are left.
+// Returns the number of exports it picked up.
+func (s *DataExportService) ProcessQueued(ctx context.Context) (int, error) {
+	processed := 0
+	for ctx.Err() == nil {
+		req, err := s.repo.ClaimNext(ctx, time.Now().Add(-s.cfg.StaleAfter))
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return processed, nil
+		}
+		if err != nil {
+			return processed, err
+		}
+		s.build(ctx, req)
+		processed++
+	}
+	return processed, ctx.Err()
+}
+
+// build collects and stores one export, then notifies the user. A service
+// that still fails after its retries fails the whole export, since a partial
+// archive would look complete to the user.
+func (s *DataExportService) build(ctx context.Context, req *models.DataExportRequest) {
+	key, size, err := s.buildArchive(ctx, req)
+	now := time.Now()
+	req.CompletedAt = &now
+	if err != nil {
+		req.Status = models.DataExportStatusFailed
+		req.Error = err.Error()
+		s.logger.Error("Data export failed", "request_id", req.ID.Hex(), "user_id", req.UserID.Hex(), "error", err)
+		if err := s.repo.Save(ctx, req); err != nil {
+			s.logger.Error("Failed to record data export failure", "request_id", req.ID.Hex(), "error", err)
+		}
+		return
+	}
+
+	expiresAt := now.Add(s.cfg.Retention)
+	req.Status = models.DataExportStatusReady
+	req.ArchiveKey = key
+	req.SizeBytes = size
+	req.Error = ""
+	req.ExpiresAt = &expiresAt
+	if err := s.repo.Save(ctx, req); err != nil {
+		s.logger.Error("Failed to record finished data export", "request_id", req.ID.Hex(), "error", err)
+		return
+	}
+	s.logger.Info("Data export ready", "request_id", req.ID.Hex(), "user_id", req.UserID.Hex(), "size_bytes", size)
+	s.notifyReady(ctx, req)
+}
+
+// buildArchive writes every service's sections and media into a zip on disk
+// and uploads it, returning the storage key and archive size
+func (s *DataExportService) buildArchive(ctx context.Context, req *models.DataExportRequest) (string, int64, error) {
+	f, err := os.CreateTemp("", "data-export-*.zip")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	archive := newExportArchive(f, s.archives, s.cfg.MaxMediaBytes)
+	for i := range req.Services {
+		p := &req.Services[i]
+		*p = models.DataExportServiceProgress{Service: p.Service} // A reclaimed export starts over
+
+		sections, err := s.collect(ctx, req.UserID, p)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to collect %s data: %w", p.Service, err)
+		}
+		for _, section := range sections {
+			copied, err := archive.addSection(ctx, p.Service, section)
+			if err != nil {
+				return "", 0, fmt.Errorf("failed to write %s/%s: %w", p.Service, section.Name, err)
+			}
+			p.Records += section.RecordCount
+			p.MediaFiles += copied
+		}
+		completedAt := time.Now()
+		p.CompletedAt = &completedAt
+
+		// Also keeps the claim fresh so no other worker takes it over
+		if err := s.repo.Save(ctx, req); err != nil {
+			s.logger.Warn("Failed to record data export progress", "request_id", req.ID.Hex(), "service", p.Service, "error", err)
+		}
+	}
+	if err := archive.close(req); err != nil {
+		return "", 0, fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	key, err := s.archives.PutArchive(ctx, fmt.Sprintf("data-export-%s.zip", req.ID.Hex()), f, info.Size())
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to store archive: %w", err)
+	}
+	return key, info.Size(), nil
+}
+
+// collect fetches one service's sections, retrying up to MaxAttempts times
+func (s *DataExportService) collect(ctx context.Context, userID primitive.ObjectID, p *models.DataExportServiceProgress) ([]*exportpb.ExportSection, error) {
+	var err error
+	for p.Attempts < s.cfg.MaxAttempts && ctx.Err() == nil {
+		p.Attempts++
+		callCtx, cancel := context.WithTimeout(ctx, s.cfg.ServiceTimeout)
+		var sections []*exportpb.ExportSection
+		sections, err = s.fetch(callCtx, userID, p.Service)
+		cancel()
+		if err == nil {
+			return sections, nil
+		}
+		p.LastError = err.Error()
+		s.logger.Warn("Data export collection failed", "service", p.Service, "user_id", userID.Hex(), "attempt", p.Attempts, "error", err)
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	return nil, err
+}
+
+func (s *DataExportService) fetch(ctx context.Context, userID primitive.ObjectID, service string) ([]*exportpb.ExportSection, error) {
+	if service == models.DataExportServiceUser {
+		return s.exportOwnData(ctx, userID)
+	}
+	client, ok := s.exporters[service]
+	if !ok {
+		return nil, errors.New("no exporter configured")
+	}
+	resp, err := client.ExportUserData(ctx, &exportpb.ExportUserDataRequest{UserId: userID.Hex()})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Sections, nil
+}
+
+// exportOwnData collects the profile, signed-in devices and blocks held by
+// user-service
+func (s *DataExportService) exportOwnData(ctx context.Context, userID primitive.ObjectID) ([]*exportpb.ExportSection, error) {
+	user, err := s.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load profile: %w", err)
+	}
+	user.Password = ""
+
+	sessions, err := s.sessions.ListActive(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sessions: %w", err)
+	}
+	blocks, err := s.blocks.ListBlocked(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load blocks: %w", err)
+	}
+
+	var media []string
+	for _, u := range []string{user.Avatar, user.CoverPicture} {
+		if u != "" {
+			media = append(media, u)
+		}
+	}
+
+	var b dataexport.Builder
+	b.Add("profile", []*models.User{user}, media...)
+	b.Add("sessions", sessions)
+	b.Add("blocks", blocks)
+	return b.Sections()
+}
+
+// notifyReady tells the user their archive can be downloaded. The link in
+// the notification expires like any other; reading the export issues a new one.
+func (s *DataExportService) notifyReady(ctx context.Context, req *models.DataExportRequest) {
+	ttl := s.linkTTL(req)
+	link, err := s.archives.PresignArchive(ctx, req.ArchiveKey, ttl)
+	if err != nil {
+		s.logger.Error("Failed to sign data export download link", "request_id", req.ID.Hex(), "error", err)
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(events.NotificationCreatedEvent{
+		ID:          primitive.NewObjectID(),
+		RecipientID: req.UserID,
+		SenderID:    req.UserID,
+		Type:        string(models.NotificationTypeDataExportReady),
+		TargetID:    req.ID,
+		TargetType:  "data_export",
+		Content:     "Your information is ready to download",
+		Data: map[string]interface{}{
+			"download_url":    link,
+			"link_expires_at": now.Add(ttl),
+			"expires_at":      req.ExpiresAt,
+			"size_bytes":      req.SizeBytes,
+		},
+		CreatedAt: now,
+	})
+	if err == nil {
+		err = s.notifier.Produce(ctx, []byte(req.UserID.Hex()), payload)
+	}
+	if err != nil {
+		s.logger.Error("Failed to notify user of data export", "request_id", req.ID.Hex(), "error", err)
+	}
+}
+
+// ExpireArchives deletes archives past their retention. Returns how many
+// exports were expired.
+func (s *DataExportService) ExpireArchives(ctx context.Context) (int, error) {
+	requests, err := s.repo.ListExpired(ctx, time.Now(), 100)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for i := range requests {
+		req := &requests[i]
+		if err := s.archives.DeleteArchive(ctx, req.ArchiveKey); err != nil {
+			s.logger.Error("Failed to delete data export archive", "request_id", req.ID.Hex(), "error", err)
+			continue
+		}
+		req.Status = models.DataExportStatusExpired
+		req.ArchiveKey = ""
+		if err := s.repo.Save(ctx, req); err != nil {
+			s.logger.Error("Failed to record expired data export", "request_id", req.ID.Hex(), "error", err)
+			continue
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// dataExportManifest is written to manifest.json at the root of each archive
+type dataExportManifest struct {
+	RequestID    string                             `json:"request_id"`
+	UserID       string                             `json:"user_id"`
+	GeneratedAt  time.Time                          `json:"generated_at"`
+	Services     []models.DataExportServiceProgress `json:"services"`
+	Media        map[string]string                  `json:"media"`                   // Original URL to path in the archive
+	SkippedMedia []string                           `json:"skipped_media,omitempty"` // Unreadable or past the size cap
+}
+
+// exportArchive writes sections and the media they reference into a zip
+type exportArchive struct {
+	zw        *zip.Writer
+	store     ArchiveStore
+	maxMedia  int64
+	mediaSize int64
+	manifest  dataExportManifest
+}
+
+func newExportArchive(w io.Writer, store ArchiveStore, maxMedia int64) *exportArchive {
+	return &exportArchive{
+		zw:       zip.NewWriter(w),
+		store:    store,
+		maxMedia: maxMedia,
+		manifest: dataExportManifest{Media: map[string]string{}},
+	}
+}
+
+// addSection writes a section as <service>/<name>.json and copies its media
+// under <service>/media. Returns the number of media files copied.
+func (a *exportArchive) addSection(ctx context.Context, service string, section *exportpb.ExportSection) (int, error) {
+	w, err := a.zw.Create(path.Join(service, section.Name+".json"))
+	if err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(section.RecordsJson); err != nil {
+		return 0, err
+	}
+
+	copied := 0
+	for _, mediaURL := range section.MediaUrls {
+		if _, done := a.manifest.Media[mediaURL]; done {
+			continue
+		}
+		ok, err := a.addMedia(ctx, service, mediaURL)
+		if err != nil {
+			return copied, err
+		}
+		if ok {
+			copied++
+		}
+	}
+	return copied, nil
+}
+
+// addMedia copies one media file into the archive. Files that cannot be read
+// or would exceed the size cap are skipped and listed in the manifest; only
+// failures writing the archive are returned.
+func (a *exportArchive) addMedia(ctx context.Context, service, mediaURL string) (bool, error) {
+	remaining := a.maxMedia - a.mediaSize
+	if remaining <= 0 {
+		a.manifest.SkippedMedia = append(a.manifest.SkippedMedia, mediaURL)
+		return false, nil
+	}
+
+	// Spool to disk first so a failed download never leaves a truncated entry
+	tmp, err := os.CreateTemp("", "data-export-media-*")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	body, err := a.store.OpenMedia(ctx, mediaURL)
+	if err != nil {
+		a.manifest.SkippedMedia = append(a.manifest.SkippedMedia, mediaURL)
+		return false, nil
+	}
+	n, err := io.Copy(tmp, io.LimitReader(body, remaining+1))
+	body.Close()
+	if err != nil || n > remaining {
+		a.manifest.SkippedMedia = append(a.manifest.SkippedMedia, mediaURL)
+		return false, nil
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	name := path.Join(service, "media", fmt.Sprintf("%04d-%s", len(a.manifest.Media)+1, mediaBaseName(mediaURL)))
+	w, err := a.zw.Create(name)
+	if err != nil {
+		return false, err
+	}
+	if _, err := io.Copy(w, tmp); err != nil {
+		return false, err
+	}
+	a.mediaSize += n
+	a.manifest.Media[mediaURL] = name
+	return true, nil
+}
+
+// close writes the manifest and finishes the zip
+func (a *exportArchive) close(req *models.DataExportRequest) error {
+	a.manifest.RequestID = req.ID.Hex()
+	a.manifest.UserID = req.UserID.Hex()
+	a.manifest.GeneratedAt = time.Now()
+	a.manifest.Services = req.Services
+
+	w, err := a.zw.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(a.manifest); err != nil {
+		return err
+	}
+	return a.zw.Close()
+}
+
+func mediaBaseName(mediaURL string) string {
+	p := mediaURL
+	if u, err := url.Parse(mediaURL); err == nil {
+		p = u.Path
+	}
+	if base := path.Base(p); base != "." && base != "/" {
+		return base
+	}
+	return "file"
+}
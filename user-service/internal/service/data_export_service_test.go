@@ -0,0 +1,211 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"user-service/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/dataexport"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	exportpb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/export/v1"
+)
+
+type fakeExporter struct {
+	sections []*exportpb.ExportSection
+	err      error
+	calls    int
+}
+
+func (f *fakeExporter) ExportUserData(ctx context.Context, in *exportpb.ExportUserDataRequest, opts ...grpc.CallOption) (*exportpb.ExportUserDataResponse, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &exportpb.ExportUserDataResponse{Sections: f.sections}, nil
+}
+
+type fakeArchiveStore struct {
+	archives map[string][]byte
+	media    map[string]string
+	deleted  []string
+}
+
+func newFakeArchiveStore() *fakeArchiveStore {
+	return &fakeArchiveStore{archives: map[string][]byte{}, media: map[string]string{}}
+}
+
+func (f *fakeArchiveStore) PutArchive(ctx context.Context, filename string, archive io.ReaderAt, size int64) (string, error) {
+	buf := make([]byte, size)
+	if _, err := archive.ReadAt(buf, 0); err != nil && err != io.EOF {
+		return "", err
+	}
+	key := "exports/" + filename
+	f.archives[key] = buf
+	return key, nil
+}
+
+func (f *fakeArchiveStore) PresignArchive(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return "https://storage.example/" + key, nil
+}
+
+func (f *fakeArchiveStore) DeleteArchive(ctx context.Context, key string) error {
+	f.deleted = append(f.deleted, key)
+	delete(f.archives, key)
+	return nil
+}
+
+func (f *fakeArchiveStore) OpenMedia(ctx context.Context, url string) (io.ReadCloser, error) {
+	body, ok := f.media[url]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return io.NopCloser(strings.NewReader(body)), nil
+}
+
+func newTestDataExportService(repo *mocks.MockDataExportRepository, exporters map[string]exportpb.DataExportServiceClient, store *fakeArchiveStore, producer *mocks.MockEventProducer) *DataExportService {
+	return NewDataExportService(
+		repo, &mocks.MockUserRepository{}, mocks.NewMockSessionRepository(), &mocks.MockBlockRepository{},
+		exporters, store, producer,
+		DataExportConfig{MaxAttempts: 2, MaxMediaBytes: 1 << 20},
+		slog.Default(),
+	)
+}
+
+func feedSections(t *testing.T) []*exportpb.ExportSection {
+	var b dataexport.Builder
+	b.Add("posts", []map[string]string{{"content": "hello"}}, "https://cdn.example/photo.jpg", "https://cdn.example/missing.jpg")
+	sections, err := b.Sections()
+	require.NoError(t, err)
+	return sections
+}
+
+func TestDataExportService_Request(t *testing.T) {
+	repo := mocks.NewMockDataExportRepository()
+	exporters := map[string]exportpb.DataExportServiceClient{models.ErasureServiceFeed: &fakeExporter{}}
+	svc := newTestDataExportService(repo, exporters, newFakeArchiveStore(), &mocks.MockEventProducer{})
+	userID := primitive.NewObjectID()
+
+	req, err := svc.Request(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, models.DataExportStatusPending, req.Status)
+	require.Len(t, req.Services, 2)
+	assert.Equal(t, models.DataExportServiceUser, req.Services[0].Service)
+	assert.Equal(t, models.ErasureServiceFeed, req.Services[1].Service)
+
+	// A second request while one is open returns the existing one
+	again, err := svc.Request(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, req.ID, again.ID)
+	assert.Len(t, repo.Requests, 1)
+}
+
+func TestDataExportService_ProcessQueued(t *testing.T) {
+	t.Run("builds the archive and notifies the user", func(t *testing.T) {
+		repo := mocks.NewMockDataExportRepository()
+		store := newFakeArchiveStore()
+		store.media["https://cdn.example/photo.jpg"] = "jpeg-bytes"
+		producer := &mocks.MockEventProducer{}
+		exporters := map[string]exportpb.DataExportServiceClient{models.ErasureServiceFeed: &fakeExporter{sections: feedSections(t)}}
+		svc := newTestDataExportService(repo, exporters, store, producer)
+		userID := primitive.NewObjectID()
+
+		req, err := svc.Request(context.Background(), userID)
+		require.NoError(t, err)
+		n, err := svc.ProcessQueued(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		got, err := svc.GetForUser(context.Background(), userID, req.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.DataExportStatusReady, got.Status)
+		assert.NotNil(t, got.ExpiresAt)
+		assert.Equal(t, "https://storage.example/"+got.ArchiveKey, got.DownloadURL)
+		assert.Equal(t, 1, got.Service(models.ErasureServiceFeed).MediaFiles)
+
+		zr, err := zip.NewReader(bytes.NewReader(store.archives[got.ArchiveKey]), got.SizeBytes)
+		require.NoError(t, err)
+		files := map[string]bool{}
+		for _, f := range zr.File {
+			files[f.Name] = true
+		}
+		assert.True(t, files["manifest.json"])
+		assert.True(t, files["user/profile.json"])
+		assert.True(t, files["feed/posts.json"])
+		assert.True(t, files["feed/media/0001-photo.jpg"])
+
+		require.Len(t, producer.ProduceCalls, 1)
+		var evt events.NotificationCreatedEvent
+		require.NoError(t, json.Unmarshal(producer.ProduceCalls[0].Value, &evt))
+		assert.Equal(t, string(models.NotificationTypeDataExportReady), evt.Type)
+		assert.Equal(t, userID, evt.RecipientID)
+		assert.NotEmpty(t, evt.Data["download_url"])
+	})
+
+	t.Run("fails when a service keeps failing", func(t *testing.T) {
+		repo := mocks.NewMockDataExportRepository()
+		store := newFakeArchiveStore()
+		producer := &mocks.MockEventProducer{}
+		failing := &fakeExporter{err: errors.New("unavailable")}
+		exporters := map[string]exportpb.DataExportServiceClient{models.ErasureServiceFeed: failing}
+		svc := newTestDataExportService(repo, exporters, store, producer)
+		userID := primitive.NewObjectID()
+
+		req, err := svc.Request(context.Background(), userID)
+		require.NoError(t, err)
+		_, err = svc.ProcessQueued(context.Background())
+		require.NoError(t, err)
+
+		got, err := svc.GetForUser(context.Background(), userID, req.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.DataExportStatusFailed, got.Status)
+		assert.Contains(t, got.Error, "feed")
+		assert.Equal(t, 2, failing.calls)
+		assert.Empty(t, store.archives)
+		assert.Empty(t, producer.ProduceCalls)
+	})
+}
+
+func TestDataExportService_GetForUser_OtherUser(t *testing.T) {
+	repo := mocks.NewMockDataExportRepository()
+	svc := newTestDataExportService(repo, nil, newFakeArchiveStore(), &mocks.MockEventProducer{})
+	req, err := svc.Request(context.Background(), primitive.NewObjectID())
+	require.NoError(t, err)
+
+	_, err = svc.GetForUser(context.Background(), primitive.NewObjectID(), req.ID)
+	assert.ErrorIs(t, err, ErrDataExportNotFound)
+}
+
+func TestDataExportService_ExpireArchives(t *testing.T) {
+	repo := mocks.NewMockDataExportRepository()
+	store := newFakeArchiveStore()
+	svc := newTestDataExportService(repo, nil, store, &mocks.MockEventProducer{})
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	expired := &models.DataExportRequest{Status: models.DataExportStatusReady, ArchiveKey: "exports/old.zip", ExpiresAt: &past}
+	fresh := &models.DataExportRequest{Status: models.DataExportStatusReady, ArchiveKey: "exports/new.zip", ExpiresAt: &future}
+	require.NoError(t, repo.Create(context.Background(), expired))
+	require.NoError(t, repo.Create(context.Background(), fresh))
+
+	n, err := svc.ExpireArchives(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"exports/old.zip"}, store.deleted)
+	assert.Equal(t, models.DataExportStatusExpired, repo.Requests[expired.ID].Status)
+	assert.Equal(t, models.DataExportStatusReady, repo.Requests[fresh.ID].Status)
+}
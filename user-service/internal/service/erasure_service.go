@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrErasureRequestNotFound = errors.New("erasure request not found")
+
+// ErasureConfig tunes dispatch retries for the erasure orchestrator
+type ErasureConfig struct {
+	MaxAttempts   int           // Dispatches per service before it is marked failed
+	AckTimeout    time.Duration // How long to wait for an acknowledgement before redispatching
+	RetryInterval time.Duration // How often the retry worker scans open requests
+}
+
+// ErasureService orchestrates GDPR erasure across every service holding user
+// data. It fans a request out over Kafka, tracks each participant's
+// acknowledgement, redispatches stalled or failed participants and produces
+// an auditable report once the request settles.
+type ErasureService struct {
+	repo     ErasureRepository
+	producer EventProducer
+	cfg      ErasureConfig
+	logger   *slog.Logger
+	audit    *audit.Logger
+}
+
+func NewErasureService(repo ErasureRepository, producer EventProducer, cfg ErasureConfig, logger *slog.Logger) *ErasureService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.AckTimeout <= 0 {
+		cfg.AckTimeout = 15 * time.Minute
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = time.Minute
+	}
+	return &ErasureService{repo: repo, producer: producer, cfg: cfg, logger: logger}
+}
+
+// SetAuditLogger enables recording admin-initiated erasures in the audit log
+func (s *ErasureService) SetAuditLogger(logger *audit.Logger) {
+	s.audit = logger
+}
+
+// RequestErasure opens an erasure request for a user and dispatches it to all
+// participating services. If the user already has an open request it is
+// returned instead of starting a second one.
+func (s *ErasureService) RequestErasure(ctx context.Context, userID, requestedBy primitive.ObjectID, reason string) (*models.ErasureRequest, error) {
+	existing, err := s.repo.FindOpenByUser(ctx, userID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("failed to check existing erasure requests: %w", err)
+	}
+
+	now := time.Now()
+	req := &models.ErasureRequest{
+		UserID:      userID,
+		RequestedBy: requestedBy,
+		Reason:      reason,
+		Status:      models.ErasureStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	for _, svc := range models.ErasureServices {
+		req.Services = append(req.Services, models.ErasureServiceProgress{
+			Service: svc,
+			Status:  models.ErasureStatusPending,
+		})
+	}
+
+	if err := s.repo.Create(ctx, req); err != nil {
+		return nil, fmt.Errorf("failed to create erasure request: %w", err)
+	}
+	s.logger.Info("Erasure request created", "request_id", req.ID.Hex(), "user_id", userID.Hex(), "requested_by", requestedBy.Hex())
+	if requestedBy != userID {
+		s.audit.Record(ctx, models.AuditEntry{
+			Action:     models.AuditActionErasureRequested,
+			ActorID:    &requestedBy,
+			TargetType: "user",
+			TargetID:   userID.Hex(),
+			Metadata:   map[string]string{"request_id": req.ID.Hex(), "reason": reason},
+		})
+	}
+
+	for i := range req.Services {
+		s.dispatch(ctx, req, &req.Services[i])
+	}
+	s.settle(ctx, req)
+	return req, nil
+}
+
+// dispatch publishes the request to one participant and records the attempt.
+// A failed publish leaves the service pending for the retry worker.
+func (s *ErasureService) dispatch(ctx context.Context, req *models.ErasureRequest, progress *models.ErasureServiceProgress) {
+	now := time.Now()
+	progress.Attempts++
+	progress.DispatchedAt = &now
+
+	payload, err := json.Marshal(events.UserErasureRequestedEvent{
+		RequestID:   req.ID.Hex(),
+		UserID:      req.UserID.Hex(),
+		Service:     progress.Service,
+		Attempt:     progress.Attempts,
+		RequestedAt: now,
+	})
+	if err == nil {
+		err = s.producer.Produce(ctx, []byte(req.UserID.Hex()), payload)
+	}
+	if err != nil {
+		progress.Status = models.ErasureStatusPending
+		progress.LastError = "dispatch failed: " + err.Error()
+		s.logger.Error("Failed to dispatch erasure request", "request_id", req.ID.Hex(), "service", progress.Service, "error", err)
+	} else {
+		progress.Status = models.ErasureStatusInProgress
+	}
+
+	if err := s.repo.UpdateService(ctx, req.ID, *progress); err != nil {
+		s.logger.Error("Failed to record erasure dispatch", "request_id", req.ID.Hex(), "service", progress.Service, "error", err)
+	}
+}
+
+// RecordProgress applies a participant's acknowledgement
+func (s *ErasureService) RecordProgress(ctx context.Context, evt events.UserErasureProgressEvent) error {
+	requestID, err := primitive.ObjectIDFromHex(evt.RequestID)
+	if err != nil {
+		return fmt.Errorf("invalid request id %q: %w", evt.RequestID, err)
+	}
+
+	req, err := s.repo.FindByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrErasureRequestNotFound
+		}
+		return err
+	}
+
+	progress := req.Service(evt.Service)
+	if progress == nil {
+		return fmt.Errorf("service %q is not part of erasure request %s", evt.Service, evt.RequestID)
+	}
+	if progress.Status == models.ErasureStatusCompleted {
+		return nil // Duplicate acknowledgement
+	}
+
+	if evt.Success {
+		completedAt := evt.Timestamp
+		if completedAt.IsZero() {
+			completedAt = time.Now()
+		}
+		progress.Status = models.ErasureStatusCompleted
+		progress.ItemsErased = evt.ItemsErased
+		progress.LastError = ""
+		progress.CompletedAt = &completedAt
+	} else {
+		progress.LastError = evt.Error
+		if progress.Attempts >= s.cfg.MaxAttempts {
+			progress.Status = models.ErasureStatusFailed
+		} else {
+			progress.Status = models.ErasureStatusPending // Picked up by the retry worker
+		}
+	}
+
+	if err := s.repo.UpdateService(ctx, req.ID, *progress); err != nil {
+		return err
+	}
+	s.settle(ctx, req)
+	return nil
+}
+
+// settle derives the overall request status from its services
+func (s *ErasureService) settle(ctx context.Context, req *models.ErasureRequest) {
+	completed, failed := 0, 0
+	for _, p := range req.Services {
+		switch p.Status {
+		case models.ErasureStatusCompleted:
+			completed++
+		case models.ErasureStatusFailed:
+			failed++
+		}
+	}
+
+	status := models.ErasureStatusInProgress
+	var completedAt *time.Time
+	switch {
+	case completed == len(req.Services):
+		status = models.ErasureStatusCompleted
+	case completed+failed == len(req.Services):
+		status = models.ErasureStatusFailed
+	}
+	if status != models.ErasureStatusInProgress {
+		now := time.Now()
+		completedAt = &now
+	}
+
+	if status == req.Status {
+		return
+	}
+	req.Status = status
+	req.CompletedAt = completedAt
+	if err := s.repo.SetStatus(ctx, req.ID, status, completedAt); err != nil {
+		s.logger.Error("Failed to update erasure request status", "request_id", req.ID.Hex(), "error", err)
+		return
+	}
+	if status != models.ErasureStatusInProgress {
+		s.logger.Info("Erasure request settled", "request_id", req.ID.Hex(), "user_id", req.UserID.Hex(), "status", status)
+	}
+}
+
+// RetryStalled redispatches services that failed or never acknowledged, and
+// marks services that exhausted their attempts as failed. Returns the number
+// of dispatches made.
+func (s *ErasureService) RetryStalled(ctx context.Context) (int, error) {
+	requests, err := s.repo.ListOpen(ctx, 100)
+	if err != nil {
+		return 0, err
+	}
+
+	dispatched := 0
+	now := time.Now()
+	for i := range requests {
+		req := &requests[i]
+		for j := range req.Services {
+			p := &req.Services[j]
+
+			stalled := p.Status == models.ErasureStatusInProgress &&
+				p.DispatchedAt != nil && now.Sub(*p.DispatchedAt) > s.cfg.AckTimeout
+			if p.Status != models.ErasureStatusPending && !stalled {
+				continue
+			}
+
+			if p.Attempts >= s.cfg.MaxAttempts {
+				p.Status = models.ErasureStatusFailed
+				if p.LastError == "" {
+					p.LastError = fmt.Sprintf("no acknowledgement after %d attempts", p.Attempts)
+				}
+				if err := s.repo.UpdateService(ctx, req.ID, *p); err != nil {
+					s.logger.Error("Failed to mark erasure service failed", "request_id", req.ID.Hex(), "service", p.Service, "error", err)
+				}
+				continue
+			}
+
+			s.dispatch(ctx, req, p)
+			dispatched++
+		}
+		s.settle(ctx, req)
+	}
+	return dispatched, nil
+}
+
+// RunRetryWorker periodically retries stalled requests until ctx is cancelled
+func (s *ErasureService) RunRetryWorker(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.RetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.RetryStalled(ctx); err != nil {
+				s.logger.Error("Erasure retry sweep failed", "error", err)
+			} else if n > 0 {
+				s.logger.Info("Erasure retry sweep redispatched services", "count", n)
+			}
+		}
+	}
+}
+
+// RetryFailed resets failed services on a request so they are dispatched again.
+// Used by admins once the underlying fault has been fixed.
+func (s *ErasureService) RetryFailed(ctx context.Context, adminID, requestID primitive.ObjectID) (*models.ErasureRequest, error) {
+	req, err := s.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var retried []string
+	for i := range req.Services {
+		p := &req.Services[i]
+		if p.Status != models.ErasureStatusFailed {
+			continue
+		}
+		p.Attempts = 0
+		s.dispatch(ctx, req, p)
+		retried = append(retried, p.Service)
+	}
+	s.settle(ctx, req)
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditActionErasureRetried,
+		ActorID:    &adminID,
+		TargetType: "user",
+		TargetID:   req.UserID.Hex(),
+		Metadata:   map[string]string{"request_id": req.ID.Hex(), "services": strings.Join(retried, ",")},
+	})
+	return req, nil
+}
+
+func (s *ErasureService) GetRequest(ctx context.Context, requestID primitive.ObjectID) (*models.ErasureRequest, error) {
+	req, err := s.repo.FindByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrErasureRequestNotFound
+		}
+		return nil, err
+	}
+	return req, nil
+}
+
+// GetRequestForUser returns a request only if it belongs to the user
+func (s *ErasureService) GetRequestForUser(ctx context.Context, userID, requestID primitive.ObjectID) (*models.ErasureRequest, error) {
+	req, err := s.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserID != userID {
+		return nil, ErrErasureRequestNotFound
+	}
+	return req, nil
+}
+
+func (s *ErasureService) ListUserRequests(ctx context.Context, userID primitive.ObjectID) ([]models.ErasureRequest, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+func (s *ErasureService) ListRequests(ctx context.Context, status models.ErasureStatus, limit, page int64) (*models.ErasureRequestListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if page <= 0 {
+		page = 1
+	}
+	requests, total, err := s.repo.List(ctx, status, limit, page)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ErasureRequestListResponse{Requests: requests, Total: total, Page: page, Limit: limit}, nil
+}
+
+// Report builds the auditable completion report for a request
+func (s *ErasureService) Report(ctx context.Context, requestID primitive.ObjectID) (*models.ErasureReport, error) {
+	req, err := s.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return BuildErasureReport(req), nil
+}
+
+// BuildErasureReport summarises a request's per-service outcome
+func BuildErasureReport(req *models.ErasureRequest) *models.ErasureReport {
+	report := &models.ErasureReport{
+		RequestID:   req.ID,
+		UserID:      req.UserID,
+		RequestedBy: req.RequestedBy,
+		Status:      req.Status,
+		RequestedAt: req.CreatedAt,
+		CompletedAt: req.CompletedAt,
+		Services:    req.Services,
+		GeneratedAt: time.Now(),
+	}
+	for _, p := range req.Services {
+		report.TotalItemsErased += p.ItemsErased
+		report.TotalAttempts += p.Attempts
+	}
+	if req.CompletedAt != nil {
+		report.Duration = req.CompletedAt.Sub(req.CreatedAt).Round(time.Second).String()
+	}
+	return report
+}
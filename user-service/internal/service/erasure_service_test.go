@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"user-service/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+func newTestErasureService(repo *mocks.MockErasureRepository, producer *mocks.MockEventProducer) *ErasureService {
+	return NewErasureService(repo, producer, ErasureConfig{MaxAttempts: 2}, slog.Default())
+}
+
+func ack(req *models.ErasureRequest, service string, success bool) events.UserErasureProgressEvent {
+	evt := events.UserErasureProgressEvent{RequestID: req.ID.Hex(), UserID: req.UserID.Hex(), Service: service, Success: success}
+	if success {
+		evt.ItemsErased = 3
+	} else {
+		evt.Error = "boom"
+	}
+	return evt
+}
+
+func TestErasureService_RequestErasure(t *testing.T) {
+	repo := mocks.NewMockErasureRepository()
+	producer := &mocks.MockEventProducer{}
+	svc := newTestErasureService(repo, producer)
+	userID := primitive.NewObjectID()
+
+	req, err := svc.RequestErasure(context.Background(), userID, userID, "")
+	require.NoError(t, err)
+	assert.Len(t, producer.ProduceCalls, len(models.ErasureServices))
+	assert.Equal(t, models.ErasureStatusInProgress, req.Status)
+
+	// A second request while one is open returns the existing one
+	again, err := svc.RequestErasure(context.Background(), userID, userID, "")
+	require.NoError(t, err)
+	assert.Equal(t, req.ID, again.ID)
+	assert.Len(t, producer.ProduceCalls, len(models.ErasureServices))
+}
+
+func TestErasureService_RecordProgress(t *testing.T) {
+	t.Run("completes when every service acknowledges", func(t *testing.T) {
+		repo := mocks.NewMockErasureRepository()
+		svc := newTestErasureService(repo, &mocks.MockEventProducer{})
+		userID := primitive.NewObjectID()
+		req, err := svc.RequestErasure(context.Background(), userID, userID, "")
+		require.NoError(t, err)
+
+		for _, name := range models.ErasureServices {
+			require.NoError(t, svc.RecordProgress(context.Background(), ack(req, name, true)))
+		}
+
+		report, err := svc.Report(context.Background(), req.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ErasureStatusCompleted, report.Status)
+		assert.Equal(t, int64(3*len(models.ErasureServices)), report.TotalItemsErased)
+		assert.NotNil(t, report.CompletedAt)
+	})
+
+	t.Run("fails a service after max attempts", func(t *testing.T) {
+		repo := mocks.NewMockErasureRepository()
+		producer := &mocks.MockEventProducer{}
+		svc := newTestErasureService(repo, producer)
+		userID := primitive.NewObjectID()
+		req, err := svc.RequestErasure(context.Background(), userID, userID, "")
+		require.NoError(t, err)
+
+		failing := models.ErasureServices[0]
+		require.NoError(t, svc.RecordProgress(context.Background(), ack(req, failing, false)))
+
+		dispatched, err := svc.RetryStalled(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+
+		require.NoError(t, svc.RecordProgress(context.Background(), ack(req, failing, false)))
+		for _, name := range models.ErasureServices[1:] {
+			require.NoError(t, svc.RecordProgress(context.Background(), ack(req, name, true)))
+		}
+
+		got, err := svc.GetRequest(context.Background(), req.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ErasureStatusFailed, got.Status)
+		assert.Equal(t, models.ErasureStatusFailed, got.Service(failing).Status)
+		assert.Equal(t, 2, got.Service(failing).Attempts)
+	})
+
+	t.Run("unknown request", func(t *testing.T) {
+		svc := newTestErasureService(mocks.NewMockErasureRepository(), &mocks.MockEventProducer{})
+		err := svc.RecordProgress(context.Background(), events.UserErasureProgressEvent{RequestID: primitive.NewObjectID().Hex()})
+		assert.True(t, errors.Is(err, ErrErasureRequestNotFound))
+	})
+}
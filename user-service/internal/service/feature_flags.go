@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"strconv"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/featureflags"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidFeatureFlagKey = errors.New("feature flag keys are 1-64 lowercase letters, digits, dots, dashes or underscores")
+	ErrFeatureFlagNotFound   = featureflags.ErrNotFound
+)
+
+var featureFlagKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// FeatureFlagStore persists feature flags and publishes them to every service
+type FeatureFlagStore interface {
+	List(ctx context.Context) ([]models.FeatureFlag, error)
+	Get(ctx context.Context, key string) (*models.FeatureFlag, error)
+	Put(ctx context.Context, flag *models.FeatureFlag) (*models.FeatureFlag, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// FeatureFlagService lets admins manage the feature flags all services
+// evaluate
+type FeatureFlagService struct {
+	store FeatureFlagStore
+	audit *audit.Logger
+}
+
+func NewFeatureFlagService(store FeatureFlagStore) *FeatureFlagService {
+	return &FeatureFlagService{store: store}
+}
+
+// SetAuditLogger enables recording flag changes in the audit log
+func (s *FeatureFlagService) SetAuditLogger(logger *audit.Logger) {
+	s.audit = logger
+}
+
+func (s *FeatureFlagService) List(ctx context.Context) ([]models.FeatureFlag, error) {
+	return s.store.List(ctx)
+}
+
+func (s *FeatureFlagService) Get(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	return s.store.Get(ctx, key)
+}
+
+// Put creates or replaces a flag
+func (s *FeatureFlagService) Put(ctx context.Context, adminID primitive.ObjectID, key string, req models.FeatureFlagRequest) (*models.FeatureFlag, error) {
+	if !featureFlagKeyPattern.MatchString(key) {
+		return nil, ErrInvalidFeatureFlagKey
+	}
+	flag, err := s.store.Put(ctx, &models.FeatureFlag{
+		Key:            key,
+		Description:    req.Description,
+		Enabled:        req.Enabled,
+		RolloutPercent: req.RolloutPercent,
+		AllowUsers:     req.AllowUsers,
+		DenyUsers:      req.DenyUsers,
+		UpdatedBy:      adminID.Hex(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Admin %s set feature flag %s: enabled=%t rollout=%d%%", adminID.Hex(), key, flag.Enabled, flag.RolloutPercent)
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditActionFeatureFlagChanged,
+		ActorID:    &adminID,
+		TargetType: "feature_flag",
+		TargetID:   key,
+		Metadata: map[string]string{
+			"enabled":         strconv.FormatBool(flag.Enabled),
+			"rollout_percent": strconv.Itoa(flag.RolloutPercent),
+		},
+	})
+	return flag, nil
+}
+
+func (s *FeatureFlagService) Delete(ctx context.Context, adminID primitive.ObjectID, key string) error {
+	if err := s.store.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	log.Printf("Admin %s deleted feature flag %s", adminID.Hex(), key)
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditActionFeatureFlagDeleted,
+		ActorID:    &adminID,
+		TargetType: "feature_flag",
+		TargetID:   key,
+	})
+	return nil
+}
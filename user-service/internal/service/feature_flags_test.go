@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeFeatureFlagStore struct {
+	flags map[string]models.FeatureFlag
+}
+
+func (f *fakeFeatureFlagStore) List(ctx context.Context) ([]models.FeatureFlag, error) {
+	flags := []models.FeatureFlag{}
+	for _, flag := range f.flags {
+		flags = append(flags, flag)
+	}
+	return flags, nil
+}
+
+func (f *fakeFeatureFlagStore) Get(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	flag, ok := f.flags[key]
+	if !ok {
+		return nil, ErrFeatureFlagNotFound
+	}
+	return &flag, nil
+}
+
+func (f *fakeFeatureFlagStore) Put(ctx context.Context, flag *models.FeatureFlag) (*models.FeatureFlag, error) {
+	f.flags[flag.Key] = *flag
+	return flag, nil
+}
+
+func (f *fakeFeatureFlagStore) Delete(ctx context.Context, key string) error {
+	if _, ok := f.flags[key]; !ok {
+		return ErrFeatureFlagNotFound
+	}
+	delete(f.flags, key)
+	return nil
+}
+
+func TestFeatureFlagService_Put(t *testing.T) {
+	store := &fakeFeatureFlagStore{flags: map[string]models.FeatureFlag{}}
+	svc := NewFeatureFlagService(store)
+	adminID := primitive.NewObjectID()
+
+	flag, err := svc.Put(context.Background(), adminID, "feed.ranked", models.FeatureFlagRequest{Enabled: true, RolloutPercent: 10})
+	require.NoError(t, err)
+	assert.Equal(t, "feed.ranked", flag.Key)
+	assert.Equal(t, adminID.Hex(), flag.UpdatedBy)
+	assert.Equal(t, 10, store.flags["feed.ranked"].RolloutPercent)
+
+	for _, key := range []string{"", "Feed", "feed ranked", "-feed", "feed/ranked"} {
+		_, err := svc.Put(context.Background(), adminID, key, models.FeatureFlagRequest{})
+		assert.ErrorIs(t, err, ErrInvalidFeatureFlagKey, key)
+	}
+}
+
+func TestFeatureFlagService_Delete(t *testing.T) {
+	store := &fakeFeatureFlagStore{flags: map[string]models.FeatureFlag{"feed.ranked": {Key: "feed.ranked"}}}
+	svc := NewFeatureFlagService(store)
+
+	require.NoError(t, svc.Delete(context.Background(), primitive.NewObjectID(), "feed.ranked"))
+	assert.ErrorIs(t, svc.Delete(context.Background(), primitive.NewObjectID(), "feed.ranked"), ErrFeatureFlagNotFound)
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrCannotFollowSelf = errors.New("cannot follow yourself")
+	ErrFollowBlocked    = errors.New("cannot follow this user")
+	ErrNotFollowing     = errors.New("user is not followed")
+)
+
+const (
+	defaultFollowPageSize = 20
+	maxFollowPageSize     = 100
+)
+
+// FollowService manages one-way follows, kept apart from friendships. A
+// follow is a FOLLOWS edge in Neo4j; feed-service reads them to put the
+// public posts of followed users in timelines.
+type FollowService struct {
+	graph        FollowGraph
+	userRepo     UserRepository
+	blockRepo    BlockRepository
+	notifier     EventProducer
+	followEvents EventProducer
+	logger       *slog.Logger
+}
+
+func NewFollowService(graph FollowGraph, userRepo UserRepository, blockRepo BlockRepository, notifier EventProducer, logger *slog.Logger) *FollowService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &FollowService{graph: graph, userRepo: userRepo, blockRepo: blockRepo, notifier: notifier, logger: logger}
+}
+
+// SetFollowEvents announces follows and unfollows, which feed-service uses to
+// drop the timeline state it caches per follower
+func (s *FollowService) SetFollowEvents(producer EventProducer) {
+	s.followEvents = producer
+}
+
+// Follow makes followerID follow targetID and lets targetID know. Following
+// someone already followed succeeds without notifying them again.
+func (s *FollowService) Follow(ctx context.Context, followerID, targetID primitive.ObjectID) error {
+	if followerID == targetID {
+		return ErrCannotFollowSelf
+	}
+	if _, err := s.userRepo.FindUserByID(ctx, targetID); err != nil {
+		return ErrUserNotFound
+	}
+	blocked, err := s.blockRepo.IsBlocked(ctx, followerID, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to check block: %w", err)
+	}
+	if blocked {
+		return ErrFollowBlocked
+	}
+
+	created, err := s.graph.Follow(ctx, followerID, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to follow user: %w", err)
+	}
+	if created {
+		s.notifyFollowed(ctx, followerID, targetID)
+		s.publishFollowEvent(ctx, followerID, targetID, "follow")
+	}
+	return nil
+}
+
+// Unfollow stops followerID from following targetID
+func (s *FollowService) Unfollow(ctx context.Context, followerID, targetID primitive.ObjectID) error {
+	deleted, err := s.graph.Unfollow(ctx, followerID, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to unfollow user: %w", err)
+	}
+	if !deleted {
+		return ErrNotFollowing
+	}
+	s.publishFollowEvent(ctx, followerID, targetID, "unfollow")
+	return nil
+}
+
+// Counts returns how many users follow userID and how many they follow
+func (s *FollowService) Counts(ctx context.Context, userID primitive.ObjectID) (*models.FollowCounts, error) {
+	followers, following, err := s.graph.GetFollowCounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count follows: %w", err)
+	}
+	return &models.FollowCounts{Followers: followers, Following: following}, nil
+}
+
+// Followers returns a page of the users following userID, newest first
+func (s *FollowService) Followers(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]models.UserShortResponse, error) {
+	skip, limit := followPage(page, limit)
+	hexIDs, err := s.graph.GetFollowerIDs(ctx, userID, skip, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load followers: %w", err)
+	}
+	return shortUsers(ctx, s.userRepo, objectIDs(hexIDs))
+}
+
+// Following returns a page of the users userID follows, newest first
+func (s *FollowService) Following(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]models.UserShortResponse, error) {
+	skip, limit := followPage(page, limit)
+	hexIDs, err := s.graph.GetFollowingIDs(ctx, userID, skip, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load followed users: %w", err)
+	}
+	return shortUsers(ctx, s.userRepo, objectIDs(hexIDs))
+}
+
+// Suggest returns "Who to follow": users followed by the people userID
+// follows, those followed by more of them first
+func (s *FollowService) Suggest(ctx context.Context, userID primitive.ObjectID, limit int) ([]models.FollowSuggestion, error) {
+	if limit <= 0 {
+		limit = defaultSuggestionLimit
+	}
+	limit = min(limit, maxSuggestionLimit)
+
+	candidates, err := s.graph.FollowCandidates(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load follow suggestions: %w", err)
+	}
+	ids := make([]primitive.ObjectID, 0, len(candidates))
+	byID := make(map[primitive.ObjectID]int, len(candidates))
+	for i, c := range candidates {
+		if id, err := primitive.ObjectIDFromHex(c.UserID); err == nil {
+			ids = append(ids, id)
+			byID[id] = i
+		}
+	}
+	users, err := shortUsers(ctx, s.userRepo, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]models.FollowSuggestion, 0, len(users))
+	for _, u := range users {
+		c := candidates[byID[u.ID]]
+		suggestions = append(suggestions, models.FollowSuggestion{
+			User:             u,
+			FollowedByMutual: c.FollowedByMutual,
+			Followers:        c.Followers,
+		})
+	}
+	return suggestions, nil
+}
+
+func (s *FollowService) notifyFollowed(ctx context.Context, followerID, targetID primitive.ObjectID) {
+	content := "You have a new follower"
+	if follower, err := s.userRepo.FindUserByID(ctx, followerID); err == nil {
+		content = fmt.Sprintf("%s started following you.", follower.Username)
+	}
+
+	payload, err := json.Marshal(events.NotificationCreatedEvent{
+		ID:          primitive.NewObjectID(),
+		RecipientID: targetID,
+		SenderID:    followerID,
+		Type:        string(models.NotificationTypeNewFollower),
+		TargetID:    followerID,
+		TargetType:  "user",
+		Content:     content,
+		CreatedAt:   time.Now(),
+	})
+	if err == nil {
+		err = s.notifier.Produce(ctx, []byte(targetID.Hex()), payload)
+	}
+	if err != nil {
+		s.logger.Error("Failed to notify user of new follower", "follower_id", followerID.Hex(), "user_id", targetID.Hex(), "error", err)
+	}
+}
+
+func (s *FollowService) publishFollowEvent(ctx context.Context, followerID, targetID primitive.ObjectID, action string) {
+	if s.followEvents == nil {
+		return
+	}
+	payload, err := json.Marshal(events.FollowEvent{
+		FollowerID: followerID.Hex(),
+		FolloweeID: targetID.Hex(),
+		Action:     action,
+		Timestamp:  time.Now(),
+	})
+	if err == nil {
+		err = s.followEvents.Produce(ctx, []byte(followerID.Hex()), payload)
+	}
+	if err != nil {
+		s.logger.Error("Failed to publish follow event", "action", action, "follower_id", followerID.Hex(), "user_id", targetID.Hex(), "error", err)
+	}
+}
+
+// followPage turns a 1-based page and a page size into a skip and a limit
+func followPage(page, limit int) (int, int) {
+	if limit <= 0 {
+		limit = defaultFollowPageSize
+	}
+	limit = min(limit, maxFollowPageSize)
+	page = max(page, 1)
+	return (page - 1) * limit, limit
+}
+
+func objectIDs(hexIDs []string) []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, 0, len(hexIDs))
+	for _, hex := range hexIDs {
+		if id, err := primitive.ObjectIDFromHex(hex); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"user-service/internal/repository"
+	"user-service/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+func newTestFollowService() (*FollowService, *mocks.MockFollowGraph, *mocks.MockBlockRepository, *mocks.MockEventProducer) {
+	graph := mocks.NewMockFollowGraph()
+	blocks := &mocks.MockBlockRepository{}
+	notifier := &mocks.MockEventProducer{}
+	return NewFollowService(graph, &mocks.MockUserRepository{}, blocks, notifier, slog.Default()), graph, blocks, notifier
+}
+
+func TestFollowService_Follow(t *testing.T) {
+	t.Run("follows and notifies the followed user once", func(t *testing.T) {
+		svc, graph, _, notifier := newTestFollowService()
+		follower, target := primitive.NewObjectID(), primitive.NewObjectID()
+
+		require.NoError(t, svc.Follow(context.Background(), follower, target))
+		require.NoError(t, svc.Follow(context.Background(), follower, target))
+		assert.Equal(t, []primitive.ObjectID{target}, graph.Following[follower])
+
+		require.Len(t, notifier.ProduceCalls, 1)
+		assert.Equal(t, target.Hex(), string(notifier.ProduceCalls[0].Key))
+		var evt events.NotificationCreatedEvent
+		require.NoError(t, json.Unmarshal(notifier.ProduceCalls[0].Value, &evt))
+		assert.Equal(t, string(models.NotificationTypeNewFollower), evt.Type)
+		assert.Equal(t, follower, evt.SenderID)
+		assert.Equal(t, target, evt.RecipientID)
+	})
+
+	t.Run("rejects following yourself", func(t *testing.T) {
+		svc, _, _, _ := newTestFollowService()
+		id := primitive.NewObjectID()
+
+		assert.ErrorIs(t, svc.Follow(context.Background(), id, id), ErrCannotFollowSelf)
+	})
+
+	t.Run("rejects following across a block", func(t *testing.T) {
+		svc, graph, blocks, notifier := newTestFollowService()
+		follower, target := primitive.NewObjectID(), primitive.NewObjectID()
+		blocks.Blocks = append(blocks.Blocks, models.UserBlock{BlockerID: target, BlockedID: follower})
+
+		assert.ErrorIs(t, svc.Follow(context.Background(), follower, target), ErrFollowBlocked)
+		assert.Empty(t, graph.Following[follower])
+		assert.Empty(t, notifier.ProduceCalls)
+	})
+}
+
+func TestFollowService_Unfollow(t *testing.T) {
+	svc, graph, _, _ := newTestFollowService()
+	follower, target := primitive.NewObjectID(), primitive.NewObjectID()
+
+	assert.ErrorIs(t, svc.Unfollow(context.Background(), follower, target), ErrNotFollowing)
+
+	require.NoError(t, svc.Follow(context.Background(), follower, target))
+	require.NoError(t, svc.Unfollow(context.Background(), follower, target))
+	assert.Empty(t, graph.Following[follower])
+}
+
+func TestFollowService_FollowEvents(t *testing.T) {
+	svc, _, _, _ := newTestFollowService()
+	followEvents := &mocks.MockEventProducer{}
+	svc.SetFollowEvents(followEvents)
+	follower, target := primitive.NewObjectID(), primitive.NewObjectID()
+
+	require.NoError(t, svc.Follow(context.Background(), follower, target))
+	require.NoError(t, svc.Follow(context.Background(), follower, target))
+	require.NoError(t, svc.Unfollow(context.Background(), follower, target))
+	assert.ErrorIs(t, svc.Unfollow(context.Background(), follower, target), ErrNotFollowing)
+
+	// Only changes are announced, keyed by the follower
+	require.Len(t, followEvents.ProduceCalls, 2)
+	for i, action := range []string{"follow", "unfollow"} {
+		assert.Equal(t, follower.Hex(), string(followEvents.ProduceCalls[i].Key))
+		var evt events.FollowEvent
+		require.NoError(t, json.Unmarshal(followEvents.ProduceCalls[i].Value, &evt))
+		assert.Equal(t, action, evt.Action)
+		assert.Equal(t, follower.Hex(), evt.FollowerID)
+		assert.Equal(t, target.Hex(), evt.FolloweeID)
+	}
+}
+
+func TestFollowService_CountsAndLists(t *testing.T) {
+	svc, _, _, _ := newTestFollowService()
+	user := primitive.NewObjectID()
+	followed := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()}
+	for _, id := range followed {
+		require.NoError(t, svc.Follow(context.Background(), user, id))
+	}
+	require.NoError(t, svc.Follow(context.Background(), followed[0], user))
+
+	counts, err := svc.Counts(context.Background(), user)
+	require.NoError(t, err)
+	assert.Equal(t, models.FollowCounts{Followers: 1, Following: 3}, *counts)
+
+	page, err := svc.Following(context.Background(), user, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, followed[2], page[0].ID)
+
+	followers, err := svc.Followers(context.Background(), user, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, followers, 1)
+	assert.Equal(t, followed[0], followers[0].ID)
+}
+
+func TestFollowService_Suggest(t *testing.T) {
+	svc, graph, _, _ := newTestFollowService()
+	popular, niche := primitive.NewObjectID(), primitive.NewObjectID()
+	graph.Candidates = []repository.FollowCandidate{
+		{UserID: popular.Hex(), FollowedByMutual: 4, Followers: 900},
+		{UserID: niche.Hex(), FollowedByMutual: 1, Followers: 12},
+	}
+
+	suggestions, err := svc.Suggest(context.Background(), primitive.NewObjectID(), 0)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 2)
+	assert.Equal(t, popular, suggestions[0].User.ID)
+	assert.Equal(t, 4, suggestions[0].FollowedByMutual)
+	assert.Equal(t, int64(12), suggestions[1].Followers)
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	sharedevents "github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrInvalidInsightsWindow = errors.New("insights cover the last 7, 28 or 90 days")
+
+const (
+	defaultInsightsDays = 28
+
+	// reachTTL keeps each day's post viewers for the longest window
+	reachTTL = 91 * 24 * time.Hour
+)
+
+// insightsWindows are the periods insights are reported over, in days
+var insightsWindows = []int{7, 28, 90}
+
+// InsightsService aggregates profile views and post interactions into
+// creators' insights. Counts are kept in daily Mongo buckets. Reach, the
+// distinct users who saw a post, is a HyperLogLog per owner and day in Redis,
+// which merge into the reach of any window.
+type InsightsService struct {
+	repo        InsightsRepository
+	redisClient redis.UniversalClient
+	logger      *slog.Logger
+}
+
+func NewInsightsService(repo InsightsRepository, redisClient redis.UniversalClient, logger *slog.Logger) *InsightsService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &InsightsService{repo: repo, redisClient: redisClient, logger: logger}
+}
+
+// RecordInsight counts one event toward its owner's insights
+func (s *InsightsService) RecordInsight(ctx context.Context, evt sharedevents.InsightEvent) error {
+	ownerID, err := primitive.ObjectIDFromHex(evt.OwnerID)
+	if err != nil {
+		return fmt.Errorf("invalid owner id %q", evt.OwnerID)
+	}
+	switch evt.Metric {
+	case models.InsightProfileView, models.InsightPostImpression, models.InsightPostReaction, models.InsightPostComment:
+	default:
+		return fmt.Errorf("unknown insight metric %q", evt.Metric)
+	}
+	at := evt.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	if err := s.repo.Increment(ctx, ownerID, evt.Metric, at, 1); err != nil {
+		return err
+	}
+	if evt.Metric == models.InsightPostImpression && evt.ActorID != "" {
+		key := reachKey(ownerID, at)
+		if err := s.redisClient.PFAdd(ctx, key, evt.ActorID).Err(); err != nil {
+			return err
+		}
+		s.redisClient.Expire(ctx, key, reachTTL)
+	}
+	return nil
+}
+
+// GetInsights returns ownerID's insights over the last days days, today
+// included. Zero days is the default window.
+func (s *InsightsService) GetInsights(ctx context.Context, ownerID primitive.ObjectID, days int) (*models.InsightsResponse, error) {
+	if days == 0 {
+		days = defaultInsightsDays
+	}
+	if !slices.Contains(insightsWindows, days) {
+		return nil, ErrInvalidInsightsWindow
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	from := today.AddDate(0, 0, 1-days)
+	buckets, err := s.repo.ListSince(ctx, ownerID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load insights: %w", err)
+	}
+
+	resp := &models.InsightsResponse{Days: days, From: from, Daily: make([]models.InsightDay, days)}
+	reachKeys := make([]string, days)
+	for i := range resp.Daily {
+		day := from.AddDate(0, 0, i)
+		resp.Daily[i].Date = day.Format(time.DateOnly)
+		reachKeys[i] = reachKey(ownerID, day)
+	}
+	for _, b := range buckets {
+		i := int(b.Day.UTC().Sub(from) / (24 * time.Hour))
+		if i < 0 || i >= days {
+			continue
+		}
+		day := &resp.Daily[i]
+		switch b.Metric {
+		case models.InsightProfileView:
+			day.ProfileViews += b.Count
+			resp.Totals.ProfileViews += b.Count
+		case models.InsightPostImpression:
+			day.PostImpressions += b.Count
+			resp.Totals.PostImpressions += b.Count
+		case models.InsightPostReaction:
+			day.Reactions += b.Count
+			resp.Totals.Reactions += b.Count
+		case models.InsightPostComment:
+			day.Comments += b.Count
+			resp.Totals.Comments += b.Count
+		}
+	}
+
+	reach, err := s.redisClient.PFCount(ctx, reachKeys...).Result()
+	if err != nil {
+		// The counts still stand without reach
+		s.logger.Error("Failed to count post reach", "user_id", ownerID.Hex(), "error", err)
+	}
+	resp.Totals.PostReach = reach
+	return resp, nil
+}
+
+func reachKey(ownerID primitive.ObjectID, day time.Time) string {
+	return fmt.Sprintf("insights:reach:%s:%s", ownerID.Hex(), day.UTC().Format(time.DateOnly))
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/service/mocks"
+
+	sharedevents "github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestInsightsService_AggregatesEvents(t *testing.T) {
+	ownerID := primitive.NewObjectID()
+	viewerA := primitive.NewObjectID().Hex()
+	viewerB := primitive.NewObjectID().Hex()
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour)
+	longAgo := now.Add(-30 * 24 * time.Hour)
+
+	svc := NewInsightsService(&mocks.MockInsightsRepository{}, &mocks.MockRedisClient{}, nil)
+	for _, evt := range []sharedevents.InsightEvent{
+		{Metric: models.InsightProfileView, Timestamp: now},
+		{Metric: models.InsightProfileView, ActorID: viewerA, Timestamp: yesterday},
+		{Metric: models.InsightPostImpression, ActorID: viewerA, Timestamp: now},
+		{Metric: models.InsightPostImpression, ActorID: viewerA, Timestamp: yesterday},
+		{Metric: models.InsightPostImpression, ActorID: viewerB, Timestamp: now},
+		{Metric: models.InsightPostReaction, ActorID: viewerB, Timestamp: now},
+		{Metric: models.InsightPostComment, ActorID: viewerB, Timestamp: now},
+		{Metric: models.InsightPostComment, ActorID: viewerB, Timestamp: longAgo},
+	} {
+		evt.OwnerID = ownerID.Hex()
+		require.NoError(t, svc.RecordInsight(context.Background(), evt))
+	}
+
+	insights, err := svc.GetInsights(context.Background(), ownerID, 7)
+	require.NoError(t, err)
+
+	assert.Equal(t, 7, insights.Days)
+	assert.Len(t, insights.Daily, 7)
+	assert.Equal(t, models.InsightTotals{
+		ProfileViews:    2,
+		PostImpressions: 3,
+		PostReach:       2,
+		Reactions:       1,
+		Comments:        1,
+	}, insights.Totals)
+
+	last := insights.Daily[6]
+	assert.Equal(t, now.UTC().Format(time.DateOnly), last.Date)
+	assert.Equal(t, int64(1), last.ProfileViews)
+	assert.Equal(t, int64(2), last.PostImpressions)
+	assert.Equal(t, int64(1), insights.Daily[5].PostImpressions)
+}
+
+func TestInsightsService_RejectsBadInput(t *testing.T) {
+	svc := NewInsightsService(&mocks.MockInsightsRepository{}, &mocks.MockRedisClient{}, nil)
+
+	_, err := svc.GetInsights(context.Background(), primitive.NewObjectID(), 30)
+	assert.ErrorIs(t, err, ErrInvalidInsightsWindow)
+
+	insights, err := svc.GetInsights(context.Background(), primitive.NewObjectID(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, defaultInsightsDays, insights.Days)
+
+	err = svc.RecordInsight(context.Background(), sharedevents.InsightEvent{OwnerID: primitive.NewObjectID().Hex(), Metric: "shares"})
+	assert.Error(t, err)
+	err = svc.RecordInsight(context.Background(), sharedevents.InsightEvent{OwnerID: "nope", Metric: models.InsightProfileView})
+	assert.Error(t, err)
+}
@@ -2,6 +2,10 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
+
+	"user-service/internal/repository"
 
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"go.mongodb.org/mongo-driver/bson"
@@ -20,8 +24,131 @@ type UserRepository interface {
 	CountUsers(ctx context.Context, filter bson.M) (int64, error)
 	CreateUser(ctx context.Context, user *models.User) (*models.User, error)
 	UpdateUser(ctx context.Context, id primitive.ObjectID, update bson.M) (*models.User, error)
+	ChangeUsername(ctx context.Context, id primitive.ObjectID, oldUsername, newUsername string, at time.Time) (*models.User, error)
 	AddFriend(ctx context.Context, userID1, userID2 primitive.ObjectID) error
 	RemoveFriend(ctx context.Context, userID, friendID primitive.ObjectID) error
+	AddBlocked(ctx context.Context, blockerID, blockedID primitive.ObjectID) error
+	RemoveBlocked(ctx context.Context, blockerID, blockedID primitive.ObjectID) error
+}
+
+// UsernameHistoryRepository keeps the usernames users changed away from,
+// which stay reserved to them for a while
+type UsernameHistoryRepository interface {
+	Create(ctx context.Context, change *models.UsernameChange) error
+	FindReserved(ctx context.Context, usernames []string, now time.Time) ([]models.UsernameChange, error)
+	ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.UsernameChange, error)
+}
+
+// OAuthIdentityRepository keeps the provider accounts users sign in with
+type OAuthIdentityRepository interface {
+	Create(ctx context.Context, identity *models.OAuthIdentity) error
+	FindBySubject(ctx context.Context, provider models.OAuthProvider, subject string) (*models.OAuthIdentity, error)
+	ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.OAuthIdentity, error)
+	Touch(ctx context.Context, id primitive.ObjectID, at time.Time) error
+	Delete(ctx context.Context, userID primitive.ObjectID, provider models.OAuthProvider) error
+	DeleteByUser(ctx context.Context, userID primitive.ObjectID) error
+}
+
+// VerificationRepository keeps identity verification requests and their
+// reviews
+type VerificationRepository interface {
+	Create(ctx context.Context, req *models.VerificationRequest) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.VerificationRequest, error)
+	FindLatestByUser(ctx context.Context, userID primitive.ObjectID) (*models.VerificationRequest, error)
+	ListByStatus(ctx context.Context, status models.VerificationStatus, after primitive.ObjectID, limit int64) ([]models.VerificationRequest, error)
+	Review(ctx context.Context, id primitive.ObjectID, status models.VerificationStatus, reviewerID primitive.ObjectID, note string, at time.Time) (*models.VerificationRequest, error)
+}
+
+// VerificationDocuments signs and deletes the identity documents users
+// upload to storage-service
+type VerificationDocuments interface {
+	PresignDocument(ctx context.Context, key string, ttl time.Duration) (string, error)
+	DeleteDocument(ctx context.Context, key string) error
+}
+
+// InsightsRepository keeps creators' insights in daily buckets
+type InsightsRepository interface {
+	Increment(ctx context.Context, ownerID primitive.ObjectID, metric models.InsightMetric, at time.Time, n int64) error
+	ListSince(ctx context.Context, ownerID primitive.ObjectID, from time.Time) ([]models.InsightBucket, error)
+}
+
+// InsightsRecorder counts profile views toward their owner's insights
+type InsightsRecorder interface {
+	Record(ctx context.Context, metric models.InsightMetric, owner, actor, target primitive.ObjectID)
+}
+
+// BlockRepository defines persistence for user blocks
+type BlockRepository interface {
+	Create(ctx context.Context, blockerID, blockedID primitive.ObjectID) (*models.UserBlock, error)
+	Delete(ctx context.Context, blockerID, blockedID primitive.ObjectID) error
+	IsBlocked(ctx context.Context, userID1, userID2 primitive.ObjectID) (bool, error)
+	ListBlocked(ctx context.Context, blockerID primitive.ObjectID) ([]models.UserBlock, error)
+}
+
+// SessionRepository persists signed-in devices and their current refresh token
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) (*models.Session, error)
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Session, error)
+	Rotate(ctx context.Context, id primitive.ObjectID, oldTokenID, newTokenID string, expiresAt time.Time, device models.DeviceInfo) (bool, error)
+	ListActive(ctx context.Context, userID primitive.ObjectID) ([]models.Session, error)
+	Revoke(ctx context.Context, userID, id primitive.ObjectID, reason string) error
+}
+
+// SessionRevoker signs a user out of every device
+type SessionRevoker interface {
+	RevokeAllSessions(ctx context.Context, userID primitive.ObjectID, reason string) error
+}
+
+// EmailVerifier mails users a link confirming their email address
+type EmailVerifier interface {
+	SendVerification(ctx context.Context, userID primitive.ObjectID) error
+}
+
+// BlockGraph mirrors blocks as BLOCKED edges in Neo4j, which the other
+// services read to enforce them
+type BlockGraph interface {
+	BlockUser(ctx context.Context, blocker, blocked primitive.ObjectID) error
+	UnblockUser(ctx context.Context, blocker, blocked primitive.ObjectID) error
+}
+
+// FollowGraph keeps one-way follows as FOLLOWS edges in Neo4j, which
+// feed-service reads to build timelines
+type FollowGraph interface {
+	Follow(ctx context.Context, follower, followee primitive.ObjectID) (bool, error)
+	Unfollow(ctx context.Context, follower, followee primitive.ObjectID) (bool, error)
+	GetFollowCounts(ctx context.Context, userID primitive.ObjectID) (followers, following int64, err error)
+	GetFollowerIDs(ctx context.Context, userID primitive.ObjectID, skip, limit int) ([]string, error)
+	GetFollowingIDs(ctx context.Context, userID primitive.ObjectID, skip, limit int) ([]string, error)
+	FollowCandidates(ctx context.Context, userID primitive.ObjectID, limit int) ([]repository.FollowCandidate, error)
+}
+
+// CloseFriendsGraph keeps each user's close friends list as CLOSE_FRIEND
+// edges in Neo4j, which story-service reads to enforce story audiences
+type CloseFriendsGraph interface {
+	AddCloseFriend(ctx context.Context, owner, friend primitive.ObjectID) (bool, error)
+	RemoveCloseFriend(ctx context.Context, owner, friend primitive.ObjectID) (bool, error)
+	GetCloseFriendIDs(ctx context.Context, owner primitive.ObjectID) ([]string, error)
+}
+
+// SuggestionGraph reads friend suggestion candidates from Neo4j and keeps
+// each user's dismissed suggestions as DISMISSED edges
+type SuggestionGraph interface {
+	SuggestionCandidates(ctx context.Context, userID primitive.ObjectID, limit int) ([]repository.SuggestionCandidate, error)
+	DismissSuggestion(ctx context.Context, userID, dismissed primitive.ObjectID) error
+	RestoreSuggestion(ctx context.Context, userID, dismissed primitive.ObjectID) error
+	GetDismissedIDs(ctx context.Context, userID primitive.ObjectID) ([]string, error)
+}
+
+// SuggestionInvalidator drops cached friend suggestions after the graph
+// around a user changes
+type SuggestionInvalidator interface {
+	Invalidate(ctx context.Context, userIDs ...primitive.ObjectID)
+}
+
+// SearchIndexer publishes user changes for search-service to index
+type SearchIndexer interface {
+	UpsertUser(ctx context.Context, doc *models.SearchUserDocument)
+	Delete(ctx context.Context, entity models.SearchEntityType, id string)
 }
 
 // EventProducer defines the interface for Kafka event publishing
@@ -29,3 +156,56 @@ type EventProducer interface {
 	Produce(ctx context.Context, key, value []byte) error
 	Close() error
 }
+
+// ErasureRepository defines persistence for GDPR erasure requests
+type ErasureRepository interface {
+	Create(ctx context.Context, req *models.ErasureRequest) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.ErasureRequest, error)
+	FindOpenByUser(ctx context.Context, userID primitive.ObjectID) (*models.ErasureRequest, error)
+	ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.ErasureRequest, error)
+	List(ctx context.Context, status models.ErasureStatus, limit, page int64) ([]models.ErasureRequest, int64, error)
+	ListOpen(ctx context.Context, limit int64) ([]models.ErasureRequest, error)
+	UpdateService(ctx context.Context, id primitive.ObjectID, progress models.ErasureServiceProgress) error
+	SetStatus(ctx context.Context, id primitive.ObjectID, status models.ErasureStatus, completedAt *time.Time) error
+}
+
+// DataExportRepository defines persistence for users' data export requests
+type DataExportRepository interface {
+	Create(ctx context.Context, req *models.DataExportRequest) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.DataExportRequest, error)
+	FindOpenByUser(ctx context.Context, userID primitive.ObjectID) (*models.DataExportRequest, error)
+	ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.DataExportRequest, error)
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*models.DataExportRequest, error)
+	Save(ctx context.Context, req *models.DataExportRequest) error
+	ListExpired(ctx context.Context, now time.Time, limit int64) ([]models.DataExportRequest, error)
+}
+
+// AccountDeletionRepository defines persistence for account deletions
+type AccountDeletionRepository interface {
+	Create(ctx context.Context, d *models.AccountDeletion) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.AccountDeletion, error)
+	FindLatestByUser(ctx context.Context, userID primitive.ObjectID) (*models.AccountDeletion, error)
+	FindActiveByUser(ctx context.Context, userID primitive.ObjectID) (*models.AccountDeletion, error)
+	CancelScheduled(ctx context.Context, userID primitive.ObjectID, now time.Time) (*models.AccountDeletion, error)
+	ClaimDue(ctx context.Context, now, staleBefore time.Time) (*models.AccountDeletion, error)
+	MarkDeleted(ctx context.Context, id primitive.ObjectID, deletedAt time.Time, services []models.ErasureServiceProgress) error
+	ListOpen(ctx context.Context, limit int64) ([]models.AccountDeletion, error)
+	UpdateService(ctx context.Context, id primitive.ObjectID, progress models.ErasureServiceProgress) error
+	SetStatus(ctx context.Context, id primitive.ObjectID, status models.AccountDeletionStatus, completedAt *time.Time) error
+}
+
+// AccountRemover deletes the account itself once its grace period is over:
+// it signs the user out, cuts them out of the social graph and anonymizes
+// the user record. It must be safe to run again for the same user.
+type AccountRemover interface {
+	RemoveAccount(ctx context.Context, userID primitive.ObjectID) error
+}
+
+// ArchiveStore keeps finished data export archives in object storage and
+// reads the media copied into them
+type ArchiveStore interface {
+	PutArchive(ctx context.Context, filename string, archive io.ReaderAt, size int64) (key string, err error)
+	PresignArchive(ctx context.Context, key string, ttl time.Duration) (string, error)
+	DeleteArchive(ctx context.Context, key string) error
+	OpenMedia(ctx context.Context, url string) (io.ReadCloser, error)
+}
@@ -0,0 +1,137 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MockAccountDeletionRepository is an in-memory implementation of AccountDeletionRepository
+type MockAccountDeletionRepository struct {
+	Deletions map[primitive.ObjectID]*models.AccountDeletion
+}
+
+func NewMockAccountDeletionRepository() *MockAccountDeletionRepository {
+	return &MockAccountDeletionRepository{Deletions: make(map[primitive.ObjectID]*models.AccountDeletion)}
+}
+
+func (m *MockAccountDeletionRepository) Create(ctx context.Context, d *models.AccountDeletion) error {
+	d.ID = primitive.NewObjectID()
+	m.Deletions[d.ID] = cloneAccountDeletion(d)
+	return nil
+}
+
+func (m *MockAccountDeletionRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.AccountDeletion, error) {
+	d, ok := m.Deletions[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return cloneAccountDeletion(d), nil
+}
+
+func (m *MockAccountDeletionRepository) FindLatestByUser(ctx context.Context, userID primitive.ObjectID) (*models.AccountDeletion, error) {
+	var latest *models.AccountDeletion
+	for _, d := range m.Deletions {
+		if d.UserID == userID && (latest == nil || d.CreatedAt.After(latest.CreatedAt)) {
+			latest = d
+		}
+	}
+	if latest == nil {
+		return nil, mongo.ErrNoDocuments
+	}
+	return cloneAccountDeletion(latest), nil
+}
+
+func (m *MockAccountDeletionRepository) FindActiveByUser(ctx context.Context, userID primitive.ObjectID) (*models.AccountDeletion, error) {
+	for _, d := range m.Deletions {
+		if d.UserID == userID && (d.Status == models.AccountDeletionStatusScheduled || d.Status == models.AccountDeletionStatusInProgress) {
+			return cloneAccountDeletion(d), nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (m *MockAccountDeletionRepository) CancelScheduled(ctx context.Context, userID primitive.ObjectID, now time.Time) (*models.AccountDeletion, error) {
+	for _, d := range m.Deletions {
+		if d.UserID == userID && d.Status == models.AccountDeletionStatusScheduled {
+			d.Status = models.AccountDeletionStatusCancelled
+			d.CancelledAt = &now
+			d.UpdatedAt = now
+			return cloneAccountDeletion(d), nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (m *MockAccountDeletionRepository) ClaimDue(ctx context.Context, now, staleBefore time.Time) (*models.AccountDeletion, error) {
+	var next *models.AccountDeletion
+	for _, d := range m.Deletions {
+		due := (d.Status == models.AccountDeletionStatusScheduled && !d.ScheduledFor.After(now)) ||
+			(d.Status == models.AccountDeletionStatusInProgress && d.DeletedAt == nil && d.UpdatedAt.Before(staleBefore))
+		if due && (next == nil || d.ScheduledFor.Before(next.ScheduledFor)) {
+			next = d
+		}
+	}
+	if next == nil {
+		return nil, mongo.ErrNoDocuments
+	}
+	next.Status = models.AccountDeletionStatusInProgress
+	next.UpdatedAt = time.Now()
+	return cloneAccountDeletion(next), nil
+}
+
+func (m *MockAccountDeletionRepository) MarkDeleted(ctx context.Context, id primitive.ObjectID, deletedAt time.Time, services []models.ErasureServiceProgress) error {
+	d, ok := m.Deletions[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	d.DeletedAt = &deletedAt
+	d.Services = append([]models.ErasureServiceProgress(nil), services...)
+	d.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *MockAccountDeletionRepository) ListOpen(ctx context.Context, limit int64) ([]models.AccountDeletion, error) {
+	var out []models.AccountDeletion
+	for _, d := range m.Deletions {
+		if d.Status == models.AccountDeletionStatusInProgress && d.DeletedAt != nil {
+			out = append(out, *cloneAccountDeletion(d))
+		}
+	}
+	return out, nil
+}
+
+func (m *MockAccountDeletionRepository) UpdateService(ctx context.Context, id primitive.ObjectID, progress models.ErasureServiceProgress) error {
+	d, ok := m.Deletions[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	for i := range d.Services {
+		if d.Services[i].Service == progress.Service {
+			d.Services[i] = progress
+			d.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return mongo.ErrNoDocuments
+}
+
+func (m *MockAccountDeletionRepository) SetStatus(ctx context.Context, id primitive.ObjectID, status models.AccountDeletionStatus, completedAt *time.Time) error {
+	d, ok := m.Deletions[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	d.Status = status
+	d.CompletedAt = completedAt
+	d.UpdatedAt = time.Now()
+	return nil
+}
+
+func cloneAccountDeletion(d *models.AccountDeletion) *models.AccountDeletion {
+	c := *d
+	c.Services = append([]models.ErasureServiceProgress(nil), d.Services...)
+	return &c
+}
@@ -0,0 +1,81 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/repository"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockBlockRepository is an in-memory implementation of BlockRepository
+type MockBlockRepository struct {
+	Blocks []models.UserBlock
+}
+
+func (m *MockBlockRepository) Create(ctx context.Context, blockerID, blockedID primitive.ObjectID) (*models.UserBlock, error) {
+	for _, b := range m.Blocks {
+		if b.BlockerID == blockerID && b.BlockedID == blockedID {
+			return nil, repository.ErrAlreadyBlocked
+		}
+	}
+	block := models.UserBlock{ID: primitive.NewObjectID(), BlockerID: blockerID, BlockedID: blockedID, CreatedAt: time.Now()}
+	m.Blocks = append(m.Blocks, block)
+	return &block, nil
+}
+
+func (m *MockBlockRepository) Delete(ctx context.Context, blockerID, blockedID primitive.ObjectID) error {
+	for i, b := range m.Blocks {
+		if b.BlockerID == blockerID && b.BlockedID == blockedID {
+			m.Blocks = append(m.Blocks[:i], m.Blocks[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrBlockNotFound
+}
+
+func (m *MockBlockRepository) IsBlocked(ctx context.Context, userID1, userID2 primitive.ObjectID) (bool, error) {
+	for _, b := range m.Blocks {
+		if (b.BlockerID == userID1 && b.BlockedID == userID2) || (b.BlockerID == userID2 && b.BlockedID == userID1) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockBlockRepository) ListBlocked(ctx context.Context, blockerID primitive.ObjectID) ([]models.UserBlock, error) {
+	var blocks []models.UserBlock
+	for _, b := range m.Blocks {
+		if b.BlockerID == blockerID {
+			blocks = append(blocks, b)
+		}
+	}
+	return blocks, nil
+}
+
+// MockBlockGraph records graph calls
+type MockBlockGraph struct {
+	Blocked map[primitive.ObjectID][]primitive.ObjectID
+}
+
+func NewMockBlockGraph() *MockBlockGraph {
+	return &MockBlockGraph{Blocked: make(map[primitive.ObjectID][]primitive.ObjectID)}
+}
+
+func (m *MockBlockGraph) BlockUser(ctx context.Context, blocker, blocked primitive.ObjectID) error {
+	m.Blocked[blocker] = append(m.Blocked[blocker], blocked)
+	return nil
+}
+
+func (m *MockBlockGraph) UnblockUser(ctx context.Context, blocker, blocked primitive.ObjectID) error {
+	ids := m.Blocked[blocker]
+	for i, id := range ids {
+		if id == blocked {
+			m.Blocked[blocker] = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
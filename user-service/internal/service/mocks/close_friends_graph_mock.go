@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"context"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockCloseFriendsGraph keeps close friends lists in memory. Only pairs in
+// Friends can be close friends.
+type MockCloseFriendsGraph struct {
+	Friends      map[primitive.ObjectID][]primitive.ObjectID
+	CloseFriends map[primitive.ObjectID][]primitive.ObjectID
+}
+
+func NewMockCloseFriendsGraph() *MockCloseFriendsGraph {
+	return &MockCloseFriendsGraph{
+		Friends:      make(map[primitive.ObjectID][]primitive.ObjectID),
+		CloseFriends: make(map[primitive.ObjectID][]primitive.ObjectID),
+	}
+}
+
+func (m *MockCloseFriendsGraph) AddCloseFriend(ctx context.Context, owner, friend primitive.ObjectID) (bool, error) {
+	if !slices.Contains(m.Friends[owner], friend) {
+		return false, nil
+	}
+	if !slices.Contains(m.CloseFriends[owner], friend) {
+		m.CloseFriends[owner] = append(m.CloseFriends[owner], friend)
+	}
+	return true, nil
+}
+
+func (m *MockCloseFriendsGraph) RemoveCloseFriend(ctx context.Context, owner, friend primitive.ObjectID) (bool, error) {
+	ids := m.CloseFriends[owner]
+	i := slices.Index(ids, friend)
+	if i < 0 {
+		return false, nil
+	}
+	m.CloseFriends[owner] = slices.Delete(ids, i, i+1)
+	return true, nil
+}
+
+func (m *MockCloseFriendsGraph) GetCloseFriendIDs(ctx context.Context, owner primitive.ObjectID) ([]string, error) {
+	var ids []string
+	for _, id := range m.CloseFriends[owner] {
+		ids = append(ids, id.Hex())
+	}
+	return ids, nil
+}
@@ -0,0 +1,94 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MockDataExportRepository is an in-memory implementation of DataExportRepository
+type MockDataExportRepository struct {
+	Requests map[primitive.ObjectID]*models.DataExportRequest
+}
+
+func NewMockDataExportRepository() *MockDataExportRepository {
+	return &MockDataExportRepository{Requests: make(map[primitive.ObjectID]*models.DataExportRequest)}
+}
+
+func (m *MockDataExportRepository) Create(ctx context.Context, req *models.DataExportRequest) error {
+	req.ID = primitive.NewObjectID()
+	m.Requests[req.ID] = cloneDataExportRequest(req)
+	return nil
+}
+
+func (m *MockDataExportRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.DataExportRequest, error) {
+	req, ok := m.Requests[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return cloneDataExportRequest(req), nil
+}
+
+func (m *MockDataExportRepository) FindOpenByUser(ctx context.Context, userID primitive.ObjectID) (*models.DataExportRequest, error) {
+	for _, req := range m.Requests {
+		if req.UserID == userID && (req.Status == models.DataExportStatusPending || req.Status == models.DataExportStatusInProgress) {
+			return cloneDataExportRequest(req), nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (m *MockDataExportRepository) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.DataExportRequest, error) {
+	var out []models.DataExportRequest
+	for _, req := range m.Requests {
+		if req.UserID == userID {
+			out = append(out, *cloneDataExportRequest(req))
+		}
+	}
+	return out, nil
+}
+
+func (m *MockDataExportRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*models.DataExportRequest, error) {
+	var next *models.DataExportRequest
+	for _, req := range m.Requests {
+		claimable := req.Status == models.DataExportStatusPending ||
+			(req.Status == models.DataExportStatusInProgress && req.UpdatedAt.Before(staleBefore))
+		if claimable && (next == nil || req.CreatedAt.Before(next.CreatedAt)) {
+			next = req
+		}
+	}
+	if next == nil {
+		return nil, mongo.ErrNoDocuments
+	}
+	next.Status = models.DataExportStatusInProgress
+	next.UpdatedAt = time.Now()
+	return cloneDataExportRequest(next), nil
+}
+
+func (m *MockDataExportRepository) Save(ctx context.Context, req *models.DataExportRequest) error {
+	if _, ok := m.Requests[req.ID]; !ok {
+		return mongo.ErrNoDocuments
+	}
+	req.UpdatedAt = time.Now()
+	m.Requests[req.ID] = cloneDataExportRequest(req)
+	return nil
+}
+
+func (m *MockDataExportRepository) ListExpired(ctx context.Context, now time.Time, limit int64) ([]models.DataExportRequest, error) {
+	var out []models.DataExportRequest
+	for _, req := range m.Requests {
+		if req.Status == models.DataExportStatusReady && req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+			out = append(out, *cloneDataExportRequest(req))
+		}
+	}
+	return out, nil
+}
+
+func cloneDataExportRequest(req *models.DataExportRequest) *models.DataExportRequest {
+	c := *req
+	c.Services = append([]models.DataExportServiceProgress(nil), req.Services...)
+	return &c
+}
@@ -0,0 +1,107 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MockErasureRepository is an in-memory implementation of ErasureRepository
+type MockErasureRepository struct {
+	Requests map[primitive.ObjectID]*models.ErasureRequest
+}
+
+func NewMockErasureRepository() *MockErasureRepository {
+	return &MockErasureRepository{Requests: make(map[primitive.ObjectID]*models.ErasureRequest)}
+}
+
+func (m *MockErasureRepository) Create(ctx context.Context, req *models.ErasureRequest) error {
+	req.ID = primitive.NewObjectID()
+	stored := *req
+	stored.Services = append([]models.ErasureServiceProgress(nil), req.Services...)
+	m.Requests[req.ID] = &stored
+	return nil
+}
+
+func (m *MockErasureRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.ErasureRequest, error) {
+	req, ok := m.Requests[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return cloneErasureRequest(req), nil
+}
+
+func (m *MockErasureRepository) FindOpenByUser(ctx context.Context, userID primitive.ObjectID) (*models.ErasureRequest, error) {
+	for _, req := range m.Requests {
+		if req.UserID == userID && isOpenErasureRequest(req) {
+			return cloneErasureRequest(req), nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (m *MockErasureRepository) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.ErasureRequest, error) {
+	var out []models.ErasureRequest
+	for _, req := range m.Requests {
+		if req.UserID == userID {
+			out = append(out, *cloneErasureRequest(req))
+		}
+	}
+	return out, nil
+}
+
+func (m *MockErasureRepository) List(ctx context.Context, status models.ErasureStatus, limit, page int64) ([]models.ErasureRequest, int64, error) {
+	var out []models.ErasureRequest
+	for _, req := range m.Requests {
+		if status == "" || req.Status == status {
+			out = append(out, *cloneErasureRequest(req))
+		}
+	}
+	return out, int64(len(out)), nil
+}
+
+func (m *MockErasureRepository) ListOpen(ctx context.Context, limit int64) ([]models.ErasureRequest, error) {
+	var out []models.ErasureRequest
+	for _, req := range m.Requests {
+		if isOpenErasureRequest(req) {
+			out = append(out, *cloneErasureRequest(req))
+		}
+	}
+	return out, nil
+}
+
+func (m *MockErasureRepository) UpdateService(ctx context.Context, id primitive.ObjectID, progress models.ErasureServiceProgress) error {
+	req, ok := m.Requests[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	if p := req.Service(progress.Service); p != nil {
+		*p = progress
+	}
+	req.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *MockErasureRepository) SetStatus(ctx context.Context, id primitive.ObjectID, status models.ErasureStatus, completedAt *time.Time) error {
+	req, ok := m.Requests[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	req.Status = status
+	req.CompletedAt = completedAt
+	req.UpdatedAt = time.Now()
+	return nil
+}
+
+func cloneErasureRequest(req *models.ErasureRequest) *models.ErasureRequest {
+	c := *req
+	c.Services = append([]models.ErasureServiceProgress(nil), req.Services...)
+	return &c
+}
+
+func isOpenErasureRequest(req *models.ErasureRequest) bool {
+	return req.Status == models.ErasureStatusPending || req.Status == models.ErasureStatusInProgress
+}
@@ -0,0 +1,73 @@
+package mocks
+
+import (
+	"context"
+	"slices"
+
+	"user-service/internal/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockFollowGraph keeps follows in memory, in the order they were made
+type MockFollowGraph struct {
+	Following  map[primitive.ObjectID][]primitive.ObjectID
+	Candidates []repository.FollowCandidate
+}
+
+func NewMockFollowGraph() *MockFollowGraph {
+	return &MockFollowGraph{Following: make(map[primitive.ObjectID][]primitive.ObjectID)}
+}
+
+func (m *MockFollowGraph) Follow(ctx context.Context, follower, followee primitive.ObjectID) (bool, error) {
+	if slices.Contains(m.Following[follower], followee) {
+		return false, nil
+	}
+	m.Following[follower] = append(m.Following[follower], followee)
+	return true, nil
+}
+
+func (m *MockFollowGraph) Unfollow(ctx context.Context, follower, followee primitive.ObjectID) (bool, error) {
+	ids := m.Following[follower]
+	i := slices.Index(ids, followee)
+	if i < 0 {
+		return false, nil
+	}
+	m.Following[follower] = slices.Delete(ids, i, i+1)
+	return true, nil
+}
+
+func (m *MockFollowGraph) GetFollowCounts(ctx context.Context, userID primitive.ObjectID) (int64, int64, error) {
+	followers, _ := m.GetFollowerIDs(ctx, userID, 0, len(m.Following))
+	return int64(len(followers)), int64(len(m.Following[userID])), nil
+}
+
+func (m *MockFollowGraph) GetFollowerIDs(ctx context.Context, userID primitive.ObjectID, skip, limit int) ([]string, error) {
+	var ids []string
+	for follower, followees := range m.Following {
+		if slices.Contains(followees, userID) {
+			ids = append(ids, follower.Hex())
+		}
+	}
+	slices.Sort(ids)
+	return page(ids, skip, limit), nil
+}
+
+func (m *MockFollowGraph) GetFollowingIDs(ctx context.Context, userID primitive.ObjectID, skip, limit int) ([]string, error) {
+	var ids []string
+	for _, id := range m.Following[userID] {
+		ids = append(ids, id.Hex())
+	}
+	return page(ids, skip, limit), nil
+}
+
+func (m *MockFollowGraph) FollowCandidates(ctx context.Context, userID primitive.ObjectID, limit int) ([]repository.FollowCandidate, error) {
+	return m.Candidates[:min(limit, len(m.Candidates))], nil
+}
+
+func page(ids []string, skip, limit int) []string {
+	if skip >= len(ids) {
+		return nil
+	}
+	return ids[skip:min(skip+limit, len(ids))]
+}
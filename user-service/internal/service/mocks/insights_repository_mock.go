@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockInsightsRepository is an in-memory implementation of InsightsRepository
+type MockInsightsRepository struct {
+	Buckets []models.InsightBucket
+}
+
+func (m *MockInsightsRepository) Increment(ctx context.Context, ownerID primitive.ObjectID, metric models.InsightMetric, at time.Time, n int64) error {
+	day := at.UTC().Truncate(24 * time.Hour)
+	for i, b := range m.Buckets {
+		if b.OwnerID == ownerID && b.Metric == metric && b.Day.Equal(day) {
+			m.Buckets[i].Count += n
+			return nil
+		}
+	}
+	m.Buckets = append(m.Buckets, models.InsightBucket{ID: primitive.NewObjectID(), OwnerID: ownerID, Metric: metric, Day: day, Count: n})
+	return nil
+}
+
+func (m *MockInsightsRepository) ListSince(ctx context.Context, ownerID primitive.ObjectID, from time.Time) ([]models.InsightBucket, error) {
+	from = from.UTC().Truncate(24 * time.Hour)
+	buckets := []models.InsightBucket{}
+	for _, b := range m.Buckets {
+		if b.OwnerID == ownerID && !b.Day.Before(from) {
+			buckets = append(buckets, b)
+		}
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Day.Before(buckets[j].Day) })
+	return buckets, nil
+}
@@ -2,19 +2,30 @@ package mocks
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
-// MockRedisClient is a mock implementation of redis.UniversalClient
+// MockRedisClient is a mock implementation of redis.UniversalClient. Methods
+// it does not implement panic through the nil embedded client.
 type MockRedisClient struct {
+	redis.UniversalClient
+
 	GetFunc  func(ctx context.Context, key string) *redis.StringCmd
 	MGetFunc func(ctx context.Context, keys ...string) *redis.SliceCmd
 	SetFunc  func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	DelFunc  func(ctx context.Context, keys ...string) *redis.IntCmd
+
+	// SetNX keys, held forever
+	nx map[string]struct{}
 
 	GetCalls  []string
 	MGetCalls [][]string
+
+	// HyperLogLogs, counted exactly
+	hll map[string]map[string]struct{}
 }
 
 func (m *MockRedisClient) Get(ctx context.Context, key string) *redis.StringCmd {
@@ -50,3 +61,59 @@ func (m *MockRedisClient) Close() error { return nil }
 func (m *MockRedisClient) Ping(ctx context.Context) *redis.StatusCmd {
 	return redis.NewStatusCmd(ctx)
 }
+
+func (m *MockRedisClient) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	for _, key := range keys {
+		delete(m.nx, key)
+	}
+	if m.DelFunc != nil {
+		return m.DelFunc(ctx, keys...)
+	}
+	return redis.NewIntCmd(ctx)
+}
+
+func (m *MockRedisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	if m.nx == nil {
+		m.nx = map[string]struct{}{}
+	}
+	_, held := m.nx[key]
+	m.nx[key] = struct{}{}
+	cmd := redis.NewBoolCmd(ctx)
+	cmd.SetVal(!held)
+	return cmd
+}
+
+// Pipeline queues commands against an unreachable server, so Exec fails as
+// a lost cache would
+func (m *MockRedisClient) Pipeline() redis.Pipeliner {
+	return redis.NewClient(&redis.Options{Addr: "127.0.0.1:0", MaxRetries: -1}).Pipeline()
+}
+
+func (m *MockRedisClient) PFAdd(ctx context.Context, key string, els ...interface{}) *redis.IntCmd {
+	if m.hll == nil {
+		m.hll = map[string]map[string]struct{}{}
+	}
+	if m.hll[key] == nil {
+		m.hll[key] = map[string]struct{}{}
+	}
+	for _, el := range els {
+		m.hll[key][fmt.Sprint(el)] = struct{}{}
+	}
+	return redis.NewIntCmd(ctx)
+}
+
+func (m *MockRedisClient) PFCount(ctx context.Context, keys ...string) *redis.IntCmd {
+	union := map[string]struct{}{}
+	for _, key := range keys {
+		for el := range m.hll[key] {
+			union[el] = struct{}{}
+		}
+	}
+	cmd := redis.NewIntCmd(ctx)
+	cmd.SetVal(int64(len(union)))
+	return cmd
+}
+
+func (m *MockRedisClient) Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+	return redis.NewBoolCmd(ctx)
+}
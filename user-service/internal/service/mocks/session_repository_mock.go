@@ -0,0 +1,68 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/repository"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockSessionRepository keeps sessions in memory
+type MockSessionRepository struct {
+	Sessions map[primitive.ObjectID]*models.Session
+}
+
+func NewMockSessionRepository() *MockSessionRepository {
+	return &MockSessionRepository{Sessions: make(map[primitive.ObjectID]*models.Session)}
+}
+
+func (m *MockSessionRepository) Create(ctx context.Context, session *models.Session) (*models.Session, error) {
+	session.ID = primitive.NewObjectID()
+	stored := *session
+	m.Sessions[session.ID] = &stored
+	return session, nil
+}
+
+func (m *MockSessionRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Session, error) {
+	session, ok := m.Sessions[id]
+	if !ok {
+		return nil, repository.ErrSessionNotFound
+	}
+	found := *session
+	return &found, nil
+}
+
+func (m *MockSessionRepository) Rotate(ctx context.Context, id primitive.ObjectID, oldTokenID, newTokenID string, expiresAt time.Time, device models.DeviceInfo) (bool, error) {
+	session, ok := m.Sessions[id]
+	if !ok || session.RevokedAt != nil || session.RefreshTokenID != oldTokenID {
+		return false, nil
+	}
+	session.RefreshTokenID = newTokenID
+	session.ExpiresAt = expiresAt
+	session.LastUsedAt = time.Now()
+	return true, nil
+}
+
+func (m *MockSessionRepository) ListActive(ctx context.Context, userID primitive.ObjectID) ([]models.Session, error) {
+	sessions := []models.Session{}
+	for _, session := range m.Sessions {
+		if session.UserID == userID && session.RevokedAt == nil {
+			sessions = append(sessions, *session)
+		}
+	}
+	return sessions, nil
+}
+
+func (m *MockSessionRepository) Revoke(ctx context.Context, userID, id primitive.ObjectID, reason string) error {
+	session, ok := m.Sessions[id]
+	if !ok || session.UserID != userID || session.RevokedAt != nil {
+		return repository.ErrSessionNotFound
+	}
+	now := time.Now()
+	session.RevokedAt = &now
+	session.RevokedReason = reason
+	return nil
+}
@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockSessionRevoker records whom it signed out everywhere
+type MockSessionRevoker struct {
+	Revoked []primitive.ObjectID
+	Reasons []string
+}
+
+func (m *MockSessionRevoker) RevokeAllSessions(ctx context.Context, userID primitive.ObjectID, reason string) error {
+	m.Revoked = append(m.Revoked, userID)
+	m.Reasons = append(m.Reasons, reason)
+	return nil
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"user-service/internal/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockSuggestionGraph serves canned candidates and keeps dismissals in memory
+type MockSuggestionGraph struct {
+	Candidates []repository.SuggestionCandidate
+	Dismissed  map[primitive.ObjectID][]primitive.ObjectID
+
+	CandidateCalls int
+}
+
+func NewMockSuggestionGraph() *MockSuggestionGraph {
+	return &MockSuggestionGraph{Dismissed: make(map[primitive.ObjectID][]primitive.ObjectID)}
+}
+
+func (m *MockSuggestionGraph) SuggestionCandidates(ctx context.Context, userID primitive.ObjectID, limit int) ([]repository.SuggestionCandidate, error) {
+	m.CandidateCalls++
+	if len(m.Candidates) > limit {
+		return m.Candidates[:limit], nil
+	}
+	return m.Candidates, nil
+}
+
+func (m *MockSuggestionGraph) DismissSuggestion(ctx context.Context, userID, dismissed primitive.ObjectID) error {
+	m.Dismissed[userID] = append(m.Dismissed[userID], dismissed)
+	return nil
+}
+
+func (m *MockSuggestionGraph) RestoreSuggestion(ctx context.Context, userID, dismissed primitive.ObjectID) error {
+	ids := m.Dismissed[userID]
+	for i, id := range ids {
+		if id == dismissed {
+			m.Dismissed[userID] = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *MockSuggestionGraph) GetDismissedIDs(ctx context.Context, userID primitive.ObjectID) ([]string, error) {
+	var ids []string
+	for _, id := range m.Dismissed[userID] {
+		ids = append(ids, id.Hex())
+	}
+	return ids, nil
+}
@@ -20,6 +20,10 @@ type MockUserRepository struct {
 	CountUsersFunc      func(ctx context.Context, filter bson.M) (int64, error)
 	CreateUserFunc      func(ctx context.Context, user *models.User) (*models.User, error)
 
+	FindUserByUserNameFunc   func(ctx context.Context, username string) (*models.User, error)
+	FindUsersByUsernamesFunc func(ctx context.Context, usernames []string) ([]models.User, error)
+	ChangeUsernameFunc       func(ctx context.Context, id primitive.ObjectID, oldUsername, newUsername string, at time.Time) (*models.User, error)
+
 	// Track calls for verification
 	FindUserByIDCalls   []primitive.ObjectID
 	FindUsersByIDsCalls [][]primitive.ObjectID
@@ -89,9 +93,19 @@ func (m *MockUserRepository) CreateUser(ctx context.Context, user *models.User)
 }
 
 func (m *MockUserRepository) FindUserByUserName(ctx context.Context, username string) (*models.User, error) {
+	if m.FindUserByUserNameFunc != nil {
+		return m.FindUserByUserNameFunc(ctx, username)
+	}
 	return nil, nil
 }
 
+func (m *MockUserRepository) ChangeUsername(ctx context.Context, id primitive.ObjectID, oldUsername, newUsername string, at time.Time) (*models.User, error) {
+	if m.ChangeUsernameFunc != nil {
+		return m.ChangeUsernameFunc(ctx, id, oldUsername, newUsername, at)
+	}
+	return &models.User{ID: id, Username: newUsername, UsernameChangedAt: &at}, nil
+}
+
 func (m *MockUserRepository) AddFriend(ctx context.Context, userID1, userID2 primitive.ObjectID) error {
 	return nil
 }
@@ -100,7 +114,18 @@ func (m *MockUserRepository) RemoveFriend(ctx context.Context, userID, friendID
 	return nil
 }
 
+func (m *MockUserRepository) AddBlocked(ctx context.Context, blockerID, blockedID primitive.ObjectID) error {
+	return nil
+}
+
+func (m *MockUserRepository) RemoveBlocked(ctx context.Context, blockerID, blockedID primitive.ObjectID) error {
+	return nil
+}
+
 func (m *MockUserRepository) FindUsersByUsernames(ctx context.Context, usernames []string) ([]models.User, error) {
+	if m.FindUsersByUsernamesFunc != nil {
+		return m.FindUsersByUsernamesFunc(ctx, usernames)
+	}
 	users := make([]models.User, len(usernames))
 	for i, username := range usernames {
 		users[i] = models.User{ID: primitive.NewObjectID(), Username: username}
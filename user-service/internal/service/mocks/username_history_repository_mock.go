@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockUsernameHistoryRepository is an in-memory implementation of
+// UsernameHistoryRepository
+type MockUsernameHistoryRepository struct {
+	Changes []models.UsernameChange
+}
+
+func (m *MockUsernameHistoryRepository) Create(ctx context.Context, change *models.UsernameChange) error {
+	change.ID = primitive.NewObjectID()
+	m.Changes = append(m.Changes, *change)
+	return nil
+}
+
+func (m *MockUsernameHistoryRepository) FindReserved(ctx context.Context, usernames []string, now time.Time) ([]models.UsernameChange, error) {
+	latest := map[string]models.UsernameChange{}
+	for _, c := range m.Changes {
+		if !c.ReservedUntil.After(now) {
+			continue
+		}
+		for _, username := range usernames {
+			if c.OldUsername == username && c.ReservedUntil.After(latest[username].ReservedUntil) {
+				latest[username] = c
+			}
+		}
+	}
+	changes := make([]models.UsernameChange, 0, len(latest))
+	for _, c := range latest {
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+func (m *MockUsernameHistoryRepository) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.UsernameChange, error) {
+	changes := []models.UsernameChange{}
+	for _, c := range m.Changes {
+		if c.UserID == userID {
+			changes = append(changes, c)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ChangedAt.After(changes[j].ChangedAt) })
+	return changes, nil
+}
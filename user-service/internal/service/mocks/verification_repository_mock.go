@@ -0,0 +1,87 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MockVerificationRepository is an in-memory implementation of
+// VerificationRepository
+type MockVerificationRepository struct {
+	Requests []models.VerificationRequest
+	// CreateErr, when set, is returned by Create instead of storing the request
+	CreateErr error
+}
+
+func (m *MockVerificationRepository) Create(ctx context.Context, req *models.VerificationRequest) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	req.ID = primitive.NewObjectID()
+	m.Requests = append(m.Requests, *req)
+	return nil
+}
+
+func (m *MockVerificationRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.VerificationRequest, error) {
+	for _, r := range m.Requests {
+		if r.ID == id {
+			return &r, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (m *MockVerificationRepository) FindLatestByUser(ctx context.Context, userID primitive.ObjectID) (*models.VerificationRequest, error) {
+	for i := len(m.Requests) - 1; i >= 0; i-- {
+		if m.Requests[i].UserID == userID {
+			r := m.Requests[i]
+			return &r, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (m *MockVerificationRepository) ListByStatus(ctx context.Context, status models.VerificationStatus, after primitive.ObjectID, limit int64) ([]models.VerificationRequest, error) {
+	requests := []models.VerificationRequest{}
+	for _, r := range m.Requests {
+		if r.Status == status && (after.IsZero() || r.ID.Hex() > after.Hex()) && int64(len(requests)) < limit {
+			requests = append(requests, r)
+		}
+	}
+	return requests, nil
+}
+
+func (m *MockVerificationRepository) Review(ctx context.Context, id primitive.ObjectID, status models.VerificationStatus, reviewerID primitive.ObjectID, note string, at time.Time) (*models.VerificationRequest, error) {
+	for i, r := range m.Requests {
+		if r.ID != id || r.Status != models.VerificationPending {
+			continue
+		}
+		reviewed := r
+		reviewed.Status = status
+		reviewed.ReviewerID = &reviewerID
+		reviewed.ReviewNote = note
+		reviewed.ReviewedAt = &at
+		m.Requests[i] = reviewed
+		m.Requests[i].DocumentKey = ""
+		return &reviewed, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+// MockVerificationDocuments signs every key and records deletions
+type MockVerificationDocuments struct {
+	Deleted []string
+}
+
+func (m *MockVerificationDocuments) PresignDocument(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return "https://storage.example.com/" + key + "?signed", nil
+}
+
+func (m *MockVerificationDocuments) DeleteDocument(ctx context.Context, key string) error {
+	m.Deleted = append(m.Deleted, key)
+	return nil
+}
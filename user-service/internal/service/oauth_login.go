@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"user-service/internal/oauth"
+	"user-service/internal/repository"
+	"user-service/internal/validation"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrOAuthProviderUnavailable = errors.New("sign-in with this provider is not available")
+	ErrInvalidOAuthCredential   = errors.New("invalid provider credential")
+	ErrOAuthEmailRequired       = errors.New("the provider did not share a verified email address")
+	ErrOAuthEmailInUse          = errors.New("an account already uses this email, sign in with its password and link the provider from your settings")
+	ErrOAuthIdentityLinked      = errors.New("this provider account is linked to another user")
+	ErrOAuthAlreadyLinked       = errors.New("an account at this provider is already linked")
+	ErrOAuthNotLinked           = errors.New("no account at this provider is linked")
+	ErrLastSignInMethod         = errors.New("set a password before unlinking your only way to sign in")
+)
+
+const (
+	usernameSuggestionCount = 3
+	// Random suffixes tried per suggestion before giving up on a base
+	usernameSuffixAttempts = 5
+)
+
+// OAuthAuthenticator verifies what a client got back from one identity
+// provider
+type OAuthAuthenticator interface {
+	Name() models.OAuthProvider
+	Authenticate(ctx context.Context, req models.OAuthLoginRequest) (*oauth.Identity, error)
+}
+
+// SetOAuth enables signing in with the given providers, with linked provider
+// accounts kept in identities
+func (s *AuthService) SetOAuth(identities OAuthIdentityRepository, providers ...OAuthAuthenticator) {
+	s.oauthIdentities = identities
+	s.oauthProviders = make(map[models.OAuthProvider]OAuthAuthenticator, len(providers))
+	for _, p := range providers {
+		s.oauthProviders[p.Name()] = p
+	}
+}
+
+// OAuthLogin signs in with a provider account. An account linked before
+// signs in its user. Otherwise it is linked to the user with the same email,
+// when both the provider and the user have verified it, or a new user is
+// created with a username picked for them. Two-factor authentication
+// applies as with a password.
+func (s *AuthService) OAuthLogin(ctx context.Context, provider models.OAuthProvider, req models.OAuthLoginRequest, device models.DeviceInfo) (*models.AuthResponse, error) {
+	identity, err := s.authenticateOAuth(ctx, provider, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *models.User
+	var suggestions []string
+	linked, err := s.oauthIdentities.FindBySubject(ctx, provider, identity.Subject)
+	switch {
+	case err == nil:
+		if user, err = s.userRepo.FindUserByID(ctx, linked.UserID); err != nil {
+			return nil, ErrUserNotFound
+		}
+		if err := s.oauthIdentities.Touch(ctx, linked.ID, time.Now()); err != nil {
+			log.Printf("Failed to record %s sign-in for user %s: %v", provider, user.ID.Hex(), err)
+		}
+	case errors.Is(err, mongo.ErrNoDocuments):
+		if user, suggestions, err = s.linkOrCreateOAuthUser(ctx, identity, req.FullName); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	metadata := map[string]string{"provider": string(provider)}
+	if err := signInError(user, time.Now()); err != nil {
+		s.auditLogin(ctx, user, device, err.Error(), metadata)
+		return nil, err
+	}
+	if s.cfg.TwoFactorEnabled && user.TwoFactorEnabled {
+		return s.beginTwoFactorLogin(ctx, user)
+	}
+	s.auditLogin(ctx, user, device, "", metadata)
+	resp, err := s.issueTokens(ctx, user, device)
+	if err != nil {
+		return nil, err
+	}
+	if suggestions != nil {
+		resp.NewAccount = true
+		resp.UsernameSuggestions = suggestions
+	}
+	return resp, nil
+}
+
+// ListOAuthIdentities returns the provider accounts linked to the user
+func (s *AuthService) ListOAuthIdentities(ctx context.Context, userID primitive.ObjectID) ([]models.OAuthIdentity, error) {
+	if s.oauthIdentities == nil {
+		return []models.OAuthIdentity{}, nil
+	}
+	return s.oauthIdentities.ListByUser(ctx, userID)
+}
+
+// LinkOAuth links a provider account to a signed-in user, so they can sign
+// in with it too. Linking the same account again is a no-op.
+func (s *AuthService) LinkOAuth(ctx context.Context, userID primitive.ObjectID, provider models.OAuthProvider, req models.OAuthLoginRequest) (*models.OAuthIdentity, error) {
+	identity, err := s.authenticateOAuth(ctx, provider, req)
+	if err != nil {
+		return nil, err
+	}
+	linked, err := s.oauthIdentities.FindBySubject(ctx, provider, identity.Subject)
+	if err == nil {
+		if linked.UserID != userID {
+			return nil, ErrOAuthIdentityLinked
+		}
+		return linked, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	return s.linkOAuthIdentity(ctx, userID, identity)
+}
+
+// UnlinkOAuth removes a provider account from the user. The last one stays
+// while the user has no password, or they could no longer sign in.
+func (s *AuthService) UnlinkOAuth(ctx context.Context, userID primitive.ObjectID, provider models.OAuthProvider) error {
+	if s.oauthIdentities == nil {
+		return ErrOAuthNotLinked
+	}
+	user, err := s.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	identities, err := s.oauthIdentities.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, identity := range identities {
+		found = found || identity.Provider == provider
+	}
+	if !found {
+		return ErrOAuthNotLinked
+	}
+	if user.Password == "" && len(identities) == 1 {
+		return ErrLastSignInMethod
+	}
+
+	if err := s.oauthIdentities.Delete(ctx, userID, provider); errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrOAuthNotLinked
+	} else if err != nil {
+		return err
+	}
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditActionOAuthUnlinked,
+		ActorID:    &userID,
+		TargetType: "user",
+		TargetID:   userID.Hex(),
+		Metadata:   map[string]string{"provider": string(provider)},
+	})
+	return nil
+}
+
+// authenticateOAuth has provider vouch for the credential in req
+func (s *AuthService) authenticateOAuth(ctx context.Context, provider models.OAuthProvider, req models.OAuthLoginRequest) (*oauth.Identity, error) {
+	p, ok := s.oauthProviders[provider]
+	if !ok || s.oauthIdentities == nil {
+		return nil, ErrOAuthProviderUnavailable
+	}
+	identity, err := p.Authenticate(ctx, req)
+	switch {
+	case errors.Is(err, oauth.ErrInvalidToken), errors.Is(err, oauth.ErrExchangeFailed),
+		errors.Is(err, oauth.ErrCodeFlowDisabled), errors.Is(err, oauth.ErrRedirectNotAllowed),
+		errors.Is(err, oauth.ErrMissingCredential):
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOAuthCredential, err)
+	case err != nil:
+		return nil, err
+	}
+	return identity, nil
+}
+
+// linkOrCreateOAuthUser links a provider account seen for the first time to
+// the user with its email, or creates a user for it. A user whose email is
+// unverified is not linked: whoever registered it may not own the address,
+// and would keep their password into the account. Suggestions are only
+// returned for a new user.
+func (s *AuthService) linkOrCreateOAuthUser(ctx context.Context, identity *oauth.Identity, fullName string) (*models.User, []string, error) {
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, nil, ErrOAuthEmailRequired
+	}
+	if existing, _ := s.userRepo.FindUserByEmail(ctx, identity.Email); existing != nil {
+		if !existing.EmailVerified {
+			return nil, nil, ErrOAuthEmailInUse
+		}
+		if _, err := s.linkOAuthIdentity(ctx, existing.ID, identity); err != nil {
+			return nil, nil, err
+		}
+		return existing, nil, nil
+	}
+
+	suggestions, err := s.suggestUsernames(ctx, fullName, identity)
+	if err != nil {
+		return nil, nil, err
+	}
+	if fullName = strings.TrimSpace(fullName); fullName == "" {
+		fullName = identity.Name
+	}
+	// No password: the provider is the only way in until they set one
+	// through a password reset
+	user := &models.User{
+		Username:      suggestions[0],
+		Email:         identity.Email,
+		FullName:      fullName,
+		EmailVerified: true,
+	}
+	user.SetDefaultPrivacySettings()
+	createdUser, err := s.userRepo.CreateUser(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := s.linkOAuthIdentity(ctx, createdUser.ID, identity); err != nil {
+		return nil, nil, err
+	}
+
+	s.enqueueGraphSync(createdUser.ID)
+	if s.search != nil {
+		s.search.UpsertUser(ctx, models.NewSearchUserDocument(createdUser))
+	}
+	return createdUser, suggestions[1:], nil
+}
+
+func (s *AuthService) linkOAuthIdentity(ctx context.Context, userID primitive.ObjectID, identity *oauth.Identity) (*models.OAuthIdentity, error) {
+	now := time.Now()
+	linked := &models.OAuthIdentity{
+		UserID:     userID,
+		Provider:   identity.Provider,
+		Subject:    identity.Subject,
+		Email:      identity.Email,
+		LinkedAt:   now,
+		LastUsedAt: now,
+	}
+	if err := s.oauthIdentities.Create(ctx, linked); errors.Is(err, repository.ErrOAuthIdentityTaken) {
+		return nil, ErrOAuthAlreadyLinked
+	} else if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditActionOAuthLinked,
+		ActorID:    &userID,
+		TargetType: "user",
+		TargetID:   userID.Hex(),
+		Metadata:   map[string]string{"provider": string(identity.Provider)},
+	})
+	return linked, nil
+}
+
+// suggestUsernames returns usernames nobody has or holds reserved, built
+// from the user's name and email, the first to assign and the rest to offer
+func (s *AuthService) suggestUsernames(ctx context.Context, fullName string, identity *oauth.Identity) ([]string, error) {
+	localPart, _, _ := strings.Cut(identity.Email, "@")
+	bases := usernameBases(fullName, identity.Name, localPart)
+
+	var suggestions []string
+	seen := map[string]bool{}
+	try := func(username string) error {
+		if seen[username] || validation.ValidateUsername(username) != nil {
+			return nil
+		}
+		seen[username] = true
+		if u, _ := s.userRepo.FindUserByUserName(ctx, username); u != nil {
+			return nil
+		}
+		holder, err := usernameReservedBy(ctx, s.usernames, username, time.Now())
+		if err != nil {
+			return err
+		}
+		if holder.IsZero() {
+			suggestions = append(suggestions, username)
+		}
+		return nil
+	}
+
+	for _, base := range bases {
+		if err := try(base); err != nil {
+			return nil, err
+		}
+	}
+	for _, base := range bases {
+		for i := 0; i < usernameSuffixAttempts && len(suggestions) < usernameSuggestionCount; i++ {
+			n, err := rand.Int(rand.Reader, big.NewInt(10000))
+			if err != nil {
+				return nil, err
+			}
+			if err := try(fmt.Sprintf("%s%d", base, n.Int64())); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(suggestions) == 0 {
+		return nil, errors.New("could not find a free username")
+	}
+	if len(suggestions) > usernameSuggestionCount {
+		suggestions = suggestions[:usernameSuggestionCount]
+	}
+	return suggestions, nil
+}
+
+// usernameBases turns names into usernames: lowercase letters and digits,
+// with spaces and punctuation dropped or, between words, an underscore
+func usernameBases(names ...string) []string {
+	var bases []string
+	for _, name := range names {
+		var joined, underscored strings.Builder
+		for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		}) {
+			if underscored.Len() > 0 {
+				underscored.WriteByte('_')
+			}
+			joined.WriteString(word)
+			underscored.WriteString(word)
+		}
+		for _, base := range []string{joined.String(), underscored.String()} {
+			base = truncateRunes(base, 24)
+			if len(base) >= 3 && !slices.Contains(bases, base) {
+				bases = append(bases, base)
+			}
+		}
+	}
+	if len(bases) == 0 {
+		bases = append(bases, "user")
+	}
+	return bases
+}
+
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) > n {
+		return strings.TrimRight(string(runes[:n]), "_")
+	}
+	return s
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsernameBases(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{name: "full name", names: []string{"Jane Doe"}, want: []string{"janedoe", "jane_doe"}},
+		{name: "single word", names: []string{"", "Jane", "jane.doe+news"}, want: []string{"jane", "janedoenews", "jane_doe_news"}},
+		{name: "too short", names: []string{"Al", "a."}, want: []string{"user"}},
+		{name: "truncated", names: []string{"Maximilian Alexander Wolfeschlegel"}, want: []string{"maximilianalexanderwolfe", "maximilian_alexander_wol"}},
+		{name: "no names", names: nil, want: []string{"user"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, usernameBases(tt.names...))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidProfile wraps the validation error of a rejected profile update
+var ErrInvalidProfile = errors.New("invalid profile")
+
+// profileViewer is how a viewer relates to the owner of a profile, from the
+// narrowest audience to the widest
+type profileViewer int
+
+const (
+	viewerStranger profileViewer = iota // Signed out, blocked or unrelated
+	viewerFriendOfFriend
+	viewerFriend
+	viewerSelf
+)
+
+// sees reports whether the viewer is in audience; an unset audience is public
+func (v profileViewer) sees(audience models.PrivacySettingType) bool {
+	switch audience {
+	case "", models.PrivacySettingPublic, models.PrivacySettingEveryone:
+		return true
+	case models.PrivacySettingFriendsOfFriends:
+		return v >= viewerFriendOfFriend
+	case models.PrivacySettingFriends:
+		return v >= viewerFriend
+	}
+	// ONLY_ME, and anything unknown
+	return v == viewerSelf
+}
+
+// GetUserProfile returns id's profile as viewerID may see it, with the
+// sections whose audience leaves the viewer out blanked. A zero viewerID is
+// a signed-out viewer. The cached user is shared by all viewers, so profile
+// updates invalidate it like any other change. Views by anyone but the owner
+// count toward the owner's insights.
+func (s *UserService) GetUserProfile(ctx context.Context, id, viewerID primitive.ObjectID) (*models.User, error) {
+	user, err := s.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// A copy, as the cache may still be marshalling user
+	profile := *user
+	redactProfile(&profile, s.profileViewer(ctx, user, viewerID))
+	if s.insights != nil {
+		s.insights.Record(ctx, models.InsightProfileView, id, viewerID, primitive.NilObjectID)
+	}
+	return &profile, nil
+}
+
+// profileViewer works out how viewerID relates to user. Friends of friends
+// are only looked up when a section is shared with them.
+func (s *UserService) profileViewer(ctx context.Context, user *models.User, viewerID primitive.ObjectID) profileViewer {
+	switch {
+	case viewerID.IsZero():
+		return viewerStranger
+	case viewerID == user.ID:
+		return viewerSelf
+	case containsID(user.Blocked, viewerID):
+		return viewerStranger
+	case containsID(user.Friends, viewerID):
+		return viewerFriend
+	case !sharesWithFriendsOfFriends(user.ProfilePrivacy):
+		return viewerStranger
+	}
+
+	viewer, err := s.GetUserByID(ctx, viewerID)
+	if err != nil {
+		return viewerStranger
+	}
+	for _, friendID := range viewer.Friends {
+		if containsID(user.Friends, friendID) {
+			return viewerFriendOfFriend
+		}
+	}
+	return viewerStranger
+}
+
+func sharesWithFriendsOfFriends(p models.ProfilePrivacy) bool {
+	for _, audience := range []models.PrivacySettingType{p.Bio, p.Websites, p.Pronouns, p.Work, p.Education, p.Location} {
+		if audience == models.PrivacySettingFriendsOfFriends {
+			return true
+		}
+	}
+	return false
+}
+
+// redactProfile blanks the sections of user that viewer may not see, and
+// everything private to the owner when viewer is someone else
+func redactProfile(user *models.User, viewer profileViewer) {
+	user.Password = ""
+	if viewer == viewerSelf {
+		return
+	}
+
+	p := user.ProfilePrivacy
+	if !viewer.sees(p.Bio) {
+		user.Bio = ""
+	}
+	if !viewer.sees(p.Websites) {
+		user.Websites = nil
+	}
+	if !viewer.sees(p.Pronouns) {
+		user.Pronouns = ""
+	}
+	if !viewer.sees(p.Work) {
+		user.Work = nil
+	}
+	if !viewer.sees(p.Education) {
+		user.Education = nil
+	}
+	if !viewer.sees(p.Location) {
+		user.Location = ""
+	}
+	user.ProfilePrivacy = models.ProfilePrivacy{}
+}
+
+func containsID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+func TestProfileViewer_Sees(t *testing.T) {
+	tests := []struct {
+		audience models.PrivacySettingType
+		want     map[profileViewer]bool
+	}{
+		{"", map[profileViewer]bool{viewerStranger: true, viewerFriendOfFriend: true, viewerFriend: true, viewerSelf: true}},
+		{models.PrivacySettingPublic, map[profileViewer]bool{viewerStranger: true, viewerFriendOfFriend: true, viewerFriend: true, viewerSelf: true}},
+		{models.PrivacySettingFriendsOfFriends, map[profileViewer]bool{viewerStranger: false, viewerFriendOfFriend: true, viewerFriend: true, viewerSelf: true}},
+		{models.PrivacySettingFriends, map[profileViewer]bool{viewerStranger: false, viewerFriendOfFriend: false, viewerFriend: true, viewerSelf: true}},
+		{models.PrivacySettingOnlyMe, map[profileViewer]bool{viewerStranger: false, viewerFriendOfFriend: false, viewerFriend: false, viewerSelf: true}},
+	}
+
+	for _, tt := range tests {
+		for viewer, want := range tt.want {
+			assert.Equal(t, want, viewer.sees(tt.audience), "audience %q, viewer %d", tt.audience, viewer)
+		}
+	}
+}
+
+func TestUserService_GetUserProfile(t *testing.T) {
+	ownerID := primitive.NewObjectID()
+	friendID := primitive.NewObjectID()
+	mutualID := primitive.NewObjectID()
+	blockedID := primitive.NewObjectID()
+	strangerID := primitive.NewObjectID()
+
+	owner := &models.User{
+		ID:       ownerID,
+		Password: "hash",
+		Bio:      "bio",
+		Pronouns: "they/them",
+		Location: "Dhaka",
+		Websites: []models.ProfileWebsite{{URL: "https://example.com"}},
+		Work:     []models.WorkEntry{{Company: "Acme"}},
+		Friends:  []primitive.ObjectID{friendID, blockedID},
+		Blocked:  []primitive.ObjectID{blockedID},
+		ProfilePrivacy: models.ProfilePrivacy{
+			Pronouns: models.PrivacySettingFriendsOfFriends,
+			Location: models.PrivacySettingFriends,
+			Work:     models.PrivacySettingOnlyMe,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		viewerID     primitive.ObjectID
+		wantPronouns bool
+		wantLocation bool
+		wantWork     bool
+	}{
+		{"signed out", primitive.NilObjectID, false, false, false},
+		{"stranger", strangerID, false, false, false},
+		{"friend of friend", mutualID, true, false, false},
+		{"friend", friendID, true, true, false},
+		{"blocked friend", blockedID, false, false, false},
+		{"self", ownerID, true, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockUserRepository{}
+			mockRepo.FindUserByIDFunc = func(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+				switch id {
+				case ownerID:
+					copied := *owner
+					return &copied, nil
+				case mutualID:
+					return &models.User{ID: id, Friends: []primitive.ObjectID{friendID}}, nil
+				}
+				return &models.User{ID: id}, nil
+			}
+
+			svc := newTestUserService(mockRepo, nil, nil)
+			profile, err := svc.GetUserProfile(context.Background(), ownerID, tt.viewerID)
+
+			require.NoError(t, err)
+			assert.Empty(t, profile.Password)
+			assert.Equal(t, "bio", profile.Bio)
+			assert.NotEmpty(t, profile.Websites)
+			assert.Equal(t, tt.wantPronouns, profile.Pronouns != "")
+			assert.Equal(t, tt.wantLocation, profile.Location != "")
+			assert.Equal(t, tt.wantWork, profile.Work != nil)
+			if tt.viewerID != ownerID {
+				assert.Equal(t, models.ProfilePrivacy{}, profile.ProfilePrivacy)
+			}
+		})
+	}
+}
+
+func TestUserService_UpdateProfileFields(t *testing.T) {
+	t.Run("rejects invalid sections", func(t *testing.T) {
+		mockRepo := &mocks.MockUserRepository{}
+		svc := newTestUserService(mockRepo, nil, nil)
+
+		work := []models.WorkEntry{{Company: "Acme", StartYear: 2020, EndYear: 2010}}
+		_, err := svc.UpdateProfileFields(context.Background(), primitive.NewObjectID(), &models.UpdateProfileRequest{Work: &work})
+
+		assert.ErrorIs(t, err, ErrInvalidProfile)
+		assert.Empty(t, mockRepo.UpdateUserCalls)
+	})
+
+	t.Run("sets sections and merges audiences", func(t *testing.T) {
+		mockRepo := &mocks.MockUserRepository{}
+		svc := newTestUserService(mockRepo, &mocks.MockEventProducer{}, nil)
+
+		pronouns := " she/her "
+		_, err := svc.UpdateProfileFields(context.Background(), primitive.NewObjectID(), &models.UpdateProfileRequest{
+			Pronouns: &pronouns,
+			Website:  "https://example.com",
+			Privacy:  &models.ProfilePrivacy{Pronouns: models.PrivacySettingFriends},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, mockRepo.UpdateUserCalls, 1)
+		update := mockRepo.UpdateUserCalls[0].Update
+		assert.Equal(t, "she/her", update["pronouns"])
+		assert.Equal(t, []models.ProfileWebsite{{URL: "https://example.com"}}, update["websites"])
+		assert.Equal(t, models.PrivacySettingFriends, update["profile_privacy.pronouns"])
+		assert.NotContains(t, update, "profile_privacy.bio")
+	})
+}
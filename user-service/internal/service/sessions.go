@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"user-service/internal/repository"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/middleware"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used, session revoked")
+	ErrSessionNotFound     = errors.New("session not found")
+)
+
+// Reasons recorded on revoked sessions and sent to messaging-app
+const (
+	sessionRevokedByUser = "revoked_by_user"
+	sessionRevokedLogout = "logout"
+	sessionRevokedReuse  = "refresh_token_reuse"
+)
+
+// ListSessions returns the user's signed-in devices, flagging the one with
+// currentSessionID
+func (s *AuthService) ListSessions(ctx context.Context, userID primitive.ObjectID, currentSessionID string) ([]models.Session, error) {
+	sessions, err := s.sessions.ListActive(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID.Hex() == currentSessionID
+	}
+	return sessions, nil
+}
+
+// RevokeSession signs one of the user's devices out. Its refresh token stops
+// working, its access token is rejected and its WebSocket is closed.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID primitive.ObjectID) error {
+	return s.revokeSession(ctx, userID, sessionID, sessionRevokedByUser)
+}
+
+// RevokeAllSessions signs the user out of every device, as after a password
+// reset
+func (s *AuthService) RevokeAllSessions(ctx context.Context, userID primitive.ObjectID, reason string) error {
+	return s.revokeAllSessions(ctx, userID, reason)
+}
+
+func (s *AuthService) startSession(ctx context.Context, userID primitive.ObjectID, device models.DeviceInfo) (*models.Session, error) {
+	tokenID, err := newRefreshTokenID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	session, err := s.sessions.Create(ctx, &models.Session{
+		UserID:         userID,
+		RefreshTokenID: tokenID,
+		DeviceName:     device.Name,
+		UserAgent:      device.UserAgent,
+		IPAddress:      device.IPAddress,
+		CreatedAt:      now,
+		LastUsedAt:     now,
+		ExpiresAt:      now.Add(s.cfg.RefreshTokenTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return session, nil
+}
+
+// rotateSession checks that tokenID is the session's current refresh token and
+// replaces it, returning the new one. An older token, or losing a race with
+// another refresh of the same token, revokes the session.
+func (s *AuthService) rotateSession(ctx context.Context, userID, sessionID primitive.ObjectID, tokenID string, device models.DeviceInfo) (string, error) {
+	session, err := s.sessions.FindByID(ctx, sessionID)
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		return "", ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return "", err
+	}
+	if session.UserID != userID || session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return "", ErrInvalidRefreshToken
+	}
+	if session.RefreshTokenID != tokenID {
+		return "", s.revokeReusedSession(ctx, session)
+	}
+
+	newTokenID, err := newRefreshTokenID()
+	if err != nil {
+		return "", err
+	}
+	rotated, err := s.sessions.Rotate(ctx, sessionID, tokenID, newTokenID, time.Now().Add(s.cfg.RefreshTokenTTL), device)
+	if err != nil {
+		return "", fmt.Errorf("failed to rotate session: %w", err)
+	}
+	if !rotated {
+		return "", s.revokeReusedSession(ctx, session)
+	}
+	return newTokenID, nil
+}
+
+func (s *AuthService) revokeReusedSession(ctx context.Context, session *models.Session) error {
+	log.Printf("Refresh token reuse detected for user %s, revoking session %s", session.UserID.Hex(), session.ID.Hex())
+	if err := s.revokeSession(ctx, session.UserID, session.ID, sessionRevokedReuse); err != nil && !errors.Is(err, ErrSessionNotFound) {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// revokeSession marks the session revoked, blacklists it for the lifetime of
+// any access token already issued to it and tells messaging-app to close the
+// device's WebSocket
+func (s *AuthService) revokeSession(ctx context.Context, userID, sessionID primitive.ObjectID, reason string) error {
+	err := s.sessions.Revoke(ctx, userID, sessionID, reason)
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	if s.redisClient == nil {
+		return nil
+	}
+
+	if err := s.redisClient.Set(ctx, middleware.RevokedSessionKey(sessionID.Hex()), reason, s.cfg.AccessTokenTTL).Err(); err != nil {
+		return fmt.Errorf("failed to blacklist session: %w", err)
+	}
+	payload, err := json.Marshal(models.SessionRevokedEvent{
+		UserID:    userID.Hex(),
+		SessionID: sessionID.Hex(),
+		Reason:    reason,
+	})
+	if err == nil {
+		err = s.redisClient.Publish(ctx, models.SessionRevokedChannel, payload).Err()
+	}
+	if err != nil {
+		// The session is already unusable; only the open WebSocket lingers
+		log.Printf("Failed to announce revoked session %s: %v", sessionID.Hex(), err)
+	}
+	return nil
+}
+
+func newRefreshTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/config"
+	"user-service/internal/service/mocks"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newTestSessionAuthService() (*AuthService, *mocks.MockSessionRepository) {
+	sessions := mocks.NewMockSessionRepository()
+	return &AuthService{
+		sessions: sessions,
+		cfg:      &config.Config{RefreshTokenTTL: time.Hour, AccessTokenTTL: time.Minute},
+	}, sessions
+}
+
+func TestAuthService_RotateSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rotates the refresh token", func(t *testing.T) {
+		svc, sessions := newTestSessionAuthService()
+		userID := primitive.NewObjectID()
+		session, err := svc.startSession(ctx, userID, models.DeviceInfo{Name: "phone"})
+		require.NoError(t, err)
+		first := session.RefreshTokenID
+
+		second, err := svc.rotateSession(ctx, userID, session.ID, first, models.DeviceInfo{})
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+		assert.Equal(t, second, sessions.Sessions[session.ID].RefreshTokenID)
+
+		_, err = svc.rotateSession(ctx, userID, session.ID, second, models.DeviceInfo{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("revokes the session when an old token is reused", func(t *testing.T) {
+		svc, sessions := newTestSessionAuthService()
+		userID := primitive.NewObjectID()
+		session, err := svc.startSession(ctx, userID, models.DeviceInfo{})
+		require.NoError(t, err)
+		stolen := session.RefreshTokenID
+
+		current, err := svc.rotateSession(ctx, userID, session.ID, stolen, models.DeviceInfo{})
+		require.NoError(t, err)
+
+		_, err = svc.rotateSession(ctx, userID, session.ID, stolen, models.DeviceInfo{})
+		assert.ErrorIs(t, err, ErrRefreshTokenReused)
+		require.NotNil(t, sessions.Sessions[session.ID].RevokedAt)
+		assert.Equal(t, sessionRevokedReuse, sessions.Sessions[session.ID].RevokedReason)
+
+		_, err = svc.rotateSession(ctx, userID, session.ID, current, models.DeviceInfo{})
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken, "the legitimate token dies with the session")
+	})
+
+	t.Run("rejects another user's session", func(t *testing.T) {
+		svc, _ := newTestSessionAuthService()
+		session, err := svc.startSession(ctx, primitive.NewObjectID(), models.DeviceInfo{})
+		require.NoError(t, err)
+
+		_, err = svc.rotateSession(ctx, primitive.NewObjectID(), session.ID, session.RefreshTokenID, models.DeviceInfo{})
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+
+	t.Run("rejects an unknown session", func(t *testing.T) {
+		svc, _ := newTestSessionAuthService()
+
+		_, err := svc.rotateSession(ctx, primitive.NewObjectID(), primitive.NewObjectID(), "token", models.DeviceInfo{})
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+}
+
+func TestAuthService_Sessions(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestSessionAuthService()
+	userID := primitive.NewObjectID()
+	laptop, err := svc.startSession(ctx, userID, models.DeviceInfo{Name: "laptop"})
+	require.NoError(t, err)
+	phone, err := svc.startSession(ctx, userID, models.DeviceInfo{Name: "phone"})
+	require.NoError(t, err)
+
+	list, err := svc.ListSessions(ctx, userID, laptop.ID.Hex())
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	for _, s := range list {
+		assert.Equal(t, s.ID == laptop.ID, s.Current)
+	}
+
+	assert.ErrorIs(t, svc.RevokeSession(ctx, primitive.NewObjectID(), phone.ID), ErrSessionNotFound, "only the owner can revoke")
+	require.NoError(t, svc.RevokeSession(ctx, userID, phone.ID))
+	assert.ErrorIs(t, svc.RevokeSession(ctx, userID, phone.ID), ErrSessionNotFound)
+
+	list, err = svc.ListSessions(ctx, userID, laptop.ID.Hex())
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, laptop.ID, list[0].ID)
+}
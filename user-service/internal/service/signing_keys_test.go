@@ -0,0 +1,78 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"user-service/config"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/jwks"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/middleware"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func generateSigningKey(t *testing.T) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestAuthService_SigningKeyRotation(t *testing.T) {
+	cfg := &config.Config{JWTSecret: "secret", AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour, LegacyHS256Until: time.Now().Add(time.Hour)}
+	user := &models.User{ID: primitive.NewObjectID(), Email: "jane@example.com"}
+	sessionID := primitive.NewObjectID()
+	oldKey, newKey := generateSigningKey(t), generateSigningKey(t)
+
+	// Before the switch, tokens are signed with the shared secret
+	svc := &AuthService{cfg: cfg}
+	legacyToken, _, err := svc.generateTokens(user, sessionID, "jti")
+	require.NoError(t, err)
+
+	oldRing, err := jwks.NewKeyRing(oldKey, "")
+	require.NoError(t, err)
+	svc.SetSigningKeys(oldRing)
+	oldToken, _, err := svc.generateTokens(user, sessionID, "jti")
+	require.NoError(t, err)
+
+	// Rotated: the new key signs while the old one stays published
+	newRing, err := jwks.NewKeyRing(newKey, oldKey)
+	require.NoError(t, err)
+	svc.SetSigningKeys(newRing)
+	newToken, _, err := svc.generateTokens(user, sessionID, "jti")
+	require.NoError(t, err)
+
+	parsed, err := jwt.Parse(newToken, svc.accessTokenKeyfunc())
+	require.NoError(t, err)
+	assert.Equal(t, "RS256", parsed.Method.Alg())
+	assert.NotEmpty(t, parsed.Header["kid"])
+	for _, token := range []string{legacyToken, oldToken} {
+		_, err := jwt.Parse(token, svc.accessTokenKeyfunc())
+		assert.NoError(t, err, "tokens signed before the rotation verify until they expire")
+	}
+
+	// Once the migration window closes the shared secret no longer verifies
+	cfg.LegacyHS256Until = time.Now().Add(-time.Minute)
+	_, err = jwt.Parse(legacyToken, svc.accessTokenKeyfunc())
+	assert.ErrorIs(t, err, middleware.ErrLegacyTokenRejected)
+	cfg.LegacyHS256Until = time.Time{}
+	_, err = jwt.Parse(legacyToken, svc.accessTokenKeyfunc())
+	assert.ErrorIs(t, err, middleware.ErrLegacyTokenRejected, "off unless configured")
+
+	// Once the old key is retired its tokens stop verifying
+	retired, err := jwks.NewKeyRing(newKey, "")
+	require.NoError(t, err)
+	svc.SetSigningKeys(retired)
+	_, err = jwt.Parse(oldToken, svc.accessTokenKeyfunc())
+	assert.ErrorIs(t, err, jwks.ErrKeyNotFound)
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"user-service/internal/repository"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrCannotDismissSelf = errors.New("cannot dismiss yourself")
+
+const (
+	defaultSuggestionLimit = 20
+	maxSuggestionLimit     = 50
+
+	// suggestionPoolSize candidates are ranked and cached per user, so paging
+	// through suggestions never goes back to Neo4j
+	suggestionPoolSize = 200
+
+	defaultSuggestionCacheTTL = 10 * time.Minute
+)
+
+// SuggestionWeights sets how much each kind of shared connection adds to a
+// suggestion's score
+type SuggestionWeights struct {
+	MutualFriend    float64
+	SharedCommunity float64
+	SharedEvent     float64
+}
+
+func DefaultSuggestionWeights() SuggestionWeights {
+	return SuggestionWeights{
+		MutualFriend:    3,
+		SharedCommunity: 1.5,
+		SharedEvent:     1,
+	}
+}
+
+// Score ranks a candidate. Each signal grows logarithmically, so ten shared
+// events cannot outweigh a handful of mutual friends.
+func (w SuggestionWeights) Score(c repository.SuggestionCandidate) float64 {
+	return w.MutualFriend*math.Log1p(float64(c.MutualFriends)) +
+		w.SharedCommunity*math.Log1p(float64(c.SharedCommunities)) +
+		w.SharedEvent*math.Log1p(float64(c.SharedEvents))
+}
+
+type rankedCandidate struct {
+	repository.SuggestionCandidate
+	Score float64 `json:"score"`
+}
+
+// SuggestionService builds "People You May Know" lists from the social graph.
+// Ranked candidates are cached in Redis; profiles are loaded per request so
+// name and avatar changes show up immediately.
+type SuggestionService struct {
+	graph       SuggestionGraph
+	userRepo    UserRepository
+	redisClient redis.UniversalClient
+	cacheTTL    time.Duration
+	weights     SuggestionWeights
+	logger      *slog.Logger
+}
+
+func NewSuggestionService(graph SuggestionGraph, userRepo UserRepository, redisClient redis.UniversalClient, cacheTTL time.Duration, logger *slog.Logger) *SuggestionService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cacheTTL <= 0 {
+		cacheTTL = defaultSuggestionCacheTTL
+	}
+	return &SuggestionService{
+		graph:       graph,
+		userRepo:    userRepo,
+		redisClient: redisClient,
+		cacheTTL:    cacheTTL,
+		weights:     DefaultSuggestionWeights(),
+		logger:      logger,
+	}
+}
+
+// SetWeights replaces the default scoring weights
+func (s *SuggestionService) SetWeights(weights SuggestionWeights) {
+	s.weights = weights
+}
+
+// Suggest returns up to limit people userID may know, best match first
+func (s *SuggestionService) Suggest(ctx context.Context, userID primitive.ObjectID, limit int) ([]models.FriendSuggestion, error) {
+	if limit <= 0 {
+		limit = defaultSuggestionLimit
+	}
+	limit = min(limit, maxSuggestionLimit)
+
+	ranked, err := s.ranked(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	ranked = ranked[:min(limit, len(ranked))]
+
+	ids := make([]primitive.ObjectID, 0, len(ranked))
+	byID := make(map[primitive.ObjectID]rankedCandidate, len(ranked))
+	for _, c := range ranked {
+		if id, err := primitive.ObjectIDFromHex(c.UserID); err == nil {
+			ids = append(ids, id)
+			byID[id] = c
+		}
+	}
+	users, err := shortUsers(ctx, s.userRepo, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]models.FriendSuggestion, 0, len(users))
+	for _, u := range users {
+		c := byID[u.ID]
+		suggestions = append(suggestions, models.FriendSuggestion{
+			User:              u,
+			MutualFriends:     c.MutualFriends,
+			SharedCommunities: c.SharedCommunities,
+			SharedEvents:      c.SharedEvents,
+			Score:             c.Score,
+		})
+	}
+	return suggestions, nil
+}
+
+// Dismiss stops targetID from ever being suggested to userID again
+func (s *SuggestionService) Dismiss(ctx context.Context, userID, targetID primitive.ObjectID) error {
+	if userID == targetID {
+		return ErrCannotDismissSelf
+	}
+	if _, err := s.userRepo.FindUserByID(ctx, targetID); err != nil {
+		return ErrUserNotFound
+	}
+	if err := s.graph.DismissSuggestion(ctx, userID, targetID); err != nil {
+		return fmt.Errorf("failed to dismiss suggestion: %w", err)
+	}
+	s.Invalidate(ctx, userID)
+	return nil
+}
+
+// Restore undoes a dismissal so targetID can be suggested again
+func (s *SuggestionService) Restore(ctx context.Context, userID, targetID primitive.ObjectID) error {
+	if err := s.graph.RestoreSuggestion(ctx, userID, targetID); err != nil {
+		return fmt.Errorf("failed to restore suggestion: %w", err)
+	}
+	s.Invalidate(ctx, userID)
+	return nil
+}
+
+// ListDismissed returns the users userID asked not to be shown again
+func (s *SuggestionService) ListDismissed(ctx context.Context, userID primitive.ObjectID) ([]models.UserShortResponse, error) {
+	hexIDs, err := s.graph.GetDismissedIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dismissed suggestions: %w", err)
+	}
+	ids := make([]primitive.ObjectID, 0, len(hexIDs))
+	for _, hex := range hexIDs {
+		if id, err := primitive.ObjectIDFromHex(hex); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return shortUsers(ctx, s.userRepo, ids)
+}
+
+// Invalidate drops the cached suggestions of each user
+func (s *SuggestionService) Invalidate(ctx context.Context, userIDs ...primitive.ObjectID) {
+	if s.redisClient == nil || len(userIDs) == 0 {
+		return
+	}
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = suggestionCacheKey(id)
+	}
+	if err := s.redisClient.Del(ctx, keys...).Err(); err != nil {
+		s.logger.Warn("Failed to invalidate friend suggestions", "error", err)
+	}
+}
+
+// ranked returns the scored candidate pool for userID, from cache if possible
+func (s *SuggestionService) ranked(ctx context.Context, userID primitive.ObjectID) ([]rankedCandidate, error) {
+	key := suggestionCacheKey(userID)
+	if s.redisClient != nil {
+		if val, err := s.redisClient.Get(ctx, key).Result(); err == nil {
+			var cached []rankedCandidate
+			if err := json.Unmarshal([]byte(val), &cached); err == nil {
+				return cached, nil
+			}
+		}
+	}
+
+	candidates, err := s.graph.SuggestionCandidates(ctx, userID, suggestionPoolSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load suggestions: %w", err)
+	}
+	ranked := make([]rankedCandidate, len(candidates))
+	for i, c := range candidates {
+		ranked[i] = rankedCandidate{SuggestionCandidate: c, Score: s.weights.Score(c)}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+
+	if s.redisClient != nil {
+		if data, err := json.Marshal(ranked); err == nil {
+			if err := s.redisClient.Set(ctx, key, data, s.cacheTTL).Err(); err != nil {
+				s.logger.Warn("Failed to cache friend suggestions", "user_id", userID.Hex(), "error", err)
+			}
+		}
+	}
+	return ranked, nil
+}
+
+func suggestionCacheKey(userID primitive.ObjectID) string {
+	return "user:suggestions:" + userID.Hex()
+}
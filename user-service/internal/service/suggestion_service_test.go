@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"user-service/internal/repository"
+	"user-service/internal/service/mocks"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newTestSuggestionService() (*SuggestionService, *mocks.MockSuggestionGraph, *mocks.MockUserRepository) {
+	graph := mocks.NewMockSuggestionGraph()
+	users := &mocks.MockUserRepository{}
+	return NewSuggestionService(graph, users, nil, 0, slog.Default()), graph, users
+}
+
+func TestSuggestionWeights_Score(t *testing.T) {
+	w := DefaultSuggestionWeights()
+
+	assert.Zero(t, w.Score(repository.SuggestionCandidate{}))
+	assert.Greater(t,
+		w.Score(repository.SuggestionCandidate{MutualFriends: 2}),
+		w.Score(repository.SuggestionCandidate{SharedEvents: 2}),
+		"a mutual friend counts for more than a shared event")
+	assert.Greater(t,
+		w.Score(repository.SuggestionCandidate{MutualFriends: 3}),
+		w.Score(repository.SuggestionCandidate{SharedEvents: 10}),
+		"signals have diminishing returns")
+}
+
+func TestSuggestionService_Suggest(t *testing.T) {
+	t.Run("ranks candidates by score and skips invalid IDs", func(t *testing.T) {
+		svc, graph, _ := newTestSuggestionService()
+		events, friends, both := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+		graph.Candidates = []repository.SuggestionCandidate{
+			{UserID: events.Hex(), SharedEvents: 2},
+			{UserID: "not-an-id", MutualFriends: 9},
+			{UserID: friends.Hex(), MutualFriends: 2},
+			{UserID: both.Hex(), MutualFriends: 2, SharedCommunities: 1},
+		}
+
+		suggestions, err := svc.Suggest(context.Background(), primitive.NewObjectID(), 0)
+		require.NoError(t, err)
+		require.Len(t, suggestions, 3)
+		assert.Equal(t, both, suggestions[0].User.ID)
+		assert.Equal(t, friends, suggestions[1].User.ID)
+		assert.Equal(t, events, suggestions[2].User.ID)
+		assert.Equal(t, 2, suggestions[0].MutualFriends)
+		assert.Equal(t, 1, suggestions[0].SharedCommunities)
+		assert.Greater(t, suggestions[0].Score, suggestions[1].Score)
+	})
+
+	t.Run("caps the limit", func(t *testing.T) {
+		svc, graph, _ := newTestSuggestionService()
+		for i := 0; i < maxSuggestionLimit+10; i++ {
+			graph.Candidates = append(graph.Candidates, repository.SuggestionCandidate{
+				UserID:        primitive.NewObjectID().Hex(),
+				MutualFriends: 1,
+			})
+		}
+
+		suggestions, err := svc.Suggest(context.Background(), primitive.NewObjectID(), 1000)
+		require.NoError(t, err)
+		assert.Len(t, suggestions, maxSuggestionLimit)
+	})
+
+	t.Run("drops users whose profile is gone", func(t *testing.T) {
+		svc, graph, users := newTestSuggestionService()
+		kept, gone := primitive.NewObjectID(), primitive.NewObjectID()
+		graph.Candidates = []repository.SuggestionCandidate{
+			{UserID: gone.Hex(), MutualFriends: 5},
+			{UserID: kept.Hex(), MutualFriends: 1},
+		}
+		users.FindUsersByIDsFunc = func(ctx context.Context, ids []primitive.ObjectID) ([]models.User, error) {
+			return []models.User{{ID: kept, Username: "kept"}}, nil
+		}
+
+		suggestions, err := svc.Suggest(context.Background(), primitive.NewObjectID(), 10)
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		assert.Equal(t, kept, suggestions[0].User.ID)
+	})
+}
+
+func TestSuggestionService_Dismiss(t *testing.T) {
+	t.Run("records the dismissal", func(t *testing.T) {
+		svc, graph, _ := newTestSuggestionService()
+		user, target := primitive.NewObjectID(), primitive.NewObjectID()
+
+		require.NoError(t, svc.Dismiss(context.Background(), user, target))
+		assert.Equal(t, []primitive.ObjectID{target}, graph.Dismissed[user])
+
+		dismissed, err := svc.ListDismissed(context.Background(), user)
+		require.NoError(t, err)
+		require.Len(t, dismissed, 1)
+		assert.Equal(t, target, dismissed[0].ID)
+
+		require.NoError(t, svc.Restore(context.Background(), user, target))
+		assert.Empty(t, graph.Dismissed[user])
+	})
+
+	t.Run("rejects dismissing yourself", func(t *testing.T) {
+		svc, _, _ := newTestSuggestionService()
+		id := primitive.NewObjectID()
+
+		assert.ErrorIs(t, svc.Dismiss(context.Background(), id, id), ErrCannotDismissSelf)
+	})
+
+	t.Run("rejects an unknown user", func(t *testing.T) {
+		svc, graph, users := newTestSuggestionService()
+		users.FindUserByIDFunc = func(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+			return nil, errors.New("not found")
+		}
+		user := primitive.NewObjectID()
+
+		assert.ErrorIs(t, svc.Dismiss(context.Background(), user, primitive.NewObjectID()), ErrUserNotFound)
+		assert.Empty(t, graph.Dismissed[user])
+	})
+}
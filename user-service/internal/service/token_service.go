@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidToken is returned for a token that is malformed, forged, expired,
+// superseded or already used
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// TokenPurpose keeps a token issued for one flow from being used in another
+type TokenPurpose string
+
+const (
+	TokenEmailVerification TokenPurpose = "email_verify"
+	TokenPasswordReset     TokenPurpose = "password_reset"
+)
+
+var tokenEncoding = base64.RawURLEncoding
+
+// TokenService issues signed, single-use tokens for links mailed to users.
+// A token is "<user id>.<nonce>.<signature>": the HMAC signature rejects
+// forged tokens without a lookup, and Redis holds only a hash of the nonce,
+// under one key per user and purpose. Issuing a token supersedes the user's
+// previous one for the same purpose, and consuming it deletes the key.
+type TokenService struct {
+	redisClient redis.UniversalClient
+	secret      []byte
+}
+
+func NewTokenService(redisClient redis.UniversalClient, secret string) *TokenService {
+	return &TokenService{redisClient: redisClient, secret: []byte(secret)}
+}
+
+// Issue returns a token for userID valid for ttl. subject, such as the email
+// address being verified, comes back from Consume.
+func (s *TokenService) Issue(ctx context.Context, purpose TokenPurpose, userID primitive.ObjectID, subject string, ttl time.Duration) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	nonce := tokenEncoding.EncodeToString(buf)
+	payload := userID.Hex() + "." + nonce
+
+	if err := s.redisClient.Set(ctx, tokenKey(purpose, userID), hashNonce(nonce)+":"+subject, ttl).Err(); err != nil {
+		return "", fmt.Errorf("failed to store token: %w", err)
+	}
+	return payload + "." + s.sign(purpose, payload), nil
+}
+
+// Consume checks token and uses it up, returning who it was issued to and
+// its subject
+func (s *TokenService) Consume(ctx context.Context, purpose TokenPurpose, token string) (primitive.ObjectID, string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return primitive.NilObjectID, "", ErrInvalidToken
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(purpose, payload))) {
+		return primitive.NilObjectID, "", ErrInvalidToken
+	}
+	userID, err := primitive.ObjectIDFromHex(parts[0])
+	if err != nil {
+		return primitive.NilObjectID, "", ErrInvalidToken
+	}
+
+	key := tokenKey(purpose, userID)
+	stored, err := s.redisClient.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return primitive.NilObjectID, "", ErrInvalidToken
+	}
+	if err != nil {
+		return primitive.NilObjectID, "", err
+	}
+	hash, subject, _ := strings.Cut(stored, ":")
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(hashNonce(parts[1]))) != 1 {
+		// Superseded by a newer token
+		return primitive.NilObjectID, "", ErrInvalidToken
+	}
+	if n, err := s.redisClient.Del(ctx, key).Result(); err != nil || n == 0 {
+		// Another request already used this token
+		return primitive.NilObjectID, "", ErrInvalidToken
+	}
+	return userID, subject, nil
+}
+
+// Revoke invalidates the user's outstanding token for purpose, if any
+func (s *TokenService) Revoke(ctx context.Context, purpose TokenPurpose, userID primitive.ObjectID) error {
+	return s.redisClient.Del(ctx, tokenKey(purpose, userID)).Err()
+}
+
+func (s *TokenService) sign(purpose TokenPurpose, payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(string(purpose) + ":" + payload))
+	return tokenEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func hashNonce(nonce string) string {
+	sum := sha256.Sum256([]byte(nonce))
+	return hex.EncodeToString(sum[:])
+}
+
+func tokenKey(purpose TokenPurpose, userID primitive.ObjectID) string {
+	return "token:" + string(purpose) + ":" + userID.Hex()
+}
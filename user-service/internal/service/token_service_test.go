@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"user-service/internal/service/mocks"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newKeyValueRedis returns a mock Redis whose Get, Set and Del share one store
+func newKeyValueRedis() *mocks.MockRedisClient {
+	store := map[string]string{}
+	return &mocks.MockRedisClient{
+		GetFunc: func(ctx context.Context, key string) *redis.StringCmd {
+			cmd := redis.NewStringCmd(ctx)
+			if v, ok := store[key]; ok {
+				cmd.SetVal(v)
+			} else {
+				cmd.SetErr(redis.Nil)
+			}
+			return cmd
+		},
+		SetFunc: func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+			switch v := value.(type) {
+			case string:
+				store[key] = v
+			case []byte:
+				store[key] = string(v)
+			}
+			return redis.NewStatusCmd(ctx)
+		},
+		DelFunc: func(ctx context.Context, keys ...string) *redis.IntCmd {
+			var n int64
+			for _, key := range keys {
+				if _, ok := store[key]; ok {
+					delete(store, key)
+					n++
+				}
+			}
+			cmd := redis.NewIntCmd(ctx)
+			cmd.SetVal(n)
+			return cmd
+		},
+	}
+}
+
+func TestTokenService_IssueAndConsume(t *testing.T) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+	tokens := NewTokenService(newKeyValueRedis(), "secret")
+
+	token, err := tokens.Issue(ctx, TokenPasswordReset, userID, "jane@example.com", time.Hour)
+	require.NoError(t, err)
+
+	// Signed for one purpose only
+	_, _, err = tokens.Consume(ctx, TokenEmailVerification, token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	gotID, subject, err := tokens.Consume(ctx, TokenPasswordReset, token)
+	require.NoError(t, err)
+	assert.Equal(t, userID, gotID)
+	assert.Equal(t, "jane@example.com", subject)
+
+	// Single use
+	_, _, err = tokens.Consume(ctx, TokenPasswordReset, token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestTokenService_RejectsForgedAndSuperseded(t *testing.T) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+	tokens := NewTokenService(newKeyValueRedis(), "secret")
+
+	first, err := tokens.Issue(ctx, TokenEmailVerification, userID, "jane@example.com", time.Hour)
+	require.NoError(t, err)
+	second, err := tokens.Issue(ctx, TokenEmailVerification, userID, "jane@example.com", time.Hour)
+	require.NoError(t, err)
+
+	_, _, err = tokens.Consume(ctx, TokenEmailVerification, first)
+	assert.ErrorIs(t, err, ErrInvalidToken, "superseded by the second token")
+
+	parts := strings.Split(second, ".")
+	other := primitive.NewObjectID().Hex()
+	for _, forged := range []string{
+		"",
+		"garbage",
+		other + "." + parts[1] + "." + parts[2],
+		parts[0] + "." + parts[1] + "." + parts[2] + "x",
+	} {
+		_, _, err := tokens.Consume(ctx, TokenEmailVerification, forged)
+		assert.ErrorIs(t, err, ErrInvalidToken, forged)
+	}
+
+	forgedBySomeoneElse, err := NewTokenService(newKeyValueRedis(), "other-secret").Issue(ctx, TokenEmailVerification, userID, "jane@example.com", time.Hour)
+	require.NoError(t, err)
+	_, _, err = tokens.Consume(ctx, TokenEmailVerification, forgedBySomeoneElse)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, _, err = tokens.Consume(ctx, TokenEmailVerification, second)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"user-service/internal/totp"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrTwoFactorUnavailable     = errors.New("two-factor authentication is not available")
+	ErrTwoFactorAlreadyEnabled  = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled      = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorSetupRequired   = errors.New("two-factor authentication must be enabled through setup")
+	ErrTwoFactorSetupExpired    = errors.New("two-factor setup expired, start again")
+	ErrInvalidTwoFactorCode     = errors.New("invalid two-factor code")
+	ErrInvalidPreAuthToken      = errors.New("invalid or expired pre-auth token")
+	ErrTooManyTwoFactorAttempts = errors.New("too many two-factor attempts, try again later")
+)
+
+const (
+	recoveryCodeCount = 10
+	twoFactorSetupTTL = 10 * time.Minute
+	// A TOTP code stays valid for one step either side of its own
+	usedCodeTTL = 3 * totp.Period
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// BeginTwoFactorSetup generates a TOTP secret for the user to add to an
+// authenticator app. It only takes effect once EnableTwoFactor confirms a
+// code from it.
+func (s *AuthService) BeginTwoFactorSetup(ctx context.Context, userID primitive.ObjectID) (*models.TwoFactorSetupResponse, error) {
+	if !s.cfg.TwoFactorEnabled {
+		return nil, ErrTwoFactorUnavailable
+	}
+	user, err := s.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.TwoFactorEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.redisClient.Set(ctx, twoFactorSetupKey(userID), secret, twoFactorSetupTTL).Err(); err != nil {
+		return nil, err
+	}
+
+	return &models.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURL: totp.URI(s.cfg.TwoFactorIssuer, user.Email, secret),
+	}, nil
+}
+
+// EnableTwoFactor turns on two-factor authentication once the user proves
+// their authenticator app works, and returns their first recovery codes
+func (s *AuthService) EnableTwoFactor(ctx context.Context, userID primitive.ObjectID, code string) (*models.RecoveryCodesResponse, error) {
+	if !s.cfg.TwoFactorEnabled {
+		return nil, ErrTwoFactorUnavailable
+	}
+	if err := s.checkTwoFactorAttempts(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	secret, err := s.redisClient.Get(ctx, twoFactorSetupKey(userID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrTwoFactorSetupExpired
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateTOTP(ctx, userID, secret, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.UpdateUser(ctx, userID, bson.M{
+		"two_factor_enabled": true,
+		"two_factor_secret":  secret,
+		"recovery_codes":     hashes,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	s.redisClient.Del(ctx, twoFactorSetupKey(userID))
+	s.resetTwoFactorAttempts(ctx, userID)
+	return &models.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off. It takes a current
+// TOTP code or a recovery code, so a stolen session alone cannot do it.
+func (s *AuthService) DisableTwoFactor(ctx context.Context, userID primitive.ObjectID, code string) error {
+	user, err := s.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if !user.TwoFactorEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+	if err := s.verifySecondFactor(ctx, user, code, true); err != nil {
+		return err
+	}
+	return s.clearTwoFactor(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces every recovery code, invalidating the old
+// ones. It takes a current TOTP code.
+func (s *AuthService) RegenerateRecoveryCodes(ctx context.Context, userID primitive.ObjectID, code string) (*models.RecoveryCodesResponse, error) {
+	user, err := s.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if !user.TwoFactorEnabled {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if err := s.verifySecondFactor(ctx, user, code, false); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.UpdateUser(ctx, userID, bson.M{"recovery_codes": hashes}); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+	return &models.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// ResetTwoFactor turns two-factor authentication off without a code, for
+// admins helping a user who lost both their device and recovery codes
+func (s *AuthService) ResetTwoFactor(ctx context.Context, adminID, userID primitive.ObjectID) error {
+	if _, err := s.userRepo.FindUserByID(ctx, userID); err != nil {
+		return ErrUserNotFound
+	}
+	if err := s.clearTwoFactor(ctx, userID); err != nil {
+		return err
+	}
+	s.resetTwoFactorAttempts(ctx, userID)
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditActionTwoFactorReset,
+		ActorID:    &adminID,
+		TargetType: "user",
+		TargetID:   userID.Hex(),
+	})
+	return nil
+}
+
+// CompleteTwoFactorLogin exchanges the pre-auth token from Login and a TOTP
+// or recovery code for access and refresh tokens
+func (s *AuthService) CompleteTwoFactorLogin(ctx context.Context, preAuthToken, code string, device models.DeviceInfo) (*models.AuthResponse, error) {
+	tokenKey := preAuthKey(preAuthToken)
+	userIDStr, err := s.redisClient.Get(ctx, tokenKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidPreAuthToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		return nil, ErrInvalidPreAuthToken
+	}
+	user, err := s.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, ErrInvalidPreAuthToken
+	}
+	if err := signInError(user, time.Now()); err != nil {
+		s.redisClient.Del(ctx, tokenKey)
+		return nil, err
+	}
+
+	if err := s.verifySecondFactor(ctx, user, code, true); err != nil {
+		s.auditLogin(ctx, user, device, err.Error(), nil)
+		if errors.Is(err, ErrTooManyTwoFactorAttempts) {
+			// Make the user start over with their password
+			s.redisClient.Del(ctx, tokenKey)
+		}
+		return nil, err
+	}
+	if n, err := s.redisClient.Del(ctx, tokenKey).Result(); err != nil || n == 0 {
+		// Another request already used this token
+		return nil, ErrInvalidPreAuthToken
+	}
+
+	s.auditLogin(ctx, user, device, "", map[string]string{"second_factor": "true"})
+	return s.issueTokens(ctx, user, device)
+}
+
+// beginTwoFactorLogin answers a correct password on a 2FA account with a
+// short-lived, single-use pre-auth token instead of session tokens
+func (s *AuthService) beginTwoFactorLogin(ctx context.Context, user *models.User) (*models.AuthResponse, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(buf)
+	if err := s.redisClient.Set(ctx, preAuthKey(token), user.ID.Hex(), s.cfg.TwoFactorPreAuthTTL).Err(); err != nil {
+		return nil, err
+	}
+	return &models.AuthResponse{
+		TwoFactorRequired: true,
+		PreAuthToken:      token,
+	}, nil
+}
+
+// verifySecondFactor checks a TOTP code or, when allowRecovery is set, a
+// recovery code, counting the attempt against the user's limit
+func (s *AuthService) verifySecondFactor(ctx context.Context, user *models.User, code string, allowRecovery bool) error {
+	if err := s.checkTwoFactorAttempts(ctx, user.ID); err != nil {
+		return err
+	}
+
+	err := s.validateTOTP(ctx, user.ID, user.TwoFactorSecret, code)
+	if errors.Is(err, ErrInvalidTwoFactorCode) && allowRecovery {
+		var ok bool
+		ok, err = s.userRepo.ConsumeRecoveryCode(ctx, user.ID, hashRecoveryCode(code))
+		if err == nil && !ok {
+			err = ErrInvalidTwoFactorCode
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	s.resetTwoFactorAttempts(ctx, user.ID)
+	return nil
+}
+
+// validateTOTP checks code against secret and refuses a code that was
+// already used, so an intercepted code cannot be replayed
+func (s *AuthService) validateTOTP(ctx context.Context, userID primitive.ObjectID, secret, code string) error {
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+	fresh, err := s.redisClient.SetNX(ctx, fmt.Sprintf("2fa:used:%s:%d", userID.Hex(), step), 1, usedCodeTTL).Result()
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// checkTwoFactorAttempts counts an attempt and refuses it once the user has
+// made too many within the window
+func (s *AuthService) checkTwoFactorAttempts(ctx context.Context, userID primitive.ObjectID) error {
+	key := twoFactorAttemptsKey(userID)
+	attempts, err := s.redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if attempts == 1 {
+		s.redisClient.Expire(ctx, key, s.cfg.TwoFactorAttemptWindow)
+	}
+	if attempts > int64(s.cfg.TwoFactorMaxAttempts) {
+		return ErrTooManyTwoFactorAttempts
+	}
+	return nil
+}
+
+func (s *AuthService) resetTwoFactorAttempts(ctx context.Context, userID primitive.ObjectID) {
+	s.redisClient.Del(ctx, twoFactorAttemptsKey(userID))
+}
+
+func (s *AuthService) clearTwoFactor(ctx context.Context, userID primitive.ObjectID) error {
+	if _, err := s.userRepo.UpdateUser(ctx, userID, bson.M{
+		"two_factor_enabled": false,
+		"two_factor_secret":  "",
+		"recovery_codes":     []string{},
+	}); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+	return nil
+}
+
+// generateRecoveryCodes returns codes to show the user once and the hashes
+// to store in their place
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	buf := make([]byte, 5)
+	for i := range codes {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(recoveryEncoding.EncodeToString(buf))
+		codes[i] = raw[:4] + "-" + raw[4:]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode ignores case, spaces and dashes so codes can be typed
+// however they were written down
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+func twoFactorSetupKey(userID primitive.ObjectID) string {
+	return "2fa:setup:" + userID.Hex()
+}
+
+func twoFactorAttemptsKey(userID primitive.ObjectID) string {
+	return "2fa:attempts:" + userID.Hex()
+}
+
+func preAuthKey(token string) string {
+	return "2fa:preauth:" + token
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes, err := generateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+	require.Len(t, hashes, recoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		assert.Regexp(t, `^[a-z2-7]{4}-[a-z2-7]{4}$`, code)
+		assert.Equal(t, hashRecoveryCode(code), hashes[i])
+		assert.NotContains(t, hashes[i], code, "only hashes are stored")
+		assert.False(t, seen[code], "codes are unique")
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCode_Normalizes(t *testing.T) {
+	want := hashRecoveryCode("abcd-efgh")
+	assert.Equal(t, want, hashRecoveryCode(" ABCD-EFGH "))
+	assert.Equal(t, want, hashRecoveryCode("abcd efgh"))
+	assert.Equal(t, want, hashRecoveryCode(strings.ToUpper("abcdefgh")))
+	assert.NotEqual(t, want, hashRecoveryCode("abcd-efgi"))
+}
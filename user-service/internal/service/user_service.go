@@ -6,10 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 	"user-service/config"
 	"user-service/internal/platform"
+	"user-service/internal/validation"
 
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
 	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/bson"
@@ -26,6 +30,11 @@ type UserService struct {
 	cfg         *config.Config
 	logger      *slog.Logger
 	metrics     *platform.BusinessMetrics
+	search      SearchIndexer
+	audit       *audit.Logger
+	usernames   UsernameHistoryRepository
+	insights    InsightsRecorder
+	emails      EmailVerifier
 }
 
 func NewUserService(userRepo UserRepository, producer EventProducer, redisClient redis.UniversalClient, cfg *config.Config, logger *slog.Logger, metrics *platform.BusinessMetrics) *UserService {
@@ -42,13 +51,53 @@ func NewUserService(userRepo UserRepository, producer EventProducer, redisClient
 	}
 }
 
+// SetSearchIndexer enables publishing profile changes to the search index
+func (s *UserService) SetSearchIndexer(search SearchIndexer) {
+	s.search = search
+}
+
+// SetAuditLogger enables recording password changes in the audit log
+func (s *UserService) SetAuditLogger(logger *audit.Logger) {
+	s.audit = logger
+}
+
+// SetInsights enables counting profile views toward their owner's insights
+func (s *UserService) SetInsights(insights InsightsRecorder) {
+	s.insights = insights
+}
+
+// SetEmailVerifier enables mailing a verification link to changed emails
+func (s *UserService) SetEmailVerifier(emails EmailVerifier) {
+	s.emails = emails
+}
+
+// userUpdatedMessage carries the UserUpdatedEvent the replicas in other
+// services read, next to the full user in user_data
+type userUpdatedMessage struct {
+	events.UserUpdatedEvent
+	EventType string       `json:"event_type"`
+	Timestamp time.Time    `json:"timestamp"`
+	UserData  *models.User `json:"user_data"`
+}
+
 // publishUserUpdatedEvent publishes an event to Kafka when user data changes
 func (s *UserService) publishUserUpdatedEvent(ctx context.Context, userID string, updatedUser *models.User) {
-	event := map[string]interface{}{
-		"event_type": "USER_UPDATED",
-		"user_id":    userID,
-		"timestamp":  time.Now(),
-		"user_data":  updatedUser,
+	if s.search != nil && updatedUser != nil {
+		s.search.UpsertUser(ctx, models.NewSearchUserDocument(updatedUser))
+	}
+
+	event := userUpdatedMessage{
+		UserUpdatedEvent: events.UserUpdatedEvent{UserID: userID},
+		EventType:        "USER_UPDATED",
+		Timestamp:        time.Now(),
+		UserData:         updatedUser,
+	}
+	if updatedUser != nil {
+		event.Username = updatedUser.Username
+		event.FullName = updatedUser.FullName
+		event.Avatar = updatedUser.Avatar
+		event.DateOfBirth = updatedUser.DateOfBirth
+		event.Verified = updatedUser.Verified
 	}
 
 	payload, err := json.Marshal(event)
@@ -94,11 +143,17 @@ func (s *UserService) GetUserByID(ctx context.Context, id primitive.ObjectID) (*
 	return user, nil
 }
 
+// GetUsersByUsernames looks users up by username. Usernames given up
+// recently resolve to the users who had them, so old mentions still land.
 func (s *UserService) GetUsersByUsernames(ctx context.Context, usernames []string) ([]models.User, error) {
 	if len(usernames) == 0 {
 		return []models.User{}, nil
 	}
-	return s.userRepo.FindUsersByUsernames(ctx, usernames)
+	users, err := s.userRepo.FindUsersByUsernames(ctx, usernames)
+	if err != nil {
+		return nil, err
+	}
+	return s.withRenamedUsers(ctx, usernames, users)
 }
 
 func (s *UserService) GetUsersByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.User, error) {
@@ -324,6 +379,12 @@ func (s *UserService) UpdateUser(ctx context.Context, id primitive.ObjectID, upd
 		return nil, err
 	}
 
+	s.userUpdated(ctx, id, updatedUser)
+	return updatedUser, nil
+}
+
+// userUpdated drops the cached copy of an updated user and announces the change
+func (s *UserService) userUpdated(ctx context.Context, id primitive.ObjectID, updatedUser *models.User) {
 	// Invalidate Cache
 	if err := s.redisClient.Del(ctx, fmt.Sprintf("user:profile:%s", id.Hex())).Err(); err != nil {
 		s.logger.Error("Failed to invalidate user cache", "user_id", id.Hex(), "error", err)
@@ -334,31 +395,59 @@ func (s *UserService) UpdateUser(ctx context.Context, id primitive.ObjectID, upd
 	if s.metrics != nil {
 		s.metrics.IncrementProfileUpdates()
 	}
-
-	return updatedUser, nil
 }
 
-// UpdateProfileFields updates user profile fields
-func (s *UserService) UpdateProfileFields(ctx context.Context, userID primitive.ObjectID, fullName, bio, avatar, coverPhoto, location, website string) (*models.User, error) {
+// UpdateProfileFields validates and applies a profile update. Audiences in
+// req.Privacy are merged into the current ones section by section.
+func (s *UserService) UpdateProfileFields(ctx context.Context, userID primitive.ObjectID, req *models.UpdateProfileRequest) (*models.User, error) {
+	if err := validation.ValidateProfileUpdate(req, time.Now().Year()); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProfile, err)
+	}
+
 	update := bson.M{}
 
-	if fullName != "" {
-		update["full_name"] = fullName
+	if req.FullName != "" {
+		update["full_name"] = req.FullName
+	}
+	if req.Bio != "" {
+		update["bio"] = req.Bio
+	}
+	if req.Avatar != "" {
+		update["avatar"] = req.Avatar
+	}
+	if req.CoverPhoto != "" {
+		update["cover_photo"] = req.CoverPhoto
+	}
+	if req.Location != "" {
+		update["location"] = req.Location
 	}
-	if bio != "" {
-		update["bio"] = bio
+	if req.Pronouns != nil {
+		update["pronouns"] = strings.TrimSpace(*req.Pronouns)
 	}
-	if avatar != "" {
-		update["avatar"] = avatar
+	if req.Websites != nil {
+		update["websites"] = *req.Websites
+	} else if req.Website != "" {
+		update["websites"] = []models.ProfileWebsite{{URL: req.Website}}
 	}
-	if coverPhoto != "" {
-		update["cover_photo"] = coverPhoto
+	if req.Work != nil {
+		update["work"] = *req.Work
 	}
-	if location != "" {
-		update["location"] = location
+	if req.Education != nil {
+		update["education"] = *req.Education
 	}
-	if website != "" {
-		update["website"] = website
+	if req.Privacy != nil {
+		for field, audience := range map[string]models.PrivacySettingType{
+			"bio":       req.Privacy.Bio,
+			"websites":  req.Privacy.Websites,
+			"pronouns":  req.Privacy.Pronouns,
+			"work":      req.Privacy.Work,
+			"education": req.Privacy.Education,
+			"location":  req.Privacy.Location,
+		} {
+			if audience != "" {
+				update["profile_privacy."+field] = audience
+			}
+		}
 	}
 
 	if len(update) == 0 {
@@ -389,6 +478,11 @@ func (s *UserService) UpdateEmail(ctx context.Context, userID primitive.ObjectID
 	if s.metrics != nil {
 		s.metrics.IncrementEmailChanges()
 	}
+	if s.emails != nil {
+		if err := s.emails.SendVerification(ctx, userID); err != nil {
+			s.logger.Error("Failed to send verification email", "user_id", userID.Hex(), "error", err)
+		}
+	}
 	s.logger.Info("Email updated successfully", "user_id", userID.Hex())
 	return nil
 }
@@ -404,6 +498,7 @@ func (s *UserService) UpdatePassword(ctx context.Context, userID primitive.Objec
 
 	// Verify current password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
+		s.auditPasswordChange(ctx, userID, "current password is incorrect")
 		return errors.New("current password is incorrect")
 	}
 
@@ -427,12 +522,35 @@ func (s *UserService) UpdatePassword(ctx context.Context, userID primitive.Objec
 	if s.metrics != nil {
 		s.metrics.IncrementPasswordChanges()
 	}
+	s.auditPasswordChange(ctx, userID, "")
 	return nil
 }
 
+// auditPasswordChange records a password change; an empty failure means it went through
+func (s *UserService) auditPasswordChange(ctx context.Context, userID primitive.ObjectID, failure string) {
+	entry := models.AuditEntry{
+		Action:     models.AuditActionPasswordChanged,
+		ActorID:    &userID,
+		TargetType: "user",
+		TargetID:   userID.Hex(),
+	}
+	if failure != "" {
+		entry.Outcome = models.AuditOutcomeFailure
+		entry.Metadata = map[string]string{"reason": failure}
+	}
+	s.audit.Record(ctx, entry)
+}
+
+// ToggleTwoFactor can only switch two-factor authentication off; turning it
+// on needs a verified secret, which AuthService.EnableTwoFactor sets up
 func (s *UserService) ToggleTwoFactor(ctx context.Context, userID primitive.ObjectID, enable bool) error {
+	if enable {
+		return ErrTwoFactorSetupRequired
+	}
 	update := bson.M{
-		"two_factor_enabled": enable,
+		"two_factor_enabled": false,
+		"two_factor_secret":  "",
+		"recovery_codes":     []string{},
 		"updated_at":         time.Now(),
 	}
 
@@ -285,10 +285,13 @@ func TestUserService_UpdatePassword(t *testing.T) {
 
 // Helper to create test UserService with mocks
 func newTestUserService(repo *mocks.MockUserRepository, producer *mocks.MockEventProducer, redisMock *mocks.MockRedisClient) *UserService {
+	if redisMock == nil {
+		redisMock = &mocks.MockRedisClient{} // Every read is a cache miss
+	}
 	return &UserService{
 		userRepo:    repo,
 		producer:    producer,
-		redisClient: nil, // Redis tests can be added separately
+		redisClient: redisMock,
 		cfg:         &config.Config{},
 		logger:      slog.Default(),
 		metrics:     nil, // Metrics tests can be added separately
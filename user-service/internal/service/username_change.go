@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"user-service/internal/repository"
+	"user-service/internal/validation"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrInvalidUsername        = errors.New("invalid username")
+	ErrUsernameTaken          = errors.New("username is taken")
+	ErrUsernameUnchanged      = errors.New("username is unchanged")
+	ErrUsernameChangeCooldown = errors.New("username was changed too recently")
+)
+
+// SetUsernameHistory enables keeping the usernames users change away from.
+// Without it old usernames stop resolving and are free as soon as they change.
+func (s *UserService) SetUsernameHistory(history UsernameHistoryRepository) {
+	s.usernames = history
+}
+
+// ChangeUsername renames the user, at most once per UsernameChangeCooldown.
+// For UsernameReservation afterwards the old username still resolves to them
+// and nobody else can take it, which they can use to change back.
+func (s *UserService) ChangeUsername(ctx context.Context, userID primitive.ObjectID, username string) (*models.User, error) {
+	username = strings.TrimSpace(username)
+	if err := validation.ValidateUsername(username); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidUsername, err)
+	}
+
+	user, err := s.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.Username == username {
+		return nil, ErrUsernameUnchanged
+	}
+	now := time.Now()
+	if user.UsernameChangedAt != nil {
+		if next := user.UsernameChangedAt.Add(s.cfg.UsernameChangeCooldown); now.Before(next) {
+			return nil, fmt.Errorf("%w, it can change again after %s", ErrUsernameChangeCooldown, next.UTC().Format(time.RFC3339))
+		}
+	}
+
+	if owner, _ := s.userRepo.FindUserByUserName(ctx, username); owner != nil {
+		return nil, ErrUsernameTaken
+	}
+	holder, err := usernameReservedBy(ctx, s.usernames, username, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check username history: %w", err)
+	}
+	if !holder.IsZero() && holder != userID {
+		return nil, ErrUsernameTaken
+	}
+
+	updatedUser, err := s.userRepo.ChangeUsername(ctx, userID, user.Username, username, now)
+	switch {
+	case errors.Is(err, repository.ErrUsernameTaken):
+		return nil, ErrUsernameTaken
+	case errors.Is(err, mongo.ErrNoDocuments):
+		// Renamed by a concurrent request, which started the cooldown
+		return nil, ErrUsernameChangeCooldown
+	case err != nil:
+		return nil, err
+	}
+
+	if s.usernames != nil {
+		change := &models.UsernameChange{
+			UserID:        userID,
+			OldUsername:   user.Username,
+			NewUsername:   username,
+			ChangedAt:     now,
+			ReservedUntil: now.Add(s.cfg.UsernameReservation),
+		}
+		if err := s.usernames.Create(ctx, change); err != nil {
+			// The old username is free for others right away
+			s.logger.Error("Failed to record username change", "user_id", userID.Hex(), "error", err)
+		}
+	}
+
+	// Mention and search indexes pick the new username up from the event
+	s.userUpdated(ctx, userID, updatedUser)
+	updatedUser.Password = ""
+	return updatedUser, nil
+}
+
+// ListUsernameHistory returns the usernames the user changed away from,
+// newest first
+func (s *UserService) ListUsernameHistory(ctx context.Context, userID primitive.ObjectID) ([]models.UsernameChange, error) {
+	if s.usernames == nil {
+		return []models.UsernameChange{}, nil
+	}
+	return s.usernames.ListByUser(ctx, userID)
+}
+
+// ResolveUsername returns the ID of the user with username, or of the user
+// who gave it up while it is still reserved to them
+func (s *UserService) ResolveUsername(ctx context.Context, username string) (primitive.ObjectID, error) {
+	if user, err := s.userRepo.FindUserByUserName(ctx, username); err == nil && user != nil {
+		return user.ID, nil
+	}
+	holder, err := usernameReservedBy(ctx, s.usernames, username, time.Now())
+	if err != nil {
+		return primitive.NilObjectID, fmt.Errorf("failed to check username history: %w", err)
+	}
+	if holder.IsZero() {
+		return primitive.NilObjectID, ErrUserNotFound
+	}
+	return holder, nil
+}
+
+// withRenamedUsers adds to users, found by usernames, the users who recently
+// gave up the usernames nobody has now
+func (s *UserService) withRenamedUsers(ctx context.Context, usernames []string, users []models.User) ([]models.User, error) {
+	if s.usernames == nil {
+		return users, nil
+	}
+	found := make(map[string]bool, len(users))
+	seen := make(map[primitive.ObjectID]bool, len(users))
+	for _, u := range users {
+		found[u.Username] = true
+		seen[u.ID] = true
+	}
+	var missing []string
+	for _, username := range usernames {
+		if !found[username] {
+			missing = append(missing, username)
+		}
+	}
+	if len(missing) == 0 {
+		return users, nil
+	}
+
+	changes, err := s.usernames.FindReserved(ctx, missing, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to resolve old usernames", "error", err)
+		return users, nil
+	}
+	var ids []primitive.ObjectID
+	for _, change := range changes {
+		if !seen[change.UserID] {
+			seen[change.UserID] = true
+			ids = append(ids, change.UserID)
+		}
+	}
+	if len(ids) == 0 {
+		return users, nil
+	}
+	renamed, err := s.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return append(users, renamed...), nil
+}
+
+// usernameReservedBy returns who username is reserved to at now, or a zero ID
+// when it is not reserved or history is not kept
+func usernameReservedBy(ctx context.Context, history UsernameHistoryRepository, username string, now time.Time) (primitive.ObjectID, error) {
+	if history == nil {
+		return primitive.NilObjectID, nil
+	}
+	changes, err := history.FindReserved(ctx, []string{username}, now)
+	if err != nil || len(changes) == 0 {
+		return primitive.NilObjectID, err
+	}
+	return changes[0].UserID, nil
+}
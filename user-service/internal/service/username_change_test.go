@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+func newUsernameTestService(repo *mocks.MockUserRepository, history *mocks.MockUsernameHistoryRepository) *UserService {
+	svc := newTestUserService(repo, &mocks.MockEventProducer{}, nil)
+	svc.cfg.UsernameChangeCooldown = 30 * 24 * time.Hour
+	svc.cfg.UsernameReservation = 14 * 24 * time.Hour
+	svc.SetUsernameHistory(history)
+	return svc
+}
+
+func TestUserService_ChangeUsername(t *testing.T) {
+	userID := primitive.NewObjectID()
+	otherID := primitive.NewObjectID()
+	recently := time.Now().Add(-24 * time.Hour)
+	longAgo := time.Now().Add(-60 * 24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		username  string
+		changedAt *time.Time
+		taken     bool
+		reserved  *primitive.ObjectID
+		wantErr   error
+	}{
+		{name: "first change", username: "newname"},
+		{name: "after cooldown", username: "newname", changedAt: &longAgo},
+		{name: "within cooldown", username: "newname", changedAt: &recently, wantErr: ErrUsernameChangeCooldown},
+		{name: "invalid", username: "a!", wantErr: ErrInvalidUsername},
+		{name: "unchanged", username: "oldname", wantErr: ErrUsernameUnchanged},
+		{name: "held by another user", username: "newname", taken: true, wantErr: ErrUsernameTaken},
+		{name: "reserved to another user", username: "newname", reserved: &otherID, wantErr: ErrUsernameTaken},
+		{name: "reclaims own old username", username: "newname", reserved: &userID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockUserRepository{}
+			repo.FindUserByIDFunc = func(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+				return &models.User{ID: id, Username: "oldname", UsernameChangedAt: tt.changedAt}, nil
+			}
+			repo.FindUserByUserNameFunc = func(ctx context.Context, username string) (*models.User, error) {
+				if tt.taken {
+					return &models.User{ID: otherID, Username: username}, nil
+				}
+				return nil, nil
+			}
+			history := &mocks.MockUsernameHistoryRepository{}
+			if tt.reserved != nil {
+				history.Changes = append(history.Changes, models.UsernameChange{
+					UserID:        *tt.reserved,
+					OldUsername:   "newname",
+					ReservedUntil: time.Now().Add(time.Hour),
+				})
+			}
+
+			svc := newUsernameTestService(repo, history)
+			user, err := svc.ChangeUsername(context.Background(), userID, tt.username)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "newname", user.Username)
+
+			last := history.Changes[len(history.Changes)-1]
+			assert.Equal(t, userID, last.UserID)
+			assert.Equal(t, "oldname", last.OldUsername)
+			assert.Equal(t, "newname", last.NewUsername)
+			assert.WithinDuration(t, time.Now().Add(14*24*time.Hour), last.ReservedUntil, time.Minute)
+		})
+	}
+}
+
+func TestUserService_OldUsernamesResolve(t *testing.T) {
+	userID := primitive.NewObjectID()
+	repo := &mocks.MockUserRepository{}
+	repo.FindUsersByUsernamesFunc = func(ctx context.Context, usernames []string) ([]models.User, error) {
+		return []models.User{}, nil
+	}
+	repo.FindUsersByIDsFunc = func(ctx context.Context, ids []primitive.ObjectID) ([]models.User, error) {
+		return []models.User{{ID: userID, Username: "newname"}}, nil
+	}
+	history := &mocks.MockUsernameHistoryRepository{Changes: []models.UsernameChange{
+		{UserID: userID, OldUsername: "oldname", NewUsername: "newname", ReservedUntil: time.Now().Add(time.Hour)},
+		{UserID: primitive.NewObjectID(), OldUsername: "expired", ReservedUntil: time.Now().Add(-time.Hour)},
+	}}
+	svc := newUsernameTestService(repo, history)
+
+	id, err := svc.ResolveUsername(context.Background(), "oldname")
+	require.NoError(t, err)
+	assert.Equal(t, userID, id)
+
+	_, err = svc.ResolveUsername(context.Background(), "expired")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	users, err := svc.GetUsersByUsernames(context.Background(), []string{"oldname", "expired"})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, userID, users[0].ID)
+}
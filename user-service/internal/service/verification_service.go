@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"user-service/internal/repository"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/audit"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrAlreadyVerified             = errors.New("user is already verified")
+	ErrNotVerified                 = errors.New("user is not verified")
+	ErrVerificationPending         = errors.New("a verification request is already pending")
+	ErrVerificationNotFound        = errors.New("verification request not found")
+	ErrVerificationReviewed        = errors.New("verification request was already reviewed")
+	ErrCannotReviewOwnVerification = errors.New("admins cannot review their own verification request")
+	ErrInvalidVerificationDocument = errors.New("document_key must be the key of a storage-service upload")
+	ErrInvalidVerificationRequest  = errors.New("legal_name must not be blank")
+)
+
+const (
+	// verificationDocumentLinkTTL only needs to cover an admin looking at
+	// the document
+	verificationDocumentLinkTTL = 15 * time.Minute
+
+	defaultVerificationQueueLimit = 20
+	maxVerificationQueueLimit     = 100
+)
+
+// VerificationService runs identity verification: users ask for the badge
+// with an identity document, and admins work through the pending requests.
+// Approval sets the user's verified flag, which reaches the other services'
+// user replicas through the UserUpdated event.
+type VerificationService struct {
+	repo      VerificationRepository
+	users     *UserService
+	documents VerificationDocuments
+	audit     *audit.Logger
+	logger    *slog.Logger
+}
+
+func NewVerificationService(repo VerificationRepository, users *UserService, documents VerificationDocuments, logger *slog.Logger) *VerificationService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &VerificationService{repo: repo, users: users, documents: documents, logger: logger}
+}
+
+// SetAuditLogger enables recording reviews and revocations in the audit log
+func (s *VerificationService) SetAuditLogger(logger *audit.Logger) {
+	s.audit = logger
+}
+
+// Request queues the user's request for review. The document is uploaded to
+// storage-service first and referenced by its key.
+func (s *VerificationService) Request(ctx context.Context, userID primitive.ObjectID, req models.CreateVerificationRequest) (*models.VerificationRequest, error) {
+	legalName := strings.TrimSpace(req.LegalName)
+	if legalName == "" {
+		return nil, ErrInvalidVerificationRequest
+	}
+	// Keys are object names, never URLs or paths into other buckets
+	if req.DocumentKey == "" || strings.ContainsAny(req.DocumentKey, "/\\") || strings.Contains(req.DocumentKey, "..") {
+		return nil, ErrInvalidVerificationDocument
+	}
+
+	user, err := s.users.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.Verified {
+		return nil, ErrAlreadyVerified
+	}
+
+	request := &models.VerificationRequest{
+		UserID:      userID,
+		Category:    req.Category,
+		LegalName:   legalName,
+		DocumentKey: req.DocumentKey,
+		Status:      models.VerificationPending,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.repo.Create(ctx, request); err != nil {
+		if errors.Is(err, repository.ErrVerificationPending) {
+			return nil, ErrVerificationPending
+		}
+		return nil, fmt.Errorf("failed to create verification request: %w", err)
+	}
+	return request, nil
+}
+
+// Latest returns the user's most recent request, to show where it is in
+// review or why it was rejected
+func (s *VerificationService) Latest(ctx context.Context, userID primitive.ObjectID) (*models.VerificationRequest, error) {
+	request, err := s.repo.FindLatestByUser(ctx, userID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrVerificationNotFound
+	}
+	return request, err
+}
+
+// Queue returns up to limit pending requests after the one with ID after,
+// oldest first, each with its user and a short-lived link to its document
+func (s *VerificationService) Queue(ctx context.Context, after primitive.ObjectID, limit int) ([]models.VerificationReviewItem, error) {
+	if limit <= 0 {
+		limit = defaultVerificationQueueLimit
+	}
+	limit = min(limit, maxVerificationQueueLimit)
+
+	requests, err := s.repo.ListByStatus(ctx, models.VerificationPending, after, int64(limit))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(requests))
+	for _, request := range requests {
+		ids = append(ids, request.UserID)
+	}
+	users, err := shortUsers(ctx, s.users.userRepo, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[primitive.ObjectID]models.UserShortResponse, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+
+	items := make([]models.VerificationReviewItem, 0, len(requests))
+	for _, request := range requests {
+		item := models.VerificationReviewItem{VerificationRequest: request, User: byID[request.UserID]}
+		if url, err := s.documents.PresignDocument(ctx, request.DocumentKey, verificationDocumentLinkTTL); err != nil {
+			// The admin can still reject a request whose document is gone
+			s.logger.Error("Failed to sign verification document", "request_id", request.ID.Hex(), "error", err)
+		} else {
+			item.DocumentURL = url
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Review approves or rejects a pending request. Approval gives the user the
+// badge. Either way the document is deleted, as it is only kept for review.
+func (s *VerificationService) Review(ctx context.Context, adminID, requestID primitive.ObjectID, req models.ReviewVerificationRequest) (*models.VerificationRequest, error) {
+	request, err := s.repo.FindByID(ctx, requestID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrVerificationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if request.UserID == adminID {
+		return nil, ErrCannotReviewOwnVerification
+	}
+	if request.Status != models.VerificationPending {
+		return nil, ErrVerificationReviewed
+	}
+
+	status := models.VerificationRejected
+	if req.Approve {
+		status = models.VerificationApproved
+	}
+	now := time.Now()
+	reviewed, err := s.repo.Review(ctx, requestID, status, adminID, strings.TrimSpace(req.Note), now)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// Another admin got there first
+		return nil, ErrVerificationReviewed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to review verification request: %w", err)
+	}
+
+	if req.Approve {
+		if _, err := s.setVerified(ctx, reviewed.UserID, true, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.documents.DeleteDocument(ctx, reviewed.DocumentKey); err != nil {
+		s.logger.Error("Failed to delete verification document", "request_id", requestID.Hex(), "error", err)
+	}
+	reviewed.DocumentKey = ""
+
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditActionVerificationReviewed,
+		ActorID:    &adminID,
+		TargetType: "user",
+		TargetID:   reviewed.UserID.Hex(),
+		Metadata:   map[string]string{"request_id": requestID.Hex(), "status": string(status)},
+	})
+	return reviewed, nil
+}
+
+// Revoke takes the badge away from a verified user, e.g. after an
+// impersonation report
+func (s *VerificationService) Revoke(ctx context.Context, adminID, userID primitive.ObjectID) (*models.User, error) {
+	user, err := s.users.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if !user.Verified {
+		return nil, ErrNotVerified
+	}
+	updated, err := s.setVerified(ctx, userID, false, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditActionVerificationRevoked,
+		ActorID:    &adminID,
+		TargetType: "user",
+		TargetID:   userID.Hex(),
+	})
+	return updated, nil
+}
+
+// setVerified changes the badge and publishes it like any other profile change
+func (s *VerificationService) setVerified(ctx context.Context, userID primitive.ObjectID, verified bool, at time.Time) (*models.User, error) {
+	update := bson.M{"verified": verified, "verified_at": nil}
+	if verified {
+		update["verified_at"] = at
+	}
+	updated, err := s.users.userRepo.UpdateUser(ctx, userID, update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update verified flag: %w", err)
+	}
+	s.users.userUpdated(ctx, userID, updated)
+	updated.Password = ""
+	return updated, nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"user-service/internal/repository"
+	"user-service/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/events"
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+)
+
+func newTestVerificationService(userRepo *mocks.MockUserRepository, producer *mocks.MockEventProducer) (*VerificationService, *mocks.MockVerificationRepository, *mocks.MockVerificationDocuments) {
+	repo := &mocks.MockVerificationRepository{}
+	documents := &mocks.MockVerificationDocuments{}
+	users := newTestUserService(userRepo, producer, nil)
+	return NewVerificationService(repo, users, documents, nil), repo, documents
+}
+
+func TestVerificationService_Request(t *testing.T) {
+	userID := primitive.NewObjectID()
+	valid := models.CreateVerificationRequest{
+		Category:    models.VerificationCategoryCreator,
+		LegalName:   " Jane Doe ",
+		DocumentKey: "1700000000-abc.jpg",
+	}
+
+	t.Run("queues a pending request", func(t *testing.T) {
+		svc, repo, _ := newTestVerificationService(&mocks.MockUserRepository{}, nil)
+
+		request, err := svc.Request(context.Background(), userID, valid)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.VerificationPending, request.Status)
+		assert.Equal(t, "Jane Doe", request.LegalName)
+		require.Len(t, repo.Requests, 1)
+		assert.Equal(t, "1700000000-abc.jpg", repo.Requests[0].DocumentKey)
+	})
+
+	t.Run("rejects bad input", func(t *testing.T) {
+		svc, _, _ := newTestVerificationService(&mocks.MockUserRepository{}, nil)
+
+		for _, key := range []string{"", "../secrets", "https://example.com/id.jpg", "archives/export.zip"} {
+			req := valid
+			req.DocumentKey = key
+			_, err := svc.Request(context.Background(), userID, req)
+			assert.ErrorIs(t, err, ErrInvalidVerificationDocument, key)
+		}
+
+		req := valid
+		req.LegalName = "  "
+		_, err := svc.Request(context.Background(), userID, req)
+		assert.ErrorIs(t, err, ErrInvalidVerificationRequest)
+	})
+
+	t.Run("refuses verified users and second requests", func(t *testing.T) {
+		userRepo := &mocks.MockUserRepository{}
+		userRepo.FindUserByIDFunc = func(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+			return &models.User{ID: id, Verified: true}, nil
+		}
+		svc, _, _ := newTestVerificationService(userRepo, nil)
+		_, err := svc.Request(context.Background(), userID, valid)
+		assert.ErrorIs(t, err, ErrAlreadyVerified)
+
+		svc, repo, _ := newTestVerificationService(&mocks.MockUserRepository{}, nil)
+		repo.CreateErr = repository.ErrVerificationPending
+		_, err = svc.Request(context.Background(), userID, valid)
+		assert.ErrorIs(t, err, ErrVerificationPending)
+	})
+}
+
+func TestVerificationService_Review(t *testing.T) {
+	adminID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+
+	t.Run("approval verifies the user and publishes it", func(t *testing.T) {
+		userRepo := &mocks.MockUserRepository{}
+		userRepo.UpdateUserFunc = func(ctx context.Context, id primitive.ObjectID, update bson.M) (*models.User, error) {
+			return &models.User{ID: id, Username: "jane", Verified: update["verified"].(bool)}, nil
+		}
+		producer := &mocks.MockEventProducer{}
+		svc, _, documents := newTestVerificationService(userRepo, producer)
+		request, err := svc.Request(context.Background(), userID, models.CreateVerificationRequest{
+			Category: models.VerificationCategoryPerson, LegalName: "Jane Doe", DocumentKey: "doc.pdf",
+		})
+		require.NoError(t, err)
+
+		queue, err := svc.Queue(context.Background(), primitive.NilObjectID, 0)
+		require.NoError(t, err)
+		require.Len(t, queue, 1)
+		assert.Equal(t, userID, queue[0].User.ID)
+		assert.Contains(t, queue[0].DocumentURL, "doc.pdf")
+
+		reviewed, err := svc.Review(context.Background(), adminID, request.ID, models.ReviewVerificationRequest{Approve: true})
+		require.NoError(t, err)
+		assert.Equal(t, models.VerificationApproved, reviewed.Status)
+		assert.Equal(t, adminID, *reviewed.ReviewerID)
+		assert.Empty(t, reviewed.DocumentKey)
+		assert.Equal(t, []string{"doc.pdf"}, documents.Deleted)
+
+		require.Len(t, userRepo.UpdateUserCalls, 1)
+		assert.Equal(t, true, userRepo.UpdateUserCalls[0].Update["verified"])
+		require.Len(t, producer.ProduceCalls, 1)
+		var evt events.UserUpdatedEvent
+		require.NoError(t, json.Unmarshal(producer.ProduceCalls[0].Value, &evt))
+		assert.Equal(t, userID.Hex(), evt.UserID)
+		assert.Equal(t, "jane", evt.Username)
+		assert.True(t, evt.Verified)
+
+		queue, err = svc.Queue(context.Background(), primitive.NilObjectID, 0)
+		require.NoError(t, err)
+		assert.Empty(t, queue)
+		_, err = svc.Review(context.Background(), adminID, request.ID, models.ReviewVerificationRequest{})
+		assert.ErrorIs(t, err, ErrVerificationReviewed)
+	})
+
+	t.Run("rejection leaves the user unverified", func(t *testing.T) {
+		userRepo := &mocks.MockUserRepository{}
+		svc, _, documents := newTestVerificationService(userRepo, &mocks.MockEventProducer{})
+		request, err := svc.Request(context.Background(), userID, models.CreateVerificationRequest{
+			Category: models.VerificationCategoryPerson, LegalName: "Jane Doe", DocumentKey: "doc.pdf",
+		})
+		require.NoError(t, err)
+
+		reviewed, err := svc.Review(context.Background(), adminID, request.ID, models.ReviewVerificationRequest{Note: "Document is unreadable"})
+		require.NoError(t, err)
+		assert.Equal(t, models.VerificationRejected, reviewed.Status)
+		assert.Equal(t, "Document is unreadable", reviewed.ReviewNote)
+		assert.Empty(t, userRepo.UpdateUserCalls)
+		assert.Equal(t, []string{"doc.pdf"}, documents.Deleted)
+
+		latest, err := svc.Latest(context.Background(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, models.VerificationRejected, latest.Status)
+	})
+
+	t.Run("admins cannot review their own request", func(t *testing.T) {
+		svc, _, _ := newTestVerificationService(&mocks.MockUserRepository{}, nil)
+		request, err := svc.Request(context.Background(), adminID, models.CreateVerificationRequest{
+			Category: models.VerificationCategoryPerson, LegalName: "Admin", DocumentKey: "doc.pdf",
+		})
+		require.NoError(t, err)
+
+		_, err = svc.Review(context.Background(), adminID, request.ID, models.ReviewVerificationRequest{Approve: true})
+		assert.ErrorIs(t, err, ErrCannotReviewOwnVerification)
+		_, err = svc.Review(context.Background(), adminID, primitive.NewObjectID(), models.ReviewVerificationRequest{})
+		assert.ErrorIs(t, err, ErrVerificationNotFound)
+	})
+}
+
+func TestVerificationService_Revoke(t *testing.T) {
+	adminID := primitive.NewObjectID()
+	userRepo := &mocks.MockUserRepository{}
+	verified := true
+	userRepo.FindUserByIDFunc = func(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+		return &models.User{ID: id, Verified: verified}, nil
+	}
+	svc, _, _ := newTestVerificationService(userRepo, &mocks.MockEventProducer{})
+
+	_, err := svc.Revoke(context.Background(), adminID, primitive.NewObjectID())
+	require.NoError(t, err)
+	require.Len(t, userRepo.UpdateUserCalls, 1)
+	assert.Equal(t, bson.M{"verified": false, "verified_at": nil}, userRepo.UpdateUserCalls[0].Update)
+
+	verified = false
+	_, err = svc.Revoke(context.Background(), adminID, primitive.NewObjectID())
+	assert.ErrorIs(t, err, ErrNotVerified)
+}
@@ -0,0 +1,121 @@
+// Package storage keeps data export archives and verification documents in
+// storage-service, and reads the media exports copy
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	storagepb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/storage/v1"
+)
+
+// mediaLinkTTL only needs to cover the download that follows signing
+const mediaLinkTTL = 15 * time.Minute
+
+// ArchiveStore uploads archives through storage-service's resumable uploads
+// and downloads media over presigned links
+type ArchiveStore struct {
+	client storagepb.StorageServiceClient
+	http   *http.Client
+}
+
+func NewArchiveStore(client storagepb.StorageServiceClient) *ArchiveStore {
+	return &ArchiveStore{
+		client: client,
+		http:   &http.Client{Timeout: 10 * time.Minute},
+	}
+}
+
+// PutArchive uploads the archive in the part size storage-service asks for
+// and returns its object key. A failed upload is aborted.
+func (s *ArchiveStore) PutArchive(ctx context.Context, filename string, archive io.ReaderAt, size int64) (string, error) {
+	upload, err := s.client.InitiateUpload(ctx, &storagepb.InitiateUploadRequest{
+		Filename:    filename,
+		ContentType: "application/zip",
+		TotalSize:   size,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to initiate upload: %w", err)
+	}
+
+	buf := make([]byte, upload.PartSize)
+	for part := int32(1); part <= upload.PartCount; part++ {
+		offset := int64(part-1) * upload.PartSize
+		n, err := archive.ReadAt(buf[:min(upload.PartSize, size-offset)], offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			s.abort(upload.UploadId)
+			return "", fmt.Errorf("failed to read part %d: %w", part, err)
+		}
+		if _, err := s.client.UploadPart(ctx, &storagepb.UploadPartRequest{
+			UploadId:   upload.UploadId,
+			PartNumber: part,
+			Data:       buf[:n],
+		}); err != nil {
+			s.abort(upload.UploadId)
+			return "", fmt.Errorf("failed to upload part %d: %w", part, err)
+		}
+	}
+
+	result, err := s.client.CompleteUpload(ctx, &storagepb.CompleteUploadRequest{UploadId: upload.UploadId})
+	if err != nil {
+		s.abort(upload.UploadId)
+		return "", fmt.Errorf("failed to complete upload: %w", err)
+	}
+	return result.Key, nil
+}
+
+// abort runs on its own context so a cancelled export still cleans up
+func (s *ArchiveStore) abort(uploadID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = s.client.AbortUpload(ctx, &storagepb.AbortUploadRequest{UploadId: uploadID})
+}
+
+func (s *ArchiveStore) PresignArchive(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	resp, err := s.client.GetPresignedURL(ctx, &storagepb.GetPresignedURLRequest{
+		Key:           key,
+		ExpirySeconds: int64(ttl.Seconds()),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Url, nil
+}
+
+func (s *ArchiveStore) DeleteArchive(ctx context.Context, key string) error {
+	_, err := s.client.Delete(ctx, &storagepb.DeleteRequest{Key: key})
+	return err
+}
+
+// OpenMedia signs a stored file's URL and starts downloading it
+func (s *ArchiveStore) OpenMedia(ctx context.Context, url string) (io.ReadCloser, error) {
+	resp, err := s.client.BatchGetPresignedURLs(ctx, &storagepb.BatchGetPresignedURLsRequest{
+		Keys:          []string{url},
+		ExpirySeconds: int64(mediaLinkTTL.Seconds()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	signed, ok := resp.Urls[url]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a stored file", url)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, signed, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("download of %s failed with status %d", url, res.StatusCode)
+	}
+	return res.Body, nil
+}
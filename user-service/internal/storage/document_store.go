@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/storage/v1"
+)
+
+// DocumentStore signs and deletes the identity documents users upload to
+// storage-service for verification
+type DocumentStore struct {
+	client storagepb.StorageServiceClient
+}
+
+func NewDocumentStore(client storagepb.StorageServiceClient) *DocumentStore {
+	return &DocumentStore{client: client}
+}
+
+func (s *DocumentStore) PresignDocument(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	resp, err := s.client.GetPresignedURL(ctx, &storagepb.GetPresignedURLRequest{
+		Key:           key,
+		ExpirySeconds: int64(ttl.Seconds()),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Url, nil
+}
+
+func (s *DocumentStore) DeleteDocument(ctx context.Context, key string) error {
+	_, err := s.client.Delete(ctx, &storagepb.DeleteRequest{Key: key})
+	return err
+}
@@ -0,0 +1,51 @@
+// Package stories reads the story highlights shown on profiles from
+// story-service
+package stories
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
+	storypb "github.com/MuhibNayem/connectify-v2/shared-entity/proto/story/v1"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HighlightCovers lists the highlight covers of profiles
+type HighlightCovers struct {
+	client storypb.StoryServiceClient
+	logger *slog.Logger
+}
+
+func NewHighlightCovers(client storypb.StoryServiceClient, logger *slog.Logger) *HighlightCovers {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &HighlightCovers{client: client, logger: logger}
+}
+
+// Covers returns the highlights on userID's profile as viewerID sees them; a
+// zero viewerID is signed out. Profiles are served without highlights when
+// story-service cannot list them, so failures only log and return nil.
+func (h *HighlightCovers) Covers(ctx context.Context, userID, viewerID primitive.ObjectID) []models.StoryHighlightCover {
+	req := &storypb.ListHighlightCoversRequest{UserId: userID.Hex()}
+	if !viewerID.IsZero() {
+		req.ViewerId = viewerID.Hex()
+	}
+	resp, err := h.client.ListHighlightCovers(ctx, req)
+	if err != nil {
+		h.logger.Warn("Failed to list story highlights", "user_id", userID.Hex(), "error", err)
+		return nil
+	}
+
+	covers := make([]models.StoryHighlightCover, 0, len(resp.Highlights))
+	for _, c := range resp.Highlights {
+		covers = append(covers, models.StoryHighlightCover{
+			ID:         c.Id,
+			Title:      c.Title,
+			CoverURL:   c.CoverUrl,
+			StoryCount: int(c.StoryCount),
+		})
+	}
+	return covers
+}
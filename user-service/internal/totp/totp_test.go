@@ -0,0 +1,77 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 key from RFC 6238 appendix B
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238Vectors(t *testing.T) {
+	// The RFC lists eight-digit codes; six-digit codes are their last six digits
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, want := range vectors {
+		got, err := Code(rfcSecret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "time %d", unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	now := time.Now()
+
+	t.Run("accepts the current code", func(t *testing.T) {
+		code, err := Code(secret, now)
+		require.NoError(t, err)
+		step, ok := Validate(secret, code, now)
+		assert.True(t, ok)
+		assert.Equal(t, Step(now), step)
+	})
+
+	t.Run("tolerates one step of drift", func(t *testing.T) {
+		code, err := Code(secret, now.Add(-Period))
+		require.NoError(t, err)
+		step, ok := Validate(secret, code, now)
+		assert.True(t, ok)
+		assert.Equal(t, Step(now)-1, step)
+	})
+
+	t.Run("rejects stale codes", func(t *testing.T) {
+		code, err := Code(secret, now.Add(-3*Period))
+		require.NoError(t, err)
+		_, ok := Validate(secret, code, now)
+		assert.False(t, ok)
+	})
+
+	t.Run("rejects malformed input", func(t *testing.T) {
+		_, ok := Validate(secret, "12345", now)
+		assert.False(t, ok)
+		_, ok = Validate("not base32!", "123456", now)
+		assert.False(t, ok)
+	})
+}
+
+func TestURI(t *testing.T) {
+	u, err := url.Parse(URI("Connectify", "jane@example.com", "ABCDEF"))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Connectify:jane@example.com", u.Path)
+	assert.Equal(t, "ABCDEF", u.Query().Get("secret"))
+	assert.Equal(t, "Connectify", u.Query().Get("issuer"))
+}
@@ -4,44 +4,144 @@ import (
 	"errors"
 	"net/url"
 	"strings"
+
+	"github.com/MuhibNayem/connectify-v2/shared-entity/models"
 )
 
 // Profile validation functions
 
 var (
-	ErrBioTooLong      = errors.New("bio must be 500 characters or less")
-	ErrInvalidWebsite  = errors.New("invalid website URL format")
-	ErrLocationTooLong = errors.New("location must be 100 characters or less")
-	ErrFullNameTooLong = errors.New("full name must be 100 characters or less")
+	ErrBioTooLong       = errors.New("bio must be 500 characters or less")
+	ErrInvalidWebsite   = errors.New("invalid website URL format")
+	ErrLocationTooLong  = errors.New("location must be 100 characters or less")
+	ErrFullNameTooLong  = errors.New("full name must be 100 characters or less")
+	ErrPronounsTooLong  = errors.New("pronouns must be 30 characters or less")
+	ErrTooManyWebsites  = errors.New("a profile lists at most 5 websites")
+	ErrTooManyEntries   = errors.New("a profile lists at most 10 work or education entries")
+	ErrEntryNameMissing = errors.New("work entries need a company and education entries a school")
+	ErrEntryTooLong     = errors.New("work and education fields must be 100 characters or less")
+	ErrInvalidYears     = errors.New("years must be between 1900 and next year, and end no earlier than they start")
+	ErrInvalidAudience  = errors.New("profile sections are visible to PUBLIC, FRIENDS, FRIENDS_OF_FRIENDS or ONLY_ME")
+)
+
+const (
+	maxProfileWebsites = 5
+	maxProfileEntries  = 10
+	maxEntryFieldLen   = 100
+	maxWebsiteLabelLen = 50
+	minProfileYear     = 1900
 )
 
-// ValidateProfileUpdate validates profile update fields
-func ValidateProfileUpdate(fullName, bio, website, location string) error {
+// ValidateProfileUpdate validates profile update fields. Years are checked
+// against thisYear, allowing entries that start next year.
+func ValidateProfileUpdate(req *models.UpdateProfileRequest, thisYear int) error {
 	// Full name validation
-	if len(fullName) > 100 {
+	if len(req.FullName) > 100 {
 		return ErrFullNameTooLong
 	}
-	
+
 	// Bio validation
-	if len(bio) > 500 {
+	if len(req.Bio) > 500 {
 		return ErrBioTooLong
 	}
-	
+
 	// Website validation
-	if website != "" {
-		if !isValidURL(website) {
-			return ErrInvalidWebsite
-		}
+	if req.Website != "" && !isValidURL(req.Website) {
+		return ErrInvalidWebsite
 	}
-	
+
 	// Location validation
-	if len(location) > 100 {
+	if len(req.Location) > 100 {
 		return ErrLocationTooLong
 	}
-	
+
+	if req.Pronouns != nil && len(*req.Pronouns) > 30 {
+		return ErrPronounsTooLong
+	}
+
+	if req.Websites != nil {
+		if len(*req.Websites) > maxProfileWebsites {
+			return ErrTooManyWebsites
+		}
+		for _, site := range *req.Websites {
+			if !isValidURL(site.URL) || len(site.Label) > maxWebsiteLabelLen {
+				return ErrInvalidWebsite
+			}
+		}
+	}
+
+	if req.Work != nil {
+		if len(*req.Work) > maxProfileEntries {
+			return ErrTooManyEntries
+		}
+		for _, job := range *req.Work {
+			if err := validateEntry(job.Company, []string{job.Position}, job.StartYear, job.EndYear, thisYear); err != nil {
+				return err
+			}
+		}
+	}
+
+	if req.Education != nil {
+		if len(*req.Education) > maxProfileEntries {
+			return ErrTooManyEntries
+		}
+		for _, school := range *req.Education {
+			if err := validateEntry(school.School, []string{school.Degree, school.Field}, school.StartYear, school.EndYear, thisYear); err != nil {
+				return err
+			}
+		}
+	}
+
+	if req.Privacy != nil {
+		for _, audience := range []models.PrivacySettingType{
+			req.Privacy.Bio, req.Privacy.Websites, req.Privacy.Pronouns,
+			req.Privacy.Work, req.Privacy.Education, req.Privacy.Location,
+		} {
+			if !validProfileAudience(audience) {
+				return ErrInvalidAudience
+			}
+		}
+	}
+
 	return nil
 }
 
+// validateEntry checks a work or education entry: its name is required, and
+// its years are optional but must be plausible and in order
+func validateEntry(name string, details []string, startYear, endYear, thisYear int) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrEntryNameMissing
+	}
+	if len(name) > maxEntryFieldLen {
+		return ErrEntryTooLong
+	}
+	for _, detail := range details {
+		if len(detail) > maxEntryFieldLen {
+			return ErrEntryTooLong
+		}
+	}
+	for _, year := range []int{startYear, endYear} {
+		if year != 0 && (year < minProfileYear || year > thisYear+1) {
+			return ErrInvalidYears
+		}
+	}
+	if startYear != 0 && endYear != 0 && endYear < startYear {
+		return ErrInvalidYears
+	}
+	return nil
+}
+
+// validProfileAudience reports whether audience may be set on a profile
+// section; empty leaves the section as it is
+func validProfileAudience(audience models.PrivacySettingType) bool {
+	switch audience {
+	case "", models.PrivacySettingPublic, models.PrivacySettingFriends,
+		models.PrivacySettingFriendsOfFriends, models.PrivacySettingOnlyMe:
+		return true
+	}
+	return false
+}
+
 // ValidateAvatarURL validates avatar URL format
 func ValidateAvatarURL(avatarURL string) error {
 	if avatarURL == "" {